@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gity/point-system/config"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/frameworks/web/realtime"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/gity/point-system/gateways/infra/infraemail"
+	"github.com/gity/point-system/gateways/infra/infralogger"
+	"github.com/gity/point-system/gateways/infra/infrapassword"
+	"github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/gateways/infra/infrapush"
+	"github.com/gity/point-system/gateways/infra/infrasqlite"
+	"github.com/gity/point-system/gateways/repository/balance_ledger"
+	"github.com/gity/point-system/gateways/repository/budget"
+	"github.com/gity/point-system/gateways/repository/department"
+	"github.com/gity/point-system/gateways/repository/email_template"
+	"github.com/gity/point-system/gateways/repository/notification"
+	"github.com/gity/point-system/gateways/repository/pending_admin_action"
+	"github.com/gity/point-system/gateways/repository/point_batch"
+	"github.com/gity/point-system/gateways/repository/point_expiry_policy"
+	"github.com/gity/point-system/gateways/repository/reason_code"
+	"github.com/gity/point-system/gateways/repository/session"
+	"github.com/gity/point-system/gateways/repository/system_settings"
+	"github.com/gity/point-system/gateways/repository/transaction"
+	"github.com/gity/point-system/gateways/repository/user"
+	"github.com/gity/point-system/gateways/repository/worker_lease"
+	"github.com/gity/point-system/migrations"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// adminApp はサブコマンドが使うユースケース・リポジトリ
+// サーバー（cmd/clean_server）と同じ実装を組み立てる。プッシュ通知は送らずログに出す（通知自体は保存される）
+type adminApp struct {
+	cfg    *config.Config
+	db     infrapostgres.DB
+	logger entities.Logger
+
+	txManager       repository.TransactionManager
+	userRepo        repository.UserRepository
+	transactionRepo repository.TransactionRepository
+	pointBatchRepo  repository.PointBatchRepository
+	policyRepo      repository.PointExpiryPolicyRepository
+	settingsRepo    repository.SystemSettingsRepository
+
+	adminUC          inputport.AdminInputPort
+	adminAccountUC   inputport.AdminAccountInputPort
+	reconciliationUC inputport.BalanceReconciliationInputPort
+	workerLeaseUC    inputport.WorkerLeaseInputPort
+}
+
+// openDB は設定のDBへ接続する（SQLログは出さない）
+func openDB(cfg *config.Config) (infrapostgres.DB, error) {
+	if cfg.Database.Driver == config.DriverSQLite {
+		return infrasqlite.NewSQLiteDB(&infrasqlite.Config{Path: cfg.Database.Path, Env: "production"})
+	}
+	return infrapostgres.NewPostgresDB(&infrapostgres.Config{
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		User:     cfg.Database.User,
+		Password: cfg.Database.Password,
+		DBName:   cfg.Database.DBName,
+		SSLMode:  cfg.Database.SSLMode,
+		Env:      "production",
+		Pool: infrapostgres.PoolConfig{
+			MaxOpenConns:     cfg.Database.MaxOpenConns,
+			MaxIdleConns:     cfg.Database.MaxIdleConns,
+			ConnMaxLifetime:  cfg.Database.ConnMaxLifetime,
+			StatementTimeout: cfg.Database.StatementTimeout,
+			LockTimeout:      cfg.Database.LockTimeout,
+		},
+	})
+}
+
+// newAdminApp はDBへ接続し、スキーマが最新であることを確かめてからユースケースを組み立てる
+// 呼び出し側で close を呼ぶこと
+func newAdminApp(ctx context.Context) (*adminApp, error) {
+	cfg := config.LoadConfig()
+	db, err := openDB(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect database: %w", err)
+	}
+	if err := checkSchema(ctx, cfg, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	passwordService, err := infrapassword.NewPasswordService(infrapassword.Config{
+		Algorithm:  cfg.Security.Password.Algorithm,
+		BcryptCost: cfg.Security.Password.BcryptCost,
+		Argon2id: infrapassword.Argon2idParams{
+			MemoryKiB:   uint32(cfg.Security.Password.Argon2MemoryKiB),
+			Iterations:  uint32(cfg.Security.Password.Argon2Iterations),
+			Parallelism: uint8(cfg.Security.Password.Argon2Parallelism),
+		},
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	logger := infralogger.NewLogger()
+	txManager := infrapostgres.NewGormTransactionManager(db.GetDB())
+	userRepo := user.NewUserRepository(dspostgresimpl.NewUserDataSource(db), logger)
+	sessionRepo := session.NewSessionRepository(dspostgresimpl.NewSessionDataSource(db), logger)
+	transactionRepo := transaction.NewTransactionRepository(dspostgresimpl.NewTransactionDataSource(db), logger)
+	idempotencyKeyRepo := transaction.NewIdempotencyKeyRepository(dspostgresimpl.NewIdempotencyKeyDataSource(db), logger)
+	pointExpiryPolicyDataSource := dspostgresimpl.NewPointExpiryPolicyDataSource(db)
+	pointBatchRepo := point_batch.NewPointBatchRepository(dspostgresimpl.NewPointBatchDataSource(db), pointExpiryPolicyDataSource)
+	policyRepo := point_expiry_policy.NewPointExpiryPolicyRepository(pointExpiryPolicyDataSource)
+	settingsRepo := system_settings.NewSystemSettingsRepository(dspostgresimpl.NewSystemSettingsDataSource(db))
+	reasonCodeRepo := reason_code.NewReasonCodeRepository(dspostgresimpl.NewReasonCodeDataSource(db))
+	departmentRepo := department.NewDepartmentRepository(dspostgresimpl.NewDepartmentDataSource(db))
+	budgetRepo := budget.NewBudgetRepository(dspostgresimpl.NewBudgetDataSource(db))
+	pendingAdminActionRepo := pending_admin_action.NewPendingAdminActionRepository(dspostgresimpl.NewPendingAdminActionDataSource(db))
+	balanceLedgerRepo := balance_ledger.NewBalanceLedgerRepository(dspostgresimpl.NewBalanceLedgerDataSource(db))
+	workerLeaseRepo := worker_lease.NewWorkerLeaseRepository(dspostgresimpl.NewWorkerLeaseDataSource(db))
+
+	emailService := infraemail.NewConsoleEmailService(email_template.NewEmailTemplateRepository(dspostgresimpl.NewEmailTemplateDataSource(db)), logger)
+	notificationUC := interactor.NewNotificationInteractor(notification.NewNotificationRepository(dspostgresimpl.NewNotificationDataSource(db)),
+		userRepo, infrapush.NewConsolePushService(logger), emailService, realtime.NewHub(logger), logger)
+
+	return &adminApp{
+		cfg:             cfg,
+		db:              db,
+		logger:          logger,
+		txManager:       txManager,
+		userRepo:        userRepo,
+		transactionRepo: transactionRepo,
+		pointBatchRepo:  pointBatchRepo,
+		policyRepo:      policyRepo,
+		settingsRepo:    settingsRepo,
+		adminUC: interactor.NewAdminInteractor(txManager, userRepo, transactionRepo, idempotencyKeyRepo, pointBatchRepo, reasonCodeRepo,
+			departmentRepo, budgetRepo, pendingAdminActionRepo, settingsRepo, dspostgresimpl.NewAnalyticsDataSource(db), notificationUC, logger),
+		adminAccountUC:   interactor.NewAdminAccountInteractor(userRepo, sessionRepo, passwordService, logger),
+		reconciliationUC: interactor.NewBalanceReconciliationInteractor(txManager, balanceLedgerRepo, userRepo, transactionRepo, pointBatchRepo, logger),
+		workerLeaseUC:    interactor.NewWorkerLeaseInteractor(workerLeaseRepo, userRepo, logger),
+	}, nil
+}
+
+func (a *adminApp) close() {
+	a.db.Close()
+}
+
+// checkSchema はDBのスキーマが埋め込みマイグレーションに追いついているかを確認する（遅れていれば run-migrations を促す）
+// SQLiteの場合はサーバーと同じくモデルからスキーマを作成する
+func checkSchema(ctx context.Context, cfg *config.Config, db infrapostgres.DB) error {
+	if cfg.Database.Driver == config.DriverSQLite {
+		return infrasqlite.Migrate(ctx, db, dspostgresimpl.Models()...)
+	}
+	migrator, err := infrapostgres.NewMigrator(db, migrations.FS)
+	if err != nil {
+		return err
+	}
+	if err := migrator.CheckUpToDate(ctx); err != nil {
+		return fmt.Errorf("%w (run `admin run-migrations` first)", err)
+	}
+	return nil
+}
+
+// findUser はユーザー名・メールアドレス・IDのいずれかでユーザーを探す
+func (a *adminApp) findUser(ctx context.Context, ref string) (*entities.User, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("user is required")
+	}
+	if id, err := uuid.Parse(ref); err == nil {
+		return a.userRepo.Read(ctx, id)
+	}
+	if u, err := a.userRepo.ReadByUsername(ctx, ref); err == nil {
+		return u, nil
+	}
+	if u, err := a.userRepo.ReadByEmail(ctx, ref); err == nil {
+		return u, nil
+	}
+	return nil, fmt.Errorf("user %q not found", ref)
+}
+
+// actingAdmin は --as で指定した管理者を返す（権限の確認は各ユースケースで行う）
+func (a *adminApp) actingAdmin(ctx context.Context, opts *rootOptions) (*entities.User, error) {
+	if opts.as == "" {
+		return nil, fmt.Errorf("--as is required for this command")
+	}
+	admin, err := a.findUser(ctx, opts.as)
+	if err != nil {
+		return nil, fmt.Errorf("--as: %w", err)
+	}
+	return admin, nil
+}
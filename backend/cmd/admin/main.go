@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// admin はサーバーと同じユースケース・リポジトリを使って運用作業を行う管理CLI
+// 操作はサーバーと同じくログに残り、利用者に関わる操作は --as で指定した管理者の操作として記録する
+//
+//	go run ./cmd/admin create-admin --username alice --email alice@example.com --first-name 花子 --last-name 鈴木
+//	go run ./cmd/admin reset-password --as alice --user bob
+//	go run ./cmd/admin grant-points --as alice --user bob --amount 100 --reason-code bonus
+//	go run ./cmd/admin list-users --as alice --search bob
+//	go run ./cmd/admin expire-batches
+//	go run ./cmd/admin run-migrations [--status]
+//	go run ./cmd/admin verify-balances --as alice [--apply --reason "..."]
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// rootOptions はすべてのサブコマンドに共通のフラグ
+type rootOptions struct {
+	as string // 操作する管理者（ユーザー名・メールアドレス・ID）
+}
+
+func newRootCommand() *cobra.Command {
+	opts := &rootOptions{}
+	root := &cobra.Command{
+		Use:          "admin",
+		Short:        "ポイントシステムの管理CLI",
+		SilenceUsage: true,
+	}
+	root.PersistentFlags().StringVar(&opts.as, "as", "", "操作する管理者（ユーザー名・メールアドレス・ID）")
+
+	root.AddCommand(
+		newCreateAdminCommand(opts),
+		newResetPasswordCommand(opts),
+		newGrantPointsCommand(opts),
+		newListUsersCommand(opts),
+		newExpireBatchesCommand(),
+		newRunMigrationsCommand(),
+		newVerifyBalancesCommand(opts),
+	)
+	return root
+}
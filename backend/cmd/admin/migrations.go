@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gity/point-system/config"
+	"github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/migrations"
+	"github.com/spf13/cobra"
+)
+
+// newRunMigrationsCommand は埋め込みSQLマイグレーションを適用するコマンド（cmd/migrate と同じ処理）
+func newRunMigrationsCommand() *cobra.Command {
+	var status bool
+	cmd := &cobra.Command{
+		Use:   "run-migrations",
+		Short: "未適用のマイグレーションを適用する（--status で適用状況を表示する）",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			cfg := config.LoadConfig()
+			if cfg.Database.Driver == config.DriverSQLite {
+				return fmt.Errorf("migrations are for PostgreSQL; SQLite schema is created from models on startup")
+			}
+			db, err := openDB(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to connect database: %w", err)
+			}
+			defer db.Close()
+
+			migrator, err := infrapostgres.NewMigrator(db, migrations.FS)
+			if err != nil {
+				return fmt.Errorf("failed to load migrations: %w", err)
+			}
+
+			if status {
+				pending, err := migrator.Pending(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to read migration status: %w", err)
+				}
+				pendingSet := make(map[int]bool, len(pending))
+				for _, m := range pending {
+					pendingSet[m.Version] = true
+				}
+				for _, m := range migrator.Migrations() {
+					state := "applied"
+					if pendingSet[m.Version] {
+						state = "pending"
+					}
+					fmt.Printf("%03d_%s\t%s\n", m.Version, m.Name, state)
+				}
+				return nil
+			}
+
+			applied, err := migrator.Up(ctx)
+			for _, m := range applied {
+				fmt.Printf("applied %03d_%s\n", m.Version, m.Name)
+			}
+			if err != nil {
+				return fmt.Errorf("migration failed: %w", err)
+			}
+			fmt.Printf("schema is up to date (version %d)\n", migrator.LatestVersion())
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&status, "status", false, "適用せずに適用状況を表示する")
+	return cmd
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/gity/point-system/gateways/infra"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// newGrantPointsCommand は管理者としてポイントを付与するコマンド
+// 管理APIの付与と同じく、理由コード・予算・承認の閾値を確認する（閾値を超えれば承認待ちになる）
+func newGrantPointsCommand(opts *rootOptions) *cobra.Command {
+	req := &inputport.GrantPointsRequest{}
+	var userRef string
+	cmd := &cobra.Command{
+		Use:   "grant-points",
+		Short: "ユーザーにポイントを付与する",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			app, err := newAdminApp(ctx)
+			if err != nil {
+				return err
+			}
+			defer app.close()
+
+			actor, err := app.actingAdmin(ctx, opts)
+			if err != nil {
+				return err
+			}
+			target, err := app.findUser(ctx, userRef)
+			if err != nil {
+				return err
+			}
+			req.AdminID = actor.ID
+			req.UserID = target.ID
+			if req.IdempotencyKey == "" {
+				req.IdempotencyKey = uuid.NewString()
+			}
+
+			resp, err := app.adminUC.GrantPoints(ctx, req)
+			if err != nil {
+				return err
+			}
+			switch {
+			case resp.PendingAction != nil:
+				fmt.Printf("grant requires approval by another admin: pending action %s\n", resp.PendingAction.ID)
+			case resp.DryRun:
+				fmt.Printf("dry run: %s would have %d points (requires approval: %t)\n", resp.User.Username, resp.User.Balance, resp.RequiresApproval)
+			default:
+				fmt.Printf("granted %d points to %s (transaction %s, balance %d)\n", req.Amount, resp.User.Username, resp.Transaction.ID, resp.User.Balance)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&userRef, "user", "", "付与するユーザー（ユーザー名・メールアドレス・ID、必須）")
+	cmd.Flags().Int64Var(&req.Amount, "amount", 0, "付与するポイント（必須）")
+	cmd.Flags().StringVar(&req.ReasonCode, "reason-code", "", "理由コード（必須）")
+	cmd.Flags().StringVar(&req.Description, "description", "", "説明")
+	cmd.Flags().StringVar(&req.Tag, "tag", "", "プロジェクト・タグ")
+	cmd.Flags().StringVar(&req.IdempotencyKey, "idempotency-key", "", "冪等性キー（再実行で二重に付与しないよう指定する。省略時は生成する）")
+	cmd.Flags().BoolVar(&req.DryRun, "dry-run", false, "検証のみ行い、付与しない")
+	cmd.Flags().BoolVar(&req.BudgetOverride, "budget-override", false, "overrideの予算を超えて付与する")
+	_ = cmd.MarkFlagRequired("user")
+	_ = cmd.MarkFlagRequired("amount")
+	_ = cmd.MarkFlagRequired("reason-code")
+	return cmd
+}
+
+// newExpireBatchesCommand は期限切れのポイントバッチをすぐに失効させるコマンド
+// サーバーのワーカーと同時に処理しないよう、ワーカーのリーダーになれたときだけ実行する
+func newExpireBatchesCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "expire-batches",
+		Short: "期限切れのポイントを失効させる（サーバーのワーカーと同じ処理を1回行う）",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			app, err := newAdminApp(ctx)
+			if err != nil {
+				return err
+			}
+			defer app.close()
+
+			leader := infra.NewLeaderElector(app.workerLeaseUC, app.logger)
+			worker := infra.NewPointExpiryWorker(
+				app.pointBatchRepo, app.policyRepo, app.settingsRepo, app.userRepo, app.transactionRepo,
+				app.txManager, app.logger,
+			).WithLeaderElection(leader).
+				WithLimits(infra.PointExpiryLimits{
+					BatchSize:  app.cfg.PointExpiry.BatchSize,
+					BatchPause: app.cfg.PointExpiry.BatchPause,
+					MaxRuntime: app.cfg.PointExpiry.MaxRuntime,
+				})
+			// 処理中もリーダーの期限を延ばし、終わったらすぐサーバーのワーカーへ返す
+			leader.Start()
+			defer leader.Release()
+
+			run, err := worker.RunOnce(ctx)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s: expired %d batches (%d points), extended %d, failed %d\n",
+				run.State, run.ExpiredBatches, run.ExpiredPoints, run.ExtendedBatches, run.FailedBatches)
+			if run.Error != "" {
+				return fmt.Errorf("point expiry failed: %s", run.Error)
+			}
+			return nil
+		},
+	}
+}
+
+// newVerifyBalancesCommand は全ユーザーの残高を取引履歴と照合するコマンド（--applyで補正する）
+func newVerifyBalancesCommand(opts *rootOptions) *cobra.Command {
+	req := &inputport.RecomputeBalancesRequest{}
+	cmd := &cobra.Command{
+		Use:   "verify-balances",
+		Short: "残高を取引履歴と照合する（--apply で補正取引を記録して合わせる）",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			app, err := newAdminApp(ctx)
+			if err != nil {
+				return err
+			}
+			defer app.close()
+
+			actor, err := app.actingAdmin(ctx, opts)
+			if err != nil {
+				return err
+			}
+			req.AdminID = actor.ID
+			return verifyBalances(ctx, app, req)
+		},
+	}
+	cmd.Flags().BoolVar(&req.Apply, "apply", false, "一致しない残高を補正する")
+	cmd.Flags().IntVar(&req.BatchSize, "batch-size", 0, "1回に照合するユーザー数（0なら既定値）")
+	cmd.Flags().StringVar(&req.Reason, "reason", "", "補正取引の説明に残す理由")
+	return cmd
+}
+
+func verifyBalances(ctx context.Context, app *adminApp, req *inputport.RecomputeBalancesRequest) error {
+	resp, err := app.reconciliationUC.RecomputeBalances(ctx, req)
+	if err != nil {
+		return err
+	}
+	if len(resp.Discrepancies) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "USER_ID\tUSERNAME\tSTORED\tLEDGER\tCORRECTED\tSKIP_REASON")
+		for _, d := range resp.Discrepancies {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%t\t%s\n", d.UserID, d.Username, d.StoredBalance, d.LedgerBalance, d.Corrected, d.SkipReason)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("checked %d users in %d batches, %d discrepancies (applied: %t)\n",
+		resp.CheckedUsers, resp.Batches, len(resp.Discrepancies), resp.Applied)
+	if len(resp.Discrepancies) > 0 && !resp.Applied {
+		return fmt.Errorf("found %d balance discrepancies", len(resp.Discrepancies))
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// newCreateAdminCommand は管理者を作成するコマンド
+// ユーザーがいない状態での最初の管理者は --as なしで作成できる
+func newCreateAdminCommand(opts *rootOptions) *cobra.Command {
+	req := &inputport.CreateAdminRequest{}
+	cmd := &cobra.Command{
+		Use:   "create-admin",
+		Short: "管理者を作成する（最初の管理者は --as なしで作成できる）",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			app, err := newAdminApp(ctx)
+			if err != nil {
+				return err
+			}
+			defer app.close()
+
+			if opts.as != "" {
+				actor, err := app.actingAdmin(ctx, opts)
+				if err != nil {
+					return err
+				}
+				req.ActorID = &actor.ID
+			}
+
+			resp, err := app.adminAccountUC.CreateAdmin(ctx, req)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("created admin %s (%s)\n", resp.Admin.Username, resp.Admin.ID)
+			if resp.TemporaryPassword != "" {
+				fmt.Printf("temporary password: %s\n", resp.TemporaryPassword)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&req.Username, "username", "", "ユーザー名（必須）")
+	cmd.Flags().StringVar(&req.Email, "email", "", "メールアドレス（必須）")
+	cmd.Flags().StringVar(&req.DisplayName, "display-name", "", "表示名（省略時はユーザー名）")
+	cmd.Flags().StringVar(&req.FirstName, "first-name", "", "名（必須）")
+	cmd.Flags().StringVar(&req.LastName, "last-name", "", "姓（必須）")
+	cmd.Flags().StringVar(&req.Password, "password", "", "パスワード（省略時は仮パスワードを生成して表示する）")
+	_ = cmd.MarkFlagRequired("username")
+	_ = cmd.MarkFlagRequired("email")
+	_ = cmd.MarkFlagRequired("first-name")
+	_ = cmd.MarkFlagRequired("last-name")
+	return cmd
+}
+
+// newResetPasswordCommand はユーザーのパスワードを再設定するコマンド（ログイン中のセッションは無効になる）
+func newResetPasswordCommand(opts *rootOptions) *cobra.Command {
+	var userRef, password string
+	cmd := &cobra.Command{
+		Use:   "reset-password",
+		Short: "ユーザーのパスワードを再設定し、ログイン中のセッションを無効にする",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			app, err := newAdminApp(ctx)
+			if err != nil {
+				return err
+			}
+			defer app.close()
+
+			actor, err := app.actingAdmin(ctx, opts)
+			if err != nil {
+				return err
+			}
+			target, err := app.findUser(ctx, userRef)
+			if err != nil {
+				return err
+			}
+
+			resp, err := app.adminAccountUC.ResetPassword(ctx, &inputport.ResetUserPasswordRequest{
+				ActorID:  actor.ID,
+				UserID:   target.ID,
+				Password: password,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Printf("password reset for %s (%s)\n", resp.User.Username, resp.User.ID)
+			if resp.TemporaryPassword != "" {
+				fmt.Printf("temporary password: %s\n", resp.TemporaryPassword)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&userRef, "user", "", "対象のユーザー（ユーザー名・メールアドレス・ID、必須）")
+	cmd.Flags().StringVar(&password, "password", "", "新しいパスワード（省略時は仮パスワードを生成して表示する）")
+	_ = cmd.MarkFlagRequired("user")
+	return cmd
+}
+
+// newListUsersCommand はユーザーの一覧を表示するコマンド
+func newListUsersCommand(opts *rootOptions) *cobra.Command {
+	req := &inputport.ListAllUsersRequest{}
+	var departmentID string
+	cmd := &cobra.Command{
+		Use:   "list-users",
+		Short: "ユーザーの一覧を表示する",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			app, err := newAdminApp(ctx)
+			if err != nil {
+				return err
+			}
+			defer app.close()
+
+			// 一覧の取得は管理APIと同じく管理者だけに許す
+			actor, err := app.actingAdmin(ctx, opts)
+			if err != nil {
+				return err
+			}
+			if !actor.IsAdmin() {
+				return fmt.Errorf("--as: %s is not an admin", actor.Username)
+			}
+			if departmentID != "" {
+				id, err := uuid.Parse(departmentID)
+				if err != nil {
+					return fmt.Errorf("--department: %w", err)
+				}
+				req.DepartmentID = &id
+			}
+
+			resp, err := app.adminUC.ListAllUsers(ctx, req)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tUSERNAME\tEMAIL\tROLE\tBALANCE\tACTIVE")
+			for _, u := range resp.Users {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%t\n", u.ID, u.Username, u.Email, u.Role, u.Balance, u.IsActive)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			fmt.Printf("%d of %d users\n", len(resp.Users), resp.Total)
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&req.Offset, "offset", 0, "先頭から飛ばす件数")
+	cmd.Flags().IntVar(&req.Limit, "limit", 50, "表示する件数")
+	cmd.Flags().StringVar(&req.Search, "search", "", "名前・ユーザー名・IDで検索")
+	cmd.Flags().StringVar(&departmentID, "department", "", "部署IDで絞り込む（配下の部署を含む）")
+	cmd.Flags().StringVar(&req.SortBy, "sort", "", "並び順（created_at, balance, role, username, display_name）")
+	cmd.Flags().StringVar(&req.SortOrder, "order", "", "asc または desc")
+	return cmd
+}
@@ -0,0 +1,92 @@
+// Package app はサーバーの依存関係（Wire）とスキーマの準備をまとめる
+// cmd/clean_server の main とE2Eテストのハーネス（tests/e2e/harness）の両方から使う
+package app
+
+import (
+	"context"
+	"log"
+
+	"github.com/gity/point-system/config"
+	"github.com/gity/point-system/entities"
+	frameworksweb "github.com/gity/point-system/frameworks/web"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/gity/point-system/gateways/infra/infraakerun"
+	"github.com/gity/point-system/gateways/infra/infrabreaker"
+	"github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/gateways/infra/infrasqlite"
+	"github.com/gity/point-system/migrations"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+// AppContainer はアプリケーションの依存関係を管理
+// Wire が自動注入するフィールド
+type AppContainer struct {
+	Router *frameworksweb.Router
+	DB     infrapostgres.DB
+
+	// Workers 構築に必要な依存を Wire から受け取る
+	DailyBonusUC    *interactor.DailyBonusInteractor
+	PointBatchRepo  repository.PointBatchRepository
+	UserRepo        repository.UserRepository
+	TransactionRepo repository.TransactionRepository
+	TxManager       repository.TransactionManager
+	Logger          entities.Logger
+	TimeProvider    frameworksweb.TimeProvider
+
+	IdempotentRequestRepo repository.IdempotentRequestRepository
+	MaintenanceUC         inputport.MaintenanceInputPort
+	PointExpiryPolicyRepo repository.PointExpiryPolicyRepository
+	DataExportUC          inputport.DataExportInputPort
+	RecurringTransferUC   inputport.RecurringTransferInputPort
+	NotificationUC        inputport.NotificationInputPort
+	UserTierUC            inputport.UserTierInputPort
+	AdminApprovalUC       inputport.AdminApprovalInputPort
+	ScheduledJobUC        inputport.ScheduledJobInputPort
+	WorkerLeaseUC         inputport.WorkerLeaseInputPort
+	SystemSettingsRepo    repository.SystemSettingsRepository
+	AkerunConfigs         []*infraakerun.AkerunConfig
+	CircuitBreakers       *infrabreaker.Registry
+	AkerunRepollUC        inputport.AkerunRepollInputPort
+	BusinessMetricsUC     inputport.BusinessMetricsInputPort
+}
+
+// PrepareSchema はDBのスキーマを使える状態にする（スキーマバージョンの確認と、新規テーブルのAutoMigrate）
+// applyPending が true の場合は未適用のマイグレーションを先に適用する
+func PrepareSchema(cfg *config.Config, db infrapostgres.DB, applyPending bool) error {
+	if err := ensureSchema(cfg, db, applyPending); err != nil {
+		return err
+	}
+	// AutoMigrate（新規テーブルのみ）
+	return db.GetDB().AutoMigrate(
+		&dspostgresimpl.CategoryModel{},
+	)
+}
+
+// ensureSchema は埋め込みマイグレーションとDBスキーマのバージョンを照合する
+// applyPending が true の場合は未適用分を適用し、false の場合は遅れていればエラーを返す
+// SQLiteの場合はマイグレーション（PostgreSQL用）の代わりにモデルからスキーマを作成する
+func ensureSchema(cfg *config.Config, db infrapostgres.DB, applyPending bool) error {
+	if cfg.Database.Driver == config.DriverSQLite {
+		return infrasqlite.Migrate(context.Background(), db, dspostgresimpl.Models()...)
+	}
+
+	migrator, err := infrapostgres.NewMigrator(db, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if applyPending {
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			log.Printf("Applied migration %03d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return migrator.CheckUpToDate(ctx)
+}
@@ -0,0 +1,587 @@
+package app
+
+import (
+	"github.com/gity/point-system/config"
+	"github.com/gity/point-system/controllers/web"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	frameworksweb "github.com/gity/point-system/frameworks/web"
+	"github.com/gity/point-system/frameworks/web/middleware"
+	"github.com/gity/point-system/frameworks/web/realtime"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/gity/point-system/gateways/infra/infralogger"
+	"github.com/gity/point-system/gateways/infra/infrapassword"
+	"github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/gateways/infra/infraqrpayload"
+	"github.com/gity/point-system/gateways/infra/infrareceipt"
+	"github.com/gity/point-system/gateways/infra/infrasqlite"
+	admindashboardrepo "github.com/gity/point-system/gateways/repository/admin_dashboard"
+	akerunrepollrepo "github.com/gity/point-system/gateways/repository/akerun_repoll"
+	announcementrepo "github.com/gity/point-system/gateways/repository/announcement"
+	balanceholdrepo "github.com/gity/point-system/gateways/repository/balance_hold"
+	balanceledgerrepo "github.com/gity/point-system/gateways/repository/balance_ledger"
+	budgetrepo "github.com/gity/point-system/gateways/repository/budget"
+	businessmetricsrepo "github.com/gity/point-system/gateways/repository/business_metrics"
+	cartrepo "github.com/gity/point-system/gateways/repository/cart"
+	categoryrepo "github.com/gity/point-system/gateways/repository/category"
+	contentviolationrepo "github.com/gity/point-system/gateways/repository/content_violation"
+	dailybonusrepo "github.com/gity/point-system/gateways/repository/daily_bonus"
+	dataexportrepo "github.com/gity/point-system/gateways/repository/data_export"
+	dsmysql "github.com/gity/point-system/gateways/repository/datasource/dsmysql"
+	departmentrepo "github.com/gity/point-system/gateways/repository/department"
+	earningrulerepo "github.com/gity/point-system/gateways/repository/earning_rule"
+	emailtemplaterepo "github.com/gity/point-system/gateways/repository/email_template"
+	eventrepo "github.com/gity/point-system/gateways/repository/event"
+	failedakerunaccessrepo "github.com/gity/point-system/gateways/repository/failed_akerun_access"
+	frienddiscoveryrepo "github.com/gity/point-system/gateways/repository/friend_discovery"
+	friendpinrepo "github.com/gity/point-system/gateways/repository/friend_pin"
+	friendshiprepo "github.com/gity/point-system/gateways/repository/friendship"
+	idempotentrequestrepo "github.com/gity/point-system/gateways/repository/idempotent_request"
+	kioskrepo "github.com/gity/point-system/gateways/repository/kiosk"
+	loginattemptrepo "github.com/gity/point-system/gateways/repository/login_attempt"
+	lotterytierrepo "github.com/gity/point-system/gateways/repository/lottery_tier"
+	mutelistrepo "github.com/gity/point-system/gateways/repository/mute_list"
+	notificationrepo "github.com/gity/point-system/gateways/repository/notification"
+	pendingadminactionrepo "github.com/gity/point-system/gateways/repository/pending_admin_action"
+	pointbatchrepo "github.com/gity/point-system/gateways/repository/point_batch"
+	pointexpirypolicyrepo "github.com/gity/point-system/gateways/repository/point_expiry_policy"
+	pricingrulerepo "github.com/gity/point-system/gateways/repository/pricing_rule"
+	processedakerunaccessrepo "github.com/gity/point-system/gateways/repository/processed_akerun_access"
+	productrepo "github.com/gity/point-system/gateways/repository/product"
+	qrcoderepo "github.com/gity/point-system/gateways/repository/qrcode"
+	reasoncoderepo "github.com/gity/point-system/gateways/repository/reason_code"
+	recurringtransferrepo "github.com/gity/point-system/gateways/repository/recurring_transfer"
+	referralrepo "github.com/gity/point-system/gateways/repository/referral"
+	scheduledjobrepo "github.com/gity/point-system/gateways/repository/scheduled_job"
+	sessionrepo "github.com/gity/point-system/gateways/repository/session"
+	shippingaddressrepo "github.com/gity/point-system/gateways/repository/shipping_address"
+	splitrequestrepo "github.com/gity/point-system/gateways/repository/split_request"
+	suspiciousactivityrepo "github.com/gity/point-system/gateways/repository/suspicious_activity"
+	systemsettingsrepo "github.com/gity/point-system/gateways/repository/system_settings"
+	tenantrepo "github.com/gity/point-system/gateways/repository/tenant"
+	transactionrepo "github.com/gity/point-system/gateways/repository/transaction"
+	transactionarchiverepo "github.com/gity/point-system/gateways/repository/transaction_archive"
+	transferattachmentrepo "github.com/gity/point-system/gateways/repository/transfer_attachment"
+	transferrequestrepo "github.com/gity/point-system/gateways/repository/transfer_request"
+	userrepo "github.com/gity/point-system/gateways/repository/user"
+	usersettingsrepo "github.com/gity/point-system/gateways/repository/user_settings"
+	usertierrepo "github.com/gity/point-system/gateways/repository/user_tier"
+	weeklydigestrepo "github.com/gity/point-system/gateways/repository/weekly_digest"
+	workerleaserepo "github.com/gity/point-system/gateways/repository/worker_lease"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/wire"
+)
+
+// ========================================
+// Infra ProviderSet
+// ========================================
+
+var InfraSet = wire.NewSet(
+	ProvideDB,
+	ProvideSlowQueryLog,
+	wire.Bind(new(service.SlowQueryMonitor), new(*infrapostgres.SlowQueryLog)),
+	infrapostgres.NewPoolStatsMonitor,
+	wire.Bind(new(service.DBStatsMonitor), new(*infrapostgres.PoolStatsMonitor)),
+	infralogger.NewLogger,
+	ProvideGormTransactionManager,
+	wire.Bind(new(repository.TransactionManager), new(*infrapostgres.GormTransactionManager)),
+)
+
+// ProvideDB は config.Database.Driver に応じてDBへ接続する（SQLiteはDockerなしでのデモ・結合テスト用）
+func ProvideDB(cfg *config.Config, dbConfig *infrapostgres.Config, slowQueries *infrapostgres.SlowQueryLog) (infrapostgres.DB, error) {
+	if cfg.Database.Driver == config.DriverSQLite {
+		return infrasqlite.NewSQLiteDB(&infrasqlite.Config{Path: cfg.Database.Path, Env: cfg.Server.Env}, slowQueries)
+	}
+	return infrapostgres.NewPostgresDB(dbConfig, slowQueries)
+}
+
+// ProvideSlowQueryLog は遅いクエリのログを作成（実行計画は本番環境では取得しない）
+func ProvideSlowQueryLog(cfg *config.Config, logger entities.Logger) *infrapostgres.SlowQueryLog {
+	return infrapostgres.NewSlowQueryLog(infrapostgres.SlowQueryConfig{
+		Threshold: cfg.Database.SlowQueryThreshold,
+		Explain:   cfg.Database.SlowQueryExplain && cfg.Server.Env != config.EnvProduction,
+	}, logger)
+}
+
+// ProvideGormTransactionManager は DB から TransactionManager を作成
+func ProvideGormTransactionManager(db infrapostgres.DB) *infrapostgres.GormTransactionManager {
+	return infrapostgres.NewGormTransactionManager(db.GetDB())
+}
+
+// ========================================
+// DataSource ProviderSet
+// ========================================
+
+var DataSourceSet = wire.NewSet(
+	dspostgresimpl.NewUserDataSource,
+	dspostgresimpl.NewTransactionDataSource,
+	dspostgresimpl.NewIdempotencyKeyDataSource,
+	dspostgresimpl.NewSessionDataSource,
+	dspostgresimpl.NewFriendshipDataSource,
+	dspostgresimpl.NewQRCodeDataSource,
+	dspostgresimpl.NewTransferRequestDataSource,
+	dspostgresimpl.NewDailyBonusDataSource,
+	dspostgresimpl.NewProductDataSource,
+	dspostgresimpl.NewProductExchangeDataSource,
+	dspostgresimpl.NewCategoryDataSource,
+	dspostgresimpl.NewArchivedUserDataSource,
+	dspostgresimpl.NewEmailVerificationDataSource,
+	dspostgresimpl.NewUsernameChangeHistoryDataSource,
+	dspostgresimpl.NewPasswordChangeHistoryDataSource,
+	dspostgresimpl.NewSystemSettingsDataSource,
+	dspostgresimpl.NewPointBatchDataSource,
+	dspostgresimpl.NewPointExpiryPolicyDataSource,
+	dspostgresimpl.NewLotteryTierDataSource,
+	dspostgresimpl.NewAnalyticsDataSource,
+	dspostgresimpl.NewKioskDataSource,
+	dspostgresimpl.NewLoginAttemptDataSource,
+	dspostgresimpl.NewIdempotentRequestDataSource,
+	dspostgresimpl.NewDataExportDataSource,
+	dspostgresimpl.NewContentViolationDataSource,
+	dspostgresimpl.NewAnnouncementDataSource,
+	dspostgresimpl.NewPricingRuleDataSource,
+	dspostgresimpl.NewEarningRuleDataSource,
+	dspostgresimpl.NewTenantDataSource,
+	dspostgresimpl.NewTransactionArchiveDataSource,
+	dspostgresimpl.NewWeeklyDigestDataSource,
+	dspostgresimpl.NewTransferAttachmentDataSource,
+	dspostgresimpl.NewSplitRequestDataSource,
+	dspostgresimpl.NewUserTierDataSource,
+	dspostgresimpl.NewReferralDataSource,
+	dspostgresimpl.NewEventDataSource,
+	dspostgresimpl.NewReasonCodeDataSource,
+	dspostgresimpl.NewDepartmentDataSource,
+	dspostgresimpl.NewBudgetDataSource,
+	dspostgresimpl.NewPendingAdminActionDataSource,
+	dspostgresimpl.NewScheduledJobDataSource,
+	dspostgresimpl.NewWorkerLeaseDataSource,
+	dspostgresimpl.NewBalanceLedgerDataSource,
+	dspostgresimpl.NewFailedAkerunAccessDataSource,
+	dspostgresimpl.NewProcessedAkerunAccessDataSource,
+	dspostgresimpl.NewAkerunRepollDataSource,
+	dspostgresimpl.NewCartDataSource,
+	dspostgresimpl.NewShippingAddressDataSource,
+	dspostgresimpl.NewEmailTemplateDataSource,
+	dspostgresimpl.NewAdminDashboardDataSource,
+	dspostgresimpl.NewBusinessMetricsDataSource,
+	dspostgresimpl.NewRecurringTransferDataSource,
+	dspostgresimpl.NewFriendDiscoveryDataSource,
+	dspostgresimpl.NewFriendPinDataSource,
+	dspostgresimpl.NewMutedSenderDataSource,
+	dspostgresimpl.NewBalanceHoldDataSource,
+	dspostgresimpl.NewNotificationDataSource,
+	dspostgresimpl.NewSuspiciousActivityDataSource,
+
+	// concrete → interface bindings (DataSource constructors that return *Impl instead of interface)
+	wire.Bind(new(dsmysql.ArchivedUserDataSource), new(*dspostgresimpl.ArchivedUserDataSourceImpl)),
+	wire.Bind(new(dsmysql.EmailVerificationDataSource), new(*dspostgresimpl.EmailVerificationDataSourceImpl)),
+	wire.Bind(new(dsmysql.UsernameChangeHistoryDataSource), new(*dspostgresimpl.UsernameChangeHistoryDataSourceImpl)),
+	wire.Bind(new(dsmysql.PasswordChangeHistoryDataSource), new(*dspostgresimpl.PasswordChangeHistoryDataSourceImpl)),
+
+	// AnalyticsDataSource → repository.AnalyticsRepository
+	// AnalyticsDataSourceは dsmysql.AnalyticsDataSource を返すが、AdminInteractorは repository.AnalyticsRepository を要求
+	wire.Bind(new(repository.AnalyticsRepository), new(dsmysql.AnalyticsDataSource)),
+)
+
+// ========================================
+// Repository ProviderSet
+// ========================================
+
+var RepositorySet = wire.NewSet(
+	userrepo.NewUserRepository,
+	transactionrepo.NewTransactionRepository,
+	transactionrepo.NewIdempotencyKeyRepository,
+	sessionrepo.NewSessionRepository,
+	friendshiprepo.NewFriendshipRepository,
+	qrcoderepo.NewQRCodeRepository,
+	transferrequestrepo.NewTransferRequestRepository,
+	dailybonusrepo.NewDailyBonusRepository,
+	productrepo.NewProductRepository,
+	productrepo.NewProductExchangeRepository,
+	categoryrepo.NewCategoryRepository,
+	usersettingsrepo.NewUserSettingsRepository,
+	usersettingsrepo.NewArchivedUserRepository,
+	usersettingsrepo.NewEmailVerificationRepository,
+	usersettingsrepo.NewUsernameChangeHistoryRepository,
+	usersettingsrepo.NewPasswordChangeHistoryRepository,
+	systemsettingsrepo.NewSystemSettingsRepository,
+	pointbatchrepo.NewPointBatchRepository,
+	pointexpirypolicyrepo.NewPointExpiryPolicyRepository,
+	lotterytierrepo.NewLotteryTierRepository,
+	kioskrepo.NewKioskRepository,
+	loginattemptrepo.NewLoginAttemptRepository,
+	idempotentrequestrepo.NewIdempotentRequestRepository,
+	dataexportrepo.NewDataExportRepository,
+	contentviolationrepo.NewContentViolationRepository,
+	announcementrepo.NewAnnouncementRepository,
+	pricingrulerepo.NewPricingRuleRepository,
+	earningrulerepo.NewEarningRuleRepository,
+	tenantrepo.NewTenantRepository,
+	transactionarchiverepo.NewTransactionArchiveRepository,
+	weeklydigestrepo.NewWeeklyDigestRepository,
+	transferattachmentrepo.NewTransferAttachmentRepository,
+	splitrequestrepo.NewSplitRequestRepository,
+	usertierrepo.NewUserTierRepository,
+	referralrepo.NewReferralRepository,
+	eventrepo.NewEventRepository,
+	reasoncoderepo.NewReasonCodeRepository,
+	departmentrepo.NewDepartmentRepository,
+	budgetrepo.NewBudgetRepository,
+	pendingadminactionrepo.NewPendingAdminActionRepository,
+	scheduledjobrepo.NewScheduledJobRepository,
+	workerleaserepo.NewWorkerLeaseRepository,
+	balanceledgerrepo.NewBalanceLedgerRepository,
+	failedakerunaccessrepo.NewFailedAkerunAccessRepository,
+	processedakerunaccessrepo.NewProcessedAkerunAccessRepository,
+	akerunrepollrepo.NewAkerunRepollRepository,
+	cartrepo.NewCartRepository,
+	shippingaddressrepo.NewShippingAddressRepository,
+	emailtemplaterepo.NewEmailTemplateRepository,
+	admindashboardrepo.NewAdminDashboardRepository,
+	businessmetricsrepo.NewBusinessMetricsRepository,
+	recurringtransferrepo.NewRecurringTransferRepository,
+	frienddiscoveryrepo.NewFriendDiscoveryRepository,
+	friendpinrepo.NewFriendPinRepository,
+	mutelistrepo.NewMuteListRepository,
+	balanceholdrepo.NewBalanceHoldRepository,
+	notificationrepo.NewNotificationRepository,
+	suspiciousactivityrepo.NewSuspiciousActivityRepository,
+
+	// concrete → interface bindings
+	wire.Bind(new(repository.DailyBonusRepository), new(*dailybonusrepo.DailyBonusRepositoryImpl)),
+	wire.Bind(new(repository.SystemSettingsRepository), new(*systemsettingsrepo.SystemSettingsRepositoryImpl)),
+	wire.Bind(new(repository.PointBatchRepository), new(*pointbatchrepo.PointBatchRepositoryImpl)),
+	wire.Bind(new(repository.PointExpiryPolicyRepository), new(*pointexpirypolicyrepo.PointExpiryPolicyRepositoryImpl)),
+	wire.Bind(new(repository.LotteryTierRepository), new(*lotterytierrepo.LotteryTierRepositoryImpl)),
+	wire.Bind(new(repository.DataExportRepository), new(*dataexportrepo.DataExportRepositoryImpl)),
+	wire.Bind(new(repository.ContentViolationRepository), new(*contentviolationrepo.ContentViolationRepositoryImpl)),
+	wire.Bind(new(repository.AnnouncementRepository), new(*announcementrepo.AnnouncementRepositoryImpl)),
+	wire.Bind(new(repository.PricingRuleRepository), new(*pricingrulerepo.PricingRuleRepositoryImpl)),
+	wire.Bind(new(repository.EarningRuleRepository), new(*earningrulerepo.EarningRuleRepositoryImpl)),
+	wire.Bind(new(repository.TenantRepository), new(*tenantrepo.TenantRepositoryImpl)),
+	wire.Bind(new(repository.TransactionArchiveRepository), new(*transactionarchiverepo.TransactionArchiveRepositoryImpl)),
+	wire.Bind(new(repository.WeeklyDigestRepository), new(*weeklydigestrepo.WeeklyDigestRepositoryImpl)),
+	wire.Bind(new(repository.TransferAttachmentRepository), new(*transferattachmentrepo.TransferAttachmentRepositoryImpl)),
+	wire.Bind(new(repository.SplitRequestRepository), new(*splitrequestrepo.SplitRequestRepositoryImpl)),
+	wire.Bind(new(repository.UserTierRepository), new(*usertierrepo.UserTierRepositoryImpl)),
+	wire.Bind(new(repository.ReferralRepository), new(*referralrepo.ReferralRepositoryImpl)),
+	wire.Bind(new(repository.EventRepository), new(*eventrepo.EventRepositoryImpl)),
+	wire.Bind(new(repository.ReasonCodeRepository), new(*reasoncoderepo.ReasonCodeRepositoryImpl)),
+	wire.Bind(new(repository.DepartmentRepository), new(*departmentrepo.DepartmentRepositoryImpl)),
+	wire.Bind(new(repository.BudgetRepository), new(*budgetrepo.BudgetRepositoryImpl)),
+	wire.Bind(new(repository.PendingAdminActionRepository), new(*pendingadminactionrepo.PendingAdminActionRepositoryImpl)),
+	wire.Bind(new(repository.ScheduledJobRepository), new(*scheduledjobrepo.ScheduledJobRepositoryImpl)),
+	wire.Bind(new(repository.WorkerLeaseRepository), new(*workerleaserepo.WorkerLeaseRepositoryImpl)),
+	wire.Bind(new(repository.BalanceLedgerRepository), new(*balanceledgerrepo.BalanceLedgerRepositoryImpl)),
+	wire.Bind(new(repository.FailedAkerunAccessRepository), new(*failedakerunaccessrepo.FailedAkerunAccessRepositoryImpl)),
+	wire.Bind(new(repository.ProcessedAkerunAccessRepository), new(*processedakerunaccessrepo.ProcessedAkerunAccessRepositoryImpl)),
+	wire.Bind(new(repository.AkerunRepollRepository), new(*akerunrepollrepo.AkerunRepollRepositoryImpl)),
+	wire.Bind(new(repository.CartRepository), new(*cartrepo.CartRepositoryImpl)),
+	wire.Bind(new(repository.ShippingAddressRepository), new(*shippingaddressrepo.ShippingAddressRepositoryImpl)),
+	wire.Bind(new(repository.EmailTemplateRepository), new(*emailtemplaterepo.EmailTemplateRepositoryImpl)),
+	wire.Bind(new(repository.AdminDashboardRepository), new(*admindashboardrepo.AdminDashboardRepositoryImpl)),
+	wire.Bind(new(repository.BusinessMetricsRepository), new(*businessmetricsrepo.BusinessMetricsRepositoryImpl)),
+	wire.Bind(new(repository.RecurringTransferRepository), new(*recurringtransferrepo.RecurringTransferRepositoryImpl)),
+	wire.Bind(new(repository.FriendDiscoveryRepository), new(*frienddiscoveryrepo.FriendDiscoveryRepositoryImpl)),
+	wire.Bind(new(repository.FriendPinRepository), new(*friendpinrepo.FriendPinRepositoryImpl)),
+	wire.Bind(new(repository.MuteListRepository), new(*mutelistrepo.MuteListRepositoryImpl)),
+	wire.Bind(new(repository.BalanceHoldRepository), new(*balanceholdrepo.BalanceHoldRepositoryImpl)),
+	wire.Bind(new(repository.NotificationRepository), new(*notificationrepo.NotificationRepositoryImpl)),
+	wire.Bind(new(repository.SuspiciousActivityRepository), new(*suspiciousactivityrepo.SuspiciousActivityRepositoryImpl)),
+)
+
+// ========================================
+// Service ProviderSet
+// ========================================
+
+var ServiceSet = wire.NewSet(
+	ProvidePasswordService,
+	ProvideQRPayloadCodec,
+	ProvideReceiptSigner,
+)
+
+// ProvidePasswordService は設定したアルゴリズムでハッシュを作るパスワードサービスを返す
+// 他のアルゴリズム・パラメータの既存のハッシュも検証でき、ログイン時に作り直す
+func ProvidePasswordService(cfg *config.Config) (service.PasswordService, error) {
+	return infrapassword.NewPasswordService(infrapassword.Config{
+		Algorithm:  cfg.Security.Password.Algorithm,
+		BcryptCost: cfg.Security.Password.BcryptCost,
+		Argon2id: infrapassword.Argon2idParams{
+			MemoryKiB:   uint32(cfg.Security.Password.Argon2MemoryKiB),
+			Iterations:  uint32(cfg.Security.Password.Argon2Iterations),
+			Parallelism: uint8(cfg.Security.Password.Argon2Parallelism),
+		},
+	})
+}
+
+// ProvideQRPayloadCodec はQRコードに埋め込む文字列を署名・検証するサービスを返す
+// 署名キーが未設定ならセッション暗号化キーから導出する
+func ProvideQRPayloadCodec(cfg *config.Config) service.QRPayloadCodec {
+	secret := cfg.Security.QRSigningSecret
+	if secret == "" {
+		secret = cfg.Security.SessionSecret
+	}
+	return infraqrpayload.NewCodec(secret)
+}
+
+// ProvideReceiptSigner は取引の控えの検証ハッシュを計算するサービスを返す
+// 署名キーが未設定ならセッション暗号化キーから導出する
+func ProvideReceiptSigner(cfg *config.Config) service.ReceiptSigner {
+	secret := cfg.Security.ReceiptSigningSecret
+	if secret == "" {
+		secret = cfg.Security.SessionSecret
+	}
+	return infrareceipt.NewHMACReceiptSigner(secret)
+}
+
+// ========================================
+// Interactor ProviderSet
+// ========================================
+
+var InteractorSet = wire.NewSet(
+	interactor.NewAuthInteractor,
+	interactor.NewSessionBindingInteractor,
+	interactor.NewCSRFInteractor,
+	interactor.NewPointTransferInteractor,
+	interactor.NewFriendshipInteractor,
+	interactor.NewTransferRequestInteractor,
+	interactor.NewDailyBonusInteractor,
+	interactor.NewQRCodeInteractor,
+	interactor.NewAdminInteractor,
+	interactor.NewProductManagementInteractor,
+	interactor.NewProductExchangeInteractor,
+	interactor.NewCategoryManagementInteractor,
+	interactor.NewUserQueryInteractor,
+	interactor.NewUserSettingsInteractor,
+	interactor.NewKioskInteractor,
+	interactor.NewSessionInteractor,
+	interactor.NewIdempotencyInteractor,
+	interactor.NewMaintenanceInteractor,
+	interactor.NewPointExpiryPolicyInteractor,
+	interactor.NewDataExportInteractor,
+	interactor.NewSecurityHistoryInteractor,
+	interactor.NewContentModerationInteractor,
+	interactor.NewAnnouncementInteractor,
+	interactor.NewPricingRuleInteractor,
+	interactor.NewUserTierInteractor,
+	interactor.NewReferralInteractor,
+	interactor.NewEventInteractor,
+	interactor.NewReasonCodeInteractor,
+	interactor.NewUserImportInteractor,
+	interactor.NewDepartmentInteractor,
+	interactor.NewBudgetInteractor,
+	interactor.NewAdminApprovalInteractor,
+	interactor.NewScheduledJobInteractor,
+	interactor.NewWorkerLeaseInteractor,
+	interactor.NewSystemConfigInteractor,
+	interactor.NewBalanceReconciliationInteractor,
+	interactor.NewRecurringTransferInteractor,
+	interactor.NewFriendDiscoveryInteractor,
+	interactor.NewNotificationInteractor,
+	interactor.NewTransferScreeningInteractor,
+	interactor.NewSuspiciousActivityInteractor,
+	interactor.NewTransferEligibilityInteractor,
+	interactor.NewTransferPolicyInteractor,
+	interactor.NewTransferRequestQuotaInteractor,
+	interactor.NewBalanceHoldInteractor,
+	interactor.NewTransactionReceiptInteractor,
+	interactor.NewTransactionImportInteractor,
+	interactor.NewTenantInteractor,
+	interactor.NewTransactionArchiveInteractor,
+	interactor.NewWeeklyDigestInteractor,
+	interactor.NewTransferAttachmentInteractor,
+	interactor.NewSplitRequestInteractor,
+	interactor.NewOnboardingBonusInteractor,
+	interactor.NewAkerunRepollInteractor,
+	interactor.NewCartInteractor,
+	interactor.NewShippingAddressInteractor,
+	interactor.NewQuickSendInteractor,
+	interactor.NewEmailTemplateInteractor,
+	interactor.NewAdminDashboardInteractor,
+	interactor.NewBusinessMetricsInteractor,
+	interactor.NewDBStatsInteractor,
+	interactor.NewEmailVerificationRequirementInteractor,
+	interactor.NewResourceAuthorizationInteractor,
+
+	// concrete → interface bindings
+	wire.Bind(new(inputport.PointTransferInputPort), new(*interactor.PointTransferInteractor)),
+	wire.Bind(new(inputport.TransferEligibilityChecker), new(*interactor.TransferEligibilityInteractor)),
+	wire.Bind(new(inputport.TransferEligibilityInputPort), new(*interactor.TransferEligibilityInteractor)),
+	wire.Bind(new(inputport.TransferPolicyProvider), new(*interactor.TransferPolicyInteractor)),
+	wire.Bind(new(inputport.TransferPolicyInputPort), new(*interactor.TransferPolicyInteractor)),
+	wire.Bind(new(inputport.TransferRequestQuotaChecker), new(*interactor.TransferRequestQuotaInteractor)),
+	wire.Bind(new(inputport.TransferRequestQuotaInputPort), new(*interactor.TransferRequestQuotaInteractor)),
+	wire.Bind(new(inputport.BalanceHolder), new(*interactor.BalanceHoldInteractor)),
+	wire.Bind(new(inputport.BalanceHoldReleaser), new(*interactor.BalanceHoldInteractor)),
+	interactor.NewEarningRuleInteractor,
+	wire.Bind(new(inputport.EarningEventRecorder), new(*interactor.EarningRuleInteractor)),
+	wire.Bind(new(inputport.EarningRuleInputPort), new(*interactor.EarningRuleInteractor)),
+	wire.Bind(new(inputport.TransactionArchiver), new(*interactor.TransactionArchiveInteractor)),
+	wire.Bind(new(inputport.TransactionArchiveInputPort), new(*interactor.TransactionArchiveInteractor)),
+	wire.Bind(new(inputport.WeeklyDigestSender), new(*interactor.WeeklyDigestInteractor)),
+	wire.Bind(new(inputport.WeeklyDigestInputPort), new(*interactor.WeeklyDigestInteractor)),
+	wire.Bind(new(inputport.TransferAttachmentCleaner), new(*interactor.TransferAttachmentInteractor)),
+	wire.Bind(new(inputport.TransferAttachmentInputPort), new(*interactor.TransferAttachmentInteractor)),
+	wire.Bind(new(inputport.OnboardingBonusGranter), new(*interactor.OnboardingBonusInteractor)),
+	wire.Bind(new(inputport.OnboardingBonusInputPort), new(*interactor.OnboardingBonusInteractor)),
+	wire.Bind(new(inputport.EmailVerificationGate), new(*interactor.EmailVerificationRequirementInteractor)),
+	wire.Bind(new(inputport.EmailVerificationRequirementInputPort), new(*interactor.EmailVerificationRequirementInteractor)),
+	wire.Bind(new(inputport.ResourceAuthorizationInputPort), new(*interactor.ResourceAuthorizationInteractor)),
+	wire.Bind(new(inputport.DailyBonusInputPort), new(*interactor.DailyBonusInteractor)),
+	wire.Bind(new(inputport.ProductExchangeInputPort), new(*interactor.ProductExchangeInteractor)),
+	wire.Bind(new(inputport.NotificationDispatcher), new(inputport.NotificationInputPort)),
+	wire.Bind(new(inputport.ReferralTracker), new(inputport.ReferralInputPort)),
+)
+
+// ========================================
+// Presenter ProviderSet
+// ========================================
+
+var PresenterSet = wire.NewSet(
+	presenter.NewAuthPresenter,
+	presenter.NewPointPresenter,
+	presenter.NewFriendPresenter,
+	presenter.NewQRCodePresenter,
+	presenter.NewTransferRequestPresenter,
+	presenter.NewDailyBonusPresenter,
+	presenter.NewAdminPresenter,
+	presenter.NewUserSettingsPresenter,
+	presenter.NewKioskPresenter,
+	presenter.NewSessionPresenter,
+	presenter.NewMaintenancePresenter,
+	presenter.NewPointExpiryPolicyPresenter,
+	presenter.NewDataExportPresenter,
+	presenter.NewSecurityHistoryPresenter,
+	presenter.NewModerationPresenter,
+	presenter.NewAnnouncementPresenter,
+	presenter.NewPricingRulePresenter,
+	presenter.NewUserTierPresenter,
+	presenter.NewReferralPresenter,
+	presenter.NewEventPresenter,
+	presenter.NewReasonCodePresenter,
+	presenter.NewUserImportPresenter,
+	presenter.NewDepartmentPresenter,
+	presenter.NewBudgetPresenter,
+	presenter.NewAdminApprovalPresenter,
+	presenter.NewScheduledJobPresenter,
+	presenter.NewWorkerLeasePresenter,
+	presenter.NewSystemConfigPresenter,
+	presenter.NewBalanceReconciliationPresenter,
+	presenter.NewRecurringTransferPresenter,
+	presenter.NewNotificationPresenter,
+	presenter.NewSuspiciousActivityPresenter,
+	presenter.NewTransferEligibilityPresenter,
+	presenter.NewTransferPolicyPresenter,
+	presenter.NewTransferRequestQuotaPresenter,
+	presenter.NewTransactionReceiptPresenter,
+	presenter.NewTransferAttachmentPresenter,
+	presenter.NewEarningRulePresenter,
+	presenter.NewTenantPresenter,
+	presenter.NewTransactionArchivePresenter,
+	presenter.NewWeeklyDigestPresenter,
+	presenter.NewSplitRequestPresenter,
+	presenter.NewOnboardingBonusPresenter,
+	presenter.NewAkerunRepollPresenter,
+	presenter.NewCartPresenter,
+	presenter.NewShippingAddressPresenter,
+	presenter.NewEmailTemplatePresenter,
+	presenter.NewAdminDashboardPresenter,
+	presenter.NewMetricsPresenter,
+	presenter.NewDBStatsPresenter,
+	presenter.NewEmailVerificationRequirementPresenter,
+	presenter.NewTransactionImportPresenter,
+)
+
+// ========================================
+// Controller ProviderSet
+// ========================================
+
+var ControllerSet = wire.NewSet(
+	web.NewAuthController,
+	web.NewPointController,
+	web.NewFriendController,
+	web.NewQRCodeController,
+	web.NewTransferRequestController,
+	web.NewDailyBonusController,
+	web.NewAdminController,
+	web.NewProductController,
+	web.NewCategoryController,
+	web.NewUserSettingsController,
+	web.NewKioskController,
+	web.NewSessionController,
+	web.NewMaintenanceController,
+	web.NewPointExpiryPolicyController,
+	web.NewDataExportController,
+	web.NewSecurityHistoryController,
+	web.NewModerationController,
+	web.NewAnnouncementController,
+	web.NewPricingRuleController,
+	web.NewUserTierController,
+	web.NewReferralController,
+	web.NewEventController,
+	web.NewReasonCodeController,
+	web.NewUserImportController,
+	web.NewDepartmentController,
+	web.NewBudgetController,
+	web.NewAdminApprovalController,
+	web.NewScheduledJobController,
+	web.NewWorkerLeaseController,
+	web.NewSystemConfigController,
+	web.NewBalanceReconciliationController,
+	web.NewRecurringTransferController,
+	web.NewFriendDiscoveryController,
+	web.NewNotificationController,
+	web.NewSuspiciousActivityController,
+	web.NewTransferEligibilityController,
+	web.NewTransferPolicyController,
+	web.NewTransferRequestQuotaController,
+	web.NewTransactionReceiptController,
+	web.NewTransferAttachmentController,
+	web.NewEarningRuleController,
+	web.NewTenantController,
+	web.NewTransactionArchiveController,
+	web.NewWeeklyDigestController,
+	web.NewSplitRequestController,
+	web.NewOnboardingBonusController,
+	web.NewAkerunRepollController,
+	web.NewCartController,
+	web.NewShippingAddressController,
+	web.NewEmailTemplateController,
+	web.NewAdminDashboardController,
+	web.NewEmailVerificationRequirementController,
+	web.NewTransactionImportController,
+	web.NewDBStatsController,
+	ProvideMetricsController,
+)
+
+// ProvideMetricsController は /metrics のコントローラーを返す（METRICS_TOKEN を設定していればBearerトークンを求める）
+func ProvideMetricsController(cfg *config.Config, metricsUC inputport.BusinessMetricsInputPort, p *presenter.MetricsPresenter) *web.MetricsController {
+	return web.NewMetricsController(metricsUC, p, cfg.Metrics.Token)
+}
+
+// ========================================
+// Middleware ProviderSet
+// ========================================
+
+var MiddlewareSet = wire.NewSet(
+	middleware.NewAuthMiddleware,
+	middleware.NewCSRFMiddleware,
+	middleware.NewKioskAuthMiddleware,
+	middleware.NewIdempotencyMiddleware,
+	middleware.NewMaintenanceMiddleware,
+	middleware.NewResourceAuthorizationMiddleware,
+)
+
+// ========================================
+// Router & Framework ProviderSet
+// ========================================
+
+var FrameworkSet = wire.NewSet(
+	frameworksweb.NewSystemTimeProvider,
+	wire.Bind(new(service.TimeProvider), new(frameworksweb.TimeProvider)),
+	realtime.NewHub,
+	wire.Bind(new(service.RealtimeNotifier), new(*realtime.Hub)),
+)
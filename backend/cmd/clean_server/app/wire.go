@@ -0,0 +1,475 @@
+//go:build wireinject
+// +build wireinject
+
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/gity/point-system/config"
+	"github.com/gity/point-system/controllers/web"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	frameworksweb "github.com/gity/point-system/frameworks/web"
+	"github.com/gity/point-system/frameworks/web/middleware"
+	"github.com/gity/point-system/frameworks/web/realtime"
+	"github.com/gity/point-system/gateways/infra/infraakerun"
+	"github.com/gity/point-system/gateways/infra/infrabreaker"
+	"github.com/gity/point-system/gateways/infra/infraemail"
+	"github.com/gity/point-system/gateways/infra/infralogger"
+	"github.com/gity/point-system/gateways/infra/inframoderation"
+	"github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/gateways/infra/infrapush"
+	"github.com/gity/point-system/gateways/infra/infrastorage"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/wire"
+)
+
+// InitializeApp は Wire を使ってアプリケーションの依存関係を自動注入する
+func InitializeApp(cfg *config.Config) (*AppContainer, error) {
+	wire.Build(
+		// Config providers
+		ProvideDBConfig,
+		ProvideRouterConfig,
+		ProvideFileStorageService,
+		ProvideCircuitBreakers,
+		ProvideEmailService,
+		ProvideDataExportStorage,
+		ProvideContentModerator,
+		ProvidePushNotificationService,
+		ProvideJobSchedules,
+		ProvideTransactionRetention,
+		ProvideTransferAttachmentRetention,
+		ProvideSessionBindingPolicy,
+		ProvideCSRFPolicy,
+		ProvideSessionCookie,
+		ProvideConfigSettings,
+		ProvideAccessLogMiddleware,
+		ProvideTenantMiddleware,
+		ProvideAkerunConfigs,
+		ProvideAkerunOrganizations,
+
+		// レイヤー別 ProviderSet
+		InfraSet,
+		DataSourceSet,
+		RepositorySet,
+		ServiceSet,
+		InteractorSet,
+		PresenterSet,
+		ControllerSet,
+		MiddlewareSet,
+		FrameworkSet,
+
+		// Router
+		ProvideRouter,
+
+		// AppContainer
+		wire.Struct(new(AppContainer), "*"),
+	)
+	return nil, nil
+}
+
+// ========================================
+// Config Providers
+// ========================================
+
+func ProvideDBConfig(cfg *config.Config) *infrapostgres.Config {
+	return &infrapostgres.Config{
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		User:     cfg.Database.User,
+		Password: cfg.Database.Password,
+		DBName:   cfg.Database.DBName,
+		SSLMode:  cfg.Database.SSLMode,
+		Env:      cfg.Server.Env,
+
+		ReplicaDSN: cfg.Database.ReplicaDSN,
+		Pool: infrapostgres.PoolConfig{
+			MaxOpenConns:     cfg.Database.MaxOpenConns,
+			MaxIdleConns:     cfg.Database.MaxIdleConns,
+			ConnMaxLifetime:  cfg.Database.ConnMaxLifetime,
+			StatementTimeout: cfg.Database.StatementTimeout,
+			LockTimeout:      cfg.Database.LockTimeout,
+		},
+	}
+}
+
+func ProvideRouterConfig(cfg *config.Config) *frameworksweb.RouterConfig {
+	return &frameworksweb.RouterConfig{
+		Env:             cfg.Server.Env,
+		AllowedOrigins:  cfg.Security.AllowedOrigins,
+		MaxUploadSizeMB: cfg.Server.MaxUploadSizeMB,
+
+		DeprecatedAPIVersions: cfg.Server.DeprecatedAPIVersions,
+		RequestTimeouts: frameworksweb.RequestTimeouts{
+			Default: cfg.Server.RequestTimeout,
+			Groups:  cfg.Server.RequestTimeouts,
+		},
+	}
+}
+
+func ProvideFileStorageService() (service.FileStorageService, error) {
+	return infrastorage.NewLocalStorage(&infrastorage.Config{
+		BaseDir:             "./uploads/avatars",
+		BaseURL:             "/uploads/avatars",
+		MaxSizeMB:           20,
+		AttachmentDir:       "./uploads/attachments",
+		AttachmentMaxSizeMB: 2,
+	})
+}
+
+// ProvideCircuitBreakers は外部サービスごとのサーキットブレーカーをまとめて返す
+func ProvideCircuitBreakers(cfg *config.Config, logger entities.Logger) *infrabreaker.Registry {
+	return infrabreaker.NewRegistry(infrabreaker.Settings{
+		MaxFailures:      cfg.CircuitBreaker.MaxFailures,
+		OpenTimeout:      cfg.CircuitBreaker.OpenTimeout,
+		HalfOpenRequests: cfg.CircuitBreaker.HalfOpenRequests,
+	}, logger)
+}
+
+// ProvideEmailService はメール送信サービスを返す（文面は編集済みのテンプレートから作り、送信の失敗が続いたら止める）
+func ProvideEmailService(templates repository.EmailTemplateRepository, logger entities.Logger, breakers *infrabreaker.Registry) service.EmailService {
+	return infraemail.NewCircuitBreakerEmailService(infraemail.NewConsoleEmailService(templates, logger), breakers.Breaker("email"))
+}
+
+func ProvideDataExportStorage() (service.DataExportStorage, error) {
+	return infrastorage.NewLocalExportStorage("./exports")
+}
+
+// ProvideContentModerator は設定に応じたモデレーション実装を返す
+// MODERATION_API_URL が設定されていれば外部APIを使い、到達できない場合は禁止語リストで判定する
+func ProvideContentModerator(cfg *config.Config, logger entities.Logger) (service.ContentModerator, error) {
+	terms := append([]string{}, inframoderation.DefaultWordlist...)
+	if cfg.Moderation.WordlistFile != "" {
+		extra, err := inframoderation.LoadWordlist(cfg.Moderation.WordlistFile)
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, extra...)
+	}
+
+	wordlist := inframoderation.NewWordlistModerator(terms)
+	if cfg.Moderation.APIURL == "" {
+		return wordlist, nil
+	}
+	return inframoderation.NewHTTPModerator(cfg.Moderation.APIURL, cfg.Moderation.APIKey, cfg.Moderation.APITimeout, wordlist, logger), nil
+}
+
+// ProvidePushNotificationService は設定済みのプラットフォームだけAPNs・FCMへ送る実装を返す
+// 未設定のプラットフォーム宛ての通知はログ出力のみ
+func ProvidePushNotificationService(cfg *config.Config, logger entities.Logger) (service.PushNotificationService, error) {
+	platforms := map[entities.DevicePlatform]service.PushNotificationService{}
+
+	if cfg.Push.FCMCredentialsFile != "" {
+		creds, err := infrapush.LoadFCMCredentials(cfg.Push.FCMCredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		fcm, err := infrapush.NewFCMClient(creds, "", cfg.Push.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		platforms[entities.DevicePlatformAndroid] = fcm
+	}
+
+	if cfg.Push.APNsKeyFile != "" {
+		apns, err := infrapush.NewAPNsClient(infrapush.APNsConfig{
+			KeyFile:    cfg.Push.APNsKeyFile,
+			KeyID:      cfg.Push.APNsKeyID,
+			TeamID:     cfg.Push.APNsTeamID,
+			Topic:      cfg.Push.APNsTopic,
+			Timeout:    cfg.Push.Timeout,
+			Production: cfg.Push.APNsProduction,
+		})
+		if err != nil {
+			return nil, err
+		}
+		platforms[entities.DevicePlatformIOS] = apns
+	}
+
+	return infrapush.NewPlatformPushService(platforms, infrapush.NewConsolePushService(logger)), nil
+}
+
+// ProvideJobSchedules はJOB_SCHEDULESで上書きされた定期実行ジョブのcron式を返す
+func ProvideJobSchedules(cfg *config.Config) entities.JobSchedules {
+	schedules := entities.JobSchedules{}
+	for name, expr := range cfg.Scheduler.Schedules {
+		schedules[entities.ScheduledJobName(name)] = expr
+	}
+	return schedules
+}
+
+// ProvideTransactionRetention は取引の保持期間の設定を返す
+func ProvideTransactionRetention(cfg *config.Config) entities.TransactionRetention {
+	return entities.TransactionRetention{
+		Days:      cfg.Retention.TransactionDays,
+		BatchSize: cfg.Retention.BatchSize,
+	}
+}
+
+// ProvideTransferAttachmentRetention は送金の添付画像の保持期間の設定を返す
+func ProvideTransferAttachmentRetention(cfg *config.Config) entities.TransferAttachmentRetention {
+	return entities.TransferAttachmentRetention{Days: cfg.Retention.AttachmentDays}
+}
+
+// ProvideSessionBindingPolicy はセッションを端末・接続元に固定する設定を返す
+func ProvideSessionBindingPolicy(cfg *config.Config) entities.SessionBindingPolicy {
+	return entities.SessionBindingPolicy{
+		Mode:          entities.SessionBindingMode(cfg.Security.SessionBinding.Mode),
+		IPv4PrefixLen: cfg.Security.SessionBinding.IPv4PrefixLen,
+		IPv6PrefixLen: cfg.Security.SessionBinding.IPv6PrefixLen,
+	}
+}
+
+// ProvideCSRFPolicy はCSRFトークンの入れ替えの設定を返す
+func ProvideCSRFPolicy(cfg *config.Config) entities.CSRFPolicy {
+	return entities.CSRFPolicy{
+		Rotation: entities.CSRFRotationMode(cfg.Security.CSRF.Rotation),
+		TTL:      cfg.Security.CSRF.TTL,
+		Grace:    cfg.Security.CSRF.Grace,
+	}
+}
+
+// ProvideSessionCookie はセッショントークンのCookieの属性を返す
+func ProvideSessionCookie(cfg *config.Config) *presenter.SessionCookie {
+	sameSite := http.SameSiteLaxMode
+	switch cfg.Security.SessionCookie.SameSite {
+	case config.SameSiteStrict:
+		sameSite = http.SameSiteStrictMode
+	case config.SameSiteNone:
+		sameSite = http.SameSiteNoneMode
+	}
+	return presenter.NewSessionCookie(cfg.Security.SessionCookie.Domain, cfg.Security.SessionCookie.Secure, sameSite)
+}
+
+// ProvideAccessLogMiddleware はJSONで標準出力に書くアクセスログのミドルウェアを作成
+func ProvideAccessLogMiddleware(cfg *config.Config) *middleware.AccessLogMiddleware {
+	return middleware.NewAccessLogMiddleware(infralogger.NewJSONLogger(os.Stdout), middleware.AccessLogConfig{
+		Enabled:      cfg.AccessLog.Enabled,
+		BodyRoutes:   cfg.AccessLog.BodyRoutes,
+		MaxBodyBytes: cfg.AccessLog.MaxBodyBytes,
+		SampleRates:  cfg.AccessLog.SampleRates,
+	})
+}
+
+// ProvideTenantMiddleware はヘッダーまたはサブドメインからテナントを解決するミドルウェアを作成
+func ProvideTenantMiddleware(cfg *config.Config, tenantUC inputport.TenantInputPort) *middleware.TenantMiddleware {
+	return middleware.NewTenantMiddleware(tenantUC, cfg.Server.TenantBaseDomain)
+}
+
+// ProvideConfigSettings は管理者向けに表示する設定（秘密の値は伏せたもの）を返す
+func ProvideConfigSettings(cfg *config.Config) entities.ConfigSettings {
+	settings := entities.ConfigSettings{}
+	for _, s := range cfg.Settings() {
+		settings = append(settings, &entities.ConfigSetting{
+			Key:    s.Key,
+			Path:   s.Path,
+			Value:  s.Value,
+			Source: s.Source,
+			Secret: s.Secret,
+		})
+	}
+	return settings
+}
+
+// ProvideAkerunConfigs はポーリングするAkerun組織の設定を返す
+// AKERUN_ACCESS_TOKEN・AKERUN_ORGANIZATION_IDの組織（全ドアが対象）に、AKERUN_ORGANIZATIONS_FILEの組織を追加する
+// 1回の取得でたどるページ数の上限（AKERUN_MAX_PAGES）はすべての組織に共通
+func ProvideAkerunConfigs(cfg *config.Config) ([]*infraakerun.AkerunConfig, error) {
+	configs := []*infraakerun.AkerunConfig{}
+	if cfg.Akerun.AccessToken != "" && cfg.Akerun.OrganizationID != "" {
+		configs = append(configs, &infraakerun.AkerunConfig{
+			AccessToken:    cfg.Akerun.AccessToken,
+			OrganizationID: cfg.Akerun.OrganizationID,
+		})
+	}
+	if cfg.Akerun.OrganizationsFile != "" {
+		orgs, err := infraakerun.LoadOrganizations(cfg.Akerun.OrganizationsFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, org := range orgs {
+			if org.OrganizationID == cfg.Akerun.OrganizationID && cfg.Akerun.AccessToken != "" {
+				return nil, fmt.Errorf("Akerun organization %s is configured twice", org.OrganizationID)
+			}
+		}
+		configs = append(configs, orgs...)
+	}
+	for _, c := range configs {
+		c.MaxPages = cfg.Akerun.MaxPages
+	}
+	return configs, nil
+}
+
+// ProvideAkerunOrganizations は組織ごとのドアのボーナス規則を返す
+func ProvideAkerunOrganizations(configs []*infraakerun.AkerunConfig) entities.AkerunOrganizations {
+	orgs := entities.AkerunOrganizations{}
+	for _, c := range configs {
+		orgs[c.OrganizationID] = entities.AkerunOrganization{
+			ID:    c.OrganizationID,
+			Name:  c.OrganizationName,
+			Doors: c.Doors,
+		}
+	}
+	return orgs
+}
+
+// ========================================
+// Router Provider
+// ========================================
+
+func ProvideRouter(
+	cfg *frameworksweb.RouterConfig,
+	tp frameworksweb.TimeProvider,
+	auth *web.AuthController,
+	point *web.PointController,
+	friend *web.FriendController,
+	qrcode *web.QRCodeController,
+	transferReq *web.TransferRequestController,
+	dailyBonus *web.DailyBonusController,
+	admin *web.AdminController,
+	product *web.ProductController,
+	category *web.CategoryController,
+	settings *web.UserSettingsController,
+	kiosk *web.KioskController,
+	session *web.SessionController,
+	authMW *middleware.AuthMiddleware,
+	csrfMW *middleware.CSRFMiddleware,
+	kioskMW *middleware.KioskAuthMiddleware,
+	idempotencyMW *middleware.IdempotencyMiddleware,
+	maintenance *web.MaintenanceController,
+	maintenanceMW *middleware.MaintenanceMiddleware,
+	expiryPolicy *web.PointExpiryPolicyController,
+	dataExport *web.DataExportController,
+	securityHistory *web.SecurityHistoryController,
+	moderation *web.ModerationController,
+	announcement *web.AnnouncementController,
+	recurringTransfer *web.RecurringTransferController,
+	friendDiscovery *web.FriendDiscoveryController,
+	notification *web.NotificationController,
+	pricingRule *web.PricingRuleController,
+	userTier *web.UserTierController,
+	referral *web.ReferralController,
+	event *web.EventController,
+	reasonCode *web.ReasonCodeController,
+	userImport *web.UserImportController,
+	department *web.DepartmentController,
+	budget *web.BudgetController,
+	adminApproval *web.AdminApprovalController,
+	scheduledJob *web.ScheduledJobController,
+	workerLease *web.WorkerLeaseController,
+	reconciliation *web.BalanceReconciliationController,
+	suspicious *web.SuspiciousActivityController,
+	eligibility *web.TransferEligibilityController,
+	transferPolicy *web.TransferPolicyController,
+	earningRule *web.EarningRuleController,
+	transactionImport *web.TransactionImportController,
+	systemConfig *web.SystemConfigController,
+	tenant *web.TenantController,
+	transactionArchive *web.TransactionArchiveController,
+	splitRequest *web.SplitRequestController,
+	weeklyDigest *web.WeeklyDigestController,
+	onboardingBonus *web.OnboardingBonusController,
+	akerunRepoll *web.AkerunRepollController,
+	cart *web.CartController,
+	shippingAddress *web.ShippingAddressController,
+	emailTemplate *web.EmailTemplateController,
+	adminDashboard *web.AdminDashboardController,
+	emailVerification *web.EmailVerificationRequirementController,
+	requestQuota *web.TransferRequestQuotaController,
+	receipt *web.TransactionReceiptController,
+	transferAttachment *web.TransferAttachmentController,
+	dbStats *web.DBStatsController,
+	metrics *web.MetricsController,
+	realtimeHub *realtime.Hub,
+	accessLogMW *middleware.AccessLogMiddleware,
+	tenantMW *middleware.TenantMiddleware,
+	authorizationMW *middleware.ResourceAuthorizationMiddleware,
+	circuitBreakers *infrabreaker.Registry,
+) *frameworksweb.Router {
+	r := frameworksweb.NewRouter(cfg, tp).WithCircuitBreakers(circuitBreakers).WithMetrics(metrics.Metrics)
+
+	v1 := []web.RouteRegistrar{
+		auth,
+		point,
+		friend,
+		qrcode,
+		transferReq,
+		dailyBonus,
+		admin,
+		product,
+		category,
+		settings,
+		kiosk,
+		session,
+		maintenance,
+		expiryPolicy,
+		dataExport,
+		securityHistory,
+		moderation,
+		announcement,
+		recurringTransfer,
+		friendDiscovery,
+		notification,
+		pricingRule,
+		userTier,
+		referral,
+		event,
+		reasonCode,
+		userImport,
+		department,
+		budget,
+		adminApproval,
+		scheduledJob,
+		workerLease,
+		reconciliation,
+		suspicious,
+		eligibility,
+		transferPolicy,
+		earningRule,
+		transactionImport,
+		systemConfig,
+		tenant,
+		transactionArchive,
+		splitRequest,
+		weeklyDigest,
+		onboardingBonus,
+		akerunRepoll,
+		cart,
+		shippingAddress,
+		emailTemplate,
+		adminDashboard,
+		emailVerification,
+		requestQuota,
+		receipt,
+		transferAttachment,
+		dbStats,
+	}
+
+	// v2はv1のコントローラーを引き継ぎ、出力形式を変えたものだけ差し替える
+	v2 := frameworksweb.ReplaceRegistrar(v1, point, point.WithPresenter(presenter.NewPointPresenterV2()))
+
+	r.RegisterRoutes(
+		[]frameworksweb.VersionMount{
+			{Version: frameworksweb.APIVersionUnversioned, Registrars: v1},
+			{Version: frameworksweb.APIVersionV1, Registrars: v1},
+			{Version: frameworksweb.APIVersionV2, Registrars: v2},
+		},
+		&frameworksweb.Middlewares{
+			Auth:          authMW,
+			CSRF:          csrfMW,
+			Kiosk:         kioskMW,
+			Idempotency:   idempotencyMW,
+			Maintenance:   maintenanceMW,
+			Realtime:      realtimeHub,
+			AccessLog:     accessLogMW,
+			Tenant:        tenantMW,
+			Authorization: authorizationMW,
+		},
+	)
+	return r
+}
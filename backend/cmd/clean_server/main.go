@@ -1,86 +1,153 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
+	"net/http"
+	"net/http/pprof"
+	"time"
 
+	"github.com/gity/point-system/cmd/clean_server/app"
 	"github.com/gity/point-system/config"
-	"github.com/gity/point-system/entities"
-	frameworksweb "github.com/gity/point-system/frameworks/web"
-	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
 	"github.com/gity/point-system/gateways/infra"
 	"github.com/gity/point-system/gateways/infra/infraakerun"
-	"github.com/gity/point-system/gateways/infra/infrapostgres"
-	"github.com/gity/point-system/usecases/interactor"
-	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
 )
 
-// AppContainer はアプリケーションの依存関係を管理
-// Wire が自動注入するフィールド
-type AppContainer struct {
-	Router *frameworksweb.Router
-	DB     infrapostgres.DB
-
-	// Workers 構築に必要な依存を Wire から受け取る
-	DailyBonusUC    *interactor.DailyBonusInteractor
-	PointBatchRepo  repository.PointBatchRepository
-	UserRepo        repository.UserRepository
-	TransactionRepo repository.TransactionRepository
-	TxManager       repository.TransactionManager
-	Logger          entities.Logger
-	TimeProvider    frameworksweb.TimeProvider
-}
-
 func main() {
+	migrate := flag.Bool("migrate", false, "起動前に未適用のマイグレーションを適用する")
+	flag.Parse()
+
 	cfg := config.LoadConfig()
 
 	// Wire DI
-	app, err := InitializeApp(cfg)
+	container, err := app.InitializeApp(cfg)
 	if err != nil {
 		log.Fatalf("Failed to initialize app: %v", err)
 	}
 	defer func() {
-		if app.DB != nil {
-			app.DB.Close()
+		if container.DB != nil {
+			container.DB.Close()
 		}
 	}()
 
-	// AutoMigrate（新規テーブルのみ）
-	if err := app.DB.GetDB().AutoMigrate(
-		&dspostgresimpl.CategoryModel{},
-	); err != nil {
-		log.Fatalf("Failed to auto migrate: %v", err)
+	// スキーマバージョン確認（--migrate 指定時は先に適用）と新規テーブルのAutoMigrate
+	if err := app.PrepareSchema(cfg, container.DB, *migrate); err != nil {
+		log.Fatalf("Schema check failed: %v", err)
 	}
 
 	// Workers（Wire 外で構築）
-	startWorkers(cfg, app)
+	startWorkers(cfg, container)
+
+	// プロファイル（PPROF_ADDR を指定したときだけ、APIとは別のポートで公開）
+	if cfg.Server.PprofAddr != "" {
+		startProfiler(cfg.Server.PprofAddr)
+	}
 
 	// サーバー起動
 	addr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
 	log.Printf("🚀 Server starting on %s (env: %s)", addr, cfg.Server.Env)
 
-	if err := app.Router.Run(addr); err != nil {
+	if err := container.Router.Run(addr); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
 
-func startWorkers(cfg *config.Config, app *AppContainer) {
-	// Akerun Worker
-	akerunClient := infraakerun.NewAkerunClient(&infraakerun.AkerunConfig{
-		AccessToken:    cfg.Akerun.AccessToken,
-		OrganizationID: cfg.Akerun.OrganizationID,
-	})
+// startProfiler はnet/http/pprofのプロファイルを別のポートで公開する（負荷試験中のCPU・ヒープ・ゴルーチンの確認用）
+// DefaultServeMuxには登録せず、APIのルーターからは見えないようにする
+func startProfiler(addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		log.Printf("Profiler listening on %s/debug/pprof/", addr)
+		if err := server.ListenAndServe(); err != nil {
+			log.Printf("Profiler stopped: %v", err)
+		}
+	}()
+}
+
+func startWorkers(cfg *config.Config, container *app.AppContainer) {
+	// ワーカーごとのリーダー選出（複数インスタンスで動かしても、各ワーカーはリーダーの1台だけが処理する）
+	leaderElector := infra.NewLeaderElector(container.WorkerLeaseUC, container.Logger)
+
+	// Akerun Worker（組織ごとにクライアントを作り、並行してポーリングする）
+	// APIの失敗が続いた組織はサーキットブレーカーで呼び出しを止め、回復を確かめてから再開する
+	akerunGateways := make([]service.AkerunAccessGateway, 0, len(container.AkerunConfigs))
+	for _, c := range container.AkerunConfigs {
+		breaker := container.CircuitBreakers.Breaker("akerun:" + c.OrganizationID)
+		akerunGateways = append(akerunGateways, infraakerun.NewAkerunClient(c).WithCircuitBreaker(breaker))
+	}
 	akerunWorker := infraakerun.NewAkerunWorker(
-		akerunClient, app.DailyBonusUC, app.TimeProvider, app.Logger,
-	)
+		akerunGateways, container.DailyBonusUC, container.TimeProvider, container.Logger,
+	).WithMaintenance(container.MaintenanceUC).WithLeaderElection(leaderElector).WithRepolls(container.AkerunRepollUC)
 	akerunWorker.Start()
 
 	// Point Expiry Worker
 	pointExpiryWorker := infra.NewPointExpiryWorker(
-		app.PointBatchRepo, app.UserRepo, app.TransactionRepo,
-		app.TxManager, app.Logger,
-	)
+		container.PointBatchRepo, container.PointExpiryPolicyRepo, container.SystemSettingsRepo, container.UserRepo, container.TransactionRepo,
+		container.TxManager, container.Logger,
+	).WithMaintenance(container.MaintenanceUC).WithLeaderElection(leaderElector).
+		WithLimits(infra.PointExpiryLimits{
+			BatchSize:  cfg.PointExpiry.BatchSize,
+			BatchPause: cfg.PointExpiry.BatchPause,
+			MaxRuntime: cfg.PointExpiry.MaxRuntime,
+		})
 	pointExpiryWorker.Start()
 
-	app.Logger.Info("All workers started")
+	// Idempotency-Keyのレスポンス保存の掃除
+	idempotencyCleanupWorker := infra.NewIdempotencyCleanupWorker(container.IdempotentRequestRepo, container.Logger).
+		WithMaintenance(container.MaintenanceUC).
+		WithLeaderElection(leaderElector)
+	idempotencyCleanupWorker.Start()
+
+	// 個人データエクスポートの作成と期限切れファイルの削除
+	dataExportWorker := infra.NewDataExportWorker(container.DataExportUC, container.Logger).
+		WithMaintenance(container.MaintenanceUC).
+		WithLeaderElection(leaderElector)
+	dataExportWorker.Start()
+
+	// 定期送金の実行
+	recurringTransferWorker := infra.NewRecurringTransferWorker(container.RecurringTransferUC, container.Logger).
+		WithMaintenance(container.MaintenanceUC).
+		WithLeaderElection(leaderElector)
+	recurringTransferWorker.Start()
+
+	// 有効期限が近いポイントの通知
+	expiryReminderWorker := infra.NewExpiryReminderWorker(container.NotificationUC, container.Logger).
+		WithMaintenance(container.MaintenanceUC).
+		WithLeaderElection(leaderElector)
+	expiryReminderWorker.Start()
+
+	// 会員ランクの夜間判定
+	userTierWorker := infra.NewUserTierWorker(container.UserTierUC, container.Logger).
+		WithMaintenance(container.MaintenanceUC).
+		WithLeaderElection(leaderElector)
+	userTierWorker.Start()
+
+	// 承認されないまま期限を過ぎた大口の付与・減算の期限切れ
+	adminApprovalExpiryWorker := infra.NewAdminApprovalExpiryWorker(container.AdminApprovalUC, container.Logger).
+		WithMaintenance(container.MaintenanceUC).
+		WithLeaderElection(leaderElector)
+	adminApprovalExpiryWorker.Start()
+
+	// cron式で設定された定期メンテナンスジョブ（ジョブごとにDBでロックするので複数台でも1回だけ実行される）
+	jobSchedulerWorker := infra.NewJobSchedulerWorker(container.ScheduledJobUC, container.Logger).
+		WithMaintenance(container.MaintenanceUC)
+	jobSchedulerWorker.Start()
+
+	// /metrics で公開する業務の指標の集計（各インスタンスが自分の /metrics のために集計する）
+	businessMetricsWorker := infra.NewBusinessMetricsWorker(container.BusinessMetricsUC, container.Logger).
+		WithInterval(cfg.Metrics.RefreshInterval)
+	businessMetricsWorker.Start()
+
+	leaderElector.Start()
+
+	container.Logger.Info("All workers started")
 }
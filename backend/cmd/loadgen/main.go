@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gity/point-system/pkg/loadgen"
+	"github.com/spf13/cobra"
+)
+
+// loadgen は検証環境のAPIに利用者と同じ操作（登録・送金・送金リクエストの承認・QRコードの読み取り）を並行して送る負荷試験ツール
+// 操作ごとの応答時間のパーセンタイルを出し、最後にポイントが増減していないこと（ポイントの保存）を確かめる
+// ロックや性能に関わる変更の検証に使う。本番環境には向けないこと（ユーザーとポイントの付与が残る）
+//
+//	go run ./cmd/loadgen --target http://localhost:8080 --users 50 --concurrency 20 --duration 1m
+//	go run ./cmd/loadgen --target http://localhost:8080 --mix transfer=80,qr=20 --requests 10000
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := newRootCommand().ExecuteContext(ctx); err != nil {
+		os.Exit(1)
+	}
+}
+
+// 行う操作（--mix で指定する名前）
+const (
+	opRegister = "register" // 新しいユーザーを登録して、以降の操作の対象に加える
+	opTransfer = "transfer" // 直接の送金（手数料がかかる）
+	opRequest  = "request"  // 送金リクエストを作成し、受取人が承認する
+	opQR       = "qr"       // 受取人が金額付きの受取用QRコードを作り、送る側が読み取る
+)
+
+// operations は --mix で指定できる操作
+var operations = []string{opRegister, opTransfer, opRequest, opQR}
+
+// defaultMix は利用者の操作の既定の配分
+const defaultMix = "register=5,transfer=50,request=25,qr=20"
+
+// options はコマンドのフラグ
+type options struct {
+	target        string
+	adminUser     string
+	adminPassword string
+	reasonCode    string
+
+	users          int
+	initialBalance int64
+	password       string
+
+	concurrency int
+	duration    time.Duration
+	requests    int
+	mix         string
+	maxAmount   int64
+	timeout     time.Duration
+}
+
+func newRootCommand() *cobra.Command {
+	opts := &options{}
+	cmd := &cobra.Command{
+		Use:          "loadgen",
+		Short:        "APIに利用者の操作を並行して送り、応答時間とポイントの保存を確かめる",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mix, err := loadgen.ParseMix(opts.mix, operations)
+			if err != nil {
+				return err
+			}
+			if err := opts.validate(); err != nil {
+				return err
+			}
+			return run(cmd.Context(), opts, mix)
+		},
+	}
+	cmd.Flags().StringVar(&opts.target, "target", "http://localhost:8080", "サーバーのオリジン")
+	cmd.Flags().StringVar(&opts.adminUser, "admin-user", "admin", "ポイントを付与し、統計を確認する管理者")
+	cmd.Flags().StringVar(&opts.adminPassword, "admin-password", "admin123", "管理者のパスワード")
+	cmd.Flags().StringVar(&opts.reasonCode, "reason-code", "other", "初期残高の付与に使う理由コード")
+	cmd.Flags().IntVar(&opts.users, "users", 20, "最初に登録するユーザーの数")
+	cmd.Flags().Int64Var(&opts.initialBalance, "initial-balance", 10000, "最初に登録したユーザーに付与するポイント")
+	cmd.Flags().StringVar(&opts.password, "password", "loadgen-password1", "登録するユーザーのパスワード")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 10, "並行して操作するワーカーの数")
+	cmd.Flags().DurationVar(&opts.duration, "duration", 30*time.Second, "負荷をかける時間")
+	cmd.Flags().IntVar(&opts.requests, "requests", 0, "行う操作の回数（0なら --duration の間続ける。両方指定すると先に達した方で止める）")
+	cmd.Flags().StringVar(&opts.mix, "mix", defaultMix, "操作の配分（操作=重み をカンマ区切り。操作は register / transfer / request / qr）")
+	cmd.Flags().Int64Var(&opts.maxAmount, "max-amount", 100, "1回の送金の最大ポイント（1〜この値で選ぶ）")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 30*time.Second, "1回のリクエストのタイムアウト")
+	return cmd
+}
+
+func (o *options) validate() error {
+	switch {
+	case o.users < 2:
+		return fmt.Errorf("--users must be at least 2 (transfers need a sender and a receiver)")
+	case o.concurrency < 1:
+		return fmt.Errorf("--concurrency must be positive")
+	case o.duration <= 0 && o.requests <= 0:
+		return fmt.Errorf("either --duration or --requests is required")
+	case o.maxAmount < 1:
+		return fmt.Errorf("--max-amount must be positive")
+	case o.initialBalance < 0:
+		return fmt.Errorf("--initial-balance must not be negative")
+	}
+	return nil
+}
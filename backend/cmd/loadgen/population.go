@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/http/cookiejar"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gity/point-system/pkg/client"
+	"github.com/google/uuid"
+)
+
+// vuser は負荷試験で操作する1人のユーザー（セッションを持ったクライアント）
+type vuser struct {
+	api  *client.Client
+	user client.User
+}
+
+// population は操作の対象にするユーザー（負荷試験の間に登録したユーザーも加える）
+type population struct {
+	mu    sync.RWMutex
+	users []*vuser
+}
+
+func (p *population) add(u *vuser) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.users = append(p.users, u)
+}
+
+// pair は送る側と受け取る側の異なる2人を選ぶ
+func (p *population) pair(r *rand.Rand) (from, to *vuser) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	i := r.IntN(len(p.users))
+	j := r.IntN(len(p.users) - 1)
+	if j >= i {
+		j++
+	}
+	return p.users[i], p.users[j]
+}
+
+func (p *population) all() []*vuser {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return append([]*vuser(nil), p.users...)
+}
+
+// ledger は負荷試験のユーザーの残高の合計がいくらになるはずか（ユーザーの間の送金では合計は変わらない）
+type ledger struct {
+	granted atomic.Int64 // 管理者が付与したポイント
+	bonuses atomic.Int64 // 登録時の特典
+	fees    atomic.Int64 // 送金の手数料（手数料の勘定に移る）
+	// uncertain は処理されたかどうか分からない（接続エラー・5xxの）手数料のかかる送金の数
+	// 1件でもあれば、ユーザーの残高の合計は確かめられない
+	uncertain atomic.Int64
+}
+
+// expected はユーザーの残高の合計の期待値
+func (l *ledger) expected() int64 {
+	return l.granted.Load() + l.bonuses.Load() - l.fees.Load()
+}
+
+// clientFactory はユーザーごとのクライアントを作る（接続は全員で使い回し、Cookieはユーザーごとに分ける）
+type clientFactory struct {
+	target    string
+	timeout   time.Duration
+	transport http.RoundTripper
+}
+
+func newClientFactory(o *options) *clientFactory {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 0
+	transport.MaxIdleConnsPerHost = o.concurrency * 2
+	return &clientFactory{target: o.target, timeout: o.timeout, transport: transport}
+}
+
+// newClient は再送しないクライアントを作成（再送すると応答時間と失敗の数が実際と変わるため）
+func (f *clientFactory) newClient() *client.Client {
+	jar, _ := cookiejar.New(nil) // オプションなしでは失敗しない
+	return client.New(f.target).
+		WithHTTPClient(&http.Client{Jar: jar, Transport: f.transport, Timeout: f.timeout}).
+		WithRetryPolicy(client.NoRetry).
+		WithUserAgent("point-system-loadgen")
+}
+
+// registerUser は新しいユーザーを登録してログインした状態にする
+// メール認証が済むまでログインできない設定なら登録だけ行いエラーを返す
+func (f *clientFactory) registerUser(ctx context.Context, runID string, seq int64, password string, l *ledger) (*vuser, error) {
+	username := fmt.Sprintf("lg_%s_%d", runID, seq)
+	api := f.newClient()
+	resp, err := api.Register(ctx, client.RegisterRequest{
+		Username:    username,
+		Email:       username + "@loadgen.example.com",
+		Password:    password,
+		DisplayName: username,
+		FirstName:   "負荷",
+		LastName:    "試験",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.CSRFToken == "" {
+		return nil, fmt.Errorf("%s cannot log in until the email address is verified (email_verification=%s)", username, resp.EmailVerification)
+	}
+	// 操作の対象に加えるユーザーの分だけ数える（残高の合計もそのユーザーで確かめる）
+	l.bonuses.Add(resp.OnboardingBonus)
+	return &vuser{api: api, user: resp.User}, nil
+}
+
+// newRunID は実行ごとに重ならないユーザー名の接頭辞
+func newRunID() string {
+	return uuid.NewString()[:8]
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gity/point-system/pkg/client"
+	"github.com/gity/point-system/pkg/loadgen"
+)
+
+// conservationReport はポイントの保存の確認結果
+type conservationReport struct {
+	// ユーザー側: 負荷試験のユーザーの残高の合計が、付与・特典から手数料を引いた額と一致するか
+	users         int
+	userTotal     int64
+	expectedTotal int64
+	userErr       error
+	uncertain     int64 // 結果の分からない送金があれば確かめない
+
+	// サーバー側: GET /admin/analytics のユーザーとシステム勘定の残高の合計が0か
+	conservation *client.Conservation
+	accounts     []client.SystemAccount
+	serverErr    error
+}
+
+// check は負荷をかけ終えた後のポイントの保存を確かめる
+func (s *scenario) check(ctx context.Context, admin *client.Client) *conservationReport {
+	report := &conservationReport{expectedTotal: s.ledger.expected(), uncertain: s.ledger.uncertain.Load()}
+
+	users := s.pop.all()
+	report.users = len(users)
+	for _, u := range users {
+		balance, err := u.api.Balance(ctx)
+		if err != nil {
+			report.userErr = fmt.Errorf("failed to get the balance of %s: %w", u.user.Username, err)
+			break
+		}
+		report.userTotal += balance
+	}
+
+	analytics, err := admin.Analytics(ctx, 1)
+	if err != nil {
+		report.serverErr = fmt.Errorf("failed to get analytics: %w", err)
+	} else {
+		report.conservation = &analytics.Conservation
+		report.accounts = analytics.SystemAccounts
+	}
+	return report
+}
+
+// ok はどちらの確認でも不一致が見つからなかったか（確かめられなかった場合も失敗にする）
+func (r *conservationReport) ok() bool {
+	if r.userErr != nil || r.serverErr != nil || !r.conservation.Conserved {
+		return false
+	}
+	return r.uncertain > 0 || r.userTotal == r.expectedTotal
+}
+
+func (r *conservationReport) print() {
+	fmt.Println()
+	fmt.Println("point conservation:")
+	switch {
+	case r.userErr != nil:
+		fmt.Printf("  users:  ERROR %v\n", r.userErr)
+	case r.uncertain > 0:
+		fmt.Printf("  users:  SKIPPED %d transfers failed with an unknown outcome (total %d, expected %d)\n", r.uncertain, r.userTotal, r.expectedTotal)
+	case r.userTotal == r.expectedTotal:
+		fmt.Printf("  users:  OK %d users hold %d points as expected\n", r.users, r.userTotal)
+	default:
+		// ワーカーの失効・デイリーボーナスなど、負荷試験の外の処理でも残高は変わる
+		fmt.Printf("  users:  MISMATCH %d users hold %d points, expected %d (difference %d)\n",
+			r.users, r.userTotal, r.expectedTotal, r.userTotal-r.expectedTotal)
+	}
+
+	if r.serverErr != nil {
+		fmt.Printf("  server: ERROR %v\n", r.serverErr)
+		return
+	}
+	accounts := make([]string, len(r.accounts))
+	for i, a := range r.accounts {
+		accounts[i] = fmt.Sprintf("%s=%d", a.Account, a.Balance)
+	}
+	status := "OK"
+	if !r.conservation.Conserved {
+		status = "VIOLATED"
+	}
+	fmt.Printf("  server: %s users=%d system=%d difference=%d (%s)\n", status,
+		r.conservation.UserBalanceTotal, r.conservation.SystemBalanceTotal, r.conservation.Difference, strings.Join(accounts, " "))
+}
+
+// printStats は操作ごとの件数・応答時間のパーセンタイルと、断られた・失敗した理由を出す
+func printStats(stats []loadgen.OpStats, elapsed time.Duration) {
+	total := 0
+	for _, st := range stats {
+		total += st.Count
+	}
+	fmt.Printf("\n%d calls in %s (%.1f calls/s)\n\n", total, elapsed.Round(time.Millisecond), float64(total)/elapsed.Seconds())
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "OPERATION\tCALLS\tOK\tREJECTED\tFAILED\tP50\tP90\tP99\tMAX\t")
+	for _, st := range stats {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%s\t%s\t%s\t%s\t\n", st.Op, st.Count, st.OK, st.Rejected, st.Failed,
+			ms(st.P50), ms(st.P90), ms(st.P99), ms(st.Max))
+	}
+	w.Flush()
+
+	for _, st := range stats {
+		if len(st.Reasons) == 0 {
+			continue
+		}
+		reasons := make([]string, 0, len(st.Reasons))
+		for _, reason := range slices.Sorted(maps.Keys(st.Reasons)) {
+			reasons = append(reasons, fmt.Sprintf("%s=%d", reason, st.Reasons[reason]))
+		}
+		fmt.Printf("  %s: %s\n", st.Op, strings.Join(reasons, " "))
+	}
+}
+
+func ms(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+}
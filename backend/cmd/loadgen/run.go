@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gity/point-system/pkg/client"
+	"github.com/gity/point-system/pkg/loadgen"
+)
+
+// scenario は負荷試験の1回の実行の状態
+type scenario struct {
+	opts     *options
+	mix      *loadgen.Mix
+	clients  *clientFactory
+	runID    string
+	seq      atomic.Int64
+	pop      population
+	ledger   ledger
+	recorder *loadgen.Recorder
+}
+
+func run(ctx context.Context, o *options, mix *loadgen.Mix) error {
+	s := &scenario{
+		opts:     o,
+		mix:      mix,
+		clients:  newClientFactory(o),
+		runID:    newRunID(),
+		recorder: loadgen.NewRecorder(),
+	}
+
+	admin := s.clients.newClient()
+	if _, err := admin.Login(ctx, o.adminUser, o.adminPassword); err != nil {
+		return fmt.Errorf("failed to log in as %s: %w", o.adminUser, err)
+	}
+
+	fmt.Printf("run %s: setting up %d users with %d points each\n", s.runID, o.users, o.initialBalance)
+	if err := s.setup(ctx, admin); err != nil {
+		return err
+	}
+
+	fmt.Printf("running mix %s with %d workers\n", mix, o.concurrency)
+	started := time.Now()
+	s.drive(ctx)
+	elapsed := time.Since(started)
+
+	// 中断されても、ここまでの結果とポイントの保存は確かめる
+	checkCtx := context.WithoutCancel(ctx)
+	report := s.check(checkCtx, admin)
+	printStats(s.recorder.Stats(), elapsed)
+	report.print()
+	if !report.ok() {
+		return errors.New("point conservation check failed")
+	}
+	return nil
+}
+
+// setup は最初のユーザーを登録し、管理者から初期残高を付与する
+func (s *scenario) setup(ctx context.Context, admin *client.Client) error {
+	return parallel(s.opts.concurrency, s.opts.users, func() error {
+		u, err := s.clients.registerUser(ctx, s.runID, s.seq.Add(1), s.opts.password, &s.ledger)
+		if err != nil {
+			return fmt.Errorf("failed to register a user: %w", err)
+		}
+		if s.opts.initialBalance > 0 {
+			resp, err := admin.GrantPoints(ctx, client.AdjustPointsRequest{
+				UserID:      u.user.ID,
+				Amount:      s.opts.initialBalance,
+				Description: "loadgen " + s.runID,
+				ReasonCode:  s.opts.reasonCode,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to grant points to %s: %w", u.user.Username, err)
+			}
+			if resp.RequiresApproval {
+				return fmt.Errorf("granting %d points requires approval; lower --initial-balance below the approval threshold", s.opts.initialBalance)
+			}
+			s.ledger.granted.Add(s.opts.initialBalance)
+		}
+		s.pop.add(u)
+		return nil
+	})
+}
+
+// drive は --duration・--requests に達するか中断されるまで、ワーカーに配分どおりの操作をさせる
+// 実行中の操作は止めずに終わるのを待つ（途中で止めると結果の分からない送金が残るため）
+func (s *scenario) drive(ctx context.Context) {
+	var deadline time.Time
+	if s.opts.duration > 0 {
+		deadline = time.Now().Add(s.opts.duration)
+	}
+	var issued atomic.Int64
+
+	var wg sync.WaitGroup
+	for range s.opts.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+			for ctx.Err() == nil {
+				if !deadline.IsZero() && time.Now().After(deadline) {
+					return
+				}
+				if s.opts.requests > 0 && issued.Add(1) > int64(s.opts.requests) {
+					return
+				}
+				s.perform(context.WithoutCancel(ctx), s.mix.Pick(r), r)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// perform は1回の操作を行う（送金リクエスト・QRコードは2回の呼び出しをそれぞれ記録する）
+func (s *scenario) perform(ctx context.Context, op string, r *rand.Rand) {
+	amount := 1 + r.Int64N(s.opts.maxAmount)
+	switch op {
+	case opRegister:
+		var u *vuser
+		outcome := s.timed(opRegister, func() (err error) {
+			u, err = s.clients.registerUser(ctx, s.runID, s.seq.Add(1), s.opts.password, &s.ledger)
+			return err
+		})
+		if outcome == loadgen.OutcomeOK {
+			s.pop.add(u)
+		}
+
+	case opTransfer:
+		from, to := s.pop.pair(r)
+		outcome := s.timed(opTransfer, func() error {
+			resp, err := from.api.Transfer(ctx, client.TransferRequest{ToUserID: to.user.ID, Amount: amount, Description: "loadgen"})
+			if err == nil {
+				s.ledger.fees.Add(resp.Fee)
+			}
+			return err
+		})
+		if outcome == loadgen.OutcomeFailed {
+			s.ledger.uncertain.Add(1)
+		}
+
+	case opRequest:
+		from, to := s.pop.pair(r)
+		var detail *client.TransferRequestDetail
+		outcome := s.timed("request.create", func() (err error) {
+			detail, err = from.api.CreateTransferRequest(ctx, client.CreateTransferRequestRequest{ToUserID: to.user.ID, Amount: amount, Message: "loadgen"})
+			return err
+		})
+		if outcome == loadgen.OutcomeOK {
+			s.timed("request.approve", func() error {
+				_, err := to.api.ApproveTransferRequest(ctx, detail.TransferRequest.ID)
+				return err
+			})
+		}
+
+	case opQR:
+		from, to := s.pop.pair(r)
+		var qr *client.QRCodeResponse
+		outcome := s.timed("qr.generate", func() (err error) {
+			qr, err = to.api.GenerateReceiveQR(ctx, &amount)
+			return err
+		})
+		if outcome == loadgen.OutcomeOK {
+			s.timed("qr.scan", func() error {
+				_, err := from.api.ScanQR(ctx, client.ScanQRRequest{Code: qr.QRCodeData})
+				return err
+			})
+		}
+	}
+}
+
+// timed は呼び出しの応答時間と結果を記録する
+func (s *scenario) timed(op string, call func() error) loadgen.Outcome {
+	started := time.Now()
+	err := call()
+	outcome, reason := classify(err)
+	s.recorder.Record(op, time.Since(started), outcome, reason)
+	return outcome
+}
+
+// classify はエラーを結果に分ける（4xxは断られた、5xx・接続エラーは処理されたかどうか分からない失敗）
+func classify(err error) (loadgen.Outcome, string) {
+	if err == nil {
+		return loadgen.OutcomeOK, ""
+	}
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return loadgen.OutcomeFailed, "timeout"
+		}
+		return loadgen.OutcomeFailed, "connection_error"
+	}
+	reason := apiErr.Code
+	if reason == "" {
+		reason = http.StatusText(apiErr.Status)
+	}
+	if apiErr.Status >= http.StatusInternalServerError {
+		return loadgen.OutcomeFailed, reason
+	}
+	return loadgen.OutcomeRejected, reason
+}
+
+// parallel はfnをcount回、最大n個ずつ並行して呼ぶ（最初のエラーを返し、以降は呼ばない）
+func parallel(n, count int, fn func() error) error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	jobs := make(chan struct{})
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range jobs {
+				if err := fn(); err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for range count {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+		jobs <- struct{}{}
+	}
+	close(jobs)
+	wg.Wait()
+	return firstErr
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/gity/point-system/config"
+	"github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/migrations"
+)
+
+// migrate は埋め込みSQLマイグレーションを適用するコマンド
+//
+//	go run ./cmd/migrate up      未適用のマイグレーションを適用
+//	go run ./cmd/migrate status  適用状況を表示
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [up|status]\n", os.Args[0])
+	}
+	flag.Parse()
+
+	command := flag.Arg(0)
+	if command == "" {
+		command = "up"
+	}
+
+	cfg := config.LoadConfig()
+	db, err := infrapostgres.NewPostgresDB(&infrapostgres.Config{
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		User:     cfg.Database.User,
+		Password: cfg.Database.Password,
+		DBName:   cfg.Database.DBName,
+		SSLMode:  cfg.Database.SSLMode,
+		Env:      "production", // SQLログを抑制
+	})
+	if err != nil {
+		log.Fatalf("Failed to connect database: %v", err)
+	}
+	defer db.Close()
+
+	migrator, err := infrapostgres.NewMigrator(db, migrations.FS)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			log.Printf("applied %03d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		log.Printf("Schema is up to date (version %d)", migrator.LatestVersion())
+	case "status":
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		pendingSet := make(map[int]bool, len(pending))
+		for _, m := range pending {
+			pendingSet[m.Version] = true
+		}
+		for _, m := range migrator.Migrations() {
+			state := "applied"
+			if pendingSet[m.Version] {
+				state = "pending"
+			}
+			fmt.Printf("%03d_%s\t%s\n", m.Version, m.Name, state)
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
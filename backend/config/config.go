@@ -1,10 +1,15 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"os"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/gity/point-system/entities"
 )
 
 // Config はアプリケーション設定
@@ -13,64 +18,392 @@ type Config struct {
 	Database DatabaseConfig
 	Security SecurityConfig
 	Akerun   AkerunConfig
+
+	Moderation ModerationConfig
+	Push       PushConfig
+	Scheduler  SchedulerConfig
+
+	PointExpiry PointExpiryConfig
+	Retention   RetentionConfig
+	AccessLog   AccessLogConfig
+	Metrics     MetricsConfig
+
+	CircuitBreaker CircuitBreakerConfig
+
+	settings []Setting // 読み込んだ設定項目（Settingsで秘密を伏せて返す）
 }
 
+// 実行環境（ServerConfig.Env）
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+)
+
+// DefaultSessionSecret は開発用のセッション暗号化キー（本番では使えない）
+const DefaultSessionSecret = "change-this-in-production-very-secret-key-32bytes"
+
 // ServerConfig はサーバー設定
 type ServerConfig struct {
 	Port            string
 	Host            string
 	Env             string // development, production
 	MaxUploadSizeMB int    // アップロードファイルの最大サイズ（MB）
+
+	// TenantBaseDomain はテナントをサブドメインで指定する場合のドメイン（例: points.example.com）
+	// 空ならサブドメインでは判定せず、X-Tenant-IDヘッダーがなければ既定のテナントにする
+	TenantBaseDomain string
+
+	// DeprecatedAPIVersions は廃止予定のAPIバージョンと提供終了日（ゼロ値なら未定）
+	DeprecatedAPIVersions map[string]time.Time
+
+	// RequestTimeout はリクエストの処理の期限（0なら期限なし）。超えるとDBへのクエリを中断して504を返す
+	RequestTimeout time.Duration
+	// RequestTimeouts はルートグループ（public, kiosk, authenticated, session, protected, admin）ごとの期限
+	// 指定のないグループはRequestTimeout（adminはprotectedの期限）を使う
+	RequestTimeouts map[string]time.Duration
+
+	// PprofAddr はプロファイル（net/http/pprof）を公開するアドレス（例: 127.0.0.1:6060）。空なら公開しない
+	// 負荷試験で使う。APIとは別のポートで待ち受け、認証がないので外部から届くアドレスにしないこと
+	PprofAddr string
 }
 
+// RequestTimeoutGroups はREQUEST_TIMEOUTSで期限を指定できるルートグループ
+var RequestTimeoutGroups = []string{"public", "kiosk", "authenticated", "session", "protected", "admin"}
+
+// データベースの種類（DatabaseConfig.Driver）
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite" // Dockerなしでデモ・結合テストを動かす場合に使う
+)
+
 // DatabaseConfig はデータベース設定
 type DatabaseConfig struct {
+	Driver string // DriverPostgres または DriverSQLite
+
+	// Path はSQLiteのDBファイルのパス（":memory:"ならメモリ上、Driverがsqliteの場合のみ使う）
+	Path string
+
 	Host     string
 	Port     string
 	User     string
 	Password string
 	DBName   string
 	SSLMode  string
+
+	// ReplicaDSN はリードレプリカの接続文字列（空の場合はプライマリのみ）
+	ReplicaDSN string
+
+	// SlowQueryThreshold はこれより時間がかかったクエリをログに出し、/metrics で数える（0なら記録しない）
+	SlowQueryThreshold time.Duration
+	// SlowQueryExplain は遅いクエリの実行計画（EXPLAIN）もログに出すか（本番環境では出さない）
+	SlowQueryExplain bool
+
+	// コネクションプール（PostgreSQLのみ。SQLiteは接続1本で使う）
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration // 0なら接続を使い続ける
+
+	// StatementTimeout・LockTimeout はPostgreSQLのstatement_timeout・lock_timeout（0なら制限しない。マイグレーションには適用しない）
+	StatementTimeout time.Duration
+	LockTimeout      time.Duration
 }
 
 // SecurityConfig はセキュリティ設定
 type SecurityConfig struct {
 	AllowedOrigins []string // CORS許可オリジン
 	SessionSecret  string   // セッション暗号化キー
+	// QRSigningSecret はQRコードの署名キー（空ならSessionSecretから導出する）
+	// 個人QRコードは印刷して使うため、SessionSecretを入れ替えても読めるよう本番では別に設定する
+	QRSigningSecret string
+	// ReceiptSigningSecret は取引の控えの検証ハッシュのキー（空ならSessionSecretから導出する。入れ替えると発行済みの控えを確かめられなくなる）
+	ReceiptSigningSecret string
+
+	Password       PasswordHashConfig
+	SessionBinding SessionBindingConfig
+	SessionCookie  SessionCookieConfig
+	CSRF           CSRFConfig
+}
+
+// セッションCookieのSameSite属性（SessionCookieConfig.SameSite）
+const (
+	SameSiteLax    = "lax"
+	SameSiteStrict = "strict"
+	SameSiteNone   = "none" // 別サイトのSPAから使う場合（Secureが必要）
+)
+
+// SessionCookieConfig はセッショントークンのCookieの属性
+type SessionCookieConfig struct {
+	SameSite string // SameSiteLax, SameSiteStrict, SameSiteNone
+	Secure   bool   // HTTPSのときだけ送る（本番ではtrueにする）
+	Domain   string // 空ならリクエストしたホストだけに送る（サブドメインと共有する場合に指定）
+}
+
+// CSRFConfig はCSRFトークンの入れ替えの設定
+type CSRFConfig struct {
+	Rotation string        // off, per_request, periodic（entities.CSRFRotationMode）
+	TTL      time.Duration // periodicのときのトークンの有効期間
+	Grace    time.Duration // 入れ替えた後も前のトークンを受け付ける期間
+}
+
+// SessionBindingConfig はセッションを端末・接続元（User-Agentとサブネット）に固定する設定
+type SessionBindingConfig struct {
+	Mode          string // off, monitor, lenient, strict（entities.SessionBindingMode）
+	IPv4PrefixLen int    // 同じ接続元とみなすIPv4のサブネットの長さ
+	IPv6PrefixLen int    // 同じ接続元とみなすIPv6のサブネットの長さ
+}
+
+// パスワードハッシュのアルゴリズム（PasswordHashConfig.Algorithm）
+const (
+	PasswordAlgorithmBcrypt   = "bcrypt"
+	PasswordAlgorithmArgon2id = "argon2id"
+)
+
+// PasswordHashConfig はパスワードハッシュの設定
+// 新しく保存するハッシュに使い、ログイン時に設定と異なるアルゴリズム・パラメータのハッシュを作り直す
+type PasswordHashConfig struct {
+	Algorithm  string // PasswordAlgorithmBcrypt または PasswordAlgorithmArgon2id
+	BcryptCost int
+
+	Argon2MemoryKiB   int
+	Argon2Iterations  int
+	Argon2Parallelism int
 }
 
 // AkerunConfig はAkerun API設定
 type AkerunConfig struct {
 	AccessToken    string
 	OrganizationID string
+
+	// OrganizationsFile は複数組織・ドアごとのボーナス規則を書いたJSONファイル
+	// AccessToken・OrganizationIDの組織（全ドアが対象）に追加してポーリングする
+	OrganizationsFile string
+
+	// MaxPages は1回の取得でたどるページ数の上限（超えた分は取得せず警告を出す）
+	MaxPages int
+}
+
+// ModerationConfig はコンテンツモデレーション設定
+type ModerationConfig struct {
+	WordlistFile string // 既定の禁止語に追加する語のファイル（1行1語、空なら既定のみ）
+	APIURL       string // 外部モデレーションAPI（空なら禁止語リストのみで判定）
+	APIKey       string
+	APITimeout   time.Duration
+}
+
+// PushConfig はプッシュ通知の設定（未設定のプラットフォームはログ出力のみ）
+type PushConfig struct {
+	FCMCredentialsFile string // Firebaseのサービスアカウント鍵（JSON）
+
+	APNsKeyFile    string // APNsの認証鍵（.p8）
+	APNsKeyID      string
+	APNsTeamID     string
+	APNsTopic      string // アプリのバンドルID
+	APNsProduction bool   // falseならサンドボックスへ送る
+
+	Timeout time.Duration
+}
+
+// SchedulerConfig は定期実行ジョブの設定
+type SchedulerConfig struct {
+	// Schedules はジョブ名ごとのcron式（JST、offで停止）。system_settingsの値があればそちらを優先する
+	Schedules map[string]string
+}
+
+// PointExpiryConfig はポイント有効期限ワーカーの処理量の設定
+// 失効の滞留が大きい場合に、長いトランザクションやDB負荷の集中を避ける
+type PointExpiryConfig struct {
+	BatchSize  int           // 1ページで取得する期限切れバッチ数
+	BatchPause time.Duration // ページ間の待ち時間
+	MaxRuntime time.Duration // 1回の実行時間の上限（残りは次の実行に回す）
+}
+
+// RetentionConfig は取引・送金の添付画像の保持期間の設定
+// 保持期間を過ぎた取引は定期実行ジョブ（transaction_archive）で保管用のテーブルへ移し、
+// 添付画像は定期実行ジョブ（transfer_attachment_cleanup）で削除する
+type RetentionConfig struct {
+	TransactionDays int // 0なら移さない
+	BatchSize       int // 1トランザクションで移す件数
+	AttachmentDays  int // 0なら添付画像を削除しない
+}
+
+// AccessLogConfig はHTTPアクセスログ（JSON）の設定
+// ルートはバージョンなしのパス（例: /api/points/transfer）で指定する
+type AccessLogConfig struct {
+	Enabled      bool
+	BodyRoutes   []string           // リクエスト・レスポンスのボディも記録するルート（秘密の値は伏せる）
+	MaxBodyBytes int                // 記録するボディの最大バイト数
+	SampleRates  map[string]float64 // ルートごとの記録する割合（0〜1）。頻繁に呼ばれるルートのログを間引く
 }
 
-// LoadConfig は設定をロード
+// MetricsConfig は /metrics（OpenMetrics）で公開する業務の指標の設定
+type MetricsConfig struct {
+	RefreshInterval time.Duration // 指標をバックグラウンドで集計し直す間隔
+	Token           string        // 空でなければ Authorization: Bearer <token> を求める
+}
+
+// CircuitBreakerConfig は外部サービス（Akerun API・メール送信）のサーキットブレーカーの設定
+type CircuitBreakerConfig struct {
+	MaxFailures      int           // 連続してこの回数失敗したら呼び出しを止める
+	OpenTimeout      time.Duration // 止めてから回復を試すまでの時間
+	HalfOpenRequests int           // 回復を試すときに通す呼び出しの数
+}
+
+// LoadConfig は設定をロード（不正な設定があれば内容を表示して終了する）
 func LoadConfig() *Config {
-	return &Config{
+	cfg, err := Load()
+	if err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+	return cfg
+}
+
+// Load は設定ファイル（CONFIG_FILE）・環境変数・シークレットファイルから設定を読み込んで検証する
+// 起動を止めるほどではない問題（Akerunのトークン未設定など）は警告としてログに出す
+func Load() (*Config, error) {
+	l, err := newLoader()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
 		Server: ServerConfig{
-			Port:            getEnv("SERVER_PORT", "8080"),
-			Host:            getEnv("SERVER_HOST", "0.0.0.0"),
-			Env:             getEnv("ENV", "development"),
-			MaxUploadSizeMB: getEnvInt("MAX_UPLOAD_SIZE_MB", 10),
+			Port:            l.str("SERVER_PORT", "server.port", "8080"),
+			Host:            l.str("SERVER_HOST", "server.host", "0.0.0.0"),
+			Env:             l.oneOf("ENV", "server.env", EnvDevelopment, EnvDevelopment, EnvProduction),
+			MaxUploadSizeMB: l.int("MAX_UPLOAD_SIZE_MB", "server.max_upload_size_mb", 10),
+
+			TenantBaseDomain: l.str("TENANT_BASE_DOMAIN", "server.tenant_base_domain", ""),
+
+			DeprecatedAPIVersions: loadDeprecatedAPIVersions(l),
+
+			RequestTimeout:  l.duration("REQUEST_TIMEOUT_MS", "server.request_timeout_ms", 15000, time.Millisecond),
+			RequestTimeouts: loadRequestTimeouts(l),
+
+			PprofAddr: l.str("PPROF_ADDR", "server.pprof_addr", ""),
 		},
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5432"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "postgres"),
-			DBName:   getEnv("DB_NAME", "point_system"),
-			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
+			Driver: l.oneOf("DB_DRIVER", "database.driver", DriverPostgres, DriverPostgres, DriverSQLite),
+			Path:   l.str("DB_PATH", "database.path", "point_system.db"),
+
+			Host:     l.str("DB_HOST", "database.host", "localhost"),
+			Port:     l.str("DB_PORT", "database.port", "5432"),
+			User:     l.str("DB_USER", "database.user", "postgres"),
+			Password: l.secret("DB_PASSWORD", "database.password", "postgres"),
+			DBName:   l.str("DB_NAME", "database.name", "point_system"),
+			SSLMode:  l.str("DB_SSL_MODE", "database.ssl_mode", "disable"),
+
+			ReplicaDSN: l.secret("DB_REPLICA_DSN", "database.replica_dsn", ""),
+
+			SlowQueryThreshold: l.duration("DB_SLOW_QUERY_THRESHOLD_MS", "database.slow_query_threshold_ms", 200, time.Millisecond),
+			SlowQueryExplain:   l.oneOf("DB_SLOW_QUERY_EXPLAIN", "database.slow_query_explain", "false", "true", "false") == "true",
+
+			MaxOpenConns:    l.int("DB_MAX_OPEN_CONNS", "database.max_open_conns", 100),
+			MaxIdleConns:    l.int("DB_MAX_IDLE_CONNS", "database.max_idle_conns", 10),
+			ConnMaxLifetime: l.duration("DB_CONN_MAX_LIFETIME_SEC", "database.conn_max_lifetime_sec", 3600, time.Second),
+
+			StatementTimeout: l.duration("DB_STATEMENT_TIMEOUT_MS", "database.statement_timeout_ms", 0, time.Millisecond),
+			LockTimeout:      l.duration("DB_LOCK_TIMEOUT_MS", "database.lock_timeout_ms", 0, time.Millisecond),
 		},
 		Security: SecurityConfig{
-			AllowedOrigins: getAllowedOrigins(),
-			SessionSecret:  getEnv("SESSION_SECRET", "change-this-in-production-very-secret-key-32bytes"),
+			AllowedOrigins: l.list("ALLOWED_ORIGINS", "security.allowed_origins", "http://localhost:3000,http://localhost:5173"),
+			SessionSecret:  l.secret("SESSION_SECRET", "security.session_secret", DefaultSessionSecret),
+
+			QRSigningSecret:      l.secret("QR_SIGNING_SECRET", "security.qr_signing_secret", ""),
+			ReceiptSigningSecret: l.secret("RECEIPT_SIGNING_SECRET", "security.receipt_signing_secret", ""),
+
+			Password: PasswordHashConfig{
+				Algorithm:  l.oneOf("PASSWORD_HASH_ALGORITHM", "security.password_hash.algorithm", PasswordAlgorithmBcrypt, PasswordAlgorithmBcrypt, PasswordAlgorithmArgon2id),
+				BcryptCost: l.int("BCRYPT_COST", "security.password_hash.bcrypt_cost", 10),
+
+				Argon2MemoryKiB:   l.int("ARGON2_MEMORY_KIB", "security.password_hash.argon2_memory_kib", 65536),
+				Argon2Iterations:  l.int("ARGON2_ITERATIONS", "security.password_hash.argon2_iterations", 3),
+				Argon2Parallelism: l.int("ARGON2_PARALLELISM", "security.password_hash.argon2_parallelism", 2),
+			},
+			SessionBinding: SessionBindingConfig{
+				Mode:          loadSessionBindingMode(l),
+				IPv4PrefixLen: l.int("SESSION_BINDING_IPV4_PREFIX", "security.session_binding.ipv4_prefix", 24),
+				IPv6PrefixLen: l.int("SESSION_BINDING_IPV6_PREFIX", "security.session_binding.ipv6_prefix", 64),
+			},
+			SessionCookie: SessionCookieConfig{
+				SameSite: l.oneOf("SESSION_COOKIE_SAMESITE", "security.session_cookie.same_site", SameSiteLax, SameSiteLax, SameSiteStrict, SameSiteNone),
+				Secure:   l.oneOf("SESSION_COOKIE_SECURE", "security.session_cookie.secure", "false", "true", "false") == "true",
+				Domain:   l.str("SESSION_COOKIE_DOMAIN", "security.session_cookie.domain", ""),
+			},
+			CSRF: CSRFConfig{
+				Rotation: loadCSRFRotation(l),
+				TTL:      l.duration("CSRF_TOKEN_TTL_SEC", "security.csrf.token_ttl_sec", 3600, time.Second),
+				Grace:    l.duration("CSRF_GRACE_SEC", "security.csrf.grace_sec", 60, time.Second),
+			},
 		},
 		Akerun: AkerunConfig{
-			AccessToken:    getEnv("AKERUN_ACCESS_TOKEN", ""),
-			OrganizationID: getEnv("AKERUN_ORGANIZATION_ID", ""),
+			AccessToken:    l.secret("AKERUN_ACCESS_TOKEN", "akerun.access_token", ""),
+			OrganizationID: l.str("AKERUN_ORGANIZATION_ID", "akerun.organization_id", ""),
+
+			OrganizationsFile: l.str("AKERUN_ORGANIZATIONS_FILE", "akerun.organizations_file", ""),
+			MaxPages:          l.int("AKERUN_MAX_PAGES", "akerun.max_pages", 10),
+		},
+		Moderation: ModerationConfig{
+			WordlistFile: l.str("MODERATION_WORDLIST_FILE", "moderation.wordlist_file", ""),
+			APIURL:       l.str("MODERATION_API_URL", "moderation.api_url", ""),
+			APIKey:       l.secret("MODERATION_API_KEY", "moderation.api_key", ""),
+			APITimeout:   l.duration("MODERATION_API_TIMEOUT_MS", "moderation.api_timeout_ms", 2000, time.Millisecond),
 		},
+		Push: PushConfig{
+			FCMCredentialsFile: l.str("FCM_CREDENTIALS_FILE", "push.fcm_credentials_file", ""),
+			APNsKeyFile:        l.str("APNS_KEY_FILE", "push.apns_key_file", ""),
+			APNsKeyID:          l.str("APNS_KEY_ID", "push.apns_key_id", ""),
+			APNsTeamID:         l.str("APNS_TEAM_ID", "push.apns_team_id", ""),
+			APNsTopic:          l.str("APNS_TOPIC", "push.apns_topic", ""),
+			APNsProduction:     l.oneOf("APNS_ENVIRONMENT", "push.apns_environment", "sandbox", "sandbox", "production") == "production",
+			Timeout:            l.duration("PUSH_TIMEOUT_MS", "push.timeout_ms", 3000, time.Millisecond),
+		},
+		Scheduler: SchedulerConfig{
+			Schedules: l.pairs("JOB_SCHEDULES", "scheduler.schedules", ";", false),
+		},
+		PointExpiry: PointExpiryConfig{
+			BatchSize:  l.int("POINT_EXPIRY_BATCH_SIZE", "point_expiry.batch_size", 100),
+			BatchPause: l.duration("POINT_EXPIRY_BATCH_PAUSE_MS", "point_expiry.batch_pause_ms", 200, time.Millisecond),
+			MaxRuntime: l.duration("POINT_EXPIRY_MAX_RUNTIME_SEC", "point_expiry.max_runtime_sec", 600, time.Second),
+		},
+		Retention: RetentionConfig{
+			TransactionDays: l.int("TRANSACTION_RETENTION_DAYS", "retention.transaction_days", 0),
+			BatchSize:       l.int("TRANSACTION_ARCHIVE_BATCH_SIZE", "retention.batch_size", 500),
+			AttachmentDays:  l.int("TRANSFER_ATTACHMENT_RETENTION_DAYS", "retention.attachment_days", entities.DefaultTransferAttachmentRetentionDays),
+		},
+		AccessLog: AccessLogConfig{
+			Enabled:      l.oneOf("ACCESS_LOG_ENABLED", "access_log.enabled", "true", "true", "false") == "true",
+			BodyRoutes:   l.list("ACCESS_LOG_BODY_ROUTES", "access_log.body_routes", ""),
+			MaxBodyBytes: l.int("ACCESS_LOG_MAX_BODY_BYTES", "access_log.max_body_bytes", 4096),
+			SampleRates:  loadAccessLogSampleRates(l),
+		},
+		Metrics: MetricsConfig{
+			RefreshInterval: l.duration("METRICS_REFRESH_INTERVAL_SEC", "metrics.refresh_interval_sec", 60, time.Second),
+			Token:           l.secret("METRICS_TOKEN", "metrics.token", ""),
+		},
+		CircuitBreaker: CircuitBreakerConfig{
+			MaxFailures:      l.int("CIRCUIT_BREAKER_MAX_FAILURES", "circuit_breaker.max_failures", 5),
+			OpenTimeout:      l.duration("CIRCUIT_BREAKER_OPEN_TIMEOUT_SEC", "circuit_breaker.open_timeout_sec", 60, time.Second),
+			HalfOpenRequests: l.int("CIRCUIT_BREAKER_HALF_OPEN_REQUESTS", "circuit_breaker.half_open_requests", 1),
+		},
+		settings: l.settings,
 	}
+
+	problems := l.errs
+	for _, key := range l.unknownKeys() {
+		problems = append(problems, fmt.Sprintf("config file: unknown key %q", key))
+	}
+	warnings, err := cfg.Validate()
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	if len(problems) > 0 {
+		return nil, errors.New(strings.Join(problems, "\n"))
+	}
+
+	for _, w := range warnings {
+		log.Printf("Warning: %s", w)
+	}
+	return cfg, nil
 }
 
 // GetDSN はPostgreSQL接続文字列を返す
@@ -90,28 +423,69 @@ func getEnv(key, defaultValue string) string {
 	return value
 }
 
-// getEnvInt は環境変数を整数として取得（デフォルト値付き）
-func getEnvInt(key string, defaultValue int) int {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
+// loadDeprecatedAPIVersions は廃止予定のAPIバージョンを取得
+// 形式: "v1=2027-03-31,v0"（日付は提供終了日、省略時は未定）
+func loadDeprecatedAPIVersions(l *loader) map[string]time.Time {
+	versions := map[string]time.Time{}
+	for name, date := range l.pairs("API_DEPRECATED_VERSIONS", "server.deprecated_api_versions", ",", true) {
+		var sunset time.Time
+		if date != "" {
+			parsed, err := time.Parse("2006-01-02", date)
+			if err != nil {
+				l.errorf("API_DEPRECATED_VERSIONS: invalid sunset date for %s: %q", name, date)
+				continue
+			}
+			sunset = parsed
+		}
+		versions[name] = sunset
 	}
-	intValue, err := strconv.Atoi(value)
-	if err != nil {
-		return defaultValue
+	return versions
+}
+
+// loadSessionBindingMode はセッションを端末・接続元に固定する強さを取得
+func loadSessionBindingMode(l *loader) string {
+	modes := make([]string, len(entities.SessionBindingModes))
+	for i, m := range entities.SessionBindingModes {
+		modes[i] = string(m)
 	}
-	return intValue
+	return l.oneOf("SESSION_BINDING", "security.session_binding.mode", string(entities.SessionBindingOff), modes...)
 }
 
-// getAllowedOrigins はALLOWED_ORIGINS環境変数からオリジンリストを取得
-func getAllowedOrigins() []string {
-	originsStr := getEnv("ALLOWED_ORIGINS", "http://localhost:3000,http://localhost:5173")
-	origins := strings.Split(originsStr, ",")
+// loadCSRFRotation はCSRFトークンを入れ替える方法を取得
+func loadCSRFRotation(l *loader) string {
+	modes := make([]string, len(entities.CSRFRotationModes))
+	for i, m := range entities.CSRFRotationModes {
+		modes[i] = string(m)
+	}
+	return l.oneOf("CSRF_ROTATION", "security.csrf.rotation", string(entities.CSRFRotationOff), modes...)
+}
 
-	// 空白を削除
-	for i, origin := range origins {
-		origins[i] = strings.TrimSpace(origin)
+// loadRequestTimeouts はルートグループごとのリクエストの期限を取得
+// 形式: "admin=60000,kiosk=5000"（ミリ秒）
+func loadRequestTimeouts(l *loader) map[string]time.Duration {
+	timeouts := map[string]time.Duration{}
+	for group, raw := range l.pairs("REQUEST_TIMEOUTS", "server.request_timeouts", ",", false) {
+		ms, err := strconv.Atoi(raw)
+		if err != nil {
+			l.errorf("REQUEST_TIMEOUTS: timeout for %s must be an integer in milliseconds (got %q)", group, raw)
+			continue
+		}
+		timeouts[group] = time.Duration(ms) * time.Millisecond
 	}
+	return timeouts
+}
 
-	return origins
+// loadAccessLogSampleRates はルートごとのアクセスログの記録割合を取得
+// 形式: "/api/points/balance=0.1,/api/notifications=0.05"
+func loadAccessLogSampleRates(l *loader) map[string]float64 {
+	rates := map[string]float64{}
+	for route, raw := range l.pairs("ACCESS_LOG_SAMPLE_RATES", "access_log.sample_rates", ",", false) {
+		rate, err := strconv.ParseFloat(raw, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			l.errorf("ACCESS_LOG_SAMPLE_RATES: rate for %s must be between 0 and 1 (got %q)", route, raw)
+			continue
+		}
+		rates[route] = rate
+	}
+	return rates
 }
@@ -0,0 +1,276 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 設定値の取得元（Setting.Source）
+const (
+	SourceDefault = "default" // 既定値
+	SourceFile    = "file"    // 設定ファイル（CONFIG_FILE）
+	SourceEnv     = "env"     // 環境変数
+	SourceSecret  = "secret"  // シークレットファイル（<KEY>_FILE またはDockerシークレット）
+)
+
+// DefaultSecretsDir はDockerシークレットがマウントされるディレクトリ
+const DefaultSecretsDir = "/run/secrets"
+
+// redactedValue はダンプ時に秘密の値の代わりに表示する文字列
+const redactedValue = "********"
+
+// Setting は読み込んだ設定項目1つ（管理者向けの設定ダンプに使う）
+type Setting struct {
+	Key    string // 環境変数名
+	Path   string // 設定ファイルでのキー（例: database.password）
+	Value  string
+	Source string
+	Secret bool // trueならダンプ時に値を伏せる
+}
+
+// loader は設定ファイル・環境変数・シークレットファイルから値を取り出す
+// 優先順位は 環境変数 > シークレットファイル > 設定ファイル > 既定値
+type loader struct {
+	file       map[string]string // 設定ファイルの値（ネストしたキーを.でつないだもの）
+	used       map[string]bool   // 参照された設定ファイルのキー（未知のキーの検出に使う）
+	secretsDir string
+	settings   []Setting
+	errs       []string
+}
+
+// newLoader はCONFIG_FILE（あれば）を読み込んだloaderを作成
+func newLoader() (*loader, error) {
+	l := &loader{
+		file:       map[string]string{},
+		used:       map[string]bool{},
+		secretsDir: getEnv("SECRETS_DIR", DefaultSecretsDir),
+	}
+
+	path := os.Getenv("CONFIG_FILE")
+	if path == "" {
+		return l, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var tree map[string]interface{}
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	flatten("", tree, l.file)
+	return l, nil
+}
+
+// flatten はYAMLのネストしたキーを.でつなぐ（リストはカンマ区切りの文字列にする）
+func flatten(prefix string, node interface{}, out map[string]string) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			flatten(key, child, out)
+		}
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		out[prefix] = strings.Join(items, ",")
+	case nil:
+		out[prefix] = ""
+	case time.Time:
+		// 引用符なしの日付はYAMLでは日時になるため、書かれた形に戻す
+		if v.Equal(v.Truncate(24 * time.Hour)) {
+			out[prefix] = v.Format("2006-01-02")
+		} else {
+			out[prefix] = v.Format(time.RFC3339)
+		}
+	default:
+		out[prefix] = fmt.Sprint(v)
+	}
+}
+
+// lookup は値と取得元を返す（secretなら <KEY>_FILE とDockerシークレットも見る）
+func (l *loader) lookup(key, path string, secret bool) (string, string, bool) {
+	if _, ok := l.file[path]; ok {
+		l.used[path] = true
+	}
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value, SourceEnv, true
+	}
+	if secret {
+		if value, ok := l.readSecret(key); ok {
+			return value, SourceSecret, true
+		}
+	}
+	if value, ok := l.file[path]; ok {
+		return value, SourceFile, true
+	}
+	return "", SourceDefault, false
+}
+
+// readSecret は <KEY>_FILE で指定したファイル、またはDockerシークレット（/run/secrets/<key>）から値を読む
+func (l *loader) readSecret(key string) (string, bool) {
+	if path := os.Getenv(key + "_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			l.errorf("%s_FILE: %v", key, err)
+			return "", false
+		}
+		return strings.TrimSpace(string(data)), true
+	}
+	data, err := os.ReadFile(filepath.Join(l.secretsDir, strings.ToLower(key)))
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(string(data)), true
+}
+
+func (l *loader) errorf(format string, args ...interface{}) {
+	l.errs = append(l.errs, fmt.Sprintf(format, args...))
+}
+
+func (l *loader) record(key, path, value, source string, secret bool) {
+	l.settings = append(l.settings, Setting{Key: key, Path: path, Value: value, Source: source, Secret: secret})
+}
+
+// str は文字列の設定を取得
+func (l *loader) str(key, path, defaultValue string) string {
+	value, source, ok := l.lookup(key, path, false)
+	if !ok {
+		value = defaultValue
+	}
+	l.record(key, path, value, source, false)
+	return value
+}
+
+// secret は秘密の設定を取得（ダンプでは伏せる）
+func (l *loader) secret(key, path, defaultValue string) string {
+	value, source, ok := l.lookup(key, path, true)
+	if !ok {
+		value = defaultValue
+	}
+	l.record(key, path, value, source, true)
+	return value
+}
+
+// int は整数の設定を取得（整数でなければエラーにして既定値を使う）
+func (l *loader) int(key, path string, defaultValue int) int {
+	raw := l.str(key, path, strconv.Itoa(defaultValue))
+	value, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil {
+		l.errorf("%s: %q is not an integer", key, raw)
+		return defaultValue
+	}
+	return value
+}
+
+// duration は整数の設定を単位付きの時間として取得
+func (l *loader) duration(key, path string, defaultValue int, unit time.Duration) time.Duration {
+	return time.Duration(l.int(key, path, defaultValue)) * unit
+}
+
+// oneOf は決まった値のどれかを取る設定を取得
+func (l *loader) oneOf(key, path, defaultValue string, allowed ...string) string {
+	value := l.str(key, path, defaultValue)
+	for _, a := range allowed {
+		if value == a {
+			return value
+		}
+	}
+	l.errorf("%s: %q must be one of %s", key, value, strings.Join(allowed, ", "))
+	return defaultValue
+}
+
+// list はカンマ区切りの設定を取得（設定ファイルではリストでも書ける）
+func (l *loader) list(key, path, defaultValue string) []string {
+	raw := l.str(key, path, defaultValue)
+	items := []string{}
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// pairs は名前=値の組の設定を取得
+// 環境変数では sep 区切りの "name=value"、設定ファイルでは path 配下のマップで書く
+// 値のない名前（"=" を含まない要素）は値を空にする（allowBare が false ならエラー）
+func (l *loader) pairs(key, path, sep string, allowBare bool) map[string]string {
+	prefix := path + "."
+	fromFile := map[string]string{}
+	for p, value := range l.file {
+		if strings.HasPrefix(p, prefix) {
+			l.used[p] = true
+			fromFile[strings.TrimPrefix(p, prefix)] = value
+		}
+	}
+
+	result := map[string]string{}
+	if raw, ok := os.LookupEnv(key); ok && raw != "" {
+		for _, entry := range strings.Split(raw, sep) {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			name, value, hasValue := strings.Cut(entry, "=")
+			if !hasValue && !allowBare {
+				l.errorf("%s: invalid entry %q (expected name=value)", key, entry)
+				continue
+			}
+			result[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+		l.record(key, path, raw, SourceEnv, false)
+		return result
+	}
+
+	source := SourceDefault
+	if len(fromFile) > 0 {
+		source = SourceFile
+	}
+	l.record(key, path, joinPairs(fromFile, sep), source, false)
+	return fromFile
+}
+
+func joinPairs(pairs map[string]string, sep string) string {
+	entries := make([]string, 0, len(pairs))
+	for name, value := range pairs {
+		entries = append(entries, name+"="+value)
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, sep)
+}
+
+// unknownKeys は設定ファイルにあって参照されなかったキー（書き間違いの検出に使う）
+func (l *loader) unknownKeys() []string {
+	keys := []string{}
+	for p := range l.file {
+		if !l.used[p] {
+			keys = append(keys, p)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Settings は読み込んだ設定項目を返す（秘密の値は伏せる）
+func (c *Config) Settings() []Setting {
+	settings := make([]Setting, len(c.settings))
+	for i, s := range c.settings {
+		if s.Secret && s.Value != "" {
+			s.Value = redactedValue
+		}
+		settings[i] = s
+	}
+	return settings
+}
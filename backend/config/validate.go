@@ -0,0 +1,281 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gity/point-system/entities"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// minSessionSecretLength はセッション暗号化キーの最小の長さ
+const minSessionSecretLength = 32
+
+// Validate は設定を検証する
+// 起動できない設定はエラー（すべての問題を改行区切りでまとめる）、機能が無効になるだけの設定は警告として返す
+func (c *Config) Validate() (warnings []string, err error) {
+	var problems []string
+	fail := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+	warn := func(format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	}
+	production := c.Server.Env == EnvProduction
+
+	// サーバー
+	if !validPort(c.Server.Port) {
+		fail("SERVER_PORT: %q is not a valid port", c.Server.Port)
+	}
+	if c.Server.MaxUploadSizeMB <= 0 {
+		fail("MAX_UPLOAD_SIZE_MB: must be positive (got %d)", c.Server.MaxUploadSizeMB)
+	}
+	if c.Server.RequestTimeout < 0 {
+		fail("REQUEST_TIMEOUT_MS: must not be negative (0 disables the timeout)")
+	}
+	for _, group := range slices.Sorted(maps.Keys(c.Server.RequestTimeouts)) {
+		if !slices.Contains(RequestTimeoutGroups, group) {
+			fail("REQUEST_TIMEOUTS: unknown route group %q (expected one of %s)", group, strings.Join(RequestTimeoutGroups, ", "))
+		} else if c.Server.RequestTimeouts[group] < 0 {
+			fail("REQUEST_TIMEOUTS: timeout for %s must not be negative", group)
+		}
+	}
+	if c.Server.PprofAddr != "" {
+		if host, port, err := net.SplitHostPort(c.Server.PprofAddr); err != nil || !validPort(port) {
+			fail("PPROF_ADDR: %q is not a valid host:port", c.Server.PprofAddr)
+		} else if production && !isLoopbackHost(host) {
+			warn("PPROF_ADDR listens on %s in production; profiles are served without authentication", c.Server.PprofAddr)
+		}
+	}
+
+	// データベース
+	switch c.Database.Driver {
+	case DriverSQLite:
+		if c.Database.Path == "" {
+			fail("DB_PATH: required when DB_DRIVER is %s", DriverSQLite)
+		}
+	default:
+		if c.Database.Host == "" {
+			fail("DB_HOST: required")
+		}
+		if !validPort(c.Database.Port) {
+			fail("DB_PORT: %q is not a valid port", c.Database.Port)
+		}
+		if c.Database.User == "" {
+			fail("DB_USER: required")
+		}
+		if c.Database.DBName == "" {
+			fail("DB_NAME: required")
+		}
+		switch c.Database.SSLMode {
+		case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+		default:
+			fail("DB_SSL_MODE: %q is not a valid sslmode", c.Database.SSLMode)
+		}
+	}
+	if c.Database.SlowQueryThreshold < 0 {
+		fail("DB_SLOW_QUERY_THRESHOLD_MS: must not be negative")
+	}
+	if c.Database.MaxOpenConns < 1 {
+		fail("DB_MAX_OPEN_CONNS: must be at least 1")
+	}
+	if c.Database.MaxIdleConns < 0 || c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		fail("DB_MAX_IDLE_CONNS: must be between 0 and DB_MAX_OPEN_CONNS (%d)", c.Database.MaxOpenConns)
+	}
+	if c.Database.ConnMaxLifetime < 0 {
+		fail("DB_CONN_MAX_LIFETIME_SEC: must not be negative")
+	}
+	if c.Database.StatementTimeout < 0 {
+		fail("DB_STATEMENT_TIMEOUT_MS: must not be negative")
+	}
+	if c.Database.LockTimeout < 0 {
+		fail("DB_LOCK_TIMEOUT_MS: must not be negative")
+	}
+	if c.Database.StatementTimeout > 0 && c.Database.LockTimeout > c.Database.StatementTimeout {
+		fail("DB_LOCK_TIMEOUT_MS: must not exceed DB_STATEMENT_TIMEOUT_MS")
+	}
+	if production && c.Database.SlowQueryExplain {
+		warn("DB_SLOW_QUERY_EXPLAIN is ignored in production")
+	}
+
+	// セキュリティ
+	if len(c.Security.AllowedOrigins) == 0 {
+		fail("ALLOWED_ORIGINS: at least one origin is required")
+	}
+	for _, origin := range c.Security.AllowedOrigins {
+		if u, err := url.Parse(origin); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			fail("ALLOWED_ORIGINS: %q is not an http(s) origin", origin)
+		}
+	}
+	switch {
+	case production && c.Security.SessionSecret == DefaultSessionSecret:
+		fail("SESSION_SECRET: the development default must not be used in production")
+	case len(c.Security.SessionSecret) < minSessionSecretLength:
+		fail("SESSION_SECRET: must be at least %d characters", minSessionSecretLength)
+	case c.Security.SessionSecret == DefaultSessionSecret:
+		warn("SESSION_SECRET is the development default; set a random value before deploying")
+	}
+	if c.Security.QRSigningSecret != "" && len(c.Security.QRSigningSecret) < minSessionSecretLength {
+		fail("QR_SIGNING_SECRET: must be at least %d characters", minSessionSecretLength)
+	}
+	if c.Security.ReceiptSigningSecret != "" && len(c.Security.ReceiptSigningSecret) < minSessionSecretLength {
+		fail("RECEIPT_SIGNING_SECRET: must be at least %d characters", minSessionSecretLength)
+	}
+
+	// パスワードハッシュ
+	if c.Security.Password.BcryptCost < bcrypt.MinCost || c.Security.Password.BcryptCost > bcrypt.MaxCost {
+		fail("BCRYPT_COST: must be between %d and %d (got %d)", bcrypt.MinCost, bcrypt.MaxCost, c.Security.Password.BcryptCost)
+	}
+	if c.Security.Password.Argon2Iterations <= 0 {
+		fail("ARGON2_ITERATIONS: must be positive")
+	}
+	if c.Security.Password.Argon2Parallelism <= 0 || c.Security.Password.Argon2Parallelism > 255 {
+		fail("ARGON2_PARALLELISM: must be between 1 and 255")
+	}
+	if c.Security.Password.Argon2MemoryKiB < 8*c.Security.Password.Argon2Parallelism {
+		fail("ARGON2_MEMORY_KIB: must be at least 8 times ARGON2_PARALLELISM")
+	}
+
+	// セッションの固定
+	if n := c.Security.SessionBinding.IPv4PrefixLen; n <= 0 || n > 32 {
+		fail("SESSION_BINDING_IPV4_PREFIX: must be between 1 and 32 (got %d)", n)
+	}
+	if n := c.Security.SessionBinding.IPv6PrefixLen; n <= 0 || n > 128 {
+		fail("SESSION_BINDING_IPV6_PREFIX: must be between 1 and 128 (got %d)", n)
+	}
+
+	// セッションCookie・CSRFトークン
+	if c.Security.SessionCookie.SameSite == SameSiteNone && !c.Security.SessionCookie.Secure {
+		fail("SESSION_COOKIE_SAMESITE: none requires SESSION_COOKIE_SECURE=true (browsers reject the cookie otherwise)")
+	}
+	if production && !c.Security.SessionCookie.Secure {
+		warn("SESSION_COOKIE_SECURE is false in production; the session cookie is also sent over plain HTTP")
+	}
+	if c.Security.CSRF.TTL <= 0 {
+		fail("CSRF_TOKEN_TTL_SEC: must be positive")
+	}
+	if c.Security.CSRF.Grace < 0 {
+		fail("CSRF_GRACE_SEC: must not be negative")
+	} else if c.Security.CSRF.Rotation == string(entities.CSRFRotationPeriodic) && c.Security.CSRF.Grace >= c.Security.CSRF.TTL {
+		fail("CSRF_GRACE_SEC: must be shorter than CSRF_TOKEN_TTL_SEC")
+	}
+
+	// Akerun（トークンがなければワーカーを止めるだけ）
+	if c.Akerun.OrganizationsFile == "" {
+		switch {
+		case c.Akerun.AccessToken == "":
+			warn("AKERUN_ACCESS_TOKEN is not set; the Akerun worker is disabled")
+		case c.Akerun.OrganizationID == "":
+			warn("AKERUN_ORGANIZATION_ID is not set; the Akerun worker is disabled")
+		}
+	}
+	if c.Akerun.MaxPages <= 0 {
+		fail("AKERUN_MAX_PAGES: must be positive")
+	}
+
+	// モデレーション
+	if c.Moderation.APIURL != "" {
+		if u, err := url.Parse(c.Moderation.APIURL); err != nil || u.Scheme == "" || u.Host == "" {
+			fail("MODERATION_API_URL: %q is not a valid URL", c.Moderation.APIURL)
+		}
+		if c.Moderation.APIKey == "" {
+			warn("MODERATION_API_KEY is not set; requests to the moderation API are sent without a key")
+		}
+	}
+	if c.Moderation.APITimeout <= 0 {
+		fail("MODERATION_API_TIMEOUT_MS: must be positive")
+	}
+
+	// プッシュ通知（鍵がそろっていないプラットフォームはログ出力のみ）
+	if c.Push.APNsKeyFile != "" && (c.Push.APNsKeyID == "" || c.Push.APNsTeamID == "" || c.Push.APNsTopic == "") {
+		warn("APNS_KEY_ID, APNS_TEAM_ID and APNS_TOPIC are required with APNS_KEY_FILE; iOS push is disabled")
+	}
+	if c.Push.Timeout <= 0 {
+		fail("PUSH_TIMEOUT_MS: must be positive")
+	}
+
+	// 定期実行ジョブ
+	for name, expr := range c.Scheduler.Schedules {
+		if _, ok := entities.DefaultJobSchedules[entities.ScheduledJobName(name)]; !ok {
+			fail("JOB_SCHEDULES: unknown job %q", name)
+			continue
+		}
+		if expr == entities.ScheduledJobDisabled {
+			continue
+		}
+		if _, err := entities.ParseCronSchedule(expr); err != nil {
+			fail("JOB_SCHEDULES: invalid schedule for %s: %v", name, err)
+		}
+	}
+
+	// ポイント有効期限ワーカー
+	if c.PointExpiry.BatchSize <= 0 {
+		fail("POINT_EXPIRY_BATCH_SIZE: must be positive")
+	}
+	if c.PointExpiry.BatchPause < 0 {
+		fail("POINT_EXPIRY_BATCH_PAUSE_MS: must not be negative")
+	}
+	if c.PointExpiry.MaxRuntime <= 0 {
+		fail("POINT_EXPIRY_MAX_RUNTIME_SEC: must be positive")
+	}
+
+	// 取引の保持期間
+	if c.Retention.TransactionDays != 0 && c.Retention.TransactionDays < entities.TransactionRetentionMinDays {
+		fail("TRANSACTION_RETENTION_DAYS: must be 0 (keep all) or at least %d", entities.TransactionRetentionMinDays)
+	}
+	if c.Retention.BatchSize <= 0 {
+		fail("TRANSACTION_ARCHIVE_BATCH_SIZE: must be positive")
+	}
+	if c.Retention.AttachmentDays < 0 {
+		fail("TRANSFER_ATTACHMENT_RETENTION_DAYS: must be 0 (keep all) or positive")
+	}
+
+	// アクセスログ
+	if c.AccessLog.MaxBodyBytes <= 0 {
+		fail("ACCESS_LOG_MAX_BODY_BYTES: must be positive")
+	}
+
+	// 業務の指標
+	if c.Metrics.RefreshInterval <= 0 {
+		fail("METRICS_REFRESH_INTERVAL_SEC: must be positive")
+	}
+	if production && c.Metrics.Token == "" {
+		warn("METRICS_TOKEN is empty in production; /metrics is served without authentication")
+	}
+
+	// サーキットブレーカー
+	if c.CircuitBreaker.MaxFailures <= 0 {
+		fail("CIRCUIT_BREAKER_MAX_FAILURES: must be positive")
+	}
+	if c.CircuitBreaker.OpenTimeout <= 0 {
+		fail("CIRCUIT_BREAKER_OPEN_TIMEOUT_SEC: must be positive")
+	}
+	if c.CircuitBreaker.HalfOpenRequests <= 0 {
+		fail("CIRCUIT_BREAKER_HALF_OPEN_REQUESTS: must be positive")
+	}
+
+	if len(problems) > 0 {
+		return warnings, errors.New(strings.Join(problems, "\n"))
+	}
+	return warnings, nil
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
+
+// isLoopbackHost はホストが自分自身（localhostかループバックアドレス）か
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
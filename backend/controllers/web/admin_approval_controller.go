@@ -0,0 +1,199 @@
+package web
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// AdminApprovalController は大口の付与・減算の承認のコントローラー
+type AdminApprovalController struct {
+	approvalUC inputport.AdminApprovalInputPort
+	presenter  *presenter.AdminApprovalPresenter
+}
+
+// NewAdminApprovalController は新しいAdminApprovalControllerを作成
+func NewAdminApprovalController(
+	approvalUC inputport.AdminApprovalInputPort,
+	presenter *presenter.AdminApprovalPresenter,
+) *AdminApprovalController {
+	return &AdminApprovalController{
+		approvalUC: approvalUC,
+		presenter:  presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *AdminApprovalController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/approvals", c.ListPendingActions)
+	routes.Admin.GET("/approvals/threshold", c.GetThreshold)
+	routes.Admin.PUT("/approvals/threshold", c.UpdateThreshold)
+	routes.Admin.GET("/approvals/:id", c.GetPendingAction)
+	routes.Admin.POST("/approvals/:id/approve", c.ApproveAction)
+	routes.Admin.POST("/approvals/:id/reject", c.RejectAction)
+}
+
+// GetThreshold は承認が必要になる金額を取得
+// GET /api/admin/approvals/threshold
+func (c *AdminApprovalController) GetThreshold(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	threshold, err := c.approvalUC.GetApprovalThreshold(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentThreshold(threshold))
+}
+
+// UpdateThreshold は承認が必要になる金額を設定（0で無効）
+// PUT /api/admin/approvals/threshold
+func (c *AdminApprovalController) UpdateThreshold(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		Threshold *int64 `json:"threshold" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	threshold, err := c.approvalUC.UpdateApprovalThreshold(ctx, &inputport.UpdateApprovalThresholdRequest{
+		AdminID:   adminID.(uuid.UUID),
+		Threshold: *req.Threshold,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentThreshold(threshold))
+}
+
+// ListPendingActions は承認待ちの操作を新しい順に取得（statusで絞り込み、既定は承認待ちのみ）
+// GET /api/admin/approvals
+func (c *AdminApprovalController) ListPendingActions(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 50)
+	if !ok {
+		return
+	}
+
+	// status=all で全状態
+	status := entities.PendingAdminActionStatus(ctx.DefaultQuery("status", string(entities.PendingAdminActionStatusPending)))
+	if status == "all" {
+		status = ""
+	} else if !status.IsValid() {
+		respondError(ctx, http.StatusBadRequest, invalidParam("status", "must be one of the allowed values"))
+		return
+	}
+
+	resp, err := c.approvalUC.ListPendingActions(ctx, &inputport.ListPendingActionsRequest{
+		AdminID: adminID.(uuid.UUID),
+		Status:  status,
+		Offset:  offset,
+		Limit:   limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentList(resp))
+}
+
+// GetPendingAction は操作と監査記録を取得
+// GET /api/admin/approvals/:id
+func (c *AdminApprovalController) GetPendingAction(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	actionID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	detail, err := c.approvalUC.GetPendingAction(ctx, &inputport.GetPendingActionRequest{
+		AdminID:  adminID.(uuid.UUID),
+		ActionID: actionID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentDetail(detail))
+}
+
+// ApproveAction は操作を承認して実行する
+// POST /api/admin/approvals/:id/approve
+func (c *AdminApprovalController) ApproveAction(ctx *gin.Context) {
+	c.review(ctx, c.approvalUC.ApproveAction)
+}
+
+// RejectAction は操作を却下する
+// POST /api/admin/approvals/:id/reject
+func (c *AdminApprovalController) RejectAction(ctx *gin.Context) {
+	c.review(ctx, c.approvalUC.RejectAction)
+}
+
+func (c *AdminApprovalController) review(ctx *gin.Context, review func(ctx context.Context, req *inputport.ReviewPendingActionRequest) (*inputport.PendingActionDetail, error)) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	actionID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	// コメントは任意
+	var req struct {
+		Comment string `json:"comment"`
+	}
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	detail, err := review(ctx, &inputport.ReviewPendingActionRequest{
+		AdminID:  adminID.(uuid.UUID),
+		ActionID: actionID,
+		Comment:  req.Comment,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentDetail(detail))
+}
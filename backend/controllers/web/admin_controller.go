@@ -3,9 +3,11 @@ package web
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/google/uuid"
 )
@@ -27,32 +29,52 @@ func NewAdminController(
 	}
 }
 
+// RegisterRoutes はルートを登録
+func (c *AdminController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.POST("/points/grant", c.GrantPoints)
+	routes.Admin.POST("/points/deduct", c.DeductPoints)
+
+	routes.Admin.GET("/users", c.ListAllUsers)
+	routes.Admin.PUT("/users/:id/role", c.UpdateUserRole)
+	routes.Admin.POST("/users/:id/deactivate", c.DeactivateUser)
+
+	routes.Admin.GET("/transactions", c.ListAllTransactions)
+
+	routes.Admin.GET("/analytics", c.GetAnalytics)
+	routes.Admin.GET("/analytics/cohorts", c.GetCohortAnalytics)
+	routes.Admin.GET("/analytics/forecast", c.GetForecast)
+}
+
 // GrantPoints はユーザーにポイントを付与
 // POST /api/admin/points/grant
 func (c *AdminController) GrantPoints(ctx *gin.Context) {
 	// ログインユーザー（管理者）取得
 	adminID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// リクエストボディ解析
 	var req struct {
-		UserID         string `json:"user_id" binding:"required"`
-		Amount         int64  `json:"amount" binding:"required"`
+		UserID         string `json:"user_id" binding:"required,uuid"`
+		Amount         int64  `json:"amount" binding:"required,points"`
 		Description    string `json:"description" binding:"required"`
+		ReasonCode     string `json:"reason_code"` // 必須（未指定はInteractorでreason_code_required）
+		Tag            string `json:"tag"`
 		IdempotencyKey string `json:"idempotency_key" binding:"required"`
+		DryRun         bool   `json:"dry_run"`         // trueなら結果だけ返して反映しない
+		BudgetOverride bool   `json:"budget_override"` // trueならoverrideの予算を超えて付与する
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	// UUID変換
 	userID, err := uuid.Parse(req.UserID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("user_id", "must be a valid ID"))
 		return
 	}
 
@@ -62,10 +84,20 @@ func (c *AdminController) GrantPoints(ctx *gin.Context) {
 		UserID:         userID,
 		Amount:         req.Amount,
 		Description:    req.Description,
+		ReasonCode:     req.ReasonCode,
+		Tag:            req.Tag,
 		IdempotencyKey: req.IdempotencyKey,
+		DryRun:         req.DryRun,
+		BudgetOverride: req.BudgetOverride,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// 閾値を超える場合は承認待ちとして登録だけ行う
+	if resp.PendingAction != nil {
+		ctx.JSON(http.StatusAccepted, c.presenter.PresentPendingAction(resp.PendingAction))
 		return
 	}
 
@@ -79,26 +111,29 @@ func (c *AdminController) DeductPoints(ctx *gin.Context) {
 	// ログインユーザー（管理者）取得
 	adminID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// リクエストボディ解析
 	var req struct {
-		UserID         string `json:"user_id" binding:"required"`
-		Amount         int64  `json:"amount" binding:"required"`
+		UserID         string `json:"user_id" binding:"required,uuid"`
+		Amount         int64  `json:"amount" binding:"required,points"`
 		Description    string `json:"description" binding:"required"`
+		ReasonCode     string `json:"reason_code"` // 必須（未指定はInteractorでreason_code_required）
+		Tag            string `json:"tag"`
 		IdempotencyKey string `json:"idempotency_key" binding:"required"`
+		DryRun         bool   `json:"dry_run"` // trueなら結果だけ返して反映しない
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	// UUID変換
 	userID, err := uuid.Parse(req.UserID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("user_id", "must be a valid ID"))
 		return
 	}
 
@@ -108,10 +143,19 @@ func (c *AdminController) DeductPoints(ctx *gin.Context) {
 		UserID:         userID,
 		Amount:         req.Amount,
 		Description:    req.Description,
+		ReasonCode:     req.ReasonCode,
+		Tag:            req.Tag,
 		IdempotencyKey: req.IdempotencyKey,
+		DryRun:         req.DryRun,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// 閾値を超える場合は承認待ちとして登録だけ行う
+	if resp.PendingAction != nil {
+		ctx.JSON(http.StatusAccepted, c.presenter.PresentPendingAction(resp.PendingAction))
 		return
 	}
 
@@ -125,27 +169,30 @@ func (c *AdminController) ListAllUsers(ctx *gin.Context) {
 	// 管理者権限チェックはInteractor層で行う
 
 	// クエリパラメータ取得
-	var offset, limit int
-	fmt.Sscanf(ctx.Query("offset"), "%d", &offset)
-	fmt.Sscanf(ctx.Query("limit"), "%d", &limit)
-	if limit == 0 {
-		limit = 50
+	offset, limit, ok := bindPage(ctx, 50)
+	if !ok {
+		return
 	}
 
 	search := ctx.Query("search")
 	sortBy := ctx.Query("sort_by")
 	sortOrder := ctx.Query("sort_order")
+	departmentID, ok := parseDepartmentQuery(ctx)
+	if !ok {
+		return
+	}
 
 	// ユースケース実行
 	resp, err := c.adminUC.ListAllUsers(ctx, &inputport.ListAllUsersRequest{
-		Offset:    offset,
-		Limit:     limit,
-		Search:    search,
-		SortBy:    sortBy,
-		SortOrder: sortOrder,
+		Offset:       offset,
+		Limit:        limit,
+		Search:       search,
+		DepartmentID: departmentID,
+		SortBy:       sortBy,
+		SortOrder:    sortOrder,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -159,18 +206,21 @@ func (c *AdminController) ListAllTransactions(ctx *gin.Context) {
 	// 管理者権限チェックはInteractor層で行う
 
 	// クエリパラメータ取得
-	var offset, limit int
-	fmt.Sscanf(ctx.Query("offset"), "%d", &offset)
-	fmt.Sscanf(ctx.Query("limit"), "%d", &limit)
-	if limit == 0 {
-		limit = 50
+	offset, limit, ok := bindPage(ctx, 50)
+	if !ok {
+		return
 	}
 
 	transactionType := ctx.Query("transaction_type")
 	dateFrom := ctx.Query("date_from")
 	dateTo := ctx.Query("date_to")
+	reasonCode := ctx.Query("reason_code")
 	sortBy := ctx.Query("sort_by")
 	sortOrder := ctx.Query("sort_order")
+	departmentID, ok := parseDepartmentQuery(ctx)
+	if !ok {
+		return
+	}
 
 	// ユースケース実行
 	resp, err := c.adminUC.ListAllTransactions(ctx, &inputport.ListAllTransactionsRequest{
@@ -179,11 +229,13 @@ func (c *AdminController) ListAllTransactions(ctx *gin.Context) {
 		TransactionType: transactionType,
 		DateFrom:        dateFrom,
 		DateTo:          dateTo,
+		ReasonCode:      reasonCode,
+		DepartmentID:    departmentID,
 		SortBy:          sortBy,
 		SortOrder:       sortOrder,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -191,20 +243,34 @@ func (c *AdminController) ListAllTransactions(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, c.presenter.PresentListAllTransactions(resp))
 }
 
+// parseDepartmentQuery はクエリパラメータ department_id を解析（省略時はnil、不正なら400を返してfalse）
+func parseDepartmentQuery(ctx *gin.Context) (*uuid.UUID, bool) {
+	raw := ctx.Query("department_id")
+	if raw == "" {
+		return nil, true
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("department_id", "must be a valid ID"))
+		return nil, false
+	}
+	return &id, true
+}
+
 // UpdateUserRole はユーザーの役割を更新
 // PUT /api/admin/users/:id/role
 func (c *AdminController) UpdateUserRole(ctx *gin.Context) {
 	// ログインユーザー（管理者）取得
 	adminID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// パスパラメータ取得
 	userID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
 		return
 	}
 
@@ -213,7 +279,7 @@ func (c *AdminController) UpdateUserRole(ctx *gin.Context) {
 		Role string `json:"role" binding:"required"`
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -224,7 +290,7 @@ func (c *AdminController) UpdateUserRole(ctx *gin.Context) {
 		Role:    req.Role,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -238,14 +304,14 @@ func (c *AdminController) DeactivateUser(ctx *gin.Context) {
 	// ログインユーザー（管理者）取得
 	adminID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// パスパラメータ取得
 	userID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
 		return
 	}
 
@@ -255,7 +321,7 @@ func (c *AdminController) DeactivateUser(ctx *gin.Context) {
 		UserID:  userID,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -273,12 +339,89 @@ func (c *AdminController) GetAnalytics(ctx *gin.Context) {
 	}
 
 	resp, err := c.adminUC.GetAnalytics(ctx, &inputport.GetAnalyticsRequest{
-		Days: days,
+		Days:       days,
+		ReasonCode: ctx.Query("reason_code"),
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, c.presenter.PresentAnalytics(resp))
 }
+
+// GetCohortAnalytics はアクティブユーザー推移・コホート継続率・機能別利用状況を取得
+// GET /api/admin/analytics/cohorts?date_from=2026-01-01&date_to=2026-03-31&granularity=week&basis=transactions
+func (c *AdminController) GetCohortAnalytics(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	req := &inputport.GetCohortAnalyticsRequest{
+		AdminID:     adminID.(uuid.UUID),
+		Granularity: entities.AnalyticsGranularity(ctx.DefaultQuery("granularity", string(entities.AnalyticsGranularityWeek))),
+		Basis:       entities.ActivityBasis(ctx.DefaultQuery("basis", string(entities.ActivityBasisTransactions))),
+	}
+	if !req.Granularity.IsValid() {
+		respondError(ctx, http.StatusBadRequest, invalidParam("granularity", "must be one of: week, month"))
+		return
+	}
+	if !req.Basis.IsValid() {
+		respondError(ctx, http.StatusBadRequest, invalidParam("basis", "must be one of: transactions, logins"))
+		return
+	}
+
+	// date_to はその日を含むため翌日0時を終端にする
+	if v := ctx.Query("date_from"); v != "" {
+		from, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			respondError(ctx, http.StatusBadRequest, invalidParam("date_from", "must be a date in YYYY-MM-DD format"))
+			return
+		}
+		req.From = from
+	}
+	if v := ctx.Query("date_to"); v != "" {
+		to, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			respondError(ctx, http.StatusBadRequest, invalidParam("date_to", "must be a date in YYYY-MM-DD format"))
+			return
+		}
+		req.To = to.AddDate(0, 0, 1)
+	}
+
+	resp, err := c.adminUC.GetCohortAnalytics(ctx, req)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentCohortAnalytics(resp))
+}
+
+// GetForecast は失効予定ポイントの予測とポイントの流通速度を取得
+// GET /api/admin/analytics/forecast?weeks=8&days=90
+func (c *AdminController) GetForecast(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var weeks, days int
+	fmt.Sscanf(ctx.Query("weeks"), "%d", &weeks)
+	fmt.Sscanf(ctx.Query("days"), "%d", &days)
+
+	resp, err := c.adminUC.GetForecast(ctx, &inputport.GetForecastRequest{
+		AdminID: adminID.(uuid.UUID),
+		Weeks:   weeks,
+		Days:    days,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentForecast(resp))
+}
@@ -0,0 +1,52 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// AdminDashboardController は管理者ダッシュボードのコントローラー（管理者用）
+type AdminDashboardController struct {
+	dashboardUC inputport.AdminDashboardInputPort
+	presenter   *presenter.AdminDashboardPresenter
+}
+
+// NewAdminDashboardController は新しいAdminDashboardControllerを作成
+func NewAdminDashboardController(
+	dashboardUC inputport.AdminDashboardInputPort,
+	presenter *presenter.AdminDashboardPresenter,
+) *AdminDashboardController {
+	return &AdminDashboardController{
+		dashboardUC: dashboardUC,
+		presenter:   presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *AdminDashboardController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/dashboard", c.GetDashboard)
+}
+
+// GetDashboard はリアルタイムのカウンターとDBの状態を取得（数秒間キャッシュ）
+// GET /api/admin/dashboard
+func (c *AdminDashboardController) GetDashboard(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	dashboard, err := c.dashboardUC.GetDashboard(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, c.presenter.PresentDashboard(dashboard))
+}
@@ -0,0 +1,120 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// AkerunRepollController はAkerunの入退室記録の再取得のコントローラー（管理者用）
+type AkerunRepollController struct {
+	repollUC  inputport.AkerunRepollInputPort
+	presenter *presenter.AkerunRepollPresenter
+}
+
+// NewAkerunRepollController は新しいAkerunRepollControllerを作成
+func NewAkerunRepollController(
+	repollUC inputport.AkerunRepollInputPort,
+	presenter *presenter.AkerunRepollPresenter,
+) *AkerunRepollController {
+	return &AkerunRepollController{
+		repollUC:  repollUC,
+		presenter: presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *AkerunRepollController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.POST("/akerun/repoll", c.RequestRepoll)
+	routes.Admin.GET("/akerun/repoll", c.ListRepolls)
+	routes.Admin.GET("/akerun/repoll/:id", c.GetRepoll)
+}
+
+// RequestRepoll はポーリングできなかった期間の再取得を依頼する（取得はワーカーが非同期で行う）
+// POST /api/admin/akerun/repoll
+func (c *AkerunRepollController) RequestRepoll(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		OrganizationID string    `json:"organization_id"`
+		From           time.Time `json:"from" binding:"required"`
+		To             time.Time `json:"to" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	repoll, err := c.repollUC.RequestRepoll(ctx, &inputport.RequestAkerunRepollRequest{
+		OrganizationID: req.OrganizationID,
+		From:           req.From,
+		To:             req.To,
+		AdminID:        adminID.(uuid.UUID),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, c.presenter.PresentRepoll(repoll))
+}
+
+// ListRepolls は再取得の依頼を新しい順に取得
+// GET /api/admin/akerun/repoll?offset=0&limit=20
+func (c *AkerunRepollController) ListRepolls(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
+
+	resp, err := c.repollUC.ListRepolls(ctx, &inputport.ListAkerunRepollsRequest{
+		AdminID: adminID.(uuid.UUID),
+		Offset:  offset,
+		Limit:   limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentRepolls(resp))
+}
+
+// GetRepoll は再取得の依頼と進み具合を取得
+// GET /api/admin/akerun/repoll/:id
+func (c *AkerunRepollController) GetRepoll(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	repoll, err := c.repollUC.GetRepoll(ctx, adminID.(uuid.UUID), id)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentRepoll(repoll))
+}
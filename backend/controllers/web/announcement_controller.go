@@ -0,0 +1,227 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// AnnouncementController はお知らせ配信のコントローラー
+type AnnouncementController struct {
+	announcementUC inputport.AnnouncementInputPort
+	presenter      *presenter.AnnouncementPresenter
+}
+
+// NewAnnouncementController は新しいAnnouncementControllerを作成
+func NewAnnouncementController(
+	announcementUC inputport.AnnouncementInputPort,
+	presenter *presenter.AnnouncementPresenter,
+) *AnnouncementController {
+	return &AnnouncementController{
+		announcementUC: announcementUC,
+		presenter:      presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *AnnouncementController) RegisterRoutes(routes *RouteGroups) {
+	routes.Authenticated.GET("/announcements", c.GetActiveAnnouncements)
+	routes.Protected.POST("/announcements/:id/dismiss", c.DismissAnnouncement)
+
+	routes.Admin.GET("/announcements", c.GetAnnouncementList)
+	routes.Admin.POST("/announcements", c.CreateAnnouncement)
+	routes.Admin.PUT("/announcements/:id", c.UpdateAnnouncement)
+	routes.Admin.DELETE("/announcements/:id", c.DeleteAnnouncement)
+}
+
+// announcementRequest はお知らせ作成・更新のリクエストボディ
+type announcementRequest struct {
+	Title      string     `json:"title" binding:"required"`
+	Body       string     `json:"body" binding:"required"`
+	Severity   string     `json:"severity" binding:"required"`
+	Audience   string     `json:"audience" binding:"required"`
+	TargetRole string     `json:"target_role"`
+	StartsAt   *time.Time `json:"starts_at"`
+	EndsAt     *time.Time `json:"ends_at"`
+}
+
+func (r *announcementRequest) toContent() inputport.AnnouncementContent {
+	content := inputport.AnnouncementContent{
+		Title:      r.Title,
+		Body:       r.Body,
+		Severity:   entities.AnnouncementSeverity(r.Severity),
+		Audience:   entities.AnnouncementAudience(r.Audience),
+		TargetRole: entities.UserRole(r.TargetRole),
+		EndsAt:     r.EndsAt,
+	}
+	if r.StartsAt != nil {
+		content.StartsAt = *r.StartsAt
+	}
+	return content
+}
+
+// GetActiveAnnouncements は表示中のお知らせを取得
+// GET /api/announcements?include_dismissed=true
+func (c *AnnouncementController) GetActiveAnnouncements(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	resp, err := c.announcementUC.GetActiveAnnouncements(ctx, &inputport.GetActiveAnnouncementsRequest{
+		UserID:           userID.(uuid.UUID),
+		IncludeDismissed: ctx.Query("include_dismissed") == "true",
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentActiveAnnouncements(resp))
+}
+
+// DismissAnnouncement はお知らせを非表示にする
+// POST /api/announcements/:id/dismiss
+func (c *AnnouncementController) DismissAnnouncement(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	announcementID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	if err := c.announcementUC.DismissAnnouncement(ctx, &inputport.DismissAnnouncementRequest{
+		UserID:         userID.(uuid.UUID),
+		AnnouncementID: announcementID,
+	}); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "announcement dismissed"})
+}
+
+// GetAnnouncementList は表示期間外を含むお知らせの一覧を取得（管理者用）
+// GET /api/admin/announcements?offset=0&limit=20
+func (c *AnnouncementController) GetAnnouncementList(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
+
+	resp, err := c.announcementUC.GetAnnouncementList(ctx, &inputport.GetAnnouncementListRequest{
+		AdminID: adminID.(uuid.UUID),
+		Offset:  offset,
+		Limit:   limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentAnnouncementList(resp))
+}
+
+// CreateAnnouncement はお知らせを作成（管理者用）
+// POST /api/admin/announcements
+func (c *AnnouncementController) CreateAnnouncement(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req announcementRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	announcement, err := c.announcementUC.CreateAnnouncement(ctx, &inputport.CreateAnnouncementRequest{
+		AdminID:             adminID.(uuid.UUID),
+		AnnouncementContent: req.toContent(),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"announcement": c.presenter.PresentAnnouncement(announcement)})
+}
+
+// UpdateAnnouncement はお知らせを更新（管理者用）
+// PUT /api/admin/announcements/:id
+func (c *AnnouncementController) UpdateAnnouncement(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	announcementID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	var req announcementRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	announcement, err := c.announcementUC.UpdateAnnouncement(ctx, &inputport.UpdateAnnouncementRequest{
+		AdminID:             adminID.(uuid.UUID),
+		AnnouncementID:      announcementID,
+		AnnouncementContent: req.toContent(),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"announcement": c.presenter.PresentAnnouncement(announcement)})
+}
+
+// DeleteAnnouncement はお知らせを削除（管理者用）
+// DELETE /api/admin/announcements/:id
+func (c *AnnouncementController) DeleteAnnouncement(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	announcementID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	if err := c.announcementUC.DeleteAnnouncement(ctx, &inputport.DeleteAnnouncementRequest{
+		AdminID:        adminID.(uuid.UUID),
+		AnnouncementID: announcementID,
+	}); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "announcement deleted"})
+}
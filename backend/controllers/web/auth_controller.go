@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/google/uuid"
 )
@@ -13,20 +14,53 @@ import (
 // AuthController は認証関連のコントローラー
 type AuthController struct {
 	authUC    inputport.AuthInputPort
+	csrfUC    inputport.CSRFInputPort
 	presenter *presenter.AuthPresenter
+	cookie    *presenter.SessionCookie
 }
 
 // NewAuthController は新しいAuthControllerを作成
 func NewAuthController(
 	authUC inputport.AuthInputPort,
+	csrfUC inputport.CSRFInputPort,
 	presenter *presenter.AuthPresenter,
+	cookie *presenter.SessionCookie,
 ) *AuthController {
 	return &AuthController{
 		authUC:    authUC,
+		csrfUC:    csrfUC,
 		presenter: presenter,
+		cookie:    cookie,
 	}
 }
 
+// RegisterRoutes はルートを登録
+func (c *AuthController) RegisterRoutes(routes *RouteGroups) {
+	// ログイン・ロック解除はメンテナンス中も可能（許可リストの管理者がログインできるように）
+	routes.Public.POST("/auth/register", routes.Maintenance, func(ctx *gin.Context) {
+		c.Register(ctx, routes.Now())
+	})
+	routes.Public.POST("/auth/login", func(ctx *gin.Context) {
+		c.Login(ctx, routes.Now())
+	})
+	routes.Public.POST("/auth/unlock", func(ctx *gin.Context) {
+		c.UnlockAccount(ctx, routes.Now())
+	})
+
+	routes.Authenticated.GET("/auth/me", func(ctx *gin.Context) {
+		c.GetCurrentUser(ctx, routes.Now())
+	})
+	routes.Authenticated.GET("/auth/csrf", func(ctx *gin.Context) {
+		c.GetCSRFToken(ctx, routes.Now())
+	})
+	routes.Session.POST("/auth/logout", func(ctx *gin.Context) {
+		c.Logout(ctx, routes.Now())
+	})
+	routes.Protected.POST("/auth/deactivate", func(ctx *gin.Context) {
+		c.DeactivateAccount(ctx, routes.Now())
+	})
+}
+
 // RegisterRequest は登録リクエスト
 type RegisterRequest struct {
 	Username    string `json:"username" binding:"required,min=3,max=50"`
@@ -35,6 +69,8 @@ type RegisterRequest struct {
 	DisplayName string `json:"display_name" binding:"required,min=1,max=100"`
 	FirstName   string `json:"first_name" binding:"required,max=100"`
 	LastName    string `json:"last_name" binding:"required,max=100"`
+
+	ReferralCode string `json:"referral_code" binding:"max=20"`
 }
 
 // Register は新しいユーザーを登録
@@ -42,7 +78,7 @@ type RegisterRequest struct {
 func (c *AuthController) Register(ctx *gin.Context, currentTime time.Time) {
 	var req RegisterRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -53,23 +89,20 @@ func (c *AuthController) Register(ctx *gin.Context, currentTime time.Time) {
 		DisplayName: req.DisplayName,
 		FirstName:   req.FirstName,
 		LastName:    req.LastName,
+
+		ReferralCode: req.ReferralCode,
+		IPAddress:    ctx.ClientIP(),
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
-	// セッショントークンをCookieに設定
-	ctx.SetCookie(
-		"session_token",
-		resp.Session.SessionToken,
-		24*60*60, // 24時間
-		"/",
-		"",
-		false, // HTTPS only in production
-		true,  // HttpOnly
-	)
+	// セッショントークンをCookieに設定（メール認証が済むまでログインできない設定ならセッションはない）
+	if resp.Session != nil {
+		c.cookie.Set(ctx, resp.Session.SessionToken)
+	}
 
 	output := c.presenter.PresentRegisterResponse(resp)
 	ctx.JSON(http.StatusCreated, output)
@@ -86,7 +119,7 @@ type LoginRequest struct {
 func (c *AuthController) Login(ctx *gin.Context, currentTime time.Time) {
 	var req LoginRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -95,23 +128,16 @@ func (c *AuthController) Login(ctx *gin.Context, currentTime time.Time) {
 		Password:  req.Password,
 		IPAddress: ctx.ClientIP(),
 		UserAgent: ctx.GetHeader("User-Agent"),
+		Country:   ctx.GetHeader("CF-IPCountry"), // Cloudflare経由の場合のみ付与される
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusUnauthorized, err)
 		return
 	}
 
 	// セッショントークンをCookieに設定
-	ctx.SetCookie(
-		"session_token",
-		resp.Session.SessionToken,
-		24*60*60,
-		"/",
-		"",
-		false,
-		true,
-	)
+	c.cookie.Set(ctx, resp.Session.SessionToken)
 
 	output := c.presenter.PresentLoginResponse(resp)
 	ctx.JSON(http.StatusOK, output)
@@ -122,7 +148,7 @@ func (c *AuthController) Login(ctx *gin.Context, currentTime time.Time) {
 func (c *AuthController) Logout(ctx *gin.Context, currentTime time.Time) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
@@ -131,22 +157,57 @@ func (c *AuthController) Logout(ctx *gin.Context, currentTime time.Time) {
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	// Cookieをクリア
-	ctx.SetCookie("session_token", "", -1, "/", "", false, true)
+	c.cookie.Clear(ctx)
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "logout successful"})
 }
 
+// DeactivateAccountRequest はアカウント休止リクエスト
+type DeactivateAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// DeactivateAccount はアカウントを一時的に休止（期間内に再びログインすると再開）
+// POST /api/auth/deactivate
+func (c *AuthController) DeactivateAccount(ctx *gin.Context, currentTime time.Time) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req DeactivateAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := c.authUC.DeactivateAccount(ctx, &inputport.DeactivateAccountRequest{
+		UserID:   userID.(uuid.UUID),
+		Password: req.Password,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// セッションは失効済みのためCookieもクリア
+	c.cookie.Clear(ctx)
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentDeactivateAccountResponse(resp))
+}
+
 // GetCurrentUser は現在のユーザー情報を取得
 // GET /api/auth/me
 func (c *AuthController) GetCurrentUser(ctx *gin.Context, currentTime time.Time) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
@@ -155,10 +216,57 @@ func (c *AuthController) GetCurrentUser(ctx *gin.Context, currentTime time.Time)
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	output := c.presenter.PresentCurrentUserResponse(resp)
 	ctx.JSON(http.StatusOK, output)
 }
+
+// GetCSRFToken はCSRFトークンを取得（期限切れなら新しいトークンに入れ替える）
+// SPAはCSRFトークンの期限が切れたら、再ログインせずにここから取得し直す
+// GET /api/auth/csrf
+func (c *AuthController) GetCSRFToken(ctx *gin.Context, currentTime time.Time) {
+	session, ok := currentSession(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	resp, err := c.csrfUC.IssueToken(ctx, &inputport.IssueCSRFTokenRequest{
+		Session: session,
+		Now:     currentTime,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, c.presenter.PresentCSRFToken(resp))
+}
+
+// UnlockAccountRequest はアカウントロック解除リクエスト
+type UnlockAccountRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// UnlockAccount はメールのリンクからアカウントロックを解除
+// POST /api/auth/unlock
+func (c *AuthController) UnlockAccount(ctx *gin.Context, currentTime time.Time) {
+	var req UnlockAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := c.authUC.UnlockAccount(ctx, &inputport.UnlockAccountRequest{
+		Token: req.Token,
+	}); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "account unlocked"})
+}
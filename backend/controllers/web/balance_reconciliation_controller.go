@@ -0,0 +1,67 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// BalanceReconciliationController は残高と取引履歴の照合のコントローラー
+type BalanceReconciliationController struct {
+	reconciliationUC inputport.BalanceReconciliationInputPort
+	presenter        *presenter.BalanceReconciliationPresenter
+}
+
+// NewBalanceReconciliationController は新しいBalanceReconciliationControllerを作成
+func NewBalanceReconciliationController(
+	reconciliationUC inputport.BalanceReconciliationInputPort,
+	presenter *presenter.BalanceReconciliationPresenter,
+) *BalanceReconciliationController {
+	return &BalanceReconciliationController{
+		reconciliationUC: reconciliationUC,
+		presenter:        presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *BalanceReconciliationController) RegisterRoutes(routes *RouteGroups) {
+	// applyで補正取引を記録して残高を合わせる
+	routes.Admin.POST("/maintenance/recompute-balances", c.RecomputeBalances)
+}
+
+// RecomputeBalances は全ユーザーの残高を取引履歴から計算し直す（applyがtrueなら補正する）
+// POST /api/admin/maintenance/recompute-balances
+func (c *BalanceReconciliationController) RecomputeBalances(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		Apply     bool   `json:"apply"`
+		BatchSize int    `json:"batch_size" binding:"min=0"`
+		Reason    string `json:"reason" binding:"max=500"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := c.reconciliationUC.RecomputeBalances(ctx, &inputport.RecomputeBalancesRequest{
+		AdminID:   adminID.(uuid.UUID),
+		Apply:     req.Apply,
+		BatchSize: req.BatchSize,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentRecomputeBalances(resp))
+}
@@ -0,0 +1,189 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// BudgetController は管理者付与の予算のコントローラー
+type BudgetController struct {
+	budgetUC  inputport.BudgetInputPort
+	presenter *presenter.BudgetPresenter
+}
+
+// NewBudgetController は新しいBudgetControllerを作成
+func NewBudgetController(
+	budgetUC inputport.BudgetInputPort,
+	presenter *presenter.BudgetPresenter,
+) *BudgetController {
+	return &BudgetController{
+		budgetUC:  budgetUC,
+		presenter: presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *BudgetController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/budgets", c.GetBudgets)
+	routes.Admin.POST("/budgets", c.CreateBudget)
+	routes.Admin.GET("/budgets/:id", c.GetBudget)
+	routes.Admin.PUT("/budgets/:id", c.UpdateBudget)
+	routes.Admin.DELETE("/budgets/:id", c.DeleteBudget)
+}
+
+// GetBudgets は全予算と現在の期間の消化状況を取得
+// GET /api/admin/budgets
+func (c *BudgetController) GetBudgets(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	statuses, err := c.budgetUC.ListBudgets(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentBudgetList(statuses))
+}
+
+// GetBudget は予算と現在の期間の消化状況を取得
+// GET /api/admin/budgets/:id
+func (c *BudgetController) GetBudget(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	budgetID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	status, err := c.budgetUC.GetBudget(ctx, &inputport.GetBudgetRequest{
+		AdminID:  adminID.(uuid.UUID),
+		BudgetID: budgetID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"budget": c.presenter.PresentBudget(status)})
+}
+
+// CreateBudget は予算を作成
+// POST /api/admin/budgets
+func (c *BudgetController) CreateBudget(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		Name        string     `json:"name" binding:"required"`
+		Scope       string     `json:"scope" binding:"required"`
+		ScopeID     *uuid.UUID `json:"scope_id"` // departmentなら部署ID、adminなら管理者のユーザーID
+		Period      string     `json:"period" binding:"required"`
+		Amount      int64      `json:"amount" binding:"required"`
+		Enforcement string     `json:"enforcement" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	status, err := c.budgetUC.CreateBudget(ctx, &inputport.CreateBudgetRequest{
+		AdminID:     adminID.(uuid.UUID),
+		Name:        req.Name,
+		Scope:       entities.BudgetScope(req.Scope),
+		ScopeID:     req.ScopeID,
+		Period:      entities.BudgetPeriod(req.Period),
+		Amount:      req.Amount,
+		Enforcement: entities.BudgetEnforcement(req.Enforcement),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"budget": c.presenter.PresentBudget(status)})
+}
+
+// UpdateBudget は予算名・金額・超過時の扱い・有効状態を更新
+// PUT /api/admin/budgets/:id
+func (c *BudgetController) UpdateBudget(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	budgetID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	var req struct {
+		Name        string `json:"name" binding:"required"`
+		Amount      int64  `json:"amount" binding:"required"`
+		Enforcement string `json:"enforcement" binding:"required"`
+		IsActive    *bool  `json:"is_active" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	status, err := c.budgetUC.UpdateBudget(ctx, &inputport.UpdateBudgetRequest{
+		AdminID:     adminID.(uuid.UUID),
+		BudgetID:    budgetID,
+		Name:        req.Name,
+		Amount:      req.Amount,
+		Enforcement: entities.BudgetEnforcement(req.Enforcement),
+		IsActive:    *req.IsActive,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"budget": c.presenter.PresentBudget(status)})
+}
+
+// DeleteBudget は予算を削除
+// DELETE /api/admin/budgets/:id
+func (c *BudgetController) DeleteBudget(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	budgetID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	if err := c.budgetUC.DeleteBudget(ctx, &inputport.DeleteBudgetRequest{
+		AdminID:  adminID.(uuid.UUID),
+		BudgetID: budgetID,
+	}); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "budget deleted"})
+}
@@ -0,0 +1,189 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// CartController は商品交換のカートのコントローラー
+type CartController struct {
+	cartUC    inputport.CartInputPort
+	presenter *presenter.CartPresenter
+}
+
+// NewCartController は新しいCartControllerを作成
+func NewCartController(
+	cartUC inputport.CartInputPort,
+	presenter *presenter.CartPresenter,
+) *CartController {
+	return &CartController{
+		cartUC:    cartUC,
+		presenter: presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *CartController) RegisterRoutes(routes *RouteGroups) {
+	routes.Protected.GET("/cart", c.GetCart)
+	routes.Protected.POST("/cart/items", c.AddItem)
+	routes.Protected.PUT("/cart/items/:product_id", c.UpdateItem)
+	routes.Protected.DELETE("/cart/items/:product_id", c.RemoveItem)
+	routes.Protected.POST("/cart/checkout", c.Checkout)
+}
+
+// GetCart はカートの中身を取得
+// GET /api/cart
+func (c *CartController) GetCart(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	resp, err := c.cartUC.GetCart(ctx, userID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentCart(resp))
+}
+
+// AddItem はカートに商品を追加（入っている商品なら数量を足す）
+// POST /api/cart/items
+func (c *CartController) AddItem(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		ProductID string `json:"product_id" binding:"required"`
+		Quantity  int    `json:"quantity" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	productID, err := uuid.Parse(req.ProductID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("product_id", "must be a valid ID"))
+		return
+	}
+
+	resp, err := c.cartUC.AddItem(ctx, &inputport.AddCartItemRequest{
+		UserID:    userID.(uuid.UUID),
+		ProductID: productID,
+		Quantity:  req.Quantity,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentCart(resp))
+}
+
+// UpdateItem はカートの商品の数量を変更（0なら削除）
+// PUT /api/cart/items/:product_id
+func (c *CartController) UpdateItem(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	productID, err := uuid.Parse(ctx.Param("product_id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("product_id", "must be a valid ID"))
+		return
+	}
+
+	var req struct {
+		Quantity *int `json:"quantity" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := c.cartUC.UpdateItem(ctx, &inputport.UpdateCartItemRequest{
+		UserID:    userID.(uuid.UUID),
+		ProductID: productID,
+		Quantity:  *req.Quantity,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentCart(resp))
+}
+
+// RemoveItem はカートから商品を削除
+// DELETE /api/cart/items/:product_id
+func (c *CartController) RemoveItem(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	productID, err := uuid.Parse(ctx.Param("product_id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("product_id", "must be a valid ID"))
+		return
+	}
+
+	resp, err := c.cartUC.RemoveItem(ctx, userID.(uuid.UUID), productID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentCart(resp))
+}
+
+// Checkout はカートの商品をまとめて交換する（いずれかの商品で失敗した場合は何も交換しない）
+// POST /api/cart/checkout
+func (c *CartController) Checkout(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		Notes             string `json:"notes"`
+		ShippingAddressID string `json:"shipping_address_id"` // 省略時は既定のお届け先
+	}
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	addressID, ok := parseOptionalID(ctx, "shipping_address_id", req.ShippingAddressID)
+	if !ok {
+		return
+	}
+
+	resp, err := c.cartUC.Checkout(ctx, &inputport.CheckoutCartRequest{
+		UserID:            userID.(uuid.UUID),
+		Notes:             req.Notes,
+		ShippingAddressID: addressID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentCheckout(resp))
+}
@@ -26,20 +26,29 @@ func NewCategoryController(
 	}
 }
 
+// RegisterRoutes はルートを登録
+func (c *CategoryController) RegisterRoutes(routes *RouteGroups) {
+	routes.Public.GET("/categories", c.GetCategoryList)
+
+	routes.Admin.POST("/categories", c.CreateCategory)
+	routes.Admin.PUT("/categories/:id", c.UpdateCategory)
+	routes.Admin.DELETE("/categories/:id", c.DeleteCategory)
+}
+
 // CreateCategory は新しいカテゴリを作成（管理者のみ）
 // POST /admin/categories
 func (c *CategoryController) CreateCategory(ctx *gin.Context) {
 	var req inputport.CreateCategoryRequest
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	resp, err := c.categoryUseCase.CreateCategory(ctx, &req)
 	if err != nil {
 		c.logger.Error("Failed to create category", entities.NewField("error", err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -51,13 +60,13 @@ func (c *CategoryController) CreateCategory(ctx *gin.Context) {
 func (c *CategoryController) UpdateCategory(ctx *gin.Context) {
 	categoryID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid category ID"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
 		return
 	}
 
 	var req inputport.UpdateCategoryRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -66,7 +75,7 @@ func (c *CategoryController) UpdateCategory(ctx *gin.Context) {
 	resp, err := c.categoryUseCase.UpdateCategory(ctx, &req)
 	if err != nil {
 		c.logger.Error("Failed to update category", entities.NewField("error", err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -78,7 +87,7 @@ func (c *CategoryController) UpdateCategory(ctx *gin.Context) {
 func (c *CategoryController) DeleteCategory(ctx *gin.Context) {
 	categoryID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid category ID"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
 		return
 	}
 
@@ -88,7 +97,7 @@ func (c *CategoryController) DeleteCategory(ctx *gin.Context) {
 
 	if err := c.categoryUseCase.DeleteCategory(ctx, req); err != nil {
 		c.logger.Error("Failed to delete category", entities.NewField("error", err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -107,7 +116,7 @@ func (c *CategoryController) GetCategoryList(ctx *gin.Context) {
 	resp, err := c.categoryUseCase.GetCategoryList(ctx, req)
 	if err != nil {
 		c.logger.Error("Failed to get category list", entities.NewField("error", err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -2,10 +2,10 @@ package web
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/google/uuid"
 )
@@ -27,11 +27,32 @@ func NewDailyBonusController(
 	}
 }
 
+// RegisterRoutes はルートを登録
+func (c *DailyBonusController) RegisterRoutes(routes *RouteGroups) {
+	routes.Authenticated.GET("/daily-bonus/today", c.GetTodayBonus)
+	routes.Authenticated.GET("/daily-bonus/recent", c.GetRecentBonuses)
+	routes.Protected.POST("/daily-bonus/mark-viewed", c.MarkBonusViewed)
+	routes.Protected.POST("/daily-bonus/draw", c.DrawLottery)
+
+	// Akerun入退室ボーナスの抽選ティア
+	routes.Admin.GET("/bonus-settings", c.GetBonusSettings)
+	routes.Admin.PUT("/bonus-settings/timezone", c.UpdateBonusTimezone)
+	routes.Admin.PUT("/lottery-tiers", c.UpdateLotteryTiers)
+
+	// ボーナスの付与に失敗した入退室記録（自動再試行の上限に達したものは再投入する）
+	routes.Admin.GET("/akerun/failed-accesses", c.ListFailedAccesses)
+	routes.Admin.POST("/akerun/failed-accesses/:id/requeue", c.RequeueFailedAccess)
+
+	// 出席のレポート（ボーナスを受け取った日を出席とみなす）
+	routes.Admin.GET("/daily-bonuses/export", c.ExportBonuses)
+	routes.Admin.GET("/daily-bonuses/attendance", c.GetAttendanceMatrix)
+}
+
 // GetTodayBonus は本日のボーナス状況を取得
 func (c *DailyBonusController) GetTodayBonus(ctx *gin.Context) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
@@ -39,7 +60,7 @@ func (c *DailyBonusController) GetTodayBonus(ctx *gin.Context) {
 		UserID: userID.(uuid.UUID),
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -50,15 +71,13 @@ func (c *DailyBonusController) GetTodayBonus(ctx *gin.Context) {
 func (c *DailyBonusController) GetRecentBonuses(ctx *gin.Context) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
-	limit := 7 // デフォルトで7日分
-	if limitStr := ctx.Query("limit"); limitStr != "" {
-		if val, err := strconv.Atoi(limitStr); err == nil {
-			limit = val
-		}
+	limit, ok := bindLimit(ctx, 7) // デフォルトで7日分
+	if !ok {
+		return
 	}
 
 	resp, err := c.dailyBonusPort.GetRecentBonuses(ctx, &inputport.GetRecentBonusesRequest{
@@ -66,7 +85,7 @@ func (c *DailyBonusController) GetRecentBonuses(ctx *gin.Context) {
 		Limit:  limit,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -77,7 +96,7 @@ func (c *DailyBonusController) GetRecentBonuses(ctx *gin.Context) {
 func (c *DailyBonusController) GetBonusSettings(ctx *gin.Context) {
 	resp, err := c.dailyBonusPort.GetBonusSettings(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -96,6 +115,32 @@ func (c *DailyBonusController) GetBonusSettings(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{
 		"bonus_points":  resp.BonusPoints,
 		"lottery_tiers": tiers,
+		"timezone":      resp.Timezone,
+	})
+}
+
+// UpdateBonusTimezone はボーナス日の区切りに使うタイムゾーンを更新（管理者用）
+// PUT /api/admin/bonus-settings/timezone
+func (c *DailyBonusController) UpdateBonusTimezone(ctx *gin.Context) {
+	var req struct {
+		Timezone string `json:"timezone" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	err := c.dailyBonusPort.UpdateBonusTimezone(ctx, &inputport.UpdateBonusTimezoneRequest{
+		Timezone: req.Timezone,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message":  "ボーナス日のタイムゾーンを更新しました",
+		"timezone": req.Timezone,
 	})
 }
 
@@ -110,7 +155,7 @@ func (c *DailyBonusController) UpdateLotteryTiers(ctx *gin.Context) {
 		} `json:"tiers" binding:"required"`
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request: " + err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -128,7 +173,7 @@ func (c *DailyBonusController) UpdateLotteryTiers(ctx *gin.Context) {
 		Tiers: tiers,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -137,11 +182,75 @@ func (c *DailyBonusController) UpdateLotteryTiers(ctx *gin.Context) {
 	})
 }
 
+// ListFailedAccesses はボーナスの付与に失敗した入退室記録を取得（管理者用、既定は再試行を止めたもののみ）
+// GET /api/admin/akerun/failed-accesses
+func (c *DailyBonusController) ListFailedAccesses(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 50)
+	if !ok {
+		return
+	}
+
+	// status=all で全状態
+	status := entities.FailedAkerunAccessStatus(ctx.DefaultQuery("status", string(entities.FailedAkerunAccessDead)))
+	if status == "all" {
+		status = ""
+	} else if !status.IsValid() {
+		respondError(ctx, http.StatusBadRequest, invalidParam("status", "must be one of the allowed values"))
+		return
+	}
+
+	resp, err := c.dailyBonusPort.ListFailedAccesses(ctx, &inputport.ListFailedAccessesRequest{
+		AdminID: adminID.(uuid.UUID),
+		Status:  status,
+		Offset:  offset,
+		Limit:   limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentFailedAccesses(resp))
+}
+
+// RequeueFailedAccess は再試行を止めた入退室記録を再試行待ちに戻す（管理者用）
+// POST /api/admin/akerun/failed-accesses/:id/requeue
+func (c *DailyBonusController) RequeueFailedAccess(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	access, err := c.dailyBonusPort.RequeueFailedAccess(ctx, &inputport.RequeueFailedAccessRequest{
+		ID:      id,
+		AdminID: adminID.(uuid.UUID),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentFailedAccess(access))
+}
+
 // MarkBonusViewed はボーナスを閲覧済みにする
 func (c *DailyBonusController) MarkBonusViewed(ctx *gin.Context) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
@@ -149,13 +258,13 @@ func (c *DailyBonusController) MarkBonusViewed(ctx *gin.Context) {
 		BonusID string `json:"bonus_id" binding:"required"`
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	bonusUUID, err := uuid.Parse(req.BonusID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid bonus_id"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("bonus_id", "must be a valid ID"))
 		return
 	}
 
@@ -164,7 +273,7 @@ func (c *DailyBonusController) MarkBonusViewed(ctx *gin.Context) {
 		UserID:  userID.(uuid.UUID),
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -177,7 +286,7 @@ func (c *DailyBonusController) MarkBonusViewed(ctx *gin.Context) {
 func (c *DailyBonusController) DrawLottery(ctx *gin.Context) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
@@ -185,7 +294,7 @@ func (c *DailyBonusController) DrawLottery(ctx *gin.Context) {
 		UserID: userID.(uuid.UUID),
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -195,3 +304,65 @@ func (c *DailyBonusController) DrawLottery(ctx *gin.Context) {
 		"bonus_id":          resp.BonusID,
 	})
 }
+
+// csvResponseWriter は最初の書き込みでCSVのレスポンスヘッダーを送る
+// 書き込む前に失敗した場合はJSONのエラーを返せるよう、ヘッダーを先に確定させない
+type csvResponseWriter struct {
+	ctx      *gin.Context
+	filename string
+	started  bool
+}
+
+func (w *csvResponseWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.ctx.Header("Content-Type", "text/csv; charset=utf-8")
+		w.ctx.Header("Content-Disposition", `attachment; filename="`+w.filename+`"`)
+		w.ctx.Header("Cache-Control", "no-store")
+		w.ctx.Status(http.StatusOK)
+	}
+	return w.ctx.Writer.Write(p)
+}
+
+// ExportBonuses はボーナス日が期間内のボーナスをCSVで出力（管理者用）
+// GET /api/admin/daily-bonuses/export?from=2024-04-01&to=2024-04-30
+func (c *DailyBonusController) ExportBonuses(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	req := &inputport.ExportBonusesRequest{AdminID: adminID.(uuid.UUID), From: ctx.Query("from"), To: ctx.Query("to")}
+	w := &csvResponseWriter{ctx: ctx, filename: "daily-bonuses-" + req.From + "-" + req.To + ".csv"}
+
+	if err := c.dailyBonusPort.ExportBonuses(ctx, req, w); err != nil {
+		if !w.started {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		// 送信を始めた後はステータスを変えられないため、CSVは途中で終わる（アクセスログにエラーを残す）
+		ctx.Error(err)
+	}
+}
+
+// GetAttendanceMatrix は月のユーザーごと・日ごとの出席表を取得（管理者用）
+// GET /api/admin/daily-bonuses/attendance?month=2024-04
+func (c *DailyBonusController) GetAttendanceMatrix(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	matrix, err := c.dailyBonusPort.GetAttendanceMatrix(ctx, &inputport.GetAttendanceMatrixRequest{
+		AdminID: adminID.(uuid.UUID),
+		Month:   ctx.Query("month"),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentAttendanceMatrix(matrix))
+}
@@ -0,0 +1,99 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// DataExportController は個人データエクスポートのコントローラー
+type DataExportController struct {
+	dataExportUC inputport.DataExportInputPort
+	presenter    *presenter.DataExportPresenter
+}
+
+// NewDataExportController は新しいDataExportControllerを作成
+func NewDataExportController(
+	dataExportUC inputport.DataExportInputPort,
+	presenter *presenter.DataExportPresenter,
+) *DataExportController {
+	return &DataExportController{
+		dataExportUC: dataExportUC,
+		presenter:    presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *DataExportController) RegisterRoutes(routes *RouteGroups) {
+	routes.Authenticated.GET("/settings/data-export", c.GetLatestExport)
+	routes.Authenticated.GET("/settings/data-export/:id/download", c.DownloadExport)
+	routes.Protected.POST("/settings/data-export", c.RequestExport)
+}
+
+// RequestExport は個人データのエクスポートを依頼する（完了はメールで通知）
+// POST /api/settings/data-export
+func (c *DataExportController) RequestExport(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	export, err := c.dataExportUC.RequestExport(ctx, userID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, c.presenter.PresentExport(export))
+}
+
+// GetLatestExport は最新のエクスポート依頼の状態を取得
+// GET /api/settings/data-export
+func (c *DataExportController) GetLatestExport(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	export, err := c.dataExportUC.GetLatestExport(ctx, userID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentExport(export))
+}
+
+// DownloadExport は作成済みのエクスポートファイル（ZIP）をダウンロード
+// GET /api/settings/data-export/:id/download
+func (c *DataExportController) DownloadExport(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	exportID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	resp, err := c.dataExportUC.OpenExport(ctx, userID.(uuid.UUID), exportID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	defer resp.Content.Close()
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.DataFromReader(http.StatusOK, -1, "application/zip", resp.Content, map[string]string{
+		"Content-Disposition": `attachment; filename="personal-data-` + resp.Export.RequestedAt.Format("20060102") + `.zip"`,
+	})
+}
@@ -0,0 +1,51 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// DBStatsController はDBのコネクションプールの状態のコントローラー
+type DBStatsController struct {
+	dbStatsUC inputport.DBStatsInputPort
+	presenter *presenter.DBStatsPresenter
+}
+
+// NewDBStatsController は新しいDBStatsControllerを作成
+func NewDBStatsController(
+	dbStatsUC inputport.DBStatsInputPort,
+	presenter *presenter.DBStatsPresenter,
+) *DBStatsController {
+	return &DBStatsController{
+		dbStatsUC: dbStatsUC,
+		presenter: presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *DBStatsController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/db-stats", c.GetDBStats)
+}
+
+// GetDBStats はこのインスタンスのコネクションプールの状態を取得
+// GET /api/admin/db-stats
+func (c *DBStatsController) GetDBStats(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	stats, err := c.dbStatsUC.GetDBStats(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentDBStats(stats))
+}
@@ -0,0 +1,215 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// DepartmentController は組織・部署のコントローラー
+type DepartmentController struct {
+	departmentUC inputport.DepartmentInputPort
+	presenter    *presenter.DepartmentPresenter
+}
+
+// NewDepartmentController は新しいDepartmentControllerを作成
+func NewDepartmentController(
+	departmentUC inputport.DepartmentInputPort,
+	presenter *presenter.DepartmentPresenter,
+) *DepartmentController {
+	return &DepartmentController{
+		departmentUC: departmentUC,
+		presenter:    presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *DepartmentController) RegisterRoutes(routes *RouteGroups) {
+	// 削除は子部署がない場合のみ、所属ユーザーは未所属になる
+	routes.Admin.GET("/departments", c.GetDepartments)
+	routes.Admin.POST("/departments", c.CreateDepartment)
+	routes.Admin.PUT("/departments/:id", c.UpdateDepartment)
+	routes.Admin.DELETE("/departments/:id", c.DeleteDepartment)
+	routes.Admin.PUT("/users/:id/department", c.AssignUserDepartment)
+	routes.Admin.GET("/analytics/departments", c.GetDepartmentAnalytics)
+}
+
+// departmentRequest は部署の作成・更新リクエストボディ
+type departmentRequest struct {
+	Name               string     `json:"name" binding:"required"`
+	ParentID           *uuid.UUID `json:"parent_id"`            // 省略・nullなら最上位の組織
+	MonthlyGrantBudget *int64     `json:"monthly_grant_budget"` // 省略・nullなら予算なし
+}
+
+// GetDepartments は全部署を取得
+// GET /api/admin/departments
+func (c *DepartmentController) GetDepartments(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	departments, err := c.departmentUC.ListDepartments(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentDepartmentList(departments))
+}
+
+// CreateDepartment は部署を作成
+// POST /api/admin/departments
+func (c *DepartmentController) CreateDepartment(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req departmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	department, err := c.departmentUC.CreateDepartment(ctx, &inputport.CreateDepartmentRequest{
+		AdminID:            adminID.(uuid.UUID),
+		Name:               req.Name,
+		ParentID:           req.ParentID,
+		MonthlyGrantBudget: req.MonthlyGrantBudget,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"department": c.presenter.PresentDepartment(department)})
+}
+
+// UpdateDepartment は部署名・親・月間予算を更新
+// PUT /api/admin/departments/:id
+func (c *DepartmentController) UpdateDepartment(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	departmentID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	var req departmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	department, err := c.departmentUC.UpdateDepartment(ctx, &inputport.UpdateDepartmentRequest{
+		AdminID:            adminID.(uuid.UUID),
+		DepartmentID:       departmentID,
+		Name:               req.Name,
+		ParentID:           req.ParentID,
+		MonthlyGrantBudget: req.MonthlyGrantBudget,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"department": c.presenter.PresentDepartment(department)})
+}
+
+// DeleteDepartment は部署を削除
+// DELETE /api/admin/departments/:id
+func (c *DepartmentController) DeleteDepartment(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	departmentID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	if err := c.departmentUC.DeleteDepartment(ctx, &inputport.DeleteDepartmentRequest{
+		AdminID:      adminID.(uuid.UUID),
+		DepartmentID: departmentID,
+	}); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "department deleted"})
+}
+
+// AssignUserDepartment はユーザーの所属部署を設定・解除
+// PUT /api/admin/users/:id/department
+func (c *DepartmentController) AssignUserDepartment(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	var req struct {
+		DepartmentID *uuid.UUID `json:"department_id"` // nullなら所属を解除
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := c.departmentUC.AssignUserDepartment(ctx, &inputport.AssignUserDepartmentRequest{
+		AdminID:      adminID.(uuid.UUID),
+		UserID:       userID,
+		DepartmentID: req.DepartmentID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentUserDepartment(user))
+}
+
+// GetDepartmentAnalytics は部署ごとの付与・利用ポイントを取得
+// GET /api/admin/analytics/departments?days=30
+func (c *DepartmentController) GetDepartmentAnalytics(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	days, _ := strconv.Atoi(ctx.DefaultQuery("days", "30"))
+
+	resp, err := c.departmentUC.GetDepartmentAnalytics(ctx, &inputport.GetDepartmentAnalyticsRequest{
+		AdminID: adminID.(uuid.UUID),
+		Days:    days,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentDepartmentAnalytics(resp))
+}
@@ -0,0 +1,218 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// EarningRuleController はポイント獲得ルール管理のコントローラー（管理者用）
+type EarningRuleController struct {
+	earningRuleUC inputport.EarningRuleInputPort
+	presenter     *presenter.EarningRulePresenter
+}
+
+// NewEarningRuleController は新しいEarningRuleControllerを作成
+func NewEarningRuleController(
+	earningRuleUC inputport.EarningRuleInputPort,
+	presenter *presenter.EarningRulePresenter,
+) *EarningRuleController {
+	return &EarningRuleController{
+		earningRuleUC: earningRuleUC,
+		presenter:     presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *EarningRuleController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/earning-rules", c.GetEarningRuleList)
+	routes.Admin.POST("/earning-rules", c.CreateEarningRule)
+	routes.Admin.GET("/earning-rules/:id", c.GetEarningRule)
+	routes.Admin.PUT("/earning-rules/:id", c.UpdateEarningRule)
+	routes.Admin.DELETE("/earning-rules/:id", c.DeleteEarningRule)
+}
+
+// earningRuleRequest はポイント獲得ルール作成・更新のリクエストボディ
+type earningRuleRequest struct {
+	Name             string     `json:"name" binding:"required"`
+	Trigger          string     `json:"trigger" binding:"required"`
+	Points           int64      `json:"points" binding:"required"`
+	Occurrence       int64      `json:"occurrence"`
+	MinAmount        int64      `json:"min_amount"`
+	OncePerUser      bool       `json:"once_per_user"`
+	MaxGrantsPerUser int        `json:"max_grants_per_user"`
+	MaxGrantsTotal   int        `json:"max_grants_total"`
+	StartsAt         *time.Time `json:"starts_at"`
+	EndsAt           *time.Time `json:"ends_at"`
+	IsActive         *bool      `json:"is_active"` // 更新時のみ（省略時は有効）
+}
+
+func (r *earningRuleRequest) toContent() inputport.EarningRuleContent {
+	content := inputport.EarningRuleContent{
+		Name:             r.Name,
+		Trigger:          entities.EarningTrigger(r.Trigger),
+		Points:           r.Points,
+		Occurrence:       r.Occurrence,
+		MinAmount:        r.MinAmount,
+		OncePerUser:      r.OncePerUser,
+		MaxGrantsPerUser: r.MaxGrantsPerUser,
+		MaxGrantsTotal:   r.MaxGrantsTotal,
+		EndsAt:           r.EndsAt,
+	}
+	if r.StartsAt != nil {
+		content.StartsAt = *r.StartsAt
+	}
+	return content
+}
+
+// GetEarningRuleList は期間外・無効を含むポイント獲得ルールの一覧を付与の実績つきで取得
+// GET /api/admin/earning-rules?offset=0&limit=20
+func (c *EarningRuleController) GetEarningRuleList(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
+
+	resp, err := c.earningRuleUC.GetEarningRuleList(ctx, &inputport.GetEarningRuleListRequest{
+		AdminID: adminID.(uuid.UUID),
+		Offset:  offset,
+		Limit:   limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentEarningRuleList(resp))
+}
+
+// GetEarningRule はポイント獲得ルールと付与の実績を取得
+// GET /api/admin/earning-rules/:id
+func (c *EarningRuleController) GetEarningRule(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	ruleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	rule, err := c.earningRuleUC.GetEarningRule(ctx, &inputport.GetEarningRuleRequest{
+		AdminID:       adminID.(uuid.UUID),
+		EarningRuleID: ruleID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"earning_rule": c.presenter.PresentEarningRuleWithStats(rule)})
+}
+
+// CreateEarningRule はポイント獲得ルールを作成
+// POST /api/admin/earning-rules
+func (c *EarningRuleController) CreateEarningRule(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req earningRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	rule, err := c.earningRuleUC.CreateEarningRule(ctx, &inputport.CreateEarningRuleRequest{
+		AdminID:            adminID.(uuid.UUID),
+		EarningRuleContent: req.toContent(),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"earning_rule": c.presenter.PresentEarningRule(rule)})
+}
+
+// UpdateEarningRule はポイント獲得ルールを更新
+// PUT /api/admin/earning-rules/:id
+func (c *EarningRuleController) UpdateEarningRule(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	ruleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	var req earningRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	rule, err := c.earningRuleUC.UpdateEarningRule(ctx, &inputport.UpdateEarningRuleRequest{
+		AdminID:            adminID.(uuid.UUID),
+		EarningRuleID:      ruleID,
+		IsActive:           isActive,
+		EarningRuleContent: req.toContent(),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"earning_rule": c.presenter.PresentEarningRule(rule)})
+}
+
+// DeleteEarningRule はポイント獲得ルールを削除
+// DELETE /api/admin/earning-rules/:id
+func (c *EarningRuleController) DeleteEarningRule(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	ruleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	if err := c.earningRuleUC.DeleteEarningRule(ctx, &inputport.DeleteEarningRuleRequest{
+		AdminID:       adminID.(uuid.UUID),
+		EarningRuleID: ruleID,
+	}); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "earning rule deleted"})
+}
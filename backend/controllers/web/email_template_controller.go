@@ -0,0 +1,235 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// EmailTemplateController はメールテンプレート管理のコントローラー（管理者用）
+type EmailTemplateController struct {
+	templateUC inputport.EmailTemplateInputPort
+	presenter  *presenter.EmailTemplatePresenter
+}
+
+// NewEmailTemplateController は新しいEmailTemplateControllerを作成
+func NewEmailTemplateController(
+	templateUC inputport.EmailTemplateInputPort,
+	presenter *presenter.EmailTemplatePresenter,
+) *EmailTemplateController {
+	return &EmailTemplateController{
+		templateUC: templateUC,
+		presenter:  presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *EmailTemplateController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/email-templates", c.ListTemplates)
+	routes.Admin.GET("/email-templates/:key", c.GetTemplate)
+	routes.Admin.PUT("/email-templates/:key", c.SaveTemplate)
+	routes.Admin.DELETE("/email-templates/:key", c.ResetTemplate)
+	routes.Admin.POST("/email-templates/:key/versions/:version/restore", c.RestoreVersion)
+	routes.Admin.POST("/email-templates/:key/preview", c.PreviewTemplate)
+	routes.Admin.POST("/email-templates/:key/test", c.SendTestEmail)
+}
+
+// emailTemplateBody はテンプレートの保存・プレビュー・テスト送信のリクエストボディ
+type emailTemplateBody struct {
+	Subject   string            `json:"subject"`
+	HTMLBody  string            `json:"html_body"`
+	TextBody  string            `json:"text_body"`
+	Variables map[string]string `json:"variables"` // プレビューのみ
+}
+
+// draft はボディにテンプレートがあれば下書きとして返す（なければ現在のテンプレートを使う）
+func (b *emailTemplateBody) draft() *inputport.EmailTemplateDraft {
+	if b.Subject == "" && b.HTMLBody == "" && b.TextBody == "" {
+		return nil
+	}
+	return &inputport.EmailTemplateDraft{Subject: b.Subject, HTMLBody: b.HTMLBody, TextBody: b.TextBody}
+}
+
+// bindOptionalBody はボディがあれば読み込む
+func (c *EmailTemplateController) bindOptionalBody(ctx *gin.Context, body *emailTemplateBody) bool {
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(body); err != nil {
+			respondError(ctx, http.StatusBadRequest, err)
+			return false
+		}
+	}
+	return true
+}
+
+// ListTemplates はメールの種類ごとに現在のテンプレートを取得
+// GET /api/admin/email-templates
+func (c *EmailTemplateController) ListTemplates(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	summaries, err := c.templateUC.ListTemplates(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentSummaries(summaries))
+}
+
+// GetTemplate は現在のテンプレートと過去の版を取得
+// GET /api/admin/email-templates/:key
+func (c *EmailTemplateController) GetTemplate(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	resp, err := c.templateUC.GetTemplate(ctx, adminID.(uuid.UUID), entities.EmailTemplateKey(ctx.Param("key")))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentDetail(resp))
+}
+
+// SaveTemplate はテンプレートを新しい版として保存
+// PUT /api/admin/email-templates/:key
+func (c *EmailTemplateController) SaveTemplate(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var body emailTemplateBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	template, err := c.templateUC.SaveTemplate(ctx, &inputport.SaveEmailTemplateRequest{
+		AdminID: adminID.(uuid.UUID),
+		Key:     entities.EmailTemplateKey(ctx.Param("key")),
+		EmailTemplateDraft: inputport.EmailTemplateDraft{
+			Subject:  body.Subject,
+			HTMLBody: body.HTMLBody,
+			TextBody: body.TextBody,
+		},
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"template": c.presenter.PresentTemplate(template)})
+}
+
+// RestoreVersion は過去の版の内容を新しい版として保存
+// POST /api/admin/email-templates/:key/versions/:version/restore
+func (c *EmailTemplateController) RestoreVersion(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	version, err := strconv.Atoi(ctx.Param("version"))
+	if err != nil || version < 1 {
+		respondError(ctx, http.StatusBadRequest, invalidParam("version", "version must be a positive integer"))
+		return
+	}
+
+	template, err := c.templateUC.RestoreVersion(ctx, &inputport.RestoreEmailTemplateRequest{
+		AdminID: adminID.(uuid.UUID),
+		Key:     entities.EmailTemplateKey(ctx.Param("key")),
+		Version: version,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"template": c.presenter.PresentTemplate(template)})
+}
+
+// ResetTemplate はすべての版を削除して組み込みの既定に戻す
+// DELETE /api/admin/email-templates/:key
+func (c *EmailTemplateController) ResetTemplate(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	if err := c.templateUC.ResetTemplate(ctx, adminID.(uuid.UUID), entities.EmailTemplateKey(ctx.Param("key"))); err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Email template reset to built-in default"})
+}
+
+// PreviewTemplate はテンプレートを例の変数で描画（ボディを省略すると現在のテンプレート）
+// POST /api/admin/email-templates/:key/preview
+func (c *EmailTemplateController) PreviewTemplate(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var body emailTemplateBody
+	if !c.bindOptionalBody(ctx, &body) {
+		return
+	}
+
+	email, err := c.templateUC.PreviewTemplate(ctx, &inputport.PreviewEmailTemplateRequest{
+		AdminID:   adminID.(uuid.UUID),
+		Key:       entities.EmailTemplateKey(ctx.Param("key")),
+		Draft:     body.draft(),
+		Variables: body.Variables,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"email": c.presenter.PresentRenderedEmail(email)})
+}
+
+// SendTestEmail はテンプレートを例の変数で描画し、管理者自身のアドレスへ送信
+// POST /api/admin/email-templates/:key/test
+func (c *EmailTemplateController) SendTestEmail(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var body emailTemplateBody
+	if !c.bindOptionalBody(ctx, &body) {
+		return
+	}
+
+	email, err := c.templateUC.SendTestEmail(ctx, &inputport.SendTestEmailRequest{
+		AdminID: adminID.(uuid.UUID),
+		Key:     entities.EmailTemplateKey(ctx.Param("key")),
+		Draft:   body.draft(),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"email": c.presenter.PresentRenderedEmail(email)})
+}
@@ -0,0 +1,106 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// EmailVerificationRequirementController は新規登録ユーザーのメール認証のコントローラー
+type EmailVerificationRequirementController struct {
+	requirementUC inputport.EmailVerificationRequirementInputPort
+	presenter     *presenter.EmailVerificationRequirementPresenter
+}
+
+// NewEmailVerificationRequirementController は新しいEmailVerificationRequirementControllerを作成
+func NewEmailVerificationRequirementController(
+	requirementUC inputport.EmailVerificationRequirementInputPort,
+	presenter *presenter.EmailVerificationRequirementPresenter,
+) *EmailVerificationRequirementController {
+	return &EmailVerificationRequirementController{
+		requirementUC: requirementUC,
+		presenter:     presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *EmailVerificationRequirementController) RegisterRoutes(routes *RouteGroups) {
+	// 認証前はログインできない設定もあるため、再送はログインせずに使えるようにする
+	routes.Public.POST("/auth/email/resend", c.ResendVerification)
+
+	routes.Admin.GET("/email-verification-requirement", c.GetRequirement)
+	routes.Admin.PUT("/email-verification-requirement", c.UpdateRequirement)
+}
+
+// ResendVerificationRequest は認証メールの再送リクエスト
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResendVerification は未認証のユーザーに認証メールを再送
+// POST /api/auth/email/resend
+func (c *EmailVerificationRequirementController) ResendVerification(ctx *gin.Context) {
+	var req ResendVerificationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := c.requirementUC.ResendVerification(ctx, &inputport.ResendEmailVerificationRequest{Email: req.Email}); err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, c.presenter.PresentResent())
+}
+
+// GetRequirement はメール認証を求める設定を取得
+// GET /api/admin/email-verification-requirement
+func (c *EmailVerificationRequirementController) GetRequirement(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	requirement, err := c.requirementUC.GetRequirement(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentRequirement(requirement))
+}
+
+// UpdateRequirement はメール認証を求める設定を更新
+// PUT /api/admin/email-verification-requirement
+func (c *EmailVerificationRequirementController) UpdateRequirement(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		Stage string `json:"stage" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	requirement, err := c.requirementUC.UpdateRequirement(ctx, &inputport.UpdateEmailVerificationRequirementRequest{
+		AdminID: adminID.(uuid.UUID),
+		Stage:   entities.EmailVerificationStage(req.Stage),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentRequirement(requirement))
+}
@@ -0,0 +1,19 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+)
+
+// respondError はエラーレスポンスをproblem+jsonで返す
+// ドメインエラーはエラーコードに応じたステータスと、Accept-Languageに応じたメッセージで返す
+// ドメインエラー以外はfallbackStatusで返す
+func respondError(ctx *gin.Context, fallbackStatus int, err error) {
+	presenter.RenderError(ctx, fallbackStatus, err)
+}
+
+// invalidParam はパスパラメータ・クエリなど1項目の不正を表す検証エラーを作成
+func invalidParam(field, message string) error {
+	return entities.NewValidationError(entities.FieldError{Field: field, Message: message})
+}
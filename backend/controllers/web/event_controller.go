@@ -0,0 +1,222 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// EventController はチェックインイベントのコントローラー
+type EventController struct {
+	eventUC   inputport.EventInputPort
+	presenter *presenter.EventPresenter
+}
+
+// NewEventController は新しいEventControllerを作成
+func NewEventController(
+	eventUC inputport.EventInputPort,
+	presenter *presenter.EventPresenter,
+) *EventController {
+	return &EventController{
+		eventUC:   eventUC,
+		presenter: presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *EventController) RegisterRoutes(routes *RouteGroups) {
+	// QRコードの読み取りでポイント付与（1人1回）
+	routes.Protected.POST("/events/check-in", c.CheckIn)
+
+	routes.Admin.GET("/events", c.GetEventList)
+	routes.Admin.POST("/events", c.CreateEvent)
+	routes.Admin.PUT("/events/:id", c.UpdateEvent)
+	routes.Admin.GET("/events/:id/attendees", c.GetEventAttendees)
+}
+
+// eventRequest はイベント作成・更新のリクエストボディ
+type eventRequest struct {
+	Name        string     `json:"name" binding:"required"`
+	Description string     `json:"description"`
+	Points      int64      `json:"points" binding:"required"`
+	Capacity    int        `json:"capacity"`
+	StartsAt    *time.Time `json:"starts_at"`
+	EndsAt      time.Time  `json:"ends_at" binding:"required"`
+	IsActive    *bool      `json:"is_active"` // 更新時のみ（省略時は有効）
+}
+
+func (r *eventRequest) toContent() inputport.EventContent {
+	content := inputport.EventContent{
+		Name:        r.Name,
+		Description: r.Description,
+		Points:      r.Points,
+		Capacity:    r.Capacity,
+		EndsAt:      r.EndsAt,
+	}
+	if r.StartsAt != nil {
+		content.StartsAt = *r.StartsAt
+	}
+	return content
+}
+
+// CheckIn はイベントのQRコードを読み取ってポイントを受け取る
+// POST /api/events/check-in
+func (c *EventController) CheckIn(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		Code string `json:"code" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := c.eventUC.CheckIn(ctx, &inputport.EventCheckInRequest{
+		UserID: userID.(uuid.UUID),
+		Code:   req.Code,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentCheckIn(resp))
+}
+
+// GetEventList は終了・無効を含むイベントの一覧を取得
+// GET /api/admin/events?offset=0&limit=20
+func (c *EventController) GetEventList(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
+
+	resp, err := c.eventUC.GetEventList(ctx, &inputport.GetEventListRequest{
+		AdminID: adminID.(uuid.UUID),
+		Offset:  offset,
+		Limit:   limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentEventList(resp))
+}
+
+// CreateEvent はイベントを作成
+// POST /api/admin/events
+func (c *EventController) CreateEvent(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req eventRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	event, err := c.eventUC.CreateEvent(ctx, &inputport.CreateEventRequest{
+		AdminID:      adminID.(uuid.UUID),
+		EventContent: req.toContent(),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"event": c.presenter.PresentEvent(event)})
+}
+
+// UpdateEvent はイベントを更新
+// PUT /api/admin/events/:id
+func (c *EventController) UpdateEvent(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	var req eventRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	event, err := c.eventUC.UpdateEvent(ctx, &inputport.UpdateEventRequest{
+		AdminID:      adminID.(uuid.UUID),
+		EventID:      eventID,
+		IsActive:     isActive,
+		EventContent: req.toContent(),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"event": c.presenter.PresentEvent(event)})
+}
+
+// GetEventAttendees はイベントの参加者の一覧を取得
+// GET /api/admin/events/:id/attendees?offset=0&limit=100
+func (c *EventController) GetEventAttendees(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 100)
+	if !ok {
+		return
+	}
+
+	resp, err := c.eventUC.GetEventAttendees(ctx, &inputport.GetEventAttendeesRequest{
+		AdminID: adminID.(uuid.UUID),
+		EventID: eventID,
+		Offset:  offset,
+		Limit:   limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentEventAttendees(resp))
+}
@@ -1,11 +1,12 @@
 package web
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/google/uuid"
 )
@@ -14,6 +15,7 @@ import (
 type FriendController struct {
 	friendshipUC inputport.FriendshipInputPort
 	userQueryUC  inputport.UserQueryInputPort
+	quickSendUC  inputport.QuickSendInputPort
 	presenter    *presenter.FriendPresenter
 }
 
@@ -21,21 +23,42 @@ type FriendController struct {
 func NewFriendController(
 	friendshipUC inputport.FriendshipInputPort,
 	userQueryUC inputport.UserQueryInputPort,
+	quickSendUC inputport.QuickSendInputPort,
 	presenter *presenter.FriendPresenter,
 ) *FriendController {
 	return &FriendController{
 		friendshipUC: friendshipUC,
 		userQueryUC:  userQueryUC,
+		quickSendUC:  quickSendUC,
 		presenter:    presenter,
 	}
 }
 
+// RegisterRoutes はルートを登録
+func (c *FriendController) RegisterRoutes(routes *RouteGroups) {
+	routes.Protected.GET("/users/search", c.SearchUserByUsername)
+	routes.Protected.GET("/users/:id", c.GetUserByID)
+
+	friends := routes.Protected.Group("/friends")
+	friends.POST("/requests", c.SendFriendRequest)
+	friends.GET("/requests/count", c.GetPendingRequestCount)
+	friends.POST("/requests/:id/accept", c.AcceptFriendRequest)
+	friends.POST("/requests/:id/reject", c.RejectFriendRequest)
+	friends.GET("", c.GetFriends)
+	friends.GET("/requests", c.GetPendingRequests)
+	friends.DELETE("/:id", c.RemoveFriend)
+	friends.GET("/:id/mutual", c.GetMutualFriends)
+	friends.GET("/quick-send", c.GetQuickSend)
+	friends.PUT("/:id/pin", c.PinFriend)
+	friends.DELETE("/:id/pin", c.UnpinFriend)
+}
+
 // SearchUserByUsername はユーザー名でユーザーを検索
 // GET /api/users/search?username=xxx
 func (c *FriendController) SearchUserByUsername(ctx *gin.Context) {
 	username := ctx.Query("username")
 	if username == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "username is required"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("username", "is required"))
 		return
 	}
 
@@ -43,7 +66,7 @@ func (c *FriendController) SearchUserByUsername(ctx *gin.Context) {
 		Username: username,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "ユーザーが見つかりません"})
+		respondError(ctx, http.StatusNotFound, entities.ErrUserNotFound)
 		return
 	}
 
@@ -54,6 +77,8 @@ func (c *FriendController) SearchUserByUsername(ctx *gin.Context) {
 			"display_name": resp.User.DisplayName,
 			"avatar_url":   resp.User.AvatarURL,
 			"avatar_type":  resp.User.AvatarType,
+			// 送る前に、友達からだけ受け取る相手かを分かるようにする
+			"accepts_transfers_from": resp.AcceptsTransfersFrom,
 		},
 	})
 }
@@ -64,7 +89,7 @@ func (c *FriendController) GetUserByID(ctx *gin.Context) {
 	userIDStr := ctx.Param("id")
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
 		return
 	}
 
@@ -72,7 +97,7 @@ func (c *FriendController) GetUserByID(ctx *gin.Context) {
 		UserID: userID,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "ユーザーが見つかりません"})
+		respondError(ctx, http.StatusNotFound, entities.ErrUserNotFound)
 		return
 	}
 
@@ -83,6 +108,8 @@ func (c *FriendController) GetUserByID(ctx *gin.Context) {
 			"display_name": resp.User.DisplayName,
 			"avatar_url":   resp.User.AvatarURL,
 			"avatar_type":  resp.User.AvatarType,
+			// 送る前に、友達からだけ受け取る相手かを分かるようにする
+			"accepts_transfers_from": resp.AcceptsTransfersFrom,
 		},
 	})
 }
@@ -93,7 +120,7 @@ func (c *FriendController) SendFriendRequest(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
@@ -102,14 +129,14 @@ func (c *FriendController) SendFriendRequest(ctx *gin.Context) {
 		AddresseeID string `json:"addressee_id" binding:"required"`
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	// UUID変換
 	addresseeID, err := uuid.Parse(req.AddresseeID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid addressee_id"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("addressee_id", "must be a valid ID"))
 		return
 	}
 
@@ -119,7 +146,7 @@ func (c *FriendController) SendFriendRequest(ctx *gin.Context) {
 		AddresseeID: addresseeID,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -133,14 +160,14 @@ func (c *FriendController) AcceptFriendRequest(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// パスパラメータ取得
 	friendshipID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid friendship_id"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
 		return
 	}
 
@@ -150,7 +177,7 @@ func (c *FriendController) AcceptFriendRequest(ctx *gin.Context) {
 		FriendshipID: friendshipID,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -164,14 +191,14 @@ func (c *FriendController) RejectFriendRequest(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// パスパラメータ取得
 	friendshipID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid friendship_id"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
 		return
 	}
 
@@ -181,7 +208,7 @@ func (c *FriendController) RejectFriendRequest(ctx *gin.Context) {
 		FriendshipID: friendshipID,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -195,16 +222,14 @@ func (c *FriendController) GetFriends(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// クエリパラメータ取得
-	var offset, limit int
-	fmt.Sscanf(ctx.Query("offset"), "%d", &offset)
-	fmt.Sscanf(ctx.Query("limit"), "%d", &limit)
-	if limit == 0 {
-		limit = 20
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
 	}
 
 	// ユースケース実行
@@ -214,7 +239,7 @@ func (c *FriendController) GetFriends(ctx *gin.Context) {
 		Limit:  limit,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -228,16 +253,14 @@ func (c *FriendController) GetPendingRequests(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// クエリパラメータ取得
-	var offset, limit int
-	fmt.Sscanf(ctx.Query("offset"), "%d", &offset)
-	fmt.Sscanf(ctx.Query("limit"), "%d", &limit)
-	if limit == 0 {
-		limit = 20
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
 	}
 
 	// ユースケース実行
@@ -247,7 +270,7 @@ func (c *FriendController) GetPendingRequests(ctx *gin.Context) {
 		Limit:  limit,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -261,14 +284,14 @@ func (c *FriendController) RemoveFriend(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// パスパラメータ取得
 	friendshipID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid friendship_id"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
 		return
 	}
 
@@ -278,7 +301,7 @@ func (c *FriendController) RemoveFriend(ctx *gin.Context) {
 		FriendshipID: friendshipID,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -286,13 +309,123 @@ func (c *FriendController) RemoveFriend(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, c.presenter.PresentRemoveFriend(resp))
 }
 
+// GetMutualFriends は相手のユーザーとの共通の友達を取得
+// GET /api/friends/:id/mutual（:idは相手のユーザーID）
+func (c *FriendController) GetMutualFriends(ctx *gin.Context) {
+	// ログインユーザー取得
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	// パスパラメータ取得
+	otherUserID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+	if otherUserID == userID.(uuid.UUID) {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be another user"))
+		return
+	}
+
+	// クエリパラメータ取得
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
+
+	// ユースケース実行
+	resp, err := c.friendshipUC.GetMutualFriends(ctx, &inputport.GetMutualFriendsRequest{
+		UserID:      userID.(uuid.UUID),
+		OtherUserID: otherUserID,
+		Offset:      offset,
+		Limit:       limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	// レスポンス生成
+	ctx.JSON(http.StatusOK, c.presenter.PresentGetMutualFriends(resp))
+}
+
+// GetQuickSend は友達ごとに最後に送った金額・よく送る金額を付けて取得（ピン留めした友達が先頭）
+// GET /api/friends/quick-send
+func (c *FriendController) GetQuickSend(ctx *gin.Context) {
+	// ログインユーザー取得
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	// クエリパラメータ取得
+	limit, ok := bindLimit(ctx, 20)
+	if !ok {
+		return
+	}
+
+	// ユースケース実行
+	entries, err := c.quickSendUC.GetQuickSend(ctx, &inputport.GetQuickSendRequest{
+		UserID: userID.(uuid.UUID),
+		Limit:  limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	// レスポンス生成
+	ctx.JSON(http.StatusOK, c.presenter.PresentQuickSend(entries))
+}
+
+// PinFriend は友達をクイック送金の先頭にピン留め
+// PUT /api/friends/:id/pin（:idは友達のユーザーID）
+func (c *FriendController) PinFriend(ctx *gin.Context) {
+	c.updatePin(ctx, c.quickSendUC.PinFriend, true)
+}
+
+// UnpinFriend は友達のピン留めを外す
+// DELETE /api/friends/:id/pin（:idは友達のユーザーID）
+func (c *FriendController) UnpinFriend(ctx *gin.Context) {
+	c.updatePin(ctx, c.quickSendUC.UnpinFriend, false)
+}
+
+func (c *FriendController) updatePin(ctx *gin.Context, update func(ctx context.Context, userID, friendID uuid.UUID) error, pinned bool) {
+	// ログインユーザー取得
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	// パスパラメータ取得
+	friendID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	// ユースケース実行
+	if err := update(ctx, userID.(uuid.UUID), friendID); err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	// レスポンス生成
+	ctx.JSON(http.StatusOK, gin.H{"friend_id": friendID, "pinned": pinned})
+}
+
 // GetPendingRequestCount は保留中の友達申請件数を取得
 // GET /api/friends/requests/count
 func (c *FriendController) GetPendingRequestCount(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
@@ -301,7 +434,7 @@ func (c *FriendController) GetPendingRequestCount(ctx *gin.Context) {
 		UserID: userID.(uuid.UUID),
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
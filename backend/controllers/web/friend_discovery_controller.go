@@ -0,0 +1,65 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// FriendDiscoveryController は連絡先ハッシュによる友達検索のコントローラー
+type FriendDiscoveryController struct {
+	friendDiscoveryUC inputport.FriendDiscoveryInputPort
+	presenter         *presenter.FriendPresenter
+}
+
+// NewFriendDiscoveryController は新しいFriendDiscoveryControllerを作成
+func NewFriendDiscoveryController(
+	friendDiscoveryUC inputport.FriendDiscoveryInputPort,
+	presenter *presenter.FriendPresenter,
+) *FriendDiscoveryController {
+	return &FriendDiscoveryController{
+		friendDiscoveryUC: friendDiscoveryUC,
+		presenter:         presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *FriendDiscoveryController) RegisterRoutes(routes *RouteGroups) {
+	routes.Protected.POST("/friends/discover", c.DiscoverFriends)
+}
+
+// DiscoverFriendsRequest は友達検索リクエスト
+type DiscoverFriendsRequest struct {
+	Hashes []string `json:"hashes" binding:"required"`
+}
+
+// DiscoverFriends はアプリの連絡先から登録済みのユーザーを探す
+// POST /api/friends/discover
+func (c *FriendDiscoveryController) DiscoverFriends(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req DiscoverFriendsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := c.friendDiscoveryUC.DiscoverFriends(ctx, &inputport.DiscoverFriendsRequest{
+		UserID: userID.(uuid.UUID),
+		Hashes: req.Hashes,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentDiscoverFriends(resp))
+}
@@ -0,0 +1,319 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// KioskController はキオスク端末機能のコントローラー
+type KioskController struct {
+	kioskUC   inputport.KioskInputPort
+	presenter *presenter.KioskPresenter
+}
+
+// NewKioskController は新しいKioskControllerを作成
+func NewKioskController(
+	kioskUC inputport.KioskInputPort,
+	presenter *presenter.KioskPresenter,
+) *KioskController {
+	return &KioskController{
+		kioskUC:   kioskUC,
+		presenter: presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *KioskController) RegisterRoutes(routes *RouteGroups) {
+	routes.Kiosk.POST("/lookup", c.LookupUser)
+	routes.Kiosk.POST("/grant", c.GrantBonus)
+
+	routes.Admin.GET("/kiosk/devices", c.ListDevices)
+	routes.Admin.POST("/kiosk/devices", c.RegisterDevice)
+	routes.Admin.PUT("/kiosk/devices/:id", c.UpdateDevice)
+	routes.Admin.POST("/kiosk/devices/:id/rotate-key", c.RotateDeviceKey)
+	routes.Admin.DELETE("/kiosk/devices/:id", c.DeactivateDevice)
+	routes.Admin.POST("/kiosk/cards", c.RegisterCard)
+	routes.Admin.DELETE("/kiosk/cards/:card_id", c.DeleteCard)
+}
+
+// ========================================
+// 端末向けAPI（X-Kiosk-Key認証）
+// ========================================
+
+// LookupUser は個人QRコードまたはカードIDでユーザーを検索
+// POST /api/kiosk/lookup
+func (c *KioskController) LookupUser(ctx *gin.Context) {
+	deviceID, exists := ctx.Get("kiosk_device_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		QRCode string `json:"qr_code"`
+		CardID string `json:"card_id"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := c.kioskUC.LookupUser(ctx, &inputport.KioskLookupUserRequest{
+		DeviceID: deviceID.(uuid.UUID),
+		QRCode:   req.QRCode,
+		CardID:   req.CardID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentLookupUser(resp))
+}
+
+// GrantBonus は端末に設定されたボーナスを付与
+// POST /api/kiosk/grant
+func (c *KioskController) GrantBonus(ctx *gin.Context) {
+	deviceID, exists := ctx.Get("kiosk_device_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		QRCode         string `json:"qr_code"`
+		CardID         string `json:"card_id"`
+		IdempotencyKey string `json:"idempotency_key" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := c.kioskUC.GrantBonus(ctx, &inputport.KioskGrantBonusRequest{
+		DeviceID:       deviceID.(uuid.UUID),
+		QRCode:         req.QRCode,
+		CardID:         req.CardID,
+		IdempotencyKey: req.IdempotencyKey,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentGrantBonus(resp))
+}
+
+// ========================================
+// 管理者向けAPI
+// ========================================
+
+// ListDevices は端末一覧を取得
+// GET /api/admin/kiosk/devices
+func (c *KioskController) ListDevices(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	resp, err := c.kioskUC.ListDevices(ctx, &inputport.ListKioskDevicesRequest{
+		AdminID: adminID.(uuid.UUID),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentListDevices(resp))
+}
+
+// RegisterDevice は端末を登録
+// POST /api/admin/kiosk/devices
+func (c *KioskController) RegisterDevice(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		Name        string `json:"name" binding:"required"`
+		BonusAmount int64  `json:"bonus_amount" binding:"required,gt=0"`
+		DailyLimit  int    `json:"daily_limit" binding:"min=0"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := c.kioskUC.RegisterDevice(ctx, &inputport.RegisterKioskDeviceRequest{
+		AdminID:     adminID.(uuid.UUID),
+		Name:        req.Name,
+		BonusAmount: req.BonusAmount,
+		DailyLimit:  req.DailyLimit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.presenter.PresentRegisterDevice(resp))
+}
+
+// UpdateDevice は端末設定を更新
+// PUT /api/admin/kiosk/devices/:id
+func (c *KioskController) UpdateDevice(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	deviceID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	var req struct {
+		Name        string `json:"name" binding:"required"`
+		BonusAmount int64  `json:"bonus_amount" binding:"required,gt=0"`
+		DailyLimit  int    `json:"daily_limit" binding:"min=0"`
+		IsActive    bool   `json:"is_active"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := c.kioskUC.UpdateDevice(ctx, &inputport.UpdateKioskDeviceRequest{
+		AdminID:     adminID.(uuid.UUID),
+		DeviceID:    deviceID,
+		Name:        req.Name,
+		BonusAmount: req.BonusAmount,
+		DailyLimit:  req.DailyLimit,
+		IsActive:    req.IsActive,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentUpdateDevice(resp))
+}
+
+// RotateDeviceKey は端末のAPIキーを再発行
+// POST /api/admin/kiosk/devices/:id/rotate-key
+func (c *KioskController) RotateDeviceKey(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	deviceID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	resp, err := c.kioskUC.RotateDeviceKey(ctx, &inputport.RotateKioskDeviceKeyRequest{
+		AdminID:  adminID.(uuid.UUID),
+		DeviceID: deviceID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentRegisterDevice(resp))
+}
+
+// DeactivateDevice は端末を無効化
+// DELETE /api/admin/kiosk/devices/:id
+func (c *KioskController) DeactivateDevice(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	deviceID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	if err := c.kioskUC.DeactivateDevice(ctx, &inputport.DeactivateKioskDeviceRequest{
+		AdminID:  adminID.(uuid.UUID),
+		DeviceID: deviceID,
+	}); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "端末を無効化しました"})
+}
+
+// RegisterCard はカードIDをユーザーに紐付け
+// POST /api/admin/kiosk/cards
+func (c *KioskController) RegisterCard(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		CardID string `json:"card_id" binding:"required"`
+		UserID string `json:"user_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("user_id", "must be a valid ID"))
+		return
+	}
+
+	card, err := c.kioskUC.RegisterCard(ctx, &inputport.RegisterKioskCardRequest{
+		AdminID: adminID.(uuid.UUID),
+		CardID:  req.CardID,
+		UserID:  userID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.presenter.PresentCard(card))
+}
+
+// DeleteCard はカードIDの紐付けを解除
+// DELETE /api/admin/kiosk/cards/:card_id
+func (c *KioskController) DeleteCard(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	if err := c.kioskUC.DeleteCard(ctx, &inputport.DeleteKioskCardRequest{
+		AdminID: adminID.(uuid.UUID),
+		CardID:  ctx.Param("card_id"),
+	}); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "カードの紐付けを解除しました"})
+}
@@ -0,0 +1,109 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// MaintenanceController はメンテナンスモードのコントローラー
+type MaintenanceController struct {
+	maintenanceUC inputport.MaintenanceInputPort
+	presenter     *presenter.MaintenancePresenter
+}
+
+// NewMaintenanceController は新しいMaintenanceControllerを作成
+func NewMaintenanceController(
+	maintenanceUC inputport.MaintenanceInputPort,
+	presenter *presenter.MaintenancePresenter,
+) *MaintenanceController {
+	return &MaintenanceController{
+		maintenanceUC: maintenanceUC,
+		presenter:     presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *MaintenanceController) RegisterRoutes(routes *RouteGroups) {
+	routes.Public.GET("/maintenance", c.GetStatus)
+
+	routes.Admin.GET("/maintenance", c.GetSettings)
+	routes.Admin.PUT("/maintenance", c.UpdateSettings)
+}
+
+// GetStatus はメンテナンス中かどうかを取得（フロントエンドのお知らせ表示用）
+// GET /api/maintenance
+func (c *MaintenanceController) GetStatus(ctx *gin.Context) {
+	mode, err := c.maintenanceUC.GetMaintenanceMode(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentStatus(mode))
+}
+
+// GetSettings は許可リストを含むメンテナンス設定を取得
+// GET /api/admin/maintenance
+func (c *MaintenanceController) GetSettings(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	mode, err := c.maintenanceUC.GetMaintenanceSettings(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentSettings(mode))
+}
+
+// UpdateSettings はメンテナンスモードを切り替える
+// PUT /api/admin/maintenance
+func (c *MaintenanceController) UpdateSettings(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		Enabled        bool     `json:"enabled"`
+		Message        string   `json:"message" binding:"max=500"`
+		AllowedUserIDs []string `json:"allowed_user_ids"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	allowed := make([]uuid.UUID, 0, len(req.AllowedUserIDs))
+	for _, s := range req.AllowedUserIDs {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			respondError(ctx, http.StatusBadRequest, invalidParam("allowed_user_ids", "must be a list of valid IDs"))
+			return
+		}
+		allowed = append(allowed, id)
+	}
+
+	resp, err := c.maintenanceUC.UpdateMaintenanceMode(ctx, &inputport.UpdateMaintenanceModeRequest{
+		AdminID:        adminID.(uuid.UUID),
+		Enabled:        req.Enabled,
+		Message:        req.Message,
+		AllowedUserIDs: allowed,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentSettings(resp.Mode))
+}
@@ -0,0 +1,48 @@
+package web
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// MetricsController は /metrics（OpenMetrics）のコントローラー
+// APIのバージョンやルートグループには載せず、ルーターが /health と同じくルートに登録する
+type MetricsController struct {
+	metricsUC inputport.BusinessMetricsInputPort
+	presenter *presenter.MetricsPresenter
+	token     string // 空でなければ Authorization: Bearer <token> を求める
+}
+
+// NewMetricsController は新しいMetricsControllerを作成
+func NewMetricsController(
+	metricsUC inputport.BusinessMetricsInputPort,
+	presenter *presenter.MetricsPresenter,
+	token string,
+) *MetricsController {
+	return &MetricsController{
+		metricsUC: metricsUC,
+		presenter: presenter,
+		token:     token,
+	}
+}
+
+// Metrics は最後に集計した業務の指標を返す
+// GET /metrics
+func (c *MetricsController) Metrics(ctx *gin.Context) {
+	if c.token != "" {
+		given, ok := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(c.token)) != 1 {
+			respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+			return
+		}
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, presenter.OpenMetricsContentType, []byte(c.presenter.PresentOpenMetrics(c.metricsUC.GetBusinessMetrics())))
+}
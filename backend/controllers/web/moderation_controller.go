@@ -0,0 +1,89 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// ModerationController はコンテンツモデレーション違反記録のコントローラー（管理者用）
+type ModerationController struct {
+	moderationUC inputport.ContentModerationInputPort
+	presenter    *presenter.ModerationPresenter
+}
+
+// NewModerationController は新しいModerationControllerを作成
+func NewModerationController(
+	moderationUC inputport.ContentModerationInputPort,
+	presenter *presenter.ModerationPresenter,
+) *ModerationController {
+	return &ModerationController{
+		moderationUC: moderationUC,
+		presenter:    presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *ModerationController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/moderation/violations", c.GetViolations)
+	routes.Admin.POST("/moderation/violations/:id/review", c.ReviewViolation)
+}
+
+// GetViolations は違反記録の一覧を取得
+// GET /api/admin/moderation/violations?unreviewed=true&offset=0&limit=20
+func (c *ModerationController) GetViolations(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
+
+	resp, err := c.moderationUC.GetViolations(ctx, &inputport.GetContentViolationsRequest{
+		AdminID:        adminID.(uuid.UUID),
+		UnreviewedOnly: ctx.Query("unreviewed") == "true",
+		Offset:         offset,
+		Limit:          limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentViolations(resp))
+}
+
+// ReviewViolation は違反記録をレビュー済みにする
+// POST /api/admin/moderation/violations/:id/review
+func (c *ModerationController) ReviewViolation(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	violationID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	violation, err := c.moderationUC.ReviewViolation(ctx, &inputport.ReviewContentViolationRequest{
+		AdminID:     adminID.(uuid.UUID),
+		ViolationID: violationID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"violation": c.presenter.PresentViolation(violation)})
+}
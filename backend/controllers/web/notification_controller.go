@@ -0,0 +1,173 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// NotificationController はプッシュ通知の端末登録・通知設定のコントローラー
+type NotificationController struct {
+	notificationUC inputport.NotificationInputPort
+	presenter      *presenter.NotificationPresenter
+}
+
+// NewNotificationController は新しいNotificationControllerを作成
+func NewNotificationController(
+	notificationUC inputport.NotificationInputPort,
+	presenter *presenter.NotificationPresenter,
+) *NotificationController {
+	return &NotificationController{
+		notificationUC: notificationUC,
+		presenter:      presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *NotificationController) RegisterRoutes(routes *RouteGroups) {
+	routes.Authenticated.GET("/settings/notifications", c.GetPreferences)
+	routes.Protected.POST("/settings/devices", c.RegisterDevice)
+	routes.Protected.DELETE("/settings/devices", c.UnregisterDevice)
+	routes.Protected.PUT("/settings/notifications", c.UpdatePreferences)
+}
+
+// RegisterDevice はプッシュ通知を受け取る端末を登録（アプリ起動時に毎回呼んでよい）
+// POST /api/settings/devices
+func (c *NotificationController) RegisterDevice(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		Platform string `json:"platform" binding:"required"`
+		Token    string `json:"token" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	device, err := c.notificationUC.RegisterDevice(ctx, &inputport.RegisterDeviceRequest{
+		UserID:   userID.(uuid.UUID),
+		Platform: entities.DevicePlatform(req.Platform),
+		Token:    req.Token,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.presenter.PresentDevice(device))
+}
+
+// UnregisterDevice は端末の登録を解除（ログアウト時など）
+// DELETE /api/settings/devices
+func (c *NotificationController) UnregisterDevice(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		Token string `json:"token" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	err := c.notificationUC.UnregisterDevice(ctx, &inputport.UnregisterDeviceRequest{
+		UserID: userID.(uuid.UUID),
+		Token:  req.Token,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "device unregistered"})
+}
+
+// GetPreferences は通知設定を取得
+// GET /api/settings/notifications
+func (c *NotificationController) GetPreferences(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	prefs, err := c.notificationUC.GetPreferences(ctx, userID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentPreferences(prefs))
+}
+
+// UpdatePreferences は通知設定を更新（指定した項目のみ）
+// PUT /api/settings/notifications
+func (c *NotificationController) UpdatePreferences(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		PushEnabled  *bool `json:"push_enabled"`
+		EmailEnabled *bool `json:"email_enabled"`
+		Events       struct {
+			TransferRequests *bool `json:"transfer_request_received"`
+			PointsReceived   *bool `json:"points_received"`
+			PointsExpiring   *bool `json:"points_expiring"`
+			NewLogin         *bool `json:"new_login"`
+			WeeklyDigest     *bool `json:"weekly_digest"`
+		} `json:"events"`
+		QuietHours *struct {
+			Enabled  bool   `json:"enabled"`
+			Start    string `json:"start"`
+			End      string `json:"end"`
+			Timezone string `json:"timezone"`
+		} `json:"quiet_hours"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	update := &inputport.UpdateNotificationPreferencesRequest{
+		UserID:           userID.(uuid.UUID),
+		PushEnabled:      req.PushEnabled,
+		EmailEnabled:     req.EmailEnabled,
+		TransferRequests: req.Events.TransferRequests,
+		PointsReceived:   req.Events.PointsReceived,
+		PointsExpiring:   req.Events.PointsExpiring,
+		NewLogin:         req.Events.NewLogin,
+		WeeklyDigest:     req.Events.WeeklyDigest,
+	}
+	if req.QuietHours != nil {
+		update.QuietHours = &inputport.QuietHoursRequest{
+			Enabled:  req.QuietHours.Enabled,
+			Start:    req.QuietHours.Start,
+			End:      req.QuietHours.End,
+			Timezone: req.QuietHours.Timezone,
+		}
+	}
+
+	prefs, err := c.notificationUC.UpdatePreferences(ctx, update)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentPreferences(prefs))
+}
@@ -0,0 +1,85 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// OnboardingBonusController はウェルカムボーナスの設定のコントローラー
+type OnboardingBonusController struct {
+	bonusUC   inputport.OnboardingBonusInputPort
+	presenter *presenter.OnboardingBonusPresenter
+}
+
+// NewOnboardingBonusController は新しいOnboardingBonusControllerを作成
+func NewOnboardingBonusController(
+	bonusUC inputport.OnboardingBonusInputPort,
+	presenter *presenter.OnboardingBonusPresenter,
+) *OnboardingBonusController {
+	return &OnboardingBonusController{
+		bonusUC:   bonusUC,
+		presenter: presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *OnboardingBonusController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/onboarding-bonus", c.GetPolicy)
+	routes.Admin.PUT("/onboarding-bonus", c.UpdatePolicy)
+}
+
+// GetPolicy はウェルカムボーナスの設定を取得
+// GET /api/admin/onboarding-bonus
+func (c *OnboardingBonusController) GetPolicy(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	policy, err := c.bonusUC.GetPolicy(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentPolicy(policy))
+}
+
+// UpdatePolicy はウェルカムボーナスの設定を更新
+// PUT /api/admin/onboarding-bonus
+func (c *OnboardingBonusController) UpdatePolicy(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		Enabled      bool  `json:"enabled"`
+		Amount       int64 `json:"amount"`
+		ValidityDays int   `json:"validity_days"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	policy, err := c.bonusUC.UpdatePolicy(ctx, &inputport.UpdateOnboardingBonusRequest{
+		AdminID:      adminID.(uuid.UUID),
+		Enabled:      req.Enabled,
+		Amount:       req.Amount,
+		ValidityDays: req.ValidityDays,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentPolicy(policy))
+}
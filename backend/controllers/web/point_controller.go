@@ -1,12 +1,13 @@
 package web
 
 import (
-	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/google/uuid"
 )
@@ -29,12 +30,38 @@ func NewPointController(
 	}
 }
 
+// WithPresenter はプレゼンターだけを差し替えたコントローラーを返す（APIバージョンごとの出力形式用）
+func (c *PointController) WithPresenter(presenter *presenter.PointPresenter) *PointController {
+	return &PointController{
+		pointTransferUC: c.pointTransferUC,
+		presenter:       presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *PointController) RegisterRoutes(routes *RouteGroups) {
+	points := routes.Protected.Group("/points")
+	points.POST("/transfer", func(ctx *gin.Context) {
+		c.Transfer(ctx, routes.Now())
+	})
+	points.GET("/transfer/quote", c.QuoteTransfer)
+	points.GET("/balance", func(ctx *gin.Context) {
+		c.GetBalance(ctx, routes.Now())
+	})
+	points.GET("/history", func(ctx *gin.Context) {
+		c.GetTransactionHistory(ctx, routes.Now())
+	})
+	points.GET("/expiring", func(ctx *gin.Context) {
+		c.GetExpiringPoints(ctx, routes.Now())
+	})
+}
+
 // TransferRequest はポイント転送リクエスト
 type TransferRequest struct {
 	ToUserID       string `json:"to_user_id" binding:"required,uuid"`
-	Amount         int64  `json:"amount" binding:"required,min=1"`
+	Amount         int64  `json:"amount" binding:"required,points"`
 	IdempotencyKey string `json:"idempotency_key" binding:"required"`
-	Description    string `json:"description"`
+	Description    string `json:"description" binding:"message"`
 }
 
 // Transfer はポイント転送
@@ -42,20 +69,20 @@ type TransferRequest struct {
 func (c *PointController) Transfer(ctx *gin.Context, currentTime time.Time) {
 	var req TransferRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	// 認証ユーザーIDを取得（ミドルウェアでセット済み）
 	fromUserID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	toUserID, err := uuid.Parse(req.ToUserID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid to_user_id"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("to_user_id", "must be a valid ID"))
 		return
 	}
 
@@ -69,7 +96,7 @@ func (c *PointController) Transfer(ctx *gin.Context, currentTime time.Time) {
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -78,12 +105,39 @@ func (c *PointController) Transfer(ctx *gin.Context, currentTime time.Time) {
 	ctx.JSON(http.StatusOK, output)
 }
 
+// QuoteTransfer は送金前に手数料と合計額を見積もる
+// GET /api/points/transfer/quote?amount=100
+func (c *PointController) QuoteTransfer(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	amount, err := strconv.ParseInt(ctx.Query("amount"), 10, 64)
+	if err != nil || amount <= 0 {
+		respondError(ctx, http.StatusBadRequest, invalidParam("amount", "must be a positive number"))
+		return
+	}
+
+	resp, err := c.pointTransferUC.QuoteTransfer(ctx, &inputport.QuoteTransferRequest{
+		FromUserID: userID.(uuid.UUID),
+		Amount:     amount,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentQuoteResponse(resp))
+}
+
 // GetBalance は残高を取得
 // GET /api/points/balance
 func (c *PointController) GetBalance(ctx *gin.Context, currentTime time.Time) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
@@ -92,7 +146,7 @@ func (c *PointController) GetBalance(ctx *gin.Context, currentTime time.Time) {
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -105,28 +159,25 @@ func (c *PointController) GetBalance(ctx *gin.Context, currentTime time.Time) {
 func (c *PointController) GetTransactionHistory(ctx *gin.Context, currentTime time.Time) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// ページネーション
-	offset := 0
-	limit := 20
-	if ctx.Query("offset") != "" {
-		fmt.Sscanf(ctx.Query("offset"), "%d", &offset)
-	}
-	if ctx.Query("limit") != "" {
-		fmt.Sscanf(ctx.Query("limit"), "%d", &limit)
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
 	}
 
 	resp, err := c.pointTransferUC.GetTransactionHistory(ctx, &inputport.GetTransactionHistoryRequest{
-		UserID: userID.(uuid.UUID),
-		Offset: offset,
-		Limit:  limit,
+		UserID:     userID.(uuid.UUID),
+		ReasonCode: ctx.Query("reason_code"),
+		Offset:     offset,
+		Limit:      limit,
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -139,7 +190,7 @@ func (c *PointController) GetTransactionHistory(ctx *gin.Context, currentTime ti
 func (c *PointController) GetExpiringPoints(ctx *gin.Context, currentTime time.Time) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
@@ -148,7 +199,7 @@ func (c *PointController) GetExpiringPoints(ctx *gin.Context, currentTime time.T
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -0,0 +1,370 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// PointExpiryPolicyController はポイント有効期間設定と失効取り消しのコントローラー（管理者用）
+type PointExpiryPolicyController struct {
+	policyUC  inputport.PointExpiryPolicyInputPort
+	presenter *presenter.PointExpiryPolicyPresenter
+}
+
+// NewPointExpiryPolicyController は新しいPointExpiryPolicyControllerを作成
+func NewPointExpiryPolicyController(
+	policyUC inputport.PointExpiryPolicyInputPort,
+	presenter *presenter.PointExpiryPolicyPresenter,
+) *PointExpiryPolicyController {
+	return &PointExpiryPolicyController{
+		policyUC:  policyUC,
+		presenter: presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *PointExpiryPolicyController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/expiry-policies", c.GetPolicies)
+	routes.Admin.PUT("/expiry-policies/grace-period", c.UpdateGracePeriod)
+	routes.Admin.PUT("/expiry-policies/:source_type", c.UpdatePolicy)
+	routes.Admin.DELETE("/expiry-policies/:source_type", c.DeletePolicy)
+	routes.Admin.GET("/users/:id/expiry-override", c.GetUserOverride)
+	routes.Admin.PUT("/users/:id/expiry-override", c.SetUserOverride)
+	routes.Admin.DELETE("/users/:id/expiry-override", c.DeleteUserOverride)
+	routes.Admin.GET("/point-batches/expired", c.ListRestorableBatches)
+	routes.Admin.POST("/point-batches/:id/restore", c.RestoreBatch)
+	routes.Admin.PATCH("/point-batches/:id", c.AdjustBatch)
+	routes.Admin.GET("/users/:id/point-batches", c.ListUserBatches)
+	routes.Admin.GET("/point-expiry/status", c.GetWorkerStatus)
+}
+
+// GetPolicies は付与種別ごとの有効期間と猶予日数を取得
+// GET /api/admin/expiry-policies
+func (c *PointExpiryPolicyController) GetPolicies(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	resp, err := c.policyUC.GetPolicies(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentPolicies(resp))
+}
+
+// UpdatePolicy は付与種別の有効期間を設定
+// PUT /api/admin/expiry-policies/:source_type
+func (c *PointExpiryPolicyController) UpdatePolicy(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		ValidityDays int `json:"validity_days" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	policy, err := c.policyUC.UpdatePolicy(ctx, &inputport.UpdateExpiryPolicyRequest{
+		AdminID:      adminID.(uuid.UUID),
+		SourceType:   entities.PointBatchSourceType(ctx.Param("source_type")),
+		ValidityDays: req.ValidityDays,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentPolicy(policy))
+}
+
+// DeletePolicy は付与種別の有効期間を既定に戻す
+// DELETE /api/admin/expiry-policies/:source_type
+func (c *PointExpiryPolicyController) DeletePolicy(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	sourceType := entities.PointBatchSourceType(ctx.Param("source_type"))
+	if err := c.policyUC.DeletePolicy(ctx, adminID.(uuid.UUID), sourceType); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "expiry policy deleted"})
+}
+
+// UpdateGracePeriod は失効を取り消せる猶予日数を設定
+// PUT /api/admin/expiry-policies/grace-period
+func (c *PointExpiryPolicyController) UpdateGracePeriod(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		GraceDays *int `json:"grace_days" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := c.policyUC.UpdateGracePeriod(ctx, adminID.(uuid.UUID), *req.GraceDays); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"grace_days": *req.GraceDays})
+}
+
+// GetUserOverride はユーザー個別の有効期間を取得
+// GET /api/admin/users/:id/expiry-override
+func (c *PointExpiryPolicyController) GetUserOverride(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	override, err := c.policyUC.GetUserOverride(ctx, adminID.(uuid.UUID), userID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentUserOverride(override))
+}
+
+// SetUserOverride はユーザー個別の有効期間を設定
+// PUT /api/admin/users/:id/expiry-override
+func (c *PointExpiryPolicyController) SetUserOverride(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	var req struct {
+		ValidityDays int    `json:"validity_days" binding:"required"`
+		Reason       string `json:"reason" binding:"max=500"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	override, err := c.policyUC.SetUserOverride(ctx, &inputport.SetUserExpiryOverrideRequest{
+		AdminID:      adminID.(uuid.UUID),
+		UserID:       userID,
+		ValidityDays: req.ValidityDays,
+		Reason:       req.Reason,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentUserOverride(override))
+}
+
+// DeleteUserOverride はユーザー個別の有効期間を削除
+// DELETE /api/admin/users/:id/expiry-override
+func (c *PointExpiryPolicyController) DeleteUserOverride(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	if err := c.policyUC.DeleteUserOverride(ctx, adminID.(uuid.UUID), userID); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "expiry override deleted"})
+}
+
+// ListRestorableBatches は取り消し可能な失効済みバッチを取得
+// GET /api/admin/point-batches/expired?user_id=...&limit=50
+func (c *PointExpiryPolicyController) ListRestorableBatches(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	req := &inputport.ListRestorableBatchesRequest{AdminID: adminID.(uuid.UUID)}
+	if s := ctx.Query("user_id"); s != "" {
+		userID, err := uuid.Parse(s)
+		if err != nil {
+			respondError(ctx, http.StatusBadRequest, invalidParam("user_id", "must be a valid ID"))
+			return
+		}
+		req.UserID = &userID
+	}
+	limit, ok := bindLimit(ctx, 0)
+	if !ok {
+		return
+	}
+	req.Limit = limit
+
+	resp, err := c.policyUC.ListRestorableBatches(ctx, req)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentRestorableBatches(resp))
+}
+
+// RestoreBatch は失効を取り消し、失効したポイントを戻す
+// POST /api/admin/point-batches/:id/restore
+func (c *PointExpiryPolicyController) RestoreBatch(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	batchID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	resp, err := c.policyUC.RestoreBatch(ctx, &inputport.RestoreBatchRequest{
+		AdminID: adminID.(uuid.UUID),
+		BatchID: batchID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentRestore(resp))
+}
+
+// ListUserBatches はユーザーのすべてのポイントバッチを調整の記録つきで取得
+// GET /api/admin/users/:id/point-batches?offset=0&limit=50
+func (c *PointExpiryPolicyController) ListUserBatches(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	req := &inputport.ListUserBatchesRequest{AdminID: adminID.(uuid.UUID), UserID: userID}
+	offset, limit, ok := bindPage(ctx, 0)
+	if !ok {
+		return
+	}
+	req.Offset, req.Limit = offset, limit
+
+	resp, err := c.policyUC.ListUserBatches(ctx, req)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentUserBatches(resp))
+}
+
+// AdjustBatch はポイントバッチの有効期限を変更するか、バッチを取り消す
+// PATCH /api/admin/point-batches/:id
+func (c *PointExpiryPolicyController) AdjustBatch(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	batchID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	var req struct {
+		ExpiresAt *time.Time `json:"expires_at"`
+		Cancel    bool       `json:"cancel"`
+		Reason    string     `json:"reason" binding:"required,max=500"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := c.policyUC.AdjustBatch(ctx, &inputport.AdjustBatchRequest{
+		AdminID:   adminID.(uuid.UUID),
+		BatchID:   batchID,
+		ExpiresAt: req.ExpiresAt,
+		Cancel:    req.Cancel,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentAdjustBatch(resp))
+}
+
+// GetWorkerStatus はポイント有効期限ワーカーの直近の実行状況を取得
+// GET /api/admin/point-expiry/status
+func (c *PointExpiryPolicyController) GetWorkerStatus(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	run, err := c.policyUC.GetWorkerStatus(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentWorkerStatus(run))
+}
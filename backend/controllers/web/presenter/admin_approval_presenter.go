@@ -0,0 +1,77 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// AdminApprovalPresenter は大口の付与・減算の承認のPresenter
+type AdminApprovalPresenter struct{}
+
+// NewAdminApprovalPresenter は新しいAdminApprovalPresenterを作成
+func NewAdminApprovalPresenter() *AdminApprovalPresenter {
+	return &AdminApprovalPresenter{}
+}
+
+// PresentThreshold は承認が必要になる金額をJSON形式に変換
+func (p *AdminApprovalPresenter) PresentThreshold(threshold int64) gin.H {
+	return gin.H{
+		"threshold": threshold,
+		"enabled":   threshold > 0,
+	}
+}
+
+// PresentList は承認待ちの操作一覧をJSON形式に変換
+func (p *AdminApprovalPresenter) PresentList(resp *inputport.ListPendingActionsResponse) gin.H {
+	actions := make([]gin.H, 0, len(resp.Actions))
+	for _, action := range resp.Actions {
+		actions = append(actions, presentPendingAdminAction(action))
+	}
+	return gin.H{
+		"pending_actions": actions,
+		"total":           resp.Total,
+	}
+}
+
+// PresentDetail は操作と監査記録をJSON形式に変換
+func (p *AdminApprovalPresenter) PresentDetail(detail *inputport.PendingActionDetail) gin.H {
+	events := make([]gin.H, 0, len(detail.Events))
+	for _, e := range detail.Events {
+		events = append(events, gin.H{
+			"id":         e.ID,
+			"event":      e.Event,
+			"actor_id":   e.ActorID,
+			"comment":    e.Comment,
+			"created_at": e.CreatedAt,
+		})
+	}
+	action := presentPendingAdminAction(detail.Action)
+	action["events"] = events
+	return gin.H{"pending_action": action}
+}
+
+// presentPendingAdminAction は承認待ちの操作をJSON形式に変換（付与・減算のレスポンスでも使う）
+func presentPendingAdminAction(a *entities.PendingAdminAction) gin.H {
+	return gin.H{
+		"id":              a.ID,
+		"action_type":     a.ActionType,
+		"requested_by":    a.RequestedBy,
+		"target_user_id":  a.TargetUserID,
+		"amount":          a.Amount,
+		"description":     a.Description,
+		"reason_code":     a.ReasonCode,
+		"tag":             a.Tag,
+		"idempotency_key": a.IdempotencyKey,
+		"budget_override": a.BudgetOverride,
+		"status":          a.Status,
+		"reviewed_by":     a.ReviewedBy,
+		"review_comment":  a.ReviewComment,
+		"reviewed_at":     a.ReviewedAt,
+		"transaction_id":  a.TransactionID,
+		"failure_reason":  a.FailureReason,
+		"expires_at":      a.ExpiresAt,
+		"created_at":      a.CreatedAt,
+		"updated_at":      a.UpdatedAt,
+	}
+}
@@ -0,0 +1,52 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// AdminDashboardPresenter は管理者ダッシュボードのPresenter
+type AdminDashboardPresenter struct{}
+
+// NewAdminDashboardPresenter は新しいAdminDashboardPresenterを作成
+func NewAdminDashboardPresenter() *AdminDashboardPresenter {
+	return &AdminDashboardPresenter{}
+}
+
+// PresentDashboard はダッシュボードをJSON形式に変換
+func (p *AdminDashboardPresenter) PresentDashboard(d *entities.AdminDashboard) gin.H {
+	status := "up"
+	if !d.Database.Healthy {
+		status = "down"
+	}
+	database := gin.H{
+		"status":     status,
+		"latency_ms": d.Database.Latency.Milliseconds(),
+	}
+	if d.Database.Error != "" {
+		database["error"] = d.Database.Error
+	}
+
+	h := gin.H{
+		"database":     database,
+		"generated_at": d.GeneratedAt,
+	}
+	if c := d.Counters; c != nil {
+		h["counters"] = gin.H{
+			"online_users":              c.OnlineUsers,
+			"transfers_last_hour":       c.TransfersLastHour,
+			"pending_transfer_requests": c.PendingTransferRequests,
+			"failed_worker_runs": gin.H{
+				"scheduled_jobs":       c.FailedScheduledJobs,
+				"akerun_repolls":       c.FailedAkerunRepolls,
+				"dead_akerun_accesses": c.DeadAkerunAccesses,
+			},
+			"upcoming_expirations": gin.H{
+				"points": c.ExpiringPoints,
+				"users":  c.ExpiringUsers,
+				"days":   int(entities.DashboardExpiryWindow.Hours() / 24),
+			},
+		}
+	}
+	return h
+}
@@ -1,6 +1,7 @@
 package presenter
 
 import (
+	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 )
 
@@ -14,7 +15,7 @@ func NewAdminPresenter() *AdminPresenter {
 
 // PresentGrantPoints はポイント付与レスポンスを生成
 func (p *AdminPresenter) PresentGrantPoints(resp *inputport.GrantPointsResponse) map[string]interface{} {
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		"transaction": TransactionResponse{
 			ID:              resp.Transaction.ID,
 			FromUserID:      resp.Transaction.FromUserID,
@@ -23,6 +24,8 @@ func (p *AdminPresenter) PresentGrantPoints(resp *inputport.GrantPointsResponse)
 			TransactionType: string(resp.Transaction.TransactionType),
 			Status:          string(resp.Transaction.Status),
 			Description:     resp.Transaction.Description,
+			ReasonCode:      resp.Transaction.ReasonCode,
+			Tag:             resp.Transaction.Tag,
 			CreatedAt:       resp.Transaction.CreatedAt,
 		},
 		"user": UserResponse{
@@ -33,15 +36,30 @@ func (p *AdminPresenter) PresentGrantPoints(resp *inputport.GrantPointsResponse)
 			Balance:     resp.User.Balance,
 			Role:        string(resp.User.Role),
 			IsActive:    resp.User.IsActive,
+			Tier:        string(resp.User.CurrentTier()),
 			CreatedAt:   resp.User.CreatedAt,
 			UpdatedAt:   resp.User.UpdatedAt,
 		},
+		"dry_run": resp.DryRun,
+		"budgets": presentBudgetStatuses(resp.Budgets),
+	}
+	if resp.DryRun {
+		result["requires_approval"] = resp.RequiresApproval
 	}
+	// ドライランでは作成されるはずだったバッチの有効期限を返す
+	if resp.DryRun && resp.Batch != nil {
+		result["point_batch"] = map[string]interface{}{
+			"amount":      resp.Batch.OriginalAmount,
+			"source_type": resp.Batch.SourceType,
+			"expires_at":  resp.Batch.ExpiresAt,
+		}
+	}
+	return result
 }
 
 // PresentDeductPoints はポイント減算レスポンスを生成
 func (p *AdminPresenter) PresentDeductPoints(resp *inputport.DeductPointsResponse) map[string]interface{} {
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		"transaction": TransactionResponse{
 			ID:              resp.Transaction.ID,
 			FromUserID:      resp.Transaction.FromUserID,
@@ -50,6 +68,8 @@ func (p *AdminPresenter) PresentDeductPoints(resp *inputport.DeductPointsRespons
 			TransactionType: string(resp.Transaction.TransactionType),
 			Status:          string(resp.Transaction.Status),
 			Description:     resp.Transaction.Description,
+			ReasonCode:      resp.Transaction.ReasonCode,
+			Tag:             resp.Transaction.Tag,
 			CreatedAt:       resp.Transaction.CreatedAt,
 		},
 		"user": UserResponse{
@@ -60,9 +80,36 @@ func (p *AdminPresenter) PresentDeductPoints(resp *inputport.DeductPointsRespons
 			Balance:     resp.User.Balance,
 			Role:        string(resp.User.Role),
 			IsActive:    resp.User.IsActive,
+			Tier:        string(resp.User.CurrentTier()),
 			CreatedAt:   resp.User.CreatedAt,
 			UpdatedAt:   resp.User.UpdatedAt,
 		},
+		"dry_run": resp.DryRun,
+	}
+	// ドライランではどのバッチからいくつ引かれるかを返す
+	if resp.DryRun {
+		plan := make([]map[string]interface{}, 0, len(resp.ConsumptionPlan))
+		for _, c := range resp.ConsumptionPlan {
+			plan = append(plan, map[string]interface{}{
+				"batch_id":        c.BatchID,
+				"source_type":     c.SourceType,
+				"expires_at":      c.ExpiresAt,
+				"consumed":        c.Consumed,
+				"remaining_after": c.RemainingAfter,
+			})
+		}
+		result["consumption_plan"] = plan
+		result["uncovered_amount"] = resp.UncoveredAmount
+		result["requires_approval"] = resp.RequiresApproval
+	}
+	return result
+}
+
+// PresentPendingAction は承認待ちになった付与・減算のレスポンスを生成
+func (p *AdminPresenter) PresentPendingAction(action *entities.PendingAdminAction) map[string]interface{} {
+	return map[string]interface{}{
+		"requires_approval": true,
+		"pending_action":    presentPendingAdminAction(action),
 	}
 }
 
@@ -71,15 +118,17 @@ func (p *AdminPresenter) PresentListAllUsers(resp *inputport.ListAllUsersRespons
 	users := make([]UserResponse, 0, len(resp.Users))
 	for _, user := range resp.Users {
 		users = append(users, UserResponse{
-			ID:          user.ID,
-			Username:    user.Username,
-			DisplayName: user.DisplayName,
-			AvatarURL:   user.AvatarURL,
-			Balance:     user.Balance,
-			Role:        string(user.Role),
-			IsActive:    user.IsActive,
-			CreatedAt:   user.CreatedAt,
-			UpdatedAt:   user.UpdatedAt,
+			ID:           user.ID,
+			Username:     user.Username,
+			DisplayName:  user.DisplayName,
+			AvatarURL:    user.AvatarURL,
+			Balance:      user.Balance,
+			Role:         string(user.Role),
+			IsActive:     user.IsActive,
+			Tier:         string(user.CurrentTier()),
+			DepartmentID: user.DepartmentID,
+			CreatedAt:    user.CreatedAt,
+			UpdatedAt:    user.UpdatedAt,
 		})
 	}
 
@@ -102,6 +151,9 @@ func (p *AdminPresenter) PresentListAllTransactions(resp *inputport.ListAllTrans
 			TransactionType: string(tx.TransactionType),
 			Status:          string(tx.Status),
 			Description:     tx.Description,
+			ReasonCode:      tx.ReasonCode,
+			Tag:             tx.Tag,
+			Migrated:        tx.Migrated(),
 			CreatedAt:       tx.CreatedAt,
 		}
 
@@ -118,6 +170,8 @@ func (p *AdminPresenter) PresentListAllTransactions(resp *inputport.ListAllTrans
 				CreatedAt:   txWithUsers.FromUser.CreatedAt,
 				UpdatedAt:   txWithUsers.FromUser.UpdatedAt,
 			}
+		} else if tx.FromUserDeleted() {
+			txResp.FromUser = deletedUserResponse()
 		}
 
 		// 受信者情報を追加
@@ -133,6 +187,8 @@ func (p *AdminPresenter) PresentListAllTransactions(resp *inputport.ListAllTrans
 				CreatedAt:   txWithUsers.ToUser.CreatedAt,
 				UpdatedAt:   txWithUsers.ToUser.UpdatedAt,
 			}
+		} else if tx.ToUserDeleted() {
+			txResp.ToUser = deletedUserResponse()
 		}
 
 		transactions = append(transactions, txResp)
@@ -155,6 +211,7 @@ func (p *AdminPresenter) PresentUpdateUserRole(resp *inputport.UpdateUserRoleRes
 			Balance:     resp.User.Balance,
 			Role:        string(resp.User.Role),
 			IsActive:    resp.User.IsActive,
+			Tier:        string(resp.User.CurrentTier()),
 			CreatedAt:   resp.User.CreatedAt,
 			UpdatedAt:   resp.User.UpdatedAt,
 		},
@@ -172,6 +229,7 @@ func (p *AdminPresenter) PresentDeactivateUser(resp *inputport.DeactivateUserRes
 			Balance:     resp.User.Balance,
 			Role:        string(resp.User.Role),
 			IsActive:    resp.User.IsActive,
+			Tier:        string(resp.User.CurrentTier()),
 			CreatedAt:   resp.User.CreatedAt,
 			UpdatedAt:   resp.User.UpdatedAt,
 		},
@@ -210,6 +268,27 @@ func (p *AdminPresenter) PresentAnalytics(resp *inputport.GetAnalyticsResponse)
 		})
 	}
 
+	reasonBreakdown := make([]map[string]interface{}, 0, len(resp.ReasonCodeBreakdown))
+	for _, r := range resp.ReasonCodeBreakdown {
+		reasonBreakdown = append(reasonBreakdown, map[string]interface{}{
+			"reason_code":      r.ReasonCode,
+			"label":            r.Label,
+			"transaction_type": r.TransactionType,
+			"count":            r.Count,
+			"total_amount":     r.TotalAmount,
+		})
+	}
+
+	systemAccounts := make([]map[string]interface{}, 0, len(resp.Conservation.Accounts))
+	for _, a := range resp.Conservation.Accounts {
+		systemAccounts = append(systemAccounts, map[string]interface{}{
+			"account":  a.Account,
+			"credited": a.Credited,
+			"debited":  a.Debited,
+			"balance":  a.Balance(),
+		})
+	}
+
 	return map[string]interface{}{
 		"summary": map[string]interface{}{
 			"total_points_in_circulation": resp.Summary.TotalPointsInCirculation,
@@ -221,5 +300,88 @@ func (p *AdminPresenter) PresentAnalytics(resp *inputport.GetAnalyticsResponse)
 		"top_holders":                topHolders,
 		"daily_stats":                dailyStats,
 		"transaction_type_breakdown": typeBreakdown,
+		"reason_code_breakdown":      reasonBreakdown,
+		"system_accounts":            systemAccounts,
+		"conservation": map[string]interface{}{
+			"user_balance_total":   resp.Conservation.UserBalanceTotal,
+			"system_balance_total": resp.Conservation.SystemBalanceTotal(),
+			"difference":           resp.Conservation.Difference(),
+			"conserved":            resp.Conservation.Conserved(),
+		},
+	}
+}
+
+// PresentCohortAnalytics はコホート分析レスポンスを生成
+func (p *AdminPresenter) PresentCohortAnalytics(resp *inputport.GetCohortAnalyticsResponse) map[string]interface{} {
+	activeUsers := make([]map[string]interface{}, 0, len(resp.ActiveUsers))
+	for _, a := range resp.ActiveUsers {
+		activeUsers = append(activeUsers, map[string]interface{}{
+			"period_start": a.PeriodStart.Format("2006-01-02"),
+			"active_users": a.ActiveUsers,
+		})
+	}
+
+	cohorts := make([]map[string]interface{}, 0, len(resp.Cohorts))
+	for _, c := range resp.Cohorts {
+		retention := make([]map[string]interface{}, 0, len(c.Retention))
+		for _, r := range c.Retention {
+			retention = append(retention, map[string]interface{}{
+				"period":       r.Period,
+				"active_users": r.ActiveUsers,
+				"percentage":   r.Percentage,
+			})
+		}
+		cohorts = append(cohorts, map[string]interface{}{
+			"cohort_start": c.CohortStart.Format("2006-01-02"),
+			"size":         c.Size,
+			"retention":    retention,
+		})
+	}
+
+	featureUsage := make([]map[string]interface{}, 0, len(resp.FeatureUsage))
+	for _, f := range resp.FeatureUsage {
+		featureUsage = append(featureUsage, map[string]interface{}{
+			"feature":      f.Feature,
+			"count":        f.Count,
+			"users":        f.Users,
+			"total_amount": f.TotalAmount,
+			"percentage":   f.Percentage,
+		})
+	}
+
+	return map[string]interface{}{
+		"date_from":     resp.From.Format("2006-01-02"),
+		"date_to":       resp.To.AddDate(0, 0, -1).Format("2006-01-02"),
+		"granularity":   resp.Granularity,
+		"basis":         resp.Basis,
+		"active_users":  activeUsers,
+		"cohorts":       cohorts,
+		"feature_usage": featureUsage,
+	}
+}
+
+// PresentForecast は失効予測・流通速度レスポンスを生成
+func (p *AdminPresenter) PresentForecast(resp *inputport.GetForecastResponse) map[string]interface{} {
+	forecast := make([]map[string]interface{}, 0, len(resp.ExpiryForecast))
+	for _, w := range resp.ExpiryForecast {
+		forecast = append(forecast, map[string]interface{}{
+			"week_start": w.WeekStart.Format("2006-01-02"),
+			"week_end":   w.WeekEnd.AddDate(0, 0, -1).Format("2006-01-02"),
+			"amount":     w.Amount,
+			"users":      w.Users,
+			"batches":    w.Batches,
+		})
+	}
+
+	return map[string]interface{}{
+		"expiry_forecast":     forecast,
+		"total_expiring":      resp.TotalExpiring,
+		"outstanding_points":  resp.OutstandingPoints,
+		"expiring_percentage": resp.ExpiringPercentage,
+		"velocity": map[string]interface{}{
+			"since":        resp.Velocity.Since.Format("2006-01-02"),
+			"sample_count": resp.Velocity.SampleCount,
+			"median_hours": resp.Velocity.MedianHours,
+		},
 	}
 }
@@ -0,0 +1,53 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// AkerunRepollPresenter はAkerunの再取得のPresenter
+type AkerunRepollPresenter struct{}
+
+// NewAkerunRepollPresenter は新しいAkerunRepollPresenterを作成
+func NewAkerunRepollPresenter() *AkerunRepollPresenter {
+	return &AkerunRepollPresenter{}
+}
+
+// PresentRepoll は再取得の依頼をJSON形式に変換
+func (p *AkerunRepollPresenter) PresentRepoll(repoll *entities.AkerunRepoll) gin.H {
+	return gin.H{
+		"repoll": p.presentRepoll(repoll),
+	}
+}
+
+// PresentRepolls は再取得の依頼の一覧をJSON形式に変換
+func (p *AkerunRepollPresenter) PresentRepolls(resp *inputport.ListAkerunRepollsResponse) gin.H {
+	repolls := make([]gin.H, len(resp.Repolls))
+	for i, repoll := range resp.Repolls {
+		repolls[i] = p.presentRepoll(repoll)
+	}
+	return gin.H{
+		"repolls": repolls,
+		"total":   resp.Total,
+	}
+}
+
+func (p *AkerunRepollPresenter) presentRepoll(repoll *entities.AkerunRepoll) gin.H {
+	return gin.H{
+		"id":                repoll.ID,
+		"organization_id":   repoll.OrganizationID,
+		"from":              repoll.From,
+		"to":                repoll.To,
+		"status":            repoll.Status,
+		"total_windows":     repoll.TotalWindows,
+		"completed_windows": repoll.CompletedWindows,
+		"progress_percent":  repoll.ProgressPercent(),
+		"fetched_accesses":  repoll.FetchedAccesses,
+		"error_message":     repoll.ErrorMessage,
+		"requested_by":      repoll.RequestedBy,
+		"created_at":        repoll.CreatedAt,
+		"started_at":        repoll.StartedAt,
+		"finished_at":       repoll.FinishedAt,
+	}
+}
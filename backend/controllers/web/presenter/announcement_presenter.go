@@ -0,0 +1,68 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// AnnouncementPresenter はお知らせのPresenter
+type AnnouncementPresenter struct{}
+
+// NewAnnouncementPresenter は新しいAnnouncementPresenterを作成
+func NewAnnouncementPresenter() *AnnouncementPresenter {
+	return &AnnouncementPresenter{}
+}
+
+// PresentAnnouncement は管理者向けにお知らせをJSON形式に変換（配信設定を含む）
+func (p *AnnouncementPresenter) PresentAnnouncement(a *entities.Announcement) gin.H {
+	item := gin.H{
+		"id":         a.ID,
+		"title":      a.Title,
+		"body":       a.Body,
+		"severity":   a.Severity,
+		"audience":   a.Audience,
+		"starts_at":  a.StartsAt,
+		"ends_at":    a.EndsAt,
+		"created_by": a.CreatedBy,
+		"created_at": a.CreatedAt,
+		"updated_at": a.UpdatedAt,
+	}
+	if a.Audience == entities.AnnouncementAudienceRole {
+		item["target_role"] = a.TargetRole
+	}
+	return item
+}
+
+// PresentAnnouncementList は管理者向けのお知らせ一覧をJSON形式に変換
+func (p *AnnouncementPresenter) PresentAnnouncementList(resp *inputport.GetAnnouncementListResponse) gin.H {
+	announcements := make([]gin.H, 0, len(resp.Announcements))
+	for _, a := range resp.Announcements {
+		announcements = append(announcements, p.PresentAnnouncement(a))
+	}
+	return gin.H{
+		"announcements": announcements,
+		"total":         resp.Total,
+		"offset":        resp.Offset,
+		"limit":         resp.Limit,
+	}
+}
+
+// PresentActiveAnnouncements はユーザー向けのお知らせをJSON形式に変換
+// 配信対象や作成者などの管理情報は返さない
+func (p *AnnouncementPresenter) PresentActiveAnnouncements(resp *inputport.GetActiveAnnouncementsResponse) gin.H {
+	announcements := make([]gin.H, 0, len(resp.Announcements))
+	for _, item := range resp.Announcements {
+		a := item.Announcement
+		announcements = append(announcements, gin.H{
+			"id":        a.ID,
+			"title":     a.Title,
+			"body":      a.Body,
+			"severity":  a.Severity,
+			"starts_at": a.StartsAt,
+			"ends_at":   a.EndsAt,
+			"dismissed": item.Dismissed,
+		})
+	}
+	return gin.H{"announcements": announcements}
+}
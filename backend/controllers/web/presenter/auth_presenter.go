@@ -15,6 +15,14 @@ func NewAuthPresenter() *AuthPresenter {
 
 // PresentRegisterResponse はRegisterResponseをJSON形式に変換
 func (p *AuthPresenter) PresentRegisterResponse(resp *inputport.RegisterResponse) gin.H {
+	var bonus int64
+	if resp.OnboardingBonus != nil {
+		bonus = resp.OnboardingBonus.Amount
+	}
+	var csrfToken string
+	if resp.Session != nil {
+		csrfToken = resp.Session.CSRFToken
+	}
 	return gin.H{
 		"message": "registration successful",
 		"user": gin.H{
@@ -28,7 +36,9 @@ func (p *AuthPresenter) PresentRegisterResponse(resp *inputport.RegisterResponse
 			"balance":      resp.User.Balance,
 			"role":         resp.User.Role,
 		},
-		"csrf_token": resp.Session.CSRFToken,
+		"onboarding_bonus":   bonus,
+		"csrf_token":         csrfToken,
+		"email_verification": resp.EmailVerification,
 	}
 }
 
@@ -46,7 +56,8 @@ func (p *AuthPresenter) PresentLoginResponse(resp *inputport.LoginResponse) gin.
 			"balance":      resp.User.Balance,
 			"role":         resp.User.Role,
 		},
-		"csrf_token": resp.Session.CSRFToken,
+		"reactivated": resp.Reactivated,
+		"csrf_token":  resp.Session.CSRFToken,
 	}
 }
 
@@ -63,8 +74,27 @@ func (p *AuthPresenter) PresentCurrentUserResponse(resp *inputport.GetCurrentUse
 			"avatar_url":   resp.User.AvatarURL,
 			"balance":      resp.User.Balance,
 			"role":         resp.User.Role,
+			"tier":         resp.User.CurrentTier(),
 			"is_active":    resp.User.IsActive,
+			"status":       resp.User.CurrentStatus(),
 			"created_at":   resp.User.CreatedAt,
 		},
 	}
 }
+
+// PresentCSRFToken はCSRFTokenResponseをJSON形式に変換
+func (p *AuthPresenter) PresentCSRFToken(resp *inputport.CSRFTokenResponse) gin.H {
+	return gin.H{
+		"csrf_token": resp.Token,
+		"expires_at": resp.ExpiresAt,
+	}
+}
+
+// PresentDeactivateAccountResponse はDeactivateAccountResponseをJSON形式に変換
+func (p *AuthPresenter) PresentDeactivateAccountResponse(resp *inputport.DeactivateAccountResponse) gin.H {
+	return gin.H{
+		"message":             "account deactivated",
+		"status":              resp.User.CurrentStatus(),
+		"reactivatable_until": resp.ReactivatableUntil,
+	}
+}
@@ -0,0 +1,42 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// BalanceReconciliationPresenter は残高と取引履歴の照合のPresenter
+type BalanceReconciliationPresenter struct{}
+
+// NewBalanceReconciliationPresenter は新しいBalanceReconciliationPresenterを作成
+func NewBalanceReconciliationPresenter() *BalanceReconciliationPresenter {
+	return &BalanceReconciliationPresenter{}
+}
+
+// PresentRecomputeBalances は残高の再計算結果をJSON形式に変換
+func (p *BalanceReconciliationPresenter) PresentRecomputeBalances(resp *inputport.RecomputeBalancesResponse) gin.H {
+	list := make([]gin.H, 0, len(resp.Discrepancies))
+	corrected := 0
+	for _, d := range resp.Discrepancies {
+		if d.Corrected {
+			corrected++
+		}
+		list = append(list, gin.H{
+			"user_id":        d.UserID,
+			"username":       d.Username,
+			"stored_balance": d.StoredBalance,
+			"ledger_balance": d.LedgerBalance,
+			"difference":     d.Difference(),
+			"corrected":      d.Corrected,
+			"transaction_id": d.TransactionID,
+			"skip_reason":    d.SkipReason,
+		})
+	}
+	return gin.H{
+		"applied":       resp.Applied,
+		"checked_users": resp.CheckedUsers,
+		"batches":       resp.Batches,
+		"corrected":     corrected,
+		"discrepancies": list,
+	}
+}
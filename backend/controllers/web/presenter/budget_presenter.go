@@ -0,0 +1,53 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// BudgetPresenter は管理者付与の予算のPresenter
+type BudgetPresenter struct{}
+
+// NewBudgetPresenter は新しいBudgetPresenterを作成
+func NewBudgetPresenter() *BudgetPresenter {
+	return &BudgetPresenter{}
+}
+
+// PresentBudget は予算と現在の期間の消化状況をJSON形式に変換
+func (p *BudgetPresenter) PresentBudget(s *entities.BudgetStatus) gin.H {
+	return presentBudgetStatus(s)
+}
+
+// PresentBudgetList は予算一覧をJSON形式に変換
+func (p *BudgetPresenter) PresentBudgetList(statuses []*entities.BudgetStatus) gin.H {
+	return gin.H{"budgets": presentBudgetStatuses(statuses)}
+}
+
+// presentBudgetStatus は予算の消化状況をJSON形式に変換（付与のレスポンスでも使う）
+func presentBudgetStatus(s *entities.BudgetStatus) gin.H {
+	return gin.H{
+		"id":           s.Budget.ID,
+		"name":         s.Budget.Name,
+		"scope":        s.Budget.Scope,
+		"scope_id":     s.Budget.ScopeID,
+		"period":       s.Budget.Period,
+		"amount":       s.Budget.Amount,
+		"enforcement":  s.Budget.Enforcement,
+		"is_active":    s.Budget.IsActive,
+		"period_start": s.PeriodStart,
+		"period_end":   s.PeriodEnd,
+		"consumed":     s.Consumed,
+		"remaining":    s.Remaining,
+		"percent":      s.Percent,
+		"created_at":   s.Budget.CreatedAt,
+		"updated_at":   s.Budget.UpdatedAt,
+	}
+}
+
+func presentBudgetStatuses(statuses []*entities.BudgetStatus) []gin.H {
+	list := make([]gin.H, 0, len(statuses))
+	for _, s := range statuses {
+		list = append(list, presentBudgetStatus(s))
+	}
+	return list
+}
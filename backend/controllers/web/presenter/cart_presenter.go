@@ -0,0 +1,78 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// CartPresenter は商品交換のカートのPresenter
+type CartPresenter struct{}
+
+// NewCartPresenter は新しいCartPresenterを作成
+func NewCartPresenter() *CartPresenter {
+	return &CartPresenter{}
+}
+
+// PresentCart はカートの中身をJSON形式に変換
+func (p *CartPresenter) PresentCart(resp *inputport.CartResponse) gin.H {
+	items := make([]gin.H, len(resp.Lines))
+	for i, line := range resp.Lines {
+		item := gin.H{
+			"product_id": line.Item.ProductID,
+			"quantity":   line.Item.Quantity,
+			"unit_price": line.UnitPrice,
+			"subtotal":   line.Subtotal,
+			"available":  line.Available,
+			"added_at":   line.Item.CreatedAt,
+		}
+		if line.Product != nil {
+			item["product_name"] = line.Product.Name
+			item["image_url"] = line.Product.ImageURL
+			item["base_price"] = line.Product.Price
+		}
+		if line.PricingRule != nil {
+			item["pricing_rule"] = gin.H{
+				"id":   line.PricingRule.ID,
+				"name": line.PricingRule.Name,
+			}
+		}
+		items[i] = item
+	}
+	return gin.H{
+		"items":        items,
+		"total_points": resp.TotalPoints,
+	}
+}
+
+// PresentCheckout はカートの決済結果をJSON形式に変換
+func (p *CartPresenter) PresentCheckout(resp *inputport.CheckoutCartResponse) gin.H {
+	exchanges := make([]gin.H, len(resp.Exchanges))
+	for i, exchange := range resp.Exchanges {
+		exchanges[i] = p.presentExchange(exchange)
+	}
+	result := gin.H{
+		"exchanges":      exchanges,
+		"transaction_id": resp.Transaction.ID,
+		"total_points":   resp.TotalPoints,
+	}
+	if resp.User != nil {
+		result["balance"] = resp.User.Balance
+	}
+	return result
+}
+
+func (p *CartPresenter) presentExchange(exchange *entities.ProductExchange) gin.H {
+	return gin.H{
+		"id":               exchange.ID,
+		"product_id":       exchange.ProductID,
+		"quantity":         exchange.Quantity,
+		"points_used":      exchange.PointsUsed,
+		"status":           exchange.Status,
+		"notes":            exchange.Notes,
+		"redemption_code":  exchange.RedemptionCode,
+		"shipping_address": exchange.ShippingAddress,
+		"created_at":       exchange.CreatedAt,
+		"completed_at":     exchange.CompletedAt,
+	}
+}
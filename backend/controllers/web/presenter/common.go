@@ -3,20 +3,32 @@ package presenter
 import (
 	"time"
 
+	"github.com/gity/point-system/entities"
 	"github.com/google/uuid"
 )
 
 // UserResponse はユーザーの共通レスポンス型
 type UserResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Username    string    `json:"username"`
-	DisplayName string    `json:"display_name"`
-	AvatarURL   *string   `json:"avatar_url,omitempty"`
-	Balance     int64     `json:"balance"`
-	Role        string    `json:"role"`
-	IsActive    bool      `json:"is_active"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID           uuid.UUID  `json:"id"`
+	Username     string     `json:"username"`
+	DisplayName  string     `json:"display_name"`
+	AvatarURL    *string    `json:"avatar_url,omitempty"`
+	Balance      int64      `json:"balance"`
+	Role         string     `json:"role"`
+	IsActive     bool       `json:"is_active"`
+	Tier         string     `json:"tier,omitempty"`          // 管理者向けのレスポンスのみ
+	DepartmentID *uuid.UUID `json:"department_id,omitempty"` // 管理者向けのレスポンスのみ
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	IsDeleted    bool       `json:"is_deleted,omitempty"` // 退会済みユーザーの代わりの表示
+}
+
+// deletedUserResponse は退会したユーザーの代わりに返す相手情報
+func deletedUserResponse() *UserResponse {
+	return &UserResponse{
+		DisplayName: entities.DeletedUserDisplayName,
+		IsDeleted:   true,
+	}
 }
 
 // TransactionResponse は取引の共通レスポンス型
@@ -28,6 +40,9 @@ type TransactionResponse struct {
 	TransactionType string        `json:"transaction_type"`
 	Status          string        `json:"status"`
 	Description     string        `json:"description"`
+	ReasonCode      string        `json:"reason_code"`
+	Tag             string        `json:"tag"`
+	Migrated        bool          `json:"migrated,omitempty"` // 他のシステムから取り込んだ過去の取引
 	FromUser        *UserResponse `json:"from_user,omitempty"`
 	ToUser          *UserResponse `json:"to_user,omitempty"`
 	CreatedAt       time.Time     `json:"created_at"`
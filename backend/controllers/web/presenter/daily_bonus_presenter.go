@@ -1,6 +1,7 @@
 package presenter
 
 import (
+	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 )
 
@@ -30,6 +31,8 @@ func (p *DailyBonusPresenter) PresentGetTodayBonus(resp *inputport.GetTodayBonus
 			"akerun_user_name":  resp.DailyBonus.AkerunUserName,
 			"accessed_at":       resp.DailyBonus.AccessedAt,
 			"lottery_tier_name": resp.DailyBonus.LotteryTierName,
+			"door_name":         resp.DailyBonus.AkerunDoorName,
+			"points_multiplier": resp.DailyBonus.PointsMultiplier,
 			"is_viewed":         resp.DailyBonus.IsViewed,
 			"is_drawn":          resp.DailyBonus.IsDrawn,
 			"created_at":        resp.DailyBonus.CreatedAt,
@@ -50,6 +53,7 @@ func (p *DailyBonusPresenter) PresentGetRecentBonuses(resp *inputport.GetRecentB
 			"akerun_user_name":  bonus.AkerunUserName,
 			"accessed_at":       bonus.AccessedAt,
 			"lottery_tier_name": bonus.LotteryTierName,
+			"door_name":         bonus.AkerunDoorName,
 			"is_drawn":          bonus.IsDrawn,
 		}
 	}
@@ -59,3 +63,76 @@ func (p *DailyBonusPresenter) PresentGetRecentBonuses(resp *inputport.GetRecentB
 		"total_days": resp.TotalDays,
 	}
 }
+
+// PresentFailedAccesses は付与に失敗した入退室記録の一覧レスポンスを生成
+func (p *DailyBonusPresenter) PresentFailedAccesses(resp *inputport.ListFailedAccessesResponse) map[string]interface{} {
+	accesses := make([]map[string]interface{}, len(resp.Accesses))
+	for i, access := range resp.Accesses {
+		accesses[i] = p.presentFailedAccess(access)
+	}
+
+	return map[string]interface{}{
+		"failed_accesses": accesses,
+		"total":           resp.Total,
+	}
+}
+
+// PresentFailedAccess は付与に失敗した入退室記録のレスポンスを生成
+func (p *DailyBonusPresenter) PresentFailedAccess(access *entities.FailedAkerunAccess) map[string]interface{} {
+	return map[string]interface{}{
+		"failed_access": p.presentFailedAccess(access),
+	}
+}
+
+func (p *DailyBonusPresenter) presentFailedAccess(access *entities.FailedAkerunAccess) map[string]interface{} {
+	item := map[string]interface{}{
+		"id":               access.ID,
+		"access_id":        access.AccessID,
+		"akerun_user_name": access.UserName,
+		"accessed_at":      access.AccessedAt,
+		"organization_id":  access.OrganizationID,
+		"door_name":        access.DoorName,
+		"status":           access.Status,
+		"attempts":         access.Attempts,
+		"last_error":       access.LastError,
+		"created_at":       access.CreatedAt,
+		"updated_at":       access.UpdatedAt,
+	}
+	if !access.IsDead() {
+		item["next_retry_at"] = access.NextRetryAt
+	}
+	return item
+}
+
+// PresentAttendanceMatrix は出席表のレスポンスを生成
+// daysは期間の日付の順で、ユーザーごとのdaysは出席した日のポイント（欠席ならnull）
+func (p *DailyBonusPresenter) PresentAttendanceMatrix(matrix *entities.AttendanceMatrix) map[string]interface{} {
+	dates := make([]string, matrix.Period.Days())
+	for d := range dates {
+		dates[d] = matrix.Period.From.AddDate(0, 0, d).Format("2006-01-02")
+	}
+
+	users := make([]map[string]interface{}, len(matrix.Rows))
+	for i, row := range matrix.Rows {
+		days := make([]interface{}, len(row.Attended))
+		for d, attended := range row.Attended {
+			if attended {
+				days[d] = row.Points[d]
+			}
+		}
+		users[i] = map[string]interface{}{
+			"user_id":       row.UserID,
+			"username":      row.Username,
+			"display_name":  row.DisplayName,
+			"days":          days,
+			"attended_days": row.AttendedDays,
+			"total_points":  row.TotalPoints,
+		}
+	}
+
+	return map[string]interface{}{
+		"month": matrix.Period.From.Format("2006-01"),
+		"dates": dates,
+		"users": users,
+	}
+}
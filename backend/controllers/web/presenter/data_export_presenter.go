@@ -0,0 +1,32 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// DataExportPresenter は個人データエクスポートのPresenter
+type DataExportPresenter struct{}
+
+// NewDataExportPresenter は新しいDataExportPresenterを作成
+func NewDataExportPresenter() *DataExportPresenter {
+	return &DataExportPresenter{}
+}
+
+// PresentExport はエクスポート依頼をJSON形式に変換（依頼がない場合はnull）
+func (p *DataExportPresenter) PresentExport(export *entities.DataExport) gin.H {
+	if export == nil {
+		return gin.H{"export": nil}
+	}
+	data := gin.H{
+		"id":           export.ID,
+		"status":       export.Status,
+		"requested_at": export.RequestedAt,
+		"completed_at": export.CompletedAt,
+		"expires_at":   export.ExpiresAt,
+	}
+	if export.Status == entities.DataExportStatusReady {
+		data["download_url"] = "/api/settings/data-export/" + export.ID.String() + "/download"
+	}
+	return gin.H{"export": data}
+}
@@ -0,0 +1,34 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/usecases/service"
+)
+
+// DBStatsPresenter はDBのコネクションプールの状態のPresenter
+type DBStatsPresenter struct{}
+
+// NewDBStatsPresenter は新しいDBStatsPresenterを作成
+func NewDBStatsPresenter() *DBStatsPresenter {
+	return &DBStatsPresenter{}
+}
+
+// PresentDBStats はコネクションプールの状態をJSON形式に変換（待ち時間はミリ秒）
+func (p *DBStatsPresenter) PresentDBStats(stats []service.DBPoolStats) gin.H {
+	pools := make([]gin.H, 0, len(stats))
+	for _, s := range stats {
+		pools = append(pools, gin.H{
+			"name":                 s.Name,
+			"max_open_connections": s.MaxOpenConnections,
+			"open_connections":     s.OpenConnections,
+			"in_use":               s.InUse,
+			"idle":                 s.Idle,
+			"wait_count":           s.WaitCount,
+			"wait_duration_ms":     s.WaitDuration.Milliseconds(),
+			"max_idle_closed":      s.MaxIdleClosed,
+			"max_idle_time_closed": s.MaxIdleTimeClosed,
+			"max_lifetime_closed":  s.MaxLifetimeClosed,
+		})
+	}
+	return gin.H{"pools": pools}
+}
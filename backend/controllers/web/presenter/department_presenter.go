@@ -0,0 +1,66 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// DepartmentPresenter は組織・部署のPresenter
+type DepartmentPresenter struct{}
+
+// NewDepartmentPresenter は新しいDepartmentPresenterを作成
+func NewDepartmentPresenter() *DepartmentPresenter {
+	return &DepartmentPresenter{}
+}
+
+// PresentDepartment は部署をJSON形式に変換
+func (p *DepartmentPresenter) PresentDepartment(d *entities.Department) gin.H {
+	return gin.H{
+		"id":                   d.ID,
+		"name":                 d.Name,
+		"parent_id":            d.ParentID,
+		"monthly_grant_budget": d.MonthlyGrantBudget,
+		"created_at":           d.CreatedAt,
+		"updated_at":           d.UpdatedAt,
+	}
+}
+
+// PresentDepartmentList は部署一覧をJSON形式に変換
+func (p *DepartmentPresenter) PresentDepartmentList(departments []*entities.Department) gin.H {
+	list := make([]gin.H, 0, len(departments))
+	for _, d := range departments {
+		list = append(list, p.PresentDepartment(d))
+	}
+	return gin.H{"departments": list}
+}
+
+// PresentUserDepartment は所属部署を設定したユーザーをJSON形式に変換
+func (p *DepartmentPresenter) PresentUserDepartment(u *entities.User) gin.H {
+	return gin.H{
+		"user_id":       u.ID,
+		"username":      u.Username,
+		"department_id": u.DepartmentID,
+	}
+}
+
+// PresentDepartmentAnalytics は部署別分析をJSON形式に変換
+func (p *DepartmentPresenter) PresentDepartmentAnalytics(resp *inputport.GetDepartmentAnalyticsResponse) gin.H {
+	list := make([]gin.H, 0, len(resp.Departments))
+	for _, d := range resp.Departments {
+		list = append(list, gin.H{
+			"department_id":        d.DepartmentID,
+			"name":                 d.Name,
+			"parent_id":            d.ParentID,
+			"member_count":         d.MemberCount,
+			"granted":              d.Granted,
+			"spent":                d.Spent,
+			"total_member_count":   d.TotalMemberCount,
+			"total_granted":        d.TotalGranted,
+			"total_spent":          d.TotalSpent,
+			"monthly_grant_budget": d.MonthlyGrantBudget,
+			"budget_used":          d.BudgetUsed,
+		})
+	}
+	return gin.H{"departments": list}
+}
@@ -0,0 +1,63 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// EarningRulePresenter はポイント獲得ルールのPresenter
+type EarningRulePresenter struct{}
+
+// NewEarningRulePresenter は新しいEarningRulePresenterを作成
+func NewEarningRulePresenter() *EarningRulePresenter {
+	return &EarningRulePresenter{}
+}
+
+// PresentEarningRule はポイント獲得ルールをJSON形式に変換
+func (p *EarningRulePresenter) PresentEarningRule(r *entities.EarningRule) gin.H {
+	return gin.H{
+		"id":                  r.ID,
+		"name":                r.Name,
+		"trigger":             r.Trigger,
+		"points":              r.Points,
+		"occurrence":          r.Occurrence,
+		"min_amount":          r.MinAmount,
+		"once_per_user":       r.OncePerUser,
+		"max_grants_per_user": r.MaxGrantsPerUser,
+		"max_grants_total":    r.MaxGrantsTotal,
+		"grant_count":         r.GrantCount,
+		"starts_at":           r.StartsAt,
+		"ends_at":             r.EndsAt,
+		"is_active":           r.IsActive,
+		"created_by":          r.CreatedBy,
+		"created_at":          r.CreatedAt,
+		"updated_at":          r.UpdatedAt,
+	}
+}
+
+// PresentEarningRuleWithStats はポイント獲得ルールと付与の実績をJSON形式に変換
+func (p *EarningRulePresenter) PresentEarningRuleWithStats(r *inputport.EarningRuleWithStats) gin.H {
+	rule := p.PresentEarningRule(r.Rule)
+	rule["stats"] = gin.H{
+		"grant_count":     r.Stats.GrantCount,
+		"user_count":      r.Stats.UserCount,
+		"points_granted":  r.Stats.PointsGranted,
+		"last_granted_at": r.Stats.LastGrantedAt,
+	}
+	return rule
+}
+
+// PresentEarningRuleList はポイント獲得ルール一覧をJSON形式に変換
+func (p *EarningRulePresenter) PresentEarningRuleList(resp *inputport.GetEarningRuleListResponse) gin.H {
+	rules := make([]gin.H, 0, len(resp.Rules))
+	for _, r := range resp.Rules {
+		rules = append(rules, p.PresentEarningRuleWithStats(r))
+	}
+	return gin.H{
+		"earning_rules": rules,
+		"total":         resp.Total,
+		"offset":        resp.Offset,
+		"limit":         resp.Limit,
+	}
+}
@@ -0,0 +1,73 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// EmailTemplatePresenter はメールテンプレートのPresenter
+type EmailTemplatePresenter struct{}
+
+// NewEmailTemplatePresenter は新しいEmailTemplatePresenterを作成
+func NewEmailTemplatePresenter() *EmailTemplatePresenter {
+	return &EmailTemplatePresenter{}
+}
+
+// PresentTemplate はテンプレートの版をJSON形式に変換
+func (p *EmailTemplatePresenter) PresentTemplate(t *entities.EmailTemplate) gin.H {
+	h := gin.H{
+		"key":       t.Key,
+		"version":   t.Version,
+		"built_in":  t.IsBuiltIn(),
+		"subject":   t.Subject,
+		"html_body": t.HTMLBody,
+		"text_body": t.TextBody,
+	}
+	if !t.IsBuiltIn() {
+		h["created_by"] = t.CreatedBy
+		h["created_at"] = t.CreatedAt
+	}
+	return h
+}
+
+func (p *EmailTemplatePresenter) presentDefinition(def *entities.EmailTemplateDefinition) gin.H {
+	return gin.H{
+		"key":       def.Key,
+		"name":      def.Name,
+		"variables": def.Variables,
+	}
+}
+
+// PresentSummaries はメールの種類ごとの現在のテンプレートをJSON形式に変換
+func (p *EmailTemplatePresenter) PresentSummaries(summaries []*inputport.EmailTemplateSummary) gin.H {
+	items := make([]gin.H, len(summaries))
+	for i, s := range summaries {
+		item := p.presentDefinition(s.Definition)
+		item["current"] = p.PresentTemplate(s.Current)
+		items[i] = item
+	}
+	return gin.H{"templates": items}
+}
+
+// PresentDetail はテンプレートの詳細と過去の版をJSON形式に変換
+func (p *EmailTemplatePresenter) PresentDetail(resp *inputport.GetEmailTemplateResponse) gin.H {
+	versions := make([]gin.H, len(resp.Versions))
+	for i, v := range resp.Versions {
+		versions[i] = p.PresentTemplate(v)
+	}
+	h := p.presentDefinition(resp.Definition)
+	h["current"] = p.PresentTemplate(resp.Current)
+	h["default"] = p.PresentTemplate(resp.Definition.Default())
+	h["versions"] = versions
+	return h
+}
+
+// PresentRenderedEmail は描画したメールをJSON形式に変換
+func (p *EmailTemplatePresenter) PresentRenderedEmail(email *entities.RenderedEmail) gin.H {
+	return gin.H{
+		"subject": email.Subject,
+		"html":    email.HTML,
+		"text":    email.Text,
+	}
+}
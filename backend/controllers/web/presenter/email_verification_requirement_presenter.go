@@ -0,0 +1,31 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// EmailVerificationRequirementPresenter は新規登録ユーザーのメール認証のPresenter
+type EmailVerificationRequirementPresenter struct{}
+
+// NewEmailVerificationRequirementPresenter は新しいEmailVerificationRequirementPresenterを作成
+func NewEmailVerificationRequirementPresenter() *EmailVerificationRequirementPresenter {
+	return &EmailVerificationRequirementPresenter{}
+}
+
+// PresentRequirement はメール認証を求める設定をJSON形式に変換
+func (p *EmailVerificationRequirementPresenter) PresentRequirement(requirement *entities.EmailVerificationRequirement) gin.H {
+	return gin.H{
+		"stage":      requirement.Stage,
+		"enabled_at": requirement.EnabledAt,
+		"updated_by": requirement.UpdatedBy,
+		"updated_at": requirement.UpdatedAt,
+	}
+}
+
+// PresentResent は認証メールの再送の結果をJSON形式に変換（登録の有無がわからないよう常に同じ内容を返す）
+func (p *EmailVerificationRequirementPresenter) PresentResent() gin.H {
+	return gin.H{
+		"message": "if the address belongs to an unverified account, a verification email has been sent",
+	}
+}
@@ -0,0 +1,976 @@
+package presenter
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gity/point-system/entities"
+)
+
+// Language はエラーメッセージの表示言語
+type Language string
+
+const (
+	LanguageJapanese Language = "ja"
+	LanguageEnglish  Language = "en"
+
+	// DefaultLanguage はAccept-Languageが無い・未対応の場合の言語
+	DefaultLanguage = LanguageJapanese
+)
+
+// errorStatuses はエラーコードとHTTPステータスの対応（未登録のコードは400）
+var errorStatuses = map[entities.ErrorCode]int{
+	entities.ErrCodeUserNotFound:            http.StatusNotFound,
+	entities.ErrCodeTransferRequestNotFound: http.StatusNotFound,
+	entities.ErrCodeQRCodeNotFound:          http.StatusNotFound,
+	entities.ErrCodeAdminRequired:           http.StatusForbidden,
+	entities.ErrCodeInvalidCredentials:      http.StatusUnauthorized,
+	entities.ErrCodeSessionExpired:          http.StatusUnauthorized,
+	entities.ErrCodeEmailAlreadyExists:      http.StatusConflict,
+	entities.ErrCodeUsernameAlreadyExists:   http.StatusConflict,
+	entities.ErrCodeUpdateConflict:          http.StatusConflict,
+	entities.ErrCodeAccountLocked:           http.StatusLocked,
+	entities.ErrCodeTooManyLoginAttempts:    http.StatusTooManyRequests,
+	entities.ErrCodeKioskDailyLimitReached:  http.StatusTooManyRequests,
+	entities.ErrCodeIdempotencyKeyReused:    http.StatusUnprocessableEntity,
+	entities.ErrCodeRequestInProgress:       http.StatusConflict,
+	entities.ErrCodeMaintenanceMode:         http.StatusServiceUnavailable,
+	entities.ErrCodePointBatchNotFound:      http.StatusNotFound,
+	entities.ErrCodePointBatchNotRestorable: http.StatusConflict,
+	entities.ErrCodePointBatchRestored:      http.StatusConflict,
+	entities.ErrCodePointBatchNotAdjustable: http.StatusConflict,
+	entities.ErrCodeDataExportNotFound:      http.StatusNotFound,
+	entities.ErrCodeDataExportInProgress:    http.StatusConflict,
+	entities.ErrCodeDataExportTooSoon:       http.StatusTooManyRequests,
+	entities.ErrCodeDataExportNotReady:      http.StatusConflict,
+	entities.ErrCodeContentRejected:         http.StatusUnprocessableEntity,
+	entities.ErrCodeViolationNotFound:       http.StatusNotFound,
+	entities.ErrCodeAnnouncementNotFound:    http.StatusNotFound,
+	entities.ErrCodeRecurringNotFound:       http.StatusNotFound,
+	entities.ErrCodeRecurringNotActive:      http.StatusConflict,
+	entities.ErrCodeDeviceTokenNotFound:     http.StatusNotFound,
+	entities.ErrCodeRedemptionCodeNotFound:  http.StatusNotFound,
+	entities.ErrCodeRedemptionCodeUsed:      http.StatusConflict,
+	entities.ErrCodePricingRuleNotFound:     http.StatusNotFound,
+	entities.ErrCodeEarningRuleNotFound:     http.StatusNotFound,
+	entities.ErrCodeTenantNotFound:          http.StatusNotFound,
+	entities.ErrCodeTenantInactive:          http.StatusForbidden,
+	entities.ErrCodeTenantSlugTaken:         http.StatusConflict,
+	entities.ErrCodeSuperAdminRequired:      http.StatusForbidden,
+	entities.ErrCodeSaleLimitExceeded:       http.StatusConflict,
+	entities.ErrCodeEventNotFound:           http.StatusNotFound,
+	entities.ErrCodeEventFull:               http.StatusConflict,
+	entities.ErrCodeEventAlreadyCheckedIn:   http.StatusConflict,
+	entities.ErrCodeReasonCodeNotFound:      http.StatusNotFound,
+	entities.ErrCodeReasonCodeExists:        http.StatusConflict,
+	entities.ErrCodeDepartmentNotFound:      http.StatusNotFound,
+	entities.ErrCodeDepartmentExists:        http.StatusConflict,
+	entities.ErrCodeDepartmentHasChildren:   http.StatusConflict,
+	entities.ErrCodeBudgetNotFound:          http.StatusNotFound,
+	entities.ErrCodePendingActionNotFound:   http.StatusNotFound,
+	entities.ErrCodePendingActionNotPending: http.StatusConflict,
+	entities.ErrCodePendingActionExpired:    http.StatusConflict,
+	entities.ErrCodeSelfApproval:            http.StatusForbidden,
+	entities.ErrCodeFailedAccessNotFound:    http.StatusNotFound,
+	entities.ErrCodeFailedAccessNotDead:     http.StatusConflict,
+	entities.ErrCodeTransferHeld:            http.StatusAccepted,
+	entities.ErrCodeTransferRejected:        http.StatusForbidden,
+	entities.ErrCodeSuspiciousNotFound:      http.StatusNotFound,
+	entities.ErrCodeSuspiciousReviewed:      http.StatusConflict,
+	entities.ErrCodeEmailVerificationNeeded: http.StatusForbidden,
+	entities.ErrCodeAccountTooNew:           http.StatusForbidden,
+	entities.ErrCodeRecipientFriendsOnly:    http.StatusForbidden,
+	entities.ErrCodeInvalidAcceptance:       http.StatusBadRequest,
+	entities.ErrCodeUnauthorized:            http.StatusUnauthorized,
+	entities.ErrCodeValidationFailed:        http.StatusBadRequest,
+	entities.ErrCodeInvalidCSRFToken:        http.StatusForbidden,
+	entities.ErrCodeInvalidKioskDevice:      http.StatusUnauthorized,
+	entities.ErrCodeRequestTooLarge:         http.StatusRequestEntityTooLarge,
+	entities.ErrCodeUnsupportedMediaType:    http.StatusUnsupportedMediaType,
+	entities.ErrCodeTooManyConnections:      http.StatusTooManyRequests,
+	entities.ErrCodeRouteNotFound:           http.StatusNotFound,
+	entities.ErrCodeRequestTimeout:          http.StatusGatewayTimeout,
+	entities.ErrCodeSplitRequestNotFound:    http.StatusNotFound,
+	entities.ErrCodeSplitRequestClosed:      http.StatusConflict,
+	entities.ErrCodeNotFriends:              http.StatusForbidden,
+	entities.ErrCodeAkerunRepollNotFound:    http.StatusNotFound,
+	entities.ErrCodeAkerunRepollInProgress:  http.StatusConflict,
+	entities.ErrCodeCartFull:                http.StatusConflict,
+	entities.ErrCodeCartItemNotFound:        http.StatusNotFound,
+	entities.ErrCodeCartItemUnavailable:     http.StatusConflict,
+	entities.ErrCodeGiftNotFound:            http.StatusNotFound,
+	entities.ErrCodeGiftAlreadyAnswered:     http.StatusConflict,
+	entities.ErrCodeGiftAwaitingResponse:    http.StatusConflict,
+	entities.ErrCodeShippingAddressNotFound: http.StatusNotFound,
+	entities.ErrCodeShippingAddressLimit:    http.StatusConflict,
+	entities.ErrCodeEmailTemplateNotFound:   http.StatusNotFound,
+	entities.ErrCodeFriendPinLimit:          http.StatusConflict,
+	entities.ErrCodePinTargetNotFriend:      http.StatusForbidden,
+	entities.ErrCodeAccountDeactivated:      http.StatusForbidden,
+	entities.ErrCodeReactivationExpired:     http.StatusForbidden,
+	entities.ErrCodeSessionReauthRequired:   http.StatusUnauthorized,
+	entities.ErrCodeCSRFTokenExpired:        http.StatusForbidden,
+	entities.ErrCodeEmailNotVerified:        http.StatusForbidden,
+	entities.ErrCodeInvalidEmailRequirement: http.StatusBadRequest,
+	entities.ErrCodeExchangeNotFound:        http.StatusNotFound,
+	entities.ErrCodeActionNotPermitted:      http.StatusForbidden,
+	entities.ErrCodeCannotMuteSelf:          http.StatusBadRequest,
+	entities.ErrCodeInvalidMuteMode:         http.StatusBadRequest,
+	entities.ErrCodePendingRequestLimit:     http.StatusConflict,
+	entities.ErrCodeRecipientRequestLimit:   http.StatusConflict,
+	entities.ErrCodeInvalidRequestQuota:     http.StatusBadRequest,
+	entities.ErrCodeBalanceHoldSettled:      http.StatusConflict,
+	entities.ErrCodeInvalidQRPayload:        http.StatusBadRequest,
+	entities.ErrCodeUnsupportedQRVersion:    http.StatusBadRequest,
+	entities.ErrCodeTransactionNotFound:     http.StatusNotFound,
+	entities.ErrCodeReceiptUnavailable:      http.StatusConflict,
+	entities.ErrCodeAttachmentNotFound:      http.StatusNotFound,
+	entities.ErrCodeAttachmentNotAllowed:    http.StatusConflict,
+	entities.ErrCodeInvalidAttachment:       http.StatusBadRequest,
+}
+
+// errorMessages はエラーコードごとの表示メッセージ
+var errorMessages = map[entities.ErrorCode]map[Language]string{
+	entities.ErrCodeInvalidAmount: {
+		LanguageJapanese: "ポイント数は1以上を指定してください",
+		LanguageEnglish:  "Amount must be positive.",
+	},
+	entities.ErrCodeInvalidQuantity: {
+		LanguageJapanese: "数量は1以上を指定してください",
+		LanguageEnglish:  "Quantity must be positive.",
+	},
+	entities.ErrCodeIdempotencyKeyRequired: {
+		LanguageJapanese: "冪等性キーが必要です",
+		LanguageEnglish:  "Idempotency key is required.",
+	},
+	entities.ErrCodeSameUserTransfer: {
+		LanguageJapanese: "自分自身には送金できません",
+		LanguageEnglish:  "You cannot transfer points to yourself.",
+	},
+	entities.ErrCodeEmailAlreadyExists: {
+		LanguageJapanese: "このメールアドレスは既に使用されています",
+		LanguageEnglish:  "This email address is already in use.",
+	},
+	entities.ErrCodeUsernameAlreadyExists: {
+		LanguageJapanese: "このユーザー名は既に使用されています",
+		LanguageEnglish:  "This username is already taken.",
+	},
+	entities.ErrCodeInsufficientBalance: {
+		LanguageJapanese: "ポイント残高が不足しています",
+		LanguageEnglish:  "Insufficient balance.",
+	},
+	entities.ErrCodeUserInactive: {
+		LanguageJapanese: "このユーザーは無効化されています",
+		LanguageEnglish:  "This user is not active.",
+	},
+	entities.ErrCodeProductNotAvailable: {
+		LanguageJapanese: "この商品は現在交換できません",
+		LanguageEnglish:  "This product is not available.",
+	},
+	entities.ErrCodeRequestNotPending: {
+		LanguageJapanese: "このリクエストは既に処理されています",
+		LanguageEnglish:  "This request is no longer pending.",
+	},
+	entities.ErrCodeRequestExpired: {
+		LanguageJapanese: "このリクエストは有効期限切れです",
+		LanguageEnglish:  "This request has expired.",
+	},
+	entities.ErrCodeQRCodeExpired: {
+		LanguageJapanese: "QRコードの有効期限が切れています",
+		LanguageEnglish:  "This QR code has expired.",
+	},
+	entities.ErrCodeQRCodeAlreadyUsed: {
+		LanguageJapanese: "このQRコードは既に使用されています",
+		LanguageEnglish:  "This QR code has already been used.",
+	},
+	entities.ErrCodeTokenExpired: {
+		LanguageJapanese: "リンクの有効期限が切れています",
+		LanguageEnglish:  "This link has expired.",
+	},
+	entities.ErrCodeTokenAlreadyUsed: {
+		LanguageJapanese: "このリンクは既に使用されています",
+		LanguageEnglish:  "This link has already been used.",
+	},
+	entities.ErrCodeKioskDailyLimitReached: {
+		LanguageJapanese: "この端末の本日の付与上限に達しました",
+		LanguageEnglish:  "This kiosk has reached its daily limit.",
+	},
+	entities.ErrCodeUpdateConflict: {
+		LanguageJapanese: "他の更新と競合しました。しばらくしてから再度お試しください",
+		LanguageEnglish:  "The update conflicted with another change. Please retry later.",
+	},
+	entities.ErrCodeUserNotFound: {
+		LanguageJapanese: "ユーザーが見つかりません",
+		LanguageEnglish:  "User not found.",
+	},
+	entities.ErrCodeTransferRequestNotFound: {
+		LanguageJapanese: "送金リクエストが見つかりません",
+		LanguageEnglish:  "Transfer request not found.",
+	},
+	entities.ErrCodeQRCodeNotFound: {
+		LanguageJapanese: "QRコードが見つかりません",
+		LanguageEnglish:  "QR code not found.",
+	},
+	entities.ErrCodeAdminRequired: {
+		LanguageJapanese: "管理者権限が必要です",
+		LanguageEnglish:  "Administrator privileges are required.",
+	},
+	entities.ErrCodeInvalidCredentials: {
+		LanguageJapanese: "ユーザー名またはパスワードが正しくありません",
+		LanguageEnglish:  "Invalid username or password.",
+	},
+	entities.ErrCodeSessionExpired: {
+		LanguageJapanese: "セッションの有効期限が切れました。再度ログインしてください",
+		LanguageEnglish:  "Your session has expired. Please log in again.",
+	},
+	entities.ErrCodeAccountLocked: {
+		LanguageJapanese: "アカウントがロックされています。時間をおくか、メールのリンクからロックを解除してください",
+		LanguageEnglish:  "Your account is locked. Try again later or unlock it from the email we sent.",
+	},
+	entities.ErrCodeTooManyLoginAttempts: {
+		LanguageJapanese: "ログイン試行回数が多すぎます。しばらくしてから再度お試しください",
+		LanguageEnglish:  "Too many login attempts. Please try again later.",
+	},
+	entities.ErrCodeIdempotencyKeyInvalid: {
+		LanguageJapanese: "Idempotency-Keyは1〜255文字で指定してください",
+		LanguageEnglish:  "Idempotency-Key must be 1 to 255 characters.",
+	},
+	entities.ErrCodeIdempotencyKeyReused: {
+		LanguageJapanese: "このIdempotency-Keyは別のリクエストで使用済みです",
+		LanguageEnglish:  "This Idempotency-Key was already used for a different request.",
+	},
+	entities.ErrCodeRequestInProgress: {
+		LanguageJapanese: "同じリクエストを処理中です。しばらくしてから再度お試しください",
+		LanguageEnglish:  "The same request is still being processed. Please retry later.",
+	},
+	entities.ErrCodeInvalidDateRange: {
+		LanguageJapanese: "期間の指定が正しくありません",
+		LanguageEnglish:  "The date range is invalid.",
+	},
+	entities.ErrCodeMaintenanceMode: {
+		LanguageJapanese: "メンテナンス中のため、現在この操作は行えません",
+		LanguageEnglish:  "This operation is unavailable during maintenance.",
+	},
+	entities.ErrCodeInvalidExpiryPolicy: {
+		LanguageJapanese: "有効期限の設定が正しくありません",
+		LanguageEnglish:  "The expiry policy is invalid.",
+	},
+	entities.ErrCodePointBatchNotFound: {
+		LanguageJapanese: "ポイントバッチが見つかりません",
+		LanguageEnglish:  "Point batch not found.",
+	},
+	entities.ErrCodePointBatchNotRestorable: {
+		LanguageJapanese: "このポイントは失効していないか、取り消し可能な期間を過ぎています",
+		LanguageEnglish:  "This point batch is not expired or the grace period has passed.",
+	},
+	entities.ErrCodePointBatchRestored: {
+		LanguageJapanese: "このポイントは既に復元されています",
+		LanguageEnglish:  "This point batch has already been restored.",
+	},
+	entities.ErrCodePointBatchNotAdjustable: {
+		LanguageJapanese: "このポイントは失効済みか、既に取り消されています",
+		LanguageEnglish:  "This point batch has already expired or been cancelled.",
+	},
+	entities.ErrCodeInvalidBatchAdjustment: {
+		LanguageJapanese: "新しい有効期限（現在より後で5年以内）か取り消しのどちらかと、理由（500文字以内）を指定してください",
+		LanguageEnglish:  "Specify either a new expiry date (in the future and within 5 years) or cancellation, with a reason of up to 500 characters.",
+	},
+	entities.ErrCodeDataExportNotFound: {
+		LanguageJapanese: "データエクスポートが見つかりません",
+		LanguageEnglish:  "Data export not found.",
+	},
+	entities.ErrCodeDataExportInProgress: {
+		LanguageJapanese: "データエクスポートを作成中です。完了するとメールでお知らせします",
+		LanguageEnglish:  "Your data export is being prepared. We will email you when it is ready.",
+	},
+	entities.ErrCodeDataExportTooSoon: {
+		LanguageJapanese: "データエクスポートは24時間に1回まで依頼できます",
+		LanguageEnglish:  "You can request a data export once every 24 hours.",
+	},
+	entities.ErrCodeDataExportNotReady: {
+		LanguageJapanese: "データエクスポートはまだ準備できていないか、ダウンロード期限を過ぎています",
+		LanguageEnglish:  "The data export is not ready yet or has expired.",
+	},
+	entities.ErrCodeContentRejected: {
+		LanguageJapanese: "利用できない表現が含まれています",
+		LanguageEnglish:  "The text contains content that is not allowed.",
+	},
+	entities.ErrCodeViolationNotFound: {
+		LanguageJapanese: "違反記録が見つかりません",
+		LanguageEnglish:  "Content violation not found.",
+	},
+	entities.ErrCodeAnnouncementNotFound: {
+		LanguageJapanese: "お知らせが見つかりません",
+		LanguageEnglish:  "Announcement not found.",
+	},
+	entities.ErrCodeInvalidAnnouncement: {
+		LanguageJapanese: "お知らせの内容が正しくありません（タイトル・本文・重要度・配信対象・表示期間を確認してください）",
+		LanguageEnglish:  "Invalid announcement. Check the title, body, severity, audience and display period.",
+	},
+	entities.ErrCodeRequestNotCountered: {
+		LanguageJapanese: "このリクエストには確認待ちの金額変更がありません",
+		LanguageEnglish:  "This request has no counter-offer to confirm.",
+	},
+	entities.ErrCodeInvalidCounterAmount: {
+		LanguageJapanese: "変更後の金額は1以上で、元の金額と異なる必要があります",
+		LanguageEnglish:  "The counter amount must be positive and differ from the requested amount.",
+	},
+	entities.ErrCodeRecurringNotFound: {
+		LanguageJapanese: "定期送金が見つかりません",
+		LanguageEnglish:  "Recurring transfer not found.",
+	},
+	entities.ErrCodeRecurringNotActive: {
+		LanguageJapanese: "この定期送金は既に解約または停止されています",
+		LanguageEnglish:  "This recurring transfer is already cancelled or stopped.",
+	},
+	entities.ErrCodeInvalidRecurring: {
+		LanguageJapanese: "定期送金の内容が正しくありません（周期・メッセージ・開始日を確認してください）",
+		LanguageEnglish:  "Invalid recurring transfer. Check the interval, message and start date.",
+	},
+	entities.ErrCodeInvalidContactHashes: {
+		LanguageJapanese: "連絡先ハッシュの形式が正しくありません（SHA-256の16進数文字列を1〜500件）",
+		LanguageEnglish:  "Invalid contact hashes. Send 1-500 SHA-256 hex digests.",
+	},
+	entities.ErrCodeInvalidDeviceToken: {
+		LanguageJapanese: "デバイストークンが正しくありません（platformはiosまたはandroid）",
+		LanguageEnglish:  "Invalid device token. Platform must be ios or android.",
+	},
+	entities.ErrCodeDeviceTokenNotFound: {
+		LanguageJapanese: "登録されていない端末です",
+		LanguageEnglish:  "Device is not registered.",
+	},
+	entities.ErrCodeInvalidQuietHours: {
+		LanguageJapanese: "おやすみ時間の指定が正しくありません（HH:MM形式の時刻と有効なタイムゾーン）",
+		LanguageEnglish:  "Invalid quiet hours. Use HH:MM times and a valid IANA timezone.",
+	},
+	entities.ErrCodeRedemptionCodeNotFound: {
+		LanguageJapanese: "引換コードが見つかりません",
+		LanguageEnglish:  "Redemption code not found.",
+	},
+	entities.ErrCodeRedemptionCodeUsed: {
+		LanguageJapanese: "この引換コードは使用済みか、交換がキャンセルされています",
+		LanguageEnglish:  "This redemption code has already been used or the exchange was cancelled.",
+	},
+	entities.ErrCodePricingRuleNotFound: {
+		LanguageJapanese: "価格ルールが見つかりません",
+		LanguageEnglish:  "Pricing rule not found.",
+	},
+	entities.ErrCodeInvalidPricingRule: {
+		LanguageJapanese: "価格ルールの内容が正しくありません（対象、割引、購入上限、期間を確認してください）",
+		LanguageEnglish:  "Invalid pricing rule. Check the target, discount, quantity limit and period.",
+	},
+	entities.ErrCodeSaleLimitExceeded: {
+		LanguageJapanese: "セール期間中の購入上限を超えています",
+		LanguageEnglish:  "This exceeds the per-user limit for the sale.",
+	},
+	entities.ErrCodeInvalidUserTier: {
+		LanguageJapanese: "会員ランクはbronze・silver・goldのいずれかを指定してください",
+		LanguageEnglish:  "Tier must be bronze, silver or gold.",
+	},
+	entities.ErrCodeInvalidReferralCode: {
+		LanguageJapanese: "紹介コードが正しくありません",
+		LanguageEnglish:  "The referral code is invalid.",
+	},
+	entities.ErrCodeEventNotFound: {
+		LanguageJapanese: "イベントが見つかりません",
+		LanguageEnglish:  "Event not found.",
+	},
+	entities.ErrCodeInvalidEvent: {
+		LanguageJapanese: "イベントの内容が正しくありません（名前、ポイント、定員、期間を確認してください）",
+		LanguageEnglish:  "Invalid event. Check the name, points, capacity and period.",
+	},
+	entities.ErrCodeEventNotOpen: {
+		LanguageJapanese: "このイベントは現在チェックインを受け付けていません",
+		LanguageEnglish:  "This event is not accepting check-ins right now.",
+	},
+	entities.ErrCodeEventFull: {
+		LanguageJapanese: "このイベントは定員に達しました",
+		LanguageEnglish:  "This event has reached its capacity.",
+	},
+	entities.ErrCodeEventAlreadyCheckedIn: {
+		LanguageJapanese: "このイベントにはチェックイン済みです",
+		LanguageEnglish:  "You have already checked in to this event.",
+	},
+	entities.ErrCodeReasonCodeRequired: {
+		LanguageJapanese: "理由コードを選択してください",
+		LanguageEnglish:  "Select a reason code.",
+	},
+	entities.ErrCodeReasonCodeNotFound: {
+		LanguageJapanese: "理由コードが見つかりません",
+		LanguageEnglish:  "Reason code not found.",
+	},
+	entities.ErrCodeInvalidReasonCode: {
+		LanguageJapanese: "理由コードの内容が正しくありません（コードは英小文字・数字・_の1〜32文字、表示名は必須です）",
+		LanguageEnglish:  "Invalid reason code. Codes use 1-32 lowercase letters, digits or underscores, and a label is required.",
+	},
+	entities.ErrCodeReasonCodeExists: {
+		LanguageJapanese: "この理由コードは既に登録されています",
+		LanguageEnglish:  "This reason code already exists.",
+	},
+	entities.ErrCodeReasonCodeInactive: {
+		LanguageJapanese: "この理由コードは現在使用できません",
+		LanguageEnglish:  "This reason code is no longer in use.",
+	},
+	entities.ErrCodeInvalidTransactionTag: {
+		LanguageJapanese: "タグは50文字以内で、改行を含めないでください",
+		LanguageEnglish:  "Tags must be 50 characters or fewer without line breaks.",
+	},
+	entities.ErrCodeInvalidUserImportFile: {
+		LanguageJapanese: "CSVのヘッダーと行数を確認してください",
+		LanguageEnglish:  "Please check the CSV header and the number of rows.",
+	},
+	entities.ErrCodeInvalidUserImportRow: {
+		LanguageJapanese: "ユーザー名・メールアドレス・名前・初期ポイント・役割を確認してください",
+		LanguageEnglish:  "Please check the username, email, name, initial points and role.",
+	},
+	entities.ErrCodeUserImportChunkFailed: {
+		LanguageJapanese: "同じまとまりの別の行でエラーが発生したため登録されませんでした。再度取り込んでください",
+		LanguageEnglish:  "Not imported because another row in the same batch failed. Please import it again.",
+	},
+	entities.ErrCodeInvalidTxImportFile: {
+		LanguageJapanese: "CSVのヘッダーと行数を確認してください",
+		LanguageEnglish:  "Please check the CSV header and the number of rows.",
+	},
+	entities.ErrCodeInvalidTxImportRow: {
+		LanguageJapanese: "日時（未来は不可）・送信者・受信者・金額・種別（transfer / admin_grant / admin_deduct / system_grant）を確認してください",
+		LanguageEnglish:  "Please check the date (not in the future), sender, recipient, amount and type (transfer, admin_grant, admin_deduct or system_grant).",
+	},
+	entities.ErrCodeDepartmentNotFound: {
+		LanguageJapanese: "部署が見つかりません",
+		LanguageEnglish:  "Department not found.",
+	},
+	entities.ErrCodeInvalidDepartment: {
+		LanguageJapanese: "部署名・親部署・予算を確認してください（部署を自分の配下に移すことはできません）",
+		LanguageEnglish:  "Please check the name, parent and budget. A department cannot be moved under itself.",
+	},
+	entities.ErrCodeDepartmentExists: {
+		LanguageJapanese: "同じ親部署に同じ名前の部署があります",
+		LanguageEnglish:  "A department with the same name already exists under this parent.",
+	},
+	entities.ErrCodeDepartmentHasChildren: {
+		LanguageJapanese: "配下に部署があるため削除できません",
+		LanguageEnglish:  "This department has sub-departments and cannot be deleted.",
+	},
+	entities.ErrCodeDepartmentOverBudget: {
+		LanguageJapanese: "部署の今月の付与予算を超えます",
+		LanguageEnglish:  "This grant exceeds the department's monthly budget.",
+	},
+	entities.ErrCodeBudgetNotFound: {
+		LanguageJapanese: "予算が見つかりません",
+		LanguageEnglish:  "Budget not found.",
+	},
+	entities.ErrCodeInvalidBudget: {
+		LanguageJapanese: "予算の名前・対象・期間・金額・超過時の扱いを確認してください",
+		LanguageEnglish:  "Please check the budget's name, scope, period, amount and enforcement.",
+	},
+	entities.ErrCodeBudgetExceeded: {
+		LanguageJapanese: "予算を超えるため付与できません",
+		LanguageEnglish:  "This grant exceeds the budget.",
+	},
+	entities.ErrCodeBudgetOverrideRequired: {
+		LanguageJapanese: "予算を超えます。超えて付与する場合は予算超過を許可してください",
+		LanguageEnglish:  "This grant exceeds the budget. Allow the budget override to grant anyway.",
+	},
+	entities.ErrCodePendingActionNotFound: {
+		LanguageJapanese: "承認待ちの操作が見つかりません",
+		LanguageEnglish:  "Pending action not found.",
+	},
+	entities.ErrCodePendingActionNotPending: {
+		LanguageJapanese: "この操作はすでに承認・却下されています",
+		LanguageEnglish:  "This action has already been reviewed.",
+	},
+	entities.ErrCodePendingActionExpired: {
+		LanguageJapanese: "この操作は承認期限を過ぎています。もう一度申請してください",
+		LanguageEnglish:  "This action has expired. Please submit it again.",
+	},
+	entities.ErrCodeSelfApproval: {
+		LanguageJapanese: "自分が申請した操作は承認できません",
+		LanguageEnglish:  "You cannot approve your own request.",
+	},
+	entities.ErrCodeInvalidApprovalComment: {
+		LanguageJapanese: "コメントは500文字以内で入力してください",
+		LanguageEnglish:  "Comment must be 500 characters or less.",
+	},
+	entities.ErrCodeInvalidApprovalLimit: {
+		LanguageJapanese: "承認が必要になる金額は0以上を指定してください",
+		LanguageEnglish:  "Approval threshold must be zero or more.",
+	},
+	entities.ErrCodeInvalidCronSchedule: {
+		LanguageJapanese: "cron式は「分 時 日 月 曜日」の5項目で指定してください",
+		LanguageEnglish:  "Cron expression must have 5 fields: minute hour day month weekday.",
+	},
+	entities.ErrCodeFailedAccessNotFound: {
+		LanguageJapanese: "付与に失敗した入退室記録が見つかりません",
+		LanguageEnglish:  "Failed access record not found.",
+	},
+	entities.ErrCodeFailedAccessNotDead: {
+		LanguageJapanese: "この入退室記録はまだ自動で再試行中です",
+		LanguageEnglish:  "This access record is still being retried automatically.",
+	},
+	entities.ErrCodeInvalidTimezone: {
+		LanguageJapanese: "タイムゾーンの指定が正しくありません（例: Asia/Tokyo）",
+		LanguageEnglish:  "Invalid timezone. Use an IANA timezone such as Asia/Tokyo.",
+	},
+	entities.ErrCodeTransferHeld: {
+		LanguageJapanese: "送金は確認のため保留されました。管理者の確認後に実行されます",
+		LanguageEnglish:  "The transfer is on hold for review and will be sent once an admin approves it.",
+	},
+	entities.ErrCodeTransferRejected: {
+		LanguageJapanese: "この送金は管理者により取り消されました",
+		LanguageEnglish:  "This transfer was rejected by an admin.",
+	},
+	entities.ErrCodeSuspiciousNotFound: {
+		LanguageJapanese: "不審な送金の記録が見つかりません",
+		LanguageEnglish:  "Suspicious activity not found.",
+	},
+	entities.ErrCodeSuspiciousReviewed: {
+		LanguageJapanese: "この記録は確認済みです",
+		LanguageEnglish:  "This activity has already been reviewed.",
+	},
+	entities.ErrCodeEmailVerificationNeeded: {
+		LanguageJapanese: "ポイントを送るにはメールアドレスの認証が必要です",
+		LanguageEnglish:  "Please verify your email address before sending points.",
+	},
+	entities.ErrCodeAccountTooNew: {
+		LanguageJapanese: "アカウントの作成直後はポイントを送れません",
+		LanguageEnglish:  "Your account is too new to send points.",
+	},
+	entities.ErrCodeInvalidEligibility: {
+		LanguageJapanese: "送金できるまでの時間は0〜8760時間で指定してください",
+		LanguageEnglish:  "Minimum account age must be between 0 and 8760 hours.",
+	},
+	entities.ErrCodeRecipientFriendsOnly: {
+		LanguageJapanese: "この相手は友達からのポイントだけを受け取る設定です",
+		LanguageEnglish:  "This user only accepts points from friends.",
+	},
+	entities.ErrCodeInvalidAcceptance: {
+		LanguageJapanese: "ポイントを受け取る相手は anyone（誰からでも）か friends_only（友達だけ）で指定してください",
+		LanguageEnglish:  "Accept points from must be anyone or friends_only.",
+	},
+	entities.ErrCodeUnauthorized: {
+		LanguageJapanese: "ログインが必要です",
+		LanguageEnglish:  "Authentication is required.",
+	},
+	entities.ErrCodeValidationFailed: {
+		LanguageJapanese: "入力内容に誤りがあります",
+		LanguageEnglish:  "The request is invalid.",
+	},
+	entities.ErrCodeInvalidCSRFToken: {
+		LanguageJapanese: "CSRFトークンが無効です。ページを再読み込みしてください",
+		LanguageEnglish:  "Invalid CSRF token. Please reload the page.",
+	},
+	entities.ErrCodeInvalidKioskDevice: {
+		LanguageJapanese: "登録されていない端末です",
+		LanguageEnglish:  "This kiosk device is not registered.",
+	},
+	entities.ErrCodeRequestTooLarge: {
+		LanguageJapanese: "リクエストボディが大きすぎます",
+		LanguageEnglish:  "The request body is too large.",
+	},
+	entities.ErrCodeUnsupportedMediaType: {
+		LanguageJapanese: "Content-Typeはapplication/jsonを指定してください",
+		LanguageEnglish:  "Content-Type must be application/json.",
+	},
+	entities.ErrCodeTooManyConnections: {
+		LanguageJapanese: "同時接続数の上限に達しました",
+		LanguageEnglish:  "Too many connections.",
+	},
+	entities.ErrCodeRouteNotFound: {
+		LanguageJapanese: "APIが見つかりません",
+		LanguageEnglish:  "The requested API was not found.",
+	},
+	entities.ErrCodeRequestTimeout: {
+		LanguageJapanese: "処理に時間がかかりすぎたため中断しました。時間をおいて再度お試しください",
+		LanguageEnglish:  "The request took too long and was cancelled. Please try again later.",
+	},
+	entities.ErrCodeTransferAmountTooSmall: {
+		LanguageJapanese: "送金額が下限を下回っています",
+		LanguageEnglish:  "The transfer amount is below the minimum.",
+	},
+	entities.ErrCodeTransferAmountTooLarge: {
+		LanguageJapanese: "送金額が上限を超えています",
+		LanguageEnglish:  "The transfer amount exceeds the maximum.",
+	},
+	entities.ErrCodeInvalidTransferPolicy: {
+		LanguageJapanese: "送金額の上下限・手数料・手数料の受け取り用アカウントの設定が不正です",
+		LanguageEnglish:  "Invalid transfer policy. Check the limits, fee and fee account.",
+	},
+	entities.ErrCodeEarningRuleNotFound: {
+		LanguageJapanese: "ポイント獲得ルールが見つかりません",
+		LanguageEnglish:  "Earning rule not found.",
+	},
+	entities.ErrCodeInvalidEarningRule: {
+		LanguageJapanese: "ポイント獲得ルールの内容が正しくありません（きっかけ、ポイント数、条件、付与回数の上限、期間を確認してください）",
+		LanguageEnglish:  "Invalid earning rule. Check the trigger, points, conditions, limits and period.",
+	},
+	entities.ErrCodeTenantNotFound: {
+		LanguageJapanese: "テナントが見つかりません",
+		LanguageEnglish:  "Tenant not found.",
+	},
+	entities.ErrCodeTenantInactive: {
+		LanguageJapanese: "このテナントは利用停止中です",
+		LanguageEnglish:  "This tenant is inactive.",
+	},
+	entities.ErrCodeTenantSlugTaken: {
+		LanguageJapanese: "このスラッグは既に使われています",
+		LanguageEnglish:  "This tenant slug is already taken.",
+	},
+	entities.ErrCodeInvalidTenant: {
+		LanguageJapanese: "テナントのスラッグ（英小文字・数字・ハイフン2〜40文字）または名前が正しくありません",
+		LanguageEnglish:  "Invalid tenant. The slug must be 2-40 lowercase letters, digits or hyphens, and the name is required.",
+	},
+	entities.ErrCodeSuperAdminRequired: {
+		LanguageJapanese: "スーパー管理者の権限が必要です",
+		LanguageEnglish:  "Super administrator privileges are required.",
+	},
+	entities.ErrCodeInvalidArchiveQuery: {
+		LanguageJapanese: "保管済みの取引の検索には366日以内の期間（from・to）を、集計の年月はYYYY-MMの形式で指定してください",
+		LanguageEnglish:  "Specify a period (from, to) of at most 366 days to search archived transactions, and months as YYYY-MM.",
+	},
+	entities.ErrCodeSplitRequestNotFound: {
+		LanguageJapanese: "割り勘のリクエストが見つかりません",
+		LanguageEnglish:  "Split request not found.",
+	},
+	entities.ErrCodeInvalidSplitRequest: {
+		LanguageJapanese: "割り勘の内容が正しくありません（合計・参加者・各自の金額を確認してください）",
+		LanguageEnglish:  "Invalid split request. Check the total, participants and shares.",
+	},
+	entities.ErrCodeSplitRequestClosed: {
+		LanguageJapanese: "この割り勘は既に完了またはキャンセルされています",
+		LanguageEnglish:  "This split request is already completed or cancelled.",
+	},
+	entities.ErrCodeSplitShareNotCounter: {
+		LanguageJapanese: "割り勘の支払いは金額を変更できません",
+		LanguageEnglish:  "The amount of a split share cannot be changed.",
+	},
+	entities.ErrCodeNotFriends: {
+		LanguageJapanese: "友達にのみリクエストできます",
+		LanguageEnglish:  "You can only send requests to friends.",
+	},
+	entities.ErrCodeInvalidOnboardingBonus: {
+		LanguageJapanese: "ウェルカムボーナスの設定が正しくありません（金額・有効日数を確認してください）",
+		LanguageEnglish:  "Invalid onboarding bonus. Check the amount and validity days.",
+	},
+	entities.ErrCodeAkerunRepollNotFound: {
+		LanguageJapanese: "Akerunの再取得が見つかりません",
+		LanguageEnglish:  "Akerun repoll not found.",
+	},
+	entities.ErrCodeAkerunRepollInProgress: {
+		LanguageJapanese: "別のAkerunの再取得が処理待ちまたは実行中です。完了してから依頼してください",
+		LanguageEnglish:  "Another Akerun repoll is pending or running. Wait until it finishes.",
+	},
+	entities.ErrCodeInvalidAkerunRepoll: {
+		LanguageJapanese: "再取得の期間は過去の7日以内（開始 < 終了）で、設定済みの組織を指定してください",
+		LanguageEnglish:  "Specify a past period of at most 7 days (from < to) and a configured organization.",
+	},
+	entities.ErrCodeCartEmpty: {
+		LanguageJapanese: "カートに商品がありません",
+		LanguageEnglish:  "Your cart is empty.",
+	},
+	entities.ErrCodeCartFull: {
+		LanguageJapanese: "カートに入れられる商品の種類の上限に達しています",
+		LanguageEnglish:  "Your cart has reached the maximum number of items.",
+	},
+	entities.ErrCodeCartItemNotFound: {
+		LanguageJapanese: "カートに商品が見つかりません",
+		LanguageEnglish:  "The product is not in your cart.",
+	},
+	entities.ErrCodeCartItemUnavailable: {
+		LanguageJapanese: "カートの商品に交換できないものがあります（在庫切れ・販売停止）。カートを見直してください",
+		LanguageEnglish:  "A product in your cart cannot be exchanged (out of stock or unavailable). Review your cart.",
+	},
+	entities.ErrCodeInvalidGift: {
+		LanguageJapanese: "贈り物は自分以外の友達に送ってください",
+		LanguageEnglish:  "Send gifts to a friend other than yourself.",
+	},
+	entities.ErrCodeGiftNotFound: {
+		LanguageJapanese: "贈り物が見つかりません",
+		LanguageEnglish:  "Gift not found.",
+	},
+	entities.ErrCodeGiftAlreadyAnswered: {
+		LanguageJapanese: "この贈り物はすでに受け取りまたは辞退しています",
+		LanguageEnglish:  "This gift has already been accepted or declined.",
+	},
+	entities.ErrCodeGiftAwaitingResponse: {
+		LanguageJapanese: "贈られた人がまだ受け取っていないため、受け渡しできません",
+		LanguageEnglish:  "The recipient has not accepted this gift yet.",
+	},
+	entities.ErrCodeInvalidShippingAddress: {
+		LanguageJapanese: "お届け先の入力内容が正しくありません",
+		LanguageEnglish:  "The shipping address is invalid.",
+	},
+	entities.ErrCodeShippingAddressNotFound: {
+		LanguageJapanese: "お届け先が見つかりません",
+		LanguageEnglish:  "Shipping address not found.",
+	},
+	entities.ErrCodeShippingAddressLimit: {
+		LanguageJapanese: "登録できるお届け先の上限に達しています",
+		LanguageEnglish:  "You have reached the maximum number of shipping addresses.",
+	},
+	entities.ErrCodeShippingAddressRequired: {
+		LanguageJapanese: "配送が必要な商品です。お届け先を登録してください",
+		LanguageEnglish:  "This product is shipped. Register a shipping address first.",
+	},
+	entities.ErrCodeInvalidEmailTemplate: {
+		LanguageJapanese: "メールテンプレートの内容が正しくありません",
+		LanguageEnglish:  "The email template is invalid.",
+	},
+	entities.ErrCodeEmailTemplateNotFound: {
+		LanguageJapanese: "メールテンプレートが見つかりません",
+		LanguageEnglish:  "Email template not found.",
+	},
+	entities.ErrCodeInvalidBonusReportQuery: {
+		LanguageJapanese: "ボーナスの出力には366日以内の期間（from・to）をYYYY-MM-DDの形式で、出席表の年月はYYYY-MMの形式で指定してください",
+		LanguageEnglish:  "Specify a period (from, to) of at most 366 days as YYYY-MM-DD to export bonuses, and the attendance month as YYYY-MM.",
+	},
+	entities.ErrCodeFriendPinLimit: {
+		LanguageJapanese: "ピン留めできる友達の上限に達しています",
+		LanguageEnglish:  "You have reached the maximum number of pinned friends.",
+	},
+	entities.ErrCodePinTargetNotFriend: {
+		LanguageJapanese: "ピン留めできるのは友達だけです",
+		LanguageEnglish:  "You can only pin friends.",
+	},
+	entities.ErrCodeAccountDeactivated: {
+		LanguageJapanese: "アカウントは休止中です。再びログインすると再開できます",
+		LanguageEnglish:  "This account is deactivated. Log in again to reactivate it.",
+	},
+	entities.ErrCodeReactivationExpired: {
+		LanguageJapanese: "休止したアカウントを再開できる期間を過ぎています。管理者にお問い合わせください",
+		LanguageEnglish:  "The period to reactivate this account has passed. Please contact an administrator.",
+	},
+	entities.ErrCodeSessionReauthRequired: {
+		LanguageJapanese: "ログインしたときと異なる端末・ネットワークからの利用のため、もう一度ログインしてください",
+		LanguageEnglish:  "This session was used from a different device or network. Please log in again.",
+	},
+	entities.ErrCodeCSRFTokenExpired: {
+		LanguageJapanese: "CSRFトークンの有効期限が切れました。トークンを取得し直してください",
+		LanguageEnglish:  "The CSRF token has expired. Please fetch a new token.",
+	},
+	entities.ErrCodeEmailNotVerified: {
+		LanguageJapanese: "メールアドレスの認証が済んでいません。届いたメールのリンクから認証してください",
+		LanguageEnglish:  "Your email address has not been verified. Please use the link in the verification email.",
+	},
+	entities.ErrCodeInvalidEmailRequirement: {
+		LanguageJapanese: "メール認証の設定が正しくありません",
+		LanguageEnglish:  "The email verification requirement is invalid.",
+	},
+	entities.ErrCodeExchangeNotFound: {
+		LanguageJapanese: "交換が見つかりません",
+		LanguageEnglish:  "Exchange not found.",
+	},
+	entities.ErrCodeActionNotPermitted: {
+		LanguageJapanese: "この操作を行う権限がありません",
+		LanguageEnglish:  "You are not allowed to perform this action.",
+	},
+	entities.ErrCodeCannotMuteSelf: {
+		LanguageJapanese: "自分自身はミュートできません",
+		LanguageEnglish:  "You cannot mute yourself.",
+	},
+	entities.ErrCodeInvalidMuteMode: {
+		LanguageJapanese: "ミュートの方法は reject（自動で拒否）か drop（知らせずに保留）で指定してください",
+		LanguageEnglish:  "Mode must be reject or drop.",
+	},
+	entities.ErrCodePendingRequestLimit: {
+		LanguageJapanese: "承認待ちの送金リクエストが多すぎます。相手の返事を待つか、いくつかキャンセルしてください",
+		LanguageEnglish:  "You have too many pending transfer requests. Wait for replies or cancel some of them.",
+	},
+	entities.ErrCodeRecipientRequestLimit: {
+		LanguageJapanese: "この相手への承認待ちの送金リクエストが多すぎます。相手の返事を待ってください",
+		LanguageEnglish:  "You have too many pending transfer requests to this user. Wait for a reply.",
+	},
+	entities.ErrCodeInvalidRequestQuota: {
+		LanguageJapanese: "上限は0以上で、相手ごとの上限は全体の上限以下にしてください",
+		LanguageEnglish:  "Limits must not be negative, and the per-recipient limit must not exceed the overall limit.",
+	},
+	entities.ErrCodeBalanceHoldSettled: {
+		LanguageJapanese: "確保したポイントはすでに使われたか、戻されています",
+		LanguageEnglish:  "The reserved points have already been used or released.",
+	},
+	entities.ErrCodeInvalidQRPayload: {
+		LanguageJapanese: "読み取れないQRコードです",
+		LanguageEnglish:  "This QR code could not be read.",
+	},
+	entities.ErrCodeUnsupportedQRVersion: {
+		LanguageJapanese: "このQRコードを読み取るにはアプリを更新してください",
+		LanguageEnglish:  "Update the app to read this QR code.",
+	},
+	entities.ErrCodeTransactionNotFound: {
+		LanguageJapanese: "取引が見つかりません",
+		LanguageEnglish:  "Transaction not found.",
+	},
+	entities.ErrCodeReceiptUnavailable: {
+		LanguageJapanese: "控えは完了した取引のみ発行できます",
+		LanguageEnglish:  "Receipts are available only for completed transactions.",
+	},
+	entities.ErrCodeAttachmentNotFound: {
+		LanguageJapanese: "添付画像が見つかりません",
+		LanguageEnglish:  "Attachment not found.",
+	},
+	entities.ErrCodeAttachmentNotAllowed: {
+		LanguageJapanese: "画像を添付できるのは完了した送金と承認待ちの送金リクエストのみです",
+		LanguageEnglish:  "Images can be attached only to completed transfers and pending transfer requests.",
+	},
+	entities.ErrCodeInvalidAttachment: {
+		LanguageJapanese: "添付する画像を選択してください",
+		LanguageEnglish:  "Select an image to attach.",
+	},
+}
+
+// errorDetails はParamsがある場合にメッセージへ付け加える詳細（{name} はParamsで置換）
+var errorDetails = map[entities.ErrorCode]map[Language]string{
+	entities.ErrCodeInsufficientBalance: {
+		LanguageJapanese: "（残高: {balance}、必要: {required}）",
+		LanguageEnglish:  " (balance: {balance}, required: {required})",
+	},
+	entities.ErrCodeContentRejected: {
+		LanguageJapanese: "（項目: {field}）",
+		LanguageEnglish:  " (field: {field})",
+	},
+	entities.ErrCodeSaleLimitExceeded: {
+		LanguageJapanese: "（上限: {limit}、残り: {remaining}）",
+		LanguageEnglish:  " (limit: {limit}, remaining: {remaining})",
+	},
+	entities.ErrCodeDepartmentOverBudget: {
+		LanguageJapanese: "（予算: {budget}、残り: {remaining}）",
+		LanguageEnglish:  " (budget: {budget}, remaining: {remaining})",
+	},
+	entities.ErrCodeBudgetExceeded: {
+		LanguageJapanese: "（{name}: 予算 {budget}、残り {remaining}）",
+		LanguageEnglish:  " ({name}: budget {budget}, remaining {remaining})",
+	},
+	entities.ErrCodeBudgetOverrideRequired: {
+		LanguageJapanese: "（{name}: 予算 {budget}、残り {remaining}）",
+		LanguageEnglish:  " ({name}: budget {budget}, remaining {remaining})",
+	},
+	entities.ErrCodeAccountTooNew: {
+		LanguageJapanese: "（{available_at}から送れます）",
+		LanguageEnglish:  " (available from {available_at})",
+	},
+	entities.ErrCodeTransferAmountTooSmall: {
+		LanguageJapanese: "（下限: {min_amount}）",
+		LanguageEnglish:  " (minimum: {min_amount})",
+	},
+	entities.ErrCodeTransferAmountTooLarge: {
+		LanguageJapanese: "（上限: {max_amount}）",
+		LanguageEnglish:  " (maximum: {max_amount})",
+	},
+	entities.ErrCodeCartItemUnavailable: {
+		LanguageJapanese: "（商品: {product}）",
+		LanguageEnglish:  " (product: {product})",
+	},
+	entities.ErrCodeInvalidShippingAddress: {
+		LanguageJapanese: "（項目: {field}）",
+		LanguageEnglish:  " (field: {field})",
+	},
+	entities.ErrCodeShippingAddressLimit: {
+		LanguageJapanese: "（上限: {max}件）",
+		LanguageEnglish:  " (maximum: {max})",
+	},
+	entities.ErrCodeInvalidEmailTemplate: {
+		LanguageJapanese: "（{reason}）",
+		LanguageEnglish:  " ({reason})",
+	},
+	entities.ErrCodeFriendPinLimit: {
+		LanguageJapanese: "（上限: {max}人）",
+		LanguageEnglish:  " (maximum: {max})",
+	},
+	entities.ErrCodeReactivationExpired: {
+		LanguageJapanese: "（再開できる期間: 休止から{days}日）",
+		LanguageEnglish:  " (reactivation period: {days} days after deactivation)",
+	},
+	entities.ErrCodePendingRequestLimit: {
+		LanguageJapanese: "（上限: {max}件）",
+		LanguageEnglish:  " (maximum: {max})",
+	},
+	entities.ErrCodeRecipientRequestLimit: {
+		LanguageJapanese: "（上限: 1人あたり{max}件）",
+		LanguageEnglish:  " (maximum: {max} per recipient)",
+	},
+}
+
+// genericErrorCodes はドメインエラー以外のエラーに付与するコード（HTTPステータス別）
+var genericErrorCodes = map[int]string{
+	http.StatusBadRequest:            "bad_request",
+	http.StatusUnauthorized:          "unauthorized",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not_found",
+	http.StatusConflict:              "conflict",
+	http.StatusRequestEntityTooLarge: "request_too_large",
+	http.StatusUnsupportedMediaType:  "unsupported_media_type",
+	http.StatusTooManyRequests:       "too_many_requests",
+	http.StatusInternalServerError:   "internal_error",
+	http.StatusServiceUnavailable:    "service_unavailable",
+}
+
+// LocalizeError はドメインエラーを指定言語のメッセージに変換
+// カタログに無いコードは英語のエラーメッセージをそのまま返す
+func LocalizeError(de *entities.DomainError, lang Language) string {
+	messages, ok := errorMessages[de.Code]
+	if !ok {
+		return de.Message
+	}
+	message, ok := messages[lang]
+	if !ok {
+		message = messages[DefaultLanguage]
+	}
+
+	// 詳細はすべてのパラメータが揃っている場合のみ付け加える
+	if details, ok := errorDetails[de.Code]; ok && len(de.Params) > 0 {
+		detail := details[lang]
+		for key, value := range de.Params {
+			detail = strings.ReplaceAll(detail, "{"+key+"}", fmt.Sprint(value))
+		}
+		if !strings.Contains(detail, "{") {
+			message += detail
+		}
+	}
+	return message
+}
+
+// NegotiateLanguage はAccept-Languageヘッダーから対応言語を選択
+// q値の大きい順に ja / en を探し、見つからなければDefaultLanguageを返す
+func NegotiateLanguage(acceptLanguage string) Language {
+	best := DefaultLanguage
+	bestQ := -1.0
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		tag := part
+		q := 1.0
+		if i := strings.Index(part, ";"); i >= 0 {
+			tag = strings.TrimSpace(part[:i])
+			var parsed float64
+			if _, err := fmt.Sscanf(strings.TrimSpace(part[i+1:]), "q=%g", &parsed); err == nil {
+				q = parsed
+			}
+		}
+
+		primary := strings.ToLower(tag)
+		if i := strings.Index(primary, "-"); i >= 0 {
+			primary = primary[:i]
+		}
+
+		var lang Language
+		switch primary {
+		case "ja":
+			lang = LanguageJapanese
+		case "en":
+			lang = LanguageEnglish
+		default:
+			continue
+		}
+
+		if q > bestQ {
+			best = lang
+			bestQ = q
+		}
+	}
+
+	return best
+}
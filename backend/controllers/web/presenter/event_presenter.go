@@ -0,0 +1,84 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// EventPresenter はチェックインイベントのPresenter
+type EventPresenter struct{}
+
+// NewEventPresenter は新しいEventPresenterを作成
+func NewEventPresenter() *EventPresenter {
+	return &EventPresenter{}
+}
+
+// PresentEvent はイベントをJSON形式に変換（管理者向け。QRコードのデータを含む）
+func (p *EventPresenter) PresentEvent(e *entities.Event) gin.H {
+	return gin.H{
+		"id":             e.ID,
+		"name":           e.Name,
+		"description":    e.Description,
+		"points":         e.Points,
+		"capacity":       e.Capacity,
+		"attendee_count": e.AttendeeCount,
+		"qr_code_data":   e.QRCodeData(),
+		"starts_at":      e.StartsAt,
+		"ends_at":        e.EndsAt,
+		"is_active":      e.IsActive,
+		"created_by":     e.CreatedBy,
+		"created_at":     e.CreatedAt,
+		"updated_at":     e.UpdatedAt,
+	}
+}
+
+// PresentEventList はイベント一覧をJSON形式に変換
+func (p *EventPresenter) PresentEventList(resp *inputport.GetEventListResponse) gin.H {
+	events := make([]gin.H, 0, len(resp.Events))
+	for _, e := range resp.Events {
+		events = append(events, p.PresentEvent(e))
+	}
+	return gin.H{
+		"events": events,
+		"total":  resp.Total,
+		"offset": resp.Offset,
+		"limit":  resp.Limit,
+	}
+}
+
+// PresentEventAttendees はイベントの参加者一覧をJSON形式に変換
+func (p *EventPresenter) PresentEventAttendees(resp *inputport.GetEventAttendeesResponse) gin.H {
+	attendees := make([]gin.H, 0, len(resp.Attendees))
+	for _, a := range resp.Attendees {
+		attendees = append(attendees, gin.H{
+			"user_id":       a.UserID,
+			"username":      a.Username,
+			"display_name":  a.DisplayName,
+			"points":        a.Points,
+			"checked_in_at": a.CheckedInAt,
+		})
+	}
+	return gin.H{
+		"event":     p.PresentEvent(resp.Event),
+		"attendees": attendees,
+		"total":     resp.Event.AttendeeCount,
+		"offset":    resp.Offset,
+		"limit":     resp.Limit,
+	}
+}
+
+// PresentCheckIn はチェックインの結果をJSON形式に変換（参加者向け。QRコードのデータは含めない）
+func (p *EventPresenter) PresentCheckIn(resp *inputport.EventCheckInResponse) gin.H {
+	return gin.H{
+		"event": gin.H{
+			"id":          resp.Event.ID,
+			"name":        resp.Event.Name,
+			"description": resp.Event.Description,
+			"ends_at":     resp.Event.EndsAt,
+		},
+		"points":         resp.Attendance.Points,
+		"transaction_id": resp.Transaction.ID,
+		"checked_in_at":  resp.Attendance.CreatedAt,
+	}
+}
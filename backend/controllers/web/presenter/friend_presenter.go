@@ -28,8 +28,25 @@ type FriendshipResponse struct {
 
 // FriendInfoResponse は友達情報のレスポンス
 type FriendInfoResponse struct {
-	Friendship FriendshipResponse `json:"friendship"`
-	Friend     UserResponse       `json:"friend"`
+	Friendship      FriendshipResponse      `json:"friendship"`
+	Friend          UserResponse            `json:"friend"`
+	Since           time.Time               `json:"since"`
+	PointsExchanged PointsExchangedResponse `json:"points_exchanged"`
+}
+
+// PointsExchangedResponse は友達とやり取りしたポイントのレスポンス
+type PointsExchangedResponse struct {
+	Sent     int64 `json:"sent"`
+	Received int64 `json:"received"`
+	Total    int64 `json:"total"`
+}
+
+// FriendSummaryResponse は一覧表示用の友達のレスポンス（共通の友達・クイック送金）
+type FriendSummaryResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	AvatarURL   *string   `json:"avatar_url,omitempty"`
 }
 
 // PendingRequestInfoResponse は保留中申請情報のレスポンス
@@ -38,6 +55,22 @@ type PendingRequestInfoResponse struct {
 	Requester  UserResponse       `json:"requester"`
 }
 
+// DiscoveredUserResponse は友達検索で見つかったユーザーのレスポンス
+// 友達になる前のユーザーなので残高などは含めない
+type DiscoveredUserResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	AvatarURL   *string   `json:"avatar_url,omitempty"`
+}
+
+// ContactMatchResponse は連絡先ハッシュの一致結果のレスポンス
+type ContactMatchResponse struct {
+	Hash      string                 `json:"hash"`
+	MatchedBy string                 `json:"matched_by"`
+	User      DiscoveredUserResponse `json:"user"`
+}
+
 // PresentSendFriendRequest は友達申請送信レスポンスを生成
 func (p *FriendPresenter) PresentSendFriendRequest(resp *inputport.SendFriendRequestResponse) map[string]interface{} {
 	return map[string]interface{}{
@@ -66,6 +99,12 @@ func (p *FriendPresenter) PresentGetFriends(resp *inputport.GetFriendsResponse)
 		friends = append(friends, FriendInfoResponse{
 			Friendship: p.toFriendshipResponse(f.Friendship),
 			Friend:     p.toUserResponse(f.Friend),
+			Since:      f.Since,
+			PointsExchanged: PointsExchangedResponse{
+				Sent:     f.PointsExchanged.Sent,
+				Received: f.PointsExchanged.Received,
+				Total:    f.PointsExchanged.Total(),
+			},
 		})
 	}
 
@@ -74,6 +113,65 @@ func (p *FriendPresenter) PresentGetFriends(resp *inputport.GetFriendsResponse)
 	}
 }
 
+// PresentGetMutualFriends は共通の友達一覧レスポンスを生成
+// 一覧の表示に必要な項目だけを返す（残高などは含めない）
+func (p *FriendPresenter) PresentGetMutualFriends(resp *inputport.GetMutualFriendsResponse) map[string]interface{} {
+	friends := make([]FriendSummaryResponse, 0, len(resp.Friends))
+	for _, u := range resp.Friends {
+		friends = append(friends, FriendSummaryResponse{
+			ID:          u.ID,
+			Username:    u.Username,
+			DisplayName: u.DisplayName,
+			AvatarURL:   u.AvatarURL,
+		})
+	}
+
+	return map[string]interface{}{
+		"mutual_friends": friends,
+		"count":          resp.Count,
+	}
+}
+
+// QuickSendEntryResponse はクイック送金の1件のレスポンス
+type QuickSendEntryResponse struct {
+	Friend         FriendSummaryResponse `json:"friend"`
+	Pinned         bool                  `json:"pinned"`
+	PinnedAt       *time.Time            `json:"pinned_at,omitempty"`
+	LastAmount     *int64                `json:"last_amount"`     // 集計期間に送金していなければnull
+	FrequentAmount *int64                `json:"frequent_amount"` // 同上
+	TransferCount  int64                 `json:"transfer_count"`
+	LastSentAt     *time.Time            `json:"last_sent_at,omitempty"`
+}
+
+// PresentQuickSend はクイック送金の友達一覧レスポンスを生成
+func (p *FriendPresenter) PresentQuickSend(entries []*entities.QuickSendEntry) map[string]interface{} {
+	friends := make([]QuickSendEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		r := QuickSendEntryResponse{
+			Friend: FriendSummaryResponse{
+				ID:          e.Friend.ID,
+				Username:    e.Friend.Username,
+				DisplayName: e.Friend.DisplayName,
+				AvatarURL:   e.Friend.AvatarURL,
+			},
+			Pinned:   e.PinnedAt != nil,
+			PinnedAt: e.PinnedAt,
+		}
+		if a := e.Amounts; a != nil {
+			r.LastAmount = &a.LastAmount
+			r.FrequentAmount = &a.FrequentAmount
+			r.TransferCount = a.TransferCount
+			r.LastSentAt = &a.LastSentAt
+		}
+		friends = append(friends, r)
+	}
+
+	return map[string]interface{}{
+		"friends":       friends,
+		"lookback_days": int(entities.QuickSendLookback.Hours() / 24),
+	}
+}
+
 // PresentGetPendingRequests は保留中申請一覧レスポンスを生成
 func (p *FriendPresenter) PresentGetPendingRequests(resp *inputport.GetPendingRequestsResponse) map[string]interface{} {
 	requests := make([]PendingRequestInfoResponse, 0, len(resp.Requests))
@@ -96,6 +194,27 @@ func (p *FriendPresenter) PresentRemoveFriend(resp *inputport.RemoveFriendRespon
 	}
 }
 
+// PresentDiscoverFriends は連絡先ハッシュによる友達検索レスポンスを生成
+func (p *FriendPresenter) PresentDiscoverFriends(resp *inputport.DiscoverFriendsResponse) map[string]interface{} {
+	matches := make([]ContactMatchResponse, 0, len(resp.Matches))
+	for _, m := range resp.Matches {
+		matches = append(matches, ContactMatchResponse{
+			Hash:      m.Hash,
+			MatchedBy: string(m.MatchedBy),
+			User: DiscoveredUserResponse{
+				ID:          m.User.ID,
+				Username:    m.User.Username,
+				DisplayName: m.User.DisplayName,
+				AvatarURL:   m.User.AvatarURL,
+			},
+		})
+	}
+
+	return map[string]interface{}{
+		"matches": matches,
+	}
+}
+
 // toFriendshipResponse はFriendshipエンティティをレスポンスに変換
 func (p *FriendPresenter) toFriendshipResponse(friendship *entities.Friendship) FriendshipResponse {
 	return FriendshipResponse{
@@ -0,0 +1,102 @@
+package presenter
+
+import (
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// KioskPresenter はキオスク端末機能のプレゼンター
+type KioskPresenter struct{}
+
+// NewKioskPresenter は新しいKioskPresenterを作成
+func NewKioskPresenter() *KioskPresenter {
+	return &KioskPresenter{}
+}
+
+// PresentLookupUser はユーザー検索レスポンスを生成
+// 端末は共用のため、メールアドレスや残高は返さない
+func (p *KioskPresenter) PresentLookupUser(resp *inputport.KioskLookupUserResponse) map[string]interface{} {
+	return map[string]interface{}{
+		"user":            p.presentKioskUser(resp.User),
+		"already_granted": resp.AlreadyGranted,
+	}
+}
+
+// PresentGrantBonus はボーナス付与レスポンスを生成
+func (p *KioskPresenter) PresentGrantBonus(resp *inputport.KioskGrantBonusResponse) map[string]interface{} {
+	return map[string]interface{}{
+		"grant": map[string]interface{}{
+			"id":              resp.Grant.ID,
+			"device_id":       resp.Grant.DeviceID,
+			"user_id":         resp.Grant.UserID,
+			"transaction_id":  resp.Grant.TransactionID,
+			"amount":          resp.Grant.Amount,
+			"idempotency_key": resp.Grant.IdempotencyKey,
+			"created_at":      resp.Grant.CreatedAt,
+		},
+		"user":     p.presentKioskUser(resp.User),
+		"replayed": resp.Replayed,
+	}
+}
+
+// PresentRegisterDevice は端末登録・APIキー再発行レスポンスを生成
+func (p *KioskPresenter) PresentRegisterDevice(resp *inputport.RegisterKioskDeviceResponse) map[string]interface{} {
+	return map[string]interface{}{
+		"device":  p.presentDevice(resp.Device),
+		"api_key": resp.APIKey,
+	}
+}
+
+// PresentListDevices は端末一覧レスポンスを生成
+func (p *KioskPresenter) PresentListDevices(resp *inputport.ListKioskDevicesResponse) map[string]interface{} {
+	devices := make([]map[string]interface{}, len(resp.Devices))
+	for i, device := range resp.Devices {
+		devices[i] = p.presentDevice(device)
+	}
+
+	return map[string]interface{}{
+		"devices": devices,
+	}
+}
+
+// PresentUpdateDevice は端末設定更新レスポンスを生成
+func (p *KioskPresenter) PresentUpdateDevice(resp *inputport.UpdateKioskDeviceResponse) map[string]interface{} {
+	return map[string]interface{}{
+		"device": p.presentDevice(resp.Device),
+	}
+}
+
+// PresentCard はカード紐付けレスポンスを生成
+func (p *KioskPresenter) PresentCard(card *entities.KioskCard) map[string]interface{} {
+	return map[string]interface{}{
+		"card": map[string]interface{}{
+			"card_id":    card.CardID,
+			"user_id":    card.UserID,
+			"created_at": card.CreatedAt,
+		},
+	}
+}
+
+// presentDevice は端末情報を変換（APIキーハッシュは含めない）
+func (p *KioskPresenter) presentDevice(device *entities.KioskDevice) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           device.ID,
+		"name":         device.Name,
+		"bonus_amount": device.BonusAmount,
+		"daily_limit":  device.DailyLimit,
+		"is_active":    device.IsActive,
+		"last_seen_at": device.LastSeenAt,
+		"created_at":   device.CreatedAt,
+		"updated_at":   device.UpdatedAt,
+	}
+}
+
+// presentKioskUser は端末表示用の最小限のユーザー情報を変換
+func (p *KioskPresenter) presentKioskUser(user *entities.User) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           user.ID,
+		"username":     user.Username,
+		"display_name": user.DisplayName,
+		"avatar_url":   user.AvatarURL,
+	}
+}
@@ -0,0 +1,38 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// MaintenancePresenter はメンテナンスモードのPresenter
+type MaintenancePresenter struct{}
+
+// NewMaintenancePresenter は新しいMaintenancePresenterを作成
+func NewMaintenancePresenter() *MaintenancePresenter {
+	return &MaintenancePresenter{}
+}
+
+// PresentStatus は一般ユーザー向けのメンテナンス状態をJSON形式に変換（許可リストは返さない）
+func (p *MaintenancePresenter) PresentStatus(mode *entities.MaintenanceMode) gin.H {
+	return gin.H{
+		"enabled": mode.Enabled,
+		"message": mode.Message,
+	}
+}
+
+// PresentSettings は管理者向けのメンテナンス設定をJSON形式に変換
+func (p *MaintenancePresenter) PresentSettings(mode *entities.MaintenanceMode) gin.H {
+	allowed := mode.AllowedUserIDs
+	if allowed == nil {
+		allowed = []uuid.UUID{}
+	}
+	return gin.H{
+		"enabled":          mode.Enabled,
+		"message":          mode.Message,
+		"allowed_user_ids": allowed,
+		"updated_by":       mode.UpdatedBy,
+		"updated_at":       mode.UpdatedAt,
+	}
+}
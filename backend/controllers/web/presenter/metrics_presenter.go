@@ -0,0 +1,68 @@
+package presenter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// OpenMetricsContentType はOpenMetricsのテキスト形式のContent-Type
+const OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// metricsNamespace は公開する指標の名前の接頭辞
+const metricsNamespace = "point_system"
+
+// MetricsPresenter は /metrics のPresenter
+type MetricsPresenter struct{}
+
+// NewMetricsPresenter は新しいMetricsPresenterを作成
+func NewMetricsPresenter() *MetricsPresenter {
+	return &MetricsPresenter{}
+}
+
+// PresentOpenMetrics は業務の指標をOpenMetricsのテキスト形式に変換
+// まだ集計できていなければ、集計の失敗回数だけを返す（0のゲージを返すとダッシュボードで本当の0と区別できないため）
+func (p *MetricsPresenter) PresentOpenMetrics(snapshot *inputport.BusinessMetricsSnapshot) string {
+	var b strings.Builder
+
+	if m := snapshot.Metrics; m != nil {
+		gauges := []struct {
+			name  string
+			help  string
+			value int64
+		}{
+			{"points_in_circulation", "Sum of all user balances.", m.PointsInCirculation},
+			{"points_issued_today", "Points granted from the treasury today (JST).", m.IssuedToday},
+			{"points_consumed_today", "Points returned to the treasury by deductions and product exchanges today (JST).", m.ConsumedToday},
+			{"points_transferred_today", "Points transferred between users today (JST).", m.TransferredToday},
+			{"transfer_requests_pending", "Open transfer requests awaiting approval or confirmation.", m.PendingTransferRequests},
+			{"active_users_today", "Users with session activity today (JST).", m.ActiveUsersToday},
+			{"business_metrics_collected_timestamp_seconds", "When the business metrics were last collected.", m.CollectedAt.Unix()},
+		}
+		for _, g := range gauges {
+			name := metricsNamespace + "_" + g.name
+			fmt.Fprintf(&b, "# TYPE %s gauge\n# HELP %s %s\n%s %d\n", name, name, g.help, name, g.value)
+		}
+	}
+
+	name := metricsNamespace + "_business_metrics_refresh_failures"
+	fmt.Fprintf(&b, "# TYPE %s counter\n# HELP %s Failed collections of the business metrics since startup.\n%s_total %d\n",
+		name, name, name, snapshot.RefreshFailures)
+
+	if len(snapshot.SlowQueries) > 0 {
+		name := metricsNamespace + "_db_slow_queries"
+		fmt.Fprintf(&b, "# TYPE %s counter\n# HELP %s Queries slower than the configured threshold since startup, by repository method.\n", name, name)
+		for _, q := range snapshot.SlowQueries {
+			fmt.Fprintf(&b, "%s_total{method=\"%s\"} %d\n", name, escapeLabelValue(q.Method), q.Count)
+		}
+	}
+
+	b.WriteString("# EOF\n")
+	return b.String()
+}
+
+// escapeLabelValue はラベルの値に使えない文字（\・"・改行）をエスケープする
+func escapeLabelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
@@ -0,0 +1,48 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// ModerationPresenter はコンテンツモデレーション違反記録のPresenter
+type ModerationPresenter struct{}
+
+// NewModerationPresenter は新しいModerationPresenterを作成
+func NewModerationPresenter() *ModerationPresenter {
+	return &ModerationPresenter{}
+}
+
+// PresentViolation は違反記録をJSON形式に変換
+func (p *ModerationPresenter) PresentViolation(v *entities.ContentViolation) gin.H {
+	matched := v.MatchedTerms
+	if matched == nil {
+		matched = []string{}
+	}
+	return gin.H{
+		"id":            v.ID,
+		"user_id":       v.UserID,
+		"field":         v.Field,
+		"content":       v.Content,
+		"matched_terms": matched,
+		"created_at":    v.CreatedAt,
+		"reviewed":      v.IsReviewed(),
+		"reviewed_at":   v.ReviewedAt,
+		"reviewed_by":   v.ReviewedBy,
+	}
+}
+
+// PresentViolations は違反記録一覧をJSON形式に変換
+func (p *ModerationPresenter) PresentViolations(resp *inputport.GetContentViolationsResponse) gin.H {
+	violations := make([]gin.H, 0, len(resp.Violations))
+	for _, v := range resp.Violations {
+		violations = append(violations, p.PresentViolation(v))
+	}
+	return gin.H{
+		"violations": violations,
+		"total":      resp.Total,
+		"offset":     resp.Offset,
+		"limit":      resp.Limit,
+	}
+}
@@ -0,0 +1,50 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// NotificationPresenter は通知の配信先・設定のPresenter
+type NotificationPresenter struct{}
+
+// NewNotificationPresenter は新しいNotificationPresenterを作成
+func NewNotificationPresenter() *NotificationPresenter {
+	return &NotificationPresenter{}
+}
+
+// PresentDevice は登録した端末をJSON形式に変換（トークン自体は返さない）
+func (p *NotificationPresenter) PresentDevice(device *entities.DeviceToken) gin.H {
+	return gin.H{
+		"device": gin.H{
+			"id":           device.ID,
+			"platform":     device.Platform,
+			"created_at":   device.CreatedAt,
+			"last_seen_at": device.LastSeenAt,
+		},
+	}
+}
+
+// PresentPreferences は通知設定をJSON形式に変換
+func (p *NotificationPresenter) PresentPreferences(prefs *entities.NotificationPreferences) gin.H {
+	return gin.H{
+		"preferences": gin.H{
+			"push_enabled":  prefs.PushEnabled,
+			"email_enabled": prefs.EmailEnabled,
+			"events": gin.H{
+				string(entities.NotificationTypeTransferRequest): prefs.TransferRequests,
+				string(entities.NotificationTypePointsReceived):  prefs.PointsReceived,
+				string(entities.NotificationTypePointsExpiring):  prefs.PointsExpiring,
+				string(entities.NotificationTypeNewLogin):        prefs.NewLogin,
+				string(entities.NotificationTypeWeeklyDigest):    prefs.WeeklyDigest,
+			},
+			"quiet_hours": gin.H{
+				"enabled":  prefs.QuietHours.Enabled,
+				"start":    prefs.QuietHours.StartString(),
+				"end":      prefs.QuietHours.EndString(),
+				"timezone": prefs.QuietHours.Timezone,
+			},
+			"updated_at": prefs.UpdatedAt,
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// OnboardingBonusPresenter はウェルカムボーナスの設定のPresenter
+type OnboardingBonusPresenter struct{}
+
+// NewOnboardingBonusPresenter は新しいOnboardingBonusPresenterを作成
+func NewOnboardingBonusPresenter() *OnboardingBonusPresenter {
+	return &OnboardingBonusPresenter{}
+}
+
+// PresentPolicy はウェルカムボーナスの設定をJSON形式に変換
+func (p *OnboardingBonusPresenter) PresentPolicy(policy *entities.OnboardingBonusPolicy) gin.H {
+	return gin.H{
+		"enabled":       policy.Enabled,
+		"amount":        policy.Amount,
+		"validity_days": policy.ValidityDays,
+		"updated_by":    policy.UpdatedBy,
+		"updated_at":    policy.UpdatedAt,
+	}
+}
@@ -0,0 +1,184 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// PointExpiryPolicyPresenter はポイント有効期間設定のPresenter
+type PointExpiryPolicyPresenter struct{}
+
+// NewPointExpiryPolicyPresenter は新しいPointExpiryPolicyPresenterを作成
+func NewPointExpiryPolicyPresenter() *PointExpiryPolicyPresenter {
+	return &PointExpiryPolicyPresenter{}
+}
+
+// PresentPolicies は有効期間設定の一覧をJSON形式に変換
+func (p *PointExpiryPolicyPresenter) PresentPolicies(resp *inputport.GetExpiryPoliciesResponse) gin.H {
+	policies := make([]gin.H, 0, len(resp.Policies))
+	for _, policy := range resp.Policies {
+		policies = append(policies, p.PresentPolicy(policy))
+	}
+	return gin.H{
+		"policies":              policies,
+		"default_validity_days": resp.DefaultValidityDays,
+		"grace_days":            resp.GraceDays,
+	}
+}
+
+// PresentPolicy は付与種別の有効期間をJSON形式に変換
+func (p *PointExpiryPolicyPresenter) PresentPolicy(policy *entities.PointExpiryPolicy) gin.H {
+	return gin.H{
+		"source_type":   policy.SourceType,
+		"validity_days": policy.ValidityDays,
+		"updated_by":    policy.UpdatedBy,
+		"updated_at":    policy.UpdatedAt,
+	}
+}
+
+// PresentUserOverride はユーザー個別の有効期間をJSON形式に変換（未設定の場合はnull）
+func (p *PointExpiryPolicyPresenter) PresentUserOverride(override *entities.UserPointExpiryOverride) gin.H {
+	if override == nil {
+		return gin.H{"override": nil}
+	}
+	return gin.H{
+		"override": gin.H{
+			"user_id":       override.UserID,
+			"validity_days": override.ValidityDays,
+			"reason":        override.Reason,
+			"updated_by":    override.UpdatedBy,
+			"updated_at":    override.UpdatedAt,
+		},
+	}
+}
+
+// PresentWorkerStatus はポイント有効期限ワーカーの実行状況をJSON形式に変換（未実行の場合はnull）
+func (p *PointExpiryPolicyPresenter) PresentWorkerStatus(run *entities.PointExpiryRun) gin.H {
+	if run == nil {
+		return gin.H{"status": nil}
+	}
+	return gin.H{
+		"status": gin.H{
+			"state":            run.State,
+			"started_at":       run.StartedAt,
+			"updated_at":       run.UpdatedAt,
+			"finished_at":      run.FinishedAt,
+			"pages":            run.Pages,
+			"expired_batches":  run.ExpiredBatches,
+			"expired_users":    run.ExpiredUsers,
+			"expired_points":   run.ExpiredPoints,
+			"extended_batches": run.ExtendedBatches,
+			"failed_batches":   run.FailedBatches,
+			"error":            run.Error,
+		},
+	}
+}
+
+// PresentRestorableBatches は取り消し可能なバッチ一覧をJSON形式に変換
+func (p *PointExpiryPolicyPresenter) PresentRestorableBatches(resp *inputport.ListRestorableBatchesResponse) gin.H {
+	batches := make([]gin.H, 0, len(resp.Batches))
+	for _, batch := range resp.Batches {
+		batches = append(batches, p.presentBatch(batch))
+	}
+	return gin.H{
+		"batches":    batches,
+		"grace_days": resp.GraceDays,
+	}
+}
+
+// PresentRestore は失効取り消しの結果をJSON形式に変換
+func (p *PointExpiryPolicyPresenter) PresentRestore(resp *inputport.RestoreBatchResponse) gin.H {
+	return gin.H{
+		"restored_batch": p.presentBatch(resp.RestoredBatch),
+		"new_batch":      p.presentBatch(resp.NewBatch),
+		"transaction": TransactionResponse{
+			ID:              resp.Transaction.ID,
+			FromUserID:      resp.Transaction.FromUserID,
+			ToUserID:        resp.Transaction.ToUserID,
+			Amount:          resp.Transaction.Amount,
+			TransactionType: string(resp.Transaction.TransactionType),
+			Status:          string(resp.Transaction.Status),
+			Description:     resp.Transaction.Description,
+			CreatedAt:       resp.Transaction.CreatedAt,
+		},
+		"balance": resp.User.Balance,
+	}
+}
+
+// PresentUserBatches はユーザーのバッチ一覧を調整の記録つきでJSON形式に変換
+func (p *PointExpiryPolicyPresenter) PresentUserBatches(resp *inputport.ListUserBatchesResponse) gin.H {
+	batches := make([]gin.H, 0, len(resp.Batches))
+	for _, batch := range resp.Batches {
+		b := p.presentBatch(batch)
+		adjustments := make([]gin.H, 0, len(resp.Adjustments[batch.ID]))
+		for _, a := range resp.Adjustments[batch.ID] {
+			adjustments = append(adjustments, p.presentAdjustment(a))
+		}
+		b["adjustments"] = adjustments
+		batches = append(batches, b)
+	}
+	return gin.H{
+		"batches": batches,
+		"total":   resp.Total,
+		"offset":  resp.Offset,
+		"limit":   resp.Limit,
+	}
+}
+
+// PresentAdjustBatch はバッチの調整の結果をJSON形式に変換
+func (p *PointExpiryPolicyPresenter) PresentAdjustBatch(resp *inputport.AdjustBatchResponse) gin.H {
+	result := gin.H{
+		"batch":      p.presentBatch(resp.Batch),
+		"adjustment": p.presentAdjustment(resp.Adjustment),
+		"balance":    resp.User.Balance,
+	}
+	if resp.Transaction != nil {
+		result["transaction"] = TransactionResponse{
+			ID:              resp.Transaction.ID,
+			FromUserID:      resp.Transaction.FromUserID,
+			ToUserID:        resp.Transaction.ToUserID,
+			Amount:          resp.Transaction.Amount,
+			TransactionType: string(resp.Transaction.TransactionType),
+			Status:          string(resp.Transaction.Status),
+			Description:     resp.Transaction.Description,
+			CreatedAt:       resp.Transaction.CreatedAt,
+		}
+	}
+	return result
+}
+
+func (p *PointExpiryPolicyPresenter) presentBatch(batch *entities.PointBatch) gin.H {
+	return gin.H{
+		"id":                 batch.ID,
+		"user_id":            batch.UserID,
+		"source_type":        batch.SourceType,
+		"status":             batch.Status(time.Now()),
+		"original_amount":    batch.OriginalAmount,
+		"remaining_amount":   batch.RemainingAmount,
+		"expires_at":         batch.ExpiresAt,
+		"expired_at":         batch.ExpiredAt,
+		"expired_amount":     batch.ExpiredAmount,
+		"restored_at":        batch.RestoredAt,
+		"expiry_adjusted_at": batch.ExpiryAdjustedAt,
+		"cancelled_at":       batch.CancelledAt,
+		"cancelled_amount":   batch.CancelledAmount,
+		"created_at":         batch.CreatedAt,
+	}
+}
+
+func (p *PointExpiryPolicyPresenter) presentAdjustment(a *entities.PointBatchAdjustment) gin.H {
+	return gin.H{
+		"id":                  a.ID,
+		"action":              a.Action,
+		"admin_id":            a.AdminID,
+		"previous_expires_at": a.PreviousExpiresAt,
+		"new_expires_at":      a.NewExpiresAt,
+		"cancelled_amount":    a.CancelledAmount,
+		"transaction_id":      a.TransactionID,
+		"reason":              a.Reason,
+		"created_at":          a.CreatedAt,
+	}
+}
@@ -2,20 +2,45 @@ package presenter
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 )
 
 // PointPresenter はポイント関連のPresenter
 // Interactorから返ってきたEntityを外界が求める出力フォーマットに変更
-type PointPresenter struct{}
+type PointPresenter struct {
+	v2 bool
+}
 
 // NewPointPresenter は新しいPointPresenterを作成
 func NewPointPresenter() *PointPresenter {
 	return &PointPresenter{}
 }
 
+// NewPointPresenterV2 はAPI v2用のPointPresenterを作成
+// v2では送金結果の残高キーを残高取得APIと同じ "balance" に揃え、"message" を廃止している
+func NewPointPresenterV2() *PointPresenter {
+	return &PointPresenter{v2: true}
+}
+
 // PresentTransferResponse はTransferResponseをJSON形式に変換
 func (p *PointPresenter) PresentTransferResponse(resp *inputport.TransferResponse) gin.H {
+	if p.v2 {
+		return gin.H{
+			"transaction": gin.H{
+				"id":           resp.Transaction.ID,
+				"from_user_id": resp.Transaction.FromUserID,
+				"to_user_id":   resp.Transaction.ToUserID,
+				"amount":       resp.Transaction.Amount,
+				"status":       resp.Transaction.Status,
+				"description":  resp.Transaction.Description,
+				"created_at":   resp.Transaction.CreatedAt,
+			},
+			"fee":     resp.Fee,
+			"balance": resp.FromUser.Balance,
+		}
+	}
+
 	return gin.H{
 		"message": "transfer successful",
 		"transaction": gin.H{
@@ -24,14 +49,31 @@ func (p *PointPresenter) PresentTransferResponse(resp *inputport.TransferRespons
 			"status":      resp.Transaction.Status,
 			"created_at":  resp.Transaction.CreatedAt,
 		},
+		"fee":         resp.Fee,
 		"new_balance": resp.FromUser.Balance,
 	}
 }
 
+// PresentQuoteResponse は送金の見積もりをJSON形式に変換
+func (p *PointPresenter) PresentQuoteResponse(resp *inputport.QuoteTransferResponse) gin.H {
+	return gin.H{
+		"amount":           resp.Amount,
+		"fee":              resp.Fee,
+		"total":            resp.Total,
+		"fee_type":         resp.FeeType,
+		"min_amount":       resp.MinAmount,
+		"max_amount":       resp.MaxAmount,
+		"balance":          resp.Balance,
+		"sufficient_funds": resp.SufficientFunds,
+	}
+}
+
 // PresentBalanceResponse はBalanceResponseをJSON形式に変換
 func (p *PointPresenter) PresentBalanceResponse(resp *inputport.GetBalanceResponse) gin.H {
 	return gin.H{
-		"balance": resp.Balance,
+		"balance":           resp.Balance,
+		"reserved_balance":  resp.Reserved,
+		"available_balance": resp.Available,
 		"user": gin.H{
 			"id":           resp.User.ID,
 			"username":     resp.User.Username,
@@ -53,9 +95,25 @@ func (p *PointPresenter) PresentTransactionHistoryResponse(resp *inputport.GetTr
 			"transaction_type": tx.TransactionType,
 			"status":           tx.Status,
 			"description":      tx.Description,
+			"reason_code":      tx.ReasonCode,
+			"tag":              tx.Tag,
 			"created_at":       tx.CreatedAt,
 		}
 
+		// 付与・減算・失効・手数料の相手のシステムの口座
+		if tx.FromAccount != "" {
+			txData["from_account"] = tx.FromAccount
+		}
+		if tx.ToAccount != "" {
+			txData["to_account"] = tx.ToAccount
+		}
+		if tx.Migrated() {
+			txData["migrated"] = true
+		}
+		if txWithUsers.HasAttachment {
+			txData["has_attachment"] = true
+		}
+
 		// 送信者情報を追加
 		if txWithUsers.FromUser != nil {
 			txData["from_user"] = gin.H{
@@ -64,6 +122,8 @@ func (p *PointPresenter) PresentTransactionHistoryResponse(resp *inputport.GetTr
 				"display_name": txWithUsers.FromUser.DisplayName,
 				"avatar_url":   txWithUsers.FromUser.AvatarURL,
 			}
+		} else if tx.FromUserDeleted() {
+			txData["from_user"] = deletedUserData()
 		}
 
 		// 受信者情報を追加
@@ -74,6 +134,8 @@ func (p *PointPresenter) PresentTransactionHistoryResponse(resp *inputport.GetTr
 				"display_name": txWithUsers.ToUser.DisplayName,
 				"avatar_url":   txWithUsers.ToUser.AvatarURL,
 			}
+		} else if tx.ToUserDeleted() {
+			txData["to_user"] = deletedUserData()
 		}
 
 		transactions[i] = txData
@@ -84,3 +146,14 @@ func (p *PointPresenter) PresentTransactionHistoryResponse(resp *inputport.GetTr
 		"total":        resp.Total,
 	}
 }
+
+// deletedUserData は退会したユーザーの代わりに返す相手情報
+func deletedUserData() gin.H {
+	return gin.H{
+		"id":           nil,
+		"username":     "",
+		"display_name": entities.DeletedUserDisplayName,
+		"avatar_url":   nil,
+		"is_deleted":   true,
+	}
+}
@@ -0,0 +1,50 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// PricingRulePresenter は価格ルールのPresenter
+type PricingRulePresenter struct{}
+
+// NewPricingRulePresenter は新しいPricingRulePresenterを作成
+func NewPricingRulePresenter() *PricingRulePresenter {
+	return &PricingRulePresenter{}
+}
+
+// PresentPricingRule は価格ルールをJSON形式に変換
+func (p *PricingRulePresenter) PresentPricingRule(r *entities.PricingRule) gin.H {
+	return gin.H{
+		"id":                    r.ID,
+		"name":                  r.Name,
+		"product_id":            r.ProductID,
+		"category_code":         r.CategoryCode,
+		"discount_type":         r.DiscountType,
+		"discount_value":        r.DiscountValue,
+		"target_role":           r.TargetRole,
+		"min_tier":              r.MinTier,
+		"max_quantity_per_user": r.MaxQuantityPerUser,
+		"starts_at":             r.StartsAt,
+		"ends_at":               r.EndsAt,
+		"is_active":             r.IsActive,
+		"created_by":            r.CreatedBy,
+		"created_at":            r.CreatedAt,
+		"updated_at":            r.UpdatedAt,
+	}
+}
+
+// PresentPricingRuleList は価格ルール一覧をJSON形式に変換
+func (p *PricingRulePresenter) PresentPricingRuleList(resp *inputport.GetPricingRuleListResponse) gin.H {
+	rules := make([]gin.H, 0, len(resp.Rules))
+	for _, r := range resp.Rules {
+		rules = append(rules, p.PresentPricingRule(r))
+	}
+	return gin.H{
+		"pricing_rules": rules,
+		"total":         resp.Total,
+		"offset":        resp.Offset,
+		"limit":         resp.Limit,
+	}
+}
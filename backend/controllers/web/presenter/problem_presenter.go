@@ -0,0 +1,174 @@
+package presenter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/go-playground/validator/v10"
+)
+
+// ProblemContentType はRFC 7807のエラーレスポンスのContent-Type
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix はドメインエラーのtype（エラーコードごとのURI）の接頭辞
+const problemTypePrefix = "urn:gity-point-system:error:"
+
+// Problem はRFC 7807（problem+json）形式のエラーレスポンス
+// error・code はproblem+json以前の形式（{"error": メッセージ, "code": コード}）を読むクライアントのための互換フィールドで、
+// それぞれdetail・error_codeと同じ値
+type Problem struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Status    int                    `json:"status"`
+	Detail    string                 `json:"detail"`
+	Instance  string                 `json:"instance,omitempty"`
+	ErrorCode string                 `json:"error_code"`
+	Errors    []entities.FieldError  `json:"errors,omitempty"`
+	Params    map[string]interface{} `json:"params,omitempty"`
+	Error     string                 `json:"error"`
+	Code      string                 `json:"code"`
+}
+
+// internalErrorMessages はドメインエラー以外の5xxで返すメッセージ（内部のエラー内容は返さない）
+var internalErrorMessages = map[Language]string{
+	LanguageJapanese: "サーバーでエラーが発生しました。時間をおいて再度お試しください",
+	LanguageEnglish:  "An internal error occurred. Please try again later.",
+}
+
+// PresentProblem はエラーをproblem+jsonに変換
+// ドメインエラーはコードに応じたステータスとAccept-Languageに応じた翻訳済みメッセージにする
+// リクエストの読み取り・検証のエラーは項目ごとのエラー付きのvalidation_failedに、サイズ超過はrequest_too_largeにする
+// リクエストの期限切れ（context.DeadlineExceeded）はrequest_timeout（504）にする
+// それ以外のエラーはfallbackStatusで返し、5xxなら内部のエラー内容を隠す
+func PresentProblem(err error, fallbackStatus int, acceptLanguage, instance string) *Problem {
+	lang := NegotiateLanguage(acceptLanguage)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		err = entities.ErrRequestTooLarge
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		err = entities.ErrRequestTimeout
+	} else if fields, ok := bindingFieldErrors(err); ok {
+		err = entities.NewValidationError(fields...)
+	}
+
+	de, ok := entities.AsDomainError(err)
+	if !ok {
+		code, exists := genericErrorCodes[fallbackStatus]
+		if !exists {
+			code = "error"
+		}
+		detail := err.Error()
+		if fallbackStatus >= http.StatusInternalServerError {
+			detail = internalErrorMessages[lang]
+		}
+		return &Problem{
+			Type:      "about:blank",
+			Title:     http.StatusText(fallbackStatus),
+			Status:    fallbackStatus,
+			Detail:    detail,
+			Instance:  instance,
+			ErrorCode: code,
+			Error:     detail,
+			Code:      code,
+		}
+	}
+
+	status, exists := errorStatuses[de.Code]
+	if !exists {
+		status = http.StatusBadRequest
+	}
+	detail := LocalizeError(de, lang)
+	problem := &Problem{
+		Type:      problemTypePrefix + string(de.Code),
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  instance,
+		ErrorCode: string(de.Code),
+		Errors:    de.Fields,
+		Error:     detail,
+		Code:      string(de.Code),
+	}
+	if len(de.Params) > 0 {
+		problem.Params = de.Params
+	}
+	return problem
+}
+
+// RenderError はエラーをproblem+jsonで返し、以降のハンドラーを止める
+// コントローラーとミドルウェアのエラーレスポンスはすべてここを通す
+func RenderError(ctx *gin.Context, fallbackStatus int, err error) {
+	ctx.Error(err) // アクセスログ・エラーハンドラーから参照できるように記録する
+	problem := PresentProblem(err, fallbackStatus, ctx.GetHeader("Accept-Language"), ctx.Request.URL.Path)
+	WriteProblem(ctx, problem)
+}
+
+// WriteProblem はproblem+jsonを書き込んで以降のハンドラーを止める
+func WriteProblem(ctx *gin.Context, problem *Problem) {
+	ctx.Header("Content-Type", ProblemContentType)
+	ctx.AbortWithStatusJSON(problem.Status, problem)
+}
+
+// bindingFieldErrors はリクエストのバインド（ShouldBindJSONなど）のエラーを項目ごとのエラーに変換
+// バインドのエラーでなければfalseを返す
+func bindingFieldErrors(err error) ([]entities.FieldError, bool) {
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &validationErrs):
+		fields := make([]entities.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, entities.FieldError{Field: fe.Field(), Message: validationMessage(fe)})
+		}
+		return fields, true
+	case errors.As(err, &typeErr):
+		return []entities.FieldError{{Field: typeErr.Field, Message: "must be of type " + typeErr.Type.String()}}, true
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return []entities.FieldError{{Field: "body", Message: "must be valid JSON"}}, true
+	case errors.Is(err, io.EOF):
+		return []entities.FieldError{{Field: "body", Message: "is required"}}, true
+	}
+	return nil, false
+}
+
+// validationMessage はバリデーションタグを英語の説明にする
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "uuid", "uuid4":
+		return "must be a valid ID"
+	case "min":
+		return "must be at least " + fe.Param()
+	case "max":
+		return "must be at most " + fe.Param()
+	case "len":
+		return "must have length " + fe.Param()
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "gte":
+		return "must be " + fe.Param() + " or more"
+	case "lt":
+		return "must be less than " + fe.Param()
+	case "lte":
+		return "must be " + fe.Param() + " or less"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "points":
+		return fmt.Sprintf("must be between 1 and %d", entities.PointAmountMax)
+	case "message":
+		return fmt.Sprintf("must be at most %d characters", entities.TransferMessageMaxLength)
+	default:
+		return fmt.Sprintf("failed the %q check", fe.Tag())
+	}
+}
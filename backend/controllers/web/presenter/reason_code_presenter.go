@@ -0,0 +1,35 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// ReasonCodePresenter は理由コードのPresenter
+type ReasonCodePresenter struct{}
+
+// NewReasonCodePresenter は新しいReasonCodePresenterを作成
+func NewReasonCodePresenter() *ReasonCodePresenter {
+	return &ReasonCodePresenter{}
+}
+
+// PresentReasonCode は理由コードをJSON形式に変換
+func (p *ReasonCodePresenter) PresentReasonCode(r *entities.ReasonCode) gin.H {
+	return gin.H{
+		"code":        r.Code,
+		"label":       r.Label,
+		"description": r.Description,
+		"is_active":   r.IsActive,
+		"created_at":  r.CreatedAt,
+		"updated_at":  r.UpdatedAt,
+	}
+}
+
+// PresentReasonCodeList は理由コード一覧をJSON形式に変換
+func (p *ReasonCodePresenter) PresentReasonCodeList(reasonCodes []*entities.ReasonCode) gin.H {
+	list := make([]gin.H, 0, len(reasonCodes))
+	for _, r := range reasonCodes {
+		list = append(list, p.PresentReasonCode(r))
+	}
+	return gin.H{"reason_codes": list}
+}
@@ -0,0 +1,76 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// RecurringTransferPresenter は定期送金のPresenter
+type RecurringTransferPresenter struct{}
+
+// NewRecurringTransferPresenter は新しいRecurringTransferPresenterを作成
+func NewRecurringTransferPresenter() *RecurringTransferPresenter {
+	return &RecurringTransferPresenter{}
+}
+
+// PresentRecurringTransfer は定期送金をJSON形式に変換
+func (p *RecurringTransferPresenter) PresentRecurringTransfer(t *entities.RecurringTransfer) gin.H {
+	item := gin.H{
+		"id":           t.ID,
+		"from_user_id": t.FromUserID,
+		"to_user_id":   t.ToUserID,
+		"amount":       t.Amount,
+		"interval":     t.Interval,
+		"message":      t.Message,
+		"status":       t.Status,
+		"start_at":     t.StartAt,
+		"next_run_at":  t.NextRunAt,
+		"last_run_at":  t.LastRunAt,
+		"run_count":    t.RunCount,
+		"created_at":   t.CreatedAt,
+		"updated_at":   t.UpdatedAt,
+	}
+	if t.Status == entities.RecurringTransferStatusStopped {
+		item["stop_reason"] = t.StopReason
+	}
+	if t.CancelledAt != nil {
+		item["cancelled_at"] = t.CancelledAt
+		item["cancelled_by"] = t.CancelledBy
+	}
+	return item
+}
+
+// PresentRecurringTransferInfo は定期送金を送信者・受取人の情報付きでJSON形式に変換
+func (p *RecurringTransferPresenter) PresentRecurringTransferInfo(info *inputport.RecurringTransferInfo) gin.H {
+	item := p.PresentRecurringTransfer(info.Transfer)
+	item["from_user"] = p.presentUser(info.FromUser)
+	item["to_user"] = p.presentUser(info.ToUser)
+	return item
+}
+
+// PresentRecurringTransferList は定期送金一覧をJSON形式に変換
+func (p *RecurringTransferPresenter) PresentRecurringTransferList(resp *inputport.GetRecurringTransfersResponse) gin.H {
+	transfers := make([]gin.H, 0, len(resp.Transfers))
+	for _, info := range resp.Transfers {
+		transfers = append(transfers, p.PresentRecurringTransferInfo(info))
+	}
+	return gin.H{
+		"recurring_transfers": transfers,
+		"total":               resp.Total,
+		"offset":              resp.Offset,
+		"limit":               resp.Limit,
+	}
+}
+
+func (p *RecurringTransferPresenter) presentUser(user *entities.User) gin.H {
+	if user == nil {
+		return deletedUserData()
+	}
+	return gin.H{
+		"id":           user.ID,
+		"username":     user.Username,
+		"display_name": user.DisplayName,
+		"avatar_url":   user.AvatarURL,
+	}
+}
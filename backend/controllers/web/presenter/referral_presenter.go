@@ -0,0 +1,51 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// ReferralPresenter は紹介プログラムのPresenter
+type ReferralPresenter struct{}
+
+// NewReferralPresenter は新しいReferralPresenterを作成
+func NewReferralPresenter() *ReferralPresenter {
+	return &ReferralPresenter{}
+}
+
+// PresentReferralCode は紹介コードをJSON形式に変換
+func (p *ReferralPresenter) PresentReferralCode(code *entities.ReferralCode) gin.H {
+	return gin.H{
+		"code":            code.Code,
+		"referrer_reward": entities.ReferrerRewardPoints,
+		"referee_reward":  entities.RefereeRewardPoints,
+		"created_at":      code.CreatedAt,
+	}
+}
+
+// PresentReferralReport は紹介の実績をJSON形式に変換
+func (p *ReferralPresenter) PresentReferralReport(r *entities.ReferralReport) gin.H {
+	referrers := make([]gin.H, 0, len(r.TopReferrers))
+	for _, s := range r.TopReferrers {
+		referrers = append(referrers, gin.H{
+			"user_id":       s.UserID,
+			"username":      s.Username,
+			"display_name":  s.DisplayName,
+			"invited":       s.Invited,
+			"rewarded":      s.Rewarded,
+			"rejected":      s.Rejected,
+			"points_earned": s.PointsEarned,
+		})
+	}
+	return gin.H{
+		"from":            r.From,
+		"to":              r.To,
+		"invited":         r.Invited,
+		"pending":         r.Pending,
+		"rewarded":        r.Rewarded,
+		"rejected":        r.Rejected,
+		"conversion_rate": r.ConversionRate(),
+		"points_awarded":  r.PointsAwarded,
+		"top_referrers":   referrers,
+	}
+}
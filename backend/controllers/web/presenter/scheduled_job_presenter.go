@@ -0,0 +1,40 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// ScheduledJobPresenter は定期実行ジョブのPresenter
+type ScheduledJobPresenter struct{}
+
+// NewScheduledJobPresenter は新しいScheduledJobPresenterを作成
+func NewScheduledJobPresenter() *ScheduledJobPresenter {
+	return &ScheduledJobPresenter{}
+}
+
+// PresentJobs はジョブのスケジュールと直近の実行状況をJSON形式に変換
+func (p *ScheduledJobPresenter) PresentJobs(jobs []*entities.ScheduledJob, now time.Time) gin.H {
+	list := make([]gin.H, 0, len(jobs))
+	for _, j := range jobs {
+		item := gin.H{
+			"name":             j.Name,
+			"schedule":         j.Schedule,
+			"enabled":          j.NextRunAt != nil,
+			"next_run_at":      j.NextRunAt,
+			"running":          j.IsRunning(now),
+			"last_started_at":  j.LastStartedAt,
+			"last_finished_at": j.LastFinishedAt,
+			"last_status":      j.LastStatus,
+			"last_error":       j.LastError,
+			"last_processed":   j.LastProcessed,
+		}
+		if j.IsRunning(now) {
+			item["locked_by"] = j.LockedBy
+		}
+		list = append(list, item)
+	}
+	return gin.H{"jobs": list}
+}
@@ -0,0 +1,91 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// SecurityHistoryPresenter はユーザー名・パスワード変更履歴とログイン履歴のPresenter
+type SecurityHistoryPresenter struct{}
+
+// NewSecurityHistoryPresenter は新しいSecurityHistoryPresenterを作成
+func NewSecurityHistoryPresenter() *SecurityHistoryPresenter {
+	return &SecurityHistoryPresenter{}
+}
+
+// PresentOwnHistory は本人向けの変更履歴をJSON形式に変換
+// 変更者のIDは返さず、管理者による変更かどうかだけを示す
+func (p *SecurityHistoryPresenter) PresentOwnHistory(resp *inputport.GetSecurityHistoryResponse) gin.H {
+	return p.present(resp, false)
+}
+
+// PresentAdminHistory は管理者向けの変更履歴をJSON形式に変換
+func (p *SecurityHistoryPresenter) PresentAdminHistory(resp *inputport.GetSecurityHistoryResponse) gin.H {
+	return p.present(resp, true)
+}
+
+func (p *SecurityHistoryPresenter) present(resp *inputport.GetSecurityHistoryResponse, includeChangedBy bool) gin.H {
+	history := make([]gin.H, 0, len(resp.Entries))
+	for _, e := range resp.Entries {
+		item := gin.H{
+			"type":       e.Type,
+			"changed_at": e.ChangedAt,
+			"ip_address": e.IPAddress,
+		}
+		if e.UserAgent != nil {
+			item["user_agent"] = *e.UserAgent
+		}
+		if e.OldUsername != "" || e.NewUsername != "" {
+			item["old_username"] = e.OldUsername
+			item["new_username"] = e.NewUsername
+			item["changed_by_admin"] = e.ChangedBy != nil && *e.ChangedBy != resp.UserID
+			if includeChangedBy {
+				item["changed_by"] = e.ChangedBy
+			}
+		}
+		if e.Type == entities.SecurityHistorySessionBindingViolation {
+			item["change"] = e.BindingChange
+			item["session_revoked"] = e.SessionRevoked
+		}
+		history = append(history, item)
+	}
+	return gin.H{
+		"history": history,
+		"total":   resp.Total,
+		"offset":  resp.Offset,
+		"limit":   resp.Limit,
+	}
+}
+
+// PresentOwnLogins は本人向けのログイン履歴をJSON形式に変換
+// anomaly は確認を促す理由（new_device・account_locked・repeated_failures）で、該当しなければ省略する
+func (p *SecurityHistoryPresenter) PresentOwnLogins(resp *inputport.GetLoginHistoryResponse) gin.H {
+	logins := make([]gin.H, 0, len(resp.Entries))
+	anomalies := 0
+	for _, e := range resp.Entries {
+		item := gin.H{
+			"attempted_at": e.CreatedAt,
+			"ip_address":   e.IPAddress,
+			"user_agent":   e.UserAgent,
+			"country":      e.Country,
+			"success":      e.Success,
+			"new_device":   e.NewDevice,
+		}
+		if !e.Success {
+			item["failure_reason"] = e.FailureReason
+		}
+		if e.Anomaly != entities.LoginAnomalyNone {
+			item["anomaly"] = e.Anomaly
+			anomalies++
+		}
+		logins = append(logins, item)
+	}
+	return gin.H{
+		"logins":    logins,
+		"anomalies": anomalies,
+		"total":     resp.Total,
+		"offset":    resp.Offset,
+		"limit":     resp.Limit,
+	}
+}
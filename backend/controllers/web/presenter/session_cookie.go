@@ -0,0 +1,49 @@
+package presenter
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// SessionCookieName はセッショントークンを入れるCookieの名前
+	SessionCookieName = "session_token"
+	// sessionCookieMaxAge はセッショントークンのCookieの有効期間（セッションと同じ24時間）
+	sessionCookieMaxAge = 24 * 60 * 60
+)
+
+// SessionCookie はセッショントークンのCookieの属性（SESSION_COOKIE_* の設定）
+type SessionCookie struct {
+	Domain   string
+	Secure   bool
+	SameSite http.SameSite
+}
+
+// NewSessionCookie は新しいSessionCookieを作成
+func NewSessionCookie(domain string, secure bool, sameSite http.SameSite) *SessionCookie {
+	return &SessionCookie{Domain: domain, Secure: secure, SameSite: sameSite}
+}
+
+// Set はセッショントークンをCookieに設定
+func (sc *SessionCookie) Set(c *gin.Context, token string) {
+	sc.write(c, token, sessionCookieMaxAge)
+}
+
+// Clear はセッショントークンのCookieをクリア（設定したときと同じ属性でないとブラウザが消さない）
+func (sc *SessionCookie) Clear(c *gin.Context) {
+	sc.write(c, "", -1)
+}
+
+func (sc *SessionCookie) write(c *gin.Context, value string, maxAge int) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    value,
+		Path:     "/",
+		Domain:   sc.Domain,
+		MaxAge:   maxAge,
+		Secure:   sc.Secure,
+		HttpOnly: true,
+		SameSite: sc.SameSite,
+	})
+}
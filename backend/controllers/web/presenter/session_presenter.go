@@ -0,0 +1,60 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// SessionPresenter はセッション管理のPresenter
+type SessionPresenter struct{}
+
+// NewSessionPresenter は新しいSessionPresenterを作成
+func NewSessionPresenter() *SessionPresenter {
+	return &SessionPresenter{}
+}
+
+// PresentListSessions はセッション一覧をJSON形式に変換
+// セッショントークン・CSRFトークンは返さない
+func (p *SessionPresenter) PresentListSessions(resp *inputport.ListSessionsResponse) gin.H {
+	sessions := make([]gin.H, len(resp.Sessions))
+	for i, info := range resp.Sessions {
+		sessions[i] = gin.H{
+			"id":             info.Session.ID,
+			"device":         info.Session.DeviceName(),
+			"user_agent":     info.Session.UserAgent,
+			"ip_address":     info.Session.IPAddress,
+			"last_active_at": info.Session.LastActiveAt,
+			"created_at":     info.Session.CreatedAt,
+			"expires_at":     info.Session.ExpiresAt,
+			"current":        info.IsCurrent,
+		}
+	}
+
+	return gin.H{
+		"sessions": sessions,
+	}
+}
+
+// PresentRevokeSession はセッション失効結果をJSON形式に変換
+func (p *SessionPresenter) PresentRevokeSession(resp *inputport.RevokeSessionResponse) gin.H {
+	return gin.H{
+		"message":         "session revoked",
+		"revoked_current": resp.RevokedCurrent,
+	}
+}
+
+// PresentRevokeOtherSessions は他セッション一括失効結果をJSON形式に変換
+func (p *SessionPresenter) PresentRevokeOtherSessions(resp *inputport.RevokeOtherSessionsResponse) gin.H {
+	return gin.H{
+		"message":       "other sessions revoked",
+		"revoked_count": resp.RevokedCount,
+	}
+}
+
+// PresentForceLogout は強制ログアウトの結果をJSON形式に変換
+func (p *SessionPresenter) PresentForceLogout(message string, resp *inputport.ForceLogoutResponse) gin.H {
+	return gin.H{
+		"message":       message,
+		"revoked_count": resp.RevokedCount,
+	}
+}
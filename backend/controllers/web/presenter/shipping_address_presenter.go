@@ -0,0 +1,44 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// ShippingAddressPresenter はお届け先のPresenter
+type ShippingAddressPresenter struct{}
+
+// NewShippingAddressPresenter は新しいShippingAddressPresenterを作成
+func NewShippingAddressPresenter() *ShippingAddressPresenter {
+	return &ShippingAddressPresenter{}
+}
+
+// PresentAddress はお届け先をJSON形式に変換
+func (p *ShippingAddressPresenter) PresentAddress(a *entities.ShippingAddress) gin.H {
+	return gin.H{
+		"id":             a.ID,
+		"label":          a.Label,
+		"recipient_name": a.RecipientName,
+		"postal_code":    a.PostalCode,
+		"prefecture":     a.Prefecture,
+		"city":           a.City,
+		"line1":          a.Line1,
+		"line2":          a.Line2,
+		"phone":          a.Phone,
+		"is_default":     a.IsDefault,
+		"created_at":     a.CreatedAt,
+		"updated_at":     a.UpdatedAt,
+	}
+}
+
+// PresentAddresses はお届け先の一覧をJSON形式に変換
+func (p *ShippingAddressPresenter) PresentAddresses(addresses []*entities.ShippingAddress) gin.H {
+	items := make([]gin.H, len(addresses))
+	for i, a := range addresses {
+		items[i] = p.PresentAddress(a)
+	}
+	return gin.H{
+		"addresses": items,
+		"max":       entities.ShippingAddressMaxPerUser,
+	}
+}
@@ -0,0 +1,67 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// SplitRequestPresenter は割り勘のプレゼンター
+type SplitRequestPresenter struct{}
+
+// NewSplitRequestPresenter は新しいSplitRequestPresenterを作成
+func NewSplitRequestPresenter() *SplitRequestPresenter {
+	return &SplitRequestPresenter{}
+}
+
+// PresentSplitRequest は割り勘と参加者ごとの支払い状況をJSON形式に変換
+func (p *SplitRequestPresenter) PresentSplitRequest(detail *inputport.SplitRequestDetail) gin.H {
+	s := detail.SplitRequest
+	shares := make([]gin.H, 0, len(detail.Shares))
+	for _, share := range detail.Shares {
+		tr := share.TransferRequest
+		shares = append(shares, gin.H{
+			"transfer_request_id": tr.ID,
+			"user": gin.H{
+				"id":           share.User.ID,
+				"username":     share.User.Username,
+				"display_name": share.User.DisplayName,
+				"avatar_url":   share.User.AvatarURL,
+			},
+			"amount":         tr.Amount,
+			"status":         tr.Status,
+			"paid":           tr.ApprovedAt != nil,
+			"paid_at":        tr.ApprovedAt,
+			"transaction_id": tr.TransactionID,
+			"expires_at":     tr.ExpiresAt,
+		})
+	}
+	return gin.H{
+		"id":                s.ID,
+		"requester_id":      s.RequesterID,
+		"total_amount":      s.TotalAmount,
+		"split_type":        s.SplitType,
+		"include_requester": s.IncludeRequester,
+		"message":           s.Message,
+		"status":            detail.Summary.Status,
+		"summary": gin.H{
+			"share_count":        detail.Summary.ShareCount,
+			"paid_count":         detail.Summary.PaidCount,
+			"paid_amount":        detail.Summary.PaidAmount,
+			"outstanding_amount": detail.Summary.OutstandingAmount,
+			"requester_amount":   detail.Summary.RequesterAmount,
+		},
+		"shares":       shares,
+		"cancelled_at": s.CancelledAt,
+		"created_at":   s.CreatedAt,
+		"updated_at":   s.UpdatedAt,
+	}
+}
+
+// PresentSplitRequestList は割り勘の一覧をJSON形式に変換
+func (p *SplitRequestPresenter) PresentSplitRequestList(resp *inputport.ListSplitRequestsResponse) gin.H {
+	list := make([]gin.H, 0, len(resp.SplitRequests))
+	for _, detail := range resp.SplitRequests {
+		list = append(list, p.PresentSplitRequest(detail))
+	}
+	return gin.H{"split_requests": list}
+}
@@ -0,0 +1,61 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// SuspiciousActivityPresenter は不審な送金の確認のPresenter
+type SuspiciousActivityPresenter struct{}
+
+// NewSuspiciousActivityPresenter は新しいSuspiciousActivityPresenterを作成
+func NewSuspiciousActivityPresenter() *SuspiciousActivityPresenter {
+	return &SuspiciousActivityPresenter{}
+}
+
+// PresentHoldEnabled は不審な送金を保留するかをJSON形式に変換
+func (p *SuspiciousActivityPresenter) PresentHoldEnabled(enabled bool) gin.H {
+	return gin.H{"enabled": enabled}
+}
+
+// PresentList は不審な送金の記録一覧をJSON形式に変換
+func (p *SuspiciousActivityPresenter) PresentList(resp *inputport.ListSuspiciousActivitiesResponse) gin.H {
+	activities := make([]gin.H, 0, len(resp.Activities))
+	for _, a := range resp.Activities {
+		activities = append(activities, presentSuspiciousActivity(a))
+	}
+	return gin.H{
+		"activities": activities,
+		"total":      resp.Total,
+	}
+}
+
+// PresentActivity は不審な送金の記録をJSON形式に変換
+func (p *SuspiciousActivityPresenter) PresentActivity(a *entities.SuspiciousActivity) gin.H {
+	return gin.H{"activity": presentSuspiciousActivity(a)}
+}
+
+func presentSuspiciousActivity(a *entities.SuspiciousActivity) gin.H {
+	reasons := a.Reasons
+	if reasons == nil {
+		reasons = []entities.SuspiciousActivityReason{}
+	}
+	return gin.H{
+		"id":              a.ID,
+		"from_user_id":    a.FromUserID,
+		"to_user_id":      a.ToUserID,
+		"amount":          a.Amount,
+		"description":     a.Description,
+		"idempotency_key": a.IdempotencyKey,
+		"reasons":         reasons,
+		"status":          a.Status,
+		"transaction_id":  a.TransactionID,
+		"reviewed_by":     a.ReviewedBy,
+		"review_comment":  a.ReviewComment,
+		"reviewed_at":     a.ReviewedAt,
+		"failure_reason":  a.FailureReason,
+		"created_at":      a.CreatedAt,
+		"updated_at":      a.UpdatedAt,
+	}
+}
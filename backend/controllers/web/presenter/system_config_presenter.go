@@ -0,0 +1,29 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// SystemConfigPresenter はサーバー設定のPresenter
+type SystemConfigPresenter struct{}
+
+// NewSystemConfigPresenter は新しいSystemConfigPresenterを作成
+func NewSystemConfigPresenter() *SystemConfigPresenter {
+	return &SystemConfigPresenter{}
+}
+
+// PresentSettings は設定の一覧をJSON形式に変換
+func (p *SystemConfigPresenter) PresentSettings(settings entities.ConfigSettings) gin.H {
+	list := make([]gin.H, 0, len(settings))
+	for _, s := range settings {
+		list = append(list, gin.H{
+			"key":    s.Key,
+			"path":   s.Path,
+			"value":  s.Value,
+			"source": s.Source,
+			"secret": s.Secret,
+		})
+	}
+	return gin.H{"settings": list}
+}
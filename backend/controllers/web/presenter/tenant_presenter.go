@@ -0,0 +1,56 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// TenantPresenter はテナント管理のプレゼンター
+type TenantPresenter struct{}
+
+// NewTenantPresenter は新しいTenantPresenterを作成
+func NewTenantPresenter() *TenantPresenter {
+	return &TenantPresenter{}
+}
+
+// PresentTenant はテナントをJSON形式に変換
+func (p *TenantPresenter) PresentTenant(t *entities.Tenant) gin.H {
+	return gin.H{
+		"id":         t.ID,
+		"slug":       t.Slug,
+		"name":       t.Name,
+		"is_active":  t.IsActive,
+		"is_default": t.IsDefault(),
+		"created_at": t.CreatedAt,
+		"updated_at": t.UpdatedAt,
+	}
+}
+
+// PresentTenantList はテナント一覧をJSON形式に変換
+func (p *TenantPresenter) PresentTenantList(resp *inputport.ListTenantsResponse) gin.H {
+	tenants := make([]gin.H, 0, len(resp.Tenants))
+	for _, t := range resp.Tenants {
+		tenants = append(tenants, p.PresentTenant(t))
+	}
+	return gin.H{
+		"tenants": tenants,
+		"total":   resp.Total,
+		"offset":  resp.Offset,
+		"limit":   resp.Limit,
+	}
+}
+
+// PresentCreateTenant はテナント作成結果をJSON形式に変換（最初の管理者の仮パスワードを含む）
+func (p *TenantPresenter) PresentCreateTenant(resp *inputport.CreateTenantResponse) gin.H {
+	return gin.H{
+		"tenant": p.PresentTenant(resp.Tenant),
+		"admin": gin.H{
+			"id":                 resp.Admin.ID,
+			"username":           resp.Admin.Username,
+			"email":              resp.Admin.Email,
+			"role":               resp.Admin.Role,
+			"temporary_password": resp.TemporaryPassword,
+		},
+	}
+}
@@ -0,0 +1,70 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// TransactionArchivePresenter は保管済みの取引のPresenter
+type TransactionArchivePresenter struct{}
+
+// NewTransactionArchivePresenter は新しいTransactionArchivePresenterを作成
+func NewTransactionArchivePresenter() *TransactionArchivePresenter {
+	return &TransactionArchivePresenter{}
+}
+
+// PresentListArchivedTransactions は保管済みの取引の検索結果をJSON形式に変換
+func (p *TransactionArchivePresenter) PresentListArchivedTransactions(resp *inputport.ListArchivedTransactionsResponse) gin.H {
+	transactions := make([]gin.H, len(resp.Transactions))
+	for i, tx := range resp.Transactions {
+		data := gin.H{
+			"id":               tx.ID,
+			"from_user_id":     tx.FromUserID,
+			"to_user_id":       tx.ToUserID,
+			"amount":           tx.Amount,
+			"transaction_type": tx.TransactionType,
+			"status":           tx.Status,
+			"description":      tx.Description,
+			"reason_code":      tx.ReasonCode,
+			"tag":              tx.Tag,
+			"created_at":       tx.CreatedAt,
+			"archived_at":      tx.ArchivedAt,
+		}
+		if tx.FromAccount != "" {
+			data["from_account"] = tx.FromAccount
+		}
+		if tx.ToAccount != "" {
+			data["to_account"] = tx.ToAccount
+		}
+		if tx.Migrated() {
+			data["migrated"] = true
+		}
+		transactions[i] = data
+	}
+	return gin.H{
+		"transactions":   transactions,
+		"total":          resp.Total,
+		"offset":         resp.Offset,
+		"limit":          resp.Limit,
+		"retention_days": resp.RetentionDays,
+	}
+}
+
+// PresentArchiveSummaries は保管済みの取引の集計をJSON形式に変換
+func (p *TransactionArchivePresenter) PresentArchiveSummaries(resp *inputport.GetArchiveSummariesResponse) gin.H {
+	summaries := make([]gin.H, len(resp.Summaries))
+	for i, s := range resp.Summaries {
+		summaries[i] = gin.H{
+			"month":             s.Month,
+			"transaction_type":  s.TransactionType,
+			"status":            s.Status,
+			"transaction_count": s.TransactionCount,
+			"total_amount":      s.TotalAmount,
+			"updated_at":        s.UpdatedAt,
+		}
+	}
+	return gin.H{
+		"summaries":      summaries,
+		"retention_days": resp.RetentionDays,
+	}
+}
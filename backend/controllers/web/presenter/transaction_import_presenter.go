@@ -0,0 +1,71 @@
+package presenter
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// TransactionImportPresenter は過去の取引の取り込みのPresenter
+type TransactionImportPresenter struct{}
+
+// NewTransactionImportPresenter は新しいTransactionImportPresenterを作成
+func NewTransactionImportPresenter() *TransactionImportPresenter {
+	return &TransactionImportPresenter{}
+}
+
+// PresentImportTransactionsResponse は取り込みの結果をJSON形式に変換
+// 行の status は failed（エラー）・imported（書き込み済み）・valid（ドライランまたは他の行のエラーで未書き込み）
+func (p *TransactionImportPresenter) PresentImportTransactionsResponse(resp *inputport.ImportTransactionsResponse, acceptLanguage string) gin.H {
+	results := make([]gin.H, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		row := gin.H{
+			"line":   r.Line,
+			"from":   r.From,
+			"to":     r.To,
+			"amount": r.Amount,
+			"type":   r.Type,
+		}
+		if !r.Date.IsZero() {
+			row["date"] = r.Date
+		}
+		switch {
+		case r.Err != nil:
+			problem := PresentProblem(r.Err, http.StatusInternalServerError, acceptLanguage, "")
+			row["status"] = "failed"
+			row["error"] = problem.Detail
+			row["error_code"] = problem.ErrorCode
+			row["code"] = problem.ErrorCode
+		case r.TransactionID != nil:
+			row["status"] = "imported"
+			row["transaction_id"] = r.TransactionID
+		default:
+			row["status"] = "valid"
+		}
+		results = append(results, row)
+	}
+
+	balances := make([]gin.H, 0, len(resp.Balances))
+	for _, b := range resp.Balances {
+		balances = append(balances, gin.H{
+			"user_id":        b.UserID,
+			"username":       b.Username,
+			"balance_before": b.BalanceBefore,
+			"balance_after":  b.StoredBalance,
+			"ledger_balance": b.LedgerBalance,
+			"difference":     b.Difference(),
+			"reconciled":     !b.HasDiscrepancy(),
+		})
+	}
+
+	return gin.H{
+		"import_id":      resp.ImportID,
+		"dry_run":        resp.DryRun,
+		"imported":       resp.Imported,
+		"imported_count": resp.ImportedCount,
+		"failed_count":   resp.FailedCount,
+		"results":        results,
+		"balances":       balances,
+	}
+}
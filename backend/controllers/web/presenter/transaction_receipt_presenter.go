@@ -0,0 +1,96 @@
+package presenter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// TransactionReceiptPresenter は取引の控えのPresenter
+type TransactionReceiptPresenter struct{}
+
+// NewTransactionReceiptPresenter は新しいTransactionReceiptPresenterを作成
+func NewTransactionReceiptPresenter() *TransactionReceiptPresenter {
+	return &TransactionReceiptPresenter{}
+}
+
+// PresentReceipt は取引の控えをJSON形式に変換（textはそのまま印刷できる控え）
+func (p *TransactionReceiptPresenter) PresentReceipt(r *entities.TransactionReceipt) gin.H {
+	return gin.H{
+		"transaction_id":   r.TransactionID,
+		"transaction_type": r.Type,
+		"status":           r.Status,
+		"amount":           r.Amount,
+		"description":      r.Description,
+		"from":             presentReceiptParty(r.From),
+		"to":               presentReceiptParty(r.To),
+		"created_at":       r.CreatedAt,
+		"completed_at":     r.CompletedAt,
+		"issued_at":        r.IssuedAt,
+		"verification": gin.H{
+			"version":   r.Version,
+			"algorithm": "HMAC-SHA256",
+			"hash":      r.Hash,
+		},
+		"text": printableReceipt(r),
+	}
+}
+
+// PresentVerification は取引の控えの確認結果をJSON形式に変換
+func (p *TransactionReceiptPresenter) PresentVerification(resp *inputport.VerifyTransactionReceiptResponse) gin.H {
+	return gin.H{
+		"valid":   resp.Valid,
+		"receipt": p.PresentReceipt(resp.Receipt),
+	}
+}
+
+func presentReceiptParty(party entities.ReceiptParty) gin.H {
+	if party.UserID == nil {
+		return gin.H{"account": party.Account}
+	}
+	return gin.H{
+		"user_id":      party.UserID,
+		"username":     party.Username,
+		"display_name": party.DisplayName,
+	}
+}
+
+// printableReceipt は控えを印刷用のテキストにする
+func printableReceipt(r *entities.TransactionReceipt) string {
+	lines := []string{
+		"ポイント取引控え",
+		"取引ID: " + r.TransactionID.String(),
+		"種類: " + string(r.Type),
+		"状態: " + string(r.Status),
+		fmt.Sprintf("金額: %d pt", r.Amount),
+		"送信元: " + printableParty(r.From),
+		"送信先: " + printableParty(r.To),
+		"取引日時: " + r.CreatedAt.Format(time.RFC3339),
+	}
+	if r.CompletedAt != nil {
+		lines = append(lines, "完了日時: "+r.CompletedAt.Format(time.RFC3339))
+	}
+	if r.Description != "" {
+		lines = append(lines, "メモ: "+r.Description)
+	}
+	lines = append(lines,
+		"発行日時: "+r.IssuedAt.Format(time.RFC3339),
+		fmt.Sprintf("検証ハッシュ (v%d): %s", r.Version, r.Hash),
+	)
+	return strings.Join(lines, "\n")
+}
+
+func printableParty(party entities.ReceiptParty) string {
+	switch {
+	case party.UserID == nil:
+		return "システム (" + string(party.Account) + ")"
+	case party.Username == "":
+		return party.UserID.String()
+	default:
+		return fmt.Sprintf("%s (@%s)", party.DisplayName, party.Username)
+	}
+}
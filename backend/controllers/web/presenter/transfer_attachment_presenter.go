@@ -0,0 +1,27 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// TransferAttachmentPresenter は送金・送金リクエストの添付画像のPresenter
+type TransferAttachmentPresenter struct{}
+
+// NewTransferAttachmentPresenter は新しいTransferAttachmentPresenterを作成
+func NewTransferAttachmentPresenter() *TransferAttachmentPresenter {
+	return &TransferAttachmentPresenter{}
+}
+
+// PresentAttachment は添付画像の情報をJSON形式に変換（画像そのものは添付画像の取得APIで返す）
+func (p *TransferAttachmentPresenter) PresentAttachment(a *entities.TransferAttachment) gin.H {
+	return gin.H{
+		"id":                  a.ID,
+		"transaction_id":      a.TransactionID,
+		"transfer_request_id": a.TransferRequestID,
+		"uploaded_by":         a.UploadedBy,
+		"file_name":           a.FileName,
+		"size_bytes":          a.SizeBytes,
+		"created_at":          a.CreatedAt,
+	}
+}
@@ -0,0 +1,29 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// TransferEligibilityPresenter は送金できるユーザーの条件のPresenter
+type TransferEligibilityPresenter struct{}
+
+// NewTransferEligibilityPresenter は新しいTransferEligibilityPresenterを作成
+func NewTransferEligibilityPresenter() *TransferEligibilityPresenter {
+	return &TransferEligibilityPresenter{}
+}
+
+// PresentPolicy は送金できるユーザーの条件をJSON形式に変換
+func (p *TransferEligibilityPresenter) PresentPolicy(policy *entities.TransferEligibilityPolicy) gin.H {
+	defaultAcceptance := policy.DefaultAcceptance
+	if defaultAcceptance == "" {
+		defaultAcceptance = entities.TransferAcceptanceAnyone
+	}
+	return gin.H{
+		"require_email_verification":    policy.RequireEmailVerification,
+		"min_account_age_hours":         policy.MinAccountAgeHours,
+		"default_accept_transfers_from": defaultAcceptance,
+		"updated_by":                    policy.UpdatedBy,
+		"updated_at":                    policy.UpdatedAt,
+	}
+}
@@ -0,0 +1,28 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// TransferPolicyPresenter は送金額の上下限と手数料のPresenter
+type TransferPolicyPresenter struct{}
+
+// NewTransferPolicyPresenter は新しいTransferPolicyPresenterを作成
+func NewTransferPolicyPresenter() *TransferPolicyPresenter {
+	return &TransferPolicyPresenter{}
+}
+
+// PresentPolicy は送金額の上下限と手数料をJSON形式に変換
+func (p *TransferPolicyPresenter) PresentPolicy(policy *entities.TransferPolicy) gin.H {
+	return gin.H{
+		"min_amount":            policy.MinAmount,
+		"max_amount":            policy.MaxAmount,
+		"fee_type":              policy.FeeType,
+		"fee_flat":              policy.FeeFlat,
+		"fee_rate_basis_points": policy.FeeRateBasisPoints,
+		"fee_account_id":        policy.FeeAccountID,
+		"updated_by":            policy.UpdatedBy,
+		"updated_at":            policy.UpdatedAt,
+	}
+}
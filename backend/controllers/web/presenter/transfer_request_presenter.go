@@ -18,19 +18,26 @@ func NewTransferRequestPresenter() *TransferRequestPresenter {
 
 // TransferRequestResponse は送金リクエストのレスポンス
 type TransferRequestResponse struct {
-	ID             uuid.UUID  `json:"id"`
-	FromUserID     uuid.UUID  `json:"from_user_id"`
-	ToUserID       uuid.UUID  `json:"to_user_id"`
-	Amount         int64      `json:"amount"`
-	Message        string     `json:"message"`
-	Status         string     `json:"status"`
-	ExpiresAt      time.Time  `json:"expires_at"`
-	ApprovedAt     *time.Time `json:"approved_at,omitempty"`
-	RejectedAt     *time.Time `json:"rejected_at,omitempty"`
-	CancelledAt    *time.Time `json:"cancelled_at,omitempty"`
-	TransactionID  *uuid.UUID `json:"transaction_id,omitempty"`
-	CreatedAt      time.Time  `json:"created_at"`
-	UpdatedAt      time.Time  `json:"updated_at"`
+	ID            uuid.UUID  `json:"id"`
+	FromUserID    uuid.UUID  `json:"from_user_id"`
+	ToUserID      uuid.UUID  `json:"to_user_id"`
+	Amount        int64      `json:"amount"`
+	Message       string     `json:"message"`
+	Status        string     `json:"status"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	ApprovedAt    *time.Time `json:"approved_at,omitempty"`
+	RejectedAt    *time.Time `json:"rejected_at,omitempty"`
+	CancelledAt   *time.Time `json:"cancelled_at,omitempty"`
+	TransactionID *uuid.UUID `json:"transaction_id,omitempty"`
+	CounterAmount *int64     `json:"counter_amount,omitempty"`
+	CounteredAt   *time.Time `json:"countered_at,omitempty"`
+	FinalAmount   int64      `json:"final_amount"`
+	LastActedBy   uuid.UUID  `json:"last_acted_by"`
+	LastActedRole string     `json:"last_acted_role"`    // "sender" または "receiver"
+	SplitID       *uuid.UUID `json:"split_id,omitempty"` // 割り勘の1人分（送信者が承認して支払う）
+	Hold          bool       `json:"hold"`               // 送信者の残高から金額を確保している
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
 }
 
 // TransferRequestInfoResponse は送金リクエスト情報のレスポンス
@@ -75,6 +82,23 @@ func (p *TransferRequestPresenter) PresentRejectTransferRequest(resp *inputport.
 	}
 }
 
+// PresentCounterTransferRequest はカウンターオファーレスポンスを生成
+func (p *TransferRequestPresenter) PresentCounterTransferRequest(resp *inputport.CounterTransferRequestResponse) map[string]interface{} {
+	return map[string]interface{}{
+		"transfer_request": p.toTransferRequestResponse(resp.TransferRequest),
+	}
+}
+
+// PresentConfirmCounterOffer はカウンターオファー確定レスポンスを生成
+func (p *TransferRequestPresenter) PresentConfirmCounterOffer(resp *inputport.ConfirmCounterOfferResponse) map[string]interface{} {
+	return p.PresentApproveTransferRequest(&inputport.ApproveTransferRequestResponse{
+		TransferRequest: resp.TransferRequest,
+		Transaction:     resp.Transaction,
+		FromUser:        resp.FromUser,
+		ToUser:          resp.ToUser,
+	})
+}
+
 // PresentCancelTransferRequest は送金リクエストキャンセルレスポンスを生成
 func (p *TransferRequestPresenter) PresentCancelTransferRequest(resp *inputport.CancelTransferRequestResponse) map[string]interface{} {
 	return map[string]interface{}{
@@ -137,11 +161,53 @@ func (p *TransferRequestPresenter) toTransferRequestResponse(tr *entities.Transf
 		RejectedAt:    tr.RejectedAt,
 		CancelledAt:   tr.CancelledAt,
 		TransactionID: tr.TransactionID,
+		CounterAmount: tr.CounterAmount,
+		CounteredAt:   tr.CounteredAt,
+		FinalAmount:   tr.FinalAmount(),
+		LastActedBy:   tr.LastActedBy,
+		LastActedRole: lastActedRole(tr),
+		SplitID:       tr.SplitID,
+		Hold:          tr.Hold,
 		CreatedAt:     tr.CreatedAt,
 		UpdatedAt:     tr.UpdatedAt,
 	}
 }
 
+// lastActedRole は最後に操作したのが送信者か受取人かを返す
+func lastActedRole(tr *entities.TransferRequest) string {
+	if tr.LastActedBy == tr.ToUserID {
+		return "receiver"
+	}
+	return "sender"
+}
+
+// PresentMutedSender は送信者のミュートのレスポンスを生成
+func (p *TransferRequestPresenter) PresentMutedSender(mute *entities.MutedSender) map[string]interface{} {
+	return map[string]interface{}{
+		"muted_user_id": mute.MutedUserID,
+		"mode":          mute.Mode,
+		"muted":         true,
+	}
+}
+
+// PresentMutedSenders はミュートした送信者一覧のレスポンスを生成（ミュートした相手の残高などは含めない）
+func (p *TransferRequestPresenter) PresentMutedSenders(mutes []*entities.MutedSenderWithUser) map[string]interface{} {
+	items := make([]map[string]interface{}, len(mutes))
+	for i, m := range mutes {
+		items[i] = map[string]interface{}{
+			"user": map[string]interface{}{
+				"id":           m.User.ID,
+				"username":     m.User.Username,
+				"display_name": m.User.DisplayName,
+				"avatar_url":   m.User.AvatarURL,
+			},
+			"mode":     m.Mode,
+			"muted_at": m.CreatedAt,
+		}
+	}
+	return map[string]interface{}{"mutes": items}
+}
+
 // toUserResponse はUserエンティティをレスポンスに変換
 func (p *TransferRequestPresenter) toUserResponse(user *entities.User) UserResponse {
 	return UserResponse{
@@ -0,0 +1,24 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// TransferRequestQuotaPresenter は承認待ちの送金リクエストの上限のPresenter
+type TransferRequestQuotaPresenter struct{}
+
+// NewTransferRequestQuotaPresenter は新しいTransferRequestQuotaPresenterを作成
+func NewTransferRequestQuotaPresenter() *TransferRequestQuotaPresenter {
+	return &TransferRequestQuotaPresenter{}
+}
+
+// PresentQuota は承認待ちの送金リクエストの上限をJSON形式に変換
+func (p *TransferRequestQuotaPresenter) PresentQuota(quota *entities.TransferRequestQuota) gin.H {
+	return gin.H{
+		"max_pending_per_sender":    quota.MaxPendingPerSender,
+		"max_pending_per_recipient": quota.MaxPendingPerRecipient,
+		"updated_by":                quota.UpdatedBy,
+		"updated_at":                quota.UpdatedAt,
+	}
+}
@@ -0,0 +1,51 @@
+package presenter
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// UserImportPresenter はユーザー一括登録のPresenter
+type UserImportPresenter struct{}
+
+// NewUserImportPresenter は新しいUserImportPresenterを作成
+func NewUserImportPresenter() *UserImportPresenter {
+	return &UserImportPresenter{}
+}
+
+// PresentImportUsersResponse は一括登録の結果をJSON形式に変換
+// 行ごとのエラーはエラーレスポンス（problem+json）と同じ "error"（翻訳済み）と "error_code"（互換用に "code" も）で返す
+func (p *UserImportPresenter) PresentImportUsersResponse(resp *inputport.ImportUsersResponse, acceptLanguage string) gin.H {
+	results := make([]gin.H, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		row := gin.H{
+			"line":            r.Line,
+			"username":        r.Username,
+			"email":           r.Email,
+			"initial_points":  r.InitialPoints,
+			"invitation_sent": r.InvitationSent,
+		}
+		if r.Err != nil {
+			problem := PresentProblem(r.Err, http.StatusInternalServerError, acceptLanguage, "")
+			row["status"] = "failed"
+			row["error"] = problem.Detail
+			row["error_code"] = problem.ErrorCode
+			row["code"] = problem.ErrorCode
+		} else {
+			row["status"] = "created"
+			row["user_id"] = r.UserID
+			if r.TemporaryPassword != "" {
+				row["temporary_password"] = r.TemporaryPassword
+			}
+		}
+		results = append(results, row)
+	}
+
+	return gin.H{
+		"created_count": resp.CreatedCount,
+		"failed_count":  resp.FailedCount,
+		"results":       results,
+	}
+}
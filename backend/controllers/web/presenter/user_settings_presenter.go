@@ -32,7 +32,8 @@ func (p *UserSettingsPresenter) PresentUpdateProfileResponse(resp *inputport.Upd
 
 	if resp.EmailVerificationSent {
 		result["email_verification_sent"] = true
-		result["message"] = "profile updated successfully. verification email sent to new email address"
+		result["pending_email"] = resp.PendingEmail
+		result["message"] = "profile updated successfully. confirm the email change from both your current and new email addresses"
 	}
 
 	return result
@@ -48,6 +49,26 @@ func (p *UserSettingsPresenter) PresentUploadAvatarResponse(resp *inputport.Uplo
 
 // PresentVerifyEmailResponse はVerifyEmailResponseをJSON形式に変換
 func (p *UserSettingsPresenter) PresentVerifyEmailResponse(resp *inputport.VerifyEmailResponse) gin.H {
+	if resp.AwaitingNewEmail || resp.AwaitingOldEmail {
+		message := "confirmed. please also verify your new email address"
+		if resp.AwaitingOldEmail {
+			message = "verified. please also confirm the change from your current email address"
+		}
+		return gin.H{
+			"message":            message,
+			"pending_email":      resp.Email,
+			"awaiting_new_email": resp.AwaitingNewEmail,
+			"awaiting_old_email": resp.AwaitingOldEmail,
+		}
+	}
+
+	if resp.User == nil {
+		return gin.H{
+			"message": "email verified successfully",
+			"email":   resp.Email,
+		}
+	}
+
 	return gin.H{
 		"message": "email verified successfully",
 		"user": gin.H{
@@ -67,22 +88,50 @@ func (p *UserSettingsPresenter) PresentVerifyEmailResponse(resp *inputport.Verif
 func (p *UserSettingsPresenter) PresentGetProfileResponse(resp *inputport.GetProfileResponse) gin.H {
 	return gin.H{
 		"user": gin.H{
-			"id":                resp.User.ID,
-			"username":          resp.User.Username,
-			"email":             resp.User.Email,
-			"display_name":      resp.User.DisplayName,
-			"first_name":        resp.User.FirstName,
-			"last_name":         resp.User.LastName,
-			"avatar_url":        resp.User.AvatarURL,
-			"email_verified":    resp.User.EmailVerified,
-			"email_verified_at": resp.User.EmailVerifiedAt,
-			"balance":           resp.User.Balance,
-			"role":              resp.User.Role,
-			"created_at":        resp.User.CreatedAt,
+			"id":                    resp.User.ID,
+			"username":              resp.User.Username,
+			"email":                 resp.User.Email,
+			"display_name":          resp.User.DisplayName,
+			"first_name":            resp.User.FirstName,
+			"last_name":             resp.User.LastName,
+			"avatar_url":            resp.User.AvatarURL,
+			"email_verified":        resp.User.EmailVerified,
+			"email_verified_at":     resp.User.EmailVerifiedAt,
+			"discoverable":          resp.User.Discoverable,
+			"timezone":              resp.User.Timezone,
+			"accept_transfers_from": resp.User.AcceptTransfersFrom,
+			"balance":               resp.User.Balance,
+			"role":                  resp.User.Role,
+			"tier":                  resp.User.CurrentTier(),
+			"created_at":            resp.User.CreatedAt,
 		},
 	}
 }
 
+// PresentUpdatePrivacyResponse はUpdatePrivacyResponseをJSON形式に変換
+func (p *UserSettingsPresenter) PresentUpdatePrivacyResponse(resp *inputport.UpdatePrivacyResponse) gin.H {
+	return gin.H{
+		"message":      "privacy settings updated successfully",
+		"discoverable": resp.User.Discoverable,
+	}
+}
+
+// PresentUpdateTimezoneResponse はUpdateTimezoneResponseをJSON形式に変換
+func (p *UserSettingsPresenter) PresentUpdateTimezoneResponse(resp *inputport.UpdateTimezoneResponse) gin.H {
+	return gin.H{
+		"message":  "timezone updated successfully",
+		"timezone": resp.User.Timezone,
+	}
+}
+
+// PresentUpdateTransferAcceptanceResponse はUpdateTransferAcceptanceResponseをJSON形式に変換
+func (p *UserSettingsPresenter) PresentUpdateTransferAcceptanceResponse(resp *inputport.UpdateTransferAcceptanceResponse) gin.H {
+	return gin.H{
+		"message":               "transfer acceptance updated successfully",
+		"accept_transfers_from": resp.User.AcceptTransfersFrom,
+	}
+}
+
 // PresentSuccessMessage は成功メッセージをJSON形式に変換
 func (p *UserSettingsPresenter) PresentSuccessMessage(message string) gin.H {
 	return gin.H{
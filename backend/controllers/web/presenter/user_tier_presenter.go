@@ -0,0 +1,25 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// UserTierPresenter は会員ランクのPresenter
+type UserTierPresenter struct{}
+
+// NewUserTierPresenter は新しいUserTierPresenterを作成
+func NewUserTierPresenter() *UserTierPresenter {
+	return &UserTierPresenter{}
+}
+
+// PresentUserTier はユーザーの会員ランクをJSON形式に変換
+func (p *UserTierPresenter) PresentUserTier(u *entities.User) gin.H {
+	return gin.H{
+		"user_id":         u.ID,
+		"username":        u.Username,
+		"tier":            u.CurrentTier(),
+		"tier_overridden": u.TierOverridden,
+		"tier_updated_at": u.TierUpdatedAt,
+	}
+}
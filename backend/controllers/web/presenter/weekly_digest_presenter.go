@@ -0,0 +1,45 @@
+package presenter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// WeeklyDigestPresenter は週次のまとめメールのPresenter
+type WeeklyDigestPresenter struct{}
+
+// NewWeeklyDigestPresenter は新しいWeeklyDigestPresenterを作成
+func NewWeeklyDigestPresenter() *WeeklyDigestPresenter {
+	return &WeeklyDigestPresenter{}
+}
+
+// PresentPreview は週次のまとめメールのプレビューをJSON形式に変換
+func (p *WeeklyDigestPresenter) PresentPreview(preview *inputport.WeeklyDigestPreview) gin.H {
+	d := preview.Digest
+	expirations := make([]gin.H, len(d.Expirations))
+	for i, b := range d.Expirations {
+		expirations[i] = gin.H{
+			"batch_id":   b.ID,
+			"amount":     b.RemainingAmount,
+			"expires_at": b.ExpiresAt,
+		}
+	}
+	return gin.H{
+		"user_id":    d.UserID,
+		"to":         d.Email,
+		"opted_in":   preview.OptedIn,
+		"would_send": preview.WouldSend,
+		"summary": gin.H{
+			"period_start":     d.PeriodStart,
+			"period_end":       d.PeriodEnd,
+			"earned":           d.Earned,
+			"spent":            d.Spent,
+			"balance":          d.Balance,
+			"expiring_amount":  d.ExpiringAmount(),
+			"expirations":      expirations,
+			"pending_requests": d.PendingRequests,
+		},
+		"subject": preview.Email.Subject,
+		"body":    preview.Email.Body,
+	}
+}
@@ -0,0 +1,33 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// WorkerLeasePresenter はワーカーのリーダーのPresenter
+type WorkerLeasePresenter struct{}
+
+// NewWorkerLeasePresenter は新しいWorkerLeasePresenterを作成
+func NewWorkerLeasePresenter() *WorkerLeasePresenter {
+	return &WorkerLeasePresenter{}
+}
+
+// PresentLeases はワーカーごとのリーダーと交代回数をJSON形式に変換
+func (p *WorkerLeasePresenter) PresentLeases(leases []*entities.WorkerLease, now time.Time) gin.H {
+	list := make([]gin.H, 0, len(leases))
+	for _, l := range leases {
+		list = append(list, gin.H{
+			"name":           l.Name,
+			"holder":         l.Holder,
+			"active":         l.IsHeld(now),
+			"acquired_at":    l.AcquiredAt,
+			"renewed_at":     l.RenewedAt,
+			"expires_at":     l.ExpiresAt,
+			"leader_changes": l.LeaderChanges,
+		})
+	}
+	return gin.H{"workers": list}
+}
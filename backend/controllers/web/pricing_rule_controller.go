@@ -0,0 +1,190 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// PricingRuleController は価格ルール管理のコントローラー（管理者用）
+type PricingRuleController struct {
+	pricingRuleUC inputport.PricingRuleInputPort
+	presenter     *presenter.PricingRulePresenter
+}
+
+// NewPricingRuleController は新しいPricingRuleControllerを作成
+func NewPricingRuleController(
+	pricingRuleUC inputport.PricingRuleInputPort,
+	presenter *presenter.PricingRulePresenter,
+) *PricingRuleController {
+	return &PricingRuleController{
+		pricingRuleUC: pricingRuleUC,
+		presenter:     presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *PricingRuleController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/pricing-rules", c.GetPricingRuleList)
+	routes.Admin.POST("/pricing-rules", c.CreatePricingRule)
+	routes.Admin.PUT("/pricing-rules/:id", c.UpdatePricingRule)
+	routes.Admin.DELETE("/pricing-rules/:id", c.DeletePricingRule)
+}
+
+// pricingRuleRequest は価格ルール作成・更新のリクエストボディ
+type pricingRuleRequest struct {
+	Name               string     `json:"name" binding:"required"`
+	ProductID          *uuid.UUID `json:"product_id"`
+	CategoryCode       string     `json:"category_code"`
+	DiscountType       string     `json:"discount_type" binding:"required"`
+	DiscountValue      int64      `json:"discount_value" binding:"required"`
+	TargetRole         string     `json:"target_role"`
+	MinTier            string     `json:"min_tier"`
+	MaxQuantityPerUser int        `json:"max_quantity_per_user"`
+	StartsAt           *time.Time `json:"starts_at"`
+	EndsAt             *time.Time `json:"ends_at"`
+	IsActive           *bool      `json:"is_active"` // 更新時のみ（省略時は有効）
+}
+
+func (r *pricingRuleRequest) toContent() inputport.PricingRuleContent {
+	content := inputport.PricingRuleContent{
+		Name:               r.Name,
+		ProductID:          r.ProductID,
+		CategoryCode:       r.CategoryCode,
+		DiscountType:       entities.PricingDiscountType(r.DiscountType),
+		DiscountValue:      r.DiscountValue,
+		TargetRole:         entities.UserRole(r.TargetRole),
+		MinTier:            entities.UserTier(r.MinTier),
+		MaxQuantityPerUser: r.MaxQuantityPerUser,
+		EndsAt:             r.EndsAt,
+	}
+	if r.StartsAt != nil {
+		content.StartsAt = *r.StartsAt
+	}
+	return content
+}
+
+// GetPricingRuleList は期間外・無効を含む価格ルールの一覧を取得
+// GET /api/admin/pricing-rules?offset=0&limit=20
+func (c *PricingRuleController) GetPricingRuleList(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
+
+	resp, err := c.pricingRuleUC.GetPricingRuleList(ctx, &inputport.GetPricingRuleListRequest{
+		AdminID: adminID.(uuid.UUID),
+		Offset:  offset,
+		Limit:   limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentPricingRuleList(resp))
+}
+
+// CreatePricingRule は価格ルールを作成
+// POST /api/admin/pricing-rules
+func (c *PricingRuleController) CreatePricingRule(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req pricingRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	rule, err := c.pricingRuleUC.CreatePricingRule(ctx, &inputport.CreatePricingRuleRequest{
+		AdminID:            adminID.(uuid.UUID),
+		PricingRuleContent: req.toContent(),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"pricing_rule": c.presenter.PresentPricingRule(rule)})
+}
+
+// UpdatePricingRule は価格ルールを更新
+// PUT /api/admin/pricing-rules/:id
+func (c *PricingRuleController) UpdatePricingRule(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	ruleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	var req pricingRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	rule, err := c.pricingRuleUC.UpdatePricingRule(ctx, &inputport.UpdatePricingRuleRequest{
+		AdminID:            adminID.(uuid.UUID),
+		PricingRuleID:      ruleID,
+		IsActive:           isActive,
+		PricingRuleContent: req.toContent(),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"pricing_rule": c.presenter.PresentPricingRule(rule)})
+}
+
+// DeletePricingRule は価格ルールを削除
+// DELETE /api/admin/pricing-rules/:id
+func (c *PricingRuleController) DeletePricingRule(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	ruleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	if err := c.pricingRuleUC.DeletePricingRule(ctx, &inputport.DeletePricingRuleRequest{
+		AdminID:       adminID.(uuid.UUID),
+		PricingRuleID: ruleID,
+	}); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "pricing rule deleted"})
+}
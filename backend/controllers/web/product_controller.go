@@ -2,7 +2,7 @@ package web
 
 import (
 	"net/http"
-	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gity/point-system/entities"
@@ -30,20 +30,45 @@ func NewProductController(
 	}
 }
 
+// RegisterRoutes はルートを登録
+func (c *ProductController) RegisterRoutes(routes *RouteGroups) {
+	routes.Public.GET("/products", c.GetProductList)
+
+	routes.Protected.POST("/products/exchange", c.ExchangeProduct)
+	routes.Protected.GET("/products/exchanges/history", c.GetExchangeHistory)
+	routes.Protected.POST("/products/exchanges/:id/cancel", routes.Authorize(entities.ResourceExchange, entities.ActionCancel), c.CancelExchange)
+	routes.Protected.GET("/products/gifts/received", c.GetReceivedGifts)
+	routes.Protected.POST("/products/exchanges/:id/accept", routes.Authorize(entities.ResourceExchange, entities.ActionAccept), c.AcceptGift)
+	routes.Protected.POST("/products/exchanges/:id/decline", routes.Authorize(entities.ResourceExchange, entities.ActionDecline), c.DeclineGift)
+
+	routes.Admin.POST("/products", c.CreateProduct)
+	routes.Admin.PUT("/products/:id", c.UpdateProduct)
+	routes.Admin.DELETE("/products/:id", c.DeleteProduct)
+	routes.Admin.GET("/exchanges", c.GetAllExchanges)
+	routes.Admin.POST("/exchanges/:id/deliver", c.MarkExchangeDelivered)
+	routes.Admin.GET("/exchanges/report", c.GetExchangeReport)
+	routes.Admin.POST("/exchanges/redemption/validate", c.ValidateRedemptionCode)
+	routes.Admin.POST("/exchanges/redemption/redeem", c.RedeemCode)
+}
+
 // CreateProduct は新しい商品を作成（管理者のみ）
 // POST /admin/products
 func (c *ProductController) CreateProduct(ctx *gin.Context) {
 	var req inputport.CreateProductRequest
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
+	if adminID, ok := ctx.Get("user_id"); ok {
+		req.AdminID = adminID.(uuid.UUID)
+	}
+
 	resp, err := c.productManagementUseCase.CreateProduct(ctx, &req)
 	if err != nil {
 		c.logger.Error("Failed to create product", entities.NewField("error", err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -55,22 +80,25 @@ func (c *ProductController) CreateProduct(ctx *gin.Context) {
 func (c *ProductController) UpdateProduct(ctx *gin.Context) {
 	productID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid product ID"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
 		return
 	}
 
 	var req inputport.UpdateProductRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	req.ProductID = productID
+	if adminID, ok := ctx.Get("user_id"); ok {
+		req.AdminID = adminID.(uuid.UUID)
+	}
 
 	resp, err := c.productManagementUseCase.UpdateProduct(ctx, &req)
 	if err != nil {
 		c.logger.Error("Failed to update product", entities.NewField("error", err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -82,7 +110,7 @@ func (c *ProductController) UpdateProduct(ctx *gin.Context) {
 func (c *ProductController) DeleteProduct(ctx *gin.Context) {
 	productID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid product ID"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
 		return
 	}
 
@@ -92,7 +120,7 @@ func (c *ProductController) DeleteProduct(ctx *gin.Context) {
 
 	if err := c.productManagementUseCase.DeleteProduct(ctx, req); err != nil {
 		c.logger.Error("Failed to delete product", entities.NewField("error", err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -102,8 +130,10 @@ func (c *ProductController) DeleteProduct(ctx *gin.Context) {
 // GetProductList は商品一覧を取得
 // GET /products?category=snack&available_only=true&offset=0&limit=20
 func (c *ProductController) GetProductList(ctx *gin.Context) {
-	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
-	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
 	category := ctx.Query("category")
 	availableOnly := ctx.Query("available_only") == "true"
 
@@ -117,7 +147,7 @@ func (c *ProductController) GetProductList(ctx *gin.Context) {
 	resp, err := c.productManagementUseCase.GetProductList(ctx, req)
 	if err != nil {
 		c.logger.Error("Failed to get product list", entities.NewField("error", err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -130,24 +160,27 @@ func (c *ProductController) ExchangeProduct(ctx *gin.Context) {
 	// ユーザーIDはセッションから取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	var reqBody struct {
-		ProductID string `json:"product_id" binding:"required"`
-		Quantity  int    `json:"quantity" binding:"required"`
-		Notes     string `json:"notes"`
+		ProductID   string `json:"product_id" binding:"required"`
+		Quantity    int    `json:"quantity" binding:"required"`
+		Notes       string `json:"notes"`
+		RecipientID string `json:"recipient_id"` // 友達に贈る場合のみ
+		// ShippingAddressID は配送が必要な商品のお届け先（省略時は既定のお届け先）
+		ShippingAddressID string `json:"shipping_address_id"`
 	}
 
 	if err := ctx.ShouldBindJSON(&reqBody); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	productID, err := uuid.Parse(reqBody.ProductID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid product ID"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("product_id", "must be a valid ID"))
 		return
 	}
 
@@ -157,11 +190,18 @@ func (c *ProductController) ExchangeProduct(ctx *gin.Context) {
 		Quantity:  reqBody.Quantity,
 		Notes:     reqBody.Notes,
 	}
+	var ok bool
+	if req.RecipientID, ok = parseOptionalID(ctx, "recipient_id", reqBody.RecipientID); !ok {
+		return
+	}
+	if req.ShippingAddressID, ok = parseOptionalID(ctx, "shipping_address_id", reqBody.ShippingAddressID); !ok {
+		return
+	}
 
 	resp, err := c.productExchangeUseCase.ExchangeProduct(ctx, req)
 	if err != nil {
 		c.logger.Error("Failed to exchange product", entities.NewField("error", err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -173,12 +213,14 @@ func (c *ProductController) ExchangeProduct(ctx *gin.Context) {
 func (c *ProductController) GetExchangeHistory(ctx *gin.Context) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
-	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
-	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
 
 	req := &inputport.GetExchangeHistoryRequest{
 		UserID: userID.(uuid.UUID),
@@ -189,7 +231,7 @@ func (c *ProductController) GetExchangeHistory(ctx *gin.Context) {
 	resp, err := c.productExchangeUseCase.GetExchangeHistory(ctx, req)
 	if err != nil {
 		c.logger.Error("Failed to get exchange history", entities.NewField("error", err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -201,13 +243,13 @@ func (c *ProductController) GetExchangeHistory(ctx *gin.Context) {
 func (c *ProductController) CancelExchange(ctx *gin.Context) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	exchangeID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid exchange ID"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
 		return
 	}
 
@@ -218,19 +260,112 @@ func (c *ProductController) CancelExchange(ctx *gin.Context) {
 
 	if err := c.productExchangeUseCase.CancelExchange(ctx, req); err != nil {
 		c.logger.Error("Failed to cancel exchange", entities.NewField("error", err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "exchange cancelled successfully"})
 }
 
+// GetReceivedGifts は友達から贈られた交換を取得
+// GET /products/gifts/received?offset=0&limit=20
+func (c *ProductController) GetReceivedGifts(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
+
+	resp, err := c.productExchangeUseCase.GetReceivedGifts(ctx, &inputport.GetExchangeHistoryRequest{
+		UserID: userID.(uuid.UUID),
+		Offset: offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		c.logger.Error("Failed to get received gifts", entities.NewField("error", err))
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// AcceptGift は贈られた交換を受け取る（配送が必要な商品は shipping_address_id、省略時は既定のお届け先）
+// POST /products/exchanges/:id/accept
+func (c *ProductController) AcceptGift(ctx *gin.Context) {
+	req, ok := c.bindRespondGiftRequest(ctx)
+	if !ok {
+		return
+	}
+
+	var reqBody struct {
+		ShippingAddressID string `json:"shipping_address_id"`
+	}
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&reqBody); err != nil {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+	if req.ShippingAddressID, ok = parseOptionalID(ctx, "shipping_address_id", reqBody.ShippingAddressID); !ok {
+		return
+	}
+
+	exchange, err := c.productExchangeUseCase.AcceptGift(ctx, req)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"exchange": exchange})
+}
+
+// DeclineGift は贈られた交換を辞退する（贈った人にポイントを返す）
+// POST /products/exchanges/:id/decline
+func (c *ProductController) DeclineGift(ctx *gin.Context) {
+	req, ok := c.bindRespondGiftRequest(ctx)
+	if !ok {
+		return
+	}
+
+	exchange, err := c.productExchangeUseCase.DeclineGift(ctx, req)
+	if err != nil {
+		c.logger.Error("Failed to decline gift", entities.NewField("error", err))
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"exchange": exchange})
+}
+
+// bindRespondGiftRequest はギフトへの返事のリクエストを読み取る
+func (c *ProductController) bindRespondGiftRequest(ctx *gin.Context) (*inputport.RespondGiftRequest, bool) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return nil, false
+	}
+
+	exchangeID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return nil, false
+	}
+
+	return &inputport.RespondGiftRequest{UserID: userID.(uuid.UUID), ExchangeID: exchangeID}, true
+}
+
 // MarkExchangeDelivered は配達完了にする（管理者のみ）
 // POST /admin/exchanges/:id/deliver
 func (c *ProductController) MarkExchangeDelivered(ctx *gin.Context) {
 	exchangeID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid exchange ID"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
 		return
 	}
 
@@ -240,7 +375,7 @@ func (c *ProductController) MarkExchangeDelivered(ctx *gin.Context) {
 
 	if err := c.productExchangeUseCase.MarkExchangeDelivered(ctx, req); err != nil {
 		c.logger.Error("Failed to mark exchange as delivered", entities.NewField("error", err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -250,13 +385,108 @@ func (c *ProductController) MarkExchangeDelivered(ctx *gin.Context) {
 // GetAllExchanges はすべての交換履歴を取得（管理者のみ）
 // GET /admin/exchanges?offset=0&limit=20
 func (c *ProductController) GetAllExchanges(ctx *gin.Context) {
-	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
-	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
 
 	resp, err := c.productExchangeUseCase.GetAllExchanges(ctx, offset, limit)
 	if err != nil {
 		c.logger.Error("Failed to get all exchanges", entities.NewField("error", err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ValidateRedemptionCode は引換コードに対応する交換を確認（管理者のみ、使用済みにはしない）
+// POST /admin/exchanges/redemption/validate
+func (c *ProductController) ValidateRedemptionCode(ctx *gin.Context) {
+	req, ok := c.bindRedeemCodeRequest(ctx)
+	if !ok {
+		return
+	}
+
+	resp, err := c.productExchangeUseCase.ValidateRedemptionCode(ctx, req)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RedeemCode は引換コードを使用済みにして受け渡し済みにする（管理者のみ）
+// POST /admin/exchanges/redemption/redeem
+func (c *ProductController) RedeemCode(ctx *gin.Context) {
+	req, ok := c.bindRedeemCodeRequest(ctx)
+	if !ok {
+		return
+	}
+
+	resp, err := c.productExchangeUseCase.RedeemCode(ctx, req)
+	if err != nil {
+		c.logger.Warn("Failed to redeem code", entities.NewField("error", err))
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// bindRedeemCodeRequest は引換コードのリクエストを読み取る（コードはURLに載せずボディで受け取る）
+func (c *ProductController) bindRedeemCodeRequest(ctx *gin.Context) (*inputport.RedeemCodeRequest, bool) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return nil, false
+	}
+
+	var reqBody struct {
+		Code string `json:"code" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&reqBody); err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("code", "is required"))
+		return nil, false
+	}
+
+	return &inputport.RedeemCodeRequest{AdminID: adminID.(uuid.UUID), Code: reqBody.Code}, true
+}
+
+// GetExchangeReport は商品ごと・期間ごとの交換実績を取得（管理者のみ）
+// GET /admin/exchanges/report?date_from=2026-01-01&date_to=2026-03-31&granularity=week
+func (c *ProductController) GetExchangeReport(ctx *gin.Context) {
+	req := &inputport.GetExchangeReportRequest{
+		Granularity: entities.AnalyticsGranularity(ctx.DefaultQuery("granularity", string(entities.AnalyticsGranularityWeek))),
+	}
+	if !req.Granularity.IsValid() {
+		respondError(ctx, http.StatusBadRequest, invalidParam("granularity", "must be one of: week, month"))
+		return
+	}
+
+	// date_to はその日を含むため翌日0時を終端にする
+	if v := ctx.Query("date_from"); v != "" {
+		from, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			respondError(ctx, http.StatusBadRequest, invalidParam("date_from", "must be a date in YYYY-MM-DD format"))
+			return
+		}
+		req.From = from
+	}
+	if v := ctx.Query("date_to"); v != "" {
+		to, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			respondError(ctx, http.StatusBadRequest, invalidParam("date_to", "must be a date in YYYY-MM-DD format"))
+			return
+		}
+		req.To = to.AddDate(0, 0, 1)
+	}
+
+	resp, err := c.productExchangeUseCase.GetExchangeReport(ctx, req)
+	if err != nil {
+		c.logger.Error("Failed to get exchange report", entities.NewField("error", err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -1,11 +1,11 @@
 package web
 
 import (
-	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/google/uuid"
 )
@@ -27,13 +27,23 @@ func NewQRCodeController(
 	}
 }
 
+// RegisterRoutes はルートを登録
+func (c *QRCodeController) RegisterRoutes(routes *RouteGroups) {
+	// 旧機能 - 削除予定
+	qrcodes := routes.Protected.Group("/qrcodes")
+	qrcodes.POST("/receive", c.GenerateReceiveQR)
+	qrcodes.POST("/send", c.GenerateSendQR)
+	qrcodes.POST("/scan", c.ScanQR)
+	qrcodes.GET("/history", c.GetQRCodeHistory)
+}
+
 // GenerateReceiveQR は受取用QRコードを生成
 // POST /api/qrcodes/receive
 func (c *QRCodeController) GenerateReceiveQR(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
@@ -42,7 +52,7 @@ func (c *QRCodeController) GenerateReceiveQR(ctx *gin.Context) {
 		Amount *int64 `json:"amount"`
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -52,7 +62,7 @@ func (c *QRCodeController) GenerateReceiveQR(ctx *gin.Context) {
 		Amount: req.Amount,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -66,16 +76,16 @@ func (c *QRCodeController) GenerateSendQR(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// リクエストボディ解析
 	var req struct {
-		Amount int64 `json:"amount" binding:"required"`
+		Amount int64 `json:"amount" binding:"required,points"`
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -85,7 +95,7 @@ func (c *QRCodeController) GenerateSendQR(ctx *gin.Context) {
 		Amount: req.Amount,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -99,7 +109,7 @@ func (c *QRCodeController) ScanQR(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
@@ -110,7 +120,7 @@ func (c *QRCodeController) ScanQR(ctx *gin.Context) {
 		IdempotencyKey string `json:"idempotency_key" binding:"required"`
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -122,7 +132,7 @@ func (c *QRCodeController) ScanQR(ctx *gin.Context) {
 		IdempotencyKey: req.IdempotencyKey,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -136,16 +146,14 @@ func (c *QRCodeController) GetQRCodeHistory(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// クエリパラメータ取得
-	var offset, limit int
-	fmt.Sscanf(ctx.Query("offset"), "%d", &offset)
-	fmt.Sscanf(ctx.Query("limit"), "%d", &limit)
-	if limit == 0 {
-		limit = 20
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
 	}
 
 	// ユースケース実行
@@ -155,7 +163,7 @@ func (c *QRCodeController) GetQRCodeHistory(ctx *gin.Context) {
 		Limit:  limit,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
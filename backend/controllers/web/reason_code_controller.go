@@ -0,0 +1,134 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// ReasonCodeController は理由コードのコントローラー
+type ReasonCodeController struct {
+	reasonCodeUC inputport.ReasonCodeInputPort
+	presenter    *presenter.ReasonCodePresenter
+}
+
+// NewReasonCodeController は新しいReasonCodeControllerを作成
+func NewReasonCodeController(
+	reasonCodeUC inputport.ReasonCodeInputPort,
+	presenter *presenter.ReasonCodePresenter,
+) *ReasonCodeController {
+	return &ReasonCodeController{
+		reasonCodeUC: reasonCodeUC,
+		presenter:    presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *ReasonCodeController) RegisterRoutes(routes *RouteGroups) {
+	routes.Protected.GET("/points/reason-codes", c.GetReasonCodes)
+
+	// 削除はせず is_active=false で停止する
+	routes.Admin.GET("/reason-codes", c.GetAdminReasonCodes)
+	routes.Admin.POST("/reason-codes", c.CreateReasonCode)
+	routes.Admin.PUT("/reason-codes/:code", c.UpdateReasonCode)
+}
+
+// GetReasonCodes は有効な理由コードの一覧を取得（取引履歴の絞り込み用）
+// GET /api/reason-codes
+func (c *ReasonCodeController) GetReasonCodes(ctx *gin.Context) {
+	c.list(ctx, false)
+}
+
+// GetAdminReasonCodes は無効なものを含む理由コードの一覧を取得
+// GET /api/admin/reason-codes
+func (c *ReasonCodeController) GetAdminReasonCodes(ctx *gin.Context) {
+	c.list(ctx, true)
+}
+
+func (c *ReasonCodeController) list(ctx *gin.Context, includeInactive bool) {
+	reasonCodes, err := c.reasonCodeUC.ListReasonCodes(ctx, &inputport.ListReasonCodesRequest{
+		IncludeInactive: includeInactive,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentReasonCodeList(reasonCodes))
+}
+
+// CreateReasonCode は理由コードを作成
+// POST /api/admin/reason-codes
+func (c *ReasonCodeController) CreateReasonCode(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		Code        string `json:"code" binding:"required"`
+		Label       string `json:"label" binding:"required"`
+		Description string `json:"description"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	reasonCode, err := c.reasonCodeUC.CreateReasonCode(ctx, &inputport.CreateReasonCodeRequest{
+		AdminID:     adminID.(uuid.UUID),
+		Code:        req.Code,
+		Label:       req.Label,
+		Description: req.Description,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"reason_code": c.presenter.PresentReasonCode(reasonCode)})
+}
+
+// UpdateReasonCode は理由コードの表示名・説明・有効状態を更新
+// PUT /api/admin/reason-codes/:code
+func (c *ReasonCodeController) UpdateReasonCode(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		Label       string `json:"label" binding:"required"`
+		Description string `json:"description"`
+		IsActive    *bool  `json:"is_active"` // 省略時は有効
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	reasonCode, err := c.reasonCodeUC.UpdateReasonCode(ctx, &inputport.UpdateReasonCodeRequest{
+		AdminID:     adminID.(uuid.UUID),
+		Code:        ctx.Param("code"),
+		Label:       req.Label,
+		Description: req.Description,
+		IsActive:    isActive,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"reason_code": c.presenter.PresentReasonCode(reasonCode)})
+}
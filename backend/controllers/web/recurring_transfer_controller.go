@@ -0,0 +1,137 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// RecurringTransferController は定期送金のコントローラー
+type RecurringTransferController struct {
+	recurringTransferUC inputport.RecurringTransferInputPort
+	presenter           *presenter.RecurringTransferPresenter
+}
+
+// NewRecurringTransferController は新しいRecurringTransferControllerを作成
+func NewRecurringTransferController(
+	recurringTransferUC inputport.RecurringTransferInputPort,
+	presenter *presenter.RecurringTransferPresenter,
+) *RecurringTransferController {
+	return &RecurringTransferController{
+		recurringTransferUC: recurringTransferUC,
+		presenter:           presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *RecurringTransferController) RegisterRoutes(routes *RouteGroups) {
+	routes.Protected.GET("/points/recurring", c.GetRecurringTransfers)
+	routes.Protected.POST("/points/recurring", c.CreateRecurringTransfer)
+	routes.Protected.DELETE("/points/recurring/:id", c.CancelRecurringTransfer)
+}
+
+// GetRecurringTransfers は自分が送信者または受取人の定期送金一覧を取得
+// GET /api/points/recurring?offset=0&limit=20
+func (c *RecurringTransferController) GetRecurringTransfers(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
+
+	resp, err := c.recurringTransferUC.GetRecurringTransfers(ctx, &inputport.GetRecurringTransfersRequest{
+		UserID: userID.(uuid.UUID),
+		Offset: offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentRecurringTransferList(resp))
+}
+
+// CreateRecurringTransfer は定期送金を登録
+// POST /api/points/recurring
+func (c *RecurringTransferController) CreateRecurringTransfer(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		ToUserID string     `json:"to_user_id" binding:"required,uuid"`
+		Amount   int64      `json:"amount" binding:"required,points"`
+		Interval string     `json:"interval" binding:"required"`
+		Message  string     `json:"message" binding:"message"`
+		StartAt  *time.Time `json:"start_at"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	toUserID, err := uuid.Parse(req.ToUserID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("to_user_id", "must be a valid ID"))
+		return
+	}
+
+	input := &inputport.CreateRecurringTransferRequest{
+		FromUserID: userID.(uuid.UUID),
+		ToUserID:   toUserID,
+		Amount:     req.Amount,
+		Interval:   entities.RecurringInterval(req.Interval),
+		Message:    req.Message,
+	}
+	if req.StartAt != nil {
+		input.StartAt = *req.StartAt
+	}
+
+	info, err := c.recurringTransferUC.CreateRecurringTransfer(ctx, input)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"recurring_transfer": c.presenter.PresentRecurringTransferInfo(info)})
+}
+
+// CancelRecurringTransfer は定期送金を解約（送信者・受取人のどちらからでも可能）
+// DELETE /api/points/recurring/:id
+func (c *RecurringTransferController) CancelRecurringTransfer(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	transferID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	transfer, err := c.recurringTransferUC.CancelRecurringTransfer(ctx, &inputport.CancelRecurringTransferRequest{
+		TransferID: transferID,
+		UserID:     userID.(uuid.UUID),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"recurring_transfer": c.presenter.PresentRecurringTransfer(transfer)})
+}
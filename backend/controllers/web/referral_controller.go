@@ -0,0 +1,96 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// ReferralController は紹介プログラムのコントローラー
+type ReferralController struct {
+	referralUC inputport.ReferralInputPort
+	presenter  *presenter.ReferralPresenter
+}
+
+// NewReferralController は新しいReferralControllerを作成
+func NewReferralController(
+	referralUC inputport.ReferralInputPort,
+	presenter *presenter.ReferralPresenter,
+) *ReferralController {
+	return &ReferralController{
+		referralUC: referralUC,
+		presenter:  presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *ReferralController) RegisterRoutes(routes *RouteGroups) {
+	// 未発行なら発行する
+	routes.Authenticated.GET("/referrals/code", c.GetReferralCode)
+	routes.Admin.GET("/referrals/report", c.GetReferralReport)
+}
+
+// GetReferralCode は自分の紹介コードを取得（未発行なら発行する）
+// GET /api/referrals/code
+func (c *ReferralController) GetReferralCode(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	code, err := c.referralUC.GetReferralCode(ctx, userID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentReferralCode(code))
+}
+
+// GetReferralReport は期間内の紹介の実績を取得（管理者用）
+// GET /api/admin/referrals/report
+func (c *ReferralController) GetReferralReport(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+	req := &inputport.GetReferralReportRequest{AdminID: adminID.(uuid.UUID)}
+
+	// date_to はその日を含むため翌日0時を終端にする
+	if v := ctx.Query("date_from"); v != "" {
+		from, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			respondError(ctx, http.StatusBadRequest, invalidParam("date_from", "must be a date in YYYY-MM-DD format"))
+			return
+		}
+		req.From = from
+	}
+	if v := ctx.Query("date_to"); v != "" {
+		to, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			respondError(ctx, http.StatusBadRequest, invalidParam("date_to", "must be a date in YYYY-MM-DD format"))
+			return
+		}
+		req.To = to.AddDate(0, 0, 1)
+	}
+	limit, ok := bindLimit(ctx, 0)
+	if !ok {
+		return
+	}
+	req.Limit = limit
+
+	report, err := c.referralUC.GetReferralReport(ctx, req)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentReferralReport(report))
+}
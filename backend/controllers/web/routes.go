@@ -0,0 +1,34 @@
+package web
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+)
+
+// RouteGroups はコントローラーがルートを登録する先のグループ（アクセス制御ごと）
+// 各グループのミドルウェア（認証・CSRF保護など）はフレームワーク層で設定済みのため、
+// コントローラーはどのグループに載せるかだけを選ぶ
+type RouteGroups struct {
+	Public        *gin.RouterGroup // 公開（リクエスト検証のみ）
+	Kiosk         *gin.RouterGroup // キオスク端末（/kiosk。APIキー認証、セッション・CSRFなし）
+	Authenticated *gin.RouterGroup // 認証のみ（CSRF保護なし。状態変更のないGET用）
+	Session       *gin.RouterGroup // 認証 + CSRF保護（メンテナンス中も可能。ログアウト用）
+	Protected     *gin.RouterGroup // 認証 + CSRF保護 + メンテナンス + リクエスト検証 + 冪等性
+	Admin         *gin.RouterGroup // Protectedの /admin 配下
+
+	// Maintenance はメンテナンス中に止めたい公開ルートへ個別に付けるミドルウェア
+	Maintenance gin.HandlerFunc
+	// Now はリクエスト時の時刻（時刻を受け取るハンドラーに渡す）
+	Now func() time.Time
+	// Authorize はパスの:idが指すユーザーのリソースへの操作を確認するミドルウェアを返す
+	// （関わりのないユーザーには404、関わっているが許されない操作には403。ハンドラーの前に付ける）
+	Authorize func(resource entities.ResourceType, action entities.Action) gin.HandlerFunc
+}
+
+// RouteRegistrar は自分のルートをRouteGroupsへ登録するコントローラー
+// ルーターはAPIバージョンごとにRouteRegistrarの一覧を受け取り、順に登録させる
+type RouteRegistrar interface {
+	RegisterRoutes(routes *RouteGroups)
+}
@@ -0,0 +1,52 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// ScheduledJobController は定期実行ジョブのコントローラー
+type ScheduledJobController struct {
+	scheduledJobUC inputport.ScheduledJobInputPort
+	presenter      *presenter.ScheduledJobPresenter
+}
+
+// NewScheduledJobController は新しいScheduledJobControllerを作成
+func NewScheduledJobController(
+	scheduledJobUC inputport.ScheduledJobInputPort,
+	presenter *presenter.ScheduledJobPresenter,
+) *ScheduledJobController {
+	return &ScheduledJobController{
+		scheduledJobUC: scheduledJobUC,
+		presenter:      presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *ScheduledJobController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/jobs", c.GetJobs)
+}
+
+// GetJobs は全ジョブのスケジュールと直近の実行状況を取得
+// GET /api/admin/jobs
+func (c *ScheduledJobController) GetJobs(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	jobs, err := c.scheduledJobUC.ListJobs(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentJobs(jobs, time.Now()))
+}
@@ -0,0 +1,123 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// SecurityHistoryController はユーザー名・パスワード変更履歴とログイン履歴のコントローラー
+type SecurityHistoryController struct {
+	historyUC inputport.SecurityHistoryInputPort
+	presenter *presenter.SecurityHistoryPresenter
+}
+
+// NewSecurityHistoryController は新しいSecurityHistoryControllerを作成
+func NewSecurityHistoryController(
+	historyUC inputport.SecurityHistoryInputPort,
+	presenter *presenter.SecurityHistoryPresenter,
+) *SecurityHistoryController {
+	return &SecurityHistoryController{
+		historyUC: historyUC,
+		presenter: presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *SecurityHistoryController) RegisterRoutes(routes *RouteGroups) {
+	routes.Authenticated.GET("/settings/security/history", c.GetOwnHistory)
+	routes.Authenticated.GET("/settings/security/logins", c.GetOwnLogins)
+	routes.Admin.GET("/users/:id/history", c.GetUserHistory)
+}
+
+// GetOwnHistory は本人の変更履歴を取得
+// GET /api/settings/security/history?offset=0&limit=20
+func (c *SecurityHistoryController) GetOwnHistory(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
+
+	resp, err := c.historyUC.GetOwnHistory(ctx, &inputport.GetSecurityHistoryRequest{
+		UserID: userID.(uuid.UUID),
+		Offset: offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentOwnHistory(resp))
+}
+
+// GetOwnLogins は本人のログイン履歴を取得
+// GET /api/settings/security/logins?offset=0&limit=20
+func (c *SecurityHistoryController) GetOwnLogins(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
+
+	resp, err := c.historyUC.GetOwnLogins(ctx, &inputport.GetSecurityHistoryRequest{
+		UserID: userID.(uuid.UUID),
+		Offset: offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentOwnLogins(resp))
+}
+
+// GetUserHistory は指定ユーザーの変更履歴を取得（管理者用）
+// GET /api/admin/users/:id/history?offset=0&limit=20
+func (c *SecurityHistoryController) GetUserHistory(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
+
+	resp, err := c.historyUC.GetUserHistory(ctx, &inputport.GetUserSecurityHistoryRequest{
+		AdminID: adminID.(uuid.UUID),
+		UserID:  userID,
+		Offset:  offset,
+		Limit:   limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentAdminHistory(resp))
+}
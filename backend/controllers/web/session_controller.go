@@ -0,0 +1,175 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// SessionController はログイン中セッション（端末）管理のコントローラー
+type SessionController struct {
+	sessionUC inputport.SessionInputPort
+	presenter *presenter.SessionPresenter
+	cookie    *presenter.SessionCookie
+}
+
+// NewSessionController は新しいSessionControllerを作成
+func NewSessionController(
+	sessionUC inputport.SessionInputPort,
+	presenter *presenter.SessionPresenter,
+	cookie *presenter.SessionCookie,
+) *SessionController {
+	return &SessionController{
+		sessionUC: sessionUC,
+		presenter: presenter,
+		cookie:    cookie,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *SessionController) RegisterRoutes(routes *RouteGroups) {
+	routes.Authenticated.GET("/settings/sessions", c.ListSessions)
+	routes.Protected.DELETE("/settings/sessions", c.RevokeOtherSessions)
+	routes.Protected.DELETE("/settings/sessions/:id", c.RevokeSession)
+
+	routes.Admin.POST("/users/:id/logout", c.ForceLogoutUser)
+	routes.Admin.POST("/sessions/purge", c.PurgeSessions)
+}
+
+// ListSessions はログイン中のセッション一覧を取得
+// GET /api/settings/sessions
+func (c *SessionController) ListSessions(ctx *gin.Context) {
+	current, ok := currentSession(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	resp, err := c.sessionUC.ListSessions(ctx, &inputport.ListSessionsRequest{
+		UserID:           current.UserID,
+		CurrentSessionID: current.ID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentListSessions(resp))
+}
+
+// RevokeSession は指定したセッションを失効
+// DELETE /api/settings/sessions/:id
+func (c *SessionController) RevokeSession(ctx *gin.Context) {
+	current, ok := currentSession(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	sessionID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	resp, err := c.sessionUC.RevokeSession(ctx, &inputport.RevokeSessionRequest{
+		UserID:           current.UserID,
+		CurrentSessionID: current.ID,
+		SessionID:        sessionID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusNotFound, err)
+		return
+	}
+
+	// 現在のセッションを失効した場合はログアウトと同様にCookieをクリア
+	if resp.RevokedCurrent {
+		c.cookie.Clear(ctx)
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentRevokeSession(resp))
+}
+
+// RevokeOtherSessions は現在のセッション以外をすべて失効
+// DELETE /api/settings/sessions
+func (c *SessionController) RevokeOtherSessions(ctx *gin.Context) {
+	current, ok := currentSession(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	resp, err := c.sessionUC.RevokeOtherSessions(ctx, &inputport.RevokeOtherSessionsRequest{
+		UserID:           current.UserID,
+		CurrentSessionID: current.ID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentRevokeOtherSessions(resp))
+}
+
+// ForceLogoutUser は指定ユーザーの全セッションを失効（管理者用）
+// POST /api/admin/users/:id/logout
+func (c *SessionController) ForceLogoutUser(ctx *gin.Context) {
+	current, ok := currentSession(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid user ID"))
+		return
+	}
+
+	resp, err := c.sessionUC.ForceLogoutUser(ctx, &inputport.ForceLogoutUserRequest{
+		AdminID:          current.UserID,
+		CurrentSessionID: current.ID,
+		UserID:           userID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentForceLogout("user logged out", resp))
+}
+
+// PurgeSessions は全ユーザーのセッションを失効（管理者用、操作中のセッションは残す）
+// POST /api/admin/sessions/purge
+func (c *SessionController) PurgeSessions(ctx *gin.Context) {
+	current, ok := currentSession(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	resp, err := c.sessionUC.PurgeSessions(ctx, &inputport.PurgeSessionsRequest{
+		AdminID:          current.UserID,
+		CurrentSessionID: current.ID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentForceLogout("all sessions revoked", resp))
+}
+
+// currentSession はAuthMiddlewareがセットしたセッションを取得
+func currentSession(ctx *gin.Context) (*entities.Session, bool) {
+	value, exists := ctx.Get("session")
+	if !exists {
+		return nil, false
+	}
+	session, ok := value.(*entities.Session)
+	return session, ok
+}
@@ -0,0 +1,204 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// ShippingAddressController はお届け先（住所録）のコントローラー
+type ShippingAddressController struct {
+	addressUC inputport.ShippingAddressInputPort
+	presenter *presenter.ShippingAddressPresenter
+}
+
+// NewShippingAddressController は新しいShippingAddressControllerを作成
+func NewShippingAddressController(
+	addressUC inputport.ShippingAddressInputPort,
+	presenter *presenter.ShippingAddressPresenter,
+) *ShippingAddressController {
+	return &ShippingAddressController{
+		addressUC: addressUC,
+		presenter: presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *ShippingAddressController) RegisterRoutes(routes *RouteGroups) {
+	routes.Authenticated.GET("/settings/addresses", c.ListAddresses)
+	routes.Protected.POST("/settings/addresses", c.CreateAddress)
+	routes.Protected.PUT("/settings/addresses/:id", routes.Authorize(entities.ResourceShippingAddress, entities.ActionUpdate), c.UpdateAddress)
+	routes.Protected.DELETE("/settings/addresses/:id", routes.Authorize(entities.ResourceShippingAddress, entities.ActionDelete), c.DeleteAddress)
+	routes.Protected.POST("/settings/addresses/:id/default", routes.Authorize(entities.ResourceShippingAddress, entities.ActionUpdate), c.SetDefaultAddress)
+}
+
+// shippingAddressBody はお届け先の登録・変更のリクエストボディ
+type shippingAddressBody struct {
+	Label         string `json:"label"`
+	RecipientName string `json:"recipient_name" binding:"required"`
+	PostalCode    string `json:"postal_code" binding:"required"`
+	Prefecture    string `json:"prefecture" binding:"required"`
+	City          string `json:"city" binding:"required"`
+	Line1         string `json:"line1" binding:"required"`
+	Line2         string `json:"line2"`
+	Phone         string `json:"phone" binding:"required"`
+	IsDefault     bool   `json:"is_default"`
+}
+
+func (b *shippingAddressBody) input() entities.ShippingAddressInput {
+	return entities.ShippingAddressInput{
+		Label:         b.Label,
+		RecipientName: b.RecipientName,
+		PostalCode:    b.PostalCode,
+		Prefecture:    b.Prefecture,
+		City:          b.City,
+		Line1:         b.Line1,
+		Line2:         b.Line2,
+		Phone:         b.Phone,
+	}
+}
+
+// ListAddresses はお届け先の一覧を取得
+// GET /api/settings/addresses
+func (c *ShippingAddressController) ListAddresses(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	addresses, err := c.addressUC.ListAddresses(ctx, userID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentAddresses(addresses))
+}
+
+// CreateAddress はお届け先を登録
+// POST /api/settings/addresses
+func (c *ShippingAddressController) CreateAddress(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var body shippingAddressBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	address, err := c.addressUC.CreateAddress(ctx, &inputport.CreateShippingAddressRequest{
+		UserID:    userID.(uuid.UUID),
+		Address:   body.input(),
+		IsDefault: body.IsDefault,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"address": c.presenter.PresentAddress(address)})
+}
+
+// UpdateAddress はお届け先を変更
+// PUT /api/settings/addresses/:id
+func (c *ShippingAddressController) UpdateAddress(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	addressID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	var body shippingAddressBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	address, err := c.addressUC.UpdateAddress(ctx, &inputport.UpdateShippingAddressRequest{
+		UserID:    userID.(uuid.UUID),
+		AddressID: addressID,
+		Address:   body.input(),
+		IsDefault: body.IsDefault,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"address": c.presenter.PresentAddress(address)})
+}
+
+// SetDefaultAddress は既定のお届け先を切り替える
+// POST /api/settings/addresses/:id/default
+func (c *ShippingAddressController) SetDefaultAddress(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	addressID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	address, err := c.addressUC.SetDefaultAddress(ctx, userID.(uuid.UUID), addressID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"address": c.presenter.PresentAddress(address)})
+}
+
+// DeleteAddress はお届け先を削除
+// DELETE /api/settings/addresses/:id
+func (c *ShippingAddressController) DeleteAddress(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	addressID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	if err := c.addressUC.DeleteAddress(ctx, userID.(uuid.UUID), addressID); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "address deleted"})
+}
+
+// parseOptionalID はリクエストボディの任意のID（お届け先など）を解析（空ならnil、不正なら400を返してfalse）
+func parseOptionalID(ctx *gin.Context, field, raw string) (*uuid.UUID, bool) {
+	if raw == "" {
+		return nil, true
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam(field, "must be a valid ID"))
+		return nil, false
+	}
+	return &id, true
+}
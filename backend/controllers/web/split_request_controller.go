@@ -0,0 +1,171 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// SplitRequestController は割り勘のコントローラー
+// 参加者の支払い・拒否は送金リクエストの承認・拒否（/transfer-requests/:id/approve・reject）で行う
+type SplitRequestController struct {
+	splitRequestUC inputport.SplitRequestInputPort
+	presenter      *presenter.SplitRequestPresenter
+}
+
+// NewSplitRequestController は新しいSplitRequestControllerを作成
+func NewSplitRequestController(
+	splitRequestUC inputport.SplitRequestInputPort,
+	presenter *presenter.SplitRequestPresenter,
+) *SplitRequestController {
+	return &SplitRequestController{
+		splitRequestUC: splitRequestUC,
+		presenter:      presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *SplitRequestController) RegisterRoutes(routes *RouteGroups) {
+	splits := routes.Protected.Group("/split-requests")
+	splits.POST("", c.CreateSplitRequest)
+	splits.GET("", c.ListSplitRequests)
+	splits.GET("/:id", c.GetSplitRequest)
+	splits.DELETE("/:id", c.CancelSplitRequest)
+}
+
+// CreateSplitRequest は割り勘を作成
+// POST /api/split-requests
+func (c *SplitRequestController) CreateSplitRequest(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		TotalAmount  int64  `json:"total_amount" binding:"required,points"`
+		SplitType    string `json:"split_type" binding:"required,oneof=equal custom"`
+		Participants []struct {
+			UserID string `json:"user_id" binding:"required"`
+			Amount int64  `json:"amount"`
+		} `json:"participants" binding:"required,min=1,dive"`
+		IncludeRequester bool   `json:"include_requester"`
+		Message          string `json:"message" binding:"message"`
+		IdempotencyKey   string `json:"idempotency_key" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	participants := make([]entities.SplitShare, len(req.Participants))
+	for i, p := range req.Participants {
+		participantID, err := uuid.Parse(p.UserID)
+		if err != nil {
+			respondError(ctx, http.StatusBadRequest, invalidParam("participants.user_id", "must be a valid ID"))
+			return
+		}
+		participants[i] = entities.SplitShare{UserID: participantID, Amount: p.Amount}
+	}
+
+	resp, err := c.splitRequestUC.CreateSplitRequest(ctx, &inputport.CreateSplitRequestRequest{
+		RequesterID:      userID.(uuid.UUID),
+		TotalAmount:      req.TotalAmount,
+		SplitType:        entities.SplitType(req.SplitType),
+		Participants:     participants,
+		IncludeRequester: req.IncludeRequester,
+		Message:          req.Message,
+		IdempotencyKey:   req.IdempotencyKey,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"split_request": c.presenter.PresentSplitRequest(resp)})
+}
+
+// ListSplitRequests は作成した割り勘の一覧を取得
+// GET /api/split-requests?offset=0&limit=20
+func (c *SplitRequestController) ListSplitRequests(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
+
+	resp, err := c.splitRequestUC.ListSplitRequests(ctx, &inputport.ListSplitRequestsRequest{
+		RequesterID: userID.(uuid.UUID),
+		Offset:      offset,
+		Limit:       limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentSplitRequestList(resp))
+}
+
+// GetSplitRequest は割り勘の支払い状況（誰が支払ったか）を取得
+// GET /api/split-requests/:id
+func (c *SplitRequestController) GetSplitRequest(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	splitID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	resp, err := c.splitRequestUC.GetSplitRequest(ctx, &inputport.GetSplitRequestRequest{
+		SplitRequestID: splitID,
+		UserID:         userID.(uuid.UUID),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"split_request": c.presenter.PresentSplitRequest(resp)})
+}
+
+// CancelSplitRequest は割り勘をキャンセル
+// DELETE /api/split-requests/:id
+func (c *SplitRequestController) CancelSplitRequest(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	splitID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	resp, err := c.splitRequestUC.CancelSplitRequest(ctx, &inputport.CancelSplitRequestRequest{
+		SplitRequestID: splitID,
+		UserID:         userID.(uuid.UUID),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"split_request": c.presenter.PresentSplitRequest(resp)})
+}
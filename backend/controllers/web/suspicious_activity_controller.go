@@ -0,0 +1,168 @@
+package web
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// SuspiciousActivityController は不審な送金の確認のコントローラー
+type SuspiciousActivityController struct {
+	activityUC inputport.SuspiciousActivityInputPort
+	presenter  *presenter.SuspiciousActivityPresenter
+}
+
+// NewSuspiciousActivityController は新しいSuspiciousActivityControllerを作成
+func NewSuspiciousActivityController(
+	activityUC inputport.SuspiciousActivityInputPort,
+	presenter *presenter.SuspiciousActivityPresenter,
+) *SuspiciousActivityController {
+	return &SuspiciousActivityController{
+		activityUC: activityUC,
+		presenter:  presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *SuspiciousActivityController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/suspicious-activities", c.ListActivities)
+	routes.Admin.GET("/suspicious-activities/hold", c.GetHold)
+	routes.Admin.PUT("/suspicious-activities/hold", c.UpdateHold)
+	routes.Admin.POST("/suspicious-activities/:id/dismiss", c.DismissActivity)
+	routes.Admin.POST("/suspicious-activities/:id/confirm", c.ConfirmActivity)
+}
+
+// GetHold は不審な送金を保留するかを取得
+// GET /api/admin/suspicious-activities/hold
+func (c *SuspiciousActivityController) GetHold(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	enabled, err := c.activityUC.GetHoldEnabled(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentHoldEnabled(enabled))
+}
+
+// UpdateHold は不審な送金を保留するかを設定
+// PUT /api/admin/suspicious-activities/hold
+func (c *SuspiciousActivityController) UpdateHold(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		Enabled *bool `json:"enabled" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	enabled, err := c.activityUC.UpdateHoldEnabled(ctx, &inputport.UpdateTransferHoldRequest{
+		AdminID: adminID.(uuid.UUID),
+		Enabled: *req.Enabled,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentHoldEnabled(enabled))
+}
+
+// ListActivities は不審な送金の記録を新しい順に取得（statusで絞り込み、既定は全状態）
+// GET /api/admin/suspicious-activities
+func (c *SuspiciousActivityController) ListActivities(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 50)
+	if !ok {
+		return
+	}
+
+	status := entities.SuspiciousActivityStatus(ctx.Query("status"))
+	if status != "" && !status.IsValid() {
+		respondError(ctx, http.StatusBadRequest, invalidParam("status", "must be one of the allowed values"))
+		return
+	}
+
+	resp, err := c.activityUC.ListActivities(ctx, &inputport.ListSuspiciousActivitiesRequest{
+		AdminID: adminID.(uuid.UUID),
+		Status:  status,
+		Offset:  offset,
+		Limit:   limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentList(resp))
+}
+
+// DismissActivity は問題なしと判断する（保留中の送金は実行する）
+// POST /api/admin/suspicious-activities/:id/dismiss
+func (c *SuspiciousActivityController) DismissActivity(ctx *gin.Context) {
+	c.review(ctx, c.activityUC.DismissActivity)
+}
+
+// ConfirmActivity は不正と判断する（保留中の送金は取り消す）
+// POST /api/admin/suspicious-activities/:id/confirm
+func (c *SuspiciousActivityController) ConfirmActivity(ctx *gin.Context) {
+	c.review(ctx, c.activityUC.ConfirmActivity)
+}
+
+func (c *SuspiciousActivityController) review(ctx *gin.Context, review func(ctx context.Context, req *inputport.ReviewSuspiciousActivityRequest) (*entities.SuspiciousActivity, error)) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	activityID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	// コメントは任意
+	var req struct {
+		Comment string `json:"comment"`
+	}
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	activity, err := review(ctx, &inputport.ReviewSuspiciousActivityRequest{
+		AdminID:    adminID.(uuid.UUID),
+		ActivityID: activityID,
+		Comment:    req.Comment,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentActivity(activity))
+}
@@ -0,0 +1,51 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// SystemConfigController はサーバー設定のコントローラー
+type SystemConfigController struct {
+	systemConfigUC inputport.SystemConfigInputPort
+	presenter      *presenter.SystemConfigPresenter
+}
+
+// NewSystemConfigController は新しいSystemConfigControllerを作成
+func NewSystemConfigController(
+	systemConfigUC inputport.SystemConfigInputPort,
+	presenter *presenter.SystemConfigPresenter,
+) *SystemConfigController {
+	return &SystemConfigController{
+		systemConfigUC: systemConfigUC,
+		presenter:      presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *SystemConfigController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/config", c.GetSettings)
+}
+
+// GetSettings は起動時に読み込んだ設定を取得（秘密の値は伏せる）
+// GET /api/admin/config
+func (c *SystemConfigController) GetSettings(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	settings, err := c.systemConfigUC.GetSettings(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentSettings(settings))
+}
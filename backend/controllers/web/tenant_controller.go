@@ -0,0 +1,140 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// TenantController はテナント管理のコントローラー（スーパー管理者用）
+type TenantController struct {
+	tenantUC  inputport.TenantInputPort
+	presenter *presenter.TenantPresenter
+}
+
+// NewTenantController は新しいTenantControllerを作成
+func NewTenantController(
+	tenantUC inputport.TenantInputPort,
+	presenter *presenter.TenantPresenter,
+) *TenantController {
+	return &TenantController{
+		tenantUC:  tenantUC,
+		presenter: presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+// スーパー管理者かどうかはユースケースで確認する（管理者のグループには入れない）
+func (c *TenantController) RegisterRoutes(routes *RouteGroups) {
+	routes.Protected.GET("/super-admin/tenants", c.ListTenants)
+	routes.Protected.POST("/super-admin/tenants", c.CreateTenant)
+	routes.Protected.PUT("/super-admin/tenants/:id", c.UpdateTenant)
+}
+
+// ListTenants はテナントの一覧を取得
+// GET /api/super-admin/tenants?offset=0&limit=50
+func (c *TenantController) ListTenants(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 50)
+	if !ok {
+		return
+	}
+
+	resp, err := c.tenantUC.ListTenants(ctx, &inputport.ListTenantsRequest{
+		SuperAdminID: userID.(uuid.UUID),
+		Offset:       offset,
+		Limit:        limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentTenantList(resp))
+}
+
+// CreateTenant はテナントと最初の管理者を作成
+// POST /api/super-admin/tenants
+func (c *TenantController) CreateTenant(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		Slug           string `json:"slug" binding:"required"`
+		Name           string `json:"name" binding:"required"`
+		AdminUsername  string `json:"admin_username" binding:"required,min=3,max=255"`
+		AdminEmail     string `json:"admin_email" binding:"required,email"`
+		AdminFirstName string `json:"admin_first_name" binding:"required,max=100"`
+		AdminLastName  string `json:"admin_last_name" binding:"required,max=100"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := c.tenantUC.CreateTenant(ctx, &inputport.CreateTenantRequest{
+		SuperAdminID:   userID.(uuid.UUID),
+		Slug:           req.Slug,
+		Name:           req.Name,
+		AdminUsername:  req.AdminUsername,
+		AdminEmail:     req.AdminEmail,
+		AdminFirstName: req.AdminFirstName,
+		AdminLastName:  req.AdminLastName,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.presenter.PresentCreateTenant(resp))
+}
+
+// UpdateTenant はテナント名と有効・無効を変更
+// PUT /api/super-admin/tenants/:id
+func (c *TenantController) UpdateTenant(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	tenantID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	var req struct {
+		Name     string `json:"name" binding:"required"`
+		IsActive *bool  `json:"is_active" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	tenant, err := c.tenantUC.UpdateTenant(ctx, &inputport.UpdateTenantRequest{
+		SuperAdminID: userID.(uuid.UUID),
+		TenantID:     tenantID,
+		Name:         req.Name,
+		IsActive:     *req.IsActive,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"tenant": c.presenter.PresentTenant(tenant)})
+}
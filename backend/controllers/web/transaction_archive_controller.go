@@ -0,0 +1,108 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// TransactionArchiveController は保管済みの取引（保持期間を過ぎて移した取引）のコントローラー
+type TransactionArchiveController struct {
+	archiveUC inputport.TransactionArchiveInputPort
+	presenter *presenter.TransactionArchivePresenter
+}
+
+// NewTransactionArchiveController は新しいTransactionArchiveControllerを作成
+func NewTransactionArchiveController(
+	archiveUC inputport.TransactionArchiveInputPort,
+	presenter *presenter.TransactionArchivePresenter,
+) *TransactionArchiveController {
+	return &TransactionArchiveController{
+		archiveUC: archiveUC,
+		presenter: presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *TransactionArchiveController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/archive/transactions", c.ListArchivedTransactions)
+	routes.Admin.GET("/archive/summaries", c.GetArchiveSummaries)
+}
+
+// ListArchivedTransactions は保管済みの取引を検索する（期間は366日以内で必須）
+// GET /api/admin/archive/transactions?date_from=2024-01-01&date_to=2024-03-31&user_id=...
+func (c *TransactionArchiveController) ListArchivedTransactions(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	offset, limit, ok := bindPage(ctx, 0)
+	if !ok {
+		return
+	}
+
+	req := &inputport.ListArchivedTransactionsRequest{
+		AdminID: adminID.(uuid.UUID),
+		Offset:  offset,
+		Limit:   limit,
+	}
+	if v := ctx.Query("user_id"); v != "" {
+		userID, err := uuid.Parse(v)
+		if err != nil {
+			respondError(ctx, http.StatusBadRequest, invalidParam("user_id", "must be a valid ID"))
+			return
+		}
+		req.Filter.UserID = &userID
+	}
+
+	// date_to はその日を含むため翌日0時を終端にする
+	from, err := time.ParseInLocation("2006-01-02", ctx.Query("date_from"), time.Local)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("date_from", "must be a date in YYYY-MM-DD format"))
+		return
+	}
+	to, err := time.ParseInLocation("2006-01-02", ctx.Query("date_to"), time.Local)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("date_to", "must be a date in YYYY-MM-DD format"))
+		return
+	}
+	req.Filter.From = from
+	req.Filter.To = to.AddDate(0, 0, 1)
+
+	resp, err := c.archiveUC.ListArchivedTransactions(ctx, req)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentListArchivedTransactions(resp))
+}
+
+// GetArchiveSummaries は保管済みの取引の月・種別・状態ごとの集計を取得
+// GET /api/admin/archive/summaries?month_from=2024-01&month_to=2024-12
+func (c *TransactionArchiveController) GetArchiveSummaries(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	resp, err := c.archiveUC.GetArchiveSummaries(ctx, &inputport.GetArchiveSummariesRequest{
+		AdminID:   adminID.(uuid.UUID),
+		FromMonth: ctx.Query("month_from"),
+		ToMonth:   ctx.Query("month_to"),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentArchiveSummaries(resp))
+}
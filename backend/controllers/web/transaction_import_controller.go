@@ -0,0 +1,76 @@
+package web
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// TransactionImportController は過去の取引の取り込みのコントローラー
+type TransactionImportController struct {
+	transactionImportUC inputport.TransactionImportInputPort
+	presenter           *presenter.TransactionImportPresenter
+}
+
+// NewTransactionImportController は新しいTransactionImportControllerを作成
+func NewTransactionImportController(
+	transactionImportUC inputport.TransactionImportInputPort,
+	presenter *presenter.TransactionImportPresenter,
+) *TransactionImportController {
+	return &TransactionImportController{
+		transactionImportUC: transactionImportUC,
+		presenter:           presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *TransactionImportController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.POST("/transactions/import", c.ImportTransactions)
+}
+
+// ImportTransactions はCSVファイルから過去の取引を取り込む
+// POST /api/admin/transactions/import (multipart/form-data: file, dry_run)
+func (c *TransactionImportController) ImportTransactions(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	file, _, err := ctx.Request.FormFile("file")
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("file", "is required"))
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, entities.TransactionImportMaxBytes+1))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(data) > entities.TransactionImportMaxBytes {
+		respondError(ctx, http.StatusBadRequest, entities.ErrInvalidTransactionImportFile)
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(ctx.PostForm("dry_run"))
+
+	resp, err := c.transactionImportUC.ImportTransactions(ctx, &inputport.ImportTransactionsRequest{
+		AdminID: adminID.(uuid.UUID),
+		CSVData: data,
+		DryRun:  dryRun,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentImportTransactionsResponse(resp, ctx.GetHeader("Accept-Language")))
+}
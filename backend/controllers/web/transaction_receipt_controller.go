@@ -0,0 +1,97 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// TransactionReceiptController は取引の控えのコントローラー
+type TransactionReceiptController struct {
+	receiptUC inputport.TransactionReceiptInputPort
+	presenter *presenter.TransactionReceiptPresenter
+}
+
+// NewTransactionReceiptController は新しいTransactionReceiptControllerを作成
+func NewTransactionReceiptController(
+	receiptUC inputport.TransactionReceiptInputPort,
+	presenter *presenter.TransactionReceiptPresenter,
+) *TransactionReceiptController {
+	return &TransactionReceiptController{
+		receiptUC: receiptUC,
+		presenter: presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *TransactionReceiptController) RegisterRoutes(routes *RouteGroups) {
+	routes.Protected.GET("/points/transactions/:id/receipt", routes.Authorize(entities.ResourceTransaction, entities.ActionView), c.GetReceipt)
+	routes.Admin.POST("/receipts/verify", c.VerifyReceipt)
+}
+
+// GetReceipt は取引の控えを発行
+// GET /api/points/transactions/:id/receipt
+func (c *TransactionReceiptController) GetReceipt(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	transactionID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	receipt, err := c.receiptUC.GetReceipt(ctx, &inputport.GetTransactionReceiptRequest{
+		UserID:        userID.(uuid.UUID),
+		TransactionID: transactionID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentReceipt(receipt))
+}
+
+// VerifyReceipt は提示された取引の控えの検証ハッシュを確認
+// POST /api/admin/receipts/verify
+func (c *TransactionReceiptController) VerifyReceipt(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		TransactionID string `json:"transaction_id" binding:"required"`
+		Hash          string `json:"hash" binding:"required"` // 控えに印刷された検証ハッシュ
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	transactionID, err := uuid.Parse(req.TransactionID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("transaction_id", "must be a valid ID"))
+		return
+	}
+
+	resp, err := c.receiptUC.VerifyReceipt(ctx, &inputport.VerifyTransactionReceiptRequest{
+		AdminID:       adminID.(uuid.UUID),
+		TransactionID: transactionID,
+		Hash:          req.Hash,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentVerification(resp))
+}
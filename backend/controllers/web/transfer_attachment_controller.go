@@ -0,0 +1,140 @@
+package web
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// TransferAttachmentController は送金・送金リクエストの添付画像のコントローラー
+type TransferAttachmentController struct {
+	attachmentUC inputport.TransferAttachmentInputPort
+	presenter    *presenter.TransferAttachmentPresenter
+}
+
+// NewTransferAttachmentController は新しいTransferAttachmentControllerを作成
+func NewTransferAttachmentController(
+	attachmentUC inputport.TransferAttachmentInputPort,
+	presenter *presenter.TransferAttachmentPresenter,
+) *TransferAttachmentController {
+	return &TransferAttachmentController{
+		attachmentUC: attachmentUC,
+		presenter:    presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録（添付・取得とも当事者のみ）
+func (c *TransferAttachmentController) RegisterRoutes(routes *RouteGroups) {
+	transaction := routes.Authorize(entities.ResourceTransaction, entities.ActionView)
+	routes.Protected.POST("/points/transactions/:id/attachment", transaction, c.AttachToTransaction)
+	routes.Protected.GET("/points/transactions/:id/attachment", transaction, c.GetTransactionAttachment)
+
+	request := routes.Authorize(entities.ResourceTransferRequest, entities.ActionView)
+	routes.Protected.POST("/transfer-requests/:id/attachment", request, c.AttachToTransferRequest)
+	routes.Protected.GET("/transfer-requests/:id/attachment", request, c.GetTransferRequestAttachment)
+}
+
+// AttachToTransaction は完了した送金に画像を添付
+// POST /api/points/transactions/:id/attachment
+func (c *TransferAttachmentController) AttachToTransaction(ctx *gin.Context) {
+	c.attach(ctx, c.attachmentUC.AttachToTransaction)
+}
+
+// AttachToTransferRequest は承認待ちの送金リクエストに画像を添付
+// POST /api/transfer-requests/:id/attachment
+func (c *TransferAttachmentController) AttachToTransferRequest(ctx *gin.Context) {
+	c.attach(ctx, c.attachmentUC.AttachToTransferRequest)
+}
+
+// GetTransactionAttachment は送金の添付画像を返す
+// GET /api/points/transactions/:id/attachment
+func (c *TransferAttachmentController) GetTransactionAttachment(ctx *gin.Context) {
+	c.serve(ctx, c.attachmentUC.GetTransactionAttachment)
+}
+
+// GetTransferRequestAttachment は送金リクエストの添付画像を返す
+// GET /api/transfer-requests/:id/attachment
+func (c *TransferAttachmentController) GetTransferRequestAttachment(ctx *gin.Context) {
+	c.serve(ctx, c.attachmentUC.GetTransferRequestAttachment)
+}
+
+// attach はmultipartのimageを読み込んで添付する
+func (c *TransferAttachmentController) attach(ctx *gin.Context, attach func(ctx context.Context, req *inputport.AttachTransferImageRequest) (*entities.TransferAttachment, error)) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	targetID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	file, header, err := ctx.Request.FormFile("image")
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("image", "is required"))
+		return
+	}
+	defer file.Close()
+
+	fileData, err := io.ReadAll(file)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	attachment, err := attach(ctx, &inputport.AttachTransferImageRequest{
+		UserID:   userID.(uuid.UUID),
+		TargetID: targetID,
+		FileData: fileData,
+		FileName: header.Filename,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.presenter.PresentAttachment(attachment))
+}
+
+// serve は添付画像をそのまま返す（当事者以外に残らないようキャッシュさせない）
+func (c *TransferAttachmentController) serve(ctx *gin.Context, get func(ctx context.Context, req *inputport.GetTransferAttachmentRequest) (*inputport.GetTransferAttachmentResponse, error)) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	targetID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	resp, err := get(ctx, &inputport.GetTransferAttachmentRequest{
+		UserID:   userID.(uuid.UUID),
+		TargetID: targetID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer resp.Content.Close()
+
+	contentType := mime.TypeByExtension(filepath.Ext(resp.Attachment.FilePath))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	ctx.Header("Cache-Control", "private, no-store")
+	ctx.DataFromReader(http.StatusOK, resp.Attachment.SizeBytes, contentType, resp.Content, nil)
+}
@@ -0,0 +1,85 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// TransferEligibilityController は送金できるユーザーの条件のコントローラー
+type TransferEligibilityController struct {
+	eligibilityUC inputport.TransferEligibilityInputPort
+	presenter     *presenter.TransferEligibilityPresenter
+}
+
+// NewTransferEligibilityController は新しいTransferEligibilityControllerを作成
+func NewTransferEligibilityController(
+	eligibilityUC inputport.TransferEligibilityInputPort,
+	presenter *presenter.TransferEligibilityPresenter,
+) *TransferEligibilityController {
+	return &TransferEligibilityController{
+		eligibilityUC: eligibilityUC,
+		presenter:     presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *TransferEligibilityController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/transfer-eligibility", c.GetPolicy)
+	routes.Admin.PUT("/transfer-eligibility", c.UpdatePolicy)
+}
+
+// GetPolicy は送金できるユーザーの条件を取得
+// GET /api/admin/transfer-eligibility
+func (c *TransferEligibilityController) GetPolicy(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	policy, err := c.eligibilityUC.GetPolicy(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentPolicy(policy))
+}
+
+// UpdatePolicy は送金できるユーザーの条件を設定
+// PUT /api/admin/transfer-eligibility
+func (c *TransferEligibilityController) UpdatePolicy(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		RequireEmailVerification   bool   `json:"require_email_verification"`
+		MinAccountAgeHours         int    `json:"min_account_age_hours"`
+		DefaultAcceptTransfersFrom string `json:"default_accept_transfers_from"` // anyone / friends_only（省略でanyone）
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	policy, err := c.eligibilityUC.UpdatePolicy(ctx, &inputport.UpdateTransferEligibilityRequest{
+		AdminID:                  adminID.(uuid.UUID),
+		RequireEmailVerification: req.RequireEmailVerification,
+		MinAccountAgeHours:       req.MinAccountAgeHours,
+		DefaultAcceptance:        entities.TransferAcceptance(req.DefaultAcceptTransfersFrom),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentPolicy(policy))
+}
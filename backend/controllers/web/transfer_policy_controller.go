@@ -0,0 +1,101 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// TransferPolicyController は送金額の上下限と手数料のコントローラー
+type TransferPolicyController struct {
+	policyUC  inputport.TransferPolicyInputPort
+	presenter *presenter.TransferPolicyPresenter
+}
+
+// NewTransferPolicyController は新しいTransferPolicyControllerを作成
+func NewTransferPolicyController(
+	policyUC inputport.TransferPolicyInputPort,
+	presenter *presenter.TransferPolicyPresenter,
+) *TransferPolicyController {
+	return &TransferPolicyController{
+		policyUC:  policyUC,
+		presenter: presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *TransferPolicyController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/transfer-policy", c.GetPolicy)
+	routes.Admin.PUT("/transfer-policy", c.UpdatePolicy)
+}
+
+// GetPolicy は送金額の上下限と手数料を取得
+// GET /api/admin/transfer-policy
+func (c *TransferPolicyController) GetPolicy(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	policy, err := c.policyUC.GetPolicy(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentPolicy(policy))
+}
+
+// UpdatePolicy は送金額の上下限と手数料を設定
+// PUT /api/admin/transfer-policy
+func (c *TransferPolicyController) UpdatePolicy(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req struct {
+		MinAmount          int64  `json:"min_amount"`
+		MaxAmount          int64  `json:"max_amount"`
+		FeeType            string `json:"fee_type"`
+		FeeFlat            int64  `json:"fee_flat"`
+		FeeRateBasisPoints int64  `json:"fee_rate_basis_points"`
+		FeeAccountID       string `json:"fee_account_id"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var feeAccountID *uuid.UUID
+	if req.FeeAccountID != "" {
+		id, err := uuid.Parse(req.FeeAccountID)
+		if err != nil {
+			respondError(ctx, http.StatusBadRequest, invalidParam("fee_account_id", "must be a valid ID"))
+			return
+		}
+		feeAccountID = &id
+	}
+
+	policy, err := c.policyUC.UpdatePolicy(ctx, &inputport.UpdateTransferPolicyRequest{
+		AdminID:            adminID.(uuid.UUID),
+		MinAmount:          req.MinAmount,
+		MaxAmount:          req.MaxAmount,
+		FeeType:            entities.TransferFeeType(req.FeeType),
+		FeeFlat:            req.FeeFlat,
+		FeeRateBasisPoints: req.FeeRateBasisPoints,
+		FeeAccountID:       feeAccountID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentPolicy(policy))
+}
@@ -2,10 +2,10 @@ package web
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/google/uuid"
 )
@@ -13,45 +13,64 @@ import (
 // TransferRequestController は送金リクエスト機能のコントローラー
 type TransferRequestController struct {
 	transferRequestUC inputport.TransferRequestInputPort
-	userQueryUC       inputport.UserQueryInputPort
+	qrCodeUC          inputport.QRCodeInputPort
 	presenter         *presenter.TransferRequestPresenter
 }
 
 // NewTransferRequestController は新しいTransferRequestControllerを作成
 func NewTransferRequestController(
 	transferRequestUC inputport.TransferRequestInputPort,
-	userQueryUC inputport.UserQueryInputPort,
+	qrCodeUC inputport.QRCodeInputPort,
 	presenter *presenter.TransferRequestPresenter,
 ) *TransferRequestController {
 	return &TransferRequestController{
 		transferRequestUC: transferRequestUC,
-		userQueryUC:       userQueryUC,
+		qrCodeUC:          qrCodeUC,
 		presenter:         presenter,
 	}
 }
 
+// RegisterRoutes はルートを登録
+func (c *TransferRequestController) RegisterRoutes(routes *RouteGroups) {
+	requests := routes.Protected.Group("/transfer-requests")
+	requests.GET("/personal-qr", c.GetPersonalQRCode)
+	requests.POST("", c.CreateTransferRequest)
+	requests.GET("/pending", c.GetPendingRequests)
+	requests.GET("/sent", c.GetSentRequests)
+	requests.GET("/pending/count", c.GetPendingRequestCount)
+	requests.GET("/mutes", c.GetMutedSenders)
+	requests.PUT("/mutes/:user_id", c.MuteSender)
+	requests.DELETE("/mutes/:user_id", c.UnmuteSender)
+	requests.GET("/:id", routes.Authorize(entities.ResourceTransferRequest, entities.ActionView), c.GetRequestDetail)
+	requests.POST("/:id/approve", routes.Authorize(entities.ResourceTransferRequest, entities.ActionApprove), c.ApproveTransferRequest)
+	requests.POST("/:id/reject", routes.Authorize(entities.ResourceTransferRequest, entities.ActionReject), c.RejectTransferRequest)
+	requests.POST("/:id/counter", routes.Authorize(entities.ResourceTransferRequest, entities.ActionCounter), c.CounterTransferRequest)
+	requests.POST("/:id/confirm", routes.Authorize(entities.ResourceTransferRequest, entities.ActionConfirm), c.ConfirmCounterOffer)
+	requests.DELETE("/:id", routes.Authorize(entities.ResourceTransferRequest, entities.ActionCancel), c.CancelTransferRequest)
+}
+
 // GetPersonalQRCode は個人固定QRコードを取得
 // GET /api/transfer-requests/personal-qr
 func (c *TransferRequestController) GetPersonalQRCode(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
-	// ユーザー情報取得
-	resp, err := c.userQueryUC.GetUserByID(ctx.Request.Context(), &inputport.GetUserByIDRequest{
+	// 個人QRコード取得
+	resp, err := c.qrCodeUC.GetPersonalQR(ctx.Request.Context(), &inputport.GetPersonalQRRequest{
 		UserID: userID.(uuid.UUID),
 	})
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		respondError(ctx, http.StatusNotFound, entities.ErrUserNotFound)
 		return
 	}
 
 	// レスポンス
 	ctx.JSON(http.StatusOK, gin.H{
-		"qr_code":      resp.User.PersonalQRCode,
+		"qr_code":      resp.QRCodeData,
 		"display_name": resp.User.DisplayName,
 		"username":     resp.User.Username,
 	})
@@ -63,26 +82,27 @@ func (c *TransferRequestController) CreateTransferRequest(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// リクエストボディ解析
 	var req struct {
-		ToUserID       string `json:"to_user_id" binding:"required"`
-		Amount         int64  `json:"amount" binding:"required,gt=0"`
-		Message        string `json:"message"`
+		ToUserID       string `json:"to_user_id" binding:"required,uuid"`
+		Amount         int64  `json:"amount" binding:"required,points"`
+		Message        string `json:"message" binding:"message"`
 		IdempotencyKey string `json:"idempotency_key" binding:"required"`
+		Hold           bool   `json:"hold"` // 承認まで送信者の残高から金額を確保する
 	}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	// UUID変換
 	toUserID, err := uuid.Parse(req.ToUserID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid to_user_id"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("to_user_id", "must be a valid ID"))
 		return
 	}
 
@@ -93,9 +113,10 @@ func (c *TransferRequestController) CreateTransferRequest(ctx *gin.Context) {
 		Amount:         req.Amount,
 		Message:        req.Message,
 		IdempotencyKey: req.IdempotencyKey,
+		Hold:           req.Hold,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -109,14 +130,14 @@ func (c *TransferRequestController) ApproveTransferRequest(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// パスパラメータ取得
 	requestID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request_id"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
 		return
 	}
 
@@ -126,7 +147,7 @@ func (c *TransferRequestController) ApproveTransferRequest(ctx *gin.Context) {
 		UserID:    userID.(uuid.UUID),
 	})
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -140,14 +161,14 @@ func (c *TransferRequestController) RejectTransferRequest(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// パスパラメータ取得
 	requestID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request_id"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
 		return
 	}
 
@@ -157,7 +178,7 @@ func (c *TransferRequestController) RejectTransferRequest(ctx *gin.Context) {
 		UserID:    userID.(uuid.UUID),
 	})
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -165,20 +186,92 @@ func (c *TransferRequestController) RejectTransferRequest(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, c.presenter.PresentRejectTransferRequest(resp))
 }
 
+// CounterTransferRequest は金額を変更して送信者に差し戻す
+// POST /api/transfer-requests/:id/counter
+func (c *TransferRequestController) CounterTransferRequest(ctx *gin.Context) {
+	// ログインユーザー取得
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	// パスパラメータ取得
+	requestID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	// リクエストボディ解析
+	var req struct {
+		Amount int64 `json:"amount" binding:"required,points"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// ユースケース実行
+	resp, err := c.transferRequestUC.CounterTransferRequest(ctx, &inputport.CounterTransferRequestRequest{
+		RequestID: requestID,
+		UserID:    userID.(uuid.UUID),
+		Amount:    req.Amount,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// レスポンス生成
+	ctx.JSON(http.StatusOK, c.presenter.PresentCounterTransferRequest(resp))
+}
+
+// ConfirmCounterOffer はカウンターオファーを確定して送金する
+// POST /api/transfer-requests/:id/confirm
+func (c *TransferRequestController) ConfirmCounterOffer(ctx *gin.Context) {
+	// ログインユーザー取得
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	// パスパラメータ取得
+	requestID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	// ユースケース実行
+	resp, err := c.transferRequestUC.ConfirmCounterOffer(ctx, &inputport.ConfirmCounterOfferRequest{
+		RequestID: requestID,
+		UserID:    userID.(uuid.UUID),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// レスポンス生成
+	ctx.JSON(http.StatusOK, c.presenter.PresentConfirmCounterOffer(resp))
+}
+
 // CancelTransferRequest は送金リクエストをキャンセル
 // DELETE /api/transfer-requests/:id
 func (c *TransferRequestController) CancelTransferRequest(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// パスパラメータ取得
 	requestID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request_id"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
 		return
 	}
 
@@ -188,7 +281,7 @@ func (c *TransferRequestController) CancelTransferRequest(ctx *gin.Context) {
 		UserID:    userID.(uuid.UUID),
 	})
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -202,13 +295,15 @@ func (c *TransferRequestController) GetPendingRequests(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// クエリパラメータ
-	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
-	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
 
 	// ユースケース実行
 	resp, err := c.transferRequestUC.GetPendingRequests(ctx, &inputport.GetPendingTransferRequestsRequest{
@@ -217,7 +312,7 @@ func (c *TransferRequestController) GetPendingRequests(ctx *gin.Context) {
 		Limit:    limit,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -231,13 +326,15 @@ func (c *TransferRequestController) GetSentRequests(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// クエリパラメータ
-	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
-	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	offset, limit, ok := bindPage(ctx, 20)
+	if !ok {
+		return
+	}
 
 	// ユースケース実行
 	resp, err := c.transferRequestUC.GetSentRequests(ctx, &inputport.GetSentTransferRequestsRequest{
@@ -246,7 +343,7 @@ func (c *TransferRequestController) GetSentRequests(ctx *gin.Context) {
 		Limit:      limit,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -260,14 +357,14 @@ func (c *TransferRequestController) GetRequestDetail(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	// パスパラメータ取得
 	requestID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request_id"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
 		return
 	}
 
@@ -277,7 +374,7 @@ func (c *TransferRequestController) GetRequestDetail(ctx *gin.Context) {
 		UserID:    userID.(uuid.UUID),
 	})
 	if err != nil {
-		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusForbidden, err)
 		return
 	}
 
@@ -291,7 +388,7 @@ func (c *TransferRequestController) GetPendingRequestCount(ctx *gin.Context) {
 	// ログインユーザー取得
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
@@ -300,7 +397,7 @@ func (c *TransferRequestController) GetPendingRequestCount(ctx *gin.Context) {
 		ToUserID: userID.(uuid.UUID),
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -309,3 +406,101 @@ func (c *TransferRequestController) GetPendingRequestCount(ctx *gin.Context) {
 		"count": resp.Count,
 	})
 }
+
+// MuteSenderRequest は送信者のミュートリクエスト
+type MuteSenderRequest struct {
+	Mode string `json:"mode"` // reject（既定）/ drop
+}
+
+// MuteSender は送信者をミュート（既にミュートしていればmodeだけ変える）
+// PUT /api/transfer-requests/mutes/:user_id
+func (c *TransferRequestController) MuteSender(ctx *gin.Context) {
+	// ログインユーザー取得
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	// パスパラメータ取得
+	mutedUserID, err := uuid.Parse(ctx.Param("user_id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("user_id", "must be a valid ID"))
+		return
+	}
+
+	// ボディは省略できる（省略すればreject）
+	var req MuteSenderRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+	mode := entities.MuteMode(req.Mode)
+	if mode == "" {
+		mode = entities.MuteModeReject
+	}
+
+	// ユースケース実行
+	mute, err := c.transferRequestUC.MuteSender(ctx, &inputport.MuteSenderRequest{
+		UserID:      userID.(uuid.UUID),
+		MutedUserID: mutedUserID,
+		Mode:        mode,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	// レスポンス生成
+	ctx.JSON(http.StatusOK, c.presenter.PresentMutedSender(mute))
+}
+
+// UnmuteSender は送信者のミュートを解除
+// DELETE /api/transfer-requests/mutes/:user_id
+func (c *TransferRequestController) UnmuteSender(ctx *gin.Context) {
+	// ログインユーザー取得
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	// パスパラメータ取得
+	mutedUserID, err := uuid.Parse(ctx.Param("user_id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("user_id", "must be a valid ID"))
+		return
+	}
+
+	// ユースケース実行
+	if err := c.transferRequestUC.UnmuteSender(ctx, userID.(uuid.UUID), mutedUserID); err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	// レスポンス生成
+	ctx.JSON(http.StatusOK, gin.H{"muted_user_id": mutedUserID, "muted": false})
+}
+
+// GetMutedSenders はミュートした送信者一覧を取得
+// GET /api/transfer-requests/mutes
+func (c *TransferRequestController) GetMutedSenders(ctx *gin.Context) {
+	// ログインユーザー取得
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	// ユースケース実行
+	mutes, err := c.transferRequestUC.GetMutedSenders(ctx, userID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	// レスポンス生成
+	ctx.JSON(http.StatusOK, c.presenter.PresentMutedSenders(mutes))
+}
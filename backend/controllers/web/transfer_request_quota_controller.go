@@ -0,0 +1,84 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// TransferRequestQuotaController は承認待ちの送金リクエストの上限のコントローラー
+type TransferRequestQuotaController struct {
+	quotaUC   inputport.TransferRequestQuotaInputPort
+	presenter *presenter.TransferRequestQuotaPresenter
+}
+
+// NewTransferRequestQuotaController は新しいTransferRequestQuotaControllerを作成
+func NewTransferRequestQuotaController(
+	quotaUC inputport.TransferRequestQuotaInputPort,
+	presenter *presenter.TransferRequestQuotaPresenter,
+) *TransferRequestQuotaController {
+	return &TransferRequestQuotaController{
+		quotaUC:   quotaUC,
+		presenter: presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *TransferRequestQuotaController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/transfer-request-quota", c.GetQuota)
+	routes.Admin.PUT("/transfer-request-quota", c.UpdateQuota)
+}
+
+// GetQuota は承認待ちの送金リクエストの上限を取得
+// GET /api/admin/transfer-request-quota
+func (c *TransferRequestQuotaController) GetQuota(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	quota, err := c.quotaUC.GetQuota(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentQuota(quota))
+}
+
+// UpdateQuota は承認待ちの送金リクエストの上限を設定
+// PUT /api/admin/transfer-request-quota
+func (c *TransferRequestQuotaController) UpdateQuota(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	// 省略を「上限なし」と取り違えないよう、どちらも指定させる（0で無効）
+	var req struct {
+		MaxPendingPerSender    *int `json:"max_pending_per_sender" binding:"required"`
+		MaxPendingPerRecipient *int `json:"max_pending_per_recipient" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	quota, err := c.quotaUC.UpdateQuota(ctx, &inputport.UpdateTransferRequestQuotaRequest{
+		AdminID:                adminID.(uuid.UUID),
+		MaxPendingPerSender:    *req.MaxPendingPerSender,
+		MaxPendingPerRecipient: *req.MaxPendingPerRecipient,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentQuota(quota))
+}
@@ -0,0 +1,76 @@
+package web
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// UserImportController はユーザー一括登録のコントローラー
+type UserImportController struct {
+	userImportUC inputport.UserImportInputPort
+	presenter    *presenter.UserImportPresenter
+}
+
+// NewUserImportController は新しいUserImportControllerを作成
+func NewUserImportController(
+	userImportUC inputport.UserImportInputPort,
+	presenter *presenter.UserImportPresenter,
+) *UserImportController {
+	return &UserImportController{
+		userImportUC: userImportUC,
+		presenter:    presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *UserImportController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.POST("/users/import", c.ImportUsers)
+}
+
+// ImportUsers はCSVファイルからユーザーを一括登録
+// POST /api/admin/users/import (multipart/form-data: file, send_invitations)
+func (c *UserImportController) ImportUsers(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	file, _, err := ctx.Request.FormFile("file")
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("file", "is required"))
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, entities.UserImportMaxBytes+1))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(data) > entities.UserImportMaxBytes {
+		respondError(ctx, http.StatusBadRequest, entities.ErrInvalidUserImportFile)
+		return
+	}
+
+	sendInvitations, _ := strconv.ParseBool(ctx.PostForm("send_invitations"))
+
+	resp, err := c.userImportUC.ImportUsers(ctx, &inputport.ImportUsersRequest{
+		AdminID:         adminID.(uuid.UUID),
+		CSVData:         data,
+		SendInvitations: sendInvitations,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentImportUsersResponse(resp, ctx.GetHeader("Accept-Language")))
+}
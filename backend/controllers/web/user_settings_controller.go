@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/google/uuid"
 )
@@ -14,19 +15,42 @@ import (
 type UserSettingsController struct {
 	userSettingsUC inputport.UserSettingsInputPort
 	presenter      *presenter.UserSettingsPresenter
+	cookie         *presenter.SessionCookie
 }
 
 // NewUserSettingsController は新しいUserSettingsControllerを作成
 func NewUserSettingsController(
 	userSettingsUC inputport.UserSettingsInputPort,
 	presenter *presenter.UserSettingsPresenter,
+	cookie *presenter.SessionCookie,
 ) *UserSettingsController {
 	return &UserSettingsController{
 		userSettingsUC: userSettingsUC,
 		presenter:      presenter,
+		cookie:         cookie,
 	}
 }
 
+// RegisterRoutes はルートを登録
+func (c *UserSettingsController) RegisterRoutes(routes *RouteGroups) {
+	routes.Authenticated.GET("/settings/profile", c.GetProfile)
+	// 登録時の認証メールのリンクはログインせずに開けるようにする（認証が済むまでログインできない設定のため）
+	routes.Public.POST("/auth/email/verify", c.VerifyEmail)
+
+	settings := routes.Protected.Group("/settings")
+	settings.PUT("/profile", c.UpdateProfile)
+	settings.PUT("/username", c.UpdateUsername)
+	settings.PUT("/password", c.ChangePassword)
+	settings.POST("/avatar", c.UploadAvatar)
+	settings.DELETE("/avatar", c.DeleteAvatar)
+	settings.POST("/email/verify", c.SendEmailVerification)
+	settings.POST("/email/verify/confirm", c.VerifyEmail)
+	settings.PUT("/privacy", c.UpdatePrivacy)
+	settings.PUT("/timezone", c.UpdateTimezone)
+	settings.PUT("/transfer-acceptance", c.UpdateTransferAcceptance)
+	settings.DELETE("/account", c.ArchiveAccount)
+}
+
 // UpdateProfileRequest はプロフィール更新リクエスト
 type UpdateProfileRequest struct {
 	DisplayName string `json:"display_name" binding:"required,min=1,max=100"`
@@ -40,13 +64,13 @@ type UpdateProfileRequest struct {
 func (c *UserSettingsController) UpdateProfile(ctx *gin.Context) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	var req UpdateProfileRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -59,7 +83,7 @@ func (c *UserSettingsController) UpdateProfile(ctx *gin.Context) {
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -77,13 +101,13 @@ type UpdateUsernameRequest struct {
 func (c *UserSettingsController) UpdateUsername(ctx *gin.Context) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	var req UpdateUsernameRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -95,7 +119,7 @@ func (c *UserSettingsController) UpdateUsername(ctx *gin.Context) {
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -114,13 +138,13 @@ type ChangePasswordRequest struct {
 func (c *UserSettingsController) ChangePassword(ctx *gin.Context) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	var req ChangePasswordRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -135,7 +159,7 @@ func (c *UserSettingsController) ChangePassword(ctx *gin.Context) {
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -148,13 +172,13 @@ func (c *UserSettingsController) ChangePassword(ctx *gin.Context) {
 func (c *UserSettingsController) UploadAvatar(ctx *gin.Context) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	file, header, err := ctx.Request.FormFile("avatar")
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "no file uploaded"})
+		respondError(ctx, http.StatusBadRequest, invalidParam("file", "is required"))
 		return
 	}
 	defer file.Close()
@@ -162,7 +186,7 @@ func (c *UserSettingsController) UploadAvatar(ctx *gin.Context) {
 	// ファイルデータを読み込み
 	fileData, err := io.ReadAll(file)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read file"})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -177,7 +201,7 @@ func (c *UserSettingsController) UploadAvatar(ctx *gin.Context) {
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -190,7 +214,7 @@ func (c *UserSettingsController) UploadAvatar(ctx *gin.Context) {
 func (c *UserSettingsController) DeleteAvatar(ctx *gin.Context) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
@@ -199,7 +223,7 @@ func (c *UserSettingsController) DeleteAvatar(ctx *gin.Context) {
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -212,7 +236,7 @@ func (c *UserSettingsController) DeleteAvatar(ctx *gin.Context) {
 func (c *UserSettingsController) SendEmailVerification(ctx *gin.Context) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
@@ -221,7 +245,7 @@ func (c *UserSettingsController) SendEmailVerification(ctx *gin.Context) {
 		UserID: userID.(uuid.UUID),
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get user profile"})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -233,7 +257,7 @@ func (c *UserSettingsController) SendEmailVerification(ctx *gin.Context) {
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -248,10 +272,11 @@ type VerifyEmailRequest struct {
 
 // VerifyEmail はメールアドレスを認証
 // POST /api/settings/email/verify/confirm
+// POST /api/auth/email/verify
 func (c *UserSettingsController) VerifyEmail(ctx *gin.Context) {
 	var req VerifyEmailRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -260,7 +285,7 @@ func (c *UserSettingsController) VerifyEmail(ctx *gin.Context) {
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -279,13 +304,13 @@ type ArchiveAccountRequest struct {
 func (c *UserSettingsController) ArchiveAccount(ctx *gin.Context) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
 	var req ArchiveAccountRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -296,20 +321,12 @@ func (c *UserSettingsController) ArchiveAccount(ctx *gin.Context) {
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	// セッションをクリア
-	ctx.SetCookie(
-		"session_token",
-		"",
-		-1, // 即座に削除
-		"/",
-		"",
-		false,
-		true,
-	)
+	c.cookie.Clear(ctx)
 
 	output := c.presenter.PresentSuccessMessage("account deleted successfully")
 	ctx.JSON(http.StatusOK, output)
@@ -320,7 +337,7 @@ func (c *UserSettingsController) ArchiveAccount(ctx *gin.Context) {
 func (c *UserSettingsController) GetProfile(ctx *gin.Context) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
 		return
 	}
 
@@ -329,10 +346,109 @@ func (c *UserSettingsController) GetProfile(ctx *gin.Context) {
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	output := c.presenter.PresentGetProfileResponse(resp)
 	ctx.JSON(http.StatusOK, output)
 }
+
+// UpdatePrivacyRequest はプライバシー設定更新リクエスト
+type UpdatePrivacyRequest struct {
+	Discoverable *bool `json:"discoverable" binding:"required"`
+}
+
+// UpdatePrivacy はプライバシー設定（友達検索での公開可否）を更新
+// PUT /api/settings/privacy
+func (c *UserSettingsController) UpdatePrivacy(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req UpdatePrivacyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := c.userSettingsUC.UpdatePrivacy(ctx, &inputport.UpdatePrivacyRequest{
+		UserID:       userID.(uuid.UUID),
+		Discoverable: *req.Discoverable,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	output := c.presenter.PresentUpdatePrivacyResponse(resp)
+	ctx.JSON(http.StatusOK, output)
+}
+
+// UpdateTimezoneRequest はタイムゾーン更新リクエスト（空文字でシステム設定に戻す）
+type UpdateTimezoneRequest struct {
+	Timezone *string `json:"timezone" binding:"required"`
+}
+
+// UpdateTimezone はボーナス日の区切りに使うタイムゾーンを更新
+// PUT /api/settings/timezone
+func (c *UserSettingsController) UpdateTimezone(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req UpdateTimezoneRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := c.userSettingsUC.UpdateTimezone(ctx, &inputport.UpdateTimezoneRequest{
+		UserID:   userID.(uuid.UUID),
+		Timezone: *req.Timezone,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	output := c.presenter.PresentUpdateTimezoneResponse(resp)
+	ctx.JSON(http.StatusOK, output)
+}
+
+// UpdateTransferAcceptanceRequest はポイントを受け取る相手の設定の更新リクエスト（空文字でシステム設定に戻す）
+type UpdateTransferAcceptanceRequest struct {
+	AcceptTransfersFrom *string `json:"accept_transfers_from" binding:"required"`
+}
+
+// UpdateTransferAcceptance は誰からのポイントを受け取るか（anyone / friends_only）を更新
+// PUT /api/settings/transfer-acceptance
+func (c *UserSettingsController) UpdateTransferAcceptance(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	var req UpdateTransferAcceptanceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := c.userSettingsUC.UpdateTransferAcceptance(ctx, &inputport.UpdateTransferAcceptanceRequest{
+		UserID:              userID.(uuid.UUID),
+		AcceptTransfersFrom: entities.TransferAcceptance(*req.AcceptTransfersFrom),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	output := c.presenter.PresentUpdateTransferAcceptanceResponse(resp)
+	ctx.JSON(http.StatusOK, output)
+}
@@ -0,0 +1,97 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// UserTierController は会員ランクのコントローラー（管理者用）
+type UserTierController struct {
+	userTierUC inputport.UserTierInputPort
+	presenter  *presenter.UserTierPresenter
+}
+
+// NewUserTierController は新しいUserTierControllerを作成
+func NewUserTierController(
+	userTierUC inputport.UserTierInputPort,
+	presenter *presenter.UserTierPresenter,
+) *UserTierController {
+	return &UserTierController{
+		userTierUC: userTierUC,
+		presenter:  presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *UserTierController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.PUT("/users/:id/tier", c.OverrideTier)
+	routes.Admin.DELETE("/users/:id/tier", c.ClearTierOverride)
+}
+
+// OverrideTier はユーザーの会員ランクを固定する
+// PUT /api/admin/users/:id/tier
+func (c *UserTierController) OverrideTier(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	var req struct {
+		Tier string `json:"tier" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := c.userTierUC.OverrideTier(ctx, &inputport.OverrideTierRequest{
+		AdminID: adminID.(uuid.UUID),
+		UserID:  userID,
+		Tier:    entities.UserTier(req.Tier),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentUserTier(user))
+}
+
+// ClearTierOverride は会員ランクの固定を解除し、利用状況から判定し直す
+// DELETE /api/admin/users/:id/tier
+func (c *UserTierController) ClearTierOverride(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("id", "must be a valid ID"))
+		return
+	}
+
+	user, err := c.userTierUC.ClearTierOverride(ctx, &inputport.ClearTierOverrideRequest{
+		AdminID: adminID.(uuid.UUID),
+		UserID:  userID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentUserTier(user))
+}
@@ -0,0 +1,130 @@
+package web
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gity/point-system/entities"
+	"github.com/go-playground/validator/v10"
+)
+
+// init はginのバリデーターにリクエストの検証の設定を登録する
+// ルーターを通さずにコントローラーを使うテストでも同じ検証になるよう、パッケージの読み込み時に行う
+//   - 検証エラーの項目名はGoのフィールド名ではなくJSONのキー（クエリはformタグ）
+//   - bindingタグの独自ルール points: ポイントの額（1〜entities.PointAmountMax）
+//   - bindingタグの独自ルール message: 送金の説明・メッセージ（entities.TransferMessageMaxLength文字以下）
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+	rules := map[string]validator.Func{
+		"points":  validatePoints,
+		"message": validateMessage,
+	}
+	for tag, fn := range rules {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func validatePoints(fl validator.FieldLevel) bool {
+	if !fl.Field().CanInt() {
+		return false
+	}
+	amount := fl.Field().Int()
+	return amount >= 1 && amount <= entities.PointAmountMax
+}
+
+func validateMessage(fl validator.FieldLevel) bool {
+	return utf8.RuneCountInString(fl.Field().String()) <= entities.TransferMessageMaxLength
+}
+
+// PageQuery は一覧のoffset・limitのクエリ（limitが0なら各エンドポイントの既定値）
+type PageQuery struct {
+	Offset int `form:"offset" binding:"min=0"`
+	Limit  int `form:"limit" binding:"min=0,max=1000"`
+}
+
+// LimitQuery はoffsetを取らない一覧のlimitのクエリ（0なら各エンドポイントの既定値）
+type LimitQuery struct {
+	Limit int `form:"limit" binding:"min=0,max=1000"`
+}
+
+// bindPage はoffset・limitのクエリを検証して返す（limitを省略したらdefaultLimit）
+// 不正な値なら項目ごとのエラーで400を返し、okはfalse
+func bindPage(ctx *gin.Context, defaultLimit int) (offset, limit int, ok bool) {
+	var q PageQuery
+	if !bindQuery(ctx, &q) {
+		return 0, 0, false
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultLimit
+	}
+	return q.Offset, q.Limit, true
+}
+
+// bindLimit はlimitのクエリを検証して返す（省略したらdefaultLimit）
+func bindLimit(ctx *gin.Context, defaultLimit int) (limit int, ok bool) {
+	var q LimitQuery
+	if !bindQuery(ctx, &q) {
+		return 0, false
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultLimit
+	}
+	return q.Limit, true
+}
+
+// bindQuery はクエリをDTOにバインドしてbindingタグで検証する
+// 不正な値なら項目ごとのエラーで400を返してfalse
+func bindQuery(ctx *gin.Context, dst interface{}) bool {
+	if err := parseNumericQueries(ctx, dst); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return false
+	}
+	if err := ctx.ShouldBindQuery(dst); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return false
+	}
+	return true
+}
+
+// parseNumericQueries はDTOの数値の項目のクエリを1つずつ変換し、変換できなければその項目のエラーを返す
+// （ginのバインドは変換のエラーに項目名を含めないため、バインドの前に確かめる）
+func parseNumericQueries(ctx *gin.Context, dst interface{}) error {
+	t := reflect.TypeOf(dst).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := field.Tag.Get("form")
+		value := ctx.Query(key)
+		if key == "" || value == "" {
+			continue
+		}
+		switch field.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if _, err := strconv.ParseInt(value, 10, field.Type.Bits()); err != nil {
+				return invalidParam(key, "must be a number")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// WeeklyDigestController は週次のまとめメールのコントローラー
+type WeeklyDigestController struct {
+	digestUC  inputport.WeeklyDigestInputPort
+	presenter *presenter.WeeklyDigestPresenter
+}
+
+// NewWeeklyDigestController は新しいWeeklyDigestControllerを作成
+func NewWeeklyDigestController(
+	digestUC inputport.WeeklyDigestInputPort,
+	presenter *presenter.WeeklyDigestPresenter,
+) *WeeklyDigestController {
+	return &WeeklyDigestController{
+		digestUC:  digestUC,
+		presenter: presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *WeeklyDigestController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/weekly-digest/preview", c.PreviewWeeklyDigest)
+}
+
+// PreviewWeeklyDigest はユーザーに今送る週次のまとめメールを送らずに表示する
+// GET /api/admin/weekly-digest/preview?user_id=...
+func (c *WeeklyDigestController) PreviewWeeklyDigest(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(ctx.Query("user_id"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, invalidParam("user_id", "must be a valid ID"))
+		return
+	}
+
+	preview, err := c.digestUC.PreviewWeeklyDigest(ctx, &inputport.PreviewWeeklyDigestRequest{
+		AdminID: adminID.(uuid.UUID),
+		UserID:  userID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentPreview(preview))
+}
@@ -0,0 +1,52 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// WorkerLeaseController はワーカーのリーダーのコントローラー
+type WorkerLeaseController struct {
+	workerLeaseUC inputport.WorkerLeaseInputPort
+	presenter     *presenter.WorkerLeasePresenter
+}
+
+// NewWorkerLeaseController は新しいWorkerLeaseControllerを作成
+func NewWorkerLeaseController(
+	workerLeaseUC inputport.WorkerLeaseInputPort,
+	presenter *presenter.WorkerLeasePresenter,
+) *WorkerLeaseController {
+	return &WorkerLeaseController{
+		workerLeaseUC: workerLeaseUC,
+		presenter:     presenter,
+	}
+}
+
+// RegisterRoutes はルートを登録
+func (c *WorkerLeaseController) RegisterRoutes(routes *RouteGroups) {
+	routes.Admin.GET("/workers", c.GetLeases)
+}
+
+// GetLeases はワーカーごとのリーダーと交代回数を取得
+// GET /api/admin/workers
+func (c *WorkerLeaseController) GetLeases(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		respondError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+		return
+	}
+
+	leases, err := c.workerLeaseUC.ListLeases(ctx, adminID.(uuid.UUID))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentLeases(leases, time.Now()))
+}
@@ -12,4 +12,8 @@ type AccessRecord struct {
 	ID         uuid.UUID // Akerunアクセス記録ID
 	UserName   string    // Akerunユーザー名
 	AccessedAt time.Time // アクセス時刻（パース済み）
+
+	OrganizationID string // Akerun組織ID
+	DoorID         string // 通ったドア（Akerun）のID
+	DoorName       string
 }
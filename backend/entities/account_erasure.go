@@ -0,0 +1,31 @@
+package entities
+
+// AccountErasureModeSettingKey は退会時の個人データの扱いを保存するsystem_settingsのキー
+const AccountErasureModeSettingKey = "account_erasure_mode"
+
+// DeletedUserDisplayName は退会したユーザーの代わりに表示する名前
+const DeletedUserDisplayName = "退会済みユーザー"
+
+// AccountErasureMode は退会時に相手側の取引履歴をどこまで匿名化するか
+// 取引自体（日時・ポイント数）は会計上の記録として常に残す
+type AccountErasureMode string
+
+const (
+	// AccountErasureModeAnonymize は退会者を「退会済みユーザー」と表示し、退会者が書いたメモも消す（既定）
+	AccountErasureModeAnonymize AccountErasureMode = "anonymize"
+	// AccountErasureModeRetain は退会者を「退会済みユーザー」と表示するが、メモは監査のため残す
+	AccountErasureModeRetain AccountErasureMode = "retain"
+)
+
+// ParseAccountErasureMode は設定値を解釈する（未設定・不明な値は匿名化）
+func ParseAccountErasureMode(value string) AccountErasureMode {
+	if AccountErasureMode(value) == AccountErasureModeRetain {
+		return AccountErasureModeRetain
+	}
+	return AccountErasureModeAnonymize
+}
+
+// ScrubsMemos は退会者が書いたメモを消すかどうか
+func (m AccountErasureMode) ScrubsMemos() bool {
+	return m == AccountErasureModeAnonymize
+}
@@ -0,0 +1,208 @@
+package entities
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdminApprovalThresholdSettingKey は承認が必要になる付与・減算の金額を保存するsystem_settingsのキー
+// 0・未設定なら承認は不要
+const AdminApprovalThresholdSettingKey = "admin_approval_threshold"
+
+const (
+	// PendingAdminActionTTL は承認待ちの操作の有効期間（過ぎると期限切れになり実行できない）
+	PendingAdminActionTTL = 72 * time.Hour
+	// AdminApprovalCommentMaxLength は承認・却下のコメントの最大文字数
+	AdminApprovalCommentMaxLength = 500
+)
+
+// PendingAdminActionType は承認待ちの操作の種類
+type PendingAdminActionType string
+
+const (
+	PendingAdminActionGrant  PendingAdminActionType = "grant"
+	PendingAdminActionDeduct PendingAdminActionType = "deduct"
+)
+
+// PendingAdminActionStatus は承認待ちの操作の状態
+type PendingAdminActionStatus string
+
+const (
+	PendingAdminActionStatusPending  PendingAdminActionStatus = "pending"  // 承認待ち
+	PendingAdminActionStatusApproved PendingAdminActionStatus = "approved" // 承認済み・実行中
+	PendingAdminActionStatusExecuted PendingAdminActionStatus = "executed" // 実行済み
+	PendingAdminActionStatusFailed   PendingAdminActionStatus = "failed"   // 承認後の実行に失敗（残高不足など）
+	PendingAdminActionStatusRejected PendingAdminActionStatus = "rejected" // 却下
+	PendingAdminActionStatusExpired  PendingAdminActionStatus = "expired"  // 期限切れ
+)
+
+// IsValid は状態が定義済みかを判定
+func (s PendingAdminActionStatus) IsValid() bool {
+	switch s {
+	case PendingAdminActionStatusPending, PendingAdminActionStatusApproved, PendingAdminActionStatusExecuted,
+		PendingAdminActionStatusFailed, PendingAdminActionStatusRejected, PendingAdminActionStatusExpired:
+		return true
+	}
+	return false
+}
+
+// PendingAdminAction は承認を待っている管理者の付与・減算（二人目の管理者が承認すると実行される）
+type PendingAdminAction struct {
+	ID             uuid.UUID
+	ActionType     PendingAdminActionType
+	RequestedBy    uuid.UUID // 申請した管理者
+	TargetUserID   uuid.UUID
+	Amount         int64
+	Description    string
+	ReasonCode     string
+	Tag            string
+	IdempotencyKey string // 申請時のキー（実行時の付与・減算にも使う）
+	BudgetOverride bool
+	Status         PendingAdminActionStatus
+	ReviewedBy     *uuid.UUID
+	ReviewComment  string
+	ReviewedAt     *time.Time
+	TransactionID  *uuid.UUID // 実行した取引
+	FailureReason  string
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewPendingAdminAction は承認待ちの操作を作成
+func NewPendingAdminAction(actionType PendingAdminActionType, requestedBy, targetUserID uuid.UUID, amount int64, description, reasonCode, tag, idempotencyKey string, budgetOverride bool, now time.Time) *PendingAdminAction {
+	return &PendingAdminAction{
+		ID:             uuid.New(),
+		ActionType:     actionType,
+		RequestedBy:    requestedBy,
+		TargetUserID:   targetUserID,
+		Amount:         amount,
+		Description:    description,
+		ReasonCode:     reasonCode,
+		Tag:            tag,
+		IdempotencyKey: idempotencyKey,
+		BudgetOverride: budgetOverride,
+		Status:         PendingAdminActionStatusPending,
+		ExpiresAt:      now.Add(PendingAdminActionTTL),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// IsExpired は承認待ちのまま期限を過ぎたかを判定
+func (a *PendingAdminAction) IsExpired(now time.Time) bool {
+	return a.Status == PendingAdminActionStatusPending && !now.Before(a.ExpiresAt)
+}
+
+// Approve は承認する（申請した本人は承認できない）
+func (a *PendingAdminAction) Approve(reviewerID uuid.UUID, comment string, now time.Time) error {
+	if err := a.review(reviewerID, comment, now); err != nil {
+		return err
+	}
+	if reviewerID == a.RequestedBy {
+		return ErrSelfApproval
+	}
+	a.setReview(PendingAdminActionStatusApproved, reviewerID, comment, now)
+	return nil
+}
+
+// Reject は却下する（申請した本人による取り下げも却下として扱う）
+func (a *PendingAdminAction) Reject(reviewerID uuid.UUID, comment string, now time.Time) error {
+	if err := a.review(reviewerID, comment, now); err != nil {
+		return err
+	}
+	a.setReview(PendingAdminActionStatusRejected, reviewerID, comment, now)
+	return nil
+}
+
+func (a *PendingAdminAction) review(reviewerID uuid.UUID, comment string, now time.Time) error {
+	if a.Status != PendingAdminActionStatusPending {
+		return ErrPendingActionNotPending
+	}
+	if a.IsExpired(now) {
+		return ErrPendingActionExpired
+	}
+	if len([]rune(strings.TrimSpace(comment))) > AdminApprovalCommentMaxLength {
+		return ErrInvalidApprovalComment
+	}
+	return nil
+}
+
+func (a *PendingAdminAction) setReview(status PendingAdminActionStatus, reviewerID uuid.UUID, comment string, now time.Time) {
+	a.Status = status
+	a.ReviewedBy = &reviewerID
+	a.ReviewComment = strings.TrimSpace(comment)
+	a.ReviewedAt = &now
+	a.UpdatedAt = now
+}
+
+// Expire は期限切れにする
+func (a *PendingAdminAction) Expire(now time.Time) {
+	a.Status = PendingAdminActionStatusExpired
+	a.UpdatedAt = now
+}
+
+// MarkExecuted は承認後の実行が完了したことを記録
+func (a *PendingAdminAction) MarkExecuted(transactionID uuid.UUID, now time.Time) {
+	a.Status = PendingAdminActionStatusExecuted
+	a.TransactionID = &transactionID
+	a.UpdatedAt = now
+}
+
+// MarkFailed は承認後の実行に失敗したことを記録
+func (a *PendingAdminAction) MarkFailed(reason string, now time.Time) {
+	a.Status = PendingAdminActionStatusFailed
+	a.FailureReason = reason
+	a.UpdatedAt = now
+}
+
+// PendingAdminActionEventType は承認待ちの操作の監査記録の種類
+type PendingAdminActionEventType string
+
+const (
+	PendingAdminActionEventRequested PendingAdminActionEventType = "requested"
+	PendingAdminActionEventApproved  PendingAdminActionEventType = "approved"
+	PendingAdminActionEventRejected  PendingAdminActionEventType = "rejected"
+	PendingAdminActionEventExpired   PendingAdminActionEventType = "expired"
+	PendingAdminActionEventExecuted  PendingAdminActionEventType = "executed"
+	PendingAdminActionEventFailed    PendingAdminActionEventType = "failed"
+)
+
+// PendingAdminActionEvent は承認待ちの操作の監査記録（申請から実行までの各段階を残す）
+type PendingAdminActionEvent struct {
+	ID        uuid.UUID
+	ActionID  uuid.UUID
+	Event     PendingAdminActionEventType
+	ActorID   *uuid.UUID // 操作した管理者（期限切れなどシステムによる場合はnil）
+	Comment   string
+	CreatedAt time.Time
+}
+
+// NewPendingAdminActionEvent は監査記録を作成
+func NewPendingAdminActionEvent(actionID uuid.UUID, event PendingAdminActionEventType, actorID *uuid.UUID, comment string, now time.Time) *PendingAdminActionEvent {
+	return &PendingAdminActionEvent{
+		ID:        uuid.New(),
+		ActionID:  actionID,
+		Event:     event,
+		ActorID:   actorID,
+		Comment:   comment,
+		CreatedAt: now,
+	}
+}
+
+// ParseAdminApprovalThreshold はsystem_settingsの値を承認が必要になる金額として解釈（未設定・不正な値は0）
+func ParseAdminApprovalThreshold(value string) int64 {
+	threshold, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || threshold < 0 {
+		return 0
+	}
+	return threshold
+}
+
+// RequiresAdminApproval はamountの付与・減算に二人目の管理者の承認が必要かを判定（閾値を超える場合）
+func RequiresAdminApproval(threshold, amount int64) bool {
+	return threshold > 0 && amount > threshold
+}
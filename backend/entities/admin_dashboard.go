@@ -0,0 +1,41 @@
+package entities
+
+import "time"
+
+const (
+	// DashboardOnlineWindow はこの時間内に操作があったセッションのユーザーをオンラインとみなす
+	DashboardOnlineWindow = 15 * time.Minute
+	// DashboardTransferWindow は送金数を数える期間
+	DashboardTransferWindow = 1 * time.Hour
+	// DashboardFailedRepollWindow は失敗したAkerunの再取得を数える期間
+	DashboardFailedRepollWindow = 24 * time.Hour
+	// DashboardExpiryWindow は失効予定のポイントを数える期間
+	DashboardExpiryWindow = 7 * 24 * time.Hour
+)
+
+// AdminDashboardCounters は管理者ダッシュボードのカウンター
+type AdminDashboardCounters struct {
+	OnlineUsers             int64 // DashboardOnlineWindow内に操作があった有効なセッションのユーザー数
+	TransfersLastHour       int64 // DashboardTransferWindow内のユーザー間送金の数
+	PendingTransferRequests int64 // 承認待ち・送信者の確認待ちで期限内の送金リクエストの数
+	FailedScheduledJobs     int64 // 直近の実行が失敗した定期実行ジョブの数
+	FailedAkerunRepolls     int64 // DashboardFailedRepollWindow内に失敗したAkerunの再取得の数
+	DeadAkerunAccesses      int64 // 再試行の上限に達し、管理者の再投入を待っているAkerunアクセス記録の数
+	ExpiringPoints          int64 // DashboardExpiryWindow内に失効するポイントの合計
+	ExpiringUsers           int64 // DashboardExpiryWindow内に失効するポイントを持つユーザー数
+}
+
+// DatabaseHealth はDBへの疎通の結果
+type DatabaseHealth struct {
+	Healthy bool
+	Latency time.Duration
+	Error   string // 疎通できなかった場合の理由
+}
+
+// AdminDashboard は管理者ダッシュボードの内容
+// DBに疎通できない場合、Countersはnil
+type AdminDashboard struct {
+	Counters    *AdminDashboardCounters
+	Database    DatabaseHealth
+	GeneratedAt time.Time
+}
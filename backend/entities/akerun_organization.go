@@ -0,0 +1,50 @@
+package entities
+
+// AkerunDoorRule はドア（Akerun）ごとのボーナス規則
+type AkerunDoorRule struct {
+	DoorID     string
+	Name       string
+	Multiplier float64 // 抽選で決まったポイントに掛ける倍率（0なら1倍）
+}
+
+// PointsMultiplier は倍率を返す（未設定なら1倍）
+func (r AkerunDoorRule) PointsMultiplier() float64 {
+	if r.Multiplier <= 0 {
+		return 1
+	}
+	return r.Multiplier
+}
+
+// AkerunOrganization はポーリングするAkerun組織とドアごとのボーナス規則
+// Doorsが空なら組織内のすべてのドアを1倍で対象にし、指定があれば指定したドアだけを対象にする
+type AkerunOrganization struct {
+	ID    string
+	Name  string
+	Doors []AkerunDoorRule
+}
+
+// RuleFor はドアの規則を返す（ボーナスの対象外のドアならfalse）
+func (o AkerunOrganization) RuleFor(doorID string) (AkerunDoorRule, bool) {
+	if len(o.Doors) == 0 {
+		return AkerunDoorRule{DoorID: doorID, Multiplier: 1}, true
+	}
+	for _, door := range o.Doors {
+		if door.DoorID == doorID {
+			return door, true
+		}
+	}
+	return AkerunDoorRule{}, false
+}
+
+// AkerunOrganizations は組織IDごとのボーナス規則
+type AkerunOrganizations map[string]AkerunOrganization
+
+// RuleFor はアクセス記録のドアの規則を返す（ボーナスの対象外のドアならfalse）
+// 設定にない組織（組織IDのないアクセス記録を含む）はすべてのドアを1倍で対象にする
+func (orgs AkerunOrganizations) RuleFor(access AccessRecord) (AkerunDoorRule, bool) {
+	org, ok := orgs[access.OrganizationID]
+	if !ok {
+		return AkerunDoorRule{DoorID: access.DoorID, Multiplier: 1}, true
+	}
+	return org.RuleFor(access.DoorID)
+}
@@ -0,0 +1,119 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AkerunRepollStatus はAkerunの再取得の状態
+type AkerunRepollStatus string
+
+const (
+	AkerunRepollStatusPending   AkerunRepollStatus = "pending" // ワーカーの処理待ち
+	AkerunRepollStatusRunning   AkerunRepollStatus = "running" // 取得中（ワーカーが止まった場合は次のワーカーが続きから再開する）
+	AkerunRepollStatusSucceeded AkerunRepollStatus = "succeeded"
+	AkerunRepollStatusFailed    AkerunRepollStatus = "failed"
+)
+
+const (
+	// AkerunRepollMaxRange は1回の再取得で指定できる期間の上限
+	AkerunRepollMaxRange = 7 * 24 * time.Hour
+	// AkerunRepollWindow は1回のAPI呼び出しで取得する期間（ワーカーのリカバリモードと同じ）
+	AkerunRepollWindow = 1 * time.Hour
+)
+
+// AkerunRepoll は管理者が依頼したAkerunの入退室記録の再取得
+// トークンの期限切れなどでポーリングできなかった期間を、ワーカーが1時間ずつ取得してボーナスを付与し直す
+// 付与済みのアクセス記録は処理済みとして飛ばすので、取得済みの期間を含めても二重には付与されない
+type AkerunRepoll struct {
+	ID               uuid.UUID
+	OrganizationID   string // 空なら設定済みのすべての組織
+	From             time.Time
+	To               time.Time
+	Status           AkerunRepollStatus
+	TotalWindows     int
+	CompletedWindows int
+	FetchedAccesses  int
+	ErrorMessage     string
+	RequestedBy      uuid.UUID
+	CreatedAt        time.Time
+	StartedAt        *time.Time
+	FinishedAt       *time.Time
+}
+
+// NewAkerunRepoll は新しい再取得の依頼を作成（期間は過去の AkerunRepollMaxRange 以内）
+func NewAkerunRepoll(organizationID string, from, to time.Time, requestedBy uuid.UUID, now time.Time) (*AkerunRepoll, error) {
+	if !from.Before(to) || to.After(now) || to.Sub(from) > AkerunRepollMaxRange {
+		return nil, ErrInvalidAkerunRepoll
+	}
+
+	total := int(to.Sub(from) / AkerunRepollWindow)
+	if to.Sub(from)%AkerunRepollWindow != 0 {
+		total++
+	}
+
+	return &AkerunRepoll{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		From:           from,
+		To:             to,
+		Status:         AkerunRepollStatusPending,
+		TotalWindows:   total,
+		RequestedBy:    requestedBy,
+		CreatedAt:      now,
+	}, nil
+}
+
+// IsInProgress は処理待ちまたは取得中かどうか
+func (r *AkerunRepoll) IsInProgress() bool {
+	return r.Status == AkerunRepollStatusPending || r.Status == AkerunRepollStatusRunning
+}
+
+// NextWindow は次に取得する期間（すべて取得済みならfalse）
+func (r *AkerunRepoll) NextWindow() (from, to time.Time, ok bool) {
+	if r.CompletedWindows >= r.TotalWindows {
+		return time.Time{}, time.Time{}, false
+	}
+	from = r.From.Add(time.Duration(r.CompletedWindows) * AkerunRepollWindow)
+	to = from.Add(AkerunRepollWindow)
+	if to.After(r.To) {
+		to = r.To
+	}
+	return from, to, true
+}
+
+// Start は取得中に更新（再開した場合は最初の開始時刻を残す）
+func (r *AkerunRepoll) Start(now time.Time) {
+	r.Status = AkerunRepollStatusRunning
+	if r.StartedAt == nil {
+		r.StartedAt = &now
+	}
+}
+
+// RecordWindow は1期間分の取得が終わったことを記録
+func (r *AkerunRepoll) RecordWindow(fetched int) {
+	r.CompletedWindows++
+	r.FetchedAccesses += fetched
+}
+
+// MarkSucceeded は完了に更新
+func (r *AkerunRepoll) MarkSucceeded(now time.Time) {
+	r.Status = AkerunRepollStatusSucceeded
+	r.FinishedAt = &now
+}
+
+// MarkFailed は失敗に更新（取得済みの期間の付与はそのまま残る）
+func (r *AkerunRepoll) MarkFailed(message string, now time.Time) {
+	r.Status = AkerunRepollStatusFailed
+	r.ErrorMessage = message
+	r.FinishedAt = &now
+}
+
+// ProgressPercent は取得済みの期間の割合（0〜100）
+func (r *AkerunRepoll) ProgressPercent() int {
+	if r.TotalWindows == 0 {
+		return 100
+	}
+	return r.CompletedWindows * 100 / r.TotalWindows
+}
@@ -1,6 +1,10 @@
 package entities
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // AnalyticsSummaryResult は集約サマリーの結果
 type AnalyticsSummaryResult struct {
@@ -24,6 +28,25 @@ type TypeBreakdownResult struct {
 	TotalAmount int64
 }
 
+// ReasonCodeBreakdownResult は理由コード・取引種別ごとの管理者付与・減算の集計結果
+type ReasonCodeBreakdownResult struct {
+	ReasonCode      string
+	Label           string
+	TransactionType string
+	Count           int64
+	TotalAmount     int64
+}
+
+// DepartmentBreakdownResult は部署ごとの集計結果（配下の部署は含めず、現在の所属ユーザーで集計）
+type DepartmentBreakdownResult struct {
+	DepartmentID uuid.UUID
+	Name         string
+	ParentID     *uuid.UUID
+	MemberCount  int64
+	Granted      int64 // 所属ユーザーが管理者・システムから受け取ったポイント
+	Spent        int64 // 所属ユーザーが商品交換・管理者減算で使ったポイント
+}
+
 // TopHolderResult はポイント保有上位ユーザーの結果
 type TopHolderResult struct {
 	ID          string
@@ -31,3 +54,112 @@ type TopHolderResult struct {
 	DisplayName string
 	Balance     int64
 }
+
+// AnalyticsGranularity はコホート・アクティブユーザー集計の期間単位
+type AnalyticsGranularity string
+
+const (
+	AnalyticsGranularityWeek  AnalyticsGranularity = "week"  // 週単位（月曜始まり）
+	AnalyticsGranularityMonth AnalyticsGranularity = "month" // 月単位
+)
+
+// IsValid は対応している期間単位かどうか
+func (g AnalyticsGranularity) IsValid() bool {
+	return g == AnalyticsGranularityWeek || g == AnalyticsGranularityMonth
+}
+
+// Truncate は時刻を期間の開始時刻に切り捨てる（PostgreSQLのdate_truncと同じ境界）
+func (g AnalyticsGranularity) Truncate(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if g == AnalyticsGranularityMonth {
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	// time.Weekday は日曜=0 なので月曜始まりに補正
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// Next は次の期間の開始時刻を返す
+func (g AnalyticsGranularity) Next(periodStart time.Time) time.Time {
+	if g == AnalyticsGranularityMonth {
+		return periodStart.AddDate(0, 1, 0)
+	}
+	return periodStart.AddDate(0, 0, 7)
+}
+
+// PeriodsBetween はfromの期間からtoの期間までの期間数を返す（同じ期間なら0）
+func (g AnalyticsGranularity) PeriodsBetween(from, to time.Time) int {
+	from, to = g.Truncate(from), g.Truncate(to)
+	if g == AnalyticsGranularityMonth {
+		return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+	}
+	// サマータイムの切り替えで端数が出ないよう、暦日をUTCに載せ替えて日数を数える
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDay.Sub(fromDay).Hours()/24) / 7
+}
+
+// ActivityBasis はアクティブ判定の基準
+type ActivityBasis string
+
+const (
+	// ActivityBasisTransactions は送金・受取・商品交換があればアクティブとみなす
+	ActivityBasisTransactions ActivityBasis = "transactions"
+	// ActivityBasisLogins はログインに成功していればアクティブとみなす
+	ActivityBasisLogins ActivityBasis = "logins"
+)
+
+// IsValid は対応している判定基準かどうか
+func (b ActivityBasis) IsValid() bool {
+	return b == ActivityBasisTransactions || b == ActivityBasisLogins
+}
+
+// 機能別利用状況の機能名
+const (
+	FeatureTransfer        = "transfer"         // 直接送金
+	FeatureQRCode          = "qr"               // QRコード送金
+	FeatureTransferRequest = "transfer_request" // 送金リクエスト（承認済み）
+	FeatureExchange        = "exchange"         // 商品交換
+)
+
+// ActiveUserCountResult は期間ごとのアクティブユーザー数
+type ActiveUserCountResult struct {
+	PeriodStart time.Time
+	ActiveUsers int64
+}
+
+// CohortSizeResult は登録期間ごとの新規ユーザー数
+type CohortSizeResult struct {
+	CohortStart time.Time
+	Users       int64
+}
+
+// CohortActivityResult はコホートのうち、ある期間にアクティブだったユーザー数
+type CohortActivityResult struct {
+	CohortStart time.Time
+	PeriodStart time.Time
+	ActiveUsers int64
+}
+
+// FeatureUsageResult は機能別の利用状況
+type FeatureUsageResult struct {
+	Feature     string
+	Count       int64
+	Users       int64
+	TotalAmount int64
+}
+
+// ExpiryForecastResult は失効予定ポイントの週ごとの集計
+// WeekIndex は集計開始日から何週目か（0始まり）
+type ExpiryForecastResult struct {
+	WeekIndex int
+	Amount    int64
+	Users     int64
+	Batches   int64
+}
+
+// CirculationVelocityResult はポイントを獲得してから使うまでの時間の集計
+type CirculationVelocityResult struct {
+	SampleCount   int64
+	MedianSeconds float64
+}
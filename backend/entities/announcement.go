@@ -0,0 +1,128 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnnouncementSeverity はお知らせの重要度
+type AnnouncementSeverity string
+
+const (
+	AnnouncementSeverityInfo     AnnouncementSeverity = "info"
+	AnnouncementSeverityWarning  AnnouncementSeverity = "warning"
+	AnnouncementSeverityCritical AnnouncementSeverity = "critical"
+)
+
+// AnnouncementAudience はお知らせの配信対象
+type AnnouncementAudience string
+
+const (
+	AnnouncementAudienceAll    AnnouncementAudience = "all"    // 全ユーザー
+	AnnouncementAudienceAdmins AnnouncementAudience = "admins" // 管理者のみ
+	AnnouncementAudienceRole   AnnouncementAudience = "role"   // TargetRoleのユーザーのみ
+)
+
+const (
+	// AnnouncementTitleMaxLength はタイトルの最大文字数
+	AnnouncementTitleMaxLength = 100
+	// AnnouncementBodyMaxLength は本文の最大文字数
+	AnnouncementBodyMaxLength = 2000
+)
+
+// Announcement は管理者がアプリ内に配信するお知らせ
+// 表示期間（StartsAt〜EndsAt）の間だけ配信対象のユーザーに表示される
+type Announcement struct {
+	ID         uuid.UUID
+	Title      string
+	Body       string
+	Severity   AnnouncementSeverity
+	Audience   AnnouncementAudience
+	TargetRole UserRole   // Audienceがroleのときのみ設定
+	StartsAt   time.Time  // 表示開始日時
+	EndsAt     *time.Time // 表示終了日時（nilなら無期限）
+	CreatedBy  uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewAnnouncement は新しいお知らせを作成
+func NewAnnouncement(title, body string, severity AnnouncementSeverity, audience AnnouncementAudience, targetRole UserRole, startsAt time.Time, endsAt *time.Time, createdBy uuid.UUID) (*Announcement, error) {
+	now := time.Now()
+	a := &Announcement{
+		ID:        uuid.New(),
+		CreatedBy: createdBy,
+		CreatedAt: now,
+	}
+	if err := a.Update(title, body, severity, audience, targetRole, startsAt, endsAt); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Update はお知らせの内容を検証して更新
+func (a *Announcement) Update(title, body string, severity AnnouncementSeverity, audience AnnouncementAudience, targetRole UserRole, startsAt time.Time, endsAt *time.Time) error {
+	title = strings.TrimSpace(title)
+	body = strings.TrimSpace(body)
+	if title == "" || len([]rune(title)) > AnnouncementTitleMaxLength {
+		return ErrInvalidAnnouncement
+	}
+	if body == "" || len([]rune(body)) > AnnouncementBodyMaxLength {
+		return ErrInvalidAnnouncement
+	}
+	switch severity {
+	case AnnouncementSeverityInfo, AnnouncementSeverityWarning, AnnouncementSeverityCritical:
+	default:
+		return ErrInvalidAnnouncement
+	}
+	switch audience {
+	case AnnouncementAudienceAll, AnnouncementAudienceAdmins:
+		targetRole = ""
+	case AnnouncementAudienceRole:
+		if targetRole != RoleUser && targetRole != RoleAdmin {
+			return ErrInvalidAnnouncement
+		}
+	default:
+		return ErrInvalidAnnouncement
+	}
+	if startsAt.IsZero() {
+		startsAt = time.Now()
+	}
+	if endsAt != nil && !endsAt.After(startsAt) {
+		return ErrInvalidAnnouncement
+	}
+
+	a.Title = title
+	a.Body = body
+	a.Severity = severity
+	a.Audience = audience
+	a.TargetRole = targetRole
+	a.StartsAt = startsAt
+	a.EndsAt = endsAt
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsActiveAt は指定日時が表示期間内かを判定
+func (a *Announcement) IsActiveAt(now time.Time) bool {
+	if now.Before(a.StartsAt) {
+		return false
+	}
+	return a.EndsAt == nil || now.Before(*a.EndsAt)
+}
+
+// IsVisibleTo は指定した役割のユーザーが配信対象かを判定
+func (a *Announcement) IsVisibleTo(role UserRole) bool {
+	switch a.Audience {
+	case AnnouncementAudienceAll:
+		return true
+	case AnnouncementAudienceAdmins:
+		return role == RoleAdmin || role == RoleSuperAdmin
+	case AnnouncementAudienceRole:
+		return role == a.TargetRole
+	default:
+		return false
+	}
+}
@@ -64,6 +64,7 @@ func (au *ArchivedUser) RestoreToUser() *User {
 		EmailVerifiedAt: au.EmailVerifiedAt,
 		Version:         1, // 復元時はバージョンをリセット
 		IsActive:        true,
+		Discoverable:    false, // 公開設定はアーカイブに残していないため、復元時は非公開にする
 		CreatedAt:       au.OriginalCreatedAt,
 		UpdatedAt:       time.Now(),
 	}
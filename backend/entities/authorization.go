@@ -0,0 +1,101 @@
+package entities
+
+import "github.com/google/uuid"
+
+// ResourceType はユーザーが持つ（関わる）リソースの種類（パスの:idが指すもの）
+type ResourceType string
+
+const (
+	ResourceTransferRequest ResourceType = "transfer_request" // 送信者・受取人
+	ResourceQRCode          ResourceType = "qr_code"          // 作成者
+	ResourceExchange        ResourceType = "exchange"         // 交換した人・贈られた人
+	ResourceShippingAddress ResourceType = "shipping_address" // 登録した人
+	ResourceTransaction     ResourceType = "transaction"      // 送信者・受取人
+)
+
+// Action はリソースへの操作
+type Action string
+
+const (
+	ActionView    Action = "view"
+	ActionUpdate  Action = "update"
+	ActionDelete  Action = "delete"
+	ActionApprove Action = "approve"
+	ActionReject  Action = "reject"
+	ActionCounter Action = "counter"
+	ActionConfirm Action = "confirm"
+	ActionCancel  Action = "cancel"
+	ActionAccept  Action = "accept"
+	ActionDecline Action = "decline"
+)
+
+// OwnedResource はユーザーが持つ（関わる）リソース
+type OwnedResource interface {
+	// Authorize はユーザーがリソースを操作できるかを判定
+	// 関わりのないユーザーには存在を明かさないようリソースのNotFoundを、
+	// 関わっているが許されない操作（送信者による承認など）にはErrActionNotPermittedを返す
+	Authorize(userID uuid.UUID, action Action) error
+}
+
+// authorizeParty はリソースに関わるか（involved）と操作が許されるか（permitted）からOwnedResource.Authorizeの結果を返す
+func authorizeParty(involved, permitted bool, notFound error) error {
+	if !involved {
+		return notFound
+	}
+	if !permitted {
+		return ErrActionNotPermitted
+	}
+	return nil
+}
+
+// Authorize は送金リクエストを操作できるかを判定
+// 閲覧は送信者・受取人、承認・拒否は承認者、カウンターオファーは受取人、その確定は送信者、キャンセルはキャンセルできる人のみ
+func (tr *TransferRequest) Authorize(userID uuid.UUID, action Action) error {
+	var permitted bool
+	switch action {
+	case ActionView:
+		permitted = true
+	case ActionApprove, ActionReject:
+		permitted = tr.Approver() == userID
+	case ActionCounter:
+		permitted = tr.ToUserID == userID
+	case ActionConfirm:
+		permitted = tr.FromUserID == userID
+	case ActionCancel:
+		permitted = tr.Canceller() == userID
+	}
+	return authorizeParty(tr.FromUserID == userID || tr.ToUserID == userID, permitted, ErrTransferRequestNotFound)
+}
+
+// Authorize はQRコードを操作できるかを判定（作成者の閲覧のみ。読み取りはIDではなくコードで行う）
+func (q *QRCode) Authorize(userID uuid.UUID, action Action) error {
+	return authorizeParty(q.UserID == userID, action == ActionView, ErrQRCodeNotFound)
+}
+
+// Authorize は交換を操作できるかを判定
+// 閲覧は交換した人・贈られた人、キャンセルは交換した人、贈り物の受け取り・辞退は贈られた人のみ
+func (e *ProductExchange) Authorize(userID uuid.UUID, action Action) error {
+	isRecipient := e.IsGift() && *e.RecipientID == userID
+	var permitted bool
+	switch action {
+	case ActionView:
+		permitted = true
+	case ActionCancel:
+		permitted = e.UserID == userID
+	case ActionAccept, ActionDecline:
+		permitted = isRecipient
+	}
+	return authorizeParty(e.UserID == userID || isRecipient, permitted, ErrExchangeNotFound)
+}
+
+// Authorize はお届け先を操作できるかを判定（登録した人だけが閲覧・変更（既定の切り替えを含む）・削除できる）
+func (a *ShippingAddress) Authorize(userID uuid.UUID, action Action) error {
+	permitted := action == ActionView || action == ActionUpdate || action == ActionDelete
+	return authorizeParty(a.UserID == userID, permitted, ErrShippingAddressNotFound)
+}
+
+// Authorize は取引を操作できるかを判定（送信者・受取人の閲覧のみ）
+func (t *Transaction) Authorize(userID uuid.UUID, action Action) error {
+	involved := (t.FromUserID != nil && *t.FromUserID == userID) || (t.ToUserID != nil && *t.ToUserID == userID)
+	return authorizeParty(involved, action == ActionView, ErrTransactionNotFound)
+}
@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BalanceHoldStatus は残高の確保の状態
+type BalanceHoldStatus string
+
+const (
+	BalanceHoldStatusActive   BalanceHoldStatus = "active"   // 確保中（送信者はこの分を使えない）
+	BalanceHoldStatusConsumed BalanceHoldStatus = "consumed" // 承認された送金に使った
+	BalanceHoldStatusReleased BalanceHoldStatus = "released" // 拒否・キャンセル・期限切れで戻した
+)
+
+// BalanceHold は送金リクエストのために送信者の残高から確保した額
+// 確保中の額はユーザーのReservedBalanceに含まれ、承認までほかの送金・交換に使えない
+type BalanceHold struct {
+	ID                uuid.UUID
+	UserID            uuid.UUID // 確保した送信者
+	TransferRequestID uuid.UUID
+	Amount            int64
+	Status            BalanceHoldStatus
+	CreatedAt         time.Time
+	SettledAt         *time.Time // 使った・戻した日時
+}
+
+// NewBalanceHold は送金リクエストの送信者の残高からリクエストの金額を確保する
+func NewBalanceHold(tr *TransferRequest) (*BalanceHold, error) {
+	if tr.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	return &BalanceHold{
+		ID:                uuid.New(),
+		UserID:            tr.FromUserID,
+		TransferRequestID: tr.ID,
+		Amount:            tr.Amount,
+		Status:            BalanceHoldStatusActive,
+		CreatedAt:         time.Now(),
+	}, nil
+}
+
+// IsActive は確保中かを判定
+func (h *BalanceHold) IsActive() bool {
+	return h.Status == BalanceHoldStatusActive
+}
+
+// Consume は承認された送金に確保を使う
+func (h *BalanceHold) Consume(now time.Time) error {
+	return h.settle(BalanceHoldStatusConsumed, now)
+}
+
+// Release は確保を戻す
+func (h *BalanceHold) Release(now time.Time) error {
+	return h.settle(BalanceHoldStatusReleased, now)
+}
+
+func (h *BalanceHold) settle(status BalanceHoldStatus, now time.Time) error {
+	if !h.IsActive() {
+		return ErrBalanceHoldSettled
+	}
+	h.Status = status
+	h.SettledAt = &now
+	return nil
+}
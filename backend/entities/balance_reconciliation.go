@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// BalanceRecomputeDefaultBatchSize は残高の再計算で1回に照合するユーザー数の既定値
+	BalanceRecomputeDefaultBatchSize = 200
+	// BalanceRecomputeMaxBatchSize は1回に照合するユーザー数の上限（補正時はこの人数の行をまとめてロックする）
+	BalanceRecomputeMaxBatchSize = 1000
+)
+
+// BalanceCorrectionSkipNegativeLedger は取引履歴から計算した残高が負のため補正しなかったことを示す
+const BalanceCorrectionSkipNegativeLedger = "negative_ledger_balance"
+
+// BalanceCorrectionSkipReserved は減らす分が確保中の送金リクエストの分に掛かるため補正しなかったことを示す
+// （確保が解放された後の再計算で補正する）
+const BalanceCorrectionSkipReserved = "reserved_balance"
+
+// BalanceCheck は保存されている残高と、取引履歴から計算した残高の照合結果
+// 取引履歴から計算した残高 = 移行時の残高（source_typeがmigrationのバッチ）
+// ＋ それ以降の完了済み取引の受取 − 送付 ＋ キャンセルした商品交換の返金（補正取引は含めない）
+type BalanceCheck struct {
+	UserID        uuid.UUID
+	Username      string
+	StoredBalance int64 // users.balance
+	LedgerBalance int64 // 取引履歴から計算した残高
+}
+
+// Difference は補正で残高に加える量を返す（負なら減らす）
+func (c *BalanceCheck) Difference() int64 {
+	return c.LedgerBalance - c.StoredBalance
+}
+
+// HasDiscrepancy は残高が取引履歴と一致しないかを判定
+func (c *BalanceCheck) HasDiscrepancy() bool {
+	return c.Difference() != 0
+}
+
+// BalanceDiscrepancy は取引履歴と一致しなかった残高と、補正の結果
+type BalanceDiscrepancy struct {
+	BalanceCheck
+	Corrected     bool
+	TransactionID *uuid.UUID // 補正取引のID（補正した場合のみ）
+	SkipReason    string     // 補正しなかった理由（照合のみの場合は空）
+}
+
+// NewBalanceCorrection は残高を取引履歴に合わせる補正取引を作成
+// 増やす場合はシステムからの受取、減らす場合はシステムへの返却として記録する
+func NewBalanceCorrection(check *BalanceCheck, adminID uuid.UUID, reason string, now time.Time) (*Transaction, error) {
+	diff := check.Difference()
+	if diff == 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	description := "取引履歴との照合による残高の補正"
+	if reason != "" {
+		description += ": " + reason
+	}
+
+	userID := check.UserID
+	tx := &Transaction{
+		ID:              uuid.New(),
+		Amount:          diff,
+		TransactionType: TransactionTypeBalanceCorrection,
+		Status:          TransactionStatusCompleted,
+		Description:     description,
+		Metadata: map[string]interface{}{
+			"admin_id":       adminID.String(),
+			"stored_balance": check.StoredBalance,
+			"ledger_balance": check.LedgerBalance,
+		},
+		CreatedAt:   now,
+		CompletedAt: ptrTime(now),
+	}
+	if diff > 0 {
+		tx.ToUserID = &userID
+	} else {
+		tx.FromUserID = &userID
+		tx.Amount = -diff
+	}
+	return tx, nil
+}
@@ -0,0 +1,237 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BudgetScope は予算の対象範囲
+type BudgetScope string
+
+const (
+	BudgetScopeGlobal     BudgetScope = "global"     // すべての管理者付与
+	BudgetScopeDepartment BudgetScope = "department" // 部署（配下の部署を含む）の所属ユーザーへの付与
+	BudgetScopeAdmin      BudgetScope = "admin"      // 特定の管理者による付与
+)
+
+// BudgetPeriod は予算の集計期間
+type BudgetPeriod string
+
+const (
+	BudgetPeriodDaily   BudgetPeriod = "daily"
+	BudgetPeriodWeekly  BudgetPeriod = "weekly" // 月曜始まり
+	BudgetPeriodMonthly BudgetPeriod = "monthly"
+)
+
+// BudgetEnforcement は予算を超える付与の扱い
+type BudgetEnforcement string
+
+const (
+	BudgetEnforcementBlock    BudgetEnforcement = "block"    // 超える付与は常に拒否
+	BudgetEnforcementOverride BudgetEnforcement = "override" // 管理者が明示的に許可すれば超えて付与できる
+)
+
+// BudgetNameMaxLength は予算名の最大文字数
+const BudgetNameMaxLength = 100
+
+// BudgetAlertThresholds は消化率の通知の閾値（%、昇順）
+var BudgetAlertThresholds = []int{80, 100}
+
+// Budget は管理者付与の予算
+type Budget struct {
+	ID          uuid.UUID
+	Name        string
+	Scope       BudgetScope
+	ScopeID     *uuid.UUID // 部署IDまたは管理者のユーザーID（globalならnil）
+	Period      BudgetPeriod
+	Amount      int64
+	Enforcement BudgetEnforcement
+	IsActive    bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// BudgetUsage は予算の期間ごとの消化状況
+type BudgetUsage struct {
+	BudgetID       uuid.UUID
+	PeriodStart    time.Time
+	Consumed       int64
+	AlertedPercent int // この期間に通知済みの閾値（未通知なら0）
+	UpdatedAt      time.Time
+}
+
+// NewBudget は新しい予算を作成
+func NewBudget(name string, scope BudgetScope, scopeID *uuid.UUID, period BudgetPeriod, amount int64, enforcement BudgetEnforcement) (*Budget, error) {
+	switch scope {
+	case BudgetScopeGlobal:
+		if scopeID != nil {
+			return nil, ErrInvalidBudget
+		}
+	case BudgetScopeDepartment, BudgetScopeAdmin:
+		if scopeID == nil {
+			return nil, ErrInvalidBudget
+		}
+	default:
+		return nil, ErrInvalidBudget
+	}
+	switch period {
+	case BudgetPeriodDaily, BudgetPeriodWeekly, BudgetPeriodMonthly:
+	default:
+		return nil, ErrInvalidBudget
+	}
+
+	now := time.Now()
+	b := &Budget{
+		ID:        uuid.New(),
+		Scope:     scope,
+		ScopeID:   scopeID,
+		Period:    period,
+		CreatedAt: now,
+	}
+	if err := b.Update(name, amount, enforcement, true); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Update は予算名・金額・超過時の扱い・有効状態を更新（対象範囲と期間は変えられない）
+func (b *Budget) Update(name string, amount int64, enforcement BudgetEnforcement, isActive bool) error {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > BudgetNameMaxLength {
+		return ErrInvalidBudget
+	}
+	if amount <= 0 {
+		return ErrInvalidBudget
+	}
+	if enforcement != BudgetEnforcementBlock && enforcement != BudgetEnforcementOverride {
+		return ErrInvalidBudget
+	}
+
+	b.Name = name
+	b.Amount = amount
+	b.Enforcement = enforcement
+	b.IsActive = isActive
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+// PeriodStart はnowを含む集計期間の開始日時
+func (b *Budget) PeriodStart(now time.Time) time.Time {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch b.Period {
+	case BudgetPeriodWeekly:
+		offset := (int(day.Weekday()) + 6) % 7 // 月曜からの日数
+		return day.AddDate(0, 0, -offset)
+	case BudgetPeriodMonthly:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	}
+	return day
+}
+
+// PeriodEnd はperiodStartから始まる集計期間の終了日時（この日時は含まない）
+func (b *Budget) PeriodEnd(periodStart time.Time) time.Time {
+	switch b.Period {
+	case BudgetPeriodWeekly:
+		return periodStart.AddDate(0, 0, 7)
+	case BudgetPeriodMonthly:
+		return periodStart.AddDate(0, 1, 0)
+	}
+	return periodStart.AddDate(0, 0, 1)
+}
+
+// Consume は期間の消化状況にamountを加える
+// 予算を超える場合、blockなら常に、overrideなら許可がなければエラーを返し、usageは変更しない
+// 新たに超えた通知の閾値があればその値を返す（なければ0）
+func (b *Budget) Consume(usage *BudgetUsage, amount int64, allowOverride bool) (int, error) {
+	if usage.Consumed+amount > b.Amount {
+		params := map[string]interface{}{
+			"name":      b.Name,
+			"budget":    b.Amount,
+			"remaining": max(b.Amount-usage.Consumed, 0),
+		}
+		if b.Enforcement == BudgetEnforcementBlock {
+			return 0, ErrBudgetExceeded.WithParams(params)
+		}
+		if !allowOverride {
+			return 0, ErrBudgetOverrideRequired.WithParams(params)
+		}
+	}
+
+	usage.Consumed += amount
+	usage.UpdatedAt = time.Now()
+
+	crossed := 0
+	for _, threshold := range BudgetAlertThresholds {
+		if usage.AlertedPercent < threshold && usage.Consumed*100 >= b.Amount*int64(threshold) {
+			crossed = threshold
+		}
+	}
+	if crossed > 0 {
+		usage.AlertedPercent = crossed
+	}
+	return crossed, nil
+}
+
+// Applies は管理者adminIDから部署departmentIDs（所属部署とその上位の部署）の所属ユーザーへの付与が対象かを判定
+func (b *Budget) Applies(adminID uuid.UUID, departmentIDs []uuid.UUID) bool {
+	switch b.Scope {
+	case BudgetScopeGlobal:
+		return true
+	case BudgetScopeAdmin:
+		return b.ScopeID != nil && *b.ScopeID == adminID
+	case BudgetScopeDepartment:
+		for _, id := range departmentIDs {
+			if b.ScopeID != nil && *b.ScopeID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// BudgetStatus は予算の現在の期間の消化状況
+type BudgetStatus struct {
+	Budget      *Budget
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Consumed    int64
+	Remaining   int64 // 超過している場合は0
+	Percent     int   // 消化率（%、100を超えることがある）
+}
+
+// NewBudgetStatus は予算と期間の消化状況から状況を作成
+func NewBudgetStatus(b *Budget, usage *BudgetUsage) *BudgetStatus {
+	return &BudgetStatus{
+		Budget:      b,
+		PeriodStart: usage.PeriodStart,
+		PeriodEnd:   b.PeriodEnd(usage.PeriodStart),
+		Consumed:    usage.Consumed,
+		Remaining:   max(b.Amount-usage.Consumed, 0),
+		Percent:     int(usage.Consumed * 100 / b.Amount),
+	}
+}
+
+// NewBudgetAlertNotification は予算の消化率が閾値を超えたことを管理者へ知らせる通知を作成
+func NewBudgetAlertNotification(adminID uuid.UUID, b *Budget, usage *BudgetUsage, threshold int) *Notification {
+	title := fmt.Sprintf("予算「%s」の%d%%を消化しました", b.Name, threshold)
+	if threshold >= 100 {
+		title = fmt.Sprintf("予算「%s」を使い切りました", b.Name)
+	}
+	return &Notification{
+		UserID: adminID,
+		Type:   NotificationTypeBudgetAlert,
+		Title:  title,
+		Body:   fmt.Sprintf("今期間の付与は%d / %dポイントです", usage.Consumed, b.Amount),
+		Data: map[string]string{
+			"budget_id":    b.ID.String(),
+			"threshold":    fmt.Sprint(threshold),
+			"consumed":     fmt.Sprint(usage.Consumed),
+			"amount":       fmt.Sprint(b.Amount),
+			"period_start": usage.PeriodStart.Format(time.RFC3339),
+		},
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// DefaultBusinessMetricsInterval は業務の指標を集計し直す既定の間隔
+const DefaultBusinessMetricsInterval = time.Minute
+
+// businessMetricsLocation は「今日」の区切りに使うタイムゾーン（JST）
+var businessMetricsLocation = time.FixedZone("JST", 9*60*60)
+
+// BusinessMetrics は /metrics で公開する業務の指標
+// リクエストごとにDBを集計しないよう、バックグラウンドで定期的に集計した値を保持して返す
+// 「今日」はJSTの暦日で、ポイントの移動は完了した取引だけを数える（テナントを分けている場合は全テナントの合計）
+type BusinessMetrics struct {
+	PointsInCirculation     int64     // 全ユーザーの残高の合計
+	IssuedToday             int64     // 今日、発行元（treasury）からユーザーへ付与したポイント
+	ConsumedToday           int64     // 今日、管理者の減算・商品交換でユーザーから発行元へ戻ったポイント
+	TransferredToday        int64     // 今日のユーザー間送金の合計額
+	PendingTransferRequests int64     // 承認待ち・送信者の確認待ちで期限内の送金リクエストの数
+	ActiveUsersToday        int64     // 今日操作があったセッションのユーザー数
+	CollectedAt             time.Time // 集計した日時
+}
+
+// BusinessMetricsDayStart はnowが属する日（JST）の開始時刻を返す
+func BusinessMetricsDayStart(now time.Time) time.Time {
+	t := now.In(businessMetricsLocation)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, businessMetricsLocation)
+}
@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// CartMaxItems はカートに入れられる商品の種類の上限
+	CartMaxItems = 20
+	// CartMaxQuantity は1商品あたりの数量の上限
+	CartMaxQuantity = 99
+)
+
+// CartItem はカートに入れた商品（ユーザーと商品ごとに1件で、同じ商品を追加すると数量を足す）
+// 価格はカートに入れた時点では確定せず、決済時の価格ルールで計算する
+type CartItem struct {
+	UserID    uuid.UUID
+	ProductID uuid.UUID
+	Quantity  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewCartItem は新しいカートの商品を作成
+func NewCartItem(userID, productID uuid.UUID, quantity int, now time.Time) (*CartItem, error) {
+	if quantity <= 0 || quantity > CartMaxQuantity {
+		return nil, ErrInvalidQuantity
+	}
+	return &CartItem{
+		UserID:    userID,
+		ProductID: productID,
+		Quantity:  quantity,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// AddQuantity は数量を足す（合計が CartMaxQuantity を超える場合はエラー）
+func (c *CartItem) AddQuantity(quantity int, now time.Time) error {
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	return c.SetQuantity(c.Quantity+quantity, now)
+}
+
+// SetQuantity は数量を変更
+func (c *CartItem) SetQuantity(quantity int, now time.Time) error {
+	if quantity <= 0 || quantity > CartMaxQuantity {
+		return ErrInvalidQuantity
+	}
+	c.Quantity = quantity
+	c.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// ContactDiscoveryMaxHashes は1回の友達検索で受け付けるハッシュの最大件数
+const ContactDiscoveryMaxHashes = 500
+
+// ContactMatchField は連絡先ハッシュが一致した項目
+type ContactMatchField string
+
+const (
+	ContactMatchEmail    ContactMatchField = "email"
+	ContactMatchUsername ContactMatchField = "username"
+)
+
+// ContactMatch は連絡先ハッシュと一致したユーザー
+type ContactMatch struct {
+	Hash      string
+	MatchedBy ContactMatchField
+	User      *User
+}
+
+// HashContactIdentifier はメールアドレス・ユーザー名を友達検索用にハッシュ化
+// クライアントと同じく前後の空白を除いて小文字化した値の SHA-256 を16進数で返す
+func HashContactIdentifier(identifier string) string {
+	return HashToken(strings.ToLower(strings.TrimSpace(identifier)))
+}
+
+// NormalizeContactHashes はクライアントから送られたハッシュを検証し、小文字化・重複除去して返す
+func NormalizeContactHashes(hashes []string) ([]string, error) {
+	if len(hashes) == 0 || len(hashes) > ContactDiscoveryMaxHashes {
+		return nil, ErrInvalidContactHashes
+	}
+
+	seen := make(map[string]bool, len(hashes))
+	normalized := make([]string, 0, len(hashes))
+	for _, h := range hashes {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if len(h) != 64 {
+			return nil, ErrInvalidContactHashes
+		}
+		if _, err := hex.DecodeString(h); err != nil {
+			return nil, ErrInvalidContactHashes
+		}
+		if seen[h] {
+			continue
+		}
+		seen[h] = true
+		normalized = append(normalized, h)
+	}
+	return normalized, nil
+}
+
+// MatchContactHashes はユーザーのメールアドレス・ユーザー名のハッシュが送られたハッシュに含まれるか判定
+// 両方一致する場合はメールアドレスを優先する
+func MatchContactHashes(user *User, hashes map[string]bool) (*ContactMatch, bool) {
+	if h := HashContactIdentifier(user.Email); hashes[h] {
+		return &ContactMatch{Hash: h, MatchedBy: ContactMatchEmail, User: user}, true
+	}
+	if h := HashContactIdentifier(user.Username); hashes[h] {
+		return &ContactMatch{Hash: h, MatchedBy: ContactMatchUsername, User: user}, true
+	}
+	return nil, false
+}
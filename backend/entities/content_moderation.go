@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// ModerationField はモデレーション対象のテキスト項目
+type ModerationField string
+
+const (
+	ModerationFieldDisplayName        ModerationField = "display_name"
+	ModerationFieldFirstName          ModerationField = "first_name"
+	ModerationFieldLastName           ModerationField = "last_name"
+	ModerationFieldTransferMessage    ModerationField = "transfer_message"
+	ModerationFieldProductName        ModerationField = "product_name"
+	ModerationFieldProductDescription ModerationField = "product_description"
+)
+
+// ModerationVerdict はテキストの判定結果
+type ModerationVerdict struct {
+	Allowed      bool
+	MatchedTerms []string // 不許可の根拠となった語（外部APIではカテゴリ名など）
+}
+
+// AllowedVerdict は問題なしの判定結果を返す
+func AllowedVerdict() *ModerationVerdict {
+	return &ModerationVerdict{Allowed: true}
+}
+
+// ContentViolation は拒否されたテキストの記録（管理者レビュー用）
+type ContentViolation struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID // 入力したユーザー（商品の場合は操作した管理者）
+	Field        ModerationField
+	Content      string
+	MatchedTerms []string
+	CreatedAt    time.Time
+	ReviewedAt   *time.Time
+	ReviewedBy   *uuid.UUID
+}
+
+// NewContentViolation は拒否されたテキストの記録を作成
+func NewContentViolation(userID uuid.UUID, field ModerationField, content string, matchedTerms []string) *ContentViolation {
+	return &ContentViolation{
+		ID:           uuid.New(),
+		UserID:       userID,
+		Field:        field,
+		Content:      content,
+		MatchedTerms: matchedTerms,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// IsReviewed はレビュー済みかを判定
+func (v *ContentViolation) IsReviewed() bool {
+	return v.ReviewedAt != nil
+}
+
+// MarkReviewed はレビュー済みにする
+func (v *ContentViolation) MarkReviewed(adminID uuid.UUID) {
+	now := time.Now()
+	v.ReviewedAt = &now
+	v.ReviewedBy = &adminID
+}
+
+// NormalizeForModeration は語句照合用にテキストを正規化する
+// 小文字化し、空白・記号を取り除いて「b a d」「b.a.d」のような回避を防ぐ
+func NormalizeForModeration(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// MatchModerationTerms は正規化済みテキストに含まれる禁止語を返す
+func MatchModerationTerms(text string, terms []string) []string {
+	normalized := NormalizeForModeration(text)
+	if normalized == "" {
+		return nil
+	}
+
+	var matched []string
+	for _, term := range terms {
+		t := NormalizeForModeration(term)
+		if t != "" && strings.Contains(normalized, t) {
+			matched = append(matched, term)
+		}
+	}
+	return matched
+}
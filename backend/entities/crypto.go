@@ -2,6 +2,7 @@ package entities
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 )
@@ -23,3 +24,9 @@ func GenerateSecureTokenHex(length int) (string, error) {
 	}
 	return hex.EncodeToString(bytes), nil
 }
+
+// HashToken はトークンをSHA-256でハッシュ化（DBにはハッシュのみ保存する用途）
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"crypto/subtle"
+	"time"
+)
+
+// CSRFRotationMode はCSRFトークンを入れ替える方法
+type CSRFRotationMode string
+
+const (
+	CSRFRotationOff        CSRFRotationMode = "off"         // セッションの間は同じトークンを使う
+	CSRFRotationPerRequest CSRFRotationMode = "per_request" // 状態を変えるリクエストのたびに入れ替える
+	CSRFRotationPeriodic   CSRFRotationMode = "periodic"    // 一定時間で期限切れにし、GET /api/auth/csrf で入れ替える
+)
+
+// CSRFRotationModes は設定できる入れ替えの方法
+var CSRFRotationModes = []CSRFRotationMode{CSRFRotationOff, CSRFRotationPerRequest, CSRFRotationPeriodic}
+
+// CSRFPolicy はCSRFトークンの入れ替えの設定
+type CSRFPolicy struct {
+	Rotation CSRFRotationMode
+	TTL      time.Duration // periodicのときのトークンの有効期間
+	Grace    time.Duration // 入れ替えた後も前のトークンを受け付ける期間（入れ替えと並行したリクエストのため）
+}
+
+// ExpiresAt はトークンの有効期限（期限がなければfalse）
+func (p CSRFPolicy) ExpiresAt(issuedAt time.Time) (time.Time, bool) {
+	if p.Rotation != CSRFRotationPeriodic || p.TTL <= 0 {
+		return time.Time{}, false
+	}
+	return issuedAt.Add(p.TTL), true
+}
+
+// Expired はトークンが期限切れか
+func (p CSRFPolicy) Expired(issuedAt, now time.Time) bool {
+	expiresAt, ok := p.ExpiresAt(issuedAt)
+	return ok && !now.Before(expiresAt)
+}
+
+// csrfTokenEqual はトークンを比較（比較にかかる時間からトークンを推測されないよう定数時間で比べる）
+func csrfTokenEqual(a, b string) bool {
+	return a != "" && subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
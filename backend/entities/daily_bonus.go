@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"math"
 	"strings"
 	"time"
 
@@ -14,7 +15,7 @@ const DefaultAkerunBonusPoints int64 = 5
 type DailyBonus struct {
 	ID              uuid.UUID
 	UserID          uuid.UUID
-	BonusDate       time.Time // JST AM6:00区切りの日付
+	BonusDate       time.Time // ボーナス日の区切り（既定はAsia/Tokyo AM6:00）で決めた日付
 	BonusPoints     int64
 	AkerunAccessID  string
 	AkerunUserName  string
@@ -24,6 +25,12 @@ type DailyBonus struct {
 	IsViewed        bool
 	IsDrawn         bool
 	CreatedAt       time.Time
+
+	// 入退室したAkerun組織とドア（複数組織・複数ドアの場合にどこで付与されたかを残す）
+	AkerunOrganizationID string
+	AkerunDoorID         string
+	AkerunDoorName       string
+	PointsMultiplier     float64 // ドアの規則による倍率（抽選で決まったポイントに掛ける）
 }
 
 // NewDailyBonus は新しいDailyBonusを作成
@@ -41,6 +48,8 @@ func NewDailyBonus(userID uuid.UUID, bonusDate time.Time, bonusPoints int64, ake
 		IsViewed:        false,
 		IsDrawn:         true,
 		CreatedAt:       time.Now(),
+
+		PointsMultiplier: 1,
 	}
 }
 
@@ -59,21 +68,71 @@ func NewPendingDailyBonus(userID uuid.UUID, bonusDate time.Time, akerunAccessID,
 		IsViewed:        false,
 		IsDrawn:         false,
 		CreatedAt:       time.Now(),
+
+		PointsMultiplier: 1,
+	}
+}
+
+// WithDoor は入退室した組織・ドアとドアの規則の倍率を設定する
+func (b *DailyBonus) WithDoor(access AccessRecord, rule AkerunDoorRule) *DailyBonus {
+	b.AkerunOrganizationID = access.OrganizationID
+	b.AkerunDoorID = access.DoorID
+	b.AkerunDoorName = access.DoorName
+	b.PointsMultiplier = rule.PointsMultiplier()
+	return b
+}
+
+// ApplyMultiplier は抽選で決まったポイントにドアの倍率を掛ける（端数は四捨五入）
+func (b *DailyBonus) ApplyMultiplier(points int64) int64 {
+	if b.PointsMultiplier <= 0 || b.PointsMultiplier == 1 {
+		return points
+	}
+	return int64(math.Round(float64(points) * b.PointsMultiplier))
+}
+
+const (
+	// DefaultBonusTimezone はボーナス日の区切りに使う既定のタイムゾーン
+	DefaultBonusTimezone = "Asia/Tokyo"
+	// BonusTimezoneSettingKey はボーナス日の区切りに使うタイムゾーンを保存するsystem_settingsのキー
+	BonusTimezoneSettingKey = "bonus_timezone"
+	// BonusDayStartHour はボーナス日が切り替わる現地時刻（時）
+	BonusDayStartHour = 6
+)
+
+// LoadBonusLocation はボーナス日の区切りに使うIANAタイムゾーンを読み込む（空なら既定のタイムゾーン）
+func LoadBonusLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return DefaultBonusLocation(), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	return loc, nil
+}
+
+// DefaultBonusLocation は既定のタイムゾーンを返す
+// タイムゾーンデータベースがない環境ではJST（UTC+9固定）を使う
+func DefaultBonusLocation() *time.Location {
+	if loc, err := time.LoadLocation(DefaultBonusTimezone); err == nil {
+		return loc
 	}
+	return time.FixedZone("JST", 9*60*60)
 }
 
-// GetBonusDateJST はJST AM6:00区切りでボーナス対象日を計算する
-// AM6:00より前の場合は前日扱い
-func GetBonusDateJST(t time.Time) time.Time {
-	jst := time.FixedZone("JST", 9*60*60)
-	tJST := t.In(jst)
+// GetBonusDate はlocの現地時刻AM6:00区切りでボーナス対象日を計算する
+// AM6:00より前の場合は前日扱い。夏時間の切り替えがあっても現地の時計で判定する
+// 夏時間の切り替えで現地の0:00が存在しない日があるため、日付はUTCの0:00で返す（DATE列の読み出しと同じ表現）
+func GetBonusDate(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	year, month, day := local.Date()
 
-	// AM6:00より前なら前日扱い
-	if tJST.Hour() < 6 {
-		tJST = tJST.AddDate(0, 0, -1)
+	// AM6:00より前なら前日扱い（time.Dateが月・年の繰り下がりを正規化する）
+	if local.Hour() < BonusDayStartHour {
+		day--
 	}
 
-	return time.Date(tJST.Year(), tJST.Month(), tJST.Day(), 0, 0, 0, 0, jst)
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
 }
 
 // NormalizeName はAkerunユーザー名を正規化する（全角/半角スペース除去、小文字化）
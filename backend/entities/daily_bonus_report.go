@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DailyBonusReportMaxRange はボーナスの出力で指定できる期間の上限
+const DailyBonusReportMaxRange = 366 * 24 * time.Hour
+
+// DailyBonusRecord はレポート用にユーザー名を付けたデイリーボーナス
+type DailyBonusRecord struct {
+	DailyBonus
+	Username    string
+	DisplayName string
+}
+
+// BonusReportPeriod はボーナス日で区切った出力の期間（[From, To)、どちらもUTCの0時）
+// ボーナス日はタイムゾーンで区切った後の日付のため、期間もタイムゾーンを持たない日付で扱う
+type BonusReportPeriod struct {
+	From time.Time
+	To   time.Time
+}
+
+// NewBonusReportPeriod はYYYY-MM-DDの開始日・終了日（終了日を含む）から期間を作成
+func NewBonusReportPeriod(from, to string) (BonusReportPeriod, error) {
+	f, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return BonusReportPeriod{}, ErrInvalidBonusReportQuery
+	}
+	t, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return BonusReportPeriod{}, ErrInvalidBonusReportQuery
+	}
+	p := BonusReportPeriod{From: f, To: t.AddDate(0, 0, 1)}
+	if !p.From.Before(p.To) || p.To.Sub(p.From) > DailyBonusReportMaxRange {
+		return BonusReportPeriod{}, ErrInvalidBonusReportQuery
+	}
+	return p, nil
+}
+
+// NewBonusReportMonth はYYYY-MMの年月の期間を作成
+func NewBonusReportMonth(month string) (BonusReportPeriod, error) {
+	f, err := time.Parse("2006-01", month)
+	if err != nil {
+		return BonusReportPeriod{}, ErrInvalidBonusReportQuery
+	}
+	return BonusReportPeriod{From: f, To: f.AddDate(0, 1, 0)}, nil
+}
+
+// Days は期間の日数
+func (p BonusReportPeriod) Days() int {
+	return int(p.To.Sub(p.From).Hours() / 24)
+}
+
+// AttendanceRow は出席表の1ユーザー分（日ごとの値は期間の初日からの順）
+type AttendanceRow struct {
+	UserID       uuid.UUID
+	Username     string
+	DisplayName  string
+	Attended     []bool  // ボーナスを受け取った日
+	Points       []int64 // その日のボーナスのポイント（未抽選なら0）
+	AttendedDays int
+	TotalPoints  int64
+}
+
+// AttendanceMatrix はユーザーごと・日ごとの出席表（ボーナスを受け取った日を出席とみなす）
+type AttendanceMatrix struct {
+	Period BonusReportPeriod
+	Rows   []*AttendanceRow // ユーザー名の順（出席した日がないユーザーは含まない）
+
+	byUser map[uuid.UUID]*AttendanceRow
+}
+
+// NewAttendanceMatrix は期間の空の出席表を作成
+func NewAttendanceMatrix(period BonusReportPeriod) *AttendanceMatrix {
+	return &AttendanceMatrix{Period: period, Rows: []*AttendanceRow{}, byUser: make(map[uuid.UUID]*AttendanceRow)}
+}
+
+// Add はボーナスを出席として加える（期間外のボーナスは無視する）
+func (m *AttendanceMatrix) Add(r *DailyBonusRecord) {
+	day := int(r.BonusDate.Sub(m.Period.From).Hours() / 24)
+	if r.BonusDate.Before(m.Period.From) || day >= m.Period.Days() {
+		return
+	}
+
+	row, ok := m.byUser[r.UserID]
+	if !ok {
+		days := m.Period.Days()
+		row = &AttendanceRow{
+			UserID:      r.UserID,
+			Username:    r.Username,
+			DisplayName: r.DisplayName,
+			Attended:    make([]bool, days),
+			Points:      make([]int64, days),
+		}
+		m.byUser[r.UserID] = row
+		i := sort.Search(len(m.Rows), func(i int) bool { return m.Rows[i].Username > r.Username })
+		m.Rows = slices.Insert(m.Rows, i, row)
+	}
+
+	if !row.Attended[day] {
+		row.Attended[day] = true
+		row.AttendedDays++
+	}
+	row.Points[day] += r.BonusPoints
+	row.TotalPoints += r.BonusPoints
+}
@@ -0,0 +1,158 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DataExportStatus は個人データエクスポートの状態
+type DataExportStatus string
+
+const (
+	DataExportStatusPending    DataExportStatus = "pending"    // ワーカーの処理待ち
+	DataExportStatusProcessing DataExportStatus = "processing" // 作成中
+	DataExportStatusReady      DataExportStatus = "ready"      // ダウンロード可能
+	DataExportStatusFailed     DataExportStatus = "failed"
+	DataExportStatusExpired    DataExportStatus = "expired" // 保存期間を過ぎてファイルを削除済み
+)
+
+const (
+	// DataExportRetention はエクスポートファイルを保存しておく期間
+	DataExportRetention = 7 * 24 * time.Hour
+	// DataExportCooldown は次のエクスポートを依頼できるまでの間隔
+	DataExportCooldown = 24 * time.Hour
+)
+
+// DataExport はユーザーの個人データエクスポート依頼
+// 依頼を受けるとワーカーが非同期でZIPを作成し、完了をメールで知らせる
+type DataExport struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	Status       DataExportStatus
+	FilePath     string
+	ErrorMessage string
+	RequestedAt  time.Time
+	CompletedAt  *time.Time
+	ExpiresAt    *time.Time
+}
+
+// NewDataExport は新しいエクスポート依頼を作成
+func NewDataExport(userID uuid.UUID) *DataExport {
+	return &DataExport{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Status:      DataExportStatusPending,
+		RequestedAt: time.Now(),
+	}
+}
+
+// IsInProgress は処理待ちまたは作成中かどうか
+func (e *DataExport) IsInProgress() bool {
+	return e.Status == DataExportStatusPending || e.Status == DataExportStatusProcessing
+}
+
+// CanRequestNext は前回の依頼から次の依頼を受け付けられるかどうか
+// 失敗した場合はすぐにやり直せる
+func (e *DataExport) CanRequestNext(now time.Time) bool {
+	if e.IsInProgress() {
+		return false
+	}
+	if e.Status == DataExportStatusFailed {
+		return true
+	}
+	return !now.Before(e.RequestedAt.Add(DataExportCooldown))
+}
+
+// IsDownloadable はファイルをダウンロードできるかどうか
+func (e *DataExport) IsDownloadable(now time.Time) bool {
+	return e.Status == DataExportStatusReady && e.ExpiresAt != nil && now.Before(*e.ExpiresAt)
+}
+
+// MarkReady は作成完了に更新
+func (e *DataExport) MarkReady(filePath string, now time.Time) {
+	expiresAt := now.Add(DataExportRetention)
+	e.Status = DataExportStatusReady
+	e.FilePath = filePath
+	e.CompletedAt = &now
+	e.ExpiresAt = &expiresAt
+}
+
+// MarkFailed は作成失敗に更新
+func (e *DataExport) MarkFailed(message string, now time.Time) {
+	e.Status = DataExportStatusFailed
+	e.ErrorMessage = message
+	e.CompletedAt = &now
+}
+
+// MarkExpired は保存期間を過ぎてファイルを削除したことを記録
+func (e *DataExport) MarkExpired() {
+	e.Status = DataExportStatusExpired
+	e.FilePath = ""
+}
+
+// PersonalDataExport はエクスポートファイル（data.json）の内容
+type PersonalDataExport struct {
+	ExportedAt   time.Time                 `json:"exported_at"`
+	Profile      ExportedProfile           `json:"profile"`
+	Transactions []ExportedTransaction     `json:"transactions"`
+	DailyBonuses []ExportedDailyBonus      `json:"daily_bonuses"`
+	Friendships  []ExportedFriendship      `json:"friendships"`
+	Exchanges    []ExportedProductExchange `json:"exchanges"`
+}
+
+// ExportedProfile はエクスポートするプロフィール
+type ExportedProfile struct {
+	ID            uuid.UUID `json:"id"`
+	Username      string    `json:"username"`
+	Email         string    `json:"email"`
+	DisplayName   string    `json:"display_name"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	Balance       int64     `json:"balance"`
+	Role          string    `json:"role"`
+	EmailVerified bool      `json:"email_verified"`
+	AvatarURL     *string   `json:"avatar_url,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ExportedTransaction はエクスポートする取引（相手は表示名のみ）
+type ExportedTransaction struct {
+	ID           uuid.UUID  `json:"id"`
+	Type         string     `json:"type"`
+	Status       string     `json:"status"`
+	Direction    string     `json:"direction"` // "in" | "out"
+	Amount       int64      `json:"amount"`
+	Counterparty string     `json:"counterparty,omitempty"`
+	Description  string     `json:"description"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+}
+
+// ExportedDailyBonus はエクスポートするデイリーボーナス
+type ExportedDailyBonus struct {
+	BonusDate       time.Time `json:"bonus_date"`
+	BonusPoints     int64     `json:"bonus_points"`
+	LotteryTierName string    `json:"lottery_tier_name,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// ExportedFriendship はエクスポートする友達関係
+type ExportedFriendship struct {
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ExportedProductExchange はエクスポートする商品交換
+type ExportedProductExchange struct {
+	ID         uuid.UUID `json:"id"`
+	ProductID  uuid.UUID `json:"product_id"`
+	Quantity   int       `json:"quantity"`
+	PointsUsed int64     `json:"points_used"`
+	Status     string    `json:"status"`
+	Notes      string    `json:"notes,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
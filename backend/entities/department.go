@@ -0,0 +1,141 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DepartmentNameMaxLength は部署名の最大文字数
+const DepartmentNameMaxLength = 100
+
+// Department は組織・部署（親を持たないものが最上位の組織）
+type Department struct {
+	ID       uuid.UUID
+	Name     string
+	ParentID *uuid.UUID
+	// MonthlyGrantBudget は所属ユーザーへの管理者付与の月間上限（nilなら上限なし）
+	MonthlyGrantBudget *int64
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// NewDepartment は新しい部署を作成
+func NewDepartment(name string, parentID *uuid.UUID, monthlyGrantBudget *int64) (*Department, error) {
+	now := time.Now()
+	d := &Department{
+		ID:        uuid.New(),
+		CreatedAt: now,
+	}
+	if err := d.Update(name, parentID, monthlyGrantBudget); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Update は部署名・親・月間予算を更新（親の循環チェックはValidateDepartmentParentで行う）
+func (d *Department) Update(name string, parentID *uuid.UUID, monthlyGrantBudget *int64) error {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > DepartmentNameMaxLength {
+		return ErrInvalidDepartment
+	}
+	if parentID != nil && *parentID == d.ID {
+		return ErrInvalidDepartment
+	}
+	if monthlyGrantBudget != nil && *monthlyGrantBudget < 0 {
+		return ErrInvalidDepartment
+	}
+
+	d.Name = name
+	d.ParentID = parentID
+	d.MonthlyGrantBudget = monthlyGrantBudget
+	d.UpdatedAt = time.Now()
+	return nil
+}
+
+// CheckGrantBudget は当月の付与済み合計usedにamountを加えても月間予算に収まるかを確認
+func (d *Department) CheckGrantBudget(used, amount int64) error {
+	if d.MonthlyGrantBudget == nil {
+		return nil
+	}
+	if used+amount > *d.MonthlyGrantBudget {
+		return ErrDepartmentOverBudget.WithParams(map[string]interface{}{
+			"budget":    *d.MonthlyGrantBudget,
+			"remaining": max(*d.MonthlyGrantBudget-used, 0),
+		})
+	}
+	return nil
+}
+
+// DepartmentBudgetPeriodStart は月間予算の集計開始日時（当月1日の0時）
+func DepartmentBudgetPeriodStart(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+}
+
+// ValidateDepartmentParent はidの部署の親をparentIDにしても階層が循環しないかを確認
+// allには全部署を渡す。parentIDが存在しない場合はErrDepartmentNotFound
+func ValidateDepartmentParent(all []*Department, id uuid.UUID, parentID *uuid.UUID) error {
+	if parentID == nil {
+		return nil
+	}
+	byID := make(map[uuid.UUID]*Department, len(all))
+	for _, d := range all {
+		byID[d.ID] = d
+	}
+	if _, ok := byID[*parentID]; !ok {
+		return ErrDepartmentNotFound
+	}
+
+	// 新しい親から上にたどって自分に戻ってきたら循環（件数で打ち切って既存データの循環にも備える）
+	current := parentID
+	for steps := 0; current != nil && steps <= len(all); steps++ {
+		if *current == id {
+			return ErrInvalidDepartment
+		}
+		parent, ok := byID[*current]
+		if !ok {
+			break
+		}
+		current = parent.ParentID
+	}
+	return nil
+}
+
+// DepartmentSubtreeIDs はrootIDの部署とその配下すべての部署IDを返す（絞り込み用）
+func DepartmentSubtreeIDs(all []*Department, rootID uuid.UUID) []uuid.UUID {
+	children := make(map[uuid.UUID][]uuid.UUID, len(all))
+	for _, d := range all {
+		if d.ParentID != nil {
+			children[*d.ParentID] = append(children[*d.ParentID], d.ID)
+		}
+	}
+
+	ids := []uuid.UUID{rootID}
+	seen := map[uuid.UUID]bool{rootID: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids
+}
+
+// DepartmentAncestorIDs はidの部署とその上位すべての部署IDを近い順に返す（部署単位の予算の判定用）
+func DepartmentAncestorIDs(all []*Department, id uuid.UUID) []uuid.UUID {
+	byID := make(map[uuid.UUID]*Department, len(all))
+	for _, d := range all {
+		byID[d.ID] = d
+	}
+
+	ids := []uuid.UUID{id}
+	current, ok := byID[id]
+	for steps := 0; ok && current.ParentID != nil && steps < len(all); steps++ {
+		ids = append(ids, *current.ParentID)
+		current, ok = byID[*current.ParentID]
+	}
+	return ids
+}
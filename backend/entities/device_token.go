@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DevicePlatform はプッシュ通知の配信先プラットフォーム
+type DevicePlatform string
+
+const (
+	DevicePlatformIOS     DevicePlatform = "ios"     // APNs
+	DevicePlatformAndroid DevicePlatform = "android" // FCM
+)
+
+const (
+	// DeviceTokenMaxLength はデバイストークンの最大長（FCMトークンは約160文字、APNsは64文字）
+	DeviceTokenMaxLength = 4096
+	// DeviceTokensPerUserMax はユーザーごとに登録できる端末数の上限（超えた分は古い順に削除）
+	DeviceTokensPerUserMax = 10
+)
+
+// DeviceToken はプッシュ通知を受け取る端末の登録情報
+// 同じトークンは1ユーザーにだけ紐づく（別ユーザーでログインし直した端末は付け替える）
+type DeviceToken struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Platform   DevicePlatform
+	Token      string
+	CreatedAt  time.Time
+	LastSeenAt time.Time // 最後に登録（アプリ起動時の再登録を含む）された日時
+}
+
+// NewDeviceToken は新しいデバイストークンを作成
+func NewDeviceToken(userID uuid.UUID, platform DevicePlatform, token string) (*DeviceToken, error) {
+	if platform != DevicePlatformIOS && platform != DevicePlatformAndroid {
+		return nil, ErrInvalidDeviceToken
+	}
+	token = strings.TrimSpace(token)
+	if token == "" || len(token) > DeviceTokenMaxLength || strings.ContainsAny(token, " \t\r\n") {
+		return nil, ErrInvalidDeviceToken
+	}
+
+	now := time.Now()
+	return &DeviceToken{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Platform:   platform,
+		Token:      token,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}, nil
+}
@@ -0,0 +1,215 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EarningTrigger はポイント獲得ルールのきっかけとなる出来事
+type EarningTrigger string
+
+const (
+	EarningTriggerProfileCompleted EarningTrigger = "profile_completed" // 表示名・氏名・アバター画像がそろった
+	EarningTriggerFriendAdded      EarningTrigger = "friend_added"      // 友達になった（申請した側・承認した側の両方）
+	EarningTriggerTransferSent     EarningTrigger = "transfer_sent"     // ポイントを送った
+)
+
+// IsValid は定義済みのきっかけかどうか
+func (t EarningTrigger) IsValid() bool {
+	switch t {
+	case EarningTriggerProfileCompleted, EarningTriggerFriendAdded, EarningTriggerTransferSent:
+		return true
+	}
+	return false
+}
+
+// HasAmount は出来事に金額があるか（MinAmountの条件を指定できるか）
+func (t EarningTrigger) HasAmount() bool {
+	return t == EarningTriggerTransferSent
+}
+
+const (
+	// EarningRuleNameMaxLength はポイント獲得ルール名の最大文字数
+	EarningRuleNameMaxLength = 100
+	// EarningRuleMaxPoints は1回の獲得で付与できるポイントの上限
+	EarningRuleMaxPoints int64 = 10000
+)
+
+// EarningEvent は各機能のユースケースが発行する、ポイント獲得ルールの判定対象となる出来事
+type EarningEvent struct {
+	Trigger    EarningTrigger
+	UserID     uuid.UUID
+	Amount     int64  // 送金額など（金額のない出来事は0）
+	SourceID   string // 出来事の発生元（送金の取引IDなど）。同じ発生元では同じルールで1回だけ付与する
+	OccurredAt time.Time
+}
+
+// NewEarningEvent は現在時刻の出来事を作成
+func NewEarningEvent(trigger EarningTrigger, userID uuid.UUID, amount int64, sourceID string) *EarningEvent {
+	return &EarningEvent{
+		Trigger:    trigger,
+		UserID:     userID,
+		Amount:     amount,
+		SourceID:   sourceID,
+		OccurredAt: time.Now(),
+	}
+}
+
+// EarningRule は管理者が設定する「Xをしたらポイントを付与する」ルール
+// Occurrenceを指定するとユーザーにとってN回目の出来事だけを対象にし（例: 初めての友達、10回目の送金）、
+// 付与回数はユーザーごと（OncePerUser・MaxGrantsPerUser）とルール全体（MaxGrantsTotal）で制限できる
+type EarningRule struct {
+	ID               uuid.UUID
+	Name             string
+	Trigger          EarningTrigger
+	Points           int64
+	Occurrence       int64      // ユーザーにとって何回目の出来事を対象にするか（0なら毎回）
+	MinAmount        int64      // 出来事の金額の下限（金額のあるきっかけのみ、0なら条件なし）
+	OncePerUser      bool       // 1人1回だけ付与する
+	MaxGrantsPerUser int        // 1人に付与できる回数（0なら無制限、OncePerUserなら1）
+	MaxGrantsTotal   int        // ルール全体で付与できる回数（0なら無制限）
+	GrantCount       int        // 付与済みの回数
+	StartsAt         time.Time  // この日時以降の出来事が対象
+	EndsAt           *time.Time // nilなら無期限
+	IsActive         bool
+	CreatedBy        uuid.UUID
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// NewEarningRule は新しいポイント獲得ルールを作成
+func NewEarningRule(name string, trigger EarningTrigger, points, occurrence, minAmount int64, oncePerUser bool, maxGrantsPerUser, maxGrantsTotal int, startsAt time.Time, endsAt *time.Time, createdBy uuid.UUID) (*EarningRule, error) {
+	now := time.Now()
+	r := &EarningRule{
+		ID:        uuid.New(),
+		CreatedBy: createdBy,
+		CreatedAt: now,
+	}
+	if err := r.Update(name, trigger, points, occurrence, minAmount, oncePerUser, maxGrantsPerUser, maxGrantsTotal, startsAt, endsAt, true); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Update はポイント獲得ルールの内容を検証して更新（付与済みの回数は変えない）
+func (r *EarningRule) Update(name string, trigger EarningTrigger, points, occurrence, minAmount int64, oncePerUser bool, maxGrantsPerUser, maxGrantsTotal int, startsAt time.Time, endsAt *time.Time, isActive bool) error {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > EarningRuleNameMaxLength {
+		return ErrInvalidEarningRule
+	}
+	if !trigger.IsValid() {
+		return ErrInvalidEarningRule
+	}
+	if points < 1 || points > EarningRuleMaxPoints {
+		return ErrInvalidEarningRule
+	}
+	if occurrence < 0 || minAmount < 0 || maxGrantsPerUser < 0 || maxGrantsTotal < 0 {
+		return ErrInvalidEarningRule
+	}
+	if minAmount > 0 && !trigger.HasAmount() {
+		return ErrInvalidEarningRule
+	}
+	if oncePerUser && maxGrantsPerUser > 1 {
+		return ErrInvalidEarningRule
+	}
+	if startsAt.IsZero() {
+		startsAt = time.Now()
+	}
+	if endsAt != nil && !endsAt.After(startsAt) {
+		return ErrInvalidEarningRule
+	}
+
+	r.Name = name
+	r.Trigger = trigger
+	r.Points = points
+	r.Occurrence = occurrence
+	r.MinAmount = minAmount
+	r.OncePerUser = oncePerUser
+	r.MaxGrantsPerUser = maxGrantsPerUser
+	r.MaxGrantsTotal = maxGrantsTotal
+	r.StartsAt = startsAt
+	r.EndsAt = endsAt
+	r.IsActive = isActive
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// PerUserLimit は1人に付与できる回数（0なら無制限）
+func (r *EarningRule) PerUserLimit() int {
+	if r.OncePerUser {
+		return 1
+	}
+	return r.MaxGrantsPerUser
+}
+
+// NeedsOccurrence は判定にユーザーの出来事の回数が必要か
+func (r *EarningRule) NeedsOccurrence() bool {
+	return r.Occurrence > 0
+}
+
+// Matches は出来事がこのルールの条件を満たすかを判定
+// occurrence はユーザーにとって何回目の出来事か（NeedsOccurrenceでなければ使わない）
+// 付与回数の上限は付与するトランザクション内で確認する
+func (r *EarningRule) Matches(event *EarningEvent, occurrence int64) bool {
+	if !r.IsActive || r.Trigger != event.Trigger {
+		return false
+	}
+	if event.OccurredAt.Before(r.StartsAt) {
+		return false
+	}
+	if r.EndsAt != nil && !event.OccurredAt.Before(*r.EndsAt) {
+		return false
+	}
+	if r.MinAmount > 0 && event.Amount < r.MinAmount {
+		return false
+	}
+	if r.NeedsOccurrence() && occurrence != r.Occurrence {
+		return false
+	}
+	return true
+}
+
+// TransactionMetadata は付与の取引のメタデータ
+func (r *EarningRule) TransactionMetadata(event *EarningEvent) map[string]interface{} {
+	return map[string]interface{}{
+		"earning_rule_id":   r.ID.String(),
+		"earning_rule_name": r.Name,
+		"trigger":           string(event.Trigger),
+		"source_id":         event.SourceID,
+	}
+}
+
+// EarningRuleGrant はポイント獲得ルールによる付与の記録
+type EarningRuleGrant struct {
+	ID            uuid.UUID
+	RuleID        uuid.UUID
+	UserID        uuid.UUID
+	TransactionID uuid.UUID
+	Points        int64
+	SourceID      string
+	CreatedAt     time.Time
+}
+
+// NewEarningRuleGrant は付与の記録を作成
+func NewEarningRuleGrant(rule *EarningRule, event *EarningEvent, transactionID uuid.UUID) *EarningRuleGrant {
+	return &EarningRuleGrant{
+		ID:            uuid.New(),
+		RuleID:        rule.ID,
+		UserID:        event.UserID,
+		TransactionID: transactionID,
+		Points:        rule.Points,
+		SourceID:      event.SourceID,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// EarningRuleStats はポイント獲得ルールごとの付与の実績
+type EarningRuleStats struct {
+	RuleID        uuid.UUID
+	GrantCount    int64      // 付与した回数
+	UserCount     int64      // 付与したユーザー数
+	PointsGranted int64      // 付与したポイントの合計
+	LastGrantedAt *time.Time // 最後に付与した日時（未付与ならnil）
+}
@@ -0,0 +1,263 @@
+package entities
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailTemplateKey は送信するメールの種類
+type EmailTemplateKey string
+
+const (
+	EmailTemplateVerification    EmailTemplateKey = "verification"
+	EmailTemplateEmailChange     EmailTemplateKey = "email_change_confirmation"
+	EmailTemplatePasswordChanged EmailTemplateKey = "password_changed"
+	EmailTemplateAccountDeleted  EmailTemplateKey = "account_deleted"
+	EmailTemplateAccountLocked   EmailTemplateKey = "account_locked"
+	EmailTemplateNewLogin        EmailTemplateKey = "new_login"
+	EmailTemplateNotification    EmailTemplateKey = "notification"
+	EmailTemplateDataExportReady EmailTemplateKey = "data_export_ready"
+	EmailTemplateUserInvitation  EmailTemplateKey = "user_invitation"
+)
+
+const (
+	emailTemplateSubjectMaxLength = 200
+	emailTemplateBodyMaxLength    = 20000
+)
+
+// EmailTemplate は管理者が編集したメールのテンプレート
+// 保存するたびに新しい版を作り、最新の版を送信に使う（版がなければ組み込みの既定を使う）
+// 件名・本文はGoのテンプレート（{{.Token}} など）で、使える変数はメールの種類ごとに決まっている
+type EmailTemplate struct {
+	ID        uuid.UUID
+	Key       EmailTemplateKey
+	Version   int // 1から。組み込みの既定は0
+	Subject   string
+	HTMLBody  string // 任意（空ならテキストのみのメール）
+	TextBody  string
+	CreatedBy *uuid.UUID
+	CreatedAt time.Time
+}
+
+// RenderedEmail は変数を埋め込んだメール
+type RenderedEmail struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// EmailTemplateDefinition はメールの種類ごとの説明・変数・組み込みの既定
+type EmailTemplateDefinition struct {
+	Key       EmailTemplateKey
+	Name      string            // 管理画面とコンソール出力の見出し
+	Variables map[string]string // 変数名と、プレビュー・テスト送信に使う例
+	Subject   string
+	TextBody  string
+}
+
+// NewEmailTemplate はテンプレートの新しい版を作成
+// 件名とテキスト本文は必須で、例の変数で描画できなければErrInvalidEmailTemplate
+func NewEmailTemplate(key EmailTemplateKey, version int, subject, htmlBody, textBody string, createdBy *uuid.UUID) (*EmailTemplate, error) {
+	def, ok := LookupEmailTemplateDefinition(key)
+	if !ok {
+		return nil, ErrEmailTemplateNotFound
+	}
+	t := &EmailTemplate{
+		ID:        uuid.New(),
+		Key:       key,
+		Version:   version,
+		Subject:   strings.TrimSpace(subject),
+		HTMLBody:  strings.TrimSpace(htmlBody),
+		TextBody:  strings.TrimSpace(textBody),
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+	if t.Subject == "" || len([]rune(t.Subject)) > emailTemplateSubjectMaxLength {
+		return nil, invalidEmailTemplate("subject must be 1-200 characters")
+	}
+	if t.TextBody == "" || len([]rune(t.TextBody)) > emailTemplateBodyMaxLength || len([]rune(t.HTMLBody)) > emailTemplateBodyMaxLength {
+		return nil, invalidEmailTemplate("text body is required and bodies must be at most 20000 characters")
+	}
+	if _, err := t.Render(def.Variables); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Render は変数を埋め込む（HTML本文の変数はエスケープする）
+// 定義されていない変数を使っているとErrInvalidEmailTemplate
+func (t *EmailTemplate) Render(vars map[string]string) (*RenderedEmail, error) {
+	subject, err := renderText("subject", t.Subject, vars)
+	if err != nil {
+		return nil, err
+	}
+	text, err := renderText("text", t.TextBody, vars)
+	if err != nil {
+		return nil, err
+	}
+	rendered := &RenderedEmail{
+		// 件名に改行が入るとヘッダーが壊れるため1行にする
+		Subject: strings.Join(strings.Fields(subject), " "),
+		Text:    text,
+	}
+	if t.HTMLBody != "" {
+		tmpl, err := htmltemplate.New("html").Option("missingkey=error").Parse(t.HTMLBody)
+		if err != nil {
+			return nil, invalidEmailTemplate(err.Error())
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, vars); err != nil {
+			return nil, invalidEmailTemplate(err.Error())
+		}
+		rendered.HTML = buf.String()
+	}
+	return rendered, nil
+}
+
+// IsBuiltIn は組み込みの既定かどうか
+func (t *EmailTemplate) IsBuiltIn() bool {
+	return t.Version == 0
+}
+
+// Default は組み込みの既定のテンプレート
+func (d *EmailTemplateDefinition) Default() *EmailTemplate {
+	return &EmailTemplate{Key: d.Key, Subject: d.Subject, TextBody: d.TextBody}
+}
+
+// EmailTemplateDefinitions はメールの種類の一覧
+func EmailTemplateDefinitions() []*EmailTemplateDefinition {
+	return emailTemplateDefinitions
+}
+
+// LookupEmailTemplateDefinition はメールの種類の定義を取得
+func LookupEmailTemplateDefinition(key EmailTemplateKey) (*EmailTemplateDefinition, bool) {
+	for _, d := range emailTemplateDefinitions {
+		if d.Key == key {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
+func renderText(name, source string, vars map[string]string) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return "", invalidEmailTemplate(err.Error())
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", invalidEmailTemplate(err.Error())
+	}
+	return buf.String(), nil
+}
+
+func invalidEmailTemplate(reason string) error {
+	return ErrInvalidEmailTemplate.WithParams(map[string]interface{}{"reason": reason})
+}
+
+// emailTemplateDefinitions は組み込みの既定（管理者が編集するまではこの文面で送る）
+var emailTemplateDefinitions = []*EmailTemplateDefinition{
+	{
+		Key:       EmailTemplateVerification,
+		Name:      "メール認証",
+		Variables: map[string]string{"Token": "example-token"},
+		Subject:   "メールアドレスの認証",
+		TextBody: `以下のリンクをクリックしてメールアドレスを認証してください：
+http://localhost:3000/verify-email?token={{.Token}}
+
+このリンクは24時間有効です。`,
+	},
+	{
+		Key:       EmailTemplateEmailChange,
+		Name:      "メールアドレス変更の確認",
+		Variables: map[string]string{"NewEmail": "new@example.com", "Token": "example-token"},
+		Subject:   "メールアドレス変更の確認",
+		TextBody: `あなたのアカウントのメールアドレスを {{.NewEmail}} に変更する申請がありました。
+以下のリンクをクリックして変更を承認してください：
+http://localhost:3000/verify-email?token={{.Token}}
+
+新しいアドレスでの認証と、このアドレスでの承認の両方が完了すると変更が反映されます。
+もしこの変更に覚えがない場合は、すぐにパスワードを変更してください。`,
+	},
+	{
+		Key:       EmailTemplatePasswordChanged,
+		Name:      "パスワード変更通知",
+		Variables: map[string]string{},
+		Subject:   "パスワードが変更されました",
+		TextBody: `あなたのアカウントのパスワードが変更されました。
+
+もしこの変更に覚えがない場合は、すぐにサポートに連絡してください。`,
+	},
+	{
+		Key:       EmailTemplateAccountDeleted,
+		Name:      "アカウント削除通知",
+		Variables: map[string]string{},
+		Subject:   "アカウントが削除されました",
+		TextBody: `あなたのアカウントは正常に削除されました。
+
+ご利用ありがとうございました。`,
+	},
+	{
+		Key:       EmailTemplateAccountLocked,
+		Name:      "アカウントロック通知",
+		Variables: map[string]string{"LockedUntil": "2026-01-01 09:30", "UnlockToken": "example-token"},
+		Subject:   "ログイン失敗が続いたためアカウントをロックしました",
+		TextBody: `{{.LockedUntil}} までログインできません。
+ご本人の操作であれば、以下のリンクからすぐにロックを解除できます：
+http://localhost:3000/unlock-account?token={{.UnlockToken}}
+
+このリンクは1時間有効です。
+心当たりがない場合は、パスワードの変更をおすすめします。`,
+	},
+	{
+		Key:  EmailTemplateNewLogin,
+		Name: "新しいログイン通知",
+		Variables: map[string]string{
+			"LoggedInAt": "2026-01-01 09:00", "IPAddress": "203.0.113.1", "Country": "JP", "UserAgent": "Mozilla/5.0",
+		},
+		Subject: "新しい端末からログインがありました",
+		TextBody: `日時: {{.LoggedInAt}}
+IPアドレス: {{.IPAddress}}
+国: {{.Country}}
+端末: {{.UserAgent}}
+
+心当たりがない場合は、すぐにパスワードを変更してください。`,
+	},
+	{
+		Key:       EmailTemplateNotification,
+		Name:      "お知らせ",
+		Variables: map[string]string{"Subject": "ポイントの有効期限が近づいています", "Body": "100ポイントが7日後に失効します。"},
+		Subject:   "{{.Subject}}",
+		TextBody: `{{.Body}}
+
+通知の受け取り方は設定画面から変更できます。`,
+	},
+	{
+		Key:       EmailTemplateDataExportReady,
+		Name:      "データエクスポート完了のお知らせ",
+		Variables: map[string]string{"ExportID": "00000000-0000-0000-0000-000000000000", "ExpiresAt": "2026-01-08 09:00"},
+		Subject:   "個人データのエクスポートが完了しました",
+		TextBody: `以下のリンクからダウンロードできます（ログインが必要です）：
+http://localhost:3000/settings/data-export/{{.ExportID}}
+
+ダウンロードの期限は {{.ExpiresAt}} までです。`,
+	},
+	{
+		Key:       EmailTemplateUserInvitation,
+		Name:      "アカウント作成のお知らせ",
+		Variables: map[string]string{"Username": "taro", "TemporaryPassword": "Temp-Pass-1234"},
+		Subject:   "ポイントシステムのアカウントが作成されました",
+		TextBody: `管理者があなたのアカウントを作成しました。以下の情報でログインしてください：
+http://localhost:3000/login
+
+ユーザー名: {{.Username}}
+仮パスワード: {{.TemporaryPassword}}
+
+ログイン後、設定画面からパスワードを変更してください。`,
+	},
+}
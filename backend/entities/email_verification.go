@@ -15,6 +15,14 @@ const (
 	TokenTypeEmailChange  TokenType = "email_change" // メールアドレス変更時の認証
 )
 
+const (
+	// EmailChangeTokenTTL はメール変更トークン（新旧アドレス共通）の有効期間
+	EmailChangeTokenTTL = 72 * time.Hour
+	// EmailChangeOldConfirmTimeout は旧アドレスの確認を待つ期間
+	// 新アドレスが認証済みで、この期間を過ぎても旧アドレスが確認されない場合は変更を適用する
+	EmailChangeOldConfirmTimeout = 48 * time.Hour
+)
+
 // EmailVerificationToken はメール認証トークン
 type EmailVerificationToken struct {
 	ID         uuid.UUID
@@ -24,7 +32,13 @@ type EmailVerificationToken struct {
 	TokenType  TokenType
 	ExpiresAt  time.Time
 	CreatedAt  time.Time
-	VerifiedAt *time.Time
+	VerifiedAt *time.Time // 新アドレス（Email）の認証日時
+
+	// 以下はメール変更時の二段階確認用（旧形式のトークンではnil）
+	OldEmail            *string    // 変更前のメールアドレス
+	OldEmailToken       *string    // 旧アドレスに送る確認用トークン
+	OldEmailConfirmedAt *time.Time // 旧アドレスでの確認日時
+	AppliedAt           *time.Time // メールアドレス変更の適用日時
 }
 
 // NewEmailVerificationToken は新しいメール認証トークンを作成
@@ -77,3 +91,72 @@ func (t *EmailVerificationToken) Verify() error {
 	return nil
 }
 
+// NewEmailChangeToken は新旧両方のアドレスで確認するメール変更トークンを作成
+func NewEmailChangeToken(userID uuid.UUID, oldEmail, newEmail string) (*EmailVerificationToken, error) {
+	if oldEmail == "" {
+		return nil, errors.New("old email is required")
+	}
+
+	t, err := NewEmailVerificationToken(&userID, newEmail, TokenTypeEmailChange)
+	if err != nil {
+		return nil, err
+	}
+
+	oldToken, err := GenerateSecureTokenHex(32)
+	if err != nil {
+		return nil, err
+	}
+
+	t.OldEmail = &oldEmail
+	t.OldEmailToken = &oldToken
+	t.ExpiresAt = t.CreatedAt.Add(EmailChangeTokenTTL)
+	return t, nil
+}
+
+// RequiresOldEmailConfirmation は旧アドレスでの確認が必要なトークンかどうかを確認
+func (t *EmailVerificationToken) RequiresOldEmailConfirmation() bool {
+	return t.OldEmailToken != nil
+}
+
+// IsOldEmailConfirmed は旧アドレスで確認済みかどうかを確認
+func (t *EmailVerificationToken) IsOldEmailConfirmed() bool {
+	return t.OldEmailConfirmedAt != nil
+}
+
+// IsApplied はメールアドレス変更が適用済みかどうかを確認
+func (t *EmailVerificationToken) IsApplied() bool {
+	return t.AppliedAt != nil
+}
+
+// ConfirmOldEmail は旧アドレスでの確認を記録
+func (t *EmailVerificationToken) ConfirmOldEmail(now time.Time) error {
+	if !t.RequiresOldEmailConfirmation() {
+		return errors.New("old email confirmation is not required")
+	}
+	if t.IsOldEmailConfirmed() {
+		return errors.New("token already verified")
+	}
+	if now.After(t.ExpiresAt) {
+		return errors.New("token expired")
+	}
+
+	t.OldEmailConfirmedAt = &now
+	return nil
+}
+
+// CanApply はメールアドレス変更を適用できるかどうかを確認
+// 新アドレスの認証が必須。旧アドレスは確認済みか、確認待ち期間を過ぎていればよい
+func (t *EmailVerificationToken) CanApply(now time.Time) bool {
+	if t.IsApplied() || !t.IsVerified() {
+		return false
+	}
+	if !t.RequiresOldEmailConfirmation() || t.IsOldEmailConfirmed() {
+		return true
+	}
+	return !now.Before(t.CreatedAt.Add(EmailChangeOldConfirmTimeout))
+}
+
+// MarkApplied はメールアドレス変更を適用済みにする
+func (t *EmailVerificationToken) MarkApplied(now time.Time) {
+	t.AppliedAt = &now
+}
@@ -0,0 +1,453 @@
+package entities
+
+import "errors"
+
+// ErrorCode はAPIクライアント向けの機械可読なエラーコード
+// 値はクライアントが分岐に使うため、一度公開したら変更しないこと
+type ErrorCode string
+
+const (
+	ErrCodeInvalidAmount           ErrorCode = "invalid_amount"
+	ErrCodeInvalidQuantity         ErrorCode = "invalid_quantity"
+	ErrCodeIdempotencyKeyRequired  ErrorCode = "idempotency_key_required"
+	ErrCodeSameUserTransfer        ErrorCode = "same_user_transfer"
+	ErrCodeEmailAlreadyExists      ErrorCode = "email_already_exists"
+	ErrCodeUsernameAlreadyExists   ErrorCode = "username_already_exists"
+	ErrCodeInsufficientBalance     ErrorCode = "insufficient_balance"
+	ErrCodeUserInactive            ErrorCode = "user_inactive"
+	ErrCodeProductNotAvailable     ErrorCode = "product_not_available"
+	ErrCodeRequestNotPending       ErrorCode = "request_not_pending"
+	ErrCodeRequestExpired          ErrorCode = "request_expired"
+	ErrCodeQRCodeExpired           ErrorCode = "qr_code_expired"
+	ErrCodeQRCodeAlreadyUsed       ErrorCode = "qr_code_already_used"
+	ErrCodeTokenExpired            ErrorCode = "token_expired"
+	ErrCodeTokenAlreadyUsed        ErrorCode = "token_already_used"
+	ErrCodeKioskDailyLimitReached  ErrorCode = "kiosk_daily_limit_reached"
+	ErrCodeUpdateConflict          ErrorCode = "update_conflict"
+	ErrCodeUserNotFound            ErrorCode = "user_not_found"
+	ErrCodeTransferRequestNotFound ErrorCode = "transfer_request_not_found"
+	ErrCodeQRCodeNotFound          ErrorCode = "qr_code_not_found"
+	ErrCodeAdminRequired           ErrorCode = "admin_required"
+	ErrCodeInvalidCredentials      ErrorCode = "invalid_credentials"
+	ErrCodeSessionExpired          ErrorCode = "session_expired"
+	ErrCodeAccountLocked           ErrorCode = "account_locked"
+	ErrCodeTooManyLoginAttempts    ErrorCode = "too_many_login_attempts"
+	ErrCodeIdempotencyKeyInvalid   ErrorCode = "idempotency_key_invalid"
+	ErrCodeIdempotencyKeyReused    ErrorCode = "idempotency_key_reused"
+	ErrCodeRequestInProgress       ErrorCode = "request_in_progress"
+	ErrCodeInvalidDateRange        ErrorCode = "invalid_date_range"
+	ErrCodeMaintenanceMode         ErrorCode = "maintenance_mode"
+	ErrCodeInvalidExpiryPolicy     ErrorCode = "invalid_expiry_policy"
+	ErrCodePointBatchNotFound      ErrorCode = "point_batch_not_found"
+	ErrCodePointBatchNotRestorable ErrorCode = "point_batch_not_restorable"
+	ErrCodePointBatchRestored      ErrorCode = "point_batch_already_restored"
+	ErrCodePointBatchNotAdjustable ErrorCode = "point_batch_not_adjustable"
+	ErrCodeInvalidBatchAdjustment  ErrorCode = "invalid_point_batch_adjustment"
+	ErrCodeDataExportNotFound      ErrorCode = "data_export_not_found"
+	ErrCodeDataExportInProgress    ErrorCode = "data_export_in_progress"
+	ErrCodeDataExportTooSoon       ErrorCode = "data_export_too_soon"
+	ErrCodeDataExportNotReady      ErrorCode = "data_export_not_ready"
+	ErrCodeContentRejected         ErrorCode = "content_rejected"
+	ErrCodeViolationNotFound       ErrorCode = "content_violation_not_found"
+	ErrCodeAnnouncementNotFound    ErrorCode = "announcement_not_found"
+	ErrCodeInvalidAnnouncement     ErrorCode = "invalid_announcement"
+	ErrCodeRequestNotCountered     ErrorCode = "request_not_countered"
+	ErrCodeInvalidCounterAmount    ErrorCode = "invalid_counter_amount"
+	ErrCodeRecurringNotFound       ErrorCode = "recurring_transfer_not_found"
+	ErrCodeRecurringNotActive      ErrorCode = "recurring_transfer_not_active"
+	ErrCodeInvalidRecurring        ErrorCode = "invalid_recurring_transfer"
+	ErrCodeInvalidContactHashes    ErrorCode = "invalid_contact_hashes"
+	ErrCodeInvalidDeviceToken      ErrorCode = "invalid_device_token"
+	ErrCodeDeviceTokenNotFound     ErrorCode = "device_token_not_found"
+	ErrCodeInvalidQuietHours       ErrorCode = "invalid_quiet_hours"
+	ErrCodeRedemptionCodeNotFound  ErrorCode = "redemption_code_not_found"
+	ErrCodeRedemptionCodeUsed      ErrorCode = "redemption_code_used"
+	ErrCodePricingRuleNotFound     ErrorCode = "pricing_rule_not_found"
+	ErrCodeInvalidPricingRule      ErrorCode = "invalid_pricing_rule"
+	ErrCodeSaleLimitExceeded       ErrorCode = "sale_limit_exceeded"
+	ErrCodeInvalidUserTier         ErrorCode = "invalid_user_tier"
+	ErrCodeInvalidReferralCode     ErrorCode = "invalid_referral_code"
+	ErrCodeEventNotFound           ErrorCode = "event_not_found"
+	ErrCodeInvalidEvent            ErrorCode = "invalid_event"
+	ErrCodeEventNotOpen            ErrorCode = "event_not_open"
+	ErrCodeEventFull               ErrorCode = "event_full"
+	ErrCodeEventAlreadyCheckedIn   ErrorCode = "event_already_checked_in"
+	ErrCodeReasonCodeRequired      ErrorCode = "reason_code_required"
+	ErrCodeReasonCodeNotFound      ErrorCode = "reason_code_not_found"
+	ErrCodeInvalidReasonCode       ErrorCode = "invalid_reason_code"
+	ErrCodeReasonCodeExists        ErrorCode = "reason_code_exists"
+	ErrCodeReasonCodeInactive      ErrorCode = "reason_code_inactive"
+	ErrCodeInvalidTransactionTag   ErrorCode = "invalid_transaction_tag"
+	ErrCodeInvalidUserImportFile   ErrorCode = "invalid_user_import_file"
+	ErrCodeInvalidUserImportRow    ErrorCode = "invalid_user_import_row"
+	ErrCodeUserImportChunkFailed   ErrorCode = "user_import_chunk_failed"
+	ErrCodeInvalidTxImportFile     ErrorCode = "invalid_transaction_import_file"
+	ErrCodeInvalidTxImportRow      ErrorCode = "invalid_transaction_import_row"
+	ErrCodeDepartmentNotFound      ErrorCode = "department_not_found"
+	ErrCodeInvalidDepartment       ErrorCode = "invalid_department"
+	ErrCodeDepartmentExists        ErrorCode = "department_exists"
+	ErrCodeDepartmentHasChildren   ErrorCode = "department_has_children"
+	ErrCodeDepartmentOverBudget    ErrorCode = "department_budget_exceeded"
+	ErrCodeBudgetNotFound          ErrorCode = "budget_not_found"
+	ErrCodeInvalidBudget           ErrorCode = "invalid_budget"
+	ErrCodeBudgetExceeded          ErrorCode = "budget_exceeded"
+	ErrCodeBudgetOverrideRequired  ErrorCode = "budget_override_required"
+	ErrCodePendingActionNotFound   ErrorCode = "pending_action_not_found"
+	ErrCodePendingActionNotPending ErrorCode = "pending_action_not_pending"
+	ErrCodePendingActionExpired    ErrorCode = "pending_action_expired"
+	ErrCodeSelfApproval            ErrorCode = "self_approval"
+	ErrCodeInvalidApprovalComment  ErrorCode = "invalid_approval_comment"
+	ErrCodeInvalidApprovalLimit    ErrorCode = "invalid_approval_threshold"
+	ErrCodeInvalidCronSchedule     ErrorCode = "invalid_cron_schedule"
+	ErrCodeFailedAccessNotFound    ErrorCode = "failed_access_not_found"
+	ErrCodeFailedAccessNotDead     ErrorCode = "failed_access_not_dead"
+	ErrCodeInvalidTimezone         ErrorCode = "invalid_timezone"
+	ErrCodeTransferHeld            ErrorCode = "transfer_held"
+	ErrCodeTransferRejected        ErrorCode = "transfer_rejected"
+	ErrCodeSuspiciousNotFound      ErrorCode = "suspicious_activity_not_found"
+	ErrCodeSuspiciousReviewed      ErrorCode = "suspicious_activity_reviewed"
+	ErrCodeEmailVerificationNeeded ErrorCode = "email_verification_required"
+	ErrCodeAccountTooNew           ErrorCode = "account_too_new"
+	ErrCodeInvalidEligibility      ErrorCode = "invalid_transfer_eligibility"
+	ErrCodeRecipientFriendsOnly    ErrorCode = "recipient_accepts_friends_only"
+	ErrCodeInvalidAcceptance       ErrorCode = "invalid_transfer_acceptance"
+	ErrCodeUnauthorized            ErrorCode = "unauthorized"
+	ErrCodeValidationFailed        ErrorCode = "validation_failed"
+	ErrCodeInvalidCSRFToken        ErrorCode = "invalid_csrf_token"
+	ErrCodeInvalidKioskDevice      ErrorCode = "invalid_kiosk_device"
+	ErrCodeRequestTooLarge         ErrorCode = "request_too_large"
+	ErrCodeUnsupportedMediaType    ErrorCode = "unsupported_media_type"
+	ErrCodeTooManyConnections      ErrorCode = "too_many_connections"
+	ErrCodeRouteNotFound           ErrorCode = "route_not_found"
+	ErrCodeRequestTimeout          ErrorCode = "request_timeout"
+	ErrCodeTransferAmountTooSmall  ErrorCode = "transfer_amount_too_small"
+	ErrCodeTransferAmountTooLarge  ErrorCode = "transfer_amount_too_large"
+	ErrCodeInvalidTransferPolicy   ErrorCode = "invalid_transfer_policy"
+	ErrCodeEarningRuleNotFound     ErrorCode = "earning_rule_not_found"
+	ErrCodeInvalidEarningRule      ErrorCode = "invalid_earning_rule"
+	ErrCodeTenantNotFound          ErrorCode = "tenant_not_found"
+	ErrCodeTenantInactive          ErrorCode = "tenant_inactive"
+	ErrCodeTenantSlugTaken         ErrorCode = "tenant_slug_taken"
+	ErrCodeInvalidTenant           ErrorCode = "invalid_tenant"
+	ErrCodeSuperAdminRequired      ErrorCode = "super_admin_required"
+	ErrCodeInvalidArchiveQuery     ErrorCode = "invalid_archive_query"
+	ErrCodeSplitRequestNotFound    ErrorCode = "split_request_not_found"
+	ErrCodeInvalidSplitRequest     ErrorCode = "invalid_split_request"
+	ErrCodeSplitRequestClosed      ErrorCode = "split_request_closed"
+	ErrCodeSplitShareNotCounter    ErrorCode = "split_share_not_counterable"
+	ErrCodeNotFriends              ErrorCode = "not_friends"
+	ErrCodeInvalidOnboardingBonus  ErrorCode = "invalid_onboarding_bonus"
+	ErrCodeAkerunRepollNotFound    ErrorCode = "akerun_repoll_not_found"
+	ErrCodeAkerunRepollInProgress  ErrorCode = "akerun_repoll_in_progress"
+	ErrCodeInvalidAkerunRepoll     ErrorCode = "invalid_akerun_repoll"
+	ErrCodeCartEmpty               ErrorCode = "cart_empty"
+	ErrCodeCartFull                ErrorCode = "cart_full"
+	ErrCodeCartItemNotFound        ErrorCode = "cart_item_not_found"
+	ErrCodeCartItemUnavailable     ErrorCode = "cart_item_unavailable"
+	ErrCodeInvalidGift             ErrorCode = "invalid_gift"
+	ErrCodeGiftNotFound            ErrorCode = "gift_not_found"
+	ErrCodeGiftAlreadyAnswered     ErrorCode = "gift_already_answered"
+	ErrCodeGiftAwaitingResponse    ErrorCode = "gift_awaiting_response"
+	ErrCodeInvalidShippingAddress  ErrorCode = "invalid_shipping_address"
+	ErrCodeShippingAddressNotFound ErrorCode = "shipping_address_not_found"
+	ErrCodeShippingAddressLimit    ErrorCode = "shipping_address_limit"
+	ErrCodeShippingAddressRequired ErrorCode = "shipping_address_required"
+	ErrCodeInvalidEmailTemplate    ErrorCode = "invalid_email_template"
+	ErrCodeEmailTemplateNotFound   ErrorCode = "email_template_not_found"
+	ErrCodeInvalidBonusReportQuery ErrorCode = "invalid_bonus_report_query"
+	ErrCodeFriendPinLimit          ErrorCode = "friend_pin_limit"
+	ErrCodePinTargetNotFriend      ErrorCode = "pin_target_not_friend"
+	ErrCodeAccountDeactivated      ErrorCode = "account_deactivated"
+	ErrCodeReactivationExpired     ErrorCode = "reactivation_expired"
+	ErrCodeSessionReauthRequired   ErrorCode = "session_reauth_required"
+	ErrCodeCSRFTokenExpired        ErrorCode = "csrf_token_expired"
+	ErrCodeEmailNotVerified        ErrorCode = "email_not_verified"
+	ErrCodeInvalidEmailRequirement ErrorCode = "invalid_email_verification_requirement"
+	ErrCodeExchangeNotFound        ErrorCode = "exchange_not_found"
+	ErrCodeActionNotPermitted      ErrorCode = "action_not_permitted"
+	ErrCodeCannotMuteSelf          ErrorCode = "cannot_mute_self"
+	ErrCodeInvalidMuteMode         ErrorCode = "invalid_mute_mode"
+	ErrCodePendingRequestLimit     ErrorCode = "pending_request_limit"
+	ErrCodeRecipientRequestLimit   ErrorCode = "recipient_request_limit"
+	ErrCodeInvalidRequestQuota     ErrorCode = "invalid_request_quota"
+	ErrCodeBalanceHoldSettled      ErrorCode = "balance_hold_settled"
+	ErrCodeInvalidQRPayload        ErrorCode = "invalid_qr_payload"
+	ErrCodeUnsupportedQRVersion    ErrorCode = "unsupported_qr_payload_version"
+	ErrCodeTransactionNotFound     ErrorCode = "transaction_not_found"
+	ErrCodeReceiptUnavailable      ErrorCode = "receipt_unavailable"
+	ErrCodeAttachmentNotFound      ErrorCode = "attachment_not_found"
+	ErrCodeAttachmentNotAllowed    ErrorCode = "attachment_not_allowed"
+	ErrCodeInvalidAttachment       ErrorCode = "invalid_attachment"
+)
+
+// DomainError はエラーコードとメッセージ用パラメータを持つドメインエラー
+// Error() は従来どおり英語のメッセージを返し、表示用の翻訳はプレゼンター側で行う
+type DomainError struct {
+	Code    ErrorCode
+	Message string
+	Params  map[string]interface{}
+	Fields  []FieldError // 入力項目ごとのエラー（入力の検証エラーの場合）
+}
+
+// FieldError は入力項目1つの検証エラー
+type FieldError struct {
+	Field   string `json:"field"`   // 項目名（リクエストのJSONのキー、パス・クエリのパラメータ名）
+	Message string `json:"message"` // 英語の説明（例: is required）
+}
+
+// Error はerrorインターフェースの実装
+func (e *DomainError) Error() string {
+	return e.Message
+}
+
+// Is はエラーコードが一致すれば同じエラーとみなす（WithParamsで複製したエラーもerrors.Isで判定できる）
+func (e *DomainError) Is(target error) bool {
+	t, ok := target.(*DomainError)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code
+}
+
+// WithParams はパラメータを付与したエラーを返す（元のエラーは変更しない）
+func (e *DomainError) WithParams(params map[string]interface{}) *DomainError {
+	return &DomainError{
+		Code:    e.Code,
+		Message: e.Message,
+		Params:  params,
+		Fields:  e.Fields,
+	}
+}
+
+// WithFields は入力項目ごとのエラーを付与したエラーを返す（元のエラーは変更しない）
+func (e *DomainError) WithFields(fields ...FieldError) *DomainError {
+	return &DomainError{
+		Code:    e.Code,
+		Message: e.Message,
+		Params:  e.Params,
+		Fields:  fields,
+	}
+}
+
+// NewValidationError は入力項目の検証エラーを作成
+func NewValidationError(fields ...FieldError) *DomainError {
+	return ErrValidationFailed.WithFields(fields...)
+}
+
+// NewDomainError は新しいDomainErrorを作成
+func NewDomainError(code ErrorCode, message string) *DomainError {
+	return &DomainError{Code: code, Message: message}
+}
+
+// AsDomainError はエラーチェーンからDomainErrorを取り出す
+func AsDomainError(err error) (*DomainError, bool) {
+	var de *DomainError
+	if errors.As(err, &de) {
+		return de, true
+	}
+	return nil, false
+}
+
+// ドメインエラーの一覧
+var (
+	ErrInvalidAmount           = NewDomainError(ErrCodeInvalidAmount, "amount must be positive")
+	ErrInvalidQuantity         = NewDomainError(ErrCodeInvalidQuantity, "quantity must be positive")
+	ErrIdempotencyKeyRequired  = NewDomainError(ErrCodeIdempotencyKeyRequired, "idempotency key is required")
+	ErrSameUserTransfer        = NewDomainError(ErrCodeSameUserTransfer, "cannot transfer to the same user")
+	ErrEmailAlreadyExists      = NewDomainError(ErrCodeEmailAlreadyExists, "email already exists")
+	ErrUsernameAlreadyExists   = NewDomainError(ErrCodeUsernameAlreadyExists, "username already exists")
+	ErrInsufficientBalance     = NewDomainError(ErrCodeInsufficientBalance, "insufficient balance")
+	ErrUserInactive            = NewDomainError(ErrCodeUserInactive, "user is not active")
+	ErrProductNotAvailable     = NewDomainError(ErrCodeProductNotAvailable, "product is not available")
+	ErrRequestNotPending       = NewDomainError(ErrCodeRequestNotPending, "request is not pending")
+	ErrRequestExpired          = NewDomainError(ErrCodeRequestExpired, "request has expired")
+	ErrQRCodeExpired           = NewDomainError(ErrCodeQRCodeExpired, "qr code expired")
+	ErrQRCodeAlreadyUsed       = NewDomainError(ErrCodeQRCodeAlreadyUsed, "qr code already used")
+	ErrTokenExpired            = NewDomainError(ErrCodeTokenExpired, "token has expired")
+	ErrTokenAlreadyUsed        = NewDomainError(ErrCodeTokenAlreadyUsed, "token has already been used")
+	ErrKioskDailyLimitReached  = NewDomainError(ErrCodeKioskDailyLimitReached, "kiosk daily limit reached")
+	ErrUpdateConflict          = NewDomainError(ErrCodeUpdateConflict, "update conflict: please retry later")
+	ErrUserNotFound            = NewDomainError(ErrCodeUserNotFound, "user not found")
+	ErrTransferRequestNotFound = NewDomainError(ErrCodeTransferRequestNotFound, "transfer request not found")
+	ErrQRCodeNotFound          = NewDomainError(ErrCodeQRCodeNotFound, "qr code not found")
+	ErrAdminRequired           = NewDomainError(ErrCodeAdminRequired, "unauthorized: admin role required")
+	ErrInvalidCredentials      = NewDomainError(ErrCodeInvalidCredentials, "invalid username or password")
+	ErrSessionExpired          = NewDomainError(ErrCodeSessionExpired, "session expired")
+	ErrAccountLocked           = NewDomainError(ErrCodeAccountLocked, "account is locked, please try again later or unlock it from the email we sent")
+	ErrTooManyLoginAttempts    = NewDomainError(ErrCodeTooManyLoginAttempts, "too many login attempts, please try again later")
+	ErrIdempotencyKeyInvalid   = NewDomainError(ErrCodeIdempotencyKeyInvalid, "idempotency key must be 1 to 255 characters")
+	ErrIdempotencyKeyReused    = NewDomainError(ErrCodeIdempotencyKeyReused, "idempotency key was already used for a different request")
+	ErrRequestInProgress       = NewDomainError(ErrCodeRequestInProgress, "a request with the same idempotency key is still in progress")
+	ErrInvalidDateRange        = NewDomainError(ErrCodeInvalidDateRange, "invalid date range")
+	ErrMaintenanceMode         = NewDomainError(ErrCodeMaintenanceMode, "the service is under maintenance")
+	ErrInvalidExpiryPolicy     = NewDomainError(ErrCodeInvalidExpiryPolicy, "invalid expiry policy")
+	ErrPointBatchNotFound      = NewDomainError(ErrCodePointBatchNotFound, "point batch not found")
+	ErrPointBatchNotRestorable = NewDomainError(ErrCodePointBatchNotRestorable, "point batch is not expired or the grace period has passed")
+	ErrPointBatchRestored      = NewDomainError(ErrCodePointBatchRestored, "point batch has already been restored")
+	ErrPointBatchNotAdjustable = NewDomainError(ErrCodePointBatchNotAdjustable, "point batch has already expired or been cancelled")
+	ErrInvalidBatchAdjustment  = NewDomainError(ErrCodeInvalidBatchAdjustment, "invalid adjustment: specify either a new expiry date or cancel, with a reason")
+	ErrDataExportNotFound      = NewDomainError(ErrCodeDataExportNotFound, "data export not found")
+	ErrDataExportInProgress    = NewDomainError(ErrCodeDataExportInProgress, "data export is already in progress")
+	ErrDataExportTooSoon       = NewDomainError(ErrCodeDataExportTooSoon, "data export was requested recently")
+	ErrDataExportNotReady      = NewDomainError(ErrCodeDataExportNotReady, "data export is not ready or has expired")
+	ErrContentRejected         = NewDomainError(ErrCodeContentRejected, "content was rejected by moderation")
+	ErrViolationNotFound       = NewDomainError(ErrCodeViolationNotFound, "content violation not found")
+	ErrAnnouncementNotFound    = NewDomainError(ErrCodeAnnouncementNotFound, "announcement not found")
+	ErrInvalidAnnouncement     = NewDomainError(ErrCodeInvalidAnnouncement, "invalid announcement: check title, body, severity, audience and display period")
+	ErrRequestNotCountered     = NewDomainError(ErrCodeRequestNotCountered, "request has no counter-offer to confirm")
+	ErrInvalidCounterAmount    = NewDomainError(ErrCodeInvalidCounterAmount, "counter amount must be positive and differ from the requested amount")
+	ErrRecurringNotFound       = NewDomainError(ErrCodeRecurringNotFound, "recurring transfer not found")
+	ErrRecurringNotActive      = NewDomainError(ErrCodeRecurringNotActive, "recurring transfer is not active")
+	ErrInvalidRecurring        = NewDomainError(ErrCodeInvalidRecurring, "invalid recurring transfer: check interval, message and start date")
+	ErrInvalidContactHashes    = NewDomainError(ErrCodeInvalidContactHashes, "contact hashes must be 1-500 lowercase hex SHA-256 digests")
+	ErrInvalidDeviceToken      = NewDomainError(ErrCodeInvalidDeviceToken, "device token must be a non-empty token for ios or android")
+	ErrDeviceTokenNotFound     = NewDomainError(ErrCodeDeviceTokenNotFound, "device token not found")
+	ErrInvalidQuietHours       = NewDomainError(ErrCodeInvalidQuietHours, "quiet hours must be HH:MM times with a valid IANA timezone")
+	ErrRedemptionCodeNotFound  = NewDomainError(ErrCodeRedemptionCodeNotFound, "redemption code not found")
+	ErrRedemptionCodeUsed      = NewDomainError(ErrCodeRedemptionCodeUsed, "redemption code has already been used or the exchange was cancelled")
+	ErrPricingRuleNotFound     = NewDomainError(ErrCodePricingRuleNotFound, "pricing rule not found")
+	ErrInvalidPricingRule      = NewDomainError(ErrCodeInvalidPricingRule, "invalid pricing rule: check target, discount, quantity limit and period")
+	ErrSaleLimitExceeded       = NewDomainError(ErrCodeSaleLimitExceeded, "quantity exceeds the per-user limit for this sale")
+	ErrInvalidUserTier         = NewDomainError(ErrCodeInvalidUserTier, "tier must be bronze, silver or gold")
+	ErrInvalidReferralCode     = NewDomainError(ErrCodeInvalidReferralCode, "referral code is invalid")
+	ErrEventNotFound           = NewDomainError(ErrCodeEventNotFound, "event not found")
+	ErrInvalidEvent            = NewDomainError(ErrCodeInvalidEvent, "invalid event: check name, points, capacity and period")
+	ErrEventNotOpen            = NewDomainError(ErrCodeEventNotOpen, "event is not accepting check-ins")
+	ErrEventFull               = NewDomainError(ErrCodeEventFull, "event has reached its capacity")
+	ErrEventAlreadyCheckedIn   = NewDomainError(ErrCodeEventAlreadyCheckedIn, "already checked in to this event")
+	ErrReasonCodeRequired      = NewDomainError(ErrCodeReasonCodeRequired, "reason code is required")
+	ErrReasonCodeNotFound      = NewDomainError(ErrCodeReasonCodeNotFound, "reason code not found")
+	ErrInvalidReasonCode       = NewDomainError(ErrCodeInvalidReasonCode, "invalid reason code: check code format, label and description")
+	ErrReasonCodeExists        = NewDomainError(ErrCodeReasonCodeExists, "reason code already exists")
+	ErrReasonCodeInactive      = NewDomainError(ErrCodeReasonCodeInactive, "reason code is no longer in use")
+	ErrInvalidTransactionTag   = NewDomainError(ErrCodeInvalidTransactionTag, "tag is too long or contains invalid characters")
+	ErrInvalidUserImportFile   = NewDomainError(ErrCodeInvalidUserImportFile, "invalid import file: check the CSV header and the number of rows")
+	ErrInvalidUserImportRow    = NewDomainError(ErrCodeInvalidUserImportRow, "invalid row: check username, email, name, initial points and role")
+	ErrUserImportChunkFailed   = NewDomainError(ErrCodeUserImportChunkFailed, "not imported because another row in the same chunk failed")
+
+	ErrInvalidTransactionImportFile = NewDomainError(ErrCodeInvalidTxImportFile, "invalid import file: check the CSV header and the number of rows")
+	ErrInvalidTransactionImportRow  = NewDomainError(ErrCodeInvalidTxImportRow, "invalid row: check date, from, to, amount and type")
+
+	ErrDepartmentNotFound      = NewDomainError(ErrCodeDepartmentNotFound, "department not found")
+	ErrInvalidDepartment       = NewDomainError(ErrCodeInvalidDepartment, "invalid department: check name, parent and budget")
+	ErrDepartmentExists        = NewDomainError(ErrCodeDepartmentExists, "a department with the same name already exists under this parent")
+	ErrDepartmentHasChildren   = NewDomainError(ErrCodeDepartmentHasChildren, "department has sub-departments")
+	ErrDepartmentOverBudget    = NewDomainError(ErrCodeDepartmentOverBudget, "grant exceeds the department's monthly budget")
+	ErrBudgetNotFound          = NewDomainError(ErrCodeBudgetNotFound, "budget not found")
+	ErrInvalidBudget           = NewDomainError(ErrCodeInvalidBudget, "invalid budget: check name, scope, period, amount and enforcement")
+	ErrBudgetExceeded          = NewDomainError(ErrCodeBudgetExceeded, "grant exceeds the budget")
+	ErrBudgetOverrideRequired  = NewDomainError(ErrCodeBudgetOverrideRequired, "grant exceeds the budget: set budget_override to grant anyway")
+	ErrPendingActionNotFound   = NewDomainError(ErrCodePendingActionNotFound, "pending admin action not found")
+	ErrPendingActionNotPending = NewDomainError(ErrCodePendingActionNotPending, "pending admin action has already been reviewed")
+	ErrPendingActionExpired    = NewDomainError(ErrCodePendingActionExpired, "pending admin action has expired")
+	ErrSelfApproval            = NewDomainError(ErrCodeSelfApproval, "an admin cannot approve their own request")
+	ErrInvalidApprovalComment  = NewDomainError(ErrCodeInvalidApprovalComment, "approval comment is too long")
+	ErrInvalidApprovalLimit    = NewDomainError(ErrCodeInvalidApprovalLimit, "approval threshold must be zero or more")
+	ErrInvalidCronSchedule     = NewDomainError(ErrCodeInvalidCronSchedule, "invalid cron expression: expected 5 fields (minute hour day month weekday)")
+	ErrFailedAccessNotFound    = NewDomainError(ErrCodeFailedAccessNotFound, "failed akerun access not found")
+	ErrFailedAccessNotDead     = NewDomainError(ErrCodeFailedAccessNotDead, "failed akerun access is still being retried")
+	ErrInvalidTimezone         = NewDomainError(ErrCodeInvalidTimezone, "timezone must be a valid IANA timezone such as Asia/Tokyo")
+	ErrTransferHeld            = NewDomainError(ErrCodeTransferHeld, "transfer is held for review by an admin")
+	ErrTransferRejected        = NewDomainError(ErrCodeTransferRejected, "transfer was rejected by an admin")
+
+	ErrSuspiciousActivityNotFound = NewDomainError(ErrCodeSuspiciousNotFound, "suspicious activity not found")
+	ErrSuspiciousActivityReviewed = NewDomainError(ErrCodeSuspiciousReviewed, "suspicious activity has already been reviewed")
+	ErrEmailVerificationRequired  = NewDomainError(ErrCodeEmailVerificationNeeded, "verify your email address before sending points")
+	ErrAccountTooNew              = NewDomainError(ErrCodeAccountTooNew, "account is too new to send points")
+	ErrInvalidTransferEligibility = NewDomainError(ErrCodeInvalidEligibility, "minimum account age must be between 0 and 8760 hours")
+
+	// 受取人が受け取る相手を友達に限っている
+	ErrRecipientAcceptsFriendsOnly = NewDomainError(ErrCodeRecipientFriendsOnly, "recipient only accepts points from friends")
+	ErrInvalidTransferAcceptance   = NewDomainError(ErrCodeInvalidAcceptance, "accept transfers from must be anyone or friends_only")
+
+	// 認証・入力の検証などHTTPの入口で返すエラー
+	ErrUnauthorized         = NewDomainError(ErrCodeUnauthorized, "unauthorized")
+	ErrValidationFailed     = NewDomainError(ErrCodeValidationFailed, "invalid request")
+	ErrInvalidCSRFToken     = NewDomainError(ErrCodeInvalidCSRFToken, "invalid csrf token")
+	ErrInvalidKioskDevice   = NewDomainError(ErrCodeInvalidKioskDevice, "invalid kiosk device")
+	ErrRequestTooLarge      = NewDomainError(ErrCodeRequestTooLarge, "request body is too large")
+	ErrUnsupportedMediaType = NewDomainError(ErrCodeUnsupportedMediaType, "unsupported content type")
+	ErrTooManyConnections   = NewDomainError(ErrCodeTooManyConnections, "too many realtime connections")
+	ErrRouteNotFound        = NewDomainError(ErrCodeRouteNotFound, "no such endpoint")
+	ErrRequestTimeout       = NewDomainError(ErrCodeRequestTimeout, "request timed out")
+
+	ErrTransferAmountTooSmall = NewDomainError(ErrCodeTransferAmountTooSmall, "transfer amount is below the minimum")
+	ErrTransferAmountTooLarge = NewDomainError(ErrCodeTransferAmountTooLarge, "transfer amount exceeds the maximum")
+	ErrInvalidTransferPolicy  = NewDomainError(ErrCodeInvalidTransferPolicy, "invalid transfer policy: check the limits, fee and fee account")
+	ErrEarningRuleNotFound    = NewDomainError(ErrCodeEarningRuleNotFound, "earning rule not found")
+	ErrInvalidEarningRule     = NewDomainError(ErrCodeInvalidEarningRule, "invalid earning rule: check trigger, points, conditions, limits and period")
+
+	ErrTenantNotFound     = NewDomainError(ErrCodeTenantNotFound, "tenant not found")
+	ErrTenantInactive     = NewDomainError(ErrCodeTenantInactive, "tenant is inactive")
+	ErrTenantSlugTaken    = NewDomainError(ErrCodeTenantSlugTaken, "tenant slug is already taken")
+	ErrInvalidTenant      = NewDomainError(ErrCodeInvalidTenant, "invalid tenant: check slug and name")
+	ErrSuperAdminRequired = NewDomainError(ErrCodeSuperAdminRequired, "unauthorized: super admin role required")
+
+	ErrInvalidArchiveQuery = NewDomainError(ErrCodeInvalidArchiveQuery, "invalid archive query: specify a period (from, to) of at most 366 days and months as YYYY-MM")
+
+	ErrSplitRequestNotFound     = NewDomainError(ErrCodeSplitRequestNotFound, "split request not found")
+	ErrInvalidSplitRequest      = NewDomainError(ErrCodeInvalidSplitRequest, "invalid split request: check the total, participants and shares")
+	ErrSplitRequestClosed       = NewDomainError(ErrCodeSplitRequestClosed, "split request is already completed or cancelled")
+	ErrSplitShareNotCounterable = NewDomainError(ErrCodeSplitShareNotCounter, "a split share cannot be countered")
+	ErrNotFriends               = NewDomainError(ErrCodeNotFriends, "users are not friends")
+
+	ErrInvalidOnboardingBonus = NewDomainError(ErrCodeInvalidOnboardingBonus, "invalid onboarding bonus: check the amount and validity days")
+
+	ErrAkerunRepollNotFound   = NewDomainError(ErrCodeAkerunRepollNotFound, "akerun repoll not found")
+	ErrAkerunRepollInProgress = NewDomainError(ErrCodeAkerunRepollInProgress, "another akerun repoll is already pending or running")
+	ErrInvalidAkerunRepoll    = NewDomainError(ErrCodeInvalidAkerunRepoll, "invalid akerun repoll: specify a past period (from < to) of at most 7 days and a configured organization")
+
+	ErrCartEmpty           = NewDomainError(ErrCodeCartEmpty, "cart is empty")
+	ErrCartFull            = NewDomainError(ErrCodeCartFull, "cart has reached the maximum number of items")
+	ErrCartItemNotFound    = NewDomainError(ErrCodeCartItemNotFound, "cart item not found")
+	ErrCartItemUnavailable = NewDomainError(ErrCodeCartItemUnavailable, "a product in the cart cannot be exchanged")
+
+	ErrInvalidGift          = NewDomainError(ErrCodeInvalidGift, "a gift must be sent to another user")
+	ErrGiftNotFound         = NewDomainError(ErrCodeGiftNotFound, "gift not found")
+	ErrGiftAlreadyAnswered  = NewDomainError(ErrCodeGiftAlreadyAnswered, "gift has already been accepted or declined")
+	ErrGiftAwaitingResponse = NewDomainError(ErrCodeGiftAwaitingResponse, "gift has not been accepted by the recipient yet")
+
+	ErrInvalidShippingAddress  = NewDomainError(ErrCodeInvalidShippingAddress, "invalid shipping address")
+	ErrShippingAddressNotFound = NewDomainError(ErrCodeShippingAddressNotFound, "shipping address not found")
+	ErrShippingAddressLimit    = NewDomainError(ErrCodeShippingAddressLimit, "shipping address limit reached")
+	ErrShippingAddressRequired = NewDomainError(ErrCodeShippingAddressRequired, "a shipping address is required for physical products")
+
+	ErrInvalidEmailTemplate  = NewDomainError(ErrCodeInvalidEmailTemplate, "invalid email template")
+	ErrEmailTemplateNotFound = NewDomainError(ErrCodeEmailTemplateNotFound, "email template not found")
+
+	ErrInvalidBonusReportQuery = NewDomainError(ErrCodeInvalidBonusReportQuery, "invalid bonus report query: specify dates (from, to) as YYYY-MM-DD within 366 days, or a month as YYYY-MM")
+
+	ErrFriendPinLimit     = NewDomainError(ErrCodeFriendPinLimit, "friend pin limit reached")
+	ErrPinTargetNotFriend = NewDomainError(ErrCodePinTargetNotFriend, "only friends can be pinned")
+
+	ErrAccountDeactivated  = NewDomainError(ErrCodeAccountDeactivated, "account is deactivated, log in again to reactivate it")
+	ErrReactivationExpired = NewDomainError(ErrCodeReactivationExpired, "the period to reactivate the account has passed")
+
+	ErrSessionReauthRequired = NewDomainError(ErrCodeSessionReauthRequired, "the session was used from a different device or network, log in again")
+	ErrCSRFTokenExpired      = NewDomainError(ErrCodeCSRFTokenExpired, "csrf token has expired, fetch a new one from GET /api/auth/csrf")
+
+	ErrEmailNotVerified                    = NewDomainError(ErrCodeEmailNotVerified, "verify your email address to continue")
+	ErrInvalidEmailVerificationRequirement = NewDomainError(ErrCodeInvalidEmailRequirement, "stage must be one of off, before_login, before_transfer")
+
+	ErrExchangeNotFound   = NewDomainError(ErrCodeExchangeNotFound, "exchange not found")
+	ErrActionNotPermitted = NewDomainError(ErrCodeActionNotPermitted, "you are not allowed to perform this action on the resource")
+
+	ErrCannotMuteSelf  = NewDomainError(ErrCodeCannotMuteSelf, "you cannot mute yourself")
+	ErrInvalidMuteMode = NewDomainError(ErrCodeInvalidMuteMode, "mode must be reject or drop")
+
+	ErrPendingRequestLimit   = NewDomainError(ErrCodePendingRequestLimit, "too many pending transfer requests, wait for them to be answered or cancel some")
+	ErrRecipientRequestLimit = NewDomainError(ErrCodeRecipientRequestLimit, "too many pending transfer requests to this recipient")
+	ErrInvalidRequestQuota   = NewDomainError(ErrCodeInvalidRequestQuota, "limits must not be negative, and the per-recipient limit must not exceed the overall limit")
+
+	ErrBalanceHoldSettled = NewDomainError(ErrCodeBalanceHoldSettled, "balance hold is already consumed or released")
+
+	ErrInvalidQRPayload            = NewDomainError(ErrCodeInvalidQRPayload, "qr code is malformed or its signature is invalid")
+	ErrUnsupportedQRPayloadVersion = NewDomainError(ErrCodeUnsupportedQRVersion, "qr code was created by a newer version of the app")
+
+	ErrTransactionNotFound = NewDomainError(ErrCodeTransactionNotFound, "transaction not found")
+	ErrReceiptUnavailable  = NewDomainError(ErrCodeReceiptUnavailable, "receipts are available only for completed transactions")
+
+	ErrAttachmentNotFound   = NewDomainError(ErrCodeAttachmentNotFound, "attachment not found")
+	ErrAttachmentNotAllowed = NewDomainError(ErrCodeAttachmentNotAllowed, "images can be attached only to completed transfers and pending transfer requests")
+	ErrInvalidAttachment    = NewDomainError(ErrCodeInvalidAttachment, "attachment must be a non-empty image")
+)
@@ -0,0 +1,148 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// EventNameMaxLength はイベント名の最大文字数
+	EventNameMaxLength = 100
+	// EventDescriptionMaxLength はイベント説明の最大文字数
+	EventDescriptionMaxLength = 500
+	// EventMaxPoints は1回のチェックインで付与できるポイントの上限
+	EventMaxPoints int64 = 10000
+
+	// eventQRPrefix はイベントのQRコードに含めるデータの接頭辞（個人QRの "receive:" と区別する）
+	eventQRPrefix = "event:"
+)
+
+// Event は管理者が作成するチェックインイベント
+// 開催期間中にQRコードを読み取ったユーザーに、1人1回だけPointsを付与する
+type Event struct {
+	ID            uuid.UUID
+	Name          string
+	Description   string
+	Points        int64
+	Capacity      int // 参加できる人数（0なら無制限）
+	AttendeeCount int // チェックイン済みの人数
+	Code          string
+	StartsAt      time.Time
+	EndsAt        time.Time
+	IsActive      bool
+	CreatedBy     uuid.UUID
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NewEvent は新しいイベントを作成（QRコード用のコードも発行する）
+func NewEvent(name, description string, points int64, capacity int, startsAt, endsAt time.Time, createdBy uuid.UUID) (*Event, error) {
+	code, err := generateQRCode()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	e := &Event{
+		ID:        uuid.New(),
+		Code:      code,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+	}
+	if err := e.Update(name, description, points, capacity, startsAt, endsAt, true); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Update はイベントの内容を検証して更新
+func (e *Event) Update(name, description string, points int64, capacity int, startsAt, endsAt time.Time, isActive bool) error {
+	name = strings.TrimSpace(name)
+	description = strings.TrimSpace(description)
+	if name == "" || len([]rune(name)) > EventNameMaxLength {
+		return ErrInvalidEvent
+	}
+	if len([]rune(description)) > EventDescriptionMaxLength {
+		return ErrInvalidEvent
+	}
+	if points < 1 || points > EventMaxPoints {
+		return ErrInvalidEvent
+	}
+	if capacity < 0 {
+		return ErrInvalidEvent
+	}
+	if startsAt.IsZero() {
+		startsAt = time.Now()
+	}
+	if endsAt.IsZero() || !endsAt.After(startsAt) {
+		return ErrInvalidEvent
+	}
+
+	e.Name = name
+	e.Description = description
+	e.Points = points
+	e.Capacity = capacity
+	e.StartsAt = startsAt
+	e.EndsAt = endsAt
+	e.IsActive = isActive
+	e.UpdatedAt = time.Now()
+	return nil
+}
+
+// CanCheckIn は指定日時にチェックインを受け付けるかを確認
+func (e *Event) CanCheckIn(now time.Time) error {
+	if !e.IsActive || now.Before(e.StartsAt) || !now.Before(e.EndsAt) {
+		return ErrEventNotOpen
+	}
+	if e.IsFull() {
+		return ErrEventFull
+	}
+	return nil
+}
+
+// IsFull は定員に達しているかを確認
+func (e *Event) IsFull() bool {
+	return e.Capacity > 0 && e.AttendeeCount >= e.Capacity
+}
+
+// QRCodeData はイベントのQRコードに含めるデータ
+func (e *Event) QRCodeData() string {
+	return eventQRPrefix + e.Code
+}
+
+// ParseEventQRCodeData は読み取ったQRコードのデータからイベントのコードを取り出す（コードのみの入力も受け付ける）
+func ParseEventQRCodeData(data string) string {
+	return strings.TrimPrefix(strings.TrimSpace(data), eventQRPrefix)
+}
+
+// EventAttendance はイベントへのチェックインの記録（1ユーザー1回）
+type EventAttendance struct {
+	ID            uuid.UUID
+	EventID       uuid.UUID
+	UserID        uuid.UUID
+	Points        int64
+	TransactionID uuid.UUID
+	CreatedAt     time.Time
+}
+
+// NewEventAttendance はチェックインの記録を作成
+func NewEventAttendance(event *Event, userID, transactionID uuid.UUID) *EventAttendance {
+	return &EventAttendance{
+		ID:            uuid.New(),
+		EventID:       event.ID,
+		UserID:        userID,
+		Points:        event.Points,
+		TransactionID: transactionID,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// EventAttendee はイベントの参加者（管理者向けの結果一覧）
+type EventAttendee struct {
+	UserID      uuid.UUID
+	Username    string
+	DisplayName string
+	Points      int64
+	CheckedInAt time.Time
+}
@@ -0,0 +1,123 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FailedAkerunAccessStatus は付与に失敗したアクセス記録の状態
+type FailedAkerunAccessStatus string
+
+const (
+	// FailedAkerunAccessPending は再試行待ち
+	FailedAkerunAccessPending FailedAkerunAccessStatus = "pending"
+	// FailedAkerunAccessDead は再試行の上限に達し、管理者の再投入を待っている
+	FailedAkerunAccessDead FailedAkerunAccessStatus = "dead"
+)
+
+// IsValid は状態が定義済みかを判定
+func (s FailedAkerunAccessStatus) IsValid() bool {
+	return s == FailedAkerunAccessPending || s == FailedAkerunAccessDead
+}
+
+const (
+	// FailedAkerunAccessMaxAttempts はデッドレターに移すまでの失敗回数（最初の失敗を含む）
+	FailedAkerunAccessMaxAttempts = 5
+	// FailedAkerunAccessBaseBackoff は最初の再試行までの待ち時間（失敗のたびに倍にする）
+	FailedAkerunAccessBaseBackoff = 1 * time.Minute
+	// FailedAkerunAccessMaxBackoff は再試行までの待ち時間の上限
+	FailedAkerunAccessMaxBackoff = 1 * time.Hour
+)
+
+// FailedAkerunAccess はボーナスの付与に失敗したAkerunアクセス記録（再試行キュー）
+// 同じアクセス記録は1件だけ登録し、付与できたら削除する
+type FailedAkerunAccess struct {
+	ID          uuid.UUID
+	AccessID    uuid.UUID // Akerunアクセス記録ID
+	UserName    string    // Akerunユーザー名
+	AccessedAt  time.Time
+	Status      FailedAkerunAccessStatus
+	Attempts    int // 失敗した回数（最初の失敗を含む）
+	LastError   string
+	NextRetryAt time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	OrganizationID string
+	DoorID         string
+	DoorName       string
+}
+
+// NewFailedAkerunAccess は最初の失敗を記録した再試行待ちのアクセス記録を作成
+func NewFailedAkerunAccess(access AccessRecord, cause error, now time.Time) *FailedAkerunAccess {
+	f := &FailedAkerunAccess{
+		ID:         uuid.New(),
+		AccessID:   access.ID,
+		UserName:   access.UserName,
+		AccessedAt: access.AccessedAt,
+		Status:     FailedAkerunAccessPending,
+		CreatedAt:  now,
+
+		OrganizationID: access.OrganizationID,
+		DoorID:         access.DoorID,
+		DoorName:       access.DoorName,
+	}
+	f.RecordFailure(cause, now)
+	return f
+}
+
+// FailedAkerunAccessBackoff はattempts回失敗した後、次の再試行までの待ち時間を返す
+func FailedAkerunAccessBackoff(attempts int) time.Duration {
+	backoff := FailedAkerunAccessBaseBackoff
+	for n := 1; n < attempts; n++ {
+		backoff *= 2
+		if backoff >= FailedAkerunAccessMaxBackoff {
+			return FailedAkerunAccessMaxBackoff
+		}
+	}
+	return backoff
+}
+
+// Access は再処理に使うアクセス記録を返す
+func (f *FailedAkerunAccess) Access() AccessRecord {
+	return AccessRecord{
+		ID:             f.AccessID,
+		UserName:       f.UserName,
+		AccessedAt:     f.AccessedAt,
+		OrganizationID: f.OrganizationID,
+		DoorID:         f.DoorID,
+		DoorName:       f.DoorName,
+	}
+}
+
+// RecordFailure は失敗を記録し、上限に達したらデッドレターに移す
+func (f *FailedAkerunAccess) RecordFailure(cause error, now time.Time) {
+	f.Attempts++
+	if cause != nil {
+		f.LastError = cause.Error()
+	}
+	f.UpdatedAt = now
+	if f.Attempts >= FailedAkerunAccessMaxAttempts {
+		f.Status = FailedAkerunAccessDead
+		return
+	}
+	f.NextRetryAt = now.Add(FailedAkerunAccessBackoff(f.Attempts))
+}
+
+// IsDead はデッドレターかを判定
+func (f *FailedAkerunAccess) IsDead() bool {
+	return f.Status == FailedAkerunAccessDead
+}
+
+// Requeue はデッドレターを再試行待ちに戻す（失敗回数は0からやり直す）
+func (f *FailedAkerunAccess) Requeue(now time.Time) error {
+	if !f.IsDead() {
+		return ErrFailedAccessNotDead
+	}
+	f.Status = FailedAkerunAccessPending
+	f.Attempts = 0
+	f.NextRetryAt = now
+	f.UpdatedAt = now
+	return nil
+}
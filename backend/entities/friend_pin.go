@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// FriendPinMaxPerUser は1人がピン留めできる友達の上限
+	FriendPinMaxPerUser = 10
+	// QuickSendLookback はクイック送金で覚えておく送金額の集計期間
+	QuickSendLookback = 180 * 24 * time.Hour
+	// QuickSendMaxFriends はクイック送金の並び替えの対象にする友達の上限
+	QuickSendMaxFriends = 500
+)
+
+// FriendPin はクイック送金の先頭に表示する友達（ピン留め）
+type FriendPin struct {
+	UserID    uuid.UUID
+	FriendID  uuid.UUID
+	CreatedAt time.Time
+}
+
+// NewFriendPin は友達のピン留めを作成（自分自身はピン留めできない）
+func NewFriendPin(userID, friendID uuid.UUID) (*FriendPin, error) {
+	if userID == friendID {
+		return nil, ErrPinTargetNotFriend
+	}
+	return &FriendPin{UserID: userID, FriendID: friendID, CreatedAt: time.Now()}, nil
+}
+
+// TransferAmountUsage は送金先・金額ごとの送金回数と最後に送った日時（送金履歴の集計結果）
+type TransferAmountUsage struct {
+	RecipientID uuid.UUID
+	Amount      int64
+	Count       int64
+	LastSentAt  time.Time
+}
+
+// RememberedAmounts は送金先ごとに覚えておく送金額
+type RememberedAmounts struct {
+	LastAmount     int64 // 最後に送った金額
+	FrequentAmount int64 // 最もよく送った金額（同じ回数なら最近送った方）
+	TransferCount  int64
+	LastSentAt     time.Time
+}
+
+// SummarizeTransferAmounts は送金履歴の集計結果から送金先ごとの送金額をまとめる
+func SummarizeTransferAmounts(usages []*TransferAmountUsage) map[uuid.UUID]*RememberedAmounts {
+	summaries := make(map[uuid.UUID]*RememberedAmounts)
+	frequentCount := make(map[uuid.UUID]int64)
+	frequentAt := make(map[uuid.UUID]time.Time)
+
+	for _, u := range usages {
+		s, ok := summaries[u.RecipientID]
+		if !ok {
+			s = &RememberedAmounts{}
+			summaries[u.RecipientID] = s
+		}
+		s.TransferCount += u.Count
+		if u.LastSentAt.After(s.LastSentAt) {
+			s.LastSentAt = u.LastSentAt
+			s.LastAmount = u.Amount
+		}
+		if c := frequentCount[u.RecipientID]; u.Count > c || (u.Count == c && u.LastSentAt.After(frequentAt[u.RecipientID])) {
+			frequentCount[u.RecipientID] = u.Count
+			frequentAt[u.RecipientID] = u.LastSentAt
+			s.FrequentAmount = u.Amount
+		}
+	}
+	return summaries
+}
+
+// QuickSendEntry はクイック送金の1件（友達と、その友達に覚えておく送金額）
+type QuickSendEntry struct {
+	Friend   *User
+	PinnedAt *time.Time         // ピン留めしていなければnil
+	Amounts  *RememberedAmounts // 集計期間に送金していなければnil
+}
+
+// SortQuickSendEntries はクイック送金の表示順に並べる
+// ピン留めした友達（ピン留めした順）、最近送金した友達、ユーザー名の順
+func SortQuickSendEntries(entries []*QuickSendEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if (a.PinnedAt != nil) != (b.PinnedAt != nil) {
+			return a.PinnedAt != nil
+		}
+		if a.PinnedAt != nil && !a.PinnedAt.Equal(*b.PinnedAt) {
+			return a.PinnedAt.Before(*b.PinnedAt)
+		}
+		if (a.Amounts != nil) != (b.Amounts != nil) {
+			return a.Amounts != nil
+		}
+		if a.Amounts != nil && !a.Amounts.LastSentAt.Equal(b.Amounts.LastSentAt) {
+			return a.Amounts.LastSentAt.After(b.Amounts.LastSentAt)
+		}
+		return a.Friend.Username < b.Friend.Username
+	})
+}
@@ -69,6 +69,14 @@ func (f *Friendship) Block() {
 	f.UpdatedAt = time.Now()
 }
 
+// FriendsSince は友達になった日時（承認済みの関係は承認以降更新されないため、更新日時を承認日時とみなす）
+func (f *Friendship) FriendsSince() time.Time {
+	if f.UpdatedAt.IsZero() {
+		return f.CreatedAt
+	}
+	return f.UpdatedAt
+}
+
 // IsAccepted は友達関係が承認済みかどうかを確認
 func (f *Friendship) IsAccepted() bool {
 	return f.Status == FriendshipStatusAccepted
@@ -79,3 +87,15 @@ type FriendshipWithUser struct {
 	Friendship *Friendship
 	User       *User
 }
+
+// PointsExchanged はユーザーと相手の間でやり取りしたポイントの合計（完了した取引のみ）
+type PointsExchanged struct {
+	CounterpartID uuid.UUID
+	Sent          int64 // ユーザーから相手へ
+	Received      int64 // 相手からユーザーへ
+}
+
+// Total は両方向の合計
+func (p PointsExchanged) Total() int64 {
+	return p.Sent + p.Received
+}
@@ -0,0 +1,77 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// IdempotentResponseTTL は保存したレスポンスを再送に使う期間
+	IdempotentResponseTTL = 24 * time.Hour
+	// IdempotencyKeyMaxLength はIdempotency-Keyヘッダーの最大長
+	IdempotencyKeyMaxLength = 255
+)
+
+// IdempotentRequest はIdempotency-Keyヘッダー付きリクエストの処理状態と保存済みレスポンス
+// (Scope, Key, Route) ごとに1件だけ存在する
+type IdempotentRequest struct {
+	ID           uuid.UUID
+	Scope        string // リクエストの主体（"user:<id>" / "kiosk:<id>"）
+	Key          string // Idempotency-Keyヘッダーの値
+	Route        string // "POST /api/v1/qrcodes/scan"
+	RequestHash  string // リクエストボディのハッシュ（同じキーでの別リクエストの検出用）
+	StatusCode   int    // 0 = 処理中
+	ContentType  string
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+	CompletedAt  *time.Time
+}
+
+// NewIdempotentRequest は処理中の状態で新しいIdempotentRequestを作成
+func NewIdempotentRequest(scope, key, route, requestHash string, now time.Time) (*IdempotentRequest, error) {
+	if key == "" || len(key) > IdempotencyKeyMaxLength {
+		return nil, ErrIdempotencyKeyInvalid
+	}
+
+	return &IdempotentRequest{
+		ID:          uuid.New(),
+		Scope:       scope,
+		Key:         key,
+		Route:       route,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(IdempotentResponseTTL),
+	}, nil
+}
+
+// IsExpired は保存期間を過ぎているかを判定
+func (r *IdempotentRequest) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// IsCompleted はレスポンスが保存済みかを判定
+func (r *IdempotentRequest) IsCompleted() bool {
+	return r.StatusCode != 0
+}
+
+// CheckRetry は同じキーで再送されたリクエストを検証する
+// ボディが違えばキーの使い回し、処理が終わっていなければ並行リクエストとしてエラーを返す
+func (r *IdempotentRequest) CheckRetry(requestHash string) error {
+	if r.RequestHash != requestHash {
+		return ErrIdempotencyKeyReused
+	}
+	if !r.IsCompleted() {
+		return ErrRequestInProgress
+	}
+	return nil
+}
+
+// Complete はレスポンスを保存して処理済みにする
+func (r *IdempotentRequest) Complete(statusCode int, contentType string, body []byte, now time.Time) {
+	r.StatusCode = statusCode
+	r.ContentType = contentType
+	r.ResponseBody = body
+	r.CompletedAt = &now
+}
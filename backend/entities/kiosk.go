@@ -0,0 +1,151 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// KioskDevice はキオスク端末エンティティ
+// 端末はAPIキーで認証され、設定されたボーナスを付与できる
+type KioskDevice struct {
+	ID          uuid.UUID
+	Name        string
+	APIKeyHash  string // APIキーのSHA-256（平文は登録時のみ返す）
+	BonusAmount int64  // 1回の付与ポイント
+	DailyLimit  int    // 1日あたりの付与回数上限（0 = 無制限）
+	IsActive    bool
+	LastSeenAt  *time.Time
+	CreatedBy   *uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewKioskDevice は新しいキオスク端末を作成し、平文のAPIキーを併せて返す
+func NewKioskDevice(name string, bonusAmount int64, dailyLimit int, createdBy uuid.UUID) (*KioskDevice, string, error) {
+	if err := validateKioskSettings(name, bonusAmount, dailyLimit); err != nil {
+		return nil, "", err
+	}
+
+	apiKey, err := GenerateKioskAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	return &KioskDevice{
+		ID:          uuid.New(),
+		Name:        name,
+		APIKeyHash:  HashKioskAPIKey(apiKey),
+		BonusAmount: bonusAmount,
+		DailyLimit:  dailyLimit,
+		IsActive:    true,
+		CreatedBy:   &createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, apiKey, nil
+}
+
+// validateKioskSettings は端末設定を検証
+func validateKioskSettings(name string, bonusAmount int64, dailyLimit int) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("device name is required")
+	}
+	if bonusAmount <= 0 {
+		return errors.New("bonus amount must be positive")
+	}
+	if dailyLimit < 0 {
+		return errors.New("daily limit must not be negative")
+	}
+	return nil
+}
+
+// UpdateSettings は端末設定を更新
+func (d *KioskDevice) UpdateSettings(name string, bonusAmount int64, dailyLimit int) error {
+	if err := validateKioskSettings(name, bonusAmount, dailyLimit); err != nil {
+		return err
+	}
+	d.Name = name
+	d.BonusAmount = bonusAmount
+	d.DailyLimit = dailyLimit
+	d.UpdatedAt = time.Now()
+	return nil
+}
+
+// RotateAPIKey はAPIキーを再発行し、平文のAPIキーを返す
+func (d *KioskDevice) RotateAPIKey() (string, error) {
+	apiKey, err := GenerateKioskAPIKey()
+	if err != nil {
+		return "", err
+	}
+	d.APIKeyHash = HashKioskAPIKey(apiKey)
+	d.UpdatedAt = time.Now()
+	return apiKey, nil
+}
+
+// Deactivate は端末を無効化
+func (d *KioskDevice) Deactivate() {
+	d.IsActive = false
+	d.UpdatedAt = time.Now()
+}
+
+// HasReachedDailyLimit は本日の付与回数が上限に達しているかを判定
+func (d *KioskDevice) HasReachedDailyLimit(grantedToday int64) bool {
+	return d.DailyLimit > 0 && grantedToday >= int64(d.DailyLimit)
+}
+
+// GenerateKioskAPIKey は端末用のAPIキーを生成
+func GenerateKioskAPIKey() (string, error) {
+	token, err := GenerateSecureTokenHex(32)
+	if err != nil {
+		return "", err
+	}
+	return "kiosk_" + token, nil
+}
+
+// HashKioskAPIKey はAPIキーをSHA-256でハッシュ化
+func HashKioskAPIKey(apiKey string) string {
+	return HashToken(apiKey)
+}
+
+// KioskCard はカードIDとユーザーの紐付け
+type KioskCard struct {
+	CardID    string
+	UserID    uuid.UUID
+	CreatedAt time.Time
+}
+
+// KioskGrant はキオスク端末によるポイント付与記録
+type KioskGrant struct {
+	ID             uuid.UUID
+	DeviceID       uuid.UUID
+	UserID         uuid.UUID
+	TransactionID  *uuid.UUID
+	Amount         int64
+	IdempotencyKey string // 端末側で生成（オフライン再送時も同じ値）
+	CreatedAt      time.Time
+}
+
+// NewKioskGrant は新しいキオスク付与記録を作成
+func NewKioskGrant(deviceID, userID uuid.UUID, transactionID *uuid.UUID, amount int64, idempotencyKey string) (*KioskGrant, error) {
+	if idempotencyKey == "" {
+		return nil, ErrIdempotencyKeyRequired
+	}
+	return &KioskGrant{
+		ID:             uuid.New(),
+		DeviceID:       deviceID,
+		UserID:         userID,
+		TransactionID:  transactionID,
+		Amount:         amount,
+		IdempotencyKey: idempotencyKey,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// GetKioskDayStart はlocの暦日の開始時刻を返す（端末の日次上限の区切り）
+func GetKioskDayStart(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+}
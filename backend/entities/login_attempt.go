@@ -0,0 +1,210 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ログイン失敗理由
+const (
+	LoginFailureInvalidCredentials = "invalid_credentials"
+	LoginFailureAccountLocked      = "account_locked"
+	LoginFailureIPBlocked          = "ip_blocked"
+	LoginFailureInactive           = "inactive"
+	LoginFailureEmailNotVerified   = "email_not_verified"
+)
+
+// ロックアウトポリシー
+const (
+	LoginMaxFailuresPerAccount = 5                // アカウント単位の連続失敗上限
+	LoginMaxFailuresPerIP      = 20               // IP単位の失敗上限（LoginIPFailureWindow内）
+	LoginIPFailureWindow       = 15 * time.Minute // IP単位の集計期間
+	LoginBaseLockDuration      = 15 * time.Minute // 初回ロック時間（ロックごとに倍増）
+	LoginMaxLockDuration       = 24 * time.Hour   // ロック時間の上限
+	AccountUnlockTokenTTL      = 1 * time.Hour    // メールでのロック解除リンクの有効期限
+)
+
+// ログイン履歴で本人に確認を促す基準
+const (
+	LoginAnomalyFailureThreshold = 3         // この回数以上の失敗が続いたら強調する
+	LoginAnomalyFailureWindow    = time.Hour // 失敗が続いたとみなす期間
+)
+
+// LoginAttempt はログイン試行の記録
+type LoginAttempt struct {
+	ID            uuid.UUID
+	UserID        *uuid.UUID // 存在しないユーザー名の場合はnil
+	Username      string
+	IPAddress     string
+	UserAgent     string
+	Country       string // ISO 3166-1 alpha-2（不明な場合は空）
+	Success       bool
+	FailureReason string
+	NewDevice     bool // 初めての端末・国からのログイン成功（新しい端末の通知を送ったもの）
+	CreatedAt     time.Time
+}
+
+// NewLoginAttempt は新しいログイン試行記録を作成
+func NewLoginAttempt(userID *uuid.UUID, username, ipAddress, userAgent, country string, success bool, failureReason string) *LoginAttempt {
+	return &LoginAttempt{
+		ID:            uuid.New(),
+		UserID:        userID,
+		Username:      username,
+		IPAddress:     ipAddress,
+		UserAgent:     userAgent,
+		Country:       country,
+		Success:       success,
+		FailureReason: failureReason,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// LoginAnomaly はログイン履歴で本人に確認を促す理由
+type LoginAnomaly string
+
+const (
+	LoginAnomalyNone             LoginAnomaly = ""
+	LoginAnomalyNewDevice        LoginAnomaly = "new_device"        // 初めての端末・国からのログイン成功
+	LoginAnomalyAccountLocked    LoginAnomaly = "account_locked"    // ロック中にログインを試みられた
+	LoginAnomalyRepeatedFailures LoginAnomaly = "repeated_failures" // 短い間にパスワードを何度も間違えた
+)
+
+// LoginHistoryEntry はログイン履歴の1件
+type LoginHistoryEntry struct {
+	LoginAttempt
+	Anomaly LoginAnomaly
+}
+
+// NewLoginHistory は新しい順に並んだログイン試行に、本人に確認を促す理由を付ける
+// 続いた失敗は渡された範囲の中だけで判定する（ページの境目をまたいだ失敗は数えない）
+func NewLoginHistory(attempts []*LoginAttempt) []*LoginHistoryEntry {
+	entries := make([]*LoginHistoryEntry, len(attempts))
+	for i, a := range attempts {
+		entry := &LoginHistoryEntry{LoginAttempt: *a}
+		switch {
+		case a.Success && a.NewDevice:
+			entry.Anomaly = LoginAnomalyNewDevice
+		case a.FailureReason == LoginFailureAccountLocked:
+			entry.Anomaly = LoginAnomalyAccountLocked
+		case !a.Success && countNearbyFailures(attempts, a.CreatedAt) >= LoginAnomalyFailureThreshold:
+			entry.Anomaly = LoginAnomalyRepeatedFailures
+		}
+		entries[i] = entry
+	}
+	return entries
+}
+
+// countNearbyFailures は指定日時の前後LoginAnomalyFailureWindow以内の失敗の数
+func countNearbyFailures(attempts []*LoginAttempt, at time.Time) int {
+	count := 0
+	for _, a := range attempts {
+		if !a.Success && a.CreatedAt.Sub(at).Abs() <= LoginAnomalyFailureWindow {
+			count++
+		}
+	}
+	return count
+}
+
+// Masked は本人向けにIPアドレスの末尾を伏せた履歴を返す
+func (e *LoginHistoryEntry) Masked() *LoginHistoryEntry {
+	masked := *e
+	if e.IPAddress != "" {
+		masked.IPAddress = MaskIPAddress(e.IPAddress)
+	}
+	return &masked
+}
+
+// AccountLockout はアカウントのロックアウト状態
+type AccountLockout struct {
+	UserID               uuid.UUID
+	FailedCount          int        // 直近の連続失敗回数
+	LockCount            int        // 連続ロック回数（ロック時間の倍増に使用）
+	LockedUntil          *time.Time // nil = ロックなし
+	UnlockTokenHash      string     // メールで送るロック解除トークンのハッシュ
+	UnlockTokenExpiresAt *time.Time
+	UpdatedAt            time.Time
+}
+
+// NewAccountLockout は初期状態のロックアウト情報を作成
+func NewAccountLockout(userID uuid.UUID) *AccountLockout {
+	return &AccountLockout{
+		UserID:    userID,
+		UpdatedAt: time.Now(),
+	}
+}
+
+// IsLocked は指定時刻にロック中かどうかを判定
+func (l *AccountLockout) IsLocked(now time.Time) bool {
+	return l.LockedUntil != nil && now.Before(*l.LockedUntil)
+}
+
+// RecordFailure はログイン失敗を記録し、今回の失敗でロックされた場合trueを返す
+// ロック時間は LoginBaseLockDuration から連続ロックごとに倍増する（上限 LoginMaxLockDuration）
+func (l *AccountLockout) RecordFailure(now time.Time) bool {
+	l.FailedCount++
+	l.UpdatedAt = now
+
+	if l.FailedCount < LoginMaxFailuresPerAccount {
+		return false
+	}
+
+	l.LockCount++
+	duration := LoginBaseLockDuration
+	for i := 1; i < l.LockCount && duration < LoginMaxLockDuration; i++ {
+		duration *= 2
+	}
+	if duration > LoginMaxLockDuration {
+		duration = LoginMaxLockDuration
+	}
+
+	lockedUntil := now.Add(duration)
+	l.LockedUntil = &lockedUntil
+	l.FailedCount = 0
+	return true
+}
+
+// IssueUnlockToken はメール用のロック解除トークンを発行し、平文トークンを返す
+func (l *AccountLockout) IssueUnlockToken(now time.Time) (string, error) {
+	token, err := GenerateSecureTokenHex(32)
+	if err != nil {
+		return "", err
+	}
+
+	expiresAt := now.Add(AccountUnlockTokenTTL)
+	l.UnlockTokenHash = HashToken(token)
+	l.UnlockTokenExpiresAt = &expiresAt
+	l.UpdatedAt = now
+	return token, nil
+}
+
+// Unlock はロック解除トークンでロックを解除する
+// 連続ロック回数は維持し、解除直後の再ロックも段階的に長くなるようにする
+func (l *AccountLockout) Unlock(now time.Time) error {
+	if l.UnlockTokenExpiresAt == nil || now.After(*l.UnlockTokenExpiresAt) {
+		return errors.New("unlock token expired")
+	}
+
+	l.LockedUntil = nil
+	l.FailedCount = 0
+	l.UnlockTokenHash = ""
+	l.UnlockTokenExpiresAt = nil
+	l.UpdatedAt = now
+	return nil
+}
+
+// Reset はログイン成功時にロックアウト状態を初期化
+func (l *AccountLockout) Reset(now time.Time) {
+	l.FailedCount = 0
+	l.LockCount = 0
+	l.LockedUntil = nil
+	l.UnlockTokenHash = ""
+	l.UnlockTokenExpiresAt = nil
+	l.UpdatedAt = now
+}
+
+// IsClean はロックアウト状態が初期状態かどうか（保存不要の判定用）
+func (l *AccountLockout) IsClean() bool {
+	return l.FailedCount == 0 && l.LockCount == 0 && l.LockedUntil == nil && l.UnlockTokenHash == ""
+}
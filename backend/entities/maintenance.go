@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaintenanceSettingKey はメンテナンスモードを保存するsystem_settingsのキー
+const MaintenanceSettingKey = "maintenance_mode"
+
+// MaintenanceMode はメンテナンスモードの状態
+// 有効な間は状態を変更するAPIを拒否し（参照は可能）、バックグラウンドワーカーも停止する
+type MaintenanceMode struct {
+	Enabled        bool        `json:"enabled"`
+	Message        string      `json:"message"`          // ユーザーに表示するメッセージ（空なら既定の文言）
+	AllowedUserIDs []uuid.UUID `json:"allowed_user_ids"` // メンテナンス中も操作できるユーザー（動作確認用の管理者）
+	UpdatedBy      *uuid.UUID  `json:"updated_by,omitempty"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+// CanBypass はユーザーがメンテナンス中でも状態変更できるかどうか
+func (m *MaintenanceMode) CanBypass(userID uuid.UUID) bool {
+	if !m.Enabled {
+		return true
+	}
+	for _, id := range m.AllowedUserIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
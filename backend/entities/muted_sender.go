@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MuteMode はミュートした送信者からの送金リクエストの扱い
+type MuteMode string
+
+const (
+	MuteModeReject MuteMode = "reject" // 作成と同時に拒否する（送信者には拒否として見える）
+	MuteModeDrop   MuteMode = "drop"   // 承認待ちのまま受取人に見せず、期限切れにする（送信者には拒否されたと分からない）
+)
+
+// Validate はミュートの方法を検証
+func (m MuteMode) Validate() error {
+	switch m {
+	case MuteModeReject, MuteModeDrop:
+		return nil
+	default:
+		return ErrInvalidMuteMode
+	}
+}
+
+// MutedSender はユーザーがミュートした送金リクエストの送信者
+// ミュートした送信者からの送金リクエストは通知せず、承認待ちの一覧・件数にも含めない
+type MutedSender struct {
+	UserID      uuid.UUID // ミュートした人（送金リクエストの受取人）
+	MutedUserID uuid.UUID // ミュートされた送信者
+	Mode        MuteMode
+	CreatedAt   time.Time
+}
+
+// NewMutedSender は送信者のミュートを作成（自分自身はミュートできない）
+func NewMutedSender(userID, mutedUserID uuid.UUID, mode MuteMode) (*MutedSender, error) {
+	if userID == mutedUserID {
+		return nil, ErrCannotMuteSelf
+	}
+	if err := mode.Validate(); err != nil {
+		return nil, err
+	}
+	return &MutedSender{UserID: userID, MutedUserID: mutedUserID, Mode: mode, CreatedAt: time.Now()}, nil
+}
+
+// MutedSenderWithUser はミュートした送信者とそのユーザー情報
+type MutedSenderWithUser struct {
+	*MutedSender
+	User *User
+}
@@ -0,0 +1,320 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationType はユーザーへ通知するイベントの種類
+type NotificationType string
+
+const (
+	NotificationTypeTransferRequest NotificationType = "transfer_request_received" // 送金リクエストが届いた
+	NotificationTypePointsReceived  NotificationType = "points_received"           // ポイントを受け取った
+	NotificationTypePointsExpiring  NotificationType = "points_expiring"           // ポイントの有効期限が近い
+	NotificationTypeNewLogin        NotificationType = "new_login"                 // 新しい端末・国からログインした
+	NotificationTypeBudgetAlert     NotificationType = "budget_alert"              // 管理者付与の予算の消化率が閾値を超えた（管理者向け）
+	NotificationTypeWeeklyDigest    NotificationType = "weekly_digest"             // 週次のまとめ（定期実行ジョブがメールで送る）
+	NotificationTypeGiftReceived    NotificationType = "gift_received"             // 友達から商品が贈られた
+
+	// NotificationTypeSuspiciousTransfer は不審な送金を検出した（管理者向け）
+	NotificationTypeSuspiciousTransfer NotificationType = "suspicious_transfer"
+)
+
+// NotificationChannel は通知を届ける経路
+type NotificationChannel string
+
+const (
+	NotificationChannelPush  NotificationChannel = "push"   // 登録済みの端末へのプッシュ通知
+	NotificationChannelEmail NotificationChannel = "email"  // 登録メールアドレス
+	NotificationChannelInApp NotificationChannel = "in_app" // ログイン中の画面（WebSocket）
+)
+
+// notificationChannels は種類ごとに届ける経路（実際に届けるかは通知設定で決まる）
+var notificationChannels = map[NotificationType][]NotificationChannel{
+	NotificationTypeTransferRequest: {NotificationChannelPush, NotificationChannelInApp},
+	NotificationTypePointsReceived:  {NotificationChannelPush, NotificationChannelInApp},
+	NotificationTypePointsExpiring:  {NotificationChannelPush, NotificationChannelEmail, NotificationChannelInApp},
+	NotificationTypeNewLogin:        {NotificationChannelEmail, NotificationChannelPush},
+	NotificationTypeBudgetAlert:     {NotificationChannelEmail, NotificationChannelInApp},
+	NotificationTypeWeeklyDigest:    {NotificationChannelEmail},
+	NotificationTypeGiftReceived:    {NotificationChannelPush, NotificationChannelInApp},
+
+	NotificationTypeSuspiciousTransfer: {NotificationChannelEmail, NotificationChannelInApp},
+}
+
+// Channels はその種類の通知を届ける経路を返す
+func (t NotificationType) Channels() []NotificationChannel {
+	return notificationChannels[t]
+}
+
+// PointsExpiringReminderWindow は有効期限が近いポイントを通知する期間（期限のこの期間前に1度だけ通知）
+const PointsExpiringReminderWindow = 7 * 24 * time.Hour
+
+// Notification はユーザーの端末へ届ける通知
+// Data はアプリが画面遷移に使う値（プッシュ通知のペイロードは文字列のみ）
+type Notification struct {
+	UserID    uuid.UUID
+	Type      NotificationType
+	Title     string
+	Body      string
+	Data      map[string]string
+	CreatedAt time.Time
+}
+
+// NewTransferRequestNotification は受取人へ送る「送金リクエストが届いた」通知を作成
+func NewTransferRequestNotification(req *TransferRequest, from *User) *Notification {
+	return &Notification{
+		UserID: req.ToUserID,
+		Type:   NotificationTypeTransferRequest,
+		Title:  "送金リクエストが届きました",
+		Body:   fmt.Sprintf("%sさんから%dポイントの送金リクエストが届いています", from.DisplayName, req.Amount),
+		Data: map[string]string{
+			"transfer_request_id": req.ID.String(),
+			"from_user_id":        req.FromUserID.String(),
+			"amount":              fmt.Sprint(req.Amount),
+		},
+		CreatedAt: time.Now(),
+	}
+}
+
+// NewSplitShareNotification は割り勘の参加者へ送る「支払いのリクエストが届いた」通知を作成
+// 通知設定は送金リクエストと同じ種類として扱う
+func NewSplitShareNotification(req *TransferRequest, requester *User) *Notification {
+	return &Notification{
+		UserID: req.FromUserID,
+		Type:   NotificationTypeTransferRequest,
+		Title:  "割り勘の支払いリクエストが届きました",
+		Body:   fmt.Sprintf("%sさんから割り勘で%dポイントの支払いをリクエストされています", requester.DisplayName, req.Amount),
+		Data: map[string]string{
+			"transfer_request_id": req.ID.String(),
+			"split_request_id":    req.SplitID.String(),
+			"to_user_id":          req.ToUserID.String(),
+			"amount":              fmt.Sprint(req.Amount),
+		},
+		CreatedAt: time.Now(),
+	}
+}
+
+// NewPointsReceivedNotification は受取人へ送る「ポイントを受け取った」通知を作成
+func NewPointsReceivedNotification(tx *Transaction, from *User) *Notification {
+	return &Notification{
+		UserID: *tx.ToUserID,
+		Type:   NotificationTypePointsReceived,
+		Title:  "ポイントを受け取りました",
+		Body:   fmt.Sprintf("%sさんから%dポイントを受け取りました", from.DisplayName, tx.Amount),
+		Data: map[string]string{
+			"transaction_id": tx.ID.String(),
+			"from_user_id":   from.ID.String(),
+			"amount":         fmt.Sprint(tx.Amount),
+		},
+		CreatedAt: time.Now(),
+	}
+}
+
+// NewGiftReceivedNotification は贈られた人へ送る「友達から商品が贈られた」通知を作成
+func NewGiftReceivedNotification(exchange *ProductExchange, product *Product, from *User) *Notification {
+	return &Notification{
+		UserID: exchange.Recipient(),
+		Type:   NotificationTypeGiftReceived,
+		Title:  "商品が贈られました",
+		Body:   fmt.Sprintf("%sさんから%s x%dが贈られました。受け取るか辞退するかを選んでください", from.DisplayName, product.Name, exchange.Quantity),
+		Data: map[string]string{
+			"exchange_id":  exchange.ID.String(),
+			"product_id":   product.ID.String(),
+			"from_user_id": from.ID.String(),
+		},
+		CreatedAt: time.Now(),
+	}
+}
+
+// NewPointsExpiringNotification は「ポイントの有効期限が近い」通知を作成
+// expiresAt は対象のうち最も早い有効期限
+func NewPointsExpiringNotification(userID uuid.UUID, amount int64, expiresAt time.Time) *Notification {
+	return &Notification{
+		UserID: userID,
+		Type:   NotificationTypePointsExpiring,
+		Title:  "ポイントの有効期限が近づいています",
+		Body:   fmt.Sprintf("%dポイントが%sに失効します", amount, expiresAt.Format("1月2日")),
+		Data: map[string]string{
+			"amount":     fmt.Sprint(amount),
+			"expires_at": expiresAt.Format(time.RFC3339),
+		},
+		CreatedAt: time.Now(),
+	}
+}
+
+// NewLoginNotification は「新しい端末・国からログインした」通知を作成
+func NewLoginNotification(user *User, ipAddress, userAgent, country string, loggedInAt time.Time) *Notification {
+	return &Notification{
+		UserID: user.ID,
+		Type:   NotificationTypeNewLogin,
+		Title:  "新しい端末からログインがありました",
+		Body:   fmt.Sprintf("%sに新しい端末からログインがありました。心当たりがない場合はパスワードを変更してください", loggedInAt.Format("1月2日 15:04")),
+		Data: map[string]string{
+			"ip_address":   ipAddress,
+			"user_agent":   userAgent,
+			"country":      country,
+			"logged_in_at": loggedInAt.Format(time.RFC3339),
+		},
+		CreatedAt: loggedInAt,
+	}
+}
+
+// DefaultQuietHoursTimezone はタイムゾーン未指定のおやすみ時間に使うタイムゾーン
+const DefaultQuietHoursTimezone = "Asia/Tokyo"
+
+// QuietHours はプッシュ通知を止める時間帯（StartからEndまで、日付をまたいでもよい）
+// Start・Endは0時からの分数で、Start == End は終日
+type QuietHours struct {
+	Enabled  bool
+	Start    int
+	End      int
+	Timezone string
+}
+
+// NewQuietHours は "HH:MM" 形式の時刻とIANAタイムゾーンからおやすみ時間を作成
+func NewQuietHours(enabled bool, start, end, timezone string) (QuietHours, error) {
+	startMin, ok := parseMinuteOfDay(start)
+	if !ok {
+		return QuietHours{}, ErrInvalidQuietHours
+	}
+	endMin, ok := parseMinuteOfDay(end)
+	if !ok {
+		return QuietHours{}, ErrInvalidQuietHours
+	}
+	if timezone == "" {
+		timezone = DefaultQuietHoursTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return QuietHours{}, ErrInvalidQuietHours
+	}
+	return QuietHours{Enabled: enabled, Start: startMin, End: endMin, Timezone: timezone}, nil
+}
+
+// Contains は時刻がおやすみ時間に含まれるかを判定（ユーザーのタイムゾーンで判定）
+func (q QuietHours) Contains(t time.Time) bool {
+	if !q.Enabled {
+		return false
+	}
+	if loc, err := time.LoadLocation(q.Timezone); err == nil {
+		t = t.In(loc)
+	}
+	minute := t.Hour()*60 + t.Minute()
+	if q.Start == q.End {
+		return true
+	}
+	if q.Start < q.End {
+		return minute >= q.Start && minute < q.End
+	}
+	// 22:00〜7:00 のように日付をまたぐ
+	return minute >= q.Start || minute < q.End
+}
+
+// StartString は開始時刻を "HH:MM" 形式で返す
+func (q QuietHours) StartString() string {
+	return formatMinuteOfDay(q.Start)
+}
+
+// EndString は終了時刻を "HH:MM" 形式で返す
+func (q QuietHours) EndString() string {
+	return formatMinuteOfDay(q.End)
+}
+
+func parseMinuteOfDay(s string) (int, bool) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 || len(parts[0]) != 2 || len(parts[1]) != 2 {
+		return 0, false
+	}
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, false
+	}
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, false
+	}
+	return hour*60 + minute, true
+}
+
+func formatMinuteOfDay(m int) string {
+	return fmt.Sprintf("%02d:%02d", m/60, m%60)
+}
+
+// NotificationPreferences はユーザーごとの通知設定（未設定のユーザーはすべて受け取る）
+type NotificationPreferences struct {
+	UserID       uuid.UUID
+	PushEnabled  bool // falseならすべてのプッシュ通知を止める
+	EmailEnabled bool // falseならすべての通知メールを止める
+
+	// 種類ごとの受け取り設定（オフにするとどの経路でも届かない）
+	TransferRequests bool
+	PointsReceived   bool
+	PointsExpiring   bool
+	NewLogin         bool
+	WeeklyDigest     bool // 週次のまとめメール
+
+	// QuietHours の間はプッシュ通知だけを止める（メール・画面への通知は届ける）
+	QuietHours QuietHours
+
+	UpdatedAt time.Time
+}
+
+// DefaultNotificationPreferences はすべての通知を受け取る初期設定を返す
+func DefaultNotificationPreferences(userID uuid.UUID) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:           userID,
+		PushEnabled:      true,
+		EmailEnabled:     true,
+		TransferRequests: true,
+		PointsReceived:   true,
+		PointsExpiring:   true,
+		NewLogin:         true,
+		WeeklyDigest:     true,
+		QuietHours: QuietHours{
+			Start:    22 * 60,
+			End:      7 * 60,
+			Timezone: DefaultQuietHoursTimezone,
+		},
+		UpdatedAt: time.Now(),
+	}
+}
+
+// AllowsType は種類ごとの設定でその通知を受け取るかを判定
+func (p *NotificationPreferences) AllowsType(t NotificationType) bool {
+	switch t {
+	case NotificationTypeTransferRequest:
+		return p.TransferRequests
+	case NotificationTypePointsReceived, NotificationTypeGiftReceived:
+		return p.PointsReceived // 贈り物は受け取りの通知と同じ設定に従う
+	case NotificationTypePointsExpiring:
+		return p.PointsExpiring
+	case NotificationTypeNewLogin:
+		return p.NewLogin
+	case NotificationTypeWeeklyDigest:
+		return p.WeeklyDigest
+	case NotificationTypeBudgetAlert, NotificationTypeSuspiciousTransfer:
+		return true // 運用上の通知のため種類ごとには止められない（メールは EmailEnabled に従う）
+	}
+	return false
+}
+
+// Allows はその通知を指定の経路で now に届けるかを判定
+func (p *NotificationPreferences) Allows(channel NotificationChannel, t NotificationType, now time.Time) bool {
+	if !p.AllowsType(t) {
+		return false
+	}
+	switch channel {
+	case NotificationChannelPush:
+		return p.PushEnabled && !p.QuietHours.Contains(now)
+	case NotificationChannelEmail:
+		return p.EmailEnabled
+	case NotificationChannelInApp:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// OnboardingBonusSettingKey は登録時のウェルカムボーナスの設定を保存するsystem_settingsのキー
+	OnboardingBonusSettingKey = "onboarding_bonus"
+	// MaxOnboardingBonusAmount は設定できるウェルカムボーナスの上限
+	MaxOnboardingBonusAmount = 100000
+)
+
+// OnboardingBonusPolicy は新規登録したユーザーに自動で付与するウェルカムボーナスの設定
+// 未設定なら付与しない
+type OnboardingBonusPolicy struct {
+	Enabled      bool       `json:"enabled"`
+	Amount       int64      `json:"amount"`
+	ValidityDays int        `json:"validity_days"` // 付与したポイントの有効日数（0なら POINT_EXPIRATION_MONTHS か月）
+	UpdatedBy    *uuid.UUID `json:"updated_by,omitempty"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// Validate は設定値を検証（無効にしている間も金額と有効日数は範囲内であること）
+func (p *OnboardingBonusPolicy) Validate() error {
+	if p.Amount < 0 || p.Amount > MaxOnboardingBonusAmount {
+		return ErrInvalidOnboardingBonus
+	}
+	if p.Enabled && p.Amount == 0 {
+		return ErrInvalidOnboardingBonus
+	}
+	if p.ValidityDays < 0 || p.ValidityDays > MaxPointValidityDays {
+		return ErrInvalidOnboardingBonus
+	}
+	return nil
+}
+
+// IsActive は登録時に付与するかを判定
+func (p *OnboardingBonusPolicy) IsActive() bool {
+	return p.Enabled && p.Amount > 0
+}
+
+// ExpiresAt はnowに付与したボーナスの有効期限
+func (p *OnboardingBonusPolicy) ExpiresAt(now time.Time) time.Time {
+	if p.ValidityDays > 0 {
+		return now.AddDate(0, 0, p.ValidityDays)
+	}
+	return now.AddDate(0, POINT_EXPIRATION_MONTHS, 0)
+}
+
+// OnboardingBonusIdempotencyKey はユーザーごとに1回だけ付与するための取引の冪等性キー
+func OnboardingBonusIdempotencyKey(userID uuid.UUID) string {
+	return "onboarding-bonus-" + userID.String()
+}
+
+// NewOnboardingBonus はウェルカムボーナスの取引を作成（発行元から付与し、冪等性キーでユーザーごとに1回に限る）
+func NewOnboardingBonus(toUserID uuid.UUID, policy *OnboardingBonusPolicy, now time.Time) (*Transaction, error) {
+	if !policy.IsActive() {
+		return nil, ErrInvalidAmount
+	}
+
+	key := OnboardingBonusIdempotencyKey(toUserID)
+	return &Transaction{
+		ID:              uuid.New(),
+		ToUserID:        &toUserID,
+		FromAccount:     SystemAccountTreasury,
+		Amount:          policy.Amount,
+		TransactionType: TransactionTypeOnboardingBonus,
+		Status:          TransactionStatusCompleted,
+		IdempotencyKey:  &key,
+		Description:     "ウェルカムボーナス",
+		Metadata: map[string]interface{}{
+			"validity_days": policy.ValidityDays,
+		},
+		CreatedAt:   now,
+		CompletedAt: ptrTime(now),
+	}, nil
+}
@@ -15,8 +15,25 @@ const (
 	PointBatchSourceDailyBonus  PointBatchSourceType = "daily_bonus"
 	PointBatchSourceSystemGrant PointBatchSourceType = "system_grant"
 	PointBatchSourceMigration   PointBatchSourceType = "migration"
+	// PointBatchSourceExpiryRestore は猶予期間内に管理者が失効を取り消したときの補填バッチ
+	PointBatchSourceExpiryRestore PointBatchSourceType = "expiry_restore"
+	// PointBatchSourceBalanceCorrection は残高の補正で増やした分のバッチ
+	PointBatchSourceBalanceCorrection PointBatchSourceType = "balance_correction"
+	// PointBatchSourceOnboardingBonus は登録時のウェルカムボーナスのバッチ（期限はボーナスの設定で決まる）
+	PointBatchSourceOnboardingBonus PointBatchSourceType = "onboarding_bonus"
 )
 
+// IsValid は既知のソースタイプかどうか
+func (t PointBatchSourceType) IsValid() bool {
+	switch t {
+	case PointBatchSourceTransfer, PointBatchSourceAdminGrant, PointBatchSourceDailyBonus,
+		PointBatchSourceSystemGrant, PointBatchSourceMigration, PointBatchSourceExpiryRestore,
+		PointBatchSourceBalanceCorrection, PointBatchSourceOnboardingBonus:
+		return true
+	}
+	return false
+}
+
 // PointExpirationDuration はポイントの有効期限（3ヶ月）
 const POINT_EXPIRATION_MONTHS = 3
 
@@ -31,6 +48,16 @@ type PointBatch struct {
 	SourceTransactionID *uuid.UUID
 	ExpiresAt           time.Time
 	CreatedAt           time.Time
+
+	// 失効処理の記録（猶予期間内の取り消しに使う）
+	ExpiredAt     *time.Time
+	ExpiredAmount int64 // 失効時に残っていたポイント
+	RestoredAt    *time.Time
+
+	// 管理者による調整の記録
+	ExpiryAdjustedAt *time.Time // 有効期限を個別に変更した日時（以降は有効期間の設定で再計算しない）
+	CancelledAt      *time.Time
+	CancelledAmount  int64 // 取り消し時に残っていたポイント
 }
 
 // NewPointBatch は新しいポイントバッチを作成
@@ -46,3 +73,99 @@ func NewPointBatch(userID uuid.UUID, amount int64, sourceType PointBatchSourceTy
 		CreatedAt:           now,
 	}
 }
+
+// CheckRestorable は失効を取り消せるか確認する
+// 失効済み・未取り消しで、失効から猶予日数以内のバッチのみ取り消せる
+func (b *PointBatch) CheckRestorable(now time.Time, graceDays int) error {
+	if b.ExpiredAt == nil || b.ExpiredAmount <= 0 {
+		return ErrPointBatchNotRestorable
+	}
+	if b.RestoredAt != nil {
+		return ErrPointBatchRestored
+	}
+	if now.After(b.ExpiredAt.AddDate(0, 0, graceDays)) {
+		return ErrPointBatchNotRestorable
+	}
+	return nil
+}
+
+// HasFixedExpiry は有効期間の設定の変更で期限を再計算しないバッチかどうか
+// 失効取り消しの補填バッチ、ウェルカムボーナスのバッチと、管理者が期限を個別に変更したバッチが該当する
+func (b *PointBatch) HasFixedExpiry() bool {
+	return b.SourceType == PointBatchSourceExpiryRestore || b.SourceType == PointBatchSourceOnboardingBonus ||
+		b.ExpiryAdjustedAt != nil
+}
+
+// CheckAdjustable は管理者が有効期限の変更・取り消しをできるか確認する（失効済み・取り消し済みは不可）
+func (b *PointBatch) CheckAdjustable() error {
+	if b.ExpiredAt != nil || b.CancelledAt != nil {
+		return ErrPointBatchNotAdjustable
+	}
+	return nil
+}
+
+// PointBatchStatus は管理画面に表示するバッチの状態
+type PointBatchStatus string
+
+const (
+	PointBatchStatusActive    PointBatchStatus = "active"    // 残量があり期限内
+	PointBatchStatusConsumed  PointBatchStatus = "consumed"  // すべて使用済み
+	PointBatchStatusExpired   PointBatchStatus = "expired"   // 失効済み（期限切れで失効処理待ちを含む）
+	PointBatchStatusRestored  PointBatchStatus = "restored"  // 失効を取り消し済み
+	PointBatchStatusCancelled PointBatchStatus = "cancelled" // 管理者が取り消し済み
+)
+
+// Status はバッチの状態を返す
+func (b *PointBatch) Status(now time.Time) PointBatchStatus {
+	switch {
+	case b.CancelledAt != nil:
+		return PointBatchStatusCancelled
+	case b.RestoredAt != nil:
+		return PointBatchStatusRestored
+	case b.ExpiredAt != nil:
+		return PointBatchStatusExpired
+	case b.RemainingAmount <= 0:
+		return PointBatchStatusConsumed
+	case !b.ExpiresAt.After(now):
+		return PointBatchStatusExpired
+	}
+	return PointBatchStatusActive
+}
+
+// BatchConsumption はFIFO消費で1つのバッチから引かれるポイント
+type BatchConsumption struct {
+	BatchID        uuid.UUID
+	SourceType     PointBatchSourceType
+	ExpiresAt      time.Time
+	Consumed       int64
+	RemainingAfter int64
+}
+
+// PlanPointConsumption はamountポイントを消費するときに、どのバッチからいくつ引かれるかを返す
+// batchesは古い順に並んでいること。ConsumePointsFIFOと同じく期限切れのバッチは使わない
+// バッチで賄えない分（バッチ導入前の残高など）はuncoveredとして返す
+func PlanPointConsumption(batches []*PointBatch, amount int64, now time.Time) (plan []*BatchConsumption, uncovered int64) {
+	plan = make([]*BatchConsumption, 0)
+	remaining := amount
+	for _, b := range batches {
+		if remaining <= 0 {
+			break
+		}
+		if b.RemainingAmount <= 0 || !b.ExpiresAt.After(now) {
+			continue
+		}
+		consume := b.RemainingAmount
+		if consume > remaining {
+			consume = remaining
+		}
+		plan = append(plan, &BatchConsumption{
+			BatchID:        b.ID,
+			SourceType:     b.SourceType,
+			ExpiresAt:      b.ExpiresAt,
+			Consumed:       consume,
+			RemainingAfter: b.RemainingAmount - consume,
+		})
+		remaining -= consume
+	}
+	return plan, remaining
+}
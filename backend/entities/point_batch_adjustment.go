@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// PointBatchMaxExpiryYears は管理者が設定できる有効期限の上限（現在から）
+	PointBatchMaxExpiryYears = 5
+	// pointBatchAdjustmentReasonMaxLength は調整理由の最大文字数
+	pointBatchAdjustmentReasonMaxLength = 500
+)
+
+// PointBatchAdjustmentAction は管理者によるポイントバッチの調整の種類
+type PointBatchAdjustmentAction string
+
+const (
+	PointBatchAdjustmentExpiryChanged PointBatchAdjustmentAction = "expiry_changed" // 有効期限の延長・短縮
+	PointBatchAdjustmentCancelled     PointBatchAdjustmentAction = "cancelled"      // 取り消し（残っていた分は残高から減算）
+)
+
+// PointBatchAdjustment は管理者によるポイントバッチの調整の監査記録
+type PointBatchAdjustment struct {
+	ID                uuid.UUID
+	BatchID           uuid.UUID
+	UserID            uuid.UUID
+	AdminID           uuid.UUID
+	Action            PointBatchAdjustmentAction
+	PreviousExpiresAt time.Time
+	NewExpiresAt      *time.Time // 有効期限の変更のみ
+	CancelledAmount   int64      // 取り消しで残高から減算したポイント
+	TransactionID     *uuid.UUID // 取り消しで減算した取引（残っていた分がなければnil）
+	Reason            string
+	CreatedAt         time.Time
+}
+
+// NewPointBatchExpiryChange はバッチの有効期限の変更を検証し、監査記録を作成する
+// 新しい期限は現在より後で、PointBatchMaxExpiryYears年以内、かつ現在の期限と異なること
+func NewPointBatchExpiryChange(batch *PointBatch, expiresAt time.Time, adminID uuid.UUID, reason string, now time.Time) (*PointBatchAdjustment, error) {
+	if err := batch.CheckAdjustable(); err != nil {
+		return nil, err
+	}
+	if !expiresAt.After(now) || expiresAt.After(now.AddDate(PointBatchMaxExpiryYears, 0, 0)) || expiresAt.Equal(batch.ExpiresAt) {
+		return nil, ErrInvalidBatchAdjustment
+	}
+	adjustment, err := newPointBatchAdjustment(batch, PointBatchAdjustmentExpiryChanged, adminID, reason, now)
+	if err != nil {
+		return nil, err
+	}
+	adjustment.NewExpiresAt = &expiresAt
+	return adjustment, nil
+}
+
+// NewPointBatchCancellation はバッチの取り消しを検証し、監査記録を作成する
+// 残っていたポイントはCancelledAmountに記録し、減算の取引は呼び出し側で設定する
+func NewPointBatchCancellation(batch *PointBatch, adminID uuid.UUID, reason string, now time.Time) (*PointBatchAdjustment, error) {
+	if err := batch.CheckAdjustable(); err != nil {
+		return nil, err
+	}
+	adjustment, err := newPointBatchAdjustment(batch, PointBatchAdjustmentCancelled, adminID, reason, now)
+	if err != nil {
+		return nil, err
+	}
+	adjustment.CancelledAmount = batch.RemainingAmount
+	return adjustment, nil
+}
+
+func newPointBatchAdjustment(batch *PointBatch, action PointBatchAdjustmentAction, adminID uuid.UUID, reason string, now time.Time) (*PointBatchAdjustment, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len([]rune(reason)) > pointBatchAdjustmentReasonMaxLength {
+		return nil, ErrInvalidBatchAdjustment
+	}
+	return &PointBatchAdjustment{
+		ID:                uuid.New(),
+		BatchID:           batch.ID,
+		UserID:            batch.UserID,
+		AdminID:           adminID,
+		Action:            action,
+		PreviousExpiresAt: batch.ExpiresAt,
+		Reason:            reason,
+		CreatedAt:         now,
+	}, nil
+}
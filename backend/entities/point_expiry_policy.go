@@ -0,0 +1,89 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// PointExpiryGraceDaysSettingKey は失効を取り消せる猶予日数を保存するsystem_settingsのキー
+	PointExpiryGraceDaysSettingKey = "point_expiry_grace_days"
+	// DefaultPointExpiryGraceDays は猶予日数の既定値
+	DefaultPointExpiryGraceDays = 30
+	// MaxPointExpiryGraceDays は設定できる猶予日数の上限
+	MaxPointExpiryGraceDays = 365
+
+	// MaxPointValidityDays は設定できる有効日数の上限（10年）
+	MaxPointValidityDays = 3650
+)
+
+// PointExpiryPolicy は付与種別ごとの有効期間
+// ポリシーがない種別は POINT_EXPIRATION_MONTHS か月で失効する
+type PointExpiryPolicy struct {
+	SourceType   PointBatchSourceType
+	ValidityDays int
+	UpdatedBy    *uuid.UUID
+	UpdatedAt    time.Time
+}
+
+// NewPointExpiryPolicy は新しいPointExpiryPolicyを作成
+func NewPointExpiryPolicy(sourceType PointBatchSourceType, validityDays int, adminID uuid.UUID) (*PointExpiryPolicy, error) {
+	if !sourceType.IsValid() || validityDays < 1 || validityDays > MaxPointValidityDays {
+		return nil, ErrInvalidExpiryPolicy
+	}
+	return &PointExpiryPolicy{
+		SourceType:   sourceType,
+		ValidityDays: validityDays,
+		UpdatedBy:    &adminID,
+		UpdatedAt:    time.Now(),
+	}, nil
+}
+
+// UserPointExpiryOverride はユーザー個別の有効期間
+// 設定されている間は付与種別に関係なくこの日数で失効する（長期休職者の延長など）
+type UserPointExpiryOverride struct {
+	UserID       uuid.UUID
+	ValidityDays int
+	Reason       string
+	UpdatedBy    *uuid.UUID
+	UpdatedAt    time.Time
+}
+
+// NewUserPointExpiryOverride は新しいUserPointExpiryOverrideを作成
+func NewUserPointExpiryOverride(userID uuid.UUID, validityDays int, reason string, adminID uuid.UUID) (*UserPointExpiryOverride, error) {
+	if validityDays < 1 || validityDays > MaxPointValidityDays {
+		return nil, ErrInvalidExpiryPolicy
+	}
+	return &UserPointExpiryOverride{
+		UserID:       userID,
+		ValidityDays: validityDays,
+		Reason:       strings.TrimSpace(reason),
+		UpdatedBy:    &adminID,
+		UpdatedAt:    time.Now(),
+	}, nil
+}
+
+// ResolvePointExpiry はバッチの有効期限を決める
+// 優先順位: ユーザー個別の設定 > 付与種別のポリシー > 既定（POINT_EXPIRATION_MONTHS か月）
+func ResolvePointExpiry(sourceType PointBatchSourceType, createdAt time.Time, policies []*PointExpiryPolicy, override *UserPointExpiryOverride) time.Time {
+	if override != nil {
+		return createdAt.AddDate(0, 0, override.ValidityDays)
+	}
+	for _, p := range policies {
+		if p.SourceType == sourceType {
+			return createdAt.AddDate(0, 0, p.ValidityDays)
+		}
+	}
+	return createdAt.AddDate(0, POINT_EXPIRATION_MONTHS, 0)
+}
+
+// ApplyExpiryPolicy は作成前のバッチに有効期間の設定を反映する
+// 失効取り消しの補填バッチ・ウェルカムボーナスのバッチは呼び出し側で期限を決めるため変更しない
+func (b *PointBatch) ApplyExpiryPolicy(policies []*PointExpiryPolicy, override *UserPointExpiryOverride) {
+	if b.HasFixedExpiry() {
+		return
+	}
+	b.ExpiresAt = ResolvePointExpiry(b.SourceType, b.CreatedAt, policies, override)
+}
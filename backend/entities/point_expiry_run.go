@@ -0,0 +1,39 @@
+package entities
+
+import "time"
+
+// PointExpiryRunSettingKey はポイント有効期限ワーカーの直近の実行状況を保存するsystem_settingsのキー
+const PointExpiryRunSettingKey = "point_expiry_worker_status"
+
+// PointExpiryRunState はポイント有効期限ワーカーの実行状態
+type PointExpiryRunState string
+
+const (
+	PointExpiryRunRunning     PointExpiryRunState = "running"
+	PointExpiryRunCompleted   PointExpiryRunState = "completed"
+	PointExpiryRunTimeLimited PointExpiryRunState = "time_limited" // 1回の実行時間の上限に達し、残りを次の実行に回した
+	PointExpiryRunFailed      PointExpiryRunState = "failed"
+)
+
+// PointExpiryRun はポイント有効期限ワーカーの1回の実行の進捗
+// 処理したページごとに更新するため、実行中でも進み具合を確認できる
+type PointExpiryRun struct {
+	State           PointExpiryRunState `json:"state"`
+	StartedAt       time.Time           `json:"started_at"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+	FinishedAt      *time.Time          `json:"finished_at,omitempty"`
+	Pages           int                 `json:"pages"`            // 取得したページ数（1ページ最大バッチサイズ件）
+	ExpiredBatches  int                 `json:"expired_batches"`  // 失効させたバッチ数
+	ExpiredUsers    int                 `json:"expired_users"`    // 失効の取引を作った回数（ユーザーごとにまとめる）
+	ExpiredPoints   int64               `json:"expired_points"`   // 失効させたポイント
+	ExtendedBatches int                 `json:"extended_batches"` // 設定の延長で期限を延ばしたバッチ数
+	FailedBatches   int                 `json:"failed_batches"`   // 失効・延長に失敗したバッチ数（次の実行で再試行）
+	Error           string              `json:"error,omitempty"`
+}
+
+// Finish は実行を終了した状態にする
+func (r *PointExpiryRun) Finish(state PointExpiryRunState, now time.Time) {
+	r.State = state
+	r.UpdatedAt = now
+	r.FinishedAt = &now
+}
@@ -0,0 +1,177 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PricingDiscountType は価格ルールの割引方法
+type PricingDiscountType string
+
+const (
+	PricingDiscountPercentage PricingDiscountType = "percentage" // DiscountValue%引き
+	PricingDiscountFixed      PricingDiscountType = "fixed"      // DiscountValueポイント引き
+)
+
+// PricingRuleNameMaxLength は価格ルール名の最大文字数
+const PricingRuleNameMaxLength = 100
+
+// PricingRule は管理者が設定する期間限定の割引
+// 対象は商品（ProductID）かカテゴリ（CategoryCode）のどちらか一方で、
+// TargetRole・MinTierを指定すると条件を満たす会員だけに適用する
+type PricingRule struct {
+	ID                 uuid.UUID
+	Name               string
+	ProductID          *uuid.UUID // 対象商品（CategoryCodeとどちらか一方）
+	CategoryCode       string     // 対象カテゴリ（ProductIDとどちらか一方）
+	DiscountType       PricingDiscountType
+	DiscountValue      int64    // percentageなら1〜100、fixedならポイント数
+	TargetRole         UserRole // 空なら全員
+	MinTier            UserTier // 空なら全員（指定したランク以上の会員価格）
+	MaxQuantityPerUser int      // 期間中に1人が割引価格で交換できる数量（0なら無制限）
+	StartsAt           time.Time
+	EndsAt             *time.Time // nilなら無期限
+	IsActive           bool
+	CreatedBy          uuid.UUID
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// NewPricingRule は新しい価格ルールを作成
+func NewPricingRule(name string, productID *uuid.UUID, categoryCode string, discountType PricingDiscountType, discountValue int64, targetRole UserRole, minTier UserTier, maxQuantityPerUser int, startsAt time.Time, endsAt *time.Time, createdBy uuid.UUID) (*PricingRule, error) {
+	now := time.Now()
+	r := &PricingRule{
+		ID:        uuid.New(),
+		CreatedBy: createdBy,
+		CreatedAt: now,
+	}
+	if err := r.Update(name, productID, categoryCode, discountType, discountValue, targetRole, minTier, maxQuantityPerUser, startsAt, endsAt, true); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Update は価格ルールの内容を検証して更新
+func (r *PricingRule) Update(name string, productID *uuid.UUID, categoryCode string, discountType PricingDiscountType, discountValue int64, targetRole UserRole, minTier UserTier, maxQuantityPerUser int, startsAt time.Time, endsAt *time.Time, isActive bool) error {
+	name = strings.TrimSpace(name)
+	categoryCode = strings.TrimSpace(categoryCode)
+	if name == "" || len([]rune(name)) > PricingRuleNameMaxLength {
+		return ErrInvalidPricingRule
+	}
+	if (productID == nil) == (categoryCode == "") {
+		return ErrInvalidPricingRule
+	}
+	switch discountType {
+	case PricingDiscountPercentage:
+		if discountValue < 1 || discountValue > 100 {
+			return ErrInvalidPricingRule
+		}
+	case PricingDiscountFixed:
+		if discountValue < 1 {
+			return ErrInvalidPricingRule
+		}
+	default:
+		return ErrInvalidPricingRule
+	}
+	if targetRole != "" && targetRole != RoleUser && targetRole != RoleAdmin {
+		return ErrInvalidPricingRule
+	}
+	if minTier != "" && !minTier.IsValid() {
+		return ErrInvalidPricingRule
+	}
+	if maxQuantityPerUser < 0 {
+		return ErrInvalidPricingRule
+	}
+	if startsAt.IsZero() {
+		startsAt = time.Now()
+	}
+	if endsAt != nil && !endsAt.After(startsAt) {
+		return ErrInvalidPricingRule
+	}
+
+	r.Name = name
+	r.ProductID = productID
+	r.CategoryCode = categoryCode
+	r.DiscountType = discountType
+	r.DiscountValue = discountValue
+	r.TargetRole = targetRole
+	r.MinTier = minTier
+	r.MaxQuantityPerUser = maxQuantityPerUser
+	r.StartsAt = startsAt
+	r.EndsAt = endsAt
+	r.IsActive = isActive
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// AppliesTo は指定日時・ユーザーでこの商品に適用されるかを判定
+func (r *PricingRule) AppliesTo(product *Product, user *User, now time.Time) bool {
+	if !r.IsActive || now.Before(r.StartsAt) {
+		return false
+	}
+	if r.EndsAt != nil && !now.Before(*r.EndsAt) {
+		return false
+	}
+	if r.TargetRole != "" && r.TargetRole != user.Role {
+		return false
+	}
+	if r.MinTier != "" && !user.CurrentTier().AtLeast(r.MinTier) {
+		return false
+	}
+	if r.ProductID != nil {
+		return *r.ProductID == product.ID
+	}
+	return r.CategoryCode == product.CategoryCode
+}
+
+// UnitPrice は元の単価にこのルールの割引を適用した単価を返す（1ポイント未満にはしない）
+func (r *PricingRule) UnitPrice(basePrice int64) int64 {
+	price := basePrice
+	switch r.DiscountType {
+	case PricingDiscountPercentage:
+		price = basePrice - basePrice*r.DiscountValue/100
+	case PricingDiscountFixed:
+		price = basePrice - r.DiscountValue
+	}
+	if price < 1 {
+		return 1
+	}
+	return price
+}
+
+// PriceQuote は交換時点の実効価格
+type PriceQuote struct {
+	BaseUnitPrice int64        // 商品の通常単価
+	UnitPrice     int64        // 割引適用後の単価
+	Rule          *PricingRule // 適用したルール（なければnil）
+}
+
+// ResolvePrice は適用できるルールのうち最も安くなるものを1つだけ適用した価格を返す（割引は重ねない）
+func ResolvePrice(product *Product, rules []*PricingRule, user *User, now time.Time) *PriceQuote {
+	quote := &PriceQuote{BaseUnitPrice: product.Price, UnitPrice: product.Price}
+	for _, rule := range rules {
+		if !rule.AppliesTo(product, user, now) {
+			continue
+		}
+		if price := rule.UnitPrice(product.Price); price < quote.UnitPrice {
+			quote.UnitPrice = price
+			quote.Rule = rule
+		}
+	}
+	return quote
+}
+
+// TransactionMetadata は取引のメタデータに残す適用内容を返す（ルールが適用されなければnil）
+func (q *PriceQuote) TransactionMetadata() map[string]interface{} {
+	if q.Rule == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"pricing_rule_id":   q.Rule.ID.String(),
+		"pricing_rule_name": q.Rule.Name,
+		"base_unit_price":   q.BaseUnitPrice,
+		"unit_price":        q.UnitPrice,
+	}
+}
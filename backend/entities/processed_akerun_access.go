@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProcessedAkerunAccessRetention は処理済みのAkerunアクセス記録IDを残す期間
+// ポーリングの取得期間が重なっても、この期間内に処理した記録は二度処理しない
+const ProcessedAkerunAccessRetention = 30 * 24 * time.Hour
+
+// ProcessedAkerunAccess はボーナスの処理を済ませたAkerunアクセス記録
+// ボーナスを作成しなかった記録（対象外のドア・マッチしないユーザー）や再試行キューに回した記録も含む
+type ProcessedAkerunAccess struct {
+	AccessID       uuid.UUID // Akerunアクセス記録ID
+	OrganizationID string
+	AccessedAt     time.Time
+	ProcessedAt    time.Time
+}
+
+// NewProcessedAkerunAccess はアクセス記録を処理済みとして作成
+func NewProcessedAkerunAccess(access AccessRecord, now time.Time) *ProcessedAkerunAccess {
+	return &ProcessedAkerunAccess{
+		AccessID:       access.ID,
+		OrganizationID: access.OrganizationID,
+		AccessedAt:     access.AccessedAt,
+		ProcessedAt:    now,
+	}
+}
@@ -17,6 +17,7 @@ type Product struct {
 	Stock       int     // 在庫数（-1 = 無制限）
 	ImageURL    string
 	IsAvailable bool
+	IsDigital   bool // trueなら交換時に引換コードを発行し、受け取り時にコードで引き換える
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	DeletedAt   *time.Time
@@ -55,13 +56,13 @@ func (p *Product) IsUnlimitedStock() bool {
 // CanExchange は交換可能かどうか
 func (p *Product) CanExchange(quantity int) error {
 	if !p.IsAvailable {
-		return errors.New("product is not available")
+		return ErrProductNotAvailable
 	}
 	if p.DeletedAt != nil {
 		return errors.New("product is deleted")
 	}
 	if quantity <= 0 {
-		return errors.New("quantity must be positive")
+		return ErrInvalidQuantity
 	}
 	if !p.IsUnlimitedStock() && p.Stock < quantity {
 		return errors.New("insufficient stock")
@@ -84,7 +85,7 @@ func (p *Product) DeductStock(quantity int) error {
 // RestoreStock は在庫を戻す（キャンセル時）
 func (p *Product) RestoreStock(quantity int) error {
 	if quantity <= 0 {
-		return errors.New("quantity must be positive")
+		return ErrInvalidQuantity
 	}
 	if !p.IsUnlimitedStock() {
 		p.Stock += quantity
@@ -116,12 +117,36 @@ type ProductExchange struct {
 	CreatedAt     time.Time
 	CompletedAt   *time.Time
 	DeliveredAt   *time.Time
+
+	// デジタル商品の引換コード（物理商品は空）
+	RedemptionCode string
+	RedeemedAt     *time.Time
+
+	// 割引価格で交換した場合の価格ルール（セール期間中の購入上限の集計に使う）
+	PricingRuleID *uuid.UUID
+
+	// 友達へ贈った場合の受け取る人と返事（自分用の交換はnil・空）。UserIDはポイントを払った人のまま
+	RecipientID     *uuid.UUID
+	GiftStatus      GiftStatus
+	GiftRespondedAt *time.Time
+
+	// 配送が必要な商品のお届け先（交換時点の住所を文字列で残す。デジタル商品・受け取り前の贈り物は空）
+	ShippingAddress string
 }
 
+// GiftStatus は友達へ贈った交換の返事
+type GiftStatus string
+
+const (
+	GiftStatusPending  GiftStatus = "pending"  // 受け取る人の返事待ち（受け取るまで受け渡ししない）
+	GiftStatusAccepted GiftStatus = "accepted" // 受け取った
+	GiftStatusDeclined GiftStatus = "declined" // 辞退した（交換はキャンセルし、払った人へ返金する）
+)
+
 // NewProductExchange は新しい商品交換を作成
 func NewProductExchange(userID, productID uuid.UUID, quantity int, pointsUsed int64, notes string) (*ProductExchange, error) {
 	if quantity <= 0 {
-		return nil, errors.New("quantity must be positive")
+		return nil, ErrInvalidQuantity
 	}
 	if pointsUsed <= 0 {
 		return nil, errors.New("points used must be positive")
@@ -165,8 +190,99 @@ func (e *ProductExchange) MarkAsDelivered() error {
 	if e.Status != ExchangeStatusCompleted {
 		return errors.New("exchange must be completed before delivery")
 	}
+	if e.RedemptionCode != "" {
+		return errors.New("digital exchange must be redeemed with its redemption code")
+	}
+	if e.GiftStatus == GiftStatusPending {
+		return ErrGiftAwaitingResponse
+	}
 	now := time.Now()
 	e.Status = ExchangeStatusDelivered
 	e.DeliveredAt = &now
 	return nil
 }
+
+// AssignRedemptionCode はデジタル商品の交換に引換コードを発行する
+func (e *ProductExchange) AssignRedemptionCode() error {
+	code, err := GenerateRedemptionCode()
+	if err != nil {
+		return err
+	}
+	e.RedemptionCode = code
+	return nil
+}
+
+// CanRedeem は引換コードで引き換えられる状態かどうか（交換完了済みで未使用、贈り物なら受け取り済み）
+func (e *ProductExchange) CanRedeem() bool {
+	return e.RedemptionCode != "" && e.Status == ExchangeStatusCompleted && e.RedeemedAt == nil && e.GiftStatus != GiftStatusPending
+}
+
+// Redeem は引換コードを使用済みにし、受け渡し済みにする
+func (e *ProductExchange) Redeem(now time.Time) error {
+	if !e.CanRedeem() {
+		return ErrRedemptionCodeUsed
+	}
+	e.Status = ExchangeStatusDelivered
+	e.RedeemedAt = &now
+	e.DeliveredAt = &now
+	return nil
+}
+
+// SendAsGift は交換を友達への贈り物にする（受け取る人の返事待ちになる）
+func (e *ProductExchange) SendAsGift(recipientID uuid.UUID) error {
+	if recipientID == uuid.Nil || recipientID == e.UserID {
+		return ErrInvalidGift
+	}
+	e.RecipientID = &recipientID
+	e.GiftStatus = GiftStatusPending
+	return nil
+}
+
+// IsGift は友達へ贈った交換かどうか
+func (e *ProductExchange) IsGift() bool {
+	return e.RecipientID != nil
+}
+
+// Recipient は商品を受け取る人（贈り物なら贈られた人、それ以外は交換した人）
+func (e *ProductExchange) Recipient() uuid.UUID {
+	if e.RecipientID != nil {
+		return *e.RecipientID
+	}
+	return e.UserID
+}
+
+// AcceptGift は贈られた人が受け取る
+func (e *ProductExchange) AcceptGift(userID uuid.UUID, now time.Time) error {
+	if err := e.checkGiftResponse(userID); err != nil {
+		return err
+	}
+	e.GiftStatus = GiftStatusAccepted
+	e.GiftRespondedAt = &now
+	return nil
+}
+
+// DeclineGift は贈られた人が辞退する（交換はキャンセルになり、払った人へ返金する）
+func (e *ProductExchange) DeclineGift(userID uuid.UUID, now time.Time) error {
+	if err := e.checkGiftResponse(userID); err != nil {
+		return err
+	}
+	e.Status = ExchangeStatusCancelled
+	e.GiftStatus = GiftStatusDeclined
+	e.GiftRespondedAt = &now
+	return nil
+}
+
+func (e *ProductExchange) checkGiftResponse(userID uuid.UUID) error {
+	if !e.IsGift() || *e.RecipientID != userID {
+		return ErrGiftNotFound
+	}
+	if e.GiftStatus != GiftStatusPending || e.Status != ExchangeStatusCompleted {
+		return ErrGiftAlreadyAnswered
+	}
+	return nil
+}
+
+// ShipTo はお届け先を交換記録に残す
+func (e *ProductExchange) ShipTo(address *ShippingAddress) {
+	e.ShippingAddress = address.Format()
+}
@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExchangeReportRow は商品ごと・期間ごとの交換実績（キャンセルされた交換は含まない）
+type ExchangeReportRow struct {
+	PeriodStart   time.Time
+	ProductID     uuid.UUID
+	ProductName   string
+	ExchangeCount int64
+	Quantity      int64
+	PointsUsed    int64
+}
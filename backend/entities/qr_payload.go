@@ -0,0 +1,75 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QRPayloadType はQRコードに埋め込むデータの種類
+type QRPayloadType string
+
+const (
+	QRPayloadTypeUser    QRPayloadType = "user"    // 個人QRコード（Dataはユーザーの ID）
+	QRPayloadTypeReceive QRPayloadType = "receive" // 受取用QRコード（Dataはコード）
+	QRPayloadTypeSend    QRPayloadType = "send"    // 送信用QRコード（Dataはコード）
+)
+
+// QRペイロードの形式のバージョン
+const (
+	QRPayloadVersionLegacy  = 0 // 署名のない旧形式（user:{user_id}、receive:{code}[:{amount}]、send:{code}:{amount}）
+	QRPayloadVersionCurrent = 1 // 署名・有効期限付きのディープリンク
+)
+
+// QRPayload はQRコードに埋め込むデータ
+// 埋め込む文字列への変換（署名・検証を含む）はservice.QRPayloadCodecが行う
+type QRPayload struct {
+	Type      QRPayloadType
+	Version   int
+	Data      string     // ユーザーIDまたはQRコードのコード
+	Amount    *int64     // 金額（表示用。送金にはQRコードに保存した金額を使う）
+	ExpiresAt *time.Time // nil=無期限（個人QRコード）
+}
+
+// NewPersonalQRPayload は個人QRコードのペイロードを作成（印刷して使うため無期限）
+func NewPersonalQRPayload(userID uuid.UUID) *QRPayload {
+	return &QRPayload{
+		Type:    QRPayloadTypeUser,
+		Version: QRPayloadVersionCurrent,
+		Data:    userID.String(),
+	}
+}
+
+// NewQRCodePayload は受取用・送信用QRコードのペイロードを作成（有効期限はQRコードと同じ）
+func NewQRCodePayload(q *QRCode) *QRPayload {
+	expiresAt := q.ExpiresAt
+	return &QRPayload{
+		Type:      QRPayloadType(q.QRType),
+		Version:   QRPayloadVersionCurrent,
+		Data:      q.Code,
+		Amount:    q.Amount,
+		ExpiresAt: &expiresAt,
+	}
+}
+
+// IsLegacy は署名のない旧形式から読み取ったかを判定
+func (p *QRPayload) IsLegacy() bool {
+	return p.Version == QRPayloadVersionLegacy
+}
+
+// IsExpired は有効期限を過ぎているかを判定
+func (p *QRPayload) IsExpired(now time.Time) bool {
+	return p.ExpiresAt != nil && now.After(*p.ExpiresAt)
+}
+
+// UserID は個人QRコードのユーザーIDを返す（個人QRコード以外はErrInvalidQRPayload）
+func (p *QRPayload) UserID() (uuid.UUID, error) {
+	if p.Type != QRPayloadTypeUser {
+		return uuid.Nil, ErrInvalidQRPayload
+	}
+	id, err := uuid.Parse(p.Data)
+	if err != nil {
+		return uuid.Nil, ErrInvalidQRPayload
+	}
+	return id, nil
+}
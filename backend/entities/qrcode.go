@@ -33,7 +33,7 @@ type QRCode struct {
 // NewReceiveQRCode はポイント受取用QRコードを作成
 func NewReceiveQRCode(userID uuid.UUID, amount *int64) (*QRCode, error) {
 	if amount != nil && *amount <= 0 {
-		return nil, errors.New("amount must be positive")
+		return nil, ErrInvalidAmount
 	}
 
 	code, err := generateQRCode()
@@ -55,7 +55,7 @@ func NewReceiveQRCode(userID uuid.UUID, amount *int64) (*QRCode, error) {
 // NewSendQRCode はポイント送信用QRコードを作成
 func NewSendQRCode(userID uuid.UUID, amount int64) (*QRCode, error) {
 	if amount <= 0 {
-		return nil, errors.New("amount must be positive")
+		return nil, ErrInvalidAmount
 	}
 
 	code, err := generateQRCode()
@@ -87,10 +87,10 @@ func (q *QRCode) IsUsed() bool {
 // MarkAsUsed はQRコードを使用済みにする
 func (q *QRCode) MarkAsUsed(userID uuid.UUID) error {
 	if q.IsUsed() {
-		return errors.New("qr code already used")
+		return ErrQRCodeAlreadyUsed
 	}
 	if q.IsExpired() {
-		return errors.New("qr code expired")
+		return ErrQRCodeExpired
 	}
 	now := time.Now()
 	q.UsedAt = &now
@@ -101,10 +101,10 @@ func (q *QRCode) MarkAsUsed(userID uuid.UUID) error {
 // CanBeUsedBy はQRコードが使用可能かどうかを確認
 func (q *QRCode) CanBeUsedBy(userID uuid.UUID) error {
 	if q.IsExpired() {
-		return errors.New("qr code expired")
+		return ErrQRCodeExpired
 	}
 	if q.IsUsed() {
-		return errors.New("qr code already used")
+		return ErrQRCodeAlreadyUsed
 	}
 	if q.UserID == userID {
 		return errors.New("cannot use your own qr code")
@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RealtimeTopic はWebSocketで購読できるイベントの分類
+type RealtimeTopic string
+
+const (
+	RealtimeTopicQR            RealtimeTopic = "qr"            // 自分のQRコードの読み取り・送金完了
+	RealtimeTopicNotifications RealtimeTopic = "notifications" // 自分宛ての通知
+)
+
+// RealtimeEventType はWebSocketで配信するイベントの種類
+type RealtimeEventType string
+
+const (
+	RealtimeEventQRScanned         RealtimeEventType = "qr-scanned"         // QRコードが読み取られた（送金処理の開始）
+	RealtimeEventTransferCompleted RealtimeEventType = "transfer-completed" // QRコードによる送金が完了した
+	RealtimeEventNotification      RealtimeEventType = "notification"       // 通知（送金リクエスト・受け取りなど）
+)
+
+// RealtimeEvent はログイン中の端末へ即時に届けるイベント
+type RealtimeEvent struct {
+	Topic      RealtimeTopic
+	Type       RealtimeEventType
+	Data       map[string]interface{}
+	OccurredAt time.Time
+}
+
+// IsKnownRealtimeTopic は購読可能なトピックかを判定
+func IsKnownRealtimeTopic(topic RealtimeTopic) bool {
+	return topic == RealtimeTopicQR || topic == RealtimeTopicNotifications
+}
+
+// NewQRScannedEvent はQRコードの持ち主に送る「読み取られた」イベントを作成
+func NewQRScannedEvent(qrCode *QRCode, scannedBy uuid.UUID, amount int64) *RealtimeEvent {
+	return &RealtimeEvent{
+		Topic: RealtimeTopicQR,
+		Type:  RealtimeEventQRScanned,
+		Data: map[string]interface{}{
+			"qr_code_id": qrCode.ID,
+			"qr_type":    qrCode.QRType,
+			"scanned_by": scannedBy,
+			"amount":     amount,
+		},
+		OccurredAt: time.Now(),
+	}
+}
+
+// NewQRTransferCompletedEvent はQRコードの持ち主に送る「送金完了」イベントを作成
+// counterpart はQRコードを読み取った相手
+func NewQRTransferCompletedEvent(qrCode *QRCode, transaction *Transaction, counterpart *User) *RealtimeEvent {
+	data := map[string]interface{}{
+		"qr_code_id":     qrCode.ID,
+		"qr_type":        qrCode.QRType,
+		"transaction_id": transaction.ID,
+		"amount":         transaction.Amount,
+	}
+	if counterpart != nil {
+		data["counterpart"] = map[string]interface{}{
+			"id":           counterpart.ID,
+			"username":     counterpart.Username,
+			"display_name": counterpart.DisplayName,
+		}
+	}
+	return &RealtimeEvent{
+		Topic:      RealtimeTopicQR,
+		Type:       RealtimeEventTransferCompleted,
+		Data:       data,
+		OccurredAt: time.Now(),
+	}
+}
+
+// NewNotificationEvent は通知をログイン中の画面へ届けるイベントを作成
+func NewNotificationEvent(notification *Notification) *RealtimeEvent {
+	return &RealtimeEvent{
+		Topic: RealtimeTopicNotifications,
+		Type:  RealtimeEventNotification,
+		Data: map[string]interface{}{
+			"type":  notification.Type,
+			"title": notification.Title,
+			"body":  notification.Body,
+			"data":  notification.Data,
+		},
+		OccurredAt: notification.CreatedAt,
+	}
+}
@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// ReasonCodeLabelMaxLength は理由コードの表示名の最大文字数
+	ReasonCodeLabelMaxLength = 50
+	// ReasonCodeDescriptionMaxLength は理由コードの説明の最大文字数
+	ReasonCodeDescriptionMaxLength = 200
+	// TransactionTagMaxLength は取引に付けるプロジェクト・タグの最大文字数
+	TransactionTagMaxLength = 50
+)
+
+// reasonCodePattern は理由コードの形式（集計・CSV出力で扱いやすいよう英小文字・数字・_に限る）
+var reasonCodePattern = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+// ReasonCode は管理者の付与・減算に付ける理由コード（管理者が一覧を管理する）
+// 取引はコード文字列で参照するため、一度作ったコードは削除せず無効化して使う
+type ReasonCode struct {
+	Code        string
+	Label       string
+	Description string
+	IsActive    bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewReasonCode は新しい理由コードを作成
+func NewReasonCode(code, label, description string) (*ReasonCode, error) {
+	code = NormalizeReasonCode(code)
+	if !reasonCodePattern.MatchString(code) {
+		return nil, ErrInvalidReasonCode
+	}
+	now := time.Now()
+	r := &ReasonCode{
+		Code:      code,
+		CreatedAt: now,
+	}
+	if err := r.Update(label, description, true); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Update は表示名・説明・有効状態を更新（コードは変更できない）
+func (r *ReasonCode) Update(label, description string, isActive bool) error {
+	label = strings.TrimSpace(label)
+	description = strings.TrimSpace(description)
+	if label == "" || len([]rune(label)) > ReasonCodeLabelMaxLength {
+		return ErrInvalidReasonCode
+	}
+	if len([]rune(description)) > ReasonCodeDescriptionMaxLength {
+		return ErrInvalidReasonCode
+	}
+
+	r.Label = label
+	r.Description = description
+	r.IsActive = isActive
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// NormalizeReasonCode は入力された理由コードを比較用の形に揃える
+func NormalizeReasonCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
+
+// NormalizeTransactionTag は取引のタグを検証して前後の空白を除く
+func NormalizeTransactionTag(tag string) (string, error) {
+	tag = strings.TrimSpace(tag)
+	if len([]rune(tag)) > TransactionTagMaxLength || strings.ContainsAny(tag, "\r\n\t") {
+		return "", ErrInvalidTransactionTag
+	}
+	return tag, nil
+}
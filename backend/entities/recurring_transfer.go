@@ -0,0 +1,187 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecurringInterval は定期送金の周期
+type RecurringInterval string
+
+const (
+	RecurringIntervalWeekly  RecurringInterval = "weekly"  // 毎週
+	RecurringIntervalMonthly RecurringInterval = "monthly" // 毎月
+)
+
+// RecurringTransferStatus は定期送金の状態
+type RecurringTransferStatus string
+
+const (
+	RecurringTransferStatusActive    RecurringTransferStatus = "active"    // 実行中
+	RecurringTransferStatusCancelled RecurringTransferStatus = "cancelled" // 送信者または受取人が解約
+	RecurringTransferStatusStopped   RecurringTransferStatus = "stopped"   // 残高不足などで自動停止
+)
+
+const (
+	// RecurringTransferMessageMaxLength はメッセージの最大文字数
+	RecurringTransferMessageMaxLength = 200
+	// RecurringTransferMaxFailures は連続失敗で自動停止するまでの回数
+	RecurringTransferMaxFailures = 3
+)
+
+// RecurringTransfer はユーザー間の定期送金（送信者が承認した購読型の送金）
+// NextRunAt を過ぎるとワーカーが送金し、次の周期に進める
+type RecurringTransfer struct {
+	ID                  uuid.UUID
+	FromUserID          uuid.UUID // 送信者（送金を承認したユーザー）
+	ToUserID            uuid.UUID // 受取人
+	Amount              int64
+	Interval            RecurringInterval
+	Message             string
+	Status              RecurringTransferStatus
+	StartAt             time.Time // 初回実行日時（毎月の実行日はこの日付に合わせる）
+	NextRunAt           time.Time
+	LastRunAt           *time.Time
+	RunCount            int
+	ConsecutiveFailures int
+	StopReason          string // 自動停止の理由
+	CancelledBy         *uuid.UUID
+	CancelledAt         *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// NewRecurringTransfer は新しい定期送金を作成
+// startAt がゼロ値なら作成時点から開始する
+func NewRecurringTransfer(fromUserID, toUserID uuid.UUID, amount int64, interval RecurringInterval, message string, startAt time.Time) (*RecurringTransfer, error) {
+	if fromUserID == toUserID {
+		return nil, ErrSameUserTransfer
+	}
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if interval != RecurringIntervalWeekly && interval != RecurringIntervalMonthly {
+		return nil, ErrInvalidRecurring
+	}
+	message = strings.TrimSpace(message)
+	if len([]rune(message)) > RecurringTransferMessageMaxLength {
+		return nil, ErrInvalidRecurring
+	}
+
+	now := time.Now()
+	if startAt.IsZero() {
+		startAt = now
+	}
+	if startAt.Before(now.Add(-time.Minute)) {
+		return nil, ErrInvalidRecurring
+	}
+
+	return &RecurringTransfer{
+		ID:         uuid.New(),
+		FromUserID: fromUserID,
+		ToUserID:   toUserID,
+		Amount:     amount,
+		Interval:   interval,
+		Message:    message,
+		Status:     RecurringTransferStatusActive,
+		StartAt:    startAt,
+		NextRunAt:  startAt,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+// IsActive は定期送金が有効かを判定
+func (r *RecurringTransfer) IsActive() bool {
+	return r.Status == RecurringTransferStatusActive
+}
+
+// IsDue は指定日時に実行すべきかを判定
+func (r *RecurringTransfer) IsDue(now time.Time) bool {
+	return r.IsActive() && !r.NextRunAt.After(now)
+}
+
+// IsParticipant は送信者または受取人かを判定
+func (r *RecurringTransfer) IsParticipant(userID uuid.UUID) bool {
+	return r.FromUserID == userID || r.ToUserID == userID
+}
+
+// PeriodKey は今回実行する周期を表すキー（weekly: 2026-W42、monthly: 2026-10）
+func (r *RecurringTransfer) PeriodKey() string {
+	if r.Interval == RecurringIntervalWeekly {
+		year, week := r.NextRunAt.UTC().ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	}
+	return r.NextRunAt.UTC().Format("2006-01")
+}
+
+// IdempotencyKey は今回の送金に使う冪等性キー
+// 同じ周期の送金は何度実行しても1回だけになる
+func (r *RecurringTransfer) IdempotencyKey() string {
+	return fmt.Sprintf("recurring-%s-%s", r.ID, r.PeriodKey())
+}
+
+// MarkRun は送金成功を記録し、次回実行日時を now より後の周期まで進める
+// サーバー停止などで複数周期を逃した場合もまとめて送金せず、次の周期から再開する
+func (r *RecurringTransfer) MarkRun(now time.Time) {
+	r.LastRunAt = &now
+	r.RunCount++
+	r.ConsecutiveFailures = 0
+	for !r.NextRunAt.After(now) {
+		r.NextRunAt = r.nextAfter(r.NextRunAt)
+	}
+	r.UpdatedAt = now
+}
+
+// MarkFailed は送金失敗を記録し、連続失敗が上限に達したら自動停止する
+func (r *RecurringTransfer) MarkFailed(reason string, now time.Time) {
+	r.ConsecutiveFailures++
+	if r.ConsecutiveFailures >= RecurringTransferMaxFailures {
+		r.Stop(reason, now)
+		return
+	}
+	r.UpdatedAt = now
+}
+
+// Stop は定期送金を自動停止する（残高不足・アカウント無効など）
+func (r *RecurringTransfer) Stop(reason string, now time.Time) {
+	r.Status = RecurringTransferStatusStopped
+	r.StopReason = reason
+	r.UpdatedAt = now
+}
+
+// Cancel は送信者または受取人が定期送金を解約する
+func (r *RecurringTransfer) Cancel(userID uuid.UUID) error {
+	if !r.IsParticipant(userID) {
+		return ErrRecurringNotFound
+	}
+	if !r.IsActive() {
+		return ErrRecurringNotActive
+	}
+
+	now := time.Now()
+	r.Status = RecurringTransferStatusCancelled
+	r.CancelledBy = &userID
+	r.CancelledAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+// nextAfter は指定した実行日時の次の実行日時を返す
+// 毎月の場合は StartAt の日付に合わせ、その月に存在しない日は月末にする（1/31 → 2/28 → 3/31）
+func (r *RecurringTransfer) nextAfter(t time.Time) time.Time {
+	if r.Interval == RecurringIntervalWeekly {
+		return t.AddDate(0, 0, 7)
+	}
+
+	year, month, _ := t.Date()
+	firstOfNext := time.Date(year, month+1, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	day := r.StartAt.In(t.Location()).Day()
+	if last := firstOfNext.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return firstOfNext.AddDate(0, 0, day-1)
+}
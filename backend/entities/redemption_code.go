@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// redemptionCodeAlphabet は引換コードに使う文字（読み間違えやすい 0/O・1/I/L を除く）
+const redemptionCodeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+const (
+	redemptionCodeGroups    = 3
+	redemptionCodeGroupSize = 4
+)
+
+// GenerateRedemptionCode は "XXXX-XXXX-XXXX" 形式の引換コードを生成
+func GenerateRedemptionCode() (string, error) {
+	max := big.NewInt(int64(len(redemptionCodeAlphabet)))
+	var b strings.Builder
+	for i := 0; i < redemptionCodeGroups*redemptionCodeGroupSize; i++ {
+		if i > 0 && i%redemptionCodeGroupSize == 0 {
+			b.WriteByte('-')
+		}
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate redemption code: %w", err)
+		}
+		b.WriteByte(redemptionCodeAlphabet[n.Int64()])
+	}
+	return b.String(), nil
+}
+
+// NormalizeRedemptionCode は入力された引換コードを保存形式にそろえる
+// 大文字・小文字、空白やハイフンの有無を区別しない（形式が合わなければ空文字列）
+func NormalizeRedemptionCode(input string) string {
+	var chars []byte
+	for _, r := range strings.ToUpper(input) {
+		switch {
+		case r == '-' || r == ' ':
+			continue
+		case r < 128 && strings.IndexByte(redemptionCodeAlphabet, byte(r)) >= 0:
+			chars = append(chars, byte(r))
+		default:
+			return ""
+		}
+	}
+	if len(chars) != redemptionCodeGroups*redemptionCodeGroupSize {
+		return ""
+	}
+
+	var b strings.Builder
+	for i, c := range chars {
+		if i > 0 && i%redemptionCodeGroupSize == 0 {
+			b.WriteByte('-')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,152 @@
+package entities
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReferralCodeLength は紹介コードの文字数（引換コードと同じ読み間違えにくい文字を使う）
+const ReferralCodeLength = 8
+
+const (
+	// ReferrerRewardPoints は紹介した側に付与するポイント
+	ReferrerRewardPoints int64 = 300
+	// RefereeRewardPoints は紹介された側に付与するポイント
+	RefereeRewardPoints int64 = 100
+
+	// ReferralMaxPerIP は同じIPアドレスから ReferralIPWindow 内に特典対象として受け付ける紹介の数
+	ReferralMaxPerIP = 3
+	// ReferralIPWindow はIPアドレスごとの紹介数を数える期間
+	ReferralIPWindow = 24 * time.Hour
+)
+
+// ReferralStatus は紹介の状態
+type ReferralStatus string
+
+const (
+	ReferralStatusPending  ReferralStatus = "pending"  // 紹介された側の初回チェックイン待ち
+	ReferralStatusRewarded ReferralStatus = "rewarded" // 双方に特典を付与済み
+	ReferralStatusRejected ReferralStatus = "rejected" // 不正の疑いがあり特典の対象外
+)
+
+// ReferralRejectReason は紹介を特典の対象外にした理由
+type ReferralRejectReason string
+
+const (
+	ReferralRejectSelfReferral ReferralRejectReason = "self_referral" // 紹介者本人がログインしたIPアドレスからの登録
+	ReferralRejectIPLimit      ReferralRejectReason = "ip_limit"      // 同じIPアドレスからの登録が多すぎる
+)
+
+// ReferralCode はユーザーごとの紹介コード
+type ReferralCode struct {
+	UserID    uuid.UUID
+	Code      string
+	CreatedAt time.Time
+}
+
+// NewReferralCode はユーザーの紹介コードを発行
+func NewReferralCode(userID uuid.UUID) (*ReferralCode, error) {
+	max := big.NewInt(int64(len(redemptionCodeAlphabet)))
+	var b strings.Builder
+	for i := 0; i < ReferralCodeLength; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate referral code: %w", err)
+		}
+		b.WriteByte(redemptionCodeAlphabet[n.Int64()])
+	}
+	return &ReferralCode{
+		UserID:    userID,
+		Code:      b.String(),
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// NormalizeReferralCode は入力された紹介コードを保存形式にそろえる（形式が正しくなければ空文字）
+func NormalizeReferralCode(input string) string {
+	var chars []byte
+	for _, r := range strings.ToUpper(strings.TrimSpace(input)) {
+		switch {
+		case r == '-' || r == ' ':
+			continue
+		case r < 128 && strings.IndexByte(redemptionCodeAlphabet, byte(r)) >= 0:
+			chars = append(chars, byte(r))
+		default:
+			return ""
+		}
+	}
+	if len(chars) != ReferralCodeLength {
+		return ""
+	}
+	return string(chars)
+}
+
+// Referral は紹介コードを使った登録の記録（紹介された側1人につき1件）
+type Referral struct {
+	ID             uuid.UUID
+	ReferrerID     uuid.UUID
+	RefereeID      uuid.UUID
+	Code           string
+	Status         ReferralStatus
+	RejectReason   ReferralRejectReason
+	IPAddress      string
+	ReferrerReward int64
+	RefereeReward  int64
+	CreatedAt      time.Time
+	RewardedAt     *time.Time
+}
+
+// NewReferral は初回チェックイン待ちの紹介を作成
+func NewReferral(referrerID, refereeID uuid.UUID, code, ipAddress string) *Referral {
+	return &Referral{
+		ID:         uuid.New(),
+		ReferrerID: referrerID,
+		RefereeID:  refereeID,
+		Code:       code,
+		Status:     ReferralStatusPending,
+		IPAddress:  ipAddress,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// Reject は紹介を特典の対象外にする
+func (r *Referral) Reject(reason ReferralRejectReason) {
+	r.Status = ReferralStatusRejected
+	r.RejectReason = reason
+}
+
+// ReferrerStats は紹介者ごとの実績
+type ReferrerStats struct {
+	UserID       uuid.UUID
+	Username     string
+	DisplayName  string
+	Invited      int64 // 紹介コードで登録した人数
+	Rewarded     int64 // 初回チェックインまで進んだ人数
+	Rejected     int64 // 不正の疑いで対象外にした人数
+	PointsEarned int64 // 紹介者が受け取ったポイント
+}
+
+// ReferralReport は期間内の紹介の実績（管理者向け）
+type ReferralReport struct {
+	From          time.Time
+	To            time.Time
+	Invited       int64
+	Pending       int64
+	Rewarded      int64
+	Rejected      int64
+	PointsAwarded int64 // 紹介者・紹介された側に付与したポイントの合計
+	TopReferrers  []*ReferrerStats
+}
+
+// ConversionRate は登録者のうち特典の付与まで進んだ割合（%）
+func (r *ReferralReport) ConversionRate() float64 {
+	if r.Invited == 0 {
+		return 0
+	}
+	return float64(r.Rewarded) * 100 / float64(r.Invited)
+}
@@ -0,0 +1,213 @@
+package entities
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScheduledJobName は定期実行ジョブの名前
+type ScheduledJobName string
+
+const (
+	ScheduledJobIdempotencyKeyCleanup        ScheduledJobName = "idempotency_key_cleanup"         // 期限切れの冪等性キーの削除
+	ScheduledJobSessionPurge                 ScheduledJobName = "session_purge"                   // 期限切れセッションの削除
+	ScheduledJobTransferRequestExpiry        ScheduledJobName = "transfer_request_expiry"         // 期限切れの送金リクエストを期限切れにする
+	ScheduledJobTransactionArchive           ScheduledJobName = "transaction_archive"             // 保持期間を過ぎた取引を保管用のテーブルへ移す
+	ScheduledJobWeeklyDigest                 ScheduledJobName = "weekly_digest"                   // 週次のまとめメールを送る
+	ScheduledJobProcessedAkerunAccessCleanup ScheduledJobName = "processed_akerun_access_cleanup" // 保持期間を過ぎた処理済みのAkerunアクセス記録IDの削除
+	ScheduledJobTransferAttachmentCleanup    ScheduledJobName = "transfer_attachment_cleanup"     // 保持期間を過ぎた送金の添付画像の削除
+)
+
+// DefaultJobSchedules はジョブごとの既定のcron式（JST）
+var DefaultJobSchedules = map[ScheduledJobName]string{
+	ScheduledJobIdempotencyKeyCleanup:        "15 * * * *",
+	ScheduledJobSessionPurge:                 "30 3 * * *",
+	ScheduledJobTransferRequestExpiry:        "*/10 * * * *",
+	ScheduledJobTransactionArchive:           "0 4 * * *",
+	ScheduledJobWeeklyDigest:                 "0 9 * * 1",
+	ScheduledJobProcessedAkerunAccessCleanup: "45 3 * * *",
+	ScheduledJobTransferAttachmentCleanup:    "50 3 * * *",
+}
+
+// JobSchedules は設定ファイル・環境変数で指定したジョブごとのcron式（既定を上書きする）
+type JobSchedules map[ScheduledJobName]string
+
+const (
+	// ScheduledJobDisabled はジョブを止めるときにcron式の代わりに指定する値
+	ScheduledJobDisabled = "off"
+	// ScheduledJobLockTimeout はジョブのロックの有効期間（実行中のインスタンスが落ちても、過ぎれば他のインスタンスが実行できる）
+	ScheduledJobLockTimeout = 30 * time.Minute
+)
+
+// JobScheduleSettingKey はジョブのcron式を上書きするsystem_settingsのキー（設定ファイルより優先）
+func JobScheduleSettingKey(name ScheduledJobName) string {
+	return "job_schedule." + string(name)
+}
+
+// ScheduledJobStatus はジョブの直近の実行結果
+type ScheduledJobStatus string
+
+const (
+	ScheduledJobStatusRunning   ScheduledJobStatus = "running"
+	ScheduledJobStatusSucceeded ScheduledJobStatus = "succeeded"
+	ScheduledJobStatusFailed    ScheduledJobStatus = "failed"
+)
+
+// ScheduledJob はジョブのスケジュールと直近の実行状況（インスタンス間で共有し、ロックにも使う）
+type ScheduledJob struct {
+	Name           ScheduledJobName
+	Schedule       string     // cron式（offなら停止中）
+	NextRunAt      *time.Time // 停止中はnil
+	LockedBy       string     // 実行中のインスタンス
+	LockedUntil    *time.Time
+	LastStartedAt  *time.Time
+	LastFinishedAt *time.Time
+	LastStatus     ScheduledJobStatus // 一度も実行していなければ空
+	LastError      string
+	LastProcessed  *int64 // 処理した件数（件数が分からないジョブはnil）
+}
+
+// IsRunning はジョブがいずれかのインスタンスで実行中かを判定
+func (j *ScheduledJob) IsRunning(now time.Time) bool {
+	return j.LockedUntil != nil && now.Before(*j.LockedUntil)
+}
+
+// ScheduledJobRun はジョブ1回分の実行結果
+type ScheduledJobRun struct {
+	Name       ScheduledJobName
+	Owner      string
+	FinishedAt time.Time
+	Status     ScheduledJobStatus
+	Processed  *int64
+	Error      string
+}
+
+// scheduleLocation はcron式を解釈するタイムゾーン
+var scheduleLocation = time.FixedZone("JST", 9*60*60)
+
+// CronSchedule は5項目（分 時 日 月 曜日）のcron式
+// 各項目は * ・数値・範囲（a-b）・間隔（*/n, a-b/n）とそのカンマ区切りに対応する
+type CronSchedule struct {
+	expr     string
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+	// 日と曜日の両方を指定した場合は、どちらかに一致すれば実行する（cronと同じ）
+	dayRestricted     bool
+	weekdayRestricted bool
+}
+
+// ParseCronSchedule はcron式を解釈する
+func ParseCronSchedule(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, ErrInvalidCronSchedule
+	}
+	s := &CronSchedule{expr: strings.Join(fields, " ")}
+	var err error
+	if s.minutes, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, err
+	}
+	if s.hours, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, err
+	}
+	if s.days, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, err
+	}
+	if s.months, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, err
+	}
+	if s.weekdays, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, err
+	}
+	// 7は日曜日
+	if s.weekdays&(1<<7) != 0 {
+		s.weekdays = s.weekdays&^(1<<7) | 1
+	}
+	s.dayRestricted = fields[2] != "*"
+	s.weekdayRestricted = fields[4] != "*"
+	return s, nil
+}
+
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, ErrInvalidCronSchedule
+			}
+			step = n
+		}
+
+		lo, hi := min, max
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = strconv.Atoi(from); err != nil {
+				return 0, ErrInvalidCronSchedule
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(to); err != nil {
+					return 0, ErrInvalidCronSchedule
+				}
+			} else if hasStep {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, ErrInvalidCronSchedule
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// String はcron式を返す
+func (s *CronSchedule) String() string {
+	return s.expr
+}
+
+// Next はafterより後で最初に実行する時刻を返す（該当する日時がなければゼロ値）
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	t := after.In(scheduleLocation).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		y, m, d := t.Date()
+		switch {
+		case s.months&(1<<uint(m)) == 0:
+			t = time.Date(y, m+1, 1, 0, 0, 0, 0, scheduleLocation)
+		case !s.dayMatches(t):
+			t = time.Date(y, m, d+1, 0, 0, 0, 0, scheduleLocation)
+		case s.hours&(1<<uint(t.Hour())) == 0:
+			t = time.Date(y, m, d, t.Hour()+1, 0, 0, 0, scheduleLocation)
+		case s.minutes&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	day := s.days&(1<<uint(t.Day())) != 0
+	weekday := s.weekdays&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.dayRestricted && s.weekdayRestricted:
+		return day || weekday
+	case s.dayRestricted:
+		return day
+	case s.weekdayRestricted:
+		return weekday
+	}
+	return true
+}
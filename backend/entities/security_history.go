@@ -0,0 +1,109 @@
+package entities
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SecurityHistoryType はアカウントのセキュリティ履歴の種別
+type SecurityHistoryType string
+
+const (
+	SecurityHistoryUsernameChange SecurityHistoryType = "username_change"
+	SecurityHistoryPasswordChange SecurityHistoryType = "password_change"
+
+	SecurityHistorySessionBindingViolation SecurityHistoryType = "session_binding_violation"
+)
+
+// SecurityHistoryEntry はユーザー名変更・パスワード変更・セッションの固定の違反をひとつの時系列で扱うための履歴
+type SecurityHistoryEntry struct {
+	Type        SecurityHistoryType
+	ChangedAt   time.Time
+	IPAddress   *string
+	UserAgent   *string
+	OldUsername string     // ユーザー名変更のみ
+	NewUsername string     // ユーザー名変更のみ
+	ChangedBy   *uuid.UUID // ユーザー名変更のみ（本人以外が変更した場合は管理者）
+
+	BindingChange  SessionFingerprintChange // セッションの固定の違反のみ
+	SessionRevoked bool                     // セッションの固定の違反のみ（セッションを失効させたか）
+}
+
+// NewSecurityHistoryFromUsernameChange はユーザー名変更履歴から作成
+func NewSecurityHistoryFromUsernameChange(h *UsernameChangeHistory) *SecurityHistoryEntry {
+	return &SecurityHistoryEntry{
+		Type:        SecurityHistoryUsernameChange,
+		ChangedAt:   h.ChangedAt,
+		IPAddress:   h.IPAddress,
+		OldUsername: h.OldUsername,
+		NewUsername: h.NewUsername,
+		ChangedBy:   h.ChangedBy,
+	}
+}
+
+// NewSecurityHistoryFromPasswordChange はパスワード変更履歴から作成
+func NewSecurityHistoryFromPasswordChange(h *PasswordChangeHistory) *SecurityHistoryEntry {
+	return &SecurityHistoryEntry{
+		Type:      SecurityHistoryPasswordChange,
+		ChangedAt: h.ChangedAt,
+		IPAddress: h.IPAddress,
+		UserAgent: h.UserAgent,
+	}
+}
+
+// NewSecurityHistoryFromBindingViolation はセッションの固定の違反から作成
+func NewSecurityHistoryFromBindingViolation(v *SessionBindingViolation) *SecurityHistoryEntry {
+	ipAddress, userAgent := v.IPAddress, v.UserAgent
+	return &SecurityHistoryEntry{
+		Type:           SecurityHistorySessionBindingViolation,
+		ChangedAt:      v.CreatedAt,
+		IPAddress:      &ipAddress,
+		UserAgent:      &userAgent,
+		BindingChange:  v.Change,
+		SessionRevoked: v.Rejected,
+	}
+}
+
+// MergeSecurityHistory は新しい順に並べた履歴を結合し、offsetからlimit件を返す
+func MergeSecurityHistory(entries []*SecurityHistoryEntry, offset, limit int) []*SecurityHistoryEntry {
+	sort.SliceStable(entries, func(a, b int) bool {
+		return entries[a].ChangedAt.After(entries[b].ChangedAt)
+	})
+	if offset >= len(entries) {
+		return []*SecurityHistoryEntry{}
+	}
+	end := offset + limit
+	if end > len(entries) {
+		end = len(entries)
+	}
+	return entries[offset:end]
+}
+
+// Masked は本人向けにIPアドレスの末尾を伏せた履歴を返す
+// セッションを乗っ取られた場合に正確な接続元を渡さないため
+func (e *SecurityHistoryEntry) Masked() *SecurityHistoryEntry {
+	masked := *e
+	if e.IPAddress != nil {
+		ip := MaskIPAddress(*e.IPAddress)
+		masked.IPAddress = &ip
+	}
+	return &masked
+}
+
+// MaskIPAddress はIPv4の最後のオクテット、IPv6の下位64ビットを伏せる
+func MaskIPAddress(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return "***"
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		parts := strings.Split(v4.String(), ".")
+		return strings.Join(parts[:3], ".") + ".***"
+	}
+	prefix := net.IP(append(parsed[:8:8], make([]byte, 8)...))
+	return strings.TrimSuffix(prefix.String(), "::") + "::***"
+}
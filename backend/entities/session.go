@@ -1,7 +1,7 @@
 package entities
 
 import (
-	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,14 +9,18 @@ import (
 
 // Session はセッションエンティティ
 type Session struct {
-	ID           uuid.UUID
-	UserID       uuid.UUID
-	SessionToken string
-	CSRFToken    string
-	IPAddress    string
-	UserAgent    string
-	ExpiresAt    time.Time
-	CreatedAt    time.Time
+	ID                uuid.UUID
+	UserID            uuid.UUID
+	SessionToken      string
+	CSRFToken         string
+	PreviousCSRFToken string    // 入れ替える前のCSRFトークン（入れ替えてからGraceの間だけ受け付ける）
+	CSRFIssuedAt      time.Time // 今のCSRFトークンを発行した日時
+	IPAddress         string
+	UserAgent         string
+	Fingerprint       string // 固定した端末・接続元（SessionFingerprintの文字列、未固定なら空）
+	ExpiresAt         time.Time
+	LastActiveAt      time.Time
+	CreatedAt         time.Time
 }
 
 // NewSession は新しいセッションを作成
@@ -36,15 +40,18 @@ func NewSession(userID uuid.UUID, ipAddress, userAgent string) (*Session, error)
 		ipAddress = "127.0.0.1"
 	}
 
+	now := time.Now()
 	return &Session{
 		ID:           uuid.New(),
 		UserID:       userID,
 		SessionToken: sessionToken,
 		CSRFToken:    csrfToken,
+		CSRFIssuedAt: now,
 		IPAddress:    ipAddress,
 		UserAgent:    userAgent,
-		ExpiresAt:    time.Now().Add(24 * time.Hour), // 24時間有効
-		CreatedAt:    time.Now(),
+		ExpiresAt:    now.Add(24 * time.Hour), // 24時間有効
+		LastActiveAt: now,
+		CreatedAt:    now,
 	}, nil
 }
 
@@ -54,18 +61,84 @@ func (s *Session) IsExpired() bool {
 }
 
 // ValidateCSRF はCSRFトークンを検証
-func (s *Session) ValidateCSRF(token string) error {
-	if s.CSRFToken != token {
-		return errors.New("invalid csrf token")
+// 入れ替える前のトークンは入れ替えてからGraceの間だけ受け付ける
+// periodicの設定で期限切れのトークンはErrCSRFTokenExpired（GET /api/auth/csrf で取得し直せる）
+func (s *Session) ValidateCSRF(token string, policy CSRFPolicy, now time.Time) error {
+	switch {
+	case csrfTokenEqual(token, s.CSRFToken):
+		if policy.Expired(s.CSRFIssuedAt, now) {
+			return ErrCSRFTokenExpired
+		}
+	case csrfTokenEqual(token, s.PreviousCSRFToken) && now.Before(s.CSRFIssuedAt.Add(policy.Grace)):
+	default:
+		return ErrInvalidCSRFToken
 	}
 	if s.IsExpired() {
-		return errors.New("session expired")
+		return ErrSessionExpired
 	}
 	return nil
 }
 
-// Refresh はセッションの有効期限を延長
+// RotateCSRF はCSRFトークンを新しいトークンに入れ替える
+func (s *Session) RotateCSRF(now time.Time) error {
+	csrfToken, err := GenerateSecureTokenBase64(32)
+	if err != nil {
+		return err
+	}
+	s.PreviousCSRFToken = s.CSRFToken
+	s.CSRFToken = csrfToken
+	s.CSRFIssuedAt = now
+	return nil
+}
+
+// Refresh はセッションの有効期限を延長し、最終アクティブ日時を更新
 func (s *Session) Refresh() {
-	s.ExpiresAt = time.Now().Add(24 * time.Hour)
+	now := time.Now()
+	s.ExpiresAt = now.Add(24 * time.Hour)
+	s.LastActiveAt = now
+}
+
+// BelongsTo はセッションが指定ユーザーのものか確認
+func (s *Session) BelongsTo(userID uuid.UUID) bool {
+	return s.UserID == userID
 }
 
+// DeviceName はUser-Agentから表示用の端末名を推定（例: "Chrome on macOS"）
+func (s *Session) DeviceName() string {
+	ua := s.UserAgent
+	if ua == "" {
+		return "Unknown device"
+	}
+
+	browser := "Unknown browser"
+	switch {
+	case strings.Contains(ua, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(ua, "OPR/"):
+		browser = "Opera"
+	case strings.Contains(ua, "Chrome/"):
+		browser = "Chrome"
+	case strings.Contains(ua, "Firefox/"):
+		browser = "Firefox"
+	case strings.Contains(ua, "Safari/"):
+		browser = "Safari"
+	case strings.HasPrefix(ua, "curl/"):
+		browser = "curl"
+	}
+
+	platform := "Unknown OS"
+	switch {
+	case strings.Contains(ua, "iPhone"), strings.Contains(ua, "iPad"):
+		platform = "iOS"
+	case strings.Contains(ua, "Android"):
+		platform = "Android"
+	case strings.Contains(ua, "Windows"):
+		platform = "Windows"
+	case strings.Contains(ua, "Mac OS X"), strings.Contains(ua, "Macintosh"):
+		platform = "macOS"
+	case strings.Contains(ua, "Linux"):
+		platform = "Linux"
+	}
+
+	return browser + " on " + platform
+}
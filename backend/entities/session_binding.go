@@ -0,0 +1,154 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SessionBindingMode はセッションを端末・接続元に固定する強さ
+type SessionBindingMode string
+
+const (
+	SessionBindingOff     SessionBindingMode = "off"     // 固定しない
+	SessionBindingMonitor SessionBindingMode = "monitor" // 変化を記録するだけで、セッションはそのまま使える
+	SessionBindingLenient SessionBindingMode = "lenient" // User-Agentと接続元のサブネットがどちらも変わったら再ログインを求める
+	SessionBindingStrict  SessionBindingMode = "strict"  // どちらかが変わったら再ログインを求める
+)
+
+// SessionBindingModes は設定できる固定の強さ
+var SessionBindingModes = []SessionBindingMode{SessionBindingOff, SessionBindingMonitor, SessionBindingLenient, SessionBindingStrict}
+
+// SessionBindingPolicy はセッションの固定の設定
+type SessionBindingPolicy struct {
+	Mode          SessionBindingMode
+	IPv4PrefixLen int // 同じ接続元とみなすIPv4のサブネット（例: 24）
+	IPv6PrefixLen int // 同じ接続元とみなすIPv6のサブネット（例: 64）
+}
+
+// Enabled はセッションを固定するか
+func (p SessionBindingPolicy) Enabled() bool {
+	return p.Mode != "" && p.Mode != SessionBindingOff
+}
+
+// Fingerprint はIPアドレスとUser-Agentから端末・接続元の指紋を作成
+// IPアドレスはサブネットに丸めるため、同じネットワーク内でのアドレスの変化は同じ接続元とみなす
+func (p SessionBindingPolicy) Fingerprint(ipAddress, userAgent string) SessionFingerprint {
+	return SessionFingerprint{
+		UserAgent: fingerprintHash(userAgent),
+		Subnet:    fingerprintHash(p.subnet(ipAddress)),
+	}
+}
+
+// subnet はIPアドレスを設定したサブネットに丸める（解釈できなければそのまま返す）
+func (p SessionBindingPolicy) subnet(ipAddress string) string {
+	ip := net.ParseIP(strings.TrimSpace(ipAddress))
+	if ip == nil {
+		return ipAddress
+	}
+	if v4 := ip.To4(); v4 != nil {
+		return (&net.IPNet{IP: v4.Mask(net.CIDRMask(p.IPv4PrefixLen, 32)), Mask: net.CIDRMask(p.IPv4PrefixLen, 32)}).String()
+	}
+	return (&net.IPNet{IP: ip.Mask(net.CIDRMask(p.IPv6PrefixLen, 128)), Mask: net.CIDRMask(p.IPv6PrefixLen, 128)}).String()
+}
+
+// Evaluate は固定した指紋と今回の指紋を比べ、変化と再ログインを求めるかを返す
+func (p SessionBindingPolicy) Evaluate(bound, current SessionFingerprint) (SessionFingerprintChange, bool) {
+	change := bound.Compare(current)
+	switch {
+	case change == SessionFingerprintUnchanged:
+		return change, false
+	case p.Mode == SessionBindingStrict:
+		return change, true
+	case p.Mode == SessionBindingLenient:
+		return change, change.Drastic()
+	}
+	return change, false
+}
+
+// SessionFingerprint はセッションを固定する端末・接続元の指紋（User-Agentとサブネットそれぞれのハッシュ）
+// どちらが変わったかを判定できるよう、まとめずに別々のハッシュで持つ
+type SessionFingerprint struct {
+	UserAgent string
+	Subnet    string
+}
+
+// fingerprintHash は指紋に使う短いハッシュ（SHA-256の先頭64ビット）
+func fingerprintHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:8])
+}
+
+// String は保存用の文字列（"<User-Agentのハッシュ>.<サブネットのハッシュ>"）
+func (f SessionFingerprint) String() string {
+	return f.UserAgent + "." + f.Subnet
+}
+
+// ParseSessionFingerprint は保存した指紋を読み込む（未固定・不正な値ならfalse）
+func ParseSessionFingerprint(value string) (SessionFingerprint, bool) {
+	userAgent, subnet, ok := strings.Cut(value, ".")
+	if !ok || userAgent == "" || subnet == "" {
+		return SessionFingerprint{}, false
+	}
+	return SessionFingerprint{UserAgent: userAgent, Subnet: subnet}, true
+}
+
+// Compare は指紋のどの部分が変わったかを返す
+func (f SessionFingerprint) Compare(other SessionFingerprint) SessionFingerprintChange {
+	userAgentChanged := f.UserAgent != other.UserAgent
+	subnetChanged := f.Subnet != other.Subnet
+	switch {
+	case userAgentChanged && subnetChanged:
+		return SessionFingerprintChangedBoth
+	case userAgentChanged:
+		return SessionFingerprintChangedUserAgent
+	case subnetChanged:
+		return SessionFingerprintChangedSubnet
+	}
+	return SessionFingerprintUnchanged
+}
+
+// SessionFingerprintChange は端末・接続元の指紋の変化
+type SessionFingerprintChange string
+
+const (
+	SessionFingerprintUnchanged        SessionFingerprintChange = ""
+	SessionFingerprintChangedUserAgent SessionFingerprintChange = "user_agent"
+	SessionFingerprintChangedSubnet    SessionFingerprintChange = "subnet"
+	SessionFingerprintChangedBoth      SessionFingerprintChange = "user_agent_and_subnet"
+)
+
+// Drastic は端末と接続元のどちらも変わったか（トークンを持ち出された可能性が高い）
+func (c SessionFingerprintChange) Drastic() bool {
+	return c == SessionFingerprintChangedBoth
+}
+
+// SessionBindingViolation はセッションが固定した端末・接続元と異なるところから使われた記録
+type SessionBindingViolation struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	SessionID uuid.UUID
+	Change    SessionFingerprintChange
+	Rejected  bool // セッションを失効させて再ログインを求めたか
+	IPAddress string
+	UserAgent string
+	CreatedAt time.Time
+}
+
+// NewSessionBindingViolation はセッションの固定の違反の記録を作成
+func NewSessionBindingViolation(session *Session, change SessionFingerprintChange, rejected bool, ipAddress, userAgent string) *SessionBindingViolation {
+	return &SessionBindingViolation{
+		ID:        uuid.New(),
+		UserID:    session.UserID,
+		SessionID: session.ID,
+		Change:    change,
+		Rejected:  rejected,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+		CreatedAt: time.Now(),
+	}
+}
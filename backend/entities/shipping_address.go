@@ -0,0 +1,150 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShippingAddressMaxPerUser は1人が登録できるお届け先の上限
+const ShippingAddressMaxPerUser = 10
+
+// shippingAddressFieldMaxLength は各項目の最大文字数
+const shippingAddressFieldMaxLength = 100
+
+var (
+	// postalCodePattern は日本の郵便番号（123-4567 または 1234567）
+	postalCodePattern = regexp.MustCompile(`^(\d{3})-?(\d{4})$`)
+	// phonePattern は日本の電話番号（ハイフンを除いて0から始まる10〜11桁）
+	phonePattern = regexp.MustCompile(`^0\d{9,10}$`)
+)
+
+// prefectures は都道府県名（お届け先の入力チェックに使う）
+var prefectures = []string{
+	"北海道", "青森県", "岩手県", "宮城県", "秋田県", "山形県", "福島県",
+	"茨城県", "栃木県", "群馬県", "埼玉県", "千葉県", "東京都", "神奈川県",
+	"新潟県", "富山県", "石川県", "福井県", "山梨県", "長野県", "岐阜県",
+	"静岡県", "愛知県", "三重県", "滋賀県", "京都府", "大阪府", "兵庫県",
+	"奈良県", "和歌山県", "鳥取県", "島根県", "岡山県", "広島県", "山口県",
+	"徳島県", "香川県", "愛媛県", "高知県", "福岡県", "佐賀県", "長崎県",
+	"熊本県", "大分県", "宮崎県", "鹿児島県", "沖縄県",
+}
+
+// ShippingAddress はユーザーのお届け先（配送が必要な商品の交換時に使う）
+type ShippingAddress struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	Label         string // 「自宅」「会社」など（任意）
+	RecipientName string
+	PostalCode    string // 123-4567 形式に正規化
+	Prefecture    string
+	City          string // 市区町村
+	Line1         string // 町名・番地
+	Line2         string // 建物名・部屋番号（任意）
+	Phone         string // ハイフンなしの数字に正規化
+	IsDefault     bool   // 交換時に指定がなければ使うお届け先（ユーザーごとに1件）
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// ShippingAddressInput はお届け先の入力値
+type ShippingAddressInput struct {
+	Label         string
+	RecipientName string
+	PostalCode    string
+	Prefecture    string
+	City          string
+	Line1         string
+	Line2         string
+	Phone         string
+}
+
+// NewShippingAddress は新しいお届け先を作成
+func NewShippingAddress(userID uuid.UUID, input ShippingAddressInput) (*ShippingAddress, error) {
+	now := time.Now()
+	a := &ShippingAddress{
+		ID:        uuid.New(),
+		UserID:    userID,
+		CreatedAt: now,
+	}
+	if err := a.Update(input); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Update は入力値を検証して住所を更新（既定かどうかは変えない）
+// 不正な項目があればErrInvalidShippingAddress（Paramsのfieldに項目名）
+func (a *ShippingAddress) Update(input ShippingAddressInput) error {
+	invalid := func(field string) error {
+		return ErrInvalidShippingAddress.WithParams(map[string]interface{}{"field": field})
+	}
+
+	label := strings.TrimSpace(input.Label)
+	name := strings.TrimSpace(input.RecipientName)
+	city := strings.TrimSpace(input.City)
+	line1 := strings.TrimSpace(input.Line1)
+	line2 := strings.TrimSpace(input.Line2)
+	for _, f := range []struct{ name, value string }{
+		{"label", label}, {"recipient_name", name}, {"city", city}, {"line1", line1}, {"line2", line2},
+	} {
+		if len([]rune(f.value)) > shippingAddressFieldMaxLength {
+			return invalid(f.name)
+		}
+	}
+	if name == "" {
+		return invalid("recipient_name")
+	}
+
+	postalCode, ok := NormalizePostalCode(input.PostalCode)
+	if !ok {
+		return invalid("postal_code")
+	}
+	prefecture := strings.TrimSpace(input.Prefecture)
+	if !slices.Contains(prefectures, prefecture) {
+		return invalid("prefecture")
+	}
+	if city == "" {
+		return invalid("city")
+	}
+	if line1 == "" {
+		return invalid("line1")
+	}
+	phone := strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(input.Phone))
+	if !phonePattern.MatchString(phone) {
+		return invalid("phone")
+	}
+
+	a.Label = label
+	a.RecipientName = name
+	a.PostalCode = postalCode
+	a.Prefecture = prefecture
+	a.City = city
+	a.Line1 = line1
+	a.Line2 = line2
+	a.Phone = phone
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+// Format は交換記録に残す宛名付きの住所（後でお届け先を変更・削除しても交換時の住所が残る）
+func (a *ShippingAddress) Format() string {
+	address := a.Prefecture + a.City + a.Line1
+	if a.Line2 != "" {
+		address += " " + a.Line2
+	}
+	return fmt.Sprintf("〒%s %s %s様 TEL:%s", a.PostalCode, address, a.RecipientName, a.Phone)
+}
+
+// NormalizePostalCode は郵便番号を 123-4567 形式にする（日本の郵便番号でなければfalse）
+func NormalizePostalCode(code string) (string, bool) {
+	m := postalCodePattern.FindStringSubmatch(strings.TrimSpace(code))
+	if m == nil {
+		return "", false
+	}
+	return m[1] + "-" + m[2], true
+}
@@ -0,0 +1,195 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// SplitRequestMaxParticipants は1つの割り勘でリクエストできる友達の上限
+	SplitRequestMaxParticipants = 20
+	// SplitShareTTL は割り勘で各自に送る送金リクエストの有効期間（通常の送金リクエストより長い）
+	SplitShareTTL = 7 * 24 * time.Hour
+)
+
+// SplitType は割り勘の分け方
+type SplitType string
+
+const (
+	SplitTypeEqual  SplitType = "equal"  // 均等（割り切れない分は作成者、または先頭の参加者から1ポイントずつ多く払う）
+	SplitTypeCustom SplitType = "custom" // 参加者ごとに金額を指定
+)
+
+// SplitRequestStatus は割り勘全体の状態（各自の送金リクエストから導く）
+type SplitRequestStatus string
+
+const (
+	SplitRequestStatusOpen      SplitRequestStatus = "open"      // 支払い待ちの参加者がいる
+	SplitRequestStatusCompleted SplitRequestStatus = "completed" // 全員が支払った
+	SplitRequestStatusClosed    SplitRequestStatus = "closed"    // 支払い待ちはいないが、拒否・期限切れの参加者がいる
+	SplitRequestStatusCancelled SplitRequestStatus = "cancelled" // 作成者がキャンセルした
+)
+
+// SplitShare は割り勘の参加者1人分の金額
+type SplitShare struct {
+	UserID uuid.UUID
+	Amount int64
+}
+
+// SplitRequest は合計額を複数の友達に割り勘で請求するリクエスト
+// 参加者ごとに作成者宛の送金リクエスト（SplitIDを持つ）を作り、参加者が承認すると支払われる
+type SplitRequest struct {
+	ID               uuid.UUID
+	RequesterID      uuid.UUID // 作成者（ポイントを受け取る）
+	TotalAmount      int64
+	SplitType        SplitType
+	IncludeRequester bool // 作成者も自分の分を負担する（参加者への請求の合計は合計額より少ない）
+	Message          string
+	IdempotencyKey   string
+	CancelledAt      *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// NewSplitRequest は割り勘を検証し、参加者ごとの金額とともに作成する
+// 均等の場合は participants の金額を無視して計算し、指定の場合は各自1以上で、
+// 合計が合計額と一致する（作成者も負担する場合は合計額より少ない）こと
+func NewSplitRequest(requesterID uuid.UUID, totalAmount int64, splitType SplitType, participants []SplitShare, includeRequester bool, message, idempotencyKey string) (*SplitRequest, []SplitShare, error) {
+	if requesterID == uuid.Nil || totalAmount <= 0 || idempotencyKey == "" {
+		return nil, nil, ErrInvalidSplitRequest
+	}
+	if len(participants) == 0 || len(participants) > SplitRequestMaxParticipants {
+		return nil, nil, ErrInvalidSplitRequest
+	}
+	seen := make(map[uuid.UUID]bool, len(participants))
+	for _, p := range participants {
+		if p.UserID == uuid.Nil || p.UserID == requesterID || seen[p.UserID] {
+			return nil, nil, ErrInvalidSplitRequest
+		}
+		seen[p.UserID] = true
+	}
+
+	var shares []SplitShare
+	switch splitType {
+	case SplitTypeEqual:
+		shares = equalShares(participants, totalAmount, includeRequester)
+	case SplitTypeCustom:
+		shares = make([]SplitShare, len(participants))
+		copy(shares, participants)
+	default:
+		return nil, nil, ErrInvalidSplitRequest
+	}
+
+	var sum int64
+	for _, s := range shares {
+		if s.Amount <= 0 {
+			return nil, nil, ErrInvalidSplitRequest
+		}
+		sum += s.Amount
+	}
+	if sum > totalAmount || (sum < totalAmount && !includeRequester) {
+		return nil, nil, ErrInvalidSplitRequest
+	}
+
+	now := time.Now()
+	return &SplitRequest{
+		ID:               uuid.New(),
+		RequesterID:      requesterID,
+		TotalAmount:      totalAmount,
+		SplitType:        splitType,
+		IncludeRequester: includeRequester,
+		Message:          message,
+		IdempotencyKey:   idempotencyKey,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}, shares, nil
+}
+
+// equalShares は合計額を均等に分ける
+// 作成者も負担する場合は端数を作成者が負担し、そうでなければ先頭の参加者から1ポイントずつ多く払う
+func equalShares(participants []SplitShare, totalAmount int64, includeRequester bool) []SplitShare {
+	parts := int64(len(participants))
+	if includeRequester {
+		parts++
+	}
+	base, remainder := totalAmount/parts, totalAmount%parts
+	shares := make([]SplitShare, len(participants))
+	for i, p := range participants {
+		shares[i] = SplitShare{UserID: p.UserID, Amount: base}
+		if !includeRequester && int64(i) < remainder {
+			shares[i].Amount++
+		}
+	}
+	return shares
+}
+
+// NewShareRequest は参加者から作成者への送金リクエストを作成する
+// 冪等性キーは割り勘と参加者から決まり、同じ割り勘の再送で二重に作られない
+func (s *SplitRequest) NewShareRequest(share SplitShare) (*TransferRequest, error) {
+	tr, err := NewTransferRequest(share.UserID, s.RequesterID, share.Amount, s.Message, fmt.Sprintf("split-%s-%s", s.ID, share.UserID))
+	if err != nil {
+		return nil, err
+	}
+	splitID := s.ID
+	tr.SplitID = &splitID
+	tr.ExpiresAt = tr.CreatedAt.Add(SplitShareTTL)
+	tr.LastActedBy = s.RequesterID
+	return tr, nil
+}
+
+// IsCancelled は作成者がキャンセルしたかどうか
+func (s *SplitRequest) IsCancelled() bool {
+	return s.CancelledAt != nil
+}
+
+// Cancel は割り勘をキャンセルする（支払い待ちの送金リクエストは呼び出し側でキャンセルする）
+func (s *SplitRequest) Cancel(shares []*TransferRequest) error {
+	if s.Summarize(shares).Status != SplitRequestStatusOpen {
+		return ErrSplitRequestClosed
+	}
+	now := time.Now()
+	s.CancelledAt = &now
+	s.UpdatedAt = now
+	return nil
+}
+
+// SplitRequestSummary は割り勘の支払い状況
+type SplitRequestSummary struct {
+	Status            SplitRequestStatus
+	ShareCount        int
+	PaidCount         int
+	PaidAmount        int64
+	OutstandingAmount int64 // 支払い待ち（有効期限内）の合計
+	RequesterAmount   int64 // 作成者が負担する分
+}
+
+// Summarize は各自の送金リクエストから支払い状況を集計する
+func (s *SplitRequest) Summarize(shares []*TransferRequest) *SplitRequestSummary {
+	summary := &SplitRequestSummary{ShareCount: len(shares), RequesterAmount: s.TotalAmount}
+	open := 0
+	for _, tr := range shares {
+		summary.RequesterAmount -= tr.Amount
+		switch {
+		case tr.Status == TransferRequestStatusApproved:
+			summary.PaidCount++
+			summary.PaidAmount += tr.FinalAmount()
+		case tr.IsPending() && !tr.IsExpired():
+			open++
+			summary.OutstandingAmount += tr.Amount
+		}
+	}
+
+	switch {
+	case s.IsCancelled():
+		summary.Status = SplitRequestStatusCancelled
+	case summary.PaidCount == summary.ShareCount:
+		summary.Status = SplitRequestStatusCompleted
+	case open > 0:
+		summary.Status = SplitRequestStatusOpen
+	default:
+		summary.Status = SplitRequestStatusClosed
+	}
+	return summary
+}
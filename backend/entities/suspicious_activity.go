@@ -0,0 +1,242 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransferHoldSettingKey は不審な送金を管理者の確認まで保留するかを保存するsystem_settingsのキー
+// "true" なら保留し、未設定・それ以外なら記録と通知だけ行って送金はそのまま実行する
+const TransferHoldSettingKey = "transfer_hold_suspicious"
+
+// ParseTransferHoldEnabled はsystem_settingsの値を保留の有無として解釈（未設定・不正な値は保留しない）
+func ParseTransferHoldEnabled(value string) bool {
+	enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+	return err == nil && enabled
+}
+
+// 不審な送金の検出条件（いずれも送金を止める上限ではなく、管理者へ知らせる目安）
+const (
+	// SuspiciousNewAccountAge はこの期間内に作成されたアカウントを新規アカウントとみなす
+	SuspiciousNewAccountAge = 7 * 24 * time.Hour
+	// SuspiciousNewAccountWindow・SuspiciousNewAccountTransfers は、この期間内の新規アカウントへの送金がこの回数に達したら検出する
+	SuspiciousNewAccountWindow    = 24 * time.Hour
+	SuspiciousNewAccountTransfers = 5
+	// SuspiciousWashWindow・SuspiciousWashTransfers は、この期間内に同じ相手と双方向にこの回数送り合ったら検出する
+	SuspiciousWashWindow    = time.Hour
+	SuspiciousWashTransfers = 3
+	// SuspiciousAfterGrantWindow は付与を受けてからこの期間内にその額以上を送金したら検出する
+	SuspiciousAfterGrantWindow = 10 * time.Minute
+)
+
+// SuspiciousGrantTransactionTypes は「付与を受けた直後の送金」で付与として数える取引の種類（入退室ボーナスは管理者付与として記録される）
+var SuspiciousGrantTransactionTypes = []TransactionType{TransactionTypeAdminGrant, TransactionTypeSystemGrant}
+
+// SuspiciousActivityReason は不審と判定した理由
+type SuspiciousActivityReason string
+
+const (
+	SuspiciousReasonNewAccountFanout SuspiciousActivityReason = "new_account_fanout" // 作成直後のアカウントへの送金が多い
+	SuspiciousReasonWashTransfer     SuspiciousActivityReason = "wash_transfer"      // 同じ相手と短時間に送り合っている
+	SuspiciousReasonAfterGrant       SuspiciousActivityReason = "after_grant"        // 付与を受けた直後にその額以上を送金
+)
+
+// suspiciousReasonLabels は通知に載せる理由の表示名
+var suspiciousReasonLabels = map[SuspiciousActivityReason]string{
+	SuspiciousReasonNewAccountFanout: "新規アカウントへの送金が多い",
+	SuspiciousReasonWashTransfer:     "同じ相手と短時間に送り合っている",
+	SuspiciousReasonAfterGrant:       "付与の直後に送金している",
+}
+
+// SuspiciousActivityStatus は不審な送金の記録の状態
+type SuspiciousActivityStatus string
+
+const (
+	SuspiciousActivityStatusOpen      SuspiciousActivityStatus = "open"      // 送金済み・確認待ち
+	SuspiciousActivityStatusHeld      SuspiciousActivityStatus = "held"      // 送金を保留中・確認待ち
+	SuspiciousActivityStatusDismissed SuspiciousActivityStatus = "dismissed" // 確認の結果、問題なし
+	SuspiciousActivityStatusConfirmed SuspiciousActivityStatus = "confirmed" // 確認の結果、不正と判断（送金済み）
+	SuspiciousActivityStatusReleased  SuspiciousActivityStatus = "released"  // 保留を解除して送金した
+	SuspiciousActivityStatusRejected  SuspiciousActivityStatus = "rejected"  // 保留した送金を取り消した
+	SuspiciousActivityStatusFailed    SuspiciousActivityStatus = "failed"    // 保留を解除したが送金に失敗した（残高不足など）
+)
+
+// IsValid は状態が定義済みかを判定
+func (s SuspiciousActivityStatus) IsValid() bool {
+	switch s {
+	case SuspiciousActivityStatusOpen, SuspiciousActivityStatusHeld, SuspiciousActivityStatusDismissed,
+		SuspiciousActivityStatusConfirmed, SuspiciousActivityStatusReleased, SuspiciousActivityStatusRejected,
+		SuspiciousActivityStatusFailed:
+		return true
+	}
+	return false
+}
+
+// TransferVelocityQuery は送金の検出に使う直近の取引を集計する条件
+type TransferVelocityQuery struct {
+	FromUserID       uuid.UUID
+	ToUserID         uuid.UUID
+	NewAccountSince  time.Time // この時刻以降に作成されたアカウントを新規アカウントとして数える
+	NewAccountWindow time.Time // この時刻以降の新規アカウントへの送金を数える
+	WashWindow       time.Time // この時刻以降の同じ相手との送金を数える
+	GrantWindow      time.Time // この時刻以降に送信者が受けた付与を合計する
+}
+
+// NewTransferVelocityQuery は now 時点の検出条件で集計条件を作成
+func NewTransferVelocityQuery(fromUserID, toUserID uuid.UUID, now time.Time) *TransferVelocityQuery {
+	return &TransferVelocityQuery{
+		FromUserID:       fromUserID,
+		ToUserID:         toUserID,
+		NewAccountSince:  now.Add(-SuspiciousNewAccountAge),
+		NewAccountWindow: now.Add(-SuspiciousNewAccountWindow),
+		WashWindow:       now.Add(-SuspiciousWashWindow),
+		GrantWindow:      now.Add(-SuspiciousAfterGrantWindow),
+	}
+}
+
+// TransferVelocity は送信者の直近の完了済み取引の集計（今回の送金は含まない）
+type TransferVelocity struct {
+	NewAccountTransfers int64 // 新規アカウントへの送金の回数
+	PairTransfers       int64 // 同じ相手との送金の回数（双方向）
+	ReverseTransfers    int64 // 相手から送信者への送金の回数
+	RecentGrantAmount   int64 // 送信者が受けた付与の合計
+}
+
+// DetectSuspiciousTransfer は送金が不審かを判定し、該当する理由を返す（該当なしなら空）
+func DetectSuspiciousTransfer(amount int64, recipient *User, v *TransferVelocity, now time.Time) []SuspiciousActivityReason {
+	var reasons []SuspiciousActivityReason
+	if recipient.CreatedAt.After(now.Add(-SuspiciousNewAccountAge)) && v.NewAccountTransfers+1 >= SuspiciousNewAccountTransfers {
+		reasons = append(reasons, SuspiciousReasonNewAccountFanout)
+	}
+	if v.ReverseTransfers > 0 && v.PairTransfers+1 >= SuspiciousWashTransfers {
+		reasons = append(reasons, SuspiciousReasonWashTransfer)
+	}
+	if v.RecentGrantAmount > 0 && amount >= v.RecentGrantAmount {
+		reasons = append(reasons, SuspiciousReasonAfterGrant)
+	}
+	return reasons
+}
+
+// SuspiciousActivity は不審と判定した送金の記録（管理者が確認する）
+// 保留した送金は確認されるまで実行せず、問題なしと判断されたら同じ冪等性キーで実行する
+type SuspiciousActivity struct {
+	ID             uuid.UUID
+	FromUserID     uuid.UUID
+	ToUserID       uuid.UUID
+	Amount         int64
+	Description    string
+	IdempotencyKey string
+	Reasons        []SuspiciousActivityReason
+	Status         SuspiciousActivityStatus
+	TransactionID  *uuid.UUID // 実行した送金（保留中・取り消しはnil）
+	ReviewedBy     *uuid.UUID
+	ReviewComment  string
+	ReviewedAt     *time.Time
+	FailureReason  string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewSuspiciousActivity は不審な送金の記録を作成（hold なら保留中として作成）
+func NewSuspiciousActivity(fromUserID, toUserID uuid.UUID, amount int64, description, idempotencyKey string, reasons []SuspiciousActivityReason, hold bool, now time.Time) *SuspiciousActivity {
+	status := SuspiciousActivityStatusOpen
+	if hold {
+		status = SuspiciousActivityStatusHeld
+	}
+	return &SuspiciousActivity{
+		ID:             uuid.New(),
+		FromUserID:     fromUserID,
+		ToUserID:       toUserID,
+		Amount:         amount,
+		Description:    description,
+		IdempotencyKey: idempotencyKey,
+		Reasons:        reasons,
+		Status:         status,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// IsHeld は送金を保留中かを判定
+func (a *SuspiciousActivity) IsHeld() bool {
+	return a.Status == SuspiciousActivityStatusHeld
+}
+
+// SetTransaction は実行した送金を記録
+func (a *SuspiciousActivity) SetTransaction(transactionID uuid.UUID, now time.Time) {
+	a.TransactionID = &transactionID
+	a.UpdatedAt = now
+}
+
+// Dismiss は問題なしと判断する（保留中なら保留を解除し、送金を実行する状態にする）
+func (a *SuspiciousActivity) Dismiss(reviewerID uuid.UUID, comment string, now time.Time) error {
+	next := SuspiciousActivityStatusDismissed
+	if a.IsHeld() {
+		next = SuspiciousActivityStatusReleased
+	}
+	return a.review(next, reviewerID, comment, now)
+}
+
+// Confirm は不正と判断する（保留中なら送金を取り消す）
+func (a *SuspiciousActivity) Confirm(reviewerID uuid.UUID, comment string, now time.Time) error {
+	next := SuspiciousActivityStatusConfirmed
+	if a.IsHeld() {
+		next = SuspiciousActivityStatusRejected
+	}
+	return a.review(next, reviewerID, comment, now)
+}
+
+func (a *SuspiciousActivity) review(next SuspiciousActivityStatus, reviewerID uuid.UUID, comment string, now time.Time) error {
+	if a.Status != SuspiciousActivityStatusOpen && a.Status != SuspiciousActivityStatusHeld {
+		return ErrSuspiciousActivityReviewed
+	}
+	if len([]rune(strings.TrimSpace(comment))) > AdminApprovalCommentMaxLength {
+		return ErrInvalidApprovalComment
+	}
+	a.Status = next
+	a.ReviewedBy = &reviewerID
+	a.ReviewComment = strings.TrimSpace(comment)
+	a.ReviewedAt = &now
+	a.UpdatedAt = now
+	return nil
+}
+
+// MarkFailed は保留を解除した送金に失敗したことを記録
+func (a *SuspiciousActivity) MarkFailed(reason string, now time.Time) {
+	a.Status = SuspiciousActivityStatusFailed
+	a.FailureReason = reason
+	a.UpdatedAt = now
+}
+
+// NewSuspiciousTransferNotification は不審な送金を検出したことを管理者へ知らせる通知を作成
+func NewSuspiciousTransferNotification(adminID uuid.UUID, a *SuspiciousActivity, from, to *User) *Notification {
+	title := "不審な送金を検出しました"
+	if a.IsHeld() {
+		title = "不審な送金を保留しました"
+	}
+	labels := make([]string, 0, len(a.Reasons))
+	reasons := make([]string, 0, len(a.Reasons))
+	for _, r := range a.Reasons {
+		labels = append(labels, suspiciousReasonLabels[r])
+		reasons = append(reasons, string(r))
+	}
+	return &Notification{
+		UserID: adminID,
+		Type:   NotificationTypeSuspiciousTransfer,
+		Title:  title,
+		Body:   fmt.Sprintf("%sさんから%sさんへの%dポイントの送金（%s）", from.DisplayName, to.DisplayName, a.Amount, strings.Join(labels, "、")),
+		Data: map[string]string{
+			"activity_id":  a.ID.String(),
+			"from_user_id": a.FromUserID.String(),
+			"to_user_id":   a.ToUserID.String(),
+			"amount":       fmt.Sprint(a.Amount),
+			"status":       string(a.Status),
+			"reasons":      strings.Join(reasons, ","),
+		},
+		CreatedAt: time.Now(),
+	}
+}
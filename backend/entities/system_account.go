@@ -0,0 +1,75 @@
+package entities
+
+// SystemAccount はユーザー以外のポイントの移動元・移動先となるシステムの口座
+// 付与・減算・失効・手数料の取引はユーザーとシステムの口座の間の移動として記録し、
+// ユーザーの残高の合計とシステムの口座の残高の合計が打ち消し合う（ポイントの保存）ことを確認できるようにする
+type SystemAccount string
+
+const (
+	SystemAccountTreasury   SystemAccount = "treasury"    // 発行元（付与はここから出し、管理者の減算・商品交換はここへ戻す。残高は負になる）
+	SystemAccountExpirySink SystemAccount = "expiry_sink" // 失効したポイントの回収先（失効の取り消しはここから戻す）
+	SystemAccountFees       SystemAccount = "fees"        // 送金手数料の受け取り先（受け取り用のユーザーを指定しない場合）
+)
+
+// SystemAccounts はすべてのシステムの口座
+func SystemAccounts() []SystemAccount {
+	return []SystemAccount{SystemAccountTreasury, SystemAccountExpirySink, SystemAccountFees}
+}
+
+// IsValid は定義済みのシステムの口座かどうか
+func (a SystemAccount) IsValid() bool {
+	switch a {
+	case SystemAccountTreasury, SystemAccountExpirySink, SystemAccountFees:
+		return true
+	}
+	return false
+}
+
+// SystemAccountBalance はシステムの口座の入出金と残高
+type SystemAccountBalance struct {
+	Account  SystemAccount
+	Credited int64 // ユーザーから受け取ったポイント
+	Debited  int64 // ユーザーへ出したポイント
+}
+
+// Balance は口座の残高（受け取り - 出金）
+func (b *SystemAccountBalance) Balance() int64 {
+	return b.Credited - b.Debited
+}
+
+// PointConservation はポイントの保存の確認結果
+// 付与・減算・失効・手数料はすべてユーザーとシステムの口座の間の移動なので、
+// ユーザーの残高の合計とシステムの口座の残高の合計は打ち消し合う（差が0にならなければ記録のない増減がある）
+type PointConservation struct {
+	UserBalanceTotal int64                   // ユーザーの残高の合計（保存されている値、退会済みを含む）
+	Accounts         []*SystemAccountBalance // SystemAccounts() の順
+}
+
+// SystemBalanceTotal はシステムの口座の残高の合計
+func (c *PointConservation) SystemBalanceTotal() int64 {
+	var total int64
+	for _, a := range c.Accounts {
+		total += a.Balance()
+	}
+	return total
+}
+
+// Difference は記録のない増減（正ならどこからも出ていないポイントがユーザーの残高にある）
+func (c *PointConservation) Difference() int64 {
+	return c.UserBalanceTotal + c.SystemBalanceTotal()
+}
+
+// Conserved はポイントが保存されているか
+func (c *PointConservation) Conserved() bool {
+	return c.Difference() == 0
+}
+
+// Account は口座の残高を取得（記録がなければ0）
+func (c *PointConservation) Account(account SystemAccount) *SystemAccountBalance {
+	for _, a := range c.Accounts {
+		if a.Account == account {
+			return a
+		}
+	}
+	return &SystemAccountBalance{Account: account}
+}
@@ -0,0 +1,13 @@
+package entities
+
+// ConfigSetting は起動時に読み込んだ設定項目（秘密の値は伏せてある）
+type ConfigSetting struct {
+	Key    string // 環境変数名
+	Path   string // 設定ファイルでのキー
+	Value  string
+	Source string // default / file / env / secret
+	Secret bool
+}
+
+// ConfigSettings は管理者向けに表示する設定の一覧
+type ConfigSettings []*ConfigSetting
@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTenantID は既定のテナント（マルチテナント化する前のデータと、テナントを指定しないリクエストの所属先）
+var DefaultTenantID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+const (
+	// DefaultTenantSlug は既定のテナントのスラッグ
+	DefaultTenantSlug = "default"
+	// tenantNameMaxLength はテナント名の最大文字数
+	tenantNameMaxLength = 100
+)
+
+// tenantSlugPattern はスラッグの形式（サブドメインに使えるよう英小文字・数字・ハイフン、2〜40文字）
+var tenantSlugPattern = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9-]{0,38}[a-z0-9])$`)
+
+// reservedTenantSlugs はサブドメインとして別の用途に使うためテナントに付けられないスラッグ
+var reservedTenantSlugs = map[string]bool{
+	"www": true, "api": true, "admin": true, "app": true, "static": true, "mail": true,
+}
+
+// Tenant は1つのデプロイメントを共有する組織
+// ユーザー・取引・商品・システム設定はテナントごとに分かれ、他のテナントからは見えない
+type Tenant struct {
+	ID        uuid.UUID
+	Slug      string // サブドメイン・X-Tenant-IDヘッダーで指定する識別子
+	Name      string
+	IsActive  bool // falseならそのテナントへのリクエストをすべて拒否する
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewTenant は新しいテナントを作成
+func NewTenant(slug, name string, now time.Time) (*Tenant, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if !tenantSlugPattern.MatchString(slug) || reservedTenantSlugs[slug] || slug == DefaultTenantSlug {
+		return nil, ErrInvalidTenant
+	}
+	t := &Tenant{
+		ID:        uuid.New(),
+		Slug:      slug,
+		IsActive:  true,
+		CreatedAt: now,
+	}
+	if err := t.Update(name, true, now); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Update はテナント名と有効・無効を変更（既定のテナントは無効にできない）
+func (t *Tenant) Update(name string, isActive bool, now time.Time) error {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > tenantNameMaxLength {
+		return ErrInvalidTenant
+	}
+	if !isActive && t.IsDefault() {
+		return ErrInvalidTenant
+	}
+	t.Name = name
+	t.IsActive = isActive
+	t.UpdatedAt = now
+	return nil
+}
+
+// IsDefault は既定のテナントかどうか
+func (t *Tenant) IsDefault() bool {
+	return t.ID == DefaultTenantID
+}
+
+type tenantContextKey struct{}
+
+// WithTenantID はリクエストの対象テナントを設定したcontextを返す
+// データソースはこのcontextで実行するクエリをテナントの行だけに絞り込む
+func WithTenantID(ctx context.Context, tenantID uuid.UUID) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// TenantIDFromContext はcontextの対象テナントを返す
+// 設定されていなければfalse（ワーカーなど、すべてのテナントを処理する場合）
+func TenantIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(tenantContextKey{}).(uuid.UUID)
+	return id, ok
+}
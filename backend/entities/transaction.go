@@ -16,6 +16,20 @@ const (
 	TransactionTypeAdminDeduct  TransactionType = "admin_deduct"  // 管理者減算
 	TransactionTypeSystemGrant  TransactionType = "system_grant"  // システム付与
 	TransactionTypeSystemExpire TransactionType = "system_expire" // ポイント期限切れ
+	TransactionTypeTransferFee  TransactionType = "transfer_fee"  // 送金手数料（送信者から手数料の受け取り用アカウントへ）
+
+	// TransactionTypeBalanceCorrection は取引履歴との照合による残高の補正（取引履歴から計算する残高には含めない）
+	TransactionTypeBalanceCorrection TransactionType = "balance_correction"
+	// TransactionTypeOnboardingBonus は登録時のウェルカムボーナス（分析ではシステム付与と分けて集計する）
+	TransactionTypeOnboardingBonus TransactionType = "onboarding_bonus"
+)
+
+const (
+	// TransferMessageMaxLength は送金の説明・送金リクエストのメッセージの最大文字数
+	TransferMessageMaxLength = 200
+	// PointAmountMax は1回の送金・リクエストで指定できるポイントの上限
+	// （桁の打ち間違いや残高の計算のオーバーフローを入力の時点で防ぐ）
+	PointAmountMax int64 = 100_000_000
 )
 
 // TransactionStatus は取引状態
@@ -31,13 +45,17 @@ const (
 // Transaction はポイント取引エンティティ
 type Transaction struct {
 	ID              uuid.UUID
-	FromUserID      *uuid.UUID // 送信者（nilの場合はシステム付与）
-	ToUserID        *uuid.UUID // 受信者（nilの場合はシステムへの返却）
+	FromUserID      *uuid.UUID    // 送信者（nilの場合はFromAccountのシステムの口座から）
+	ToUserID        *uuid.UUID    // 受信者（nilの場合はToAccountのシステムの口座へ）
+	FromAccount     SystemAccount // 移動元のシステムの口座（ユーザーからの移動なら空）
+	ToAccount       SystemAccount // 移動先のシステムの口座（ユーザーへの移動なら空）
 	Amount          int64
 	TransactionType TransactionType
 	Status          TransactionStatus
 	IdempotencyKey  *string // 冪等性キー
 	Description     string
+	ReasonCode      string                 // 理由コード（管理者の付与・減算のみ、ReasonCodeのCode）
+	Tag             string                 // プロジェクト・タグ（集計用の自由入力）
 	Metadata        map[string]interface{} // 追加メタデータ（JSONBとして保存）
 	CreatedAt       time.Time
 	CompletedAt     *time.Time
@@ -46,13 +64,13 @@ type Transaction struct {
 // NewTransfer はユーザー間送金トランザクションを作成
 func NewTransfer(fromUserID, toUserID uuid.UUID, amount int64, idempotencyKey string, description string) (*Transaction, error) {
 	if fromUserID == toUserID {
-		return nil, errors.New("cannot transfer to the same user")
+		return nil, ErrSameUserTransfer
 	}
 	if amount <= 0 {
-		return nil, errors.New("amount must be positive")
+		return nil, ErrInvalidAmount
 	}
 	if idempotencyKey == "" {
-		return nil, errors.New("idempotency key is required")
+		return nil, ErrIdempotencyKeyRequired
 	}
 
 	toUserIDPtr := toUserID
@@ -70,10 +88,40 @@ func NewTransfer(fromUserID, toUserID uuid.UUID, amount int64, idempotencyKey st
 	}, nil
 }
 
+// NewTransferFee は送金手数料のトランザクションを作成（元の送金をメタデータに記録）
+// 受け取り用のユーザー（feeAccountID）がnilならシステムの手数料口座に送る
+func NewTransferFee(fromUserID uuid.UUID, feeAccountID *uuid.UUID, fee int64, transfer *Transaction) (*Transaction, error) {
+	if fee <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	now := time.Now()
+	tx := &Transaction{
+		ID:              uuid.New(),
+		FromUserID:      &fromUserID,
+		Amount:          fee,
+		TransactionType: TransactionTypeTransferFee,
+		Status:          TransactionStatusCompleted,
+		Description:     "送金手数料",
+		Metadata: map[string]interface{}{
+			"transfer_id": transfer.ID.String(),
+		},
+		CreatedAt:   now,
+		CompletedAt: &now,
+	}
+	if feeAccountID != nil {
+		toUserID := *feeAccountID
+		tx.ToUserID = &toUserID
+	} else {
+		tx.ToAccount = SystemAccountFees
+	}
+	return tx, nil
+}
+
 // NewAdminGrant は管理者によるポイント付与トランザクションを作成
 func NewAdminGrant(toUserID uuid.UUID, amount int64, description string, adminID uuid.UUID) (*Transaction, error) {
 	if amount <= 0 {
-		return nil, errors.New("amount must be positive")
+		return nil, ErrInvalidAmount
 	}
 
 	metadata := map[string]interface{}{
@@ -85,6 +133,7 @@ func NewAdminGrant(toUserID uuid.UUID, amount int64, description string, adminID
 		ID:              uuid.New(),
 		FromUserID:      nil, // システムからの付与
 		ToUserID:        &toUserIDPtr,
+		FromAccount:     SystemAccountTreasury,
 		Amount:          amount,
 		TransactionType: TransactionTypeAdminGrant,
 		Status:          TransactionStatusCompleted,
@@ -95,10 +144,35 @@ func NewAdminGrant(toUserID uuid.UUID, amount int64, description string, adminID
 	}, nil
 }
 
+// NewSystemGrant はシステム（キオスク端末等）によるポイント付与トランザクションを作成
+func NewSystemGrant(toUserID uuid.UUID, amount int64, description string, metadata map[string]interface{}) (*Transaction, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+
+	toUserIDPtr := toUserID
+	return &Transaction{
+		ID:              uuid.New(),
+		FromUserID:      nil, // システムからの付与
+		ToUserID:        &toUserIDPtr,
+		FromAccount:     SystemAccountTreasury,
+		Amount:          amount,
+		TransactionType: TransactionTypeSystemGrant,
+		Status:          TransactionStatusCompleted,
+		Description:     description,
+		Metadata:        metadata,
+		CreatedAt:       time.Now(),
+		CompletedAt:     ptrTime(time.Now()),
+	}, nil
+}
+
 // NewAdminDeduct は管理者によるポイント減算トランザクションを作成
 func NewAdminDeduct(fromUserID uuid.UUID, amount int64, description string, adminID uuid.UUID) (*Transaction, error) {
 	if amount <= 0 {
-		return nil, errors.New("amount must be positive")
+		return nil, ErrInvalidAmount
 	}
 
 	metadata := map[string]interface{}{
@@ -110,6 +184,7 @@ func NewAdminDeduct(fromUserID uuid.UUID, amount int64, description string, admi
 		ID:              uuid.New(),
 		FromUserID:      &fromUserID,
 		ToUserID:        nil, // システムへの返却
+		ToAccount:       SystemAccountTreasury,
 		Amount:          amount,
 		TransactionType: TransactionTypeAdminDeduct,
 		Status:          TransactionStatusCompleted,
@@ -120,6 +195,41 @@ func NewAdminDeduct(fromUserID uuid.UUID, amount int64, description string, admi
 	}, nil
 }
 
+// NewSystemExpire はポイント失効のトランザクションを作成（失効したポイントは失効の回収口座へ移す）
+func NewSystemExpire(fromUserID uuid.UUID, amount int64, description string, metadata map[string]interface{}) (*Transaction, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+
+	return &Transaction{
+		ID:              uuid.New(),
+		FromUserID:      &fromUserID,
+		ToUserID:        nil, // システムへの返却
+		ToAccount:       SystemAccountExpirySink,
+		Amount:          amount,
+		TransactionType: TransactionTypeSystemExpire,
+		Status:          TransactionStatusCompleted,
+		Description:     description,
+		Metadata:        metadata,
+		CreatedAt:       time.Now(),
+		CompletedAt:     ptrTime(time.Now()),
+	}, nil
+}
+
+// SetMemo は理由コードとプロジェクト・タグを設定する（理由コードの存在確認は呼び出し側で行う）
+func (t *Transaction) SetMemo(reasonCode, tag string) error {
+	tag, err := NormalizeTransactionTag(tag)
+	if err != nil {
+		return err
+	}
+	t.ReasonCode = NormalizeReasonCode(reasonCode)
+	t.Tag = tag
+	return nil
+}
+
 // Complete は取引を完了状態にする
 func (t *Transaction) Complete() error {
 	if t.Status != TransactionStatusPending {
@@ -166,9 +276,37 @@ func ptrTime(t time.Time) *time.Time {
 	return &t
 }
 
+// 退会したユーザーの側を示すメタデータのキー（ユーザー削除でIDはNULLになるため、システム付与と区別する）
+const (
+	MetadataFromUserDeleted = "from_user_deleted"
+	MetadataToUserDeleted   = "to_user_deleted"
+)
+
+// FromUserDeleted は送信者が退会済みかどうか
+func (t *Transaction) FromUserDeleted() bool {
+	deleted, _ := t.Metadata[MetadataFromUserDeleted].(bool)
+	return deleted
+}
+
+// ToUserDeleted は受信者が退会済みかどうか
+func (t *Transaction) ToUserDeleted() bool {
+	deleted, _ := t.Metadata[MetadataToUserDeleted].(bool)
+	return deleted
+}
+
+// MetadataMigrated は他のシステムから取り込んだ過去の取引を示すメタデータのキー
+const MetadataMigrated = "migrated"
+
+// Migrated は他のシステムから取り込んだ過去の取引かどうか
+func (t *Transaction) Migrated() bool {
+	migrated, _ := t.Metadata[MetadataMigrated].(bool)
+	return migrated
+}
+
 // TransactionWithUsers はトランザクションとユーザー情報のセット（JOIN結果）
 type TransactionWithUsers struct {
-	Transaction *Transaction
-	FromUser    *User // nilの場合がある（システム付与等）
-	ToUser      *User // nilの場合がある
+	Transaction   *Transaction
+	FromUser      *User // nilの場合がある（システム付与等）
+	ToUser        *User // nilの場合がある
+	HasAttachment bool  // 送金（または元の送金リクエスト）に画像が添付されている
 }
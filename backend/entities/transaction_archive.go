@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// TransactionRetentionMinDays は設定できる取引の保持期間の下限（日）
+	// ランク判定・分析・不審な取引の検出など、直近の取引を数える処理に影響しないよう1年より短くはしない
+	TransactionRetentionMinDays = 365
+	// TransactionArchiveMaxRange は保管済みの取引の検索で指定できる期間の上限
+	TransactionArchiveMaxRange = 366 * 24 * time.Hour
+)
+
+// TransactionRetention は取引の保持期間の設定
+// Daysより古い確定済みの取引を保管用のテーブル（archived_transactions）へ移す
+type TransactionRetention struct {
+	Days      int // 0なら移さない
+	BatchSize int // 1トランザクションで移す件数
+}
+
+// Enabled は取引を保管用のテーブルへ移すかどうか
+func (r TransactionRetention) Enabled() bool {
+	return r.Days > 0
+}
+
+// Cutoff はこの日時より前に作成された取引を移す
+func (r TransactionRetention) Cutoff(now time.Time) time.Time {
+	return now.AddDate(0, 0, -r.Days)
+}
+
+// ArchivedTransaction は保持期間を過ぎて保管用のテーブルへ移した取引
+type ArchivedTransaction struct {
+	Transaction
+	ArchivedAt time.Time
+}
+
+// ArchivedTransactionFilter は保管済みの取引の検索条件
+// 保管用のテーブルは索引が少ないため、期間は必ず指定する
+type ArchivedTransactionFilter struct {
+	UserID *uuid.UUID // 送信者・受信者のどちらか
+	From   time.Time
+	To     time.Time
+}
+
+// Validate は検索条件を検証する（期間はTransactionArchiveMaxRange以内）
+func (f *ArchivedTransactionFilter) Validate() error {
+	if f.From.IsZero() || f.To.IsZero() || !f.From.Before(f.To) || f.To.Sub(f.From) > TransactionArchiveMaxRange {
+		return ErrInvalidArchiveQuery
+	}
+	return nil
+}
+
+// TransactionArchiveSummary は保管済みの取引の月・種別・状態ごとの集計
+// 取引を移すときに加算し、移した後も分析で月ごとの件数・ポイントを使えるようにする
+type TransactionArchiveSummary struct {
+	Month            string // JSTの年月（2006-01）
+	TransactionType  TransactionType
+	Status           TransactionStatus
+	TransactionCount int64
+	TotalAmount      int64
+	UpdatedAt        time.Time
+}
+
+// TransactionArchiveMonth は取引を集計する年月（JST）
+func TransactionArchiveMonth(t time.Time) string {
+	return t.In(scheduleLocation).Format("2006-01")
+}
+
+// ValidateArchiveMonth は集計の年月の指定（2006-01、空なら制限なし）を検証する
+func ValidateArchiveMonth(month string) error {
+	if month == "" {
+		return nil
+	}
+	if _, err := time.Parse("2006-01", month); err != nil {
+		return ErrInvalidArchiveQuery
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package entities
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// TransactionImportMaxBytes は過去の取引の取り込みで受け付けるCSVの最大サイズ
+	TransactionImportMaxBytes = 5 << 20
+	// TransactionImportMaxRows は1回の取り込みで受け付ける最大行数（ヘッダーを除く）
+	// すべての行を1トランザクションで書き込むため、ユーザーの一括登録より多くはしない
+	TransactionImportMaxRows = 5000
+	// transactionImportDescriptionMaxLength は取引の説明の最大文字数
+	transactionImportDescriptionMaxLength = 500
+)
+
+// TransactionImportTag は取り込んだ取引に付けるタグ
+const TransactionImportTag = "transaction_import"
+
+// transactionImportColumns はCSVヘッダーに必要な列名（順不同、description は任意、余分な列は無視する）
+var transactionImportColumns = []string{"date", "from", "to", "amount", "type"}
+
+// transactionImportDateLayouts は日時の列で受け付ける形式（タイムゾーンのない形式はJSTとみなす）
+var transactionImportDateLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006/01/02",
+}
+
+var transactionImportLocation = time.FixedZone("JST", 9*60*60)
+
+// TransactionImportRow は過去の取引の取り込みCSVの1行
+// From・To はユーザー名。付与は送信者、減算は受信者を空にする（システムの発行元の口座との取引になる）
+type TransactionImportRow struct {
+	Line        int // ファイル上の行番号（ヘッダーが1行目）
+	Date        time.Time
+	From        string
+	To          string
+	Amount      int64
+	Type        TransactionType
+	Description string
+	Err         error // 行の形式エラー（nilなら取り込み対象）
+}
+
+// ParseTransactionImportCSV は過去の取引の取り込みCSVを読み込む
+// ヘッダー不備・行数超過・行がない場合はファイル全体のエラー、各行の形式エラーは行ごとにErrへ入れて返す
+func ParseTransactionImportCSV(r io.Reader, now time.Time) ([]*TransactionImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, ErrInvalidTransactionImportFile
+	}
+	index := make(map[string]int, len(header))
+	for i, col := range header {
+		if i == 0 {
+			col = strings.TrimPrefix(col, "\ufeff") // Excelが付けるBOM
+		}
+		index[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+	for _, col := range transactionImportColumns {
+		if _, ok := index[col]; !ok {
+			return nil, ErrInvalidTransactionImportFile
+		}
+	}
+
+	var rows []*TransactionImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidTransactionImportFile
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		if len(rows) >= TransactionImportMaxRows {
+			return nil, ErrInvalidTransactionImportFile
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		row := &TransactionImportRow{
+			Line:        line,
+			From:        field("from"),
+			To:          field("to"),
+			Type:        TransactionType(strings.ToLower(field("type"))),
+			Description: field("description"),
+		}
+		row.Err = row.parse(field("date"), field("amount"), now)
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, ErrInvalidTransactionImportFile
+	}
+	return rows, nil
+}
+
+// parse は日時・金額を読み取り、種別ごとに送信者・受信者の有無を検証する
+func (r *TransactionImportRow) parse(date, amount string, now time.Time) error {
+	at, ok := parseTransactionImportDate(date)
+	if !ok || at.After(now) {
+		return ErrInvalidTransactionImportRow
+	}
+	r.Date = at
+
+	n, err := strconv.ParseInt(amount, 10, 64)
+	if err != nil || n <= 0 {
+		return ErrInvalidTransactionImportRow
+	}
+	r.Amount = n
+
+	if len([]rune(r.Description)) > transactionImportDescriptionMaxLength {
+		return ErrInvalidTransactionImportRow
+	}
+
+	switch r.Type {
+	case TransactionTypeTransfer:
+		if r.From == "" || r.To == "" || r.From == r.To {
+			return ErrInvalidTransactionImportRow
+		}
+	case TransactionTypeAdminGrant, TransactionTypeSystemGrant:
+		if r.From != "" || r.To == "" {
+			return ErrInvalidTransactionImportRow
+		}
+	case TransactionTypeAdminDeduct:
+		if r.From == "" || r.To != "" {
+			return ErrInvalidTransactionImportRow
+		}
+	default:
+		return ErrInvalidTransactionImportRow
+	}
+	return nil
+}
+
+func parseTransactionImportDate(value string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, true
+	}
+	for _, layout := range transactionImportDateLayouts {
+		if t, err := time.ParseInLocation(layout, value, transactionImportLocation); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// PointBatchSource は受け取った側のポイントバッチのソースタイプ
+func (r *TransactionImportRow) PointBatchSource() PointBatchSourceType {
+	switch r.Type {
+	case TransactionTypeTransfer:
+		return PointBatchSourceTransfer
+	case TransactionTypeSystemGrant:
+		return PointBatchSourceSystemGrant
+	}
+	return PointBatchSourceAdminGrant
+}
+
+// NewTransaction は行の内容から元の日時の完了済み取引を作成する
+// ユーザーのいない側はシステムの発行元の口座とし、メタデータに移行した取引であることと取り込みIDを記録する
+func (r *TransactionImportRow) NewTransaction(fromUserID, toUserID *uuid.UUID, importID, adminID uuid.UUID) *Transaction {
+	description := r.Description
+	if description == "" {
+		description = "移行した取引"
+	}
+	at := r.Date
+	tx := &Transaction{
+		ID:              uuid.New(),
+		FromUserID:      fromUserID,
+		ToUserID:        toUserID,
+		Amount:          r.Amount,
+		TransactionType: r.Type,
+		Status:          TransactionStatusCompleted,
+		Description:     description,
+		Tag:             TransactionImportTag,
+		Metadata: map[string]interface{}{
+			MetadataMigrated: true,
+			"import_id":      importID.String(),
+			"import_line":    r.Line,
+			"admin_id":       adminID.String(),
+		},
+		CreatedAt:   at,
+		CompletedAt: &at,
+	}
+	if fromUserID == nil {
+		tx.FromAccount = SystemAccountTreasury
+	}
+	if toUserID == nil {
+		tx.ToAccount = SystemAccountTreasury
+	}
+	return tx
+}
@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransactionReceiptVersion は控えの検証ハッシュの計算方法のバージョン
+const TransactionReceiptVersion = 1
+
+// ReceiptParty は控えに載せる取引の当事者（ユーザーまたはシステムの口座）
+type ReceiptParty struct {
+	UserID      *uuid.UUID
+	Username    string
+	DisplayName string
+	Account     SystemAccount // システムの口座の場合
+}
+
+// TransactionReceipt は取引の印刷用の控え
+// Hashは取引の変わらない内容（ID・種類・金額・当事者・日時）に対するサーバーの鍵のHMACで、
+// 控えを受け取った人は管理者に確かめてもらうことで本物かを確認できる（状態や表示名は含めない）
+type TransactionReceipt struct {
+	TransactionID uuid.UUID
+	Type          TransactionType
+	Status        TransactionStatus
+	Amount        int64
+	Description   string
+	From          ReceiptParty
+	To            ReceiptParty
+	CreatedAt     time.Time
+	CompletedAt   *time.Time
+	IssuedAt      time.Time
+	Version       int
+	Hash          string
+}
+
+// NewTransactionReceipt は取引の控えを作成（fromUser・toUserは当事者がユーザーの場合のみ。Hashは署名して設定する）
+// 完了していない取引（処理中・失敗）の控えは作れない
+func NewTransactionReceipt(tx *Transaction, fromUser, toUser *User, issuedAt time.Time) (*TransactionReceipt, error) {
+	if tx.Status != TransactionStatusCompleted && tx.Status != TransactionStatusReversed {
+		return nil, ErrReceiptUnavailable
+	}
+	return &TransactionReceipt{
+		TransactionID: tx.ID,
+		Type:          tx.TransactionType,
+		Status:        tx.Status,
+		Amount:        tx.Amount,
+		Description:   tx.Description,
+		From:          newReceiptParty(tx.FromUserID, fromUser, tx.FromAccount),
+		To:            newReceiptParty(tx.ToUserID, toUser, tx.ToAccount),
+		CreatedAt:     tx.CreatedAt,
+		CompletedAt:   tx.CompletedAt,
+		IssuedAt:      issuedAt,
+		Version:       TransactionReceiptVersion,
+	}, nil
+}
+
+func newReceiptParty(userID *uuid.UUID, user *User, account SystemAccount) ReceiptParty {
+	party := ReceiptParty{UserID: userID, Account: account}
+	if user != nil {
+		party.Username = user.Username
+		party.DisplayName = user.DisplayName
+	}
+	return party
+}
+
+// SigningPayload は検証ハッシュを計算する対象（バージョン・ID・種類・金額・当事者・日時（UNIX秒）を改行で区切ったもの）
+func (r *TransactionReceipt) SigningPayload() string {
+	completedAt := ""
+	if r.CompletedAt != nil {
+		completedAt = strconv.FormatInt(r.CompletedAt.Unix(), 10)
+	}
+	return strings.Join([]string{
+		strconv.Itoa(r.Version),
+		r.TransactionID.String(),
+		string(r.Type),
+		strconv.FormatInt(r.Amount, 10),
+		r.From.signingValue(),
+		r.To.signingValue(),
+		strconv.FormatInt(r.CreatedAt.Unix(), 10),
+		completedAt,
+	}, "\n")
+}
+
+func (p ReceiptParty) signingValue() string {
+	if p.UserID != nil {
+		return "user:" + p.UserID.String()
+	}
+	return "account:" + string(p.Account)
+}
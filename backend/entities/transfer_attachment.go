@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransferAttachment は送金・送金リクエストに添付した画像（購入したものの写真など）
+// 1件の送金・送金リクエストにつき1枚で、添付し直すと置き換える
+// 送金リクエストの添付は、承認してできた送金の添付としても当事者に見える
+type TransferAttachment struct {
+	ID                uuid.UUID
+	TransactionID     *uuid.UUID // 送金に添付した場合
+	TransferRequestID *uuid.UUID // 送金リクエストに添付した場合
+	UploadedBy        uuid.UUID
+	FilePath          string // FileStorageServiceの保存先（相対パス）
+	FileName          string // アップロードしたときのファイル名
+	SizeBytes         int64
+	CreatedAt         time.Time
+}
+
+// NewTransactionAttachment は送金への添付を作成
+func NewTransactionAttachment(transactionID, uploadedBy uuid.UUID, filePath, fileName string, sizeBytes int64) *TransferAttachment {
+	a := newTransferAttachment(uploadedBy, filePath, fileName, sizeBytes)
+	a.TransactionID = &transactionID
+	return a
+}
+
+// NewTransferRequestAttachment は送金リクエストへの添付を作成
+func NewTransferRequestAttachment(requestID, uploadedBy uuid.UUID, filePath, fileName string, sizeBytes int64) *TransferAttachment {
+	a := newTransferAttachment(uploadedBy, filePath, fileName, sizeBytes)
+	a.TransferRequestID = &requestID
+	return a
+}
+
+func newTransferAttachment(uploadedBy uuid.UUID, filePath, fileName string, sizeBytes int64) *TransferAttachment {
+	return &TransferAttachment{
+		ID:         uuid.New(),
+		UploadedBy: uploadedBy,
+		FilePath:   filePath,
+		FileName:   fileName,
+		SizeBytes:  sizeBytes,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// CanAttach は送金に画像を添付できるかを判定（ユーザー間の完了した送金のみ）
+func (t *Transaction) CanAttach() error {
+	if t.TransactionType != TransactionTypeTransfer || t.Status != TransactionStatusCompleted {
+		return ErrAttachmentNotAllowed
+	}
+	return nil
+}
+
+// CanAttach は送金リクエストに画像を添付できるかを判定（承認待ち・カウンターオファー確認待ちのみ）
+func (tr *TransferRequest) CanAttach() error {
+	if !tr.IsOpen() {
+		return ErrAttachmentNotAllowed
+	}
+	return nil
+}
+
+// DefaultTransferAttachmentRetentionDays は添付画像の既定の保持期間（日）
+const DefaultTransferAttachmentRetentionDays = 90
+
+// TransferAttachmentRetention は添付画像の保持期間の設定
+// 添付した送金（承認されなかった送金リクエストは添付した日時）がDaysより古くなった画像を
+// 定期実行ジョブ（transfer_attachment_cleanup）で削除する
+type TransferAttachmentRetention struct {
+	Days int // 0なら削除しない
+}
+
+// Enabled は添付画像を削除するかどうか
+func (r TransferAttachmentRetention) Enabled() bool {
+	return r.Days > 0
+}
+
+// Cutoff はこの日時より前の送金の添付画像を削除する
+func (r TransferAttachmentRetention) Cutoff(now time.Time) time.Time {
+	return now.AddDate(0, 0, -r.Days)
+}
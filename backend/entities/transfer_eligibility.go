@@ -0,0 +1,83 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransferEligibilitySettingKey は送金できるユーザーの条件を保存するsystem_settingsのキー
+const TransferEligibilitySettingKey = "transfer_eligibility"
+
+// TransferMinAccountAgeMaxHours はアカウント作成から送金できるまでの時間の上限（1年）
+const TransferMinAccountAgeMaxHours = 365 * 24
+
+// TransferAcceptance はユーザーが誰からのポイントを受け取るか
+type TransferAcceptance string
+
+const (
+	TransferAcceptanceAnyone      TransferAcceptance = "anyone"       // 誰からでも受け取る
+	TransferAcceptanceFriendsOnly TransferAcceptance = "friends_only" // 友達からだけ受け取る
+)
+
+// Validate は受け取る相手の設定を検証（空は「指定なし」として許す）
+func (a TransferAcceptance) Validate() error {
+	switch a {
+	case "", TransferAcceptanceAnyone, TransferAcceptanceFriendsOnly:
+		return nil
+	default:
+		return ErrInvalidTransferAcceptance
+	}
+}
+
+// TransferEligibilityPolicy は送金・送金リクエストの作成ができるユーザーの条件
+// 未設定なら条件なし（誰でも送金でき、誰からでも受け取る）
+type TransferEligibilityPolicy struct {
+	RequireEmailVerification bool               `json:"require_email_verification"`              // メール認証済みのユーザーだけ送金できる
+	MinAccountAgeHours       int                `json:"min_account_age_hours"`                   // アカウント作成からこの時間が経つまで送金できない（0で無効）
+	DefaultAcceptance        TransferAcceptance `json:"default_accept_transfers_from,omitempty"` // 受け取る相手を設定していないユーザーの既定（空ならanyone）
+	UpdatedBy                *uuid.UUID         `json:"updated_by,omitempty"`
+	UpdatedAt                time.Time          `json:"updated_at"`
+}
+
+// Validate は条件の値を検証
+func (p *TransferEligibilityPolicy) Validate() error {
+	if p.MinAccountAgeHours < 0 || p.MinAccountAgeHours > TransferMinAccountAgeMaxHours {
+		return ErrInvalidTransferEligibility
+	}
+	return p.DefaultAcceptance.Validate()
+}
+
+// AcceptanceOf はユーザーが誰からのポイントを受け取るか（ユーザーの設定がなければ既定、既定もなければanyone）
+func (p *TransferEligibilityPolicy) AcceptanceOf(user *User) TransferAcceptance {
+	if user.AcceptTransfersFrom != "" {
+		return user.AcceptTransfersFrom
+	}
+	if p.DefaultAcceptance != "" {
+		return p.DefaultAcceptance
+	}
+	return TransferAcceptanceAnyone
+}
+
+// IsEnabled はいずれかの条件が有効かを判定
+func (p *TransferEligibilityPolicy) IsEnabled() bool {
+	return p.RequireEmailVerification || p.MinAccountAgeHours > 0
+}
+
+// Check はユーザーが now 時点で送金できるかを判定
+// アカウントが新しすぎる場合は送金できるようになる日時をParamsに含める
+func (p *TransferEligibilityPolicy) Check(user *User, now time.Time) error {
+	if p.RequireEmailVerification && !user.EmailVerified {
+		return ErrEmailVerificationRequired
+	}
+	if p.MinAccountAgeHours > 0 {
+		availableAt := user.CreatedAt.Add(time.Duration(p.MinAccountAgeHours) * time.Hour)
+		if now.Before(availableAt) {
+			return ErrAccountTooNew.WithParams(map[string]interface{}{
+				"min_account_age_hours": p.MinAccountAgeHours,
+				"available_at":          availableAt.Format(time.RFC3339),
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransferPolicySettingKey は送金額の上下限と手数料を保存するsystem_settingsのキー
+const TransferPolicySettingKey = "transfer_policy"
+
+// TransferFeeType は送金手数料の種類
+type TransferFeeType string
+
+const (
+	TransferFeeNone       TransferFeeType = "none"       // 手数料なし
+	TransferFeeFlat       TransferFeeType = "flat"       // 1回の送金ごとに定額
+	TransferFeePercentage TransferFeeType = "percentage" // 送金額に対する割合（1ポイント未満は切り上げ）
+)
+
+// TransferFeeRateMaxBasisPoints は割合の手数料の上限（10000 = 100%）
+const TransferFeeRateMaxBasisPoints = 10000
+
+// TransferPolicy はユーザー間送金の金額の上下限と手数料
+// 未設定なら上下限なし・手数料なし
+// 手数料は送金額とは別に送信者から差し引き、手数料の受け取り用アカウント（未指定ならシステムの手数料口座）へ送る（受信者は送金額をそのまま受け取る）
+type TransferPolicy struct {
+	MinAmount          int64           `json:"min_amount"`               // 1回の送金の最小額（0で無効）
+	MaxAmount          int64           `json:"max_amount"`               // 1回の送金の最大額（0で無効）
+	FeeType            TransferFeeType `json:"fee_type"`                 // 空はnoneと同じ
+	FeeFlat            int64           `json:"fee_flat"`                 // 定額の手数料（flatの場合）
+	FeeRateBasisPoints int64           `json:"fee_rate_basis_points"`    // 手数料率（percentageの場合、1 = 0.01%）
+	FeeAccountID       *uuid.UUID      `json:"fee_account_id,omitempty"` // nilならシステムの手数料口座
+	UpdatedBy          *uuid.UUID      `json:"updated_by,omitempty"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+}
+
+// Validate は設定値を検証
+func (p *TransferPolicy) Validate() error {
+	if p.MinAmount < 0 || p.MaxAmount < 0 {
+		return ErrInvalidTransferPolicy
+	}
+	if p.MaxAmount > 0 && p.MinAmount > p.MaxAmount {
+		return ErrInvalidTransferPolicy
+	}
+	switch p.FeeType {
+	case "", TransferFeeNone:
+	case TransferFeeFlat:
+		if p.FeeFlat <= 0 {
+			return ErrInvalidTransferPolicy
+		}
+	case TransferFeePercentage:
+		if p.FeeRateBasisPoints <= 0 || p.FeeRateBasisPoints > TransferFeeRateMaxBasisPoints {
+			return ErrInvalidTransferPolicy
+		}
+	default:
+		return ErrInvalidTransferPolicy
+	}
+	return nil
+}
+
+// HasFee は手数料が有効かを判定
+func (p *TransferPolicy) HasFee() bool {
+	return p.FeeType == TransferFeeFlat || p.FeeType == TransferFeePercentage
+}
+
+// CheckAmount は送金額が上下限の範囲内かを判定（範囲外なら上下限をParamsに含める）
+func (p *TransferPolicy) CheckAmount(amount int64) error {
+	if p.MinAmount > 0 && amount < p.MinAmount {
+		return ErrTransferAmountTooSmall.WithParams(map[string]interface{}{"min_amount": p.MinAmount})
+	}
+	if p.MaxAmount > 0 && amount > p.MaxAmount {
+		return ErrTransferAmountTooLarge.WithParams(map[string]interface{}{"max_amount": p.MaxAmount})
+	}
+	return nil
+}
+
+// FeeFor は送信者が送金額とは別に支払う手数料を計算
+// 手数料の受け取り用アカウントからの送金には手数料をかけない
+func (p *TransferPolicy) FeeFor(fromUserID uuid.UUID, amount int64) int64 {
+	if p.FeeAccountID != nil && *p.FeeAccountID == fromUserID {
+		return 0
+	}
+	switch p.FeeType {
+	case TransferFeeFlat:
+		return p.FeeFlat
+	case TransferFeePercentage:
+		return (amount*p.FeeRateBasisPoints + TransferFeeRateMaxBasisPoints - 1) / TransferFeeRateMaxBasisPoints
+	default:
+		return 0
+	}
+}
@@ -16,8 +16,12 @@ const (
 	TransferRequestStatusRejected  TransferRequestStatus = "rejected"  // 拒否
 	TransferRequestStatusCancelled TransferRequestStatus = "cancelled" // キャンセル
 	TransferRequestStatusExpired   TransferRequestStatus = "expired"   // 期限切れ
+	TransferRequestStatusCountered TransferRequestStatus = "countered" // 受取人が金額を変更し、送信者の確認待ち
 )
 
+// TransferRequestTTL は送金リクエスト・カウンターオファーの有効期間
+const TransferRequestTTL = 24 * time.Hour
+
 // TransferRequest は送金リクエストエンティティ
 type TransferRequest struct {
 	ID             uuid.UUID
@@ -32,6 +36,11 @@ type TransferRequest struct {
 	RejectedAt     *time.Time // 拒否日時
 	CancelledAt    *time.Time // キャンセル日時
 	TransactionID  *uuid.UUID // 承認後に作成されるTransaction ID
+	CounterAmount  *int64     // 受取人が提示した変更後の金額
+	CounteredAt    *time.Time // カウンターオファー日時
+	LastActedBy    uuid.UUID  // 最後に操作したユーザー（作成時は送信者）
+	SplitID        *uuid.UUID // 割り勘の1人分の場合の割り勘ID（送信者は参加者、受取人は割り勘の作成者）
+	Hold           bool       // 送信者の残高から金額を確保している（BalanceHold）
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
@@ -48,7 +57,7 @@ func NewTransferRequest(fromUserID, toUserID uuid.UUID, amount int64, message, i
 		return nil, errors.New("cannot send to yourself")
 	}
 	if amount <= 0 {
-		return nil, errors.New("amount must be positive")
+		return nil, ErrInvalidAmount
 	}
 	if idempotencyKey == "" {
 		return nil, errors.New("idempotency_key is required")
@@ -63,7 +72,8 @@ func NewTransferRequest(fromUserID, toUserID uuid.UUID, amount int64, message, i
 		Message:        message,
 		Status:         TransferRequestStatusPending,
 		IdempotencyKey: idempotencyKey,
-		ExpiresAt:      now.Add(24 * time.Hour), // 24時間有効
+		ExpiresAt:      now.Add(TransferRequestTTL), // 24時間有効
+		LastActedBy:    fromUserID,
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}, nil
@@ -79,13 +89,63 @@ func (tr *TransferRequest) IsPending() bool {
 	return tr.Status == TransferRequestStatusPending
 }
 
+// IsCountered はカウンターオファーが送信者の確認待ちかどうかを確認
+func (tr *TransferRequest) IsCountered() bool {
+	return tr.Status == TransferRequestStatusCountered
+}
+
+// IsOpen は承認待ちまたはカウンターオファー確認待ちかどうかを確認
+func (tr *TransferRequest) IsOpen() bool {
+	return tr.IsPending() || tr.IsCountered()
+}
+
+// HoldRequestID は送信者の残高を確保していればリクエストのIDを返す（承認時の送金で確保を使うため。確保していなければnil）
+func (tr *TransferRequest) HoldRequestID() *uuid.UUID {
+	if !tr.Hold {
+		return nil
+	}
+	id := tr.ID
+	return &id
+}
+
+// IsSplitShare は割り勘の1人分の送金リクエストかどうかを確認
+func (tr *TransferRequest) IsSplitShare() bool {
+	return tr.SplitID != nil
+}
+
+// Approver は承認・拒否できるユーザー
+// 通常は受取人、割り勘の1人分は支払う送信者（参加者）
+func (tr *TransferRequest) Approver() uuid.UUID {
+	if tr.IsSplitShare() {
+		return tr.FromUserID
+	}
+	return tr.ToUserID
+}
+
+// Canceller はキャンセルできるユーザー
+// 通常は送信者、割り勘の1人分は請求した受取人（割り勘の作成者）
+func (tr *TransferRequest) Canceller() uuid.UUID {
+	if tr.IsSplitShare() {
+		return tr.ToUserID
+	}
+	return tr.FromUserID
+}
+
+// FinalAmount は実際に送金される金額（カウンターオファーがあればその金額）
+func (tr *TransferRequest) FinalAmount() int64 {
+	if tr.CounterAmount != nil {
+		return *tr.CounterAmount
+	}
+	return tr.Amount
+}
+
 // CanApprove は承認可能かどうかを確認
 func (tr *TransferRequest) CanApprove() error {
 	if tr.Status != TransferRequestStatusPending {
-		return errors.New("request is not pending")
+		return ErrRequestNotPending
 	}
 	if tr.IsExpired() {
-		return errors.New("request has expired")
+		return ErrRequestExpired
 	}
 	return nil
 }
@@ -93,15 +153,44 @@ func (tr *TransferRequest) CanApprove() error {
 // CanReject は拒否可能かどうかを確認
 func (tr *TransferRequest) CanReject() error {
 	if tr.Status != TransferRequestStatusPending {
-		return errors.New("request is not pending")
+		return ErrRequestNotPending
 	}
 	return nil
 }
 
 // CanCancel はキャンセル可能かどうかを確認
+// カウンターオファー確認待ちのキャンセルは、送信者による変更後金額の辞退を意味する
 func (tr *TransferRequest) CanCancel() error {
+	if !tr.IsOpen() {
+		return ErrRequestNotPending
+	}
+	return nil
+}
+
+// CanCounter はカウンターオファー可能かどうかを確認
+func (tr *TransferRequest) CanCounter(amount int64) error {
+	if tr.IsSplitShare() {
+		return ErrSplitShareNotCounterable
+	}
 	if tr.Status != TransferRequestStatusPending {
-		return errors.New("request is not pending")
+		return ErrRequestNotPending
+	}
+	if tr.IsExpired() {
+		return ErrRequestExpired
+	}
+	if amount <= 0 || amount == tr.Amount {
+		return ErrInvalidCounterAmount
+	}
+	return nil
+}
+
+// CanConfirmCounter はカウンターオファーを確定可能かどうかを確認
+func (tr *TransferRequest) CanConfirmCounter() error {
+	if tr.Status != TransferRequestStatusCountered || tr.CounterAmount == nil {
+		return ErrRequestNotCountered
+	}
+	if tr.IsExpired() {
+		return ErrRequestExpired
 	}
 	return nil
 }
@@ -116,6 +205,39 @@ func (tr *TransferRequest) Approve(transactionID uuid.UUID) error {
 	tr.Status = TransferRequestStatusApproved
 	tr.ApprovedAt = &now
 	tr.TransactionID = &transactionID
+	tr.LastActedBy = tr.Approver()
+	tr.UpdatedAt = now
+	return nil
+}
+
+// Counter は受取人が金額を変更してリクエストを送信者に差し戻す
+// 有効期限はカウンターオファー時点から改めて24時間とする
+func (tr *TransferRequest) Counter(amount int64) error {
+	if err := tr.CanCounter(amount); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	tr.Status = TransferRequestStatusCountered
+	tr.CounterAmount = &amount
+	tr.CounteredAt = &now
+	tr.ExpiresAt = now.Add(TransferRequestTTL)
+	tr.LastActedBy = tr.ToUserID
+	tr.UpdatedAt = now
+	return nil
+}
+
+// ConfirmCounter は送信者がカウンターオファーを確定して承認済みにする
+func (tr *TransferRequest) ConfirmCounter(transactionID uuid.UUID) error {
+	if err := tr.CanConfirmCounter(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	tr.Status = TransferRequestStatusApproved
+	tr.ApprovedAt = &now
+	tr.TransactionID = &transactionID
+	tr.LastActedBy = tr.FromUserID
 	tr.UpdatedAt = now
 	return nil
 }
@@ -129,6 +251,7 @@ func (tr *TransferRequest) Reject() error {
 	now := time.Now()
 	tr.Status = TransferRequestStatusRejected
 	tr.RejectedAt = &now
+	tr.LastActedBy = tr.Approver()
 	tr.UpdatedAt = now
 	return nil
 }
@@ -142,13 +265,14 @@ func (tr *TransferRequest) Cancel() error {
 	now := time.Now()
 	tr.Status = TransferRequestStatusCancelled
 	tr.CancelledAt = &now
+	tr.LastActedBy = tr.Canceller()
 	tr.UpdatedAt = now
 	return nil
 }
 
 // MarkAsExpired はリクエストを期限切れにマーク
 func (tr *TransferRequest) MarkAsExpired() {
-	if tr.Status == TransferRequestStatusPending && tr.IsExpired() {
+	if tr.IsOpen() && tr.IsExpired() {
 		tr.Status = TransferRequestStatusExpired
 		tr.UpdatedAt = time.Now()
 	}
@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransferRequestQuotaSettingKey は送信者ごとの承認待ちの送金リクエストの上限を保存するsystem_settingsのキー
+const TransferRequestQuotaSettingKey = "transfer_request_quota"
+
+// 未設定の場合の承認待ちの送金リクエストの上限
+const (
+	DefaultMaxPendingRequestsPerSender    = 20 // 送信者1人あたり
+	DefaultMaxPendingRequestsPerRecipient = 5  // 同じ受取人宛
+)
+
+// TransferRequestQuota は送信者が同時に出しておける承認待ちの送金リクエストの上限
+// リクエストは（holdを指定しなければ）ポイントを確保しないため、上限がないと大量に送りつけられる
+// 有効期限を過ぎたもの（期限切れにするワーカーが動く前のものを含む）と割り勘の1人分は数えない
+type TransferRequestQuota struct {
+	MaxPendingPerSender    int        `json:"max_pending_per_sender"`    // 送信者の承認待ちの上限（0で無効）
+	MaxPendingPerRecipient int        `json:"max_pending_per_recipient"` // 同じ受取人宛の承認待ちの上限（0で無効）
+	UpdatedBy              *uuid.UUID `json:"updated_by,omitempty"`
+	UpdatedAt              time.Time  `json:"updated_at"`
+}
+
+// DefaultTransferRequestQuota は未設定の場合の上限
+func DefaultTransferRequestQuota() *TransferRequestQuota {
+	return &TransferRequestQuota{
+		MaxPendingPerSender:    DefaultMaxPendingRequestsPerSender,
+		MaxPendingPerRecipient: DefaultMaxPendingRequestsPerRecipient,
+	}
+}
+
+// Validate は上限の値を検証（受取人ごとの上限は全体の上限を超えられない）
+func (q *TransferRequestQuota) Validate() error {
+	if q.MaxPendingPerSender < 0 || q.MaxPendingPerRecipient < 0 {
+		return ErrInvalidRequestQuota
+	}
+	if q.MaxPendingPerSender > 0 && q.MaxPendingPerRecipient > q.MaxPendingPerSender {
+		return ErrInvalidRequestQuota
+	}
+	return nil
+}
+
+// IsEnabled はいずれかの上限が有効かを判定
+func (q *TransferRequestQuota) IsEnabled() bool {
+	return q.MaxPendingPerSender > 0 || q.MaxPendingPerRecipient > 0
+}
+
+// Check は承認待ちの件数（全体・受取人宛）にもう1件加えられるかを判定（上限に達していれば上限をParamsに含める）
+func (q *TransferRequestQuota) Check(pending, pendingToRecipient int64) error {
+	if q.MaxPendingPerSender > 0 && pending >= int64(q.MaxPendingPerSender) {
+		return ErrPendingRequestLimit.WithParams(map[string]interface{}{"max": q.MaxPendingPerSender})
+	}
+	if q.MaxPendingPerRecipient > 0 && pendingToRecipient >= int64(q.MaxPendingPerRecipient) {
+		return ErrRecipientRequestLimit.WithParams(map[string]interface{}{"max": q.MaxPendingPerRecipient})
+	}
+	return nil
+}
@@ -13,6 +13,9 @@ type UserRole string
 const (
 	RoleUser  UserRole = "user"
 	RoleAdmin UserRole = "admin"
+	// RoleSuperAdmin はテナントを管理するスーパー管理者（既定のテナントのユーザーだけが有効）
+	// 所属テナントでは管理者として扱う。APIでは付与できず、DBで直接設定する
+	RoleSuperAdmin UserRole = "super_admin"
 )
 
 // AvatarType はアバタータイプを表す型
@@ -23,9 +26,22 @@ const (
 	AvatarTypeUploaded  AvatarType = "uploaded"  // ユーザーアップロード
 )
 
+// UserStatus はユーザーの利用状態を表す型
+// 退会（アーカイブ）したユーザーはusersから削除されるため、ここには含めない
+type UserStatus string
+
+const (
+	UserStatusActive      UserStatus = "active"
+	UserStatusDeactivated UserStatus = "deactivated" // 本人が一時的に休止（期間内にログインすれば再開）
+)
+
+// UserReactivationWindow は休止したアカウントをログインで再開できる期間
+const UserReactivationWindow = 30 * 24 * time.Hour
+
 // User はユーザーエンティティ
 type User struct {
 	ID              uuid.UUID
+	TenantID        uuid.UUID // 所属テナント（作成時にゼロ値ならリクエストのテナント）
 	Username        string
 	Email           string
 	PasswordHash    string
@@ -33,16 +49,27 @@ type User struct {
 	FirstName       string // 名前（プロフィール表示用）
 	LastName        string // 苗字（プロフィール表示用）
 	Balance         int64  // ポイント残高
+	ReservedBalance int64  // 残高のうち確保中の送金リクエストの分（使えるのはBalance - ReservedBalance）
 	Role            UserRole
 	Version         int // 楽観的ロック用
 	IsActive        bool
+	Status          UserStatus // 利用状態（空ならactive。休止中はIsActiveもfalse）
+	DeactivatedAt   *time.Time // 本人がアカウントを休止した日時
 	AvatarURL       *string    // アバター画像URL
 	AvatarType      AvatarType // アバタータイプ
-	PersonalQRCode  string     // 個人固定QRコード（user:{user_id}形式）
+	PersonalQRCode  string     // 個人固定QRコード（user:{user_id}形式。QRコードには署名付きのQRPayloadを埋め込む）
 	EmailVerified   bool       // メール認証済みか
 	EmailVerifiedAt *time.Time // メール認証日時
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	Discoverable    bool       // 連絡先ハッシュによる友達検索でヒットさせるか
+	Tier            UserTier   // 会員ランク（夜間バッチで再計算）
+	TierOverridden  bool       // 管理者がランクを固定しているか（固定中は再計算しない）
+	TierUpdatedAt   *time.Time // ランクが最後に変わった日時
+	DepartmentID    *uuid.UUID // 所属部署（未所属ならnil）
+	Timezone        string     // ボーナス日の区切りに使うタイムゾーン（空ならシステム設定に従う）
+	// AcceptTransfersFrom は誰からのポイントを受け取るか（空ならシステム設定の既定に従う）
+	AcceptTransfersFrom TransferAcceptance
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
 }
 
 // NewUser は新しいユーザーを作成
@@ -79,10 +106,13 @@ func NewUser(username, email, passwordHash, displayName, firstName, lastName str
 		Role:           RoleUser,
 		Version:        1,
 		IsActive:       true,
+		Status:         UserStatusActive,
 		AvatarURL:      nil,
 		AvatarType:     AvatarTypeGenerated,
 		PersonalQRCode: GeneratePersonalQRCode(userID), // 個人QRコード生成
 		EmailVerified:  false,                          // 初期は未認証
+		Discoverable:   true,
+		Tier:           TierBronze,
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 	}, nil
@@ -93,21 +123,39 @@ func GeneratePersonalQRCode(userID uuid.UUID) string {
 	return "user:" + userID.String()
 }
 
+// CurrentTier は会員ランクを返す（未計算ならbronze）
+func (u *User) CurrentTier() UserTier {
+	if u.Tier == "" {
+		return TierBronze
+	}
+	return u.Tier
+}
+
 // IsAdmin はユーザーが管理者かどうかを確認
 func (u *User) IsAdmin() bool {
-	return u.Role == RoleAdmin
+	return u.Role == RoleAdmin || u.Role == RoleSuperAdmin
+}
+
+// IsSuperAdmin はユーザーがテナントを管理できるスーパー管理者かどうかを確認
+func (u *User) IsSuperAdmin() bool {
+	return u.Role == RoleSuperAdmin && u.TenantID == DefaultTenantID
 }
 
-// CanTransfer は送金可能かどうかを確認
+// AvailableBalance は使える残高（確保中の送金リクエストの分を除く）
+func (u *User) AvailableBalance() int64 {
+	return u.Balance - u.ReservedBalance
+}
+
+// CanTransfer は送金可能かどうかを確認（確保中の分は使えない）
 func (u *User) CanTransfer(amount int64) error {
 	if !u.IsActive {
-		return errors.New("user is not active")
+		return ErrUserInactive
 	}
-	if u.Balance < amount {
-		return errors.New("insufficient balance")
+	if u.AvailableBalance() < amount {
+		return ErrInsufficientBalance.WithParams(map[string]interface{}{"balance": u.AvailableBalance(), "required": amount})
 	}
 	if amount <= 0 {
-		return errors.New("amount must be positive")
+		return ErrInvalidAmount
 	}
 	return nil
 }
@@ -125,7 +173,7 @@ func (u *User) Deduct(amount int64) error {
 // Add はポイントを加算
 func (u *User) Add(amount int64) error {
 	if amount <= 0 {
-		return errors.New("amount must be positive")
+		return ErrInvalidAmount
 	}
 	u.Balance += amount
 	u.UpdatedAt = time.Now()
@@ -133,10 +181,14 @@ func (u *User) Add(amount int64) error {
 }
 
 // UpdateRole はユーザーの役割を更新（管理者操作）
+// スーパー管理者はAPIから付与・変更できない（データベースで直接設定する）
 func (u *User) UpdateRole(newRole UserRole) error {
 	if newRole != RoleUser && newRole != RoleAdmin {
 		return errors.New("invalid role")
 	}
+	if u.Role == RoleSuperAdmin {
+		return errors.New("super admin role cannot be changed")
+	}
 	u.Role = newRole
 	u.UpdatedAt = time.Now()
 	return nil
@@ -154,6 +206,58 @@ func (u *User) Activate() {
 	u.UpdatedAt = time.Now()
 }
 
+// CurrentStatus は利用状態を返す（未設定ならactive）
+func (u *User) CurrentStatus() UserStatus {
+	if u.Status == "" {
+		return UserStatusActive
+	}
+	return u.Status
+}
+
+// IsDeactivated は本人がアカウントを休止しているかを確認
+func (u *User) IsDeactivated() bool {
+	return u.CurrentStatus() == UserStatusDeactivated
+}
+
+// DeactivateSelf は本人がアカウントを一時的に休止（検索に出ず、取引できない）
+// 管理者が無効化したアカウントは本人の操作で休止・再開できない
+func (u *User) DeactivateSelf(now time.Time) error {
+	if u.IsDeactivated() {
+		return ErrAccountDeactivated
+	}
+	if !u.IsActive {
+		return ErrUserInactive
+	}
+	u.Status = UserStatusDeactivated
+	u.IsActive = false
+	u.DeactivatedAt = &now
+	u.UpdatedAt = now
+	return nil
+}
+
+// ReactivatableUntil は休止したアカウントをログインで再開できる期限
+func (u *User) ReactivatableUntil() time.Time {
+	if u.DeactivatedAt == nil {
+		return time.Time{}
+	}
+	return u.DeactivatedAt.Add(UserReactivationWindow)
+}
+
+// Reactivate は休止したアカウントを再開（休止から一定期間内のみ）
+func (u *User) Reactivate(now time.Time) error {
+	if !u.IsDeactivated() {
+		return ErrUserInactive
+	}
+	if u.DeactivatedAt != nil && now.After(u.ReactivatableUntil()) {
+		return ErrReactivationExpired.WithParams(map[string]interface{}{"days": int(UserReactivationWindow.Hours() / 24)})
+	}
+	u.Status = UserStatusActive
+	u.IsActive = true
+	u.DeactivatedAt = nil
+	u.UpdatedAt = now
+	return nil
+}
+
 // UpdateProfile はプロフィール更新
 func (u *User) UpdateProfile(displayName, email, firstName, lastName string) error {
 	changed := false
@@ -213,6 +317,11 @@ func (u *User) UpdateAvatar(avatarURL string, avatarType AvatarType) error {
 	return nil
 }
 
+// ProfileCompleted は表示名・氏名・アップロードしたアバター画像がそろっているか
+func (u *User) ProfileCompleted() bool {
+	return u.DisplayName != "" && u.FirstName != "" && u.LastName != "" && u.AvatarType == AvatarTypeUploaded
+}
+
 // DeleteAvatar はアバター削除（自動生成に戻す）
 func (u *User) DeleteAvatar() {
 	u.AvatarURL = nil
@@ -238,3 +347,31 @@ func (u *User) UpdatePassword(newPasswordHash string) error {
 	u.UpdatedAt = time.Now()
 	return nil
 }
+
+// SetDiscoverable は連絡先ハッシュによる友達検索の公開設定を変更
+func (u *User) SetDiscoverable(discoverable bool) {
+	u.Discoverable = discoverable
+	u.UpdatedAt = time.Now()
+}
+
+// SetTimezone はボーナス日の区切りに使うタイムゾーンを変更（空ならシステム設定に従う）
+func (u *User) SetTimezone(timezone string) error {
+	if timezone != "" {
+		if _, err := LoadBonusLocation(timezone); err != nil {
+			return err
+		}
+	}
+	u.Timezone = timezone
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetAcceptTransfersFrom は誰からのポイントを受け取るかを変更（空ならシステム設定の既定に従う）
+func (u *User) SetAcceptTransfersFrom(acceptance TransferAcceptance) error {
+	if err := acceptance.Validate(); err != nil {
+		return err
+	}
+	u.AcceptTransfersFrom = acceptance
+	u.UpdatedAt = time.Now()
+	return nil
+}
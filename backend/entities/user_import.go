@@ -0,0 +1,160 @@
+package entities
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/mail"
+	"strconv"
+	"strings"
+)
+
+const (
+	// UserImportMaxBytes はユーザー一括登録で受け付けるCSVの最大サイズ
+	UserImportMaxBytes = 5 << 20
+	// UserImportMaxRows は1回の一括登録で受け付ける最大行数（ヘッダーを除く）
+	UserImportMaxRows = 5000
+	// UserImportChunkSize は1トランザクションで登録する行数
+	// 途中の行で失敗しても、それまでのまとまりは登録済みのまま残る
+	UserImportChunkSize = 200
+	// userImportTemporaryPasswordBytes は仮パスワードの元になる乱数のバイト数（base64で16文字）
+	userImportTemporaryPasswordBytes = 12
+)
+
+// UserImportPointsTag は一括登録の初期ポイント付与の取引に付けるタグ
+const UserImportPointsTag = "user_import"
+
+// userImportColumns はCSVヘッダーに必要な列名（順不同、余分な列は無視する）
+var userImportColumns = []string{"username", "email", "name", "initial_points", "role"}
+
+// UserImportRow はユーザー一括登録CSVの1行
+type UserImportRow struct {
+	Line          int // ファイル上の行番号（ヘッダーが1行目）
+	Username      string
+	Email         string
+	Name          string
+	InitialPoints int64
+	Role          UserRole
+	Err           error // 行の形式エラー（nilなら登録対象）
+}
+
+// ParseUserImportCSV はユーザー一括登録のCSVを読み込む
+// ヘッダー不備や行数超過はファイル全体のエラー、各行の形式エラーは行ごとにErrへ入れて返す
+func ParseUserImportCSV(r io.Reader) ([]*UserImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, ErrInvalidUserImportFile
+	}
+	index := make(map[string]int, len(header))
+	for i, col := range header {
+		if i == 0 {
+			col = strings.TrimPrefix(col, "\ufeff") // Excelが付けるBOM
+		}
+		index[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+	for _, col := range userImportColumns {
+		if _, ok := index[col]; !ok {
+			return nil, ErrInvalidUserImportFile
+		}
+	}
+
+	var rows []*UserImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidUserImportFile
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		if len(rows) >= UserImportMaxRows {
+			return nil, ErrInvalidUserImportFile
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			if i := index[name]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		row := &UserImportRow{
+			Line:     line,
+			Username: field("username"),
+			Email:    field("email"),
+			Name:     field("name"),
+		}
+		row.Err = row.parse(field("initial_points"), field("role"))
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parse は初期ポイント・役割を読み取り、行の形式を検証する
+func (r *UserImportRow) parse(initialPoints, role string) error {
+	if n := len([]rune(r.Username)); n < 3 || n > 50 {
+		return ErrInvalidUserImportRow
+	}
+	if addr, err := mail.ParseAddress(r.Email); err != nil || addr.Address != r.Email {
+		return ErrInvalidUserImportRow
+	}
+	if r.Name == "" || len([]rune(r.Name)) > 100 {
+		return ErrInvalidUserImportRow
+	}
+
+	if initialPoints != "" {
+		points, err := strconv.ParseInt(initialPoints, 10, 64)
+		if err != nil || points < 0 {
+			return ErrInvalidUserImportRow
+		}
+		r.InitialPoints = points
+	}
+
+	switch UserRole(strings.ToLower(role)) {
+	case "", RoleUser:
+		r.Role = RoleUser
+	case RoleAdmin:
+		r.Role = RoleAdmin
+	default:
+		return ErrInvalidUserImportRow
+	}
+	return nil
+}
+
+// NewUser は行の内容からユーザーを作成する
+// 名前は表示名にそのまま使い、空白で区切られていれば先頭を姓、残りを名とする
+func (r *UserImportRow) NewUser(passwordHash string) (*User, error) {
+	lastName, firstName := r.Name, r.Name
+	if fields := strings.Fields(r.Name); len(fields) > 1 {
+		lastName = fields[0]
+		firstName = strings.Join(fields[1:], " ")
+	}
+
+	user, err := NewUser(r.Username, r.Email, passwordHash, r.Name, firstName, lastName)
+	if err != nil {
+		return nil, err
+	}
+	user.Role = r.Role
+	return user, nil
+}
+
+// GenerateTemporaryPassword は一括登録したユーザーに渡す仮パスワードを生成
+func GenerateTemporaryPassword() (string, error) {
+	return GenerateSecureTokenBase64(userImportTemporaryPasswordBytes)
+}
+
+func isBlankRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
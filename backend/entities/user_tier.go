@@ -0,0 +1,131 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserTier は利用状況に応じた会員ランク
+type UserTier string
+
+const (
+	TierBronze UserTier = "bronze"
+	TierSilver UserTier = "silver"
+	TierGold   UserTier = "gold"
+)
+
+// TierEarningWindowDays はランク判定で獲得ポイントを数える期間（日）
+const TierEarningWindowDays = 90
+
+// TierRequirement はランクに上がるための条件（どちらか一方を満たせばよい）
+type TierRequirement struct {
+	EarnedPoints  int64 // 直近TierEarningWindowDays日に獲得したポイント（送金の受け取りは含まない）
+	CheckInStreak int   // 現在の連続チェックイン日数
+}
+
+// tierRequirements は上位ランクから順に並べた昇格条件
+var tierRequirements = []struct {
+	tier        UserTier
+	requirement TierRequirement
+}{
+	{TierGold, TierRequirement{EarnedPoints: 5000, CheckInStreak: 20}},
+	{TierSilver, TierRequirement{EarnedPoints: 1000, CheckInStreak: 5}},
+}
+
+// tierLotteryBoosts はランクごとの抽選の当選確率の倍率
+var tierLotteryBoosts = map[UserTier]float64{
+	TierBronze: 1.0,
+	TierSilver: 1.1,
+	TierGold:   1.25,
+}
+
+// IsValid は定義済みのランクかを判定
+func (t UserTier) IsValid() bool {
+	_, ok := tierLotteryBoosts[t]
+	return ok
+}
+
+// Rank はランクの序列（bronze=0、未設定は-1）
+func (t UserTier) Rank() int {
+	switch t {
+	case TierBronze:
+		return 0
+	case TierSilver:
+		return 1
+	case TierGold:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// AtLeast は指定したランク以上かを判定
+func (t UserTier) AtLeast(min UserTier) bool {
+	return t.Rank() >= min.Rank()
+}
+
+// LotteryBoost は抽選の当選確率に掛ける倍率
+func (t UserTier) LotteryBoost() float64 {
+	if boost, ok := tierLotteryBoosts[t]; ok {
+		return boost
+	}
+	return 1.0
+}
+
+// Requirement はランクの昇格条件を返す（bronzeは条件なし）
+func (t UserTier) Requirement() (TierRequirement, bool) {
+	for _, r := range tierRequirements {
+		if r.tier == t {
+			return r.requirement, true
+		}
+	}
+	return TierRequirement{}, false
+}
+
+// TierActivity はランク判定に使うユーザーの利用状況
+type TierActivity struct {
+	UserID        uuid.UUID
+	EarnedPoints  int64
+	CheckInStreak int
+}
+
+// ComputeTier は利用状況から満たしている最も高いランクを返す
+func ComputeTier(a *TierActivity) UserTier {
+	for _, r := range tierRequirements {
+		if a.EarnedPoints >= r.requirement.EarnedPoints || a.CheckInStreak >= r.requirement.CheckInStreak {
+			return r.tier
+		}
+	}
+	return TierBronze
+}
+
+// TierEarningSince はランク判定で獲得ポイントを数え始める日時
+func TierEarningSince(now time.Time) time.Time {
+	return now.AddDate(0, 0, -TierEarningWindowDays)
+}
+
+// BoostLotteryTiers はポイントが当たるティアの確率に倍率を掛けたコピーを返す
+// 合計が100%を超える場合は100%に収まるよう比例して縮める（ハズレの確率が0になる）
+func BoostLotteryTiers(tiers []*LotteryTier, boost float64) []*LotteryTier {
+	if boost <= 1.0 {
+		return tiers
+	}
+
+	boosted := make([]*LotteryTier, len(tiers))
+	total := 0.0
+	for i, t := range tiers {
+		copied := *t
+		if copied.Points > 0 {
+			copied.Probability *= boost
+		}
+		total += copied.Probability
+		boosted[i] = &copied
+	}
+	if total > 100.0 {
+		for _, t := range boosted {
+			t.Probability = t.Probability * 100.0 / total
+		}
+	}
+	return boosted
+}
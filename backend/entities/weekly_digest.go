@@ -0,0 +1,114 @@
+package entities
+
+import (
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// WeeklyDigestPeriod は週次のまとめメールで集計する期間（送信時刻までの直近1週間）
+	WeeklyDigestPeriod = 7 * 24 * time.Hour
+	// WeeklyDigestBatchSize は週次のまとめメールの送信対象を一度に読み出す人数
+	WeeklyDigestBatchSize = 200
+)
+
+// WeeklyDigestActivity はユーザーの期間中のポイントの増減（確定済みの取引のみ）
+type WeeklyDigestActivity struct {
+	UserID uuid.UUID
+	Earned int64 // 受け取った・付与されたポイント
+	Spent  int64 // 送った・交換に使ったポイント（失効は含まない）
+}
+
+// WeeklyDigest はユーザーへ送る週次のまとめ
+type WeeklyDigest struct {
+	UserID          uuid.UUID
+	Email           string
+	DisplayName     string
+	PeriodStart     time.Time
+	PeriodEnd       time.Time
+	Earned          int64
+	Spent           int64
+	Balance         int64
+	Expirations     []*PointBatch // 1か月以内に失効する残量のあるバッチ（期限が近い順）
+	PendingRequests int64         // 承認待ちの送金リクエスト（自分が支払う割り勘の1人分を含む）
+}
+
+// NewWeeklyDigest はnowまでの1週間のまとめを作成（activityがnilなら増減なし）
+func NewWeeklyDigest(user *User, activity *WeeklyDigestActivity, expirations []*PointBatch, pendingRequests int64, now time.Time) *WeeklyDigest {
+	digest := &WeeklyDigest{
+		UserID:          user.ID,
+		Email:           user.Email,
+		DisplayName:     user.DisplayName,
+		PeriodStart:     now.Add(-WeeklyDigestPeriod),
+		PeriodEnd:       now,
+		Balance:         user.Balance,
+		Expirations:     expirations,
+		PendingRequests: pendingRequests,
+	}
+	if activity != nil {
+		digest.Earned = activity.Earned
+		digest.Spent = activity.Spent
+	}
+	return digest
+}
+
+// ExpiringAmount は1か月以内に失効するポイントの合計
+func (d *WeeklyDigest) ExpiringAmount() int64 {
+	var total int64
+	for _, b := range d.Expirations {
+		total += b.RemainingAmount
+	}
+	return total
+}
+
+// IsEmpty は知らせる内容がないかを判定（残高も増減も期限も承認待ちもなければ送らない）
+func (d *WeeklyDigest) IsEmpty() bool {
+	return d.Earned == 0 && d.Spent == 0 && d.Balance == 0 && len(d.Expirations) == 0 && d.PendingRequests == 0
+}
+
+// WeeklyDigestEmail は週次のまとめメールの件名と本文
+type WeeklyDigestEmail struct {
+	Subject string
+	Body    string
+}
+
+var weeklyDigestTemplates = template.Must(template.New("weekly_digest").
+	Funcs(template.FuncMap{
+		"date": func(t time.Time) string { return t.In(scheduleLocation).Format("1月2日") },
+	}).
+	Parse(`{{define "subject"}}今週のポイントのまとめ（{{date .PeriodEnd}}）{{end}}` +
+		`{{define "body"}}{{.DisplayName}}さん
+
+{{date .PeriodStart}}〜{{date .PeriodEnd}}のポイントのまとめです。
+
+獲得したポイント: {{.Earned}}ポイント
+使ったポイント: {{.Spent}}ポイント
+現在の残高: {{.Balance}}ポイント
+{{- if .Expirations}}
+
+1か月以内に有効期限を迎えるポイント（合計{{.ExpiringAmount}}ポイント）:
+{{- range .Expirations}}
+・{{date .ExpiresAt}}に{{.RemainingAmount}}ポイント
+{{- end}}
+{{- end}}
+{{- if .PendingRequests}}
+
+承認待ちの送金リクエストが{{.PendingRequests}}件あります。
+{{- end}}
+
+このメールは通知設定の「週次のまとめ」をオフにすると停止できます。{{end}}`))
+
+// Render はまとめをメールの件名と本文にする
+func (d *WeeklyDigest) Render() (*WeeklyDigestEmail, error) {
+	var subject, body strings.Builder
+	if err := weeklyDigestTemplates.ExecuteTemplate(&subject, "subject", d); err != nil {
+		return nil, err
+	}
+	if err := weeklyDigestTemplates.ExecuteTemplate(&body, "body", d); err != nil {
+		return nil, err
+	}
+	return &WeeklyDigestEmail{Subject: subject.String(), Body: body.String()}, nil
+}
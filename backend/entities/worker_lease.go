@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+const (
+	// WorkerLeaseTTL はリーダーの期限。更新が途絶えたインスタンスのリーダーはこの時間で他のインスタンスに移る
+	WorkerLeaseTTL = 30 * time.Second
+	// WorkerLeaseRenewInterval はリーダーの更新（立候補）の間隔
+	WorkerLeaseRenewInterval = 10 * time.Second
+)
+
+// WorkerLease は1つのワーカーを実行するインスタンス（リーダー）の記録
+// 複数インスタンスで動かす場合も、各ワーカーはリーダーのインスタンスだけが処理する
+type WorkerLease struct {
+	Name          string
+	Holder        string // リーダーのインスタンス（ホスト名:PID）
+	AcquiredAt    time.Time
+	RenewedAt     time.Time
+	ExpiresAt     time.Time
+	LeaderChanges int64 // リーダーが別のインスタンスに移った回数（最初の取得を含む）
+}
+
+// IsHeld はリーダーの期限内かを判定
+func (l *WorkerLease) IsHeld(now time.Time) bool {
+	return now.Before(l.ExpiresAt)
+}
@@ -0,0 +1,234 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/frameworks/web/openapi"
+	"github.com/google/uuid"
+)
+
+// maskedValue はパスワード・トークンなどの値の代わりに記録する文字列
+const maskedValue = "********"
+
+// AccessLogConfig はアクセスログの設定
+// ルートはバージョンなしのパス（例: /api/points/transfer）で指定し、すべてのバージョンに適用する
+type AccessLogConfig struct {
+	Enabled bool
+
+	// BodyRoutes はリクエスト・レスポンスのボディも記録するルート（パスワード・トークン・メールアドレスは伏せる）
+	BodyRoutes []string
+	// MaxBodyBytes は記録するボディの最大バイト数（超えた分は切り詰める）
+	MaxBodyBytes int
+	// SampleRates はルートごとの記録する割合（0〜1、未指定のルートはすべて記録）
+	// 4xx・5xxの応答は割合に関係なく記録する
+	SampleRates map[string]float64
+}
+
+// AccessLogMiddleware はリクエストごとにメソッド・パス・ステータス・処理時間・ユーザーIDを構造化ログに記録するミドルウェア
+type AccessLogMiddleware struct {
+	logger      entities.Logger
+	enabled     bool
+	bodyRoutes  map[string]bool
+	maxBody     int
+	sampleRates map[string]float64
+	random      func() float64
+}
+
+// NewAccessLogMiddleware は新しいAccessLogMiddlewareを作成
+func NewAccessLogMiddleware(logger entities.Logger, cfg AccessLogConfig) *AccessLogMiddleware {
+	bodyRoutes := make(map[string]bool, len(cfg.BodyRoutes))
+	for _, route := range cfg.BodyRoutes {
+		bodyRoutes[route] = true
+	}
+	return &AccessLogMiddleware{
+		logger:      logger,
+		enabled:     cfg.Enabled,
+		bodyRoutes:  bodyRoutes,
+		maxBody:     cfg.MaxBodyBytes,
+		sampleRates: cfg.SampleRates,
+		random:      rand.Float64,
+	}
+}
+
+// Handle はエンジン全体に登録する（ユーザーIDは後続の認証ミドルウェアが設定したものを読む）
+func (m *AccessLogMiddleware) Handle() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !m.enabled {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		route := openapi.CanonicalPath(c.FullPath())
+		logBody := route != "" && m.bodyRoutes[route]
+
+		var requestBody []byte
+		var recorder *bodyRecorder
+		if logBody {
+			requestBody = m.readRequestBody(c)
+			recorder = &bodyRecorder{ResponseWriter: c.Writer}
+			c.Writer = recorder
+		}
+
+		c.Next()
+
+		status := c.Writer.Status()
+		if status < 400 && !m.sampled(route) {
+			return
+		}
+
+		fields := []entities.Field{
+			entities.NewField("method", c.Request.Method),
+			entities.NewField("path", c.Request.URL.Path),
+			entities.NewField("route", c.FullPath()),
+			entities.NewField("status", status),
+			entities.NewField("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			entities.NewField("client_ip", c.ClientIP()),
+		}
+		if userID, exists := c.Get("user_id"); exists {
+			if id, ok := userID.(uuid.UUID); ok {
+				fields = append(fields, entities.NewField("user_id", id.String()))
+			}
+		}
+		if logBody {
+			if requestBody != nil {
+				fields = append(fields, entities.NewField("request_body", m.maskBody(requestBody)))
+			}
+			fields = append(fields, entities.NewField("response_body", m.maskBody(recorder.body.Bytes())))
+		}
+
+		switch {
+		case status >= 500:
+			m.logger.Error("http_request", fields...)
+		case status >= 400:
+			m.logger.Warn("http_request", fields...)
+		default:
+			m.logger.Info("http_request", fields...)
+		}
+	}
+}
+
+// sampled はルートのリクエストを記録するかを割合に従って決める
+func (m *AccessLogMiddleware) sampled(route string) bool {
+	rate, ok := m.sampleRates[route]
+	if !ok || rate >= 1 {
+		return true
+	}
+	return rate > 0 && m.random() < rate
+}
+
+// readRequestBody はJSONのリクエストボディを読み、コントローラーで再度読めるように戻す
+// JSON以外（アップロードなど）は読まずにnilを返す
+func (m *AccessLogMiddleware) readRequestBody(c *gin.Context) []byte {
+	if c.Request.Body == nil || !strings.Contains(c.GetHeader("Content-Type"), "application/json") {
+		return nil
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	c.Request.Body.Close()
+	c.Request.Body = io.NopCloser(bytes.NewBuffer(body))
+	if err != nil {
+		return nil
+	}
+	return body
+}
+
+// maskBody はボディの秘密の値を伏せ、ログに載せる形にする
+// JSONとして読めないボディは内容を記録せず、サイズだけを残す
+func (m *AccessLogMiddleware) maskBody(body []byte) interface{} {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Sprintf("<non-JSON body, %d bytes>", len(body))
+	}
+	masked, err := json.Marshal(MaskSensitive(value))
+	if err != nil {
+		return fmt.Sprintf("<unencodable body, %d bytes>", len(body))
+	}
+	if m.maxBody > 0 && len(masked) > m.maxBody {
+		// 切り詰めたJSONは壊れているため文字列として残す
+		return string(masked[:m.maxBody]) + "...(truncated)"
+	}
+	return json.RawMessage(masked)
+}
+
+// sensitiveKeys は値を伏せるキー（小文字にして _ と - を除いたものに含まれていれば対象）
+var sensitiveKeys = []string{"password", "passwd", "token", "secret", "apikey", "authorization", "credential"}
+
+// emailPattern はメールアドレスらしい文字列
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// MaskSensitive はJSONの値からパスワード・トークンを伏せ、メールアドレスを一部だけ残して隠す
+// キー名で判定できない値（自由入力のメッセージなど）に含まれるメールアドレスも隠す
+func MaskSensitive(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			if isSensitiveKey(key) && child != nil {
+				masked[key] = maskedValue
+				continue
+			}
+			masked[key] = MaskSensitive(child)
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(v))
+		for i, child := range v {
+			masked[i] = MaskSensitive(child)
+		}
+		return masked
+	case string:
+		return emailPattern.ReplaceAllStringFunc(v, maskEmail)
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	if normalized == "key" {
+		return true // キオスク端末のAPIキーなど
+	}
+	for _, s := range sensitiveKeys {
+		if strings.Contains(normalized, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskEmail はメールアドレスのローカル部を先頭1文字だけ残して隠す（例: t***@example.com）
+func maskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return maskedValue
+	}
+	return email[:1] + "***" + email[at:]
+}
+
+// bodyRecorder はレスポンスボディを記録するためにResponseWriterを包む
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIDeprecation は廃止予定バージョンで返すヘッダーの情報
+type APIDeprecation struct {
+	Sunset    time.Time // 提供終了日時（ゼロ値ならSunsetヘッダーを付けない）
+	Successor string    // 後継バージョンのURLプレフィックス（/api/v2）
+}
+
+// APIVersionMiddleware はリクエストのAPIバージョンをコンテキストにセットし、
+// 廃止予定のバージョンではDeprecation / Sunset / Linkヘッダーを付与する
+func APIVersionMiddleware(version string, deprecation *APIDeprecation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("api_version", version)
+		c.Header("X-API-Version", version)
+
+		if deprecation != nil {
+			c.Header("Deprecation", "true")
+			if !deprecation.Sunset.IsZero() {
+				c.Header("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if deprecation.Successor != "" {
+				c.Header("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", deprecation.Successor))
+			}
+		}
+
+		c.Next()
+	}
+}
@@ -1,20 +1,25 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 )
 
 // AuthMiddleware は認証ミドルウェア
 type AuthMiddleware struct {
-	authUC inputport.AuthInputPort
+	authUC    inputport.AuthInputPort
+	bindingUC inputport.SessionBindingInputPort
+	cookie    *presenter.SessionCookie
 }
 
 // NewAuthMiddleware は新しいAuthMiddlewareを作成
-func NewAuthMiddleware(authUC inputport.AuthInputPort) *AuthMiddleware {
-	return &AuthMiddleware{authUC: authUC}
+func NewAuthMiddleware(authUC inputport.AuthInputPort, bindingUC inputport.SessionBindingInputPort, cookie *presenter.SessionCookie) *AuthMiddleware {
+	return &AuthMiddleware{authUC: authUC, bindingUC: bindingUC, cookie: cookie}
 }
 
 // Authenticate は認証を行う
@@ -24,20 +29,35 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 		sessionToken := c.GetHeader("Authorization")
 		if sessionToken == "" {
 			// Cookieからも取得を試みる
-			sessionToken, _ = c.Cookie("session_token")
+			sessionToken, _ = c.Cookie(presenter.SessionCookieName)
 		}
 
 		if sessionToken == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
-			c.Abort()
+			presenter.RenderError(c, http.StatusUnauthorized, entities.ErrUnauthorized)
 			return
 		}
 
 		// セッション検証
 		session, err := m.authUC.ValidateSession(c.Request.Context(), sessionToken)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid session"})
-			c.Abort()
+			// 失効・期限切れのトークンを送り続けないようCookieもクリア
+			m.cookie.Clear(c)
+			if errors.Is(err, entities.ErrAccountDeactivated) {
+				presenter.RenderError(c, http.StatusForbidden, err)
+				return
+			}
+			presenter.RenderError(c, http.StatusUnauthorized, entities.ErrSessionExpired)
+			return
+		}
+
+		// ログインしたときと異なる端末・接続元からの利用（SESSION_BINDINGの設定による）
+		if err := m.bindingUC.VerifyBinding(c.Request.Context(), &inputport.VerifySessionBindingRequest{
+			Session:   session,
+			IPAddress: c.ClientIP(),
+			UserAgent: c.GetHeader("User-Agent"),
+		}); err != nil {
+			m.cookie.Clear(c)
+			presenter.RenderError(c, http.StatusUnauthorized, err)
 			return
 		}
 
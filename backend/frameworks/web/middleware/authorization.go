@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// ResourceAuthorizationMiddleware はパスの:idが指すユーザーのリソースへの操作を、ハンドラーの前にまとめて確認するミドルウェア
+// 関わりのないユーザーには404（存在を明かさない）、関わっているが許されない操作には403を返す
+type ResourceAuthorizationMiddleware struct {
+	authorizationUC inputport.ResourceAuthorizationInputPort
+}
+
+// NewResourceAuthorizationMiddleware は新しいResourceAuthorizationMiddlewareを作成
+func NewResourceAuthorizationMiddleware(authorizationUC inputport.ResourceAuthorizationInputPort) *ResourceAuthorizationMiddleware {
+	return &ResourceAuthorizationMiddleware{authorizationUC: authorizationUC}
+}
+
+// Authorize はセッションのユーザーが:idのリソースにactionを行えるかを確認する（認証ミドルウェアの後に、ルートごとに登録する）
+func (m *ResourceAuthorizationMiddleware) Authorize(resource entities.ResourceType, action entities.Action) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := c.Get("user_id")
+		id, isUUID := userID.(uuid.UUID)
+		if !ok || !isUUID {
+			presenter.RenderError(c, http.StatusUnauthorized, entities.ErrUnauthorized)
+			return
+		}
+
+		resourceID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			presenter.RenderError(c, http.StatusBadRequest,
+				entities.NewValidationError(entities.FieldError{Field: "id", Message: "must be a valid ID"}))
+			return
+		}
+
+		if err := m.authorizationUC.Authorize(c.Request.Context(), id, resource, resourceID, action); err != nil {
+			presenter.RenderError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireAdmin はセッションのユーザーが管理者かを確認する（管理APIのグループに、認証ミドルウェアの後に登録する）
+// 管理者でなければ403を返す
+func (m *ResourceAuthorizationMiddleware) RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := c.Get("user_id")
+		id, isUUID := userID.(uuid.UUID)
+		if !ok || !isUUID {
+			presenter.RenderError(c, http.StatusUnauthorized, entities.ErrUnauthorized)
+			return
+		}
+
+		if err := m.authorizationUC.AuthorizeAdmin(c.Request.Context(), id); err != nil {
+			presenter.RenderError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		c.Next()
+	}
+}
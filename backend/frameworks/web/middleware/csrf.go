@@ -1,18 +1,28 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
 	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
 )
 
+// CSRFTokenHeader はCSRFトークンを送受信するヘッダー
+// 入れ替えたトークンはレスポンスの同じヘッダーで返すため、SPAは受け取ったら次のリクエストから使う
+const CSRFTokenHeader = "X-CSRF-Token"
+
 // CSRFMiddleware はCSRF保護ミドルウェア
-type CSRFMiddleware struct{}
+type CSRFMiddleware struct {
+	csrfUC inputport.CSRFInputPort
+}
 
 // NewCSRFMiddleware は新しいCSRFMiddlewareを作成
-func NewCSRFMiddleware() *CSRFMiddleware {
-	return &CSRFMiddleware{}
+func NewCSRFMiddleware(csrfUC inputport.CSRFInputPort) *CSRFMiddleware {
+	return &CSRFMiddleware{csrfUC: csrfUC}
 }
 
 // Protect はCSRF保護を行う
@@ -20,29 +30,39 @@ func (m *CSRFMiddleware) Protect() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// GET, HEAD, OPTIONS以外はCSRFトークンをチェック
 		if c.Request.Method != "GET" && c.Request.Method != "HEAD" && c.Request.Method != "OPTIONS" {
-			csrfToken := c.GetHeader("X-CSRF-Token")
+			csrfToken := c.GetHeader(CSRFTokenHeader)
 			if csrfToken == "" {
-				c.JSON(http.StatusForbidden, gin.H{"error": "csrf token required"})
-				c.Abort()
+				presenter.RenderError(c, http.StatusForbidden, entities.ErrInvalidCSRFToken)
 				return
 			}
 
 			// セッションから取得
 			sessionInterface, exists := c.Get("session")
 			if !exists {
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
-				c.Abort()
+				presenter.RenderError(c, http.StatusUnauthorized, entities.ErrUnauthorized)
 				return
 			}
 
 			session := sessionInterface.(*entities.Session)
 
-			// CSRFトークン検証
-			if err := session.ValidateCSRF(csrfToken); err != nil {
-				c.JSON(http.StatusForbidden, gin.H{"error": "invalid csrf token"})
-				c.Abort()
+			// CSRFトークン検証（CSRF_ROTATIONの設定によりトークンを入れ替える）
+			resp, err := m.csrfUC.VerifyToken(c.Request.Context(), &inputport.VerifyCSRFTokenRequest{
+				Session: session,
+				Token:   csrfToken,
+				Now:     time.Now(),
+			})
+			if err != nil {
+				switch {
+				case errors.Is(err, entities.ErrSessionExpired):
+					presenter.RenderError(c, http.StatusUnauthorized, err)
+				case errors.Is(err, entities.ErrCSRFTokenExpired):
+					presenter.RenderError(c, http.StatusForbidden, err)
+				default:
+					presenter.RenderError(c, http.StatusForbidden, entities.ErrInvalidCSRFToken)
+				}
 				return
 			}
+			c.Header(CSRFTokenHeader, resp.Token)
 		}
 
 		c.Next()
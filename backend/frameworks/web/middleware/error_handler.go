@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+)
+
+// RecoveryMiddleware はハンドラーのパニックを500のproblem+jsonにする
+// スタックトレースはgin標準のリカバリーと同じく標準エラー出力に書く
+func RecoveryMiddleware() gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
+		if c.Writer.Written() {
+			c.Abort()
+			return
+		}
+		presenter.RenderError(c, http.StatusInternalServerError, fmt.Errorf("panic: %v", recovered))
+	})
+}
+
+// ErrorHandlerMiddleware はハンドラーがレスポンスを書かずにc.Errorで記録しただけのエラーをproblem+jsonで返す
+// コントローラー・ミドルウェアはエラーを返すだけでよく、レスポンスの形式はこことpresenterで揃える
+func ErrorHandlerMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if c.Writer.Written() || len(c.Errors) == 0 {
+			return
+		}
+		problem := presenter.PresentProblem(c.Errors.Last().Err, http.StatusInternalServerError, c.GetHeader("Accept-Language"), c.Request.URL.Path)
+		presenter.WriteProblem(c, problem)
+	}
+}
@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyHeader は再送制御に使うリクエストヘッダー
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyMiddleware はIdempotency-Keyヘッダーによる再送制御ミドルウェア
+// 状態を変更するリクエスト（POST/PUT/PATCH/DELETE）のレスポンスを(主体, キー, ルート)ごとに保存し、
+// 同じキーで再送されたら処理を実行せずに保存済みのレスポンスを返す
+type IdempotencyMiddleware struct {
+	idempotencyUC inputport.IdempotencyInputPort
+	logger        entities.Logger
+}
+
+// NewIdempotencyMiddleware は新しいIdempotencyMiddlewareを作成
+func NewIdempotencyMiddleware(idempotencyUC inputport.IdempotencyInputPort, logger entities.Logger) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{idempotencyUC: idempotencyUC, logger: logger}
+}
+
+// responseCaptureWriter はレスポンスボディを記録しながらクライアントに書き出す
+type responseCaptureWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *responseCaptureWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseCaptureWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Handle は認証ミドルウェアの後に登録する（主体をuser_id / kiosk_device_idから決めるため）
+// ヘッダーが無いリクエストはそのまま通す
+func (m *IdempotencyMiddleware) Handle() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || !isMutatingMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		scope, ok := idempotencyScope(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(c.Request.Body)
+			if err != nil {
+				presenter.RenderError(c, http.StatusRequestEntityTooLarge, entities.ErrRequestTooLarge)
+				return
+			}
+			c.Request.Body.Close()
+			c.Request.Body = io.NopCloser(bytes.NewBuffer(body))
+		}
+
+		// 実際のパス（/api/v1/friends/requests/<id>/accept）で区別し、クエリとボディはハッシュで比較する
+		route := c.Request.Method + " " + c.Request.URL.Path
+		hash := sha256.Sum256(append([]byte(c.Request.URL.RawQuery+"\n"), body...))
+
+		begin, err := m.idempotencyUC.Begin(c.Request.Context(), &inputport.BeginIdempotentRequestRequest{
+			Scope:       scope,
+			Key:         key,
+			Route:       route,
+			RequestHash: hex.EncodeToString(hash[:]),
+		})
+		if err != nil {
+			presenter.RenderError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		if begin.Replay != nil {
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(begin.Replay.StatusCode, begin.Replay.ContentType, begin.Replay.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		writer := &responseCaptureWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = writer
+
+		completed := false
+		defer func() {
+			// パニック時は保存せず、同じキーでやり直せるようにする
+			if !completed {
+				m.complete(c, &inputport.CompleteIdempotentRequestRequest{
+					RequestID:  begin.RequestID,
+					StatusCode: http.StatusInternalServerError,
+				})
+			}
+		}()
+
+		c.Next()
+
+		statusCode := writer.Status()
+		if errors.Is(c.Request.Context().Err(), context.DeadlineExceeded) && !writer.Written() {
+			// 何も返さずに期限を過ぎた（外側のタイムアウトのミドルウェアが504を返す）
+			statusCode = http.StatusGatewayTimeout
+		}
+		m.complete(c, &inputport.CompleteIdempotentRequestRequest{
+			RequestID:   begin.RequestID,
+			StatusCode:  statusCode,
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		})
+		completed = true
+	}
+}
+
+// complete はレスポンスを保存する
+// リクエストのcontextが期限切れ・切断で終わっていても保存・削除できるよう、キャンセルを外したcontextで実行する
+// （保存できないとキーが処理中のまま残り、期限まで同じキーの再送がすべて409になる）
+func (m *IdempotencyMiddleware) complete(c *gin.Context, req *inputport.CompleteIdempotentRequestRequest) {
+	if err := m.idempotencyUC.Complete(context.WithoutCancel(c.Request.Context()), req); err != nil {
+		m.logger.Error("Failed to complete idempotent request",
+			entities.NewField("request_id", req.RequestID),
+			entities.NewField("status", req.StatusCode),
+			entities.NewField("error", err))
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// idempotencyScope はリクエストの主体（ログインユーザーまたはキオスク端末）を返す
+func idempotencyScope(c *gin.Context) (string, bool) {
+	if userID, exists := c.Get("user_id"); exists {
+		if id, ok := userID.(uuid.UUID); ok {
+			return fmt.Sprintf("user:%s", id), true
+		}
+	}
+	if deviceID, exists := c.Get("kiosk_device_id"); exists {
+		if id, ok := deviceID.(uuid.UUID); ok {
+			return fmt.Sprintf("kiosk:%s", id), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// KioskAuthMiddleware はキオスク端末の認証ミドルウェア
+type KioskAuthMiddleware struct {
+	kioskUC inputport.KioskInputPort
+}
+
+// NewKioskAuthMiddleware は新しいKioskAuthMiddlewareを作成
+func NewKioskAuthMiddleware(kioskUC inputport.KioskInputPort) *KioskAuthMiddleware {
+	return &KioskAuthMiddleware{kioskUC: kioskUC}
+}
+
+// Authenticate はX-Kiosk-KeyヘッダーのAPIキーで端末を認証する
+func (m *KioskAuthMiddleware) Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey := c.GetHeader("X-Kiosk-Key")
+		if apiKey == "" {
+			presenter.RenderError(c, http.StatusUnauthorized, entities.ErrUnauthorized)
+			return
+		}
+
+		device, err := m.kioskUC.AuthenticateDevice(c.Request.Context(), apiKey)
+		if err != nil {
+			presenter.RenderError(c, http.StatusUnauthorized, entities.ErrInvalidKioskDevice)
+			return
+		}
+
+		// 端末IDをコンテキストにセット
+		c.Set("kiosk_device_id", device.ID)
+		c.Set("kiosk_device", device)
+
+		c.Next()
+	}
+}
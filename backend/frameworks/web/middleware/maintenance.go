@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
+)
+
+// maintenanceRetryAfterSeconds はメンテナンス中の503に付けるRetry-After（秒）
+const maintenanceRetryAfterSeconds = "300"
+
+// MaintenanceMiddleware はメンテナンス中に状態を変更するリクエストを503で拒否するミドルウェア
+// 参照（GET/HEAD/OPTIONS）は通し、許可リストのユーザーとメンテナンスモード自体の切り替えは常に通す
+type MaintenanceMiddleware struct {
+	maintenanceUC inputport.MaintenanceInputPort
+}
+
+// NewMaintenanceMiddleware は新しいMaintenanceMiddlewareを作成
+func NewMaintenanceMiddleware(maintenanceUC inputport.MaintenanceInputPort) *MaintenanceMiddleware {
+	return &MaintenanceMiddleware{maintenanceUC: maintenanceUC}
+}
+
+// Handle は認証ミドルウェアの後に登録する（許可リストをuser_idで判定するため）
+func (m *MaintenanceMiddleware) Handle() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !isMutatingMethod(c.Request.Method) || strings.HasSuffix(c.FullPath(), "/admin/maintenance") {
+			c.Next()
+			return
+		}
+
+		mode, err := m.maintenanceUC.GetMaintenanceMode(c.Request.Context())
+		if err != nil || !mode.Enabled {
+			// 設定を読めない場合はサービスを止めない
+			c.Next()
+			return
+		}
+
+		if userID, exists := c.Get("user_id"); exists {
+			if id, ok := userID.(uuid.UUID); ok && mode.CanBypass(id) {
+				c.Next()
+				return
+			}
+		}
+
+		problem := presenter.PresentProblem(entities.ErrMaintenanceMode, http.StatusServiceUnavailable, c.GetHeader("Accept-Language"), c.Request.URL.Path)
+		if mode.Message != "" {
+			// 管理者が設定したお知らせはdetailで返す
+			problem.Detail = mode.Message
+			problem.Error = mode.Message
+		}
+		c.Header("Retry-After", maintenanceRetryAfterSeconds)
+		presenter.WriteProblem(c, problem)
+	}
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/frameworks/web/openapi"
+)
+
+// RequestValidationMiddleware はOpenAPIドキュメントに定義したスキーマでJSONリクエストボディを検証
+// スキーマに合わないリクエストはコントローラーに渡さず400を返す
+// スキーマが定義されていないルートとmultipartのリクエストは検証しない
+func RequestValidationMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		schema := openapi.RequestBodySchema(c.Request.Method, c.FullPath())
+		if schema == nil || strings.Contains(c.GetHeader("Content-Type"), "multipart/form-data") {
+			c.Next()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(c.Request.Body)
+			if err != nil {
+				presenter.RenderError(c, http.StatusRequestEntityTooLarge, entities.ErrRequestTooLarge)
+				return
+			}
+			c.Request.Body.Close()
+			// コントローラーで再度読めるように戻す
+			c.Request.Body = io.NopCloser(bytes.NewBuffer(body))
+		}
+
+		if len(bytes.TrimSpace(body)) == 0 {
+			abortInvalidRequest(c, entities.FieldError{Field: "body", Message: "is required"})
+			return
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err != nil {
+			abortInvalidRequest(c, entities.FieldError{Field: "body", Message: "must be valid JSON"})
+			return
+		}
+
+		if errs := schema.Validate(value); len(errs) > 0 {
+			fields := make([]entities.FieldError, 0, len(errs))
+			for _, e := range errs {
+				fields = append(fields, entities.FieldError{Field: e.Field, Message: e.Message})
+			}
+			abortInvalidRequest(c, fields...)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// abortInvalidRequest は項目ごとのエラーをvalidation_failedのproblem+jsonで返す
+func abortInvalidRequest(c *gin.Context, fields ...entities.FieldError) {
+	presenter.RenderError(c, http.StatusBadRequest, entities.NewValidationError(fields...))
+}
@@ -10,6 +10,8 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
 )
 
 // maxRequestBodySize はリクエストボディの最大サイズ（1MB）
@@ -50,9 +52,7 @@ func InputSanitizationMiddleware(maxUploadBodySize int64) gin.HandlerFunc {
 		if c.Request.Method == http.MethodPost || c.Request.Method == http.MethodPut || c.Request.Method == http.MethodPatch {
 			contentType := c.GetHeader("Content-Type")
 			if contentType != "" && !isAllowedContentType(contentType) {
-				c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{
-					"error": "サポートされていないContent-Typeです",
-				})
+				presenter.RenderError(c, http.StatusUnsupportedMediaType, entities.ErrUnsupportedMediaType)
 				return
 			}
 		}
@@ -70,9 +70,7 @@ func InputSanitizationMiddleware(maxUploadBodySize int64) gin.HandlerFunc {
 		if c.Request.Body != nil && strings.Contains(c.GetHeader("Content-Type"), "application/json") {
 			body, err := io.ReadAll(c.Request.Body)
 			if err != nil {
-				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
-					"error": "リクエストボディが大きすぎます",
-				})
+				presenter.RenderError(c, http.StatusRequestEntityTooLarge, entities.ErrRequestTooLarge)
 				return
 			}
 			c.Request.Body.Close()
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// TenantHeader はテナントのスラッグを指定するリクエストヘッダー
+const TenantHeader = "X-Tenant-ID"
+
+// TenantMiddleware はリクエストのテナントを決め、以降の処理のcontextに設定するミドルウェア
+// X-Tenant-IDヘッダー、サブドメイン（baseDomainの設定時）の順に見て、どちらもなければ既定のテナントにする
+type TenantMiddleware struct {
+	tenantUC   inputport.TenantInputPort
+	baseDomain string
+}
+
+// NewTenantMiddleware は新しいTenantMiddlewareを作成
+func NewTenantMiddleware(tenantUC inputport.TenantInputPort, baseDomain string) *TenantMiddleware {
+	return &TenantMiddleware{
+		tenantUC:   tenantUC,
+		baseDomain: strings.ToLower(strings.Trim(baseDomain, ".")),
+	}
+}
+
+// Handle は認証より前に登録する（セッション・ユーザーの検索をテナントに絞り込むため）
+func (m *TenantMiddleware) Handle() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenant, err := m.tenantUC.ResolveTenant(c.Request.Context(), m.slug(c.Request))
+		if err != nil {
+			// 存在しないテナントは404、無効なテナントは403（ステータスはエラーコードから決まる）
+			presenter.RenderError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		c.Set("tenant_id", tenant.ID)
+		c.Request = c.Request.WithContext(entities.WithTenantID(c.Request.Context(), tenant.ID))
+		c.Next()
+	}
+}
+
+// slug はリクエストで指定されたテナントのスラッグ（指定がなければ空）
+func (m *TenantMiddleware) slug(r *http.Request) string {
+	if slug := strings.TrimSpace(r.Header.Get(TenantHeader)); slug != "" {
+		return slug
+	}
+	if m.baseDomain == "" {
+		return ""
+	}
+	host := strings.ToLower(r.Host)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	sub, ok := strings.CutSuffix(host, "."+m.baseDomain)
+	if !ok || strings.Contains(sub, ".") {
+		return ""
+	}
+	return sub
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+)
+
+// timeoutBaseContextKey は期限を付ける前のリクエストのcontextを保持するgin.Contextのキー
+const timeoutBaseContextKey = "request_timeout_base_context"
+
+// RequestTimeoutMiddleware はリクエストのcontextにtimeoutの期限を付けるミドルウェア
+// DBへのクエリはリクエストのcontextで実行するため、期限を過ぎると中断される
+// ハンドラーが何も返さないまま期限を過ぎた場合は504（request_timeout）を返す
+// 入れ子のグループで重ねて登録した場合は内側の期限で置き換える（管理APIだけ長くできるように）
+// timeoutが0以下なら期限を付けず、WebSocketの接続も対象にしない
+func RequestTimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.IsWebsocket() {
+			c.Next()
+			return
+		}
+
+		// 外側の期限は外し、クライアントの切断によるキャンセルとcontextの値は引き継ぐ
+		parent := c.Request.Context()
+		if base, ok := c.Get(timeoutBaseContextKey); ok {
+			var cancel context.CancelFunc
+			parent, cancel = context.WithCancel(context.WithoutCancel(parent))
+			defer cancel()
+			stop := context.AfterFunc(base.(context.Context), cancel)
+			defer stop()
+		} else {
+			c.Set(timeoutBaseContextKey, parent)
+		}
+		if timeout <= 0 {
+			c.Request = c.Request.WithContext(parent)
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			presenter.RenderError(c, http.StatusGatewayTimeout, entities.ErrRequestTimeout)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package openapi
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"regexp"
+	"sort"
+)
+
+// Schema はOpenAPI 3.0のSchema Object（このAPIで使う範囲のみ）
+type Schema struct {
+	Ref              string             `json:"$ref,omitempty"` // 共通のスキーマ（components/schemas）の参照
+	Type             string             `json:"type,omitempty"`
+	Format           string             `json:"format,omitempty"`
+	Description      string             `json:"description,omitempty"`
+	Properties       map[string]*Schema `json:"properties,omitempty"`
+	Required         []string           `json:"required,omitempty"`
+	Items            *Schema            `json:"items,omitempty"`
+	MinLength        *int               `json:"minLength,omitempty"`
+	MaxLength        *int               `json:"maxLength,omitempty"`
+	MinItems         *int               `json:"minItems,omitempty"`
+	Minimum          *float64           `json:"minimum,omitempty"`
+	Maximum          *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum bool               `json:"exclusiveMinimum,omitempty"`
+	Enum             []string           `json:"enum,omitempty"`
+	Nullable         bool               `json:"nullable,omitempty"`
+}
+
+// ValidationError はスキーマ違反1件分の情報
+type ValidationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Validate はencoding/jsonでデコードした値をスキーマで検証し、違反をすべて返す
+// 数値はfloat64、オブジェクトはmap[string]interface{}としてデコードされている前提
+func (s *Schema) Validate(value interface{}) []ValidationError {
+	var errs []ValidationError
+	s.validate("", value, &errs)
+	return errs
+}
+
+func (s *Schema) validate(field string, value interface{}, errs *[]ValidationError) {
+	add := func(format string, args ...interface{}) {
+		*errs = append(*errs, ValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if value == nil {
+		if !s.Nullable {
+			add("must not be null")
+		}
+		return
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			add("must be an object")
+			return
+		}
+		for _, name := range s.Required {
+			if _, exists := obj[name]; !exists {
+				*errs = append(*errs, ValidationError{Field: joinField(field, name), Message: "is required"})
+			}
+		}
+		// 出力順を安定させるためプロパティ名でソート
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if v, exists := obj[name]; exists {
+				s.Properties[name].validate(joinField(field, name), v, errs)
+			}
+		}
+
+	case "array":
+		arr, ok := value.([]interface{})
+		if !ok {
+			add("must be an array")
+			return
+		}
+		if s.MinItems != nil && len(arr) < *s.MinItems {
+			add("must contain at least %d items", *s.MinItems)
+		}
+		if s.Items != nil {
+			for i, v := range arr {
+				s.Items.validate(fmt.Sprintf("%s[%d]", field, i), v, errs)
+			}
+		}
+
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			add("must be a string")
+			return
+		}
+		length := len([]rune(str))
+		if s.MinLength != nil && length < *s.MinLength {
+			add("must be at least %d characters", *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			add("must be at most %d characters", *s.MaxLength)
+		}
+		switch s.Format {
+		case "uuid":
+			if !uuidPattern.MatchString(str) {
+				add("must be a valid UUID")
+			}
+		case "email":
+			if _, err := mail.ParseAddress(str); err != nil {
+				add("must be a valid email address")
+			}
+		}
+		if len(s.Enum) > 0 && !containsString(s.Enum, str) {
+			add("must be one of %v", s.Enum)
+		}
+
+	case "integer", "number":
+		num, ok := value.(float64)
+		if !ok {
+			add("must be a %s", s.Type)
+			return
+		}
+		if s.Type == "integer" && num != math.Trunc(num) {
+			add("must be an integer")
+			return
+		}
+		if s.Minimum != nil {
+			if s.ExclusiveMinimum && num <= *s.Minimum {
+				add("must be greater than %g", *s.Minimum)
+			} else if !s.ExclusiveMinimum && num < *s.Minimum {
+				add("must be at least %g", *s.Minimum)
+			}
+		}
+		if s.Maximum != nil && num > *s.Maximum {
+			add("must be at most %g", *s.Maximum)
+		}
+
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			add("must be a boolean")
+		}
+	}
+}
+
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
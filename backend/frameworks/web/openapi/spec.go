@@ -0,0 +1,1128 @@
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Document はOpenAPI 3.0ドキュメントのルート
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+// Info はAPIのメタ情報
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Components は共通定義（セキュリティスキーム・共通のスキーマ）
+type Components struct {
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+}
+
+// SecurityScheme はOpenAPIのSecurity Scheme Object
+type SecurityScheme struct {
+	Type string `json:"type"`
+	In   string `json:"in"`
+	Name string `json:"name"`
+}
+
+// PathItem は1パス分のオペレーション（メソッド別）
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty"`
+	Post   *Operation `json:"post,omitempty"`
+	Put    *Operation `json:"put,omitempty"`
+	Patch  *Operation `json:"patch,omitempty"`
+	Delete *Operation `json:"delete,omitempty"`
+}
+
+// Operation はOpenAPIのOperation Object
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+}
+
+// Parameter はパスパラメータ
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody はJSONリクエストボディ
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// MediaType はコンテンツタイプごとのスキーマ
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Response はレスポンス定義
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// problemSchema はエラーレスポンス（RFC 7807のproblem+json）のスキーマ
+var problemSchema = &Schema{
+	Type: "object",
+	Properties: map[string]*Schema{
+		"type":       {Type: "string", Description: "エラーの種類（urn:gity-point-system:error:<error_code>、汎用のエラーはabout:blank）"},
+		"title":      {Type: "string", Description: "HTTPステータスの説明"},
+		"status":     {Type: "integer"},
+		"detail":     {Type: "string", Description: "Accept-Languageに応じた翻訳済みのメッセージ"},
+		"instance":   {Type: "string", Description: "リクエストのパス"},
+		"error_code": {Type: "string"},
+		"errors": {
+			Type:        "array",
+			Description: "検証エラー（validation_failed）の項目ごとの内容",
+			Items: &Schema{
+				Type: "object",
+				Properties: map[string]*Schema{
+					"field":   {Type: "string"},
+					"message": {Type: "string"},
+				},
+			},
+		},
+		"params": {Type: "object", Description: "メッセージの差し込み値（残高など）"},
+		"error":  {Type: "string", Description: "detailと同じ（以前の形式との互換用）"},
+		"code":   {Type: "string", Description: "error_codeと同じ（以前の形式との互換用）"},
+	},
+	Required: []string{"type", "title", "status", "detail", "error_code"},
+}
+
+// problemResponse はproblem+jsonを返すエラーレスポンスの定義
+func problemResponse(description string) *Response {
+	return &Response{
+		Description: description,
+		Content: map[string]*MediaType{
+			"application/problem+json": {Schema: &Schema{Ref: "#/components/schemas/Problem"}},
+		},
+	}
+}
+
+// Route はドキュメント生成の入力となるルート情報（gin.RouteInfoから変換）
+type Route struct {
+	Method  string
+	Path    string // ginの形式（/api/users/:id）
+	Handler string // ハンドラー関数名（runtime.FuncForPCの名前）
+
+	Deprecated bool // 廃止予定のAPIバージョンのルートか
+}
+
+// 認証方式
+const (
+	authSession = "session" // セッションCookie（状態変更はX-CSRF-Tokenも必要）
+	authKiosk   = "kiosk"   // X-Kiosk-Key
+	authNone    = "none"    // 公開
+)
+
+// operationSpec はルートごとに手で管理する定義
+// ハンドラー名から要約を作れないルート（クロージャ）や、リクエストボディを持つルートを登録する
+type operationSpec struct {
+	Summary     string
+	Auth        string
+	RequestBody *Schema
+}
+
+func operationKey(method, path string) string {
+	return method + " " + path
+}
+
+// versionPrefix は /api/v1 のようなバージョン付きプレフィックス
+var versionPrefix = regexp.MustCompile(`^/api/v[0-9]+(/|$)`)
+
+// CanonicalPath はバージョン付きのパスをバージョンなしの形式（/api/...）に変換
+func CanonicalPath(path string) string {
+	return versionPrefix.ReplaceAllString(path, "/api$1")
+}
+
+// lookupOperation はルートの定義を探す
+// バージョン固有の定義（/api/v2/...）があればそれを優先し、なければバージョンなしの定義を使う
+func lookupOperation(method, path string) (*operationSpec, bool) {
+	if spec, ok := operations[operationKey(method, path)]; ok {
+		return spec, true
+	}
+	spec, ok := operations[operationKey(method, CanonicalPath(path))]
+	return spec, ok
+}
+
+// operations はルート（"METHOD /api/path"）ごとの定義
+// キーはバージョンなしのパスで、全バージョンに適用される
+// RequestBodyはコントローラーのbindingタグと揃えること
+var operations = map[string]*operationSpec{
+	// 認証
+	operationKey(http.MethodPost, "/api/auth/register"): {
+		Summary: "ユーザー登録",
+		Auth:    authNone,
+		RequestBody: object(map[string]*Schema{
+			"username":      str(3, 50),
+			"email":         email(),
+			"password":      str(8, 0),
+			"display_name":  str(1, 100),
+			"first_name":    str(1, 100),
+			"last_name":     str(1, 100),
+			"referral_code": str(0, 20),
+		}, "username", "email", "password", "display_name", "first_name", "last_name"),
+	},
+	operationKey(http.MethodPost, "/api/auth/login"): {
+		Summary: "ログイン",
+		Auth:    authNone,
+		RequestBody: object(map[string]*Schema{
+			"username": str(1, 0),
+			"password": str(1, 0),
+		}, "username", "password"),
+	},
+	operationKey(http.MethodPost, "/api/auth/unlock"): {
+		Summary:     "アカウントロック解除",
+		Auth:        authNone,
+		RequestBody: object(map[string]*Schema{"token": str(1, 0)}, "token"),
+	},
+	operationKey(http.MethodPost, "/api/auth/email/verify"): {
+		Summary:     "登録時のメールアドレス確認（ログイン不要）",
+		Auth:        authNone,
+		RequestBody: object(map[string]*Schema{"token": str(1, 0)}, "token"),
+	},
+	operationKey(http.MethodPost, "/api/auth/email/resend"): {
+		Summary:     "確認メールの再送（202。登録の有無によらず同じ応答。1分に1回・24時間に5回まで）",
+		Auth:        authNone,
+		RequestBody: object(map[string]*Schema{"email": email()}, "email"),
+	},
+	operationKey(http.MethodGet, "/api/auth/me"):      {Summary: "ログイン中ユーザーの取得"},
+	operationKey(http.MethodGet, "/api/auth/csrf"):    {Summary: "CSRFトークンの取得（期限切れなら入れ替える）"},
+	operationKey(http.MethodPost, "/api/auth/logout"): {Summary: "ログアウト"},
+	operationKey(http.MethodPost, "/api/auth/deactivate"): {
+		Summary:     "アカウントの一時休止（30日以内に再びログインすると再開）",
+		RequestBody: object(map[string]*Schema{"password": str(1, 0)}, "password"),
+	},
+
+	// 公開API
+	operationKey(http.MethodGet, "/api/products"):   {Summary: "商品一覧", Auth: authNone},
+	operationKey(http.MethodGet, "/api/categories"): {Summary: "カテゴリ一覧", Auth: authNone},
+
+	// キオスク端末
+	operationKey(http.MethodPost, "/api/kiosk/lookup"): {
+		Summary: "QRコード・カードからユーザーを照会",
+		RequestBody: object(map[string]*Schema{
+			"qr_code": str(0, 0),
+			"card_id": str(0, 0),
+		}),
+	},
+	operationKey(http.MethodPost, "/api/kiosk/grant"): {
+		Summary: "端末からボーナスを付与",
+		RequestBody: object(map[string]*Schema{
+			"qr_code":         str(0, 0),
+			"card_id":         str(0, 0),
+			"idempotency_key": str(1, 0),
+		}, "idempotency_key"),
+	},
+
+	// ポイント
+	operationKey(http.MethodPost, "/api/points/transfer"): {
+		Summary: "ポイント送金",
+		RequestBody: object(map[string]*Schema{
+			"to_user_id":      uuidString(),
+			"amount":          integer(1, false),
+			"idempotency_key": str(1, 0),
+			"description":     str(0, 200),
+		}, "to_user_id", "amount", "idempotency_key"),
+	},
+	operationKey(http.MethodGet, "/api/points/transfer/quote"): {Summary: "送金前に手数料・合計額・残高が足りるかを見積もる（amountを指定）"},
+	operationKey(http.MethodPost, "/api/points/recurring"): {
+		Summary: "定期送金を登録",
+		RequestBody: object(map[string]*Schema{
+			"to_user_id": uuidString(),
+			"amount":     integer(1, false),
+			"interval":   enum("weekly", "monthly"),
+			"message":    str(0, 200),
+			"start_at":   nullable(dateTime()),
+		}, "to_user_id", "amount", "interval"),
+	},
+	operationKey(http.MethodGet, "/api/points/balance"):  {Summary: "ポイント残高（送金リクエストのために確保中の額reserved_balanceと使える額available_balanceを含む）"},
+	operationKey(http.MethodGet, "/api/points/history"):  {Summary: "取引履歴"},
+	operationKey(http.MethodGet, "/api/points/expiring"): {Summary: "失効予定のポイント"},
+	operationKey(http.MethodGet, "/api/points/transactions/:id/receipt"): {
+		Summary: "取引の印刷用の控え（当事者のみ。完了・取り消し済みの取引に限り、本物かを確かめる検証ハッシュを含む）",
+	},
+	// multipart/form-data（image）のためJSONボディは定義しない
+	operationKey(http.MethodPost, "/api/points/transactions/:id/attachment"): {
+		Summary: "完了した送金に画像を添付（当事者のみ。1件につき1枚で、添付し直すと置き換える）",
+	},
+	operationKey(http.MethodGet, "/api/points/transactions/:id/attachment"): {
+		Summary: "送金の添付画像（送金リクエストに添付したものを含む。当事者のみ）",
+	},
+
+	// 友達
+	operationKey(http.MethodPost, "/api/friends/requests"): {
+		Summary:     "友達申請",
+		RequestBody: object(map[string]*Schema{"addressee_id": uuidString()}, "addressee_id"),
+	},
+	operationKey(http.MethodPost, "/api/friends/discover"): {
+		Summary: "連絡先ハッシュから友達を探す",
+		RequestBody: object(map[string]*Schema{
+			"hashes": {Type: "array", Items: str(64, 64)}, // lower(trim(メールアドレス or ユーザー名)) のSHA-256（最大500件）
+		}, "hashes"),
+	},
+	operationKey(http.MethodGet, "/api/friends/:id/mutual"): {Summary: "ユーザー（:id）との共通の友達と人数"},
+	operationKey(http.MethodGet, "/api/friends/quick-send"): {Summary: "クイック送金の友達一覧（ピン留めと覚えておく送金額）"},
+	operationKey(http.MethodPut, "/api/friends/:id/pin"):    {Summary: "友達をクイック送金の先頭にピン留め"},
+	operationKey(http.MethodDelete, "/api/friends/:id/pin"): {Summary: "友達のピン留めを解除"},
+
+	// QRコード
+	operationKey(http.MethodPost, "/api/qrcodes/receive"): {
+		Summary:     "受取用QRコードを生成",
+		RequestBody: object(map[string]*Schema{"amount": nullable(integer(0, true))}),
+	},
+	operationKey(http.MethodPost, "/api/qrcodes/send"): {
+		Summary:     "送信用QRコードを生成",
+		RequestBody: object(map[string]*Schema{"amount": integer(0, true)}, "amount"),
+	},
+	operationKey(http.MethodPost, "/api/qrcodes/scan"): {
+		Summary: "QRコードを読み取り（codeは読み取ったディープリンク・旧形式の文字列、またはコードのみ）",
+		RequestBody: object(map[string]*Schema{
+			"code":            str(1, 0),
+			"amount":          nullable(integer(0, true)),
+			"idempotency_key": str(1, 0),
+		}, "code", "idempotency_key"),
+	},
+
+	// デイリーボーナス
+	operationKey(http.MethodPost, "/api/daily-bonus/draw"): {
+		Summary:     "ボーナス抽選",
+		RequestBody: object(map[string]*Schema{"bonus_id": uuidString()}, "bonus_id"),
+	},
+
+	// 送金リクエスト
+	operationKey(http.MethodPost, "/api/transfer-requests"): {
+		Summary: "送金リクエストを作成（承認待ちのリクエストが上限に達していれば409 pending_request_limit / recipient_request_limit。holdがtrueなら承認まで送信者の残高から金額を確保する）",
+		RequestBody: object(map[string]*Schema{
+			"to_user_id":      uuidString(),
+			"amount":          integer(0, true),
+			"message":         str(0, 200),
+			"idempotency_key": str(1, 0),
+			"hold":            {Type: "boolean"},
+		}, "to_user_id", "amount", "idempotency_key"),
+	},
+	operationKey(http.MethodPost, "/api/transfer-requests/:id/counter"): {
+		Summary:     "金額を変更して送信者に差し戻す（カウンターオファー）",
+		RequestBody: object(map[string]*Schema{"amount": integer(0, true)}, "amount"),
+	},
+	// multipart/form-data（image）のためJSONボディは定義しない
+	operationKey(http.MethodPost, "/api/transfer-requests/:id/attachment"): {
+		Summary: "承認待ちの送金リクエストに画像を添付（当事者のみ。承認すると送金の添付として見える）",
+	},
+	operationKey(http.MethodGet, "/api/transfer-requests/:id/attachment"): {Summary: "送金リクエストの添付画像（当事者のみ）"},
+	operationKey(http.MethodPut, "/api/transfer-requests/mutes/:user_id"): {
+		Summary:     "送信者をミュート（以降のリクエストは通知せず、rejectなら自動で拒否、dropなら承認待ちのまま見せない。modeは省略でreject）",
+		RequestBody: object(map[string]*Schema{"mode": enum("reject", "drop")}),
+	},
+
+	// 割り勘（参加者の支払い・拒否は送金リクエストの承認・拒否で行う）
+	operationKey(http.MethodPost, "/api/split-requests"): {
+		Summary: "割り勘を作成し、友達の参加者それぞれに送金リクエストを送る（均等の場合はamountを省略）",
+		RequestBody: object(map[string]*Schema{
+			"total_amount": integer(0, true),
+			"split_type":   enum("equal", "custom"),
+			"participants": {
+				Type: "array",
+				Items: object(map[string]*Schema{
+					"user_id": uuidString(),
+					"amount":  integer(0, false),
+				}, "user_id"),
+			},
+			"include_requester": {Type: "boolean"},
+			"message":           str(0, 200),
+			"idempotency_key":   str(1, 0),
+		}, "total_amount", "split_type", "participants", "idempotency_key"),
+	},
+	operationKey(http.MethodGet, "/api/split-requests"):        {Summary: "作成した割り勘の一覧と支払い状況"},
+	operationKey(http.MethodGet, "/api/split-requests/:id"):    {Summary: "割り勘の支払い状況（誰が支払ったか。作成者・参加者のみ）"},
+	operationKey(http.MethodDelete, "/api/split-requests/:id"): {Summary: "割り勘をキャンセル（支払い待ちの送金リクエストを取り消す。支払い済みの分はそのまま）"},
+
+	// 商品交換
+	operationKey(http.MethodPost, "/api/products/exchange"): {
+		Summary: "商品交換",
+		RequestBody: object(map[string]*Schema{
+			"product_id":          uuidString(),
+			"quantity":            integer(1, false),
+			"recipient_id":        uuidString(), // 友達に贈る場合のみ
+			"shipping_address_id": uuidString(), // 配送が必要な商品のお届け先（省略時は既定）
+		}, "product_id", "quantity"),
+	},
+
+	// カート（複数商品の交換）
+	operationKey(http.MethodGet, "/api/cart"): {Summary: "カートの中身と現在の価格での合計"},
+	operationKey(http.MethodPost, "/api/cart/items"): {
+		Summary: "カートに商品を追加（入っている商品なら数量を足す）",
+		RequestBody: object(map[string]*Schema{
+			"product_id": uuidString(),
+			"quantity":   integer(1, false),
+		}, "product_id", "quantity"),
+	},
+	operationKey(http.MethodPut, "/api/cart/items/:product_id"): {
+		Summary: "カートの商品の数量を変更（0なら削除）",
+		RequestBody: object(map[string]*Schema{
+			"quantity": integer(0, false),
+		}, "quantity"),
+	},
+	operationKey(http.MethodDelete, "/api/cart/items/:product_id"): {Summary: "カートから商品を削除"},
+	operationKey(http.MethodPost, "/api/cart/checkout"):            {Summary: "カートの商品をまとめて交換（notes, shipping_address_idは任意。ポイントは1回で減算し、いずれかの商品で失敗した場合は何も交換しない）"},
+
+	// 設定
+	operationKey(http.MethodPut, "/api/settings/profile"): {
+		Summary: "プロフィール更新",
+		RequestBody: object(map[string]*Schema{
+			"display_name": str(1, 100),
+			"email":        email(),
+			"first_name":   str(1, 100),
+			"last_name":    str(1, 100),
+		}, "display_name", "email", "first_name", "last_name"),
+	},
+	operationKey(http.MethodPut, "/api/settings/username"): {
+		Summary:     "ユーザー名変更",
+		RequestBody: object(map[string]*Schema{"new_username": str(3, 50)}, "new_username"),
+	},
+	operationKey(http.MethodPut, "/api/settings/privacy"): {
+		Summary:     "プライバシー設定（友達検索での公開可否）",
+		RequestBody: object(map[string]*Schema{"discoverable": {Type: "boolean"}}, "discoverable"),
+	},
+	operationKey(http.MethodPut, "/api/settings/timezone"): {
+		Summary:     "ボーナス日の区切りに使うタイムゾーン（IANA名。空ならシステム設定）",
+		RequestBody: object(map[string]*Schema{"timezone": str(0, 64)}, "timezone"),
+	},
+	operationKey(http.MethodPut, "/api/settings/transfer-acceptance"): {
+		Summary:     "誰からのポイントを受け取るか（anyone / friends_only。空ならシステム設定の既定）",
+		RequestBody: object(map[string]*Schema{"accept_transfers_from": enum("", "anyone", "friends_only")}, "accept_transfers_from"),
+	},
+	operationKey(http.MethodPost, "/api/settings/devices"): {
+		Summary: "プッシュ通知を受け取る端末の登録",
+		RequestBody: object(map[string]*Schema{
+			"platform": enum("ios", "android"),
+			"token":    str(1, 4096), // APNsのデバイストークンまたはFCMの登録トークン
+		}, "platform", "token"),
+	},
+	operationKey(http.MethodDelete, "/api/settings/devices"): {
+		Summary:     "プッシュ通知を受け取る端末の登録解除",
+		RequestBody: object(map[string]*Schema{"token": str(1, 4096)}, "token"),
+	},
+	operationKey(http.MethodPut, "/api/settings/notifications"): {
+		Summary: "通知設定（指定した項目のみ更新）",
+		RequestBody: object(map[string]*Schema{
+			"push_enabled":  {Type: "boolean"},
+			"email_enabled": {Type: "boolean"},
+			"events": object(map[string]*Schema{
+				"transfer_request_received": {Type: "boolean"},
+				"points_received":           {Type: "boolean"},
+				"points_expiring":           {Type: "boolean"},
+				"new_login":                 {Type: "boolean"},
+				"weekly_digest":             {Type: "boolean"},
+			}),
+			"quiet_hours": object(map[string]*Schema{
+				"enabled":  {Type: "boolean"},
+				"start":    str(5, 5),
+				"end":      str(5, 5),
+				"timezone": str(0, 64),
+			}, "enabled", "start", "end"),
+		}),
+	},
+	operationKey(http.MethodGet, "/api/settings/addresses"): {Summary: "お届け先の一覧（既定のもの、登録順）"},
+	operationKey(http.MethodPost, "/api/settings/addresses"): {
+		Summary:     "お届け先の登録（最初の1件は既定になる）",
+		RequestBody: shippingAddressSchema(),
+	},
+	operationKey(http.MethodPut, "/api/settings/addresses/:id"): {
+		Summary:     "お届け先の変更",
+		RequestBody: shippingAddressSchema(),
+	},
+	operationKey(http.MethodDelete, "/api/settings/addresses/:id"):       {Summary: "お届け先の削除（既定を削除すると最も古いお届け先が既定になる）"},
+	operationKey(http.MethodPost, "/api/settings/addresses/:id/default"): {Summary: "既定のお届け先の切り替え"},
+	operationKey(http.MethodPut, "/api/settings/password"): {
+		Summary: "パスワード変更",
+		RequestBody: object(map[string]*Schema{
+			"current_password": str(1, 0),
+			"new_password":     str(8, 0),
+		}, "current_password", "new_password"),
+	},
+	operationKey(http.MethodPost, "/api/settings/email/verify/confirm"): {
+		Summary:     "メールアドレス確認",
+		RequestBody: object(map[string]*Schema{"token": str(1, 0)}, "token"),
+	},
+	operationKey(http.MethodDelete, "/api/settings/account"): {
+		Summary: "アカウント削除",
+		RequestBody: object(map[string]*Schema{
+			"password":        str(1, 0),
+			"deletion_reason": nullable(str(0, 0)),
+		}, "password"),
+	},
+
+	// 管理者
+	operationKey(http.MethodPost, "/api/admin/points/grant"): {
+		Summary:     "ポイント付与（対象の予算を消化する。承認の閾値を超える場合は承認待ちとして202を返す）",
+		RequestBody: adminGrantBody(),
+	},
+	operationKey(http.MethodPost, "/api/admin/points/deduct"): {
+		Summary:     "ポイント減算（承認の閾値を超える場合は承認待ちとして202を返す）",
+		RequestBody: adminPointsBody(),
+	},
+	operationKey(http.MethodPost, "/api/admin/exchanges/redemption/validate"): {
+		Summary:     "引換コードの確認",
+		RequestBody: object(map[string]*Schema{"code": str(1, 32)}, "code"),
+	},
+	operationKey(http.MethodPost, "/api/admin/exchanges/redemption/redeem"): {
+		Summary:     "引換コードの引き換え（受け渡し済みにする）",
+		RequestBody: object(map[string]*Schema{"code": str(1, 32)}, "code"),
+	},
+	operationKey(http.MethodPut, "/api/admin/users/:id/role"): {
+		Summary:     "ユーザーのロール変更",
+		RequestBody: object(map[string]*Schema{"role": enum("user", "admin")}, "role"),
+	},
+	operationKey(http.MethodPost, "/api/admin/users/:id/logout"): {Summary: "ユーザーの強制ログアウト（全セッションを失効）"},
+	operationKey(http.MethodPost, "/api/admin/sessions/purge"):   {Summary: "全ユーザーのセッションを失効（操作中のセッションは残す）"},
+	operationKey(http.MethodPut, "/api/admin/lottery-tiers"): {
+		Summary: "抽選ティア更新",
+		RequestBody: object(map[string]*Schema{
+			"tiers": {
+				Type: "array",
+				Items: object(map[string]*Schema{
+					"name":          str(1, 0),
+					"points":        integer(0, false),
+					"probability":   number(0, 100),
+					"display_order": integer(0, false),
+				}, "name", "probability"),
+			},
+		}, "tiers"),
+	},
+	operationKey(http.MethodPut, "/api/admin/bonus-settings/timezone"): {
+		Summary:     "ボーナス日の区切りに使うタイムゾーン更新（IANA名）",
+		RequestBody: object(map[string]*Schema{"timezone": str(1, 64)}, "timezone"),
+	},
+	operationKey(http.MethodPost, "/api/admin/kiosk/devices"): {
+		Summary:     "キオスク端末登録",
+		RequestBody: kioskDeviceBody(),
+	},
+	operationKey(http.MethodPut, "/api/admin/kiosk/devices/:id"): {
+		Summary:     "キオスク端末更新",
+		RequestBody: kioskDeviceBody(),
+	},
+	operationKey(http.MethodPost, "/api/admin/kiosk/cards"): {
+		Summary: "ICカード登録",
+		RequestBody: object(map[string]*Schema{
+			"card_id": str(1, 0),
+			"user_id": uuidString(),
+		}, "card_id", "user_id"),
+	},
+	operationKey(http.MethodPut, "/api/admin/maintenance"): {
+		Summary: "メンテナンスモード切り替え",
+		RequestBody: object(map[string]*Schema{
+			"enabled":          {Type: "boolean"},
+			"message":          str(0, 500),
+			"allowed_user_ids": {Type: "array", Items: uuidString()},
+		}),
+	},
+	operationKey(http.MethodPost, "/api/admin/maintenance/recompute-balances"): {
+		Summary: "残高を取引履歴から再計算（applyで補正取引を記録して残高を合わせる）",
+		RequestBody: object(map[string]*Schema{
+			"apply":      {Type: "boolean"},
+			"batch_size": integer(0, false),
+			"reason":     str(0, 500),
+		}),
+	},
+	operationKey(http.MethodPut, "/api/admin/expiry-policies/grace-period"): {
+		Summary: "失効取り消しの猶予日数設定",
+		RequestBody: object(map[string]*Schema{
+			"grace_days": integer(0, false),
+		}, "grace_days"),
+	},
+	operationKey(http.MethodPut, "/api/admin/expiry-policies/:source_type"): {
+		Summary: "付与種別の有効期間設定",
+		RequestBody: object(map[string]*Schema{
+			"validity_days": integer(0, true),
+		}, "validity_days"),
+	},
+	operationKey(http.MethodPut, "/api/admin/users/:id/expiry-override"): {
+		Summary: "ユーザー個別の有効期間設定",
+		RequestBody: object(map[string]*Schema{
+			"validity_days": integer(0, true),
+			"reason":        str(0, 500),
+		}, "validity_days"),
+	},
+	operationKey(http.MethodGet, "/api/admin/point-expiry/status"): {Summary: "ポイント有効期限ワーカーの直近の実行状況（処理中は進捗）"},
+	operationKey(http.MethodGet, "/api/admin/users/:id/point-batches"): {
+		Summary: "ユーザーのポイントバッチ一覧（使用済み・失効済み・取り消し済みを含む、調整の記録つき）",
+	},
+	operationKey(http.MethodPatch, "/api/admin/point-batches/:id"): {
+		Summary: "ポイントバッチの有効期限の変更か取り消し（取り消しは残っていた分を残高から減算）",
+		RequestBody: object(map[string]*Schema{
+			"expires_at": nullable(dateTime()),
+			"cancel":     {Type: "boolean"},
+			"reason":     str(1, 500),
+		}, "reason"),
+	},
+	operationKey(http.MethodPost, "/api/admin/announcements"): {
+		Summary:     "お知らせ作成",
+		RequestBody: announcementBody(),
+	},
+	operationKey(http.MethodPut, "/api/admin/announcements/:id"): {
+		Summary:     "お知らせ更新",
+		RequestBody: announcementBody(),
+	},
+	operationKey(http.MethodPost, "/api/admin/pricing-rules"): {
+		Summary:     "価格ルール作成",
+		RequestBody: pricingRuleBody(),
+	},
+	operationKey(http.MethodPut, "/api/admin/pricing-rules/:id"): {
+		Summary:     "価格ルール更新",
+		RequestBody: pricingRuleBody(),
+	},
+	operationKey(http.MethodPut, "/api/admin/users/:id/tier"): {
+		Summary: "会員ランクの固定",
+		RequestBody: object(map[string]*Schema{
+			"tier": enum("bronze", "silver", "gold"),
+		}, "tier"),
+	},
+	operationKey(http.MethodPost, "/api/admin/events"): {
+		Summary:     "イベント作成",
+		RequestBody: eventBody(),
+	},
+	operationKey(http.MethodPut, "/api/admin/events/:id"): {
+		Summary:     "イベント更新",
+		RequestBody: eventBody(),
+	},
+	operationKey(http.MethodGet, "/api/admin/earning-rules"): {Summary: "ポイント獲得ルール一覧（付与回数・ユーザー数・付与ポイントの実績つき）"},
+	operationKey(http.MethodPost, "/api/admin/earning-rules"): {
+		Summary:     "ポイント獲得ルール作成",
+		RequestBody: earningRuleBody(),
+	},
+	operationKey(http.MethodPut, "/api/admin/earning-rules/:id"): {
+		Summary:     "ポイント獲得ルール更新",
+		RequestBody: earningRuleBody(),
+	},
+	operationKey(http.MethodDelete, "/api/admin/earning-rules/:id"): {Summary: "ポイント獲得ルール削除（付与済みのポイントは残る）"},
+	operationKey(http.MethodPost, "/api/events/check-in"): {
+		Summary: "イベントのチェックイン",
+		RequestBody: object(map[string]*Schema{
+			"code": str(1, 0),
+		}, "code"),
+	},
+	operationKey(http.MethodGet, "/api/points/reason-codes"): {Summary: "理由コード一覧（取引履歴の絞り込み用）"},
+	operationKey(http.MethodPost, "/api/admin/reason-codes"): {
+		Summary: "理由コード作成",
+		RequestBody: object(map[string]*Schema{
+			"code":        str(1, 32),
+			"label":       str(1, 50),
+			"description": str(0, 200),
+		}, "code", "label"),
+	},
+	operationKey(http.MethodPut, "/api/admin/reason-codes/:code"): {
+		Summary: "理由コード更新",
+		RequestBody: object(map[string]*Schema{
+			"label":       str(1, 50),
+			"description": str(0, 200),
+			"is_active":   {Type: "boolean"},
+		}, "label"),
+	},
+	// multipart/form-data（file, send_invitations）のためJSONボディは定義しない
+	operationKey(http.MethodPost, "/api/admin/users/import"): {Summary: "CSVからユーザーを一括登録"},
+	// multipart/form-data（file, dry_run）のためJSONボディは定義しない
+	operationKey(http.MethodPost, "/api/admin/transactions/import"): {
+		Summary: "CSVから他のシステムの過去の取引を元の日時で取り込み（dry_runで検証と残高の照合だけ）",
+	},
+	operationKey(http.MethodGet, "/api/admin/departments"): {Summary: "部署一覧"},
+	operationKey(http.MethodPost, "/api/admin/departments"): {
+		Summary:     "部署作成",
+		RequestBody: departmentBody(),
+	},
+	operationKey(http.MethodPut, "/api/admin/departments/:id"): {
+		Summary:     "部署更新（親・月間予算は指定した値で置き換える）",
+		RequestBody: departmentBody(),
+	},
+	operationKey(http.MethodDelete, "/api/admin/departments/:id"): {Summary: "部署削除（子部署がない場合のみ）"},
+	operationKey(http.MethodPut, "/api/admin/users/:id/department"): {
+		Summary: "ユーザーの所属部署を設定（nullで解除）",
+		RequestBody: object(map[string]*Schema{
+			"department_id": nullable(uuidString()),
+		}),
+	},
+	operationKey(http.MethodGet, "/api/admin/analytics/departments"): {Summary: "部署別の付与・利用ポイントと予算の消化状況"},
+	operationKey(http.MethodGet, "/api/admin/budgets"):               {Summary: "予算一覧（現在の期間の消化状況を含む）"},
+	operationKey(http.MethodPost, "/api/admin/budgets"): {
+		Summary: "予算作成",
+		RequestBody: object(map[string]*Schema{
+			"name":        str(1, 100),
+			"scope":       enum("global", "department", "admin"),
+			"scope_id":    nullable(uuidString()),
+			"period":      enum("daily", "weekly", "monthly"),
+			"amount":      integer(0, true),
+			"enforcement": enum("block", "override"),
+		}, "name", "scope", "period", "amount", "enforcement"),
+	},
+	operationKey(http.MethodGet, "/api/admin/budgets/:id"): {Summary: "予算と現在の期間の消化状況"},
+	operationKey(http.MethodPut, "/api/admin/budgets/:id"): {
+		Summary: "予算の名前・金額・超過時の扱い・有効状態を更新（対象範囲と期間は変えられない）",
+		RequestBody: object(map[string]*Schema{
+			"name":        str(1, 100),
+			"amount":      integer(0, true),
+			"enforcement": enum("block", "override"),
+			"is_active":   {Type: "boolean"},
+		}, "name", "amount", "enforcement", "is_active"),
+	},
+	operationKey(http.MethodDelete, "/api/admin/budgets/:id"):      {Summary: "予算削除（消化状況も削除される）"},
+	operationKey(http.MethodGet, "/api/admin/approvals"):           {Summary: "承認待ちの付与・減算一覧（status=allで全状態）"},
+	operationKey(http.MethodGet, "/api/admin/approvals/threshold"): {Summary: "承認が必要になる付与・減算の金額"},
+	operationKey(http.MethodPut, "/api/admin/approvals/threshold"): {
+		Summary:     "承認が必要になる付与・減算の金額を設定（0で無効）",
+		RequestBody: object(map[string]*Schema{"threshold": integer(0, false)}, "threshold"),
+	},
+	operationKey(http.MethodGet, "/api/admin/approvals/:id"):              {Summary: "承認待ちの操作と監査記録"},
+	operationKey(http.MethodPost, "/api/admin/approvals/:id/approve"):     {Summary: "承認して実行（申請した本人は承認できない。commentは任意）"},
+	operationKey(http.MethodPost, "/api/admin/approvals/:id/reject"):      {Summary: "却下（commentは任意）"},
+	operationKey(http.MethodGet, "/api/admin/suspicious-activities"):      {Summary: "不審な送金の記録一覧（statusで絞り込み）"},
+	operationKey(http.MethodGet, "/api/admin/suspicious-activities/hold"): {Summary: "不審な送金を管理者の確認まで保留するか"},
+	operationKey(http.MethodPut, "/api/admin/suspicious-activities/hold"): {
+		Summary:     "不審な送金を管理者の確認まで保留するかを設定",
+		RequestBody: object(map[string]*Schema{"enabled": {Type: "boolean"}}, "enabled"),
+	},
+	operationKey(http.MethodPost, "/api/admin/suspicious-activities/:id/dismiss"): {Summary: "問題なしと判断（保留中の送金は実行する。commentは任意）"},
+	operationKey(http.MethodPost, "/api/admin/suspicious-activities/:id/confirm"): {Summary: "不正と判断（保留中の送金は取り消す。commentは任意）"},
+	operationKey(http.MethodGet, "/api/admin/transfer-eligibility"):               {Summary: "送金できるユーザーの条件（メール認証・アカウント作成からの時間・受け取る相手の既定）"},
+	operationKey(http.MethodPut, "/api/admin/transfer-eligibility"): {
+		Summary: "送金できるユーザーの条件を設定（送金・送金リクエストの作成に適用）",
+		RequestBody: object(map[string]*Schema{
+			"require_email_verification":    {Type: "boolean"},
+			"min_account_age_hours":         integer(0, false),
+			"default_accept_transfers_from": enum("", "anyone", "friends_only"),
+		}),
+	},
+	operationKey(http.MethodGet, "/api/admin/transfer-policy"): {Summary: "送金額の上下限と手数料"},
+	operationKey(http.MethodPut, "/api/admin/transfer-policy"): {
+		Summary: "送金額の上下限と手数料を設定（0で無効。手数料は受け取り用アカウントへ送る）",
+		RequestBody: object(map[string]*Schema{
+			"min_amount":            integer(0, false),
+			"max_amount":            integer(0, false),
+			"fee_type":              enum("none", "flat", "percentage"),
+			"fee_flat":              integer(0, false),
+			"fee_rate_basis_points": integer(0, false),
+			"fee_account_id":        nullable(uuidString()),
+		}),
+	},
+	operationKey(http.MethodGet, "/api/admin/transfer-request-quota"): {Summary: "送信者ごとの承認待ちの送金リクエストの上限（未設定なら全体20件・同じ受取人宛5件）"},
+	operationKey(http.MethodPut, "/api/admin/transfer-request-quota"): {
+		Summary: "送信者ごとの承認待ちの送金リクエストの上限を設定（0で無効。期限切れと割り勘の1人分は数えない）",
+		RequestBody: object(map[string]*Schema{
+			"max_pending_per_sender":    integer(0, false),
+			"max_pending_per_recipient": integer(0, false),
+		}, "max_pending_per_sender", "max_pending_per_recipient"),
+	},
+	operationKey(http.MethodPost, "/api/admin/receipts/verify"): {
+		Summary: "提示された取引の控えの検証ハッシュが本物かを確認（現在の控えの内容も返す）",
+		RequestBody: object(map[string]*Schema{
+			"transaction_id": uuidString(),
+			"hash":           str(1, 128),
+		}, "transaction_id", "hash"),
+	},
+	operationKey(http.MethodGet, "/api/admin/onboarding-bonus"): {Summary: "登録時のウェルカムボーナスの設定"},
+	operationKey(http.MethodPut, "/api/admin/onboarding-bonus"): {
+		Summary: "登録時のウェルカムボーナスを設定（以降の登録から反映。validity_daysが0なら既定の有効期間）",
+		RequestBody: object(map[string]*Schema{
+			"enabled":       {Type: "boolean"},
+			"amount":        integer(0, false),
+			"validity_days": integer(0, false),
+		}, "enabled", "amount"),
+	},
+	operationKey(http.MethodGet, "/api/admin/email-verification-requirement"): {Summary: "新規登録ユーザーにメール認証を求める設定"},
+	operationKey(http.MethodPut, "/api/admin/email-verification-requirement"): {
+		Summary:     "新規登録ユーザーにメール認証を求める設定（有効にした後の登録から反映）",
+		RequestBody: object(map[string]*Schema{"stage": enum("off", "before_login", "before_transfer")}, "stage"),
+	},
+	operationKey(http.MethodPost, "/api/admin/akerun/repoll"): {
+		Summary: "ポーリングできなかった期間（過去の7日以内）の入退室記録の再取得を依頼（202。処理待ち・実行中の依頼があれば409）",
+		RequestBody: object(map[string]*Schema{
+			"organization_id": str(0, 100),
+			"from":            dateTime(),
+			"to":              dateTime(),
+		}, "from", "to"),
+	},
+	operationKey(http.MethodGet, "/api/admin/akerun/repoll"):        {Summary: "再取得の依頼の一覧（新しい順）"},
+	operationKey(http.MethodGet, "/api/admin/email-templates"):      {Summary: "メールの種類ごとの現在のテンプレート・使える変数（編集していなければ組み込みの既定）"},
+	operationKey(http.MethodGet, "/api/admin/email-templates/:key"): {Summary: "メールテンプレートの現在の版・組み込みの既定・過去の版（新しい順）"},
+	operationKey(http.MethodPut, "/api/admin/email-templates/:key"): {
+		Summary: "メールテンプレートを新しい版として保存（Goのテンプレート。定義されていない変数を使うと400）",
+		RequestBody: object(map[string]*Schema{
+			"subject":   str(1, 200),
+			"html_body": str(0, 20000),
+			"text_body": str(1, 20000),
+		}, "subject", "text_body"),
+	},
+	operationKey(http.MethodDelete, "/api/admin/email-templates/:key"):                         {Summary: "メールテンプレートのすべての版を削除して組み込みの既定に戻す"},
+	operationKey(http.MethodPost, "/api/admin/email-templates/:key/versions/:version/restore"): {Summary: "過去の版の内容を新しい版として保存"},
+	operationKey(http.MethodPost, "/api/admin/email-templates/:key/preview"):                   {Summary: "例の変数で描画（subject・html_body・text_bodyを送ると保存前の下書き、variablesで例を上書き）"},
+	operationKey(http.MethodPost, "/api/admin/email-templates/:key/test"):                      {Summary: "例の変数で描画して管理者自身のアドレスへ送信（ボディを送ると保存前の下書き）"},
+	operationKey(http.MethodGet, "/api/admin/dashboard"):                                       {Summary: "オンラインのユーザー数・直近1時間の送金数・承認待ちの送金リクエスト・失敗したワーカー・7日以内の失効予定・DBの状態（5秒間キャッシュ）"},
+	operationKey(http.MethodGet, "/api/admin/akerun/repoll/:id"):                               {Summary: "再取得の依頼の状態と進み具合（取得済みの期間・件数）"},
+	operationKey(http.MethodGet, "/api/admin/jobs"):                                            {Summary: "定期実行ジョブのスケジュール・次回実行日時・直近の実行結果"},
+	operationKey(http.MethodGet, "/api/admin/workers"):                                         {Summary: "バックグラウンドワーカーごとのリーダーのインスタンスと交代回数"},
+	operationKey(http.MethodGet, "/api/admin/config"):                                          {Summary: "起動時に読み込んだ設定と取得元（設定ファイル・環境変数・シークレット。秘密の値は伏せる）"},
+	operationKey(http.MethodGet, "/api/admin/db-stats"):                                        {Summary: "このインスタンスのDBのコネクションプールの状態（プライマリ・リードレプリカごとの接続数・待ち回数・待ち時間）"},
+	operationKey(http.MethodGet, "/api/admin/akerun/failed-accesses"):                          {Summary: "ボーナスの付与に失敗した入退室記録（既定は再試行を止めたもの。status=pending/allで絞り込み）"},
+	operationKey(http.MethodPost, "/api/admin/akerun/failed-accesses/:id/requeue"):             {Summary: "再試行を止めた入退室記録を再試行待ちに戻す（次のポーリングで再処理）"},
+	operationKey(http.MethodGet, "/api/admin/daily-bonuses/export"):                            {Summary: "ボーナス日がfrom〜to（YYYY-MM-DD、366日以内）のボーナスをCSVで出力（ユーザー・日付・ポイント・ティア・ドア・入退室日時）"},
+	operationKey(http.MethodGet, "/api/admin/daily-bonuses/attendance"):                        {Summary: "month（YYYY-MM）のユーザーごと・日ごとの出席表（ボーナスを受け取った日のポイント、欠席はnull）"},
+	operationKey(http.MethodGet, "/api/admin/archive/transactions"):                            {Summary: "保持期間を過ぎて移した取引の検索（date_from・date_toは必須で366日以内）"},
+	operationKey(http.MethodGet, "/api/admin/archive/summaries"):                               {Summary: "移した取引の月・種別・状態ごとの件数とポイントの合計"},
+	operationKey(http.MethodGet, "/api/admin/weekly-digest/preview"):                           {Summary: "ユーザーに今送る週次のまとめメールを送らずに表示（user_idは必須）"},
+	operationKey(http.MethodGet, "/api/super-admin/tenants"):                                   {Summary: "テナント一覧（スーパー管理者のみ）"},
+	operationKey(http.MethodPost, "/api/super-admin/tenants"): {
+		Summary: "テナントと最初の管理者を作成（管理者の仮パスワードを返す）",
+		RequestBody: object(map[string]*Schema{
+			"slug":             str(2, 40),
+			"name":             str(1, 100),
+			"admin_username":   str(3, 255),
+			"admin_email":      email(),
+			"admin_first_name": str(1, 100),
+			"admin_last_name":  str(1, 100),
+		}, "slug", "name", "admin_username", "admin_email", "admin_first_name", "admin_last_name"),
+	},
+	operationKey(http.MethodPut, "/api/super-admin/tenants/:id"): {
+		Summary: "テナント名の変更・停止（停止したテナントへのリクエストはすべて拒否する）",
+		RequestBody: object(map[string]*Schema{
+			"name":      str(1, 100),
+			"is_active": {Type: "boolean"},
+		}, "name", "is_active"),
+	},
+}
+
+func departmentBody() *Schema {
+	return object(map[string]*Schema{
+		"name":                 str(1, 100),
+		"parent_id":            nullable(uuidString()),
+		"monthly_grant_budget": nullable(integer(0, false)),
+	}, "name")
+}
+
+func adminGrantBody() *Schema {
+	body := adminPointsBody()
+	body.Properties["budget_override"] = &Schema{Type: "boolean"}
+	return body
+}
+
+func adminPointsBody() *Schema {
+	return object(map[string]*Schema{
+		"user_id":         uuidString(),
+		"amount":          integer(0, true),
+		"description":     str(1, 0),
+		"reason_code":     str(1, 32),
+		"tag":             str(0, 50),
+		"idempotency_key": str(1, 0),
+		"dry_run":         {Type: "boolean"},
+	}, "user_id", "amount", "description", "reason_code", "idempotency_key")
+}
+
+func announcementBody() *Schema {
+	return object(map[string]*Schema{
+		"title":       str(1, 100),
+		"body":        str(1, 2000),
+		"severity":    enum("info", "warning", "critical"),
+		"audience":    enum("all", "admins", "role"),
+		"target_role": enum("user", "admin"),
+		"starts_at":   dateTime(),
+		"ends_at":     nullable(dateTime()),
+	}, "title", "body", "severity", "audience")
+}
+
+func pricingRuleBody() *Schema {
+	return object(map[string]*Schema{
+		"name":                  str(1, 100),
+		"product_id":            nullable(uuidString()),
+		"category_code":         str(0, 50),
+		"discount_type":         enum("percentage", "fixed"),
+		"discount_value":        integer(0, true),
+		"target_role":           enum("user", "admin"),
+		"min_tier":              enum("bronze", "silver", "gold"),
+		"max_quantity_per_user": integer(0, false),
+		"starts_at":             dateTime(),
+		"ends_at":               nullable(dateTime()),
+		"is_active":             {Type: "boolean"},
+	}, "name", "discount_type", "discount_value")
+}
+
+func eventBody() *Schema {
+	return object(map[string]*Schema{
+		"name":        str(1, 100),
+		"description": str(0, 500),
+		"points":      integer(0, true),
+		"capacity":    integer(0, false),
+		"starts_at":   dateTime(),
+		"ends_at":     dateTime(),
+		"is_active":   {Type: "boolean"},
+	}, "name", "points", "ends_at")
+}
+
+func earningRuleBody() *Schema {
+	return object(map[string]*Schema{
+		"name":                str(1, 100),
+		"trigger":             enum("profile_completed", "friend_added", "transfer_sent"),
+		"points":              integer(0, true),
+		"occurrence":          integer(0, false),
+		"min_amount":          integer(0, false),
+		"once_per_user":       {Type: "boolean"},
+		"max_grants_per_user": integer(0, false),
+		"max_grants_total":    integer(0, false),
+		"starts_at":           dateTime(),
+		"ends_at":             nullable(dateTime()),
+		"is_active":           {Type: "boolean"},
+	}, "name", "trigger", "points")
+}
+
+func kioskDeviceBody() *Schema {
+	return object(map[string]*Schema{
+		"name":         str(1, 0),
+		"bonus_amount": integer(0, true),
+		"daily_limit":  integer(0, false),
+		"is_active":    {Type: "boolean"},
+	}, "name", "bonus_amount")
+}
+
+// RequestBodySchema はルートのリクエストボディのスキーマを返す（未定義ならnil）
+// pathはginの形式（c.FullPath()）で指定する
+func RequestBodySchema(method, path string) *Schema {
+	spec, ok := lookupOperation(method, path)
+	if !ok {
+		return nil
+	}
+	return spec.RequestBody
+}
+
+// Build は登録済みのルートからOpenAPIドキュメントを生成
+func Build(title, version string, routes []Route) *Document {
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: title, Version: version},
+		Paths:   map[string]*PathItem{},
+		Components: Components{
+			SecuritySchemes: map[string]*SecurityScheme{
+				"sessionCookie": {Type: "apiKey", In: "cookie", Name: "session_token"},
+				"csrfToken":     {Type: "apiKey", In: "header", Name: "X-CSRF-Token"},
+				"kioskKey":      {Type: "apiKey", In: "header", Name: "X-Kiosk-Key"},
+			},
+			Schemas: map[string]*Schema{"Problem": problemSchema},
+		},
+	}
+
+	// 同じ入力からは常に同じドキュメントになるようパス順に処理
+	sorted := make([]Route, len(routes))
+	copy(sorted, routes)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+
+	for _, route := range sorted {
+		if !strings.HasPrefix(route.Path, "/api/") {
+			continue
+		}
+		op := buildOperation(route)
+		path, _ := convertPath(route.Path)
+
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+		switch route.Method {
+		case http.MethodGet:
+			item.Get = op
+		case http.MethodPost:
+			item.Post = op
+		case http.MethodPut:
+			item.Put = op
+		case http.MethodPatch:
+			item.Patch = op
+		case http.MethodDelete:
+			item.Delete = op
+		}
+	}
+
+	return doc
+}
+
+func buildOperation(route Route) *Operation {
+	spec, ok := lookupOperation(route.Method, route.Path)
+	if !ok {
+		spec = &operationSpec{}
+	}
+
+	path, params := convertPath(route.Path)
+	op := &Operation{
+		OperationID: operationID(route.Method, path),
+		Summary:     spec.Summary,
+		Tags:        []string{tagOf(route.Path)},
+		Deprecated:  route.Deprecated,
+		Responses: map[string]*Response{
+			"200": {Description: "成功"},
+			"400": problemResponse("リクエストが不正"),
+			"500": problemResponse("サーバーエラー"),
+		},
+	}
+	if op.Summary == "" {
+		op.Summary = handlerSummary(route.Handler)
+	}
+
+	for _, name := range params {
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+
+	if spec.RequestBody != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{
+				"application/json": {Schema: spec.RequestBody},
+			},
+		}
+	}
+
+	auth := spec.Auth
+	if auth == "" {
+		auth = authSession
+		if strings.HasPrefix(CanonicalPath(route.Path), "/api/kiosk/") {
+			auth = authKiosk
+		}
+	}
+	switch auth {
+	case authNone:
+		op.Security = []map[string][]string{}
+	case authKiosk:
+		op.Security = []map[string][]string{{"kioskKey": {}}}
+		op.Responses["401"] = problemResponse("端末の認証に失敗")
+	default:
+		requirement := map[string][]string{"sessionCookie": {}}
+		if route.Method != http.MethodGet {
+			requirement["csrfToken"] = []string{}
+		}
+		op.Security = []map[string][]string{requirement}
+		op.Responses["401"] = problemResponse("未ログイン")
+	}
+
+	return op
+}
+
+// convertPath はginのパス（/users/:id）をOpenAPIの形式（/users/{id}）に変換し、パラメータ名を返す
+func convertPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	var params []string
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			name := seg[1:]
+			params = append(params, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operationID は "post_api_users_id_role" のようなIDを作る
+func operationID(method, path string) string {
+	replacer := strings.NewReplacer("/", "_", "-", "_", "{", "", "}", "")
+	return strings.ToLower(method) + strings.TrimSuffix(replacer.Replace(path), "_")
+}
+
+// tagOf はパスの先頭セグメント（/api/v1/admin/... なら admin）をタグにする
+func tagOf(path string) string {
+	segments := strings.Split(strings.TrimPrefix(CanonicalPath(path), "/api/"), "/")
+	return segments[0]
+}
+
+// handlerSummary はハンドラー名（.../web.(*FriendController).GetFriends-fm）から要約を作る
+func handlerSummary(handler string) string {
+	name := handler[strings.LastIndex(handler, "/")+1:]
+	name = strings.TrimSuffix(name, "-fm")
+	name = strings.NewReplacer("(*", "", ")", "").Replace(name)
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// shippingAddressSchema はお届け先の登録・変更のリクエストボディ（郵便番号・都道府県・電話番号の形式はサーバーで検証）
+func shippingAddressSchema() *Schema {
+	return object(map[string]*Schema{
+		"label":          str(0, 100),
+		"recipient_name": str(1, 100),
+		"postal_code":    str(7, 8),
+		"prefecture":     str(1, 10),
+		"city":           str(1, 100),
+		"line1":          str(1, 100),
+		"line2":          str(0, 100),
+		"phone":          str(10, 20),
+		"is_default":     {Type: "boolean"},
+	}, "recipient_name", "postal_code", "prefecture", "city", "line1", "phone")
+}
+
+func object(properties map[string]*Schema, required ...string) *Schema {
+	return &Schema{Type: "object", Properties: properties, Required: required}
+}
+
+// str は文字列スキーマ（0は制約なし）
+func str(minLength, maxLength int) *Schema {
+	s := &Schema{Type: "string"}
+	if minLength > 0 {
+		s.MinLength = &minLength
+	}
+	if maxLength > 0 {
+		s.MaxLength = &maxLength
+	}
+	return s
+}
+
+func email() *Schema {
+	return &Schema{Type: "string", Format: "email"}
+}
+
+func uuidString() *Schema {
+	return &Schema{Type: "string", Format: "uuid"}
+}
+
+func dateTime() *Schema {
+	return &Schema{Type: "string", Format: "date-time"}
+}
+
+func enum(values ...string) *Schema {
+	return &Schema{Type: "string", Enum: values}
+}
+
+// integer は下限付きの整数スキーマ（exclusiveがtrueならminimumより大きい値のみ許可）
+func integer(minimum float64, exclusive bool) *Schema {
+	return &Schema{Type: "integer", Minimum: &minimum, ExclusiveMinimum: exclusive}
+}
+
+func number(minimum, maximum float64) *Schema {
+	return &Schema{Type: "number", Minimum: &minimum, Maximum: &maximum}
+}
+
+func nullable(s *Schema) *Schema {
+	s.Nullable = true
+	return s
+}
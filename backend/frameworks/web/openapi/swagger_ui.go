@@ -0,0 +1,26 @@
+package openapi
+
+import "fmt"
+
+// swaggerUIVersion はCDNから読み込むswagger-ui-distのバージョン
+const swaggerUIVersion = "5.17.14"
+
+// SwaggerUIHTML はspecURLのドキュメントを表示するSwagger UIのHTMLを返す
+func SwaggerUIHTML(specURL string) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="ja">
+<head>
+  <meta charset="utf-8">
+  <title>API Docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@%[1]s/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@%[1]s/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: %[2]q, dom_id: "#swagger-ui", withCredentials: true });
+  </script>
+</body>
+</html>
+`, swaggerUIVersion, specURL)
+}
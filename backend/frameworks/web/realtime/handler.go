@@ -0,0 +1,175 @@
+package realtime
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// writeTimeout は1メッセージの送信にかけられる時間
+	writeTimeout = 10 * time.Second
+	// heartbeatInterval は無通信の接続がプロキシに切られないよう送るハートビートの間隔
+	heartbeatInterval = 30 * time.Second
+	// maxClientMessageSize はクライアントから受け付けるメッセージの最大サイズ（内容は使わない）
+	maxClientMessageSize = 1024
+)
+
+// 配信イベント以外にサーバーが送るメッセージの種類
+const (
+	messageTypeConnected = "connected"
+	messageTypeHeartbeat = "heartbeat"
+)
+
+// message はクライアントへ送るJSONメッセージ
+type message struct {
+	Topic      entities.RealtimeTopic `json:"topic,omitempty"`
+	Type       string                 `json:"type"`
+	Data       map[string]interface{} `json:"data,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
+// Handler はWebSocket接続を受け付けるハンドラーを返す（認証ミドルウェアの後に置く）
+// GET /api/ws?topics=qr （topics省略時はすべてのトピックを購読）
+// ブラウザはWebSocketのハンドシェイクにCORSを適用しないため、Originを許可リストで検証する
+func (h *Hub) Handler(allowedOrigins []string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, exists := ctx.Get("user_id")
+		if !exists {
+			presenter.RenderError(ctx, http.StatusUnauthorized, entities.ErrUnauthorized)
+			return
+		}
+
+		topics, err := parseTopics(ctx.Query("topics"))
+		if err != nil {
+			presenter.RenderError(ctx, http.StatusBadRequest, entities.NewValidationError(entities.FieldError{Field: "topics", Message: err.Error()}))
+			return
+		}
+
+		c := &client{
+			userID: userID.(uuid.UUID),
+			topics: topics,
+			send:   make(chan *entities.RealtimeEvent, sendBufferSize),
+		}
+		if !h.register(c) {
+			presenter.RenderError(ctx, http.StatusTooManyRequests, entities.ErrTooManyConnections)
+			return
+		}
+		defer h.unregister(c)
+
+		server := websocket.Server{
+			Handshake: func(_ *websocket.Config, req *http.Request) error {
+				if !isAllowedOrigin(req.Header.Get("Origin"), allowedOrigins) {
+					return errors.New("origin not allowed")
+				}
+				return nil
+			},
+			Handler: func(conn *websocket.Conn) {
+				h.serve(conn, c)
+			},
+		}
+		server.ServeHTTP(ctx.Writer, ctx.Request)
+	}
+}
+
+// serve は接続が閉じるまでイベントとハートビートを送り続ける
+func (h *Hub) serve(conn *websocket.Conn, c *client) {
+	defer conn.Close()
+	conn.MaxPayloadBytes = maxClientMessageSize
+
+	// クライアントからのメッセージは読み捨て、切断の検知にだけ使う
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var discard string
+		for {
+			if err := websocket.Message.Receive(conn, &discard); err != nil {
+				return
+			}
+		}
+	}()
+
+	topics := make([]entities.RealtimeTopic, 0, len(c.topics))
+	for topic := range c.topics {
+		topics = append(topics, topic)
+	}
+	if err := write(conn, &message{
+		Type:       messageTypeConnected,
+		Data:       map[string]interface{}{"topics": topics},
+		OccurredAt: time.Now(),
+	}); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event := <-c.send:
+			if err := write(conn, &message{
+				Topic:      event.Topic,
+				Type:       string(event.Type),
+				Data:       event.Data,
+				OccurredAt: event.OccurredAt,
+			}); err != nil {
+				h.logger.Debug("Realtime connection closed while sending",
+					entities.NewField("user_id", c.userID),
+					entities.NewField("error", err))
+				return
+			}
+		case <-ticker.C:
+			if err := write(conn, &message{Type: messageTypeHeartbeat, OccurredAt: time.Now()}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// write はタイムアウト付きでメッセージを送る
+func write(conn *websocket.Conn, msg *message) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	return websocket.JSON.Send(conn, msg)
+}
+
+// parseTopics はカンマ区切りのトピック指定を解析する（空なら全トピック）
+func parseTopics(raw string) (map[entities.RealtimeTopic]bool, error) {
+	topics := make(map[entities.RealtimeTopic]bool)
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		topic := entities.RealtimeTopic(t)
+		if !entities.IsKnownRealtimeTopic(topic) {
+			return nil, errors.New("unknown topic: " + t)
+		}
+		topics[topic] = true
+	}
+	return topics, nil
+}
+
+// isAllowedOrigin はハンドシェイクのOriginが許可されているかを判定
+// Originを送らないクライアント（モバイルアプリなど）はセッショントークンの認証のみで許可する
+func isAllowedOrigin(origin string, allowedOrigins []string) bool {
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range allowedOrigins {
+		if allowed == "*" || strings.EqualFold(origin, allowed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+const (
+	// sendBufferSize は1接続あたりの未送信イベントの上限（超えた分は破棄する）
+	sendBufferSize = 16
+	// maxConnectionsPerUser は1ユーザーが同時に張れる接続数の上限
+	maxConnectionsPerUser = 5
+)
+
+// client はWebSocket接続1本分の購読状態
+type client struct {
+	userID uuid.UUID
+	topics map[entities.RealtimeTopic]bool // 空なら全トピックを購読
+	send   chan *entities.RealtimeEvent
+}
+
+// subscribes はイベントのトピックを購読しているかを判定
+func (c *client) subscribes(topic entities.RealtimeTopic) bool {
+	return len(c.topics) == 0 || c.topics[topic]
+}
+
+// Hub はユーザーごとのWebSocket接続を管理し、イベントを配信する
+// service.RealtimeNotifier の実装で、同じユーザーが複数端末で接続していれば全端末へ送る
+// 接続はプロセス内で管理するため、複数台構成では接続しているサーバーのイベントのみ届く
+type Hub struct {
+	mu      sync.RWMutex
+	clients map[uuid.UUID]map[*client]struct{}
+	logger  entities.Logger
+}
+
+// NewHub は新しいHubを作成
+func NewHub(logger entities.Logger) *Hub {
+	return &Hub{
+		clients: make(map[uuid.UUID]map[*client]struct{}),
+		logger:  logger,
+	}
+}
+
+// Notify はユーザーの接続中の端末へイベントを送る
+// 送信待ちが溜まっている遅い接続にはイベントを破棄し、呼び出し側を待たせない
+func (h *Hub) Notify(ctx context.Context, userID uuid.UUID, event *entities.RealtimeEvent) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for c := range h.clients[userID] {
+		if !c.subscribes(event.Topic) {
+			continue
+		}
+		select {
+		case c.send <- event:
+		default:
+			h.logger.Warn("Realtime event dropped: send buffer full",
+				entities.NewField("user_id", userID),
+				entities.NewField("event_type", event.Type))
+		}
+	}
+}
+
+// ConnectionCount は接続中のWebSocket数を返す
+func (h *Hub) ConnectionCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	count := 0
+	for _, conns := range h.clients {
+		count += len(conns)
+	}
+	return count
+}
+
+// register は接続を登録する（接続数の上限に達していればfalse）
+func (h *Hub) register(c *client) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	conns := h.clients[c.userID]
+	if len(conns) >= maxConnectionsPerUser {
+		return false
+	}
+	if conns == nil {
+		conns = make(map[*client]struct{})
+		h.clients[c.userID] = conns
+	}
+	conns[c] = struct{}{}
+	return true
+}
+
+// unregister は接続を削除する
+func (h *Hub) unregister(c *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	conns := h.clients[c.userID]
+	delete(conns, c)
+	if len(conns) == 0 {
+		delete(h.clients, c.userID)
+	}
+}
@@ -0,0 +1,99 @@
+package web
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web"
+	"github.com/gity/point-system/frameworks/web/middleware"
+)
+
+// ルートグループの名前（RequestTimeoutsのキー）
+const (
+	RouteGroupPublic        = "public"
+	RouteGroupKiosk         = "kiosk"
+	RouteGroupAuthenticated = "authenticated"
+	RouteGroupSession       = "session"
+	RouteGroupProtected     = "protected"
+	RouteGroupAdmin         = "admin"
+)
+
+// RequestTimeouts はルートグループごとのリクエストの期限
+type RequestTimeouts struct {
+	Default time.Duration            // グループの指定がなければ使う（0なら期限なし）
+	Groups  map[string]time.Duration // ルートグループの名前ごとの期限（0なら期限なし）
+}
+
+// For はグループの期限（管理APIは指定がなければProtectedと同じ）
+func (t RequestTimeouts) For(group string) time.Duration {
+	if d, ok := t.Groups[group]; ok {
+		return d
+	}
+	if group == RouteGroupAdmin {
+		return t.For(RouteGroupProtected)
+	}
+	return t.Default
+}
+
+// GroupMiddlewares はルートグループごとのミドルウェア（記載順に実行する）
+// グループの意味は web.RouteGroups を参照
+type GroupMiddlewares struct {
+	Public        []gin.HandlerFunc
+	Kiosk         []gin.HandlerFunc
+	Authenticated []gin.HandlerFunc
+	Session       []gin.HandlerFunc
+	Protected     []gin.HandlerFunc
+	Admin         []gin.HandlerFunc // Protectedのミドルウェアの後に実行する（管理者以外は403）
+}
+
+// Groups はグループごとのミドルウェアを組み立てる
+// 期限は認証などのDBへの問い合わせも含めるため最初に付け、リクエストボディのスキーマ検証は認証・CSRFチェックの後に実行する
+func (m *Middlewares) Groups(timeouts RequestTimeouts) GroupMiddlewares {
+	requestValidation := middleware.RequestValidationMiddleware()
+	timeout := func(group string) gin.HandlerFunc {
+		return middleware.RequestTimeoutMiddleware(timeouts.For(group))
+	}
+	groups := GroupMiddlewares{
+		Public: []gin.HandlerFunc{timeout(RouteGroupPublic), requestValidation},
+		Kiosk: []gin.HandlerFunc{
+			timeout(RouteGroupKiosk),
+			m.Kiosk.Authenticate(),
+			m.Maintenance.Handle(),
+			requestValidation,
+			m.Idempotency.Handle(),
+		},
+		Authenticated: []gin.HandlerFunc{timeout(RouteGroupAuthenticated), m.Auth.Authenticate()},
+		Session:       []gin.HandlerFunc{timeout(RouteGroupSession), m.Auth.Authenticate(), m.CSRF.Protect()},
+		Protected: []gin.HandlerFunc{
+			timeout(RouteGroupProtected),
+			m.Auth.Authenticate(),
+			m.CSRF.Protect(),
+			m.Maintenance.Handle(),
+			requestValidation,
+			m.Idempotency.Handle(),
+		},
+	}
+	// 管理APIの期限を指定した場合だけ、Protectedの期限を置き換える
+	if _, ok := timeouts.Groups[RouteGroupAdmin]; ok {
+		groups.Admin = append(groups.Admin, timeout(RouteGroupAdmin))
+	}
+	groups.Admin = append(groups.Admin, m.Authorization.RequireAdmin())
+	return groups
+}
+
+// newRouteGroups はAPIバージョンのグループ配下にルートグループを作成
+func (r *Router) newRouteGroups(api *gin.RouterGroup, mws *Middlewares) *web.RouteGroups {
+	groups := mws.Groups(r.requestTimeouts)
+	protected := api.Group("", groups.Protected...)
+	return &web.RouteGroups{
+		Public:        api.Group("", groups.Public...),
+		Kiosk:         api.Group("/kiosk", groups.Kiosk...),
+		Authenticated: api.Group("", groups.Authenticated...),
+		Session:       api.Group("", groups.Session...),
+		Protected:     protected,
+		Admin:         protected.Group("/admin", groups.Admin...),
+		Maintenance:   mws.Maintenance.Handle(),
+		Now:           r.timeProvider.Now,
+		Authorize:     mws.Authorization.Authorize,
+	}
+}
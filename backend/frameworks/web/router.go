@@ -1,12 +1,17 @@
 package web
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/gity/point-system/controllers/web"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/frameworks/web/middleware"
+	"github.com/gity/point-system/frameworks/web/openapi"
+	"github.com/gity/point-system/usecases/service"
 )
 
 // RouterConfig はルーター設定
@@ -14,12 +19,23 @@ type RouterConfig struct {
 	Env             string
 	AllowedOrigins  []string
 	MaxUploadSizeMB int // アップロードファイルの最大サイズ（MB）
+
+	// DeprecatedAPIVersions は廃止予定のAPIバージョンと提供終了日時（ゼロ値なら未定）
+	DeprecatedAPIVersions map[string]time.Time
+
+	// RequestTimeouts はルートグループごとのリクエストの期限（WebSocketは対象外）
+	RequestTimeouts RequestTimeouts
 }
 
 // Router はHTTPルーター
 type Router struct {
-	engine       *gin.Engine
-	timeProvider TimeProvider
+	engine             *gin.Engine
+	allowedOrigins     []string
+	timeProvider       TimeProvider
+	deprecatedVersions map[string]time.Time
+	requestTimeouts    RequestTimeouts
+	circuitBreakers    service.CircuitBreakerMonitor
+	mounts             []mountedVersion
 }
 
 // NewRouter は新しいRouterを作成
@@ -29,17 +45,23 @@ func NewRouter(cfg *RouterConfig, timeProvider TimeProvider) *Router {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	engine := gin.Default()
-
+	// パニックと記録だけされたエラーもproblem+jsonで返す（gin標準のリカバリーの代わり）
+	engine := gin.New()
+	// コントローラーはgin.Contextをそのままユースケースに渡すため、ミドルウェアがリクエストのcontextに入れた値（テナントなど）も参照できるようにする
+	engine.ContextWithFallback = true
+	engine.Use(gin.Logger(), middleware.RecoveryMiddleware(), middleware.ErrorHandlerMiddleware())
+	engine.NoRoute(func(c *gin.Context) {
+		presenter.RenderError(c, http.StatusNotFound, entities.ErrRouteNotFound)
+	})
 	// マルチパートフォームのメモリ制限（アバターアップロード用）
 	engine.MaxMultipartMemory = 32 << 20 // 32MB
 
 	// CORS設定
 	corsConfig := cors.Config{
 		AllowOrigins:     cfg.AllowedOrigins,
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "X-CSRF-Token"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "X-CSRF-Token", "X-Kiosk-Key", "Idempotency-Key", middleware.TenantHeader},
+		ExposeHeaders:    []string{"Content-Length", "X-API-Version", "Deprecation", "Sunset", "Link", "Idempotent-Replayed", "Retry-After", middleware.CSRFTokenHeader},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}
@@ -61,210 +83,116 @@ func NewRouter(cfg *RouterConfig, timeProvider TimeProvider) *Router {
 	// 音声ファイルの静的ファイル配信
 	engine.Static("/public", "./public")
 
+	r := &Router{
+		engine:             engine,
+		allowedOrigins:     cfg.AllowedOrigins,
+		timeProvider:       timeProvider,
+		deprecatedVersions: cfg.DeprecatedAPIVersions,
+		requestTimeouts:    cfg.RequestTimeouts,
+	}
+
 	// ヘルスチェック
-	engine.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "ok"})
-	})
+	engine.GET("/health", r.health)
 
-	return &Router{
-		engine:       engine,
-		timeProvider: timeProvider,
-	}
+	return r
 }
 
-// RegisterRoutes はルートを登録
-// HTTP RequestのURLなどを参照し、該当するControllerへRequestを渡す
-func (r *Router) RegisterRoutes(
-	authController *web.AuthController,
-	pointController *web.PointController,
-	friendController *web.FriendController,
-	qrcodeController *web.QRCodeController,
-	transferRequestController *web.TransferRequestController,
-	dailyBonusController *web.DailyBonusController,
-	adminController *web.AdminController,
-	productController *web.ProductController,
-	categoryController *web.CategoryController,
-	userSettingsController *web.UserSettingsController,
-	authMiddleware *middleware.AuthMiddleware,
-	csrfMiddleware *middleware.CSRFMiddleware,
-) {
-	api := r.engine.Group("/api")
-	{
-		// 認証（公開）
-		auth := api.Group("/auth")
-		{
-			auth.POST("/register", func(c *gin.Context) {
-				authController.Register(c, r.timeProvider.Now())
-			})
-			auth.POST("/login", func(c *gin.Context) {
-				authController.Login(c, r.timeProvider.Now())
-			})
-		}
+// WithCircuitBreakers はヘルスチェックで外部サービスのサーキットブレーカーの状態も返すようにする
+func (r *Router) WithCircuitBreakers(monitor service.CircuitBreakerMonitor) *Router {
+	r.circuitBreakers = monitor
+	return r
+}
 
-		// 商品一覧（公開）
-		api.GET("/products", productController.GetProductList)
-
-		// カテゴリ一覧（公開）
-		api.GET("/categories", categoryController.GetCategoryList)
-
-		// 認証が必要なルート（CSRF保護なし）
-		protected := api.Group("")
-		protected.Use(authMiddleware.Authenticate())
-		{
-			// 認証済みユーザー情報取得
-			protected.GET("/auth/me", func(c *gin.Context) {
-				authController.GetCurrentUser(c, r.timeProvider.Now())
-			})
-
-			// プロフィール取得（GET）
-			protected.GET("/settings/profile", userSettingsController.GetProfile)
-
-			// デイリーボーナス（GET - 状態変更なし）
-			dailyBonus := protected.Group("/daily-bonus")
-			{
-				dailyBonus.GET("/today", dailyBonusController.GetTodayBonus)
-				dailyBonus.GET("/recent", dailyBonusController.GetRecentBonuses)
-			}
-		}
+// WithMetrics は /metrics（OpenMetrics）を登録する
+// APIのバージョン・ルートグループとは別に、/health と同じくルートに置く（認証はハンドラーが行う）
+func (r *Router) WithMetrics(handler gin.HandlerFunc) *Router {
+	r.engine.GET("/metrics", handler)
+	return r
+}
 
-		// 認証 + CSRF保護が必要なルート（状態変更あり）
-		protectedAuth := api.Group("/auth")
-		protectedAuth.Use(authMiddleware.Authenticate())
-		protectedAuth.Use(csrfMiddleware.Protect())
-		{
-			protectedAuth.POST("/logout", func(c *gin.Context) {
-				authController.Logout(c, r.timeProvider.Now())
-			})
+// health はヘルスチェック
+// 外部サービスのサーキットブレーカーがopenならstatusをdegradedにする（このサーバー自体は動いているため200のまま）
+func (r *Router) health(c *gin.Context) {
+	if r.circuitBreakers == nil {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+		return
+	}
+
+	status := "ok"
+	breakers := r.circuitBreakers.CircuitBreakers()
+	for _, b := range breakers {
+		if b.State != service.CircuitClosed {
+			status = "degraded"
 		}
+	}
+	c.JSON(http.StatusOK, gin.H{"status": status, "circuit_breakers": breakers})
+}
 
-		// 認証 + CSRF保護が必要なルート
-		protectedWithCSRF := api.Group("")
-		protectedWithCSRF.Use(authMiddleware.Authenticate())
-		protectedWithCSRF.Use(csrfMiddleware.Protect())
-		{
-			// ポイント
-			points := protectedWithCSRF.Group("/points")
-			{
-				// Controllerに時刻情報を渡す
-				points.POST("/transfer", func(c *gin.Context) {
-					pointController.Transfer(c, r.timeProvider.Now())
-				})
-				points.GET("/balance", func(c *gin.Context) {
-					pointController.GetBalance(c, r.timeProvider.Now())
-				})
-				points.GET("/history", func(c *gin.Context) {
-					pointController.GetTransactionHistory(c, r.timeProvider.Now())
-				})
-				points.GET("/expiring", func(c *gin.Context) {
-					pointController.GetExpiringPoints(c, r.timeProvider.Now())
-				})
-			}
-
-			// ユーザー検索・取得
-			protectedWithCSRF.GET("/users/search", friendController.SearchUserByUsername)
-			protectedWithCSRF.GET("/users/:id", friendController.GetUserByID)
-
-			// 友達
-			friends := protectedWithCSRF.Group("/friends")
-			{
-				friends.POST("/requests", friendController.SendFriendRequest)
-				friends.GET("/requests/count", friendController.GetPendingRequestCount)
-				friends.POST("/requests/:id/accept", friendController.AcceptFriendRequest)
-				friends.POST("/requests/:id/reject", friendController.RejectFriendRequest)
-				friends.GET("", friendController.GetFriends)
-				friends.GET("/requests", friendController.GetPendingRequests)
-				friends.DELETE("/:id", friendController.RemoveFriend)
-			}
-
-			// QRコード（旧機能 - 削除予定）
-			qrcodes := protectedWithCSRF.Group("/qrcodes")
-			{
-				qrcodes.POST("/receive", qrcodeController.GenerateReceiveQR)
-				qrcodes.POST("/send", qrcodeController.GenerateSendQR)
-				qrcodes.POST("/scan", qrcodeController.ScanQR)
-				qrcodes.GET("/history", qrcodeController.GetQRCodeHistory)
-			}
-
-			// デイリーボーナス（状態変更あり）
-			dailyBonusWithCSRF := protectedWithCSRF.Group("/daily-bonus")
-			{
-				dailyBonusWithCSRF.POST("/mark-viewed", dailyBonusController.MarkBonusViewed)
-				dailyBonusWithCSRF.POST("/draw", dailyBonusController.DrawLottery)
-			}
-
-			// 送金リクエスト（PayPay風）
-			transferRequests := protectedWithCSRF.Group("/transfer-requests")
-			{
-				transferRequests.GET("/personal-qr", transferRequestController.GetPersonalQRCode)
-				transferRequests.POST("", transferRequestController.CreateTransferRequest)
-				transferRequests.GET("/pending", transferRequestController.GetPendingRequests)
-				transferRequests.GET("/sent", transferRequestController.GetSentRequests)
-				transferRequests.GET("/pending/count", transferRequestController.GetPendingRequestCount)
-				transferRequests.GET("/:id", transferRequestController.GetRequestDetail)
-				transferRequests.POST("/:id/approve", transferRequestController.ApproveTransferRequest)
-				transferRequests.POST("/:id/reject", transferRequestController.RejectTransferRequest)
-				transferRequests.DELETE("/:id", transferRequestController.CancelTransferRequest)
-			}
-
-			// 商品交換（ユーザー）
-			products := protectedWithCSRF.Group("/products")
-			{
-				products.POST("/exchange", productController.ExchangeProduct)
-				products.GET("/exchanges/history", productController.GetExchangeHistory)
-				products.POST("/exchanges/:id/cancel", productController.CancelExchange)
-			}
-
-			// ユーザー設定（状態変更のみ - GETは上のprotectedグループ）
-			settings := protectedWithCSRF.Group("/settings")
-			{
-				settings.PUT("/profile", userSettingsController.UpdateProfile)
-				settings.PUT("/username", userSettingsController.UpdateUsername)
-				settings.PUT("/password", userSettingsController.ChangePassword)
-				settings.POST("/avatar", userSettingsController.UploadAvatar)
-				settings.DELETE("/avatar", userSettingsController.DeleteAvatar)
-				settings.POST("/email/verify", userSettingsController.SendEmailVerification)
-				settings.POST("/email/verify/confirm", userSettingsController.VerifyEmail)
-				settings.DELETE("/account", userSettingsController.ArchiveAccount)
-			}
-
-			// 管理者
-			admin := protectedWithCSRF.Group("/admin")
-			{
-				// ポイント管理
-				admin.POST("/points/grant", adminController.GrantPoints)
-				admin.POST("/points/deduct", adminController.DeductPoints)
-
-				// ユーザー管理
-				admin.GET("/users", adminController.ListAllUsers)
-				admin.PUT("/users/:id/role", adminController.UpdateUserRole)
-				admin.POST("/users/:id/deactivate", adminController.DeactivateUser)
-
-				// トランザクション管理
-				admin.GET("/transactions", adminController.ListAllTransactions)
-
-				// 分析ダッシュボード
-				admin.GET("/analytics", adminController.GetAnalytics)
-
-				// 商品管理
-				admin.POST("/products", productController.CreateProduct)
-				admin.PUT("/products/:id", productController.UpdateProduct)
-				admin.DELETE("/products/:id", productController.DeleteProduct)
-
-				// 商品交換管理
-				admin.GET("/exchanges", productController.GetAllExchanges)
-				admin.POST("/exchanges/:id/deliver", productController.MarkExchangeDelivered)
-
-				// カテゴリ管理
-				admin.POST("/categories", categoryController.CreateCategory)
-				admin.PUT("/categories/:id", categoryController.UpdateCategory)
-				admin.DELETE("/categories/:id", categoryController.DeleteCategory)
-
-				// ボーナス設定（Akerun入退室ボーナス抽選ティア）
-				admin.GET("/bonus-settings", dailyBonusController.GetBonusSettings)
-				admin.PUT("/lottery-tiers", dailyBonusController.UpdateLotteryTiers)
-			}
+// RegisterRoutes はAPIバージョンごとにルートを登録
+// mountsの順に各バージョンのプレフィックス配下へコントローラーのルートをマウントする
+// （バージョンごとにコントローラー・プレゼンターを差し替えられる）
+// 最後のバージョンを最新とし、廃止予定のバージョンには後継としてLinkヘッダーで案内する
+func (r *Router) RegisterRoutes(mounts []VersionMount, mws *Middlewares) {
+	latest := ""
+	if len(mounts) > 0 {
+		latest = mounts[len(mounts)-1].Version.Prefix
+	}
+
+	// アクセスログ（ヘルスチェックはNewRouterで登録済みのため対象外）
+	r.engine.Use(mws.AccessLog.Handle())
+
+	for _, mount := range mounts {
+		var deprecation *middleware.APIDeprecation
+		if sunset, ok := r.deprecatedVersions[mount.Version.Name]; ok {
+			deprecation = &middleware.APIDeprecation{Sunset: sunset, Successor: latest}
 		}
+
+		api := r.engine.Group(mount.Version.Prefix)
+		api.Use(middleware.APIVersionMiddleware(mount.Version.Name, deprecation))
+		// テナントは認証より前に決める（セッション・ユーザーの検索をテナントに絞り込むため）
+		api.Use(mws.Tenant.Handle())
+		r.registerAPIRoutes(api, mount.Registrars, mws)
+
+		r.mounts = append(r.mounts, mountedVersion{APIVersion: mount.Version, deprecated: deprecation != nil})
+	}
+
+	r.registerOpenAPIRoutes()
+}
+
+// registerAPIRoutes は1バージョン分のルートを登録
+// 各コントローラーが自分のルートをアクセス制御ごとのグループへ登録する
+func (r *Router) registerAPIRoutes(api *gin.RouterGroup, registrars []web.RouteRegistrar, mws *Middlewares) {
+	routes := r.newRouteGroups(api, mws)
+	for _, registrar := range registrars {
+		registrar.RegisterRoutes(routes)
+	}
+
+	// リアルタイム通知（WebSocket。QRコードの読み取り・送金完了など）
+	routes.Authenticated.GET("/ws", mws.Realtime.Handler(r.allowedOrigins))
+}
+
+// registerOpenAPIRoutes は登録済みルートからOpenAPIドキュメントを生成し、
+// /api/openapi.json とSwagger UI（/api/docs）を公開する
+// RegisterRoutesの最後に呼び、すべてのルートがドキュメントに含まれるようにする
+func (r *Router) registerOpenAPIRoutes() {
+	routes := make([]openapi.Route, 0, len(r.engine.Routes()))
+	for _, route := range r.engine.Routes() {
+		routes = append(routes, openapi.Route{
+			Method:     route.Method,
+			Path:       route.Path,
+			Handler:    route.Handler,
+			Deprecated: r.isDeprecatedPath(route.Path),
+		})
 	}
+	doc := openapi.Build("Gity Point System API", "1.0.0", routes)
+	docsHTML := openapi.SwaggerUIHTML("/api/openapi.json")
+
+	r.engine.GET("/api/openapi.json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, doc)
+	})
+	r.engine.GET("/api/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(docsHTML))
+	})
 }
 
 // GetEngine はGinエンジンを取得
@@ -0,0 +1,78 @@
+package web
+
+import (
+	"strings"
+
+	"github.com/gity/point-system/controllers/web"
+	"github.com/gity/point-system/frameworks/web/middleware"
+	"github.com/gity/point-system/frameworks/web/realtime"
+)
+
+// APIVersion はAPIバージョンとURLプレフィックス
+type APIVersion struct {
+	Name   string // バージョン名（v1, v2）。廃止予定の設定はこの名前で指定する
+	Prefix string // URLプレフィックス（/api/v1）
+}
+
+var (
+	// APIVersionV1 は現行のAPI
+	APIVersionV1 = APIVersion{Name: "v1", Prefix: "/api/v1"}
+	// APIVersionUnversioned はバージョンなしの旧パス（/api）。中身はv1と同じ
+	// 既存クライアントのために残しており、v1を廃止するときに一緒に廃止される
+	APIVersionUnversioned = APIVersion{Name: "v1", Prefix: "/api"}
+	// APIVersionV2 は互換性のない変更を入れるバージョン
+	APIVersionV2 = APIVersion{Name: "v2", Prefix: "/api/v2"}
+)
+
+// Middlewares はすべてのバージョンで共有するミドルウェア（とWebSocket接続の管理）
+type Middlewares struct {
+	Auth          *middleware.AuthMiddleware
+	CSRF          *middleware.CSRFMiddleware
+	Kiosk         *middleware.KioskAuthMiddleware
+	Idempotency   *middleware.IdempotencyMiddleware
+	Maintenance   *middleware.MaintenanceMiddleware
+	Realtime      *realtime.Hub
+	AccessLog     *middleware.AccessLogMiddleware
+	Tenant        *middleware.TenantMiddleware
+	Authorization *middleware.ResourceAuthorizationMiddleware
+}
+
+// VersionMount はバージョンとそこにマウントするコントローラーの組
+// 複数のバージョンに同じインスタンスをマウントでき、
+// 変更が必要なコントローラーだけプレゼンターを差し替えたものに置き換える（ReplaceRegistrar）
+type VersionMount struct {
+	Version    APIVersion
+	Registrars []web.RouteRegistrar
+}
+
+// ReplaceRegistrar はoldをreplacementに置き換えた一覧を返す（元の一覧は変更しない）
+func ReplaceRegistrar(registrars []web.RouteRegistrar, old, replacement web.RouteRegistrar) []web.RouteRegistrar {
+	replaced := make([]web.RouteRegistrar, len(registrars))
+	for i, registrar := range registrars {
+		if registrar == old {
+			registrar = replacement
+		}
+		replaced[i] = registrar
+	}
+	return replaced
+}
+
+// mountedVersion は登録済みのバージョン（OpenAPIドキュメント生成用）
+type mountedVersion struct {
+	APIVersion
+	deprecated bool
+}
+
+// isDeprecatedPath はパスが廃止予定のバージョンに属するかを返す
+// /api と /api/v1 のようにプレフィックスが重なる場合は最も長く一致するものを採用する
+func (r *Router) isDeprecatedPath(path string) bool {
+	matched := -1
+	deprecated := false
+	for _, m := range r.mounts {
+		if (path == m.Prefix || strings.HasPrefix(path, m.Prefix+"/")) && len(m.Prefix) > matched {
+			matched = len(m.Prefix)
+			deprecated = m.deprecated
+		}
+	}
+	return deprecated
+}
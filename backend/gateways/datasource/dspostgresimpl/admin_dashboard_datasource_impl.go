@@ -0,0 +1,79 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"gorm.io/gorm"
+)
+
+// AdminDashboardDataSource は管理者ダッシュボードの集計のデータソース
+// テナントごとのテーブルを絞り込めるよう、Rawではなくモデルを指定して数える
+type AdminDashboardDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewAdminDashboardDataSource は新しいAdminDashboardDataSourceを作成
+func NewAdminDashboardDataSource(db infrapostgres.DB) *AdminDashboardDataSource {
+	return &AdminDashboardDataSource{db: db}
+}
+
+// SelectCounters はnow時点のカウンターを集計
+func (ds *AdminDashboardDataSource) SelectCounters(ctx context.Context, now time.Time) (*entities.AdminDashboardCounters, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	counters := &entities.AdminDashboardCounters{}
+
+	counts := []struct {
+		dest  *int64
+		query *gorm.DB
+	}{
+		{&counters.OnlineUsers, db.Model(&SessionModel{}).
+			Distinct("user_id").
+			Where("last_active_at >= ? AND expires_at > ?", now.Add(-entities.DashboardOnlineWindow), now)},
+		{&counters.TransfersLastHour, db.Model(&TransactionModel{}).
+			Where("transaction_type = ? AND created_at >= ?", entities.TransactionTypeTransfer, now.Add(-entities.DashboardTransferWindow))},
+		{&counters.PendingTransferRequests, db.Model(&TransferRequestModel{}).
+			Where("status IN ? AND expires_at > ?", []string{
+				string(entities.TransferRequestStatusPending),
+				string(entities.TransferRequestStatusCountered),
+			}, now)},
+		{&counters.FailedScheduledJobs, db.Model(&ScheduledJobModel{}).
+			Where("last_status = ?", entities.ScheduledJobStatusFailed)},
+		{&counters.FailedAkerunRepolls, db.Model(&AkerunRepollModel{}).
+			Where("status = ? AND finished_at >= ?", entities.AkerunRepollStatusFailed, now.Add(-entities.DashboardFailedRepollWindow))},
+		{&counters.DeadAkerunAccesses, db.Model(&FailedAkerunAccessModel{}).
+			Where("status = ?", entities.FailedAkerunAccessDead)},
+	}
+	for _, c := range counts {
+		if err := c.query.Count(c.dest).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	var expiring struct {
+		Amount int64
+		Users  int64
+	}
+	err := db.Model(&PointBatchModel{}).
+		Select("COALESCE(SUM(remaining_amount), 0) AS amount, COUNT(DISTINCT user_id) AS users").
+		Where("remaining_amount > 0 AND expires_at >= ? AND expires_at < ?", now, now.Add(entities.DashboardExpiryWindow)).
+		Scan(&expiring).Error
+	if err != nil {
+		return nil, err
+	}
+	counters.ExpiringPoints = expiring.Amount
+	counters.ExpiringUsers = expiring.Users
+
+	return counters, nil
+}
+
+// Ping はプライマリへ疎通できるか確認
+func (ds *AdminDashboardDataSource) Ping(ctx context.Context) error {
+	sqlDB, err := ds.db.GetDB().DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,154 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AkerunRepollModel はAkerunの再取得の依頼のGORMモデル
+type AkerunRepollModel struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primary_key"`
+	OrganizationID   string     `gorm:"type:text;not null;default:''"`
+	FromTime         time.Time  `gorm:"type:timestamptz;not null"`
+	ToTime           time.Time  `gorm:"type:timestamptz;not null"`
+	Status           string     `gorm:"type:varchar(20);not null"`
+	TotalWindows     int        `gorm:"not null"`
+	CompletedWindows int        `gorm:"not null;default:0"`
+	FetchedAccesses  int        `gorm:"not null;default:0"`
+	ErrorMessage     string     `gorm:"type:text;not null;default:''"`
+	RequestedBy      uuid.UUID  `gorm:"type:uuid;not null"`
+	CreatedAt        time.Time  `gorm:"type:timestamptz;not null;index"`
+	StartedAt        *time.Time `gorm:"type:timestamptz"`
+	FinishedAt       *time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName はテーブル名を指定
+func (AkerunRepollModel) TableName() string {
+	return "akerun_repolls"
+}
+
+// AkerunRepollDataSource はAkerunの再取得の依頼のデータソース
+type AkerunRepollDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewAkerunRepollDataSource は新しいAkerunRepollDataSourceを作成
+func NewAkerunRepollDataSource(db infrapostgres.DB) *AkerunRepollDataSource {
+	return &AkerunRepollDataSource{db: db}
+}
+
+func (ds *AkerunRepollDataSource) toEntity(m *AkerunRepollModel) *entities.AkerunRepoll {
+	return &entities.AkerunRepoll{
+		ID:               m.ID,
+		OrganizationID:   m.OrganizationID,
+		From:             m.FromTime,
+		To:               m.ToTime,
+		Status:           entities.AkerunRepollStatus(m.Status),
+		TotalWindows:     m.TotalWindows,
+		CompletedWindows: m.CompletedWindows,
+		FetchedAccesses:  m.FetchedAccesses,
+		ErrorMessage:     m.ErrorMessage,
+		RequestedBy:      m.RequestedBy,
+		CreatedAt:        m.CreatedAt,
+		StartedAt:        m.StartedAt,
+		FinishedAt:       m.FinishedAt,
+	}
+}
+
+func (ds *AkerunRepollDataSource) toEntities(models []AkerunRepollModel) []*entities.AkerunRepoll {
+	repolls := make([]*entities.AkerunRepoll, len(models))
+	for i := range models {
+		repolls[i] = ds.toEntity(&models[i])
+	}
+	return repolls
+}
+
+// Insert は再取得の依頼を挿入
+func (ds *AkerunRepollDataSource) Insert(ctx context.Context, r *entities.AkerunRepoll) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Create(&AkerunRepollModel{
+		ID:               r.ID,
+		OrganizationID:   r.OrganizationID,
+		FromTime:         r.From,
+		ToTime:           r.To,
+		Status:           string(r.Status),
+		TotalWindows:     r.TotalWindows,
+		CompletedWindows: r.CompletedWindows,
+		FetchedAccesses:  r.FetchedAccesses,
+		ErrorMessage:     r.ErrorMessage,
+		RequestedBy:      r.RequestedBy,
+		CreatedAt:        r.CreatedAt,
+		StartedAt:        r.StartedAt,
+		FinishedAt:       r.FinishedAt,
+	}).Error
+}
+
+// Select はIDで取得
+func (ds *AkerunRepollDataSource) Select(ctx context.Context, id uuid.UUID) (*entities.AkerunRepoll, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m AkerunRepollModel
+	if err := db.Where("id = ?", id).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrAkerunRepollNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// SelectActive は処理待ちまたは取得中の依頼を取得（ない場合はnil）
+func (ds *AkerunRepollDataSource) SelectActive(ctx context.Context) (*entities.AkerunRepoll, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m AkerunRepollModel
+	err := db.Where("status IN ?", []string{string(entities.AkerunRepollStatusPending), string(entities.AkerunRepollStatusRunning)}).
+		Order("created_at ASC").
+		First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// SelectList は新しい順に取得
+func (ds *AkerunRepollDataSource) SelectList(ctx context.Context, offset, limit int) ([]*entities.AkerunRepoll, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var models []AkerunRepollModel
+	if err := db.Order("created_at DESC").Offset(offset).Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return ds.toEntities(models), nil
+}
+
+// Count は件数を取得
+func (ds *AkerunRepollDataSource) Count(ctx context.Context) (int64, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var count int64
+	if err := db.Model(&AkerunRepollModel{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Update は状態と進み具合を更新
+func (ds *AkerunRepollDataSource) Update(ctx context.Context, r *entities.AkerunRepoll) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Model(&AkerunRepollModel{}).
+		Where("id = ?", r.ID).
+		Updates(map[string]interface{}{
+			"status":            string(r.Status),
+			"completed_windows": r.CompletedWindows,
+			"fetched_accesses":  r.FetchedAccesses,
+			"error_message":     r.ErrorMessage,
+			"started_at":        r.StartedAt,
+			"finished_at":       r.FinishedAt,
+		}).Error
+}
@@ -21,7 +21,7 @@ func NewAnalyticsDataSource(db infrapostgres.DB) dsmysql.AnalyticsDataSource {
 
 // GetUserBalanceSummary はアクティブユーザーの残高サマリーを取得
 func (ds *AnalyticsDataSourceImpl) GetUserBalanceSummary(ctx context.Context) (*entities.AnalyticsSummaryResult, error) {
-	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	db := infrapostgres.GetReadDB(ctx, ds.db)
 
 	var result struct {
 		TotalBalance   int64
@@ -30,6 +30,7 @@ func (ds *AnalyticsDataSourceImpl) GetUserBalanceSummary(ctx context.Context) (*
 	}
 
 	err := db.Table("users").
+		Scopes(tenantScope(ctx, "tenant_id")).
 		Select("COALESCE(SUM(balance), 0) as total_balance, COALESCE(AVG(balance), 0) as average_balance, COUNT(*) as active_users").
 		Where("is_active = ?", true).
 		Scan(&result).Error
@@ -46,7 +47,7 @@ func (ds *AnalyticsDataSourceImpl) GetUserBalanceSummary(ctx context.Context) (*
 
 // GetTopHolders はポイント保有上位ユーザーを取得
 func (ds *AnalyticsDataSourceImpl) GetTopHolders(ctx context.Context, limit int) ([]*entities.TopHolderResult, error) {
-	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	db := infrapostgres.GetReadDB(ctx, ds.db)
 
 	var results []struct {
 		ID          string
@@ -56,6 +57,7 @@ func (ds *AnalyticsDataSourceImpl) GetTopHolders(ctx context.Context, limit int)
 	}
 
 	err := db.Table("users").
+		Scopes(tenantScope(ctx, "tenant_id")).
 		Select("id, username, display_name, balance").
 		Where("is_active = ?", true).
 		Order("balance DESC").
@@ -79,7 +81,7 @@ func (ds *AnalyticsDataSourceImpl) GetTopHolders(ctx context.Context, limit int)
 
 // GetDailyStats は日別統計を取得（期間内の全日をゼロ埋めで返す）
 func (ds *AnalyticsDataSourceImpl) GetDailyStats(ctx context.Context, since time.Time) ([]*entities.DailyStatResult, error) {
-	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	db := infrapostgres.GetReadDB(ctx, ds.db)
 
 	var results []struct {
 		Date        time.Time
@@ -89,9 +91,10 @@ func (ds *AnalyticsDataSourceImpl) GetDailyStats(ctx context.Context, since time
 	}
 
 	err := db.Table("transactions").
+		Scopes(tenantScope(ctx, "tenant_id")).
 		Select(`
 			DATE(created_at) as date,
-			COALESCE(SUM(CASE WHEN transaction_type IN ('admin_grant', 'system_grant') THEN amount ELSE 0 END), 0) as issued,
+			COALESCE(SUM(CASE WHEN transaction_type IN ('admin_grant', 'system_grant', 'onboarding_bonus') THEN amount ELSE 0 END), 0) as issued,
 			COALESCE(SUM(CASE WHEN transaction_type IN ('admin_deduct', 'system_expire') THEN amount ELSE 0 END), 0) as consumed,
 			COALESCE(SUM(CASE WHEN transaction_type = 'transfer' THEN amount ELSE 0 END), 0) as transferred
 		`).
@@ -139,7 +142,7 @@ func (ds *AnalyticsDataSourceImpl) GetDailyStats(ctx context.Context, since time
 
 // GetTransactionTypeBreakdown はトランザクション種別構成を取得
 func (ds *AnalyticsDataSourceImpl) GetTransactionTypeBreakdown(ctx context.Context) ([]*entities.TypeBreakdownResult, error) {
-	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	db := infrapostgres.GetReadDB(ctx, ds.db)
 
 	var results []struct {
 		Type        string `gorm:"column:transaction_type"`
@@ -148,6 +151,7 @@ func (ds *AnalyticsDataSourceImpl) GetTransactionTypeBreakdown(ctx context.Conte
 	}
 
 	err := db.Table("transactions").
+		Scopes(tenantScope(ctx, "tenant_id")).
 		Select("transaction_type, COUNT(*) as count, COALESCE(SUM(amount), 0) as total_amount").
 		Where("status = ?", "completed").
 		Group("transaction_type").
@@ -167,9 +171,52 @@ func (ds *AnalyticsDataSourceImpl) GetTransactionTypeBreakdown(ctx context.Conte
 	return breakdowns, nil
 }
 
+// GetReasonCodeBreakdown はsince以降の管理者付与・減算を理由コード・取引種別ごとに集計
+// 理由コード導入前の取引は reason_code が空のまま集計する
+func (ds *AnalyticsDataSourceImpl) GetReasonCodeBreakdown(ctx context.Context, since time.Time, reasonCode string) ([]*entities.ReasonCodeBreakdownResult, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+
+	var results []struct {
+		ReasonCode      string `gorm:"column:reason_code"`
+		Label           string `gorm:"column:label"`
+		TransactionType string `gorm:"column:transaction_type"`
+		Count           int64
+		TotalAmount     int64 `gorm:"column:total_amount"`
+	}
+
+	query := db.Table("transactions t").
+		Scopes(tenantScope(ctx, "t.tenant_id")).
+		Select("t.reason_code, COALESCE(rc.label, '') as label, t.transaction_type, COUNT(*) as count, COALESCE(SUM(t.amount), 0) as total_amount").
+		Joins("LEFT JOIN reason_codes rc ON rc.code = t.reason_code").
+		Where("t.status = ? AND t.created_at >= ?", "completed", since).
+		Where("t.transaction_type IN ?", []string{string(entities.TransactionTypeAdminGrant), string(entities.TransactionTypeAdminDeduct)})
+	if reasonCode != "" {
+		query = query.Where("t.reason_code = ?", reasonCode)
+	}
+	err := query.
+		Group("t.reason_code, rc.label, t.transaction_type").
+		Order("total_amount DESC").
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	breakdowns := make([]*entities.ReasonCodeBreakdownResult, 0, len(results))
+	for _, r := range results {
+		breakdowns = append(breakdowns, &entities.ReasonCodeBreakdownResult{
+			ReasonCode:      r.ReasonCode,
+			Label:           r.Label,
+			TransactionType: r.TransactionType,
+			Count:           r.Count,
+			TotalAmount:     r.TotalAmount,
+		})
+	}
+	return breakdowns, nil
+}
+
 // GetMonthlyIssuedPoints は今月の発行ポイント数を取得
 func (ds *AnalyticsDataSourceImpl) GetMonthlyIssuedPoints(ctx context.Context) (int64, error) {
-	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	db := infrapostgres.GetReadDB(ctx, ds.db)
 
 	now := time.Now()
 	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
@@ -179,9 +226,10 @@ func (ds *AnalyticsDataSourceImpl) GetMonthlyIssuedPoints(ctx context.Context) (
 	}
 
 	err := db.Table("transactions").
+		Scopes(tenantScope(ctx, "tenant_id")).
 		Select("COALESCE(SUM(amount), 0) as total").
-		Where("transaction_type IN (?, ?) AND status = ? AND created_at >= ?",
-			"admin_grant", "system_grant", "completed", monthStart).
+		Where("transaction_type IN (?, ?, ?) AND status = ? AND created_at >= ?",
+			"admin_grant", "system_grant", "onboarding_bonus", "completed", monthStart).
 		Scan(&result).Error
 	if err != nil {
 		return 0, err
@@ -191,13 +239,14 @@ func (ds *AnalyticsDataSourceImpl) GetMonthlyIssuedPoints(ctx context.Context) (
 
 // GetMonthlyTransactionCount は今月のトランザクション数を取得
 func (ds *AnalyticsDataSourceImpl) GetMonthlyTransactionCount(ctx context.Context) (int64, error) {
-	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	db := infrapostgres.GetReadDB(ctx, ds.db)
 
 	now := time.Now()
 	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
 
 	var count int64
 	err := db.Table("transactions").
+		Scopes(tenantScope(ctx, "tenant_id")).
 		Where("created_at >= ?", monthStart).
 		Count(&count).Error
 	if err != nil {
@@ -205,3 +254,395 @@ func (ds *AnalyticsDataSourceImpl) GetMonthlyTransactionCount(ctx context.Contex
 	}
 	return count, nil
 }
+
+// activityEventsSQL はアクティブ判定に使うイベント（user_id, created_at）を返すサブクエリ
+// 送金は送信側・受取側の両方、商品交換はキャンセル以外をアクティブな行動とみなす
+func activityEventsSQL(basis entities.ActivityBasis) string {
+	if basis == entities.ActivityBasisLogins {
+		return `
+			SELECT user_id, created_at FROM login_attempts
+			WHERE success = TRUE AND user_id IS NOT NULL`
+	}
+	return `
+		SELECT from_user_id AS user_id, created_at FROM transactions
+		WHERE transaction_type = 'transfer' AND status = 'completed' AND from_user_id IS NOT NULL
+		UNION ALL
+		SELECT to_user_id AS user_id, created_at FROM transactions
+		WHERE transaction_type = 'transfer' AND status = 'completed' AND to_user_id IS NOT NULL
+		UNION ALL
+		SELECT user_id, created_at FROM product_exchanges
+		WHERE status <> 'cancelled'`
+}
+
+// GetActiveUserCounts は期間ごとのアクティブユーザー数を取得（期間内の全期間をゼロ埋めで返す）
+func (ds *AnalyticsDataSourceImpl) GetActiveUserCounts(ctx context.Context, granularity entities.AnalyticsGranularity, basis entities.ActivityBasis, from, to time.Time) ([]*entities.ActiveUserCountResult, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+
+	var results []struct {
+		PeriodStart time.Time `gorm:"column:period_start"`
+		ActiveUsers int64     `gorm:"column:active_users"`
+	}
+
+	tenant, tenantArgs := tenantUserCondition(ctx, "a.user_id")
+	err := db.Raw(`
+		SELECT date_trunc(?, a.created_at) AS period_start, COUNT(DISTINCT a.user_id) AS active_users
+		FROM (`+activityEventsSQL(basis)+`) a
+		WHERE a.created_at >= ? AND a.created_at < ?`+tenant+`
+		GROUP BY 1
+		ORDER BY 1`,
+		append([]interface{}{string(granularity), from, to}, tenantArgs...)...).
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	dataMap := make(map[string]int64, len(results))
+	for _, r := range results {
+		dataMap[r.PeriodStart.In(from.Location()).Format("2006-01-02")] = r.ActiveUsers
+	}
+
+	counts := make([]*entities.ActiveUserCountResult, 0)
+	for p := granularity.Truncate(from); p.Before(to); p = granularity.Next(p) {
+		counts = append(counts, &entities.ActiveUserCountResult{
+			PeriodStart: p,
+			ActiveUsers: dataMap[p.Format("2006-01-02")],
+		})
+	}
+	return counts, nil
+}
+
+// GetCohortSizes は登録期間ごとの新規ユーザー数を取得
+func (ds *AnalyticsDataSourceImpl) GetCohortSizes(ctx context.Context, granularity entities.AnalyticsGranularity, from, to time.Time) ([]*entities.CohortSizeResult, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+
+	var results []struct {
+		CohortStart time.Time `gorm:"column:cohort_start"`
+		Users       int64
+	}
+
+	err := db.Table("users").
+		Scopes(tenantScope(ctx, "tenant_id")).
+		Select("date_trunc(?, created_at) AS cohort_start, COUNT(*) AS users", string(granularity)).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group("1").
+		Order("1").
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := make([]*entities.CohortSizeResult, 0, len(results))
+	for _, r := range results {
+		sizes = append(sizes, &entities.CohortSizeResult{
+			CohortStart: r.CohortStart,
+			Users:       r.Users,
+		})
+	}
+	return sizes, nil
+}
+
+// GetCohortActivity はコホートごと・期間ごとのアクティブユーザー数を取得
+// 登録期間より前の行動は数えない
+func (ds *AnalyticsDataSourceImpl) GetCohortActivity(ctx context.Context, granularity entities.AnalyticsGranularity, basis entities.ActivityBasis, from, to time.Time) ([]*entities.CohortActivityResult, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+
+	var results []struct {
+		CohortStart time.Time `gorm:"column:cohort_start"`
+		PeriodStart time.Time `gorm:"column:period_start"`
+		ActiveUsers int64     `gorm:"column:active_users"`
+	}
+
+	g := string(granularity)
+	tenant, tenantArgs := tenantCondition(ctx, "u.tenant_id")
+	err := db.Raw(`
+		SELECT date_trunc(?, u.created_at) AS cohort_start,
+			date_trunc(?, a.created_at) AS period_start,
+			COUNT(DISTINCT a.user_id) AS active_users
+		FROM users u
+		JOIN (`+activityEventsSQL(basis)+`) a ON a.user_id = u.id
+		WHERE u.created_at >= ? AND u.created_at < ?
+			AND a.created_at >= date_trunc(?, u.created_at) AND a.created_at < ?`+tenant+`
+		GROUP BY 1, 2
+		ORDER BY 1, 2`,
+		append([]interface{}{g, g, from, to, g, to}, tenantArgs...)...).
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	activity := make([]*entities.CohortActivityResult, 0, len(results))
+	for _, r := range results {
+		activity = append(activity, &entities.CohortActivityResult{
+			CohortStart: r.CohortStart,
+			PeriodStart: r.PeriodStart,
+			ActiveUsers: r.ActiveUsers,
+		})
+	}
+	return activity, nil
+}
+
+// GetFeatureUsage は機能別の利用状況を取得
+// 送金トランザクションは送金リクエスト経由・QRコード経由・直接送金に重複なく振り分け、
+// 利用ユーザー数はポイントを支払った側で数える
+func (ds *AnalyticsDataSourceImpl) GetFeatureUsage(ctx context.Context, from, to time.Time) ([]*entities.FeatureUsageResult, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+
+	var results []struct {
+		Feature     string
+		Count       int64
+		Users       int64
+		TotalAmount int64 `gorm:"column:total_amount"`
+	}
+
+	transactionTenant, transactionTenantArgs := tenantCondition(ctx, "t.tenant_id")
+	exchangeTenant, exchangeTenantArgs := tenantUserCondition(ctx, "user_id")
+	args := []interface{}{entities.FeatureTransferRequest, entities.FeatureQRCode, entities.FeatureTransfer, from, to}
+	args = append(args, transactionTenantArgs...)
+	args = append(args, entities.FeatureExchange, from, to)
+	args = append(args, exchangeTenantArgs...)
+	err := db.Raw(`
+		SELECT feature, COUNT(*) AS count, COUNT(DISTINCT user_id) AS users, COALESCE(SUM(amount), 0) AS total_amount
+		FROM (
+			SELECT CASE
+					WHEN tr.id IS NOT NULL THEN ?
+					WHEN t.description LIKE 'QR code transfer: %' THEN ?
+					ELSE ?
+				END AS feature,
+				t.from_user_id AS user_id, t.amount
+			FROM transactions t
+			LEFT JOIN transfer_requests tr ON tr.transaction_id = t.id
+			WHERE t.transaction_type = 'transfer' AND t.status = 'completed'
+				AND t.created_at >= ? AND t.created_at < ?`+transactionTenant+`
+			UNION ALL
+			SELECT ? AS feature, user_id, points_used AS amount
+			FROM product_exchanges
+			WHERE status <> 'cancelled' AND created_at >= ? AND created_at < ?`+exchangeTenant+`
+		) usage
+		GROUP BY feature`, args...).
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 利用がない機能も0件として返す
+	dataMap := make(map[string]*entities.FeatureUsageResult, len(results))
+	for _, r := range results {
+		dataMap[r.Feature] = &entities.FeatureUsageResult{
+			Feature:     r.Feature,
+			Count:       r.Count,
+			Users:       r.Users,
+			TotalAmount: r.TotalAmount,
+		}
+	}
+
+	features := []string{entities.FeatureTransfer, entities.FeatureQRCode, entities.FeatureTransferRequest, entities.FeatureExchange}
+	usage := make([]*entities.FeatureUsageResult, 0, len(features))
+	for _, f := range features {
+		if entry, ok := dataMap[f]; ok {
+			usage = append(usage, entry)
+		} else {
+			usage = append(usage, &entities.FeatureUsageResult{Feature: f})
+		}
+	}
+	return usage, nil
+}
+
+// GetExpiryForecast はfromから7日ごとに区切り、weeks週先までの失効予定ポイントを取得
+// 失効予定がない週は含まれない
+func (ds *AnalyticsDataSourceImpl) GetExpiryForecast(ctx context.Context, from time.Time, weeks int) ([]*entities.ExpiryForecastResult, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+
+	var results []struct {
+		WeekIndex int `gorm:"column:week_index"`
+		Amount    int64
+		Users     int64
+		Batches   int64
+	}
+
+	until := from.AddDate(0, 0, 7*weeks)
+	err := db.Table("point_batches").
+		Scopes(tenantUserScope(ctx, "user_id")).
+		Select(`
+			FLOOR(EXTRACT(EPOCH FROM (expires_at - ?)) / 604800)::int AS week_index,
+			COALESCE(SUM(remaining_amount), 0) AS amount,
+			COUNT(DISTINCT user_id) AS users,
+			COUNT(*) AS batches
+		`, from).
+		Where("remaining_amount > 0 AND expires_at >= ? AND expires_at < ?", from, until).
+		Group("1").
+		Order("1").
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	forecast := make([]*entities.ExpiryForecastResult, 0, len(results))
+	for _, r := range results {
+		forecast = append(forecast, &entities.ExpiryForecastResult{
+			WeekIndex: r.WeekIndex,
+			Amount:    r.Amount,
+			Users:     r.Users,
+			Batches:   r.Batches,
+		})
+	}
+	return forecast, nil
+}
+
+// GetOutstandingPoints は失効前のバッチに残っているポイントの合計を取得
+func (ds *AnalyticsDataSourceImpl) GetOutstandingPoints(ctx context.Context, now time.Time) (int64, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+
+	var result struct {
+		Total int64
+	}
+
+	err := db.Table("point_batches").
+		Scopes(tenantUserScope(ctx, "user_id")).
+		Select("COALESCE(SUM(remaining_amount), 0) as total").
+		Where("remaining_amount > 0 AND expires_at > ?", now).
+		Scan(&result).Error
+	if err != nil {
+		return 0, err
+	}
+	return result.Total, nil
+}
+
+// GetCirculationVelocity はsince以降の支払いについて、獲得から支払いまでの時間の中央値を取得
+// バッチは消費日時を持たないため、ConsumePointsFIFOと同じ古い順の消費をユーザーごとの累計額で再現し、
+// 支払い直前までの累計支出額を超えた最初のバッチを「そのポイントを獲得した時点」とみなす。
+// 失効・管理者減算も累計支出には含めるが、中央値の対象は送金と商品交換（admin_deduct）のみ
+func (ds *AnalyticsDataSourceImpl) GetCirculationVelocity(ctx context.Context, since time.Time) (*entities.CirculationVelocityResult, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+
+	var result struct {
+		SampleCount   int64   `gorm:"column:sample_count"`
+		MedianSeconds float64 `gorm:"column:median_seconds"`
+	}
+
+	earnTenant, earnTenantArgs := tenantUserCondition(ctx, "user_id")
+	spendTenant, spendTenantArgs := tenantCondition(ctx, "tenant_id")
+	args := append(append([]interface{}{}, earnTenantArgs...), spendTenantArgs...)
+	args = append(args, since)
+	err := db.Raw(`
+		WITH earns AS (
+			SELECT user_id, created_at,
+				SUM(original_amount) OVER (PARTITION BY user_id ORDER BY created_at, id) AS cum_earned
+			FROM point_batches
+			WHERE 1 = 1`+earnTenant+`
+		), spends AS (
+			SELECT from_user_id AS user_id, created_at, transaction_type,
+				SUM(amount) OVER (PARTITION BY from_user_id ORDER BY created_at, id) - amount AS cum_before
+			FROM transactions
+			WHERE status = 'completed' AND from_user_id IS NOT NULL
+				AND transaction_type IN ('transfer', 'admin_deduct', 'system_expire')`+spendTenant+`
+		)
+		SELECT COUNT(*) AS sample_count,
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM (s.created_at - e.created_at))), 0) AS median_seconds
+		FROM spends s
+		JOIN LATERAL (
+			SELECT created_at FROM earns
+			WHERE earns.user_id = s.user_id AND earns.cum_earned > s.cum_before
+				AND earns.created_at <= s.created_at
+			ORDER BY earns.cum_earned
+			LIMIT 1
+		) e ON TRUE
+		WHERE s.created_at >= ? AND s.transaction_type IN ('transfer', 'admin_deduct')`, args...).
+		Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.CirculationVelocityResult{
+		SampleCount:   result.SampleCount,
+		MedianSeconds: result.MedianSeconds,
+	}, nil
+}
+
+// GetPointConservation はユーザーの残高の合計とシステムの口座ごとの入出金を取得
+// 移行前の残高（migrationバッチ）は発行元から出したものとみなし、その作成以降の取引だけを積み上げる（残高と取引履歴の照合と同じ基準）
+// 商品交換のキャンセルは取引を作らずに残高を戻しているため、発行元からの出金として加える
+func (ds *AnalyticsDataSourceImpl) GetPointConservation(ctx context.Context) (*entities.PointConservation, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	userTenant, userTenantArgs := tenantCondition(ctx, "tenant_id")
+	ownerTenant, ownerTenantArgs := tenantUserCondition(ctx, "user_id")
+	transactionTenant, transactionTenantArgs := tenantCondition(ctx, "t.tenant_id")
+
+	var users struct {
+		Total int64
+	}
+	if err := db.Raw(`SELECT COALESCE(SUM(balance), 0) AS total FROM users WHERE 1=1`+userTenant,
+		userTenantArgs...).Scan(&users).Error; err != nil {
+		return nil, err
+	}
+
+	openingArgs := append([]interface{}{entities.PointBatchSourceMigration}, ownerTenantArgs...)
+	var opening struct {
+		Total int64
+	}
+	if err := db.Raw(`SELECT COALESCE(SUM(original_amount), 0) AS total FROM point_batches WHERE source_type = ?`+ownerTenant,
+		openingArgs...).Scan(&opening).Error; err != nil {
+		return nil, err
+	}
+	var since []time.Time
+	if err := db.Raw(`SELECT created_at FROM point_batches WHERE source_type = ?`+ownerTenant+` ORDER BY created_at ASC LIMIT 1`,
+		openingArgs...).Scan(&since).Error; err != nil {
+		return nil, err
+	}
+	cond, condArgs := "", []interface{}{}
+	if len(since) > 0 {
+		cond, condArgs = " AND created_at > ?", []interface{}{since[0]}
+	}
+
+	var movements []struct {
+		Account  string
+		Credited int64
+		Debited  int64
+	}
+	args := []interface{}{entities.TransactionStatusCompleted}
+	args = append(args, condArgs...)
+	args = append(args, transactionTenantArgs...)
+	args = append(args, entities.TransactionStatusCompleted)
+	args = append(args, condArgs...)
+	args = append(args, transactionTenantArgs...)
+	err := db.Raw(`
+		SELECT account, SUM(credited) AS credited, SUM(debited) AS debited FROM (
+			SELECT to_system_account AS account, amount AS credited, 0 AS debited
+			FROM `+ledgerTransactions+` t
+			WHERE to_system_account IS NOT NULL AND status = ?`+cond+transactionTenant+`
+			UNION ALL
+			SELECT from_system_account AS account, 0 AS credited, amount AS debited
+			FROM `+ledgerTransactions+` t
+			WHERE from_system_account IS NOT NULL AND status = ?`+cond+transactionTenant+`
+		) m
+		GROUP BY account`, args...).
+		Scan(&movements).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var refunds struct {
+		Total int64
+	}
+	refundArgs := append([]interface{}{entities.ExchangeStatusCancelled}, condArgs...)
+	if err := db.Raw(`SELECT COALESCE(SUM(points_used), 0) AS total FROM product_exchanges WHERE status = ?`+cond+ownerTenant,
+		append(refundArgs, ownerTenantArgs...)...).Scan(&refunds).Error; err != nil {
+		return nil, err
+	}
+
+	balances := make(map[entities.SystemAccount]*entities.SystemAccountBalance)
+	conservation := &entities.PointConservation{UserBalanceTotal: users.Total}
+	for _, account := range entities.SystemAccounts() {
+		balances[account] = &entities.SystemAccountBalance{Account: account}
+		conservation.Accounts = append(conservation.Accounts, balances[account])
+	}
+	for _, m := range movements {
+		balance, ok := balances[entities.SystemAccount(m.Account)]
+		if !ok {
+			continue
+		}
+		balance.Credited += m.Credited
+		balance.Debited += m.Debited
+	}
+	balances[entities.SystemAccountTreasury].Debited += opening.Total + refunds.Total
+	return conservation, nil
+}
@@ -0,0 +1,210 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AnnouncementModel はお知らせのGORMモデル
+type AnnouncementModel struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key"`
+	Title      string     `gorm:"type:varchar(100);not null"`
+	Body       string     `gorm:"type:text;not null"`
+	Severity   string     `gorm:"type:varchar(20);not null"`
+	Audience   string     `gorm:"type:varchar(20);not null"`
+	TargetRole string     `gorm:"type:varchar(20);not null;default:''"`
+	StartsAt   time.Time  `gorm:"type:timestamptz;not null"`
+	EndsAt     *time.Time `gorm:"type:timestamptz"`
+	CreatedBy  uuid.UUID  `gorm:"type:uuid"` // 作成者の退会後はNULL
+	CreatedAt  time.Time  `gorm:"type:timestamptz;not null"`
+	UpdatedAt  time.Time  `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (AnnouncementModel) TableName() string {
+	return "announcements"
+}
+
+// AnnouncementDismissalModel はお知らせの非表示記録のGORMモデル
+type AnnouncementDismissalModel struct {
+	AnnouncementID uuid.UUID `gorm:"type:uuid;primary_key"`
+	UserID         uuid.UUID `gorm:"type:uuid;primary_key"`
+	DismissedAt    time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (AnnouncementDismissalModel) TableName() string {
+	return "announcement_dismissals"
+}
+
+// AnnouncementDataSource はお知らせのデータソース
+type AnnouncementDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewAnnouncementDataSource は新しいAnnouncementDataSourceを作成
+func NewAnnouncementDataSource(db infrapostgres.DB) *AnnouncementDataSource {
+	return &AnnouncementDataSource{db: db}
+}
+
+func (ds *AnnouncementDataSource) toEntity(m *AnnouncementModel) *entities.Announcement {
+	return &entities.Announcement{
+		ID:         m.ID,
+		Title:      m.Title,
+		Body:       m.Body,
+		Severity:   entities.AnnouncementSeverity(m.Severity),
+		Audience:   entities.AnnouncementAudience(m.Audience),
+		TargetRole: entities.UserRole(m.TargetRole),
+		StartsAt:   m.StartsAt,
+		EndsAt:     m.EndsAt,
+		CreatedBy:  m.CreatedBy,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
+
+func (ds *AnnouncementDataSource) toModel(e *entities.Announcement) *AnnouncementModel {
+	return &AnnouncementModel{
+		ID:         e.ID,
+		Title:      e.Title,
+		Body:       e.Body,
+		Severity:   string(e.Severity),
+		Audience:   string(e.Audience),
+		TargetRole: string(e.TargetRole),
+		StartsAt:   e.StartsAt,
+		EndsAt:     e.EndsAt,
+		CreatedBy:  e.CreatedBy,
+		CreatedAt:  e.CreatedAt,
+		UpdatedAt:  e.UpdatedAt,
+	}
+}
+
+func (ds *AnnouncementDataSource) toEntities(models []AnnouncementModel) []*entities.Announcement {
+	announcements := make([]*entities.Announcement, len(models))
+	for i := range models {
+		announcements[i] = ds.toEntity(&models[i])
+	}
+	return announcements
+}
+
+// Insert はお知らせを挿入
+func (ds *AnnouncementDataSource) Insert(ctx context.Context, announcement *entities.Announcement) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Create(ds.toModel(announcement)).Error
+}
+
+// SelectByID はIDでお知らせを取得
+func (ds *AnnouncementDataSource) SelectByID(ctx context.Context, id uuid.UUID) (*entities.Announcement, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m AnnouncementModel
+	if err := db.Where("id = ?", id).First(&m).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrAnnouncementNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// Update はお知らせを更新
+func (ds *AnnouncementDataSource) Update(ctx context.Context, announcement *entities.Announcement) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&AnnouncementModel{}).
+		Where("id = ?", announcement.ID).
+		Updates(map[string]interface{}{
+			"title":       announcement.Title,
+			"body":        announcement.Body,
+			"severity":    string(announcement.Severity),
+			"audience":    string(announcement.Audience),
+			"target_role": string(announcement.TargetRole),
+			"starts_at":   announcement.StartsAt,
+			"ends_at":     announcement.EndsAt,
+			"updated_at":  announcement.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrAnnouncementNotFound
+	}
+	return nil
+}
+
+// Delete はお知らせを削除（非表示記録も削除される）
+func (ds *AnnouncementDataSource) Delete(ctx context.Context, id uuid.UUID) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Where("id = ?", id).Delete(&AnnouncementModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrAnnouncementNotFound
+	}
+	return nil
+}
+
+// SelectList はお知らせを表示開始日時の新しい順に取得
+func (ds *AnnouncementDataSource) SelectList(ctx context.Context, offset, limit int) ([]*entities.Announcement, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var models []AnnouncementModel
+	if err := db.Order("starts_at DESC").Offset(offset).Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return ds.toEntities(models), nil
+}
+
+// Count はお知らせの件数を取得
+func (ds *AnnouncementDataSource) Count(ctx context.Context) (int64, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var count int64
+	if err := db.Model(&AnnouncementModel{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// SelectActive は指定日時に表示期間内のお知らせを重要度の高い順・新しい順に取得
+func (ds *AnnouncementDataSource) SelectActive(ctx context.Context, now time.Time) ([]*entities.Announcement, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var models []AnnouncementModel
+	err := db.Where("starts_at <= ? AND (ends_at IS NULL OR ends_at > ?)", now, now).
+		Order("CASE severity WHEN 'critical' THEN 0 WHEN 'warning' THEN 1 ELSE 2 END").
+		Order("starts_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return ds.toEntities(models), nil
+}
+
+// InsertDismissal はお知らせの非表示を記録（記録済みなら何もしない）
+func (ds *AnnouncementDataSource) InsertDismissal(ctx context.Context, announcementID, userID uuid.UUID, dismissedAt time.Time) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&AnnouncementDismissalModel{
+		AnnouncementID: announcementID,
+		UserID:         userID,
+		DismissedAt:    dismissedAt,
+	}).Error
+}
+
+// SelectDismissedIDs は指定したお知らせのうちユーザーが非表示にしたもののIDを取得
+func (ds *AnnouncementDataSource) SelectDismissedIDs(ctx context.Context, userID uuid.UUID, announcementIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(announcementIDs) == 0 {
+		return []uuid.UUID{}, nil
+	}
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var ids []uuid.UUID
+	err := db.Model(&AnnouncementDismissalModel{}).
+		Where("user_id = ? AND announcement_id IN ?", userID, announcementIDs).
+		Pluck("announcement_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
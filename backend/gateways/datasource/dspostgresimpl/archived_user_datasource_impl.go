@@ -156,8 +156,8 @@ func (ds *ArchivedUserDataSourceImpl) Count(ctx context.Context) (int64, error)
 }
 
 // Delete はアーカイブユーザーを削除
-func (ds *ArchivedUserDataSourceImpl) Delete(id uuid.UUID) error {
-	return ds.db.GetDB().Delete(&ArchivedUserModel{}, "id = ?", id).Error
+func (ds *ArchivedUserDataSourceImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return infrapostgres.GetDB(ctx, ds.db.GetDB()).Delete(&ArchivedUserModel{}, "id = ?", id).Error
 }
 
 // Restore はアーカイブユーザーを復元（トランザクション内で使用）
@@ -167,6 +167,7 @@ func (ds *ArchivedUserDataSourceImpl) Restore(ctx context.Context, tx interface{
 	if !ok {
 		return errors.New("invalid transaction type")
 	}
+	gormTx = gormTx.WithContext(ctx)
 
 	// アーカイブからユーザーを作成
 	userModel := &UserModel{}
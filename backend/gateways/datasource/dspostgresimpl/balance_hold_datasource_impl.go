@@ -0,0 +1,119 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BalanceHoldModel は送金リクエストのために確保した残高のGORMモデル
+type BalanceHoldModel struct {
+	ID                uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	UserID            uuid.UUID  `gorm:"type:uuid;not null;index"`
+	TransferRequestID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex"`
+	Amount            int64      `gorm:"not null"`
+	Status            string     `gorm:"type:varchar(20);not null"`
+	CreatedAt         time.Time  `gorm:"type:timestamptz;not null"`
+	SettledAt         *time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName はテーブル名を指定
+func (BalanceHoldModel) TableName() string {
+	return "balance_holds"
+}
+
+// ToDomain はドメインエンティティに変換
+func (m *BalanceHoldModel) ToDomain() *entities.BalanceHold {
+	return &entities.BalanceHold{
+		ID:                m.ID,
+		UserID:            m.UserID,
+		TransferRequestID: m.TransferRequestID,
+		Amount:            m.Amount,
+		Status:            entities.BalanceHoldStatus(m.Status),
+		CreatedAt:         m.CreatedAt,
+		SettledAt:         m.SettledAt,
+	}
+}
+
+// FromDomain はドメインエンティティから変換
+func (m *BalanceHoldModel) FromDomain(h *entities.BalanceHold) {
+	m.ID = h.ID
+	m.UserID = h.UserID
+	m.TransferRequestID = h.TransferRequestID
+	m.Amount = h.Amount
+	m.Status = string(h.Status)
+	m.CreatedAt = h.CreatedAt
+	m.SettledAt = h.SettledAt
+}
+
+// BalanceHoldDataSource は送金リクエストのために確保した残高のデータソース
+type BalanceHoldDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewBalanceHoldDataSource は新しいBalanceHoldDataSourceを作成
+func NewBalanceHoldDataSource(db infrapostgres.DB) *BalanceHoldDataSource {
+	return &BalanceHoldDataSource{db: db}
+}
+
+// Insert は確保を挿入
+func (ds *BalanceHoldDataSource) Insert(ctx context.Context, hold *entities.BalanceHold) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	model := &BalanceHoldModel{}
+	model.FromDomain(hold)
+	return db.Create(model).Error
+}
+
+// SelectByTransferRequestIDForUpdate は送金リクエストの確保をSELECT FOR UPDATEで取得（確保していなければnil）
+func (ds *BalanceHoldDataSource) SelectByTransferRequestIDForUpdate(ctx context.Context, transferRequestID uuid.UUID) (*entities.BalanceHold, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var model BalanceHoldModel
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("transfer_request_id = ?", transferRequestID).
+		First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// Update は確保の状態を更新
+func (ds *BalanceHoldDataSource) Update(ctx context.Context, hold *entities.BalanceHold) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Model(&BalanceHoldModel{}).
+		Where("id = ?", hold.ID).
+		Updates(map[string]interface{}{
+			"status":     string(hold.Status),
+			"settled_at": hold.SettledAt,
+		}).Error
+}
+
+// SelectReleasable は送金リクエストが承認待ちでなくなったのに確保中のものを古い順に取得
+func (ds *BalanceHoldDataSource) SelectReleasable(ctx context.Context, limit int) ([]*entities.BalanceHold, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var models []BalanceHoldModel
+	if err := db.Table("balance_holds AS h").
+		Select("h.*").
+		Joins("JOIN transfer_requests tr ON tr.id = h.transfer_request_id").
+		Where("h.status = ? AND tr.status NOT IN ?", string(entities.BalanceHoldStatusActive),
+			[]string{string(entities.TransferRequestStatusPending), string(entities.TransferRequestStatusCountered)}).
+		Order("h.created_at ASC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	holds := make([]*entities.BalanceHold, len(models))
+	for i := range models {
+		holds[i] = models[i].ToDomain()
+	}
+	return holds, nil
+}
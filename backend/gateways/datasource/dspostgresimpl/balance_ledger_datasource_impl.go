@@ -0,0 +1,118 @@
+package dspostgresimpl
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+)
+
+// BalanceLedgerDataSource は残高と取引履歴の照合のデータソース
+type BalanceLedgerDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewBalanceLedgerDataSource は新しいBalanceLedgerDataSourceを作成
+func NewBalanceLedgerDataSource(db infrapostgres.DB) *BalanceLedgerDataSource {
+	return &BalanceLedgerDataSource{db: db}
+}
+
+// SelectUserIDs はafterより後のユーザーID（退会済みを除く）を昇順にlimit件取得
+func (ds *BalanceLedgerDataSource) SelectUserIDs(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	tenant, tenantArgs := tenantCondition(ctx, "tenant_id")
+	args := append([]interface{}{after}, tenantArgs...)
+	var ids []uuid.UUID
+	err := db.Raw(`
+		SELECT id FROM users
+		WHERE id > ? AND deleted_at IS NULL`+tenant+`
+		ORDER BY id ASC
+		LIMIT ?`, append(args, limit)...).
+		Scan(&ids).Error
+	return ids, err
+}
+
+// LockUsers はユーザーの行をID順にSELECT FOR UPDATEでロックする
+// 残高の更新（UpdateBalancesWithLock）と同じID順なのでデッドロックしない
+func (ds *BalanceLedgerDataSource) LockUsers(ctx context.Context, userIDs []uuid.UUID) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var locked []uuid.UUID
+	return db.Raw(`SELECT id FROM users WHERE id IN ? ORDER BY id ASC `+forUpdate(db, "FOR UPDATE"), userIDs).
+		Scan(&locked).Error
+}
+
+// balanceCheckRow は照合結果の読み出し用
+type balanceCheckRow struct {
+	UserID        uuid.UUID
+	Username      string
+	StoredBalance int64
+	LedgerBalance int64
+}
+
+// SelectBalanceChecks は保存されている残高と取引履歴から計算した残高をユーザーID順に取得
+// 移行前の残高はmigrationバッチにしか残っていないため、その作成以降の取引だけを積み上げる
+// 商品交換のキャンセルは取引を作らずに残高を戻しているため、返金として加える
+// 保持期間を過ぎて保管用のテーブルへ移した取引も積み上げる
+func (ds *BalanceLedgerDataSource) SelectBalanceChecks(ctx context.Context, userIDs []uuid.UUID) ([]*entities.BalanceCheck, error) {
+	if len(userIDs) == 0 {
+		return []*entities.BalanceCheck{}, nil
+	}
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+
+	var rows []balanceCheckRow
+	err := db.Raw(`
+		WITH opening AS (
+			SELECT user_id, SUM(original_amount) AS amount, MIN(created_at) AS since
+			FROM point_batches
+			WHERE source_type = ? AND user_id IN ?
+			GROUP BY user_id
+		)
+		SELECT
+			u.id AS user_id,
+			u.username,
+			u.balance AS stored_balance,
+			COALESCE(o.amount, 0)
+			+ COALESCE((
+				SELECT SUM(t.amount) FROM `+ledgerTransactions+` t
+				WHERE t.to_user_id = u.id AND t.status = ? AND t.transaction_type <> ?
+					AND (o.since IS NULL OR t.created_at > o.since)
+			), 0)
+			- COALESCE((
+				SELECT SUM(t.amount) FROM `+ledgerTransactions+` t
+				WHERE t.from_user_id = u.id AND t.status = ? AND t.transaction_type <> ?
+					AND (o.since IS NULL OR t.created_at > o.since)
+			), 0)
+			+ COALESCE((
+				SELECT SUM(e.points_used) FROM product_exchanges e
+				WHERE e.user_id = u.id AND e.status = ?
+					AND (o.since IS NULL OR e.created_at > o.since)
+			), 0) AS ledger_balance
+		FROM users u
+		LEFT JOIN opening o ON o.user_id = u.id
+		WHERE u.id IN ?
+		ORDER BY u.id ASC`,
+		entities.PointBatchSourceMigration, userIDs,
+		entities.TransactionStatusCompleted, entities.TransactionTypeBalanceCorrection,
+		entities.TransactionStatusCompleted, entities.TransactionTypeBalanceCorrection,
+		entities.ExchangeStatusCancelled,
+		userIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make([]*entities.BalanceCheck, len(rows))
+	for i, row := range rows {
+		checks[i] = &entities.BalanceCheck{
+			UserID:        row.UserID,
+			Username:      row.Username,
+			StoredBalance: row.StoredBalance,
+			LedgerBalance: row.LedgerBalance,
+		}
+	}
+	return checks, nil
+}
@@ -0,0 +1,220 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BudgetModel は予算のGORMモデル
+type BudgetModel struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key"`
+	Name        string     `gorm:"type:varchar(100);not null"`
+	Scope       string     `gorm:"type:varchar(20);not null"`
+	ScopeID     *uuid.UUID `gorm:"type:uuid"`
+	Period      string     `gorm:"type:varchar(10);not null"`
+	Amount      int64      `gorm:"not null"`
+	Enforcement string     `gorm:"type:varchar(10);not null"`
+	IsActive    bool       `gorm:"not null;default:true"`
+	CreatedAt   time.Time  `gorm:"type:timestamptz;not null"`
+	UpdatedAt   time.Time  `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (BudgetModel) TableName() string {
+	return "budgets"
+}
+
+// BudgetUsageModel は予算の期間ごとの消化状況のGORMモデル
+type BudgetUsageModel struct {
+	BudgetID       uuid.UUID `gorm:"type:uuid;primary_key"`
+	PeriodStart    time.Time `gorm:"type:timestamptz;primary_key"`
+	Consumed       int64     `gorm:"not null;default:0"`
+	AlertedPercent int       `gorm:"not null;default:0"`
+	UpdatedAt      time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (BudgetUsageModel) TableName() string {
+	return "budget_usages"
+}
+
+// BudgetDataSource は予算のデータソース
+type BudgetDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewBudgetDataSource は新しいBudgetDataSourceを作成
+func NewBudgetDataSource(db infrapostgres.DB) *BudgetDataSource {
+	return &BudgetDataSource{db: db}
+}
+
+func (ds *BudgetDataSource) toEntity(m *BudgetModel) *entities.Budget {
+	return &entities.Budget{
+		ID:          m.ID,
+		Name:        m.Name,
+		Scope:       entities.BudgetScope(m.Scope),
+		ScopeID:     m.ScopeID,
+		Period:      entities.BudgetPeriod(m.Period),
+		Amount:      m.Amount,
+		Enforcement: entities.BudgetEnforcement(m.Enforcement),
+		IsActive:    m.IsActive,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func (ds *BudgetDataSource) toEntities(models []BudgetModel) []*entities.Budget {
+	budgets := make([]*entities.Budget, len(models))
+	for i := range models {
+		budgets[i] = ds.toEntity(&models[i])
+	}
+	return budgets
+}
+
+// Insert は予算を挿入
+func (ds *BudgetDataSource) Insert(ctx context.Context, b *entities.Budget) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Create(&BudgetModel{
+		ID:          b.ID,
+		Name:        b.Name,
+		Scope:       string(b.Scope),
+		ScopeID:     b.ScopeID,
+		Period:      string(b.Period),
+		Amount:      b.Amount,
+		Enforcement: string(b.Enforcement),
+		IsActive:    b.IsActive,
+		CreatedAt:   b.CreatedAt,
+		UpdatedAt:   b.UpdatedAt,
+	}).Error
+}
+
+// Select はIDで予算を取得
+func (ds *BudgetDataSource) Select(ctx context.Context, id uuid.UUID) (*entities.Budget, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m BudgetModel
+	if err := db.Where("id = ?", id).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrBudgetNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// SelectList は全予算を作成順に取得
+func (ds *BudgetDataSource) SelectList(ctx context.Context) ([]*entities.Budget, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var models []BudgetModel
+	if err := db.Order("created_at ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return ds.toEntities(models), nil
+}
+
+// Update は予算名・金額・超過時の扱い・有効状態を更新
+func (ds *BudgetDataSource) Update(ctx context.Context, b *entities.Budget) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&BudgetModel{}).
+		Where("id = ?", b.ID).
+		Updates(map[string]interface{}{
+			"name":        b.Name,
+			"amount":      b.Amount,
+			"enforcement": string(b.Enforcement),
+			"is_active":   b.IsActive,
+			"updated_at":  b.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrBudgetNotFound
+	}
+	return nil
+}
+
+// Delete は予算を削除（消化状況は外部キーで削除される）
+func (ds *BudgetDataSource) Delete(ctx context.Context, id uuid.UUID) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Where("id = ?", id).Delete(&BudgetModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrBudgetNotFound
+	}
+	return nil
+}
+
+// SelectApplicableForUpdate は付与が対象の有効な予算を行ロック付きでID順に取得
+// ID順にロックして、複数の予算にかかる同時付与でデッドロックしないようにする
+func (ds *BudgetDataSource) SelectApplicableForUpdate(ctx context.Context, adminID uuid.UUID, departmentIDs []uuid.UUID) ([]*entities.Budget, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+
+	scope := db.Where("scope = ?", string(entities.BudgetScopeGlobal)).
+		Or("scope = ? AND scope_id = ?", string(entities.BudgetScopeAdmin), adminID)
+	if len(departmentIDs) > 0 {
+		scope = scope.Or("scope = ? AND scope_id IN ?", string(entities.BudgetScopeDepartment), departmentIDs)
+	}
+
+	var models []BudgetModel
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("is_active = ?", true).
+		Where(scope).
+		Order("id ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return ds.toEntities(models), nil
+}
+
+// SelectUsage は予算の期間の消化状況を取得（行がなければConsumed=0）
+func (ds *BudgetDataSource) SelectUsage(ctx context.Context, budgetID uuid.UUID, periodStart time.Time) (*entities.BudgetUsage, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	usage := &entities.BudgetUsage{BudgetID: budgetID, PeriodStart: periodStart}
+
+	var m BudgetUsageModel
+	err := db.Where("budget_id = ? AND period_start = ?", budgetID, periodStart).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return usage, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	usage.Consumed = m.Consumed
+	usage.AlertedPercent = m.AlertedPercent
+	usage.UpdatedAt = m.UpdatedAt
+	return usage, nil
+}
+
+// UpsertUsage は期間の消化状況を保存
+func (ds *BudgetDataSource) UpsertUsage(ctx context.Context, usage *entities.BudgetUsage) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "budget_id"}, {Name: "period_start"}},
+		DoUpdates: clause.AssignmentColumns([]string{"consumed", "alerted_percent", "updated_at"}),
+	}).Create(&BudgetUsageModel{
+		BudgetID:       usage.BudgetID,
+		PeriodStart:    usage.PeriodStart,
+		Consumed:       usage.Consumed,
+		AlertedPercent: usage.AlertedPercent,
+		UpdatedAt:      usage.UpdatedAt,
+	}).Error
+}
+
+// SelectAlertRecipients は有効な管理者のIDを取得
+func (ds *BudgetDataSource) SelectAlertRecipients(ctx context.Context) ([]uuid.UUID, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var ids []uuid.UUID
+	err := db.Model(&UserModel{}).
+		Where("role IN ? AND is_active = ?", []string{string(entities.RoleAdmin), string(entities.RoleSuperAdmin)}, true).
+		Pluck("id", &ids).Error
+	return ids, err
+}
@@ -0,0 +1,72 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+)
+
+// BusinessMetricsDataSource は /metrics で公開する業務の指標の集計のデータソース
+// 管理者ダッシュボードと同じく、テナントごとのテーブルを絞り込めるようモデルを指定して集計する
+type BusinessMetricsDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewBusinessMetricsDataSource は新しいBusinessMetricsDataSourceを作成
+func NewBusinessMetricsDataSource(db infrapostgres.DB) *BusinessMetricsDataSource {
+	return &BusinessMetricsDataSource{db: db}
+}
+
+// SelectBusinessMetrics はnow時点の指標を集計（今日のポイントの移動はdayStartからnowまでに作成された完了した取引）
+func (ds *BusinessMetricsDataSource) SelectBusinessMetrics(ctx context.Context, dayStart, now time.Time) (*entities.BusinessMetrics, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	// SQLiteは日時を文字列で比べるため、区切りもnowと同じタイムゾーンで渡す
+	dayStart = dayStart.In(now.Location())
+	metrics := &entities.BusinessMetrics{CollectedAt: now}
+
+	if err := db.Model(&UserModel{}).
+		Select("COALESCE(SUM(balance), 0)").
+		Scan(&metrics.PointsInCirculation).Error; err != nil {
+		return nil, err
+	}
+
+	var moved struct {
+		Issued      int64
+		Consumed    int64
+		Transferred int64
+	}
+	treasury := string(entities.SystemAccountTreasury)
+	err := db.Model(&TransactionModel{}).
+		Select(`COALESCE(SUM(CASE WHEN from_system_account = ? THEN amount ELSE 0 END), 0) AS issued,
+			COALESCE(SUM(CASE WHEN to_system_account = ? THEN amount ELSE 0 END), 0) AS consumed,
+			COALESCE(SUM(CASE WHEN transaction_type = ? THEN amount ELSE 0 END), 0) AS transferred`,
+			treasury, treasury, entities.TransactionTypeTransfer).
+		Where("status = ? AND created_at >= ? AND created_at < ?", entities.TransactionStatusCompleted, dayStart, now).
+		Scan(&moved).Error
+	if err != nil {
+		return nil, err
+	}
+	metrics.IssuedToday = moved.Issued
+	metrics.ConsumedToday = moved.Consumed
+	metrics.TransferredToday = moved.Transferred
+
+	if err := db.Model(&TransferRequestModel{}).
+		Where("status IN ? AND expires_at > ?", []string{
+			string(entities.TransferRequestStatusPending),
+			string(entities.TransferRequestStatusCountered),
+		}, now).
+		Count(&metrics.PendingTransferRequests).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Model(&SessionModel{}).
+		Distinct("user_id").
+		Where("last_active_at >= ?", dayStart).
+		Count(&metrics.ActiveUsersToday).Error; err != nil {
+		return nil, err
+	}
+
+	return metrics, nil
+}
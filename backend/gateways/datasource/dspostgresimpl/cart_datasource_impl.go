@@ -0,0 +1,110 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CartItemModel はカートの商品のGORMモデル
+type CartItemModel struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primary_key"`
+	ProductID uuid.UUID `gorm:"type:uuid;primary_key"`
+	Quantity  int       `gorm:"not null"`
+	CreatedAt time.Time `gorm:"type:timestamptz;not null"`
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (CartItemModel) TableName() string {
+	return "cart_items"
+}
+
+// CartDataSource はカートのデータソース
+type CartDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewCartDataSource は新しいCartDataSourceを作成
+func NewCartDataSource(db infrapostgres.DB) *CartDataSource {
+	return &CartDataSource{db: db}
+}
+
+func (ds *CartDataSource) toEntity(m *CartItemModel) *entities.CartItem {
+	return &entities.CartItem{
+		UserID:    m.UserID,
+		ProductID: m.ProductID,
+		Quantity:  m.Quantity,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// SelectByUser はユーザーのカートの商品を入れた順に取得
+func (ds *CartDataSource) SelectByUser(ctx context.Context, userID uuid.UUID) ([]*entities.CartItem, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var models []CartItemModel
+	if err := db.Where("user_id = ?", userID).
+		Order("created_at ASC, product_id ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	items := make([]*entities.CartItem, len(models))
+	for i := range models {
+		items[i] = ds.toEntity(&models[i])
+	}
+	return items, nil
+}
+
+// Select はカートの商品を取得
+func (ds *CartDataSource) Select(ctx context.Context, userID, productID uuid.UUID) (*entities.CartItem, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m CartItemModel
+	if err := db.Where("user_id = ? AND product_id = ?", userID, productID).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrCartItemNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// Upsert はカートの商品を挿入（同じユーザーと商品があれば数量を更新）
+func (ds *CartDataSource) Upsert(ctx context.Context, item *entities.CartItem) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "product_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"quantity", "updated_at"}),
+	}).Create(&CartItemModel{
+		UserID:    item.UserID,
+		ProductID: item.ProductID,
+		Quantity:  item.Quantity,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}).Error
+}
+
+// Delete はカートの商品を削除
+func (ds *CartDataSource) Delete(ctx context.Context, userID, productID uuid.UUID) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Where("user_id = ? AND product_id = ?", userID, productID).Delete(&CartItemModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrCartItemNotFound
+	}
+	return nil
+}
+
+// DeleteByUser はユーザーのカートの商品をすべて削除
+func (ds *CartDataSource) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Where("user_id = ?", userID).Delete(&CartItemModel{}).Error
+}
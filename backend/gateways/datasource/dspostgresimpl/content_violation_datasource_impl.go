@@ -0,0 +1,134 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ContentViolationModel はモデレーション違反記録のGORMモデル
+type ContentViolationModel struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null"`
+	Field        string     `gorm:"type:varchar(50);not null"`
+	Content      string     `gorm:"type:text;not null"`
+	MatchedTerms string     `gorm:"type:text;not null;default:''"` // カンマ区切り
+	CreatedAt    time.Time  `gorm:"type:timestamptz;not null"`
+	ReviewedAt   *time.Time `gorm:"type:timestamptz"`
+	ReviewedBy   *uuid.UUID `gorm:"type:uuid"`
+}
+
+// TableName はテーブル名を指定
+func (ContentViolationModel) TableName() string {
+	return "content_violations"
+}
+
+// ContentViolationDataSource はモデレーション違反記録のデータソース
+type ContentViolationDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewContentViolationDataSource は新しいContentViolationDataSourceを作成
+func NewContentViolationDataSource(db infrapostgres.DB) *ContentViolationDataSource {
+	return &ContentViolationDataSource{db: db}
+}
+
+func (ds *ContentViolationDataSource) toEntity(m *ContentViolationModel) *entities.ContentViolation {
+	var terms []string
+	if m.MatchedTerms != "" {
+		terms = strings.Split(m.MatchedTerms, ",")
+	}
+	return &entities.ContentViolation{
+		ID:           m.ID,
+		UserID:       m.UserID,
+		Field:        entities.ModerationField(m.Field),
+		Content:      m.Content,
+		MatchedTerms: terms,
+		CreatedAt:    m.CreatedAt,
+		ReviewedAt:   m.ReviewedAt,
+		ReviewedBy:   m.ReviewedBy,
+	}
+}
+
+func (ds *ContentViolationDataSource) toModel(e *entities.ContentViolation) *ContentViolationModel {
+	return &ContentViolationModel{
+		ID:           e.ID,
+		UserID:       e.UserID,
+		Field:        string(e.Field),
+		Content:      e.Content,
+		MatchedTerms: strings.Join(e.MatchedTerms, ","),
+		CreatedAt:    e.CreatedAt,
+		ReviewedAt:   e.ReviewedAt,
+		ReviewedBy:   e.ReviewedBy,
+	}
+}
+
+// Insert は違反記録を挿入
+func (ds *ContentViolationDataSource) Insert(ctx context.Context, violation *entities.ContentViolation) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Create(ds.toModel(violation)).Error
+}
+
+// SelectByID はIDで違反記録を取得
+func (ds *ContentViolationDataSource) SelectByID(ctx context.Context, id uuid.UUID) (*entities.ContentViolation, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m ContentViolationModel
+	if err := db.Where("id = ?", id).First(&m).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrViolationNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// SelectList は違反記録を新しい順に取得（unreviewedOnlyなら未レビューのみ）
+func (ds *ContentViolationDataSource) SelectList(ctx context.Context, unreviewedOnly bool, offset, limit int) ([]*entities.ContentViolation, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	query := db.Model(&ContentViolationModel{})
+	if unreviewedOnly {
+		query = query.Where("reviewed_at IS NULL")
+	}
+
+	var models []ContentViolationModel
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	violations := make([]*entities.ContentViolation, len(models))
+	for i := range models {
+		violations[i] = ds.toEntity(&models[i])
+	}
+	return violations, nil
+}
+
+// Count は違反記録の件数を取得（unreviewedOnlyなら未レビューのみ）
+func (ds *ContentViolationDataSource) Count(ctx context.Context, unreviewedOnly bool) (int64, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	query := db.Model(&ContentViolationModel{})
+	if unreviewedOnly {
+		query = query.Where("reviewed_at IS NULL")
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// UpdateReviewed はレビュー結果を保存
+func (ds *ContentViolationDataSource) UpdateReviewed(ctx context.Context, violation *entities.ContentViolation) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Model(&ContentViolationModel{}).
+		Where("id = ?", violation.ID).
+		Updates(map[string]interface{}{
+			"reviewed_at": violation.ReviewedAt,
+			"reviewed_by": violation.ReviewedBy,
+		}).Error
+}
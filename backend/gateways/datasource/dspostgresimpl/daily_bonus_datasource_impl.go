@@ -8,6 +8,7 @@ import (
 	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // DailyBonusModel はAkerun入退室ベースのデイリーボーナスGORMモデル
@@ -24,6 +25,11 @@ type DailyBonusModel struct {
 	IsViewed        bool       `gorm:"not null;default:false"`
 	IsDrawn         bool       `gorm:"not null;default:false"`
 	CreatedAt       time.Time  `gorm:"type:timestamptz;not null;default:CURRENT_TIMESTAMP"`
+
+	AkerunOrganizationID *string `gorm:"type:text"`
+	AkerunDoorID         *string `gorm:"type:text"`
+	AkerunDoorName       *string `gorm:"type:text"`
+	PointsMultiplier     float64 `gorm:"type:numeric(6,2);not null;default:1"`
 }
 
 // TableName はテーブル名を指定
@@ -43,6 +49,18 @@ func (AkerunPollStateModel) TableName() string {
 	return "akerun_poll_state"
 }
 
+// AkerunPollCursorModel は組織ごとのポーリング状態のGORMモデル
+type AkerunPollCursorModel struct {
+	OrganizationID string    `gorm:"type:text;primary_key"`
+	LastPolledAt   time.Time `gorm:"type:timestamptz;not null"`
+	UpdatedAt      time.Time `gorm:"type:timestamptz;not null;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName はテーブル名を指定
+func (AkerunPollCursorModel) TableName() string {
+	return "akerun_poll_cursors"
+}
+
 // DailyBonusDataSource はデイリーボーナスのデータソース
 type DailyBonusDataSource struct {
 	db infrapostgres.DB
@@ -65,6 +83,8 @@ func (ds *DailyBonusDataSource) toEntity(model *DailyBonusModel) *entities.Daily
 		IsViewed:      model.IsViewed,
 		IsDrawn:       model.IsDrawn,
 		CreatedAt:     model.CreatedAt,
+
+		PointsMultiplier: model.PointsMultiplier,
 	}
 	if model.AkerunAccessID != nil {
 		bonus.AkerunAccessID = *model.AkerunAccessID
@@ -75,6 +95,15 @@ func (ds *DailyBonusDataSource) toEntity(model *DailyBonusModel) *entities.Daily
 	if model.LotteryTierName != nil {
 		bonus.LotteryTierName = *model.LotteryTierName
 	}
+	if model.AkerunOrganizationID != nil {
+		bonus.AkerunOrganizationID = *model.AkerunOrganizationID
+	}
+	if model.AkerunDoorID != nil {
+		bonus.AkerunDoorID = *model.AkerunDoorID
+	}
+	if model.AkerunDoorName != nil {
+		bonus.AkerunDoorName = *model.AkerunDoorName
+	}
 	return bonus
 }
 
@@ -90,6 +119,11 @@ func (ds *DailyBonusDataSource) toModel(bonus *entities.DailyBonus) *DailyBonusM
 		IsViewed:      bonus.IsViewed,
 		IsDrawn:       bonus.IsDrawn,
 		CreatedAt:     bonus.CreatedAt,
+
+		PointsMultiplier: bonus.PointsMultiplier,
+	}
+	if model.PointsMultiplier <= 0 {
+		model.PointsMultiplier = 1
 	}
 	if bonus.AkerunAccessID != "" {
 		model.AkerunAccessID = &bonus.AkerunAccessID
@@ -100,6 +134,15 @@ func (ds *DailyBonusDataSource) toModel(bonus *entities.DailyBonus) *DailyBonusM
 	if bonus.LotteryTierName != "" {
 		model.LotteryTierName = &bonus.LotteryTierName
 	}
+	if bonus.AkerunOrganizationID != "" {
+		model.AkerunOrganizationID = &bonus.AkerunOrganizationID
+	}
+	if bonus.AkerunDoorID != "" {
+		model.AkerunDoorID = &bonus.AkerunDoorID
+	}
+	if bonus.AkerunDoorName != "" {
+		model.AkerunDoorName = &bonus.AkerunDoorName
+	}
 	return model
 }
 
@@ -127,7 +170,7 @@ func (ds *DailyBonusDataSource) SelectByUserAndDate(ctx context.Context, userID
 
 // SelectRecentByUser はユーザーの最近のデイリーボーナスを取得
 func (ds *DailyBonusDataSource) SelectRecentByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*entities.DailyBonus, error) {
-	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	db := infrapostgres.GetReadDB(ctx, ds.db)
 	var models []DailyBonusModel
 	err := db.
 		Where("user_id = ?", userID).
@@ -147,7 +190,7 @@ func (ds *DailyBonusDataSource) SelectRecentByUser(ctx context.Context, userID u
 
 // CountByUser はユーザーのボーナス獲得日数をカウント
 func (ds *DailyBonusDataSource) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
-	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	db := infrapostgres.GetReadDB(ctx, ds.db)
 	var count int64
 	err := db.Model(&DailyBonusModel{}).
 		Where("user_id = ?", userID).
@@ -180,6 +223,34 @@ func (ds *DailyBonusDataSource) UpdateLastPolledAt(ctx context.Context, t time.T
 		}).Error
 }
 
+// GetOrganizationLastPolledAt は組織の前回ポーリング時刻を取得
+// 組織の行がなければ組織ごとのポーリング導入前の時刻（akerun_poll_state）から始める
+func (ds *DailyBonusDataSource) GetOrganizationLastPolledAt(ctx context.Context, organizationID string) (time.Time, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var model AkerunPollCursorModel
+	err := db.Where("organization_id = ?", organizationID).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ds.GetLastPolledAt(ctx)
+		}
+		return time.Time{}, err
+	}
+	return model.LastPolledAt, nil
+}
+
+// UpsertOrganizationLastPolledAt は組織のポーリング時刻を更新（行がなければ作成）
+func (ds *DailyBonusDataSource) UpsertOrganizationLastPolledAt(ctx context.Context, organizationID string, t time.Time) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "organization_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_polled_at", "updated_at"}),
+	}).Create(&AkerunPollCursorModel{
+		OrganizationID: organizationID,
+		LastPolledAt:   t,
+		UpdatedAt:      time.Now(),
+	}).Error
+}
+
 // UpdateIsViewed はデイリーボーナスの閲覧状態を更新
 func (ds *DailyBonusDataSource) UpdateIsViewed(ctx context.Context, id uuid.UUID) error {
 	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
@@ -201,3 +272,43 @@ func (ds *DailyBonusDataSource) UpdateDrawnResult(ctx context.Context, id uuid.U
 		Where("id = ? AND is_drawn = false", id).
 		Updates(updates).Error
 }
+
+// StreamByPeriod はボーナス日が期間内のボーナスをユーザー名付きで、ボーナス日・入退室日時の順に1件ずつfnへ渡す
+// daily_bonusesはテナントの列を持たないため、contextにテナントがあればユーザーのテナントで絞り込む
+func (ds *DailyBonusDataSource) StreamByPeriod(ctx context.Context, period entities.BonusReportPeriod, fn func(*entities.DailyBonusRecord) error) error {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	query := db.Model(&DailyBonusModel{}).
+		Select("daily_bonuses.*, u.username, u.display_name").
+		Joins("JOIN users u ON u.id = daily_bonuses.user_id").
+		Where("daily_bonuses.bonus_date >= ? AND daily_bonuses.bonus_date < ?",
+			period.From.Format("2006-01-02"), period.To.Format("2006-01-02"))
+	if tenantID, ok := entities.TenantIDFromContext(ctx); ok {
+		query = query.Where("u.tenant_id = ?", tenantID)
+	}
+
+	rows, err := query.Order("daily_bonuses.bonus_date, daily_bonuses.accessed_at, u.username").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row struct {
+			DailyBonusModel
+			Username    string
+			DisplayName string
+		}
+		if err := query.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		record := &entities.DailyBonusRecord{
+			DailyBonus:  *ds.toEntity(&row.DailyBonusModel),
+			Username:    row.Username,
+			DisplayName: row.DisplayName,
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,154 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DataExportModel は個人データエクスポート依頼のGORMモデル
+type DataExportModel struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key"`
+	UserID       uuid.UUID  `gorm:"type:uuid"` // 退会後はNULL
+	Status       string     `gorm:"type:varchar(20);not null"`
+	FilePath     string     `gorm:"type:text;not null;default:''"`
+	ErrorMessage string     `gorm:"type:text;not null;default:''"`
+	RequestedAt  time.Time  `gorm:"type:timestamptz;not null"`
+	CompletedAt  *time.Time `gorm:"type:timestamptz"`
+	ExpiresAt    *time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName はテーブル名を指定
+func (DataExportModel) TableName() string {
+	return "data_exports"
+}
+
+// DataExportDataSource は個人データエクスポート依頼のデータソース
+type DataExportDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewDataExportDataSource は新しいDataExportDataSourceを作成
+func NewDataExportDataSource(db infrapostgres.DB) *DataExportDataSource {
+	return &DataExportDataSource{db: db}
+}
+
+func (ds *DataExportDataSource) toEntity(m *DataExportModel) *entities.DataExport {
+	return &entities.DataExport{
+		ID:           m.ID,
+		UserID:       m.UserID,
+		Status:       entities.DataExportStatus(m.Status),
+		FilePath:     m.FilePath,
+		ErrorMessage: m.ErrorMessage,
+		RequestedAt:  m.RequestedAt,
+		CompletedAt:  m.CompletedAt,
+		ExpiresAt:    m.ExpiresAt,
+	}
+}
+
+func (ds *DataExportDataSource) toModel(e *entities.DataExport) *DataExportModel {
+	return &DataExportModel{
+		ID:           e.ID,
+		UserID:       e.UserID,
+		Status:       string(e.Status),
+		FilePath:     e.FilePath,
+		ErrorMessage: e.ErrorMessage,
+		RequestedAt:  e.RequestedAt,
+		CompletedAt:  e.CompletedAt,
+		ExpiresAt:    e.ExpiresAt,
+	}
+}
+
+func (ds *DataExportDataSource) toEntities(models []DataExportModel) []*entities.DataExport {
+	exports := make([]*entities.DataExport, len(models))
+	for i := range models {
+		exports[i] = ds.toEntity(&models[i])
+	}
+	return exports
+}
+
+// Insert はエクスポート依頼を挿入
+func (ds *DataExportDataSource) Insert(ctx context.Context, export *entities.DataExport) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Create(ds.toModel(export)).Error
+}
+
+// SelectByID はIDでエクスポート依頼を取得
+func (ds *DataExportDataSource) SelectByID(ctx context.Context, id uuid.UUID) (*entities.DataExport, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m DataExportModel
+	if err := db.Where("id = ?", id).First(&m).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrDataExportNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// SelectLatestByUser はユーザーの最新のエクスポート依頼を取得（ない場合はnil）
+func (ds *DataExportDataSource) SelectLatestByUser(ctx context.Context, userID uuid.UUID) (*entities.DataExport, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m DataExportModel
+	if err := db.Where("user_id = ?", userID).Order("requested_at DESC").First(&m).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// ClaimPending は処理待ちの依頼を古い順に作成中へ更新して取得
+// 複数インスタンスで同じ依頼を処理しないよう、行ロックを取れたものだけを対象にする
+func (ds *DataExportDataSource) ClaimPending(ctx context.Context, limit int) ([]*entities.DataExport, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var models []DataExportModel
+	err := db.Raw(`UPDATE data_exports SET status = ?
+		WHERE id IN (
+			SELECT id FROM data_exports
+			WHERE status = ? AND user_id IS NOT NULL
+			ORDER BY requested_at ASC
+			LIMIT ?
+			`+forUpdate(db, "FOR UPDATE SKIP LOCKED")+`
+		)
+		RETURNING *`,
+		string(entities.DataExportStatusProcessing), string(entities.DataExportStatusPending), limit).
+		Scan(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return ds.toEntities(models), nil
+}
+
+// Update はエクスポート依頼を更新
+func (ds *DataExportDataSource) Update(ctx context.Context, export *entities.DataExport) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Model(&DataExportModel{}).
+		Where("id = ?", export.ID).
+		Updates(map[string]interface{}{
+			"status":        string(export.Status),
+			"file_path":     export.FilePath,
+			"error_message": export.ErrorMessage,
+			"completed_at":  export.CompletedAt,
+			"expires_at":    export.ExpiresAt,
+		}).Error
+}
+
+// SelectExpired はダウンロード期限を過ぎたか、退会によって不要になったファイルのある依頼を取得
+func (ds *DataExportDataSource) SelectExpired(ctx context.Context, now time.Time, limit int) ([]*entities.DataExport, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var models []DataExportModel
+	err := db.Where("status = ? AND (expires_at <= ? OR user_id IS NULL)", string(entities.DataExportStatusReady), now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return ds.toEntities(models), nil
+}
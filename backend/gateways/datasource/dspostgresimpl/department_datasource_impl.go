@@ -0,0 +1,245 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DepartmentModel は部署のGORMモデル
+type DepartmentModel struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;primary_key"`
+	Name               string     `gorm:"type:varchar(100);not null"`
+	ParentID           *uuid.UUID `gorm:"type:uuid"`
+	MonthlyGrantBudget *int64
+	CreatedAt          time.Time `gorm:"type:timestamptz;not null"`
+	UpdatedAt          time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (DepartmentModel) TableName() string {
+	return "departments"
+}
+
+// DepartmentDataSource は部署のデータソース（usersテーブルのdepartment_id列も扱う）
+type DepartmentDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewDepartmentDataSource は新しいDepartmentDataSourceを作成
+func NewDepartmentDataSource(db infrapostgres.DB) *DepartmentDataSource {
+	return &DepartmentDataSource{db: db}
+}
+
+func (ds *DepartmentDataSource) toEntity(m *DepartmentModel) *entities.Department {
+	return &entities.Department{
+		ID:                 m.ID,
+		Name:               m.Name,
+		ParentID:           m.ParentID,
+		MonthlyGrantBudget: m.MonthlyGrantBudget,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
+	}
+}
+
+// existsSibling は同じ親の下に同名の別の部署があるかを確認
+func (ds *DepartmentDataSource) existsSibling(db *gorm.DB, d *entities.Department) (bool, error) {
+	query := db.Model(&DepartmentModel{}).Where("name = ? AND id <> ?", d.Name, d.ID)
+	if d.ParentID != nil {
+		query = query.Where("parent_id = ?", *d.ParentID)
+	} else {
+		query = query.Where("parent_id IS NULL")
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Insert は部署を挿入
+func (ds *DepartmentDataSource) Insert(ctx context.Context, d *entities.Department) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	exists, err := ds.existsSibling(db, d)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return entities.ErrDepartmentExists
+	}
+	return db.Create(&DepartmentModel{
+		ID:                 d.ID,
+		Name:               d.Name,
+		ParentID:           d.ParentID,
+		MonthlyGrantBudget: d.MonthlyGrantBudget,
+		CreatedAt:          d.CreatedAt,
+		UpdatedAt:          d.UpdatedAt,
+	}).Error
+}
+
+// Select はIDで部署を取得
+func (ds *DepartmentDataSource) Select(ctx context.Context, id uuid.UUID) (*entities.Department, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return ds.selectOne(db, id)
+}
+
+// SelectForUpdate はIDで部署を行ロック付きで取得
+func (ds *DepartmentDataSource) SelectForUpdate(ctx context.Context, id uuid.UUID) (*entities.Department, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return ds.selectOne(db.Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (ds *DepartmentDataSource) selectOne(db *gorm.DB, id uuid.UUID) (*entities.Department, error) {
+	var m DepartmentModel
+	if err := db.Where("id = ?", id).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrDepartmentNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// SelectList は全部署を名前順に取得
+func (ds *DepartmentDataSource) SelectList(ctx context.Context) ([]*entities.Department, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var models []DepartmentModel
+	if err := db.Order("name ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	departments := make([]*entities.Department, len(models))
+	for i := range models {
+		departments[i] = ds.toEntity(&models[i])
+	}
+	return departments, nil
+}
+
+// Update は部署名・親・月間予算を更新
+func (ds *DepartmentDataSource) Update(ctx context.Context, d *entities.Department) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	exists, err := ds.existsSibling(db, d)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return entities.ErrDepartmentExists
+	}
+	result := db.Model(&DepartmentModel{}).
+		Where("id = ?", d.ID).
+		Updates(map[string]interface{}{
+			"name":                 d.Name,
+			"parent_id":            d.ParentID,
+			"monthly_grant_budget": d.MonthlyGrantBudget,
+			"updated_at":           d.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrDepartmentNotFound
+	}
+	return nil
+}
+
+// Delete は部署を削除（所属ユーザーの department_id は外部キーでNULLになる）
+func (ds *DepartmentDataSource) Delete(ctx context.Context, id uuid.UUID) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var children int64
+	if err := db.Model(&DepartmentModel{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+		return err
+	}
+	if children > 0 {
+		return entities.ErrDepartmentHasChildren
+	}
+	result := db.Where("id = ?", id).Delete(&DepartmentModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrDepartmentNotFound
+	}
+	return nil
+}
+
+// UpdateUserDepartment はユーザーの所属部署を設定・解除
+func (ds *DepartmentDataSource) UpdateUserDepartment(ctx context.Context, userID uuid.UUID, departmentID *uuid.UUID) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&UserModel{}).Where("id = ?", userID).Update("department_id", departmentID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrUserNotFound
+	}
+	return nil
+}
+
+// SumAdminGrantsSince はsince以降に部署の現在の所属ユーザーへ管理者が付与したポイントの合計を取得
+func (ds *DepartmentDataSource) SumAdminGrantsSince(ctx context.Context, departmentID uuid.UUID, since time.Time) (int64, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+
+	var result struct {
+		Total int64
+	}
+	err := db.Table("transactions t").
+		Select("COALESCE(SUM(t.amount), 0) as total").
+		Joins("JOIN users u ON u.id = t.to_user_id").
+		Where("u.department_id = ? AND t.transaction_type = ? AND t.status = ? AND t.created_at >= ?",
+			departmentID, string(entities.TransactionTypeAdminGrant), "completed", since).
+		Scan(&result).Error
+	if err != nil {
+		return 0, err
+	}
+	return result.Total, nil
+}
+
+// SelectBreakdown はsince以降の部署ごとの付与・利用ポイントを集計
+func (ds *DepartmentDataSource) SelectBreakdown(ctx context.Context, since time.Time) ([]*entities.DepartmentBreakdownResult, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+
+	var rows []struct {
+		DepartmentID uuid.UUID  `gorm:"column:department_id"`
+		Name         string     `gorm:"column:name"`
+		ParentID     *uuid.UUID `gorm:"column:parent_id"`
+		MemberCount  int64      `gorm:"column:member_count"`
+		Granted      int64      `gorm:"column:granted"`
+		Spent        int64      `gorm:"column:spent"`
+	}
+	args := map[string]interface{}{"since": since}
+	tenant := namedTenantCondition(ctx, "u.tenant_id", args)
+	err := db.Raw(`
+		SELECT d.id AS department_id, d.name, d.parent_id,
+			(SELECT COUNT(*) FROM users u WHERE u.department_id = d.id`+tenant+`) AS member_count,
+			COALESCE((SELECT SUM(t.amount) FROM transactions t JOIN users u ON u.id = t.to_user_id
+				WHERE u.department_id = d.id AND t.status = 'completed' AND t.created_at >= @since
+				AND t.transaction_type IN ('admin_grant', 'system_grant', 'onboarding_bonus')`+tenant+`), 0) AS granted,
+			COALESCE((SELECT SUM(t.amount) FROM transactions t JOIN users u ON u.id = t.from_user_id
+				WHERE u.department_id = d.id AND t.status = 'completed' AND t.created_at >= @since
+				AND t.transaction_type = 'admin_deduct'`+tenant+`), 0) AS spent
+		FROM departments d
+		ORDER BY d.name`,
+		args).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*entities.DepartmentBreakdownResult, 0, len(rows))
+	for _, r := range rows {
+		results = append(results, &entities.DepartmentBreakdownResult{
+			DepartmentID: r.DepartmentID,
+			Name:         r.Name,
+			ParentID:     r.ParentID,
+			MemberCount:  r.MemberCount,
+			Granted:      r.Granted,
+			Spent:        r.Spent,
+		})
+	}
+	return results, nil
+}
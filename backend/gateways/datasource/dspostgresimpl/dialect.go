@@ -0,0 +1,68 @@
+package dspostgresimpl
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// isSQLite はSQLite（infrasqlite）で動かしているかを返す
+// PostgreSQL固有の構文（行ロック・jsonb演算子・ILIKEなど）を使う箇所の切り替えに使う
+func isSQLite(db *gorm.DB) bool {
+	return db.Dialector.Name() == "sqlite"
+}
+
+// forUpdate は行ロックの句を返す（SQLiteは書き込みをDB単位で直列化するため不要）
+func forUpdate(db *gorm.DB, clause string) string {
+	if isSQLite(db) {
+		return ""
+	}
+	return clause
+}
+
+// sqliteTimeLayouts はSQLiteのドライバーが日時を文字列で保存する形式
+var sqliteTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// aggregatedTime はMAXなど集計関数の結果の日時を受け取る型
+// SQLiteでは式の列に型名が付かず、ドライバーが日時に変換せず文字列のまま返すため、文字列も解釈する
+type aggregatedTime struct {
+	time.Time
+}
+
+// Scan はDBから読み込む際の変換
+func (t *aggregatedTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case time.Time:
+		t.Time = v
+		return nil
+	case []byte:
+		return t.Scan(string(v))
+	case string:
+		s, _, _ := strings.Cut(v, " m=") // time.Time.Stringの単調時計の部分
+		for _, layout := range sqliteTimeLayouts {
+			if parsed, err := time.Parse(layout, s); err == nil {
+				t.Time = parsed
+				return nil
+			}
+		}
+		return fmt.Errorf("cannot parse %q as time", v)
+	case nil:
+		t.Time = time.Time{}
+		return nil
+	default:
+		return fmt.Errorf("unsupported time value %T", value)
+	}
+}
+
+// Value はDBへ書き込む際の変換
+func (t aggregatedTime) Value() (driver.Value, error) {
+	return t.Time, nil
+}
@@ -0,0 +1,323 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EarningRuleModel はポイント獲得ルールのGORMモデル
+type EarningRuleModel struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primary_key"`
+	Name             string     `gorm:"type:varchar(100);not null"`
+	TriggerType      string     `gorm:"type:varchar(50);not null;index"`
+	Points           int64      `gorm:"not null"`
+	Occurrence       int64      `gorm:"not null;default:0"`
+	MinAmount        int64      `gorm:"not null;default:0"`
+	OncePerUser      bool       `gorm:"not null;default:false"`
+	MaxGrantsPerUser int        `gorm:"not null;default:0"`
+	MaxGrantsTotal   int        `gorm:"not null;default:0"`
+	GrantCount       int        `gorm:"not null;default:0"`
+	StartsAt         time.Time  `gorm:"type:timestamptz;not null"`
+	EndsAt           *time.Time `gorm:"type:timestamptz"`
+	IsActive         bool       `gorm:"not null;default:true"`
+	CreatedBy        uuid.UUID  `gorm:"type:uuid"` // 作成者の退会後はNULL
+	CreatedAt        time.Time  `gorm:"type:timestamptz;not null"`
+	UpdatedAt        time.Time  `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (EarningRuleModel) TableName() string {
+	return "earning_rules"
+}
+
+// EarningRuleGrantModel はポイント獲得ルールによる付与の記録のGORMモデル
+type EarningRuleGrantModel struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key"`
+	RuleID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_earning_rule_grants_source"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_earning_rule_grants_source"`
+	SourceID      string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_earning_rule_grants_source"`
+	TransactionID uuid.UUID `gorm:"type:uuid"`
+	Points        int64     `gorm:"not null"`
+	CreatedAt     time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (EarningRuleGrantModel) TableName() string {
+	return "earning_rule_grants"
+}
+
+// EarningRuleDataSource はポイント獲得ルールのデータソース
+type EarningRuleDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewEarningRuleDataSource は新しいEarningRuleDataSourceを作成
+func NewEarningRuleDataSource(db infrapostgres.DB) *EarningRuleDataSource {
+	return &EarningRuleDataSource{db: db}
+}
+
+func (ds *EarningRuleDataSource) toEntity(m *EarningRuleModel) *entities.EarningRule {
+	return &entities.EarningRule{
+		ID:               m.ID,
+		Name:             m.Name,
+		Trigger:          entities.EarningTrigger(m.TriggerType),
+		Points:           m.Points,
+		Occurrence:       m.Occurrence,
+		MinAmount:        m.MinAmount,
+		OncePerUser:      m.OncePerUser,
+		MaxGrantsPerUser: m.MaxGrantsPerUser,
+		MaxGrantsTotal:   m.MaxGrantsTotal,
+		GrantCount:       m.GrantCount,
+		StartsAt:         m.StartsAt,
+		EndsAt:           m.EndsAt,
+		IsActive:         m.IsActive,
+		CreatedBy:        m.CreatedBy,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+func (ds *EarningRuleDataSource) toModel(e *entities.EarningRule) *EarningRuleModel {
+	return &EarningRuleModel{
+		ID:               e.ID,
+		Name:             e.Name,
+		TriggerType:      string(e.Trigger),
+		Points:           e.Points,
+		Occurrence:       e.Occurrence,
+		MinAmount:        e.MinAmount,
+		OncePerUser:      e.OncePerUser,
+		MaxGrantsPerUser: e.MaxGrantsPerUser,
+		MaxGrantsTotal:   e.MaxGrantsTotal,
+		GrantCount:       e.GrantCount,
+		StartsAt:         e.StartsAt,
+		EndsAt:           e.EndsAt,
+		IsActive:         e.IsActive,
+		CreatedBy:        e.CreatedBy,
+		CreatedAt:        e.CreatedAt,
+		UpdatedAt:        e.UpdatedAt,
+	}
+}
+
+func (ds *EarningRuleDataSource) toEntities(models []EarningRuleModel) []*entities.EarningRule {
+	rules := make([]*entities.EarningRule, len(models))
+	for i := range models {
+		rules[i] = ds.toEntity(&models[i])
+	}
+	return rules
+}
+
+// Insert はポイント獲得ルールを挿入
+func (ds *EarningRuleDataSource) Insert(ctx context.Context, rule *entities.EarningRule) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Create(ds.toModel(rule)).Error
+}
+
+// SelectByID はIDでポイント獲得ルールを取得
+func (ds *EarningRuleDataSource) SelectByID(ctx context.Context, id uuid.UUID) (*entities.EarningRule, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m EarningRuleModel
+	if err := db.Where("id = ?", id).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrEarningRuleNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// Update はポイント獲得ルールを更新（付与済みの回数は変えない）
+func (ds *EarningRuleDataSource) Update(ctx context.Context, rule *entities.EarningRule) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&EarningRuleModel{}).
+		Where("id = ?", rule.ID).
+		Updates(map[string]interface{}{
+			"name":                rule.Name,
+			"trigger_type":        string(rule.Trigger),
+			"points":              rule.Points,
+			"occurrence":          rule.Occurrence,
+			"min_amount":          rule.MinAmount,
+			"once_per_user":       rule.OncePerUser,
+			"max_grants_per_user": rule.MaxGrantsPerUser,
+			"max_grants_total":    rule.MaxGrantsTotal,
+			"starts_at":           rule.StartsAt,
+			"ends_at":             rule.EndsAt,
+			"is_active":           rule.IsActive,
+			"updated_at":          rule.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrEarningRuleNotFound
+	}
+	return nil
+}
+
+// Delete はポイント獲得ルールを削除（付与の記録も削除され、付与した取引は残る）
+func (ds *EarningRuleDataSource) Delete(ctx context.Context, id uuid.UUID) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Where("id = ?", id).Delete(&EarningRuleModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrEarningRuleNotFound
+	}
+	return nil
+}
+
+// SelectList はポイント獲得ルールを作成日時の新しい順に取得
+func (ds *EarningRuleDataSource) SelectList(ctx context.Context, offset, limit int) ([]*entities.EarningRule, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var models []EarningRuleModel
+	if err := db.Order("created_at DESC").Offset(offset).Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return ds.toEntities(models), nil
+}
+
+// Count はポイント獲得ルールの件数を取得
+func (ds *EarningRuleDataSource) Count(ctx context.Context) (int64, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var count int64
+	if err := db.Model(&EarningRuleModel{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// SelectActiveByTrigger は指定日時に有効な、きっかけが一致するルールを作成日時の古い順に取得
+func (ds *EarningRuleDataSource) SelectActiveByTrigger(ctx context.Context, trigger entities.EarningTrigger, now time.Time) ([]*entities.EarningRule, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var models []EarningRuleModel
+	err := db.Where("trigger_type = ? AND is_active = ? AND starts_at <= ? AND (ends_at IS NULL OR ends_at > ?)", string(trigger), true, now, now).
+		Order("created_at ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return ds.toEntities(models), nil
+}
+
+// CountOccurrences はユーザーのきっかけとなる出来事の回数を取得
+// プロフィールの完成は1人1回の出来事なので常に1
+func (ds *EarningRuleDataSource) CountOccurrences(ctx context.Context, userID uuid.UUID, trigger entities.EarningTrigger) (int64, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var count int64
+	var err error
+	switch trigger {
+	case entities.EarningTriggerTransferSent:
+		err = db.Model(&TransactionModel{}).
+			Where("from_user_id = ? AND transaction_type = ? AND status = ?",
+				userID, string(entities.TransactionTypeTransfer), string(entities.TransactionStatusCompleted)).
+			Count(&count).Error
+	case entities.EarningTriggerFriendAdded:
+		err = db.Model(&FriendshipModel{}).
+			Where("(requester_id = ? OR addressee_id = ?) AND status = ?",
+				userID, userID, string(entities.FriendshipStatusAccepted)).
+			Count(&count).Error
+	default:
+		count = 1
+	}
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// IncrementGrantCount はルール全体の上限に達していなければ付与済みの回数を1増やす
+// 行ロックを取る条件付き更新のため、同時に付与しても上限を超えない
+func (ds *EarningRuleDataSource) IncrementGrantCount(ctx context.Context, ruleID uuid.UUID) (bool, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&EarningRuleModel{}).
+		Where("id = ? AND (max_grants_total = 0 OR grant_count < max_grants_total)", ruleID).
+		Update("grant_count", gorm.Expr("grant_count + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CountUserGrants はユーザーにルールで付与した回数を取得
+func (ds *EarningRuleDataSource) CountUserGrants(ctx context.Context, ruleID, userID uuid.UUID) (int, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var count int64
+	if err := db.Model(&EarningRuleGrantModel{}).
+		Where("rule_id = ? AND user_id = ?", ruleID, userID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// InsertGrant は付与を記録（同じルール・ユーザー・発生元で記録済みならfalse）
+func (ds *EarningRuleDataSource) InsertGrant(ctx context.Context, grant *entities.EarningRuleGrant) (bool, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "rule_id"}, {Name: "user_id"}, {Name: "source_id"}},
+		DoNothing: true,
+	}).Create(&EarningRuleGrantModel{
+		ID:            grant.ID,
+		RuleID:        grant.RuleID,
+		UserID:        grant.UserID,
+		SourceID:      grant.SourceID,
+		TransactionID: grant.TransactionID,
+		Points:        grant.Points,
+		CreatedAt:     grant.CreatedAt,
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SelectStats はルールごとの付与の実績を取得
+func (ds *EarningRuleDataSource) SelectStats(ctx context.Context, ruleIDs []uuid.UUID) (map[uuid.UUID]*entities.EarningRuleStats, error) {
+	stats := make(map[uuid.UUID]*entities.EarningRuleStats, len(ruleIDs))
+	if len(ruleIDs) == 0 {
+		return stats, nil
+	}
+
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var rows []struct {
+		RuleID        uuid.UUID
+		GrantCount    int64
+		UserCount     int64
+		PointsGranted int64
+	}
+	err := db.Model(&EarningRuleGrantModel{}).
+		Select("rule_id, COUNT(*) AS grant_count, COUNT(DISTINCT user_id) AS user_count, COALESCE(SUM(points), 0) AS points_granted").
+		Where("rule_id IN ?", ruleIDs).
+		Group("rule_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		stats[r.RuleID] = &entities.EarningRuleStats{
+			RuleID:        r.RuleID,
+			GrantCount:    r.GrantCount,
+			UserCount:     r.UserCount,
+			PointsGranted: r.PointsGranted,
+		}
+	}
+
+	// 最後に付与した日時（集計関数の結果は時刻型として読めないドライバがあるため並べ替えて取る）
+	for id, s := range stats {
+		var last EarningRuleGrantModel
+		if err := db.Where("rule_id = ?", id).Order("created_at DESC").Limit(1).Find(&last).Error; err != nil {
+			return nil, err
+		}
+		if !last.CreatedAt.IsZero() {
+			s.LastGrantedAt = &last.CreatedAt
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,135 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EmailTemplateModel はメールテンプレートのGORMモデル
+type EmailTemplateModel struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key"`
+	TemplateKey string     `gorm:"type:varchar(50);not null;uniqueIndex:idx_email_templates_key_version"`
+	Version     int        `gorm:"not null;uniqueIndex:idx_email_templates_key_version"`
+	Subject     string     `gorm:"type:varchar(200);not null"`
+	HTMLBody    string     `gorm:"column:html_body;type:text;not null;default:''"`
+	TextBody    string     `gorm:"type:text;not null"`
+	CreatedBy   *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt   time.Time  `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (EmailTemplateModel) TableName() string {
+	return "email_templates"
+}
+
+// EmailTemplateDataSource はメールテンプレートのデータソース
+type EmailTemplateDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewEmailTemplateDataSource は新しいEmailTemplateDataSourceを作成
+func NewEmailTemplateDataSource(db infrapostgres.DB) *EmailTemplateDataSource {
+	return &EmailTemplateDataSource{db: db}
+}
+
+func (ds *EmailTemplateDataSource) toEntity(m *EmailTemplateModel) *entities.EmailTemplate {
+	return &entities.EmailTemplate{
+		ID:        m.ID,
+		Key:       entities.EmailTemplateKey(m.TemplateKey),
+		Version:   m.Version,
+		Subject:   m.Subject,
+		HTMLBody:  m.HTMLBody,
+		TextBody:  m.TextBody,
+		CreatedBy: m.CreatedBy,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// Insert はテンプレートの版を挿入（同じ版が既にあれば一意制約違反）
+func (ds *EmailTemplateDataSource) Insert(ctx context.Context, t *entities.EmailTemplate) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Create(&EmailTemplateModel{
+		ID:          t.ID,
+		TemplateKey: string(t.Key),
+		Version:     t.Version,
+		Subject:     t.Subject,
+		HTMLBody:    t.HTMLBody,
+		TextBody:    t.TextBody,
+		CreatedBy:   t.CreatedBy,
+		CreatedAt:   t.CreatedAt,
+	}).Error
+}
+
+// SelectLatest はメールの種類の最新の版を取得
+func (ds *EmailTemplateDataSource) SelectLatest(ctx context.Context, key entities.EmailTemplateKey) (*entities.EmailTemplate, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m EmailTemplateModel
+	if err := db.Where("template_key = ?", string(key)).Order("version DESC").First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrEmailTemplateNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// SelectVersion は指定した版を取得
+func (ds *EmailTemplateDataSource) SelectVersion(ctx context.Context, key entities.EmailTemplateKey, version int) (*entities.EmailTemplate, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m EmailTemplateModel
+	if err := db.Where("template_key = ? AND version = ?", string(key), version).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrEmailTemplateNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// SelectVersions はメールの種類のすべての版を新しい順に取得
+func (ds *EmailTemplateDataSource) SelectVersions(ctx context.Context, key entities.EmailTemplateKey) ([]*entities.EmailTemplate, error) {
+	return ds.selectList(ctx, "template_key = ?", string(key))
+}
+
+// SelectLatestList はメールの種類ごとに最新の版を取得
+func (ds *EmailTemplateDataSource) SelectLatestList(ctx context.Context) ([]*entities.EmailTemplate, error) {
+	all, err := ds.selectList(ctx, "1 = 1")
+	if err != nil {
+		return nil, err
+	}
+	// 種類は十数件なので、新しい順に並べて最初の版だけ残す
+	seen := make(map[entities.EmailTemplateKey]bool)
+	latest := make([]*entities.EmailTemplate, 0)
+	for _, t := range all {
+		if !seen[t.Key] {
+			seen[t.Key] = true
+			latest = append(latest, t)
+		}
+	}
+	return latest, nil
+}
+
+func (ds *EmailTemplateDataSource) selectList(ctx context.Context, query string, args ...interface{}) ([]*entities.EmailTemplate, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var models []EmailTemplateModel
+	if err := db.Where(query, args...).Order("template_key ASC, version DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	templates := make([]*entities.EmailTemplate, len(models))
+	for i := range models {
+		templates[i] = ds.toEntity(&models[i])
+	}
+	return templates, nil
+}
+
+// DeleteByKey はメールの種類のすべての版を削除
+func (ds *EmailTemplateDataSource) DeleteByKey(ctx context.Context, key entities.EmailTemplateKey) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Where("template_key = ?", string(key)).Delete(&EmailTemplateModel{}).Error
+}
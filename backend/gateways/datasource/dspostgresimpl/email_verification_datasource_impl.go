@@ -21,6 +21,11 @@ type EmailVerificationTokenModel struct {
 	ExpiresAt  time.Time  `gorm:"not null"`
 	CreatedAt  time.Time  `gorm:"not null;default:now()"`
 	VerifiedAt *time.Time
+
+	OldEmail            *string `gorm:"type:varchar(255)"`
+	OldEmailToken       *string `gorm:"type:varchar(255)"`
+	OldEmailConfirmedAt *time.Time
+	AppliedAt           *time.Time
 }
 
 // TableName はテーブル名を指定
@@ -39,6 +44,11 @@ func (m *EmailVerificationTokenModel) ToDomain() *entities.EmailVerificationToke
 		ExpiresAt:  m.ExpiresAt,
 		CreatedAt:  m.CreatedAt,
 		VerifiedAt: m.VerifiedAt,
+
+		OldEmail:            m.OldEmail,
+		OldEmailToken:       m.OldEmailToken,
+		OldEmailConfirmedAt: m.OldEmailConfirmedAt,
+		AppliedAt:           m.AppliedAt,
 	}
 }
 
@@ -52,6 +62,10 @@ func (m *EmailVerificationTokenModel) FromDomain(token *entities.EmailVerificati
 	m.ExpiresAt = token.ExpiresAt
 	m.CreatedAt = token.CreatedAt
 	m.VerifiedAt = token.VerifiedAt
+	m.OldEmail = token.OldEmail
+	m.OldEmailToken = token.OldEmailToken
+	m.OldEmailConfirmedAt = token.OldEmailConfirmedAt
+	m.AppliedAt = token.AppliedAt
 }
 
 // EmailVerificationDataSourceImpl はEmailVerificationDataSourceの実装
@@ -87,6 +101,21 @@ func (ds *EmailVerificationDataSourceImpl) SelectByToken(ctx context.Context, to
 	return model.ToDomain(), nil
 }
 
+// SelectByOldEmailToken は旧アドレス確認用トークンで検索
+func (ds *EmailVerificationDataSourceImpl) SelectByOldEmailToken(ctx context.Context, token string) (*entities.EmailVerificationToken, error) {
+	var model EmailVerificationTokenModel
+
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("old_email_token = ?", token).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("token not found")
+		}
+		return nil, err
+	}
+
+	return model.ToDomain(), nil
+}
+
 // Update はトークン情報を更新
 func (ds *EmailVerificationDataSourceImpl) Update(ctx context.Context, token *entities.EmailVerificationToken) error {
 	model := &EmailVerificationTokenModel{}
@@ -95,7 +124,9 @@ func (ds *EmailVerificationDataSourceImpl) Update(ctx context.Context, token *en
 	return infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&EmailVerificationTokenModel{}).
 		Where("id = ?", token.ID).
 		Updates(map[string]interface{}{
-			"verified_at": model.VerifiedAt,
+			"verified_at":            model.VerifiedAt,
+			"old_email_confirmed_at": model.OldEmailConfirmedAt,
+			"applied_at":             model.AppliedAt,
 		}).Error
 }
 
@@ -112,3 +143,12 @@ func (ds *EmailVerificationDataSourceImpl) DeleteByUserID(ctx context.Context, u
 		Where("user_id = ?", userID).
 		Delete(&EmailVerificationTokenModel{}).Error
 }
+
+// CountByUserIDSince はユーザーに指定日時以降に発行した種類ごとのトークン数を取得
+func (ds *EmailVerificationDataSourceImpl) CountByUserIDSince(ctx context.Context, userID uuid.UUID, tokenType entities.TokenType, since time.Time) (int64, error) {
+	var count int64
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&EmailVerificationTokenModel{}).
+		Where("user_id = ? AND token_type = ? AND created_at >= ?", userID, string(tokenType), since).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,238 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EventModel はチェックインイベントのGORMモデル
+type EventModel struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key"`
+	Name          string    `gorm:"type:varchar(100);not null"`
+	Description   string    `gorm:"type:varchar(500);not null;default:''"`
+	Points        int64     `gorm:"not null"`
+	Capacity      int       `gorm:"not null;default:0"`
+	AttendeeCount int       `gorm:"not null;default:0"`
+	Code          string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	StartsAt      time.Time `gorm:"type:timestamptz;not null"`
+	EndsAt        time.Time `gorm:"type:timestamptz;not null"`
+	IsActive      bool      `gorm:"not null;default:true"`
+	CreatedBy     uuid.UUID `gorm:"type:uuid"` // 作成者の退会後はNULL
+	CreatedAt     time.Time `gorm:"type:timestamptz;not null"`
+	UpdatedAt     time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (EventModel) TableName() string {
+	return "events"
+}
+
+// EventAttendanceModel はイベントへのチェックインのGORMモデル
+type EventAttendanceModel struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key"`
+	EventID       uuid.UUID `gorm:"type:uuid;not null"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null"`
+	Points        int64     `gorm:"not null"`
+	TransactionID uuid.UUID `gorm:"type:uuid"`
+	CreatedAt     time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (EventAttendanceModel) TableName() string {
+	return "event_attendances"
+}
+
+// EventDataSource はチェックインイベントのデータソース
+type EventDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewEventDataSource は新しいEventDataSourceを作成
+func NewEventDataSource(db infrapostgres.DB) *EventDataSource {
+	return &EventDataSource{db: db}
+}
+
+func (ds *EventDataSource) toEntity(m *EventModel) *entities.Event {
+	return &entities.Event{
+		ID:            m.ID,
+		Name:          m.Name,
+		Description:   m.Description,
+		Points:        m.Points,
+		Capacity:      m.Capacity,
+		AttendeeCount: m.AttendeeCount,
+		Code:          m.Code,
+		StartsAt:      m.StartsAt,
+		EndsAt:        m.EndsAt,
+		IsActive:      m.IsActive,
+		CreatedBy:     m.CreatedBy,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+// Insert はイベントを挿入
+func (ds *EventDataSource) Insert(ctx context.Context, e *entities.Event) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Create(&EventModel{
+		ID:            e.ID,
+		Name:          e.Name,
+		Description:   e.Description,
+		Points:        e.Points,
+		Capacity:      e.Capacity,
+		AttendeeCount: e.AttendeeCount,
+		Code:          e.Code,
+		StartsAt:      e.StartsAt,
+		EndsAt:        e.EndsAt,
+		IsActive:      e.IsActive,
+		CreatedBy:     e.CreatedBy,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}).Error
+}
+
+// SelectByID はIDでイベントを取得
+func (ds *EventDataSource) SelectByID(ctx context.Context, id uuid.UUID) (*entities.Event, error) {
+	return ds.selectOne(ctx, "id = ?", id)
+}
+
+// SelectByCode はQRコードのコードでイベントを取得
+func (ds *EventDataSource) SelectByCode(ctx context.Context, code string) (*entities.Event, error) {
+	return ds.selectOne(ctx, "code = ?", code)
+}
+
+func (ds *EventDataSource) selectOne(ctx context.Context, query string, arg interface{}) (*entities.Event, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m EventModel
+	if err := db.Where(query, arg).First(&m).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrEventNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// Update はイベントの内容を更新（attendee_countはチェックイン時にだけ更新する）
+func (ds *EventDataSource) Update(ctx context.Context, e *entities.Event) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&EventModel{}).
+		Where("id = ?", e.ID).
+		Updates(map[string]interface{}{
+			"name":        e.Name,
+			"description": e.Description,
+			"points":      e.Points,
+			"capacity":    e.Capacity,
+			"starts_at":   e.StartsAt,
+			"ends_at":     e.EndsAt,
+			"is_active":   e.IsActive,
+			"updated_at":  e.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrEventNotFound
+	}
+	return nil
+}
+
+// SelectList はイベントを開始日時の新しい順に取得
+func (ds *EventDataSource) SelectList(ctx context.Context, offset, limit int) ([]*entities.Event, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var models []EventModel
+	if err := db.Order("starts_at DESC").Offset(offset).Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	events := make([]*entities.Event, len(models))
+	for i := range models {
+		events[i] = ds.toEntity(&models[i])
+	}
+	return events, nil
+}
+
+// Count はイベントの件数を取得
+func (ds *EventDataSource) Count(ctx context.Context) (int64, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var count int64
+	if err := db.Model(&EventModel{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// IncrementAttendeeCount は定員に空きがあればチェックイン済みの人数を1増やす（定員に達していればfalse）
+// 行ロックを取る条件付き更新のため、同時にチェックインしても定員を超えない
+func (ds *EventDataSource) IncrementAttendeeCount(ctx context.Context, id uuid.UUID) (bool, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&EventModel{}).
+		Where("id = ? AND (capacity = 0 OR attendee_count < capacity)", id).
+		Update("attendee_count", gorm.Expr("attendee_count + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// InsertAttendance はチェックインを挿入（同じユーザーが既にチェックイン済みならErrEventAlreadyCheckedIn）
+func (ds *EventDataSource) InsertAttendance(ctx context.Context, a *entities.EventAttendance) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}, {Name: "user_id"}},
+		DoNothing: true,
+	}).Create(&EventAttendanceModel{
+		ID:            a.ID,
+		EventID:       a.EventID,
+		UserID:        a.UserID,
+		Points:        a.Points,
+		TransactionID: a.TransactionID,
+		CreatedAt:     a.CreatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrEventAlreadyCheckedIn
+	}
+	return nil
+}
+
+// SelectAttendees はイベントの参加者をチェックインの早い順に取得
+func (ds *EventDataSource) SelectAttendees(ctx context.Context, eventID uuid.UUID, offset, limit int) ([]*entities.EventAttendee, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var rows []struct {
+		UserID      uuid.UUID
+		Username    string
+		DisplayName string
+		Points      int64
+		CheckedInAt time.Time
+	}
+	err := db.Table("event_attendances a").
+		Select("a.user_id, u.username, u.display_name, a.points, a.created_at AS checked_in_at").
+		Joins("JOIN users u ON u.id = a.user_id").
+		Where("a.event_id = ?", eventID).
+		Order("a.created_at ASC").
+		Offset(offset).
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	attendees := make([]*entities.EventAttendee, 0, len(rows))
+	for _, r := range rows {
+		attendees = append(attendees, &entities.EventAttendee{
+			UserID:      r.UserID,
+			Username:    r.Username,
+			DisplayName: r.DisplayName,
+			Points:      r.Points,
+			CheckedInAt: r.CheckedInAt,
+		})
+	}
+	return attendees, nil
+}
@@ -0,0 +1,171 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FailedAkerunAccessModel はボーナスの付与に失敗したAkerunアクセス記録のGORMモデル
+type FailedAkerunAccessModel struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key"`
+	AccessID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	UserName    string    `gorm:"type:text;not null"`
+	AccessedAt  time.Time `gorm:"type:timestamptz;not null"`
+	Status      string    `gorm:"type:varchar(10);not null"`
+	Attempts    int       `gorm:"not null;default:0"`
+	LastError   string    `gorm:"type:text;not null;default:''"`
+	NextRetryAt time.Time `gorm:"type:timestamptz;not null"`
+	CreatedAt   time.Time `gorm:"type:timestamptz;not null"`
+	UpdatedAt   time.Time `gorm:"type:timestamptz;not null"`
+
+	OrganizationID string `gorm:"type:text;not null;default:''"`
+	DoorID         string `gorm:"type:text;not null;default:''"`
+	DoorName       string `gorm:"type:text;not null;default:''"`
+}
+
+// TableName はテーブル名を指定
+func (FailedAkerunAccessModel) TableName() string {
+	return "failed_akerun_accesses"
+}
+
+// FailedAkerunAccessDataSource はボーナスの付与に失敗したAkerunアクセス記録のデータソース
+type FailedAkerunAccessDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewFailedAkerunAccessDataSource は新しいFailedAkerunAccessDataSourceを作成
+func NewFailedAkerunAccessDataSource(db infrapostgres.DB) *FailedAkerunAccessDataSource {
+	return &FailedAkerunAccessDataSource{db: db}
+}
+
+func (ds *FailedAkerunAccessDataSource) toEntity(m *FailedAkerunAccessModel) *entities.FailedAkerunAccess {
+	return &entities.FailedAkerunAccess{
+		ID:          m.ID,
+		AccessID:    m.AccessID,
+		UserName:    m.UserName,
+		AccessedAt:  m.AccessedAt,
+		Status:      entities.FailedAkerunAccessStatus(m.Status),
+		Attempts:    m.Attempts,
+		LastError:   m.LastError,
+		NextRetryAt: m.NextRetryAt,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+
+		OrganizationID: m.OrganizationID,
+		DoorID:         m.DoorID,
+		DoorName:       m.DoorName,
+	}
+}
+
+func (ds *FailedAkerunAccessDataSource) toEntities(models []FailedAkerunAccessModel) []*entities.FailedAkerunAccess {
+	accesses := make([]*entities.FailedAkerunAccess, len(models))
+	for i := range models {
+		accesses[i] = ds.toEntity(&models[i])
+	}
+	return accesses
+}
+
+// Insert は失敗したアクセス記録を挿入（access_idが登録済みなら何もしない）
+func (ds *FailedAkerunAccessDataSource) Insert(ctx context.Context, f *entities.FailedAkerunAccess) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "access_id"}},
+		DoNothing: true,
+	}).Create(&FailedAkerunAccessModel{
+		ID:          f.ID,
+		AccessID:    f.AccessID,
+		UserName:    f.UserName,
+		AccessedAt:  f.AccessedAt,
+		Status:      string(f.Status),
+		Attempts:    f.Attempts,
+		LastError:   f.LastError,
+		NextRetryAt: f.NextRetryAt,
+		CreatedAt:   f.CreatedAt,
+		UpdatedAt:   f.UpdatedAt,
+
+		OrganizationID: f.OrganizationID,
+		DoorID:         f.DoorID,
+		DoorName:       f.DoorName,
+	}).Error
+}
+
+// Select はIDで取得
+func (ds *FailedAkerunAccessDataSource) Select(ctx context.Context, id uuid.UUID) (*entities.FailedAkerunAccess, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m FailedAkerunAccessModel
+	if err := db.Where("id = ?", id).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrFailedAccessNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// SelectDue は再試行待ちのうちnowまでに再試行時刻を迎えたものを古い順に取得
+func (ds *FailedAkerunAccessDataSource) SelectDue(ctx context.Context, now time.Time, limit int) ([]*entities.FailedAkerunAccess, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var models []FailedAkerunAccessModel
+	if err := db.Where("status = ? AND next_retry_at <= ?", string(entities.FailedAkerunAccessPending), now).
+		Order("next_retry_at ASC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return ds.toEntities(models), nil
+}
+
+// SelectList は新しい順に取得（statusが空なら全状態）
+func (ds *FailedAkerunAccessDataSource) SelectList(ctx context.Context, status entities.FailedAkerunAccessStatus, offset, limit int) ([]*entities.FailedAkerunAccess, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	query := db.Model(&FailedAkerunAccessModel{})
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+	var models []FailedAkerunAccessModel
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return ds.toEntities(models), nil
+}
+
+// Count は件数を取得（statusが空なら全状態）
+func (ds *FailedAkerunAccessDataSource) Count(ctx context.Context, status entities.FailedAkerunAccessStatus) (int64, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	query := db.Model(&FailedAkerunAccessModel{})
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Update は状態・失敗回数・次回の再試行時刻を更新
+func (ds *FailedAkerunAccessDataSource) Update(ctx context.Context, f *entities.FailedAkerunAccess) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Model(&FailedAkerunAccessModel{}).
+		Where("id = ?", f.ID).
+		Updates(map[string]interface{}{
+			"status":        string(f.Status),
+			"attempts":      f.Attempts,
+			"last_error":    f.LastError,
+			"next_retry_at": f.NextRetryAt,
+			"updated_at":    f.UpdatedAt,
+		}).Error
+}
+
+// Delete はIDで削除
+func (ds *FailedAkerunAccessDataSource) Delete(ctx context.Context, id uuid.UUID) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Where("id = ?", id).Delete(&FailedAkerunAccessModel{}).Error
+}
@@ -0,0 +1,50 @@
+package dspostgresimpl
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+)
+
+// FriendDiscoveryDataSource は連絡先ハッシュによる友達検索のデータソース
+type FriendDiscoveryDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewFriendDiscoveryDataSource は新しいFriendDiscoveryDataSourceを作成
+func NewFriendDiscoveryDataSource(db infrapostgres.DB) *FriendDiscoveryDataSource {
+	return &FriendDiscoveryDataSource{db: db}
+}
+
+// SelectDiscoverableByHashes はメールアドレスまたはユーザー名のハッシュが一致する公開設定のユーザーを取得
+// 検索したユーザー本人と、既に友達またはブロック関係にあるユーザーは除外する
+func (ds *FriendDiscoveryDataSource) SelectDiscoverableByHashes(ctx context.Context, userID uuid.UUID, hashes []string) ([]*entities.User, error) {
+	if len(hashes) == 0 {
+		return []*entities.User{}, nil
+	}
+
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var models []UserModel
+	err := db.Model(&UserModel{}).
+		Where("discoverable = TRUE AND is_active = TRUE AND id <> ?", userID).
+		Where("email_sha256 IN ? OR username_sha256 IN ?", hashes, hashes).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM friendships f
+			WHERE f.status IN ('accepted', 'blocked')
+			  AND ((f.requester_id = ? AND f.addressee_id = users.id)
+			    OR (f.addressee_id = ? AND f.requester_id = users.id))
+		)`, userID, userID).
+		Order("username ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*entities.User, len(models))
+	for i := range models {
+		users[i] = models[i].ToDomain()
+	}
+	return users, nil
+}
@@ -0,0 +1,65 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// FriendPinModel は友達のピン留めのGORMモデル
+type FriendPinModel struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	FriendID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (FriendPinModel) TableName() string {
+	return "friend_pins"
+}
+
+// FriendPinDataSource は友達のピン留めのデータソース
+type FriendPinDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewFriendPinDataSource は新しいFriendPinDataSourceを作成
+func NewFriendPinDataSource(db infrapostgres.DB) *FriendPinDataSource {
+	return &FriendPinDataSource{db: db}
+}
+
+// Insert はピン留めを挿入（既にピン留めしていれば何もしない）
+func (ds *FriendPinDataSource) Insert(ctx context.Context, pin *entities.FriendPin) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&FriendPinModel{
+		UserID:    pin.UserID,
+		FriendID:  pin.FriendID,
+		CreatedAt: pin.CreatedAt,
+	}).Error
+}
+
+// Delete はピン留めを外す（ピン留めしていなければ何もしない）
+func (ds *FriendPinDataSource) Delete(ctx context.Context, userID, friendID uuid.UUID) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Where("user_id = ? AND friend_id = ?", userID, friendID).Delete(&FriendPinModel{}).Error
+}
+
+// SelectListByUserID はユーザーのピン留めをピン留めした順に取得
+func (ds *FriendPinDataSource) SelectListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.FriendPin, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var models []FriendPinModel
+	if err := db.Where("user_id = ?", userID).
+		Order("created_at ASC, friend_id ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	pins := make([]*entities.FriendPin, len(models))
+	for i, m := range models {
+		pins[i] = &entities.FriendPin{UserID: m.UserID, FriendID: m.FriendID, CreatedAt: m.CreatedAt}
+	}
+	return pins, nil
+}
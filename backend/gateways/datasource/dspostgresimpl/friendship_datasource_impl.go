@@ -163,6 +163,48 @@ func (ds *FriendshipDataSourceImpl) CountPendingRequests(ctx context.Context, us
 	return count, err
 }
 
+// friendIDsSubquery はユーザーの承認済みの友達のIDを返すサブクエリ
+func friendIDsSubquery(db *gorm.DB, userID uuid.UUID) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).
+		Model(&FriendshipModel{}).
+		Select("CASE WHEN requester_id = ? THEN addressee_id ELSE requester_id END", userID).
+		Where("(requester_id = ? OR addressee_id = ?) AND status = ?", userID, userID, "accepted")
+}
+
+// mutualFriendsQuery は2人のユーザーの両方と友達であるユーザーのクエリ
+func (ds *FriendshipDataSourceImpl) mutualFriendsQuery(ctx context.Context, userID1, userID2 uuid.UUID) *gorm.DB {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Model(&UserModel{}).
+		Where("id IN (?)", friendIDsSubquery(db, userID1)).
+		Where("id IN (?)", friendIDsSubquery(db, userID2))
+}
+
+// SelectListMutualFriends は2人のユーザーの共通の友達一覧を取得（ユーザー名順）
+func (ds *FriendshipDataSourceImpl) SelectListMutualFriends(ctx context.Context, userID1, userID2 uuid.UUID, offset, limit int) ([]*entities.User, error) {
+	var models []UserModel
+	err := ds.mutualFriendsQuery(ctx, userID1, userID2).
+		Order("username ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*entities.User, len(models))
+	for i := range models {
+		users[i] = models[i].ToDomain()
+	}
+	return users, nil
+}
+
+// CountMutualFriends は2人のユーザーの共通の友達の人数を取得
+func (ds *FriendshipDataSourceImpl) CountMutualFriends(ctx context.Context, userID1, userID2 uuid.UUID) (int64, error) {
+	var count int64
+	err := ds.mutualFriendsQuery(ctx, userID1, userID2).Count(&count).Error
+	return count, err
+}
+
 // Update は友達関係を更新
 func (ds *FriendshipDataSourceImpl) Update(ctx context.Context, friendship *entities.Friendship) error {
 	model := &FriendshipModel{}
@@ -221,7 +263,14 @@ func (ds *FriendshipDataSourceImpl) ArchiveAndDelete(ctx context.Context, id uui
 			return err
 		}
 
-		// 3. 元テーブルから削除
+		// 3. 2人の間のピン留めを外す（友達でなくなった相手はクイック送金に出さない）
+		if err := tx.Where("(user_id = ? AND friend_id = ?) OR (user_id = ? AND friend_id = ?)",
+			model.RequesterID, model.AddresseeID, model.AddresseeID, model.RequesterID).
+			Delete(&FriendPinModel{}).Error; err != nil {
+			return err
+		}
+
+		// 4. 元テーブルから削除
 		return tx.Where("id = ?", id).Delete(&FriendshipModel{}).Error
 	})
 }
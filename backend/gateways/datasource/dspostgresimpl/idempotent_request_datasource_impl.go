@@ -0,0 +1,135 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/gateways/repository/datasource/dsmysql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotentRequestModel はGORM用のIdempotency-Keyリクエストモデル
+type IdempotentRequestModel struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key"`
+	Scope        string     `gorm:"type:varchar(100);not null"`
+	Key          string     `gorm:"type:varchar(255);not null"`
+	Route        string     `gorm:"type:varchar(255);not null"`
+	RequestHash  string     `gorm:"type:varchar(64);not null"`
+	StatusCode   int        `gorm:"not null;default:0"`
+	ContentType  string     `gorm:"type:varchar(255);not null;default:''"`
+	ResponseBody []byte     `gorm:"type:bytea"`
+	CreatedAt    time.Time  `gorm:"not null;default:now()"`
+	ExpiresAt    time.Time  `gorm:"not null"`
+	CompletedAt  *time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName はテーブル名を指定
+func (IdempotentRequestModel) TableName() string {
+	return "idempotent_requests"
+}
+
+// ToDomain はドメインモデルに変換
+func (m *IdempotentRequestModel) ToDomain() *entities.IdempotentRequest {
+	return &entities.IdempotentRequest{
+		ID:           m.ID,
+		Scope:        m.Scope,
+		Key:          m.Key,
+		Route:        m.Route,
+		RequestHash:  m.RequestHash,
+		StatusCode:   m.StatusCode,
+		ContentType:  m.ContentType,
+		ResponseBody: m.ResponseBody,
+		CreatedAt:    m.CreatedAt,
+		ExpiresAt:    m.ExpiresAt,
+		CompletedAt:  m.CompletedAt,
+	}
+}
+
+// FromDomain はドメインモデルから変換
+func (m *IdempotentRequestModel) FromDomain(r *entities.IdempotentRequest) {
+	m.ID = r.ID
+	m.Scope = r.Scope
+	m.Key = r.Key
+	m.Route = r.Route
+	m.RequestHash = r.RequestHash
+	m.StatusCode = r.StatusCode
+	m.ContentType = r.ContentType
+	m.ResponseBody = r.ResponseBody
+	m.CreatedAt = r.CreatedAt
+	m.ExpiresAt = r.ExpiresAt
+	m.CompletedAt = r.CompletedAt
+}
+
+// IdempotentRequestDataSourceImpl はIdempotentRequestDataSourceの実装
+type IdempotentRequestDataSourceImpl struct {
+	db infrapostgres.DB
+}
+
+// NewIdempotentRequestDataSource は新しいIdempotentRequestDataSourceを作成
+func NewIdempotentRequestDataSource(db infrapostgres.DB) dsmysql.IdempotentRequestDataSource {
+	return &IdempotentRequestDataSourceImpl{db: db}
+}
+
+// InsertIfAbsent は同じ(scope, key, route)が無い場合のみ挿入し、挿入できたかを返す
+// 同時に届いた同じキーのリクエストは、一意制約によりどちらか一方だけが挿入できる
+func (ds *IdempotentRequestDataSourceImpl) InsertIfAbsent(ctx context.Context, req *entities.IdempotentRequest) (bool, error) {
+	model := &IdempotentRequestModel{}
+	model.FromDomain(req)
+
+	result := infrapostgres.GetDB(ctx, ds.db.GetDB()).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(model)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SelectByKey は(scope, key, route)で取得（存在しない場合はnil）
+func (ds *IdempotentRequestDataSourceImpl) SelectByKey(ctx context.Context, scope, key, route string) (*entities.IdempotentRequest, error) {
+	var model IdempotentRequestModel
+
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).
+		Where("scope = ? AND key = ? AND route = ?", scope, key, route).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.ToDomain(), nil
+}
+
+// Update は保存済みレスポンスを更新
+func (ds *IdempotentRequestDataSourceImpl) Update(ctx context.Context, req *entities.IdempotentRequest) error {
+	return infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&IdempotentRequestModel{}).
+		Where("id = ?", req.ID).
+		Updates(map[string]interface{}{
+			"status_code":   req.StatusCode,
+			"content_type":  req.ContentType,
+			"response_body": req.ResponseBody,
+			"completed_at":  req.CompletedAt,
+		}).Error
+}
+
+// Delete は削除
+func (ds *IdempotentRequestDataSourceImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return infrapostgres.GetDB(ctx, ds.db.GetDB()).
+		Where("id = ?", id).
+		Delete(&IdempotentRequestModel{}).Error
+}
+
+// DeleteExpired は指定時刻までに期限切れになったものを削除
+func (ds *IdempotentRequestDataSourceImpl) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := infrapostgres.GetDB(ctx, ds.db.GetDB()).
+		Where("expires_at <= ?", now).
+		Delete(&IdempotentRequestModel{})
+	return result.RowsAffected, result.Error
+}
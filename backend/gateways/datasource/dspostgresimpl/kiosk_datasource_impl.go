@@ -0,0 +1,279 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/gateways/repository/datasource/dsmysql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// KioskDeviceModel はGORM用のキオスク端末モデル
+type KioskDeviceModel struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name        string     `gorm:"type:varchar(100);not null"`
+	APIKeyHash  string     `gorm:"column:api_key_hash;type:varchar(64);not null;uniqueIndex"`
+	BonusAmount int64      `gorm:"not null"`
+	DailyLimit  int        `gorm:"not null;default:0"`
+	IsActive    bool       `gorm:"not null;default:true"`
+	LastSeenAt  *time.Time `gorm:"type:timestamptz"`
+	CreatedBy   *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt   time.Time  `gorm:"not null;default:now()"`
+	UpdatedAt   time.Time  `gorm:"not null;default:now()"`
+}
+
+// TableName はテーブル名を指定
+func (KioskDeviceModel) TableName() string {
+	return "kiosk_devices"
+}
+
+// ToDomain はドメインモデルに変換
+func (m *KioskDeviceModel) ToDomain() *entities.KioskDevice {
+	return &entities.KioskDevice{
+		ID:          m.ID,
+		Name:        m.Name,
+		APIKeyHash:  m.APIKeyHash,
+		BonusAmount: m.BonusAmount,
+		DailyLimit:  m.DailyLimit,
+		IsActive:    m.IsActive,
+		LastSeenAt:  m.LastSeenAt,
+		CreatedBy:   m.CreatedBy,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// FromDomain はドメインモデルから変換
+func (m *KioskDeviceModel) FromDomain(d *entities.KioskDevice) {
+	m.ID = d.ID
+	m.Name = d.Name
+	m.APIKeyHash = d.APIKeyHash
+	m.BonusAmount = d.BonusAmount
+	m.DailyLimit = d.DailyLimit
+	m.IsActive = d.IsActive
+	m.LastSeenAt = d.LastSeenAt
+	m.CreatedBy = d.CreatedBy
+	m.CreatedAt = d.CreatedAt
+	m.UpdatedAt = d.UpdatedAt
+}
+
+// KioskCardModel はGORM用のカード紐付けモデル
+type KioskCardModel struct {
+	CardID    string    `gorm:"type:varchar(100);primary_key"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	CreatedAt time.Time `gorm:"not null;default:now()"`
+}
+
+// TableName はテーブル名を指定
+func (KioskCardModel) TableName() string {
+	return "kiosk_cards"
+}
+
+// KioskGrantModel はGORM用のキオスク付与記録モデル
+type KioskGrantModel struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	DeviceID       uuid.UUID  `gorm:"type:uuid;not null"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null"`
+	TransactionID  *uuid.UUID `gorm:"type:uuid"`
+	Amount         int64      `gorm:"not null"`
+	IdempotencyKey string     `gorm:"type:varchar(255);not null"`
+	CreatedAt      time.Time  `gorm:"not null;default:now()"`
+}
+
+// TableName はテーブル名を指定
+func (KioskGrantModel) TableName() string {
+	return "kiosk_grants"
+}
+
+// ToDomain はドメインモデルに変換
+func (m *KioskGrantModel) ToDomain() *entities.KioskGrant {
+	return &entities.KioskGrant{
+		ID:             m.ID,
+		DeviceID:       m.DeviceID,
+		UserID:         m.UserID,
+		TransactionID:  m.TransactionID,
+		Amount:         m.Amount,
+		IdempotencyKey: m.IdempotencyKey,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+// KioskDataSourceImpl はKioskDataSourceの実装
+type KioskDataSourceImpl struct {
+	db infrapostgres.DB
+}
+
+// NewKioskDataSource は新しいKioskDataSourceを作成
+func NewKioskDataSource(db infrapostgres.DB) dsmysql.KioskDataSource {
+	return &KioskDataSourceImpl{db: db}
+}
+
+// InsertDevice は新しい端末を挿入
+func (ds *KioskDataSourceImpl) InsertDevice(ctx context.Context, device *entities.KioskDevice) error {
+	model := &KioskDeviceModel{}
+	model.FromDomain(device)
+
+	if err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Create(model).Error; err != nil {
+		return err
+	}
+
+	*device = *model.ToDomain()
+	return nil
+}
+
+// SelectDevice はIDで端末を検索
+func (ds *KioskDataSourceImpl) SelectDevice(ctx context.Context, id uuid.UUID) (*entities.KioskDevice, error) {
+	var model KioskDeviceModel
+
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("kiosk device not found")
+		}
+		return nil, err
+	}
+
+	return model.ToDomain(), nil
+}
+
+// SelectDeviceByAPIKeyHash はAPIキーハッシュで端末を検索
+func (ds *KioskDataSourceImpl) SelectDeviceByAPIKeyHash(ctx context.Context, apiKeyHash string) (*entities.KioskDevice, error) {
+	var model KioskDeviceModel
+
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("api_key_hash = ?", apiKeyHash).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("kiosk device not found")
+		}
+		return nil, err
+	}
+
+	return model.ToDomain(), nil
+}
+
+// SelectDeviceList は端末一覧を取得
+func (ds *KioskDataSourceImpl) SelectDeviceList(ctx context.Context) ([]*entities.KioskDevice, error) {
+	var models []KioskDeviceModel
+
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).
+		Order("created_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	devices := make([]*entities.KioskDevice, len(models))
+	for i, model := range models {
+		devices[i] = model.ToDomain()
+	}
+	return devices, nil
+}
+
+// UpdateDevice は端末情報を更新
+func (ds *KioskDataSourceImpl) UpdateDevice(ctx context.Context, device *entities.KioskDevice) error {
+	return infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&KioskDeviceModel{}).
+		Where("id = ?", device.ID).
+		Updates(map[string]interface{}{
+			"name":         device.Name,
+			"api_key_hash": device.APIKeyHash,
+			"bonus_amount": device.BonusAmount,
+			"daily_limit":  device.DailyLimit,
+			"is_active":    device.IsActive,
+			"updated_at":   time.Now(),
+		}).Error
+}
+
+// UpdateDeviceLastSeen は端末の最終アクセス日時を更新
+func (ds *KioskDataSourceImpl) UpdateDeviceLastSeen(ctx context.Context, id uuid.UUID, seenAt time.Time) error {
+	return infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&KioskDeviceModel{}).
+		Where("id = ?", id).
+		Update("last_seen_at", seenAt).Error
+}
+
+// InsertCard はカードIDとユーザーの紐付けを挿入
+func (ds *KioskDataSourceImpl) InsertCard(ctx context.Context, card *entities.KioskCard) error {
+	model := &KioskCardModel{
+		CardID:    card.CardID,
+		UserID:    card.UserID,
+		CreatedAt: card.CreatedAt,
+	}
+	return infrapostgres.GetDB(ctx, ds.db.GetDB()).Create(model).Error
+}
+
+// SelectCard はカードIDで紐付けを検索
+func (ds *KioskDataSourceImpl) SelectCard(ctx context.Context, cardID string) (*entities.KioskCard, error) {
+	var model KioskCardModel
+
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("card_id = ?", cardID).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("card not found")
+		}
+		return nil, err
+	}
+
+	return &entities.KioskCard{
+		CardID:    model.CardID,
+		UserID:    model.UserID,
+		CreatedAt: model.CreatedAt,
+	}, nil
+}
+
+// DeleteCard はカードIDの紐付けを削除
+func (ds *KioskDataSourceImpl) DeleteCard(ctx context.Context, cardID string) error {
+	return infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("card_id = ?", cardID).Delete(&KioskCardModel{}).Error
+}
+
+// InsertGrant は付与記録を挿入
+func (ds *KioskDataSourceImpl) InsertGrant(ctx context.Context, grant *entities.KioskGrant) error {
+	model := &KioskGrantModel{
+		ID:             grant.ID,
+		DeviceID:       grant.DeviceID,
+		UserID:         grant.UserID,
+		TransactionID:  grant.TransactionID,
+		Amount:         grant.Amount,
+		IdempotencyKey: grant.IdempotencyKey,
+		CreatedAt:      grant.CreatedAt,
+	}
+	return infrapostgres.GetDB(ctx, ds.db.GetDB()).Create(model).Error
+}
+
+// SelectGrantByIdempotencyKey は端末と冪等性キーで付与記録を検索（存在しない場合はnil）
+func (ds *KioskDataSourceImpl) SelectGrantByIdempotencyKey(ctx context.Context, deviceID uuid.UUID, key string) (*entities.KioskGrant, error) {
+	var model KioskGrantModel
+
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).
+		Where("device_id = ? AND idempotency_key = ?", deviceID, key).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.ToDomain(), nil
+}
+
+// CountGrantsByDeviceSince は端末の指定日時以降の付与回数を取得
+func (ds *KioskDataSourceImpl) CountGrantsByDeviceSince(ctx context.Context, deviceID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&KioskGrantModel{}).
+		Where("device_id = ? AND created_at >= ?", deviceID, since).
+		Count(&count).Error
+	return count, err
+}
+
+// ExistsGrantByDeviceAndUserSince は端末が指定日時以降にユーザーへ付与済みか確認
+func (ds *KioskDataSourceImpl) ExistsGrantByDeviceAndUserSince(ctx context.Context, deviceID, userID uuid.UUID, since time.Time) (bool, error) {
+	var count int64
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&KioskGrantModel{}).
+		Where("device_id = ? AND user_id = ? AND created_at >= ?", deviceID, userID, since).
+		Limit(1).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,246 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/gateways/repository/datasource/dsmysql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LoginAttemptModel はGORM用のログイン試行モデル
+type LoginAttemptModel struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID        *uuid.UUID `gorm:"type:uuid;index"`
+	Username      string     `gorm:"type:varchar(255);not null"`
+	IPAddress     string     `gorm:"type:varchar(100);not null;default:''"`
+	UserAgent     string     `gorm:"type:text;not null;default:''"`
+	Country       string     `gorm:"type:varchar(2);not null;default:''"`
+	Success       bool       `gorm:"not null"`
+	FailureReason string     `gorm:"type:varchar(50);not null;default:''"`
+	NewDevice     bool       `gorm:"not null;default:false"`
+	CreatedAt     time.Time  `gorm:"not null;default:now()"`
+}
+
+// TableName はテーブル名を指定
+func (LoginAttemptModel) TableName() string {
+	return "login_attempts"
+}
+
+// FromDomain はドメインモデルから変換
+func (m *LoginAttemptModel) FromDomain(a *entities.LoginAttempt) {
+	m.ID = a.ID
+	m.UserID = a.UserID
+	m.Username = a.Username
+	m.IPAddress = a.IPAddress
+	m.UserAgent = a.UserAgent
+	m.Country = a.Country
+	m.Success = a.Success
+	m.FailureReason = a.FailureReason
+	m.NewDevice = a.NewDevice
+	m.CreatedAt = a.CreatedAt
+}
+
+// ToDomain はドメインモデルに変換
+func (m *LoginAttemptModel) ToDomain() *entities.LoginAttempt {
+	return &entities.LoginAttempt{
+		ID:            m.ID,
+		UserID:        m.UserID,
+		Username:      m.Username,
+		IPAddress:     m.IPAddress,
+		UserAgent:     m.UserAgent,
+		Country:       m.Country,
+		Success:       m.Success,
+		FailureReason: m.FailureReason,
+		NewDevice:     m.NewDevice,
+		CreatedAt:     m.CreatedAt,
+	}
+}
+
+// AccountLockoutModel はGORM用のアカウントロックアウトモデル
+type AccountLockoutModel struct {
+	UserID               uuid.UUID  `gorm:"type:uuid;primary_key"`
+	FailedCount          int        `gorm:"not null;default:0"`
+	LockCount            int        `gorm:"not null;default:0"`
+	LockedUntil          *time.Time `gorm:"type:timestamptz"`
+	UnlockTokenHash      *string    `gorm:"type:varchar(64)"`
+	UnlockTokenExpiresAt *time.Time `gorm:"type:timestamptz"`
+	UpdatedAt            time.Time  `gorm:"not null;default:now()"`
+}
+
+// TableName はテーブル名を指定
+func (AccountLockoutModel) TableName() string {
+	return "account_lockouts"
+}
+
+// ToDomain はドメインモデルに変換
+func (m *AccountLockoutModel) ToDomain() *entities.AccountLockout {
+	lockout := &entities.AccountLockout{
+		UserID:               m.UserID,
+		FailedCount:          m.FailedCount,
+		LockCount:            m.LockCount,
+		LockedUntil:          m.LockedUntil,
+		UnlockTokenExpiresAt: m.UnlockTokenExpiresAt,
+		UpdatedAt:            m.UpdatedAt,
+	}
+	if m.UnlockTokenHash != nil {
+		lockout.UnlockTokenHash = *m.UnlockTokenHash
+	}
+	return lockout
+}
+
+// FromDomain はドメインモデルから変換
+func (m *AccountLockoutModel) FromDomain(l *entities.AccountLockout) {
+	m.UserID = l.UserID
+	m.FailedCount = l.FailedCount
+	m.LockCount = l.LockCount
+	m.LockedUntil = l.LockedUntil
+	m.UnlockTokenExpiresAt = l.UnlockTokenExpiresAt
+	m.UpdatedAt = l.UpdatedAt
+	// 空文字はNULLとして保存（部分ユニークインデックスのため）
+	m.UnlockTokenHash = nil
+	if l.UnlockTokenHash != "" {
+		hash := l.UnlockTokenHash
+		m.UnlockTokenHash = &hash
+	}
+}
+
+// LoginAttemptDataSourceImpl はLoginAttemptDataSourceの実装
+type LoginAttemptDataSourceImpl struct {
+	db infrapostgres.DB
+}
+
+// NewLoginAttemptDataSource は新しいLoginAttemptDataSourceを作成
+func NewLoginAttemptDataSource(db infrapostgres.DB) dsmysql.LoginAttemptDataSource {
+	return &LoginAttemptDataSourceImpl{db: db}
+}
+
+// Insert はログイン試行を挿入
+func (ds *LoginAttemptDataSourceImpl) Insert(ctx context.Context, attempt *entities.LoginAttempt) error {
+	model := &LoginAttemptModel{}
+	model.FromDomain(attempt)
+	return infrapostgres.GetDB(ctx, ds.db.GetDB()).Create(model).Error
+}
+
+// CountFailedByIPSince はIPアドレスの指定日時以降の失敗回数を取得
+func (ds *LoginAttemptDataSourceImpl) CountFailedByIPSince(ctx context.Context, ipAddress string, since time.Time) (int64, error) {
+	var count int64
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&LoginAttemptModel{}).
+		Where("ip_address = ? AND success = ? AND created_at >= ?", ipAddress, false, since).
+		Count(&count).Error
+	return count, err
+}
+
+// CountSuccessByUser はユーザーのログイン成功回数を取得
+func (ds *LoginAttemptDataSourceImpl) CountSuccessByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&LoginAttemptModel{}).
+		Where("user_id = ? AND success = ?", userID, true).
+		Count(&count).Error
+	return count, err
+}
+
+// ExistsSuccessByUserAndUserAgent は同じUser-Agentでのログイン成功履歴があるか確認
+func (ds *LoginAttemptDataSourceImpl) ExistsSuccessByUserAndUserAgent(ctx context.Context, userID uuid.UUID, userAgent string) (bool, error) {
+	var count int64
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&LoginAttemptModel{}).
+		Where("user_id = ? AND success = ? AND user_agent = ?", userID, true, userAgent).
+		Limit(1).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ExistsSuccessByUserAndCountry は同じ国からのログイン成功履歴があるか確認
+func (ds *LoginAttemptDataSourceImpl) ExistsSuccessByUserAndCountry(ctx context.Context, userID uuid.UUID, country string) (bool, error) {
+	var count int64
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&LoginAttemptModel{}).
+		Where("user_id = ? AND success = ? AND country = ?", userID, true, country).
+		Limit(1).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ExistsSuccessByUserAndIP は同じIPアドレスからのログイン成功履歴があるか確認
+func (ds *LoginAttemptDataSourceImpl) ExistsSuccessByUserAndIP(ctx context.Context, userID uuid.UUID, ipAddress string) (bool, error) {
+	var count int64
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&LoginAttemptModel{}).
+		Where("user_id = ? AND success = ? AND ip_address = ?", userID, true, ipAddress).
+		Limit(1).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// SelectListByUserID はユーザーのログイン試行を新しい順に取得
+func (ds *LoginAttemptDataSourceImpl) SelectListByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.LoginAttempt, error) {
+	var models []LoginAttemptModel
+	err := infrapostgres.GetReadDB(ctx, ds.db).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := make([]*entities.LoginAttempt, len(models))
+	for i := range models {
+		attempts[i] = models[i].ToDomain()
+	}
+	return attempts, nil
+}
+
+// CountByUserID はユーザーのログイン試行の件数を取得
+func (ds *LoginAttemptDataSourceImpl) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := infrapostgres.GetReadDB(ctx, ds.db).Model(&LoginAttemptModel{}).
+		Where("user_id = ?", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// SelectLockout はユーザーのロックアウト状態を取得（存在しない場合はnil）
+func (ds *LoginAttemptDataSourceImpl) SelectLockout(ctx context.Context, userID uuid.UUID) (*entities.AccountLockout, error) {
+	var model AccountLockoutModel
+
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("user_id = ?", userID).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.ToDomain(), nil
+}
+
+// SelectLockoutByUnlockTokenHash はロック解除トークンのハッシュでロックアウト状態を取得
+func (ds *LoginAttemptDataSourceImpl) SelectLockoutByUnlockTokenHash(ctx context.Context, tokenHash string) (*entities.AccountLockout, error) {
+	var model AccountLockoutModel
+
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("unlock_token_hash = ?", tokenHash).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("unlock token not found")
+		}
+		return nil, err
+	}
+
+	return model.ToDomain(), nil
+}
+
+// UpsertLockout はロックアウト状態を保存（存在しない場合は挿入）
+func (ds *LoginAttemptDataSourceImpl) UpsertLockout(ctx context.Context, lockout *entities.AccountLockout) error {
+	model := &AccountLockoutModel{}
+	model.FromDomain(lockout)
+
+	return infrapostgres.GetDB(ctx, ds.db.GetDB()).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"failed_count", "lock_count", "locked_until", "unlock_token_hash", "unlock_token_expires_at", "updated_at"}),
+	}).Create(model).Error
+}
@@ -0,0 +1,76 @@
+package dspostgresimpl
+
+// Models はすべてのテーブルのGORMモデルを返す
+// PostgreSQLのスキーマは migrations/ のSQLで管理するため、これはSQLiteなどでモデルからスキーマを作る場合に使う
+func Models() []interface{} {
+	return []interface{}{
+		&TenantModel{},
+		&UserModel{},
+		&ArchivedUserModel{},
+		&SessionModel{},
+		&SessionBindingViolationModel{},
+		&LoginAttemptModel{},
+		&AccountLockoutModel{},
+		&EmailVerificationTokenModel{},
+		&UsernameChangeHistoryModel{},
+		&PasswordChangeHistoryModel{},
+		&TransactionModel{},
+		&ArchivedTransactionModel{},
+		&TransactionArchiveSummaryModel{},
+		&IdempotencyKeyModel{},
+		&IdempotentRequestModel{},
+		&TransferRequestModel{},
+		&SplitRequestModel{},
+		&RecurringTransferModel{},
+		&SuspiciousActivityModel{},
+		&PointBatchModel{},
+		&PointBatchAdjustmentModel{},
+		&PointExpiryPolicyModel{},
+		&UserPointExpiryOverrideModel{},
+		&FriendshipModel{},
+		&FriendshipArchiveModel{},
+		&QRCodeModel{},
+		&DailyBonusModel{},
+		&AkerunPollStateModel{},
+		&AkerunPollCursorModel{},
+		&FailedAkerunAccessModel{},
+		&ProcessedAkerunAccessModel{},
+		&AkerunRepollModel{},
+		&LotteryTierModel{},
+		&CategoryModel{},
+		&ProductModel{},
+		&ProductExchangeModel{},
+		&CartItemModel{},
+		&ShippingAddressModel{},
+		&FriendPinModel{},
+		&MutedSenderModel{},
+		&BalanceHoldModel{},
+		&TransferAttachmentModel{},
+		&EmailTemplateModel{},
+		&PricingRuleModel{},
+		&EarningRuleModel{},
+		&EarningRuleGrantModel{},
+		&SystemSettingModel{},
+		&ReasonCodeModel{},
+		&DepartmentModel{},
+		&BudgetModel{},
+		&BudgetUsageModel{},
+		&PendingAdminActionModel{},
+		&PendingAdminActionEventModel{},
+		&AnnouncementModel{},
+		&AnnouncementDismissalModel{},
+		&ContentViolationModel{},
+		&DataExportModel{},
+		&EventModel{},
+		&EventAttendanceModel{},
+		&KioskDeviceModel{},
+		&KioskCardModel{},
+		&KioskGrantModel{},
+		&DeviceTokenModel{},
+		&NotificationPreferencesModel{},
+		&ReferralCodeModel{},
+		&ReferralModel{},
+		&ScheduledJobModel{},
+		&WorkerLeaseModel{},
+	}
+}
@@ -0,0 +1,96 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MutedSenderModel は送金リクエストの送信者のミュートのGORMモデル
+type MutedSenderModel struct {
+	UserID      uuid.UUID `gorm:"type:uuid;primaryKey"`
+	MutedUserID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Mode        string    `gorm:"type:varchar(20);not null"`
+	CreatedAt   time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (MutedSenderModel) TableName() string {
+	return "muted_senders"
+}
+
+// ToDomain はドメインエンティティに変換
+func (m *MutedSenderModel) ToDomain() *entities.MutedSender {
+	return &entities.MutedSender{
+		UserID:      m.UserID,
+		MutedUserID: m.MutedUserID,
+		Mode:        entities.MuteMode(m.Mode),
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+// MutedSenderDataSource は送金リクエストの送信者のミュートのデータソース
+type MutedSenderDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewMutedSenderDataSource は新しいMutedSenderDataSourceを作成
+func NewMutedSenderDataSource(db infrapostgres.DB) *MutedSenderDataSource {
+	return &MutedSenderDataSource{db: db}
+}
+
+// Upsert はミュートを挿入（既にミュートしていればミュートの方法だけ更新）
+func (ds *MutedSenderDataSource) Upsert(ctx context.Context, mute *entities.MutedSender) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "muted_user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"mode"}),
+	}).Create(&MutedSenderModel{
+		UserID:      mute.UserID,
+		MutedUserID: mute.MutedUserID,
+		Mode:        string(mute.Mode),
+		CreatedAt:   mute.CreatedAt,
+	}).Error
+}
+
+// Delete はミュートを解除（ミュートしていなければ何もしない）
+func (ds *MutedSenderDataSource) Delete(ctx context.Context, userID, mutedUserID uuid.UUID) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Where("user_id = ? AND muted_user_id = ?", userID, mutedUserID).Delete(&MutedSenderModel{}).Error
+}
+
+// Select はユーザーが送信者をミュートしているかを取得（ミュートしていなければnil）
+func (ds *MutedSenderDataSource) Select(ctx context.Context, userID, mutedUserID uuid.UUID) (*entities.MutedSender, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var model MutedSenderModel
+	err := db.Where("user_id = ? AND muted_user_id = ?", userID, mutedUserID).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// SelectListByUserID はユーザーのミュートをミュートした順に取得
+func (ds *MutedSenderDataSource) SelectListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.MutedSender, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var models []MutedSenderModel
+	if err := db.Where("user_id = ?", userID).
+		Order("created_at ASC, muted_user_id ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	mutes := make([]*entities.MutedSender, len(models))
+	for i := range models {
+		mutes[i] = models[i].ToDomain()
+	}
+	return mutes, nil
+}
@@ -0,0 +1,230 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DeviceTokenModel はプッシュ通知の配信先端末のGORMモデル
+type DeviceTokenModel struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null"`
+	Platform   string    `gorm:"type:varchar(20);not null"`
+	Token      string    `gorm:"type:text;not null"`
+	CreatedAt  time.Time `gorm:"type:timestamptz;not null"`
+	LastSeenAt time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (DeviceTokenModel) TableName() string {
+	return "device_tokens"
+}
+
+// NotificationPreferencesModel は通知設定のGORMモデル
+// GORMは既定値を指定した列のゼロ値を既定値で置き換えるため、オン/オフの列には既定値を指定しない
+// （初めて保存するときにオフにした項目がオンになる。列の既定値はmigrations/で指定する）
+type NotificationPreferencesModel struct {
+	UserID             uuid.UUID `gorm:"type:uuid;primary_key"`
+	PushEnabled        bool      `gorm:"not null"`
+	EmailEnabled       bool      `gorm:"not null"`
+	TransferRequests   bool      `gorm:"not null"`
+	PointsReceived     bool      `gorm:"not null"`
+	PointsExpiring     bool      `gorm:"not null"`
+	NewLogin           bool      `gorm:"not null"`
+	WeeklyDigest       bool      `gorm:"not null"`
+	QuietHoursEnabled  bool      `gorm:"not null"`
+	QuietHoursStart    int       `gorm:"type:smallint;not null"`
+	QuietHoursEnd      int       `gorm:"type:smallint;not null"`
+	QuietHoursTimezone string    `gorm:"type:varchar(64);not null"`
+	UpdatedAt          time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (NotificationPreferencesModel) TableName() string {
+	return "notification_preferences"
+}
+
+// NotificationDataSource は通知の配信先・設定のデータソース
+type NotificationDataSource struct {
+	db      infrapostgres.DB
+	batches *PointBatchDataSource
+}
+
+// NewNotificationDataSource は新しいNotificationDataSourceを作成
+func NewNotificationDataSource(db infrapostgres.DB) *NotificationDataSource {
+	return &NotificationDataSource{db: db, batches: NewPointBatchDataSource(db)}
+}
+
+func (ds *NotificationDataSource) toDeviceEntity(m *DeviceTokenModel) *entities.DeviceToken {
+	return &entities.DeviceToken{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		Platform:   entities.DevicePlatform(m.Platform),
+		Token:      m.Token,
+		CreatedAt:  m.CreatedAt,
+		LastSeenAt: m.LastSeenAt,
+	}
+}
+
+// UpsertDeviceToken はトークンを登録（登録済みなら所有者・プラットフォーム・LastSeenAtを更新）
+// 登録済みだった場合は既存のIDと登録日時をエンティティに反映する
+func (ds *NotificationDataSource) UpsertDeviceToken(ctx context.Context, device *entities.DeviceToken) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	model := DeviceTokenModel{
+		ID:         device.ID,
+		UserID:     device.UserID,
+		Platform:   string(device.Platform),
+		Token:      device.Token,
+		CreatedAt:  device.CreatedAt,
+		LastSeenAt: device.LastSeenAt,
+	}
+	err := db.Clauses(
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: "token"}},
+			DoUpdates: clause.AssignmentColumns([]string{"user_id", "platform", "last_seen_at"}),
+		},
+		clause.Returning{},
+	).Create(&model).Error
+	if err != nil {
+		return err
+	}
+	device.ID = model.ID
+	device.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// DeleteDeviceToken はユーザーのトークンを削除
+func (ds *NotificationDataSource) DeleteDeviceToken(ctx context.Context, userID uuid.UUID, token string) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Where("user_id = ? AND token = ?", userID, token).Delete(&DeviceTokenModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrDeviceTokenNotFound
+	}
+	return nil
+}
+
+// DeleteDeviceTokenByID はIDでトークンを削除
+func (ds *NotificationDataSource) DeleteDeviceTokenByID(ctx context.Context, id uuid.UUID) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Where("id = ?", id).Delete(&DeviceTokenModel{}).Error
+}
+
+// SelectDeviceTokensByUser はユーザーの登録済みトークンを新しい順に取得
+func (ds *NotificationDataSource) SelectDeviceTokensByUser(ctx context.Context, userID uuid.UUID) ([]*entities.DeviceToken, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var models []DeviceTokenModel
+	if err := db.Where("user_id = ?", userID).Order("last_seen_at DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	devices := make([]*entities.DeviceToken, len(models))
+	for i := range models {
+		devices[i] = ds.toDeviceEntity(&models[i])
+	}
+	return devices, nil
+}
+
+// PruneDeviceTokens はユーザーのトークンを新しい順にkeep件だけ残して削除
+func (ds *NotificationDataSource) PruneDeviceTokens(ctx context.Context, userID uuid.UUID, keep int) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Exec(`
+		DELETE FROM device_tokens
+		WHERE user_id = ? AND id NOT IN (
+			SELECT id FROM device_tokens WHERE user_id = ? ORDER BY last_seen_at DESC LIMIT ?
+		)`, userID, userID, keep).Error
+}
+
+// SelectPreferences はユーザーの通知設定を取得（行が無ければ初期設定）
+func (ds *NotificationDataSource) SelectPreferences(ctx context.Context, userID uuid.UUID) (*entities.NotificationPreferences, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var m NotificationPreferencesModel
+	if err := db.Where("user_id = ?", userID).First(&m).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entities.DefaultNotificationPreferences(userID), nil
+		}
+		return nil, err
+	}
+	return &entities.NotificationPreferences{
+		UserID:           m.UserID,
+		PushEnabled:      m.PushEnabled,
+		EmailEnabled:     m.EmailEnabled,
+		TransferRequests: m.TransferRequests,
+		PointsReceived:   m.PointsReceived,
+		PointsExpiring:   m.PointsExpiring,
+		NewLogin:         m.NewLogin,
+		WeeklyDigest:     m.WeeklyDigest,
+		QuietHours: entities.QuietHours{
+			Enabled:  m.QuietHoursEnabled,
+			Start:    m.QuietHoursStart,
+			End:      m.QuietHoursEnd,
+			Timezone: m.QuietHoursTimezone,
+		},
+		UpdatedAt: m.UpdatedAt,
+	}, nil
+}
+
+// UpsertPreferences はユーザーの通知設定を保存（upsert）
+func (ds *NotificationDataSource) UpsertPreferences(ctx context.Context, prefs *entities.NotificationPreferences) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	model := NotificationPreferencesModel{
+		UserID:             prefs.UserID,
+		PushEnabled:        prefs.PushEnabled,
+		EmailEnabled:       prefs.EmailEnabled,
+		TransferRequests:   prefs.TransferRequests,
+		PointsReceived:     prefs.PointsReceived,
+		PointsExpiring:     prefs.PointsExpiring,
+		NewLogin:           prefs.NewLogin,
+		WeeklyDigest:       prefs.WeeklyDigest,
+		QuietHoursEnabled:  prefs.QuietHours.Enabled,
+		QuietHoursStart:    prefs.QuietHours.Start,
+		QuietHoursEnd:      prefs.QuietHours.End,
+		QuietHoursTimezone: prefs.QuietHours.Timezone,
+		UpdatedAt:          prefs.UpdatedAt,
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"push_enabled", "email_enabled",
+			"transfer_requests", "points_received", "points_expiring", "new_login", "weekly_digest",
+			"quiet_hours_enabled", "quiet_hours_start", "quiet_hours_end", "quiet_hours_timezone",
+			"updated_at",
+		}),
+	}).Create(&model).Error
+}
+
+// SelectExpiringBatches は期限までwithin以内で、まだ期限の通知をしていない残量のあるバッチをユーザー順に取得
+func (ds *NotificationDataSource) SelectExpiringBatches(ctx context.Context, now time.Time, within time.Duration, limit int) ([]*entities.PointBatch, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var models []PointBatchModel
+	err := db.Where("remaining_amount > 0 AND expiry_reminded_at IS NULL AND expires_at > ? AND expires_at <= ?", now, now.Add(within)).
+		Order("user_id ASC, expires_at ASC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	batches := make([]*entities.PointBatch, len(models))
+	for i := range models {
+		batches[i] = ds.batches.toEntity(&models[i])
+	}
+	return batches, nil
+}
+
+// MarkExpiryReminded はバッチを期限の通知済みにする
+func (ds *NotificationDataSource) MarkExpiryReminded(ctx context.Context, batchIDs []uuid.UUID, remindedAt time.Time) error {
+	if len(batchIDs) == 0 {
+		return nil
+	}
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Model(&PointBatchModel{}).
+		Where("id IN ?", batchIDs).
+		Update("expiry_reminded_at", remindedAt).Error
+}
@@ -0,0 +1,241 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PendingAdminActionModel は承認待ちの管理者操作のGORMモデル
+type PendingAdminActionModel struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key"`
+	ActionType     string     `gorm:"type:varchar(10);not null"`
+	RequestedBy    uuid.UUID  `gorm:"type:uuid;not null"`
+	TargetUserID   uuid.UUID  `gorm:"type:uuid;not null"`
+	Amount         int64      `gorm:"not null"`
+	Description    string     `gorm:"type:text;not null;default:''"`
+	ReasonCode     string     `gorm:"type:varchar(50);not null;default:''"`
+	Tag            string     `gorm:"type:varchar(50);not null;default:''"`
+	IdempotencyKey string     `gorm:"type:varchar(255);not null;uniqueIndex"`
+	BudgetOverride bool       `gorm:"not null;default:false"`
+	Status         string     `gorm:"type:varchar(10);not null"`
+	ReviewedBy     *uuid.UUID `gorm:"type:uuid"`
+	ReviewComment  string     `gorm:"type:text;not null;default:''"`
+	ReviewedAt     *time.Time `gorm:"type:timestamptz"`
+	TransactionID  *uuid.UUID `gorm:"type:uuid"`
+	FailureReason  string     `gorm:"type:text;not null;default:''"`
+	ExpiresAt      time.Time  `gorm:"type:timestamptz;not null"`
+	CreatedAt      time.Time  `gorm:"type:timestamptz;not null"`
+	UpdatedAt      time.Time  `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (PendingAdminActionModel) TableName() string {
+	return "pending_admin_actions"
+}
+
+// PendingAdminActionEventModel は承認待ちの操作の監査記録のGORMモデル
+type PendingAdminActionEventModel struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key"`
+	ActionID  uuid.UUID  `gorm:"type:uuid;not null"`
+	Event     string     `gorm:"type:varchar(10);not null"`
+	ActorID   *uuid.UUID `gorm:"type:uuid"`
+	Comment   string     `gorm:"type:text;not null;default:''"`
+	CreatedAt time.Time  `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (PendingAdminActionEventModel) TableName() string {
+	return "pending_admin_action_events"
+}
+
+// PendingAdminActionDataSource は承認待ちの管理者操作のデータソース
+type PendingAdminActionDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewPendingAdminActionDataSource は新しいPendingAdminActionDataSourceを作成
+func NewPendingAdminActionDataSource(db infrapostgres.DB) *PendingAdminActionDataSource {
+	return &PendingAdminActionDataSource{db: db}
+}
+
+func (ds *PendingAdminActionDataSource) toEntity(m *PendingAdminActionModel) *entities.PendingAdminAction {
+	return &entities.PendingAdminAction{
+		ID:             m.ID,
+		ActionType:     entities.PendingAdminActionType(m.ActionType),
+		RequestedBy:    m.RequestedBy,
+		TargetUserID:   m.TargetUserID,
+		Amount:         m.Amount,
+		Description:    m.Description,
+		ReasonCode:     m.ReasonCode,
+		Tag:            m.Tag,
+		IdempotencyKey: m.IdempotencyKey,
+		BudgetOverride: m.BudgetOverride,
+		Status:         entities.PendingAdminActionStatus(m.Status),
+		ReviewedBy:     m.ReviewedBy,
+		ReviewComment:  m.ReviewComment,
+		ReviewedAt:     m.ReviewedAt,
+		TransactionID:  m.TransactionID,
+		FailureReason:  m.FailureReason,
+		ExpiresAt:      m.ExpiresAt,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+func (ds *PendingAdminActionDataSource) toEntities(models []PendingAdminActionModel) []*entities.PendingAdminAction {
+	actions := make([]*entities.PendingAdminAction, len(models))
+	for i := range models {
+		actions[i] = ds.toEntity(&models[i])
+	}
+	return actions
+}
+
+// Insert は承認待ちの操作を挿入
+func (ds *PendingAdminActionDataSource) Insert(ctx context.Context, a *entities.PendingAdminAction) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Create(&PendingAdminActionModel{
+		ID:             a.ID,
+		ActionType:     string(a.ActionType),
+		RequestedBy:    a.RequestedBy,
+		TargetUserID:   a.TargetUserID,
+		Amount:         a.Amount,
+		Description:    a.Description,
+		ReasonCode:     a.ReasonCode,
+		Tag:            a.Tag,
+		IdempotencyKey: a.IdempotencyKey,
+		BudgetOverride: a.BudgetOverride,
+		Status:         string(a.Status),
+		ReviewedBy:     a.ReviewedBy,
+		ReviewComment:  a.ReviewComment,
+		ReviewedAt:     a.ReviewedAt,
+		TransactionID:  a.TransactionID,
+		FailureReason:  a.FailureReason,
+		ExpiresAt:      a.ExpiresAt,
+		CreatedAt:      a.CreatedAt,
+		UpdatedAt:      a.UpdatedAt,
+	}).Error
+}
+
+// Select はIDで承認待ちの操作を取得
+func (ds *PendingAdminActionDataSource) Select(ctx context.Context, id uuid.UUID) (*entities.PendingAdminAction, error) {
+	return ds.selectOne(ctx, "id = ?", id)
+}
+
+// SelectByIdempotencyKey は申請時の冪等性キーで取得
+func (ds *PendingAdminActionDataSource) SelectByIdempotencyKey(ctx context.Context, key string) (*entities.PendingAdminAction, error) {
+	return ds.selectOne(ctx, "idempotency_key = ?", key)
+}
+
+func (ds *PendingAdminActionDataSource) selectOne(ctx context.Context, query string, arg interface{}) (*entities.PendingAdminAction, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m PendingAdminActionModel
+	if err := db.Where(query, arg).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrPendingActionNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// SelectList は操作を新しい順に取得（statusが空なら全状態）
+func (ds *PendingAdminActionDataSource) SelectList(ctx context.Context, status entities.PendingAdminActionStatus, offset, limit int) ([]*entities.PendingAdminAction, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	query := db.Model(&PendingAdminActionModel{})
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+	var models []PendingAdminActionModel
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return ds.toEntities(models), nil
+}
+
+// Count は操作の件数を取得（statusが空なら全状態）
+func (ds *PendingAdminActionDataSource) Count(ctx context.Context, status entities.PendingAdminActionStatus) (int64, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	query := db.Model(&PendingAdminActionModel{})
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// UpdateStatus は状態がfromのときだけ状態と審査・実行の結果を更新（更新できたかを返す）
+func (ds *PendingAdminActionDataSource) UpdateStatus(ctx context.Context, a *entities.PendingAdminAction, from entities.PendingAdminActionStatus) (bool, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&PendingAdminActionModel{}).
+		Where("id = ? AND status = ?", a.ID, string(from)).
+		Updates(map[string]interface{}{
+			"status":         string(a.Status),
+			"reviewed_by":    a.ReviewedBy,
+			"review_comment": a.ReviewComment,
+			"reviewed_at":    a.ReviewedAt,
+			"transaction_id": a.TransactionID,
+			"failure_reason": a.FailureReason,
+			"updated_at":     a.UpdatedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SelectExpired は承認待ちのまま期限を過ぎた操作を古い順に取得
+func (ds *PendingAdminActionDataSource) SelectExpired(ctx context.Context, now time.Time, limit int) ([]*entities.PendingAdminAction, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var models []PendingAdminActionModel
+	if err := db.Where("status = ? AND expires_at <= ?", string(entities.PendingAdminActionStatusPending), now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return ds.toEntities(models), nil
+}
+
+// InsertEvent は監査記録を挿入
+func (ds *PendingAdminActionDataSource) InsertEvent(ctx context.Context, e *entities.PendingAdminActionEvent) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Create(&PendingAdminActionEventModel{
+		ID:        e.ID,
+		ActionID:  e.ActionID,
+		Event:     string(e.Event),
+		ActorID:   e.ActorID,
+		Comment:   e.Comment,
+		CreatedAt: e.CreatedAt,
+	}).Error
+}
+
+// SelectEvents は操作の監査記録を古い順に取得
+func (ds *PendingAdminActionDataSource) SelectEvents(ctx context.Context, actionID uuid.UUID) ([]*entities.PendingAdminActionEvent, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var models []PendingAdminActionEventModel
+	if err := db.Where("action_id = ?", actionID).Order("created_at ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	events := make([]*entities.PendingAdminActionEvent, len(models))
+	for i := range models {
+		m := &models[i]
+		events[i] = &entities.PendingAdminActionEvent{
+			ID:        m.ID,
+			ActionID:  m.ActionID,
+			Event:     entities.PendingAdminActionEventType(m.Event),
+			ActorID:   m.ActorID,
+			Comment:   m.Comment,
+			CreatedAt: m.CreatedAt,
+		}
+	}
+	return events, nil
+}
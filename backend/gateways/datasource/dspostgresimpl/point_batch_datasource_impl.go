@@ -21,6 +21,13 @@ type PointBatchModel struct {
 	SourceTransactionID *uuid.UUID `gorm:"type:uuid"`
 	ExpiresAt           time.Time  `gorm:"type:timestamptz;not null"`
 	CreatedAt           time.Time  `gorm:"type:timestamptz;not null;default:CURRENT_TIMESTAMP"`
+	ExpiredAt           *time.Time `gorm:"type:timestamptz"`
+	ExpiredAmount       int64      `gorm:"not null;default:0"`
+	RestoredAt          *time.Time `gorm:"type:timestamptz"`
+	ExpiryAdjustedAt    *time.Time `gorm:"type:timestamptz"`
+	CancelledAt         *time.Time `gorm:"type:timestamptz"`
+	CancelledAmount     int64      `gorm:"not null;default:0"`
+	ExpiryRemindedAt    *time.Time `gorm:"type:timestamptz;->"` // 失効前通知の送信日時（通知側でのみ更新）
 }
 
 // TableName はテーブル名を指定
@@ -49,6 +56,12 @@ func (ds *PointBatchDataSource) toEntity(model *PointBatchModel) *entities.Point
 		SourceTransactionID: model.SourceTransactionID,
 		ExpiresAt:           model.ExpiresAt,
 		CreatedAt:           model.CreatedAt,
+		ExpiredAt:           model.ExpiredAt,
+		ExpiredAmount:       model.ExpiredAmount,
+		RestoredAt:          model.RestoredAt,
+		ExpiryAdjustedAt:    model.ExpiryAdjustedAt,
+		CancelledAt:         model.CancelledAt,
+		CancelledAmount:     model.CancelledAmount,
 	}
 }
 
@@ -63,6 +76,12 @@ func (ds *PointBatchDataSource) toModel(batch *entities.PointBatch) *PointBatchM
 		SourceTransactionID: batch.SourceTransactionID,
 		ExpiresAt:           batch.ExpiresAt,
 		CreatedAt:           batch.CreatedAt,
+		ExpiredAt:           batch.ExpiredAt,
+		ExpiredAmount:       batch.ExpiredAmount,
+		RestoredAt:          batch.RestoredAt,
+		ExpiryAdjustedAt:    batch.ExpiryAdjustedAt,
+		CancelledAt:         batch.CancelledAt,
+		CancelledAmount:     batch.CancelledAmount,
 	}
 }
 
@@ -133,19 +152,103 @@ func (ds *PointBatchDataSource) SelectExpiredBatches(ctx context.Context, before
 }
 
 // MarkExpired はバッチのremaining_amountを0に更新
+// 失効時の残量と日時を残しておき、猶予期間内の取り消しに使う
 func (ds *PointBatchDataSource) MarkExpired(ctx context.Context, batchID uuid.UUID) error {
 	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
 	return db.Model(&PointBatchModel{}).
 		Where("id = ?", batchID).
-		Update("remaining_amount", 0).Error
+		Updates(map[string]interface{}{
+			"expired_amount":   gorm.Expr("remaining_amount"),
+			"expired_at":       gorm.Expr("NOW()"),
+			"remaining_amount": 0,
+		}).Error
+}
+
+// SelectByID はIDでバッチを取得
+func (ds *PointBatchDataSource) SelectByID(ctx context.Context, id uuid.UUID) (*entities.PointBatch, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var model PointBatchModel
+	if err := db.Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrPointBatchNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&model), nil
+}
+
+// UpdateExpiresAt はバッチの有効期限を更新
+func (ds *PointBatchDataSource) UpdateExpiresAt(ctx context.Context, id uuid.UUID, expiresAt time.Time) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Model(&PointBatchModel{}).
+		Where("id = ?", id).
+		Update("expires_at", expiresAt).Error
+}
+
+// SelectActiveByUser はユーザーの残量があるバッチを古い順に取得
+func (ds *PointBatchDataSource) SelectActiveByUser(ctx context.Context, userID uuid.UUID) ([]*entities.PointBatch, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+
+	var models []PointBatchModel
+	err := db.Where("user_id = ? AND remaining_amount > 0", userID).
+		Order("created_at ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	batches := make([]*entities.PointBatch, len(models))
+	for i, model := range models {
+		batches[i] = ds.toEntity(&model)
+	}
+	return batches, nil
+}
+
+// MarkRestored はバッチを取り消し済みに更新
+// 二重の取り消しを防ぐため、未取り消しのバッチのみ更新する
+func (ds *PointBatchDataSource) MarkRestored(ctx context.Context, id uuid.UUID, restoredAt time.Time) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&PointBatchModel{}).
+		Where("id = ? AND restored_at IS NULL", id).
+		Update("restored_at", restoredAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrPointBatchRestored
+	}
+	return nil
+}
+
+// SelectRestorableBatches は指定日時以降に失効し、まだ取り消されていないバッチを新しい順に取得
+// userIDがnilの場合は全ユーザーが対象
+func (ds *PointBatchDataSource) SelectRestorableBatches(ctx context.Context, expiredAfter time.Time, userID *uuid.UUID, limit int) ([]*entities.PointBatch, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+
+	query := db.Where("expired_at >= ? AND restored_at IS NULL AND expired_amount > 0", expiredAfter)
+	if userID != nil {
+		query = query.Where("user_id = ?", *userID)
+	}
+
+	var models []PointBatchModel
+	if err := query.Order("expired_at DESC").Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	batches := make([]*entities.PointBatch, len(models))
+	for i, model := range models {
+		batches[i] = ds.toEntity(&model)
+	}
+	return batches, nil
 }
 
 // SelectUpcomingExpirations はユーザーの1ヶ月以内に失効するバッチを期限が近い順に取得
 func (ds *PointBatchDataSource) SelectUpcomingExpirations(ctx context.Context, userID uuid.UUID) ([]*entities.PointBatch, error) {
 	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
 
+	now := time.Now()
 	var models []PointBatchModel
-	err := db.Where("user_id = ? AND remaining_amount > 0 AND expires_at > NOW() AND expires_at <= NOW() + INTERVAL '1 month'", userID).
+	err := db.Where("user_id = ? AND remaining_amount > 0 AND expires_at > ? AND expires_at <= ?", userID, now, now.AddDate(0, 1, 0)).
 		Order("expires_at ASC").
 		Find(&models).Error
 	if err != nil {
@@ -158,3 +261,155 @@ func (ds *PointBatchDataSource) SelectUpcomingExpirations(ctx context.Context, u
 	}
 	return batches, nil
 }
+
+// SelectByUser はユーザーのすべてのバッチを作成日時の新しい順に取得
+func (ds *PointBatchDataSource) SelectByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.PointBatch, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+
+	var models []PointBatchModel
+	err := db.Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	batches := make([]*entities.PointBatch, len(models))
+	for i, model := range models {
+		batches[i] = ds.toEntity(&model)
+	}
+	return batches, nil
+}
+
+// CountByUser はユーザーのバッチの件数を取得
+func (ds *PointBatchDataSource) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var count int64
+	err := db.Model(&PointBatchModel{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// AdjustExpiresAt は管理者が変更した有効期限を設定する
+// 失効処理・取り消しと競合しないよう、未失効・未取り消しのバッチのみ更新する
+func (ds *PointBatchDataSource) AdjustExpiresAt(ctx context.Context, id uuid.UUID, expiresAt, adjustedAt time.Time) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&PointBatchModel{}).
+		Where("id = ? AND expired_at IS NULL AND cancelled_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"expires_at":         expiresAt,
+			"expiry_adjusted_at": adjustedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrPointBatchNotAdjustable
+	}
+	return nil
+}
+
+// Cancel はバッチを取り消し、残量を0にする
+// 読み取ってから残量が変わっていた場合（同時の消費・失効）は更新しない
+func (ds *PointBatchDataSource) Cancel(ctx context.Context, id uuid.UUID, remaining int64, cancelledAt time.Time) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&PointBatchModel{}).
+		Where("id = ? AND expired_at IS NULL AND cancelled_at IS NULL", id).
+		Where("remaining_amount = ?", remaining).
+		Updates(map[string]interface{}{
+			"remaining_amount": 0,
+			"cancelled_amount": remaining,
+			"cancelled_at":     cancelledAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var model PointBatchModel
+	if err := db.Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entities.ErrPointBatchNotFound
+		}
+		return err
+	}
+	if model.ExpiredAt != nil || model.CancelledAt != nil {
+		return entities.ErrPointBatchNotAdjustable
+	}
+	return entities.ErrUpdateConflict
+}
+
+// PointBatchAdjustmentModel は管理者によるポイントバッチの調整の記録のGORMモデル
+type PointBatchAdjustmentModel struct {
+	ID                uuid.UUID  `gorm:"type:uuid;primary_key"`
+	BatchID           uuid.UUID  `gorm:"type:uuid;not null"`
+	UserID            uuid.UUID  `gorm:"type:uuid;not null"`
+	AdminID           *uuid.UUID `gorm:"type:uuid"` // 管理者の退会後も記録は残す
+	Action            string     `gorm:"type:varchar(20);not null"`
+	PreviousExpiresAt time.Time  `gorm:"type:timestamptz;not null"`
+	NewExpiresAt      *time.Time `gorm:"type:timestamptz"`
+	CancelledAmount   int64      `gorm:"not null;default:0"`
+	TransactionID     *uuid.UUID `gorm:"type:uuid"`
+	Reason            string     `gorm:"type:text;not null"`
+	CreatedAt         time.Time  `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (PointBatchAdjustmentModel) TableName() string {
+	return "point_batch_adjustments"
+}
+
+// InsertAdjustment は調整の記録を挿入
+func (ds *PointBatchDataSource) InsertAdjustment(ctx context.Context, adjustment *entities.PointBatchAdjustment) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	adminID := adjustment.AdminID
+	return db.Create(&PointBatchAdjustmentModel{
+		ID:                adjustment.ID,
+		BatchID:           adjustment.BatchID,
+		UserID:            adjustment.UserID,
+		AdminID:           &adminID,
+		Action:            string(adjustment.Action),
+		PreviousExpiresAt: adjustment.PreviousExpiresAt,
+		NewExpiresAt:      adjustment.NewExpiresAt,
+		CancelledAmount:   adjustment.CancelledAmount,
+		TransactionID:     adjustment.TransactionID,
+		Reason:            adjustment.Reason,
+		CreatedAt:         adjustment.CreatedAt,
+	}).Error
+}
+
+// SelectAdjustments はバッチの調整の記録を古い順に取得
+func (ds *PointBatchDataSource) SelectAdjustments(ctx context.Context, batchIDs []uuid.UUID) ([]*entities.PointBatchAdjustment, error) {
+	if len(batchIDs) == 0 {
+		return []*entities.PointBatchAdjustment{}, nil
+	}
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+
+	var models []PointBatchAdjustmentModel
+	if err := db.Where("batch_id IN ?", batchIDs).Order("created_at ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	adjustments := make([]*entities.PointBatchAdjustment, len(models))
+	for i, m := range models {
+		adjustments[i] = &entities.PointBatchAdjustment{
+			ID:                m.ID,
+			BatchID:           m.BatchID,
+			UserID:            m.UserID,
+			Action:            entities.PointBatchAdjustmentAction(m.Action),
+			PreviousExpiresAt: m.PreviousExpiresAt,
+			NewExpiresAt:      m.NewExpiresAt,
+			CancelledAmount:   m.CancelledAmount,
+			TransactionID:     m.TransactionID,
+			Reason:            m.Reason,
+			CreatedAt:         m.CreatedAt,
+		}
+		if m.AdminID != nil {
+			adjustments[i].AdminID = *m.AdminID
+		}
+	}
+	return adjustments, nil
+}
@@ -0,0 +1,133 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PointExpiryPolicyModel は付与種別ごとの有効期間のGORMモデル
+type PointExpiryPolicyModel struct {
+	SourceType   string     `gorm:"type:varchar(50);primary_key"`
+	ValidityDays int        `gorm:"not null"`
+	UpdatedBy    *uuid.UUID `gorm:"type:uuid"`
+	UpdatedAt    time.Time  `gorm:"type:timestamptz;not null;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName はテーブル名を指定
+func (PointExpiryPolicyModel) TableName() string {
+	return "point_expiry_policies"
+}
+
+// UserPointExpiryOverrideModel はユーザー個別の有効期間のGORMモデル
+type UserPointExpiryOverrideModel struct {
+	UserID       uuid.UUID  `gorm:"type:uuid;primary_key"`
+	ValidityDays int        `gorm:"not null"`
+	Reason       string     `gorm:"type:text;not null;default:''"`
+	UpdatedBy    *uuid.UUID `gorm:"type:uuid"`
+	UpdatedAt    time.Time  `gorm:"type:timestamptz;not null;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName はテーブル名を指定
+func (UserPointExpiryOverrideModel) TableName() string {
+	return "user_point_expiry_overrides"
+}
+
+// PointExpiryPolicyDataSource はポイント有効期間設定のデータソース
+type PointExpiryPolicyDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewPointExpiryPolicyDataSource は新しいPointExpiryPolicyDataSourceを作成
+func NewPointExpiryPolicyDataSource(db infrapostgres.DB) *PointExpiryPolicyDataSource {
+	return &PointExpiryPolicyDataSource{db: db}
+}
+
+// SelectPolicies は全ての付与種別ポリシーを取得
+func (ds *PointExpiryPolicyDataSource) SelectPolicies(ctx context.Context) ([]*entities.PointExpiryPolicy, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+
+	var models []PointExpiryPolicyModel
+	if err := db.Order("source_type ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	policies := make([]*entities.PointExpiryPolicy, len(models))
+	for i, m := range models {
+		policies[i] = &entities.PointExpiryPolicy{
+			SourceType:   entities.PointBatchSourceType(m.SourceType),
+			ValidityDays: m.ValidityDays,
+			UpdatedBy:    m.UpdatedBy,
+			UpdatedAt:    m.UpdatedAt,
+		}
+	}
+	return policies, nil
+}
+
+// UpsertPolicy は付与種別ポリシーを保存（upsert）
+func (ds *PointExpiryPolicyDataSource) UpsertPolicy(ctx context.Context, policy *entities.PointExpiryPolicy) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	model := PointExpiryPolicyModel{
+		SourceType:   string(policy.SourceType),
+		ValidityDays: policy.ValidityDays,
+		UpdatedBy:    policy.UpdatedBy,
+		UpdatedAt:    policy.UpdatedAt,
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "source_type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"validity_days", "updated_by", "updated_at"}),
+	}).Create(&model).Error
+}
+
+// DeletePolicy は付与種別ポリシーを削除
+func (ds *PointExpiryPolicyDataSource) DeletePolicy(ctx context.Context, sourceType entities.PointBatchSourceType) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Where("source_type = ?", string(sourceType)).Delete(&PointExpiryPolicyModel{}).Error
+}
+
+// SelectUserOverride はユーザー個別の設定を取得（ない場合はnil）
+func (ds *PointExpiryPolicyDataSource) SelectUserOverride(ctx context.Context, userID uuid.UUID) (*entities.UserPointExpiryOverride, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+
+	var m UserPointExpiryOverrideModel
+	if err := db.Where("user_id = ?", userID).First(&m).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entities.UserPointExpiryOverride{
+		UserID:       m.UserID,
+		ValidityDays: m.ValidityDays,
+		Reason:       m.Reason,
+		UpdatedBy:    m.UpdatedBy,
+		UpdatedAt:    m.UpdatedAt,
+	}, nil
+}
+
+// UpsertUserOverride はユーザー個別の設定を保存（upsert）
+func (ds *PointExpiryPolicyDataSource) UpsertUserOverride(ctx context.Context, override *entities.UserPointExpiryOverride) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	model := UserPointExpiryOverrideModel{
+		UserID:       override.UserID,
+		ValidityDays: override.ValidityDays,
+		Reason:       override.Reason,
+		UpdatedBy:    override.UpdatedBy,
+		UpdatedAt:    override.UpdatedAt,
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"validity_days", "reason", "updated_by", "updated_at"}),
+	}).Create(&model).Error
+}
+
+// DeleteUserOverride はユーザー個別の設定を削除
+func (ds *PointExpiryPolicyDataSource) DeleteUserOverride(ctx context.Context, userID uuid.UUID) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Where("user_id = ?", userID).Delete(&UserPointExpiryOverrideModel{}).Error
+}
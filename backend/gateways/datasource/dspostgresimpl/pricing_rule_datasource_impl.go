@@ -0,0 +1,188 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PricingRuleModel は価格ルールのGORMモデル
+type PricingRuleModel struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;primary_key"`
+	Name               string     `gorm:"type:varchar(100);not null"`
+	ProductID          *uuid.UUID `gorm:"type:uuid"`
+	CategoryCode       string     `gorm:"type:varchar(50);not null;default:''"`
+	DiscountType       string     `gorm:"type:varchar(20);not null"`
+	DiscountValue      int64      `gorm:"not null"`
+	TargetRole         string     `gorm:"type:varchar(20);not null;default:''"`
+	MinTier            string     `gorm:"type:varchar(20);not null;default:''"`
+	MaxQuantityPerUser int        `gorm:"not null;default:0"`
+	StartsAt           time.Time  `gorm:"type:timestamptz;not null"`
+	EndsAt             *time.Time `gorm:"type:timestamptz"`
+	IsActive           bool       `gorm:"not null;default:true"`
+	CreatedBy          uuid.UUID  `gorm:"type:uuid"` // 作成者の退会後はNULL
+	CreatedAt          time.Time  `gorm:"type:timestamptz;not null"`
+	UpdatedAt          time.Time  `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (PricingRuleModel) TableName() string {
+	return "pricing_rules"
+}
+
+// PricingRuleDataSource は価格ルールのデータソース
+type PricingRuleDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewPricingRuleDataSource は新しいPricingRuleDataSourceを作成
+func NewPricingRuleDataSource(db infrapostgres.DB) *PricingRuleDataSource {
+	return &PricingRuleDataSource{db: db}
+}
+
+func (ds *PricingRuleDataSource) toEntity(m *PricingRuleModel) *entities.PricingRule {
+	return &entities.PricingRule{
+		ID:                 m.ID,
+		Name:               m.Name,
+		ProductID:          m.ProductID,
+		CategoryCode:       m.CategoryCode,
+		DiscountType:       entities.PricingDiscountType(m.DiscountType),
+		DiscountValue:      m.DiscountValue,
+		TargetRole:         entities.UserRole(m.TargetRole),
+		MinTier:            entities.UserTier(m.MinTier),
+		MaxQuantityPerUser: m.MaxQuantityPerUser,
+		StartsAt:           m.StartsAt,
+		EndsAt:             m.EndsAt,
+		IsActive:           m.IsActive,
+		CreatedBy:          m.CreatedBy,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
+	}
+}
+
+func (ds *PricingRuleDataSource) toModel(e *entities.PricingRule) *PricingRuleModel {
+	return &PricingRuleModel{
+		ID:                 e.ID,
+		Name:               e.Name,
+		ProductID:          e.ProductID,
+		CategoryCode:       e.CategoryCode,
+		DiscountType:       string(e.DiscountType),
+		DiscountValue:      e.DiscountValue,
+		TargetRole:         string(e.TargetRole),
+		MinTier:            string(e.MinTier),
+		MaxQuantityPerUser: e.MaxQuantityPerUser,
+		StartsAt:           e.StartsAt,
+		EndsAt:             e.EndsAt,
+		IsActive:           e.IsActive,
+		CreatedBy:          e.CreatedBy,
+		CreatedAt:          e.CreatedAt,
+		UpdatedAt:          e.UpdatedAt,
+	}
+}
+
+func (ds *PricingRuleDataSource) toEntities(models []PricingRuleModel) []*entities.PricingRule {
+	rules := make([]*entities.PricingRule, len(models))
+	for i := range models {
+		rules[i] = ds.toEntity(&models[i])
+	}
+	return rules
+}
+
+// Insert は価格ルールを挿入
+func (ds *PricingRuleDataSource) Insert(ctx context.Context, rule *entities.PricingRule) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Create(ds.toModel(rule)).Error
+}
+
+// SelectByID はIDで価格ルールを取得
+func (ds *PricingRuleDataSource) SelectByID(ctx context.Context, id uuid.UUID) (*entities.PricingRule, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m PricingRuleModel
+	if err := db.Where("id = ?", id).First(&m).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrPricingRuleNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// Update は価格ルールを更新
+func (ds *PricingRuleDataSource) Update(ctx context.Context, rule *entities.PricingRule) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&PricingRuleModel{}).
+		Where("id = ?", rule.ID).
+		Updates(map[string]interface{}{
+			"name":                  rule.Name,
+			"product_id":            rule.ProductID,
+			"category_code":         rule.CategoryCode,
+			"discount_type":         string(rule.DiscountType),
+			"discount_value":        rule.DiscountValue,
+			"target_role":           string(rule.TargetRole),
+			"min_tier":              string(rule.MinTier),
+			"max_quantity_per_user": rule.MaxQuantityPerUser,
+			"starts_at":             rule.StartsAt,
+			"ends_at":               rule.EndsAt,
+			"is_active":             rule.IsActive,
+			"updated_at":            rule.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrPricingRuleNotFound
+	}
+	return nil
+}
+
+// Delete は価格ルールを削除（適用済みの交換のpricing_rule_idはNULLになる）
+func (ds *PricingRuleDataSource) Delete(ctx context.Context, id uuid.UUID) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Where("id = ?", id).Delete(&PricingRuleModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrPricingRuleNotFound
+	}
+	return nil
+}
+
+// SelectList は価格ルールを開始日時の新しい順に取得
+func (ds *PricingRuleDataSource) SelectList(ctx context.Context, offset, limit int) ([]*entities.PricingRule, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var models []PricingRuleModel
+	if err := db.Order("starts_at DESC").Offset(offset).Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return ds.toEntities(models), nil
+}
+
+// Count は価格ルールの件数を取得
+func (ds *PricingRuleDataSource) Count(ctx context.Context) (int64, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var count int64
+	if err := db.Model(&PricingRuleModel{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// SelectActiveForProduct は指定日時に有効な、商品またはそのカテゴリを対象とする価格ルールを取得
+// 交換のトランザクション内で呼ばれるため書き込み用の接続を使う
+func (ds *PricingRuleDataSource) SelectActiveForProduct(ctx context.Context, productID uuid.UUID, categoryCode string, now time.Time) ([]*entities.PricingRule, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var models []PricingRuleModel
+	err := db.Where("is_active = ? AND starts_at <= ? AND (ends_at IS NULL OR ends_at > ?)", true, now, now).
+		Where("product_id = ? OR (product_id IS NULL AND category_code = ?)", productID, categoryCode).
+		Order("created_at ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return ds.toEntities(models), nil
+}
@@ -0,0 +1,81 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// ProcessedAkerunAccessModel は処理済みのAkerunアクセス記録のGORMモデル
+type ProcessedAkerunAccessModel struct {
+	AccessID       uuid.UUID `gorm:"type:uuid;primary_key"`
+	OrganizationID string    `gorm:"type:text;not null;default:''"`
+	AccessedAt     time.Time `gorm:"type:timestamptz;not null"`
+	ProcessedAt    time.Time `gorm:"type:timestamptz;not null;index"`
+}
+
+// TableName はテーブル名を指定
+func (ProcessedAkerunAccessModel) TableName() string {
+	return "processed_akerun_accesses"
+}
+
+// ProcessedAkerunAccessDataSource は処理済みのAkerunアクセス記録のデータソース
+type ProcessedAkerunAccessDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewProcessedAkerunAccessDataSource は新しいProcessedAkerunAccessDataSourceを作成
+func NewProcessedAkerunAccessDataSource(db infrapostgres.DB) *ProcessedAkerunAccessDataSource {
+	return &ProcessedAkerunAccessDataSource{db: db}
+}
+
+// SelectProcessedIDs はaccessIDsのうち処理済みのIDを取得
+func (ds *ProcessedAkerunAccessDataSource) SelectProcessedIDs(ctx context.Context, accessIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	processed := map[uuid.UUID]bool{}
+	if len(accessIDs) == 0 {
+		return processed, nil
+	}
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var ids []uuid.UUID
+	if err := db.Model(&ProcessedAkerunAccessModel{}).
+		Where("access_id IN ?", accessIDs).
+		Pluck("access_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		processed[id] = true
+	}
+	return processed, nil
+}
+
+// Insert は処理済みのアクセス記録を挿入（access_idが記録済みなら何もしない）
+func (ds *ProcessedAkerunAccessDataSource) Insert(ctx context.Context, accesses []*entities.ProcessedAkerunAccess) error {
+	if len(accesses) == 0 {
+		return nil
+	}
+	models := make([]ProcessedAkerunAccessModel, len(accesses))
+	for i, a := range accesses {
+		models[i] = ProcessedAkerunAccessModel{
+			AccessID:       a.AccessID,
+			OrganizationID: a.OrganizationID,
+			AccessedAt:     a.AccessedAt,
+			ProcessedAt:    a.ProcessedAt,
+		}
+	}
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "access_id"}},
+		DoNothing: true,
+	}).Create(&models).Error
+}
+
+// DeleteProcessedBefore はbeforeより前に処理した記録を削除
+func (ds *ProcessedAkerunAccessDataSource) DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Where("processed_at < ?", before).Delete(&ProcessedAkerunAccessModel{})
+	return result.RowsAffected, result.Error
+}
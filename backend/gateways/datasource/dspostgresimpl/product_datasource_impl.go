@@ -15,6 +15,7 @@ import (
 // ProductModel はGORM用の商品モデル
 type ProductModel struct {
 	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantID    uuid.UUID  `gorm:"type:uuid;not null;index;default:'00000000-0000-0000-0000-000000000001'"`
 	Name        string     `gorm:"type:varchar(255);not null"`
 	Description string     `gorm:"type:text"`
 	Category    string     `gorm:"type:varchar(100);not null"`
@@ -22,6 +23,7 @@ type ProductModel struct {
 	Stock       int        `gorm:"not null;check:stock >= -1"`
 	ImageURL    string     `gorm:"type:text"`
 	IsAvailable bool       `gorm:"not null;default:true"`
+	IsDigital   bool       `gorm:"not null;default:false"`
 	CreatedAt   time.Time  `gorm:"not null;default:now()"`
 	UpdatedAt   time.Time  `gorm:"not null;default:now()"`
 	DeletedAt   *time.Time `gorm:"index"`
@@ -43,6 +45,7 @@ func (p *ProductModel) ToDomain() *entities.Product {
 		Stock:        p.Stock,
 		ImageURL:     p.ImageURL,
 		IsAvailable:  p.IsAvailable,
+		IsDigital:    p.IsDigital,
 		CreatedAt:    p.CreatedAt,
 		UpdatedAt:    p.UpdatedAt,
 		DeletedAt:    p.DeletedAt,
@@ -59,6 +62,7 @@ func (p *ProductModel) FromDomain(product *entities.Product) {
 	p.Stock = product.Stock
 	p.ImageURL = product.ImageURL
 	p.IsAvailable = product.IsAvailable
+	p.IsDigital = product.IsDigital
 	p.CreatedAt = product.CreatedAt
 	p.UpdatedAt = product.UpdatedAt
 	p.DeletedAt = product.DeletedAt
@@ -108,8 +112,9 @@ func (ds *ProductDataSourceImpl) Update(ctx context.Context, product *entities.P
 	model.FromDomain(product)
 	model.UpdatedAt = time.Now()
 
+	// falseにした公開状態・デジタル商品の設定も保存するため全列を更新する
 	return infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("id = ? AND deleted_at IS NULL", product.ID).
-		Updates(model).Error
+		Select("*").Updates(model).Error
 }
 
 // Delete は商品を論理削除
@@ -25,6 +25,17 @@ type ProductExchangeModel struct {
 	CreatedAt     time.Time  `gorm:"not null;default:now()"`
 	CompletedAt   *time.Time
 	DeliveredAt   *time.Time
+
+	RedemptionCode *string `gorm:"type:varchar(20)"`
+	RedeemedAt     *time.Time
+
+	PricingRuleID *uuid.UUID `gorm:"type:uuid"`
+
+	RecipientID     *uuid.UUID `gorm:"type:uuid"`
+	GiftStatus      string     `gorm:"type:varchar(20);not null;default:''"`
+	GiftRespondedAt *time.Time
+
+	ShippingAddress string `gorm:"type:text;not null;default:''"`
 }
 
 // TableName はテーブル名を指定
@@ -34,7 +45,7 @@ func (ProductExchangeModel) TableName() string {
 
 // ToDomain はドメインモデルに変換
 func (e *ProductExchangeModel) ToDomain() *entities.ProductExchange {
-	return &entities.ProductExchange{
+	exchange := &entities.ProductExchange{
 		ID:            e.ID,
 		UserID:        e.UserID,
 		ProductID:     e.ProductID,
@@ -46,7 +57,19 @@ func (e *ProductExchangeModel) ToDomain() *entities.ProductExchange {
 		CreatedAt:     e.CreatedAt,
 		CompletedAt:   e.CompletedAt,
 		DeliveredAt:   e.DeliveredAt,
+		RedeemedAt:    e.RedeemedAt,
+		PricingRuleID: e.PricingRuleID,
+
+		RecipientID:     e.RecipientID,
+		GiftStatus:      entities.GiftStatus(e.GiftStatus),
+		GiftRespondedAt: e.GiftRespondedAt,
+
+		ShippingAddress: e.ShippingAddress,
 	}
+	if e.RedemptionCode != nil {
+		exchange.RedemptionCode = *e.RedemptionCode
+	}
+	return exchange
 }
 
 // FromDomain はドメインモデルから変換
@@ -62,6 +85,18 @@ func (e *ProductExchangeModel) FromDomain(exchange *entities.ProductExchange) {
 	e.CreatedAt = exchange.CreatedAt
 	e.CompletedAt = exchange.CompletedAt
 	e.DeliveredAt = exchange.DeliveredAt
+	e.RedeemedAt = exchange.RedeemedAt
+	e.PricingRuleID = exchange.PricingRuleID
+	e.RecipientID = exchange.RecipientID
+	e.GiftStatus = string(exchange.GiftStatus)
+	e.GiftRespondedAt = exchange.GiftRespondedAt
+	e.ShippingAddress = exchange.ShippingAddress
+	// 物理商品はNULL（一意制約の対象外にする）
+	e.RedemptionCode = nil
+	if exchange.RedemptionCode != "" {
+		code := exchange.RedemptionCode
+		e.RedemptionCode = &code
+	}
 }
 
 // ProductExchangeDataSourceImpl はProductExchangeDataSourceの実装
@@ -96,7 +131,7 @@ func (ds *ProductExchangeDataSourceImpl) Select(ctx context.Context, id uuid.UUI
 	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("id = ?", id).First(&model).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("exchange not found")
+			return nil, entities.ErrExchangeNotFound
 		}
 		return nil, err
 	}
@@ -118,7 +153,7 @@ func (ds *ProductExchangeDataSourceImpl) Update(ctx context.Context, exchange *e
 func (ds *ProductExchangeDataSourceImpl) SelectListByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.ProductExchange, error) {
 	var models []ProductExchangeModel
 
-	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("user_id = ?", userID).
+	err := infrapostgres.GetReadDB(ctx, ds.db).Where("user_id = ?", userID).
 		Offset(offset).
 		Limit(limit).
 		Order("created_at DESC").
@@ -140,7 +175,7 @@ func (ds *ProductExchangeDataSourceImpl) SelectListByUserID(ctx context.Context,
 func (ds *ProductExchangeDataSourceImpl) SelectListAll(ctx context.Context, offset, limit int) ([]*entities.ProductExchange, error) {
 	var models []ProductExchangeModel
 
-	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).
+	err := infrapostgres.GetReadDB(ctx, ds.db).
 		Offset(offset).
 		Limit(limit).
 		Order("created_at DESC").
@@ -158,16 +193,133 @@ func (ds *ProductExchangeDataSourceImpl) SelectListAll(ctx context.Context, offs
 	return exchanges, nil
 }
 
+// SelectListByRecipientID はユーザーが贈られた交換を取得
+func (ds *ProductExchangeDataSourceImpl) SelectListByRecipientID(ctx context.Context, recipientID uuid.UUID, offset, limit int) ([]*entities.ProductExchange, error) {
+	var models []ProductExchangeModel
+
+	err := infrapostgres.GetReadDB(ctx, ds.db).Where("recipient_id = ?", recipientID).
+		Offset(offset).
+		Limit(limit).
+		Order("created_at DESC").
+		Find(&models).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	exchanges := make([]*entities.ProductExchange, len(models))
+	for i, model := range models {
+		exchanges[i] = model.ToDomain()
+	}
+	return exchanges, nil
+}
+
 // CountByUserID はユーザーの交換総数を取得
 func (ds *ProductExchangeDataSourceImpl) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
 	var count int64
-	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&ProductExchangeModel{}).Where("user_id = ?", userID).Count(&count).Error
+	err := infrapostgres.GetReadDB(ctx, ds.db).Model(&ProductExchangeModel{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// CountByRecipientID はユーザーが贈られた交換の総数を取得
+func (ds *ProductExchangeDataSourceImpl) CountByRecipientID(ctx context.Context, recipientID uuid.UUID) (int64, error) {
+	var count int64
+	err := infrapostgres.GetReadDB(ctx, ds.db).Model(&ProductExchangeModel{}).Where("recipient_id = ?", recipientID).Count(&count).Error
 	return count, err
 }
 
 // CountAll は全体の交換総数を取得
 func (ds *ProductExchangeDataSourceImpl) CountAll(ctx context.Context) (int64, error) {
 	var count int64
-	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&ProductExchangeModel{}).Count(&count).Error
+	err := infrapostgres.GetReadDB(ctx, ds.db).Model(&ProductExchangeModel{}).Count(&count).Error
 	return count, err
 }
+
+// SelectByRedemptionCode は引換コードで交換を検索
+func (ds *ProductExchangeDataSourceImpl) SelectByRedemptionCode(ctx context.Context, code string) (*entities.ProductExchange, error) {
+	var model ProductExchangeModel
+
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("redemption_code = ?", code).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrRedemptionCodeNotFound
+		}
+		return nil, err
+	}
+
+	return model.ToDomain(), nil
+}
+
+// UpdateRedeemed は未使用の引換コードだけを使用済みにする（同時に引き換えられても1回だけ成功する）
+func (ds *ProductExchangeDataSourceImpl) UpdateRedeemed(ctx context.Context, exchange *entities.ProductExchange) error {
+	result := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&ProductExchangeModel{}).
+		Where("id = ? AND status = ? AND redeemed_at IS NULL", exchange.ID, string(entities.ExchangeStatusCompleted)).
+		Updates(map[string]interface{}{
+			"status":       string(exchange.Status),
+			"redeemed_at":  exchange.RedeemedAt,
+			"delivered_at": exchange.DeliveredAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrRedemptionCodeUsed
+	}
+	return nil
+}
+
+// exchangeReportRow は交換実績の集計結果の行
+type exchangeReportRow struct {
+	PeriodStart   time.Time
+	ProductID     uuid.UUID
+	ProductName   string
+	ExchangeCount int64
+	Quantity      int64
+	PointsUsed    int64
+}
+
+// SelectReport は期間内の交換を商品ごと・期間ごとに集計（キャンセルは除く）
+func (ds *ProductExchangeDataSourceImpl) SelectReport(ctx context.Context, from, to time.Time, granularity entities.AnalyticsGranularity) ([]*entities.ExchangeReportRow, error) {
+	tenant, tenantArgs := tenantCondition(ctx, "p.tenant_id")
+	args := append([]interface{}{string(granularity), from, to, string(entities.ExchangeStatusCancelled)}, tenantArgs...)
+	var rows []exchangeReportRow
+	err := infrapostgres.GetReadDB(ctx, ds.db).Raw(`
+		SELECT date_trunc(?, e.created_at) AS period_start,
+			e.product_id,
+			p.name AS product_name,
+			COUNT(*) AS exchange_count,
+			COALESCE(SUM(e.quantity), 0) AS quantity,
+			COALESCE(SUM(e.points_used), 0) AS points_used
+		FROM product_exchanges e
+		JOIN products p ON p.id = e.product_id
+		WHERE e.created_at >= ? AND e.created_at < ? AND e.status <> ?`+tenant+`
+		GROUP BY period_start, e.product_id, p.name
+		ORDER BY period_start ASC, quantity DESC, p.name ASC`, args...).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.ExchangeReportRow, len(rows))
+	for i, r := range rows {
+		result[i] = &entities.ExchangeReportRow{
+			PeriodStart:   r.PeriodStart,
+			ProductID:     r.ProductID,
+			ProductName:   r.ProductName,
+			ExchangeCount: r.ExchangeCount,
+			Quantity:      r.Quantity,
+			PointsUsed:    r.PointsUsed,
+		}
+	}
+	return result, nil
+}
+
+// SumQuantityByPricingRule はユーザーが価格ルールの割引価格で交換した数量の合計を取得（キャンセルは除く）
+func (ds *ProductExchangeDataSourceImpl) SumQuantityByPricingRule(ctx context.Context, userID, ruleID uuid.UUID) (int, error) {
+	var total int
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&ProductExchangeModel{}).
+		Where("user_id = ? AND pricing_rule_id = ? AND status <> ?", userID, ruleID, string(entities.ExchangeStatusCancelled)).
+		Select("COALESCE(SUM(quantity), 0)").
+		Scan(&total).Error
+	return total, err
+}
@@ -88,7 +88,7 @@ func (ds *QRCodeDataSourceImpl) SelectByCode(ctx context.Context, code string) (
 	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("code = ?", code).First(&model).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("qr code not found")
+			return nil, entities.ErrQRCodeNotFound
 		}
 		return nil, err
 	}
@@ -103,7 +103,7 @@ func (ds *QRCodeDataSourceImpl) Select(ctx context.Context, id uuid.UUID) (*enti
 	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("id = ?", id).First(&model).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("qr code not found")
+			return nil, entities.ErrQRCodeNotFound
 		}
 		return nil, err
 	}
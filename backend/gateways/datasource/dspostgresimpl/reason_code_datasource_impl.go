@@ -0,0 +1,118 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReasonCodeModel は理由コードのGORMモデル
+type ReasonCodeModel struct {
+	Code        string    `gorm:"type:varchar(32);primary_key"`
+	Label       string    `gorm:"type:varchar(50);not null"`
+	Description string    `gorm:"type:varchar(200);not null;default:''"`
+	IsActive    bool      `gorm:"not null;default:true"`
+	CreatedAt   time.Time `gorm:"type:timestamptz;not null"`
+	UpdatedAt   time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (ReasonCodeModel) TableName() string {
+	return "reason_codes"
+}
+
+// ReasonCodeDataSource は理由コードのデータソース
+type ReasonCodeDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewReasonCodeDataSource は新しいReasonCodeDataSourceを作成
+func NewReasonCodeDataSource(db infrapostgres.DB) *ReasonCodeDataSource {
+	return &ReasonCodeDataSource{db: db}
+}
+
+func (ds *ReasonCodeDataSource) toEntity(m *ReasonCodeModel) *entities.ReasonCode {
+	return &entities.ReasonCode{
+		Code:        m.Code,
+		Label:       m.Label,
+		Description: m.Description,
+		IsActive:    m.IsActive,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// Insert は理由コードを挿入（同じコードが既にあればErrReasonCodeExists）
+func (ds *ReasonCodeDataSource) Insert(ctx context.Context, r *entities.ReasonCode) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&ReasonCodeModel{
+		Code:        r.Code,
+		Label:       r.Label,
+		Description: r.Description,
+		IsActive:    r.IsActive,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrReasonCodeExists
+	}
+	return nil
+}
+
+// SelectByCode はコードで理由コードを取得
+func (ds *ReasonCodeDataSource) SelectByCode(ctx context.Context, code string) (*entities.ReasonCode, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m ReasonCodeModel
+	if err := db.Where("code = ?", code).First(&m).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrReasonCodeNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// Update は理由コードの表示名・説明・有効状態を更新
+func (ds *ReasonCodeDataSource) Update(ctx context.Context, r *entities.ReasonCode) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&ReasonCodeModel{}).
+		Where("code = ?", r.Code).
+		Updates(map[string]interface{}{
+			"label":       r.Label,
+			"description": r.Description,
+			"is_active":   r.IsActive,
+			"updated_at":  r.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrReasonCodeNotFound
+	}
+	return nil
+}
+
+// SelectList は理由コードをコード順に取得
+func (ds *ReasonCodeDataSource) SelectList(ctx context.Context, activeOnly bool) ([]*entities.ReasonCode, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	query := db.Order("code ASC")
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	var models []ReasonCodeModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+	reasonCodes := make([]*entities.ReasonCode, len(models))
+	for i := range models {
+		reasonCodes[i] = ds.toEntity(&models[i])
+	}
+	return reasonCodes, nil
+}
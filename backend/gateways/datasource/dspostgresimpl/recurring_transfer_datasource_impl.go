@@ -0,0 +1,185 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecurringTransferModel は定期送金のGORMモデル
+type RecurringTransferModel struct {
+	ID                  uuid.UUID  `gorm:"type:uuid;primary_key"`
+	FromUserID          uuid.UUID  `gorm:"type:uuid;not null"`
+	ToUserID            uuid.UUID  `gorm:"type:uuid;not null"`
+	Amount              int64      `gorm:"not null"`
+	ScheduleInterval    string     `gorm:"type:varchar(20);not null"`
+	Message             string     `gorm:"type:text;not null;default:''"`
+	Status              string     `gorm:"type:varchar(20);not null"`
+	StartAt             time.Time  `gorm:"type:timestamptz;not null"`
+	NextRunAt           time.Time  `gorm:"type:timestamptz;not null"`
+	LastRunAt           *time.Time `gorm:"type:timestamptz"`
+	RunCount            int        `gorm:"not null;default:0"`
+	ConsecutiveFailures int        `gorm:"not null;default:0"`
+	StopReason          string     `gorm:"type:text;not null;default:''"`
+	CancelledBy         *uuid.UUID `gorm:"type:uuid"`
+	CancelledAt         *time.Time `gorm:"type:timestamptz"`
+	CreatedAt           time.Time  `gorm:"type:timestamptz;not null"`
+	UpdatedAt           time.Time  `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (RecurringTransferModel) TableName() string {
+	return "recurring_transfers"
+}
+
+// RecurringTransferDataSource は定期送金のデータソース
+type RecurringTransferDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewRecurringTransferDataSource は新しいRecurringTransferDataSourceを作成
+func NewRecurringTransferDataSource(db infrapostgres.DB) *RecurringTransferDataSource {
+	return &RecurringTransferDataSource{db: db}
+}
+
+func (ds *RecurringTransferDataSource) toEntity(m *RecurringTransferModel) *entities.RecurringTransfer {
+	return &entities.RecurringTransfer{
+		ID:                  m.ID,
+		FromUserID:          m.FromUserID,
+		ToUserID:            m.ToUserID,
+		Amount:              m.Amount,
+		Interval:            entities.RecurringInterval(m.ScheduleInterval),
+		Message:             m.Message,
+		Status:              entities.RecurringTransferStatus(m.Status),
+		StartAt:             m.StartAt,
+		NextRunAt:           m.NextRunAt,
+		LastRunAt:           m.LastRunAt,
+		RunCount:            m.RunCount,
+		ConsecutiveFailures: m.ConsecutiveFailures,
+		StopReason:          m.StopReason,
+		CancelledBy:         m.CancelledBy,
+		CancelledAt:         m.CancelledAt,
+		CreatedAt:           m.CreatedAt,
+		UpdatedAt:           m.UpdatedAt,
+	}
+}
+
+func (ds *RecurringTransferDataSource) toModel(e *entities.RecurringTransfer) *RecurringTransferModel {
+	return &RecurringTransferModel{
+		ID:                  e.ID,
+		FromUserID:          e.FromUserID,
+		ToUserID:            e.ToUserID,
+		Amount:              e.Amount,
+		ScheduleInterval:    string(e.Interval),
+		Message:             e.Message,
+		Status:              string(e.Status),
+		StartAt:             e.StartAt,
+		NextRunAt:           e.NextRunAt,
+		LastRunAt:           e.LastRunAt,
+		RunCount:            e.RunCount,
+		ConsecutiveFailures: e.ConsecutiveFailures,
+		StopReason:          e.StopReason,
+		CancelledBy:         e.CancelledBy,
+		CancelledAt:         e.CancelledAt,
+		CreatedAt:           e.CreatedAt,
+		UpdatedAt:           e.UpdatedAt,
+	}
+}
+
+func (ds *RecurringTransferDataSource) toEntities(models []RecurringTransferModel) []*entities.RecurringTransfer {
+	transfers := make([]*entities.RecurringTransfer, len(models))
+	for i := range models {
+		transfers[i] = ds.toEntity(&models[i])
+	}
+	return transfers
+}
+
+// Insert は定期送金を挿入
+func (ds *RecurringTransferDataSource) Insert(ctx context.Context, transfer *entities.RecurringTransfer) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Create(ds.toModel(transfer)).Error
+}
+
+// SelectByID はIDで定期送金を取得
+func (ds *RecurringTransferDataSource) SelectByID(ctx context.Context, id uuid.UUID) (*entities.RecurringTransfer, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m RecurringTransferModel
+	if err := db.Where("id = ?", id).First(&m).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrRecurringNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// Update は定期送金の状態と実行履歴を更新
+func (ds *RecurringTransferDataSource) Update(ctx context.Context, transfer *entities.RecurringTransfer) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&RecurringTransferModel{}).
+		Where("id = ?", transfer.ID).
+		Updates(map[string]interface{}{
+			"status":               string(transfer.Status),
+			"next_run_at":          transfer.NextRunAt,
+			"last_run_at":          transfer.LastRunAt,
+			"run_count":            transfer.RunCount,
+			"consecutive_failures": transfer.ConsecutiveFailures,
+			"stop_reason":          transfer.StopReason,
+			"cancelled_by":         transfer.CancelledBy,
+			"cancelled_at":         transfer.CancelledAt,
+			"updated_at":           transfer.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrRecurringNotFound
+	}
+	return nil
+}
+
+// SelectByUser は送信者または受取人として関わる定期送金を新しい順に取得
+func (ds *RecurringTransferDataSource) SelectByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.RecurringTransfer, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var models []RecurringTransferModel
+	err := db.Where("from_user_id = ? OR to_user_id = ?", userID, userID).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return ds.toEntities(models), nil
+}
+
+// CountByUser は送信者または受取人として関わる定期送金の件数を取得
+func (ds *RecurringTransferDataSource) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var count int64
+	err := db.Model(&RecurringTransferModel{}).
+		Where("from_user_id = ? OR to_user_id = ?", userID, userID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// SelectDue は実行日時を過ぎた有効な定期送金を古い順に取得
+func (ds *RecurringTransferDataSource) SelectDue(ctx context.Context, now time.Time, limit int) ([]*entities.RecurringTransfer, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var models []RecurringTransferModel
+	err := db.Where("status = ? AND next_run_at <= ?", string(entities.RecurringTransferStatusActive), now).
+		Order("next_run_at ASC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return ds.toEntities(models), nil
+}
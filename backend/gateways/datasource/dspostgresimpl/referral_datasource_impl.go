@@ -0,0 +1,246 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReferralCodeModel は紹介コードのGORMモデル
+type ReferralCodeModel struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primary_key"`
+	Code      string    `gorm:"type:varchar(20);not null;uniqueIndex"`
+	CreatedAt time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (ReferralCodeModel) TableName() string {
+	return "referral_codes"
+}
+
+// ReferralModel は紹介のGORMモデル
+type ReferralModel struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key"`
+	ReferrerID     *uuid.UUID `gorm:"type:uuid"` // 紹介者の退会後はNULL
+	RefereeID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex"`
+	Code           string     `gorm:"type:varchar(20);not null"`
+	Status         string     `gorm:"type:varchar(20);not null"`
+	RejectReason   string     `gorm:"type:varchar(50);not null;default:''"`
+	IPAddress      string     `gorm:"type:varchar(100);not null;default:''"`
+	ReferrerReward int64      `gorm:"not null;default:0"`
+	RefereeReward  int64      `gorm:"not null;default:0"`
+	CreatedAt      time.Time  `gorm:"type:timestamptz;not null"`
+	RewardedAt     *time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName はテーブル名を指定
+func (ReferralModel) TableName() string {
+	return "referrals"
+}
+
+// ReferralDataSource は紹介コード・紹介のデータソース
+type ReferralDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewReferralDataSource は新しいReferralDataSourceを作成
+func NewReferralDataSource(db infrapostgres.DB) *ReferralDataSource {
+	return &ReferralDataSource{db: db}
+}
+
+func (ds *ReferralDataSource) toEntity(m *ReferralModel) *entities.Referral {
+	r := &entities.Referral{
+		ID:             m.ID,
+		RefereeID:      m.RefereeID,
+		Code:           m.Code,
+		Status:         entities.ReferralStatus(m.Status),
+		RejectReason:   entities.ReferralRejectReason(m.RejectReason),
+		IPAddress:      m.IPAddress,
+		ReferrerReward: m.ReferrerReward,
+		RefereeReward:  m.RefereeReward,
+		CreatedAt:      m.CreatedAt,
+		RewardedAt:     m.RewardedAt,
+	}
+	if m.ReferrerID != nil {
+		r.ReferrerID = *m.ReferrerID
+	}
+	return r
+}
+
+// SelectCodeByUser はユーザーの紹介コードを取得（未発行ならnil）
+func (ds *ReferralDataSource) SelectCodeByUser(ctx context.Context, userID uuid.UUID) (*entities.ReferralCode, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m ReferralCodeModel
+	if err := db.Where("user_id = ?", userID).First(&m).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entities.ReferralCode{UserID: m.UserID, Code: m.Code, CreatedAt: m.CreatedAt}, nil
+}
+
+// SelectCodeByCode は紹介コードから持ち主を取得
+func (ds *ReferralDataSource) SelectCodeByCode(ctx context.Context, code string) (*entities.ReferralCode, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m ReferralCodeModel
+	if err := db.Where("code = ?", code).First(&m).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrInvalidReferralCode
+		}
+		return nil, err
+	}
+	return &entities.ReferralCode{UserID: m.UserID, Code: m.Code, CreatedAt: m.CreatedAt}, nil
+}
+
+// InsertCode は紹介コードを挿入（同じユーザーのコードが既にあれば何もしない）
+func (ds *ReferralDataSource) InsertCode(ctx context.Context, code *entities.ReferralCode) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoNothing: true,
+	}).Create(&ReferralCodeModel{
+		UserID:    code.UserID,
+		Code:      code.Code,
+		CreatedAt: code.CreatedAt,
+	}).Error
+}
+
+// Insert は紹介を挿入
+func (ds *ReferralDataSource) Insert(ctx context.Context, r *entities.Referral) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	referrerID := r.ReferrerID
+	return db.Create(&ReferralModel{
+		ID:             r.ID,
+		ReferrerID:     &referrerID,
+		RefereeID:      r.RefereeID,
+		Code:           r.Code,
+		Status:         string(r.Status),
+		RejectReason:   string(r.RejectReason),
+		IPAddress:      r.IPAddress,
+		ReferrerReward: r.ReferrerReward,
+		RefereeReward:  r.RefereeReward,
+		CreatedAt:      r.CreatedAt,
+		RewardedAt:     r.RewardedAt,
+	}).Error
+}
+
+// SelectPendingByReferee は紹介された側の初回チェックイン待ちの紹介を取得（なければnil）
+func (ds *ReferralDataSource) SelectPendingByReferee(ctx context.Context, refereeID uuid.UUID) (*entities.Referral, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m ReferralModel
+	err := db.Where("referee_id = ? AND status = ?", refereeID, string(entities.ReferralStatusPending)).First(&m).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// CountByIPSince は指定日時以降に同じIPアドレスから登録された紹介の数を取得
+func (ds *ReferralDataSource) CountByIPSince(ctx context.Context, ipAddress string, since time.Time) (int64, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var count int64
+	err := db.Model(&ReferralModel{}).
+		Where("ip_address = ? AND created_at >= ?", ipAddress, since).
+		Count(&count).Error
+	return count, err
+}
+
+// UpdateRewarded は初回チェックイン待ちの紹介を特典付与済みにする（既に処理済みならfalse）
+func (ds *ReferralDataSource) UpdateRewarded(ctx context.Context, id uuid.UUID, referrerReward, refereeReward int64, at time.Time) (bool, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&ReferralModel{}).
+		Where("id = ? AND status = ?", id, string(entities.ReferralStatusPending)).
+		Updates(map[string]interface{}{
+			"status":          string(entities.ReferralStatusRewarded),
+			"referrer_reward": referrerReward,
+			"referee_reward":  refereeReward,
+			"rewarded_at":     at,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SelectReport は期間内に登録された紹介の実績を集計
+func (ds *ReferralDataSource) SelectReport(ctx context.Context, from, to time.Time, limit int) (*entities.ReferralReport, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+
+	var totals struct {
+		Invited       int64
+		Pending       int64
+		Rewarded      int64
+		Rejected      int64
+		PointsAwarded int64
+	}
+	err := db.Model(&ReferralModel{}).
+		Scopes(tenantUserScope(ctx, "referrer_id")).
+		Select(`COUNT(*) AS invited,
+			COUNT(*) FILTER (WHERE status = 'pending') AS pending,
+			COUNT(*) FILTER (WHERE status = 'rewarded') AS rewarded,
+			COUNT(*) FILTER (WHERE status = 'rejected') AS rejected,
+			COALESCE(SUM(referrer_reward + referee_reward), 0) AS points_awarded`).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		UserID       uuid.UUID
+		Username     string
+		DisplayName  string
+		Invited      int64
+		Rewarded     int64
+		Rejected     int64
+		PointsEarned int64
+	}
+	err = db.Table("referrals r").
+		Scopes(tenantScope(ctx, "u.tenant_id")).
+		Select(`r.referrer_id AS user_id, u.username, u.display_name,
+			COUNT(*) AS invited,
+			COUNT(*) FILTER (WHERE r.status = 'rewarded') AS rewarded,
+			COUNT(*) FILTER (WHERE r.status = 'rejected') AS rejected,
+			COALESCE(SUM(r.referrer_reward), 0) AS points_earned`).
+		Joins("JOIN users u ON u.id = r.referrer_id").
+		Where("r.created_at >= ? AND r.created_at < ?", from, to).
+		Group("r.referrer_id, u.username, u.display_name").
+		Order("invited DESC, rewarded DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entities.ReferralReport{
+		From:          from,
+		To:            to,
+		Invited:       totals.Invited,
+		Pending:       totals.Pending,
+		Rewarded:      totals.Rewarded,
+		Rejected:      totals.Rejected,
+		PointsAwarded: totals.PointsAwarded,
+		TopReferrers:  make([]*entities.ReferrerStats, 0, len(rows)),
+	}
+	for _, r := range rows {
+		report.TopReferrers = append(report.TopReferrers, &entities.ReferrerStats{
+			UserID:       r.UserID,
+			Username:     r.Username,
+			DisplayName:  r.DisplayName,
+			Invited:      r.Invited,
+			Rewarded:     r.Rewarded,
+			Rejected:     r.Rejected,
+			PointsEarned: r.PointsEarned,
+		})
+	}
+	return report, nil
+}
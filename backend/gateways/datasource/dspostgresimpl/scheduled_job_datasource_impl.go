@@ -0,0 +1,118 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ScheduledJobModel は定期実行ジョブのGORMモデル
+type ScheduledJobModel struct {
+	Name           string     `gorm:"type:varchar(50);primary_key"`
+	Schedule       string     `gorm:"type:varchar(100);not null"`
+	NextRunAt      *time.Time `gorm:"type:timestamptz"`
+	LockedBy       string     `gorm:"type:varchar(255);not null;default:''"`
+	LockedUntil    *time.Time `gorm:"type:timestamptz"`
+	LastStartedAt  *time.Time `gorm:"type:timestamptz"`
+	LastFinishedAt *time.Time `gorm:"type:timestamptz"`
+	LastStatus     string     `gorm:"type:varchar(20);not null;default:''"`
+	LastError      string     `gorm:"type:text;not null;default:''"`
+	LastProcessed  *int64
+}
+
+// TableName はテーブル名を指定
+func (ScheduledJobModel) TableName() string {
+	return "scheduled_jobs"
+}
+
+// ScheduledJobDataSource は定期実行ジョブのデータソース
+type ScheduledJobDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewScheduledJobDataSource は新しいScheduledJobDataSourceを作成
+func NewScheduledJobDataSource(db infrapostgres.DB) *ScheduledJobDataSource {
+	return &ScheduledJobDataSource{db: db}
+}
+
+// UpsertSchedule はジョブの行がなければ作成し、cron式が変わっていれば次回実行日時とともに更新
+func (ds *ScheduledJobDataSource) UpsertSchedule(ctx context.Context, name entities.ScheduledJobName, schedule string, nextRunAt *time.Time) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "name"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"schedule":    gorm.Expr("EXCLUDED.schedule"),
+			"next_run_at": gorm.Expr("EXCLUDED.next_run_at"),
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			gorm.Expr("scheduled_jobs.schedule <> EXCLUDED.schedule"),
+		}},
+	}).Create(&ScheduledJobModel{
+		Name:      string(name),
+		Schedule:  schedule,
+		NextRunAt: nextRunAt,
+	}).Error
+}
+
+// UpdateLock は実行予定時刻を過ぎていてロックが空いている場合だけロックを取る（取れたかを返す）
+func (ds *ScheduledJobDataSource) UpdateLock(ctx context.Context, name entities.ScheduledJobName, owner string, now, lockedUntil, nextRunAt time.Time) (bool, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&ScheduledJobModel{}).
+		Where("name = ? AND next_run_at <= ? AND (locked_until IS NULL OR locked_until <= ?)", string(name), now, now).
+		Updates(map[string]interface{}{
+			"locked_by":       owner,
+			"locked_until":    lockedUntil,
+			"next_run_at":     nextRunAt,
+			"last_started_at": now,
+			"last_status":     string(entities.ScheduledJobStatusRunning),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateFinished は実行結果を記録してロックを解放
+func (ds *ScheduledJobDataSource) UpdateFinished(ctx context.Context, run *entities.ScheduledJobRun) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Model(&ScheduledJobModel{}).
+		Where("name = ? AND locked_by = ?", string(run.Name), run.Owner).
+		Updates(map[string]interface{}{
+			"locked_by":        "",
+			"locked_until":     nil,
+			"last_finished_at": run.FinishedAt,
+			"last_status":      string(run.Status),
+			"last_error":       run.Error,
+			"last_processed":   run.Processed,
+		}).Error
+}
+
+// SelectList は全ジョブの状況を名前順に取得
+func (ds *ScheduledJobDataSource) SelectList(ctx context.Context) ([]*entities.ScheduledJob, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var models []ScheduledJobModel
+	if err := db.Order("name ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	jobs := make([]*entities.ScheduledJob, len(models))
+	for i := range models {
+		m := &models[i]
+		jobs[i] = &entities.ScheduledJob{
+			Name:           entities.ScheduledJobName(m.Name),
+			Schedule:       m.Schedule,
+			NextRunAt:      m.NextRunAt,
+			LockedBy:       m.LockedBy,
+			LockedUntil:    m.LockedUntil,
+			LastStartedAt:  m.LastStartedAt,
+			LastFinishedAt: m.LastFinishedAt,
+			LastStatus:     entities.ScheduledJobStatus(m.LastStatus),
+			LastError:      m.LastError,
+			LastProcessed:  m.LastProcessed,
+		}
+	}
+	return jobs, nil
+}
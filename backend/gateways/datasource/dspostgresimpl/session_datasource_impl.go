@@ -14,14 +14,19 @@ import (
 
 // SessionModel はGORM用のセッションモデル
 type SessionModel struct {
-	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	UserID       uuid.UUID `gorm:"type:uuid;not null;index"`
-	SessionToken string    `gorm:"type:varchar(255);uniqueIndex;not null"`
-	CSRFToken    string    `gorm:"type:varchar(255);not null"`
-	IPAddress    string    `gorm:"type:varchar(100)"`
-	UserAgent    string    `gorm:"type:text"`
-	ExpiresAt    time.Time `gorm:"not null;index"`
-	CreatedAt    time.Time `gorm:"not null;default:now()"`
+	ID                uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantID          uuid.UUID `gorm:"type:uuid;not null;default:'00000000-0000-0000-0000-000000000001'"`
+	UserID            uuid.UUID `gorm:"type:uuid;not null;index"`
+	SessionToken      string    `gorm:"type:varchar(255);uniqueIndex;not null"`
+	CSRFToken         string    `gorm:"type:varchar(255);not null"`
+	PreviousCSRFToken string    `gorm:"column:previous_csrf_token;type:varchar(255);not null;default:''"`
+	CSRFIssuedAt      time.Time `gorm:"column:csrf_issued_at;not null;default:now()"`
+	IPAddress         string    `gorm:"type:varchar(100)"`
+	UserAgent         string    `gorm:"type:text"`
+	Fingerprint       string    `gorm:"type:varchar(64);not null;default:''"`
+	ExpiresAt         time.Time `gorm:"not null;index"`
+	LastActiveAt      time.Time `gorm:"not null;default:now()"`
+	CreatedAt         time.Time `gorm:"not null;default:now()"`
 }
 
 // TableName はテーブル名を指定
@@ -29,17 +34,30 @@ func (SessionModel) TableName() string {
 	return "sessions"
 }
 
+// BeforeCreate はテナントのないcontextで作成するセッションを、ユーザーのテナントに入れる
+// セッションはテナントごとに分けるため、他のテナントのホストではトークンが無効になる
+func (s *SessionModel) BeforeCreate(tx *gorm.DB) error {
+	if s.TenantID == uuid.Nil {
+		s.TenantID = tenantOfUser(tx, s.UserID)
+	}
+	return nil
+}
+
 // ToDomain はドメインモデルに変換
 func (s *SessionModel) ToDomain() *entities.Session {
 	return &entities.Session{
-		ID:           s.ID,
-		UserID:       s.UserID,
-		SessionToken: s.SessionToken,
-		CSRFToken:    s.CSRFToken,
-		IPAddress:    s.IPAddress,
-		UserAgent:    s.UserAgent,
-		ExpiresAt:    s.ExpiresAt,
-		CreatedAt:    s.CreatedAt,
+		ID:                s.ID,
+		UserID:            s.UserID,
+		SessionToken:      s.SessionToken,
+		CSRFToken:         s.CSRFToken,
+		PreviousCSRFToken: s.PreviousCSRFToken,
+		CSRFIssuedAt:      s.CSRFIssuedAt,
+		IPAddress:         s.IPAddress,
+		UserAgent:         s.UserAgent,
+		Fingerprint:       s.Fingerprint,
+		ExpiresAt:         s.ExpiresAt,
+		LastActiveAt:      s.LastActiveAt,
+		CreatedAt:         s.CreatedAt,
 	}
 }
 
@@ -49,9 +67,13 @@ func (s *SessionModel) FromDomain(session *entities.Session) {
 	s.UserID = session.UserID
 	s.SessionToken = session.SessionToken
 	s.CSRFToken = session.CSRFToken
+	s.PreviousCSRFToken = session.PreviousCSRFToken
+	s.CSRFIssuedAt = session.CSRFIssuedAt
 	s.IPAddress = session.IPAddress
 	s.UserAgent = session.UserAgent
+	s.Fingerprint = session.Fingerprint
 	s.ExpiresAt = session.ExpiresAt
+	s.LastActiveAt = session.LastActiveAt
 	s.CreatedAt = session.CreatedAt
 }
 
@@ -93,16 +115,89 @@ func (ds *SessionDataSourceImpl) SelectByToken(ctx context.Context, token string
 	return model.ToDomain(), nil
 }
 
+// Select はIDでセッションを検索
+func (ds *SessionDataSourceImpl) Select(ctx context.Context, id uuid.UUID) (*entities.Session, error) {
+	var model SessionModel
+
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("session not found")
+		}
+		return nil, err
+	}
+
+	return model.ToDomain(), nil
+}
+
+// SelectListByUserID はユーザーの有効なセッション一覧を取得（最終アクティブ順）
+func (ds *SessionDataSourceImpl) SelectListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Session, error) {
+	var models []SessionModel
+
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).
+		Where("user_id = ? AND expires_at > ?", userID, time.Now()).
+		Order("last_active_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*entities.Session, len(models))
+	for i, model := range models {
+		sessions[i] = model.ToDomain()
+	}
+	return sessions, nil
+}
+
 // Update はセッションを更新
+// 更新対象が存在しない場合（他リクエストで失効済み）は "session not found" を返す
 func (ds *SessionDataSourceImpl) Update(ctx context.Context, session *entities.Session) error {
 	model := &SessionModel{}
 	model.FromDomain(session)
 
-	return infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&SessionModel{}).
+	result := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&SessionModel{}).
 		Where("id = ?", session.ID).
 		Updates(map[string]interface{}{
-			"expires_at": model.ExpiresAt,
-		}).Error
+			"expires_at":     model.ExpiresAt,
+			"last_active_at": model.LastActiveAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("session not found")
+	}
+	return nil
+}
+
+// UpdateCSRFToken は保存されたトークンがpreviousTokenのときだけCSRFトークンを更新し、更新したかを返す
+// 条件付きの更新にすることで、並行したリクエストが同時に入れ替えても片方の入れ替えだけが残る
+func (ds *SessionDataSourceImpl) UpdateCSRFToken(ctx context.Context, session *entities.Session, previousToken string) (bool, error) {
+	result := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&SessionModel{}).
+		Where("id = ? AND csrf_token = ?", session.ID, previousToken).
+		Updates(map[string]interface{}{
+			"csrf_token":          session.CSRFToken,
+			"previous_csrf_token": session.PreviousCSRFToken,
+			"csrf_issued_at":      session.CSRFIssuedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateFingerprint はセッションを固定する端末・接続元の指紋を更新
+func (ds *SessionDataSourceImpl) UpdateFingerprint(ctx context.Context, id uuid.UUID, fingerprint string) error {
+	result := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&SessionModel{}).
+		Where("id = ?", id).
+		Update("fingerprint", fingerprint)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("session not found")
+	}
+	return nil
 }
 
 // Delete はセッションを削除
@@ -115,9 +210,104 @@ func (ds *SessionDataSourceImpl) DeleteByUserID(ctx context.Context, userID uuid
 	return infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("user_id = ?", userID).Delete(&SessionModel{}).Error
 }
 
+// DeleteByUserIDExcept は指定セッション以外のユーザーの全セッションを削除
+func (ds *SessionDataSourceImpl) DeleteByUserIDExcept(ctx context.Context, userID, exceptID uuid.UUID) (int64, error) {
+	result := infrapostgres.GetDB(ctx, ds.db.GetDB()).
+		Where("user_id = ? AND id <> ?", userID, exceptID).
+		Delete(&SessionModel{})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteAllExcept は指定セッション以外の全ユーザーのセッションを削除（テナントのあるcontextではそのテナントのみ）
+func (ds *SessionDataSourceImpl) DeleteAllExcept(ctx context.Context, exceptID uuid.UUID) (int64, error) {
+	result := infrapostgres.GetDB(ctx, ds.db.GetDB()).
+		Where("id <> ?", exceptID).
+		Delete(&SessionModel{})
+	return result.RowsAffected, result.Error
+}
+
 // DeleteExpired は期限切れセッションを削除
 func (ds *SessionDataSourceImpl) DeleteExpired(ctx context.Context) error {
 	return infrapostgres.GetDB(ctx, ds.db.GetDB()).
 		Where("expires_at < ?", time.Now()).
 		Delete(&SessionModel{}).Error
 }
+
+// SessionBindingViolationModel はGORM用のセッションの固定の違反モデル
+type SessionBindingViolationModel struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null"`
+	SessionID uuid.UUID `gorm:"type:uuid;not null"`
+	Change    string    `gorm:"column:fingerprint_change;type:varchar(30);not null"`
+	Rejected  bool      `gorm:"not null;default:false"`
+	IPAddress string    `gorm:"type:varchar(100)"`
+	UserAgent string    `gorm:"type:text"`
+	CreatedAt time.Time `gorm:"not null;default:now()"`
+}
+
+// TableName はテーブル名を指定
+func (SessionBindingViolationModel) TableName() string {
+	return "session_binding_violations"
+}
+
+// ToDomain はドメインモデルに変換
+func (m *SessionBindingViolationModel) ToDomain() *entities.SessionBindingViolation {
+	return &entities.SessionBindingViolation{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		SessionID: m.SessionID,
+		Change:    entities.SessionFingerprintChange(m.Change),
+		Rejected:  m.Rejected,
+		IPAddress: m.IPAddress,
+		UserAgent: m.UserAgent,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// FromDomain はドメインモデルから変換
+func (m *SessionBindingViolationModel) FromDomain(v *entities.SessionBindingViolation) {
+	m.ID = v.ID
+	m.UserID = v.UserID
+	m.SessionID = v.SessionID
+	m.Change = string(v.Change)
+	m.Rejected = v.Rejected
+	m.IPAddress = v.IPAddress
+	m.UserAgent = v.UserAgent
+	m.CreatedAt = v.CreatedAt
+}
+
+// InsertBindingViolation はセッションの固定の違反を挿入
+func (ds *SessionDataSourceImpl) InsertBindingViolation(ctx context.Context, violation *entities.SessionBindingViolation) error {
+	model := &SessionBindingViolationModel{}
+	model.FromDomain(violation)
+	return infrapostgres.GetDB(ctx, ds.db.GetDB()).Create(model).Error
+}
+
+// SelectBindingViolationsByUserID はユーザーのセッションの固定の違反を新しい順に取得
+func (ds *SessionDataSourceImpl) SelectBindingViolationsByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.SessionBindingViolation, error) {
+	var models []SessionBindingViolationModel
+	err := infrapostgres.GetReadDB(ctx, ds.db).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	violations := make([]*entities.SessionBindingViolation, len(models))
+	for i, model := range models {
+		violations[i] = model.ToDomain()
+	}
+	return violations, nil
+}
+
+// CountBindingViolationsByUserID はユーザーのセッションの固定の違反の件数を取得
+func (ds *SessionDataSourceImpl) CountBindingViolationsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := infrapostgres.GetReadDB(ctx, ds.db).Model(&SessionBindingViolationModel{}).
+		Where("user_id = ?", userID).
+		Count(&count).Error
+	return count, err
+}
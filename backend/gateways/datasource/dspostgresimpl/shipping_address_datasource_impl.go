@@ -0,0 +1,184 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ShippingAddressModel はお届け先のGORMモデル
+type ShippingAddressModel struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null;index"`
+	Label         string    `gorm:"type:varchar(100);not null;default:''"`
+	RecipientName string    `gorm:"type:varchar(100);not null"`
+	PostalCode    string    `gorm:"type:varchar(8);not null"`
+	Prefecture    string    `gorm:"type:varchar(10);not null"`
+	City          string    `gorm:"type:varchar(100);not null"`
+	Line1         string    `gorm:"type:varchar(100);not null"`
+	Line2         string    `gorm:"type:varchar(100);not null;default:''"`
+	Phone         string    `gorm:"type:varchar(20);not null"`
+	IsDefault     bool      `gorm:"not null;default:false"`
+	CreatedAt     time.Time `gorm:"type:timestamptz;not null"`
+	UpdatedAt     time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (ShippingAddressModel) TableName() string {
+	return "shipping_addresses"
+}
+
+// ShippingAddressDataSource はお届け先のデータソース
+type ShippingAddressDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewShippingAddressDataSource は新しいShippingAddressDataSourceを作成
+func NewShippingAddressDataSource(db infrapostgres.DB) *ShippingAddressDataSource {
+	return &ShippingAddressDataSource{db: db}
+}
+
+func (ds *ShippingAddressDataSource) toEntity(m *ShippingAddressModel) *entities.ShippingAddress {
+	return &entities.ShippingAddress{
+		ID:            m.ID,
+		UserID:        m.UserID,
+		Label:         m.Label,
+		RecipientName: m.RecipientName,
+		PostalCode:    m.PostalCode,
+		Prefecture:    m.Prefecture,
+		City:          m.City,
+		Line1:         m.Line1,
+		Line2:         m.Line2,
+		Phone:         m.Phone,
+		IsDefault:     m.IsDefault,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+func (ds *ShippingAddressDataSource) toModel(a *entities.ShippingAddress) *ShippingAddressModel {
+	return &ShippingAddressModel{
+		ID:            a.ID,
+		UserID:        a.UserID,
+		Label:         a.Label,
+		RecipientName: a.RecipientName,
+		PostalCode:    a.PostalCode,
+		Prefecture:    a.Prefecture,
+		City:          a.City,
+		Line1:         a.Line1,
+		Line2:         a.Line2,
+		Phone:         a.Phone,
+		IsDefault:     a.IsDefault,
+		CreatedAt:     a.CreatedAt,
+		UpdatedAt:     a.UpdatedAt,
+	}
+}
+
+// Insert はお届け先を挿入
+func (ds *ShippingAddressDataSource) Insert(ctx context.Context, address *entities.ShippingAddress) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Create(ds.toModel(address)).Error
+}
+
+// Select はIDでお届け先を取得
+func (ds *ShippingAddressDataSource) Select(ctx context.Context, id uuid.UUID) (*entities.ShippingAddress, error) {
+	return ds.first(ctx, "id = ?", id)
+}
+
+// SelectDefault はユーザーの既定のお届け先を取得
+func (ds *ShippingAddressDataSource) SelectDefault(ctx context.Context, userID uuid.UUID) (*entities.ShippingAddress, error) {
+	return ds.first(ctx, "user_id = ? AND is_default", userID)
+}
+
+func (ds *ShippingAddressDataSource) first(ctx context.Context, query string, args ...interface{}) (*entities.ShippingAddress, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m ShippingAddressModel
+	if err := db.Where(query, args...).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrShippingAddressNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// SelectListByUserID はユーザーのお届け先を既定のもの、登録順の順に取得
+func (ds *ShippingAddressDataSource) SelectListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.ShippingAddress, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var models []ShippingAddressModel
+	if err := db.Where("user_id = ?", userID).
+		Order("is_default DESC, created_at ASC, id ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	addresses := make([]*entities.ShippingAddress, len(models))
+	for i := range models {
+		addresses[i] = ds.toEntity(&models[i])
+	}
+	return addresses, nil
+}
+
+// Update はお届け先を更新（既定かどうかはSetDefaultで切り替える）
+func (ds *ShippingAddressDataSource) Update(ctx context.Context, address *entities.ShippingAddress) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	m := ds.toModel(address)
+	result := db.Model(&ShippingAddressModel{}).Where("id = ?", address.ID).Updates(map[string]interface{}{
+		"label":          m.Label,
+		"recipient_name": m.RecipientName,
+		"postal_code":    m.PostalCode,
+		"prefecture":     m.Prefecture,
+		"city":           m.City,
+		"line1":          m.Line1,
+		"line2":          m.Line2,
+		"phone":          m.Phone,
+		"updated_at":     m.UpdatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrShippingAddressNotFound
+	}
+	return nil
+}
+
+// UpdateDefault はユーザーの既定のお届け先をidだけにする
+func (ds *ShippingAddressDataSource) UpdateDefault(ctx context.Context, userID, id uuid.UUID) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Transaction(func(tx *gorm.DB) error {
+		// 既定は部分一意インデックスで1件に制限しているため、先に外してから付け替える
+		if err := tx.Model(&ShippingAddressModel{}).
+			Where("user_id = ? AND is_default AND id <> ?", userID, id).
+			Update("is_default", false).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&ShippingAddressModel{}).
+			Where("id = ? AND user_id = ?", id, userID).
+			Update("is_default", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return entities.ErrShippingAddressNotFound
+		}
+		return nil
+	})
+}
+
+// Delete はお届け先を削除
+func (ds *ShippingAddressDataSource) Delete(ctx context.Context, id uuid.UUID) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Where("id = ?", id).Delete(&ShippingAddressModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrShippingAddressNotFound
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SplitRequestModel は割り勘のGORMモデル（各自の金額・支払い状況はtransfer_requestsに持つ）
+type SplitRequestModel struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primary_key"`
+	RequesterID      uuid.UUID  `gorm:"type:uuid;not null;index"`
+	TotalAmount      int64      `gorm:"not null"`
+	SplitType        string     `gorm:"type:varchar(20);not null"`
+	IncludeRequester bool       `gorm:"not null;default:false"`
+	Message          string     `gorm:"type:text"`
+	IdempotencyKey   string     `gorm:"type:varchar(255);not null;uniqueIndex"`
+	CancelledAt      *time.Time `gorm:"type:timestamptz"`
+	CreatedAt        time.Time  `gorm:"type:timestamptz;not null"`
+	UpdatedAt        time.Time  `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (SplitRequestModel) TableName() string {
+	return "split_requests"
+}
+
+func (m *SplitRequestModel) toEntity() *entities.SplitRequest {
+	return &entities.SplitRequest{
+		ID:               m.ID,
+		RequesterID:      m.RequesterID,
+		TotalAmount:      m.TotalAmount,
+		SplitType:        entities.SplitType(m.SplitType),
+		IncludeRequester: m.IncludeRequester,
+		Message:          m.Message,
+		IdempotencyKey:   m.IdempotencyKey,
+		CancelledAt:      m.CancelledAt,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+// SplitRequestDataSource は割り勘のデータソース
+type SplitRequestDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewSplitRequestDataSource は新しいSplitRequestDataSourceを作成
+func NewSplitRequestDataSource(db infrapostgres.DB) *SplitRequestDataSource {
+	return &SplitRequestDataSource{db: db}
+}
+
+// Insert は割り勘を挿入
+func (ds *SplitRequestDataSource) Insert(ctx context.Context, split *entities.SplitRequest) error {
+	return infrapostgres.GetDB(ctx, ds.db.GetDB()).Create(&SplitRequestModel{
+		ID:               split.ID,
+		RequesterID:      split.RequesterID,
+		TotalAmount:      split.TotalAmount,
+		SplitType:        string(split.SplitType),
+		IncludeRequester: split.IncludeRequester,
+		Message:          split.Message,
+		IdempotencyKey:   split.IdempotencyKey,
+		CancelledAt:      split.CancelledAt,
+		CreatedAt:        split.CreatedAt,
+		UpdatedAt:        split.UpdatedAt,
+	}).Error
+}
+
+// Select はIDで割り勘を取得
+func (ds *SplitRequestDataSource) Select(ctx context.Context, id uuid.UUID) (*entities.SplitRequest, error) {
+	var m SplitRequestModel
+	if err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("id = ?", id).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrSplitRequestNotFound
+		}
+		return nil, err
+	}
+	return m.toEntity(), nil
+}
+
+// SelectByIdempotencyKey は冪等性キーで割り勘を取得（存在しない場合はnil）
+func (ds *SplitRequestDataSource) SelectByIdempotencyKey(ctx context.Context, key string) (*entities.SplitRequest, error) {
+	var m SplitRequestModel
+	if err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("idempotency_key = ?", key).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return m.toEntity(), nil
+}
+
+// SelectListByRequester は作成者の割り勘を新しい順に取得
+func (ds *SplitRequestDataSource) SelectListByRequester(ctx context.Context, requesterID uuid.UUID, offset, limit int) ([]*entities.SplitRequest, error) {
+	var models []SplitRequestModel
+	err := infrapostgres.GetReadDB(ctx, ds.db).
+		Where("requester_id = ?", requesterID).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*entities.SplitRequest, len(models))
+	for i := range models {
+		list[i] = models[i].toEntity()
+	}
+	return list, nil
+}
+
+// Update は割り勘のキャンセル日時を更新
+func (ds *SplitRequestDataSource) Update(ctx context.Context, split *entities.SplitRequest) error {
+	result := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&SplitRequestModel{}).
+		Where("id = ?", split.ID).
+		Updates(map[string]interface{}{
+			"cancelled_at": split.CancelledAt,
+			"updated_at":   split.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrSplitRequestNotFound
+	}
+	return nil
+}
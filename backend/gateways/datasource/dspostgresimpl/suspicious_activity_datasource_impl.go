@@ -0,0 +1,206 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SuspiciousActivityModel は不審な送金の記録のGORMモデル
+type SuspiciousActivityModel struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key"`
+	FromUserID     uuid.UUID  `gorm:"type:uuid;not null"`
+	ToUserID       uuid.UUID  `gorm:"type:uuid;not null"`
+	Amount         int64      `gorm:"not null"`
+	Description    string     `gorm:"type:text;not null;default:''"`
+	IdempotencyKey string     `gorm:"type:varchar(255);not null"`
+	Reasons        string     `gorm:"type:text;not null"` // カンマ区切り
+	Status         string     `gorm:"type:varchar(10);not null"`
+	TransactionID  *uuid.UUID `gorm:"type:uuid"`
+	ReviewedBy     *uuid.UUID `gorm:"type:uuid"`
+	ReviewComment  string     `gorm:"type:text;not null;default:''"`
+	ReviewedAt     *time.Time `gorm:"type:timestamptz"`
+	FailureReason  string     `gorm:"type:text;not null;default:''"`
+	CreatedAt      time.Time  `gorm:"type:timestamptz;not null"`
+	UpdatedAt      time.Time  `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (SuspiciousActivityModel) TableName() string {
+	return "suspicious_activities"
+}
+
+// SuspiciousActivityDataSource は不審な送金の記録のデータソース
+type SuspiciousActivityDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewSuspiciousActivityDataSource は新しいSuspiciousActivityDataSourceを作成
+func NewSuspiciousActivityDataSource(db infrapostgres.DB) *SuspiciousActivityDataSource {
+	return &SuspiciousActivityDataSource{db: db}
+}
+
+func (ds *SuspiciousActivityDataSource) toEntity(m *SuspiciousActivityModel) *entities.SuspiciousActivity {
+	var reasons []entities.SuspiciousActivityReason
+	if m.Reasons != "" {
+		for _, r := range strings.Split(m.Reasons, ",") {
+			reasons = append(reasons, entities.SuspiciousActivityReason(r))
+		}
+	}
+	return &entities.SuspiciousActivity{
+		ID:             m.ID,
+		FromUserID:     m.FromUserID,
+		ToUserID:       m.ToUserID,
+		Amount:         m.Amount,
+		Description:    m.Description,
+		IdempotencyKey: m.IdempotencyKey,
+		Reasons:        reasons,
+		Status:         entities.SuspiciousActivityStatus(m.Status),
+		TransactionID:  m.TransactionID,
+		ReviewedBy:     m.ReviewedBy,
+		ReviewComment:  m.ReviewComment,
+		ReviewedAt:     m.ReviewedAt,
+		FailureReason:  m.FailureReason,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+// Insert は不審な送金の記録を挿入
+func (ds *SuspiciousActivityDataSource) Insert(ctx context.Context, a *entities.SuspiciousActivity) error {
+	reasons := make([]string, len(a.Reasons))
+	for i, r := range a.Reasons {
+		reasons[i] = string(r)
+	}
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Create(&SuspiciousActivityModel{
+		ID:             a.ID,
+		FromUserID:     a.FromUserID,
+		ToUserID:       a.ToUserID,
+		Amount:         a.Amount,
+		Description:    a.Description,
+		IdempotencyKey: a.IdempotencyKey,
+		Reasons:        strings.Join(reasons, ","),
+		Status:         string(a.Status),
+		TransactionID:  a.TransactionID,
+		ReviewedBy:     a.ReviewedBy,
+		ReviewComment:  a.ReviewComment,
+		ReviewedAt:     a.ReviewedAt,
+		FailureReason:  a.FailureReason,
+		CreatedAt:      a.CreatedAt,
+		UpdatedAt:      a.UpdatedAt,
+	}).Error
+}
+
+// Select はIDで記録を取得
+func (ds *SuspiciousActivityDataSource) Select(ctx context.Context, id uuid.UUID) (*entities.SuspiciousActivity, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var m SuspiciousActivityModel
+	if err := db.Where("id = ?", id).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrSuspiciousActivityNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// SelectList は記録を新しい順に取得（statusが空なら全状態）
+func (ds *SuspiciousActivityDataSource) SelectList(ctx context.Context, status entities.SuspiciousActivityStatus, offset, limit int) ([]*entities.SuspiciousActivity, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	query := db.Model(&SuspiciousActivityModel{})
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+	var models []SuspiciousActivityModel
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	activities := make([]*entities.SuspiciousActivity, len(models))
+	for i := range models {
+		activities[i] = ds.toEntity(&models[i])
+	}
+	return activities, nil
+}
+
+// Count は記録の件数を取得（statusが空なら全状態）
+func (ds *SuspiciousActivityDataSource) Count(ctx context.Context, status entities.SuspiciousActivityStatus) (int64, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	query := db.Model(&SuspiciousActivityModel{})
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// UpdateStatus は状態がfromのときだけ状態と確認・実行の結果を更新（更新できたかを返す）
+func (ds *SuspiciousActivityDataSource) UpdateStatus(ctx context.Context, a *entities.SuspiciousActivity, from entities.SuspiciousActivityStatus) (bool, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&SuspiciousActivityModel{}).
+		Where("id = ? AND status = ?", a.ID, string(from)).
+		Updates(map[string]interface{}{
+			"status":         string(a.Status),
+			"transaction_id": a.TransactionID,
+			"reviewed_by":    a.ReviewedBy,
+			"review_comment": a.ReviewComment,
+			"reviewed_at":    a.ReviewedAt,
+			"failure_reason": a.FailureReason,
+			"updated_at":     a.UpdatedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SelectTransferVelocity は送信者の直近の完了済み取引を検出条件に沿って集計
+func (ds *SuspiciousActivityDataSource) SelectTransferVelocity(ctx context.Context, q *entities.TransferVelocityQuery) (*entities.TransferVelocity, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var v entities.TransferVelocity
+	err := db.Raw(`
+		SELECT
+			(SELECT COUNT(*) FROM transactions t
+				JOIN users u ON u.id = t.to_user_id
+				WHERE t.from_user_id = ? AND t.transaction_type = ? AND t.status = ?
+					AND t.created_at >= ? AND u.created_at >= ?) AS new_account_transfers,
+			(SELECT COUNT(*) FROM transactions t
+				WHERE t.transaction_type = ? AND t.status = ? AND t.created_at >= ?
+					AND ((t.from_user_id = ? AND t.to_user_id = ?) OR (t.from_user_id = ? AND t.to_user_id = ?))) AS pair_transfers,
+			(SELECT COUNT(*) FROM transactions t
+				WHERE t.transaction_type = ? AND t.status = ? AND t.created_at >= ?
+					AND t.from_user_id = ? AND t.to_user_id = ?) AS reverse_transfers,
+			(SELECT COALESCE(SUM(t.amount), 0) FROM transactions t
+				WHERE t.to_user_id = ? AND t.transaction_type IN ? AND t.status = ?
+					AND t.created_at >= ?) AS recent_grant_amount`,
+		q.FromUserID, entities.TransactionTypeTransfer, entities.TransactionStatusCompleted, q.NewAccountWindow, q.NewAccountSince,
+		entities.TransactionTypeTransfer, entities.TransactionStatusCompleted, q.WashWindow,
+		q.FromUserID, q.ToUserID, q.ToUserID, q.FromUserID,
+		entities.TransactionTypeTransfer, entities.TransactionStatusCompleted, q.WashWindow,
+		q.ToUserID, q.FromUserID,
+		q.FromUserID, entities.SuspiciousGrantTransactionTypes, entities.TransactionStatusCompleted, q.GrantWindow).
+		Scan(&v).Error
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// SelectAlertRecipients は有効な管理者のIDを取得
+func (ds *SuspiciousActivityDataSource) SelectAlertRecipients(ctx context.Context) ([]uuid.UUID, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var ids []uuid.UUID
+	err := db.Model(&UserModel{}).
+		Where("role IN ? AND is_active = ?", []string{string(entities.RoleAdmin), string(entities.RoleSuperAdmin)}, true).
+		Pluck("id", &ids).Error
+	return ids, err
+}
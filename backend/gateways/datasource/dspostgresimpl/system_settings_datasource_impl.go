@@ -4,13 +4,16 @@ import (
 	"context"
 	"time"
 
+	"github.com/gity/point-system/entities"
 	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 // SystemSettingModel はシステム設定のGORMモデル
 type SystemSettingModel struct {
+	TenantID    uuid.UUID `gorm:"type:uuid;primary_key;default:'00000000-0000-0000-0000-000000000001'"`
 	Key         string    `gorm:"type:varchar(100);primary_key"`
 	Value       string    `gorm:"type:text;not null"`
 	Description *string   `gorm:"type:text"`
@@ -32,11 +35,20 @@ func NewSystemSettingsDataSource(db infrapostgres.DB) *SystemSettingsDataSource
 	return &SystemSettingsDataSource{db: db}
 }
 
+// settingsTenant は設定を読み書きするテナント
+// テナントのないcontext（ワーカーなど）ではデプロイメント全体の設定として既定のテナントの値を使う
+func settingsTenant(ctx context.Context) uuid.UUID {
+	if tenantID, ok := entities.TenantIDFromContext(ctx); ok {
+		return tenantID
+	}
+	return entities.DefaultTenantID
+}
+
 // GetSetting はキーに対応する設定値を取得
 func (ds *SystemSettingsDataSource) GetSetting(ctx context.Context, key string) (string, error) {
 	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
 	var model SystemSettingModel
-	err := db.Where("key = ?", key).First(&model).Error
+	err := db.Where("tenant_id = ? AND key = ?", settingsTenant(ctx), key).First(&model).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return "", nil
@@ -50,6 +62,7 @@ func (ds *SystemSettingsDataSource) GetSetting(ctx context.Context, key string)
 func (ds *SystemSettingsDataSource) SetSetting(ctx context.Context, key, value, description string) error {
 	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
 	model := SystemSettingModel{
+		TenantID:  settingsTenant(ctx),
 		Key:       key,
 		Value:     value,
 		UpdatedAt: time.Now(),
@@ -59,7 +72,7 @@ func (ds *SystemSettingsDataSource) SetSetting(ctx context.Context, key, value,
 	}
 
 	return db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "key"}},
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "key"}},
 		DoUpdates: clause.AssignmentColumns([]string{"value", "description", "updated_at"}),
 	}).Create(&model).Error
 }
@@ -0,0 +1,140 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TenantModel はテナントのGORMモデル
+type TenantModel struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key"`
+	Slug      string    `gorm:"type:varchar(40);not null;uniqueIndex"`
+	Name      string    `gorm:"type:varchar(100);not null"`
+	IsActive  bool      `gorm:"not null;default:true"`
+	CreatedAt time.Time `gorm:"type:timestamptz;not null"`
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (TenantModel) TableName() string {
+	return "tenants"
+}
+
+// tenantOfUser はユーザーの所属テナントを取得する（見つからなければ既定のテナント）
+// テナントのないcontextで作成する行を、関係するユーザーと同じテナントに入れるために使う
+func tenantOfUser(tx *gorm.DB, userID uuid.UUID) uuid.UUID {
+	var tenantIDs []uuid.UUID
+	tx.Session(&gorm.Session{NewDB: true}).Model(&UserModel{}).
+		Where("id = ?", userID.String()).Pluck("tenant_id", &tenantIDs)
+	if len(tenantIDs) == 0 {
+		return entities.DefaultTenantID
+	}
+	return tenantIDs[0]
+}
+
+// TenantDataSource はテナントのデータソース
+type TenantDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewTenantDataSource は新しいTenantDataSourceを作成
+func NewTenantDataSource(db infrapostgres.DB) *TenantDataSource {
+	return &TenantDataSource{db: db}
+}
+
+func (ds *TenantDataSource) toEntity(m *TenantModel) *entities.Tenant {
+	return &entities.Tenant{
+		ID:        m.ID,
+		Slug:      m.Slug,
+		Name:      m.Name,
+		IsActive:  m.IsActive,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// Insert はテナントを挿入（スラッグが重複すればErrTenantSlugTaken）
+func (ds *TenantDataSource) Insert(ctx context.Context, tenant *entities.Tenant) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var count int64
+	if err := db.Model(&TenantModel{}).Where("slug = ?", tenant.Slug).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return entities.ErrTenantSlugTaken
+	}
+	return db.Create(&TenantModel{
+		ID:        tenant.ID,
+		Slug:      tenant.Slug,
+		Name:      tenant.Name,
+		IsActive:  tenant.IsActive,
+		CreatedAt: tenant.CreatedAt,
+		UpdatedAt: tenant.UpdatedAt,
+	}).Error
+}
+
+// Select はIDでテナントを取得
+func (ds *TenantDataSource) Select(ctx context.Context, id uuid.UUID) (*entities.Tenant, error) {
+	return ds.selectOne(ctx, "id = ?", id)
+}
+
+// SelectBySlug はスラッグでテナントを取得
+func (ds *TenantDataSource) SelectBySlug(ctx context.Context, slug string) (*entities.Tenant, error) {
+	return ds.selectOne(ctx, "slug = ?", slug)
+}
+
+func (ds *TenantDataSource) selectOne(ctx context.Context, query string, arg interface{}) (*entities.Tenant, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var m TenantModel
+	if err := db.Where(query, arg).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entities.ErrTenantNotFound
+		}
+		return nil, err
+	}
+	return ds.toEntity(&m), nil
+}
+
+// SelectList はテナントを作成日時の古い順に取得
+func (ds *TenantDataSource) SelectList(ctx context.Context, offset, limit int) ([]*entities.Tenant, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var models []TenantModel
+	if err := db.Order("created_at ASC").Offset(offset).Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	tenants := make([]*entities.Tenant, len(models))
+	for i := range models {
+		tenants[i] = ds.toEntity(&models[i])
+	}
+	return tenants, nil
+}
+
+// Count はテナントの件数を取得
+func (ds *TenantDataSource) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := infrapostgres.GetReadDB(ctx, ds.db).Model(&TenantModel{}).Count(&count).Error
+	return count, err
+}
+
+// Update はテナント名と有効・無効を更新
+func (ds *TenantDataSource) Update(ctx context.Context, tenant *entities.Tenant) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Model(&TenantModel{}).Where("id = ?", tenant.ID).Updates(map[string]interface{}{
+		"name":       tenant.Name,
+		"is_active":  tenant.IsActive,
+		"updated_at": tenant.UpdatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrTenantNotFound
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package dspostgresimpl
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"gorm.io/gorm"
+)
+
+// テナントの絞り込み
+// Raw・Table（モデルを指定しないクエリ）はテナントの自動の絞り込み（infrapostgres.RegisterTenantScope）の対象外のため、
+// テナントごとのテーブル（users, transactions, products など）を参照する集計・一覧ではここの条件を付ける
+// テナントのないcontext（ワーカーなど）では何も絞り込まず、すべてのテナントを対象にする
+
+// tenantUsersSQL はテナントの所属ユーザーのIDを返すサブクエリ
+const tenantUsersSQL = "SELECT id FROM users WHERE tenant_id = ?"
+
+// tenantCondition はcolumn（テナントIDの列）をテナントに絞り込む条件（" AND ..."）と引数を返す
+func tenantCondition(ctx context.Context, column string) (string, []interface{}) {
+	tenantID, ok := entities.TenantIDFromContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	return " AND " + column + " = ?", []interface{}{tenantID}
+}
+
+// tenantUserCondition はテナントIDの列を持たないテーブルを、column（ユーザーIDの列）で所属ユーザーのテナントに絞り込む条件と引数を返す
+func tenantUserCondition(ctx context.Context, column string) (string, []interface{}) {
+	tenantID, ok := entities.TenantIDFromContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	return " AND " + column + " IN (" + tenantUsersSQL + ")", []interface{}{tenantID}
+}
+
+// namedTenantCondition は名前付きの引数（@name）で書いたクエリ向けのtenantCondition（テナントIDはargsの"tenant_id"に入れる）
+func namedTenantCondition(ctx context.Context, column string, args map[string]interface{}) string {
+	tenantID, ok := entities.TenantIDFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	args["tenant_id"] = tenantID
+	return " AND " + column + " = @tenant_id"
+}
+
+// tenantScope はTableで書いたクエリをcolumn（テナントIDの列）でテナントに絞り込むスコープ
+func tenantScope(ctx context.Context, column string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if tenantID, ok := entities.TenantIDFromContext(ctx); ok {
+			return db.Where(column+" = ?", tenantID)
+		}
+		return db
+	}
+}
+
+// tenantUserScope はTableで書いたクエリをcolumn（ユーザーIDの列）で所属ユーザーのテナントに絞り込むスコープ
+func tenantUserScope(ctx context.Context, column string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if tenantID, ok := entities.TenantIDFromContext(ctx); ok {
+			return db.Where(column+" IN ("+tenantUsersSQL+")", tenantID)
+		}
+		return db
+	}
+}
@@ -0,0 +1,187 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ArchivedTransactionModel は保持期間を過ぎて移した取引のGORMモデル（列はtransactionsと同じ）
+type ArchivedTransactionModel struct {
+	TransactionModel `gorm:"embedded"`
+	ArchivedAt       time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (ArchivedTransactionModel) TableName() string {
+	return "archived_transactions"
+}
+
+// TransactionArchiveSummaryModel は保管済みの取引の月・種別・状態ごとの集計のGORMモデル
+type TransactionArchiveSummaryModel struct {
+	TenantID         uuid.UUID `gorm:"type:uuid;primary_key;default:'00000000-0000-0000-0000-000000000001'"`
+	Month            string    `gorm:"type:varchar(7);primary_key"`
+	TransactionType  string    `gorm:"type:varchar(50);primary_key"`
+	Status           string    `gorm:"type:varchar(50);primary_key"`
+	TransactionCount int64     `gorm:"not null;default:0"`
+	TotalAmount      int64     `gorm:"not null;default:0"`
+	UpdatedAt        time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (TransactionArchiveSummaryModel) TableName() string {
+	return "transaction_archive_summaries"
+}
+
+// ledgerTransactions は残高の照合・ポイントの保存の確認で積み上げる取引（保管済みの取引を含む）
+const ledgerTransactions = `(
+	SELECT tenant_id, from_user_id, to_user_id, from_system_account, to_system_account, amount, transaction_type, status, created_at FROM transactions
+	UNION ALL
+	SELECT tenant_id, from_user_id, to_user_id, from_system_account, to_system_account, amount, transaction_type, status, created_at FROM archived_transactions
+)`
+
+// TransactionArchiveDataSource は取引の保管（移動・検索・集計）のデータソース
+type TransactionArchiveDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewTransactionArchiveDataSource は新しいTransactionArchiveDataSourceを作成
+func NewTransactionArchiveDataSource(db infrapostgres.DB) *TransactionArchiveDataSource {
+	return &TransactionArchiveDataSource{db: db}
+}
+
+// MoveBefore はcutoffより前に作成された確定済み（処理中以外）の取引を古い順にlimit件、保管用のテーブルへ移す
+// 移した分は月・種別・状態ごとの集計に加算する。トランザクション内で呼ぶ
+func (ds *TransactionArchiveDataSource) MoveBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+
+	var models []TransactionModel
+	err := db.Where("created_at < ? AND status <> ?", cutoff, entities.TransactionStatusPending).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil || len(models) == 0 {
+		return 0, err
+	}
+
+	now := time.Now()
+	archived := make([]ArchivedTransactionModel, len(models))
+	ids := make([]uuid.UUID, len(models))
+	type summaryKey struct {
+		tenantID uuid.UUID
+		month    string
+		txType   string
+		status   string
+	}
+	summaries := make(map[summaryKey]*TransactionArchiveSummaryModel)
+	var keys []summaryKey
+	for i, m := range models {
+		archived[i] = ArchivedTransactionModel{TransactionModel: m, ArchivedAt: now}
+		ids[i] = m.ID
+
+		key := summaryKey{m.TenantID, entities.TransactionArchiveMonth(m.CreatedAt), m.TransactionType, m.Status}
+		s, ok := summaries[key]
+		if !ok {
+			s = &TransactionArchiveSummaryModel{
+				TenantID:        key.tenantID,
+				Month:           key.month,
+				TransactionType: key.txType,
+				Status:          key.status,
+				UpdatedAt:       now,
+			}
+			summaries[key] = s
+			keys = append(keys, key)
+		}
+		s.TransactionCount++
+		s.TotalAmount += m.Amount
+	}
+
+	if err := db.CreateInBatches(archived, 100).Error; err != nil {
+		return 0, err
+	}
+	for _, key := range keys {
+		err := db.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "tenant_id"}, {Name: "month"}, {Name: "transaction_type"}, {Name: "status"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"transaction_count": gorm.Expr("transaction_archive_summaries.transaction_count + excluded.transaction_count"),
+				"total_amount":      gorm.Expr("transaction_archive_summaries.total_amount + excluded.total_amount"),
+				"updated_at":        now,
+			}),
+		}).Create(summaries[key]).Error
+		if err != nil {
+			return 0, err
+		}
+	}
+	if err := db.Where("id IN ?", ids).Delete(&TransactionModel{}).Error; err != nil {
+		return 0, err
+	}
+	return int64(len(models)), nil
+}
+
+// filtered は保管済みの取引の検索条件を付けたクエリ
+func (ds *TransactionArchiveDataSource) filtered(ctx context.Context, filter *entities.ArchivedTransactionFilter) *gorm.DB {
+	query := infrapostgres.GetReadDB(ctx, ds.db).Model(&ArchivedTransactionModel{}).
+		Where("created_at >= ? AND created_at < ?", filter.From, filter.To)
+	if filter.UserID != nil {
+		query = query.Where("(from_user_id = ? OR to_user_id = ?)", *filter.UserID, *filter.UserID)
+	}
+	return query
+}
+
+// SelectList は保管済みの取引を作成日時の新しい順に取得
+func (ds *TransactionArchiveDataSource) SelectList(ctx context.Context, filter *entities.ArchivedTransactionFilter, offset, limit int) ([]*entities.ArchivedTransaction, error) {
+	var models []ArchivedTransactionModel
+	err := ds.filtered(ctx, filter).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*entities.ArchivedTransaction, len(models))
+	for i := range models {
+		list[i] = &entities.ArchivedTransaction{Transaction: *models[i].ToDomain(), ArchivedAt: models[i].ArchivedAt}
+	}
+	return list, nil
+}
+
+// Count は検索条件に一致する保管済みの取引の件数を取得
+func (ds *TransactionArchiveDataSource) Count(ctx context.Context, filter *entities.ArchivedTransactionFilter) (int64, error) {
+	var count int64
+	err := ds.filtered(ctx, filter).Count(&count).Error
+	return count, err
+}
+
+// SelectSummaries は年月がfromMonthからtoMonthまで（空なら制限なし）の集計を年月・種別・状態の順に取得
+func (ds *TransactionArchiveDataSource) SelectSummaries(ctx context.Context, fromMonth, toMonth string) ([]*entities.TransactionArchiveSummary, error) {
+	query := infrapostgres.GetReadDB(ctx, ds.db)
+	if fromMonth != "" {
+		query = query.Where("month >= ?", fromMonth)
+	}
+	if toMonth != "" {
+		query = query.Where("month <= ?", toMonth)
+	}
+	var models []TransactionArchiveSummaryModel
+	err := query.Order("month ASC, transaction_type ASC, status ASC").Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*entities.TransactionArchiveSummary, len(models))
+	for i, m := range models {
+		list[i] = &entities.TransactionArchiveSummary{
+			Month:            m.Month,
+			TransactionType:  entities.TransactionType(m.TransactionType),
+			Status:           entities.TransactionStatus(m.Status),
+			TransactionCount: m.TransactionCount,
+			TotalAmount:      m.TotalAmount,
+			UpdatedAt:        m.UpdatedAt,
+		}
+	}
+	return list, nil
+}
@@ -22,27 +22,35 @@ func (j JSONB) Value() (driver.Value, error) {
 	return json.Marshal(j)
 }
 
-// Scan は DB から読み込む際の変換
+// Scan は DB から読み込む際の変換（SQLiteではテキストとして返る）
 func (j *JSONB) Scan(value interface{}) error {
-	bytes, ok := value.([]byte)
-	if !ok {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, &j)
+	case string:
+		return json.Unmarshal([]byte(v), &j)
+	default:
 		return errors.New("type assertion to []byte failed")
 	}
-	return json.Unmarshal(bytes, &j)
 }
 
 // TransactionModel はGORM用のトランザクションモデル
 type TransactionModel struct {
 	ID              uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	FromUserID      *uuid.UUID `gorm:"type:uuid;index"`
-	ToUserID        *uuid.UUID `gorm:"type:uuid;index"`
+	TenantID        uuid.UUID  `gorm:"type:uuid;not null;index;default:'00000000-0000-0000-0000-000000000001'"`
+	FromUserID      *uuid.UUID `gorm:"type:uuid;index:,composite:from_user_created,priority:1"`
+	ToUserID        *uuid.UUID `gorm:"type:uuid;index:,composite:to_user_created,priority:1"`
+	FromAccount     *string    `gorm:"column:from_system_account;type:varchar(32)"`
+	ToAccount       *string    `gorm:"column:to_system_account;type:varchar(32)"`
 	Amount          int64      `gorm:"not null"`
-	TransactionType string     `gorm:"type:varchar(50);not null;index"`
+	TransactionType string     `gorm:"type:varchar(50);not null;index:,composite:type_created,priority:1"`
 	Status          string     `gorm:"type:varchar(50);not null;index"`
 	IdempotencyKey  *string    `gorm:"type:varchar(255);uniqueIndex"`
 	Description     string     `gorm:"type:text"`
+	ReasonCode      string     `gorm:"type:varchar(32);not null;default:''"`
+	Tag             string     `gorm:"type:varchar(50);not null;default:''"`
 	Metadata        JSONB      `gorm:"type:jsonb"`
-	CreatedAt       time.Time  `gorm:"not null;default:now();index"`
+	CreatedAt       time.Time  `gorm:"not null;default:now();index;index:,composite:from_user_created,priority:2,sort:desc;index:,composite:to_user_created,priority:2,sort:desc;index:,composite:type_created,priority:2,sort:desc"`
 	CompletedAt     *time.Time
 }
 
@@ -51,17 +59,35 @@ func (TransactionModel) TableName() string {
 	return "transactions"
 }
 
+// BeforeCreate はテナントのないcontext（ワーカーなど）で作成する取引を、当事者のユーザーのテナントに入れる
+func (t *TransactionModel) BeforeCreate(tx *gorm.DB) error {
+	if t.TenantID != uuid.Nil {
+		return nil
+	}
+	for _, userID := range []*uuid.UUID{t.ToUserID, t.FromUserID} {
+		if userID != nil {
+			t.TenantID = tenantOfUser(tx, *userID)
+			break
+		}
+	}
+	return nil
+}
+
 // ToDomain はドメインモデルに変換
 func (t *TransactionModel) ToDomain() *entities.Transaction {
 	return &entities.Transaction{
 		ID:              t.ID,
 		FromUserID:      t.FromUserID,
 		ToUserID:        t.ToUserID,
+		FromAccount:     systemAccountFromColumn(t.FromAccount),
+		ToAccount:       systemAccountFromColumn(t.ToAccount),
 		Amount:          t.Amount,
 		TransactionType: entities.TransactionType(t.TransactionType),
 		Status:          entities.TransactionStatus(t.Status),
 		IdempotencyKey:  t.IdempotencyKey,
 		Description:     t.Description,
+		ReasonCode:      t.ReasonCode,
+		Tag:             t.Tag,
 		Metadata:        map[string]interface{}(t.Metadata),
 		CreatedAt:       t.CreatedAt,
 		CompletedAt:     t.CompletedAt,
@@ -73,16 +99,37 @@ func (t *TransactionModel) FromDomain(transaction *entities.Transaction) {
 	t.ID = transaction.ID
 	t.FromUserID = transaction.FromUserID
 	t.ToUserID = transaction.ToUserID
+	t.FromAccount = systemAccountColumn(transaction.FromAccount)
+	t.ToAccount = systemAccountColumn(transaction.ToAccount)
 	t.Amount = transaction.Amount
 	t.TransactionType = string(transaction.TransactionType)
 	t.Status = string(transaction.Status)
 	t.IdempotencyKey = transaction.IdempotencyKey
 	t.Description = transaction.Description
+	t.ReasonCode = transaction.ReasonCode
+	t.Tag = transaction.Tag
 	t.Metadata = JSONB(transaction.Metadata)
 	t.CreatedAt = transaction.CreatedAt
 	t.CompletedAt = transaction.CompletedAt
 }
 
+// systemAccountColumn はシステムの口座を列の値にする（ユーザー間の移動はNULL）
+func systemAccountColumn(account entities.SystemAccount) *string {
+	if account == "" {
+		return nil
+	}
+	value := string(account)
+	return &value
+}
+
+// systemAccountFromColumn は列の値をシステムの口座にする
+func systemAccountFromColumn(value *string) entities.SystemAccount {
+	if value == nil {
+		return ""
+	}
+	return entities.SystemAccount(*value)
+}
+
 // TransactionDataSourceImpl はTransactionDataSourceの実装
 type TransactionDataSourceImpl struct {
 	db infrapostgres.DB
@@ -114,7 +161,7 @@ func (ds *TransactionDataSourceImpl) Select(ctx context.Context, id uuid.UUID) (
 	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Where("id = ?", id).First(&model).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("transaction not found")
+			return nil, entities.ErrTransactionNotFound
 		}
 		return nil, err
 	}
@@ -141,8 +188,9 @@ func (ds *TransactionDataSourceImpl) SelectByIdempotencyKey(ctx context.Context,
 func (ds *TransactionDataSourceImpl) SelectListByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.Transaction, error) {
 	var models []TransactionModel
 
-	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).
-		Where("from_user_id = ? OR to_user_id = ?", userID, userID).
+	ids, args := userTransactionIDsSQL(userID, "", offset+limit)
+	err := infrapostgres.GetReadDB(ctx, ds.db).
+		Where("id IN (?)", gorm.Expr(ids, args...)).
 		Offset(offset).
 		Limit(limit).
 		Order("created_at DESC").
@@ -164,7 +212,7 @@ func (ds *TransactionDataSourceImpl) SelectListByUserID(ctx context.Context, use
 func (ds *TransactionDataSourceImpl) SelectListAll(ctx context.Context, offset, limit int) ([]*entities.Transaction, error) {
 	var models []TransactionModel
 
-	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).
+	err := infrapostgres.GetReadDB(ctx, ds.db).
 		Offset(offset).
 		Limit(limit).
 		Order("created_at DESC").
@@ -183,10 +231,16 @@ func (ds *TransactionDataSourceImpl) SelectListAll(ctx context.Context, offset,
 }
 
 // applyFilterConditions はフィルタ条件を適用するヘルパー
-func (ds *TransactionDataSourceImpl) applyFilterConditions(query *gorm.DB, transactionType, dateFrom, dateTo string) *gorm.DB {
+func (ds *TransactionDataSourceImpl) applyFilterConditions(query *gorm.DB, transactionType, dateFrom, dateTo, reasonCode string, departmentIDs []uuid.UUID) *gorm.DB {
 	if transactionType != "" {
 		query = query.Where("transaction_type = ?", transactionType)
 	}
+	if reasonCode != "" {
+		query = query.Where("reason_code = ?", reasonCode)
+	}
+	if len(departmentIDs) > 0 {
+		query = query.Where(departmentMemberCondition, departmentIDs, departmentIDs)
+	}
 	if dateFrom != "" {
 		query = query.Where("created_at >= ?", dateFrom+" 00:00:00")
 	}
@@ -196,12 +250,15 @@ func (ds *TransactionDataSourceImpl) applyFilterConditions(query *gorm.DB, trans
 	return query
 }
 
+// departmentMemberCondition は送信者・受信者のどちらかが指定部署に所属する取引に絞り込む条件
+const departmentMemberCondition = "(from_user_id IN (SELECT id FROM users WHERE department_id IN ?) OR to_user_id IN (SELECT id FROM users WHERE department_id IN ?))"
+
 // SelectListAllWithFilter はフィルタ・ソート付きで全トランザクション一覧を取得
-func (ds *TransactionDataSourceImpl) SelectListAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, sortBy, sortOrder string, offset, limit int) ([]*entities.Transaction, error) {
-	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+func (ds *TransactionDataSourceImpl) SelectListAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode, sortBy, sortOrder string, offset, limit int) ([]*entities.Transaction, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
 	query := db.Model(&TransactionModel{})
 
-	query = ds.applyFilterConditions(query, transactionType, dateFrom, dateTo)
+	query = ds.applyFilterConditions(query, transactionType, dateFrom, dateTo, reasonCode, nil)
 
 	// ソート（ホワイトリスト方式）
 	allowedSortColumns := map[string]string{
@@ -234,15 +291,15 @@ func (ds *TransactionDataSourceImpl) SelectListAllWithFilter(ctx context.Context
 // CountAll は全トランザクション総数を取得
 func (ds *TransactionDataSourceImpl) CountAll(ctx context.Context) (int64, error) {
 	var count int64
-	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&TransactionModel{}).Count(&count).Error
+	err := infrapostgres.GetReadDB(ctx, ds.db).Model(&TransactionModel{}).Count(&count).Error
 	return count, err
 }
 
 // CountAllWithFilter はフィルタ付きで全トランザクション総数を取得
-func (ds *TransactionDataSourceImpl) CountAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo string) (int64, error) {
-	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+func (ds *TransactionDataSourceImpl) CountAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode string, departmentIDs []uuid.UUID) (int64, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
 	query := db.Model(&TransactionModel{})
-	query = ds.applyFilterConditions(query, transactionType, dateFrom, dateTo)
+	query = ds.applyFilterConditions(query, transactionType, dateFrom, dateTo, reasonCode, departmentIDs)
 	var count int64
 	err := query.Count(&count).Error
 	return count, err
@@ -262,13 +319,50 @@ func (ds *TransactionDataSourceImpl) Update(ctx context.Context, transaction *en
 		}).Error
 }
 
-// CountByUserID はユーザーのトランザクション総数を取得
-func (ds *TransactionDataSourceImpl) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
-	var count int64
-	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).Model(&TransactionModel{}).
-		Where("from_user_id = ? OR to_user_id = ?", userID, userID).
-		Count(&count).Error
-	return count, err
+// CountByUserID はユーザーのトランザクション総数を取得（reasonCodeが空でなければその理由コードの取引のみ）
+// 送信側・受信側のインデックスをそれぞれ使えるよう分けて数え、自分宛ての取引は送信側だけで数える
+func (ds *TransactionDataSourceImpl) CountByUserID(ctx context.Context, userID uuid.UUID, reasonCode string) (int64, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	count := func(query *gorm.DB) (int64, error) {
+		if reasonCode != "" {
+			query = query.Where("reason_code = ?", reasonCode)
+		}
+		var n int64
+		err := query.Count(&n).Error
+		return n, err
+	}
+
+	sent, err := count(db.Model(&TransactionModel{}).Where("from_user_id = ?", userID))
+	if err != nil {
+		return 0, err
+	}
+	received, err := count(db.Model(&TransactionModel{}).
+		Where("to_user_id = ? AND (from_user_id IS NULL OR from_user_id <> ?)", userID, userID))
+	if err != nil {
+		return 0, err
+	}
+	return sent + received, nil
+}
+
+// userTransactionIDsSQL はユーザーが送信者・受信者の取引のIDを、送信側・受信側それぞれ新しい順にwindow件まで取り出すSQL
+// (from_user_id = ? OR to_user_id = ?) ではどちらの列のインデックスも使えないため、UNIONに分けて
+// idx_transactions_from_user_created・idx_transactions_to_user_created（理由コードがあれば *_reason）をたどる
+// 呼び出し側で新しい順に並べ直してOFFSET・LIMITを適用する前提で、windowにはoffset+limitを渡す
+func userTransactionIDsSQL(userID uuid.UUID, reasonCode string, window int) (string, []interface{}) {
+	side := func(column, alias string) (string, []interface{}) {
+		sql := "SELECT id FROM (SELECT id FROM transactions WHERE " + column + " = ?"
+		args := []interface{}{userID}
+		if reasonCode != "" {
+			sql += " AND reason_code = ?"
+			args = append(args, reasonCode)
+		}
+		sql += " ORDER BY created_at DESC LIMIT ?) AS " + alias
+		return sql, append(args, window)
+	}
+
+	sent, sentArgs := side("from_user_id", "sent")
+	received, receivedArgs := side("to_user_id", "received")
+	return sent + " UNION " + received, append(sentArgs, receivedArgs...)
 }
 
 // transactionWithUsersRow はJOINクエリの結果を受け取る構造体
@@ -277,11 +371,15 @@ type transactionWithUsersRow struct {
 	ID              uuid.UUID  `gorm:"column:id"`
 	FromUserID      *uuid.UUID `gorm:"column:from_user_id"`
 	ToUserID        *uuid.UUID `gorm:"column:to_user_id"`
+	FromAccount     *string    `gorm:"column:from_system_account"`
+	ToAccount       *string    `gorm:"column:to_system_account"`
 	Amount          int64      `gorm:"column:amount"`
 	TransactionType string     `gorm:"column:transaction_type"`
 	Status          string     `gorm:"column:status"`
 	IdempotencyKey  *string    `gorm:"column:idempotency_key"`
 	Description     string     `gorm:"column:description"`
+	ReasonCode      string     `gorm:"column:reason_code"`
+	Tag             string     `gorm:"column:tag"`
 	Metadata        JSONB      `gorm:"column:metadata"`
 	CreatedAt       time.Time  `gorm:"column:created_at"`
 	CompletedAt     *time.Time `gorm:"column:completed_at"`
@@ -301,6 +399,8 @@ type transactionWithUsersRow struct {
 	ToLastName    *string `gorm:"column:to_last_name"`
 	ToAvatarURL   *string `gorm:"column:to_avatar_url"`
 	ToAvatarType  *string `gorm:"column:to_avatar_type"`
+	// 送金（または元の送金リクエスト）に画像が添付されているか
+	HasAttachment bool `gorm:"column:has_attachment"`
 }
 
 func (r *transactionWithUsersRow) toDomain() *entities.TransactionWithUsers {
@@ -309,15 +409,20 @@ func (r *transactionWithUsersRow) toDomain() *entities.TransactionWithUsers {
 			ID:              r.ID,
 			FromUserID:      r.FromUserID,
 			ToUserID:        r.ToUserID,
+			FromAccount:     systemAccountFromColumn(r.FromAccount),
+			ToAccount:       systemAccountFromColumn(r.ToAccount),
 			Amount:          r.Amount,
 			TransactionType: entities.TransactionType(r.TransactionType),
 			Status:          entities.TransactionStatus(r.Status),
 			IdempotencyKey:  r.IdempotencyKey,
 			Description:     r.Description,
+			ReasonCode:      r.ReasonCode,
+			Tag:             r.Tag,
 			Metadata:        map[string]interface{}(r.Metadata),
 			CreatedAt:       r.CreatedAt,
 			CompletedAt:     r.CompletedAt,
 		},
+		HasAttachment: r.HasAttachment,
 	}
 
 	if r.FromID != nil {
@@ -365,8 +470,10 @@ func deref(s *string) string {
 	return *s
 }
 
-const transactionWithUsersSQL = `SELECT t.id, t.from_user_id, t.to_user_id, t.amount,
-	t.transaction_type, t.status, t.idempotency_key, t.description, t.metadata,
+const transactionWithUsersSQL = `SELECT t.id, t.from_user_id, t.to_user_id,
+	t.from_system_account, t.to_system_account, t.amount,
+	t.transaction_type, t.status, t.idempotency_key, t.description,
+	t.reason_code, t.tag, t.metadata,
 	t.created_at, t.completed_at,
 	from_u.id AS from_id, from_u.username AS from_username,
 	from_u.display_name AS from_display_name, from_u.first_name AS from_first_name,
@@ -375,21 +482,24 @@ const transactionWithUsersSQL = `SELECT t.id, t.from_user_id, t.to_user_id, t.am
 	to_u.id AS to_id, to_u.username AS to_username,
 	to_u.display_name AS to_display_name, to_u.first_name AS to_first_name,
 	to_u.last_name AS to_last_name, to_u.avatar_url AS to_avatar_url,
-	to_u.avatar_type AS to_avatar_type
+	to_u.avatar_type AS to_avatar_type,
+	EXISTS (SELECT 1 FROM transfer_attachments a
+		WHERE a.transaction_id = t.id
+			OR a.transfer_request_id IN (SELECT tr.id FROM transfer_requests tr WHERE tr.transaction_id = t.id)) AS has_attachment
 FROM transactions t
 LEFT JOIN users from_u ON from_u.id = t.from_user_id
 LEFT JOIN users to_u ON to_u.id = t.to_user_id`
 
 // SelectListByUserIDWithUsers はユーザーに関連するトランザクション一覧をユーザー情報付きで取得（JOIN）
-func (ds *TransactionDataSourceImpl) SelectListByUserIDWithUsers(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.TransactionWithUsers, error) {
-	var rows []transactionWithUsersRow
+func (ds *TransactionDataSourceImpl) SelectListByUserIDWithUsers(ctx context.Context, userID uuid.UUID, reasonCode string, offset, limit int) ([]*entities.TransactionWithUsers, error) {
+	ids, args := userTransactionIDsSQL(userID, reasonCode, offset+limit)
+	query := transactionWithUsersSQL + " WHERE t.id IN (" + ids + ")"
+	query += " ORDER BY t.created_at DESC LIMIT ? OFFSET ?"
+	args = append(args, limit, offset)
 
-	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).
-		Raw(transactionWithUsersSQL+`
-		WHERE t.from_user_id = ? OR t.to_user_id = ?
-		ORDER BY t.created_at DESC
-		LIMIT ? OFFSET ?`,
-			userID, userID, limit, offset).
+	var rows []transactionWithUsersRow
+	err := infrapostgres.GetReadDB(ctx, ds.db).
+		Raw(query, args...).
 		Scan(&rows).Error
 
 	if err != nil {
@@ -404,14 +514,23 @@ func (ds *TransactionDataSourceImpl) SelectListByUserIDWithUsers(ctx context.Con
 }
 
 // SelectListAllWithFilterAndUsers はフィルタ・ソート付きで全トランザクション一覧をユーザー情報付きで取得（JOIN）
-func (ds *TransactionDataSourceImpl) SelectListAllWithFilterAndUsers(ctx context.Context, transactionType, dateFrom, dateTo, sortBy, sortOrder string, offset, limit int) ([]*entities.TransactionWithUsers, error) {
-	query := transactionWithUsersSQL + " WHERE 1=1"
-	args := make([]interface{}, 0)
+func (ds *TransactionDataSourceImpl) SelectListAllWithFilterAndUsers(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode string, departmentIDs []uuid.UUID, sortBy, sortOrder string, offset, limit int) ([]*entities.TransactionWithUsers, error) {
+	tenant, tenantArgs := tenantCondition(ctx, "t.tenant_id")
+	query := transactionWithUsersSQL + " WHERE 1=1" + tenant
+	args := append(make([]interface{}, 0), tenantArgs...)
 
 	if transactionType != "" {
 		query += " AND t.transaction_type = ?"
 		args = append(args, transactionType)
 	}
+	if reasonCode != "" {
+		query += " AND t.reason_code = ?"
+		args = append(args, reasonCode)
+	}
+	if len(departmentIDs) > 0 {
+		query += " AND " + departmentMemberCondition
+		args = append(args, departmentIDs, departmentIDs)
+	}
 	if dateFrom != "" {
 		query += " AND t.created_at >= ?"
 		args = append(args, dateFrom+" 00:00:00")
@@ -439,7 +558,7 @@ func (ds *TransactionDataSourceImpl) SelectListAllWithFilterAndUsers(ctx context
 	args = append(args, limit, offset)
 
 	var rows []transactionWithUsersRow
-	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).
+	err := infrapostgres.GetReadDB(ctx, ds.db).
 		Raw(query, args...).
 		Scan(&rows).Error
 
@@ -454,6 +573,109 @@ func (ds *TransactionDataSourceImpl) SelectListAllWithFilterAndUsers(ctx context
 	return results, nil
 }
 
+// UpdateAnonymizeUser は退会するユーザーが関わった取引に退会済みの印を付ける
+// 送金メモは送信者が書いたものなので、送信側の取引だけを消す
+func (ds *TransactionDataSourceImpl) UpdateAnonymizeUser(ctx context.Context, userID uuid.UUID, scrubMemos bool) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+
+	fromSet := "metadata = " + setMetadataFlag(db, entities.MetadataFromUserDeleted)
+	if scrubMemos {
+		fromSet += ", description = CASE WHEN transaction_type = '" + string(entities.TransactionTypeTransfer) + "' THEN '' ELSE description END"
+	}
+	if err := db.Exec("UPDATE transactions SET "+fromSet+" WHERE from_user_id = ?", userID).Error; err != nil {
+		return err
+	}
+
+	toSet := "metadata = " + setMetadataFlag(db, entities.MetadataToUserDeleted)
+	return db.Exec("UPDATE transactions SET "+toSet+" WHERE to_user_id = ?", userID).Error
+}
+
+// pointsExchangedRow は相手ごとの集計結果を受け取る構造体
+type pointsExchangedRow struct {
+	CounterpartID uuid.UUID `gorm:"column:counterpart_id"`
+	Sent          int64     `gorm:"column:sent"`
+	Received      int64     `gorm:"column:received"`
+}
+
+// SumExchangedWith はユーザーと各相手の間でやり取りした完了済みの取引の合計を取得
+// 一覧の1ページ分の相手をまとめて1回のクエリで集計する
+func (ds *TransactionDataSourceImpl) SumExchangedWith(ctx context.Context, userID uuid.UUID, counterpartIDs []uuid.UUID) ([]*entities.PointsExchanged, error) {
+	if len(counterpartIDs) == 0 {
+		return []*entities.PointsExchanged{}, nil
+	}
+
+	var rows []pointsExchangedRow
+	err := infrapostgres.GetReadDB(ctx, ds.db).
+		Raw(`SELECT
+			CASE WHEN from_user_id = ? THEN to_user_id ELSE from_user_id END AS counterpart_id,
+			COALESCE(SUM(CASE WHEN from_user_id = ? THEN amount ELSE 0 END), 0) AS sent,
+			COALESCE(SUM(CASE WHEN to_user_id = ? THEN amount ELSE 0 END), 0) AS received
+		FROM transactions
+		WHERE status = ?
+			AND ((from_user_id = ? AND to_user_id IN ?) OR (to_user_id = ? AND from_user_id IN ?))
+		GROUP BY CASE WHEN from_user_id = ? THEN to_user_id ELSE from_user_id END`,
+			userID, userID, userID,
+			entities.TransactionStatusCompleted,
+			userID, counterpartIDs, userID, counterpartIDs,
+			userID).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*entities.PointsExchanged, len(rows))
+	for i, row := range rows {
+		results[i] = &entities.PointsExchanged{CounterpartID: row.CounterpartID, Sent: row.Sent, Received: row.Received}
+	}
+	return results, nil
+}
+
+// transferAmountUsageRow は送金先・金額ごとの集計結果を受け取る構造体
+type transferAmountUsageRow struct {
+	ToUserID   uuid.UUID      `gorm:"column:to_user_id"`
+	Amount     int64          `gorm:"column:amount"`
+	Count      int64          `gorm:"column:transfer_count"`
+	LastSentAt aggregatedTime `gorm:"column:last_sent_at"`
+}
+
+// SelectTransferAmountUsage はsince以降にユーザーが各送金先へ送った完了済みの送金を、送金先・金額ごとに集計
+func (ds *TransactionDataSourceImpl) SelectTransferAmountUsage(ctx context.Context, fromUserID uuid.UUID, toUserIDs []uuid.UUID, since time.Time) ([]*entities.TransferAmountUsage, error) {
+	if len(toUserIDs) == 0 {
+		return []*entities.TransferAmountUsage{}, nil
+	}
+
+	var rows []transferAmountUsageRow
+	err := infrapostgres.GetReadDB(ctx, ds.db).Model(&TransactionModel{}).
+		Select("to_user_id, amount, COUNT(*) AS transfer_count, MAX(created_at) AS last_sent_at").
+		Where("from_user_id = ? AND to_user_id IN ?", fromUserID, toUserIDs).
+		Where("transaction_type = ? AND status = ? AND created_at >= ?",
+			entities.TransactionTypeTransfer, entities.TransactionStatusCompleted, since).
+		Group("to_user_id, amount").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make([]*entities.TransferAmountUsage, len(rows))
+	for i, row := range rows {
+		usages[i] = &entities.TransferAmountUsage{
+			RecipientID: row.ToUserID,
+			Amount:      row.Amount,
+			Count:       row.Count,
+			LastSentAt:  row.LastSentAt.Time,
+		}
+	}
+	return usages, nil
+}
+
+// setMetadataFlag はmetadataのkeyをtrueにした値の式を返す
+func setMetadataFlag(db *gorm.DB, key string) string {
+	if isSQLite(db) {
+		return "json_set(COALESCE(metadata, '{}'), '$." + key + "', json('true'))"
+	}
+	return "COALESCE(metadata, '{}'::jsonb) || jsonb_build_object('" + key + "', true)"
+}
+
 // IdempotencyKeyModel はGORM用の冪等性キーモデル
 type IdempotencyKeyModel struct {
 	Key           string     `gorm:"type:varchar(255);primary_key"`
@@ -0,0 +1,124 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TransferAttachmentModel は送金・送金リクエストの添付画像のGORMモデル
+type TransferAttachmentModel struct {
+	ID                uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	TransactionID     *uuid.UUID `gorm:"type:uuid"`
+	TransferRequestID *uuid.UUID `gorm:"type:uuid"`
+	UploadedBy        uuid.UUID  `gorm:"type:uuid;not null"`
+	FilePath          string     `gorm:"type:text;not null"`
+	FileName          string     `gorm:"type:varchar(255);not null"`
+	SizeBytes         int64      `gorm:"not null"`
+	CreatedAt         time.Time  `gorm:"type:timestamptz;not null"`
+}
+
+// TableName はテーブル名を指定
+func (TransferAttachmentModel) TableName() string {
+	return "transfer_attachments"
+}
+
+// ToDomain はドメインエンティティに変換
+func (m *TransferAttachmentModel) ToDomain() *entities.TransferAttachment {
+	return &entities.TransferAttachment{
+		ID:                m.ID,
+		TransactionID:     m.TransactionID,
+		TransferRequestID: m.TransferRequestID,
+		UploadedBy:        m.UploadedBy,
+		FilePath:          m.FilePath,
+		FileName:          m.FileName,
+		SizeBytes:         m.SizeBytes,
+		CreatedAt:         m.CreatedAt,
+	}
+}
+
+// TransferAttachmentDataSource は送金・送金リクエストの添付画像のデータソース
+type TransferAttachmentDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewTransferAttachmentDataSource は新しいTransferAttachmentDataSourceを作成
+func NewTransferAttachmentDataSource(db infrapostgres.DB) *TransferAttachmentDataSource {
+	return &TransferAttachmentDataSource{db: db}
+}
+
+// Insert は添付を挿入
+func (ds *TransferAttachmentDataSource) Insert(ctx context.Context, a *entities.TransferAttachment) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Create(&TransferAttachmentModel{
+		ID:                a.ID,
+		TransactionID:     a.TransactionID,
+		TransferRequestID: a.TransferRequestID,
+		UploadedBy:        a.UploadedBy,
+		FilePath:          a.FilePath,
+		FileName:          a.FileName,
+		SizeBytes:         a.SizeBytes,
+		CreatedAt:         a.CreatedAt,
+	}).Error
+}
+
+// Delete は添付を削除
+func (ds *TransferAttachmentDataSource) Delete(ctx context.Context, id uuid.UUID) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Where("id = ?", id).Delete(&TransferAttachmentModel{}).Error
+}
+
+// SelectByTransactionID は送金に直接添付したもの、または承認してその送金になった送金リクエストの添付を取得（新しいものを優先）
+func (ds *TransferAttachmentDataSource) SelectByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entities.TransferAttachment, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return takeTransferAttachment(db.Table("transfer_attachments a").
+		Select("a.*").
+		Joins("LEFT JOIN transfer_requests tr ON tr.id = a.transfer_request_id").
+		Where("a.transaction_id = ? OR tr.transaction_id = ?", transactionID, transactionID).
+		Order("a.created_at DESC"))
+}
+
+// SelectByTransferRequestID は送金リクエストの添付を取得
+func (ds *TransferAttachmentDataSource) SelectByTransferRequestID(ctx context.Context, requestID uuid.UUID) (*entities.TransferAttachment, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return takeTransferAttachment(db.Where("transfer_request_id = ?", requestID).Order("created_at DESC"))
+}
+
+// SelectListBefore は添付した送金の作成日時（送金がない・保管用のテーブルへ移った場合は添付した日時）がcutoffより前の添付を古い順に取得
+func (ds *TransferAttachmentDataSource) SelectListBefore(ctx context.Context, cutoff time.Time, limit int) ([]*entities.TransferAttachment, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	var models []TransferAttachmentModel
+	if err := db.Table("transfer_attachments a").
+		Select("a.*").
+		Joins("LEFT JOIN transfer_requests tr ON tr.id = a.transfer_request_id").
+		Joins("LEFT JOIN transactions t ON t.id = COALESCE(a.transaction_id, tr.transaction_id)").
+		Where("COALESCE(t.created_at, a.created_at) < ?", cutoff).
+		Order("a.created_at ASC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	attachments := make([]*entities.TransferAttachment, len(models))
+	for i := range models {
+		attachments[i] = models[i].ToDomain()
+	}
+	return attachments, nil
+}
+
+// takeTransferAttachment はクエリの最初の添付を取得（なければnil）
+func takeTransferAttachment(query *gorm.DB) (*entities.TransferAttachment, error) {
+	var model TransferAttachmentModel
+	err := query.Take(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
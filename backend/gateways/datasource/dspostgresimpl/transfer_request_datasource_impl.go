@@ -26,6 +26,11 @@ type TransferRequestModel struct {
 	RejectedAt     *time.Time `gorm:"type:timestamp with time zone"`
 	CancelledAt    *time.Time `gorm:"type:timestamp with time zone"`
 	TransactionID  *uuid.UUID `gorm:"type:uuid"`
+	CounterAmount  *int64
+	CounteredAt    *time.Time `gorm:"type:timestamp with time zone"`
+	LastActedBy    *uuid.UUID `gorm:"type:uuid"`
+	SplitID        *uuid.UUID `gorm:"type:uuid;index"`
+	Hold           bool       `gorm:"not null;default:false"`
 	CreatedAt      time.Time  `gorm:"not null;default:now()"`
 	UpdatedAt      time.Time  `gorm:"not null;default:now()"`
 }
@@ -50,6 +55,11 @@ func (tr *TransferRequestModel) ToDomain() *entities.TransferRequest {
 		RejectedAt:     tr.RejectedAt,
 		CancelledAt:    tr.CancelledAt,
 		TransactionID:  tr.TransactionID,
+		CounterAmount:  tr.CounterAmount,
+		CounteredAt:    tr.CounteredAt,
+		LastActedBy:    lastActedByOrSender(tr.LastActedBy, tr.FromUserID),
+		SplitID:        tr.SplitID,
+		Hold:           tr.Hold,
 		CreatedAt:      tr.CreatedAt,
 		UpdatedAt:      tr.UpdatedAt,
 	}
@@ -69,10 +79,27 @@ func (tr *TransferRequestModel) FromDomain(transferRequest *entities.TransferReq
 	tr.RejectedAt = transferRequest.RejectedAt
 	tr.CancelledAt = transferRequest.CancelledAt
 	tr.TransactionID = transferRequest.TransactionID
+	tr.CounterAmount = transferRequest.CounterAmount
+	tr.CounteredAt = transferRequest.CounteredAt
+	tr.LastActedBy = nil
+	if transferRequest.LastActedBy != uuid.Nil {
+		lastActedBy := transferRequest.LastActedBy
+		tr.LastActedBy = &lastActedBy
+	}
+	tr.SplitID = transferRequest.SplitID
+	tr.Hold = transferRequest.Hold
 	tr.CreatedAt = transferRequest.CreatedAt
 	tr.UpdatedAt = transferRequest.UpdatedAt
 }
 
+// lastActedByOrSender は最終操作者が未設定（退会済みユーザー等）の場合に送信者を返す
+func lastActedByOrSender(lastActedBy *uuid.UUID, fromUserID uuid.UUID) uuid.UUID {
+	if lastActedBy == nil {
+		return fromUserID
+	}
+	return *lastActedBy
+}
+
 // TransferRequestDataSourceImpl はTransferRequestDataSourceの実装
 type TransferRequestDataSourceImpl struct {
 	db infrapostgres.DB
@@ -144,13 +171,21 @@ func (ds *TransferRequestDataSourceImpl) Update(ctx context.Context, transferReq
 	return nil
 }
 
-// SelectPendingByToUser は受取人宛の承認待ちリクエストを取得
+// pendingForApproverSQL は承認者宛の承認待ちの条件（通常は受取人、割り勘の1人分は支払う送信者）
+const pendingForApproverSQL = "((to_user_id = ? AND split_id IS NULL) OR (from_user_id = ? AND split_id IS NOT NULL)) AND status = ?"
+
+// notMutedSenderSQL は承認者がミュートした送信者のリクエストを除く条件
+// 割り勘の1人分は送信者が承認者自身なので除かれない
+const notMutedSenderSQL = "NOT EXISTS (SELECT 1 FROM muted_senders ms WHERE ms.user_id = ? AND ms.muted_user_id = transfer_requests.from_user_id)"
+
+// SelectPendingByToUser は受取人宛の承認待ちリクエストを取得（自分が支払う割り勘の1人分を含み、ミュートした送信者のものは除く）
 func (ds *TransferRequestDataSourceImpl) SelectPendingByToUser(ctx context.Context, toUserID uuid.UUID, offset, limit int) ([]*entities.TransferRequest, error) {
 	var models []TransferRequestModel
 
 	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).
-		Where("to_user_id = ? AND status = ?", toUserID, string(entities.TransferRequestStatusPending)).
+		Where(pendingForApproverSQL, toUserID, toUserID, string(entities.TransferRequestStatusPending)).
 		Where("expires_at > ?", time.Now()). // 有効期限内のみ
+		Where(notMutedSenderSQL, toUserID).
 		Order("created_at DESC").
 		Offset(offset).
 		Limit(limit).
@@ -168,12 +203,12 @@ func (ds *TransferRequestDataSourceImpl) SelectPendingByToUser(ctx context.Conte
 	return requests, nil
 }
 
-// SelectSentByFromUser は送信者が送ったリクエストを取得
+// SelectSentByFromUser は送信者が送ったリクエストを取得（割り勘の1人分は除く）
 func (ds *TransferRequestDataSourceImpl) SelectSentByFromUser(ctx context.Context, fromUserID uuid.UUID, offset, limit int) ([]*entities.TransferRequest, error) {
 	var models []TransferRequestModel
 
 	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).
-		Where("from_user_id = ?", fromUserID).
+		Where("from_user_id = ? AND split_id IS NULL", fromUserID).
 		Order("created_at DESC").
 		Offset(offset).
 		Limit(limit).
@@ -191,14 +226,15 @@ func (ds *TransferRequestDataSourceImpl) SelectSentByFromUser(ctx context.Contex
 	return requests, nil
 }
 
-// CountPendingByToUser は受取人宛の承認待ちリクエスト数を取得
+// CountPendingByToUser は受取人宛の承認待ちリクエスト数を取得（自分が支払う割り勘の1人分を含み、ミュートした送信者のものは除く）
 func (ds *TransferRequestDataSourceImpl) CountPendingByToUser(ctx context.Context, toUserID uuid.UUID) (int64, error) {
 	var count int64
 
 	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).
 		Model(&TransferRequestModel{}).
-		Where("to_user_id = ? AND status = ?", toUserID, string(entities.TransferRequestStatusPending)).
+		Where(pendingForApproverSQL, toUserID, toUserID, string(entities.TransferRequestStatusPending)).
 		Where("expires_at > ?", time.Now()). // 有効期限内のみ
+		Where(notMutedSenderSQL, toUserID).
 		Count(&count).Error
 
 	if err != nil {
@@ -208,11 +244,67 @@ func (ds *TransferRequestDataSourceImpl) CountPendingByToUser(ctx context.Contex
 	return count, nil
 }
 
+// CountPendingByFromUser は送信者が送った有効期限内の承認待ちリクエスト数を取得（割り勘の1人分は除く）
+func (ds *TransferRequestDataSourceImpl) CountPendingByFromUser(ctx context.Context, fromUserID uuid.UUID) (int64, error) {
+	return ds.countPendingSent(ctx, "from_user_id = ?", fromUserID)
+}
+
+// CountPendingByFromUserTo は送信者が受取人に送った有効期限内の承認待ちリクエスト数を取得（割り勘の1人分は除く）
+func (ds *TransferRequestDataSourceImpl) CountPendingByFromUserTo(ctx context.Context, fromUserID, toUserID uuid.UUID) (int64, error) {
+	return ds.countPendingSent(ctx, "from_user_id = ? AND to_user_id = ?", fromUserID, toUserID)
+}
+
+// countPendingSent は送信者の条件に合う有効期限内の承認待ちリクエスト数を取得
+// 有効期限を過ぎたものは期限切れにするワーカーが動く前でも数えない
+func (ds *TransferRequestDataSourceImpl) countPendingSent(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	var count int64
+
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).
+		Model(&TransferRequestModel{}).
+		Where(query, args...).
+		Where("split_id IS NULL AND status = ?", string(entities.TransferRequestStatusPending)).
+		Where("expires_at > ?", time.Now()).
+		Count(&count).Error
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// SelectBySplitIDs は割り勘ごとの送金リクエストを作成順に取得
+func (ds *TransferRequestDataSourceImpl) SelectBySplitIDs(ctx context.Context, splitIDs []uuid.UUID) ([]*entities.TransferRequest, error) {
+	if len(splitIDs) == 0 {
+		return nil, nil
+	}
+	var models []TransferRequestModel
+
+	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).
+		Where("split_id IN ?", splitIDs).
+		Order("created_at ASC, id ASC").
+		Find(&models).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]*entities.TransferRequest, 0, len(models))
+	for _, model := range models {
+		requests = append(requests, model.ToDomain())
+	}
+
+	return requests, nil
+}
+
 // UpdateExpiredRequests は期限切れのリクエストを一括更新
 func (ds *TransferRequestDataSourceImpl) UpdateExpiredRequests(ctx context.Context) (int64, error) {
 	result := infrapostgres.GetDB(ctx, ds.db.GetDB()).
 		Model(&TransferRequestModel{}).
-		Where("status = ? AND expires_at <= ?", string(entities.TransferRequestStatusPending), time.Now()).
+		Where("status IN ? AND expires_at <= ?", []string{
+			string(entities.TransferRequestStatusPending),
+			string(entities.TransferRequestStatusCountered),
+		}, time.Now()).
 		Update("status", string(entities.TransferRequestStatusExpired))
 
 	if result.Error != nil {
@@ -237,6 +329,11 @@ type transferRequestWithUsersRow struct {
 	RejectedAt     *time.Time `gorm:"column:rejected_at"`
 	CancelledAt    *time.Time `gorm:"column:cancelled_at"`
 	TransactionID  *uuid.UUID `gorm:"column:transaction_id"`
+	CounterAmount  *int64     `gorm:"column:counter_amount"`
+	CounteredAt    *time.Time `gorm:"column:countered_at"`
+	LastActedBy    *uuid.UUID `gorm:"column:last_acted_by"`
+	SplitID        *uuid.UUID `gorm:"column:split_id"`
+	Hold           bool       `gorm:"column:hold"`
 	CreatedAt      time.Time  `gorm:"column:created_at"`
 	UpdatedAt      time.Time  `gorm:"column:updated_at"`
 	// FromUser fields
@@ -274,6 +371,11 @@ func (r *transferRequestWithUsersRow) toDomain() *entities.TransferRequestWithUs
 			RejectedAt:     r.RejectedAt,
 			CancelledAt:    r.CancelledAt,
 			TransactionID:  r.TransactionID,
+			CounterAmount:  r.CounterAmount,
+			CounteredAt:    r.CounteredAt,
+			LastActedBy:    lastActedByOrSender(r.LastActedBy, r.FromUserID),
+			SplitID:        r.SplitID,
+			Hold:           r.Hold,
 			CreatedAt:      r.CreatedAt,
 			UpdatedAt:      r.UpdatedAt,
 		},
@@ -300,7 +402,8 @@ func (r *transferRequestWithUsersRow) toDomain() *entities.TransferRequestWithUs
 
 const transferRequestWithUsersSQL = `SELECT tr.id, tr.from_user_id, tr.to_user_id, tr.amount, tr.message,
 	tr.status, tr.idempotency_key, tr.expires_at, tr.approved_at, tr.rejected_at,
-	tr.cancelled_at, tr.transaction_id, tr.created_at, tr.updated_at,
+	tr.cancelled_at, tr.transaction_id, tr.counter_amount, tr.countered_at,
+	tr.last_acted_by, tr.split_id, tr.hold, tr.created_at, tr.updated_at,
 	from_u.id AS from_id, from_u.username AS from_username,
 	from_u.display_name AS from_display_name, from_u.first_name AS from_first_name,
 	from_u.last_name AS from_last_name, from_u.avatar_url AS from_avatar_url,
@@ -313,16 +416,18 @@ FROM transfer_requests tr
 LEFT JOIN users from_u ON from_u.id = tr.from_user_id
 LEFT JOIN users to_u ON to_u.id = tr.to_user_id`
 
-// SelectPendingByToUserWithUsers は受取人宛の承認待ちリクエストをユーザー情報付きで取得（JOIN）
+// SelectPendingByToUserWithUsers は受取人宛の承認待ちリクエストをユーザー情報付きで取得（JOIN、自分が支払う割り勘の1人分を含み、ミュートした送信者のものは除く）
 func (ds *TransferRequestDataSourceImpl) SelectPendingByToUserWithUsers(ctx context.Context, toUserID uuid.UUID, offset, limit int) ([]*entities.TransferRequestWithUsers, error) {
 	var rows []transferRequestWithUsersRow
 
 	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).
 		Raw(transferRequestWithUsersSQL+`
-		WHERE tr.to_user_id = ? AND tr.status = ? AND tr.expires_at > ?
+		WHERE ((tr.to_user_id = ? AND tr.split_id IS NULL) OR (tr.from_user_id = ? AND tr.split_id IS NOT NULL))
+			AND tr.status = ? AND tr.expires_at > ?
+			AND NOT EXISTS (SELECT 1 FROM muted_senders ms WHERE ms.user_id = ? AND ms.muted_user_id = tr.from_user_id)
 		ORDER BY tr.created_at DESC
 		LIMIT ? OFFSET ?`,
-			toUserID, string(entities.TransferRequestStatusPending), time.Now(), limit, offset).
+			toUserID, toUserID, string(entities.TransferRequestStatusPending), time.Now(), toUserID, limit, offset).
 		Scan(&rows).Error
 
 	if err != nil {
@@ -336,13 +441,13 @@ func (ds *TransferRequestDataSourceImpl) SelectPendingByToUserWithUsers(ctx cont
 	return results, nil
 }
 
-// SelectSentByFromUserWithUsers は送信者が送ったリクエストをユーザー情報付きで取得（JOIN）
+// SelectSentByFromUserWithUsers は送信者が送ったリクエストをユーザー情報付きで取得（JOIN、割り勘の1人分は除く）
 func (ds *TransferRequestDataSourceImpl) SelectSentByFromUserWithUsers(ctx context.Context, fromUserID uuid.UUID, offset, limit int) ([]*entities.TransferRequestWithUsers, error) {
 	var rows []transferRequestWithUsersRow
 
 	err := infrapostgres.GetDB(ctx, ds.db.GetDB()).
 		Raw(transferRequestWithUsersSQL+`
-		WHERE tr.from_user_id = ?
+		WHERE tr.from_user_id = ? AND tr.split_id IS NULL
 		ORDER BY tr.created_at DESC
 		LIMIT ? OFFSET ?`,
 			fromUserID, limit, offset).
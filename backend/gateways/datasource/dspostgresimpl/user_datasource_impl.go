@@ -1,8 +1,11 @@
 package dspostgresimpl
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gity/point-system/entities"
@@ -16,21 +19,34 @@ import (
 // UserModel はGORMのユーザーモデル
 type UserModel struct {
 	ID              string     `gorm:"column:id;primaryKey;type:char(36)"`
-	Username        string     `gorm:"column:username;uniqueIndex;not null"`
-	Email           string     `gorm:"column:email;uniqueIndex;not null"`
+	TenantID        uuid.UUID  `gorm:"column:tenant_id;type:uuid;not null;default:'00000000-0000-0000-0000-000000000001';uniqueIndex:idx_users_tenant_username,priority:1;uniqueIndex:idx_users_tenant_email,priority:1"`
+	Username        string     `gorm:"column:username;uniqueIndex:idx_users_tenant_username,priority:2;not null"`
+	Email           string     `gorm:"column:email;uniqueIndex:idx_users_tenant_email,priority:2;not null"`
 	PasswordHash    string     `gorm:"column:password_hash;not null"`
 	DisplayName     string     `gorm:"column:display_name;not null"`
 	FirstName       string     `gorm:"column:first_name;not null;default:''"`
 	LastName        string     `gorm:"column:last_name;not null;default:''"`
 	Balance         int64      `gorm:"column:balance;not null;default:0"`
+	ReservedBalance int64      `gorm:"column:reserved_balance;not null;default:0"`
 	Role            string     `gorm:"column:role;not null;default:'user'"`
 	Version         int        `gorm:"column:version;not null;default:1"`
 	IsActive        bool       `gorm:"column:is_active;not null;default:true"`
+	Status          string     `gorm:"column:status;not null;default:'active'"`
+	DeactivatedAt   *time.Time `gorm:"column:deactivated_at"`
 	AvatarURL       *string    `gorm:"column:avatar_url"`
 	AvatarType      string     `gorm:"column:avatar_type;not null;default:'generated'"`
 	PersonalQRCode  string     `gorm:"column:personal_qr_code"`
 	EmailVerified   bool       `gorm:"column:email_verified;not null;default:false"`
 	EmailVerifiedAt *time.Time `gorm:"column:email_verified_at"`
+	Discoverable    bool       `gorm:"column:discoverable;not null;default:true"`
+	Tier            string     `gorm:"column:tier;not null;default:'bronze'"` // 会員ランク（更新はUserTierDataSourceだけが行う）
+	TierOverridden  bool       `gorm:"column:tier_overridden;not null;default:false"`
+	TierUpdatedAt   *time.Time `gorm:"column:tier_updated_at"`
+	DepartmentID    *uuid.UUID `gorm:"column:department_id"`   // 所属部署（更新はDepartmentDataSourceだけが行う）
+	EmailSHA256     string     `gorm:"column:email_sha256"`    // 友達検索用（正規化したメールアドレスのSHA-256）
+	UsernameSHA256  string     `gorm:"column:username_sha256"` // 友達検索用（正規化したユーザー名のSHA-256）
+	Timezone        string     `gorm:"column:timezone;not null;default:''"`
+	AcceptFrom      string     `gorm:"column:accept_transfers_from;not null;default:''"`
 	CreatedAt       time.Time  `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt       time.Time  `gorm:"column:updated_at;autoUpdateTime"`
 }
@@ -44,30 +60,42 @@ func (UserModel) TableName() string {
 func (m *UserModel) ToDomain() *entities.User {
 	userID, _ := uuid.Parse(m.ID)
 	return &entities.User{
-		ID:              userID,
-		Username:        m.Username,
-		Email:           m.Email,
-		PasswordHash:    m.PasswordHash,
-		DisplayName:     m.DisplayName,
-		FirstName:       m.FirstName,
-		LastName:        m.LastName,
-		Balance:         m.Balance,
-		Role:            entities.UserRole(m.Role),
-		Version:         m.Version,
-		IsActive:        m.IsActive,
-		AvatarURL:       m.AvatarURL,
-		AvatarType:      entities.AvatarType(m.AvatarType),
-		PersonalQRCode:  m.PersonalQRCode,
-		EmailVerified:   m.EmailVerified,
-		EmailVerifiedAt: m.EmailVerifiedAt,
-		CreatedAt:       m.CreatedAt,
-		UpdatedAt:       m.UpdatedAt,
+		ID:                  userID,
+		TenantID:            m.TenantID,
+		Username:            m.Username,
+		Email:               m.Email,
+		PasswordHash:        m.PasswordHash,
+		DisplayName:         m.DisplayName,
+		FirstName:           m.FirstName,
+		LastName:            m.LastName,
+		Balance:             m.Balance,
+		ReservedBalance:     m.ReservedBalance,
+		Role:                entities.UserRole(m.Role),
+		Version:             m.Version,
+		IsActive:            m.IsActive,
+		Status:              entities.UserStatus(m.Status),
+		DeactivatedAt:       m.DeactivatedAt,
+		AvatarURL:           m.AvatarURL,
+		AvatarType:          entities.AvatarType(m.AvatarType),
+		PersonalQRCode:      m.PersonalQRCode,
+		EmailVerified:       m.EmailVerified,
+		EmailVerifiedAt:     m.EmailVerifiedAt,
+		Discoverable:        m.Discoverable,
+		Tier:                entities.UserTier(m.Tier),
+		TierOverridden:      m.TierOverridden,
+		TierUpdatedAt:       m.TierUpdatedAt,
+		DepartmentID:        m.DepartmentID,
+		Timezone:            m.Timezone,
+		AcceptTransfersFrom: entities.TransferAcceptance(m.AcceptFrom),
+		CreatedAt:           m.CreatedAt,
+		UpdatedAt:           m.UpdatedAt,
 	}
 }
 
 // FromDomain はドメインモデルから変換
 func (u *UserModel) FromDomain(user *entities.User) {
 	u.ID = user.ID.String()
+	u.TenantID = user.TenantID
 	u.Username = user.Username
 	u.Email = user.Email
 	u.PasswordHash = user.PasswordHash
@@ -75,14 +103,26 @@ func (u *UserModel) FromDomain(user *entities.User) {
 	u.FirstName = user.FirstName
 	u.LastName = user.LastName
 	u.Balance = user.Balance
+	u.ReservedBalance = user.ReservedBalance
 	u.Role = string(user.Role)
 	u.Version = user.Version
 	u.IsActive = user.IsActive
+	u.Status = string(user.CurrentStatus())
+	u.DeactivatedAt = user.DeactivatedAt
 	u.AvatarURL = user.AvatarURL
 	u.AvatarType = string(user.AvatarType)
 	u.PersonalQRCode = user.PersonalQRCode
 	u.EmailVerified = user.EmailVerified
 	u.EmailVerifiedAt = user.EmailVerifiedAt
+	u.Discoverable = user.Discoverable
+	u.Tier = string(user.CurrentTier())
+	u.TierOverridden = user.TierOverridden
+	u.TierUpdatedAt = user.TierUpdatedAt
+	u.DepartmentID = user.DepartmentID
+	u.Timezone = user.Timezone
+	u.AcceptFrom = string(user.AcceptTransfersFrom)
+	u.EmailSHA256 = entities.HashContactIdentifier(user.Email)
+	u.UsernameSHA256 = entities.HashContactIdentifier(user.Username)
 	u.CreatedAt = user.CreatedAt
 	u.UpdatedAt = user.UpdatedAt
 }
@@ -120,7 +160,7 @@ func (ds *UserDataSourceImpl) Select(ctx context.Context, id uuid.UUID) (*entiti
 	err := db.Where("id = ?", id.String()).First(&model).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, entities.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -136,7 +176,7 @@ func (ds *UserDataSourceImpl) SelectByUsername(ctx context.Context, username str
 	err := db.Where("username = ?", username).First(&model).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, entities.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -152,7 +192,7 @@ func (ds *UserDataSourceImpl) SelectByEmail(ctx context.Context, email string) (
 	err := db.Where("email = ?", email).First(&model).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, entities.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -171,21 +211,28 @@ func (ds *UserDataSourceImpl) Update(ctx context.Context, user *entities.User) (
 	// versionはDB側でアトミックにインクリメント
 	result := db.Model(&UserModel{}).Where("id = ? AND version = ?", user.ID.String(), user.Version).
 		Updates(map[string]interface{}{
-			"username":          model.Username,
-			"email":             model.Email,
-			"password_hash":     model.PasswordHash,
-			"display_name":      model.DisplayName,
-			"first_name":        model.FirstName,
-			"last_name":         model.LastName,
-			"balance":           model.Balance,
-			"role":              model.Role,
-			"version":           gorm.Expr("version + 1"),
-			"is_active":         model.IsActive,
-			"avatar_url":        model.AvatarURL,
-			"avatar_type":       model.AvatarType,
-			"email_verified":    model.EmailVerified,
-			"email_verified_at": model.EmailVerifiedAt,
-			"updated_at":        time.Now(),
+			"username":              model.Username,
+			"username_sha256":       model.UsernameSHA256,
+			"email":                 model.Email,
+			"email_sha256":          model.EmailSHA256,
+			"password_hash":         model.PasswordHash,
+			"display_name":          model.DisplayName,
+			"first_name":            model.FirstName,
+			"last_name":             model.LastName,
+			"balance":               model.Balance,
+			"role":                  model.Role,
+			"version":               gorm.Expr("version + 1"),
+			"is_active":             model.IsActive,
+			"status":                model.Status,
+			"deactivated_at":        model.DeactivatedAt,
+			"avatar_url":            model.AvatarURL,
+			"avatar_type":           model.AvatarType,
+			"email_verified":        model.EmailVerified,
+			"email_verified_at":     model.EmailVerifiedAt,
+			"discoverable":          model.Discoverable,
+			"timezone":              model.Timezone,
+			"accept_transfers_from": model.AcceptFrom,
+			"updated_at":            time.Now(),
 		})
 
 	if result.Error != nil {
@@ -223,6 +270,7 @@ func (ds *UserDataSourceImpl) UpdatePartial(ctx context.Context, userID uuid.UUI
 }
 
 // UpdateBalanceWithLock は残高を更新（悲観的ロック: SELECT FOR UPDATE）
+// 減算は確保中の分（reserved_balance）を除いた残高から行う
 func (ds *UserDataSourceImpl) UpdateBalanceWithLock(ctx context.Context, userID uuid.UUID, amount int64, isDeduct bool) error {
 	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
 
@@ -235,14 +283,14 @@ func (ds *UserDataSourceImpl) UpdateBalanceWithLock(ctx context.Context, userID
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("user not found")
+			return entities.ErrUserNotFound
 		}
 		return err
 	}
 
-	// 残高チェック（減算の場合）
-	if isDeduct && model.Balance < amount {
-		return errors.New("insufficient balance")
+	// 残高チェック（減算の場合、確保中の分は使えない）
+	if available := model.Balance - model.ReservedBalance; isDeduct && available < amount {
+		return entities.ErrInsufficientBalance.WithParams(map[string]interface{}{"balance": available, "required": amount})
 	}
 
 	// 残高更新
@@ -268,8 +316,13 @@ func (ds *UserDataSourceImpl) UpdateBalanceWithLock(ctx context.Context, userID
 	return err
 }
 
+// balanceUpdateBatchSize は1文でロック・更新するユーザー数の上限（プレースホルダの数を抑えるため）
+const balanceUpdateBatchSize = 500
+
 // UpdateBalancesWithLock は複数ユーザーの残高を一括更新（悲観的ロック、デッドロック回避）
 // デッドロック回避のために、常にUUID順（小さい順）にSELECT FOR UPDATEを実行します
+// ロックはID順に並べた1文のSELECT FOR UPDATEで、更新はユーザーごとの増減をCASE式で加える1文のUPDATEで行う（ユーザー数によらず往復は2回）
+// 同じユーザーへの更新が複数あれば、渡された順に残高を確かめながら適用する
 func (ds *UserDataSourceImpl) UpdateBalancesWithLock(ctx context.Context, updates []dsmysql.BalanceUpdate) error {
 	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
 
@@ -277,38 +330,60 @@ func (ds *UserDataSourceImpl) UpdateBalancesWithLock(ctx context.Context, update
 		return errors.New("no updates provided")
 	}
 
-	// ID順にソート（デッドロック回避のため）
+	// ID順にソート（デッドロック回避のため。UUIDのバイト順はPostgreSQLのuuid型の順序と同じ）
 	sortedUpdates := make([]dsmysql.BalanceUpdate, len(updates))
 	copy(sortedUpdates, updates)
-
-	// UUID文字列で比較してソート
-	for i := 0; i < len(sortedUpdates)-1; i++ {
-		for j := i + 1; j < len(sortedUpdates); j++ {
-			if sortedUpdates[j].UserID.String() < sortedUpdates[i].UserID.String() {
-				sortedUpdates[i], sortedUpdates[j] = sortedUpdates[j], sortedUpdates[i]
-			}
+	sort.SliceStable(sortedUpdates, func(i, j int) bool {
+		return bytes.Compare(sortedUpdates[i].UserID[:], sortedUpdates[j].UserID[:]) < 0
+	})
+	userIDs := make([]uuid.UUID, 0, len(sortedUpdates))
+	for _, update := range sortedUpdates {
+		if len(userIDs) == 0 || userIDs[len(userIDs)-1] != update.UserID {
+			userIDs = append(userIDs, update.UserID)
 		}
 	}
 
-	// ソート順にロックを取得し、残高を更新
-	for _, update := range sortedUpdates {
-		var model UserModel
-
-		// SELECT FOR UPDATE で行ロック
+	// ID順にロックを取得（ORDER BYで並べた順に行をロックする）
+	balances := make(map[uuid.UUID]*UserModel, len(userIDs))
+	for _, chunk := range chunkUserIDs(userIDs) {
+		var models []UserModel
 		err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
-			Where("id = ?", update.UserID).
-			First(&model).Error
-
+			Select("id", "balance", "reserved_balance").
+			Where("id IN ?", chunk).
+			Order("id ASC").
+			Find(&models).Error
 		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return errors.New("user not found")
-			}
 			return err
 		}
+		for i := range models {
+			userID, err := uuid.Parse(models[i].ID)
+			if err != nil {
+				return err
+			}
+			balances[userID] = &models[i]
+		}
+	}
+	if len(balances) != len(userIDs) {
+		return entities.ErrUserNotFound
+	}
+
+	// 残高を計算（書き込む前にすべて確かめるので、1件でも失敗すればどの残高も変えない）
+	deltas := make(map[uuid.UUID]*balanceDelta, len(userIDs))
+	for _, update := range sortedUpdates {
+		model := balances[update.UserID]
+		delta, ok := deltas[update.UserID]
+		if !ok {
+			delta = &balanceDelta{}
+			deltas[update.UserID] = delta
+		}
 
-		// 残高チェック（減算の場合）
-		if update.IsDeduct && model.Balance < update.Amount {
-			return errors.New("insufficient balance")
+		// 残高チェック（減算の場合。確保中の分は、その確保を使う減算でなければ使えない）
+		reserved := model.ReservedBalance - update.Reserved
+		if reserved < 0 {
+			return errors.New("reserved balance cannot be negative")
+		}
+		if update.IsDeduct && model.Balance-reserved < update.Amount {
+			return entities.ErrInsufficientBalance
 		}
 
 		// 残高更新
@@ -324,13 +399,23 @@ func (ds *UserDataSourceImpl) UpdateBalancesWithLock(ctx context.Context, update
 			return errors.New("balance cannot be negative")
 		}
 
-		// 更新実行
-		err = db.Model(&model).Updates(map[string]interface{}{
-			"balance":    newBalance,
-			"version":    gorm.Expr("version + 1"),
-			"updated_at": time.Now(),
-		}).Error
+		delta.balance += newBalance - model.Balance
+		delta.reserved += reserved - model.ReservedBalance
+		model.Balance = newBalance
+		model.ReservedBalance = reserved
+	}
 
+	// 更新実行
+	now := time.Now()
+	for _, chunk := range chunkUserIDs(userIDs) {
+		err := db.Model(&UserModel{}).
+			Where("id IN ?", chunk).
+			Updates(map[string]interface{}{
+				"balance":          deltaCase("balance", chunk, func(d *balanceDelta) int64 { return d.balance }, deltas),
+				"reserved_balance": deltaCase("reserved_balance", chunk, func(d *balanceDelta) int64 { return d.reserved }, deltas),
+				"version":          gorm.Expr("version + 1"),
+				"updated_at":       now,
+			}).Error
 		if err != nil {
 			return err
 		}
@@ -339,9 +424,82 @@ func (ds *UserDataSourceImpl) UpdateBalancesWithLock(ctx context.Context, update
 	return nil
 }
 
+// chunkUserIDs はユーザーIDをbalanceUpdateBatchSize件ずつに分ける（ID順は保つ）
+func chunkUserIDs(userIDs []uuid.UUID) [][]uuid.UUID {
+	chunks := make([][]uuid.UUID, 0, (len(userIDs)+balanceUpdateBatchSize-1)/balanceUpdateBatchSize)
+	for start := 0; start < len(userIDs); start += balanceUpdateBatchSize {
+		end := start + balanceUpdateBatchSize
+		if end > len(userIDs) {
+			end = len(userIDs)
+		}
+		chunks = append(chunks, userIDs[start:end])
+	}
+	return chunks
+}
+
+// balanceDelta はUpdateBalancesWithLockでのユーザーごとの残高・確保中の額の増減
+type balanceDelta struct {
+	balance  int64
+	reserved int64
+}
+
+// deltaCase は column + CASE id WHEN ? THEN ? ... END の式を作る
+// 計算した値で上書きせず増減を加えるので、トランザクションの外で呼ばれても他の更新を消さない
+// プレースホルダだけのCASEはPostgreSQLがtext型と推論するため、値はBIGINTにキャストする
+func deltaCase(column string, userIDs []uuid.UUID, value func(*balanceDelta) int64, deltas map[uuid.UUID]*balanceDelta) clause.Expr {
+	var sql strings.Builder
+	args := make([]interface{}, 0, len(userIDs)*2)
+	sql.WriteString(column + " + CASE id")
+	for _, userID := range userIDs {
+		sql.WriteString(" WHEN ? THEN CAST(? AS BIGINT)")
+		args = append(args, userID, value(deltas[userID]))
+	}
+	sql.WriteString(" END")
+	return gorm.Expr(sql.String(), args...)
+}
+
+// UpdateReservedBalanceWithLock は確保中の額を増減（悲観的ロック: SELECT FOR UPDATE）
+// 確保は使える残高（残高 - 確保中の額）の範囲で行う
+func (ds *UserDataSourceImpl) UpdateReservedBalanceWithLock(ctx context.Context, userID uuid.UUID, amount int64, isRelease bool) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+
+	var model UserModel
+
+	// SELECT FOR UPDATE で行ロック
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", userID).
+		First(&model).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.ErrUserNotFound
+		}
+		return err
+	}
+
+	reserved := model.ReservedBalance
+	if isRelease {
+		reserved -= amount
+	} else {
+		if available := model.Balance - model.ReservedBalance; available < amount {
+			return entities.ErrInsufficientBalance.WithParams(map[string]interface{}{"balance": available, "required": amount})
+		}
+		reserved += amount
+	}
+	if reserved < 0 {
+		return errors.New("reserved balance cannot be negative")
+	}
+
+	return db.Model(&model).Updates(map[string]interface{}{
+		"reserved_balance": reserved,
+		"version":          gorm.Expr("version + 1"),
+		"updated_at":       time.Now(),
+	}).Error
+}
+
 // SelectList はユーザー一覧を取得
 func (ds *UserDataSourceImpl) SelectList(ctx context.Context, offset, limit int) ([]*entities.User, error) {
-	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	db := infrapostgres.GetReadDB(ctx, ds.db)
 	var models []UserModel
 
 	err := db.
@@ -364,7 +522,7 @@ func (ds *UserDataSourceImpl) SelectList(ctx context.Context, offset, limit int)
 
 // Count はユーザー総数を取得
 func (ds *UserDataSourceImpl) Count(ctx context.Context) (int64, error) {
-	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	db := infrapostgres.GetReadDB(ctx, ds.db)
 	var count int64
 	err := db.Model(&UserModel{}).Count(&count).Error
 	return count, err
@@ -382,19 +540,32 @@ func (ds *UserDataSourceImpl) applySearchCondition(db *gorm.DB, search string) *
 		return db
 	}
 	pattern := "%" + search + "%"
+	like := "ILIKE"
+	if isSQLite(db) {
+		like = "LIKE" // SQLiteのLIKEは大文字小文字を区別しない
+	}
 	return db.Where(
-		"username ILIKE ? OR display_name ILIKE ? OR CAST(id AS TEXT) ILIKE ?",
+		"username "+like+" ? OR display_name "+like+" ? OR CAST(id AS TEXT) "+like+" ?",
 		pattern, pattern, pattern,
 	)
 }
 
+// applyDepartmentCondition は部署の絞り込み条件を適用（空なら絞り込まない）
+func applyDepartmentCondition(db *gorm.DB, departmentIDs []uuid.UUID) *gorm.DB {
+	if len(departmentIDs) == 0 {
+		return db
+	}
+	return db.Where("department_id IN ?", departmentIDs)
+}
+
 // SelectListWithSearch は検索・ソート付きでユーザー一覧を取得
-func (ds *UserDataSourceImpl) SelectListWithSearch(ctx context.Context, search string, sortBy string, sortOrder string, offset, limit int) ([]*entities.User, error) {
-	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+func (ds *UserDataSourceImpl) SelectListWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID, sortBy string, sortOrder string, offset, limit int) ([]*entities.User, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
 	query := db.Model(&UserModel{})
 
 	// 検索条件適用
 	query = ds.applySearchCondition(query, search)
+	query = applyDepartmentCondition(query, departmentIDs)
 
 	// ソート（ホワイトリスト方式で安全に）
 	allowedSortColumns := map[string]string{
@@ -428,10 +599,11 @@ func (ds *UserDataSourceImpl) SelectListWithSearch(ctx context.Context, search s
 }
 
 // CountWithSearch は検索条件付きでユーザー総数を取得
-func (ds *UserDataSourceImpl) CountWithSearch(ctx context.Context, search string) (int64, error) {
-	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+func (ds *UserDataSourceImpl) CountWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID) (int64, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
 	query := db.Model(&UserModel{})
 	query = ds.applySearchCondition(query, search)
+	query = applyDepartmentCondition(query, departmentIDs)
 	var count int64
 	err := query.Count(&count).Error
 	return count, err
@@ -0,0 +1,159 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+)
+
+// UserTierDataSource は会員ランクのデータソース（usersテーブルのランク列だけを扱う）
+type UserTierDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewUserTierDataSource は新しいUserTierDataSourceを作成
+func NewUserTierDataSource(db infrapostgres.DB) *UserTierDataSource {
+	return &UserTierDataSource{db: db}
+}
+
+// tierActivitySQL はユーザーごとの獲得ポイントと継続中の連続チェックイン日数を集計する
+// 獲得ポイントは管理者・システムからの付与だけを数え、ユーザー間の送金は含めない
+// 連続日数は bonus_date から連番を引いた値が同じ日をひと続きとみなし（gaps and islands）、
+// 最終日がstreakAsOfの前日以降のものだけを継続中とする
+const tierActivitySQL = `
+	WITH earned AS (
+		SELECT to_user_id AS user_id, SUM(amount) AS earned_points
+		FROM transactions
+		WHERE status = 'completed'
+			AND transaction_type IN ('admin_grant', 'system_grant')
+			AND to_user_id IS NOT NULL
+			AND created_at >= @earned_since
+		GROUP BY to_user_id
+	), runs AS (
+		SELECT user_id, MAX(bonus_date) AS last_day, COUNT(*) AS streak
+		FROM (
+			SELECT user_id, bonus_date,
+				bonus_date - (ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY bonus_date))::int AS grp
+			FROM daily_bonuses
+			WHERE bonus_date <= @streak_as_of
+		) d
+		GROUP BY user_id, grp
+	), streaks AS (
+		SELECT user_id, MAX(streak) AS check_in_streak
+		FROM runs
+		WHERE last_day >= CAST(@streak_as_of AS date) - 1
+		GROUP BY user_id
+	)
+	SELECT u.id AS user_id,
+		COALESCE(e.earned_points, 0) AS earned_points,
+		COALESCE(s.check_in_streak, 0) AS check_in_streak
+	FROM users u
+	LEFT JOIN earned e ON e.user_id = u.id
+	LEFT JOIN streaks s ON s.user_id = u.id`
+
+type tierActivityRow struct {
+	UserID        uuid.UUID `gorm:"column:user_id"`
+	EarnedPoints  int64     `gorm:"column:earned_points"`
+	CheckInStreak int       `gorm:"column:check_in_streak"`
+}
+
+func tierActivityArgs(earnedSince, streakAsOf time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"earned_since": earnedSince,
+		"streak_as_of": streakAsOf.Format("2006-01-02"),
+	}
+}
+
+// SelectActivities は有効な全ユーザーの利用状況を取得
+func (ds *UserTierDataSource) SelectActivities(ctx context.Context, earnedSince, streakAsOf time.Time) ([]*entities.TierActivity, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+
+	args := tierActivityArgs(earnedSince, streakAsOf)
+	tenant := namedTenantCondition(ctx, "u.tenant_id", args)
+	var rows []tierActivityRow
+	err := db.Raw(tierActivitySQL+`
+		WHERE u.is_active = true`+tenant+`
+		ORDER BY u.id`,
+		args).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	activities := make([]*entities.TierActivity, 0, len(rows))
+	for _, r := range rows {
+		activities = append(activities, &entities.TierActivity{
+			UserID:        r.UserID,
+			EarnedPoints:  r.EarnedPoints,
+			CheckInStreak: r.CheckInStreak,
+		})
+	}
+	return activities, nil
+}
+
+// SelectActivity は1ユーザーの利用状況を取得
+func (ds *UserTierDataSource) SelectActivity(ctx context.Context, userID uuid.UUID, earnedSince, streakAsOf time.Time) (*entities.TierActivity, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+
+	args := tierActivityArgs(earnedSince, streakAsOf)
+	args["user_id"] = userID
+
+	var rows []tierActivityRow
+	err := db.Raw(tierActivitySQL+`
+		WHERE u.id = @user_id`, args).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, entities.ErrUserNotFound
+	}
+
+	return &entities.TierActivity{
+		UserID:        rows[0].UserID,
+		EarnedPoints:  rows[0].EarnedPoints,
+		CheckInStreak: rows[0].CheckInStreak,
+	}, nil
+}
+
+// UpdateComputedTier は判定したランクを保存（固定中・変化なしのユーザーは更新しない）
+func (ds *UserTierDataSource) UpdateComputedTier(ctx context.Context, userID uuid.UUID, tier entities.UserTier, at time.Time) (bool, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+
+	result := db.Model(&UserModel{}).
+		Where("id = ? AND tier_overridden = false AND tier <> ?", userID, string(tier)).
+		Updates(map[string]interface{}{
+			"tier":            string(tier),
+			"tier_updated_at": at,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateOverride は管理者によるランクの固定を設定・解除
+func (ds *UserTierDataSource) UpdateOverride(ctx context.Context, userID uuid.UUID, tier *entities.UserTier, at time.Time) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+
+	updates := map[string]interface{}{"tier_overridden": false}
+	if tier != nil {
+		updates = map[string]interface{}{
+			"tier":            string(*tier),
+			"tier_overridden": true,
+			"tier_updated_at": at,
+		}
+	}
+
+	result := db.Model(&UserModel{}).Where("id = ?", userID).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrUserNotFound
+	}
+	return nil
+}
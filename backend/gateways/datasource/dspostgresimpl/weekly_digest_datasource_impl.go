@@ -0,0 +1,86 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/google/uuid"
+)
+
+// WeeklyDigestDataSource は週次のまとめメールのデータソース
+type WeeklyDigestDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewWeeklyDigestDataSource は新しいWeeklyDigestDataSourceを作成
+func NewWeeklyDigestDataSource(db infrapostgres.DB) *WeeklyDigestDataSource {
+	return &WeeklyDigestDataSource{db: db}
+}
+
+// SelectRecipientIDs はafterより後の送信対象のユーザーIDを昇順にlimit件取得
+// 有効でメールアドレスがあり、通知設定でメールと週次のまとめを止めていない（設定の行が無いユーザーを含む）ユーザーが対象
+func (ds *WeeklyDigestDataSource) SelectRecipientIDs(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	tenant, tenantArgs := tenantCondition(ctx, "u.tenant_id")
+	args := append([]interface{}{after, true, true, true}, tenantArgs...)
+	var ids []uuid.UUID
+	err := db.Raw(`
+		SELECT u.id FROM users u
+		LEFT JOIN notification_preferences p ON p.user_id = u.id
+		WHERE u.id > ? AND u.is_active = ? AND u.email <> ''
+			AND (p.user_id IS NULL OR (p.email_enabled = ? AND p.weekly_digest = ?))`+tenant+`
+		ORDER BY u.id ASC
+		LIMIT ?`, append(args, limit)...).
+		Scan(&ids).Error
+	return ids, err
+}
+
+// weeklyDigestActivityRow は期間中の増減の読み出し用
+type weeklyDigestActivityRow struct {
+	UserID uuid.UUID
+	Earned int64
+	Spent  int64
+}
+
+// SelectActivity はユーザーごとに[from, to)に作成された確定済みの取引の獲得・使用の合計をユーザーID順に取得
+// 残高の補正は含めず、失効は使用に数えない
+func (ds *WeeklyDigestDataSource) SelectActivity(ctx context.Context, userIDs []uuid.UUID, from, to time.Time) ([]*entities.WeeklyDigestActivity, error) {
+	if len(userIDs) == 0 {
+		return []*entities.WeeklyDigestActivity{}, nil
+	}
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+
+	var rows []weeklyDigestActivityRow
+	err := db.Raw(`
+		SELECT
+			u.id AS user_id,
+			COALESCE((
+				SELECT SUM(t.amount) FROM transactions t
+				WHERE t.to_user_id = u.id AND t.status = ? AND t.transaction_type <> ?
+					AND t.created_at >= ? AND t.created_at < ?
+			), 0) AS earned,
+			COALESCE((
+				SELECT SUM(t.amount) FROM transactions t
+				WHERE t.from_user_id = u.id AND t.status = ? AND t.transaction_type NOT IN ?
+					AND t.created_at >= ? AND t.created_at < ?
+			), 0) AS spent
+		FROM users u
+		WHERE u.id IN ?
+		ORDER BY u.id ASC`,
+		entities.TransactionStatusCompleted, entities.TransactionTypeBalanceCorrection, from, to,
+		entities.TransactionStatusCompleted,
+		[]entities.TransactionType{entities.TransactionTypeBalanceCorrection, entities.TransactionTypeSystemExpire}, from, to,
+		userIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	activities := make([]*entities.WeeklyDigestActivity, len(rows))
+	for i, row := range rows {
+		activities[i] = &entities.WeeklyDigestActivity{UserID: row.UserID, Earned: row.Earned, Spent: row.Spent}
+	}
+	return activities, nil
+}
@@ -0,0 +1,85 @@
+package dspostgresimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+)
+
+// WorkerLeaseModel はワーカーのリーダーのGORMモデル
+type WorkerLeaseModel struct {
+	Name          string    `gorm:"type:varchar(50);primary_key"`
+	Holder        string    `gorm:"type:varchar(255);not null"`
+	AcquiredAt    time.Time `gorm:"type:timestamptz;not null"`
+	RenewedAt     time.Time `gorm:"type:timestamptz;not null"`
+	ExpiresAt     time.Time `gorm:"type:timestamptz;not null"`
+	LeaderChanges int64     `gorm:"not null;default:0"`
+}
+
+// TableName はテーブル名を指定
+func (WorkerLeaseModel) TableName() string {
+	return "worker_leases"
+}
+
+// WorkerLeaseDataSource はワーカーのリーダーのデータソース
+type WorkerLeaseDataSource struct {
+	db infrapostgres.DB
+}
+
+// NewWorkerLeaseDataSource は新しいWorkerLeaseDataSourceを作成
+func NewWorkerLeaseDataSource(db infrapostgres.DB) *WorkerLeaseDataSource {
+	return &WorkerLeaseDataSource{db: db}
+}
+
+// Upsert は行がなければ作成し、holderがリーダーか期限切れの場合だけ更新する（更新できたかを返す）
+// 1文の条件付きUPSERTなので、同時に立候補しても更新できるのは1インスタンスだけ
+func (ds *WorkerLeaseDataSource) Upsert(ctx context.Context, name, holder string, now, expiresAt time.Time) (bool, error) {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	result := db.Exec(`
+		INSERT INTO worker_leases (name, holder, acquired_at, renewed_at, expires_at, leader_changes)
+		VALUES (?, ?, ?, ?, ?, 1)
+		ON CONFLICT (name) DO UPDATE SET
+			holder = EXCLUDED.holder,
+			acquired_at = CASE WHEN worker_leases.holder = EXCLUDED.holder THEN worker_leases.acquired_at ELSE EXCLUDED.acquired_at END,
+			renewed_at = EXCLUDED.renewed_at,
+			expires_at = EXCLUDED.expires_at,
+			leader_changes = worker_leases.leader_changes + CASE WHEN worker_leases.holder = EXCLUDED.holder THEN 0 ELSE 1 END
+		WHERE worker_leases.holder = EXCLUDED.holder OR worker_leases.expires_at <= EXCLUDED.renewed_at`,
+		name, holder, now, now, expiresAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateExpired はholderがリーダーなら期限をnowにする
+func (ds *WorkerLeaseDataSource) UpdateExpired(ctx context.Context, name, holder string, now time.Time) error {
+	db := infrapostgres.GetDB(ctx, ds.db.GetDB())
+	return db.Model(&WorkerLeaseModel{}).
+		Where("name = ? AND holder = ? AND expires_at > ?", name, holder, now).
+		Update("expires_at", now).Error
+}
+
+// SelectList は全ワーカーのリーダーを名前順に取得
+func (ds *WorkerLeaseDataSource) SelectList(ctx context.Context) ([]*entities.WorkerLease, error) {
+	db := infrapostgres.GetReadDB(ctx, ds.db)
+	var models []WorkerLeaseModel
+	if err := db.Order("name ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	leases := make([]*entities.WorkerLease, len(models))
+	for i := range models {
+		m := &models[i]
+		leases[i] = &entities.WorkerLease{
+			Name:          m.Name,
+			Holder:        m.Holder,
+			AcquiredAt:    m.AcquiredAt,
+			RenewedAt:     m.RenewedAt,
+			ExpiresAt:     m.ExpiresAt,
+			LeaderChanges: m.LeaderChanges,
+		}
+	}
+	return leases, nil
+}
@@ -0,0 +1,99 @@
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// adminApprovalExpiryLeaseName はリーダー選出で使うワーカー名
+const adminApprovalExpiryLeaseName = "admin_approval_expiry"
+
+// AdminApprovalExpiryWorker は承認されないまま期限を過ぎた大口の付与・減算を期限切れにするワーカー
+type AdminApprovalExpiryWorker struct {
+	approvalUC inputport.AdminApprovalInputPort
+	logger     entities.Logger
+	interval   time.Duration
+	stopCh     chan struct{}
+
+	// メンテナンス中は処理しない（期限切れの操作は承認もできないため、再開後の実行で処理すればよい）
+	maintenance inputport.MaintenanceInputPort
+
+	// 複数インスタンスで動かす場合は、リーダーのインスタンスだけが処理する
+	leader *LeaderElector
+}
+
+// NewAdminApprovalExpiryWorker は新しいAdminApprovalExpiryWorkerを作成
+func NewAdminApprovalExpiryWorker(
+	approvalUC inputport.AdminApprovalInputPort,
+	logger entities.Logger,
+) *AdminApprovalExpiryWorker {
+	return &AdminApprovalExpiryWorker{
+		approvalUC: approvalUC,
+		logger:     logger,
+		interval:   time.Hour,
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// WithMaintenance はメンテナンス中に処理を止めるよう設定する
+func (w *AdminApprovalExpiryWorker) WithMaintenance(maintenance inputport.MaintenanceInputPort) *AdminApprovalExpiryWorker {
+	w.maintenance = maintenance
+	return w
+}
+
+// WithLeaderElection は複数インスタンスのうちリーダーだけが処理するよう設定する
+func (w *AdminApprovalExpiryWorker) WithLeaderElection(leader *LeaderElector) *AdminApprovalExpiryWorker {
+	leader.Register(adminApprovalExpiryLeaseName)
+	w.leader = leader
+	return w
+}
+
+// Start はワーカーを開始
+func (w *AdminApprovalExpiryWorker) Start() {
+	w.logger.Info("AdminApprovalExpiryWorker started", entities.NewField("interval", w.interval.String()))
+
+	go func() {
+		w.run()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				w.run()
+			case <-w.stopCh:
+				w.logger.Info("AdminApprovalExpiryWorker stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop はワーカーを停止
+func (w *AdminApprovalExpiryWorker) Stop() {
+	close(w.stopCh)
+}
+
+func (w *AdminApprovalExpiryWorker) run() {
+	ctx := context.Background()
+	if w.leader != nil && !w.leader.IsLeader(adminApprovalExpiryLeaseName) {
+		return
+	}
+	if w.maintenance != nil && w.maintenance.IsActive(ctx) {
+		w.logger.Info("AdminApprovalExpiryWorker: paused during maintenance")
+		return
+	}
+
+	// 1回の実行で処理しきれない場合は次の実行に回す
+	expired, err := w.approvalUC.ExpireStaleActions(ctx, time.Now())
+	if err != nil {
+		w.logger.Error("Failed to expire pending admin actions", entities.NewField("error", err))
+	}
+	if expired > 0 {
+		w.logger.Info("Expired pending admin actions", entities.NewField("count", expired))
+	}
+}
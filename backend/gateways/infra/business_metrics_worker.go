@@ -0,0 +1,77 @@
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// BusinessMetricsWorker は /metrics で公開する業務の指標を定期的に集計し直すワーカー
+// 指標は各インスタンスの /metrics が返すため、リーダー選出はせずすべてのインスタンスで集計する
+// 集計は読み取りだけなのでメンテナンス中も止めない
+type BusinessMetricsWorker struct {
+	metricsUC inputport.BusinessMetricsInputPort
+	logger    entities.Logger
+	interval  time.Duration
+	stopCh    chan struct{}
+}
+
+// NewBusinessMetricsWorker は新しいBusinessMetricsWorkerを作成
+func NewBusinessMetricsWorker(
+	metricsUC inputport.BusinessMetricsInputPort,
+	logger entities.Logger,
+) *BusinessMetricsWorker {
+	return &BusinessMetricsWorker{
+		metricsUC: metricsUC,
+		logger:    logger,
+		interval:  entities.DefaultBusinessMetricsInterval,
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// WithInterval は集計の間隔を設定する（0以下なら既定の間隔のまま）
+func (w *BusinessMetricsWorker) WithInterval(interval time.Duration) *BusinessMetricsWorker {
+	if interval > 0 {
+		w.interval = interval
+	}
+	return w
+}
+
+// Start はワーカーを開始（起動直後に1回集計し、以降intervalごと）
+func (w *BusinessMetricsWorker) Start() {
+	w.logger.Info("BusinessMetricsWorker started", entities.NewField("interval", w.interval.String()))
+
+	go func() {
+		w.run()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				w.run()
+			case <-w.stopCh:
+				w.logger.Info("BusinessMetricsWorker stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop はワーカーを停止
+func (w *BusinessMetricsWorker) Stop() {
+	close(w.stopCh)
+}
+
+func (w *BusinessMetricsWorker) run() {
+	// 集計が次の回まで長引かないよう、間隔を上限にする
+	ctx, cancel := context.WithTimeout(context.Background(), w.interval)
+	defer cancel()
+
+	if err := w.metricsUC.RefreshBusinessMetrics(ctx, time.Now()); err != nil {
+		w.logger.Error("Failed to refresh business metrics", entities.NewField("error", err))
+	}
+}
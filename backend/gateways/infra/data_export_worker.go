@@ -0,0 +1,108 @@
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// dataExportBatchSize は1回の実行で処理する依頼の上限
+const dataExportBatchSize = 10
+
+// dataExportLeaseName はリーダー選出で使うワーカー名
+const dataExportLeaseName = "data_export"
+
+// DataExportWorker は個人データエクスポートを作成し、期限切れのファイルを削除するワーカー
+type DataExportWorker struct {
+	dataExportUC inputport.DataExportInputPort
+	logger       entities.Logger
+	interval     time.Duration
+	stopCh       chan struct{}
+
+	maintenance inputport.MaintenanceInputPort
+
+	// 複数インスタンスで動かす場合は、リーダーのインスタンスだけが処理する
+	leader *LeaderElector
+}
+
+// NewDataExportWorker は新しいDataExportWorkerを作成
+func NewDataExportWorker(
+	dataExportUC inputport.DataExportInputPort,
+	logger entities.Logger,
+) *DataExportWorker {
+	return &DataExportWorker{
+		dataExportUC: dataExportUC,
+		logger:       logger,
+		interval:     1 * time.Minute,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// WithMaintenance はメンテナンス中に処理を止めるよう設定する
+func (w *DataExportWorker) WithMaintenance(maintenance inputport.MaintenanceInputPort) *DataExportWorker {
+	w.maintenance = maintenance
+	return w
+}
+
+// WithLeaderElection は複数インスタンスのうちリーダーだけが処理するよう設定する
+func (w *DataExportWorker) WithLeaderElection(leader *LeaderElector) *DataExportWorker {
+	leader.Register(dataExportLeaseName)
+	w.leader = leader
+	return w
+}
+
+// Start はワーカーを開始
+func (w *DataExportWorker) Start() {
+	w.logger.Info("DataExportWorker started", entities.NewField("interval", w.interval.String()))
+
+	go func() {
+		w.run()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				w.run()
+			case <-w.stopCh:
+				w.logger.Info("DataExportWorker stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop はワーカーを停止
+func (w *DataExportWorker) Stop() {
+	close(w.stopCh)
+}
+
+func (w *DataExportWorker) run() {
+	ctx := context.Background()
+	if w.leader != nil && !w.leader.IsLeader(dataExportLeaseName) {
+		return
+	}
+	if w.maintenance != nil && w.maintenance.IsActive(ctx) {
+		w.logger.Info("DataExportWorker: paused during maintenance")
+		return
+	}
+
+	completed, err := w.dataExportUC.ProcessPendingExports(ctx, dataExportBatchSize)
+	if err != nil {
+		w.logger.Error("Failed to process data exports", entities.NewField("error", err))
+	}
+	if completed > 0 {
+		w.logger.Info("Created data exports", entities.NewField("count", completed))
+	}
+
+	cleaned, err := w.dataExportUC.CleanupExpiredExports(ctx, dataExportBatchSize)
+	if err != nil {
+		w.logger.Error("Failed to clean up expired data exports", entities.NewField("error", err))
+	}
+	if cleaned > 0 {
+		w.logger.Info("Deleted expired data exports", entities.NewField("count", cleaned))
+	}
+}
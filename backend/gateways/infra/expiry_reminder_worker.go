@@ -0,0 +1,103 @@
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// expiryReminderBatchSize は1回の実行で読み込むポイントバッチの上限
+const expiryReminderBatchSize = 500
+
+// expiryReminderLeaseName はリーダー選出で使うワーカー名
+const expiryReminderLeaseName = "expiry_reminder"
+
+// ExpiryReminderWorker は有効期限が近いポイントをプッシュ通知するワーカー
+// 通知済みのバッチは記録されるため、1バッチにつき1度だけ通知する
+type ExpiryReminderWorker struct {
+	notificationUC inputport.NotificationInputPort
+	logger         entities.Logger
+	interval       time.Duration
+	stopCh         chan struct{}
+
+	// メンテナンス中は通知しない（再開後の実行でまとめて処理される）
+	maintenance inputport.MaintenanceInputPort
+
+	// 複数インスタンスで動かす場合は、リーダーのインスタンスだけが処理する
+	leader *LeaderElector
+}
+
+// NewExpiryReminderWorker は新しいExpiryReminderWorkerを作成
+func NewExpiryReminderWorker(
+	notificationUC inputport.NotificationInputPort,
+	logger entities.Logger,
+) *ExpiryReminderWorker {
+	return &ExpiryReminderWorker{
+		notificationUC: notificationUC,
+		logger:         logger,
+		interval:       1 * time.Hour,
+		stopCh:         make(chan struct{}),
+	}
+}
+
+// WithMaintenance はメンテナンス中に処理を止めるよう設定する
+func (w *ExpiryReminderWorker) WithMaintenance(maintenance inputport.MaintenanceInputPort) *ExpiryReminderWorker {
+	w.maintenance = maintenance
+	return w
+}
+
+// WithLeaderElection は複数インスタンスのうちリーダーだけが処理するよう設定する
+func (w *ExpiryReminderWorker) WithLeaderElection(leader *LeaderElector) *ExpiryReminderWorker {
+	leader.Register(expiryReminderLeaseName)
+	w.leader = leader
+	return w
+}
+
+// Start はワーカーを開始
+func (w *ExpiryReminderWorker) Start() {
+	w.logger.Info("ExpiryReminderWorker started", entities.NewField("interval", w.interval.String()))
+
+	go func() {
+		w.run()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				w.run()
+			case <-w.stopCh:
+				w.logger.Info("ExpiryReminderWorker stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop はワーカーを停止
+func (w *ExpiryReminderWorker) Stop() {
+	close(w.stopCh)
+}
+
+func (w *ExpiryReminderWorker) run() {
+	ctx := context.Background()
+	if w.leader != nil && !w.leader.IsLeader(expiryReminderLeaseName) {
+		return
+	}
+	if w.maintenance != nil && w.maintenance.IsActive(ctx) {
+		w.logger.Info("ExpiryReminderWorker: paused during maintenance")
+		return
+	}
+
+	// 1回の実行で処理しきれない場合は次の実行に回す
+	notified, err := w.notificationUC.SendExpiryReminders(ctx, time.Now(), expiryReminderBatchSize)
+	if err != nil {
+		w.logger.Error("Failed to send expiry reminders", entities.NewField("error", err))
+	}
+	if notified > 0 {
+		w.logger.Info("Sent expiry reminders", entities.NewField("users", notified))
+	}
+}
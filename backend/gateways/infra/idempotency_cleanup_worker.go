@@ -0,0 +1,99 @@
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+// idempotencyCleanupLeaseName はリーダー選出で使うワーカー名
+const idempotencyCleanupLeaseName = "idempotency_cleanup"
+
+// IdempotencyCleanupWorker は保存期間を過ぎたIdempotency-Keyのレスポンスを削除するワーカー
+type IdempotencyCleanupWorker struct {
+	idempotentRequestRepo repository.IdempotentRequestRepository
+	logger                entities.Logger
+	interval              time.Duration
+	stopCh                chan struct{}
+
+	maintenance inputport.MaintenanceInputPort
+
+	// 複数インスタンスで動かす場合は、リーダーのインスタンスだけが処理する
+	leader *LeaderElector
+}
+
+// NewIdempotencyCleanupWorker は新しいIdempotencyCleanupWorkerを作成
+func NewIdempotencyCleanupWorker(
+	idempotentRequestRepo repository.IdempotentRequestRepository,
+	logger entities.Logger,
+) *IdempotencyCleanupWorker {
+	return &IdempotencyCleanupWorker{
+		idempotentRequestRepo: idempotentRequestRepo,
+		logger:                logger,
+		interval:              1 * time.Hour,
+		stopCh:                make(chan struct{}),
+	}
+}
+
+// WithMaintenance はメンテナンス中に掃除を止めるよう設定する
+func (w *IdempotencyCleanupWorker) WithMaintenance(maintenance inputport.MaintenanceInputPort) *IdempotencyCleanupWorker {
+	w.maintenance = maintenance
+	return w
+}
+
+// WithLeaderElection は複数インスタンスのうちリーダーだけが処理するよう設定する
+func (w *IdempotencyCleanupWorker) WithLeaderElection(leader *LeaderElector) *IdempotencyCleanupWorker {
+	leader.Register(idempotencyCleanupLeaseName)
+	w.leader = leader
+	return w
+}
+
+// Start はワーカーを開始
+func (w *IdempotencyCleanupWorker) Start() {
+	w.logger.Info("IdempotencyCleanupWorker started", entities.NewField("interval", w.interval.String()))
+
+	go func() {
+		w.cleanup()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				w.cleanup()
+			case <-w.stopCh:
+				w.logger.Info("IdempotencyCleanupWorker stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop はワーカーを停止
+func (w *IdempotencyCleanupWorker) Stop() {
+	close(w.stopCh)
+}
+
+func (w *IdempotencyCleanupWorker) cleanup() {
+	ctx := context.Background()
+	if w.leader != nil && !w.leader.IsLeader(idempotencyCleanupLeaseName) {
+		return
+	}
+	if w.maintenance != nil && w.maintenance.IsActive(ctx) {
+		w.logger.Info("IdempotencyCleanupWorker: paused during maintenance")
+		return
+	}
+
+	deleted, err := w.idempotentRequestRepo.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		w.logger.Error("Failed to delete expired idempotent requests", entities.NewField("error", err))
+		return
+	}
+	if deleted > 0 {
+		w.logger.Info("Deleted expired idempotent requests", entities.NewField("count", deleted))
+	}
+}
@@ -3,6 +3,7 @@ package infraakerun
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,16 +11,26 @@ import (
 	"time"
 
 	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/infra/infrabreaker"
+	"github.com/gity/point-system/usecases/service"
 	"github.com/google/uuid"
 )
 
-// AkerunConfig はAkerun APIの設定
+// AkerunConfig はAkerun APIの設定（1組織分）
 type AkerunConfig struct {
 	AccessToken    string
 	OrganizationID string
 	BaseURL        string // デフォルト: https://api.akerun.com
+
+	OrganizationName string
+	Doors            []entities.AkerunDoorRule // ボーナスの対象にするドア（空なら全ドア）
+
+	MaxPages int // 1回の取得でたどるページ数の上限（0ならdefaultMaxPages）
 }
 
+// defaultMaxPages は1回の取得でたどるページ数の既定の上限
+const defaultMaxPages = 10
+
 // AccessRecord はAkerun入退室履歴レコード
 type AccessRecord struct {
 	ID         json.Number `json:"id"`
@@ -54,6 +65,7 @@ type accessesResponse struct {
 type AkerunClient struct {
 	config     *AkerunConfig
 	httpClient *http.Client
+	breaker    *infrabreaker.CircuitBreaker // nilなら失敗が続いても呼び出しを止めない
 }
 
 // NewAkerunClient は新しいAkerunClientを作成
@@ -69,8 +81,68 @@ func NewAkerunClient(config *AkerunConfig) *AkerunClient {
 	}
 }
 
+// WithCircuitBreaker はAPIの失敗が続いたら呼び出しを止めるサーキットブレーカーを設定
+func (c *AkerunClient) WithCircuitBreaker(breaker *infrabreaker.CircuitBreaker) *AkerunClient {
+	c.breaker = breaker
+	return c
+}
+
 // GetAccesses は入退室履歴を取得
+// Akerun APIは新しい順に1ページlimit件まで返すため、ページが埋まっている間はid_beforeで次のページをたどる
+// ページの境界で重複した記録はIDで除く
+// MaxPagesページで取り切れなかった場合は、取得できた分とservice.ErrAccessesTruncatedを返す
+// サーキットブレーカーがopenの間はAPIを呼ばずにservice.ErrCircuitOpenを返す
 func (c *AkerunClient) GetAccesses(ctx context.Context, after, before time.Time, limit int) ([]AccessRecord, error) {
+	maxPages := c.config.MaxPages
+	if maxPages <= 0 {
+		maxPages = defaultMaxPages
+	}
+
+	accesses := []AccessRecord{}
+	seen := map[string]bool{}
+	idBefore := ""
+	for page := 1; ; page++ {
+		records, err := c.getPage(ctx, after, before, limit, idBefore)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range records {
+			if id := r.ID.String(); !seen[id] {
+				seen[id] = true
+				accesses = append(accesses, r)
+			}
+		}
+
+		if len(records) < limit {
+			return accesses, nil
+		}
+		next := oldestID(records)
+		if next == "" || (idBefore != "" && !lessID(next, idBefore)) {
+			// 次のページの位置が進まない場合は打ち切る（同じページを取り続けないため）
+			return accesses, nil
+		}
+		if page >= maxPages {
+			return accesses, fmt.Errorf("%d pages of %d accesses: %w", maxPages, limit, service.ErrAccessesTruncated)
+		}
+		idBefore = next
+	}
+}
+
+// getPage は1ページ分の入退室履歴を取得（idBeforeが空なら最新のページ）
+func (c *AkerunClient) getPage(ctx context.Context, after, before time.Time, limit int, idBefore string) ([]AccessRecord, error) {
+	if c.breaker == nil {
+		return c.requestPage(ctx, after, before, limit, idBefore)
+	}
+	var records []AccessRecord
+	err := c.breaker.Execute(func() error {
+		var err error
+		records, err = c.requestPage(ctx, after, before, limit, idBefore)
+		return err
+	})
+	return records, err
+}
+
+func (c *AkerunClient) requestPage(ctx context.Context, after, before time.Time, limit int, idBefore string) ([]AccessRecord, error) {
 	endpoint := fmt.Sprintf("%s/v3/organizations/%s/accesses",
 		c.config.BaseURL, c.config.OrganizationID)
 
@@ -80,6 +152,9 @@ func (c *AkerunClient) GetAccesses(ctx context.Context, after, before time.Time,
 	params.Set("limit", fmt.Sprintf("%d", limit))
 	params.Set("datetime_after", after.In(jst).Format(time.RFC3339))
 	params.Set("datetime_before", before.In(jst).Format(time.RFC3339))
+	if idBefore != "" {
+		params.Set("id_before", idBefore)
+	}
 
 	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
 
@@ -109,6 +184,34 @@ func (c *AkerunClient) GetAccesses(ctx context.Context, after, before time.Time,
 	return result.Accesses, nil
 }
 
+// oldestID はページの中で最も小さい（古い）アクセスID
+func oldestID(records []AccessRecord) string {
+	oldest := ""
+	for _, r := range records {
+		if id := r.ID.String(); id != "" && (oldest == "" || lessID(id, oldest)) {
+			oldest = id
+		}
+	}
+	return oldest
+}
+
+// lessID はアクセスIDを数値として比較する（int64に収まらない桁数があるため文字列のまま比べる）
+func lessID(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+// Organization はポーリングする組織とドアごとのボーナス規則を返す
+func (c *AkerunClient) Organization() entities.AkerunOrganization {
+	return entities.AkerunOrganization{
+		ID:    c.config.OrganizationID,
+		Name:  c.config.OrganizationName,
+		Doors: c.config.Doors,
+	}
+}
+
 // IsConfigured はAkerun APIが設定されているかを返す
 func (c *AkerunClient) IsConfigured() bool {
 	return c.config.AccessToken != "" && c.config.OrganizationID != ""
@@ -118,7 +221,7 @@ func (c *AkerunClient) IsConfigured() bool {
 // インフラ固有のAccessRecordをentities.AccessRecordに変換して返す
 func (c *AkerunClient) FetchAccesses(ctx context.Context, after, before time.Time, limit int) ([]entities.AccessRecord, error) {
 	rawAccesses, err := c.GetAccesses(ctx, after, before, limit)
-	if err != nil {
+	if err != nil && !errors.Is(err, service.ErrAccessesTruncated) {
 		return nil, err
 	}
 
@@ -136,12 +239,18 @@ func (c *AkerunClient) FetchAccesses(ctx context.Context, after, before time.Tim
 		// IDをUUIDに変換（Akerun APIはjson.Numberなので文字列ベースで生成）
 		accessID := uuid.NewSHA1(uuid.NameSpaceURL, []byte(raw.ID.String()))
 
-		result = append(result, entities.AccessRecord{
-			ID:         accessID,
-			UserName:   raw.User.Name,
-			AccessedAt: accessedAt,
-		})
+		record := entities.AccessRecord{
+			ID:             accessID,
+			UserName:       raw.User.Name,
+			AccessedAt:     accessedAt,
+			OrganizationID: c.config.OrganizationID,
+		}
+		if raw.Akerun != nil {
+			record.DoorID = raw.Akerun.ID
+			record.DoorName = raw.Akerun.Name
+		}
+		result = append(result, record)
 	}
 
-	return result, nil
+	return result, err
 }
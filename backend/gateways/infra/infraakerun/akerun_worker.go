@@ -2,35 +2,51 @@ package infraakerun
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/infra"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/gity/point-system/usecases/service"
 )
 
+// akerunLeaseName はリーダー選出で使うワーカー名
+const akerunLeaseName = "akerun"
+
 // AkerunWorker はAkerun入退室ポーリングワーカー
 // ポーリング制御のみを担当し、ビジネスロジックはAkerunBonusInputPortに委譲する
+// 複数の組織を並行してポーリングし、前回ポーリング時刻は組織ごとに独立して進める
 type AkerunWorker struct {
-	gateway       service.AkerunAccessGateway
+	gateways      []service.AkerunAccessGateway
 	interactor    inputport.AkerunBonusInputPort
 	timeProvider  service.TimeProvider
 	logger        entities.Logger
 	interval      time.Duration
 	recoverySleep time.Duration
 	stopCh        chan struct{}
+
+	// メンテナンス中はポーリングしない（再開後は前回ポーリング時刻からリカバリーモードで取り戻す）
+	maintenance inputport.MaintenanceInputPort
+
+	// 複数インスタンスで動かす場合は、リーダーのインスタンスだけが処理する
+	leader *infra.LeaderElector
+
+	// 管理者が依頼した期間の再取得（設定した場合のみ、通常のポーリングとは別のgoroutineで順に処理する）
+	repolls inputport.AkerunRepollInputPort
 }
 
 // NewAkerunWorker は新しいAkerunWorkerを作成
 func NewAkerunWorker(
-	gateway service.AkerunAccessGateway,
+	gateways []service.AkerunAccessGateway,
 	interactor inputport.AkerunBonusInputPort,
 	timeProvider service.TimeProvider,
 	logger entities.Logger,
 ) *AkerunWorker {
 	return &AkerunWorker{
-		gateway:       gateway,
+		gateways:      gateways,
 		interactor:    interactor,
 		timeProvider:  timeProvider,
 		logger:        logger,
@@ -40,14 +56,41 @@ func NewAkerunWorker(
 	}
 }
 
+// WithMaintenance はメンテナンス中にポーリングを止めるよう設定する
+func (w *AkerunWorker) WithMaintenance(maintenance inputport.MaintenanceInputPort) *AkerunWorker {
+	w.maintenance = maintenance
+	return w
+}
+
+// WithRepolls は管理者が依頼した期間の再取得を処理するよう設定する
+func (w *AkerunWorker) WithRepolls(repolls inputport.AkerunRepollInputPort) *AkerunWorker {
+	w.repolls = repolls
+	return w
+}
+
+// WithLeaderElection は複数インスタンスのうちリーダーだけが処理するよう設定する
+func (w *AkerunWorker) WithLeaderElection(leader *infra.LeaderElector) *AkerunWorker {
+	leader.Register(akerunLeaseName)
+	w.leader = leader
+	return w
+}
+
 // Start はポーリングを開始（バックグラウンドgoroutine）
 func (w *AkerunWorker) Start() {
-	if !w.gateway.IsConfigured() {
+	configured := 0
+	for _, gateway := range w.gateways {
+		if gateway.IsConfigured() {
+			configured++
+		}
+	}
+	if configured == 0 {
 		w.logger.Info("Akerun worker: not configured, skipping")
 		return
 	}
 
-	w.logger.Info("Akerun worker: starting polling", entities.NewField("interval", w.interval.String()))
+	w.logger.Info("Akerun worker: starting polling",
+		entities.NewField("interval", w.interval.String()),
+		entities.NewField("organizations", configured))
 
 	go func() {
 		// 起動直後に1回実行
@@ -66,6 +109,24 @@ func (w *AkerunWorker) Start() {
 			}
 		}
 	}()
+
+	if w.repolls != nil {
+		// 再取得は1期間ごとにrecoverySleepだけ待つため、通常のポーリングを止めないよう別のgoroutineで処理する
+		// 同じgoroutineで順に処理するので、1台の中で再取得が重なることはない
+		go func() {
+			ticker := time.NewTicker(repollInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ticker.C:
+					w.runRepoll()
+				case <-w.stopCh:
+					return
+				}
+			}
+		}()
+	}
 }
 
 // Stop はポーリングを停止
@@ -74,48 +135,102 @@ func (w *AkerunWorker) Stop() {
 }
 
 const (
-	// 通常モード
+	// 通常モード（limitは1ページの件数。埋まっていれば次のページもたどる）
 	normalLimit    = 300
 	normalInterval = 5 * time.Minute
 
 	// リカバリモード
-	recoveryLimit        = 720 // 一時間で最高720件までの取得(12回/分 * 60分)
+	recoveryLimit        = 720 // 1ページ720件(12回/分 * 60分)。埋まっていれば次のページもたどる
 	recoveryWindow       = 1 * time.Hour
 	recoveryGapThreshold = 10 * time.Minute // この閾値を超えたらリカバリモード
+
+	// 管理者が依頼した再取得を確認する間隔
+	repollInterval = 1 * time.Minute
 )
 
 // poll は1回のポーリング処理
 func (w *AkerunWorker) poll() {
 	ctx := context.Background()
 
-	// 前回ポーリング時刻を取得
-	lastPolledAt, err := w.interactor.GetLastPolledAt(ctx)
-	if err != nil {
-		w.logger.Error("Akerun worker: failed to get last polled time", entities.NewField("error", err))
+	if w.leader != nil && !w.leader.IsLeader(akerunLeaseName) {
+		return
+	}
+	if w.maintenance != nil && w.maintenance.IsActive(ctx) {
+		w.logger.Info("Akerun worker: paused during maintenance")
 		return
 	}
 
 	now := w.timeProvider.Now()
+
+	// 組織ごとに並行してポーリング（1組織の遅延・エラーが他の組織を止めない）
+	var wg sync.WaitGroup
+	for _, gateway := range w.gateways {
+		if !gateway.IsConfigured() {
+			continue
+		}
+		wg.Add(1)
+		go func(gateway service.AkerunAccessGateway) {
+			defer wg.Done()
+			w.pollOrganization(ctx, gateway, now)
+		}(gateway)
+	}
+	wg.Wait()
+
+	// 付与に失敗したアクセス記録のうち、再試行時刻を迎えたものを再処理
+	if err := w.interactor.RetryFailedAccesses(ctx, now); err != nil {
+		w.logger.Error("Akerun worker: failed to retry failed accesses", entities.NewField("error", err))
+	}
+}
+
+// pollOrganization は1組織分のポーリング処理
+func (w *AkerunWorker) pollOrganization(ctx context.Context, gateway service.AkerunAccessGateway, now time.Time) {
+	orgID := gateway.Organization().ID
+
+	// 組織の前回ポーリング時刻を取得
+	lastPolledAt, err := w.interactor.GetLastPolledAt(ctx, orgID)
+	if err != nil {
+		w.logger.Error("Akerun worker: failed to get last polled time",
+			entities.NewField("organization", orgID),
+			entities.NewField("error", err))
+		return
+	}
+
 	gap := now.Sub(lastPolledAt)
 
 	if gap > recoveryGapThreshold {
 		// リカバリモード: 1時間ウィンドウで分割取得
-		w.pollRecovery(ctx, lastPolledAt, now)
+		w.pollRecovery(ctx, gateway, orgID, lastPolledAt, now)
 	} else {
 		// 通常モード: 一括取得
-		w.pollNormal(ctx, lastPolledAt, now)
+		w.pollNormal(ctx, gateway, orgID, lastPolledAt, now)
 	}
 }
 
 // pollNormal は通常モードのポーリング（5分間隔、limit=300）
-func (w *AkerunWorker) pollNormal(ctx context.Context, after, before time.Time) {
-	accesses, err := w.gateway.FetchAccesses(ctx, after, before, normalLimit)
+func (w *AkerunWorker) pollNormal(ctx context.Context, gateway service.AkerunAccessGateway, orgID string, after, before time.Time) {
+	accesses, err := gateway.FetchAccesses(ctx, after, before, normalLimit)
+	if errors.Is(err, service.ErrAccessesTruncated) {
+		// 取得できた分は処理する（ページ数の上限は設定で引き上げる）
+		w.logger.Warn("Akerun worker: accesses truncated at the page limit, some records may be missed",
+			entities.NewField("organization", orgID),
+			entities.NewField("error", err))
+		err = nil
+	}
+	if errors.Is(err, service.ErrCircuitOpen) {
+		// 失敗が続いている間はAPIを呼ばない（前回ポーリング時刻は進めず、回復後にまとめて取得する）
+		w.logger.Warn("Akerun worker: skipped while the API is failing",
+			entities.NewField("organization", orgID))
+		return
+	}
 	if err != nil {
-		w.logger.Error("Akerun worker: failed to get accesses", entities.NewField("error", err))
+		w.logger.Error("Akerun worker: failed to get accesses",
+			entities.NewField("organization", orgID),
+			entities.NewField("error", err))
 		return
 	}
 
 	w.logger.Info("Akerun worker: fetched accesses",
+		entities.NewField("organization", orgID),
 		entities.NewField("count", len(accesses)),
 		entities.NewField("from", after.Format(time.RFC3339)),
 		entities.NewField("to", before.Format(time.RFC3339)))
@@ -126,17 +241,20 @@ func (w *AkerunWorker) pollNormal(ctx context.Context, after, before time.Time)
 		}
 	}
 
-	if err := w.interactor.UpdateLastPolledAt(ctx, before); err != nil {
-		w.logger.Error("Akerun worker: failed to update last polled time", entities.NewField("error", err))
+	if err := w.interactor.UpdateLastPolledAt(ctx, orgID, before); err != nil {
+		w.logger.Error("Akerun worker: failed to update last polled time",
+			entities.NewField("organization", orgID),
+			entities.NewField("error", err))
 	}
 }
 
 // pollRecovery はリカバリモードのポーリング（1時間ウィンドウ、limit=720）
-func (w *AkerunWorker) pollRecovery(ctx context.Context, lastPolledAt, now time.Time) {
+func (w *AkerunWorker) pollRecovery(ctx context.Context, gateway service.AkerunAccessGateway, orgID string, lastPolledAt, now time.Time) {
 	gap := now.Sub(lastPolledAt)
 	totalWindows := int(gap/recoveryWindow) + 1
 
 	w.logger.Info("Akerun worker: recovery mode started",
+		entities.NewField("organization", orgID),
 		entities.NewField("gap", gap.String()),
 		entities.NewField("lastPolledAt", lastPolledAt.Format(time.RFC3339)),
 		entities.NewField("totalWindows", totalWindows))
@@ -149,36 +267,27 @@ func (w *AkerunWorker) pollRecovery(ctx context.Context, lastPolledAt, now time.
 			end = now
 		}
 
-		accesses, err := w.gateway.FetchAccesses(ctx, cursor, end, recoveryLimit)
+		window := fmt.Sprintf("%d/%d", windowIdx+1, totalWindows)
+		_, err := w.processWindow(ctx, gateway, orgID, cursor, end, window)
+		if errors.Is(err, service.ErrCircuitOpen) {
+			w.logger.Warn("Akerun worker: recovery paused while the API is failing",
+				entities.NewField("organization", orgID),
+				entities.NewField("window", window))
+			return
+		}
 		if err != nil {
 			w.logger.Error("Akerun worker: recovery fetch failed",
-				entities.NewField("window", windowIdx+1),
+				entities.NewField("organization", orgID),
+				entities.NewField("window", window),
 				entities.NewField("error", err))
 			return // エラー時は中断、次回pollで再開
 		}
 
-		w.logger.Info("Akerun worker: recovery window fetched",
-			entities.NewField("window", fmt.Sprintf("%d/%d", windowIdx+1, totalWindows)),
-			entities.NewField("count", len(accesses)),
-			entities.NewField("from", cursor.Format(time.RFC3339)),
-			entities.NewField("to", end.Format(time.RFC3339)))
-
-		if len(accesses) >= recoveryLimit {
-			w.logger.Warn("Akerun worker: recovery window hit limit, some records may be missed",
-				entities.NewField("window", windowIdx+1),
-				entities.NewField("limit", recoveryLimit))
-		}
-
-		if len(accesses) > 0 {
-			if err := w.interactor.ProcessAccesses(ctx, accesses); err != nil {
-				w.logger.Error("Akerun worker: failed to process accesses in recovery",
-					entities.NewField("error", err))
-			}
-		}
-
 		// ウィンドウ完了 → last_polled_at を段階的に更新（途中で落ちても再開可能）
-		if err := w.interactor.UpdateLastPolledAt(ctx, end); err != nil {
-			w.logger.Error("Akerun worker: failed to update last polled time", entities.NewField("error", err))
+		if err := w.interactor.UpdateLastPolledAt(ctx, orgID, end); err != nil {
+			w.logger.Error("Akerun worker: failed to update last polled time",
+				entities.NewField("organization", orgID),
+				entities.NewField("error", err))
 			return
 		}
 
@@ -191,15 +300,146 @@ func (w *AkerunWorker) pollRecovery(ctx context.Context, lastPolledAt, now time.
 	}
 
 	w.logger.Info("Akerun worker: recovery completed",
+		entities.NewField("organization", orgID),
 		entities.NewField("gap", gap.String()),
 		entities.NewField("windows", totalWindows))
 }
 
+// processWindow は1期間分（1時間以内）のアクセス記録を取得してボーナスを付与し、取得した件数を返す（リカバリモードと再取得で共通）
+// ページ数の上限で打ち切られた場合は、取得できた分を処理して警告を残す
+func (w *AkerunWorker) processWindow(ctx context.Context, gateway service.AkerunAccessGateway, orgID string, from, to time.Time, window string) (int, error) {
+	accesses, err := gateway.FetchAccesses(ctx, from, to, recoveryLimit)
+	if errors.Is(err, service.ErrAccessesTruncated) {
+		w.logger.Warn("Akerun worker: window truncated at the page limit, some records may be missed",
+			entities.NewField("organization", orgID),
+			entities.NewField("window", window),
+			entities.NewField("error", err))
+		err = nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	w.logger.Info("Akerun worker: window fetched",
+		entities.NewField("organization", orgID),
+		entities.NewField("window", window),
+		entities.NewField("count", len(accesses)),
+		entities.NewField("from", from.Format(time.RFC3339)),
+		entities.NewField("to", to.Format(time.RFC3339)))
+
+	if len(accesses) > 0 {
+		if err := w.interactor.ProcessAccesses(ctx, accesses); err != nil {
+			w.logger.Error("Akerun worker: failed to process accesses in window",
+				entities.NewField("organization", orgID),
+				entities.NewField("error", err))
+		}
+	}
+	return len(accesses), nil
+}
+
+// runRepoll は管理者が依頼した再取得を1件処理する
+// 前回ポーリング時刻は進めない（通常のポーリングとは独立に、指定された期間だけを取り直す）
+// リーダーでなくなった・メンテナンスが始まった・APIの失敗が続いている場合は取得中のまま中断し、次回続きから再開する
+func (w *AkerunWorker) runRepoll() {
+	ctx := context.Background()
+
+	if !w.canRepoll(ctx) {
+		return
+	}
+
+	repoll, err := w.repolls.StartNextRepoll(ctx)
+	if err != nil {
+		w.logger.Error("Akerun worker: failed to start repoll", entities.NewField("error", err))
+		return
+	}
+	if repoll == nil {
+		return
+	}
+
+	gateways := w.repollGateways(repoll.OrganizationID)
+	w.logger.Info("Akerun worker: repoll started",
+		entities.NewField("repoll_id", repoll.ID),
+		entities.NewField("organizations", len(gateways)),
+		entities.NewField("windows", fmt.Sprintf("%d/%d", repoll.CompletedWindows, repoll.TotalWindows)))
+
+	for {
+		from, to, ok := repoll.NextWindow()
+		if !ok {
+			break
+		}
+		if !w.canRepoll(ctx) {
+			w.logger.Info("Akerun worker: repoll paused", entities.NewField("repoll_id", repoll.ID))
+			return
+		}
+
+		window := fmt.Sprintf("repoll %d/%d", repoll.CompletedWindows+1, repoll.TotalWindows)
+		fetched := 0
+		for _, gateway := range gateways {
+			orgID := gateway.Organization().ID
+			count, err := w.processWindow(ctx, gateway, orgID, from, to, window)
+			if errors.Is(err, service.ErrCircuitOpen) {
+				w.logger.Warn("Akerun worker: repoll paused while the API is failing",
+					entities.NewField("repoll_id", repoll.ID),
+					entities.NewField("organization", orgID))
+				return
+			}
+			if err != nil {
+				if err := w.repolls.FinishRepoll(ctx, repoll, fmt.Errorf("%s: %w", orgID, err)); err != nil {
+					w.logger.Error("Akerun worker: failed to finish repoll", entities.NewField("error", err))
+				}
+				return
+			}
+			fetched += count
+		}
+
+		if err := w.repolls.RecordRepollWindow(ctx, repoll, fetched); err != nil {
+			w.logger.Error("Akerun worker: failed to record repoll progress", entities.NewField("error", err))
+			return
+		}
+
+		// レートリミット配慮（最後の期間の後は待たない）
+		if _, _, more := repoll.NextWindow(); more {
+			time.Sleep(w.recoverySleep)
+		}
+	}
+
+	if err := w.repolls.FinishRepoll(ctx, repoll, nil); err != nil {
+		w.logger.Error("Akerun worker: failed to finish repoll", entities.NewField("error", err))
+	}
+}
+
+// canRepoll はこのインスタンスで再取得を進めてよいか
+func (w *AkerunWorker) canRepoll(ctx context.Context) bool {
+	if w.leader != nil && !w.leader.IsLeader(akerunLeaseName) {
+		return false
+	}
+	return w.maintenance == nil || !w.maintenance.IsActive(ctx)
+}
+
+// repollGateways は再取得の対象の組織のゲートウェイ（組織IDが空なら設定済みのすべての組織）
+func (w *AkerunWorker) repollGateways(orgID string) []service.AkerunAccessGateway {
+	targets := make([]service.AkerunAccessGateway, 0, len(w.gateways))
+	for _, gateway := range w.gateways {
+		if !gateway.IsConfigured() {
+			continue
+		}
+		if orgID == "" || gateway.Organization().ID == orgID {
+			targets = append(targets, gateway)
+		}
+	}
+	return targets
+}
+
 // PollForTest はテスト用にpollをエクスポート
 func (w *AkerunWorker) PollForTest() {
 	w.poll()
 }
 
+// RunRepollForTest はテスト用にrunRepollをエクスポート
+func (w *AkerunWorker) RunRepollForTest() {
+	w.runRepoll()
+}
+
 // SetRecoverySleepForTest はテスト用にrecoverySleepをオーバーライド
 func (w *AkerunWorker) SetRecoverySleepForTest(d time.Duration) {
 	w.recoverySleep = d
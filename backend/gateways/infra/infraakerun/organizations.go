@@ -0,0 +1,72 @@
+package infraakerun
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/gity/point-system/entities"
+)
+
+// maxDoorMultiplier はドアの倍率の上限
+const maxDoorMultiplier = 100
+
+// organizationFile は組織設定ファイルの1組織分
+type organizationFile struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	AccessToken string `json:"access_token"`
+	Doors       []struct {
+		ID         string  `json:"id"`
+		Name       string  `json:"name"`
+		Multiplier float64 `json:"multiplier"`
+	} `json:"doors"`
+}
+
+// LoadOrganizations は組織設定ファイル（JSON配列）を読み込む
+// 組織ごとにアクセストークンとボーナスの対象にするドア・倍率を指定する
+func LoadOrganizations(path string) ([]*AkerunConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Akerun organizations: %w", err)
+	}
+	var orgs []organizationFile
+	if err := json.Unmarshal(data, &orgs); err != nil {
+		return nil, fmt.Errorf("failed to parse Akerun organizations: %w", err)
+	}
+
+	seen := make(map[string]bool, len(orgs))
+	configs := make([]*AkerunConfig, 0, len(orgs))
+	for _, org := range orgs {
+		if org.ID == "" || org.AccessToken == "" {
+			return nil, fmt.Errorf("Akerun organization must include id and access_token")
+		}
+		if seen[org.ID] {
+			return nil, fmt.Errorf("duplicate Akerun organization: %s", org.ID)
+		}
+		seen[org.ID] = true
+
+		doors := make([]entities.AkerunDoorRule, 0, len(org.Doors))
+		for _, door := range org.Doors {
+			if door.ID == "" {
+				return nil, fmt.Errorf("Akerun organization %s: door must include id", org.ID)
+			}
+			if door.Multiplier < 0 || door.Multiplier > maxDoorMultiplier {
+				return nil, fmt.Errorf("Akerun organization %s: door %s multiplier must be between 0 and %d", org.ID, door.ID, maxDoorMultiplier)
+			}
+			doors = append(doors, entities.AkerunDoorRule{
+				DoorID:     door.ID,
+				Name:       door.Name,
+				Multiplier: door.Multiplier,
+			})
+		}
+
+		configs = append(configs, &AkerunConfig{
+			AccessToken:      org.AccessToken,
+			OrganizationID:   org.ID,
+			OrganizationName: org.Name,
+			Doors:            doors,
+		})
+	}
+	return configs, nil
+}
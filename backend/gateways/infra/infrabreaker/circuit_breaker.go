@@ -0,0 +1,237 @@
+package infrabreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/service"
+)
+
+// Settings はサーキットブレーカーの設定
+type Settings struct {
+	MaxFailures      int           // 連続してこの回数失敗したらopenにする
+	OpenTimeout      time.Duration // openにしてからhalf_openで試すまでの時間
+	HalfOpenRequests int           // half_openで通す呼び出しの数（すべて成功したらclosedに戻す）
+}
+
+// DefaultSettings はサーキットブレーカーの既定の設定
+var DefaultSettings = Settings{MaxFailures: 5, OpenTimeout: time.Minute, HalfOpenRequests: 1}
+
+// CircuitBreaker は外部サービスの呼び出しを包み、失敗が続いたら一定時間呼び出しを止める
+// closed（通常）→ 連続失敗でopen（呼び出さずにErrCircuitOpen）→ OpenTimeout後にhalf_open（一部だけ試す）
+// → 成功すればclosed、失敗すれば再びopen、と遷移する
+type CircuitBreaker struct {
+	name     string
+	settings Settings
+	now      func() time.Time
+	onChange func(name, from, to string)
+
+	mu         sync.Mutex
+	state      string
+	generation uint64 // 状態が変わるたびに増やし、前の状態で始めた呼び出しの結果を無視する
+	failures   int
+	openedAt   time.Time
+	inFlight   int // half_openで通した呼び出しの数
+	successes  int // half_openで成功した呼び出しの数
+}
+
+// NewCircuitBreaker は新しいCircuitBreakerを作成（設定の0以下の値は既定値にする）
+func NewCircuitBreaker(name string, settings Settings) *CircuitBreaker {
+	if settings.MaxFailures <= 0 {
+		settings.MaxFailures = DefaultSettings.MaxFailures
+	}
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = DefaultSettings.OpenTimeout
+	}
+	if settings.HalfOpenRequests <= 0 {
+		settings.HalfOpenRequests = DefaultSettings.HalfOpenRequests
+	}
+	return &CircuitBreaker{
+		name:     name,
+		settings: settings,
+		now:      time.Now,
+		state:    service.CircuitClosed,
+	}
+}
+
+// WithClock は現在時刻の取得を差し替える（テスト用）
+func (b *CircuitBreaker) WithClock(now func() time.Time) *CircuitBreaker {
+	b.now = now
+	return b
+}
+
+// Name はサーキットブレーカーの名前
+func (b *CircuitBreaker) Name() string {
+	return b.name
+}
+
+// Execute はfnを呼び出し、結果で状態を更新する
+// openの間はfnを呼ばずにErrCircuitOpenを返す
+// 呼び出し側のキャンセル（context.Canceled）は外部サービスの失敗として数えない
+func (b *CircuitBreaker) Execute(fn func() error) error {
+	generation, err := b.before()
+	if err != nil {
+		return err
+	}
+	err = fn()
+	b.after(generation, err == nil || errors.Is(err, context.Canceled))
+	return err
+}
+
+// Status は現在の状態
+func (b *CircuitBreaker) Status() service.CircuitBreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh()
+
+	status := service.CircuitBreakerStatus{
+		Name:                b.name,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+	}
+	if b.state != service.CircuitClosed {
+		openedAt := b.openedAt
+		status.OpenedAt = &openedAt
+	}
+	return status
+}
+
+func (b *CircuitBreaker) before() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh()
+
+	switch b.state {
+	case service.CircuitOpen:
+		return 0, fmt.Errorf("%s: %w", b.name, service.ErrCircuitOpen)
+	case service.CircuitHalfOpen:
+		if b.inFlight >= b.settings.HalfOpenRequests {
+			return 0, fmt.Errorf("%s: %w", b.name, service.ErrCircuitOpen)
+		}
+		b.inFlight++
+	}
+	return b.generation, nil
+}
+
+func (b *CircuitBreaker) after(generation uint64, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh()
+	if generation != b.generation {
+		return
+	}
+
+	if success {
+		switch b.state {
+		case service.CircuitClosed:
+			b.failures = 0
+		case service.CircuitHalfOpen:
+			b.successes++
+			if b.successes >= b.settings.HalfOpenRequests {
+				b.setState(service.CircuitClosed)
+			}
+		}
+		return
+	}
+
+	b.failures++
+	switch b.state {
+	case service.CircuitClosed:
+		if b.failures >= b.settings.MaxFailures {
+			b.setState(service.CircuitOpen)
+		}
+	case service.CircuitHalfOpen:
+		b.setState(service.CircuitOpen)
+	}
+}
+
+// refresh はopenのままOpenTimeoutを過ぎていればhalf_openにする
+func (b *CircuitBreaker) refresh() {
+	if b.state == service.CircuitOpen && !b.now().Before(b.openedAt.Add(b.settings.OpenTimeout)) {
+		b.setState(service.CircuitHalfOpen)
+	}
+}
+
+func (b *CircuitBreaker) setState(state string) {
+	from := b.state
+	b.state = state
+	b.generation++
+	b.inFlight = 0
+	b.successes = 0
+	switch state {
+	case service.CircuitOpen:
+		b.openedAt = b.now()
+	case service.CircuitClosed:
+		b.failures = 0
+	}
+	if b.onChange != nil {
+		b.onChange(b.name, from, state)
+	}
+}
+
+// Registry は外部サービスごとのサーキットブレーカーをまとめ、ヘルスチェックに状態を返す
+type Registry struct {
+	settings Settings
+	logger   entities.Logger
+
+	mu       sync.Mutex
+	breakers map[string]*CircuitBreaker
+}
+
+// NewRegistry は新しいRegistryを作成
+func NewRegistry(settings Settings, logger entities.Logger) *Registry {
+	return &Registry{
+		settings: settings,
+		logger:   logger,
+		breakers: map[string]*CircuitBreaker{},
+	}
+}
+
+// Breaker は名前のサーキットブレーカーを返す（なければ作成し、状態の変化をログに残す）
+func (r *Registry) Breaker(name string) *CircuitBreaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if b, ok := r.breakers[name]; ok {
+		return b
+	}
+	b := NewCircuitBreaker(name, r.settings)
+	b.onChange = r.logStateChange
+	r.breakers[name] = b
+	return b
+}
+
+// CircuitBreakers はすべてのサーキットブレーカーの状態（名前順）
+func (r *Registry) CircuitBreakers() []service.CircuitBreakerStatus {
+	r.mu.Lock()
+	breakers := make([]*CircuitBreaker, 0, len(r.breakers))
+	for _, b := range r.breakers {
+		breakers = append(breakers, b)
+	}
+	r.mu.Unlock()
+
+	sort.Slice(breakers, func(i, j int) bool { return breakers[i].name < breakers[j].name })
+	statuses := make([]service.CircuitBreakerStatus, 0, len(breakers))
+	for _, b := range breakers {
+		statuses = append(statuses, b.Status())
+	}
+	return statuses
+}
+
+func (r *Registry) logStateChange(name, from, to string) {
+	fields := []entities.Field{
+		entities.NewField("breaker", name),
+		entities.NewField("from", from),
+		entities.NewField("to", to),
+	}
+	if to == service.CircuitOpen {
+		r.logger.Warn("Circuit breaker opened", fields...)
+		return
+	}
+	r.logger.Info("Circuit breaker state changed", fields...)
+}
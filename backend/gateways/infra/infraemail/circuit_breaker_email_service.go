@@ -0,0 +1,73 @@
+package infraemail
+
+import (
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/infra/infrabreaker"
+	"github.com/gity/point-system/usecases/service"
+)
+
+// CircuitBreakerEmailService は送信の失敗が続いたらメールサーバーへの送信を止める EmailService
+// サーキットブレーカーがopenの間は送信せずにservice.ErrCircuitOpenを返す
+type CircuitBreakerEmailService struct {
+	next    service.EmailService
+	breaker *infrabreaker.CircuitBreaker
+}
+
+// NewCircuitBreakerEmailService はnextの送信をbreakerで包む
+func NewCircuitBreakerEmailService(next service.EmailService, breaker *infrabreaker.CircuitBreaker) service.EmailService {
+	return &CircuitBreakerEmailService{next: next, breaker: breaker}
+}
+
+// SendVerificationEmail はメール認証用のメールを送信
+func (s *CircuitBreakerEmailService) SendVerificationEmail(to, token string) error {
+	return s.breaker.Execute(func() error { return s.next.SendVerificationEmail(to, token) })
+}
+
+// SendEmailChangeConfirmation はメールアドレス変更の確認メールを送信
+func (s *CircuitBreakerEmailService) SendEmailChangeConfirmation(to, newEmail, token string) error {
+	return s.breaker.Execute(func() error { return s.next.SendEmailChangeConfirmation(to, newEmail, token) })
+}
+
+// SendPasswordChangeNotification はパスワード変更通知メールを送信
+func (s *CircuitBreakerEmailService) SendPasswordChangeNotification(to string) error {
+	return s.breaker.Execute(func() error { return s.next.SendPasswordChangeNotification(to) })
+}
+
+// SendAccountDeletedNotification はアカウント削除通知メールを送信
+func (s *CircuitBreakerEmailService) SendAccountDeletedNotification(to string) error {
+	return s.breaker.Execute(func() error { return s.next.SendAccountDeletedNotification(to) })
+}
+
+// SendAccountLockedNotification はアカウントロック通知とロック解除リンクを送信
+func (s *CircuitBreakerEmailService) SendAccountLockedNotification(to, unlockToken string, lockedUntil time.Time) error {
+	return s.breaker.Execute(func() error { return s.next.SendAccountLockedNotification(to, unlockToken, lockedUntil) })
+}
+
+// SendNewLoginNotification は新しい端末・国からのログイン通知を送信
+func (s *CircuitBreakerEmailService) SendNewLoginNotification(to, ipAddress, userAgent, country string, loggedInAt time.Time) error {
+	return s.breaker.Execute(func() error {
+		return s.next.SendNewLoginNotification(to, ipAddress, userAgent, country, loggedInAt)
+	})
+}
+
+// SendNotificationEmail は通知をメールで送信
+func (s *CircuitBreakerEmailService) SendNotificationEmail(to, subject, body string) error {
+	return s.breaker.Execute(func() error { return s.next.SendNotificationEmail(to, subject, body) })
+}
+
+// SendDataExportReady は個人データエクスポートの完了とダウンロードリンクを送信
+func (s *CircuitBreakerEmailService) SendDataExportReady(to, exportID string, expiresAt time.Time) error {
+	return s.breaker.Execute(func() error { return s.next.SendDataExportReady(to, exportID, expiresAt) })
+}
+
+// SendUserInvitation は一括登録したユーザーへログイン情報を送信
+func (s *CircuitBreakerEmailService) SendUserInvitation(to, username, temporaryPassword string) error {
+	return s.breaker.Execute(func() error { return s.next.SendUserInvitation(to, username, temporaryPassword) })
+}
+
+// SendEmail は描画済みのメールを送信
+func (s *CircuitBreakerEmailService) SendEmail(to string, email *entities.RenderedEmail) error {
+	return s.breaker.Execute(func() error { return s.next.SendEmail(to, email) })
+}
@@ -1,84 +1,148 @@
 package infraemail
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
 	"github.com/gity/point-system/usecases/service"
 )
 
 // ConsoleEmailService はコンソールにメールを出力する実装（開発用）
+// 文面は管理者が編集したテンプレートの最新の版から作り、版がない・描画できない場合は組み込みの既定を使う
 type ConsoleEmailService struct {
-	logger entities.Logger
+	templates repository.EmailTemplateRepository
+	logger    entities.Logger
 }
 
 // NewConsoleEmailService は新しいConsoleEmailServiceを作成
-func NewConsoleEmailService(logger entities.Logger) service.EmailService {
+func NewConsoleEmailService(templates repository.EmailTemplateRepository, logger entities.Logger) service.EmailService {
 	return &ConsoleEmailService{
-		logger: logger,
+		templates: templates,
+		logger:    logger,
 	}
 }
 
 // SendVerificationEmail はメール認証用のメールを送信（コンソール出力）
 func (s *ConsoleEmailService) SendVerificationEmail(to, token string) error {
-	message := fmt.Sprintf(`
-========================================
-メール認証
-========================================
-宛先: %s
-件名: メールアドレスの認証
+	return s.sendTemplate(to, entities.EmailTemplateVerification, map[string]string{"Token": token})
+}
 
-以下のリンクをクリックしてメールアドレスを認証してください：
-http://localhost:3000/verify-email?token=%s
+// SendEmailChangeConfirmation はメールアドレス変更の確認メールを旧アドレスへ送信（コンソール出力）
+func (s *ConsoleEmailService) SendEmailChangeConfirmation(to, newEmail, token string) error {
+	return s.sendTemplate(to, entities.EmailTemplateEmailChange, map[string]string{"NewEmail": newEmail, "Token": token})
+}
 
-このリンクは24時間有効です。
-========================================
-`, to, token)
+// SendPasswordChangeNotification はパスワード変更通知メールを送信（コンソール出力）
+func (s *ConsoleEmailService) SendPasswordChangeNotification(to string) error {
+	return s.sendTemplate(to, entities.EmailTemplatePasswordChanged, map[string]string{})
+}
 
-	s.logger.Info("Sending verification email", entities.NewField("to", to))
-	fmt.Println(message)
+// SendAccountDeletedNotification はアカウント削除通知メールを送信（コンソール出力）
+func (s *ConsoleEmailService) SendAccountDeletedNotification(to string) error {
+	return s.sendTemplate(to, entities.EmailTemplateAccountDeleted, map[string]string{})
+}
 
-	return nil
+// SendAccountLockedNotification はアカウントロック通知メールを送信（コンソール出力）
+func (s *ConsoleEmailService) SendAccountLockedNotification(to, unlockToken string, lockedUntil time.Time) error {
+	return s.sendTemplate(to, entities.EmailTemplateAccountLocked, map[string]string{
+		"LockedUntil": lockedUntil.Format("2006-01-02 15:04"),
+		"UnlockToken": unlockToken,
+	})
 }
 
-// SendPasswordChangeNotification はパスワード変更通知メールを送信（コンソール出力）
-func (s *ConsoleEmailService) SendPasswordChangeNotification(to string) error {
-	message := fmt.Sprintf(`
-========================================
-パスワード変更通知
-========================================
-宛先: %s
-件名: パスワードが変更されました
+// SendNewLoginNotification は新しい端末・国からのログイン通知メールを送信（コンソール出力）
+func (s *ConsoleEmailService) SendNewLoginNotification(to, ipAddress, userAgent, country string, loggedInAt time.Time) error {
+	if country == "" {
+		country = "不明"
+	}
+	return s.sendTemplate(to, entities.EmailTemplateNewLogin, map[string]string{
+		"LoggedInAt": loggedInAt.Format("2006-01-02 15:04"),
+		"IPAddress":  ipAddress,
+		"Country":    country,
+		"UserAgent":  userAgent,
+	})
+}
 
-あなたのアカウントのパスワードが変更されました。
+// SendNotificationEmail は通知メールを送信（コンソール出力）
+func (s *ConsoleEmailService) SendNotificationEmail(to, subject, body string) error {
+	return s.sendTemplate(to, entities.EmailTemplateNotification, map[string]string{"Subject": subject, "Body": body})
+}
 
-もしこの変更に覚えがない場合は、すぐにサポートに連絡してください。
-========================================
-`, to)
+// SendDataExportReady は個人データエクスポートの完了通知メールを送信（コンソール出力）
+func (s *ConsoleEmailService) SendDataExportReady(to, exportID string, expiresAt time.Time) error {
+	return s.sendTemplate(to, entities.EmailTemplateDataExportReady, map[string]string{
+		"ExportID":  exportID,
+		"ExpiresAt": expiresAt.Format("2006-01-02 15:04"),
+	})
+}
 
-	s.logger.Info("Sending password change notification", entities.NewField("to", to))
-	fmt.Println(message)
+// SendUserInvitation は一括登録したユーザーへの招待メールを送信（コンソール出力）
+func (s *ConsoleEmailService) SendUserInvitation(to, username, temporaryPassword string) error {
+	return s.sendTemplate(to, entities.EmailTemplateUserInvitation, map[string]string{
+		"Username":          username,
+		"TemporaryPassword": temporaryPassword,
+	})
+}
 
+// SendEmail は描画済みのメールを送信（コンソール出力）
+func (s *ConsoleEmailService) SendEmail(to string, email *entities.RenderedEmail) error {
+	s.print(to, "テスト送信", email)
 	return nil
 }
 
-// SendAccountDeletedNotification はアカウント削除通知メールを送信（コンソール出力）
-func (s *ConsoleEmailService) SendAccountDeletedNotification(to string) error {
+// sendTemplate はテンプレートの最新の版で文面を作って送信
+func (s *ConsoleEmailService) sendTemplate(to string, key entities.EmailTemplateKey, vars map[string]string) error {
+	def, ok := entities.LookupEmailTemplateDefinition(key)
+	if !ok {
+		return entities.ErrEmailTemplateNotFound
+	}
+
+	email, err := s.render(def, vars)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Sending email", entities.NewField("to", to), entities.NewField("template", string(key)))
+	s.print(to, def.Name, email)
+	return nil
+}
+
+// render は編集済みの版で描画し、版がない・取得や描画に失敗した場合は組み込みの既定で描画する
+// テンプレートの不備でメールが届かなくなるのを避けるため、編集済みの版の失敗は警告に留める
+func (s *ConsoleEmailService) render(def *entities.EmailTemplateDefinition, vars map[string]string) (*entities.RenderedEmail, error) {
+	template, err := s.templates.ReadLatest(context.Background(), def.Key)
+	if err == nil {
+		email, renderErr := template.Render(vars)
+		if renderErr == nil {
+			return email, nil
+		}
+		err = renderErr
+	}
+	if !errors.Is(err, entities.ErrEmailTemplateNotFound) {
+		s.logger.Warn("Falling back to built-in email template",
+			entities.NewField("template", string(def.Key)),
+			entities.NewField("error", err.Error()))
+	}
+	return def.Default().Render(vars)
+}
+
+func (s *ConsoleEmailService) print(to, title string, email *entities.RenderedEmail) {
 	message := fmt.Sprintf(`
 ========================================
-アカウント削除通知
+%s
 ========================================
 宛先: %s
-件名: アカウントが削除されました
+件名: %s
 
-あなたのアカウントは正常に削除されました。
-
-ご利用ありがとうございました。
+%s
 ========================================
-`, to)
-
-	s.logger.Info("Sending account deleted notification", entities.NewField("to", to))
+`, title, to, email.Subject, email.Text)
+	if email.HTML != "" {
+		message += fmt.Sprintf("HTML:\n%s\n========================================\n", email.HTML)
+	}
 	fmt.Println(message)
-
-	return nil
 }
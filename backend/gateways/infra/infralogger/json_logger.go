@@ -0,0 +1,75 @@
+package infralogger
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+)
+
+// JSONLogger は1行に1つのJSONオブジェクトでログを出力するLogger（ログ収集基盤での検索用）
+// フィールドはJSONのキーとしてそのまま出力する（time・level・msgは上書きされない）
+type JSONLogger struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewJSONLogger は新しいJSONLoggerを作成
+func NewJSONLogger(out io.Writer) entities.Logger {
+	return &JSONLogger{out: out}
+}
+
+// Debug はデバッグログを出力
+func (l *JSONLogger) Debug(msg string, fields ...entities.Field) {
+	l.output("DEBUG", msg, fields...)
+}
+
+// Info は情報ログを出力
+func (l *JSONLogger) Info(msg string, fields ...entities.Field) {
+	l.output("INFO", msg, fields...)
+}
+
+// Warn は警告ログを出力
+func (l *JSONLogger) Warn(msg string, fields ...entities.Field) {
+	l.output("WARN", msg, fields...)
+}
+
+// Error はエラーログを出力
+func (l *JSONLogger) Error(msg string, fields ...entities.Field) {
+	l.output("ERROR", msg, fields...)
+}
+
+// Fatal は致命的エラーログを出力してプログラムを終了
+func (l *JSONLogger) Fatal(msg string, fields ...entities.Field) {
+	l.output("FATAL", msg, fields...)
+	os.Exit(1)
+}
+
+// output はログを1行のJSONで出力
+func (l *JSONLogger) output(level, msg string, fields ...entities.Field) {
+	entry := make(map[string]interface{}, len(fields)+3)
+	for _, field := range fields {
+		entry[field.Key] = field.Value
+	}
+	entry["time"] = time.Now().Format(time.RFC3339Nano)
+	entry["level"] = level
+	entry["msg"] = msg
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		// JSONにできないフィールドがあっても、ログ自体は失わない
+		data, _ = json.Marshal(map[string]interface{}{
+			"time":  entry["time"],
+			"level": level,
+			"msg":   msg,
+			"error": "failed to encode log fields: " + err.Error(),
+		})
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.out.Write(append(data, '\n'))
+}
@@ -0,0 +1,95 @@
+package inframoderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/service"
+)
+
+// HTTPModerator は外部のモデレーションAPIでテキストを判定する実装
+//
+// リクエスト: POST {"field": "...", "text": "..."}
+// レスポンス: {"allowed": true/false, "categories": ["..."]}
+//
+// APIに到達できない場合はfallbackで判定する（入力を止めないため）
+type HTTPModerator struct {
+	endpoint string
+	apiKey   string
+	client   *http.Client
+	fallback service.ContentModerator
+	logger   entities.Logger
+}
+
+// NewHTTPModerator は新しいHTTPModeratorを作成
+func NewHTTPModerator(endpoint, apiKey string, timeout time.Duration, fallback service.ContentModerator, logger entities.Logger) service.ContentModerator {
+	return &HTTPModerator{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: timeout},
+		fallback: fallback,
+		logger:   logger,
+	}
+}
+
+type moderationAPIRequest struct {
+	Field string `json:"field"`
+	Text  string `json:"text"`
+}
+
+type moderationAPIResponse struct {
+	Allowed    bool     `json:"allowed"`
+	Categories []string `json:"categories"`
+}
+
+// Moderate は外部APIにテキストを問い合わせる
+func (m *HTTPModerator) Moderate(ctx context.Context, field entities.ModerationField, text string) (*entities.ModerationVerdict, error) {
+	verdict, err := m.call(ctx, field, text)
+	if err != nil {
+		m.logger.Warn("Moderation API unavailable, falling back to wordlist",
+			entities.NewField("error", err))
+		return m.fallback.Moderate(ctx, field, text)
+	}
+	return verdict, nil
+}
+
+func (m *HTTPModerator) call(ctx context.Context, field entities.ModerationField, text string) (*entities.ModerationVerdict, error) {
+	body, err := json.Marshal(moderationAPIRequest{Field: string(field), Text: text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal moderation request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create moderation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if m.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("moderation request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("moderation API returned status %d", resp.StatusCode)
+	}
+
+	var result moderationAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode moderation response: %w", err)
+	}
+
+	if result.Allowed {
+		return entities.AllowedVerdict(), nil
+	}
+	return &entities.ModerationVerdict{Allowed: false, MatchedTerms: result.Categories}, nil
+}
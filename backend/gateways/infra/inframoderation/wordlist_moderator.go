@@ -0,0 +1,68 @@
+package inframoderation
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/service"
+)
+
+// DefaultWordlist は既定の禁止語リスト
+var DefaultWordlist = []string{
+	"fuck",
+	"shit",
+	"bitch",
+	"asshole",
+	"死ね",
+	"殺す",
+	"きもい",
+	"ばか",
+	"バカ",
+	"アホ",
+}
+
+// WordlistModerator は禁止語リストでテキストを判定する実装
+type WordlistModerator struct {
+	terms []string
+}
+
+// NewWordlistModerator は新しいWordlistModeratorを作成
+func NewWordlistModerator(terms []string) service.ContentModerator {
+	return &WordlistModerator{terms: terms}
+}
+
+// LoadWordlist は1行1語のファイルから禁止語を読み込む（空行と#で始まる行は無視）
+func LoadWordlist(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open wordlist: %w", err)
+	}
+	defer f.Close()
+
+	var terms []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		terms = append(terms, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read wordlist: %w", err)
+	}
+	return terms, nil
+}
+
+// Moderate は禁止語を含むテキストを不許可と判定する
+func (m *WordlistModerator) Moderate(ctx context.Context, field entities.ModerationField, text string) (*entities.ModerationVerdict, error) {
+	matched := entities.MatchModerationTerms(text, m.terms)
+	if len(matched) > 0 {
+		return &entities.ModerationVerdict{Allowed: false, MatchedTerms: matched}, nil
+	}
+	return entities.AllowedVerdict(), nil
+}
@@ -0,0 +1,116 @@
+package infrapassword
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2idPrefix     = "$argon2id$"
+	argon2idSaltLength = 16
+	argon2idKeyLength  = 32
+)
+
+var errInvalidArgon2idHash = errors.New("invalid argon2id hash")
+
+// Argon2idParams はargon2idのパラメータ
+type Argon2idParams struct {
+	MemoryKiB   uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// Argon2idPasswordService はargon2idを使用したパスワードサービス
+// ハッシュはPHC形式（$argon2id$v=19$m=<KiB>,t=<回数>,p=<並列数>$<salt>$<hash>）で、パラメータを含む
+type Argon2idPasswordService struct {
+	params Argon2idParams
+}
+
+// NewArgon2idPasswordService は新しいArgon2idPasswordServiceを作成
+func NewArgon2idPasswordService(params Argon2idParams) *Argon2idPasswordService {
+	return &Argon2idPasswordService{params: params}
+}
+
+// Algorithm はアルゴリズム名
+func (s *Argon2idPasswordService) Algorithm() string {
+	return AlgorithmArgon2id
+}
+
+// Recognizes はargon2idのハッシュならtrue
+func (s *Argon2idPasswordService) Recognizes(hashedPassword string) bool {
+	return strings.HasPrefix(hashedPassword, argon2idPrefix)
+}
+
+// HashPassword はパスワードをハッシュ化
+func (s *Argon2idPasswordService) HashPassword(password string) (string, error) {
+	salt := make([]byte, argon2idSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, s.params.Iterations, s.params.MemoryKiB, s.params.Parallelism, argon2idKeyLength)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version,
+		s.params.MemoryKiB, s.params.Iterations, s.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// VerifyPassword はパスワードを検証（ハッシュに含まれるパラメータで計算する）
+func (s *Argon2idPasswordService) VerifyPassword(hashedPassword, password string) bool {
+	h, err := parseArgon2idHash(hashedPassword)
+	if err != nil {
+		return false
+	}
+	key := argon2.IDKey([]byte(password), h.salt, h.params.Iterations, h.params.MemoryKiB, h.params.Parallelism, uint32(len(h.key)))
+	return subtle.ConstantTimeCompare(key, h.key) == 1
+}
+
+// NeedsRehash はハッシュのバージョン・パラメータ・鍵長が設定と異なればtrue
+func (s *Argon2idPasswordService) NeedsRehash(hashedPassword string) bool {
+	h, err := parseArgon2idHash(hashedPassword)
+	if err != nil {
+		return true
+	}
+	return h.version != argon2.Version || h.params != s.params || len(h.key) != argon2idKeyLength
+}
+
+// argon2idHash は保存されたargon2idのハッシュを分解したもの
+type argon2idHash struct {
+	version int
+	params  Argon2idParams
+	salt    []byte
+	key     []byte
+}
+
+// parseArgon2idHash はPHC形式のargon2idのハッシュを分解
+func parseArgon2idHash(hashedPassword string) (*argon2idHash, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return nil, errInvalidArgon2idHash
+	}
+
+	h := &argon2idHash{}
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &h.version); err != nil {
+		return nil, errInvalidArgon2idHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &h.params.MemoryKiB, &h.params.Iterations, &h.params.Parallelism); err != nil {
+		return nil, errInvalidArgon2idHash
+	}
+	if h.params.Iterations == 0 || h.params.Parallelism == 0 {
+		return nil, errInvalidArgon2idHash
+	}
+
+	var err error
+	if h.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, errInvalidArgon2idHash
+	}
+	if h.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(h.key) == 0 {
+		return nil, errInvalidArgon2idHash
+	}
+	return h, nil
+}
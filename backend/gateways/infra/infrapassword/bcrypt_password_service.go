@@ -1,20 +1,37 @@
 package infrapassword
 
 import (
-	"github.com/gity/point-system/usecases/service"
+	"strings"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
 // BcryptPasswordService はbcryptを使用したパスワードサービス
+// ハッシュ（$2a$<cost>$...）にコストを含むため、設定と異なるコストのハッシュを判定できる
 type BcryptPasswordService struct {
 	cost int
 }
 
 // NewBcryptPasswordService は新しいBcryptPasswordServiceを作成
-func NewBcryptPasswordService() service.PasswordService {
+func NewBcryptPasswordService(cost int) *BcryptPasswordService {
 	return &BcryptPasswordService{
-		cost: bcrypt.DefaultCost, // デフォルトは10
+		cost: cost,
+	}
+}
+
+// Algorithm はアルゴリズム名
+func (s *BcryptPasswordService) Algorithm() string {
+	return AlgorithmBcrypt
+}
+
+// Recognizes はbcryptのハッシュならtrue
+func (s *BcryptPasswordService) Recognizes(hashedPassword string) bool {
+	for _, prefix := range []string{"$2a$", "$2b$", "$2y$"} {
+		if strings.HasPrefix(hashedPassword, prefix) {
+			return true
+		}
 	}
+	return false
 }
 
 // HashPassword はパスワードをハッシュ化
@@ -31,3 +48,9 @@ func (s *BcryptPasswordService) VerifyPassword(hashedPassword, password string)
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 	return err == nil
 }
+
+// NeedsRehash はハッシュのコストが設定と異なればtrue
+func (s *BcryptPasswordService) NeedsRehash(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	return err != nil || cost != s.cost
+}
@@ -0,0 +1,85 @@
+package infrapassword
+
+import (
+	"fmt"
+
+	"github.com/gity/point-system/usecases/service"
+)
+
+// パスワードハッシュのアルゴリズム
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+// Hasher は1つのアルゴリズムのパスワードサービス
+type Hasher interface {
+	service.PasswordService
+
+	// Algorithm はアルゴリズム名
+	Algorithm() string
+
+	// Recognizes はハッシュがこのアルゴリズムの形式ならtrue
+	Recognizes(hashedPassword string) bool
+}
+
+// Config はパスワードサービスの設定
+type Config struct {
+	Algorithm  string // 新しく保存するハッシュのアルゴリズム
+	BcryptCost int
+	Argon2id   Argon2idParams
+}
+
+// MigratingPasswordService は複数のアルゴリズムのハッシュを検証できるパスワードサービス
+// 新しいハッシュは設定したアルゴリズムで作り、それ以外のアルゴリズム・パラメータのハッシュは作り直しが必要と判定する
+type MigratingPasswordService struct {
+	current Hasher
+	hashers []Hasher
+}
+
+// NewPasswordService は設定したアルゴリズムで新しくハッシュを作るパスワードサービスを作成
+// 対応するすべてのアルゴリズムのハッシュを検証できる
+func NewPasswordService(cfg Config) (service.PasswordService, error) {
+	hashers := []Hasher{
+		NewBcryptPasswordService(cfg.BcryptCost),
+		NewArgon2idPasswordService(cfg.Argon2id),
+	}
+	for _, h := range hashers {
+		if h.Algorithm() == cfg.Algorithm {
+			return NewMigratingPasswordService(h, hashers...), nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported password hash algorithm: %q", cfg.Algorithm)
+}
+
+// NewMigratingPasswordService は新しいMigratingPasswordServiceを作成
+// currentで新しいハッシュを作り、current・legacyのどちらの形式のハッシュも検証する
+func NewMigratingPasswordService(current Hasher, legacy ...Hasher) *MigratingPasswordService {
+	return &MigratingPasswordService{
+		current: current,
+		hashers: append([]Hasher{current}, legacy...),
+	}
+}
+
+// HashPassword はパスワードを設定したアルゴリズムでハッシュ化
+func (s *MigratingPasswordService) HashPassword(password string) (string, error) {
+	return s.current.HashPassword(password)
+}
+
+// VerifyPassword はハッシュの形式に対応するアルゴリズムでパスワードを検証
+func (s *MigratingPasswordService) VerifyPassword(hashedPassword, password string) bool {
+	for _, h := range s.hashers {
+		if h.Recognizes(hashedPassword) {
+			return h.VerifyPassword(hashedPassword, password)
+		}
+	}
+	return false
+}
+
+// NeedsRehash はハッシュのアルゴリズムまたはパラメータが設定と異なればtrue
+func (s *MigratingPasswordService) NeedsRehash(hashedPassword string) bool {
+	if !s.current.Recognizes(hashedPassword) {
+		return true
+	}
+	return s.current.NeedsRehash(hashedPassword)
+}
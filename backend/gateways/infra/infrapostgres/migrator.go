@@ -0,0 +1,245 @@
+package infrapostgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrSchemaBehind はDBスキーマが埋め込みマイグレーションより古い場合のエラー
+var ErrSchemaBehind = errors.New("database schema is behind: run migrations")
+
+// Migration は1つのSQLマイグレーション
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// SchemaMigrationModel は適用済みマイグレーションの記録
+type SchemaMigrationModel struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"type:varchar(255);not null"`
+	AppliedAt time.Time `gorm:"not null;default:now()"`
+}
+
+// TableName はテーブル名を指定
+func (SchemaMigrationModel) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator は埋め込みSQLファイルを schema_migrations テーブルで管理しながら適用する
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator は新しいMigratorを作成
+// source には NNN_name.sql 形式のファイルを含むFSを渡す
+func NewMigrator(db DB, source fs.FS) (*Migrator, error) {
+	migrations, err := LoadMigrations(source)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db.GetDB(), migrations: migrations}, nil
+}
+
+// LoadMigrations はFSからマイグレーションをバージョン順に読み込む
+func LoadMigrations(source fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	seen := make(map[int]string)
+	migrations := make([]Migration, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		version, name, err := parseMigrationName(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if prev, ok := seen[version]; ok {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, prev, entry.Name())
+		}
+		seen[version] = entry.Name()
+
+		body, err := fs.ReadFile(source, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		migrations = append(migrations, Migration{
+			Version: version,
+			Name:    name,
+			SQL:     string(body),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// parseMigrationName は "013_point_expiration.sql" を (13, "point_expiration") に分解
+func parseMigrationName(filename string) (int, string, error) {
+	base := strings.TrimSuffix(filename, ".sql")
+	prefix, name, ok := strings.Cut(base, "_")
+	if !ok || name == "" {
+		return 0, "", fmt.Errorf("invalid migration filename: %s", filename)
+	}
+	version, err := strconv.Atoi(prefix)
+	if err != nil || version <= 0 {
+		return 0, "", fmt.Errorf("invalid migration version in filename: %s", filename)
+	}
+	return version, name, nil
+}
+
+// Migrations は読み込まれたマイグレーション一覧を返す
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// LatestVersion は埋め込まれている最新のバージョンを返す
+func (m *Migrator) LatestVersion() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// ensureTable は schema_migrations テーブルを作成する
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	return m.db.WithContext(ctx).Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+		)`).Error
+}
+
+// appliedVersions は適用済みバージョンの集合を返す
+func (m *Migrator) appliedVersions(ctx context.Context) (map[int]bool, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	var versions []int
+	if err := m.db.WithContext(ctx).Model(&SchemaMigrationModel{}).Pluck("version", &versions).Error; err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+
+	applied := make(map[int]bool, len(versions))
+	for _, v := range versions {
+		applied[v] = true
+	}
+	return applied, nil
+}
+
+// Pending は未適用のマイグレーションをバージョン順に返す
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]Migration, 0)
+	for _, mig := range m.migrations {
+		if !applied[mig.Version] {
+			pending = append(pending, mig)
+		}
+	}
+	return pending, nil
+}
+
+// Up は未適用のマイグレーションを順番に適用し、適用したものを返す
+// 各マイグレーションはSQL本体と schema_migrations への記録を同一トランザクションで実行する
+// Migrator導入前の方法（psqlでの全ファイルの実行・initdb）で作ったDBでは、既に存在するテーブル・カラムなどを
+// 作ろうとして失敗したマイグレーションを適用済みとして記録する（返す一覧にも含める）
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+	adopting, err := m.isUntrackedSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make([]Migration, 0, len(pending))
+	for _, mig := range pending {
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(mig.SQL).Error; err != nil {
+				return err
+			}
+			return m.record(tx, mig)
+		})
+		if err != nil && adopting && isDuplicateObjectError(err) {
+			err = m.record(m.db.WithContext(ctx), mig)
+		}
+		if err != nil {
+			return applied, fmt.Errorf("failed to apply migration %03d_%s: %w", mig.Version, mig.Name, err)
+		}
+		applied = append(applied, mig)
+	}
+	return applied, nil
+}
+
+// record はマイグレーションを適用済みとして記録する
+func (m *Migrator) record(db *gorm.DB, mig Migration) error {
+	return db.Create(&SchemaMigrationModel{
+		Version:   mig.Version,
+		Name:      mig.Name,
+		AppliedAt: time.Now(),
+	}).Error
+}
+
+// isUntrackedSchema は schema_migrations に記録がないのにテーブルがあるDB（Migrator導入前に作ったDB）かを返す
+func (m *Migrator) isUntrackedSchema(ctx context.Context) (bool, error) {
+	var count int64
+	if err := m.db.WithContext(ctx).Model(&SchemaMigrationModel{}).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	return count == 0 && m.db.WithContext(ctx).Migrator().HasTable("users"), nil
+}
+
+// duplicateObjectStates は作成済みのオブジェクトを作ろうとしたときのSQLSTATE
+// （テーブル・インデックス、カラム、制約・トリガー・型、関数、スキーマ、シードデータの一意制約）
+var duplicateObjectStates = map[string]bool{
+	"42P07": true,
+	"42701": true,
+	"42710": true,
+	"42723": true,
+	"42P06": true,
+	"23505": true,
+}
+
+// isDuplicateObjectError は作成済みのオブジェクトを作ろうとしたエラーかを判定
+func isDuplicateObjectError(err error) bool {
+	var pgErr interface{ SQLState() string }
+	return errors.As(err, &pgErr) && duplicateObjectStates[pgErr.SQLState()]
+}
+
+// CheckUpToDate は未適用のマイグレーションがある場合 ErrSchemaBehind を返す
+func (m *Migrator) CheckUpToDate(ctx context.Context) error {
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w (%d pending, first: %03d_%s)", ErrSchemaBehind, len(pending), pending[0].Version, pending[0].Name)
+	}
+	return nil
+}
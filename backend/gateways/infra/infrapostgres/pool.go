@@ -0,0 +1,122 @@
+package infrapostgres
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gity/point-system/usecases/service"
+	"gorm.io/gorm"
+)
+
+// コネクションプールの既定値（PoolConfigのMaxOpenConnsが0の場合に使う）
+const (
+	DefaultMaxOpenConns    = 100
+	DefaultMaxIdleConns    = 10
+	DefaultConnMaxLifetime = time.Hour
+)
+
+// PoolConfig はコネクションプールとクエリのタイムアウトの設定
+// MaxOpenConnsが0ならプールの大きさ・寿命は既定値を使う（マイグレーションなど設定を読まない呼び出し用）
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration // 0なら接続を使い続ける
+
+	// StatementTimeout・LockTimeout は接続ごとのstatement_timeout・lock_timeout（0なら制限しない）
+	// 超えたクエリはPostgreSQLがキャンセルしてエラーを返す
+	StatementTimeout time.Duration
+	LockTimeout      time.Duration
+}
+
+// apply はコネクションプールの大きさと寿命を設定する
+func (c PoolConfig) apply(sqlDB *sql.DB) {
+	if c.MaxOpenConns <= 0 {
+		c.MaxOpenConns, c.MaxIdleConns, c.ConnMaxLifetime = DefaultMaxOpenConns, DefaultMaxIdleConns, DefaultConnMaxLifetime
+	}
+	sqlDB.SetMaxOpenConns(c.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(c.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(c.ConnMaxLifetime)
+}
+
+// withTimeouts はDSNにstatement_timeout・lock_timeout（ミリ秒）を加える
+// 接続のたびにPostgreSQLへ渡す実行時パラメータになるため、プールのすべての接続に効く
+// DSNは key=value 形式と postgres:// のURL形式のどちらでもよい
+func withTimeouts(dsn string, c PoolConfig) string {
+	params := make([][2]string, 0, 2)
+	if c.StatementTimeout > 0 {
+		params = append(params, [2]string{"statement_timeout", fmt.Sprint(c.StatementTimeout.Milliseconds())})
+	}
+	if c.LockTimeout > 0 {
+		params = append(params, [2]string{"lock_timeout", fmt.Sprint(c.LockTimeout.Milliseconds())})
+	}
+	if len(params) == 0 {
+		return dsn
+	}
+
+	if u, err := url.Parse(dsn); err == nil && (u.Scheme == "postgres" || u.Scheme == "postgresql") {
+		q := u.Query()
+		for _, p := range params {
+			q.Set(p[0], p[1])
+		}
+		u.RawQuery = q.Encode()
+		return u.String()
+	}
+	var b strings.Builder
+	b.WriteString(dsn)
+	for _, p := range params {
+		fmt.Fprintf(&b, " %s=%s", p[0], p[1])
+	}
+	return b.String()
+}
+
+// PoolStatsMonitor はプライマリ（とリードレプリカ）のコネクションプールの状態を返す
+type PoolStatsMonitor struct {
+	db DB
+}
+
+var _ service.DBStatsMonitor = (*PoolStatsMonitor)(nil)
+
+// NewPoolStatsMonitor は新しいPoolStatsMonitorを作成
+func NewPoolStatsMonitor(db DB) *PoolStatsMonitor {
+	return &PoolStatsMonitor{db: db}
+}
+
+// DBStats はプライマリ・リードレプリカ（設定されていれば）の順に状態を返す
+func (m *PoolStatsMonitor) DBStats() []service.DBPoolStats {
+	stats := make([]service.DBPoolStats, 0, 2)
+	if s, ok := poolStats("primary", m.db.GetDB()); ok {
+		stats = append(stats, s)
+	}
+	if rp, ok := m.db.(ReplicaProvider); ok {
+		if s, ok := poolStats("replica", rp.GetReplicaDB()); ok {
+			stats = append(stats, s)
+		}
+	}
+	return stats
+}
+
+func poolStats(name string, db *gorm.DB) (service.DBPoolStats, bool) {
+	if db == nil {
+		return service.DBPoolStats{}, false
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return service.DBPoolStats{}, false
+	}
+	s := sqlDB.Stats()
+	return service.DBPoolStats{
+		Name:               name,
+		MaxOpenConnections: s.MaxOpenConnections,
+		OpenConnections:    s.OpenConnections,
+		InUse:              s.InUse,
+		Idle:               s.Idle,
+		WaitCount:          s.WaitCount,
+		WaitDuration:       s.WaitDuration,
+		MaxIdleClosed:      s.MaxIdleClosed,
+		MaxIdleTimeClosed:  s.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  s.MaxLifetimeClosed,
+	}, true
+}
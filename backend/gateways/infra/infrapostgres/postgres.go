@@ -2,7 +2,6 @@ package infrapostgres
 
 import (
 	"fmt"
-	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -18,7 +17,8 @@ type DB interface {
 
 // PostgresDB はPostgreSQLの接続実装
 type PostgresDB struct {
-	db *gorm.DB
+	db      *gorm.DB
+	replica *gorm.DB // リードレプリカ（未設定時はnil）
 }
 
 // Config はPostgreSQLの設定
@@ -30,10 +30,16 @@ type Config struct {
 	DBName   string
 	SSLMode  string
 	Env      string
+
+	// ReplicaDSN はリードレプリカの接続文字列（空の場合はプライマリのみ使用）
+	ReplicaDSN string
+
+	// Pool はコネクションプールとタイムアウトの設定（プライマリ・リードレプリカの両方に適用する）
+	Pool PoolConfig
 }
 
-// NewPostgresDB は新しいPostgresDBを作成
-func NewPostgresDB(cfg *Config) (DB, error) {
+// NewPostgresDB は新しいPostgresDBを作成（pluginsはプライマリ・リードレプリカの両方に登録する）
+func NewPostgresDB(cfg *Config, plugins ...gorm.Plugin) (DB, error) {
 	dsn := fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode,
@@ -49,10 +55,43 @@ func NewPostgresDB(cfg *Config) (DB, error) {
 	}
 
 	// PostgreSQL接続
+	db, err := openDB(withTimeouts(dsn, cfg.Pool), gormConfig, cfg.Pool, plugins)
+	if err != nil {
+		return nil, err
+	}
+
+	// トランザクション分離レベルをREPEATABLE READに設定
+	// PostgreSQLのREPEATABLE READは、ファントムリードも防止する
+	if err := db.Exec("SET SESSION CHARACTERISTICS AS TRANSACTION ISOLATION LEVEL REPEATABLE READ").Error; err != nil {
+		return nil, fmt.Errorf("failed to set transaction isolation level: %w", err)
+	}
+
+	// リードレプリカ接続（任意）
+	var replica *gorm.DB
+	if cfg.ReplicaDSN != "" {
+		replica, err = openDB(withTimeouts(cfg.ReplicaDSN, cfg.Pool), gormConfig, cfg.Pool, plugins)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open read replica: %w", err)
+		}
+	}
+
+	return &PostgresDB{db: db, replica: replica}, nil
+}
+
+// openDB はDSNから接続を開き、プラグインを登録してコネクションプールを設定する
+func openDB(dsn string, gormConfig *gorm.Config, pool PoolConfig, plugins []gorm.Plugin) (*gorm.DB, error) {
 	db, err := gorm.Open(postgres.Open(dsn), gormConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
+	if err := RegisterTenantScope(db); err != nil {
+		return nil, err
+	}
+	for _, plugin := range plugins {
+		if err := db.Use(plugin); err != nil {
+			return nil, fmt.Errorf("failed to register %s: %w", plugin.Name(), err)
+		}
+	}
 
 	// コネクションプール設定
 	sqlDB, err := db.DB()
@@ -60,17 +99,9 @@ func NewPostgresDB(cfg *Config) (DB, error) {
 		return nil, fmt.Errorf("failed to get database instance: %w", err)
 	}
 
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	pool.apply(sqlDB)
 
-	// トランザクション分離レベルをREPEATABLE READに設定
-	// PostgreSQLのREPEATABLE READは、ファントムリードも防止する
-	if err := db.Exec("SET SESSION CHARACTERISTICS AS TRANSACTION ISOLATION LEVEL REPEATABLE READ").Error; err != nil {
-		return nil, fmt.Errorf("failed to set transaction isolation level: %w", err)
-	}
-
-	return &PostgresDB{db: db}, nil
+	return db, nil
 }
 
 // GetDB はGORMのDBインスタンスを取得
@@ -78,8 +109,18 @@ func (p *PostgresDB) GetDB() *gorm.DB {
 	return p.db
 }
 
+// GetReplicaDB はリードレプリカのDBインスタンスを取得（未設定時はnil）
+func (p *PostgresDB) GetReplicaDB() *gorm.DB {
+	return p.replica
+}
+
 // Close はデータベース接続を閉じる
 func (p *PostgresDB) Close() error {
+	if p.replica != nil {
+		if sqlDB, err := p.replica.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}
 	sqlDB, err := p.db.DB()
 	if err != nil {
 		return err
@@ -0,0 +1,44 @@
+package infrapostgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// ReplicaProvider はリードレプリカ接続を提供できるDBのインターフェース
+// DB インターフェースには含めず、対応している実装だけが満たす
+type ReplicaProvider interface {
+	GetReplicaDB() *gorm.DB
+}
+
+const primaryKey contextKey = "force_primary"
+
+// WithPrimary は以降の読み取りをプライマリへ強制するcontextを返す
+// 書き込み直後に自分の更新を読みたい場合（read-your-writes）に使う
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey, true)
+}
+
+// GetReadDB は読み取り専用クエリ用のDBを返します
+// トランザクション中はトランザクションを、WithPrimary指定時はプライマリを、
+// それ以外でレプリカが設定されていればレプリカを返します
+// GetDBと同じく、クエリはctxで実行し、contextのテナントで絞り込みます
+func GetReadDB(ctx context.Context, db DB) *gorm.DB {
+	return withTenant(ctx, readDB(ctx, db).WithContext(ctx))
+}
+
+func readDB(ctx context.Context, db DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey).(*gorm.DB); ok {
+		return tx
+	}
+	if force, ok := ctx.Value(primaryKey).(bool); ok && force {
+		return db.GetDB()
+	}
+	if rp, ok := db.(ReplicaProvider); ok {
+		if replica := rp.GetReplicaDB(); replica != nil {
+			return replica
+		}
+	}
+	return db.GetDB()
+}
@@ -0,0 +1,275 @@
+package infrapostgres
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// slowQueryStartedKey はクエリの開始時刻をステートメントに保持するためのキー
+const slowQueryStartedKey = "point_system:slow_query_started_at"
+
+// slowQueryUnknownMethod はリポジトリ・データソースの外から実行したクエリのメソッド名
+const slowQueryUnknownMethod = "unknown"
+
+// maskedValue はログに出さないパラメータ（文字列など）の代わりに出す値
+const maskedValue = "***"
+
+// SlowQueryConfig は遅いクエリのログの設定
+type SlowQueryConfig struct {
+	Threshold time.Duration // これより時間がかかったクエリを記録する（0なら記録しない）
+	Explain   bool          // 記録したクエリの実行計画（EXPLAIN）もログに出す（本番では使わない）
+}
+
+// SlowQueryLog は閾値を超えたクエリをログに出し、リポジトリのメソッドごとに件数を数えるGORMのプラグイン
+// パラメータは数値・日時・UUIDなどだけを出し、文字列は伏せる（パスワードのハッシュやメールアドレスを出さないため）
+// 実行計画はトランザクションの外のSELECT・UPDATE・DELETEだけ取得する（PostgreSQLは失敗したトランザクションを続けられないため）
+type SlowQueryLog struct {
+	cfg    SlowQueryConfig
+	logger entities.Logger
+
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+var _ service.SlowQueryMonitor = (*SlowQueryLog)(nil)
+
+// NewSlowQueryLog は新しいSlowQueryLogを作成
+func NewSlowQueryLog(cfg SlowQueryConfig, logger entities.Logger) *SlowQueryLog {
+	return &SlowQueryLog{cfg: cfg, logger: logger, counts: make(map[string]int64)}
+}
+
+// Name はGORMのプラグイン名
+func (s *SlowQueryLog) Name() string {
+	return "point_system:slow_query_log"
+}
+
+// Initialize はすべての種類のクエリの前後にコールバックを登録する（閾値が0なら何もしない）
+func (s *SlowQueryLog) Initialize(db *gorm.DB) error {
+	if s.cfg.Threshold <= 0 {
+		return nil
+	}
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("slow_query:start", s.start),
+		cb.Create().After("gorm:create").Register("slow_query:finish", s.finish),
+		cb.Query().Before("gorm:query").Register("slow_query:start", s.start),
+		cb.Query().After("gorm:query").Register("slow_query:finish", s.finish),
+		cb.Update().Before("gorm:update").Register("slow_query:start", s.start),
+		cb.Update().After("gorm:update").Register("slow_query:finish", s.finish),
+		cb.Delete().Before("gorm:delete").Register("slow_query:start", s.start),
+		cb.Delete().After("gorm:delete").Register("slow_query:finish", s.finish),
+		cb.Row().Before("gorm:row").Register("slow_query:start", s.start),
+		cb.Row().After("gorm:row").Register("slow_query:finish", s.finishRow),
+		cb.Raw().Before("gorm:raw").Register("slow_query:start", s.start),
+		cb.Raw().After("gorm:raw").Register("slow_query:finish", s.finish),
+	} {
+		if err != nil {
+			return fmt.Errorf("failed to register slow query callback: %w", err)
+		}
+	}
+	return nil
+}
+
+// SlowQueries はメソッドごとの遅いクエリの件数をメソッド名の順に返す
+func (s *SlowQueryLog) SlowQueries() []service.SlowQueryCount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := make([]service.SlowQueryCount, 0, len(s.counts))
+	for method, count := range s.counts {
+		counts = append(counts, service.SlowQueryCount{Method: method, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].Method < counts[j].Method })
+	return counts
+}
+
+func (s *SlowQueryLog) start(db *gorm.DB) {
+	db.InstanceSet(slowQueryStartedKey, time.Now())
+}
+
+func (s *SlowQueryLog) finish(db *gorm.DB) {
+	s.record(db, s.cfg.Explain)
+}
+
+// finishRow はRow・Rowsのクエリを記録する
+// 呼び出し元が結果を読み終えるまで接続を使っているため、実行計画は取得しない（SQLiteは接続が1本なので待ち続ける）
+func (s *SlowQueryLog) finishRow(db *gorm.DB) {
+	s.record(db, false)
+}
+
+func (s *SlowQueryLog) record(db *gorm.DB, withPlan bool) {
+	v, ok := db.InstanceGet(slowQueryStartedKey)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(v.(time.Time))
+	if elapsed < s.cfg.Threshold {
+		return
+	}
+
+	method := callerMethod()
+	s.mu.Lock()
+	s.counts[method]++
+	s.mu.Unlock()
+
+	query := db.Statement.SQL.String()
+	fields := []entities.Field{
+		entities.NewField("method", method),
+		entities.NewField("elapsed_ms", elapsed.Milliseconds()),
+		entities.NewField("sql", query),
+		entities.NewField("params", maskParams(db.Statement.Vars)),
+		entities.NewField("rows", db.Statement.RowsAffected),
+	}
+	if db.Error != nil {
+		fields = append(fields, entities.NewField("error", db.Error))
+	}
+	if withPlan && db.Error == nil {
+		if plan, ok := explain(db, query); ok {
+			fields = append(fields, entities.NewField("plan", plan))
+		}
+	}
+	s.logger.Warn("Slow query", fields...)
+}
+
+// explain はクエリの実行計画を取得する（トランザクションの中・取得できない種類のクエリ・取得に失敗した場合はfalse）
+// GORMのコールバックを通さないよう接続に直接問い合わせる
+func explain(db *gorm.DB, query string) (string, bool) {
+	if _, inTx := db.Statement.ConnPool.(gorm.TxCommitter); inTx {
+		return "", false
+	}
+	verb := strings.ToUpper(strings.SplitN(strings.TrimSpace(query), " ", 2)[0])
+	if verb != "SELECT" && verb != "UPDATE" && verb != "DELETE" {
+		return "", false
+	}
+	prefix := "EXPLAIN "
+	if db.Dialector.Name() == "sqlite" {
+		prefix = "EXPLAIN QUERY PLAN "
+	}
+
+	rows, err := db.Statement.ConnPool.QueryContext(db.Statement.Context, prefix+query, db.Statement.Vars...)
+	if err != nil {
+		return "", false
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", false
+	}
+
+	var lines []string
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return "", false
+		}
+		// SQLiteは (id, parent, notused, detail) を返すため、最後の列（説明）だけを使う
+		line := fmt.Sprint(values[len(values)-1])
+		if b, ok := values[len(values)-1].([]byte); ok {
+			line = string(b)
+		}
+		lines = append(lines, line)
+	}
+	if rows.Err() != nil {
+		return "", false
+	}
+	return strings.Join(lines, "\n"), true
+}
+
+// callerMethod はクエリを実行したリポジトリのメソッド名を返す
+// リポジトリを通さずに呼ばれたデータソースはそのメソッド名、どちらでもなければ unknown
+func callerMethod() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	datasource := ""
+	for {
+		frame, more := frames.Next()
+		switch {
+		case strings.Contains(frame.Function, "/gateways/repository/"):
+			return shortMethodName(frame.Function)
+		case datasource == "" && strings.Contains(frame.Function, "/gateways/datasource/"):
+			datasource = shortMethodName(frame.Function)
+		}
+		if !more {
+			break
+		}
+	}
+	if datasource != "" {
+		return datasource
+	}
+	return slowQueryUnknownMethod
+}
+
+// shortMethodName は関数の完全名から「パッケージ.型.メソッド」を取り出す（リポジトリの型名はどれもRepositoryImplのため）
+// 例: github.com/.../repository/transaction.(*RepositoryImpl).Read → transaction.RepositoryImpl.Read
+// クロージャの中で実行した場合（トランザクションなど）は .func1 などを除く
+func shortMethodName(function string) string {
+	name := function[strings.LastIndex(function, "/")+1:]
+	name = strings.NewReplacer("(*", "", "(", "", ")", "").Replace(name)
+	parts := strings.Split(name, ".")
+	for len(parts) > 3 && strings.HasPrefix(parts[len(parts)-1], "func") {
+		parts = parts[:len(parts)-1]
+	}
+	return strings.Join(parts, ".")
+}
+
+// maskParams はログに出すパラメータを作る（数値・真偽値・日時・UUID以外は伏せる）
+func maskParams(vars []interface{}) []string {
+	params := make([]string, len(vars))
+	for i, v := range vars {
+		params[i] = maskParam(v)
+	}
+	return params
+}
+
+func maskParam(v interface{}) string {
+	if v == nil {
+		return "NULL"
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return "NULL"
+		}
+		return maskParam(rv.Elem().Interface())
+	}
+	switch x := v.(type) {
+	case uuid.UUID:
+		return x.String()
+	case time.Time:
+		return x.Format(time.RFC3339Nano)
+	case bool:
+		return fmt.Sprint(x)
+	case driver.Valuer:
+		return maskedValue
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v)
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return maskedValue
+		}
+		items := make([]string, rv.Len())
+		for i := range items {
+			items[i] = maskParam(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	return maskedValue
+}
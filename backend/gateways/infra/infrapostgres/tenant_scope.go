@@ -0,0 +1,97 @@
+package infrapostgres
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/gity/point-system/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// tenantColumn はテナントごとに分けるテーブルのテナントIDの列
+const tenantColumn = "tenant_id"
+
+// tenantSettingKey はクエリの対象テナントをgorm.DBに保持するためのキー
+const tenantSettingKey = "point_system:tenant_id"
+
+// RegisterTenantScope はテナントIDの列を持つテーブルをリクエストのテナントに自動で絞り込むコールバックを登録する
+// GetDB・GetReadDBで取得したDBは、contextにテナント（entities.WithTenantID）があれば
+//   - 検索・更新・削除: WHERE tenant_id = ? を追加する
+//   - 作成: テナントIDがゼロ値ならリクエストのテナントを入れる
+//
+// テナントのないcontext（ワーカーなど）ではすべてのテナントの行を対象にする
+// Raw・Exec・モデルを指定しないTableで書いたSQLは対象外のため、テナントごとのテーブルを直接参照する場合は自分で絞り込む（dspostgresimplのtenantConditionなど）
+func RegisterTenantScope(db *gorm.DB) error {
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:before_create").Register("tenant:assign", assignTenant),
+		cb.Query().Before("gorm:query").Register("tenant:scope", scopeTenant),
+		cb.Row().Before("gorm:row").Register("tenant:scope", scopeTenant),
+		cb.Update().Before("gorm:update").Register("tenant:scope", scopeTenant),
+		cb.Delete().Before("gorm:delete").Register("tenant:scope", scopeTenant),
+	} {
+		if err != nil {
+			return fmt.Errorf("failed to register tenant callback: %w", err)
+		}
+	}
+	return nil
+}
+
+// withTenant はcontextのテナントをDBに設定する（テナントがなければそのまま返す）
+// Setの返すDBは続けて組み立てたクエリの条件を次のクエリへ持ち越すため、新しいセッションにして使い回せるようにする
+func withTenant(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tenantID, ok := entities.TenantIDFromContext(ctx); ok {
+		return db.Set(tenantSettingKey, tenantID).Session(&gorm.Session{})
+	}
+	return db
+}
+
+// tenantField はステートメントの対象がテナントごとのテーブルなら、テナントとテナントIDの列を返す
+func tenantField(db *gorm.DB) (interface{}, bool) {
+	tenantID, ok := db.Get(tenantSettingKey)
+	if !ok || db.Statement.Schema == nil {
+		return nil, false
+	}
+	if _, ok := db.Statement.Schema.FieldsByDBName[tenantColumn]; !ok {
+		return nil, false
+	}
+	return tenantID, true
+}
+
+func scopeTenant(db *gorm.DB) {
+	tenantID, ok := tenantField(db)
+	if !ok {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: tenantColumn}, Value: tenantID},
+	}})
+}
+
+func assignTenant(db *gorm.DB) {
+	tenantID, ok := tenantField(db)
+	if !ok {
+		return
+	}
+	field := db.Statement.Schema.FieldsByDBName[tenantColumn]
+	ctx := db.Statement.Context
+	assign := func(rv reflect.Value) {
+		if _, zero := field.ValueOf(ctx, rv); zero {
+			if err := field.Set(ctx, rv, tenantID); err != nil {
+				db.AddError(err)
+			}
+		}
+	}
+
+	rv := reflect.Indirect(db.Statement.ReflectValue)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			assign(reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		assign(rv)
+	}
+}
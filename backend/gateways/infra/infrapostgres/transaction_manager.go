@@ -24,9 +24,10 @@ func NewGormTransactionManager(db *gorm.DB) *GormTransactionManager {
 
 // Do は関数fnをトランザクション内で実行します
 // fn内でエラーが返ればRollback、nilならCommitされます
+// ctxがキャンセルされるとトランザクションはRollbackされます（database/sqlの仕様）
 func (tm *GormTransactionManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
 	// トランザクション開始
-	tx := tm.db.Begin()
+	tx := tm.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
 		return fmt.Errorf("failed to begin transaction: %w", tx.Error)
 	}
@@ -61,9 +62,11 @@ func (tm *GormTransactionManager) Do(ctx context.Context, fn func(ctx context.Co
 
 // GetDB はcontextからトランザクションを取得します
 // トランザクションが存在しない場合はdefaultDBを返します
+// クエリはctxで実行するため、リクエストの期限切れ・キャンセルで中断されます
+// contextにテナントがあれば、テナントごとのテーブルはそのテナントの行に絞り込みます（RegisterTenantScope）
 func GetDB(ctx context.Context, defaultDB *gorm.DB) *gorm.DB {
 	if tx, ok := ctx.Value(txKey).(*gorm.DB); ok {
-		return tx
+		return withTenant(ctx, tx.WithContext(ctx))
 	}
-	return defaultDB
+	return withTenant(ctx, defaultDB.WithContext(ctx))
 }
@@ -0,0 +1,201 @@
+package infrapush
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/service"
+)
+
+const (
+	apnsProductionBase = "https://api.push.apple.com"
+	apnsSandboxBase    = "https://api.sandbox.push.apple.com"
+
+	// apnsTokenLifetime は認証トークンを使い回す期間（APNsは20分〜60分での更新を求める）
+	apnsTokenLifetime = 50 * time.Minute
+)
+
+// APNsConfig はAPNsのトークン認証の設定
+type APNsConfig struct {
+	KeyFile string // App Store Connectで発行した .p8 鍵
+	KeyID   string
+	TeamID  string
+	Topic   string // アプリのバンドルID
+	BaseURL string // 空ならProductionに応じて本番またはサンドボックス
+	Timeout time.Duration
+
+	Production bool
+}
+
+// APNsClient はApple Push Notification service（HTTP/2 API）でiOS端末へ送る実装
+// 認証は .p8 鍵で署名したJWT（ES256）で行う
+type APNsClient struct {
+	cfg     APNsConfig
+	key     *ecdsa.PrivateKey
+	baseURL string
+	client  *http.Client
+
+	mu        sync.Mutex
+	authToken string
+	issuedAt  time.Time
+}
+
+// NewAPNsClient は新しいAPNsClientを作成
+func NewAPNsClient(cfg APNsConfig) (*APNsClient, error) {
+	if cfg.KeyID == "" || cfg.TeamID == "" || cfg.Topic == "" {
+		return nil, errors.New("APNs requires key id, team id and topic")
+	}
+	data, err := os.ReadFile(cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read APNs key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("APNs key is not PEM encoded")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse APNs key: %w", err)
+	}
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("APNs key must be an ECDSA P-256 key")
+	}
+
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = apnsSandboxBase
+		if cfg.Production {
+			baseURL = apnsProductionBase
+		}
+	}
+	return &APNsClient{
+		cfg:     cfg,
+		key:     key,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		// 既定のTransportはTLSのALPNでHTTP/2を使う
+		client: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+type apnsAPS struct {
+	Alert apnsAlert `json:"alert"`
+	Sound string    `json:"sound"`
+}
+
+type apnsAlert struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// Send はAPNsへ通知を送る（トークンが無効な場合はErrPushTokenUnregistered）
+func (c *APNsClient) Send(ctx context.Context, device *entities.DeviceToken, notification *entities.Notification) error {
+	authToken, err := c.token()
+	if err != nil {
+		return err
+	}
+
+	// アプリ向けのデータは aps と並べてトップレベルに置く
+	payload := map[string]interface{}{}
+	for k, v := range pushData(notification) {
+		payload[k] = v
+	}
+	payload["aps"] = apnsAPS{
+		Alert: apnsAlert{Title: notification.Title, Body: notification.Body},
+		Sound: "default",
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal APNs payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/3/device/"+device.Token, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create APNs request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "bearer "+authToken)
+	req.Header.Set("apns-topic", c.cfg.Topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("APNs request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var result struct {
+		Reason string `json:"reason"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+
+	switch {
+	case resp.StatusCode == http.StatusGone,
+		result.Reason == "BadDeviceToken",
+		result.Reason == "Unregistered",
+		result.Reason == "DeviceTokenNotForTopic":
+		return service.ErrPushTokenUnregistered
+	case result.Reason == "ExpiredProviderToken":
+		c.invalidateToken()
+	}
+	return fmt.Errorf("APNs returned status %d: %s", resp.StatusCode, result.Reason)
+}
+
+// token はキャッシュした認証トークンを返す（一定時間ごとに作り直す）
+func (c *APNsClient) token() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.authToken != "" && time.Since(c.issuedAt) < apnsTokenLifetime {
+		return c.authToken, nil
+	}
+
+	now := time.Now()
+	token, err := signJWT(
+		map[string]interface{}{"alg": "ES256", "kid": c.cfg.KeyID},
+		map[string]interface{}{"iss": c.cfg.TeamID, "iat": now.Unix()},
+		func(digest []byte) ([]byte, error) {
+			r, s, err := ecdsa.Sign(rand.Reader, c.key, digest)
+			if err != nil {
+				return nil, err
+			}
+			// JWSのES256は r と s を32バイトずつ並べた形式
+			signature := make([]byte, 64)
+			r.FillBytes(signature[:32])
+			s.FillBytes(signature[32:])
+			return signature, nil
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	c.authToken = token
+	c.issuedAt = now
+	return c.authToken, nil
+}
+
+// invalidateToken は認証トークンの期限切れ時に次回作り直す
+func (c *APNsClient) invalidateToken() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.authToken = ""
+}
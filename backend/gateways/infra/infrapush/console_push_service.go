@@ -0,0 +1,29 @@
+package infrapush
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/service"
+)
+
+// ConsolePushService は通知をログに出力する実装（開発用、または配信先が未設定のプラットフォーム用）
+type ConsolePushService struct {
+	logger entities.Logger
+}
+
+// NewConsolePushService は新しいConsolePushServiceを作成
+func NewConsolePushService(logger entities.Logger) service.PushNotificationService {
+	return &ConsolePushService{logger: logger}
+}
+
+// Send は通知の内容をログに出力する
+func (s *ConsolePushService) Send(ctx context.Context, device *entities.DeviceToken, notification *entities.Notification) error {
+	s.logger.Info("Sending push notification",
+		entities.NewField("user_id", device.UserID),
+		entities.NewField("platform", string(device.Platform)),
+		entities.NewField("type", string(notification.Type)),
+		entities.NewField("title", notification.Title),
+		entities.NewField("body", notification.Body))
+	return nil
+}
@@ -0,0 +1,248 @@
+package infrapush
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/service"
+)
+
+const (
+	fcmScope       = "https://www.googleapis.com/auth/firebase.messaging"
+	fcmDefaultBase = "https://fcm.googleapis.com"
+)
+
+// FCMCredentials はFirebaseのサービスアカウント鍵（JSON）のうち送信に使う項目
+type FCMCredentials struct {
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// LoadFCMCredentials はサービスアカウント鍵のファイルを読み込む
+func LoadFCMCredentials(path string) (*FCMCredentials, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read FCM credentials: %w", err)
+	}
+	var creds FCMCredentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse FCM credentials: %w", err)
+	}
+	if creds.ProjectID == "" || creds.ClientEmail == "" || creds.PrivateKey == "" || creds.TokenURI == "" {
+		return nil, errors.New("FCM credentials must include project_id, client_email, private_key and token_uri")
+	}
+	return &creds, nil
+}
+
+// FCMClient はFirebase Cloud Messaging（HTTP v1 API）でAndroid端末へ送る実装
+//
+// 認証はサービスアカウント鍵で署名したJWTをOAuth2アクセストークンに交換して行う
+// アクセストークンは期限の1分前まで使い回す
+type FCMClient struct {
+	creds   *FCMCredentials
+	key     *rsa.PrivateKey
+	baseURL string
+	client  *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// NewFCMClient は新しいFCMClientを作成（baseURLが空ならFCMの本番エンドポイント）
+func NewFCMClient(creds *FCMCredentials, baseURL string, timeout time.Duration) (*FCMClient, error) {
+	block, _ := pem.Decode([]byte(creds.PrivateKey))
+	if block == nil {
+		return nil, errors.New("FCM private key is not PEM encoded")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse FCM private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("FCM private key must be an RSA key")
+	}
+
+	if baseURL == "" {
+		baseURL = fcmDefaultBase
+	}
+	return &FCMClient{
+		creds:   creds,
+		key:     key,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}, nil
+}
+
+type fcmMessage struct {
+	Message fcmMessageBody `json:"message"`
+}
+
+type fcmMessageBody struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type fcmErrorResponse struct {
+	Error struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// Send はFCMへ通知を送る（UNREGISTEREDの場合はErrPushTokenUnregistered）
+func (c *FCMClient) Send(ctx context.Context, device *entities.DeviceToken, notification *entities.Notification) error {
+	accessToken, err := c.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(fcmMessage{Message: fcmMessageBody{
+		Token:        device.Token,
+		Notification: fcmNotification{Title: notification.Title, Body: notification.Body},
+		Data:         pushData(notification),
+	}})
+	if err != nil {
+		return fmt.Errorf("failed to marshal FCM message: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/projects/%s/messages:send", c.baseURL, url.PathEscape(c.creds.ProjectID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create FCM request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("FCM request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var errResp fcmErrorResponse
+	_ = json.NewDecoder(resp.Body).Decode(&errResp)
+	if resp.StatusCode == http.StatusNotFound {
+		return service.ErrPushTokenUnregistered
+	}
+	for _, detail := range errResp.Error.Details {
+		if detail.ErrorCode == "UNREGISTERED" {
+			return service.ErrPushTokenUnregistered
+		}
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		c.invalidateToken()
+	}
+	return fmt.Errorf("FCM returned status %d: %s", resp.StatusCode, errResp.Error.Status)
+}
+
+// token はキャッシュしたアクセストークンを返す（期限が近ければ取り直す）
+func (c *FCMClient) token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.accessToken != "" && time.Now().Before(c.expiresAt.Add(-time.Minute)) {
+		return c.accessToken, nil
+	}
+
+	now := time.Now()
+	assertion, err := signJWT(
+		map[string]interface{}{"alg": "RS256", "typ": "JWT"},
+		map[string]interface{}{
+			"iss":   c.creds.ClientEmail,
+			"scope": fcmScope,
+			"aud":   c.creds.TokenURI,
+			"iat":   now.Unix(),
+			"exp":   now.Add(time.Hour).Unix(),
+		},
+		func(digest []byte) ([]byte, error) {
+			return rsa.SignPKCS1v15(rand.Reader, c.key, crypto.SHA256, digest)
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.creds.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create FCM token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("FCM token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("FCM token endpoint returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode FCM token response: %w", err)
+	}
+	if result.AccessToken == "" {
+		return "", errors.New("FCM token response has no access_token")
+	}
+
+	c.accessToken = result.AccessToken
+	c.expiresAt = now.Add(time.Duration(result.ExpiresIn) * time.Second)
+	return c.accessToken, nil
+}
+
+// invalidateToken は認証エラー時にアクセストークンを捨てて次回取り直す
+func (c *FCMClient) invalidateToken() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accessToken = ""
+}
+
+// pushData は通知の種類をアプリ向けのデータに加える
+func pushData(notification *entities.Notification) map[string]string {
+	data := make(map[string]string, len(notification.Data)+1)
+	for k, v := range notification.Data {
+		data[k] = v
+	}
+	data["type"] = string(notification.Type)
+	return data
+}
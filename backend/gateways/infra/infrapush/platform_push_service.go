@@ -0,0 +1,28 @@
+package infrapush
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/service"
+)
+
+// PlatformPushService は端末のプラットフォームに応じて配信先を振り分ける実装
+// 配信先が設定されていないプラットフォームはfallbackで送る
+type PlatformPushService struct {
+	services map[entities.DevicePlatform]service.PushNotificationService
+	fallback service.PushNotificationService
+}
+
+// NewPlatformPushService は新しいPlatformPushServiceを作成
+func NewPlatformPushService(services map[entities.DevicePlatform]service.PushNotificationService, fallback service.PushNotificationService) service.PushNotificationService {
+	return &PlatformPushService{services: services, fallback: fallback}
+}
+
+// Send は端末のプラットフォームの配信先へ送る
+func (s *PlatformPushService) Send(ctx context.Context, device *entities.DeviceToken, notification *entities.Notification) error {
+	if svc, ok := s.services[device.Platform]; ok {
+		return svc.Send(ctx, device, notification)
+	}
+	return s.fallback.Send(ctx, device, notification)
+}
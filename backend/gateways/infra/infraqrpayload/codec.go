@@ -0,0 +1,168 @@
+package infraqrpayload
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+)
+
+// ディープリンクの形式: gity://qr?a={amount}&exp={unix}&p={data}&t={type}&v={version}&sig={signature}
+// 署名はsigを除いたクエリ（キー順に並べたもの）のHMAC-SHA256をbase64url（パディングなし）にしたもの
+const (
+	scheme = "gity"
+	host   = "qr"
+
+	paramVersion   = "v"
+	paramType      = "t"
+	paramData      = "p"
+	paramAmount    = "a"
+	paramExpiresAt = "exp"
+	paramSignature = "sig"
+)
+
+// keyContext は設定した秘密鍵から署名用の鍵を導出するときの用途（ほかの用途と鍵を分ける）
+const keyContext = "gity/qr-payload"
+
+// Codec はHMAC-SHA256で署名したディープリンクでQRペイロードを表すservice.QRPayloadCodecの実装
+type Codec struct {
+	key []byte
+}
+
+// NewCodec は秘密鍵から導出した鍵で署名するCodecを作成
+func NewCodec(secret string) service.QRPayloadCodec {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(keyContext))
+	return &Codec{key: mac.Sum(nil)}
+}
+
+// Encode はペイロードに署名し、QRコードに埋め込むディープリンクにする
+func (c *Codec) Encode(payload *entities.QRPayload) (string, error) {
+	if !validType(payload.Type) || payload.Data == "" {
+		return "", entities.ErrInvalidQRPayload
+	}
+
+	q := url.Values{}
+	q.Set(paramVersion, strconv.Itoa(entities.QRPayloadVersionCurrent))
+	q.Set(paramType, string(payload.Type))
+	q.Set(paramData, payload.Data)
+	if payload.Amount != nil {
+		q.Set(paramAmount, strconv.FormatInt(*payload.Amount, 10))
+	}
+	if payload.ExpiresAt != nil {
+		q.Set(paramExpiresAt, strconv.FormatInt(payload.ExpiresAt.Unix(), 10))
+	}
+	signed := q.Encode()
+
+	return scheme + "://" + host + "?" + signed + "&" + paramSignature + "=" + c.sign(signed), nil
+}
+
+// Decode はディープリンクまたは旧形式の文字列を読み取ってペイロードを返す
+func (c *Codec) Decode(data string) (*entities.QRPayload, error) {
+	data = strings.TrimSpace(data)
+	if !strings.HasPrefix(data, scheme+"://") {
+		return decodeLegacy(data)
+	}
+
+	u, err := url.Parse(data)
+	if err != nil || u.Host != host {
+		return nil, entities.ErrInvalidQRPayload
+	}
+	q := u.Query()
+
+	version, err := strconv.Atoi(q.Get(paramVersion))
+	if err != nil || version < entities.QRPayloadVersionCurrent {
+		return nil, entities.ErrInvalidQRPayload
+	}
+	if version > entities.QRPayloadVersionCurrent {
+		return nil, entities.ErrUnsupportedQRPayloadVersion
+	}
+
+	signature := q.Get(paramSignature)
+	q.Del(paramSignature)
+	if !hmac.Equal([]byte(signature), []byte(c.sign(q.Encode()))) {
+		return nil, entities.ErrInvalidQRPayload
+	}
+
+	payload := &entities.QRPayload{
+		Type:    entities.QRPayloadType(q.Get(paramType)),
+		Version: version,
+		Data:    q.Get(paramData),
+	}
+	if !validType(payload.Type) || payload.Data == "" {
+		return nil, entities.ErrInvalidQRPayload
+	}
+	if s := q.Get(paramAmount); s != "" {
+		amount, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, entities.ErrInvalidQRPayload
+		}
+		payload.Amount = &amount
+	}
+	if s := q.Get(paramExpiresAt); s != "" {
+		unix, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, entities.ErrInvalidQRPayload
+		}
+		expiresAt := time.Unix(unix, 0)
+		payload.ExpiresAt = &expiresAt
+	}
+	if payload.IsExpired(time.Now()) {
+		return nil, entities.ErrQRCodeExpired
+	}
+	return payload, nil
+}
+
+func (c *Codec) sign(query string) string {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(query))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// decodeLegacy は署名のない旧形式（user:{user_id}、receive:{code}[:{amount}]、send:{code}:{amount}）を読む
+func decodeLegacy(data string) (*entities.QRPayload, error) {
+	parts := strings.Split(data, ":")
+	payload := &entities.QRPayload{
+		Type:    entities.QRPayloadType(parts[0]),
+		Version: entities.QRPayloadVersionLegacy,
+	}
+
+	switch {
+	case payload.Type == entities.QRPayloadTypeUser && len(parts) == 2:
+		if _, err := uuid.Parse(parts[1]); err != nil {
+			return nil, entities.ErrInvalidQRPayload
+		}
+	case payload.Type == entities.QRPayloadTypeReceive && (len(parts) == 2 || len(parts) == 3),
+		payload.Type == entities.QRPayloadTypeSend && len(parts) == 3:
+		if len(parts) == 3 {
+			amount, err := strconv.ParseInt(parts[2], 10, 64)
+			if err != nil {
+				return nil, entities.ErrInvalidQRPayload
+			}
+			payload.Amount = &amount
+		}
+	default:
+		return nil, entities.ErrInvalidQRPayload
+	}
+
+	payload.Data = parts[1]
+	if payload.Data == "" {
+		return nil, entities.ErrInvalidQRPayload
+	}
+	return payload, nil
+}
+
+func validType(t entities.QRPayloadType) bool {
+	switch t {
+	case entities.QRPayloadTypeUser, entities.QRPayloadTypeReceive, entities.QRPayloadTypeSend:
+		return true
+	}
+	return false
+}
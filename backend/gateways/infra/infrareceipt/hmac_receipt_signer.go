@@ -0,0 +1,38 @@
+package infrareceipt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/gity/point-system/usecases/service"
+)
+
+// keyContext は設定した秘密鍵から署名用の鍵を導出するときの用途（ほかの用途と鍵を分ける）
+const keyContext = "gity/transaction-receipt"
+
+// HMACReceiptSigner はHMAC-SHA256（16進数）を検証ハッシュとするservice.ReceiptSignerの実装
+type HMACReceiptSigner struct {
+	key []byte
+}
+
+// NewHMACReceiptSigner は秘密鍵から導出した鍵で署名するHMACReceiptSignerを作成
+func NewHMACReceiptSigner(secret string) service.ReceiptSigner {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(keyContext))
+	return &HMACReceiptSigner{key: mac.Sum(nil)}
+}
+
+// Sign は控えの内容の検証ハッシュを返す
+func (s *HMACReceiptSigner) Sign(payload string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify は提示された検証ハッシュが控えの内容と一致するかを判定（大文字・前後の空白は無視する）
+func (s *HMACReceiptSigner) Verify(payload, hash string) bool {
+	hash = strings.ToLower(strings.TrimSpace(hash))
+	return hmac.Equal([]byte(hash), []byte(s.Sign(payload)))
+}
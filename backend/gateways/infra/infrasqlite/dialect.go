@@ -0,0 +1,81 @@
+package infrasqlite
+
+import (
+	"database/sql/driver"
+	"strings"
+	"time"
+
+	gosqlite "github.com/glebarez/go-sqlite"
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+)
+
+// モデルのデフォルト値やSQLで使っているPostgreSQLの関数をSQLiteでも使えるように登録する
+// （登録後に開いた接続から使える）
+func init() {
+	gosqlite.MustRegisterScalarFunction("gen_random_uuid", 0, func(ctx *gosqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		return uuid.New().String(), nil
+	})
+	gosqlite.MustRegisterScalarFunction("now", 0, func(ctx *gosqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		// ドライバーが time.Time を書き込むときと同じ形式にして、文字列のまま比較できるようにする
+		return time.Now().Format("2006-01-02 15:04:05.999999999-07:00"), nil
+	})
+}
+
+// dialector はモデルのPostgreSQL向けの型・デフォルト値をSQLiteの表現に読み替えるDialector
+type dialector struct {
+	sqlite.Dialector
+}
+
+func newDialector(dsn string) gorm.Dialector {
+	return dialector{Dialector: sqlite.Dialector{DSN: dsn}}
+}
+
+// DataTypeOf はPostgreSQL固有の型をSQLiteの型に読み替える
+// 日時はドライバーが time.Time として読み込める型名（datetime）にする
+func (d dialector) DataTypeOf(field *schema.Field) string {
+	switch strings.ToLower(string(field.DataType)) {
+	case "timestamptz", "timestamp with time zone":
+		return "datetime"
+	case "uuid", "jsonb", "inet":
+		return "text"
+	case "bytea":
+		return "blob"
+	}
+	return d.Dialector.DataTypeOf(field)
+}
+
+// Migrator は読み替えた型でテーブルを作るMigratorを返す
+func (d dialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return sqliteMigrator{sqlite.Migrator{Migrator: migrator.Migrator{Config: migrator.Config{
+		DB:                          db,
+		Dialector:                   d,
+		CreateIndexAfterCreateTable: true,
+	}}}}
+}
+
+// sqliteMigrator は関数呼び出しのデフォルト値（now()・gen_random_uuid()）を括弧で囲むMigrator
+// SQLiteは DEFAULT に式を書く場合に括弧が必要
+type sqliteMigrator struct {
+	sqlite.Migrator
+}
+
+// FullDataTypeOf はカラムの型・NOT NULL・デフォルト値を返す
+func (m sqliteMigrator) FullDataTypeOf(field *schema.Field) clause.Expr {
+	expr := m.Migrator.FullDataTypeOf(field)
+	if field.DefaultValueInterface == nil && strings.HasSuffix(field.DefaultValue, ")") {
+		expr.SQL = strings.Replace(expr.SQL, " DEFAULT "+field.DefaultValue, " DEFAULT ("+field.DefaultValue+")", 1)
+	}
+	return expr
+}
+
+// MigrateColumn は既存カラムの変更を行わない
+// SQLiteはカラムの変更にテーブルの作り直しが必要で、デフォルト値の表記の違いでも作り直しになるため
+// 足りないテーブル・カラムの追加だけを行う
+func (m sqliteMigrator) MigrateColumn(value interface{}, field *schema.Field, columnType gorm.ColumnType) error {
+	return nil
+}
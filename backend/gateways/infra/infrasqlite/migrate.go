@@ -0,0 +1,28 @@
+package infrasqlite
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/gity/point-system/gateways/infra/infrapostgres"
+)
+
+// seedSQL は migrations/ の初期データ（管理者・テストユーザー、カテゴリ、システム設定など）をSQLite向けに書き直したもの
+//
+//go:embed seed.sql
+var seedSQL string
+
+// Migrate はモデルからテーブルを作成し、初期データを投入する
+// migrations/ のSQLはPostgreSQL専用のため、SQLiteではGORMのAutoMigrateでスキーマを作る
+// 初期データは既にある行を上書きしないので、起動のたびに呼んでよい
+func Migrate(ctx context.Context, db infrapostgres.DB, models ...interface{}) error {
+	gormDB := db.GetDB().WithContext(ctx)
+	if err := gormDB.AutoMigrate(models...); err != nil {
+		return fmt.Errorf("failed to create schema: %w", err)
+	}
+	if err := gormDB.Exec(seedSQL).Error; err != nil {
+		return fmt.Errorf("failed to seed database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+-- migrations/ の初期データのSQLite版（INSERT OR IGNORE で既存の行は変えない）
+
+-- 既定のテナント（既存のデータ・テナントを指定しないリクエストはここに入る）
+INSERT OR IGNORE INTO tenants (id, slug, name, is_active, created_at, updated_at) VALUES
+('00000000-0000-0000-0000-000000000001', 'default', '既定のテナント', true, now(), now());
+
+-- 管理者: admin / admin123、テストユーザー: testuser / test123
+-- usersのIDと日時はアプリ側で入れるため、ここで明示する
+INSERT OR IGNORE INTO users (id, username, email, password_hash, display_name, role, balance, email_sha256, username_sha256, created_at, updated_at) VALUES
+(gen_random_uuid(), 'admin', 'admin@example.com', '$2a$10$Rw0nZrWoh7CvWW9HbwYM6.P251lNv94/jmhuLLhWF70Qt2vH8dthO', 'System Administrator', 'admin', 1000000,
+ '258d8dc916db8cea2cafb6c3cd0cb0246efe061421dbd83ec3a350428cabda4f', '8c6976e5b5410415bde908bd4dee15dfb167a9c873fc4bb8a81f6f2ab448a918', now(), now()),
+(gen_random_uuid(), 'testuser', 'test@example.com', '$2a$10$Icg8iyLTgkbpAT8TNLIxG.HigjDo4EjmqyLjELlu1XBlU0uyx8emy', 'Test User', 'user', 10000,
+ '973dfe463ec85785f5f95af5ba3906eedb2d931c24e69824a89ea65dba4e813b', 'ae5deb822e0d71992900471a7199d0d95b8e7c9d05c40a8245a281fd2c1d6684', now(), now());
+
+-- 初期残高は発行元（treasury）からの付与として記録する（PostgreSQLでは移行時のバッチが同じ役割を持つ）
+-- 取引のないユーザーだけが対象なので、残高が変わった後に適用しても付与し直さない
+INSERT INTO transactions (id, to_user_id, from_system_account, amount, transaction_type, status, description, metadata, created_at, completed_at)
+SELECT gen_random_uuid(), u.id, 'treasury', u.balance, 'system_grant', 'completed', '初期残高', '{}', now(), now()
+FROM users u
+WHERE u.username IN ('admin', 'testuser') AND u.balance > 0
+    AND NOT EXISTS (SELECT 1 FROM transactions t WHERE t.to_user_id = u.id OR t.from_user_id = u.id);
+
+INSERT OR IGNORE INTO categories (name, code, description, display_order, is_active) VALUES
+('飲み物', 'drink', 'ジュースやお茶などの飲料', 1, true),
+('お菓子', 'snack', 'スナックやチョコレートなどのお菓子', 2, true),
+('おもちゃ', 'toy', 'ガンプラやカードゲームなどのおもちゃ', 3, true),
+('その他', 'other', 'その他の商品', 99, true);
+
+INSERT OR IGNORE INTO akerun_poll_state (id, last_polled_at) VALUES (1, now());
+
+INSERT OR IGNORE INTO system_settings (key, value, description) VALUES
+('akerun_bonus_points', '5', 'Akerun入退室ボーナスのポイント数'),
+('point_expiry_grace_days', '30', '失効したポイントを管理者が取り消せる日数'),
+('account_erasure_mode', 'anonymize', '退会時に相手側の取引履歴に残る退会者のメモを消すか（anonymize / retain）'),
+('bonus_timezone', 'Asia/Tokyo', 'ボーナス日の区切り（AM6:00）に使うタイムゾーン');
+
+INSERT INTO bonus_lottery_tiers (name, points, probability, display_order)
+SELECT name, points, probability, display_order FROM (
+    SELECT '大当たり' AS name, 100 AS points, 1.00 AS probability, 1 AS display_order
+    UNION ALL SELECT '当たり', 10, 10.00, 2
+    UNION ALL SELECT '通常', 5, 89.00, 3
+)
+WHERE NOT EXISTS (SELECT 1 FROM bonus_lottery_tiers);
+
+INSERT OR IGNORE INTO reason_codes (code, label, description, created_at, updated_at) VALUES
+('reward', '表彰・インセンティブ', '成果や貢献に対する付与', now(), now()),
+('event', 'イベント参加', 'イベント・勉強会への参加に対する付与', now(), now()),
+('correction', '残高の訂正', '誤った付与・減算の訂正', now(), now()),
+('other', 'その他', '上記に当てはまらないもの', now(), now());
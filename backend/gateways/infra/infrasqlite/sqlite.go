@@ -0,0 +1,89 @@
+package infrasqlite
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/gity/point-system/gateways/infra/infrapostgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// MemoryPath を Config.Path に指定すると、プロセス内のメモリ上にDBを作る（終了すると消える）
+const MemoryPath = ":memory:"
+
+// SQLiteDB はSQLiteの接続実装
+// Dockerなしでデモや結合テストを動かすためのもので、本番ではPostgreSQLを使う
+type SQLiteDB struct {
+	db *gorm.DB
+}
+
+// Config はSQLiteの設定
+type Config struct {
+	Path string // DBファイルのパス（MemoryPath または空ならメモリ上）
+	Env  string
+}
+
+// NewSQLiteDB は新しいSQLiteDBを作成（pluginsはinfrapostgres.NewSlowQueryLogなど）
+func NewSQLiteDB(cfg *Config, plugins ...gorm.Plugin) (infrapostgres.DB, error) {
+	gormConfig := &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	}
+	if cfg.Env == "production" {
+		gormConfig.Logger = logger.Default.LogMode(logger.Error)
+	}
+
+	db, err := gorm.Open(newDialector(dsn(cfg.Path)), gormConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if err := infrapostgres.RegisterTenantScope(db); err != nil {
+		return nil, err
+	}
+	for _, plugin := range plugins {
+		if err := db.Use(plugin); err != nil {
+			return nil, fmt.Errorf("failed to register %s: %w", plugin.Name(), err)
+		}
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database instance: %w", err)
+	}
+
+	// SQLiteは書き込みが1つずつなので、接続を1本にしてトランザクションを直列に実行する
+	// （複数の接続から同時に書き込むとSQLITE_BUSYになる）。メモリ上のDBは接続を閉じると消えるため、期限も設けない
+	sqlDB.SetMaxOpenConns(1)
+	sqlDB.SetMaxIdleConns(1)
+	sqlDB.SetConnMaxLifetime(0)
+
+	return &SQLiteDB{db: db}, nil
+}
+
+// dsn はパスから接続文字列を作る（外部キー制約を有効にし、ロック待ちを5秒まで許す）
+func dsn(path string) string {
+	pragmas := url.Values{}
+	pragmas.Add("_pragma", "foreign_keys(1)")
+	pragmas.Add("_pragma", "busy_timeout(5000)")
+
+	if path == "" || path == MemoryPath {
+		return "file::memory:?" + pragmas.Encode()
+	}
+	pragmas.Add("_pragma", "journal_mode(WAL)")
+	return "file:" + strings.TrimPrefix(path, "file:") + "?" + pragmas.Encode()
+}
+
+// GetDB はGORMのDBインスタンスを取得
+func (s *SQLiteDB) GetDB() *gorm.DB {
+	return s.db
+}
+
+// Close はデータベース接続を閉じる
+func (s *SQLiteDB) Close() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
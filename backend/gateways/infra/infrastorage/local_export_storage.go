@@ -0,0 +1,107 @@
+package infrastorage
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gity/point-system/usecases/service"
+)
+
+// LocalExportStorage は個人データエクスポートのZIPをローカルファイルシステムに保存する実装
+type LocalExportStorage struct {
+	baseDir string
+}
+
+// NewLocalExportStorage は新しいLocalExportStorageを作成
+func NewLocalExportStorage(baseDir string) (service.DataExportStorage, error) {
+	if baseDir == "" {
+		return nil, errors.New("base directory is required")
+	}
+	if err := os.MkdirAll(baseDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create base directory: %w", err)
+	}
+	return &LocalExportStorage{baseDir: baseDir}, nil
+}
+
+// Save はファイルをZIPにまとめて保存し、ベースディレクトリからの相対パスを返す
+func (s *LocalExportStorage) Save(exportID string, files map[string][]byte) (string, error) {
+	fileName := exportID + ".zip"
+	fullPath := filepath.Join(s.baseDir, fileName)
+
+	out, err := os.OpenFile(fullPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+
+	// ZIP内の並びを毎回同じにする
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	zw := zip.NewWriter(out)
+	for _, name := range names {
+		w, err := zw.Create(name)
+		if err == nil {
+			_, err = w.Write(files[name])
+		}
+		if err != nil {
+			zw.Close()
+			out.Close()
+			os.Remove(fullPath)
+			return "", fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		out.Close()
+		os.Remove(fullPath)
+		return "", fmt.Errorf("failed to finalize archive: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(fullPath)
+		return "", fmt.Errorf("failed to close file: %w", err)
+	}
+
+	return fileName, nil
+}
+
+// Open は保存したZIPを開く
+func (s *LocalExportStorage) Open(filePath string) (io.ReadCloser, error) {
+	fullPath, err := s.resolve(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(fullPath)
+}
+
+// Delete は保存したZIPを削除（存在しない場合もエラーとしない）
+func (s *LocalExportStorage) Delete(filePath string) error {
+	fullPath, err := s.resolve(filePath)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// resolve は相対パスをベースディレクトリ配下の絶対パスに変換
+func (s *LocalExportStorage) resolve(filePath string) (string, error) {
+	if filePath == "" {
+		return "", errors.New("file path is empty")
+	}
+	// セキュリティチェック: パストラバーサル攻撃を防ぐ
+	cleanPath := filepath.Clean(filePath)
+	if strings.Contains(cleanPath, "..") {
+		return "", errors.New("invalid file path")
+	}
+	return filepath.Join(s.baseDir, cleanPath), nil
+}
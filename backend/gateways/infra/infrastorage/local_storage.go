@@ -12,22 +12,27 @@ import (
 	"time"
 
 	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
 )
 
 // LocalStorage はローカルファイルシステムを使用したストレージ実装
 type LocalStorage struct {
-	baseDir    string // 保存先のベースディレクトリ
-	baseURL    string // アクセス用のベースURL
-	maxSizeMB  int64  // 最大ファイルサイズ（MB）
-	allowedExt map[string]bool
+	baseDir             string // 保存先のベースディレクトリ
+	baseURL             string // アクセス用のベースURL
+	maxSizeMB           int64  // 最大ファイルサイズ（MB）
+	attachmentDir       string // 送金の添付画像の保存先（空なら保存できない）
+	attachmentMaxSizeMB int64  // 送金の添付画像の最大ファイルサイズ（MB）
+	allowedExt          map[string]bool
 }
 
 // Config はLocalStorageの設定
 type Config struct {
-	BaseDir    string   // 例: "./uploads/avatars"
-	BaseURL    string   // 例: "/uploads/avatars"
-	MaxSizeMB  int64    // 例: 20 (20MB)
-	AllowedExt []string // 例: []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
+	BaseDir             string   // 例: "./uploads/avatars"
+	BaseURL             string   // 例: "/uploads/avatars"
+	MaxSizeMB           int64    // 例: 20 (20MB)
+	AttachmentDir       string   // 例: "./uploads/attachments"（静的配信しないディレクトリにする）
+	AttachmentMaxSizeMB int64    // 例: 2 (2MB)
+	AllowedExt          []string // 例: []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
 }
 
 // NewLocalStorage は新しいLocalStorageを作成
@@ -41,6 +46,9 @@ func NewLocalStorage(cfg *Config) (service.FileStorageService, error) {
 	if cfg.MaxSizeMB <= 0 {
 		cfg.MaxSizeMB = 20 // デフォルト20MB
 	}
+	if cfg.AttachmentMaxSizeMB <= 0 {
+		cfg.AttachmentMaxSizeMB = 2 // デフォルト2MB
+	}
 
 	// 許可する拡張子のマップを作成
 	allowedExt := make(map[string]bool)
@@ -56,21 +64,41 @@ func NewLocalStorage(cfg *Config) (service.FileStorageService, error) {
 	if err := os.MkdirAll(cfg.BaseDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create base directory: %w", err)
 	}
+	if cfg.AttachmentDir != "" {
+		if err := os.MkdirAll(cfg.AttachmentDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create attachment directory: %w", err)
+		}
+	}
 
 	return &LocalStorage{
-		baseDir:    cfg.BaseDir,
-		baseURL:    cfg.BaseURL,
-		maxSizeMB:  cfg.MaxSizeMB,
-		allowedExt: allowedExt,
+		baseDir:             cfg.BaseDir,
+		baseURL:             cfg.BaseURL,
+		maxSizeMB:           cfg.MaxSizeMB,
+		attachmentDir:       cfg.AttachmentDir,
+		attachmentMaxSizeMB: cfg.AttachmentMaxSizeMB,
+		allowedExt:          allowedExt,
 	}, nil
 }
 
 // SaveAvatar はアバター画像を保存
 func (s *LocalStorage) SaveAvatar(userID string, fileName string, file io.Reader, fileSize int64) (string, error) {
+	return s.saveFile(s.baseDir, s.maxSizeMB, userID, fileName, file, fileSize)
+}
+
+// SaveTransferAttachment は送金の添付画像を保存（アバターと同じ検証で、サイズの上限だけ異なる）
+func (s *LocalStorage) SaveTransferAttachment(userID string, fileName string, file io.Reader, fileSize int64) (string, error) {
+	if s.attachmentDir == "" {
+		return "", errors.New("attachment directory is not configured")
+	}
+	return s.saveFile(s.attachmentDir, s.attachmentMaxSizeMB, userID, fileName, file, fileSize)
+}
+
+// saveFile はファイルの拡張子・サイズを検証し、baseDirのユーザーごとのディレクトリに保存する
+func (s *LocalStorage) saveFile(baseDir string, maxSizeMB int64, userID string, fileName string, file io.Reader, fileSize int64) (string, error) {
 	// ファイルサイズチェック
-	maxBytes := s.maxSizeMB * 1024 * 1024
+	maxBytes := maxSizeMB * 1024 * 1024
 	if fileSize > maxBytes {
-		return "", fmt.Errorf("file size exceeds maximum allowed size of %dMB", s.maxSizeMB)
+		return "", fmt.Errorf("file size exceeds maximum allowed size of %dMB", maxSizeMB)
 	}
 
 	// 拡張子チェック
@@ -80,7 +108,7 @@ func (s *LocalStorage) SaveAvatar(userID string, fileName string, file io.Reader
 	}
 
 	// ユーザーごとのディレクトリを作成
-	userDir := filepath.Join(s.baseDir, userID)
+	userDir := filepath.Join(baseDir, userID)
 	if err := os.MkdirAll(userDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create user directory: %w", err)
 	}
@@ -120,17 +148,47 @@ func (s *LocalStorage) SaveAvatar(userID string, fileName string, file io.Reader
 
 // DeleteAvatar はアバター画像を削除
 func (s *LocalStorage) DeleteAvatar(filePath string) error {
+	return s.deleteFile(s.baseDir, filePath)
+}
+
+// OpenTransferAttachment は送金の添付画像を読み出す
+func (s *LocalStorage) OpenTransferAttachment(filePath string) (io.ReadCloser, error) {
+	fullPath, err := resolvePath(s.attachmentDir, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(fullPath)
+}
+
+// DeleteTransferAttachment は送金の添付画像を削除
+func (s *LocalStorage) DeleteTransferAttachment(filePath string) error {
+	return s.deleteFile(s.attachmentDir, filePath)
+}
+
+// resolvePath はbaseDirからの相対パスを実際のパスにする
+func resolvePath(baseDir, filePath string) (string, error) {
+	if baseDir == "" {
+		return "", errors.New("storage directory is not configured")
+	}
 	if filePath == "" {
-		return errors.New("file path is empty")
+		return "", errors.New("file path is empty")
 	}
 
 	// セキュリティチェック: パストラバーサル攻撃を防ぐ
 	cleanPath := filepath.Clean(filePath)
 	if strings.Contains(cleanPath, "..") {
-		return errors.New("invalid file path")
+		return "", errors.New("invalid file path")
 	}
 
-	fullPath := filepath.Join(s.baseDir, cleanPath)
+	return filepath.Join(baseDir, cleanPath), nil
+}
+
+// deleteFile はbaseDirからの相対パスのファイルを削除
+func (s *LocalStorage) deleteFile(baseDir, filePath string) error {
+	fullPath, err := resolvePath(baseDir, filePath)
+	if err != nil {
+		return err
+	}
 
 	// ファイルが存在するか確認
 	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
@@ -161,8 +219,9 @@ func (s *LocalStorage) GetAvatarURL(filePath string) string {
 }
 
 // generateFileHash はファイル名のハッシュを生成
+// 同じ秒に同じ名前のファイルを保存し直しても上書きしないよう、ランダムな値を含める
 func (s *LocalStorage) generateFileHash(userID, fileName string, timestamp int64) string {
-	data := fmt.Sprintf("%s:%s:%d", userID, fileName, timestamp)
+	data := fmt.Sprintf("%s:%s:%d:%s", userID, fileName, timestamp, uuid.NewString())
 	hash := sha256.Sum256([]byte(data))
 	return hex.EncodeToString(hash[:])
 }
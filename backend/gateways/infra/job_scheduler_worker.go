@@ -0,0 +1,81 @@
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// JobSchedulerWorker は毎分、実行時刻を過ぎた定期実行ジョブを実行するワーカー
+// ジョブごとにDBでロックを取るため、複数インスタンスで動かしても同じ時刻のジョブは一度だけ実行される
+type JobSchedulerWorker struct {
+	scheduledJobUC inputport.ScheduledJobInputPort
+	logger         entities.Logger
+	interval       time.Duration
+	owner          string
+	stopCh         chan struct{}
+
+	// メンテナンス中は実行しない（再開後の最初の実行でまとめて処理される）
+	maintenance inputport.MaintenanceInputPort
+}
+
+// NewJobSchedulerWorker は新しいJobSchedulerWorkerを作成
+func NewJobSchedulerWorker(
+	scheduledJobUC inputport.ScheduledJobInputPort,
+	logger entities.Logger,
+) *JobSchedulerWorker {
+	return &JobSchedulerWorker{
+		scheduledJobUC: scheduledJobUC,
+		logger:         logger,
+		interval:       time.Minute,
+		owner:          instanceID(),
+		stopCh:         make(chan struct{}),
+	}
+}
+
+// WithMaintenance はメンテナンス中に処理を止めるよう設定する
+func (w *JobSchedulerWorker) WithMaintenance(maintenance inputport.MaintenanceInputPort) *JobSchedulerWorker {
+	w.maintenance = maintenance
+	return w
+}
+
+// Start はワーカーを開始
+func (w *JobSchedulerWorker) Start() {
+	w.logger.Info("JobSchedulerWorker started",
+		entities.NewField("interval", w.interval.String()),
+		entities.NewField("owner", w.owner))
+
+	go func() {
+		w.run()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				w.run()
+			case <-w.stopCh:
+				w.logger.Info("JobSchedulerWorker stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop はワーカーを停止
+func (w *JobSchedulerWorker) Stop() {
+	close(w.stopCh)
+}
+
+func (w *JobSchedulerWorker) run() {
+	ctx := context.Background()
+	if w.maintenance != nil && w.maintenance.IsActive(ctx) {
+		w.logger.Info("JobSchedulerWorker: paused during maintenance")
+		return
+	}
+
+	w.scheduledJobUC.RunDueJobs(ctx, w.owner, time.Now())
+}
@@ -0,0 +1,174 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// LeaderElector はワーカーごとにリーダーのインスタンスを選ぶ
+// 登録したワーカーのリーダーに定期的に立候補し、リーダーの間だけIsLeaderがtrueを返す
+// リーダーが落ちると期限（WorkerLeaseTTL）が切れた後に他のインスタンスが引き継ぐ
+type LeaderElector struct {
+	leaseUC  inputport.WorkerLeaseInputPort
+	logger   entities.Logger
+	holder   string
+	interval time.Duration
+	stopCh   chan struct{}
+	doneCh   chan struct{} // Startの立候補のループが終わると閉じる（Startしていなければnil）
+
+	mu sync.Mutex
+	// leaderUntil はワーカーごとのリーダーの期限（リーダーでなければゼロ値）
+	// 更新に失敗し続けても、DB上の期限より前にリーダーをやめるよう立候補前の時刻から数える
+	leaderUntil map[string]time.Time
+}
+
+// NewLeaderElector は新しいLeaderElectorを作成
+func NewLeaderElector(leaseUC inputport.WorkerLeaseInputPort, logger entities.Logger) *LeaderElector {
+	return &LeaderElector{
+		leaseUC:     leaseUC,
+		logger:      logger,
+		holder:      instanceID(),
+		interval:    entities.WorkerLeaseRenewInterval,
+		stopCh:      make(chan struct{}),
+		leaderUntil: make(map[string]time.Time),
+	}
+}
+
+// Register はワーカーを登録してすぐに1回立候補する（起動直後の実行に間に合わせるため）
+func (e *LeaderElector) Register(name string) {
+	e.mu.Lock()
+	if _, ok := e.leaderUntil[name]; ok {
+		e.mu.Unlock()
+		return
+	}
+	e.leaderUntil[name] = time.Time{}
+	e.mu.Unlock()
+
+	e.campaign(context.Background(), name)
+}
+
+// IsLeader はこのインスタンスがワーカーのリーダーかを判定
+func (e *LeaderElector) IsLeader(name string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return time.Now().Before(e.leaderUntil[name])
+}
+
+// Start は登録したワーカーへの定期的な立候補を開始
+func (e *LeaderElector) Start() {
+	e.logger.Info("LeaderElector started",
+		entities.NewField("interval", e.interval.String()),
+		entities.NewField("holder", e.holder))
+
+	e.doneCh = make(chan struct{})
+	go func() {
+		defer close(e.doneCh)
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ctx := context.Background()
+				for _, name := range e.names() {
+					e.campaign(ctx, name)
+				}
+			case <-e.stopCh:
+				e.resignAll(context.Background())
+				e.logger.Info("LeaderElector stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop は立候補をやめ、リーダーのワーカーをすべて降りる
+func (e *LeaderElector) Stop() {
+	close(e.stopCh)
+}
+
+// Release は立候補をやめ、リーダーのワーカーをすべて降りるまで待つ
+// 終了する前に他のインスタンスへすぐ引き継ぐため、1回だけ処理する管理CLIから使う
+func (e *LeaderElector) Release() {
+	close(e.stopCh)
+	if e.doneCh != nil {
+		<-e.doneCh
+		return
+	}
+	e.resignAll(context.Background())
+}
+
+func (e *LeaderElector) names() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	names := make([]string, 0, len(e.leaderUntil))
+	for name := range e.leaderUntil {
+		names = append(names, name)
+	}
+	return names
+}
+
+// campaign はリーダーに立候補し、リーダーの交代をログに出す
+func (e *LeaderElector) campaign(ctx context.Context, name string) {
+	started := time.Now()
+	acquired, err := e.leaseUC.Campaign(ctx, name, e.holder, started)
+	if err != nil {
+		// 更新できなくても期限まではリーダーのまま（期限を過ぎればIsLeaderがfalseになる）
+		e.logger.Error("Failed to campaign for worker leadership",
+			entities.NewField("worker", name),
+			entities.NewField("error", err))
+		return
+	}
+
+	e.mu.Lock()
+	wasLeader := started.Before(e.leaderUntil[name])
+	if acquired {
+		e.leaderUntil[name] = started.Add(entities.WorkerLeaseTTL)
+	} else {
+		e.leaderUntil[name] = time.Time{}
+	}
+	e.mu.Unlock()
+
+	switch {
+	case acquired && !wasLeader:
+		e.logger.Info("Acquired worker leadership",
+			entities.NewField("worker", name),
+			entities.NewField("holder", e.holder))
+	case !acquired && wasLeader:
+		e.logger.Warn("Lost worker leadership",
+			entities.NewField("worker", name),
+			entities.NewField("holder", e.holder))
+	}
+}
+
+func (e *LeaderElector) resignAll(ctx context.Context) {
+	for _, name := range e.names() {
+		if !e.IsLeader(name) {
+			continue
+		}
+		e.mu.Lock()
+		e.leaderUntil[name] = time.Time{}
+		e.mu.Unlock()
+
+		if err := e.leaseUC.Resign(ctx, name, e.holder); err != nil {
+			e.logger.Error("Failed to resign worker leadership",
+				entities.NewField("worker", name),
+				entities.NewField("error", err))
+		}
+	}
+}
+
+// instanceID はリーダーやジョブのロックを持つインスタンスの識別子（ホスト名:PID）
+func instanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", hostname, os.Getpid())
+}
@@ -2,30 +2,60 @@ package infra
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
 	"github.com/gity/point-system/usecases/repository"
 	"github.com/google/uuid"
 )
 
+// pointExpiryLeaseName はリーダー選出で使うワーカー名
+const pointExpiryLeaseName = "point_expiry"
+
+// PointExpiryLimits はポイント有効期限ワーカーの処理量の上限
+type PointExpiryLimits struct {
+	BatchSize  int           // 1ページで取得する期限切れバッチ数
+	BatchPause time.Duration // ページ間の待ち時間（DBへの負荷を分散する）
+	MaxRuntime time.Duration // 1回の実行時間の上限（超えたら残りは次の実行に回す）
+}
+
+// defaultPointExpiryLimits は処理量の上限の既定値
+var defaultPointExpiryLimits = PointExpiryLimits{
+	BatchSize:  100,
+	BatchPause: 200 * time.Millisecond,
+	MaxRuntime: 10 * time.Minute,
+}
+
 // PointExpiryWorker はポイント期限切れ処理ワーカー
 // 毎時実行し、期限切れのポイントバッチを検出・失効処理する
+// 同じユーザーのバッチはページごとに1つのトランザクションでまとめて失効させる
 type PointExpiryWorker struct {
 	pointBatchRepo  repository.PointBatchRepository
+	policyRepo      repository.PointExpiryPolicyRepository
+	settingsRepo    repository.SystemSettingsRepository
 	userRepo        repository.UserRepository
 	transactionRepo repository.TransactionRepository
 	txManager       repository.TransactionManager
 	logger          entities.Logger
 	interval        time.Duration
-	batchSize       int
+	limits          PointExpiryLimits
 	stopCh          chan struct{}
+
+	// メンテナンス中は失効処理を行わない（再開後の実行でまとめて処理される）
+	maintenance inputport.MaintenanceInputPort
+
+	// 複数インスタンスで動かす場合は、リーダーのインスタンスだけが処理する
+	leader *LeaderElector
 }
 
 // NewPointExpiryWorker は新しいPointExpiryWorkerを作成
 func NewPointExpiryWorker(
 	pointBatchRepo repository.PointBatchRepository,
+	policyRepo repository.PointExpiryPolicyRepository,
+	settingsRepo repository.SystemSettingsRepository,
 	userRepo repository.UserRepository,
 	transactionRepo repository.TransactionRepository,
 	txManager repository.TransactionManager,
@@ -33,19 +63,52 @@ func NewPointExpiryWorker(
 ) *PointExpiryWorker {
 	return &PointExpiryWorker{
 		pointBatchRepo:  pointBatchRepo,
+		policyRepo:      policyRepo,
+		settingsRepo:    settingsRepo,
 		userRepo:        userRepo,
 		transactionRepo: transactionRepo,
 		txManager:       txManager,
 		logger:          logger,
 		interval:        1 * time.Hour,
-		batchSize:       100,
+		limits:          defaultPointExpiryLimits,
 		stopCh:          make(chan struct{}),
 	}
 }
 
+// WithMaintenance はメンテナンス中に失効処理を止めるよう設定する
+func (w *PointExpiryWorker) WithMaintenance(maintenance inputport.MaintenanceInputPort) *PointExpiryWorker {
+	w.maintenance = maintenance
+	return w
+}
+
+// WithLeaderElection は複数インスタンスのうちリーダーだけが処理するよう設定する
+func (w *PointExpiryWorker) WithLeaderElection(leader *LeaderElector) *PointExpiryWorker {
+	leader.Register(pointExpiryLeaseName)
+	w.leader = leader
+	return w
+}
+
+// WithLimits は処理量の上限を設定する（0以下の項目は既定値のまま。ページ間の待ち時間は0で待たない）
+func (w *PointExpiryWorker) WithLimits(limits PointExpiryLimits) *PointExpiryWorker {
+	if limits.BatchSize > 0 {
+		w.limits.BatchSize = limits.BatchSize
+	}
+	if limits.BatchPause >= 0 {
+		w.limits.BatchPause = limits.BatchPause
+	}
+	if limits.MaxRuntime > 0 {
+		w.limits.MaxRuntime = limits.MaxRuntime
+	}
+	return w
+}
+
 // Start はワーカーを開始
 func (w *PointExpiryWorker) Start() {
-	w.logger.Info("PointExpiryWorker started", entities.NewField("interval", w.interval.String()))
+	w.logger.Info("PointExpiryWorker started",
+		entities.NewField("interval", w.interval.String()),
+		entities.NewField("batch_size", w.limits.BatchSize),
+		entities.NewField("batch_pause", w.limits.BatchPause.String()),
+		entities.NewField("max_runtime", w.limits.MaxRuntime.String()))
 
 	go func() {
 		// 初回実行
@@ -74,82 +137,255 @@ func (w *PointExpiryWorker) Stop() {
 // processExpiredBatches は期限切れバッチを処理
 func (w *PointExpiryWorker) processExpiredBatches() {
 	ctx := context.Background()
+
+	if w.leader != nil && !w.leader.IsLeader(pointExpiryLeaseName) {
+		return
+	}
+	if w.maintenance != nil && w.maintenance.IsActive(ctx) {
+		w.logger.Info("PointExpiryWorker: paused during maintenance")
+		return
+	}
+
+	w.run(ctx)
+}
+
+// RunOnce は定期実行を待たずに失効処理を1回行い、実行結果を返す（管理CLIのexpire-batchesから使う）
+// リーダー選出を設定していれば、リーダーになれたときだけ処理する（他のインスタンスのワーカーと同時に失効させないため）
+func (w *PointExpiryWorker) RunOnce(ctx context.Context) (*entities.PointExpiryRun, error) {
+	if w.leader != nil && !w.leader.IsLeader(pointExpiryLeaseName) {
+		return nil, fmt.Errorf("point expiry worker is running on another instance, retry later")
+	}
+	return w.run(ctx), nil
+}
+
+// run は期限切れのバッチを上限の時間までページごとに処理し、進捗を保存する
+func (w *PointExpiryWorker) run(ctx context.Context) *entities.PointExpiryRun {
 	now := time.Now()
+	run := &entities.PointExpiryRun{State: entities.PointExpiryRunRunning, StartedAt: now, UpdatedAt: now}
+	w.saveRun(ctx, run)
 
-	totalExpired := 0
-	totalPoints := int64(0)
+	// 付与後に延長された有効期間を反映するため、実行ごとに設定を読み直す
+	policies, err := w.policyRepo.ReadListPolicies(ctx)
+	if err != nil {
+		w.logger.Error("PointExpiryWorker: failed to load expiry policies",
+			entities.NewField("error", err))
+		run.Error = err.Error()
+		run.Finish(entities.PointExpiryRunFailed, time.Now())
+		w.saveRun(ctx, run)
+		return run
+	}
+	overrides := make(map[uuid.UUID]*entities.UserPointExpiryOverride)
+	deadline := now.Add(w.limits.MaxRuntime)
 
 	for {
-		batches, err := w.pointBatchRepo.FindExpiredBatches(ctx, now, w.batchSize)
+		// 失効に失敗し続けるバッチが残っても、上限の時間で打ち切る
+		if !time.Now().Before(deadline) {
+			run.Finish(entities.PointExpiryRunTimeLimited, time.Now())
+			break
+		}
+
+		batches, err := w.pointBatchRepo.FindExpiredBatches(ctx, now, w.limits.BatchSize)
 		if err != nil {
 			w.logger.Error("PointExpiryWorker: failed to find expired batches",
 				entities.NewField("error", err))
-			return
+			run.Error = err.Error()
+			run.Finish(entities.PointExpiryRunFailed, time.Now())
+			break
 		}
 
 		if len(batches) == 0 {
+			run.Finish(entities.PointExpiryRunCompleted, time.Now())
 			break
 		}
+		run.Pages++
 
-		for _, batch := range batches {
-			if err := w.expireBatch(ctx, batch); err != nil {
-				w.logger.Error("PointExpiryWorker: failed to expire batch",
-					entities.NewField("batch_id", batch.ID),
-					entities.NewField("user_id", batch.UserID),
-					entities.NewField("error", err))
-				continue
-			}
-			totalExpired++
-			totalPoints += batch.RemainingAmount
-		}
+		w.processPage(ctx, batches, policies, overrides, now, run)
+
+		run.UpdatedAt = time.Now()
+		w.saveRun(ctx, run)
+		w.logger.Info("PointExpiryWorker: progress",
+			entities.NewField("pages", run.Pages),
+			entities.NewField("expired_batches", run.ExpiredBatches),
+			entities.NewField("expired_points", run.ExpiredPoints),
+			entities.NewField("extended_batches", run.ExtendedBatches),
+			entities.NewField("failed_batches", run.FailedBatches))
 
 		// バッチサイズ未満 = もうデータなし
-		if len(batches) < w.batchSize {
+		if len(batches) < w.limits.BatchSize {
+			run.Finish(entities.PointExpiryRunCompleted, time.Now())
+			break
+		}
+
+		if !w.pause() {
+			run.Finish(entities.PointExpiryRunTimeLimited, time.Now())
 			break
 		}
 	}
 
-	if totalExpired > 0 {
+	w.saveRun(ctx, run)
+	if run.ExpiredBatches > 0 || run.ExtendedBatches > 0 || run.State != entities.PointExpiryRunCompleted {
 		w.logger.Info("PointExpiryWorker: completed",
-			entities.NewField("expired_batches", totalExpired),
-			entities.NewField("expired_points", totalPoints))
+			entities.NewField("state", string(run.State)),
+			entities.NewField("duration", run.UpdatedAt.Sub(run.StartedAt).String()),
+			entities.NewField("expired_batches", run.ExpiredBatches),
+			entities.NewField("expired_users", run.ExpiredUsers),
+			entities.NewField("expired_points", run.ExpiredPoints),
+			entities.NewField("extended_batches", run.ExtendedBatches),
+			entities.NewField("failed_batches", run.FailedBatches))
+	}
+	return run
+}
+
+// processPage は1ページ分のバッチを延長または失効させる
+// 失効させるバッチはユーザーごとにまとめ、1ユーザー1トランザクションで処理する
+func (w *PointExpiryWorker) processPage(
+	ctx context.Context,
+	batches []*entities.PointBatch,
+	policies []*entities.PointExpiryPolicy,
+	overrides map[uuid.UUID]*entities.UserPointExpiryOverride,
+	now time.Time,
+	run *entities.PointExpiryRun,
+) {
+	var userOrder []uuid.UUID
+	byUser := make(map[uuid.UUID][]*entities.PointBatch)
+
+	for _, batch := range batches {
+		extended, err := w.extendIfPolicyAllows(ctx, batch, policies, overrides, now)
+		if err != nil {
+			w.logger.Error("PointExpiryWorker: failed to apply expiry policy",
+				entities.NewField("batch_id", batch.ID),
+				entities.NewField("user_id", batch.UserID),
+				entities.NewField("error", err))
+			run.FailedBatches++
+			continue
+		}
+		if extended {
+			run.ExtendedBatches++
+			continue
+		}
+
+		if _, ok := byUser[batch.UserID]; !ok {
+			userOrder = append(userOrder, batch.UserID)
+		}
+		byUser[batch.UserID] = append(byUser[batch.UserID], batch)
+	}
+
+	for _, userID := range userOrder {
+		userBatches := byUser[userID]
+		amount, err := w.expireUserBatches(ctx, userID, userBatches)
+		if err != nil {
+			w.logger.Error("PointExpiryWorker: failed to expire batches",
+				entities.NewField("user_id", userID),
+				entities.NewField("batches", len(userBatches)),
+				entities.NewField("error", err))
+			run.FailedBatches += len(userBatches)
+			continue
+		}
+		run.ExpiredUsers++
+		run.ExpiredBatches += len(userBatches)
+		run.ExpiredPoints += amount
+	}
+}
+
+// pause はページ間で待つ（停止された場合はfalse）
+func (w *PointExpiryWorker) pause() bool {
+	if w.limits.BatchPause <= 0 {
+		return true
+	}
+	timer := time.NewTimer(w.limits.BatchPause)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-w.stopCh:
+		return false
 	}
 }
 
-// expireBatch は1つのバッチを失効処理
-func (w *PointExpiryWorker) expireBatch(ctx context.Context, batch *entities.PointBatch) error {
-	return w.txManager.Do(ctx, func(txCtx context.Context) error {
+// saveRun は実行状況をsystem_settingsに保存する（失敗しても失効処理は続ける）
+func (w *PointExpiryWorker) saveRun(ctx context.Context, run *entities.PointExpiryRun) {
+	value, err := json.Marshal(run)
+	if err == nil {
+		err = w.settingsRepo.SetSetting(ctx, entities.PointExpiryRunSettingKey, string(value), "ポイント有効期限ワーカーの実行状況")
+	}
+	if err != nil {
+		w.logger.Warn("PointExpiryWorker: failed to save run status", entities.NewField("error", err))
+	}
+}
+
+// extendIfPolicyAllows は現在の設定で有効期限が先になるバッチの期限を延ばす
+// 延ばした場合はtrueを返し、そのバッチは失効させない
+func (w *PointExpiryWorker) extendIfPolicyAllows(
+	ctx context.Context,
+	batch *entities.PointBatch,
+	policies []*entities.PointExpiryPolicy,
+	overrides map[uuid.UUID]*entities.UserPointExpiryOverride,
+	now time.Time,
+) (bool, error) {
+	if batch.HasFixedExpiry() {
+		return false, nil
+	}
+
+	override, ok := overrides[batch.UserID]
+	if !ok {
+		var err error
+		override, err = w.policyRepo.ReadUserOverride(ctx, batch.UserID)
+		if err != nil {
+			return false, err
+		}
+		overrides[batch.UserID] = override
+	}
+
+	expiresAt := entities.ResolvePointExpiry(batch.SourceType, batch.CreatedAt, policies, override)
+	if !expiresAt.After(now) {
+		return false, nil
+	}
+	return true, w.pointBatchRepo.UpdateExpiresAt(ctx, batch.ID, expiresAt)
+}
+
+// expireUserBatches はユーザーの期限切れバッチをまとめて1つのトランザクションで失効させ、失効したポイントを返す
+// 確保中の送金リクエストの分に掛かる失効はErrInsufficientBalanceで失敗し、バッチは残る（確保が解放された後の実行で失効させる）
+func (w *PointExpiryWorker) expireUserBatches(ctx context.Context, userID uuid.UUID, batches []*entities.PointBatch) (int64, error) {
+	var amount int64
+	batchIDs := make([]string, len(batches))
+	for i, batch := range batches {
+		amount += batch.RemainingAmount
+		batchIDs[i] = batch.ID.String()
+	}
+
+	description := fmt.Sprintf("ポイント期限切れ（バッチ: %s）", batches[0].ID)
+	if len(batches) > 1 {
+		description = fmt.Sprintf("ポイント期限切れ（%d件のバッチ）", len(batches))
+	}
+
+	err := w.txManager.Do(ctx, func(txCtx context.Context) error {
 		// 1. ユーザー残高から減算
-		if err := w.userRepo.UpdateBalanceWithLock(txCtx, batch.UserID, batch.RemainingAmount, true); err != nil {
+		if err := w.userRepo.UpdateBalanceWithLock(txCtx, userID, amount, true); err != nil {
 			return fmt.Errorf("failed to deduct expired points: %w", err)
 		}
 
 		// 2. system_expire トランザクション記録
-		fromUserID := batch.UserID
-		tx := &entities.Transaction{
-			ID:              uuid.New(),
-			FromUserID:      &fromUserID,
-			ToUserID:        nil, // システムへの返却
-			Amount:          batch.RemainingAmount,
-			TransactionType: entities.TransactionTypeSystemExpire,
-			Status:          entities.TransactionStatusCompleted,
-			Description:     fmt.Sprintf("ポイント期限切れ（バッチ: %s）", batch.ID),
-			Metadata:        map[string]interface{}{"batch_id": batch.ID.String()},
-			CreatedAt:       time.Now(),
-			CompletedAt:     ptrTime(time.Now()),
+		tx, err := entities.NewSystemExpire(userID, amount, description, map[string]interface{}{"batch_ids": batchIDs})
+		if err != nil {
+			return err
 		}
-
 		if err := w.transactionRepo.Create(txCtx, tx); err != nil {
 			return fmt.Errorf("failed to create expire transaction: %w", err)
 		}
 
 		// 3. バッチの remaining_amount を 0 に
-		if err := w.pointBatchRepo.MarkExpired(txCtx, batch.ID); err != nil {
-			return fmt.Errorf("failed to mark batch expired: %w", err)
+		for _, batch := range batches {
+			if err := w.pointBatchRepo.MarkExpired(txCtx, batch.ID); err != nil {
+				return fmt.Errorf("failed to mark batch expired: %w", err)
+			}
 		}
 
 		return nil
 	})
+	if err != nil {
+		return 0, err
+	}
+	return amount, nil
 }
 
 // ptrTime はtime.Timeのポインタを返すヘルパー
@@ -0,0 +1,102 @@
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// recurringTransferBatchSize は1回の実行で処理する定期送金の上限
+const recurringTransferBatchSize = 100
+
+// recurringTransferLeaseName はリーダー選出で使うワーカー名
+const recurringTransferLeaseName = "recurring_transfer"
+
+// RecurringTransferWorker は実行日時を過ぎた定期送金を送金するワーカー
+type RecurringTransferWorker struct {
+	recurringTransferUC inputport.RecurringTransferInputPort
+	logger              entities.Logger
+	interval            time.Duration
+	stopCh              chan struct{}
+
+	// メンテナンス中は送金しない（再開後の実行でまとめて処理される）
+	maintenance inputport.MaintenanceInputPort
+
+	// 複数インスタンスで動かす場合は、リーダーのインスタンスだけが処理する
+	leader *LeaderElector
+}
+
+// NewRecurringTransferWorker は新しいRecurringTransferWorkerを作成
+func NewRecurringTransferWorker(
+	recurringTransferUC inputport.RecurringTransferInputPort,
+	logger entities.Logger,
+) *RecurringTransferWorker {
+	return &RecurringTransferWorker{
+		recurringTransferUC: recurringTransferUC,
+		logger:              logger,
+		interval:            5 * time.Minute,
+		stopCh:              make(chan struct{}),
+	}
+}
+
+// WithMaintenance はメンテナンス中に処理を止めるよう設定する
+func (w *RecurringTransferWorker) WithMaintenance(maintenance inputport.MaintenanceInputPort) *RecurringTransferWorker {
+	w.maintenance = maintenance
+	return w
+}
+
+// WithLeaderElection は複数インスタンスのうちリーダーだけが処理するよう設定する
+func (w *RecurringTransferWorker) WithLeaderElection(leader *LeaderElector) *RecurringTransferWorker {
+	leader.Register(recurringTransferLeaseName)
+	w.leader = leader
+	return w
+}
+
+// Start はワーカーを開始
+func (w *RecurringTransferWorker) Start() {
+	w.logger.Info("RecurringTransferWorker started", entities.NewField("interval", w.interval.String()))
+
+	go func() {
+		w.run()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				w.run()
+			case <-w.stopCh:
+				w.logger.Info("RecurringTransferWorker stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop はワーカーを停止
+func (w *RecurringTransferWorker) Stop() {
+	close(w.stopCh)
+}
+
+func (w *RecurringTransferWorker) run() {
+	ctx := context.Background()
+	if w.leader != nil && !w.leader.IsLeader(recurringTransferLeaseName) {
+		return
+	}
+	if w.maintenance != nil && w.maintenance.IsActive(ctx) {
+		w.logger.Info("RecurringTransferWorker: paused during maintenance")
+		return
+	}
+
+	// 1回の実行で処理しきれない場合は次の実行に回す
+	executed, err := w.recurringTransferUC.ProcessDueTransfers(ctx, time.Now(), recurringTransferBatchSize)
+	if err != nil {
+		w.logger.Error("Failed to process recurring transfers", entities.NewField("error", err))
+	}
+	if executed > 0 {
+		w.logger.Info("Executed recurring transfers", entities.NewField("count", executed))
+	}
+}
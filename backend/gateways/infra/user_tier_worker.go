@@ -0,0 +1,123 @@
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+)
+
+// userTierRunHourJST は会員ランクを判定し直す時刻（JST）
+const userTierRunHourJST = 3
+
+// userTierLeaseName はリーダー選出で使うワーカー名
+const userTierLeaseName = "user_tier"
+
+// UserTierWorker は毎晩全ユーザーの会員ランクを判定し直すワーカー
+type UserTierWorker struct {
+	userTierUC inputport.UserTierInputPort
+	logger     entities.Logger
+	interval   time.Duration
+	stopCh     chan struct{}
+
+	// メンテナンス中は判定しない（翌日の実行で判定される）
+	maintenance inputport.MaintenanceInputPort
+
+	// 複数インスタンスで動かす場合は、リーダーのインスタンスだけが処理する
+	leader *LeaderElector
+}
+
+// NewUserTierWorker は新しいUserTierWorkerを作成
+func NewUserTierWorker(
+	userTierUC inputport.UserTierInputPort,
+	logger entities.Logger,
+) *UserTierWorker {
+	return &UserTierWorker{
+		userTierUC: userTierUC,
+		logger:     logger,
+		interval:   24 * time.Hour,
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// WithMaintenance はメンテナンス中に処理を止めるよう設定する
+func (w *UserTierWorker) WithMaintenance(maintenance inputport.MaintenanceInputPort) *UserTierWorker {
+	w.maintenance = maintenance
+	return w
+}
+
+// WithLeaderElection は複数インスタンスのうちリーダーだけが処理するよう設定する
+func (w *UserTierWorker) WithLeaderElection(leader *LeaderElector) *UserTierWorker {
+	leader.Register(userTierLeaseName)
+	w.leader = leader
+	return w
+}
+
+// Start はワーカーを開始（次のJST 3:00に初回実行し、以降24時間ごと）
+func (w *UserTierWorker) Start() {
+	delay := w.untilNextRun(time.Now())
+	w.logger.Info("UserTierWorker started",
+		entities.NewField("interval", w.interval.String()),
+		entities.NewField("first_run_in", delay.String()))
+
+	go func() {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			w.run()
+		case <-w.stopCh:
+			w.logger.Info("UserTierWorker stopped")
+			return
+		}
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				w.run()
+			case <-w.stopCh:
+				w.logger.Info("UserTierWorker stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop はワーカーを停止
+func (w *UserTierWorker) Stop() {
+	close(w.stopCh)
+}
+
+// untilNextRun は次のJST 3:00までの時間を返す
+func (w *UserTierWorker) untilNextRun(now time.Time) time.Duration {
+	jst := time.FixedZone("JST", 9*60*60)
+	nowJST := now.In(jst)
+	next := time.Date(nowJST.Year(), nowJST.Month(), nowJST.Day(), userTierRunHourJST, 0, 0, 0, jst)
+	if !next.After(nowJST) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next.Sub(nowJST)
+}
+
+func (w *UserTierWorker) run() {
+	ctx := context.Background()
+	if w.leader != nil && !w.leader.IsLeader(userTierLeaseName) {
+		return
+	}
+	if w.maintenance != nil && w.maintenance.IsActive(ctx) {
+		w.logger.Info("UserTierWorker: paused during maintenance")
+		return
+	}
+
+	changed, err := w.userTierUC.RecalculateTiers(ctx, time.Now())
+	if err != nil {
+		w.logger.Error("Failed to recalculate user tiers", entities.NewField("error", err))
+		return
+	}
+	w.logger.Info("Recalculated user tiers", entities.NewField("changed", changed))
+}
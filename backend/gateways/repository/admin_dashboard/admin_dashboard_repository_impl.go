@@ -0,0 +1,29 @@
+package admin_dashboard
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+)
+
+// AdminDashboardRepositoryImpl は管理者ダッシュボードの集計用リポジトリの実装
+type AdminDashboardRepositoryImpl struct {
+	ds *dspostgresimpl.AdminDashboardDataSource
+}
+
+// NewAdminDashboardRepository は新しいAdminDashboardRepositoryを作成
+func NewAdminDashboardRepository(ds *dspostgresimpl.AdminDashboardDataSource) *AdminDashboardRepositoryImpl {
+	return &AdminDashboardRepositoryImpl{ds: ds}
+}
+
+// GetCounters はnow時点のカウンターを取得
+func (r *AdminDashboardRepositoryImpl) GetCounters(ctx context.Context, now time.Time) (*entities.AdminDashboardCounters, error) {
+	return r.ds.SelectCounters(ctx, now)
+}
+
+// Ping はDBへ疎通できるか確認
+func (r *AdminDashboardRepositoryImpl) Ping(ctx context.Context) error {
+	return r.ds.Ping(ctx)
+}
@@ -0,0 +1,49 @@
+package akerun_repoll
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// AkerunRepollRepositoryImpl はAkerunの再取得の依頼のリポジトリの実装
+type AkerunRepollRepositoryImpl struct {
+	ds *dspostgresimpl.AkerunRepollDataSource
+}
+
+// NewAkerunRepollRepository は新しいAkerunRepollRepositoryを作成
+func NewAkerunRepollRepository(ds *dspostgresimpl.AkerunRepollDataSource) *AkerunRepollRepositoryImpl {
+	return &AkerunRepollRepositoryImpl{ds: ds}
+}
+
+// Create は再取得の依頼を作成
+func (r *AkerunRepollRepositoryImpl) Create(ctx context.Context, repoll *entities.AkerunRepoll) error {
+	return r.ds.Insert(ctx, repoll)
+}
+
+// Read はIDで取得
+func (r *AkerunRepollRepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.AkerunRepoll, error) {
+	return r.ds.Select(ctx, id)
+}
+
+// ReadActive は処理待ちまたは取得中の依頼を取得
+func (r *AkerunRepollRepositoryImpl) ReadActive(ctx context.Context) (*entities.AkerunRepoll, error) {
+	return r.ds.SelectActive(ctx)
+}
+
+// ReadList は新しい順に取得
+func (r *AkerunRepollRepositoryImpl) ReadList(ctx context.Context, offset, limit int) ([]*entities.AkerunRepoll, error) {
+	return r.ds.SelectList(ctx, offset, limit)
+}
+
+// Count は件数を取得
+func (r *AkerunRepollRepositoryImpl) Count(ctx context.Context) (int64, error) {
+	return r.ds.Count(ctx)
+}
+
+// Update は状態と進み具合を更新
+func (r *AkerunRepollRepositoryImpl) Update(ctx context.Context, repoll *entities.AkerunRepoll) error {
+	return r.ds.Update(ctx, repoll)
+}
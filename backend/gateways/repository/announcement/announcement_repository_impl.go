@@ -0,0 +1,65 @@
+package announcement
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// AnnouncementRepositoryImpl はお知らせリポジトリの実装
+type AnnouncementRepositoryImpl struct {
+	ds *dspostgresimpl.AnnouncementDataSource
+}
+
+// NewAnnouncementRepository は新しいAnnouncementRepositoryを作成
+func NewAnnouncementRepository(ds *dspostgresimpl.AnnouncementDataSource) *AnnouncementRepositoryImpl {
+	return &AnnouncementRepositoryImpl{ds: ds}
+}
+
+// Create はお知らせを作成
+func (r *AnnouncementRepositoryImpl) Create(ctx context.Context, announcement *entities.Announcement) error {
+	return r.ds.Insert(ctx, announcement)
+}
+
+// Read はIDでお知らせを取得
+func (r *AnnouncementRepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.Announcement, error) {
+	return r.ds.SelectByID(ctx, id)
+}
+
+// Update はお知らせを更新
+func (r *AnnouncementRepositoryImpl) Update(ctx context.Context, announcement *entities.Announcement) error {
+	return r.ds.Update(ctx, announcement)
+}
+
+// Delete はお知らせを削除
+func (r *AnnouncementRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.ds.Delete(ctx, id)
+}
+
+// ReadList はお知らせを新しい順に取得
+func (r *AnnouncementRepositoryImpl) ReadList(ctx context.Context, offset, limit int) ([]*entities.Announcement, error) {
+	return r.ds.SelectList(ctx, offset, limit)
+}
+
+// Count はお知らせの件数を取得
+func (r *AnnouncementRepositoryImpl) Count(ctx context.Context) (int64, error) {
+	return r.ds.Count(ctx)
+}
+
+// ReadActive は表示期間内のお知らせを取得
+func (r *AnnouncementRepositoryImpl) ReadActive(ctx context.Context, now time.Time) ([]*entities.Announcement, error) {
+	return r.ds.SelectActive(ctx, now)
+}
+
+// Dismiss はお知らせの非表示を記録
+func (r *AnnouncementRepositoryImpl) Dismiss(ctx context.Context, announcementID, userID uuid.UUID, dismissedAt time.Time) error {
+	return r.ds.InsertDismissal(ctx, announcementID, userID, dismissedAt)
+}
+
+// ReadDismissedIDs はユーザーが非表示にしたお知らせのIDを取得
+func (r *AnnouncementRepositoryImpl) ReadDismissedIDs(ctx context.Context, userID uuid.UUID, announcementIDs []uuid.UUID) ([]uuid.UUID, error) {
+	return r.ds.SelectDismissedIDs(ctx, userID, announcementIDs)
+}
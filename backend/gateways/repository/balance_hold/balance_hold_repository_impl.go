@@ -0,0 +1,39 @@
+package balance_hold
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// BalanceHoldRepositoryImpl は送金リクエストのために確保した残高のリポジトリの実装
+type BalanceHoldRepositoryImpl struct {
+	ds *dspostgresimpl.BalanceHoldDataSource
+}
+
+// NewBalanceHoldRepository は新しいBalanceHoldRepositoryを作成
+func NewBalanceHoldRepository(ds *dspostgresimpl.BalanceHoldDataSource) *BalanceHoldRepositoryImpl {
+	return &BalanceHoldRepositoryImpl{ds: ds}
+}
+
+// Create は確保を作成
+func (r *BalanceHoldRepositoryImpl) Create(ctx context.Context, hold *entities.BalanceHold) error {
+	return r.ds.Insert(ctx, hold)
+}
+
+// ReadByTransferRequestIDForUpdate は送金リクエストの確保を行ロックして取得
+func (r *BalanceHoldRepositoryImpl) ReadByTransferRequestIDForUpdate(ctx context.Context, transferRequestID uuid.UUID) (*entities.BalanceHold, error) {
+	return r.ds.SelectByTransferRequestIDForUpdate(ctx, transferRequestID)
+}
+
+// Update は確保の状態を更新
+func (r *BalanceHoldRepositoryImpl) Update(ctx context.Context, hold *entities.BalanceHold) error {
+	return r.ds.Update(ctx, hold)
+}
+
+// ReadReleasable は送金リクエストが承認待ちでなくなったのに確保中のものを古い順に取得
+func (r *BalanceHoldRepositoryImpl) ReadReleasable(ctx context.Context, limit int) ([]*entities.BalanceHold, error) {
+	return r.ds.SelectReleasable(ctx, limit)
+}
@@ -0,0 +1,34 @@
+package balance_ledger
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// BalanceLedgerRepositoryImpl は残高と取引履歴の照合のリポジトリの実装
+type BalanceLedgerRepositoryImpl struct {
+	ds *dspostgresimpl.BalanceLedgerDataSource
+}
+
+// NewBalanceLedgerRepository は新しいBalanceLedgerRepositoryを作成
+func NewBalanceLedgerRepository(ds *dspostgresimpl.BalanceLedgerDataSource) *BalanceLedgerRepositoryImpl {
+	return &BalanceLedgerRepositoryImpl{ds: ds}
+}
+
+// ReadUserIDs はafterより後のユーザーIDを昇順にlimit件取得
+func (r *BalanceLedgerRepositoryImpl) ReadUserIDs(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	return r.ds.SelectUserIDs(ctx, after, limit)
+}
+
+// LockUsers はユーザーの行をID順にロックする
+func (r *BalanceLedgerRepositoryImpl) LockUsers(ctx context.Context, userIDs []uuid.UUID) error {
+	return r.ds.LockUsers(ctx, userIDs)
+}
+
+// ReadBalanceChecks は保存されている残高と取引履歴から計算した残高を取得
+func (r *BalanceLedgerRepositoryImpl) ReadBalanceChecks(ctx context.Context, userIDs []uuid.UUID) ([]*entities.BalanceCheck, error) {
+	return r.ds.SelectBalanceChecks(ctx, userIDs)
+}
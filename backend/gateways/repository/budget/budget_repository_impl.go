@@ -0,0 +1,65 @@
+package budget
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// BudgetRepositoryImpl は予算リポジトリの実装
+type BudgetRepositoryImpl struct {
+	ds *dspostgresimpl.BudgetDataSource
+}
+
+// NewBudgetRepository は新しいBudgetRepositoryを作成
+func NewBudgetRepository(ds *dspostgresimpl.BudgetDataSource) *BudgetRepositoryImpl {
+	return &BudgetRepositoryImpl{ds: ds}
+}
+
+// Create は予算を作成
+func (r *BudgetRepositoryImpl) Create(ctx context.Context, budget *entities.Budget) error {
+	return r.ds.Insert(ctx, budget)
+}
+
+// Read はIDで予算を取得
+func (r *BudgetRepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.Budget, error) {
+	return r.ds.Select(ctx, id)
+}
+
+// ReadList は全予算を取得
+func (r *BudgetRepositoryImpl) ReadList(ctx context.Context) ([]*entities.Budget, error) {
+	return r.ds.SelectList(ctx)
+}
+
+// Update は予算を更新
+func (r *BudgetRepositoryImpl) Update(ctx context.Context, budget *entities.Budget) error {
+	return r.ds.Update(ctx, budget)
+}
+
+// Delete は予算を削除
+func (r *BudgetRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.ds.Delete(ctx, id)
+}
+
+// ReadApplicableForUpdate は付与が対象の有効な予算を行ロック付きで取得
+func (r *BudgetRepositoryImpl) ReadApplicableForUpdate(ctx context.Context, adminID uuid.UUID, departmentIDs []uuid.UUID) ([]*entities.Budget, error) {
+	return r.ds.SelectApplicableForUpdate(ctx, adminID, departmentIDs)
+}
+
+// ReadUsage は予算の期間の消化状況を取得
+func (r *BudgetRepositoryImpl) ReadUsage(ctx context.Context, budgetID uuid.UUID, periodStart time.Time) (*entities.BudgetUsage, error) {
+	return r.ds.SelectUsage(ctx, budgetID, periodStart)
+}
+
+// SaveUsage は期間の消化状況を保存
+func (r *BudgetRepositoryImpl) SaveUsage(ctx context.Context, usage *entities.BudgetUsage) error {
+	return r.ds.UpsertUsage(ctx, usage)
+}
+
+// ReadAlertRecipients は予算の通知を届ける管理者のIDを取得
+func (r *BudgetRepositoryImpl) ReadAlertRecipients(ctx context.Context) ([]uuid.UUID, error) {
+	return r.ds.SelectAlertRecipients(ctx)
+}
@@ -0,0 +1,24 @@
+package business_metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+)
+
+// BusinessMetricsRepositoryImpl は業務の指標の集計用リポジトリの実装
+type BusinessMetricsRepositoryImpl struct {
+	ds *dspostgresimpl.BusinessMetricsDataSource
+}
+
+// NewBusinessMetricsRepository は新しいBusinessMetricsRepositoryを作成
+func NewBusinessMetricsRepository(ds *dspostgresimpl.BusinessMetricsDataSource) *BusinessMetricsRepositoryImpl {
+	return &BusinessMetricsRepositoryImpl{ds: ds}
+}
+
+// GetBusinessMetrics はnow時点の指標を集計
+func (r *BusinessMetricsRepositoryImpl) GetBusinessMetrics(ctx context.Context, dayStart, now time.Time) (*entities.BusinessMetrics, error) {
+	return r.ds.SelectBusinessMetrics(ctx, dayStart, now)
+}
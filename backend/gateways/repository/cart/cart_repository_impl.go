@@ -0,0 +1,44 @@
+package cart
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// CartRepositoryImpl はカートのリポジトリの実装
+type CartRepositoryImpl struct {
+	ds *dspostgresimpl.CartDataSource
+}
+
+// NewCartRepository は新しいCartRepositoryを作成
+func NewCartRepository(ds *dspostgresimpl.CartDataSource) *CartRepositoryImpl {
+	return &CartRepositoryImpl{ds: ds}
+}
+
+// ReadByUser はユーザーのカートの商品を入れた順に取得
+func (r *CartRepositoryImpl) ReadByUser(ctx context.Context, userID uuid.UUID) ([]*entities.CartItem, error) {
+	return r.ds.SelectByUser(ctx, userID)
+}
+
+// Read はカートの商品を取得
+func (r *CartRepositoryImpl) Read(ctx context.Context, userID, productID uuid.UUID) (*entities.CartItem, error) {
+	return r.ds.Select(ctx, userID, productID)
+}
+
+// Save はカートの商品を作成または更新
+func (r *CartRepositoryImpl) Save(ctx context.Context, item *entities.CartItem) error {
+	return r.ds.Upsert(ctx, item)
+}
+
+// Delete はカートから商品を削除
+func (r *CartRepositoryImpl) Delete(ctx context.Context, userID, productID uuid.UUID) error {
+	return r.ds.Delete(ctx, userID, productID)
+}
+
+// DeleteByUser はユーザーのカートを空にする
+func (r *CartRepositoryImpl) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	return r.ds.DeleteByUser(ctx, userID)
+}
@@ -0,0 +1,44 @@
+package content_violation
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// ContentViolationRepositoryImpl はモデレーション違反記録リポジトリの実装
+type ContentViolationRepositoryImpl struct {
+	ds *dspostgresimpl.ContentViolationDataSource
+}
+
+// NewContentViolationRepository は新しいContentViolationRepositoryを作成
+func NewContentViolationRepository(ds *dspostgresimpl.ContentViolationDataSource) *ContentViolationRepositoryImpl {
+	return &ContentViolationRepositoryImpl{ds: ds}
+}
+
+// Create は違反記録を作成
+func (r *ContentViolationRepositoryImpl) Create(ctx context.Context, violation *entities.ContentViolation) error {
+	return r.ds.Insert(ctx, violation)
+}
+
+// Read はIDで違反記録を取得
+func (r *ContentViolationRepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.ContentViolation, error) {
+	return r.ds.SelectByID(ctx, id)
+}
+
+// ReadList は違反記録を新しい順に取得
+func (r *ContentViolationRepositoryImpl) ReadList(ctx context.Context, unreviewedOnly bool, offset, limit int) ([]*entities.ContentViolation, error) {
+	return r.ds.SelectList(ctx, unreviewedOnly, offset, limit)
+}
+
+// Count は違反記録の件数を取得
+func (r *ContentViolationRepositoryImpl) Count(ctx context.Context, unreviewedOnly bool) (int64, error) {
+	return r.ds.Count(ctx, unreviewedOnly)
+}
+
+// UpdateReviewed はレビュー結果を保存
+func (r *ContentViolationRepositoryImpl) UpdateReviewed(ctx context.Context, violation *entities.ContentViolation) error {
+	return r.ds.UpdateReviewed(ctx, violation)
+}
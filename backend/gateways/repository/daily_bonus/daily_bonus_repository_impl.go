@@ -39,14 +39,14 @@ func (r *DailyBonusRepositoryImpl) CountByUser(ctx context.Context, userID uuid.
 	return r.ds.CountByUser(ctx, userID)
 }
 
-// GetLastPolledAt は前回ポーリング時刻を取得
-func (r *DailyBonusRepositoryImpl) GetLastPolledAt(ctx context.Context) (time.Time, error) {
-	return r.ds.GetLastPolledAt(ctx)
+// GetLastPolledAt はAkerun組織の前回ポーリング時刻を取得
+func (r *DailyBonusRepositoryImpl) GetLastPolledAt(ctx context.Context, organizationID string) (time.Time, error) {
+	return r.ds.GetOrganizationLastPolledAt(ctx, organizationID)
 }
 
-// UpdateLastPolledAt はポーリング時刻を更新
-func (r *DailyBonusRepositoryImpl) UpdateLastPolledAt(ctx context.Context, t time.Time) error {
-	return r.ds.UpdateLastPolledAt(ctx, t)
+// UpdateLastPolledAt はAkerun組織のポーリング時刻を更新
+func (r *DailyBonusRepositoryImpl) UpdateLastPolledAt(ctx context.Context, organizationID string, t time.Time) error {
+	return r.ds.UpsertOrganizationLastPolledAt(ctx, organizationID, t)
 }
 
 // MarkAsViewed はデイリーボーナスを閲覧済みにする
@@ -58,3 +58,8 @@ func (r *DailyBonusRepositoryImpl) MarkAsViewed(ctx context.Context, id uuid.UUI
 func (r *DailyBonusRepositoryImpl) UpdateDrawnResult(ctx context.Context, id uuid.UUID, bonusPoints int64, lotteryTierID *uuid.UUID, lotteryTierName string) error {
 	return r.ds.UpdateDrawnResult(ctx, id, bonusPoints, lotteryTierID, lotteryTierName)
 }
+
+// StreamByPeriod はボーナス日が期間内のボーナスをユーザー名付きで1件ずつfnへ渡す
+func (r *DailyBonusRepositoryImpl) StreamByPeriod(ctx context.Context, period entities.BonusReportPeriod, fn func(*entities.DailyBonusRecord) error) error {
+	return r.ds.StreamByPeriod(ctx, period, fn)
+}
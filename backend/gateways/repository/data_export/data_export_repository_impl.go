@@ -0,0 +1,50 @@
+package data_export
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// DataExportRepositoryImpl は個人データエクスポート依頼リポジトリの実装
+type DataExportRepositoryImpl struct {
+	ds *dspostgresimpl.DataExportDataSource
+}
+
+// NewDataExportRepository は新しいDataExportRepositoryを作成
+func NewDataExportRepository(ds *dspostgresimpl.DataExportDataSource) *DataExportRepositoryImpl {
+	return &DataExportRepositoryImpl{ds: ds}
+}
+
+// Create はエクスポート依頼を作成
+func (r *DataExportRepositoryImpl) Create(ctx context.Context, export *entities.DataExport) error {
+	return r.ds.Insert(ctx, export)
+}
+
+// Read はIDでエクスポート依頼を取得
+func (r *DataExportRepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.DataExport, error) {
+	return r.ds.SelectByID(ctx, id)
+}
+
+// ReadLatestByUser はユーザーの最新のエクスポート依頼を取得
+func (r *DataExportRepositoryImpl) ReadLatestByUser(ctx context.Context, userID uuid.UUID) (*entities.DataExport, error) {
+	return r.ds.SelectLatestByUser(ctx, userID)
+}
+
+// ClaimPending は処理待ちの依頼を作成中にして取得
+func (r *DataExportRepositoryImpl) ClaimPending(ctx context.Context, limit int) ([]*entities.DataExport, error) {
+	return r.ds.ClaimPending(ctx, limit)
+}
+
+// Update はエクスポート依頼を更新
+func (r *DataExportRepositoryImpl) Update(ctx context.Context, export *entities.DataExport) error {
+	return r.ds.Update(ctx, export)
+}
+
+// ReadListExpired はダウンロード期限を過ぎたファイルのある依頼を取得
+func (r *DataExportRepositoryImpl) ReadListExpired(ctx context.Context, now time.Time, limit int) ([]*entities.DataExport, error) {
+	return r.ds.SelectExpired(ctx, now, limit)
+}
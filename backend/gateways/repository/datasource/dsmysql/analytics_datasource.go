@@ -21,9 +21,36 @@ type AnalyticsDataSource interface {
 	// GetTransactionTypeBreakdown はトランザクション種別構成を取得
 	GetTransactionTypeBreakdown(ctx context.Context) ([]*entities.TypeBreakdownResult, error)
 
+	// GetReasonCodeBreakdown はsince以降の管理者付与・減算を理由コード・取引種別ごとに集計（reasonCodeが空でなければそのコードのみ）
+	GetReasonCodeBreakdown(ctx context.Context, since time.Time, reasonCode string) ([]*entities.ReasonCodeBreakdownResult, error)
+
 	// GetMonthlyIssuedPoints は今月の発行ポイント数を取得
 	GetMonthlyIssuedPoints(ctx context.Context) (int64, error)
 
 	// GetMonthlyTransactionCount は今月のトランザクション数を取得
 	GetMonthlyTransactionCount(ctx context.Context) (int64, error)
+
+	// GetActiveUserCounts は期間ごとのアクティブユーザー数（WAU/MAU）を取得（[from, to)）
+	GetActiveUserCounts(ctx context.Context, granularity entities.AnalyticsGranularity, basis entities.ActivityBasis, from, to time.Time) ([]*entities.ActiveUserCountResult, error)
+
+	// GetCohortSizes は登録期間ごとの新規ユーザー数を取得（[from, to)に登録したユーザーが対象）
+	GetCohortSizes(ctx context.Context, granularity entities.AnalyticsGranularity, from, to time.Time) ([]*entities.CohortSizeResult, error)
+
+	// GetCohortActivity はコホートごと・期間ごとのアクティブユーザー数を取得
+	GetCohortActivity(ctx context.Context, granularity entities.AnalyticsGranularity, basis entities.ActivityBasis, from, to time.Time) ([]*entities.CohortActivityResult, error)
+
+	// GetFeatureUsage は機能別の利用状況を取得（[from, to)）
+	GetFeatureUsage(ctx context.Context, from, to time.Time) ([]*entities.FeatureUsageResult, error)
+
+	// GetExpiryForecast はfromから週ごとに、weeks週先までの失効予定ポイントを取得
+	GetExpiryForecast(ctx context.Context, from time.Time, weeks int) ([]*entities.ExpiryForecastResult, error)
+
+	// GetOutstandingPoints は失効前のバッチに残っているポイントの合計を取得
+	GetOutstandingPoints(ctx context.Context, now time.Time) (int64, error)
+
+	// GetCirculationVelocity はsince以降の支払いについて、獲得から支払いまでの時間の中央値を取得
+	GetCirculationVelocity(ctx context.Context, since time.Time) (*entities.CirculationVelocityResult, error)
+
+	// GetPointConservation はユーザーの残高の合計とシステムの口座ごとの入出金を取得
+	GetPointConservation(ctx context.Context) (*entities.PointConservation, error)
 }
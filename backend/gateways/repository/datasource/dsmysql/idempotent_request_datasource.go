@@ -0,0 +1,27 @@
+package dsmysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// IdempotentRequestDataSource はIdempotency-Keyヘッダー付きリクエストのデータソースインターフェース
+type IdempotentRequestDataSource interface {
+	// InsertIfAbsent は同じ(scope, key, route)が無い場合のみ挿入し、挿入できたかを返す
+	InsertIfAbsent(ctx context.Context, req *entities.IdempotentRequest) (bool, error)
+
+	// SelectByKey は(scope, key, route)で取得（存在しない場合はnil）
+	SelectByKey(ctx context.Context, scope, key, route string) (*entities.IdempotentRequest, error)
+
+	// Update は保存済みレスポンスを更新
+	Update(ctx context.Context, req *entities.IdempotentRequest) error
+
+	// Delete は削除
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// DeleteExpired は指定時刻までに期限切れになったものを削除
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
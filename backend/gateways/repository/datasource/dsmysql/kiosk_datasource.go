@@ -0,0 +1,51 @@
+package dsmysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// KioskDataSource はキオスク端末・カード・付与記録のデータソースインターフェース
+type KioskDataSource interface {
+	// InsertDevice は新しい端末を挿入
+	InsertDevice(ctx context.Context, device *entities.KioskDevice) error
+
+	// SelectDevice はIDで端末を検索
+	SelectDevice(ctx context.Context, id uuid.UUID) (*entities.KioskDevice, error)
+
+	// SelectDeviceByAPIKeyHash はAPIキーハッシュで端末を検索
+	SelectDeviceByAPIKeyHash(ctx context.Context, apiKeyHash string) (*entities.KioskDevice, error)
+
+	// SelectDeviceList は端末一覧を取得
+	SelectDeviceList(ctx context.Context) ([]*entities.KioskDevice, error)
+
+	// UpdateDevice は端末情報を更新
+	UpdateDevice(ctx context.Context, device *entities.KioskDevice) error
+
+	// UpdateDeviceLastSeen は端末の最終アクセス日時を更新
+	UpdateDeviceLastSeen(ctx context.Context, id uuid.UUID, seenAt time.Time) error
+
+	// InsertCard はカードIDとユーザーの紐付けを挿入
+	InsertCard(ctx context.Context, card *entities.KioskCard) error
+
+	// SelectCard はカードIDで紐付けを検索
+	SelectCard(ctx context.Context, cardID string) (*entities.KioskCard, error)
+
+	// DeleteCard はカードIDの紐付けを削除
+	DeleteCard(ctx context.Context, cardID string) error
+
+	// InsertGrant は付与記録を挿入
+	InsertGrant(ctx context.Context, grant *entities.KioskGrant) error
+
+	// SelectGrantByIdempotencyKey は端末と冪等性キーで付与記録を検索（存在しない場合はnil）
+	SelectGrantByIdempotencyKey(ctx context.Context, deviceID uuid.UUID, key string) (*entities.KioskGrant, error)
+
+	// CountGrantsByDeviceSince は端末の指定日時以降の付与回数を取得
+	CountGrantsByDeviceSince(ctx context.Context, deviceID uuid.UUID, since time.Time) (int64, error)
+
+	// ExistsGrantByDeviceAndUserSince は端末が指定日時以降にユーザーへ付与済みか確認
+	ExistsGrantByDeviceAndUserSince(ctx context.Context, deviceID, userID uuid.UUID, since time.Time) (bool, error)
+}
@@ -0,0 +1,45 @@
+package dsmysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// LoginAttemptDataSource はログイン試行・ロックアウトのデータソースインターフェース
+type LoginAttemptDataSource interface {
+	// Insert はログイン試行を挿入
+	Insert(ctx context.Context, attempt *entities.LoginAttempt) error
+
+	// CountFailedByIPSince はIPアドレスの指定日時以降の失敗回数を取得
+	CountFailedByIPSince(ctx context.Context, ipAddress string, since time.Time) (int64, error)
+
+	// CountSuccessByUser はユーザーのログイン成功回数を取得
+	CountSuccessByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// ExistsSuccessByUserAndUserAgent は同じUser-Agentでのログイン成功履歴があるか確認
+	ExistsSuccessByUserAndUserAgent(ctx context.Context, userID uuid.UUID, userAgent string) (bool, error)
+
+	// ExistsSuccessByUserAndCountry は同じ国からのログイン成功履歴があるか確認
+	ExistsSuccessByUserAndCountry(ctx context.Context, userID uuid.UUID, country string) (bool, error)
+
+	// ExistsSuccessByUserAndIP は同じIPアドレスからのログイン成功履歴があるか確認
+	ExistsSuccessByUserAndIP(ctx context.Context, userID uuid.UUID, ipAddress string) (bool, error)
+
+	// SelectListByUserID はユーザーのログイン試行を新しい順に取得
+	SelectListByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.LoginAttempt, error)
+
+	// CountByUserID はユーザーのログイン試行の件数を取得
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// SelectLockout はユーザーのロックアウト状態を取得（存在しない場合はnil）
+	SelectLockout(ctx context.Context, userID uuid.UUID) (*entities.AccountLockout, error)
+
+	// SelectLockoutByUnlockTokenHash はロック解除トークンのハッシュでロックアウト状態を取得
+	SelectLockoutByUnlockTokenHash(ctx context.Context, tokenHash string) (*entities.AccountLockout, error)
+
+	// UpsertLockout はロックアウト状態を保存（存在しない場合は挿入）
+	UpsertLockout(ctx context.Context, lockout *entities.AccountLockout) error
+}
@@ -2,6 +2,7 @@ package dsmysql
 
 import (
 	"context"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/google/uuid"
@@ -57,6 +58,24 @@ type ProductExchangeDataSource interface {
 	// CountByUserID はユーザーの交換総数を取得
 	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
 
+	// SelectListByRecipientID はユーザーが贈られた交換を取得
+	SelectListByRecipientID(ctx context.Context, recipientID uuid.UUID, offset, limit int) ([]*entities.ProductExchange, error)
+
+	// CountByRecipientID はユーザーが贈られた交換の総数を取得
+	CountByRecipientID(ctx context.Context, recipientID uuid.UUID) (int64, error)
+
 	// CountAll は全体の交換総数を取得
 	CountAll(ctx context.Context) (int64, error)
+
+	// SelectByRedemptionCode は引換コードで交換を検索
+	SelectByRedemptionCode(ctx context.Context, code string) (*entities.ProductExchange, error)
+
+	// UpdateRedeemed は未使用の引換コードだけを使用済みにする
+	UpdateRedeemed(ctx context.Context, exchange *entities.ProductExchange) error
+
+	// SelectReport は期間内の交換を商品ごと・期間ごとに集計
+	SelectReport(ctx context.Context, from, to time.Time, granularity entities.AnalyticsGranularity) ([]*entities.ExchangeReportRow, error)
+
+	// SumQuantityByPricingRule はユーザーが価格ルールの割引価格で交換した数量の合計を取得
+	SumQuantityByPricingRule(ctx context.Context, userID, ruleID uuid.UUID) (int, error)
 }
@@ -15,15 +15,42 @@ type SessionDataSource interface {
 	// SelectByToken はトークンでセッションを検索
 	SelectByToken(ctx context.Context, token string) (*entities.Session, error)
 
+	// Select はIDでセッションを検索
+	Select(ctx context.Context, id uuid.UUID) (*entities.Session, error)
+
+	// SelectListByUserID はユーザーの有効なセッション一覧を取得（最終アクティブ順）
+	SelectListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Session, error)
+
 	// Update はセッションを更新
 	Update(ctx context.Context, session *entities.Session) error
 
+	// UpdateCSRFToken は保存されたトークンがpreviousTokenのときだけCSRFトークンを更新し、更新したかを返す
+	UpdateCSRFToken(ctx context.Context, session *entities.Session, previousToken string) (bool, error)
+
+	// UpdateFingerprint はセッションを固定する端末・接続元の指紋を更新
+	UpdateFingerprint(ctx context.Context, id uuid.UUID, fingerprint string) error
+
 	// Delete はセッションを削除
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// DeleteByUserID はユーザーの全セッションを削除
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
 
+	// DeleteByUserIDExcept は指定セッション以外のユーザーの全セッションを削除し、削除件数を返す
+	DeleteByUserIDExcept(ctx context.Context, userID, exceptID uuid.UUID) (int64, error)
+
+	// DeleteAllExcept は指定セッション以外の全ユーザーのセッションを削除し、削除件数を返す
+	DeleteAllExcept(ctx context.Context, exceptID uuid.UUID) (int64, error)
+
 	// DeleteExpired は期限切れセッションを削除
 	DeleteExpired(ctx context.Context) error
+
+	// InsertBindingViolation はセッションの固定の違反を挿入
+	InsertBindingViolation(ctx context.Context, violation *entities.SessionBindingViolation) error
+
+	// SelectBindingViolationsByUserID はユーザーのセッションの固定の違反を新しい順に取得
+	SelectBindingViolationsByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.SessionBindingViolation, error)
+
+	// CountBindingViolationsByUserID はユーザーのセッションの固定の違反の件数を取得
+	CountBindingViolationsByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
 }
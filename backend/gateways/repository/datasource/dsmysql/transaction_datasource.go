@@ -2,6 +2,7 @@ package dsmysql
 
 import (
 	"context"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/google/uuid"
@@ -25,25 +26,34 @@ type TransactionDataSource interface {
 	SelectListAll(ctx context.Context, offset, limit int) ([]*entities.Transaction, error)
 
 	// SelectListAllWithFilter はフィルタ・ソート付きで全トランザクション一覧を取得
-	SelectListAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, sortBy, sortOrder string, offset, limit int) ([]*entities.Transaction, error)
+	SelectListAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode, sortBy, sortOrder string, offset, limit int) ([]*entities.Transaction, error)
 
 	// CountAll は全トランザクション総数を取得
 	CountAll(ctx context.Context) (int64, error)
 
 	// CountAllWithFilter はフィルタ付きで全トランザクション総数を取得
-	CountAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo string) (int64, error)
+	CountAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode string, departmentIDs []uuid.UUID) (int64, error)
 
 	// Update はトランザクションを更新
 	Update(ctx context.Context, transaction *entities.Transaction) error
 
-	// CountByUserID はユーザーのトランザクション総数を取得
-	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+	// CountByUserID はユーザーのトランザクション総数を取得（reasonCodeが空でなければその理由コードの取引のみ）
+	CountByUserID(ctx context.Context, userID uuid.UUID, reasonCode string) (int64, error)
 
 	// SelectListByUserIDWithUsers はユーザーに関連するトランザクション一覧をユーザー情報付きで取得（JOIN）
-	SelectListByUserIDWithUsers(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.TransactionWithUsers, error)
+	SelectListByUserIDWithUsers(ctx context.Context, userID uuid.UUID, reasonCode string, offset, limit int) ([]*entities.TransactionWithUsers, error)
 
 	// SelectListAllWithFilterAndUsers はフィルタ・ソート付きで全トランザクション一覧をユーザー情報付きで取得（JOIN）
-	SelectListAllWithFilterAndUsers(ctx context.Context, transactionType, dateFrom, dateTo, sortBy, sortOrder string, offset, limit int) ([]*entities.TransactionWithUsers, error)
+	SelectListAllWithFilterAndUsers(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode string, departmentIDs []uuid.UUID, sortBy, sortOrder string, offset, limit int) ([]*entities.TransactionWithUsers, error)
+
+	// UpdateAnonymizeUser は退会するユーザーが関わった取引に退会済みの印を付ける（scrubMemosがtrueなら退会者のメモも消す）
+	UpdateAnonymizeUser(ctx context.Context, userID uuid.UUID, scrubMemos bool) error
+
+	// SumExchangedWith はユーザーと各相手の間でやり取りした完了済みの取引の合計を取得
+	SumExchangedWith(ctx context.Context, userID uuid.UUID, counterpartIDs []uuid.UUID) ([]*entities.PointsExchanged, error)
+
+	// SelectTransferAmountUsage はsince以降にユーザーが各送金先へ送った完了済みの送金を、送金先・金額ごとに集計
+	SelectTransferAmountUsage(ctx context.Context, fromUserID uuid.UUID, toUserIDs []uuid.UUID, since time.Time) ([]*entities.TransferAmountUsage, error)
 }
 
 // IdempotencyKeyDataSource はMySQLの冪等性キーデータソースインターフェース
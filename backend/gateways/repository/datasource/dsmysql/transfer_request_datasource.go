@@ -21,15 +21,24 @@ type TransferRequestDataSource interface {
 	// Update は送金リクエストを更新
 	Update(ctx context.Context, transferRequest *entities.TransferRequest) error
 
-	// SelectPendingByToUser は受取人宛の承認待ちリクエストを取得
+	// SelectPendingByToUser は受取人宛の承認待ちリクエストを取得（自分が支払う割り勘の1人分を含む）
 	SelectPendingByToUser(ctx context.Context, toUserID uuid.UUID, offset, limit int) ([]*entities.TransferRequest, error)
 
-	// SelectSentByFromUser は送信者が送ったリクエストを取得
+	// SelectSentByFromUser は送信者が送ったリクエストを取得（割り勘の1人分は除く）
 	SelectSentByFromUser(ctx context.Context, fromUserID uuid.UUID, offset, limit int) ([]*entities.TransferRequest, error)
 
 	// CountPendingByToUser は受取人宛の承認待ちリクエスト数を取得
 	CountPendingByToUser(ctx context.Context, toUserID uuid.UUID) (int64, error)
 
+	// CountPendingByFromUser は送信者が送った有効期限内の承認待ちリクエスト数を取得（割り勘の1人分は除く）
+	CountPendingByFromUser(ctx context.Context, fromUserID uuid.UUID) (int64, error)
+
+	// CountPendingByFromUserTo は送信者が受取人に送った有効期限内の承認待ちリクエスト数を取得（割り勘の1人分は除く）
+	CountPendingByFromUserTo(ctx context.Context, fromUserID, toUserID uuid.UUID) (int64, error)
+
+	// SelectBySplitIDs は割り勘ごとの送金リクエストを作成順に取得
+	SelectBySplitIDs(ctx context.Context, splitIDs []uuid.UUID) ([]*entities.TransferRequest, error)
+
 	// UpdateExpiredRequests は期限切れのリクエストを一括更新
 	UpdateExpiredRequests(ctx context.Context) (int64, error)
 
@@ -11,7 +11,8 @@ import (
 type BalanceUpdate struct {
 	UserID   uuid.UUID
 	Amount   int64
-	IsDeduct bool // true: 減算, false: 加算
+	IsDeduct bool  // true: 減算, false: 加算
+	Reserved int64 // 減算のうち確保中の分から使う額
 }
 
 // UserDataSource はMySQLのユーザーデータソースインターフェース
@@ -42,17 +43,20 @@ type UserDataSource interface {
 	// 内部でID順にロックを取得することでデッドロックを回避します
 	UpdateBalancesWithLock(ctx context.Context, updates []BalanceUpdate) error
 
+	// UpdateReservedBalanceWithLock は確保中の額を増減（悲観的ロック）
+	UpdateReservedBalanceWithLock(ctx context.Context, userID uuid.UUID, amount int64, isRelease bool) error
+
 	// SelectList はユーザー一覧を取得
 	SelectList(ctx context.Context, offset, limit int) ([]*entities.User, error)
 
 	// SelectListWithSearch は検索・ソート付きでユーザー一覧を取得
-	SelectListWithSearch(ctx context.Context, search string, sortBy string, sortOrder string, offset, limit int) ([]*entities.User, error)
+	SelectListWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID, sortBy string, sortOrder string, offset, limit int) ([]*entities.User, error)
 
 	// Count はユーザー総数を取得
 	Count(ctx context.Context) (int64, error)
 
 	// CountWithSearch は検索条件付きでユーザー総数を取得
-	CountWithSearch(ctx context.Context, search string) (int64, error)
+	CountWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID) (int64, error)
 
 	// Delete はユーザーを論理削除
 	Delete(ctx context.Context, id uuid.UUID) error
@@ -2,6 +2,7 @@ package dsmysql
 
 import (
 	"context"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/google/uuid"
@@ -36,6 +37,9 @@ type EmailVerificationDataSource interface {
 	// SelectByToken はトークンで検索
 	SelectByToken(ctx context.Context, token string) (*entities.EmailVerificationToken, error)
 
+	// SelectByOldEmailToken は旧アドレス確認用トークンで検索
+	SelectByOldEmailToken(ctx context.Context, token string) (*entities.EmailVerificationToken, error)
+
 	// Update はトークン情報を更新
 	Update(ctx context.Context, token *entities.EmailVerificationToken) error
 
@@ -44,6 +48,9 @@ type EmailVerificationDataSource interface {
 
 	// DeleteByUserID はユーザーIDに紐づくトークンを削除
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+
+	// CountByUserIDSince はユーザーに指定日時以降に発行した種類ごとのトークン数を取得
+	CountByUserIDSince(ctx context.Context, userID uuid.UUID, tokenType entities.TokenType, since time.Time) (int64, error)
 }
 
 // UsernameChangeHistoryDataSource はユーザー名変更履歴のデータソースインターフェース
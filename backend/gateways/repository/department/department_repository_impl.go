@@ -0,0 +1,65 @@
+package department
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// DepartmentRepositoryImpl は部署リポジトリの実装
+type DepartmentRepositoryImpl struct {
+	ds *dspostgresimpl.DepartmentDataSource
+}
+
+// NewDepartmentRepository は新しいDepartmentRepositoryを作成
+func NewDepartmentRepository(ds *dspostgresimpl.DepartmentDataSource) *DepartmentRepositoryImpl {
+	return &DepartmentRepositoryImpl{ds: ds}
+}
+
+// Create は部署を作成
+func (r *DepartmentRepositoryImpl) Create(ctx context.Context, department *entities.Department) error {
+	return r.ds.Insert(ctx, department)
+}
+
+// Read はIDで部署を取得
+func (r *DepartmentRepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.Department, error) {
+	return r.ds.Select(ctx, id)
+}
+
+// ReadForUpdate は部署を行ロック付きで取得
+func (r *DepartmentRepositoryImpl) ReadForUpdate(ctx context.Context, id uuid.UUID) (*entities.Department, error) {
+	return r.ds.SelectForUpdate(ctx, id)
+}
+
+// ReadList は全部署を取得
+func (r *DepartmentRepositoryImpl) ReadList(ctx context.Context) ([]*entities.Department, error) {
+	return r.ds.SelectList(ctx)
+}
+
+// Update は部署を更新
+func (r *DepartmentRepositoryImpl) Update(ctx context.Context, department *entities.Department) error {
+	return r.ds.Update(ctx, department)
+}
+
+// Delete は部署を削除
+func (r *DepartmentRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.ds.Delete(ctx, id)
+}
+
+// SetUserDepartment はユーザーの所属部署を設定・解除
+func (r *DepartmentRepositoryImpl) SetUserDepartment(ctx context.Context, userID uuid.UUID, departmentID *uuid.UUID) error {
+	return r.ds.UpdateUserDepartment(ctx, userID, departmentID)
+}
+
+// SumAdminGrantsSince は部署の所属ユーザーへの管理者付与の合計を取得
+func (r *DepartmentRepositoryImpl) SumAdminGrantsSince(ctx context.Context, departmentID uuid.UUID, since time.Time) (int64, error) {
+	return r.ds.SumAdminGrantsSince(ctx, departmentID, since)
+}
+
+// ReadBreakdown は部署ごとの付与・利用ポイントを取得
+func (r *DepartmentRepositoryImpl) ReadBreakdown(ctx context.Context, since time.Time) ([]*entities.DepartmentBreakdownResult, error) {
+	return r.ds.SelectBreakdown(ctx, since)
+}
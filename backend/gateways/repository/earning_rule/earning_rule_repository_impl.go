@@ -0,0 +1,80 @@
+package earning_rule
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// EarningRuleRepositoryImpl はポイント獲得ルールリポジトリの実装
+type EarningRuleRepositoryImpl struct {
+	ds *dspostgresimpl.EarningRuleDataSource
+}
+
+// NewEarningRuleRepository は新しいEarningRuleRepositoryを作成
+func NewEarningRuleRepository(ds *dspostgresimpl.EarningRuleDataSource) *EarningRuleRepositoryImpl {
+	return &EarningRuleRepositoryImpl{ds: ds}
+}
+
+// Create はポイント獲得ルールを作成
+func (r *EarningRuleRepositoryImpl) Create(ctx context.Context, rule *entities.EarningRule) error {
+	return r.ds.Insert(ctx, rule)
+}
+
+// Read はIDでポイント獲得ルールを取得
+func (r *EarningRuleRepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.EarningRule, error) {
+	return r.ds.SelectByID(ctx, id)
+}
+
+// Update はポイント獲得ルールを更新
+func (r *EarningRuleRepositoryImpl) Update(ctx context.Context, rule *entities.EarningRule) error {
+	return r.ds.Update(ctx, rule)
+}
+
+// Delete はポイント獲得ルールを削除
+func (r *EarningRuleRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.ds.Delete(ctx, id)
+}
+
+// ReadList はポイント獲得ルールを新しい順に取得
+func (r *EarningRuleRepositoryImpl) ReadList(ctx context.Context, offset, limit int) ([]*entities.EarningRule, error) {
+	return r.ds.SelectList(ctx, offset, limit)
+}
+
+// Count はポイント獲得ルールの件数を取得
+func (r *EarningRuleRepositoryImpl) Count(ctx context.Context) (int64, error) {
+	return r.ds.Count(ctx)
+}
+
+// ReadActiveByTrigger はきっかけが一致する有効なルールを取得
+func (r *EarningRuleRepositoryImpl) ReadActiveByTrigger(ctx context.Context, trigger entities.EarningTrigger, now time.Time) ([]*entities.EarningRule, error) {
+	return r.ds.SelectActiveByTrigger(ctx, trigger, now)
+}
+
+// CountOccurrences はユーザーのきっかけとなる出来事の回数を取得
+func (r *EarningRuleRepositoryImpl) CountOccurrences(ctx context.Context, userID uuid.UUID, trigger entities.EarningTrigger) (int64, error) {
+	return r.ds.CountOccurrences(ctx, userID, trigger)
+}
+
+// IncrementGrantCount は上限に達していなければ付与済みの回数を1増やす
+func (r *EarningRuleRepositoryImpl) IncrementGrantCount(ctx context.Context, ruleID uuid.UUID) (bool, error) {
+	return r.ds.IncrementGrantCount(ctx, ruleID)
+}
+
+// CountUserGrants はユーザーにルールで付与した回数を取得
+func (r *EarningRuleRepositoryImpl) CountUserGrants(ctx context.Context, ruleID, userID uuid.UUID) (int, error) {
+	return r.ds.CountUserGrants(ctx, ruleID, userID)
+}
+
+// CreateGrant は付与を記録
+func (r *EarningRuleRepositoryImpl) CreateGrant(ctx context.Context, grant *entities.EarningRuleGrant) (bool, error) {
+	return r.ds.InsertGrant(ctx, grant)
+}
+
+// ReadStats はルールごとの付与の実績を取得
+func (r *EarningRuleRepositoryImpl) ReadStats(ctx context.Context, ruleIDs []uuid.UUID) (map[uuid.UUID]*entities.EarningRuleStats, error) {
+	return r.ds.SelectStats(ctx, ruleIDs)
+}
@@ -0,0 +1,48 @@
+package email_template
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+)
+
+// EmailTemplateRepositoryImpl はメールテンプレートリポジトリの実装
+type EmailTemplateRepositoryImpl struct {
+	ds *dspostgresimpl.EmailTemplateDataSource
+}
+
+// NewEmailTemplateRepository は新しいEmailTemplateRepositoryを作成
+func NewEmailTemplateRepository(ds *dspostgresimpl.EmailTemplateDataSource) *EmailTemplateRepositoryImpl {
+	return &EmailTemplateRepositoryImpl{ds: ds}
+}
+
+// Create はテンプレートの新しい版を保存
+func (r *EmailTemplateRepositoryImpl) Create(ctx context.Context, template *entities.EmailTemplate) error {
+	return r.ds.Insert(ctx, template)
+}
+
+// ReadLatest はメールの種類の最新の版を取得
+func (r *EmailTemplateRepositoryImpl) ReadLatest(ctx context.Context, key entities.EmailTemplateKey) (*entities.EmailTemplate, error) {
+	return r.ds.SelectLatest(ctx, key)
+}
+
+// ReadVersion は指定した版を取得
+func (r *EmailTemplateRepositoryImpl) ReadVersion(ctx context.Context, key entities.EmailTemplateKey, version int) (*entities.EmailTemplate, error) {
+	return r.ds.SelectVersion(ctx, key, version)
+}
+
+// ReadVersions はメールの種類のすべての版を新しい順に取得
+func (r *EmailTemplateRepositoryImpl) ReadVersions(ctx context.Context, key entities.EmailTemplateKey) ([]*entities.EmailTemplate, error) {
+	return r.ds.SelectVersions(ctx, key)
+}
+
+// ReadLatestList は編集済みのメールの種類ごとに最新の版を取得
+func (r *EmailTemplateRepositoryImpl) ReadLatestList(ctx context.Context) ([]*entities.EmailTemplate, error) {
+	return r.ds.SelectLatestList(ctx)
+}
+
+// DeleteByKey はメールの種類のすべての版を削除
+func (r *EmailTemplateRepositoryImpl) DeleteByKey(ctx context.Context, key entities.EmailTemplateKey) error {
+	return r.ds.DeleteByKey(ctx, key)
+}
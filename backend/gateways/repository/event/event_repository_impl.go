@@ -0,0 +1,64 @@
+package event
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// EventRepositoryImpl はチェックインイベントリポジトリの実装
+type EventRepositoryImpl struct {
+	ds *dspostgresimpl.EventDataSource
+}
+
+// NewEventRepository は新しいEventRepositoryを作成
+func NewEventRepository(ds *dspostgresimpl.EventDataSource) *EventRepositoryImpl {
+	return &EventRepositoryImpl{ds: ds}
+}
+
+// Create はイベントを作成
+func (r *EventRepositoryImpl) Create(ctx context.Context, event *entities.Event) error {
+	return r.ds.Insert(ctx, event)
+}
+
+// Read はIDでイベントを取得
+func (r *EventRepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.Event, error) {
+	return r.ds.SelectByID(ctx, id)
+}
+
+// ReadByCode はQRコードのコードでイベントを取得
+func (r *EventRepositoryImpl) ReadByCode(ctx context.Context, code string) (*entities.Event, error) {
+	return r.ds.SelectByCode(ctx, code)
+}
+
+// Update はイベントの内容を更新
+func (r *EventRepositoryImpl) Update(ctx context.Context, event *entities.Event) error {
+	return r.ds.Update(ctx, event)
+}
+
+// ReadList はイベントを新しい順に取得
+func (r *EventRepositoryImpl) ReadList(ctx context.Context, offset, limit int) ([]*entities.Event, error) {
+	return r.ds.SelectList(ctx, offset, limit)
+}
+
+// Count はイベントの件数を取得
+func (r *EventRepositoryImpl) Count(ctx context.Context) (int64, error) {
+	return r.ds.Count(ctx)
+}
+
+// IncrementAttendeeCount は定員に空きがあればチェックイン済みの人数を1増やす
+func (r *EventRepositoryImpl) IncrementAttendeeCount(ctx context.Context, id uuid.UUID) (bool, error) {
+	return r.ds.IncrementAttendeeCount(ctx, id)
+}
+
+// CreateAttendance はチェックインを記録
+func (r *EventRepositoryImpl) CreateAttendance(ctx context.Context, attendance *entities.EventAttendance) error {
+	return r.ds.InsertAttendance(ctx, attendance)
+}
+
+// ReadAttendees はイベントの参加者を取得
+func (r *EventRepositoryImpl) ReadAttendees(ctx context.Context, eventID uuid.UUID, offset, limit int) ([]*entities.EventAttendee, error) {
+	return r.ds.SelectAttendees(ctx, eventID, offset, limit)
+}
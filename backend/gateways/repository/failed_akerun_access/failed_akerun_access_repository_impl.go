@@ -0,0 +1,55 @@
+package failed_akerun_access
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// FailedAkerunAccessRepositoryImpl はボーナスの付与に失敗したAkerunアクセス記録のリポジトリの実装
+type FailedAkerunAccessRepositoryImpl struct {
+	ds *dspostgresimpl.FailedAkerunAccessDataSource
+}
+
+// NewFailedAkerunAccessRepository は新しいFailedAkerunAccessRepositoryを作成
+func NewFailedAkerunAccessRepository(ds *dspostgresimpl.FailedAkerunAccessDataSource) *FailedAkerunAccessRepositoryImpl {
+	return &FailedAkerunAccessRepositoryImpl{ds: ds}
+}
+
+// Enqueue は失敗したアクセス記録を登録する（登録済みなら何もしない）
+func (r *FailedAkerunAccessRepositoryImpl) Enqueue(ctx context.Context, access *entities.FailedAkerunAccess) error {
+	return r.ds.Insert(ctx, access)
+}
+
+// Read はIDで取得
+func (r *FailedAkerunAccessRepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.FailedAkerunAccess, error) {
+	return r.ds.Select(ctx, id)
+}
+
+// ReadDue は再試行時刻を迎えたものを古い順に取得
+func (r *FailedAkerunAccessRepositoryImpl) ReadDue(ctx context.Context, now time.Time, limit int) ([]*entities.FailedAkerunAccess, error) {
+	return r.ds.SelectDue(ctx, now, limit)
+}
+
+// ReadList は新しい順に取得
+func (r *FailedAkerunAccessRepositoryImpl) ReadList(ctx context.Context, status entities.FailedAkerunAccessStatus, offset, limit int) ([]*entities.FailedAkerunAccess, error) {
+	return r.ds.SelectList(ctx, status, offset, limit)
+}
+
+// Count は件数を取得
+func (r *FailedAkerunAccessRepositoryImpl) Count(ctx context.Context, status entities.FailedAkerunAccessStatus) (int64, error) {
+	return r.ds.Count(ctx, status)
+}
+
+// Update は状態・失敗回数・次回の再試行時刻を更新
+func (r *FailedAkerunAccessRepositoryImpl) Update(ctx context.Context, access *entities.FailedAkerunAccess) error {
+	return r.ds.Update(ctx, access)
+}
+
+// Delete は付与できたアクセス記録を削除
+func (r *FailedAkerunAccessRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.ds.Delete(ctx, id)
+}
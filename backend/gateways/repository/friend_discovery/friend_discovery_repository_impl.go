@@ -0,0 +1,24 @@
+package friend_discovery
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// FriendDiscoveryRepositoryImpl は友達検索リポジトリの実装
+type FriendDiscoveryRepositoryImpl struct {
+	ds *dspostgresimpl.FriendDiscoveryDataSource
+}
+
+// NewFriendDiscoveryRepository は新しいFriendDiscoveryRepositoryを作成
+func NewFriendDiscoveryRepository(ds *dspostgresimpl.FriendDiscoveryDataSource) *FriendDiscoveryRepositoryImpl {
+	return &FriendDiscoveryRepositoryImpl{ds: ds}
+}
+
+// ReadDiscoverableByHashes はハッシュが一致する公開設定のユーザーを取得
+func (r *FriendDiscoveryRepositoryImpl) ReadDiscoverableByHashes(ctx context.Context, userID uuid.UUID, hashes []string) ([]*entities.User, error) {
+	return r.ds.SelectDiscoverableByHashes(ctx, userID, hashes)
+}
@@ -0,0 +1,34 @@
+package friend_pin
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// FriendPinRepositoryImpl は友達のピン留めのリポジトリの実装
+type FriendPinRepositoryImpl struct {
+	ds *dspostgresimpl.FriendPinDataSource
+}
+
+// NewFriendPinRepository は新しいFriendPinRepositoryを作成
+func NewFriendPinRepository(ds *dspostgresimpl.FriendPinDataSource) *FriendPinRepositoryImpl {
+	return &FriendPinRepositoryImpl{ds: ds}
+}
+
+// Create はピン留めを作成（既にピン留めしていれば何もしない）
+func (r *FriendPinRepositoryImpl) Create(ctx context.Context, pin *entities.FriendPin) error {
+	return r.ds.Insert(ctx, pin)
+}
+
+// Delete はピン留めを外す
+func (r *FriendPinRepositoryImpl) Delete(ctx context.Context, userID, friendID uuid.UUID) error {
+	return r.ds.Delete(ctx, userID, friendID)
+}
+
+// ReadListByUserID はユーザーのピン留めをピン留めした順に取得
+func (r *FriendPinRepositoryImpl) ReadListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.FriendPin, error) {
+	return r.ds.SelectListByUserID(ctx, userID)
+}
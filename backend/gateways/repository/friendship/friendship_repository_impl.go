@@ -93,3 +93,13 @@ func (r *RepositoryImpl) ReadListPendingRequestsWithUsers(ctx context.Context, u
 func (r *RepositoryImpl) CountPendingRequests(ctx context.Context, userID uuid.UUID) (int64, error) {
 	return r.friendshipDS.CountPendingRequests(ctx, userID)
 }
+
+// ReadListMutualFriends は2人のユーザーの共通の友達一覧を取得（ユーザー名順）
+func (r *RepositoryImpl) ReadListMutualFriends(ctx context.Context, userID1, userID2 uuid.UUID, offset, limit int) ([]*entities.User, error) {
+	return r.friendshipDS.SelectListMutualFriends(ctx, userID1, userID2, offset, limit)
+}
+
+// CountMutualFriends は2人のユーザーの共通の友達の人数を取得
+func (r *RepositoryImpl) CountMutualFriends(ctx context.Context, userID1, userID2 uuid.UUID) (int64, error) {
+	return r.friendshipDS.CountMutualFriends(ctx, userID1, userID2)
+}
@@ -0,0 +1,57 @@
+package idempotent_request
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/repository/datasource/dsmysql"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// IdempotentRequestRepositoryImpl はIdempotentRequestRepositoryの実装
+type IdempotentRequestRepositoryImpl struct {
+	idempotentRequestDS dsmysql.IdempotentRequestDataSource
+	logger              entities.Logger
+}
+
+// NewIdempotentRequestRepository は新しいIdempotentRequestRepositoryを作成
+func NewIdempotentRequestRepository(idempotentRequestDS dsmysql.IdempotentRequestDataSource, logger entities.Logger) repository.IdempotentRequestRepository {
+	return &IdempotentRequestRepositoryImpl{
+		idempotentRequestDS: idempotentRequestDS,
+		logger:              logger,
+	}
+}
+
+// CreateIfAbsent は同じ(scope, key, route)が無い場合のみ作成
+func (r *IdempotentRequestRepositoryImpl) CreateIfAbsent(ctx context.Context, req *entities.IdempotentRequest) (bool, error) {
+	r.logger.Debug("Creating idempotent request",
+		entities.NewField("scope", req.Scope),
+		entities.NewField("route", req.Route))
+	return r.idempotentRequestDS.InsertIfAbsent(ctx, req)
+}
+
+// ReadByKey は(scope, key, route)で取得
+func (r *IdempotentRequestRepositoryImpl) ReadByKey(ctx context.Context, scope, key, route string) (*entities.IdempotentRequest, error) {
+	return r.idempotentRequestDS.SelectByKey(ctx, scope, key, route)
+}
+
+// Update は保存済みレスポンスを更新
+func (r *IdempotentRequestRepositoryImpl) Update(ctx context.Context, req *entities.IdempotentRequest) error {
+	r.logger.Debug("Saving idempotent response",
+		entities.NewField("id", req.ID),
+		entities.NewField("status_code", req.StatusCode))
+	return r.idempotentRequestDS.Update(ctx, req)
+}
+
+// Delete は削除
+func (r *IdempotentRequestRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	r.logger.Debug("Deleting idempotent request", entities.NewField("id", id))
+	return r.idempotentRequestDS.Delete(ctx, id)
+}
+
+// DeleteExpired は期限切れのものを削除
+func (r *IdempotentRequestRepositoryImpl) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return r.idempotentRequestDS.DeleteExpired(ctx, now)
+}
@@ -0,0 +1,97 @@
+package kiosk
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/repository/datasource/dsmysql"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// KioskRepositoryImpl はKioskRepositoryの実装
+type KioskRepositoryImpl struct {
+	kioskDS dsmysql.KioskDataSource
+	logger  entities.Logger
+}
+
+// NewKioskRepository は新しいKioskRepositoryを作成
+func NewKioskRepository(kioskDS dsmysql.KioskDataSource, logger entities.Logger) repository.KioskRepository {
+	return &KioskRepositoryImpl{
+		kioskDS: kioskDS,
+		logger:  logger,
+	}
+}
+
+// CreateDevice は新しい端末を登録
+func (r *KioskRepositoryImpl) CreateDevice(ctx context.Context, device *entities.KioskDevice) error {
+	r.logger.Debug("Creating kiosk device", entities.NewField("name", device.Name))
+	return r.kioskDS.InsertDevice(ctx, device)
+}
+
+// ReadDevice はIDで端末を検索
+func (r *KioskRepositoryImpl) ReadDevice(ctx context.Context, id uuid.UUID) (*entities.KioskDevice, error) {
+	return r.kioskDS.SelectDevice(ctx, id)
+}
+
+// ReadDeviceByAPIKeyHash はAPIキーハッシュで端末を検索
+func (r *KioskRepositoryImpl) ReadDeviceByAPIKeyHash(ctx context.Context, apiKeyHash string) (*entities.KioskDevice, error) {
+	return r.kioskDS.SelectDeviceByAPIKeyHash(ctx, apiKeyHash)
+}
+
+// ReadDeviceList は端末一覧を取得
+func (r *KioskRepositoryImpl) ReadDeviceList(ctx context.Context) ([]*entities.KioskDevice, error) {
+	return r.kioskDS.SelectDeviceList(ctx)
+}
+
+// UpdateDevice は端末情報を更新
+func (r *KioskRepositoryImpl) UpdateDevice(ctx context.Context, device *entities.KioskDevice) error {
+	r.logger.Debug("Updating kiosk device", entities.NewField("device_id", device.ID))
+	return r.kioskDS.UpdateDevice(ctx, device)
+}
+
+// UpdateDeviceLastSeen は端末の最終アクセス日時を更新
+func (r *KioskRepositoryImpl) UpdateDeviceLastSeen(ctx context.Context, id uuid.UUID, seenAt time.Time) error {
+	return r.kioskDS.UpdateDeviceLastSeen(ctx, id, seenAt)
+}
+
+// CreateCard はカードIDとユーザーを紐付け
+func (r *KioskRepositoryImpl) CreateCard(ctx context.Context, card *entities.KioskCard) error {
+	r.logger.Debug("Registering kiosk card", entities.NewField("user_id", card.UserID))
+	return r.kioskDS.InsertCard(ctx, card)
+}
+
+// ReadCard はカードIDで紐付けを検索
+func (r *KioskRepositoryImpl) ReadCard(ctx context.Context, cardID string) (*entities.KioskCard, error) {
+	return r.kioskDS.SelectCard(ctx, cardID)
+}
+
+// DeleteCard はカードIDの紐付けを削除
+func (r *KioskRepositoryImpl) DeleteCard(ctx context.Context, cardID string) error {
+	r.logger.Debug("Deleting kiosk card")
+	return r.kioskDS.DeleteCard(ctx, cardID)
+}
+
+// CreateGrant は付与記録を作成
+func (r *KioskRepositoryImpl) CreateGrant(ctx context.Context, grant *entities.KioskGrant) error {
+	r.logger.Debug("Creating kiosk grant",
+		entities.NewField("device_id", grant.DeviceID),
+		entities.NewField("user_id", grant.UserID))
+	return r.kioskDS.InsertGrant(ctx, grant)
+}
+
+// ReadGrantByIdempotencyKey は端末と冪等性キーで付与記録を検索
+func (r *KioskRepositoryImpl) ReadGrantByIdempotencyKey(ctx context.Context, deviceID uuid.UUID, key string) (*entities.KioskGrant, error) {
+	return r.kioskDS.SelectGrantByIdempotencyKey(ctx, deviceID, key)
+}
+
+// CountGrantsByDeviceSince は端末の指定日時以降の付与回数を取得
+func (r *KioskRepositoryImpl) CountGrantsByDeviceSince(ctx context.Context, deviceID uuid.UUID, since time.Time) (int64, error) {
+	return r.kioskDS.CountGrantsByDeviceSince(ctx, deviceID, since)
+}
+
+// ExistsGrantByDeviceAndUserSince は端末が指定日時以降にユーザーへ付与済みか確認
+func (r *KioskRepositoryImpl) ExistsGrantByDeviceAndUserSince(ctx context.Context, deviceID, userID uuid.UUID, since time.Time) (bool, error) {
+	return r.kioskDS.ExistsGrantByDeviceAndUserSince(ctx, deviceID, userID, since)
+}
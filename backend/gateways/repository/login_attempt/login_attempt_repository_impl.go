@@ -0,0 +1,84 @@
+package login_attempt
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/repository/datasource/dsmysql"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// LoginAttemptRepositoryImpl はLoginAttemptRepositoryの実装
+type LoginAttemptRepositoryImpl struct {
+	loginAttemptDS dsmysql.LoginAttemptDataSource
+	logger         entities.Logger
+}
+
+// NewLoginAttemptRepository は新しいLoginAttemptRepositoryを作成
+func NewLoginAttemptRepository(loginAttemptDS dsmysql.LoginAttemptDataSource, logger entities.Logger) repository.LoginAttemptRepository {
+	return &LoginAttemptRepositoryImpl{
+		loginAttemptDS: loginAttemptDS,
+		logger:         logger,
+	}
+}
+
+// Create はログイン試行を記録
+func (r *LoginAttemptRepositoryImpl) Create(ctx context.Context, attempt *entities.LoginAttempt) error {
+	r.logger.Debug("Recording login attempt",
+		entities.NewField("username", attempt.Username),
+		entities.NewField("success", attempt.Success))
+	return r.loginAttemptDS.Insert(ctx, attempt)
+}
+
+// CountFailedByIPSince はIPアドレスの指定日時以降の失敗回数を取得
+func (r *LoginAttemptRepositoryImpl) CountFailedByIPSince(ctx context.Context, ipAddress string, since time.Time) (int64, error) {
+	return r.loginAttemptDS.CountFailedByIPSince(ctx, ipAddress, since)
+}
+
+// CountSuccessByUser はユーザーのログイン成功回数を取得
+func (r *LoginAttemptRepositoryImpl) CountSuccessByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return r.loginAttemptDS.CountSuccessByUser(ctx, userID)
+}
+
+// ExistsSuccessByUserAndUserAgent は同じ端末でのログイン成功履歴があるか確認
+func (r *LoginAttemptRepositoryImpl) ExistsSuccessByUserAndUserAgent(ctx context.Context, userID uuid.UUID, userAgent string) (bool, error) {
+	return r.loginAttemptDS.ExistsSuccessByUserAndUserAgent(ctx, userID, userAgent)
+}
+
+// ExistsSuccessByUserAndCountry は同じ国からのログイン成功履歴があるか確認
+func (r *LoginAttemptRepositoryImpl) ExistsSuccessByUserAndCountry(ctx context.Context, userID uuid.UUID, country string) (bool, error) {
+	return r.loginAttemptDS.ExistsSuccessByUserAndCountry(ctx, userID, country)
+}
+
+// ExistsSuccessByUserAndIP は同じIPアドレスからのログイン成功履歴があるか確認
+func (r *LoginAttemptRepositoryImpl) ExistsSuccessByUserAndIP(ctx context.Context, userID uuid.UUID, ipAddress string) (bool, error) {
+	return r.loginAttemptDS.ExistsSuccessByUserAndIP(ctx, userID, ipAddress)
+}
+
+// ReadListByUserID はユーザーのログイン試行を新しい順に取得
+func (r *LoginAttemptRepositoryImpl) ReadListByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.LoginAttempt, error) {
+	return r.loginAttemptDS.SelectListByUserID(ctx, userID, offset, limit)
+}
+
+// CountByUserID はユーザーのログイン試行の件数を取得
+func (r *LoginAttemptRepositoryImpl) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return r.loginAttemptDS.CountByUserID(ctx, userID)
+}
+
+// ReadLockout はユーザーのロックアウト状態を取得
+func (r *LoginAttemptRepositoryImpl) ReadLockout(ctx context.Context, userID uuid.UUID) (*entities.AccountLockout, error) {
+	return r.loginAttemptDS.SelectLockout(ctx, userID)
+}
+
+// ReadLockoutByUnlockTokenHash はロック解除トークンのハッシュでロックアウト状態を取得
+func (r *LoginAttemptRepositoryImpl) ReadLockoutByUnlockTokenHash(ctx context.Context, tokenHash string) (*entities.AccountLockout, error) {
+	return r.loginAttemptDS.SelectLockoutByUnlockTokenHash(ctx, tokenHash)
+}
+
+// SaveLockout はロックアウト状態を保存
+func (r *LoginAttemptRepositoryImpl) SaveLockout(ctx context.Context, lockout *entities.AccountLockout) error {
+	r.logger.Debug("Saving account lockout", entities.NewField("user_id", lockout.UserID))
+	return r.loginAttemptDS.UpsertLockout(ctx, lockout)
+}
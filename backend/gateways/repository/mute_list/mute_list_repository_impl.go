@@ -0,0 +1,39 @@
+package mute_list
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// MuteListRepositoryImpl は送金リクエストの送信者のミュートのリポジトリの実装
+type MuteListRepositoryImpl struct {
+	ds *dspostgresimpl.MutedSenderDataSource
+}
+
+// NewMuteListRepository は新しいMuteListRepositoryを作成
+func NewMuteListRepository(ds *dspostgresimpl.MutedSenderDataSource) *MuteListRepositoryImpl {
+	return &MuteListRepositoryImpl{ds: ds}
+}
+
+// Upsert はミュートを作成（既にミュートしていればミュートの方法だけ変える）
+func (r *MuteListRepositoryImpl) Upsert(ctx context.Context, mute *entities.MutedSender) error {
+	return r.ds.Upsert(ctx, mute)
+}
+
+// Delete はミュートを解除
+func (r *MuteListRepositoryImpl) Delete(ctx context.Context, userID, mutedUserID uuid.UUID) error {
+	return r.ds.Delete(ctx, userID, mutedUserID)
+}
+
+// Read はユーザーが送信者をミュートしているかを取得
+func (r *MuteListRepositoryImpl) Read(ctx context.Context, userID, mutedUserID uuid.UUID) (*entities.MutedSender, error) {
+	return r.ds.Select(ctx, userID, mutedUserID)
+}
+
+// ReadListByUserID はユーザーのミュートをミュートした順に取得
+func (r *MuteListRepositoryImpl) ReadListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.MutedSender, error) {
+	return r.ds.SelectListByUserID(ctx, userID)
+}
@@ -0,0 +1,65 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// NotificationRepositoryImpl は通知の配信先・設定リポジトリの実装
+type NotificationRepositoryImpl struct {
+	ds *dspostgresimpl.NotificationDataSource
+}
+
+// NewNotificationRepository は新しいNotificationRepositoryを作成
+func NewNotificationRepository(ds *dspostgresimpl.NotificationDataSource) *NotificationRepositoryImpl {
+	return &NotificationRepositoryImpl{ds: ds}
+}
+
+// UpsertDeviceToken はトークンを登録する（登録済みなら所有者とLastSeenAtを更新）
+func (r *NotificationRepositoryImpl) UpsertDeviceToken(ctx context.Context, device *entities.DeviceToken) error {
+	return r.ds.UpsertDeviceToken(ctx, device)
+}
+
+// DeleteDeviceToken はユーザーのトークンを削除
+func (r *NotificationRepositoryImpl) DeleteDeviceToken(ctx context.Context, userID uuid.UUID, token string) error {
+	return r.ds.DeleteDeviceToken(ctx, userID, token)
+}
+
+// DeleteDeviceTokenByID はトークンを削除
+func (r *NotificationRepositoryImpl) DeleteDeviceTokenByID(ctx context.Context, id uuid.UUID) error {
+	return r.ds.DeleteDeviceTokenByID(ctx, id)
+}
+
+// ReadDeviceTokensByUser はユーザーの登録済みトークンを新しい順に取得
+func (r *NotificationRepositoryImpl) ReadDeviceTokensByUser(ctx context.Context, userID uuid.UUID) ([]*entities.DeviceToken, error) {
+	return r.ds.SelectDeviceTokensByUser(ctx, userID)
+}
+
+// PruneDeviceTokens はユーザーのトークンを新しい順にkeep件だけ残して削除
+func (r *NotificationRepositoryImpl) PruneDeviceTokens(ctx context.Context, userID uuid.UUID, keep int) error {
+	return r.ds.PruneDeviceTokens(ctx, userID, keep)
+}
+
+// ReadPreferences はユーザーの通知設定を取得
+func (r *NotificationRepositoryImpl) ReadPreferences(ctx context.Context, userID uuid.UUID) (*entities.NotificationPreferences, error) {
+	return r.ds.SelectPreferences(ctx, userID)
+}
+
+// SavePreferences はユーザーの通知設定を保存
+func (r *NotificationRepositoryImpl) SavePreferences(ctx context.Context, prefs *entities.NotificationPreferences) error {
+	return r.ds.UpsertPreferences(ctx, prefs)
+}
+
+// ReadExpiringBatches は期限の通知をしていない、期限が近いバッチをユーザー順に取得
+func (r *NotificationRepositoryImpl) ReadExpiringBatches(ctx context.Context, now time.Time, within time.Duration, limit int) ([]*entities.PointBatch, error) {
+	return r.ds.SelectExpiringBatches(ctx, now, within, limit)
+}
+
+// MarkExpiryReminded はバッチを期限の通知済みにする
+func (r *NotificationRepositoryImpl) MarkExpiryReminded(ctx context.Context, batchIDs []uuid.UUID, remindedAt time.Time) error {
+	return r.ds.MarkExpiryReminded(ctx, batchIDs, remindedAt)
+}
@@ -0,0 +1,65 @@
+package pending_admin_action
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// PendingAdminActionRepositoryImpl は承認待ちの管理者操作リポジトリの実装
+type PendingAdminActionRepositoryImpl struct {
+	ds *dspostgresimpl.PendingAdminActionDataSource
+}
+
+// NewPendingAdminActionRepository は新しいPendingAdminActionRepositoryを作成
+func NewPendingAdminActionRepository(ds *dspostgresimpl.PendingAdminActionDataSource) *PendingAdminActionRepositoryImpl {
+	return &PendingAdminActionRepositoryImpl{ds: ds}
+}
+
+// Create は承認待ちの操作を作成
+func (r *PendingAdminActionRepositoryImpl) Create(ctx context.Context, action *entities.PendingAdminAction) error {
+	return r.ds.Insert(ctx, action)
+}
+
+// Read はIDで承認待ちの操作を取得
+func (r *PendingAdminActionRepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.PendingAdminAction, error) {
+	return r.ds.Select(ctx, id)
+}
+
+// ReadByIdempotencyKey は申請時の冪等性キーで取得
+func (r *PendingAdminActionRepositoryImpl) ReadByIdempotencyKey(ctx context.Context, key string) (*entities.PendingAdminAction, error) {
+	return r.ds.SelectByIdempotencyKey(ctx, key)
+}
+
+// ReadList は操作を新しい順に取得
+func (r *PendingAdminActionRepositoryImpl) ReadList(ctx context.Context, status entities.PendingAdminActionStatus, offset, limit int) ([]*entities.PendingAdminAction, error) {
+	return r.ds.SelectList(ctx, status, offset, limit)
+}
+
+// Count は操作の件数を取得
+func (r *PendingAdminActionRepositoryImpl) Count(ctx context.Context, status entities.PendingAdminActionStatus) (int64, error) {
+	return r.ds.Count(ctx, status)
+}
+
+// UpdateStatus は状態がfromのときだけ状態を更新
+func (r *PendingAdminActionRepositoryImpl) UpdateStatus(ctx context.Context, action *entities.PendingAdminAction, from entities.PendingAdminActionStatus) (bool, error) {
+	return r.ds.UpdateStatus(ctx, action, from)
+}
+
+// ReadExpired は期限を過ぎた承認待ちの操作を取得
+func (r *PendingAdminActionRepositoryImpl) ReadExpired(ctx context.Context, now time.Time, limit int) ([]*entities.PendingAdminAction, error) {
+	return r.ds.SelectExpired(ctx, now, limit)
+}
+
+// CreateEvent は監査記録を追加
+func (r *PendingAdminActionRepositoryImpl) CreateEvent(ctx context.Context, event *entities.PendingAdminActionEvent) error {
+	return r.ds.InsertEvent(ctx, event)
+}
+
+// ReadEvents は操作の監査記録を取得
+func (r *PendingAdminActionRepositoryImpl) ReadEvents(ctx context.Context, actionID uuid.UUID) ([]*entities.PendingAdminActionEvent, error) {
+	return r.ds.SelectEvents(ctx, actionID)
+}
@@ -11,16 +11,29 @@ import (
 
 // PointBatchRepositoryImpl はポイントバッチリポジトリの実装
 type PointBatchRepositoryImpl struct {
-	ds *dspostgresimpl.PointBatchDataSource
+	ds       *dspostgresimpl.PointBatchDataSource
+	policyDS *dspostgresimpl.PointExpiryPolicyDataSource
 }
 
 // NewPointBatchRepository は新しいPointBatchRepositoryを作成
-func NewPointBatchRepository(ds *dspostgresimpl.PointBatchDataSource) *PointBatchRepositoryImpl {
-	return &PointBatchRepositoryImpl{ds: ds}
+func NewPointBatchRepository(ds *dspostgresimpl.PointBatchDataSource, policyDS *dspostgresimpl.PointExpiryPolicyDataSource) *PointBatchRepositoryImpl {
+	return &PointBatchRepositoryImpl{ds: ds, policyDS: policyDS}
 }
 
 // Create は新しいポイントバッチを作成
+// 付与種別・ユーザーごとの有効期間の設定があれば有効期限に反映する
 func (r *PointBatchRepositoryImpl) Create(ctx context.Context, batch *entities.PointBatch) error {
+	if r.policyDS != nil {
+		policies, err := r.policyDS.SelectPolicies(ctx)
+		if err != nil {
+			return err
+		}
+		override, err := r.policyDS.SelectUserOverride(ctx, batch.UserID)
+		if err != nil {
+			return err
+		}
+		batch.ApplyExpiryPolicy(policies, override)
+	}
 	return r.ds.Insert(ctx, batch)
 }
 
@@ -43,3 +56,58 @@ func (r *PointBatchRepositoryImpl) MarkExpired(ctx context.Context, batchID uuid
 func (r *PointBatchRepositoryImpl) FindUpcomingExpirations(ctx context.Context, userID uuid.UUID) ([]*entities.PointBatch, error) {
 	return r.ds.SelectUpcomingExpirations(ctx, userID)
 }
+
+// Read はIDでバッチを取得
+func (r *PointBatchRepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.PointBatch, error) {
+	return r.ds.SelectByID(ctx, id)
+}
+
+// UpdateExpiresAt はバッチの有効期限を更新
+func (r *PointBatchRepositoryImpl) UpdateExpiresAt(ctx context.Context, id uuid.UUID, expiresAt time.Time) error {
+	return r.ds.UpdateExpiresAt(ctx, id, expiresAt)
+}
+
+// FindActiveByUser はユーザーの残量があるバッチを古い順に取得
+func (r *PointBatchRepositoryImpl) FindActiveByUser(ctx context.Context, userID uuid.UUID) ([]*entities.PointBatch, error) {
+	return r.ds.SelectActiveByUser(ctx, userID)
+}
+
+// MarkRestored はバッチを取り消し済みに更新
+func (r *PointBatchRepositoryImpl) MarkRestored(ctx context.Context, id uuid.UUID, restoredAt time.Time) error {
+	return r.ds.MarkRestored(ctx, id, restoredAt)
+}
+
+// FindRestorableBatches は取り消し可能な失効済みバッチを取得
+func (r *PointBatchRepositoryImpl) FindRestorableBatches(ctx context.Context, expiredAfter time.Time, userID *uuid.UUID, limit int) ([]*entities.PointBatch, error) {
+	return r.ds.SelectRestorableBatches(ctx, expiredAfter, userID, limit)
+}
+
+// FindByUser はユーザーのすべてのバッチを作成日時の新しい順に取得
+func (r *PointBatchRepositoryImpl) FindByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.PointBatch, error) {
+	return r.ds.SelectByUser(ctx, userID, offset, limit)
+}
+
+// CountByUser はユーザーのバッチの件数を取得
+func (r *PointBatchRepositoryImpl) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return r.ds.CountByUser(ctx, userID)
+}
+
+// AdjustExpiresAt は管理者が変更した有効期限を設定する
+func (r *PointBatchRepositoryImpl) AdjustExpiresAt(ctx context.Context, id uuid.UUID, expiresAt, adjustedAt time.Time) error {
+	return r.ds.AdjustExpiresAt(ctx, id, expiresAt, adjustedAt)
+}
+
+// Cancel はバッチを取り消し、残量を0にする
+func (r *PointBatchRepositoryImpl) Cancel(ctx context.Context, id uuid.UUID, remaining int64, cancelledAt time.Time) error {
+	return r.ds.Cancel(ctx, id, remaining, cancelledAt)
+}
+
+// CreateAdjustment は調整の記録を作成
+func (r *PointBatchRepositoryImpl) CreateAdjustment(ctx context.Context, adjustment *entities.PointBatchAdjustment) error {
+	return r.ds.InsertAdjustment(ctx, adjustment)
+}
+
+// FindAdjustments はバッチの調整の記録を古い順に取得
+func (r *PointBatchRepositoryImpl) FindAdjustments(ctx context.Context, batchIDs []uuid.UUID) ([]*entities.PointBatchAdjustment, error) {
+	return r.ds.SelectAdjustments(ctx, batchIDs)
+}
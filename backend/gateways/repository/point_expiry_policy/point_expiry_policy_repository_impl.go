@@ -0,0 +1,49 @@
+package point_expiry_policy
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// PointExpiryPolicyRepositoryImpl はポイント有効期間設定リポジトリの実装
+type PointExpiryPolicyRepositoryImpl struct {
+	ds *dspostgresimpl.PointExpiryPolicyDataSource
+}
+
+// NewPointExpiryPolicyRepository は新しいPointExpiryPolicyRepositoryを作成
+func NewPointExpiryPolicyRepository(ds *dspostgresimpl.PointExpiryPolicyDataSource) *PointExpiryPolicyRepositoryImpl {
+	return &PointExpiryPolicyRepositoryImpl{ds: ds}
+}
+
+// ReadListPolicies は全ての付与種別ポリシーを取得
+func (r *PointExpiryPolicyRepositoryImpl) ReadListPolicies(ctx context.Context) ([]*entities.PointExpiryPolicy, error) {
+	return r.ds.SelectPolicies(ctx)
+}
+
+// SavePolicy は付与種別ポリシーを保存
+func (r *PointExpiryPolicyRepositoryImpl) SavePolicy(ctx context.Context, policy *entities.PointExpiryPolicy) error {
+	return r.ds.UpsertPolicy(ctx, policy)
+}
+
+// DeletePolicy は付与種別ポリシーを削除
+func (r *PointExpiryPolicyRepositoryImpl) DeletePolicy(ctx context.Context, sourceType entities.PointBatchSourceType) error {
+	return r.ds.DeletePolicy(ctx, sourceType)
+}
+
+// ReadUserOverride はユーザー個別の設定を取得
+func (r *PointExpiryPolicyRepositoryImpl) ReadUserOverride(ctx context.Context, userID uuid.UUID) (*entities.UserPointExpiryOverride, error) {
+	return r.ds.SelectUserOverride(ctx, userID)
+}
+
+// SaveUserOverride はユーザー個別の設定を保存
+func (r *PointExpiryPolicyRepositoryImpl) SaveUserOverride(ctx context.Context, override *entities.UserPointExpiryOverride) error {
+	return r.ds.UpsertUserOverride(ctx, override)
+}
+
+// DeleteUserOverride はユーザー個別の設定を削除
+func (r *PointExpiryPolicyRepositoryImpl) DeleteUserOverride(ctx context.Context, userID uuid.UUID) error {
+	return r.ds.DeleteUserOverride(ctx, userID)
+}
@@ -0,0 +1,55 @@
+package pricing_rule
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// PricingRuleRepositoryImpl は価格ルールリポジトリの実装
+type PricingRuleRepositoryImpl struct {
+	ds *dspostgresimpl.PricingRuleDataSource
+}
+
+// NewPricingRuleRepository は新しいPricingRuleRepositoryを作成
+func NewPricingRuleRepository(ds *dspostgresimpl.PricingRuleDataSource) *PricingRuleRepositoryImpl {
+	return &PricingRuleRepositoryImpl{ds: ds}
+}
+
+// Create は価格ルールを作成
+func (r *PricingRuleRepositoryImpl) Create(ctx context.Context, rule *entities.PricingRule) error {
+	return r.ds.Insert(ctx, rule)
+}
+
+// Read はIDで価格ルールを取得
+func (r *PricingRuleRepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.PricingRule, error) {
+	return r.ds.SelectByID(ctx, id)
+}
+
+// Update は価格ルールを更新
+func (r *PricingRuleRepositoryImpl) Update(ctx context.Context, rule *entities.PricingRule) error {
+	return r.ds.Update(ctx, rule)
+}
+
+// Delete は価格ルールを削除
+func (r *PricingRuleRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.ds.Delete(ctx, id)
+}
+
+// ReadList は価格ルールを新しい順に取得
+func (r *PricingRuleRepositoryImpl) ReadList(ctx context.Context, offset, limit int) ([]*entities.PricingRule, error) {
+	return r.ds.SelectList(ctx, offset, limit)
+}
+
+// Count は価格ルールの件数を取得
+func (r *PricingRuleRepositoryImpl) Count(ctx context.Context) (int64, error) {
+	return r.ds.Count(ctx)
+}
+
+// ReadActiveForProduct は商品に適用されうる有効な価格ルールを取得
+func (r *PricingRuleRepositoryImpl) ReadActiveForProduct(ctx context.Context, productID uuid.UUID, categoryCode string, now time.Time) ([]*entities.PricingRule, error) {
+	return r.ds.SelectActiveForProduct(ctx, productID, categoryCode, now)
+}
@@ -0,0 +1,35 @@
+package processed_akerun_access
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// ProcessedAkerunAccessRepositoryImpl は処理済みのAkerunアクセス記録のリポジトリの実装
+type ProcessedAkerunAccessRepositoryImpl struct {
+	ds *dspostgresimpl.ProcessedAkerunAccessDataSource
+}
+
+// NewProcessedAkerunAccessRepository は新しいProcessedAkerunAccessRepositoryを作成
+func NewProcessedAkerunAccessRepository(ds *dspostgresimpl.ProcessedAkerunAccessDataSource) *ProcessedAkerunAccessRepositoryImpl {
+	return &ProcessedAkerunAccessRepositoryImpl{ds: ds}
+}
+
+// ReadProcessedIDs はaccessIDsのうち処理済みのIDを返す
+func (r *ProcessedAkerunAccessRepositoryImpl) ReadProcessedIDs(ctx context.Context, accessIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	return r.ds.SelectProcessedIDs(ctx, accessIDs)
+}
+
+// Create は処理済みとして記録する（記録済みなら何もしない）
+func (r *ProcessedAkerunAccessRepositoryImpl) Create(ctx context.Context, accesses []*entities.ProcessedAkerunAccess) error {
+	return r.ds.Insert(ctx, accesses)
+}
+
+// DeleteProcessedBefore はbeforeより前に処理した記録を削除
+func (r *ProcessedAkerunAccessRepositoryImpl) DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error) {
+	return r.ds.DeleteProcessedBefore(ctx, before)
+}
@@ -2,6 +2,7 @@ package product
 
 import (
 	"context"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/gateways/repository/datasource/dsmysql"
@@ -57,7 +58,38 @@ func (r *ProductExchangeRepositoryImpl) CountByUserID(ctx context.Context, userI
 	return r.exchangeDS.CountByUserID(ctx, userID)
 }
 
+// ReadListByRecipientID はユーザーが贈られた交換を取得
+func (r *ProductExchangeRepositoryImpl) ReadListByRecipientID(ctx context.Context, recipientID uuid.UUID, offset, limit int) ([]*entities.ProductExchange, error) {
+	return r.exchangeDS.SelectListByRecipientID(ctx, recipientID, offset, limit)
+}
+
+// CountByRecipientID はユーザーが贈られた交換の総数を取得
+func (r *ProductExchangeRepositoryImpl) CountByRecipientID(ctx context.Context, recipientID uuid.UUID) (int64, error) {
+	return r.exchangeDS.CountByRecipientID(ctx, recipientID)
+}
+
 // CountAll は全体の交換総数を取得
 func (r *ProductExchangeRepositoryImpl) CountAll(ctx context.Context) (int64, error) {
 	return r.exchangeDS.CountAll(ctx)
 }
+
+// ReadByRedemptionCode は引換コードで交換を検索
+func (r *ProductExchangeRepositoryImpl) ReadByRedemptionCode(ctx context.Context, code string) (*entities.ProductExchange, error) {
+	return r.exchangeDS.SelectByRedemptionCode(ctx, code)
+}
+
+// MarkRedeemed は引換コードを使用済みにする
+func (r *ProductExchangeRepositoryImpl) MarkRedeemed(ctx context.Context, exchange *entities.ProductExchange) error {
+	r.logger.Debug("Redeeming product exchange", entities.NewField("exchange_id", exchange.ID))
+	return r.exchangeDS.UpdateRedeemed(ctx, exchange)
+}
+
+// ReadReport は期間内の交換を商品ごと・期間ごとに集計
+func (r *ProductExchangeRepositoryImpl) ReadReport(ctx context.Context, from, to time.Time, granularity entities.AnalyticsGranularity) ([]*entities.ExchangeReportRow, error) {
+	return r.exchangeDS.SelectReport(ctx, from, to, granularity)
+}
+
+// SumQuantityByPricingRule はユーザーが価格ルールの割引価格で交換した数量の合計を取得
+func (r *ProductExchangeRepositoryImpl) SumQuantityByPricingRule(ctx context.Context, userID, ruleID uuid.UUID) (int, error) {
+	return r.exchangeDS.SumQuantityByPricingRule(ctx, userID, ruleID)
+}
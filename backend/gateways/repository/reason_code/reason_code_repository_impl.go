@@ -0,0 +1,38 @@
+package reason_code
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+)
+
+// ReasonCodeRepositoryImpl は理由コードリポジトリの実装
+type ReasonCodeRepositoryImpl struct {
+	ds *dspostgresimpl.ReasonCodeDataSource
+}
+
+// NewReasonCodeRepository は新しいReasonCodeRepositoryを作成
+func NewReasonCodeRepository(ds *dspostgresimpl.ReasonCodeDataSource) *ReasonCodeRepositoryImpl {
+	return &ReasonCodeRepositoryImpl{ds: ds}
+}
+
+// Create は理由コードを作成
+func (r *ReasonCodeRepositoryImpl) Create(ctx context.Context, reasonCode *entities.ReasonCode) error {
+	return r.ds.Insert(ctx, reasonCode)
+}
+
+// ReadByCode はコードで理由コードを取得
+func (r *ReasonCodeRepositoryImpl) ReadByCode(ctx context.Context, code string) (*entities.ReasonCode, error) {
+	return r.ds.SelectByCode(ctx, code)
+}
+
+// Update は理由コードを更新
+func (r *ReasonCodeRepositoryImpl) Update(ctx context.Context, reasonCode *entities.ReasonCode) error {
+	return r.ds.Update(ctx, reasonCode)
+}
+
+// ReadList は理由コードをコード順に取得
+func (r *ReasonCodeRepositoryImpl) ReadList(ctx context.Context, activeOnly bool) ([]*entities.ReasonCode, error) {
+	return r.ds.SelectList(ctx, activeOnly)
+}
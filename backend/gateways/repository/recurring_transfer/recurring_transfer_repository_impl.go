@@ -0,0 +1,50 @@
+package recurring_transfer
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// RecurringTransferRepositoryImpl は定期送金リポジトリの実装
+type RecurringTransferRepositoryImpl struct {
+	ds *dspostgresimpl.RecurringTransferDataSource
+}
+
+// NewRecurringTransferRepository は新しいRecurringTransferRepositoryを作成
+func NewRecurringTransferRepository(ds *dspostgresimpl.RecurringTransferDataSource) *RecurringTransferRepositoryImpl {
+	return &RecurringTransferRepositoryImpl{ds: ds}
+}
+
+// Create は定期送金を作成
+func (r *RecurringTransferRepositoryImpl) Create(ctx context.Context, transfer *entities.RecurringTransfer) error {
+	return r.ds.Insert(ctx, transfer)
+}
+
+// Read はIDで定期送金を取得
+func (r *RecurringTransferRepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.RecurringTransfer, error) {
+	return r.ds.SelectByID(ctx, id)
+}
+
+// Update は定期送金の状態と実行履歴を更新
+func (r *RecurringTransferRepositoryImpl) Update(ctx context.Context, transfer *entities.RecurringTransfer) error {
+	return r.ds.Update(ctx, transfer)
+}
+
+// ReadListByUser は送信者または受取人として関わる定期送金を新しい順に取得
+func (r *RecurringTransferRepositoryImpl) ReadListByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.RecurringTransfer, error) {
+	return r.ds.SelectByUser(ctx, userID, offset, limit)
+}
+
+// CountByUser は送信者または受取人として関わる定期送金の件数を取得
+func (r *RecurringTransferRepositoryImpl) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return r.ds.CountByUser(ctx, userID)
+}
+
+// ReadDue は実行日時を過ぎた有効な定期送金を古い順に取得
+func (r *RecurringTransferRepositoryImpl) ReadDue(ctx context.Context, now time.Time, limit int) ([]*entities.RecurringTransfer, error) {
+	return r.ds.SelectDue(ctx, now, limit)
+}
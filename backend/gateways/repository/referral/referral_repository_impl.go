@@ -0,0 +1,60 @@
+package referral
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// ReferralRepositoryImpl は紹介リポジトリの実装
+type ReferralRepositoryImpl struct {
+	ds *dspostgresimpl.ReferralDataSource
+}
+
+// NewReferralRepository は新しいReferralRepositoryを作成
+func NewReferralRepository(ds *dspostgresimpl.ReferralDataSource) *ReferralRepositoryImpl {
+	return &ReferralRepositoryImpl{ds: ds}
+}
+
+// ReadCodeByUser はユーザーの紹介コードを取得
+func (r *ReferralRepositoryImpl) ReadCodeByUser(ctx context.Context, userID uuid.UUID) (*entities.ReferralCode, error) {
+	return r.ds.SelectCodeByUser(ctx, userID)
+}
+
+// ReadCodeByCode は紹介コードから持ち主を取得
+func (r *ReferralRepositoryImpl) ReadCodeByCode(ctx context.Context, code string) (*entities.ReferralCode, error) {
+	return r.ds.SelectCodeByCode(ctx, code)
+}
+
+// CreateCode は紹介コードを保存
+func (r *ReferralRepositoryImpl) CreateCode(ctx context.Context, code *entities.ReferralCode) error {
+	return r.ds.InsertCode(ctx, code)
+}
+
+// Create は紹介を記録
+func (r *ReferralRepositoryImpl) Create(ctx context.Context, referral *entities.Referral) error {
+	return r.ds.Insert(ctx, referral)
+}
+
+// ReadPendingByReferee は紹介された側の初回チェックイン待ちの紹介を取得
+func (r *ReferralRepositoryImpl) ReadPendingByReferee(ctx context.Context, refereeID uuid.UUID) (*entities.Referral, error) {
+	return r.ds.SelectPendingByReferee(ctx, refereeID)
+}
+
+// CountByIPSince は同じIPアドレスから登録された紹介の数を取得
+func (r *ReferralRepositoryImpl) CountByIPSince(ctx context.Context, ipAddress string, since time.Time) (int64, error) {
+	return r.ds.CountByIPSince(ctx, ipAddress, since)
+}
+
+// MarkRewarded は紹介を特典付与済みにする
+func (r *ReferralRepositoryImpl) MarkRewarded(ctx context.Context, id uuid.UUID, referrerReward, refereeReward int64, at time.Time) (bool, error) {
+	return r.ds.UpdateRewarded(ctx, id, referrerReward, refereeReward, at)
+}
+
+// ReadReport は期間内の紹介の実績を集計
+func (r *ReferralRepositoryImpl) ReadReport(ctx context.Context, from, to time.Time, limit int) (*entities.ReferralReport, error) {
+	return r.ds.SelectReport(ctx, from, to, limit)
+}
@@ -0,0 +1,39 @@
+package scheduled_job
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+)
+
+// ScheduledJobRepositoryImpl は定期実行ジョブリポジトリの実装
+type ScheduledJobRepositoryImpl struct {
+	ds *dspostgresimpl.ScheduledJobDataSource
+}
+
+// NewScheduledJobRepository は新しいScheduledJobRepositoryを作成
+func NewScheduledJobRepository(ds *dspostgresimpl.ScheduledJobDataSource) *ScheduledJobRepositoryImpl {
+	return &ScheduledJobRepositoryImpl{ds: ds}
+}
+
+// SyncSchedule はジョブの行を作成し、cron式の変更を反映
+func (r *ScheduledJobRepositoryImpl) SyncSchedule(ctx context.Context, name entities.ScheduledJobName, schedule string, nextRunAt *time.Time) error {
+	return r.ds.UpsertSchedule(ctx, name, schedule, nextRunAt)
+}
+
+// TryLock はジョブのロックを取る
+func (r *ScheduledJobRepositoryImpl) TryLock(ctx context.Context, name entities.ScheduledJobName, owner string, now, lockedUntil, nextRunAt time.Time) (bool, error) {
+	return r.ds.UpdateLock(ctx, name, owner, now, lockedUntil, nextRunAt)
+}
+
+// Finish は実行結果を記録してロックを解放
+func (r *ScheduledJobRepositoryImpl) Finish(ctx context.Context, run *entities.ScheduledJobRun) error {
+	return r.ds.UpdateFinished(ctx, run)
+}
+
+// ReadList は全ジョブの状況を取得
+func (r *ScheduledJobRepositoryImpl) ReadList(ctx context.Context) ([]*entities.ScheduledJob, error) {
+	return r.ds.SelectList(ctx)
+}
@@ -37,12 +37,34 @@ func (r *RepositoryImpl) ReadByToken(ctx context.Context, token string) (*entiti
 	return r.sessionDS.SelectByToken(ctx, token)
 }
 
+// Read はIDでセッションを検索
+func (r *RepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.Session, error) {
+	return r.sessionDS.Select(ctx, id)
+}
+
+// ReadListByUserID はユーザーの有効なセッション一覧を取得
+func (r *RepositoryImpl) ReadListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Session, error) {
+	return r.sessionDS.SelectListByUserID(ctx, userID)
+}
+
 // Update はセッションを更新
 func (r *RepositoryImpl) Update(ctx context.Context, session *entities.Session) error {
 	r.logger.Debug("Updating session", entities.NewField("session_id", session.ID))
 	return r.sessionDS.Update(ctx, session)
 }
 
+// RotateCSRFToken はCSRFトークンを入れ替えたセッションを保存
+func (r *RepositoryImpl) RotateCSRFToken(ctx context.Context, session *entities.Session, previousToken string) (bool, error) {
+	r.logger.Debug("Rotating CSRF token", entities.NewField("session_id", session.ID))
+	return r.sessionDS.UpdateCSRFToken(ctx, session, previousToken)
+}
+
+// UpdateFingerprint はセッションを固定する端末・接続元の指紋を更新
+func (r *RepositoryImpl) UpdateFingerprint(ctx context.Context, id uuid.UUID, fingerprint string) error {
+	r.logger.Debug("Binding session", entities.NewField("session_id", id))
+	return r.sessionDS.UpdateFingerprint(ctx, id, fingerprint)
+}
+
 // Delete はセッションを削除
 func (r *RepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	r.logger.Debug("Deleting session", entities.NewField("session_id", id))
@@ -55,8 +77,40 @@ func (r *RepositoryImpl) DeleteByUserID(ctx context.Context, userID uuid.UUID) e
 	return r.sessionDS.DeleteByUserID(ctx, userID)
 }
 
+// DeleteByUserIDExcept は指定セッション以外のユーザーの全セッションを削除
+func (r *RepositoryImpl) DeleteByUserIDExcept(ctx context.Context, userID, exceptID uuid.UUID) (int64, error) {
+	r.logger.Debug("Deleting other user sessions",
+		entities.NewField("user_id", userID),
+		entities.NewField("except_session_id", exceptID))
+	return r.sessionDS.DeleteByUserIDExcept(ctx, userID, exceptID)
+}
+
+// DeleteAllExcept は指定セッション以外の全ユーザーのセッションを削除
+func (r *RepositoryImpl) DeleteAllExcept(ctx context.Context, exceptID uuid.UUID) (int64, error) {
+	r.logger.Debug("Deleting all sessions", entities.NewField("except_session_id", exceptID))
+	return r.sessionDS.DeleteAllExcept(ctx, exceptID)
+}
+
 // DeleteExpired は期限切れセッションを削除
 func (r *RepositoryImpl) DeleteExpired(ctx context.Context) error {
 	r.logger.Debug("Deleting expired sessions")
 	return r.sessionDS.DeleteExpired(ctx)
 }
+
+// CreateBindingViolation はセッションの固定の違反を記録
+func (r *RepositoryImpl) CreateBindingViolation(ctx context.Context, violation *entities.SessionBindingViolation) error {
+	r.logger.Debug("Recording session binding violation",
+		entities.NewField("session_id", violation.SessionID),
+		entities.NewField("change", violation.Change))
+	return r.sessionDS.InsertBindingViolation(ctx, violation)
+}
+
+// ReadBindingViolationsByUserID はユーザーのセッションの固定の違反を新しい順に取得
+func (r *RepositoryImpl) ReadBindingViolationsByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.SessionBindingViolation, error) {
+	return r.sessionDS.SelectBindingViolationsByUserID(ctx, userID, offset, limit)
+}
+
+// CountBindingViolationsByUserID はユーザーのセッションの固定の違反の件数を取得
+func (r *RepositoryImpl) CountBindingViolationsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return r.sessionDS.CountBindingViolationsByUserID(ctx, userID)
+}
@@ -0,0 +1,54 @@
+package shipping_address
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// ShippingAddressRepositoryImpl はお届け先のリポジトリの実装
+type ShippingAddressRepositoryImpl struct {
+	ds *dspostgresimpl.ShippingAddressDataSource
+}
+
+// NewShippingAddressRepository は新しいShippingAddressRepositoryを作成
+func NewShippingAddressRepository(ds *dspostgresimpl.ShippingAddressDataSource) *ShippingAddressRepositoryImpl {
+	return &ShippingAddressRepositoryImpl{ds: ds}
+}
+
+// Create はお届け先を作成
+func (r *ShippingAddressRepositoryImpl) Create(ctx context.Context, address *entities.ShippingAddress) error {
+	return r.ds.Insert(ctx, address)
+}
+
+// Read はIDでお届け先を取得
+func (r *ShippingAddressRepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.ShippingAddress, error) {
+	return r.ds.Select(ctx, id)
+}
+
+// ReadListByUserID はユーザーのお届け先を既定のもの、登録順の順に取得
+func (r *ShippingAddressRepositoryImpl) ReadListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.ShippingAddress, error) {
+	return r.ds.SelectListByUserID(ctx, userID)
+}
+
+// ReadDefault はユーザーの既定のお届け先を取得
+func (r *ShippingAddressRepositoryImpl) ReadDefault(ctx context.Context, userID uuid.UUID) (*entities.ShippingAddress, error) {
+	return r.ds.SelectDefault(ctx, userID)
+}
+
+// Update はお届け先を更新
+func (r *ShippingAddressRepositoryImpl) Update(ctx context.Context, address *entities.ShippingAddress) error {
+	return r.ds.Update(ctx, address)
+}
+
+// SetDefault はユーザーの既定のお届け先をidに切り替える
+func (r *ShippingAddressRepositoryImpl) SetDefault(ctx context.Context, userID, id uuid.UUID) error {
+	return r.ds.UpdateDefault(ctx, userID, id)
+}
+
+// Delete はお届け先を削除
+func (r *ShippingAddressRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.ds.Delete(ctx, id)
+}
@@ -0,0 +1,44 @@
+package split_request
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// SplitRequestRepositoryImpl は割り勘リポジトリの実装
+type SplitRequestRepositoryImpl struct {
+	ds *dspostgresimpl.SplitRequestDataSource
+}
+
+// NewSplitRequestRepository は新しいSplitRequestRepositoryを作成
+func NewSplitRequestRepository(ds *dspostgresimpl.SplitRequestDataSource) *SplitRequestRepositoryImpl {
+	return &SplitRequestRepositoryImpl{ds: ds}
+}
+
+// Create は割り勘を作成
+func (r *SplitRequestRepositoryImpl) Create(ctx context.Context, split *entities.SplitRequest) error {
+	return r.ds.Insert(ctx, split)
+}
+
+// Read はIDで割り勘を取得
+func (r *SplitRequestRepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.SplitRequest, error) {
+	return r.ds.Select(ctx, id)
+}
+
+// ReadByIdempotencyKey は冪等性キーで割り勘を取得
+func (r *SplitRequestRepositoryImpl) ReadByIdempotencyKey(ctx context.Context, key string) (*entities.SplitRequest, error) {
+	return r.ds.SelectByIdempotencyKey(ctx, key)
+}
+
+// ReadListByRequester は作成者の割り勘を新しい順に取得
+func (r *SplitRequestRepositoryImpl) ReadListByRequester(ctx context.Context, requesterID uuid.UUID, offset, limit int) ([]*entities.SplitRequest, error) {
+	return r.ds.SelectListByRequester(ctx, requesterID, offset, limit)
+}
+
+// Update は割り勘のキャンセル日時を更新
+func (r *SplitRequestRepositoryImpl) Update(ctx context.Context, split *entities.SplitRequest) error {
+	return r.ds.Update(ctx, split)
+}
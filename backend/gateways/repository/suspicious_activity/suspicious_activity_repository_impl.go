@@ -0,0 +1,54 @@
+package suspicious_activity
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// SuspiciousActivityRepositoryImpl は不審な送金の記録リポジトリの実装
+type SuspiciousActivityRepositoryImpl struct {
+	ds *dspostgresimpl.SuspiciousActivityDataSource
+}
+
+// NewSuspiciousActivityRepository は新しいSuspiciousActivityRepositoryを作成
+func NewSuspiciousActivityRepository(ds *dspostgresimpl.SuspiciousActivityDataSource) *SuspiciousActivityRepositoryImpl {
+	return &SuspiciousActivityRepositoryImpl{ds: ds}
+}
+
+// Create は不審な送金の記録を作成
+func (r *SuspiciousActivityRepositoryImpl) Create(ctx context.Context, activity *entities.SuspiciousActivity) error {
+	return r.ds.Insert(ctx, activity)
+}
+
+// Read はIDで記録を取得
+func (r *SuspiciousActivityRepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.SuspiciousActivity, error) {
+	return r.ds.Select(ctx, id)
+}
+
+// ReadList は記録を新しい順に取得
+func (r *SuspiciousActivityRepositoryImpl) ReadList(ctx context.Context, status entities.SuspiciousActivityStatus, offset, limit int) ([]*entities.SuspiciousActivity, error) {
+	return r.ds.SelectList(ctx, status, offset, limit)
+}
+
+// Count は記録の件数を取得
+func (r *SuspiciousActivityRepositoryImpl) Count(ctx context.Context, status entities.SuspiciousActivityStatus) (int64, error) {
+	return r.ds.Count(ctx, status)
+}
+
+// UpdateStatus は状態がfromのときだけ状態と確認・実行の結果を更新
+func (r *SuspiciousActivityRepositoryImpl) UpdateStatus(ctx context.Context, activity *entities.SuspiciousActivity, from entities.SuspiciousActivityStatus) (bool, error) {
+	return r.ds.UpdateStatus(ctx, activity, from)
+}
+
+// ReadTransferVelocity は送信者の直近の完了済み取引を集計
+func (r *SuspiciousActivityRepositoryImpl) ReadTransferVelocity(ctx context.Context, query *entities.TransferVelocityQuery) (*entities.TransferVelocity, error) {
+	return r.ds.SelectTransferVelocity(ctx, query)
+}
+
+// ReadAlertRecipients は検出を通知する管理者のIDを取得
+func (r *SuspiciousActivityRepositoryImpl) ReadAlertRecipients(ctx context.Context) ([]uuid.UUID, error) {
+	return r.ds.SelectAlertRecipients(ctx)
+}
@@ -0,0 +1,49 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// TenantRepositoryImpl はテナントリポジトリの実装
+type TenantRepositoryImpl struct {
+	ds *dspostgresimpl.TenantDataSource
+}
+
+// NewTenantRepository は新しいTenantRepositoryを作成
+func NewTenantRepository(ds *dspostgresimpl.TenantDataSource) *TenantRepositoryImpl {
+	return &TenantRepositoryImpl{ds: ds}
+}
+
+// Create はテナントを作成
+func (r *TenantRepositoryImpl) Create(ctx context.Context, tenant *entities.Tenant) error {
+	return r.ds.Insert(ctx, tenant)
+}
+
+// Read はIDでテナントを取得
+func (r *TenantRepositoryImpl) Read(ctx context.Context, id uuid.UUID) (*entities.Tenant, error) {
+	return r.ds.Select(ctx, id)
+}
+
+// ReadBySlug はスラッグでテナントを取得
+func (r *TenantRepositoryImpl) ReadBySlug(ctx context.Context, slug string) (*entities.Tenant, error) {
+	return r.ds.SelectBySlug(ctx, slug)
+}
+
+// ReadList はテナントを作成日時の古い順に取得
+func (r *TenantRepositoryImpl) ReadList(ctx context.Context, offset, limit int) ([]*entities.Tenant, error) {
+	return r.ds.SelectList(ctx, offset, limit)
+}
+
+// Count はテナントの件数を取得
+func (r *TenantRepositoryImpl) Count(ctx context.Context) (int64, error) {
+	return r.ds.Count(ctx)
+}
+
+// Update はテナント名と有効・無効を更新
+func (r *TenantRepositoryImpl) Update(ctx context.Context, tenant *entities.Tenant) error {
+	return r.ds.Update(ctx, tenant)
+}
@@ -2,6 +2,7 @@ package transaction
 
 import (
 	"context"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/gateways/repository/datasource/dsmysql"
@@ -53,8 +54,8 @@ func (r *RepositoryImpl) ReadListAll(ctx context.Context, offset, limit int) ([]
 }
 
 // ReadListAllWithFilter はフィルタ・ソート付きで全トランザクション一覧を取得
-func (r *RepositoryImpl) ReadListAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, sortBy, sortOrder string, offset, limit int) ([]*entities.Transaction, error) {
-	return r.transactionDS.SelectListAllWithFilter(ctx, transactionType, dateFrom, dateTo, sortBy, sortOrder, offset, limit)
+func (r *RepositoryImpl) ReadListAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode, sortBy, sortOrder string, offset, limit int) ([]*entities.Transaction, error) {
+	return r.transactionDS.SelectListAllWithFilter(ctx, transactionType, dateFrom, dateTo, reasonCode, sortBy, sortOrder, offset, limit)
 }
 
 // CountAll は全トランザクション総数を取得
@@ -63,8 +64,8 @@ func (r *RepositoryImpl) CountAll(ctx context.Context) (int64, error) {
 }
 
 // CountAllWithFilter はフィルタ付きで全トランザクション総数を取得
-func (r *RepositoryImpl) CountAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo string) (int64, error) {
-	return r.transactionDS.CountAllWithFilter(ctx, transactionType, dateFrom, dateTo)
+func (r *RepositoryImpl) CountAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode string, departmentIDs []uuid.UUID) (int64, error) {
+	return r.transactionDS.CountAllWithFilter(ctx, transactionType, dateFrom, dateTo, reasonCode, departmentIDs)
 }
 
 // Update はトランザクションを更新
@@ -74,18 +75,34 @@ func (r *RepositoryImpl) Update(ctx context.Context, transaction *entities.Trans
 }
 
 // CountByUserID はユーザーのトランザクション総数を取得
-func (r *RepositoryImpl) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
-	return r.transactionDS.CountByUserID(ctx, userID)
+func (r *RepositoryImpl) CountByUserID(ctx context.Context, userID uuid.UUID, reasonCode string) (int64, error) {
+	return r.transactionDS.CountByUserID(ctx, userID, reasonCode)
 }
 
 // ReadListByUserIDWithUsers はユーザーに関連するトランザクション一覧をユーザー情報付きで取得（JOIN）
-func (r *RepositoryImpl) ReadListByUserIDWithUsers(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.TransactionWithUsers, error) {
-	return r.transactionDS.SelectListByUserIDWithUsers(ctx, userID, offset, limit)
+func (r *RepositoryImpl) ReadListByUserIDWithUsers(ctx context.Context, userID uuid.UUID, reasonCode string, offset, limit int) ([]*entities.TransactionWithUsers, error) {
+	return r.transactionDS.SelectListByUserIDWithUsers(ctx, userID, reasonCode, offset, limit)
 }
 
 // ReadListAllWithFilterAndUsers はフィルタ・ソート付きで全トランザクション一覧をユーザー情報付きで取得（JOIN）
-func (r *RepositoryImpl) ReadListAllWithFilterAndUsers(ctx context.Context, transactionType, dateFrom, dateTo, sortBy, sortOrder string, offset, limit int) ([]*entities.TransactionWithUsers, error) {
-	return r.transactionDS.SelectListAllWithFilterAndUsers(ctx, transactionType, dateFrom, dateTo, sortBy, sortOrder, offset, limit)
+func (r *RepositoryImpl) ReadListAllWithFilterAndUsers(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode string, departmentIDs []uuid.UUID, sortBy, sortOrder string, offset, limit int) ([]*entities.TransactionWithUsers, error) {
+	return r.transactionDS.SelectListAllWithFilterAndUsers(ctx, transactionType, dateFrom, dateTo, reasonCode, departmentIDs, sortBy, sortOrder, offset, limit)
+}
+
+// AnonymizeUserReferences は退会するユーザーが相手側の取引履歴に残らないよう印を付ける
+func (r *RepositoryImpl) AnonymizeUserReferences(ctx context.Context, userID uuid.UUID, scrubMemos bool) error {
+	r.logger.Debug("Anonymizing transaction references", entities.NewField("user_id", userID))
+	return r.transactionDS.UpdateAnonymizeUser(ctx, userID, scrubMemos)
+}
+
+// SumExchangedWith はユーザーと各相手の間でやり取りした完了済みの取引の合計を取得
+func (r *RepositoryImpl) SumExchangedWith(ctx context.Context, userID uuid.UUID, counterpartIDs []uuid.UUID) ([]*entities.PointsExchanged, error) {
+	return r.transactionDS.SumExchangedWith(ctx, userID, counterpartIDs)
+}
+
+// ReadTransferAmountUsage はsince以降にユーザーが各送金先へ送った完了済みの送金を、送金先・金額ごとに集計
+func (r *RepositoryImpl) ReadTransferAmountUsage(ctx context.Context, fromUserID uuid.UUID, toUserIDs []uuid.UUID, since time.Time) ([]*entities.TransferAmountUsage, error) {
+	return r.transactionDS.SelectTransferAmountUsage(ctx, fromUserID, toUserIDs, since)
 }
 
 // IdempotencyRepositoryImpl はIdempotencyKeyRepositoryの実装
@@ -0,0 +1,39 @@
+package transaction_archive
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+)
+
+// TransactionArchiveRepositoryImpl は取引の保管のリポジトリの実装
+type TransactionArchiveRepositoryImpl struct {
+	ds *dspostgresimpl.TransactionArchiveDataSource
+}
+
+// NewTransactionArchiveRepository は新しいTransactionArchiveRepositoryを作成
+func NewTransactionArchiveRepository(ds *dspostgresimpl.TransactionArchiveDataSource) *TransactionArchiveRepositoryImpl {
+	return &TransactionArchiveRepositoryImpl{ds: ds}
+}
+
+// MoveBefore はcutoffより前の確定済みの取引をlimit件、保管用のテーブルへ移す
+func (r *TransactionArchiveRepositoryImpl) MoveBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	return r.ds.MoveBefore(ctx, cutoff, limit)
+}
+
+// ReadList は保管済みの取引を作成日時の新しい順に取得
+func (r *TransactionArchiveRepositoryImpl) ReadList(ctx context.Context, filter *entities.ArchivedTransactionFilter, offset, limit int) ([]*entities.ArchivedTransaction, error) {
+	return r.ds.SelectList(ctx, filter, offset, limit)
+}
+
+// Count は検索条件に一致する保管済みの取引の件数を取得
+func (r *TransactionArchiveRepositoryImpl) Count(ctx context.Context, filter *entities.ArchivedTransactionFilter) (int64, error) {
+	return r.ds.Count(ctx, filter)
+}
+
+// ReadSummaries は保管済みの取引の月ごとの集計を取得
+func (r *TransactionArchiveRepositoryImpl) ReadSummaries(ctx context.Context, fromMonth, toMonth string) ([]*entities.TransactionArchiveSummary, error) {
+	return r.ds.SelectSummaries(ctx, fromMonth, toMonth)
+}
@@ -0,0 +1,45 @@
+package transfer_attachment
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// TransferAttachmentRepositoryImpl は送金・送金リクエストの添付画像のリポジトリの実装
+type TransferAttachmentRepositoryImpl struct {
+	ds *dspostgresimpl.TransferAttachmentDataSource
+}
+
+// NewTransferAttachmentRepository は新しいTransferAttachmentRepositoryを作成
+func NewTransferAttachmentRepository(ds *dspostgresimpl.TransferAttachmentDataSource) *TransferAttachmentRepositoryImpl {
+	return &TransferAttachmentRepositoryImpl{ds: ds}
+}
+
+// Create は添付を作成
+func (r *TransferAttachmentRepositoryImpl) Create(ctx context.Context, attachment *entities.TransferAttachment) error {
+	return r.ds.Insert(ctx, attachment)
+}
+
+// Delete は添付を削除
+func (r *TransferAttachmentRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.ds.Delete(ctx, id)
+}
+
+// ReadByTransactionID は送金の添付を取得（送金リクエストに添付したものを含む）
+func (r *TransferAttachmentRepositoryImpl) ReadByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entities.TransferAttachment, error) {
+	return r.ds.SelectByTransactionID(ctx, transactionID)
+}
+
+// ReadByTransferRequestID は送金リクエストの添付を取得
+func (r *TransferAttachmentRepositoryImpl) ReadByTransferRequestID(ctx context.Context, requestID uuid.UUID) (*entities.TransferAttachment, error) {
+	return r.ds.SelectByTransferRequestID(ctx, requestID)
+}
+
+// ReadListBefore は保持期間を過ぎた添付を古い順に取得
+func (r *TransferAttachmentRepositoryImpl) ReadListBefore(ctx context.Context, cutoff time.Time, limit int) ([]*entities.TransferAttachment, error) {
+	return r.ds.SelectListBefore(ctx, cutoff, limit)
+}
@@ -68,6 +68,21 @@ func (r *RepositoryImpl) CountPendingByToUser(ctx context.Context, toUserID uuid
 	return r.transferRequestDS.CountPendingByToUser(ctx, toUserID)
 }
 
+// CountPendingByFromUser は送信者が送った有効期限内の承認待ちリクエスト数を取得
+func (r *RepositoryImpl) CountPendingByFromUser(ctx context.Context, fromUserID uuid.UUID) (int64, error) {
+	return r.transferRequestDS.CountPendingByFromUser(ctx, fromUserID)
+}
+
+// CountPendingByFromUserTo は送信者が受取人に送った有効期限内の承認待ちリクエスト数を取得
+func (r *RepositoryImpl) CountPendingByFromUserTo(ctx context.Context, fromUserID, toUserID uuid.UUID) (int64, error) {
+	return r.transferRequestDS.CountPendingByFromUserTo(ctx, fromUserID, toUserID)
+}
+
+// ReadBySplitIDs は割り勘ごとの送金リクエストを作成順に取得
+func (r *RepositoryImpl) ReadBySplitIDs(ctx context.Context, splitIDs []uuid.UUID) ([]*entities.TransferRequest, error) {
+	return r.transferRequestDS.SelectBySplitIDs(ctx, splitIDs)
+}
+
 // UpdateExpiredRequests は期限切れのリクエストを一括更新
 func (r *RepositoryImpl) UpdateExpiredRequests(ctx context.Context) (int64, error) {
 	r.logger.Debug("Updating expired transfer requests")
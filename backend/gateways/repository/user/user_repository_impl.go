@@ -77,20 +77,30 @@ func (r *RepositoryImpl) UpdateBalancesWithLock(ctx context.Context, updates []r
 			UserID:   update.UserID,
 			Amount:   update.Amount,
 			IsDeduct: update.IsDeduct,
+			Reserved: update.Reserved,
 		}
 	}
 
 	return r.userDS.UpdateBalancesWithLock(ctx, dsUpdates)
 }
 
+// UpdateReservedBalanceWithLock は確保中の額を増減（悲観的ロック）
+func (r *RepositoryImpl) UpdateReservedBalanceWithLock(ctx context.Context, userID uuid.UUID, amount int64, isRelease bool) error {
+	r.logger.Debug("Updating reserved balance with lock",
+		entities.NewField("user_id", userID),
+		entities.NewField("amount", amount),
+		entities.NewField("is_release", isRelease))
+	return r.userDS.UpdateReservedBalanceWithLock(ctx, userID, amount, isRelease)
+}
+
 // ReadList はユーザー一覧を取得
 func (r *RepositoryImpl) ReadList(ctx context.Context, offset, limit int) ([]*entities.User, error) {
 	return r.userDS.SelectList(ctx, offset, limit)
 }
 
 // ReadListWithSearch は検索・ソート付きでユーザー一覧を取得
-func (r *RepositoryImpl) ReadListWithSearch(ctx context.Context, search, sortBy, sortOrder string, offset, limit int) ([]*entities.User, error) {
-	return r.userDS.SelectListWithSearch(ctx, search, sortBy, sortOrder, offset, limit)
+func (r *RepositoryImpl) ReadListWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID, sortBy, sortOrder string, offset, limit int) ([]*entities.User, error) {
+	return r.userDS.SelectListWithSearch(ctx, search, departmentIDs, sortBy, sortOrder, offset, limit)
 }
 
 // Count はユーザー総数を取得
@@ -99,8 +109,8 @@ func (r *RepositoryImpl) Count(ctx context.Context) (int64, error) {
 }
 
 // CountWithSearch は検索条件付きでユーザー総数を取得
-func (r *RepositoryImpl) CountWithSearch(ctx context.Context, search string) (int64, error) {
-	return r.userDS.CountWithSearch(ctx, search)
+func (r *RepositoryImpl) CountWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID) (int64, error) {
+	return r.userDS.CountWithSearch(ctx, search, departmentIDs)
 }
 
 // Delete はユーザーを論理削除
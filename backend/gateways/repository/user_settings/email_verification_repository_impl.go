@@ -2,6 +2,7 @@ package user_settings
 
 import (
 	"context"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/gateways/repository/datasource/dsmysql"
@@ -34,6 +35,11 @@ func (r *EmailVerificationRepositoryImpl) ReadByToken(ctx context.Context, token
 	return r.emailVerificationDS.SelectByToken(ctx, token)
 }
 
+// ReadByOldEmailToken は旧アドレス確認用トークンで検索
+func (r *EmailVerificationRepositoryImpl) ReadByOldEmailToken(ctx context.Context, token string) (*entities.EmailVerificationToken, error) {
+	return r.emailVerificationDS.SelectByOldEmailToken(ctx, token)
+}
+
 // Update はトークン情報を更新
 func (r *EmailVerificationRepositoryImpl) Update(ctx context.Context, token *entities.EmailVerificationToken) error {
 	r.logger.Debug("Updating email verification token", entities.NewField("token_id", token.ID))
@@ -51,3 +57,8 @@ func (r *EmailVerificationRepositoryImpl) DeleteByUserID(ctx context.Context, us
 	r.logger.Debug("Deleting email verification tokens by user ID", entities.NewField("user_id", userID))
 	return r.emailVerificationDS.DeleteByUserID(ctx, userID)
 }
+
+// CountByUserIDSince はユーザーに指定日時以降に発行した種類ごとのトークン数を取得
+func (r *EmailVerificationRepositoryImpl) CountByUserIDSince(ctx context.Context, userID uuid.UUID, tokenType entities.TokenType, since time.Time) (int64, error) {
+	return r.emailVerificationDS.CountByUserIDSince(ctx, userID, tokenType, since)
+}
@@ -29,14 +29,18 @@ func (r *UserSettingsRepositoryImpl) UpdateProfile(ctx context.Context, user *en
 	// プロフィール更新では楽観的ロックを使わず、変更されたフィールドのみ更新
 	// これにより、画像アップロード後のバージョン競合を回避
 	return r.userDS.UpdatePartial(ctx, user.ID, map[string]interface{}{
-		"display_name":      user.DisplayName,
-		"email":             user.Email,
-		"first_name":        user.FirstName,
-		"last_name":         user.LastName,
-		"email_verified":    user.EmailVerified,
-		"email_verified_at": user.EmailVerifiedAt,
-		"avatar_url":        user.AvatarURL,
-		"avatar_type":       user.AvatarType,
+		"display_name":          user.DisplayName,
+		"email":                 user.Email,
+		"email_sha256":          entities.HashContactIdentifier(user.Email),
+		"first_name":            user.FirstName,
+		"last_name":             user.LastName,
+		"email_verified":        user.EmailVerified,
+		"email_verified_at":     user.EmailVerifiedAt,
+		"avatar_url":            user.AvatarURL,
+		"avatar_type":           user.AvatarType,
+		"discoverable":          user.Discoverable,
+		"timezone":              user.Timezone,
+		"accept_transfers_from": string(user.AcceptTransfersFrom),
 	})
 }
 
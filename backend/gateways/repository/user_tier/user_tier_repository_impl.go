@@ -0,0 +1,40 @@
+package user_tier
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// UserTierRepositoryImpl は会員ランクリポジトリの実装
+type UserTierRepositoryImpl struct {
+	ds *dspostgresimpl.UserTierDataSource
+}
+
+// NewUserTierRepository は新しいUserTierRepositoryを作成
+func NewUserTierRepository(ds *dspostgresimpl.UserTierDataSource) *UserTierRepositoryImpl {
+	return &UserTierRepositoryImpl{ds: ds}
+}
+
+// ReadActivities は有効な全ユーザーの利用状況を取得
+func (r *UserTierRepositoryImpl) ReadActivities(ctx context.Context, earnedSince, streakAsOf time.Time) ([]*entities.TierActivity, error) {
+	return r.ds.SelectActivities(ctx, earnedSince, streakAsOf)
+}
+
+// ReadActivity は1ユーザーの利用状況を取得
+func (r *UserTierRepositoryImpl) ReadActivity(ctx context.Context, userID uuid.UUID, earnedSince, streakAsOf time.Time) (*entities.TierActivity, error) {
+	return r.ds.SelectActivity(ctx, userID, earnedSince, streakAsOf)
+}
+
+// UpdateComputedTier は判定したランクを保存
+func (r *UserTierRepositoryImpl) UpdateComputedTier(ctx context.Context, userID uuid.UUID, tier entities.UserTier, at time.Time) (bool, error) {
+	return r.ds.UpdateComputedTier(ctx, userID, tier, at)
+}
+
+// SetOverride は管理者によるランクの固定を設定・解除
+func (r *UserTierRepositoryImpl) SetOverride(ctx context.Context, userID uuid.UUID, tier *entities.UserTier, at time.Time) error {
+	return r.ds.UpdateOverride(ctx, userID, tier, at)
+}
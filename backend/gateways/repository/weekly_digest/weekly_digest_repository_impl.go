@@ -0,0 +1,30 @@
+package weekly_digest
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+)
+
+// WeeklyDigestRepositoryImpl は週次のまとめメールのリポジトリの実装
+type WeeklyDigestRepositoryImpl struct {
+	ds *dspostgresimpl.WeeklyDigestDataSource
+}
+
+// NewWeeklyDigestRepository は新しいWeeklyDigestRepositoryを作成
+func NewWeeklyDigestRepository(ds *dspostgresimpl.WeeklyDigestDataSource) *WeeklyDigestRepositoryImpl {
+	return &WeeklyDigestRepositoryImpl{ds: ds}
+}
+
+// ReadRecipientIDs はafterより後の送信対象のユーザーIDを昇順にlimit件取得
+func (r *WeeklyDigestRepositoryImpl) ReadRecipientIDs(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	return r.ds.SelectRecipientIDs(ctx, after, limit)
+}
+
+// ReadActivity はユーザーごとの期間中の獲得・使用の合計を取得
+func (r *WeeklyDigestRepositoryImpl) ReadActivity(ctx context.Context, userIDs []uuid.UUID, from, to time.Time) ([]*entities.WeeklyDigestActivity, error) {
+	return r.ds.SelectActivity(ctx, userIDs, from, to)
+}
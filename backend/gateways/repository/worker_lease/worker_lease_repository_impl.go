@@ -0,0 +1,34 @@
+package worker_lease
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+)
+
+// WorkerLeaseRepositoryImpl はワーカーのリーダーのリポジトリの実装
+type WorkerLeaseRepositoryImpl struct {
+	ds *dspostgresimpl.WorkerLeaseDataSource
+}
+
+// NewWorkerLeaseRepository は新しいWorkerLeaseRepositoryを作成
+func NewWorkerLeaseRepository(ds *dspostgresimpl.WorkerLeaseDataSource) *WorkerLeaseRepositoryImpl {
+	return &WorkerLeaseRepositoryImpl{ds: ds}
+}
+
+// TryAcquire はリーダーの期限を延ばすか、期限切れのリーダーを引き継ぐ
+func (r *WorkerLeaseRepositoryImpl) TryAcquire(ctx context.Context, name, holder string, now, expiresAt time.Time) (bool, error) {
+	return r.ds.Upsert(ctx, name, holder, now, expiresAt)
+}
+
+// Release はholderがリーダーなら期限を切る
+func (r *WorkerLeaseRepositoryImpl) Release(ctx context.Context, name, holder string, now time.Time) error {
+	return r.ds.UpdateExpired(ctx, name, holder, now)
+}
+
+// ReadList は全ワーカーのリーダーを名前順に取得
+func (r *WorkerLeaseRepositoryImpl) ReadList(ctx context.Context) ([]*entities.WorkerLease, error) {
+	return r.ds.SelectList(ctx)
+}
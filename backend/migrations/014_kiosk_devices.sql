@@ -0,0 +1,45 @@
+-- 014_kiosk_devices.sql
+-- キオスク端末（NFC/据え置き端末）からのポイント付与
+
+-- キオスク端末: APIキーはハッシュのみ保存
+CREATE TABLE IF NOT EXISTS kiosk_devices (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    api_key_hash VARCHAR(64) NOT NULL UNIQUE,
+    bonus_amount BIGINT NOT NULL CHECK (bonus_amount > 0),
+    daily_limit INTEGER NOT NULL DEFAULT 0 CHECK (daily_limit >= 0), -- 0 = 無制限
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    last_seen_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- 社員証などのカードIDとユーザーの紐付け
+CREATE TABLE IF NOT EXISTS kiosk_cards (
+    card_id VARCHAR(100) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kiosk_cards_user_id ON kiosk_cards(user_id);
+
+-- キオスク付与履歴: 冪等性キーは端末側で生成（オフライン再送対応）
+CREATE TABLE IF NOT EXISTS kiosk_grants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    device_id UUID NOT NULL REFERENCES kiosk_devices(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    transaction_id UUID REFERENCES transactions(id),
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    idempotency_key VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (device_id, idempotency_key)
+);
+
+-- 端末ごとの日次上限チェック用
+CREATE INDEX IF NOT EXISTS idx_kiosk_grants_device_created
+    ON kiosk_grants(device_id, created_at DESC);
+
+-- 同一端末・同一ユーザーの重複付与チェック用
+CREATE INDEX IF NOT EXISTS idx_kiosk_grants_device_user_created
+    ON kiosk_grants(device_id, user_id, created_at DESC);
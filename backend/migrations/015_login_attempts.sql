@@ -0,0 +1,37 @@
+-- 015_login_attempts.sql
+-- ログイン試行の記録とアカウントロックアウト
+
+-- ログイン試行履歴（成功・失敗とも記録）
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE, -- 存在しないユーザー名の場合はNULL
+    username VARCHAR(255) NOT NULL,
+    ip_address VARCHAR(100) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    country VARCHAR(2) NOT NULL DEFAULT '',
+    success BOOLEAN NOT NULL,
+    failure_reason VARCHAR(50) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- IP単位の失敗回数チェック用
+CREATE INDEX IF NOT EXISTS idx_login_attempts_ip_created
+    ON login_attempts(ip_address, created_at DESC) WHERE success = FALSE;
+
+-- 新しい端末・国の判定用
+CREATE INDEX IF NOT EXISTS idx_login_attempts_user_success
+    ON login_attempts(user_id, created_at DESC) WHERE success = TRUE;
+
+-- アカウントロックアウト状態（ユーザーごとに1行）
+CREATE TABLE IF NOT EXISTS account_lockouts (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    lock_count INTEGER NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    unlock_token_hash VARCHAR(64),
+    unlock_token_expires_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_account_lockouts_unlock_token
+    ON account_lockouts(unlock_token_hash) WHERE unlock_token_hash IS NOT NULL;
@@ -0,0 +1,6 @@
+-- 016_session_last_active.sql
+-- セッション一覧（端末管理）用に最終アクティブ日時を記録
+
+ALTER TABLE sessions
+    ADD COLUMN IF NOT EXISTS last_active_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+
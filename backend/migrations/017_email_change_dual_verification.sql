@@ -0,0 +1,15 @@
+-- 017_email_change_dual_verification.sql
+-- メールアドレス変更時に新旧両方のアドレスで確認する
+
+ALTER TABLE email_verification_tokens
+    ADD COLUMN IF NOT EXISTS old_email VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS old_email_token VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS old_email_confirmed_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS applied_at TIMESTAMP WITH TIME ZONE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_email_verification_tokens_old_email_token
+    ON email_verification_tokens(old_email_token)
+    WHERE old_email_token IS NOT NULL;
+
+COMMENT ON COLUMN email_verification_tokens.old_email_token IS '旧アドレス確認用トークン（メール変更時のみ）';
+COMMENT ON COLUMN email_verification_tokens.applied_at IS 'メールアドレス変更の適用日時（新アドレス認証＋旧アドレス確認またはタイムアウト後）';
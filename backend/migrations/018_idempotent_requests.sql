@@ -0,0 +1,22 @@
+-- 018_idempotent_requests.sql
+-- Idempotency-Keyヘッダー付きリクエストのレスポンス保存（再送時に同じレスポンスを返す）
+
+CREATE TABLE IF NOT EXISTS idempotent_requests (
+    id UUID PRIMARY KEY,
+    scope VARCHAR(100) NOT NULL,          -- "user:<id>" / "kiosk:<id>"
+    key VARCHAR(255) NOT NULL,
+    route VARCHAR(255) NOT NULL,          -- "POST /api/v1/qrcodes/scan"
+    request_hash VARCHAR(64) NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0, -- 0 = 処理中
+    content_type VARCHAR(255) NOT NULL DEFAULT '',
+    response_body BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotent_requests_scope_key_route
+    ON idempotent_requests(scope, key, route);
+
+CREATE INDEX IF NOT EXISTS idx_idempotent_requests_expires_at
+    ON idempotent_requests(expires_at);
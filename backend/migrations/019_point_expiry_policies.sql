@@ -0,0 +1,38 @@
+-- 019_point_expiry_policies.sql
+-- 付与種別ごと・ユーザーごとの有効期間と、猶予期間内の失効取り消し
+
+-- 失効処理の記録: 失効時の残量を残しておき、猶予期間内なら管理者が取り消せるようにする
+ALTER TABLE point_batches ADD COLUMN IF NOT EXISTS expired_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE point_batches ADD COLUMN IF NOT EXISTS expired_amount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE point_batches ADD COLUMN IF NOT EXISTS restored_at TIMESTAMP WITH TIME ZONE;
+
+-- 取り消し時の補填バッチ用のsource_typeを追加
+ALTER TABLE point_batches DROP CONSTRAINT IF EXISTS point_batches_source_type_check;
+ALTER TABLE point_batches ADD CONSTRAINT point_batches_source_type_check
+    CHECK (source_type IN ('transfer', 'admin_grant', 'daily_bonus', 'system_grant', 'migration', 'expiry_restore'));
+
+-- 取り消し候補の検索用
+CREATE INDEX IF NOT EXISTS idx_point_batches_expired_at
+    ON point_batches(expired_at DESC)
+    WHERE expired_at IS NOT NULL AND restored_at IS NULL;
+
+-- 付与種別ごとの有効期間（行がない種別は既定の3ヶ月）
+CREATE TABLE IF NOT EXISTS point_expiry_policies (
+    source_type VARCHAR(50) PRIMARY KEY,
+    validity_days INTEGER NOT NULL CHECK (validity_days > 0),
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- ユーザー個別の有効期間（付与種別のポリシーより優先）
+CREATE TABLE IF NOT EXISTS user_point_expiry_overrides (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    validity_days INTEGER NOT NULL CHECK (validity_days > 0),
+    reason TEXT NOT NULL DEFAULT '',
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO system_settings (key, value, description)
+VALUES ('point_expiry_grace_days', '30', '失効したポイントを管理者が取り消せる日数')
+ON CONFLICT (key) DO NOTHING;
@@ -0,0 +1,28 @@
+-- 020_data_exports.sql
+-- 個人データのエクスポート依頼と、退会時の匿名化設定
+
+-- 退会してもワーカーが作成済みのファイルを削除できるよう、user_id は NULL にして行を残す
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'processing', 'ready', 'failed', 'expired')),
+    file_path TEXT NOT NULL DEFAULT '',
+    error_message TEXT NOT NULL DEFAULT '',
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user_requested
+    ON data_exports(user_id, requested_at DESC);
+
+-- ワーカーの処理待ち検索用
+CREATE INDEX IF NOT EXISTS idx_data_exports_pending
+    ON data_exports(requested_at)
+    WHERE status = 'pending';
+
+-- 退会時の相手側の取引履歴の扱い（anonymize: メモも消す / retain: メモは残す）
+INSERT INTO system_settings (key, value, description)
+VALUES ('account_erasure_mode', 'anonymize', '退会時に相手側の取引履歴に残る退会者のメモを消すか（anonymize / retain）')
+ON CONFLICT (key) DO NOTHING;
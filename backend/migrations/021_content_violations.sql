@@ -0,0 +1,22 @@
+-- 021_content_violations.sql
+-- モデレーションで拒否されたテキストの記録（管理者レビュー用）
+
+-- 入力内容は個人データのため、退会時は記録ごと削除する
+CREATE TABLE IF NOT EXISTS content_violations (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    field VARCHAR(50) NOT NULL,
+    content TEXT NOT NULL,
+    matched_terms TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_content_violations_created
+    ON content_violations(created_at DESC);
+
+-- 未レビュー一覧用
+CREATE INDEX IF NOT EXISTS idx_content_violations_unreviewed
+    ON content_violations(created_at DESC)
+    WHERE reviewed_at IS NULL;
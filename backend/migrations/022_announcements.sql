@@ -0,0 +1,32 @@
+-- 022_announcements.sql
+-- 管理者がアプリ内に配信するお知らせと、ユーザーごとの既読（非表示）記録
+
+CREATE TABLE IF NOT EXISTS announcements (
+    id UUID PRIMARY KEY,
+    title VARCHAR(100) NOT NULL,
+    body TEXT NOT NULL,
+    severity VARCHAR(20) NOT NULL DEFAULT 'info'
+        CHECK (severity IN ('info', 'warning', 'critical')),
+    audience VARCHAR(20) NOT NULL DEFAULT 'all'
+        CHECK (audience IN ('all', 'admins', 'role')),
+    target_role VARCHAR(20) NOT NULL DEFAULT '',
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ends_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- 表示中のお知らせの検索用
+CREATE INDEX IF NOT EXISTS idx_announcements_period
+    ON announcements(starts_at, ends_at);
+
+CREATE TABLE IF NOT EXISTS announcement_dismissals (
+    announcement_id UUID NOT NULL REFERENCES announcements(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    dismissed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (announcement_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_announcement_dismissals_user
+    ON announcement_dismissals(user_id);
@@ -0,0 +1,18 @@
+-- 023_transfer_request_counter_offers.sql
+-- 送金リクエストのカウンターオファー（受取人による金額変更と送信者の確認）
+
+ALTER TABLE transfer_requests ADD COLUMN IF NOT EXISTS counter_amount BIGINT CHECK (counter_amount > 0); -- 受取人が提示した変更後の金額
+ALTER TABLE transfer_requests ADD COLUMN IF NOT EXISTS countered_at TIMESTAMP WITH TIME ZONE;            -- カウンターオファー日時
+ALTER TABLE transfer_requests ADD COLUMN IF NOT EXISTS last_acted_by UUID REFERENCES users(id) ON DELETE SET NULL; -- 最後に操作したユーザー
+
+-- 既存データは送信者が最後に操作したものとみなす
+UPDATE transfer_requests SET last_acted_by = from_user_id WHERE last_acted_by IS NULL;
+
+-- status に countered を追加
+ALTER TABLE transfer_requests DROP CONSTRAINT IF EXISTS transfer_requests_status_check;
+ALTER TABLE transfer_requests ADD CONSTRAINT transfer_requests_status_check
+    CHECK (status IN ('pending', 'countered', 'approved', 'rejected', 'cancelled', 'expired'));
+
+-- 送信者の確認待ち一覧・期限切れ更新用
+CREATE INDEX IF NOT EXISTS idx_transfer_requests_countered
+    ON transfer_requests(from_user_id, expires_at) WHERE status = 'countered';
@@ -0,0 +1,29 @@
+-- 024_recurring_transfers.sql
+-- ユーザー間の定期送金（毎週・毎月）。ワーカーが next_run_at を過ぎたものを送金する
+
+CREATE TABLE IF NOT EXISTS recurring_transfers (
+    id UUID PRIMARY KEY,
+    from_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- 送信者
+    to_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,   -- 受取人
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    schedule_interval VARCHAR(20) NOT NULL CHECK (schedule_interval IN ('weekly', 'monthly')),
+    message TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'cancelled', 'stopped')),
+    start_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    next_run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    run_count INTEGER NOT NULL DEFAULT 0,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    stop_reason TEXT NOT NULL DEFAULT '',
+    cancelled_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (from_user_id <> to_user_id)
+);
+
+-- ワーカーの実行対象検索用
+CREATE INDEX IF NOT EXISTS idx_recurring_transfers_due
+    ON recurring_transfers(next_run_at) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_recurring_transfers_from_user ON recurring_transfers(from_user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_recurring_transfers_to_user ON recurring_transfers(to_user_id, created_at DESC);
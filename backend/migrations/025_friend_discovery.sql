@@ -0,0 +1,21 @@
+-- 025_friend_discovery.sql
+-- 連絡先ハッシュによる友達検索（生の連絡先を送らずにクライアント側で SHA-256 したものを照合する）
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS discoverable BOOLEAN NOT NULL DEFAULT TRUE; -- 友達検索でヒットさせるか
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_sha256 VARCHAR(64);                  -- lower(trim(email)) の SHA-256（16進数）
+ALTER TABLE users ADD COLUMN IF NOT EXISTS username_sha256 VARCHAR(64);               -- lower(trim(username)) の SHA-256（16進数）
+
+-- 既存ユーザーのハッシュを計算（以降はアプリケーション側で更新する）
+UPDATE users
+SET email_sha256 = encode(sha256(convert_to(lower(btrim(email)), 'UTF8')), 'hex')
+WHERE email_sha256 IS NULL;
+
+UPDATE users
+SET username_sha256 = encode(sha256(convert_to(lower(btrim(username)), 'UTF8')), 'hex')
+WHERE username_sha256 IS NULL;
+
+-- 友達検索用（公開設定のユーザーのみ）
+CREATE INDEX IF NOT EXISTS idx_users_email_sha256
+    ON users(email_sha256) WHERE discoverable = TRUE;
+CREATE INDEX IF NOT EXISTS idx_users_username_sha256
+    ON users(username_sha256) WHERE discoverable = TRUE;
@@ -0,0 +1,31 @@
+-- 026_push_notifications.sql
+-- プッシュ通知の配信先端末と、ユーザーごとの通知設定
+
+CREATE TABLE IF NOT EXISTS device_tokens (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    platform VARCHAR(20) NOT NULL CHECK (platform IN ('ios', 'android')),
+    token TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- 同じ端末は1ユーザーにだけ紐づける（別ユーザーでログインし直したら付け替える）
+CREATE UNIQUE INDEX IF NOT EXISTS idx_device_tokens_token ON device_tokens(token);
+CREATE INDEX IF NOT EXISTS idx_device_tokens_user ON device_tokens(user_id, last_seen_at DESC);
+
+-- 行が無いユーザーはすべての通知を受け取る
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    push_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    transfer_requests BOOLEAN NOT NULL DEFAULT TRUE,
+    points_received BOOLEAN NOT NULL DEFAULT TRUE,
+    points_expiring BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- 有効期限が近いポイントの通知は1バッチにつき1度だけ送る
+ALTER TABLE point_batches ADD COLUMN IF NOT EXISTS expiry_reminded_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_point_batches_expiry_reminder
+    ON point_batches(expires_at) WHERE remaining_amount > 0 AND expiry_reminded_at IS NULL;
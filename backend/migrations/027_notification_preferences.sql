@@ -0,0 +1,12 @@
+-- 027_notification_preferences.sql
+-- 通知設定にメールのオン/オフ・ログイン通知・おやすみ時間を追加
+
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS email_enabled BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS new_login BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- おやすみ時間（0時からの分数。開始と終了が同じなら終日）
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS quiet_hours_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS quiet_hours_start SMALLINT NOT NULL DEFAULT 1320;
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS quiet_hours_end SMALLINT NOT NULL DEFAULT 420;
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS quiet_hours_timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Tokyo';
+
@@ -0,0 +1,14 @@
+-- デジタル商品の引換コードと交換実績レポート用のインデックス
+
+-- デジタル商品は交換時に引換コードを発行する
+ALTER TABLE products ADD COLUMN IF NOT EXISTS is_digital BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- 引換コード（物理商品はNULL）と引き換え日時
+ALTER TABLE product_exchanges ADD COLUMN IF NOT EXISTS redemption_code VARCHAR(20);
+ALTER TABLE product_exchanges ADD COLUMN IF NOT EXISTS redeemed_at TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_exchanges_redemption_code
+    ON product_exchanges(redemption_code) WHERE redemption_code IS NOT NULL;
+
+-- 期間ごとの集計用
+CREATE INDEX IF NOT EXISTS idx_product_exchanges_created_at ON product_exchanges(created_at);
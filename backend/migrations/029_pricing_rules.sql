@@ -0,0 +1,29 @@
+-- 価格ルール（期間限定の割引・会員価格・セール中の購入上限）
+
+CREATE TABLE IF NOT EXISTS pricing_rules (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    product_id UUID REFERENCES products(id) ON DELETE CASCADE,
+    category_code VARCHAR(50) NOT NULL DEFAULT '',
+    discount_type VARCHAR(20) NOT NULL CHECK (discount_type IN ('percentage', 'fixed')),
+    discount_value BIGINT NOT NULL CHECK (discount_value > 0),
+    target_role VARCHAR(20) NOT NULL DEFAULT '',
+    max_quantity_per_user INTEGER NOT NULL DEFAULT 0 CHECK (max_quantity_per_user >= 0),
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- 対象は商品かカテゴリのどちらか一方
+    CHECK ((product_id IS NULL) <> (category_code = ''))
+);
+
+CREATE INDEX IF NOT EXISTS idx_pricing_rules_product ON pricing_rules(product_id) WHERE product_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_pricing_rules_category ON pricing_rules(category_code) WHERE product_id IS NULL;
+
+-- 割引価格で交換した場合の価格ルール（セール中の購入上限の集計用）
+ALTER TABLE product_exchanges ADD COLUMN IF NOT EXISTS pricing_rule_id UUID REFERENCES pricing_rules(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_product_exchanges_pricing_rule
+    ON product_exchanges(user_id, pricing_rule_id) WHERE pricing_rule_id IS NOT NULL;
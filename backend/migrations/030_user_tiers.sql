@@ -0,0 +1,9 @@
+-- 会員ランク（bronze/silver/gold）と価格ルールのランク条件
+
+-- 夜間ワーカーが判定したランク。tier_overridden が TRUE の間は管理者が固定した値を保つ
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tier VARCHAR(20) NOT NULL DEFAULT 'bronze';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tier_overridden BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tier_updated_at TIMESTAMPTZ;
+
+-- 指定したランク以上の会員だけに適用する価格ルール（空なら全員）
+ALTER TABLE pricing_rules ADD COLUMN IF NOT EXISTS min_tier VARCHAR(20) NOT NULL DEFAULT '';
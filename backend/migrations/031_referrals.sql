@@ -0,0 +1,28 @@
+-- 紹介プログラム（ユーザーごとの紹介コードと、紹介コードを使った登録の記録）
+
+CREATE TABLE IF NOT EXISTS referral_codes (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    code VARCHAR(20) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- 紹介された側1人につき1件。紹介者の退会後はreferrer_idがNULLになる
+CREATE TABLE IF NOT EXISTS referrals (
+    id UUID PRIMARY KEY,
+    referrer_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    referee_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    code VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'rewarded', 'rejected')),
+    reject_reason VARCHAR(50) NOT NULL DEFAULT '',
+    ip_address VARCHAR(100) NOT NULL DEFAULT '',
+    referrer_reward BIGINT NOT NULL DEFAULT 0,
+    referee_reward BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    rewarded_at TIMESTAMPTZ
+);
+
+-- IPアドレスごとの登録数の確認用
+CREATE INDEX IF NOT EXISTS idx_referrals_ip_created ON referrals(ip_address, created_at DESC) WHERE ip_address <> '';
+-- 紹介者ごと・期間ごとの集計用
+CREATE INDEX IF NOT EXISTS idx_referrals_referrer ON referrals(referrer_id);
+CREATE INDEX IF NOT EXISTS idx_referrals_created_at ON referrals(created_at);
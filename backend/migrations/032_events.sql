@@ -0,0 +1,35 @@
+-- チェックインイベント（期間限定のQRコードを読み取ったユーザーに1人1回ポイントを付与）
+
+CREATE TABLE IF NOT EXISTS events (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    points BIGINT NOT NULL CHECK (points > 0),
+    capacity INTEGER NOT NULL DEFAULT 0 CHECK (capacity >= 0),
+    attendee_count INTEGER NOT NULL DEFAULT 0 CHECK (attendee_count >= 0),
+    code VARCHAR(64) NOT NULL UNIQUE,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (ends_at > starts_at),
+    -- 定員のあるイベントは参加人数が定員を超えない
+    CHECK (capacity = 0 OR attendee_count <= capacity)
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_starts_at ON events(starts_at DESC);
+
+CREATE TABLE IF NOT EXISTS event_attendances (
+    id UUID PRIMARY KEY,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    points BIGINT NOT NULL,
+    transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- 1ユーザー1回
+    UNIQUE (event_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_attendances_event ON event_attendances(event_id, created_at);
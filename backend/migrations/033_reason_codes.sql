@@ -0,0 +1,25 @@
+-- 管理者の付与・減算に付ける理由コードと、取引の構造化メモ（理由コード・プロジェクト/タグ）
+
+CREATE TABLE IF NOT EXISTS reason_codes (
+    code VARCHAR(32) PRIMARY KEY CHECK (code ~ '^[a-z0-9_]+$'),
+    label VARCHAR(50) NOT NULL,
+    description VARCHAR(200) NOT NULL DEFAULT '',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- 付与・減算で理由コードが必須になるため、よく使う理由を初期登録しておく
+INSERT INTO reason_codes (code, label, description) VALUES
+    ('reward', '表彰・インセンティブ', '成果や貢献に対する付与'),
+    ('event', 'イベント参加', 'イベント・勉強会への参加に対する付与'),
+    ('correction', '残高の訂正', '誤った付与・減算の訂正'),
+    ('other', 'その他', '上記に当てはまらないもの')
+ON CONFLICT (code) DO NOTHING;
+
+-- 既存の取引は空文字のまま（理由コード導入前）
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reason_code VARCHAR(32) NOT NULL DEFAULT '';
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS tag VARCHAR(50) NOT NULL DEFAULT '';
+
+-- 理由コードでの絞り込み・集計用（理由コードのある取引だけ索引する）
+CREATE INDEX IF NOT EXISTS idx_transactions_reason_code ON transactions(reason_code, created_at DESC) WHERE reason_code <> '';
@@ -0,0 +1,21 @@
+-- 組織・部署の階層と所属、部署ごとの管理者付与の月間予算
+
+CREATE TABLE IF NOT EXISTS departments (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    -- NULLなら最上位（組織）。子部署がある部署は削除できない
+    parent_id UUID REFERENCES departments(id) ON DELETE RESTRICT,
+    -- NULLなら予算なし。所属ユーザーへの管理者付与の当月合計の上限
+    monthly_grant_budget BIGINT CHECK (monthly_grant_budget IS NULL OR monthly_grant_budget >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (parent_id IS NULL OR parent_id <> id)
+);
+
+-- 同じ親の下では部署名を重複させない（最上位同士も含む）
+CREATE UNIQUE INDEX IF NOT EXISTS idx_departments_parent_name
+    ON departments(COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'::uuid), name);
+
+-- 部署を削除すると所属は解除される
+ALTER TABLE users ADD COLUMN IF NOT EXISTS department_id UUID REFERENCES departments(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_users_department_id ON users(department_id) WHERE department_id IS NOT NULL;
@@ -0,0 +1,29 @@
+-- 管理者付与の予算（全体・部署・管理者ごと）と期間ごとの消化状況
+
+CREATE TABLE IF NOT EXISTS budgets (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    scope VARCHAR(20) NOT NULL CHECK (scope IN ('global', 'department', 'admin')),
+    -- 部署IDまたは管理者のユーザーID（globalはNULL）。参照先が2種類あるため外部キーは張らない
+    scope_id UUID,
+    period VARCHAR(10) NOT NULL CHECK (period IN ('daily', 'weekly', 'monthly')),
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    enforcement VARCHAR(10) NOT NULL CHECK (enforcement IN ('block', 'override')),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((scope = 'global') = (scope_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_budgets_scope ON budgets(scope, scope_id) WHERE is_active;
+
+-- 付与のたびにトランザクション内で加算する（予算の行をロックしてから更新する）
+CREATE TABLE IF NOT EXISTS budget_usages (
+    budget_id UUID NOT NULL REFERENCES budgets(id) ON DELETE CASCADE,
+    period_start TIMESTAMPTZ NOT NULL,
+    consumed BIGINT NOT NULL DEFAULT 0,
+    -- この期間に通知済みの消化率の閾値（80, 100）
+    alerted_percent INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (budget_id, period_start)
+);
@@ -0,0 +1,41 @@
+-- 二人目の管理者の承認を待つ大口の付与・減算と、その監査記録
+
+CREATE TABLE IF NOT EXISTS pending_admin_actions (
+    id UUID PRIMARY KEY,
+    action_type VARCHAR(10) NOT NULL CHECK (action_type IN ('grant', 'deduct')),
+    requested_by UUID NOT NULL REFERENCES users(id),
+    target_user_id UUID NOT NULL REFERENCES users(id),
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    description TEXT NOT NULL DEFAULT '',
+    reason_code VARCHAR(50) NOT NULL DEFAULT '',
+    tag VARCHAR(50) NOT NULL DEFAULT '',
+    -- 申請時の冪等性キー（承認後の実行にも同じキーを使う）
+    idempotency_key VARCHAR(255) NOT NULL UNIQUE,
+    budget_override BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'approved', 'executed', 'failed', 'rejected', 'expired')),
+    reviewed_by UUID REFERENCES users(id),
+    review_comment TEXT NOT NULL DEFAULT '',
+    reviewed_at TIMESTAMPTZ,
+    transaction_id UUID REFERENCES transactions(id),
+    failure_reason TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pending_admin_actions_status ON pending_admin_actions(status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_pending_admin_actions_expires ON pending_admin_actions(expires_at) WHERE status = 'pending';
+
+-- 申請・承認・却下・期限切れ・実行の各段階の記録（削除しない）
+CREATE TABLE IF NOT EXISTS pending_admin_action_events (
+    id UUID PRIMARY KEY,
+    action_id UUID NOT NULL REFERENCES pending_admin_actions(id) ON DELETE CASCADE,
+    event VARCHAR(10) NOT NULL,
+    -- 操作した管理者（期限切れなどシステムによる場合はNULL）
+    actor_id UUID REFERENCES users(id),
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pending_admin_action_events_action ON pending_admin_action_events(action_id, created_at);
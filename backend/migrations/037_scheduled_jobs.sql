@@ -0,0 +1,17 @@
+-- 定期実行ジョブのスケジュールと直近の実行状況
+-- 複数インスタンスで同じジョブを二重に実行しないよう、実行前に行を条件付きで更新してロックを取る
+
+CREATE TABLE IF NOT EXISTS scheduled_jobs (
+    name VARCHAR(50) PRIMARY KEY,
+    schedule VARCHAR(100) NOT NULL,
+    -- 停止中（off）はNULL
+    next_run_at TIMESTAMPTZ,
+    locked_by VARCHAR(255) NOT NULL DEFAULT '',
+    locked_until TIMESTAMPTZ,
+    last_started_at TIMESTAMPTZ,
+    last_finished_at TIMESTAMPTZ,
+    last_status VARCHAR(20) NOT NULL DEFAULT '',
+    last_error TEXT NOT NULL DEFAULT '',
+    -- 処理した件数（件数が分からないジョブはNULL）
+    last_processed BIGINT
+);
@@ -0,0 +1,12 @@
+-- ワーカーごとのリーダー（実行するインスタンス）
+-- 複数インスタンスで同じワーカーを二重に実行しないよう、期限付きの行を条件付きで更新して取り合う
+
+CREATE TABLE IF NOT EXISTS worker_leases (
+    name VARCHAR(50) PRIMARY KEY,
+    holder VARCHAR(255) NOT NULL,
+    acquired_at TIMESTAMPTZ NOT NULL,
+    renewed_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    -- リーダーが別のインスタンスに移った回数（最初の取得を含む）
+    leader_changes BIGINT NOT NULL DEFAULT 0
+);
@@ -0,0 +1,18 @@
+-- ボーナスの付与に失敗したAkerunアクセス記録（再試行キュー）
+-- 失敗のたびに待ち時間を倍にして再試行し、上限に達したものは管理者が確認するまでdeadで残す
+
+CREATE TABLE IF NOT EXISTS failed_akerun_accesses (
+    id UUID PRIMARY KEY,
+    -- 同じアクセス記録は1件だけ（ポーリングが重なっても重複して登録しない）
+    access_id UUID NOT NULL UNIQUE,
+    user_name TEXT NOT NULL,
+    accessed_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_retry_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_failed_akerun_accesses_due ON failed_akerun_accesses(status, next_retry_at);
@@ -0,0 +1,21 @@
+-- 複数のAkerun組織・ドアに対応する
+-- 組織ごとに独立してポーリングし、ボーナスにはどの組織・ドアで入退室したかとドアの倍率を残す
+
+-- 組織ごとの前回ポーリング時刻（行がない組織は akerun_poll_state の値から始める）
+CREATE TABLE IF NOT EXISTS akerun_poll_cursors (
+    organization_id TEXT PRIMARY KEY,
+    last_polled_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE daily_bonuses ADD COLUMN IF NOT EXISTS akerun_organization_id TEXT;
+ALTER TABLE daily_bonuses ADD COLUMN IF NOT EXISTS akerun_door_id TEXT;
+ALTER TABLE daily_bonuses ADD COLUMN IF NOT EXISTS akerun_door_name TEXT;
+ALTER TABLE daily_bonuses ADD COLUMN IF NOT EXISTS points_multiplier NUMERIC(6, 2) NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN daily_bonuses.akerun_door_id IS '入退室したドア（Akerun）のID';
+COMMENT ON COLUMN daily_bonuses.points_multiplier IS 'ドアの規則による倍率（抽選で決まったポイントに掛ける）';
+
+ALTER TABLE failed_akerun_accesses ADD COLUMN IF NOT EXISTS organization_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE failed_akerun_accesses ADD COLUMN IF NOT EXISTS door_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE failed_akerun_accesses ADD COLUMN IF NOT EXISTS door_name TEXT NOT NULL DEFAULT '';
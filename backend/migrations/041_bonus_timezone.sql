@@ -0,0 +1,10 @@
+-- ボーナス日の区切りをタイムゾーンごとに判定する
+-- 既定はsystem_settingsのbonus_timezone（未設定ならAsia/Tokyo）で、ユーザーごとに上書きできる
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT ''; -- 空ならシステム設定に従う
+
+INSERT INTO system_settings (key, value, description)
+VALUES ('bonus_timezone', 'Asia/Tokyo', 'ボーナス日の区切り（AM6:00）に使うタイムゾーン')
+ON CONFLICT (key) DO NOTHING;
+
+COMMENT ON COLUMN daily_bonuses.bonus_date IS 'ボーナス対象日（ユーザーのタイムゾーン、未設定ならbonus_timezoneのAM6:00区切り）';
@@ -0,0 +1,12 @@
+-- 042_balance_corrections.sql
+-- 取引履歴との照合による残高の補正（管理者が /api/admin/maintenance/recompute-balances で実行する）
+
+-- 補正取引のtransaction_typeを追加（取引履歴から計算する残高には含めない）
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_transaction_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('transfer', 'admin_grant', 'admin_deduct', 'system_grant', 'daily_bonus', 'system_expire', 'balance_correction'));
+
+-- 補正で増やした分のバッチ用のsource_typeを追加
+ALTER TABLE point_batches DROP CONSTRAINT IF EXISTS point_batches_source_type_check;
+ALTER TABLE point_batches ADD CONSTRAINT point_batches_source_type_check
+    CHECK (source_type IN ('transfer', 'admin_grant', 'daily_bonus', 'system_grant', 'migration', 'expiry_restore', 'balance_correction'));
@@ -0,0 +1,31 @@
+-- 043_suspicious_activities.sql
+-- 不審な送金（新規アカウントへの大量送金・短時間の送り合い・付与直後の送金）の記録
+-- system_settings の transfer_hold_suspicious が true なら、検出した送金は管理者が確認するまで実行しない
+
+CREATE TABLE IF NOT EXISTS suspicious_activities (
+    id UUID PRIMARY KEY,
+    from_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    to_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    description TEXT NOT NULL DEFAULT '',
+    -- 送金時の冪等性キー（保留を解除して実行するときにも同じキーを使う）
+    idempotency_key VARCHAR(255) NOT NULL,
+    -- 検出した理由（カンマ区切り）
+    reasons TEXT NOT NULL,
+    status VARCHAR(10) NOT NULL
+        CHECK (status IN ('open', 'held', 'dismissed', 'confirmed', 'released', 'rejected', 'failed')),
+    transaction_id UUID REFERENCES transactions(id),
+    reviewed_by UUID REFERENCES users(id),
+    review_comment TEXT NOT NULL DEFAULT '',
+    reviewed_at TIMESTAMPTZ,
+    failure_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_suspicious_activities_status ON suspicious_activities(status, created_at DESC);
+
+-- 保留中・取り消した送金の冪等性キーの状態を追加
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_status_check;
+ALTER TABLE idempotency_keys ADD CONSTRAINT idempotency_keys_status_check
+    CHECK (status IN ('processing', 'completed', 'failed', 'held', 'rejected'));
@@ -0,0 +1,7 @@
+-- 044_transfer_fees.sql
+-- 送金手数料（送金額の上下限・手数料の設定は system_settings の transfer_policy に保存する）
+
+-- 手数料取引のtransaction_typeを追加（送信者から手数料の受け取り用アカウントへの移動として残高の計算に含める）
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_transaction_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('transfer', 'admin_grant', 'admin_deduct', 'system_grant', 'daily_bonus', 'system_expire', 'balance_correction', 'transfer_fee'));
@@ -0,0 +1,50 @@
+-- 045_system_accounts.sql
+-- システムの口座（発行元・失効の回収先・手数料の受け取り先）
+-- 付与・減算・失効・手数料をユーザーとシステムの口座の間の移動として記録し、ポイントの保存を確認できるようにする
+
+CREATE TABLE IF NOT EXISTS system_accounts (
+    code VARCHAR(32) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO system_accounts (code, name, description) VALUES
+    ('treasury', '発行元', '付与はここから出し、管理者の減算・商品交換はここへ戻す'),
+    ('expiry_sink', '失効ポイントの回収先', '失効したポイントを受け取り、失効の取り消しはここから戻す'),
+    ('fees', '送金手数料', '受け取り用のユーザーを指定しない送金手数料を受け取る')
+ON CONFLICT (code) DO NOTHING;
+
+-- 取引の移動元・移動先のシステムの口座（ユーザー間の移動はNULL）
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS from_system_account VARCHAR(32) REFERENCES system_accounts(code);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS to_system_account VARCHAR(32) REFERENCES system_accounts(code);
+
+-- 既存の取引を口座に振り分ける（残高の補正は取引履歴から計算する残高に含めないため振り分けない）
+UPDATE transactions SET from_system_account = 'expiry_sink'
+WHERE from_user_id IS NULL AND from_system_account IS NULL
+    AND transaction_type = 'admin_grant' AND metadata->>'restored_batch_id' IS NOT NULL;
+
+UPDATE transactions SET from_system_account = 'treasury'
+WHERE from_user_id IS NULL AND from_system_account IS NULL
+    AND transaction_type IN ('admin_grant', 'system_grant', 'daily_bonus');
+
+UPDATE transactions SET to_system_account = 'treasury'
+WHERE to_user_id IS NULL AND to_system_account IS NULL
+    AND transaction_type = 'admin_deduct';
+
+UPDATE transactions SET to_system_account = 'expiry_sink'
+WHERE to_user_id IS NULL AND to_system_account IS NULL
+    AND transaction_type = 'system_expire';
+
+-- 片側はユーザーかシステムの口座のどちらか一方
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS check_from_user_or_system_account;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS check_to_user_or_system_account;
+ALTER TABLE transactions ADD CONSTRAINT check_from_user_or_system_account
+    CHECK (from_user_id IS NULL OR from_system_account IS NULL);
+ALTER TABLE transactions ADD CONSTRAINT check_to_user_or_system_account
+    CHECK (to_user_id IS NULL OR to_system_account IS NULL);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_from_system_account ON transactions(from_system_account) WHERE from_system_account IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_transactions_to_system_account ON transactions(to_system_account) WHERE to_system_account IS NOT NULL;
+
+COMMENT ON TABLE system_accounts IS 'システムの口座: ユーザー以外のポイントの移動元・移動先。残高は取引から計算する';
@@ -0,0 +1,36 @@
+-- ポイント獲得ルール（プロフィールの完成・初めての友達・N回目の送金などでポイントを付与する）
+
+CREATE TABLE IF NOT EXISTS earning_rules (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    trigger_type VARCHAR(50) NOT NULL CHECK (trigger_type IN ('profile_completed', 'friend_added', 'transfer_sent')),
+    points BIGINT NOT NULL CHECK (points > 0),
+    occurrence BIGINT NOT NULL DEFAULT 0 CHECK (occurrence >= 0),
+    min_amount BIGINT NOT NULL DEFAULT 0 CHECK (min_amount >= 0),
+    once_per_user BOOLEAN NOT NULL DEFAULT FALSE,
+    max_grants_per_user INTEGER NOT NULL DEFAULT 0 CHECK (max_grants_per_user >= 0),
+    max_grants_total INTEGER NOT NULL DEFAULT 0 CHECK (max_grants_total >= 0),
+    grant_count INTEGER NOT NULL DEFAULT 0 CHECK (grant_count >= 0),
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_earning_rules_trigger ON earning_rules(trigger_type) WHERE is_active;
+
+-- ルールによる付与の記録（同じ発生元の出来事では同じルールで1回だけ付与する）
+CREATE TABLE IF NOT EXISTS earning_rule_grants (
+    id UUID PRIMARY KEY,
+    rule_id UUID NOT NULL REFERENCES earning_rules(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source_id VARCHAR(100) NOT NULL,
+    transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    points BIGINT NOT NULL CHECK (points > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (rule_id, user_id, source_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_earning_rule_grants_rule_created ON earning_rule_grants(rule_id, created_at DESC);
@@ -0,0 +1,25 @@
+-- 047_point_batch_adjustments.sql
+-- 管理者によるポイントバッチの有効期限の変更・取り消し（PATCH /api/admin/point-batches/:id）
+
+-- 期限を個別に変更したバッチは有効期間の設定を変えても再計算しない
+ALTER TABLE point_batches ADD COLUMN IF NOT EXISTS expiry_adjusted_at TIMESTAMPTZ;
+ALTER TABLE point_batches ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMPTZ;
+ALTER TABLE point_batches ADD COLUMN IF NOT EXISTS cancelled_amount BIGINT NOT NULL DEFAULT 0 CHECK (cancelled_amount >= 0);
+
+-- 調整の監査記録（変更前後の期限、取り消しで減算したポイントと取引、理由）
+CREATE TABLE IF NOT EXISTS point_batch_adjustments (
+    id UUID PRIMARY KEY,
+    batch_id UUID NOT NULL REFERENCES point_batches(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    admin_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('expiry_changed', 'cancelled')),
+    previous_expires_at TIMESTAMPTZ NOT NULL,
+    new_expires_at TIMESTAMPTZ,
+    cancelled_amount BIGINT NOT NULL DEFAULT 0 CHECK (cancelled_amount >= 0),
+    transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_point_batch_adjustments_batch ON point_batch_adjustments(batch_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_point_batch_adjustments_user ON point_batch_adjustments(user_id, created_at DESC);
@@ -0,0 +1,43 @@
+-- 048_tenants.sql
+-- マルチテナント（1つのデプロイで複数の組織を分離する）
+-- テナントはX-Tenant-IDヘッダーまたはサブドメインで解決し、既存のデータはすべて既定のテナントに入る
+
+CREATE TABLE IF NOT EXISTS tenants (
+    id UUID PRIMARY KEY,
+    slug VARCHAR(40) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO tenants (id, slug, name) VALUES
+    ('00000000-0000-0000-0000-000000000001', 'default', '既定のテナント')
+ON CONFLICT DO NOTHING;
+
+-- テナントで分離するテーブル（既存の行は既定のテナント）
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES tenants(id);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES tenants(id);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES tenants(id);
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES tenants(id);
+ALTER TABLE system_settings ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES tenants(id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_tenant ON transactions(tenant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_products_tenant ON products(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_sessions_tenant ON sessions(tenant_id);
+
+-- ユーザー名・メールアドレスはテナントごとに一意
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_key;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_tenant_username ON users(tenant_id, username);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_tenant_email ON users(tenant_id, email);
+
+-- システム設定はテナントごとに持つ
+ALTER TABLE system_settings DROP CONSTRAINT IF EXISTS system_settings_pkey;
+ALTER TABLE system_settings ADD PRIMARY KEY (tenant_id, key);
+
+-- テナントの作成・停止を行うスーパー管理者（既定のテナントのユーザーだけがなれる）
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('user', 'admin', 'super_admin'));
+
+COMMENT ON TABLE tenants IS 'テナント（組織）。slugはサブドメイン・X-Tenant-IDヘッダーで使う';
@@ -0,0 +1,56 @@
+-- 049_transaction_archive.sql
+-- 取引の保持期間（TRANSACTION_RETENTION_DAYS）を過ぎた確定済みの取引を保管用のテーブルへ移す
+-- 移すのは定期実行ジョブ transaction_archive。残高の照合・ポイントの保存の確認は両方のテーブルを積み上げる
+
+-- 列はtransactionsと同じ（ユーザーの削除・冪等性キーの削除の影響を受けないよう外部キーは付けない）
+CREATE TABLE IF NOT EXISTS archived_transactions (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES tenants(id),
+    from_user_id UUID,
+    to_user_id UUID,
+    from_system_account VARCHAR(32),
+    to_system_account VARCHAR(32),
+    amount BIGINT NOT NULL,
+    transaction_type VARCHAR(50) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    idempotency_key VARCHAR(255),
+    description TEXT,
+    reason_code VARCHAR(32) NOT NULL DEFAULT '',
+    tag VARCHAR(50) NOT NULL DEFAULT '',
+    metadata JSONB,
+    created_at TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- 検索は期間の指定が必須のため、索引は作成日時とユーザーのみ
+CREATE INDEX IF NOT EXISTS idx_archived_transactions_tenant ON archived_transactions(tenant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_archived_transactions_from_user ON archived_transactions(from_user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_archived_transactions_to_user ON archived_transactions(to_user_id, created_at DESC);
+
+-- 移した取引の月（JST）・種別・状態ごとの集計（移すときに加算する）
+CREATE TABLE IF NOT EXISTS transaction_archive_summaries (
+    tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES tenants(id),
+    month VARCHAR(7) NOT NULL,
+    transaction_type VARCHAR(50) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    transaction_count BIGINT NOT NULL DEFAULT 0,
+    total_amount BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, month, transaction_type, status)
+);
+
+-- 取引を参照する記録は、移した後も保管用のテーブルの取引IDを指したまま残す
+ALTER TABLE product_exchanges DROP CONSTRAINT IF EXISTS product_exchanges_transaction_id_fkey;
+ALTER TABLE transfer_requests DROP CONSTRAINT IF EXISTS transfer_requests_transaction_id_fkey;
+ALTER TABLE daily_bonuses DROP CONSTRAINT IF EXISTS daily_bonuses_transfer_transaction_id_fkey;
+ALTER TABLE point_batches DROP CONSTRAINT IF EXISTS point_batches_source_transaction_id_fkey;
+ALTER TABLE kiosk_grants DROP CONSTRAINT IF EXISTS kiosk_grants_transaction_id_fkey;
+ALTER TABLE event_attendances DROP CONSTRAINT IF EXISTS event_attendances_transaction_id_fkey;
+ALTER TABLE pending_admin_actions DROP CONSTRAINT IF EXISTS pending_admin_actions_transaction_id_fkey;
+ALTER TABLE suspicious_activities DROP CONSTRAINT IF EXISTS suspicious_activities_transaction_id_fkey;
+ALTER TABLE earning_rule_grants DROP CONSTRAINT IF EXISTS earning_rule_grants_transaction_id_fkey;
+ALTER TABLE point_batch_adjustments DROP CONSTRAINT IF EXISTS point_batch_adjustments_transaction_id_fkey;
+
+COMMENT ON TABLE archived_transactions IS '保持期間を過ぎて移した取引。ユーザーの取引履歴には表示しない';
+COMMENT ON TABLE transaction_archive_summaries IS '移した取引の月・種別・状態ごとの件数とポイントの合計';
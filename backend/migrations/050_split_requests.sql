@@ -0,0 +1,25 @@
+-- 050_split_requests.sql
+-- 割り勘（合計額を複数の友達に均等または指定の金額で請求する）
+-- 参加者ごとに作成者宛の送金リクエスト（split_idを持つ）を作り、参加者が承認すると支払われる
+
+CREATE TABLE IF NOT EXISTS split_requests (
+    id UUID PRIMARY KEY,
+    requester_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    total_amount BIGINT NOT NULL CHECK (total_amount > 0),
+    split_type VARCHAR(20) NOT NULL CHECK (split_type IN ('equal', 'custom')),
+    include_requester BOOLEAN NOT NULL DEFAULT false,
+    message TEXT,
+    idempotency_key VARCHAR(255) NOT NULL UNIQUE,
+    cancelled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_split_requests_requester ON split_requests(requester_id, created_at DESC);
+
+-- 割り勘の1人分の送金リクエストは、送信者（参加者）が承認・拒否し、受取人（作成者）がキャンセルする
+ALTER TABLE transfer_requests ADD COLUMN IF NOT EXISTS split_id UUID REFERENCES split_requests(id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_transfer_requests_split ON transfer_requests(split_id) WHERE split_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_transfer_requests_split_pending ON transfer_requests(from_user_id, status) WHERE split_id IS NOT NULL;
+
+COMMENT ON TABLE split_requests IS '割り勘。各自の金額・支払い状況はtransfer_requests（split_id）に持つ';
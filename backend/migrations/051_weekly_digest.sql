@@ -0,0 +1,5 @@
+-- 051_weekly_digest.sql
+-- 週次のまとめメール（定期実行ジョブ weekly_digest）の受け取り設定
+-- 行が無いユーザー・既存のユーザーは受け取る
+
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS weekly_digest BOOLEAN NOT NULL DEFAULT TRUE;
@@ -0,0 +1,12 @@
+-- 052_onboarding_bonus.sql
+-- 登録時のウェルカムボーナス（金額・有効日数・有効/無効は system_settings の onboarding_bonus に保存する）
+
+-- ウェルカムボーナスのtransaction_typeを追加（ユーザーごとに1回に限るため冪等性キーを付けて記録する）
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_transaction_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_transaction_type_check
+    CHECK (transaction_type IN ('transfer', 'admin_grant', 'admin_deduct', 'system_grant', 'daily_bonus', 'system_expire', 'balance_correction', 'transfer_fee', 'onboarding_bonus'));
+
+-- ウェルカムボーナスのバッチ用のsource_typeを追加
+ALTER TABLE point_batches DROP CONSTRAINT IF EXISTS point_batches_source_type_check;
+ALTER TABLE point_batches ADD CONSTRAINT point_batches_source_type_check
+    CHECK (source_type IN ('transfer', 'admin_grant', 'daily_bonus', 'system_grant', 'migration', 'expiry_restore', 'balance_correction', 'onboarding_bonus'));
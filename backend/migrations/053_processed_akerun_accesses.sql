@@ -0,0 +1,12 @@
+-- 053_processed_akerun_accesses.sql
+-- 処理済みのAkerunアクセス記録ID（ポーリングの取得期間が重なっても同じ記録を二度処理しない）
+-- 定期実行ジョブ processed_akerun_access_cleanup が保持期間（30日）を過ぎたものを削除する
+
+CREATE TABLE IF NOT EXISTS processed_akerun_accesses (
+    access_id UUID PRIMARY KEY,
+    organization_id TEXT NOT NULL DEFAULT '',
+    accessed_at TIMESTAMPTZ NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_processed_akerun_accesses_processed_at ON processed_akerun_accesses(processed_at);
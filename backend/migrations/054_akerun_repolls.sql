@@ -0,0 +1,24 @@
+-- 054_akerun_repolls.sql
+-- 管理者が依頼したAkerunの入退室記録の再取得（ポーリングできなかった期間の取り戻し）
+-- ワーカーが1時間ずつ取得し、進み具合を completed_windows / total_windows に記録する
+
+CREATE TABLE IF NOT EXISTS akerun_repolls (
+    id UUID PRIMARY KEY,
+    organization_id TEXT NOT NULL DEFAULT '',
+    from_time TIMESTAMPTZ NOT NULL,
+    to_time TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    total_windows INTEGER NOT NULL,
+    completed_windows INTEGER NOT NULL DEFAULT 0,
+    fetched_accesses INTEGER NOT NULL DEFAULT 0,
+    error_message TEXT NOT NULL DEFAULT '',
+    requested_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_akerun_repolls_created_at ON akerun_repolls(created_at);
+
+-- 処理待ち・取得中の依頼は同時に1件まで（期間の重なる取得を並行して走らせない）
+CREATE UNIQUE INDEX IF NOT EXISTS idx_akerun_repolls_active ON akerun_repolls((1)) WHERE status IN ('pending', 'running');
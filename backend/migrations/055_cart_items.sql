@@ -0,0 +1,11 @@
+-- 055_cart_items.sql
+-- 商品交換のカート（ユーザーと商品ごとに1行。決済すると交換記録を作成して空にする）
+
+CREATE TABLE IF NOT EXISTS cart_items (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, product_id)
+);
@@ -0,0 +1,9 @@
+-- 056_product_exchange_gifts.sql
+-- 友達へのギフト交換（ポイントを払うのはuser_id、受け取るのはrecipient_id）
+-- gift_statusはギフトでなければ空文字、ギフトならpending → accepted / declined
+
+ALTER TABLE product_exchanges ADD COLUMN IF NOT EXISTS recipient_id UUID REFERENCES users(id);
+ALTER TABLE product_exchanges ADD COLUMN IF NOT EXISTS gift_status VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE product_exchanges ADD COLUMN IF NOT EXISTS gift_responded_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_product_exchanges_recipient_id ON product_exchanges(recipient_id) WHERE recipient_id IS NOT NULL;
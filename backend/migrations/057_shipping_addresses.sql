@@ -0,0 +1,27 @@
+-- 057_shipping_addresses.sql
+-- ユーザーのお届け先（配送が必要な商品の交換時に使う）と、交換時点の住所の控え
+
+CREATE TABLE IF NOT EXISTS shipping_addresses (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    label VARCHAR(100) NOT NULL DEFAULT '',
+    recipient_name VARCHAR(100) NOT NULL,
+    postal_code VARCHAR(8) NOT NULL,
+    prefecture VARCHAR(10) NOT NULL,
+    city VARCHAR(100) NOT NULL,
+    line1 VARCHAR(100) NOT NULL,
+    line2 VARCHAR(100) NOT NULL DEFAULT '',
+    phone VARCHAR(20) NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_shipping_addresses_user_id ON shipping_addresses(user_id);
+
+-- 既定のお届け先はユーザーごとに1件
+CREATE UNIQUE INDEX IF NOT EXISTS idx_shipping_addresses_default
+    ON shipping_addresses(user_id) WHERE is_default;
+
+-- 交換時点のお届け先（後でお届け先を変更・削除しても配送先が分かるように文字列で残す）
+ALTER TABLE product_exchanges ADD COLUMN IF NOT EXISTS shipping_address TEXT NOT NULL DEFAULT '';
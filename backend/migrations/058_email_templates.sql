@@ -0,0 +1,16 @@
+-- 058_email_templates.sql
+-- 管理者が編集したメールテンプレート（保存するたびに版を増やし、最新の版で送信する）
+-- 版のないメールの種類は組み込みの既定の文面で送る
+
+CREATE TABLE IF NOT EXISTS email_templates (
+    id UUID PRIMARY KEY,
+    template_key VARCHAR(50) NOT NULL,
+    version INTEGER NOT NULL,
+    subject VARCHAR(200) NOT NULL,
+    html_body TEXT NOT NULL DEFAULT '',
+    text_body TEXT NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_email_templates_key_version ON email_templates(template_key, version);
@@ -0,0 +1,14 @@
+-- 059_friend_pins.sql
+-- クイック送金の先頭に表示する友達（ピン留め）
+
+CREATE TABLE IF NOT EXISTS friend_pins (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    friend_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, friend_id)
+);
+
+-- 送金先・金額ごとの集計（クイック送金で覚えておく送金額）に使う
+CREATE INDEX IF NOT EXISTS idx_transactions_from_user_to_user
+    ON transactions(from_user_id, to_user_id, created_at)
+    WHERE transaction_type = 'transfer' AND status = 'completed';
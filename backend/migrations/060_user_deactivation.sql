@@ -0,0 +1,12 @@
+-- 060_user_deactivation.sql
+-- 本人によるアカウントの一時休止（退会＝アーカイブとは別）
+-- 休止中は検索に出ず取引できない（is_activeもfalseにする）。休止から30日以内にログインすると再開する
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMPTZ;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check CHECK (status IN ('active', 'deactivated'));
+
+COMMENT ON COLUMN users.status IS '利用状態（active: 利用中、deactivated: 本人が休止中）。管理者による無効化はis_activeだけで表す';
+COMMENT ON COLUMN users.deactivated_at IS '本人がアカウントを休止した日時（再開できる期限の起点）';
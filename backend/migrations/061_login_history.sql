@@ -0,0 +1,11 @@
+-- 061_login_history.sql
+-- 本人向けのログイン履歴（GET /api/settings/security/logins）
+-- 新しい端末・国からのログインとして通知したかを残し、履歴で強調する
+
+ALTER TABLE login_attempts ADD COLUMN IF NOT EXISTS new_device BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- ユーザーごとの履歴（成功・失敗とも）を新しい順に読む
+CREATE INDEX IF NOT EXISTS idx_login_attempts_user_created
+    ON login_attempts(user_id, created_at DESC);
+
+COMMENT ON COLUMN login_attempts.new_device IS '初めての端末・国からのログイン成功（新しい端末の通知を送ったもの）';
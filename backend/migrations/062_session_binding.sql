@@ -0,0 +1,26 @@
+-- 062_session_binding.sql
+-- セッションを端末・接続元に固定する（SESSION_BINDING）
+-- User-Agentと接続元のサブネットのハッシュを保存し、変わったら記録して設定に応じて再ログインを求める
+
+-- 未固定（空）のセッションは次のリクエストでログイン時の接続元に固定する
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS fingerprint VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS session_binding_violations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    session_id UUID NOT NULL,
+    fingerprint_change VARCHAR(30) NOT NULL
+        CHECK (fingerprint_change IN ('user_agent', 'subnet', 'user_agent_and_subnet')),
+    rejected BOOLEAN NOT NULL DEFAULT FALSE,
+    ip_address VARCHAR(100),
+    user_agent TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- セキュリティ履歴（GET /api/settings/security/history）で新しい順に読む
+CREATE INDEX IF NOT EXISTS idx_session_binding_violations_user_created
+    ON session_binding_violations(user_id, created_at DESC);
+
+COMMENT ON COLUMN sessions.fingerprint IS 'セッションを固定した端末・接続元（User-Agentとサブネットのハッシュ、未固定なら空）';
+COMMENT ON TABLE session_binding_violations IS 'セッションが固定した端末・接続元と異なるところから使われた記録';
+COMMENT ON COLUMN session_binding_violations.rejected IS 'セッションを失効させて再ログインを求めたか';
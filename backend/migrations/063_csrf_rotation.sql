@@ -0,0 +1,15 @@
+-- 063_csrf_rotation.sql
+-- CSRFトークンの入れ替え（CSRF_ROTATION）
+-- 入れ替える前のトークンを猶予期間（CSRF_GRACE_SEC）だけ受け付けるため、前のトークンと発行日時を保存する
+
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS previous_csrf_token VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS csrf_issued_at TIMESTAMP WITH TIME ZONE;
+
+-- 既存のセッションのトークンはセッションを作成したときに発行したもの
+UPDATE sessions SET csrf_issued_at = created_at WHERE csrf_issued_at IS NULL;
+
+ALTER TABLE sessions ALTER COLUMN csrf_issued_at SET DEFAULT NOW();
+ALTER TABLE sessions ALTER COLUMN csrf_issued_at SET NOT NULL;
+
+COMMENT ON COLUMN sessions.previous_csrf_token IS '入れ替える前のCSRFトークン（入れ替えてから猶予期間だけ受け付ける）';
+COMMENT ON COLUMN sessions.csrf_issued_at IS '今のCSRFトークンを発行した日時';
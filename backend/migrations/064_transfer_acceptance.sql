@@ -0,0 +1,11 @@
+-- 064_transfer_acceptance.sql
+-- 誰からのポイントを受け取るか（anyone: 誰からでも / friends_only: 友達からだけ）
+-- 空ならsystem_settingsのtransfer_eligibility（default_accept_transfers_from、未設定ならanyone）に従う
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS accept_transfers_from VARCHAR(20) NOT NULL DEFAULT '';
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_accept_transfers_from_check;
+ALTER TABLE users ADD CONSTRAINT users_accept_transfers_from_check
+    CHECK (accept_transfers_from IN ('', 'anyone', 'friends_only'));
+
+COMMENT ON COLUMN users.accept_transfers_from IS '誰からのポイントを受け取るか（anyone / friends_only。空ならシステム設定の既定）';
@@ -0,0 +1,14 @@
+-- 065_muted_senders.sql
+-- 送金リクエストの送信者のミュート
+-- ミュートした送信者からのリクエストは通知せず、承認待ちの一覧・件数にも含めない
+
+CREATE TABLE IF NOT EXISTS muted_senders (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    muted_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    mode VARCHAR(20) NOT NULL CHECK (mode IN ('reject', 'drop')),
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, muted_user_id)
+);
+
+COMMENT ON TABLE muted_senders IS 'ユーザー（user_id）がミュートした送金リクエストの送信者（muted_user_id）';
+COMMENT ON COLUMN muted_senders.mode IS 'ミュートした送信者のリクエストの扱い（reject: 作成と同時に拒否、drop: 承認待ちのまま見せずに期限切れにする）';
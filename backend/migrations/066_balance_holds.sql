@@ -0,0 +1,31 @@
+-- 066_balance_holds.sql
+-- 送金リクエストの作成時に送信者の残高から金額を確保する（hold）
+-- 確保した額はusers.reserved_balanceに含め、承認まではほかの送金・交換に使えない
+-- 承認時の送金で使用済みに、拒否・キャンセル・期限切れで解放済みにする
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS reserved_balance BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_reserved_balance_check;
+ALTER TABLE users ADD CONSTRAINT users_reserved_balance_check CHECK (reserved_balance >= 0);
+
+COMMENT ON COLUMN users.reserved_balance IS '残高のうち送金リクエストのために確保中の額（使えるのはbalance - reserved_balance）';
+
+ALTER TABLE transfer_requests ADD COLUMN IF NOT EXISTS hold BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN transfer_requests.hold IS '送信者の残高から金額を確保しているか（balance_holds）';
+
+CREATE TABLE IF NOT EXISTS balance_holds (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    transfer_request_id UUID NOT NULL UNIQUE REFERENCES transfer_requests(id) ON DELETE CASCADE,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('active', 'consumed', 'released')),
+    created_at TIMESTAMPTZ NOT NULL,
+    settled_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_balance_holds_user_id ON balance_holds(user_id);
+CREATE INDEX IF NOT EXISTS idx_balance_holds_active ON balance_holds(created_at) WHERE status = 'active';
+
+COMMENT ON TABLE balance_holds IS '送金リクエスト（transfer_request_id）のために送信者（user_id）の残高から確保した額';
+COMMENT ON COLUMN balance_holds.status IS 'active: 確保中、consumed: 承認された送金に使った、released: 拒否・キャンセル・期限切れで戻した';
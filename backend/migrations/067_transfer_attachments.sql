@@ -0,0 +1,25 @@
+-- 067_transfer_attachments.sql
+-- 送金・送金リクエストに添付した画像（購入したものの写真など）
+-- 画像はFileStorageServiceに保存し、当事者だけがAPIから取得できる
+-- 送金リクエストの添付は、承認してできた送金（transfer_requests.transaction_id）の添付としても見える
+-- 取引は保管用のテーブルへ移ることがあるため、transaction_idには外部キーを付けない
+
+CREATE TABLE IF NOT EXISTS transfer_attachments (
+    id UUID PRIMARY KEY,
+    transaction_id UUID,
+    transfer_request_id UUID REFERENCES transfer_requests(id) ON DELETE CASCADE,
+    uploaded_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    file_path TEXT NOT NULL,
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    created_at TIMESTAMPTZ NOT NULL,
+    CHECK ((transaction_id IS NULL) <> (transfer_request_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_transfer_attachments_transaction_id ON transfer_attachments(transaction_id) WHERE transaction_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_transfer_attachments_transfer_request_id ON transfer_attachments(transfer_request_id) WHERE transfer_request_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_transfer_attachments_created_at ON transfer_attachments(created_at);
+CREATE INDEX IF NOT EXISTS idx_transfer_requests_transaction_id ON transfer_requests(transaction_id) WHERE transaction_id IS NOT NULL;
+
+COMMENT ON TABLE transfer_attachments IS '送金（transaction_id）または送金リクエスト（transfer_request_id）に添付した画像';
+COMMENT ON COLUMN transfer_attachments.file_path IS 'FileStorageServiceの保存先（添付画像のディレクトリからの相対パス）';
@@ -0,0 +1,22 @@
+-- 068_transaction_query_indexes.sql
+-- 取引の履歴・管理画面の絞り込みに使う複合・部分インデックス
+-- ユーザーの履歴は (from_user_id = ? OR to_user_id = ?) ではどちらの列のインデックスも使えず、
+-- ユーザーの取引をすべて読んで並べ替えていたため、送信側・受信側のUNIONに書き直した
+-- それぞれ idx_transactions_from_user_created・idx_transactions_to_user_created（009）を新しい順にたどる
+
+-- 理由コードで絞り込んだユーザーの履歴（理由コードのある取引だけ）
+CREATE INDEX IF NOT EXISTS idx_transactions_from_user_reason
+    ON transactions(from_user_id, reason_code, created_at DESC)
+    WHERE reason_code <> '';
+CREATE INDEX IF NOT EXISTS idx_transactions_to_user_reason
+    ON transactions(to_user_id, reason_code, created_at DESC)
+    WHERE reason_code <> '';
+
+-- 管理画面の種類と期間での絞り込み（idx_transactions_typeは009で削除済み）
+CREATE INDEX IF NOT EXISTS idx_transactions_type_created
+    ON transactions(transaction_type, created_at DESC);
+
+-- 完了した取引の期間での集計（分析・/metrics の今日の発行・消費・送金）
+CREATE INDEX IF NOT EXISTS idx_transactions_completed_created
+    ON transactions(created_at DESC)
+    WHERE status = 'completed';
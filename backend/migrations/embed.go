@@ -0,0 +1,9 @@
+// Package migrations はSQLマイグレーションファイルをバイナリに埋め込む
+package migrations
+
+import "embed"
+
+// FS は NNN_name.sql 形式のマイグレーションファイル群
+//
+//go:embed *.sql
+var FS embed.FS
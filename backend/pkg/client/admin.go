@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// AdjustPointsRequest は管理者によるポイントの付与・減算のリクエスト
+type AdjustPointsRequest struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Amount      int64     `json:"amount"`
+	Description string    `json:"description"`
+	ReasonCode  string    `json:"reason_code"`
+	Tag         string    `json:"tag,omitempty"`
+	DryRun      bool      `json:"dry_run,omitempty"` // trueなら結果だけ返して反映しない
+	// BudgetOverride はoverrideの予算を超えて付与する（付与のみ）
+	BudgetOverride bool `json:"budget_override,omitempty"`
+	// IdempotencyKey は付与・減算の冪等性キー（空ならctxのキー、無ければ新しく作る）
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+// AdjustPointsResponse は管理者によるポイントの付与・減算のレスポンス
+// 金額が承認の閾値を超える場合はRequiresApprovalがtrueでPendingActionだけが入る（反映は二人目の管理者の承認後）
+type AdjustPointsResponse struct {
+	Transaction      *Transaction        `json:"transaction,omitempty"`
+	User             *User               `json:"user,omitempty"`
+	DryRun           bool                `json:"dry_run"`
+	RequiresApproval bool                `json:"requires_approval"`
+	PendingAction    *PendingAdminAction `json:"pending_action,omitempty"`
+}
+
+// UserList はユーザー一覧
+type UserList struct {
+	Users []User `json:"users"`
+	Total int64  `json:"total"`
+}
+
+// ListUsersRequest はユーザー一覧の絞り込み
+type ListUsersRequest struct {
+	Page
+	Search    string
+	SortBy    string
+	SortOrder string // asc / desc
+}
+
+// TransactionList は全ユーザーの取引一覧
+type TransactionList struct {
+	Transactions []Transaction `json:"transactions"`
+	Total        int64         `json:"total"`
+}
+
+// ListTransactionsRequest は全ユーザーの取引一覧の絞り込み
+type ListTransactionsRequest struct {
+	Page
+	TransactionType string
+	ReasonCode      string
+	DateFrom        string // YYYY-MM-DD
+	DateTo          string // YYYY-MM-DD
+}
+
+// GrantPoints は管理者としてユーザーにポイントを付与する
+func (c *Client) GrantPoints(ctx context.Context, req AdjustPointsRequest) (*AdjustPointsResponse, error) {
+	return c.adjustPoints(ctx, "/admin/points/grant", req)
+}
+
+// DeductPoints は管理者としてユーザーのポイントを減算する
+func (c *Client) DeductPoints(ctx context.Context, req AdjustPointsRequest) (*AdjustPointsResponse, error) {
+	return c.adjustPoints(ctx, "/admin/points/deduct", req)
+}
+
+func (c *Client) adjustPoints(ctx context.Context, path string, req AdjustPointsRequest) (*AdjustPointsResponse, error) {
+	ctx, req.IdempotencyKey = bodyIdempotencyKey(ctx, req.IdempotencyKey)
+	var resp AdjustPointsResponse
+	if err := c.mutate(ctx, http.MethodPost, path, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListUsers は管理者としてユーザー一覧を返す
+func (c *Client) ListUsers(ctx context.Context, req ListUsersRequest) (*UserList, error) {
+	query := req.values()
+	setIfNotEmpty(query, "search", req.Search)
+	setIfNotEmpty(query, "sort_by", req.SortBy)
+	setIfNotEmpty(query, "sort_order", req.SortOrder)
+	var resp UserList
+	if err := c.get(ctx, "/admin/users", query, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListTransactions は管理者として全ユーザーの取引一覧を返す
+func (c *Client) ListTransactions(ctx context.Context, req ListTransactionsRequest) (*TransactionList, error) {
+	query := req.values()
+	setIfNotEmpty(query, "transaction_type", req.TransactionType)
+	setIfNotEmpty(query, "reason_code", req.ReasonCode)
+	setIfNotEmpty(query, "date_from", req.DateFrom)
+	setIfNotEmpty(query, "date_to", req.DateTo)
+	var resp TransactionList
+	if err := c.get(ctx, "/admin/transactions", query, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateUserRole は管理者としてユーザーの権限を変える（user / admin）
+func (c *Client) UpdateUserRole(ctx context.Context, userID uuid.UUID, role string) (*User, error) {
+	var resp struct {
+		User User `json:"user"`
+	}
+	if err := c.mutate(ctx, http.MethodPut, "/admin/users/"+userID.String()+"/role", map[string]string{"role": role}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.User, nil
+}
+
+// DeactivateUser は管理者としてユーザーを無効にする
+func (c *Client) DeactivateUser(ctx context.Context, userID uuid.UUID) (*User, error) {
+	var resp struct {
+		User User `json:"user"`
+	}
+	if err := c.mutate(ctx, http.MethodPost, "/admin/users/"+userID.String()+"/deactivate", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.User, nil
+}
+
+// Analytics はポイントの統計のうち、流通量とポイントの保存の確認に使う部分
+type Analytics struct {
+	Summary        AnalyticsSummary `json:"summary"`
+	SystemAccounts []SystemAccount  `json:"system_accounts"`
+	Conservation   Conservation     `json:"conservation"`
+}
+
+// AnalyticsSummary はポイントの流通量の概要
+type AnalyticsSummary struct {
+	TotalPointsInCirculation int64 `json:"total_points_in_circulation"`
+	PointsIssuedThisMonth    int64 `json:"points_issued_this_month"`
+	TransactionsThisMonth    int64 `json:"transactions_this_month"`
+	ActiveUsers              int64 `json:"active_users"`
+}
+
+// SystemAccount はシステム勘定（treasury・expiry_sink・fees）の残高
+type SystemAccount struct {
+	Account  string `json:"account"`
+	Credited int64  `json:"credited"`
+	Debited  int64  `json:"debited"`
+	Balance  int64  `json:"balance"`
+}
+
+// Conservation はユーザーとシステム勘定の残高の合計が0になっているか（ポイントが増減していないか）
+type Conservation struct {
+	UserBalanceTotal   int64 `json:"user_balance_total"`
+	SystemBalanceTotal int64 `json:"system_balance_total"`
+	Difference         int64 `json:"difference"`
+	Conserved          bool  `json:"conserved"`
+}
+
+// Analytics は管理者としてポイントの統計を返す（daysは日別の集計の日数。0ならサーバーの既定）
+func (c *Client) Analytics(ctx context.Context, days int) (*Analytics, error) {
+	query := url.Values{}
+	if days > 0 {
+		query.Set("days", strconv.Itoa(days))
+	}
+	var resp Analytics
+	if err := c.get(ctx, "/admin/analytics", query, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// csrfPath はCSRFトークンを取得するパス
+const csrfPath = "/auth/csrf"
+
+// RegisterRequest はユーザー登録のリクエスト
+type RegisterRequest struct {
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	Password     string `json:"password"`
+	DisplayName  string `json:"display_name"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	ReferralCode string `json:"referral_code,omitempty"`
+}
+
+// RegisterResponse はユーザー登録のレスポンス
+type RegisterResponse struct {
+	User            User   `json:"user"`
+	OnboardingBonus int64  `json:"onboarding_bonus"`
+	CSRFToken       string `json:"csrf_token"` // メール認証が済むまでログインできない設定なら空
+	// EmailVerification はメール認証が済むまで制限される操作（off / before_login / before_transfer）
+	EmailVerification string `json:"email_verification"`
+}
+
+// LoginResponse はログインのレスポンス
+type LoginResponse struct {
+	User        User   `json:"user"`
+	Reactivated bool   `json:"reactivated"` // 退会の取り消し期間中のログインでアカウントを戻した
+	CSRFToken   string `json:"csrf_token"`
+}
+
+// CSRFTokenResponse はCSRFトークンの取得のレスポンス
+type CSRFTokenResponse struct {
+	CSRFToken string     `json:"csrf_token"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// Register はユーザーを登録する（セッションが作られればそのままログインした状態になる）
+func (c *Client) Register(ctx context.Context, req RegisterRequest) (*RegisterResponse, error) {
+	var resp RegisterResponse
+	if err := c.call(ctx, &request{method: http.MethodPost, path: "/auth/register", body: req}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.CSRFToken != "" {
+		c.SetCSRFToken(resp.CSRFToken)
+	}
+	return &resp, nil
+}
+
+// Login はログインしてセッションとCSRFトークンを保持する
+func (c *Client) Login(ctx context.Context, username, password string) (*LoginResponse, error) {
+	var resp LoginResponse
+	body := map[string]string{"username": username, "password": password}
+	if err := c.call(ctx, &request{method: http.MethodPost, path: "/auth/login", body: body}, &resp); err != nil {
+		return nil, err
+	}
+	c.SetCSRFToken(resp.CSRFToken)
+	return &resp, nil
+}
+
+// Logout はログアウトしてCSRFトークンを破棄する
+func (c *Client) Logout(ctx context.Context) error {
+	if err := c.call(ctx, &request{method: http.MethodPost, path: "/auth/logout"}, nil); err != nil {
+		return err
+	}
+	c.SetCSRFToken("")
+	return nil
+}
+
+// Me はログイン中のユーザーを返す
+func (c *Client) Me(ctx context.Context) (*User, error) {
+	var resp struct {
+		User User `json:"user"`
+	}
+	if err := c.get(ctx, "/auth/me", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.User, nil
+}
+
+// RefreshCSRFToken はCSRFトークンを取得し直して保持する（期限切れなら入れ替わる）
+// 期限切れのエラーを受けたら自動で呼ぶため、通常は呼ばなくてよい
+func (c *Client) RefreshCSRFToken(ctx context.Context) (*CSRFTokenResponse, error) {
+	var resp CSRFTokenResponse
+	if err := c.get(ctx, csrfPath, nil, &resp); err != nil {
+		return nil, err
+	}
+	c.SetCSRFToken(resp.CSRFToken)
+	return &resp, nil
+}
@@ -0,0 +1,241 @@
+// Package client はポイントシステムのAPIを型付きのメソッドで呼ぶGoクライアント
+//
+// セッションCookieとCSRFトークンを保持し、状態を変えるリクエストにはIdempotency-Keyを付けて
+// 一時的な失敗（接続エラー・429・502〜504）を再送する。E2Eテスト・CLI・外部の連携から使う。
+//
+//	c := client.New("https://points.example.com")
+//	if _, err := c.Login(ctx, "alice", "password"); err != nil {
+//		return err
+//	}
+//	tx, err := c.Transfer(ctx, client.TransferRequest{ToUserID: bob, Amount: 100})
+//
+// エラーレスポンスは*APIErrorで返す（IsCode・StatusCodeOfで判定できる）。
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIPrefix はクライアントが呼ぶAPIバージョンのURLプレフィックス
+const APIPrefix = "/api/v1"
+
+const (
+	// CSRFTokenHeader はCSRFトークンを送受信するヘッダー（入れ替えたトークンもレスポンスのこのヘッダーで返る）
+	CSRFTokenHeader = "X-CSRF-Token"
+	// IdempotencyKeyHeader は再送制御に使うリクエストヘッダー
+	IdempotencyKeyHeader = "Idempotency-Key"
+)
+
+// defaultTimeout は1回のリクエストの既定のタイムアウト
+const defaultTimeout = 30 * time.Second
+
+// Client はポイントシステムのAPIクライアント
+// 1つのClientは1人の利用者のセッションを持つ。並行して呼んでもよい
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	retry      RetryPolicy
+	userAgent  string
+
+	mu   sync.Mutex
+	csrf string
+}
+
+// New は新しいClientを作成（baseURLはサーバーのオリジン。例: https://points.example.com）
+func New(baseURL string) *Client {
+	jar, _ := cookiejar.New(nil) // オプションなしでは失敗しない
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/") + APIPrefix,
+		httpClient: &http.Client{Jar: jar, Timeout: defaultTimeout},
+		retry:      DefaultRetryPolicy,
+	}
+}
+
+// WithHTTPClient は使うhttp.Clientを設定（Jarが無ければセッションCookieを保持するJarを付ける）
+func (c *Client) WithHTTPClient(httpClient *http.Client) *Client {
+	if httpClient.Jar == nil {
+		jar, _ := cookiejar.New(nil)
+		httpClient.Jar = jar
+	}
+	c.httpClient = httpClient
+	return c
+}
+
+// WithRetryPolicy は再送の方針を設定（NoRetryで再送しない）
+func (c *Client) WithRetryPolicy(policy RetryPolicy) *Client {
+	c.retry = policy
+	return c
+}
+
+// WithUserAgent はUser-Agentヘッダーを設定
+func (c *Client) WithUserAgent(userAgent string) *Client {
+	c.userAgent = userAgent
+	return c
+}
+
+// CSRFToken は保持しているCSRFトークン
+func (c *Client) CSRFToken() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.csrf
+}
+
+// SetCSRFToken はCSRFトークンを設定（ログイン以外でセッションを得た場合に使う）
+func (c *Client) SetCSRFToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.csrf = token
+}
+
+// request は1回のAPI呼び出し
+type request struct {
+	method string
+	path   string // APIPrefixからのパス（/points/transfer）
+	query  url.Values
+	body   any
+
+	// idempotencyKey は状態を変えるリクエストに付けるIdempotency-Key
+	// 空でなければ同じキーで再送しても二重に処理されないため、接続エラーやタイムアウトでも再送する
+	idempotencyKey string
+}
+
+// retryable はリクエストを再送してよいか
+// 状態を変えないリクエストと、サーバーが再送制御するリクエスト（Idempotency-Key付き）だけを再送する
+func (r *request) retryable() bool {
+	return r.method == http.MethodGet || r.method == http.MethodHead || r.idempotencyKey != ""
+}
+
+// Do はAPIを呼び、成功（2xx）したらレスポンスをoutに読み込む（outがnilなら読み捨てる）
+// 型付きのメソッドが無いAPIを呼ぶために公開している。pathはAPIPrefixからのパス
+// 失敗したら*APIErrorを返す
+func (c *Client) Do(ctx context.Context, method, path string, body, out any) error {
+	req := &request{method: method, path: path, body: body}
+	if method != http.MethodGet && method != http.MethodHead {
+		req.idempotencyKey = IdempotencyKeyFrom(ctx)
+	}
+	return c.call(ctx, req, out)
+}
+
+// call はリクエストを送り、RetryPolicyに従って再送する
+// CSRFトークンの期限切れ（csrf_token_expired）なら一度だけトークンを取り直して送り直す
+func (c *Client) call(ctx context.Context, req *request, out any) error {
+	var payload []byte
+	if req.body != nil {
+		var err error
+		if payload, err = json.Marshal(req.body); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	refreshed := false
+	for attempt := 1; ; attempt++ {
+		status, header, respBody, err := c.send(ctx, req, payload)
+		if err == nil && status >= 200 && status < 300 {
+			if out == nil || len(respBody) == 0 {
+				return nil
+			}
+			if err := json.Unmarshal(respBody, out); err != nil {
+				return fmt.Errorf("failed to decode response of %s %s: %w", req.method, req.path, err)
+			}
+			return nil
+		}
+
+		if err == nil {
+			apiErr := newAPIError(status, respBody)
+			if apiErr.Code == ErrCodeCSRFTokenExpired && !refreshed && req.path != csrfPath {
+				refreshed = true
+				if _, err := c.RefreshCSRFToken(ctx); err != nil {
+					return err
+				}
+				attempt--
+				continue
+			}
+			err = apiErr
+		}
+
+		if !req.retryable() || !c.retry.shouldRetry(attempt, status, err) || ctx.Err() != nil {
+			return err
+		}
+		if waitErr := sleep(ctx, c.retry.backoff(attempt, header)); waitErr != nil {
+			return err
+		}
+	}
+}
+
+// send はリクエストを1回送る。接続エラーならerrを返す（ステータスはerrがnilのときだけ有効）
+func (c *Client) send(ctx context.Context, req *request, payload []byte) (int, http.Header, []byte, error) {
+	target := c.baseURL + req.path
+	if len(req.query) > 0 {
+		target += "?" + req.query.Encode()
+	}
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, target, body)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if payload != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if token := c.CSRFToken(); token != "" {
+		httpReq.Header.Set(CSRFTokenHeader, token)
+	}
+	if req.idempotencyKey != "" {
+		httpReq.Header.Set(IdempotencyKeyHeader, req.idempotencyKey)
+	}
+	if c.userAgent != "" {
+		httpReq.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("%s %s: %w", req.method, req.path, err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to read response of %s %s: %w", req.method, req.path, err)
+	}
+
+	// 状態を変えるリクエストのたびにトークンを入れ替える設定では、次のトークンがヘッダーで返る
+	if token := resp.Header.Get(CSRFTokenHeader); token != "" {
+		c.SetCSRFToken(token)
+	}
+	return resp.StatusCode, resp.Header, respBody, nil
+}
+
+// sleep はdだけ待つ（ctxが終わったらctxのエラーを返す）
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// get はGETリクエストを送る
+func (c *Client) get(ctx context.Context, path string, query url.Values, out any) error {
+	return c.call(ctx, &request{method: http.MethodGet, path: path, query: query}, out)
+}
+
+// mutate は状態を変えるリクエストをIdempotency-Key付きで送る
+// キーはctxに設定されたもの（WithIdempotencyKey）、無ければ新しく作る
+func (c *Client) mutate(ctx context.Context, method, path string, body, out any) error {
+	return c.call(ctx, &request{method: method, path: path, body: body, idempotencyKey: idempotencyKeyOrNew(ctx)}, out)
+}
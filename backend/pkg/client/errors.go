@@ -0,0 +1,70 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// よく判定するエラーコード（サーバーのエラーコードの一部）
+const (
+	ErrCodeUnauthorized        = "unauthorized"
+	ErrCodeInvalidCSRFToken    = "invalid_csrf_token"
+	ErrCodeCSRFTokenExpired    = "csrf_token_expired"
+	ErrCodeValidationFailed    = "validation_failed"
+	ErrCodeInsufficientBalance = "insufficient_balance"
+	ErrCodeMaintenanceMode     = "maintenance_mode"
+)
+
+// FieldError は検証エラーの項目ごとの内容
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// APIError はAPIが返したエラー（RFC 7807のproblem+json）
+type APIError struct {
+	Status int            `json:"status"`
+	Code   string         `json:"error_code"`
+	Title  string         `json:"title"`
+	Detail string         `json:"detail"`
+	Type   string         `json:"type"`
+	Errors []FieldError   `json:"errors,omitempty"`
+	Params map[string]any `json:"params,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("api error (status %d): %s", e.Status, e.Detail)
+	}
+	return fmt.Sprintf("api error %s (status %d): %s", e.Code, e.Status, e.Detail)
+}
+
+// newAPIError はエラーレスポンスを*APIErrorにする（problem+jsonでなければ本文をDetailにする）
+func newAPIError(status int, body []byte) *APIError {
+	apiErr := &APIError{}
+	if err := json.Unmarshal(body, apiErr); err != nil || (apiErr.Code == "" && apiErr.Detail == "") {
+		apiErr = &APIError{Detail: string(body)}
+	}
+	apiErr.Status = status
+	if apiErr.Title == "" {
+		apiErr.Title = http.StatusText(status)
+	}
+	return apiErr
+}
+
+// IsCode はerrが指定したエラーコードの*APIErrorかを判定
+func IsCode(err error, code string) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
+// StatusCodeOf はerrが*APIErrorならそのステータスを返す（それ以外は0）
+func StatusCodeOf(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status
+	}
+	return 0
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// SendFriendRequest は友達申請を送る
+func (c *Client) SendFriendRequest(ctx context.Context, addresseeID uuid.UUID) (*Friendship, error) {
+	var resp struct {
+		Friendship Friendship `json:"friendship"`
+	}
+	if err := c.mutate(ctx, http.MethodPost, "/friends/requests", map[string]uuid.UUID{"addressee_id": addresseeID}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Friendship, nil
+}
+
+// AcceptFriendRequest は自分宛ての友達申請を承認する
+func (c *Client) AcceptFriendRequest(ctx context.Context, friendshipID uuid.UUID) error {
+	return c.mutate(ctx, http.MethodPost, "/friends/requests/"+friendshipID.String()+"/accept", nil, nil)
+}
+
+// RejectFriendRequest は自分宛ての友達申請を拒否する
+func (c *Client) RejectFriendRequest(ctx context.Context, friendshipID uuid.UUID) error {
+	return c.mutate(ctx, http.MethodPost, "/friends/requests/"+friendshipID.String()+"/reject", nil, nil)
+}
+
+// RemoveFriend は友達を解除する
+func (c *Client) RemoveFriend(ctx context.Context, friendshipID uuid.UUID) error {
+	return c.mutate(ctx, http.MethodDelete, "/friends/"+friendshipID.String(), nil, nil)
+}
+
+// Friends は友達一覧を返す
+func (c *Client) Friends(ctx context.Context) ([]Friend, error) {
+	var resp struct {
+		Friends []Friend `json:"friends"`
+	}
+	if err := c.get(ctx, "/friends", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Friends, nil
+}
+
+// PendingFriendRequests は自分宛ての保留中の友達申請を返す
+func (c *Client) PendingFriendRequests(ctx context.Context) ([]FriendRequest, error) {
+	var resp struct {
+		Requests []FriendRequest `json:"requests"`
+	}
+	if err := c.get(ctx, "/friends/requests", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Requests, nil
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type idempotencyKeyContextKey struct{}
+
+// NewIdempotencyKey は新しい冪等性キーを作成
+func NewIdempotencyKey() string {
+	return uuid.NewString()
+}
+
+// WithIdempotencyKey は状態を変えるリクエストに使う冪等性キーをctxに設定
+// 呼び出しが失敗した後に同じキーで呼び直せば、サーバーは二重に処理せずに前の結果を返す
+// （プロセスをまたいで送り直す場合は、キーを保存しておいて同じキーを設定する）
+//
+//	key := client.NewIdempotencyKey()
+//	tx, err := c.Transfer(client.WithIdempotencyKey(ctx, key), req)
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// IdempotencyKeyFrom はctxに設定された冪等性キー（無ければ空）
+func IdempotencyKeyFrom(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// idempotencyKeyOrNew はctxに設定された冪等性キー、無ければ新しいキー
+func idempotencyKeyOrNew(ctx context.Context) string {
+	if key := IdempotencyKeyFrom(ctx); key != "" {
+		return key
+	}
+	return NewIdempotencyKey()
+}
+
+// bodyIdempotencyKey はリクエストボディの冪等性キーを決める
+// 指定されていなければctxのキー（無ければ新しいキー）を使い、ヘッダーと同じ値にする
+func bodyIdempotencyKey(ctx context.Context, key string) (context.Context, string) {
+	if key == "" {
+		key = idempotencyKeyOrNew(ctx)
+	}
+	return WithIdempotencyKey(ctx, key), key
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransferRequest はポイント送金のリクエスト
+type TransferRequest struct {
+	ToUserID    uuid.UUID `json:"to_user_id"`
+	Amount      int64     `json:"amount"`
+	Description string    `json:"description,omitempty"`
+	// IdempotencyKey は送金の冪等性キー（空ならctxのキー、無ければ新しく作る）
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+// TransferResponse はポイント送金のレスポンス
+type TransferResponse struct {
+	Transaction Transaction `json:"transaction"`
+	Fee         int64       `json:"fee"`
+	NewBalance  int64       `json:"new_balance"`
+}
+
+// TransferQuote は送金の見積もり
+type TransferQuote struct {
+	Amount          int64  `json:"amount"`
+	Fee             int64  `json:"fee"`
+	Total           int64  `json:"total"`
+	FeeType         string `json:"fee_type"`
+	MinAmount       int64  `json:"min_amount"`
+	MaxAmount       int64  `json:"max_amount"`
+	Balance         int64  `json:"balance"`
+	SufficientFunds bool   `json:"sufficient_funds"`
+}
+
+// TransactionHistory は取引履歴
+type TransactionHistory struct {
+	Transactions []Transaction `json:"transactions"`
+	Total        int64         `json:"total"`
+}
+
+// ExpiringPoints は失効予定のポイント
+type ExpiringPoints struct {
+	Amount    int64     `json:"amount"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Transfer はポイントを送る
+func (c *Client) Transfer(ctx context.Context, req TransferRequest) (*TransferResponse, error) {
+	ctx, req.IdempotencyKey = bodyIdempotencyKey(ctx, req.IdempotencyKey)
+	var resp TransferResponse
+	if err := c.mutate(ctx, http.MethodPost, "/points/transfer", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// QuoteTransfer は送金前に手数料・合計額・残高が足りるかを見積もる
+func (c *Client) QuoteTransfer(ctx context.Context, amount int64) (*TransferQuote, error) {
+	var resp TransferQuote
+	query := url.Values{"amount": {strconv.FormatInt(amount, 10)}}
+	if err := c.get(ctx, "/points/transfer/quote", query, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Balance はログイン中のユーザーの残高を返す
+func (c *Client) Balance(ctx context.Context) (int64, error) {
+	var resp struct {
+		Balance int64 `json:"balance"`
+	}
+	if err := c.get(ctx, "/points/balance", nil, &resp); err != nil {
+		return 0, err
+	}
+	return resp.Balance, nil
+}
+
+// TransactionHistory は取引履歴を返す（reasonCodeが空なら全件）
+func (c *Client) TransactionHistory(ctx context.Context, page Page, reasonCode string) (*TransactionHistory, error) {
+	query := page.values()
+	if reasonCode != "" {
+		query.Set("reason_code", reasonCode)
+	}
+	var resp TransactionHistory
+	if err := c.get(ctx, "/points/history", query, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ExpiringPoints は失効予定のポイントを返す
+func (c *Client) ExpiringPoints(ctx context.Context) ([]ExpiringPoints, error) {
+	var resp struct {
+		ExpiringPoints []ExpiringPoints `json:"expiring_points"`
+	}
+	if err := c.get(ctx, "/points/expiring", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.ExpiringPoints, nil
+}
+
+// values は取得範囲をクエリにする
+func (p Page) values() url.Values {
+	query := url.Values{}
+	if p.Offset > 0 {
+		query.Set("offset", strconv.Itoa(p.Offset))
+	}
+	if p.Limit > 0 {
+		query.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return query
+}
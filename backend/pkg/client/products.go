@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+// ProductRequest は管理者による商品の作成・更新のリクエスト
+type ProductRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
+	Price       int64  `json:"price"`
+	Stock       int    `json:"stock"`
+	ImageURL    string `json:"image_url,omitempty"`
+	IsAvailable bool   `json:"is_available"`
+}
+
+// ExchangeRequest は商品交換のリクエスト
+type ExchangeRequest struct {
+	ProductID         uuid.UUID  `json:"product_id"`
+	Quantity          int        `json:"quantity"`
+	Notes             string     `json:"notes,omitempty"`
+	RecipientID       *uuid.UUID `json:"recipient_id,omitempty"` // 友達に贈る場合のみ
+	ShippingAddressID *uuid.UUID `json:"shipping_address_id,omitempty"`
+}
+
+// Products は商品一覧を返す（categoryが空なら全カテゴリ）
+func (c *Client) Products(ctx context.Context, category string) ([]Product, error) {
+	query := url.Values{}
+	setIfNotEmpty(query, "category", category)
+	var resp struct {
+		Products []Product `json:"Products"`
+	}
+	if err := c.get(ctx, "/products", query, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Products, nil
+}
+
+// ExchangeProduct は商品をポイントと交換する
+func (c *Client) ExchangeProduct(ctx context.Context, req ExchangeRequest) (*Exchange, error) {
+	var resp struct {
+		Exchange Exchange `json:"Exchange"`
+	}
+	if err := c.mutate(ctx, http.MethodPost, "/products/exchange", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Exchange, nil
+}
+
+// ExchangeHistory は自分の交換履歴を返す
+func (c *Client) ExchangeHistory(ctx context.Context, page Page) ([]Exchange, error) {
+	return c.listExchanges(ctx, "/products/exchanges/history", page)
+}
+
+// CreateProduct は管理者として商品を作成する
+func (c *Client) CreateProduct(ctx context.Context, req ProductRequest) (*Product, error) {
+	var resp struct {
+		Product Product `json:"Product"`
+	}
+	if err := c.mutate(ctx, http.MethodPost, "/admin/products", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Product, nil
+}
+
+// UpdateProduct は管理者として商品を更新する
+func (c *Client) UpdateProduct(ctx context.Context, id uuid.UUID, req ProductRequest) error {
+	return c.mutate(ctx, http.MethodPut, "/admin/products/"+id.String(), req, nil)
+}
+
+// DeleteProduct は管理者として商品を削除する
+func (c *Client) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	return c.mutate(ctx, http.MethodDelete, "/admin/products/"+id.String(), nil, nil)
+}
+
+// AllExchanges は管理者として全ユーザーの交換履歴を返す
+func (c *Client) AllExchanges(ctx context.Context, page Page) ([]Exchange, error) {
+	return c.listExchanges(ctx, "/admin/exchanges", page)
+}
+
+// MarkExchangeDelivered は管理者として交換を配達済みにする
+func (c *Client) MarkExchangeDelivered(ctx context.Context, exchangeID uuid.UUID) error {
+	return c.mutate(ctx, http.MethodPost, "/admin/exchanges/"+exchangeID.String()+"/deliver", nil, nil)
+}
+
+func (c *Client) listExchanges(ctx context.Context, path string, page Page) ([]Exchange, error) {
+	var resp struct {
+		Exchanges []Exchange `json:"Exchanges"`
+	}
+	if err := c.get(ctx, path, page.values(), &resp); err != nil {
+		return nil, err
+	}
+	return resp.Exchanges, nil
+}
+
+// setIfNotEmpty は値が空でなければクエリに設定する
+func setIfNotEmpty(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
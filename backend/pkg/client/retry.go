@@ -0,0 +1,69 @@
+package client
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy は一時的な失敗の再送の方針
+// 再送するのは接続エラーと、429・502・503・504のレスポンス
+// 状態を変えるリクエストはIdempotency-Keyを付けたものだけを再送する（サーバーが二重に処理しない）
+type RetryPolicy struct {
+	MaxAttempts    int           // 最初の送信を含めた送信回数の上限（1以下なら再送しない）
+	InitialBackoff time.Duration // 1回目の再送までの待ち時間（以降は倍にしていく）
+	MaxBackoff     time.Duration // 待ち時間の上限（Retry-Afterもこれで頭打ちにする）
+}
+
+var (
+	// DefaultRetryPolicy は既定の再送の方針
+	DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, InitialBackoff: 200 * time.Millisecond, MaxBackoff: 5 * time.Second}
+	// NoRetry は再送しない
+	NoRetry = RetryPolicy{MaxAttempts: 1}
+)
+
+// retryableStatuses は再送すると成功しうるステータス
+var retryableStatuses = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true, // メンテナンス中（Retry-After付き）
+	http.StatusGatewayTimeout:     true,
+}
+
+// shouldRetry はattempt回目の送信の結果を受けて再送するか
+func (p RetryPolicy) shouldRetry(attempt, status int, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return retryableStatuses[apiErr.Status]
+	}
+	return err != nil // 接続エラー
+}
+
+// backoff はattempt回目の送信の後に待つ時間
+// Retry-Afterが秒数で指定されていればそれに従い、無ければ指数的に伸ばして揺らぎを加える
+func (p RetryPolicy) backoff(attempt int, header http.Header) time.Duration {
+	if header != nil {
+		if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return p.cap(time.Duration(seconds) * time.Second)
+		}
+	}
+	d := p.InitialBackoff << (attempt - 1)
+	if d <= 0 {
+		return 0
+	}
+	// 同時に失敗したクライアントが一斉に再送しないよう、半分から全体の間で揺らす
+	d = d/2 + rand.N(d/2+1)
+	return p.cap(d)
+}
+
+func (p RetryPolicy) cap(d time.Duration) time.Duration {
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return d
+}
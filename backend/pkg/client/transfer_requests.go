@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// CreateTransferRequestRequest は送金リクエストの作成のリクエスト
+type CreateTransferRequestRequest struct {
+	ToUserID uuid.UUID `json:"to_user_id"`
+	Amount   int64     `json:"amount"`
+	Message  string    `json:"message,omitempty"`
+	// IdempotencyKey は作成の冪等性キー（空ならctxのキー、無ければ新しく作る）
+	// 同じキーで作成し直すと前に作成した送金リクエストが返る
+	IdempotencyKey string `json:"idempotency_key"`
+	// Hold は承認まで送信者の残高から金額を確保する
+	Hold bool `json:"hold,omitempty"`
+}
+
+// CreateTransferRequest は送金リクエストを作成する（受取人が承認するとポイントが移る）
+func (c *Client) CreateTransferRequest(ctx context.Context, req CreateTransferRequestRequest) (*TransferRequestDetail, error) {
+	ctx, req.IdempotencyKey = bodyIdempotencyKey(ctx, req.IdempotencyKey)
+	var resp TransferRequestDetail
+	if err := c.mutate(ctx, http.MethodPost, "/transfer-requests", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// TransferRequest は送金リクエストの詳細を返す
+func (c *Client) TransferRequest(ctx context.Context, id uuid.UUID) (*TransferRequestDetail, error) {
+	var resp TransferRequestDetail
+	if err := c.get(ctx, transferRequestPath(id, ""), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PendingTransferRequests は自分宛ての承認待ちの送金リクエストを返す
+func (c *Client) PendingTransferRequests(ctx context.Context, page Page) ([]TransferRequestDetail, error) {
+	return c.listTransferRequests(ctx, "/transfer-requests/pending", page)
+}
+
+// SentTransferRequests は自分が作成した送金リクエストを返す
+func (c *Client) SentTransferRequests(ctx context.Context, page Page) ([]TransferRequestDetail, error) {
+	return c.listTransferRequests(ctx, "/transfer-requests/sent", page)
+}
+
+// PendingTransferRequestCount は自分宛ての承認待ちの送金リクエストの件数を返す
+func (c *Client) PendingTransferRequestCount(ctx context.Context) (int64, error) {
+	var resp struct {
+		Count int64 `json:"count"`
+	}
+	if err := c.get(ctx, "/transfer-requests/pending/count", nil, &resp); err != nil {
+		return 0, err
+	}
+	return resp.Count, nil
+}
+
+// ApproveTransferRequest は受取人として送金リクエストを承認する（Transactionに取引が入る）
+func (c *Client) ApproveTransferRequest(ctx context.Context, id uuid.UUID) (*TransferRequestDetail, error) {
+	return c.actOnTransferRequest(ctx, id, "/approve", nil)
+}
+
+// RejectTransferRequest は受取人として送金リクエストを拒否する
+func (c *Client) RejectTransferRequest(ctx context.Context, id uuid.UUID) (*TransferRequestDetail, error) {
+	return c.actOnTransferRequest(ctx, id, "/reject", nil)
+}
+
+// CounterTransferRequest は受取人として金額を変えて提案し直す（送信者が確定すると送金される）
+func (c *Client) CounterTransferRequest(ctx context.Context, id uuid.UUID, amount int64) (*TransferRequestDetail, error) {
+	return c.actOnTransferRequest(ctx, id, "/counter", map[string]int64{"amount": amount})
+}
+
+// ConfirmCounterOffer は送信者として提案し直された金額で確定する
+func (c *Client) ConfirmCounterOffer(ctx context.Context, id uuid.UUID) (*TransferRequestDetail, error) {
+	return c.actOnTransferRequest(ctx, id, "/confirm", nil)
+}
+
+// CancelTransferRequest は送信者として送金リクエストを取り消す
+func (c *Client) CancelTransferRequest(ctx context.Context, id uuid.UUID) (*TransferRequestDetail, error) {
+	var resp TransferRequestDetail
+	if err := c.mutate(ctx, http.MethodDelete, transferRequestPath(id, ""), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) actOnTransferRequest(ctx context.Context, id uuid.UUID, action string, body any) (*TransferRequestDetail, error) {
+	var resp TransferRequestDetail
+	if err := c.mutate(ctx, http.MethodPost, transferRequestPath(id, action), body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) listTransferRequests(ctx context.Context, path string, page Page) ([]TransferRequestDetail, error) {
+	var resp struct {
+		Requests []TransferRequestDetail `json:"requests"`
+	}
+	if err := c.get(ctx, path, page.values(), &resp); err != nil {
+		return nil, err
+	}
+	return resp.Requests, nil
+}
+
+func transferRequestPath(id uuid.UUID, action string) string {
+	return "/transfer-requests/" + id.String() + action
+}
@@ -0,0 +1,164 @@
+package client
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// User はユーザー（レスポンスによって含まれる項目が異なる）
+type User struct {
+	ID           uuid.UUID  `json:"id"`
+	Username     string     `json:"username"`
+	Email        string     `json:"email,omitempty"`
+	DisplayName  string     `json:"display_name"`
+	FirstName    string     `json:"first_name,omitempty"`
+	LastName     string     `json:"last_name,omitempty"`
+	AvatarURL    *string    `json:"avatar_url,omitempty"`
+	Balance      int64      `json:"balance"`
+	Role         string     `json:"role"`
+	IsActive     bool       `json:"is_active"`
+	Tier         string     `json:"tier,omitempty"`
+	Status       string     `json:"status,omitempty"`
+	DepartmentID *uuid.UUID `json:"department_id,omitempty"`
+	IsDeleted    bool       `json:"is_deleted,omitempty"` // 退会済みユーザーの代わりの表示
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// Transaction は取引
+type Transaction struct {
+	ID              uuid.UUID  `json:"id"`
+	FromUserID      *uuid.UUID `json:"from_user_id"`
+	ToUserID        *uuid.UUID `json:"to_user_id"`
+	FromAccount     string     `json:"from_account,omitempty"` // 付与・減算・失効・手数料の相手のシステムの口座
+	ToAccount       string     `json:"to_account,omitempty"`
+	Amount          int64      `json:"amount"`
+	TransactionType string     `json:"transaction_type"`
+	Status          string     `json:"status"`
+	Description     string     `json:"description"`
+	ReasonCode      string     `json:"reason_code"`
+	Tag             string     `json:"tag"`
+	Migrated        bool       `json:"migrated,omitempty"`
+	FromUser        *User      `json:"from_user,omitempty"`
+	ToUser          *User      `json:"to_user,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// QRCode はQRコード
+type QRCode struct {
+	ID           uuid.UUID  `json:"id"`
+	UserID       uuid.UUID  `json:"user_id"`
+	Code         string     `json:"code"`
+	QRType       string     `json:"qr_type"`
+	Amount       *int64     `json:"amount,omitempty"`
+	IsUsed       bool       `json:"is_used"`
+	UsedByUserID *uuid.UUID `json:"used_by_user_id,omitempty"`
+	UsedAt       *time.Time `json:"used_at,omitempty"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// TransferRequestInfo は送金リクエスト
+type TransferRequestInfo struct {
+	ID            uuid.UUID  `json:"id"`
+	FromUserID    uuid.UUID  `json:"from_user_id"`
+	ToUserID      uuid.UUID  `json:"to_user_id"`
+	Amount        int64      `json:"amount"`
+	Message       string     `json:"message"`
+	Status        string     `json:"status"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	ApprovedAt    *time.Time `json:"approved_at,omitempty"`
+	RejectedAt    *time.Time `json:"rejected_at,omitempty"`
+	CancelledAt   *time.Time `json:"cancelled_at,omitempty"`
+	TransactionID *uuid.UUID `json:"transaction_id,omitempty"`
+	CounterAmount *int64     `json:"counter_amount,omitempty"`
+	CounteredAt   *time.Time `json:"countered_at,omitempty"`
+	FinalAmount   int64      `json:"final_amount"`
+	LastActedBy   uuid.UUID  `json:"last_acted_by"`
+	LastActedRole string     `json:"last_acted_role"` // "sender" または "receiver"
+	SplitID       *uuid.UUID `json:"split_id,omitempty"`
+	Hold          bool       `json:"hold"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// TransferRequestDetail は送金リクエストと送信者・受取人
+type TransferRequestDetail struct {
+	TransferRequest TransferRequestInfo `json:"transfer_request"`
+	Transaction     *Transaction        `json:"transaction,omitempty"` // 承認・確定したときの取引
+	FromUser        User                `json:"from_user"`
+	ToUser          User                `json:"to_user"`
+}
+
+// Friendship は友達関係
+type Friendship struct {
+	ID          uuid.UUID `json:"id"`
+	RequesterID uuid.UUID `json:"requester_id"`
+	AddresseeID uuid.UUID `json:"addressee_id"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Friend は友達一覧の1件
+type Friend struct {
+	Friendship      Friendship `json:"friendship"`
+	Friend          User       `json:"friend"`
+	Since           time.Time  `json:"since"`
+	PointsExchanged struct {
+		Sent     int64 `json:"sent"`
+		Received int64 `json:"received"`
+		Total    int64 `json:"total"`
+	} `json:"points_exchanged"`
+}
+
+// FriendRequest は保留中の友達申請の1件
+type FriendRequest struct {
+	Friendship Friendship `json:"friendship"`
+	Requester  User       `json:"requester"`
+}
+
+// Product は商品（レスポンスのキーはサーバーのエンティティのフィールド名）
+type Product struct {
+	ID          uuid.UUID `json:"ID"`
+	Name        string    `json:"Name"`
+	Description string    `json:"Description"`
+	Category    string    `json:"Category"`
+	Price       int64     `json:"Price"`
+	Stock       int       `json:"Stock"`
+	ImageURL    string    `json:"ImageURL"`
+	IsAvailable bool      `json:"IsAvailable"`
+}
+
+// Exchange は商品交換（レスポンスのキーはサーバーのエンティティのフィールド名）
+type Exchange struct {
+	ID         uuid.UUID `json:"ID"`
+	UserID     uuid.UUID `json:"UserID"`
+	ProductID  uuid.UUID `json:"ProductID"`
+	Quantity   int       `json:"Quantity"`
+	PointsUsed int64     `json:"PointsUsed"`
+	Status     string    `json:"Status"`
+	Notes      string    `json:"Notes"`
+}
+
+// PendingAdminAction は二人目の管理者の承認を待っている付与・減算
+type PendingAdminAction struct {
+	ID           uuid.UUID  `json:"id"`
+	ActionType   string     `json:"action_type"`
+	RequestedBy  uuid.UUID  `json:"requested_by"`
+	TargetUserID uuid.UUID  `json:"target_user_id"`
+	Amount       int64      `json:"amount"`
+	Description  string     `json:"description"`
+	ReasonCode   string     `json:"reason_code"`
+	Status       string     `json:"status"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	ReviewedBy   *uuid.UUID `json:"reviewed_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// Page は一覧の取得範囲（ゼロ値ならサーバーの既定）
+type Page struct {
+	Offset int
+	Limit  int
+}
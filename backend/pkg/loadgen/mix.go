@@ -0,0 +1,84 @@
+// Package loadgen は負荷試験（cmd/loadgen）の操作の配分と応答時間の集計
+//
+// 操作の中身（APIの呼び出し）はcmd/loadgenにあり、ここではどの操作をどの割合で行うか（Mix）と
+// 操作ごとの結果・応答時間のパーセンタイル（Recorder）だけを扱う。
+package loadgen
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Mix は操作ごとの重み（重みの比で操作を選ぶ）
+type Mix struct {
+	ops     []string
+	weights []int
+	total   int
+}
+
+// ParseMix は "register=5,transfer=50,request=25,qr=20" の形式の配分を読む
+// knownにない操作・重複・0以下の重みはエラー。重み0の操作は書かなければ行わない
+func ParseMix(s string, known []string) (*Mix, error) {
+	m := &Mix{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		op, value, ok := strings.Cut(part, "=")
+		op = strings.TrimSpace(op)
+		if !ok {
+			return nil, fmt.Errorf("mix: %q must be op=weight", part)
+		}
+		if !slices.Contains(known, op) {
+			return nil, fmt.Errorf("mix: unknown operation %q (expected one of %s)", op, strings.Join(known, ", "))
+		}
+		if slices.Contains(m.ops, op) {
+			return nil, fmt.Errorf("mix: %s is specified more than once", op)
+		}
+		weight, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || weight <= 0 {
+			return nil, fmt.Errorf("mix: weight of %s must be a positive integer (got %q)", op, value)
+		}
+		m.ops = append(m.ops, op)
+		m.weights = append(m.weights, weight)
+		m.total += weight
+	}
+	if m.total == 0 {
+		return nil, fmt.Errorf("mix: at least one operation is required")
+	}
+	return m, nil
+}
+
+// Pick は重みに比例して操作を選ぶ
+func (m *Mix) Pick(r *rand.Rand) string {
+	n := r.IntN(m.total)
+	for i, w := range m.weights {
+		if n < w {
+			return m.ops[i]
+		}
+		n -= w
+	}
+	return m.ops[len(m.ops)-1] // 到達しない
+}
+
+// Share は操作が選ばれる割合（0〜1。配分にない操作は0）
+func (m *Mix) Share(op string) float64 {
+	i := slices.Index(m.ops, op)
+	if i < 0 {
+		return 0
+	}
+	return float64(m.weights[i]) / float64(m.total)
+}
+
+// String は配分を "op=weight,..." の形式で返す
+func (m *Mix) String() string {
+	parts := make([]string, len(m.ops))
+	for i, op := range m.ops {
+		parts[i] = fmt.Sprintf("%s=%d", op, m.weights[i])
+	}
+	return strings.Join(parts, ",")
+}
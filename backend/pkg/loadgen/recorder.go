@@ -0,0 +1,101 @@
+package loadgen
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Outcome は1回の呼び出しの結果
+type Outcome int
+
+const (
+	OutcomeOK       Outcome = iota // 成功
+	OutcomeRejected                // サーバーが4xxで断った（残高不足など、負荷をかけていれば起きうる結果）
+	OutcomeFailed                  // 5xx・接続エラー・タイムアウト（処理されたかどうか分からない）
+)
+
+// OpStats は操作ごとの集計
+type OpStats struct {
+	Op       string
+	Count    int
+	OK       int
+	Rejected int
+	Failed   int
+	// P50・P90・P99・Max は応答時間（断られた・失敗した呼び出しも含む）
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+	Max time.Duration
+	// Reasons は断られた・失敗した理由（エラーコード）ごとの回数
+	Reasons map[string]int
+}
+
+// Recorder は操作ごとの結果と応答時間を集める。並行して呼んでよい
+type Recorder struct {
+	mu  sync.Mutex
+	ops map[string]*samples
+}
+
+type samples struct {
+	latencies []time.Duration
+	outcomes  [OutcomeFailed + 1]int
+	reasons   map[string]int
+}
+
+// NewRecorder は新しいRecorderを作成
+func NewRecorder() *Recorder {
+	return &Recorder{ops: make(map[string]*samples)}
+}
+
+// Record は1回の呼び出しを記録する（reasonは断られた・失敗した理由。成功なら空）
+func (r *Recorder) Record(op string, latency time.Duration, outcome Outcome, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.ops[op]
+	if !ok {
+		s = &samples{reasons: make(map[string]int)}
+		r.ops[op] = s
+	}
+	s.latencies = append(s.latencies, latency)
+	s.outcomes[outcome]++
+	if outcome != OutcomeOK && reason != "" {
+		s.reasons[reason]++
+	}
+}
+
+// Stats は操作の名前順に集計を返す
+func (r *Recorder) Stats() []OpStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := make([]OpStats, 0, len(r.ops))
+	for _, op := range slices.Sorted(maps.Keys(r.ops)) {
+		s := r.ops[op]
+		sorted := slices.Clone(s.latencies)
+		slices.Sort(sorted)
+		stats = append(stats, OpStats{
+			Op:       op,
+			Count:    len(sorted),
+			OK:       s.outcomes[OutcomeOK],
+			Rejected: s.outcomes[OutcomeRejected],
+			Failed:   s.outcomes[OutcomeFailed],
+			P50:      Percentile(sorted, 50),
+			P90:      Percentile(sorted, 90),
+			P99:      Percentile(sorted, 99),
+			Max:      Percentile(sorted, 100),
+			Reasons:  maps.Clone(s.reasons),
+		})
+	}
+	return stats
+}
+
+// Percentile は昇順に並んだ応答時間のpパーセンタイル（nearest-rank法。空なら0）
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
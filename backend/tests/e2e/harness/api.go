@@ -0,0 +1,217 @@
+package harness
+
+import (
+	"github.com/gity/point-system/pkg/client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// DefaultPassword はNewUserで登録するユーザーのパスワード
+const DefaultPassword = "TestPass123"
+
+// GrantReasonCode は付与に使う理由コード（マイグレーションで作成される）
+const GrantReasonCode = "other"
+
+// ========================================
+// 認証
+// ========================================
+
+// Register はユーザーを登録する
+func (c *Client) Register(req client.RegisterRequest) client.User {
+	c.t.Helper()
+	resp, err := c.API.Register(c.ctx(), req)
+	c.must(err, "register")
+	return resp.User
+}
+
+// Login はログインする
+func (c *Client) Login(username, password string) client.User {
+	c.t.Helper()
+	resp, err := c.API.Login(c.ctx(), username, password)
+	c.must(err, "login")
+	c.User = resp.User
+	return resp.User
+}
+
+// ========================================
+// ポイント
+// ========================================
+
+// Balance はログイン中のユーザーの残高を返す
+func (c *Client) Balance() int64 {
+	c.t.Helper()
+	balance, err := c.API.Balance(c.ctx())
+	c.must(err, "balance")
+	return balance
+}
+
+// Transfer はポイントを送る
+func (c *Client) Transfer(to uuid.UUID, amount int64, description string) *client.TransferResponse {
+	c.t.Helper()
+	resp, err := c.API.Transfer(c.ctx(), client.TransferRequest{ToUserID: to, Amount: amount, Description: description})
+	c.must(err, "transfer")
+	return resp
+}
+
+// GrantPoints は管理者としてユーザーにポイントを付与する
+func (c *Client) GrantPoints(userID uuid.UUID, amount int64) {
+	c.t.Helper()
+	resp, err := c.API.GrantPoints(c.ctx(), client.AdjustPointsRequest{
+		UserID:      userID,
+		Amount:      amount,
+		Description: "E2Eテスト用の付与",
+		ReasonCode:  GrantReasonCode,
+	})
+	c.must(err, "grant points")
+	require.False(c.t, resp.RequiresApproval, "付与が承認待ちになった（承認の閾値が設定されている）")
+}
+
+// ========================================
+// 友達
+// ========================================
+
+// SendFriendRequest は友達申請を送り、友達関係のIDを返す
+func (c *Client) SendFriendRequest(addresseeID uuid.UUID) uuid.UUID {
+	c.t.Helper()
+	friendship, err := c.API.SendFriendRequest(c.ctx(), addresseeID)
+	c.must(err, "send friend request")
+	return friendship.ID
+}
+
+// AcceptFriendRequest は友達申請を承認する
+func (c *Client) AcceptFriendRequest(friendshipID uuid.UUID) {
+	c.t.Helper()
+	c.must(c.API.AcceptFriendRequest(c.ctx(), friendshipID), "accept friend request")
+}
+
+// RejectFriendRequest は友達申請を拒否する
+func (c *Client) RejectFriendRequest(friendshipID uuid.UUID) {
+	c.t.Helper()
+	c.must(c.API.RejectFriendRequest(c.ctx(), friendshipID), "reject friend request")
+}
+
+// RemoveFriend は友達を解除する
+func (c *Client) RemoveFriend(friendshipID uuid.UUID) {
+	c.t.Helper()
+	c.must(c.API.RemoveFriend(c.ctx(), friendshipID), "remove friend")
+}
+
+// Friends は友達一覧を返す
+func (c *Client) Friends() []client.Friend {
+	c.t.Helper()
+	friends, err := c.API.Friends(c.ctx())
+	c.must(err, "friends")
+	return friends
+}
+
+// PendingFriendRequests は自分宛ての保留中の友達申請を返す
+func (c *Client) PendingFriendRequests() []client.FriendRequest {
+	c.t.Helper()
+	requests, err := c.API.PendingFriendRequests(c.ctx())
+	c.must(err, "pending friend requests")
+	return requests
+}
+
+// ========================================
+// 送金リクエスト
+// ========================================
+
+// CreateTransferRequest は送金リクエストを作成し、IDを返す
+func (c *Client) CreateTransferRequest(req client.CreateTransferRequestRequest) uuid.UUID {
+	c.t.Helper()
+	resp, err := c.API.CreateTransferRequest(c.ctx(), req)
+	c.must(err, "create transfer request")
+	return resp.TransferRequest.ID
+}
+
+// ApproveTransferRequest は送金リクエストを承認する
+func (c *Client) ApproveTransferRequest(id uuid.UUID) *client.TransferRequestDetail {
+	c.t.Helper()
+	resp, err := c.API.ApproveTransferRequest(c.ctx(), id)
+	c.must(err, "approve transfer request")
+	return resp
+}
+
+// RejectTransferRequest は送金リクエストを拒否する
+func (c *Client) RejectTransferRequest(id uuid.UUID) {
+	c.t.Helper()
+	_, err := c.API.RejectTransferRequest(c.ctx(), id)
+	c.must(err, "reject transfer request")
+}
+
+// CancelTransferRequest は自分が作成した送金リクエストを取り消す
+func (c *Client) CancelTransferRequest(id uuid.UUID) {
+	c.t.Helper()
+	_, err := c.API.CancelTransferRequest(c.ctx(), id)
+	c.must(err, "cancel transfer request")
+}
+
+// PendingTransferRequestCount は自分宛ての承認待ちの送金リクエストの件数を返す
+func (c *Client) PendingTransferRequestCount() int64 {
+	c.t.Helper()
+	count, err := c.API.PendingTransferRequestCount(c.ctx())
+	c.must(err, "pending transfer request count")
+	return count
+}
+
+// ========================================
+// 商品交換
+// ========================================
+
+// CreateProduct は管理者として商品を作成する
+func (c *Client) CreateProduct(req client.ProductRequest) *client.Product {
+	c.t.Helper()
+	product, err := c.API.CreateProduct(c.ctx(), req)
+	c.must(err, "create product")
+	return product
+}
+
+// UpdateProduct は管理者として商品を更新する
+func (c *Client) UpdateProduct(id uuid.UUID, req client.ProductRequest) {
+	c.t.Helper()
+	c.must(c.API.UpdateProduct(c.ctx(), id, req), "update product")
+}
+
+// DeleteProduct は管理者として商品を削除する
+func (c *Client) DeleteProduct(id uuid.UUID) {
+	c.t.Helper()
+	c.must(c.API.DeleteProduct(c.ctx(), id), "delete product")
+}
+
+// Products は商品一覧を返す（categoryが空なら全カテゴリ）
+func (c *Client) Products(category string) []client.Product {
+	c.t.Helper()
+	products, err := c.API.Products(c.ctx(), category)
+	c.must(err, "products")
+	return products
+}
+
+// ExchangeProduct は商品をポイントと交換する
+func (c *Client) ExchangeProduct(productID uuid.UUID, quantity int, notes string) *client.Exchange {
+	c.t.Helper()
+	exchange, err := c.API.ExchangeProduct(c.ctx(), client.ExchangeRequest{ProductID: productID, Quantity: quantity, Notes: notes})
+	c.must(err, "exchange product")
+	return exchange
+}
+
+// ExchangeHistory は自分の交換履歴を返す
+func (c *Client) ExchangeHistory() []client.Exchange {
+	c.t.Helper()
+	exchanges, err := c.API.ExchangeHistory(c.ctx(), client.Page{})
+	c.must(err, "exchange history")
+	return exchanges
+}
+
+// AllExchanges は管理者として全ユーザーの交換履歴を返す
+func (c *Client) AllExchanges() []client.Exchange {
+	c.t.Helper()
+	exchanges, err := c.API.AllExchanges(c.ctx(), client.Page{})
+	c.must(err, "all exchanges")
+	return exchanges
+}
+
+// MarkDelivered は管理者として交換を配達済みにする
+func (c *Client) MarkDelivered(exchangeID uuid.UUID) {
+	c.t.Helper()
+	c.must(c.API.MarkExchangeDelivered(c.ctx(), exchangeID), "mark delivered")
+}
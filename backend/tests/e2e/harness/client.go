@@ -0,0 +1,75 @@
+package harness
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gity/point-system/pkg/client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// Client はテストから1人の利用者としてAPIを呼ぶクライアント
+// pkg/clientの呼び出しを、失敗したらテストを失敗させる形で包む
+// エラーのレスポンスを確かめるテストはAPIから直接呼ぶ（ゴルーチンから呼ぶ場合も同様）
+type Client struct {
+	// API はpkg/clientのクライアント（セッションとCSRFトークンを保持している）
+	API *client.Client
+	// User はログイン中のユーザー（ログイン前はゼロ値）
+	User client.User
+
+	t testing.TB
+}
+
+// NewClient はログインしていないクライアントを作成
+func (e *Env) NewClient(t testing.TB) *Client {
+	return &Client{API: client.New(e.BaseURL).WithRetryPolicy(client.NoRetry), t: t}
+}
+
+// Login は管理者など既存のユーザーでログインしたクライアントを作成
+func (e *Env) Login(t testing.TB, username, password string) *Client {
+	t.Helper()
+	c := e.NewClient(t)
+	c.Login(username, password)
+	return c
+}
+
+// Admin はマイグレーションで作成された管理者でログインしたクライアントを作成
+func (e *Env) Admin(t testing.TB) *Client {
+	t.Helper()
+	return e.Login(t, SeededAdminUsername, SeededAdminPassword)
+}
+
+// NewUser は新しいユーザーを登録してログインし、balanceポイントを管理者から付与したクライアントを作成
+// ユーザー名はテストの間で重ならないように生成する（初期残高の決まったユーザーを前提にしない）
+func (e *Env) NewUser(t testing.TB, balance int64) *Client {
+	t.Helper()
+	username := fmt.Sprintf("e2e_%d_%s", e.userSeq.Add(1), uuid.NewString()[:8])
+	c := e.NewClient(t)
+	c.Register(client.RegisterRequest{
+		Username:    username,
+		Email:       username + "@example.com",
+		Password:    DefaultPassword,
+		DisplayName: username,
+		FirstName:   "太郎",
+		LastName:    "テスト",
+	})
+	c.Login(username, DefaultPassword)
+
+	if topUp := balance - c.Balance(); topUp > 0 {
+		e.Admin(t).GrantPoints(c.User.ID, topUp)
+	}
+	return c
+}
+
+// must は呼び出しが失敗したらテストを失敗させる
+func (c *Client) must(err error, call string) {
+	c.t.Helper()
+	require.NoError(c.t, err, call)
+}
+
+// ctx はテストの間だけ有効なコンテキスト
+func (c *Client) ctx() context.Context {
+	return c.t.Context()
+}
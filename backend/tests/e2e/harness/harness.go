@@ -0,0 +1,182 @@
+// Package harness はE2Eテスト用の環境を起動する
+//
+// PostgreSQLをtestcontainersで起動し、Wireで組み立てたルーターをテストのプロセス内（httptest.Server）で動かす。
+// 起動時にマイグレーション（初期データを含む）を適用するため、起動済みのサーバーやDBを前提にしない。
+// ワーカーは起動しない（APIの呼び出しだけを確かめる）。
+//
+//	var env *harness.Env
+//
+//	func TestMain(m *testing.M) {
+//		var err error
+//		env, err = harness.Start(context.Background())
+//		if err != nil {
+//			log.Fatalf("failed to start e2e environment: %v", err)
+//		}
+//		code := m.Run()
+//		env.Close()
+//		os.Exit(code)
+//	}
+package harness
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/gity/point-system/cmd/clean_server/app"
+	"github.com/gity/point-system/config"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// SeededAdminUsername・SeededAdminPassword はマイグレーションで作成される管理者
+	SeededAdminUsername = "admin"
+	SeededAdminPassword = "admin123"
+
+	// startupTimeout はPostgreSQLの起動を待つ時間
+	startupTimeout = 60 * time.Second
+)
+
+// Env はE2Eテスト用に起動したPostgreSQLとサーバー
+type Env struct {
+	// BaseURL はサーバーのオリジン（http://127.0.0.1:<port>。pkg/clientにそのまま渡せる）
+	BaseURL string
+
+	container *postgres.PostgresContainer
+	app       *app.AppContainer
+	server    *httptest.Server
+	workDir   string
+	prevDir   string
+	userSeq   atomic.Int64
+}
+
+// Start はPostgreSQLのコンテナとサーバーを起動する
+// 途中で失敗した場合は起動したものを片付けてからエラーを返す
+func Start(ctx context.Context) (*Env, error) {
+	workDir, err := os.MkdirTemp("", "point-system-e2e-")
+	if err != nil {
+		return nil, err
+	}
+	env := &Env{workDir: workDir}
+
+	if err := env.start(ctx); err != nil {
+		env.Close()
+		return nil, err
+	}
+	return env, nil
+}
+
+func (e *Env) start(ctx context.Context) error {
+	var err error
+	e.container, err = postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("e2e_db"),
+		postgres.WithUsername("e2e"),
+		postgres.WithPassword("e2e"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(startupTimeout),
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start postgres container: %w", err)
+	}
+	dbHost, err := e.container.Host(ctx)
+	if err != nil {
+		return err
+	}
+	dbPort, err := e.container.MappedPort(ctx, "5432/tcp")
+	if err != nil {
+		return err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return err
+	}
+
+	// 開発者の環境変数・設定ファイルに左右されないよう、接続先と秘密情報はすべて指定する
+	if err := setEnv(map[string]string{
+		"CONFIG_FILE":               "",
+		"SECRETS_DIR":               filepath.Join(e.workDir, "secrets"),
+		"ENV":                       config.EnvDevelopment,
+		"DB_DRIVER":                 config.DriverPostgres,
+		"DB_HOST":                   dbHost,
+		"DB_PORT":                   dbPort.Port(),
+		"DB_USER":                   "e2e",
+		"DB_PASSWORD":               "e2e",
+		"DB_NAME":                   "e2e_db",
+		"DB_SSL_MODE":               "disable",
+		"SESSION_SECRET":            secret,
+		"AKERUN_ACCESS_TOKEN":       "",
+		"AKERUN_ORGANIZATIONS_FILE": "",
+	}); err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// アップロード・エクスポートのファイルは作業ディレクトリからの相対パスに置かれるため、一時ディレクトリで動かす
+	if e.prevDir, err = os.Getwd(); err != nil {
+		return err
+	}
+	if err := os.Chdir(e.workDir); err != nil {
+		return err
+	}
+
+	if e.app, err = app.InitializeApp(cfg); err != nil {
+		return fmt.Errorf("failed to initialize app: %w", err)
+	}
+	if err := app.PrepareSchema(cfg, e.app.DB, true); err != nil {
+		return fmt.Errorf("failed to prepare schema: %w", err)
+	}
+
+	e.server = httptest.NewServer(e.app.Router.GetEngine())
+	e.BaseURL = e.server.URL
+	return nil
+}
+
+// Close はサーバーとPostgreSQLのコンテナを止め、作業用のディレクトリを削除する
+func (e *Env) Close() {
+	if e.server != nil {
+		e.server.Close()
+	}
+	if e.app != nil && e.app.DB != nil {
+		e.app.DB.Close()
+	}
+	if e.container != nil {
+		_ = e.container.Terminate(context.Background())
+	}
+	if e.prevDir != "" {
+		_ = os.Chdir(e.prevDir)
+	}
+	_ = os.RemoveAll(e.workDir)
+}
+
+// setEnv はconfig.Loadが読む環境変数を設定する
+func setEnv(vars map[string]string) error {
+	for key, value := range vars {
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,29 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/gity/point-system/tests/e2e/harness"
+)
+
+// env はテスト全体で共有する環境（PostgreSQLとサーバーはパッケージで1つだけ起動する）
+// テストごとにharness.Env.NewUserでユーザーを作るため、テストの間で状態を共有しない
+var env *harness.Env
+
+func TestMain(m *testing.M) {
+	var err error
+	env, err = harness.Start(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start e2e environment: %v\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	env.Close()
+	os.Exit(code)
+}
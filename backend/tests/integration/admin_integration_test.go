@@ -23,7 +23,7 @@ func setupAdmin(t *testing.T) (inputport.AdminInputPort, infrapostgres.DB) {
 	txManager := infrapostgres.NewGormTransactionManager(db.GetDB())
 
 	admin := interactor.NewAdminInteractor(
-		txManager, repos.User, repos.Transaction, repos.IdempotencyKey, repos.PointBatch, repos.Analytics, lg,
+		txManager, repos.User, repos.Transaction, repos.IdempotencyKey, repos.PointBatch, repos.ReasonCode, repos.Department, repos.Budget, repos.PendingAdminAction, repos.SystemSettings, repos.Analytics, &mockNotificationDispatcher{}, lg,
 	)
 	return admin, db
 }
@@ -41,12 +41,16 @@ func TestAdmin_GrantPoints(t *testing.T) {
 		UserID:         targetUser.ID,
 		Amount:         500,
 		Description:    "integration test grant",
+		ReasonCode:     "reward",
+		Tag:            "integration",
 		IdempotencyKey: "integ-admin-grant-001",
 	})
 	require.NoError(t, err)
 	require.NotNil(t, resp)
 	assert.Equal(t, int64(500), resp.User.Balance)
 	assert.Equal(t, int64(500), resp.Transaction.Amount)
+	assert.Equal(t, "reward", resp.Transaction.ReasonCode)
+	assert.Equal(t, "integration", resp.Transaction.Tag)
 }
 
 // TestAdmin_DeductPoints はポイント減算を検証
@@ -62,6 +66,7 @@ func TestAdmin_DeductPoints(t *testing.T) {
 		UserID:         targetUser.ID,
 		Amount:         300,
 		Description:    "integration test deduct",
+		ReasonCode:     "correction",
 		IdempotencyKey: "integ-admin-deduct-001",
 	})
 	require.NoError(t, err)
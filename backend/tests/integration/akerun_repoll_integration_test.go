@@ -0,0 +1,66 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAkerunRepollRepository は再取得の依頼の保存・進み具合の更新と、同時に1件までの制約を検証
+func TestAkerunRepollRepository(t *testing.T) {
+	db := setupIntegrationDB(t)
+	repos := setupAllRepos(db, newTestLogger(t))
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	repoll, err := entities.NewAkerunRepoll("org-1", now.Add(-3*time.Hour), now.Add(-time.Hour), uuid.New(), now)
+	require.NoError(t, err)
+	require.NoError(t, repos.AkerunRepoll.Create(ctx, repoll))
+
+	// 処理待ち・取得中の依頼は同時に1件まで（部分一意インデックス）
+	other, err := entities.NewAkerunRepoll("", now.Add(-3*time.Hour), now.Add(-time.Hour), uuid.New(), now)
+	require.NoError(t, err)
+	assert.Error(t, repos.AkerunRepoll.Create(ctx, other))
+
+	active, err := repos.AkerunRepoll.ReadActive(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, active)
+	assert.Equal(t, repoll.ID, active.ID)
+	assert.Equal(t, 2, active.TotalWindows)
+
+	active.Start(now)
+	active.RecordWindow(7)
+	require.NoError(t, repos.AkerunRepoll.Update(ctx, active))
+	stored, err := repos.AkerunRepoll.Read(ctx, repoll.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.AkerunRepollStatusRunning, stored.Status)
+	assert.Equal(t, 1, stored.CompletedWindows)
+	assert.Equal(t, 7, stored.FetchedAccesses)
+	require.NotNil(t, stored.StartedAt)
+
+	stored.MarkSucceeded(now)
+	require.NoError(t, repos.AkerunRepoll.Update(ctx, stored))
+	active, err = repos.AkerunRepoll.ReadActive(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, active)
+
+	// 完了すれば次の依頼を作成できる
+	require.NoError(t, repos.AkerunRepoll.Create(ctx, other))
+	list, err := repos.AkerunRepoll.ReadList(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+	count, err := repos.AkerunRepoll.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	_, err = repos.AkerunRepoll.Read(ctx, uuid.New())
+	assert.ErrorIs(t, err, entities.ErrAkerunRepollNotFound)
+}
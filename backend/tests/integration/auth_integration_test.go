@@ -21,7 +21,7 @@ func setupAuth(t *testing.T) (inputport.AuthInputPort, infrapostgres.DB) {
 	repos := setupAllRepos(db, lg)
 	pwdSvc := &mockPasswordService{}
 
-	auth := interactor.NewAuthInteractor(repos.User, repos.Session, pwdSvc, lg)
+	auth := interactor.NewAuthInteractor(repos.User, repos.Session, repos.LoginAttempt, pwdSvc, &mockEmailService{}, &mockNotificationDispatcher{}, &mockReferralTracker{}, &mockOnboardingBonusGranter{}, &mockEmailVerificationGate{}, lg)
 	return auth, db
 }
 
@@ -179,3 +179,67 @@ func TestAuth_DuplicateUsername(t *testing.T) {
 	_, err = auth.Register(ctx, req)
 	assert.Error(t, err)
 }
+
+// TestAuth_RevokeSessions はセッション一覧・個別失効・他セッション一括失効を検証
+func TestAuth_RevokeSessions(t *testing.T) {
+	auth, db := setupAuth(t)
+	ctx := context.Background()
+	lg := newTestLogger(t)
+	repos := setupAllRepos(db, lg)
+	sessions := interactor.NewSessionInteractor(repos.Session, repos.User, lg)
+
+	regResp, err := auth.Register(ctx, &inputport.RegisterRequest{
+		Username:    "integ_revoke_user",
+		Email:       "integ_revoke@test.com",
+		Password:    "password123",
+		DisplayName: "Revoke User",
+		FirstName:   "Test",
+		LastName:    "User",
+	})
+	require.NoError(t, err)
+
+	var others []string
+	for _, ua := range []string{"agent-a", "agent-b"} {
+		loginResp, err := auth.Login(ctx, &inputport.LoginRequest{
+			Username:  "integ_revoke_user",
+			Password:  "password123",
+			IPAddress: "127.0.0.1",
+			UserAgent: ua,
+		})
+		require.NoError(t, err)
+		others = append(others, loginResp.Session.SessionToken)
+	}
+
+	current := regResp.Session
+	listResp, err := sessions.ListSessions(ctx, &inputport.ListSessionsRequest{
+		UserID:           current.UserID,
+		CurrentSessionID: current.ID,
+	})
+	require.NoError(t, err)
+	assert.Len(t, listResp.Sessions, 3)
+
+	// 1件を個別に失効
+	other, err := auth.ValidateSession(ctx, others[0])
+	require.NoError(t, err)
+	_, err = sessions.RevokeSession(ctx, &inputport.RevokeSessionRequest{
+		UserID:           current.UserID,
+		CurrentSessionID: current.ID,
+		SessionID:        other.ID,
+	})
+	require.NoError(t, err)
+	_, err = auth.ValidateSession(ctx, others[0])
+	assert.Error(t, err)
+
+	// 残りを一括失効しても現在のセッションは有効
+	revokeResp, err := sessions.RevokeOtherSessions(ctx, &inputport.RevokeOtherSessionsRequest{
+		UserID:           current.UserID,
+		CurrentSessionID: current.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), revokeResp.RevokedCount)
+
+	_, err = auth.ValidateSession(ctx, others[1])
+	assert.Error(t, err)
+	_, err = auth.ValidateSession(ctx, current.SessionToken)
+	assert.NoError(t, err)
+}
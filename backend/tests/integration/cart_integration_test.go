@@ -0,0 +1,123 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingExchangeRepo は決められた回数目のCreateで失敗するProductExchangeRepository
+type failingExchangeRepo struct {
+	repository.ProductExchangeRepository
+	failAt int
+	calls  int
+}
+
+func (r *failingExchangeRepo) Create(ctx context.Context, exchange *entities.ProductExchange) error {
+	r.calls++
+	if r.calls == r.failAt {
+		return errors.New("exchange insert failed")
+	}
+	return r.ProductExchangeRepository.Create(ctx, exchange)
+}
+
+// TestCartInteractor_Checkout はカートの決済が1つのトランザクションで行われることを検証
+func TestCartInteractor_Checkout(t *testing.T) {
+	db := setupIntegrationDB(t)
+	lg := newTestLogger(t)
+	repos := setupAllRepos(db, lg)
+	txManager := infrapostgres.NewGormTransactionManager(db.GetDB())
+	ctx := context.Background()
+
+	newUC := func(exchanges repository.ProductExchangeRepository) inputport.CartInputPort {
+		return interactor.NewCartInteractor(
+			txManager, repos.Cart, repos.Product, exchanges, repos.User, repos.Transaction, repos.PointBatch, repos.PricingRule, repos.ShippingAddress, lg,
+		)
+	}
+
+	id := uuid.New()
+	user := &entities.User{
+		ID:             id,
+		Username:       "cart_user_" + id.String()[:8],
+		Email:          "cart_" + id.String()[:8] + "@example.com",
+		PasswordHash:   "$2a$10$test",
+		DisplayName:    "Cart Test User",
+		FirstName:      "Test",
+		LastName:       "User",
+		Balance:        1000,
+		Role:           entities.RoleUser,
+		IsActive:       true,
+		PersonalQRCode: "qr_" + id.String()[:8],
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	require.NoError(t, repos.User.Create(ctx, user))
+	createTestShippingAddress(t, repos, user.ID)
+
+	cola, err := entities.NewProduct("コーラ", "", "drink", 100, 10)
+	require.NoError(t, err)
+	require.NoError(t, repos.Product.Create(ctx, cola))
+	tea, err := entities.NewProduct("お茶", "", "drink", 80, 10)
+	require.NoError(t, err)
+	require.NoError(t, repos.Product.Create(ctx, tea))
+
+	uc := newUC(repos.ProductExchange)
+	_, err = uc.AddItem(ctx, &inputport.AddCartItemRequest{UserID: user.ID, ProductID: cola.ID, Quantity: 3})
+	require.NoError(t, err)
+	_, err = uc.AddItem(ctx, &inputport.AddCartItemRequest{UserID: user.ID, ProductID: tea.ID, Quantity: 2})
+	require.NoError(t, err)
+
+	t.Run("途中の交換記録で失敗したらすべて取り消す", func(t *testing.T) {
+		_, err := newUC(&failingExchangeRepo{ProductExchangeRepository: repos.ProductExchange, failAt: 2}).
+			Checkout(ctx, &inputport.CheckoutCartRequest{UserID: user.ID})
+		require.Error(t, err)
+
+		stored, err := repos.User.Read(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1000), stored.Balance, "ポイントは減らない")
+		product, err := repos.Product.Read(ctx, cola.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 10, product.Stock, "在庫も戻る")
+		count, err := repos.ProductExchange.CountByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Zero(t, count, "1件目の交換記録も残らない")
+		items, err := repos.Cart.ReadByUser(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Len(t, items, 2, "カートはそのまま")
+	})
+
+	t.Run("まとめて交換し、カートを空にする", func(t *testing.T) {
+		resp, err := uc.Checkout(ctx, &inputport.CheckoutCartRequest{UserID: user.ID})
+		require.NoError(t, err)
+		assert.Equal(t, int64(460), resp.TotalPoints)
+		require.Len(t, resp.Exchanges, 2)
+
+		stored, err := repos.User.Read(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(540), stored.Balance)
+		product, err := repos.Product.Read(ctx, tea.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 8, product.Stock)
+		for _, exchange := range resp.Exchanges {
+			saved, err := repos.ProductExchange.Read(ctx, exchange.ID)
+			require.NoError(t, err)
+			assert.Equal(t, resp.Transaction.ID, *saved.TransactionID)
+		}
+		items, err := repos.Cart.ReadByUser(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Empty(t, items)
+	})
+}
@@ -25,7 +25,7 @@ func setupDailyBonus(t *testing.T) (inputport.DailyBonusInputPort, infrapostgres
 	txManager := infrapostgres.NewGormTransactionManager(db.GetDB())
 
 	dailyBonus := interactor.NewDailyBonusInteractor(
-		repos.DailyBonus, repos.User, repos.Transaction, txManager, repos.SystemSettings, repos.PointBatch, repos.LotteryTier, lg,
+		repos.DailyBonus, repos.User, repos.Transaction, txManager, repos.SystemSettings, repos.PointBatch, repos.LotteryTier, repos.FailedAkerunAccess, repos.ProcessedAkerunAccess, entities.AkerunOrganizations{}, &mockReferralTracker{}, lg,
 	)
 	return dailyBonus, db
 }
@@ -44,7 +44,7 @@ func seedLotteryTier(t *testing.T, db infrapostgres.DB) {
 // seedPendingBonus は対象ユーザーの今日の未抽選ボーナスレコードを挿入する
 func seedPendingBonus(t *testing.T, db infrapostgres.DB, userID uuid.UUID) {
 	t.Helper()
-	bonusDate := entities.GetBonusDateJST(time.Now())
+	bonusDate := entities.GetBonusDate(time.Now(), entities.DefaultBonusLocation())
 	now := time.Now()
 	err := db.GetDB().Exec(`
 		INSERT INTO daily_bonuses (id, user_id, bonus_date, bonus_points, akerun_user_name, accessed_at, is_drawn, is_viewed, created_at)
@@ -70,6 +70,36 @@ func TestDailyBonus_DrawLottery(t *testing.T) {
 	assert.GreaterOrEqual(t, resp.BonusPoints, int64(0))
 }
 
+// TestDailyBonus_DoorMultiplier は入退室したドアの倍率が抽選結果に掛かることを検証
+func TestDailyBonus_DoorMultiplier(t *testing.T) {
+	dailyBonus, db := setupDailyBonus(t)
+	ctx := context.Background()
+
+	user := createTestUser(t, db, "daily_door_user")
+	seedLotteryTier(t, db) // 確率100%で5ポイント
+	seedPendingBonus(t, db, user.ID)
+	err := db.GetDB().Exec(`
+		UPDATE daily_bonuses
+		SET akerun_organization_id = 'org-main', akerun_door_id = 'door-entrance', akerun_door_name = '正面入口', points_multiplier = 2
+		WHERE user_id = ?
+	`, user.ID).Error
+	require.NoError(t, err)
+
+	resp, err := dailyBonus.DrawLotteryAndGrant(ctx, &inputport.DrawLotteryRequest{
+		UserID: user.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), resp.BonusPoints)
+
+	status, err := dailyBonus.GetTodayBonus(ctx, &inputport.GetTodayBonusRequest{
+		UserID: user.ID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, status.DailyBonus)
+	assert.Equal(t, "正面入口", status.DailyBonus.AkerunDoorName)
+	assert.Equal(t, int64(10), status.DailyBonus.BonusPoints)
+}
+
 // TestDailyBonus_DuplicateDraw は同日の重複ルーレットを検証（2回目は既存結果を返す）
 func TestDailyBonus_DuplicateDraw(t *testing.T) {
 	dailyBonus, db := setupDailyBonus(t)
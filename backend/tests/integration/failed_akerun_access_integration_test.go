@@ -0,0 +1,63 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFailedAkerunAccessRepository_Queue は再試行キューの登録・取得・更新・削除を検証
+func TestFailedAkerunAccessRepository_Queue(t *testing.T) {
+	db := setupIntegrationDB(t)
+	repos := setupAllRepos(db, newTestLogger(t))
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	access := entities.AccessRecord{ID: uuid.New(), UserName: "テスト太郎", AccessedAt: now.Add(-time.Hour)}
+	failed := entities.NewFailedAkerunAccess(access, fmt.Errorf("db down"), now)
+	require.NoError(t, repos.FailedAkerunAccess.Enqueue(ctx, failed))
+
+	// 同じアクセス記録を再び登録しても1件のまま（最初の記録が残る）
+	require.NoError(t, repos.FailedAkerunAccess.Enqueue(ctx, entities.NewFailedAkerunAccess(access, fmt.Errorf("again"), now)))
+	count, err := repos.FailedAkerunAccess.Count(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// 再試行時刻前は取得されない
+	due, err := repos.FailedAkerunAccess.ReadDue(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+
+	due, err = repos.FailedAkerunAccess.ReadDue(ctx, failed.NextRetryAt, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, access.ID, due[0].AccessID)
+	assert.Equal(t, "db down", due[0].LastError)
+
+	// デッドレターは再試行の対象外
+	for !due[0].IsDead() {
+		due[0].RecordFailure(fmt.Errorf("still down"), now)
+	}
+	require.NoError(t, repos.FailedAkerunAccess.Update(ctx, due[0]))
+	stillDue, err := repos.FailedAkerunAccess.ReadDue(ctx, now.Add(24*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, stillDue)
+
+	dead, err := repos.FailedAkerunAccess.ReadList(ctx, entities.FailedAkerunAccessDead, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, entities.FailedAkerunAccessMaxAttempts, dead[0].Attempts)
+
+	require.NoError(t, repos.FailedAkerunAccess.Delete(ctx, failed.ID))
+	_, err = repos.FailedAkerunAccess.Read(ctx, failed.ID)
+	assert.ErrorIs(t, err, entities.ErrFailedAccessNotFound)
+}
@@ -21,7 +21,7 @@ func setupFriendship(t *testing.T) (inputport.FriendshipInputPort, infrapostgres
 	lg := newTestLogger(t)
 	repos := setupAllRepos(db, lg)
 
-	friendship := interactor.NewFriendshipInteractor(repos.Friendship, repos.User, lg)
+	friendship := interactor.NewFriendshipInteractor(repos.Friendship, repos.User, repos.Transaction, &mockEarningEvents{}, lg)
 	return friendship, db
 }
 
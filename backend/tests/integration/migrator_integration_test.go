@@ -0,0 +1,39 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMigrator_AdoptsUntrackedSchema はinitdbで全ファイルを実行したDB（schema_migrations なし）を
+// Up が適用済みとして取り込み、以降は最新と判定されることを検証
+// （他のテストに影響しないよう、外側のトランザクションはロールバックする）
+func TestMigrator_AdoptsUntrackedSchema(t *testing.T) {
+	setupIntegrationDB(t)
+	ctx := context.Background()
+
+	tx := testGormDB.Begin()
+	require.NoError(t, tx.Error)
+	defer tx.Rollback()
+	require.NoError(t, tx.Exec("DROP TABLE IF EXISTS schema_migrations").Error)
+
+	migrator, err := infrapostgres.NewMigrator(&testDBWrapper{db: tx}, migrations.FS)
+	require.NoError(t, err)
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, len(migrator.Migrations()))
+	assert.NoError(t, migrator.CheckUpToDate(ctx))
+
+	pending, err := migrator.Pending(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
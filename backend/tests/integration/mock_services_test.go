@@ -4,7 +4,14 @@
 package integration
 
 import (
+	"context"
 	"io"
+	"io/fs"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/google/uuid"
 )
 
 // ========================================
@@ -21,6 +28,10 @@ func (m *mockPasswordService) VerifyPassword(hashedPassword, password string) bo
 	return hashedPassword == "$2a$10$mock_hashed_"+password
 }
 
+func (m *mockPasswordService) NeedsRehash(hashedPassword string) bool {
+	return false
+}
+
 // ========================================
 // MockEmailService
 // ========================================
@@ -40,6 +51,11 @@ func (m *mockEmailService) SendVerificationEmail(to, token string) error {
 	return nil
 }
 
+func (m *mockEmailService) SendEmailChangeConfirmation(to, newEmail, token string) error {
+	m.sentEmails = append(m.sentEmails, sentEmail{To: to, Type: "email_change_confirmation", Token: token})
+	return nil
+}
+
 func (m *mockEmailService) SendPasswordChangeNotification(to string) error {
 	m.sentEmails = append(m.sentEmails, sentEmail{To: to, Type: "password_change"})
 	return nil
@@ -50,6 +66,36 @@ func (m *mockEmailService) SendAccountDeletedNotification(to string) error {
 	return nil
 }
 
+func (m *mockEmailService) SendAccountLockedNotification(to, unlockToken string, lockedUntil time.Time) error {
+	m.sentEmails = append(m.sentEmails, sentEmail{To: to, Type: "account_locked", Token: unlockToken})
+	return nil
+}
+
+func (m *mockEmailService) SendNewLoginNotification(to, ipAddress, userAgent, country string, loggedInAt time.Time) error {
+	m.sentEmails = append(m.sentEmails, sentEmail{To: to, Type: "new_login"})
+	return nil
+}
+
+func (m *mockEmailService) SendNotificationEmail(to, subject, body string) error {
+	m.sentEmails = append(m.sentEmails, sentEmail{To: to, Type: "notification"})
+	return nil
+}
+
+func (m *mockEmailService) SendDataExportReady(to, exportID string, expiresAt time.Time) error {
+	m.sentEmails = append(m.sentEmails, sentEmail{To: to, Type: "data_export_ready", Token: exportID})
+	return nil
+}
+
+func (m *mockEmailService) SendUserInvitation(to, username, temporaryPassword string) error {
+	m.sentEmails = append(m.sentEmails, sentEmail{To: to, Type: "user_invitation", Token: temporaryPassword})
+	return nil
+}
+
+func (m *mockEmailService) SendEmail(to string, email *entities.RenderedEmail) error {
+	m.sentEmails = append(m.sentEmails, sentEmail{To: to, Type: "rendered"})
+	return nil
+}
+
 // ========================================
 // MockFileStorageService
 // ========================================
@@ -71,3 +117,128 @@ func (m *mockFileStorageService) DeleteAvatar(filePath string) error {
 func (m *mockFileStorageService) GetAvatarURL(filePath string) string {
 	return "http://localhost:8080" + filePath
 }
+
+func (m *mockFileStorageService) SaveTransferAttachment(userID string, fileName string, file io.Reader, fileSize int64) (string, error) {
+	path := "/attachments/" + userID + "/" + fileName
+	m.savedFiles = append(m.savedFiles, path)
+	return path, nil
+}
+
+func (m *mockFileStorageService) OpenTransferAttachment(filePath string) (io.ReadCloser, error) {
+	return nil, fs.ErrNotExist
+}
+
+func (m *mockFileStorageService) DeleteTransferAttachment(filePath string) error {
+	return nil
+}
+
+// ========================================
+// MockContentModeration
+// ========================================
+
+// mockContentModeration はすべてのテキストを許可する
+type mockContentModeration struct{}
+
+func (m *mockContentModeration) CheckContent(ctx context.Context, userID uuid.UUID, field entities.ModerationField, text string) error {
+	return nil
+}
+
+func (m *mockContentModeration) GetViolations(ctx context.Context, req *inputport.GetContentViolationsRequest) (*inputport.GetContentViolationsResponse, error) {
+	return &inputport.GetContentViolationsResponse{}, nil
+}
+
+func (m *mockContentModeration) ReviewViolation(ctx context.Context, req *inputport.ReviewContentViolationRequest) (*entities.ContentViolation, error) {
+	return nil, entities.ErrViolationNotFound
+}
+
+// ========================================
+// MockNotificationDispatcher
+// ========================================
+
+// mockNotificationDispatcher は届けた通知を記録する
+type mockNotificationDispatcher struct {
+	notifications []*entities.Notification
+}
+
+func (m *mockNotificationDispatcher) Dispatch(ctx context.Context, notification *entities.Notification) {
+	m.notifications = append(m.notifications, notification)
+}
+
+// mockTransferScreener は送金を不審と判定しない TransferScreener のモック
+type mockTransferScreener struct{}
+
+func (m *mockTransferScreener) ScreenTransfer(ctx context.Context, req *inputport.TransferRequest, now time.Time) *entities.SuspiciousActivity {
+	return nil
+}
+func (m *mockTransferScreener) RecordSuspiciousTransfer(ctx context.Context, activity *entities.SuspiciousActivity) error {
+	return nil
+}
+
+// mockTransferEligibility は送金の条件を設けない TransferEligibilityChecker のモック
+type mockTransferEligibility struct{}
+
+func (m *mockTransferEligibility) CheckSender(ctx context.Context, userID uuid.UUID) error {
+	return nil
+}
+
+func (m *mockTransferEligibility) CheckRecipient(ctx context.Context, senderID, recipientID uuid.UUID) error {
+	return nil
+}
+
+func (m *mockTransferEligibility) AcceptanceOf(ctx context.Context, user *entities.User) (entities.TransferAcceptance, error) {
+	return entities.TransferAcceptanceAnyone, nil
+}
+
+// mockTransferRequestQuota は承認待ちの送金リクエストの上限を設けない TransferRequestQuotaChecker のモック
+type mockTransferRequestQuota struct{}
+
+func (m *mockTransferRequestQuota) CheckQuota(ctx context.Context, fromUserID, toUserID uuid.UUID) error {
+	return nil
+}
+
+// mockTransferPolicy は送金額の上下限・手数料を設けない TransferPolicyProvider のモック
+type mockTransferPolicy struct{}
+
+func (m *mockTransferPolicy) CurrentPolicy(ctx context.Context) (*entities.TransferPolicy, error) {
+	return &entities.TransferPolicy{FeeType: entities.TransferFeeNone}, nil
+}
+
+// mockEarningEvents はポイント獲得ルールを判定しない EarningEventRecorder のモック
+type mockEarningEvents struct {
+	events []*entities.EarningEvent
+}
+
+func (m *mockEarningEvents) Record(ctx context.Context, event *entities.EarningEvent) {
+	m.events = append(m.events, event)
+}
+
+// mockReferralTracker は紹介を記録しない ReferralTracker のモック
+type mockReferralTracker struct {
+	completed []uuid.UUID
+}
+
+func (m *mockReferralTracker) ResolveReferralCode(ctx context.Context, code string) (*entities.ReferralCode, error) {
+	return nil, entities.ErrInvalidReferralCode
+}
+func (m *mockReferralTracker) RecordReferral(ctx context.Context, req *inputport.RecordReferralRequest) (*entities.Referral, error) {
+	return entities.NewReferral(req.Code.UserID, req.RefereeID, req.Code.Code, req.IPAddress), nil
+}
+func (m *mockReferralTracker) CompleteQualifyingAction(ctx context.Context, refereeID uuid.UUID) {
+	m.completed = append(m.completed, refereeID)
+}
+
+type mockOnboardingBonusGranter struct{}
+
+func (m *mockOnboardingBonusGranter) GrantOnboardingBonus(ctx context.Context, userID uuid.UUID) (*entities.Transaction, error) {
+	return nil, nil
+}
+
+type mockEmailVerificationGate struct{}
+
+func (m *mockEmailVerificationGate) StartVerification(ctx context.Context, user *entities.User) (entities.EmailVerificationStage, error) {
+	return entities.EmailVerificationNotRequired, nil
+}
+
+func (m *mockEmailVerificationGate) CheckLogin(ctx context.Context, user *entities.User) error {
+	return nil
+}
@@ -0,0 +1,77 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/gity/point-system/gateways/infra"
+	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	pointExpiryPolicyRepo "github.com/gity/point-system/gateways/repository/point_expiry_policy"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedExpiredBatch は期限切れで残量があるバッチを挿入する
+func seedExpiredBatch(t *testing.T, db infrapostgres.DB, userID uuid.UUID, amount int64, expiresAt time.Time) {
+	t.Helper()
+	err := db.GetDB().Exec(`
+		INSERT INTO point_batches (id, user_id, original_amount, remaining_amount, source_type, expires_at, created_at)
+		VALUES (?, ?, ?, ?, 'admin_grant', ?, ?)
+	`, uuid.New(), userID, amount, amount, expiresAt, expiresAt.AddDate(0, -3, 0)).Error
+	require.NoError(t, err)
+}
+
+// TestPointExpiryWorker_GroupsByUser は同じユーザーの期限切れバッチが1つの失効取引にまとまることを検証
+func TestPointExpiryWorker_GroupsByUser(t *testing.T) {
+	db := setupIntegrationDB(t)
+	lg := newTestLogger(t)
+	repos := setupAllRepos(db, lg)
+	txManager := infrapostgres.NewGormTransactionManager(db.GetDB())
+	policyRepo := pointExpiryPolicyRepo.NewPointExpiryPolicyRepository(dspostgresimpl.NewPointExpiryPolicyDataSource(db))
+	ctx := context.Background()
+
+	many := createTestUserWithBalance(t, db, "expiry_many", 1000)
+	single := createTestUserWithBalance(t, db, "expiry_single", 1000)
+	expiredAt := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		seedExpiredBatch(t, db, many.ID, 100, expiredAt.Add(time.Duration(i)*time.Minute))
+	}
+	seedExpiredBatch(t, db, single.ID, 300, expiredAt)
+
+	// 1ページ3件に制限しても、全件を処理しきる
+	worker := infra.NewPointExpiryWorker(repos.PointBatch, policyRepo, repos.SystemSettings, repos.User, repos.Transaction, txManager, lg).
+		WithLimits(infra.PointExpiryLimits{BatchSize: 3, BatchPause: 0, MaxRuntime: time.Minute})
+	worker.ProcessExpiredBatchesForTest()
+
+	var remaining int64
+	require.NoError(t, db.GetDB().Raw("SELECT COUNT(*) FROM point_batches WHERE remaining_amount > 0").Scan(&remaining).Error)
+	assert.Equal(t, int64(0), remaining)
+
+	var balance int64
+	require.NoError(t, db.GetDB().Raw("SELECT balance FROM users WHERE id = ?", many.ID).Scan(&balance).Error)
+	assert.Equal(t, int64(500), balance)
+
+	// 1ページ目の2件と2ページ目の3件がそれぞれ1取引にまとまる（ページをまたぐ分は別の取引）
+	var expireTxs int64
+	require.NoError(t, db.GetDB().Raw(
+		"SELECT COUNT(*) FROM transactions WHERE transaction_type = 'system_expire' AND from_user_id = ?", many.ID,
+	).Scan(&expireTxs).Error)
+	assert.Equal(t, int64(2), expireTxs)
+
+	value, err := repos.SystemSettings.GetSetting(ctx, entities.PointExpiryRunSettingKey)
+	require.NoError(t, err)
+	run := &entities.PointExpiryRun{}
+	require.NoError(t, json.Unmarshal([]byte(value), run))
+	assert.Equal(t, entities.PointExpiryRunCompleted, run.State)
+	assert.Equal(t, 6, run.ExpiredBatches)
+	assert.Equal(t, int64(800), run.ExpiredPoints)
+	assert.Equal(t, 2, run.Pages)
+}
@@ -23,7 +23,7 @@ func setupPointTransfer(t *testing.T) (*interactor.PointTransferInteractor, infr
 	txManager := infrapostgres.NewGormTransactionManager(db.GetDB())
 
 	pt := interactor.NewPointTransferInteractor(
-		txManager, repos.User, repos.Transaction, repos.IdempotencyKey, repos.Friendship, repos.PointBatch, lg,
+		txManager, repos.User, repos.Transaction, repos.IdempotencyKey, repos.Friendship, repos.PointBatch, repos.BalanceHold, &mockNotificationDispatcher{}, &mockTransferScreener{}, &mockTransferEligibility{}, &mockTransferPolicy{}, &mockEarningEvents{}, lg,
 	)
 	return pt, db
 }
@@ -188,7 +188,7 @@ func setupPointTransferWithTxManager(t *testing.T) (*interactor.PointTransferInt
 	txManager := infrapostgres.NewGormTransactionManager(db.GetDB())
 
 	pt := interactor.NewPointTransferInteractor(
-		txManager, repos.User, repos.Transaction, repos.IdempotencyKey, repos.Friendship, repos.PointBatch, lg,
+		txManager, repos.User, repos.Transaction, repos.IdempotencyKey, repos.Friendship, repos.PointBatch, repos.BalanceHold, &mockNotificationDispatcher{}, &mockTransferScreener{}, &mockTransferEligibility{}, &mockTransferPolicy{}, &mockEarningEvents{}, lg,
 	)
 	return pt, repos, txManager, db
 }
@@ -0,0 +1,48 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestProcessedAkerunAccessRepository は処理済みのアクセス記録IDの記録・参照・削除を検証
+func TestProcessedAkerunAccessRepository(t *testing.T) {
+	db := setupIntegrationDB(t)
+	repos := setupAllRepos(db, newTestLogger(t))
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	old := entities.AccessRecord{ID: uuid.New(), UserName: "テスト太郎", AccessedAt: now.Add(-40 * 24 * time.Hour)}
+	recent := entities.AccessRecord{ID: uuid.New(), UserName: "テスト太郎", AccessedAt: now.Add(-time.Hour)}
+	require.NoError(t, repos.ProcessedAkerunAccess.Create(ctx, []*entities.ProcessedAkerunAccess{
+		entities.NewProcessedAkerunAccess(old, now.Add(-40*24*time.Hour)),
+		entities.NewProcessedAkerunAccess(recent, now),
+	}))
+
+	// 記録済みのアクセス記録を再び記録してもエラーにしない
+	require.NoError(t, repos.ProcessedAkerunAccess.Create(ctx, []*entities.ProcessedAkerunAccess{
+		entities.NewProcessedAkerunAccess(recent, now),
+	}))
+
+	unknown := uuid.New()
+	processed, err := repos.ProcessedAkerunAccess.ReadProcessedIDs(ctx, []uuid.UUID{old.ID, recent.ID, unknown})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]bool{old.ID: true, recent.ID: true}, processed)
+
+	deleted, err := repos.ProcessedAkerunAccess.DeleteProcessedBefore(ctx, now.Add(-entities.ProcessedAkerunAccessRetention))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	processed, err = repos.ProcessedAkerunAccess.ReadProcessedIDs(ctx, []uuid.UUID{old.ID, recent.ID})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]bool{recent.ID: true}, processed)
+}
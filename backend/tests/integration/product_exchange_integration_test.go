@@ -25,7 +25,7 @@ func TestProductExchangeInteractor_ExchangeProduct(t *testing.T) {
 	txManager := infrapostgres.NewGormTransactionManager(db.GetDB())
 
 	productExchangeUC := interactor.NewProductExchangeInteractor(
-		txManager, repos.Product, repos.ProductExchange, repos.User, repos.Transaction, repos.PointBatch, lg,
+		txManager, repos.Product, repos.ProductExchange, repos.User, repos.Transaction, repos.PointBatch, repos.PricingRule, repos.Friendship, repos.ShippingAddress, &mockNotificationDispatcher{}, lg,
 	)
 
 	// テストデータ準備
@@ -47,6 +47,7 @@ func TestProductExchangeInteractor_ExchangeProduct(t *testing.T) {
 	}
 	err := repos.User.Create(context.Background(), testUser)
 	require.NoError(t, err)
+	address := createTestShippingAddress(t, repos, testUser.ID)
 
 	// 商品作成
 	testProduct, err := entities.NewProduct(
@@ -73,6 +74,10 @@ func TestProductExchangeInteractor_ExchangeProduct(t *testing.T) {
 		assert.Equal(t, int64(400), resp.Exchange.PointsUsed)
 		assert.Equal(t, 2, resp.Exchange.Quantity)
 
+		saved, err := repos.ProductExchange.Read(context.Background(), resp.Exchange.ID)
+		require.NoError(t, err)
+		assert.Equal(t, address.Format(), saved.ShippingAddress, "交換時点のお届け先を控える")
+
 		// ユーザー残高確認
 		updatedUser, err := repos.User.Read(context.Background(), testUser.ID)
 		require.NoError(t, err)
@@ -103,6 +108,7 @@ func TestProductExchangeInteractor_ExchangeProduct(t *testing.T) {
 		}
 		err := repos.User.Create(context.Background(), poorUser)
 		require.NoError(t, err)
+		createTestShippingAddress(t, repos, poorUser.ID)
 
 		_, err = productExchangeUC.ExchangeProduct(context.Background(), &inputport.ExchangeProductRequest{
 			UserID:    poorUser.ID,
@@ -129,6 +135,59 @@ func TestProductExchangeInteractor_ExchangeProduct(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "insufficient stock")
 	})
+
+	t.Run("友達へ贈り、辞退されたら払った人へ返金", func(t *testing.T) {
+		ctx := context.Background()
+		friendID := uuid.New()
+		friend := &entities.User{
+			ID:             friendID,
+			Username:       "gift_friend_" + friendID.String()[:8],
+			Email:          "gift_" + friendID.String()[:8] + "@example.com",
+			PasswordHash:   "$2a$10$test",
+			DisplayName:    "Gift Friend",
+			FirstName:      "Gift",
+			LastName:       "Friend",
+			Role:           entities.RoleUser,
+			IsActive:       true,
+			PersonalQRCode: "qr_gift_" + friendID.String()[:8],
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
+		}
+		require.NoError(t, repos.User.Create(ctx, friend))
+		friendship, err := entities.NewFriendship(testUser.ID, friend.ID)
+		require.NoError(t, err)
+		require.NoError(t, friendship.Accept())
+		require.NoError(t, repos.Friendship.Create(ctx, friendship))
+
+		before, err := repos.User.Read(ctx, testUser.ID)
+		require.NoError(t, err)
+
+		resp, err := productExchangeUC.ExchangeProduct(ctx, &inputport.ExchangeProductRequest{
+			UserID:      testUser.ID,
+			ProductID:   testProduct.ID,
+			Quantity:    1,
+			RecipientID: &friend.ID,
+		})
+		require.NoError(t, err)
+
+		gifts, err := productExchangeUC.GetReceivedGifts(ctx, &inputport.GetExchangeHistoryRequest{UserID: friend.ID, Limit: 20})
+		require.NoError(t, err)
+		require.Len(t, gifts.Exchanges, 1)
+		assert.Equal(t, entities.GiftStatusPending, gifts.Exchanges[0].GiftStatus)
+
+		_, err = productExchangeUC.DeclineGift(ctx, &inputport.RespondGiftRequest{UserID: friend.ID, ExchangeID: resp.Exchange.ID})
+		require.NoError(t, err)
+
+		saved, err := repos.ProductExchange.Read(ctx, resp.Exchange.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.ExchangeStatusCancelled, saved.Status)
+		assert.Equal(t, entities.GiftStatusDeclined, saved.GiftStatus)
+		assert.NotNil(t, saved.GiftRespondedAt)
+
+		after, err := repos.User.Read(ctx, testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, before.Balance, after.Balance, "辞退されたポイントは払った人に戻る")
+	})
 }
 
 // TestProductManagementInteractor は商品管理の統合テスト
@@ -137,7 +196,7 @@ func TestProductManagementInteractor(t *testing.T) {
 	lg := newTestLogger(t)
 	repos := setupAllRepos(db, lg)
 
-	productManagementUC := interactor.NewProductManagementInteractor(repos.Product, lg)
+	productManagementUC := interactor.NewProductManagementInteractor(repos.Product, &mockContentModeration{}, lg)
 
 	t.Run("商品作成", func(t *testing.T) {
 		resp, err := productManagementUC.CreateProduct(context.Background(), &inputport.CreateProductRequest{
@@ -7,7 +7,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/gity/point-system/frameworks/web/realtime"
 	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/gateways/infra/infraqrpayload"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/gity/point-system/usecases/interactor"
 	"github.com/stretchr/testify/assert"
@@ -22,9 +24,9 @@ func setupQRCode(t *testing.T) (inputport.QRCodeInputPort, infrapostgres.DB) {
 	txManager := infrapostgres.NewGormTransactionManager(db.GetDB())
 
 	pt := interactor.NewPointTransferInteractor(
-		txManager, repos.User, repos.Transaction, repos.IdempotencyKey, repos.Friendship, repos.PointBatch, lg,
+		txManager, repos.User, repos.Transaction, repos.IdempotencyKey, repos.Friendship, repos.PointBatch, repos.BalanceHold, &mockNotificationDispatcher{}, &mockTransferScreener{}, &mockTransferEligibility{}, &mockTransferPolicy{}, &mockEarningEvents{}, lg,
 	)
-	qr := interactor.NewQRCodeInteractor(repos.QRCode, pt, lg)
+	qr := interactor.NewQRCodeInteractor(repos.QRCode, repos.User, pt, realtime.NewHub(lg), infraqrpayload.NewCodec("integration-test-qr-signing-secret"), lg)
 	return qr, db
 }
 
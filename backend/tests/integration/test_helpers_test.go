@@ -17,14 +17,27 @@ import (
 	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
 	infrapostgres "github.com/gity/point-system/gateways/infra/infrapostgres"
+	akerunRepollRepo "github.com/gity/point-system/gateways/repository/akerun_repoll"
+	balanceHoldRepo "github.com/gity/point-system/gateways/repository/balance_hold"
+	budgetRepo "github.com/gity/point-system/gateways/repository/budget"
+	cartRepo "github.com/gity/point-system/gateways/repository/cart"
 	categoryRepo "github.com/gity/point-system/gateways/repository/category"
 	dailyBonusRepo "github.com/gity/point-system/gateways/repository/daily_bonus"
+	departmentRepo "github.com/gity/point-system/gateways/repository/department"
+	failedAkerunAccessRepo "github.com/gity/point-system/gateways/repository/failed_akerun_access"
 	friendshipRepo "github.com/gity/point-system/gateways/repository/friendship"
+	loginAttemptRepo "github.com/gity/point-system/gateways/repository/login_attempt"
 	lotteryTierRepo "github.com/gity/point-system/gateways/repository/lottery_tier"
+	muteListRepo "github.com/gity/point-system/gateways/repository/mute_list"
+	pendingAdminActionRepo "github.com/gity/point-system/gateways/repository/pending_admin_action"
 	pointBatchRepo "github.com/gity/point-system/gateways/repository/point_batch"
+	pricingRuleRepo "github.com/gity/point-system/gateways/repository/pricing_rule"
+	processedAkerunAccessRepo "github.com/gity/point-system/gateways/repository/processed_akerun_access"
 	productRepo "github.com/gity/point-system/gateways/repository/product"
 	qrcodeRepo "github.com/gity/point-system/gateways/repository/qrcode"
+	reasonCodeRepo "github.com/gity/point-system/gateways/repository/reason_code"
 	sessionRepo "github.com/gity/point-system/gateways/repository/session"
+	shippingAddressRepo "github.com/gity/point-system/gateways/repository/shipping_address"
 	systemSettingsRepo "github.com/gity/point-system/gateways/repository/system_settings"
 	transactionRepo "github.com/gity/point-system/gateways/repository/transaction"
 	transferRequestRepo "github.com/gity/point-system/gateways/repository/transfer_request"
@@ -33,6 +46,7 @@ import (
 	"github.com/gity/point-system/usecases/interactor"
 	"github.com/gity/point-system/usecases/repository"
 	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
@@ -147,14 +161,30 @@ func (w *testDBWrapper) Close() error {
 
 // truncatedTables は TRUNCATE 対象テーブル一覧（依存順序を考慮）
 var truncatedTables = []string{
+	"pending_admin_action_events",
+	"pending_admin_actions",
+	"budget_usages",
+	"budgets",
+	"cart_items",
+	"shipping_addresses",
+	"email_templates",
 	"product_exchanges",
+	"balance_holds",
 	"transfer_requests",
 	"transactions",
 	"idempotency_keys",
+	"friend_pins",
+	"muted_senders",
 	"friendships",
 	"sessions",
+	"login_attempts",
+	"account_lockouts",
+	"failed_akerun_accesses",
+	"processed_akerun_accesses",
+	"akerun_repolls",
 	"daily_bonuses",
 	"akerun_poll_state",
+	"akerun_poll_cursors",
 	"point_batches",
 	"qr_codes",
 	"username_change_history",
@@ -215,6 +245,19 @@ type Repos struct {
 	EmailVerification     repository.EmailVerificationRepository
 	UsernameChangeHistory repository.UsernameChangeHistoryRepository
 	PasswordChangeHistory repository.PasswordChangeHistoryRepository
+	LoginAttempt          repository.LoginAttemptRepository
+	PricingRule           repository.PricingRuleRepository
+	ReasonCode            repository.ReasonCodeRepository
+	Department            repository.DepartmentRepository
+	Budget                repository.BudgetRepository
+	PendingAdminAction    repository.PendingAdminActionRepository
+	FailedAkerunAccess    repository.FailedAkerunAccessRepository
+	ProcessedAkerunAccess repository.ProcessedAkerunAccessRepository
+	AkerunRepoll          repository.AkerunRepollRepository
+	Cart                  repository.CartRepository
+	ShippingAddress       repository.ShippingAddressRepository
+	MuteList              repository.MuteListRepository
+	BalanceHold           repository.BalanceHoldRepository
 }
 
 func setupAllRepos(db infrapostgres.DB, lg entities.Logger) *Repos {
@@ -231,6 +274,7 @@ func setupAllRepos(db infrapostgres.DB, lg entities.Logger) *Repos {
 	qrcodeDS := dspostgresimpl.NewQRCodeDataSource(db)
 	dailyBonusDS := dspostgresimpl.NewDailyBonusDataSource(db)
 	pointBatchDS := dspostgresimpl.NewPointBatchDataSource(db)
+	pointExpiryPolicyDS := dspostgresimpl.NewPointExpiryPolicyDataSource(db)
 	systemSettingsDS := dspostgresimpl.NewSystemSettingsDataSource(db)
 	lotteryTierDS := dspostgresimpl.NewLotteryTierDataSource(db)
 	analyticsDS := dspostgresimpl.NewAnalyticsDataSource(db)
@@ -238,6 +282,19 @@ func setupAllRepos(db infrapostgres.DB, lg entities.Logger) *Repos {
 	emailVerificationDS := dspostgresimpl.NewEmailVerificationDataSource(db)
 	usernameChangeHistoryDS := dspostgresimpl.NewUsernameChangeHistoryDataSource(db)
 	passwordChangeHistoryDS := dspostgresimpl.NewPasswordChangeHistoryDataSource(db)
+	loginAttemptDS := dspostgresimpl.NewLoginAttemptDataSource(db)
+	pricingRuleDS := dspostgresimpl.NewPricingRuleDataSource(db)
+	reasonCodeDS := dspostgresimpl.NewReasonCodeDataSource(db)
+	departmentDS := dspostgresimpl.NewDepartmentDataSource(db)
+	budgetDS := dspostgresimpl.NewBudgetDataSource(db)
+	pendingAdminActionDS := dspostgresimpl.NewPendingAdminActionDataSource(db)
+	failedAkerunAccessDS := dspostgresimpl.NewFailedAkerunAccessDataSource(db)
+	processedAkerunAccessDS := dspostgresimpl.NewProcessedAkerunAccessDataSource(db)
+	akerunRepollDS := dspostgresimpl.NewAkerunRepollDataSource(db)
+	cartDS := dspostgresimpl.NewCartDataSource(db)
+	shippingAddressDS := dspostgresimpl.NewShippingAddressDataSource(db)
+	mutedSenderDS := dspostgresimpl.NewMutedSenderDataSource(db)
+	balanceHoldDS := dspostgresimpl.NewBalanceHoldDataSource(db)
 
 	// Repositories
 	return &Repos{
@@ -252,7 +309,7 @@ func setupAllRepos(db infrapostgres.DB, lg entities.Logger) *Repos {
 		Category:              categoryRepo.NewCategoryRepository(categoryDS, lg),
 		QRCode:                qrcodeRepo.NewQRCodeRepository(qrcodeDS, lg),
 		DailyBonus:            dailyBonusRepo.NewDailyBonusRepository(dailyBonusDS),
-		PointBatch:            pointBatchRepo.NewPointBatchRepository(pointBatchDS),
+		PointBatch:            pointBatchRepo.NewPointBatchRepository(pointBatchDS, pointExpiryPolicyDS),
 		SystemSettings:        systemSettingsRepo.NewSystemSettingsRepository(systemSettingsDS),
 		LotteryTier:           lotteryTierRepo.NewLotteryTierRepository(lotteryTierDS),
 		Analytics:             analyticsDS,
@@ -261,6 +318,19 @@ func setupAllRepos(db infrapostgres.DB, lg entities.Logger) *Repos {
 		EmailVerification:     userSettingsRepo.NewEmailVerificationRepository(emailVerificationDS, lg),
 		UsernameChangeHistory: userSettingsRepo.NewUsernameChangeHistoryRepository(usernameChangeHistoryDS, lg),
 		PasswordChangeHistory: userSettingsRepo.NewPasswordChangeHistoryRepository(passwordChangeHistoryDS, lg),
+		LoginAttempt:          loginAttemptRepo.NewLoginAttemptRepository(loginAttemptDS, lg),
+		PricingRule:           pricingRuleRepo.NewPricingRuleRepository(pricingRuleDS),
+		ReasonCode:            reasonCodeRepo.NewReasonCodeRepository(reasonCodeDS),
+		Department:            departmentRepo.NewDepartmentRepository(departmentDS),
+		Budget:                budgetRepo.NewBudgetRepository(budgetDS),
+		PendingAdminAction:    pendingAdminActionRepo.NewPendingAdminActionRepository(pendingAdminActionDS),
+		FailedAkerunAccess:    failedAkerunAccessRepo.NewFailedAkerunAccessRepository(failedAkerunAccessDS),
+		ProcessedAkerunAccess: processedAkerunAccessRepo.NewProcessedAkerunAccessRepository(processedAkerunAccessDS),
+		AkerunRepoll:          akerunRepollRepo.NewAkerunRepollRepository(akerunRepollDS),
+		Cart:                  cartRepo.NewCartRepository(cartDS),
+		ShippingAddress:       shippingAddressRepo.NewShippingAddressRepository(shippingAddressDS),
+		MuteList:              muteListRepo.NewMuteListRepository(mutedSenderDS),
+		BalanceHold:           balanceHoldRepo.NewBalanceHoldRepository(balanceHoldDS),
 	}
 }
 
@@ -294,16 +364,16 @@ type Services struct {
 func setupAllInteractors(repos *Repos, svcs *Services, txManager repository.TransactionManager, lg entities.Logger) *Interactors {
 	// PointTransfer は他のインタラクターの依存でもある
 	pointTransfer := interactor.NewPointTransferInteractor(
-		txManager, repos.User, repos.Transaction, repos.IdempotencyKey, repos.Friendship, repos.PointBatch, lg,
+		txManager, repos.User, repos.Transaction, repos.IdempotencyKey, repos.Friendship, repos.PointBatch, repos.BalanceHold, &mockNotificationDispatcher{}, &mockTransferScreener{}, &mockTransferEligibility{}, &mockTransferPolicy{}, &mockEarningEvents{}, lg,
 	)
 
 	return &Interactors{
 		PointTransfer: pointTransfer,
 		ProductExchange: interactor.NewProductExchangeInteractor(
-			txManager, repos.Product, repos.ProductExchange, repos.User, repos.Transaction, repos.PointBatch, lg,
+			txManager, repos.Product, repos.ProductExchange, repos.User, repos.Transaction, repos.PointBatch, repos.PricingRule, repos.Friendship, repos.ShippingAddress, &mockNotificationDispatcher{}, lg,
 		),
 		DailyBonus: interactor.NewDailyBonusInteractor(
-			repos.DailyBonus, repos.User, repos.Transaction, txManager, repos.SystemSettings, repos.PointBatch, repos.LotteryTier, lg,
+			repos.DailyBonus, repos.User, repos.Transaction, txManager, repos.SystemSettings, repos.PointBatch, repos.LotteryTier, repos.FailedAkerunAccess, repos.ProcessedAkerunAccess, entities.AkerunOrganizations{}, &mockReferralTracker{}, lg,
 		),
 	}
 }
@@ -352,6 +422,20 @@ func createTestAdminUser(t *testing.T, db infrapostgres.DB, username string) *en
 	return user
 }
 
+// createTestShippingAddress はユーザーの既定のお届け先を登録
+func createTestShippingAddress(t *testing.T, repos *Repos, userID uuid.UUID) *entities.ShippingAddress {
+	t.Helper()
+	address, err := entities.NewShippingAddress(userID, entities.ShippingAddressInput{
+		RecipientName: "山田太郎", PostalCode: "100-0001", Prefecture: "東京都",
+		City: "千代田区", Line1: "千代田1-1", Phone: "03-1234-5678",
+	})
+	require.NoError(t, err)
+	require.NoError(t, repos.ShippingAddress.Create(context.Background(), address))
+	require.NoError(t, repos.ShippingAddress.SetDefault(context.Background(), userID, address.ID))
+	address.IsDefault = true
+	return address
+}
+
 // ========================================
 // マイグレーションファイル取得ヘルパー
 // ========================================
@@ -6,12 +6,15 @@ package integration
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/gateways/repository/datasource/dsmysql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
@@ -187,6 +190,99 @@ func TestConcurrentTransactions_DeadlockPrevention(t *testing.T) {
 	})
 }
 
+func TestUpdateBalancesWithLock_InterleavedTransfers(t *testing.T) {
+	db := setupIntegrationDB(t)
+
+	userDS := dspostgresimpl.NewUserDataSource(db)
+	txManager := infrapostgres.NewGormTransactionManager(db.GetDB())
+
+	const userCount, initialBalance = 30, 100000
+	users := make([]*entities.User, userCount)
+	for i := range users {
+		u, _ := entities.NewUser(fmt.Sprintf("batch%02d", i), fmt.Sprintf("batch%02d@test.com", i), "hash", fmt.Sprintf("Batch %d", i), "Batch", "User")
+		u.Balance = initialBalance
+		require.NoError(t, userDS.Insert(context.Background(), u))
+		users[i] = u
+	}
+
+	// transfer はfromからtoの全員へ1ポイントずつ送る（更新は渡された順のまま。ロックの順序はUpdateBalancesWithLockが決める）
+	transfer := func(from int, to []int) error {
+		updates := []dsmysql.BalanceUpdate{{UserID: users[from].ID, Amount: int64(len(to)), IsDeduct: true}}
+		for _, i := range to {
+			updates = append(updates, dsmysql.BalanceUpdate{UserID: users[i].ID, Amount: 1})
+		}
+		for {
+			err := txManager.Do(context.Background(), func(txCtx context.Context) error {
+				if err := userDS.UpdateBalancesWithLock(txCtx, updates); err != nil {
+					return err
+				}
+				// ロックを持ったまま待ち、他のトランザクションと重ねる
+				return infrapostgres.GetDB(txCtx, db.GetDB()).Exec("SELECT pg_sleep(0.002)").Error
+			})
+			// REPEATABLE READの接続では、待っていた行が更新されると直列化の失敗になるのでやり直す
+			if err != nil && strings.Contains(err.Error(), "SQLSTATE 40001") {
+				continue
+			}
+			return err
+		}
+	}
+
+	t.Run("逆順に重なる送金と全員への一括付与を並行してもデッドロックしない", func(t *testing.T) {
+		var wg sync.WaitGroup
+		errs := make(chan error, 1000)
+
+		for w := 0; w < 8; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for r := 0; r < 20; r++ {
+					from := (w*7 + r) % userCount
+					// 偶数のワーカーは昇順、奇数のワーカーは降順に受取人を並べる
+					to := make([]int, 0, 5)
+					for k := 1; k <= 5; k++ {
+						if w%2 == 0 {
+							to = append(to, (from+k)%userCount)
+						} else {
+							to = append(to, (from-k+userCount)%userCount)
+						}
+					}
+					errs <- transfer(from, to)
+				}
+			}(w)
+		}
+
+		// Akerunのボーナスのように全員の残高を一括で更新するトランザクションも重ねる
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := 0; r < 10; r++ {
+				to := make([]int, 0, userCount-1)
+				for i := userCount - 1; i >= 0; i-- {
+					if i != r {
+						to = append(to, i)
+					}
+				}
+				errs <- transfer(r, to)
+			}
+		}()
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			if err != nil {
+				assert.NotContains(t, err.Error(), "SQLSTATE 40P01", "deadlock detected")
+				assert.NoError(t, err)
+			}
+		}
+
+		// ポイント保存則
+		var total int64
+		require.NoError(t, db.GetDB().Raw("SELECT COALESCE(SUM(balance), 0) FROM users WHERE username LIKE 'batch%'").Scan(&total).Error)
+		assert.Equal(t, int64(userCount*initialBalance), total)
+	})
+}
+
 func TestConcurrentTransactions_RaceCondition(t *testing.T) {
 	db := setupIntegrationDB(t)
 
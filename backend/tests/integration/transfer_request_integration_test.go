@@ -23,9 +23,9 @@ func setupTransferRequest(t *testing.T) (inputport.TransferRequestInputPort, inf
 	txManager := infrapostgres.NewGormTransactionManager(db.GetDB())
 
 	pt := interactor.NewPointTransferInteractor(
-		txManager, repos.User, repos.Transaction, repos.IdempotencyKey, repos.Friendship, repos.PointBatch, lg,
+		txManager, repos.User, repos.Transaction, repos.IdempotencyKey, repos.Friendship, repos.PointBatch, repos.BalanceHold, &mockNotificationDispatcher{}, &mockTransferScreener{}, &mockTransferEligibility{}, &mockTransferPolicy{}, &mockEarningEvents{}, lg,
 	)
-	tr := interactor.NewTransferRequestInteractor(repos.TransferRequest, repos.User, pt, lg)
+	tr := interactor.NewTransferRequestInteractor(repos.TransferRequest, repos.User, pt, &mockContentModeration{}, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, repos.MuteList, interactor.NewBalanceHoldInteractor(txManager, repos.User, repos.BalanceHold, lg), lg)
 	return tr, db
 }
 
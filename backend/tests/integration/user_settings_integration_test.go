@@ -32,9 +32,13 @@ func setupUserSettings(t *testing.T) (inputport.UserSettingsInputPort, infrapost
 		repos.EmailVerification,
 		repos.UsernameChangeHistory,
 		repos.PasswordChangeHistory,
+		repos.Transaction,
+		repos.SystemSettings,
 		fileSvc,
 		pwdSvc,
 		emailSvc,
+		&mockContentModeration{},
+		&mockEarningEvents{},
 		lg,
 	)
 	return us, db
@@ -0,0 +1,45 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+var _ repository.AdminDashboardRepository = (*AdminDashboardRepository)(nil)
+
+// AdminDashboardRepository はAdminDashboardRepositoryのインメモリ実装
+// 集計はSQLに任せているので計算はせず、テストで設定したカウンターをそのまま返す（未設定ならすべて0）
+type AdminDashboardRepository struct {
+	Faults
+	mu sync.Mutex
+
+	Counters *entities.AdminDashboardCounters
+}
+
+// NewAdminDashboardRepository はすべて0のカウンターを返すAdminDashboardRepositoryを作成
+func NewAdminDashboardRepository() *AdminDashboardRepository {
+	return &AdminDashboardRepository{}
+}
+
+// GetCounters は設定されたカウンターのコピーを返す
+func (r *AdminDashboardRepository) GetCounters(ctx context.Context, now time.Time) (*entities.AdminDashboardCounters, error) {
+	if err := r.hit("GetCounters"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Counters == nil {
+		return &entities.AdminDashboardCounters{}, nil
+	}
+	c := *r.Counters
+	return &c, nil
+}
+
+// Ping はFailOnで設定したエラーを返す
+func (r *AdminDashboardRepository) Ping(ctx context.Context) error {
+	return r.hit("Ping")
+}
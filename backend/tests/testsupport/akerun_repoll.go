@@ -0,0 +1,104 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.AkerunRepollRepository = (*AkerunRepollRepository)(nil)
+
+// AkerunRepollRepository はAkerunRepollRepositoryのインメモリ実装
+type AkerunRepollRepository struct {
+	Faults
+	mu      sync.Mutex
+	repolls *table[uuid.UUID, entities.AkerunRepoll]
+}
+
+// NewAkerunRepollRepository は空のAkerunRepollRepositoryを作成
+func NewAkerunRepollRepository() *AkerunRepollRepository {
+	return &AkerunRepollRepository{repolls: newTable[uuid.UUID, entities.AkerunRepoll]()}
+}
+
+// Create は再取得の依頼を保存（処理待ち・取得中の依頼があればErrDuplicate、DBの部分一意インデックスに相当）
+func (r *AkerunRepollRepository) Create(ctx context.Context, repoll *entities.AkerunRepoll) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if repoll.IsInProgress() && r.repolls.count((*entities.AkerunRepoll).IsInProgress) > 0 {
+		return ErrDuplicate
+	}
+	r.repolls.put(repoll.ID, repoll)
+	return nil
+}
+
+// Read はIDで再取得の依頼を取得
+func (r *AkerunRepollRepository) Read(ctx context.Context, id uuid.UUID) (*entities.AkerunRepoll, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if repoll, ok := r.repolls.get(id); ok {
+		return repoll, nil
+	}
+	return nil, entities.ErrAkerunRepollNotFound
+}
+
+// ReadActive は処理待ちまたは取得中の依頼を取得（ない場合はnil）
+func (r *AkerunRepollRepository) ReadActive(ctx context.Context) (*entities.AkerunRepoll, error) {
+	if err := r.hit("ReadActive"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if repoll, ok := r.repolls.first((*entities.AkerunRepoll).IsInProgress); ok {
+		return repoll, nil
+	}
+	return nil, nil
+}
+
+// ReadList は依頼を新しい順に取得
+func (r *AkerunRepollRepository) ReadList(ctx context.Context, offset, limit int) ([]*entities.AkerunRepoll, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.repolls.find(nil)
+	return page(sortBy(list, newestFirst(func(a *entities.AkerunRepoll) time.Time { return a.CreatedAt })), offset, limit), nil
+}
+
+// Count は依頼の件数を取得
+func (r *AkerunRepollRepository) Count(ctx context.Context) (int64, error) {
+	if err := r.hit("Count"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.repolls.count(nil), nil
+}
+
+// Update は状態と進み具合を更新
+func (r *AkerunRepollRepository) Update(ctx context.Context, repoll *entities.AkerunRepoll) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.repolls.ref(repoll.ID); ok {
+		stored.Status = repoll.Status
+		stored.CompletedWindows = repoll.CompletedWindows
+		stored.FetchedAccesses = repoll.FetchedAccesses
+		stored.ErrorMessage = repoll.ErrorMessage
+		stored.StartedAt = repoll.StartedAt
+		stored.FinishedAt = repoll.FinishedAt
+	}
+	return nil
+}
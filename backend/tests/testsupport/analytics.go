@@ -0,0 +1,217 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+var _ repository.AnalyticsRepository = (*AnalyticsRepository)(nil)
+
+// AnalyticsRepository はAnalyticsRepositoryのインメモリ実装
+// 集計はSQLに任せているので計算はせず、テストで設定した結果をそのまま返す（未設定なら空の結果）
+type AnalyticsRepository struct {
+	Faults
+	mu sync.Mutex
+
+	Summary             *entities.AnalyticsSummaryResult
+	TopHolders          []*entities.TopHolderResult
+	DailyStats          []*entities.DailyStatResult
+	TypeBreakdown       []*entities.TypeBreakdownResult
+	ReasonCodeBreakdown []*entities.ReasonCodeBreakdownResult
+	MonthlyIssuedPoints int64
+	MonthlyTransactions int64
+	ActiveUserCounts    []*entities.ActiveUserCountResult
+	CohortSizes         []*entities.CohortSizeResult
+	CohortActivity      []*entities.CohortActivityResult
+	FeatureUsage        []*entities.FeatureUsageResult
+	ExpiryForecast      []*entities.ExpiryForecastResult
+	OutstandingPoints   int64
+	CirculationVelocity *entities.CirculationVelocityResult
+	Conservation        *entities.PointConservation
+}
+
+// NewAnalyticsRepository は空の結果を返すAnalyticsRepositoryを作成
+func NewAnalyticsRepository() *AnalyticsRepository {
+	return &AnalyticsRepository{}
+}
+
+// GetUserBalanceSummary は設定された残高サマリーを返す
+func (r *AnalyticsRepository) GetUserBalanceSummary(ctx context.Context) (*entities.AnalyticsSummaryResult, error) {
+	if err := r.hit("GetUserBalanceSummary"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Summary == nil {
+		return &entities.AnalyticsSummaryResult{}, nil
+	}
+	return r.Summary, nil
+}
+
+// GetTopHolders は設定されたポイント保有上位ユーザーを先頭からlimit件返す
+func (r *AnalyticsRepository) GetTopHolders(ctx context.Context, limit int) ([]*entities.TopHolderResult, error) {
+	if err := r.hit("GetTopHolders"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return page(orEmpty(r.TopHolders), 0, limit), nil
+}
+
+// GetDailyStats は設定された日別統計を返す
+func (r *AnalyticsRepository) GetDailyStats(ctx context.Context, since time.Time) ([]*entities.DailyStatResult, error) {
+	if err := r.hit("GetDailyStats"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return orEmpty(r.DailyStats), nil
+}
+
+// GetTransactionTypeBreakdown は設定されたトランザクション種別構成を返す
+func (r *AnalyticsRepository) GetTransactionTypeBreakdown(ctx context.Context) ([]*entities.TypeBreakdownResult, error) {
+	if err := r.hit("GetTransactionTypeBreakdown"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return orEmpty(r.TypeBreakdown), nil
+}
+
+// GetReasonCodeBreakdown は設定された理由コード別の集計を返す（reasonCodeが空でなければそのコードのみ）
+func (r *AnalyticsRepository) GetReasonCodeBreakdown(ctx context.Context, since time.Time, reasonCode string) ([]*entities.ReasonCodeBreakdownResult, error) {
+	if err := r.hit("GetReasonCodeBreakdown"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	results := []*entities.ReasonCodeBreakdownResult{}
+	for _, b := range r.ReasonCodeBreakdown {
+		if reasonCode == "" || b.ReasonCode == reasonCode {
+			results = append(results, b)
+		}
+	}
+	return results, nil
+}
+
+// GetMonthlyIssuedPoints は設定された今月の発行ポイント数を返す
+func (r *AnalyticsRepository) GetMonthlyIssuedPoints(ctx context.Context) (int64, error) {
+	if err := r.hit("GetMonthlyIssuedPoints"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.MonthlyIssuedPoints, nil
+}
+
+// GetMonthlyTransactionCount は設定された今月のトランザクション数を返す
+func (r *AnalyticsRepository) GetMonthlyTransactionCount(ctx context.Context) (int64, error) {
+	if err := r.hit("GetMonthlyTransactionCount"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.MonthlyTransactions, nil
+}
+
+// GetActiveUserCounts は設定された期間ごとのアクティブユーザー数を返す
+func (r *AnalyticsRepository) GetActiveUserCounts(ctx context.Context, granularity entities.AnalyticsGranularity, basis entities.ActivityBasis, from, to time.Time) ([]*entities.ActiveUserCountResult, error) {
+	if err := r.hit("GetActiveUserCounts"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return orEmpty(r.ActiveUserCounts), nil
+}
+
+// GetCohortSizes は設定されたコホートごとの新規ユーザー数を返す
+func (r *AnalyticsRepository) GetCohortSizes(ctx context.Context, granularity entities.AnalyticsGranularity, from, to time.Time) ([]*entities.CohortSizeResult, error) {
+	if err := r.hit("GetCohortSizes"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return orEmpty(r.CohortSizes), nil
+}
+
+// GetCohortActivity は設定されたコホートごと・期間ごとのアクティブユーザー数を返す
+func (r *AnalyticsRepository) GetCohortActivity(ctx context.Context, granularity entities.AnalyticsGranularity, basis entities.ActivityBasis, from, to time.Time) ([]*entities.CohortActivityResult, error) {
+	if err := r.hit("GetCohortActivity"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return orEmpty(r.CohortActivity), nil
+}
+
+// GetFeatureUsage は設定された機能別の利用状況を返す
+func (r *AnalyticsRepository) GetFeatureUsage(ctx context.Context, from, to time.Time) ([]*entities.FeatureUsageResult, error) {
+	if err := r.hit("GetFeatureUsage"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return orEmpty(r.FeatureUsage), nil
+}
+
+// GetExpiryForecast は設定された失効予定ポイントを先頭からweeks件返す
+func (r *AnalyticsRepository) GetExpiryForecast(ctx context.Context, from time.Time, weeks int) ([]*entities.ExpiryForecastResult, error) {
+	if err := r.hit("GetExpiryForecast"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return page(orEmpty(r.ExpiryForecast), 0, weeks), nil
+}
+
+// GetOutstandingPoints は設定された失効前のポイントの合計を返す
+func (r *AnalyticsRepository) GetOutstandingPoints(ctx context.Context, now time.Time) (int64, error) {
+	if err := r.hit("GetOutstandingPoints"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.OutstandingPoints, nil
+}
+
+// GetCirculationVelocity は設定された流通速度を返す
+func (r *AnalyticsRepository) GetCirculationVelocity(ctx context.Context, since time.Time) (*entities.CirculationVelocityResult, error) {
+	if err := r.hit("GetCirculationVelocity"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.CirculationVelocity == nil {
+		return &entities.CirculationVelocityResult{}, nil
+	}
+	return r.CirculationVelocity, nil
+}
+
+// GetPointConservation は設定されたポイントの保存の確認結果を返す（未設定なら残高・入出金なし）
+func (r *AnalyticsRepository) GetPointConservation(ctx context.Context) (*entities.PointConservation, error) {
+	if err := r.hit("GetPointConservation"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Conservation == nil {
+		conservation := &entities.PointConservation{}
+		for _, account := range entities.SystemAccounts() {
+			conservation.Accounts = append(conservation.Accounts, &entities.SystemAccountBalance{Account: account})
+		}
+		return conservation, nil
+	}
+	return r.Conservation, nil
+}
+
+// orEmpty はnilのスライスを空のスライスにする（JSONで null ではなく [] になるように）
+func orEmpty[T any](list []*T) []*T {
+	if list == nil {
+		return []*T{}
+	}
+	return list
+}
@@ -0,0 +1,165 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.AnnouncementRepository = (*AnnouncementRepository)(nil)
+
+// AnnouncementRepository はAnnouncementRepositoryのインメモリ実装
+type AnnouncementRepository struct {
+	Faults
+	mu            sync.Mutex
+	announcements *table[uuid.UUID, entities.Announcement]
+	dismissals    map[[2]uuid.UUID]time.Time // {お知らせID, ユーザーID} → 非表示にした日時
+}
+
+// NewAnnouncementRepository は空のAnnouncementRepositoryを作成
+func NewAnnouncementRepository() *AnnouncementRepository {
+	return &AnnouncementRepository{
+		announcements: newTable[uuid.UUID, entities.Announcement](),
+		dismissals:    make(map[[2]uuid.UUID]time.Time),
+	}
+}
+
+// Create はお知らせを作成
+func (r *AnnouncementRepository) Create(ctx context.Context, announcement *entities.Announcement) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.announcements.has(announcement.ID) {
+		return ErrDuplicate
+	}
+	r.announcements.put(announcement.ID, announcement)
+	return nil
+}
+
+// Read はIDでお知らせを取得
+func (r *AnnouncementRepository) Read(ctx context.Context, id uuid.UUID) (*entities.Announcement, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if a, ok := r.announcements.get(id); ok {
+		return a, nil
+	}
+	return nil, entities.ErrAnnouncementNotFound
+}
+
+// Update はお知らせを更新
+func (r *AnnouncementRepository) Update(ctx context.Context, announcement *entities.Announcement) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.announcements.ref(announcement.ID)
+	if !ok {
+		return entities.ErrAnnouncementNotFound
+	}
+	updated := *announcement
+	updated.CreatedBy = stored.CreatedBy
+	updated.CreatedAt = stored.CreatedAt
+	*stored = updated
+	return nil
+}
+
+// Delete はお知らせを削除
+func (r *AnnouncementRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.announcements.remove(id) {
+		return entities.ErrAnnouncementNotFound
+	}
+	return nil
+}
+
+// ReadList はお知らせを表示開始日時の新しい順に取得
+func (r *AnnouncementRepository) ReadList(ctx context.Context, offset, limit int) ([]*entities.Announcement, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := sortBy(r.announcements.find(nil), newestFirst(func(a *entities.Announcement) time.Time { return a.StartsAt }))
+	return page(list, offset, limit), nil
+}
+
+// Count はお知らせの件数を取得
+func (r *AnnouncementRepository) Count(ctx context.Context) (int64, error) {
+	if err := r.hit("Count"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.announcements.count(nil), nil
+}
+
+// ReadActive はnow時点で表示期間内のお知らせを重要度の高い順・表示開始日時の新しい順に取得
+func (r *AnnouncementRepository) ReadActive(ctx context.Context, now time.Time) ([]*entities.Announcement, error) {
+	if err := r.hit("ReadActive"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.announcements.find(func(a *entities.Announcement) bool {
+		return !a.StartsAt.After(now) && (a.EndsAt == nil || a.EndsAt.After(now))
+	})
+	rank := func(a *entities.Announcement) int {
+		switch a.Severity {
+		case entities.AnnouncementSeverityCritical:
+			return 0
+		case entities.AnnouncementSeverityWarning:
+			return 1
+		}
+		return 2
+	}
+	return sortBy(list, func(a, b *entities.Announcement) bool {
+		if rank(a) != rank(b) {
+			return rank(a) < rank(b)
+		}
+		return a.StartsAt.After(b.StartsAt)
+	}), nil
+}
+
+// Dismiss はお知らせの非表示を記録（記録済みなら何もしない）
+func (r *AnnouncementRepository) Dismiss(ctx context.Context, announcementID, userID uuid.UUID, dismissedAt time.Time) error {
+	if err := r.hit("Dismiss"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := [2]uuid.UUID{announcementID, userID}
+	if _, ok := r.dismissals[key]; !ok {
+		r.dismissals[key] = dismissedAt
+	}
+	return nil
+}
+
+// ReadDismissedIDs は指定したお知らせのうちユーザーが非表示にしたもののIDを取得
+func (r *AnnouncementRepository) ReadDismissedIDs(ctx context.Context, userID uuid.UUID, announcementIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if err := r.hit("ReadDismissedIDs"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids := []uuid.UUID{}
+	for _, id := range announcementIDs {
+		if _, ok := r.dismissals[[2]uuid.UUID{id, userID}]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,103 @@
+package testsupport
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.ArchivedUserRepository = (*ArchivedUserRepository)(nil)
+
+// ArchivedUserRepository はArchivedUserRepositoryのインメモリ実装
+// Restore はユーザーを users に作成する（txは使わない）
+type ArchivedUserRepository struct {
+	Faults
+	mu       sync.Mutex
+	users    *UserRepository
+	archived *table[uuid.UUID, entities.ArchivedUser]
+}
+
+// NewArchivedUserRepository は空のArchivedUserRepositoryを作成
+func NewArchivedUserRepository(users *UserRepository) *ArchivedUserRepository {
+	return &ArchivedUserRepository{users: users, archived: newTable[uuid.UUID, entities.ArchivedUser]()}
+}
+
+// Create はアーカイブユーザーを作成
+func (r *ArchivedUserRepository) Create(ctx context.Context, archivedUser *entities.ArchivedUser) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.archived.has(archivedUser.ID) {
+		return ErrDuplicate
+	}
+	r.archived.put(archivedUser.ID, archivedUser)
+	return nil
+}
+
+// Read はIDでアーカイブユーザーを検索
+func (r *ArchivedUserRepository) Read(ctx context.Context, id uuid.UUID) (*entities.ArchivedUser, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if a, ok := r.archived.get(id); ok {
+		return a, nil
+	}
+	return nil, errors.New("archived user not found")
+}
+
+// ReadByUsername はユーザー名でアーカイブユーザーを検索
+func (r *ArchivedUserRepository) ReadByUsername(ctx context.Context, username string) (*entities.ArchivedUser, error) {
+	if err := r.hit("ReadByUsername"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if a, ok := r.archived.first(func(a *entities.ArchivedUser) bool { return a.Username == username }); ok {
+		return a, nil
+	}
+	return nil, errors.New("archived user not found")
+}
+
+// ReadList はアーカイブユーザー一覧をアーカイブ日時の新しい順に取得
+func (r *ArchivedUserRepository) ReadList(ctx context.Context, offset, limit int) ([]*entities.ArchivedUser, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := sortBy(r.archived.find(nil), newestFirst(func(a *entities.ArchivedUser) time.Time { return a.ArchivedAt }))
+	return page(list, offset, limit), nil
+}
+
+// Count はアーカイブユーザー総数を取得
+func (r *ArchivedUserRepository) Count(ctx context.Context) (int64, error) {
+	if err := r.hit("Count"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.archived.count(nil), nil
+}
+
+// Restore はユーザーを作成してアーカイブから削除する
+func (r *ArchivedUserRepository) Restore(ctx context.Context, tx interface{}, archivedUser *entities.ArchivedUser, user *entities.User) error {
+	if err := r.hit("Restore"); err != nil {
+		return err
+	}
+	if err := r.users.create(user); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.archived.remove(archivedUser.ID)
+	return nil
+}
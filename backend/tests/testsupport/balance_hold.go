@@ -0,0 +1,84 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.BalanceHoldRepository = (*BalanceHoldRepository)(nil)
+
+// BalanceHoldRepository はBalanceHoldRepositoryのインメモリ実装
+// 戻せる確保を探すには transferRequests の状態を使う
+type BalanceHoldRepository struct {
+	Faults
+	mu               sync.Mutex
+	transferRequests *TransferRequestRepository
+	holds            *table[uuid.UUID, entities.BalanceHold]
+}
+
+// NewBalanceHoldRepository は空のBalanceHoldRepositoryを作成
+func NewBalanceHoldRepository(transferRequests *TransferRequestRepository) *BalanceHoldRepository {
+	return &BalanceHoldRepository{transferRequests: transferRequests, holds: newTable[uuid.UUID, entities.BalanceHold]()}
+}
+
+// Create は確保を作成（同じ送金リクエストの確保があればErrDuplicate）
+func (r *BalanceHoldRepository) Create(ctx context.Context, hold *entities.BalanceHold) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.holds.has(hold.TransferRequestID) {
+		return ErrDuplicate
+	}
+	r.holds.put(hold.TransferRequestID, hold)
+	return nil
+}
+
+// ReadByTransferRequestIDForUpdate は送金リクエストの確保を取得（確保していなければnil）
+func (r *BalanceHoldRepository) ReadByTransferRequestIDForUpdate(ctx context.Context, transferRequestID uuid.UUID) (*entities.BalanceHold, error) {
+	if err := r.hit("ReadByTransferRequestIDForUpdate"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if hold, ok := r.holds.get(transferRequestID); ok {
+		return hold, nil
+	}
+	return nil, nil
+}
+
+// Update は確保の状態を更新
+func (r *BalanceHoldRepository) Update(ctx context.Context, hold *entities.BalanceHold) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.holds.put(hold.TransferRequestID, hold)
+	return nil
+}
+
+// ReadReleasable は送金リクエストが承認待ちでなくなったのに確保中のものを古い順に取得
+func (r *BalanceHoldRepository) ReadReleasable(ctx context.Context, limit int) ([]*entities.BalanceHold, error) {
+	if err := r.hit("ReadReleasable"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	active := r.holds.find(func(h *entities.BalanceHold) bool { return h.IsActive() })
+	r.mu.Unlock()
+
+	releasable := make([]*entities.BalanceHold, 0, len(active))
+	for _, hold := range active {
+		tr, _ := r.transferRequests.Read(ctx, hold.TransferRequestID)
+		if tr != nil && !tr.IsOpen() {
+			releasable = append(releasable, hold)
+		}
+	}
+	return page(sortBy(releasable, oldestFirst(func(h *entities.BalanceHold) time.Time { return h.CreatedAt })), 0, limit), nil
+}
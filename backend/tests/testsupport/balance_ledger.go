@@ -0,0 +1,101 @@
+package testsupport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.BalanceLedgerRepository = (*BalanceLedgerRepository)(nil)
+
+// BalanceLedgerRepository はBalanceLedgerRepositoryのインメモリ実装
+// 取引履歴からの残高は users・transactions・archive・batches・exchanges の内容から計算する
+type BalanceLedgerRepository struct {
+	Faults
+	users        *UserRepository
+	transactions *TransactionRepository
+	archive      *TransactionArchiveRepository
+	batches      *PointBatchRepository
+	exchanges    *ProductExchangeRepository
+}
+
+// NewBalanceLedgerRepository はBalanceLedgerRepositoryを作成
+func NewBalanceLedgerRepository(users *UserRepository, transactions *TransactionRepository, archive *TransactionArchiveRepository, batches *PointBatchRepository, exchanges *ProductExchangeRepository) *BalanceLedgerRepository {
+	return &BalanceLedgerRepository{users: users, transactions: transactions, archive: archive, batches: batches, exchanges: exchanges}
+}
+
+// ReadUserIDs はafterより後のユーザーIDを昇順にlimit件取得
+func (r *BalanceLedgerRepository) ReadUserIDs(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	if err := r.hit("ReadUserIDs"); err != nil {
+		return nil, err
+	}
+	users := sortBy(r.users.all(func(u *entities.User) bool { return u.ID.String() > after.String() }),
+		func(a, b *entities.User) bool { return a.ID.String() < b.ID.String() })
+	users = page(users, 0, limit)
+	ids := make([]uuid.UUID, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+	return ids, nil
+}
+
+// LockUsers は何もしない（インメモリでは行ロックは取らない）
+func (r *BalanceLedgerRepository) LockUsers(ctx context.Context, userIDs []uuid.UUID) error {
+	return r.hit("LockUsers")
+}
+
+// ReadBalanceChecks は保存されている残高と取引履歴から計算した残高をユーザーID順に取得
+// 移行バッチがあればその作成以降の取引だけを積み上げ、キャンセルした交換は返金として加える
+func (r *BalanceLedgerRepository) ReadBalanceChecks(ctx context.Context, userIDs []uuid.UUID) ([]*entities.BalanceCheck, error) {
+	if err := r.hit("ReadBalanceChecks"); err != nil {
+		return nil, err
+	}
+	checks := []*entities.BalanceCheck{}
+	for _, u := range r.users.all(func(u *entities.User) bool { return containsID(userIDs, u.ID) }) {
+		checks = append(checks, &entities.BalanceCheck{
+			UserID:        u.ID,
+			Username:      u.Username,
+			StoredBalance: u.Balance,
+			LedgerBalance: r.ledgerBalance(u.ID),
+		})
+	}
+	return sortBy(checks, func(a, b *entities.BalanceCheck) bool { return a.UserID.String() < b.UserID.String() }), nil
+}
+
+func (r *BalanceLedgerRepository) ledgerBalance(userID uuid.UUID) int64 {
+	var balance int64
+	var since *time.Time
+	for _, b := range r.batches.all(func(b *entities.PointBatch) bool {
+		return b.UserID == userID && b.SourceType == entities.PointBatchSourceMigration
+	}) {
+		balance += b.OriginalAmount
+		if since == nil || b.CreatedAt.Before(*since) {
+			createdAt := b.CreatedAt
+			since = &createdAt
+		}
+	}
+	after := func(t time.Time) bool { return since == nil || t.After(*since) }
+
+	counted := func(t *entities.Transaction) bool {
+		return t.Status == entities.TransactionStatusCompleted &&
+			t.TransactionType != entities.TransactionTypeBalanceCorrection && after(t.CreatedAt)
+	}
+	// 保持期間を過ぎて移した取引も積み上げる
+	for _, t := range append(r.transactions.all(counted), r.archive.all(counted)...) {
+		if t.ToUserID != nil && *t.ToUserID == userID {
+			balance += t.Amount
+		}
+		if t.FromUserID != nil && *t.FromUserID == userID {
+			balance -= t.Amount
+		}
+	}
+	for _, e := range r.exchanges.all(func(e *entities.ProductExchange) bool {
+		return e.UserID == userID && e.Status == entities.ExchangeStatusCancelled && after(e.CreatedAt)
+	}) {
+		balance += e.PointsUsed
+	}
+	return balance
+}
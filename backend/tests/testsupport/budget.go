@@ -0,0 +1,171 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.BudgetRepository = (*BudgetRepository)(nil)
+
+// BudgetRepository はBudgetRepositoryのインメモリ実装
+// 通知先の管理者は users から探す
+type BudgetRepository struct {
+	Faults
+	mu      sync.Mutex
+	users   *UserRepository
+	budgets *table[uuid.UUID, entities.Budget]
+	usages  *table[budgetPeriod, entities.BudgetUsage]
+}
+
+type budgetPeriod struct {
+	budgetID    uuid.UUID
+	periodStart int64
+}
+
+// NewBudgetRepository は空のBudgetRepositoryを作成
+func NewBudgetRepository(users *UserRepository) *BudgetRepository {
+	return &BudgetRepository{
+		users:   users,
+		budgets: newTable[uuid.UUID, entities.Budget](),
+		usages:  newTable[budgetPeriod, entities.BudgetUsage](),
+	}
+}
+
+// Create は予算を作成
+func (r *BudgetRepository) Create(ctx context.Context, budget *entities.Budget) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.budgets.has(budget.ID) {
+		return ErrDuplicate
+	}
+	r.budgets.put(budget.ID, budget)
+	return nil
+}
+
+// Read はIDで予算を取得
+func (r *BudgetRepository) Read(ctx context.Context, id uuid.UUID) (*entities.Budget, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if b, ok := r.budgets.get(id); ok {
+		return b, nil
+	}
+	return nil, entities.ErrBudgetNotFound
+}
+
+// ReadList は予算を作成日時の古い順に取得
+func (r *BudgetRepository) ReadList(ctx context.Context) ([]*entities.Budget, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return sortBy(r.budgets.find(nil), oldestFirst(func(b *entities.Budget) time.Time { return b.CreatedAt })), nil
+}
+
+// Update は予算名・金額・超過時の扱い・有効状態を更新
+func (r *BudgetRepository) Update(ctx context.Context, budget *entities.Budget) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.budgets.ref(budget.ID)
+	if !ok {
+		return entities.ErrBudgetNotFound
+	}
+	stored.Name = budget.Name
+	stored.Amount = budget.Amount
+	stored.Enforcement = budget.Enforcement
+	stored.IsActive = budget.IsActive
+	stored.UpdatedAt = budget.UpdatedAt
+	return nil
+}
+
+// Delete は予算を削除
+func (r *BudgetRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.budgets.remove(id) {
+		return entities.ErrBudgetNotFound
+	}
+	return nil
+}
+
+// ReadApplicableForUpdate は管理者の付与に適用される有効な予算をID順に取得
+func (r *BudgetRepository) ReadApplicableForUpdate(ctx context.Context, adminID uuid.UUID, departmentIDs []uuid.UUID) ([]*entities.Budget, error) {
+	if err := r.hit("ReadApplicableForUpdate"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.budgets.find(func(b *entities.Budget) bool {
+		if !b.IsActive {
+			return false
+		}
+		switch b.Scope {
+		case entities.BudgetScopeGlobal:
+			return true
+		case entities.BudgetScopeAdmin:
+			return b.ScopeID != nil && *b.ScopeID == adminID
+		case entities.BudgetScopeDepartment:
+			return b.ScopeID != nil && containsID(departmentIDs, *b.ScopeID)
+		}
+		return false
+	})
+	return sortBy(list, func(a, b *entities.Budget) bool { return a.ID.String() < b.ID.String() }), nil
+}
+
+// ReadUsage は予算の期間の消化状況を取得（記録がなければConsumed=0）
+func (r *BudgetRepository) ReadUsage(ctx context.Context, budgetID uuid.UUID, periodStart time.Time) (*entities.BudgetUsage, error) {
+	if err := r.hit("ReadUsage"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.usages.get(budgetPeriod{budgetID, periodStart.UnixNano()}); ok {
+		return u, nil
+	}
+	return &entities.BudgetUsage{BudgetID: budgetID, PeriodStart: periodStart}, nil
+}
+
+// SaveUsage は期間の消化状況を保存
+func (r *BudgetRepository) SaveUsage(ctx context.Context, usage *entities.BudgetUsage) error {
+	if err := r.hit("SaveUsage"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.usages.put(budgetPeriod{usage.BudgetID, usage.PeriodStart.UnixNano()}, usage)
+	return nil
+}
+
+// ReadAlertRecipients は有効な管理者のIDを取得
+func (r *BudgetRepository) ReadAlertRecipients(ctx context.Context) ([]uuid.UUID, error) {
+	if err := r.hit("ReadAlertRecipients"); err != nil {
+		return nil, err
+	}
+	return activeAdminIDs(r.users), nil
+}
+
+// activeAdminIDs は有効な管理者のIDを登録順に返す
+func activeAdminIDs(users *UserRepository) []uuid.UUID {
+	ids := []uuid.UUID{}
+	for _, u := range users.all(func(u *entities.User) bool { return u.IsAdmin() && u.IsActive }) {
+		ids = append(ids, u.ID)
+	}
+	return ids
+}
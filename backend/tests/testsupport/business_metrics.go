@@ -0,0 +1,41 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+var _ repository.BusinessMetricsRepository = (*BusinessMetricsRepository)(nil)
+
+// BusinessMetricsRepository はBusinessMetricsRepositoryのインメモリ実装
+// 集計はSQLに任せているので計算はせず、テストで設定した指標をそのまま返す（未設定ならすべて0）
+type BusinessMetricsRepository struct {
+	Faults
+	mu sync.Mutex
+
+	Metrics *entities.BusinessMetrics
+}
+
+// NewBusinessMetricsRepository はすべて0の指標を返すBusinessMetricsRepositoryを作成
+func NewBusinessMetricsRepository() *BusinessMetricsRepository {
+	return &BusinessMetricsRepository{}
+}
+
+// GetBusinessMetrics は設定された指標のコピーを集計した日時をnowにして返す
+func (r *BusinessMetricsRepository) GetBusinessMetrics(ctx context.Context, dayStart, now time.Time) (*entities.BusinessMetrics, error) {
+	if err := r.hit("GetBusinessMetrics"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := entities.BusinessMetrics{}
+	if r.Metrics != nil {
+		m = *r.Metrics
+	}
+	m.CollectedAt = now
+	return &m, nil
+}
@@ -0,0 +1,87 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.CartRepository = (*CartRepository)(nil)
+
+// CartRepository はCartRepositoryのインメモリ実装
+type CartRepository struct {
+	Faults
+	mu    sync.Mutex
+	items *table[cartItemKey, entities.CartItem]
+}
+
+type cartItemKey struct {
+	userID    uuid.UUID
+	productID uuid.UUID
+}
+
+// NewCartRepository は空のCartRepositoryを作成
+func NewCartRepository() *CartRepository {
+	return &CartRepository{items: newTable[cartItemKey, entities.CartItem]()}
+}
+
+// ReadByUser はユーザーのカートの商品を入れた順に取得
+func (r *CartRepository) ReadByUser(ctx context.Context, userID uuid.UUID) ([]*entities.CartItem, error) {
+	if err := r.hit("ReadByUser"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.items.find(func(i *entities.CartItem) bool { return i.UserID == userID }), nil
+}
+
+// Read はカートの商品を取得
+func (r *CartRepository) Read(ctx context.Context, userID, productID uuid.UUID) (*entities.CartItem, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if item, ok := r.items.get(cartItemKey{userID, productID}); ok {
+		return item, nil
+	}
+	return nil, entities.ErrCartItemNotFound
+}
+
+// Save はカートの商品を作成または更新
+func (r *CartRepository) Save(ctx context.Context, item *entities.CartItem) error {
+	if err := r.hit("Save"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items.put(cartItemKey{item.UserID, item.ProductID}, item)
+	return nil
+}
+
+// Delete はカートから商品を削除
+func (r *CartRepository) Delete(ctx context.Context, userID, productID uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.items.remove(cartItemKey{userID, productID}) {
+		return entities.ErrCartItemNotFound
+	}
+	return nil
+}
+
+// DeleteByUser はユーザーのカートを空にする
+func (r *CartRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	if err := r.hit("DeleteByUser"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items.removeWhere(func(i *entities.CartItem) bool { return i.UserID == userID })
+	return nil
+}
@@ -0,0 +1,140 @@
+package testsupport
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.CategoryRepository = (*CategoryRepository)(nil)
+
+// CategoryRepository はCategoryRepositoryのインメモリ実装
+// 削除は論理削除で、削除済みのカテゴリはどのメソッドからも見えない
+type CategoryRepository struct {
+	Faults
+	clock
+	mu         sync.Mutex
+	categories *table[uuid.UUID, entities.Category]
+}
+
+// NewCategoryRepository は空のCategoryRepositoryを作成
+func NewCategoryRepository() *CategoryRepository {
+	return &CategoryRepository{categories: newTable[uuid.UUID, entities.Category]()}
+}
+
+// Create はカテゴリを作成
+func (r *CategoryRepository) Create(ctx context.Context, category *entities.Category) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.categories.has(category.ID) {
+		return ErrDuplicate
+	}
+	r.categories.put(category.ID, category)
+	return nil
+}
+
+// Read はIDでカテゴリを取得
+func (r *CategoryRepository) Read(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, ok := r.categories.first(func(c *entities.Category) bool { return c.ID == id && c.DeletedAt == nil }); ok {
+		return c, nil
+	}
+	return nil, errors.New("category not found")
+}
+
+// ReadByCode はコードでカテゴリを取得
+func (r *CategoryRepository) ReadByCode(ctx context.Context, code string) (*entities.Category, error) {
+	if err := r.hit("ReadByCode"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, ok := r.categories.first(func(c *entities.Category) bool { return c.Code == code && c.DeletedAt == nil }); ok {
+		return c, nil
+	}
+	return nil, errors.New("category not found")
+}
+
+// Update はカテゴリを更新
+func (r *CategoryRepository) Update(ctx context.Context, category *entities.Category) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.categories.ref(category.ID)
+	if !ok || stored.DeletedAt != nil {
+		return nil
+	}
+	updated := *category
+	updated.CreatedAt = stored.CreatedAt
+	updated.DeletedAt = nil
+	updated.UpdatedAt = r.now()
+	*stored = updated
+	return nil
+}
+
+// Delete はカテゴリを論理削除
+func (r *CategoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.categories.ref(id); ok && stored.DeletedAt == nil {
+		now := r.now()
+		stored.DeletedAt = &now
+	}
+	return nil
+}
+
+// ReadList はカテゴリを表示順・名前順に取得
+func (r *CategoryRepository) ReadList(ctx context.Context, activeOnly bool) ([]*entities.Category, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.categories.find(func(c *entities.Category) bool {
+		return c.DeletedAt == nil && (!activeOnly || c.IsActive)
+	})
+	return sortBy(list, func(a, b *entities.Category) bool {
+		if a.DisplayOrder != b.DisplayOrder {
+			return a.DisplayOrder < b.DisplayOrder
+		}
+		return a.Name < b.Name
+	}), nil
+}
+
+// Count は削除されていないカテゴリの件数を取得
+func (r *CategoryRepository) Count(ctx context.Context) (int64, error) {
+	if err := r.hit("Count"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.categories.count(func(c *entities.Category) bool { return c.DeletedAt == nil }), nil
+}
+
+// ExistsCode はコードが使われているかを確認（excludeIDのカテゴリは除く）
+func (r *CategoryRepository) ExistsCode(ctx context.Context, code string, excludeID *uuid.UUID) (bool, error) {
+	if err := r.hit("ExistsCode"); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.categories.count(func(c *entities.Category) bool {
+		return c.Code == code && c.DeletedAt == nil && (excludeID == nil || c.ID != *excludeID)
+	}) > 0, nil
+}
@@ -0,0 +1,91 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.ContentViolationRepository = (*ContentViolationRepository)(nil)
+
+// ContentViolationRepository はContentViolationRepositoryのインメモリ実装
+type ContentViolationRepository struct {
+	Faults
+	mu         sync.Mutex
+	violations *table[uuid.UUID, entities.ContentViolation]
+}
+
+// NewContentViolationRepository は空のContentViolationRepositoryを作成
+func NewContentViolationRepository() *ContentViolationRepository {
+	return &ContentViolationRepository{violations: newTable[uuid.UUID, entities.ContentViolation]()}
+}
+
+// Create は違反の記録を作成
+func (r *ContentViolationRepository) Create(ctx context.Context, violation *entities.ContentViolation) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.violations.has(violation.ID) {
+		return ErrDuplicate
+	}
+	r.violations.put(violation.ID, violation)
+	return nil
+}
+
+// Read はIDで違反の記録を取得
+func (r *ContentViolationRepository) Read(ctx context.Context, id uuid.UUID) (*entities.ContentViolation, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if v, ok := r.violations.get(id); ok {
+		return v, nil
+	}
+	return nil, entities.ErrViolationNotFound
+}
+
+// ReadList は違反の記録を新しい順に取得（unreviewedOnlyなら未確認のみ）
+func (r *ContentViolationRepository) ReadList(ctx context.Context, unreviewedOnly bool, offset, limit int) ([]*entities.ContentViolation, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.violations.find(unreviewed(unreviewedOnly))
+	return page(sortBy(list, newestFirst(func(v *entities.ContentViolation) time.Time { return v.CreatedAt })), offset, limit), nil
+}
+
+// Count は違反の記録の件数を取得
+func (r *ContentViolationRepository) Count(ctx context.Context, unreviewedOnly bool) (int64, error) {
+	if err := r.hit("Count"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.violations.count(unreviewed(unreviewedOnly)), nil
+}
+
+// UpdateReviewed は確認した日時と管理者を保存
+func (r *ContentViolationRepository) UpdateReviewed(ctx context.Context, violation *entities.ContentViolation) error {
+	if err := r.hit("UpdateReviewed"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.violations.ref(violation.ID); ok {
+		stored.ReviewedAt = violation.ReviewedAt
+		stored.ReviewedBy = violation.ReviewedBy
+	}
+	return nil
+}
+
+func unreviewed(only bool) func(v *entities.ContentViolation) bool {
+	return func(v *entities.ContentViolation) bool { return !only || v.ReviewedAt == nil }
+}
@@ -0,0 +1,186 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.DailyBonusRepository = (*DailyBonusRepository)(nil)
+
+// DailyBonusRepository はDailyBonusRepositoryのインメモリ実装
+// 同じユーザー・同じボーナス日のボーナスは1件だけ保存できる
+type DailyBonusRepository struct {
+	Faults
+	clock
+	mu         sync.Mutex
+	users      *UserRepository
+	bonuses    *table[uuid.UUID, entities.DailyBonus]
+	lastPolled map[string]time.Time
+}
+
+// NewDailyBonusRepository は空のDailyBonusRepositoryを作成（ユーザー名はusersから引く）
+func NewDailyBonusRepository(users *UserRepository) *DailyBonusRepository {
+	return &DailyBonusRepository{
+		users:      users,
+		bonuses:    newTable[uuid.UUID, entities.DailyBonus](),
+		lastPolled: make(map[string]time.Time),
+	}
+}
+
+// Create はデイリーボーナスを作成（同じユーザー・同じボーナス日があればErrDuplicate）
+func (r *DailyBonusRepository) Create(ctx context.Context, bonus *entities.DailyBonus) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.bonuses.has(bonus.ID) || r.bonuses.count(onBonusDate(bonus.UserID, bonus.BonusDate)) > 0 {
+		return ErrDuplicate
+	}
+	r.bonuses.put(bonus.ID, bonus)
+	return nil
+}
+
+// ReadByUserAndDate はユーザーのボーナス日のボーナスを取得（なければnil）
+func (r *DailyBonusRepository) ReadByUserAndDate(ctx context.Context, userID uuid.UUID, date time.Time) (*entities.DailyBonus, error) {
+	if err := r.hit("ReadByUserAndDate"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if b, ok := r.bonuses.first(onBonusDate(userID, date)); ok {
+		return b, nil
+	}
+	return nil, nil
+}
+
+// ReadRecentByUser はユーザーのボーナスをボーナス日の新しい順に取得
+func (r *DailyBonusRepository) ReadRecentByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*entities.DailyBonus, error) {
+	if err := r.hit("ReadRecentByUser"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.bonuses.find(func(b *entities.DailyBonus) bool { return b.UserID == userID })
+	return page(sortBy(list, newestFirst(func(b *entities.DailyBonus) time.Time { return b.BonusDate })), 0, limit), nil
+}
+
+// CountByUser はユーザーのボーナス獲得日数を取得
+func (r *DailyBonusRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	if err := r.hit("CountByUser"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.bonuses.count(func(b *entities.DailyBonus) bool { return b.UserID == userID }), nil
+}
+
+// GetLastPolledAt はAkerun組織の前回ポーリング時刻を取得（未記録なら24時間前）
+func (r *DailyBonusRepository) GetLastPolledAt(ctx context.Context, organizationID string) (time.Time, error) {
+	if err := r.hit("GetLastPolledAt"); err != nil {
+		return time.Time{}, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := r.lastPolled[organizationID]; ok {
+		return t, nil
+	}
+	return r.now().Add(-24 * time.Hour), nil
+}
+
+// UpdateLastPolledAt はAkerun組織のポーリング時刻を更新
+func (r *DailyBonusRepository) UpdateLastPolledAt(ctx context.Context, organizationID string, t time.Time) error {
+	if err := r.hit("UpdateLastPolledAt"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastPolled[organizationID] = t
+	return nil
+}
+
+// MarkAsViewed はボーナスを閲覧済みにする
+func (r *DailyBonusRepository) MarkAsViewed(ctx context.Context, id uuid.UUID) error {
+	if err := r.hit("MarkAsViewed"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if b, ok := r.bonuses.ref(id); ok {
+		b.IsViewed = true
+	}
+	return nil
+}
+
+// UpdateDrawnResult は抽選結果を保存（抽選済みなら何もしない）
+func (r *DailyBonusRepository) UpdateDrawnResult(ctx context.Context, id uuid.UUID, bonusPoints int64, lotteryTierID *uuid.UUID, lotteryTierName string) error {
+	if err := r.hit("UpdateDrawnResult"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if b, ok := r.bonuses.ref(id); ok && !b.IsDrawn {
+		b.BonusPoints = bonusPoints
+		b.LotteryTierID = lotteryTierID
+		b.LotteryTierName = lotteryTierName
+		b.IsDrawn = true
+	}
+	return nil
+}
+
+// onBonusDate はユーザーのボーナス日が同じ日付かの条件（時刻は見ない）
+func onBonusDate(userID uuid.UUID, date time.Time) func(b *entities.DailyBonus) bool {
+	day := date.Format(time.DateOnly)
+	return func(b *entities.DailyBonus) bool {
+		return b.UserID == userID && b.BonusDate.Format(time.DateOnly) == day
+	}
+}
+
+// bonusDays はユーザーがボーナスを受け取った日（YYYY-MM-DD）を返す（他のリポジトリが連続日数を数えるために使う）
+func (r *DailyBonusRepository) bonusDays(userID uuid.UUID) map[string]bool {
+	days := make(map[string]bool)
+	if r == nil {
+		return days
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, b := range r.bonuses.refs(func(b *entities.DailyBonus) bool { return b.UserID == userID }) {
+		days[b.BonusDate.Format(time.DateOnly)] = true
+	}
+	return days
+}
+
+// StreamByPeriod はボーナス日が期間内のボーナスをユーザー名付きで、ボーナス日・入退室日時の順にfnへ渡す
+func (r *DailyBonusRepository) StreamByPeriod(ctx context.Context, period entities.BonusReportPeriod, fn func(*entities.DailyBonusRecord) error) error {
+	if err := r.hit("StreamByPeriod"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	list := r.bonuses.find(func(b *entities.DailyBonus) bool {
+		return !b.BonusDate.Before(period.From) && b.BonusDate.Before(period.To)
+	})
+	r.mu.Unlock()
+
+	sortBy(list, func(a, b *entities.DailyBonus) bool {
+		if !a.BonusDate.Equal(b.BonusDate) {
+			return a.BonusDate.Before(b.BonusDate)
+		}
+		return a.AccessedAt != nil && (b.AccessedAt == nil || a.AccessedAt.Before(*b.AccessedAt))
+	})
+	for _, b := range list {
+		record := &entities.DailyBonusRecord{DailyBonus: *b}
+		if u := r.users.lookup(b.UserID); u != nil {
+			record.Username = u.Username
+			record.DisplayName = u.DisplayName
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.DataExportRepository = (*DataExportRepository)(nil)
+
+// DataExportRepository はDataExportRepositoryのインメモリ実装
+type DataExportRepository struct {
+	Faults
+	mu      sync.Mutex
+	exports *table[uuid.UUID, entities.DataExport]
+}
+
+// NewDataExportRepository は空のDataExportRepositoryを作成
+func NewDataExportRepository() *DataExportRepository {
+	return &DataExportRepository{exports: newTable[uuid.UUID, entities.DataExport]()}
+}
+
+// Create はエクスポート依頼を作成
+func (r *DataExportRepository) Create(ctx context.Context, export *entities.DataExport) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.exports.has(export.ID) {
+		return ErrDuplicate
+	}
+	r.exports.put(export.ID, export)
+	return nil
+}
+
+// Read はIDでエクスポート依頼を取得
+func (r *DataExportRepository) Read(ctx context.Context, id uuid.UUID) (*entities.DataExport, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e, ok := r.exports.get(id); ok {
+		return e, nil
+	}
+	return nil, entities.ErrDataExportNotFound
+}
+
+// ReadLatestByUser はユーザーの最新のエクスポート依頼を取得（なければnil）
+func (r *DataExportRepository) ReadLatestByUser(ctx context.Context, userID uuid.UUID) (*entities.DataExport, error) {
+	if err := r.hit("ReadLatestByUser"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := sortBy(r.exports.find(func(e *entities.DataExport) bool { return e.UserID == userID }),
+		newestFirst(func(e *entities.DataExport) time.Time { return e.RequestedAt }))
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// ClaimPending は処理待ちの依頼を依頼日時の古い順に作成中へ更新して取得
+func (r *DataExportRepository) ClaimPending(ctx context.Context, limit int) ([]*entities.DataExport, error) {
+	if err := r.hit("ClaimPending"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pending := sortBy(r.exports.refs(func(e *entities.DataExport) bool {
+		return e.Status == entities.DataExportStatusPending && e.UserID != uuid.Nil
+	}), oldestFirst(func(e *entities.DataExport) time.Time { return e.RequestedAt }))
+	claimed := page(pending, 0, limit)
+	for _, e := range claimed {
+		e.Status = entities.DataExportStatusProcessing
+	}
+	return copies(claimed), nil
+}
+
+// Update はエクスポート依頼の状態・ファイル・期限を更新
+func (r *DataExportRepository) Update(ctx context.Context, export *entities.DataExport) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.exports.ref(export.ID); ok {
+		stored.Status = export.Status
+		stored.FilePath = export.FilePath
+		stored.ErrorMessage = export.ErrorMessage
+		stored.CompletedAt = export.CompletedAt
+		stored.ExpiresAt = export.ExpiresAt
+	}
+	return nil
+}
+
+// ReadListExpired はダウンロード期限が切れた（または退会済みユーザーの）作成済みの依頼を期限の古い順に取得
+func (r *DataExportRepository) ReadListExpired(ctx context.Context, now time.Time, limit int) ([]*entities.DataExport, error) {
+	if err := r.hit("ReadListExpired"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.exports.find(func(e *entities.DataExport) bool {
+		return e.Status == entities.DataExportStatusReady &&
+			((e.ExpiresAt != nil && !e.ExpiresAt.After(now)) || e.UserID == uuid.Nil)
+	})
+	expiresAt := func(e *entities.DataExport) time.Time {
+		if e.ExpiresAt == nil {
+			return time.Time{}
+		}
+		return *e.ExpiresAt
+	}
+	return page(sortBy(list, oldestFirst(expiresAt)), 0, limit), nil
+}
@@ -0,0 +1,218 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.DepartmentRepository = (*DepartmentRepository)(nil)
+
+// DepartmentRepository はDepartmentRepositoryのインメモリ実装
+// 所属の変更は users に、付与の集計は transactions に対して行う
+type DepartmentRepository struct {
+	Faults
+	mu           sync.Mutex
+	users        *UserRepository
+	transactions *TransactionRepository
+	departments  *table[uuid.UUID, entities.Department]
+}
+
+// NewDepartmentRepository は空のDepartmentRepositoryを作成
+func NewDepartmentRepository(users *UserRepository, transactions *TransactionRepository) *DepartmentRepository {
+	return &DepartmentRepository{
+		users:        users,
+		transactions: transactions,
+		departments:  newTable[uuid.UUID, entities.Department](),
+	}
+}
+
+// Create は部署を作成（同じ親の下に同じ名前があればErrDepartmentExists）
+func (r *DepartmentRepository) Create(ctx context.Context, department *entities.Department) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.existsSibling(department) {
+		return entities.ErrDepartmentExists
+	}
+	r.departments.put(department.ID, department)
+	return nil
+}
+
+// Read はIDで部署を取得
+func (r *DepartmentRepository) Read(ctx context.Context, id uuid.UUID) (*entities.Department, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	return r.read(id)
+}
+
+// ReadForUpdate はIDで部署を取得（インメモリでは行ロックは取らない）
+func (r *DepartmentRepository) ReadForUpdate(ctx context.Context, id uuid.UUID) (*entities.Department, error) {
+	if err := r.hit("ReadForUpdate"); err != nil {
+		return nil, err
+	}
+	return r.read(id)
+}
+
+func (r *DepartmentRepository) read(id uuid.UUID) (*entities.Department, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if d, ok := r.departments.get(id); ok {
+		return d, nil
+	}
+	return nil, entities.ErrDepartmentNotFound
+}
+
+// ReadList は部署を名前順に取得
+func (r *DepartmentRepository) ReadList(ctx context.Context) ([]*entities.Department, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return sortBy(r.departments.find(nil), func(a, b *entities.Department) bool { return a.Name < b.Name }), nil
+}
+
+// Update は部署名・親部署・月間予算を更新
+func (r *DepartmentRepository) Update(ctx context.Context, department *entities.Department) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.existsSibling(department) {
+		return entities.ErrDepartmentExists
+	}
+	stored, ok := r.departments.ref(department.ID)
+	if !ok {
+		return entities.ErrDepartmentNotFound
+	}
+	stored.Name = department.Name
+	stored.ParentID = department.ParentID
+	stored.MonthlyGrantBudget = department.MonthlyGrantBudget
+	stored.UpdatedAt = department.UpdatedAt
+	return nil
+}
+
+// Delete は部署を削除（配下の部署があればErrDepartmentHasChildren）
+func (r *DepartmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.departments.count(func(d *entities.Department) bool { return d.ParentID != nil && *d.ParentID == id }) > 0 {
+		return entities.ErrDepartmentHasChildren
+	}
+	if !r.departments.remove(id) {
+		return entities.ErrDepartmentNotFound
+	}
+	return nil
+}
+
+// SetUserDepartment はユーザーの所属部署を変更（nilなら未所属）
+func (r *DepartmentRepository) SetUserDepartment(ctx context.Context, userID uuid.UUID, departmentID *uuid.UUID) error {
+	if err := r.hit("SetUserDepartment"); err != nil {
+		return err
+	}
+	if !r.users.update(userID, func(u *entities.User) { u.DepartmentID = departmentID }) {
+		return entities.ErrUserNotFound
+	}
+	return nil
+}
+
+// SumAdminGrantsSince はsince以降に部署の所属ユーザーへ管理者が付与したポイントの合計を取得
+func (r *DepartmentRepository) SumAdminGrantsSince(ctx context.Context, departmentID uuid.UUID, since time.Time) (int64, error) {
+	if err := r.hit("SumAdminGrantsSince"); err != nil {
+		return 0, err
+	}
+	members := r.members(departmentID)
+	var total int64
+	for _, t := range r.transactions.all(completedSince(since, entities.TransactionTypeAdminGrant)) {
+		if t.ToUserID != nil && members[*t.ToUserID] {
+			total += t.Amount
+		}
+	}
+	return total, nil
+}
+
+// ReadBreakdown はsince以降の部署ごとの付与・利用ポイントを部署名順に集計
+func (r *DepartmentRepository) ReadBreakdown(ctx context.Context, since time.Time) ([]*entities.DepartmentBreakdownResult, error) {
+	if err := r.hit("ReadBreakdown"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	departments := sortBy(r.departments.find(nil), func(a, b *entities.Department) bool { return a.Name < b.Name })
+	r.mu.Unlock()
+
+	granted := r.transactions.all(completedSince(since, entities.TransactionTypeAdminGrant, entities.TransactionTypeSystemGrant, entities.TransactionTypeOnboardingBonus))
+	spent := r.transactions.all(completedSince(since, entities.TransactionTypeAdminDeduct))
+	results := make([]*entities.DepartmentBreakdownResult, 0, len(departments))
+	for _, d := range departments {
+		members := r.members(d.ID)
+		result := &entities.DepartmentBreakdownResult{
+			DepartmentID: d.ID,
+			Name:         d.Name,
+			ParentID:     d.ParentID,
+			MemberCount:  int64(len(members)),
+		}
+		for _, t := range granted {
+			if t.ToUserID != nil && members[*t.ToUserID] {
+				result.Granted += t.Amount
+			}
+		}
+		for _, t := range spent {
+			if t.FromUserID != nil && members[*t.FromUserID] {
+				result.Spent += t.Amount
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// existsSibling は同じ親の下に同じ名前の別の部署があるかを返す
+func (r *DepartmentRepository) existsSibling(department *entities.Department) bool {
+	return r.departments.count(func(d *entities.Department) bool {
+		if d.ID == department.ID || d.Name != department.Name {
+			return false
+		}
+		if d.ParentID == nil || department.ParentID == nil {
+			return d.ParentID == nil && department.ParentID == nil
+		}
+		return *d.ParentID == *department.ParentID
+	}) > 0
+}
+
+// members は部署に直接所属するユーザーのIDを返す
+func (r *DepartmentRepository) members(departmentID uuid.UUID) map[uuid.UUID]bool {
+	members := make(map[uuid.UUID]bool)
+	for _, u := range r.users.all(func(u *entities.User) bool {
+		return u.DepartmentID != nil && *u.DepartmentID == departmentID
+	}) {
+		members[u.ID] = true
+	}
+	return members
+}
+
+// completedSince はsince以降に完了した指定種別の取引の条件
+func completedSince(since time.Time, types ...entities.TransactionType) func(t *entities.Transaction) bool {
+	return func(t *entities.Transaction) bool {
+		if t.Status != entities.TransactionStatusCompleted || t.CreatedAt.Before(since) {
+			return false
+		}
+		for _, typ := range types {
+			if t.TransactionType == typ {
+				return true
+			}
+		}
+		return false
+	}
+}
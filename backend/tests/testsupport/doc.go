@@ -0,0 +1,14 @@
+// Package testsupport はテスト用のリポジトリのインメモリ実装を提供する
+//
+// usecases/repository のすべてのインターフェースについて、データベースを使わずに
+// 同じ振る舞い（見つからない場合のエラー、並び順、ページング、楽観的ロックなど）を
+// 再現する。テストごとに New() で作り直せば状態は共有されない。
+//
+//	repos := testsupport.New()
+//	repos.Users.Seed(user)
+//	repos.Transactions.FailOn("Create", errors.New("db down"))
+//	sut := interactor.NewPointTransferInteractor(repos.TxManager, repos.Users, repos.Transactions, ...)
+//
+// 各リポジトリは Faults を埋め込んでおり、メソッド名を指定してエラーを返させられる。
+// 集計だけを行うメソッド（分析・紹介実績など）は、テストで設定した結果をそのまま返す。
+package testsupport
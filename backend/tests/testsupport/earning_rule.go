@@ -0,0 +1,213 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.EarningRuleRepository = (*EarningRuleRepository)(nil)
+
+// EarningRuleRepository はEarningRuleRepositoryのインメモリ実装
+// 出来事の回数は transactions（送金）と friendships（友達）に対して数える
+type EarningRuleRepository struct {
+	Faults
+	mu           sync.Mutex
+	transactions *TransactionRepository
+	friendships  *FriendshipRepository
+	rules        *table[uuid.UUID, entities.EarningRule]
+	grants       *table[uuid.UUID, entities.EarningRuleGrant]
+}
+
+// NewEarningRuleRepository は空のEarningRuleRepositoryを作成
+func NewEarningRuleRepository(transactions *TransactionRepository, friendships *FriendshipRepository) *EarningRuleRepository {
+	return &EarningRuleRepository{
+		transactions: transactions,
+		friendships:  friendships,
+		rules:        newTable[uuid.UUID, entities.EarningRule](),
+		grants:       newTable[uuid.UUID, entities.EarningRuleGrant](),
+	}
+}
+
+// Create はポイント獲得ルールを作成
+func (r *EarningRuleRepository) Create(ctx context.Context, rule *entities.EarningRule) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.rules.has(rule.ID) {
+		return ErrDuplicate
+	}
+	r.rules.put(rule.ID, rule)
+	return nil
+}
+
+// Read はIDでポイント獲得ルールを取得
+func (r *EarningRuleRepository) Read(ctx context.Context, id uuid.UUID) (*entities.EarningRule, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rule, ok := r.rules.get(id); ok {
+		return rule, nil
+	}
+	return nil, entities.ErrEarningRuleNotFound
+}
+
+// Update はポイント獲得ルールを更新（作成者・作成日時・付与済みの回数は変えない）
+func (r *EarningRuleRepository) Update(ctx context.Context, rule *entities.EarningRule) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.rules.ref(rule.ID)
+	if !ok {
+		return entities.ErrEarningRuleNotFound
+	}
+	createdBy, createdAt, grantCount := stored.CreatedBy, stored.CreatedAt, stored.GrantCount
+	*stored = *rule
+	stored.CreatedBy, stored.CreatedAt, stored.GrantCount = createdBy, createdAt, grantCount
+	return nil
+}
+
+// Delete はポイント獲得ルールと付与の記録を削除
+func (r *EarningRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.rules.remove(id) {
+		return entities.ErrEarningRuleNotFound
+	}
+	r.grants.removeWhere(func(g *entities.EarningRuleGrant) bool { return g.RuleID == id })
+	return nil
+}
+
+// ReadList はポイント獲得ルールを作成日時の新しい順に取得
+func (r *EarningRuleRepository) ReadList(ctx context.Context, offset, limit int) ([]*entities.EarningRule, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := sortBy(r.rules.find(nil), newestFirst(func(rule *entities.EarningRule) time.Time { return rule.CreatedAt }))
+	return page(list, offset, limit), nil
+}
+
+// Count はポイント獲得ルールの件数を取得
+func (r *EarningRuleRepository) Count(ctx context.Context) (int64, error) {
+	if err := r.hit("Count"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rules.count(nil), nil
+}
+
+// ReadActiveByTrigger は指定日時に有効な、きっかけが一致するルールを作成日時の古い順に取得
+func (r *EarningRuleRepository) ReadActiveByTrigger(ctx context.Context, trigger entities.EarningTrigger, now time.Time) ([]*entities.EarningRule, error) {
+	if err := r.hit("ReadActiveByTrigger"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.rules.find(func(rule *entities.EarningRule) bool {
+		return rule.IsActive && rule.Trigger == trigger && !rule.StartsAt.After(now) && (rule.EndsAt == nil || rule.EndsAt.After(now))
+	})
+	return sortBy(list, oldestFirst(func(rule *entities.EarningRule) time.Time { return rule.CreatedAt })), nil
+}
+
+// CountOccurrences はユーザーのきっかけとなる出来事の回数を取得（プロフィールの完成は常に1）
+func (r *EarningRuleRepository) CountOccurrences(ctx context.Context, userID uuid.UUID, trigger entities.EarningTrigger) (int64, error) {
+	if err := r.hit("CountOccurrences"); err != nil {
+		return 0, err
+	}
+	switch trigger {
+	case entities.EarningTriggerTransferSent:
+		return int64(len(r.transactions.all(func(t *entities.Transaction) bool {
+			return t.FromUserID != nil && *t.FromUserID == userID &&
+				t.TransactionType == entities.TransactionTypeTransfer && t.Status == entities.TransactionStatusCompleted
+		}))), nil
+	case entities.EarningTriggerFriendAdded:
+		return int64(len(r.friendships.all(acceptedWith(userID)))), nil
+	}
+	return 1, nil
+}
+
+// IncrementGrantCount はルール全体の上限に達していなければ付与済みの回数を1増やす
+func (r *EarningRuleRepository) IncrementGrantCount(ctx context.Context, ruleID uuid.UUID) (bool, error) {
+	if err := r.hit("IncrementGrantCount"); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rule, ok := r.rules.ref(ruleID)
+	if !ok || (rule.MaxGrantsTotal != 0 && rule.GrantCount >= rule.MaxGrantsTotal) {
+		return false, nil
+	}
+	rule.GrantCount++
+	return true, nil
+}
+
+// CountUserGrants はユーザーにルールで付与した回数を取得
+func (r *EarningRuleRepository) CountUserGrants(ctx context.Context, ruleID, userID uuid.UUID) (int, error) {
+	if err := r.hit("CountUserGrants"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return int(r.grants.count(func(g *entities.EarningRuleGrant) bool { return g.RuleID == ruleID && g.UserID == userID })), nil
+}
+
+// CreateGrant は付与を記録（同じルール・ユーザー・発生元で記録済みならfalse）
+func (r *EarningRuleRepository) CreateGrant(ctx context.Context, grant *entities.EarningRuleGrant) (bool, error) {
+	if err := r.hit("CreateGrant"); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.grants.first(func(g *entities.EarningRuleGrant) bool {
+		return g.RuleID == grant.RuleID && g.UserID == grant.UserID && g.SourceID == grant.SourceID
+	}); ok {
+		return false, nil
+	}
+	r.grants.put(grant.ID, grant)
+	return true, nil
+}
+
+// ReadStats はルールごとの付与の実績を取得（付与のないルールは含めない）
+func (r *EarningRuleRepository) ReadStats(ctx context.Context, ruleIDs []uuid.UUID) (map[uuid.UUID]*entities.EarningRuleStats, error) {
+	if err := r.hit("ReadStats"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make(map[uuid.UUID]*entities.EarningRuleStats)
+	for _, id := range ruleIDs {
+		users := make(map[uuid.UUID]bool)
+		for _, g := range r.grants.refs(func(g *entities.EarningRuleGrant) bool { return g.RuleID == id }) {
+			s, ok := result[id]
+			if !ok {
+				s = &entities.EarningRuleStats{RuleID: id}
+				result[id] = s
+			}
+			s.GrantCount++
+			s.PointsGranted += g.Points
+			users[g.UserID] = true
+			s.UserCount = int64(len(users))
+			if s.LastGrantedAt == nil || g.CreatedAt.After(*s.LastGrantedAt) {
+				createdAt := g.CreatedAt
+				s.LastGrantedAt = &createdAt
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,116 @@
+package testsupport
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.EmailTemplateRepository = (*EmailTemplateRepository)(nil)
+
+// EmailTemplateRepository はEmailTemplateRepositoryのインメモリ実装
+type EmailTemplateRepository struct {
+	Faults
+	mu        sync.Mutex
+	templates *table[uuid.UUID, entities.EmailTemplate]
+}
+
+// NewEmailTemplateRepository は空のEmailTemplateRepositoryを作成
+func NewEmailTemplateRepository() *EmailTemplateRepository {
+	return &EmailTemplateRepository{templates: newTable[uuid.UUID, entities.EmailTemplate]()}
+}
+
+// Create はテンプレートの版を保存（同じ種類・版が既にあればErrDuplicate）
+func (r *EmailTemplateRepository) Create(ctx context.Context, template *entities.EmailTemplate) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.templates.first(func(t *entities.EmailTemplate) bool {
+		return t.Key == template.Key && t.Version == template.Version
+	}); ok {
+		return ErrDuplicate
+	}
+	r.templates.put(template.ID, template)
+	return nil
+}
+
+// ReadLatest はメールの種類の最新の版を取得
+func (r *EmailTemplateRepository) ReadLatest(ctx context.Context, key entities.EmailTemplateKey) (*entities.EmailTemplate, error) {
+	if err := r.hit("ReadLatest"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	versions := r.versions(key)
+	if len(versions) == 0 {
+		return nil, entities.ErrEmailTemplateNotFound
+	}
+	return versions[0], nil
+}
+
+// ReadVersion は指定した版を取得
+func (r *EmailTemplateRepository) ReadVersion(ctx context.Context, key entities.EmailTemplateKey, version int) (*entities.EmailTemplate, error) {
+	if err := r.hit("ReadVersion"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := r.templates.first(func(t *entities.EmailTemplate) bool { return t.Key == key && t.Version == version }); ok {
+		return t, nil
+	}
+	return nil, entities.ErrEmailTemplateNotFound
+}
+
+// ReadVersions はメールの種類のすべての版を新しい順に取得
+func (r *EmailTemplateRepository) ReadVersions(ctx context.Context, key entities.EmailTemplateKey) ([]*entities.EmailTemplate, error) {
+	if err := r.hit("ReadVersions"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.versions(key), nil
+}
+
+func (r *EmailTemplateRepository) versions(key entities.EmailTemplateKey) []*entities.EmailTemplate {
+	list := r.templates.find(func(t *entities.EmailTemplate) bool { return t.Key == key })
+	sort.Slice(list, func(i, j int) bool { return list[i].Version > list[j].Version })
+	return list
+}
+
+// ReadLatestList は編集済みのメールの種類ごとに最新の版を取得
+func (r *EmailTemplateRepository) ReadLatestList(ctx context.Context) ([]*entities.EmailTemplate, error) {
+	if err := r.hit("ReadLatestList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	latest := make(map[entities.EmailTemplateKey]*entities.EmailTemplate)
+	for _, t := range r.templates.find(func(*entities.EmailTemplate) bool { return true }) {
+		if current, ok := latest[t.Key]; !ok || t.Version > current.Version {
+			latest[t.Key] = t
+		}
+	}
+	list := make([]*entities.EmailTemplate, 0, len(latest))
+	for _, t := range latest {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	return list, nil
+}
+
+// DeleteByKey はメールの種類のすべての版を削除
+func (r *EmailTemplateRepository) DeleteByKey(ctx context.Context, key entities.EmailTemplateKey) error {
+	if err := r.hit("DeleteByKey"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.templates.removeWhere(func(t *entities.EmailTemplate) bool { return t.Key == key })
+	return nil
+}
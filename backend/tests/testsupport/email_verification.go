@@ -0,0 +1,119 @@
+package testsupport
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.EmailVerificationRepository = (*EmailVerificationRepository)(nil)
+
+// EmailVerificationRepository はEmailVerificationRepositoryのインメモリ実装
+type EmailVerificationRepository struct {
+	Faults
+	clock
+	mu     sync.Mutex
+	tokens *table[uuid.UUID, entities.EmailVerificationToken]
+}
+
+// NewEmailVerificationRepository は空のEmailVerificationRepositoryを作成
+func NewEmailVerificationRepository() *EmailVerificationRepository {
+	return &EmailVerificationRepository{tokens: newTable[uuid.UUID, entities.EmailVerificationToken]()}
+}
+
+// Create は新しいメール認証トークンを作成（同じトークンがあればErrDuplicate）
+func (r *EmailVerificationRepository) Create(ctx context.Context, token *entities.EmailVerificationToken) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.tokens.has(token.ID) || r.tokens.count(func(t *entities.EmailVerificationToken) bool { return t.Token == token.Token }) > 0 {
+		return ErrDuplicate
+	}
+	r.tokens.put(token.ID, token)
+	return nil
+}
+
+// ReadByToken はトークンで検索
+func (r *EmailVerificationRepository) ReadByToken(ctx context.Context, token string) (*entities.EmailVerificationToken, error) {
+	if err := r.hit("ReadByToken"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := r.tokens.first(func(t *entities.EmailVerificationToken) bool { return t.Token == token }); ok {
+		return t, nil
+	}
+	return nil, errors.New("token not found")
+}
+
+// ReadByOldEmailToken は旧アドレス確認用トークンで検索
+func (r *EmailVerificationRepository) ReadByOldEmailToken(ctx context.Context, token string) (*entities.EmailVerificationToken, error) {
+	if err := r.hit("ReadByOldEmailToken"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := r.tokens.first(func(t *entities.EmailVerificationToken) bool {
+		return t.OldEmailToken != nil && *t.OldEmailToken == token
+	}); ok {
+		return t, nil
+	}
+	return nil, errors.New("token not found")
+}
+
+// Update は認証・確認・適用の日時を更新
+func (r *EmailVerificationRepository) Update(ctx context.Context, token *entities.EmailVerificationToken) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.tokens.ref(token.ID); ok {
+		stored.VerifiedAt = token.VerifiedAt
+		stored.OldEmailConfirmedAt = token.OldEmailConfirmedAt
+		stored.AppliedAt = token.AppliedAt
+	}
+	return nil
+}
+
+// DeleteExpired は期限切れのトークンを削除
+func (r *EmailVerificationRepository) DeleteExpired(ctx context.Context) error {
+	if err := r.hit("DeleteExpired"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	r.tokens.removeWhere(func(t *entities.EmailVerificationToken) bool { return t.ExpiresAt.Before(now) })
+	return nil
+}
+
+// DeleteByUserID はユーザーIDに紐づくトークンを削除
+func (r *EmailVerificationRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	if err := r.hit("DeleteByUserID"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens.removeWhere(func(t *entities.EmailVerificationToken) bool { return t.UserID != nil && *t.UserID == userID })
+	return nil
+}
+
+// CountByUserIDSince はユーザーに指定日時以降に発行した種類ごとのトークン数を取得
+func (r *EmailVerificationRepository) CountByUserIDSince(ctx context.Context, userID uuid.UUID, tokenType entities.TokenType, since time.Time) (int64, error) {
+	if err := r.hit("CountByUserIDSince"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.tokens.count(func(t *entities.EmailVerificationToken) bool {
+		return t.UserID != nil && *t.UserID == userID && t.TokenType == tokenType && !t.CreatedAt.Before(since)
+	}), nil
+}
@@ -0,0 +1,167 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.EventRepository = (*EventRepository)(nil)
+
+// EventRepository はEventRepositoryのインメモリ実装
+// 参加者一覧のユーザー名は users から取る
+type EventRepository struct {
+	Faults
+	mu          sync.Mutex
+	users       *UserRepository
+	events      *table[uuid.UUID, entities.Event]
+	attendances *table[[2]uuid.UUID, entities.EventAttendance] // {イベントID, ユーザーID}
+}
+
+// NewEventRepository は空のEventRepositoryを作成
+func NewEventRepository(users *UserRepository) *EventRepository {
+	return &EventRepository{
+		users:       users,
+		events:      newTable[uuid.UUID, entities.Event](),
+		attendances: newTable[[2]uuid.UUID, entities.EventAttendance](),
+	}
+}
+
+// Create はイベントを作成（同じコードがあればErrDuplicate）
+func (r *EventRepository) Create(ctx context.Context, event *entities.Event) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.events.has(event.ID) || r.events.count(func(e *entities.Event) bool { return e.Code == event.Code }) > 0 {
+		return ErrDuplicate
+	}
+	r.events.put(event.ID, event)
+	return nil
+}
+
+// Read はIDでイベントを取得
+func (r *EventRepository) Read(ctx context.Context, id uuid.UUID) (*entities.Event, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e, ok := r.events.get(id); ok {
+		return e, nil
+	}
+	return nil, entities.ErrEventNotFound
+}
+
+// ReadByCode はコードでイベントを取得
+func (r *EventRepository) ReadByCode(ctx context.Context, code string) (*entities.Event, error) {
+	if err := r.hit("ReadByCode"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e, ok := r.events.first(func(e *entities.Event) bool { return e.Code == code }); ok {
+		return e, nil
+	}
+	return nil, entities.ErrEventNotFound
+}
+
+// Update はイベントの内容を更新（参加人数とコードは変えない）
+func (r *EventRepository) Update(ctx context.Context, event *entities.Event) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.events.ref(event.ID)
+	if !ok {
+		return entities.ErrEventNotFound
+	}
+	stored.Name = event.Name
+	stored.Description = event.Description
+	stored.Points = event.Points
+	stored.Capacity = event.Capacity
+	stored.StartsAt = event.StartsAt
+	stored.EndsAt = event.EndsAt
+	stored.IsActive = event.IsActive
+	stored.UpdatedAt = event.UpdatedAt
+	return nil
+}
+
+// ReadList はイベントを開始日時の新しい順に取得
+func (r *EventRepository) ReadList(ctx context.Context, offset, limit int) ([]*entities.Event, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := sortBy(r.events.find(nil), newestFirst(func(e *entities.Event) time.Time { return e.StartsAt }))
+	return page(list, offset, limit), nil
+}
+
+// Count はイベントの件数を取得
+func (r *EventRepository) Count(ctx context.Context) (int64, error) {
+	if err := r.hit("Count"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.events.count(nil), nil
+}
+
+// IncrementAttendeeCount は定員に空きがあれば参加人数を1増やす（満員ならfalse）
+func (r *EventRepository) IncrementAttendeeCount(ctx context.Context, id uuid.UUID) (bool, error) {
+	if err := r.hit("IncrementAttendeeCount"); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.events.ref(id)
+	if !ok || (e.Capacity != 0 && e.AttendeeCount >= e.Capacity) {
+		return false, nil
+	}
+	e.AttendeeCount++
+	return true, nil
+}
+
+// CreateAttendance は参加を記録（チェックイン済みならErrEventAlreadyCheckedIn）
+func (r *EventRepository) CreateAttendance(ctx context.Context, attendance *entities.EventAttendance) error {
+	if err := r.hit("CreateAttendance"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := [2]uuid.UUID{attendance.EventID, attendance.UserID}
+	if r.attendances.has(key) {
+		return entities.ErrEventAlreadyCheckedIn
+	}
+	r.attendances.put(key, attendance)
+	return nil
+}
+
+// ReadAttendees はイベントの参加者をチェックインの早い順に取得
+func (r *EventRepository) ReadAttendees(ctx context.Context, eventID uuid.UUID, offset, limit int) ([]*entities.EventAttendee, error) {
+	if err := r.hit("ReadAttendees"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	list := r.attendances.find(func(a *entities.EventAttendance) bool { return a.EventID == eventID })
+	r.mu.Unlock()
+
+	list = page(sortBy(list, oldestFirst(func(a *entities.EventAttendance) time.Time { return a.CreatedAt })), offset, limit)
+	attendees := make([]*entities.EventAttendee, 0, len(list))
+	for _, a := range list {
+		attendee := &entities.EventAttendee{UserID: a.UserID, Points: a.Points, CheckedInAt: a.CreatedAt}
+		if u := r.users.lookup(a.UserID); u != nil {
+			attendee.Username = u.Username
+			attendee.DisplayName = u.DisplayName
+		}
+		attendees = append(attendees, attendee)
+	}
+	return attendees, nil
+}
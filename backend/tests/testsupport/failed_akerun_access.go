@@ -0,0 +1,118 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.FailedAkerunAccessRepository = (*FailedAkerunAccessRepository)(nil)
+
+// FailedAkerunAccessRepository はFailedAkerunAccessRepositoryのインメモリ実装
+type FailedAkerunAccessRepository struct {
+	Faults
+	mu       sync.Mutex
+	accesses *table[uuid.UUID, entities.FailedAkerunAccess]
+}
+
+// NewFailedAkerunAccessRepository は空のFailedAkerunAccessRepositoryを作成
+func NewFailedAkerunAccessRepository() *FailedAkerunAccessRepository {
+	return &FailedAkerunAccessRepository{accesses: newTable[uuid.UUID, entities.FailedAkerunAccess]()}
+}
+
+// Enqueue は失敗した入退室記録を登録（同じアクセス記録が登録済みなら何もしない）
+func (r *FailedAkerunAccessRepository) Enqueue(ctx context.Context, access *entities.FailedAkerunAccess) error {
+	if err := r.hit("Enqueue"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.accesses.count(func(a *entities.FailedAkerunAccess) bool { return a.AccessID == access.AccessID }) > 0 {
+		return nil
+	}
+	r.accesses.put(access.ID, access)
+	return nil
+}
+
+// Read はIDで失敗した入退室記録を取得
+func (r *FailedAkerunAccessRepository) Read(ctx context.Context, id uuid.UUID) (*entities.FailedAkerunAccess, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if a, ok := r.accesses.get(id); ok {
+		return a, nil
+	}
+	return nil, entities.ErrFailedAccessNotFound
+}
+
+// ReadDue は再試行の時刻を過ぎた再試行待ちの記録を再試行の時刻の古い順に取得
+func (r *FailedAkerunAccessRepository) ReadDue(ctx context.Context, now time.Time, limit int) ([]*entities.FailedAkerunAccess, error) {
+	if err := r.hit("ReadDue"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.accesses.find(func(a *entities.FailedAkerunAccess) bool {
+		return a.Status == entities.FailedAkerunAccessPending && !a.NextRetryAt.After(now)
+	})
+	return page(sortBy(list, oldestFirst(func(a *entities.FailedAkerunAccess) time.Time { return a.NextRetryAt })), 0, limit), nil
+}
+
+// ReadList は記録を新しい順に取得（statusが空ならすべて）
+func (r *FailedAkerunAccessRepository) ReadList(ctx context.Context, status entities.FailedAkerunAccessStatus, offset, limit int) ([]*entities.FailedAkerunAccess, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.accesses.find(failedAccessStatus(status))
+	return page(sortBy(list, newestFirst(func(a *entities.FailedAkerunAccess) time.Time { return a.CreatedAt })), offset, limit), nil
+}
+
+// Count は記録の件数を取得（statusが空ならすべて）
+func (r *FailedAkerunAccessRepository) Count(ctx context.Context, status entities.FailedAkerunAccessStatus) (int64, error) {
+	if err := r.hit("Count"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.accesses.count(failedAccessStatus(status)), nil
+}
+
+// Update は状態・試行回数・次の再試行の時刻を更新
+func (r *FailedAkerunAccessRepository) Update(ctx context.Context, access *entities.FailedAkerunAccess) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.accesses.ref(access.ID); ok {
+		stored.Status = access.Status
+		stored.Attempts = access.Attempts
+		stored.LastError = access.LastError
+		stored.NextRetryAt = access.NextRetryAt
+		stored.UpdatedAt = access.UpdatedAt
+	}
+	return nil
+}
+
+// Delete は記録を削除
+func (r *FailedAkerunAccessRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.accesses.remove(id)
+	return nil
+}
+
+func failedAccessStatus(status entities.FailedAkerunAccessStatus) func(a *entities.FailedAkerunAccess) bool {
+	return func(a *entities.FailedAkerunAccess) bool { return status == "" || a.Status == status }
+}
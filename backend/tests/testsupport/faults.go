@@ -0,0 +1,109 @@
+package testsupport
+
+import "sync"
+
+// AnyMethod を FailOn・FailOnce に指定すると、すべてのメソッドが対象になる
+const AnyMethod = "*"
+
+// Faults はリポジトリのメソッドにエラーを返させるための設定と呼び出し回数を保持する
+// 各リポジトリに埋め込まれ、メソッドの先頭で hit を呼ぶ
+type Faults struct {
+	mu     sync.Mutex
+	always map[string]error
+	once   map[string][]error
+	calls  map[string]int
+	hooks  map[string]func() error
+}
+
+// FailOn は以降のmethodの呼び出しですべてerrを返させる（errがnilなら解除）
+func (f *Faults) FailOn(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.always == nil {
+		f.always = make(map[string]error)
+	}
+	if err == nil {
+		delete(f.always, method)
+		return
+	}
+	f.always[method] = err
+}
+
+// FailOnce は次のmethodの呼び出しで一度だけerrを返させる
+// 複数回呼ぶと、呼び出しごとに登録した順に返す
+func (f *Faults) FailOnce(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.once == nil {
+		f.once = make(map[string][]error)
+	}
+	f.once[method] = append(f.once[method], err)
+}
+
+// Hook はmethodが呼ばれるたびにfnを実行し、fnがエラーを返せばそれを返させる
+// n回目だけ失敗させる・呼び出し時に別の状態を変えるなどの細かい制御に使う
+func (f *Faults) Hook(method string, fn func() error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.hooks == nil {
+		f.hooks = make(map[string]func() error)
+	}
+	if fn == nil {
+		delete(f.hooks, method)
+		return
+	}
+	f.hooks[method] = fn
+}
+
+// Calls はmethodが呼ばれた回数を返す（エラーを返した呼び出しも含む）
+func (f *Faults) Calls(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[method]
+}
+
+// Reset はエラーの設定と呼び出し回数を消す（保存したデータはそのまま）
+func (f *Faults) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.always = nil
+	f.once = nil
+	f.calls = nil
+	f.hooks = nil
+}
+
+// hit はmethodの呼び出しを記録し、設定されたエラーがあれば返す
+// 優先順位は FailOnce → Hook → FailOn（メソッド名の指定 → AnyMethod）
+func (f *Faults) hit(method string) error {
+	f.mu.Lock()
+	if f.calls == nil {
+		f.calls = make(map[string]int)
+	}
+	f.calls[method]++
+
+	for _, name := range []string{method, AnyMethod} {
+		if queue := f.once[name]; len(queue) > 0 {
+			err := queue[0]
+			f.once[name] = queue[1:]
+			f.mu.Unlock()
+			return err
+		}
+	}
+	hook := f.hooks[method]
+	if hook == nil {
+		hook = f.hooks[AnyMethod]
+	}
+	always, ok := f.always[method]
+	if !ok {
+		always = f.always[AnyMethod]
+	}
+	f.mu.Unlock()
+
+	// hookの中から同じリポジトリを呼べるようにロックを外してから実行する
+	if hook != nil {
+		if err := hook(); err != nil {
+			return err
+		}
+	}
+	return always
+}
@@ -0,0 +1,52 @@
+package testsupport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.FriendDiscoveryRepository = (*FriendDiscoveryRepository)(nil)
+
+// FriendDiscoveryRepository はFriendDiscoveryRepositoryのインメモリ実装
+// users と friendships の内容から検索する
+type FriendDiscoveryRepository struct {
+	Faults
+	users       *UserRepository
+	friendships *FriendshipRepository
+}
+
+// NewFriendDiscoveryRepository はFriendDiscoveryRepositoryを作成
+func NewFriendDiscoveryRepository(users *UserRepository, friendships *FriendshipRepository) *FriendDiscoveryRepository {
+	return &FriendDiscoveryRepository{users: users, friendships: friendships}
+}
+
+// ReadDiscoverableByHashes はメールアドレスまたはユーザー名のハッシュが一致する公開設定のユーザーをユーザー名順に取得
+func (r *FriendDiscoveryRepository) ReadDiscoverableByHashes(ctx context.Context, userID uuid.UUID, hashes []string) ([]*entities.User, error) {
+	if err := r.hit("ReadDiscoverableByHashes"); err != nil {
+		return nil, err
+	}
+	if len(hashes) == 0 {
+		return []*entities.User{}, nil
+	}
+	wanted := make(map[string]bool, len(hashes))
+	for _, h := range hashes {
+		wanted[h] = true
+	}
+	related := make(map[uuid.UUID]bool)
+	for _, f := range r.friendships.all(func(f *entities.Friendship) bool {
+		return (f.RequesterID == userID || f.AddresseeID == userID) &&
+			(f.Status == entities.FriendshipStatusAccepted || f.Status == entities.FriendshipStatusBlocked)
+	}) {
+		related[f.RequesterID] = true
+		related[f.AddresseeID] = true
+	}
+
+	list := r.users.all(func(u *entities.User) bool {
+		return u.ID != userID && u.IsActive && u.Discoverable && !related[u.ID] &&
+			(wanted[entities.HashContactIdentifier(u.Email)] || wanted[entities.HashContactIdentifier(u.Username)])
+	})
+	return sortBy(list, func(a, b *entities.User) bool { return a.Username < b.Username }), nil
+}
@@ -0,0 +1,66 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.FriendPinRepository = (*FriendPinRepository)(nil)
+
+type friendPinKey struct {
+	userID   uuid.UUID
+	friendID uuid.UUID
+}
+
+// FriendPinRepository はFriendPinRepositoryのインメモリ実装
+type FriendPinRepository struct {
+	Faults
+	mu   sync.Mutex
+	pins *table[friendPinKey, entities.FriendPin]
+}
+
+// NewFriendPinRepository は空のFriendPinRepositoryを作成
+func NewFriendPinRepository() *FriendPinRepository {
+	return &FriendPinRepository{pins: newTable[friendPinKey, entities.FriendPin]()}
+}
+
+// Create はピン留めを作成（既にピン留めしていれば何もしない）
+func (r *FriendPinRepository) Create(ctx context.Context, pin *entities.FriendPin) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := friendPinKey{pin.UserID, pin.FriendID}
+	if !r.pins.has(key) {
+		r.pins.put(key, pin)
+	}
+	return nil
+}
+
+// Delete はピン留めを外す（ピン留めしていなければ何もしない）
+func (r *FriendPinRepository) Delete(ctx context.Context, userID, friendID uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pins.remove(friendPinKey{userID, friendID})
+	return nil
+}
+
+// ReadListByUserID はユーザーのピン留めをピン留めした順に取得
+func (r *FriendPinRepository) ReadListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.FriendPin, error) {
+	if err := r.hit("ReadListByUserID"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return sortBy(r.pins.find(func(p *entities.FriendPin) bool { return p.UserID == userID }),
+		oldestFirst(func(p *entities.FriendPin) time.Time { return p.CreatedAt })), nil
+}
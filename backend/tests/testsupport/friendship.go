@@ -0,0 +1,276 @@
+package testsupport
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.FriendshipRepository = (*FriendshipRepository)(nil)
+
+// FriendshipRepository はFriendshipRepositoryのインメモリ実装
+// ユーザー情報付きの取得には users を使う（nilならユーザー情報なし）
+type FriendshipRepository struct {
+	Faults
+	clock
+	mu          sync.Mutex
+	users       *UserRepository
+	friendships *table[uuid.UUID, entities.Friendship]
+	archived    []*entities.Friendship
+}
+
+// NewFriendshipRepository は空のFriendshipRepositoryを作成
+func NewFriendshipRepository(users *UserRepository) *FriendshipRepository {
+	return &FriendshipRepository{users: users, friendships: newTable[uuid.UUID, entities.Friendship]()}
+}
+
+// Archived はArchiveAndDeleteでアーカイブされた友達関係を返す
+func (r *FriendshipRepository) Archived() []*entities.Friendship {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return copies(r.archived)
+}
+
+// Create は新しい友達申請を作成（同じ2人の関係が既にあればErrDuplicate）
+func (r *FriendshipRepository) Create(ctx context.Context, friendship *entities.Friendship) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.friendships.has(friendship.ID) || r.friendships.count(between(friendship.RequesterID, friendship.AddresseeID)) > 0 {
+		return ErrDuplicate
+	}
+	r.friendships.put(friendship.ID, friendship)
+	return nil
+}
+
+// Read はIDで友達関係を検索
+func (r *FriendshipRepository) Read(ctx context.Context, id uuid.UUID) (*entities.Friendship, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if f, ok := r.friendships.get(id); ok {
+		return f, nil
+	}
+	return nil, errors.New("friendship not found")
+}
+
+// ReadByUsers は2人のユーザー間の友達関係を検索（どちらが申請者でもよい）
+func (r *FriendshipRepository) ReadByUsers(ctx context.Context, userID1, userID2 uuid.UUID) (*entities.Friendship, error) {
+	if err := r.hit("ReadByUsers"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if f, ok := r.friendships.first(between(userID1, userID2)); ok {
+		return f, nil
+	}
+	return nil, errors.New("friendship not found")
+}
+
+// ReadListFriends は承認済みの友達関係を新しい順に取得
+func (r *FriendshipRepository) ReadListFriends(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.Friendship, error) {
+	if err := r.hit("ReadListFriends"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return page(r.newest(acceptedWith(userID)), offset, limit), nil
+}
+
+// ReadListPendingRequests はユーザー宛の承認待ちの申請を新しい順に取得
+func (r *FriendshipRepository) ReadListPendingRequests(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.Friendship, error) {
+	if err := r.hit("ReadListPendingRequests"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return page(r.newest(pendingTo(userID)), offset, limit), nil
+}
+
+// Update は友達関係の状態を更新
+func (r *FriendshipRepository) Update(ctx context.Context, friendship *entities.Friendship) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.friendships.ref(friendship.ID); ok {
+		stored.Status = friendship.Status
+		stored.UpdatedAt = r.now()
+	}
+	return nil
+}
+
+// Delete は友達関係を削除
+func (r *FriendshipRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.friendships.remove(id)
+	return nil
+}
+
+// ArchiveAndDelete は友達関係をアーカイブしてから削除
+func (r *FriendshipRepository) ArchiveAndDelete(ctx context.Context, id uuid.UUID, archivedBy uuid.UUID) error {
+	if err := r.hit("ArchiveAndDelete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, ok := r.friendships.get(id)
+	if !ok {
+		return errors.New("friendship not found")
+	}
+	r.archived = append(r.archived, f)
+	r.friendships.remove(id)
+	return nil
+}
+
+// CheckAreFriends は2人のユーザーが友達かどうかを確認
+func (r *FriendshipRepository) CheckAreFriends(ctx context.Context, userID1, userID2 uuid.UUID) (bool, error) {
+	if err := r.hit("CheckAreFriends"); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	match := between(userID1, userID2)
+	return r.friendships.count(func(f *entities.Friendship) bool {
+		return match(f) && f.Status == entities.FriendshipStatusAccepted
+	}) > 0, nil
+}
+
+// ReadListFriendsWithUsers は承認済みの友達を相手のユーザー情報付きで新しい順に取得
+func (r *FriendshipRepository) ReadListFriendsWithUsers(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.FriendshipWithUser, error) {
+	if err := r.hit("ReadListFriendsWithUsers"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	list := page(r.newest(acceptedWith(userID)), offset, limit)
+	r.mu.Unlock()
+
+	results := make([]*entities.FriendshipWithUser, len(list))
+	for i, f := range list {
+		other := f.RequesterID
+		if other == userID {
+			other = f.AddresseeID
+		}
+		results[i] = &entities.FriendshipWithUser{Friendship: f, User: r.users.lookup(other)}
+	}
+	return results, nil
+}
+
+// ReadListPendingRequestsWithUsers はユーザー宛の承認待ちの申請を申請者の情報付きで新しい順に取得
+func (r *FriendshipRepository) ReadListPendingRequestsWithUsers(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.FriendshipWithUser, error) {
+	if err := r.hit("ReadListPendingRequestsWithUsers"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	list := page(r.newest(pendingTo(userID)), offset, limit)
+	r.mu.Unlock()
+
+	results := make([]*entities.FriendshipWithUser, len(list))
+	for i, f := range list {
+		results[i] = &entities.FriendshipWithUser{Friendship: f, User: r.users.lookup(f.RequesterID)}
+	}
+	return results, nil
+}
+
+// CountPendingRequests はユーザー宛の承認待ちの申請数を取得
+func (r *FriendshipRepository) CountPendingRequests(ctx context.Context, userID uuid.UUID) (int64, error) {
+	if err := r.hit("CountPendingRequests"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.friendships.count(pendingTo(userID)), nil
+}
+
+// ReadListMutualFriends は2人のユーザーの両方と友達であるユーザーをユーザー名順に取得
+func (r *FriendshipRepository) ReadListMutualFriends(ctx context.Context, userID1, userID2 uuid.UUID, offset, limit int) ([]*entities.User, error) {
+	if err := r.hit("ReadListMutualFriends"); err != nil {
+		return nil, err
+	}
+	users := []*entities.User{}
+	for _, id := range r.mutualFriendIDs(userID1, userID2) {
+		if u := r.users.lookup(id); u != nil {
+			users = append(users, u)
+		}
+	}
+	sortBy(users, func(a, b *entities.User) bool { return a.Username < b.Username })
+	return page(users, offset, limit), nil
+}
+
+// CountMutualFriends は2人のユーザーの両方と友達であるユーザーの人数を取得
+func (r *FriendshipRepository) CountMutualFriends(ctx context.Context, userID1, userID2 uuid.UUID) (int64, error) {
+	if err := r.hit("CountMutualFriends"); err != nil {
+		return 0, err
+	}
+	return int64(len(r.mutualFriendIDs(userID1, userID2))), nil
+}
+
+func (r *FriendshipRepository) mutualFriendIDs(userID1, userID2 uuid.UUID) []uuid.UUID {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	friendsOf := func(userID uuid.UUID) map[uuid.UUID]bool {
+		ids := make(map[uuid.UUID]bool)
+		for _, f := range r.friendships.find(acceptedWith(userID)) {
+			if f.RequesterID == userID {
+				ids[f.AddresseeID] = true
+			} else {
+				ids[f.RequesterID] = true
+			}
+		}
+		return ids
+	}
+	other := friendsOf(userID2)
+	var ids []uuid.UUID
+	for id := range friendsOf(userID1) {
+		if other[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (r *FriendshipRepository) newest(match func(f *entities.Friendship) bool) []*entities.Friendship {
+	return sortBy(r.friendships.find(match), newestFirst(func(f *entities.Friendship) time.Time { return f.CreatedAt }))
+}
+
+func between(userID1, userID2 uuid.UUID) func(f *entities.Friendship) bool {
+	return func(f *entities.Friendship) bool {
+		return (f.RequesterID == userID1 && f.AddresseeID == userID2) || (f.RequesterID == userID2 && f.AddresseeID == userID1)
+	}
+}
+
+func acceptedWith(userID uuid.UUID) func(f *entities.Friendship) bool {
+	return func(f *entities.Friendship) bool {
+		return (f.RequesterID == userID || f.AddresseeID == userID) && f.Status == entities.FriendshipStatusAccepted
+	}
+}
+
+func pendingTo(userID uuid.UUID) func(f *entities.Friendship) bool {
+	return func(f *entities.Friendship) bool {
+		return f.AddresseeID == userID && f.Status == entities.FriendshipStatusPending
+	}
+}
+
+// all は他のリポジトリが友達関係を探すために使う
+func (r *FriendshipRepository) all(match func(f *entities.Friendship) bool) []*entities.Friendship {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.friendships.find(match)
+}
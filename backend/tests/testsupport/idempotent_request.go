@@ -0,0 +1,95 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.IdempotentRequestRepository = (*IdempotentRequestRepository)(nil)
+
+// IdempotentRequestRepository はIdempotentRequestRepositoryのインメモリ実装
+type IdempotentRequestRepository struct {
+	Faults
+	mu       sync.Mutex
+	requests *table[uuid.UUID, entities.IdempotentRequest]
+}
+
+// NewIdempotentRequestRepository は空のIdempotentRequestRepositoryを作成
+func NewIdempotentRequestRepository() *IdempotentRequestRepository {
+	return &IdempotentRequestRepository{requests: newTable[uuid.UUID, entities.IdempotentRequest]()}
+}
+
+// CreateIfAbsent は同じ(scope, key, route)が無い場合のみ作成し、作成できたかを返す
+func (r *IdempotentRequestRepository) CreateIfAbsent(ctx context.Context, req *entities.IdempotentRequest) (bool, error) {
+	if err := r.hit("CreateIfAbsent"); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.requests.count(sameRequest(req.Scope, req.Key, req.Route)) > 0 {
+		return false, nil
+	}
+	r.requests.put(req.ID, req)
+	return true, nil
+}
+
+// ReadByKey は(scope, key, route)で取得（存在しない場合はnil）
+func (r *IdempotentRequestRepository) ReadByKey(ctx context.Context, scope, key, route string) (*entities.IdempotentRequest, error) {
+	if err := r.hit("ReadByKey"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if req, ok := r.requests.first(sameRequest(scope, key, route)); ok {
+		return req, nil
+	}
+	return nil, nil
+}
+
+// Update は保存済みレスポンスを更新
+func (r *IdempotentRequestRepository) Update(ctx context.Context, req *entities.IdempotentRequest) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.requests.ref(req.ID); ok {
+		stored.StatusCode = req.StatusCode
+		stored.ContentType = req.ContentType
+		stored.ResponseBody = req.ResponseBody
+		stored.CompletedAt = req.CompletedAt
+	}
+	return nil
+}
+
+// Delete は削除
+func (r *IdempotentRequestRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests.remove(id)
+	return nil
+}
+
+// DeleteExpired は指定時刻までに期限切れになったものを削除
+func (r *IdempotentRequestRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	if err := r.hit("DeleteExpired"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests.removeWhere(func(req *entities.IdempotentRequest) bool { return !req.ExpiresAt.After(now) }), nil
+}
+
+func sameRequest(scope, key, route string) func(req *entities.IdempotentRequest) bool {
+	return func(req *entities.IdempotentRequest) bool {
+		return req.Scope == scope && req.Key == key && req.Route == route
+	}
+}
@@ -0,0 +1,207 @@
+package testsupport
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.KioskRepository = (*KioskRepository)(nil)
+
+// KioskRepository はKioskRepositoryのインメモリ実装
+type KioskRepository struct {
+	Faults
+	clock
+	mu      sync.Mutex
+	devices *table[uuid.UUID, entities.KioskDevice]
+	cards   *table[string, entities.KioskCard]
+	grants  *table[uuid.UUID, entities.KioskGrant]
+}
+
+// NewKioskRepository は空のKioskRepositoryを作成
+func NewKioskRepository() *KioskRepository {
+	return &KioskRepository{
+		devices: newTable[uuid.UUID, entities.KioskDevice](),
+		cards:   newTable[string, entities.KioskCard](),
+		grants:  newTable[uuid.UUID, entities.KioskGrant](),
+	}
+}
+
+// CreateDevice は新しい端末を登録
+func (r *KioskRepository) CreateDevice(ctx context.Context, device *entities.KioskDevice) error {
+	if err := r.hit("CreateDevice"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.devices.has(device.ID) {
+		return ErrDuplicate
+	}
+	r.devices.put(device.ID, device)
+	return nil
+}
+
+// ReadDevice はIDで端末を検索
+func (r *KioskRepository) ReadDevice(ctx context.Context, id uuid.UUID) (*entities.KioskDevice, error) {
+	if err := r.hit("ReadDevice"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if d, ok := r.devices.get(id); ok {
+		return d, nil
+	}
+	return nil, errors.New("kiosk device not found")
+}
+
+// ReadDeviceByAPIKeyHash はAPIキーハッシュで端末を検索
+func (r *KioskRepository) ReadDeviceByAPIKeyHash(ctx context.Context, apiKeyHash string) (*entities.KioskDevice, error) {
+	if err := r.hit("ReadDeviceByAPIKeyHash"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if d, ok := r.devices.first(func(d *entities.KioskDevice) bool { return d.APIKeyHash == apiKeyHash }); ok {
+		return d, nil
+	}
+	return nil, errors.New("kiosk device not found")
+}
+
+// ReadDeviceList は端末一覧を登録日時の新しい順に取得
+func (r *KioskRepository) ReadDeviceList(ctx context.Context) ([]*entities.KioskDevice, error) {
+	if err := r.hit("ReadDeviceList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return sortBy(r.devices.find(nil), newestFirst(func(d *entities.KioskDevice) time.Time { return d.CreatedAt })), nil
+}
+
+// UpdateDevice は端末情報を更新
+func (r *KioskRepository) UpdateDevice(ctx context.Context, device *entities.KioskDevice) error {
+	if err := r.hit("UpdateDevice"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.devices.ref(device.ID); ok {
+		stored.Name = device.Name
+		stored.APIKeyHash = device.APIKeyHash
+		stored.BonusAmount = device.BonusAmount
+		stored.DailyLimit = device.DailyLimit
+		stored.IsActive = device.IsActive
+		stored.UpdatedAt = r.now()
+	}
+	return nil
+}
+
+// UpdateDeviceLastSeen は端末の最終アクセス日時を更新
+func (r *KioskRepository) UpdateDeviceLastSeen(ctx context.Context, id uuid.UUID, seenAt time.Time) error {
+	if err := r.hit("UpdateDeviceLastSeen"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.devices.ref(id); ok {
+		stored.LastSeenAt = &seenAt
+	}
+	return nil
+}
+
+// CreateCard はカードIDとユーザーを紐付け（登録済みのカードIDならErrDuplicate）
+func (r *KioskRepository) CreateCard(ctx context.Context, card *entities.KioskCard) error {
+	if err := r.hit("CreateCard"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cards.has(card.CardID) {
+		return ErrDuplicate
+	}
+	r.cards.put(card.CardID, card)
+	return nil
+}
+
+// ReadCard はカードIDで紐付けを検索
+func (r *KioskRepository) ReadCard(ctx context.Context, cardID string) (*entities.KioskCard, error) {
+	if err := r.hit("ReadCard"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, ok := r.cards.get(cardID); ok {
+		return c, nil
+	}
+	return nil, errors.New("card not found")
+}
+
+// DeleteCard はカードIDの紐付けを削除
+func (r *KioskRepository) DeleteCard(ctx context.Context, cardID string) error {
+	if err := r.hit("DeleteCard"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cards.remove(cardID)
+	return nil
+}
+
+// CreateGrant は付与記録を作成（同じ端末で同じ冪等性キーがあればErrDuplicate）
+func (r *KioskRepository) CreateGrant(ctx context.Context, grant *entities.KioskGrant) error {
+	if err := r.hit("CreateGrant"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.grants.has(grant.ID) || r.grants.count(grantKey(grant.DeviceID, grant.IdempotencyKey)) > 0 {
+		return ErrDuplicate
+	}
+	r.grants.put(grant.ID, grant)
+	return nil
+}
+
+// ReadGrantByIdempotencyKey は端末と冪等性キーで付与記録を検索（存在しない場合はnil）
+func (r *KioskRepository) ReadGrantByIdempotencyKey(ctx context.Context, deviceID uuid.UUID, key string) (*entities.KioskGrant, error) {
+	if err := r.hit("ReadGrantByIdempotencyKey"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if g, ok := r.grants.first(grantKey(deviceID, key)); ok {
+		return g, nil
+	}
+	return nil, nil
+}
+
+// CountGrantsByDeviceSince は端末の指定日時以降の付与回数を取得
+func (r *KioskRepository) CountGrantsByDeviceSince(ctx context.Context, deviceID uuid.UUID, since time.Time) (int64, error) {
+	if err := r.hit("CountGrantsByDeviceSince"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.grants.count(func(g *entities.KioskGrant) bool {
+		return g.DeviceID == deviceID && !g.CreatedAt.Before(since)
+	}), nil
+}
+
+// ExistsGrantByDeviceAndUserSince は端末が指定日時以降にユーザーへ付与済みか確認
+func (r *KioskRepository) ExistsGrantByDeviceAndUserSince(ctx context.Context, deviceID, userID uuid.UUID, since time.Time) (bool, error) {
+	if err := r.hit("ExistsGrantByDeviceAndUserSince"); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.grants.count(func(g *entities.KioskGrant) bool {
+		return g.DeviceID == deviceID && g.UserID == userID && !g.CreatedAt.Before(since)
+	}) > 0, nil
+}
+
+func grantKey(deviceID uuid.UUID, key string) func(g *entities.KioskGrant) bool {
+	return func(g *entities.KioskGrant) bool { return g.DeviceID == deviceID && g.IdempotencyKey == key }
+}
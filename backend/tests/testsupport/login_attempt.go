@@ -0,0 +1,172 @@
+package testsupport
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.LoginAttemptRepository = (*LoginAttemptRepository)(nil)
+
+// LoginAttemptRepository はLoginAttemptRepositoryのインメモリ実装
+type LoginAttemptRepository struct {
+	Faults
+	mu       sync.Mutex
+	attempts *table[uuid.UUID, entities.LoginAttempt]
+	lockouts *table[uuid.UUID, entities.AccountLockout]
+}
+
+// NewLoginAttemptRepository は空のLoginAttemptRepositoryを作成
+func NewLoginAttemptRepository() *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		attempts: newTable[uuid.UUID, entities.LoginAttempt](),
+		lockouts: newTable[uuid.UUID, entities.AccountLockout](),
+	}
+}
+
+// Attempts は記録されたログイン試行を記録順に返す
+func (r *LoginAttemptRepository) Attempts() []*entities.LoginAttempt {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.attempts.find(nil)
+}
+
+// Create はログイン試行を記録
+func (r *LoginAttemptRepository) Create(ctx context.Context, attempt *entities.LoginAttempt) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts.put(attempt.ID, attempt)
+	return nil
+}
+
+// CountFailedByIPSince はIPアドレスの指定日時以降の失敗回数を取得
+func (r *LoginAttemptRepository) CountFailedByIPSince(ctx context.Context, ipAddress string, since time.Time) (int64, error) {
+	if err := r.hit("CountFailedByIPSince"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.attempts.count(func(a *entities.LoginAttempt) bool {
+		return a.IPAddress == ipAddress && !a.Success && !a.CreatedAt.Before(since)
+	}), nil
+}
+
+// CountSuccessByUser はユーザーのログイン成功回数を取得
+func (r *LoginAttemptRepository) CountSuccessByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	if err := r.hit("CountSuccessByUser"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.attempts.count(succeededBy(userID, nil)), nil
+}
+
+// ExistsSuccessByUserAndUserAgent は同じ端末（User-Agent）でのログイン成功履歴があるか確認
+func (r *LoginAttemptRepository) ExistsSuccessByUserAndUserAgent(ctx context.Context, userID uuid.UUID, userAgent string) (bool, error) {
+	if err := r.hit("ExistsSuccessByUserAndUserAgent"); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.attempts.count(succeededBy(userID, func(a *entities.LoginAttempt) bool { return a.UserAgent == userAgent })) > 0, nil
+}
+
+// ExistsSuccessByUserAndCountry は同じ国からのログイン成功履歴があるか確認
+func (r *LoginAttemptRepository) ExistsSuccessByUserAndCountry(ctx context.Context, userID uuid.UUID, country string) (bool, error) {
+	if err := r.hit("ExistsSuccessByUserAndCountry"); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.attempts.count(succeededBy(userID, func(a *entities.LoginAttempt) bool { return a.Country == country })) > 0, nil
+}
+
+// ExistsSuccessByUserAndIP は同じIPアドレスからのログイン成功履歴があるか確認
+func (r *LoginAttemptRepository) ExistsSuccessByUserAndIP(ctx context.Context, userID uuid.UUID, ipAddress string) (bool, error) {
+	if err := r.hit("ExistsSuccessByUserAndIP"); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.attempts.count(succeededBy(userID, func(a *entities.LoginAttempt) bool { return a.IPAddress == ipAddress })) > 0, nil
+}
+
+// ReadListByUserID はユーザーのログイン試行を新しい順に取得
+func (r *LoginAttemptRepository) ReadListByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.LoginAttempt, error) {
+	if err := r.hit("ReadListByUserID"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.attempts.find(attemptedBy(userID))
+	return page(sortBy(list, newestFirst(func(a *entities.LoginAttempt) time.Time { return a.CreatedAt })), offset, limit), nil
+}
+
+// CountByUserID はユーザーのログイン試行の件数を取得
+func (r *LoginAttemptRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	if err := r.hit("CountByUserID"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.attempts.count(attemptedBy(userID)), nil
+}
+
+// ReadLockout はユーザーのロックアウト状態を取得（存在しない場合はnil）
+func (r *LoginAttemptRepository) ReadLockout(ctx context.Context, userID uuid.UUID) (*entities.AccountLockout, error) {
+	if err := r.hit("ReadLockout"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if l, ok := r.lockouts.get(userID); ok {
+		return l, nil
+	}
+	return nil, nil
+}
+
+// ReadLockoutByUnlockTokenHash はロック解除トークンのハッシュでロックアウト状態を取得
+func (r *LoginAttemptRepository) ReadLockoutByUnlockTokenHash(ctx context.Context, tokenHash string) (*entities.AccountLockout, error) {
+	if err := r.hit("ReadLockoutByUnlockTokenHash"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if l, ok := r.lockouts.first(func(l *entities.AccountLockout) bool { return l.UnlockTokenHash == tokenHash }); ok {
+		return l, nil
+	}
+	return nil, errors.New("unlock token not found")
+}
+
+// SaveLockout はロックアウト状態を保存
+func (r *LoginAttemptRepository) SaveLockout(ctx context.Context, lockout *entities.AccountLockout) error {
+	if err := r.hit("SaveLockout"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lockouts.put(lockout.UserID, lockout)
+	return nil
+}
+
+// attemptedBy はユーザーのログイン試行（成功・失敗とも）の条件
+func attemptedBy(userID uuid.UUID) func(a *entities.LoginAttempt) bool {
+	return func(a *entities.LoginAttempt) bool {
+		return a.UserID != nil && *a.UserID == userID
+	}
+}
+
+// succeededBy はユーザーのログイン成功のうちmatchに一致するものの条件（matchがnilなら成功すべて）
+func succeededBy(userID uuid.UUID, match func(a *entities.LoginAttempt) bool) func(a *entities.LoginAttempt) bool {
+	return func(a *entities.LoginAttempt) bool {
+		return a.Success && a.UserID != nil && *a.UserID == userID && (match == nil || match(a))
+	}
+}
@@ -0,0 +1,98 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.LotteryTierRepository = (*LotteryTierRepository)(nil)
+
+// LotteryTierRepository はLotteryTierRepositoryのインメモリ実装
+type LotteryTierRepository struct {
+	Faults
+	mu    sync.Mutex
+	tiers *table[uuid.UUID, entities.LotteryTier]
+}
+
+// NewLotteryTierRepository は空のLotteryTierRepositoryを作成
+func NewLotteryTierRepository() *LotteryTierRepository {
+	return &LotteryTierRepository{tiers: newTable[uuid.UUID, entities.LotteryTier]()}
+}
+
+// ReadAll は全ティアを表示順に取得
+func (r *LotteryTierRepository) ReadAll(ctx context.Context) ([]*entities.LotteryTier, error) {
+	if err := r.hit("ReadAll"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return sortBy(r.tiers.find(nil), byDisplayOrder), nil
+}
+
+// ReadActive はアクティブなティアのみ表示順に取得
+func (r *LotteryTierRepository) ReadActive(ctx context.Context) ([]*entities.LotteryTier, error) {
+	if err := r.hit("ReadActive"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return sortBy(r.tiers.find(func(t *entities.LotteryTier) bool { return t.IsActive }), byDisplayOrder), nil
+}
+
+// Create はティアを作成
+func (r *LotteryTierRepository) Create(ctx context.Context, tier *entities.LotteryTier) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.tiers.has(tier.ID) {
+		return ErrDuplicate
+	}
+	r.tiers.put(tier.ID, tier)
+	return nil
+}
+
+// Update はティアを更新
+func (r *LotteryTierRepository) Update(ctx context.Context, tier *entities.LotteryTier) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.tiers.has(tier.ID) {
+		r.tiers.put(tier.ID, tier)
+	}
+	return nil
+}
+
+// Delete はティアを削除
+func (r *LotteryTierRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tiers.remove(id)
+	return nil
+}
+
+// ReplaceAll は全ティアを一括置換
+func (r *LotteryTierRepository) ReplaceAll(ctx context.Context, tiers []*entities.LotteryTier) error {
+	if err := r.hit("ReplaceAll"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tiers = newTable[uuid.UUID, entities.LotteryTier]()
+	for _, t := range tiers {
+		r.tiers.put(t.ID, t)
+	}
+	return nil
+}
+
+func byDisplayOrder(a, b *entities.LotteryTier) bool { return a.DisplayOrder < b.DisplayOrder }
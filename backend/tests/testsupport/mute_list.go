@@ -0,0 +1,91 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.MuteListRepository = (*MuteListRepository)(nil)
+
+type mutedSenderKey struct {
+	userID      uuid.UUID
+	mutedUserID uuid.UUID
+}
+
+// MuteListRepository はMuteListRepositoryのインメモリ実装
+type MuteListRepository struct {
+	Faults
+	mu    sync.Mutex
+	mutes *table[mutedSenderKey, entities.MutedSender]
+}
+
+// NewMuteListRepository は空のMuteListRepositoryを作成
+func NewMuteListRepository() *MuteListRepository {
+	return &MuteListRepository{mutes: newTable[mutedSenderKey, entities.MutedSender]()}
+}
+
+// Upsert はミュートを作成（既にミュートしていればミュートの方法だけ変える）
+func (r *MuteListRepository) Upsert(ctx context.Context, mute *entities.MutedSender) error {
+	if err := r.hit("Upsert"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := mutedSenderKey{mute.UserID, mute.MutedUserID}
+	if existing, ok := r.mutes.ref(key); ok {
+		existing.Mode = mute.Mode
+		return nil
+	}
+	r.mutes.put(key, mute)
+	return nil
+}
+
+// Delete はミュートを解除（ミュートしていなければ何もしない）
+func (r *MuteListRepository) Delete(ctx context.Context, userID, mutedUserID uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mutes.remove(mutedSenderKey{userID, mutedUserID})
+	return nil
+}
+
+// Read はユーザーが送信者をミュートしているかを取得（ミュートしていなければnil）
+func (r *MuteListRepository) Read(ctx context.Context, userID, mutedUserID uuid.UUID) (*entities.MutedSender, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if mute, ok := r.mutes.get(mutedSenderKey{userID, mutedUserID}); ok {
+		return mute, nil
+	}
+	return nil, nil
+}
+
+// ReadListByUserID はユーザーのミュートをミュートした順に取得
+func (r *MuteListRepository) ReadListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.MutedSender, error) {
+	if err := r.hit("ReadListByUserID"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return sortBy(r.mutes.find(func(m *entities.MutedSender) bool { return m.UserID == userID }),
+		oldestFirst(func(m *entities.MutedSender) time.Time { return m.CreatedAt })), nil
+}
+
+// muted は他のリポジトリが承認待ちの一覧からミュートした送信者を除くために使う
+func (r *MuteListRepository) muted(userID, senderID uuid.UUID) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.mutes.has(mutedSenderKey{userID, senderID})
+}
@@ -0,0 +1,164 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.NotificationRepository = (*NotificationRepository)(nil)
+
+// NotificationRepository はNotificationRepositoryのインメモリ実装
+// 期限の近いバッチは batches から探し、通知済みかどうかはこのリポジトリで覚える
+type NotificationRepository struct {
+	Faults
+	mu          sync.Mutex
+	batches     *PointBatchRepository
+	tokens      *table[string, entities.DeviceToken]
+	preferences *table[uuid.UUID, entities.NotificationPreferences]
+	reminded    map[uuid.UUID]time.Time
+}
+
+// NewNotificationRepository は空のNotificationRepositoryを作成
+func NewNotificationRepository(batches *PointBatchRepository) *NotificationRepository {
+	return &NotificationRepository{
+		batches:     batches,
+		tokens:      newTable[string, entities.DeviceToken](),
+		preferences: newTable[uuid.UUID, entities.NotificationPreferences](),
+		reminded:    make(map[uuid.UUID]time.Time),
+	}
+}
+
+// UpsertDeviceToken はトークンを登録する（登録済みなら所有者とLastSeenAtを更新し、既存のIDと登録日時を反映する）
+func (r *NotificationRepository) UpsertDeviceToken(ctx context.Context, device *entities.DeviceToken) error {
+	if err := r.hit("UpsertDeviceToken"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.tokens.ref(device.Token); ok {
+		stored.UserID = device.UserID
+		stored.Platform = device.Platform
+		stored.LastSeenAt = device.LastSeenAt
+		device.ID = stored.ID
+		device.CreatedAt = stored.CreatedAt
+		return nil
+	}
+	r.tokens.put(device.Token, device)
+	return nil
+}
+
+// DeleteDeviceToken はユーザーのトークンを削除（存在しない場合はErrDeviceTokenNotFound）
+func (r *NotificationRepository) DeleteDeviceToken(ctx context.Context, userID uuid.UUID, token string) error {
+	if err := r.hit("DeleteDeviceToken"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.tokens.removeWhere(func(d *entities.DeviceToken) bool { return d.UserID == userID && d.Token == token }) == 0 {
+		return entities.ErrDeviceTokenNotFound
+	}
+	return nil
+}
+
+// DeleteDeviceTokenByID はトークンを削除
+func (r *NotificationRepository) DeleteDeviceTokenByID(ctx context.Context, id uuid.UUID) error {
+	if err := r.hit("DeleteDeviceTokenByID"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens.removeWhere(func(d *entities.DeviceToken) bool { return d.ID == id })
+	return nil
+}
+
+// ReadDeviceTokensByUser はユーザーの登録済みトークンを新しい順に取得
+func (r *NotificationRepository) ReadDeviceTokensByUser(ctx context.Context, userID uuid.UUID) ([]*entities.DeviceToken, error) {
+	if err := r.hit("ReadDeviceTokensByUser"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.tokensOf(userID), nil
+}
+
+// PruneDeviceTokens はユーザーのトークンを新しい順にkeep件だけ残して削除
+func (r *NotificationRepository) PruneDeviceTokens(ctx context.Context, userID uuid.UUID, keep int) error {
+	if err := r.hit("PruneDeviceTokens"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tokens := r.tokensOf(userID)
+	for i := keep; i < len(tokens); i++ {
+		r.tokens.remove(tokens[i].Token)
+	}
+	return nil
+}
+
+// ReadPreferences はユーザーの通知設定を取得（未設定なら初期設定を返す）
+func (r *NotificationRepository) ReadPreferences(ctx context.Context, userID uuid.UUID) (*entities.NotificationPreferences, error) {
+	if err := r.hit("ReadPreferences"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if p, ok := r.preferences.get(userID); ok {
+		return p, nil
+	}
+	return entities.DefaultNotificationPreferences(userID), nil
+}
+
+// SavePreferences はユーザーの通知設定を保存
+func (r *NotificationRepository) SavePreferences(ctx context.Context, prefs *entities.NotificationPreferences) error {
+	if err := r.hit("SavePreferences"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.preferences.put(prefs.UserID, prefs)
+	return nil
+}
+
+// ReadExpiringBatches は期限までwithin以内で、まだ期限の通知をしていない残量のあるバッチをユーザー順に取得
+func (r *NotificationRepository) ReadExpiringBatches(ctx context.Context, now time.Time, within time.Duration, limit int) ([]*entities.PointBatch, error) {
+	if err := r.hit("ReadExpiringBatches"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deadline := now.Add(within)
+	list := r.batches.all(func(b *entities.PointBatch) bool {
+		_, reminded := r.reminded[b.ID]
+		return b.RemainingAmount > 0 && !reminded && b.ExpiresAt.After(now) && !b.ExpiresAt.After(deadline)
+	})
+	list = sortBy(list, func(a, b *entities.PointBatch) bool {
+		if a.UserID != b.UserID {
+			return a.UserID.String() < b.UserID.String()
+		}
+		return a.ExpiresAt.Before(b.ExpiresAt)
+	})
+	return page(list, 0, limit), nil
+}
+
+// MarkExpiryReminded はバッチを期限の通知済みにする
+func (r *NotificationRepository) MarkExpiryReminded(ctx context.Context, batchIDs []uuid.UUID, remindedAt time.Time) error {
+	if err := r.hit("MarkExpiryReminded"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, id := range batchIDs {
+		r.reminded[id] = remindedAt
+	}
+	return nil
+}
+
+func (r *NotificationRepository) tokensOf(userID uuid.UUID) []*entities.DeviceToken {
+	list := r.tokens.find(func(d *entities.DeviceToken) bool { return d.UserID == userID })
+	return sortBy(list, newestFirst(func(d *entities.DeviceToken) time.Time { return d.LastSeenAt }))
+}
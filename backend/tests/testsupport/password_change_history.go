@@ -0,0 +1,57 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.PasswordChangeHistoryRepository = (*PasswordChangeHistoryRepository)(nil)
+
+// PasswordChangeHistoryRepository はPasswordChangeHistoryRepositoryのインメモリ実装
+type PasswordChangeHistoryRepository struct {
+	Faults
+	mu        sync.Mutex
+	histories *table[uuid.UUID, entities.PasswordChangeHistory]
+}
+
+// NewPasswordChangeHistoryRepository は空のPasswordChangeHistoryRepositoryを作成
+func NewPasswordChangeHistoryRepository() *PasswordChangeHistoryRepository {
+	return &PasswordChangeHistoryRepository{histories: newTable[uuid.UUID, entities.PasswordChangeHistory]()}
+}
+
+// Create は新しいパスワード変更履歴を作成
+func (r *PasswordChangeHistoryRepository) Create(ctx context.Context, history *entities.PasswordChangeHistory) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.histories.put(history.ID, history)
+	return nil
+}
+
+// ReadListByUserID はユーザーの履歴を変更日時の新しい順に取得
+func (r *PasswordChangeHistoryRepository) ReadListByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.PasswordChangeHistory, error) {
+	if err := r.hit("ReadListByUserID"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.histories.find(func(h *entities.PasswordChangeHistory) bool { return h.UserID == userID })
+	return page(sortBy(list, newestFirst(func(h *entities.PasswordChangeHistory) time.Time { return h.ChangedAt })), offset, limit), nil
+}
+
+// CountByUserID はユーザーの履歴数を取得
+func (r *PasswordChangeHistoryRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	if err := r.hit("CountByUserID"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.histories.count(func(h *entities.PasswordChangeHistory) bool { return h.UserID == userID }), nil
+}
@@ -0,0 +1,152 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.PendingAdminActionRepository = (*PendingAdminActionRepository)(nil)
+
+// PendingAdminActionRepository はPendingAdminActionRepositoryのインメモリ実装
+type PendingAdminActionRepository struct {
+	Faults
+	mu      sync.Mutex
+	actions *table[uuid.UUID, entities.PendingAdminAction]
+	events  *table[uuid.UUID, entities.PendingAdminActionEvent]
+}
+
+// NewPendingAdminActionRepository は空のPendingAdminActionRepositoryを作成
+func NewPendingAdminActionRepository() *PendingAdminActionRepository {
+	return &PendingAdminActionRepository{
+		actions: newTable[uuid.UUID, entities.PendingAdminAction](),
+		events:  newTable[uuid.UUID, entities.PendingAdminActionEvent](),
+	}
+}
+
+// Create は承認待ちの操作を作成（同じ冪等性キーがあればErrDuplicate）
+func (r *PendingAdminActionRepository) Create(ctx context.Context, action *entities.PendingAdminAction) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.actions.has(action.ID) || r.actions.count(func(a *entities.PendingAdminAction) bool {
+		return a.IdempotencyKey == action.IdempotencyKey
+	}) > 0 {
+		return ErrDuplicate
+	}
+	r.actions.put(action.ID, action)
+	return nil
+}
+
+// Read はIDで承認待ちの操作を取得
+func (r *PendingAdminActionRepository) Read(ctx context.Context, id uuid.UUID) (*entities.PendingAdminAction, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if a, ok := r.actions.get(id); ok {
+		return a, nil
+	}
+	return nil, entities.ErrPendingActionNotFound
+}
+
+// ReadByIdempotencyKey は申請時の冪等性キーで取得（なければErrPendingActionNotFound）
+func (r *PendingAdminActionRepository) ReadByIdempotencyKey(ctx context.Context, key string) (*entities.PendingAdminAction, error) {
+	if err := r.hit("ReadByIdempotencyKey"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if a, ok := r.actions.first(func(a *entities.PendingAdminAction) bool { return a.IdempotencyKey == key }); ok {
+		return a, nil
+	}
+	return nil, entities.ErrPendingActionNotFound
+}
+
+// ReadList は操作を新しい順に取得（statusが空なら全状態）
+func (r *PendingAdminActionRepository) ReadList(ctx context.Context, status entities.PendingAdminActionStatus, offset, limit int) ([]*entities.PendingAdminAction, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.actions.find(pendingActionStatus(status))
+	return page(sortBy(list, newestFirst(func(a *entities.PendingAdminAction) time.Time { return a.CreatedAt })), offset, limit), nil
+}
+
+// Count は操作の件数を取得（statusが空なら全状態）
+func (r *PendingAdminActionRepository) Count(ctx context.Context, status entities.PendingAdminActionStatus) (int64, error) {
+	if err := r.hit("Count"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.actions.count(pendingActionStatus(status)), nil
+}
+
+// UpdateStatus は状態がfromのときだけ状態と審査・実行の結果を更新する
+func (r *PendingAdminActionRepository) UpdateStatus(ctx context.Context, action *entities.PendingAdminAction, from entities.PendingAdminActionStatus) (bool, error) {
+	if err := r.hit("UpdateStatus"); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.actions.ref(action.ID)
+	if !ok || stored.Status != from {
+		return false, nil
+	}
+	stored.Status = action.Status
+	stored.ReviewedBy = action.ReviewedBy
+	stored.ReviewComment = action.ReviewComment
+	stored.ReviewedAt = action.ReviewedAt
+	stored.TransactionID = action.TransactionID
+	stored.FailureReason = action.FailureReason
+	stored.UpdatedAt = action.UpdatedAt
+	return true, nil
+}
+
+// ReadExpired は承認待ちのままnowまでに期限を過ぎた操作を古い順に取得
+func (r *PendingAdminActionRepository) ReadExpired(ctx context.Context, now time.Time, limit int) ([]*entities.PendingAdminAction, error) {
+	if err := r.hit("ReadExpired"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.actions.find(func(a *entities.PendingAdminAction) bool {
+		return a.Status == entities.PendingAdminActionStatusPending && !a.ExpiresAt.After(now)
+	})
+	return page(sortBy(list, oldestFirst(func(a *entities.PendingAdminAction) time.Time { return a.ExpiresAt })), 0, limit), nil
+}
+
+// CreateEvent は監査記録を追加
+func (r *PendingAdminActionRepository) CreateEvent(ctx context.Context, event *entities.PendingAdminActionEvent) error {
+	if err := r.hit("CreateEvent"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events.put(event.ID, event)
+	return nil
+}
+
+// ReadEvents は操作の監査記録を古い順に取得
+func (r *PendingAdminActionRepository) ReadEvents(ctx context.Context, actionID uuid.UUID) ([]*entities.PendingAdminActionEvent, error) {
+	if err := r.hit("ReadEvents"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.events.find(func(e *entities.PendingAdminActionEvent) bool { return e.ActionID == actionID })
+	return sortBy(list, oldestFirst(func(e *entities.PendingAdminActionEvent) time.Time { return e.CreatedAt })), nil
+}
+
+func pendingActionStatus(status entities.PendingAdminActionStatus) func(a *entities.PendingAdminAction) bool {
+	return func(a *entities.PendingAdminAction) bool { return status == "" || a.Status == status }
+}
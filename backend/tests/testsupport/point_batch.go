@@ -0,0 +1,282 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.PointBatchRepository = (*PointBatchRepository)(nil)
+
+// PointBatchRepository はPointBatchRepositoryのインメモリ実装
+type PointBatchRepository struct {
+	Faults
+	clock
+	mu          sync.Mutex
+	batches     *table[uuid.UUID, entities.PointBatch]
+	adjustments *table[uuid.UUID, entities.PointBatchAdjustment]
+}
+
+// NewPointBatchRepository は空のPointBatchRepositoryを作成
+func NewPointBatchRepository() *PointBatchRepository {
+	return &PointBatchRepository{
+		batches:     newTable[uuid.UUID, entities.PointBatch](),
+		adjustments: newTable[uuid.UUID, entities.PointBatchAdjustment](),
+	}
+}
+
+// Create は新しいポイントバッチを作成
+func (r *PointBatchRepository) Create(ctx context.Context, batch *entities.PointBatch) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.batches.has(batch.ID) {
+		return ErrDuplicate
+	}
+	r.batches.put(batch.ID, batch)
+	return nil
+}
+
+// ConsumePointsFIFO は有効なバッチから古い順にポイントを消費する
+// 足りない分はそのまま（残高の確認はユーザーの残高で行う）
+func (r *PointBatchRepository) ConsumePointsFIFO(ctx context.Context, userID uuid.UUID, amount int64) error {
+	if err := r.hit("ConsumePointsFIFO"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	active := sortBy(r.batches.refs(func(b *entities.PointBatch) bool {
+		return b.UserID == userID && b.RemainingAmount > 0 && b.ExpiresAt.After(now)
+	}), oldestFirst(func(b *entities.PointBatch) time.Time { return b.CreatedAt }))
+	remaining := amount
+	for _, b := range active {
+		if remaining <= 0 {
+			break
+		}
+		consume := min(b.RemainingAmount, remaining)
+		b.RemainingAmount -= consume
+		remaining -= consume
+	}
+	return nil
+}
+
+// FindExpiredBatches はbefore より前に期限が切れて残量があるバッチを期限の古い順に取得
+func (r *PointBatchRepository) FindExpiredBatches(ctx context.Context, before time.Time, limit int) ([]*entities.PointBatch, error) {
+	if err := r.hit("FindExpiredBatches"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.batches.find(func(b *entities.PointBatch) bool {
+		return b.ExpiresAt.Before(before) && b.RemainingAmount > 0
+	})
+	return page(sortBy(list, oldestFirst(func(b *entities.PointBatch) time.Time { return b.ExpiresAt })), 0, limit), nil
+}
+
+// MarkExpired はバッチを失効させる（失効時の残量と日時を記録する）
+func (r *PointBatchRepository) MarkExpired(ctx context.Context, batchID uuid.UUID) error {
+	if err := r.hit("MarkExpired"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if b, ok := r.batches.ref(batchID); ok {
+		now := r.now()
+		b.ExpiredAmount = b.RemainingAmount
+		b.ExpiredAt = &now
+		b.RemainingAmount = 0
+	}
+	return nil
+}
+
+// FindUpcomingExpirations はユーザーの1ヶ月以内に失効するバッチを期限が近い順に取得
+func (r *PointBatchRepository) FindUpcomingExpirations(ctx context.Context, userID uuid.UUID) ([]*entities.PointBatch, error) {
+	if err := r.hit("FindUpcomingExpirations"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	until := now.AddDate(0, 1, 0)
+	list := r.batches.find(func(b *entities.PointBatch) bool {
+		return b.UserID == userID && b.RemainingAmount > 0 && b.ExpiresAt.After(now) && !b.ExpiresAt.After(until)
+	})
+	return sortBy(list, oldestFirst(func(b *entities.PointBatch) time.Time { return b.ExpiresAt })), nil
+}
+
+// Read はIDでバッチを取得
+func (r *PointBatchRepository) Read(ctx context.Context, id uuid.UUID) (*entities.PointBatch, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if b, ok := r.batches.get(id); ok {
+		return b, nil
+	}
+	return nil, entities.ErrPointBatchNotFound
+}
+
+// UpdateExpiresAt はバッチの有効期限を更新
+func (r *PointBatchRepository) UpdateExpiresAt(ctx context.Context, id uuid.UUID, expiresAt time.Time) error {
+	if err := r.hit("UpdateExpiresAt"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if b, ok := r.batches.ref(id); ok {
+		b.ExpiresAt = expiresAt
+	}
+	return nil
+}
+
+// FindActiveByUser はユーザーの残量があるバッチを古い順に取得
+func (r *PointBatchRepository) FindActiveByUser(ctx context.Context, userID uuid.UUID) ([]*entities.PointBatch, error) {
+	if err := r.hit("FindActiveByUser"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.batches.find(func(b *entities.PointBatch) bool {
+		return b.UserID == userID && b.RemainingAmount > 0
+	})
+	return sortBy(list, oldestFirst(func(b *entities.PointBatch) time.Time { return b.CreatedAt })), nil
+}
+
+// MarkRestored はバッチを取り消し済みにする（取り消し済みならErrPointBatchRestored）
+func (r *PointBatchRepository) MarkRestored(ctx context.Context, id uuid.UUID, restoredAt time.Time) error {
+	if err := r.hit("MarkRestored"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.batches.ref(id)
+	if !ok || b.RestoredAt != nil {
+		return entities.ErrPointBatchRestored
+	}
+	b.RestoredAt = &restoredAt
+	return nil
+}
+
+// FindRestorableBatches はexpiredAfter以降に失効し、取り消されていないバッチを新しい順に取得
+func (r *PointBatchRepository) FindRestorableBatches(ctx context.Context, expiredAfter time.Time, userID *uuid.UUID, limit int) ([]*entities.PointBatch, error) {
+	if err := r.hit("FindRestorableBatches"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.batches.find(func(b *entities.PointBatch) bool {
+		if userID != nil && b.UserID != *userID {
+			return false
+		}
+		return b.ExpiredAt != nil && !b.ExpiredAt.Before(expiredAfter) && b.RestoredAt == nil && b.ExpiredAmount > 0
+	})
+	return page(sortBy(list, newestFirst(func(b *entities.PointBatch) time.Time { return *b.ExpiredAt })), 0, limit), nil
+}
+
+// FindByUser はユーザーのすべてのバッチを作成日時の新しい順に取得
+func (r *PointBatchRepository) FindByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.PointBatch, error) {
+	if err := r.hit("FindByUser"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.batches.find(func(b *entities.PointBatch) bool { return b.UserID == userID })
+	return page(sortBy(list, newestFirst(func(b *entities.PointBatch) time.Time { return b.CreatedAt })), offset, limit), nil
+}
+
+// CountByUser はユーザーのバッチの件数を取得
+func (r *PointBatchRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	if err := r.hit("CountByUser"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.batches.count(func(b *entities.PointBatch) bool { return b.UserID == userID }), nil
+}
+
+// AdjustExpiresAt は管理者が変更した有効期限を設定する（失効済み・取り消し済みならErrPointBatchNotAdjustable）
+func (r *PointBatchRepository) AdjustExpiresAt(ctx context.Context, id uuid.UUID, expiresAt, adjustedAt time.Time) error {
+	if err := r.hit("AdjustExpiresAt"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.batches.ref(id)
+	if !ok || b.ExpiredAt != nil || b.CancelledAt != nil {
+		return entities.ErrPointBatchNotAdjustable
+	}
+	b.ExpiresAt = expiresAt
+	b.ExpiryAdjustedAt = &adjustedAt
+	return nil
+}
+
+// Cancel はバッチを取り消し、残量を0にする（残量がremainingから変わっていればErrUpdateConflict）
+func (r *PointBatchRepository) Cancel(ctx context.Context, id uuid.UUID, remaining int64, cancelledAt time.Time) error {
+	if err := r.hit("Cancel"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.batches.ref(id)
+	if !ok {
+		return entities.ErrPointBatchNotFound
+	}
+	if b.ExpiredAt != nil || b.CancelledAt != nil {
+		return entities.ErrPointBatchNotAdjustable
+	}
+	if b.RemainingAmount != remaining {
+		return entities.ErrUpdateConflict
+	}
+	b.CancelledAmount = b.RemainingAmount
+	b.RemainingAmount = 0
+	b.CancelledAt = &cancelledAt
+	return nil
+}
+
+// CreateAdjustment は調整の記録を作成
+func (r *PointBatchRepository) CreateAdjustment(ctx context.Context, adjustment *entities.PointBatchAdjustment) error {
+	if err := r.hit("CreateAdjustment"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.adjustments.has(adjustment.ID) {
+		return ErrDuplicate
+	}
+	r.adjustments.put(adjustment.ID, adjustment)
+	return nil
+}
+
+// FindAdjustments はバッチの調整の記録を古い順に取得
+func (r *PointBatchRepository) FindAdjustments(ctx context.Context, batchIDs []uuid.UUID) ([]*entities.PointBatchAdjustment, error) {
+	if err := r.hit("FindAdjustments"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids := make(map[uuid.UUID]bool, len(batchIDs))
+	for _, id := range batchIDs {
+		ids[id] = true
+	}
+	list := r.adjustments.find(func(a *entities.PointBatchAdjustment) bool { return ids[a.BatchID] })
+	return sortBy(list, oldestFirst(func(a *entities.PointBatchAdjustment) time.Time { return a.CreatedAt })), nil
+}
+
+// all は他のリポジトリがバッチを探すために使う
+func (r *PointBatchRepository) all(match func(b *entities.PointBatch) bool) []*entities.PointBatch {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.batches.find(match)
+}
@@ -0,0 +1,95 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.PointExpiryPolicyRepository = (*PointExpiryPolicyRepository)(nil)
+
+// PointExpiryPolicyRepository はPointExpiryPolicyRepositoryのインメモリ実装
+type PointExpiryPolicyRepository struct {
+	Faults
+	mu        sync.Mutex
+	policies  *table[entities.PointBatchSourceType, entities.PointExpiryPolicy]
+	overrides *table[uuid.UUID, entities.UserPointExpiryOverride]
+}
+
+// NewPointExpiryPolicyRepository は空のPointExpiryPolicyRepositoryを作成
+func NewPointExpiryPolicyRepository() *PointExpiryPolicyRepository {
+	return &PointExpiryPolicyRepository{
+		policies:  newTable[entities.PointBatchSourceType, entities.PointExpiryPolicy](),
+		overrides: newTable[uuid.UUID, entities.UserPointExpiryOverride](),
+	}
+}
+
+// ReadListPolicies は全ての付与種別ポリシーを付与種別順に取得
+func (r *PointExpiryPolicyRepository) ReadListPolicies(ctx context.Context) ([]*entities.PointExpiryPolicy, error) {
+	if err := r.hit("ReadListPolicies"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return sortBy(r.policies.find(nil), func(a, b *entities.PointExpiryPolicy) bool { return a.SourceType < b.SourceType }), nil
+}
+
+// SavePolicy は付与種別ポリシーを保存
+func (r *PointExpiryPolicyRepository) SavePolicy(ctx context.Context, policy *entities.PointExpiryPolicy) error {
+	if err := r.hit("SavePolicy"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policies.put(policy.SourceType, policy)
+	return nil
+}
+
+// DeletePolicy は付与種別ポリシーを削除
+func (r *PointExpiryPolicyRepository) DeletePolicy(ctx context.Context, sourceType entities.PointBatchSourceType) error {
+	if err := r.hit("DeletePolicy"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policies.remove(sourceType)
+	return nil
+}
+
+// ReadUserOverride はユーザー個別の設定を取得（ない場合はnil）
+func (r *PointExpiryPolicyRepository) ReadUserOverride(ctx context.Context, userID uuid.UUID) (*entities.UserPointExpiryOverride, error) {
+	if err := r.hit("ReadUserOverride"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if o, ok := r.overrides.get(userID); ok {
+		return o, nil
+	}
+	return nil, nil
+}
+
+// SaveUserOverride はユーザー個別の設定を保存
+func (r *PointExpiryPolicyRepository) SaveUserOverride(ctx context.Context, override *entities.UserPointExpiryOverride) error {
+	if err := r.hit("SaveUserOverride"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.overrides.put(override.UserID, override)
+	return nil
+}
+
+// DeleteUserOverride はユーザー個別の設定を削除
+func (r *PointExpiryPolicyRepository) DeleteUserOverride(ctx context.Context, userID uuid.UUID) error {
+	if err := r.hit("DeleteUserOverride"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.overrides.remove(userID)
+	return nil
+}
@@ -0,0 +1,122 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.PricingRuleRepository = (*PricingRuleRepository)(nil)
+
+// PricingRuleRepository はPricingRuleRepositoryのインメモリ実装
+type PricingRuleRepository struct {
+	Faults
+	mu    sync.Mutex
+	rules *table[uuid.UUID, entities.PricingRule]
+}
+
+// NewPricingRuleRepository は空のPricingRuleRepositoryを作成
+func NewPricingRuleRepository() *PricingRuleRepository {
+	return &PricingRuleRepository{rules: newTable[uuid.UUID, entities.PricingRule]()}
+}
+
+// Create は価格ルールを作成
+func (r *PricingRuleRepository) Create(ctx context.Context, rule *entities.PricingRule) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.rules.has(rule.ID) {
+		return ErrDuplicate
+	}
+	r.rules.put(rule.ID, rule)
+	return nil
+}
+
+// Read はIDで価格ルールを取得
+func (r *PricingRuleRepository) Read(ctx context.Context, id uuid.UUID) (*entities.PricingRule, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rule, ok := r.rules.get(id); ok {
+		return rule, nil
+	}
+	return nil, entities.ErrPricingRuleNotFound
+}
+
+// Update は価格ルールを更新（作成者と作成日時は変えない）
+func (r *PricingRuleRepository) Update(ctx context.Context, rule *entities.PricingRule) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.rules.ref(rule.ID)
+	if !ok {
+		return entities.ErrPricingRuleNotFound
+	}
+	createdBy, createdAt := stored.CreatedBy, stored.CreatedAt
+	*stored = *rule
+	stored.CreatedBy, stored.CreatedAt = createdBy, createdAt
+	return nil
+}
+
+// Delete は価格ルールを削除
+func (r *PricingRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.rules.remove(id) {
+		return entities.ErrPricingRuleNotFound
+	}
+	return nil
+}
+
+// ReadList は価格ルールを開始日時の新しい順に取得
+func (r *PricingRuleRepository) ReadList(ctx context.Context, offset, limit int) ([]*entities.PricingRule, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := sortBy(r.rules.find(nil), newestFirst(func(rule *entities.PricingRule) time.Time { return rule.StartsAt }))
+	return page(list, offset, limit), nil
+}
+
+// Count は価格ルールの件数を取得
+func (r *PricingRuleRepository) Count(ctx context.Context) (int64, error) {
+	if err := r.hit("Count"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rules.count(nil), nil
+}
+
+// ReadActiveForProduct は指定日時に有効な、商品またはそのカテゴリを対象とする価格ルールを作成日時の古い順に取得
+func (r *PricingRuleRepository) ReadActiveForProduct(ctx context.Context, productID uuid.UUID, categoryCode string, now time.Time) ([]*entities.PricingRule, error) {
+	if err := r.hit("ReadActiveForProduct"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.rules.find(func(rule *entities.PricingRule) bool {
+		if !rule.IsActive || rule.StartsAt.After(now) || (rule.EndsAt != nil && !rule.EndsAt.After(now)) {
+			return false
+		}
+		if rule.ProductID != nil {
+			return *rule.ProductID == productID
+		}
+		return rule.CategoryCode == categoryCode
+	})
+	return sortBy(list, oldestFirst(func(rule *entities.PricingRule) time.Time { return rule.CreatedAt })), nil
+}
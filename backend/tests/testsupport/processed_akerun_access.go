@@ -0,0 +1,73 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.ProcessedAkerunAccessRepository = (*ProcessedAkerunAccessRepository)(nil)
+
+// ProcessedAkerunAccessRepository はProcessedAkerunAccessRepositoryのインメモリ実装
+type ProcessedAkerunAccessRepository struct {
+	Faults
+	mu       sync.Mutex
+	accesses *table[uuid.UUID, entities.ProcessedAkerunAccess]
+}
+
+// NewProcessedAkerunAccessRepository は空のProcessedAkerunAccessRepositoryを作成
+func NewProcessedAkerunAccessRepository() *ProcessedAkerunAccessRepository {
+	return &ProcessedAkerunAccessRepository{accesses: newTable[uuid.UUID, entities.ProcessedAkerunAccess]()}
+}
+
+// Processed は処理済みの記録を記録順に返す
+func (r *ProcessedAkerunAccessRepository) Processed() []*entities.ProcessedAkerunAccess {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.accesses.find(nil)
+}
+
+// ReadProcessedIDs はaccessIDsのうち処理済みのIDを返す
+func (r *ProcessedAkerunAccessRepository) ReadProcessedIDs(ctx context.Context, accessIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	if err := r.hit("ReadProcessedIDs"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	processed := map[uuid.UUID]bool{}
+	for _, id := range accessIDs {
+		if r.accesses.has(id) {
+			processed[id] = true
+		}
+	}
+	return processed, nil
+}
+
+// Create は処理済みとして記録（記録済みのアクセス記録は何もしない）
+func (r *ProcessedAkerunAccessRepository) Create(ctx context.Context, accesses []*entities.ProcessedAkerunAccess) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, a := range accesses {
+		if !r.accesses.has(a.AccessID) {
+			r.accesses.put(a.AccessID, a)
+		}
+	}
+	return nil
+}
+
+// DeleteProcessedBefore はbeforeより前に処理した記録を削除
+func (r *ProcessedAkerunAccessRepository) DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error) {
+	if err := r.hit("DeleteProcessedBefore"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.accesses.removeWhere(func(a *entities.ProcessedAkerunAccess) bool { return a.ProcessedAt.Before(before) }), nil
+}
@@ -0,0 +1,139 @@
+package testsupport
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.ProductRepository = (*ProductRepository)(nil)
+
+// ProductRepository はProductRepositoryのインメモリ実装
+type ProductRepository struct {
+	Faults
+	clock
+	mu       sync.Mutex
+	products *table[uuid.UUID, entities.Product]
+}
+
+// NewProductRepository は空のProductRepositoryを作成
+func NewProductRepository() *ProductRepository {
+	return &ProductRepository{products: newTable[uuid.UUID, entities.Product]()}
+}
+
+// Create は新しい商品を作成
+func (r *ProductRepository) Create(ctx context.Context, product *entities.Product) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.products.has(product.ID) {
+		return ErrDuplicate
+	}
+	r.products.put(product.ID, product)
+	return nil
+}
+
+// Read はIDで商品を検索（論理削除済みは見つからない）
+func (r *ProductRepository) Read(ctx context.Context, id uuid.UUID) (*entities.Product, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if p, ok := r.products.get(id); ok && p.DeletedAt == nil {
+		return p, nil
+	}
+	return nil, errors.New("product not found")
+}
+
+// Update は商品情報を更新
+func (r *ProductRepository) Update(ctx context.Context, product *entities.Product) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.products.ref(product.ID); ok && stored.DeletedAt == nil {
+		*stored = *product
+	}
+	return nil
+}
+
+// Delete は商品を論理削除
+func (r *ProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.products.ref(id); ok && stored.DeletedAt == nil {
+		now := r.now()
+		stored.DeletedAt = &now
+	}
+	return nil
+}
+
+// ReadList は商品一覧を新しい順に取得
+func (r *ProductRepository) ReadList(ctx context.Context, offset, limit int) ([]*entities.Product, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	return r.list(nil, offset, limit), nil
+}
+
+// ReadListByCategory はカテゴリ別の商品一覧を新しい順に取得
+func (r *ProductRepository) ReadListByCategory(ctx context.Context, categoryCode string, offset, limit int) ([]*entities.Product, error) {
+	if err := r.hit("ReadListByCategory"); err != nil {
+		return nil, err
+	}
+	return r.list(func(p *entities.Product) bool { return p.CategoryCode == categoryCode }, offset, limit), nil
+}
+
+// ReadAvailableList は交換可能な商品一覧を新しい順に取得
+func (r *ProductRepository) ReadAvailableList(ctx context.Context, offset, limit int) ([]*entities.Product, error) {
+	if err := r.hit("ReadAvailableList"); err != nil {
+		return nil, err
+	}
+	return r.list(func(p *entities.Product) bool { return p.IsAvailable }, offset, limit), nil
+}
+
+// Count は商品総数を取得
+func (r *ProductRepository) Count(ctx context.Context) (int64, error) {
+	if err := r.hit("Count"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.products.count(notDeleted(nil)), nil
+}
+
+// UpdateStock は在庫をquantityだけ増減する
+func (r *ProductRepository) UpdateStock(ctx context.Context, productID uuid.UUID, quantity int) error {
+	if err := r.hit("UpdateStock"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.products.ref(productID); ok && stored.DeletedAt == nil {
+		stored.Stock += quantity
+	}
+	return nil
+}
+
+func (r *ProductRepository) list(match func(p *entities.Product) bool, offset, limit int) []*entities.Product {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := sortBy(r.products.find(notDeleted(match)), newestFirst(func(p *entities.Product) time.Time { return p.CreatedAt }))
+	return page(list, offset, limit)
+}
+
+func notDeleted(match func(p *entities.Product) bool) func(p *entities.Product) bool {
+	return func(p *entities.Product) bool { return p.DeletedAt == nil && (match == nil || match(p)) }
+}
@@ -0,0 +1,204 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.ProductExchangeRepository = (*ProductExchangeRepository)(nil)
+
+// ProductExchangeRepository はProductExchangeRepositoryのインメモリ実装
+// ReadReport は Report に設定した行をそのまま返す
+type ProductExchangeRepository struct {
+	Faults
+	mu        sync.Mutex
+	exchanges *table[uuid.UUID, entities.ProductExchange]
+
+	Report []*entities.ExchangeReportRow
+}
+
+// NewProductExchangeRepository は空のProductExchangeRepositoryを作成
+func NewProductExchangeRepository() *ProductExchangeRepository {
+	return &ProductExchangeRepository{exchanges: newTable[uuid.UUID, entities.ProductExchange]()}
+}
+
+// Create は新しい交換を作成
+func (r *ProductExchangeRepository) Create(ctx context.Context, exchange *entities.ProductExchange) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.exchanges.has(exchange.ID) {
+		return ErrDuplicate
+	}
+	r.exchanges.put(exchange.ID, exchange)
+	return nil
+}
+
+// Read はIDで交換を検索
+func (r *ProductExchangeRepository) Read(ctx context.Context, id uuid.UUID) (*entities.ProductExchange, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e, ok := r.exchanges.get(id); ok {
+		return e, nil
+	}
+	return nil, entities.ErrExchangeNotFound
+}
+
+// Update は交換情報を更新
+func (r *ProductExchangeRepository) Update(ctx context.Context, exchange *entities.ProductExchange) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.exchanges.has(exchange.ID) {
+		r.exchanges.put(exchange.ID, exchange)
+	}
+	return nil
+}
+
+// ReadListByUserID はユーザーの交換履歴を新しい順に取得
+func (r *ProductExchangeRepository) ReadListByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.ProductExchange, error) {
+	if err := r.hit("ReadListByUserID"); err != nil {
+		return nil, err
+	}
+	return r.list(exchangedBy(userID), offset, limit), nil
+}
+
+// ReadListAll はすべての交換履歴を新しい順に取得
+func (r *ProductExchangeRepository) ReadListAll(ctx context.Context, offset, limit int) ([]*entities.ProductExchange, error) {
+	if err := r.hit("ReadListAll"); err != nil {
+		return nil, err
+	}
+	return r.list(nil, offset, limit), nil
+}
+
+// CountByUserID はユーザーの交換総数を取得
+func (r *ProductExchangeRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	if err := r.hit("CountByUserID"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.exchanges.count(exchangedBy(userID)), nil
+}
+
+// ReadListByRecipientID はユーザーが贈られた交換を新しい順に取得
+func (r *ProductExchangeRepository) ReadListByRecipientID(ctx context.Context, recipientID uuid.UUID, offset, limit int) ([]*entities.ProductExchange, error) {
+	if err := r.hit("ReadListByRecipientID"); err != nil {
+		return nil, err
+	}
+	return r.list(giftedTo(recipientID), offset, limit), nil
+}
+
+// CountByRecipientID はユーザーが贈られた交換の総数を取得
+func (r *ProductExchangeRepository) CountByRecipientID(ctx context.Context, recipientID uuid.UUID) (int64, error) {
+	if err := r.hit("CountByRecipientID"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.exchanges.count(giftedTo(recipientID)), nil
+}
+
+// CountAll は全体の交換総数を取得
+func (r *ProductExchangeRepository) CountAll(ctx context.Context) (int64, error) {
+	if err := r.hit("CountAll"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.exchanges.count(nil), nil
+}
+
+// ReadByRedemptionCode は引換コードで交換を検索（見つからなければErrRedemptionCodeNotFound）
+func (r *ProductExchangeRepository) ReadByRedemptionCode(ctx context.Context, code string) (*entities.ProductExchange, error) {
+	if err := r.hit("ReadByRedemptionCode"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e, ok := r.exchanges.first(func(e *entities.ProductExchange) bool { return e.RedemptionCode == code }); ok {
+		return e, nil
+	}
+	return nil, entities.ErrRedemptionCodeNotFound
+}
+
+// MarkRedeemed は引換コードを使用済みにする（使用済み・キャンセル済みならErrRedemptionCodeUsed）
+func (r *ProductExchangeRepository) MarkRedeemed(ctx context.Context, exchange *entities.ProductExchange) error {
+	if err := r.hit("MarkRedeemed"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.exchanges.ref(exchange.ID)
+	if !ok || stored.Status != entities.ExchangeStatusCompleted || stored.RedeemedAt != nil {
+		return entities.ErrRedemptionCodeUsed
+	}
+	stored.Status = exchange.Status
+	stored.RedeemedAt = exchange.RedeemedAt
+	stored.DeliveredAt = exchange.DeliveredAt
+	return nil
+}
+
+// ReadReport は Report に設定した行を返す
+func (r *ProductExchangeRepository) ReadReport(ctx context.Context, from, to time.Time, granularity entities.AnalyticsGranularity) ([]*entities.ExchangeReportRow, error) {
+	if err := r.hit("ReadReport"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return copies(r.Report), nil
+}
+
+// SumQuantityByPricingRule はユーザーが価格ルールの割引価格で交換した数量の合計を取得（キャンセルは除く）
+func (r *ProductExchangeRepository) SumQuantityByPricingRule(ctx context.Context, userID, ruleID uuid.UUID) (int, error) {
+	if err := r.hit("SumQuantityByPricingRule"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	total := 0
+	for _, e := range r.exchanges.refs(func(e *entities.ProductExchange) bool {
+		return e.UserID == userID && e.PricingRuleID != nil && *e.PricingRuleID == ruleID &&
+			e.Status != entities.ExchangeStatusCancelled
+	}) {
+		total += e.Quantity
+	}
+	return total, nil
+}
+
+func (r *ProductExchangeRepository) list(match func(e *entities.ProductExchange) bool, offset, limit int) []*entities.ProductExchange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := sortBy(r.exchanges.find(match), newestFirst(func(e *entities.ProductExchange) time.Time { return e.CreatedAt }))
+	return page(list, offset, limit)
+}
+
+func exchangedBy(userID uuid.UUID) func(e *entities.ProductExchange) bool {
+	return func(e *entities.ProductExchange) bool { return e.UserID == userID }
+}
+
+func giftedTo(recipientID uuid.UUID) func(e *entities.ProductExchange) bool {
+	return func(e *entities.ProductExchange) bool { return e.RecipientID != nil && *e.RecipientID == recipientID }
+}
+
+// all は他のリポジトリが交換を探すために使う
+func (r *ProductExchangeRepository) all(match func(e *entities.ProductExchange) bool) []*entities.ProductExchange {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.exchanges.find(match)
+}
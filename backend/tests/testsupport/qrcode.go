@@ -0,0 +1,103 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.QRCodeRepository = (*QRCodeRepository)(nil)
+
+// QRCodeRepository はQRCodeRepositoryのインメモリ実装
+type QRCodeRepository struct {
+	Faults
+	clock
+	mu      sync.Mutex
+	qrcodes *table[uuid.UUID, entities.QRCode]
+}
+
+// NewQRCodeRepository は空のQRCodeRepositoryを作成
+func NewQRCodeRepository() *QRCodeRepository {
+	return &QRCodeRepository{qrcodes: newTable[uuid.UUID, entities.QRCode]()}
+}
+
+// Create は新しいQRコードを作成（同じコードがあればErrDuplicate）
+func (r *QRCodeRepository) Create(ctx context.Context, qrCode *entities.QRCode) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.qrcodes.has(qrCode.ID) || r.qrcodes.count(func(q *entities.QRCode) bool { return q.Code == qrCode.Code }) > 0 {
+		return ErrDuplicate
+	}
+	r.qrcodes.put(qrCode.ID, qrCode)
+	return nil
+}
+
+// ReadByCode はコードでQRコードを検索
+func (r *QRCodeRepository) ReadByCode(ctx context.Context, code string) (*entities.QRCode, error) {
+	if err := r.hit("ReadByCode"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if q, ok := r.qrcodes.first(func(q *entities.QRCode) bool { return q.Code == code }); ok {
+		return q, nil
+	}
+	return nil, entities.ErrQRCodeNotFound
+}
+
+// Read はIDでQRコードを検索
+func (r *QRCodeRepository) Read(ctx context.Context, id uuid.UUID) (*entities.QRCode, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if q, ok := r.qrcodes.get(id); ok {
+		return q, nil
+	}
+	return nil, entities.ErrQRCodeNotFound
+}
+
+// ReadListByUserID はユーザーのQRコード一覧を新しい順に取得
+func (r *QRCodeRepository) ReadListByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.QRCode, error) {
+	if err := r.hit("ReadListByUserID"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.qrcodes.find(func(q *entities.QRCode) bool { return q.UserID == userID })
+	return page(sortBy(list, newestFirst(func(q *entities.QRCode) time.Time { return q.CreatedAt })), offset, limit), nil
+}
+
+// Update はQRコードの使用状況を更新
+func (r *QRCodeRepository) Update(ctx context.Context, qrCode *entities.QRCode) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.qrcodes.ref(qrCode.ID); ok {
+		stored.UsedAt = qrCode.UsedAt
+		stored.UsedByUserID = qrCode.UsedByUserID
+	}
+	return nil
+}
+
+// DeleteExpired は期限切れQRコードを削除
+func (r *QRCodeRepository) DeleteExpired(ctx context.Context) error {
+	if err := r.hit("DeleteExpired"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	r.qrcodes.removeWhere(func(q *entities.QRCode) bool { return q.ExpiresAt.Before(now) })
+	return nil
+}
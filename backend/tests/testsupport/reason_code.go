@@ -0,0 +1,79 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+var _ repository.ReasonCodeRepository = (*ReasonCodeRepository)(nil)
+
+// ReasonCodeRepository はReasonCodeRepositoryのインメモリ実装
+type ReasonCodeRepository struct {
+	Faults
+	mu    sync.Mutex
+	codes *table[string, entities.ReasonCode]
+}
+
+// NewReasonCodeRepository は空のReasonCodeRepositoryを作成
+func NewReasonCodeRepository() *ReasonCodeRepository {
+	return &ReasonCodeRepository{codes: newTable[string, entities.ReasonCode]()}
+}
+
+// Create は理由コードを作成（同じコードが既にあればErrReasonCodeExists）
+func (r *ReasonCodeRepository) Create(ctx context.Context, reasonCode *entities.ReasonCode) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.codes.has(reasonCode.Code) {
+		return entities.ErrReasonCodeExists
+	}
+	r.codes.put(reasonCode.Code, reasonCode)
+	return nil
+}
+
+// ReadByCode はコードで理由コードを取得
+func (r *ReasonCodeRepository) ReadByCode(ctx context.Context, code string) (*entities.ReasonCode, error) {
+	if err := r.hit("ReadByCode"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rc, ok := r.codes.get(code); ok {
+		return rc, nil
+	}
+	return nil, entities.ErrReasonCodeNotFound
+}
+
+// Update は理由コードの表示名・説明・有効状態を更新
+func (r *ReasonCodeRepository) Update(ctx context.Context, reasonCode *entities.ReasonCode) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.codes.ref(reasonCode.Code)
+	if !ok {
+		return entities.ErrReasonCodeNotFound
+	}
+	stored.Label = reasonCode.Label
+	stored.Description = reasonCode.Description
+	stored.IsActive = reasonCode.IsActive
+	stored.UpdatedAt = reasonCode.UpdatedAt
+	return nil
+}
+
+// ReadList は理由コードをコード順に取得（activeOnlyがfalseなら無効なものも含む）
+func (r *ReasonCodeRepository) ReadList(ctx context.Context, activeOnly bool) ([]*entities.ReasonCode, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.codes.find(func(rc *entities.ReasonCode) bool { return !activeOnly || rc.IsActive })
+	return sortBy(list, func(a, b *entities.ReasonCode) bool { return a.Code < b.Code }), nil
+}
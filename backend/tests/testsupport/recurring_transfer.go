@@ -0,0 +1,113 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.RecurringTransferRepository = (*RecurringTransferRepository)(nil)
+
+// RecurringTransferRepository はRecurringTransferRepositoryのインメモリ実装
+type RecurringTransferRepository struct {
+	Faults
+	mu        sync.Mutex
+	transfers *table[uuid.UUID, entities.RecurringTransfer]
+}
+
+// NewRecurringTransferRepository は空のRecurringTransferRepositoryを作成
+func NewRecurringTransferRepository() *RecurringTransferRepository {
+	return &RecurringTransferRepository{transfers: newTable[uuid.UUID, entities.RecurringTransfer]()}
+}
+
+// Create は定期送金を作成
+func (r *RecurringTransferRepository) Create(ctx context.Context, transfer *entities.RecurringTransfer) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.transfers.has(transfer.ID) {
+		return ErrDuplicate
+	}
+	r.transfers.put(transfer.ID, transfer)
+	return nil
+}
+
+// Read はIDで定期送金を取得（存在しない場合はErrRecurringNotFound）
+func (r *RecurringTransferRepository) Read(ctx context.Context, id uuid.UUID) (*entities.RecurringTransfer, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := r.transfers.get(id); ok {
+		return t, nil
+	}
+	return nil, entities.ErrRecurringNotFound
+}
+
+// Update は定期送金の状態と実行履歴を更新
+func (r *RecurringTransferRepository) Update(ctx context.Context, transfer *entities.RecurringTransfer) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.transfers.ref(transfer.ID)
+	if !ok {
+		return entities.ErrRecurringNotFound
+	}
+	stored.Status = transfer.Status
+	stored.NextRunAt = transfer.NextRunAt
+	stored.LastRunAt = transfer.LastRunAt
+	stored.RunCount = transfer.RunCount
+	stored.ConsecutiveFailures = transfer.ConsecutiveFailures
+	stored.StopReason = transfer.StopReason
+	stored.CancelledBy = transfer.CancelledBy
+	stored.CancelledAt = transfer.CancelledAt
+	stored.UpdatedAt = transfer.UpdatedAt
+	return nil
+}
+
+// ReadListByUser は送信者または受取人として関わる定期送金を新しい順に取得
+func (r *RecurringTransferRepository) ReadListByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.RecurringTransfer, error) {
+	if err := r.hit("ReadListByUser"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.transfers.find(recurringFor(userID))
+	return page(sortBy(list, newestFirst(func(t *entities.RecurringTransfer) time.Time { return t.CreatedAt })), offset, limit), nil
+}
+
+// CountByUser は送信者または受取人として関わる定期送金の件数を取得
+func (r *RecurringTransferRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	if err := r.hit("CountByUser"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.transfers.count(recurringFor(userID)), nil
+}
+
+// ReadDue は実行日時を過ぎた有効な定期送金を実行日時の古い順に取得
+func (r *RecurringTransferRepository) ReadDue(ctx context.Context, now time.Time, limit int) ([]*entities.RecurringTransfer, error) {
+	if err := r.hit("ReadDue"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.transfers.find(func(t *entities.RecurringTransfer) bool {
+		return t.Status == entities.RecurringTransferStatusActive && !t.NextRunAt.After(now)
+	})
+	return page(sortBy(list, oldestFirst(func(t *entities.RecurringTransfer) time.Time { return t.NextRunAt })), 0, limit), nil
+}
+
+func recurringFor(userID uuid.UUID) func(t *entities.RecurringTransfer) bool {
+	return func(t *entities.RecurringTransfer) bool { return t.FromUserID == userID || t.ToUserID == userID }
+}
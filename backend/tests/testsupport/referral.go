@@ -0,0 +1,146 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.ReferralRepository = (*ReferralRepository)(nil)
+
+// ReferralRepository はReferralRepositoryのインメモリ実装
+// ReadReport は Report に設定した集計結果を返す（nilなら件数0の集計）
+type ReferralRepository struct {
+	Faults
+	mu        sync.Mutex
+	codes     *table[uuid.UUID, entities.ReferralCode]
+	referrals *table[uuid.UUID, entities.Referral]
+
+	Report *entities.ReferralReport
+}
+
+// NewReferralRepository は空のReferralRepositoryを作成
+func NewReferralRepository() *ReferralRepository {
+	return &ReferralRepository{
+		codes:     newTable[uuid.UUID, entities.ReferralCode](),
+		referrals: newTable[uuid.UUID, entities.Referral](),
+	}
+}
+
+// ReadCodeByUser はユーザーの紹介コードを取得（未発行ならnil）
+func (r *ReferralRepository) ReadCodeByUser(ctx context.Context, userID uuid.UUID) (*entities.ReferralCode, error) {
+	if err := r.hit("ReadCodeByUser"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, ok := r.codes.get(userID); ok {
+		return c, nil
+	}
+	return nil, nil
+}
+
+// ReadCodeByCode は紹介コードから持ち主を取得（存在しなければErrInvalidReferralCode）
+func (r *ReferralRepository) ReadCodeByCode(ctx context.Context, code string) (*entities.ReferralCode, error) {
+	if err := r.hit("ReadCodeByCode"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, ok := r.codes.first(func(c *entities.ReferralCode) bool { return c.Code == code }); ok {
+		return c, nil
+	}
+	return nil, entities.ErrInvalidReferralCode
+}
+
+// CreateCode は紹介コードを保存（同じユーザーのコードが既にあれば何もしない）
+func (r *ReferralRepository) CreateCode(ctx context.Context, code *entities.ReferralCode) error {
+	if err := r.hit("CreateCode"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.codes.has(code.UserID) {
+		r.codes.put(code.UserID, code)
+	}
+	return nil
+}
+
+// Create は紹介を記録（紹介された側の紹介が既にあればErrDuplicate）
+func (r *ReferralRepository) Create(ctx context.Context, referral *entities.Referral) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.referrals.has(referral.ID) || r.referrals.count(func(ref *entities.Referral) bool {
+		return ref.RefereeID == referral.RefereeID
+	}) > 0 {
+		return ErrDuplicate
+	}
+	r.referrals.put(referral.ID, referral)
+	return nil
+}
+
+// ReadPendingByReferee は紹介された側の初回チェックイン待ちの紹介を取得（なければnil）
+func (r *ReferralRepository) ReadPendingByReferee(ctx context.Context, refereeID uuid.UUID) (*entities.Referral, error) {
+	if err := r.hit("ReadPendingByReferee"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if ref, ok := r.referrals.first(func(ref *entities.Referral) bool {
+		return ref.RefereeID == refereeID && ref.Status == entities.ReferralStatusPending
+	}); ok {
+		return ref, nil
+	}
+	return nil, nil
+}
+
+// CountByIPSince は指定日時以降に同じIPアドレスから登録された紹介の数を取得
+func (r *ReferralRepository) CountByIPSince(ctx context.Context, ipAddress string, since time.Time) (int64, error) {
+	if err := r.hit("CountByIPSince"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.referrals.count(func(ref *entities.Referral) bool {
+		return ref.IPAddress == ipAddress && !ref.CreatedAt.Before(since)
+	}), nil
+}
+
+// MarkRewarded は初回チェックイン待ちの紹介を特典付与済みにする（既に処理済みならfalse）
+func (r *ReferralRepository) MarkRewarded(ctx context.Context, id uuid.UUID, referrerReward, refereeReward int64, at time.Time) (bool, error) {
+	if err := r.hit("MarkRewarded"); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.referrals.ref(id)
+	if !ok || stored.Status != entities.ReferralStatusPending {
+		return false, nil
+	}
+	stored.Status = entities.ReferralStatusRewarded
+	stored.ReferrerReward = referrerReward
+	stored.RefereeReward = refereeReward
+	stored.RewardedAt = &at
+	return true, nil
+}
+
+// ReadReport は Report に設定した集計結果を返す
+func (r *ReferralRepository) ReadReport(ctx context.Context, from, to time.Time, limit int) (*entities.ReferralReport, error) {
+	if err := r.hit("ReadReport"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Report == nil {
+		return &entities.ReferralReport{From: from, To: to, TopReferrers: []*entities.ReferrerStats{}}, nil
+	}
+	report := *r.Report
+	return &report, nil
+}
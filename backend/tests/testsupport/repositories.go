@@ -0,0 +1,147 @@
+package testsupport
+
+// Repositories はすべてのインメモリリポジトリをまとめたもの
+// 集計を行うリポジトリは、同じ Repositories の他のリポジトリの内容を参照する
+type Repositories struct {
+	TxManager *TransactionManager
+
+	Users                 *UserRepository
+	Transactions          *TransactionRepository
+	IdempotencyKeys       *IdempotencyKeyRepository
+	PointBatches          *PointBatchRepository
+	BalanceHolds          *BalanceHoldRepository
+	Friendships           *FriendshipRepository
+	FriendPins            *FriendPinRepository
+	MuteList              *MuteListRepository
+	FriendDiscovery       *FriendDiscoveryRepository
+	SystemSettings        *SystemSettingsRepository
+	Announcements         *AnnouncementRepository
+	AkerunRepolls         *AkerunRepollRepository
+	Budgets               *BudgetRepository
+	Carts                 *CartRepository
+	Categories            *CategoryRepository
+	ContentViolations     *ContentViolationRepository
+	DailyBonuses          *DailyBonusRepository
+	DataExports           *DataExportRepository
+	Departments           *DepartmentRepository
+	Events                *EventRepository
+	FailedAkerunAccesses  *FailedAkerunAccessRepository
+	IdempotentRequests    *IdempotentRequestRepository
+	Kiosks                *KioskRepository
+	LoginAttempts         *LoginAttemptRepository
+	LotteryTiers          *LotteryTierRepository
+	Notifications         *NotificationRepository
+	PendingAdminActions   *PendingAdminActionRepository
+	PointExpiryPolicies   *PointExpiryPolicyRepository
+	ProcessedAccesses     *ProcessedAkerunAccessRepository
+	PricingRules          *PricingRuleRepository
+	EarningRules          *EarningRuleRepository
+	Products              *ProductRepository
+	ProductExchanges      *ProductExchangeRepository
+	QRCodes               *QRCodeRepository
+	ReasonCodes           *ReasonCodeRepository
+	RecurringTransfers    *RecurringTransferRepository
+	Referrals             *ReferralRepository
+	ScheduledJobs         *ScheduledJobRepository
+	Sessions              *SessionRepository
+	ShippingAddresses     *ShippingAddressRepository
+	SplitRequests         *SplitRequestRepository
+	SuspiciousActivity    *SuspiciousActivityRepository
+	Tenants               *TenantRepository
+	TransactionArchive    *TransactionArchiveRepository
+	TransferRequests      *TransferRequestRepository
+	TransferAttachments   *TransferAttachmentRepository
+	UserSettings          *UserSettingsRepository
+	ArchivedUsers         *ArchivedUserRepository
+	EmailVerifications    *EmailVerificationRepository
+	EmailTemplates        *EmailTemplateRepository
+	UsernameChangeHistory *UsernameChangeHistoryRepository
+	PasswordChangeHistory *PasswordChangeHistoryRepository
+	UserTiers             *UserTierRepository
+	WeeklyDigests         *WeeklyDigestRepository
+	WorkerLeases          *WorkerLeaseRepository
+	BalanceLedger         *BalanceLedgerRepository
+	Analytics             *AnalyticsRepository
+	AdminDashboard        *AdminDashboardRepository
+	BusinessMetrics       *BusinessMetricsRepository
+}
+
+// New は空のリポジトリ一式を作成
+func New() *Repositories {
+	users := NewUserRepository()
+	transactions := NewTransactionRepository(users)
+	batches := NewPointBatchRepository()
+	friendships := NewFriendshipRepository(users)
+	bonuses := NewDailyBonusRepository(users)
+	exchanges := NewProductExchangeRepository()
+	archive := NewTransactionArchiveRepository(transactions)
+	notifications := NewNotificationRepository(batches)
+	mutes := NewMuteListRepository()
+	transferRequests := NewTransferRequestRepository(users, mutes)
+	attachments := NewTransferAttachmentRepository(transferRequests, transactions)
+	transactions.attachments = attachments
+
+	return &Repositories{
+		TxManager: NewTransactionManager(),
+
+		Users:                 users,
+		Transactions:          transactions,
+		IdempotencyKeys:       NewIdempotencyKeyRepository(),
+		PointBatches:          batches,
+		BalanceHolds:          NewBalanceHoldRepository(transferRequests),
+		Friendships:           friendships,
+		FriendPins:            NewFriendPinRepository(),
+		MuteList:              mutes,
+		FriendDiscovery:       NewFriendDiscoveryRepository(users, friendships),
+		SystemSettings:        NewSystemSettingsRepository(),
+		Announcements:         NewAnnouncementRepository(),
+		AkerunRepolls:         NewAkerunRepollRepository(),
+		Budgets:               NewBudgetRepository(users),
+		Carts:                 NewCartRepository(),
+		Categories:            NewCategoryRepository(),
+		ContentViolations:     NewContentViolationRepository(),
+		DailyBonuses:          bonuses,
+		DataExports:           NewDataExportRepository(),
+		Departments:           NewDepartmentRepository(users, transactions),
+		Events:                NewEventRepository(users),
+		FailedAkerunAccesses:  NewFailedAkerunAccessRepository(),
+		IdempotentRequests:    NewIdempotentRequestRepository(),
+		Kiosks:                NewKioskRepository(),
+		LoginAttempts:         NewLoginAttemptRepository(),
+		LotteryTiers:          NewLotteryTierRepository(),
+		Notifications:         notifications,
+		PendingAdminActions:   NewPendingAdminActionRepository(),
+		PointExpiryPolicies:   NewPointExpiryPolicyRepository(),
+		ProcessedAccesses:     NewProcessedAkerunAccessRepository(),
+		PricingRules:          NewPricingRuleRepository(),
+		EarningRules:          NewEarningRuleRepository(transactions, friendships),
+		Products:              NewProductRepository(),
+		ProductExchanges:      exchanges,
+		QRCodes:               NewQRCodeRepository(),
+		ReasonCodes:           NewReasonCodeRepository(),
+		RecurringTransfers:    NewRecurringTransferRepository(),
+		Referrals:             NewReferralRepository(),
+		ScheduledJobs:         NewScheduledJobRepository(),
+		Sessions:              NewSessionRepository(),
+		ShippingAddresses:     NewShippingAddressRepository(),
+		SplitRequests:         NewSplitRequestRepository(),
+		SuspiciousActivity:    NewSuspiciousActivityRepository(users, transactions),
+		Tenants:               NewTenantRepository(),
+		TransactionArchive:    archive,
+		TransferRequests:      transferRequests,
+		TransferAttachments:   attachments,
+		UserSettings:          NewUserSettingsRepository(users),
+		ArchivedUsers:         NewArchivedUserRepository(users),
+		EmailVerifications:    NewEmailVerificationRepository(),
+		EmailTemplates:        NewEmailTemplateRepository(),
+		UsernameChangeHistory: NewUsernameChangeHistoryRepository(),
+		PasswordChangeHistory: NewPasswordChangeHistoryRepository(),
+		UserTiers:             NewUserTierRepository(users, transactions, bonuses),
+		WeeklyDigests:         NewWeeklyDigestRepository(users, transactions, notifications),
+		WorkerLeases:          NewWorkerLeaseRepository(),
+		BalanceLedger:         NewBalanceLedgerRepository(users, transactions, archive, batches, exchanges),
+		Analytics:             NewAnalyticsRepository(),
+		AdminDashboard:        NewAdminDashboardRepository(),
+		BusinessMetrics:       NewBusinessMetricsRepository(),
+	}
+}
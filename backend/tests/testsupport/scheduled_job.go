@@ -0,0 +1,94 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+var _ repository.ScheduledJobRepository = (*ScheduledJobRepository)(nil)
+
+// ScheduledJobRepository はScheduledJobRepositoryのインメモリ実装
+type ScheduledJobRepository struct {
+	Faults
+	mu   sync.Mutex
+	jobs *table[entities.ScheduledJobName, entities.ScheduledJob]
+}
+
+// NewScheduledJobRepository は空のScheduledJobRepositoryを作成
+func NewScheduledJobRepository() *ScheduledJobRepository {
+	return &ScheduledJobRepository{jobs: newTable[entities.ScheduledJobName, entities.ScheduledJob]()}
+}
+
+// SyncSchedule はジョブがなければ作成し、cron式が変わっていれば次回実行日時とともに更新する
+func (r *ScheduledJobRepository) SyncSchedule(ctx context.Context, name entities.ScheduledJobName, schedule string, nextRunAt *time.Time) error {
+	if err := r.hit("SyncSchedule"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.jobs.ref(name)
+	if !ok {
+		r.jobs.put(name, &entities.ScheduledJob{Name: name, Schedule: schedule, NextRunAt: nextRunAt})
+		return nil
+	}
+	if stored.Schedule != schedule {
+		stored.Schedule = schedule
+		stored.NextRunAt = nextRunAt
+	}
+	return nil
+}
+
+// TryLock は次回実行日時を過ぎていて、他のインスタンスが実行中でなければロックを取り、次回実行日時を進める
+func (r *ScheduledJobRepository) TryLock(ctx context.Context, name entities.ScheduledJobName, owner string, now, lockedUntil, nextRunAt time.Time) (bool, error) {
+	if err := r.hit("TryLock"); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.jobs.ref(name)
+	if !ok || stored.NextRunAt == nil || stored.NextRunAt.After(now) ||
+		(stored.LockedUntil != nil && stored.LockedUntil.After(now)) {
+		return false, nil
+	}
+	stored.LockedBy = owner
+	stored.LockedUntil = &lockedUntil
+	stored.NextRunAt = &nextRunAt
+	stored.LastStartedAt = &now
+	stored.LastStatus = entities.ScheduledJobStatusRunning
+	return true, nil
+}
+
+// Finish は実行結果を記録してロックを解放する（ownerがロックを持っている場合のみ）
+func (r *ScheduledJobRepository) Finish(ctx context.Context, run *entities.ScheduledJobRun) error {
+	if err := r.hit("Finish"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.jobs.ref(run.Name)
+	if !ok || stored.LockedBy != run.Owner {
+		return nil
+	}
+	finishedAt := run.FinishedAt
+	stored.LockedBy = ""
+	stored.LockedUntil = nil
+	stored.LastFinishedAt = &finishedAt
+	stored.LastStatus = run.Status
+	stored.LastError = run.Error
+	stored.LastProcessed = run.Processed
+	return nil
+}
+
+// ReadList は全ジョブの状況を名前順に取得
+func (r *ScheduledJobRepository) ReadList(ctx context.Context) ([]*entities.ScheduledJob, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return sortBy(r.jobs.find(nil), func(a, b *entities.ScheduledJob) bool { return a.Name < b.Name }), nil
+}
@@ -0,0 +1,220 @@
+package testsupport
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.SessionRepository = (*SessionRepository)(nil)
+
+// SessionRepository はSessionRepositoryのインメモリ実装
+type SessionRepository struct {
+	Faults
+	clock
+	mu         sync.Mutex
+	sessions   *table[uuid.UUID, entities.Session]
+	violations *table[uuid.UUID, entities.SessionBindingViolation]
+}
+
+// NewSessionRepository は空のSessionRepositoryを作成
+func NewSessionRepository() *SessionRepository {
+	return &SessionRepository{
+		sessions:   newTable[uuid.UUID, entities.Session](),
+		violations: newTable[uuid.UUID, entities.SessionBindingViolation](),
+	}
+}
+
+// Create は新しいセッションを作成（同じトークンがあればErrDuplicate）
+func (r *SessionRepository) Create(ctx context.Context, session *entities.Session) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.sessions.has(session.ID) || r.sessions.count(func(s *entities.Session) bool {
+		return s.SessionToken == session.SessionToken
+	}) > 0 {
+		return ErrDuplicate
+	}
+	r.sessions.put(session.ID, session)
+	return nil
+}
+
+// ReadByToken はトークンでセッションを検索
+func (r *SessionRepository) ReadByToken(ctx context.Context, token string) (*entities.Session, error) {
+	if err := r.hit("ReadByToken"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.sessions.first(func(s *entities.Session) bool { return s.SessionToken == token }); ok {
+		return s, nil
+	}
+	return nil, errors.New("session not found")
+}
+
+// Read はIDでセッションを検索
+func (r *SessionRepository) Read(ctx context.Context, id uuid.UUID) (*entities.Session, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.sessions.get(id); ok {
+		return s, nil
+	}
+	return nil, errors.New("session not found")
+}
+
+// ReadListByUserID はユーザーの有効なセッション一覧を最終アクティブの新しい順に取得
+func (r *SessionRepository) ReadListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Session, error) {
+	if err := r.hit("ReadListByUserID"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	list := r.sessions.find(func(s *entities.Session) bool { return s.UserID == userID && s.ExpiresAt.After(now) })
+	return sortBy(list, newestFirst(func(s *entities.Session) time.Time { return s.LastActiveAt })), nil
+}
+
+// Update はセッションの有効期限と最終アクティブ日時を更新
+func (r *SessionRepository) Update(ctx context.Context, session *entities.Session) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.sessions.ref(session.ID)
+	if !ok {
+		return errors.New("session not found")
+	}
+	stored.ExpiresAt = session.ExpiresAt
+	stored.LastActiveAt = session.LastActiveAt
+	return nil
+}
+
+// RotateCSRFToken は保存されたトークンがpreviousTokenのときだけCSRFトークンを更新
+func (r *SessionRepository) RotateCSRFToken(ctx context.Context, session *entities.Session, previousToken string) (bool, error) {
+	if err := r.hit("RotateCSRFToken"); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.sessions.ref(session.ID)
+	if !ok || stored.CSRFToken != previousToken {
+		return false, nil
+	}
+	stored.CSRFToken = session.CSRFToken
+	stored.PreviousCSRFToken = session.PreviousCSRFToken
+	stored.CSRFIssuedAt = session.CSRFIssuedAt
+	return true, nil
+}
+
+// UpdateFingerprint はセッションを固定する端末・接続元の指紋を更新
+func (r *SessionRepository) UpdateFingerprint(ctx context.Context, id uuid.UUID, fingerprint string) error {
+	if err := r.hit("UpdateFingerprint"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.sessions.ref(id)
+	if !ok {
+		return errors.New("session not found")
+	}
+	stored.Fingerprint = fingerprint
+	return nil
+}
+
+// Delete はセッションを削除
+func (r *SessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions.remove(id)
+	return nil
+}
+
+// DeleteByUserID はユーザーの全セッションを削除
+func (r *SessionRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	if err := r.hit("DeleteByUserID"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions.removeWhere(func(s *entities.Session) bool { return s.UserID == userID })
+	return nil
+}
+
+// DeleteByUserIDExcept は指定セッション以外のユーザーの全セッションを削除し、削除件数を返す
+func (r *SessionRepository) DeleteByUserIDExcept(ctx context.Context, userID, exceptID uuid.UUID) (int64, error) {
+	if err := r.hit("DeleteByUserIDExcept"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sessions.removeWhere(func(s *entities.Session) bool { return s.UserID == userID && s.ID != exceptID }), nil
+}
+
+// DeleteAllExcept は指定セッション以外の全ユーザーのセッションを削除し、削除件数を返す
+func (r *SessionRepository) DeleteAllExcept(ctx context.Context, exceptID uuid.UUID) (int64, error) {
+	if err := r.hit("DeleteAllExcept"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sessions.removeWhere(func(s *entities.Session) bool { return s.ID != exceptID }), nil
+}
+
+// DeleteExpired は期限切れセッションを削除
+func (r *SessionRepository) DeleteExpired(ctx context.Context) error {
+	if err := r.hit("DeleteExpired"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	r.sessions.removeWhere(func(s *entities.Session) bool { return s.ExpiresAt.Before(now) })
+	return nil
+}
+
+// CreateBindingViolation はセッションの固定の違反を記録
+func (r *SessionRepository) CreateBindingViolation(ctx context.Context, violation *entities.SessionBindingViolation) error {
+	if err := r.hit("CreateBindingViolation"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.violations.put(violation.ID, violation)
+	return nil
+}
+
+// ReadBindingViolationsByUserID はユーザーのセッションの固定の違反を新しい順に取得
+func (r *SessionRepository) ReadBindingViolationsByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.SessionBindingViolation, error) {
+	if err := r.hit("ReadBindingViolationsByUserID"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.violations.find(func(v *entities.SessionBindingViolation) bool { return v.UserID == userID })
+	list = sortBy(list, newestFirst(func(v *entities.SessionBindingViolation) time.Time { return v.CreatedAt }))
+	return page(list, offset, limit), nil
+}
+
+// CountBindingViolationsByUserID はユーザーのセッションの固定の違反の件数を取得
+func (r *SessionRepository) CountBindingViolationsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	if err := r.hit("CountBindingViolationsByUserID"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.violations.count(func(v *entities.SessionBindingViolation) bool { return v.UserID == userID }), nil
+}
@@ -0,0 +1,124 @@
+package testsupport
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.ShippingAddressRepository = (*ShippingAddressRepository)(nil)
+
+// ShippingAddressRepository はShippingAddressRepositoryのインメモリ実装
+type ShippingAddressRepository struct {
+	Faults
+	mu        sync.Mutex
+	addresses *table[uuid.UUID, entities.ShippingAddress]
+}
+
+// NewShippingAddressRepository は空のShippingAddressRepositoryを作成
+func NewShippingAddressRepository() *ShippingAddressRepository {
+	return &ShippingAddressRepository{addresses: newTable[uuid.UUID, entities.ShippingAddress]()}
+}
+
+// Create はお届け先を保存
+func (r *ShippingAddressRepository) Create(ctx context.Context, address *entities.ShippingAddress) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.addresses.has(address.ID) {
+		return ErrDuplicate
+	}
+	r.addresses.put(address.ID, address)
+	return nil
+}
+
+// Read はIDでお届け先を取得
+func (r *ShippingAddressRepository) Read(ctx context.Context, id uuid.UUID) (*entities.ShippingAddress, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if address, ok := r.addresses.get(id); ok {
+		return address, nil
+	}
+	return nil, entities.ErrShippingAddressNotFound
+}
+
+// ReadListByUserID はユーザーのお届け先を既定のもの、登録順の順に取得
+func (r *ShippingAddressRepository) ReadListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.ShippingAddress, error) {
+	if err := r.hit("ReadListByUserID"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.addresses.find(func(a *entities.ShippingAddress) bool { return a.UserID == userID })
+	sort.SliceStable(list, func(i, j int) bool { return list[i].IsDefault && !list[j].IsDefault })
+	return list, nil
+}
+
+// ReadDefault はユーザーの既定のお届け先を取得
+func (r *ShippingAddressRepository) ReadDefault(ctx context.Context, userID uuid.UUID) (*entities.ShippingAddress, error) {
+	if err := r.hit("ReadDefault"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if address, ok := r.addresses.first(func(a *entities.ShippingAddress) bool { return a.UserID == userID && a.IsDefault }); ok {
+		return address, nil
+	}
+	return nil, entities.ErrShippingAddressNotFound
+}
+
+// Update はお届け先を更新（既定かどうかは変えない）
+func (r *ShippingAddressRepository) Update(ctx context.Context, address *entities.ShippingAddress) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.addresses.get(address.ID)
+	if !ok {
+		return entities.ErrShippingAddressNotFound
+	}
+	updated := *address
+	updated.IsDefault = stored.IsDefault
+	r.addresses.put(address.ID, &updated)
+	return nil
+}
+
+// SetDefault はユーザーの既定のお届け先をidだけにする
+func (r *ShippingAddressRepository) SetDefault(ctx context.Context, userID, id uuid.UUID) error {
+	if err := r.hit("SetDefault"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	target, ok := r.addresses.ref(id)
+	if !ok || target.UserID != userID {
+		return entities.ErrShippingAddressNotFound
+	}
+	for _, a := range r.addresses.refs(func(a *entities.ShippingAddress) bool { return a.UserID == userID }) {
+		a.IsDefault = a.ID == id
+	}
+	return nil
+}
+
+// Delete はお届け先を削除
+func (r *ShippingAddressRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.addresses.remove(id) {
+		return entities.ErrShippingAddressNotFound
+	}
+	return nil
+}
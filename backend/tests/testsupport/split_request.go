@@ -0,0 +1,94 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.SplitRequestRepository = (*SplitRequestRepository)(nil)
+
+// SplitRequestRepository はSplitRequestRepositoryのインメモリ実装
+type SplitRequestRepository struct {
+	Faults
+	mu     sync.Mutex
+	splits *table[uuid.UUID, entities.SplitRequest]
+}
+
+// NewSplitRequestRepository は空のSplitRequestRepositoryを作成
+func NewSplitRequestRepository() *SplitRequestRepository {
+	return &SplitRequestRepository{splits: newTable[uuid.UUID, entities.SplitRequest]()}
+}
+
+// Create は割り勘を作成（同じ冪等性キーがあればErrDuplicate）
+func (r *SplitRequestRepository) Create(ctx context.Context, split *entities.SplitRequest) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.splits.has(split.ID) || r.splits.count(func(s *entities.SplitRequest) bool {
+		return s.IdempotencyKey == split.IdempotencyKey
+	}) > 0 {
+		return ErrDuplicate
+	}
+	r.splits.put(split.ID, split)
+	return nil
+}
+
+// Read はIDで割り勘を取得
+func (r *SplitRequestRepository) Read(ctx context.Context, id uuid.UUID) (*entities.SplitRequest, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.splits.get(id); ok {
+		return s, nil
+	}
+	return nil, entities.ErrSplitRequestNotFound
+}
+
+// ReadByIdempotencyKey は冪等性キーで割り勘を取得（存在しない場合はnil）
+func (r *SplitRequestRepository) ReadByIdempotencyKey(ctx context.Context, key string) (*entities.SplitRequest, error) {
+	if err := r.hit("ReadByIdempotencyKey"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.splits.first(func(s *entities.SplitRequest) bool { return s.IdempotencyKey == key }); ok {
+		return s, nil
+	}
+	return nil, nil
+}
+
+// ReadListByRequester は作成者の割り勘を新しい順に取得
+func (r *SplitRequestRepository) ReadListByRequester(ctx context.Context, requesterID uuid.UUID, offset, limit int) ([]*entities.SplitRequest, error) {
+	if err := r.hit("ReadListByRequester"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.splits.find(func(s *entities.SplitRequest) bool { return s.RequesterID == requesterID })
+	return page(sortBy(list, newestFirst(func(s *entities.SplitRequest) time.Time { return s.CreatedAt })), offset, limit), nil
+}
+
+// Update は割り勘のキャンセル日時を更新
+func (r *SplitRequestRepository) Update(ctx context.Context, split *entities.SplitRequest) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.splits.ref(split.ID)
+	if !ok {
+		return entities.ErrSplitRequestNotFound
+	}
+	s.CancelledAt = split.CancelledAt
+	s.UpdatedAt = split.UpdatedAt
+	return nil
+}
@@ -0,0 +1,147 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.SuspiciousActivityRepository = (*SuspiciousActivityRepository)(nil)
+
+// SuspiciousActivityRepository はSuspiciousActivityRepositoryのインメモリ実装
+// 送金の集計は transactions と users から、通知先は users から求める
+type SuspiciousActivityRepository struct {
+	Faults
+	mu           sync.Mutex
+	users        *UserRepository
+	transactions *TransactionRepository
+	activities   *table[uuid.UUID, entities.SuspiciousActivity]
+}
+
+// NewSuspiciousActivityRepository は空のSuspiciousActivityRepositoryを作成
+func NewSuspiciousActivityRepository(users *UserRepository, transactions *TransactionRepository) *SuspiciousActivityRepository {
+	return &SuspiciousActivityRepository{
+		users:        users,
+		transactions: transactions,
+		activities:   newTable[uuid.UUID, entities.SuspiciousActivity](),
+	}
+}
+
+// Create は不審な送金の記録を作成
+func (r *SuspiciousActivityRepository) Create(ctx context.Context, activity *entities.SuspiciousActivity) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.activities.has(activity.ID) {
+		return ErrDuplicate
+	}
+	r.activities.put(activity.ID, activity)
+	return nil
+}
+
+// Read はIDで記録を取得（なければErrSuspiciousActivityNotFound）
+func (r *SuspiciousActivityRepository) Read(ctx context.Context, id uuid.UUID) (*entities.SuspiciousActivity, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if a, ok := r.activities.get(id); ok {
+		return a, nil
+	}
+	return nil, entities.ErrSuspiciousActivityNotFound
+}
+
+// ReadList は記録を新しい順に取得（statusが空なら全状態）
+func (r *SuspiciousActivityRepository) ReadList(ctx context.Context, status entities.SuspiciousActivityStatus, offset, limit int) ([]*entities.SuspiciousActivity, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.activities.find(suspiciousStatus(status))
+	return page(sortBy(list, newestFirst(func(a *entities.SuspiciousActivity) time.Time { return a.CreatedAt })), offset, limit), nil
+}
+
+// Count は記録の件数を取得（statusが空なら全状態）
+func (r *SuspiciousActivityRepository) Count(ctx context.Context, status entities.SuspiciousActivityStatus) (int64, error) {
+	if err := r.hit("Count"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.activities.count(suspiciousStatus(status)), nil
+}
+
+// UpdateStatus は状態がfromのときだけ状態と確認・実行の結果を更新する
+func (r *SuspiciousActivityRepository) UpdateStatus(ctx context.Context, activity *entities.SuspiciousActivity, from entities.SuspiciousActivityStatus) (bool, error) {
+	if err := r.hit("UpdateStatus"); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.activities.ref(activity.ID)
+	if !ok || stored.Status != from {
+		return false, nil
+	}
+	stored.Status = activity.Status
+	stored.TransactionID = activity.TransactionID
+	stored.ReviewedBy = activity.ReviewedBy
+	stored.ReviewComment = activity.ReviewComment
+	stored.ReviewedAt = activity.ReviewedAt
+	stored.FailureReason = activity.FailureReason
+	stored.UpdatedAt = activity.UpdatedAt
+	return true, nil
+}
+
+// ReadTransferVelocity は送信者の直近の完了済み取引を検出条件に沿って集計
+func (r *SuspiciousActivityRepository) ReadTransferVelocity(ctx context.Context, query *entities.TransferVelocityQuery) (*entities.TransferVelocity, error) {
+	if err := r.hit("ReadTransferVelocity"); err != nil {
+		return nil, err
+	}
+	transfer := func(t *entities.Transaction, from, to uuid.UUID, since time.Time) bool {
+		return t.TransactionType == entities.TransactionTypeTransfer &&
+			t.Status == entities.TransactionStatusCompleted && !t.CreatedAt.Before(since) &&
+			t.FromUserID != nil && *t.FromUserID == from && t.ToUserID != nil && *t.ToUserID == to
+	}
+
+	v := &entities.TransferVelocity{}
+	for _, t := range r.transactions.all(nil) {
+		if t.TransactionType == entities.TransactionTypeTransfer && t.Status == entities.TransactionStatusCompleted &&
+			t.FromUserID != nil && *t.FromUserID == query.FromUserID && t.ToUserID != nil &&
+			!t.CreatedAt.Before(query.NewAccountWindow) {
+			if u := r.users.lookup(*t.ToUserID); u != nil && !u.CreatedAt.Before(query.NewAccountSince) {
+				v.NewAccountTransfers++
+			}
+		}
+		if transfer(t, query.FromUserID, query.ToUserID, query.WashWindow) || transfer(t, query.ToUserID, query.FromUserID, query.WashWindow) {
+			v.PairTransfers++
+		}
+		if transfer(t, query.ToUserID, query.FromUserID, query.WashWindow) {
+			v.ReverseTransfers++
+		}
+		if completedSince(query.GrantWindow, entities.SuspiciousGrantTransactionTypes...)(t) &&
+			t.ToUserID != nil && *t.ToUserID == query.FromUserID {
+			v.RecentGrantAmount += t.Amount
+		}
+	}
+	return v, nil
+}
+
+// ReadAlertRecipients は検出を通知する有効な管理者のIDを取得
+func (r *SuspiciousActivityRepository) ReadAlertRecipients(ctx context.Context) ([]uuid.UUID, error) {
+	if err := r.hit("ReadAlertRecipients"); err != nil {
+		return nil, err
+	}
+	return activeAdminIDs(r.users), nil
+}
+
+func suspiciousStatus(status entities.SuspiciousActivityStatus) func(a *entities.SuspiciousActivity) bool {
+	return func(a *entities.SuspiciousActivity) bool { return status == "" || a.Status == status }
+}
@@ -0,0 +1,45 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gity/point-system/usecases/repository"
+)
+
+var _ repository.SystemSettingsRepository = (*SystemSettingsRepository)(nil)
+
+// SystemSettingsRepository はSystemSettingsRepositoryのインメモリ実装
+type SystemSettingsRepository struct {
+	Faults
+	mu           sync.Mutex
+	values       map[string]string
+	descriptions map[string]string
+}
+
+// NewSystemSettingsRepository は空のSystemSettingsRepositoryを作成
+func NewSystemSettingsRepository() *SystemSettingsRepository {
+	return &SystemSettingsRepository{values: make(map[string]string), descriptions: make(map[string]string)}
+}
+
+// GetSetting は設定値を取得（未設定なら空文字）
+func (r *SystemSettingsRepository) GetSetting(ctx context.Context, key string) (string, error) {
+	if err := r.hit("GetSetting"); err != nil {
+		return "", err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.values[key], nil
+}
+
+// SetSetting は設定値を保存
+func (r *SystemSettingsRepository) SetSetting(ctx context.Context, key, value, description string) error {
+	if err := r.hit("SetSetting"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values[key] = value
+	r.descriptions[key] = description
+	return nil
+}
@@ -0,0 +1,170 @@
+package testsupport
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// ErrDuplicate は一意制約に違反する行を保存しようとした場合のエラー
+var ErrDuplicate = errors.New("testsupport: duplicate key")
+
+// table は行を挿入順に保持するインメモリのテーブル
+// 保存・取得のたびに浅いコピーを作り、呼び出し側の変更が保存済みの行に漏れないようにする
+type table[K comparable, V any] struct {
+	keys []K
+	rows map[K]*V
+}
+
+func newTable[K comparable, V any]() *table[K, V] {
+	return &table[K, V]{rows: make(map[K]*V)}
+}
+
+// put は行を保存する（同じキーがあれば挿入順を保ったまま置き換える）
+func (t *table[K, V]) put(key K, row *V) {
+	copied := *row
+	if _, ok := t.rows[key]; !ok {
+		t.keys = append(t.keys, key)
+	}
+	t.rows[key] = &copied
+}
+
+// get は行のコピーを返す
+func (t *table[K, V]) get(key K) (*V, bool) {
+	row, ok := t.rows[key]
+	if !ok {
+		return nil, false
+	}
+	copied := *row
+	return &copied, true
+}
+
+// ref は保存済みの行そのものを返す（その場で更新する場合に使う）
+func (t *table[K, V]) ref(key K) (*V, bool) {
+	row, ok := t.rows[key]
+	return row, ok
+}
+
+// has は行が存在するかを返す
+func (t *table[K, V]) has(key K) bool {
+	_, ok := t.rows[key]
+	return ok
+}
+
+// remove は行を削除し、削除したかを返す
+func (t *table[K, V]) remove(key K) bool {
+	if _, ok := t.rows[key]; !ok {
+		return false
+	}
+	delete(t.rows, key)
+	for i, k := range t.keys {
+		if k == key {
+			t.keys = append(t.keys[:i], t.keys[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// removeWhere は条件に合う行を削除し、削除した件数を返す
+func (t *table[K, V]) removeWhere(match func(*V) bool) int64 {
+	var n int64
+	kept := t.keys[:0]
+	for _, k := range t.keys {
+		if match(t.rows[k]) {
+			delete(t.rows, k)
+			n++
+			continue
+		}
+		kept = append(kept, k)
+	}
+	t.keys = kept
+	return n
+}
+
+// refs は条件に合う保存済みの行を挿入順に返す（matchがnilならすべて）
+func (t *table[K, V]) refs(match func(*V) bool) []*V {
+	var list []*V
+	for _, k := range t.keys {
+		row := t.rows[k]
+		if match == nil || match(row) {
+			list = append(list, row)
+		}
+	}
+	return list
+}
+
+// find は条件に合う行のコピーを挿入順に返す
+func (t *table[K, V]) find(match func(*V) bool) []*V {
+	return copies(t.refs(match))
+}
+
+// first は条件に合う最初の行のコピーを返す
+func (t *table[K, V]) first(match func(*V) bool) (*V, bool) {
+	for _, k := range t.keys {
+		if row := t.rows[k]; match(row) {
+			copied := *row
+			return &copied, true
+		}
+	}
+	return nil, false
+}
+
+// count は条件に合う行の数を返す
+func (t *table[K, V]) count(match func(*V) bool) int64 {
+	return int64(len(t.refs(match)))
+}
+
+// copies は行のスライスを浅いコピーのスライスにする
+func copies[V any](list []*V) []*V {
+	out := make([]*V, len(list))
+	for i, row := range list {
+		copied := *row
+		out[i] = &copied
+	}
+	return out
+}
+
+// page はoffset・limitで切り出す（limitが0以下なら上限なし、SQLのLIMITを省略した場合と同じ）
+func page[V any](list []*V, offset, limit int) []*V {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(list) {
+		return []*V{}
+	}
+	list = list[offset:]
+	if limit > 0 && limit < len(list) {
+		list = list[:limit]
+	}
+	return list
+}
+
+// sortBy はlessの順に並べる（同じ値は挿入順のまま）
+func sortBy[V any](list []*V, less func(a, b *V) bool) []*V {
+	sort.SliceStable(list, func(i, j int) bool { return less(list[i], list[j]) })
+	return list
+}
+
+// newestFirst は時刻の新しい順の比較関数を作る
+func newestFirst[V any](at func(*V) time.Time) func(a, b *V) bool {
+	return func(a, b *V) bool { return at(a).After(at(b)) }
+}
+
+// oldestFirst は時刻の古い順の比較関数を作る
+func oldestFirst[V any](at func(*V) time.Time) func(a, b *V) bool {
+	return func(a, b *V) bool { return at(a).Before(at(b)) }
+}
+
+// clock はテストから現在時刻を差し替えられるようにする
+// Now が nil なら time.Now を使う
+type clock struct {
+	Now func() time.Time
+}
+
+func (c *clock) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
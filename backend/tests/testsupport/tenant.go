@@ -0,0 +1,115 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.TenantRepository = (*TenantRepository)(nil)
+
+// TenantRepository はTenantRepositoryのインメモリ実装
+// マイグレーションと同じく既定のテナントを最初から持つ
+type TenantRepository struct {
+	Faults
+	mu      sync.Mutex
+	tenants *table[uuid.UUID, entities.Tenant]
+}
+
+// NewTenantRepository は既定のテナントだけを持つTenantRepositoryを作成
+func NewTenantRepository() *TenantRepository {
+	r := &TenantRepository{tenants: newTable[uuid.UUID, entities.Tenant]()}
+	now := time.Now()
+	r.tenants.put(entities.DefaultTenantID, &entities.Tenant{
+		ID:        entities.DefaultTenantID,
+		Slug:      entities.DefaultTenantSlug,
+		Name:      "既定のテナント",
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	return r
+}
+
+// Create はテナントを作成
+func (r *TenantRepository) Create(ctx context.Context, tenant *entities.Tenant) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.tenants.has(tenant.ID) {
+		return ErrDuplicate
+	}
+	if _, ok := r.tenants.first(func(t *entities.Tenant) bool { return t.Slug == tenant.Slug }); ok {
+		return entities.ErrTenantSlugTaken
+	}
+	r.tenants.put(tenant.ID, tenant)
+	return nil
+}
+
+// Read はIDでテナントを取得
+func (r *TenantRepository) Read(ctx context.Context, id uuid.UUID) (*entities.Tenant, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if tenant, ok := r.tenants.get(id); ok {
+		return tenant, nil
+	}
+	return nil, entities.ErrTenantNotFound
+}
+
+// ReadBySlug はスラッグでテナントを取得
+func (r *TenantRepository) ReadBySlug(ctx context.Context, slug string) (*entities.Tenant, error) {
+	if err := r.hit("ReadBySlug"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if tenant, ok := r.tenants.first(func(t *entities.Tenant) bool { return t.Slug == slug }); ok {
+		return tenant, nil
+	}
+	return nil, entities.ErrTenantNotFound
+}
+
+// ReadList はテナントを作成日時の古い順に取得
+func (r *TenantRepository) ReadList(ctx context.Context, offset, limit int) ([]*entities.Tenant, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := sortBy(r.tenants.find(nil), oldestFirst(func(t *entities.Tenant) time.Time { return t.CreatedAt }))
+	return page(list, offset, limit), nil
+}
+
+// Count はテナントの件数を取得
+func (r *TenantRepository) Count(ctx context.Context) (int64, error) {
+	if err := r.hit("Count"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.tenants.count(nil), nil
+}
+
+// Update はテナント名と有効・無効を更新
+func (r *TenantRepository) Update(ctx context.Context, tenant *entities.Tenant) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.tenants.ref(tenant.ID)
+	if !ok {
+		return entities.ErrTenantNotFound
+	}
+	stored.Name, stored.IsActive, stored.UpdatedAt = tenant.Name, tenant.IsActive, tenant.UpdatedAt
+	return nil
+}
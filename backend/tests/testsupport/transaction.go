@@ -0,0 +1,451 @@
+package testsupport
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	_ repository.TransactionRepository    = (*TransactionRepository)(nil)
+	_ repository.IdempotencyKeyRepository = (*IdempotencyKeyRepository)(nil)
+	_ repository.TransactionManager       = (*TransactionManager)(nil)
+)
+
+// TransactionManager はTransactionManagerのインメモリ実装
+// fnをそのまま実行する（インメモリのリポジトリはロールバックしない）
+type TransactionManager struct {
+	Faults
+}
+
+// NewTransactionManager は新しいTransactionManagerを作成
+func NewTransactionManager() *TransactionManager {
+	return &TransactionManager{}
+}
+
+// Do はfnを実行する
+func (m *TransactionManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := m.hit("Do"); err != nil {
+		return err
+	}
+	return fn(ctx)
+}
+
+// TransactionRepository はTransactionRepositoryのインメモリ実装
+// ユーザー情報付きの取得と部署での絞り込みには users を使う（nilならユーザー情報なし）
+// 履歴の添付画像の有無には attachments を使う（送金リクエストより先に作るため、New で後から設定する）
+type TransactionRepository struct {
+	Faults
+	mu           sync.Mutex
+	users        *UserRepository
+	attachments  *TransferAttachmentRepository
+	transactions *table[uuid.UUID, entities.Transaction]
+}
+
+// NewTransactionRepository は空のTransactionRepositoryを作成
+func NewTransactionRepository(users *UserRepository) *TransactionRepository {
+	return &TransactionRepository{users: users, transactions: newTable[uuid.UUID, entities.Transaction]()}
+}
+
+// Create は新しいトランザクションを作成（冪等性キーが重複すればErrDuplicate）
+func (r *TransactionRepository) Create(ctx context.Context, transaction *entities.Transaction) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.transactions.has(transaction.ID) {
+		return ErrDuplicate
+	}
+	if key := transaction.IdempotencyKey; key != nil && r.transactions.count(func(t *entities.Transaction) bool {
+		return t.IdempotencyKey != nil && *t.IdempotencyKey == *key
+	}) > 0 {
+		return ErrDuplicate
+	}
+	r.transactions.put(transaction.ID, transaction)
+	return nil
+}
+
+// Read はIDでトランザクションを検索
+func (r *TransactionRepository) Read(ctx context.Context, id uuid.UUID) (*entities.Transaction, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := r.transactions.get(id); ok {
+		return t, nil
+	}
+	return nil, entities.ErrTransactionNotFound
+}
+
+// ReadByIdempotencyKey は冪等性キーでトランザクションを検索
+func (r *TransactionRepository) ReadByIdempotencyKey(ctx context.Context, key string) (*entities.Transaction, error) {
+	if err := r.hit("ReadByIdempotencyKey"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := r.transactions.first(func(t *entities.Transaction) bool {
+		return t.IdempotencyKey != nil && *t.IdempotencyKey == key
+	}); ok {
+		return t, nil
+	}
+	return nil, errors.New("transaction not found")
+}
+
+// ReadListByUserID はユーザーが送信者または受信者のトランザクションを新しい順に取得
+func (r *TransactionRepository) ReadListByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.Transaction, error) {
+	if err := r.hit("ReadListByUserID"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return page(r.sorted(involves(userID, ""), "", ""), offset, limit), nil
+}
+
+// ReadListAll は全トランザクションを新しい順に取得
+func (r *TransactionRepository) ReadListAll(ctx context.Context, offset, limit int) ([]*entities.Transaction, error) {
+	if err := r.hit("ReadListAll"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return page(r.sorted(nil, "", ""), offset, limit), nil
+}
+
+// ReadListAllWithFilter はフィルタ・ソート付きで全トランザクションを取得
+func (r *TransactionRepository) ReadListAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode, sortBy, sortOrder string, offset, limit int) ([]*entities.Transaction, error) {
+	if err := r.hit("ReadListAllWithFilter"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return page(r.sorted(r.filter(transactionType, dateFrom, dateTo, reasonCode, nil), sortBy, sortOrder), offset, limit), nil
+}
+
+// CountAll は全トランザクション数を取得
+func (r *TransactionRepository) CountAll(ctx context.Context) (int64, error) {
+	if err := r.hit("CountAll"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.transactions.count(nil), nil
+}
+
+// CountAllWithFilter はフィルタ付きで全トランザクション数を取得
+func (r *TransactionRepository) CountAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode string, departmentIDs []uuid.UUID) (int64, error) {
+	if err := r.hit("CountAllWithFilter"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.transactions.count(r.filter(transactionType, dateFrom, dateTo, reasonCode, departmentIDs)), nil
+}
+
+// Update はトランザクションの状態と完了日時を更新
+func (r *TransactionRepository) Update(ctx context.Context, transaction *entities.Transaction) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.transactions.ref(transaction.ID); ok {
+		stored.Status = transaction.Status
+		stored.CompletedAt = transaction.CompletedAt
+	}
+	return nil
+}
+
+// CountByUserID はユーザーのトランザクション数を取得（reasonCodeが空でなければその理由コードのみ）
+func (r *TransactionRepository) CountByUserID(ctx context.Context, userID uuid.UUID, reasonCode string) (int64, error) {
+	if err := r.hit("CountByUserID"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.transactions.count(involves(userID, reasonCode)), nil
+}
+
+// ReadListByUserIDWithUsers はユーザーのトランザクションをユーザー情報付きで新しい順に取得
+func (r *TransactionRepository) ReadListByUserIDWithUsers(ctx context.Context, userID uuid.UUID, reasonCode string, offset, limit int) ([]*entities.TransactionWithUsers, error) {
+	if err := r.hit("ReadListByUserIDWithUsers"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	list := page(r.sorted(involves(userID, reasonCode), "", ""), offset, limit)
+	r.mu.Unlock()
+	return r.withUsers(list), nil
+}
+
+// ReadListAllWithFilterAndUsers はフィルタ・ソート付きで全トランザクションをユーザー情報付きで取得
+func (r *TransactionRepository) ReadListAllWithFilterAndUsers(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode string, departmentIDs []uuid.UUID, sortBy, sortOrder string, offset, limit int) ([]*entities.TransactionWithUsers, error) {
+	if err := r.hit("ReadListAllWithFilterAndUsers"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	list := page(r.sorted(r.filter(transactionType, dateFrom, dateTo, reasonCode, departmentIDs), sortBy, sortOrder), offset, limit)
+	r.mu.Unlock()
+	return r.withUsers(list), nil
+}
+
+// AnonymizeUserReferences は退会するユーザーが関わった取引に退会済みの印を付ける
+func (r *TransactionRepository) AnonymizeUserReferences(ctx context.Context, userID uuid.UUID, scrubMemos bool) error {
+	if err := r.hit("AnonymizeUserReferences"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, t := range r.transactions.refs(nil) {
+		if t.FromUserID != nil && *t.FromUserID == userID {
+			t.Metadata = withMetadata(t.Metadata, entities.MetadataFromUserDeleted)
+			if scrubMemos && t.TransactionType == entities.TransactionTypeTransfer {
+				t.Description = ""
+			}
+		}
+		if t.ToUserID != nil && *t.ToUserID == userID {
+			t.Metadata = withMetadata(t.Metadata, entities.MetadataToUserDeleted)
+		}
+	}
+	return nil
+}
+
+// SumExchangedWith はユーザーと各相手の間でやり取りした完了済みの取引を相手ごとに合計する
+func (r *TransactionRepository) SumExchangedWith(ctx context.Context, userID uuid.UUID, counterpartIDs []uuid.UUID) ([]*entities.PointsExchanged, error) {
+	if err := r.hit("SumExchangedWith"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sums := make(map[uuid.UUID]*entities.PointsExchanged, len(counterpartIDs))
+	for _, id := range counterpartIDs {
+		sums[id] = &entities.PointsExchanged{CounterpartID: id}
+	}
+	for _, t := range r.transactions.find(func(t *entities.Transaction) bool {
+		return t.Status == entities.TransactionStatusCompleted && t.FromUserID != nil && t.ToUserID != nil
+	}) {
+		if sum := sums[*t.ToUserID]; *t.FromUserID == userID && sum != nil {
+			sum.Sent += t.Amount
+		} else if sum := sums[*t.FromUserID]; *t.ToUserID == userID && sum != nil {
+			sum.Received += t.Amount
+		}
+	}
+
+	results := []*entities.PointsExchanged{}
+	for _, id := range counterpartIDs {
+		if sum := sums[id]; sum.Total() > 0 {
+			results = append(results, sum)
+		}
+	}
+	return results, nil
+}
+
+// ReadTransferAmountUsage はsince以降にユーザーが各送金先へ送った完了済みの送金を、送金先・金額ごとに集計
+func (r *TransactionRepository) ReadTransferAmountUsage(ctx context.Context, fromUserID uuid.UUID, toUserIDs []uuid.UUID, since time.Time) ([]*entities.TransferAmountUsage, error) {
+	if err := r.hit("ReadTransferAmountUsage"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	type key struct {
+		to     uuid.UUID
+		amount int64
+	}
+	usages := make(map[key]*entities.TransferAmountUsage)
+	results := []*entities.TransferAmountUsage{}
+	for _, t := range r.transactions.find(func(t *entities.Transaction) bool {
+		return t.TransactionType == entities.TransactionTypeTransfer && t.Status == entities.TransactionStatusCompleted &&
+			t.FromUserID != nil && *t.FromUserID == fromUserID && t.ToUserID != nil && slices.Contains(toUserIDs, *t.ToUserID) &&
+			!t.CreatedAt.Before(since)
+	}) {
+		k := key{*t.ToUserID, t.Amount}
+		u, ok := usages[k]
+		if !ok {
+			u = &entities.TransferAmountUsage{RecipientID: k.to, Amount: k.amount}
+			usages[k] = u
+			results = append(results, u)
+		}
+		u.Count++
+		if t.CreatedAt.After(u.LastSentAt) {
+			u.LastSentAt = t.CreatedAt
+		}
+	}
+	return results, nil
+}
+
+// all は他のリポジトリが取引を集計するために使う
+func (r *TransactionRepository) all(match func(t *entities.Transaction) bool) []*entities.Transaction {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.transactions.find(match)
+}
+
+// sorted は条件に合う取引を並べる（sortByはcreated_at・amount、sortOrderがasc以外なら降順）
+func (r *TransactionRepository) sorted(match func(t *entities.Transaction) bool, column, order string) []*entities.Transaction {
+	less := func(a, b *entities.Transaction) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	if column == "amount" {
+		less = func(a, b *entities.Transaction) bool { return a.Amount < b.Amount }
+	}
+	if order != "asc" {
+		asc := less
+		less = func(a, b *entities.Transaction) bool { return asc(b, a) }
+	}
+	return sortBy(r.transactions.find(match), less)
+}
+
+// filter は管理画面の絞り込み条件（日付はYYYY-MM-DD、dateToはその日の終わりまで）
+func (r *TransactionRepository) filter(transactionType, dateFrom, dateTo, reasonCode string, departmentIDs []uuid.UUID) func(t *entities.Transaction) bool {
+	from, fromErr := time.ParseInLocation("2006-01-02", dateFrom, time.Local)
+	to, toErr := time.ParseInLocation("2006-01-02", dateTo, time.Local)
+	var members map[uuid.UUID]bool
+	if len(departmentIDs) > 0 {
+		members = make(map[uuid.UUID]bool)
+		for _, u := range r.users.all(func(u *entities.User) bool {
+			return u.DepartmentID != nil && containsID(departmentIDs, *u.DepartmentID)
+		}) {
+			members[u.ID] = true
+		}
+	}
+	return func(t *entities.Transaction) bool {
+		if transactionType != "" && string(t.TransactionType) != transactionType {
+			return false
+		}
+		if reasonCode != "" && t.ReasonCode != reasonCode {
+			return false
+		}
+		if members != nil && !(t.FromUserID != nil && members[*t.FromUserID]) && !(t.ToUserID != nil && members[*t.ToUserID]) {
+			return false
+		}
+		if fromErr == nil && t.CreatedAt.Before(from) {
+			return false
+		}
+		if toErr == nil && !t.CreatedAt.Before(to.AddDate(0, 0, 1)) {
+			return false
+		}
+		return true
+	}
+}
+
+func (r *TransactionRepository) withUsers(list []*entities.Transaction) []*entities.TransactionWithUsers {
+	results := make([]*entities.TransactionWithUsers, len(list))
+	for i, t := range list {
+		result := &entities.TransactionWithUsers{Transaction: t, HasAttachment: r.attachments.forTransaction(t.ID) != nil}
+		if t.FromUserID != nil {
+			result.FromUser = r.users.lookup(*t.FromUserID)
+		}
+		if t.ToUserID != nil {
+			result.ToUser = r.users.lookup(*t.ToUserID)
+		}
+		results[i] = result
+	}
+	return results
+}
+
+// lookup は他のリポジトリが取引を参照するために使う（存在しなければnil）
+func (r *TransactionRepository) lookup(id uuid.UUID) *entities.Transaction {
+	if r == nil || id == uuid.Nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, _ := r.transactions.get(id)
+	return t
+}
+
+// involves はユーザーが送信者または受信者の取引の条件（reasonCodeが空でなければ理由コードも）
+func involves(userID uuid.UUID, reasonCode string) func(t *entities.Transaction) bool {
+	return func(t *entities.Transaction) bool {
+		if reasonCode != "" && t.ReasonCode != reasonCode {
+			return false
+		}
+		return (t.FromUserID != nil && *t.FromUserID == userID) || (t.ToUserID != nil && *t.ToUserID == userID)
+	}
+}
+
+// withMetadata はメタデータのコピーにキーを追加する（保存済みの取引とマップを共有しない）
+func withMetadata(metadata map[string]interface{}, key string) map[string]interface{} {
+	copied := make(map[string]interface{}, len(metadata)+1)
+	for k, v := range metadata {
+		copied[k] = v
+	}
+	copied[key] = true
+	return copied
+}
+
+// IdempotencyKeyRepository はIdempotencyKeyRepositoryのインメモリ実装
+type IdempotencyKeyRepository struct {
+	Faults
+	clock
+	mu   sync.Mutex
+	keys *table[string, entities.IdempotencyKey]
+}
+
+// NewIdempotencyKeyRepository は空のIdempotencyKeyRepositoryを作成
+func NewIdempotencyKeyRepository() *IdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{keys: newTable[string, entities.IdempotencyKey]()}
+}
+
+// Create は新しい冪等性キーを作成（同じキーがあればErrDuplicate）
+func (r *IdempotencyKeyRepository) Create(ctx context.Context, key *entities.IdempotencyKey) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.keys.has(key.Key) {
+		return ErrDuplicate
+	}
+	r.keys.put(key.Key, key)
+	return nil
+}
+
+// ReadByKey はキーで冪等性キーを検索
+func (r *IdempotencyKeyRepository) ReadByKey(ctx context.Context, key string) (*entities.IdempotencyKey, error) {
+	if err := r.hit("ReadByKey"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if k, ok := r.keys.get(key); ok {
+		return k, nil
+	}
+	return nil, errors.New("idempotency key not found")
+}
+
+// Update は冪等性キーの取引IDと状態を更新
+func (r *IdempotencyKeyRepository) Update(ctx context.Context, key *entities.IdempotencyKey) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.keys.ref(key.Key); ok {
+		stored.TransactionID = key.TransactionID
+		stored.Status = key.Status
+	}
+	return nil
+}
+
+// DeleteExpired は期限切れの冪等性キーを削除
+func (r *IdempotencyKeyRepository) DeleteExpired(ctx context.Context) error {
+	if err := r.hit("DeleteExpired"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	r.keys.removeWhere(func(k *entities.IdempotencyKey) bool { return k.ExpiresAt.Before(now) })
+	return nil
+}
@@ -0,0 +1,145 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.TransactionArchiveRepository = (*TransactionArchiveRepository)(nil)
+
+// TransactionArchiveRepository はTransactionArchiveRepositoryのインメモリ実装
+// 移す取引は transactions から取り出して削除する
+type TransactionArchiveRepository struct {
+	Faults
+	mu           sync.Mutex
+	transactions *TransactionRepository
+	archived     *table[uuid.UUID, entities.ArchivedTransaction]
+	summaries    *table[transactionArchiveSummaryKey, entities.TransactionArchiveSummary]
+}
+
+type transactionArchiveSummaryKey struct {
+	month  string
+	txType entities.TransactionType
+	status entities.TransactionStatus
+}
+
+// NewTransactionArchiveRepository は空のTransactionArchiveRepositoryを作成
+func NewTransactionArchiveRepository(transactions *TransactionRepository) *TransactionArchiveRepository {
+	return &TransactionArchiveRepository{
+		transactions: transactions,
+		archived:     newTable[uuid.UUID, entities.ArchivedTransaction](),
+		summaries:    newTable[transactionArchiveSummaryKey, entities.TransactionArchiveSummary](),
+	}
+}
+
+// MoveBefore はcutoffより前に作成された処理中以外の取引を古い順にlimit件移し、集計に加算する
+func (r *TransactionArchiveRepository) MoveBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	if err := r.hit("MoveBefore"); err != nil {
+		return 0, err
+	}
+	moved := sortBy(r.transactions.all(func(t *entities.Transaction) bool {
+		return t.CreatedAt.Before(cutoff) && t.Status != entities.TransactionStatusPending
+	}), oldestFirst(func(t *entities.Transaction) time.Time { return t.CreatedAt }))
+	moved = page(moved, 0, limit)
+
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, t := range moved {
+		r.archived.put(t.ID, &entities.ArchivedTransaction{Transaction: *t, ArchivedAt: now})
+
+		key := transactionArchiveSummaryKey{entities.TransactionArchiveMonth(t.CreatedAt), t.TransactionType, t.Status}
+		s, ok := r.summaries.ref(key)
+		if !ok {
+			r.summaries.put(key, &entities.TransactionArchiveSummary{Month: key.month, TransactionType: key.txType, Status: key.status})
+			s, _ = r.summaries.ref(key)
+		}
+		s.TransactionCount++
+		s.TotalAmount += t.Amount
+		s.UpdatedAt = now
+	}
+
+	r.transactions.mu.Lock()
+	defer r.transactions.mu.Unlock()
+	for _, t := range moved {
+		r.transactions.transactions.remove(t.ID)
+	}
+	return int64(len(moved)), nil
+}
+
+// ReadList は保管済みの取引を作成日時の新しい順に取得
+func (r *TransactionArchiveRepository) ReadList(ctx context.Context, filter *entities.ArchivedTransactionFilter, offset, limit int) ([]*entities.ArchivedTransaction, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := sortBy(r.archived.find(archivedTransactionMatch(filter)),
+		newestFirst(func(t *entities.ArchivedTransaction) time.Time { return t.CreatedAt }))
+	return page(list, offset, limit), nil
+}
+
+// Count は検索条件に一致する保管済みの取引の件数を取得
+func (r *TransactionArchiveRepository) Count(ctx context.Context, filter *entities.ArchivedTransactionFilter) (int64, error) {
+	if err := r.hit("Count"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.archived.count(archivedTransactionMatch(filter)), nil
+}
+
+// ReadSummaries は年月がfromMonthからtoMonthまで（空なら制限なし）の集計を年月・種別・状態の順に取得
+func (r *TransactionArchiveRepository) ReadSummaries(ctx context.Context, fromMonth, toMonth string) ([]*entities.TransactionArchiveSummary, error) {
+	if err := r.hit("ReadSummaries"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.summaries.find(func(s *entities.TransactionArchiveSummary) bool {
+		return (fromMonth == "" || s.Month >= fromMonth) && (toMonth == "" || s.Month <= toMonth)
+	})
+	return sortBy(list, func(a, b *entities.TransactionArchiveSummary) bool {
+		if a.Month != b.Month {
+			return a.Month < b.Month
+		}
+		if a.TransactionType != b.TransactionType {
+			return a.TransactionType < b.TransactionType
+		}
+		return a.Status < b.Status
+	}), nil
+}
+
+// all は他のリポジトリが取引を集計するために使う
+func (r *TransactionArchiveRepository) all(match func(t *entities.Transaction) bool) []*entities.Transaction {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var list []*entities.Transaction
+	for _, a := range r.archived.refs(nil) {
+		t := a.Transaction
+		if match(&t) {
+			list = append(list, &t)
+		}
+	}
+	return list
+}
+
+func archivedTransactionMatch(filter *entities.ArchivedTransactionFilter) func(t *entities.ArchivedTransaction) bool {
+	return func(t *entities.ArchivedTransaction) bool {
+		if t.CreatedAt.Before(filter.From) || !t.CreatedAt.Before(filter.To) {
+			return false
+		}
+		if filter.UserID != nil {
+			return (t.FromUserID != nil && *t.FromUserID == *filter.UserID) || (t.ToUserID != nil && *t.ToUserID == *filter.UserID)
+		}
+		return true
+	}
+}
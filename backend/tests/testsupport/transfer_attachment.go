@@ -0,0 +1,141 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.TransferAttachmentRepository = (*TransferAttachmentRepository)(nil)
+
+// TransferAttachmentRepository はTransferAttachmentRepositoryのインメモリ実装
+// 送金リクエストの添付を承認してできた送金から引くには requests を、保持期間の判定には transactions を使う
+type TransferAttachmentRepository struct {
+	Faults
+	mu           sync.Mutex
+	requests     *TransferRequestRepository
+	transactions *TransactionRepository
+	attachments  *table[uuid.UUID, entities.TransferAttachment]
+}
+
+// NewTransferAttachmentRepository は空のTransferAttachmentRepositoryを作成
+func NewTransferAttachmentRepository(requests *TransferRequestRepository, transactions *TransactionRepository) *TransferAttachmentRepository {
+	return &TransferAttachmentRepository{
+		requests:     requests,
+		transactions: transactions,
+		attachments:  newTable[uuid.UUID, entities.TransferAttachment](),
+	}
+}
+
+// Create は添付を作成
+func (r *TransferAttachmentRepository) Create(ctx context.Context, attachment *entities.TransferAttachment) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.attachments.has(attachment.ID) {
+		return ErrDuplicate
+	}
+	r.attachments.put(attachment.ID, attachment)
+	return nil
+}
+
+// Delete は添付を削除（存在しなければ何もしない）
+func (r *TransferAttachmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attachments.remove(id)
+	return nil
+}
+
+// ReadByTransactionID は送金の添付を取得（送金リクエストに添付したものを含め、新しいものを優先。なければnil）
+func (r *TransferAttachmentRepository) ReadByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entities.TransferAttachment, error) {
+	if err := r.hit("ReadByTransactionID"); err != nil {
+		return nil, err
+	}
+	return r.forTransaction(transactionID), nil
+}
+
+// ReadByTransferRequestID は送金リクエストの添付を取得（なければnil）
+func (r *TransferAttachmentRepository) ReadByTransferRequestID(ctx context.Context, requestID uuid.UUID) (*entities.TransferAttachment, error) {
+	if err := r.hit("ReadByTransferRequestID"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return newestAttachment(r.attachments.find(func(a *entities.TransferAttachment) bool {
+		return a.TransferRequestID != nil && *a.TransferRequestID == requestID
+	})), nil
+}
+
+// ReadListBefore は添付した送金（送金がなければ添付した日時）がcutoffより前の添付を古い順にlimit件取得
+func (r *TransferAttachmentRepository) ReadListBefore(ctx context.Context, cutoff time.Time, limit int) ([]*entities.TransferAttachment, error) {
+	if err := r.hit("ReadListBefore"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	all := r.attachments.find(func(a *entities.TransferAttachment) bool { return true })
+	r.mu.Unlock()
+
+	expired := make([]*entities.TransferAttachment, 0)
+	for _, a := range all {
+		at := a.CreatedAt
+		if tx := r.transactions.lookup(r.transactionIDOf(a)); tx != nil {
+			at = tx.CreatedAt
+		}
+		if at.Before(cutoff) {
+			expired = append(expired, a)
+		}
+	}
+	return page(sortBy(expired, oldestFirst(func(a *entities.TransferAttachment) time.Time { return a.CreatedAt })), 0, limit), nil
+}
+
+// forTransaction は送金の添付（送金リクエストに添付したものを含む）のうち最も新しいものを返す
+func (r *TransferAttachmentRepository) forTransaction(transactionID uuid.UUID) *entities.TransferAttachment {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	all := r.attachments.find(func(a *entities.TransferAttachment) bool { return true })
+	r.mu.Unlock()
+
+	matched := make([]*entities.TransferAttachment, 0)
+	for _, a := range all {
+		if id := r.transactionIDOf(a); id != uuid.Nil && id == transactionID {
+			matched = append(matched, a)
+		}
+	}
+	return newestAttachment(matched)
+}
+
+// transactionIDOf は添付した送金のID（送金になっていない送金リクエストの添付ならuuid.Nil）
+func (r *TransferAttachmentRepository) transactionIDOf(a *entities.TransferAttachment) uuid.UUID {
+	if a.TransactionID != nil {
+		return *a.TransactionID
+	}
+	if a.TransferRequestID == nil {
+		return uuid.Nil
+	}
+	r.requests.mu.Lock()
+	defer r.requests.mu.Unlock()
+	if tr, ok := r.requests.requests.get(*a.TransferRequestID); ok && tr.TransactionID != nil {
+		return *tr.TransactionID
+	}
+	return uuid.Nil
+}
+
+// newestAttachment は添付のうち最も新しいものを返す（空ならnil）
+func newestAttachment(list []*entities.TransferAttachment) *entities.TransferAttachment {
+	if len(list) == 0 {
+		return nil
+	}
+	return sortBy(list, newestFirst(func(a *entities.TransferAttachment) time.Time { return a.CreatedAt }))[0]
+}
@@ -0,0 +1,221 @@
+package testsupport
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.TransferRequestRepository = (*TransferRequestRepository)(nil)
+
+// TransferRequestRepository はTransferRequestRepositoryのインメモリ実装
+// ユーザー情報付きの取得には users を、承認待ちからミュートした送信者を除くには mutes を使う
+type TransferRequestRepository struct {
+	Faults
+	clock
+	mu       sync.Mutex
+	users    *UserRepository
+	mutes    *MuteListRepository
+	requests *table[uuid.UUID, entities.TransferRequest]
+}
+
+// NewTransferRequestRepository は空のTransferRequestRepositoryを作成
+func NewTransferRequestRepository(users *UserRepository, mutes *MuteListRepository) *TransferRequestRepository {
+	return &TransferRequestRepository{users: users, mutes: mutes, requests: newTable[uuid.UUID, entities.TransferRequest]()}
+}
+
+// Create は新しい送金リクエストを作成（同じ冪等性キーがあればErrDuplicate）
+func (r *TransferRequestRepository) Create(ctx context.Context, transferRequest *entities.TransferRequest) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.requests.has(transferRequest.ID) || r.requests.count(func(tr *entities.TransferRequest) bool {
+		return tr.IdempotencyKey == transferRequest.IdempotencyKey
+	}) > 0 {
+		return ErrDuplicate
+	}
+	r.requests.put(transferRequest.ID, transferRequest)
+	return nil
+}
+
+// Read はIDで送金リクエストを検索（存在しない場合はnil）
+func (r *TransferRequestRepository) Read(ctx context.Context, id uuid.UUID) (*entities.TransferRequest, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if tr, ok := r.requests.get(id); ok {
+		return tr, nil
+	}
+	return nil, nil
+}
+
+// ReadByIdempotencyKey は冪等性キーで送金リクエストを検索（存在しない場合はnil）
+func (r *TransferRequestRepository) ReadByIdempotencyKey(ctx context.Context, key string) (*entities.TransferRequest, error) {
+	if err := r.hit("ReadByIdempotencyKey"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if tr, ok := r.requests.first(func(tr *entities.TransferRequest) bool { return tr.IdempotencyKey == key }); ok {
+		return tr, nil
+	}
+	return nil, nil
+}
+
+// Update は送金リクエストを更新
+func (r *TransferRequestRepository) Update(ctx context.Context, transferRequest *entities.TransferRequest) error {
+	if err := r.hit("Update"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.requests.has(transferRequest.ID) {
+		return errors.New("no rows affected")
+	}
+	r.requests.put(transferRequest.ID, transferRequest)
+	return nil
+}
+
+// ReadPendingByToUser は受取人宛の有効期限内の承認待ちリクエスト（ミュートした送信者のものは除く）を新しい順に取得
+func (r *TransferRequestRepository) ReadPendingByToUser(ctx context.Context, toUserID uuid.UUID, offset, limit int) ([]*entities.TransferRequest, error) {
+	if err := r.hit("ReadPendingByToUser"); err != nil {
+		return nil, err
+	}
+	return r.list(r.pendingTo(toUserID), offset, limit), nil
+}
+
+// ReadSentByFromUser は送信者が送ったリクエスト（割り勘の1人分は除く）を新しい順に取得
+func (r *TransferRequestRepository) ReadSentByFromUser(ctx context.Context, fromUserID uuid.UUID, offset, limit int) ([]*entities.TransferRequest, error) {
+	if err := r.hit("ReadSentByFromUser"); err != nil {
+		return nil, err
+	}
+	return r.list(sentBy(fromUserID), offset, limit), nil
+}
+
+// CountPendingByToUser は受取人宛の有効期限内の承認待ちリクエスト数（ミュートした送信者のものは除く）を取得
+func (r *TransferRequestRepository) CountPendingByToUser(ctx context.Context, toUserID uuid.UUID) (int64, error) {
+	if err := r.hit("CountPendingByToUser"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests.count(r.pendingTo(toUserID)), nil
+}
+
+// CountPendingByFromUser は送信者が送った有効期限内の承認待ちリクエスト数（割り勘の1人分は除く）を取得
+func (r *TransferRequestRepository) CountPendingByFromUser(ctx context.Context, fromUserID uuid.UUID) (int64, error) {
+	if err := r.hit("CountPendingByFromUser"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests.count(r.pendingFrom(fromUserID, nil)), nil
+}
+
+// CountPendingByFromUserTo は送信者が受取人に送った有効期限内の承認待ちリクエスト数（割り勘の1人分は除く）を取得
+func (r *TransferRequestRepository) CountPendingByFromUserTo(ctx context.Context, fromUserID, toUserID uuid.UUID) (int64, error) {
+	if err := r.hit("CountPendingByFromUserTo"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests.count(r.pendingFrom(fromUserID, &toUserID)), nil
+}
+
+// ReadBySplitIDs は割り勘ごとの送金リクエストを作成順に取得
+func (r *TransferRequestRepository) ReadBySplitIDs(ctx context.Context, splitIDs []uuid.UUID) ([]*entities.TransferRequest, error) {
+	if err := r.hit("ReadBySplitIDs"); err != nil {
+		return nil, err
+	}
+	ids := make(map[uuid.UUID]bool, len(splitIDs))
+	for _, id := range splitIDs {
+		ids[id] = true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.requests.find(func(tr *entities.TransferRequest) bool { return tr.SplitID != nil && ids[*tr.SplitID] })
+	return sortBy(list, oldestFirst(func(tr *entities.TransferRequest) time.Time { return tr.CreatedAt })), nil
+}
+
+// UpdateExpiredRequests は期限切れの承認待ち・確認待ちのリクエストを期限切れにする
+func (r *TransferRequestRepository) UpdateExpiredRequests(ctx context.Context) (int64, error) {
+	if err := r.hit("UpdateExpiredRequests"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	expired := r.requests.refs(func(tr *entities.TransferRequest) bool {
+		return (tr.Status == entities.TransferRequestStatusPending || tr.Status == entities.TransferRequestStatusCountered) &&
+			!tr.ExpiresAt.After(now)
+	})
+	for _, tr := range expired {
+		tr.Status = entities.TransferRequestStatusExpired
+	}
+	return int64(len(expired)), nil
+}
+
+// ReadPendingByToUserWithUsers は受取人宛の承認待ちリクエストをユーザー情報付きで取得
+func (r *TransferRequestRepository) ReadPendingByToUserWithUsers(ctx context.Context, toUserID uuid.UUID, offset, limit int) ([]*entities.TransferRequestWithUsers, error) {
+	if err := r.hit("ReadPendingByToUserWithUsers"); err != nil {
+		return nil, err
+	}
+	return r.withUsers(r.list(r.pendingTo(toUserID), offset, limit)), nil
+}
+
+// ReadSentByFromUserWithUsers は送信者が送ったリクエストをユーザー情報付きで取得
+func (r *TransferRequestRepository) ReadSentByFromUserWithUsers(ctx context.Context, fromUserID uuid.UUID, offset, limit int) ([]*entities.TransferRequestWithUsers, error) {
+	if err := r.hit("ReadSentByFromUserWithUsers"); err != nil {
+		return nil, err
+	}
+	return r.withUsers(r.list(sentBy(fromUserID), offset, limit)), nil
+}
+
+func (r *TransferRequestRepository) list(match func(tr *entities.TransferRequest) bool, offset, limit int) []*entities.TransferRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := sortBy(r.requests.find(match), newestFirst(func(tr *entities.TransferRequest) time.Time { return tr.CreatedAt }))
+	return page(list, offset, limit)
+}
+
+func (r *TransferRequestRepository) withUsers(list []*entities.TransferRequest) []*entities.TransferRequestWithUsers {
+	results := make([]*entities.TransferRequestWithUsers, len(list))
+	for i, tr := range list {
+		results[i] = &entities.TransferRequestWithUsers{
+			TransferRequest: tr,
+			FromUser:        r.users.lookup(tr.FromUserID),
+			ToUser:          r.users.lookup(tr.ToUserID),
+		}
+	}
+	return results
+}
+
+func (r *TransferRequestRepository) pendingTo(toUserID uuid.UUID) func(tr *entities.TransferRequest) bool {
+	now := r.now()
+	return func(tr *entities.TransferRequest) bool {
+		return tr.Approver() == toUserID && tr.Status == entities.TransferRequestStatusPending && tr.ExpiresAt.After(now) &&
+			!r.mutes.muted(toUserID, tr.FromUserID)
+	}
+}
+
+// pendingFrom は送信者が送った（toUserIDがあればその受取人宛の）有効期限内の承認待ちリクエストか
+func (r *TransferRequestRepository) pendingFrom(fromUserID uuid.UUID, toUserID *uuid.UUID) func(tr *entities.TransferRequest) bool {
+	now := r.now()
+	return func(tr *entities.TransferRequest) bool {
+		return sentBy(fromUserID)(tr) && (toUserID == nil || tr.ToUserID == *toUserID) &&
+			tr.Status == entities.TransferRequestStatusPending && tr.ExpiresAt.After(now)
+	}
+}
+
+func sentBy(fromUserID uuid.UUID) func(tr *entities.TransferRequest) bool {
+	return func(tr *entities.TransferRequest) bool { return tr.FromUserID == fromUserID && !tr.IsSplitShare() }
+}
@@ -0,0 +1,371 @@
+package testsupport
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.UserRepository = (*UserRepository)(nil)
+
+// UserRepository はUserRepositoryのインメモリ実装
+// ユーザー名・メールアドレスの一意制約と、versionによる楽観的ロックを再現する
+type UserRepository struct {
+	Faults
+	clock
+	mu    sync.Mutex
+	users *table[uuid.UUID, entities.User]
+}
+
+// NewUserRepository は空のUserRepositoryを作成
+func NewUserRepository() *UserRepository {
+	return &UserRepository{users: newTable[uuid.UUID, entities.User]()}
+}
+
+// Seed はユーザーをそのまま保存する（一意制約の確認やエラーの注入を行わない）
+func (r *UserRepository) Seed(users ...*entities.User) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range users {
+		r.users.put(u.ID, u)
+	}
+}
+
+// Create は新しいユーザーを作成
+func (r *UserRepository) Create(ctx context.Context, user *entities.User) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	return r.create(user)
+}
+
+// create はCreateの本体（他のリポジトリがユーザーを作るときにも使う）
+func (r *UserRepository) create(user *entities.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.users.has(user.ID) || r.users.count(func(u *entities.User) bool {
+		return u.TenantID == user.TenantID && (u.Username == user.Username || u.Email == user.Email)
+	}) > 0 {
+		return ErrDuplicate
+	}
+	if user.Version == 0 {
+		user.Version = 1
+	}
+	now := r.now()
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = now
+	}
+	if user.UpdatedAt.IsZero() {
+		user.UpdatedAt = now
+	}
+	r.users.put(user.ID, user)
+	return nil
+}
+
+// Read はIDでユーザーを検索
+func (r *UserRepository) Read(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	if err := r.hit("Read"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users.get(id); ok {
+		return u, nil
+	}
+	return nil, entities.ErrUserNotFound
+}
+
+// ReadByUsername はユーザー名でユーザーを検索
+func (r *UserRepository) ReadByUsername(ctx context.Context, username string) (*entities.User, error) {
+	if err := r.hit("ReadByUsername"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users.first(func(u *entities.User) bool { return u.Username == username }); ok {
+		return u, nil
+	}
+	return nil, entities.ErrUserNotFound
+}
+
+// ReadByEmail はメールアドレスでユーザーを検索
+func (r *UserRepository) ReadByEmail(ctx context.Context, email string) (*entities.User, error) {
+	if err := r.hit("ReadByEmail"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users.first(func(u *entities.User) bool { return u.Email == email }); ok {
+		return u, nil
+	}
+	return nil, entities.ErrUserNotFound
+}
+
+// Update はユーザー情報を更新（versionが一致しなければfalse）
+// ランク・部署はそれぞれのリポジトリだけが更新するため変更しない
+func (r *UserRepository) Update(ctx context.Context, user *entities.User) (bool, error) {
+	if err := r.hit("Update"); err != nil {
+		return false, err
+	}
+	return r.save(user)
+}
+
+// save はUpdateの本体（他のリポジトリがユーザーを更新するときにも使う）
+func (r *UserRepository) save(user *entities.User) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users.ref(user.ID)
+	if !ok || stored.Version != user.Version {
+		return false, nil
+	}
+	if r.users.count(func(u *entities.User) bool {
+		return u.ID != user.ID && (u.Username == user.Username || u.Email == user.Email)
+	}) > 0 {
+		return false, ErrDuplicate
+	}
+	updated := *user
+	updated.ReservedBalance = stored.ReservedBalance
+	updated.Tier = stored.Tier
+	updated.TierOverridden = stored.TierOverridden
+	updated.TierUpdatedAt = stored.TierUpdatedAt
+	updated.DepartmentID = stored.DepartmentID
+	updated.PersonalQRCode = stored.PersonalQRCode
+	updated.CreatedAt = stored.CreatedAt
+	updated.Version = stored.Version + 1
+	updated.UpdatedAt = r.now()
+	*stored = updated
+	return true, nil
+}
+
+// UpdateBalanceWithLock は残高を更新（減算は確保中の分を除いた残高から行い、足りなければErrInsufficientBalance）
+func (r *UserRepository) UpdateBalanceWithLock(ctx context.Context, userID uuid.UUID, amount int64, isDeduct bool) error {
+	if err := r.hit("UpdateBalanceWithLock"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.applyBalances([]repository.BalanceUpdate{{UserID: userID, Amount: amount, IsDeduct: isDeduct}})
+}
+
+// UpdateBalancesWithLock は複数ユーザーの残高を一括更新（減算は確保中の分を除いた残高から行う）
+// 1件でも失敗すればどのユーザーの残高も変えない（トランザクションのロールバックと同じ）
+func (r *UserRepository) UpdateBalancesWithLock(ctx context.Context, updates []repository.BalanceUpdate) error {
+	if err := r.hit("UpdateBalancesWithLock"); err != nil {
+		return err
+	}
+	if len(updates) == 0 {
+		return errors.New("no updates provided")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.applyBalances(updates)
+}
+
+// UpdateReservedBalanceWithLock は確保中の額を増減（確保は使える残高が足りなければErrInsufficientBalance）
+func (r *UserRepository) UpdateReservedBalanceWithLock(ctx context.Context, userID uuid.UUID, amount int64, isRelease bool) error {
+	if err := r.hit("UpdateReservedBalanceWithLock"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.users.ref(userID)
+	if !ok {
+		return entities.ErrUserNotFound
+	}
+	reserved := stored.ReservedBalance
+	if isRelease {
+		reserved -= amount
+	} else {
+		if available := stored.AvailableBalance(); available < amount {
+			return entities.ErrInsufficientBalance.WithParams(map[string]interface{}{"balance": available, "required": amount})
+		}
+		reserved += amount
+	}
+	if reserved < 0 {
+		return errors.New("reserved balance cannot be negative")
+	}
+	stored.ReservedBalance = reserved
+	stored.Version++
+	stored.UpdatedAt = r.now()
+	return nil
+}
+
+// applyBalances は残高を更新する（減算に確保中の分は使わせない）
+func (r *UserRepository) applyBalances(updates []repository.BalanceUpdate) error {
+	type balanceState struct{ balance, reserved int64 }
+	states := make(map[uuid.UUID]*balanceState)
+	for _, u := range updates {
+		stored, ok := r.users.ref(u.UserID)
+		if !ok {
+			return entities.ErrUserNotFound
+		}
+		state, seen := states[u.UserID]
+		if !seen {
+			state = &balanceState{balance: stored.Balance, reserved: stored.ReservedBalance}
+			states[u.UserID] = state
+		}
+		state.reserved -= u.Reserved
+		if state.reserved < 0 {
+			return errors.New("reserved balance cannot be negative")
+		}
+		if u.IsDeduct {
+			if state.balance-state.reserved < u.Amount {
+				return entities.ErrInsufficientBalance
+			}
+			state.balance -= u.Amount
+		} else {
+			state.balance += u.Amount
+		}
+		if state.balance < 0 {
+			return errors.New("balance cannot be negative")
+		}
+	}
+
+	now := r.now()
+	for id, state := range states {
+		stored, _ := r.users.ref(id)
+		stored.Balance = state.balance
+		stored.ReservedBalance = state.reserved
+		stored.Version++
+		stored.UpdatedAt = now
+	}
+	return nil
+}
+
+// ReadList はユーザー一覧を作成日時の新しい順に取得
+func (r *UserRepository) ReadList(ctx context.Context, offset, limit int) ([]*entities.User, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := sortBy(r.users.find(nil), newestFirst(func(u *entities.User) time.Time { return u.CreatedAt }))
+	return page(list, offset, limit), nil
+}
+
+// ReadListWithSearch は検索・ソート付きでユーザー一覧を取得
+// searchはユーザー名・表示名・IDの部分一致（大文字小文字を区別しない）
+func (r *UserRepository) ReadListWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID, sortColumn, sortOrder string, offset, limit int) ([]*entities.User, error) {
+	if err := r.hit("ReadListWithSearch"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := r.users.find(userSearchFilter(search, departmentIDs))
+	var less func(a, b *entities.User) bool
+	switch sortColumn {
+	case "balance":
+		less = func(a, b *entities.User) bool { return a.Balance < b.Balance }
+	case "role":
+		less = func(a, b *entities.User) bool { return a.Role < b.Role }
+	case "username":
+		less = func(a, b *entities.User) bool { return a.Username < b.Username }
+	case "display_name":
+		less = func(a, b *entities.User) bool { return a.DisplayName < b.DisplayName }
+	default:
+		less = func(a, b *entities.User) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	}
+	if sortOrder != "asc" {
+		asc := less
+		less = func(a, b *entities.User) bool { return asc(b, a) }
+	}
+	return page(sortBy(list, less), offset, limit), nil
+}
+
+// Count はユーザー総数を取得
+func (r *UserRepository) Count(ctx context.Context) (int64, error) {
+	if err := r.hit("Count"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.users.count(nil), nil
+}
+
+// CountWithSearch は検索条件付きでユーザー総数を取得
+func (r *UserRepository) CountWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID) (int64, error) {
+	if err := r.hit("CountWithSearch"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.users.count(userSearchFilter(search, departmentIDs)), nil
+}
+
+// Delete はユーザーを削除
+func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.hit("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users.remove(id)
+	return nil
+}
+
+// lookup は他のリポジトリがユーザー情報を結合するために使う（エラーの注入は行わない）
+func (r *UserRepository) lookup(id uuid.UUID) *entities.User {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, _ := r.users.get(id)
+	return u
+}
+
+// update は他のリポジトリがユーザーの一部の列だけを更新するために使う
+func (r *UserRepository) update(id uuid.UUID, fn func(u *entities.User)) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.users.ref(id)
+	if !ok {
+		return false
+	}
+	fn(stored)
+	return true
+}
+
+// all は他のリポジトリが条件に合うユーザーを探すために使う
+func (r *UserRepository) all(match func(u *entities.User) bool) []*entities.User {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.users.find(match)
+}
+
+func userSearchFilter(search string, departmentIDs []uuid.UUID) func(u *entities.User) bool {
+	search = strings.ToLower(search)
+	return func(u *entities.User) bool {
+		if search != "" &&
+			!strings.Contains(strings.ToLower(u.Username), search) &&
+			!strings.Contains(strings.ToLower(u.DisplayName), search) &&
+			!strings.Contains(u.ID.String(), search) {
+			return false
+		}
+		return len(departmentIDs) == 0 || (u.DepartmentID != nil && containsID(departmentIDs, *u.DepartmentID))
+	}
+}
+
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
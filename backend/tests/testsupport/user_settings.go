@@ -0,0 +1,75 @@
+package testsupport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.UserSettingsRepository = (*UserSettingsRepository)(nil)
+
+// UserSettingsRepository はUserSettingsRepositoryのインメモリ実装
+// 変更は users に対して行う
+type UserSettingsRepository struct {
+	Faults
+	users *UserRepository
+}
+
+// NewUserSettingsRepository はUserSettingsRepositoryを作成
+func NewUserSettingsRepository(users *UserRepository) *UserSettingsRepository {
+	return &UserSettingsRepository{users: users}
+}
+
+// UpdateProfile はプロフィール情報を更新（部分更新、楽観的ロックなし）
+func (r *UserSettingsRepository) UpdateProfile(ctx context.Context, user *entities.User) (bool, error) {
+	if err := r.hit("UpdateProfile"); err != nil {
+		return false, err
+	}
+	return r.users.update(user.ID, func(u *entities.User) {
+		u.DisplayName = user.DisplayName
+		u.Email = user.Email
+		u.FirstName = user.FirstName
+		u.LastName = user.LastName
+		u.EmailVerified = user.EmailVerified
+		u.EmailVerifiedAt = user.EmailVerifiedAt
+		u.AvatarURL = user.AvatarURL
+		u.AvatarType = user.AvatarType
+		u.Discoverable = user.Discoverable
+		u.Timezone = user.Timezone
+		u.AcceptTransfersFrom = user.AcceptTransfersFrom
+	}), nil
+}
+
+// UpdateUsername はユーザー名を更新（楽観的ロック・一意性チェック付き）
+func (r *UserSettingsRepository) UpdateUsername(ctx context.Context, user *entities.User) (bool, error) {
+	if err := r.hit("UpdateUsername"); err != nil {
+		return false, err
+	}
+	return r.users.save(user)
+}
+
+// UpdatePassword はパスワードを更新（楽観的ロック付き）
+func (r *UserSettingsRepository) UpdatePassword(ctx context.Context, user *entities.User) (bool, error) {
+	if err := r.hit("UpdatePassword"); err != nil {
+		return false, err
+	}
+	return r.users.save(user)
+}
+
+// CheckUsernameExists はユーザー名が既に存在するかチェック（excludeUserID本人は除く）
+func (r *UserSettingsRepository) CheckUsernameExists(ctx context.Context, username string, excludeUserID uuid.UUID) (bool, error) {
+	if err := r.hit("CheckUsernameExists"); err != nil {
+		return false, err
+	}
+	return len(r.users.all(func(u *entities.User) bool { return u.Username == username && u.ID != excludeUserID })) > 0, nil
+}
+
+// CheckEmailExists はメールアドレスが既に存在するかチェック（excludeUserID本人は除く）
+func (r *UserSettingsRepository) CheckEmailExists(ctx context.Context, email string, excludeUserID uuid.UUID) (bool, error) {
+	if err := r.hit("CheckEmailExists"); err != nil {
+		return false, err
+	}
+	return len(r.users.all(func(u *entities.User) bool { return u.Email == email && u.ID != excludeUserID })) > 0, nil
+}
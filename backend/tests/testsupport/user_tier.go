@@ -0,0 +1,107 @@
+package testsupport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.UserTierRepository = (*UserTierRepository)(nil)
+
+// UserTierRepository はUserTierRepositoryのインメモリ実装
+// 獲得ポイントは transactions、連続チェックイン日数は bonuses から集計し、ランクは users に保存する
+type UserTierRepository struct {
+	Faults
+	users        *UserRepository
+	transactions *TransactionRepository
+	bonuses      *DailyBonusRepository
+}
+
+// NewUserTierRepository はUserTierRepositoryを作成
+func NewUserTierRepository(users *UserRepository, transactions *TransactionRepository, bonuses *DailyBonusRepository) *UserTierRepository {
+	return &UserTierRepository{users: users, transactions: transactions, bonuses: bonuses}
+}
+
+// ReadActivities は有効な全ユーザーの利用状況をユーザーID順に取得
+func (r *UserTierRepository) ReadActivities(ctx context.Context, earnedSince, streakAsOf time.Time) ([]*entities.TierActivity, error) {
+	if err := r.hit("ReadActivities"); err != nil {
+		return nil, err
+	}
+	users := sortBy(r.users.all(func(u *entities.User) bool { return u.IsActive }), func(a, b *entities.User) bool {
+		return a.ID.String() < b.ID.String()
+	})
+	activities := make([]*entities.TierActivity, 0, len(users))
+	for _, u := range users {
+		activities = append(activities, r.activity(u.ID, earnedSince, streakAsOf))
+	}
+	return activities, nil
+}
+
+// ReadActivity は1ユーザーの利用状況を取得
+func (r *UserTierRepository) ReadActivity(ctx context.Context, userID uuid.UUID, earnedSince, streakAsOf time.Time) (*entities.TierActivity, error) {
+	if err := r.hit("ReadActivity"); err != nil {
+		return nil, err
+	}
+	if r.users.lookup(userID) == nil {
+		return nil, entities.ErrUserNotFound
+	}
+	return r.activity(userID, earnedSince, streakAsOf), nil
+}
+
+// UpdateComputedTier は判定したランクを保存（固定中・変化なしのユーザーは更新しない）
+func (r *UserTierRepository) UpdateComputedTier(ctx context.Context, userID uuid.UUID, tier entities.UserTier, at time.Time) (bool, error) {
+	if err := r.hit("UpdateComputedTier"); err != nil {
+		return false, err
+	}
+	updated := false
+	r.users.update(userID, func(u *entities.User) {
+		if u.TierOverridden || u.Tier == tier {
+			return
+		}
+		u.Tier = tier
+		u.TierUpdatedAt = &at
+		updated = true
+	})
+	return updated, nil
+}
+
+// SetOverride は管理者によるランクの固定を設定（tierがnilなら固定を解除）
+func (r *UserTierRepository) SetOverride(ctx context.Context, userID uuid.UUID, tier *entities.UserTier, at time.Time) error {
+	if err := r.hit("SetOverride"); err != nil {
+		return err
+	}
+	if !r.users.update(userID, func(u *entities.User) {
+		u.TierOverridden = tier != nil
+		if tier != nil {
+			u.Tier = *tier
+			u.TierUpdatedAt = &at
+		}
+	}) {
+		return entities.ErrUserNotFound
+	}
+	return nil
+}
+
+// activity は管理者・システムからの付与の合計と、streakAsOfの前日以降まで続いている連続チェックイン日数を集計する
+func (r *UserTierRepository) activity(userID uuid.UUID, earnedSince, streakAsOf time.Time) *entities.TierActivity {
+	a := &entities.TierActivity{UserID: userID}
+	for _, t := range r.transactions.all(completedSince(earnedSince, entities.TransactionTypeAdminGrant, entities.TransactionTypeSystemGrant)) {
+		if t.ToUserID != nil && *t.ToUserID == userID {
+			a.EarnedPoints += t.Amount
+		}
+	}
+
+	days := r.bonuses.bonusDays(userID)
+	day := streakAsOf
+	if !days[day.Format(time.DateOnly)] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for days[day.Format(time.DateOnly)] {
+		a.CheckInStreak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return a
+}
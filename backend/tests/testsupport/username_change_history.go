@@ -0,0 +1,57 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.UsernameChangeHistoryRepository = (*UsernameChangeHistoryRepository)(nil)
+
+// UsernameChangeHistoryRepository はUsernameChangeHistoryRepositoryのインメモリ実装
+type UsernameChangeHistoryRepository struct {
+	Faults
+	mu        sync.Mutex
+	histories *table[uuid.UUID, entities.UsernameChangeHistory]
+}
+
+// NewUsernameChangeHistoryRepository は空のUsernameChangeHistoryRepositoryを作成
+func NewUsernameChangeHistoryRepository() *UsernameChangeHistoryRepository {
+	return &UsernameChangeHistoryRepository{histories: newTable[uuid.UUID, entities.UsernameChangeHistory]()}
+}
+
+// Create は新しいユーザー名変更履歴を作成
+func (r *UsernameChangeHistoryRepository) Create(ctx context.Context, history *entities.UsernameChangeHistory) error {
+	if err := r.hit("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.histories.put(history.ID, history)
+	return nil
+}
+
+// ReadListByUserID はユーザーの履歴を変更日時の新しい順に取得
+func (r *UsernameChangeHistoryRepository) ReadListByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.UsernameChangeHistory, error) {
+	if err := r.hit("ReadListByUserID"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := r.histories.find(func(h *entities.UsernameChangeHistory) bool { return h.UserID == userID })
+	return page(sortBy(list, newestFirst(func(h *entities.UsernameChangeHistory) time.Time { return h.ChangedAt })), offset, limit), nil
+}
+
+// CountByUserID はユーザーの履歴数を取得
+func (r *UsernameChangeHistoryRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	if err := r.hit("CountByUserID"); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.histories.count(func(h *entities.UsernameChangeHistory) bool { return h.UserID == userID }), nil
+}
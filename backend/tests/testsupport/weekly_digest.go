@@ -0,0 +1,80 @@
+package testsupport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+var _ repository.WeeklyDigestRepository = (*WeeklyDigestRepository)(nil)
+
+// WeeklyDigestRepository はWeeklyDigestRepositoryのインメモリ実装
+// 送信対象は users と notifications の通知設定から、期間中の増減は transactions から求める
+type WeeklyDigestRepository struct {
+	Faults
+	users         *UserRepository
+	transactions  *TransactionRepository
+	notifications *NotificationRepository
+}
+
+// NewWeeklyDigestRepository はWeeklyDigestRepositoryを作成
+func NewWeeklyDigestRepository(users *UserRepository, transactions *TransactionRepository, notifications *NotificationRepository) *WeeklyDigestRepository {
+	return &WeeklyDigestRepository{users: users, transactions: transactions, notifications: notifications}
+}
+
+// ReadRecipientIDs はafterより後の送信対象のユーザーIDを昇順にlimit件取得
+func (r *WeeklyDigestRepository) ReadRecipientIDs(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	if err := r.hit("ReadRecipientIDs"); err != nil {
+		return nil, err
+	}
+	users := sortBy(r.users.all(func(u *entities.User) bool {
+		return u.ID.String() > after.String() && u.IsActive && u.Email != "" && r.optedIn(u.ID)
+	}), func(a, b *entities.User) bool { return a.ID.String() < b.ID.String() })
+	users = page(users, 0, limit)
+	ids := make([]uuid.UUID, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+	return ids, nil
+}
+
+// ReadActivity はユーザーごとに[from, to)の確定済みの取引の獲得・使用の合計をユーザーID順に取得
+func (r *WeeklyDigestRepository) ReadActivity(ctx context.Context, userIDs []uuid.UUID, from, to time.Time) ([]*entities.WeeklyDigestActivity, error) {
+	if err := r.hit("ReadActivity"); err != nil {
+		return nil, err
+	}
+	activities := make(map[uuid.UUID]*entities.WeeklyDigestActivity, len(userIDs))
+	list := []*entities.WeeklyDigestActivity{}
+	for _, u := range r.users.all(func(u *entities.User) bool { return containsID(userIDs, u.ID) }) {
+		activities[u.ID] = &entities.WeeklyDigestActivity{UserID: u.ID}
+		list = append(list, activities[u.ID])
+	}
+	for _, t := range r.transactions.all(func(t *entities.Transaction) bool {
+		return t.Status == entities.TransactionStatusCompleted &&
+			t.TransactionType != entities.TransactionTypeBalanceCorrection &&
+			!t.CreatedAt.Before(from) && t.CreatedAt.Before(to)
+	}) {
+		if t.ToUserID != nil {
+			if a, ok := activities[*t.ToUserID]; ok {
+				a.Earned += t.Amount
+			}
+		}
+		if t.FromUserID != nil && t.TransactionType != entities.TransactionTypeSystemExpire {
+			if a, ok := activities[*t.FromUserID]; ok {
+				a.Spent += t.Amount
+			}
+		}
+	}
+	return sortBy(list, func(a, b *entities.WeeklyDigestActivity) bool { return a.UserID.String() < b.UserID.String() }), nil
+}
+
+// optedIn は通知設定でメールと週次のまとめを止めていないか（設定が無ければ受け取る）
+func (r *WeeklyDigestRepository) optedIn(userID uuid.UUID) bool {
+	r.notifications.mu.Lock()
+	defer r.notifications.mu.Unlock()
+	prefs, ok := r.notifications.preferences.get(userID)
+	return !ok || (prefs.EmailEnabled && prefs.WeeklyDigest)
+}
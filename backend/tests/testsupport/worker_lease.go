@@ -0,0 +1,74 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+var _ repository.WorkerLeaseRepository = (*WorkerLeaseRepository)(nil)
+
+// WorkerLeaseRepository はWorkerLeaseRepositoryのインメモリ実装
+type WorkerLeaseRepository struct {
+	Faults
+	mu     sync.Mutex
+	leases *table[string, entities.WorkerLease]
+}
+
+// NewWorkerLeaseRepository は空のWorkerLeaseRepositoryを作成
+func NewWorkerLeaseRepository() *WorkerLeaseRepository {
+	return &WorkerLeaseRepository{leases: newTable[string, entities.WorkerLease]()}
+}
+
+// TryAcquire はholderがリーダーなら期限を延ばし、他のリーダーの期限が切れていれば引き継ぐ
+func (r *WorkerLeaseRepository) TryAcquire(ctx context.Context, name, holder string, now, expiresAt time.Time) (bool, error) {
+	if err := r.hit("TryAcquire"); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.leases.ref(name)
+	if !ok {
+		r.leases.put(name, &entities.WorkerLease{
+			Name: name, Holder: holder, AcquiredAt: now, RenewedAt: now, ExpiresAt: expiresAt, LeaderChanges: 1,
+		})
+		return true, nil
+	}
+	if stored.Holder != holder {
+		if stored.ExpiresAt.After(now) {
+			return false, nil
+		}
+		stored.Holder = holder
+		stored.AcquiredAt = now
+		stored.LeaderChanges++
+	}
+	stored.RenewedAt = now
+	stored.ExpiresAt = expiresAt
+	return true, nil
+}
+
+// Release はholderがリーダーなら期限を切って、他のインスタンスがすぐ引き継げるようにする
+func (r *WorkerLeaseRepository) Release(ctx context.Context, name, holder string, now time.Time) error {
+	if err := r.hit("Release"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.leases.ref(name); ok && stored.Holder == holder && stored.ExpiresAt.After(now) {
+		stored.ExpiresAt = now
+	}
+	return nil
+}
+
+// ReadList は全ワーカーのリーダーを名前順に取得
+func (r *WorkerLeaseRepository) ReadList(ctx context.Context) ([]*entities.WorkerLease, error) {
+	if err := r.hit("ReadList"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return sortBy(r.leases.find(nil), func(a, b *entities.WorkerLease) bool { return a.Name < b.Name }), nil
+}
@@ -0,0 +1,172 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/pkg/client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fastRetry はテストで待たないための再送の方針
+var fastRetry = client.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+func newTestClient(t *testing.T, mux *http.ServeMux) *client.Client {
+	t.Helper()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return client.New(server.URL).WithRetryPolicy(fastRetry)
+}
+
+func writeProblem(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"type": "urn:gity-point-system:error:" + code, "status": status, "error_code": code, "detail": code + " detail",
+	})
+}
+
+func TestClient_SessionAndCSRF(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ログインで受け取ったCookieとCSRFトークンを送り、入れ替えられたトークンに追従する", func(t *testing.T) {
+		var gotTokens []string
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /api/v1/auth/login", func(w http.ResponseWriter, r *http.Request) {
+			http.SetCookie(w, &http.Cookie{Name: "session_token", Value: "session", Path: "/"})
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"user": map[string]string{"username": "alice"}, "csrf_token": "token-1"})
+		})
+		mux.HandleFunc("POST /api/v1/friends/requests", func(w http.ResponseWriter, r *http.Request) {
+			cookie, err := r.Cookie("session_token")
+			require.NoError(t, err)
+			assert.Equal(t, "session", cookie.Value)
+			gotTokens = append(gotTokens, r.Header.Get(client.CSRFTokenHeader))
+			w.Header().Set(client.CSRFTokenHeader, "token-2")
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"friendship": map[string]string{"status": "pending"}})
+		})
+		c := newTestClient(t, mux)
+
+		login, err := c.Login(ctx, "alice", "password")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", login.User.Username)
+
+		for i := 0; i < 2; i++ {
+			_, err := c.SendFriendRequest(ctx, uuid.New())
+			require.NoError(t, err)
+		}
+		assert.Equal(t, []string{"token-1", "token-2"}, gotTokens)
+	})
+
+	t.Run("CSRFトークンの期限切れなら取り直して一度だけ送り直す", func(t *testing.T) {
+		var calls atomic.Int32
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /api/v1/auth/csrf", func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"csrf_token": "fresh"})
+		})
+		mux.HandleFunc("DELETE /api/v1/friends/{id}", func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			if r.Header.Get(client.CSRFTokenHeader) != "fresh" {
+				writeProblem(w, http.StatusForbidden, client.ErrCodeCSRFTokenExpired)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+		c := newTestClient(t, mux)
+		c.SetCSRFToken("stale")
+
+		require.NoError(t, c.RemoveFriend(ctx, uuid.New()))
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Equal(t, "fresh", c.CSRFToken())
+	})
+}
+
+func TestClient_Retry(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("状態を変えるリクエストは同じIdempotency-Keyで再送し、ボディの冪等性キーもそろえる", func(t *testing.T) {
+		var headerKeys, bodyKeys []string
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /api/v1/points/transfer", func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				IdempotencyKey string `json:"idempotency_key"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			headerKeys = append(headerKeys, r.Header.Get(client.IdempotencyKeyHeader))
+			bodyKeys = append(bodyKeys, body.IdempotencyKey)
+			if len(headerKeys) == 1 {
+				w.Header().Set("Retry-After", "0")
+				writeProblem(w, http.StatusServiceUnavailable, client.ErrCodeMaintenanceMode)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"transaction": map[string]int64{"amount": 100}, "new_balance": 900})
+		})
+		c := newTestClient(t, mux)
+
+		resp, err := c.Transfer(ctx, client.TransferRequest{ToUserID: uuid.New(), Amount: 100})
+		require.NoError(t, err)
+		assert.Equal(t, int64(900), resp.NewBalance)
+		require.Len(t, headerKeys, 2)
+		assert.NotEmpty(t, headerKeys[0])
+		assert.Equal(t, headerKeys[0], headerKeys[1])
+		assert.Equal(t, headerKeys, bodyKeys)
+	})
+
+	t.Run("WithIdempotencyKeyで指定したキーを使う", func(t *testing.T) {
+		var got string
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /api/v1/admin/points/grant", func(w http.ResponseWriter, r *http.Request) {
+			got = r.Header.Get(client.IdempotencyKeyHeader)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"dry_run": false})
+		})
+		c := newTestClient(t, mux)
+
+		_, err := c.GrantPoints(client.WithIdempotencyKey(ctx, "saved-key"), client.AdjustPointsRequest{UserID: uuid.New(), Amount: 10})
+		require.NoError(t, err)
+		assert.Equal(t, "saved-key", got)
+	})
+
+	t.Run("再送制御の無いリクエストと回復しないエラーは再送しない", func(t *testing.T) {
+		var registers, balances atomic.Int32
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /api/v1/auth/register", func(w http.ResponseWriter, r *http.Request) {
+			registers.Add(1)
+			writeProblem(w, http.StatusServiceUnavailable, client.ErrCodeMaintenanceMode)
+		})
+		mux.HandleFunc("GET /api/v1/points/balance", func(w http.ResponseWriter, r *http.Request) {
+			balances.Add(1)
+			writeProblem(w, http.StatusUnauthorized, client.ErrCodeUnauthorized)
+		})
+		c := newTestClient(t, mux)
+
+		_, err := c.Register(ctx, client.RegisterRequest{Username: "alice"})
+		assert.Equal(t, http.StatusServiceUnavailable, client.StatusCodeOf(err))
+		_, err = c.Balance(ctx)
+		assert.True(t, client.IsCode(err, client.ErrCodeUnauthorized))
+		assert.Equal(t, int32(1), registers.Load())
+		assert.Equal(t, int32(1), balances.Load())
+	})
+
+	t.Run("回数の上限まで再送したら最後のエラーを返す", func(t *testing.T) {
+		var calls atomic.Int32
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /api/v1/points/balance", func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			writeProblem(w, http.StatusBadGateway, "error")
+		})
+		c := newTestClient(t, mux)
+
+		_, err := c.Balance(ctx)
+		var apiErr *client.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadGateway, apiErr.Status)
+		assert.Equal(t, int32(fastRetry.MaxAttempts), calls.Load())
+	})
+}
@@ -0,0 +1,372 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupEnv は設定ファイル・シークレットのディレクトリを一時ディレクトリに向ける
+func setupEnv(t *testing.T, yaml string) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("SECRETS_DIR", filepath.Join(dir, "secrets"))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "secrets"), 0o700))
+	if yaml == "" {
+		t.Setenv("CONFIG_FILE", "")
+		return dir
+	}
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(yaml), 0o600))
+	t.Setenv("CONFIG_FILE", path)
+	return dir
+}
+
+func settingOf(t *testing.T, cfg *config.Config, key string) config.Setting {
+	t.Helper()
+	for _, s := range cfg.Settings() {
+		if s.Key == key {
+			return s
+		}
+	}
+	t.Fatalf("setting %s not found", key)
+	return config.Setting{}
+}
+
+// ========================================
+// Load Tests
+// ========================================
+
+func TestLoad(t *testing.T) {
+	t.Run("設定ファイルの値を環境変数で上書きできる", func(t *testing.T) {
+		setupEnv(t, `
+server:
+  port: 9090
+  deprecated_api_versions:
+    v1: 2027-03-31
+database:
+  host: db.internal
+security:
+  allowed_origins:
+    - https://points.example.com
+scheduler:
+  schedules:
+    session_purge: "0 4 * * *"
+`)
+		t.Setenv("DB_HOST", "override.internal")
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Equal(t, "9090", cfg.Server.Port)
+		assert.Equal(t, "override.internal", cfg.Database.Host)
+		assert.Equal(t, []string{"https://points.example.com"}, cfg.Security.AllowedOrigins)
+		assert.Equal(t, "0 4 * * *", cfg.Scheduler.Schedules["session_purge"])
+		assert.Equal(t, "2027-03-31", cfg.Server.DeprecatedAPIVersions["v1"].Format("2006-01-02"))
+
+		assert.Equal(t, config.SourceFile, settingOf(t, cfg, "SERVER_PORT").Source)
+		assert.Equal(t, config.SourceEnv, settingOf(t, cfg, "DB_HOST").Source)
+		assert.Equal(t, config.SourceDefault, settingOf(t, cfg, "DB_NAME").Source)
+	})
+
+	t.Run("シークレットを<KEY>_FILEとDockerシークレットから読み込み、ダンプでは伏せる", func(t *testing.T) {
+		dir := setupEnv(t, "")
+		passwordFile := filepath.Join(dir, "db_password")
+		require.NoError(t, os.WriteFile(passwordFile, []byte("from-file\n"), 0o600))
+		t.Setenv("DB_PASSWORD_FILE", passwordFile)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "secrets", "akerun_access_token"), []byte("docker-secret"), 0o600))
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Equal(t, "from-file", cfg.Database.Password)
+		assert.Equal(t, "docker-secret", cfg.Akerun.AccessToken)
+
+		password := settingOf(t, cfg, "DB_PASSWORD")
+		assert.Equal(t, config.SourceSecret, password.Source)
+		assert.True(t, password.Secret)
+		assert.Equal(t, "********", password.Value)
+		assert.Equal(t, "", settingOf(t, cfg, "MODERATION_API_KEY").Value)
+	})
+
+	t.Run("不正な値はまとめてエラーになる", func(t *testing.T) {
+		setupEnv(t, `
+server:
+  prot: 8080
+`)
+		t.Setenv("MAX_UPLOAD_SIZE_MB", "ten")
+		t.Setenv("DB_DRIVER", "mysql")
+		t.Setenv("JOB_SCHEDULES", "unknown_job=* * * * *")
+		t.Setenv("AKERUN_MAX_PAGES", "0")
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "MAX_UPLOAD_SIZE_MB")
+		assert.Contains(t, err.Error(), "DB_DRIVER")
+		assert.Contains(t, err.Error(), `unknown key "server.prot"`)
+		assert.Contains(t, err.Error(), `unknown job "unknown_job"`)
+		assert.Contains(t, err.Error(), "AKERUN_MAX_PAGES")
+	})
+
+	t.Run("アクセスログのルートごとの記録割合を読み込む", func(t *testing.T) {
+		setupEnv(t, `
+access_log:
+  body_routes:
+    - /api/points/transfer
+  sample_rates:
+    /api/points/balance: 0.1
+`)
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.True(t, cfg.AccessLog.Enabled)
+		assert.Equal(t, []string{"/api/points/transfer"}, cfg.AccessLog.BodyRoutes)
+		assert.Equal(t, map[string]float64{"/api/points/balance": 0.1}, cfg.AccessLog.SampleRates)
+
+		t.Setenv("ACCESS_LOG_SAMPLE_RATES", "/api/points/balance=1.5")
+		_, err = config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ACCESS_LOG_SAMPLE_RATES")
+	})
+
+	t.Run("ルートグループごとのリクエストの期限を読み込む", func(t *testing.T) {
+		setupEnv(t, `
+server:
+  request_timeouts:
+    admin: 60000
+`)
+		t.Setenv("REQUEST_TIMEOUT_MS", "5000")
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Second, cfg.Server.RequestTimeout)
+		assert.Equal(t, map[string]time.Duration{"admin": time.Minute}, cfg.Server.RequestTimeouts)
+
+		t.Setenv("REQUEST_TIMEOUTS", "admin=60000,reports=1000")
+		_, err = config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown route group "reports"`)
+	})
+
+	t.Run("存在しない設定ファイルはエラー", func(t *testing.T) {
+		setupEnv(t, "")
+		t.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+
+		_, err := config.Load()
+		assert.Error(t, err)
+	})
+}
+
+// ========================================
+// Validate Tests
+// ========================================
+
+func validConfig(t *testing.T) *config.Config {
+	t.Helper()
+	setupEnv(t, "")
+	t.Setenv("AKERUN_ACCESS_TOKEN", "token")
+	t.Setenv("AKERUN_ORGANIZATION_ID", "org")
+	t.Setenv("SESSION_SECRET", "a-random-session-secret-of-32-bytes!")
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("Akerunのトークンがなければ警告のみ", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Akerun.AccessToken = ""
+
+		warnings, err := cfg.Validate()
+		require.NoError(t, err)
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "Akerun worker is disabled")
+	})
+
+	t.Run("本番で開発用のセッションキーはエラー", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Server.Env = config.EnvProduction
+		cfg.Security.SessionSecret = config.DefaultSessionSecret
+
+		_, err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SESSION_SECRET")
+	})
+
+	t.Run("開発用のセッションキーは開発環境では警告のみ", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Security.SessionSecret = config.DefaultSessionSecret
+
+		warnings, err := cfg.Validate()
+		require.NoError(t, err)
+		assert.Len(t, warnings, 1)
+	})
+
+	t.Run("QRコードの署名キーは設定するなら32文字以上", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Security.QRSigningSecret = "short"
+
+		_, err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "QR_SIGNING_SECRET")
+	})
+
+	t.Run("ポートとオリジンの形式を検証する", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Server.Port = "70000"
+		cfg.Security.AllowedOrigins = []string{"localhost:3000"}
+
+		_, err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SERVER_PORT")
+		assert.Contains(t, err.Error(), "ALLOWED_ORIGINS")
+	})
+
+	t.Run("取引の保持期間は0か1年以上", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Retention.TransactionDays = 90
+
+		_, err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "TRANSACTION_RETENTION_DAYS")
+
+		cfg.Retention.TransactionDays = 730
+		_, err = cfg.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("パスワードハッシュのパラメータを検証する", func(t *testing.T) {
+		cfg := validConfig(t)
+		assert.Equal(t, config.PasswordAlgorithmBcrypt, cfg.Security.Password.Algorithm)
+		cfg.Security.Password.BcryptCost = 3
+		cfg.Security.Password.Argon2Parallelism = 0
+
+		_, err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "BCRYPT_COST")
+		assert.Contains(t, err.Error(), "ARGON2_PARALLELISM")
+	})
+
+	t.Run("SameSite=NoneのCookieにはSecureが必要", func(t *testing.T) {
+		cfg := validConfig(t)
+		assert.Equal(t, config.SameSiteLax, cfg.Security.SessionCookie.SameSite)
+		cfg.Security.SessionCookie.SameSite = config.SameSiteNone
+		cfg.Security.CSRF.Rotation = "periodic"
+		cfg.Security.CSRF.Grace = cfg.Security.CSRF.TTL
+
+		_, err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SESSION_COOKIE_SAMESITE")
+		assert.Contains(t, err.Error(), "CSRF_GRACE_SEC")
+
+		cfg.Security.SessionCookie.Secure = true
+		cfg.Metrics.Token = "metrics-token"
+		cfg.Security.CSRF.Grace = time.Minute
+		_, err = cfg.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("プロファイルのアドレスはhost:portで、本番で外から届くなら警告する", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Server.PprofAddr = "6060"
+
+		_, err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "PPROF_ADDR")
+
+		cfg.Server.PprofAddr = "127.0.0.1:6060"
+		cfg.Server.Env = config.EnvProduction
+		cfg.Security.SessionCookie.Secure = true
+		cfg.Metrics.Token = "metrics-token"
+		warnings, err := cfg.Validate()
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+
+		cfg.Server.PprofAddr = "0.0.0.0:6060"
+		warnings, err = cfg.Validate()
+		require.NoError(t, err)
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "PPROF_ADDR")
+	})
+
+	t.Run("本番で/metricsのトークンがなければ警告する", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Server.Env = config.EnvProduction
+		cfg.Security.SessionCookie.Secure = true
+
+		warnings, err := cfg.Validate()
+		require.NoError(t, err)
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "METRICS_TOKEN")
+
+		cfg.Metrics.Token = "metrics-token"
+		warnings, err = cfg.Validate()
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("SQLiteではPostgreSQLの接続先を問わない", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Database.Driver = config.DriverSQLite
+		cfg.Database.Host = ""
+		cfg.Database.Port = ""
+
+		_, err := cfg.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("遅いクエリの閾値は0以上で、本番ではEXPLAINを使わない", func(t *testing.T) {
+		cfg := validConfig(t)
+		assert.Equal(t, 200*time.Millisecond, cfg.Database.SlowQueryThreshold)
+		cfg.Database.SlowQueryThreshold = -time.Millisecond
+
+		_, err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "DB_SLOW_QUERY_THRESHOLD_MS")
+
+		cfg.Database.SlowQueryThreshold = 0
+		cfg.Database.SlowQueryExplain = true
+		cfg.Server.Env = config.EnvProduction
+		cfg.Security.SessionCookie.Secure = true
+		cfg.Metrics.Token = "metrics-token"
+		warnings, err := cfg.Validate()
+		require.NoError(t, err)
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "DB_SLOW_QUERY_EXPLAIN")
+	})
+
+	t.Run("コネクションプールとタイムアウトは環境変数で変えられ、矛盾する値は拒否する", func(t *testing.T) {
+		cfg := validConfig(t)
+		assert.Equal(t, 100, cfg.Database.MaxOpenConns)
+		assert.Equal(t, 10, cfg.Database.MaxIdleConns)
+		assert.Equal(t, time.Hour, cfg.Database.ConnMaxLifetime)
+		assert.Zero(t, cfg.Database.StatementTimeout)
+
+		t.Setenv("DB_MAX_OPEN_CONNS", "20")
+		t.Setenv("DB_MAX_IDLE_CONNS", "5")
+		t.Setenv("DB_STATEMENT_TIMEOUT_MS", "30000")
+		t.Setenv("DB_LOCK_TIMEOUT_MS", "5000")
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Equal(t, 20, cfg.Database.MaxOpenConns)
+		assert.Equal(t, 5, cfg.Database.MaxIdleConns)
+		assert.Equal(t, 30*time.Second, cfg.Database.StatementTimeout)
+		assert.Equal(t, 5*time.Second, cfg.Database.LockTimeout)
+		_, err = cfg.Validate()
+		require.NoError(t, err)
+
+		cfg.Database.MaxIdleConns = 21
+		_, err = cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "DB_MAX_IDLE_CONNS")
+
+		cfg.Database.MaxIdleConns = 5
+		cfg.Database.LockTimeout = time.Minute
+		_, err = cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "DB_LOCK_TIMEOUT_MS")
+	})
+}
@@ -0,0 +1,107 @@
+package controllers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// metricsSlowQueries は決まった遅いクエリの件数を返すSlowQueryMonitor
+type metricsSlowQueries []service.SlowQueryCount
+
+func (s metricsSlowQueries) SlowQueries() []service.SlowQueryCount {
+	return s
+}
+
+func setupMetricsRouter(t *testing.T, token string, metrics *entities.BusinessMetrics, slowQueries ...service.SlowQueryCount) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	repos := testsupport.New()
+	repos.BusinessMetrics.Metrics = metrics
+	uc := interactor.NewBusinessMetricsInteractor(repos.BusinessMetrics, metricsSlowQueries(slowQueries))
+	if metrics != nil {
+		require.NoError(t, uc.RefreshBusinessMetrics(context.Background(), time.Unix(1775000000, 0)))
+	}
+	controller := web.NewMetricsController(uc, presenter.NewMetricsPresenter(), token)
+
+	r := gin.New()
+	r.GET("/metrics", controller.Metrics)
+	return r
+}
+
+func getMetrics(r *gin.Engine, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestMetricsController_Metrics(t *testing.T) {
+	t.Run("集計した指標をOpenMetricsの形式で返す", func(t *testing.T) {
+		r := setupMetricsRouter(t, "", &entities.BusinessMetrics{
+			PointsInCirculation: 1010000, IssuedToday: 100, ConsumedToday: 30, TransferredToday: 40,
+			PendingTransferRequests: 2, ActiveUsersToday: 5,
+		})
+
+		w := getMetrics(r, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, presenter.OpenMetricsContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		body := w.Body.String()
+		assert.Contains(t, body, "# TYPE point_system_points_in_circulation gauge\n")
+		assert.Contains(t, body, "point_system_points_in_circulation 1010000\n")
+		assert.Contains(t, body, "point_system_points_issued_today 100\n")
+		assert.Contains(t, body, "point_system_points_consumed_today 30\n")
+		assert.Contains(t, body, "point_system_points_transferred_today 40\n")
+		assert.Contains(t, body, "point_system_transfer_requests_pending 2\n")
+		assert.Contains(t, body, "point_system_active_users_today 5\n")
+		assert.Contains(t, body, "point_system_business_metrics_collected_timestamp_seconds 1775000000\n")
+		assert.Contains(t, body, "point_system_business_metrics_refresh_failures_total 0\n")
+		assert.Regexp(t, "# EOF\n$", body)
+	})
+
+	t.Run("集計前は失敗回数だけを返す", func(t *testing.T) {
+		r := setupMetricsRouter(t, "", nil)
+
+		w := getMetrics(r, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "point_system_points_in_circulation")
+		assert.Contains(t, w.Body.String(), "point_system_business_metrics_refresh_failures_total 0\n")
+		assert.NotContains(t, w.Body.String(), "point_system_db_slow_queries")
+	})
+
+	t.Run("遅いクエリの件数をリポジトリのメソッドごとに返す", func(t *testing.T) {
+		r := setupMetricsRouter(t, "", nil,
+			service.SlowQueryCount{Method: "transaction.RepositoryImpl.ReadListByUserIDWithUsers", Count: 3},
+			service.SlowQueryCount{Method: "user.RepositoryImpl.Read", Count: 1})
+
+		body := getMetrics(r, "").Body.String()
+		assert.Contains(t, body, "# TYPE point_system_db_slow_queries counter\n")
+		assert.Contains(t, body, `point_system_db_slow_queries_total{method="transaction.RepositoryImpl.ReadListByUserIDWithUsers"} 3`+"\n")
+		assert.Contains(t, body, `point_system_db_slow_queries_total{method="user.RepositoryImpl.Read"} 1`+"\n")
+		assert.Regexp(t, "# EOF\n$", body)
+	})
+
+	t.Run("トークンを設定するとBearerトークンが一致しなければ401", func(t *testing.T) {
+		r := setupMetricsRouter(t, "metrics-scrape-token", &entities.BusinessMetrics{})
+
+		assert.Equal(t, http.StatusUnauthorized, getMetrics(r, "").Code)
+		assert.Equal(t, http.StatusUnauthorized, getMetrics(r, "Bearer wrong-token").Code)
+		assert.Equal(t, http.StatusUnauthorized, getMetrics(r, "metrics-scrape-token").Code)
+		assert.Equal(t, http.StatusOK, getMetrics(r, "Bearer metrics-scrape-token").Code)
+	})
+}
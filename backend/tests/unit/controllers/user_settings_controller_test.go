@@ -82,11 +82,35 @@ func (m *MockUserSettingsInputPort) GetProfile(ctx context.Context, req *inputpo
 	return args.Get(0).(*inputport.GetProfileResponse), args.Error(1)
 }
 
+func (m *MockUserSettingsInputPort) UpdatePrivacy(ctx context.Context, req *inputport.UpdatePrivacyRequest) (*inputport.UpdatePrivacyResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inputport.UpdatePrivacyResponse), args.Error(1)
+}
+
+func (m *MockUserSettingsInputPort) UpdateTimezone(ctx context.Context, req *inputport.UpdateTimezoneRequest) (*inputport.UpdateTimezoneResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inputport.UpdateTimezoneResponse), args.Error(1)
+}
+
+func (m *MockUserSettingsInputPort) UpdateTransferAcceptance(ctx context.Context, req *inputport.UpdateTransferAcceptanceRequest) (*inputport.UpdateTransferAcceptanceResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inputport.UpdateTransferAcceptanceResponse), args.Error(1)
+}
+
 // テスト用のヘルパー関数
 func setupTestController() (*web.UserSettingsController, *MockUserSettingsInputPort) {
 	mockUC := new(MockUserSettingsInputPort)
-	presenter := presenter.NewUserSettingsPresenter()
-	controller := web.NewUserSettingsController(mockUC, presenter)
+	cookie := presenter.NewSessionCookie("", false, http.SameSiteLaxMode)
+	controller := web.NewUserSettingsController(mockUC, presenter.NewUserSettingsPresenter(), cookie)
 	return controller, mockUC
 }
 
@@ -235,3 +259,85 @@ func TestGetProfile(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 }
+
+// TestUpdatePrivacy はUpdatePrivacyメソッドのテスト
+func TestUpdatePrivacy(t *testing.T) {
+	controller, mockUC := setupTestController()
+
+	t.Run("成功: 友達検索を非公開にする", func(t *testing.T) {
+		userID := uuid.New()
+		discoverable := false
+
+		c, w := setupTestContext("PUT", "/api/settings/privacy", web.UpdatePrivacyRequest{Discoverable: &discoverable})
+		c.Set("user_id", userID)
+
+		mockUC.On("UpdatePrivacy", mock.Anything, &inputport.UpdatePrivacyRequest{UserID: userID, Discoverable: false}).
+			Return(&inputport.UpdatePrivacyResponse{
+				User: &entities.User{ID: userID, Discoverable: false},
+			}, nil)
+
+		controller.UpdatePrivacy(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"discoverable":false`)
+		mockUC.AssertExpectations(t)
+	})
+
+	t.Run("失敗: discoverable未指定", func(t *testing.T) {
+		c, w := setupTestContext("PUT", "/api/settings/privacy", map[string]interface{}{})
+		c.Set("user_id", uuid.New())
+
+		controller.UpdatePrivacy(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+// TestUpdateTimezone はUpdateTimezoneメソッドのテスト
+func TestUpdateTimezone(t *testing.T) {
+	controller, mockUC := setupTestController()
+
+	t.Run("成功: タイムゾーンを設定する", func(t *testing.T) {
+		userID := uuid.New()
+		timezone := "America/New_York"
+
+		c, w := setupTestContext("PUT", "/api/settings/timezone", web.UpdateTimezoneRequest{Timezone: &timezone})
+		c.Set("user_id", userID)
+
+		mockUC.On("UpdateTimezone", mock.Anything, &inputport.UpdateTimezoneRequest{UserID: userID, Timezone: timezone}).
+			Return(&inputport.UpdateTimezoneResponse{
+				User: &entities.User{ID: userID, Timezone: timezone},
+			}, nil)
+
+		controller.UpdateTimezone(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"timezone":"America/New_York"`)
+		mockUC.AssertExpectations(t)
+	})
+
+	t.Run("失敗: 存在しないタイムゾーン", func(t *testing.T) {
+		userID := uuid.New()
+		timezone := "Mars/Olympus"
+
+		c, w := setupTestContext("PUT", "/api/settings/timezone", web.UpdateTimezoneRequest{Timezone: &timezone})
+		c.Set("user_id", userID)
+
+		mockUC.On("UpdateTimezone", mock.Anything, &inputport.UpdateTimezoneRequest{UserID: userID, Timezone: timezone}).
+			Return(nil, entities.ErrInvalidTimezone)
+
+		controller.UpdateTimezone(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_timezone")
+	})
+
+	t.Run("失敗: timezone未指定", func(t *testing.T) {
+		c, w := setupTestContext("PUT", "/api/settings/timezone", map[string]interface{}{})
+		c.Set("user_id", uuid.New())
+
+		controller.UpdateTimezone(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
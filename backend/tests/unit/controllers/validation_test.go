@@ -0,0 +1,92 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/controllers/web"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRequestValidation は入力の検証エラーがユースケースを呼ばずに項目ごとの400になることのテスト
+// （ユースケースはnilのため、検証を通過すると失敗する）
+func TestRequestValidation(t *testing.T) {
+	controller := web.NewPointController(nil, presenter.NewPointPresenter())
+
+	fieldErrors := func(t *testing.T, body []byte) []entities.FieldError {
+		var problem struct {
+			Errors []entities.FieldError `json:"errors"`
+		}
+		require.NoError(t, json.Unmarshal(body, &problem))
+		return problem.Errors
+	}
+
+	t.Run("一覧のoffset・limitの検証", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			query string
+			want  entities.FieldError
+		}{
+			{"負のoffset", "offset=-1", entities.FieldError{Field: "offset", Message: "must be at least 0"}},
+			{"数値でないlimit", "limit=abc", entities.FieldError{Field: "limit", Message: "must be a number"}},
+			{"同じ値の別のクエリがあっても数値でない項目を返す", "reason_code=ten&limit=ten", entities.FieldError{Field: "limit", Message: "must be a number"}},
+			{"offsetとlimitが同じ値ならoffsetを返す", "offset=x&limit=x", entities.FieldError{Field: "offset", Message: "must be a number"}},
+			{"上限を超えるlimit", "limit=5000", entities.FieldError{Field: "limit", Message: "must be at most 1000"}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				c, w := setupTestContext("GET", "/api/points/history?"+tt.query, nil)
+				c.Set("user_id", uuid.New())
+
+				controller.GetTransactionHistory(c, time.Now())
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				assert.Equal(t, []entities.FieldError{tt.want}, fieldErrors(t, w.Body.Bytes()))
+			})
+		}
+	})
+
+	t.Run("送金の額と説明の検証", func(t *testing.T) {
+		valid := func() web.TransferRequest {
+			return web.TransferRequest{ToUserID: uuid.NewString(), Amount: 100, IdempotencyKey: uuid.NewString()}
+		}
+		tests := []struct {
+			name   string
+			modify func(*web.TransferRequest)
+			want   entities.FieldError
+		}{
+			{"長すぎる説明", func(r *web.TransferRequest) {
+				r.Description = strings.Repeat("あ", entities.TransferMessageMaxLength+1)
+			}, entities.FieldError{Field: "description", Message: "must be at most 200 characters"}},
+			{"上限を超える額", func(r *web.TransferRequest) {
+				r.Amount = entities.PointAmountMax + 1
+			}, entities.FieldError{Field: "amount", Message: "must be between 1 and 100000000"}},
+			{"負の額", func(r *web.TransferRequest) {
+				r.Amount = -1
+			}, entities.FieldError{Field: "amount", Message: "must be between 1 and 100000000"}},
+			{"IDの形式でない送金先", func(r *web.TransferRequest) {
+				r.ToUserID = "alice"
+			}, entities.FieldError{Field: "to_user_id", Message: "must be a valid ID"}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req := valid()
+				tt.modify(&req)
+				c, w := setupTestContext("POST", "/api/points/transfer", req)
+				c.Set("user_id", uuid.New())
+
+				controller.Transfer(c, time.Now())
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				assert.Equal(t, []entities.FieldError{tt.want}, fieldErrors(t, w.Body.Bytes()))
+			})
+		}
+	})
+}
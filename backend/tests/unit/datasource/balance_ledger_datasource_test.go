@@ -0,0 +1,139 @@
+//go:build integration
+// +build integration
+
+package datasource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ========================================
+// BalanceLedgerDataSource SelectBalanceChecks Tests
+// ========================================
+
+func TestBalanceLedgerDataSource_SelectBalanceChecks(t *testing.T) {
+	db := setupIntegrationDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	ds := dspostgresimpl.NewBalanceLedgerDataSource(db)
+	txDS := dspostgresimpl.NewTransactionDataSource(db)
+	batchDS := dspostgresimpl.NewPointBatchDataSource(db)
+	now := time.Now()
+
+	insertTransfer := func(from, to uuid.UUID, amount int64, status entities.TransactionStatus, at time.Time) {
+		t.Helper()
+		tx, err := entities.NewTransfer(from, to, amount, uuid.NewString(), "ledger test")
+		require.NoError(t, err)
+		tx.Status = status
+		tx.CreatedAt = at
+		require.NoError(t, txDS.Insert(ctx, tx))
+	}
+
+	t.Run("完了済みの取引だけを積み上げる", func(t *testing.T) {
+		alice := createTestUserWithBalanceDB(t, db, "ledger_alice", 700)
+		bob := createTestUserWithBalanceDB(t, db, "ledger_bob", 300)
+
+		grant, err := entities.NewAdminGrant(alice.ID, 1000, "grant", uuid.New())
+		require.NoError(t, err)
+		require.NoError(t, txDS.Insert(ctx, grant))
+		insertTransfer(alice.ID, bob.ID, 300, entities.TransactionStatusCompleted, now)
+		insertTransfer(alice.ID, bob.ID, 50, entities.TransactionStatusFailed, now)
+
+		checks, err := ds.SelectBalanceChecks(ctx, []uuid.UUID{alice.ID, bob.ID})
+		require.NoError(t, err)
+		require.Len(t, checks, 2)
+
+		byUser := map[uuid.UUID]*entities.BalanceCheck{}
+		for _, c := range checks {
+			byUser[c.UserID] = c
+		}
+		assert.Equal(t, int64(700), byUser[alice.ID].LedgerBalance)
+		assert.Equal(t, int64(300), byUser[bob.ID].LedgerBalance)
+		assert.False(t, byUser[alice.ID].HasDiscrepancy())
+		assert.False(t, byUser[bob.ID].HasDiscrepancy())
+	})
+
+	t.Run("移行時の残高とそれ以降の取引から計算する", func(t *testing.T) {
+		user := createTestUserWithBalanceDB(t, db, "ledger_migrated", 999)
+		other := createTestUser(t, db, "ledger_other")
+
+		// 移行前の取引は移行時の残高に含まれているため数えない
+		insertTransfer(other.ID, user.ID, 400, entities.TransactionStatusCompleted, now.Add(-2*time.Hour))
+		require.NoError(t, batchDS.Insert(ctx, entities.NewPointBatch(user.ID, 500, entities.PointBatchSourceMigration, nil, now.Add(-time.Hour))))
+		insertTransfer(other.ID, user.ID, 200, entities.TransactionStatusCompleted, now)
+
+		checks, err := ds.SelectBalanceChecks(ctx, []uuid.UUID{user.ID})
+		require.NoError(t, err)
+		require.Len(t, checks, 1)
+		assert.Equal(t, int64(999), checks[0].StoredBalance)
+		assert.Equal(t, int64(700), checks[0].LedgerBalance)
+		assert.Equal(t, int64(-299), checks[0].Difference())
+	})
+
+	t.Run("キャンセルした商品交換は返金として加え、補正取引は数えない", func(t *testing.T) {
+		user := createTestUserWithBalanceDB(t, db, "ledger_exchange", 1000)
+
+		grant, err := entities.NewAdminGrant(user.ID, 1000, "grant", uuid.New())
+		require.NoError(t, err)
+		require.NoError(t, txDS.Insert(ctx, grant))
+		exchange, err := entities.NewAdminDeduct(user.ID, 200, "exchange", uuid.New())
+		require.NoError(t, err)
+		require.NoError(t, txDS.Insert(ctx, exchange))
+		require.NoError(t, db.GetDB().Exec(
+			`INSERT INTO product_exchanges (user_id, product_id, quantity, points_used, status) VALUES (?, ?, 1, 200, ?)`,
+			user.ID, uuid.New(), entities.ExchangeStatusCancelled).Error)
+
+		correction, err := entities.NewBalanceCorrection(
+			&entities.BalanceCheck{UserID: user.ID, StoredBalance: 0, LedgerBalance: 500}, uuid.New(), "", now)
+		require.NoError(t, err)
+		require.NoError(t, txDS.Insert(ctx, correction))
+
+		checks, err := ds.SelectBalanceChecks(ctx, []uuid.UUID{user.ID})
+		require.NoError(t, err)
+		require.Len(t, checks, 1)
+		assert.Equal(t, int64(1000), checks[0].LedgerBalance)
+	})
+}
+
+// ========================================
+// BalanceLedgerDataSource SelectUserIDs Tests
+// ========================================
+
+func TestBalanceLedgerDataSource_SelectUserIDs(t *testing.T) {
+	db := setupIntegrationDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	ds := dspostgresimpl.NewBalanceLedgerDataSource(db)
+	for _, name := range []string{"page_a", "page_b", "page_c"} {
+		createTestUser(t, db, name)
+	}
+
+	t.Run("ID順にページングする", func(t *testing.T) {
+		var all []uuid.UUID
+		after := uuid.Nil
+		for {
+			ids, err := ds.SelectUserIDs(ctx, after, 2)
+			require.NoError(t, err)
+			if len(ids) == 0 {
+				break
+			}
+			all = append(all, ids...)
+			after = ids[len(ids)-1]
+		}
+
+		require.GreaterOrEqual(t, len(all), 3)
+		for n := 1; n < len(all); n++ {
+			assert.Less(t, all[n-1].String(), all[n].String())
+		}
+	})
+}
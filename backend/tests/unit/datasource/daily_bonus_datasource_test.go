@@ -256,3 +256,41 @@ func TestDailyBonusDataSource_PollState(t *testing.T) {
 		assert.WithinDuration(t, newPolledAt, lastPolled2, 2*time.Second)
 	})
 }
+
+func TestDailyBonusDataSource_OrganizationPollCursor(t *testing.T) {
+	db := setupIntegrationDB(t)
+	defer db.Close()
+
+	ds := dspostgresimpl.NewDailyBonusDataSource(db)
+	ctx := context.Background()
+
+	t.Run("組織ごとのカーソルがなければ共通のポーリング時刻から始める", func(t *testing.T) {
+		legacy := time.Now().Add(-2 * time.Hour)
+		db.GetDB().Exec("DELETE FROM akerun_poll_state")
+		db.GetDB().Exec("INSERT INTO akerun_poll_state (id, last_polled_at, updated_at) VALUES (1, ?, ?)", legacy, time.Now())
+
+		lastPolled, err := ds.GetOrganizationLastPolledAt(ctx, "org-new")
+		require.NoError(t, err)
+		assert.WithinDuration(t, legacy, lastPolled, time.Second)
+	})
+
+	t.Run("組織ごとに独立して更新される", func(t *testing.T) {
+		mainPolledAt := time.Now().Add(-5 * time.Minute)
+		branchPolledAt := time.Now().Add(-3 * time.Hour)
+
+		require.NoError(t, ds.UpsertOrganizationLastPolledAt(ctx, "org-main", mainPolledAt))
+		require.NoError(t, ds.UpsertOrganizationLastPolledAt(ctx, "org-branch", branchPolledAt))
+
+		// 2回目は同じ行を更新する
+		mainPolledAt = time.Now()
+		require.NoError(t, ds.UpsertOrganizationLastPolledAt(ctx, "org-main", mainPolledAt))
+
+		got, err := ds.GetOrganizationLastPolledAt(ctx, "org-main")
+		require.NoError(t, err)
+		assert.WithinDuration(t, mainPolledAt, got, time.Second)
+
+		got, err = ds.GetOrganizationLastPolledAt(ctx, "org-branch")
+		require.NoError(t, err)
+		assert.WithinDuration(t, branchPolledAt, got, time.Second)
+	})
+}
@@ -0,0 +1,100 @@
+//go:build integration
+// +build integration
+
+package datasource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ========================================
+// SuspiciousActivityDataSource SelectTransferVelocity Tests
+// ========================================
+
+func TestSuspiciousActivityDataSource_SelectTransferVelocity(t *testing.T) {
+	db := setupIntegrationDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	ds := dspostgresimpl.NewSuspiciousActivityDataSource(db)
+	txDS := dspostgresimpl.NewTransactionDataSource(db)
+	now := time.Now()
+
+	insertTransfer := func(from, to uuid.UUID, status entities.TransactionStatus, at time.Time) {
+		t.Helper()
+		tx, err := entities.NewTransfer(from, to, 100, uuid.NewString(), "velocity test")
+		require.NoError(t, err)
+		tx.Status = status
+		tx.CreatedAt = at
+		require.NoError(t, txDS.Insert(ctx, tx))
+	}
+
+	t.Run("検出条件の期間内の完了済み取引だけを集計する", func(t *testing.T) {
+		sender := createTestUserWithBalanceDB(t, db, "velocity_sender", 10000)
+		partner := createTestUserWithBalanceDB(t, db, "velocity_partner", 10000)
+
+		insertTransfer(sender.ID, partner.ID, entities.TransactionStatusCompleted, now.Add(-10*time.Minute))
+		insertTransfer(partner.ID, sender.ID, entities.TransactionStatusCompleted, now.Add(-5*time.Minute))
+		insertTransfer(sender.ID, partner.ID, entities.TransactionStatusFailed, now.Add(-time.Minute))
+		insertTransfer(partner.ID, sender.ID, entities.TransactionStatusCompleted, now.Add(-2*time.Hour))
+
+		grant, err := entities.NewAdminGrant(sender.ID, 700, "grant", uuid.New())
+		require.NoError(t, err)
+		require.NoError(t, txDS.Insert(ctx, grant))
+
+		v, err := ds.SelectTransferVelocity(ctx, entities.NewTransferVelocityQuery(sender.ID, partner.ID, now))
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), v.PairTransfers)
+		assert.Equal(t, int64(1), v.ReverseTransfers)
+		assert.Equal(t, int64(700), v.RecentGrantAmount)
+		// partnerは作成直後のアカウント
+		assert.Equal(t, int64(1), v.NewAccountTransfers)
+	})
+}
+
+// ========================================
+// SuspiciousActivityDataSource UpdateStatus Tests
+// ========================================
+
+func TestSuspiciousActivityDataSource_UpdateStatus(t *testing.T) {
+	db := setupIntegrationDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	ds := dspostgresimpl.NewSuspiciousActivityDataSource(db)
+	sender := createTestUser(t, db, "suspicious_sender")
+	receiver := createTestUser(t, db, "suspicious_receiver")
+	admin := createTestUser(t, db, "suspicious_admin")
+
+	activity := entities.NewSuspiciousActivity(sender.ID, receiver.ID, 500, "", uuid.NewString(),
+		[]entities.SuspiciousActivityReason{entities.SuspiciousReasonWashTransfer, entities.SuspiciousReasonAfterGrant}, true, time.Now())
+	require.NoError(t, ds.Insert(ctx, activity))
+
+	stored, err := ds.Select(ctx, activity.ID)
+	require.NoError(t, err)
+	assert.Equal(t, activity.Reasons, stored.Reasons)
+	assert.Equal(t, entities.SuspiciousActivityStatusHeld, stored.Status)
+
+	require.NoError(t, activity.Confirm(admin.ID, "", time.Now()))
+	updated, err := ds.UpdateStatus(ctx, activity, entities.SuspiciousActivityStatusHeld)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	// 先に確認された記録は更新しない
+	updated, err = ds.UpdateStatus(ctx, activity, entities.SuspiciousActivityStatusHeld)
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	list, err := ds.SelectList(ctx, entities.SuspiciousActivityStatusRejected, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, activity.ID, list[0].ID)
+}
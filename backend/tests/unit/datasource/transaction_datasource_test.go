@@ -133,7 +133,7 @@ func TestTransactionDataSource_CountByUserID(t *testing.T) {
 			require.NoError(t, ds.Insert(context.Background(), tx))
 		}
 
-		count, err := ds.CountByUserID(context.Background(), sender.ID)
+		count, err := ds.CountByUserID(context.Background(), sender.ID, "")
 		require.NoError(t, err)
 		assert.GreaterOrEqual(t, count, int64(3))
 	})
@@ -0,0 +1,63 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiresAdminApproval(t *testing.T) {
+	assert.False(t, entities.RequiresAdminApproval(0, 1000000), "閾値0は承認不要")
+	assert.False(t, entities.RequiresAdminApproval(1000, 1000), "閾値ちょうどは承認不要")
+	assert.True(t, entities.RequiresAdminApproval(1000, 1001))
+
+	assert.Equal(t, int64(0), entities.ParseAdminApprovalThreshold(""))
+	assert.Equal(t, int64(0), entities.ParseAdminApprovalThreshold("-5"))
+	assert.Equal(t, int64(5000), entities.ParseAdminApprovalThreshold(" 5000 "))
+}
+
+func TestPendingAdminAction_Review(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	requester := uuid.New()
+	reviewer := uuid.New()
+	newAction := func() *entities.PendingAdminAction {
+		return entities.NewPendingAdminAction(entities.PendingAdminActionGrant, requester, uuid.New(), 5000, "大口付与", "reward", "", "key", false, now)
+	}
+
+	t.Run("別の管理者が承認できる", func(t *testing.T) {
+		a := newAction()
+		require.NoError(t, a.Approve(reviewer, " 確認済み ", now.Add(time.Hour)))
+		assert.Equal(t, entities.PendingAdminActionStatusApproved, a.Status)
+		assert.Equal(t, reviewer, *a.ReviewedBy)
+		assert.Equal(t, "確認済み", a.ReviewComment)
+	})
+
+	t.Run("申請した本人は承認できないが却下はできる", func(t *testing.T) {
+		a := newAction()
+		assert.ErrorIs(t, a.Approve(requester, "", now), entities.ErrSelfApproval)
+		assert.Equal(t, entities.PendingAdminActionStatusPending, a.Status)
+		require.NoError(t, a.Reject(requester, "取り下げ", now))
+		assert.Equal(t, entities.PendingAdminActionStatusRejected, a.Status)
+	})
+
+	t.Run("承認・却下済みや期限切れは審査できない", func(t *testing.T) {
+		a := newAction()
+		require.NoError(t, a.Reject(reviewer, "", now))
+		assert.ErrorIs(t, a.Approve(uuid.New(), "", now), entities.ErrPendingActionNotPending)
+
+		expired := newAction()
+		assert.True(t, expired.IsExpired(now.Add(entities.PendingAdminActionTTL)))
+		assert.ErrorIs(t, expired.Approve(reviewer, "", now.Add(entities.PendingAdminActionTTL)), entities.ErrPendingActionExpired)
+	})
+
+	t.Run("長すぎるコメントはErrInvalidApprovalComment", func(t *testing.T) {
+		a := newAction()
+		comment := strings.Repeat("あ", entities.AdminApprovalCommentMaxLength+1)
+		assert.ErrorIs(t, a.Approve(reviewer, comment, now), entities.ErrInvalidApprovalComment)
+	})
+}
@@ -0,0 +1,55 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAkerunOrganizations_RuleFor(t *testing.T) {
+	orgs := entities.AkerunOrganizations{
+		"org-main": {
+			ID: "org-main",
+			Doors: []entities.AkerunDoorRule{
+				{DoorID: "door-entrance", Name: "正面入口", Multiplier: 1.5},
+				{DoorID: "door-lab", Name: "ラボ"},
+			},
+		},
+		"org-branch": {ID: "org-branch"},
+	}
+
+	rule, ok := orgs.RuleFor(entities.AccessRecord{OrganizationID: "org-main", DoorID: "door-entrance"})
+	assert.True(t, ok)
+	assert.Equal(t, 1.5, rule.PointsMultiplier())
+
+	rule, ok = orgs.RuleFor(entities.AccessRecord{OrganizationID: "org-main", DoorID: "door-lab"})
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, rule.PointsMultiplier(), "倍率の指定がなければ1倍")
+
+	_, ok = orgs.RuleFor(entities.AccessRecord{OrganizationID: "org-main", DoorID: "door-storage"})
+	assert.False(t, ok, "指定していないドアは対象外")
+
+	rule, ok = orgs.RuleFor(entities.AccessRecord{OrganizationID: "org-branch", DoorID: "door-any"})
+	assert.True(t, ok, "ドアの指定がない組織は全ドアが対象")
+	assert.Equal(t, 1.0, rule.PointsMultiplier())
+
+	_, ok = orgs.RuleFor(entities.AccessRecord{DoorID: "door-any"})
+	assert.True(t, ok, "組織IDのない記録（導入前の再試行分）も対象")
+}
+
+func TestDailyBonus_ApplyMultiplier(t *testing.T) {
+	now := time.Now()
+	access := entities.AccessRecord{ID: uuid.New(), OrganizationID: "org-main", DoorID: "door-entrance", DoorName: "正面入口"}
+
+	bonus := entities.NewPendingDailyBonus(uuid.New(), now, access.ID.String(), "テスト太郎", &now)
+	assert.Equal(t, int64(5), bonus.ApplyMultiplier(5), "既定は1倍")
+
+	bonus.WithDoor(access, entities.AkerunDoorRule{DoorID: "door-entrance", Multiplier: 1.5})
+	assert.Equal(t, "org-main", bonus.AkerunOrganizationID)
+	assert.Equal(t, "正面入口", bonus.AkerunDoorName)
+	assert.Equal(t, int64(8), bonus.ApplyMultiplier(5), "端数は四捨五入")
+	assert.Equal(t, int64(0), bonus.ApplyMultiplier(0), "ハズレは0のまま")
+}
@@ -0,0 +1,74 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAkerunRepoll(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	admin := uuid.New()
+
+	t.Run("期間を1時間ずつに分けて処理待ちにする", func(t *testing.T) {
+		r, err := entities.NewAkerunRepoll("org-1", now.Add(-150*time.Minute), now, admin, now)
+		require.NoError(t, err)
+		assert.Equal(t, entities.AkerunRepollStatusPending, r.Status)
+		assert.Equal(t, 3, r.TotalWindows, "端数の30分も1期間として数える")
+		assert.True(t, r.IsInProgress())
+		assert.Equal(t, 0, r.ProgressPercent())
+	})
+
+	for name, tc := range map[string]struct{ from, to time.Time }{
+		"開始が終了より後": {now.Add(-time.Hour), now.Add(-2 * time.Hour)},
+		"開始と終了が同じ": {now.Add(-time.Hour), now.Add(-time.Hour)},
+		"終了が未来":    {now.Add(-time.Hour), now.Add(time.Minute)},
+		"7日を超える期間": {now.Add(-entities.AkerunRepollMaxRange - time.Minute), now},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := entities.NewAkerunRepoll("", tc.from, tc.to, admin, now)
+			assert.ErrorIs(t, err, entities.ErrInvalidAkerunRepoll)
+		})
+	}
+}
+
+func TestAkerunRepoll_Windows(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	from := now.Add(-90 * time.Minute)
+	r, err := entities.NewAkerunRepoll("", from, now, uuid.New(), now)
+	require.NoError(t, err)
+
+	r.Start(now)
+	require.NotNil(t, r.StartedAt)
+	assert.Equal(t, entities.AkerunRepollStatusRunning, r.Status)
+
+	start, end, ok := r.NextWindow()
+	require.True(t, ok)
+	assert.Equal(t, from, start)
+	assert.Equal(t, from.Add(time.Hour), end)
+	r.RecordWindow(5)
+
+	start, end, ok = r.NextWindow()
+	require.True(t, ok)
+	assert.Equal(t, from.Add(time.Hour), start)
+	assert.Equal(t, now, end, "最後の期間は終了時刻まで")
+	assert.Equal(t, 50, r.ProgressPercent())
+
+	// 再開しても最初の開始時刻は残す
+	r.Start(now.Add(time.Hour))
+	assert.Equal(t, now, *r.StartedAt)
+
+	r.RecordWindow(3)
+	_, _, ok = r.NextWindow()
+	assert.False(t, ok)
+	assert.Equal(t, 8, r.FetchedAccesses)
+	assert.Equal(t, 100, r.ProgressPercent())
+
+	r.MarkSucceeded(now)
+	assert.False(t, r.IsInProgress())
+	require.NotNil(t, r.FinishedAt)
+}
@@ -0,0 +1,50 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+// ========================================
+// AnalyticsGranularity Tests
+// ========================================
+
+func TestAnalyticsGranularity_Truncate(t *testing.T) {
+	wed := time.Date(2026, 4, 15, 13, 30, 0, 0, time.UTC)
+
+	t.Run("週単位は月曜0時に切り捨てる", func(t *testing.T) {
+		assert.Equal(t, time.Date(2026, 4, 13, 0, 0, 0, 0, time.UTC), entities.AnalyticsGranularityWeek.Truncate(wed))
+	})
+
+	t.Run("日曜は前の週の月曜に切り捨てる", func(t *testing.T) {
+		sun := time.Date(2026, 4, 19, 23, 0, 0, 0, time.UTC)
+		assert.Equal(t, time.Date(2026, 4, 13, 0, 0, 0, 0, time.UTC), entities.AnalyticsGranularityWeek.Truncate(sun))
+	})
+
+	t.Run("月単位は1日0時に切り捨てる", func(t *testing.T) {
+		assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), entities.AnalyticsGranularityMonth.Truncate(wed))
+	})
+}
+
+func TestAnalyticsGranularity_PeriodsBetween(t *testing.T) {
+	t.Run("週単位", func(t *testing.T) {
+		from := time.Date(2026, 4, 15, 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, 0, entities.AnalyticsGranularityWeek.PeriodsBetween(from, from.AddDate(0, 0, 3)))
+		assert.Equal(t, 2, entities.AnalyticsGranularityWeek.PeriodsBetween(from, from.AddDate(0, 0, 14)))
+	})
+
+	t.Run("月単位は年をまたいで数える", func(t *testing.T) {
+		from := time.Date(2025, 11, 30, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, 3, entities.AnalyticsGranularityMonth.PeriodsBetween(from, to))
+	})
+}
+
+func TestActivityBasis_IsValid(t *testing.T) {
+	assert.True(t, entities.ActivityBasisTransactions.IsValid())
+	assert.True(t, entities.ActivityBasisLogins.IsValid())
+	assert.False(t, entities.ActivityBasis("sessions").IsValid())
+}
@@ -0,0 +1,81 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAnnouncement(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		title      string
+		body       string
+		severity   entities.AnnouncementSeverity
+		audience   entities.AnnouncementAudience
+		targetRole entities.UserRole
+		endsAt     *time.Time
+		wantErr    bool
+	}{
+		{"全員向け", "メンテナンスのお知らせ", "本日22時から停止します", entities.AnnouncementSeverityWarning, entities.AnnouncementAudienceAll, "", &later, false},
+		{"役割指定", "キャンペーン", "ポイント2倍", entities.AnnouncementSeverityInfo, entities.AnnouncementAudienceRole, entities.RoleUser, nil, false},
+		{"タイトルなし", " ", "本文", entities.AnnouncementSeverityInfo, entities.AnnouncementAudienceAll, "", nil, true},
+		{"タイトルが長すぎる", strings.Repeat("あ", entities.AnnouncementTitleMaxLength+1), "本文", entities.AnnouncementSeverityInfo, entities.AnnouncementAudienceAll, "", nil, true},
+		{"不明な重要度", "タイトル", "本文", "urgent", entities.AnnouncementAudienceAll, "", nil, true},
+		{"役割指定で役割なし", "タイトル", "本文", entities.AnnouncementSeverityInfo, entities.AnnouncementAudienceRole, "", nil, true},
+		{"終了が開始より前", "タイトル", "本文", entities.AnnouncementSeverityInfo, entities.AnnouncementAudienceAll, "", &earlier, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := entities.NewAnnouncement(tt.title, tt.body, tt.severity, tt.audience, tt.targetRole, now, tt.endsAt, uuid.New())
+			if tt.wantErr {
+				assert.ErrorIs(t, err, entities.ErrInvalidAnnouncement)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.title, a.Title)
+		})
+	}
+}
+
+func TestAnnouncement_IsActiveAt(t *testing.T) {
+	start := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	a, err := entities.NewAnnouncement("タイトル", "本文", entities.AnnouncementSeverityInfo, entities.AnnouncementAudienceAll, "", start, &end, uuid.New())
+	require.NoError(t, err)
+
+	assert.False(t, a.IsActiveAt(start.Add(-time.Second)))
+	assert.True(t, a.IsActiveAt(start))
+	assert.True(t, a.IsActiveAt(end.Add(-time.Second)))
+	assert.False(t, a.IsActiveAt(end))
+}
+
+func TestAnnouncement_IsVisibleTo(t *testing.T) {
+	newWith := func(audience entities.AnnouncementAudience, role entities.UserRole) *entities.Announcement {
+		a, err := entities.NewAnnouncement("タイトル", "本文", entities.AnnouncementSeverityInfo, audience, role, time.Now(), nil, uuid.New())
+		require.NoError(t, err)
+		return a
+	}
+
+	all := newWith(entities.AnnouncementAudienceAll, "")
+	assert.True(t, all.IsVisibleTo(entities.RoleUser))
+	assert.True(t, all.IsVisibleTo(entities.RoleAdmin))
+
+	admins := newWith(entities.AnnouncementAudienceAdmins, "")
+	assert.False(t, admins.IsVisibleTo(entities.RoleUser))
+	assert.True(t, admins.IsVisibleTo(entities.RoleAdmin))
+
+	users := newWith(entities.AnnouncementAudienceRole, entities.RoleUser)
+	assert.True(t, users.IsVisibleTo(entities.RoleUser))
+	assert.False(t, users.IsVisibleTo(entities.RoleAdmin))
+}
@@ -0,0 +1,91 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferRequest_Authorize(t *testing.T) {
+	from, to, stranger := uuid.New(), uuid.New(), uuid.New()
+	request := &entities.TransferRequest{FromUserID: from, ToUserID: to}
+	splitID := uuid.New()
+	share := &entities.TransferRequest{FromUserID: from, ToUserID: to, SplitID: &splitID}
+
+	tests := []struct {
+		name    string
+		request *entities.TransferRequest
+		userID  uuid.UUID
+		action  entities.Action
+		want    error
+	}{
+		{"送信者は閲覧できる", request, from, entities.ActionView, nil},
+		{"受取人は閲覧できる", request, to, entities.ActionView, nil},
+		{"受取人は承認できる", request, to, entities.ActionApprove, nil},
+		{"送信者は承認できない", request, from, entities.ActionApprove, entities.ErrActionNotPermitted},
+		{"送信者は拒否できない", request, from, entities.ActionReject, entities.ErrActionNotPermitted},
+		{"受取人はカウンターオファーできる", request, to, entities.ActionCounter, nil},
+		{"送信者はカウンターオファーを確定できる", request, from, entities.ActionConfirm, nil},
+		{"受取人はカウンターオファーを確定できない", request, to, entities.ActionConfirm, entities.ErrActionNotPermitted},
+		{"送信者はキャンセルできる", request, from, entities.ActionCancel, nil},
+		{"受取人はキャンセルできない", request, to, entities.ActionCancel, entities.ErrActionNotPermitted},
+		{"割り勘の1人分は支払う参加者が承認する", share, from, entities.ActionApprove, nil},
+		{"割り勘の1人分は請求した作成者がキャンセルする", share, to, entities.ActionCancel, nil},
+		{"関わりのないユーザーには存在を明かさない", request, stranger, entities.ActionView, entities.ErrTransferRequestNotFound},
+		{"関わりのないユーザーの操作も見つからない扱い", request, stranger, entities.ActionApprove, entities.ErrTransferRequestNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Authorize(tt.userID, tt.action)
+			if tt.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductExchange_Authorize(t *testing.T) {
+	payer, recipient, stranger := uuid.New(), uuid.New(), uuid.New()
+	exchange := &entities.ProductExchange{UserID: payer}
+	gift := &entities.ProductExchange{UserID: payer, RecipientID: &recipient}
+
+	assert.NoError(t, exchange.Authorize(payer, entities.ActionCancel))
+	assert.NoError(t, gift.Authorize(recipient, entities.ActionView))
+	assert.NoError(t, gift.Authorize(recipient, entities.ActionAccept))
+	assert.NoError(t, gift.Authorize(recipient, entities.ActionDecline))
+	assert.ErrorIs(t, gift.Authorize(payer, entities.ActionDecline), entities.ErrActionNotPermitted)
+	assert.ErrorIs(t, gift.Authorize(recipient, entities.ActionCancel), entities.ErrActionNotPermitted)
+	assert.ErrorIs(t, exchange.Authorize(payer, entities.ActionAccept), entities.ErrActionNotPermitted)
+	assert.ErrorIs(t, exchange.Authorize(stranger, entities.ActionCancel), entities.ErrExchangeNotFound)
+	assert.ErrorIs(t, gift.Authorize(stranger, entities.ActionView), entities.ErrExchangeNotFound)
+}
+
+func TestOwnedResource_Authorize(t *testing.T) {
+	owner, stranger := uuid.New(), uuid.New()
+
+	t.Run("QRコードは作成者だけが閲覧できる", func(t *testing.T) {
+		qr := &entities.QRCode{UserID: owner}
+		assert.NoError(t, qr.Authorize(owner, entities.ActionView))
+		assert.ErrorIs(t, qr.Authorize(owner, entities.ActionDelete), entities.ErrActionNotPermitted)
+		assert.ErrorIs(t, qr.Authorize(stranger, entities.ActionView), entities.ErrQRCodeNotFound)
+	})
+
+	t.Run("お届け先は登録した人だけが変更・削除できる", func(t *testing.T) {
+		address := &entities.ShippingAddress{UserID: owner}
+		assert.NoError(t, address.Authorize(owner, entities.ActionUpdate))
+		assert.NoError(t, address.Authorize(owner, entities.ActionDelete))
+		assert.ErrorIs(t, address.Authorize(owner, entities.ActionApprove), entities.ErrActionNotPermitted)
+		assert.ErrorIs(t, address.Authorize(stranger, entities.ActionDelete), entities.ErrShippingAddressNotFound)
+	})
+
+	t.Run("取引は送信者・受取人だけが閲覧できる（システムの口座との取引は一方のみ）", func(t *testing.T) {
+		tx := &entities.Transaction{FromUserID: &owner, ToAccount: entities.SystemAccountTreasury}
+		assert.NoError(t, tx.Authorize(owner, entities.ActionView))
+		assert.ErrorIs(t, tx.Authorize(owner, entities.ActionCancel), entities.ErrActionNotPermitted)
+		assert.ErrorIs(t, tx.Authorize(stranger, entities.ActionView), entities.ErrTransactionNotFound)
+	})
+}
@@ -0,0 +1,55 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBalanceCorrection(t *testing.T) {
+	adminID := uuid.New()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("残高が足りなければ受取として記録する", func(t *testing.T) {
+		check := &entities.BalanceCheck{UserID: uuid.New(), StoredBalance: 100, LedgerBalance: 250}
+
+		tx, err := entities.NewBalanceCorrection(check, adminID, "", now)
+		require.NoError(t, err)
+
+		assert.Equal(t, entities.TransactionTypeBalanceCorrection, tx.TransactionType)
+		assert.Equal(t, entities.TransactionStatusCompleted, tx.Status)
+		assert.Equal(t, int64(150), tx.Amount)
+		require.NotNil(t, tx.ToUserID)
+		assert.Equal(t, check.UserID, *tx.ToUserID)
+		assert.Nil(t, tx.FromUserID)
+		assert.Equal(t, "取引履歴との照合による残高の補正", tx.Description)
+		assert.Equal(t, adminID.String(), tx.Metadata["admin_id"])
+		assert.Equal(t, int64(100), tx.Metadata["stored_balance"])
+		assert.Equal(t, int64(250), tx.Metadata["ledger_balance"])
+	})
+
+	t.Run("残高が多すぎれば送付として記録する", func(t *testing.T) {
+		check := &entities.BalanceCheck{UserID: uuid.New(), StoredBalance: 500, LedgerBalance: 300}
+
+		tx, err := entities.NewBalanceCorrection(check, adminID, "二重付与の取り消し", now)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(200), tx.Amount)
+		require.NotNil(t, tx.FromUserID)
+		assert.Equal(t, check.UserID, *tx.FromUserID)
+		assert.Nil(t, tx.ToUserID)
+		assert.Equal(t, "取引履歴との照合による残高の補正: 二重付与の取り消し", tx.Description)
+	})
+
+	t.Run("一致していれば補正しない", func(t *testing.T) {
+		check := &entities.BalanceCheck{UserID: uuid.New(), StoredBalance: 80, LedgerBalance: 80}
+		assert.False(t, check.HasDiscrepancy())
+
+		_, err := entities.NewBalanceCorrection(check, adminID, "", now)
+		assert.ErrorIs(t, err, entities.ErrInvalidAmount)
+	})
+}
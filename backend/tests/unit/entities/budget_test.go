@@ -0,0 +1,114 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBudget(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("対象範囲ごとに対象IDの有無を確認する", func(t *testing.T) {
+		_, err := entities.NewBudget("全体", entities.BudgetScopeGlobal, nil, entities.BudgetPeriodMonthly, 100, entities.BudgetEnforcementBlock)
+		assert.NoError(t, err)
+		_, err = entities.NewBudget("全体", entities.BudgetScopeGlobal, &id, entities.BudgetPeriodMonthly, 100, entities.BudgetEnforcementBlock)
+		assert.ErrorIs(t, err, entities.ErrInvalidBudget)
+		_, err = entities.NewBudget("部署", entities.BudgetScopeDepartment, nil, entities.BudgetPeriodMonthly, 100, entities.BudgetEnforcementBlock)
+		assert.ErrorIs(t, err, entities.ErrInvalidBudget)
+	})
+
+	t.Run("不正な期間・金額・超過時の扱いはErrInvalidBudget", func(t *testing.T) {
+		_, err := entities.NewBudget("予算", entities.BudgetScopeGlobal, nil, "yearly", 100, entities.BudgetEnforcementBlock)
+		assert.ErrorIs(t, err, entities.ErrInvalidBudget)
+		_, err = entities.NewBudget("予算", entities.BudgetScopeGlobal, nil, entities.BudgetPeriodDaily, 0, entities.BudgetEnforcementBlock)
+		assert.ErrorIs(t, err, entities.ErrInvalidBudget)
+		_, err = entities.NewBudget("予算", entities.BudgetScopeGlobal, nil, entities.BudgetPeriodDaily, 100, "warn")
+		assert.ErrorIs(t, err, entities.ErrInvalidBudget)
+	})
+}
+
+func TestBudget_PeriodStart(t *testing.T) {
+	now := time.Date(2026, 10, 16, 15, 30, 0, 0, time.UTC) // 金曜日
+	cases := map[entities.BudgetPeriod][2]time.Time{
+		entities.BudgetPeriodDaily:   {time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+		entities.BudgetPeriodWeekly:  {time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		entities.BudgetPeriodMonthly: {time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for period, want := range cases {
+		b, err := entities.NewBudget("予算", entities.BudgetScopeGlobal, nil, period, 100, entities.BudgetEnforcementBlock)
+		require.NoError(t, err)
+		start := b.PeriodStart(now)
+		assert.Equal(t, want[0], start, period)
+		assert.Equal(t, want[1], b.PeriodEnd(start), period)
+	}
+}
+
+func TestBudget_Consume(t *testing.T) {
+	newUsage := func(consumed int64) *entities.BudgetUsage {
+		return &entities.BudgetUsage{BudgetID: uuid.New(), Consumed: consumed}
+	}
+
+	t.Run("blockは超える付与を常に拒否し、消化状況を変えない", func(t *testing.T) {
+		b, err := entities.NewBudget("予算", entities.BudgetScopeGlobal, nil, entities.BudgetPeriodMonthly, 1000, entities.BudgetEnforcementBlock)
+		require.NoError(t, err)
+		usage := newUsage(900)
+
+		_, err = b.Consume(usage, 101, true)
+		assert.ErrorIs(t, err, entities.ErrBudgetExceeded)
+		assert.Equal(t, int64(900), usage.Consumed)
+
+		_, err = b.Consume(usage, 100, false)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1000), usage.Consumed)
+	})
+
+	t.Run("overrideは許可があれば超えられる", func(t *testing.T) {
+		b, err := entities.NewBudget("予算", entities.BudgetScopeGlobal, nil, entities.BudgetPeriodMonthly, 1000, entities.BudgetEnforcementOverride)
+		require.NoError(t, err)
+		usage := newUsage(900)
+
+		_, err = b.Consume(usage, 200, false)
+		assert.ErrorIs(t, err, entities.ErrBudgetOverrideRequired)
+		_, err = b.Consume(usage, 200, true)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1100), usage.Consumed)
+	})
+
+	t.Run("新たに超えた最も高い閾値だけを返す", func(t *testing.T) {
+		b, err := entities.NewBudget("予算", entities.BudgetScopeGlobal, nil, entities.BudgetPeriodMonthly, 1000, entities.BudgetEnforcementBlock)
+		require.NoError(t, err)
+		usage := newUsage(0)
+
+		crossed, _ := b.Consume(usage, 799, false)
+		assert.Equal(t, 0, crossed)
+		crossed, _ = b.Consume(usage, 1, false)
+		assert.Equal(t, 80, crossed)
+		crossed, _ = b.Consume(usage, 100, false)
+		assert.Equal(t, 0, crossed)
+
+		skip := newUsage(0)
+		crossed, _ = b.Consume(skip, 1000, false)
+		assert.Equal(t, 100, crossed)
+		assert.Equal(t, 100, skip.AlertedPercent)
+	})
+}
+
+func TestBudget_Applies(t *testing.T) {
+	adminID := uuid.New()
+	deptID := uuid.New()
+
+	adminBudget, err := entities.NewBudget("管理者", entities.BudgetScopeAdmin, &adminID, entities.BudgetPeriodMonthly, 100, entities.BudgetEnforcementBlock)
+	require.NoError(t, err)
+	deptBudget, err := entities.NewBudget("部署", entities.BudgetScopeDepartment, &deptID, entities.BudgetPeriodMonthly, 100, entities.BudgetEnforcementBlock)
+	require.NoError(t, err)
+
+	assert.True(t, adminBudget.Applies(adminID, nil))
+	assert.False(t, adminBudget.Applies(uuid.New(), nil))
+	assert.True(t, deptBudget.Applies(uuid.New(), []uuid.UUID{uuid.New(), deptID}))
+	assert.False(t, deptBudget.Applies(uuid.New(), nil))
+}
@@ -0,0 +1,40 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCartItem(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("数量は1からCartMaxQuantityまで", func(t *testing.T) {
+		_, err := entities.NewCartItem(uuid.New(), uuid.New(), 0, now)
+		assert.ErrorIs(t, err, entities.ErrInvalidQuantity)
+		_, err = entities.NewCartItem(uuid.New(), uuid.New(), entities.CartMaxQuantity+1, now)
+		assert.ErrorIs(t, err, entities.ErrInvalidQuantity)
+
+		item, err := entities.NewCartItem(uuid.New(), uuid.New(), entities.CartMaxQuantity, now)
+		require.NoError(t, err)
+		assert.Equal(t, now, item.CreatedAt)
+	})
+
+	t.Run("AddQuantityは上限を超えたら数量を変えない", func(t *testing.T) {
+		item, err := entities.NewCartItem(uuid.New(), uuid.New(), 2, now)
+		require.NoError(t, err)
+
+		later := now.Add(time.Minute)
+		require.NoError(t, item.AddQuantity(3, later))
+		assert.Equal(t, 5, item.Quantity)
+		assert.Equal(t, later, item.UpdatedAt)
+
+		assert.ErrorIs(t, item.AddQuantity(entities.CartMaxQuantity, later), entities.ErrInvalidQuantity)
+		assert.ErrorIs(t, item.AddQuantity(0, later), entities.ErrInvalidQuantity)
+		assert.Equal(t, 5, item.Quantity)
+	})
+}
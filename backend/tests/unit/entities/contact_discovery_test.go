@@ -0,0 +1,76 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashContactIdentifier(t *testing.T) {
+	t.Run("前後の空白と大文字小文字を無視する", func(t *testing.T) {
+		assert.Equal(t, entities.HashContactIdentifier("alice@example.com"), entities.HashContactIdentifier("  Alice@Example.COM "))
+	})
+
+	t.Run("SHA-256の16進数を返す", func(t *testing.T) {
+		// echo -n "alice" | sha256sum
+		assert.Equal(t, "2bd806c97f0e00af1a1fc3328fa763a9269723c8db8fac4f93af71db186d6e90", entities.HashContactIdentifier("Alice"))
+	})
+}
+
+func TestNormalizeContactHashes(t *testing.T) {
+	valid := entities.HashContactIdentifier("alice@example.com")
+
+	t.Run("大文字を小文字にして重複を除く", func(t *testing.T) {
+		hashes, err := entities.NormalizeContactHashes([]string{strings.ToUpper(valid), valid, " " + valid})
+		require.NoError(t, err)
+		assert.Equal(t, []string{valid}, hashes)
+	})
+
+	t.Run("空・上限超過・不正な形式はエラー", func(t *testing.T) {
+		tooMany := make([]string, entities.ContactDiscoveryMaxHashes+1)
+		for i := range tooMany {
+			tooMany[i] = valid
+		}
+
+		cases := map[string][]string{
+			"空":      {},
+			"上限超過":   tooMany,
+			"長さが違う":  {valid[:63]},
+			"16進数以外": {strings.Repeat("z", 64)},
+			"生のメール":  {"alice@example.com"},
+		}
+		for name, input := range cases {
+			_, err := entities.NormalizeContactHashes(input)
+			assert.ErrorIs(t, err, entities.ErrInvalidContactHashes, name)
+		}
+	})
+}
+
+func TestMatchContactHashes(t *testing.T) {
+	user := &entities.User{Username: "alice", Email: "alice@example.com"}
+
+	t.Run("メールアドレスとユーザー名の両方が一致する場合はメールアドレスを優先", func(t *testing.T) {
+		hashes := map[string]bool{
+			entities.HashContactIdentifier("alice"):             true,
+			entities.HashContactIdentifier("alice@example.com"): true,
+		}
+		match, ok := entities.MatchContactHashes(user, hashes)
+		require.True(t, ok)
+		assert.Equal(t, entities.ContactMatchEmail, match.MatchedBy)
+		assert.Equal(t, entities.HashContactIdentifier("alice@example.com"), match.Hash)
+	})
+
+	t.Run("ユーザー名のみ一致", func(t *testing.T) {
+		match, ok := entities.MatchContactHashes(user, map[string]bool{entities.HashContactIdentifier("ALICE"): true})
+		require.True(t, ok)
+		assert.Equal(t, entities.ContactMatchUsername, match.MatchedBy)
+	})
+
+	t.Run("一致しない", func(t *testing.T) {
+		_, ok := entities.MatchContactHashes(user, map[string]bool{entities.HashContactIdentifier("bob"): true})
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,55 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeForModeration(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"小文字化", "BadWord", "badword"},
+		{"空白を除去", "b a d", "bad"},
+		{"記号を除去", "b.a-d!", "bad"},
+		{"日本語はそのまま", "死　ね", "死ね"},
+		{"空文字", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, entities.NormalizeForModeration(tt.input))
+		})
+	}
+}
+
+func TestMatchModerationTerms(t *testing.T) {
+	terms := []string{"badword", "死ね"}
+
+	t.Run("区切り文字を挟んでも検出する", func(t *testing.T) {
+		assert.Equal(t, []string{"badword"}, entities.MatchModerationTerms("you B-A-D w.o.r.d", terms))
+	})
+
+	t.Run("日本語の禁止語を検出する", func(t *testing.T) {
+		assert.Equal(t, []string{"死ね"}, entities.MatchModerationTerms("もう死 ね", terms))
+	})
+
+	t.Run("問題のないテキストは何も返さない", func(t *testing.T) {
+		assert.Empty(t, entities.MatchModerationTerms("ありがとう！", terms))
+	})
+}
+
+func TestContentViolation_MarkReviewed(t *testing.T) {
+	v := entities.NewContentViolation(uuid.New(), entities.ModerationFieldProductName, "badword", []string{"badword"})
+	assert.False(t, v.IsReviewed())
+
+	adminID := uuid.New()
+	v.MarkReviewed(adminID)
+	assert.True(t, v.IsReviewed())
+	assert.Equal(t, adminID, *v.ReviewedBy)
+}
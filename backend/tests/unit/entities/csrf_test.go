@@ -0,0 +1,66 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionCSRFRotation(t *testing.T) {
+	newSession := func(t *testing.T) *entities.Session {
+		t.Helper()
+		session, err := entities.NewSession(uuid.New(), "203.0.113.10", "Laptop")
+		require.NoError(t, err)
+		return session
+	}
+
+	t.Run("入れ替える前のトークンは猶予期間だけ受け付ける", func(t *testing.T) {
+		session := newSession(t)
+		policy := entities.CSRFPolicy{Rotation: entities.CSRFRotationPerRequest, TTL: time.Hour, Grace: time.Minute}
+		now := time.Now()
+		old := session.CSRFToken
+
+		require.NoError(t, session.RotateCSRF(now))
+		assert.NotEqual(t, old, session.CSRFToken)
+		assert.Equal(t, old, session.PreviousCSRFToken)
+
+		assert.NoError(t, session.ValidateCSRF(session.CSRFToken, policy, now))
+		assert.NoError(t, session.ValidateCSRF(old, policy, now.Add(30*time.Second)))
+		assert.ErrorIs(t, session.ValidateCSRF(old, policy, now.Add(time.Minute)), entities.ErrInvalidCSRFToken)
+		assert.ErrorIs(t, session.ValidateCSRF("", policy, now), entities.ErrInvalidCSRFToken, "前のトークンがなくても空のトークンは通さない")
+	})
+
+	t.Run("periodicでは有効期間を過ぎたトークンを期限切れにする", func(t *testing.T) {
+		session := newSession(t)
+		policy := entities.CSRFPolicy{Rotation: entities.CSRFRotationPeriodic, TTL: time.Hour, Grace: time.Minute}
+
+		assert.NoError(t, session.ValidateCSRF(session.CSRFToken, policy, session.CSRFIssuedAt.Add(59*time.Minute)))
+		assert.ErrorIs(t, session.ValidateCSRF(session.CSRFToken, policy, session.CSRFIssuedAt.Add(time.Hour)), entities.ErrCSRFTokenExpired)
+
+		expiresAt, ok := policy.ExpiresAt(session.CSRFIssuedAt)
+		assert.True(t, ok)
+		assert.Equal(t, session.CSRFIssuedAt.Add(time.Hour), expiresAt)
+	})
+
+	t.Run("off・per_requestではトークンに期限がない", func(t *testing.T) {
+		session := newSession(t)
+		for _, rotation := range []entities.CSRFRotationMode{entities.CSRFRotationOff, entities.CSRFRotationPerRequest} {
+			policy := entities.CSRFPolicy{Rotation: rotation, TTL: time.Hour}
+			assert.NoError(t, session.ValidateCSRF(session.CSRFToken, policy, session.CSRFIssuedAt.Add(2*time.Hour)), rotation)
+			_, ok := policy.ExpiresAt(session.CSRFIssuedAt)
+			assert.False(t, ok, rotation)
+		}
+	})
+
+	t.Run("期限切れのセッションのトークンは通さない", func(t *testing.T) {
+		session := newSession(t)
+		session.ExpiresAt = time.Now().Add(-time.Minute)
+
+		err := session.ValidateCSRF(session.CSRFToken, entities.CSRFPolicy{Rotation: entities.CSRFRotationOff}, time.Now())
+		assert.ErrorIs(t, err, entities.ErrSessionExpired)
+	})
+}
@@ -0,0 +1,63 @@
+package entities_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBonusReportPeriod(t *testing.T) {
+	t.Run("終了日を含む期間にする", func(t *testing.T) {
+		p, err := entities.NewBonusReportPeriod("2024-04-01", "2024-04-30")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), p.From)
+		assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), p.To)
+		assert.Equal(t, 30, p.Days())
+	})
+
+	for name, tc := range map[string][2]string{
+		"日付の形式が違う":   {"2024/04/01", "2024-04-30"},
+		"終了日が開始日より前": {"2024-04-30", "2024-04-01"},
+		"366日を超える":   {"2024-01-01", "2025-01-01"},
+		"未指定":        {"", ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := entities.NewBonusReportPeriod(tc[0], tc[1])
+			assert.True(t, errors.Is(err, entities.ErrInvalidBonusReportQuery))
+		})
+	}
+}
+
+func TestAttendanceMatrix(t *testing.T) {
+	period, err := entities.NewBonusReportMonth("2024-02")
+	require.NoError(t, err)
+	assert.Equal(t, 29, period.Days())
+
+	alice, bob := uuid.New(), uuid.New()
+	record := func(userID uuid.UUID, username string, day int, points int64) *entities.DailyBonusRecord {
+		return &entities.DailyBonusRecord{
+			DailyBonus: entities.DailyBonus{UserID: userID, BonusDate: time.Date(2024, 2, day, 0, 0, 0, 0, time.UTC), BonusPoints: points},
+			Username:   username,
+		}
+	}
+
+	m := entities.NewAttendanceMatrix(period)
+	m.Add(record(bob, "bob", 1, 5))
+	m.Add(record(alice, "alice", 1, 10))
+	m.Add(record(alice, "alice", 29, 3))
+	m.Add(&entities.DailyBonusRecord{DailyBonus: entities.DailyBonus{UserID: alice, BonusDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}})
+
+	require.Len(t, m.Rows, 2)
+	assert.Equal(t, "alice", m.Rows[0].Username, "ユーザー名の順")
+	assert.Equal(t, 2, m.Rows[0].AttendedDays)
+	assert.Equal(t, int64(13), m.Rows[0].TotalPoints)
+	assert.True(t, m.Rows[0].Attended[28])
+	assert.False(t, m.Rows[0].Attended[1])
+	assert.Equal(t, int64(3), m.Rows[0].Points[28])
+	assert.Equal(t, 1, m.Rows[1].AttendedDays)
+}
@@ -0,0 +1,100 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func bonusDay(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGetBonusDate(t *testing.T) {
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+	newYork := mustLoadLocation(t, "America/New_York")
+	santiago := mustLoadLocation(t, "America/Santiago")
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		loc      *time.Location
+		expected time.Time
+	}{
+		{"JST AM5:59は前日", time.Date(2026, 4, 10, 5, 59, 59, 0, tokyo), tokyo, bonusDay(2026, 4, 9)},
+		{"JST AM6:00は当日", time.Date(2026, 4, 10, 6, 0, 0, 0, tokyo), tokyo, bonusDay(2026, 4, 10)},
+		{"UTCで渡してもJSTの時計で判定する", time.Date(2026, 4, 9, 21, 0, 0, 0, time.UTC), tokyo, bonusDay(2026, 4, 10)},
+		{"月初のAM6:00前は前月末日", time.Date(2026, 3, 1, 3, 0, 0, 0, tokyo), tokyo, bonusDay(2026, 2, 28)},
+		{"元日のAM6:00前は前年の大晦日", time.Date(2027, 1, 1, 5, 0, 0, 0, tokyo), tokyo, bonusDay(2026, 12, 31)},
+
+		// 2026-03-08 2:00 に夏時間開始（EST→EDT）
+		{"夏時間開始日のAM5:59は前日", time.Date(2026, 3, 8, 9, 59, 0, 0, time.UTC), newYork, bonusDay(2026, 3, 7)},
+		{"夏時間開始日のAM6:00は当日", time.Date(2026, 3, 8, 10, 0, 0, 0, time.UTC), newYork, bonusDay(2026, 3, 8)},
+		// 2026-11-01 2:00 に夏時間終了（EDT→EST）。1:00台が2回ある
+		{"夏時間終了日の2回目の1:30は前日", time.Date(2026, 11, 1, 6, 30, 0, 0, time.UTC), newYork, bonusDay(2026, 10, 31)},
+		{"夏時間終了日のAM5:59は前日", time.Date(2026, 11, 1, 10, 59, 0, 0, time.UTC), newYork, bonusDay(2026, 10, 31)},
+		{"夏時間終了日のAM6:00は当日", time.Date(2026, 11, 1, 11, 0, 0, 0, time.UTC), newYork, bonusDay(2026, 11, 1)},
+
+		// 2024-09-08 0:00 に夏時間開始（-04→-03）。現地の0:00が存在しない
+		{"0:00が存在しない日のAM6:00は当日", time.Date(2024, 9, 8, 9, 0, 0, 0, time.UTC), santiago, bonusDay(2024, 9, 8)},
+		{"0:00が存在しない日の前日扱い", time.Date(2024, 9, 8, 8, 59, 0, 0, time.UTC), santiago, bonusDay(2024, 9, 7)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, entities.GetBonusDate(tt.at, tt.loc))
+		})
+	}
+
+	t.Run("同じ時刻でもタイムゾーンによって日付が変わる", func(t *testing.T) {
+		at := time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC) // JST 21:00 / EDT 8:00
+		assert.Equal(t, bonusDay(2026, 4, 10), entities.GetBonusDate(at, tokyo))
+		assert.Equal(t, bonusDay(2026, 4, 10), entities.GetBonusDate(at, newYork))
+
+		at = time.Date(2026, 4, 10, 23, 0, 0, 0, time.UTC) // JST 翌8:00 / EDT 19:00
+		assert.Equal(t, bonusDay(2026, 4, 11), entities.GetBonusDate(at, tokyo))
+		assert.Equal(t, bonusDay(2026, 4, 10), entities.GetBonusDate(at, newYork))
+	})
+}
+
+func TestLoadBonusLocation(t *testing.T) {
+	t.Run("空なら既定のタイムゾーン", func(t *testing.T) {
+		loc, err := entities.LoadBonusLocation("")
+		require.NoError(t, err)
+		assert.Equal(t, entities.DefaultBonusTimezone, loc.String())
+	})
+
+	t.Run("IANA名を読み込む", func(t *testing.T) {
+		loc, err := entities.LoadBonusLocation("America/New_York")
+		require.NoError(t, err)
+		assert.Equal(t, "America/New_York", loc.String())
+	})
+
+	t.Run("存在しない名前はエラー", func(t *testing.T) {
+		_, err := entities.LoadBonusLocation("Mars/Olympus")
+		assert.ErrorIs(t, err, entities.ErrInvalidTimezone)
+	})
+}
+
+func TestUser_SetTimezone(t *testing.T) {
+	user := &entities.User{}
+
+	require.NoError(t, user.SetTimezone("Europe/London"))
+	assert.Equal(t, "Europe/London", user.Timezone)
+
+	assert.ErrorIs(t, user.SetTimezone("Not/AZone"), entities.ErrInvalidTimezone)
+	assert.Equal(t, "Europe/London", user.Timezone)
+
+	require.NoError(t, user.SetTimezone(""))
+	assert.Empty(t, user.Timezone)
+}
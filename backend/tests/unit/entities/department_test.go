@@ -0,0 +1,95 @@
+package entities_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDepartment(t *testing.T) {
+	t.Run("名前の前後の空白を除いて作成する", func(t *testing.T) {
+		d, err := entities.NewDepartment("  開発部  ", nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "開発部", d.Name)
+		assert.Nil(t, d.ParentID)
+		assert.Nil(t, d.MonthlyGrantBudget)
+	})
+
+	t.Run("不正な値はErrInvalidDepartment", func(t *testing.T) {
+		negative := int64(-1)
+		long := make([]rune, entities.DepartmentNameMaxLength+1)
+		for i := range long {
+			long[i] = 'あ'
+		}
+
+		_, err := entities.NewDepartment(" ", nil, nil)
+		assert.ErrorIs(t, err, entities.ErrInvalidDepartment)
+		_, err = entities.NewDepartment(string(long), nil, nil)
+		assert.ErrorIs(t, err, entities.ErrInvalidDepartment)
+		_, err = entities.NewDepartment("開発部", nil, &negative)
+		assert.ErrorIs(t, err, entities.ErrInvalidDepartment)
+	})
+
+	t.Run("自分自身を親にはできない", func(t *testing.T) {
+		d, err := entities.NewDepartment("開発部", nil, nil)
+		require.NoError(t, err)
+		assert.ErrorIs(t, d.Update("開発部", &d.ID, nil), entities.ErrInvalidDepartment)
+	})
+}
+
+func TestDepartment_CheckGrantBudget(t *testing.T) {
+	budget := int64(1000)
+	d, err := entities.NewDepartment("開発部", nil, &budget)
+	require.NoError(t, err)
+
+	assert.NoError(t, d.CheckGrantBudget(600, 400))
+
+	err = d.CheckGrantBudget(600, 401)
+	assert.ErrorIs(t, err, entities.ErrDepartmentOverBudget)
+	var domainErr *entities.DomainError
+	require.True(t, errors.As(err, &domainErr))
+	assert.Equal(t, int64(400), domainErr.Params["remaining"])
+
+	// 予算なしは上限なし
+	unlimited, err := entities.NewDepartment("営業部", nil, nil)
+	require.NoError(t, err)
+	assert.NoError(t, unlimited.CheckGrantBudget(1_000_000, 1_000_000))
+}
+
+func TestDepartmentBudgetPeriodStart(t *testing.T) {
+	now := time.Date(2026, 3, 17, 15, 4, 5, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), entities.DepartmentBudgetPeriodStart(now))
+}
+
+func TestValidateDepartmentParent(t *testing.T) {
+	org, _ := entities.NewDepartment("本社", nil, nil)
+	dev, _ := entities.NewDepartment("開発部", &org.ID, nil)
+	team, _ := entities.NewDepartment("チームA", &dev.ID, nil)
+	sales, _ := entities.NewDepartment("営業部", &org.ID, nil)
+	all := []*entities.Department{org, dev, team, sales}
+
+	assert.NoError(t, entities.ValidateDepartmentParent(all, team.ID, &sales.ID))
+	assert.NoError(t, entities.ValidateDepartmentParent(all, dev.ID, nil))
+
+	// 配下の部署を親にすると循環する
+	assert.ErrorIs(t, entities.ValidateDepartmentParent(all, org.ID, &team.ID), entities.ErrInvalidDepartment)
+
+	missing := uuid.New()
+	assert.ErrorIs(t, entities.ValidateDepartmentParent(all, dev.ID, &missing), entities.ErrDepartmentNotFound)
+}
+
+func TestDepartmentSubtreeIDs(t *testing.T) {
+	org, _ := entities.NewDepartment("本社", nil, nil)
+	dev, _ := entities.NewDepartment("開発部", &org.ID, nil)
+	team, _ := entities.NewDepartment("チームA", &dev.ID, nil)
+	other, _ := entities.NewDepartment("子会社", nil, nil)
+	all := []*entities.Department{org, dev, team, other}
+
+	assert.ElementsMatch(t, []uuid.UUID{org.ID, dev.ID, team.ID}, entities.DepartmentSubtreeIDs(all, org.ID))
+	assert.Equal(t, []uuid.UUID{team.ID}, entities.DepartmentSubtreeIDs(all, team.ID))
+}
@@ -0,0 +1,105 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEarningRule(t *testing.T) {
+	now := time.Now()
+
+	t.Run("開始日時省略時は即時で、有効な状態で作成される", func(t *testing.T) {
+		r, err := entities.NewEarningRule(" 初めての送金 ", entities.EarningTriggerTransferSent, 100, 1, 0, true, 0, 0, time.Time{}, nil, uuid.New())
+		require.NoError(t, err)
+		assert.Equal(t, "初めての送金", r.Name)
+		assert.True(t, r.IsActive)
+		assert.False(t, r.StartsAt.IsZero())
+		assert.Equal(t, 1, r.PerUserLimit())
+	})
+
+	past := now.Add(-time.Hour)
+	tests := []struct {
+		name        string
+		ruleName    string
+		trigger     entities.EarningTrigger
+		points      int64
+		occurrence  int64
+		minAmount   int64
+		oncePerUser bool
+		maxPerUser  int
+		maxTotal    int
+		endsAt      *time.Time
+	}{
+		{"名前が空", " ", entities.EarningTriggerTransferSent, 10, 0, 0, false, 0, 0, nil},
+		{"きっかけが未定義", "ルール", "email_verified", 10, 0, 0, false, 0, 0, nil},
+		{"ポイントが0", "ルール", entities.EarningTriggerTransferSent, 0, 0, 0, false, 0, 0, nil},
+		{"ポイントが上限超え", "ルール", entities.EarningTriggerTransferSent, entities.EarningRuleMaxPoints + 1, 0, 0, false, 0, 0, nil},
+		{"回数が負", "ルール", entities.EarningTriggerTransferSent, 10, -1, 0, false, 0, 0, nil},
+		{"全体の上限が負", "ルール", entities.EarningTriggerTransferSent, 10, 0, 0, false, 0, -1, nil},
+		{"金額のないきっかけに最低金額", "ルール", entities.EarningTriggerProfileCompleted, 10, 0, 100, false, 0, 0, nil},
+		{"1人1回なのに1人あたりの上限が2以上", "ルール", entities.EarningTriggerFriendAdded, 10, 0, 0, true, 2, 0, nil},
+		{"終了日時が開始より前", "ルール", entities.EarningTriggerFriendAdded, 10, 0, 0, false, 0, 0, &past},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entities.NewEarningRule(tt.ruleName, tt.trigger, tt.points, tt.occurrence, tt.minAmount, tt.oncePerUser, tt.maxPerUser, tt.maxTotal, now, tt.endsAt, uuid.New())
+			assert.ErrorIs(t, err, entities.ErrInvalidEarningRule)
+		})
+	}
+}
+
+func TestEarningRule_Matches(t *testing.T) {
+	now := time.Now()
+	userID := uuid.New()
+	newRule := func(t *testing.T, occurrence, minAmount int64, endsAt *time.Time) *entities.EarningRule {
+		r, err := entities.NewEarningRule("送金キャンペーン", entities.EarningTriggerTransferSent, 10, occurrence, minAmount, false, 0, 0, now.Add(-time.Hour), endsAt, uuid.New())
+		require.NoError(t, err)
+		return r
+	}
+	event := func(trigger entities.EarningTrigger, amount int64, at time.Time) *entities.EarningEvent {
+		e := entities.NewEarningEvent(trigger, userID, amount, "tx-1")
+		e.OccurredAt = at
+		return e
+	}
+
+	t.Run("きっかけが一致すれば付与対象", func(t *testing.T) {
+		r := newRule(t, 0, 0, nil)
+		assert.True(t, r.Matches(event(entities.EarningTriggerTransferSent, 100, now), 0))
+		assert.False(t, r.Matches(event(entities.EarningTriggerFriendAdded, 0, now), 0))
+	})
+
+	t.Run("期間外や無効なルールは対象外", func(t *testing.T) {
+		endsAt := now.Add(time.Hour)
+		r := newRule(t, 0, 0, &endsAt)
+		assert.False(t, r.Matches(event(entities.EarningTriggerTransferSent, 100, now.Add(-2*time.Hour)), 0))
+		assert.False(t, r.Matches(event(entities.EarningTriggerTransferSent, 100, endsAt), 0))
+
+		r.IsActive = false
+		assert.False(t, r.Matches(event(entities.EarningTriggerTransferSent, 100, now), 0))
+	})
+
+	t.Run("最低金額と回数の条件", func(t *testing.T) {
+		r := newRule(t, 10, 500, nil)
+		assert.True(t, r.NeedsOccurrence())
+		assert.False(t, r.Matches(event(entities.EarningTriggerTransferSent, 499, now), 10))
+		assert.False(t, r.Matches(event(entities.EarningTriggerTransferSent, 500, now), 9))
+		assert.True(t, r.Matches(event(entities.EarningTriggerTransferSent, 500, now), 10))
+	})
+}
+
+func TestUser_ProfileCompleted(t *testing.T) {
+	user, err := entities.NewUser("profile_user", "profile@example.com", "hash", "表示名", "太郎", "田中")
+	require.NoError(t, err)
+	assert.False(t, user.ProfileCompleted())
+
+	user.AvatarType = entities.AvatarTypeUploaded
+	assert.True(t, user.ProfileCompleted())
+
+	user.DisplayName = ""
+	assert.False(t, user.ProfileCompleted())
+}
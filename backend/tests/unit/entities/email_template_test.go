@@ -0,0 +1,56 @@
+package entities_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailTemplate(t *testing.T) {
+	t.Run("組み込みの既定はすべて例の変数で描画できる", func(t *testing.T) {
+		for _, def := range entities.EmailTemplateDefinitions() {
+			email, err := def.Default().Render(def.Variables)
+			require.NoError(t, err, def.Key)
+			assert.NotEmpty(t, email.Subject, def.Key)
+			assert.NotEmpty(t, email.Text, def.Key)
+			assert.Empty(t, email.HTML, "組み込みの既定はテキストのみ")
+		}
+	})
+
+	t.Run("HTML本文の変数はエスケープし、件名は1行にする", func(t *testing.T) {
+		template, err := entities.NewEmailTemplate(entities.EmailTemplateUserInvitation, 1,
+			"ようこそ\n{{.Username}}さん", "<p>{{.Username}}</p>", "ユーザー名: {{.Username}}", nil)
+		require.NoError(t, err)
+
+		email, err := template.Render(map[string]string{"Username": "<b>taro</b>", "TemporaryPassword": "x"})
+		require.NoError(t, err)
+		assert.Equal(t, "ようこそ <b>taro</b>さん", email.Subject)
+		assert.Equal(t, "<p>&lt;b&gt;taro&lt;/b&gt;</p>", email.HTML)
+		assert.Equal(t, "ユーザー名: <b>taro</b>", email.Text)
+	})
+
+	t.Run("定義されていない変数や構文の誤りはErrInvalidEmailTemplate", func(t *testing.T) {
+		cases := map[string][3]string{
+			"未定義の変数":  {"件名", "", "{{.Password}}"},
+			"構文の誤り":   {"件名 {{.Token", "", "本文"},
+			"HTMLの誤り": {"件名", "<p>{{if .Token}}</p>", "本文"},
+			"件名が空":    {" ", "", "本文"},
+			"本文が空":    {"件名", "<p>本文</p>", ""},
+		}
+		for name, c := range cases {
+			_, err := entities.NewEmailTemplate(entities.EmailTemplateVerification, 1, c[0], c[1], c[2], nil)
+			require.ErrorIs(t, err, entities.ErrInvalidEmailTemplate, name)
+			var domainErr *entities.DomainError
+			require.True(t, errors.As(err, &domainErr))
+			assert.NotEmpty(t, domainErr.Params["reason"], name)
+		}
+	})
+
+	t.Run("知らない種類はErrEmailTemplateNotFound", func(t *testing.T) {
+		_, err := entities.NewEmailTemplate("unknown", 1, "件名", "", "本文", nil)
+		assert.ErrorIs(t, err, entities.ErrEmailTemplateNotFound)
+	})
+}
@@ -0,0 +1,49 @@
+package entities_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainError(t *testing.T) {
+	t.Run("メッセージは従来の英語文字列のまま", func(t *testing.T) {
+		assert.Equal(t, "insufficient balance", entities.ErrInsufficientBalance.Error())
+	})
+
+	t.Run("パラメータ付きでもerrors.Isで判定できる", func(t *testing.T) {
+		err := entities.ErrInsufficientBalance.WithParams(map[string]interface{}{"balance": 10, "required": 100})
+
+		assert.ErrorIs(t, err, entities.ErrInsufficientBalance)
+		assert.False(t, errors.Is(err, entities.ErrInvalidAmount))
+		assert.Nil(t, entities.ErrInsufficientBalance.Params, "元のエラーは変更されない")
+	})
+
+	t.Run("ラップされたエラーからコードを取り出せる", func(t *testing.T) {
+		wrapped := fmt.Errorf("transfer failed: %w", entities.ErrUserNotFound)
+
+		de, ok := entities.AsDomainError(wrapped)
+		require.True(t, ok)
+		assert.Equal(t, entities.ErrCodeUserNotFound, de.Code)
+	})
+
+	t.Run("通常のエラーはDomainErrorではない", func(t *testing.T) {
+		_, ok := entities.AsDomainError(errors.New("boom"))
+		assert.False(t, ok)
+	})
+
+	t.Run("CanTransferは残高と必要額をパラメータに含める", func(t *testing.T) {
+		user, err := entities.NewUser("poor", "poor@example.com", "hash", "Poor", "太郎", "田中")
+		require.NoError(t, err)
+
+		err = user.CanTransfer(500)
+		de, ok := entities.AsDomainError(err)
+		require.True(t, ok)
+		assert.Equal(t, entities.ErrCodeInsufficientBalance, de.Code)
+		assert.Equal(t, int64(500), de.Params["required"])
+	})
+}
@@ -0,0 +1,83 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEvent(t *testing.T) {
+	now := time.Now()
+
+	t.Run("QRコード用のコードを発行し、開始日時省略時は即時", func(t *testing.T) {
+		e, err := entities.NewEvent(" 社内勉強会 ", "", 50, 0, time.Time{}, now.Add(time.Hour), uuid.New())
+		require.NoError(t, err)
+		assert.Equal(t, "社内勉強会", e.Name)
+		assert.NotEmpty(t, e.Code)
+		assert.True(t, e.IsActive)
+		assert.Equal(t, e.Code, entities.ParseEventQRCodeData(e.QRCodeData()))
+		assert.NoError(t, e.CanCheckIn(now.Add(time.Minute)))
+	})
+
+	tests := []struct {
+		name     string
+		evName   string
+		points   int64
+		capacity int
+		startsAt time.Time
+		endsAt   time.Time
+	}{
+		{"名前が空", " ", 50, 0, now, now.Add(time.Hour)},
+		{"ポイントが0", "イベント", 0, 0, now, now.Add(time.Hour)},
+		{"ポイントが上限超え", "イベント", entities.EventMaxPoints + 1, 0, now, now.Add(time.Hour)},
+		{"定員が負", "イベント", 50, -1, now, now.Add(time.Hour)},
+		{"終了日時が未指定", "イベント", 50, 0, now, time.Time{}},
+		{"終了日時が開始より前", "イベント", 50, 0, now, now.Add(-time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entities.NewEvent(tt.evName, "", tt.points, tt.capacity, tt.startsAt, tt.endsAt, uuid.New())
+			assert.ErrorIs(t, err, entities.ErrInvalidEvent)
+		})
+	}
+}
+
+func TestEvent_CanCheckIn(t *testing.T) {
+	now := time.Now()
+	newEvent := func(t *testing.T, capacity int) *entities.Event {
+		e, err := entities.NewEvent("イベント", "", 50, capacity, now, now.Add(time.Hour), uuid.New())
+		require.NoError(t, err)
+		return e
+	}
+
+	t.Run("開催期間外は受け付けない", func(t *testing.T) {
+		e := newEvent(t, 0)
+		assert.ErrorIs(t, e.CanCheckIn(now.Add(-time.Minute)), entities.ErrEventNotOpen)
+		assert.ErrorIs(t, e.CanCheckIn(now.Add(time.Hour)), entities.ErrEventNotOpen)
+	})
+
+	t.Run("無効化したイベントは受け付けない", func(t *testing.T) {
+		e := newEvent(t, 0)
+		e.IsActive = false
+		assert.ErrorIs(t, e.CanCheckIn(now.Add(time.Minute)), entities.ErrEventNotOpen)
+	})
+
+	t.Run("定員に達したら受け付けない（0なら無制限）", func(t *testing.T) {
+		e := newEvent(t, 2)
+		e.AttendeeCount = 2
+		assert.ErrorIs(t, e.CanCheckIn(now.Add(time.Minute)), entities.ErrEventFull)
+
+		unlimited := newEvent(t, 0)
+		unlimited.AttendeeCount = 1000
+		assert.NoError(t, unlimited.CanCheckIn(now.Add(time.Minute)))
+	})
+}
+
+func TestParseEventQRCodeData(t *testing.T) {
+	assert.Equal(t, "abc", entities.ParseEventQRCodeData("event:abc"))
+	assert.Equal(t, "abc", entities.ParseEventQRCodeData(" abc "))
+}
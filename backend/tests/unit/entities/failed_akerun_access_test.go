@@ -0,0 +1,41 @@
+package entities_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailedAkerunAccessBackoff(t *testing.T) {
+	assert.Equal(t, 1*time.Minute, entities.FailedAkerunAccessBackoff(1))
+	assert.Equal(t, 2*time.Minute, entities.FailedAkerunAccessBackoff(2))
+	assert.Equal(t, 8*time.Minute, entities.FailedAkerunAccessBackoff(4))
+	assert.Equal(t, entities.FailedAkerunAccessMaxBackoff, entities.FailedAkerunAccessBackoff(20), "上限で頭打ち")
+}
+
+func TestFailedAkerunAccess_RecordFailure(t *testing.T) {
+	now := time.Date(2026, 2, 17, 17, 0, 0, 0, time.UTC)
+	access := entities.AccessRecord{ID: uuid.New(), UserName: "テスト太郎", AccessedAt: now.Add(-time.Minute)}
+
+	f := entities.NewFailedAkerunAccess(access, fmt.Errorf("db down"), now)
+	assert.Equal(t, entities.FailedAkerunAccessPending, f.Status)
+	assert.Equal(t, 1, f.Attempts)
+	assert.Equal(t, "db down", f.LastError)
+	assert.Equal(t, now.Add(time.Minute), f.NextRetryAt)
+	assert.Equal(t, access, f.Access())
+
+	for f.Attempts < entities.FailedAkerunAccessMaxAttempts {
+		f.RecordFailure(fmt.Errorf("db down"), now)
+	}
+	assert.True(t, f.IsDead())
+
+	assert.NoError(t, f.Requeue(now))
+	assert.Equal(t, entities.FailedAkerunAccessPending, f.Status)
+	assert.Equal(t, 0, f.Attempts)
+	assert.Equal(t, now, f.NextRetryAt)
+	assert.ErrorIs(t, f.Requeue(now), entities.ErrFailedAccessNotDead, "再試行待ちは再投入できない")
+}
@@ -0,0 +1,69 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFriendPin(t *testing.T) {
+	t.Run("自分自身はピン留めできない", func(t *testing.T) {
+		id := uuid.New()
+		_, err := entities.NewFriendPin(id, id)
+		assert.ErrorIs(t, err, entities.ErrPinTargetNotFriend)
+	})
+}
+
+func TestSummarizeTransferAmounts(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	base := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	summaries := entities.SummarizeTransferAmounts([]*entities.TransferAmountUsage{
+		{RecipientID: alice, Amount: 100, Count: 5, LastSentAt: base},
+		{RecipientID: alice, Amount: 300, Count: 1, LastSentAt: base.Add(48 * time.Hour)},
+		{RecipientID: alice, Amount: 50, Count: 2, LastSentAt: base.Add(24 * time.Hour)},
+		{RecipientID: bob, Amount: 10, Count: 2, LastSentAt: base},
+		{RecipientID: bob, Amount: 20, Count: 2, LastSentAt: base.Add(time.Hour)},
+	})
+
+	require.Len(t, summaries, 2)
+	a := summaries[alice]
+	assert.Equal(t, int64(300), a.LastAmount)
+	assert.Equal(t, int64(100), a.FrequentAmount)
+	assert.Equal(t, int64(8), a.TransferCount)
+	assert.Equal(t, base.Add(48*time.Hour), a.LastSentAt)
+	assert.Equal(t, int64(20), summaries[bob].FrequentAmount, "同じ回数なら最近送った金額")
+}
+
+func TestSortQuickSendEntries(t *testing.T) {
+	base := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time { v := base.Add(d); return &v }
+	entry := func(name string, pinnedAt *time.Time, lastSent *time.Time) *entities.QuickSendEntry {
+		e := &entities.QuickSendEntry{Friend: &entities.User{Username: name}, PinnedAt: pinnedAt}
+		if lastSent != nil {
+			e.Amounts = &entities.RememberedAmounts{LastSentAt: *lastSent}
+		}
+		return e
+	}
+
+	entries := []*entities.QuickSendEntry{
+		entry("zoe", nil, nil),
+		entry("yuki", nil, at(time.Hour)),
+		entry("amy", nil, nil),
+		entry("xavier", nil, at(2*time.Hour)),
+		entry("will", at(time.Minute), nil),
+		entry("vera", at(0), at(time.Hour)),
+	}
+	entities.SortQuickSendEntries(entries)
+
+	names := make([]string, len(entries))
+	for i, e := range entries {
+		names[i] = e.Friend.Username
+	}
+	assert.Equal(t, []string{"vera", "will", "xavier", "yuki", "amy", "zoe"}, names,
+		"ピン留めした順、最近送金した順、ユーザー名の順")
+}
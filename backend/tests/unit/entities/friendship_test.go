@@ -2,6 +2,7 @@ package entities_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/google/uuid"
@@ -135,3 +136,24 @@ func TestFriendship_IsAccepted(t *testing.T) {
 		assert.False(t, f.IsAccepted())
 	})
 }
+
+func TestFriendship_FriendsSince(t *testing.T) {
+	created := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("承認した日時（更新日時）を返す", func(t *testing.T) {
+		accepted := created.Add(time.Hour)
+		f := &entities.Friendship{CreatedAt: created, UpdatedAt: accepted}
+
+		assert.Equal(t, accepted, f.FriendsSince())
+	})
+
+	t.Run("更新日時がなければ作成日時", func(t *testing.T) {
+		f := &entities.Friendship{CreatedAt: created}
+
+		assert.Equal(t, created, f.FriendsSince())
+	})
+}
+
+func TestPointsExchanged_Total(t *testing.T) {
+	assert.Equal(t, int64(130), entities.PointsExchanged{Sent: 100, Received: 30}.Total())
+}
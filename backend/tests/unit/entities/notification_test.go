@@ -0,0 +1,41 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuietHours_Contains(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 10, 16, hour, minute, 0, 0, time.UTC)
+	}
+
+	t.Run("日付をまたぐ時間帯", func(t *testing.T) {
+		q := entities.QuietHours{Enabled: true, Start: 22 * 60, End: 7 * 60, Timezone: "UTC"}
+		assert.True(t, q.Contains(at(22, 0)))
+		assert.True(t, q.Contains(at(3, 0)))
+		assert.False(t, q.Contains(at(7, 0)))
+		assert.False(t, q.Contains(at(12, 0)))
+	})
+
+	t.Run("日付をまたがない時間帯", func(t *testing.T) {
+		q := entities.QuietHours{Enabled: true, Start: 13 * 60, End: 14 * 60, Timezone: "UTC"}
+		assert.True(t, q.Contains(at(13, 30)))
+		assert.False(t, q.Contains(at(14, 0)))
+	})
+
+	t.Run("ユーザーのタイムゾーンで判定する", func(t *testing.T) {
+		// UTC 14:00 は日本時間 23:00
+		q := entities.QuietHours{Enabled: true, Start: 22 * 60, End: 7 * 60, Timezone: "Asia/Tokyo"}
+		assert.True(t, q.Contains(at(14, 0)))
+		assert.False(t, q.Contains(at(3, 0)))
+	})
+
+	t.Run("無効なら常にfalse", func(t *testing.T) {
+		q := entities.QuietHours{Start: 0, End: 0, Timezone: "UTC"}
+		assert.False(t, q.Contains(at(3, 0)))
+	})
+}
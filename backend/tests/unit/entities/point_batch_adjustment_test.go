@@ -0,0 +1,103 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPointBatchExpiryChange(t *testing.T) {
+	now := time.Now()
+	adminID := uuid.New()
+	newBatch := func() *entities.PointBatch {
+		return entities.NewPointBatch(uuid.New(), 100, entities.PointBatchSourceTransfer, nil, now)
+	}
+
+	t.Run("変更前後の期限と理由を記録する", func(t *testing.T) {
+		batch := newBatch()
+		expiresAt := now.AddDate(1, 0, 0)
+		a, err := entities.NewPointBatchExpiryChange(batch, expiresAt, adminID, " 障害のお詫び ", now)
+		require.NoError(t, err)
+		assert.Equal(t, entities.PointBatchAdjustmentExpiryChanged, a.Action)
+		assert.Equal(t, batch.ExpiresAt, a.PreviousExpiresAt)
+		assert.Equal(t, expiresAt, *a.NewExpiresAt)
+		assert.Equal(t, "障害のお詫び", a.Reason)
+		assert.Equal(t, batch.UserID, a.UserID)
+	})
+
+	tests := []struct {
+		name      string
+		expiresAt func(b *entities.PointBatch) time.Time
+		reason    string
+	}{
+		{"過去の日時", func(*entities.PointBatch) time.Time { return now.Add(-time.Minute) }, "理由"},
+		{"上限を超える日時", func(*entities.PointBatch) time.Time { return now.AddDate(entities.PointBatchMaxExpiryYears, 0, 1) }, "理由"},
+		{"現在の期限と同じ", func(b *entities.PointBatch) time.Time { return b.ExpiresAt }, "理由"},
+		{"理由が空", func(*entities.PointBatch) time.Time { return now.AddDate(1, 0, 0) }, " "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch := newBatch()
+			_, err := entities.NewPointBatchExpiryChange(batch, tt.expiresAt(batch), adminID, tt.reason, now)
+			assert.ErrorIs(t, err, entities.ErrInvalidBatchAdjustment)
+		})
+	}
+
+	t.Run("失効済み・取り消し済みのバッチは変更できない", func(t *testing.T) {
+		expired := newBatch()
+		expired.ExpiredAt = &now
+		_, err := entities.NewPointBatchExpiryChange(expired, now.AddDate(1, 0, 0), adminID, "理由", now)
+		assert.ErrorIs(t, err, entities.ErrPointBatchNotAdjustable)
+
+		cancelled := newBatch()
+		cancelled.CancelledAt = &now
+		_, err = entities.NewPointBatchCancellation(cancelled, adminID, "理由", now)
+		assert.ErrorIs(t, err, entities.ErrPointBatchNotAdjustable)
+	})
+}
+
+func TestNewPointBatchCancellation(t *testing.T) {
+	now := time.Now()
+	batch := entities.NewPointBatch(uuid.New(), 100, entities.PointBatchSourceAdminGrant, nil, now)
+	batch.RemainingAmount = 40
+
+	a, err := entities.NewPointBatchCancellation(batch, uuid.New(), "誤付与", now)
+	require.NoError(t, err)
+	assert.Equal(t, entities.PointBatchAdjustmentCancelled, a.Action)
+	assert.Equal(t, int64(40), a.CancelledAmount)
+	assert.Nil(t, a.NewExpiresAt)
+	assert.Nil(t, a.TransactionID)
+}
+
+func TestPointBatch_Status(t *testing.T) {
+	now := time.Now()
+	newBatch := func() *entities.PointBatch {
+		return entities.NewPointBatch(uuid.New(), 100, entities.PointBatchSourceTransfer, nil, now)
+	}
+
+	active := newBatch()
+	assert.Equal(t, entities.PointBatchStatusActive, active.Status(now))
+	assert.False(t, active.HasFixedExpiry())
+
+	consumed := newBatch()
+	consumed.RemainingAmount = 0
+	assert.Equal(t, entities.PointBatchStatusConsumed, consumed.Status(now))
+
+	overdue := newBatch()
+	overdue.ExpiresAt = now.Add(-time.Minute)
+	assert.Equal(t, entities.PointBatchStatusExpired, overdue.Status(now))
+
+	restored := newBatch()
+	restored.ExpiredAt, restored.RestoredAt = &now, &now
+	assert.Equal(t, entities.PointBatchStatusRestored, restored.Status(now))
+
+	cancelled := newBatch()
+	cancelled.CancelledAt = &now
+	cancelled.ExpiryAdjustedAt = &now
+	assert.Equal(t, entities.PointBatchStatusCancelled, cancelled.Status(now))
+	assert.True(t, cancelled.HasFixedExpiry())
+}
@@ -0,0 +1,63 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ========================================
+// PlanPointConsumption Tests
+// ========================================
+
+func TestPlanPointConsumption(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	newBatch := func(remaining int64, expiresAt time.Time) *entities.PointBatch {
+		return &entities.PointBatch{
+			ID:              uuid.New(),
+			SourceType:      entities.PointBatchSourceAdminGrant,
+			RemainingAmount: remaining,
+			ExpiresAt:       expiresAt,
+		}
+	}
+
+	t.Run("古いバッチから順に消費する", func(t *testing.T) {
+		first := newBatch(100, now.AddDate(0, 1, 0))
+		second := newBatch(300, now.AddDate(0, 2, 0))
+
+		plan, uncovered := entities.PlanPointConsumption([]*entities.PointBatch{first, second}, 250, now)
+		require.Len(t, plan, 2)
+		assert.Equal(t, first.ID, plan[0].BatchID)
+		assert.Equal(t, int64(100), plan[0].Consumed)
+		assert.Equal(t, int64(0), plan[0].RemainingAfter)
+		assert.Equal(t, second.ID, plan[1].BatchID)
+		assert.Equal(t, int64(150), plan[1].Consumed)
+		assert.Equal(t, int64(150), plan[1].RemainingAfter)
+		assert.Equal(t, int64(0), uncovered)
+	})
+
+	t.Run("期限切れ・残量0のバッチは使わない", func(t *testing.T) {
+		expired := newBatch(100, now.Add(-time.Hour))
+		empty := newBatch(0, now.AddDate(0, 1, 0))
+		active := newBatch(100, now.AddDate(0, 1, 0))
+
+		plan, uncovered := entities.PlanPointConsumption([]*entities.PointBatch{expired, empty, active}, 50, now)
+		require.Len(t, plan, 1)
+		assert.Equal(t, active.ID, plan[0].BatchID)
+		assert.Equal(t, int64(0), uncovered)
+	})
+
+	t.Run("バッチで賄えない分はuncoveredになる", func(t *testing.T) {
+		plan, uncovered := entities.PlanPointConsumption([]*entities.PointBatch{newBatch(30, now.AddDate(0, 1, 0))}, 100, now)
+		require.Len(t, plan, 1)
+		assert.Equal(t, int64(70), uncovered)
+
+		plan, uncovered = entities.PlanPointConsumption(nil, 100, now)
+		assert.Empty(t, plan)
+		assert.Equal(t, int64(100), uncovered)
+	})
+}
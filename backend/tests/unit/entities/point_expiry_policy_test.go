@@ -0,0 +1,80 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// ========================================
+// ResolvePointExpiry Tests
+// ========================================
+
+func TestResolvePointExpiry(t *testing.T) {
+	createdAt := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	policies := []*entities.PointExpiryPolicy{
+		{SourceType: entities.PointBatchSourceAdminGrant, ValidityDays: 365},
+	}
+
+	t.Run("ポリシーがない種別は既定の月数", func(t *testing.T) {
+		got := entities.ResolvePointExpiry(entities.PointBatchSourceTransfer, createdAt, policies, nil)
+		assert.Equal(t, createdAt.AddDate(0, entities.POINT_EXPIRATION_MONTHS, 0), got)
+	})
+
+	t.Run("種別のポリシーを使う", func(t *testing.T) {
+		got := entities.ResolvePointExpiry(entities.PointBatchSourceAdminGrant, createdAt, policies, nil)
+		assert.Equal(t, createdAt.AddDate(0, 0, 365), got)
+	})
+
+	t.Run("ユーザー個別の設定が優先される", func(t *testing.T) {
+		override := &entities.UserPointExpiryOverride{ValidityDays: 30}
+		got := entities.ResolvePointExpiry(entities.PointBatchSourceAdminGrant, createdAt, policies, override)
+		assert.Equal(t, createdAt.AddDate(0, 0, 30), got)
+	})
+
+	t.Run("補填バッチにはポリシーを反映しない", func(t *testing.T) {
+		batch := entities.NewPointBatch(uuid.New(), 10, entities.PointBatchSourceExpiryRestore, nil, createdAt)
+		batch.ExpiresAt = createdAt.AddDate(0, 0, 7)
+		batch.ApplyExpiryPolicy(policies, &entities.UserPointExpiryOverride{ValidityDays: 400})
+		assert.Equal(t, createdAt.AddDate(0, 0, 7), batch.ExpiresAt)
+	})
+}
+
+// ========================================
+// PointBatch.CheckRestorable Tests
+// ========================================
+
+func TestPointBatch_CheckRestorable(t *testing.T) {
+	now := time.Date(2026, 4, 20, 0, 0, 0, 0, time.UTC)
+	expiredAt := now.AddDate(0, 0, -10)
+
+	newExpired := func() *entities.PointBatch {
+		b := entities.NewPointBatch(uuid.New(), 50, entities.PointBatchSourceDailyBonus, nil, expiredAt.AddDate(0, -3, 0))
+		b.RemainingAmount = 0
+		b.ExpiredAt = &expiredAt
+		b.ExpiredAmount = 50
+		return b
+	}
+
+	t.Run("猶予期間内なら取り消せる", func(t *testing.T) {
+		assert.NoError(t, newExpired().CheckRestorable(now, 30))
+	})
+
+	t.Run("猶予期間を過ぎると取り消せない", func(t *testing.T) {
+		assert.ErrorIs(t, newExpired().CheckRestorable(now, 7), entities.ErrPointBatchNotRestorable)
+	})
+
+	t.Run("取り消し済みは取り消せない", func(t *testing.T) {
+		b := newExpired()
+		b.RestoredAt = &now
+		assert.ErrorIs(t, b.CheckRestorable(now, 30), entities.ErrPointBatchRestored)
+	})
+
+	t.Run("失効していないバッチは取り消せない", func(t *testing.T) {
+		b := entities.NewPointBatch(uuid.New(), 50, entities.PointBatchSourceTransfer, nil, now)
+		assert.ErrorIs(t, b.CheckRestorable(now, 30), entities.ErrPointBatchNotRestorable)
+	})
+}
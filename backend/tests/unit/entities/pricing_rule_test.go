@@ -0,0 +1,105 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPricingRule(t *testing.T) {
+	productID := uuid.New()
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name         string
+		productID    *uuid.UUID
+		categoryCode string
+		discountType entities.PricingDiscountType
+		value        int64
+		role         entities.UserRole
+		minTier      entities.UserTier
+		maxQuantity  int
+		endsAt       *time.Time
+		wantErr      bool
+	}{
+		{"商品に割合割引", &productID, "", entities.PricingDiscountPercentage, 10, "", "", 0, nil, false},
+		{"カテゴリに固定割引・会員限定", nil, "drink", entities.PricingDiscountFixed, 30, entities.RoleUser, "", 2, nil, false},
+		{"対象なし", nil, "", entities.PricingDiscountFixed, 30, "", "", 0, nil, true},
+		{"商品とカテゴリの両方", &productID, "drink", entities.PricingDiscountFixed, 30, "", "", 0, nil, true},
+		{"100%を超える割引", &productID, "", entities.PricingDiscountPercentage, 101, "", "", 0, nil, true},
+		{"0ポイント引き", &productID, "", entities.PricingDiscountFixed, 0, "", "", 0, nil, true},
+		{"不明な役割", &productID, "", entities.PricingDiscountFixed, 10, "gold", "", 0, nil, true},
+		{"会員ランク限定", &productID, "", entities.PricingDiscountFixed, 10, "", entities.TierSilver, 0, nil, false},
+		{"不明なランク", &productID, "", entities.PricingDiscountFixed, 10, "", "platinum", 0, nil, true},
+		{"購入上限が負", &productID, "", entities.PricingDiscountFixed, 10, "", "", -1, nil, true},
+		{"終了が開始より前", &productID, "", entities.PricingDiscountFixed, 10, "", "", 0, &earlier, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entities.NewPricingRule("セール", tt.productID, tt.categoryCode, tt.discountType, tt.value, tt.role, tt.minTier, tt.maxQuantity, now, tt.endsAt, uuid.New())
+			if tt.wantErr {
+				assert.ErrorIs(t, err, entities.ErrInvalidPricingRule)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestResolvePrice(t *testing.T) {
+	now := time.Now()
+	product, err := entities.NewProduct("コーラ", "", "drink", 150, 10)
+	require.NoError(t, err)
+	user := &entities.User{Role: entities.RoleUser, Tier: entities.TierBronze}
+
+	newRule := func(productID *uuid.UUID, category string, discountType entities.PricingDiscountType, value int64, role entities.UserRole, startsAt time.Time) *entities.PricingRule {
+		r, err := entities.NewPricingRule("セール", productID, category, discountType, value, role, "", 0, startsAt, nil, uuid.New())
+		require.NoError(t, err)
+		return r
+	}
+
+	t.Run("割合割引は端数を切り捨てた分だけ引く", func(t *testing.T) {
+		rule := newRule(&product.ID, "", entities.PricingDiscountPercentage, 15, "", now.Add(-time.Minute))
+		quote := entities.ResolvePrice(product, []*entities.PricingRule{rule}, user, now)
+		assert.Equal(t, int64(128), quote.UnitPrice) // 150 - 22
+		assert.Equal(t, rule, quote.Rule)
+	})
+
+	t.Run("固定割引でも1ポイント未満にはならない", func(t *testing.T) {
+		rule := newRule(nil, "drink", entities.PricingDiscountFixed, 500, "", now.Add(-time.Minute))
+		quote := entities.ResolvePrice(product, []*entities.PricingRule{rule}, user, now)
+		assert.Equal(t, int64(1), quote.UnitPrice)
+	})
+
+	t.Run("期間前・別カテゴリ・対象外の役割・無効なルールは適用しない", func(t *testing.T) {
+		inactive := newRule(&product.ID, "", entities.PricingDiscountFixed, 10, "", now.Add(-time.Minute))
+		inactive.IsActive = false
+		rules := []*entities.PricingRule{
+			newRule(&product.ID, "", entities.PricingDiscountFixed, 10, "", now.Add(time.Hour)),
+			newRule(nil, "snack", entities.PricingDiscountFixed, 10, "", now.Add(-time.Minute)),
+			newRule(&product.ID, "", entities.PricingDiscountFixed, 10, entities.RoleAdmin, now.Add(-time.Minute)),
+			inactive,
+		}
+		quote := entities.ResolvePrice(product, rules, user, now)
+		assert.Equal(t, int64(150), quote.UnitPrice)
+		assert.Nil(t, quote.Rule)
+		assert.Nil(t, quote.TransactionMetadata())
+	})
+	t.Run("最低ランクを満たす会員だけに適用する", func(t *testing.T) {
+		rule := newRule(&product.ID, "", entities.PricingDiscountFixed, 50, "", now.Add(-time.Minute))
+		rule.MinTier = entities.TierSilver
+
+		quote := entities.ResolvePrice(product, []*entities.PricingRule{rule}, user, now)
+		assert.Nil(t, quote.Rule)
+
+		gold := &entities.User{Role: entities.RoleUser, Tier: entities.TierGold}
+		quote = entities.ResolvePrice(product, []*entities.PricingRule{rule}, gold, now)
+		assert.Equal(t, int64(100), quote.UnitPrice)
+	})
+}
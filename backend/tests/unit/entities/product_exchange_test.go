@@ -0,0 +1,62 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductExchange_Gift(t *testing.T) {
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	gift := func(t *testing.T) (*entities.ProductExchange, uuid.UUID) {
+		t.Helper()
+		exchange, err := entities.NewProductExchange(uuid.New(), uuid.New(), 1, 100, "")
+		require.NoError(t, err)
+		require.NoError(t, exchange.Complete(uuid.New()))
+		recipientID := uuid.New()
+		require.NoError(t, exchange.SendAsGift(recipientID))
+		return exchange, recipientID
+	}
+
+	t.Run("自分や空のIDには贈れない", func(t *testing.T) {
+		exchange, err := entities.NewProductExchange(uuid.New(), uuid.New(), 1, 100, "")
+		require.NoError(t, err)
+		assert.ErrorIs(t, exchange.SendAsGift(exchange.UserID), entities.ErrInvalidGift)
+		assert.ErrorIs(t, exchange.SendAsGift(uuid.Nil), entities.ErrInvalidGift)
+		assert.False(t, exchange.IsGift())
+		assert.Equal(t, exchange.UserID, exchange.Recipient())
+	})
+
+	t.Run("返事があるまで受け渡し・引き換えはできない", func(t *testing.T) {
+		exchange, recipientID := gift(t)
+		assert.Equal(t, recipientID, exchange.Recipient())
+		assert.ErrorIs(t, exchange.MarkAsDelivered(), entities.ErrGiftAwaitingResponse)
+
+		exchange.RedemptionCode = "ABCD-EFGH"
+		assert.False(t, exchange.CanRedeem())
+		require.NoError(t, exchange.AcceptGift(recipientID, now))
+		assert.True(t, exchange.CanRedeem())
+		assert.Equal(t, now, *exchange.GiftRespondedAt)
+	})
+
+	t.Run("辞退すると交換はキャンセルになる", func(t *testing.T) {
+		exchange, recipientID := gift(t)
+		require.NoError(t, exchange.DeclineGift(recipientID, now))
+		assert.Equal(t, entities.ExchangeStatusCancelled, exchange.Status)
+		assert.Equal(t, entities.GiftStatusDeclined, exchange.GiftStatus)
+
+		assert.ErrorIs(t, exchange.AcceptGift(recipientID, now), entities.ErrGiftAlreadyAnswered)
+	})
+
+	t.Run("返事できるのは贈られた人だけ", func(t *testing.T) {
+		exchange, _ := gift(t)
+		assert.ErrorIs(t, exchange.AcceptGift(exchange.UserID, now), entities.ErrGiftNotFound)
+		assert.ErrorIs(t, exchange.DeclineGift(uuid.New(), now), entities.ErrGiftNotFound)
+		assert.Equal(t, entities.GiftStatusPending, exchange.GiftStatus)
+	})
+}
@@ -0,0 +1,63 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ========================================
+// ReasonCode Tests
+// ========================================
+
+func TestNewReasonCode(t *testing.T) {
+	t.Run("コードは小文字に揃えて有効な状態で作成される", func(t *testing.T) {
+		r, err := entities.NewReasonCode(" Event_2026 ", " イベント ", "社内イベントの参加賞")
+		require.NoError(t, err)
+		assert.Equal(t, "event_2026", r.Code)
+		assert.Equal(t, "イベント", r.Label)
+		assert.True(t, r.IsActive)
+	})
+
+	cases := []struct {
+		name, code, label, description string
+	}{
+		{"空のコード", "", "ラベル", ""},
+		{"使えない文字を含むコード", "event-2026", "ラベル", ""},
+		{"長すぎるコード", strings.Repeat("a", 33), "ラベル", ""},
+		{"空のラベル", "event", "  ", ""},
+		{"長すぎるラベル", "event", strings.Repeat("あ", entities.ReasonCodeLabelMaxLength+1), ""},
+		{"長すぎる説明", "event", "ラベル", strings.Repeat("あ", entities.ReasonCodeDescriptionMaxLength+1)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := entities.NewReasonCode(tc.code, tc.label, tc.description)
+			assert.ErrorIs(t, err, entities.ErrInvalidReasonCode)
+		})
+	}
+}
+
+func TestTransaction_SetMemo(t *testing.T) {
+	t.Run("理由コードとタグを正規化して設定する", func(t *testing.T) {
+		tx := &entities.Transaction{}
+		require.NoError(t, tx.SetMemo(" REWARD ", "  プロジェクトA "))
+		assert.Equal(t, "reward", tx.ReasonCode)
+		assert.Equal(t, "プロジェクトA", tx.Tag)
+	})
+
+	t.Run("タグは省略できる", func(t *testing.T) {
+		tx := &entities.Transaction{}
+		require.NoError(t, tx.SetMemo("reward", ""))
+		assert.Empty(t, tx.Tag)
+	})
+
+	t.Run("不正なタグはエラー", func(t *testing.T) {
+		tx := &entities.Transaction{}
+		assert.ErrorIs(t, tx.SetMemo("reward", strings.Repeat("a", entities.TransactionTagMaxLength+1)), entities.ErrInvalidTransactionTag)
+		assert.ErrorIs(t, tx.SetMemo("reward", "line1\nline2"), entities.ErrInvalidTransactionTag)
+		assert.Empty(t, tx.ReasonCode)
+	})
+}
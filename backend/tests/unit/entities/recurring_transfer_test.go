@@ -0,0 +1,112 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRecurringTransfer(t *testing.T) {
+	t.Run("開始日時を省略すると即時開始", func(t *testing.T) {
+		rt, err := entities.NewRecurringTransfer(uuid.New(), uuid.New(), 500, entities.RecurringIntervalWeekly, " おこづかい ", time.Time{})
+		require.NoError(t, err)
+
+		assert.Equal(t, entities.RecurringTransferStatusActive, rt.Status)
+		assert.Equal(t, "おこづかい", rt.Message)
+		assert.True(t, rt.IsDue(time.Now()))
+	})
+
+	t.Run("不正な値はエラー", func(t *testing.T) {
+		sender := uuid.New()
+		_, err := entities.NewRecurringTransfer(sender, sender, 500, entities.RecurringIntervalWeekly, "", time.Time{})
+		assert.ErrorIs(t, err, entities.ErrSameUserTransfer)
+
+		_, err = entities.NewRecurringTransfer(sender, uuid.New(), 0, entities.RecurringIntervalWeekly, "", time.Time{})
+		assert.ErrorIs(t, err, entities.ErrInvalidAmount)
+
+		_, err = entities.NewRecurringTransfer(sender, uuid.New(), 500, "daily", "", time.Time{})
+		assert.ErrorIs(t, err, entities.ErrInvalidRecurring)
+
+		_, err = entities.NewRecurringTransfer(sender, uuid.New(), 500, entities.RecurringIntervalMonthly, "", time.Now().Add(-24*time.Hour))
+		assert.ErrorIs(t, err, entities.ErrInvalidRecurring)
+	})
+}
+
+func TestRecurringTransfer_MarkRun(t *testing.T) {
+	t.Run("毎週は7日後に進む", func(t *testing.T) {
+		start := time.Now().Add(time.Hour)
+		rt, err := entities.NewRecurringTransfer(uuid.New(), uuid.New(), 500, entities.RecurringIntervalWeekly, "", start)
+		require.NoError(t, err)
+		firstKey := rt.IdempotencyKey()
+
+		rt.MarkRun(start)
+
+		assert.Equal(t, start.AddDate(0, 0, 7), rt.NextRunAt)
+		assert.Equal(t, 1, rt.RunCount)
+		assert.NotEqual(t, firstKey, rt.IdempotencyKey(), "周期ごとに冪等性キーが変わる")
+	})
+
+	t.Run("毎月は開始日に合わせ、存在しない日は月末にする", func(t *testing.T) {
+		start := time.Date(2030, 1, 31, 9, 0, 0, 0, time.UTC)
+		rt, err := entities.NewRecurringTransfer(uuid.New(), uuid.New(), 500, entities.RecurringIntervalMonthly, "", start)
+		require.NoError(t, err)
+		assert.Equal(t, "2030-01", rt.PeriodKey())
+
+		rt.MarkRun(start)
+		assert.Equal(t, time.Date(2030, 2, 28, 9, 0, 0, 0, time.UTC), rt.NextRunAt)
+
+		rt.MarkRun(rt.NextRunAt)
+		assert.Equal(t, time.Date(2030, 3, 31, 9, 0, 0, 0, time.UTC), rt.NextRunAt)
+	})
+
+	t.Run("複数周期を逃した場合は次の周期から再開", func(t *testing.T) {
+		start := time.Now().Add(time.Minute)
+		rt, err := entities.NewRecurringTransfer(uuid.New(), uuid.New(), 500, entities.RecurringIntervalWeekly, "", start)
+		require.NoError(t, err)
+
+		late := start.AddDate(0, 0, 20)
+		rt.MarkRun(late)
+
+		assert.Equal(t, start.AddDate(0, 0, 21), rt.NextRunAt)
+		assert.Equal(t, 1, rt.RunCount)
+	})
+}
+
+func TestRecurringTransfer_MarkFailed(t *testing.T) {
+	rt, _ := entities.NewRecurringTransfer(uuid.New(), uuid.New(), 500, entities.RecurringIntervalWeekly, "", time.Time{})
+	now := time.Now()
+
+	for i := 1; i < entities.RecurringTransferMaxFailures; i++ {
+		rt.MarkFailed("temporary error", now)
+		assert.True(t, rt.IsActive())
+	}
+	rt.MarkFailed("temporary error", now)
+
+	assert.Equal(t, entities.RecurringTransferStatusStopped, rt.Status)
+	assert.Equal(t, "temporary error", rt.StopReason)
+	assert.False(t, rt.IsDue(now))
+}
+
+func TestRecurringTransfer_Cancel(t *testing.T) {
+	t.Run("受取人も解約できる", func(t *testing.T) {
+		sender, receiver := uuid.New(), uuid.New()
+		rt, _ := entities.NewRecurringTransfer(sender, receiver, 500, entities.RecurringIntervalMonthly, "", time.Time{})
+
+		require.NoError(t, rt.Cancel(receiver))
+		assert.Equal(t, entities.RecurringTransferStatusCancelled, rt.Status)
+		assert.Equal(t, receiver, *rt.CancelledBy)
+
+		assert.ErrorIs(t, rt.Cancel(sender), entities.ErrRecurringNotActive)
+	})
+
+	t.Run("関係のないユーザーは解約できない", func(t *testing.T) {
+		rt, _ := entities.NewRecurringTransfer(uuid.New(), uuid.New(), 500, entities.RecurringIntervalMonthly, "", time.Time{})
+
+		assert.ErrorIs(t, rt.Cancel(uuid.New()), entities.ErrRecurringNotFound)
+		assert.True(t, rt.IsActive())
+	})
+}
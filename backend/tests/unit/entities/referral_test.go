@@ -0,0 +1,52 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeReferralCode(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"そのまま", "ABCD2345", "ABCD2345"},
+		{"小文字・ハイフン・空白を許容", " abcd-2345 ", "ABCD2345"},
+		{"文字数が違う", "ABCD234", ""},
+		{"使わない文字を含む", "ABCD234O", ""},
+		{"空文字", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, entities.NormalizeReferralCode(tt.input))
+		})
+	}
+}
+
+func TestNewReferralCode(t *testing.T) {
+	userID := uuid.New()
+	code, err := entities.NewReferralCode(userID)
+	require.NoError(t, err)
+	assert.Equal(t, userID, code.UserID)
+	assert.Equal(t, code.Code, entities.NormalizeReferralCode(code.Code))
+}
+
+func TestReferral_Reject(t *testing.T) {
+	referral := entities.NewReferral(uuid.New(), uuid.New(), "ABCD2345", "203.0.113.10")
+	assert.Equal(t, entities.ReferralStatusPending, referral.Status)
+
+	referral.Reject(entities.ReferralRejectIPLimit)
+	assert.Equal(t, entities.ReferralStatusRejected, referral.Status)
+	assert.Equal(t, entities.ReferralRejectIPLimit, referral.RejectReason)
+}
+
+func TestReferralReport_ConversionRate(t *testing.T) {
+	assert.Equal(t, 0.0, (&entities.ReferralReport{}).ConversionRate())
+	assert.InDelta(t, 25.0, (&entities.ReferralReport{Invited: 8, Rewarded: 2}).ConversionRate(), 0.0001)
+}
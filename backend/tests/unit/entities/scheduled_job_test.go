@@ -0,0 +1,90 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var jst = time.FixedZone("JST", 9*60*60)
+
+func TestParseCronSchedule_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+	} {
+		_, err := entities.ParseCronSchedule(expr)
+		assert.ErrorIs(t, err, entities.ErrInvalidCronSchedule, expr)
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	// 2026-10-16は金曜日
+	base := time.Date(2026, 10, 16, 12, 34, 56, 0, jst)
+
+	tests := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{"毎分は次の分の0秒", "* * * * *", time.Date(2026, 10, 16, 12, 35, 0, 0, jst)},
+		{"毎時15分", "15 * * * *", time.Date(2026, 10, 16, 13, 15, 0, 0, jst)},
+		{"10分間隔", "*/10 * * * *", time.Date(2026, 10, 16, 12, 40, 0, 0, jst)},
+		{"毎日3時30分は翌日", "30 3 * * *", time.Date(2026, 10, 17, 3, 30, 0, 0, jst)},
+		{"範囲と間隔", "0 9-17/4 * * *", time.Date(2026, 10, 16, 13, 0, 0, 0, jst)},
+		{"カンマ区切り", "0 6,18 * * *", time.Date(2026, 10, 16, 18, 0, 0, 0, jst)},
+		{"月初は翌月", "0 0 1 * *", time.Date(2026, 11, 1, 0, 0, 0, 0, jst)},
+		{"曜日指定（月曜）", "0 9 * * 1", time.Date(2026, 10, 19, 9, 0, 0, 0, jst)},
+		{"7は日曜日", "0 9 * * 7", time.Date(2026, 10, 18, 9, 0, 0, 0, jst)},
+		{"年をまたぐ", "0 0 1 1 *", time.Date(2027, 1, 1, 0, 0, 0, 0, jst)},
+		{"日と曜日はどちらかに一致すればよい", "0 0 20 * 6", time.Date(2026, 10, 17, 0, 0, 0, 0, jst)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := entities.ParseCronSchedule(tt.expr)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(s.Next(base)), "got %s", s.Next(base))
+		})
+	}
+
+	t.Run("時刻はJSTで解釈する", func(t *testing.T) {
+		s, err := entities.ParseCronSchedule("0 9 * * *")
+		require.NoError(t, err)
+		next := s.Next(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+		assert.True(t, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC).Equal(next), "got %s", next)
+	})
+
+	t.Run("実行日が来ない式はゼロ値", func(t *testing.T) {
+		s, err := entities.ParseCronSchedule("0 0 30 2 *")
+		require.NoError(t, err)
+		assert.True(t, s.Next(base).IsZero())
+	})
+
+	t.Run("空白は正規化される", func(t *testing.T) {
+		s, err := entities.ParseCronSchedule("  */5   *  * * * ")
+		require.NoError(t, err)
+		assert.Equal(t, "*/5 * * * *", s.String())
+	})
+}
+
+func TestScheduledJob_IsRunning(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, jst)
+	until := now.Add(time.Minute)
+	past := now.Add(-time.Minute)
+
+	assert.False(t, (&entities.ScheduledJob{}).IsRunning(now))
+	assert.True(t, (&entities.ScheduledJob{LockedBy: "host:1", LockedUntil: &until}).IsRunning(now))
+	assert.False(t, (&entities.ScheduledJob{LockedBy: "host:1", LockedUntil: &past}).IsRunning(now), "期限切れのロックは実行中とみなさない")
+}
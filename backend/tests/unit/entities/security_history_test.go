@@ -0,0 +1,115 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// ========================================
+// MaskIPAddress Tests
+// ========================================
+
+func TestMaskIPAddress(t *testing.T) {
+	cases := []struct {
+		name string
+		ip   string
+		want string
+	}{
+		{"IPv4は最後のオクテットを伏せる", "192.168.1.23", "192.168.1.***"},
+		{"IPv6は下位64ビットを伏せる", "2001:db8:85a3:1:8a2e:370:7334:1", "2001:db8:85a3:1::***"},
+		{"解釈できない値はすべて伏せる", "unknown", "***"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, entities.MaskIPAddress(tc.ip))
+		})
+	}
+}
+
+// ========================================
+// MergeSecurityHistory Tests
+// ========================================
+
+func TestMergeSecurityHistory(t *testing.T) {
+	base := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	entries := []*entities.SecurityHistoryEntry{
+		{Type: entities.SecurityHistoryUsernameChange, ChangedAt: base.Add(3 * time.Hour)},
+		{Type: entities.SecurityHistoryUsernameChange, ChangedAt: base.Add(1 * time.Hour)},
+		{Type: entities.SecurityHistoryPasswordChange, ChangedAt: base.Add(2 * time.Hour)},
+		{Type: entities.SecurityHistoryPasswordChange, ChangedAt: base},
+	}
+
+	t.Run("種別をまたいで新しい順に並べる", func(t *testing.T) {
+		got := entities.MergeSecurityHistory(append([]*entities.SecurityHistoryEntry{}, entries...), 0, 10)
+		assert.Len(t, got, 4)
+		for i := 1; i < len(got); i++ {
+			assert.True(t, got[i-1].ChangedAt.After(got[i].ChangedAt))
+		}
+	})
+
+	t.Run("offsetとlimitでページングする", func(t *testing.T) {
+		got := entities.MergeSecurityHistory(append([]*entities.SecurityHistoryEntry{}, entries...), 1, 2)
+		assert.Len(t, got, 2)
+		assert.Equal(t, base.Add(2*time.Hour), got[0].ChangedAt)
+		assert.Equal(t, base.Add(1*time.Hour), got[1].ChangedAt)
+	})
+
+	t.Run("offsetが件数を超える場合は空", func(t *testing.T) {
+		got := entities.MergeSecurityHistory(append([]*entities.SecurityHistoryEntry{}, entries...), 10, 2)
+		assert.Empty(t, got)
+	})
+}
+
+func TestSecurityHistoryEntry_Masked(t *testing.T) {
+	ip := "203.0.113.9"
+	entry := entities.NewSecurityHistoryFromPasswordChange(entities.NewPasswordChangeHistory(uuid.New(), &ip, nil))
+
+	masked := entry.Masked()
+	assert.Equal(t, "203.0.113.***", *masked.IPAddress)
+	// 元の履歴は変更しない
+	assert.Equal(t, "203.0.113.9", *entry.IPAddress)
+}
+
+// ========================================
+// NewLoginHistory Tests
+// ========================================
+
+func TestNewLoginHistory(t *testing.T) {
+	base := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	attempt := func(at time.Duration, success bool, reason string, newDevice bool) *entities.LoginAttempt {
+		a := entities.NewLoginAttempt(&userID, "me", "203.0.113.9", "Mozilla/5.0", "JP", success, reason)
+		a.NewDevice = newDevice
+		a.CreatedAt = base.Add(at)
+		return a
+	}
+
+	history := entities.NewLoginHistory([]*entities.LoginAttempt{
+		attempt(5*time.Hour, true, "", true),
+		attempt(4*time.Hour, false, entities.LoginFailureAccountLocked, false),
+		attempt(3*time.Hour+40*time.Minute, false, entities.LoginFailureInvalidCredentials, false),
+		attempt(3*time.Hour+20*time.Minute, false, entities.LoginFailureInvalidCredentials, false),
+		attempt(time.Hour, false, entities.LoginFailureInvalidCredentials, false),
+		attempt(0, true, "", false),
+	})
+
+	want := []entities.LoginAnomaly{
+		entities.LoginAnomalyNewDevice,
+		entities.LoginAnomalyAccountLocked,
+		entities.LoginAnomalyRepeatedFailures,
+		entities.LoginAnomalyRepeatedFailures,
+		entities.LoginAnomalyNone, // 1時間以内に続いた失敗がない
+		entities.LoginAnomalyNone,
+	}
+	for i, entry := range history {
+		assert.Equal(t, want[i], entry.Anomaly, "entry %d", i)
+	}
+
+	masked := history[0].Masked()
+	assert.Equal(t, "203.0.113.***", masked.IPAddress)
+	assert.Equal(t, "203.0.113.9", history[0].IPAddress)
+}
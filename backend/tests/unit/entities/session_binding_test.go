@@ -0,0 +1,64 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionBindingPolicy(t *testing.T) {
+	policy := entities.SessionBindingPolicy{Mode: entities.SessionBindingLenient, IPv4PrefixLen: 24, IPv6PrefixLen: 64}
+	const chrome = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) Chrome/120.0"
+	const firefox = "Mozilla/5.0 (X11; Linux x86_64; rv:121.0) Firefox/121.0"
+
+	t.Run("同じサブネット内のアドレスの変化は同じ接続元とみなす", func(t *testing.T) {
+		assert.Equal(t, policy.Fingerprint("203.0.113.10", chrome), policy.Fingerprint("203.0.113.200", chrome))
+		assert.Equal(t, policy.Fingerprint("2001:db8:1:2::1", chrome), policy.Fingerprint("2001:db8:1:2:ffff::9", chrome))
+		assert.NotEqual(t, policy.Fingerprint("203.0.113.10", chrome), policy.Fingerprint("203.0.114.10", chrome))
+	})
+
+	t.Run("指紋は保存した文字列から読み込める", func(t *testing.T) {
+		f := policy.Fingerprint("203.0.113.10", chrome)
+		parsed, ok := entities.ParseSessionFingerprint(f.String())
+		assert.True(t, ok)
+		assert.Equal(t, f, parsed)
+
+		_, ok = entities.ParseSessionFingerprint("")
+		assert.False(t, ok, "未固定のセッション")
+	})
+
+	t.Run("強さごとに再ログインを求める変化が異なる", func(t *testing.T) {
+		bound := policy.Fingerprint("203.0.113.10", chrome)
+		uaOnly := policy.Fingerprint("203.0.113.10", firefox)
+		subnetOnly := policy.Fingerprint("198.51.100.7", chrome)
+		both := policy.Fingerprint("198.51.100.7", firefox)
+
+		tests := []struct {
+			mode    entities.SessionBindingMode
+			current entities.SessionFingerprint
+			change  entities.SessionFingerprintChange
+			reject  bool
+		}{
+			{entities.SessionBindingMonitor, both, entities.SessionFingerprintChangedBoth, false},
+			{entities.SessionBindingLenient, uaOnly, entities.SessionFingerprintChangedUserAgent, false},
+			{entities.SessionBindingLenient, subnetOnly, entities.SessionFingerprintChangedSubnet, false},
+			{entities.SessionBindingLenient, both, entities.SessionFingerprintChangedBoth, true},
+			{entities.SessionBindingStrict, subnetOnly, entities.SessionFingerprintChangedSubnet, true},
+			{entities.SessionBindingStrict, bound, entities.SessionFingerprintUnchanged, false},
+		}
+		for _, tt := range tests {
+			p := policy
+			p.Mode = tt.mode
+			change, reject := p.Evaluate(bound, tt.current)
+			assert.Equal(t, tt.change, change, tt.mode)
+			assert.Equal(t, tt.reject, reject, tt.mode)
+		}
+	})
+
+	t.Run("offと未設定では固定しない", func(t *testing.T) {
+		assert.False(t, entities.SessionBindingPolicy{Mode: entities.SessionBindingOff}.Enabled())
+		assert.False(t, entities.SessionBindingPolicy{}.Enabled())
+		assert.True(t, policy.Enabled())
+	})
+}
@@ -0,0 +1,51 @@
+package entities_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShippingAddress(t *testing.T) {
+	valid := func() entities.ShippingAddressInput {
+		return entities.ShippingAddressInput{
+			RecipientName: " 山田太郎 ", PostalCode: "1000001", Prefecture: "東京都",
+			City: "千代田区", Line1: "千代田1-1", Line2: "201号室", Phone: "090-1234-5678",
+		}
+	}
+
+	t.Run("郵便番号と電話番号を正規化する", func(t *testing.T) {
+		address, err := entities.NewShippingAddress(uuid.New(), valid())
+		require.NoError(t, err)
+		assert.Equal(t, "山田太郎", address.RecipientName)
+		assert.Equal(t, "100-0001", address.PostalCode)
+		assert.Equal(t, "09012345678", address.Phone)
+		assert.False(t, address.IsDefault)
+		assert.Equal(t, "〒100-0001 東京都千代田区千代田1-1 201号室 山田太郎様 TEL:09012345678", address.Format())
+	})
+
+	t.Run("不正な項目はErrInvalidShippingAddressで項目名を返す", func(t *testing.T) {
+		cases := map[string]func(in *entities.ShippingAddressInput){
+			"postal_code":    func(in *entities.ShippingAddressInput) { in.PostalCode = "100-001" },
+			"prefecture":     func(in *entities.ShippingAddressInput) { in.Prefecture = "東京" },
+			"phone":          func(in *entities.ShippingAddressInput) { in.Phone = "12345" },
+			"recipient_name": func(in *entities.ShippingAddressInput) { in.RecipientName = "  " },
+			"line1":          func(in *entities.ShippingAddressInput) { in.Line1 = "" },
+			"line2":          func(in *entities.ShippingAddressInput) { in.Line2 = strings.Repeat("あ", 101) },
+		}
+		for field, mutate := range cases {
+			input := valid()
+			mutate(&input)
+			_, err := entities.NewShippingAddress(uuid.New(), input)
+			require.ErrorIs(t, err, entities.ErrInvalidShippingAddress, field)
+			var domainErr *entities.DomainError
+			require.True(t, errors.As(err, &domainErr))
+			assert.Equal(t, field, domainErr.Params["field"])
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSplitRequest(t *testing.T) {
+	requester := uuid.New()
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	participants := func(ids ...uuid.UUID) []entities.SplitShare {
+		list := make([]entities.SplitShare, len(ids))
+		for i, id := range ids {
+			list[i] = entities.SplitShare{UserID: id}
+		}
+		return list
+	}
+	amounts := func(shares []entities.SplitShare) []int64 {
+		list := make([]int64, len(shares))
+		for i, s := range shares {
+			list[i] = s.Amount
+		}
+		return list
+	}
+
+	t.Run("均等で割り切れない分は先頭の参加者から1ポイントずつ多く払う", func(t *testing.T) {
+		split, shares, err := entities.NewSplitRequest(requester, 1000, entities.SplitTypeEqual, participants(a, b, c), false, "dinner", "key")
+		require.NoError(t, err)
+		assert.Equal(t, []int64{334, 333, 333}, amounts(shares))
+		assert.Equal(t, int64(1000), split.TotalAmount)
+	})
+
+	t.Run("作成者も負担する場合は端数を作成者が負担する", func(t *testing.T) {
+		_, shares, err := entities.NewSplitRequest(requester, 1000, entities.SplitTypeEqual, participants(a, b, c), true, "", "key")
+		require.NoError(t, err)
+		assert.Equal(t, []int64{250, 250, 250}, amounts(shares))
+	})
+
+	t.Run("指定の金額は合計額と一致すること", func(t *testing.T) {
+		custom := []entities.SplitShare{{UserID: a, Amount: 700}, {UserID: b, Amount: 300}}
+		_, shares, err := entities.NewSplitRequest(requester, 1000, entities.SplitTypeCustom, custom, false, "", "key")
+		require.NoError(t, err)
+		assert.Equal(t, []int64{700, 300}, amounts(shares))
+
+		_, _, err = entities.NewSplitRequest(requester, 1200, entities.SplitTypeCustom, custom, false, "", "key")
+		assert.ErrorIs(t, err, entities.ErrInvalidSplitRequest, "不足分は作成者も負担する場合のみ")
+		_, _, err = entities.NewSplitRequest(requester, 1200, entities.SplitTypeCustom, custom, true, "", "key")
+		assert.NoError(t, err)
+	})
+
+	tests := []struct {
+		name         string
+		total        int64
+		splitType    entities.SplitType
+		participants []entities.SplitShare
+	}{
+		{"参加者なし", 1000, entities.SplitTypeEqual, nil},
+		{"作成者自身を含む", 1000, entities.SplitTypeEqual, participants(a, requester)},
+		{"同じ参加者が重複", 1000, entities.SplitTypeEqual, participants(a, a)},
+		{"1人あたり0ポイント", 2, entities.SplitTypeEqual, participants(a, b, c)},
+		{"合計額が0", 0, entities.SplitTypeEqual, participants(a)},
+		{"不明な分け方", 1000, "ratio", participants(a)},
+		{"指定の金額が0", 1000, entities.SplitTypeCustom, []entities.SplitShare{{UserID: a, Amount: 1000}, {UserID: b}}},
+		{"指定の金額が合計額を超える", 1000, entities.SplitTypeCustom, []entities.SplitShare{{UserID: a, Amount: 1001}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := entities.NewSplitRequest(requester, tt.total, tt.splitType, tt.participants, false, "", "key")
+			assert.ErrorIs(t, err, entities.ErrInvalidSplitRequest)
+		})
+	}
+
+	t.Run("参加者は上限まで", func(t *testing.T) {
+		many := make([]uuid.UUID, entities.SplitRequestMaxParticipants+1)
+		for i := range many {
+			many[i] = uuid.New()
+		}
+		_, _, err := entities.NewSplitRequest(requester, 10000, entities.SplitTypeEqual, participants(many...), false, "", "key")
+		assert.ErrorIs(t, err, entities.ErrInvalidSplitRequest)
+	})
+}
+
+func TestSplitRequest_ShareRequest(t *testing.T) {
+	requester, payer := uuid.New(), uuid.New()
+	split, shares, err := entities.NewSplitRequest(requester, 500, entities.SplitTypeEqual, []entities.SplitShare{{UserID: payer}}, false, "lunch", "key")
+	require.NoError(t, err)
+
+	tr, err := split.NewShareRequest(shares[0])
+	require.NoError(t, err)
+	assert.Equal(t, payer, tr.FromUserID, "参加者から作成者へ支払う")
+	assert.Equal(t, requester, tr.ToUserID)
+	assert.Equal(t, int64(500), tr.Amount)
+	require.NotNil(t, tr.SplitID)
+	assert.Equal(t, split.ID, *tr.SplitID)
+	assert.Equal(t, entities.SplitShareTTL, tr.ExpiresAt.Sub(tr.CreatedAt))
+
+	assert.True(t, tr.IsSplitShare())
+	assert.Equal(t, payer, tr.Approver(), "支払う参加者が承認する")
+	assert.Equal(t, requester, tr.Canceller(), "請求した作成者がキャンセルする")
+	assert.ErrorIs(t, tr.CanCounter(400), entities.ErrSplitShareNotCounterable)
+
+	require.NoError(t, tr.Approve(uuid.New()))
+	assert.Equal(t, payer, tr.LastActedBy)
+}
+
+func TestSplitRequest_Summarize(t *testing.T) {
+	requester := uuid.New()
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	newSplit := func(t *testing.T) (*entities.SplitRequest, []*entities.TransferRequest) {
+		split, shares, err := entities.NewSplitRequest(requester, 1000, entities.SplitTypeEqual,
+			[]entities.SplitShare{{UserID: a}, {UserID: b}, {UserID: c}}, true, "", "key")
+		require.NoError(t, err)
+		requests := make([]*entities.TransferRequest, len(shares))
+		for i, s := range shares {
+			requests[i], err = split.NewShareRequest(s)
+			require.NoError(t, err)
+		}
+		return split, requests
+	}
+
+	t.Run("支払い済み・支払い待ちを集計する", func(t *testing.T) {
+		split, requests := newSplit(t)
+		require.NoError(t, requests[0].Approve(uuid.New()))
+		require.NoError(t, requests[1].Reject())
+
+		summary := split.Summarize(requests)
+		assert.Equal(t, entities.SplitRequestStatusOpen, summary.Status)
+		assert.Equal(t, 3, summary.ShareCount)
+		assert.Equal(t, 1, summary.PaidCount)
+		assert.Equal(t, int64(250), summary.PaidAmount)
+		assert.Equal(t, int64(250), summary.OutstandingAmount)
+		assert.Equal(t, int64(250), summary.RequesterAmount)
+	})
+
+	t.Run("全員が支払うと完了", func(t *testing.T) {
+		split, requests := newSplit(t)
+		for _, tr := range requests {
+			require.NoError(t, tr.Approve(uuid.New()))
+		}
+		assert.Equal(t, entities.SplitRequestStatusCompleted, split.Summarize(requests).Status)
+		assert.ErrorIs(t, split.Cancel(requests), entities.ErrSplitRequestClosed)
+	})
+
+	t.Run("支払い待ちがなく拒否・期限切れがあれば終了", func(t *testing.T) {
+		split, requests := newSplit(t)
+		require.NoError(t, requests[0].Approve(uuid.New()))
+		require.NoError(t, requests[1].Reject())
+		requests[2].ExpiresAt = requests[2].CreatedAt.Add(-1)
+		assert.Equal(t, entities.SplitRequestStatusClosed, split.Summarize(requests).Status)
+	})
+
+	t.Run("キャンセルした割り勘はキャンセル済み", func(t *testing.T) {
+		split, requests := newSplit(t)
+		require.NoError(t, split.Cancel(requests))
+		assert.True(t, split.IsCancelled())
+		assert.Equal(t, entities.SplitRequestStatusCancelled, split.Summarize(requests).Status)
+		assert.ErrorIs(t, split.Cancel(requests), entities.ErrSplitRequestClosed)
+	})
+}
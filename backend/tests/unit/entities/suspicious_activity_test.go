@@ -0,0 +1,100 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectSuspiciousTransfer(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	newAccount := &entities.User{CreatedAt: now.Add(-24 * time.Hour)}
+	oldAccount := &entities.User{CreatedAt: now.Add(-30 * 24 * time.Hour)}
+
+	t.Run("新規アカウントへの送金が上限に達すると検出する", func(t *testing.T) {
+		v := &entities.TransferVelocity{NewAccountTransfers: entities.SuspiciousNewAccountTransfers - 1}
+		assert.Equal(t, []entities.SuspiciousActivityReason{entities.SuspiciousReasonNewAccountFanout},
+			entities.DetectSuspiciousTransfer(100, newAccount, v, now))
+		assert.Empty(t, entities.DetectSuspiciousTransfer(100, oldAccount, v, now), "古いアカウントへの送金は数えない")
+
+		v.NewAccountTransfers--
+		assert.Empty(t, entities.DetectSuspiciousTransfer(100, newAccount, v, now))
+	})
+
+	t.Run("同じ相手と双方向に送り合うと検出する", func(t *testing.T) {
+		v := &entities.TransferVelocity{PairTransfers: entities.SuspiciousWashTransfers - 1, ReverseTransfers: 1}
+		assert.Equal(t, []entities.SuspiciousActivityReason{entities.SuspiciousReasonWashTransfer},
+			entities.DetectSuspiciousTransfer(100, oldAccount, v, now))
+
+		v.ReverseTransfers = 0
+		assert.Empty(t, entities.DetectSuspiciousTransfer(100, oldAccount, v, now), "一方向の送金だけなら検出しない")
+	})
+
+	t.Run("付与の直後にその額以上を送金すると検出する", func(t *testing.T) {
+		v := &entities.TransferVelocity{RecentGrantAmount: 1000}
+		assert.Equal(t, []entities.SuspiciousActivityReason{entities.SuspiciousReasonAfterGrant},
+			entities.DetectSuspiciousTransfer(1000, oldAccount, v, now))
+		assert.Empty(t, entities.DetectSuspiciousTransfer(999, oldAccount, v, now))
+	})
+
+	t.Run("該当する理由をすべて返す", func(t *testing.T) {
+		v := &entities.TransferVelocity{NewAccountTransfers: 10, PairTransfers: 5, ReverseTransfers: 2, RecentGrantAmount: 50}
+		assert.Len(t, entities.DetectSuspiciousTransfer(100, newAccount, v, now), 3)
+	})
+}
+
+func TestSuspiciousActivity_Review(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	reviewer := uuid.New()
+	newActivity := func(hold bool) *entities.SuspiciousActivity {
+		return entities.NewSuspiciousActivity(uuid.New(), uuid.New(), 500, "", "key", []entities.SuspiciousActivityReason{entities.SuspiciousReasonWashTransfer}, hold, now)
+	}
+
+	t.Run("送金済みの記録は問題なし・不正と判断できる", func(t *testing.T) {
+		a := newActivity(false)
+		require.NoError(t, a.Dismiss(reviewer, " 問題なし ", now))
+		assert.Equal(t, entities.SuspiciousActivityStatusDismissed, a.Status)
+		assert.Equal(t, "問題なし", a.ReviewComment)
+		assert.Equal(t, reviewer, *a.ReviewedBy)
+
+		b := newActivity(false)
+		require.NoError(t, b.Confirm(reviewer, "", now))
+		assert.Equal(t, entities.SuspiciousActivityStatusConfirmed, b.Status)
+	})
+
+	t.Run("保留中の記録は解除・取り消しになる", func(t *testing.T) {
+		a := newActivity(true)
+		require.NoError(t, a.Dismiss(reviewer, "", now))
+		assert.Equal(t, entities.SuspiciousActivityStatusReleased, a.Status)
+
+		b := newActivity(true)
+		require.NoError(t, b.Confirm(reviewer, "", now))
+		assert.Equal(t, entities.SuspiciousActivityStatusRejected, b.Status)
+	})
+
+	t.Run("確認済みの記録は再度確認できない", func(t *testing.T) {
+		a := newActivity(false)
+		require.NoError(t, a.Dismiss(reviewer, "", now))
+		assert.ErrorIs(t, a.Confirm(reviewer, "", now), entities.ErrSuspiciousActivityReviewed)
+	})
+
+	t.Run("コメントが長すぎる場合はエラー", func(t *testing.T) {
+		a := newActivity(false)
+		err := a.Dismiss(reviewer, strings.Repeat("あ", entities.AdminApprovalCommentMaxLength+1), now)
+		assert.ErrorIs(t, err, entities.ErrInvalidApprovalComment)
+		assert.Equal(t, entities.SuspiciousActivityStatusOpen, a.Status)
+	})
+}
+
+func TestParseTransferHoldEnabled(t *testing.T) {
+	assert.True(t, entities.ParseTransferHoldEnabled("true"))
+	assert.True(t, entities.ParseTransferHoldEnabled(" 1 "))
+	assert.False(t, entities.ParseTransferHoldEnabled(""))
+	assert.False(t, entities.ParseTransferHoldEnabled("false"))
+	assert.False(t, entities.ParseTransferHoldEnabled("yes"))
+}
@@ -0,0 +1,76 @@
+package entities_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTenant(t *testing.T) {
+	now := time.Now()
+
+	t.Run("スラッグは小文字に揃え、有効な状態で作成される", func(t *testing.T) {
+		tenant, err := entities.NewTenant(" Acme-01 ", " Acme ", now)
+		require.NoError(t, err)
+		assert.Equal(t, "acme-01", tenant.Slug)
+		assert.Equal(t, "Acme", tenant.Name)
+		assert.True(t, tenant.IsActive)
+		assert.False(t, tenant.IsDefault())
+	})
+
+	tests := []struct {
+		name string
+		slug string
+		tn   string
+	}{
+		{"スラッグが1文字", "a", "Acme"},
+		{"スラッグがハイフンで終わる", "acme-", "Acme"},
+		{"スラッグに使えない文字", "acme_inc", "Acme"},
+		{"スラッグが長すぎる", strings.Repeat("a", 41), "Acme"},
+		{"予約済みのスラッグ", "admin", "Acme"},
+		{"既定のテナントのスラッグ", entities.DefaultTenantSlug, "Acme"},
+		{"名前が空", "acme", " "},
+		{"名前が長すぎる", "acme", strings.Repeat("あ", 101)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entities.NewTenant(tt.slug, tt.tn, now)
+			assert.ErrorIs(t, err, entities.ErrInvalidTenant)
+		})
+	}
+}
+
+func TestTenant_Update(t *testing.T) {
+	tenant := &entities.Tenant{ID: entities.DefaultTenantID, Slug: entities.DefaultTenantSlug, Name: "既定", IsActive: true}
+	assert.ErrorIs(t, tenant.Update("既定", false, time.Now()), entities.ErrInvalidTenant, "既定のテナントは無効にできない")
+	require.NoError(t, tenant.Update("本社", true, time.Now()))
+	assert.Equal(t, "本社", tenant.Name)
+}
+
+func TestTenantIDFromContext(t *testing.T) {
+	_, ok := entities.TenantIDFromContext(context.Background())
+	assert.False(t, ok)
+
+	id := uuid.New()
+	got, ok := entities.TenantIDFromContext(entities.WithTenantID(context.Background(), id))
+	assert.True(t, ok)
+	assert.Equal(t, id, got)
+}
+
+func TestUser_IsSuperAdmin(t *testing.T) {
+	user, err := entities.NewUser("root", "root@example.com", "hash", "Root", "太郎", "田中")
+	require.NoError(t, err)
+	user.Role = entities.RoleSuperAdmin
+	user.TenantID = entities.DefaultTenantID
+	assert.True(t, user.IsSuperAdmin())
+	assert.True(t, user.IsAdmin(), "スーパー管理者は管理者の操作もできる")
+
+	user.TenantID = uuid.New()
+	assert.False(t, user.IsSuperAdmin(), "既定のテナント以外ではスーパー管理者にならない")
+}
@@ -0,0 +1,56 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionRetention(t *testing.T) {
+	now := time.Date(2026, 10, 16, 4, 0, 0, 0, time.UTC)
+
+	assert.False(t, entities.TransactionRetention{}.Enabled(), "未設定なら移さない")
+
+	r := entities.TransactionRetention{Days: 400, BatchSize: 500}
+	assert.True(t, r.Enabled())
+	assert.True(t, now.AddDate(0, 0, -400).Equal(r.Cutoff(now)))
+}
+
+func TestArchivedTransactionFilter_Validate(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		filter  entities.ArchivedTransactionFilter
+		wantErr bool
+	}{
+		{"1年分", entities.ArchivedTransactionFilter{From: from, To: from.AddDate(1, 0, 0)}, false},
+		{"上限の366日", entities.ArchivedTransactionFilter{From: from, To: from.Add(entities.TransactionArchiveMaxRange)}, false},
+		{"期間が長すぎる", entities.ArchivedTransactionFilter{From: from, To: from.AddDate(0, 0, 367)}, true},
+		{"期間の指定がない", entities.ArchivedTransactionFilter{From: from}, true},
+		{"終了が開始より前", entities.ArchivedTransactionFilter{From: from, To: from.AddDate(0, 0, -1)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, entities.ErrInvalidArchiveQuery)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTransactionArchiveMonth(t *testing.T) {
+	// UTCの月末15時以降はJSTでは翌月
+	assert.Equal(t, "2024-02", entities.TransactionArchiveMonth(time.Date(2024, 1, 31, 15, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "2024-01", entities.TransactionArchiveMonth(time.Date(2024, 1, 31, 14, 59, 0, 0, time.UTC)))
+
+	assert.NoError(t, entities.ValidateArchiveMonth(""))
+	assert.NoError(t, entities.ValidateArchiveMonth("2024-12"))
+	assert.ErrorIs(t, entities.ValidateArchiveMonth("2024-13"), entities.ErrInvalidArchiveQuery)
+	assert.ErrorIs(t, entities.ValidateArchiveMonth("2024/01"), entities.ErrInvalidArchiveQuery)
+}
@@ -0,0 +1,87 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ========================================
+// ParseTransactionImportCSV Tests
+// ========================================
+
+func TestParseTransactionImportCSV(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("列の順番は問わずBOM・空行を無視し、タイムゾーンのない日時はJSTとみなす", func(t *testing.T) {
+		csv := "\ufeffType,Amount,To,From,Date,description\n" +
+			"transfer,300,suzuki,yamada,2024-04-15 10:30,ランチ代\n" +
+			",,,,,\n" +
+			"ADMIN_GRANT,1000,yamada,,2024/04/01,\n" +
+			"admin_deduct,100,,suzuki,2024-05-01T09:00:00Z,\n"
+
+		rows, err := entities.ParseTransactionImportCSV(strings.NewReader(csv), now)
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+		for _, r := range rows {
+			assert.NoError(t, r.Err)
+		}
+		assert.Equal(t, 2, rows[0].Line)
+		assert.Equal(t, time.Date(2024, 4, 15, 1, 30, 0, 0, time.UTC), rows[0].Date.UTC())
+		assert.Equal(t, "ランチ代", rows[0].Description)
+		assert.Equal(t, 4, rows[1].Line)
+		assert.Equal(t, entities.TransactionTypeAdminGrant, rows[1].Type)
+		assert.Equal(t, int64(1000), rows[1].Amount)
+		assert.Equal(t, time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC), rows[2].Date.UTC())
+	})
+
+	t.Run("必須の列が無い・行が無ければファイル全体のエラー", func(t *testing.T) {
+		_, err := entities.ParseTransactionImportCSV(strings.NewReader("date,from,to,amount\n"), now)
+		assert.ErrorIs(t, err, entities.ErrInvalidTransactionImportFile)
+
+		_, err = entities.ParseTransactionImportCSV(strings.NewReader("date,from,to,amount,type\n"), now)
+		assert.ErrorIs(t, err, entities.ErrInvalidTransactionImportFile)
+	})
+
+	t.Run("行の形式エラーは行ごとに返す", func(t *testing.T) {
+		csv := "date,from,to,amount,type\n" +
+			"2024-04-01,,yamada,0,admin_grant\n" + // 金額が0
+			"2025-02-01,,yamada,100,admin_grant\n" + // 未来の日時
+			"04/01/2024,,yamada,100,admin_grant\n" + // 日時の形式
+			"2024-04-01,yamada,yamada,100,transfer\n" + // 自分への送金
+			"2024-04-01,yamada,suzuki,100,admin_grant\n" + // 付与に送信者
+			"2024-04-01,,suzuki,100,admin_deduct\n" + // 減算に受信者
+			"2024-04-01,,suzuki,100,transfer_fee\n" // 取り込めない種別
+
+		rows, err := entities.ParseTransactionImportCSV(strings.NewReader(csv), now)
+		require.NoError(t, err)
+		require.Len(t, rows, 7)
+		for _, r := range rows {
+			assert.ErrorIs(t, r.Err, entities.ErrInvalidTransactionImportRow, "line %d", r.Line)
+		}
+	})
+}
+
+func TestTransactionImportRow_NewTransaction(t *testing.T) {
+	date := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	importID, adminID, userID := uuid.New(), uuid.New(), uuid.New()
+
+	row := &entities.TransactionImportRow{Line: 3, Date: date, To: "yamada", Amount: 500, Type: entities.TransactionTypeSystemGrant}
+	tx := row.NewTransaction(nil, &userID, importID, adminID)
+
+	assert.Equal(t, entities.TransactionStatusCompleted, tx.Status)
+	assert.Equal(t, date, tx.CreatedAt)
+	require.NotNil(t, tx.CompletedAt)
+	assert.Equal(t, date, *tx.CompletedAt)
+	assert.Equal(t, entities.SystemAccountTreasury, tx.FromAccount)
+	assert.Empty(t, tx.ToAccount)
+	assert.Equal(t, entities.TransactionImportTag, tx.Tag)
+	assert.True(t, tx.Migrated())
+	assert.Equal(t, importID.String(), tx.Metadata["import_id"])
+	assert.Equal(t, entities.PointBatchSourceSystemGrant, row.PointBatchSource())
+}
@@ -0,0 +1,72 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferEligibilityPolicy_Check(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	user := &entities.User{CreatedAt: now.Add(-12 * time.Hour)}
+
+	t.Run("条件がなければ送金できる", func(t *testing.T) {
+		policy := &entities.TransferEligibilityPolicy{}
+		assert.False(t, policy.IsEnabled())
+		assert.NoError(t, policy.Check(user, now))
+	})
+
+	t.Run("メール未認証なら送金できない", func(t *testing.T) {
+		policy := &entities.TransferEligibilityPolicy{RequireEmailVerification: true}
+		assert.ErrorIs(t, policy.Check(user, now), entities.ErrEmailVerificationRequired)
+
+		verified := *user
+		verified.EmailVerified = true
+		assert.NoError(t, policy.Check(&verified, now))
+	})
+
+	t.Run("作成から指定時間が経つまで送金できず、送れるようになる日時を返す", func(t *testing.T) {
+		policy := &entities.TransferEligibilityPolicy{MinAccountAgeHours: 24}
+		err := policy.Check(user, now)
+		require.ErrorIs(t, err, entities.ErrAccountTooNew)
+		de, ok := entities.AsDomainError(err)
+		require.True(t, ok)
+		assert.Equal(t, "2026-10-02T00:00:00Z", de.Params["available_at"])
+
+		assert.NoError(t, policy.Check(user, now.Add(12*time.Hour)))
+	})
+}
+
+func TestTransferEligibilityPolicy_Validate(t *testing.T) {
+	assert.NoError(t, (&entities.TransferEligibilityPolicy{MinAccountAgeHours: 0}).Validate())
+	assert.NoError(t, (&entities.TransferEligibilityPolicy{MinAccountAgeHours: entities.TransferMinAccountAgeMaxHours}).Validate())
+	assert.ErrorIs(t, (&entities.TransferEligibilityPolicy{MinAccountAgeHours: -1}).Validate(), entities.ErrInvalidTransferEligibility)
+	assert.ErrorIs(t, (&entities.TransferEligibilityPolicy{MinAccountAgeHours: entities.TransferMinAccountAgeMaxHours + 1}).Validate(), entities.ErrInvalidTransferEligibility)
+}
+
+func TestTransferEligibilityPolicy_AcceptanceOf(t *testing.T) {
+	t.Run("ユーザーの設定を既定より優先する", func(t *testing.T) {
+		policy := &entities.TransferEligibilityPolicy{DefaultAcceptance: entities.TransferAcceptanceFriendsOnly}
+		assert.Equal(t, entities.TransferAcceptanceFriendsOnly, policy.AcceptanceOf(&entities.User{}))
+		assert.Equal(t, entities.TransferAcceptanceAnyone,
+			policy.AcceptanceOf(&entities.User{AcceptTransfersFrom: entities.TransferAcceptanceAnyone}))
+	})
+
+	t.Run("既定もなければ誰からでも受け取る", func(t *testing.T) {
+		policy := &entities.TransferEligibilityPolicy{}
+		assert.Equal(t, entities.TransferAcceptanceAnyone, policy.AcceptanceOf(&entities.User{}))
+	})
+
+	t.Run("不明な値は設定できない", func(t *testing.T) {
+		policy := &entities.TransferEligibilityPolicy{DefaultAcceptance: "nobody"}
+		assert.ErrorIs(t, policy.Validate(), entities.ErrInvalidTransferAcceptance)
+
+		user := &entities.User{}
+		assert.ErrorIs(t, user.SetAcceptTransfersFrom("nobody"), entities.ErrInvalidTransferAcceptance)
+		require.NoError(t, user.SetAcceptTransfersFrom(entities.TransferAcceptanceFriendsOnly))
+		assert.Equal(t, entities.TransferAcceptanceFriendsOnly, user.AcceptTransfersFrom)
+	})
+}
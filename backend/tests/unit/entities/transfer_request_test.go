@@ -293,3 +293,87 @@ func TestTransferRequest_CanCancel(t *testing.T) {
 		assert.Contains(t, err.Error(), "request is not pending")
 	})
 }
+
+func TestTransferRequest_Counter(t *testing.T) {
+	t.Run("pending状態のリクエストにカウンターオファー", func(t *testing.T) {
+		sender, receiver := uuid.New(), uuid.New()
+		tr, _ := entities.NewTransferRequest(sender, receiver, 1000, "test", "key-123")
+		assert.Equal(t, sender, tr.LastActedBy)
+		tr.ExpiresAt = time.Now().Add(time.Minute)
+
+		err := tr.Counter(800)
+		require.NoError(t, err)
+
+		assert.Equal(t, entities.TransferRequestStatusCountered, tr.Status)
+		require.NotNil(t, tr.CounterAmount)
+		assert.Equal(t, int64(800), *tr.CounterAmount)
+		assert.Equal(t, int64(800), tr.FinalAmount())
+		assert.NotNil(t, tr.CounteredAt)
+		assert.Equal(t, receiver, tr.LastActedBy)
+		assert.True(t, tr.ExpiresAt.After(time.Now().Add(23*time.Hour)), "カウンターオファー時点から有効期限を延長")
+	})
+
+	t.Run("元の金額と同じ・0以下の金額はエラー", func(t *testing.T) {
+		tr, _ := entities.NewTransferRequest(uuid.New(), uuid.New(), 1000, "test", "key-123")
+
+		assert.ErrorIs(t, tr.Counter(1000), entities.ErrInvalidCounterAmount)
+		assert.ErrorIs(t, tr.Counter(0), entities.ErrInvalidCounterAmount)
+		assert.Equal(t, entities.TransferRequestStatusPending, tr.Status)
+	})
+
+	t.Run("カウンターオファー済みのリクエストに再度カウンターはエラー", func(t *testing.T) {
+		tr, _ := entities.NewTransferRequest(uuid.New(), uuid.New(), 1000, "test", "key-123")
+		require.NoError(t, tr.Counter(800))
+
+		assert.ErrorIs(t, tr.Counter(700), entities.ErrRequestNotPending)
+	})
+
+	t.Run("期限切れの場合はエラー", func(t *testing.T) {
+		tr, _ := entities.NewTransferRequest(uuid.New(), uuid.New(), 1000, "test", "key-123")
+		tr.ExpiresAt = time.Now().Add(-1 * time.Hour)
+
+		assert.ErrorIs(t, tr.Counter(800), entities.ErrRequestExpired)
+	})
+}
+
+func TestTransferRequest_ConfirmCounter(t *testing.T) {
+	t.Run("カウンターオファーを確定すると承認済みになる", func(t *testing.T) {
+		sender, receiver := uuid.New(), uuid.New()
+		tr, _ := entities.NewTransferRequest(sender, receiver, 1000, "test", "key-123")
+		require.NoError(t, tr.Counter(1500))
+		txID := uuid.New()
+
+		err := tr.ConfirmCounter(txID)
+		require.NoError(t, err)
+
+		assert.Equal(t, entities.TransferRequestStatusApproved, tr.Status)
+		assert.Equal(t, txID, *tr.TransactionID)
+		assert.Equal(t, int64(1500), tr.FinalAmount())
+		assert.Equal(t, sender, tr.LastActedBy)
+	})
+
+	t.Run("pending状態では確定できない", func(t *testing.T) {
+		tr, _ := entities.NewTransferRequest(uuid.New(), uuid.New(), 1000, "test", "key-123")
+
+		assert.ErrorIs(t, tr.ConfirmCounter(uuid.New()), entities.ErrRequestNotCountered)
+	})
+
+	t.Run("カウンターオファーの期限切れ後は確定できない", func(t *testing.T) {
+		tr, _ := entities.NewTransferRequest(uuid.New(), uuid.New(), 1000, "test", "key-123")
+		require.NoError(t, tr.Counter(800))
+		tr.ExpiresAt = time.Now().Add(-1 * time.Minute)
+
+		assert.ErrorIs(t, tr.ConfirmCounter(uuid.New()), entities.ErrRequestExpired)
+
+		tr.MarkAsExpired()
+		assert.Equal(t, entities.TransferRequestStatusExpired, tr.Status)
+	})
+
+	t.Run("送信者はカウンターオファーを辞退（キャンセル）できる", func(t *testing.T) {
+		tr, _ := entities.NewTransferRequest(uuid.New(), uuid.New(), 1000, "test", "key-123")
+		require.NoError(t, tr.Counter(800))
+
+		require.NoError(t, tr.Cancel())
+		assert.Equal(t, entities.TransferRequestStatusCancelled, tr.Status)
+	})
+}
@@ -0,0 +1,76 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ========================================
+// ParseUserImportCSV Tests
+// ========================================
+
+func TestParseUserImportCSV(t *testing.T) {
+	t.Run("列の順番は問わずBOM・空行・余分な列を無視する", func(t *testing.T) {
+		csv := "\ufeffRole,name,Email,username,initial_points,memo\n" +
+			"ADMIN,佐藤 一郎,sato@example.com,sato,100,x\n" +
+			",,,,,\n" +
+			"user,Kim,kim@example.com,kim,,\n"
+
+		rows, err := entities.ParseUserImportCSV(strings.NewReader(csv))
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.NoError(t, rows[0].Err)
+		assert.Equal(t, 2, rows[0].Line)
+		assert.Equal(t, entities.RoleAdmin, rows[0].Role)
+		assert.Equal(t, int64(100), rows[0].InitialPoints)
+		assert.Equal(t, 4, rows[1].Line)
+		assert.Equal(t, int64(0), rows[1].InitialPoints)
+	})
+
+	t.Run("必須の列が無ければファイル全体のエラー", func(t *testing.T) {
+		_, err := entities.ParseUserImportCSV(strings.NewReader("username,email,name,role\n"))
+		assert.ErrorIs(t, err, entities.ErrInvalidUserImportFile)
+
+		_, err = entities.ParseUserImportCSV(strings.NewReader(""))
+		assert.ErrorIs(t, err, entities.ErrInvalidUserImportFile)
+	})
+
+	t.Run("行数の上限を超えるとファイル全体のエラー", func(t *testing.T) {
+		var b strings.Builder
+		b.WriteString("username,email,name,initial_points,role\n")
+		for i := 0; i <= entities.UserImportMaxRows; i++ {
+			b.WriteString("user,user@example.com,User,0,\n")
+		}
+		_, err := entities.ParseUserImportCSV(strings.NewReader(b.String()))
+		assert.ErrorIs(t, err, entities.ErrInvalidUserImportFile)
+	})
+
+	t.Run("不正な行は行ごとのエラーになる", func(t *testing.T) {
+		csv := "username,email,name,initial_points,role\n" +
+			"ab,ab@example.com,AB,0,\n" +
+			"carol,Carol <carol@example.com>,Carol,0,\n" +
+			"dave,dave@example.com,,0,\n" +
+			"erin,erin@example.com,Erin,1.5,\n"
+
+		rows, err := entities.ParseUserImportCSV(strings.NewReader(csv))
+		require.NoError(t, err)
+		require.Len(t, rows, 4)
+		for _, row := range rows {
+			assert.ErrorIs(t, row.Err, entities.ErrInvalidUserImportRow, "line %d", row.Line)
+		}
+	})
+}
+
+func TestUserImportRow_NewUser(t *testing.T) {
+	row := &entities.UserImportRow{Username: "kim", Email: "kim@example.com", Name: "Kim", Role: entities.RoleUser}
+	user, err := row.NewUser("hash")
+	require.NoError(t, err)
+	assert.Equal(t, "Kim", user.DisplayName)
+	assert.Equal(t, "Kim", user.FirstName)
+	assert.Equal(t, "Kim", user.LastName)
+	assert.Equal(t, entities.RoleUser, user.Role)
+}
@@ -2,6 +2,7 @@ package entities_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/google/uuid"
@@ -295,6 +296,52 @@ func TestEmailVerificationToken_Verify(t *testing.T) {
 	})
 }
 
+func TestEmailVerificationToken_EmailChange(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("新旧両方の確認で適用可能になる", func(t *testing.T) {
+		token, err := entities.NewEmailChangeToken(userID, "old@example.com", "new@example.com")
+		require.NoError(t, err)
+		require.True(t, token.RequiresOldEmailConfirmation())
+		assert.NotEqual(t, token.Token, *token.OldEmailToken)
+
+		now := token.CreatedAt
+		assert.False(t, token.CanApply(now))
+
+		require.NoError(t, token.Verify())
+		assert.False(t, token.CanApply(now))
+
+		require.NoError(t, token.ConfirmOldEmail(now))
+		assert.True(t, token.CanApply(now))
+
+		token.MarkApplied(now)
+		assert.False(t, token.CanApply(now))
+	})
+
+	t.Run("旧アドレス未確認でもタイムアウト後は適用可能", func(t *testing.T) {
+		token, _ := entities.NewEmailChangeToken(userID, "old@example.com", "new@example.com")
+		require.NoError(t, token.Verify())
+
+		assert.False(t, token.CanApply(token.CreatedAt.Add(entities.EmailChangeOldConfirmTimeout-time.Minute)))
+		assert.True(t, token.CanApply(token.CreatedAt.Add(entities.EmailChangeOldConfirmTimeout)))
+	})
+
+	t.Run("旧アドレスの確認のみでは適用しない", func(t *testing.T) {
+		token, _ := entities.NewEmailChangeToken(userID, "old@example.com", "new@example.com")
+		require.NoError(t, token.ConfirmOldEmail(token.CreatedAt))
+
+		assert.False(t, token.CanApply(token.CreatedAt.Add(entities.EmailChangeOldConfirmTimeout)))
+	})
+
+	t.Run("旧形式のトークンは新アドレスの認証のみで適用可能", func(t *testing.T) {
+		token, _ := entities.NewEmailVerificationToken(&userID, "new@example.com", entities.TokenTypeEmailChange)
+		require.NoError(t, token.Verify())
+
+		assert.True(t, token.CanApply(time.Now()))
+		assert.Error(t, token.ConfirmOldEmail(time.Now()))
+	})
+}
+
 // ========================================
 // UsernameChangeHistory Tests
 // ========================================
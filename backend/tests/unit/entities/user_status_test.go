@@ -0,0 +1,58 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserDeactivation(t *testing.T) {
+	newUser := func(t *testing.T) *entities.User {
+		t.Helper()
+		user, err := entities.NewUser("sleeper", "sleeper@example.com", "hash", "Sleeper", "太郎", "田中")
+		require.NoError(t, err)
+		return user
+	}
+
+	t.Run("休止すると無効になり、二重には休止できない", func(t *testing.T) {
+		user := newUser(t)
+		now := time.Now()
+
+		require.NoError(t, user.DeactivateSelf(now))
+		assert.True(t, user.IsDeactivated())
+		assert.False(t, user.IsActive)
+		assert.Equal(t, now.Add(entities.UserReactivationWindow), user.ReactivatableUntil())
+		assert.ErrorIs(t, user.DeactivateSelf(now), entities.ErrAccountDeactivated)
+	})
+
+	t.Run("期限までは再開でき、過ぎると再開できない", func(t *testing.T) {
+		user := newUser(t)
+		deactivatedAt := time.Now().Add(-entities.UserReactivationWindow)
+		require.NoError(t, user.DeactivateSelf(deactivatedAt))
+
+		assert.ErrorIs(t, user.Reactivate(user.ReactivatableUntil().Add(time.Second)), entities.ErrReactivationExpired)
+		assert.True(t, user.IsDeactivated())
+
+		require.NoError(t, user.Reactivate(user.ReactivatableUntil()))
+		assert.Equal(t, entities.UserStatusActive, user.Status)
+		assert.True(t, user.IsActive)
+		assert.Nil(t, user.DeactivatedAt)
+	})
+
+	t.Run("休止していないユーザーは再開できない", func(t *testing.T) {
+		user := newUser(t)
+		user.Deactivate()
+
+		assert.ErrorIs(t, user.Reactivate(time.Now()), entities.ErrUserInactive)
+		assert.ErrorIs(t, user.DeactivateSelf(time.Now()), entities.ErrUserInactive)
+	})
+
+	t.Run("状態が未設定ならactive", func(t *testing.T) {
+		user := &entities.User{IsActive: true}
+		assert.Equal(t, entities.UserStatusActive, user.CurrentStatus())
+		assert.False(t, user.IsDeactivated())
+	})
+}
@@ -0,0 +1,72 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeTier(t *testing.T) {
+	tests := []struct {
+		name   string
+		earned int64
+		streak int
+		want   entities.UserTier
+	}{
+		{"利用なし", 0, 0, entities.TierBronze},
+		{"獲得ポイントでsilver", 1000, 0, entities.TierSilver},
+		{"連続チェックインでsilver", 0, 5, entities.TierSilver},
+		{"獲得ポイントでgold", 5000, 1, entities.TierGold},
+		{"連続チェックインでgold", 999, 20, entities.TierGold},
+		{"どちらも条件未満", 999, 4, entities.TierBronze},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := entities.ComputeTier(&entities.TierActivity{EarnedPoints: tt.earned, CheckInStreak: tt.streak})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUserTier_AtLeast(t *testing.T) {
+	assert.True(t, entities.TierGold.AtLeast(entities.TierSilver))
+	assert.True(t, entities.TierSilver.AtLeast(entities.TierSilver))
+	assert.False(t, entities.TierBronze.AtLeast(entities.TierSilver))
+
+	// ランク未設定のユーザーはbronze扱い
+	user := &entities.User{}
+	assert.Equal(t, entities.TierBronze, user.CurrentTier())
+}
+
+func TestBoostLotteryTiers(t *testing.T) {
+	t.Run("当たりの確率だけを倍率分上げ、元のティアは変更しない", func(t *testing.T) {
+		tiers := []*entities.LotteryTier{
+			entities.NewLotteryTier("大当たり", 100, 10, 1),
+			entities.NewLotteryTier("ハズレ", 0, 50, 2),
+		}
+
+		boosted := entities.BoostLotteryTiers(tiers, 1.25)
+		assert.InDelta(t, 12.5, boosted[0].Probability, 0.0001)
+		assert.InDelta(t, 50.0, boosted[1].Probability, 0.0001)
+		assert.InDelta(t, 10.0, tiers[0].Probability, 0.0001)
+	})
+
+	t.Run("合計が100%を超える場合は100%に収める", func(t *testing.T) {
+		tiers := []*entities.LotteryTier{
+			entities.NewLotteryTier("当たり", 10, 90, 1),
+			entities.NewLotteryTier("ハズレ", 0, 10, 2),
+		}
+
+		boosted := entities.BoostLotteryTiers(tiers, 1.25)
+		total := boosted[0].Probability + boosted[1].Probability
+		assert.InDelta(t, 100.0, total, 0.0001)
+		assert.Greater(t, boosted[0].Probability, 90.0)
+	})
+
+	t.Run("倍率1以下ならそのまま返す", func(t *testing.T) {
+		tiers := []*entities.LotteryTier{entities.NewLotteryTier("当たり", 10, 30, 1)}
+		assert.Equal(t, tiers, entities.BoostLotteryTiers(tiers, entities.TierBronze.LotteryBoost()))
+	})
+}
@@ -0,0 +1,68 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeeklyDigest_Render(t *testing.T) {
+	user, err := entities.NewUser("taro", "taro@example.com", "hash", "たろう", "太郎", "田中")
+	require.NoError(t, err)
+	user.Balance = 1200
+	// 2026-10-19 09:00 JST
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	t.Run("増減・有効期限・承認待ちを本文に載せる", func(t *testing.T) {
+		batch := entities.NewPointBatch(user.ID, 300, entities.PointBatchSourceAdminGrant, nil, now)
+		batch.ExpiresAt = time.Date(2026, 11, 1, 3, 0, 0, 0, time.UTC)
+		digest := entities.NewWeeklyDigest(user, &entities.WeeklyDigestActivity{UserID: user.ID, Earned: 500, Spent: 120},
+			[]*entities.PointBatch{batch}, 2, now)
+
+		email, err := digest.Render()
+		require.NoError(t, err)
+		assert.Equal(t, "今週のポイントのまとめ（10月19日）", email.Subject)
+		assert.Contains(t, email.Body, "たろうさん")
+		assert.Contains(t, email.Body, "10月12日〜10月19日")
+		assert.Contains(t, email.Body, "獲得したポイント: 500ポイント")
+		assert.Contains(t, email.Body, "使ったポイント: 120ポイント")
+		assert.Contains(t, email.Body, "現在の残高: 1200ポイント")
+		assert.Contains(t, email.Body, "（合計300ポイント）")
+		assert.Contains(t, email.Body, "・11月1日に300ポイント")
+		assert.Contains(t, email.Body, "承認待ちの送金リクエストが2件あります")
+	})
+
+	t.Run("有効期限・承認待ちがなければその段落を省く", func(t *testing.T) {
+		digest := entities.NewWeeklyDigest(user, nil, nil, 0, now)
+		email, err := digest.Render()
+		require.NoError(t, err)
+		assert.Contains(t, email.Body, "獲得したポイント: 0ポイント")
+		assert.NotContains(t, email.Body, "有効期限を迎える")
+		assert.NotContains(t, email.Body, "承認待ち")
+		assert.False(t, digest.IsEmpty(), "残高があれば送る")
+	})
+
+	t.Run("残高も増減もなければ送らない", func(t *testing.T) {
+		empty := *user
+		empty.Balance = 0
+		assert.True(t, entities.NewWeeklyDigest(&empty, nil, nil, 0, now).IsEmpty())
+		assert.False(t, entities.NewWeeklyDigest(&empty, nil, nil, 1, now).IsEmpty())
+	})
+}
+
+func TestNotificationPreferences_WeeklyDigest(t *testing.T) {
+	prefs := entities.DefaultNotificationPreferences(uuid.New())
+	now := time.Now()
+	assert.True(t, prefs.Allows(entities.NotificationChannelEmail, entities.NotificationTypeWeeklyDigest, now), "初期設定では受け取る")
+
+	prefs.WeeklyDigest = false
+	assert.False(t, prefs.Allows(entities.NotificationChannelEmail, entities.NotificationTypeWeeklyDigest, now))
+
+	prefs.WeeklyDigest = true
+	prefs.EmailEnabled = false
+	assert.False(t, prefs.Allows(entities.NotificationChannelEmail, entities.NotificationTypeWeeklyDigest, now), "メールを止めていれば送らない")
+}
@@ -6,11 +6,20 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/infra"
 	"github.com/gity/point-system/gateways/infra/infraakerun"
+	"github.com/gity/point-system/gateways/infra/infrabreaker"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -20,6 +29,7 @@ import (
 // ========================================
 
 type mockAkerunGateway struct {
+	org          entities.AkerunOrganization
 	accesses     []entities.AccessRecord
 	fetchCount   int
 	isConfigured bool
@@ -38,6 +48,7 @@ type fetchCall struct {
 
 func newMockGateway() *mockAkerunGateway {
 	return &mockAkerunGateway{
+		org:          entities.AkerunOrganization{ID: "org-1"},
 		isConfigured: true,
 		fetchCalls:   make([]fetchCall, 0),
 	}
@@ -55,6 +66,10 @@ func (m *mockAkerunGateway) FetchAccesses(ctx context.Context, after, before tim
 	return m.accesses, nil
 }
 
+func (m *mockAkerunGateway) Organization() entities.AkerunOrganization {
+	return m.org
+}
+
 func (m *mockAkerunGateway) IsConfigured() bool {
 	return m.isConfigured
 }
@@ -63,20 +78,30 @@ func (m *mockAkerunGateway) IsConfigured() bool {
 // Mock: AkerunBonusInputPort (Interactor)
 // ========================================
 
+// mockBonusInteractor は組織ごとの前回ポーリング時刻を保持する（未更新の組織はinitialPolledAtを返す）
+// ワーカーは組織ごとに並行して呼び出すのでmuで保護する
 type mockBonusInteractor struct {
-	lastPolledAt     time.Time
+	mu               sync.Mutex
+	initialPolledAt  time.Time
+	lastPolledAt     time.Time // 最後に更新されたポーリング時刻
+	cursors          map[string]time.Time
 	processedBatches [][]entities.AccessRecord
 	processErr       error
+	retriedAt        []time.Time
 }
 
 func newMockBonusInteractor(lastPolledAt time.Time) *mockBonusInteractor {
 	return &mockBonusInteractor{
+		initialPolledAt:  lastPolledAt,
 		lastPolledAt:     lastPolledAt,
+		cursors:          make(map[string]time.Time),
 		processedBatches: make([][]entities.AccessRecord, 0),
 	}
 }
 
 func (m *mockBonusInteractor) ProcessAccesses(ctx context.Context, accesses []entities.AccessRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.processedBatches = append(m.processedBatches, accesses)
 	if m.processErr != nil {
 		return m.processErr
@@ -84,11 +109,24 @@ func (m *mockBonusInteractor) ProcessAccesses(ctx context.Context, accesses []en
 	return nil
 }
 
-func (m *mockBonusInteractor) GetLastPolledAt(ctx context.Context) (time.Time, error) {
-	return m.lastPolledAt, nil
+func (m *mockBonusInteractor) RetryFailedAccesses(ctx context.Context, now time.Time) error {
+	m.retriedAt = append(m.retriedAt, now)
+	return nil
 }
 
-func (m *mockBonusInteractor) UpdateLastPolledAt(ctx context.Context, t time.Time) error {
+func (m *mockBonusInteractor) GetLastPolledAt(ctx context.Context, organizationID string) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if t, ok := m.cursors[organizationID]; ok {
+		return t, nil
+	}
+	return m.initialPolledAt, nil
+}
+
+func (m *mockBonusInteractor) UpdateLastPolledAt(ctx context.Context, organizationID string, t time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cursors[organizationID] = t
 	m.lastPolledAt = t
 	return nil
 }
@@ -98,6 +136,7 @@ func (m *mockBonusInteractor) UpdateLastPolledAt(ctx context.Context, t time.Tim
 // ========================================
 
 type mockLogger struct {
+	mu     sync.Mutex
 	infos  []string
 	errors []string
 }
@@ -107,9 +146,19 @@ func newMockLogger() *mockLogger {
 }
 
 func (m *mockLogger) Debug(msg string, fields ...entities.Field) {}
-func (m *mockLogger) Info(msg string, fields ...entities.Field)  { m.infos = append(m.infos, msg) }
 func (m *mockLogger) Warn(msg string, fields ...entities.Field)  {}
-func (m *mockLogger) Error(msg string, fields ...entities.Field) { m.errors = append(m.errors, msg) }
+
+func (m *mockLogger) Info(msg string, fields ...entities.Field) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.infos = append(m.infos, msg)
+}
+
+func (m *mockLogger) Error(msg string, fields ...entities.Field) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors = append(m.errors, msg)
+}
 func (m *mockLogger) Fatal(msg string, fields ...entities.Field) {}
 
 type mockTimeProvider struct {
@@ -122,10 +171,59 @@ func newMockTimeProvider(t time.Time) *mockTimeProvider {
 
 func (m *mockTimeProvider) Now() time.Time { return m.now }
 
+// ========================================
+// Mock: WorkerLeaseInputPort
+// ========================================
+
+// mockWorkerLease はgrantedの間だけリーダーにする
+type mockWorkerLease struct {
+	granted   bool
+	campaigns []string
+}
+
+func (m *mockWorkerLease) Campaign(ctx context.Context, name, holder string, now time.Time) (bool, error) {
+	m.campaigns = append(m.campaigns, name)
+	return m.granted, nil
+}
+
+func (m *mockWorkerLease) Resign(ctx context.Context, name, holder string) error { return nil }
+
+func (m *mockWorkerLease) ListLeases(ctx context.Context, adminID uuid.UUID) ([]*entities.WorkerLease, error) {
+	return nil, nil
+}
+
 // ========================================
 // ポーリング制御テスト
 // ========================================
 
+func TestAkerunWorker_LeaderElection(t *testing.T) {
+	nowTime := time.Date(2026, 2, 17, 17, 5, 0, 0, time.UTC)
+
+	t.Run("リーダーでないインスタンスはポーリングしない", func(t *testing.T) {
+		gateway := newMockGateway()
+		lease := &mockWorkerLease{granted: false}
+		worker := infraakerun.NewAkerunWorker([]service.AkerunAccessGateway{gateway}, newMockBonusInteractor(nowTime.Add(-5*time.Minute)), newMockTimeProvider(nowTime), newMockLogger()).
+			WithLeaderElection(infra.NewLeaderElector(lease, newMockLogger()))
+
+		worker.PollForTest()
+
+		assert.Equal(t, []string{"akerun"}, lease.campaigns, "登録時に1回立候補する")
+		assert.Equal(t, 0, gateway.fetchCount)
+	})
+
+	t.Run("リーダーのインスタンスはポーリングする", func(t *testing.T) {
+		gateway := newMockGateway()
+		logger := newMockLogger()
+		worker := infraakerun.NewAkerunWorker([]service.AkerunAccessGateway{gateway}, newMockBonusInteractor(nowTime.Add(-5*time.Minute)), newMockTimeProvider(nowTime), logger).
+			WithLeaderElection(infra.NewLeaderElector(&mockWorkerLease{granted: true}, logger))
+
+		worker.PollForTest()
+
+		assert.Equal(t, 1, gateway.fetchCount)
+		assert.Contains(t, logger.infos, "Acquired worker leadership", "リーダーの交代はログに出る")
+	})
+}
+
 func TestAkerunWorker_Polling(t *testing.T) {
 	t.Run("通常モード: gapが10分以内の場合はFetchAccesses1回でInteractorに委譲", func(t *testing.T) {
 		nowTime := time.Date(2026, 2, 17, 17, 5, 0, 0, time.UTC)
@@ -137,7 +235,7 @@ func TestAkerunWorker_Polling(t *testing.T) {
 
 		interactorMock := newMockBonusInteractor(nowTime.Add(-5 * time.Minute)) // 5分前
 
-		worker := infraakerun.NewAkerunWorker(gateway, interactorMock, newMockTimeProvider(nowTime), newMockLogger())
+		worker := infraakerun.NewAkerunWorker([]service.AkerunAccessGateway{gateway}, interactorMock, newMockTimeProvider(nowTime), newMockLogger())
 		worker.SetRecoverySleepForTest(0)
 
 		worker.PollForTest()
@@ -158,7 +256,7 @@ func TestAkerunWorker_Polling(t *testing.T) {
 		gateway := newMockGateway()
 		interactorMock := newMockBonusInteractor(startTime)
 
-		worker := infraakerun.NewAkerunWorker(gateway, interactorMock, newMockTimeProvider(nowTime), newMockLogger())
+		worker := infraakerun.NewAkerunWorker([]service.AkerunAccessGateway{gateway}, interactorMock, newMockTimeProvider(nowTime), newMockLogger())
 		worker.SetRecoverySleepForTest(0)
 
 		worker.PollForTest()
@@ -175,7 +273,7 @@ func TestAkerunWorker_Polling(t *testing.T) {
 		gateway := newMockGateway()
 		interactorMock := newMockBonusInteractor(startTime)
 
-		worker := infraakerun.NewAkerunWorker(gateway, interactorMock, newMockTimeProvider(nowTime), newMockLogger())
+		worker := infraakerun.NewAkerunWorker([]service.AkerunAccessGateway{gateway}, interactorMock, newMockTimeProvider(nowTime), newMockLogger())
 		worker.SetRecoverySleepForTest(0)
 
 		worker.PollForTest()
@@ -198,7 +296,7 @@ func TestAkerunWorker_Polling(t *testing.T) {
 
 		interactorMock := newMockBonusInteractor(startTime)
 
-		worker := infraakerun.NewAkerunWorker(gateway, interactorMock, newMockTimeProvider(nowTime), newMockLogger())
+		worker := infraakerun.NewAkerunWorker([]service.AkerunAccessGateway{gateway}, interactorMock, newMockTimeProvider(nowTime), newMockLogger())
 		worker.SetRecoverySleepForTest(0)
 
 		worker.PollForTest()
@@ -217,7 +315,7 @@ func TestAkerunWorker_Polling(t *testing.T) {
 		gateway := newMockGateway()
 		interactorMock := newMockBonusInteractor(startTime)
 
-		worker := infraakerun.NewAkerunWorker(gateway, interactorMock, newMockTimeProvider(nowTime), newMockLogger())
+		worker := infraakerun.NewAkerunWorker([]service.AkerunAccessGateway{gateway}, interactorMock, newMockTimeProvider(nowTime), newMockLogger())
 		worker.SetRecoverySleepForTest(0)
 
 		worker.PollForTest()
@@ -249,7 +347,7 @@ func TestAkerunWorker_Polling(t *testing.T) {
 
 		interactorMock := newMockBonusInteractor(nowTime.Add(-5 * time.Minute))
 
-		worker := infraakerun.NewAkerunWorker(gateway, interactorMock, newMockTimeProvider(nowTime), newMockLogger())
+		worker := infraakerun.NewAkerunWorker([]service.AkerunAccessGateway{gateway}, interactorMock, newMockTimeProvider(nowTime), newMockLogger())
 		worker.SetRecoverySleepForTest(0)
 
 		worker.PollForTest()
@@ -257,6 +355,248 @@ func TestAkerunWorker_Polling(t *testing.T) {
 		assert.Equal(t, 1, gateway.fetchCount)
 		assert.Len(t, interactorMock.processedBatches, 0, "0件の場合はProcessAccesses呼ばれない")
 	})
+
+	t.Run("ポーリングのたびに失敗したアクセス記録の再試行を委譲する", func(t *testing.T) {
+		nowTime := time.Date(2026, 2, 17, 17, 5, 0, 0, time.UTC)
+
+		gateway := newMockGateway()
+		gateway.fetchErr = fmt.Errorf("API down")
+
+		interactorMock := newMockBonusInteractor(nowTime.Add(-5 * time.Minute))
+
+		worker := infraakerun.NewAkerunWorker([]service.AkerunAccessGateway{gateway}, interactorMock, newMockTimeProvider(nowTime), newMockLogger())
+		worker.SetRecoverySleepForTest(0)
+
+		worker.PollForTest()
+
+		// Akerun APIが落ちていても、キューに溜まった分の再試行は行う
+		assert.Equal(t, []time.Time{nowTime}, interactorMock.retriedAt)
+	})
+
+	t.Run("サーキットブレーカーがopenの間は前回ポーリング時刻を進めない", func(t *testing.T) {
+		nowTime := time.Date(2026, 2, 17, 17, 5, 0, 0, time.UTC)
+		lastPolledAt := nowTime.Add(-5 * time.Minute)
+
+		gateway := newMockGateway()
+		gateway.fetchErr = fmt.Errorf("akerun:org-1: %w", service.ErrCircuitOpen)
+
+		interactorMock := newMockBonusInteractor(lastPolledAt)
+
+		worker := infraakerun.NewAkerunWorker([]service.AkerunAccessGateway{gateway}, interactorMock, newMockTimeProvider(nowTime), newMockLogger())
+		worker.SetRecoverySleepForTest(0)
+
+		worker.PollForTest()
+
+		// 回復後に止まっていた期間をまとめて取得する
+		assert.Equal(t, lastPolledAt, interactorMock.lastPolledAt)
+		assert.Empty(t, interactorMock.processedBatches)
+		assert.Equal(t, []time.Time{nowTime}, interactorMock.retriedAt)
+	})
+}
+
+func TestAkerunWorker_Truncated(t *testing.T) {
+	t.Run("ページ数の上限で打ち切られても取得できた分は処理して前回ポーリング時刻を進める", func(t *testing.T) {
+		nowTime := time.Date(2026, 2, 17, 17, 5, 0, 0, time.UTC)
+
+		gateway := newMockGateway()
+		gateway.fetchFn = func(int) ([]entities.AccessRecord, error) {
+			return []entities.AccessRecord{{UserName: "テスト太郎", AccessedAt: nowTime.Add(-time.Minute)}},
+				fmt.Errorf("10 pages of 300 accesses: %w", service.ErrAccessesTruncated)
+		}
+		interactorMock := newMockBonusInteractor(nowTime.Add(-5 * time.Minute))
+
+		worker := infraakerun.NewAkerunWorker([]service.AkerunAccessGateway{gateway}, interactorMock, newMockTimeProvider(nowTime), newMockLogger())
+		worker.SetRecoverySleepForTest(0)
+
+		worker.PollForTest()
+
+		require.Len(t, interactorMock.processedBatches, 1)
+		assert.Len(t, interactorMock.processedBatches[0], 1)
+		assert.Equal(t, nowTime, interactorMock.lastPolledAt)
+	})
+}
+
+func TestAkerunWorker_MultipleOrganizations(t *testing.T) {
+	nowTime := time.Date(2026, 2, 17, 17, 5, 0, 0, time.UTC)
+
+	t.Run("組織ごとに前回ポーリング時刻を独立して進める", func(t *testing.T) {
+		main := newMockGateway()
+		main.org = entities.AkerunOrganization{ID: "org-main"}
+		main.accesses = []entities.AccessRecord{{ID: uuid.New(), UserName: "田中太郎", OrganizationID: "org-main"}}
+
+		branch := newMockGateway()
+		branch.org = entities.AkerunOrganization{ID: "org-branch"}
+		branch.fetchErr = fmt.Errorf("API down")
+
+		interactorMock := newMockBonusInteractor(nowTime.Add(-5 * time.Minute))
+
+		worker := infraakerun.NewAkerunWorker([]service.AkerunAccessGateway{main, branch}, interactorMock, newMockTimeProvider(nowTime), newMockLogger())
+		worker.SetRecoverySleepForTest(0)
+
+		worker.PollForTest()
+
+		assert.Equal(t, 1, main.fetchCount)
+		assert.Equal(t, 1, branch.fetchCount)
+		require.Len(t, interactorMock.processedBatches, 1)
+		assert.Equal(t, "org-main", interactorMock.processedBatches[0][0].OrganizationID)
+
+		// 失敗した組織のカーソルは進めず、次回同じ区間から取り直す
+		assert.Equal(t, nowTime, interactorMock.cursors["org-main"])
+		_, updated := interactorMock.cursors["org-branch"]
+		assert.False(t, updated)
+		assert.Equal(t, []time.Time{nowTime}, interactorMock.retriedAt, "再試行は全組織のポーリング後に1回だけ")
+	})
+
+	t.Run("組織ごとの前回ポーリング時刻から取得する", func(t *testing.T) {
+		main := newMockGateway()
+		main.org = entities.AkerunOrganization{ID: "org-main"}
+		branch := newMockGateway()
+		branch.org = entities.AkerunOrganization{ID: "org-branch"}
+
+		interactorMock := newMockBonusInteractor(nowTime.Add(-5 * time.Minute))
+		interactorMock.cursors["org-branch"] = nowTime.Add(-3 * time.Hour)
+
+		worker := infraakerun.NewAkerunWorker([]service.AkerunAccessGateway{main, branch}, interactorMock, newMockTimeProvider(nowTime), newMockLogger())
+		worker.SetRecoverySleepForTest(0)
+
+		worker.PollForTest()
+
+		require.Len(t, main.fetchCalls, 1, "通常モード")
+		assert.Equal(t, nowTime.Add(-5*time.Minute), main.fetchCalls[0].after)
+		require.Len(t, branch.fetchCalls, 3, "3時間分をリカバリモードで取得")
+		assert.Equal(t, nowTime.Add(-3*time.Hour), branch.fetchCalls[0].after)
+		assert.Equal(t, nowTime, interactorMock.cursors["org-branch"])
+	})
+
+	t.Run("未設定の組織はポーリングしない", func(t *testing.T) {
+		main := newMockGateway()
+		unconfigured := newMockGateway()
+		unconfigured.org = entities.AkerunOrganization{ID: "org-unconfigured"}
+		unconfigured.isConfigured = false
+
+		worker := infraakerun.NewAkerunWorker([]service.AkerunAccessGateway{main, unconfigured}, newMockBonusInteractor(nowTime.Add(-5*time.Minute)), newMockTimeProvider(nowTime), newMockLogger())
+
+		worker.PollForTest()
+
+		assert.Equal(t, 1, main.fetchCount)
+		assert.Equal(t, 0, unconfigured.fetchCount)
+	})
+}
+
+func TestAkerunWorker_Repoll(t *testing.T) {
+	ctx := context.Background()
+	repollAdminID := uuid.New()
+
+	type fixture struct {
+		main, branch *mockAkerunGateway
+		bonus        *mockBonusInteractor
+		repolls      inputport.AkerunRepollInputPort
+		repo         *testsupport.AkerunRepollRepository
+		worker       *infraakerun.AkerunWorker
+	}
+	setup := func() *fixture {
+		f := &fixture{main: newMockGateway(), branch: newMockGateway()}
+		f.main.org = entities.AkerunOrganization{ID: "org-main"}
+		f.branch.org = entities.AkerunOrganization{ID: "org-branch"}
+		f.bonus = newMockBonusInteractor(time.Now().Add(-5 * time.Minute))
+		f.repo = testsupport.NewAkerunRepollRepository()
+		orgs := entities.AkerunOrganizations{"org-main": f.main.org, "org-branch": f.branch.org}
+		users := testsupport.NewUserRepository()
+		users.Seed(&entities.User{ID: repollAdminID, Username: "admin", Role: entities.RoleAdmin, IsActive: true})
+		f.repolls = interactor.NewAkerunRepollInteractor(f.repo, users, orgs, newMockLogger())
+		f.worker = infraakerun.NewAkerunWorker([]service.AkerunAccessGateway{f.main, f.branch}, f.bonus, newMockTimeProvider(time.Now()), newMockLogger()).
+			WithRepolls(f.repolls)
+		f.worker.SetRecoverySleepForTest(0)
+		return f
+	}
+	request := func(t *testing.T, f *fixture, orgID string) (*entities.AkerunRepoll, time.Time) {
+		to := time.Now().Add(-time.Hour).Truncate(time.Minute)
+		repoll, err := f.repolls.RequestRepoll(ctx, &inputport.RequestAkerunRepollRequest{
+			OrganizationID: orgID,
+			From:           to.Add(-150 * time.Minute),
+			To:             to,
+			AdminID:        repollAdminID,
+		})
+		require.NoError(t, err)
+		return repoll, to.Add(-150 * time.Minute)
+	}
+
+	t.Run("すべての組織の期間を1時間ずつ取得し、前回ポーリング時刻は進めない", func(t *testing.T) {
+		f := setup()
+		f.main.accesses = []entities.AccessRecord{{ID: uuid.New(), UserName: "田中太郎", OrganizationID: "org-main"}}
+		repoll, from := request(t, f, "")
+
+		f.worker.RunRepollForTest()
+
+		require.Len(t, f.main.fetchCalls, 3)
+		require.Len(t, f.branch.fetchCalls, 3)
+		assert.Equal(t, from, f.main.fetchCalls[0].after)
+		assert.Equal(t, repoll.To, f.main.fetchCalls[2].before)
+		assert.Len(t, f.bonus.processedBatches, 3, "取得できた組織の分だけ処理する")
+		assert.Empty(t, f.bonus.cursors)
+
+		stored, err := f.repo.Read(ctx, repoll.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.AkerunRepollStatusSucceeded, stored.Status)
+		assert.Equal(t, 3, stored.CompletedWindows)
+		assert.Equal(t, 3, stored.FetchedAccesses)
+	})
+
+	t.Run("組織を指定すればその組織だけ取得する", func(t *testing.T) {
+		f := setup()
+		request(t, f, "org-branch")
+
+		f.worker.RunRepollForTest()
+
+		assert.Equal(t, 0, f.main.fetchCount)
+		assert.Equal(t, 3, f.branch.fetchCount)
+	})
+
+	t.Run("APIの失敗が続いている間は中断し、次回続きの期間から再開する", func(t *testing.T) {
+		f := setup()
+		f.main.fetchFn = func(callIdx int) ([]entities.AccessRecord, error) {
+			if callIdx == 1 {
+				return nil, service.ErrCircuitOpen
+			}
+			return nil, nil
+		}
+		repoll, from := request(t, f, "org-main")
+
+		f.worker.RunRepollForTest()
+
+		stored, _ := f.repo.Read(ctx, repoll.ID)
+		assert.Equal(t, entities.AkerunRepollStatusRunning, stored.Status)
+		assert.Equal(t, 1, stored.CompletedWindows)
+
+		f.worker.RunRepollForTest()
+
+		require.Len(t, f.main.fetchCalls, 4)
+		assert.Equal(t, from.Add(time.Hour), f.main.fetchCalls[2].after, "取得済みの期間は取り直さない")
+		stored, _ = f.repo.Read(ctx, repoll.ID)
+		assert.Equal(t, entities.AkerunRepollStatusSucceeded, stored.Status)
+	})
+
+	t.Run("APIのエラーは失敗として記録する", func(t *testing.T) {
+		f := setup()
+		f.main.fetchErr = fmt.Errorf("token expired")
+		repoll, _ := request(t, f, "org-main")
+
+		f.worker.RunRepollForTest()
+
+		assert.Equal(t, 1, f.main.fetchCount)
+		stored, _ := f.repo.Read(ctx, repoll.ID)
+		assert.Equal(t, entities.AkerunRepollStatusFailed, stored.Status)
+		assert.Contains(t, stored.ErrorMessage, "token expired")
+	})
+
+	t.Run("依頼がなければ何もしない", func(t *testing.T) {
+		f := setup()
+
+		f.worker.RunRepollForTest()
+
+		assert.Equal(t, 0, f.main.fetchCount)
+		assert.Equal(t, 0, f.branch.fetchCount)
+	})
 }
 
 // ========================================
@@ -302,6 +642,44 @@ func createMockAkerunServer(response akerunAPIResponse) *httptest.Server {
 	}))
 }
 
+// createPagedAkerunServer はIDがtotalから1までのアクセス記録を新しい順にlimit件ずつ返すサーバー
+// id_beforeより小さいIDの記録を返す（overlapならid_beforeの記録も含めて返す）
+// 受け取ったid_beforeを順に記録する
+func createPagedAkerunServer(total int, overlap bool, idBefores *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		*idBefores = append(*idBefores, query.Get("id_before"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		start := total
+		if idBefore := query.Get("id_before"); idBefore != "" {
+			start, _ = strconv.Atoi(idBefore)
+			if !overlap {
+				start--
+			}
+		}
+
+		response := akerunAPIResponse{Accesses: []akerunAccessJSON{}}
+		for id := start; id >= 1 && len(response.Accesses) < limit; id-- {
+			response.Accesses = append(response.Accesses, akerunAccessJSON{
+				ID:         json.Number(strconv.Itoa(id)),
+				Action:     "unlock",
+				AccessedAt: "2017-07-24T10:00:00Z",
+				User:       &akerunUserJSON{Name: fmt.Sprintf("user-%d", id)},
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func accessIDs(accesses []infraakerun.AccessRecord) []string {
+	ids := make([]string, 0, len(accesses))
+	for _, a := range accesses {
+		ids = append(ids, a.ID.String())
+	}
+	return ids
+}
+
 func createAkerunExampleResponse() akerunAPIResponse {
 	return akerunAPIResponse{
 		Accesses: []akerunAccessJSON{
@@ -390,6 +768,86 @@ func TestAkerunClient_GetAccesses(t *testing.T) {
 		assert.Contains(t, err.Error(), "401")
 	})
 
+	t.Run("失敗が続いたらサーキットブレーカーでAPIを呼ばなくなる", func(t *testing.T) {
+		var calls int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		breaker := infrabreaker.NewCircuitBreaker("akerun:O-ab345-678ij", infrabreaker.Settings{MaxFailures: 2, OpenTimeout: time.Hour})
+		client := infraakerun.NewAkerunClient(&infraakerun.AkerunConfig{
+			AccessToken:    "test-token",
+			OrganizationID: "O-ab345-678ij",
+			BaseURL:        server.URL,
+		}).WithCircuitBreaker(breaker)
+
+		for i := 0; i < 3; i++ {
+			_, err := client.GetAccesses(context.Background(), time.Now().Add(-time.Hour), time.Now(), 300)
+			require.Error(t, err)
+		}
+		_, err := client.GetAccesses(context.Background(), time.Now().Add(-time.Hour), time.Now(), 300)
+		assert.ErrorIs(t, err, service.ErrCircuitOpen)
+		assert.Equal(t, 2, calls, "openになった後はAPIを呼ばない")
+		assert.Equal(t, service.CircuitOpen, breaker.Status().State)
+	})
+
+	t.Run("ページが埋まっている間はid_beforeで次のページをたどる", func(t *testing.T) {
+		var idBefores []string
+		server := createPagedAkerunServer(7, false, &idBefores)
+		defer server.Close()
+
+		client := infraakerun.NewAkerunClient(&infraakerun.AkerunConfig{
+			AccessToken:    "test-token",
+			OrganizationID: "O-test",
+			BaseURL:        server.URL,
+		})
+
+		accesses, err := client.GetAccesses(context.Background(), time.Now().Add(-time.Hour), time.Now(), 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"7", "6", "5", "4", "3", "2", "1"}, accessIDs(accesses))
+		assert.Equal(t, []string{"", "5", "2"}, idBefores, "件数がlimitに満たないページで止める")
+	})
+
+	t.Run("ページの境界で重なった記録はIDで除く", func(t *testing.T) {
+		var idBefores []string
+		server := createPagedAkerunServer(5, true, &idBefores)
+		defer server.Close()
+
+		client := infraakerun.NewAkerunClient(&infraakerun.AkerunConfig{
+			AccessToken:    "test-token",
+			OrganizationID: "O-test",
+			BaseURL:        server.URL,
+		})
+
+		accesses, err := client.GetAccesses(context.Background(), time.Now().Add(-time.Hour), time.Now(), 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"5", "4", "3", "2", "1"}, accessIDs(accesses))
+		assert.Equal(t, []string{"", "3", "1"}, idBefores)
+	})
+
+	t.Run("MaxPagesで取り切れなければ取得できた分とErrAccessesTruncatedを返す", func(t *testing.T) {
+		var idBefores []string
+		server := createPagedAkerunServer(10, false, &idBefores)
+		defer server.Close()
+
+		client := infraakerun.NewAkerunClient(&infraakerun.AkerunConfig{
+			AccessToken:    "test-token",
+			OrganizationID: "O-test",
+			BaseURL:        server.URL,
+			MaxPages:       2,
+		})
+
+		accesses, err := client.FetchAccesses(context.Background(), time.Now().Add(-time.Hour), time.Now(), 3)
+
+		assert.ErrorIs(t, err, service.ErrAccessesTruncated)
+		assert.Len(t, accesses, 6, "取得できた2ページ分は返す")
+		assert.Len(t, idBefores, 2, "MaxPagesを超えてAPIを呼ばない")
+	})
+
 	t.Run("IsConfiguredの動作確認", func(t *testing.T) {
 		client1 := infraakerun.NewAkerunClient(&infraakerun.AkerunConfig{
 			AccessToken:    "token",
@@ -439,6 +897,11 @@ func TestAkerunClient_FetchAccesses(t *testing.T) {
 		assert.Equal(t, "Photosynth太郎", accesses[0].UserName)
 		assert.Equal(t, time.Date(2017, 7, 24, 6, 37, 19, 0, time.UTC), accesses[0].AccessedAt)
 		assert.NotEqual(t, accesses[0].ID, accesses[1].ID, "異なるアクセスは異なるIDを持つ")
+
+		// 組織とドアの情報が付く
+		assert.Equal(t, "O-test", accesses[0].OrganizationID)
+		assert.Equal(t, "A1030001", accesses[0].DoorID)
+		assert.Equal(t, "執務室表口", accesses[0].DoorName)
 	})
 
 	t.Run("userがnullのアクセスレコードはフィルタされる", func(t *testing.T) {
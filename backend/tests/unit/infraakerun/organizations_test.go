@@ -0,0 +1,58 @@
+package infraakerun_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gity/point-system/gateways/infra/infraakerun"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeOrganizationsFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "akerun_organizations.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadOrganizations(t *testing.T) {
+	t.Run("組織ごとのトークンとドアの規則を読み込む", func(t *testing.T) {
+		path := writeOrganizationsFile(t, `[
+			{"id": "O-main", "name": "本社", "access_token": "token-main",
+			 "doors": [{"id": "A1030001", "name": "正面入口", "multiplier": 1.5}]},
+			{"id": "O-branch", "name": "支社", "access_token": "token-branch"}
+		]`)
+
+		configs, err := infraakerun.LoadOrganizations(path)
+
+		require.NoError(t, err)
+		require.Len(t, configs, 2)
+		assert.Equal(t, "O-main", configs[0].OrganizationID)
+		assert.Equal(t, "token-main", configs[0].AccessToken)
+		require.Len(t, configs[0].Doors, 1)
+		assert.Equal(t, "A1030001", configs[0].Doors[0].DoorID)
+		assert.Equal(t, 1.5, configs[0].Doors[0].Multiplier)
+
+		org := infraakerun.NewAkerunClient(configs[1]).Organization()
+		assert.Equal(t, "支社", org.Name)
+		assert.Empty(t, org.Doors, "ドアの指定がなければ全ドアが対象")
+	})
+
+	t.Run("不正な設定はエラー", func(t *testing.T) {
+		cases := map[string]string{
+			"トークンなし":  `[{"id": "O-main"}]`,
+			"組織IDの重複": `[{"id": "O-main", "access_token": "a"}, {"id": "O-main", "access_token": "b"}]`,
+			"ドアIDなし":  `[{"id": "O-main", "access_token": "a", "doors": [{"name": "正面入口"}]}]`,
+			"負の倍率":    `[{"id": "O-main", "access_token": "a", "doors": [{"id": "A1", "multiplier": -1}]}]`,
+			"JSONが不正": `{"id": "O-main"}`,
+		}
+		for name, content := range cases {
+			t.Run(name, func(t *testing.T) {
+				_, err := infraakerun.LoadOrganizations(writeOrganizationsFile(t, content))
+				assert.Error(t, err)
+			})
+		}
+	})
+}
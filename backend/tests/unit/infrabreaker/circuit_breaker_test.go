@@ -0,0 +1,122 @@
+package infrabreaker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/gateways/infra/infrabreaker"
+	"github.com/gity/point-system/gateways/infra/infralogger"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errUpstream = errors.New("upstream down")
+
+func TestCircuitBreaker(t *testing.T) {
+	type fixture struct {
+		now     time.Time
+		breaker *infrabreaker.CircuitBreaker
+		calls   int
+	}
+	setup := func(settings infrabreaker.Settings) *fixture {
+		f := &fixture{now: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)}
+		f.breaker = infrabreaker.NewCircuitBreaker("email", settings).WithClock(func() time.Time { return f.now })
+		return f
+	}
+	call := func(f *fixture, err error) error {
+		return f.breaker.Execute(func() error {
+			f.calls++
+			return err
+		})
+	}
+
+	t.Run("連続して失敗したらopenにして呼び出さない", func(t *testing.T) {
+		f := setup(infrabreaker.Settings{MaxFailures: 3, OpenTimeout: time.Minute})
+		for i := 0; i < 3; i++ {
+			assert.ErrorIs(t, call(f, errUpstream), errUpstream)
+		}
+
+		err := call(f, nil)
+		assert.ErrorIs(t, err, service.ErrCircuitOpen)
+		assert.Equal(t, 3, f.calls)
+
+		status := f.breaker.Status()
+		assert.Equal(t, service.CircuitOpen, status.State)
+		assert.Equal(t, 3, status.ConsecutiveFailures)
+		require.NotNil(t, status.OpenedAt)
+		assert.Equal(t, f.now, *status.OpenedAt)
+	})
+
+	t.Run("成功すると連続失敗の数を戻す", func(t *testing.T) {
+		f := setup(infrabreaker.Settings{MaxFailures: 2, OpenTimeout: time.Minute})
+		require.Error(t, call(f, errUpstream))
+		require.NoError(t, call(f, nil))
+		require.Error(t, call(f, errUpstream))
+
+		assert.Equal(t, service.CircuitClosed, f.breaker.Status().State)
+		assert.Equal(t, 1, f.breaker.Status().ConsecutiveFailures)
+	})
+
+	t.Run("呼び出し側のキャンセルは失敗として数えない", func(t *testing.T) {
+		f := setup(infrabreaker.Settings{MaxFailures: 1, OpenTimeout: time.Minute})
+		require.ErrorIs(t, call(f, context.Canceled), context.Canceled)
+
+		assert.Equal(t, service.CircuitClosed, f.breaker.Status().State)
+	})
+
+	t.Run("OpenTimeout後はhalf_openで試し、成功すればclosedに戻す", func(t *testing.T) {
+		f := setup(infrabreaker.Settings{MaxFailures: 1, OpenTimeout: time.Minute, HalfOpenRequests: 1})
+		require.Error(t, call(f, errUpstream))
+
+		f.now = f.now.Add(time.Minute)
+		assert.Equal(t, service.CircuitHalfOpen, f.breaker.Status().State)
+		require.NoError(t, call(f, nil))
+
+		assert.Equal(t, service.CircuitClosed, f.breaker.Status().State)
+		assert.Nil(t, f.breaker.Status().OpenedAt)
+		assert.Equal(t, 2, f.calls)
+	})
+
+	t.Run("half_openで失敗すれば再びopenにする", func(t *testing.T) {
+		f := setup(infrabreaker.Settings{MaxFailures: 1, OpenTimeout: time.Minute})
+		require.Error(t, call(f, errUpstream))
+
+		f.now = f.now.Add(time.Minute)
+		require.ErrorIs(t, call(f, errUpstream), errUpstream)
+
+		assert.Equal(t, service.CircuitOpen, f.breaker.Status().State)
+		assert.ErrorIs(t, call(f, nil), service.ErrCircuitOpen)
+		assert.Equal(t, 2, f.calls)
+	})
+
+	t.Run("half_openでは設定した数を超えて呼び出さない", func(t *testing.T) {
+		f := setup(infrabreaker.Settings{MaxFailures: 1, OpenTimeout: time.Minute, HalfOpenRequests: 1})
+		require.Error(t, call(f, errUpstream))
+		f.now = f.now.Add(time.Minute)
+
+		// 試している呼び出しが終わる前の呼び出しは通さない
+		err := f.breaker.Execute(func() error {
+			return call(f, nil)
+		})
+		assert.ErrorIs(t, err, service.ErrCircuitOpen)
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	registry := infrabreaker.NewRegistry(infrabreaker.Settings{MaxFailures: 1, OpenTimeout: time.Minute}, infralogger.NewLogger())
+
+	email := registry.Breaker("email")
+	assert.Same(t, email, registry.Breaker("email"), "同じ名前なら同じサーキットブレーカー")
+	_ = email.Execute(func() error { return errUpstream })
+	registry.Breaker("akerun:org-1")
+
+	statuses := registry.CircuitBreakers()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "akerun:org-1", statuses[0].Name)
+	assert.Equal(t, service.CircuitClosed, statuses[0].State)
+	assert.Equal(t, "email", statuses[1].Name)
+	assert.Equal(t, service.CircuitOpen, statuses[1].State)
+}
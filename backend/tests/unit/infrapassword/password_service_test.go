@@ -0,0 +1,103 @@
+package infrapassword_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gity/point-system/gateways/infra/infrapassword"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// テストを速くするため、パラメータは最小に近い値にする
+var testArgon2id = infrapassword.Argon2idParams{MemoryKiB: 64, Iterations: 1, Parallelism: 1}
+
+func newService(t *testing.T, algorithm string, bcryptCost int, argon2id infrapassword.Argon2idParams) service.PasswordService {
+	t.Helper()
+	svc, err := infrapassword.NewPasswordService(infrapassword.Config{Algorithm: algorithm, BcryptCost: bcryptCost, Argon2id: argon2id})
+	require.NoError(t, err)
+	return svc
+}
+
+func TestArgon2idPasswordService(t *testing.T) {
+	svc := infrapassword.NewArgon2idPasswordService(testArgon2id)
+
+	t.Run("パラメータを含むPHC形式でハッシュ化し、検証できる", func(t *testing.T) {
+		hash, err := svc.HashPassword("password123")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"), hash)
+		assert.True(t, svc.VerifyPassword(hash, "password123"))
+		assert.False(t, svc.VerifyPassword(hash, "password124"))
+		assert.False(t, svc.NeedsRehash(hash))
+
+		again, err := svc.HashPassword("password123")
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, again, "ソルトは毎回変える")
+	})
+
+	t.Run("パラメータが変わったハッシュは作り直しが必要", func(t *testing.T) {
+		hash, err := svc.HashPassword("password123")
+		require.NoError(t, err)
+
+		stronger := infrapassword.NewArgon2idPasswordService(infrapassword.Argon2idParams{MemoryKiB: 128, Iterations: 1, Parallelism: 1})
+		assert.True(t, stronger.VerifyPassword(hash, "password123"), "検証はハッシュのパラメータで行う")
+		assert.True(t, stronger.NeedsRehash(hash))
+	})
+
+	t.Run("壊れたハッシュは検証に失敗する", func(t *testing.T) {
+		for _, hash := range []string{"", "$argon2id$v=19$m=64,t=1,p=1$", "$argon2id$v=19$m=x,t=1,p=1$c2FsdA$a2V5", "$argon2id$v=19$m=64,t=0,p=1$c2FsdA$a2V5"} {
+			assert.False(t, svc.VerifyPassword(hash, "password123"), hash)
+			assert.True(t, svc.NeedsRehash(hash), hash)
+		}
+	})
+}
+
+func TestPasswordService(t *testing.T) {
+	t.Run("bcryptのコストが変わったハッシュは作り直しが必要", func(t *testing.T) {
+		old := newService(t, infrapassword.AlgorithmBcrypt, 4, testArgon2id)
+		hash, err := old.HashPassword("password123")
+		require.NoError(t, err)
+		assert.False(t, old.NeedsRehash(hash))
+
+		current := newService(t, infrapassword.AlgorithmBcrypt, 5, testArgon2id)
+		assert.True(t, current.VerifyPassword(hash, "password123"))
+		assert.True(t, current.NeedsRehash(hash))
+	})
+
+	t.Run("argon2idに切り替えても既存のbcryptのハッシュを検証でき、作り直しが必要と判定する", func(t *testing.T) {
+		hash, err := newService(t, infrapassword.AlgorithmBcrypt, 4, testArgon2id).HashPassword("password123")
+		require.NoError(t, err)
+
+		svc := newService(t, infrapassword.AlgorithmArgon2id, 4, testArgon2id)
+		assert.True(t, svc.VerifyPassword(hash, "password123"))
+		assert.False(t, svc.VerifyPassword(hash, "wrong"))
+		assert.True(t, svc.NeedsRehash(hash))
+
+		rehashed, err := svc.HashPassword("password123")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(rehashed, "$argon2id$"))
+		assert.True(t, svc.VerifyPassword(rehashed, "password123"))
+		assert.False(t, svc.NeedsRehash(rehashed))
+	})
+
+	t.Run("bcryptに戻しても既存のargon2idのハッシュを検証できる", func(t *testing.T) {
+		hash, err := newService(t, infrapassword.AlgorithmArgon2id, 4, testArgon2id).HashPassword("password123")
+		require.NoError(t, err)
+
+		svc := newService(t, infrapassword.AlgorithmBcrypt, 4, testArgon2id)
+		assert.True(t, svc.VerifyPassword(hash, "password123"))
+		assert.True(t, svc.NeedsRehash(hash))
+	})
+
+	t.Run("対応していない形式のハッシュは検証に失敗する", func(t *testing.T) {
+		svc := newService(t, infrapassword.AlgorithmBcrypt, 4, testArgon2id)
+		assert.False(t, svc.VerifyPassword("$scrypt$ln=16,r=8,p=1$c2FsdA$a2V5", "password123"))
+		assert.False(t, svc.VerifyPassword("password123", "password123"))
+	})
+
+	t.Run("対応していないアルゴリズムはエラー", func(t *testing.T) {
+		_, err := infrapassword.NewPasswordService(infrapassword.Config{Algorithm: "md5", BcryptCost: 4, Argon2id: testArgon2id})
+		assert.Error(t, err)
+	})
+}
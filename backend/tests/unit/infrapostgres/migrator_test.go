@@ -0,0 +1,63 @@
+package infrapostgres_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ========================================
+// LoadMigrations Tests
+// ========================================
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("バージョン順に読み込まれる", func(t *testing.T) {
+		source := fstest.MapFS{
+			"002_add_b.sql": {Data: []byte("SELECT 2;")},
+			"001_init.sql":  {Data: []byte("SELECT 1;")},
+			"010_add_c.sql": {Data: []byte("SELECT 10;")},
+			"README.md":     {Data: []byte("ignored")},
+		}
+
+		migs, err := infrapostgres.LoadMigrations(source)
+		require.NoError(t, err)
+		require.Len(t, migs, 3)
+		assert.Equal(t, 1, migs[0].Version)
+		assert.Equal(t, "init", migs[0].Name)
+		assert.Equal(t, "SELECT 1;", migs[0].SQL)
+		assert.Equal(t, 2, migs[1].Version)
+		assert.Equal(t, 10, migs[2].Version)
+		assert.Equal(t, "add_c", migs[2].Name)
+	})
+
+	t.Run("バージョンが重複している場合エラー", func(t *testing.T) {
+		source := fstest.MapFS{
+			"001_init.sql":  {Data: []byte("SELECT 1;")},
+			"001_other.sql": {Data: []byte("SELECT 1;")},
+		}
+
+		_, err := infrapostgres.LoadMigrations(source)
+		assert.Error(t, err)
+	})
+
+	t.Run("ファイル名の形式が不正な場合エラー", func(t *testing.T) {
+		source := fstest.MapFS{
+			"init.sql": {Data: []byte("SELECT 1;")},
+		}
+
+		_, err := infrapostgres.LoadMigrations(source)
+		assert.Error(t, err)
+	})
+
+	t.Run("埋め込みマイグレーションが読み込める", func(t *testing.T) {
+		migs, err := infrapostgres.LoadMigrations(migrations.FS)
+		require.NoError(t, err)
+		require.NotEmpty(t, migs)
+		assert.Equal(t, 1, migs[0].Version)
+		assert.Equal(t, "initial_schema", migs[0].Name)
+	})
+}
@@ -0,0 +1,197 @@
+package infrapush_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/infra/infrapush"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testNotification() *entities.Notification {
+	return &entities.Notification{
+		UserID: uuid.New(),
+		Type:   entities.NotificationTypePointsReceived,
+		Title:  "ポイントを受け取りました",
+		Body:   "テストさんから100ポイントを受け取りました",
+		Data:   map[string]string{"amount": "100"},
+	}
+}
+
+func newFCMTestClient(t *testing.T, handler http.HandlerFunc) (*infrapush.FCMClient, *int32) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	var tokenRequests int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokenRequests, 1)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.PostForm.Get("grant_type"))
+		assert.Len(t, strings.Split(r.PostForm.Get("assertion"), "."), 3)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "access-token", "expires_in": 3600})
+	})
+	mux.HandleFunc("/v1/projects/test-project/messages:send", handler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := infrapush.NewFCMClient(&infrapush.FCMCredentials{
+		ProjectID:   "test-project",
+		ClientEmail: "push@test-project.iam.gserviceaccount.com",
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		TokenURI:    server.URL + "/token",
+	}, server.URL, 0)
+	require.NoError(t, err)
+	return client, &tokenRequests
+}
+
+func TestFCMClient_Send(t *testing.T) {
+	device := &entities.DeviceToken{Platform: entities.DevicePlatformAndroid, Token: "fcm-token"}
+
+	t.Run("アクセストークンを取得して送り、2回目以降は使い回す", func(t *testing.T) {
+		var body map[string]map[string]interface{}
+		client, tokenRequests := newFCMTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer access-token", r.Header.Get("Authorization"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			w.WriteHeader(http.StatusOK)
+		})
+
+		require.NoError(t, client.Send(context.Background(), device, testNotification()))
+		require.NoError(t, client.Send(context.Background(), device, testNotification()))
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(tokenRequests))
+		assert.Equal(t, "fcm-token", body["message"]["token"])
+		data := body["message"]["data"].(map[string]interface{})
+		assert.Equal(t, "points_received", data["type"])
+		assert.Equal(t, "100", data["amount"])
+	})
+
+	t.Run("UNREGISTEREDはErrPushTokenUnregistered", func(t *testing.T) {
+		client, _ := newFCMTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"status":"NOT_FOUND","details":[{"errorCode":"UNREGISTERED"}]}}`))
+		})
+
+		err := client.Send(context.Background(), device, testNotification())
+		assert.ErrorIs(t, err, service.ErrPushTokenUnregistered)
+	})
+
+	t.Run("その他のエラーはそのまま返す", func(t *testing.T) {
+		client, _ := newFCMTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+		err := client.Send(context.Background(), device, testNotification())
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, service.ErrPushTokenUnregistered))
+	})
+}
+
+func newAPNsTestClient(t *testing.T, handler http.HandlerFunc) *infrapush.APNsClient {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "AuthKey.p8")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := infrapush.NewAPNsClient(infrapush.APNsConfig{
+		KeyFile: keyFile,
+		KeyID:   "KEYID12345",
+		TeamID:  "TEAMID1234",
+		Topic:   "com.example.points",
+		BaseURL: server.URL,
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestAPNsClient_Send(t *testing.T) {
+	device := &entities.DeviceToken{Platform: entities.DevicePlatformIOS, Token: "apns-token"}
+
+	t.Run("トピックと認証トークンを付けて送る", func(t *testing.T) {
+		var payload map[string]interface{}
+		client := newAPNsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/3/device/apns-token", r.URL.Path)
+			assert.Equal(t, "com.example.points", r.Header.Get("apns-topic"))
+			assert.Equal(t, "alert", r.Header.Get("apns-push-type"))
+			assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "bearer "))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			w.WriteHeader(http.StatusOK)
+		})
+
+		require.NoError(t, client.Send(context.Background(), device, testNotification()))
+		aps := payload["aps"].(map[string]interface{})
+		alert := aps["alert"].(map[string]interface{})
+		assert.Equal(t, "ポイントを受け取りました", alert["title"])
+		assert.Equal(t, "points_received", payload["type"])
+	})
+
+	t.Run("410とBadDeviceTokenはErrPushTokenUnregistered", func(t *testing.T) {
+		for _, tc := range []struct {
+			status int
+			reason string
+		}{
+			{http.StatusGone, "Unregistered"},
+			{http.StatusBadRequest, "BadDeviceToken"},
+		} {
+			client := newAPNsTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(`{"reason":"` + tc.reason + `"}`))
+			})
+			err := client.Send(context.Background(), device, testNotification())
+			assert.ErrorIs(t, err, service.ErrPushTokenUnregistered, tc.reason)
+		}
+	})
+}
+
+type recordingPushService struct {
+	name string
+	sent *[]string
+}
+
+func (s *recordingPushService) Send(ctx context.Context, device *entities.DeviceToken, notification *entities.Notification) error {
+	*s.sent = append(*s.sent, s.name)
+	return nil
+}
+
+func TestPlatformPushService_Send(t *testing.T) {
+	var sent []string
+	svc := infrapush.NewPlatformPushService(
+		map[entities.DevicePlatform]service.PushNotificationService{
+			entities.DevicePlatformIOS: &recordingPushService{name: "apns", sent: &sent},
+		},
+		&recordingPushService{name: "console", sent: &sent},
+	)
+
+	require.NoError(t, svc.Send(context.Background(), &entities.DeviceToken{Platform: entities.DevicePlatformIOS}, testNotification()))
+	require.NoError(t, svc.Send(context.Background(), &entities.DeviceToken{Platform: entities.DevicePlatformAndroid}, testNotification()))
+
+	// 配信先が未設定のプラットフォームはfallbackへ
+	assert.Equal(t, []string{"apns", "console"}, sent)
+}
@@ -0,0 +1,128 @@
+package infraqrpayload_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/infra/infraqrpayload"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "qr-payload-codec-test-signing-secret"
+
+func TestCodec_EncodeDecode(t *testing.T) {
+	codec := infraqrpayload.NewCodec(testSecret)
+
+	t.Run("個人QRコードは無期限のディープリンクになり、読み取れる", func(t *testing.T) {
+		userID := uuid.New()
+		data, err := codec.Encode(entities.NewPersonalQRPayload(userID))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(data, "gity://qr?"), data)
+		assert.NotContains(t, data, "exp=")
+
+		payload, err := codec.Decode(data)
+		require.NoError(t, err)
+		assert.Equal(t, entities.QRPayloadTypeUser, payload.Type)
+		assert.Equal(t, entities.QRPayloadVersionCurrent, payload.Version)
+		assert.False(t, payload.IsLegacy())
+		id, err := payload.UserID()
+		require.NoError(t, err)
+		assert.Equal(t, userID, id)
+	})
+
+	t.Run("受取用QRコードは金額と有効期限を含む", func(t *testing.T) {
+		amount := int64(500)
+		qrCode, err := entities.NewReceiveQRCode(uuid.New(), &amount)
+		require.NoError(t, err)
+
+		data, err := codec.Encode(entities.NewQRCodePayload(qrCode))
+		require.NoError(t, err)
+		payload, err := codec.Decode(data)
+		require.NoError(t, err)
+		assert.Equal(t, entities.QRPayloadTypeReceive, payload.Type)
+		assert.Equal(t, qrCode.Code, payload.Data)
+		require.NotNil(t, payload.Amount)
+		assert.Equal(t, amount, *payload.Amount)
+		require.NotNil(t, payload.ExpiresAt)
+		assert.Equal(t, qrCode.ExpiresAt.Unix(), payload.ExpiresAt.Unix())
+	})
+
+	t.Run("書き換えたデータや別の鍵で署名したデータは読み取れない", func(t *testing.T) {
+		qrCode, err := entities.NewSendQRCode(uuid.New(), 100)
+		require.NoError(t, err)
+		data, err := codec.Encode(entities.NewQRCodePayload(qrCode))
+		require.NoError(t, err)
+
+		_, err = codec.Decode(strings.Replace(data, "a=100", "a=10000", 1))
+		assert.ErrorIs(t, err, entities.ErrInvalidQRPayload)
+
+		other, err := infraqrpayload.NewCodec("another-qr-payload-signing-secret").Encode(entities.NewQRCodePayload(qrCode))
+		require.NoError(t, err)
+		_, err = codec.Decode(other)
+		assert.ErrorIs(t, err, entities.ErrInvalidQRPayload)
+	})
+
+	t.Run("有効期限を過ぎたデータは期限切れ", func(t *testing.T) {
+		qrCode, err := entities.NewSendQRCode(uuid.New(), 100)
+		require.NoError(t, err)
+		qrCode.ExpiresAt = time.Now().Add(-time.Minute)
+		data, err := codec.Encode(entities.NewQRCodePayload(qrCode))
+		require.NoError(t, err)
+
+		_, err = codec.Decode(data)
+		assert.ErrorIs(t, err, entities.ErrQRCodeExpired)
+	})
+
+	t.Run("新しいバージョンのデータは更新を促す", func(t *testing.T) {
+		_, err := codec.Decode("gity://qr?p=abc&t=send&v=2&sig=x")
+		assert.ErrorIs(t, err, entities.ErrUnsupportedQRPayloadVersion)
+	})
+
+	t.Run("署名のないディープリンクは読み取れない", func(t *testing.T) {
+		_, err := codec.Decode("gity://qr?p=" + uuid.NewString() + "&t=user&v=1")
+		assert.ErrorIs(t, err, entities.ErrInvalidQRPayload)
+	})
+}
+
+func TestCodec_DecodeLegacy(t *testing.T) {
+	codec := infraqrpayload.NewCodec(testSecret)
+	userID := uuid.New()
+
+	t.Run("旧形式は署名なしのバージョン0として読む", func(t *testing.T) {
+		tests := []struct {
+			data     string
+			wantType entities.QRPayloadType
+			wantData string
+			amount   *int64
+		}{
+			{entities.GeneratePersonalQRCode(userID), entities.QRPayloadTypeUser, userID.String(), nil},
+			{"receive:abc", entities.QRPayloadTypeReceive, "abc", nil},
+			{"receive:abc:500", entities.QRPayloadTypeReceive, "abc", ptr(500)},
+			{"send:abc:300", entities.QRPayloadTypeSend, "abc", ptr(300)},
+		}
+		for _, tt := range tests {
+			payload, err := codec.Decode(tt.data)
+			require.NoError(t, err, tt.data)
+			assert.True(t, payload.IsLegacy(), tt.data)
+			assert.Equal(t, tt.wantType, payload.Type, tt.data)
+			assert.Equal(t, tt.wantData, payload.Data, tt.data)
+			assert.Equal(t, tt.amount, payload.Amount, tt.data)
+			assert.Nil(t, payload.ExpiresAt, tt.data)
+		}
+	})
+
+	t.Run("形式に合わない文字列は読み取れない", func(t *testing.T) {
+		for _, data := range []string{"", "abc", "user:not-a-uuid", "send:abc", "receive:abc:x", "receive::1", "other:abc", "https://example.com/qr"} {
+			_, err := codec.Decode(data)
+			assert.ErrorIs(t, err, entities.ErrInvalidQRPayload, data)
+		}
+	})
+}
+
+func ptr(v int64) *int64 {
+	return &v
+}
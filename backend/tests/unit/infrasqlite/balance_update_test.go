@@ -0,0 +1,131 @@
+package infrasqlite_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/gateways/infra/infrasqlite"
+	"github.com/gity/point-system/gateways/repository/datasource/dsmysql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// seedUsers は残高balanceのユーザーをn人作成する
+func seedUsers(t *testing.T, users dsmysql.UserDataSource, n int, balance int64) []*entities.User {
+	t.Helper()
+	created := make([]*entities.User, n)
+	for i := range created {
+		name := fmt.Sprintf("balance_user_%03d", i)
+		user, err := entities.NewUser(name, name+"@example.com", "hash", name, "First", "Last")
+		require.NoError(t, err)
+		user.Balance = balance
+		require.NoError(t, users.Insert(context.Background(), user))
+		created[i] = user
+	}
+	return created
+}
+
+// countStatements はdbで実行したSELECT・UPDATEの数を数える
+func countStatements(t *testing.T, db infrapostgres.DB) (selects, updates *int) {
+	t.Helper()
+	selects, updates = new(int), new(int)
+	require.NoError(t, db.GetDB().Callback().Query().After("gorm:query").Register("test:count_select", func(*gorm.DB) { *selects++ }))
+	require.NoError(t, db.GetDB().Callback().Update().After("gorm:update").Register("test:count_update", func(*gorm.DB) { *updates++ }))
+	return selects, updates
+}
+
+// ========================================
+// UpdateBalancesWithLock Tests
+// ========================================
+
+func TestUpdateBalancesWithLockOnSQLite(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("数百人の残高を1回のロックと1回の更新で変え、同じユーザーへの更新は順に適用する", func(t *testing.T) {
+		db := setupDB(t, infrasqlite.MemoryPath)
+		users := dspostgresimpl.NewUserDataSource(db)
+		created := seedUsers(t, users, 300, 1000)
+
+		// 逆順に渡し、先頭のユーザーには加算の後に減算を重ねる
+		updates := make([]dsmysql.BalanceUpdate, 0, len(created)+1)
+		for i := len(created) - 1; i >= 0; i-- {
+			updates = append(updates, dsmysql.BalanceUpdate{UserID: created[i].ID, Amount: int64(i), IsDeduct: i%2 == 1})
+		}
+		updates = append(updates, dsmysql.BalanceUpdate{UserID: created[0].ID, Amount: 1000, IsDeduct: true})
+
+		selects, updatesRun := countStatements(t, db)
+		require.NoError(t, users.UpdateBalancesWithLock(ctx, updates))
+		assert.Equal(t, 1, *selects)
+		assert.Equal(t, 1, *updatesRun)
+
+		for i, user := range created {
+			stored, err := users.Select(ctx, user.ID)
+			require.NoError(t, err)
+			want := int64(1000 + i)
+			switch {
+			case i == 0:
+				want = 0
+			case i%2 == 1:
+				want = int64(1000 - i)
+			}
+			assert.Equal(t, want, stored.Balance, "user %d", i)
+			assert.Equal(t, 2, stored.Version, "user %d", i)
+		}
+	})
+
+	t.Run("1件でも残高が足りなければどのユーザーの残高も変えない", func(t *testing.T) {
+		db := setupDB(t, infrasqlite.MemoryPath)
+		users := dspostgresimpl.NewUserDataSource(db)
+		created := seedUsers(t, users, 3, 100)
+
+		err := users.UpdateBalancesWithLock(ctx, []dsmysql.BalanceUpdate{
+			{UserID: created[0].ID, Amount: 50},
+			{UserID: created[1].ID, Amount: 60, IsDeduct: true},
+			{UserID: created[1].ID, Amount: 60, IsDeduct: true},
+		})
+		assert.ErrorIs(t, err, entities.ErrInsufficientBalance)
+
+		for _, user := range created {
+			stored, err := users.Select(ctx, user.ID)
+			require.NoError(t, err)
+			assert.Equal(t, int64(100), stored.Balance)
+		}
+	})
+
+	t.Run("確保中の分はその確保を使う減算でしか使えない", func(t *testing.T) {
+		db := setupDB(t, infrasqlite.MemoryPath)
+		users := dspostgresimpl.NewUserDataSource(db)
+		created := seedUsers(t, users, 2, 100)
+		require.NoError(t, users.UpdateReservedBalanceWithLock(ctx, created[0].ID, 80, false))
+
+		err := users.UpdateBalancesWithLock(ctx, []dsmysql.BalanceUpdate{{UserID: created[0].ID, Amount: 50, IsDeduct: true}})
+		assert.ErrorIs(t, err, entities.ErrInsufficientBalance)
+
+		require.NoError(t, users.UpdateBalancesWithLock(ctx, []dsmysql.BalanceUpdate{
+			{UserID: created[0].ID, Amount: 80, IsDeduct: true, Reserved: 80},
+			{UserID: created[1].ID, Amount: 80},
+		}))
+		sender, err := users.Select(ctx, created[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(20), sender.Balance)
+		assert.Equal(t, int64(0), sender.ReservedBalance)
+	})
+
+	t.Run("存在しないユーザーが含まれていればErrUserNotFound", func(t *testing.T) {
+		db := setupDB(t, infrasqlite.MemoryPath)
+		users := dspostgresimpl.NewUserDataSource(db)
+		created := seedUsers(t, users, 1, 100)
+
+		err := users.UpdateBalancesWithLock(ctx, []dsmysql.BalanceUpdate{
+			{UserID: created[0].ID, Amount: 10},
+			{UserID: uuid.New(), Amount: 10},
+		})
+		assert.ErrorIs(t, err, entities.ErrUserNotFound)
+	})
+}
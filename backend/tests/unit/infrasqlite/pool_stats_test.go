@@ -0,0 +1,29 @@
+package infrasqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ========================================
+// PoolStatsMonitor Tests
+// ========================================
+
+func TestPoolStatsMonitorOnSQLite(t *testing.T) {
+	db := setupDB(t, filepath.Join(t.TempDir(), "pool.db"))
+	require.NoError(t, db.GetDB().WithContext(context.Background()).Exec("SELECT 1").Error)
+
+	stats := infrapostgres.NewPoolStatsMonitor(db).DBStats()
+
+	// SQLiteはリードレプリカを持たないのでプライマリだけ返す
+	require.Len(t, stats, 1)
+	assert.Equal(t, "primary", stats[0].Name)
+	assert.Equal(t, 1, stats[0].MaxOpenConnections)
+	assert.Equal(t, 1, stats[0].OpenConnections)
+	assert.Equal(t, 0, stats[0].InUse)
+}
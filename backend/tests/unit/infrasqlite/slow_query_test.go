@@ -0,0 +1,111 @@
+package infrasqlite_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/gateways/infra/infrasqlite"
+	"github.com/gity/point-system/gateways/repository/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// warnLogger はWarnのログを記録するLogger
+type warnLogger struct {
+	mu    sync.Mutex
+	warns []map[string]interface{}
+}
+
+func (l *warnLogger) Debug(msg string, fields ...entities.Field) {}
+func (l *warnLogger) Info(msg string, fields ...entities.Field)  {}
+func (l *warnLogger) Error(msg string, fields ...entities.Field) {}
+func (l *warnLogger) Fatal(msg string, fields ...entities.Field) {}
+func (l *warnLogger) Warn(msg string, fields ...entities.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry := map[string]interface{}{"msg": msg}
+	for _, f := range fields {
+		entry[f.Key] = f.Value
+	}
+	l.warns = append(l.warns, entry)
+}
+
+func (l *warnLogger) last() map[string]interface{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.warns) == 0 {
+		return nil
+	}
+	return l.warns[len(l.warns)-1]
+}
+
+// ========================================
+// SlowQueryLog Tests
+// ========================================
+
+func TestSlowQueryLogOnSQLite(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("閾値を超えたクエリをリポジトリのメソッドごとに数え、文字列のパラメータは伏せる", func(t *testing.T) {
+		logger := &warnLogger{}
+		slowQueries := infrapostgres.NewSlowQueryLog(infrapostgres.SlowQueryConfig{Threshold: time.Nanosecond}, logger)
+		db := setupDB(t, infrasqlite.MemoryPath, slowQueries)
+		users := user.NewUserRepository(dspostgresimpl.NewUserDataSource(db), logger)
+
+		_, err := users.ReadByUsername(ctx, "testuser")
+		require.NoError(t, err)
+		_, err = users.ReadByUsername(ctx, "admin")
+		require.NoError(t, err)
+
+		var count int64
+		for _, q := range slowQueries.SlowQueries() {
+			if q.Method == "user.RepositoryImpl.ReadByUsername" {
+				count = q.Count
+			}
+		}
+		assert.Equal(t, int64(2), count)
+
+		entry := logger.last()
+		require.NotNil(t, entry)
+		assert.Equal(t, "Slow query", entry["msg"])
+		assert.Equal(t, "user.RepositoryImpl.ReadByUsername", entry["method"])
+		assert.Contains(t, entry["sql"], "username")
+		assert.Contains(t, entry["params"], "***")
+		assert.NotContains(t, entry["params"], "admin")
+		assert.NotContains(t, entry, "plan", "EXPLAINを有効にしなければ実行計画は出さない")
+	})
+
+	t.Run("EXPLAINを有効にすると実行計画もログに出す", func(t *testing.T) {
+		logger := &warnLogger{}
+		slowQueries := infrapostgres.NewSlowQueryLog(infrapostgres.SlowQueryConfig{Threshold: time.Nanosecond, Explain: true}, logger)
+		db := setupDB(t, infrasqlite.MemoryPath, slowQueries)
+		users := user.NewUserRepository(dspostgresimpl.NewUserDataSource(db), logger)
+
+		_, err := users.ReadByUsername(ctx, "testuser")
+		require.NoError(t, err)
+
+		entry := logger.last()
+		require.NotNil(t, entry)
+		plan, ok := entry["plan"].(string)
+		require.True(t, ok)
+		assert.True(t, strings.Contains(plan, "SEARCH") || strings.Contains(plan, "SCAN"), plan)
+	})
+
+	t.Run("閾値を超えなければ記録しない", func(t *testing.T) {
+		logger := &warnLogger{}
+		slowQueries := infrapostgres.NewSlowQueryLog(infrapostgres.SlowQueryConfig{Threshold: time.Hour}, logger)
+		db := setupDB(t, infrasqlite.MemoryPath, slowQueries)
+		users := user.NewUserRepository(dspostgresimpl.NewUserDataSource(db), logger)
+
+		_, err := users.ReadByUsername(ctx, "testuser")
+		require.NoError(t, err)
+		assert.Empty(t, slowQueries.SlowQueries())
+		assert.Nil(t, logger.last())
+	})
+}
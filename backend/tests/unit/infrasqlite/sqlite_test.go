@@ -0,0 +1,857 @@
+package infrasqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/gateways/infra/infrasqlite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupDB(t testing.TB, path string, plugins ...gorm.Plugin) infrapostgres.DB {
+	t.Helper()
+	db, err := infrasqlite.NewSQLiteDB(&infrasqlite.Config{Path: path, Env: "production"}, plugins...)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, infrasqlite.Migrate(context.Background(), db, dspostgresimpl.Models()...))
+	return db
+}
+
+// ========================================
+// Migrate Tests
+// ========================================
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("初期データのユーザーが作成される", func(t *testing.T) {
+		db := setupDB(t, infrasqlite.MemoryPath)
+		users := dspostgresimpl.NewUserDataSource(db)
+
+		admin, err := users.SelectByUsername(ctx, "admin")
+		require.NoError(t, err)
+		assert.NotEqual(t, "00000000-0000-0000-0000-000000000000", admin.ID.String())
+		assert.Equal(t, entities.RoleAdmin, admin.Role)
+		assert.Equal(t, int64(1000000), admin.Balance)
+		assert.False(t, admin.CreatedAt.IsZero())
+	})
+
+	t.Run("同じファイルに2回適用してもデータは変わらない", func(t *testing.T) {
+		path := t.TempDir() + "/point_system.db"
+		db := setupDB(t, path)
+		require.NoError(t, infrasqlite.Migrate(ctx, db, dspostgresimpl.Models()...))
+
+		reopened := setupDB(t, path)
+		var count int64
+		require.NoError(t, reopened.GetDB().Table("users").Count(&count).Error)
+		assert.Equal(t, int64(2), count)
+	})
+}
+
+// ========================================
+// DataSource Tests
+// ========================================
+
+func TestDataSourcesOnSQLite(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ユーザー検索は大文字小文字を区別しない", func(t *testing.T) {
+		users := dspostgresimpl.NewUserDataSource(setupDB(t, infrasqlite.MemoryPath))
+
+		list, err := users.SelectListWithSearch(ctx, "TEST", nil, "", "", 0, 10)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, "testuser", list[0].Username)
+	})
+
+	t.Run("取引のメタデータを保存・匿名化できる", func(t *testing.T) {
+		db := setupDB(t, infrasqlite.MemoryPath)
+		users := dspostgresimpl.NewUserDataSource(db)
+		transactions := dspostgresimpl.NewTransactionDataSource(db)
+
+		admin, err := users.SelectByUsername(ctx, "admin")
+		require.NoError(t, err)
+		user, err := users.SelectByUsername(ctx, "testuser")
+		require.NoError(t, err)
+
+		tx, err := entities.NewAdminGrant(user.ID, 100, "grant", admin.ID)
+		require.NoError(t, err)
+		require.NoError(t, transactions.Insert(ctx, tx))
+
+		require.NoError(t, transactions.UpdateAnonymizeUser(ctx, user.ID, false))
+
+		saved, err := transactions.Select(ctx, tx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, admin.ID.String(), saved.Metadata["admin_id"])
+		assert.Equal(t, true, saved.Metadata[entities.MetadataToUserDeleted])
+	})
+
+	t.Run("残高の減算は確保中の分を除いた残高から行う", func(t *testing.T) {
+		users := dspostgresimpl.NewUserDataSource(setupDB(t, infrasqlite.MemoryPath))
+
+		user, err := users.SelectByUsername(ctx, "testuser")
+		require.NoError(t, err)
+		require.NoError(t, users.UpdateReservedBalanceWithLock(ctx, user.ID, user.Balance-100, false))
+
+		err = users.UpdateBalanceWithLock(ctx, user.ID, 101, true)
+		assert.ErrorIs(t, err, entities.ErrInsufficientBalance)
+		require.NoError(t, users.UpdateBalanceWithLock(ctx, user.ID, 100, true))
+
+		saved, err := users.Select(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, user.Balance-100, saved.Balance)
+		assert.Zero(t, saved.AvailableBalance())
+	})
+}
+
+func TestFriendshipMetadataOnSQLite(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, infrasqlite.MemoryPath)
+	users := dspostgresimpl.NewUserDataSource(db)
+	friendships := dspostgresimpl.NewFriendshipDataSource(db)
+	transactions := dspostgresimpl.NewTransactionDataSource(db)
+
+	me, err := users.SelectByUsername(ctx, "testuser")
+	require.NoError(t, err)
+	other, err := users.SelectByUsername(ctx, "admin")
+	require.NoError(t, err)
+	newUser := func(name string) *entities.User {
+		u, err := entities.NewUser(name, name+"@example.com", "hash", name, "太郎", "山田")
+		require.NoError(t, err)
+		require.NoError(t, users.Insert(ctx, u))
+		return u
+	}
+	befriend := func(a, b *entities.User, accept bool) {
+		f, err := entities.NewFriendship(a.ID, b.ID)
+		require.NoError(t, err)
+		if accept {
+			require.NoError(t, f.Accept())
+		}
+		require.NoError(t, friendships.Insert(ctx, f))
+	}
+	carol, alice, bob := newUser("carol"), newUser("alice"), newUser("bob")
+	befriend(me, carol, true)
+	befriend(alice, me, true)
+	befriend(me, bob, true)
+	befriend(other, carol, true)
+	befriend(alice, other, true)
+	befriend(other, bob, false)
+
+	count, err := friendships.CountMutualFriends(ctx, me.ID, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count, "承認されていない関係は含めない")
+	mutual, err := friendships.SelectListMutualFriends(ctx, me.ID, other.ID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, mutual, 2)
+	assert.Equal(t, "alice", mutual[0].Username)
+	assert.Equal(t, "carol", mutual[1].Username)
+
+	transfer := func(from, to *entities.User, amount int64, status entities.TransactionStatus) {
+		tx, err := entities.NewTransfer(from.ID, to.ID, amount, uuid.NewString(), "")
+		require.NoError(t, err)
+		tx.Status = status
+		require.NoError(t, transactions.Insert(ctx, tx))
+	}
+	transfer(me, alice, 100, entities.TransactionStatusCompleted)
+	transfer(alice, me, 30, entities.TransactionStatusCompleted)
+	transfer(me, alice, 500, entities.TransactionStatusFailed)
+	transfer(bob, carol, 70, entities.TransactionStatusCompleted)
+
+	sums, err := transactions.SumExchangedWith(ctx, me.ID, []uuid.UUID{alice.ID, bob.ID, carol.ID})
+	require.NoError(t, err)
+	require.Len(t, sums, 1, "取引のない相手は含めない")
+	assert.Equal(t, entities.PointsExchanged{CounterpartID: alice.ID, Sent: 100, Received: 30}, *sums[0])
+}
+
+func TestQuickSendOnSQLite(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, infrasqlite.MemoryPath)
+	users := dspostgresimpl.NewUserDataSource(db)
+	friendships := dspostgresimpl.NewFriendshipDataSource(db)
+	transactions := dspostgresimpl.NewTransactionDataSource(db)
+	pins := dspostgresimpl.NewFriendPinDataSource(db)
+
+	me, err := users.SelectByUsername(ctx, "testuser")
+	require.NoError(t, err)
+	friend, err := users.SelectByUsername(ctx, "admin")
+	require.NoError(t, err)
+
+	now := time.Now()
+	transfer := func(amount int64, at time.Time) {
+		tx, err := entities.NewTransfer(me.ID, friend.ID, amount, uuid.NewString(), "")
+		require.NoError(t, err)
+		tx.Status = entities.TransactionStatusCompleted
+		require.NoError(t, transactions.Insert(ctx, tx))
+		require.NoError(t, db.GetDB().Exec("UPDATE transactions SET created_at = ? WHERE id = ?", at, tx.ID).Error)
+	}
+	transfer(100, now.Add(-2*time.Hour))
+	transfer(100, now.Add(-time.Hour))
+	transfer(300, now.Add(-time.Minute))
+	transfer(500, now.AddDate(-1, 0, 0))
+
+	usages, err := transactions.SelectTransferAmountUsage(ctx, me.ID, []uuid.UUID{friend.ID}, now.Add(-entities.QuickSendLookback))
+	require.NoError(t, err)
+	summary := entities.SummarizeTransferAmounts(usages)[friend.ID]
+	require.NotNil(t, summary)
+	assert.Equal(t, int64(300), summary.LastAmount)
+	assert.Equal(t, int64(100), summary.FrequentAmount)
+	assert.Equal(t, int64(3), summary.TransferCount, "集計期間より前の送金は含めない")
+
+	// 友達を解除するとピン留めも外れる
+	f, err := entities.NewFriendship(me.ID, friend.ID)
+	require.NoError(t, err)
+	require.NoError(t, f.Accept())
+	require.NoError(t, friendships.Insert(ctx, f))
+	pin, err := entities.NewFriendPin(me.ID, friend.ID)
+	require.NoError(t, err)
+	require.NoError(t, pins.Insert(ctx, pin))
+	require.NoError(t, pins.Insert(ctx, pin), "同じ友達のピン留めは何もしない")
+	list, err := pins.SelectListByUserID(ctx, me.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	require.NoError(t, friendships.ArchiveAndDelete(ctx, f.ID, me.ID))
+	list, err = pins.SelectListByUserID(ctx, me.ID)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+// ========================================
+// Earning Rule Tests
+// ========================================
+
+func TestEarningRuleDataSourceOnSQLite(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("付与回数の上限・発生元の重複・実績の集計", func(t *testing.T) {
+		db := setupDB(t, infrasqlite.MemoryPath)
+		users := dspostgresimpl.NewUserDataSource(db)
+		transactions := dspostgresimpl.NewTransactionDataSource(db)
+		rules := dspostgresimpl.NewEarningRuleDataSource(db)
+
+		admin, err := users.SelectByUsername(ctx, "admin")
+		require.NoError(t, err)
+		user, err := users.SelectByUsername(ctx, "testuser")
+		require.NoError(t, err)
+
+		rule, err := entities.NewEarningRule("友達追加", entities.EarningTriggerFriendAdded, 20, 0, 0, false, 0, 1, time.Now().Add(-time.Minute), nil, admin.ID)
+		require.NoError(t, err)
+		require.NoError(t, rules.Insert(ctx, rule))
+
+		active, err := rules.SelectActiveByTrigger(ctx, entities.EarningTriggerFriendAdded, time.Now())
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+
+		ok, err := rules.IncrementGrantCount(ctx, rule.ID)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		ok, err = rules.IncrementGrantCount(ctx, rule.ID)
+		require.NoError(t, err)
+		assert.False(t, ok, "全体の上限に達したら増やさない")
+
+		event := entities.NewEarningEvent(entities.EarningTriggerFriendAdded, user.ID, 0, "friendship-1")
+		tx, err := entities.NewSystemGrant(user.ID, rule.Points, "grant", rule.TransactionMetadata(event))
+		require.NoError(t, err)
+		require.NoError(t, transactions.Insert(ctx, tx))
+
+		created, err := rules.InsertGrant(ctx, entities.NewEarningRuleGrant(rule, event, tx.ID))
+		require.NoError(t, err)
+		assert.True(t, created)
+		created, err = rules.InsertGrant(ctx, entities.NewEarningRuleGrant(rule, event, tx.ID))
+		require.NoError(t, err)
+		assert.False(t, created, "同じ発生元は記録しない")
+
+		count, err := rules.CountUserGrants(ctx, rule.ID, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		stats, err := rules.SelectStats(ctx, []uuid.UUID{rule.ID})
+		require.NoError(t, err)
+		require.Contains(t, stats, rule.ID)
+		assert.Equal(t, int64(1), stats[rule.ID].GrantCount)
+		assert.Equal(t, int64(20), stats[rule.ID].PointsGranted)
+		assert.NotNil(t, stats[rule.ID].LastGrantedAt)
+
+		require.NoError(t, rules.Delete(ctx, rule.ID))
+		_, err = rules.SelectByID(ctx, rule.ID)
+		assert.ErrorIs(t, err, entities.ErrEarningRuleNotFound)
+	})
+}
+
+// ========================================
+// Tenant Scope Tests
+// ========================================
+
+func TestTenantScopeOnSQLite(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("テナントのcontextでは所属するユーザー・設定だけが見える", func(t *testing.T) {
+		db := setupDB(t, infrasqlite.MemoryPath)
+		users := dspostgresimpl.NewUserDataSource(db)
+		settings := dspostgresimpl.NewSystemSettingsDataSource(db)
+		tenants := dspostgresimpl.NewTenantDataSource(db)
+
+		tenant, err := entities.NewTenant("acme", "Acme", time.Now())
+		require.NoError(t, err)
+		require.NoError(t, tenants.Insert(ctx, tenant))
+		acmeCtx := entities.WithTenantID(ctx, tenant.ID)
+
+		// 同じユーザー名でもテナントが違えば作成できる
+		user, err := entities.NewUser("testuser", "test@example.com", "hash", "Acme User", "太郎", "山田")
+		require.NoError(t, err)
+		require.NoError(t, users.Insert(acmeCtx, user))
+
+		found, err := users.SelectByUsername(acmeCtx, "testuser")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, found.ID)
+		assert.Equal(t, tenant.ID, found.TenantID)
+
+		_, err = users.SelectByUsername(acmeCtx, "admin")
+		assert.ErrorIs(t, err, entities.ErrUserNotFound, "既定のテナントのユーザーは見えない")
+
+		defaultUser, err := users.SelectByUsername(entities.WithTenantID(ctx, entities.DefaultTenantID), "testuser")
+		require.NoError(t, err)
+		assert.NotEqual(t, user.ID, defaultUser.ID)
+
+		require.NoError(t, settings.SetSetting(acmeCtx, "akerun_bonus_points", "9", ""))
+		value, err := settings.GetSetting(acmeCtx, "akerun_bonus_points")
+		require.NoError(t, err)
+		assert.Equal(t, "9", value)
+		value, err = settings.GetSetting(ctx, "akerun_bonus_points")
+		require.NoError(t, err)
+		assert.Equal(t, "5", value, "テナントのないcontextは既定のテナントの設定")
+	})
+
+	t.Run("テナントのないcontextで作成した取引はユーザーのテナントに入る", func(t *testing.T) {
+		db := setupDB(t, infrasqlite.MemoryPath)
+		users := dspostgresimpl.NewUserDataSource(db)
+		transactions := dspostgresimpl.NewTransactionDataSource(db)
+		tenants := dspostgresimpl.NewTenantDataSource(db)
+
+		tenant, err := entities.NewTenant("acme", "Acme", time.Now())
+		require.NoError(t, err)
+		require.NoError(t, tenants.Insert(ctx, tenant))
+		user, err := entities.NewUser("acme_user", "acme@example.com", "hash", "Acme User", "太郎", "山田")
+		require.NoError(t, err)
+		require.NoError(t, users.Insert(entities.WithTenantID(ctx, tenant.ID), user))
+
+		tx, err := entities.NewSystemGrant(user.ID, 10, "grant", nil)
+		require.NoError(t, err)
+		require.NoError(t, transactions.Insert(ctx, tx))
+
+		var tenantID string
+		require.NoError(t, db.GetDB().Raw("SELECT tenant_id FROM transactions WHERE id = ?", tx.ID).Row().Scan(&tenantID))
+		assert.Equal(t, tenant.ID.String(), tenantID)
+	})
+
+	t.Run("テナントのcontextの集計・一覧は所属するユーザー・取引だけを対象にする", func(t *testing.T) {
+		db := setupDB(t, infrasqlite.MemoryPath)
+		users := dspostgresimpl.NewUserDataSource(db)
+		transactions := dspostgresimpl.NewTransactionDataSource(db)
+		tenants := dspostgresimpl.NewTenantDataSource(db)
+		analytics := dspostgresimpl.NewAnalyticsDataSource(db)
+
+		tenant, err := entities.NewTenant("acme", "Acme", time.Now())
+		require.NoError(t, err)
+		require.NoError(t, tenants.Insert(ctx, tenant))
+		acmeCtx := entities.WithTenantID(ctx, tenant.ID)
+		user, err := entities.NewUser("acme_user", "acme@example.com", "hash", "Acme User", "太郎", "山田")
+		require.NoError(t, err)
+		require.NoError(t, users.Insert(acmeCtx, user))
+		grant, err := entities.NewSystemGrant(user.ID, 300, "grant", nil)
+		require.NoError(t, err)
+		require.NoError(t, transactions.Insert(acmeCtx, grant))
+		require.NoError(t, users.UpdateBalanceWithLock(acmeCtx, user.ID, 300, false))
+
+		holders, err := analytics.GetTopHolders(acmeCtx, 10)
+		require.NoError(t, err)
+		require.Len(t, holders, 1, "既定のテナントのadmin・testuserは含めない")
+		assert.Equal(t, user.ID.String(), holders[0].ID)
+
+		summary, err := analytics.GetUserBalanceSummary(acmeCtx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), summary.ActiveUsers)
+		assert.Equal(t, int64(300), summary.TotalBalance)
+
+		breakdown, err := analytics.GetTransactionTypeBreakdown(acmeCtx)
+		require.NoError(t, err)
+		require.Len(t, breakdown, 1)
+		assert.Equal(t, int64(1), breakdown[0].Count)
+
+		conservation, err := analytics.GetPointConservation(acmeCtx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(300), conservation.UserBalanceTotal)
+		assert.Equal(t, int64(-300), conservation.Account(entities.SystemAccountTreasury).Balance())
+		assert.True(t, conservation.Conserved())
+
+		list, err := transactions.SelectListAllWithFilterAndUsers(acmeCtx, "", "", "", "", nil, "", "", 0, 10)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, grant.ID, list[0].Transaction.ID)
+
+		// テナントのないcontext（ワーカーなど）はすべてのテナントを対象にする
+		holders, err = analytics.GetTopHolders(ctx, 10)
+		require.NoError(t, err)
+		assert.Len(t, holders, 3)
+	})
+}
+
+// ========================================
+// Point Conservation Tests
+// ========================================
+
+func TestPointConservationOnSQLite(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("初期残高は発行元からの付与として記録され、ポイントが保存されている", func(t *testing.T) {
+		db := setupDB(t, infrasqlite.MemoryPath)
+		conservation, err := dspostgresimpl.NewAnalyticsDataSource(db).GetPointConservation(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(1010000), conservation.UserBalanceTotal)
+		assert.Equal(t, int64(-1010000), conservation.Account(entities.SystemAccountTreasury).Balance())
+		assert.True(t, conservation.Conserved())
+	})
+
+	t.Run("付与・減算・失効・手数料をシステムの口座の入出金として集計する", func(t *testing.T) {
+		db := setupDB(t, infrasqlite.MemoryPath)
+		users := dspostgresimpl.NewUserDataSource(db)
+		transactions := dspostgresimpl.NewTransactionDataSource(db)
+		analytics := dspostgresimpl.NewAnalyticsDataSource(db)
+
+		admin, err := users.SelectByUsername(ctx, "admin")
+		require.NoError(t, err)
+		user, err := users.SelectByUsername(ctx, "testuser")
+		require.NoError(t, err)
+		insert := func(tx *entities.Transaction, err error) *entities.Transaction {
+			t.Helper()
+			require.NoError(t, err)
+			require.NoError(t, transactions.Insert(ctx, tx))
+			return tx
+		}
+
+		grant := insert(entities.NewAdminGrant(user.ID, 500, "grant", admin.ID))
+		insert(entities.NewAdminDeduct(user.ID, 100, "deduct", admin.ID))
+		insert(entities.NewSystemExpire(user.ID, 50, "expire", nil))
+		transfer := insert(entities.NewTransfer(user.ID, admin.ID, 200, "conservation-transfer", "transfer"))
+		insert(entities.NewTransferFee(user.ID, nil, 10, transfer))
+		require.NoError(t, users.UpdateBalanceWithLock(ctx, user.ID, 500-100-50-200-10, false))
+		require.NoError(t, users.UpdateBalanceWithLock(ctx, admin.ID, 200, false))
+
+		saved, err := transactions.Select(ctx, grant.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.SystemAccountTreasury, saved.FromAccount)
+
+		conservation, err := analytics.GetPointConservation(ctx)
+		require.NoError(t, err)
+		treasury := conservation.Account(entities.SystemAccountTreasury)
+		assert.Equal(t, int64(1010000+500), treasury.Debited)
+		assert.Equal(t, int64(100), treasury.Credited)
+		assert.Equal(t, int64(50), conservation.Account(entities.SystemAccountExpirySink).Balance())
+		assert.Equal(t, int64(10), conservation.Account(entities.SystemAccountFees).Balance())
+		assert.True(t, conservation.Conserved(), "difference: %d", conservation.Difference())
+
+		// 取引のない残高の変更は保存の差として現れる
+		require.NoError(t, users.UpdateBalanceWithLock(ctx, user.ID, 30, false))
+		conservation, err = analytics.GetPointConservation(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(30), conservation.Difference())
+	})
+}
+
+func TestTransactionArchiveOnSQLite(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("保持期間を過ぎた取引を移しても残高の照合とポイントの保存は変わらない", func(t *testing.T) {
+		db := setupDB(t, infrasqlite.MemoryPath)
+		users := dspostgresimpl.NewUserDataSource(db)
+		transactions := dspostgresimpl.NewTransactionDataSource(db)
+		archive := dspostgresimpl.NewTransactionArchiveDataSource(db)
+		ledger := dspostgresimpl.NewBalanceLedgerDataSource(db)
+		analytics := dspostgresimpl.NewAnalyticsDataSource(db)
+
+		admin, err := users.SelectByUsername(ctx, "admin")
+		require.NoError(t, err)
+		user, err := users.SelectByUsername(ctx, "testuser")
+		require.NoError(t, err)
+
+		now := time.Now()
+		old := now.AddDate(-2, 0, 0)
+		for _, amount := range []int64{300, 200} {
+			tx, err := entities.NewAdminGrant(user.ID, amount, "grant", admin.ID)
+			require.NoError(t, err)
+			require.NoError(t, transactions.Insert(ctx, tx))
+			require.NoError(t, db.GetDB().Exec("UPDATE transactions SET created_at = ? WHERE id = ?", old, tx.ID).Error)
+		}
+		recent, err := entities.NewAdminGrant(user.ID, 100, "grant", admin.ID)
+		require.NoError(t, err)
+		require.NoError(t, transactions.Insert(ctx, recent))
+		require.NoError(t, users.UpdateBalanceWithLock(ctx, user.ID, 600, false))
+
+		before, err := ledger.SelectBalanceChecks(ctx, []uuid.UUID{user.ID})
+		require.NoError(t, err)
+
+		cutoff := now.AddDate(0, 0, -entities.TransactionRetentionMinDays)
+		moved, err := archive.MoveBefore(ctx, cutoff, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), moved, "1回に移すのはlimit件まで")
+		moved, err = archive.MoveBefore(ctx, cutoff, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), moved)
+
+		_, err = transactions.Select(ctx, recent.ID)
+		assert.NoError(t, err, "保持期間内の取引は残る")
+
+		filter := &entities.ArchivedTransactionFilter{UserID: &user.ID, From: old.AddDate(0, 0, -1), To: old.AddDate(0, 0, 1)}
+		list, err := archive.SelectList(ctx, filter, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, entities.TransactionTypeAdminGrant, list[0].TransactionType)
+		assert.False(t, list[0].ArchivedAt.IsZero())
+		count, err := archive.Count(ctx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		month := entities.TransactionArchiveMonth(old)
+		summaries, err := archive.SelectSummaries(ctx, month, month)
+		require.NoError(t, err)
+		require.Len(t, summaries, 1)
+		assert.Equal(t, int64(2), summaries[0].TransactionCount)
+		assert.Equal(t, int64(500), summaries[0].TotalAmount)
+
+		after, err := ledger.SelectBalanceChecks(ctx, []uuid.UUID{user.ID})
+		require.NoError(t, err)
+		assert.Equal(t, before[0].LedgerBalance, after[0].LedgerBalance)
+
+		conservation, err := analytics.GetPointConservation(ctx)
+		require.NoError(t, err)
+		assert.True(t, conservation.Conserved(), "difference: %d", conservation.Difference())
+	})
+}
+
+func TestSplitRequestOnSQLite(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, infrasqlite.MemoryPath)
+	users := dspostgresimpl.NewUserDataSource(db)
+	splits := dspostgresimpl.NewSplitRequestDataSource(db)
+	requests := dspostgresimpl.NewTransferRequestDataSource(db)
+
+	requester, err := users.SelectByUsername(ctx, "admin")
+	require.NoError(t, err)
+	payer, err := users.SelectByUsername(ctx, "testuser")
+	require.NoError(t, err)
+
+	split, shares, err := entities.NewSplitRequest(requester.ID, 500, entities.SplitTypeEqual, []entities.SplitShare{{UserID: payer.ID}}, false, "lunch", "split-sqlite")
+	require.NoError(t, err)
+	require.NoError(t, splits.Insert(ctx, split))
+	share, err := split.NewShareRequest(shares[0])
+	require.NoError(t, err)
+	require.NoError(t, requests.Insert(ctx, share))
+
+	// 割り勘の1人分は支払う参加者の承認待ちに並び、参加者の送信済み・作成者の承認待ちには並ばない
+	pending, err := requests.SelectPendingByToUserWithUsers(ctx, payer.ID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.NotNil(t, pending[0].TransferRequest.SplitID)
+	assert.Equal(t, split.ID, *pending[0].TransferRequest.SplitID)
+	assert.Equal(t, requester.Username, pending[0].ToUser.Username)
+	count, err := requests.CountPendingByToUser(ctx, requester.ID)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	sent, err := requests.SelectSentByFromUserWithUsers(ctx, payer.ID, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, sent)
+
+	got, err := requests.SelectBySplitIDs(ctx, []uuid.UUID{split.ID})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, share.ID, got[0].ID)
+
+	require.NoError(t, split.Cancel(got))
+	require.NoError(t, splits.Update(ctx, split))
+	reloaded, err := splits.Select(ctx, split.ID)
+	require.NoError(t, err)
+	assert.True(t, reloaded.IsCancelled())
+	list, err := splits.SelectListByRequester(ctx, requester.ID, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+}
+
+func TestWeeklyDigestOnSQLite(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, infrasqlite.MemoryPath)
+	users := dspostgresimpl.NewUserDataSource(db)
+	transactions := dspostgresimpl.NewTransactionDataSource(db)
+	notifications := dspostgresimpl.NewNotificationDataSource(db)
+	digests := dspostgresimpl.NewWeeklyDigestDataSource(db)
+
+	admin, err := users.SelectByUsername(ctx, "admin")
+	require.NoError(t, err)
+	user, err := users.SelectByUsername(ctx, "testuser")
+	require.NoError(t, err)
+
+	// 設定の行が無いユーザーも送信対象
+	ids, err := digests.SelectRecipientIDs(ctx, uuid.Nil, 10)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{admin.ID, user.ID}, ids)
+
+	prefs := entities.DefaultNotificationPreferences(admin.ID)
+	prefs.WeeklyDigest = false
+	require.NoError(t, notifications.UpsertPreferences(ctx, prefs))
+	ids, err = digests.SelectRecipientIDs(ctx, uuid.Nil, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{user.ID}, ids)
+	stored, err := notifications.SelectPreferences(ctx, admin.ID)
+	require.NoError(t, err)
+	assert.False(t, stored.WeeklyDigest, "初めて保存するときもオフが保存される")
+
+	// シードの取引も直近1週間に含まれるため、追加前との差で確かめる
+	now := time.Now()
+	before, err := digests.SelectActivity(ctx, []uuid.UUID{user.ID}, now.Add(-entities.WeeklyDigestPeriod), now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, before, 1)
+	grant, err := entities.NewAdminGrant(user.ID, 300, "grant", admin.ID)
+	require.NoError(t, err)
+	require.NoError(t, transactions.Insert(ctx, grant))
+	old, err := entities.NewAdminGrant(user.ID, 5000, "grant", admin.ID)
+	require.NoError(t, err)
+	require.NoError(t, transactions.Insert(ctx, old))
+	require.NoError(t, db.GetDB().Exec("UPDATE transactions SET created_at = ? WHERE id = ?", now.AddDate(0, 0, -10), old.ID).Error)
+	expired, err := entities.NewSystemExpire(user.ID, 40, "expire", nil)
+	require.NoError(t, err)
+	expired.Status = entities.TransactionStatusCompleted
+	require.NoError(t, transactions.Insert(ctx, expired))
+
+	activity, err := digests.SelectActivity(ctx, []uuid.UUID{user.ID}, now.Add(-entities.WeeklyDigestPeriod), now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, activity, 1)
+	assert.Equal(t, before[0].Earned+300, activity[0].Earned, "1週間より前の取引は含めない")
+	assert.Equal(t, before[0].Spent, activity[0].Spent, "失効は使ったポイントに含めない")
+}
+
+func TestProcessedAkerunAccessOnSQLite(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, infrasqlite.MemoryPath)
+	ds := dspostgresimpl.NewProcessedAkerunAccessDataSource(db)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	old := entities.NewProcessedAkerunAccess(entities.AccessRecord{ID: uuid.New(), AccessedAt: now.AddDate(0, 0, -40)}, now.AddDate(0, 0, -40))
+	recent := entities.NewProcessedAkerunAccess(entities.AccessRecord{ID: uuid.New(), AccessedAt: now.Add(-time.Hour)}, now)
+	require.NoError(t, ds.Insert(ctx, []*entities.ProcessedAkerunAccess{old, recent}))
+	// 記録済みのアクセス記録を含めてもエラーにしない
+	require.NoError(t, ds.Insert(ctx, []*entities.ProcessedAkerunAccess{recent}))
+
+	processed, err := ds.SelectProcessedIDs(ctx, []uuid.UUID{old.AccessID, recent.AccessID, uuid.New()})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]bool{old.AccessID: true, recent.AccessID: true}, processed)
+
+	deleted, err := ds.DeleteProcessedBefore(ctx, now.Add(-entities.ProcessedAkerunAccessRetention))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	processed, err = ds.SelectProcessedIDs(ctx, []uuid.UUID{old.AccessID, recent.AccessID})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]bool{recent.AccessID: true}, processed)
+}
+
+func TestLoginHistoryOnSQLite(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, infrasqlite.MemoryPath)
+	users := dspostgresimpl.NewUserDataSource(db)
+	attempts := dspostgresimpl.NewLoginAttemptDataSource(db)
+
+	me, err := users.SelectByUsername(ctx, "testuser")
+	require.NoError(t, err)
+	other, err := users.SelectByUsername(ctx, "admin")
+	require.NoError(t, err)
+
+	base := time.Now().Add(-time.Hour)
+	for i, newDevice := range []bool{false, true} {
+		a := entities.NewLoginAttempt(&me.ID, me.Username, "198.51.100.7", "Laptop", "JP", true, "")
+		a.NewDevice = newDevice
+		a.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, attempts.Insert(ctx, a))
+	}
+	require.NoError(t, attempts.Insert(ctx, entities.NewLoginAttempt(&other.ID, other.Username, "198.51.100.8", "Laptop", "", false, entities.LoginFailureInvalidCredentials)))
+
+	count, err := attempts.CountByUserID(ctx, me.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	list, err := attempts.SelectListByUserID(ctx, me.ID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.True(t, list[0].NewDevice, "新しい順")
+	assert.False(t, list[1].NewDevice)
+	assert.Equal(t, "JP", list[0].Country)
+}
+
+func TestSessionBindingOnSQLite(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, infrasqlite.MemoryPath)
+	users := dspostgresimpl.NewUserDataSource(db)
+	sessions := dspostgresimpl.NewSessionDataSource(db)
+
+	me, err := users.SelectByUsername(ctx, "testuser")
+	require.NoError(t, err)
+
+	session, err := entities.NewSession(me.ID, "203.0.113.10", "Laptop")
+	require.NoError(t, err)
+	require.NoError(t, sessions.Insert(ctx, session))
+	assert.Empty(t, session.Fingerprint, "未固定")
+
+	policy := entities.SessionBindingPolicy{Mode: entities.SessionBindingLenient, IPv4PrefixLen: 24, IPv6PrefixLen: 64}
+	fingerprint := policy.Fingerprint(session.IPAddress, session.UserAgent).String()
+	require.NoError(t, sessions.UpdateFingerprint(ctx, session.ID, fingerprint))
+	stored, err := sessions.SelectByToken(ctx, session.SessionToken)
+	require.NoError(t, err)
+	assert.Equal(t, fingerprint, stored.Fingerprint)
+	assert.Error(t, sessions.UpdateFingerprint(ctx, uuid.New(), fingerprint))
+
+	for _, change := range []entities.SessionFingerprintChange{entities.SessionFingerprintChangedSubnet, entities.SessionFingerprintChangedBoth} {
+		v := entities.NewSessionBindingViolation(session, change, change.Drastic(), "192.0.2.1", "curl/8.4.0")
+		require.NoError(t, sessions.InsertBindingViolation(ctx, v))
+		time.Sleep(time.Millisecond)
+	}
+
+	count, err := sessions.CountBindingViolationsByUserID(ctx, me.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	list, err := sessions.SelectBindingViolationsByUserID(ctx, me.ID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, entities.SessionFingerprintChangedBoth, list[0].Change, "新しい順")
+	assert.True(t, list[0].Rejected)
+	assert.Equal(t, session.ID, list[1].SessionID)
+}
+
+func TestCSRFRotationOnSQLite(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, infrasqlite.MemoryPath)
+	users := dspostgresimpl.NewUserDataSource(db)
+	sessions := dspostgresimpl.NewSessionDataSource(db)
+
+	me, err := users.SelectByUsername(ctx, "testuser")
+	require.NoError(t, err)
+
+	session, err := entities.NewSession(me.ID, "203.0.113.10", "Laptop")
+	require.NoError(t, err)
+	require.NoError(t, sessions.Insert(ctx, session))
+
+	previous := session.CSRFToken
+	rotated := *session
+	require.NoError(t, rotated.RotateCSRF(time.Now()))
+	ok, err := sessions.UpdateCSRFToken(ctx, &rotated, previous)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	stored, err := sessions.SelectByToken(ctx, session.SessionToken)
+	require.NoError(t, err)
+	assert.Equal(t, rotated.CSRFToken, stored.CSRFToken)
+	assert.Equal(t, previous, stored.PreviousCSRFToken)
+	assert.WithinDuration(t, rotated.CSRFIssuedAt, stored.CSRFIssuedAt, time.Second)
+
+	// 並行したリクエストが入れ替えた後の、古いトークンを前提にした更新は反映しない
+	again := *session
+	require.NoError(t, again.RotateCSRF(time.Now()))
+	ok, err = sessions.UpdateCSRFToken(ctx, &again, previous)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestDeleteAllSessionsOnSQLite(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, infrasqlite.MemoryPath)
+	users := dspostgresimpl.NewUserDataSource(db)
+	sessions := dspostgresimpl.NewSessionDataSource(db)
+
+	me, err := users.SelectByUsername(ctx, "testuser")
+	require.NoError(t, err)
+
+	var kept *entities.Session
+	for i := 0; i < 3; i++ {
+		session, err := entities.NewSession(me.ID, "203.0.113.10", "Laptop")
+		require.NoError(t, err)
+		require.NoError(t, sessions.Insert(ctx, session))
+		kept = session
+	}
+
+	count, err := sessions.DeleteAllExcept(ctx, kept.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	list, err := sessions.SelectListByUserID(ctx, me.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, kept.ID, list[0].ID)
+}
+
+func TestBusinessMetricsOnSQLite(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, infrasqlite.MemoryPath)
+	users := dspostgresimpl.NewUserDataSource(db)
+	transactions := dspostgresimpl.NewTransactionDataSource(db)
+	requests := dspostgresimpl.NewTransferRequestDataSource(db)
+	sessions := dspostgresimpl.NewSessionDataSource(db)
+	metrics := dspostgresimpl.NewBusinessMetricsDataSource(db)
+
+	admin, err := users.SelectByUsername(ctx, "admin")
+	require.NoError(t, err)
+	me, err := users.SelectByUsername(ctx, "testuser")
+	require.NoError(t, err)
+
+	now := time.Now()
+	dayStart := entities.BusinessMetricsDayStart(now)
+	before, err := metrics.SelectBusinessMetrics(ctx, dayStart, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1010000), before.PointsInCirculation)
+
+	insert := func(tx *entities.Transaction, status entities.TransactionStatus, at time.Time) {
+		tx.Status = status
+		require.NoError(t, transactions.Insert(ctx, tx))
+		require.NoError(t, db.GetDB().Exec("UPDATE transactions SET created_at = ? WHERE id = ?", at, tx.ID).Error)
+	}
+	grant, err := entities.NewAdminGrant(me.ID, 100, "grant", admin.ID)
+	require.NoError(t, err)
+	insert(grant, entities.TransactionStatusCompleted, now.Add(-time.Minute))
+	deduct, err := entities.NewAdminDeduct(me.ID, 30, "deduct", admin.ID)
+	require.NoError(t, err)
+	insert(deduct, entities.TransactionStatusCompleted, now.Add(-time.Minute))
+	for _, c := range []struct {
+		amount int64
+		status entities.TransactionStatus
+		at     time.Time
+	}{
+		{40, entities.TransactionStatusCompleted, now.Add(-time.Minute)},
+		{500, entities.TransactionStatusFailed, now.Add(-time.Minute)},
+		{70, entities.TransactionStatusCompleted, dayStart.Add(-time.Minute)},
+	} {
+		tx, err := entities.NewTransfer(me.ID, admin.ID, c.amount, uuid.NewString(), "")
+		require.NoError(t, err)
+		insert(tx, c.status, c.at)
+	}
+
+	request, err := entities.NewTransferRequest(me.ID, admin.ID, 10, "", uuid.NewString())
+	require.NoError(t, err)
+	require.NoError(t, requests.Insert(ctx, request))
+	session, err := entities.NewSession(me.ID, "203.0.113.10", "Laptop")
+	require.NoError(t, err)
+	require.NoError(t, sessions.Insert(ctx, session))
+
+	after, err := metrics.SelectBusinessMetrics(ctx, dayStart, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), after.IssuedToday-before.IssuedToday)
+	assert.Equal(t, int64(30), after.ConsumedToday-before.ConsumedToday)
+	assert.Equal(t, int64(40), after.TransferredToday-before.TransferredToday, "失敗した送金と前日の送金は含めない")
+	assert.Equal(t, int64(1), after.PendingTransferRequests-before.PendingTransferRequests)
+	assert.Equal(t, int64(1), after.ActiveUsersToday-before.ActiveUsersToday)
+	assert.True(t, now.Equal(after.CollectedAt))
+}
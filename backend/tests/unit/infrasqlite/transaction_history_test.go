@@ -0,0 +1,138 @@
+package infrasqlite_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/gateways/datasource/dspostgresimpl"
+	"github.com/gity/point-system/gateways/infra/infrapostgres"
+	"github.com/gity/point-system/gateways/infra/infrasqlite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// orHistorySQL は書き直す前のユーザーの履歴のクエリ（送信者・受信者をORで絞り込む）
+const orHistorySQL = "SELECT id FROM transactions WHERE (from_user_id = ? OR to_user_id = ?)"
+
+// seedHistory はmeが送信者・受信者・自分宛て・無関係の取引を混ぜてn件作成する（作成日時はすべて異なる）
+func seedHistory(tb testing.TB, db infrapostgres.DB, me uuid.UUID, n int) {
+	tb.Helper()
+	others := make([]uuid.UUID, 50)
+	for i := range others {
+		others[i] = uuid.New()
+	}
+	base := time.Now().Add(-time.Hour)
+	models := make([]dspostgresimpl.TransactionModel, n)
+	for i := range models {
+		from, to := others[i%len(others)], others[(i+1)%len(others)]
+		switch i % 7 {
+		case 0, 1:
+			from = me
+		case 2, 3:
+			to = me
+		case 4:
+			if i%49 == 4 {
+				from, to = me, me
+			}
+		}
+		reason := ""
+		if i%3 == 0 {
+			reason = "lunch"
+		}
+		models[i] = dspostgresimpl.TransactionModel{
+			ID: uuid.New(), FromUserID: &from, ToUserID: &to, Amount: int64(i + 1),
+			TransactionType: "transfer", Status: "completed", ReasonCode: reason,
+			CreatedAt: base.Add(-time.Duration(i) * time.Second),
+		}
+	}
+	require.NoError(tb, db.GetDB().CreateInBatches(models, 500).Error)
+}
+
+// orHistoryIDs は書き直す前のクエリで履歴のIDを取得する
+func orHistoryIDs(tb testing.TB, db infrapostgres.DB, me uuid.UUID, reasonCode string, offset, limit int) []uuid.UUID {
+	tb.Helper()
+	query, args := orHistorySQL, []interface{}{me, me}
+	if reasonCode != "" {
+		query += " AND reason_code = ?"
+		args = append(args, reasonCode)
+	}
+	var ids []uuid.UUID
+	require.NoError(tb, db.GetDB().Raw(query+" ORDER BY created_at DESC LIMIT ? OFFSET ?", append(args, limit, offset)...).Scan(&ids).Error)
+	return append([]uuid.UUID{}, ids...)
+}
+
+func TestTransactionHistoryUnionOnSQLite(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, infrasqlite.MemoryPath)
+	transactions := dspostgresimpl.NewTransactionDataSource(db)
+	me := uuid.New()
+	seedHistory(t, db, me, 500)
+
+	for _, c := range []struct {
+		reasonCode    string
+		offset, limit int
+	}{
+		{"", 0, 20}, {"", 40, 20}, {"", 0, 1000}, {"lunch", 0, 20}, {"lunch", 20, 20}, {"", 1000, 20},
+	} {
+		t.Run(fmt.Sprintf("reason=%q offset=%d limit=%d", c.reasonCode, c.offset, c.limit), func(t *testing.T) {
+			want := orHistoryIDs(t, db, me, c.reasonCode, c.offset, c.limit)
+
+			withUsers, err := transactions.SelectListByUserIDWithUsers(ctx, me, c.reasonCode, c.offset, c.limit)
+			require.NoError(t, err)
+			got := make([]uuid.UUID, len(withUsers))
+			for i, tx := range withUsers {
+				got[i] = tx.Transaction.ID
+			}
+			assert.Equal(t, want, got)
+
+			if c.reasonCode == "" {
+				list, err := transactions.SelectListByUserID(ctx, me, c.offset, c.limit)
+				require.NoError(t, err)
+				got := make([]uuid.UUID, len(list))
+				for i, tx := range list {
+					got[i] = tx.ID
+				}
+				assert.Equal(t, want, got)
+			}
+		})
+	}
+
+	t.Run("件数は自分宛ての取引を二重に数えない", func(t *testing.T) {
+		for _, reasonCode := range []string{"", "lunch"} {
+			want := int64(len(orHistoryIDs(t, db, me, reasonCode, 0, 1000)))
+			got, err := transactions.CountByUserID(ctx, me, reasonCode)
+			require.NoError(t, err)
+			assert.Equal(t, want, got, reasonCode)
+		}
+	})
+}
+
+// BenchmarkTransactionHistoryOnSQLite はユーザーの履歴の1ページ目・10ページ目を、書き直す前のOR（before）と
+// UNION（after）で取得する時間を比べる
+//
+//	go test ./tests/unit/infrasqlite/ -run '^$' -bench TransactionHistory
+func BenchmarkTransactionHistoryOnSQLite(b *testing.B) {
+	ctx := context.Background()
+	db := setupDB(b, infrasqlite.MemoryPath)
+	transactions := dspostgresimpl.NewTransactionDataSource(db)
+	me := uuid.New()
+	seedHistory(b, db, me, 20000)
+
+	for _, offset := range []int{0, 180} {
+		b.Run(fmt.Sprintf("before/offset=%d", offset), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				orHistoryIDs(b, db, me, "", offset, 20)
+			}
+		})
+		b.Run(fmt.Sprintf("after/offset=%d", offset), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := transactions.SelectListByUserIDWithUsers(ctx, me, "", offset, 20); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
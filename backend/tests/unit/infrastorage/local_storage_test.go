@@ -2,12 +2,15 @@ package infrastorage_test
 
 import (
 	"bytes"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/gity/point-system/gateways/infra/infrastorage"
+	"github.com/gity/point-system/usecases/service"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -277,6 +280,66 @@ func TestLocalStorage_GetAvatarURL(t *testing.T) {
 // 統合的なシナリオテスト
 // ========================================
 
+func TestLocalStorage_TransferAttachment(t *testing.T) {
+	newStorage := func(t *testing.T) (service.FileStorageService, string) {
+		cfg, tempDir := setupTestStorage(t)
+		t.Cleanup(func() { cleanupTestStorage(t, tempDir) })
+		cfg.AttachmentDir = filepath.Join(tempDir, "attachments")
+		cfg.AttachmentMaxSizeMB = 1
+		storage, err := infrastorage.NewLocalStorage(cfg)
+		require.NoError(t, err)
+		return storage, cfg.AttachmentDir
+	}
+
+	t.Run("添付画像を保存して読み出し、削除できる", func(t *testing.T) {
+		storage, dir := newStorage(t)
+		content := []byte("fake image content")
+
+		filePath, err := storage.SaveTransferAttachment(uuid.New().String(), "receipt.png", bytes.NewReader(content), int64(len(content)))
+		require.NoError(t, err)
+		_, err = os.Stat(filepath.Join(dir, filePath))
+		require.NoError(t, err)
+
+		file, err := storage.OpenTransferAttachment(filePath)
+		require.NoError(t, err)
+		saved, err := io.ReadAll(file)
+		file.Close()
+		require.NoError(t, err)
+		assert.Equal(t, content, saved)
+
+		require.NoError(t, storage.DeleteTransferAttachment(filePath))
+		_, err = storage.OpenTransferAttachment(filePath)
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("同じ秒に同じファイル名で保存しても上書きしない", func(t *testing.T) {
+		storage, _ := newStorage(t)
+		userID := uuid.New().String()
+
+		path1, err := storage.SaveTransferAttachment(userID, "receipt.png", bytes.NewReader([]byte("first")), 5)
+		require.NoError(t, err)
+		path2, err := storage.SaveTransferAttachment(userID, "receipt.png", bytes.NewReader([]byte("second")), 6)
+		require.NoError(t, err)
+		assert.NotEqual(t, path1, path2)
+	})
+
+	t.Run("添付画像の上限を超えるとエラー", func(t *testing.T) {
+		storage, _ := newStorage(t)
+		fileSize := int64(2 * 1024 * 1024)
+
+		_, err := storage.SaveTransferAttachment(uuid.New().String(), "large.jpg", bytes.NewReader(make([]byte, fileSize)), fileSize)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "exceeds maximum allowed size")
+	})
+
+	t.Run("保存先の外は読み出せない", func(t *testing.T) {
+		storage, _ := newStorage(t)
+
+		_, err := storage.OpenTransferAttachment("../avatars/secret.png")
+		assert.Error(t, err)
+	})
+}
+
 func TestLocalStorage_Scenario_SaveUpdateDelete(t *testing.T) {
 	cfg, tempDir := setupTestStorage(t)
 	defer cleanupTestStorage(t, tempDir)
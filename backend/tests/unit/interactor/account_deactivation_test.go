@@ -0,0 +1,127 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountDeactivation(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T, verifyOK bool) (*testsupport.Repositories, inputport.AuthInputPort, *entities.User) {
+		repos := testsupport.New()
+		sut := interactor.NewAuthInteractor(
+			repos.Users, repos.Sessions, repos.LoginAttempts,
+			&mockPasswordService{verifyOK: verifyOK}, &mockEmailService{}, &mockNotificationDispatcher{}, &mockReferralTracker{}, &mockOnboardingBonusGranter{}, &mockEmailVerificationGate{}, &mockLogger{},
+		)
+		user := createTestUserWithBalance(t, "sleeper", 100, entities.RoleUser)
+		repos.Users.Seed(user)
+		return repos, sut, user
+	}
+	login := func(sut inputport.AuthInputPort, user *entities.User) (*inputport.LoginResponse, error) {
+		return sut.Login(ctx, &inputport.LoginRequest{Username: user.Username, Password: "password123", IPAddress: "127.0.0.1", UserAgent: "TestAgent"})
+	}
+
+	t.Run("休止するとすべてのセッションが失効し、検索に出ず取引できない", func(t *testing.T) {
+		repos, sut, user := setup(t, true)
+		first, err := login(sut, user)
+		require.NoError(t, err)
+		_, err = login(sut, user)
+		require.NoError(t, err)
+
+		resp, err := sut.DeactivateAccount(ctx, &inputport.DeactivateAccountRequest{UserID: user.ID, Password: "password123"})
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(entities.UserReactivationWindow), resp.ReactivatableUntil, time.Minute)
+
+		sessions, err := repos.Sessions.ReadListByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+		_, err = sut.ValidateSession(ctx, first.Session.SessionToken)
+		assert.Error(t, err)
+
+		stored, err := repos.Users.Read(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.UserStatusDeactivated, stored.Status)
+		assert.ErrorIs(t, stored.CanTransfer(10), entities.ErrUserInactive)
+
+		query := interactor.NewUserQueryInteractor(repos.Users, &mockTransferEligibility{}, &mockLogger{})
+		_, err = query.SearchUserByUsername(ctx, &inputport.SearchUserByUsernameRequest{Username: user.Username})
+		assert.ErrorIs(t, err, entities.ErrUserNotFound)
+	})
+
+	t.Run("休止中のユーザーのセッションは検証で拒否する", func(t *testing.T) {
+		repos, sut, user := setup(t, true)
+		require.NoError(t, user.DeactivateSelf(time.Now()))
+		repos.Users.Seed(user)
+		session, err := entities.NewSession(user.ID, "127.0.0.1", "TestAgent")
+		require.NoError(t, err)
+		require.NoError(t, repos.Sessions.Create(ctx, session))
+
+		_, err = sut.ValidateSession(ctx, session.SessionToken)
+		assert.ErrorIs(t, err, entities.ErrAccountDeactivated)
+	})
+
+	t.Run("期間内にログインすると再開する", func(t *testing.T) {
+		repos, sut, user := setup(t, true)
+		_, err := sut.DeactivateAccount(ctx, &inputport.DeactivateAccountRequest{UserID: user.ID, Password: "password123"})
+		require.NoError(t, err)
+
+		resp, err := login(sut, user)
+		require.NoError(t, err)
+		assert.True(t, resp.Reactivated)
+
+		stored, err := repos.Users.Read(ctx, user.ID)
+		require.NoError(t, err)
+		assert.True(t, stored.IsActive)
+		assert.False(t, stored.IsDeactivated())
+		assert.Nil(t, stored.DeactivatedAt)
+
+		resp, err = login(sut, user)
+		require.NoError(t, err)
+		assert.False(t, resp.Reactivated)
+	})
+
+	t.Run("期間を過ぎるとログインしても再開できない", func(t *testing.T) {
+		repos, sut, user := setup(t, true)
+		require.NoError(t, user.DeactivateSelf(time.Now().Add(-entities.UserReactivationWindow-time.Hour)))
+		repos.Users.Seed(user)
+
+		_, err := login(sut, user)
+		assert.ErrorIs(t, err, entities.ErrReactivationExpired)
+
+		stored, err := repos.Users.Read(ctx, user.ID)
+		require.NoError(t, err)
+		assert.True(t, stored.IsDeactivated())
+	})
+
+	t.Run("パスワードが違えば休止しない", func(t *testing.T) {
+		repos, sut, user := setup(t, false)
+
+		_, err := sut.DeactivateAccount(ctx, &inputport.DeactivateAccountRequest{UserID: user.ID, Password: "wrong"})
+		assert.ErrorIs(t, err, entities.ErrInvalidCredentials)
+
+		stored, err := repos.Users.Read(ctx, user.ID)
+		require.NoError(t, err)
+		assert.True(t, stored.IsActive)
+	})
+
+	t.Run("管理者が無効化したアカウントは休止・再開できない", func(t *testing.T) {
+		repos, sut, user := setup(t, true)
+		user.Deactivate()
+		repos.Users.Seed(user)
+
+		_, err := sut.DeactivateAccount(ctx, &inputport.DeactivateAccountRequest{UserID: user.ID, Password: "password123"})
+		assert.ErrorIs(t, err, entities.ErrUserInactive)
+
+		_, err = login(sut, user)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,108 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminAccountInteractor_CreateAdmin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ユーザーがいなければ操作する管理者なしで最初の管理者を作成し、仮パスワードを返す", func(t *testing.T) {
+		repos := testsupport.New()
+		sut := interactor.NewAdminAccountInteractor(repos.Users, repos.Sessions, &mockPasswordService{}, &mockLogger{})
+
+		resp, err := sut.CreateAdmin(ctx, &inputport.CreateAdminRequest{Username: "root", Email: "root@example.com", FirstName: "太郎", LastName: "管理"})
+		require.NoError(t, err)
+		assert.Equal(t, entities.RoleAdmin, resp.Admin.Role)
+		assert.True(t, resp.Admin.EmailVerified)
+		assert.NotEmpty(t, resp.TemporaryPassword)
+		assert.Equal(t, "hashed_"+resp.TemporaryPassword, resp.Admin.PasswordHash)
+
+		saved, err := repos.Users.ReadByUsername(ctx, "root")
+		require.NoError(t, err)
+		assert.Equal(t, resp.Admin.ID, saved.ID)
+	})
+
+	t.Run("ユーザーがいれば操作する管理者が必要", func(t *testing.T) {
+		repos := testsupport.New()
+		user := createTestUserWithBalance(t, "member", 0, entities.RoleUser)
+		repos.Users.Seed(user)
+		sut := interactor.NewAdminAccountInteractor(repos.Users, repos.Sessions, &mockPasswordService{}, &mockLogger{})
+
+		_, err := sut.CreateAdmin(ctx, &inputport.CreateAdminRequest{Username: "second", Email: "second@example.com", FirstName: "次郎", LastName: "管理"})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+
+		_, err = sut.CreateAdmin(ctx, &inputport.CreateAdminRequest{ActorID: &user.ID, Username: "second", Email: "second@example.com", FirstName: "次郎", LastName: "管理"})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired, "管理者でないユーザーは作成できない")
+	})
+
+	t.Run("管理者は指定したパスワードで管理者を作成できる", func(t *testing.T) {
+		repos := testsupport.New()
+		admin := createTestUserWithBalance(t, "admin", 0, entities.RoleAdmin)
+		repos.Users.Seed(admin)
+		sut := interactor.NewAdminAccountInteractor(repos.Users, repos.Sessions, &mockPasswordService{}, &mockLogger{})
+
+		resp, err := sut.CreateAdmin(ctx, &inputport.CreateAdminRequest{ActorID: &admin.ID, Username: "second", Email: "second@example.com", FirstName: "次郎", LastName: "管理", Password: "password123"})
+		require.NoError(t, err)
+		assert.Empty(t, resp.TemporaryPassword)
+		assert.Equal(t, "hashed_password123", resp.Admin.PasswordHash)
+
+		_, err = sut.CreateAdmin(ctx, &inputport.CreateAdminRequest{ActorID: &admin.ID, Username: "second", Email: "other@example.com", FirstName: "次郎", LastName: "管理"})
+		assert.ErrorIs(t, err, entities.ErrUsernameAlreadyExists)
+	})
+
+	t.Run("短いパスワードは拒否する", func(t *testing.T) {
+		repos := testsupport.New()
+		sut := interactor.NewAdminAccountInteractor(repos.Users, repos.Sessions, &mockPasswordService{}, &mockLogger{})
+
+		_, err := sut.CreateAdmin(ctx, &inputport.CreateAdminRequest{Username: "root", Email: "root@example.com", FirstName: "太郎", LastName: "管理", Password: "short"})
+		assert.ErrorIs(t, err, entities.ErrValidationFailed)
+		assert.Equal(t, 0, repos.Users.Calls("Create"))
+	})
+}
+
+func TestAdminAccountInteractor_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("パスワードを再設定し、ログイン中のセッションをすべて無効にする", func(t *testing.T) {
+		repos := testsupport.New()
+		admin := createTestUserWithBalance(t, "admin", 0, entities.RoleAdmin)
+		user := createTestUserWithBalance(t, "member", 0, entities.RoleUser)
+		repos.Users.Seed(admin, user)
+		session, err := entities.NewSession(user.ID, "127.0.0.1", "test")
+		require.NoError(t, err)
+		require.NoError(t, repos.Sessions.Create(ctx, session))
+		sut := interactor.NewAdminAccountInteractor(repos.Users, repos.Sessions, &mockPasswordService{}, &mockLogger{})
+
+		resp, err := sut.ResetPassword(ctx, &inputport.ResetUserPasswordRequest{ActorID: admin.ID, UserID: user.ID})
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.TemporaryPassword)
+
+		saved, err := repos.Users.Read(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "hashed_"+resp.TemporaryPassword, saved.PasswordHash)
+
+		sessions, err := repos.Sessions.ReadListByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+
+	t.Run("管理者以外は再設定できない", func(t *testing.T) {
+		repos := testsupport.New()
+		user := createTestUserWithBalance(t, "member", 0, entities.RoleUser)
+		repos.Users.Seed(user)
+		sut := interactor.NewAdminAccountInteractor(repos.Users, repos.Sessions, &mockPasswordService{}, &mockLogger{})
+
+		_, err := sut.ResetPassword(ctx, &inputport.ResetUserPasswordRequest{ActorID: user.ID, UserID: user.ID, Password: "password123"})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		assert.Equal(t, 0, repos.Users.Calls("Update"))
+	})
+}
@@ -0,0 +1,281 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockPendingAdminActionRepo は承認待ちの操作をメモリに保持する
+type mockPendingAdminActionRepo struct {
+	actions map[uuid.UUID]*entities.PendingAdminAction
+	events  []*entities.PendingAdminActionEvent
+}
+
+func newMockPendingAdminActionRepo() *mockPendingAdminActionRepo {
+	return &mockPendingAdminActionRepo{actions: make(map[uuid.UUID]*entities.PendingAdminAction)}
+}
+
+func (m *mockPendingAdminActionRepo) Create(ctx context.Context, action *entities.PendingAdminAction) error {
+	copied := *action
+	m.actions[action.ID] = &copied
+	return nil
+}
+
+func (m *mockPendingAdminActionRepo) Read(ctx context.Context, id uuid.UUID) (*entities.PendingAdminAction, error) {
+	a, ok := m.actions[id]
+	if !ok {
+		return nil, entities.ErrPendingActionNotFound
+	}
+	copied := *a
+	return &copied, nil
+}
+
+func (m *mockPendingAdminActionRepo) ReadByIdempotencyKey(ctx context.Context, key string) (*entities.PendingAdminAction, error) {
+	for _, a := range m.actions {
+		if a.IdempotencyKey == key {
+			copied := *a
+			return &copied, nil
+		}
+	}
+	return nil, entities.ErrPendingActionNotFound
+}
+
+func (m *mockPendingAdminActionRepo) ReadList(ctx context.Context, status entities.PendingAdminActionStatus, offset, limit int) ([]*entities.PendingAdminAction, error) {
+	var list []*entities.PendingAdminAction
+	for _, a := range m.actions {
+		if status == "" || a.Status == status {
+			list = append(list, a)
+		}
+	}
+	return list, nil
+}
+
+func (m *mockPendingAdminActionRepo) Count(ctx context.Context, status entities.PendingAdminActionStatus) (int64, error) {
+	list, _ := m.ReadList(ctx, status, 0, 0)
+	return int64(len(list)), nil
+}
+
+func (m *mockPendingAdminActionRepo) UpdateStatus(ctx context.Context, action *entities.PendingAdminAction, from entities.PendingAdminActionStatus) (bool, error) {
+	stored, ok := m.actions[action.ID]
+	if !ok || stored.Status != from {
+		return false, nil
+	}
+	copied := *action
+	m.actions[action.ID] = &copied
+	return true, nil
+}
+
+func (m *mockPendingAdminActionRepo) ReadExpired(ctx context.Context, now time.Time, limit int) ([]*entities.PendingAdminAction, error) {
+	var list []*entities.PendingAdminAction
+	for _, a := range m.actions {
+		if a.IsExpired(now) {
+			copied := *a
+			list = append(list, &copied)
+		}
+	}
+	return list, nil
+}
+
+func (m *mockPendingAdminActionRepo) CreateEvent(ctx context.Context, event *entities.PendingAdminActionEvent) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *mockPendingAdminActionRepo) ReadEvents(ctx context.Context, actionID uuid.UUID) ([]*entities.PendingAdminActionEvent, error) {
+	var events []*entities.PendingAdminActionEvent
+	for _, e := range m.events {
+		if e.ActionID == actionID {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func (m *mockPendingAdminActionRepo) eventTypes(actionID uuid.UUID) []entities.PendingAdminActionEventType {
+	var types []entities.PendingAdminActionEventType
+	for _, e := range m.events {
+		if e.ActionID == actionID {
+			types = append(types, e.Event)
+		}
+	}
+	return types
+}
+
+func TestAdminApprovalInteractor(t *testing.T) {
+	type fixture struct {
+		admin     inputport.AdminInputPort
+		sut       inputport.AdminApprovalInputPort
+		repo      *mockPendingAdminActionRepo
+		userRepo  *ctxTrackingUserRepo
+		txRepo    *ctxTrackingTransactionRepo
+		requester *entities.User
+		reviewer  *entities.User
+		target    *entities.User
+	}
+	setup := func() *fixture {
+		userRepo := newCtxTrackingUserRepo()
+		txRepo := newCtxTrackingTransactionRepo()
+		repo := newMockPendingAdminActionRepo()
+		settings := newMockSystemSettingsRepo()
+		settings.settings[entities.AdminApprovalThresholdSettingKey] = "1000"
+
+		requester := createTestUserWithBalance(t, "requester", 0, "admin")
+		reviewer := createTestUserWithBalance(t, "reviewer", 0, "admin")
+		target := createTestUserWithBalance(t, "target", 500, "user")
+		userRepo.setUser(requester)
+		userRepo.setUser(reviewer)
+		userRepo.setUser(target)
+
+		admin := interactor.NewAdminInteractor(
+			&ctxTrackingTxManager{}, userRepo, txRepo, newCtxTrackingIdempotencyRepo(), newCtxTrackingPointBatchRepo(),
+			newMockReasonCodeRepo(), newMockDepartmentRepo(), newMockBudgetRepo(), repo, settings, &mockAnalyticsDS{}, &mockNotificationDispatcher{}, &mockLogger{},
+		)
+		sut := interactor.NewAdminApprovalInteractor(&ctxTrackingTxManager{}, repo, settings, userRepo, admin, &mockLogger{})
+		return &fixture{admin, sut, repo, userRepo, txRepo, requester, reviewer, target}
+	}
+	grant := func(f *fixture, amount int64, key string) (*inputport.GrantPointsResponse, error) {
+		return f.admin.GrantPoints(context.Background(), &inputport.GrantPointsRequest{
+			AdminID: f.requester.ID, UserID: f.target.ID, Amount: amount, ReasonCode: "reward",
+			Description: "approval", IdempotencyKey: key,
+		})
+	}
+	review := func(f *fixture, reviewer uuid.UUID, actionID uuid.UUID) *inputport.ReviewPendingActionRequest {
+		return &inputport.ReviewPendingActionRequest{AdminID: reviewer, ActionID: actionID, Comment: "ok"}
+	}
+
+	t.Run("閾値以下の付与はそのまま実行する", func(t *testing.T) {
+		f := setup()
+		resp, err := grant(f, 1000, "small")
+		require.NoError(t, err)
+		assert.Nil(t, resp.PendingAction)
+		assert.NotNil(t, resp.Transaction)
+	})
+
+	t.Run("閾値を超える付与は承認待ちになり、同じキーの再送では同じ操作を返す", func(t *testing.T) {
+		f := setup()
+		resp, err := grant(f, 5000, "large")
+		require.NoError(t, err)
+		require.NotNil(t, resp.PendingAction)
+		assert.Nil(t, resp.Transaction)
+		assert.Empty(t, f.txRepo.transactions)
+		assert.Equal(t, []entities.PendingAdminActionEventType{entities.PendingAdminActionEventRequested}, f.repo.eventTypes(resp.PendingAction.ID))
+
+		again, err := grant(f, 5000, "large")
+		require.NoError(t, err)
+		assert.Equal(t, resp.PendingAction.ID, again.PendingAction.ID)
+		assert.Len(t, f.repo.actions, 1)
+	})
+
+	t.Run("ドライランは承認待ちにせず承認が必要かを返す", func(t *testing.T) {
+		f := setup()
+		resp, err := f.admin.GrantPoints(context.Background(), &inputport.GrantPointsRequest{
+			AdminID: f.requester.ID, UserID: f.target.ID, Amount: 5000, ReasonCode: "reward",
+			Description: "approval", IdempotencyKey: "dry", DryRun: true,
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.RequiresApproval)
+		assert.Nil(t, resp.PendingAction)
+		assert.Empty(t, f.repo.actions)
+	})
+
+	t.Run("別の管理者が承認すると申請者の付与として実行する", func(t *testing.T) {
+		f := setup()
+		resp, err := grant(f, 5000, "approve")
+		require.NoError(t, err)
+
+		detail, err := f.sut.ApproveAction(context.Background(), review(f, f.reviewer.ID, resp.PendingAction.ID))
+		require.NoError(t, err)
+		assert.Equal(t, entities.PendingAdminActionStatusExecuted, detail.Action.Status)
+		require.Len(t, f.txRepo.transactions, 1)
+		assert.Equal(t, f.txRepo.transactions[0].ID, *detail.Action.TransactionID)
+		assert.Equal(t, []entities.PendingAdminActionEventType{
+			entities.PendingAdminActionEventRequested,
+			entities.PendingAdminActionEventApproved,
+			entities.PendingAdminActionEventExecuted,
+		}, f.repo.eventTypes(resp.PendingAction.ID))
+
+		_, err = f.sut.ApproveAction(context.Background(), review(f, uuid.New(), resp.PendingAction.ID))
+		assert.Error(t, err)
+		assert.Len(t, f.txRepo.transactions, 1, "二度は実行しない")
+	})
+
+	t.Run("申請した本人は承認できない", func(t *testing.T) {
+		f := setup()
+		resp, err := grant(f, 5000, "self")
+		require.NoError(t, err)
+
+		_, err = f.sut.ApproveAction(context.Background(), review(f, f.requester.ID, resp.PendingAction.ID))
+		assert.ErrorIs(t, err, entities.ErrSelfApproval)
+		assert.Empty(t, f.txRepo.transactions)
+	})
+
+	t.Run("承認後の実行に失敗した場合は失敗として記録する", func(t *testing.T) {
+		f := setup()
+		resp, err := f.admin.DeductPoints(context.Background(), &inputport.DeductPointsRequest{
+			AdminID: f.requester.ID, UserID: f.target.ID, Amount: 2000, ReasonCode: "reward",
+			Description: "approval", IdempotencyKey: "deduct",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp.PendingAction)
+
+		_, err = f.sut.ApproveAction(context.Background(), review(f, f.reviewer.ID, resp.PendingAction.ID))
+		assert.ErrorIs(t, err, entities.ErrInsufficientBalance)
+
+		stored := f.repo.actions[resp.PendingAction.ID]
+		assert.Equal(t, entities.PendingAdminActionStatusFailed, stored.Status)
+		assert.NotEmpty(t, stored.FailureReason)
+		assert.Equal(t, entities.PendingAdminActionEventFailed, f.repo.eventTypes(stored.ID)[2])
+	})
+
+	t.Run("却下した操作は実行しない", func(t *testing.T) {
+		f := setup()
+		resp, err := grant(f, 5000, "reject")
+		require.NoError(t, err)
+
+		detail, err := f.sut.RejectAction(context.Background(), review(f, f.reviewer.ID, resp.PendingAction.ID))
+		require.NoError(t, err)
+		assert.Equal(t, entities.PendingAdminActionStatusRejected, detail.Action.Status)
+
+		_, err = f.sut.ApproveAction(context.Background(), review(f, f.reviewer.ID, resp.PendingAction.ID))
+		assert.ErrorIs(t, err, entities.ErrPendingActionNotPending)
+		assert.Empty(t, f.txRepo.transactions)
+	})
+
+	t.Run("期限を過ぎた操作は期限切れにする", func(t *testing.T) {
+		f := setup()
+		resp, err := grant(f, 5000, "expire")
+		require.NoError(t, err)
+
+		expired, err := f.sut.ExpireStaleActions(context.Background(), time.Now().Add(entities.PendingAdminActionTTL+time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, expired)
+		assert.Equal(t, entities.PendingAdminActionStatusExpired, f.repo.actions[resp.PendingAction.ID].Status)
+
+		_, err = f.sut.ApproveAction(context.Background(), review(f, f.reviewer.ID, resp.PendingAction.ID))
+		assert.ErrorIs(t, err, entities.ErrPendingActionNotPending)
+	})
+
+	t.Run("閾値は0以上で設定し、管理者以外は扱えない", func(t *testing.T) {
+		f := setup()
+		_, err := f.sut.UpdateApprovalThreshold(context.Background(), &inputport.UpdateApprovalThresholdRequest{AdminID: f.reviewer.ID, Threshold: -1})
+		assert.ErrorIs(t, err, entities.ErrInvalidApprovalLimit)
+
+		threshold, err := f.sut.UpdateApprovalThreshold(context.Background(), &inputport.UpdateApprovalThresholdRequest{AdminID: f.reviewer.ID, Threshold: 0})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), threshold)
+		resp, err := grant(f, 5000, "disabled")
+		require.NoError(t, err)
+		assert.Nil(t, resp.PendingAction, "0なら承認は不要")
+
+		_, err = f.sut.GetApprovalThreshold(context.Background(), f.target.ID)
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
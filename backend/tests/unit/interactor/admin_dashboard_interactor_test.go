@@ -0,0 +1,101 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminDashboardInteractor(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.New()
+	newRepos := func() *testsupport.Repositories {
+		repos := testsupport.New()
+		repos.Users.Seed(&entities.User{ID: adminID, Username: "admin", Role: entities.RoleAdmin, IsActive: true})
+		return repos
+	}
+
+	t.Run("カウンターとDBの状態を返す", func(t *testing.T) {
+		repos := newRepos()
+		repos.AdminDashboard.Counters = &entities.AdminDashboardCounters{OnlineUsers: 3, PendingTransferRequests: 2, ExpiringPoints: 500}
+		sut := interactor.NewAdminDashboardInteractor(repos.AdminDashboard, repos.Users, &mockLogger{})
+
+		dashboard, err := sut.GetDashboard(ctx, adminID)
+		require.NoError(t, err)
+		assert.True(t, dashboard.Database.Healthy)
+		require.NotNil(t, dashboard.Counters)
+		assert.Equal(t, int64(3), dashboard.Counters.OnlineUsers)
+		assert.Equal(t, int64(2), dashboard.Counters.PendingTransferRequests)
+		assert.Equal(t, int64(500), dashboard.Counters.ExpiringPoints)
+	})
+
+	t.Run("キャッシュ期間内は集計し直さない", func(t *testing.T) {
+		repos := newRepos()
+		sut := interactor.NewAdminDashboardInteractor(repos.AdminDashboard, repos.Users, &mockLogger{})
+
+		first, err := sut.GetDashboard(ctx, adminID)
+		require.NoError(t, err)
+		repos.AdminDashboard.Counters = &entities.AdminDashboardCounters{OnlineUsers: 10}
+		second, err := sut.GetDashboard(ctx, adminID)
+		require.NoError(t, err)
+
+		assert.Same(t, first, second)
+		assert.Equal(t, 1, repos.AdminDashboard.Calls("GetCounters"))
+		assert.Equal(t, 1, repos.AdminDashboard.Calls("Ping"))
+	})
+
+	t.Run("テナントごとにキャッシュする", func(t *testing.T) {
+		repos := newRepos()
+		sut := interactor.NewAdminDashboardInteractor(repos.AdminDashboard, repos.Users, &mockLogger{})
+
+		_, err := sut.GetDashboard(entities.WithTenantID(ctx, uuid.New()), adminID)
+		require.NoError(t, err)
+		_, err = sut.GetDashboard(entities.WithTenantID(ctx, uuid.New()), adminID)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, repos.AdminDashboard.Calls("GetCounters"))
+	})
+
+	t.Run("DBに疎通できなければカウンターを省いてDBの状態だけ返す", func(t *testing.T) {
+		repos := newRepos()
+		repos.AdminDashboard.FailOn("Ping", errors.New("connection refused"))
+		sut := interactor.NewAdminDashboardInteractor(repos.AdminDashboard, repos.Users, &mockLogger{})
+
+		dashboard, err := sut.GetDashboard(ctx, adminID)
+		require.NoError(t, err)
+		assert.False(t, dashboard.Database.Healthy)
+		assert.Equal(t, "connection refused", dashboard.Database.Error)
+		assert.Nil(t, dashboard.Counters)
+		assert.Zero(t, repos.AdminDashboard.Calls("GetCounters"))
+	})
+
+	t.Run("集計に失敗した場合はエラーを返し、キャッシュしない", func(t *testing.T) {
+		repos := newRepos()
+		repos.AdminDashboard.FailOnce("GetCounters", errors.New("timeout"))
+		sut := interactor.NewAdminDashboardInteractor(repos.AdminDashboard, repos.Users, &mockLogger{})
+
+		_, err := sut.GetDashboard(ctx, adminID)
+		require.Error(t, err)
+		dashboard, err := sut.GetDashboard(ctx, adminID)
+		require.NoError(t, err)
+		assert.NotNil(t, dashboard.Counters)
+	})
+
+	t.Run("管理者以外はエラー", func(t *testing.T) {
+		repos := newRepos()
+		member := &entities.User{ID: uuid.New(), Username: "member", Role: entities.RoleUser, IsActive: true}
+		repos.Users.Seed(member)
+		sut := interactor.NewAdminDashboardInteractor(repos.AdminDashboard, repos.Users, &mockLogger{})
+
+		_, err := sut.GetDashboard(ctx, member.ID)
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		assert.Zero(t, repos.AdminDashboard.Calls("Ping"))
+	})
+}
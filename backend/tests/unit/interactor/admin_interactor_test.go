@@ -3,6 +3,7 @@ package interactor_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -105,6 +106,10 @@ func (m *ctxTrackingUserRepo) UpdateBalancesWithLock(ctx context.Context, update
 	m.ctxRecords["UpdateBalancesWithLock"] = ctx
 	return nil
 }
+func (m *ctxTrackingUserRepo) UpdateReservedBalanceWithLock(ctx context.Context, userID uuid.UUID, amount int64, isRelease bool) error {
+	m.ctxRecords["UpdateReservedBalanceWithLock"] = ctx
+	return nil
+}
 func (m *ctxTrackingUserRepo) ReadList(ctx context.Context, offset, limit int) ([]*entities.User, error) {
 	m.ctxRecords["ReadList"] = ctx
 	result := make([]*entities.User, 0)
@@ -117,11 +122,11 @@ func (m *ctxTrackingUserRepo) Count(ctx context.Context) (int64, error) {
 	return int64(len(m.users)), nil
 }
 func (m *ctxTrackingUserRepo) Delete(ctx context.Context, id uuid.UUID) error { return nil }
-func (m *ctxTrackingUserRepo) ReadListWithSearch(ctx context.Context, search, sortBy, sortOrder string, offset, limit int) ([]*entities.User, error) {
+func (m *ctxTrackingUserRepo) ReadListWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID, sortBy, sortOrder string, offset, limit int) ([]*entities.User, error) {
 	m.ctxRecords["ReadListWithSearch"] = ctx
 	return []*entities.User{}, nil
 }
-func (m *ctxTrackingUserRepo) CountWithSearch(ctx context.Context, search string) (int64, error) {
+func (m *ctxTrackingUserRepo) CountWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID) (int64, error) {
 	return 0, nil
 }
 func (m *ctxTrackingUserRepo) ReadPersonalQRCode(ctx context.Context, userID uuid.UUID) (string, error) {
@@ -133,6 +138,7 @@ func (m *ctxTrackingUserRepo) ReadPersonalQRCode(ctx context.Context, userID uui
 type ctxTrackingTransactionRepo struct {
 	ctxRecords   map[string]context.Context
 	transactions []*entities.Transaction
+	anonymized   map[uuid.UUID]bool // userID -> scrubMemos
 }
 
 func newCtxTrackingTransactionRepo() *ctxTrackingTransactionRepo {
@@ -170,23 +176,39 @@ func (m *ctxTrackingTransactionRepo) Update(ctx context.Context, tx *entities.Tr
 	m.ctxRecords["Update"] = ctx
 	return nil
 }
-func (m *ctxTrackingTransactionRepo) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+func (m *ctxTrackingTransactionRepo) CountByUserID(ctx context.Context, userID uuid.UUID, reasonCode string) (int64, error) {
 	return int64(len(m.transactions)), nil
 }
-func (m *ctxTrackingTransactionRepo) ReadListAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, sortBy, sortOrder string, offset, limit int) ([]*entities.Transaction, error) {
+func (m *ctxTrackingTransactionRepo) ReadListAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode, sortBy, sortOrder string, offset, limit int) ([]*entities.Transaction, error) {
 	m.ctxRecords["ReadListAllWithFilter"] = ctx
 	return m.transactions, nil
 }
 func (m *ctxTrackingTransactionRepo) CountAll(ctx context.Context) (int64, error) {
 	return int64(len(m.transactions)), nil
 }
-func (m *ctxTrackingTransactionRepo) CountAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo string) (int64, error) {
+func (m *ctxTrackingTransactionRepo) CountAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode string, departmentIDs []uuid.UUID) (int64, error) {
 	return int64(len(m.transactions)), nil
 }
-func (m *ctxTrackingTransactionRepo) ReadListByUserIDWithUsers(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.TransactionWithUsers, error) {
+func (m *ctxTrackingTransactionRepo) ReadListByUserIDWithUsers(ctx context.Context, userID uuid.UUID, reasonCode string, offset, limit int) ([]*entities.TransactionWithUsers, error) {
+	return nil, nil
+}
+func (m *ctxTrackingTransactionRepo) ReadListAllWithFilterAndUsers(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode string, departmentIDs []uuid.UUID, sortBy, sortOrder string, offset, limit int) ([]*entities.TransactionWithUsers, error) {
+	return nil, nil
+}
+func (m *ctxTrackingTransactionRepo) AnonymizeUserReferences(ctx context.Context, userID uuid.UUID, scrubMemos bool) error {
+	m.ctxRecords["AnonymizeUserReferences"] = ctx
+	if m.anonymized == nil {
+		m.anonymized = make(map[uuid.UUID]bool)
+	}
+	m.anonymized[userID] = scrubMemos
+	return nil
+}
+
+func (m *ctxTrackingTransactionRepo) SumExchangedWith(ctx context.Context, userID uuid.UUID, counterpartIDs []uuid.UUID) ([]*entities.PointsExchanged, error) {
 	return nil, nil
 }
-func (m *ctxTrackingTransactionRepo) ReadListAllWithFilterAndUsers(ctx context.Context, transactionType, dateFrom, dateTo, sortBy, sortOrder string, offset, limit int) ([]*entities.TransactionWithUsers, error) {
+
+func (m *ctxTrackingTransactionRepo) ReadTransferAmountUsage(ctx context.Context, fromUserID uuid.UUID, toUserIDs []uuid.UUID, since time.Time) ([]*entities.TransferAmountUsage, error) {
 	return nil, nil
 }
 
@@ -253,6 +275,39 @@ func (m *ctxTrackingPointBatchRepo) MarkExpired(ctx context.Context, batchID uui
 func (m *ctxTrackingPointBatchRepo) FindUpcomingExpirations(ctx context.Context, userID uuid.UUID) ([]*entities.PointBatch, error) {
 	return nil, nil
 }
+func (m *ctxTrackingPointBatchRepo) Read(ctx context.Context, id uuid.UUID) (*entities.PointBatch, error) {
+	return nil, entities.ErrPointBatchNotFound
+}
+func (m *ctxTrackingPointBatchRepo) UpdateExpiresAt(ctx context.Context, id uuid.UUID, expiresAt time.Time) error {
+	return nil
+}
+func (m *ctxTrackingPointBatchRepo) FindActiveByUser(ctx context.Context, userID uuid.UUID) ([]*entities.PointBatch, error) {
+	return nil, nil
+}
+func (m *ctxTrackingPointBatchRepo) MarkRestored(ctx context.Context, id uuid.UUID, restoredAt time.Time) error {
+	return nil
+}
+func (m *ctxTrackingPointBatchRepo) FindRestorableBatches(ctx context.Context, expiredAfter time.Time, userID *uuid.UUID, limit int) ([]*entities.PointBatch, error) {
+	return nil, nil
+}
+func (m *ctxTrackingPointBatchRepo) FindByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.PointBatch, error) {
+	return nil, nil
+}
+func (m *ctxTrackingPointBatchRepo) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return 0, nil
+}
+func (m *ctxTrackingPointBatchRepo) AdjustExpiresAt(ctx context.Context, id uuid.UUID, expiresAt, adjustedAt time.Time) error {
+	return nil
+}
+func (m *ctxTrackingPointBatchRepo) Cancel(ctx context.Context, id uuid.UUID, remaining int64, cancelledAt time.Time) error {
+	return nil
+}
+func (m *ctxTrackingPointBatchRepo) CreateAdjustment(ctx context.Context, adjustment *entities.PointBatchAdjustment) error {
+	return nil
+}
+func (m *ctxTrackingPointBatchRepo) FindAdjustments(ctx context.Context, batchIDs []uuid.UUID) ([]*entities.PointBatchAdjustment, error) {
+	return nil, nil
+}
 
 // --- Context-Tracking FriendshipRepository ---
 
@@ -299,9 +354,34 @@ func (m *ctxTrackingFriendshipRepo) CountPendingRequests(ctx context.Context, us
 	return 0, nil
 }
 
+func (m *ctxTrackingFriendshipRepo) ReadListMutualFriends(ctx context.Context, userID1, userID2 uuid.UUID, offset, limit int) ([]*entities.User, error) {
+	return nil, nil
+}
+
+func (m *ctxTrackingFriendshipRepo) CountMutualFriends(ctx context.Context, userID1, userID2 uuid.UUID) (int64, error) {
+	return 0, nil
+}
+
 // --- Mock AnalyticsDataSource ---
 
-type mockAnalyticsDS struct{}
+type mockAnalyticsDS struct {
+	cohortSizes    []*entities.CohortSizeResult
+	cohortActivity []*entities.CohortActivityResult
+	featureUsage   []*entities.FeatureUsageResult
+	expiryForecast []*entities.ExpiryForecastResult
+	outstanding    int64
+	velocity       *entities.CirculationVelocityResult
+	conservation   *entities.PointConservation
+
+	// 最後に呼ばれた集計の引数
+	granularity   entities.AnalyticsGranularity
+	basis         entities.ActivityBasis
+	from, to      time.Time
+	forecastFrom  time.Time
+	forecastWeeks int
+	velocitySince time.Time
+	reasonCode    string
+}
 
 func (m *mockAnalyticsDS) GetUserBalanceSummary(ctx context.Context) (*entities.AnalyticsSummaryResult, error) {
 	return &entities.AnalyticsSummaryResult{TotalBalance: 100000, AverageBalance: 5000, ActiveUsers: 20}, nil
@@ -327,6 +407,45 @@ func (m *mockAnalyticsDS) GetMonthlyIssuedPoints(ctx context.Context) (int64, er
 func (m *mockAnalyticsDS) GetMonthlyTransactionCount(ctx context.Context) (int64, error) {
 	return 50, nil
 }
+func (m *mockAnalyticsDS) GetActiveUserCounts(ctx context.Context, granularity entities.AnalyticsGranularity, basis entities.ActivityBasis, from, to time.Time) ([]*entities.ActiveUserCountResult, error) {
+	m.granularity, m.basis, m.from, m.to = granularity, basis, from, to
+	return []*entities.ActiveUserCountResult{{PeriodStart: granularity.Truncate(from), ActiveUsers: 5}}, nil
+}
+func (m *mockAnalyticsDS) GetCohortSizes(ctx context.Context, granularity entities.AnalyticsGranularity, from, to time.Time) ([]*entities.CohortSizeResult, error) {
+	return m.cohortSizes, nil
+}
+func (m *mockAnalyticsDS) GetCohortActivity(ctx context.Context, granularity entities.AnalyticsGranularity, basis entities.ActivityBasis, from, to time.Time) ([]*entities.CohortActivityResult, error) {
+	return m.cohortActivity, nil
+}
+func (m *mockAnalyticsDS) GetFeatureUsage(ctx context.Context, from, to time.Time) ([]*entities.FeatureUsageResult, error) {
+	return m.featureUsage, nil
+}
+func (m *mockAnalyticsDS) GetExpiryForecast(ctx context.Context, from time.Time, weeks int) ([]*entities.ExpiryForecastResult, error) {
+	m.forecastFrom, m.forecastWeeks = from, weeks
+	return m.expiryForecast, nil
+}
+func (m *mockAnalyticsDS) GetOutstandingPoints(ctx context.Context, now time.Time) (int64, error) {
+	return m.outstanding, nil
+}
+func (m *mockAnalyticsDS) GetCirculationVelocity(ctx context.Context, since time.Time) (*entities.CirculationVelocityResult, error) {
+	m.velocitySince = since
+	if m.velocity == nil {
+		return &entities.CirculationVelocityResult{}, nil
+	}
+	return m.velocity, nil
+}
+func (m *mockAnalyticsDS) GetPointConservation(ctx context.Context) (*entities.PointConservation, error) {
+	if m.conservation == nil {
+		return &entities.PointConservation{}, nil
+	}
+	return m.conservation, nil
+}
+func (m *mockAnalyticsDS) GetReasonCodeBreakdown(ctx context.Context, since time.Time, reasonCode string) ([]*entities.ReasonCodeBreakdownResult, error) {
+	m.reasonCode = reasonCode
+	return []*entities.ReasonCodeBreakdownResult{
+		{ReasonCode: "reward", Label: "報酬", TransactionType: "admin_grant", Count: 2, TotalAmount: 300},
+	}, nil
+}
 
 // --- Mock Logger ---
 
@@ -371,14 +490,14 @@ func TestAdminInteractor_GrantPoints(t *testing.T) {
 		userRepo.setUser(admin)
 		userRepo.setUser(target)
 
-		i := interactor.NewAdminInteractor(txMgr, userRepo, txRepo, idempRepo, pbRepo, analyticsDS, logger)
+		i := interactor.NewAdminInteractor(txMgr, userRepo, txRepo, idempRepo, pbRepo, newMockReasonCodeRepo(), newMockDepartmentRepo(), newMockBudgetRepo(), newMockPendingAdminActionRepo(), newMockSystemSettingsRepo(), analyticsDS, &mockNotificationDispatcher{}, logger)
 		return txMgr, userRepo, txRepo, idempRepo, pbRepo, i, admin, target
 	}
 
 	t.Run("正常にポイント付与できる", func(t *testing.T) {
 		_, _, _, _, _, sut, admin, target := setup()
 		resp, err := sut.GrantPoints(context.Background(), &inputport.GrantPointsRequest{
-			AdminID: admin.ID, UserID: target.ID, Amount: 500,
+			AdminID: admin.ID, UserID: target.ID, Amount: 500, ReasonCode: "reward",
 			Description: "test", IdempotencyKey: "grant-" + uuid.New().String(),
 		})
 		require.NoError(t, err)
@@ -390,7 +509,7 @@ func TestAdminInteractor_GrantPoints(t *testing.T) {
 	t.Run("txManager.Do内の全呼び出しがトランザクションコンテキストを使用する", func(t *testing.T) {
 		txMgr, userRepo, txRepo, idempRepo, pbRepo, sut, admin, target := setup()
 		_, err := sut.GrantPoints(context.Background(), &inputport.GrantPointsRequest{
-			AdminID: admin.ID, UserID: target.ID, Amount: 100,
+			AdminID: admin.ID, UserID: target.ID, Amount: 100, ReasonCode: "reward",
 			Description: "ctx test", IdempotencyKey: "ctx-grant-" + uuid.New().String(),
 		})
 		require.NoError(t, err)
@@ -412,18 +531,18 @@ func TestAdminInteractor_GrantPoints(t *testing.T) {
 	t.Run("金額が0以下ならエラー", func(t *testing.T) {
 		_, _, _, _, _, sut, admin, target := setup()
 		_, err := sut.GrantPoints(context.Background(), &inputport.GrantPointsRequest{
-			AdminID: admin.ID, UserID: target.ID, Amount: 0,
+			AdminID: admin.ID, UserID: target.ID, Amount: 0, ReasonCode: "reward",
 			Description: "test", IdempotencyKey: "key1",
 		})
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "amount must be positive")
+		assert.ErrorIs(t, err, entities.ErrInvalidAmount)
 	})
 
 	t.Run("管理者権限がないとエラー", func(t *testing.T) {
 		_, _, _, _, _, sut, _, target := setup()
 		nonAdmin := createTestUserWithBalance(t, "nonadmin", 0, "user")
 		_, err := sut.GrantPoints(context.Background(), &inputport.GrantPointsRequest{
-			AdminID: nonAdmin.ID, UserID: target.ID, Amount: 100,
+			AdminID: nonAdmin.ID, UserID: target.ID, Amount: 100, ReasonCode: "reward",
 			Description: "test", IdempotencyKey: "key2",
 		})
 		assert.Error(t, err)
@@ -432,7 +551,7 @@ func TestAdminInteractor_GrantPoints(t *testing.T) {
 	t.Run("対象ユーザーが存在しないとエラー", func(t *testing.T) {
 		_, _, _, _, _, sut, admin, _ := setup()
 		_, err := sut.GrantPoints(context.Background(), &inputport.GrantPointsRequest{
-			AdminID: admin.ID, UserID: uuid.New(), Amount: 100,
+			AdminID: admin.ID, UserID: uuid.New(), Amount: 100, ReasonCode: "reward",
 			Description: "test", IdempotencyKey: "key3",
 		})
 		assert.Error(t, err)
@@ -445,7 +564,7 @@ func TestAdminInteractor_GrantPoints(t *testing.T) {
 		userRepo.setUser(inactive)
 
 		_, err := sut.GrantPoints(context.Background(), &inputport.GrantPointsRequest{
-			AdminID: admin.ID, UserID: inactive.ID, Amount: 100,
+			AdminID: admin.ID, UserID: inactive.ID, Amount: 100, ReasonCode: "reward",
 			Description: "test", IdempotencyKey: "key4",
 		})
 		assert.Error(t, err)
@@ -457,19 +576,78 @@ func TestAdminInteractor_GrantPoints(t *testing.T) {
 		key := "idempotent-grant-" + uuid.New().String()
 
 		resp1, err := sut.GrantPoints(context.Background(), &inputport.GrantPointsRequest{
-			AdminID: admin.ID, UserID: target.ID, Amount: 100,
+			AdminID: admin.ID, UserID: target.ID, Amount: 100, ReasonCode: "reward",
 			Description: "test", IdempotencyKey: key,
 		})
 		require.NoError(t, err)
 
 		// 同じキーで再度呼ぶ
 		resp2, err := sut.GrantPoints(context.Background(), &inputport.GrantPointsRequest{
-			AdminID: admin.ID, UserID: target.ID, Amount: 100,
+			AdminID: admin.ID, UserID: target.ID, Amount: 100, ReasonCode: "reward",
 			Description: "test", IdempotencyKey: key,
 		})
 		require.NoError(t, err)
 		assert.Equal(t, resp1.Transaction.ID, resp2.Transaction.ID)
 	})
+
+	t.Run("ドライランでは付与結果を返すが冪等性キーを保存しない", func(t *testing.T) {
+		_, _, _, idempRepo, _, sut, admin, target := setup()
+		resp, err := sut.GrantPoints(context.Background(), &inputport.GrantPointsRequest{
+			AdminID: admin.ID, UserID: target.ID, Amount: 500, ReasonCode: "reward",
+			Description: "dry run", IdempotencyKey: "dry-grant-" + uuid.New().String(), DryRun: true,
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.DryRun)
+		require.NotNil(t, resp.Batch)
+		assert.Equal(t, int64(500), resp.Batch.OriginalAmount)
+		assert.Equal(t, int64(1500), resp.User.Balance)
+		assert.Empty(t, idempRepo.keys)
+	})
+
+	t.Run("ドライランでも検証エラーはそのまま返す", func(t *testing.T) {
+		_, _, _, _, _, sut, admin, target := setup()
+		_, err := sut.GrantPoints(context.Background(), &inputport.GrantPointsRequest{
+			AdminID: admin.ID, UserID: target.ID, Amount: 0, ReasonCode: "reward",
+			Description: "dry run", IdempotencyKey: "dry-grant-invalid", DryRun: true,
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidAmount)
+	})
+	t.Run("理由コードとタグが取引に記録される", func(t *testing.T) {
+		_, _, txRepo, _, _, sut, admin, target := setup()
+		_, err := sut.GrantPoints(context.Background(), &inputport.GrantPointsRequest{
+			AdminID: admin.ID, UserID: target.ID, Amount: 100, ReasonCode: " Reward ", Tag: " project-x ",
+			Description: "memo", IdempotencyKey: "grant-memo-" + uuid.New().String(),
+		})
+		require.NoError(t, err)
+		require.Len(t, txRepo.transactions, 1)
+		assert.Equal(t, "reward", txRepo.transactions[0].ReasonCode)
+		assert.Equal(t, "project-x", txRepo.transactions[0].Tag)
+	})
+
+	t.Run("理由コードの検証エラー", func(t *testing.T) {
+		cases := []struct {
+			name       string
+			reasonCode string
+			tag        string
+			wantErr    error
+		}{
+			{"理由コード未指定", "", "", entities.ErrReasonCodeRequired},
+			{"未登録の理由コード", "unknown", "", entities.ErrReasonCodeNotFound},
+			{"無効化された理由コード", "retired", "", entities.ErrReasonCodeInactive},
+			{"長すぎるタグ", "reward", strings.Repeat("あ", entities.TransactionTagMaxLength+1), entities.ErrInvalidTransactionTag},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, _, txRepo, _, _, sut, admin, target := setup()
+				_, err := sut.GrantPoints(context.Background(), &inputport.GrantPointsRequest{
+					AdminID: admin.ID, UserID: target.ID, Amount: 100, ReasonCode: tc.reasonCode, Tag: tc.tag,
+					Description: "test", IdempotencyKey: "grant-reason-" + uuid.New().String(),
+				})
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, txRepo.transactions)
+			})
+		}
+	})
 }
 
 // --- DeductPoints ---
@@ -489,14 +667,14 @@ func TestAdminInteractor_DeductPoints(t *testing.T) {
 		userRepo.setUser(admin)
 		userRepo.setUser(target)
 
-		i := interactor.NewAdminInteractor(txMgr, userRepo, txRepo, idempRepo, pbRepo, analyticsDS, logger)
+		i := interactor.NewAdminInteractor(txMgr, userRepo, txRepo, idempRepo, pbRepo, newMockReasonCodeRepo(), newMockDepartmentRepo(), newMockBudgetRepo(), newMockPendingAdminActionRepo(), newMockSystemSettingsRepo(), analyticsDS, &mockNotificationDispatcher{}, logger)
 		return txMgr, userRepo, txRepo, idempRepo, i, admin, target
 	}
 
 	t.Run("正常にポイント減算できる", func(t *testing.T) {
 		_, _, _, _, sut, admin, target := setup()
 		resp, err := sut.DeductPoints(context.Background(), &inputport.DeductPointsRequest{
-			AdminID: admin.ID, UserID: target.ID, Amount: 500,
+			AdminID: admin.ID, UserID: target.ID, Amount: 500, ReasonCode: "reward",
 			Description: "test", IdempotencyKey: "deduct-" + uuid.New().String(),
 		})
 		require.NoError(t, err)
@@ -507,7 +685,7 @@ func TestAdminInteractor_DeductPoints(t *testing.T) {
 	t.Run("txManager.Do内の全呼び出しがトランザクションコンテキストを使用する", func(t *testing.T) {
 		txMgr, userRepo, txRepo, idempRepo, sut, admin, target := setup()
 		_, err := sut.DeductPoints(context.Background(), &inputport.DeductPointsRequest{
-			AdminID: admin.ID, UserID: target.ID, Amount: 100,
+			AdminID: admin.ID, UserID: target.ID, Amount: 100, ReasonCode: "reward",
 			Description: "ctx test", IdempotencyKey: "ctx-deduct-" + uuid.New().String(),
 		})
 		require.NoError(t, err)
@@ -526,31 +704,88 @@ func TestAdminInteractor_DeductPoints(t *testing.T) {
 	t.Run("残高不足ならエラー", func(t *testing.T) {
 		_, _, _, _, sut, admin, target := setup()
 		_, err := sut.DeductPoints(context.Background(), &inputport.DeductPointsRequest{
-			AdminID: admin.ID, UserID: target.ID, Amount: 99999,
+			AdminID: admin.ID, UserID: target.ID, Amount: 99999, ReasonCode: "reward",
 			Description: "too much", IdempotencyKey: "deduct-fail-" + uuid.New().String(),
 		})
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "insufficient balance")
+		assert.ErrorIs(t, err, entities.ErrInsufficientBalance)
+	})
+
+	t.Run("確保中の送金リクエストの分は減算できない", func(t *testing.T) {
+		_, userRepo, _, _, sut, admin, target := setup()
+		target.ReservedBalance = 9800
+		_, err := sut.DeductPoints(context.Background(), &inputport.DeductPointsRequest{
+			AdminID: admin.ID, UserID: target.ID, Amount: 500, ReasonCode: "reward",
+			Description: "reserved", IdempotencyKey: "deduct-reserved-" + uuid.New().String(),
+		})
+		assert.ErrorIs(t, err, entities.ErrInsufficientBalance)
+		assert.NotContains(t, userRepo.ctxRecords, "UpdateBalanceWithLock")
 	})
 
 	t.Run("金額が0以下ならエラー", func(t *testing.T) {
 		_, _, _, _, sut, admin, target := setup()
 		_, err := sut.DeductPoints(context.Background(), &inputport.DeductPointsRequest{
-			AdminID: admin.ID, UserID: target.ID, Amount: -1,
+			AdminID: admin.ID, UserID: target.ID, Amount: -1, ReasonCode: "reward",
 			Description: "test", IdempotencyKey: "key",
 		})
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "amount must be positive")
+		assert.ErrorIs(t, err, entities.ErrInvalidAmount)
 	})
 
 	t.Run("管理者権限がないとエラー", func(t *testing.T) {
 		_, _, _, _, sut, _, target := setup()
 		_, err := sut.DeductPoints(context.Background(), &inputport.DeductPointsRequest{
-			AdminID: uuid.New(), UserID: target.ID, Amount: 100,
+			AdminID: uuid.New(), UserID: target.ID, Amount: 100, ReasonCode: "reward",
 			Description: "test", IdempotencyKey: "key",
 		})
 		assert.Error(t, err)
 	})
+
+	t.Run("ドライランでは消費予定のバッチを返すが冪等性キーを保存しない", func(t *testing.T) {
+		_, _, _, idempRepo, sut, admin, target := setup()
+		resp, err := sut.DeductPoints(context.Background(), &inputport.DeductPointsRequest{
+			AdminID: admin.ID, UserID: target.ID, Amount: 500, ReasonCode: "reward",
+			Description: "dry run", IdempotencyKey: "dry-deduct-" + uuid.New().String(), DryRun: true,
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.DryRun)
+		assert.Equal(t, int64(9500), resp.User.Balance)
+		// モックのバッチは空なので全額がバッチ外の残高から引かれる
+		assert.Empty(t, resp.ConsumptionPlan)
+		assert.Equal(t, int64(500), resp.UncoveredAmount)
+		assert.Empty(t, idempRepo.keys)
+	})
+
+	t.Run("ドライランでも残高不足はエラー", func(t *testing.T) {
+		_, _, _, _, sut, admin, target := setup()
+		_, err := sut.DeductPoints(context.Background(), &inputport.DeductPointsRequest{
+			AdminID: admin.ID, UserID: target.ID, Amount: 99999, ReasonCode: "reward",
+			Description: "dry run", IdempotencyKey: "dry-deduct-fail-" + uuid.New().String(), DryRun: true,
+		})
+		assert.ErrorIs(t, err, entities.ErrInsufficientBalance)
+	})
+
+	t.Run("理由コード未指定ならエラー", func(t *testing.T) {
+		_, _, txRepo, _, sut, admin, target := setup()
+		_, err := sut.DeductPoints(context.Background(), &inputport.DeductPointsRequest{
+			AdminID: admin.ID, UserID: target.ID, Amount: 100,
+			Description: "test", IdempotencyKey: "deduct-noreason-" + uuid.New().String(),
+		})
+		assert.ErrorIs(t, err, entities.ErrReasonCodeRequired)
+		assert.Empty(t, txRepo.transactions)
+	})
+
+	t.Run("理由コードとタグが取引に記録される", func(t *testing.T) {
+		_, _, txRepo, _, sut, admin, target := setup()
+		_, err := sut.DeductPoints(context.Background(), &inputport.DeductPointsRequest{
+			AdminID: admin.ID, UserID: target.ID, Amount: 100, ReasonCode: "reward", Tag: "q3",
+			Description: "memo", IdempotencyKey: "deduct-memo-" + uuid.New().String(),
+		})
+		require.NoError(t, err)
+		require.Len(t, txRepo.transactions, 1)
+		assert.Equal(t, "reward", txRepo.transactions[0].ReasonCode)
+		assert.Equal(t, "q3", txRepo.transactions[0].Tag)
+	})
 }
 
 // --- ListAllUsers ---
@@ -566,7 +801,7 @@ func TestAdminInteractor_ListAllUsers(t *testing.T) {
 		i := interactor.NewAdminInteractor(
 			&ctxTrackingTxManager{}, userRepo, newCtxTrackingTransactionRepo(),
 			newCtxTrackingIdempotencyRepo(), newCtxTrackingPointBatchRepo(),
-			&mockAnalyticsDS{}, &mockLogger{},
+			newMockReasonCodeRepo(), newMockDepartmentRepo(), newMockBudgetRepo(), newMockPendingAdminActionRepo(), newMockSystemSettingsRepo(), &mockAnalyticsDS{}, &mockNotificationDispatcher{}, &mockLogger{},
 		)
 		return i, userRepo
 	}
@@ -604,7 +839,7 @@ func TestAdminInteractor_ListAllTransactions(t *testing.T) {
 		i := interactor.NewAdminInteractor(
 			&ctxTrackingTxManager{}, userRepo, txRepo,
 			newCtxTrackingIdempotencyRepo(), newCtxTrackingPointBatchRepo(),
-			&mockAnalyticsDS{}, &mockLogger{},
+			newMockReasonCodeRepo(), newMockDepartmentRepo(), newMockBudgetRepo(), newMockPendingAdminActionRepo(), newMockSystemSettingsRepo(), &mockAnalyticsDS{}, &mockNotificationDispatcher{}, &mockLogger{},
 		)
 		return i
 	}
@@ -641,7 +876,7 @@ func TestAdminInteractor_UpdateUserRole(t *testing.T) {
 		i := interactor.NewAdminInteractor(
 			&ctxTrackingTxManager{}, userRepo, newCtxTrackingTransactionRepo(),
 			newCtxTrackingIdempotencyRepo(), newCtxTrackingPointBatchRepo(),
-			&mockAnalyticsDS{}, &mockLogger{},
+			newMockReasonCodeRepo(), newMockDepartmentRepo(), newMockBudgetRepo(), newMockPendingAdminActionRepo(), newMockSystemSettingsRepo(), &mockAnalyticsDS{}, &mockNotificationDispatcher{}, &mockLogger{},
 		)
 		return i, admin, target
 	}
@@ -686,7 +921,7 @@ func TestAdminInteractor_DeactivateUser(t *testing.T) {
 		i := interactor.NewAdminInteractor(
 			&ctxTrackingTxManager{}, userRepo, newCtxTrackingTransactionRepo(),
 			newCtxTrackingIdempotencyRepo(), newCtxTrackingPointBatchRepo(),
-			&mockAnalyticsDS{}, &mockLogger{},
+			newMockReasonCodeRepo(), newMockDepartmentRepo(), newMockBudgetRepo(), newMockPendingAdminActionRepo(), newMockSystemSettingsRepo(), &mockAnalyticsDS{}, &mockNotificationDispatcher{}, &mockLogger{},
 		)
 		return i, admin, target
 	}
@@ -725,7 +960,7 @@ func TestAdminInteractor_GetAnalytics(t *testing.T) {
 		sut := interactor.NewAdminInteractor(
 			&ctxTrackingTxManager{}, newCtxTrackingUserRepo(), newCtxTrackingTransactionRepo(),
 			newCtxTrackingIdempotencyRepo(), newCtxTrackingPointBatchRepo(),
-			&mockAnalyticsDS{}, &mockLogger{},
+			newMockReasonCodeRepo(), newMockDepartmentRepo(), newMockBudgetRepo(), newMockPendingAdminActionRepo(), newMockSystemSettingsRepo(), &mockAnalyticsDS{}, &mockNotificationDispatcher{}, &mockLogger{},
 		)
 
 		resp, err := sut.GetAnalytics(context.Background(), &inputport.GetAnalyticsRequest{
@@ -738,5 +973,194 @@ func TestAdminInteractor_GetAnalytics(t *testing.T) {
 		assert.NotEmpty(t, resp.TopHolders)
 		assert.NotEmpty(t, resp.DailyStats)
 		assert.NotEmpty(t, resp.TransactionTypeBreakdown)
+		require.Len(t, resp.ReasonCodeBreakdown, 1)
+		assert.Equal(t, "reward", resp.ReasonCodeBreakdown[0].ReasonCode)
+	})
+
+	t.Run("理由コードで絞り込める", func(t *testing.T) {
+		ds := &mockAnalyticsDS{}
+		sut := interactor.NewAdminInteractor(
+			&ctxTrackingTxManager{}, newCtxTrackingUserRepo(), newCtxTrackingTransactionRepo(),
+			newCtxTrackingIdempotencyRepo(), newCtxTrackingPointBatchRepo(),
+			newMockReasonCodeRepo(), newMockDepartmentRepo(), newMockBudgetRepo(), newMockPendingAdminActionRepo(), newMockSystemSettingsRepo(), ds, &mockNotificationDispatcher{}, &mockLogger{},
+		)
+
+		_, err := sut.GetAnalytics(context.Background(), &inputport.GetAnalyticsRequest{
+			Days: 7, ReasonCode: " Reward ",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "reward", ds.reasonCode)
+	})
+}
+
+// --- GetCohortAnalytics ---
+
+func TestAdminInteractor_GetCohortAnalytics(t *testing.T) {
+	admin := createTestUserWithBalance(t, "admin", 0, "admin")
+	member := createTestUserWithBalance(t, "member", 0, "user")
+	setup := func(ds *mockAnalyticsDS) inputport.AdminInputPort {
+		userRepo := newCtxTrackingUserRepo()
+		userRepo.setUser(admin)
+		userRepo.setUser(member)
+		return interactor.NewAdminInteractor(
+			&ctxTrackingTxManager{}, userRepo, newCtxTrackingTransactionRepo(),
+			newCtxTrackingIdempotencyRepo(), newCtxTrackingPointBatchRepo(),
+			newMockReasonCodeRepo(), newMockDepartmentRepo(), newMockBudgetRepo(), newMockPendingAdminActionRepo(), newMockSystemSettingsRepo(), ds, &mockNotificationDispatcher{}, &mockLogger{},
+		)
+	}
+	week1 := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC) // 月曜
+	week2 := week1.AddDate(0, 0, 7)
+
+	t.Run("コホートごとの継続率を経過期間ごとに計算する", func(t *testing.T) {
+		ds := &mockAnalyticsDS{
+			cohortSizes: []*entities.CohortSizeResult{
+				{CohortStart: week1, Users: 4},
+				{CohortStart: week2, Users: 2},
+			},
+			cohortActivity: []*entities.CohortActivityResult{
+				{CohortStart: week1, PeriodStart: week1, ActiveUsers: 4},
+				{CohortStart: week1, PeriodStart: week2.AddDate(0, 0, 7), ActiveUsers: 1},
+				{CohortStart: week2, PeriodStart: week2, ActiveUsers: 1},
+			},
+		}
+		sut := setup(ds)
+
+		resp, err := sut.GetCohortAnalytics(context.Background(), &inputport.GetCohortAnalyticsRequest{
+			AdminID: admin.ID,
+			From:    week1, To: week1.AddDate(0, 0, 21),
+			Granularity: entities.AnalyticsGranularityWeek, Basis: entities.ActivityBasisLogins,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entities.ActivityBasisLogins, ds.basis)
+		require.Len(t, resp.Cohorts, 2)
+
+		first := resp.Cohorts[0]
+		assert.Equal(t, int64(4), first.Size)
+		require.Len(t, first.Retention, 3, "登録週から集計終了まで3週分")
+		assert.Equal(t, 100.0, first.Retention[0].Percentage)
+		assert.Equal(t, int64(0), first.Retention[1].ActiveUsers, "アクティブがない週は0で埋める")
+		assert.Equal(t, 25.0, first.Retention[2].Percentage)
+
+		second := resp.Cohorts[1]
+		require.Len(t, second.Retention, 2)
+		assert.Equal(t, 50.0, second.Retention[0].Percentage)
+	})
+
+	t.Run("機能別利用回数の割合を計算する", func(t *testing.T) {
+		sut := setup(&mockAnalyticsDS{
+			featureUsage: []*entities.FeatureUsageResult{
+				{Feature: entities.FeatureTransfer, Count: 6, Users: 3, TotalAmount: 600},
+				{Feature: entities.FeatureQRCode, Count: 2, Users: 2, TotalAmount: 200},
+				{Feature: entities.FeatureTransferRequest, Count: 0},
+				{Feature: entities.FeatureExchange, Count: 2, Users: 1, TotalAmount: 1000},
+			},
+		})
+
+		resp, err := sut.GetCohortAnalytics(context.Background(), &inputport.GetCohortAnalyticsRequest{
+			AdminID: admin.ID,
+			From:    week1, To: week2,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.FeatureUsage, 4)
+		assert.Equal(t, 60.0, resp.FeatureUsage[0].Percentage)
+		assert.Equal(t, 0.0, resp.FeatureUsage[2].Percentage)
+		assert.Equal(t, 20.0, resp.FeatureUsage[3].Percentage)
+	})
+
+	t.Run("未指定の場合は週単位・取引基準で直近12週を集計する", func(t *testing.T) {
+		ds := &mockAnalyticsDS{}
+		sut := setup(ds)
+
+		resp, err := sut.GetCohortAnalytics(context.Background(), &inputport.GetCohortAnalyticsRequest{AdminID: admin.ID})
+		require.NoError(t, err)
+		assert.Equal(t, entities.AnalyticsGranularityWeek, resp.Granularity)
+		assert.Equal(t, entities.ActivityBasisTransactions, ds.basis)
+		assert.Equal(t, time.Monday, ds.from.Weekday())
+		assert.Equal(t, 11, entities.AnalyticsGranularityWeek.PeriodsBetween(ds.from, ds.to.Add(-time.Nanosecond)))
+		assert.NotEmpty(t, resp.ActiveUsers)
+	})
+
+	t.Run("開始が終了以降ならエラー", func(t *testing.T) {
+		sut := setup(&mockAnalyticsDS{})
+		_, err := sut.GetCohortAnalytics(context.Background(), &inputport.GetCohortAnalyticsRequest{
+			AdminID: admin.ID,
+			From:    week2, To: week1,
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidDateRange)
+	})
+
+	t.Run("期間が2年を超えるとエラー", func(t *testing.T) {
+		sut := setup(&mockAnalyticsDS{})
+		_, err := sut.GetCohortAnalytics(context.Background(), &inputport.GetCohortAnalyticsRequest{
+			AdminID: admin.ID,
+			From:    week1, To: week1.AddDate(2, 0, 1),
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidDateRange)
+	})
+
+	t.Run("管理者以外はエラー", func(t *testing.T) {
+		sut := setup(&mockAnalyticsDS{})
+		_, err := sut.GetCohortAnalytics(context.Background(), &inputport.GetCohortAnalyticsRequest{AdminID: member.ID})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
+
+// --- GetForecast ---
+
+func TestAdminInteractor_GetForecast(t *testing.T) {
+	admin := createTestUserWithBalance(t, "admin", 0, "admin")
+	member := createTestUserWithBalance(t, "member", 0, "user")
+	setup := func(ds *mockAnalyticsDS) inputport.AdminInputPort {
+		userRepo := newCtxTrackingUserRepo()
+		userRepo.setUser(admin)
+		userRepo.setUser(member)
+		return interactor.NewAdminInteractor(
+			&ctxTrackingTxManager{}, userRepo, newCtxTrackingTransactionRepo(),
+			newCtxTrackingIdempotencyRepo(), newCtxTrackingPointBatchRepo(),
+			newMockReasonCodeRepo(), newMockDepartmentRepo(), newMockBudgetRepo(), newMockPendingAdminActionRepo(), newMockSystemSettingsRepo(), ds, &mockNotificationDispatcher{}, &mockLogger{},
+		)
+	}
+
+	t.Run("失効予定を週ごとにゼロ埋めして合計と割合を計算する", func(t *testing.T) {
+		ds := &mockAnalyticsDS{
+			expiryForecast: []*entities.ExpiryForecastResult{
+				{WeekIndex: 0, Amount: 300, Users: 2, Batches: 3},
+				{WeekIndex: 2, Amount: 200, Users: 1, Batches: 1},
+			},
+			outstanding: 2000,
+			velocity:    &entities.CirculationVelocityResult{SampleCount: 10, MedianSeconds: 2 * 24 * 3600},
+		}
+		sut := setup(ds)
+
+		resp, err := sut.GetForecast(context.Background(), &inputport.GetForecastRequest{AdminID: admin.ID, Weeks: 4, Days: 30})
+		require.NoError(t, err)
+		require.Len(t, resp.ExpiryForecast, 4)
+		assert.Equal(t, int64(300), resp.ExpiryForecast[0].Amount)
+		assert.Equal(t, int64(0), resp.ExpiryForecast[1].Amount)
+		assert.Equal(t, int64(200), resp.ExpiryForecast[2].Amount)
+		assert.Equal(t, resp.ExpiryForecast[0].WeekEnd, resp.ExpiryForecast[1].WeekStart)
+		assert.Equal(t, int64(500), resp.TotalExpiring)
+		assert.Equal(t, 25.0, resp.ExpiringPercentage)
+		assert.Equal(t, 48.0, resp.Velocity.MedianHours)
+		assert.Equal(t, int64(10), resp.Velocity.SampleCount)
+		assert.Equal(t, ds.forecastFrom.AddDate(0, 0, -30), ds.velocitySince)
+	})
+
+	t.Run("範囲外の週数・日数はデフォルト値になる", func(t *testing.T) {
+		ds := &mockAnalyticsDS{}
+		sut := setup(ds)
+
+		resp, err := sut.GetForecast(context.Background(), &inputport.GetForecastRequest{AdminID: admin.ID, Weeks: 100, Days: 12})
+		require.NoError(t, err)
+		assert.Equal(t, 8, ds.forecastWeeks)
+		assert.Len(t, resp.ExpiryForecast, 8)
+		assert.Equal(t, ds.forecastFrom.AddDate(0, 0, -90), ds.velocitySince)
+		assert.Equal(t, 0.0, resp.ExpiringPercentage, "残ポイントが0なら割合は0")
+	})
+
+	t.Run("管理者以外はエラー", func(t *testing.T) {
+		sut := setup(&mockAnalyticsDS{})
+		_, err := sut.GetForecast(context.Background(), &inputport.GetForecastRequest{AdminID: member.ID})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
 	})
 }
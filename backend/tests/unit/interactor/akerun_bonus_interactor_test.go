@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
 	"github.com/gity/point-system/usecases/interactor"
 	"github.com/gity/point-system/usecases/repository"
 	"github.com/google/uuid"
@@ -21,19 +23,23 @@ import (
 // abMockDailyBonusRepo は DailyBonusRepository のモック（akerun bonus用）
 type abMockDailyBonusRepo struct {
 	bonuses      map[string]*entities.DailyBonus // key: "userID-bonusDate"
-	lastPolledAt time.Time
+	lastPolledAt map[string]time.Time            // key: 組織ID
 	created      []*entities.DailyBonus
+	createErr    error // 設定するとCreateが失敗する（DB障害の再現）
 }
 
 func newABMockDailyBonusRepo() *abMockDailyBonusRepo {
 	return &abMockDailyBonusRepo{
 		bonuses:      make(map[string]*entities.DailyBonus),
-		lastPolledAt: time.Now().Add(-1 * time.Hour),
+		lastPolledAt: make(map[string]time.Time),
 		created:      make([]*entities.DailyBonus, 0),
 	}
 }
 
 func (m *abMockDailyBonusRepo) Create(ctx context.Context, bonus *entities.DailyBonus) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
 	key := fmt.Sprintf("%s-%s", bonus.UserID.String(), bonus.BonusDate.Format("2006-01-02"))
 	if _, exists := m.bonuses[key]; exists {
 		return fmt.Errorf("duplicate bonus for user %s on %s", bonus.UserID, bonus.BonusDate.Format("2006-01-02"))
@@ -71,12 +77,15 @@ func (m *abMockDailyBonusRepo) CountByUser(ctx context.Context, userID uuid.UUID
 	return count, nil
 }
 
-func (m *abMockDailyBonusRepo) GetLastPolledAt(ctx context.Context) (time.Time, error) {
-	return m.lastPolledAt, nil
+func (m *abMockDailyBonusRepo) GetLastPolledAt(ctx context.Context, organizationID string) (time.Time, error) {
+	if t, ok := m.lastPolledAt[organizationID]; ok {
+		return t, nil
+	}
+	return time.Now().Add(-1 * time.Hour), nil
 }
 
-func (m *abMockDailyBonusRepo) UpdateLastPolledAt(ctx context.Context, t time.Time) error {
-	m.lastPolledAt = t
+func (m *abMockDailyBonusRepo) UpdateLastPolledAt(ctx context.Context, organizationID string, t time.Time) error {
+	m.lastPolledAt[organizationID] = t
 	return nil
 }
 
@@ -88,6 +97,10 @@ func (m *abMockDailyBonusRepo) UpdateDrawnResult(ctx context.Context, id uuid.UU
 	return nil
 }
 
+func (m *abMockDailyBonusRepo) StreamByPeriod(ctx context.Context, period entities.BonusReportPeriod, fn func(*entities.DailyBonusRecord) error) error {
+	return nil
+}
+
 // abMockLotteryTierRepo は LotteryTierRepository のモック
 type abMockLotteryTierRepo struct {
 	tiers []*entities.LotteryTier
@@ -170,10 +183,10 @@ func (m *abMockUserRepo) ReadList(ctx context.Context, offset, limit int) ([]*en
 func (m *abMockUserRepo) Count(ctx context.Context) (int64, error) {
 	return int64(len(m.users)), nil
 }
-func (m *abMockUserRepo) ReadListWithSearch(ctx context.Context, search, sortBy, sortOrder string, offset, limit int) ([]*entities.User, error) {
+func (m *abMockUserRepo) ReadListWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID, sortBy, sortOrder string, offset, limit int) ([]*entities.User, error) {
 	return nil, nil
 }
-func (m *abMockUserRepo) CountWithSearch(ctx context.Context, search string) (int64, error) {
+func (m *abMockUserRepo) CountWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID) (int64, error) {
 	return 0, nil
 }
 func (m *abMockUserRepo) UpdateBalanceWithLock(ctx context.Context, userID uuid.UUID, amount int64, isDeduct bool) error {
@@ -192,6 +205,9 @@ func (m *abMockUserRepo) UpdateBalancesWithLock(ctx context.Context, updates []r
 	}
 	return nil
 }
+func (m *abMockUserRepo) UpdateReservedBalanceWithLock(ctx context.Context, userID uuid.UUID, amount int64, isRelease bool) error {
+	return nil
+}
 func (m *abMockUserRepo) ReadPersonalQRCode(ctx context.Context, userID uuid.UUID) (*entities.QRCode, error) {
 	return nil, nil
 }
@@ -230,24 +246,36 @@ func (m *abMockTransactionRepo) ReadListAll(ctx context.Context, offset, limit i
 func (m *abMockTransactionRepo) Update(ctx context.Context, tx *entities.Transaction) error {
 	return nil
 }
-func (m *abMockTransactionRepo) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+func (m *abMockTransactionRepo) CountByUserID(ctx context.Context, userID uuid.UUID, reasonCode string) (int64, error) {
 	return 0, nil
 }
-func (m *abMockTransactionRepo) ReadListAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, sortBy, sortOrder string, offset, limit int) ([]*entities.Transaction, error) {
+func (m *abMockTransactionRepo) ReadListAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode, sortBy, sortOrder string, offset, limit int) ([]*entities.Transaction, error) {
 	return nil, nil
 }
 func (m *abMockTransactionRepo) CountAll(ctx context.Context) (int64, error) {
 	return 0, nil
 }
-func (m *abMockTransactionRepo) CountAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo string) (int64, error) {
+func (m *abMockTransactionRepo) CountAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode string, departmentIDs []uuid.UUID) (int64, error) {
 	return 0, nil
 }
 
-func (m *abMockTransactionRepo) ReadListByUserIDWithUsers(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.TransactionWithUsers, error) {
+func (m *abMockTransactionRepo) ReadListByUserIDWithUsers(ctx context.Context, userID uuid.UUID, reasonCode string, offset, limit int) ([]*entities.TransactionWithUsers, error) {
 	return nil, nil
 }
 
-func (m *abMockTransactionRepo) ReadListAllWithFilterAndUsers(ctx context.Context, transactionType, dateFrom, dateTo, sortBy, sortOrder string, offset, limit int) ([]*entities.TransactionWithUsers, error) {
+func (m *abMockTransactionRepo) ReadListAllWithFilterAndUsers(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode string, departmentIDs []uuid.UUID, sortBy, sortOrder string, offset, limit int) ([]*entities.TransactionWithUsers, error) {
+	return nil, nil
+}
+
+func (m *abMockTransactionRepo) AnonymizeUserReferences(ctx context.Context, userID uuid.UUID, scrubMemos bool) error {
+	return nil
+}
+
+func (m *abMockTransactionRepo) SumExchangedWith(ctx context.Context, userID uuid.UUID, counterpartIDs []uuid.UUID) ([]*entities.PointsExchanged, error) {
+	return nil, nil
+}
+
+func (m *abMockTransactionRepo) ReadTransferAmountUsage(ctx context.Context, fromUserID uuid.UUID, toUserIDs []uuid.UUID, since time.Time) ([]*entities.TransferAmountUsage, error) {
 	return nil, nil
 }
 
@@ -300,6 +328,39 @@ func (m *abMockPointBatchRepo) MarkExpired(ctx context.Context, batchID uuid.UUI
 func (m *abMockPointBatchRepo) FindUpcomingExpirations(ctx context.Context, userID uuid.UUID) ([]*entities.PointBatch, error) {
 	return nil, nil
 }
+func (m *abMockPointBatchRepo) Read(ctx context.Context, id uuid.UUID) (*entities.PointBatch, error) {
+	return nil, entities.ErrPointBatchNotFound
+}
+func (m *abMockPointBatchRepo) UpdateExpiresAt(ctx context.Context, id uuid.UUID, expiresAt time.Time) error {
+	return nil
+}
+func (m *abMockPointBatchRepo) FindActiveByUser(ctx context.Context, userID uuid.UUID) ([]*entities.PointBatch, error) {
+	return nil, nil
+}
+func (m *abMockPointBatchRepo) MarkRestored(ctx context.Context, id uuid.UUID, restoredAt time.Time) error {
+	return nil
+}
+func (m *abMockPointBatchRepo) FindRestorableBatches(ctx context.Context, expiredAfter time.Time, userID *uuid.UUID, limit int) ([]*entities.PointBatch, error) {
+	return nil, nil
+}
+func (m *abMockPointBatchRepo) FindByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.PointBatch, error) {
+	return nil, nil
+}
+func (m *abMockPointBatchRepo) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return 0, nil
+}
+func (m *abMockPointBatchRepo) AdjustExpiresAt(ctx context.Context, id uuid.UUID, expiresAt, adjustedAt time.Time) error {
+	return nil
+}
+func (m *abMockPointBatchRepo) Cancel(ctx context.Context, id uuid.UUID, remaining int64, cancelledAt time.Time) error {
+	return nil
+}
+func (m *abMockPointBatchRepo) CreateAdjustment(ctx context.Context, adjustment *entities.PointBatchAdjustment) error {
+	return nil
+}
+func (m *abMockPointBatchRepo) FindAdjustments(ctx context.Context, batchIDs []uuid.UUID) ([]*entities.PointBatchAdjustment, error) {
+	return nil, nil
+}
 
 // abMockLogger はテスト用ログ
 type abMockLogger struct {
@@ -317,6 +378,81 @@ func (m *abMockLogger) Warn(msg string, fields ...entities.Field)  {}
 func (m *abMockLogger) Error(msg string, fields ...entities.Field) { m.errors = append(m.errors, msg) }
 func (m *abMockLogger) Fatal(msg string, fields ...entities.Field) {}
 
+// abMockFailedAccessRepo は FailedAkerunAccessRepository のモック
+type abMockFailedAccessRepo struct {
+	accesses map[uuid.UUID]*entities.FailedAkerunAccess
+}
+
+func newABMockFailedAccessRepo() *abMockFailedAccessRepo {
+	return &abMockFailedAccessRepo{accesses: make(map[uuid.UUID]*entities.FailedAkerunAccess)}
+}
+
+func (m *abMockFailedAccessRepo) Enqueue(ctx context.Context, access *entities.FailedAkerunAccess) error {
+	for _, existing := range m.accesses {
+		if existing.AccessID == access.AccessID {
+			return nil
+		}
+	}
+	m.accesses[access.ID] = access
+	return nil
+}
+
+func (m *abMockFailedAccessRepo) Read(ctx context.Context, id uuid.UUID) (*entities.FailedAkerunAccess, error) {
+	access, ok := m.accesses[id]
+	if !ok {
+		return nil, entities.ErrFailedAccessNotFound
+	}
+	copied := *access
+	return &copied, nil
+}
+
+func (m *abMockFailedAccessRepo) ReadDue(ctx context.Context, now time.Time, limit int) ([]*entities.FailedAkerunAccess, error) {
+	var due []*entities.FailedAkerunAccess
+	for _, access := range m.accesses {
+		if access.Status == entities.FailedAkerunAccessPending && !access.NextRetryAt.After(now) {
+			copied := *access
+			due = append(due, &copied)
+		}
+	}
+	return due, nil
+}
+
+func (m *abMockFailedAccessRepo) ReadList(ctx context.Context, status entities.FailedAkerunAccessStatus, offset, limit int) ([]*entities.FailedAkerunAccess, error) {
+	var list []*entities.FailedAkerunAccess
+	for _, access := range m.accesses {
+		if status == "" || access.Status == status {
+			list = append(list, access)
+		}
+	}
+	return list, nil
+}
+
+func (m *abMockFailedAccessRepo) Count(ctx context.Context, status entities.FailedAkerunAccessStatus) (int64, error) {
+	list, _ := m.ReadList(ctx, status, 0, 0)
+	return int64(len(list)), nil
+}
+
+func (m *abMockFailedAccessRepo) Update(ctx context.Context, access *entities.FailedAkerunAccess) error {
+	copied := *access
+	m.accesses[access.ID] = &copied
+	return nil
+}
+
+func (m *abMockFailedAccessRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(m.accesses, id)
+	return nil
+}
+
+// only は登録されている唯一の失敗アクセス記録を返す
+func (m *abMockFailedAccessRepo) only(t *testing.T) *entities.FailedAkerunAccess {
+	t.Helper()
+	require.Len(t, m.accesses, 1)
+	for _, access := range m.accesses {
+		return access
+	}
+	return nil
+}
+
 // ========================================
 // ヘルパー: DailyBonusInteractor作成（ProcessAccesses テスト用）
 // ========================================
@@ -327,6 +463,9 @@ type dailyBonusProcessTestDeps struct {
 	transactionRepo    *abMockTransactionRepo
 	systemSettingsRepo *abMockSystemSettingsRepo
 	lotteryTierRepo    *abMockLotteryTierRepo
+	failedAccessRepo   *abMockFailedAccessRepo
+	processedRepo      *testsupport.ProcessedAkerunAccessRepository
+	organizations      entities.AkerunOrganizations
 	logger             *abMockLogger
 }
 
@@ -337,6 +476,9 @@ func createDailyBonusInteractorForProcess() (*interactor.DailyBonusInteractor, *
 		transactionRepo:    newABMockTransactionRepo(),
 		systemSettingsRepo: newABMockSystemSettingsRepo(),
 		lotteryTierRepo:    newABMockLotteryTierRepo(),
+		failedAccessRepo:   newABMockFailedAccessRepo(),
+		processedRepo:      testsupport.NewProcessedAkerunAccessRepository(),
+		organizations:      entities.AkerunOrganizations{},
 		logger:             newABMockLogger(),
 	}
 
@@ -348,6 +490,10 @@ func createDailyBonusInteractorForProcess() (*interactor.DailyBonusInteractor, *
 		deps.systemSettingsRepo,
 		&abMockPointBatchRepo{},
 		deps.lotteryTierRepo,
+		deps.failedAccessRepo,
+		deps.processedRepo,
+		deps.organizations,
+		&mockReferralTracker{},
 		deps.logger,
 	)
 
@@ -529,6 +675,192 @@ func TestDailyBonusInteractor_ProcessAccesses(t *testing.T) {
 		assert.Len(t, deps.dailyBonusRepo.created, 1, "同一ユーザー・同一日は1件のみ")
 		assert.Equal(t, int64(100), deps.userRepo.users[userID].Balance, "Phase 1では残高変わらず")
 	})
+
+	t.Run("処理済みのアクセス記録はボーナスの有無によらず二度処理しない", func(t *testing.T) {
+		i, deps := createDailyBonusInteractorForProcess()
+		deps.userRepo.addUser(&entities.User{
+			ID: uuid.New(), Username: "photosynth_taro",
+			LastName: "Photosynth", FirstName: "太郎",
+			Balance: 100, IsActive: true, Role: entities.RoleUser,
+		})
+		matched := entities.AccessRecord{ID: uuid.New(), UserName: "Photosynth太郎", AccessedAt: time.Date(2017, 7, 24, 6, 37, 19, 0, time.UTC)}
+		unmatched := entities.AccessRecord{ID: uuid.New(), UserName: "知らない人", AccessedAt: time.Date(2017, 7, 24, 6, 40, 0, 0, time.UTC)}
+
+		require.NoError(t, i.ProcessAccesses(context.Background(), []entities.AccessRecord{matched, unmatched}))
+		require.Len(t, deps.dailyBonusRepo.created, 1)
+		assert.Len(t, deps.processedRepo.Processed(), 2, "ボーナスを作成しなかった記録も処理済みにする")
+
+		// 1日1回の判定に頼らなくても、取得期間が重なって受け取った記録は処理しない
+		deps.dailyBonusRepo.bonuses = map[string]*entities.DailyBonus{}
+		require.NoError(t, i.ProcessAccesses(context.Background(), []entities.AccessRecord{matched, unmatched}))
+		assert.Len(t, deps.dailyBonusRepo.created, 1)
+	})
+
+	t.Run("処理済みかを確かめられなければすべて処理する", func(t *testing.T) {
+		i, deps := createDailyBonusInteractorForProcess()
+		deps.userRepo.addUser(&entities.User{
+			ID: uuid.New(), Username: "photosynth_taro",
+			LastName: "Photosynth", FirstName: "太郎",
+			Balance: 100, IsActive: true, Role: entities.RoleUser,
+		})
+		deps.processedRepo.FailOn("ReadProcessedIDs", fmt.Errorf("connection reset"))
+
+		access := entities.AccessRecord{ID: uuid.New(), UserName: "Photosynth太郎", AccessedAt: time.Date(2017, 7, 24, 6, 37, 19, 0, time.UTC)}
+		require.NoError(t, i.ProcessAccesses(context.Background(), []entities.AccessRecord{access}))
+		assert.Len(t, deps.dailyBonusRepo.created, 1)
+	})
+}
+
+// ========================================
+// テストケース: 付与に失敗したアクセス記録の再試行キュー
+// ========================================
+
+func TestDailyBonusInteractor_FailedAccessQueue(t *testing.T) {
+	adminID := uuid.New()
+	setup := func() (*interactor.DailyBonusInteractor, *dailyBonusProcessTestDeps, uuid.UUID, entities.AccessRecord) {
+		i, deps := createDailyBonusInteractorForProcess()
+		deps.userRepo.addUser(&entities.User{ID: adminID, Username: "admin", IsActive: true, Role: entities.RoleAdmin})
+		userID := uuid.New()
+		deps.userRepo.addUser(&entities.User{
+			ID: userID, Username: "photosynth_taro",
+			LastName: "Photosynth", FirstName: "太郎",
+			Balance: 100, IsActive: true, Role: entities.RoleUser,
+		})
+		access := entities.AccessRecord{
+			ID:         uuid.New(),
+			UserName:   "Photosynth太郎",
+			AccessedAt: time.Date(2017, 7, 24, 6, 37, 19, 0, time.UTC),
+		}
+		return i, deps, userID, access
+	}
+
+	t.Run("ボーナスを作成できなかったアクセス記録は再試行キューに登録される", func(t *testing.T) {
+		i, deps, _, access := setup()
+		deps.dailyBonusRepo.createErr = fmt.Errorf("connection reset")
+
+		err := i.ProcessAccesses(context.Background(), []entities.AccessRecord{access})
+		require.NoError(t, err)
+
+		failed := deps.failedAccessRepo.only(t)
+		assert.Equal(t, access.ID, failed.AccessID)
+		assert.Equal(t, entities.FailedAkerunAccessPending, failed.Status)
+		assert.Equal(t, 1, failed.Attempts)
+		assert.Contains(t, failed.LastError, "connection reset")
+
+		// ポーリングが重なって同じアクセス記録が再び失敗しても1件のまま
+		err = i.ProcessAccesses(context.Background(), []entities.AccessRecord{access})
+		require.NoError(t, err)
+		assert.Len(t, deps.failedAccessRepo.accesses, 1)
+	})
+
+	t.Run("再試行で作成できればキューから削除される", func(t *testing.T) {
+		i, deps, userID, access := setup()
+		deps.dailyBonusRepo.createErr = fmt.Errorf("connection reset")
+		require.NoError(t, i.ProcessAccesses(context.Background(), []entities.AccessRecord{access}))
+		failed := deps.failedAccessRepo.only(t)
+
+		// 再試行時刻前は何もしない
+		deps.dailyBonusRepo.createErr = nil
+		require.NoError(t, i.RetryFailedAccesses(context.Background(), failed.NextRetryAt.Add(-time.Second)))
+		assert.Len(t, deps.dailyBonusRepo.created, 0)
+
+		require.NoError(t, i.RetryFailedAccesses(context.Background(), failed.NextRetryAt))
+		require.Len(t, deps.dailyBonusRepo.created, 1)
+		assert.Equal(t, userID, deps.dailyBonusRepo.created[0].UserID)
+		assert.Equal(t, access.ID.String(), deps.dailyBonusRepo.created[0].AkerunAccessID)
+		assert.Empty(t, deps.failedAccessRepo.accesses)
+	})
+
+	t.Run("作成済みのボーナスは再試行で作り直さない", func(t *testing.T) {
+		i, deps, userID, access := setup()
+		deps.dailyBonusRepo.createErr = fmt.Errorf("connection reset")
+		require.NoError(t, i.ProcessAccesses(context.Background(), []entities.AccessRecord{access}))
+		failed := deps.failedAccessRepo.only(t)
+
+		// 別のアクセス記録で同じ日のボーナスが作成済み
+		deps.dailyBonusRepo.createErr = nil
+		bonusDate := entities.GetBonusDate(access.AccessedAt, entities.DefaultBonusLocation())
+		deps.dailyBonusRepo.bonuses[fmt.Sprintf("%s-%s", userID.String(), bonusDate.Format("2006-01-02"))] =
+			entities.NewPendingDailyBonus(userID, bonusDate, "other", access.UserName, nil)
+
+		require.NoError(t, i.RetryFailedAccesses(context.Background(), failed.NextRetryAt))
+		assert.Len(t, deps.dailyBonusRepo.created, 0, "同じ日のボーナスは1件のまま")
+		assert.Empty(t, deps.failedAccessRepo.accesses)
+	})
+
+	t.Run("失敗のたびに待ち時間が倍になり、上限に達するとデッドレターに移る", func(t *testing.T) {
+		i, deps, _, access := setup()
+		deps.dailyBonusRepo.createErr = fmt.Errorf("connection reset")
+		require.NoError(t, i.ProcessAccesses(context.Background(), []entities.AccessRecord{access}))
+
+		for attempt := 2; attempt <= entities.FailedAkerunAccessMaxAttempts; attempt++ {
+			now := deps.failedAccessRepo.only(t).NextRetryAt
+			require.NoError(t, i.RetryFailedAccesses(context.Background(), now))
+
+			failed := deps.failedAccessRepo.only(t)
+			assert.Equal(t, attempt, failed.Attempts)
+			if attempt < entities.FailedAkerunAccessMaxAttempts {
+				assert.Equal(t, entities.FailedAkerunAccessPending, failed.Status)
+				assert.Equal(t, now.Add(entities.FailedAkerunAccessBackoff(attempt)), failed.NextRetryAt)
+			}
+		}
+
+		failed := deps.failedAccessRepo.only(t)
+		assert.Equal(t, entities.FailedAkerunAccessDead, failed.Status)
+
+		// デッドレターは自動では再試行しない
+		deps.dailyBonusRepo.createErr = nil
+		require.NoError(t, i.RetryFailedAccesses(context.Background(), failed.NextRetryAt.Add(24*time.Hour)))
+		assert.Len(t, deps.dailyBonusRepo.created, 0)
+
+		resp, err := i.ListFailedAccesses(context.Background(), &inputport.ListFailedAccessesRequest{
+			AdminID: adminID, Status: entities.FailedAkerunAccessDead, Limit: 50,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), resp.Total)
+
+		// 管理者が再投入すると次の再試行で処理される
+		requeued, err := i.RequeueFailedAccess(context.Background(), &inputport.RequeueFailedAccessRequest{
+			ID: failed.ID, AdminID: adminID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entities.FailedAkerunAccessPending, requeued.Status)
+		assert.Equal(t, 0, requeued.Attempts)
+
+		require.NoError(t, i.RetryFailedAccesses(context.Background(), time.Now()))
+		assert.Len(t, deps.dailyBonusRepo.created, 1)
+		assert.Empty(t, deps.failedAccessRepo.accesses)
+	})
+
+	t.Run("再試行中のアクセス記録は再投入できない", func(t *testing.T) {
+		i, deps, _, access := setup()
+		deps.dailyBonusRepo.createErr = fmt.Errorf("connection reset")
+		require.NoError(t, i.ProcessAccesses(context.Background(), []entities.AccessRecord{access}))
+
+		_, err := i.RequeueFailedAccess(context.Background(), &inputport.RequeueFailedAccessRequest{
+			ID: deps.failedAccessRepo.only(t).ID, AdminID: adminID,
+		})
+		assert.ErrorIs(t, err, entities.ErrFailedAccessNotDead)
+
+		_, err = i.RequeueFailedAccess(context.Background(), &inputport.RequeueFailedAccessRequest{
+			ID: uuid.New(), AdminID: adminID,
+		})
+		assert.ErrorIs(t, err, entities.ErrFailedAccessNotFound)
+	})
+
+	t.Run("管理者以外は一覧の取得も再投入もできない", func(t *testing.T) {
+		i, deps, userID, access := setup()
+		deps.dailyBonusRepo.createErr = fmt.Errorf("connection reset")
+		require.NoError(t, i.ProcessAccesses(context.Background(), []entities.AccessRecord{access}))
+
+		_, err := i.ListFailedAccesses(context.Background(), &inputport.ListFailedAccessesRequest{AdminID: userID, Limit: 50})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+
+		_, err = i.RequeueFailedAccess(context.Background(), &inputport.RequeueFailedAccessRequest{
+			ID: deps.failedAccessRepo.only(t).ID, AdminID: userID,
+		})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
 }
 
 // ========================================
@@ -640,19 +972,185 @@ func TestDailyBonusInteractor_PollingState(t *testing.T) {
 		i, deps := createDailyBonusInteractorForProcess()
 
 		expected := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
-		deps.dailyBonusRepo.lastPolledAt = expected
+		deps.dailyBonusRepo.lastPolledAt["org-main"] = expected
 
-		result, err := i.GetLastPolledAt(context.Background())
+		result, err := i.GetLastPolledAt(context.Background(), "org-main")
 		require.NoError(t, err)
 		assert.Equal(t, expected, result)
 	})
 
-	t.Run("UpdateLastPolledAtはリポジトリに委譲される", func(t *testing.T) {
+	t.Run("UpdateLastPolledAtは組織ごとにリポジトリに委譲される", func(t *testing.T) {
 		i, deps := createDailyBonusInteractorForProcess()
 
 		newTime := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
-		err := i.UpdateLastPolledAt(context.Background(), newTime)
+		err := i.UpdateLastPolledAt(context.Background(), "org-main", newTime)
+		require.NoError(t, err)
+		assert.Equal(t, newTime, deps.dailyBonusRepo.lastPolledAt["org-main"])
+		assert.NotContains(t, deps.dailyBonusRepo.lastPolledAt, "org-branch", "他の組織のカーソルは変わらない")
+	})
+}
+
+// ========================================
+// テストケース: ドアごとのボーナス規則
+// ========================================
+
+func TestDailyBonusInteractor_DoorRules(t *testing.T) {
+	newAccess := func(orgID, doorID, doorName string) entities.AccessRecord {
+		return entities.AccessRecord{
+			ID:             uuid.New(),
+			UserName:       "Photosynth太郎",
+			AccessedAt:     time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC),
+			OrganizationID: orgID,
+			DoorID:         doorID,
+			DoorName:       doorName,
+		}
+	}
+	addUser := func(deps *dailyBonusProcessTestDeps) {
+		deps.userRepo.addUser(&entities.User{
+			ID: uuid.New(), Username: "taro",
+			LastName: "Photosynth", FirstName: "太郎",
+			IsActive: true, Role: entities.RoleUser,
+		})
+	}
+
+	t.Run("対象に指定していないドアのアクセスではボーナスを作成しない", func(t *testing.T) {
+		i, deps := createDailyBonusInteractorForProcess()
+		deps.organizations["org-main"] = entities.AkerunOrganization{
+			ID:    "org-main",
+			Doors: []entities.AkerunDoorRule{{DoorID: "door-entrance", Name: "正面入口"}},
+		}
+		addUser(deps)
+
+		err := i.ProcessAccesses(context.Background(), []entities.AccessRecord{newAccess("org-main", "door-storage", "倉庫")})
+
+		require.NoError(t, err)
+		assert.Empty(t, deps.dailyBonusRepo.created)
+		assert.Empty(t, deps.failedAccessRepo.accesses, "対象外のドアは失敗扱いにしない")
+	})
+
+	t.Run("対象のドアならドアの情報と倍率を記録する", func(t *testing.T) {
+		i, deps := createDailyBonusInteractorForProcess()
+		deps.organizations["org-main"] = entities.AkerunOrganization{
+			ID:    "org-main",
+			Doors: []entities.AkerunDoorRule{{DoorID: "door-entrance", Name: "正面入口", Multiplier: 2}},
+		}
+		addUser(deps)
+
+		err := i.ProcessAccesses(context.Background(), []entities.AccessRecord{newAccess("org-main", "door-entrance", "正面入口")})
+
+		require.NoError(t, err)
+		require.Len(t, deps.dailyBonusRepo.created, 1)
+		bonus := deps.dailyBonusRepo.created[0]
+		assert.Equal(t, "org-main", bonus.AkerunOrganizationID)
+		assert.Equal(t, "door-entrance", bonus.AkerunDoorID)
+		assert.Equal(t, "正面入口", bonus.AkerunDoorName)
+		assert.Equal(t, 2.0, bonus.PointsMultiplier)
+	})
+
+	t.Run("ドアを指定していない組織はすべてのドアを1倍で対象にする", func(t *testing.T) {
+		i, deps := createDailyBonusInteractorForProcess()
+		deps.organizations["org-branch"] = entities.AkerunOrganization{ID: "org-branch"}
+		addUser(deps)
+
+		err := i.ProcessAccesses(context.Background(), []entities.AccessRecord{newAccess("org-branch", "door-any", "裏口")})
+
+		require.NoError(t, err)
+		require.Len(t, deps.dailyBonusRepo.created, 1)
+		assert.Equal(t, 1.0, deps.dailyBonusRepo.created[0].PointsMultiplier)
+	})
+}
+
+// ========================================
+// テストケース: ボーナス日の区切りに使うタイムゾーン
+// ========================================
+
+func TestDailyBonusInteractor_BonusTimezone(t *testing.T) {
+	// JSTでは4/11 AM7:00、ニューヨーク（EDT）では4/10 PM6:00
+	accessedAt := time.Date(2026, 4, 10, 22, 0, 0, 0, time.UTC)
+	addUser := func(deps *dailyBonusProcessTestDeps, timezone string) {
+		deps.userRepo.addUser(&entities.User{
+			ID: uuid.New(), Username: "taro",
+			LastName: "Photosynth", FirstName: "太郎",
+			IsActive: true, Role: entities.RoleUser,
+			Timezone: timezone,
+		})
+	}
+	access := func(at time.Time) entities.AccessRecord {
+		return entities.AccessRecord{ID: uuid.New(), UserName: "Photosynth太郎", AccessedAt: at}
+	}
+	bonusDay := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("既定ではAsia/TokyoのAM6:00で日付を区切る", func(t *testing.T) {
+		i, deps := createDailyBonusInteractorForProcess()
+		addUser(deps, "")
+
+		require.NoError(t, i.ProcessAccesses(context.Background(), []entities.AccessRecord{access(accessedAt)}))
+
+		require.Len(t, deps.dailyBonusRepo.created, 1)
+		assert.Equal(t, bonusDay(2026, 4, 11), deps.dailyBonusRepo.created[0].BonusDate)
+	})
+
+	t.Run("システム設定のタイムゾーンで日付を区切る", func(t *testing.T) {
+		i, deps := createDailyBonusInteractorForProcess()
+		deps.systemSettingsRepo.SetSetting(context.Background(), entities.BonusTimezoneSettingKey, "America/New_York", "テスト")
+		addUser(deps, "")
+
+		require.NoError(t, i.ProcessAccesses(context.Background(), []entities.AccessRecord{access(accessedAt)}))
+
+		require.Len(t, deps.dailyBonusRepo.created, 1)
+		assert.Equal(t, bonusDay(2026, 4, 10), deps.dailyBonusRepo.created[0].BonusDate)
+	})
+
+	t.Run("ユーザーのタイムゾーンはシステム設定より優先する", func(t *testing.T) {
+		i, deps := createDailyBonusInteractorForProcess()
+		addUser(deps, "America/New_York")
+
+		require.NoError(t, i.ProcessAccesses(context.Background(), []entities.AccessRecord{access(accessedAt)}))
+
+		require.Len(t, deps.dailyBonusRepo.created, 1)
+		assert.Equal(t, bonusDay(2026, 4, 10), deps.dailyBonusRepo.created[0].BonusDate)
+	})
+
+	t.Run("ユーザーのタイムゾーンで同じ日のアクセスは1回だけ", func(t *testing.T) {
+		i, deps := createDailyBonusInteractorForProcess()
+		addUser(deps, "America/New_York")
+
+		// ニューヨークでは4/10 PM6:00と4/11 AM5:00（どちらも4/10のボーナス日）。JSTでは別の日になる
+		require.NoError(t, i.ProcessAccesses(context.Background(), []entities.AccessRecord{
+			access(accessedAt),
+			access(time.Date(2026, 4, 11, 9, 0, 0, 0, time.UTC)),
+		}))
+
+		require.Len(t, deps.dailyBonusRepo.created, 1)
+	})
+
+	t.Run("システム設定が不正なら既定のタイムゾーンを使う", func(t *testing.T) {
+		i, deps := createDailyBonusInteractorForProcess()
+		deps.systemSettingsRepo.SetSetting(context.Background(), entities.BonusTimezoneSettingKey, "Mars/Olympus", "テスト")
+		addUser(deps, "")
+
+		require.NoError(t, i.ProcessAccesses(context.Background(), []entities.AccessRecord{access(accessedAt)}))
+
+		require.Len(t, deps.dailyBonusRepo.created, 1)
+		assert.Equal(t, bonusDay(2026, 4, 11), deps.dailyBonusRepo.created[0].BonusDate)
+	})
+
+	t.Run("UpdateBonusTimezoneはIANA名だけを保存する", func(t *testing.T) {
+		i, deps := createDailyBonusInteractorForProcess()
+
+		err := i.UpdateBonusTimezone(context.Background(), &inputport.UpdateBonusTimezoneRequest{Timezone: "Mars/Olympus"})
+		assert.ErrorIs(t, err, entities.ErrInvalidTimezone)
+		err = i.UpdateBonusTimezone(context.Background(), &inputport.UpdateBonusTimezoneRequest{Timezone: ""})
+		assert.ErrorIs(t, err, entities.ErrInvalidTimezone)
+		assert.NotContains(t, deps.systemSettingsRepo.settings, entities.BonusTimezoneSettingKey)
+
+		require.NoError(t, i.UpdateBonusTimezone(context.Background(), &inputport.UpdateBonusTimezoneRequest{Timezone: "Europe/London"}))
+		assert.Equal(t, "Europe/London", deps.systemSettingsRepo.settings[entities.BonusTimezoneSettingKey])
+
+		settings, err := i.GetBonusSettings(context.Background())
 		require.NoError(t, err)
-		assert.Equal(t, newTime, deps.dailyBonusRepo.lastPolledAt)
+		assert.Equal(t, "Europe/London", settings.Timezone)
 	})
 }
@@ -0,0 +1,157 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAkerunRepollInteractor(t *testing.T) {
+	ctx := context.Background()
+	admin := uuid.New()
+	member := uuid.New()
+	orgs := entities.AkerunOrganizations{"org-1": {ID: "org-1", Name: "本社"}}
+
+	setup := func() (*testsupport.AkerunRepollRepository, inputport.AkerunRepollInputPort) {
+		repo := testsupport.NewAkerunRepollRepository()
+		users := testsupport.NewUserRepository()
+		users.Seed(
+			&entities.User{ID: admin, Username: "admin", Role: entities.RoleAdmin, IsActive: true},
+			&entities.User{ID: member, Username: "member", Role: entities.RoleUser, IsActive: true},
+		)
+		return repo, interactor.NewAkerunRepollInteractor(repo, users, orgs, &mockLogger{})
+	}
+	request := func(sut inputport.AkerunRepollInputPort, orgID string) (*entities.AkerunRepoll, error) {
+		to := time.Now().Add(-time.Minute).Truncate(time.Minute)
+		return sut.RequestRepoll(ctx, &inputport.RequestAkerunRepollRequest{
+			OrganizationID: orgID,
+			From:           to.Add(-3 * time.Hour),
+			To:             to,
+			AdminID:        admin,
+		})
+	}
+
+	t.Run("依頼を処理待ちで保存する", func(t *testing.T) {
+		repo, sut := setup()
+		repoll, err := request(sut, "org-1")
+		require.NoError(t, err)
+		assert.Equal(t, entities.AkerunRepollStatusPending, repoll.Status)
+		assert.Equal(t, 3, repoll.TotalWindows)
+		assert.Equal(t, admin, repoll.RequestedBy)
+
+		stored, err := repo.Read(ctx, repoll.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "org-1", stored.OrganizationID)
+	})
+
+	t.Run("設定にない組織は受け付けない", func(t *testing.T) {
+		_, sut := setup()
+		_, err := request(sut, "unknown")
+		assert.ErrorIs(t, err, entities.ErrInvalidAkerunRepoll)
+	})
+
+	t.Run("処理待ち・取得中の依頼があれば受け付けない", func(t *testing.T) {
+		_, sut := setup()
+		_, err := request(sut, "")
+		require.NoError(t, err)
+
+		_, err = request(sut, "org-1")
+		assert.ErrorIs(t, err, entities.ErrAkerunRepollInProgress)
+	})
+
+	t.Run("ワーカーが取得中にして進み具合を記録し、完了すれば次の依頼を受け付ける", func(t *testing.T) {
+		repo, sut := setup()
+		requested, err := request(sut, "")
+		require.NoError(t, err)
+
+		repoll, err := sut.StartNextRepoll(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, repoll)
+		assert.Equal(t, requested.ID, repoll.ID)
+		assert.Equal(t, entities.AkerunRepollStatusRunning, repoll.Status)
+
+		require.NoError(t, sut.RecordRepollWindow(ctx, repoll, 4))
+		progress, err := sut.GetRepoll(ctx, admin, repoll.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, progress.CompletedWindows)
+		assert.Equal(t, 4, progress.FetchedAccesses)
+
+		// 取得中のまま止まった依頼は続きから再開する
+		resumed, err := sut.StartNextRepoll(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, resumed)
+		assert.Equal(t, 1, resumed.CompletedWindows)
+
+		require.NoError(t, sut.FinishRepoll(ctx, resumed, nil))
+		stored, _ := repo.Read(ctx, repoll.ID)
+		assert.Equal(t, entities.AkerunRepollStatusSucceeded, stored.Status)
+
+		next, err := sut.StartNextRepoll(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		_, err = request(sut, "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("失敗した依頼は理由を残す", func(t *testing.T) {
+		repo, sut := setup()
+		_, err := request(sut, "")
+		require.NoError(t, err)
+		repoll, err := sut.StartNextRepoll(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, sut.FinishRepoll(ctx, repoll, errors.New("token expired")))
+		stored, _ := repo.Read(ctx, repoll.ID)
+		assert.Equal(t, entities.AkerunRepollStatusFailed, stored.Status)
+		assert.Equal(t, "token expired", stored.ErrorMessage)
+		require.NotNil(t, stored.FinishedAt)
+	})
+
+	t.Run("一覧は新しい順で件数も返す", func(t *testing.T) {
+		_, sut := setup()
+		first, err := request(sut, "")
+		require.NoError(t, err)
+		repoll, _ := sut.StartNextRepoll(ctx)
+		require.NoError(t, sut.FinishRepoll(ctx, repoll, nil))
+		time.Sleep(time.Millisecond)
+		second, err := request(sut, "org-1")
+		require.NoError(t, err)
+
+		resp, err := sut.ListRepolls(ctx, &inputport.ListAkerunRepollsRequest{AdminID: admin, Limit: 20})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), resp.Total)
+		require.Len(t, resp.Repolls, 2)
+		assert.Equal(t, second.ID, resp.Repolls[0].ID)
+		assert.Equal(t, first.ID, resp.Repolls[1].ID)
+	})
+
+	t.Run("存在しない依頼", func(t *testing.T) {
+		_, sut := setup()
+		_, err := sut.GetRepoll(ctx, admin, uuid.New())
+		assert.ErrorIs(t, err, entities.ErrAkerunRepollNotFound)
+	})
+
+	t.Run("管理者以外は依頼も一覧・進み具合の取得もできない", func(t *testing.T) {
+		_, sut := setup()
+		repoll, err := request(sut, "")
+		require.NoError(t, err)
+
+		_, err = sut.RequestRepoll(ctx, &inputport.RequestAkerunRepollRequest{
+			From: repoll.From, To: repoll.To, AdminID: member,
+		})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		_, err = sut.ListRepolls(ctx, &inputport.ListAkerunRepollsRequest{AdminID: member, Limit: 20})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		_, err = sut.GetRepoll(ctx, member, repoll.ID)
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
@@ -0,0 +1,197 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockAnnouncementRepo はお知らせをメモリに持つ AnnouncementRepository のモック
+type mockAnnouncementRepo struct {
+	announcements map[uuid.UUID]*entities.Announcement
+	dismissals    map[uuid.UUID]map[uuid.UUID]bool // announcementID -> userID
+}
+
+func newMockAnnouncementRepo() *mockAnnouncementRepo {
+	return &mockAnnouncementRepo{
+		announcements: make(map[uuid.UUID]*entities.Announcement),
+		dismissals:    make(map[uuid.UUID]map[uuid.UUID]bool),
+	}
+}
+
+func (m *mockAnnouncementRepo) Create(ctx context.Context, a *entities.Announcement) error {
+	m.announcements[a.ID] = a
+	return nil
+}
+func (m *mockAnnouncementRepo) Read(ctx context.Context, id uuid.UUID) (*entities.Announcement, error) {
+	a, ok := m.announcements[id]
+	if !ok {
+		return nil, entities.ErrAnnouncementNotFound
+	}
+	return a, nil
+}
+func (m *mockAnnouncementRepo) Update(ctx context.Context, a *entities.Announcement) error {
+	m.announcements[a.ID] = a
+	return nil
+}
+func (m *mockAnnouncementRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, ok := m.announcements[id]; !ok {
+		return entities.ErrAnnouncementNotFound
+	}
+	delete(m.announcements, id)
+	delete(m.dismissals, id)
+	return nil
+}
+func (m *mockAnnouncementRepo) ReadList(ctx context.Context, offset, limit int) ([]*entities.Announcement, error) {
+	result := make([]*entities.Announcement, 0)
+	for _, a := range m.announcements {
+		result = append(result, a)
+	}
+	return result, nil
+}
+func (m *mockAnnouncementRepo) Count(ctx context.Context) (int64, error) {
+	return int64(len(m.announcements)), nil
+}
+func (m *mockAnnouncementRepo) ReadActive(ctx context.Context, now time.Time) ([]*entities.Announcement, error) {
+	result := make([]*entities.Announcement, 0)
+	for _, a := range m.announcements {
+		if a.IsActiveAt(now) {
+			result = append(result, a)
+		}
+	}
+	return result, nil
+}
+func (m *mockAnnouncementRepo) Dismiss(ctx context.Context, announcementID, userID uuid.UUID, dismissedAt time.Time) error {
+	if m.dismissals[announcementID] == nil {
+		m.dismissals[announcementID] = make(map[uuid.UUID]bool)
+	}
+	m.dismissals[announcementID][userID] = true
+	return nil
+}
+func (m *mockAnnouncementRepo) ReadDismissedIDs(ctx context.Context, userID uuid.UUID, announcementIDs []uuid.UUID) ([]uuid.UUID, error) {
+	result := make([]uuid.UUID, 0)
+	for _, id := range announcementIDs {
+		if m.dismissals[id][userID] {
+			result = append(result, id)
+		}
+	}
+	return result, nil
+}
+
+type announcementDeps struct {
+	repo     *mockAnnouncementRepo
+	userRepo *ctxTrackingUserRepo
+	admin    *entities.User
+	user     *entities.User
+}
+
+func setupAnnouncementInteractor(t *testing.T) (*announcementDeps, inputport.AnnouncementInputPort) {
+	d := &announcementDeps{
+		repo:     newMockAnnouncementRepo(),
+		userRepo: newCtxTrackingUserRepo(),
+		admin:    createTestUserWithBalance(t, "announcer", 0, "admin"),
+		user:     createTestUserWithBalance(t, "reader", 0, "user"),
+	}
+	d.userRepo.setUser(d.admin)
+	d.userRepo.setUser(d.user)
+	sut := interactor.NewAnnouncementInteractor(d.repo, d.userRepo, &mockLogger{})
+	return d, sut
+}
+
+func createAnnouncement(t *testing.T, sut inputport.AnnouncementInputPort, adminID uuid.UUID, audience entities.AnnouncementAudience) *entities.Announcement {
+	a, err := sut.CreateAnnouncement(context.Background(), &inputport.CreateAnnouncementRequest{
+		AdminID: adminID,
+		AnnouncementContent: inputport.AnnouncementContent{
+			Title:    "お知らせ",
+			Body:     "本文",
+			Severity: entities.AnnouncementSeverityInfo,
+			Audience: audience,
+		},
+	})
+	require.NoError(t, err)
+	return a
+}
+
+// --- CreateAnnouncement ---
+
+func TestAnnouncementInteractor_CreateAnnouncement(t *testing.T) {
+	t.Run("管理者はお知らせを作成できる", func(t *testing.T) {
+		d, sut := setupAnnouncementInteractor(t)
+
+		a := createAnnouncement(t, sut, d.admin.ID, entities.AnnouncementAudienceAll)
+		assert.Equal(t, d.admin.ID, a.CreatedBy)
+		assert.Contains(t, d.repo.announcements, a.ID)
+	})
+
+	t.Run("一般ユーザーは作成できない", func(t *testing.T) {
+		d, sut := setupAnnouncementInteractor(t)
+
+		_, err := sut.CreateAnnouncement(context.Background(), &inputport.CreateAnnouncementRequest{
+			AdminID: d.user.ID,
+			AnnouncementContent: inputport.AnnouncementContent{
+				Title: "お知らせ", Body: "本文",
+				Severity: entities.AnnouncementSeverityInfo, Audience: entities.AnnouncementAudienceAll,
+			},
+		})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
+
+// --- GetActiveAnnouncements / DismissAnnouncement ---
+
+func TestAnnouncementInteractor_GetActiveAnnouncements(t *testing.T) {
+	t.Run("配信対象のお知らせだけを返す", func(t *testing.T) {
+		d, sut := setupAnnouncementInteractor(t)
+		forAll := createAnnouncement(t, sut, d.admin.ID, entities.AnnouncementAudienceAll)
+		createAnnouncement(t, sut, d.admin.ID, entities.AnnouncementAudienceAdmins)
+
+		resp, err := sut.GetActiveAnnouncements(context.Background(), &inputport.GetActiveAnnouncementsRequest{UserID: d.user.ID})
+		require.NoError(t, err)
+		require.Len(t, resp.Announcements, 1)
+		assert.Equal(t, forAll.ID, resp.Announcements[0].Announcement.ID)
+
+		resp, err = sut.GetActiveAnnouncements(context.Background(), &inputport.GetActiveAnnouncementsRequest{UserID: d.admin.ID})
+		require.NoError(t, err)
+		assert.Len(t, resp.Announcements, 2)
+	})
+
+	t.Run("非表示にしたお知らせは既定で除かれる", func(t *testing.T) {
+		d, sut := setupAnnouncementInteractor(t)
+		a := createAnnouncement(t, sut, d.admin.ID, entities.AnnouncementAudienceAll)
+
+		require.NoError(t, sut.DismissAnnouncement(context.Background(), &inputport.DismissAnnouncementRequest{
+			UserID: d.user.ID, AnnouncementID: a.ID,
+		}))
+
+		resp, err := sut.GetActiveAnnouncements(context.Background(), &inputport.GetActiveAnnouncementsRequest{UserID: d.user.ID})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Announcements)
+
+		resp, err = sut.GetActiveAnnouncements(context.Background(), &inputport.GetActiveAnnouncementsRequest{UserID: d.user.ID, IncludeDismissed: true})
+		require.NoError(t, err)
+		require.Len(t, resp.Announcements, 1)
+		assert.True(t, resp.Announcements[0].Dismissed)
+
+		// 他のユーザーには引き続き表示される
+		resp, err = sut.GetActiveAnnouncements(context.Background(), &inputport.GetActiveAnnouncementsRequest{UserID: d.admin.ID})
+		require.NoError(t, err)
+		assert.Len(t, resp.Announcements, 1)
+	})
+
+	t.Run("配信対象外のお知らせは非表示にできない", func(t *testing.T) {
+		d, sut := setupAnnouncementInteractor(t)
+		a := createAnnouncement(t, sut, d.admin.ID, entities.AnnouncementAudienceAdmins)
+
+		err := sut.DismissAnnouncement(context.Background(), &inputport.DismissAnnouncementRequest{
+			UserID: d.user.ID, AnnouncementID: a.ID,
+		})
+		assert.ErrorIs(t, err, entities.ErrAnnouncementNotFound)
+	})
+}
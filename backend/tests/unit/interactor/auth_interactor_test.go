@@ -43,22 +43,181 @@ func (m *mockSessionRepo) ReadByToken(ctx context.Context, token string) (*entit
 	}
 	return s, nil
 }
+func (m *mockSessionRepo) Read(ctx context.Context, id uuid.UUID) (*entities.Session, error) {
+	for _, s := range m.sessions {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return nil, errors.New("session not found")
+}
+func (m *mockSessionRepo) ReadListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Session, error) {
+	var list []*entities.Session
+	for _, s := range m.sessions {
+		if s.UserID == userID {
+			list = append(list, s)
+		}
+	}
+	return list, nil
+}
 func (m *mockSessionRepo) Update(ctx context.Context, session *entities.Session) error {
+	if _, ok := m.sessions[session.SessionToken]; !ok {
+		return errors.New("session not found")
+	}
 	m.sessions[session.SessionToken] = session
 	return nil
 }
-func (m *mockSessionRepo) Delete(ctx context.Context, id uuid.UUID) error { return nil }
+func (m *mockSessionRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	for token, s := range m.sessions {
+		if s.ID == id {
+			delete(m.sessions, token)
+		}
+	}
+	return nil
+}
 func (m *mockSessionRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	for token, s := range m.sessions {
+		if s.UserID == userID {
+			delete(m.sessions, token)
+		}
+	}
 	return nil
 }
+func (m *mockSessionRepo) DeleteByUserIDExcept(ctx context.Context, userID, exceptID uuid.UUID) (int64, error) {
+	var count int64
+	for token, s := range m.sessions {
+		if s.UserID == userID && s.ID != exceptID {
+			delete(m.sessions, token)
+			count++
+		}
+	}
+	return count, nil
+}
+func (m *mockSessionRepo) DeleteAllExcept(ctx context.Context, exceptID uuid.UUID) (int64, error) {
+	var count int64
+	for token, s := range m.sessions {
+		if s.ID != exceptID {
+			delete(m.sessions, token)
+			count++
+		}
+	}
+	return count, nil
+}
 func (m *mockSessionRepo) DeleteExpired(ctx context.Context) error { return nil }
+func (m *mockSessionRepo) UpdateFingerprint(ctx context.Context, id uuid.UUID, fingerprint string) error {
+	for _, s := range m.sessions {
+		if s.ID == id {
+			s.Fingerprint = fingerprint
+			return nil
+		}
+	}
+	return errors.New("session not found")
+}
+func (m *mockSessionRepo) RotateCSRFToken(ctx context.Context, session *entities.Session, previousToken string) (bool, error) {
+	return true, nil
+}
+func (m *mockSessionRepo) CreateBindingViolation(ctx context.Context, violation *entities.SessionBindingViolation) error {
+	return nil
+}
+func (m *mockSessionRepo) ReadBindingViolationsByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.SessionBindingViolation, error) {
+	return nil, nil
+}
+func (m *mockSessionRepo) CountBindingViolationsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return 0, nil
+}
+
+// --- Mock LoginAttemptRepository ---
+
+type mockLoginAttemptRepo struct {
+	attempts []*entities.LoginAttempt
+	lockouts map[uuid.UUID]*entities.AccountLockout
+}
+
+func newMockLoginAttemptRepo() *mockLoginAttemptRepo {
+	return &mockLoginAttemptRepo{lockouts: make(map[uuid.UUID]*entities.AccountLockout)}
+}
+
+func (m *mockLoginAttemptRepo) Create(ctx context.Context, attempt *entities.LoginAttempt) error {
+	m.attempts = append(m.attempts, attempt)
+	return nil
+}
+func (m *mockLoginAttemptRepo) CountFailedByIPSince(ctx context.Context, ipAddress string, since time.Time) (int64, error) {
+	var count int64
+	for _, a := range m.attempts {
+		if a.IPAddress == ipAddress && !a.Success && !a.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+func (m *mockLoginAttemptRepo) CountSuccessByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	for _, a := range m.attempts {
+		if a.UserID != nil && *a.UserID == userID && a.Success {
+			count++
+		}
+	}
+	return count, nil
+}
+func (m *mockLoginAttemptRepo) ExistsSuccessByUserAndUserAgent(ctx context.Context, userID uuid.UUID, userAgent string) (bool, error) {
+	for _, a := range m.attempts {
+		if a.UserID != nil && *a.UserID == userID && a.Success && a.UserAgent == userAgent {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+func (m *mockLoginAttemptRepo) ExistsSuccessByUserAndCountry(ctx context.Context, userID uuid.UUID, country string) (bool, error) {
+	for _, a := range m.attempts {
+		if a.UserID != nil && *a.UserID == userID && a.Success && a.Country == country {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+func (m *mockLoginAttemptRepo) ExistsSuccessByUserAndIP(ctx context.Context, userID uuid.UUID, ipAddress string) (bool, error) {
+	for _, a := range m.attempts {
+		if a.UserID != nil && *a.UserID == userID && a.Success && a.IPAddress == ipAddress {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+func (m *mockLoginAttemptRepo) ReadListByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.LoginAttempt, error) {
+	return nil, nil
+}
+func (m *mockLoginAttemptRepo) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return 0, nil
+}
+func (m *mockLoginAttemptRepo) ReadLockout(ctx context.Context, userID uuid.UUID) (*entities.AccountLockout, error) {
+	l, ok := m.lockouts[userID]
+	if !ok {
+		return nil, nil
+	}
+	copy := *l
+	return &copy, nil
+}
+func (m *mockLoginAttemptRepo) ReadLockoutByUnlockTokenHash(ctx context.Context, tokenHash string) (*entities.AccountLockout, error) {
+	for _, l := range m.lockouts {
+		if l.UnlockTokenHash == tokenHash {
+			copy := *l
+			return &copy, nil
+		}
+	}
+	return nil, errors.New("unlock token not found")
+}
+func (m *mockLoginAttemptRepo) SaveLockout(ctx context.Context, lockout *entities.AccountLockout) error {
+	m.lockouts[lockout.UserID] = lockout
+	return nil
+}
 
 // --- Mock PasswordService ---
 
 type mockPasswordService struct {
-	hashResult string
-	hashErr    error
-	verifyOK   bool
+	hashResult  string
+	hashErr     error
+	verifyOK    bool
+	needsRehash bool
 }
 
 func (m *mockPasswordService) HashPassword(password string) (string, error) {
@@ -75,6 +234,10 @@ func (m *mockPasswordService) VerifyPassword(hashedPassword, password string) bo
 	return m.verifyOK
 }
 
+func (m *mockPasswordService) NeedsRehash(hashedPassword string) bool {
+	return m.needsRehash
+}
+
 // --- Register ---
 
 func TestAuthInteractor_Register(t *testing.T) {
@@ -84,7 +247,7 @@ func TestAuthInteractor_Register(t *testing.T) {
 		pwService := &mockPasswordService{verifyOK: true}
 		logger := &mockLogger{}
 
-		sut := interactor.NewAuthInteractor(userRepo, sessionRepo, pwService, logger)
+		sut := interactor.NewAuthInteractor(userRepo, sessionRepo, newMockLoginAttemptRepo(), pwService, &mockEmailService{}, &mockNotificationDispatcher{}, &mockReferralTracker{}, &mockOnboardingBonusGranter{}, &mockEmailVerificationGate{}, logger)
 		return userRepo, sessionRepo, pwService, sut
 	}
 
@@ -125,7 +288,7 @@ func TestAuthInteractor_Login(t *testing.T) {
 		pwService := &mockPasswordService{verifyOK: true}
 		logger := &mockLogger{}
 
-		sut := interactor.NewAuthInteractor(userRepo, sessionRepo, pwService, logger)
+		sut := interactor.NewAuthInteractor(userRepo, sessionRepo, newMockLoginAttemptRepo(), pwService, &mockEmailService{}, &mockNotificationDispatcher{}, &mockReferralTracker{}, &mockOnboardingBonusGranter{}, &mockEmailVerificationGate{}, logger)
 		return userRepo, sessionRepo, pwService, sut
 	}
 
@@ -186,8 +349,8 @@ func TestAuthInteractor_Login(t *testing.T) {
 func TestAuthInteractor_Logout(t *testing.T) {
 	t.Run("正常にログアウトできる", func(t *testing.T) {
 		sut := interactor.NewAuthInteractor(
-			newCtxTrackingUserRepo(), newMockSessionRepo(),
-			&mockPasswordService{}, &mockLogger{},
+			newCtxTrackingUserRepo(), newMockSessionRepo(), newMockLoginAttemptRepo(),
+			&mockPasswordService{}, &mockEmailService{}, &mockNotificationDispatcher{}, &mockReferralTracker{}, &mockOnboardingBonusGranter{}, &mockEmailVerificationGate{}, &mockLogger{},
 		)
 		err := sut.Logout(context.Background(), &inputport.LogoutRequest{
 			UserID: uuid.New(),
@@ -202,8 +365,8 @@ func TestAuthInteractor_GetCurrentUser(t *testing.T) {
 	t.Run("正常にユーザー情報を取得できる", func(t *testing.T) {
 		userRepo := newCtxTrackingUserRepo()
 		sut := interactor.NewAuthInteractor(
-			userRepo, newMockSessionRepo(),
-			&mockPasswordService{}, &mockLogger{},
+			userRepo, newMockSessionRepo(), newMockLoginAttemptRepo(),
+			&mockPasswordService{}, &mockEmailService{}, &mockNotificationDispatcher{}, &mockReferralTracker{}, &mockOnboardingBonusGranter{}, &mockEmailVerificationGate{}, &mockLogger{},
 		)
 		user := createTestUserWithBalance(t, "currentuser", 1000, "user")
 		userRepo.setUser(user)
@@ -217,8 +380,8 @@ func TestAuthInteractor_GetCurrentUser(t *testing.T) {
 
 	t.Run("ユーザーが存在しない場合エラー", func(t *testing.T) {
 		sut := interactor.NewAuthInteractor(
-			newCtxTrackingUserRepo(), newMockSessionRepo(),
-			&mockPasswordService{}, &mockLogger{},
+			newCtxTrackingUserRepo(), newMockSessionRepo(), newMockLoginAttemptRepo(),
+			&mockPasswordService{}, &mockEmailService{}, &mockNotificationDispatcher{}, &mockReferralTracker{}, &mockOnboardingBonusGranter{}, &mockEmailVerificationGate{}, &mockLogger{},
 		)
 		_, err := sut.GetCurrentUser(context.Background(), &inputport.GetCurrentUserRequest{
 			UserID: uuid.New(),
@@ -231,13 +394,16 @@ func TestAuthInteractor_GetCurrentUser(t *testing.T) {
 
 func TestAuthInteractor_ValidateSession(t *testing.T) {
 	t.Run("正常にセッションを検証できる", func(t *testing.T) {
+		userRepo := newCtxTrackingUserRepo()
 		sessionRepo := newMockSessionRepo()
 		sut := interactor.NewAuthInteractor(
-			newCtxTrackingUserRepo(), sessionRepo,
-			&mockPasswordService{}, &mockLogger{},
+			userRepo, sessionRepo, newMockLoginAttemptRepo(),
+			&mockPasswordService{}, &mockEmailService{}, &mockNotificationDispatcher{}, &mockReferralTracker{}, &mockOnboardingBonusGranter{}, &mockEmailVerificationGate{}, &mockLogger{},
 		)
 
-		session, err := entities.NewSession(uuid.New(), "127.0.0.1", "TestAgent")
+		user := createTestUserWithBalance(t, "sessionuser", 0, "user")
+		userRepo.setUser(user)
+		session, err := entities.NewSession(user.ID, "127.0.0.1", "TestAgent")
 		require.NoError(t, err)
 		sessionRepo.sessions[session.SessionToken] = session
 
@@ -248,8 +414,8 @@ func TestAuthInteractor_ValidateSession(t *testing.T) {
 
 	t.Run("存在しないセッションの場合エラー", func(t *testing.T) {
 		sut := interactor.NewAuthInteractor(
-			newCtxTrackingUserRepo(), newMockSessionRepo(),
-			&mockPasswordService{}, &mockLogger{},
+			newCtxTrackingUserRepo(), newMockSessionRepo(), newMockLoginAttemptRepo(),
+			&mockPasswordService{}, &mockEmailService{}, &mockNotificationDispatcher{}, &mockReferralTracker{}, &mockOnboardingBonusGranter{}, &mockEmailVerificationGate{}, &mockLogger{},
 		)
 
 		_, err := sut.ValidateSession(context.Background(), "invalid-token")
@@ -260,8 +426,8 @@ func TestAuthInteractor_ValidateSession(t *testing.T) {
 	t.Run("期限切れセッションの場合エラー", func(t *testing.T) {
 		sessionRepo := newMockSessionRepo()
 		sut := interactor.NewAuthInteractor(
-			newCtxTrackingUserRepo(), sessionRepo,
-			&mockPasswordService{}, &mockLogger{},
+			newCtxTrackingUserRepo(), sessionRepo, newMockLoginAttemptRepo(),
+			&mockPasswordService{}, &mockEmailService{}, &mockNotificationDispatcher{}, &mockReferralTracker{}, &mockOnboardingBonusGranter{}, &mockEmailVerificationGate{}, &mockLogger{},
 		)
 
 		session, err := entities.NewSession(uuid.New(), "127.0.0.1", "TestAgent")
@@ -274,3 +440,138 @@ func TestAuthInteractor_ValidateSession(t *testing.T) {
 		assert.Contains(t, err.Error(), "session expired")
 	})
 }
+
+// --- Lockout ---
+
+func TestAuthInteractor_Lockout(t *testing.T) {
+	setup := func() (*ctxTrackingUserRepo, *mockLoginAttemptRepo, *mockPasswordService, *mockEmailService, inputport.AuthInputPort, *entities.User) {
+		userRepo := newCtxTrackingUserRepo()
+		attemptRepo := newMockLoginAttemptRepo()
+		pwService := &mockPasswordService{verifyOK: false}
+		emailService := &mockEmailService{}
+
+		user := createTestUserWithBalance(t, "lockuser", 0, "user")
+		userRepo.setUser(user)
+
+		sut := interactor.NewAuthInteractor(userRepo, newMockSessionRepo(), attemptRepo, pwService, emailService, &mockNotificationDispatcher{}, &mockReferralTracker{}, &mockOnboardingBonusGranter{}, &mockEmailVerificationGate{}, &mockLogger{})
+		return userRepo, attemptRepo, pwService, emailService, sut, user
+	}
+
+	failLogin := func(sut inputport.AuthInputPort, username, ip string) error {
+		_, err := sut.Login(context.Background(), &inputport.LoginRequest{
+			Username: username, Password: "wrong", IPAddress: ip, UserAgent: "TestAgent",
+		})
+		return err
+	}
+
+	t.Run("連続失敗が上限に達するとロックされメールが送られる", func(t *testing.T) {
+		_, attemptRepo, pwService, emailService, sut, user := setup()
+
+		for n := 1; n < entities.LoginMaxFailuresPerAccount; n++ {
+			err := failLogin(sut, user.Username, "10.0.0.1")
+			assert.Contains(t, err.Error(), "invalid username or password")
+		}
+		err := failLogin(sut, user.Username, "10.0.0.1")
+		assert.Contains(t, err.Error(), "account is locked")
+		require.Len(t, emailService.lockedNotifications, 1)
+		assert.Len(t, attemptRepo.attempts, entities.LoginMaxFailuresPerAccount)
+
+		// 正しいパスワードでもロック中はログインできない
+		pwService.verifyOK = true
+		_, err = sut.Login(context.Background(), &inputport.LoginRequest{
+			Username: user.Username, Password: "password123", IPAddress: "10.0.0.1",
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "account is locked")
+	})
+
+	t.Run("メールのトークンでロック解除できる", func(t *testing.T) {
+		_, attemptRepo, pwService, emailService, sut, user := setup()
+		for n := 0; n < entities.LoginMaxFailuresPerAccount; n++ {
+			_ = failLogin(sut, user.Username, "10.0.0.2")
+		}
+		require.Len(t, emailService.lockedNotifications, 1)
+
+		err := sut.UnlockAccount(context.Background(), &inputport.UnlockAccountRequest{
+			Token: emailService.lockedNotifications[0],
+		})
+		require.NoError(t, err)
+		assert.False(t, attemptRepo.lockouts[user.ID].IsLocked(time.Now()))
+
+		pwService.verifyOK = true
+		_, err = sut.Login(context.Background(), &inputport.LoginRequest{
+			Username: user.Username, Password: "password123", IPAddress: "10.0.0.2",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("不正なロック解除トークンはエラー", func(t *testing.T) {
+		_, _, _, _, sut, _ := setup()
+		err := sut.UnlockAccount(context.Background(), &inputport.UnlockAccountRequest{Token: "invalid"})
+		assert.Error(t, err)
+	})
+
+	t.Run("同一IPからの失敗が上限に達するとブロックされる", func(t *testing.T) {
+		_, attemptRepo, _, _, sut, _ := setup()
+		for n := 0; n < entities.LoginMaxFailuresPerIP; n++ {
+			attemptRepo.attempts = append(attemptRepo.attempts,
+				entities.NewLoginAttempt(nil, "unknown", "10.0.0.3", "bot", "", false, entities.LoginFailureInvalidCredentials))
+		}
+
+		err := failLogin(sut, "anyone", "10.0.0.3")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "too many login attempts")
+	})
+}
+
+func TestAuthInteractor_NewDeviceNotification(t *testing.T) {
+	setup := func() (*mockNotificationDispatcher, inputport.AuthInputPort, *entities.User) {
+		userRepo := newCtxTrackingUserRepo()
+		notifications := &mockNotificationDispatcher{}
+		user := createTestUserWithBalance(t, "deviceuser", 0, "user")
+		userRepo.setUser(user)
+
+		sut := interactor.NewAuthInteractor(userRepo, newMockSessionRepo(), newMockLoginAttemptRepo(),
+			&mockPasswordService{verifyOK: true}, &mockEmailService{}, notifications, &mockReferralTracker{}, &mockOnboardingBonusGranter{}, &mockEmailVerificationGate{}, &mockLogger{})
+		return notifications, sut, user
+	}
+
+	login := func(t *testing.T, sut inputport.AuthInputPort, username, userAgent, country string) {
+		t.Helper()
+		_, err := sut.Login(context.Background(), &inputport.LoginRequest{
+			Username: username, Password: "password123",
+			IPAddress: "127.0.0.1", UserAgent: userAgent, Country: country,
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("初回ログインでは通知しない", func(t *testing.T) {
+		notifications, sut, user := setup()
+		login(t, sut, user.Username, "Browser/1.0", "JP")
+		assert.Empty(t, notifications.notifications)
+	})
+
+	t.Run("同じ端末・国からのログインでは通知しない", func(t *testing.T) {
+		notifications, sut, user := setup()
+		login(t, sut, user.Username, "Browser/1.0", "JP")
+		login(t, sut, user.Username, "Browser/1.0", "JP")
+		assert.Empty(t, notifications.notifications)
+	})
+
+	t.Run("新しい端末からのログインで通知する", func(t *testing.T) {
+		notifications, sut, user := setup()
+		login(t, sut, user.Username, "Browser/1.0", "JP")
+		login(t, sut, user.Username, "Phone/2.0", "JP")
+		require.Len(t, notifications.notifications, 1)
+		assert.Equal(t, entities.NotificationTypeNewLogin, notifications.notifications[0].Type)
+		assert.Equal(t, user.ID, notifications.notifications[0].UserID)
+		assert.Equal(t, "Phone/2.0", notifications.notifications[0].Data["user_agent"])
+	})
+
+	t.Run("新しい国からのログインで通知する", func(t *testing.T) {
+		notifications, sut, user := setup()
+		login(t, sut, user.Username, "Browser/1.0", "JP")
+		login(t, sut, user.Username, "Browser/1.0", "US")
+		assert.Len(t, notifications.notifications, 1)
+	})
+}
@@ -0,0 +1,193 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockBalanceHolder は確保せずにsaveだけを呼ぶ
+type mockBalanceHolder struct{}
+
+func (m *mockBalanceHolder) Hold(ctx context.Context, tr *entities.TransferRequest, save func(ctx context.Context) error) error {
+	return save(ctx)
+}
+
+func (m *mockBalanceHolder) Release(ctx context.Context, transferRequestID uuid.UUID) error {
+	return nil
+}
+
+func TestTransferRequestInteractor_BalanceHold(t *testing.T) {
+	type fixture struct {
+		repos    *testsupport.Repositories
+		holds    *interactor.BalanceHoldInteractor
+		transfer *interactor.PointTransferInteractor
+		sut      inputport.TransferRequestInputPort
+		sender   *entities.User
+		receiver *entities.User
+	}
+	setup := func(t *testing.T) *fixture {
+		repos := testsupport.New()
+		sender := createTestUserWithBalance(t, "sender", 1000, entities.RoleUser)
+		receiver := createTestUserWithBalance(t, "receiver", 0, entities.RoleUser)
+		repos.Users.Seed(sender, receiver)
+		holds := interactor.NewBalanceHoldInteractor(repos.TxManager, repos.Users, repos.BalanceHolds, &mockLogger{})
+		transfer := interactor.NewPointTransferInteractor(repos.TxManager, repos.Users, repos.Transactions, repos.IdempotencyKeys,
+			repos.Friendships, repos.PointBatches, repos.BalanceHolds, &mockNotificationDispatcher{}, &mockTransferScreener{},
+			&mockTransferEligibility{}, &mockTransferPolicy{}, &mockEarningEvents{}, &mockLogger{})
+		sut := interactor.NewTransferRequestInteractor(repos.TransferRequests, repos.Users, transfer, &mockContentModeration{},
+			&mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, repos.MuteList, holds, &mockTransferRequestLogger{})
+		return &fixture{repos: repos, holds: holds, transfer: transfer, sut: sut, sender: sender, receiver: receiver}
+	}
+	create := func(t *testing.T, f *fixture, amount int64, hold bool) (*entities.TransferRequest, error) {
+		t.Helper()
+		resp, err := f.sut.CreateTransferRequest(context.Background(), &inputport.CreateTransferRequestRequest{
+			FromUserID: f.sender.ID, ToUserID: f.receiver.ID, Amount: amount, IdempotencyKey: uuid.NewString(), Hold: hold,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.TransferRequest, nil
+	}
+	balance := func(t *testing.T, f *fixture, userID uuid.UUID) *inputport.GetBalanceResponse {
+		t.Helper()
+		resp, err := f.transfer.GetBalance(context.Background(), &inputport.GetBalanceRequest{UserID: userID})
+		require.NoError(t, err)
+		return resp
+	}
+	holdOf := func(t *testing.T, f *fixture, tr *entities.TransferRequest) *entities.BalanceHold {
+		t.Helper()
+		hold, err := f.repos.BalanceHolds.ReadByTransferRequestIDForUpdate(context.Background(), tr.ID)
+		require.NoError(t, err)
+		require.NotNil(t, hold)
+		return hold
+	}
+
+	t.Run("確保した額は使える残高から除かれ、ほかの送金に使えない", func(t *testing.T) {
+		f := setup(t)
+		tr, err := create(t, f, 700, true)
+		require.NoError(t, err)
+		assert.True(t, tr.Hold)
+
+		b := balance(t, f, f.sender.ID)
+		assert.Equal(t, int64(1000), b.Balance)
+		assert.Equal(t, int64(700), b.Reserved)
+		assert.Equal(t, int64(300), b.Available)
+
+		_, err = f.transfer.Transfer(context.Background(), &inputport.TransferRequest{
+			FromUserID: f.sender.ID, ToUserID: f.receiver.ID, Amount: 500, IdempotencyKey: "spend",
+		})
+		assert.ErrorIs(t, err, entities.ErrInsufficientBalance)
+
+		_, err = create(t, f, 500, false)
+		assert.ErrorIs(t, err, entities.ErrInsufficientBalance, "確保しないリクエストも使える残高で確かめる")
+	})
+
+	t.Run("使える残高を超えて確保するリクエストは保存しない", func(t *testing.T) {
+		f := setup(t)
+		_, err := create(t, f, 700, true)
+		require.NoError(t, err)
+
+		_, err = create(t, f, 400, true)
+		assert.ErrorIs(t, err, entities.ErrInsufficientBalance)
+		sent, err := f.repos.TransferRequests.ReadSentByFromUser(context.Background(), f.sender.ID, 0, 10)
+		require.NoError(t, err)
+		assert.Len(t, sent, 1)
+	})
+
+	t.Run("承認すると確保を使って送金する", func(t *testing.T) {
+		f := setup(t)
+		tr, err := create(t, f, 700, true)
+		require.NoError(t, err)
+
+		_, err = f.sut.ApproveTransferRequest(context.Background(), &inputport.ApproveTransferRequestRequest{RequestID: tr.ID, UserID: f.receiver.ID})
+		require.NoError(t, err)
+
+		b := balance(t, f, f.sender.ID)
+		assert.Equal(t, int64(300), b.Balance)
+		assert.Zero(t, b.Reserved)
+		assert.Equal(t, int64(700), balance(t, f, f.receiver.ID).Balance)
+		assert.Equal(t, entities.BalanceHoldStatusConsumed, holdOf(t, f, tr).Status)
+	})
+
+	t.Run("カウンターオファーで金額が増えても確保した分と合わせて送金できる", func(t *testing.T) {
+		f := setup(t)
+		tr, err := create(t, f, 700, true)
+		require.NoError(t, err)
+		_, err = f.sut.CounterTransferRequest(context.Background(), &inputport.CounterTransferRequestRequest{RequestID: tr.ID, UserID: f.receiver.ID, Amount: 900})
+		require.NoError(t, err)
+
+		_, err = f.sut.ConfirmCounterOffer(context.Background(), &inputport.ConfirmCounterOfferRequest{RequestID: tr.ID, UserID: f.sender.ID})
+		require.NoError(t, err)
+
+		b := balance(t, f, f.sender.ID)
+		assert.Equal(t, int64(100), b.Balance)
+		assert.Zero(t, b.Reserved)
+	})
+
+	t.Run("拒否・キャンセルすると確保を戻す", func(t *testing.T) {
+		f := setup(t)
+		rejected, err := create(t, f, 300, true)
+		require.NoError(t, err)
+		cancelled, err := create(t, f, 400, true)
+		require.NoError(t, err)
+		assert.Equal(t, int64(700), balance(t, f, f.sender.ID).Reserved)
+
+		_, err = f.sut.RejectTransferRequest(context.Background(), &inputport.RejectTransferRequestRequest{RequestID: rejected.ID, UserID: f.receiver.ID})
+		require.NoError(t, err)
+		_, err = f.sut.CancelTransferRequest(context.Background(), &inputport.CancelTransferRequestRequest{RequestID: cancelled.ID, UserID: f.sender.ID})
+		require.NoError(t, err)
+
+		b := balance(t, f, f.sender.ID)
+		assert.Equal(t, int64(1000), b.Balance)
+		assert.Zero(t, b.Reserved)
+		assert.Equal(t, entities.BalanceHoldStatusReleased, holdOf(t, f, rejected).Status)
+		assert.Equal(t, entities.BalanceHoldStatusReleased, holdOf(t, f, cancelled).Status)
+
+		// 戻し済みの確保をもう一度戻しても何もしない
+		require.NoError(t, f.holds.Release(context.Background(), rejected.ID))
+		assert.Zero(t, balance(t, f, f.sender.ID).Reserved)
+	})
+
+	t.Run("期限切れになったリクエストの確保はジョブで戻す", func(t *testing.T) {
+		f := setup(t)
+		expired, err := create(t, f, 300, true)
+		require.NoError(t, err)
+		_, err = create(t, f, 200, true)
+		require.NoError(t, err)
+
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		require.NoError(t, f.repos.TransferRequests.Update(context.Background(), expired))
+		_, err = f.repos.TransferRequests.UpdateExpiredRequests(context.Background())
+		require.NoError(t, err)
+
+		released, err := f.holds.ReleaseClosedHolds(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), released)
+		assert.Equal(t, int64(200), balance(t, f, f.sender.ID).Reserved, "承認待ちのリクエストの確保は残す")
+	})
+
+	t.Run("確保を戻せなくても拒否は成功し、ジョブが改めて戻す", func(t *testing.T) {
+		f := setup(t)
+		tr, err := create(t, f, 300, true)
+		require.NoError(t, err)
+		f.repos.Users.FailOnce("UpdateReservedBalanceWithLock", assert.AnError)
+
+		_, err = f.sut.RejectTransferRequest(context.Background(), &inputport.RejectTransferRequestRequest{RequestID: tr.ID, UserID: f.receiver.ID})
+		require.NoError(t, err)
+		assert.Equal(t, int64(300), balance(t, f, f.sender.ID).Reserved)
+
+		released, err := f.holds.ReleaseClosedHolds(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), released)
+		assert.Zero(t, balance(t, f, f.sender.ID).Reserved)
+	})
+}
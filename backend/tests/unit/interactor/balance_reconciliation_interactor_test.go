@@ -0,0 +1,244 @@
+package interactor_test
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockBalanceLedgerRepo はユーザーごとの残高と取引履歴から計算した残高を持つ BalanceLedgerRepository のモック
+type mockBalanceLedgerRepo struct {
+	checks  map[uuid.UUID]*entities.BalanceCheck
+	limits  []int         // ReadUserIDsに渡されたlimit
+	locks   [][]uuid.UUID // LockUsersでロックしたユーザー
+	lockCtx []context.Context
+}
+
+func newMockBalanceLedgerRepo() *mockBalanceLedgerRepo {
+	return &mockBalanceLedgerRepo{checks: make(map[uuid.UUID]*entities.BalanceCheck)}
+}
+
+func (m *mockBalanceLedgerRepo) ReadUserIDs(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	m.limits = append(m.limits, limit)
+	ids := make([]uuid.UUID, 0, len(m.checks))
+	for id := range m.checks {
+		if bytes.Compare(id[:], after[:]) > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(a, b int) bool { return bytes.Compare(ids[a][:], ids[b][:]) < 0 })
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
+
+func (m *mockBalanceLedgerRepo) LockUsers(ctx context.Context, userIDs []uuid.UUID) error {
+	m.locks = append(m.locks, userIDs)
+	m.lockCtx = append(m.lockCtx, ctx)
+	return nil
+}
+
+func (m *mockBalanceLedgerRepo) ReadBalanceChecks(ctx context.Context, userIDs []uuid.UUID) ([]*entities.BalanceCheck, error) {
+	checks := make([]*entities.BalanceCheck, 0, len(userIDs))
+	for _, id := range userIDs {
+		c := *m.checks[id]
+		checks = append(checks, &c)
+	}
+	return checks, nil
+}
+
+// add はユーザーを追加する
+func (m *mockBalanceLedgerRepo) add(stored, ledger int64) uuid.UUID {
+	id := uuid.New()
+	m.checks[id] = &entities.BalanceCheck{UserID: id, Username: "user_" + id.String()[:8], StoredBalance: stored, LedgerBalance: ledger}
+	return id
+}
+
+// brMockUserRepo は補正で変えた残高を記録する UserRepository のモック
+type brMockUserRepo struct {
+	*ctxTrackingUserRepo
+	ledger   *mockBalanceLedgerRepo
+	deducted map[uuid.UUID]bool
+	reserved map[uuid.UUID]int64
+}
+
+func (m *brMockUserRepo) UpdateBalanceWithLock(ctx context.Context, userID uuid.UUID, amount int64, isDeduct bool) error {
+	if isDeduct && m.ledger.checks[userID].StoredBalance-m.reserved[userID] < amount {
+		return entities.ErrInsufficientBalance
+	}
+	m.deducted[userID] = isDeduct
+	if isDeduct {
+		amount = -amount
+	}
+	m.ledger.checks[userID].StoredBalance += amount
+	return nil
+}
+
+type balanceReconciliationFixture struct {
+	sut        inputport.BalanceReconciliationInputPort
+	ledgerRepo *mockBalanceLedgerRepo
+	userRepo   *brMockUserRepo
+	txRepo     *ctxTrackingTransactionRepo
+	batchRepo  *pepMockPointBatchRepo
+	admin      *entities.User
+}
+
+func setupBalanceReconciliationInteractor(t *testing.T) *balanceReconciliationFixture {
+	ledgerRepo := newMockBalanceLedgerRepo()
+	f := &balanceReconciliationFixture{
+		ledgerRepo: ledgerRepo,
+		userRepo:   &brMockUserRepo{ctxTrackingUserRepo: newCtxTrackingUserRepo(), ledger: ledgerRepo, deducted: map[uuid.UUID]bool{}, reserved: map[uuid.UUID]int64{}},
+		txRepo:     newCtxTrackingTransactionRepo(),
+		batchRepo:  newPEPMockPointBatchRepo(),
+		admin:      createTestUserWithBalance(t, "recompute_admin", 0, "admin"),
+	}
+	f.userRepo.setUser(f.admin)
+	f.sut = interactor.NewBalanceReconciliationInteractor(
+		&ctxTrackingTxManager{}, f.ledgerRepo, f.userRepo, f.txRepo, f.batchRepo, &mockLogger{},
+	)
+	return f
+}
+
+func TestBalanceReconciliationInteractor_RecomputeBalances(t *testing.T) {
+	t.Run("照合のみなら一致しない残高を返し、残高は変えない", func(t *testing.T) {
+		f := setupBalanceReconciliationInteractor(t)
+		for n := 0; n < 4; n++ {
+			f.ledgerRepo.add(100, 100)
+		}
+		tooHigh := f.ledgerRepo.add(500, 300)
+
+		resp, err := f.sut.RecomputeBalances(context.Background(), &inputport.RecomputeBalancesRequest{
+			AdminID: f.admin.ID, BatchSize: 2,
+		})
+		require.NoError(t, err)
+
+		assert.False(t, resp.Applied)
+		assert.Equal(t, 5, resp.CheckedUsers)
+		assert.Equal(t, 3, resp.Batches, "2人ずつ照合する")
+		require.Len(t, resp.Discrepancies, 1)
+		d := resp.Discrepancies[0]
+		assert.Equal(t, tooHigh, d.UserID)
+		assert.Equal(t, int64(-200), d.Difference())
+		assert.False(t, d.Corrected)
+
+		assert.Empty(t, f.ledgerRepo.locks, "照合のみならロックしない")
+		assert.Empty(t, f.txRepo.transactions)
+		assert.Equal(t, int64(500), f.ledgerRepo.checks[tooHigh].StoredBalance)
+	})
+
+	t.Run("補正すると取引履歴に合わせ、補正取引を記録する", func(t *testing.T) {
+		f := setupBalanceReconciliationInteractor(t)
+		tooLow := f.ledgerRepo.add(100, 250)
+		tooHigh := f.ledgerRepo.add(500, 300)
+		f.ledgerRepo.add(80, 80)
+
+		resp, err := f.sut.RecomputeBalances(context.Background(), &inputport.RecomputeBalancesRequest{
+			AdminID: f.admin.ID, Apply: true, Reason: "手動更新の修正",
+		})
+		require.NoError(t, err)
+
+		assert.True(t, resp.Applied)
+		require.Len(t, resp.Discrepancies, 2)
+		for _, d := range resp.Discrepancies {
+			assert.True(t, d.Corrected)
+			require.NotNil(t, d.TransactionID)
+		}
+		assert.Equal(t, int64(250), f.ledgerRepo.checks[tooLow].StoredBalance)
+		assert.Equal(t, int64(300), f.ledgerRepo.checks[tooHigh].StoredBalance)
+		assert.False(t, f.userRepo.deducted[tooLow])
+		assert.True(t, f.userRepo.deducted[tooHigh])
+
+		require.Len(t, f.txRepo.transactions, 2)
+		for _, tx := range f.txRepo.transactions {
+			assert.Equal(t, entities.TransactionTypeBalanceCorrection, tx.TransactionType)
+			assert.Equal(t, f.admin.ID.String(), tx.Metadata["admin_id"])
+			assert.Contains(t, tx.Description, "手動更新の修正")
+		}
+
+		// 増やした分だけ補正のバッチを作る
+		require.Len(t, f.batchRepo.created, 1)
+		assert.Equal(t, tooLow, f.batchRepo.created[0].UserID)
+		assert.Equal(t, int64(150), f.batchRepo.created[0].OriginalAmount)
+		assert.Equal(t, entities.PointBatchSourceBalanceCorrection, f.batchRepo.created[0].SourceType)
+
+		// 照合の前にトランザクション内で対象ユーザーをロックする
+		require.Len(t, f.ledgerRepo.locks, 1)
+		assert.Len(t, f.ledgerRepo.locks[0], 3)
+		assert.True(t, isTxContext(f.ledgerRepo.lockCtx[0]))
+	})
+
+	t.Run("取引履歴から計算した残高が負なら補正しない", func(t *testing.T) {
+		f := setupBalanceReconciliationInteractor(t)
+		id := f.ledgerRepo.add(100, -50)
+
+		resp, err := f.sut.RecomputeBalances(context.Background(), &inputport.RecomputeBalancesRequest{
+			AdminID: f.admin.ID, Apply: true,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, resp.Discrepancies, 1)
+		assert.False(t, resp.Discrepancies[0].Corrected)
+		assert.Equal(t, entities.BalanceCorrectionSkipNegativeLedger, resp.Discrepancies[0].SkipReason)
+		assert.Equal(t, int64(100), f.ledgerRepo.checks[id].StoredBalance)
+		assert.Empty(t, f.txRepo.transactions)
+	})
+
+	t.Run("減らす分が確保中の分に掛かるユーザーは補正せず、他のユーザーは補正する", func(t *testing.T) {
+		f := setupBalanceReconciliationInteractor(t)
+		held := f.ledgerRepo.add(500, 300)
+		f.userRepo.reserved[held] = 400
+		other := f.ledgerRepo.add(100, 250)
+
+		resp, err := f.sut.RecomputeBalances(context.Background(), &inputport.RecomputeBalancesRequest{
+			AdminID: f.admin.ID, Apply: true,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, resp.Discrepancies, 2)
+		for _, d := range resp.Discrepancies {
+			if d.UserID == held {
+				assert.False(t, d.Corrected)
+				assert.Equal(t, entities.BalanceCorrectionSkipReserved, d.SkipReason)
+			} else {
+				assert.True(t, d.Corrected)
+			}
+		}
+		assert.Equal(t, int64(500), f.ledgerRepo.checks[held].StoredBalance)
+		assert.Equal(t, int64(250), f.ledgerRepo.checks[other].StoredBalance)
+		require.Len(t, f.txRepo.transactions, 1)
+	})
+
+	t.Run("1回に照合する人数は上限までに抑える", func(t *testing.T) {
+		f := setupBalanceReconciliationInteractor(t)
+		f.ledgerRepo.add(0, 0)
+
+		_, err := f.sut.RecomputeBalances(context.Background(), &inputport.RecomputeBalancesRequest{
+			AdminID: f.admin.ID, BatchSize: 100000,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{entities.BalanceRecomputeMaxBatchSize}, f.ledgerRepo.limits)
+
+		f.ledgerRepo.limits = nil
+		_, err = f.sut.RecomputeBalances(context.Background(), &inputport.RecomputeBalancesRequest{AdminID: f.admin.ID})
+		require.NoError(t, err)
+		assert.Equal(t, []int{entities.BalanceRecomputeDefaultBatchSize}, f.ledgerRepo.limits)
+	})
+
+	t.Run("管理者以外は実行できない", func(t *testing.T) {
+		f := setupBalanceReconciliationInteractor(t)
+		user := createTestUserWithBalance(t, "recompute_user", 0, "user")
+		f.userRepo.setUser(user)
+
+		_, err := f.sut.RecomputeBalances(context.Background(), &inputport.RecomputeBalancesRequest{AdminID: user.ID, Apply: true})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
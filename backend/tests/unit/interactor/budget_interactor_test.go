@@ -0,0 +1,327 @@
+package interactor_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ========================================
+// Mock BudgetRepository
+// ========================================
+
+type mockBudgetRepo struct {
+	budgets    map[uuid.UUID]*entities.Budget
+	usages     map[string]*entities.BudgetUsage // 予算ID+期間の開始 → 消化状況
+	recipients []uuid.UUID
+}
+
+func newMockBudgetRepo() *mockBudgetRepo {
+	return &mockBudgetRepo{
+		budgets: make(map[uuid.UUID]*entities.Budget),
+		usages:  make(map[string]*entities.BudgetUsage),
+	}
+}
+
+// add は予算を登録して返す
+func (m *mockBudgetRepo) add(t *testing.T, scope entities.BudgetScope, scopeID *uuid.UUID, amount int64, enforcement entities.BudgetEnforcement) *entities.Budget {
+	t.Helper()
+	b, err := entities.NewBudget(string(scope)+" budget", scope, scopeID, entities.BudgetPeriodMonthly, amount, enforcement)
+	require.NoError(t, err)
+	m.budgets[b.ID] = b
+	return b
+}
+
+// consumed は予算の現在の期間の消化量を返す
+func (m *mockBudgetRepo) consumed(b *entities.Budget) int64 {
+	if u, ok := m.usages[budgetUsageKey(b.ID, b.PeriodStart(time.Now()))]; ok {
+		return u.Consumed
+	}
+	return 0
+}
+
+func budgetUsageKey(id uuid.UUID, periodStart time.Time) string {
+	return id.String() + periodStart.Format(time.RFC3339)
+}
+
+func (m *mockBudgetRepo) Create(ctx context.Context, budget *entities.Budget) error {
+	m.budgets[budget.ID] = budget
+	return nil
+}
+func (m *mockBudgetRepo) Read(ctx context.Context, id uuid.UUID) (*entities.Budget, error) {
+	b, ok := m.budgets[id]
+	if !ok {
+		return nil, entities.ErrBudgetNotFound
+	}
+	copy := *b
+	return &copy, nil
+}
+func (m *mockBudgetRepo) ReadList(ctx context.Context) ([]*entities.Budget, error) {
+	result := make([]*entities.Budget, 0, len(m.budgets))
+	for _, b := range m.budgets {
+		copy := *b
+		result = append(result, &copy)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result, nil
+}
+func (m *mockBudgetRepo) Update(ctx context.Context, budget *entities.Budget) error {
+	if _, ok := m.budgets[budget.ID]; !ok {
+		return entities.ErrBudgetNotFound
+	}
+	m.budgets[budget.ID] = budget
+	return nil
+}
+func (m *mockBudgetRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, ok := m.budgets[id]; !ok {
+		return entities.ErrBudgetNotFound
+	}
+	delete(m.budgets, id)
+	return nil
+}
+func (m *mockBudgetRepo) ReadApplicableForUpdate(ctx context.Context, adminID uuid.UUID, departmentIDs []uuid.UUID) ([]*entities.Budget, error) {
+	var result []*entities.Budget
+	for _, b := range m.budgets {
+		if b.IsActive && b.Applies(adminID, departmentIDs) {
+			copy := *b
+			result = append(result, &copy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID.String() < result[j].ID.String() })
+	return result, nil
+}
+func (m *mockBudgetRepo) ReadUsage(ctx context.Context, budgetID uuid.UUID, periodStart time.Time) (*entities.BudgetUsage, error) {
+	if u, ok := m.usages[budgetUsageKey(budgetID, periodStart)]; ok {
+		copy := *u
+		return &copy, nil
+	}
+	return &entities.BudgetUsage{BudgetID: budgetID, PeriodStart: periodStart}, nil
+}
+func (m *mockBudgetRepo) SaveUsage(ctx context.Context, usage *entities.BudgetUsage) error {
+	copy := *usage
+	m.usages[budgetUsageKey(usage.BudgetID, usage.PeriodStart)] = &copy
+	return nil
+}
+func (m *mockBudgetRepo) ReadAlertRecipients(ctx context.Context) ([]uuid.UUID, error) {
+	return m.recipients, nil
+}
+
+// ========================================
+// BudgetInteractor テスト
+// ========================================
+
+func TestBudgetInteractor(t *testing.T) {
+	setup := func() (inputport.BudgetInputPort, *mockBudgetRepo, *mockDepartmentRepo, *entities.User, *entities.User) {
+		userRepo := newCtxTrackingUserRepo()
+		budgetRepo := newMockBudgetRepo()
+		deptRepo := newMockDepartmentRepo()
+		admin := createTestUserWithBalance(t, "admin", 0, "admin")
+		member := createTestUserWithBalance(t, "member", 0, "user")
+		userRepo.setUser(admin)
+		userRepo.setUser(member)
+		sut := interactor.NewBudgetInteractor(budgetRepo, deptRepo, userRepo, &mockLogger{})
+		return sut, budgetRepo, deptRepo, admin, member
+	}
+
+	t.Run("予算を作成すると消化状況と合わせて返す", func(t *testing.T) {
+		sut, budgetRepo, deptRepo, admin, _ := setup()
+		dept := deptRepo.add(t, "開発部", nil, nil)
+
+		status, err := sut.CreateBudget(context.Background(), &inputport.CreateBudgetRequest{
+			AdminID: admin.ID, Name: "開発部の月間予算",
+			Scope: entities.BudgetScopeDepartment, ScopeID: &dept.ID,
+			Period: entities.BudgetPeriodMonthly, Amount: 10000, Enforcement: entities.BudgetEnforcementBlock,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(10000), status.Remaining)
+		assert.Equal(t, 0, status.Percent)
+		assert.Len(t, budgetRepo.budgets, 1)
+	})
+
+	t.Run("存在しない部署・管理者以外を対象にはできない", func(t *testing.T) {
+		sut, _, _, admin, member := setup()
+		missing := uuid.New()
+
+		_, err := sut.CreateBudget(context.Background(), &inputport.CreateBudgetRequest{
+			AdminID: admin.ID, Name: "予算", Scope: entities.BudgetScopeDepartment, ScopeID: &missing,
+			Period: entities.BudgetPeriodMonthly, Amount: 100, Enforcement: entities.BudgetEnforcementBlock,
+		})
+		assert.ErrorIs(t, err, entities.ErrDepartmentNotFound)
+
+		_, err = sut.CreateBudget(context.Background(), &inputport.CreateBudgetRequest{
+			AdminID: admin.ID, Name: "予算", Scope: entities.BudgetScopeAdmin, ScopeID: &member.ID,
+			Period: entities.BudgetPeriodMonthly, Amount: 100, Enforcement: entities.BudgetEnforcementBlock,
+		})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+
+	t.Run("一覧は現在の期間の消化状況を含む", func(t *testing.T) {
+		sut, budgetRepo, _, admin, _ := setup()
+		b := budgetRepo.add(t, entities.BudgetScopeGlobal, nil, 1000, entities.BudgetEnforcementBlock)
+		require.NoError(t, budgetRepo.SaveUsage(context.Background(), &entities.BudgetUsage{
+			BudgetID: b.ID, PeriodStart: b.PeriodStart(time.Now()), Consumed: 850,
+		}))
+
+		statuses, err := sut.ListBudgets(context.Background(), admin.ID)
+		require.NoError(t, err)
+		require.Len(t, statuses, 1)
+		assert.Equal(t, int64(850), statuses[0].Consumed)
+		assert.Equal(t, int64(150), statuses[0].Remaining)
+		assert.Equal(t, 85, statuses[0].Percent)
+	})
+
+	t.Run("更新では名前・金額・超過時の扱い・有効状態を変えられる", func(t *testing.T) {
+		sut, budgetRepo, _, admin, _ := setup()
+		b := budgetRepo.add(t, entities.BudgetScopeGlobal, nil, 1000, entities.BudgetEnforcementBlock)
+
+		status, err := sut.UpdateBudget(context.Background(), &inputport.UpdateBudgetRequest{
+			AdminID: admin.ID, BudgetID: b.ID, Name: "全体予算", Amount: 2000,
+			Enforcement: entities.BudgetEnforcementOverride, IsActive: false,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "全体予算", status.Budget.Name)
+		assert.Equal(t, entities.BudgetEnforcementOverride, budgetRepo.budgets[b.ID].Enforcement)
+		assert.False(t, budgetRepo.budgets[b.ID].IsActive)
+	})
+
+	t.Run("管理者以外は操作できない", func(t *testing.T) {
+		sut, budgetRepo, _, _, member := setup()
+		b := budgetRepo.add(t, entities.BudgetScopeGlobal, nil, 1000, entities.BudgetEnforcementBlock)
+
+		_, err := sut.ListBudgets(context.Background(), member.ID)
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		err = sut.DeleteBudget(context.Background(), &inputport.DeleteBudgetRequest{AdminID: member.ID, BudgetID: b.ID})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		assert.Len(t, budgetRepo.budgets, 1)
+	})
+}
+
+// ========================================
+// AdminInteractor 予算テスト
+// ========================================
+
+func TestAdminInteractor_GrantPoints_Budget(t *testing.T) {
+	type fixture struct {
+		sut           inputport.AdminInputPort
+		budgetRepo    *mockBudgetRepo
+		txRepo        *ctxTrackingTransactionRepo
+		notifications *mockNotificationDispatcher
+		admin         *entities.User
+		target        *entities.User
+		child         *entities.Department
+		parent        *entities.Department
+	}
+	setup := func() *fixture {
+		userRepo := newCtxTrackingUserRepo()
+		txRepo := newCtxTrackingTransactionRepo()
+		deptRepo := newMockDepartmentRepo()
+		budgetRepo := newMockBudgetRepo()
+		notifications := &mockNotificationDispatcher{}
+
+		parent := deptRepo.add(t, "本社", nil, nil)
+		child := deptRepo.add(t, "開発部", &parent.ID, nil)
+
+		admin := createTestUserWithBalance(t, "admin", 0, "admin")
+		target := createTestUserWithBalance(t, "target", 0, "user")
+		target.DepartmentID = &child.ID
+		userRepo.setUser(admin)
+		userRepo.setUser(target)
+		budgetRepo.recipients = []uuid.UUID{admin.ID}
+
+		sut := interactor.NewAdminInteractor(
+			&ctxTrackingTxManager{}, userRepo, txRepo, newCtxTrackingIdempotencyRepo(), newCtxTrackingPointBatchRepo(),
+			newMockReasonCodeRepo(), deptRepo, budgetRepo, newMockPendingAdminActionRepo(), newMockSystemSettingsRepo(), &mockAnalyticsDS{}, notifications, &mockLogger{},
+		)
+		return &fixture{sut, budgetRepo, txRepo, notifications, admin, target, child, parent}
+	}
+	grant := func(f *fixture, amount int64, override bool) (*inputport.GrantPointsResponse, error) {
+		return f.sut.GrantPoints(context.Background(), &inputport.GrantPointsRequest{
+			AdminID: f.admin.ID, UserID: f.target.ID, Amount: amount, ReasonCode: "reward",
+			Description: "budget", IdempotencyKey: "budget-" + uuid.New().String(), BudgetOverride: override,
+		})
+	}
+
+	t.Run("対象の予算をすべて消化し、消化状況を返す", func(t *testing.T) {
+		f := setup()
+		global := f.budgetRepo.add(t, entities.BudgetScopeGlobal, nil, 10000, entities.BudgetEnforcementBlock)
+		parentBudget := f.budgetRepo.add(t, entities.BudgetScopeDepartment, &f.parent.ID, 1000, entities.BudgetEnforcementBlock)
+		adminBudget := f.budgetRepo.add(t, entities.BudgetScopeAdmin, &f.admin.ID, 5000, entities.BudgetEnforcementBlock)
+		other := uuid.New()
+		otherBudget := f.budgetRepo.add(t, entities.BudgetScopeAdmin, &other, 5000, entities.BudgetEnforcementBlock)
+
+		resp, err := grant(f, 300, false)
+		require.NoError(t, err)
+		assert.Len(t, resp.Budgets, 3)
+		assert.Equal(t, int64(300), f.budgetRepo.consumed(global))
+		assert.Equal(t, int64(300), f.budgetRepo.consumed(parentBudget), "上位の部署の予算も対象になる")
+		assert.Equal(t, int64(300), f.budgetRepo.consumed(adminBudget))
+		assert.Equal(t, int64(0), f.budgetRepo.consumed(otherBudget))
+	})
+
+	t.Run("blockの予算は許可があっても超えられない", func(t *testing.T) {
+		f := setup()
+		dept := f.budgetRepo.add(t, entities.BudgetScopeDepartment, &f.child.ID, 500, entities.BudgetEnforcementBlock)
+
+		_, err := grant(f, 501, true)
+		assert.ErrorIs(t, err, entities.ErrBudgetExceeded)
+		assert.Empty(t, f.txRepo.transactions)
+		assert.Equal(t, int64(0), f.budgetRepo.consumed(dept))
+	})
+
+	t.Run("overrideの予算は許可があれば超えて付与できる", func(t *testing.T) {
+		f := setup()
+		b := f.budgetRepo.add(t, entities.BudgetScopeGlobal, nil, 500, entities.BudgetEnforcementOverride)
+
+		_, err := grant(f, 600, false)
+		assert.ErrorIs(t, err, entities.ErrBudgetOverrideRequired)
+
+		resp, err := grant(f, 600, true)
+		require.NoError(t, err)
+		assert.Equal(t, int64(600), f.budgetRepo.consumed(b))
+		assert.Equal(t, int64(0), resp.Budgets[0].Remaining)
+		assert.Equal(t, 120, resp.Budgets[0].Percent)
+	})
+
+	t.Run("80%と100%を超えたときに一度ずつ管理者へ通知する", func(t *testing.T) {
+		f := setup()
+		f.budgetRepo.add(t, entities.BudgetScopeGlobal, nil, 1000, entities.BudgetEnforcementBlock)
+
+		_, err := grant(f, 700, false)
+		require.NoError(t, err)
+		assert.Empty(t, f.notifications.notifications)
+
+		_, err = grant(f, 100, false)
+		require.NoError(t, err)
+		require.Len(t, f.notifications.notifications, 1)
+		assert.Equal(t, entities.NotificationTypeBudgetAlert, f.notifications.notifications[0].Type)
+		assert.Equal(t, "80", f.notifications.notifications[0].Data["threshold"])
+		assert.Equal(t, f.admin.ID, f.notifications.notifications[0].UserID)
+
+		_, err = grant(f, 100, false)
+		require.NoError(t, err)
+		assert.Len(t, f.notifications.notifications, 1, "同じ閾値では再通知しない")
+
+		_, err = grant(f, 100, false)
+		require.NoError(t, err)
+		require.Len(t, f.notifications.notifications, 2)
+		assert.Equal(t, "100", f.notifications.notifications[1].Data["threshold"])
+	})
+
+	t.Run("無効化した予算は対象にならない", func(t *testing.T) {
+		f := setup()
+		b := f.budgetRepo.add(t, entities.BudgetScopeGlobal, nil, 100, entities.BudgetEnforcementBlock)
+		b.IsActive = false
+
+		_, err := grant(f, 500, false)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), f.budgetRepo.consumed(b))
+	})
+}
@@ -0,0 +1,83 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ========================================
+// BusinessMetricsInteractor テスト
+// ========================================
+
+// stubSlowQueryMonitor は決まった遅いクエリの件数を返すSlowQueryMonitor
+type stubSlowQueryMonitor []service.SlowQueryCount
+
+func (s stubSlowQueryMonitor) SlowQueries() []service.SlowQueryCount {
+	return s
+}
+
+func TestBusinessMetricsInteractor(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("集計するまでは指標を持たない", func(t *testing.T) {
+		repos := testsupport.New()
+		sut := interactor.NewBusinessMetricsInteractor(repos.BusinessMetrics, stubSlowQueryMonitor{})
+
+		snapshot := sut.GetBusinessMetrics()
+		assert.Nil(t, snapshot.Metrics)
+		assert.Zero(t, snapshot.RefreshFailures)
+		assert.Zero(t, repos.BusinessMetrics.Calls("GetBusinessMetrics"), "取得ではDBを集計しない")
+	})
+
+	t.Run("遅いクエリの件数は集計を待たずにその時点の値を返す", func(t *testing.T) {
+		repos := testsupport.New()
+		slowQueries := stubSlowQueryMonitor{{Method: "transaction.RepositoryImpl.ReadListByUserIDWithUsers", Count: 3}}
+		sut := interactor.NewBusinessMetricsInteractor(repos.BusinessMetrics, slowQueries)
+
+		snapshot := sut.GetBusinessMetrics()
+		assert.Nil(t, snapshot.Metrics)
+		assert.Equal(t, []service.SlowQueryCount(slowQueries), snapshot.SlowQueries)
+	})
+
+	t.Run("集計した指標を保持して返す", func(t *testing.T) {
+		repos := testsupport.New()
+		repos.BusinessMetrics.Metrics = &entities.BusinessMetrics{PointsInCirculation: 5000, IssuedToday: 300, PendingTransferRequests: 2}
+		sut := interactor.NewBusinessMetricsInteractor(repos.BusinessMetrics, stubSlowQueryMonitor{})
+
+		require.NoError(t, sut.RefreshBusinessMetrics(ctx, now))
+		snapshot := sut.GetBusinessMetrics()
+		require.NotNil(t, snapshot.Metrics)
+		assert.Equal(t, int64(5000), snapshot.Metrics.PointsInCirculation)
+		assert.Equal(t, int64(300), snapshot.Metrics.IssuedToday)
+		assert.Equal(t, int64(2), snapshot.Metrics.PendingTransferRequests)
+		assert.True(t, now.Equal(snapshot.Metrics.CollectedAt))
+	})
+
+	t.Run("集計に失敗したら前回の指標を残して失敗回数を数える", func(t *testing.T) {
+		repos := testsupport.New()
+		repos.BusinessMetrics.Metrics = &entities.BusinessMetrics{PointsInCirculation: 5000}
+		sut := interactor.NewBusinessMetricsInteractor(repos.BusinessMetrics, stubSlowQueryMonitor{})
+		require.NoError(t, sut.RefreshBusinessMetrics(ctx, now))
+
+		dbErr := errors.New("connection refused")
+		repos.BusinessMetrics.FailOn("GetBusinessMetrics", dbErr)
+		err := sut.RefreshBusinessMetrics(ctx, now.Add(time.Minute))
+		assert.ErrorIs(t, err, dbErr)
+
+		snapshot := sut.GetBusinessMetrics()
+		require.NotNil(t, snapshot.Metrics)
+		assert.Equal(t, int64(5000), snapshot.Metrics.PointsInCirculation)
+		assert.True(t, now.Equal(snapshot.Metrics.CollectedAt))
+		assert.Equal(t, int64(1), snapshot.RefreshFailures)
+	})
+}
@@ -0,0 +1,197 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCartInteractor(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T, balance int64) (*testsupport.Repositories, *entities.User, inputport.CartInputPort) {
+		repos := testsupport.New()
+		user := createTestUserWithBalance(t, "buyer", balance, "user")
+		repos.Users.Seed(user)
+		seedDefaultAddress(t, repos, user.ID)
+		sut := interactor.NewCartInteractor(
+			repos.TxManager, repos.Carts, repos.Products, repos.ProductExchanges, repos.Users,
+			repos.Transactions, repos.PointBatches, repos.PricingRules, repos.ShippingAddresses, &mockLogger{},
+		)
+		return repos, user, sut
+	}
+	product := func(t *testing.T, repos *testsupport.Repositories, name string, price int64, stock int) *entities.Product {
+		t.Helper()
+		p, err := entities.NewProduct(name, "", "drink", price, stock)
+		require.NoError(t, err)
+		require.NoError(t, repos.Products.Create(ctx, p))
+		return p
+	}
+	add := func(t *testing.T, sut inputport.CartInputPort, user *entities.User, p *entities.Product, quantity int) {
+		t.Helper()
+		_, err := sut.AddItem(ctx, &inputport.AddCartItemRequest{UserID: user.ID, ProductID: p.ID, Quantity: quantity})
+		require.NoError(t, err)
+	}
+
+	t.Run("同じ商品を追加すると数量を足し、合計を返す", func(t *testing.T) {
+		repos, user, sut := setup(t, 1000)
+		cola := product(t, repos, "コーラ", 100, 10)
+		tea := product(t, repos, "お茶", 80, -1)
+		add(t, sut, user, cola, 1)
+		add(t, sut, user, tea, 1)
+		add(t, sut, user, cola, 2)
+
+		cart, err := sut.GetCart(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, cart.Lines, 2)
+		assert.Equal(t, cola.ID, cart.Lines[0].Item.ProductID)
+		assert.Equal(t, 3, cart.Lines[0].Item.Quantity)
+		assert.Equal(t, int64(300), cart.Lines[0].Subtotal)
+		assert.Equal(t, int64(380), cart.TotalPoints)
+	})
+
+	t.Run("在庫を超える数量は追加できない", func(t *testing.T) {
+		repos, user, sut := setup(t, 1000)
+		cola := product(t, repos, "コーラ", 100, 2)
+		add(t, sut, user, cola, 2)
+
+		_, err := sut.AddItem(ctx, &inputport.AddCartItemRequest{UserID: user.ID, ProductID: cola.ID, Quantity: 1})
+		assert.Error(t, err)
+		item, err := repos.Carts.Read(ctx, user.ID, cola.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, item.Quantity)
+	})
+
+	t.Run("商品の種類が上限に達するとErrCartFull", func(t *testing.T) {
+		repos, user, sut := setup(t, 1000)
+		for n := 0; n < entities.CartMaxItems; n++ {
+			add(t, sut, user, product(t, repos, "商品", 10, -1), 1)
+		}
+
+		_, err := sut.AddItem(ctx, &inputport.AddCartItemRequest{UserID: user.ID, ProductID: product(t, repos, "商品", 10, -1).ID, Quantity: 1})
+		assert.ErrorIs(t, err, entities.ErrCartFull)
+	})
+
+	t.Run("数量を0にすると削除し、ない商品はErrCartItemNotFound", func(t *testing.T) {
+		repos, user, sut := setup(t, 1000)
+		cola := product(t, repos, "コーラ", 100, 10)
+		add(t, sut, user, cola, 2)
+
+		cart, err := sut.UpdateItem(ctx, &inputport.UpdateCartItemRequest{UserID: user.ID, ProductID: cola.ID, Quantity: 5})
+		require.NoError(t, err)
+		assert.Equal(t, 5, cart.Lines[0].Item.Quantity)
+
+		cart, err = sut.UpdateItem(ctx, &inputport.UpdateCartItemRequest{UserID: user.ID, ProductID: cola.ID, Quantity: 0})
+		require.NoError(t, err)
+		assert.Empty(t, cart.Lines)
+
+		_, err = sut.RemoveItem(ctx, user.ID, cola.ID)
+		assert.ErrorIs(t, err, entities.ErrCartItemNotFound)
+	})
+
+	t.Run("決済はポイントを1回で減らし、商品ごとの交換記録を同じ取引に紐づける", func(t *testing.T) {
+		repos, user, sut := setup(t, 1000)
+		cola := product(t, repos, "コーラ", 100, 10)
+		tea := product(t, repos, "お茶", 80, -1)
+		add(t, sut, user, cola, 3)
+		add(t, sut, user, tea, 2)
+
+		resp, err := sut.Checkout(ctx, &inputport.CheckoutCartRequest{UserID: user.ID, Notes: "3階で受け取り"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(460), resp.TotalPoints)
+		assert.Equal(t, int64(540), resp.User.Balance)
+		assert.Equal(t, int64(460), resp.Transaction.Amount)
+		assert.Equal(t, 1, repos.Transactions.Calls("Create"), "取引は1件だけ作成する")
+
+		require.Len(t, resp.Exchanges, 2)
+		assert.Equal(t, int64(300), resp.Exchanges[0].PointsUsed)
+		assert.Equal(t, int64(160), resp.Exchanges[1].PointsUsed)
+		for _, exchange := range resp.Exchanges {
+			assert.Equal(t, entities.ExchangeStatusCompleted, exchange.Status)
+			assert.Equal(t, resp.Transaction.ID, *exchange.TransactionID)
+			assert.Equal(t, "3階で受け取り", exchange.Notes)
+			assert.NotEmpty(t, exchange.ShippingAddress)
+		}
+
+		stored, err := repos.Products.Read(ctx, cola.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 7, stored.Stock)
+
+		cart, err := sut.GetCart(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Empty(t, cart.Lines, "決済したらカートを空にする")
+	})
+
+	t.Run("同じセールの商品は合わせて購入上限を数える", func(t *testing.T) {
+		repos, user, sut := setup(t, 10000)
+		cola := product(t, repos, "コーラ", 100, 10)
+		soda := product(t, repos, "サイダー", 100, 10)
+		rule, err := entities.NewPricingRule("飲み物お一人様3本まで", nil, "drink", entities.PricingDiscountPercentage, 10, "", "", 3, time.Now().Add(-time.Minute), nil, uuid.New())
+		require.NoError(t, err)
+		require.NoError(t, repos.PricingRules.Create(ctx, rule))
+		add(t, sut, user, cola, 2)
+		add(t, sut, user, soda, 2)
+
+		_, err = sut.Checkout(ctx, &inputport.CheckoutCartRequest{UserID: user.ID})
+		assert.ErrorIs(t, err, entities.ErrSaleLimitExceeded)
+		var domainErr *entities.DomainError
+		require.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, 1, domainErr.Params["remaining"])
+	})
+
+	t.Run("交換できない商品があれば何も交換せず、カートも残す", func(t *testing.T) {
+		repos, user, sut := setup(t, 1000)
+		cola := product(t, repos, "コーラ", 100, 10)
+		tea := product(t, repos, "お茶", 80, 5)
+		add(t, sut, user, cola, 1)
+		add(t, sut, user, tea, 5)
+
+		// カートに入れた後で在庫が減った
+		tea.Stock = 1
+		require.NoError(t, repos.Products.Update(ctx, tea))
+
+		_, err := sut.Checkout(ctx, &inputport.CheckoutCartRequest{UserID: user.ID})
+		assert.ErrorIs(t, err, entities.ErrCartItemUnavailable)
+		var domainErr *entities.DomainError
+		require.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, "お茶", domainErr.Params["product"])
+
+		stored, err := repos.Products.Read(ctx, cola.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 10, stored.Stock)
+		assert.Equal(t, 0, repos.ProductExchanges.Calls("Create"))
+		assert.Equal(t, 0, repos.Transactions.Calls("Create"))
+		cart, err := sut.GetCart(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Len(t, cart.Lines, 2)
+		assert.False(t, cart.Lines[1].Available)
+		assert.Equal(t, int64(100), cart.TotalPoints, "交換できない商品は合計に含めない")
+	})
+
+	t.Run("合計が残高を超えるとErrInsufficientBalance", func(t *testing.T) {
+		repos, user, sut := setup(t, 250)
+		add(t, sut, user, product(t, repos, "コーラ", 100, 10), 2)
+		add(t, sut, user, product(t, repos, "お茶", 80, 10), 1)
+
+		_, err := sut.Checkout(ctx, &inputport.CheckoutCartRequest{UserID: user.ID})
+		assert.ErrorIs(t, err, entities.ErrInsufficientBalance)
+		stored, err := repos.Users.Read(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(250), stored.Balance)
+	})
+
+	t.Run("空のカートはErrCartEmpty", func(t *testing.T) {
+		_, user, sut := setup(t, 1000)
+		_, err := sut.Checkout(ctx, &inputport.CheckoutCartRequest{UserID: user.ID})
+		assert.ErrorIs(t, err, entities.ErrCartEmpty)
+	})
+}
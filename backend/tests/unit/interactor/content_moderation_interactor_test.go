@@ -0,0 +1,172 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockContentModeration は指定した項目だけを拒否する ContentModerationInputPort のモック
+type mockContentModeration struct {
+	rejectFields map[entities.ModerationField]bool
+	checked      []entities.ModerationField
+}
+
+func (m *mockContentModeration) CheckContent(ctx context.Context, userID uuid.UUID, field entities.ModerationField, text string) error {
+	m.checked = append(m.checked, field)
+	if m.rejectFields[field] {
+		return entities.ErrContentRejected.WithParams(map[string]interface{}{"field": string(field)})
+	}
+	return nil
+}
+func (m *mockContentModeration) GetViolations(ctx context.Context, req *inputport.GetContentViolationsRequest) (*inputport.GetContentViolationsResponse, error) {
+	return &inputport.GetContentViolationsResponse{}, nil
+}
+func (m *mockContentModeration) ReviewViolation(ctx context.Context, req *inputport.ReviewContentViolationRequest) (*entities.ContentViolation, error) {
+	return nil, entities.ErrViolationNotFound
+}
+
+// mockContentModerator は禁止語リストで判定する ContentModerator のモック
+type mockContentModerator struct {
+	terms []string
+}
+
+func (m *mockContentModerator) Moderate(ctx context.Context, field entities.ModerationField, text string) (*entities.ModerationVerdict, error) {
+	if matched := entities.MatchModerationTerms(text, m.terms); len(matched) > 0 {
+		return &entities.ModerationVerdict{Allowed: false, MatchedTerms: matched}, nil
+	}
+	return entities.AllowedVerdict(), nil
+}
+
+// mockContentViolationRepo は違反記録をメモリに持つ ContentViolationRepository のモック
+type mockContentViolationRepo struct {
+	violations []*entities.ContentViolation
+}
+
+func (m *mockContentViolationRepo) Create(ctx context.Context, v *entities.ContentViolation) error {
+	m.violations = append(m.violations, v)
+	return nil
+}
+func (m *mockContentViolationRepo) Read(ctx context.Context, id uuid.UUID) (*entities.ContentViolation, error) {
+	for _, v := range m.violations {
+		if v.ID == id {
+			return v, nil
+		}
+	}
+	return nil, entities.ErrViolationNotFound
+}
+func (m *mockContentViolationRepo) ReadList(ctx context.Context, unreviewedOnly bool, offset, limit int) ([]*entities.ContentViolation, error) {
+	result := make([]*entities.ContentViolation, 0)
+	for _, v := range m.filter(unreviewedOnly) {
+		if offset > 0 {
+			offset--
+			continue
+		}
+		if len(result) < limit {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+func (m *mockContentViolationRepo) Count(ctx context.Context, unreviewedOnly bool) (int64, error) {
+	return int64(len(m.filter(unreviewedOnly))), nil
+}
+func (m *mockContentViolationRepo) UpdateReviewed(ctx context.Context, v *entities.ContentViolation) error {
+	return nil
+}
+func (m *mockContentViolationRepo) filter(unreviewedOnly bool) []*entities.ContentViolation {
+	result := make([]*entities.ContentViolation, 0)
+	for _, v := range m.violations {
+		if !unreviewedOnly || !v.IsReviewed() {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func setupContentModerationInteractor() (*mockContentViolationRepo, *ctxTrackingUserRepo, inputport.ContentModerationInputPort) {
+	violationRepo := &mockContentViolationRepo{}
+	userRepo := newCtxTrackingUserRepo()
+	sut := interactor.NewContentModerationInteractor(
+		&mockContentModerator{terms: []string{"badword"}}, violationRepo, userRepo, &mockLogger{},
+	)
+	return violationRepo, userRepo, sut
+}
+
+// --- CheckContent ---
+
+func TestContentModerationInteractor_CheckContent(t *testing.T) {
+	t.Run("問題のないテキストは許可される", func(t *testing.T) {
+		violationRepo, _, sut := setupContentModerationInteractor()
+
+		err := sut.CheckContent(context.Background(), uuid.New(), entities.ModerationFieldDisplayName, "たろう")
+		assert.NoError(t, err)
+		assert.Empty(t, violationRepo.violations)
+	})
+
+	t.Run("禁止語を含むテキストは拒否され違反が記録される", func(t *testing.T) {
+		violationRepo, _, sut := setupContentModerationInteractor()
+		userID := uuid.New()
+
+		err := sut.CheckContent(context.Background(), userID, entities.ModerationFieldTransferMessage, "you B.A.D word")
+		require.ErrorIs(t, err, entities.ErrContentRejected)
+		de, ok := entities.AsDomainError(err)
+		require.True(t, ok)
+		assert.Equal(t, "transfer_message", de.Params["field"])
+
+		require.Len(t, violationRepo.violations, 1)
+		v := violationRepo.violations[0]
+		assert.Equal(t, userID, v.UserID)
+		assert.Equal(t, entities.ModerationFieldTransferMessage, v.Field)
+		assert.Equal(t, []string{"badword"}, v.MatchedTerms)
+	})
+
+	t.Run("空のテキストは判定しない", func(t *testing.T) {
+		_, _, sut := setupContentModerationInteractor()
+
+		err := sut.CheckContent(context.Background(), uuid.New(), entities.ModerationFieldLastName, "  ")
+		assert.NoError(t, err)
+	})
+}
+
+// --- GetViolations / ReviewViolation ---
+
+func TestContentModerationInteractor_ReviewViolation(t *testing.T) {
+	t.Run("管理者はレビュー済みにでき未レビュー一覧から外れる", func(t *testing.T) {
+		violationRepo, userRepo, sut := setupContentModerationInteractor()
+		admin := createTestUserWithBalance(t, "moderator", 0, "admin")
+		userRepo.setUser(admin)
+		require.Error(t, sut.CheckContent(context.Background(), uuid.New(), entities.ModerationFieldDisplayName, "badword"))
+
+		list, err := sut.GetViolations(context.Background(), &inputport.GetContentViolationsRequest{AdminID: admin.ID, UnreviewedOnly: true})
+		require.NoError(t, err)
+		require.Len(t, list.Violations, 1)
+		assert.Equal(t, 20, list.Limit)
+
+		reviewed, err := sut.ReviewViolation(context.Background(), &inputport.ReviewContentViolationRequest{
+			AdminID: admin.ID, ViolationID: violationRepo.violations[0].ID,
+		})
+		require.NoError(t, err)
+		assert.True(t, reviewed.IsReviewed())
+		assert.Equal(t, admin.ID, *reviewed.ReviewedBy)
+
+		list, err = sut.GetViolations(context.Background(), &inputport.GetContentViolationsRequest{AdminID: admin.ID, UnreviewedOnly: true})
+		require.NoError(t, err)
+		assert.Empty(t, list.Violations)
+	})
+
+	t.Run("一般ユーザーは閲覧できない", func(t *testing.T) {
+		_, userRepo, sut := setupContentModerationInteractor()
+		user := createTestUserWithBalance(t, "someone", 0, "user")
+		userRepo.setUser(user)
+
+		_, err := sut.GetViolations(context.Background(), &inputport.GetContentViolationsRequest{AdminID: user.ID})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
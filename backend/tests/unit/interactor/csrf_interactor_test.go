@@ -0,0 +1,113 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSRFInteractor(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T, rotation entities.CSRFRotationMode) (*testsupport.Repositories, inputport.CSRFInputPort, *entities.Session) {
+		t.Helper()
+		repos := testsupport.New()
+		policy := entities.CSRFPolicy{Rotation: rotation, TTL: time.Hour, Grace: time.Minute}
+		sut := interactor.NewCSRFInteractor(repos.Sessions, policy, &mockLogger{})
+
+		session, err := entities.NewSession(uuid.New(), "203.0.113.10", "Laptop")
+		require.NoError(t, err)
+		require.NoError(t, repos.Sessions.Create(ctx, session))
+		return repos, sut, session
+	}
+	stored := func(t *testing.T, repos *testsupport.Repositories, session *entities.Session) *entities.Session {
+		t.Helper()
+		s, err := repos.Sessions.Read(ctx, session.ID)
+		require.NoError(t, err)
+		return s
+	}
+
+	t.Run("offではトークンを入れ替えない", func(t *testing.T) {
+		repos, sut, session := setup(t, entities.CSRFRotationOff)
+
+		resp, err := sut.VerifyToken(ctx, &inputport.VerifyCSRFTokenRequest{Session: stored(t, repos, session), Token: session.CSRFToken, Now: time.Now()})
+		require.NoError(t, err)
+		assert.Equal(t, session.CSRFToken, resp.Token)
+		assert.False(t, resp.Rotated)
+		assert.Nil(t, resp.ExpiresAt)
+	})
+
+	t.Run("per_requestでは検証のたびに入れ替え、並行したリクエストの前のトークンも受け付ける", func(t *testing.T) {
+		repos, sut, session := setup(t, entities.CSRFRotationPerRequest)
+		now := time.Now()
+
+		resp, err := sut.VerifyToken(ctx, &inputport.VerifyCSRFTokenRequest{Session: stored(t, repos, session), Token: session.CSRFToken, Now: now})
+		require.NoError(t, err)
+		assert.True(t, resp.Rotated)
+		assert.NotEqual(t, session.CSRFToken, resp.Token)
+		assert.Equal(t, resp.Token, stored(t, repos, session).CSRFToken)
+
+		// 入れ替える前に送られたリクエストは猶予期間内なら通し、トークンは入れ替えない
+		concurrent, err := sut.VerifyToken(ctx, &inputport.VerifyCSRFTokenRequest{Session: stored(t, repos, session), Token: session.CSRFToken, Now: now.Add(time.Second)})
+		require.NoError(t, err)
+		assert.False(t, concurrent.Rotated)
+		assert.Equal(t, resp.Token, concurrent.Token)
+
+		_, err = sut.VerifyToken(ctx, &inputport.VerifyCSRFTokenRequest{Session: stored(t, repos, session), Token: session.CSRFToken, Now: now.Add(2 * time.Minute)})
+		assert.ErrorIs(t, err, entities.ErrInvalidCSRFToken)
+	})
+
+	t.Run("同時に入れ替えたら先に保存したトークンを返す", func(t *testing.T) {
+		repos, sut, session := setup(t, entities.CSRFRotationPerRequest)
+		now := time.Now()
+		first, second := stored(t, repos, session), stored(t, repos, session)
+
+		a, err := sut.VerifyToken(ctx, &inputport.VerifyCSRFTokenRequest{Session: first, Token: session.CSRFToken, Now: now})
+		require.NoError(t, err)
+		b, err := sut.VerifyToken(ctx, &inputport.VerifyCSRFTokenRequest{Session: second, Token: session.CSRFToken, Now: now})
+		require.NoError(t, err)
+		assert.Equal(t, a.Token, b.Token)
+		assert.Equal(t, a.Token, stored(t, repos, session).CSRFToken)
+	})
+
+	t.Run("入れ替えの保存に失敗しても検証済みのリクエストは通す", func(t *testing.T) {
+		repos, sut, session := setup(t, entities.CSRFRotationPerRequest)
+		repos.Sessions.FailOn("RotateCSRFToken", assert.AnError)
+
+		resp, err := sut.VerifyToken(ctx, &inputport.VerifyCSRFTokenRequest{Session: stored(t, repos, session), Token: session.CSRFToken, Now: time.Now()})
+		require.NoError(t, err)
+		assert.False(t, resp.Rotated)
+		assert.Equal(t, session.CSRFToken, resp.Token)
+	})
+
+	t.Run("periodicでは期限切れのトークンを拒否し、取得し直すと入れ替える", func(t *testing.T) {
+		repos, sut, session := setup(t, entities.CSRFRotationPeriodic)
+		expired := session.CSRFIssuedAt.Add(time.Hour)
+
+		_, err := sut.VerifyToken(ctx, &inputport.VerifyCSRFTokenRequest{Session: stored(t, repos, session), Token: session.CSRFToken, Now: expired})
+		assert.ErrorIs(t, err, entities.ErrCSRFTokenExpired)
+
+		current, err := sut.IssueToken(ctx, &inputport.IssueCSRFTokenRequest{Session: stored(t, repos, session), Now: session.CSRFIssuedAt.Add(time.Minute)})
+		require.NoError(t, err)
+		assert.False(t, current.Rotated, "期限内なら今のトークンを返す")
+		require.NotNil(t, current.ExpiresAt)
+		assert.Equal(t, session.CSRFIssuedAt.Add(time.Hour), *current.ExpiresAt)
+
+		issued, err := sut.IssueToken(ctx, &inputport.IssueCSRFTokenRequest{Session: stored(t, repos, session), Now: expired})
+		require.NoError(t, err)
+		assert.True(t, issued.Rotated)
+		assert.NotEqual(t, session.CSRFToken, issued.Token)
+		assert.Equal(t, expired.Add(time.Hour), *issued.ExpiresAt)
+
+		_, err = sut.VerifyToken(ctx, &inputport.VerifyCSRFTokenRequest{Session: stored(t, repos, session), Token: issued.Token, Now: expired.Add(time.Second)})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,101 @@
+package interactor_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDailyBonusReports(t *testing.T) {
+	ctx := context.Background()
+
+	admin := createTestUserWithBalance(t, "admin", 0, entities.RoleAdmin)
+
+	setup := func(t *testing.T) (*testsupport.Repositories, *interactor.DailyBonusInteractor, *entities.User) {
+		repos := testsupport.New()
+		user := createTestUserWithBalance(t, "yamada", 0, entities.RoleUser)
+		user.DisplayName = "=山田"
+		repos.Users.Seed(admin, user)
+		sut := interactor.NewDailyBonusInteractor(
+			repos.DailyBonuses, repos.Users, repos.Transactions, repos.TxManager, repos.SystemSettings,
+			repos.PointBatches, repos.LotteryTiers, repos.FailedAkerunAccesses, repos.ProcessedAccesses,
+			entities.AkerunOrganizations{}, &mockReferralTracker{}, &mockLogger{},
+		)
+		return repos, sut, user
+	}
+	seedBonus := func(t *testing.T, repos *testsupport.Repositories, user *entities.User, day time.Time, points int64) {
+		t.Helper()
+		accessedAt := day.Add(-time.Hour) // ボーナス日のJST 8時
+		bonus := entities.NewDailyBonus(user.ID, day, points, "access-"+day.Format("0102"), "山田", &accessedAt, nil, "大当たり")
+		bonus.AkerunDoorName = "正面玄関"
+		require.NoError(t, repos.DailyBonuses.Create(ctx, bonus))
+	}
+
+	t.Run("期間内のボーナスをボーナス日の順にCSVで出力する", func(t *testing.T) {
+		repos, sut, user := setup(t)
+		seedBonus(t, repos, user, time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC), 10)
+		seedBonus(t, repos, user, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), 5)
+		seedBonus(t, repos, user, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), 3)
+
+		var buf bytes.Buffer
+		require.NoError(t, sut.ExportBonuses(ctx, &inputport.ExportBonusesRequest{AdminID: admin.ID, From: "2024-04-01", To: "2024-04-30"}, &buf))
+
+		require.True(t, strings.HasPrefix(buf.String(), "\uFEFF"), "Excel向けにBOMを付ける")
+		records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(buf.String(), "\uFEFF"))).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, "user_id", records[0][0])
+		assert.Equal(t, []string{"2024-04-01", "5"}, records[1][3:5])
+		assert.Equal(t, []string{"2024-04-02", "10"}, records[2][3:5])
+		assert.Equal(t, "'=山田", records[1][2], "数式として解釈される値はエスケープする")
+		assert.Equal(t, "正面玄関", records[1][9])
+		assert.Equal(t, "2024-04-01T08:00:00+09:00", records[1][10])
+	})
+
+	t.Run("期間が不正なら何も書かずにエラーを返す", func(t *testing.T) {
+		_, sut, _ := setup(t)
+		var buf bytes.Buffer
+		err := sut.ExportBonuses(ctx, &inputport.ExportBonusesRequest{AdminID: admin.ID, From: "2024-04-30", To: "2024-04-01"}, &buf)
+		assert.True(t, errors.Is(err, entities.ErrInvalidBonusReportQuery))
+		assert.Zero(t, buf.Len())
+	})
+
+	t.Run("月の出席表を作る", func(t *testing.T) {
+		repos, sut, user := setup(t)
+		seedBonus(t, repos, user, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), 5)
+		seedBonus(t, repos, user, time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC), 10)
+
+		matrix, err := sut.GetAttendanceMatrix(ctx, &inputport.GetAttendanceMatrixRequest{AdminID: admin.ID, Month: "2024-04"})
+		require.NoError(t, err)
+		require.Len(t, matrix.Rows, 1)
+		row := matrix.Rows[0]
+		assert.Equal(t, user.ID, row.UserID)
+		assert.Len(t, row.Attended, 30)
+		assert.Equal(t, []bool{true, false, true}, row.Attended[:3])
+		assert.Equal(t, 2, row.AttendedDays)
+		assert.Equal(t, int64(15), row.TotalPoints)
+	})
+
+	t.Run("管理者以外はCSVの出力も出席表の取得もできない", func(t *testing.T) {
+		_, sut, user := setup(t)
+
+		var buf bytes.Buffer
+		err := sut.ExportBonuses(ctx, &inputport.ExportBonusesRequest{AdminID: user.ID, From: "2024-04-01", To: "2024-04-30"}, &buf)
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		assert.Zero(t, buf.Len())
+
+		_, err = sut.GetAttendanceMatrix(ctx, &inputport.GetAttendanceMatrixRequest{AdminID: user.ID, Month: "2024-04"})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
@@ -0,0 +1,284 @@
+package interactor_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockDataExportRepo はエクスポート依頼をメモリに持つ DataExportRepository のモック
+type mockDataExportRepo struct {
+	exports map[uuid.UUID]*entities.DataExport
+}
+
+func newMockDataExportRepo() *mockDataExportRepo {
+	return &mockDataExportRepo{exports: make(map[uuid.UUID]*entities.DataExport)}
+}
+
+func (m *mockDataExportRepo) Create(ctx context.Context, export *entities.DataExport) error {
+	m.exports[export.ID] = export
+	return nil
+}
+func (m *mockDataExportRepo) Read(ctx context.Context, id uuid.UUID) (*entities.DataExport, error) {
+	e, ok := m.exports[id]
+	if !ok {
+		return nil, entities.ErrDataExportNotFound
+	}
+	return e, nil
+}
+func (m *mockDataExportRepo) ReadLatestByUser(ctx context.Context, userID uuid.UUID) (*entities.DataExport, error) {
+	var latest *entities.DataExport
+	for _, e := range m.exports {
+		if e.UserID == userID && (latest == nil || e.RequestedAt.After(latest.RequestedAt)) {
+			latest = e
+		}
+	}
+	return latest, nil
+}
+func (m *mockDataExportRepo) ClaimPending(ctx context.Context, limit int) ([]*entities.DataExport, error) {
+	result := make([]*entities.DataExport, 0)
+	for _, e := range m.exports {
+		if e.Status == entities.DataExportStatusPending && len(result) < limit {
+			e.Status = entities.DataExportStatusProcessing
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+func (m *mockDataExportRepo) Update(ctx context.Context, export *entities.DataExport) error {
+	m.exports[export.ID] = export
+	return nil
+}
+func (m *mockDataExportRepo) ReadListExpired(ctx context.Context, now time.Time, limit int) ([]*entities.DataExport, error) {
+	result := make([]*entities.DataExport, 0)
+	for _, e := range m.exports {
+		if e.Status == entities.DataExportStatusReady && !now.Before(*e.ExpiresAt) && len(result) < limit {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+// mockDataExportStorage はアーカイブをメモリに持つ DataExportStorage のモック
+type mockDataExportStorage struct {
+	files   map[string]map[string][]byte
+	saveErr error
+}
+
+func newMockDataExportStorage() *mockDataExportStorage {
+	return &mockDataExportStorage{files: make(map[string]map[string][]byte)}
+}
+
+func (m *mockDataExportStorage) Save(exportID string, files map[string][]byte) (string, error) {
+	if m.saveErr != nil {
+		return "", m.saveErr
+	}
+	path := exportID + ".zip"
+	m.files[path] = files
+	return path, nil
+}
+func (m *mockDataExportStorage) Open(path string) (io.ReadCloser, error) {
+	files, ok := m.files[path]
+	if !ok {
+		return nil, errors.New("file not found")
+	}
+	return io.NopCloser(bytes.NewReader(files["data.json"])), nil
+}
+func (m *mockDataExportStorage) Delete(path string) error {
+	delete(m.files, path)
+	return nil
+}
+
+type dataExportDeps struct {
+	exportRepo   *mockDataExportRepo
+	userRepo     *ctxTrackingUserRepo
+	txRepo       *ctxTrackingTransactionRepo
+	bonusRepo    *abMockDailyBonusRepo
+	exchangeRepo *mockExchangeRepo
+	storage      *mockDataExportStorage
+	emailService *mockEmailService
+}
+
+func setupDataExportInteractor() (*dataExportDeps, inputport.DataExportInputPort) {
+	d := &dataExportDeps{
+		exportRepo:   newMockDataExportRepo(),
+		userRepo:     newCtxTrackingUserRepo(),
+		txRepo:       newCtxTrackingTransactionRepo(),
+		bonusRepo:    newABMockDailyBonusRepo(),
+		exchangeRepo: newMockExchangeRepo(),
+		storage:      newMockDataExportStorage(),
+		emailService: &mockEmailService{},
+	}
+	sut := interactor.NewDataExportInteractor(
+		d.exportRepo, d.userRepo, d.txRepo, d.bonusRepo, newMockFriendshipRepo(),
+		d.exchangeRepo, d.storage, d.emailService, &mockLogger{},
+	)
+	return d, sut
+}
+
+// --- RequestExport ---
+
+func TestDataExportInteractor_RequestExport(t *testing.T) {
+	t.Run("依頼は処理待ちで作成される", func(t *testing.T) {
+		_, sut := setupDataExportInteractor()
+		userID := uuid.New()
+
+		export, err := sut.RequestExport(context.Background(), userID)
+		require.NoError(t, err)
+		assert.Equal(t, userID, export.UserID)
+		assert.Equal(t, entities.DataExportStatusPending, export.Status)
+	})
+
+	t.Run("処理中の依頼がある場合はエラー", func(t *testing.T) {
+		_, sut := setupDataExportInteractor()
+		userID := uuid.New()
+
+		_, err := sut.RequestExport(context.Background(), userID)
+		require.NoError(t, err)
+
+		_, err = sut.RequestExport(context.Background(), userID)
+		assert.ErrorIs(t, err, entities.ErrDataExportInProgress)
+	})
+
+	t.Run("前回の完了から間隔が空いていない場合はエラー", func(t *testing.T) {
+		d, sut := setupDataExportInteractor()
+		userID := uuid.New()
+		prev := entities.NewDataExport(userID)
+		prev.MarkReady("prev.zip", time.Now())
+		d.exportRepo.exports[prev.ID] = prev
+
+		_, err := sut.RequestExport(context.Background(), userID)
+		assert.ErrorIs(t, err, entities.ErrDataExportTooSoon)
+	})
+
+	t.Run("前回が失敗した場合はすぐにやり直せる", func(t *testing.T) {
+		d, sut := setupDataExportInteractor()
+		userID := uuid.New()
+		prev := entities.NewDataExport(userID)
+		prev.MarkFailed("boom", time.Now())
+		d.exportRepo.exports[prev.ID] = prev
+
+		_, err := sut.RequestExport(context.Background(), userID)
+		assert.NoError(t, err)
+	})
+}
+
+// --- ProcessPendingExports ---
+
+func TestDataExportInteractor_ProcessPendingExports(t *testing.T) {
+	t.Run("個人データをまとめて保存し完了メールを送る", func(t *testing.T) {
+		d, sut := setupDataExportInteractor()
+		user := createTestUserWithBalance(t, "export_me", 1200, "user")
+		d.userRepo.setUser(user)
+		require.NoError(t, d.bonusRepo.Create(context.Background(), &entities.DailyBonus{
+			ID: uuid.New(), UserID: user.ID, BonusDate: time.Now(), BonusPoints: 5,
+		}))
+		exchange, err := entities.NewProductExchange(user.ID, uuid.New(), 1, 100, "")
+		require.NoError(t, err)
+		d.exchangeRepo.exchanges[exchange.ID] = exchange
+
+		export, err := sut.RequestExport(context.Background(), user.ID)
+		require.NoError(t, err)
+
+		completed, err := sut.ProcessPendingExports(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, completed)
+		assert.Equal(t, entities.DataExportStatusReady, export.Status)
+		assert.NotNil(t, export.ExpiresAt)
+		assert.Equal(t, []string{user.Email}, d.emailService.dataExportAddrs)
+
+		var data entities.PersonalDataExport
+		require.NoError(t, json.Unmarshal(d.storage.files[export.FilePath]["data.json"], &data))
+		assert.Equal(t, user.Username, data.Profile.Username)
+		assert.Equal(t, int64(1200), data.Profile.Balance)
+		assert.Len(t, data.DailyBonuses, 1)
+		assert.Len(t, data.Exchanges, 1)
+	})
+
+	t.Run("作成に失敗した依頼は失敗として記録する", func(t *testing.T) {
+		d, sut := setupDataExportInteractor()
+		user := createTestUserWithBalance(t, "export_me", 0, "user")
+		d.userRepo.setUser(user)
+		d.storage.saveErr = errors.New("disk full")
+
+		export, err := sut.RequestExport(context.Background(), user.ID)
+		require.NoError(t, err)
+
+		completed, err := sut.ProcessPendingExports(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Equal(t, 0, completed)
+		assert.Equal(t, entities.DataExportStatusFailed, export.Status)
+		assert.Empty(t, d.emailService.dataExportAddrs)
+	})
+}
+
+// --- OpenExport ---
+
+func TestDataExportInteractor_OpenExport(t *testing.T) {
+	t.Run("本人は作成済みのファイルを開ける", func(t *testing.T) {
+		d, sut := setupDataExportInteractor()
+		user := createTestUserWithBalance(t, "export_me", 0, "user")
+		d.userRepo.setUser(user)
+		export, err := sut.RequestExport(context.Background(), user.ID)
+		require.NoError(t, err)
+		_, err = sut.ProcessPendingExports(context.Background(), 10)
+		require.NoError(t, err)
+
+		resp, err := sut.OpenExport(context.Background(), user.ID, export.ID)
+		require.NoError(t, err)
+		defer resp.Content.Close()
+		body, err := io.ReadAll(resp.Content)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), user.Username)
+	})
+
+	t.Run("他人の依頼は見つからない扱い", func(t *testing.T) {
+		d, sut := setupDataExportInteractor()
+		user := createTestUserWithBalance(t, "export_me", 0, "user")
+		d.userRepo.setUser(user)
+		export, err := sut.RequestExport(context.Background(), user.ID)
+		require.NoError(t, err)
+		_, err = sut.ProcessPendingExports(context.Background(), 10)
+		require.NoError(t, err)
+
+		_, err = sut.OpenExport(context.Background(), uuid.New(), export.ID)
+		assert.ErrorIs(t, err, entities.ErrDataExportNotFound)
+	})
+
+	t.Run("作成前はダウンロードできない", func(t *testing.T) {
+		_, sut := setupDataExportInteractor()
+		userID := uuid.New()
+		export, err := sut.RequestExport(context.Background(), userID)
+		require.NoError(t, err)
+
+		_, err = sut.OpenExport(context.Background(), userID, export.ID)
+		assert.ErrorIs(t, err, entities.ErrDataExportNotReady)
+	})
+}
+
+// --- CleanupExpiredExports ---
+
+func TestDataExportInteractor_CleanupExpiredExports(t *testing.T) {
+	d, sut := setupDataExportInteractor()
+	export := entities.NewDataExport(uuid.New())
+	export.MarkReady("old.zip", time.Now().Add(-entities.DataExportRetention-time.Hour))
+	d.exportRepo.exports[export.ID] = export
+	d.storage.files["old.zip"] = map[string][]byte{"data.json": []byte("{}")}
+
+	cleaned, err := sut.CleanupExpiredExports(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, cleaned)
+	assert.Equal(t, entities.DataExportStatusExpired, export.Status)
+	assert.NotContains(t, d.storage.files, "old.zip")
+}
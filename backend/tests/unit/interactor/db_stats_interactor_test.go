@@ -0,0 +1,58 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubDBStatsMonitor は決まった状態を返すDBStatsMonitor
+type stubDBStatsMonitor struct {
+	stats []service.DBPoolStats
+}
+
+func (m *stubDBStatsMonitor) DBStats() []service.DBPoolStats { return m.stats }
+
+// ========================================
+// DBStatsInteractor テスト
+// ========================================
+
+func TestDBStatsInteractor(t *testing.T) {
+	ctx := context.Background()
+	monitor := &stubDBStatsMonitor{stats: []service.DBPoolStats{
+		{Name: "primary", MaxOpenConnections: 100, OpenConnections: 12, InUse: 3, Idle: 9, WaitCount: 4, WaitDuration: 250 * time.Millisecond},
+		{Name: "replica", MaxOpenConnections: 100, OpenConnections: 2, Idle: 2},
+	}}
+
+	setup := func(t *testing.T) (*testsupport.Repositories, *entities.User, *entities.User) {
+		repos := testsupport.New()
+		admin := createTestUserWithBalance(t, "admin", 0, entities.RoleAdmin)
+		user := createTestUserWithBalance(t, "user", 0, entities.RoleUser)
+		repos.Users.Seed(admin, user)
+		return repos, admin, user
+	}
+
+	t.Run("管理者はプライマリ・リードレプリカの状態を取得できる", func(t *testing.T) {
+		repos, admin, _ := setup(t)
+		sut := interactor.NewDBStatsInteractor(repos.Users, monitor)
+
+		stats, err := sut.GetDBStats(ctx, admin.ID)
+		require.NoError(t, err)
+		assert.Equal(t, monitor.stats, stats)
+	})
+
+	t.Run("管理者以外は取得できない", func(t *testing.T) {
+		repos, _, user := setup(t)
+		sut := interactor.NewDBStatsInteractor(repos.Users, monitor)
+
+		_, err := sut.GetDBStats(ctx, user.ID)
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
@@ -0,0 +1,301 @@
+package interactor_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ========================================
+// Mock DepartmentRepository
+// ========================================
+
+type mockDepartmentRepo struct {
+	departments map[uuid.UUID]*entities.Department
+	members     map[uuid.UUID]*uuid.UUID // ユーザーID → 所属部署
+	used        map[uuid.UUID]int64      // 部署ID → 当月の管理者付与の合計
+	breakdown   []*entities.DepartmentBreakdownResult
+}
+
+func newMockDepartmentRepo() *mockDepartmentRepo {
+	return &mockDepartmentRepo{
+		departments: make(map[uuid.UUID]*entities.Department),
+		members:     make(map[uuid.UUID]*uuid.UUID),
+		used:        make(map[uuid.UUID]int64),
+	}
+}
+
+// add は部署を登録して返す
+func (m *mockDepartmentRepo) add(t *testing.T, name string, parentID *uuid.UUID, budget *int64) *entities.Department {
+	t.Helper()
+	d, err := entities.NewDepartment(name, parentID, budget)
+	require.NoError(t, err)
+	m.departments[d.ID] = d
+	return d
+}
+
+func (m *mockDepartmentRepo) Create(ctx context.Context, department *entities.Department) error {
+	for _, d := range m.departments {
+		if d.Name == department.Name && equalDepartmentParent(d.ParentID, department.ParentID) {
+			return entities.ErrDepartmentExists
+		}
+	}
+	m.departments[department.ID] = department
+	return nil
+}
+func (m *mockDepartmentRepo) Read(ctx context.Context, id uuid.UUID) (*entities.Department, error) {
+	d, ok := m.departments[id]
+	if !ok {
+		return nil, entities.ErrDepartmentNotFound
+	}
+	copy := *d
+	return &copy, nil
+}
+func (m *mockDepartmentRepo) ReadForUpdate(ctx context.Context, id uuid.UUID) (*entities.Department, error) {
+	return m.Read(ctx, id)
+}
+func (m *mockDepartmentRepo) ReadList(ctx context.Context) ([]*entities.Department, error) {
+	result := make([]*entities.Department, 0, len(m.departments))
+	for _, d := range m.departments {
+		copy := *d
+		result = append(result, &copy)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+func (m *mockDepartmentRepo) Update(ctx context.Context, department *entities.Department) error {
+	if _, ok := m.departments[department.ID]; !ok {
+		return entities.ErrDepartmentNotFound
+	}
+	m.departments[department.ID] = department
+	return nil
+}
+func (m *mockDepartmentRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, ok := m.departments[id]; !ok {
+		return entities.ErrDepartmentNotFound
+	}
+	for _, d := range m.departments {
+		if d.ParentID != nil && *d.ParentID == id {
+			return entities.ErrDepartmentHasChildren
+		}
+	}
+	delete(m.departments, id)
+	for userID, deptID := range m.members {
+		if deptID != nil && *deptID == id {
+			m.members[userID] = nil
+		}
+	}
+	return nil
+}
+func (m *mockDepartmentRepo) SetUserDepartment(ctx context.Context, userID uuid.UUID, departmentID *uuid.UUID) error {
+	m.members[userID] = departmentID
+	return nil
+}
+func (m *mockDepartmentRepo) SumAdminGrantsSince(ctx context.Context, departmentID uuid.UUID, since time.Time) (int64, error) {
+	return m.used[departmentID], nil
+}
+func (m *mockDepartmentRepo) ReadBreakdown(ctx context.Context, since time.Time) ([]*entities.DepartmentBreakdownResult, error) {
+	return m.breakdown, nil
+}
+
+func equalDepartmentParent(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// ========================================
+// DepartmentInteractor テスト
+// ========================================
+
+func TestDepartmentInteractor(t *testing.T) {
+	setup := func() (inputport.DepartmentInputPort, *mockDepartmentRepo, *ctxTrackingUserRepo, *entities.User, *entities.User) {
+		userRepo := newCtxTrackingUserRepo()
+		admin := createTestUserWithBalance(t, "admin", 0, "admin")
+		user := createTestUserWithBalance(t, "user", 0, "user")
+		userRepo.setUser(admin)
+		userRepo.setUser(user)
+		repo := newMockDepartmentRepo()
+		return interactor.NewDepartmentInteractor(repo, userRepo, &mockLogger{}), repo, userRepo, admin, user
+	}
+
+	t.Run("管理者は親を指定して部署を作成できる", func(t *testing.T) {
+		sut, repo, _, admin, _ := setup()
+		org := repo.add(t, "本社", nil, nil)
+		budget := int64(10000)
+
+		created, err := sut.CreateDepartment(context.Background(), &inputport.CreateDepartmentRequest{
+			AdminID: admin.ID, Name: " 開発部 ", ParentID: &org.ID, MonthlyGrantBudget: &budget,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "開発部", created.Name)
+		assert.Equal(t, org.ID, *created.ParentID)
+		assert.Equal(t, budget, *created.MonthlyGrantBudget)
+		assert.Contains(t, repo.departments, created.ID)
+	})
+
+	t.Run("作成時のエラー", func(t *testing.T) {
+		sut, repo, _, admin, user := setup()
+		repo.add(t, "本社", nil, nil)
+		missing := uuid.New()
+		negative := int64(-1)
+
+		_, err := sut.CreateDepartment(context.Background(), &inputport.CreateDepartmentRequest{AdminID: user.ID, Name: "営業部"})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+
+		_, err = sut.CreateDepartment(context.Background(), &inputport.CreateDepartmentRequest{AdminID: admin.ID, Name: "営業部", ParentID: &missing})
+		assert.ErrorIs(t, err, entities.ErrDepartmentNotFound)
+
+		_, err = sut.CreateDepartment(context.Background(), &inputport.CreateDepartmentRequest{AdminID: admin.ID, Name: "営業部", MonthlyGrantBudget: &negative})
+		assert.ErrorIs(t, err, entities.ErrInvalidDepartment)
+
+		_, err = sut.CreateDepartment(context.Background(), &inputport.CreateDepartmentRequest{AdminID: admin.ID, Name: "本社"})
+		assert.ErrorIs(t, err, entities.ErrDepartmentExists)
+	})
+
+	t.Run("配下の部署を親にする更新は循環するので拒否する", func(t *testing.T) {
+		sut, repo, _, admin, _ := setup()
+		org := repo.add(t, "本社", nil, nil)
+		dev := repo.add(t, "開発部", &org.ID, nil)
+
+		_, err := sut.UpdateDepartment(context.Background(), &inputport.UpdateDepartmentRequest{
+			AdminID: admin.ID, DepartmentID: org.ID, Name: "本社", ParentID: &dev.ID,
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidDepartment)
+		assert.Nil(t, repo.departments[org.ID].ParentID, "失敗時は保存しない")
+	})
+
+	t.Run("子部署がある部署は削除できない", func(t *testing.T) {
+		sut, repo, _, admin, _ := setup()
+		org := repo.add(t, "本社", nil, nil)
+		dev := repo.add(t, "開発部", &org.ID, nil)
+
+		err := sut.DeleteDepartment(context.Background(), &inputport.DeleteDepartmentRequest{AdminID: admin.ID, DepartmentID: org.ID})
+		assert.ErrorIs(t, err, entities.ErrDepartmentHasChildren)
+
+		err = sut.DeleteDepartment(context.Background(), &inputport.DeleteDepartmentRequest{AdminID: admin.ID, DepartmentID: dev.ID})
+		require.NoError(t, err)
+		assert.NotContains(t, repo.departments, dev.ID)
+	})
+
+	t.Run("ユーザーの所属部署を設定・解除できる", func(t *testing.T) {
+		sut, repo, _, admin, user := setup()
+		dev := repo.add(t, "開発部", nil, nil)
+
+		updated, err := sut.AssignUserDepartment(context.Background(), &inputport.AssignUserDepartmentRequest{
+			AdminID: admin.ID, UserID: user.ID, DepartmentID: &dev.ID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, dev.ID, *updated.DepartmentID)
+		assert.Equal(t, dev.ID, *repo.members[user.ID])
+
+		updated, err = sut.AssignUserDepartment(context.Background(), &inputport.AssignUserDepartmentRequest{
+			AdminID: admin.ID, UserID: user.ID,
+		})
+		require.NoError(t, err)
+		assert.Nil(t, updated.DepartmentID)
+		assert.Nil(t, repo.members[user.ID])
+
+		missing := uuid.New()
+		_, err = sut.AssignUserDepartment(context.Background(), &inputport.AssignUserDepartmentRequest{
+			AdminID: admin.ID, UserID: user.ID, DepartmentID: &missing,
+		})
+		assert.ErrorIs(t, err, entities.ErrDepartmentNotFound)
+	})
+
+	t.Run("部署別分析は配下の部署を足し上げ、予算の消化状況を返す", func(t *testing.T) {
+		sut, repo, _, admin, _ := setup()
+		budget := int64(5000)
+		org := repo.add(t, "本社", nil, nil)
+		dev := repo.add(t, "開発部", &org.ID, &budget)
+		repo.used[dev.ID] = 1200
+		repo.breakdown = []*entities.DepartmentBreakdownResult{
+			{DepartmentID: org.ID, Name: org.Name, MemberCount: 1, Granted: 100, Spent: 10},
+			{DepartmentID: dev.ID, Name: dev.Name, ParentID: &org.ID, MemberCount: 3, Granted: 2000, Spent: 500},
+		}
+
+		resp, err := sut.GetDepartmentAnalytics(context.Background(), &inputport.GetDepartmentAnalyticsRequest{AdminID: admin.ID, Days: 30})
+		require.NoError(t, err)
+		require.Len(t, resp.Departments, 2)
+
+		top := resp.Departments[0]
+		assert.Equal(t, int64(100), top.Granted)
+		assert.Equal(t, int64(2100), top.TotalGranted)
+		assert.Equal(t, int64(510), top.TotalSpent)
+		assert.Equal(t, int64(4), top.TotalMemberCount)
+		assert.Nil(t, top.MonthlyGrantBudget)
+
+		child := resp.Departments[1]
+		assert.Equal(t, int64(2000), child.TotalGranted)
+		require.NotNil(t, child.MonthlyGrantBudget)
+		assert.Equal(t, int64(1200), child.BudgetUsed)
+	})
+}
+
+// ========================================
+// AdminInteractor 部署の月間予算テスト
+// ========================================
+
+func TestAdminInteractor_GrantPoints_DepartmentBudget(t *testing.T) {
+	setup := func(budget *int64, used int64) (inputport.AdminInputPort, *ctxTrackingTransactionRepo, *entities.User, *entities.User) {
+		userRepo := newCtxTrackingUserRepo()
+		txRepo := newCtxTrackingTransactionRepo()
+		deptRepo := newMockDepartmentRepo()
+		dept := deptRepo.add(t, "開発部", nil, budget)
+		deptRepo.used[dept.ID] = used
+
+		admin := createTestUserWithBalance(t, "admin", 0, "admin")
+		target := createTestUserWithBalance(t, "target", 0, "user")
+		target.DepartmentID = &dept.ID
+		userRepo.setUser(admin)
+		userRepo.setUser(target)
+
+		sut := interactor.NewAdminInteractor(
+			&ctxTrackingTxManager{}, userRepo, txRepo, newCtxTrackingIdempotencyRepo(), newCtxTrackingPointBatchRepo(),
+			newMockReasonCodeRepo(), deptRepo, newMockBudgetRepo(), newMockPendingAdminActionRepo(), newMockSystemSettingsRepo(), &mockAnalyticsDS{}, &mockNotificationDispatcher{}, &mockLogger{},
+		)
+		return sut, txRepo, admin, target
+	}
+	grant := func(sut inputport.AdminInputPort, admin, target *entities.User, amount int64, dryRun bool) error {
+		_, err := sut.GrantPoints(context.Background(), &inputport.GrantPointsRequest{
+			AdminID: admin.ID, UserID: target.ID, Amount: amount, ReasonCode: "reward",
+			Description: "budget", IdempotencyKey: "budget-" + uuid.New().String(), DryRun: dryRun,
+		})
+		return err
+	}
+
+	t.Run("予算の残りの範囲なら付与できる", func(t *testing.T) {
+		budget := int64(1000)
+		sut, txRepo, admin, target := setup(&budget, 700)
+		require.NoError(t, grant(sut, admin, target, 300, false))
+		assert.Len(t, txRepo.transactions, 1)
+	})
+
+	t.Run("予算を超える付与は拒否する", func(t *testing.T) {
+		budget := int64(1000)
+		sut, txRepo, admin, target := setup(&budget, 700)
+		err := grant(sut, admin, target, 301, false)
+		assert.ErrorIs(t, err, entities.ErrDepartmentOverBudget)
+		assert.Empty(t, txRepo.transactions)
+	})
+
+	t.Run("ドライランでも予算を確認する", func(t *testing.T) {
+		budget := int64(1000)
+		sut, _, admin, target := setup(&budget, 1000)
+		assert.ErrorIs(t, grant(sut, admin, target, 1, true), entities.ErrDepartmentOverBudget)
+	})
+
+	t.Run("予算のない部署は上限なく付与できる", func(t *testing.T) {
+		sut, _, admin, target := setup(nil, 1_000_000)
+		assert.NoError(t, grant(sut, admin, target, 500, false))
+	})
+}
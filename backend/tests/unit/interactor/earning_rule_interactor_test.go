@@ -0,0 +1,346 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockEarningEvents は伝えられた出来事を記録する EarningEventRecorder のモック
+type mockEarningEvents struct {
+	events []*entities.EarningEvent
+}
+
+func (m *mockEarningEvents) Record(ctx context.Context, event *entities.EarningEvent) {
+	m.events = append(m.events, event)
+}
+
+// mockEarningRuleRepo は付与回数の条件付き更新と発生元の一意制約を再現する EarningRuleRepository のモック
+type mockEarningRuleRepo struct {
+	rules       map[uuid.UUID]*entities.EarningRule
+	grants      []*entities.EarningRuleGrant
+	occurrences int64
+}
+
+func newMockEarningRuleRepo() *mockEarningRuleRepo {
+	return &mockEarningRuleRepo{rules: make(map[uuid.UUID]*entities.EarningRule)}
+}
+
+func (m *mockEarningRuleRepo) Create(ctx context.Context, rule *entities.EarningRule) error {
+	copy := *rule
+	m.rules[rule.ID] = &copy
+	return nil
+}
+func (m *mockEarningRuleRepo) Read(ctx context.Context, id uuid.UUID) (*entities.EarningRule, error) {
+	r, ok := m.rules[id]
+	if !ok {
+		return nil, entities.ErrEarningRuleNotFound
+	}
+	copy := *r
+	return &copy, nil
+}
+func (m *mockEarningRuleRepo) Update(ctx context.Context, rule *entities.EarningRule) error {
+	r, ok := m.rules[rule.ID]
+	if !ok {
+		return entities.ErrEarningRuleNotFound
+	}
+	count := r.GrantCount
+	copy := *rule
+	copy.GrantCount = count
+	m.rules[rule.ID] = &copy
+	return nil
+}
+func (m *mockEarningRuleRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, ok := m.rules[id]; !ok {
+		return entities.ErrEarningRuleNotFound
+	}
+	delete(m.rules, id)
+	return nil
+}
+func (m *mockEarningRuleRepo) ReadList(ctx context.Context, offset, limit int) ([]*entities.EarningRule, error) {
+	result := make([]*entities.EarningRule, 0, len(m.rules))
+	for _, r := range m.rules {
+		result = append(result, r)
+	}
+	return result, nil
+}
+func (m *mockEarningRuleRepo) Count(ctx context.Context) (int64, error) {
+	return int64(len(m.rules)), nil
+}
+func (m *mockEarningRuleRepo) ReadActiveByTrigger(ctx context.Context, trigger entities.EarningTrigger, now time.Time) ([]*entities.EarningRule, error) {
+	result := make([]*entities.EarningRule, 0)
+	for _, r := range m.rules {
+		if r.IsActive && r.Trigger == trigger {
+			copy := *r
+			result = append(result, &copy)
+		}
+	}
+	return result, nil
+}
+func (m *mockEarningRuleRepo) CountOccurrences(ctx context.Context, userID uuid.UUID, trigger entities.EarningTrigger) (int64, error) {
+	return m.occurrences, nil
+}
+func (m *mockEarningRuleRepo) IncrementGrantCount(ctx context.Context, ruleID uuid.UUID) (bool, error) {
+	r, ok := m.rules[ruleID]
+	if !ok || (r.MaxGrantsTotal != 0 && r.GrantCount >= r.MaxGrantsTotal) {
+		return false, nil
+	}
+	r.GrantCount++
+	return true, nil
+}
+func (m *mockEarningRuleRepo) CountUserGrants(ctx context.Context, ruleID, userID uuid.UUID) (int, error) {
+	count := 0
+	for _, g := range m.grants {
+		if g.RuleID == ruleID && g.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+func (m *mockEarningRuleRepo) CreateGrant(ctx context.Context, grant *entities.EarningRuleGrant) (bool, error) {
+	for _, g := range m.grants {
+		if g.RuleID == grant.RuleID && g.UserID == grant.UserID && g.SourceID == grant.SourceID {
+			return false, nil
+		}
+	}
+	m.grants = append(m.grants, grant)
+	return true, nil
+}
+func (m *mockEarningRuleRepo) ReadStats(ctx context.Context, ruleIDs []uuid.UUID) (map[uuid.UUID]*entities.EarningRuleStats, error) {
+	result := make(map[uuid.UUID]*entities.EarningRuleStats)
+	users := make(map[uuid.UUID]map[uuid.UUID]bool)
+	for _, g := range m.grants {
+		s, ok := result[g.RuleID]
+		if !ok {
+			s = &entities.EarningRuleStats{RuleID: g.RuleID}
+			result[g.RuleID] = s
+			users[g.RuleID] = make(map[uuid.UUID]bool)
+		}
+		s.GrantCount++
+		s.PointsGranted += g.Points
+		users[g.RuleID][g.UserID] = true
+		s.UserCount = int64(len(users[g.RuleID]))
+		createdAt := g.CreatedAt
+		s.LastGrantedAt = &createdAt
+	}
+	return result, nil
+}
+
+type earningRuleDeps struct {
+	ruleRepo *mockEarningRuleRepo
+	userRepo *ctxTrackingUserRepo
+	txRepo   *ctxTrackingTransactionRepo
+	admin    *entities.User
+	user     *entities.User
+}
+
+func setupEarningRuleInteractor(t *testing.T) (*earningRuleDeps, *interactor.EarningRuleInteractor) {
+	userRepo := newCtxTrackingUserRepo()
+	d := &earningRuleDeps{
+		ruleRepo: newMockEarningRuleRepo(),
+		userRepo: userRepo,
+		txRepo:   newCtxTrackingTransactionRepo(),
+		admin:    createTestUserWithBalance(t, "earning_admin", 0, "admin"),
+		user:     createTestUserWithBalance(t, "earning_user", 0, "user"),
+	}
+	userRepo.setUser(d.admin)
+	userRepo.setUser(d.user)
+	sut := interactor.NewEarningRuleInteractor(
+		&ctxTrackingTxManager{}, d.ruleRepo, userRepo, d.txRepo,
+		newCtxTrackingPointBatchRepo(), &mockLogger{},
+	)
+	return d, sut
+}
+
+func createEarningRule(t *testing.T, d *earningRuleDeps, sut inputport.EarningRuleInputPort, content inputport.EarningRuleContent) *entities.EarningRule {
+	t.Helper()
+	if content.Name == "" {
+		content.Name = "ポイント獲得キャンペーン"
+	}
+	if content.Points == 0 {
+		content.Points = 10
+	}
+	content.StartsAt = time.Now().Add(-time.Minute)
+	rule, err := sut.CreateEarningRule(context.Background(), &inputport.CreateEarningRuleRequest{
+		AdminID:            d.admin.ID,
+		EarningRuleContent: content,
+	})
+	require.NoError(t, err)
+	return rule
+}
+
+// --- CreateEarningRule / UpdateEarningRule ---
+
+func TestEarningRuleInteractor_CreateEarningRule(t *testing.T) {
+	t.Run("管理者以外は作成できない", func(t *testing.T) {
+		d, sut := setupEarningRuleInteractor(t)
+		_, err := sut.CreateEarningRule(context.Background(), &inputport.CreateEarningRuleRequest{
+			AdminID: d.user.ID,
+			EarningRuleContent: inputport.EarningRuleContent{
+				Name: "初めての送金", Trigger: entities.EarningTriggerTransferSent, Points: 10,
+			},
+		})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+
+	t.Run("金額のないきっかけに最低金額は指定できない", func(t *testing.T) {
+		d, sut := setupEarningRuleInteractor(t)
+		_, err := sut.CreateEarningRule(context.Background(), &inputport.CreateEarningRuleRequest{
+			AdminID: d.admin.ID,
+			EarningRuleContent: inputport.EarningRuleContent{
+				Name: "友達追加", Trigger: entities.EarningTriggerFriendAdded, Points: 10, MinAmount: 100,
+			},
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidEarningRule)
+	})
+
+	t.Run("無効化すると出来事があっても付与しない", func(t *testing.T) {
+		d, sut := setupEarningRuleInteractor(t)
+		rule := createEarningRule(t, d, sut, inputport.EarningRuleContent{Trigger: entities.EarningTriggerProfileCompleted})
+
+		_, err := sut.UpdateEarningRule(context.Background(), &inputport.UpdateEarningRuleRequest{
+			AdminID: d.admin.ID, EarningRuleID: rule.ID, IsActive: false,
+			EarningRuleContent: inputport.EarningRuleContent{
+				Name: rule.Name, Trigger: rule.Trigger, Points: rule.Points, StartsAt: rule.StartsAt,
+			},
+		})
+		require.NoError(t, err)
+
+		sut.Record(context.Background(), entities.NewEarningEvent(entities.EarningTriggerProfileCompleted, d.user.ID, 0, d.user.ID.String()))
+		assert.Empty(t, d.txRepo.transactions)
+	})
+}
+
+// --- Record ---
+
+func TestEarningRuleInteractor_Record(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("一致するルールのポイントをシステム付与し、付与の記録を残す", func(t *testing.T) {
+		d, sut := setupEarningRuleInteractor(t)
+		rule := createEarningRule(t, d, sut, inputport.EarningRuleContent{Trigger: entities.EarningTriggerTransferSent, Points: 30})
+
+		sut.Record(ctx, entities.NewEarningEvent(entities.EarningTriggerTransferSent, d.user.ID, 100, "tx-1"))
+
+		require.Len(t, d.txRepo.transactions, 1)
+		tx := d.txRepo.transactions[0]
+		assert.Equal(t, entities.TransactionTypeSystemGrant, tx.TransactionType)
+		assert.Equal(t, int64(30), tx.Amount)
+		assert.Equal(t, rule.ID.String(), tx.Metadata["earning_rule_id"])
+		assert.Equal(t, "tx-1", tx.Metadata["source_id"])
+		assert.True(t, isTxContext(d.userRepo.ctxRecords["UpdateBalancesWithLock"]))
+		require.Len(t, d.ruleRepo.grants, 1)
+		assert.Equal(t, tx.ID, d.ruleRepo.grants[0].TransactionID)
+	})
+
+	t.Run("きっかけが違うルールでは付与しない", func(t *testing.T) {
+		d, sut := setupEarningRuleInteractor(t)
+		createEarningRule(t, d, sut, inputport.EarningRuleContent{Trigger: entities.EarningTriggerFriendAdded})
+
+		sut.Record(ctx, entities.NewEarningEvent(entities.EarningTriggerTransferSent, d.user.ID, 100, "tx-1"))
+		assert.Empty(t, d.txRepo.transactions)
+	})
+
+	t.Run("同じ発生元では2回付与しない", func(t *testing.T) {
+		d, sut := setupEarningRuleInteractor(t)
+		createEarningRule(t, d, sut, inputport.EarningRuleContent{Trigger: entities.EarningTriggerFriendAdded})
+
+		event := entities.NewEarningEvent(entities.EarningTriggerFriendAdded, d.user.ID, 0, "friendship-1")
+		sut.Record(ctx, event)
+		sut.Record(ctx, event)
+		assert.Len(t, d.ruleRepo.grants, 1)
+	})
+
+	t.Run("1人1回のルールは2回目の出来事では付与しない", func(t *testing.T) {
+		d, sut := setupEarningRuleInteractor(t)
+		createEarningRule(t, d, sut, inputport.EarningRuleContent{Trigger: entities.EarningTriggerFriendAdded, OncePerUser: true})
+
+		sut.Record(ctx, entities.NewEarningEvent(entities.EarningTriggerFriendAdded, d.user.ID, 0, "friendship-1"))
+		sut.Record(ctx, entities.NewEarningEvent(entities.EarningTriggerFriendAdded, d.user.ID, 0, "friendship-2"))
+		assert.Len(t, d.ruleRepo.grants, 1)
+	})
+
+	t.Run("ルール全体の上限に達したら付与しない", func(t *testing.T) {
+		d, sut := setupEarningRuleInteractor(t)
+		other := createTestUserWithBalance(t, "earning_other", 0, "user")
+		d.userRepo.setUser(other)
+		createEarningRule(t, d, sut, inputport.EarningRuleContent{Trigger: entities.EarningTriggerProfileCompleted, MaxGrantsTotal: 1})
+
+		sut.Record(ctx, entities.NewEarningEvent(entities.EarningTriggerProfileCompleted, d.user.ID, 0, d.user.ID.String()))
+		sut.Record(ctx, entities.NewEarningEvent(entities.EarningTriggerProfileCompleted, other.ID, 0, other.ID.String()))
+		require.Len(t, d.ruleRepo.grants, 1)
+		assert.Equal(t, d.user.ID, d.ruleRepo.grants[0].UserID)
+	})
+
+	t.Run("回数を指定したルールはN回目の出来事だけで付与する", func(t *testing.T) {
+		d, sut := setupEarningRuleInteractor(t)
+		createEarningRule(t, d, sut, inputport.EarningRuleContent{Trigger: entities.EarningTriggerTransferSent, Occurrence: 3})
+
+		d.ruleRepo.occurrences = 2
+		sut.Record(ctx, entities.NewEarningEvent(entities.EarningTriggerTransferSent, d.user.ID, 100, "tx-2"))
+		assert.Empty(t, d.ruleRepo.grants)
+
+		d.ruleRepo.occurrences = 3
+		sut.Record(ctx, entities.NewEarningEvent(entities.EarningTriggerTransferSent, d.user.ID, 100, "tx-3"))
+		assert.Len(t, d.ruleRepo.grants, 1)
+	})
+
+	t.Run("最低金額に満たない送金では付与しない", func(t *testing.T) {
+		d, sut := setupEarningRuleInteractor(t)
+		createEarningRule(t, d, sut, inputport.EarningRuleContent{Trigger: entities.EarningTriggerTransferSent, MinAmount: 500})
+
+		sut.Record(ctx, entities.NewEarningEvent(entities.EarningTriggerTransferSent, d.user.ID, 499, "tx-1"))
+		assert.Empty(t, d.ruleRepo.grants)
+		sut.Record(ctx, entities.NewEarningEvent(entities.EarningTriggerTransferSent, d.user.ID, 500, "tx-2"))
+		assert.Len(t, d.ruleRepo.grants, 1)
+	})
+
+	t.Run("無効なユーザーには付与しない", func(t *testing.T) {
+		d, sut := setupEarningRuleInteractor(t)
+		createEarningRule(t, d, sut, inputport.EarningRuleContent{Trigger: entities.EarningTriggerProfileCompleted})
+		d.user.IsActive = false
+		d.userRepo.setUser(d.user)
+
+		sut.Record(ctx, entities.NewEarningEvent(entities.EarningTriggerProfileCompleted, d.user.ID, 0, d.user.ID.String()))
+		assert.Empty(t, d.txRepo.transactions)
+	})
+}
+
+// --- GetEarningRule / GetEarningRuleList ---
+
+func TestEarningRuleInteractor_GetEarningRule(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("付与の実績を集計して返す", func(t *testing.T) {
+		d, sut := setupEarningRuleInteractor(t)
+		rule := createEarningRule(t, d, sut, inputport.EarningRuleContent{Trigger: entities.EarningTriggerTransferSent, Points: 25})
+		sut.Record(ctx, entities.NewEarningEvent(entities.EarningTriggerTransferSent, d.user.ID, 100, "tx-1"))
+		sut.Record(ctx, entities.NewEarningEvent(entities.EarningTriggerTransferSent, d.user.ID, 100, "tx-2"))
+
+		resp, err := sut.GetEarningRule(ctx, &inputport.GetEarningRuleRequest{AdminID: d.admin.ID, EarningRuleID: rule.ID})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), resp.Stats.GrantCount)
+		assert.Equal(t, int64(1), resp.Stats.UserCount)
+		assert.Equal(t, int64(50), resp.Stats.PointsGranted)
+		assert.NotNil(t, resp.Stats.LastGrantedAt)
+	})
+
+	t.Run("付与のないルールは0件の実績", func(t *testing.T) {
+		d, sut := setupEarningRuleInteractor(t)
+		createEarningRule(t, d, sut, inputport.EarningRuleContent{Trigger: entities.EarningTriggerFriendAdded})
+
+		resp, err := sut.GetEarningRuleList(ctx, &inputport.GetEarningRuleListRequest{AdminID: d.admin.ID})
+		require.NoError(t, err)
+		require.Len(t, resp.Rules, 1)
+		assert.Equal(t, int64(0), resp.Rules[0].Stats.GrantCount)
+		assert.Nil(t, resp.Rules[0].Stats.LastGrantedAt)
+		assert.Equal(t, 20, resp.Limit)
+	})
+}
@@ -0,0 +1,163 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailTemplateInteractor(t *testing.T) {
+	ctx := context.Background()
+
+	type fixture struct {
+		repos *testsupport.Repositories
+		email *mockEmailService
+		admin *entities.User
+		sut   inputport.EmailTemplateInputPort
+	}
+	setup := func(t *testing.T) *fixture {
+		f := &fixture{repos: testsupport.New(), email: &mockEmailService{}}
+		f.admin = createTestUserWithBalance(t, "admin", 0, entities.RoleAdmin)
+		f.repos.Users.Seed(f.admin)
+		f.sut = interactor.NewEmailTemplateInteractor(f.repos.TxManager, f.repos.EmailTemplates, f.repos.Users, f.email, &mockLogger{})
+		return f
+	}
+	save := func(t *testing.T, f *fixture, subject string) *entities.EmailTemplate {
+		t.Helper()
+		template, err := f.sut.SaveTemplate(ctx, &inputport.SaveEmailTemplateRequest{
+			AdminID: f.admin.ID,
+			Key:     entities.EmailTemplateVerification,
+			EmailTemplateDraft: inputport.EmailTemplateDraft{
+				Subject:  subject,
+				HTMLBody: `<a href="https://example.com/verify?token={{.Token}}">認証する</a>`,
+				TextBody: "https://example.com/verify?token={{.Token}}",
+			},
+		})
+		require.NoError(t, err)
+		return template
+	}
+
+	t.Run("編集していない種類は組み込みの既定を返す", func(t *testing.T) {
+		f := setup(t)
+		summaries, err := f.sut.ListTemplates(ctx, f.admin.ID)
+		require.NoError(t, err)
+		require.Len(t, summaries, len(entities.EmailTemplateDefinitions()))
+		for _, s := range summaries {
+			assert.True(t, s.Current.IsBuiltIn(), s.Definition.Key)
+		}
+	})
+
+	t.Run("保存するたびに版を増やし、一覧には最新の版を返す", func(t *testing.T) {
+		f := setup(t)
+		first := save(t, f, "認証のお願い")
+		second := save(t, f, "メールアドレスを認証してください")
+		assert.Equal(t, 1, first.Version)
+		assert.Equal(t, 2, second.Version)
+		assert.Equal(t, f.admin.ID, *second.CreatedBy)
+
+		detail, err := f.sut.GetTemplate(ctx, f.admin.ID, entities.EmailTemplateVerification)
+		require.NoError(t, err)
+		assert.Equal(t, 2, detail.Current.Version)
+		require.Len(t, detail.Versions, 2)
+		assert.Equal(t, 1, detail.Versions[1].Version)
+
+		summaries, err := f.sut.ListTemplates(ctx, f.admin.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.EmailTemplateVerification, summaries[0].Definition.Key)
+		assert.Equal(t, "メールアドレスを認証してください", summaries[0].Current.Subject)
+	})
+
+	t.Run("過去の版の復元は新しい版として保存し、リセットで既定に戻す", func(t *testing.T) {
+		f := setup(t)
+		save(t, f, "認証のお願い")
+		save(t, f, "メールアドレスを認証してください")
+
+		restored, err := f.sut.RestoreVersion(ctx, &inputport.RestoreEmailTemplateRequest{AdminID: f.admin.ID, Key: entities.EmailTemplateVerification, Version: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 3, restored.Version)
+		assert.Equal(t, "認証のお願い", restored.Subject)
+
+		_, err = f.sut.RestoreVersion(ctx, &inputport.RestoreEmailTemplateRequest{AdminID: f.admin.ID, Key: entities.EmailTemplateVerification, Version: 9})
+		assert.ErrorIs(t, err, entities.ErrEmailTemplateNotFound)
+
+		require.NoError(t, f.sut.ResetTemplate(ctx, f.admin.ID, entities.EmailTemplateVerification))
+		detail, err := f.sut.GetTemplate(ctx, f.admin.ID, entities.EmailTemplateVerification)
+		require.NoError(t, err)
+		assert.True(t, detail.Current.IsBuiltIn())
+		assert.Empty(t, detail.Versions)
+	})
+
+	t.Run("使えない変数を含むテンプレートは保存しない", func(t *testing.T) {
+		f := setup(t)
+		_, err := f.sut.SaveTemplate(ctx, &inputport.SaveEmailTemplateRequest{
+			AdminID:            f.admin.ID,
+			Key:                entities.EmailTemplateVerification,
+			EmailTemplateDraft: inputport.EmailTemplateDraft{Subject: "件名", TextBody: "{{.Password}}"},
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidEmailTemplate)
+		assert.Equal(t, 0, f.repos.EmailTemplates.Calls("Create"))
+
+		_, err = f.sut.GetTemplate(ctx, f.admin.ID, "unknown")
+		assert.ErrorIs(t, err, entities.ErrEmailTemplateNotFound)
+	})
+
+	t.Run("プレビューは例の変数を上書きでき、下書きも描画する", func(t *testing.T) {
+		f := setup(t)
+		email, err := f.sut.PreviewTemplate(ctx, &inputport.PreviewEmailTemplateRequest{
+			AdminID:   f.admin.ID,
+			Key:       entities.EmailTemplateVerification,
+			Variables: map[string]string{"Token": "abc"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "メールアドレスの認証", email.Subject)
+		assert.Contains(t, email.Text, "token=abc")
+
+		email, err = f.sut.PreviewTemplate(ctx, &inputport.PreviewEmailTemplateRequest{
+			AdminID: f.admin.ID,
+			Key:     entities.EmailTemplateVerification,
+			Draft:   &inputport.EmailTemplateDraft{Subject: "下書き", TextBody: "{{.Token}}"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "下書き", email.Subject)
+		assert.Equal(t, "example-token", email.Text)
+	})
+
+	t.Run("テスト送信は管理者自身のアドレスへ送る", func(t *testing.T) {
+		f := setup(t)
+		save(t, f, "認証のお願い")
+
+		email, err := f.sut.SendTestEmail(ctx, &inputport.SendTestEmailRequest{AdminID: f.admin.ID, Key: entities.EmailTemplateVerification})
+		require.NoError(t, err)
+		assert.Equal(t, "[テスト] 認証のお願い", email.Subject)
+		assert.Contains(t, email.HTML, "token=example-token")
+		assert.Equal(t, []string{f.admin.Email}, f.email.sentEmailAddrs)
+	})
+
+	t.Run("管理者以外は一覧・詳細・プレビューも編集もできない", func(t *testing.T) {
+		f := setup(t)
+		member := createTestUserWithBalance(t, "member", 0, entities.RoleUser)
+		f.repos.Users.Seed(member)
+		key := entities.EmailTemplateVerification
+
+		_, err := f.sut.ListTemplates(ctx, member.ID)
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		_, err = f.sut.GetTemplate(ctx, member.ID, key)
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		_, err = f.sut.PreviewTemplate(ctx, &inputport.PreviewEmailTemplateRequest{AdminID: member.ID, Key: key})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		_, err = f.sut.SaveTemplate(ctx, &inputport.SaveEmailTemplateRequest{
+			AdminID: member.ID, Key: key, EmailTemplateDraft: inputport.EmailTemplateDraft{Subject: "件名", TextBody: "本文"},
+		})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		assert.ErrorIs(t, f.sut.ResetTemplate(ctx, member.ID, key), entities.ErrAdminRequired)
+		_, err = f.sut.SendTestEmail(ctx, &inputport.SendTestEmailRequest{AdminID: member.ID, Key: key})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		assert.Empty(t, f.email.sentEmailAddrs)
+	})
+}
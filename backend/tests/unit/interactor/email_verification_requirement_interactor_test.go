@@ -0,0 +1,235 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockEmailVerificationGate はメール認証を求めない（stage・loginErrで設定を真似る）
+type mockEmailVerificationGate struct {
+	stage    entities.EmailVerificationStage
+	loginErr error
+}
+
+func (m *mockEmailVerificationGate) StartVerification(ctx context.Context, user *entities.User) (entities.EmailVerificationStage, error) {
+	if m.stage == "" {
+		return entities.EmailVerificationNotRequired, nil
+	}
+	return m.stage, nil
+}
+
+func (m *mockEmailVerificationGate) CheckLogin(ctx context.Context, user *entities.User) error {
+	return m.loginErr
+}
+
+func TestEmailVerificationRequirementInteractor(t *testing.T) {
+	ctx := context.Background()
+
+	type fixture struct {
+		repos *testsupport.Repositories
+		email *mockEmailService
+		sut   *interactor.EmailVerificationRequirementInteractor
+		admin *entities.User
+	}
+	setup := func(t *testing.T) *fixture {
+		f := &fixture{repos: testsupport.New(), email: &mockEmailService{}}
+		f.admin = createTestUserWithBalance(t, "admin", 0, entities.RoleAdmin)
+		f.repos.Users.Seed(f.admin)
+		f.sut = interactor.NewEmailVerificationRequirementInteractor(f.repos.SystemSettings, f.repos.Users,
+			f.repos.EmailVerifications, f.email, &mockLogger{})
+		return f
+	}
+	setStage := func(t *testing.T, f *fixture, stage entities.EmailVerificationStage) *entities.EmailVerificationRequirement {
+		t.Helper()
+		r, err := f.sut.UpdateRequirement(ctx, &inputport.UpdateEmailVerificationRequirementRequest{AdminID: f.admin.ID, Stage: stage})
+		require.NoError(t, err)
+		return r
+	}
+	newcomer := func(t *testing.T, f *fixture) *entities.User {
+		t.Helper()
+		user := createTestUserWithBalance(t, "newcomer", 0, entities.RoleUser)
+		user.CreatedAt = time.Now()
+		f.repos.Users.Seed(user)
+		return user
+	}
+
+	t.Run("未設定なら登録時にメールを送らずログインもできる", func(t *testing.T) {
+		f := setup(t)
+		user := newcomer(t, f)
+
+		stage, err := f.sut.StartVerification(ctx, user)
+		assert.NoError(t, err)
+		assert.Equal(t, entities.EmailVerificationNotRequired, stage)
+		assert.Equal(t, 0, f.repos.EmailVerifications.Calls("Create"))
+		assert.NoError(t, f.sut.CheckLogin(ctx, user))
+	})
+
+	t.Run("有効にした後の登録では認証メールを送り、認証前のログインをemail_not_verifiedで拒否する", func(t *testing.T) {
+		f := setup(t)
+		setStage(t, f, entities.EmailVerificationBeforeLogin)
+		user := newcomer(t, f)
+
+		stage, err := f.sut.StartVerification(ctx, user)
+		assert.NoError(t, err)
+		assert.Equal(t, entities.EmailVerificationBeforeLogin, stage)
+		assert.Equal(t, user.Email, f.email.sentVerificationAddr)
+
+		token, err := f.repos.EmailVerifications.ReadByToken(ctx, f.email.sentVerificationToken)
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, *token.UserID, "認証したらユーザーに反映できるようユーザーIDを持たせる")
+		assert.Equal(t, entities.TokenTypeRegistration, token.TokenType)
+
+		assert.ErrorIs(t, f.sut.CheckLogin(ctx, user), entities.ErrEmailNotVerified)
+		user.VerifyEmail()
+		assert.NoError(t, f.sut.CheckLogin(ctx, user))
+	})
+
+	t.Run("有効にする前に登録したユーザーは対象にしない", func(t *testing.T) {
+		f := setup(t)
+		user := newcomer(t, f)
+		user.CreatedAt = time.Now().Add(-time.Hour)
+		setStage(t, f, entities.EmailVerificationBeforeLogin)
+
+		assert.NoError(t, f.sut.CheckLogin(ctx, user))
+	})
+
+	t.Run("送金前の設定ではログインできる", func(t *testing.T) {
+		f := setup(t)
+		setStage(t, f, entities.EmailVerificationBeforeTransfer)
+		user := newcomer(t, f)
+
+		assert.NoError(t, f.sut.CheckLogin(ctx, user))
+	})
+
+	t.Run("有効のまま制限を変えても対象のユーザーは変わらない", func(t *testing.T) {
+		f := setup(t)
+		first := setStage(t, f, entities.EmailVerificationBeforeTransfer)
+		changed := setStage(t, f, entities.EmailVerificationBeforeLogin)
+		require.NotNil(t, changed.EnabledAt)
+		assert.True(t, first.EnabledAt.Equal(*changed.EnabledAt))
+
+		off := setStage(t, f, entities.EmailVerificationNotRequired)
+		assert.Nil(t, off.EnabledAt)
+
+		got, err := f.sut.GetRequirement(ctx, f.admin.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, entities.EmailVerificationNotRequired, got.Stage)
+	})
+
+	t.Run("不正な設定と管理者以外の変更は拒否する", func(t *testing.T) {
+		f := setup(t)
+		_, err := f.sut.UpdateRequirement(ctx, &inputport.UpdateEmailVerificationRequirementRequest{AdminID: f.admin.ID, Stage: "always"})
+		assert.ErrorIs(t, err, entities.ErrInvalidEmailVerificationRequirement)
+
+		user := newcomer(t, f)
+		_, err = f.sut.UpdateRequirement(ctx, &inputport.UpdateEmailVerificationRequirementRequest{AdminID: user.ID, Stage: entities.EmailVerificationBeforeLogin})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+
+	t.Run("再送は間隔を空けずに繰り返しても送らない", func(t *testing.T) {
+		f := setup(t)
+		user := newcomer(t, f)
+
+		for i := 0; i < 2; i++ {
+			assert.NoError(t, f.sut.ResendVerification(ctx, &inputport.ResendEmailVerificationRequest{Email: user.Email}))
+		}
+		assert.Equal(t, 1, f.repos.EmailVerifications.Calls("Create"))
+	})
+
+	t.Run("再送は期間内の回数の上限に達したら送らない", func(t *testing.T) {
+		for _, tc := range []struct {
+			name  string
+			sent  int
+			sends bool
+		}{
+			{name: "上限未満なら送る", sent: entities.EmailVerificationResendMaxPerWindow - 1, sends: true},
+			{name: "上限に達したら送らない", sent: entities.EmailVerificationResendMaxPerWindow, sends: false},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				f := setup(t)
+				user := newcomer(t, f)
+				for i := 1; i <= tc.sent; i++ {
+					token, err := entities.NewEmailVerificationToken(&user.ID, user.Email, entities.TokenTypeRegistration)
+					require.NoError(t, err)
+					token.CreatedAt = time.Now().Add(-time.Duration(i) * time.Hour)
+					require.NoError(t, f.repos.EmailVerifications.Create(ctx, token))
+				}
+
+				assert.NoError(t, f.sut.ResendVerification(ctx, &inputport.ResendEmailVerificationRequest{Email: user.Email}))
+				assert.Equal(t, tc.sends, f.email.sentVerificationAddr != "")
+			})
+		}
+	})
+
+	t.Run("未登録・認証済みのアドレスにはエラーを返さずに送らない", func(t *testing.T) {
+		f := setup(t)
+		verified := createTestUserWithBalance(t, "verified", 0, entities.RoleUser)
+		verified.VerifyEmail()
+		f.repos.Users.Seed(verified)
+
+		assert.NoError(t, f.sut.ResendVerification(ctx, &inputport.ResendEmailVerificationRequest{Email: "nobody@example.com"}))
+		assert.NoError(t, f.sut.ResendVerification(ctx, &inputport.ResendEmailVerificationRequest{Email: verified.Email}))
+		assert.Equal(t, 0, f.repos.EmailVerifications.Calls("Create"))
+	})
+}
+
+func TestEmailVerificationRequirement_AuthAndTransfer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("認証前はログインできない設定なら登録してもセッションを作らない", func(t *testing.T) {
+		repos := testsupport.New()
+		sut := interactor.NewAuthInteractor(repos.Users, repos.Sessions, repos.LoginAttempts,
+			&mockPasswordService{}, &mockEmailService{}, &mockNotificationDispatcher{}, &mockReferralTracker{}, &mockOnboardingBonusGranter{},
+			&mockEmailVerificationGate{stage: entities.EmailVerificationBeforeLogin}, &mockLogger{})
+
+		resp, err := sut.Register(ctx, &inputport.RegisterRequest{
+			Username: "newcomer", Email: "newcomer@example.com", Password: "password123",
+			DisplayName: "New Comer", FirstName: "太郎", LastName: "田中",
+		})
+		require.NoError(t, err)
+		assert.Nil(t, resp.Session)
+		assert.Equal(t, entities.EmailVerificationBeforeLogin, resp.EmailVerification)
+		assert.Equal(t, 0, repos.Sessions.Calls("Create"))
+	})
+
+	t.Run("未認証のログインはemail_not_verifiedで拒否して記録する", func(t *testing.T) {
+		repos := testsupport.New()
+		user := createTestUserWithBalance(t, "newcomer", 0, entities.RoleUser)
+		repos.Users.Seed(user)
+		sut := interactor.NewAuthInteractor(repos.Users, repos.Sessions, repos.LoginAttempts,
+			&mockPasswordService{verifyOK: true}, &mockEmailService{}, &mockNotificationDispatcher{}, &mockReferralTracker{}, &mockOnboardingBonusGranter{},
+			&mockEmailVerificationGate{loginErr: entities.ErrEmailNotVerified}, &mockLogger{})
+
+		_, err := sut.Login(ctx, &inputport.LoginRequest{Username: user.Username, Password: "password123"})
+		assert.ErrorIs(t, err, entities.ErrEmailNotVerified)
+		assert.Equal(t, 0, repos.Sessions.Calls("Create"))
+
+		attempts := repos.LoginAttempts.Attempts()
+		require.Len(t, attempts, 1)
+		assert.Equal(t, entities.LoginFailureEmailNotVerified, attempts[0].FailureReason)
+	})
+
+	t.Run("どちらの設定でも認証前の新規登録ユーザーは送金できない", func(t *testing.T) {
+		repos := testsupport.New()
+		admin := createTestUserWithBalance(t, "admin", 0, entities.RoleAdmin)
+		repos.Users.Seed(admin)
+		requirement := interactor.NewEmailVerificationRequirementInteractor(repos.SystemSettings, repos.Users, repos.EmailVerifications, &mockEmailService{}, &mockLogger{})
+		_, err := requirement.UpdateRequirement(ctx, &inputport.UpdateEmailVerificationRequirementRequest{AdminID: admin.ID, Stage: entities.EmailVerificationBeforeTransfer})
+		require.NoError(t, err)
+
+		newcomer := createTestUserWithBalance(t, "newcomer", 1000, entities.RoleUser)
+		newcomer.CreatedAt = time.Now()
+		repos.Users.Seed(newcomer)
+		sut := interactor.NewTransferEligibilityInteractor(repos.SystemSettings, repos.Users, repos.Friendships, &mockLogger{})
+		assert.ErrorIs(t, sut.CheckSender(ctx, newcomer.ID), entities.ErrEmailNotVerified)
+		assert.NoError(t, sut.CheckSender(ctx, admin.ID), "有効にする前のユーザーは送金できる")
+	})
+}
@@ -0,0 +1,211 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockEventRepo はチェックインの一意制約と定員の条件付き更新を再現する EventRepository のモック
+type mockEventRepo struct {
+	events      map[uuid.UUID]*entities.Event
+	attendances []*entities.EventAttendance
+}
+
+func newMockEventRepo() *mockEventRepo {
+	return &mockEventRepo{events: make(map[uuid.UUID]*entities.Event)}
+}
+
+func (m *mockEventRepo) Create(ctx context.Context, event *entities.Event) error {
+	copy := *event
+	m.events[event.ID] = &copy
+	return nil
+}
+func (m *mockEventRepo) Read(ctx context.Context, id uuid.UUID) (*entities.Event, error) {
+	e, ok := m.events[id]
+	if !ok {
+		return nil, entities.ErrEventNotFound
+	}
+	copy := *e
+	return &copy, nil
+}
+func (m *mockEventRepo) ReadByCode(ctx context.Context, code string) (*entities.Event, error) {
+	for _, e := range m.events {
+		if e.Code == code {
+			copy := *e
+			return &copy, nil
+		}
+	}
+	return nil, entities.ErrEventNotFound
+}
+func (m *mockEventRepo) Update(ctx context.Context, event *entities.Event) error {
+	e, ok := m.events[event.ID]
+	if !ok {
+		return entities.ErrEventNotFound
+	}
+	count := e.AttendeeCount
+	copy := *event
+	copy.AttendeeCount = count
+	m.events[event.ID] = &copy
+	return nil
+}
+func (m *mockEventRepo) ReadList(ctx context.Context, offset, limit int) ([]*entities.Event, error) {
+	result := make([]*entities.Event, 0, len(m.events))
+	for _, e := range m.events {
+		result = append(result, e)
+	}
+	return result, nil
+}
+func (m *mockEventRepo) Count(ctx context.Context) (int64, error) {
+	return int64(len(m.events)), nil
+}
+func (m *mockEventRepo) IncrementAttendeeCount(ctx context.Context, id uuid.UUID) (bool, error) {
+	e, ok := m.events[id]
+	if !ok || e.IsFull() {
+		return false, nil
+	}
+	e.AttendeeCount++
+	return true, nil
+}
+func (m *mockEventRepo) CreateAttendance(ctx context.Context, attendance *entities.EventAttendance) error {
+	for _, a := range m.attendances {
+		if a.EventID == attendance.EventID && a.UserID == attendance.UserID {
+			return entities.ErrEventAlreadyCheckedIn
+		}
+	}
+	m.attendances = append(m.attendances, attendance)
+	return nil
+}
+func (m *mockEventRepo) ReadAttendees(ctx context.Context, eventID uuid.UUID, offset, limit int) ([]*entities.EventAttendee, error) {
+	result := make([]*entities.EventAttendee, 0)
+	for _, a := range m.attendances {
+		if a.EventID == eventID {
+			result = append(result, &entities.EventAttendee{UserID: a.UserID, Points: a.Points, CheckedInAt: a.CreatedAt})
+		}
+	}
+	return result, nil
+}
+
+type eventDeps struct {
+	eventRepo *mockEventRepo
+	userRepo  *ctxTrackingUserRepo
+	txRepo    *ctxTrackingTransactionRepo
+	admin     *entities.User
+	user      *entities.User
+}
+
+func setupEventInteractor(t *testing.T) (*eventDeps, inputport.EventInputPort) {
+	userRepo := newCtxTrackingUserRepo()
+	d := &eventDeps{
+		eventRepo: newMockEventRepo(),
+		userRepo:  userRepo,
+		txRepo:    newCtxTrackingTransactionRepo(),
+		admin:     createTestUserWithBalance(t, "event_admin", 0, "admin"),
+		user:      createTestUserWithBalance(t, "event_user", 0, "user"),
+	}
+	userRepo.setUser(d.admin)
+	userRepo.setUser(d.user)
+	sut := interactor.NewEventInteractor(
+		&ctxTrackingTxManager{}, d.eventRepo, userRepo, d.txRepo,
+		newCtxTrackingPointBatchRepo(), &mockLogger{},
+	)
+	return d, sut
+}
+
+func createOpenEvent(t *testing.T, d *eventDeps, sut inputport.EventInputPort, capacity int) *entities.Event {
+	event, err := sut.CreateEvent(context.Background(), &inputport.CreateEventRequest{
+		AdminID: d.admin.ID,
+		EventContent: inputport.EventContent{
+			Name: "社内勉強会", Points: 50, Capacity: capacity,
+			StartsAt: time.Now().Add(-time.Minute), EndsAt: time.Now().Add(time.Hour),
+		},
+	})
+	require.NoError(t, err)
+	return event
+}
+
+// --- CreateEvent / UpdateEvent ---
+
+func TestEventInteractor_CreateEvent(t *testing.T) {
+	t.Run("管理者以外は作成できない", func(t *testing.T) {
+		d, sut := setupEventInteractor(t)
+		_, err := sut.CreateEvent(context.Background(), &inputport.CreateEventRequest{
+			AdminID: d.user.ID,
+			EventContent: inputport.EventContent{
+				Name: "イベント", Points: 50, EndsAt: time.Now().Add(time.Hour),
+			},
+		})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+
+	t.Run("無効化するとチェックインを受け付けない", func(t *testing.T) {
+		d, sut := setupEventInteractor(t)
+		event := createOpenEvent(t, d, sut, 0)
+
+		_, err := sut.UpdateEvent(context.Background(), &inputport.UpdateEventRequest{
+			AdminID: d.admin.ID, EventID: event.ID, IsActive: false,
+			EventContent: inputport.EventContent{
+				Name: event.Name, Points: event.Points, StartsAt: event.StartsAt, EndsAt: event.EndsAt,
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = sut.CheckIn(context.Background(), &inputport.EventCheckInRequest{UserID: d.user.ID, Code: event.QRCodeData()})
+		assert.ErrorIs(t, err, entities.ErrEventNotOpen)
+	})
+}
+
+// --- CheckIn ---
+
+func TestEventInteractor_CheckIn(t *testing.T) {
+	t.Run("QRコードを読み取るとポイントを付与し、参加者一覧に載る", func(t *testing.T) {
+		d, sut := setupEventInteractor(t)
+		event := createOpenEvent(t, d, sut, 0)
+
+		resp, err := sut.CheckIn(context.Background(), &inputport.EventCheckInRequest{UserID: d.user.ID, Code: event.QRCodeData()})
+		require.NoError(t, err)
+		assert.Equal(t, int64(50), resp.Attendance.Points)
+		require.Len(t, d.txRepo.transactions, 1)
+		assert.Equal(t, event.ID.String(), d.txRepo.transactions[0].Metadata["event_id"])
+		assert.True(t, isTxContext(d.userRepo.ctxRecords["UpdateBalancesWithLock"]))
+
+		attendees, err := sut.GetEventAttendees(context.Background(), &inputport.GetEventAttendeesRequest{AdminID: d.admin.ID, EventID: event.ID})
+		require.NoError(t, err)
+		require.Len(t, attendees.Attendees, 1)
+		assert.Equal(t, d.user.ID, attendees.Attendees[0].UserID)
+		assert.Equal(t, 1, attendees.Event.AttendeeCount)
+	})
+
+	t.Run("同じユーザーは2回チェックインできない", func(t *testing.T) {
+		d, sut := setupEventInteractor(t)
+		event := createOpenEvent(t, d, sut, 0)
+
+		_, err := sut.CheckIn(context.Background(), &inputport.EventCheckInRequest{UserID: d.user.ID, Code: event.Code})
+		require.NoError(t, err)
+		_, err = sut.CheckIn(context.Background(), &inputport.EventCheckInRequest{UserID: d.user.ID, Code: event.Code})
+		assert.ErrorIs(t, err, entities.ErrEventAlreadyCheckedIn)
+	})
+
+	t.Run("定員に達したら受け付けない", func(t *testing.T) {
+		d, sut := setupEventInteractor(t)
+		event := createOpenEvent(t, d, sut, 1)
+
+		_, err := sut.CheckIn(context.Background(), &inputport.EventCheckInRequest{UserID: d.user.ID, Code: event.Code})
+		require.NoError(t, err)
+		_, err = sut.CheckIn(context.Background(), &inputport.EventCheckInRequest{UserID: d.admin.ID, Code: event.Code})
+		assert.ErrorIs(t, err, entities.ErrEventFull)
+	})
+
+	t.Run("存在しないコードはエラー", func(t *testing.T) {
+		d, sut := setupEventInteractor(t)
+		_, err := sut.CheckIn(context.Background(), &inputport.EventCheckInRequest{UserID: d.user.ID, Code: "event:unknown"})
+		assert.ErrorIs(t, err, entities.ErrEventNotFound)
+	})
+}
@@ -0,0 +1,119 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFriendDiscoveryRepo は友達検索リポジトリのモック（ハッシュが一致するユーザーを返す）
+type mockFriendDiscoveryRepo struct {
+	users      []*entities.User
+	calls      int
+	lastHashes []string
+}
+
+func (m *mockFriendDiscoveryRepo) ReadDiscoverableByHashes(ctx context.Context, userID uuid.UUID, hashes []string) ([]*entities.User, error) {
+	m.calls++
+	m.lastHashes = hashes
+	hashSet := make(map[string]bool, len(hashes))
+	for _, h := range hashes {
+		hashSet[h] = true
+	}
+	var result []*entities.User
+	for _, u := range m.users {
+		if _, ok := entities.MatchContactHashes(u, hashSet); ok {
+			result = append(result, u)
+		}
+	}
+	return result, nil
+}
+
+func newDiscoverableUser(username, email string) *entities.User {
+	return &entities.User{
+		ID:           uuid.New(),
+		Username:     username,
+		Email:        email,
+		DisplayName:  username,
+		IsActive:     true,
+		Discoverable: true,
+	}
+}
+
+func TestFriendDiscoveryInteractor_DiscoverFriends(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("一致したハッシュとユーザーを返す", func(t *testing.T) {
+		alice := newDiscoverableUser("alice", "alice@example.com")
+		bob := newDiscoverableUser("bob", "bob@example.com")
+		repo := &mockFriendDiscoveryRepo{users: []*entities.User{alice, bob}}
+		uc := interactor.NewFriendDiscoveryInteractor(repo, &mockFriendshipLogger{})
+
+		aliceHash := entities.HashContactIdentifier("Alice@Example.com")
+		bobHash := entities.HashContactIdentifier("bob")
+		resp, err := uc.DiscoverFriends(ctx, &inputport.DiscoverFriendsRequest{
+			UserID: uuid.New(),
+			Hashes: []string{aliceHash, bobHash, entities.HashContactIdentifier("carol@example.com")},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Matches, 2)
+
+		assert.Equal(t, aliceHash, resp.Matches[0].Hash)
+		assert.Equal(t, entities.ContactMatchEmail, resp.Matches[0].MatchedBy)
+		assert.Equal(t, alice.ID, resp.Matches[0].User.ID)
+		assert.Equal(t, bobHash, resp.Matches[1].Hash)
+		assert.Equal(t, entities.ContactMatchUsername, resp.Matches[1].MatchedBy)
+	})
+
+	t.Run("非公開・無効ユーザーと本人は返さない", func(t *testing.T) {
+		hidden := newDiscoverableUser("hidden", "hidden@example.com")
+		hidden.Discoverable = false
+		inactive := newDiscoverableUser("inactive", "inactive@example.com")
+		inactive.IsActive = false
+		self := newDiscoverableUser("self", "self@example.com")
+		repo := &mockFriendDiscoveryRepo{users: []*entities.User{hidden, inactive, self}}
+		uc := interactor.NewFriendDiscoveryInteractor(repo, &mockFriendshipLogger{})
+
+		resp, err := uc.DiscoverFriends(ctx, &inputport.DiscoverFriendsRequest{
+			UserID: self.ID,
+			Hashes: []string{
+				entities.HashContactIdentifier("hidden@example.com"),
+				entities.HashContactIdentifier("inactive"),
+				entities.HashContactIdentifier("self@example.com"),
+			},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Matches)
+	})
+
+	t.Run("不正なハッシュはリポジトリを呼ばずにエラー", func(t *testing.T) {
+		repo := &mockFriendDiscoveryRepo{}
+		uc := interactor.NewFriendDiscoveryInteractor(repo, &mockFriendshipLogger{})
+
+		_, err := uc.DiscoverFriends(ctx, &inputport.DiscoverFriendsRequest{
+			UserID: uuid.New(),
+			Hashes: []string{"alice@example.com"},
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidContactHashes)
+		assert.Equal(t, 0, repo.calls)
+	})
+
+	t.Run("重複したハッシュはまとめて検索する", func(t *testing.T) {
+		repo := &mockFriendDiscoveryRepo{}
+		uc := interactor.NewFriendDiscoveryInteractor(repo, &mockFriendshipLogger{})
+
+		h := entities.HashContactIdentifier("alice")
+		_, err := uc.DiscoverFriends(ctx, &inputport.DiscoverFriendsRequest{
+			UserID: uuid.New(),
+			Hashes: []string{h, h},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{h}, repo.lastHashes)
+	})
+}
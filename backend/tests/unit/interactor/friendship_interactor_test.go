@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/gity/point-system/usecases/interactor"
 	"github.com/gity/point-system/usecases/repository"
@@ -160,6 +161,14 @@ func (m *mockFriendshipRepo) CountPendingRequests(ctx context.Context, userID uu
 	return count, nil
 }
 
+func (m *mockFriendshipRepo) ReadListMutualFriends(ctx context.Context, userID1, userID2 uuid.UUID, offset, limit int) ([]*entities.User, error) {
+	return nil, nil
+}
+
+func (m *mockFriendshipRepo) CountMutualFriends(ctx context.Context, userID1, userID2 uuid.UUID) (int64, error) {
+	return 0, nil
+}
+
 func (m *mockFriendshipRepo) setExistingFriendship(f *entities.Friendship) {
 	m.friendships[f.ID] = f
 	key := f.RequesterID.String() + "-" + f.AddresseeID.String()
@@ -208,10 +217,10 @@ func (m *mockUserRepo) ReadPersonalQRCode(ctx context.Context, userID uuid.UUID)
 	return "", nil
 }
 func (m *mockUserRepo) Count(ctx context.Context) (int64, error) { return 0, nil }
-func (m *mockUserRepo) ReadListWithSearch(ctx context.Context, search, sortBy, sortOrder string, offset, limit int) ([]*entities.User, error) {
+func (m *mockUserRepo) ReadListWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID, sortBy, sortOrder string, offset, limit int) ([]*entities.User, error) {
 	return nil, nil
 }
-func (m *mockUserRepo) CountWithSearch(ctx context.Context, search string) (int64, error) {
+func (m *mockUserRepo) CountWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID) (int64, error) {
 	return 0, nil
 }
 func (m *mockUserRepo) UpdateBalanceWithLock(ctx context.Context, userID uuid.UUID, amount int64, isDeduct bool) error {
@@ -221,6 +230,10 @@ func (m *mockUserRepo) UpdateBalancesWithLock(ctx context.Context, updates []rep
 	return nil
 }
 
+func (m *mockUserRepo) UpdateReservedBalanceWithLock(ctx context.Context, userID uuid.UUID, amount int64, isRelease bool) error {
+	return nil
+}
+
 func (m *mockUserRepo) addUser(user *entities.User) {
 	m.users[user.ID] = user
 }
@@ -266,7 +279,7 @@ func TestSendFriendRequest(t *testing.T) {
 		userRepo.addUser(createActiveUser(requesterID))
 		userRepo.addUser(createActiveUser(addresseeID))
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		resp, err := interactorInstance.SendFriendRequest(context.Background(), &inputport.SendFriendRequestRequest{
 			RequesterID: requesterID,
@@ -288,7 +301,7 @@ func TestSendFriendRequest(t *testing.T) {
 		userRepo.addUser(createActiveUser(requesterID))
 		// addresseeを追加しない
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		_, err := interactorInstance.SendFriendRequest(context.Background(), &inputport.SendFriendRequestRequest{
 			RequesterID: requesterID,
@@ -307,7 +320,7 @@ func TestSendFriendRequest(t *testing.T) {
 		userRepo.addUser(createActiveUser(requesterID))
 		userRepo.addUser(createInactiveUser(addresseeID))
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		_, err := interactorInstance.SendFriendRequest(context.Background(), &inputport.SendFriendRequestRequest{
 			RequesterID: requesterID,
@@ -330,7 +343,7 @@ func TestSendFriendRequest(t *testing.T) {
 		existing.Accept()
 		friendshipRepo.setExistingFriendship(existing)
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		_, err := interactorInstance.SendFriendRequest(context.Background(), &inputport.SendFriendRequestRequest{
 			RequesterID: requesterID,
@@ -352,7 +365,7 @@ func TestSendFriendRequest(t *testing.T) {
 		existing, _ := entities.NewFriendship(requesterID, addresseeID)
 		friendshipRepo.setExistingFriendship(existing)
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		_, err := interactorInstance.SendFriendRequest(context.Background(), &inputport.SendFriendRequestRequest{
 			RequesterID: requesterID,
@@ -375,7 +388,7 @@ func TestSendFriendRequest(t *testing.T) {
 		existing.Block()
 		friendshipRepo.setExistingFriendship(existing)
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		_, err := interactorInstance.SendFriendRequest(context.Background(), &inputport.SendFriendRequestRequest{
 			RequesterID: requesterID,
@@ -398,7 +411,7 @@ func TestSendFriendRequest(t *testing.T) {
 		existing.Reject()
 		friendshipRepo.setExistingFriendship(existing)
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		resp, err := interactorInstance.SendFriendRequest(context.Background(), &inputport.SendFriendRequestRequest{
 			RequesterID: requesterID,
@@ -427,7 +440,7 @@ func TestAcceptFriendRequest(t *testing.T) {
 		f, _ := entities.NewFriendship(requesterID, addresseeID)
 		friendshipRepo.setExistingFriendship(f)
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		resp, err := interactorInstance.AcceptFriendRequest(context.Background(), &inputport.AcceptFriendRequestRequest{
 			FriendshipID: f.ID,
@@ -438,6 +451,32 @@ func TestAcceptFriendRequest(t *testing.T) {
 		assert.Equal(t, entities.FriendshipStatusAccepted, resp.Friendship.Status)
 	})
 
+	t.Run("承認すると両者の友達追加をポイント獲得ルールに伝える", func(t *testing.T) {
+		friendshipRepo := newMockFriendshipRepo()
+		requesterID := uuid.New()
+		addresseeID := uuid.New()
+
+		f, _ := entities.NewFriendship(requesterID, addresseeID)
+		friendshipRepo.setExistingFriendship(f)
+
+		earningEvents := &mockEarningEvents{}
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, newMockUserRepo(), testsupport.NewTransactionRepository(nil), earningEvents, &mockFriendshipLogger{})
+
+		_, err := interactorInstance.AcceptFriendRequest(context.Background(), &inputport.AcceptFriendRequestRequest{
+			FriendshipID: f.ID,
+			UserID:       addresseeID,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, earningEvents.events, 2)
+		assert.Equal(t, requesterID, earningEvents.events[0].UserID)
+		assert.Equal(t, addresseeID, earningEvents.events[1].UserID)
+		for _, event := range earningEvents.events {
+			assert.Equal(t, entities.EarningTriggerFriendAdded, event.Trigger)
+			assert.Equal(t, f.ID.String(), event.SourceID)
+		}
+	})
+
 	t.Run("申請者が自分の申請を承認しようとするとエラー", func(t *testing.T) {
 		friendshipRepo := newMockFriendshipRepo()
 		userRepo := newMockUserRepo()
@@ -447,7 +486,7 @@ func TestAcceptFriendRequest(t *testing.T) {
 		f, _ := entities.NewFriendship(requesterID, addresseeID)
 		friendshipRepo.setExistingFriendship(f)
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		_, err := interactorInstance.AcceptFriendRequest(context.Background(), &inputport.AcceptFriendRequestRequest{
 			FriendshipID: f.ID,
@@ -468,7 +507,7 @@ func TestAcceptFriendRequest(t *testing.T) {
 		f, _ := entities.NewFriendship(requesterID, addresseeID)
 		friendshipRepo.setExistingFriendship(f)
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		_, err := interactorInstance.AcceptFriendRequest(context.Background(), &inputport.AcceptFriendRequestRequest{
 			FriendshipID: f.ID,
@@ -483,7 +522,7 @@ func TestAcceptFriendRequest(t *testing.T) {
 		friendshipRepo := newMockFriendshipRepo()
 		userRepo := newMockUserRepo()
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		_, err := interactorInstance.AcceptFriendRequest(context.Background(), &inputport.AcceptFriendRequestRequest{
 			FriendshipID: uuid.New(),
@@ -508,7 +547,7 @@ func TestRejectFriendRequest(t *testing.T) {
 		f, _ := entities.NewFriendship(requesterID, addresseeID)
 		friendshipRepo.setExistingFriendship(f)
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		resp, err := interactorInstance.RejectFriendRequest(context.Background(), &inputport.RejectFriendRequestRequest{
 			FriendshipID: f.ID,
@@ -528,7 +567,7 @@ func TestRejectFriendRequest(t *testing.T) {
 		f, _ := entities.NewFriendship(requesterID, addresseeID)
 		friendshipRepo.setExistingFriendship(f)
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		_, err := interactorInstance.RejectFriendRequest(context.Background(), &inputport.RejectFriendRequestRequest{
 			FriendshipID: f.ID,
@@ -555,7 +594,7 @@ func TestRemoveFriend(t *testing.T) {
 		f.Accept()
 		friendshipRepo.setExistingFriendship(f)
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		resp, err := interactorInstance.RemoveFriend(context.Background(), &inputport.RemoveFriendRequest{
 			UserID:       requesterID,
@@ -579,7 +618,7 @@ func TestRemoveFriend(t *testing.T) {
 		f.Accept()
 		friendshipRepo.setExistingFriendship(f)
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		resp, err := interactorInstance.RemoveFriend(context.Background(), &inputport.RemoveFriendRequest{
 			UserID:       addresseeID,
@@ -601,7 +640,7 @@ func TestRemoveFriend(t *testing.T) {
 		f.Accept()
 		friendshipRepo.setExistingFriendship(f)
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		_, err := interactorInstance.RemoveFriend(context.Background(), &inputport.RemoveFriendRequest{
 			UserID:       otherUser,
@@ -616,7 +655,7 @@ func TestRemoveFriend(t *testing.T) {
 		friendshipRepo := newMockFriendshipRepo()
 		userRepo := newMockUserRepo()
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		_, err := interactorInstance.RemoveFriend(context.Background(), &inputport.RemoveFriendRequest{
 			UserID:       uuid.New(),
@@ -637,7 +676,7 @@ func TestRemoveFriend(t *testing.T) {
 		friendshipRepo.setExistingFriendship(f)
 		friendshipRepo.archiveErr = errors.New("archive failed")
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		_, err := interactorInstance.RemoveFriend(context.Background(), &inputport.RemoveFriendRequest{
 			UserID:       requesterID,
@@ -668,7 +707,7 @@ func TestGetFriends(t *testing.T) {
 		friendshipRepo.friends = []*entities.Friendship{f}
 		friendshipRepo.friendsUsers[friendID] = userRepo.users[friendID]
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		resp, err := interactorInstance.GetFriends(context.Background(), &inputport.GetFriendsRequest{
 			UserID: userID,
@@ -687,7 +726,7 @@ func TestGetFriends(t *testing.T) {
 		userID := uuid.New()
 		friendshipRepo.friends = []*entities.Friendship{}
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		resp, err := interactorInstance.GetFriends(context.Background(), &inputport.GetFriendsRequest{
 			UserID: userID,
@@ -718,7 +757,7 @@ func TestGetPendingRequests(t *testing.T) {
 		friendshipRepo.pending = []*entities.Friendship{f}
 		friendshipRepo.pendingUsers[requesterID] = userRepo.users[requesterID]
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		resp, err := interactorInstance.GetPendingRequests(context.Background(), &inputport.GetPendingRequestsRequest{
 			UserID: addresseeID,
@@ -736,7 +775,7 @@ func TestGetPendingRequests(t *testing.T) {
 		userRepo := newMockUserRepo()
 		friendshipRepo.pending = []*entities.Friendship{}
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		resp, err := interactorInstance.GetPendingRequests(context.Background(), &inputport.GetPendingRequestsRequest{
 			UserID: uuid.New(),
@@ -762,7 +801,7 @@ func TestFriendshipFullFlow(t *testing.T) {
 		userRepo.addUser(createActiveUser(userA))
 		userRepo.addUser(createActiveUser(userB))
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		// 1. フレンド申請
 		sendResp, err := interactorInstance.SendFriendRequest(context.Background(), &inputport.SendFriendRequestRequest{
@@ -808,7 +847,7 @@ func TestFriendshipFullFlow(t *testing.T) {
 		userRepo.addUser(createActiveUser(userA))
 		userRepo.addUser(createActiveUser(userB))
 
-		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, &mockFriendshipLogger{})
+		interactorInstance := interactor.NewFriendshipInteractor(friendshipRepo, userRepo, testsupport.NewTransactionRepository(nil), &mockEarningEvents{}, &mockFriendshipLogger{})
 
 		// 1. フレンド申請
 		sendResp, err := interactorInstance.SendFriendRequest(context.Background(), &inputport.SendFriendRequestRequest{
@@ -0,0 +1,130 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFriendshipMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*testsupport.Repositories, inputport.FriendshipInputPort) {
+		repos := testsupport.New()
+		sut := interactor.NewFriendshipInteractor(repos.Friendships, repos.Users, repos.Transactions, &mockEarningEvents{}, &mockFriendshipLogger{})
+		return repos, sut
+	}
+	seedUser := func(t *testing.T, repos *testsupport.Repositories, name string) *entities.User {
+		t.Helper()
+		user := createTestUserWithBalance(t, name, 1000, entities.RoleUser)
+		repos.Users.Seed(user)
+		return user
+	}
+	befriend := func(t *testing.T, repos *testsupport.Repositories, a, b *entities.User) *entities.Friendship {
+		t.Helper()
+		f, err := entities.NewFriendship(a.ID, b.ID)
+		require.NoError(t, err)
+		require.NoError(t, f.Accept())
+		require.NoError(t, repos.Friendships.Create(ctx, f))
+		return f
+	}
+	transfer := func(t *testing.T, repos *testsupport.Repositories, from, to *entities.User, amount int64, status entities.TransactionStatus) {
+		t.Helper()
+		tx, err := entities.NewTransfer(from.ID, to.ID, amount, uuid.NewString(), "")
+		require.NoError(t, err)
+		tx.Status = status
+		require.NoError(t, repos.Transactions.Create(ctx, tx))
+	}
+
+	t.Run("友達一覧に友達になった日時とやり取りしたポイントを含める", func(t *testing.T) {
+		repos, sut := setup(t)
+		me := seedUser(t, repos, "me")
+		alice := seedUser(t, repos, "alice")
+		bob := seedUser(t, repos, "bob")
+		carol := seedUser(t, repos, "carol")
+		f := befriend(t, repos, me, alice)
+		befriend(t, repos, bob, me)
+
+		transfer(t, repos, me, alice, 100, entities.TransactionStatusCompleted)
+		transfer(t, repos, alice, me, 30, entities.TransactionStatusCompleted)
+		transfer(t, repos, me, alice, 500, entities.TransactionStatusFailed)
+		transfer(t, repos, me, carol, 70, entities.TransactionStatusCompleted)
+
+		resp, err := sut.GetFriends(ctx, &inputport.GetFriendsRequest{UserID: me.ID, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, resp.Friends, 2)
+
+		byFriend := make(map[uuid.UUID]*inputport.FriendInfo)
+		for _, info := range resp.Friends {
+			byFriend[info.Friend.ID] = info
+		}
+		assert.Equal(t, f.UpdatedAt, byFriend[alice.ID].Since)
+		assert.Equal(t, int64(100), byFriend[alice.ID].PointsExchanged.Sent)
+		assert.Equal(t, int64(30), byFriend[alice.ID].PointsExchanged.Received)
+		assert.Equal(t, int64(130), byFriend[alice.ID].PointsExchanged.Total(), "完了していない取引と友達以外との取引は含めない")
+		assert.Equal(t, int64(0), byFriend[bob.ID].PointsExchanged.Total())
+		assert.Equal(t, bob.ID, byFriend[bob.ID].PointsExchanged.CounterpartID)
+	})
+
+	t.Run("集計に失敗したら友達一覧もエラーにする", func(t *testing.T) {
+		repos, sut := setup(t)
+		me := seedUser(t, repos, "me")
+		befriend(t, repos, me, seedUser(t, repos, "alice"))
+		repos.Transactions.FailOn("SumExchangedWith", assert.AnError)
+
+		_, err := sut.GetFriends(ctx, &inputport.GetFriendsRequest{UserID: me.ID, Limit: 10})
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("共通の友達をユーザー名順に人数付きで返す", func(t *testing.T) {
+		repos, sut := setup(t)
+		me := seedUser(t, repos, "me")
+		other := seedUser(t, repos, "other")
+		carol := seedUser(t, repos, "carol")
+		alice := seedUser(t, repos, "alice")
+		bob := seedUser(t, repos, "bob")
+		for _, u := range []*entities.User{carol, alice, bob} {
+			befriend(t, repos, me, u)
+		}
+		befriend(t, repos, carol, other)
+		befriend(t, repos, other, alice)
+
+		pending, err := entities.NewFriendship(other.ID, bob.ID)
+		require.NoError(t, err)
+		require.NoError(t, repos.Friendships.Create(ctx, pending))
+
+		resp, err := sut.GetMutualFriends(ctx, &inputport.GetMutualFriendsRequest{UserID: me.ID, OtherUserID: other.ID, Limit: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), resp.Count, "承認されていない関係は含めない")
+		require.Len(t, resp.Friends, 1)
+		assert.Equal(t, "alice", resp.Friends[0].Username)
+
+		resp, err = sut.GetMutualFriends(ctx, &inputport.GetMutualFriendsRequest{UserID: me.ID, OtherUserID: other.ID, Offset: 1, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, resp.Friends, 1)
+		assert.Equal(t, "carol", resp.Friends[0].Username)
+	})
+
+	t.Run("存在しないユーザーとの共通の友達はErrUserNotFound", func(t *testing.T) {
+		repos, sut := setup(t)
+		me := seedUser(t, repos, "me")
+
+		_, err := sut.GetMutualFriends(ctx, &inputport.GetMutualFriendsRequest{UserID: me.ID, OtherUserID: uuid.New(), Limit: 10})
+		assert.ErrorIs(t, err, entities.ErrUserNotFound)
+	})
+
+	t.Run("自分自身との共通の友達は取得できない", func(t *testing.T) {
+		repos, sut := setup(t)
+		me := seedUser(t, repos, "me")
+
+		_, err := sut.GetMutualFriends(ctx, &inputport.GetMutualFriendsRequest{UserID: me.ID, OtherUserID: me.ID, Limit: 10})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,210 @@
+package interactor_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockIdempotentRequestRepo はIdempotentRequestRepositoryのインメモリ実装
+type mockIdempotentRequestRepo struct {
+	mu      sync.Mutex
+	records map[uuid.UUID]*entities.IdempotentRequest
+}
+
+func newMockIdempotentRequestRepo() *mockIdempotentRequestRepo {
+	return &mockIdempotentRequestRepo{records: make(map[uuid.UUID]*entities.IdempotentRequest)}
+}
+
+func (m *mockIdempotentRequestRepo) find(scope, key, route string) *entities.IdempotentRequest {
+	for _, r := range m.records {
+		if r.Scope == scope && r.Key == key && r.Route == route {
+			return r
+		}
+	}
+	return nil
+}
+
+func (m *mockIdempotentRequestRepo) CreateIfAbsent(ctx context.Context, req *entities.IdempotentRequest) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.find(req.Scope, req.Key, req.Route) != nil {
+		return false, nil
+	}
+	copied := *req
+	m.records[req.ID] = &copied
+	return true, nil
+}
+
+func (m *mockIdempotentRequestRepo) ReadByKey(ctx context.Context, scope, key, route string) (*entities.IdempotentRequest, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r := m.find(scope, key, route)
+	if r == nil {
+		return nil, nil
+	}
+	copied := *r
+	return &copied, nil
+}
+
+func (m *mockIdempotentRequestRepo) Update(ctx context.Context, req *entities.IdempotentRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.records[req.ID]
+	if !ok {
+		return nil
+	}
+	r.StatusCode = req.StatusCode
+	r.ContentType = req.ContentType
+	r.ResponseBody = req.ResponseBody
+	r.CompletedAt = req.CompletedAt
+	return nil
+}
+
+func (m *mockIdempotentRequestRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.records, id)
+	return nil
+}
+
+func (m *mockIdempotentRequestRepo) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deleted int64
+	for id, r := range m.records {
+		if r.IsExpired(now) {
+			delete(m.records, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func beginReq(key, hash string) *inputport.BeginIdempotentRequestRequest {
+	return &inputport.BeginIdempotentRequestRequest{
+		Scope:       "user:1",
+		Key:         key,
+		Route:       "POST /api/v1/qrcodes/scan",
+		RequestHash: hash,
+	}
+}
+
+func TestIdempotencyInteractor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("処理済みのリクエストは保存済みレスポンスを返す", func(t *testing.T) {
+		repo := newMockIdempotentRequestRepo()
+		uc := interactor.NewIdempotencyInteractor(repo, &mockLogger{})
+
+		first, err := uc.Begin(ctx, beginReq("key-1", "hash-a"))
+		require.NoError(t, err)
+		require.Nil(t, first.Replay)
+
+		require.NoError(t, uc.Complete(ctx, &inputport.CompleteIdempotentRequestRequest{
+			RequestID:   first.RequestID,
+			StatusCode:  201,
+			ContentType: "application/json; charset=utf-8",
+			Body:        []byte(`{"ok":true}`),
+		}))
+
+		retry, err := uc.Begin(ctx, beginReq("key-1", "hash-a"))
+		require.NoError(t, err)
+		require.NotNil(t, retry.Replay)
+		assert.Equal(t, 201, retry.Replay.StatusCode)
+		assert.Equal(t, "application/json; charset=utf-8", retry.Replay.ContentType)
+		assert.Equal(t, `{"ok":true}`, string(retry.Replay.ResponseBody))
+	})
+
+	t.Run("処理中の再送は並行リクエストとして拒否", func(t *testing.T) {
+		repo := newMockIdempotentRequestRepo()
+		uc := interactor.NewIdempotencyInteractor(repo, &mockLogger{})
+
+		_, err := uc.Begin(ctx, beginReq("key-1", "hash-a"))
+		require.NoError(t, err)
+
+		_, err = uc.Begin(ctx, beginReq("key-1", "hash-a"))
+		assert.ErrorIs(t, err, entities.ErrRequestInProgress)
+	})
+
+	t.Run("同じキーで別のリクエストを送ると拒否", func(t *testing.T) {
+		repo := newMockIdempotentRequestRepo()
+		uc := interactor.NewIdempotencyInteractor(repo, &mockLogger{})
+
+		first, err := uc.Begin(ctx, beginReq("key-1", "hash-a"))
+		require.NoError(t, err)
+		require.NoError(t, uc.Complete(ctx, &inputport.CompleteIdempotentRequestRequest{RequestID: first.RequestID, StatusCode: 200}))
+
+		_, err = uc.Begin(ctx, beginReq("key-1", "hash-b"))
+		assert.ErrorIs(t, err, entities.ErrIdempotencyKeyReused)
+	})
+
+	t.Run("主体やルートが違えば別のリクエスト", func(t *testing.T) {
+		repo := newMockIdempotentRequestRepo()
+		uc := interactor.NewIdempotencyInteractor(repo, &mockLogger{})
+
+		_, err := uc.Begin(ctx, beginReq("key-1", "hash-a"))
+		require.NoError(t, err)
+
+		other := beginReq("key-1", "hash-a")
+		other.Scope = "user:2"
+		resp, err := uc.Begin(ctx, other)
+		require.NoError(t, err)
+		assert.Nil(t, resp.Replay)
+
+		otherRoute := beginReq("key-1", "hash-a")
+		otherRoute.Route = "POST /api/v1/friends/requests"
+		resp, err = uc.Begin(ctx, otherRoute)
+		require.NoError(t, err)
+		assert.Nil(t, resp.Replay)
+	})
+
+	t.Run("サーバーエラーは保存せず再試行できる", func(t *testing.T) {
+		repo := newMockIdempotentRequestRepo()
+		uc := interactor.NewIdempotencyInteractor(repo, &mockLogger{})
+
+		first, err := uc.Begin(ctx, beginReq("key-1", "hash-a"))
+		require.NoError(t, err)
+		require.NoError(t, uc.Complete(ctx, &inputport.CompleteIdempotentRequestRequest{RequestID: first.RequestID, StatusCode: 503}))
+
+		retry, err := uc.Begin(ctx, beginReq("key-1", "hash-a"))
+		require.NoError(t, err)
+		assert.Nil(t, retry.Replay)
+		assert.NotEqual(t, first.RequestID, retry.RequestID)
+	})
+
+	t.Run("期限切れのレスポンスは使わずに新しく処理する", func(t *testing.T) {
+		repo := newMockIdempotentRequestRepo()
+		uc := interactor.NewIdempotencyInteractor(repo, &mockLogger{})
+
+		first, err := uc.Begin(ctx, beginReq("key-1", "hash-a"))
+		require.NoError(t, err)
+		require.NoError(t, uc.Complete(ctx, &inputport.CompleteIdempotentRequestRequest{RequestID: first.RequestID, StatusCode: 200}))
+		repo.records[first.RequestID].ExpiresAt = time.Now().Add(-time.Minute)
+
+		retry, err := uc.Begin(ctx, beginReq("key-1", "hash-b"))
+		require.NoError(t, err)
+		assert.Nil(t, retry.Replay)
+		assert.Len(t, repo.records, 1)
+	})
+
+	t.Run("キーの長さを検証", func(t *testing.T) {
+		repo := newMockIdempotentRequestRepo()
+		uc := interactor.NewIdempotencyInteractor(repo, &mockLogger{})
+
+		long := make([]byte, entities.IdempotencyKeyMaxLength+1)
+		for i := range long {
+			long[i] = 'a'
+		}
+		_, err := uc.Begin(ctx, beginReq(string(long), "hash-a"))
+		assert.ErrorIs(t, err, entities.ErrIdempotencyKeyInvalid)
+	})
+}
@@ -0,0 +1,404 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ========================================
+// Mock KioskRepository
+// ========================================
+
+type mockKioskRepo struct {
+	devices    map[uuid.UUID]*entities.KioskDevice
+	cards      map[string]*entities.KioskCard
+	grants     []*entities.KioskGrant
+	ctxRecords map[string]context.Context
+}
+
+func newMockKioskRepo() *mockKioskRepo {
+	return &mockKioskRepo{
+		devices:    make(map[uuid.UUID]*entities.KioskDevice),
+		cards:      make(map[string]*entities.KioskCard),
+		ctxRecords: make(map[string]context.Context),
+	}
+}
+
+func (m *mockKioskRepo) CreateDevice(ctx context.Context, device *entities.KioskDevice) error {
+	m.devices[device.ID] = device
+	return nil
+}
+func (m *mockKioskRepo) ReadDevice(ctx context.Context, id uuid.UUID) (*entities.KioskDevice, error) {
+	d, ok := m.devices[id]
+	if !ok {
+		return nil, errors.New("kiosk device not found")
+	}
+	copy := *d
+	return &copy, nil
+}
+func (m *mockKioskRepo) ReadDeviceByAPIKeyHash(ctx context.Context, apiKeyHash string) (*entities.KioskDevice, error) {
+	for _, d := range m.devices {
+		if d.APIKeyHash == apiKeyHash {
+			copy := *d
+			return &copy, nil
+		}
+	}
+	return nil, errors.New("kiosk device not found")
+}
+func (m *mockKioskRepo) ReadDeviceList(ctx context.Context) ([]*entities.KioskDevice, error) {
+	result := make([]*entities.KioskDevice, 0, len(m.devices))
+	for _, d := range m.devices {
+		result = append(result, d)
+	}
+	return result, nil
+}
+func (m *mockKioskRepo) UpdateDevice(ctx context.Context, device *entities.KioskDevice) error {
+	m.devices[device.ID] = device
+	return nil
+}
+func (m *mockKioskRepo) UpdateDeviceLastSeen(ctx context.Context, id uuid.UUID, seenAt time.Time) error {
+	return nil
+}
+func (m *mockKioskRepo) CreateCard(ctx context.Context, card *entities.KioskCard) error {
+	m.cards[card.CardID] = card
+	return nil
+}
+func (m *mockKioskRepo) ReadCard(ctx context.Context, cardID string) (*entities.KioskCard, error) {
+	c, ok := m.cards[cardID]
+	if !ok {
+		return nil, errors.New("card not found")
+	}
+	return c, nil
+}
+func (m *mockKioskRepo) DeleteCard(ctx context.Context, cardID string) error {
+	delete(m.cards, cardID)
+	return nil
+}
+func (m *mockKioskRepo) CreateGrant(ctx context.Context, grant *entities.KioskGrant) error {
+	m.ctxRecords["CreateGrant"] = ctx
+	m.grants = append(m.grants, grant)
+	return nil
+}
+func (m *mockKioskRepo) ReadGrantByIdempotencyKey(ctx context.Context, deviceID uuid.UUID, key string) (*entities.KioskGrant, error) {
+	for _, g := range m.grants {
+		if g.DeviceID == deviceID && g.IdempotencyKey == key {
+			return g, nil
+		}
+	}
+	return nil, nil
+}
+func (m *mockKioskRepo) CountGrantsByDeviceSince(ctx context.Context, deviceID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	for _, g := range m.grants {
+		if g.DeviceID == deviceID && !g.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+func (m *mockKioskRepo) ExistsGrantByDeviceAndUserSince(ctx context.Context, deviceID, userID uuid.UUID, since time.Time) (bool, error) {
+	for _, g := range m.grants {
+		if g.DeviceID == deviceID && g.UserID == userID && !g.CreatedAt.Before(since) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// fixedClock はテストで進められる現在時刻
+type fixedClock struct {
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time { return c.now }
+
+// ========================================
+// KioskInteractor テスト
+// ========================================
+
+type kioskTestEnv struct {
+	txMgr    *ctxTrackingTxManager
+	userRepo *ctxTrackingUserRepo
+	txRepo   *ctxTrackingTransactionRepo
+	pbRepo   *ctxTrackingPointBatchRepo
+	kiosk    *mockKioskRepo
+	settings *mockSystemSettingsRepo
+	clock    *fixedClock
+	sut      inputport.KioskInputPort
+	admin    *entities.User
+	user     *entities.User
+	device   *entities.KioskDevice
+	apiKey   string
+}
+
+func setupKioskTest(t *testing.T, dailyLimit int) *kioskTestEnv {
+	t.Helper()
+	env := &kioskTestEnv{
+		txMgr:    &ctxTrackingTxManager{},
+		userRepo: newCtxTrackingUserRepo(),
+		txRepo:   newCtxTrackingTransactionRepo(),
+		pbRepo:   newCtxTrackingPointBatchRepo(),
+		kiosk:    newMockKioskRepo(),
+		settings: newMockSystemSettingsRepo(),
+		clock:    &fixedClock{now: time.Now()},
+	}
+
+	env.admin = createTestUserWithBalance(t, "admin", 0, "admin")
+	env.user = createTestUserWithBalance(t, "visitor", 100, "user")
+	env.userRepo.setUser(env.admin)
+	env.userRepo.setUser(env.user)
+
+	device, apiKey, err := entities.NewKioskDevice("受付端末", 50, dailyLimit, env.admin.ID)
+	require.NoError(t, err)
+	env.kiosk.devices[device.ID] = device
+	env.device = device
+	env.apiKey = apiKey
+
+	env.sut = interactor.NewKioskInteractor(env.txMgr, env.kiosk, env.userRepo, env.txRepo, env.pbRepo, env.settings, testQRCodec, env.clock, &mockLogger{})
+	return env
+}
+
+func TestKioskInteractor_AuthenticateDevice(t *testing.T) {
+	t.Run("正しいAPIキーで認証できる", func(t *testing.T) {
+		env := setupKioskTest(t, 0)
+		device, err := env.sut.AuthenticateDevice(context.Background(), env.apiKey)
+		require.NoError(t, err)
+		assert.Equal(t, env.device.ID, device.ID)
+	})
+
+	t.Run("不正なAPIキーはエラー", func(t *testing.T) {
+		env := setupKioskTest(t, 0)
+		_, err := env.sut.AuthenticateDevice(context.Background(), "kiosk_invalid")
+		assert.Error(t, err)
+	})
+
+	t.Run("無効化された端末はエラー", func(t *testing.T) {
+		env := setupKioskTest(t, 0)
+		env.device.Deactivate()
+		_, err := env.sut.AuthenticateDevice(context.Background(), env.apiKey)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not active")
+	})
+}
+
+func TestKioskInteractor_LookupUser(t *testing.T) {
+	t.Run("個人QRコードでユーザーを検索できる", func(t *testing.T) {
+		env := setupKioskTest(t, 0)
+		resp, err := env.sut.LookupUser(context.Background(), &inputport.KioskLookupUserRequest{
+			DeviceID: env.device.ID,
+			QRCode:   entities.GeneratePersonalQRCode(env.user.ID),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, env.user.ID, resp.User.ID)
+		assert.False(t, resp.AlreadyGranted)
+	})
+
+	t.Run("署名付きの個人QRコードでユーザーを検索できる", func(t *testing.T) {
+		env := setupKioskTest(t, 0)
+		qr, err := testQRCodec.Encode(entities.NewPersonalQRPayload(env.user.ID))
+		require.NoError(t, err)
+		resp, err := env.sut.LookupUser(context.Background(), &inputport.KioskLookupUserRequest{
+			DeviceID: env.device.ID,
+			QRCode:   qr,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, env.user.ID, resp.User.ID)
+	})
+
+	t.Run("カードIDでユーザーを検索できる", func(t *testing.T) {
+		env := setupKioskTest(t, 0)
+		env.kiosk.cards["CARD-001"] = &entities.KioskCard{CardID: "CARD-001", UserID: env.user.ID}
+		resp, err := env.sut.LookupUser(context.Background(), &inputport.KioskLookupUserRequest{
+			DeviceID: env.device.ID,
+			CardID:   "CARD-001",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, env.user.ID, resp.User.ID)
+	})
+
+	t.Run("付与済みかどうかは設定したタイムゾーンの暦日で判定する", func(t *testing.T) {
+		env := setupKioskTest(t, 0)
+		// JSTでは同じ10/16だが、ニューヨークでは前日（10/15 22:00）の付与
+		env.kiosk.grants = append(env.kiosk.grants, &entities.KioskGrant{
+			ID: uuid.New(), DeviceID: env.device.ID, UserID: env.user.ID, Amount: 50,
+			CreatedAt: time.Date(2026, 10, 16, 2, 0, 0, 0, time.UTC),
+		})
+		env.clock.now = time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+		lookup := func() bool {
+			resp, err := env.sut.LookupUser(context.Background(), &inputport.KioskLookupUserRequest{
+				DeviceID: env.device.ID,
+				QRCode:   entities.GeneratePersonalQRCode(env.user.ID),
+			})
+			require.NoError(t, err)
+			return resp.AlreadyGranted
+		}
+
+		assert.True(t, lookup())
+
+		env.settings.settings[entities.BonusTimezoneSettingKey] = "America/New_York"
+		assert.False(t, lookup())
+	})
+
+	t.Run("不正なQRコード形式はエラー", func(t *testing.T) {
+		env := setupKioskTest(t, 0)
+		_, err := env.sut.LookupUser(context.Background(), &inputport.KioskLookupUserRequest{
+			DeviceID: env.device.ID,
+			QRCode:   "receive:abc",
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestKioskInteractor_GrantBonus(t *testing.T) {
+	t.Run("正常にボーナスを付与できる", func(t *testing.T) {
+		env := setupKioskTest(t, 0)
+		resp, err := env.sut.GrantBonus(context.Background(), &inputport.KioskGrantBonusRequest{
+			DeviceID:       env.device.ID,
+			QRCode:         entities.GeneratePersonalQRCode(env.user.ID),
+			IdempotencyKey: "kiosk-" + uuid.New().String(),
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.Replayed)
+		assert.Equal(t, int64(50), resp.Grant.Amount)
+		require.Len(t, env.txRepo.transactions, 1)
+		assert.Equal(t, entities.TransactionTypeSystemGrant, env.txRepo.transactions[0].TransactionType)
+	})
+
+	t.Run("txManager.Do内の全呼び出しがトランザクションコンテキストを使用する", func(t *testing.T) {
+		env := setupKioskTest(t, 0)
+		_, err := env.sut.GrantBonus(context.Background(), &inputport.KioskGrantBonusRequest{
+			DeviceID:       env.device.ID,
+			QRCode:         entities.GeneratePersonalQRCode(env.user.ID),
+			IdempotencyKey: "kiosk-" + uuid.New().String(),
+		})
+		require.NoError(t, err)
+		assert.True(t, isTxContext(env.userRepo.ctxRecords["UpdateBalancesWithLock"]))
+		assert.True(t, isTxContext(env.txRepo.ctxRecords["Create"]))
+		assert.True(t, isTxContext(env.pbRepo.ctxRecords["Create"]))
+		assert.True(t, isTxContext(env.kiosk.ctxRecords["CreateGrant"]))
+	})
+
+	t.Run("同じ冪等性キーの再送は既存の結果を返す", func(t *testing.T) {
+		env := setupKioskTest(t, 0)
+		req := &inputport.KioskGrantBonusRequest{
+			DeviceID:       env.device.ID,
+			QRCode:         entities.GeneratePersonalQRCode(env.user.ID),
+			IdempotencyKey: "offline-queue-1",
+		}
+		first, err := env.sut.GrantBonus(context.Background(), req)
+		require.NoError(t, err)
+
+		second, err := env.sut.GrantBonus(context.Background(), req)
+		require.NoError(t, err)
+		assert.True(t, second.Replayed)
+		assert.Equal(t, first.Grant.ID, second.Grant.ID)
+		assert.Len(t, env.txRepo.transactions, 1, "再送で二重付与されない")
+	})
+
+	t.Run("同じ端末で同じユーザーへの1日2回目の付与はエラー", func(t *testing.T) {
+		env := setupKioskTest(t, 0)
+		qr := entities.GeneratePersonalQRCode(env.user.ID)
+		_, err := env.sut.GrantBonus(context.Background(), &inputport.KioskGrantBonusRequest{
+			DeviceID: env.device.ID, QRCode: qr, IdempotencyKey: "k1",
+		})
+		require.NoError(t, err)
+
+		_, err = env.sut.GrantBonus(context.Background(), &inputport.KioskGrantBonusRequest{
+			DeviceID: env.device.ID, QRCode: qr, IdempotencyKey: "k2",
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already granted")
+	})
+
+	t.Run("日付の判定は注入した現在時刻を使い、翌日は再び付与できる", func(t *testing.T) {
+		env := setupKioskTest(t, 0)
+		qr := entities.GeneratePersonalQRCode(env.user.ID)
+		_, err := env.sut.GrantBonus(context.Background(), &inputport.KioskGrantBonusRequest{
+			DeviceID: env.device.ID, QRCode: qr, IdempotencyKey: "k1",
+		})
+		require.NoError(t, err)
+
+		env.clock.now = env.clock.now.Add(24 * time.Hour)
+		lookup, err := env.sut.LookupUser(context.Background(), &inputport.KioskLookupUserRequest{
+			DeviceID: env.device.ID, QRCode: qr,
+		})
+		require.NoError(t, err)
+		assert.False(t, lookup.AlreadyGranted)
+
+		_, err = env.sut.GrantBonus(context.Background(), &inputport.KioskGrantBonusRequest{
+			DeviceID: env.device.ID, QRCode: qr, IdempotencyKey: "k2",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("端末の日次上限に達した場合はエラー", func(t *testing.T) {
+		env := setupKioskTest(t, 1)
+		other := createTestUserWithBalance(t, "other", 0, "user")
+		env.userRepo.setUser(other)
+
+		_, err := env.sut.GrantBonus(context.Background(), &inputport.KioskGrantBonusRequest{
+			DeviceID: env.device.ID, QRCode: entities.GeneratePersonalQRCode(env.user.ID), IdempotencyKey: "k1",
+		})
+		require.NoError(t, err)
+
+		_, err = env.sut.GrantBonus(context.Background(), &inputport.KioskGrantBonusRequest{
+			DeviceID: env.device.ID, QRCode: entities.GeneratePersonalQRCode(other.ID), IdempotencyKey: "k2",
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "daily limit")
+	})
+
+	t.Run("冪等性キーがない場合はエラー", func(t *testing.T) {
+		env := setupKioskTest(t, 0)
+		_, err := env.sut.GrantBonus(context.Background(), &inputport.KioskGrantBonusRequest{
+			DeviceID: env.device.ID, QRCode: entities.GeneratePersonalQRCode(env.user.ID),
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestKioskInteractor_RegisterDevice(t *testing.T) {
+	t.Run("管理者は端末を登録でき、平文APIキーが返る", func(t *testing.T) {
+		env := setupKioskTest(t, 0)
+		resp, err := env.sut.RegisterDevice(context.Background(), &inputport.RegisterKioskDeviceRequest{
+			AdminID: env.admin.ID, Name: "イベント会場", BonusAmount: 100, DailyLimit: 200,
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp.APIKey)
+		assert.Equal(t, entities.HashKioskAPIKey(resp.APIKey), resp.Device.APIKeyHash)
+	})
+
+	t.Run("一般ユーザーは端末を登録できない", func(t *testing.T) {
+		env := setupKioskTest(t, 0)
+		_, err := env.sut.RegisterDevice(context.Background(), &inputport.RegisterKioskDeviceRequest{
+			AdminID: env.user.ID, Name: "不正端末", BonusAmount: 100,
+		})
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
+
+func TestKioskInteractor_RotateDeviceKey(t *testing.T) {
+	t.Run("再発行後は古いAPIキーで認証できない", func(t *testing.T) {
+		env := setupKioskTest(t, 0)
+		resp, err := env.sut.RotateDeviceKey(context.Background(), &inputport.RotateKioskDeviceKeyRequest{
+			AdminID: env.admin.ID, DeviceID: env.device.ID,
+		})
+		require.NoError(t, err)
+		assert.NotEqual(t, env.apiKey, resp.APIKey)
+
+		_, err = env.sut.AuthenticateDevice(context.Background(), env.apiKey)
+		assert.Error(t, err)
+		_, err = env.sut.AuthenticateDevice(context.Background(), resp.APIKey)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,100 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSystemSettingsRepo は未設定のキーに空文字を返す（実装と同じ挙動）
+type mockSystemSettingsRepo struct {
+	settings map[string]string
+	getCalls int
+	getErr   error
+}
+
+func newMockSystemSettingsRepo() *mockSystemSettingsRepo {
+	return &mockSystemSettingsRepo{settings: make(map[string]string)}
+}
+
+func (m *mockSystemSettingsRepo) GetSetting(ctx context.Context, key string) (string, error) {
+	m.getCalls++
+	if m.getErr != nil {
+		return "", m.getErr
+	}
+	return m.settings[key], nil
+}
+
+func (m *mockSystemSettingsRepo) SetSetting(ctx context.Context, key, value, description string) error {
+	m.settings[key] = value
+	return nil
+}
+
+func TestMaintenanceInteractor(t *testing.T) {
+	setup := func() (*mockSystemSettingsRepo, inputport.MaintenanceInputPort, *entities.User, *entities.User) {
+		settingsRepo := newMockSystemSettingsRepo()
+		userRepo := newMockUserRepo()
+		admin := createTestUserWithBalance(t, "admin", 0, "admin")
+		user := createTestUserWithBalance(t, "user", 0, "user")
+		userRepo.addUser(admin)
+		userRepo.addUser(user)
+		return settingsRepo, interactor.NewMaintenanceInteractor(settingsRepo, userRepo, &mockLogger{}), admin, user
+	}
+
+	t.Run("未設定ならメンテナンス中でない", func(t *testing.T) {
+		_, sut, _, _ := setup()
+		mode, err := sut.GetMaintenanceMode(context.Background())
+		require.NoError(t, err)
+		assert.False(t, mode.Enabled)
+		assert.False(t, sut.IsActive(context.Background()))
+	})
+
+	t.Run("管理者が有効にすると許可リストのユーザーだけが操作できる", func(t *testing.T) {
+		settingsRepo, sut, admin, user := setup()
+		resp, err := sut.UpdateMaintenanceMode(context.Background(), &inputport.UpdateMaintenanceModeRequest{
+			AdminID: admin.ID, Enabled: true, Message: "22時まで停止します", AllowedUserIDs: []uuid.UUID{admin.ID},
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.Mode.Enabled)
+		assert.Contains(t, settingsRepo.settings[entities.MaintenanceSettingKey], "22時まで停止します")
+
+		mode, err := sut.GetMaintenanceMode(context.Background())
+		require.NoError(t, err)
+		assert.True(t, mode.CanBypass(admin.ID))
+		assert.False(t, mode.CanBypass(user.ID))
+		assert.True(t, sut.IsActive(context.Background()))
+	})
+
+	t.Run("一般ユーザーは切り替えられない", func(t *testing.T) {
+		_, sut, _, user := setup()
+		_, err := sut.UpdateMaintenanceMode(context.Background(), &inputport.UpdateMaintenanceModeRequest{
+			AdminID: user.ID, Enabled: true,
+		})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+
+		_, err = sut.GetMaintenanceSettings(context.Background(), user.ID)
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+
+	t.Run("設定はキャッシュされ、毎回DBを読まない", func(t *testing.T) {
+		settingsRepo, sut, _, _ := setup()
+		for n := 0; n < 3; n++ {
+			_, err := sut.GetMaintenanceMode(context.Background())
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 1, settingsRepo.getCalls)
+	})
+
+	t.Run("設定を読めない場合はメンテナンス中でないとみなす", func(t *testing.T) {
+		settingsRepo, sut, _, _ := setup()
+		settingsRepo.getErr = errors.New("db down")
+		assert.False(t, sut.IsActive(context.Background()))
+	})
+}
@@ -0,0 +1,416 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockNotificationDispatcher は届けた通知を記録する
+type mockNotificationDispatcher struct {
+	notifications []*entities.Notification
+}
+
+func (m *mockNotificationDispatcher) Dispatch(ctx context.Context, notification *entities.Notification) {
+	m.notifications = append(m.notifications, notification)
+}
+
+// mockNotificationRepo は通知リポジトリのモック
+type mockNotificationRepo struct {
+	devices  []*entities.DeviceToken
+	prefs    map[uuid.UUID]*entities.NotificationPreferences
+	batches  []*entities.PointBatch
+	reminded []uuid.UUID
+}
+
+func newMockNotificationRepo() *mockNotificationRepo {
+	return &mockNotificationRepo{prefs: make(map[uuid.UUID]*entities.NotificationPreferences)}
+}
+
+func (m *mockNotificationRepo) UpsertDeviceToken(ctx context.Context, device *entities.DeviceToken) error {
+	for _, d := range m.devices {
+		if d.Token == device.Token {
+			d.UserID = device.UserID
+			d.LastSeenAt = device.LastSeenAt
+			return nil
+		}
+	}
+	m.devices = append(m.devices, device)
+	return nil
+}
+
+func (m *mockNotificationRepo) DeleteDeviceToken(ctx context.Context, userID uuid.UUID, token string) error {
+	for i, d := range m.devices {
+		if d.UserID == userID && d.Token == token {
+			m.devices = append(m.devices[:i], m.devices[i+1:]...)
+			return nil
+		}
+	}
+	return entities.ErrDeviceTokenNotFound
+}
+
+func (m *mockNotificationRepo) DeleteDeviceTokenByID(ctx context.Context, id uuid.UUID) error {
+	for i, d := range m.devices {
+		if d.ID == id {
+			m.devices = append(m.devices[:i], m.devices[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *mockNotificationRepo) ReadDeviceTokensByUser(ctx context.Context, userID uuid.UUID) ([]*entities.DeviceToken, error) {
+	var result []*entities.DeviceToken
+	for _, d := range m.devices {
+		if d.UserID == userID {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockNotificationRepo) PruneDeviceTokens(ctx context.Context, userID uuid.UUID, keep int) error {
+	return nil
+}
+
+func (m *mockNotificationRepo) ReadPreferences(ctx context.Context, userID uuid.UUID) (*entities.NotificationPreferences, error) {
+	if p, ok := m.prefs[userID]; ok {
+		copied := *p
+		return &copied, nil
+	}
+	return entities.DefaultNotificationPreferences(userID), nil
+}
+
+func (m *mockNotificationRepo) SavePreferences(ctx context.Context, prefs *entities.NotificationPreferences) error {
+	m.prefs[prefs.UserID] = prefs
+	return nil
+}
+
+func (m *mockNotificationRepo) ReadExpiringBatches(ctx context.Context, now time.Time, within time.Duration, limit int) ([]*entities.PointBatch, error) {
+	if len(m.batches) > limit {
+		return m.batches[:limit], nil
+	}
+	return m.batches, nil
+}
+
+func (m *mockNotificationRepo) MarkExpiryReminded(ctx context.Context, batchIDs []uuid.UUID, remindedAt time.Time) error {
+	m.reminded = append(m.reminded, batchIDs...)
+	return nil
+}
+
+// mockPushService は送信した端末を記録し、指定したトークンは無効として扱う
+type mockPushService struct {
+	sent          []*entities.DeviceToken
+	notifications []*entities.Notification
+	unregistered  map[string]bool
+}
+
+func (m *mockPushService) Send(ctx context.Context, device *entities.DeviceToken, notification *entities.Notification) error {
+	if m.unregistered[device.Token] {
+		return service.ErrPushTokenUnregistered
+	}
+	m.sent = append(m.sent, device)
+	m.notifications = append(m.notifications, notification)
+	return nil
+}
+
+func newNotificationTestDevice(t *testing.T, userID uuid.UUID, token string) *entities.DeviceToken {
+	t.Helper()
+	device, err := entities.NewDeviceToken(userID, entities.DevicePlatformAndroid, token)
+	require.NoError(t, err)
+	return device
+}
+
+func TestNotificationInteractor_Dispatch(t *testing.T) {
+	userID := uuid.New()
+	notification := entities.NewPointsExpiringNotification(userID, 100, time.Now().Add(48*time.Hour))
+
+	t.Run("登録済みの全端末へ送る", func(t *testing.T) {
+		repo := newMockNotificationRepo()
+		repo.devices = []*entities.DeviceToken{
+			newNotificationTestDevice(t, userID, "token-a"),
+			newNotificationTestDevice(t, userID, "token-b"),
+			newNotificationTestDevice(t, uuid.New(), "other-user"),
+		}
+		push := &mockPushService{}
+		sut := interactor.NewNotificationInteractor(repo, newCtxTrackingUserRepo(), push, &mockEmailService{}, &mockRealtimeNotifier{}, &mockLogger{})
+
+		sut.Dispatch(context.Background(), notification)
+
+		assert.Len(t, push.sent, 2)
+	})
+
+	t.Run("プッシュ通知をオフにしていれば送らない", func(t *testing.T) {
+		repo := newMockNotificationRepo()
+		repo.devices = []*entities.DeviceToken{newNotificationTestDevice(t, userID, "token-a")}
+		prefs := entities.DefaultNotificationPreferences(userID)
+		prefs.PushEnabled = false
+		repo.prefs[userID] = prefs
+		push := &mockPushService{}
+		sut := interactor.NewNotificationInteractor(repo, newCtxTrackingUserRepo(), push, &mockEmailService{}, &mockRealtimeNotifier{}, &mockLogger{})
+
+		sut.Dispatch(context.Background(), notification)
+
+		assert.Empty(t, push.sent)
+	})
+
+	t.Run("種類ごとにオフにした通知は送らない", func(t *testing.T) {
+		repo := newMockNotificationRepo()
+		repo.devices = []*entities.DeviceToken{newNotificationTestDevice(t, userID, "token-a")}
+		prefs := entities.DefaultNotificationPreferences(userID)
+		prefs.PointsExpiring = false
+		repo.prefs[userID] = prefs
+		push := &mockPushService{}
+		sut := interactor.NewNotificationInteractor(repo, newCtxTrackingUserRepo(), push, &mockEmailService{}, &mockRealtimeNotifier{}, &mockLogger{})
+
+		sut.Dispatch(context.Background(), notification)
+		assert.Empty(t, push.sent)
+
+		// 他の種類は届く
+		sut.Dispatch(context.Background(), &entities.Notification{UserID: userID, Type: entities.NotificationTypePointsReceived})
+		assert.Len(t, push.sent, 1)
+	})
+
+	t.Run("無効と判定されたトークンは削除する", func(t *testing.T) {
+		repo := newMockNotificationRepo()
+		repo.devices = []*entities.DeviceToken{
+			newNotificationTestDevice(t, userID, "stale"),
+			newNotificationTestDevice(t, userID, "fresh"),
+		}
+		push := &mockPushService{unregistered: map[string]bool{"stale": true}}
+		sut := interactor.NewNotificationInteractor(repo, newCtxTrackingUserRepo(), push, &mockEmailService{}, &mockRealtimeNotifier{}, &mockLogger{})
+
+		sut.Dispatch(context.Background(), notification)
+
+		require.Len(t, repo.devices, 1)
+		assert.Equal(t, "fresh", repo.devices[0].Token)
+		assert.Len(t, push.sent, 1)
+	})
+}
+
+func TestNotificationInteractor_DispatchChannels(t *testing.T) {
+	setup := func(t *testing.T) (*mockNotificationRepo, *mockPushService, *mockEmailService, *mockRealtimeNotifier, inputport.NotificationInputPort, *entities.User) {
+		userRepo := newCtxTrackingUserRepo()
+		user := createTestUserWithBalance(t, "notifyuser", 0, "user")
+		userRepo.setUser(user)
+		repo := newMockNotificationRepo()
+		repo.devices = []*entities.DeviceToken{newNotificationTestDevice(t, user.ID, "token-a")}
+		push, email, notifier := &mockPushService{}, &mockEmailService{}, &mockRealtimeNotifier{}
+		sut := interactor.NewNotificationInteractor(repo, userRepo, push, email, notifier, &mockLogger{})
+		return repo, push, email, notifier, sut, user
+	}
+
+	t.Run("種類ごとに決まった経路で届ける", func(t *testing.T) {
+		_, push, email, notifier, sut, user := setup(t)
+
+		sut.Dispatch(context.Background(), entities.NewPointsExpiringNotification(user.ID, 100, time.Now().Add(48*time.Hour)))
+
+		assert.Len(t, push.sent, 1)
+		assert.Equal(t, []string{user.Email}, email.notificationAddrs)
+		require.Len(t, notifier.events, 1)
+		assert.Equal(t, entities.RealtimeTopicNotifications, notifier.events[0].event.Topic)
+	})
+
+	t.Run("ログイン通知は専用のメールで送る", func(t *testing.T) {
+		_, push, email, notifier, sut, user := setup(t)
+
+		sut.Dispatch(context.Background(), entities.NewLoginNotification(user, "10.0.0.1", "Phone/2.0", "JP", time.Now()))
+
+		assert.Equal(t, []string{user.Email}, email.newLoginAddrs)
+		assert.Empty(t, email.notificationAddrs)
+		assert.Len(t, push.sent, 1)
+		assert.Empty(t, notifier.events)
+	})
+
+	t.Run("メールをオフにするとメールだけ止まる", func(t *testing.T) {
+		repo, push, email, notifier, sut, user := setup(t)
+		prefs := entities.DefaultNotificationPreferences(user.ID)
+		prefs.EmailEnabled = false
+		repo.prefs[user.ID] = prefs
+
+		sut.Dispatch(context.Background(), entities.NewPointsExpiringNotification(user.ID, 100, time.Now().Add(48*time.Hour)))
+
+		assert.Empty(t, email.notificationAddrs)
+		assert.Len(t, push.sent, 1)
+		assert.Len(t, notifier.events, 1)
+	})
+
+	t.Run("おやすみ時間はプッシュ通知だけ止まる", func(t *testing.T) {
+		repo, push, email, notifier, sut, user := setup(t)
+		prefs := entities.DefaultNotificationPreferences(user.ID)
+		// 開始と終了が同じ時刻なら終日
+		prefs.QuietHours = entities.QuietHours{Enabled: true, Start: 0, End: 0, Timezone: "UTC"}
+		repo.prefs[user.ID] = prefs
+
+		sut.Dispatch(context.Background(), entities.NewPointsExpiringNotification(user.ID, 100, time.Now().Add(48*time.Hour)))
+
+		assert.Empty(t, push.sent)
+		assert.Len(t, email.notificationAddrs, 1)
+		assert.Len(t, notifier.events, 1)
+	})
+
+	t.Run("種類をオフにするとどの経路でも届かない", func(t *testing.T) {
+		repo, push, email, notifier, sut, user := setup(t)
+		prefs := entities.DefaultNotificationPreferences(user.ID)
+		prefs.NewLogin = false
+		repo.prefs[user.ID] = prefs
+
+		sut.Dispatch(context.Background(), entities.NewLoginNotification(user, "10.0.0.1", "Phone/2.0", "JP", time.Now()))
+
+		assert.Empty(t, push.sent)
+		assert.Empty(t, email.newLoginAddrs)
+		assert.Empty(t, notifier.events)
+	})
+}
+
+func TestNotificationInteractor_RegisterDevice(t *testing.T) {
+	t.Run("端末を登録し、同じトークンは別ユーザーへ付け替える", func(t *testing.T) {
+		repo := newMockNotificationRepo()
+		sut := interactor.NewNotificationInteractor(repo, newCtxTrackingUserRepo(), &mockPushService{}, &mockEmailService{}, &mockRealtimeNotifier{}, &mockLogger{})
+		first, second := uuid.New(), uuid.New()
+
+		_, err := sut.RegisterDevice(context.Background(), &inputport.RegisterDeviceRequest{
+			UserID: first, Platform: entities.DevicePlatformIOS, Token: "device-1",
+		})
+		require.NoError(t, err)
+		_, err = sut.RegisterDevice(context.Background(), &inputport.RegisterDeviceRequest{
+			UserID: second, Platform: entities.DevicePlatformIOS, Token: "device-1",
+		})
+		require.NoError(t, err)
+
+		require.Len(t, repo.devices, 1)
+		assert.Equal(t, second, repo.devices[0].UserID)
+	})
+
+	t.Run("不明なプラットフォームはエラー", func(t *testing.T) {
+		sut := interactor.NewNotificationInteractor(newMockNotificationRepo(), newCtxTrackingUserRepo(), &mockPushService{}, &mockEmailService{}, &mockRealtimeNotifier{}, &mockLogger{})
+
+		_, err := sut.RegisterDevice(context.Background(), &inputport.RegisterDeviceRequest{
+			UserID: uuid.New(), Platform: "windows", Token: "device-1",
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidDeviceToken)
+	})
+
+	t.Run("他人の端末は登録解除できない", func(t *testing.T) {
+		repo := newMockNotificationRepo()
+		owner := uuid.New()
+		repo.devices = []*entities.DeviceToken{newNotificationTestDevice(t, owner, "device-1")}
+		sut := interactor.NewNotificationInteractor(repo, newCtxTrackingUserRepo(), &mockPushService{}, &mockEmailService{}, &mockRealtimeNotifier{}, &mockLogger{})
+
+		err := sut.UnregisterDevice(context.Background(), &inputport.UnregisterDeviceRequest{UserID: uuid.New(), Token: "device-1"})
+		assert.ErrorIs(t, err, entities.ErrDeviceTokenNotFound)
+
+		err = sut.UnregisterDevice(context.Background(), &inputport.UnregisterDeviceRequest{UserID: owner, Token: "device-1"})
+		require.NoError(t, err)
+		assert.Empty(t, repo.devices)
+	})
+}
+
+func TestNotificationInteractor_UpdatePreferences(t *testing.T) {
+	t.Run("指定した項目だけが変わる", func(t *testing.T) {
+		repo := newMockNotificationRepo()
+		sut := interactor.NewNotificationInteractor(repo, newCtxTrackingUserRepo(), &mockPushService{}, &mockEmailService{}, &mockRealtimeNotifier{}, &mockLogger{})
+		userID := uuid.New()
+		off := false
+
+		prefs, err := sut.UpdatePreferences(context.Background(), &inputport.UpdateNotificationPreferencesRequest{
+			UserID:         userID,
+			PointsReceived: &off,
+			EmailEnabled:   &off,
+		})
+		require.NoError(t, err)
+
+		assert.True(t, prefs.PushEnabled)
+		assert.False(t, prefs.EmailEnabled)
+		assert.True(t, prefs.TransferRequests)
+		assert.False(t, prefs.PointsReceived)
+		assert.True(t, prefs.PointsExpiring)
+		assert.True(t, prefs.NewLogin)
+		assert.False(t, prefs.QuietHours.Enabled)
+	})
+
+	t.Run("おやすみ時間を設定できる", func(t *testing.T) {
+		repo := newMockNotificationRepo()
+		sut := interactor.NewNotificationInteractor(repo, newCtxTrackingUserRepo(), &mockPushService{}, &mockEmailService{}, &mockRealtimeNotifier{}, &mockLogger{})
+		userID := uuid.New()
+
+		prefs, err := sut.UpdatePreferences(context.Background(), &inputport.UpdateNotificationPreferencesRequest{
+			UserID:     userID,
+			QuietHours: &inputport.QuietHoursRequest{Enabled: true, Start: "23:30", End: "06:00"},
+		})
+		require.NoError(t, err)
+
+		assert.True(t, prefs.QuietHours.Enabled)
+		assert.Equal(t, "23:30", prefs.QuietHours.StartString())
+		assert.Equal(t, "06:00", prefs.QuietHours.EndString())
+		assert.Equal(t, entities.DefaultQuietHoursTimezone, prefs.QuietHours.Timezone)
+	})
+
+	t.Run("不正なおやすみ時間はエラー", func(t *testing.T) {
+		sut := interactor.NewNotificationInteractor(newMockNotificationRepo(), newCtxTrackingUserRepo(), &mockPushService{}, &mockEmailService{}, &mockRealtimeNotifier{}, &mockLogger{})
+
+		for _, q := range []*inputport.QuietHoursRequest{
+			{Enabled: true, Start: "24:00", End: "06:00"},
+			{Enabled: true, Start: "7:00", End: "06:00"},
+			{Enabled: true, Start: "22:00", End: "06:00", Timezone: "Mars/Olympus"},
+		} {
+			_, err := sut.UpdatePreferences(context.Background(), &inputport.UpdateNotificationPreferencesRequest{
+				UserID: uuid.New(), QuietHours: q,
+			})
+			assert.ErrorIs(t, err, entities.ErrInvalidQuietHours)
+		}
+	})
+}
+
+func TestNotificationInteractor_SendExpiryReminders(t *testing.T) {
+	now := time.Now()
+	userA, userB := uuid.New(), uuid.New()
+	batch := func(userID uuid.UUID, amount int64, expiresIn time.Duration) *entities.PointBatch {
+		return &entities.PointBatch{ID: uuid.New(), UserID: userID, RemainingAmount: amount, ExpiresAt: now.Add(expiresIn)}
+	}
+
+	t.Run("ユーザーごとにまとめて1件通知し、通知済みにする", func(t *testing.T) {
+		repo := newMockNotificationRepo()
+		repo.batches = []*entities.PointBatch{
+			batch(userA, 100, 72*time.Hour),
+			batch(userA, 50, 24*time.Hour),
+			batch(userB, 30, 48*time.Hour),
+		}
+		repo.devices = []*entities.DeviceToken{newNotificationTestDevice(t, userA, "token-a")}
+		push := &mockPushService{}
+		sut := interactor.NewNotificationInteractor(repo, newCtxTrackingUserRepo(), push, &mockEmailService{}, &mockRealtimeNotifier{}, &mockLogger{})
+
+		notified, err := sut.SendExpiryReminders(context.Background(), now, 100)
+		require.NoError(t, err)
+		assert.Equal(t, 2, notified)
+		assert.Len(t, repo.reminded, 3)
+
+		// 合計ポイントと最も早い期限で通知する
+		require.Len(t, push.notifications, 1)
+		assert.Equal(t, "150", push.notifications[0].Data["amount"])
+		assert.Equal(t, now.Add(24*time.Hour).Format(time.RFC3339), push.notifications[0].Data["expires_at"])
+	})
+
+	t.Run("上限まで取得した場合は最後のユーザーを次回に回す", func(t *testing.T) {
+		repo := newMockNotificationRepo()
+		repo.batches = []*entities.PointBatch{
+			batch(userA, 100, 72*time.Hour),
+			batch(userB, 30, 48*time.Hour),
+			batch(userB, 20, 96*time.Hour),
+		}
+		sut := interactor.NewNotificationInteractor(repo, newCtxTrackingUserRepo(), &mockPushService{}, &mockEmailService{}, &mockRealtimeNotifier{}, &mockLogger{})
+
+		notified, err := sut.SendExpiryReminders(context.Background(), now, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 1, notified)
+		assert.Equal(t, []uuid.UUID{repo.batches[0].ID}, repo.reminded)
+	})
+}
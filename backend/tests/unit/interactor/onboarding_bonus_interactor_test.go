@@ -0,0 +1,172 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockOnboardingBonusGranter は bonus を付与したことにする（nilなら付与しない）
+type mockOnboardingBonusGranter struct {
+	bonus   *entities.Transaction
+	err     error
+	granted []uuid.UUID
+}
+
+func (m *mockOnboardingBonusGranter) GrantOnboardingBonus(ctx context.Context, userID uuid.UUID) (*entities.Transaction, error) {
+	m.granted = append(m.granted, userID)
+	return m.bonus, m.err
+}
+
+func TestOnboardingBonusInteractor(t *testing.T) {
+	ctx := context.Background()
+
+	type fixture struct {
+		repos *testsupport.Repositories
+		sut   *interactor.OnboardingBonusInteractor
+		admin *entities.User
+		user  *entities.User
+	}
+	setup := func(t *testing.T) *fixture {
+		f := &fixture{repos: testsupport.New()}
+		f.admin = createTestUserWithBalance(t, "admin", 0, entities.RoleAdmin)
+		f.user = createTestUserWithBalance(t, "newcomer", 0, entities.RoleUser)
+		f.repos.Users.Seed(f.admin, f.user)
+		f.sut = interactor.NewOnboardingBonusInteractor(f.repos.TxManager, f.repos.SystemSettings, f.repos.Users,
+			f.repos.Transactions, f.repos.PointBatches, &mockLogger{})
+		return f
+	}
+	enable := func(t *testing.T, f *fixture, amount int64, validityDays int) {
+		_, err := f.sut.UpdatePolicy(ctx, &inputport.UpdateOnboardingBonusRequest{
+			AdminID: f.admin.ID, Enabled: true, Amount: amount, ValidityDays: validityDays,
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("未設定なら付与しない", func(t *testing.T) {
+		f := setup(t)
+		tx, err := f.sut.GrantOnboardingBonus(ctx, f.user.ID)
+		require.NoError(t, err)
+		assert.Nil(t, tx)
+		assert.Equal(t, 0, f.repos.Transactions.Calls("Create"))
+	})
+
+	t.Run("取引・残高・有効期限つきのバッチとして付与する", func(t *testing.T) {
+		f := setup(t)
+		enable(t, f, 500, 30)
+
+		tx, err := f.sut.GrantOnboardingBonus(ctx, f.user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, tx)
+		assert.Equal(t, entities.TransactionTypeOnboardingBonus, tx.TransactionType)
+		assert.Equal(t, entities.SystemAccountTreasury, tx.FromAccount)
+		assert.Equal(t, int64(500), tx.Amount)
+		assert.Equal(t, 1, f.repos.TxManager.Calls("Do"))
+
+		user, err := f.repos.Users.Read(ctx, f.user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(500), user.Balance)
+
+		batches, err := f.repos.PointBatches.FindActiveByUser(ctx, f.user.ID)
+		require.NoError(t, err)
+		require.Len(t, batches, 1)
+		assert.Equal(t, entities.PointBatchSourceOnboardingBonus, batches[0].SourceType)
+		assert.WithinDuration(t, tx.CreatedAt.AddDate(0, 0, 30), batches[0].ExpiresAt, time.Second)
+		assert.True(t, batches[0].HasFixedExpiry(), "有効期間の設定の変更で再計算しない")
+	})
+
+	t.Run("同じユーザーには1回だけ付与する", func(t *testing.T) {
+		f := setup(t)
+		enable(t, f, 500, 0)
+
+		_, err := f.sut.GrantOnboardingBonus(ctx, f.user.ID)
+		require.NoError(t, err)
+		tx, err := f.sut.GrantOnboardingBonus(ctx, f.user.ID)
+		require.NoError(t, err)
+		assert.Nil(t, tx)
+
+		user, err := f.repos.Users.Read(ctx, f.user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(500), user.Balance)
+	})
+
+	t.Run("無効にすると付与しない", func(t *testing.T) {
+		f := setup(t)
+		enable(t, f, 500, 0)
+		policy, err := f.sut.UpdatePolicy(ctx, &inputport.UpdateOnboardingBonusRequest{AdminID: f.admin.ID, Amount: 500})
+		require.NoError(t, err)
+		assert.False(t, policy.Enabled)
+
+		tx, err := f.sut.GrantOnboardingBonus(ctx, f.user.ID)
+		require.NoError(t, err)
+		assert.Nil(t, tx)
+	})
+
+	t.Run("バッチの保存に失敗した場合はエラー", func(t *testing.T) {
+		f := setup(t)
+		enable(t, f, 500, 0)
+		f.repos.PointBatches.FailOn("Create", errors.New("db down"))
+
+		_, err := f.sut.GrantOnboardingBonus(ctx, f.user.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("設定の検証と権限", func(t *testing.T) {
+		f := setup(t)
+		_, err := f.sut.UpdatePolicy(ctx, &inputport.UpdateOnboardingBonusRequest{AdminID: f.admin.ID, Enabled: true})
+		assert.ErrorIs(t, err, entities.ErrInvalidOnboardingBonus, "有効にするなら金額が必要")
+		_, err = f.sut.UpdatePolicy(ctx, &inputport.UpdateOnboardingBonusRequest{AdminID: f.admin.ID, Enabled: true, Amount: 100, ValidityDays: -1})
+		assert.ErrorIs(t, err, entities.ErrInvalidOnboardingBonus)
+		_, err = f.sut.UpdatePolicy(ctx, &inputport.UpdateOnboardingBonusRequest{AdminID: f.user.ID, Enabled: true, Amount: 100})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		_, err = f.sut.GetPolicy(ctx, f.user.ID)
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+
+		enable(t, f, 300, 90)
+		policy, err := f.sut.GetPolicy(ctx, f.admin.ID)
+		require.NoError(t, err)
+		assert.True(t, policy.Enabled)
+		assert.Equal(t, int64(300), policy.Amount)
+		assert.Equal(t, 90, policy.ValidityDays)
+		require.NotNil(t, policy.UpdatedBy)
+		assert.Equal(t, f.admin.ID, *policy.UpdatedBy)
+	})
+}
+
+func TestAuthInteractor_RegisterOnboardingBonus(t *testing.T) {
+	register := func(t *testing.T, granter *mockOnboardingBonusGranter) (*inputport.RegisterResponse, error) {
+		sut := interactor.NewAuthInteractor(newCtxTrackingUserRepo(), newMockSessionRepo(), newMockLoginAttemptRepo(),
+			&mockPasswordService{}, &mockEmailService{}, &mockNotificationDispatcher{}, &mockReferralTracker{}, granter, &mockEmailVerificationGate{}, &mockLogger{})
+		return sut.Register(context.Background(), &inputport.RegisterRequest{
+			Username: "newcomer", Email: "newcomer@example.com",
+			Password: "password123", DisplayName: "New Comer",
+			FirstName: "太郎", LastName: "田中",
+		})
+	}
+
+	t.Run("付与したボーナスを残高に含めて返す", func(t *testing.T) {
+		granter := &mockOnboardingBonusGranter{bonus: &entities.Transaction{Amount: 500, TransactionType: entities.TransactionTypeOnboardingBonus}}
+		resp, err := register(t, granter)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{resp.User.ID}, granter.granted)
+		assert.Equal(t, int64(500), resp.User.Balance)
+		assert.Same(t, granter.bonus, resp.OnboardingBonus)
+	})
+
+	t.Run("付与に失敗しても登録は完了する", func(t *testing.T) {
+		resp, err := register(t, &mockOnboardingBonusGranter{err: errors.New("db down")})
+		require.NoError(t, err)
+		assert.NotNil(t, resp.Session)
+		assert.Zero(t, resp.User.Balance)
+		assert.Nil(t, resp.OnboardingBonus)
+	})
+}
@@ -0,0 +1,92 @@
+package interactor_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/infra/infrapassword"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthInteractor_PasswordRehash(t *testing.T) {
+	ctx := context.Background()
+	argon2id := infrapassword.Argon2idParams{MemoryKiB: 64, Iterations: 1, Parallelism: 1}
+
+	// bcryptで保存したユーザーを、argon2idに切り替えたサービスでログインさせる
+	setup := func(t *testing.T) (*testsupport.Repositories, inputport.AuthInputPort, *entities.User) {
+		t.Helper()
+		legacy := infrapassword.NewBcryptPasswordService(4)
+		hash, err := legacy.HashPassword("password123")
+		require.NoError(t, err)
+
+		current, err := infrapassword.NewPasswordService(infrapassword.Config{Algorithm: infrapassword.AlgorithmArgon2id, BcryptCost: 4, Argon2id: argon2id})
+		require.NoError(t, err)
+
+		repos := testsupport.New()
+		sut := interactor.NewAuthInteractor(
+			repos.Users, repos.Sessions, repos.LoginAttempts,
+			current, &mockEmailService{}, &mockNotificationDispatcher{}, &mockReferralTracker{}, &mockOnboardingBonusGranter{}, &mockEmailVerificationGate{}, &mockLogger{},
+		)
+		user := createTestUserWithBalance(t, "migrating", 0, entities.RoleUser)
+		user.PasswordHash = hash
+		repos.Users.Seed(user)
+		return repos, sut, user
+	}
+	login := func(sut inputport.AuthInputPort, user *entities.User, password string) (*inputport.LoginResponse, error) {
+		return sut.Login(ctx, &inputport.LoginRequest{Username: user.Username, Password: password, IPAddress: "127.0.0.1", UserAgent: "TestAgent"})
+	}
+	storedHash := func(t *testing.T, repos *testsupport.Repositories, user *entities.User) string {
+		t.Helper()
+		stored, err := repos.Users.Read(ctx, user.ID)
+		require.NoError(t, err)
+		return stored.PasswordHash
+	}
+
+	t.Run("ログインに成功したら古いアルゴリズムのハッシュを作り直す", func(t *testing.T) {
+		repos, sut, user := setup(t)
+
+		_, err := login(sut, user, "password123")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(storedHash(t, repos, user), "$argon2id$"))
+
+		_, err = login(sut, user, "password123")
+		assert.NoError(t, err, "作り直したハッシュでログインできる")
+	})
+
+	t.Run("パスワードを間違えたら作り直さない", func(t *testing.T) {
+		repos, sut, user := setup(t)
+
+		_, err := login(sut, user, "wrong")
+		assert.ErrorIs(t, err, entities.ErrInvalidCredentials)
+		assert.Equal(t, user.PasswordHash, storedHash(t, repos, user))
+	})
+
+	t.Run("休止中のアカウントは再開と一緒に作り直す", func(t *testing.T) {
+		repos, sut, user := setup(t)
+		stored, err := repos.Users.Read(ctx, user.ID)
+		require.NoError(t, err)
+		require.NoError(t, stored.DeactivateSelf(stored.CreatedAt))
+		_, err = repos.Users.Update(ctx, stored)
+		require.NoError(t, err)
+
+		resp, err := login(sut, user, "password123")
+		require.NoError(t, err)
+		assert.True(t, resp.Reactivated)
+		assert.True(t, strings.HasPrefix(storedHash(t, repos, user), "$argon2id$"))
+	})
+
+	t.Run("保存に失敗してもログインは続ける", func(t *testing.T) {
+		repos, sut, user := setup(t)
+		repos.Users.FailOn("Update", assert.AnError)
+
+		_, err := login(sut, user, "password123")
+		require.NoError(t, err)
+		assert.Equal(t, user.PasswordHash, storedHash(t, repos, user))
+	})
+}
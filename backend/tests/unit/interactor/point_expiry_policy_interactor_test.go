@@ -0,0 +1,443 @@
+package interactor_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pepMockPointBatchRepo はバッチをメモリに持つ PointBatchRepository のモック
+type pepMockPointBatchRepo struct {
+	batches     map[uuid.UUID]*entities.PointBatch
+	created     []*entities.PointBatch
+	adjustments []*entities.PointBatchAdjustment
+}
+
+func newPEPMockPointBatchRepo() *pepMockPointBatchRepo {
+	return &pepMockPointBatchRepo{batches: make(map[uuid.UUID]*entities.PointBatch)}
+}
+
+func (m *pepMockPointBatchRepo) Create(ctx context.Context, batch *entities.PointBatch) error {
+	m.batches[batch.ID] = batch
+	m.created = append(m.created, batch)
+	return nil
+}
+func (m *pepMockPointBatchRepo) ConsumePointsFIFO(ctx context.Context, userID uuid.UUID, amount int64) error {
+	return nil
+}
+func (m *pepMockPointBatchRepo) FindExpiredBatches(ctx context.Context, before time.Time, limit int) ([]*entities.PointBatch, error) {
+	return nil, nil
+}
+func (m *pepMockPointBatchRepo) MarkExpired(ctx context.Context, batchID uuid.UUID) error {
+	return nil
+}
+func (m *pepMockPointBatchRepo) FindUpcomingExpirations(ctx context.Context, userID uuid.UUID) ([]*entities.PointBatch, error) {
+	return nil, nil
+}
+func (m *pepMockPointBatchRepo) Read(ctx context.Context, id uuid.UUID) (*entities.PointBatch, error) {
+	b, ok := m.batches[id]
+	if !ok {
+		return nil, entities.ErrPointBatchNotFound
+	}
+	return b, nil
+}
+func (m *pepMockPointBatchRepo) UpdateExpiresAt(ctx context.Context, id uuid.UUID, expiresAt time.Time) error {
+	m.batches[id].ExpiresAt = expiresAt
+	return nil
+}
+func (m *pepMockPointBatchRepo) FindActiveByUser(ctx context.Context, userID uuid.UUID) ([]*entities.PointBatch, error) {
+	var result []*entities.PointBatch
+	for _, b := range m.batches {
+		if b.UserID == userID && b.RemainingAmount > 0 {
+			result = append(result, b)
+		}
+	}
+	return result, nil
+}
+func (m *pepMockPointBatchRepo) MarkRestored(ctx context.Context, id uuid.UUID, restoredAt time.Time) error {
+	b := m.batches[id]
+	if b.RestoredAt != nil {
+		return entities.ErrPointBatchRestored
+	}
+	b.RestoredAt = &restoredAt
+	return nil
+}
+func (m *pepMockPointBatchRepo) FindRestorableBatches(ctx context.Context, expiredAfter time.Time, userID *uuid.UUID, limit int) ([]*entities.PointBatch, error) {
+	var result []*entities.PointBatch
+	for _, b := range m.batches {
+		if b.ExpiredAt != nil && !b.ExpiredAt.Before(expiredAfter) && b.RestoredAt == nil {
+			result = append(result, b)
+		}
+	}
+	return result, nil
+}
+func (m *pepMockPointBatchRepo) FindByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.PointBatch, error) {
+	var result []*entities.PointBatch
+	for _, b := range m.batches {
+		if b.UserID == userID {
+			result = append(result, b)
+		}
+	}
+	return result, nil
+}
+func (m *pepMockPointBatchRepo) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	list, _ := m.FindByUser(ctx, userID, 0, 0)
+	return int64(len(list)), nil
+}
+func (m *pepMockPointBatchRepo) AdjustExpiresAt(ctx context.Context, id uuid.UUID, expiresAt, adjustedAt time.Time) error {
+	b := m.batches[id]
+	b.ExpiresAt = expiresAt
+	b.ExpiryAdjustedAt = &adjustedAt
+	return nil
+}
+func (m *pepMockPointBatchRepo) Cancel(ctx context.Context, id uuid.UUID, remaining int64, cancelledAt time.Time) error {
+	b := m.batches[id]
+	if b.RemainingAmount != remaining {
+		return entities.ErrUpdateConflict
+	}
+	b.CancelledAmount = remaining
+	b.RemainingAmount = 0
+	b.CancelledAt = &cancelledAt
+	return nil
+}
+func (m *pepMockPointBatchRepo) CreateAdjustment(ctx context.Context, adjustment *entities.PointBatchAdjustment) error {
+	m.adjustments = append(m.adjustments, adjustment)
+	return nil
+}
+func (m *pepMockPointBatchRepo) FindAdjustments(ctx context.Context, batchIDs []uuid.UUID) ([]*entities.PointBatchAdjustment, error) {
+	var result []*entities.PointBatchAdjustment
+	for _, a := range m.adjustments {
+		for _, id := range batchIDs {
+			if a.BatchID == id {
+				result = append(result, a)
+			}
+		}
+	}
+	return result, nil
+}
+
+// expiredBatch は失効済みのバッチを追加する
+func (m *pepMockPointBatchRepo) expiredBatch(userID uuid.UUID, amount int64, expiredAt time.Time) *entities.PointBatch {
+	b := entities.NewPointBatch(userID, amount, entities.PointBatchSourceDailyBonus, nil, expiredAt.AddDate(0, -3, 0))
+	b.RemainingAmount = 0
+	b.ExpiredAt = &expiredAt
+	b.ExpiredAmount = amount
+	m.batches[b.ID] = b
+	return b
+}
+
+// mockPointExpiryPolicyRepo は PointExpiryPolicyRepository のモック
+type mockPointExpiryPolicyRepo struct {
+	policies  map[entities.PointBatchSourceType]*entities.PointExpiryPolicy
+	overrides map[uuid.UUID]*entities.UserPointExpiryOverride
+}
+
+func newMockPointExpiryPolicyRepo() *mockPointExpiryPolicyRepo {
+	return &mockPointExpiryPolicyRepo{
+		policies:  make(map[entities.PointBatchSourceType]*entities.PointExpiryPolicy),
+		overrides: make(map[uuid.UUID]*entities.UserPointExpiryOverride),
+	}
+}
+
+func (m *mockPointExpiryPolicyRepo) ReadListPolicies(ctx context.Context) ([]*entities.PointExpiryPolicy, error) {
+	var result []*entities.PointExpiryPolicy
+	for _, p := range m.policies {
+		result = append(result, p)
+	}
+	return result, nil
+}
+func (m *mockPointExpiryPolicyRepo) SavePolicy(ctx context.Context, policy *entities.PointExpiryPolicy) error {
+	m.policies[policy.SourceType] = policy
+	return nil
+}
+func (m *mockPointExpiryPolicyRepo) DeletePolicy(ctx context.Context, sourceType entities.PointBatchSourceType) error {
+	delete(m.policies, sourceType)
+	return nil
+}
+func (m *mockPointExpiryPolicyRepo) ReadUserOverride(ctx context.Context, userID uuid.UUID) (*entities.UserPointExpiryOverride, error) {
+	return m.overrides[userID], nil
+}
+func (m *mockPointExpiryPolicyRepo) SaveUserOverride(ctx context.Context, override *entities.UserPointExpiryOverride) error {
+	m.overrides[override.UserID] = override
+	return nil
+}
+func (m *mockPointExpiryPolicyRepo) DeleteUserOverride(ctx context.Context, userID uuid.UUID) error {
+	delete(m.overrides, userID)
+	return nil
+}
+
+type pointExpiryPolicyFixture struct {
+	sut          inputport.PointExpiryPolicyInputPort
+	batchRepo    *pepMockPointBatchRepo
+	policyRepo   *mockPointExpiryPolicyRepo
+	settingsRepo *mockSystemSettingsRepo
+	txRepo       *ctxTrackingTransactionRepo
+	admin        *entities.User
+	user         *entities.User
+}
+
+func setupPointExpiryPolicyInteractor(t *testing.T) *pointExpiryPolicyFixture {
+	f := &pointExpiryPolicyFixture{
+		batchRepo:    newPEPMockPointBatchRepo(),
+		policyRepo:   newMockPointExpiryPolicyRepo(),
+		settingsRepo: newMockSystemSettingsRepo(),
+		txRepo:       newCtxTrackingTransactionRepo(),
+		admin:        createTestUserWithBalance(t, "admin", 0, "admin"),
+		user:         createTestUserWithBalance(t, "user", 100, "user"),
+	}
+	userRepo := newMockUserRepo()
+	userRepo.addUser(f.admin)
+	userRepo.addUser(f.user)
+	f.sut = interactor.NewPointExpiryPolicyInteractor(
+		&ctxTrackingTxManager{}, f.batchRepo, f.policyRepo, f.settingsRepo, userRepo, f.txRepo, &mockLogger{},
+	)
+	return f
+}
+
+func TestPointExpiryPolicyInteractor_UpdatePolicy(t *testing.T) {
+	t.Run("付与種別の有効期間を設定できる", func(t *testing.T) {
+		f := setupPointExpiryPolicyInteractor(t)
+		policy, err := f.sut.UpdatePolicy(context.Background(), &inputport.UpdateExpiryPolicyRequest{
+			AdminID: f.admin.ID, SourceType: entities.PointBatchSourceAdminGrant, ValidityDays: 180,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 180, policy.ValidityDays)
+		assert.Contains(t, f.policyRepo.policies, entities.PointBatchSourceAdminGrant)
+	})
+
+	t.Run("不明な種別や範囲外の日数はエラー", func(t *testing.T) {
+		f := setupPointExpiryPolicyInteractor(t)
+		for _, req := range []*inputport.UpdateExpiryPolicyRequest{
+			{AdminID: f.admin.ID, SourceType: "unknown", ValidityDays: 30},
+			{AdminID: f.admin.ID, SourceType: entities.PointBatchSourceTransfer, ValidityDays: 0},
+			{AdminID: f.admin.ID, SourceType: entities.PointBatchSourceExpiryRestore, ValidityDays: 30},
+		} {
+			_, err := f.sut.UpdatePolicy(context.Background(), req)
+			assert.ErrorIs(t, err, entities.ErrInvalidExpiryPolicy)
+		}
+	})
+
+	t.Run("一般ユーザーは設定できない", func(t *testing.T) {
+		f := setupPointExpiryPolicyInteractor(t)
+		_, err := f.sut.UpdatePolicy(context.Background(), &inputport.UpdateExpiryPolicyRequest{
+			AdminID: f.user.ID, SourceType: entities.PointBatchSourceTransfer, ValidityDays: 30,
+		})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
+
+func TestPointExpiryPolicyInteractor_UserOverride(t *testing.T) {
+	t.Run("個別設定で有効なバッチの期限が再計算される", func(t *testing.T) {
+		f := setupPointExpiryPolicyInteractor(t)
+		createdAt := time.Now().AddDate(0, 0, -10)
+		batch := entities.NewPointBatch(f.user.ID, 100, entities.PointBatchSourceTransfer, nil, createdAt)
+		require.NoError(t, f.batchRepo.Create(context.Background(), batch))
+
+		_, err := f.sut.SetUserOverride(context.Background(), &inputport.SetUserExpiryOverrideRequest{
+			AdminID: f.admin.ID, UserID: f.user.ID, ValidityDays: 365, Reason: "育休",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, createdAt.AddDate(0, 0, 365), batch.ExpiresAt)
+
+		// 削除すると既定の期限に戻る
+		require.NoError(t, f.sut.DeleteUserOverride(context.Background(), f.admin.ID, f.user.ID))
+		assert.Equal(t, createdAt.AddDate(0, entities.POINT_EXPIRATION_MONTHS, 0), batch.ExpiresAt)
+	})
+
+	t.Run("存在しないユーザーには設定できない", func(t *testing.T) {
+		f := setupPointExpiryPolicyInteractor(t)
+		_, err := f.sut.SetUserOverride(context.Background(), &inputport.SetUserExpiryOverrideRequest{
+			AdminID: f.admin.ID, UserID: uuid.New(), ValidityDays: 30,
+		})
+		assert.ErrorIs(t, err, entities.ErrUserNotFound)
+	})
+}
+
+func TestPointExpiryPolicyInteractor_RestoreBatch(t *testing.T) {
+	t.Run("猶予期間内の失効を取り消すと補填バッチと取引が作られる", func(t *testing.T) {
+		f := setupPointExpiryPolicyInteractor(t)
+		expired := f.batchRepo.expiredBatch(f.user.ID, 40, time.Now().AddDate(0, 0, -3))
+
+		resp, err := f.sut.RestoreBatch(context.Background(), &inputport.RestoreBatchRequest{
+			AdminID: f.admin.ID, BatchID: expired.ID,
+		})
+		require.NoError(t, err)
+
+		assert.NotNil(t, expired.RestoredAt)
+		assert.Equal(t, int64(140), resp.User.Balance)
+		assert.Equal(t, entities.PointBatchSourceExpiryRestore, resp.NewBatch.SourceType)
+		assert.Equal(t, int64(40), resp.NewBatch.RemainingAmount)
+		assert.True(t, resp.NewBatch.ExpiresAt.After(time.Now()))
+		require.Len(t, f.txRepo.transactions, 1)
+		assert.Equal(t, expired.ID.String(), f.txRepo.transactions[0].Metadata["restored_batch_id"])
+		assert.Equal(t, &resp.Transaction.ID, resp.NewBatch.SourceTransactionID)
+
+		// 二重には取り消せない
+		_, err = f.sut.RestoreBatch(context.Background(), &inputport.RestoreBatchRequest{
+			AdminID: f.admin.ID, BatchID: expired.ID,
+		})
+		assert.ErrorIs(t, err, entities.ErrPointBatchRestored)
+	})
+
+	t.Run("猶予期間を過ぎた失効は取り消せない", func(t *testing.T) {
+		f := setupPointExpiryPolicyInteractor(t)
+		f.settingsRepo.settings[entities.PointExpiryGraceDaysSettingKey] = strconv.Itoa(7)
+		expired := f.batchRepo.expiredBatch(f.user.ID, 40, time.Now().AddDate(0, 0, -8))
+
+		_, err := f.sut.RestoreBatch(context.Background(), &inputport.RestoreBatchRequest{
+			AdminID: f.admin.ID, BatchID: expired.ID,
+		})
+		assert.ErrorIs(t, err, entities.ErrPointBatchNotRestorable)
+		assert.Empty(t, f.batchRepo.created)
+	})
+
+	t.Run("失効していないバッチは取り消せない", func(t *testing.T) {
+		f := setupPointExpiryPolicyInteractor(t)
+		batch := entities.NewPointBatch(f.user.ID, 40, entities.PointBatchSourceTransfer, nil, time.Now())
+		require.NoError(t, f.batchRepo.Create(context.Background(), batch))
+
+		_, err := f.sut.RestoreBatch(context.Background(), &inputport.RestoreBatchRequest{
+			AdminID: f.admin.ID, BatchID: batch.ID,
+		})
+		assert.ErrorIs(t, err, entities.ErrPointBatchNotRestorable)
+	})
+
+	t.Run("一覧は猶予期間内のバッチのみ", func(t *testing.T) {
+		f := setupPointExpiryPolicyInteractor(t)
+		f.settingsRepo.settings[entities.PointExpiryGraceDaysSettingKey] = "7"
+		recent := f.batchRepo.expiredBatch(f.user.ID, 10, time.Now().AddDate(0, 0, -2))
+		f.batchRepo.expiredBatch(f.user.ID, 20, time.Now().AddDate(0, 0, -10))
+
+		resp, err := f.sut.ListRestorableBatches(context.Background(), &inputport.ListRestorableBatchesRequest{AdminID: f.admin.ID})
+		require.NoError(t, err)
+		assert.Equal(t, 7, resp.GraceDays)
+		require.Len(t, resp.Batches, 1)
+		assert.Equal(t, recent.ID, resp.Batches[0].ID)
+	})
+}
+
+func TestPointExpiryPolicyInteractor_AdjustBatch(t *testing.T) {
+	t.Run("有効期限を変更すると記録され、有効期間の再計算の対象外になる", func(t *testing.T) {
+		f := setupPointExpiryPolicyInteractor(t)
+		createdAt := time.Now().AddDate(0, 0, -10)
+		batch := entities.NewPointBatch(f.user.ID, 100, entities.PointBatchSourceTransfer, nil, createdAt)
+		require.NoError(t, f.batchRepo.Create(context.Background(), batch))
+		previous := batch.ExpiresAt
+		expiresAt := time.Now().AddDate(2, 0, 0)
+
+		resp, err := f.sut.AdjustBatch(context.Background(), &inputport.AdjustBatchRequest{
+			AdminID: f.admin.ID, BatchID: batch.ID, ExpiresAt: &expiresAt, Reason: "障害のお詫び",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expiresAt, resp.Batch.ExpiresAt)
+		assert.Nil(t, resp.Transaction)
+		require.Len(t, f.batchRepo.adjustments, 1)
+		assert.Equal(t, previous, f.batchRepo.adjustments[0].PreviousExpiresAt)
+		assert.Equal(t, f.admin.ID, f.batchRepo.adjustments[0].AdminID)
+
+		// 個別設定を変えても管理者が決めた期限は変わらない
+		_, err = f.sut.SetUserOverride(context.Background(), &inputport.SetUserExpiryOverrideRequest{
+			AdminID: f.admin.ID, UserID: f.user.ID, ValidityDays: 30, Reason: "テスト",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expiresAt, batch.ExpiresAt)
+	})
+
+	t.Run("取り消すと残っていた分が残高から減算される", func(t *testing.T) {
+		f := setupPointExpiryPolicyInteractor(t)
+		batch := entities.NewPointBatch(f.user.ID, 100, entities.PointBatchSourceAdminGrant, nil, time.Now())
+		batch.RemainingAmount = 60
+		require.NoError(t, f.batchRepo.Create(context.Background(), batch))
+
+		resp, err := f.sut.AdjustBatch(context.Background(), &inputport.AdjustBatchRequest{
+			AdminID: f.admin.ID, BatchID: batch.ID, Cancel: true, Reason: "誤付与",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(40), resp.User.Balance)
+		assert.Equal(t, entities.PointBatchStatusCancelled, resp.Batch.Status(time.Now()))
+		assert.Equal(t, int64(60), resp.Adjustment.CancelledAmount)
+		require.Len(t, f.txRepo.transactions, 1)
+		assert.Equal(t, entities.TransactionTypeAdminDeduct, f.txRepo.transactions[0].TransactionType)
+		assert.Equal(t, batch.ID.String(), f.txRepo.transactions[0].Metadata["cancelled_batch_id"])
+		assert.Equal(t, &resp.Transaction.ID, resp.Adjustment.TransactionID)
+
+		// 取り消し済みのバッチは変更できない
+		expiresAt := time.Now().AddDate(1, 0, 0)
+		_, err = f.sut.AdjustBatch(context.Background(), &inputport.AdjustBatchRequest{
+			AdminID: f.admin.ID, BatchID: batch.ID, ExpiresAt: &expiresAt, Reason: "再延長",
+		})
+		assert.ErrorIs(t, err, entities.ErrPointBatchNotAdjustable)
+	})
+
+	t.Run("期限の変更と取り消しはどちらか一方だけ指定する", func(t *testing.T) {
+		f := setupPointExpiryPolicyInteractor(t)
+		batch := entities.NewPointBatch(f.user.ID, 100, entities.PointBatchSourceTransfer, nil, time.Now())
+		require.NoError(t, f.batchRepo.Create(context.Background(), batch))
+		expiresAt := time.Now().AddDate(1, 0, 0)
+
+		for _, req := range []*inputport.AdjustBatchRequest{
+			{AdminID: f.admin.ID, BatchID: batch.ID, Reason: "理由"},
+			{AdminID: f.admin.ID, BatchID: batch.ID, ExpiresAt: &expiresAt, Cancel: true, Reason: "理由"},
+		} {
+			_, err := f.sut.AdjustBatch(context.Background(), req)
+			assert.ErrorIs(t, err, entities.ErrInvalidBatchAdjustment)
+		}
+		assert.Empty(t, f.batchRepo.adjustments)
+	})
+
+	t.Run("ユーザーのバッチ一覧に調整の記録が付く", func(t *testing.T) {
+		f := setupPointExpiryPolicyInteractor(t)
+		batch := entities.NewPointBatch(f.user.ID, 100, entities.PointBatchSourceTransfer, nil, time.Now())
+		require.NoError(t, f.batchRepo.Create(context.Background(), batch))
+		expiresAt := time.Now().AddDate(1, 0, 0)
+		_, err := f.sut.AdjustBatch(context.Background(), &inputport.AdjustBatchRequest{
+			AdminID: f.admin.ID, BatchID: batch.ID, ExpiresAt: &expiresAt, Reason: "延長",
+		})
+		require.NoError(t, err)
+
+		resp, err := f.sut.ListUserBatches(context.Background(), &inputport.ListUserBatchesRequest{AdminID: f.admin.ID, UserID: f.user.ID})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), resp.Total)
+		require.Len(t, resp.Batches, 1)
+		assert.Len(t, resp.Adjustments[batch.ID], 1)
+
+		_, err = f.sut.ListUserBatches(context.Background(), &inputport.ListUserBatchesRequest{AdminID: f.user.ID, UserID: f.user.ID})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
+
+func TestPointExpiryPolicyInteractor_GetWorkerStatus(t *testing.T) {
+	t.Run("一度も実行していなければnil", func(t *testing.T) {
+		f := setupPointExpiryPolicyInteractor(t)
+		run, err := f.sut.GetWorkerStatus(context.Background(), f.admin.ID)
+		require.NoError(t, err)
+		assert.Nil(t, run)
+	})
+
+	t.Run("ワーカーが保存した進捗を返す", func(t *testing.T) {
+		f := setupPointExpiryPolicyInteractor(t)
+		f.settingsRepo.settings[entities.PointExpiryRunSettingKey] = `{"state":"time_limited","pages":12,"expired_batches":1100,"expired_users":430,"expired_points":52000}`
+
+		run, err := f.sut.GetWorkerStatus(context.Background(), f.admin.ID)
+		require.NoError(t, err)
+		require.NotNil(t, run)
+		assert.Equal(t, entities.PointExpiryRunTimeLimited, run.State)
+		assert.Equal(t, 12, run.Pages)
+		assert.Equal(t, 430, run.ExpiredUsers)
+		assert.Equal(t, int64(52000), run.ExpiredPoints)
+	})
+
+	t.Run("一般ユーザーは取得できない", func(t *testing.T) {
+		f := setupPointExpiryPolicyInteractor(t)
+		_, err := f.sut.GetWorkerStatus(context.Background(), f.user.ID)
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
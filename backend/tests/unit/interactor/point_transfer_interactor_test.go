@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/gity/point-system/usecases/interactor"
 	"github.com/google/uuid"
@@ -27,7 +29,7 @@ func TestPointTransferInteractor_Transfer(t *testing.T) {
 		pbRepo := newCtxTrackingPointBatchRepo()
 		logger := &mockLogger{}
 
-		i := interactor.NewPointTransferInteractor(txMgr, userRepo, txRepo, idempRepo, friendRepo, pbRepo, logger)
+		i := interactor.NewPointTransferInteractor(txMgr, userRepo, txRepo, idempRepo, friendRepo, pbRepo, testsupport.New().BalanceHolds, &mockNotificationDispatcher{}, &mockTransferScreener{}, &mockTransferEligibility{}, &mockTransferPolicy{}, &mockEarningEvents{}, logger)
 		return txMgr, userRepo, txRepo, idempRepo, pbRepo, i
 	}
 
@@ -48,6 +50,56 @@ func TestPointTransferInteractor_Transfer(t *testing.T) {
 		assert.NotNil(t, resp.Transaction)
 	})
 
+	t.Run("受取人へポイント受け取りを通知する", func(t *testing.T) {
+		userRepo := newCtxTrackingUserRepo()
+		notifications := &mockNotificationDispatcher{}
+		sut := interactor.NewPointTransferInteractor(
+			&ctxTrackingTxManager{}, userRepo, newCtxTrackingTransactionRepo(),
+			newCtxTrackingIdempotencyRepo(), newCtxTrackingFriendshipRepo(),
+			newCtxTrackingPointBatchRepo(), testsupport.New().BalanceHolds, notifications, &mockTransferScreener{}, &mockTransferEligibility{}, &mockTransferPolicy{}, &mockEarningEvents{}, &mockLogger{},
+		)
+		sender := createTestUserWithBalance(t, "sender", 10000, "user")
+		receiver := createTestUserWithBalance(t, "receiver", 1000, "user")
+		userRepo.setUser(sender)
+		userRepo.setUser(receiver)
+
+		_, err := sut.Transfer(context.Background(), &inputport.TransferRequest{
+			FromUserID: sender.ID, ToUserID: receiver.ID, Amount: 500,
+			IdempotencyKey: "notify-" + uuid.New().String(),
+		})
+		require.NoError(t, err)
+		require.Len(t, notifications.notifications, 1)
+		assert.Equal(t, receiver.ID, notifications.notifications[0].UserID)
+		assert.Equal(t, entities.NotificationTypePointsReceived, notifications.notifications[0].Type)
+		assert.Equal(t, "500", notifications.notifications[0].Data["amount"])
+	})
+
+	t.Run("送金をポイント獲得ルールに伝える", func(t *testing.T) {
+		userRepo := newCtxTrackingUserRepo()
+		earningEvents := &mockEarningEvents{}
+		sut := interactor.NewPointTransferInteractor(
+			&ctxTrackingTxManager{}, userRepo, newCtxTrackingTransactionRepo(),
+			newCtxTrackingIdempotencyRepo(), newCtxTrackingFriendshipRepo(),
+			newCtxTrackingPointBatchRepo(), testsupport.New().BalanceHolds, &mockNotificationDispatcher{}, &mockTransferScreener{}, &mockTransferEligibility{}, &mockTransferPolicy{}, earningEvents, &mockLogger{},
+		)
+		sender := createTestUserWithBalance(t, "sender", 10000, "user")
+		receiver := createTestUserWithBalance(t, "receiver", 1000, "user")
+		userRepo.setUser(sender)
+		userRepo.setUser(receiver)
+
+		resp, err := sut.Transfer(context.Background(), &inputport.TransferRequest{
+			FromUserID: sender.ID, ToUserID: receiver.ID, Amount: 500,
+			IdempotencyKey: "earning-" + uuid.New().String(),
+		})
+		require.NoError(t, err)
+		require.Len(t, earningEvents.events, 1)
+		event := earningEvents.events[0]
+		assert.Equal(t, entities.EarningTriggerTransferSent, event.Trigger)
+		assert.Equal(t, sender.ID, event.UserID)
+		assert.Equal(t, int64(500), event.Amount)
+		assert.Equal(t, resp.Transaction.ID.String(), event.SourceID)
+	})
+
 	t.Run("txManager.Do内の全呼び出しがトランザクションコンテキストを使用する", func(t *testing.T) {
 		txMgr, userRepo, txRepo, idempRepo, pbRepo, sut := setup()
 		sender := createTestUserWithBalance(t, "sender", 10000, "user")
@@ -91,7 +143,7 @@ func TestPointTransferInteractor_Transfer(t *testing.T) {
 			IdempotencyKey: "key", Description: "test",
 		})
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "amount must be positive")
+		assert.ErrorIs(t, err, entities.ErrInvalidAmount)
 	})
 
 	t.Run("自分自身への転送はエラー", func(t *testing.T) {
@@ -188,7 +240,7 @@ func TestPointTransferInteractor_GetTransactionHistory(t *testing.T) {
 		sut := interactor.NewPointTransferInteractor(
 			&ctxTrackingTxManager{}, userRepo, txRepo,
 			newCtxTrackingIdempotencyRepo(), newCtxTrackingFriendshipRepo(),
-			newCtxTrackingPointBatchRepo(), &mockLogger{},
+			newCtxTrackingPointBatchRepo(), testsupport.New().BalanceHolds, &mockNotificationDispatcher{}, &mockTransferScreener{}, &mockTransferEligibility{}, &mockTransferPolicy{}, &mockEarningEvents{}, &mockLogger{},
 		)
 
 		user := createTestUserWithBalance(t, "user", 1000, "user")
@@ -211,7 +263,7 @@ func TestPointTransferInteractor_GetBalance(t *testing.T) {
 		sut := interactor.NewPointTransferInteractor(
 			&ctxTrackingTxManager{}, userRepo, newCtxTrackingTransactionRepo(),
 			newCtxTrackingIdempotencyRepo(), newCtxTrackingFriendshipRepo(),
-			newCtxTrackingPointBatchRepo(), &mockLogger{},
+			newCtxTrackingPointBatchRepo(), testsupport.New().BalanceHolds, &mockNotificationDispatcher{}, &mockTransferScreener{}, &mockTransferEligibility{}, &mockTransferPolicy{}, &mockEarningEvents{}, &mockLogger{},
 		)
 
 		user := createTestUserWithBalance(t, "user", 5000, "user")
@@ -229,7 +281,7 @@ func TestPointTransferInteractor_GetBalance(t *testing.T) {
 		sut := interactor.NewPointTransferInteractor(
 			&ctxTrackingTxManager{}, newCtxTrackingUserRepo(), newCtxTrackingTransactionRepo(),
 			newCtxTrackingIdempotencyRepo(), newCtxTrackingFriendshipRepo(),
-			newCtxTrackingPointBatchRepo(), &mockLogger{},
+			newCtxTrackingPointBatchRepo(), testsupport.New().BalanceHolds, &mockNotificationDispatcher{}, &mockTransferScreener{}, &mockTransferEligibility{}, &mockTransferPolicy{}, &mockEarningEvents{}, &mockLogger{},
 		)
 
 		_, err := sut.GetBalance(context.Background(), &inputport.GetBalanceRequest{
@@ -0,0 +1,180 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockPricingRuleRepo は価格ルールをメモリに持つ PricingRuleRepository のモック
+type mockPricingRuleRepo struct {
+	rules map[uuid.UUID]*entities.PricingRule
+}
+
+func newMockPricingRuleRepo() *mockPricingRuleRepo {
+	return &mockPricingRuleRepo{rules: make(map[uuid.UUID]*entities.PricingRule)}
+}
+
+// add は有効期間中の価格ルールを登録する
+func (m *mockPricingRuleRepo) add(t *testing.T, name string, productID *uuid.UUID, categoryCode string, discountType entities.PricingDiscountType, value int64, role entities.UserRole, maxQuantity int) *entities.PricingRule {
+	t.Helper()
+	rule, err := entities.NewPricingRule(name, productID, categoryCode, discountType, value, role, "", maxQuantity, time.Now().Add(-time.Minute), nil, uuid.New())
+	require.NoError(t, err)
+	m.rules[rule.ID] = rule
+	return rule
+}
+
+func (m *mockPricingRuleRepo) Create(ctx context.Context, rule *entities.PricingRule) error {
+	m.rules[rule.ID] = rule
+	return nil
+}
+func (m *mockPricingRuleRepo) Read(ctx context.Context, id uuid.UUID) (*entities.PricingRule, error) {
+	r, ok := m.rules[id]
+	if !ok {
+		return nil, entities.ErrPricingRuleNotFound
+	}
+	return r, nil
+}
+func (m *mockPricingRuleRepo) Update(ctx context.Context, rule *entities.PricingRule) error {
+	m.rules[rule.ID] = rule
+	return nil
+}
+func (m *mockPricingRuleRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, ok := m.rules[id]; !ok {
+		return entities.ErrPricingRuleNotFound
+	}
+	delete(m.rules, id)
+	return nil
+}
+func (m *mockPricingRuleRepo) ReadList(ctx context.Context, offset, limit int) ([]*entities.PricingRule, error) {
+	result := make([]*entities.PricingRule, 0)
+	for _, r := range m.rules {
+		result = append(result, r)
+	}
+	return result, nil
+}
+func (m *mockPricingRuleRepo) Count(ctx context.Context) (int64, error) {
+	return int64(len(m.rules)), nil
+}
+func (m *mockPricingRuleRepo) ReadActiveForProduct(ctx context.Context, productID uuid.UUID, categoryCode string, now time.Time) ([]*entities.PricingRule, error) {
+	result := make([]*entities.PricingRule, 0)
+	for _, r := range m.rules {
+		if !r.IsActive || now.Before(r.StartsAt) || (r.EndsAt != nil && !now.Before(*r.EndsAt)) {
+			continue
+		}
+		if (r.ProductID != nil && *r.ProductID == productID) || (r.ProductID == nil && r.CategoryCode == categoryCode) {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+func setupPricingRuleInteractor(t *testing.T) (*mockPricingRuleRepo, *mockProductRepo, *entities.User, *entities.User, inputport.PricingRuleInputPort) {
+	repo := newMockPricingRuleRepo()
+	prodRepo := newMockProductRepo()
+	userRepo := newCtxTrackingUserRepo()
+	admin := createTestUserWithBalance(t, "pricer", 0, "admin")
+	user := createTestUserWithBalance(t, "shopper", 0, "user")
+	userRepo.setUser(admin)
+	userRepo.setUser(user)
+	return repo, prodRepo, admin, user, interactor.NewPricingRuleInteractor(repo, prodRepo, userRepo, &mockLogger{})
+}
+
+func TestPricingRuleInteractor_CreatePricingRule(t *testing.T) {
+	content := inputport.PricingRuleContent{
+		Name:          "週末セール",
+		CategoryCode:  "drink",
+		DiscountType:  entities.PricingDiscountPercentage,
+		DiscountValue: 20,
+	}
+
+	t.Run("管理者は価格ルールを作成できる", func(t *testing.T) {
+		repo, _, admin, _, sut := setupPricingRuleInteractor(t)
+
+		rule, err := sut.CreatePricingRule(context.Background(), &inputport.CreatePricingRuleRequest{AdminID: admin.ID, PricingRuleContent: content})
+		require.NoError(t, err)
+		assert.True(t, rule.IsActive)
+		assert.Equal(t, admin.ID, rule.CreatedBy)
+		assert.Contains(t, repo.rules, rule.ID)
+	})
+
+	t.Run("一般ユーザーは作成できない", func(t *testing.T) {
+		_, _, _, user, sut := setupPricingRuleInteractor(t)
+
+		_, err := sut.CreatePricingRule(context.Background(), &inputport.CreatePricingRuleRequest{AdminID: user.ID, PricingRuleContent: content})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+
+	t.Run("存在しない商品は指定できない", func(t *testing.T) {
+		_, _, admin, _, sut := setupPricingRuleInteractor(t)
+		productID := uuid.New()
+		c := content
+		c.CategoryCode = ""
+		c.ProductID = &productID
+
+		_, err := sut.CreatePricingRule(context.Background(), &inputport.CreatePricingRuleRequest{AdminID: admin.ID, PricingRuleContent: c})
+		assert.ErrorIs(t, err, entities.ErrInvalidPricingRule)
+	})
+}
+
+func TestPricingRuleInteractor_UpdatePricingRule(t *testing.T) {
+	t.Run("無効にしたルールは交換時に適用されない", func(t *testing.T) {
+		repo, prodRepo, admin, _, sut := setupPricingRuleInteractor(t)
+		product, _ := entities.NewProduct("コーラ", "", "drink", 100, 10)
+		prodRepo.setProduct(product)
+		rule := repo.add(t, "コーラ半額", &product.ID, "", entities.PricingDiscountPercentage, 50, "", 0)
+
+		updated, err := sut.UpdatePricingRule(context.Background(), &inputport.UpdatePricingRuleRequest{
+			AdminID:       admin.ID,
+			PricingRuleID: rule.ID,
+			IsActive:      false,
+			PricingRuleContent: inputport.PricingRuleContent{
+				Name: rule.Name, ProductID: rule.ProductID,
+				DiscountType: rule.DiscountType, DiscountValue: rule.DiscountValue,
+				StartsAt: rule.StartsAt,
+			},
+		})
+		require.NoError(t, err)
+		assert.False(t, updated.IsActive)
+
+		active, err := repo.ReadActiveForProduct(context.Background(), product.ID, product.CategoryCode, time.Now())
+		require.NoError(t, err)
+		assert.Empty(t, active)
+	})
+
+	t.Run("存在しないルールはErrPricingRuleNotFound", func(t *testing.T) {
+		_, _, admin, _, sut := setupPricingRuleInteractor(t)
+
+		_, err := sut.UpdatePricingRule(context.Background(), &inputport.UpdatePricingRuleRequest{
+			AdminID:       admin.ID,
+			PricingRuleID: uuid.New(),
+			PricingRuleContent: inputport.PricingRuleContent{
+				Name: "セール", CategoryCode: "drink",
+				DiscountType: entities.PricingDiscountFixed, DiscountValue: 10,
+			},
+		})
+		assert.ErrorIs(t, err, entities.ErrPricingRuleNotFound)
+	})
+}
+
+func TestPricingRuleInteractor_DeleteAndList(t *testing.T) {
+	repo, _, admin, _, sut := setupPricingRuleInteractor(t)
+	rule := repo.add(t, "セール", nil, "snack", entities.PricingDiscountFixed, 10, "", 0)
+	repo.add(t, "別のセール", nil, "drink", entities.PricingDiscountFixed, 10, "", 0)
+
+	resp, err := sut.GetPricingRuleList(context.Background(), &inputport.GetPricingRuleListRequest{AdminID: admin.ID, Limit: 500})
+	require.NoError(t, err)
+	assert.Len(t, resp.Rules, 2)
+	assert.Equal(t, 100, resp.Limit)
+
+	require.NoError(t, sut.DeletePricingRule(context.Background(), &inputport.DeletePricingRuleRequest{AdminID: admin.ID, PricingRuleID: rule.ID}))
+	err = sut.DeletePricingRule(context.Background(), &inputport.DeletePricingRuleRequest{AdminID: admin.ID, PricingRuleID: rule.ID})
+	assert.ErrorIs(t, err, entities.ErrPricingRuleNotFound)
+}
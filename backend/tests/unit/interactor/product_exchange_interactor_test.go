@@ -3,11 +3,15 @@ package interactor_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/gity/point-system/usecases/interactor"
+	"github.com/gity/point-system/usecases/repository"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,7 +24,9 @@ import (
 // --- Mock ProductExchangeRepository ---
 
 type mockExchangeRepo struct {
-	exchanges map[uuid.UUID]*entities.ProductExchange
+	exchanges  map[uuid.UUID]*entities.ProductExchange
+	reportFrom time.Time
+	reportTo   time.Time
 }
 
 func newMockExchangeRepo() *mockExchangeRepo {
@@ -67,9 +73,66 @@ func (m *mockExchangeRepo) CountByUserID(ctx context.Context, userID uuid.UUID)
 	}
 	return count, nil
 }
+func (m *mockExchangeRepo) ReadListByRecipientID(ctx context.Context, recipientID uuid.UUID, offset, limit int) ([]*entities.ProductExchange, error) {
+	result := make([]*entities.ProductExchange, 0)
+	for _, e := range m.exchanges {
+		if e.RecipientID != nil && *e.RecipientID == recipientID {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+func (m *mockExchangeRepo) CountByRecipientID(ctx context.Context, recipientID uuid.UUID) (int64, error) {
+	count := int64(0)
+	for _, e := range m.exchanges {
+		if e.RecipientID != nil && *e.RecipientID == recipientID {
+			count++
+		}
+	}
+	return count, nil
+}
 func (m *mockExchangeRepo) CountAll(ctx context.Context) (int64, error) {
 	return int64(len(m.exchanges)), nil
 }
+func (m *mockExchangeRepo) ReadByRedemptionCode(ctx context.Context, code string) (*entities.ProductExchange, error) {
+	for _, e := range m.exchanges {
+		if e.RedemptionCode != "" && e.RedemptionCode == code {
+			return e, nil
+		}
+	}
+	return nil, entities.ErrRedemptionCodeNotFound
+}
+func (m *mockExchangeRepo) MarkRedeemed(ctx context.Context, exchange *entities.ProductExchange) error {
+	m.exchanges[exchange.ID] = exchange
+	return nil
+}
+func (m *mockExchangeRepo) SumQuantityByPricingRule(ctx context.Context, userID, ruleID uuid.UUID) (int, error) {
+	total := 0
+	for _, e := range m.exchanges {
+		if e.UserID == userID && e.PricingRuleID != nil && *e.PricingRuleID == ruleID && e.Status != entities.ExchangeStatusCancelled {
+			total += e.Quantity
+		}
+	}
+	return total, nil
+}
+func (m *mockExchangeRepo) ReadReport(ctx context.Context, from, to time.Time, granularity entities.AnalyticsGranularity) ([]*entities.ExchangeReportRow, error) {
+	m.reportFrom, m.reportTo = from, to
+	return []*entities.ExchangeReportRow{}, nil
+}
+
+// --- Mock ShippingAddressRepository ---
+
+// mockShippingAddressRepo はどのユーザーにも既定のお届け先があるShippingAddressRepository
+type mockShippingAddressRepo struct {
+	repository.ShippingAddressRepository
+}
+
+func (m *mockShippingAddressRepo) ReadDefault(ctx context.Context, userID uuid.UUID) (*entities.ShippingAddress, error) {
+	return &entities.ShippingAddress{
+		ID: uuid.New(), UserID: userID, RecipientName: "山田太郎", PostalCode: "100-0001",
+		Prefecture: "東京都", City: "千代田区", Line1: "千代田1-1", Phone: "0312345678", IsDefault: true,
+	}, nil
+}
 
 // --- ExchangeProduct ---
 
@@ -83,7 +146,7 @@ func TestProductExchangeInteractor_ExchangeProduct(t *testing.T) {
 		pbRepo := newCtxTrackingPointBatchRepo()
 		logger := &mockLogger{}
 
-		sut := interactor.NewProductExchangeInteractor(txMgr, prodRepo, exchangeRepo, userRepo, txRepo, pbRepo, logger)
+		sut := interactor.NewProductExchangeInteractor(txMgr, prodRepo, exchangeRepo, userRepo, txRepo, pbRepo, newMockPricingRuleRepo(), newCtxTrackingFriendshipRepo(), &mockShippingAddressRepo{}, &mockNotificationDispatcher{}, logger)
 		return txMgr, userRepo, prodRepo, exchangeRepo, txRepo, pbRepo, sut
 	}
 
@@ -102,6 +165,39 @@ func TestProductExchangeInteractor_ExchangeProduct(t *testing.T) {
 		assert.NotNil(t, resp.Exchange)
 		assert.NotNil(t, resp.Transaction)
 		assert.Equal(t, int64(200), resp.Exchange.PointsUsed)
+		assert.Contains(t, resp.Exchange.ShippingAddress, "〒100-0001", "配送が必要な商品は既定のお届け先を控える")
+	})
+
+	t.Run("お届け先がなければ配送が必要な商品はErrShippingAddressRequired", func(t *testing.T) {
+		repos := testsupport.New()
+		user := createTestUserWithBalance(t, "buyer", 1000, "user")
+		repos.Users.Seed(user)
+		sut := interactor.NewProductExchangeInteractor(repos.TxManager, repos.Products, repos.ProductExchanges, repos.Users,
+			repos.Transactions, repos.PointBatches, repos.PricingRules, repos.Friendships, repos.ShippingAddresses, &mockNotificationDispatcher{}, &mockLogger{})
+		goods, err := entities.NewProduct("マグカップ", "", "goods", 100, 5)
+		require.NoError(t, err)
+		require.NoError(t, repos.Products.Create(context.Background(), goods))
+		code, err := entities.NewProduct("ギフトコード", "", "digital", 100, -1)
+		require.NoError(t, err)
+		code.IsDigital = true
+		require.NoError(t, repos.Products.Create(context.Background(), code))
+
+		_, err = sut.ExchangeProduct(context.Background(), &inputport.ExchangeProductRequest{UserID: user.ID, ProductID: goods.ID, Quantity: 1})
+		assert.ErrorIs(t, err, entities.ErrShippingAddressRequired)
+		assert.Equal(t, 0, repos.ProductExchanges.Calls("Create"))
+
+		resp, err := sut.ExchangeProduct(context.Background(), &inputport.ExchangeProductRequest{UserID: user.ID, ProductID: code.ID, Quantity: 1})
+		require.NoError(t, err, "デジタル商品はお届け先がなくても交換できる")
+		assert.Empty(t, resp.Exchange.ShippingAddress)
+
+		address := seedDefaultAddress(t, repos, user.ID)
+		other := seedDefaultAddress(t, repos, uuid.New())
+		_, err = sut.ExchangeProduct(context.Background(), &inputport.ExchangeProductRequest{UserID: user.ID, ProductID: goods.ID, Quantity: 1, ShippingAddressID: &other.ID})
+		assert.ErrorIs(t, err, entities.ErrShippingAddressNotFound, "他のユーザーのお届け先は使えない")
+
+		resp, err = sut.ExchangeProduct(context.Background(), &inputport.ExchangeProductRequest{UserID: user.ID, ProductID: goods.ID, Quantity: 1})
+		require.NoError(t, err)
+		assert.Equal(t, address.Format(), resp.Exchange.ShippingAddress)
 	})
 
 	t.Run("数量が0以下の場合エラー", func(t *testing.T) {
@@ -137,7 +233,7 @@ func TestProductExchangeInteractor_ExchangeProduct(t *testing.T) {
 			UserID: user.ID, ProductID: product.ID, Quantity: 1,
 		})
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "insufficient balance")
+		assert.ErrorIs(t, err, entities.ErrInsufficientBalance)
 	})
 
 	t.Run("在庫不足の場合エラー", func(t *testing.T) {
@@ -199,7 +295,7 @@ func TestProductExchangeInteractor_GetExchangeHistory(t *testing.T) {
 		sut := interactor.NewProductExchangeInteractor(
 			&ctxTrackingTxManager{}, newMockProductRepo(), exchangeRepo,
 			newCtxTrackingUserRepo(), newCtxTrackingTransactionRepo(),
-			newCtxTrackingPointBatchRepo(), &mockLogger{},
+			newCtxTrackingPointBatchRepo(), newMockPricingRuleRepo(), newCtxTrackingFriendshipRepo(), &mockShippingAddressRepo{}, &mockNotificationDispatcher{}, &mockLogger{},
 		)
 
 		userID := uuid.New()
@@ -225,7 +321,7 @@ func TestProductExchangeInteractor_CancelExchange(t *testing.T) {
 		sut := interactor.NewProductExchangeInteractor(
 			&ctxTrackingTxManager{}, prodRepo, exchangeRepo,
 			userRepo, newCtxTrackingTransactionRepo(),
-			newCtxTrackingPointBatchRepo(), &mockLogger{},
+			newCtxTrackingPointBatchRepo(), newMockPricingRuleRepo(), newCtxTrackingFriendshipRepo(), &mockShippingAddressRepo{}, &mockNotificationDispatcher{}, &mockLogger{},
 		)
 		return exchangeRepo, prodRepo, userRepo, sut
 	}
@@ -261,8 +357,8 @@ func TestProductExchangeInteractor_CancelExchange(t *testing.T) {
 		err := sut.CancelExchange(context.Background(), &inputport.CancelExchangeRequest{
 			UserID: uuid.New(), ExchangeID: exchange.ID,
 		})
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "unauthorized")
+		// 関わりのない交換は存在を明かさない
+		assert.ErrorIs(t, err, entities.ErrExchangeNotFound)
 	})
 
 	t.Run("存在しない交換の場合エラー", func(t *testing.T) {
@@ -284,7 +380,7 @@ func TestProductExchangeInteractor_MarkExchangeDelivered(t *testing.T) {
 		sut := interactor.NewProductExchangeInteractor(
 			&ctxTrackingTxManager{}, newMockProductRepo(), exchangeRepo,
 			newCtxTrackingUserRepo(), newCtxTrackingTransactionRepo(),
-			newCtxTrackingPointBatchRepo(), &mockLogger{},
+			newCtxTrackingPointBatchRepo(), newMockPricingRuleRepo(), newCtxTrackingFriendshipRepo(), &mockShippingAddressRepo{}, &mockNotificationDispatcher{}, &mockLogger{},
 		)
 
 		exchange, _ := entities.NewProductExchange(uuid.New(), uuid.New(), 1, 100, "")
@@ -303,7 +399,7 @@ func TestProductExchangeInteractor_MarkExchangeDelivered(t *testing.T) {
 		sut := interactor.NewProductExchangeInteractor(
 			&ctxTrackingTxManager{}, newMockProductRepo(), exchangeRepo,
 			newCtxTrackingUserRepo(), newCtxTrackingTransactionRepo(),
-			newCtxTrackingPointBatchRepo(), &mockLogger{},
+			newCtxTrackingPointBatchRepo(), newMockPricingRuleRepo(), newCtxTrackingFriendshipRepo(), &mockShippingAddressRepo{}, &mockNotificationDispatcher{}, &mockLogger{},
 		)
 
 		exchange, _ := entities.NewProductExchange(uuid.New(), uuid.New(), 1, 100, "")
@@ -325,7 +421,7 @@ func TestProductExchangeInteractor_GetAllExchanges(t *testing.T) {
 		sut := interactor.NewProductExchangeInteractor(
 			&ctxTrackingTxManager{}, newMockProductRepo(), exchangeRepo,
 			newCtxTrackingUserRepo(), newCtxTrackingTransactionRepo(),
-			newCtxTrackingPointBatchRepo(), &mockLogger{},
+			newCtxTrackingPointBatchRepo(), newMockPricingRuleRepo(), newCtxTrackingFriendshipRepo(), &mockShippingAddressRepo{}, &mockNotificationDispatcher{}, &mockLogger{},
 		)
 
 		e1, _ := entities.NewProductExchange(uuid.New(), uuid.New(), 1, 100, "")
@@ -339,3 +435,371 @@ func TestProductExchangeInteractor_GetAllExchanges(t *testing.T) {
 		assert.Equal(t, int64(2), resp.Total)
 	})
 }
+
+// --- RedemptionCode ---
+
+func TestProductExchangeInteractor_RedemptionCode(t *testing.T) {
+	admin := createTestUserWithBalance(t, "admin", 0, "admin")
+	setup := func() (*ctxTrackingUserRepo, *mockProductRepo, *mockExchangeRepo, *interactor.ProductExchangeInteractor) {
+		userRepo := newCtxTrackingUserRepo()
+		userRepo.setUser(admin)
+		prodRepo := newMockProductRepo()
+		exchangeRepo := newMockExchangeRepo()
+		sut := interactor.NewProductExchangeInteractor(
+			&ctxTrackingTxManager{}, prodRepo, exchangeRepo, userRepo,
+			newCtxTrackingTransactionRepo(), newCtxTrackingPointBatchRepo(), newMockPricingRuleRepo(), newCtxTrackingFriendshipRepo(), &mockShippingAddressRepo{}, &mockNotificationDispatcher{}, &mockLogger{},
+		)
+		return userRepo, prodRepo, exchangeRepo, sut
+	}
+
+	exchangeDigital := func(t *testing.T, userRepo *ctxTrackingUserRepo, prodRepo *mockProductRepo, sut *interactor.ProductExchangeInteractor) *entities.ProductExchange {
+		t.Helper()
+		user := createTestUserWithBalance(t, "buyer", 10000, "user")
+		userRepo.setUser(user)
+		product, _ := entities.NewProduct("ギフトカード", "オンラインで使える", "gift", 500, 10)
+		product.IsDigital = true
+		prodRepo.setProduct(product)
+
+		resp, err := sut.ExchangeProduct(context.Background(), &inputport.ExchangeProductRequest{
+			UserID: user.ID, ProductID: product.ID, Quantity: 1,
+		})
+		require.NoError(t, err)
+		return resp.Exchange
+	}
+
+	t.Run("デジタル商品の交換には引換コードが発行される", func(t *testing.T) {
+		userRepo, prodRepo, _, sut := setup()
+		exchange := exchangeDigital(t, userRepo, prodRepo, sut)
+
+		assert.Len(t, exchange.RedemptionCode, 14)
+		assert.Equal(t, exchange.RedemptionCode, entities.NormalizeRedemptionCode(exchange.RedemptionCode))
+		assert.True(t, exchange.CanRedeem())
+	})
+
+	t.Run("物理商品の交換には引換コードが発行されない", func(t *testing.T) {
+		userRepo, prodRepo, _, sut := setup()
+		user := createTestUserWithBalance(t, "buyer", 10000, "user")
+		userRepo.setUser(user)
+		product, _ := entities.NewProduct("コーラ", "", "drink", 100, 50)
+		prodRepo.setProduct(product)
+
+		resp, err := sut.ExchangeProduct(context.Background(), &inputport.ExchangeProductRequest{
+			UserID: user.ID, ProductID: product.ID, Quantity: 1,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Exchange.RedemptionCode)
+	})
+
+	t.Run("確認ではコードを使用済みにしない（小文字・ハイフンなしでもよい）", func(t *testing.T) {
+		userRepo, prodRepo, _, sut := setup()
+		exchange := exchangeDigital(t, userRepo, prodRepo, sut)
+		input := strings.ToLower(strings.ReplaceAll(exchange.RedemptionCode, "-", ""))
+
+		resp, err := sut.ValidateRedemptionCode(context.Background(), &inputport.RedeemCodeRequest{AdminID: admin.ID, Code: input})
+		require.NoError(t, err)
+		assert.Equal(t, exchange.ID, resp.Exchange.ID)
+		assert.True(t, resp.Redeemable)
+		assert.Nil(t, exchange.RedeemedAt)
+	})
+
+	t.Run("引き換えは1回だけ成功し受け渡し済みになる", func(t *testing.T) {
+		userRepo, prodRepo, _, sut := setup()
+		exchange := exchangeDigital(t, userRepo, prodRepo, sut)
+		req := &inputport.RedeemCodeRequest{AdminID: admin.ID, Code: exchange.RedemptionCode}
+
+		resp, err := sut.RedeemCode(context.Background(), req)
+		require.NoError(t, err)
+		assert.False(t, resp.Redeemable)
+		assert.Equal(t, entities.ExchangeStatusDelivered, resp.Exchange.Status)
+		assert.NotNil(t, resp.Exchange.RedeemedAt)
+
+		_, err = sut.RedeemCode(context.Background(), req)
+		assert.ErrorIs(t, err, entities.ErrRedemptionCodeUsed)
+	})
+
+	t.Run("引換コードのある交換は配達完了で受け渡し済みにできない", func(t *testing.T) {
+		userRepo, prodRepo, _, sut := setup()
+		exchange := exchangeDigital(t, userRepo, prodRepo, sut)
+
+		err := sut.MarkExchangeDelivered(context.Background(), &inputport.MarkExchangeDeliveredRequest{ExchangeID: exchange.ID})
+		assert.Error(t, err)
+	})
+
+	t.Run("存在しないコード・形式不正はErrRedemptionCodeNotFound", func(t *testing.T) {
+		_, _, _, sut := setup()
+
+		for _, code := range []string{"2345-6789-ABCD", "invalid", ""} {
+			_, err := sut.ValidateRedemptionCode(context.Background(), &inputport.RedeemCodeRequest{AdminID: admin.ID, Code: code})
+			assert.ErrorIs(t, err, entities.ErrRedemptionCodeNotFound, code)
+		}
+	})
+
+	t.Run("管理者以外は確認も引き換えもできない", func(t *testing.T) {
+		userRepo, prodRepo, _, sut := setup()
+		exchange := exchangeDigital(t, userRepo, prodRepo, sut)
+		req := &inputport.RedeemCodeRequest{AdminID: exchange.UserID, Code: exchange.RedemptionCode}
+
+		_, err := sut.ValidateRedemptionCode(context.Background(), req)
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		_, err = sut.RedeemCode(context.Background(), req)
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		assert.Nil(t, exchange.RedeemedAt)
+	})
+}
+
+// --- GetExchangeReport ---
+
+func TestProductExchangeInteractor_GetExchangeReport(t *testing.T) {
+	setup := func() (*mockExchangeRepo, *interactor.ProductExchangeInteractor) {
+		exchangeRepo := newMockExchangeRepo()
+		sut := interactor.NewProductExchangeInteractor(
+			&ctxTrackingTxManager{}, newMockProductRepo(), exchangeRepo,
+			newCtxTrackingUserRepo(), newCtxTrackingTransactionRepo(),
+			newCtxTrackingPointBatchRepo(), newMockPricingRuleRepo(), newCtxTrackingFriendshipRepo(), &mockShippingAddressRepo{}, &mockNotificationDispatcher{}, &mockLogger{},
+		)
+		return exchangeRepo, sut
+	}
+
+	t.Run("期間未指定なら直近12週間を週単位で集計する", func(t *testing.T) {
+		exchangeRepo, sut := setup()
+
+		resp, err := sut.GetExchangeReport(context.Background(), &inputport.GetExchangeReportRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, entities.AnalyticsGranularityWeek, resp.Granularity)
+		assert.Equal(t, time.Monday, resp.From.Weekday())
+		assert.Equal(t, resp.From, exchangeRepo.reportFrom)
+		assert.Equal(t, resp.To, exchangeRepo.reportTo)
+		assert.True(t, resp.To.Sub(resp.From) > 11*7*24*time.Hour)
+		assert.True(t, resp.To.Sub(resp.From) <= 12*7*24*time.Hour+time.Hour)
+	})
+
+	t.Run("開始が終了以降ならErrInvalidDateRange", func(t *testing.T) {
+		_, sut := setup()
+		now := time.Now()
+
+		_, err := sut.GetExchangeReport(context.Background(), &inputport.GetExchangeReportRequest{
+			From: now, To: now.AddDate(0, 0, -1), Granularity: entities.AnalyticsGranularityMonth,
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidDateRange)
+	})
+
+	t.Run("2年を超える期間はErrInvalidDateRange", func(t *testing.T) {
+		_, sut := setup()
+		now := time.Now()
+
+		_, err := sut.GetExchangeReport(context.Background(), &inputport.GetExchangeReportRequest{
+			From: now.AddDate(-3, 0, 0), To: now, Granularity: entities.AnalyticsGranularityMonth,
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidDateRange)
+	})
+}
+
+// --- PricingRule ---
+
+func TestProductExchangeInteractor_PricingRules(t *testing.T) {
+	setup := func(t *testing.T) (*entities.User, *entities.Product, *mockPricingRuleRepo, *ctxTrackingUserRepo, *interactor.ProductExchangeInteractor) {
+		userRepo := newCtxTrackingUserRepo()
+		prodRepo := newMockProductRepo()
+		ruleRepo := newMockPricingRuleRepo()
+		sut := interactor.NewProductExchangeInteractor(
+			&ctxTrackingTxManager{}, prodRepo, newMockExchangeRepo(), userRepo,
+			newCtxTrackingTransactionRepo(), newCtxTrackingPointBatchRepo(), ruleRepo, newCtxTrackingFriendshipRepo(), &mockShippingAddressRepo{}, &mockNotificationDispatcher{}, &mockLogger{},
+		)
+		user := createTestUserWithBalance(t, "buyer", 10000, "user")
+		userRepo.setUser(user)
+		product, _ := entities.NewProduct("コーラ", "", "drink", 200, 50)
+		prodRepo.setProduct(product)
+		return user, product, ruleRepo, userRepo, sut
+	}
+	exchange := func(sut *interactor.ProductExchangeInteractor, user *entities.User, product *entities.Product, quantity int) (*inputport.ExchangeProductResponse, error) {
+		return sut.ExchangeProduct(context.Background(), &inputport.ExchangeProductRequest{
+			UserID: user.ID, ProductID: product.ID, Quantity: quantity,
+		})
+	}
+
+	t.Run("ルールがなければ通常価格", func(t *testing.T) {
+		user, product, _, _, sut := setup(t)
+
+		resp, err := exchange(sut, user, product, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(400), resp.Exchange.PointsUsed)
+		assert.Nil(t, resp.PricingRule)
+		assert.Nil(t, resp.Exchange.PricingRuleID)
+		assert.NotContains(t, resp.Transaction.Metadata, "pricing_rule_id")
+	})
+
+	t.Run("最も安くなるルールを適用し、取引のメタデータに残す", func(t *testing.T) {
+		user, product, ruleRepo, _, sut := setup(t)
+		category := ruleRepo.add(t, "飲み物10%引き", nil, "drink", entities.PricingDiscountPercentage, 10, "", 0)
+		best := ruleRepo.add(t, "コーラ50pt引き", &product.ID, "", entities.PricingDiscountFixed, 50, "", 0)
+
+		resp, err := exchange(sut, user, product, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(300), resp.Exchange.PointsUsed)
+		assert.Equal(t, int64(150), resp.UnitPrice)
+		assert.Equal(t, best.ID, resp.PricingRule.ID)
+		assert.Equal(t, best.ID, *resp.Exchange.PricingRuleID)
+		assert.Equal(t, best.ID.String(), resp.Transaction.Metadata["pricing_rule_id"])
+		assert.Equal(t, int64(200), resp.Transaction.Metadata["base_unit_price"])
+		assert.NotEqual(t, category.ID, resp.PricingRule.ID)
+	})
+
+	t.Run("会員向けのルールは対象の役割にだけ適用する", func(t *testing.T) {
+		user, product, ruleRepo, userRepo, sut := setup(t)
+		ruleRepo.add(t, "管理者価格", &product.ID, "", entities.PricingDiscountPercentage, 50, entities.RoleAdmin, 0)
+		admin := createTestUserWithBalance(t, "staff", 10000, "admin")
+		userRepo.setUser(admin)
+
+		resp, err := exchange(sut, user, product, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(200), resp.Exchange.PointsUsed)
+
+		resp, err = exchange(sut, admin, product, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), resp.Exchange.PointsUsed)
+	})
+
+	t.Run("セール中の購入上限を超えるとErrSaleLimitExceeded", func(t *testing.T) {
+		user, product, ruleRepo, _, sut := setup(t)
+		ruleRepo.add(t, "お一人様3本まで", &product.ID, "", entities.PricingDiscountPercentage, 20, "", 3)
+
+		_, err := exchange(sut, user, product, 2)
+		require.NoError(t, err)
+
+		_, err = exchange(sut, user, product, 2)
+		assert.ErrorIs(t, err, entities.ErrSaleLimitExceeded)
+		var domainErr *entities.DomainError
+		require.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, 1, domainErr.Params["remaining"])
+
+		_, err = exchange(sut, user, product, 1)
+		assert.NoError(t, err)
+	})
+}
+
+// --- Gift ---
+
+func TestProductExchangeInteractor_Gift(t *testing.T) {
+	ctx := context.Background()
+
+	type fixture struct {
+		repos         *testsupport.Repositories
+		notifications *mockNotificationDispatcher
+		sut           *interactor.ProductExchangeInteractor
+		payer         *entities.User
+		friend        *entities.User
+		stranger      *entities.User
+		product       *entities.Product
+	}
+	setup := func(t *testing.T) *fixture {
+		f := &fixture{repos: testsupport.New(), notifications: &mockNotificationDispatcher{}}
+		f.payer = createTestUserWithBalance(t, "payer", 1000, entities.RoleUser)
+		f.friend = createTestUserWithBalance(t, "friend", 0, entities.RoleUser)
+		f.stranger = createTestUserWithBalance(t, "stranger", 0, entities.RoleUser)
+		f.repos.Users.Seed(f.payer, f.friend, f.stranger)
+		friendship, err := entities.NewFriendship(f.payer.ID, f.friend.ID)
+		require.NoError(t, err)
+		require.NoError(t, friendship.Accept())
+		require.NoError(t, f.repos.Friendships.Create(ctx, friendship))
+		seedDefaultAddress(t, f.repos, f.friend.ID)
+
+		f.product, err = entities.NewProduct("コーラ", "", "drink", 150, 5)
+		require.NoError(t, err)
+		require.NoError(t, f.repos.Products.Create(ctx, f.product))
+
+		f.sut = interactor.NewProductExchangeInteractor(f.repos.TxManager, f.repos.Products, f.repos.ProductExchanges, f.repos.Users,
+			f.repos.Transactions, f.repos.PointBatches, f.repos.PricingRules, f.repos.Friendships, f.repos.ShippingAddresses, f.notifications, &mockLogger{})
+		return f
+	}
+	send := func(t *testing.T, f *fixture) *entities.ProductExchange {
+		t.Helper()
+		resp, err := f.sut.ExchangeProduct(ctx, &inputport.ExchangeProductRequest{
+			UserID: f.payer.ID, ProductID: f.product.ID, Quantity: 2, RecipientID: &f.friend.ID,
+		})
+		require.NoError(t, err)
+		return resp.Exchange
+	}
+	balance := func(t *testing.T, f *fixture, id uuid.UUID) int64 {
+		t.Helper()
+		user, err := f.repos.Users.Read(ctx, id)
+		require.NoError(t, err)
+		return user.Balance
+	}
+
+	t.Run("払った人のポイントで交換し、贈られた人へ通知する", func(t *testing.T) {
+		f := setup(t)
+		exchange := send(t, f)
+
+		assert.Equal(t, f.payer.ID, exchange.UserID)
+		assert.Equal(t, f.friend.ID, *exchange.RecipientID)
+		assert.Equal(t, entities.GiftStatusPending, exchange.GiftStatus)
+		assert.Empty(t, exchange.ShippingAddress, "お届け先は受け取るときに決める")
+		assert.Equal(t, int64(700), balance(t, f, f.payer.ID))
+
+		require.Len(t, f.notifications.notifications, 1)
+		n := f.notifications.notifications[0]
+		assert.Equal(t, entities.NotificationTypeGiftReceived, n.Type)
+		assert.Equal(t, f.friend.ID, n.UserID)
+		assert.Equal(t, exchange.ID.String(), n.Data["exchange_id"])
+
+		gifts, err := f.sut.GetReceivedGifts(ctx, &inputport.GetExchangeHistoryRequest{UserID: f.friend.ID, Limit: 20})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), gifts.Total)
+	})
+
+	t.Run("友達でなければErrNotFriendsで、ポイントも在庫も減らない", func(t *testing.T) {
+		f := setup(t)
+		_, err := f.sut.ExchangeProduct(ctx, &inputport.ExchangeProductRequest{
+			UserID: f.payer.ID, ProductID: f.product.ID, Quantity: 1, RecipientID: &f.stranger.ID,
+		})
+		assert.ErrorIs(t, err, entities.ErrNotFriends)
+		assert.Equal(t, int64(1000), balance(t, f, f.payer.ID))
+		assert.Empty(t, f.notifications.notifications)
+
+		_, err = f.sut.ExchangeProduct(ctx, &inputport.ExchangeProductRequest{
+			UserID: f.payer.ID, ProductID: f.product.ID, Quantity: 1, RecipientID: &f.payer.ID,
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidGift)
+	})
+
+	t.Run("辞退すると払った人へ返金し、在庫を戻す", func(t *testing.T) {
+		f := setup(t)
+		exchange := send(t, f)
+
+		_, err := f.sut.DeclineGift(ctx, &inputport.RespondGiftRequest{UserID: f.payer.ID, ExchangeID: exchange.ID})
+		assert.ErrorIs(t, err, entities.ErrGiftNotFound, "払った人は返事できない")
+
+		declined, err := f.sut.DeclineGift(ctx, &inputport.RespondGiftRequest{UserID: f.friend.ID, ExchangeID: exchange.ID})
+		require.NoError(t, err)
+		assert.Equal(t, entities.ExchangeStatusCancelled, declined.Status)
+		assert.Equal(t, int64(1000), balance(t, f, f.payer.ID))
+		assert.Equal(t, int64(0), balance(t, f, f.friend.ID))
+		product, err := f.repos.Products.Read(ctx, f.product.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 5, product.Stock)
+
+		_, err = f.sut.AcceptGift(ctx, &inputport.RespondGiftRequest{UserID: f.friend.ID, ExchangeID: exchange.ID})
+		assert.ErrorIs(t, err, entities.ErrGiftAlreadyAnswered)
+	})
+
+	t.Run("受け取るまでは受け渡しできず、管理者の一覧には受け取る人を添える", func(t *testing.T) {
+		f := setup(t)
+		exchange := send(t, f)
+
+		err := f.sut.MarkExchangeDelivered(ctx, &inputport.MarkExchangeDeliveredRequest{ExchangeID: exchange.ID})
+		assert.ErrorIs(t, err, entities.ErrGiftAwaitingResponse)
+
+		accepted, err := f.sut.AcceptGift(ctx, &inputport.RespondGiftRequest{UserID: f.friend.ID, ExchangeID: exchange.ID})
+		require.NoError(t, err)
+		address, err := f.repos.ShippingAddresses.ReadDefault(ctx, f.friend.ID)
+		require.NoError(t, err)
+		assert.Equal(t, address.Format(), accepted.ShippingAddress, "贈られた人のお届け先へ送る")
+		require.NoError(t, f.sut.MarkExchangeDelivered(ctx, &inputport.MarkExchangeDeliveredRequest{ExchangeID: exchange.ID}))
+
+		all, err := f.sut.GetAllExchanges(ctx, 0, 20)
+		require.NoError(t, err)
+		require.Contains(t, all.Recipients, exchange.ID)
+		assert.Equal(t, f.friend.ID, all.Recipients[exchange.ID].UserID)
+		assert.Equal(t, f.friend.Email, all.Recipients[exchange.ID].Email)
+	})
+}
@@ -86,7 +86,7 @@ func (m *mockProductRepo) UpdateStock(ctx context.Context, productID uuid.UUID,
 func TestProductManagementInteractor_CreateProduct(t *testing.T) {
 	setup := func() (*mockProductRepo, inputport.ProductManagementInputPort) {
 		prodRepo := newMockProductRepo()
-		sut := interactor.NewProductManagementInteractor(prodRepo, &mockLogger{})
+		sut := interactor.NewProductManagementInteractor(prodRepo, &mockContentModeration{}, &mockLogger{})
 		return prodRepo, sut
 	}
 
@@ -126,7 +126,7 @@ func TestProductManagementInteractor_CreateProduct(t *testing.T) {
 func TestProductManagementInteractor_UpdateProduct(t *testing.T) {
 	setup := func() (*mockProductRepo, inputport.ProductManagementInputPort) {
 		prodRepo := newMockProductRepo()
-		sut := interactor.NewProductManagementInteractor(prodRepo, &mockLogger{})
+		sut := interactor.NewProductManagementInteractor(prodRepo, &mockContentModeration{}, &mockLogger{})
 		return prodRepo, sut
 	}
 
@@ -160,7 +160,7 @@ func TestProductManagementInteractor_UpdateProduct(t *testing.T) {
 func TestProductManagementInteractor_DeleteProduct(t *testing.T) {
 	t.Run("正常に商品を削除できる", func(t *testing.T) {
 		prodRepo := newMockProductRepo()
-		sut := interactor.NewProductManagementInteractor(prodRepo, &mockLogger{})
+		sut := interactor.NewProductManagementInteractor(prodRepo, &mockContentModeration{}, &mockLogger{})
 		product, _ := entities.NewProduct("削除対象", "説明", "drink", 100, 10)
 		prodRepo.setProduct(product)
 
@@ -176,7 +176,7 @@ func TestProductManagementInteractor_DeleteProduct(t *testing.T) {
 func TestProductManagementInteractor_GetProductList(t *testing.T) {
 	setup := func() (*mockProductRepo, inputport.ProductManagementInputPort) {
 		prodRepo := newMockProductRepo()
-		sut := interactor.NewProductManagementInteractor(prodRepo, &mockLogger{})
+		sut := interactor.NewProductManagementInteractor(prodRepo, &mockContentModeration{}, &mockLogger{})
 		return prodRepo, sut
 	}
 
@@ -3,9 +3,11 @@ package interactor_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/infra/infraqrpayload"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/gity/point-system/usecases/interactor"
 	"github.com/google/uuid"
@@ -17,6 +19,9 @@ import (
 // QRCodeInteractor テスト
 // ========================================
 
+// testQRCodec はテストで使うQRペイロードの署名・検証
+var testQRCodec = infraqrpayload.NewCodec("qrcode-interactor-test-signing-secret")
+
 // --- Mock QRCodeRepository ---
 
 type mockQRCodeRepo struct {
@@ -99,13 +104,31 @@ func (m *mockPointTransferUC) GetTransactionHistory(ctx context.Context, req *in
 func (m *mockPointTransferUC) GetExpiringPoints(ctx context.Context, req *inputport.GetExpiringPointsRequest) (*inputport.GetExpiringPointsResponse, error) {
 	return nil, nil
 }
+func (m *mockPointTransferUC) QuoteTransfer(ctx context.Context, req *inputport.QuoteTransferRequest) (*inputport.QuoteTransferResponse, error) {
+	return nil, nil
+}
+
+// --- Mock RealtimeNotifier ---
+
+type notifiedEvent struct {
+	userID uuid.UUID
+	event  *entities.RealtimeEvent
+}
+
+type mockRealtimeNotifier struct {
+	events []notifiedEvent
+}
+
+func (m *mockRealtimeNotifier) Notify(ctx context.Context, userID uuid.UUID, event *entities.RealtimeEvent) {
+	m.events = append(m.events, notifiedEvent{userID: userID, event: event})
+}
 
 // --- GenerateReceiveQR ---
 
 func TestQRCodeInteractor_GenerateReceiveQR(t *testing.T) {
 	setup := func() (*mockQRCodeRepo, inputport.QRCodeInputPort) {
 		qrRepo := newMockQRCodeRepo()
-		sut := interactor.NewQRCodeInteractor(qrRepo, &mockPointTransferUC{}, &mockLogger{})
+		sut := interactor.NewQRCodeInteractor(qrRepo, newCtxTrackingUserRepo(), &mockPointTransferUC{}, &mockRealtimeNotifier{}, testQRCodec, &mockLogger{})
 		return qrRepo, sut
 	}
 
@@ -117,7 +140,7 @@ func TestQRCodeInteractor_GenerateReceiveQR(t *testing.T) {
 		})
 		require.NoError(t, err)
 		assert.NotNil(t, resp.QRCode)
-		assert.Contains(t, resp.QRCodeData, "receive:")
+		assert.True(t, strings.HasPrefix(resp.QRCodeData, "gity://qr?"))
 		assert.Nil(t, resp.QRCode.Amount)
 	})
 
@@ -130,7 +153,7 @@ func TestQRCodeInteractor_GenerateReceiveQR(t *testing.T) {
 		})
 		require.NoError(t, err)
 		assert.NotNil(t, resp.QRCode)
-		assert.Contains(t, resp.QRCodeData, "receive:")
+		assert.True(t, strings.HasPrefix(resp.QRCodeData, "gity://qr?"))
 		assert.NotNil(t, resp.QRCode.Amount)
 		assert.Equal(t, int64(500), *resp.QRCode.Amount)
 	})
@@ -143,7 +166,7 @@ func TestQRCodeInteractor_GenerateReceiveQR(t *testing.T) {
 			UserID: uuid.New(), Amount: &amount,
 		})
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "amount must be positive")
+		assert.ErrorIs(t, err, entities.ErrInvalidAmount)
 	})
 }
 
@@ -152,7 +175,7 @@ func TestQRCodeInteractor_GenerateReceiveQR(t *testing.T) {
 func TestQRCodeInteractor_GenerateSendQR(t *testing.T) {
 	setup := func() (*mockQRCodeRepo, inputport.QRCodeInputPort) {
 		qrRepo := newMockQRCodeRepo()
-		sut := interactor.NewQRCodeInteractor(qrRepo, &mockPointTransferUC{}, &mockLogger{})
+		sut := interactor.NewQRCodeInteractor(qrRepo, newCtxTrackingUserRepo(), &mockPointTransferUC{}, &mockRealtimeNotifier{}, testQRCodec, &mockLogger{})
 		return qrRepo, sut
 	}
 
@@ -164,7 +187,7 @@ func TestQRCodeInteractor_GenerateSendQR(t *testing.T) {
 		})
 		require.NoError(t, err)
 		assert.NotNil(t, resp.QRCode)
-		assert.Contains(t, resp.QRCodeData, "send:")
+		assert.True(t, strings.HasPrefix(resp.QRCodeData, "gity://qr?"))
 		assert.Equal(t, int64(1000), *resp.QRCode.Amount)
 	})
 
@@ -175,7 +198,7 @@ func TestQRCodeInteractor_GenerateSendQR(t *testing.T) {
 			UserID: uuid.New(), Amount: 0,
 		})
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "amount must be positive")
+		assert.ErrorIs(t, err, entities.ErrInvalidAmount)
 	})
 }
 
@@ -185,7 +208,7 @@ func TestQRCodeInteractor_ScanQR(t *testing.T) {
 	setup := func() (*mockQRCodeRepo, *mockPointTransferUC, inputport.QRCodeInputPort) {
 		qrRepo := newMockQRCodeRepo()
 		transferUC := &mockPointTransferUC{}
-		sut := interactor.NewQRCodeInteractor(qrRepo, transferUC, &mockLogger{})
+		sut := interactor.NewQRCodeInteractor(qrRepo, newCtxTrackingUserRepo(), transferUC, &mockRealtimeNotifier{}, testQRCodec, &mockLogger{})
 		return qrRepo, transferUC, sut
 	}
 
@@ -250,12 +273,72 @@ func TestQRCodeInteractor_ScanQR(t *testing.T) {
 	})
 }
 
+func TestQRCodeInteractor_ScanQR_RealtimeEvents(t *testing.T) {
+	t.Run("QRコードの持ち主に読み取りと送金完了を通知する", func(t *testing.T) {
+		qrRepo := newMockQRCodeRepo()
+		notifier := &mockRealtimeNotifier{}
+		sut := interactor.NewQRCodeInteractor(qrRepo, newCtxTrackingUserRepo(), &mockPointTransferUC{}, notifier, testQRCodec, &mockLogger{})
+		ownerID := uuid.New()
+		amount := int64(300)
+		qrCode, _ := entities.NewReceiveQRCode(ownerID, &amount)
+		_ = qrRepo.Create(context.Background(), qrCode)
+
+		resp, err := sut.ScanQR(context.Background(), &inputport.ScanQRRequest{
+			UserID: uuid.New(), Code: qrCode.Code, IdempotencyKey: "scan-" + uuid.New().String(),
+		})
+		require.NoError(t, err)
+
+		require.Len(t, notifier.events, 2)
+		assert.Equal(t, ownerID, notifier.events[0].userID)
+		assert.Equal(t, entities.RealtimeEventQRScanned, notifier.events[0].event.Type)
+		assert.Equal(t, int64(300), notifier.events[0].event.Data["amount"])
+		assert.Equal(t, ownerID, notifier.events[1].userID)
+		assert.Equal(t, entities.RealtimeEventTransferCompleted, notifier.events[1].event.Type)
+		assert.Equal(t, resp.Transaction.ID, notifier.events[1].event.Data["transaction_id"])
+		assert.Equal(t, entities.RealtimeTopicQR, notifier.events[1].event.Topic)
+	})
+
+	t.Run("送金に失敗した場合は完了を通知しない", func(t *testing.T) {
+		qrRepo := newMockQRCodeRepo()
+		notifier := &mockRealtimeNotifier{}
+		transferUC := &mockPointTransferUC{transferErr: entities.ErrInsufficientBalance}
+		sut := interactor.NewQRCodeInteractor(qrRepo, newCtxTrackingUserRepo(), transferUC, notifier, testQRCodec, &mockLogger{})
+		amount := int64(300)
+		qrCode, _ := entities.NewReceiveQRCode(uuid.New(), &amount)
+		_ = qrRepo.Create(context.Background(), qrCode)
+
+		_, err := sut.ScanQR(context.Background(), &inputport.ScanQRRequest{
+			UserID: uuid.New(), Code: qrCode.Code, IdempotencyKey: "key",
+		})
+		require.Error(t, err)
+
+		require.Len(t, notifier.events, 1)
+		assert.Equal(t, entities.RealtimeEventQRScanned, notifier.events[0].event.Type)
+	})
+
+	t.Run("自分のQRコードを読み取った場合は通知しない", func(t *testing.T) {
+		qrRepo := newMockQRCodeRepo()
+		notifier := &mockRealtimeNotifier{}
+		sut := interactor.NewQRCodeInteractor(qrRepo, newCtxTrackingUserRepo(), &mockPointTransferUC{}, notifier, testQRCodec, &mockLogger{})
+		ownerID := uuid.New()
+		amount := int64(300)
+		qrCode, _ := entities.NewReceiveQRCode(ownerID, &amount)
+		_ = qrRepo.Create(context.Background(), qrCode)
+
+		_, err := sut.ScanQR(context.Background(), &inputport.ScanQRRequest{
+			UserID: ownerID, Code: qrCode.Code, IdempotencyKey: "key",
+		})
+		require.Error(t, err)
+		assert.Empty(t, notifier.events)
+	})
+}
+
 // --- GetQRCodeHistory ---
 
 func TestQRCodeInteractor_GetQRCodeHistory(t *testing.T) {
 	t.Run("正常にQRコード履歴を取得できる", func(t *testing.T) {
 		qrRepo := newMockQRCodeRepo()
-		sut := interactor.NewQRCodeInteractor(qrRepo, &mockPointTransferUC{}, &mockLogger{})
+		sut := interactor.NewQRCodeInteractor(qrRepo, newCtxTrackingUserRepo(), &mockPointTransferUC{}, &mockRealtimeNotifier{}, testQRCodec, &mockLogger{})
 
 		userID := uuid.New()
 		qr1, _ := entities.NewReceiveQRCode(userID, nil)
@@ -270,3 +353,77 @@ func TestQRCodeInteractor_GetQRCodeHistory(t *testing.T) {
 		assert.Equal(t, 2, len(resp.QRCodes))
 	})
 }
+
+// --- QRペイロード ---
+
+func TestQRCodeInteractor_QRPayload(t *testing.T) {
+	setup := func() (*mockQRCodeRepo, *ctxTrackingUserRepo, inputport.QRCodeInputPort) {
+		qrRepo := newMockQRCodeRepo()
+		userRepo := newCtxTrackingUserRepo()
+		sut := interactor.NewQRCodeInteractor(qrRepo, userRepo, &mockPointTransferUC{}, &mockRealtimeNotifier{}, testQRCodec, &mockLogger{})
+		return qrRepo, userRepo, sut
+	}
+
+	t.Run("生成したQRコードのデータを読み取って送金できる", func(t *testing.T) {
+		_, _, sut := setup()
+		amount := int64(500)
+		generated, err := sut.GenerateReceiveQR(context.Background(), &inputport.GenerateReceiveQRRequest{UserID: uuid.New(), Amount: &amount})
+		require.NoError(t, err)
+
+		resp, err := sut.ScanQR(context.Background(), &inputport.ScanQRRequest{
+			UserID: uuid.New(), Code: generated.QRCodeData, IdempotencyKey: "key",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, generated.QRCode.ID, resp.QRCode.ID)
+	})
+
+	t.Run("旧形式のQRコードも読み取れる", func(t *testing.T) {
+		qrRepo, _, sut := setup()
+		qrCode, _ := entities.NewSendQRCode(uuid.New(), 300)
+		_ = qrRepo.Create(context.Background(), qrCode)
+
+		_, err := sut.ScanQR(context.Background(), &inputport.ScanQRRequest{
+			UserID: uuid.New(), Code: "send:" + qrCode.Code + ":300", IdempotencyKey: "key",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("種類の違うQRコードとして読み取ったものは使えない", func(t *testing.T) {
+		qrRepo, _, sut := setup()
+		qrCode, _ := entities.NewSendQRCode(uuid.New(), 300)
+		_ = qrRepo.Create(context.Background(), qrCode)
+
+		_, err := sut.ScanQR(context.Background(), &inputport.ScanQRRequest{
+			UserID: uuid.New(), Code: "receive:" + qrCode.Code, IdempotencyKey: "key",
+		})
+		assert.ErrorIs(t, err, entities.ErrQRCodeNotFound)
+	})
+
+	t.Run("個人QRコードは送金に読み取れない", func(t *testing.T) {
+		_, userRepo, sut := setup()
+		user := createTestUserWithBalance(t, "owner", 0, entities.RoleUser)
+		userRepo.setUser(user)
+		personal, err := sut.GetPersonalQR(context.Background(), &inputport.GetPersonalQRRequest{UserID: user.ID})
+		require.NoError(t, err)
+
+		_, err = sut.ScanQR(context.Background(), &inputport.ScanQRRequest{
+			UserID: uuid.New(), Code: personal.QRCodeData, IdempotencyKey: "key",
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidQRPayload)
+	})
+
+	t.Run("個人QRコードは署名付きのデータで返す", func(t *testing.T) {
+		_, userRepo, sut := setup()
+		user := createTestUserWithBalance(t, "owner", 0, entities.RoleUser)
+		userRepo.setUser(user)
+
+		resp, err := sut.GetPersonalQR(context.Background(), &inputport.GetPersonalQRRequest{UserID: user.ID})
+		require.NoError(t, err)
+		payload, err := testQRCodec.Decode(resp.QRCodeData)
+		require.NoError(t, err)
+		userID, err := payload.UserID()
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, userID)
+		assert.Nil(t, payload.ExpiresAt)
+	})
+}
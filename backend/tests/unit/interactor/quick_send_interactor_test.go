@@ -0,0 +1,126 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuickSendInteractor(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*testsupport.Repositories, inputport.QuickSendInputPort, *entities.User) {
+		repos := testsupport.New()
+		me := createTestUserWithBalance(t, "me", 1000, entities.RoleUser)
+		repos.Users.Seed(me)
+		sut := interactor.NewQuickSendInteractor(repos.Friendships, repos.FriendPins, repos.Transactions, &mockLogger{})
+		return repos, sut, me
+	}
+	seedFriend := func(t *testing.T, repos *testsupport.Repositories, me *entities.User, name string) *entities.User {
+		t.Helper()
+		friend := createTestUserWithBalance(t, name, 0, entities.RoleUser)
+		repos.Users.Seed(friend)
+		f, err := entities.NewFriendship(me.ID, friend.ID)
+		require.NoError(t, err)
+		require.NoError(t, f.Accept())
+		require.NoError(t, repos.Friendships.Create(ctx, f))
+		return friend
+	}
+	transfer := func(t *testing.T, repos *testsupport.Repositories, from, to *entities.User, amount int64, ago time.Duration) {
+		t.Helper()
+		tx, err := entities.NewTransfer(from.ID, to.ID, amount, uuid.NewString(), "")
+		require.NoError(t, err)
+		tx.Status = entities.TransactionStatusCompleted
+		tx.CreatedAt = time.Now().Add(-ago)
+		require.NoError(t, repos.Transactions.Create(ctx, tx))
+	}
+	names := func(entries []*entities.QuickSendEntry) []string {
+		out := make([]string, len(entries))
+		for i, e := range entries {
+			out[i] = e.Friend.Username
+		}
+		return out
+	}
+
+	t.Run("ピン留めした友達、最近送金した友達の順に覚えている金額付きで返す", func(t *testing.T) {
+		repos, sut, me := setup(t)
+		alice := seedFriend(t, repos, me, "alice")
+		bob := seedFriend(t, repos, me, "bob")
+		carol := seedFriend(t, repos, me, "carol")
+		seedFriend(t, repos, me, "dave")
+
+		transfer(t, repos, me, bob, 100, 3*time.Hour)
+		transfer(t, repos, me, bob, 100, 2*time.Hour)
+		transfer(t, repos, me, bob, 250, time.Hour)
+		transfer(t, repos, me, carol, 50, 30*time.Minute)
+		transfer(t, repos, bob, me, 999, time.Minute)
+		transfer(t, repos, me, alice, 70, entities.QuickSendLookback+time.Hour)
+		require.NoError(t, sut.PinFriend(ctx, me.ID, alice.ID))
+
+		entries, err := sut.GetQuickSend(ctx, &inputport.GetQuickSendRequest{UserID: me.ID, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice", "carol", "bob", "dave"}, names(entries))
+
+		assert.NotNil(t, entries[0].PinnedAt)
+		assert.Nil(t, entries[0].Amounts, "集計期間より前の送金は覚えない")
+		b := entries[2].Amounts
+		require.NotNil(t, b)
+		assert.Equal(t, int64(250), b.LastAmount)
+		assert.Equal(t, int64(100), b.FrequentAmount)
+		assert.Equal(t, int64(3), b.TransferCount, "受け取った取引は含めない")
+
+		entries, err = sut.GetQuickSend(ctx, &inputport.GetQuickSendRequest{UserID: me.ID, Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice", "carol"}, names(entries))
+	})
+
+	t.Run("無効なユーザーは含めない", func(t *testing.T) {
+		repos, sut, me := setup(t)
+		seedFriend(t, repos, me, "alice")
+		inactive := seedFriend(t, repos, me, "bob")
+		inactive.IsActive = false
+		repos.Users.Seed(inactive)
+
+		entries, err := sut.GetQuickSend(ctx, &inputport.GetQuickSendRequest{UserID: me.ID, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice"}, names(entries))
+	})
+
+	t.Run("友達でないユーザーはピン留めできない", func(t *testing.T) {
+		repos, sut, me := setup(t)
+		stranger := createTestUserWithBalance(t, "stranger", 0, entities.RoleUser)
+		repos.Users.Seed(stranger)
+
+		assert.ErrorIs(t, sut.PinFriend(ctx, me.ID, stranger.ID), entities.ErrPinTargetNotFriend)
+		assert.ErrorIs(t, sut.PinFriend(ctx, me.ID, me.ID), entities.ErrPinTargetNotFriend)
+	})
+
+	t.Run("上限までピン留めでき、同じ友達のピン留めは何もしない", func(t *testing.T) {
+		repos, sut, me := setup(t)
+		var friends []*entities.User
+		for i := 0; i <= entities.FriendPinMaxPerUser; i++ {
+			friends = append(friends, seedFriend(t, repos, me, "friend"+string(rune('a'+i))))
+		}
+		for _, f := range friends[:entities.FriendPinMaxPerUser] {
+			require.NoError(t, sut.PinFriend(ctx, me.ID, f.ID))
+		}
+		require.NoError(t, sut.PinFriend(ctx, me.ID, friends[0].ID))
+
+		err := sut.PinFriend(ctx, me.ID, friends[entities.FriendPinMaxPerUser].ID)
+		assert.ErrorIs(t, err, entities.ErrFriendPinLimit)
+
+		require.NoError(t, sut.UnpinFriend(ctx, me.ID, friends[0].ID))
+		require.NoError(t, sut.PinFriend(ctx, me.ID, friends[entities.FriendPinMaxPerUser].ID))
+		pins, err := repos.FriendPins.ReadListByUserID(ctx, me.ID)
+		require.NoError(t, err)
+		assert.Len(t, pins, entities.FriendPinMaxPerUser)
+	})
+}
@@ -0,0 +1,148 @@
+package interactor_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ========================================
+// Mock ReasonCodeRepository
+// （admin_interactor_test.go からも使用）
+// ========================================
+
+type mockReasonCodeRepo struct {
+	codes map[string]*entities.ReasonCode
+}
+
+// newMockReasonCodeRepo は有効な "reward" と無効化済みの "retired" を登録済みのモックを返す
+func newMockReasonCodeRepo() *mockReasonCodeRepo {
+	reward, _ := entities.NewReasonCode("reward", "報酬", "")
+	retired, _ := entities.NewReasonCode("retired", "廃止済み", "")
+	retired.IsActive = false
+	return &mockReasonCodeRepo{
+		codes: map[string]*entities.ReasonCode{
+			reward.Code:  reward,
+			retired.Code: retired,
+		},
+	}
+}
+
+func (m *mockReasonCodeRepo) Create(ctx context.Context, reasonCode *entities.ReasonCode) error {
+	if _, ok := m.codes[reasonCode.Code]; ok {
+		return entities.ErrReasonCodeExists
+	}
+	m.codes[reasonCode.Code] = reasonCode
+	return nil
+}
+func (m *mockReasonCodeRepo) ReadByCode(ctx context.Context, code string) (*entities.ReasonCode, error) {
+	r, ok := m.codes[code]
+	if !ok {
+		return nil, entities.ErrReasonCodeNotFound
+	}
+	return r, nil
+}
+func (m *mockReasonCodeRepo) Update(ctx context.Context, reasonCode *entities.ReasonCode) error {
+	m.codes[reasonCode.Code] = reasonCode
+	return nil
+}
+func (m *mockReasonCodeRepo) ReadList(ctx context.Context, activeOnly bool) ([]*entities.ReasonCode, error) {
+	var result []*entities.ReasonCode
+	for _, r := range m.codes {
+		if activeOnly && !r.IsActive {
+			continue
+		}
+		result = append(result, r)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Code < result[j].Code })
+	return result, nil
+}
+
+// ========================================
+// ReasonCodeInteractor テスト
+// ========================================
+
+func TestReasonCodeInteractor(t *testing.T) {
+	setup := func() (inputport.ReasonCodeInputPort, *mockReasonCodeRepo, *entities.User, *entities.User) {
+		userRepo := newCtxTrackingUserRepo()
+		admin := createTestUserWithBalance(t, "admin", 0, "admin")
+		user := createTestUserWithBalance(t, "user", 0, "user")
+		userRepo.setUser(admin)
+		userRepo.setUser(user)
+		repo := newMockReasonCodeRepo()
+		return interactor.NewReasonCodeInteractor(repo, userRepo, &mockLogger{}), repo, admin, user
+	}
+
+	t.Run("一覧は既定で有効なコードのみ返す", func(t *testing.T) {
+		sut, _, _, _ := setup()
+		codes, err := sut.ListReasonCodes(context.Background(), &inputport.ListReasonCodesRequest{})
+		require.NoError(t, err)
+		require.Len(t, codes, 1)
+		assert.Equal(t, "reward", codes[0].Code)
+
+		codes, err = sut.ListReasonCodes(context.Background(), &inputport.ListReasonCodesRequest{IncludeInactive: true})
+		require.NoError(t, err)
+		assert.Len(t, codes, 2)
+	})
+
+	t.Run("管理者は理由コードを作成できる", func(t *testing.T) {
+		sut, repo, admin, _ := setup()
+		created, err := sut.CreateReasonCode(context.Background(), &inputport.CreateReasonCodeRequest{
+			AdminID: admin.ID, Code: " Bonus_2026 ", Label: "特別ボーナス",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "bonus_2026", created.Code)
+		assert.True(t, created.IsActive)
+		assert.Contains(t, repo.codes, "bonus_2026")
+	})
+
+	t.Run("作成時のエラー", func(t *testing.T) {
+		sut, _, admin, user := setup()
+		_, err := sut.CreateReasonCode(context.Background(), &inputport.CreateReasonCodeRequest{
+			AdminID: user.ID, Code: "bonus", Label: "ボーナス",
+		})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+
+		_, err = sut.CreateReasonCode(context.Background(), &inputport.CreateReasonCodeRequest{
+			AdminID: admin.ID, Code: "bad code!", Label: "不正",
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidReasonCode)
+
+		_, err = sut.CreateReasonCode(context.Background(), &inputport.CreateReasonCodeRequest{
+			AdminID: admin.ID, Code: "reward", Label: "重複",
+		})
+		assert.ErrorIs(t, err, entities.ErrReasonCodeExists)
+	})
+
+	t.Run("管理者は理由コードを無効化できる", func(t *testing.T) {
+		sut, repo, admin, _ := setup()
+		updated, err := sut.UpdateReasonCode(context.Background(), &inputport.UpdateReasonCodeRequest{
+			AdminID: admin.ID, Code: "REWARD", Label: "報酬（旧）", IsActive: false,
+		})
+		require.NoError(t, err)
+		assert.False(t, updated.IsActive)
+		assert.Equal(t, "報酬（旧）", repo.codes["reward"].Label)
+	})
+
+	t.Run("存在しないコードは更新できない", func(t *testing.T) {
+		sut, _, admin, _ := setup()
+		_, err := sut.UpdateReasonCode(context.Background(), &inputport.UpdateReasonCodeRequest{
+			AdminID: admin.ID, Code: "missing", Label: "なし", IsActive: true,
+		})
+		assert.ErrorIs(t, err, entities.ErrReasonCodeNotFound)
+	})
+
+	t.Run("一般ユーザーは更新できない", func(t *testing.T) {
+		sut, _, _, user := setup()
+		_, err := sut.UpdateReasonCode(context.Background(), &inputport.UpdateReasonCodeRequest{
+			AdminID: user.ID, Code: "reward", Label: "報酬", IsActive: true,
+		})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
@@ -0,0 +1,201 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRecurringTransferRepo は定期送金リポジトリのモック
+type mockRecurringTransferRepo struct {
+	transfers map[uuid.UUID]*entities.RecurringTransfer
+}
+
+func newMockRecurringTransferRepo() *mockRecurringTransferRepo {
+	return &mockRecurringTransferRepo{transfers: make(map[uuid.UUID]*entities.RecurringTransfer)}
+}
+
+func (m *mockRecurringTransferRepo) Create(ctx context.Context, transfer *entities.RecurringTransfer) error {
+	m.transfers[transfer.ID] = transfer
+	return nil
+}
+
+func (m *mockRecurringTransferRepo) Read(ctx context.Context, id uuid.UUID) (*entities.RecurringTransfer, error) {
+	transfer, ok := m.transfers[id]
+	if !ok {
+		return nil, entities.ErrRecurringNotFound
+	}
+	return transfer, nil
+}
+
+func (m *mockRecurringTransferRepo) Update(ctx context.Context, transfer *entities.RecurringTransfer) error {
+	m.transfers[transfer.ID] = transfer
+	return nil
+}
+
+func (m *mockRecurringTransferRepo) ReadListByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.RecurringTransfer, error) {
+	var result []*entities.RecurringTransfer
+	for _, transfer := range m.transfers {
+		if transfer.IsParticipant(userID) {
+			result = append(result, transfer)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockRecurringTransferRepo) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	list, _ := m.ReadListByUser(ctx, userID, 0, 0)
+	return int64(len(list)), nil
+}
+
+func (m *mockRecurringTransferRepo) ReadDue(ctx context.Context, now time.Time, limit int) ([]*entities.RecurringTransfer, error) {
+	var result []*entities.RecurringTransfer
+	for _, transfer := range m.transfers {
+		if transfer.IsDue(now) {
+			result = append(result, transfer)
+		}
+	}
+	return result, nil
+}
+
+func setupRecurringTransfer(t *testing.T) (inputport.RecurringTransferInputPort, *mockRecurringTransferRepo, *mockPointTransferPort, *entities.User, *entities.User) {
+	t.Helper()
+	repo := newMockRecurringTransferRepo()
+	userRepo := newMockUserRepoForTR()
+	ptPort := newMockPointTransferPort()
+
+	sender := createTestUserWithBalance(t, "rt_sender", 10000, entities.RoleUser)
+	receiver := createTestUserWithBalance(t, "rt_receiver", 0, entities.RoleUser)
+	userRepo.setUser(sender)
+	userRepo.setUser(receiver)
+
+	ptPort.transferResp = &inputport.TransferResponse{
+		Transaction: &entities.Transaction{ID: uuid.New()},
+		FromUser:    sender,
+		ToUser:      receiver,
+	}
+
+	uc := interactor.NewRecurringTransferInteractor(repo, userRepo, ptPort, &mockContentModeration{}, &mockTransferRequestLogger{})
+	return uc, repo, ptPort, sender, receiver
+}
+
+func TestRecurringTransferInteractor_CreateRecurringTransfer(t *testing.T) {
+	t.Run("定期送金を登録", func(t *testing.T) {
+		uc, repo, _, sender, receiver := setupRecurringTransfer(t)
+
+		info, err := uc.CreateRecurringTransfer(context.Background(), &inputport.CreateRecurringTransferRequest{
+			FromUserID: sender.ID,
+			ToUserID:   receiver.ID,
+			Amount:     300,
+			Interval:   entities.RecurringIntervalMonthly,
+			Message:    "家賃の分担",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, receiver.ID, info.ToUser.ID)
+		assert.Len(t, repo.transfers, 1)
+	})
+
+	t.Run("メッセージがモデレーションで拒否されると登録しない", func(t *testing.T) {
+		repo := newMockRecurringTransferRepo()
+		userRepo := newMockUserRepoForTR()
+		sender := createTestUserWithBalance(t, "rt_sender2", 10000, entities.RoleUser)
+		receiver := createTestUserWithBalance(t, "rt_receiver2", 0, entities.RoleUser)
+		userRepo.setUser(sender)
+		userRepo.setUser(receiver)
+		moderation := &mockContentModeration{rejectFields: map[entities.ModerationField]bool{entities.ModerationFieldTransferMessage: true}}
+
+		uc := interactor.NewRecurringTransferInteractor(repo, userRepo, newMockPointTransferPort(), moderation, &mockTransferRequestLogger{})
+		_, err := uc.CreateRecurringTransfer(context.Background(), &inputport.CreateRecurringTransferRequest{
+			FromUserID: sender.ID,
+			ToUserID:   receiver.ID,
+			Amount:     300,
+			Interval:   entities.RecurringIntervalWeekly,
+			Message:    "bad words",
+		})
+		assert.ErrorIs(t, err, entities.ErrContentRejected)
+		assert.Empty(t, repo.transfers)
+	})
+}
+
+func TestRecurringTransferInteractor_ProcessDueTransfers(t *testing.T) {
+	t.Run("実行日時を過ぎた定期送金を周期ごとの冪等性キーで送金", func(t *testing.T) {
+		uc, repo, ptPort, sender, receiver := setupRecurringTransfer(t)
+		rt, _ := entities.NewRecurringTransfer(sender.ID, receiver.ID, 300, entities.RecurringIntervalWeekly, "", time.Time{})
+		repo.transfers[rt.ID] = rt
+		expectedKey := rt.IdempotencyKey()
+
+		executed, err := uc.ProcessDueTransfers(context.Background(), time.Now(), 10)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, executed)
+		require.NotNil(t, ptPort.lastReq)
+		assert.Equal(t, expectedKey, ptPort.lastReq.IdempotencyKey)
+		assert.Equal(t, int64(300), ptPort.lastReq.Amount)
+		assert.Equal(t, 1, rt.RunCount)
+		assert.True(t, rt.NextRunAt.After(time.Now()))
+	})
+
+	t.Run("残高不足の場合は自動停止", func(t *testing.T) {
+		uc, repo, ptPort, sender, receiver := setupRecurringTransfer(t)
+		ptPort.transferErr = fmt.Errorf("failed to update balances: %w", entities.ErrInsufficientBalance)
+		rt, _ := entities.NewRecurringTransfer(sender.ID, receiver.ID, 300, entities.RecurringIntervalWeekly, "", time.Time{})
+		repo.transfers[rt.ID] = rt
+
+		executed, err := uc.ProcessDueTransfers(context.Background(), time.Now(), 10)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, executed)
+		assert.Equal(t, entities.RecurringTransferStatusStopped, rt.Status)
+		assert.Equal(t, "insufficient_balance", rt.StopReason)
+	})
+
+	t.Run("一時的な失敗は再試行し、上限に達したら停止", func(t *testing.T) {
+		uc, repo, ptPort, sender, receiver := setupRecurringTransfer(t)
+		ptPort.transferErr = errors.New("database is unavailable")
+		rt, _ := entities.NewRecurringTransfer(sender.ID, receiver.ID, 300, entities.RecurringIntervalWeekly, "", time.Time{})
+		repo.transfers[rt.ID] = rt
+
+		_, err := uc.ProcessDueTransfers(context.Background(), time.Now(), 10)
+		require.NoError(t, err)
+		assert.True(t, rt.IsActive())
+		assert.Equal(t, 1, rt.ConsecutiveFailures)
+
+		for i := 1; i < entities.RecurringTransferMaxFailures; i++ {
+			_, _ = uc.ProcessDueTransfers(context.Background(), time.Now(), 10)
+		}
+		assert.Equal(t, entities.RecurringTransferStatusStopped, rt.Status)
+	})
+}
+
+func TestRecurringTransferInteractor_CancelRecurringTransfer(t *testing.T) {
+	uc, repo, _, sender, receiver := setupRecurringTransfer(t)
+	rt, _ := entities.NewRecurringTransfer(sender.ID, receiver.ID, 300, entities.RecurringIntervalWeekly, "", time.Time{})
+	repo.transfers[rt.ID] = rt
+
+	_, err := uc.CancelRecurringTransfer(context.Background(), &inputport.CancelRecurringTransferRequest{
+		TransferID: rt.ID,
+		UserID:     uuid.New(),
+	})
+	assert.ErrorIs(t, err, entities.ErrRecurringNotFound)
+
+	cancelled, err := uc.CancelRecurringTransfer(context.Background(), &inputport.CancelRecurringTransferRequest{
+		TransferID: rt.ID,
+		UserID:     receiver.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entities.RecurringTransferStatusCancelled, cancelled.Status)
+
+	list, err := uc.GetRecurringTransfers(context.Background(), &inputport.GetRecurringTransfersRequest{UserID: sender.ID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), list.Total)
+	assert.Equal(t, 20, list.Limit)
+}
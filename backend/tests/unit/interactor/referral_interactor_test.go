@@ -0,0 +1,342 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mock ReferralTracker ---
+
+// mockReferralTracker は紹介コードを持たない ReferralTracker のモック（登録・チェックインのテスト用）
+type mockReferralTracker struct {
+	completed []uuid.UUID
+}
+
+func (m *mockReferralTracker) ResolveReferralCode(ctx context.Context, code string) (*entities.ReferralCode, error) {
+	return nil, entities.ErrInvalidReferralCode
+}
+func (m *mockReferralTracker) RecordReferral(ctx context.Context, req *inputport.RecordReferralRequest) (*entities.Referral, error) {
+	return entities.NewReferral(req.Code.UserID, req.RefereeID, req.Code.Code, req.IPAddress), nil
+}
+func (m *mockReferralTracker) CompleteQualifyingAction(ctx context.Context, refereeID uuid.UUID) {
+	m.completed = append(m.completed, refereeID)
+}
+
+// --- Mock ReferralRepository ---
+
+type mockReferralRepo struct {
+	codes     map[uuid.UUID]*entities.ReferralCode
+	referrals []*entities.Referral
+}
+
+func newMockReferralRepo() *mockReferralRepo {
+	return &mockReferralRepo{codes: make(map[uuid.UUID]*entities.ReferralCode)}
+}
+
+func (m *mockReferralRepo) ReadCodeByUser(ctx context.Context, userID uuid.UUID) (*entities.ReferralCode, error) {
+	return m.codes[userID], nil
+}
+func (m *mockReferralRepo) ReadCodeByCode(ctx context.Context, code string) (*entities.ReferralCode, error) {
+	for _, c := range m.codes {
+		if c.Code == code {
+			return c, nil
+		}
+	}
+	return nil, entities.ErrInvalidReferralCode
+}
+func (m *mockReferralRepo) CreateCode(ctx context.Context, code *entities.ReferralCode) error {
+	if _, ok := m.codes[code.UserID]; !ok {
+		m.codes[code.UserID] = code
+	}
+	return nil
+}
+func (m *mockReferralRepo) Create(ctx context.Context, referral *entities.Referral) error {
+	m.referrals = append(m.referrals, referral)
+	return nil
+}
+func (m *mockReferralRepo) ReadPendingByReferee(ctx context.Context, refereeID uuid.UUID) (*entities.Referral, error) {
+	for _, r := range m.referrals {
+		if r.RefereeID == refereeID && r.Status == entities.ReferralStatusPending {
+			copy := *r
+			return &copy, nil
+		}
+	}
+	return nil, nil
+}
+func (m *mockReferralRepo) CountByIPSince(ctx context.Context, ipAddress string, since time.Time) (int64, error) {
+	var count int64
+	for _, r := range m.referrals {
+		if r.IPAddress == ipAddress && !r.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+func (m *mockReferralRepo) MarkRewarded(ctx context.Context, id uuid.UUID, referrerReward, refereeReward int64, at time.Time) (bool, error) {
+	for _, r := range m.referrals {
+		if r.ID == id && r.Status == entities.ReferralStatusPending {
+			r.Status = entities.ReferralStatusRewarded
+			r.ReferrerReward = referrerReward
+			r.RefereeReward = refereeReward
+			r.RewardedAt = &at
+			return true, nil
+		}
+	}
+	return false, nil
+}
+func (m *mockReferralRepo) ReadReport(ctx context.Context, from, to time.Time, limit int) (*entities.ReferralReport, error) {
+	return &entities.ReferralReport{From: from, To: to}, nil
+}
+
+type referralDeps struct {
+	referralRepo     *mockReferralRepo
+	userRepo         *ctxTrackingUserRepo
+	txRepo           *ctxTrackingTransactionRepo
+	loginAttemptRepo *mockLoginAttemptRepo
+	referrer         *entities.User
+	referee          *entities.User
+	admin            *entities.User
+}
+
+func setupReferralInteractor(t *testing.T) (*referralDeps, inputport.ReferralInputPort) {
+	userRepo := newCtxTrackingUserRepo()
+	d := &referralDeps{
+		referralRepo:     newMockReferralRepo(),
+		userRepo:         userRepo,
+		txRepo:           newCtxTrackingTransactionRepo(),
+		loginAttemptRepo: newMockLoginAttemptRepo(),
+		referrer:         createTestUserWithBalance(t, "referrer", 0, "user"),
+		referee:          createTestUserWithBalance(t, "referee", 0, "user"),
+		admin:            createTestUserWithBalance(t, "admin", 0, "admin"),
+	}
+	userRepo.setUser(d.referrer)
+	userRepo.setUser(d.referee)
+	userRepo.setUser(d.admin)
+	sut := interactor.NewReferralInteractor(
+		&ctxTrackingTxManager{}, d.referralRepo, userRepo, d.txRepo,
+		newCtxTrackingPointBatchRepo(), d.loginAttemptRepo, &mockLogger{},
+	)
+	return d, sut
+}
+
+// --- GetReferralCode / ResolveReferralCode ---
+
+func TestReferralInteractor_GetReferralCode(t *testing.T) {
+	t.Run("未発行なら発行し、2回目以降は同じコードを返す", func(t *testing.T) {
+		d, sut := setupReferralInteractor(t)
+
+		first, err := sut.GetReferralCode(context.Background(), d.referrer.ID)
+		require.NoError(t, err)
+		assert.Len(t, first.Code, entities.ReferralCodeLength)
+
+		second, err := sut.GetReferralCode(context.Background(), d.referrer.ID)
+		require.NoError(t, err)
+		assert.Equal(t, first.Code, second.Code)
+	})
+
+	t.Run("入力の大文字小文字・ハイフンを無視してコードを確認できる", func(t *testing.T) {
+		d, sut := setupReferralInteractor(t)
+		code, err := sut.GetReferralCode(context.Background(), d.referrer.ID)
+		require.NoError(t, err)
+
+		resolved, err := sut.ResolveReferralCode(context.Background(), " "+code.Code[:4]+"-"+code.Code[4:]+" ")
+		require.NoError(t, err)
+		assert.Equal(t, d.referrer.ID, resolved.UserID)
+	})
+
+	t.Run("存在しないコードはエラー", func(t *testing.T) {
+		_, sut := setupReferralInteractor(t)
+		_, err := sut.ResolveReferralCode(context.Background(), "ZZZZ-ZZZZ")
+		assert.ErrorIs(t, err, entities.ErrInvalidReferralCode)
+
+		_, err = sut.ResolveReferralCode(context.Background(), "short")
+		assert.ErrorIs(t, err, entities.ErrInvalidReferralCode)
+	})
+}
+
+// --- RecordReferral ---
+
+func TestReferralInteractor_RecordReferral(t *testing.T) {
+	record := func(t *testing.T, d *referralDeps, sut inputport.ReferralInputPort, refereeID uuid.UUID, ip string) *entities.Referral {
+		code, err := sut.GetReferralCode(context.Background(), d.referrer.ID)
+		require.NoError(t, err)
+		referral, err := sut.RecordReferral(context.Background(), &inputport.RecordReferralRequest{
+			Code: code, RefereeID: refereeID, IPAddress: ip,
+		})
+		require.NoError(t, err)
+		return referral
+	}
+
+	t.Run("問題がなければ初回チェックイン待ちで記録する", func(t *testing.T) {
+		d, sut := setupReferralInteractor(t)
+		referral := record(t, d, sut, d.referee.ID, "203.0.113.10")
+		assert.Equal(t, entities.ReferralStatusPending, referral.Status)
+		assert.Len(t, d.referralRepo.referrals, 1)
+	})
+
+	t.Run("紹介者本人がログインしたIPアドレスからの登録は対象外", func(t *testing.T) {
+		d, sut := setupReferralInteractor(t)
+		referrerID := d.referrer.ID
+		require.NoError(t, d.loginAttemptRepo.Create(context.Background(), &entities.LoginAttempt{
+			UserID: &referrerID, IPAddress: "203.0.113.10", Success: true, CreatedAt: time.Now(),
+		}))
+
+		referral := record(t, d, sut, d.referee.ID, "203.0.113.10")
+		assert.Equal(t, entities.ReferralStatusRejected, referral.Status)
+		assert.Equal(t, entities.ReferralRejectSelfReferral, referral.RejectReason)
+	})
+
+	t.Run("自分の紹介コードでは特典の対象外", func(t *testing.T) {
+		d, sut := setupReferralInteractor(t)
+		referral := record(t, d, sut, d.referrer.ID, "")
+		assert.Equal(t, entities.ReferralRejectSelfReferral, referral.RejectReason)
+	})
+
+	t.Run("同じIPアドレスからの登録が上限を超えると対象外", func(t *testing.T) {
+		d, sut := setupReferralInteractor(t)
+		for i := 0; i < entities.ReferralMaxPerIP; i++ {
+			referral := record(t, d, sut, uuid.New(), "198.51.100.7")
+			assert.Equal(t, entities.ReferralStatusPending, referral.Status)
+		}
+
+		referral := record(t, d, sut, d.referee.ID, "198.51.100.7")
+		assert.Equal(t, entities.ReferralStatusRejected, referral.Status)
+		assert.Equal(t, entities.ReferralRejectIPLimit, referral.RejectReason)
+	})
+}
+
+// --- CompleteQualifyingAction ---
+
+func TestReferralInteractor_CompleteQualifyingAction(t *testing.T) {
+	recordPending := func(t *testing.T, d *referralDeps, sut inputport.ReferralInputPort) *entities.Referral {
+		code, err := sut.GetReferralCode(context.Background(), d.referrer.ID)
+		require.NoError(t, err)
+		referral, err := sut.RecordReferral(context.Background(), &inputport.RecordReferralRequest{
+			Code: code, RefereeID: d.referee.ID, IPAddress: "203.0.113.10",
+		})
+		require.NoError(t, err)
+		return referral
+	}
+
+	t.Run("双方に特典を1回だけ付与する", func(t *testing.T) {
+		d, sut := setupReferralInteractor(t)
+		referral := recordPending(t, d, sut)
+
+		sut.CompleteQualifyingAction(context.Background(), d.referee.ID)
+		sut.CompleteQualifyingAction(context.Background(), d.referee.ID)
+
+		assert.Equal(t, entities.ReferralStatusRewarded, referral.Status)
+		assert.Equal(t, entities.ReferrerRewardPoints, referral.ReferrerReward)
+		require.Len(t, d.txRepo.transactions, 2)
+
+		granted := map[uuid.UUID]int64{}
+		for _, tx := range d.txRepo.transactions {
+			require.NotNil(t, tx.ToUserID)
+			granted[*tx.ToUserID] = tx.Amount
+			assert.Equal(t, referral.ID.String(), tx.Metadata["referral_id"])
+		}
+		assert.Equal(t, entities.RefereeRewardPoints, granted[d.referee.ID])
+		assert.Equal(t, entities.ReferrerRewardPoints, granted[d.referrer.ID])
+	})
+
+	t.Run("無効化された紹介者には付与しない", func(t *testing.T) {
+		d, sut := setupReferralInteractor(t)
+		referral := recordPending(t, d, sut)
+		d.referrer.IsActive = false
+
+		sut.CompleteQualifyingAction(context.Background(), d.referee.ID)
+
+		assert.Equal(t, entities.ReferralStatusRewarded, referral.Status)
+		assert.Equal(t, int64(0), referral.ReferrerReward)
+		assert.Len(t, d.txRepo.transactions, 1)
+	})
+
+	t.Run("対象外の紹介や紹介のないユーザーには何もしない", func(t *testing.T) {
+		d, sut := setupReferralInteractor(t)
+		code, err := sut.GetReferralCode(context.Background(), d.referrer.ID)
+		require.NoError(t, err)
+		_, err = sut.RecordReferral(context.Background(), &inputport.RecordReferralRequest{
+			Code: code, RefereeID: d.referrer.ID,
+		})
+		require.NoError(t, err)
+
+		sut.CompleteQualifyingAction(context.Background(), d.referrer.ID)
+		sut.CompleteQualifyingAction(context.Background(), uuid.New())
+		assert.Empty(t, d.txRepo.transactions)
+	})
+}
+
+// --- GetReferralReport ---
+
+func TestReferralInteractor_GetReferralReport(t *testing.T) {
+	t.Run("期間未指定なら直近30日間", func(t *testing.T) {
+		d, sut := setupReferralInteractor(t)
+		report, err := sut.GetReferralReport(context.Background(), &inputport.GetReferralReportRequest{AdminID: d.admin.ID})
+		require.NoError(t, err)
+		assert.Equal(t, 30*24*time.Hour, report.To.Sub(report.From))
+	})
+
+	t.Run("開始日が終了日より後ならエラー", func(t *testing.T) {
+		d, sut := setupReferralInteractor(t)
+		now := time.Now()
+		_, err := sut.GetReferralReport(context.Background(), &inputport.GetReferralReportRequest{
+			AdminID: d.admin.ID, From: now, To: now.Add(-time.Hour),
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidDateRange)
+	})
+
+	t.Run("管理者以外はエラー", func(t *testing.T) {
+		d, sut := setupReferralInteractor(t)
+		_, err := sut.GetReferralReport(context.Background(), &inputport.GetReferralReportRequest{AdminID: d.referrer.ID})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
+
+// --- AuthInteractor.Register with referral code ---
+
+func TestAuthInteractor_RegisterWithReferralCode(t *testing.T) {
+	setup := func(t *testing.T) (*referralDeps, inputport.ReferralInputPort, inputport.AuthInputPort) {
+		d, referrals := setupReferralInteractor(t)
+		sut := interactor.NewAuthInteractor(d.userRepo, newMockSessionRepo(), d.loginAttemptRepo,
+			&mockPasswordService{verifyOK: true}, &mockEmailService{}, &mockNotificationDispatcher{}, referrals, &mockOnboardingBonusGranter{}, &mockEmailVerificationGate{}, &mockLogger{})
+		return d, referrals, sut
+	}
+
+	t.Run("紹介コード付きで登録すると紹介が記録される", func(t *testing.T) {
+		d, referrals, sut := setup(t)
+		code, err := referrals.GetReferralCode(context.Background(), d.referrer.ID)
+		require.NoError(t, err)
+
+		resp, err := sut.Register(context.Background(), &inputport.RegisterRequest{
+			Username: "invited", Email: "invited@example.com",
+			Password: "password123", DisplayName: "Invited",
+			FirstName: "花子", LastName: "山田",
+			ReferralCode: code.Code, IPAddress: "203.0.113.20",
+		})
+		require.NoError(t, err)
+		require.Len(t, d.referralRepo.referrals, 1)
+		assert.Equal(t, resp.User.ID, d.referralRepo.referrals[0].RefereeID)
+		assert.Equal(t, d.referrer.ID, d.referralRepo.referrals[0].ReferrerID)
+	})
+
+	t.Run("存在しない紹介コードでは登録しない", func(t *testing.T) {
+		d, _, sut := setup(t)
+
+		_, err := sut.Register(context.Background(), &inputport.RegisterRequest{
+			Username: "invited", Email: "invited@example.com",
+			Password: "password123", DisplayName: "Invited",
+			FirstName: "花子", LastName: "山田",
+			ReferralCode: "ZZZZZZZZ",
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidReferralCode)
+		assert.Empty(t, d.referralRepo.referrals)
+	})
+}
@@ -0,0 +1,355 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockScheduledJobRepo はDBの条件付き更新と同じ判定でロックを取る
+type mockScheduledJobRepo struct {
+	jobs map[entities.ScheduledJobName]*entities.ScheduledJob
+}
+
+func newMockScheduledJobRepo() *mockScheduledJobRepo {
+	return &mockScheduledJobRepo{jobs: make(map[entities.ScheduledJobName]*entities.ScheduledJob)}
+}
+
+func (m *mockScheduledJobRepo) SyncSchedule(ctx context.Context, name entities.ScheduledJobName, schedule string, nextRunAt *time.Time) error {
+	job, ok := m.jobs[name]
+	if !ok {
+		m.jobs[name] = &entities.ScheduledJob{Name: name, Schedule: schedule, NextRunAt: nextRunAt}
+		return nil
+	}
+	if job.Schedule != schedule {
+		job.Schedule = schedule
+		job.NextRunAt = nextRunAt
+	}
+	return nil
+}
+
+func (m *mockScheduledJobRepo) TryLock(ctx context.Context, name entities.ScheduledJobName, owner string, now, lockedUntil, nextRunAt time.Time) (bool, error) {
+	job, ok := m.jobs[name]
+	if !ok || job.NextRunAt == nil || job.NextRunAt.After(now) || job.IsRunning(now) {
+		return false, nil
+	}
+	job.LockedBy = owner
+	job.LockedUntil = &lockedUntil
+	job.NextRunAt = &nextRunAt
+	job.LastStartedAt = &now
+	job.LastStatus = entities.ScheduledJobStatusRunning
+	return true, nil
+}
+
+func (m *mockScheduledJobRepo) Finish(ctx context.Context, run *entities.ScheduledJobRun) error {
+	job, ok := m.jobs[run.Name]
+	if !ok || job.LockedBy != run.Owner {
+		return nil
+	}
+	job.LockedBy = ""
+	job.LockedUntil = nil
+	job.LastFinishedAt = &run.FinishedAt
+	job.LastStatus = run.Status
+	job.LastError = run.Error
+	job.LastProcessed = run.Processed
+	return nil
+}
+
+func (m *mockScheduledJobRepo) ReadList(ctx context.Context) ([]*entities.ScheduledJob, error) {
+	list := make([]*entities.ScheduledJob, 0, len(m.jobs))
+	for _, j := range m.jobs {
+		list = append(list, j)
+	}
+	sort.Slice(list, func(a, b int) bool { return list[a].Name < list[b].Name })
+	return list, nil
+}
+
+// failingSessionRepo は期限切れセッションの削除に失敗する
+type failingSessionRepo struct {
+	*mockSessionRepo
+}
+
+func (m *failingSessionRepo) DeleteExpired(ctx context.Context) error {
+	return errors.New("connection reset")
+}
+
+// stubTransactionArchiver は取引を移した件数を返すだけの実装
+type stubTransactionArchiver struct {
+	archived int64
+	calls    []time.Time
+}
+
+func (m *stubTransactionArchiver) ArchiveTransactions(ctx context.Context, now time.Time) (int64, error) {
+	m.calls = append(m.calls, now)
+	return m.archived, nil
+}
+
+// stubBalanceHoldReleaser は戻した件数を返すだけの実装
+type stubBalanceHoldReleaser struct {
+	released int64
+	calls    int
+}
+
+func (m *stubBalanceHoldReleaser) ReleaseClosedHolds(ctx context.Context) (int64, error) {
+	m.calls++
+	return m.released, nil
+}
+
+// stubWeeklyDigestSender は送った件数を返すだけの実装
+type stubWeeklyDigestSender struct {
+	sent  int64
+	calls []time.Time
+}
+
+func (m *stubWeeklyDigestSender) SendWeeklyDigests(ctx context.Context, now time.Time) (int64, error) {
+	m.calls = append(m.calls, now)
+	return m.sent, nil
+}
+
+// stubTransferAttachmentCleaner は削除した件数を返すだけの実装
+type stubTransferAttachmentCleaner struct {
+	deleted int64
+	calls   []time.Time
+}
+
+func (m *stubTransferAttachmentCleaner) CleanupAttachments(ctx context.Context, now time.Time) (int64, error) {
+	m.calls = append(m.calls, now)
+	return m.deleted, nil
+}
+
+func TestScheduledJobInteractor_RunDueJobs(t *testing.T) {
+	ctx := context.Background()
+	// 2026-10-16 12:00 JST
+	start := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+
+	newSUT := func(jobRepo *mockScheduledJobRepo, settings *mockSystemSettingsRepo, schedules entities.JobSchedules) *interactor.ScheduledJobInteractor {
+		return interactor.NewScheduledJobInteractor(
+			jobRepo, settings, newCtxTrackingIdempotencyRepo(), newMockSessionRepo(), newMockTransferRequestRepo(),
+			newCtxTrackingUserRepo(), testsupport.NewProcessedAkerunAccessRepository(), &stubBalanceHoldReleaser{}, &stubTransactionArchiver{}, &stubWeeklyDigestSender{}, &stubTransferAttachmentCleaner{}, schedules, &mockLogger{},
+		).(*interactor.ScheduledJobInteractor)
+	}
+
+	t.Run("初回は次回実行日時を登録するだけで実行しない", func(t *testing.T) {
+		jobRepo := newMockScheduledJobRepo()
+		sut := newSUT(jobRepo, newMockSystemSettingsRepo(), nil)
+
+		assert.Equal(t, 0, sut.RunDueJobs(ctx, "host-a:1", start))
+		require.Len(t, jobRepo.jobs, 7)
+
+		job := jobRepo.jobs[entities.ScheduledJobTransferRequestExpiry]
+		assert.Equal(t, "*/10 * * * *", job.Schedule)
+		assert.True(t, start.Add(10*time.Minute).Equal(*job.NextRunAt))
+	})
+
+	t.Run("実行時刻を過ぎたジョブは複数インスタンスでも1回だけ実行される", func(t *testing.T) {
+		jobRepo := newMockScheduledJobRepo()
+		a := newSUT(jobRepo, newMockSystemSettingsRepo(), nil)
+		b := newSUT(jobRepo, newMockSystemSettingsRepo(), nil)
+		a.RunDueJobs(ctx, "host-a:1", start)
+
+		due := start.Add(10 * time.Minute)
+		assert.Equal(t, 1, a.RunDueJobs(ctx, "host-a:1", due))
+		assert.Equal(t, 0, b.RunDueJobs(ctx, "host-b:1", due), "同じ回は他のインスタンスが実行済み")
+
+		job := jobRepo.jobs[entities.ScheduledJobTransferRequestExpiry]
+		assert.Equal(t, entities.ScheduledJobStatusSucceeded, job.LastStatus)
+		require.NotNil(t, job.LastProcessed)
+		assert.Equal(t, int64(0), *job.LastProcessed)
+		assert.Empty(t, job.LockedBy, "終了後はロックを解放する")
+		assert.True(t, start.Add(20*time.Minute).Equal(*job.NextRunAt))
+	})
+
+	t.Run("実行中のジョブは他のインスタンスが取らない", func(t *testing.T) {
+		jobRepo := newMockScheduledJobRepo()
+		sut := newSUT(jobRepo, newMockSystemSettingsRepo(), nil)
+		sut.RunDueJobs(ctx, "host-a:1", start)
+
+		due := start.Add(10 * time.Minute)
+		locked, err := jobRepo.TryLock(ctx, entities.ScheduledJobTransferRequestExpiry, "host-a:1", due, due.Add(entities.ScheduledJobLockTimeout), due)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		assert.Equal(t, 0, sut.RunDueJobs(ctx, "host-b:1", due.Add(time.Minute)))
+
+		// ロックの期限が切れたら引き継げる
+		takeover := due.Add(entities.ScheduledJobLockTimeout)
+		sut.RunDueJobs(ctx, "host-b:1", takeover)
+		job := jobRepo.jobs[entities.ScheduledJobTransferRequestExpiry]
+		assert.True(t, takeover.Equal(*job.LastStartedAt))
+		assert.Equal(t, entities.ScheduledJobStatusSucceeded, job.LastStatus)
+	})
+
+	t.Run("system_settingsのcron式が設定ファイルより優先され、offで停止する", func(t *testing.T) {
+		jobRepo := newMockScheduledJobRepo()
+		settings := newMockSystemSettingsRepo()
+		settings.settings[entities.JobScheduleSettingKey(entities.ScheduledJobSessionPurge)] = "0 4 * * *"
+		settings.settings[entities.JobScheduleSettingKey(entities.ScheduledJobTransferRequestExpiry)] = "off"
+		sut := newSUT(jobRepo, settings, entities.JobSchedules{
+			entities.ScheduledJobSessionPurge:          "0 5 * * *",
+			entities.ScheduledJobIdempotencyKeyCleanup: "0 * * * *",
+		})
+		sut.RunDueJobs(ctx, "host-a:1", start)
+
+		assert.Equal(t, "0 4 * * *", jobRepo.jobs[entities.ScheduledJobSessionPurge].Schedule)
+		assert.Equal(t, "0 * * * *", jobRepo.jobs[entities.ScheduledJobIdempotencyKeyCleanup].Schedule)
+
+		stopped := jobRepo.jobs[entities.ScheduledJobTransferRequestExpiry]
+		assert.Equal(t, entities.ScheduledJobDisabled, stopped.Schedule)
+		assert.Nil(t, stopped.NextRunAt)
+		assert.Equal(t, 1, sut.RunDueJobs(ctx, "host-a:1", start.Add(time.Hour)), "停止したジョブは実行しない")
+	})
+
+	t.Run("不正なcron式は無視して次の候補を使う", func(t *testing.T) {
+		jobRepo := newMockScheduledJobRepo()
+		settings := newMockSystemSettingsRepo()
+		settings.settings[entities.JobScheduleSettingKey(entities.ScheduledJobSessionPurge)] = "every day"
+		sut := newSUT(jobRepo, settings, entities.JobSchedules{
+			entities.ScheduledJobSessionPurge: "0 0 30 2 *",
+		})
+		sut.RunDueJobs(ctx, "host-a:1", start)
+
+		assert.Equal(t, "30 3 * * *", jobRepo.jobs[entities.ScheduledJobSessionPurge].Schedule)
+	})
+
+	t.Run("失敗したジョブは結果を記録して次の回に再実行する", func(t *testing.T) {
+		jobRepo := newMockScheduledJobRepo()
+		sut := interactor.NewScheduledJobInteractor(
+			jobRepo, newMockSystemSettingsRepo(), newCtxTrackingIdempotencyRepo(), &failingSessionRepo{newMockSessionRepo()},
+			newMockTransferRequestRepo(), newCtxTrackingUserRepo(), testsupport.NewProcessedAkerunAccessRepository(), &stubBalanceHoldReleaser{}, &stubTransactionArchiver{}, &stubWeeklyDigestSender{}, &stubTransferAttachmentCleaner{}, nil, &mockLogger{},
+		)
+		sut.RunDueJobs(ctx, "host-a:1", start)
+
+		// 翌日3:30 JSTにはセッション削除と毎時・10分間隔のジョブが実行される
+		due := time.Date(2026, 10, 17, 3, 30, 0, 0, time.FixedZone("JST", 9*60*60))
+		assert.Equal(t, 3, sut.RunDueJobs(ctx, "host-a:1", due))
+
+		job := jobRepo.jobs[entities.ScheduledJobSessionPurge]
+		assert.Equal(t, entities.ScheduledJobStatusFailed, job.LastStatus)
+		assert.Equal(t, "connection reset", job.LastError)
+		assert.Nil(t, job.LastProcessed)
+		assert.Empty(t, job.LockedBy)
+		assert.True(t, due.AddDate(0, 0, 1).Equal(*job.NextRunAt))
+	})
+
+	t.Run("取引の保管は移した件数を記録する", func(t *testing.T) {
+		jobRepo := newMockScheduledJobRepo()
+		archiver := &stubTransactionArchiver{archived: 120}
+		sut := interactor.NewScheduledJobInteractor(
+			jobRepo, newMockSystemSettingsRepo(), newCtxTrackingIdempotencyRepo(), newMockSessionRepo(),
+			newMockTransferRequestRepo(), newCtxTrackingUserRepo(), testsupport.NewProcessedAkerunAccessRepository(), &stubBalanceHoldReleaser{}, archiver, &stubWeeklyDigestSender{}, &stubTransferAttachmentCleaner{}, nil, &mockLogger{},
+		)
+		sut.RunDueJobs(ctx, "host-a:1", start)
+
+		// 翌日4:00 JST
+		due := time.Date(2026, 10, 17, 4, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+		sut.RunDueJobs(ctx, "host-a:1", due)
+
+		require.Len(t, archiver.calls, 1)
+		assert.True(t, due.Equal(archiver.calls[0]))
+		job := jobRepo.jobs[entities.ScheduledJobTransactionArchive]
+		assert.Equal(t, entities.ScheduledJobStatusSucceeded, job.LastStatus)
+		require.NotNil(t, job.LastProcessed)
+		assert.Equal(t, int64(120), *job.LastProcessed)
+	})
+
+	t.Run("週次のまとめは毎週月曜に送った件数を記録する", func(t *testing.T) {
+		jobRepo := newMockScheduledJobRepo()
+		digests := &stubWeeklyDigestSender{sent: 42}
+		sut := interactor.NewScheduledJobInteractor(
+			jobRepo, newMockSystemSettingsRepo(), newCtxTrackingIdempotencyRepo(), newMockSessionRepo(),
+			newMockTransferRequestRepo(), newCtxTrackingUserRepo(), testsupport.NewProcessedAkerunAccessRepository(), &stubBalanceHoldReleaser{}, &stubTransactionArchiver{}, digests, &stubTransferAttachmentCleaner{}, nil, &mockLogger{},
+		)
+		sut.RunDueJobs(ctx, "host-a:1", start)
+
+		// 翌日（土曜）には送らず、月曜9:00 JSTに送る
+		jst := time.FixedZone("JST", 9*60*60)
+		sut.RunDueJobs(ctx, "host-a:1", time.Date(2026, 10, 17, 9, 0, 0, 0, jst))
+		assert.Empty(t, digests.calls)
+		due := time.Date(2026, 10, 19, 9, 0, 0, 0, jst)
+		sut.RunDueJobs(ctx, "host-a:1", due)
+
+		require.Len(t, digests.calls, 1)
+		assert.True(t, due.Equal(digests.calls[0]))
+		job := jobRepo.jobs[entities.ScheduledJobWeeklyDigest]
+		require.NotNil(t, job.LastProcessed)
+		assert.Equal(t, int64(42), *job.LastProcessed)
+		assert.True(t, due.AddDate(0, 0, 7).Equal(*job.NextRunAt))
+	})
+
+	t.Run("添付画像の削除は削除した件数を記録する", func(t *testing.T) {
+		jobRepo := newMockScheduledJobRepo()
+		attachments := &stubTransferAttachmentCleaner{deleted: 8}
+		sut := interactor.NewScheduledJobInteractor(
+			jobRepo, newMockSystemSettingsRepo(), newCtxTrackingIdempotencyRepo(), newMockSessionRepo(),
+			newMockTransferRequestRepo(), newCtxTrackingUserRepo(), testsupport.NewProcessedAkerunAccessRepository(), &stubBalanceHoldReleaser{}, &stubTransactionArchiver{}, &stubWeeklyDigestSender{}, attachments, nil, &mockLogger{},
+		)
+		sut.RunDueJobs(ctx, "host-a:1", start)
+
+		// 翌日3:50 JST
+		due := time.Date(2026, 10, 17, 3, 50, 0, 0, time.FixedZone("JST", 9*60*60))
+		sut.RunDueJobs(ctx, "host-a:1", due)
+
+		require.Len(t, attachments.calls, 1)
+		assert.True(t, due.Equal(attachments.calls[0]))
+		job := jobRepo.jobs[entities.ScheduledJobTransferAttachmentCleanup]
+		require.NotNil(t, job.LastProcessed)
+		assert.Equal(t, int64(8), *job.LastProcessed)
+	})
+
+	t.Run("処理済みのAkerunアクセス記録IDは保持期間を過ぎたものだけ削除する", func(t *testing.T) {
+		jobRepo := newMockScheduledJobRepo()
+		processed := testsupport.NewProcessedAkerunAccessRepository()
+		// 翌日3:45 JST
+		due := time.Date(2026, 10, 17, 3, 45, 0, 0, time.FixedZone("JST", 9*60*60))
+		expired := entities.NewProcessedAkerunAccess(entities.AccessRecord{ID: uuid.New()}, due.Add(-entities.ProcessedAkerunAccessRetention-time.Minute))
+		kept := entities.NewProcessedAkerunAccess(entities.AccessRecord{ID: uuid.New()}, due.Add(-time.Hour))
+		require.NoError(t, processed.Create(ctx, []*entities.ProcessedAkerunAccess{expired, kept}))
+
+		sut := interactor.NewScheduledJobInteractor(
+			jobRepo, newMockSystemSettingsRepo(), newCtxTrackingIdempotencyRepo(), newMockSessionRepo(),
+			newMockTransferRequestRepo(), newCtxTrackingUserRepo(), processed, &stubBalanceHoldReleaser{}, &stubTransactionArchiver{}, &stubWeeklyDigestSender{}, &stubTransferAttachmentCleaner{}, nil, &mockLogger{},
+		)
+		sut.RunDueJobs(ctx, "host-a:1", start)
+		sut.RunDueJobs(ctx, "host-a:1", due)
+
+		remaining := processed.Processed()
+		require.Len(t, remaining, 1)
+		assert.Equal(t, kept.AccessID, remaining[0].AccessID)
+		job := jobRepo.jobs[entities.ScheduledJobProcessedAkerunAccessCleanup]
+		require.NotNil(t, job.LastProcessed)
+		assert.Equal(t, int64(1), *job.LastProcessed)
+	})
+}
+
+func TestScheduledJobInteractor_ListJobs(t *testing.T) {
+	ctx := context.Background()
+	userRepo := newCtxTrackingUserRepo()
+	admin := &entities.User{ID: uuid.New(), Username: "admin", Role: "admin"}
+	member := &entities.User{ID: uuid.New(), Username: "member", Role: "user"}
+	userRepo.setUser(admin)
+	userRepo.setUser(member)
+
+	jobRepo := newMockScheduledJobRepo()
+	sut := interactor.NewScheduledJobInteractor(
+		jobRepo, newMockSystemSettingsRepo(), newCtxTrackingIdempotencyRepo(), newMockSessionRepo(),
+		newMockTransferRequestRepo(), userRepo, testsupport.NewProcessedAkerunAccessRepository(), &stubBalanceHoldReleaser{}, &stubTransactionArchiver{}, &stubWeeklyDigestSender{}, &stubTransferAttachmentCleaner{}, nil, &mockLogger{},
+	)
+	sut.RunDueJobs(ctx, "host-a:1", time.Now())
+
+	jobs, err := sut.ListJobs(ctx, admin.ID)
+	require.NoError(t, err)
+	require.Len(t, jobs, 7)
+	assert.Equal(t, entities.ScheduledJobIdempotencyKeyCleanup, jobs[0].Name)
+
+	_, err = sut.ListJobs(ctx, member.ID)
+	assert.ErrorIs(t, err, entities.ErrAdminRequired)
+}
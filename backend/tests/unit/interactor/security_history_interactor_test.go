@@ -0,0 +1,163 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSecurityHistoryInteractor() (*ctxTrackingUserRepo, *mockUsernameChangeHistoryRepo, *mockPasswordChangeHistoryRepo, inputport.SecurityHistoryInputPort) {
+	userRepo := newCtxTrackingUserRepo()
+	usernameRepo := &mockUsernameChangeHistoryRepo{}
+	passwordRepo := &mockPasswordChangeHistoryRepo{}
+	sut := interactor.NewSecurityHistoryInteractor(userRepo, usernameRepo, passwordRepo, newMockLoginAttemptRepo(), newMockSessionRepo(), &mockLogger{})
+	return userRepo, usernameRepo, passwordRepo, sut
+}
+
+// seedSecurityHistory はユーザー名変更2件・パスワード変更2件を交互の時刻で登録する
+func seedSecurityHistory(userID uuid.UUID, usernameRepo *mockUsernameChangeHistoryRepo, passwordRepo *mockPasswordChangeHistoryRepo) {
+	ip := "198.51.100.7"
+	ua := "Mozilla/5.0"
+	base := time.Now().Add(-4 * time.Hour)
+	for i := 0; i < 2; i++ {
+		u := entities.NewUsernameChangeHistory(userID, "old", "new", &userID, &ip)
+		u.ChangedAt = base.Add(time.Duration(2*i) * time.Hour)
+		_ = usernameRepo.Create(context.Background(), u)
+
+		p := entities.NewPasswordChangeHistory(userID, &ip, &ua)
+		p.ChangedAt = base.Add(time.Duration(2*i+1) * time.Hour)
+		_ = passwordRepo.Create(context.Background(), p)
+	}
+}
+
+func TestSecurityHistoryInteractor_GetOwnHistory(t *testing.T) {
+	t.Run("両方の履歴を新しい順に結合し、IPアドレスを伏せる", func(t *testing.T) {
+		_, usernameRepo, passwordRepo, sut := setupSecurityHistoryInteractor()
+		userID := uuid.New()
+		seedSecurityHistory(userID, usernameRepo, passwordRepo)
+
+		resp, err := sut.GetOwnHistory(context.Background(), &inputport.GetSecurityHistoryRequest{UserID: userID})
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), resp.Total)
+		require.Len(t, resp.Entries, 4)
+		assert.Equal(t, entities.SecurityHistoryPasswordChange, resp.Entries[0].Type)
+		assert.Equal(t, entities.SecurityHistoryUsernameChange, resp.Entries[1].Type)
+		for _, e := range resp.Entries {
+			assert.Equal(t, "198.51.100.***", *e.IPAddress)
+		}
+	})
+
+	t.Run("結合後の時系列でページングする", func(t *testing.T) {
+		_, usernameRepo, passwordRepo, sut := setupSecurityHistoryInteractor()
+		userID := uuid.New()
+		seedSecurityHistory(userID, usernameRepo, passwordRepo)
+
+		resp, err := sut.GetOwnHistory(context.Background(), &inputport.GetSecurityHistoryRequest{
+			UserID: userID, Offset: 1, Limit: 2,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Entries, 2)
+		assert.Equal(t, entities.SecurityHistoryUsernameChange, resp.Entries[0].Type)
+		assert.Equal(t, entities.SecurityHistoryPasswordChange, resp.Entries[1].Type)
+		assert.True(t, resp.Entries[0].ChangedAt.After(resp.Entries[1].ChangedAt))
+	})
+}
+
+func TestSecurityHistoryInteractor_GetUserHistory(t *testing.T) {
+	t.Run("管理者はIPアドレスをそのまま閲覧できる", func(t *testing.T) {
+		userRepo, usernameRepo, passwordRepo, sut := setupSecurityHistoryInteractor()
+		admin := createTestUserWithBalance(t, "admin", 0, "admin")
+		user := createTestUserWithBalance(t, "target", 0, "user")
+		userRepo.setUser(admin)
+		userRepo.setUser(user)
+		seedSecurityHistory(user.ID, usernameRepo, passwordRepo)
+
+		resp, err := sut.GetUserHistory(context.Background(), &inputport.GetUserSecurityHistoryRequest{
+			AdminID: admin.ID, UserID: user.ID,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Entries, 4)
+		assert.Equal(t, "198.51.100.7", *resp.Entries[0].IPAddress)
+	})
+
+	t.Run("一般ユーザーは閲覧できない", func(t *testing.T) {
+		userRepo, _, _, sut := setupSecurityHistoryInteractor()
+		user := createTestUserWithBalance(t, "normal", 0, "user")
+		userRepo.setUser(user)
+
+		_, err := sut.GetUserHistory(context.Background(), &inputport.GetUserSecurityHistoryRequest{
+			AdminID: user.ID, UserID: user.ID,
+		})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
+
+func TestSecurityHistoryInteractor_GetOwnLogins(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*testsupport.Repositories, inputport.AuthInputPort, inputport.SecurityHistoryInputPort, *entities.User) {
+		repos := testsupport.New()
+		user := createTestUserWithBalance(t, "me", 0, entities.RoleUser)
+		repos.Users.Seed(user)
+		auth := interactor.NewAuthInteractor(repos.Users, repos.Sessions, repos.LoginAttempts, &mockPasswordService{verifyOK: true}, &mockEmailService{}, &mockNotificationDispatcher{}, &mockReferralTracker{}, &mockOnboardingBonusGranter{}, &mockEmailVerificationGate{}, &mockLogger{})
+		sut := interactor.NewSecurityHistoryInteractor(repos.Users, repos.UsernameChangeHistory, repos.PasswordChangeHistory, repos.LoginAttempts, repos.Sessions, &mockLogger{})
+		return repos, auth, sut, user
+	}
+	login := func(t *testing.T, auth inputport.AuthInputPort, user *entities.User, userAgent string) {
+		t.Helper()
+		_, err := auth.Login(ctx, &inputport.LoginRequest{Username: user.Username, Password: "password123", IPAddress: "198.51.100.7", UserAgent: userAgent})
+		require.NoError(t, err)
+	}
+
+	t.Run("新しい端末からのログインを強調し、IPアドレスを伏せる", func(t *testing.T) {
+		_, auth, sut, user := setup(t)
+		login(t, auth, user, "Laptop")
+		login(t, auth, user, "Unknown Phone")
+
+		resp, err := sut.GetOwnLogins(ctx, &inputport.GetSecurityHistoryRequest{UserID: user.ID})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), resp.Total)
+		require.Len(t, resp.Entries, 2)
+		assert.Equal(t, "Unknown Phone", resp.Entries[0].UserAgent)
+		assert.True(t, resp.Entries[0].NewDevice)
+		assert.Equal(t, entities.LoginAnomalyNewDevice, resp.Entries[0].Anomaly)
+		assert.Equal(t, entities.LoginAnomalyNone, resp.Entries[1].Anomaly, "初回ログインは新しい端末として扱わない")
+		for _, e := range resp.Entries {
+			assert.Equal(t, "198.51.100.***", e.IPAddress)
+		}
+	})
+
+	t.Run("本人の試行だけを新しい順にページングする", func(t *testing.T) {
+		repos, _, sut, user := setup(t)
+		other := uuid.New()
+		base := time.Now().Add(-time.Hour)
+		for i := 0; i < 3; i++ {
+			a := entities.NewLoginAttempt(&user.ID, user.Username, "198.51.100.7", "Laptop", "", i%2 == 0, "")
+			a.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+			require.NoError(t, repos.LoginAttempts.Create(ctx, a))
+		}
+		require.NoError(t, repos.LoginAttempts.Create(ctx, entities.NewLoginAttempt(&other, "other", "198.51.100.8", "Laptop", "", true, "")))
+
+		resp, err := sut.GetOwnLogins(ctx, &inputport.GetSecurityHistoryRequest{UserID: user.ID, Offset: 1, Limit: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), resp.Total)
+		require.Len(t, resp.Entries, 1)
+		assert.Equal(t, base.Add(time.Minute), resp.Entries[0].CreatedAt)
+	})
+
+	t.Run("履歴を読めなければエラー", func(t *testing.T) {
+		repos, _, sut, user := setup(t)
+		repos.LoginAttempts.FailOn("ReadListByUserID", assert.AnError)
+
+		_, err := sut.GetOwnLogins(ctx, &inputport.GetSecurityHistoryRequest{UserID: user.ID})
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,128 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionBindingInteractor_VerifyBinding(t *testing.T) {
+	ctx := context.Background()
+	const chrome = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) Chrome/120.0"
+	const curl = "curl/8.4.0"
+
+	setup := func(t *testing.T, mode entities.SessionBindingMode) (*testsupport.Repositories, inputport.SessionBindingInputPort, *entities.Session) {
+		t.Helper()
+		repos := testsupport.New()
+		policy := entities.SessionBindingPolicy{Mode: mode, IPv4PrefixLen: 24, IPv6PrefixLen: 64}
+		sut := interactor.NewSessionBindingInteractor(repos.Sessions, policy, &mockLogger{})
+
+		session, err := entities.NewSession(uuid.New(), "203.0.113.10", chrome)
+		require.NoError(t, err)
+		require.NoError(t, repos.Sessions.Create(ctx, session))
+		return repos, sut, session
+	}
+	verify := func(sut inputport.SessionBindingInputPort, repos *testsupport.Repositories, session *entities.Session, ip, ua string) error {
+		stored, err := repos.Sessions.Read(ctx, session.ID)
+		if err != nil {
+			return err
+		}
+		return sut.VerifyBinding(ctx, &inputport.VerifySessionBindingRequest{Session: stored, IPAddress: ip, UserAgent: ua})
+	}
+	violations := func(t *testing.T, repos *testsupport.Repositories, session *entities.Session) []*entities.SessionBindingViolation {
+		t.Helper()
+		list, err := repos.Sessions.ReadBindingViolationsByUserID(ctx, session.UserID, 0, 10)
+		require.NoError(t, err)
+		return list
+	}
+
+	t.Run("未固定のセッションはログインしたときの接続元に固定する", func(t *testing.T) {
+		repos, sut, session := setup(t, entities.SessionBindingStrict)
+
+		require.NoError(t, verify(sut, repos, session, "203.0.113.99", chrome), "同じサブネット内の変化は許す")
+
+		stored, err := repos.Sessions.Read(ctx, session.ID)
+		require.NoError(t, err)
+		assert.NotEmpty(t, stored.Fingerprint)
+		assert.Empty(t, violations(t, repos, session))
+	})
+
+	t.Run("lenientでは片方の変化を記録して固定し直し、両方の変化で失効させる", func(t *testing.T) {
+		repos, sut, session := setup(t, entities.SessionBindingLenient)
+
+		require.NoError(t, verify(sut, repos, session, "198.51.100.7", chrome))
+		require.NoError(t, verify(sut, repos, session, "198.51.100.8", chrome), "固定し直した接続元からは記録しない")
+		list := violations(t, repos, session)
+		require.Len(t, list, 1)
+		assert.Equal(t, entities.SessionFingerprintChangedSubnet, list[0].Change)
+		assert.False(t, list[0].Rejected)
+
+		err := verify(sut, repos, session, "192.0.2.1", curl)
+		assert.ErrorIs(t, err, entities.ErrSessionReauthRequired)
+		_, err = repos.Sessions.Read(ctx, session.ID)
+		assert.Error(t, err, "セッションを失効させる")
+
+		list = violations(t, repos, session)
+		require.Len(t, list, 2)
+		assert.Equal(t, entities.SessionFingerprintChangedBoth, list[0].Change)
+		assert.True(t, list[0].Rejected)
+		assert.Equal(t, "192.0.2.1", list[0].IPAddress)
+	})
+
+	t.Run("strictではUser-Agentだけの変化でも再ログインを求める", func(t *testing.T) {
+		repos, sut, session := setup(t, entities.SessionBindingStrict)
+
+		err := verify(sut, repos, session, "203.0.113.10", curl)
+		assert.ErrorIs(t, err, entities.ErrSessionReauthRequired)
+	})
+
+	t.Run("monitorでは記録するだけでセッションは使える", func(t *testing.T) {
+		repos, sut, session := setup(t, entities.SessionBindingMonitor)
+
+		require.NoError(t, verify(sut, repos, session, "192.0.2.1", curl))
+		list := violations(t, repos, session)
+		require.Len(t, list, 1)
+		assert.False(t, list[0].Rejected)
+	})
+
+	t.Run("offでは固定も記録もしない", func(t *testing.T) {
+		repos, sut, session := setup(t, entities.SessionBindingOff)
+
+		require.NoError(t, verify(sut, repos, session, "192.0.2.1", curl))
+		stored, err := repos.Sessions.Read(ctx, session.ID)
+		require.NoError(t, err)
+		assert.Empty(t, stored.Fingerprint)
+		assert.Empty(t, violations(t, repos, session))
+	})
+
+	t.Run("記録に失敗しても判定は変わらない", func(t *testing.T) {
+		repos, sut, session := setup(t, entities.SessionBindingStrict)
+		repos.Sessions.FailOn("CreateBindingViolation", assert.AnError)
+
+		err := verify(sut, repos, session, "192.0.2.1", curl)
+		assert.ErrorIs(t, err, entities.ErrSessionReauthRequired)
+	})
+
+	t.Run("違反はセキュリティ履歴に載る", func(t *testing.T) {
+		repos, sut, session := setup(t, entities.SessionBindingLenient)
+		require.Error(t, verify(sut, repos, session, "192.0.2.1", curl))
+
+		history := interactor.NewSecurityHistoryInteractor(repos.Users, repos.UsernameChangeHistory, repos.PasswordChangeHistory, repos.LoginAttempts, repos.Sessions, &mockLogger{})
+		resp, err := history.GetOwnHistory(ctx, &inputport.GetSecurityHistoryRequest{UserID: session.UserID})
+		require.NoError(t, err)
+		require.Len(t, resp.Entries, 1)
+		assert.Equal(t, int64(1), resp.Total)
+		entry := resp.Entries[0]
+		assert.Equal(t, entities.SecurityHistorySessionBindingViolation, entry.Type)
+		assert.Equal(t, entities.SessionFingerprintChangedBoth, entry.BindingChange)
+		assert.True(t, entry.SessionRevoked)
+		assert.Equal(t, "192.0.2.***", *entry.IPAddress)
+	})
+}
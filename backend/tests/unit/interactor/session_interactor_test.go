@@ -0,0 +1,213 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func addTestSession(t *testing.T, repo *mockSessionRepo, userID uuid.UUID, userAgent string) *entities.Session {
+	t.Helper()
+	s, err := entities.NewSession(userID, "192.0.2.1", userAgent)
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(context.Background(), s))
+	return s
+}
+
+func TestSessionInteractor_ListSessions(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockSessionRepo()
+	uc := interactor.NewSessionInteractor(repo, newMockUserRepo(), &mockLogger{})
+
+	userID := uuid.New()
+	current := addTestSession(t, repo, userID, "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_0) Chrome/120.0 Safari/537.36")
+	addTestSession(t, repo, userID, "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0) Safari/604.1")
+	expired := addTestSession(t, repo, userID, "old")
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	addTestSession(t, repo, uuid.New(), "someone else")
+
+	resp, err := uc.ListSessions(ctx, &inputport.ListSessionsRequest{
+		UserID:           userID,
+		CurrentSessionID: current.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Sessions, 2)
+
+	var currentCount int
+	for _, info := range resp.Sessions {
+		assert.Equal(t, userID, info.Session.UserID)
+		if info.IsCurrent {
+			currentCount++
+			assert.Equal(t, "Chrome on macOS", info.Session.DeviceName())
+		}
+	}
+	assert.Equal(t, 1, currentCount)
+}
+
+func TestSessionInteractor_RevokeSession(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("他のセッションを失効", func(t *testing.T) {
+		repo := newMockSessionRepo()
+		uc := interactor.NewSessionInteractor(repo, newMockUserRepo(), &mockLogger{})
+		userID := uuid.New()
+		current := addTestSession(t, repo, userID, "a")
+		other := addTestSession(t, repo, userID, "b")
+
+		resp, err := uc.RevokeSession(ctx, &inputport.RevokeSessionRequest{
+			UserID:           userID,
+			CurrentSessionID: current.ID,
+			SessionID:        other.ID,
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.RevokedCurrent)
+		_, err = repo.ReadByToken(ctx, other.SessionToken)
+		assert.Error(t, err)
+		_, err = repo.ReadByToken(ctx, current.SessionToken)
+		assert.NoError(t, err)
+	})
+
+	t.Run("現在のセッションを失効", func(t *testing.T) {
+		repo := newMockSessionRepo()
+		uc := interactor.NewSessionInteractor(repo, newMockUserRepo(), &mockLogger{})
+		userID := uuid.New()
+		current := addTestSession(t, repo, userID, "a")
+
+		resp, err := uc.RevokeSession(ctx, &inputport.RevokeSessionRequest{
+			UserID:           userID,
+			CurrentSessionID: current.ID,
+			SessionID:        current.ID,
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.RevokedCurrent)
+	})
+
+	t.Run("他ユーザーのセッションは失効できない", func(t *testing.T) {
+		repo := newMockSessionRepo()
+		uc := interactor.NewSessionInteractor(repo, newMockUserRepo(), &mockLogger{})
+		userID := uuid.New()
+		current := addTestSession(t, repo, userID, "a")
+		victim := addTestSession(t, repo, uuid.New(), "b")
+
+		_, err := uc.RevokeSession(ctx, &inputport.RevokeSessionRequest{
+			UserID:           userID,
+			CurrentSessionID: current.ID,
+			SessionID:        victim.ID,
+		})
+		assert.EqualError(t, err, "session not found")
+		_, err = repo.ReadByToken(ctx, victim.SessionToken)
+		assert.NoError(t, err)
+	})
+}
+
+func TestSessionInteractor_RevokeOtherSessions(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockSessionRepo()
+	uc := interactor.NewSessionInteractor(repo, newMockUserRepo(), &mockLogger{})
+
+	userID := uuid.New()
+	current := addTestSession(t, repo, userID, "a")
+	addTestSession(t, repo, userID, "b")
+	addTestSession(t, repo, userID, "c")
+	otherUser := addTestSession(t, repo, uuid.New(), "d")
+
+	resp, err := uc.RevokeOtherSessions(ctx, &inputport.RevokeOtherSessionsRequest{
+		UserID:           userID,
+		CurrentSessionID: current.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), resp.RevokedCount)
+
+	_, err = repo.ReadByToken(ctx, current.SessionToken)
+	assert.NoError(t, err)
+	_, err = repo.ReadByToken(ctx, otherUser.SessionToken)
+	assert.NoError(t, err)
+}
+
+func TestSessionInteractor_ForceLogout(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("指定ユーザーの全セッションを失効し、次のリクエストから使えなくする", func(t *testing.T) {
+		repo := newMockSessionRepo()
+		users := newMockUserRepo()
+		target := createTestUserWithBalance(t, "target", 0, entities.RoleUser)
+		users.users[target.ID] = target
+		uc := interactor.NewSessionInteractor(repo, users, &mockLogger{})
+		auth := interactor.NewAuthInteractor(users, repo, newMockLoginAttemptRepo(), &mockPasswordService{}, &mockEmailService{}, &mockNotificationDispatcher{}, &mockReferralTracker{}, &mockOnboardingBonusGranter{}, &mockEmailVerificationGate{}, &mockLogger{})
+
+		stolen := addTestSession(t, repo, target.ID, "a")
+		addTestSession(t, repo, target.ID, "b")
+		admin := addTestSession(t, repo, uuid.New(), "admin")
+
+		_, err := auth.ValidateSession(ctx, stolen.SessionToken)
+		require.NoError(t, err)
+
+		resp, err := uc.ForceLogoutUser(ctx, &inputport.ForceLogoutUserRequest{AdminID: admin.UserID, CurrentSessionID: admin.ID, UserID: target.ID})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), resp.RevokedCount)
+
+		_, err = auth.ValidateSession(ctx, stolen.SessionToken)
+		assert.Error(t, err)
+		_, err = repo.Read(ctx, admin.ID)
+		assert.NoError(t, err, "他のユーザーのセッションは残す")
+	})
+
+	t.Run("存在しないユーザーはErrUserNotFound", func(t *testing.T) {
+		uc := interactor.NewSessionInteractor(newMockSessionRepo(), newMockUserRepo(), &mockLogger{})
+
+		_, err := uc.ForceLogoutUser(ctx, &inputport.ForceLogoutUserRequest{AdminID: uuid.New(), UserID: uuid.New()})
+		assert.ErrorIs(t, err, entities.ErrUserNotFound)
+	})
+
+	t.Run("全セッションの失効では操作中の管理者のセッションだけ残す", func(t *testing.T) {
+		repo := newMockSessionRepo()
+		uc := interactor.NewSessionInteractor(repo, newMockUserRepo(), &mockLogger{})
+		admin := addTestSession(t, repo, uuid.New(), "admin")
+		addTestSession(t, repo, admin.UserID, "admin on another device")
+		addTestSession(t, repo, uuid.New(), "a")
+		addTestSession(t, repo, uuid.New(), "b")
+
+		resp, err := uc.PurgeSessions(ctx, &inputport.PurgeSessionsRequest{AdminID: admin.UserID, CurrentSessionID: admin.ID})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), resp.RevokedCount)
+		require.Len(t, repo.sessions, 1)
+		_, err = repo.Read(ctx, admin.ID)
+		assert.NoError(t, err)
+	})
+}
+
+func TestAuthInteractor_ValidateSession_RevokedMidRequest(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockSessionRepo()
+	userID := uuid.New()
+	s := addTestSession(t, repo, userID, "a")
+
+	// 読み込み後・更新前に失効されたケースを再現
+	racing := &revokingSessionRepo{mockSessionRepo: repo}
+	auth := interactor.NewAuthInteractor(newMockUserRepo(), racing, newMockLoginAttemptRepo(), &mockPasswordService{}, &mockEmailService{}, &mockNotificationDispatcher{}, &mockReferralTracker{}, &mockOnboardingBonusGranter{}, &mockEmailVerificationGate{}, &mockLogger{})
+
+	_, err := auth.ValidateSession(ctx, s.SessionToken)
+	assert.EqualError(t, err, "session revoked")
+}
+
+// revokingSessionRepo はReadByTokenの直後にセッションを削除する
+type revokingSessionRepo struct {
+	*mockSessionRepo
+}
+
+func (r *revokingSessionRepo) ReadByToken(ctx context.Context, token string) (*entities.Session, error) {
+	s, err := r.mockSessionRepo.ReadByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	copied := *s
+	_ = r.mockSessionRepo.Delete(ctx, s.ID)
+	return &copied, nil
+}
@@ -0,0 +1,132 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedDefaultAddress はユーザーの既定のお届け先を登録
+func seedDefaultAddress(t *testing.T, repos *testsupport.Repositories, userID uuid.UUID) *entities.ShippingAddress {
+	t.Helper()
+	address, err := entities.NewShippingAddress(userID, testAddressInput("自宅"))
+	require.NoError(t, err)
+	require.NoError(t, repos.ShippingAddresses.Create(context.Background(), address))
+	require.NoError(t, repos.ShippingAddresses.SetDefault(context.Background(), userID, address.ID))
+	address.IsDefault = true
+	return address
+}
+
+func testAddressInput(label string) entities.ShippingAddressInput {
+	return entities.ShippingAddressInput{
+		Label: label, RecipientName: "山田太郎", PostalCode: "1000001",
+		Prefecture: "東京都", City: "千代田区", Line1: "千代田1-1", Phone: "03-1234-5678",
+	}
+}
+
+func TestShippingAddressInteractor(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func() (*testsupport.Repositories, inputport.ShippingAddressInputPort) {
+		repos := testsupport.New()
+		return repos, interactor.NewShippingAddressInteractor(repos.TxManager, repos.ShippingAddresses, &mockLogger{})
+	}
+	create := func(t *testing.T, sut inputport.ShippingAddressInputPort, userID uuid.UUID, label string, isDefault bool) *entities.ShippingAddress {
+		t.Helper()
+		address, err := sut.CreateAddress(ctx, &inputport.CreateShippingAddressRequest{UserID: userID, Address: testAddressInput(label), IsDefault: isDefault})
+		require.NoError(t, err)
+		return address
+	}
+
+	t.Run("最初のお届け先は既定になり、指定すれば既定を切り替える", func(t *testing.T) {
+		_, sut := setup()
+		userID := uuid.New()
+		home := create(t, sut, userID, "自宅", false)
+		assert.True(t, home.IsDefault)
+		assert.Equal(t, "100-0001", home.PostalCode)
+		assert.Equal(t, "0312345678", home.Phone)
+
+		office := create(t, sut, userID, "会社", false)
+		assert.False(t, office.IsDefault)
+
+		_, err := sut.SetDefaultAddress(ctx, userID, office.ID)
+		require.NoError(t, err)
+		list, err := sut.ListAddresses(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, office.ID, list[0].ID, "既定のお届け先が先頭")
+		assert.False(t, list[1].IsDefault)
+
+		parents := create(t, sut, userID, "実家", true)
+		assert.True(t, parents.IsDefault)
+		list, err = sut.ListAddresses(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, parents.ID, list[0].ID)
+	})
+
+	t.Run("上限を超えるとErrShippingAddressLimit", func(t *testing.T) {
+		_, sut := setup()
+		userID := uuid.New()
+		for n := 0; n < entities.ShippingAddressMaxPerUser; n++ {
+			create(t, sut, userID, "", false)
+		}
+		_, err := sut.CreateAddress(ctx, &inputport.CreateShippingAddressRequest{UserID: userID, Address: testAddressInput("")})
+		assert.ErrorIs(t, err, entities.ErrShippingAddressLimit)
+	})
+
+	t.Run("既定のお届け先を削除すると最も古いものを既定にする", func(t *testing.T) {
+		repos, sut := setup()
+		userID := uuid.New()
+		home := create(t, sut, userID, "自宅", false)
+		office := create(t, sut, userID, "会社", false)
+		create(t, sut, userID, "実家", false)
+
+		require.NoError(t, sut.DeleteAddress(ctx, userID, home.ID))
+		address, err := repos.ShippingAddresses.ReadDefault(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, office.ID, address.ID)
+	})
+
+	t.Run("他のユーザーのお届け先はErrShippingAddressNotFound", func(t *testing.T) {
+		repos, sut := setup()
+		owner := uuid.New()
+		home := create(t, sut, owner, "自宅", false)
+		other := uuid.New()
+
+		_, err := sut.UpdateAddress(ctx, &inputport.UpdateShippingAddressRequest{UserID: other, AddressID: home.ID, Address: testAddressInput("乗っ取り")})
+		assert.ErrorIs(t, err, entities.ErrShippingAddressNotFound)
+		_, err = sut.SetDefaultAddress(ctx, other, home.ID)
+		assert.ErrorIs(t, err, entities.ErrShippingAddressNotFound)
+		assert.ErrorIs(t, sut.DeleteAddress(ctx, other, home.ID), entities.ErrShippingAddressNotFound)
+
+		stored, err := repos.ShippingAddresses.Read(ctx, home.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "自宅", stored.Label)
+	})
+
+	t.Run("変更しても既定のままで、不正な入力は保存しない", func(t *testing.T) {
+		repos, sut := setup()
+		userID := uuid.New()
+		home := create(t, sut, userID, "自宅", false)
+
+		input := testAddressInput("新居")
+		input.PostalCode = "12-34567"
+		_, err := sut.UpdateAddress(ctx, &inputport.UpdateShippingAddressRequest{UserID: userID, AddressID: home.ID, Address: input})
+		assert.ErrorIs(t, err, entities.ErrInvalidShippingAddress)
+
+		input.PostalCode = "150-0001"
+		updated, err := sut.UpdateAddress(ctx, &inputport.UpdateShippingAddressRequest{UserID: userID, AddressID: home.ID, Address: input})
+		require.NoError(t, err)
+		assert.True(t, updated.IsDefault)
+		stored, err := repos.ShippingAddresses.Read(ctx, home.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "150-0001", stored.PostalCode)
+	})
+}
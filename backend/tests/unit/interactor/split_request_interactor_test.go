@@ -0,0 +1,173 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitRequestInteractor(t *testing.T) {
+	ctx := context.Background()
+
+	type fixture struct {
+		repos         *testsupport.Repositories
+		notifications *mockNotificationDispatcher
+		sut           inputport.SplitRequestInputPort
+		requester     *entities.User
+		friends       []*entities.User
+		stranger      *entities.User
+	}
+	setup := func(t *testing.T) *fixture {
+		f := &fixture{repos: testsupport.New(), notifications: &mockNotificationDispatcher{}}
+		f.requester = createTestUserWithBalance(t, "requester", 0, entities.RoleUser)
+		f.stranger = createTestUserWithBalance(t, "stranger", 1000, entities.RoleUser)
+		f.repos.Users.Seed(f.requester, f.stranger)
+		for _, name := range []string{"alice", "bob"} {
+			friend := createTestUserWithBalance(t, name, 1000, entities.RoleUser)
+			f.repos.Users.Seed(friend)
+			friendship, err := entities.NewFriendship(f.requester.ID, friend.ID)
+			require.NoError(t, err)
+			require.NoError(t, friendship.Accept())
+			require.NoError(t, f.repos.Friendships.Create(ctx, friendship))
+			f.friends = append(f.friends, friend)
+		}
+		f.sut = interactor.NewSplitRequestInteractor(f.repos.TxManager, f.repos.SplitRequests, f.repos.TransferRequests,
+			f.repos.Users, f.repos.Friendships, &mockContentModeration{}, f.notifications, &mockLogger{})
+		return f
+	}
+	create := func(t *testing.T, f *fixture, key string) *inputport.SplitRequestDetail {
+		detail, err := f.sut.CreateSplitRequest(ctx, &inputport.CreateSplitRequestRequest{
+			RequesterID:    f.requester.ID,
+			TotalAmount:    901,
+			SplitType:      entities.SplitTypeEqual,
+			Participants:   []entities.SplitShare{{UserID: f.friends[0].ID}, {UserID: f.friends[1].ID}},
+			Message:        "dinner",
+			IdempotencyKey: key,
+		})
+		require.NoError(t, err)
+		return detail
+	}
+
+	t.Run("参加者ごとに作成者宛の送金リクエストを作り、参加者へ通知する", func(t *testing.T) {
+		f := setup(t)
+		detail := create(t, f, "split-1")
+
+		require.Len(t, detail.Shares, 2)
+		assert.Equal(t, int64(451), detail.Shares[0].TransferRequest.Amount)
+		assert.Equal(t, int64(450), detail.Shares[1].TransferRequest.Amount)
+		for i, share := range detail.Shares {
+			assert.Equal(t, f.friends[i].ID, share.TransferRequest.FromUserID)
+			assert.Equal(t, f.requester.ID, share.TransferRequest.ToUserID)
+			assert.Equal(t, f.friends[i].ID, share.User.ID)
+		}
+		assert.Equal(t, entities.SplitRequestStatusOpen, detail.Summary.Status)
+		assert.Equal(t, 1, f.repos.TxManager.Calls("Do"), "割り勘と送金リクエストは1トランザクションで作る")
+
+		require.Len(t, f.notifications.notifications, 2)
+		assert.Equal(t, f.friends[0].ID, f.notifications.notifications[0].UserID)
+		assert.Equal(t, detail.SplitRequest.ID.String(), f.notifications.notifications[0].Data["split_request_id"])
+
+		// 参加者の承認待ちに並び、作成者の送信済みには並ばない
+		pending, err := f.repos.TransferRequests.ReadPendingByToUser(ctx, f.friends[0].ID, 0, 10)
+		require.NoError(t, err)
+		assert.Len(t, pending, 1)
+		pending, err = f.repos.TransferRequests.ReadPendingByToUser(ctx, f.requester.ID, 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+		sent, err := f.repos.TransferRequests.ReadSentByFromUser(ctx, f.friends[0].ID, 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, sent)
+	})
+
+	t.Run("同じ冪等性キーでは同じ割り勘を返す", func(t *testing.T) {
+		f := setup(t)
+		first := create(t, f, "split-dup")
+		second := create(t, f, "split-dup")
+		assert.Equal(t, first.SplitRequest.ID, second.SplitRequest.ID)
+		assert.Len(t, second.Shares, 2)
+		assert.Len(t, f.notifications.notifications, 2, "再送では通知しない")
+	})
+
+	t.Run("友達でないユーザーには請求できない", func(t *testing.T) {
+		f := setup(t)
+		_, err := f.sut.CreateSplitRequest(ctx, &inputport.CreateSplitRequestRequest{
+			RequesterID:    f.requester.ID,
+			TotalAmount:    1000,
+			SplitType:      entities.SplitTypeEqual,
+			Participants:   []entities.SplitShare{{UserID: f.friends[0].ID}, {UserID: f.stranger.ID}},
+			IdempotencyKey: "split-stranger",
+		})
+		assert.ErrorIs(t, err, entities.ErrNotFriends)
+		assert.Equal(t, 0, f.repos.SplitRequests.Calls("Create"))
+	})
+
+	t.Run("送金リクエストの保存に失敗した場合はエラー", func(t *testing.T) {
+		f := setup(t)
+		f.repos.TransferRequests.FailOn("Create", errors.New("db down"))
+		_, err := f.sut.CreateSplitRequest(ctx, &inputport.CreateSplitRequestRequest{
+			RequesterID:    f.requester.ID,
+			TotalAmount:    1000,
+			SplitType:      entities.SplitTypeEqual,
+			Participants:   []entities.SplitShare{{UserID: f.friends[0].ID}},
+			IdempotencyKey: "split-fail",
+		})
+		assert.Error(t, err)
+		assert.Empty(t, f.notifications.notifications)
+	})
+
+	t.Run("参加者が支払うと支払い状況に反映される", func(t *testing.T) {
+		f := setup(t)
+		detail := create(t, f, "split-pay")
+		tr := detail.Shares[0].TransferRequest
+		require.NoError(t, tr.Approve(uuid.New()))
+		require.NoError(t, f.repos.TransferRequests.Update(ctx, tr))
+
+		got, err := f.sut.GetSplitRequest(ctx, &inputport.GetSplitRequestRequest{SplitRequestID: detail.SplitRequest.ID, UserID: f.friends[1].ID})
+		require.NoError(t, err)
+		assert.Equal(t, 1, got.Summary.PaidCount)
+		assert.Equal(t, int64(451), got.Summary.PaidAmount)
+		assert.Equal(t, int64(450), got.Summary.OutstandingAmount)
+
+		_, err = f.sut.GetSplitRequest(ctx, &inputport.GetSplitRequestRequest{SplitRequestID: detail.SplitRequest.ID, UserID: f.stranger.ID})
+		assert.ErrorIs(t, err, entities.ErrSplitRequestNotFound, "関係のないユーザーには見せない")
+
+		list, err := f.sut.ListSplitRequests(ctx, &inputport.ListSplitRequestsRequest{RequesterID: f.requester.ID, Limit: 20})
+		require.NoError(t, err)
+		require.Len(t, list.SplitRequests, 1)
+		assert.Equal(t, 1, list.SplitRequests[0].Summary.PaidCount)
+	})
+
+	t.Run("キャンセルすると支払い待ちの送金リクエストを取り消す", func(t *testing.T) {
+		f := setup(t)
+		detail := create(t, f, "split-cancel")
+		paid := detail.Shares[0].TransferRequest
+		require.NoError(t, paid.Approve(uuid.New()))
+		require.NoError(t, f.repos.TransferRequests.Update(ctx, paid))
+
+		_, err := f.sut.CancelSplitRequest(ctx, &inputport.CancelSplitRequestRequest{SplitRequestID: detail.SplitRequest.ID, UserID: f.friends[0].ID})
+		assert.ErrorIs(t, err, entities.ErrSplitRequestNotFound, "キャンセルできるのは作成者のみ")
+
+		cancelled, err := f.sut.CancelSplitRequest(ctx, &inputport.CancelSplitRequestRequest{SplitRequestID: detail.SplitRequest.ID, UserID: f.requester.ID})
+		require.NoError(t, err)
+		assert.Equal(t, entities.SplitRequestStatusCancelled, cancelled.Summary.Status)
+
+		open, err := f.repos.TransferRequests.Read(ctx, detail.Shares[1].TransferRequest.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.TransferRequestStatusCancelled, open.Status)
+		assert.Equal(t, f.requester.ID, open.LastActedBy)
+		stillPaid, err := f.repos.TransferRequests.Read(ctx, paid.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.TransferRequestStatusApproved, stillPaid.Status, "支払い済みの分はそのまま")
+
+		_, err = f.sut.CancelSplitRequest(ctx, &inputport.CancelSplitRequestRequest{SplitRequestID: detail.SplitRequest.ID, UserID: f.requester.ID})
+		assert.ErrorIs(t, err, entities.ErrSplitRequestClosed)
+	})
+}
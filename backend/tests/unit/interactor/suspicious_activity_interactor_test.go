@@ -0,0 +1,262 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTransferScreener は送金を不審と判定しない
+type mockTransferScreener struct{}
+
+func (m *mockTransferScreener) ScreenTransfer(ctx context.Context, req *inputport.TransferRequest, now time.Time) *entities.SuspiciousActivity {
+	return nil
+}
+
+func (m *mockTransferScreener) RecordSuspiciousTransfer(ctx context.Context, activity *entities.SuspiciousActivity) error {
+	return nil
+}
+
+// mockSuspiciousActivityRepo は不審な送金の記録をメモリに保持する（集計結果はvelocityを返す）
+type mockSuspiciousActivityRepo struct {
+	activities map[uuid.UUID]*entities.SuspiciousActivity
+	velocity   entities.TransferVelocity
+	recipients []uuid.UUID
+}
+
+func newMockSuspiciousActivityRepo() *mockSuspiciousActivityRepo {
+	return &mockSuspiciousActivityRepo{activities: make(map[uuid.UUID]*entities.SuspiciousActivity)}
+}
+
+func (m *mockSuspiciousActivityRepo) Create(ctx context.Context, activity *entities.SuspiciousActivity) error {
+	copied := *activity
+	m.activities[activity.ID] = &copied
+	return nil
+}
+
+func (m *mockSuspiciousActivityRepo) Read(ctx context.Context, id uuid.UUID) (*entities.SuspiciousActivity, error) {
+	a, ok := m.activities[id]
+	if !ok {
+		return nil, entities.ErrSuspiciousActivityNotFound
+	}
+	copied := *a
+	return &copied, nil
+}
+
+func (m *mockSuspiciousActivityRepo) ReadList(ctx context.Context, status entities.SuspiciousActivityStatus, offset, limit int) ([]*entities.SuspiciousActivity, error) {
+	var list []*entities.SuspiciousActivity
+	for _, a := range m.activities {
+		if status == "" || a.Status == status {
+			list = append(list, a)
+		}
+	}
+	return list, nil
+}
+
+func (m *mockSuspiciousActivityRepo) Count(ctx context.Context, status entities.SuspiciousActivityStatus) (int64, error) {
+	list, _ := m.ReadList(ctx, status, 0, 0)
+	return int64(len(list)), nil
+}
+
+func (m *mockSuspiciousActivityRepo) UpdateStatus(ctx context.Context, activity *entities.SuspiciousActivity, from entities.SuspiciousActivityStatus) (bool, error) {
+	stored, ok := m.activities[activity.ID]
+	if !ok || stored.Status != from {
+		return false, nil
+	}
+	copied := *activity
+	m.activities[activity.ID] = &copied
+	return true, nil
+}
+
+func (m *mockSuspiciousActivityRepo) ReadTransferVelocity(ctx context.Context, query *entities.TransferVelocityQuery) (*entities.TransferVelocity, error) {
+	v := m.velocity
+	return &v, nil
+}
+
+func (m *mockSuspiciousActivityRepo) ReadAlertRecipients(ctx context.Context) ([]uuid.UUID, error) {
+	return m.recipients, nil
+}
+
+func (m *mockSuspiciousActivityRepo) only(t *testing.T) *entities.SuspiciousActivity {
+	t.Helper()
+	require.Len(t, m.activities, 1)
+	for _, a := range m.activities {
+		return a
+	}
+	return nil
+}
+
+func TestSuspiciousActivityInteractor(t *testing.T) {
+	type fixture struct {
+		activityRepo  *mockSuspiciousActivityRepo
+		idempRepo     *ctxTrackingIdempotencyRepo
+		settingsRepo  *mockSystemSettingsRepo
+		userRepo      *ctxTrackingUserRepo
+		notifications *mockNotificationDispatcher
+		transfer      *interactor.PointTransferInteractor
+		sut           inputport.SuspiciousActivityInputPort
+		admin         *entities.User
+		sender        *entities.User
+		receiver      *entities.User
+	}
+	setup := func() *fixture {
+		f := &fixture{
+			activityRepo:  newMockSuspiciousActivityRepo(),
+			idempRepo:     newCtxTrackingIdempotencyRepo(),
+			settingsRepo:  newMockSystemSettingsRepo(),
+			userRepo:      newCtxTrackingUserRepo(),
+			notifications: &mockNotificationDispatcher{},
+			admin:         createTestUserWithBalance(t, "admin", 0, "admin"),
+			sender:        createTestUserWithBalance(t, "sender", 10000, "user"),
+			receiver:      createTestUserWithBalance(t, "receiver", 0, "user"),
+		}
+		f.userRepo.setUser(f.admin)
+		f.userRepo.setUser(f.sender)
+		f.userRepo.setUser(f.receiver)
+		f.activityRepo.recipients = []uuid.UUID{f.admin.ID}
+
+		logger := &mockLogger{}
+		screener := interactor.NewTransferScreeningInteractor(f.activityRepo, f.userRepo, f.settingsRepo, f.notifications, logger)
+		f.transfer = interactor.NewPointTransferInteractor(
+			&ctxTrackingTxManager{}, f.userRepo, newCtxTrackingTransactionRepo(), f.idempRepo,
+			newCtxTrackingFriendshipRepo(), newCtxTrackingPointBatchRepo(), testsupport.New().BalanceHolds, f.notifications, screener, &mockTransferEligibility{}, &mockTransferPolicy{}, &mockEarningEvents{}, logger,
+		)
+		f.sut = interactor.NewSuspiciousActivityInteractor(f.activityRepo, f.idempRepo, f.settingsRepo, f.userRepo, f.transfer, logger)
+		return f
+	}
+	// 短時間の送り合いとして検出される状態にする
+	washing := func(f *fixture) {
+		f.activityRepo.velocity = entities.TransferVelocity{PairTransfers: 2, ReverseTransfers: 1}
+	}
+	transfer := func(f *fixture, key string) (*inputport.TransferResponse, error) {
+		return f.transfer.Transfer(context.Background(), &inputport.TransferRequest{
+			FromUserID: f.sender.ID, ToUserID: f.receiver.ID, Amount: 500, IdempotencyKey: key,
+		})
+	}
+
+	t.Run("不審でない送金は記録しない", func(t *testing.T) {
+		f := setup()
+		_, err := transfer(f, "normal-"+uuid.New().String())
+		require.NoError(t, err)
+		assert.Empty(t, f.activityRepo.activities)
+	})
+
+	t.Run("保留が無効なら送金を実行し、記録して管理者へ通知する", func(t *testing.T) {
+		f := setup()
+		washing(f)
+
+		resp, err := transfer(f, "open-"+uuid.New().String())
+		require.NoError(t, err)
+
+		activity := f.activityRepo.only(t)
+		assert.Equal(t, entities.SuspiciousActivityStatusOpen, activity.Status)
+		assert.Equal(t, []entities.SuspiciousActivityReason{entities.SuspiciousReasonWashTransfer}, activity.Reasons)
+		require.NotNil(t, activity.TransactionID)
+		assert.Equal(t, resp.Transaction.ID, *activity.TransactionID)
+
+		var alerts []*entities.Notification
+		for _, n := range f.notifications.notifications {
+			if n.Type == entities.NotificationTypeSuspiciousTransfer {
+				alerts = append(alerts, n)
+			}
+		}
+		require.Len(t, alerts, 1)
+		assert.Equal(t, f.admin.ID, alerts[0].UserID)
+		assert.Equal(t, activity.ID.String(), alerts[0].Data["activity_id"])
+	})
+
+	t.Run("保留が有効なら送金を実行せずErrTransferHeldを返し、再送しても実行しない", func(t *testing.T) {
+		f := setup()
+		washing(f)
+		f.settingsRepo.settings[entities.TransferHoldSettingKey] = "true"
+		key := "held-" + uuid.New().String()
+
+		_, err := transfer(f, key)
+		require.ErrorIs(t, err, entities.ErrTransferHeld)
+		assert.Equal(t, "held", f.idempRepo.keys[key].Status)
+		assert.Equal(t, entities.SuspiciousActivityStatusHeld, f.activityRepo.only(t).Status)
+		stored, _ := f.userRepo.Read(context.Background(), f.sender.ID)
+		assert.Equal(t, int64(10000), stored.Balance)
+
+		_, err = transfer(f, key)
+		assert.ErrorIs(t, err, entities.ErrTransferHeld)
+		assert.Len(t, f.activityRepo.activities, 1)
+	})
+
+	t.Run("保留中の送金を問題なしと判断すると同じキーで実行する", func(t *testing.T) {
+		f := setup()
+		washing(f)
+		f.settingsRepo.settings[entities.TransferHoldSettingKey] = "true"
+		key := "release-" + uuid.New().String()
+		_, err := transfer(f, key)
+		require.ErrorIs(t, err, entities.ErrTransferHeld)
+		held := f.activityRepo.only(t)
+
+		activity, err := f.sut.DismissActivity(context.Background(), &inputport.ReviewSuspiciousActivityRequest{
+			AdminID: f.admin.ID, ActivityID: held.ID, Comment: "本人確認済み",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entities.SuspiciousActivityStatusReleased, activity.Status)
+		require.NotNil(t, activity.TransactionID)
+		assert.Equal(t, "completed", f.idempRepo.keys[key].Status)
+		assert.Len(t, f.activityRepo.activities, 1, "解除した送金は改めて検出しない")
+
+		// 再送は実行済みの取引を返す
+		resp, err := transfer(f, key)
+		require.NoError(t, err)
+		assert.Equal(t, *activity.TransactionID, resp.Transaction.ID)
+
+		_, err = f.sut.DismissActivity(context.Background(), &inputport.ReviewSuspiciousActivityRequest{
+			AdminID: f.admin.ID, ActivityID: held.ID,
+		})
+		assert.ErrorIs(t, err, entities.ErrSuspiciousActivityReviewed)
+	})
+
+	t.Run("保留中の送金を不正と判断すると取り消し、再送しても実行しない", func(t *testing.T) {
+		f := setup()
+		washing(f)
+		f.settingsRepo.settings[entities.TransferHoldSettingKey] = "true"
+		key := "reject-" + uuid.New().String()
+		_, err := transfer(f, key)
+		require.ErrorIs(t, err, entities.ErrTransferHeld)
+
+		activity, err := f.sut.ConfirmActivity(context.Background(), &inputport.ReviewSuspiciousActivityRequest{
+			AdminID: f.admin.ID, ActivityID: f.activityRepo.only(t).ID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entities.SuspiciousActivityStatusRejected, activity.Status)
+		assert.Equal(t, "rejected", f.idempRepo.keys[key].Status)
+
+		_, err = transfer(f, key)
+		assert.ErrorIs(t, err, entities.ErrTransferRejected)
+	})
+
+	t.Run("保留の設定を変更できる", func(t *testing.T) {
+		f := setup()
+		enabled, err := f.sut.GetHoldEnabled(context.Background(), f.admin.ID)
+		require.NoError(t, err)
+		assert.False(t, enabled)
+
+		_, err = f.sut.UpdateHoldEnabled(context.Background(), &inputport.UpdateTransferHoldRequest{AdminID: f.admin.ID, Enabled: true})
+		require.NoError(t, err)
+		enabled, err = f.sut.GetHoldEnabled(context.Background(), f.admin.ID)
+		require.NoError(t, err)
+		assert.True(t, enabled)
+	})
+
+	t.Run("管理者以外は操作できない", func(t *testing.T) {
+		f := setup()
+		_, err := f.sut.ListActivities(context.Background(), &inputport.ListSuspiciousActivitiesRequest{AdminID: f.sender.ID, Limit: 50})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		_, err = f.sut.UpdateHoldEnabled(context.Background(), &inputport.UpdateTransferHoldRequest{AdminID: f.sender.ID, Enabled: true})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
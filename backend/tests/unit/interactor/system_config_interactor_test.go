@@ -0,0 +1,34 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemConfigInteractor_GetSettings(t *testing.T) {
+	ctx := context.Background()
+	userRepo := newCtxTrackingUserRepo()
+	admin := &entities.User{ID: uuid.New(), Username: "admin", Role: "admin"}
+	member := &entities.User{ID: uuid.New(), Username: "member", Role: "user"}
+	userRepo.setUser(admin)
+	userRepo.setUser(member)
+
+	settings := entities.ConfigSettings{
+		{Key: "SERVER_PORT", Path: "server.port", Value: "8080", Source: "default"},
+		{Key: "SESSION_SECRET", Path: "security.session_secret", Value: "********", Source: "secret", Secret: true},
+	}
+	sut := interactor.NewSystemConfigInteractor(userRepo, settings)
+
+	got, err := sut.GetSettings(ctx, admin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, settings, got)
+
+	_, err = sut.GetSettings(ctx, member.ID)
+	assert.ErrorIs(t, err, entities.ErrAdminRequired)
+}
@@ -0,0 +1,112 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantInteractor(t *testing.T) {
+	ctx := context.Background()
+	setup := func(t *testing.T) (*testsupport.Repositories, inputport.TenantInputPort, *entities.User) {
+		repos := testsupport.New()
+		superAdmin := createTestUserWithBalance(t, "root", 0, entities.RoleSuperAdmin)
+		superAdmin.TenantID = entities.DefaultTenantID
+		repos.Users.Seed(superAdmin)
+		sut := interactor.NewTenantInteractor(repos.TxManager, repos.Tenants, repos.Users, &mockPasswordService{}, &mockLogger{})
+		return repos, sut, superAdmin
+	}
+	createReq := func(superAdminID uuid.UUID, slug string) *inputport.CreateTenantRequest {
+		return &inputport.CreateTenantRequest{
+			SuperAdminID:   superAdminID,
+			Slug:           slug,
+			Name:           "Acme",
+			AdminUsername:  "acme_admin",
+			AdminEmail:     "admin@acme.example.com",
+			AdminFirstName: "太郎",
+			AdminLastName:  "山田",
+		}
+	}
+
+	t.Run("テナントと最初の管理者を作成し、仮パスワードを返す", func(t *testing.T) {
+		repos, sut, superAdmin := setup(t)
+
+		resp, err := sut.CreateTenant(ctx, createReq(superAdmin.ID, "acme"))
+		require.NoError(t, err)
+		assert.Equal(t, "acme", resp.Tenant.Slug)
+		assert.NotEmpty(t, resp.TemporaryPassword)
+		assert.Equal(t, entities.RoleAdmin, resp.Admin.Role)
+		assert.Equal(t, resp.Tenant.ID, resp.Admin.TenantID)
+
+		stored, err := repos.Users.Read(ctx, resp.Admin.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "hashed_"+resp.TemporaryPassword, stored.PasswordHash)
+
+		resolved, err := sut.ResolveTenant(ctx, "ACME")
+		require.NoError(t, err)
+		assert.Equal(t, resp.Tenant.ID, resolved.ID)
+	})
+
+	t.Run("スラッグが使用済みなら作成しない", func(t *testing.T) {
+		_, sut, superAdmin := setup(t)
+
+		_, err := sut.CreateTenant(ctx, createReq(superAdmin.ID, "acme"))
+		require.NoError(t, err)
+		_, err = sut.CreateTenant(ctx, createReq(superAdmin.ID, "acme"))
+		assert.ErrorIs(t, err, entities.ErrTenantSlugTaken)
+	})
+
+	t.Run("スーパー管理者以外は管理できない", func(t *testing.T) {
+		repos, sut, _ := setup(t)
+		admin := createTestUserWithBalance(t, "tenant_admin", 0, entities.RoleAdmin)
+		other := createTestUserWithBalance(t, "other_root", 0, entities.RoleSuperAdmin)
+		other.TenantID = uuid.New()
+		repos.Users.Seed(admin, other)
+
+		_, err := sut.CreateTenant(ctx, createReq(admin.ID, "acme"))
+		assert.ErrorIs(t, err, entities.ErrSuperAdminRequired)
+		_, err = sut.ListTenants(ctx, &inputport.ListTenantsRequest{SuperAdminID: other.ID})
+		assert.ErrorIs(t, err, entities.ErrSuperAdminRequired, "既定のテナント以外のスーパー管理者は無効")
+	})
+
+	t.Run("停止したテナントは解決できず、既定のテナントは停止できない", func(t *testing.T) {
+		_, sut, superAdmin := setup(t)
+		resp, err := sut.CreateTenant(ctx, createReq(superAdmin.ID, "acme"))
+		require.NoError(t, err)
+
+		updated, err := sut.UpdateTenant(ctx, &inputport.UpdateTenantRequest{
+			SuperAdminID: superAdmin.ID, TenantID: resp.Tenant.ID, Name: "Acme Inc.", IsActive: false,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Acme Inc.", updated.Name)
+		_, err = sut.ResolveTenant(ctx, "acme")
+		assert.ErrorIs(t, err, entities.ErrTenantInactive)
+
+		_, err = sut.UpdateTenant(ctx, &inputport.UpdateTenantRequest{
+			SuperAdminID: superAdmin.ID, TenantID: entities.DefaultTenantID, Name: "既定", IsActive: false,
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidTenant)
+
+		list, err := sut.ListTenants(ctx, &inputport.ListTenantsRequest{SuperAdminID: superAdmin.ID})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), list.Total)
+		assert.Equal(t, entities.DefaultTenantID, list.Tenants[0].ID)
+	})
+
+	t.Run("スラッグが空なら既定のテナント、未登録ならエラー", func(t *testing.T) {
+		_, sut, _ := setup(t)
+
+		tenant, err := sut.ResolveTenant(ctx, "")
+		require.NoError(t, err)
+		assert.True(t, tenant.IsDefault())
+		_, err = sut.ResolveTenant(ctx, "unknown")
+		assert.ErrorIs(t, err, entities.ErrTenantNotFound)
+	})
+}
@@ -0,0 +1,113 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionArchiveInteractor(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	old := now.AddDate(-2, 0, 0)
+
+	setup := func(t *testing.T, retention entities.TransactionRetention) (*testsupport.Repositories, *interactor.TransactionArchiveInteractor, *entities.User, *entities.User) {
+		repos := testsupport.New()
+		admin := createTestUserWithBalance(t, "admin", 0, entities.RoleAdmin)
+		user := createTestUserWithBalance(t, "user", 0, entities.RoleUser)
+		repos.Users.Seed(admin, user)
+		sut := interactor.NewTransactionArchiveInteractor(repos.TxManager, repos.TransactionArchive, repos.Users, retention, &mockLogger{})
+		return repos, sut, admin, user
+	}
+	grant := func(t *testing.T, repos *testsupport.Repositories, user *entities.User, amount int64, at time.Time, status entities.TransactionStatus) *entities.Transaction {
+		tx, err := entities.NewAdminGrant(user.ID, amount, "grant", uuid.New())
+		require.NoError(t, err)
+		tx.CreatedAt = at
+		tx.Status = status
+		require.NoError(t, repos.Transactions.Create(ctx, tx))
+		return tx
+	}
+
+	t.Run("保持期間を過ぎた確定済みの取引をバッチに分けて移す", func(t *testing.T) {
+		repos, sut, admin, user := setup(t, entities.TransactionRetention{Days: 365, BatchSize: 2})
+		for i := 0; i < 3; i++ {
+			grant(t, repos, user, 100, old.Add(time.Duration(i)*time.Hour), entities.TransactionStatusCompleted)
+		}
+		pending := grant(t, repos, user, 50, old, entities.TransactionStatusPending)
+		recent := grant(t, repos, user, 10, now, entities.TransactionStatusCompleted)
+		user.Balance = 310
+		repos.Users.Seed(user)
+
+		archived, err := sut.ArchiveTransactions(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), archived)
+		assert.Equal(t, 2, repos.TxManager.Calls("Do"), "BatchSize件ずつ別のトランザクションで移す")
+
+		for _, tx := range []*entities.Transaction{pending, recent} {
+			_, err := repos.Transactions.Read(ctx, tx.ID)
+			assert.NoError(t, err, "処理中・保持期間内の取引は残る")
+		}
+
+		checks, err := repos.BalanceLedger.ReadBalanceChecks(ctx, []uuid.UUID{user.ID})
+		require.NoError(t, err)
+		assert.False(t, checks[0].HasDiscrepancy(), "移した取引も残高の照合に含める")
+
+		list, err := sut.ListArchivedTransactions(ctx, &inputport.ListArchivedTransactionsRequest{
+			AdminID: admin.ID,
+			Filter:  entities.ArchivedTransactionFilter{UserID: &user.ID, From: old.AddDate(0, 0, -1), To: old.AddDate(0, 0, 1)},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), list.Total)
+		assert.Equal(t, 50, list.Limit)
+		assert.Equal(t, 365, list.RetentionDays)
+		assert.True(t, list.Transactions[0].CreatedAt.After(list.Transactions[2].CreatedAt), "新しい順")
+
+		summaries, err := sut.GetArchiveSummaries(ctx, &inputport.GetArchiveSummariesRequest{AdminID: admin.ID})
+		require.NoError(t, err)
+		var count, total int64
+		for _, s := range summaries.Summaries {
+			count += s.TransactionCount
+			total += s.TotalAmount
+		}
+		assert.Equal(t, int64(3), count)
+		assert.Equal(t, int64(300), total)
+	})
+
+	t.Run("保持期間が未設定なら何もしない", func(t *testing.T) {
+		repos, sut, _, user := setup(t, entities.TransactionRetention{BatchSize: 500})
+		tx := grant(t, repos, user, 100, old, entities.TransactionStatusCompleted)
+
+		archived, err := sut.ArchiveTransactions(ctx, now)
+		require.NoError(t, err)
+		assert.Zero(t, archived)
+		_, err = repos.Transactions.Read(ctx, tx.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("検索は管理者のみで、期間の指定が必要", func(t *testing.T) {
+		_, sut, admin, user := setup(t, entities.TransactionRetention{Days: 365, BatchSize: 500})
+
+		_, err := sut.ListArchivedTransactions(ctx, &inputport.ListArchivedTransactionsRequest{
+			AdminID: user.ID,
+			Filter:  entities.ArchivedTransactionFilter{From: old, To: old.AddDate(0, 1, 0)},
+		})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+
+		_, err = sut.ListArchivedTransactions(ctx, &inputport.ListArchivedTransactionsRequest{
+			AdminID: admin.ID,
+			Filter:  entities.ArchivedTransactionFilter{From: old, To: old.AddDate(2, 0, 0)},
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidArchiveQuery)
+
+		_, err = sut.GetArchiveSummaries(ctx, &inputport.GetArchiveSummariesRequest{AdminID: admin.ID, FromMonth: "2024/01"})
+		assert.ErrorIs(t, err, entities.ErrInvalidArchiveQuery)
+	})
+}
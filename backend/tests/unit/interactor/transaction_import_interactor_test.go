@@ -0,0 +1,184 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tiUserRepo は残高の一括更新を記録する UserRepository のモック
+type tiUserRepo struct {
+	*importUserRepo
+	updates []repository.BalanceUpdate
+}
+
+func (m *tiUserRepo) UpdateBalancesWithLock(ctx context.Context, updates []repository.BalanceUpdate) error {
+	m.ctxRecords["UpdateBalancesWithLock"] = ctx
+	m.updates = append(m.updates, updates...)
+	return nil
+}
+
+// tiPointBatchRepo は作成したバッチと既存のバッチからの消費を記録する PointBatchRepository のモック
+type tiPointBatchRepo struct {
+	*ctxTrackingPointBatchRepo
+	batches  []*entities.PointBatch
+	consumed map[uuid.UUID]int64
+}
+
+func (m *tiPointBatchRepo) Create(ctx context.Context, batch *entities.PointBatch) error {
+	m.batches = append(m.batches, batch)
+	return nil
+}
+func (m *tiPointBatchRepo) ConsumePointsFIFO(ctx context.Context, userID uuid.UUID, amount int64) error {
+	m.consumed[userID] += amount
+	return nil
+}
+
+// ========================================
+// TransactionImportInteractor テスト
+// ========================================
+
+type transactionImportFixture struct {
+	sut       inputport.TransactionImportInputPort
+	txManager *ctxTrackingTxManager
+	userRepo  *tiUserRepo
+	txRepo    *ctxTrackingTransactionRepo
+	batchRepo *tiPointBatchRepo
+	ledger    *mockBalanceLedgerRepo
+	admin     *entities.User
+	yamada    *entities.User
+	suzuki    *entities.User
+}
+
+func setupTransactionImportInteractor(t *testing.T) *transactionImportFixture {
+	f := &transactionImportFixture{
+		txManager: &ctxTrackingTxManager{},
+		userRepo:  &tiUserRepo{importUserRepo: newImportUserRepo()},
+		txRepo:    newCtxTrackingTransactionRepo(),
+		batchRepo: &tiPointBatchRepo{ctxTrackingPointBatchRepo: newCtxTrackingPointBatchRepo(), consumed: make(map[uuid.UUID]int64)},
+		ledger:    newMockBalanceLedgerRepo(),
+		admin:     createTestUserWithBalance(t, "admin", 0, "admin"),
+		yamada:    createTestUserWithBalance(t, "yamada", 100, "user"),
+		suzuki:    createTestUserWithBalance(t, "suzuki", 0, "user"),
+	}
+	for _, u := range []*entities.User{f.admin, f.yamada, f.suzuki} {
+		f.userRepo.setUser(u)
+		f.ledger.checks[u.ID] = &entities.BalanceCheck{UserID: u.ID, Username: u.Username, StoredBalance: u.Balance, LedgerBalance: u.Balance}
+	}
+	f.sut = interactor.NewTransactionImportInteractor(f.txManager, f.userRepo, f.txRepo, f.batchRepo, f.ledger, &mockLogger{})
+	return f
+}
+
+func TestTransactionImportInteractor_ImportTransactions(t *testing.T) {
+	csv := "date,from,to,amount,type\n" +
+		"2024-04-15,yamada,suzuki,300,transfer\n" +
+		"2024-04-01,,yamada,1000,admin_grant\n" +
+		"2024-05-01,suzuki,,100,admin_deduct\n"
+
+	t.Run("日時の順に取引・残高・ポイントバッチを書き込み残高を照合する", func(t *testing.T) {
+		f := setupTransactionImportInteractor(t)
+
+		resp, err := f.sut.ImportTransactions(context.Background(), &inputport.ImportTransactionsRequest{
+			AdminID: f.admin.ID, CSVData: []byte(csv),
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.Imported)
+		assert.Equal(t, 3, resp.ImportedCount)
+		assert.Equal(t, 0, resp.FailedCount)
+		for _, r := range resp.Results {
+			assert.NotNil(t, r.TransactionID, "line %d", r.Line)
+		}
+
+		// 書き込みは日時の順（付与 → 送金 → 減算）
+		require.Len(t, f.txRepo.transactions, 3)
+		assert.Equal(t, entities.TransactionTypeAdminGrant, f.txRepo.transactions[0].TransactionType)
+		assert.Equal(t, entities.TransactionTypeTransfer, f.txRepo.transactions[1].TransactionType)
+		for _, tx := range f.txRepo.transactions {
+			assert.True(t, tx.Migrated())
+			assert.Equal(t, resp.ImportID.String(), tx.Metadata["import_id"])
+		}
+		assert.True(t, isTxContext(f.txRepo.ctxRecords["Create"]))
+
+		assert.ElementsMatch(t, []repository.BalanceUpdate{
+			{UserID: f.yamada.ID, Amount: 700},
+			{UserID: f.suzuki.ID, Amount: 200},
+		}, f.userRepo.updates)
+
+		// 送金・減算は取り込んだバッチから消費する
+		require.Len(t, f.batchRepo.batches, 2)
+		assert.Equal(t, int64(700), f.batchRepo.batches[0].RemainingAmount)
+		assert.Equal(t, int64(200), f.batchRepo.batches[1].RemainingAmount)
+		assert.Empty(t, f.batchRepo.consumed)
+
+		require.Len(t, resp.Balances, 2)
+		for _, b := range resp.Balances {
+			if b.UserID == f.yamada.ID {
+				assert.Equal(t, int64(100), b.BalanceBefore)
+			}
+		}
+	})
+
+	t.Run("取り込んだバッチで足りない分は既存のバッチから消費する", func(t *testing.T) {
+		f := setupTransactionImportInteractor(t)
+
+		_, err := f.sut.ImportTransactions(context.Background(), &inputport.ImportTransactionsRequest{
+			AdminID: f.admin.ID,
+			CSVData: []byte("date,from,to,amount,type\n2024-04-01,yamada,suzuki,80,transfer\n"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(80), f.batchRepo.consumed[f.yamada.ID])
+	})
+
+	t.Run("ドライランは照合の結果だけを返し取引IDを返さない", func(t *testing.T) {
+		f := setupTransactionImportInteractor(t)
+
+		resp, err := f.sut.ImportTransactions(context.Background(), &inputport.ImportTransactionsRequest{
+			AdminID: f.admin.ID, CSVData: []byte(csv), DryRun: true,
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.Imported)
+		assert.True(t, resp.DryRun)
+		assert.Equal(t, 0, resp.ImportedCount)
+		assert.Len(t, resp.Balances, 2)
+		for _, r := range resp.Results {
+			assert.Nil(t, r.TransactionID)
+		}
+	})
+
+	t.Run("存在しないユーザーや残高が負になる行があれば何も書き込まない", func(t *testing.T) {
+		f := setupTransactionImportInteractor(t)
+		csv := "date,from,to,amount,type\n" +
+			"2024-04-01,,nobody,100,admin_grant\n" +
+			"2024-04-02,yamada,suzuki,150,transfer\n" +
+			"2024-04-03,yamada,suzuki,100,transfer\n"
+
+		resp, err := f.sut.ImportTransactions(context.Background(), &inputport.ImportTransactionsRequest{
+			AdminID: f.admin.ID, CSVData: []byte(csv),
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.Imported)
+		assert.Equal(t, 2, resp.FailedCount)
+		assert.ErrorIs(t, resp.Results[0].Err, entities.ErrUserNotFound)
+		assert.ErrorIs(t, resp.Results[1].Err, entities.ErrInsufficientBalance)
+		assert.NoError(t, resp.Results[2].Err)
+		assert.Empty(t, f.txRepo.transactions)
+		assert.Empty(t, f.batchRepo.batches)
+		assert.Empty(t, resp.Balances)
+	})
+
+	t.Run("管理者以外はエラー", func(t *testing.T) {
+		f := setupTransactionImportInteractor(t)
+
+		_, err := f.sut.ImportTransactions(context.Background(), &inputport.ImportTransactionsRequest{
+			AdminID: f.yamada.ID, CSVData: []byte(csv),
+		})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
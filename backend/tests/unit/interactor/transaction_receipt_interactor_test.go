@@ -0,0 +1,150 @@
+package interactor_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/infra/infrareceipt"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ========================================
+// TransactionReceiptInteractor テスト
+// ========================================
+
+func TestTransactionReceiptInteractor(t *testing.T) {
+	ctx := context.Background()
+	signer := infrareceipt.NewHMACReceiptSigner("transaction-receipt-test-signing-secret")
+
+	setup := func(t *testing.T) (*testsupport.Repositories, inputport.TransactionReceiptInputPort, *entities.User, *entities.User, *entities.User) {
+		repos := testsupport.New()
+		sut := interactor.NewTransactionReceiptInteractor(repos.Transactions, repos.Users, signer, &mockLogger{})
+		sender := createTestUserWithBalance(t, "sender", 1000, entities.RoleUser)
+		receiver := createTestUserWithBalance(t, "receiver", 0, entities.RoleUser)
+		admin := createTestUserWithBalance(t, "admin", 0, entities.RoleAdmin)
+		repos.Users.Seed(sender, receiver, admin)
+		return repos, sut, sender, receiver, admin
+	}
+	transfer := func(t *testing.T, repos *testsupport.Repositories, from, to *entities.User, complete bool) *entities.Transaction {
+		tx, err := entities.NewTransfer(from.ID, to.ID, 300, uuid.NewString(), "ランチ代")
+		require.NoError(t, err)
+		if complete {
+			require.NoError(t, tx.Complete())
+		}
+		require.NoError(t, repos.Transactions.Create(ctx, tx))
+		return tx
+	}
+
+	t.Run("完了した取引の控えは両当事者と検証ハッシュを含み、何度発行しても同じハッシュになる", func(t *testing.T) {
+		repos, sut, sender, receiver, _ := setup(t)
+		tx := transfer(t, repos, sender, receiver, true)
+
+		receipt, err := sut.GetReceipt(ctx, &inputport.GetTransactionReceiptRequest{UserID: receiver.ID, TransactionID: tx.ID})
+		require.NoError(t, err)
+		assert.Equal(t, tx.ID, receipt.TransactionID)
+		assert.Equal(t, int64(300), receipt.Amount)
+		assert.Equal(t, sender.Username, receipt.From.Username)
+		assert.Equal(t, receiver.Username, receipt.To.Username)
+		assert.Equal(t, entities.TransactionReceiptVersion, receipt.Version)
+		assert.Len(t, receipt.Hash, 64)
+
+		again, err := sut.GetReceipt(ctx, &inputport.GetTransactionReceiptRequest{UserID: sender.ID, TransactionID: tx.ID})
+		require.NoError(t, err)
+		assert.Equal(t, receipt.Hash, again.Hash)
+	})
+
+	t.Run("管理者は提示されたハッシュを検証でき、書き換えたハッシュは無効になる", func(t *testing.T) {
+		repos, sut, sender, receiver, admin := setup(t)
+		tx := transfer(t, repos, sender, receiver, true)
+		receipt, err := sut.GetReceipt(ctx, &inputport.GetTransactionReceiptRequest{UserID: receiver.ID, TransactionID: tx.ID})
+		require.NoError(t, err)
+
+		resp, err := sut.VerifyReceipt(ctx, &inputport.VerifyTransactionReceiptRequest{AdminID: admin.ID, TransactionID: tx.ID, Hash: strings.ToUpper(receipt.Hash)})
+		require.NoError(t, err)
+		assert.True(t, resp.Valid)
+		assert.Equal(t, tx.ID, resp.Receipt.TransactionID)
+
+		tampered := "0" + receipt.Hash[1:]
+		if tampered == receipt.Hash {
+			tampered = "1" + receipt.Hash[1:]
+		}
+		resp, err = sut.VerifyReceipt(ctx, &inputport.VerifyTransactionReceiptRequest{AdminID: admin.ID, TransactionID: tx.ID, Hash: tampered})
+		require.NoError(t, err)
+		assert.False(t, resp.Valid)
+
+		other := transfer(t, repos, sender, receiver, true)
+		resp, err = sut.VerifyReceipt(ctx, &inputport.VerifyTransactionReceiptRequest{AdminID: admin.ID, TransactionID: other.ID, Hash: receipt.Hash})
+		require.NoError(t, err)
+		assert.False(t, resp.Valid)
+	})
+
+	t.Run("別の鍵で署名したハッシュは無効", func(t *testing.T) {
+		repos, sut, sender, receiver, admin := setup(t)
+		tx := transfer(t, repos, sender, receiver, true)
+		forged := interactor.NewTransactionReceiptInteractor(repos.Transactions, repos.Users, infrareceipt.NewHMACReceiptSigner("another-transaction-receipt-secret"), &mockLogger{})
+		receipt, err := forged.GetReceipt(ctx, &inputport.GetTransactionReceiptRequest{UserID: receiver.ID, TransactionID: tx.ID})
+		require.NoError(t, err)
+
+		resp, err := sut.VerifyReceipt(ctx, &inputport.VerifyTransactionReceiptRequest{AdminID: admin.ID, TransactionID: tx.ID, Hash: receipt.Hash})
+		require.NoError(t, err)
+		assert.False(t, resp.Valid)
+	})
+
+	t.Run("取り消された取引もハッシュは変わらず、状態は取り消しになる", func(t *testing.T) {
+		repos, sut, sender, receiver, admin := setup(t)
+		tx := transfer(t, repos, sender, receiver, true)
+		receipt, err := sut.GetReceipt(ctx, &inputport.GetTransactionReceiptRequest{UserID: receiver.ID, TransactionID: tx.ID})
+		require.NoError(t, err)
+
+		tx.Status = entities.TransactionStatusReversed
+		require.NoError(t, repos.Transactions.Update(ctx, tx))
+
+		resp, err := sut.VerifyReceipt(ctx, &inputport.VerifyTransactionReceiptRequest{AdminID: admin.ID, TransactionID: tx.ID, Hash: receipt.Hash})
+		require.NoError(t, err)
+		assert.True(t, resp.Valid)
+		assert.Equal(t, entities.TransactionStatusReversed, resp.Receipt.Status)
+	})
+
+	t.Run("システムからの付与はシステムの口座を当事者にする", func(t *testing.T) {
+		repos, sut, _, receiver, admin := setup(t)
+		tx, err := entities.NewAdminGrant(receiver.ID, 500, "イベント参加", admin.ID)
+		require.NoError(t, err)
+		require.NoError(t, repos.Transactions.Create(ctx, tx))
+
+		receipt, err := sut.GetReceipt(ctx, &inputport.GetTransactionReceiptRequest{UserID: receiver.ID, TransactionID: tx.ID})
+		require.NoError(t, err)
+		assert.Nil(t, receipt.From.UserID)
+		assert.Equal(t, entities.SystemAccountTreasury, receipt.From.Account)
+		assert.Equal(t, receiver.Username, receipt.To.Username)
+	})
+
+	t.Run("完了していない取引の控えは発行できない", func(t *testing.T) {
+		repos, sut, sender, receiver, _ := setup(t)
+		tx := transfer(t, repos, sender, receiver, false)
+
+		_, err := sut.GetReceipt(ctx, &inputport.GetTransactionReceiptRequest{UserID: sender.ID, TransactionID: tx.ID})
+		assert.ErrorIs(t, err, entities.ErrReceiptUnavailable)
+	})
+
+	t.Run("存在しない取引はErrTransactionNotFound", func(t *testing.T) {
+		_, sut, sender, _, _ := setup(t)
+
+		_, err := sut.GetReceipt(ctx, &inputport.GetTransactionReceiptRequest{UserID: sender.ID, TransactionID: uuid.New()})
+		assert.ErrorIs(t, err, entities.ErrTransactionNotFound)
+	})
+
+	t.Run("管理者以外は検証できない", func(t *testing.T) {
+		repos, sut, sender, receiver, _ := setup(t)
+		tx := transfer(t, repos, sender, receiver, true)
+
+		_, err := sut.VerifyReceipt(ctx, &inputport.VerifyTransactionReceiptRequest{AdminID: receiver.ID, TransactionID: tx.ID, Hash: "x"})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
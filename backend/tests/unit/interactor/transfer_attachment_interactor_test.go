@@ -0,0 +1,194 @@
+package interactor_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/gateways/infra/infrastorage"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ========================================
+// TransferAttachmentInteractor テスト
+// ========================================
+
+func TestTransferAttachmentInteractor(t *testing.T) {
+	ctx := context.Background()
+	image := []byte("fake image content")
+
+	setup := func(t *testing.T) (*testsupport.Repositories, *interactor.TransferAttachmentInteractor, string, *entities.User, *entities.User) {
+		repos := testsupport.New()
+		dir := t.TempDir()
+		storage, err := infrastorage.NewLocalStorage(&infrastorage.Config{
+			BaseDir:       filepath.Join(dir, "avatars"),
+			BaseURL:       "/uploads/avatars",
+			AttachmentDir: filepath.Join(dir, "attachments"),
+		})
+		require.NoError(t, err)
+		sut := interactor.NewTransferAttachmentInteractor(repos.TransferAttachments, repos.Transactions, repos.TransferRequests, storage,
+			entities.TransferAttachmentRetention{Days: entities.DefaultTransferAttachmentRetentionDays}, &mockLogger{})
+		sender := createTestUserWithBalance(t, "sender", 1000, entities.RoleUser)
+		receiver := createTestUserWithBalance(t, "receiver", 0, entities.RoleUser)
+		repos.Users.Seed(sender, receiver)
+		return repos, sut, filepath.Join(dir, "attachments"), sender, receiver
+	}
+	transfer := func(t *testing.T, repos *testsupport.Repositories, from, to *entities.User, complete bool) *entities.Transaction {
+		tx, err := entities.NewTransfer(from.ID, to.ID, 300, uuid.NewString(), "ランチ代")
+		require.NoError(t, err)
+		if complete {
+			require.NoError(t, tx.Complete())
+		}
+		require.NoError(t, repos.Transactions.Create(ctx, tx))
+		return tx
+	}
+	read := func(t *testing.T, resp *inputport.GetTransferAttachmentResponse) []byte {
+		defer resp.Content.Close()
+		data, err := io.ReadAll(resp.Content)
+		require.NoError(t, err)
+		return data
+	}
+
+	t.Run("完了した送金に添付した画像は両当事者が取得でき、履歴にも表示される", func(t *testing.T) {
+		repos, sut, _, sender, receiver := setup(t)
+		tx := transfer(t, repos, sender, receiver, true)
+
+		attachment, err := sut.AttachToTransaction(ctx, &inputport.AttachTransferImageRequest{UserID: sender.ID, TargetID: tx.ID, FileData: image, FileName: "receipt.jpg"})
+		require.NoError(t, err)
+		assert.Equal(t, "receipt.jpg", attachment.FileName)
+		assert.Equal(t, int64(len(image)), attachment.SizeBytes)
+
+		resp, err := sut.GetTransactionAttachment(ctx, &inputport.GetTransferAttachmentRequest{UserID: receiver.ID, TargetID: tx.ID})
+		require.NoError(t, err)
+		assert.Equal(t, attachment.ID, resp.Attachment.ID)
+		assert.Equal(t, image, read(t, resp))
+
+		history, err := repos.Transactions.ReadListByUserIDWithUsers(ctx, receiver.ID, "", 0, 10)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.True(t, history[0].HasAttachment)
+	})
+
+	t.Run("処理中の送金やシステムからの付与には添付できない", func(t *testing.T) {
+		repos, sut, _, sender, receiver := setup(t)
+		pending := transfer(t, repos, sender, receiver, false)
+		_, err := sut.AttachToTransaction(ctx, &inputport.AttachTransferImageRequest{UserID: sender.ID, TargetID: pending.ID, FileData: image, FileName: "receipt.jpg"})
+		assert.ErrorIs(t, err, entities.ErrAttachmentNotAllowed)
+
+		grant, err := entities.NewAdminGrant(receiver.ID, 500, "イベント参加", uuid.New())
+		require.NoError(t, err)
+		require.NoError(t, repos.Transactions.Create(ctx, grant))
+		_, err = sut.AttachToTransaction(ctx, &inputport.AttachTransferImageRequest{UserID: receiver.ID, TargetID: grant.ID, FileData: image, FileName: "receipt.jpg"})
+		assert.ErrorIs(t, err, entities.ErrAttachmentNotAllowed)
+	})
+
+	t.Run("当事者以外は添付も取得もできない", func(t *testing.T) {
+		repos, sut, _, sender, receiver := setup(t)
+		other := createTestUserWithBalance(t, "other", 0, entities.RoleUser)
+		repos.Users.Seed(other)
+		tx := transfer(t, repos, sender, receiver, true)
+		_, err := sut.AttachToTransaction(ctx, &inputport.AttachTransferImageRequest{UserID: sender.ID, TargetID: tx.ID, FileData: image, FileName: "receipt.jpg"})
+		require.NoError(t, err)
+
+		_, err = sut.AttachToTransaction(ctx, &inputport.AttachTransferImageRequest{UserID: other.ID, TargetID: tx.ID, FileData: image, FileName: "receipt.jpg"})
+		assert.Error(t, err)
+		_, err = sut.GetTransactionAttachment(ctx, &inputport.GetTransferAttachmentRequest{UserID: other.ID, TargetID: tx.ID})
+		assert.Error(t, err)
+	})
+
+	t.Run("送金リクエストの添付は承認してできた送金の添付として見える", func(t *testing.T) {
+		repos, sut, _, sender, receiver := setup(t)
+		request, err := entities.NewTransferRequest(sender.ID, receiver.ID, 300, "ランチ代", uuid.NewString())
+		require.NoError(t, err)
+		require.NoError(t, repos.TransferRequests.Create(ctx, request))
+
+		attachment, err := sut.AttachToTransferRequest(ctx, &inputport.AttachTransferImageRequest{UserID: sender.ID, TargetID: request.ID, FileData: image, FileName: "lunch.png"})
+		require.NoError(t, err)
+		resp, err := sut.GetTransferRequestAttachment(ctx, &inputport.GetTransferAttachmentRequest{UserID: receiver.ID, TargetID: request.ID})
+		require.NoError(t, err)
+		assert.Equal(t, image, read(t, resp))
+
+		tx := transfer(t, repos, sender, receiver, true)
+		require.NoError(t, request.Approve(tx.ID))
+		require.NoError(t, repos.TransferRequests.Update(ctx, request))
+
+		resp, err = sut.GetTransactionAttachment(ctx, &inputport.GetTransferAttachmentRequest{UserID: receiver.ID, TargetID: tx.ID})
+		require.NoError(t, err)
+		assert.Equal(t, attachment.ID, resp.Attachment.ID)
+		assert.Equal(t, image, read(t, resp))
+
+		// 承認後の送金リクエストには添付し直せない
+		_, err = sut.AttachToTransferRequest(ctx, &inputport.AttachTransferImageRequest{UserID: sender.ID, TargetID: request.ID, FileData: image, FileName: "lunch.png"})
+		assert.ErrorIs(t, err, entities.ErrAttachmentNotAllowed)
+	})
+
+	t.Run("添付し直すと前の画像は削除される", func(t *testing.T) {
+		repos, sut, dir, sender, receiver := setup(t)
+		tx := transfer(t, repos, sender, receiver, true)
+		first, err := sut.AttachToTransaction(ctx, &inputport.AttachTransferImageRequest{UserID: sender.ID, TargetID: tx.ID, FileData: image, FileName: "receipt.jpg"})
+		require.NoError(t, err)
+
+		second, err := sut.AttachToTransaction(ctx, &inputport.AttachTransferImageRequest{UserID: receiver.ID, TargetID: tx.ID, FileData: []byte("another image"), FileName: "receipt.jpg"})
+		require.NoError(t, err)
+		assert.NotEqual(t, first.FilePath, second.FilePath)
+
+		_, err = os.Stat(filepath.Join(dir, first.FilePath))
+		assert.True(t, os.IsNotExist(err))
+		resp, err := sut.GetTransactionAttachment(ctx, &inputport.GetTransferAttachmentRequest{UserID: sender.ID, TargetID: tx.ID})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("another image"), read(t, resp))
+	})
+
+	t.Run("空の画像や許可されていない拡張子は添付できない", func(t *testing.T) {
+		repos, sut, _, sender, receiver := setup(t)
+		tx := transfer(t, repos, sender, receiver, true)
+
+		_, err := sut.AttachToTransaction(ctx, &inputport.AttachTransferImageRequest{UserID: sender.ID, TargetID: tx.ID, FileName: "receipt.jpg"})
+		assert.ErrorIs(t, err, entities.ErrInvalidAttachment)
+		_, err = sut.AttachToTransaction(ctx, &inputport.AttachTransferImageRequest{UserID: sender.ID, TargetID: tx.ID, FileData: image, FileName: "receipt.exe"})
+		assert.Error(t, err)
+	})
+
+	t.Run("添付がなければErrAttachmentNotFound", func(t *testing.T) {
+		repos, sut, _, sender, receiver := setup(t)
+		tx := transfer(t, repos, sender, receiver, true)
+
+		_, err := sut.GetTransactionAttachment(ctx, &inputport.GetTransferAttachmentRequest{UserID: sender.ID, TargetID: tx.ID})
+		assert.ErrorIs(t, err, entities.ErrAttachmentNotFound)
+	})
+
+	t.Run("保持期間を過ぎた送金の添付画像だけ削除する", func(t *testing.T) {
+		repos, sut, dir, sender, receiver := setup(t)
+		old, err := entities.NewTransfer(sender.ID, receiver.ID, 300, uuid.NewString(), "ランチ代")
+		require.NoError(t, err)
+		require.NoError(t, old.Complete())
+		old.CreatedAt = time.Now().AddDate(0, 0, -entities.DefaultTransferAttachmentRetentionDays-1)
+		require.NoError(t, repos.Transactions.Create(ctx, old))
+		recent := transfer(t, repos, sender, receiver, true)
+
+		expired, err := sut.AttachToTransaction(ctx, &inputport.AttachTransferImageRequest{UserID: sender.ID, TargetID: old.ID, FileData: image, FileName: "old.jpg"})
+		require.NoError(t, err)
+		_, err = sut.AttachToTransaction(ctx, &inputport.AttachTransferImageRequest{UserID: sender.ID, TargetID: recent.ID, FileData: image, FileName: "recent.jpg"})
+		require.NoError(t, err)
+
+		deleted, err := sut.CleanupAttachments(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		_, err = os.Stat(filepath.Join(dir, expired.FilePath))
+		assert.True(t, os.IsNotExist(err))
+		_, err = sut.GetTransactionAttachment(ctx, &inputport.GetTransferAttachmentRequest{UserID: sender.ID, TargetID: old.ID})
+		assert.ErrorIs(t, err, entities.ErrAttachmentNotFound)
+		_, err = sut.GetTransactionAttachment(ctx, &inputport.GetTransferAttachmentRequest{UserID: sender.ID, TargetID: recent.ID})
+		assert.NoError(t, err)
+	})
+}
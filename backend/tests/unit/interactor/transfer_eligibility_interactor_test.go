@@ -0,0 +1,217 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTransferEligibility は err を返す（nilなら条件なし）
+type mockTransferEligibility struct {
+	err error
+}
+
+func (m *mockTransferEligibility) CheckSender(ctx context.Context, userID uuid.UUID) error {
+	return m.err
+}
+
+func (m *mockTransferEligibility) CheckRecipient(ctx context.Context, senderID, recipientID uuid.UUID) error {
+	return nil
+}
+
+func (m *mockTransferEligibility) AcceptanceOf(ctx context.Context, user *entities.User) (entities.TransferAcceptance, error) {
+	return entities.TransferAcceptanceAnyone, nil
+}
+
+func TestTransferEligibilityInteractor(t *testing.T) {
+	setup := func() (*mockSystemSettingsRepo, *ctxTrackingUserRepo, *interactor.TransferEligibilityInteractor, *entities.User) {
+		settingsRepo := newMockSystemSettingsRepo()
+		userRepo := newCtxTrackingUserRepo()
+		admin := createTestUserWithBalance(t, "admin", 0, "admin")
+		userRepo.setUser(admin)
+		return settingsRepo, userRepo, interactor.NewTransferEligibilityInteractor(settingsRepo, userRepo, newMockFriendshipRepo(), &mockLogger{}), admin
+	}
+	update := func(t *testing.T, sut *interactor.TransferEligibilityInteractor, admin *entities.User, requireEmail bool, hours int) {
+		t.Helper()
+		_, err := sut.UpdatePolicy(context.Background(), &inputport.UpdateTransferEligibilityRequest{
+			AdminID: admin.ID, RequireEmailVerification: requireEmail, MinAccountAgeHours: hours,
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("未設定なら誰でも送金できる", func(t *testing.T) {
+		_, _, sut, _ := setup()
+		assert.NoError(t, sut.CheckSender(context.Background(), uuid.New()), "条件がなければユーザーを読まない")
+	})
+
+	t.Run("メール認証が必要ならメール未認証のユーザーは送金できない", func(t *testing.T) {
+		_, userRepo, sut, admin := setup()
+		update(t, sut, admin, true, 0)
+
+		user := createTestUserWithBalance(t, "unverified", 1000, "user")
+		userRepo.setUser(user)
+		assert.ErrorIs(t, sut.CheckSender(context.Background(), user.ID), entities.ErrEmailVerificationRequired)
+
+		user.VerifyEmail()
+		userRepo.setUser(user)
+		assert.NoError(t, sut.CheckSender(context.Background(), user.ID))
+	})
+
+	t.Run("作成から指定時間が経っていないアカウントは送金できない", func(t *testing.T) {
+		_, userRepo, sut, admin := setup()
+		update(t, sut, admin, false, 24)
+
+		user := createTestUserWithBalance(t, "newcomer", 1000, "user")
+		userRepo.setUser(user)
+		err := sut.CheckSender(context.Background(), user.ID)
+		require.ErrorIs(t, err, entities.ErrAccountTooNew)
+		de, ok := entities.AsDomainError(err)
+		require.True(t, ok)
+		assert.Equal(t, 24, de.Params["min_account_age_hours"])
+
+		user.CreatedAt = time.Now().Add(-25 * time.Hour)
+		userRepo.setUser(user)
+		assert.NoError(t, sut.CheckSender(context.Background(), user.ID))
+	})
+
+	t.Run("設定を取得できる", func(t *testing.T) {
+		_, _, sut, admin := setup()
+		update(t, sut, admin, true, 72)
+
+		policy, err := sut.GetPolicy(context.Background(), admin.ID)
+		require.NoError(t, err)
+		assert.True(t, policy.RequireEmailVerification)
+		assert.Equal(t, 72, policy.MinAccountAgeHours)
+		require.NotNil(t, policy.UpdatedBy)
+		assert.Equal(t, admin.ID, *policy.UpdatedBy)
+	})
+
+	t.Run("範囲外の時間は設定できない", func(t *testing.T) {
+		settingsRepo, _, sut, admin := setup()
+		_, err := sut.UpdatePolicy(context.Background(), &inputport.UpdateTransferEligibilityRequest{
+			AdminID: admin.ID, MinAccountAgeHours: entities.TransferMinAccountAgeMaxHours + 1,
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidTransferEligibility)
+		assert.Empty(t, settingsRepo.settings)
+	})
+
+	t.Run("管理者以外は設定できない", func(t *testing.T) {
+		_, userRepo, sut, _ := setup()
+		user := createTestUserWithBalance(t, "user", 0, "user")
+		userRepo.setUser(user)
+
+		_, err := sut.GetPolicy(context.Background(), user.ID)
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		_, err = sut.UpdatePolicy(context.Background(), &inputport.UpdateTransferEligibilityRequest{AdminID: user.ID, RequireEmailVerification: true})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
+
+func TestTransferEligibilityInteractor_CheckRecipient(t *testing.T) {
+	setup := func(t *testing.T) (*ctxTrackingUserRepo, *mockFriendshipRepo, *interactor.TransferEligibilityInteractor, *entities.User, *entities.User) {
+		userRepo := newCtxTrackingUserRepo()
+		friendshipRepo := newMockFriendshipRepo()
+		sender := createTestUserWithBalance(t, "sender", 1000, "user")
+		recipient := createTestUserWithBalance(t, "recipient", 0, "user")
+		userRepo.setUser(sender)
+		userRepo.setUser(recipient)
+		sut := interactor.NewTransferEligibilityInteractor(newMockSystemSettingsRepo(), userRepo, friendshipRepo, &mockLogger{})
+		return userRepo, friendshipRepo, sut, sender, recipient
+	}
+	befriend := func(friendshipRepo *mockFriendshipRepo, a, b *entities.User) {
+		friendshipRepo.byUsers[a.ID.String()+"-"+b.ID.String()] = &entities.Friendship{
+			ID: uuid.New(), RequesterID: a.ID, AddresseeID: b.ID, Status: entities.FriendshipStatusAccepted,
+		}
+	}
+
+	t.Run("未設定なら誰からでも受け取る", func(t *testing.T) {
+		_, _, sut, sender, recipient := setup(t)
+		assert.NoError(t, sut.CheckRecipient(context.Background(), sender.ID, recipient.ID))
+	})
+
+	t.Run("友達からだけ受け取るユーザーには友達だけが送れる", func(t *testing.T) {
+		userRepo, friendshipRepo, sut, sender, recipient := setup(t)
+		require.NoError(t, recipient.SetAcceptTransfersFrom(entities.TransferAcceptanceFriendsOnly))
+		userRepo.setUser(recipient)
+
+		assert.ErrorIs(t, sut.CheckRecipient(context.Background(), sender.ID, recipient.ID), entities.ErrRecipientAcceptsFriendsOnly)
+
+		befriend(friendshipRepo, sender, recipient)
+		assert.NoError(t, sut.CheckRecipient(context.Background(), sender.ID, recipient.ID))
+	})
+
+	t.Run("設定していないユーザーはシステム設定の既定に従う", func(t *testing.T) {
+		userRepo, _, sut, sender, recipient := setup(t)
+		admin := createTestUserWithBalance(t, "admin", 0, "admin")
+		userRepo.setUser(admin)
+		_, err := sut.UpdatePolicy(context.Background(), &inputport.UpdateTransferEligibilityRequest{
+			AdminID: admin.ID, DefaultAcceptance: entities.TransferAcceptanceFriendsOnly,
+		})
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, sut.CheckRecipient(context.Background(), sender.ID, recipient.ID), entities.ErrRecipientAcceptsFriendsOnly)
+		acceptance, err := sut.AcceptanceOf(context.Background(), recipient)
+		require.NoError(t, err)
+		assert.Equal(t, entities.TransferAcceptanceFriendsOnly, acceptance)
+
+		require.NoError(t, recipient.SetAcceptTransfersFrom(entities.TransferAcceptanceAnyone))
+		userRepo.setUser(recipient)
+		assert.NoError(t, sut.CheckRecipient(context.Background(), sender.ID, recipient.ID), "ユーザーの設定を既定より優先する")
+	})
+}
+
+func TestPointTransferInteractor_Eligibility(t *testing.T) {
+	t.Run("条件を満たさない送信者は送金できず、冪等性キーも作らない", func(t *testing.T) {
+		userRepo := newCtxTrackingUserRepo()
+		idempRepo := newCtxTrackingIdempotencyRepo()
+		sut := interactor.NewPointTransferInteractor(
+			&ctxTrackingTxManager{}, userRepo, newCtxTrackingTransactionRepo(), idempRepo,
+			newCtxTrackingFriendshipRepo(), newCtxTrackingPointBatchRepo(), testsupport.New().BalanceHolds, &mockNotificationDispatcher{},
+			&mockTransferScreener{}, &mockTransferEligibility{err: entities.ErrEmailVerificationRequired}, &mockTransferPolicy{}, &mockEarningEvents{}, &mockLogger{},
+		)
+		sender := createTestUserWithBalance(t, "sender", 10000, "user")
+		receiver := createTestUserWithBalance(t, "receiver", 0, "user")
+		userRepo.setUser(sender)
+		userRepo.setUser(receiver)
+
+		_, err := sut.Transfer(context.Background(), &inputport.TransferRequest{
+			FromUserID: sender.ID, ToUserID: receiver.ID, Amount: 500, IdempotencyKey: "gate-" + uuid.New().String(),
+		})
+		assert.ErrorIs(t, err, entities.ErrEmailVerificationRequired)
+		assert.Empty(t, idempRepo.keys)
+	})
+}
+
+func TestTransferRequestInteractor_Eligibility(t *testing.T) {
+	t.Run("条件を満たさない送信者は送金リクエストを作成できない", func(t *testing.T) {
+		trRepo := newMockTransferRequestRepo()
+		userRepo := newMockUserRepoForTR()
+		sender, _ := entities.NewUser("sender", "sender@example.com", "hash", "Sender", "太郎", "田中")
+		sender.Balance = 10000
+		sender.IsActive = true
+		receiver, _ := entities.NewUser("receiver", "receiver@example.com", "hash", "Receiver", "花子", "山田")
+		receiver.IsActive = true
+		userRepo.setUser(sender)
+		userRepo.setUser(receiver)
+
+		tooNew := entities.ErrAccountTooNew.WithParams(map[string]interface{}{"min_account_age_hours": 24})
+		itr := interactor.NewTransferRequestInteractor(trRepo, userRepo, newMockPointTransferPort(), &mockContentModeration{},
+			&mockNotificationDispatcher{}, &mockTransferEligibility{err: tooNew}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, &mockTransferRequestLogger{})
+
+		_, err := itr.CreateTransferRequest(context.Background(), &inputport.CreateTransferRequestRequest{
+			FromUserID: sender.ID, ToUserID: receiver.ID, Amount: 1000, IdempotencyKey: "key-too-new",
+		})
+		assert.ErrorIs(t, err, entities.ErrAccountTooNew)
+
+		existing, _ := trRepo.ReadByIdempotencyKey(context.Background(), "key-too-new")
+		assert.Nil(t, existing)
+	})
+}
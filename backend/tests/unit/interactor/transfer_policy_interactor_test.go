@@ -0,0 +1,250 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTransferPolicy は policy を返す（nilなら上下限なし・手数料なし）
+type mockTransferPolicy struct {
+	policy *entities.TransferPolicy
+}
+
+func (m *mockTransferPolicy) CurrentPolicy(ctx context.Context) (*entities.TransferPolicy, error) {
+	if m.policy == nil {
+		return &entities.TransferPolicy{FeeType: entities.TransferFeeNone}, nil
+	}
+	return m.policy, nil
+}
+
+func TestTransferPolicy_FeeFor(t *testing.T) {
+	feeAccount := uuid.New()
+
+	t.Run("定額の手数料は送金額によらない", func(t *testing.T) {
+		policy := &entities.TransferPolicy{FeeType: entities.TransferFeeFlat, FeeFlat: 10, FeeAccountID: &feeAccount}
+		assert.Equal(t, int64(10), policy.FeeFor(uuid.New(), 1))
+		assert.Equal(t, int64(10), policy.FeeFor(uuid.New(), 100000))
+	})
+
+	t.Run("割合の手数料は1ポイント未満を切り上げる", func(t *testing.T) {
+		policy := &entities.TransferPolicy{FeeType: entities.TransferFeePercentage, FeeRateBasisPoints: 150, FeeAccountID: &feeAccount}
+		assert.Equal(t, int64(15), policy.FeeFor(uuid.New(), 1000))
+		assert.Equal(t, int64(2), policy.FeeFor(uuid.New(), 101))
+		assert.Equal(t, int64(1), policy.FeeFor(uuid.New(), 1))
+	})
+
+	t.Run("手数料の受け取り用アカウントからの送金には手数料をかけない", func(t *testing.T) {
+		policy := &entities.TransferPolicy{FeeType: entities.TransferFeeFlat, FeeFlat: 10, FeeAccountID: &feeAccount}
+		assert.Equal(t, int64(0), policy.FeeFor(feeAccount, 1000))
+	})
+}
+
+func TestTransferPolicyInteractor(t *testing.T) {
+	setup := func() (*mockSystemSettingsRepo, *ctxTrackingUserRepo, *interactor.TransferPolicyInteractor, *entities.User) {
+		settingsRepo := newMockSystemSettingsRepo()
+		userRepo := newCtxTrackingUserRepo()
+		admin := createTestUserWithBalance(t, "admin", 0, "admin")
+		userRepo.setUser(admin)
+		return settingsRepo, userRepo, interactor.NewTransferPolicyInteractor(settingsRepo, userRepo, &mockLogger{}), admin
+	}
+
+	t.Run("未設定なら上下限なし・手数料なし", func(t *testing.T) {
+		_, _, sut, _ := setup()
+		policy, err := sut.CurrentPolicy(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, entities.TransferFeeNone, policy.FeeType)
+		assert.NoError(t, policy.CheckAmount(1))
+		assert.Equal(t, int64(0), policy.FeeFor(uuid.New(), 1000))
+	})
+
+	t.Run("設定した上下限と手数料を取得できる", func(t *testing.T) {
+		_, userRepo, sut, admin := setup()
+		feeAccount := createTestUserWithBalance(t, "fees", 0, "user")
+		userRepo.setUser(feeAccount)
+
+		_, err := sut.UpdatePolicy(context.Background(), &inputport.UpdateTransferPolicyRequest{
+			AdminID: admin.ID, MinAmount: 10, MaxAmount: 5000,
+			FeeType: entities.TransferFeePercentage, FeeRateBasisPoints: 100, FeeAccountID: &feeAccount.ID,
+		})
+		require.NoError(t, err)
+
+		policy, err := sut.GetPolicy(context.Background(), admin.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), policy.MinAmount)
+		assert.Equal(t, int64(5000), policy.MaxAmount)
+		assert.Equal(t, entities.TransferFeePercentage, policy.FeeType)
+		require.NotNil(t, policy.FeeAccountID)
+		assert.Equal(t, feeAccount.ID, *policy.FeeAccountID)
+		require.NotNil(t, policy.UpdatedBy)
+		assert.Equal(t, admin.ID, *policy.UpdatedBy)
+
+		err = policy.CheckAmount(5)
+		require.ErrorIs(t, err, entities.ErrTransferAmountTooSmall)
+		de, ok := entities.AsDomainError(err)
+		require.True(t, ok)
+		assert.Equal(t, int64(10), de.Params["min_amount"])
+		assert.ErrorIs(t, policy.CheckAmount(5001), entities.ErrTransferAmountTooLarge)
+	})
+
+	t.Run("不正な設定は保存しない", func(t *testing.T) {
+		settingsRepo, _, sut, admin := setup()
+		feeAccount := uuid.New()
+		for name, req := range map[string]*inputport.UpdateTransferPolicyRequest{
+			"下限が上限を超える":   {AdminID: admin.ID, MinAmount: 100, MaxAmount: 10},
+			"割合が100%を超える": {AdminID: admin.ID, FeeType: entities.TransferFeePercentage, FeeRateBasisPoints: 10001, FeeAccountID: &feeAccount},
+			"未知の手数料の種類":   {AdminID: admin.ID, FeeType: "tiered"},
+		} {
+			_, err := sut.UpdatePolicy(context.Background(), req)
+			assert.ErrorIs(t, err, entities.ErrInvalidTransferPolicy, name)
+		}
+		assert.Empty(t, settingsRepo.settings)
+	})
+
+	t.Run("無効なアカウントは手数料の受け取り用にできない", func(t *testing.T) {
+		settingsRepo, userRepo, sut, admin := setup()
+		feeAccount := createTestUserWithBalance(t, "fees", 0, "user")
+		feeAccount.IsActive = false
+		userRepo.setUser(feeAccount)
+
+		_, err := sut.UpdatePolicy(context.Background(), &inputport.UpdateTransferPolicyRequest{
+			AdminID: admin.ID, FeeType: entities.TransferFeeFlat, FeeFlat: 5, FeeAccountID: &feeAccount.ID,
+		})
+		assert.ErrorIs(t, err, entities.ErrUserInactive)
+		assert.Empty(t, settingsRepo.settings)
+	})
+
+	t.Run("管理者以外は設定できない", func(t *testing.T) {
+		_, userRepo, sut, _ := setup()
+		user := createTestUserWithBalance(t, "user", 0, "user")
+		userRepo.setUser(user)
+
+		_, err := sut.GetPolicy(context.Background(), user.ID)
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		_, err = sut.UpdatePolicy(context.Background(), &inputport.UpdateTransferPolicyRequest{AdminID: user.ID, MinAmount: 1})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
+
+func TestPointTransferInteractor_TransferPolicy(t *testing.T) {
+	type fixture struct {
+		sut        *interactor.PointTransferInteractor
+		txRepo     *ctxTrackingTransactionRepo
+		idempRepo  *ctxTrackingIdempotencyRepo
+		sender     *entities.User
+		receiver   *entities.User
+		feeAccount *entities.User
+		policy     *entities.TransferPolicy
+	}
+	setup := func(t *testing.T, policy *entities.TransferPolicy) *fixture {
+		f := &fixture{
+			txRepo:     newCtxTrackingTransactionRepo(),
+			idempRepo:  newCtxTrackingIdempotencyRepo(),
+			sender:     createTestUserWithBalance(t, "sender", 10000, "user"),
+			receiver:   createTestUserWithBalance(t, "receiver", 0, "user"),
+			feeAccount: createTestUserWithBalance(t, "fees", 0, "user"),
+			policy:     policy,
+		}
+		userRepo := newCtxTrackingUserRepo()
+		userRepo.setUser(f.sender)
+		userRepo.setUser(f.receiver)
+		userRepo.setUser(f.feeAccount)
+		f.sut = interactor.NewPointTransferInteractor(
+			&ctxTrackingTxManager{}, userRepo, f.txRepo, f.idempRepo,
+			newCtxTrackingFriendshipRepo(), newCtxTrackingPointBatchRepo(), testsupport.New().BalanceHolds, &mockNotificationDispatcher{},
+			&mockTransferScreener{}, &mockTransferEligibility{}, &mockTransferPolicy{policy: policy}, &mockEarningEvents{}, &mockLogger{},
+		)
+		return f
+	}
+
+	t.Run("上下限の範囲外の送金額は送金できず、冪等性キーも作らない", func(t *testing.T) {
+		f := setup(t, &entities.TransferPolicy{MinAmount: 100, MaxAmount: 1000})
+
+		_, err := f.sut.Transfer(context.Background(), &inputport.TransferRequest{
+			FromUserID: f.sender.ID, ToUserID: f.receiver.ID, Amount: 50, IdempotencyKey: "small-" + uuid.New().String(),
+		})
+		assert.ErrorIs(t, err, entities.ErrTransferAmountTooSmall)
+		_, err = f.sut.Transfer(context.Background(), &inputport.TransferRequest{
+			FromUserID: f.sender.ID, ToUserID: f.receiver.ID, Amount: 1001, IdempotencyKey: "large-" + uuid.New().String(),
+		})
+		assert.ErrorIs(t, err, entities.ErrTransferAmountTooLarge)
+		assert.Empty(t, f.idempRepo.keys)
+		assert.Empty(t, f.txRepo.transactions)
+	})
+
+	t.Run("手数料は送金とは別の取引として受け取り用アカウントに記録する", func(t *testing.T) {
+		f := setup(t, &entities.TransferPolicy{FeeType: entities.TransferFeeFlat, FeeFlat: 20})
+		f.policy.FeeAccountID = &f.feeAccount.ID
+
+		resp, err := f.sut.Transfer(context.Background(), &inputport.TransferRequest{
+			FromUserID: f.sender.ID, ToUserID: f.receiver.ID, Amount: 500, IdempotencyKey: "fee-" + uuid.New().String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(20), resp.Fee)
+		assert.Equal(t, int64(500), resp.Transaction.Amount, "受信者は送金額をそのまま受け取る")
+
+		require.Len(t, f.txRepo.transactions, 2)
+		feeTx := f.txRepo.transactions[1]
+		assert.Equal(t, entities.TransactionTypeTransferFee, feeTx.TransactionType)
+		assert.Equal(t, int64(20), feeTx.Amount)
+		require.NotNil(t, feeTx.FromUserID)
+		assert.Equal(t, f.sender.ID, *feeTx.FromUserID)
+		require.NotNil(t, feeTx.ToUserID)
+		assert.Equal(t, f.feeAccount.ID, *feeTx.ToUserID)
+		assert.Empty(t, feeTx.ToAccount)
+	})
+
+	t.Run("受け取り用アカウントがなければ手数料はシステムの手数料口座に入る", func(t *testing.T) {
+		f := setup(t, &entities.TransferPolicy{FeeType: entities.TransferFeeFlat, FeeFlat: 20})
+
+		resp, err := f.sut.Transfer(context.Background(), &inputport.TransferRequest{
+			FromUserID: f.sender.ID, ToUserID: f.receiver.ID, Amount: 500, IdempotencyKey: "sysfee-" + uuid.New().String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(20), resp.Fee)
+
+		require.Len(t, f.txRepo.transactions, 2)
+		feeTx := f.txRepo.transactions[1]
+		assert.Nil(t, feeTx.ToUserID)
+		assert.Equal(t, entities.SystemAccountFees, feeTx.ToAccount)
+		assert.Nil(t, feeTx.IdempotencyKey, "冪等性キーは送金の取引だけが持つ")
+	})
+
+	t.Run("手数料がなければ手数料の取引を作らない", func(t *testing.T) {
+		f := setup(t, nil)
+
+		resp, err := f.sut.Transfer(context.Background(), &inputport.TransferRequest{
+			FromUserID: f.sender.ID, ToUserID: f.receiver.ID, Amount: 500, IdempotencyKey: "nofee-" + uuid.New().String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), resp.Fee)
+		assert.Len(t, f.txRepo.transactions, 1)
+	})
+
+	t.Run("見積もりは手数料・合計額・残高が足りるかを返す", func(t *testing.T) {
+		f := setup(t, &entities.TransferPolicy{MaxAmount: 20000, FeeType: entities.TransferFeePercentage, FeeRateBasisPoints: 250})
+
+		quote, err := f.sut.QuoteTransfer(context.Background(), &inputport.QuoteTransferRequest{FromUserID: f.sender.ID, Amount: 1000})
+		require.NoError(t, err)
+		assert.Equal(t, int64(25), quote.Fee)
+		assert.Equal(t, int64(1025), quote.Total)
+		assert.Equal(t, entities.TransferFeePercentage, quote.FeeType)
+		assert.Equal(t, int64(10000), quote.Balance)
+		assert.True(t, quote.SufficientFunds)
+
+		quote, err = f.sut.QuoteTransfer(context.Background(), &inputport.QuoteTransferRequest{FromUserID: f.sender.ID, Amount: 9800})
+		require.NoError(t, err)
+		assert.False(t, quote.SufficientFunds, "手数料を含めると残高が足りない")
+		assert.Empty(t, f.txRepo.transactions, "見積もりでは取引を作らない")
+
+		_, err = f.sut.QuoteTransfer(context.Background(), &inputport.QuoteTransferRequest{FromUserID: f.sender.ID, Amount: 20001})
+		assert.ErrorIs(t, err, entities.ErrTransferAmountTooLarge)
+	})
+}
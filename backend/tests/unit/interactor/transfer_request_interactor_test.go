@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/gity/point-system/usecases/interactor"
 	"github.com/gity/point-system/usecases/repository"
@@ -90,6 +91,26 @@ func (m *mockTransferRequestRepo) CountPendingByToUser(ctx context.Context, toUs
 	return m.pendingCount, nil
 }
 
+func (m *mockTransferRequestRepo) CountPendingByFromUser(ctx context.Context, fromUserID uuid.UUID) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockTransferRequestRepo) CountPendingByFromUserTo(ctx context.Context, fromUserID, toUserID uuid.UUID) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockTransferRequestRepo) ReadBySplitIDs(ctx context.Context, splitIDs []uuid.UUID) ([]*entities.TransferRequest, error) {
+	var results []*entities.TransferRequest
+	for _, tr := range m.requests {
+		for _, id := range splitIDs {
+			if tr.SplitID != nil && *tr.SplitID == id {
+				results = append(results, tr)
+			}
+		}
+	}
+	return results, nil
+}
+
 func (m *mockTransferRequestRepo) UpdateExpiredRequests(ctx context.Context) (int64, error) {
 	return 0, nil
 }
@@ -166,15 +187,18 @@ func (m *mockUserRepoForTR) UpdateBalanceWithLock(ctx context.Context, userID uu
 func (m *mockUserRepoForTR) UpdateBalancesWithLock(ctx context.Context, updates []repository.BalanceUpdate) error {
 	return nil
 }
+func (m *mockUserRepoForTR) UpdateReservedBalanceWithLock(ctx context.Context, userID uuid.UUID, amount int64, isRelease bool) error {
+	return nil
+}
 func (m *mockUserRepoForTR) ReadList(ctx context.Context, offset, limit int) ([]*entities.User, error) {
 	return nil, nil
 }
 func (m *mockUserRepoForTR) Count(ctx context.Context) (int64, error)       { return 0, nil }
 func (m *mockUserRepoForTR) Delete(ctx context.Context, id uuid.UUID) error { return nil }
-func (m *mockUserRepoForTR) ReadListWithSearch(ctx context.Context, search, sortBy, sortOrder string, offset, limit int) ([]*entities.User, error) {
+func (m *mockUserRepoForTR) ReadListWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID, sortBy, sortOrder string, offset, limit int) ([]*entities.User, error) {
 	return nil, nil
 }
-func (m *mockUserRepoForTR) CountWithSearch(ctx context.Context, search string) (int64, error) {
+func (m *mockUserRepoForTR) CountWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID) (int64, error) {
 	return 0, nil
 }
 func (m *mockUserRepoForTR) ReadPersonalQRCode(ctx context.Context, userID uuid.UUID) (string, error) {
@@ -188,6 +212,7 @@ func (m *mockUserRepoForTR) setUser(user *entities.User) {
 type mockPointTransferPort struct {
 	transferResp *inputport.TransferResponse
 	transferErr  error
+	lastReq      *inputport.TransferRequest
 }
 
 func newMockPointTransferPort() *mockPointTransferPort {
@@ -195,6 +220,7 @@ func newMockPointTransferPort() *mockPointTransferPort {
 }
 
 func (m *mockPointTransferPort) Transfer(ctx context.Context, req *inputport.TransferRequest) (*inputport.TransferResponse, error) {
+	m.lastReq = req
 	if m.transferErr != nil {
 		return nil, m.transferErr
 	}
@@ -213,6 +239,10 @@ func (m *mockPointTransferPort) GetExpiringPoints(ctx context.Context, req *inpu
 	return &inputport.GetExpiringPointsResponse{}, nil
 }
 
+func (m *mockPointTransferPort) QuoteTransfer(ctx context.Context, req *inputport.QuoteTransferRequest) (*inputport.QuoteTransferResponse, error) {
+	return &inputport.QuoteTransferResponse{Amount: req.Amount, Total: req.Amount}, nil
+}
+
 type mockTransferRequestLogger struct{}
 
 func (m *mockTransferRequestLogger) Debug(msg string, fields ...entities.Field) {}
@@ -241,7 +271,8 @@ func TestTransferRequestInteractor_CreateTransferRequest(t *testing.T) {
 		userRepo.setUser(sender)
 		userRepo.setUser(receiver)
 
-		itr := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, logger)
+		notifications := &mockNotificationDispatcher{}
+		itr := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, &mockContentModeration{}, notifications, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, logger)
 
 		req := &inputport.CreateTransferRequestRequest{
 			FromUserID:     sender.ID,
@@ -259,6 +290,44 @@ func TestTransferRequestInteractor_CreateTransferRequest(t *testing.T) {
 		assert.Equal(t, int64(1000), resp.TransferRequest.Amount)
 		assert.Equal(t, "Test transfer", resp.TransferRequest.Message)
 		assert.Equal(t, entities.TransferRequestStatusPending, resp.TransferRequest.Status)
+
+		// 受取人へ承認待ちが通知される
+		require.Len(t, notifications.notifications, 1)
+		assert.Equal(t, receiver.ID, notifications.notifications[0].UserID)
+		assert.Equal(t, entities.NotificationTypeTransferRequest, notifications.notifications[0].Type)
+	})
+
+	t.Run("メッセージがモデレーションで拒否された場合エラー", func(t *testing.T) {
+		trRepo := newMockTransferRequestRepo()
+		userRepo := newMockUserRepoForTR()
+		ptPort := newMockPointTransferPort()
+		logger := &mockTransferRequestLogger{}
+
+		sender, _ := entities.NewUser("sender", "sender@example.com", "hash", "Sender", "太郎", "田中")
+		sender.Balance = 10000
+		sender.IsActive = true
+		receiver, _ := entities.NewUser("receiver", "receiver@example.com", "hash", "Receiver", "花子", "山田")
+		receiver.IsActive = true
+
+		userRepo.setUser(sender)
+		userRepo.setUser(receiver)
+
+		moderation := &mockContentModeration{rejectFields: map[entities.ModerationField]bool{
+			entities.ModerationFieldTransferMessage: true,
+		}}
+		itr := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, moderation, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, logger)
+
+		_, err := itr.CreateTransferRequest(context.Background(), &inputport.CreateTransferRequestRequest{
+			FromUserID:     sender.ID,
+			ToUserID:       receiver.ID,
+			Amount:         1000,
+			Message:        "rejected",
+			IdempotencyKey: "key-rejected",
+		})
+		assert.ErrorIs(t, err, entities.ErrContentRejected)
+
+		existing, _ := trRepo.ReadByIdempotencyKey(context.Background(), "key-rejected")
+		assert.Nil(t, existing)
 	})
 
 	t.Run("冪等性キーで既存リクエストを返す", func(t *testing.T) {
@@ -280,7 +349,7 @@ func TestTransferRequestInteractor_CreateTransferRequest(t *testing.T) {
 		existingTR, _ := entities.NewTransferRequest(sender.ID, receiver.ID, 1000, "Existing", "key-existing")
 		trRepo.Create(context.Background(), existingTR)
 
-		itr := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, logger)
+		itr := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, &mockContentModeration{}, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, logger)
 
 		req := &inputport.CreateTransferRequestRequest{
 			FromUserID:     sender.ID,
@@ -306,7 +375,7 @@ func TestTransferRequestInteractor_CreateTransferRequest(t *testing.T) {
 		receiver.IsActive = true
 		userRepo.setUser(receiver)
 
-		itr := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, logger)
+		itr := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, &mockContentModeration{}, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, logger)
 
 		req := &inputport.CreateTransferRequestRequest{
 			FromUserID:     uuid.New(), // 存在しないユーザー
@@ -338,7 +407,7 @@ func TestTransferRequestInteractor_CreateTransferRequest(t *testing.T) {
 		userRepo.setUser(sender)
 		userRepo.setUser(receiver)
 
-		itr := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, logger)
+		itr := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, &mockContentModeration{}, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, logger)
 
 		req := &inputport.CreateTransferRequestRequest{
 			FromUserID:     sender.ID, // 存在しないユーザー
@@ -386,7 +455,7 @@ func TestTransferRequestInteractor_ApproveTransferRequest(t *testing.T) {
 			ToUser:      receiver,
 		}
 
-		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, logger)
+		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, &mockContentModeration{}, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, logger)
 
 		req := &inputport.ApproveTransferRequestRequest{
 			RequestID: tr.ID,
@@ -414,7 +483,7 @@ func TestTransferRequestInteractor_ApproveTransferRequest(t *testing.T) {
 		tr, _ := entities.NewTransferRequest(sender.ID, receiver.ID, 1000, "Test", "key-wronguser")
 		trRepo.Create(context.Background(), tr)
 
-		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, logger)
+		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, &mockContentModeration{}, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, logger)
 
 		req := &inputport.ApproveTransferRequestRequest{
 			RequestID: tr.ID,
@@ -423,7 +492,7 @@ func TestTransferRequestInteractor_ApproveTransferRequest(t *testing.T) {
 
 		_, err := interactor.ApproveTransferRequest(context.Background(), req)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "unauthorized")
+		assert.ErrorIs(t, err, entities.ErrActionNotPermitted)
 	})
 
 	t.Run("期限切れリクエストは承認できない", func(t *testing.T) {
@@ -439,7 +508,7 @@ func TestTransferRequestInteractor_ApproveTransferRequest(t *testing.T) {
 		tr.ExpiresAt = time.Now().Add(-1 * time.Hour) // 期限切れ
 		trRepo.Create(context.Background(), tr)
 
-		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, logger)
+		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, &mockContentModeration{}, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, logger)
 
 		req := &inputport.ApproveTransferRequestRequest{
 			RequestID: tr.ID,
@@ -466,7 +535,7 @@ func TestTransferRequestInteractor_ApproveTransferRequest(t *testing.T) {
 		// ポイント転送を失敗させる
 		ptPort.transferErr = errors.New("insufficient balance")
 
-		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, logger)
+		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, &mockContentModeration{}, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, logger)
 
 		req := &inputport.ApproveTransferRequestRequest{
 			RequestID: tr.ID,
@@ -477,6 +546,36 @@ func TestTransferRequestInteractor_ApproveTransferRequest(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to execute transfer")
 	})
+
+	t.Run("割り勘の1人分は支払う参加者が承認し、請求した作成者は承認できない", func(t *testing.T) {
+		trRepo := newMockTransferRequestRepo()
+		ptPort := newMockPointTransferPort()
+
+		requester := &entities.User{ID: uuid.New()}
+		payer := &entities.User{ID: uuid.New()}
+		split, shares, err := entities.NewSplitRequest(requester.ID, 1000, entities.SplitTypeEqual, []entities.SplitShare{{UserID: payer.ID}}, false, "dinner", "key-split")
+		require.NoError(t, err)
+		tr, err := split.NewShareRequest(shares[0])
+		require.NoError(t, err)
+		trRepo.Create(context.Background(), tr)
+
+		ptPort.transferResp = &inputport.TransferResponse{
+			Transaction: &entities.Transaction{ID: uuid.New(), FromUserID: &payer.ID, ToUserID: &requester.ID, Amount: 1000},
+			FromUser:    payer,
+			ToUser:      requester,
+		}
+		sut := interactor.NewTransferRequestInteractor(trRepo, newMockUserRepoForTR(), ptPort, &mockContentModeration{}, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, &mockTransferRequestLogger{})
+
+		_, err = sut.ApproveTransferRequest(context.Background(), &inputport.ApproveTransferRequestRequest{RequestID: tr.ID, UserID: requester.ID})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, entities.ErrActionNotPermitted)
+
+		resp, err := sut.ApproveTransferRequest(context.Background(), &inputport.ApproveTransferRequestRequest{RequestID: tr.ID, UserID: payer.ID})
+		require.NoError(t, err)
+		assert.Equal(t, entities.TransferRequestStatusApproved, resp.TransferRequest.Status)
+		assert.Equal(t, payer.ID, ptPort.lastReq.FromUserID, "参加者から作成者へ送金する")
+		assert.Equal(t, requester.ID, ptPort.lastReq.ToUserID)
+	})
 }
 
 func TestTransferRequestInteractor_RejectTransferRequest(t *testing.T) {
@@ -492,7 +591,7 @@ func TestTransferRequestInteractor_RejectTransferRequest(t *testing.T) {
 		tr, _ := entities.NewTransferRequest(sender.ID, receiver.ID, 1000, "Test", "key-reject")
 		trRepo.Create(context.Background(), tr)
 
-		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, logger)
+		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, &mockContentModeration{}, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, logger)
 
 		req := &inputport.RejectTransferRequestRequest{
 			RequestID: tr.ID,
@@ -520,7 +619,7 @@ func TestTransferRequestInteractor_RejectTransferRequest(t *testing.T) {
 		tr, _ := entities.NewTransferRequest(sender.ID, receiver.ID, 1000, "Test", "key-reject-wrong")
 		trRepo.Create(context.Background(), tr)
 
-		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, logger)
+		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, &mockContentModeration{}, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, logger)
 
 		req := &inputport.RejectTransferRequestRequest{
 			RequestID: tr.ID,
@@ -530,7 +629,7 @@ func TestTransferRequestInteractor_RejectTransferRequest(t *testing.T) {
 		ctx := context.Background()
 		_, err := interactor.RejectTransferRequest(ctx, req)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "unauthorized")
+		assert.ErrorIs(t, err, entities.ErrActionNotPermitted)
 	})
 }
 
@@ -547,7 +646,7 @@ func TestTransferRequestInteractor_CancelTransferRequest(t *testing.T) {
 		tr, _ := entities.NewTransferRequest(sender.ID, receiver.ID, 1000, "Test", "key-cancel")
 		trRepo.Create(context.Background(), tr)
 
-		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, logger)
+		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, &mockContentModeration{}, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, logger)
 
 		req := &inputport.CancelTransferRequestRequest{
 			RequestID: tr.ID,
@@ -573,7 +672,7 @@ func TestTransferRequestInteractor_CancelTransferRequest(t *testing.T) {
 		tr, _ := entities.NewTransferRequest(sender.ID, receiver.ID, 1000, "Test", "key-cancel-wrong")
 		trRepo.Create(context.Background(), tr)
 
-		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, logger)
+		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, &mockContentModeration{}, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, logger)
 
 		req := &inputport.CancelTransferRequestRequest{
 			RequestID: tr.ID,
@@ -583,7 +682,96 @@ func TestTransferRequestInteractor_CancelTransferRequest(t *testing.T) {
 		ctx := context.Background()
 		_, err := interactor.CancelTransferRequest(ctx, req)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "unauthorized")
+		assert.ErrorIs(t, err, entities.ErrActionNotPermitted)
+	})
+}
+
+func TestTransferRequestInteractor_CounterOffer(t *testing.T) {
+	setup := func(t *testing.T) (inputport.TransferRequestInputPort, *mockPointTransferPort, *entities.User, *entities.User, *entities.TransferRequest) {
+		trRepo := newMockTransferRequestRepo()
+		userRepo := newMockUserRepoForTR()
+		ptPort := newMockPointTransferPort()
+
+		sender := &entities.User{ID: uuid.New()}
+		receiver := &entities.User{ID: uuid.New()}
+
+		tr, _ := entities.NewTransferRequest(sender.ID, receiver.ID, 1000, "Test", "key-counter-"+uuid.NewString())
+		trRepo.Create(context.Background(), tr)
+
+		ptPort.transferResp = &inputport.TransferResponse{
+			Transaction: &entities.Transaction{ID: uuid.New(), FromUserID: &sender.ID, ToUserID: &receiver.ID, Amount: 600},
+			FromUser:    sender,
+			ToUser:      receiver,
+		}
+
+		uc := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, &mockContentModeration{}, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, &mockTransferRequestLogger{})
+		return uc, ptPort, sender, receiver, tr
+	}
+
+	t.Run("受取人のカウンターオファーを送信者が確定すると変更後の金額で送金", func(t *testing.T) {
+		uc, ptPort, sender, receiver, tr := setup(t)
+		ctx := context.Background()
+
+		counterResp, err := uc.CounterTransferRequest(ctx, &inputport.CounterTransferRequestRequest{
+			RequestID: tr.ID,
+			UserID:    receiver.ID,
+			Amount:    600,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entities.TransferRequestStatusCountered, counterResp.TransferRequest.Status)
+		assert.Equal(t, receiver.ID, counterResp.TransferRequest.LastActedBy)
+
+		confirmResp, err := uc.ConfirmCounterOffer(ctx, &inputport.ConfirmCounterOfferRequest{
+			RequestID: tr.ID,
+			UserID:    sender.ID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entities.TransferRequestStatusApproved, confirmResp.TransferRequest.Status)
+		assert.Equal(t, sender.ID, confirmResp.TransferRequest.LastActedBy)
+		require.NotNil(t, ptPort.lastReq)
+		assert.Equal(t, int64(600), ptPort.lastReq.Amount)
+	})
+
+	t.Run("送信者はカウンターオファーできない", func(t *testing.T) {
+		uc, _, sender, _, tr := setup(t)
+
+		_, err := uc.CounterTransferRequest(context.Background(), &inputport.CounterTransferRequestRequest{
+			RequestID: tr.ID,
+			UserID:    sender.ID,
+			Amount:    600,
+		})
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entities.ErrActionNotPermitted)
+	})
+
+	t.Run("受取人はカウンターオファーを確定できない", func(t *testing.T) {
+		uc, ptPort, _, receiver, tr := setup(t)
+		ctx := context.Background()
+
+		_, err := uc.CounterTransferRequest(ctx, &inputport.CounterTransferRequestRequest{
+			RequestID: tr.ID,
+			UserID:    receiver.ID,
+			Amount:    600,
+		})
+		require.NoError(t, err)
+
+		_, err = uc.ConfirmCounterOffer(ctx, &inputport.ConfirmCounterOfferRequest{
+			RequestID: tr.ID,
+			UserID:    receiver.ID,
+		})
+		assert.Error(t, err)
+		assert.Nil(t, ptPort.lastReq, "送金は実行されない")
+	})
+
+	t.Run("カウンターオファー前は確定できない", func(t *testing.T) {
+		uc, ptPort, sender, _, tr := setup(t)
+
+		_, err := uc.ConfirmCounterOffer(context.Background(), &inputport.ConfirmCounterOfferRequest{
+			RequestID: tr.ID,
+			UserID:    sender.ID,
+		})
+		assert.ErrorIs(t, err, entities.ErrRequestNotCountered)
+		assert.Nil(t, ptPort.lastReq)
 	})
 }
 
@@ -604,7 +792,7 @@ func TestTransferRequestInteractor_GetPendingRequests(t *testing.T) {
 		trRepo.pendingByTo = []*entities.TransferRequest{tr}
 		trRepo.userRef = userRepo
 
-		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, logger)
+		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, &mockContentModeration{}, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, logger)
 
 		req := &inputport.GetPendingTransferRequestsRequest{
 			ToUserID: receiver.ID,
@@ -636,7 +824,7 @@ func TestTransferRequestInteractor_GetPendingRequests(t *testing.T) {
 		trRepo.pendingByTo = []*entities.TransferRequest{tr}
 		trRepo.userRef = userRepo
 
-		itr := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, logger)
+		itr := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, &mockContentModeration{}, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, logger)
 
 		req := &inputport.GetPendingTransferRequestsRequest{
 			ToUserID: receiver.ID,
@@ -660,7 +848,7 @@ func TestTransferRequestInteractor_GetPendingRequestCount(t *testing.T) {
 
 		trRepo.pendingCount = 5
 
-		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, logger)
+		interactor := interactor.NewTransferRequestInteractor(trRepo, userRepo, ptPort, &mockContentModeration{}, &mockNotificationDispatcher{}, &mockTransferEligibility{}, &mockTransferRequestQuota{}, testsupport.NewMuteListRepository(), &mockBalanceHolder{}, logger)
 
 		req := &inputport.GetPendingRequestCountRequest{
 			ToUserID: uuid.New(),
@@ -672,3 +860,85 @@ func TestTransferRequestInteractor_GetPendingRequestCount(t *testing.T) {
 		assert.Equal(t, int64(5), resp.Count)
 	})
 }
+
+func TestTransferRequestInteractor_MutedSenders(t *testing.T) {
+	setup := func(t *testing.T) (*testsupport.Repositories, inputport.TransferRequestInputPort, *mockNotificationDispatcher, *entities.User, *entities.User) {
+		repos := testsupport.New()
+		notifications := &mockNotificationDispatcher{}
+		sut := interactor.NewTransferRequestInteractor(repos.TransferRequests, repos.Users, newMockPointTransferPort(), &mockContentModeration{},
+			notifications, &mockTransferEligibility{}, &mockTransferRequestQuota{}, repos.MuteList, &mockBalanceHolder{}, &mockTransferRequestLogger{})
+		sender := createTestUserWithBalance(t, "sender", 10000, entities.RoleUser)
+		receiver := createTestUserWithBalance(t, "receiver", 0, entities.RoleUser)
+		repos.Users.Seed(sender, receiver)
+		return repos, sut, notifications, sender, receiver
+	}
+	create := func(t *testing.T, sut inputport.TransferRequestInputPort, sender, receiver *entities.User) *entities.TransferRequest {
+		t.Helper()
+		resp, err := sut.CreateTransferRequest(context.Background(), &inputport.CreateTransferRequestRequest{
+			FromUserID: sender.ID, ToUserID: receiver.ID, Amount: 100, IdempotencyKey: uuid.NewString(),
+		})
+		require.NoError(t, err)
+		return resp.TransferRequest
+	}
+	pendingCount := func(t *testing.T, sut inputport.TransferRequestInputPort, receiver *entities.User) int64 {
+		t.Helper()
+		resp, err := sut.GetPendingRequestCount(context.Background(), &inputport.GetPendingRequestCountRequest{ToUserID: receiver.ID})
+		require.NoError(t, err)
+		return resp.Count
+	}
+
+	t.Run("rejectでミュートした送信者のリクエストは作成と同時に拒否し、通知しない", func(t *testing.T) {
+		_, sut, notifications, sender, receiver := setup(t)
+		_, err := sut.MuteSender(context.Background(), &inputport.MuteSenderRequest{UserID: receiver.ID, MutedUserID: sender.ID, Mode: entities.MuteModeReject})
+		require.NoError(t, err)
+
+		tr := create(t, sut, sender, receiver)
+		assert.Equal(t, entities.TransferRequestStatusRejected, tr.Status)
+		assert.Empty(t, notifications.notifications)
+		assert.Zero(t, pendingCount(t, sut, receiver))
+	})
+
+	t.Run("dropでミュートした送信者のリクエストは承認待ちのまま受取人に見せない", func(t *testing.T) {
+		_, sut, notifications, sender, receiver := setup(t)
+		create(t, sut, sender, receiver)
+		require.Len(t, notifications.notifications, 1)
+
+		_, err := sut.MuteSender(context.Background(), &inputport.MuteSenderRequest{UserID: receiver.ID, MutedUserID: sender.ID, Mode: entities.MuteModeDrop})
+		require.NoError(t, err)
+		tr := create(t, sut, sender, receiver)
+		assert.Equal(t, entities.TransferRequestStatusPending, tr.Status, "送信者には承認待ちに見える")
+		assert.Len(t, notifications.notifications, 1)
+
+		assert.Zero(t, pendingCount(t, sut, receiver), "ミュートする前のリクエストも一覧・件数から除く")
+		pending, err := sut.GetPendingRequests(context.Background(), &inputport.GetPendingTransferRequestsRequest{ToUserID: receiver.ID, Limit: 20})
+		require.NoError(t, err)
+		assert.Empty(t, pending.Requests)
+
+		require.NoError(t, sut.UnmuteSender(context.Background(), receiver.ID, sender.ID))
+		assert.Equal(t, int64(2), pendingCount(t, sut, receiver), "解除すると一覧に戻る")
+	})
+
+	t.Run("ミュートした送信者を一覧でき、modeを変えられる", func(t *testing.T) {
+		_, sut, _, sender, receiver := setup(t)
+		_, err := sut.MuteSender(context.Background(), &inputport.MuteSenderRequest{UserID: receiver.ID, MutedUserID: sender.ID, Mode: entities.MuteModeReject})
+		require.NoError(t, err)
+		_, err = sut.MuteSender(context.Background(), &inputport.MuteSenderRequest{UserID: receiver.ID, MutedUserID: sender.ID, Mode: entities.MuteModeDrop})
+		require.NoError(t, err)
+
+		mutes, err := sut.GetMutedSenders(context.Background(), receiver.ID)
+		require.NoError(t, err)
+		require.Len(t, mutes, 1)
+		assert.Equal(t, sender.ID, mutes[0].User.ID)
+		assert.Equal(t, entities.MuteModeDrop, mutes[0].Mode)
+	})
+
+	t.Run("自分自身・存在しないユーザー・不明なmodeはミュートできない", func(t *testing.T) {
+		_, sut, _, sender, receiver := setup(t)
+		_, err := sut.MuteSender(context.Background(), &inputport.MuteSenderRequest{UserID: receiver.ID, MutedUserID: receiver.ID, Mode: entities.MuteModeReject})
+		assert.ErrorIs(t, err, entities.ErrCannotMuteSelf)
+		_, err = sut.MuteSender(context.Background(), &inputport.MuteSenderRequest{UserID: receiver.ID, MutedUserID: uuid.New(), Mode: entities.MuteModeReject})
+		assert.ErrorIs(t, err, entities.ErrUserNotFound)
+		_, err = sut.MuteSender(context.Background(), &inputport.MuteSenderRequest{UserID: receiver.ID, MutedUserID: sender.ID, Mode: "block"})
+		assert.ErrorIs(t, err, entities.ErrInvalidMuteMode)
+	})
+}
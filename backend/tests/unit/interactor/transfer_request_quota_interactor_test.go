@@ -0,0 +1,170 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTransferRequestQuota は err を返す（nilなら上限なし）
+type mockTransferRequestQuota struct {
+	err error
+}
+
+func (m *mockTransferRequestQuota) CheckQuota(ctx context.Context, fromUserID, toUserID uuid.UUID) error {
+	return m.err
+}
+
+func TestTransferRequestQuotaInteractor(t *testing.T) {
+	setup := func(t *testing.T) (*mockSystemSettingsRepo, *ctxTrackingUserRepo, *testsupport.TransferRequestRepository, *interactor.TransferRequestQuotaInteractor, *entities.User) {
+		settingsRepo := newMockSystemSettingsRepo()
+		userRepo := newCtxTrackingUserRepo()
+		admin := createTestUserWithBalance(t, "admin", 0, "admin")
+		userRepo.setUser(admin)
+		trRepo := testsupport.New().TransferRequests
+		return settingsRepo, userRepo, trRepo, interactor.NewTransferRequestQuotaInteractor(settingsRepo, userRepo, trRepo, &mockLogger{}), admin
+	}
+	send := func(t *testing.T, trRepo *testsupport.TransferRequestRepository, from, to uuid.UUID) *entities.TransferRequest {
+		t.Helper()
+		tr, err := entities.NewTransferRequest(from, to, 100, "", uuid.NewString())
+		require.NoError(t, err)
+		require.NoError(t, trRepo.Create(context.Background(), tr))
+		return tr
+	}
+	update := func(t *testing.T, sut *interactor.TransferRequestQuotaInteractor, admin *entities.User, perSender, perRecipient int) {
+		t.Helper()
+		_, err := sut.UpdateQuota(context.Background(), &inputport.UpdateTransferRequestQuotaRequest{
+			AdminID: admin.ID, MaxPendingPerSender: perSender, MaxPendingPerRecipient: perRecipient,
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("未設定なら既定の上限で同じ受取人宛を制限する", func(t *testing.T) {
+		_, _, trRepo, sut, _ := setup(t)
+		sender, recipient := uuid.New(), uuid.New()
+		for range entities.DefaultMaxPendingRequestsPerRecipient {
+			require.NoError(t, sut.CheckQuota(context.Background(), sender, recipient))
+			send(t, trRepo, sender, recipient)
+		}
+
+		err := sut.CheckQuota(context.Background(), sender, recipient)
+		require.ErrorIs(t, err, entities.ErrRecipientRequestLimit)
+		de, ok := entities.AsDomainError(err)
+		require.True(t, ok)
+		assert.Equal(t, entities.DefaultMaxPendingRequestsPerRecipient, de.Params["max"])
+		assert.NoError(t, sut.CheckQuota(context.Background(), sender, uuid.New()), "ほかの受取人には送れる")
+	})
+
+	t.Run("送信者全体の上限に達すると誰にも送れない", func(t *testing.T) {
+		_, _, trRepo, sut, admin := setup(t)
+		update(t, sut, admin, 3, 0)
+		sender := uuid.New()
+		for range 3 {
+			send(t, trRepo, sender, uuid.New())
+		}
+
+		err := sut.CheckQuota(context.Background(), sender, uuid.New())
+		require.ErrorIs(t, err, entities.ErrPendingRequestLimit)
+		de, ok := entities.AsDomainError(err)
+		require.True(t, ok)
+		assert.Equal(t, 3, de.Params["max"])
+		assert.NoError(t, sut.CheckQuota(context.Background(), uuid.New(), uuid.New()), "ほかの送信者は送れる")
+	})
+
+	t.Run("応答済み・期限切れ・割り勘の1人分は数えない", func(t *testing.T) {
+		_, _, trRepo, sut, admin := setup(t)
+		update(t, sut, admin, 1, 1)
+		sender, recipient := uuid.New(), uuid.New()
+
+		rejected := send(t, trRepo, sender, recipient)
+		require.NoError(t, rejected.Reject())
+		require.NoError(t, trRepo.Update(context.Background(), rejected))
+		stale := send(t, trRepo, sender, recipient)
+		stale.ExpiresAt = time.Now().Add(-time.Minute) // 期限切れにするワーカーが動く前
+		require.NoError(t, trRepo.Update(context.Background(), stale))
+		share := send(t, trRepo, sender, recipient)
+		splitID := uuid.New()
+		share.SplitID = &splitID
+		require.NoError(t, trRepo.Update(context.Background(), share))
+
+		assert.NoError(t, sut.CheckQuota(context.Background(), sender, recipient))
+	})
+
+	t.Run("0にすると上限を無効にできる", func(t *testing.T) {
+		_, _, trRepo, sut, admin := setup(t)
+		update(t, sut, admin, 0, 0)
+		sender, recipient := uuid.New(), uuid.New()
+		for range entities.DefaultMaxPendingRequestsPerRecipient + 1 {
+			send(t, trRepo, sender, recipient)
+		}
+		assert.NoError(t, sut.CheckQuota(context.Background(), sender, recipient))
+	})
+
+	t.Run("設定を取得できる", func(t *testing.T) {
+		_, _, _, sut, admin := setup(t)
+		quota, err := sut.GetQuota(context.Background(), admin.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.DefaultMaxPendingRequestsPerSender, quota.MaxPendingPerSender)
+		assert.Nil(t, quota.UpdatedBy)
+
+		update(t, sut, admin, 10, 2)
+		quota, err = sut.GetQuota(context.Background(), admin.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 10, quota.MaxPendingPerSender)
+		assert.Equal(t, 2, quota.MaxPendingPerRecipient)
+		require.NotNil(t, quota.UpdatedBy)
+		assert.Equal(t, admin.ID, *quota.UpdatedBy)
+	})
+
+	t.Run("負の値や全体を超える受取人ごとの上限は設定できない", func(t *testing.T) {
+		settingsRepo, _, _, sut, admin := setup(t)
+		for _, q := range [][2]int{{-1, 0}, {0, -1}, {3, 4}} {
+			_, err := sut.UpdateQuota(context.Background(), &inputport.UpdateTransferRequestQuotaRequest{
+				AdminID: admin.ID, MaxPendingPerSender: q[0], MaxPendingPerRecipient: q[1],
+			})
+			assert.ErrorIs(t, err, entities.ErrInvalidRequestQuota, "%v", q)
+		}
+		assert.Empty(t, settingsRepo.settings)
+	})
+
+	t.Run("管理者以外は設定できない", func(t *testing.T) {
+		_, userRepo, _, sut, _ := setup(t)
+		user := createTestUserWithBalance(t, "user", 0, "user")
+		userRepo.setUser(user)
+
+		_, err := sut.GetQuota(context.Background(), user.ID)
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+		_, err = sut.UpdateQuota(context.Background(), &inputport.UpdateTransferRequestQuotaRequest{AdminID: user.ID, MaxPendingPerSender: 1})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
+
+func TestTransferRequestInteractor_Quota(t *testing.T) {
+	t.Run("上限に達した送信者は送金リクエストを作成できない", func(t *testing.T) {
+		repos := testsupport.New()
+		sender := createTestUserWithBalance(t, "sender", 10000, entities.RoleUser)
+		receiver := createTestUserWithBalance(t, "receiver", 0, entities.RoleUser)
+		repos.Users.Seed(sender, receiver)
+		limit := entities.ErrRecipientRequestLimit.WithParams(map[string]interface{}{"max": 5})
+		notifications := &mockNotificationDispatcher{}
+		sut := interactor.NewTransferRequestInteractor(repos.TransferRequests, repos.Users, newMockPointTransferPort(), &mockContentModeration{},
+			notifications, &mockTransferEligibility{}, &mockTransferRequestQuota{err: limit}, repos.MuteList, &mockBalanceHolder{}, &mockTransferRequestLogger{})
+
+		_, err := sut.CreateTransferRequest(context.Background(), &inputport.CreateTransferRequestRequest{
+			FromUserID: sender.ID, ToUserID: receiver.ID, Amount: 100, IdempotencyKey: "over-quota",
+		})
+		assert.ErrorIs(t, err, entities.ErrRecipientRequestLimit)
+		sent, err := repos.TransferRequests.ReadSentByFromUser(context.Background(), sender.ID, 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, sent)
+		assert.Empty(t, notifications.notifications)
+	})
+}
@@ -0,0 +1,193 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mock UserRepository（一括登録用） ---
+
+// importUserRepo は作成したユーザーを記録し、未登録のユーザー名・メールアドレスにErrUserNotFoundを返す
+type importUserRepo struct {
+	*ctxTrackingUserRepo
+	created    []*entities.User
+	failCreate string // このユーザー名の作成を失敗させる
+}
+
+func newImportUserRepo() *importUserRepo {
+	return &importUserRepo{ctxTrackingUserRepo: newCtxTrackingUserRepo()}
+}
+
+func (m *importUserRepo) Create(ctx context.Context, user *entities.User) error {
+	if user.Username == m.failCreate {
+		return errors.New("duplicate key value violates unique constraint")
+	}
+	m.created = append(m.created, user)
+	return nil
+}
+func (m *importUserRepo) ReadByUsername(ctx context.Context, username string) (*entities.User, error) {
+	if u, ok := m.usernameMap[username]; ok {
+		return u, nil
+	}
+	return nil, entities.ErrUserNotFound
+}
+func (m *importUserRepo) ReadByEmail(ctx context.Context, email string) (*entities.User, error) {
+	for _, u := range m.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, entities.ErrUserNotFound
+}
+
+// ========================================
+// UserImportInteractor テスト
+// ========================================
+
+func TestUserImportInteractor_ImportUsers(t *testing.T) {
+	setup := func() (inputport.UserImportInputPort, *importUserRepo, *ctxTrackingTransactionRepo, *mockEmailService, *entities.User) {
+		userRepo := newImportUserRepo()
+		admin := createTestUserWithBalance(t, "admin", 0, "admin")
+		userRepo.setUser(admin)
+		txRepo := newCtxTrackingTransactionRepo()
+		emailService := &mockEmailService{}
+		sut := interactor.NewUserImportInteractor(
+			&ctxTrackingTxManager{}, userRepo, txRepo, newCtxTrackingPointBatchRepo(),
+			&mockPasswordService{}, emailService, &mockLogger{},
+		)
+		return sut, userRepo, txRepo, emailService, admin
+	}
+
+	t.Run("ユーザーを登録し初期ポイントを付与する", func(t *testing.T) {
+		sut, userRepo, txRepo, _, admin := setup()
+		csv := "username,email,name,initial_points,role\n" +
+			"alice,alice@example.com,山田 花子,500,\n" +
+			"bob,bob@example.com,Bob,0,admin\n"
+
+		resp, err := sut.ImportUsers(context.Background(), &inputport.ImportUsersRequest{
+			AdminID: admin.ID, CSVData: []byte(csv),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, resp.CreatedCount)
+		assert.Equal(t, 0, resp.FailedCount)
+		require.Len(t, userRepo.created, 2)
+
+		alice := userRepo.created[0]
+		assert.Equal(t, "山田", alice.LastName)
+		assert.Equal(t, "花子", alice.FirstName)
+		assert.Equal(t, int64(500), alice.Balance)
+		assert.Equal(t, entities.RoleAdmin, userRepo.created[1].Role)
+
+		// 初期ポイントがある行だけ取引を作る
+		require.Len(t, txRepo.transactions, 1)
+		assert.Equal(t, entities.UserImportPointsTag, txRepo.transactions[0].Tag)
+
+		// 招待メールを送らない場合は仮パスワードを返す
+		require.NotNil(t, resp.Results[0].UserID)
+		assert.NotEmpty(t, resp.Results[0].TemporaryPassword)
+		assert.False(t, resp.Results[0].InvitationSent)
+	})
+
+	t.Run("招待メールを送った行は仮パスワードを返さない", func(t *testing.T) {
+		sut, _, _, emailService, admin := setup()
+		csv := "username,email,name,initial_points,role\ncarol,carol@example.com,Carol,,\n"
+
+		resp, err := sut.ImportUsers(context.Background(), &inputport.ImportUsersRequest{
+			AdminID: admin.ID, CSVData: []byte(csv), SendInvitations: true,
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.Results[0].InvitationSent)
+		assert.Empty(t, resp.Results[0].TemporaryPassword)
+		assert.Equal(t, []string{"carol@example.com"}, emailService.invitationAddrs)
+	})
+
+	t.Run("招待メールの送信に失敗しても登録は完了し仮パスワードを返す", func(t *testing.T) {
+		sut, _, _, emailService, admin := setup()
+		emailService.sendInvitationErr = errors.New("smtp down")
+		csv := "username,email,name,initial_points,role\ndave,dave@example.com,Dave,,\n"
+
+		resp, err := sut.ImportUsers(context.Background(), &inputport.ImportUsersRequest{
+			AdminID: admin.ID, CSVData: []byte(csv), SendInvitations: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.CreatedCount)
+		assert.False(t, resp.Results[0].InvitationSent)
+		assert.NotEmpty(t, resp.Results[0].TemporaryPassword)
+	})
+
+	t.Run("不正な行・重複した行は行ごとにエラーを返す", func(t *testing.T) {
+		sut, userRepo, _, _, admin := setup()
+		csv := "username,email,name,initial_points,role\n" +
+			"eve,eve@example.com,Eve,10,\n" +
+			"eve,eve2@example.com,Eve2,10,\n" + // ファイル内で重複
+			"admin,other@example.com,Admin,0,\n" + // 登録済みのユーザー名
+			"frank,not-an-email,Frank,0,\n" +
+			"grace,grace@example.com,Grace,-1,\n" +
+			"heidi,heidi@example.com,Heidi,0,owner\n"
+
+		resp, err := sut.ImportUsers(context.Background(), &inputport.ImportUsersRequest{
+			AdminID: admin.ID, CSVData: []byte(csv),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.CreatedCount)
+		assert.Equal(t, 5, resp.FailedCount)
+		require.Len(t, userRepo.created, 1)
+
+		assert.Equal(t, 2, resp.Results[0].Line)
+		assert.ErrorIs(t, resp.Results[1].Err, entities.ErrUsernameAlreadyExists)
+		assert.ErrorIs(t, resp.Results[2].Err, entities.ErrUsernameAlreadyExists)
+		assert.ErrorIs(t, resp.Results[3].Err, entities.ErrInvalidUserImportRow)
+		assert.ErrorIs(t, resp.Results[4].Err, entities.ErrInvalidUserImportRow)
+		assert.ErrorIs(t, resp.Results[5].Err, entities.ErrInvalidUserImportRow)
+	})
+
+	t.Run("まとまりの途中で失敗したら同じまとまりの行をすべて失敗にする", func(t *testing.T) {
+		sut, userRepo, _, _, admin := setup()
+		userRepo.failCreate = "user0001"
+
+		var b strings.Builder
+		b.WriteString("username,email,name,initial_points,role\n")
+		total := entities.UserImportChunkSize + 5
+		for n := 0; n < total; n++ {
+			fmt.Fprintf(&b, "user%04d,user%04d@example.com,User %d,0,\n", n, n, n)
+		}
+
+		resp, err := sut.ImportUsers(context.Background(), &inputport.ImportUsersRequest{
+			AdminID: admin.ID, CSVData: []byte(b.String()),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 5, resp.CreatedCount)
+		assert.Equal(t, entities.UserImportChunkSize, resp.FailedCount)
+		assert.ErrorIs(t, resp.Results[0].Err, entities.ErrUserImportChunkFailed)
+		assert.False(t, errors.Is(resp.Results[1].Err, entities.ErrUserImportChunkFailed), "失敗した行には元のエラーを返す")
+		assert.Nil(t, resp.Results[0].UserID)
+		assert.NotNil(t, resp.Results[total-1].UserID)
+	})
+
+	t.Run("ヘッダーが不正ならファイル全体をエラーにする", func(t *testing.T) {
+		sut, _, _, _, admin := setup()
+		_, err := sut.ImportUsers(context.Background(), &inputport.ImportUsersRequest{
+			AdminID: admin.ID, CSVData: []byte("username,email\nalice,alice@example.com\n"),
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidUserImportFile)
+	})
+
+	t.Run("管理者以外は実行できない", func(t *testing.T) {
+		sut, userRepo, _, _, _ := setup()
+		user := createTestUserWithBalance(t, "member", 0, "user")
+		userRepo.setUser(user)
+		_, err := sut.ImportUsers(context.Background(), &inputport.ImportUsersRequest{
+			AdminID: user.ID, CSVData: []byte("username,email,name,initial_points,role\n"),
+		})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
@@ -20,7 +20,7 @@ import (
 func TestUserQueryInteractor_GetUserByID(t *testing.T) {
 	t.Run("正常にユーザー情報を取得できる", func(t *testing.T) {
 		userRepo := newCtxTrackingUserRepo()
-		sut := interactor.NewUserQueryInteractor(userRepo, &mockLogger{})
+		sut := interactor.NewUserQueryInteractor(userRepo, &mockTransferEligibility{}, &mockLogger{})
 
 		user := createTestUserWithBalance(t, "queryuser", 5000, "user")
 		userRepo.setUser(user)
@@ -34,7 +34,7 @@ func TestUserQueryInteractor_GetUserByID(t *testing.T) {
 	})
 
 	t.Run("ユーザーが存在しない場合エラー", func(t *testing.T) {
-		sut := interactor.NewUserQueryInteractor(newCtxTrackingUserRepo(), &mockLogger{})
+		sut := interactor.NewUserQueryInteractor(newCtxTrackingUserRepo(), &mockTransferEligibility{}, &mockLogger{})
 
 		_, err := sut.GetUserByID(context.Background(), &inputport.GetUserByIDRequest{
 			UserID: uuid.New(),
@@ -49,7 +49,7 @@ func TestUserQueryInteractor_GetUserByID(t *testing.T) {
 func TestUserQueryInteractor_SearchUserByUsername(t *testing.T) {
 	t.Run("正常にユーザーを検索できる", func(t *testing.T) {
 		userRepo := newCtxTrackingUserRepo()
-		sut := interactor.NewUserQueryInteractor(userRepo, &mockLogger{})
+		sut := interactor.NewUserQueryInteractor(userRepo, &mockTransferEligibility{}, &mockLogger{})
 
 		user := createTestUserWithBalance(t, "searchable", 1000, "user")
 		userRepo.setUser(user)
@@ -62,7 +62,7 @@ func TestUserQueryInteractor_SearchUserByUsername(t *testing.T) {
 	})
 
 	t.Run("ユーザーが存在しない場合エラー", func(t *testing.T) {
-		sut := interactor.NewUserQueryInteractor(newCtxTrackingUserRepo(), &mockLogger{})
+		sut := interactor.NewUserQueryInteractor(newCtxTrackingUserRepo(), &mockTransferEligibility{}, &mockLogger{})
 
 		_, err := sut.SearchUserByUsername(context.Background(), &inputport.SearchUserByUsernameRequest{
 			Username: "nonexistent",
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"io"
+	"io/fs"
 	"testing"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
@@ -118,6 +120,14 @@ func (m *mockEmailVerificationRepo) ReadByToken(ctx context.Context, token strin
 	}
 	return t, nil
 }
+func (m *mockEmailVerificationRepo) ReadByOldEmailToken(ctx context.Context, token string) (*entities.EmailVerificationToken, error) {
+	for _, t := range m.tokens {
+		if t.OldEmailToken != nil && *t.OldEmailToken == token {
+			return t, nil
+		}
+	}
+	return nil, errors.New("token not found")
+}
 func (m *mockEmailVerificationRepo) Update(ctx context.Context, token *entities.EmailVerificationToken) error {
 	m.tokens[token.Token] = token
 	return nil
@@ -126,33 +136,78 @@ func (m *mockEmailVerificationRepo) DeleteByUserID(ctx context.Context, userID u
 	return nil
 }
 func (m *mockEmailVerificationRepo) DeleteExpired(ctx context.Context) error { return nil }
+func (m *mockEmailVerificationRepo) CountByUserIDSince(ctx context.Context, userID uuid.UUID, tokenType entities.TokenType, since time.Time) (int64, error) {
+	return 0, nil
+}
 
 // --- Mock UsernameChangeHistoryRepository ---
 
-type mockUsernameChangeHistoryRepo struct{}
+type mockUsernameChangeHistoryRepo struct {
+	histories []*entities.UsernameChangeHistory // 新しい順
+}
 
 func (m *mockUsernameChangeHistoryRepo) Create(ctx context.Context, history *entities.UsernameChangeHistory) error {
+	m.histories = append([]*entities.UsernameChangeHistory{history}, m.histories...)
 	return nil
 }
 func (m *mockUsernameChangeHistoryRepo) ReadListByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.UsernameChangeHistory, error) {
-	return nil, nil
+	result := make([]*entities.UsernameChangeHistory, 0)
+	for _, h := range m.histories {
+		if h.UserID == userID {
+			result = append(result, h)
+		}
+	}
+	if offset >= len(result) {
+		return nil, nil
+	}
+	if offset+limit < len(result) {
+		result = result[:offset+limit]
+	}
+	return result[offset:], nil
 }
 func (m *mockUsernameChangeHistoryRepo) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
-	return 0, nil
+	var count int64
+	for _, h := range m.histories {
+		if h.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
 }
 
 // --- Mock PasswordChangeHistoryRepository ---
 
-type mockPasswordChangeHistoryRepo struct{}
+type mockPasswordChangeHistoryRepo struct {
+	histories []*entities.PasswordChangeHistory // 新しい順
+}
 
 func (m *mockPasswordChangeHistoryRepo) Create(ctx context.Context, history *entities.PasswordChangeHistory) error {
+	m.histories = append([]*entities.PasswordChangeHistory{history}, m.histories...)
 	return nil
 }
 func (m *mockPasswordChangeHistoryRepo) ReadListByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.PasswordChangeHistory, error) {
-	return nil, nil
+	result := make([]*entities.PasswordChangeHistory, 0)
+	for _, h := range m.histories {
+		if h.UserID == userID {
+			result = append(result, h)
+		}
+	}
+	if offset >= len(result) {
+		return nil, nil
+	}
+	if offset+limit < len(result) {
+		result = result[:offset+limit]
+	}
+	return result[offset:], nil
 }
 func (m *mockPasswordChangeHistoryRepo) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
-	return 0, nil
+	var count int64
+	for _, h := range m.histories {
+		if h.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
 }
 
 // --- Mock FileStorageService ---
@@ -185,18 +240,49 @@ func (m *mockFileStorageService) GetAvatarURL(path string) string {
 	}
 	return "/uploads/" + path
 }
+func (m *mockFileStorageService) SaveTransferAttachment(userID, fileName string, file io.Reader, size int64) (string, error) {
+	if m.saveErr != nil {
+		return "", m.saveErr
+	}
+	return "attachments/" + userID + "/" + fileName, nil
+}
+func (m *mockFileStorageService) OpenTransferAttachment(path string) (io.ReadCloser, error) {
+	return nil, fs.ErrNotExist
+}
+func (m *mockFileStorageService) DeleteTransferAttachment(path string) error {
+	return m.deleteErr
+}
 
 // --- Mock EmailService ---
 
 type mockEmailService struct {
-	sendVerificationErr  error
-	sentVerificationAddr string
+	sendVerificationErr    error
+	sentVerificationAddr   string
+	sentVerificationToken  string
+	sentChangeConfirmAddr  string
+	sentChangeConfirmToken string
+	lockedNotifications    []string
+	newLoginAddrs          []string
+	notificationAddrs      []string
+	notificationBodies     []string
+	dataExportAddrs        []string
+	invitationAddrs        []string
+	sendInvitationErr      error
+	sendNotificationErr    error
+	sentEmails             []*entities.RenderedEmail
+	sentEmailAddrs         []string
 }
 
 func (m *mockEmailService) SendVerificationEmail(email, token string) error {
 	m.sentVerificationAddr = email
+	m.sentVerificationToken = token
 	return m.sendVerificationErr
 }
+func (m *mockEmailService) SendEmailChangeConfirmation(email, newEmail, token string) error {
+	m.sentChangeConfirmAddr = email
+	m.sentChangeConfirmToken = token
+	return nil
+}
 func (m *mockEmailService) SendPasswordChangeNotification(email string) error {
 	return nil
 }
@@ -206,6 +292,35 @@ func (m *mockEmailService) SendPasswordChangedNotification(email string) error {
 func (m *mockEmailService) SendAccountDeletedNotification(email string) error {
 	return nil
 }
+func (m *mockEmailService) SendAccountLockedNotification(email, unlockToken string, lockedUntil time.Time) error {
+	m.lockedNotifications = append(m.lockedNotifications, unlockToken)
+	return nil
+}
+func (m *mockEmailService) SendNewLoginNotification(email, ipAddress, userAgent, country string, loggedInAt time.Time) error {
+	m.newLoginAddrs = append(m.newLoginAddrs, email)
+	return nil
+}
+func (m *mockEmailService) SendNotificationEmail(email, subject, body string) error {
+	m.notificationAddrs = append(m.notificationAddrs, email)
+	m.notificationBodies = append(m.notificationBodies, body)
+	return m.sendNotificationErr
+}
+func (m *mockEmailService) SendDataExportReady(email, exportID string, expiresAt time.Time) error {
+	m.dataExportAddrs = append(m.dataExportAddrs, email)
+	return nil
+}
+func (m *mockEmailService) SendUserInvitation(email, username, temporaryPassword string) error {
+	if m.sendInvitationErr != nil {
+		return m.sendInvitationErr
+	}
+	m.invitationAddrs = append(m.invitationAddrs, email)
+	return nil
+}
+func (m *mockEmailService) SendEmail(email string, rendered *entities.RenderedEmail) error {
+	m.sentEmailAddrs = append(m.sentEmailAddrs, email)
+	m.sentEmails = append(m.sentEmails, rendered)
+	return nil
+}
 
 // ========================================
 // Tests
@@ -221,8 +336,9 @@ func TestUserSettingsInteractor_UpdateProfile(t *testing.T) {
 			&ctxTrackingTxManager{}, userRepo, settingsRepo,
 			&mockArchivedUserRepo{}, newMockEmailVerificationRepo(),
 			&mockUsernameChangeHistoryRepo{}, &mockPasswordChangeHistoryRepo{},
+			newCtxTrackingTransactionRepo(), newMockSystemSettingsRepo(),
 			&mockFileStorageService{}, &mockPasswordService{verifyOK: true},
-			&mockEmailService{}, &mockLogger{},
+			&mockEmailService{}, &mockContentModeration{}, &mockEarningEvents{}, &mockLogger{},
 		)
 		return userRepo, settingsRepo, sut
 	}
@@ -241,6 +357,33 @@ func TestUserSettingsInteractor_UpdateProfile(t *testing.T) {
 		assert.Equal(t, "New Display Name", resp.User.DisplayName)
 	})
 
+	t.Run("表示名がモデレーションで拒否された場合は更新しない", func(t *testing.T) {
+		userRepo := newCtxTrackingUserRepo()
+		moderation := &mockContentModeration{rejectFields: map[entities.ModerationField]bool{
+			entities.ModerationFieldDisplayName: true,
+		}}
+		sut := interactor.NewUserSettingsInteractor(
+			&ctxTrackingTxManager{}, userRepo, newMockUserSettingsRepo(),
+			&mockArchivedUserRepo{}, newMockEmailVerificationRepo(),
+			&mockUsernameChangeHistoryRepo{}, &mockPasswordChangeHistoryRepo{},
+			newCtxTrackingTransactionRepo(), newMockSystemSettingsRepo(),
+			&mockFileStorageService{}, &mockPasswordService{verifyOK: true},
+			&mockEmailService{}, moderation, &mockEarningEvents{}, &mockLogger{},
+		)
+		user := createTestUserWithBalance(t, "settingsuser", 1000, "user")
+		userRepo.setUser(user)
+		oldDisplayName := user.DisplayName
+
+		_, err := sut.UpdateProfile(context.Background(), &inputport.UpdateProfileRequest{
+			UserID: user.ID, DisplayName: "rejected", Email: user.Email,
+			FirstName: user.FirstName, LastName: user.LastName,
+		})
+		assert.ErrorIs(t, err, entities.ErrContentRejected)
+		assert.Equal(t, oldDisplayName, user.DisplayName)
+		// 変更していない氏名は判定しない
+		assert.Equal(t, []entities.ModerationField{entities.ModerationFieldDisplayName}, moderation.checked)
+	})
+
 	t.Run("ユーザーが存在しない場合エラー", func(t *testing.T) {
 		_, _, sut := setup()
 
@@ -275,8 +418,9 @@ func TestUserSettingsInteractor_UpdateUsername(t *testing.T) {
 			&ctxTrackingTxManager{}, userRepo, settingsRepo,
 			&mockArchivedUserRepo{}, newMockEmailVerificationRepo(),
 			&mockUsernameChangeHistoryRepo{}, &mockPasswordChangeHistoryRepo{},
+			newCtxTrackingTransactionRepo(), newMockSystemSettingsRepo(),
 			&mockFileStorageService{}, &mockPasswordService{verifyOK: true},
-			&mockEmailService{}, &mockLogger{},
+			&mockEmailService{}, &mockContentModeration{}, &mockEarningEvents{}, &mockLogger{},
 		)
 		return userRepo, settingsRepo, sut
 	}
@@ -316,8 +460,9 @@ func TestUserSettingsInteractor_ChangePassword(t *testing.T) {
 			&ctxTrackingTxManager{}, userRepo, newMockUserSettingsRepo(),
 			&mockArchivedUserRepo{}, newMockEmailVerificationRepo(),
 			&mockUsernameChangeHistoryRepo{}, &mockPasswordChangeHistoryRepo{},
+			newCtxTrackingTransactionRepo(), newMockSystemSettingsRepo(),
 			&mockFileStorageService{}, pwService,
-			&mockEmailService{}, &mockLogger{},
+			&mockEmailService{}, &mockContentModeration{}, &mockEarningEvents{}, &mockLogger{},
 		)
 		return userRepo, pwService, sut
 	}
@@ -357,8 +502,9 @@ func TestUserSettingsInteractor_UploadAvatar(t *testing.T) {
 			&ctxTrackingTxManager{}, userRepo, newMockUserSettingsRepo(),
 			&mockArchivedUserRepo{}, newMockEmailVerificationRepo(),
 			&mockUsernameChangeHistoryRepo{}, &mockPasswordChangeHistoryRepo{},
+			newCtxTrackingTransactionRepo(), newMockSystemSettingsRepo(),
 			fsService, &mockPasswordService{verifyOK: true},
-			&mockEmailService{}, &mockLogger{},
+			&mockEmailService{}, &mockContentModeration{}, &mockEarningEvents{}, &mockLogger{},
 		)
 		return userRepo, fsService, sut
 	}
@@ -376,6 +522,30 @@ func TestUserSettingsInteractor_UploadAvatar(t *testing.T) {
 		assert.NotEmpty(t, resp.AvatarURL)
 	})
 
+	t.Run("アバターでプロフィールがそろうとポイント獲得ルールに伝える", func(t *testing.T) {
+		userRepo := newCtxTrackingUserRepo()
+		earningEvents := &mockEarningEvents{}
+		sut := interactor.NewUserSettingsInteractor(
+			&ctxTrackingTxManager{}, userRepo, newMockUserSettingsRepo(),
+			&mockArchivedUserRepo{}, newMockEmailVerificationRepo(),
+			&mockUsernameChangeHistoryRepo{}, &mockPasswordChangeHistoryRepo{},
+			newCtxTrackingTransactionRepo(), newMockSystemSettingsRepo(),
+			&mockFileStorageService{}, &mockPasswordService{verifyOK: true},
+			&mockEmailService{}, &mockContentModeration{}, earningEvents, &mockLogger{},
+		)
+		user := createTestUserWithBalance(t, "avatar_user", 1000, "user")
+		userRepo.setUser(user)
+
+		_, err := sut.UploadAvatar(context.Background(), &inputport.UploadAvatarRequest{
+			UserID: user.ID, FileData: []byte("fake-image-data"),
+			FileName: "avatar.png", ContentType: "image/png",
+		})
+		require.NoError(t, err)
+		require.Len(t, earningEvents.events, 1)
+		assert.Equal(t, entities.EarningTriggerProfileCompleted, earningEvents.events[0].Trigger)
+		assert.Equal(t, user.ID, earningEvents.events[0].UserID)
+	})
+
 	t.Run("ファイル保存に失敗した場合エラー", func(t *testing.T) {
 		userRepo, fsService, sut := setup()
 		fsService.saveErr = errors.New("storage error")
@@ -400,8 +570,9 @@ func TestUserSettingsInteractor_DeleteAvatar(t *testing.T) {
 			&ctxTrackingTxManager{}, userRepo, newMockUserSettingsRepo(),
 			&mockArchivedUserRepo{}, newMockEmailVerificationRepo(),
 			&mockUsernameChangeHistoryRepo{}, &mockPasswordChangeHistoryRepo{},
+			newCtxTrackingTransactionRepo(), newMockSystemSettingsRepo(),
 			&mockFileStorageService{}, &mockPasswordService{verifyOK: true},
-			&mockEmailService{}, &mockLogger{},
+			&mockEmailService{}, &mockContentModeration{}, &mockEarningEvents{}, &mockLogger{},
 		)
 		return userRepo, sut
 	}
@@ -438,8 +609,9 @@ func TestUserSettingsInteractor_SendEmailVerification(t *testing.T) {
 			&ctxTrackingTxManager{}, newCtxTrackingUserRepo(), newMockUserSettingsRepo(),
 			&mockArchivedUserRepo{}, emailVerifRepo,
 			&mockUsernameChangeHistoryRepo{}, &mockPasswordChangeHistoryRepo{},
+			newCtxTrackingTransactionRepo(), newMockSystemSettingsRepo(),
 			&mockFileStorageService{}, &mockPasswordService{verifyOK: true},
-			emailService, &mockLogger{},
+			emailService, &mockContentModeration{}, &mockEarningEvents{}, &mockLogger{},
 		)
 		return emailService, emailVerifRepo, sut
 	}
@@ -466,26 +638,127 @@ func TestUserSettingsInteractor_SendEmailVerification(t *testing.T) {
 	})
 }
 
-// --- ArchiveAccount ---
+// --- EmailChange (新旧アドレスの二段階確認) ---
 
-func TestUserSettingsInteractor_ArchiveAccount(t *testing.T) {
-	setup := func() (*ctxTrackingUserRepo, *mockPasswordService, inputport.UserSettingsInputPort) {
+func TestUserSettingsInteractor_EmailChange(t *testing.T) {
+	setup := func(t *testing.T) (*inputport.UpdateProfileResponse, *mockEmailService, *mockEmailVerificationRepo, inputport.UserSettingsInputPort) {
 		userRepo := newCtxTrackingUserRepo()
-		pwService := &mockPasswordService{verifyOK: true}
+		emailService := &mockEmailService{}
+		emailVerifRepo := newMockEmailVerificationRepo()
 		sut := interactor.NewUserSettingsInteractor(
 			&ctxTrackingTxManager{}, userRepo, newMockUserSettingsRepo(),
+			&mockArchivedUserRepo{}, emailVerifRepo,
+			&mockUsernameChangeHistoryRepo{}, &mockPasswordChangeHistoryRepo{},
+			newCtxTrackingTransactionRepo(), newMockSystemSettingsRepo(),
+			&mockFileStorageService{}, &mockPasswordService{verifyOK: true},
+			emailService, &mockContentModeration{}, &mockEarningEvents{}, &mockLogger{},
+		)
+
+		user := createTestUserWithBalance(t, "email_changer", 1000, "user")
+		userRepo.setUser(user)
+
+		resp, err := sut.UpdateProfile(context.Background(), &inputport.UpdateProfileRequest{
+			UserID: user.ID, DisplayName: user.DisplayName,
+			Email: "new@example.com", FirstName: user.FirstName, LastName: user.LastName,
+		})
+		require.NoError(t, err)
+		require.True(t, resp.EmailVerificationSent)
+		assert.Equal(t, "new@example.com", resp.PendingEmail)
+
+		return resp, emailService, emailVerifRepo, sut
+	}
+
+	t.Run("確認完了まではメールアドレスを変更しない", func(t *testing.T) {
+		updateResp, emailService, _, _ := setup(t)
+
+		assert.Equal(t, "email_changer@example.com", updateResp.User.Email)
+		assert.Equal(t, "new@example.com", emailService.sentVerificationAddr)
+		assert.Equal(t, "email_changer@example.com", emailService.sentChangeConfirmAddr)
+		assert.NotEqual(t, emailService.sentVerificationToken, emailService.sentChangeConfirmToken)
+	})
+
+	t.Run("新アドレス→旧アドレスの順に確認すると適用される", func(t *testing.T) {
+		_, emailService, _, sut := setup(t)
+		ctx := context.Background()
+
+		resp, err := sut.VerifyEmail(ctx, &inputport.VerifyEmailRequest{Token: emailService.sentVerificationToken})
+		require.NoError(t, err)
+		assert.True(t, resp.AwaitingOldEmail)
+		assert.Equal(t, "email_changer@example.com", resp.User.Email)
+
+		resp, err = sut.VerifyEmail(ctx, &inputport.VerifyEmailRequest{Token: emailService.sentChangeConfirmToken})
+		require.NoError(t, err)
+		assert.False(t, resp.AwaitingOldEmail)
+		assert.Equal(t, "new@example.com", resp.User.Email)
+		assert.True(t, resp.User.EmailVerified)
+	})
+
+	t.Run("旧アドレス→新アドレスの順でも適用される", func(t *testing.T) {
+		_, emailService, _, sut := setup(t)
+		ctx := context.Background()
+
+		resp, err := sut.VerifyEmail(ctx, &inputport.VerifyEmailRequest{Token: emailService.sentChangeConfirmToken})
+		require.NoError(t, err)
+		assert.True(t, resp.AwaitingNewEmail)
+
+		resp, err = sut.VerifyEmail(ctx, &inputport.VerifyEmailRequest{Token: emailService.sentVerificationToken})
+		require.NoError(t, err)
+		assert.Equal(t, "new@example.com", resp.User.Email)
+	})
+
+	t.Run("旧アドレスの確認がタイムアウトした場合は新アドレスの再アクセスで適用", func(t *testing.T) {
+		_, emailService, emailVerifRepo, sut := setup(t)
+		ctx := context.Background()
+
+		resp, err := sut.VerifyEmail(ctx, &inputport.VerifyEmailRequest{Token: emailService.sentVerificationToken})
+		require.NoError(t, err)
+		require.True(t, resp.AwaitingOldEmail)
+
+		token := emailVerifRepo.tokens[emailService.sentVerificationToken]
+		token.CreatedAt = time.Now().Add(-entities.EmailChangeOldConfirmTimeout - time.Minute)
+
+		resp, err = sut.VerifyEmail(ctx, &inputport.VerifyEmailRequest{Token: emailService.sentVerificationToken})
+		require.NoError(t, err)
+		assert.False(t, resp.AwaitingOldEmail)
+		assert.Equal(t, "new@example.com", resp.User.Email)
+
+		// 適用後は再利用不可
+		_, err = sut.VerifyEmail(ctx, &inputport.VerifyEmailRequest{Token: emailService.sentChangeConfirmToken})
+		assert.Error(t, err)
+	})
+}
+
+// --- ArchiveAccount ---
+
+func TestUserSettingsInteractor_ArchiveAccount(t *testing.T) {
+	type deps struct {
+		userRepo     *ctxTrackingUserRepo
+		txRepo       *ctxTrackingTransactionRepo
+		settingsRepo *mockSystemSettingsRepo
+		pwService    *mockPasswordService
+	}
+	setup := func() (*deps, inputport.UserSettingsInputPort) {
+		d := &deps{
+			userRepo:     newCtxTrackingUserRepo(),
+			txRepo:       newCtxTrackingTransactionRepo(),
+			settingsRepo: newMockSystemSettingsRepo(),
+			pwService:    &mockPasswordService{verifyOK: true},
+		}
+		sut := interactor.NewUserSettingsInteractor(
+			&ctxTrackingTxManager{}, d.userRepo, newMockUserSettingsRepo(),
 			&mockArchivedUserRepo{}, newMockEmailVerificationRepo(),
 			&mockUsernameChangeHistoryRepo{}, &mockPasswordChangeHistoryRepo{},
-			&mockFileStorageService{}, pwService,
-			&mockEmailService{}, &mockLogger{},
+			d.txRepo, d.settingsRepo,
+			&mockFileStorageService{}, d.pwService,
+			&mockEmailService{}, &mockContentModeration{}, &mockEarningEvents{}, &mockLogger{},
 		)
-		return userRepo, pwService, sut
+		return d, sut
 	}
 
 	t.Run("正常にアカウントを削除できる", func(t *testing.T) {
-		userRepo, _, sut := setup()
+		d, sut := setup()
 		user := createTestUserWithBalance(t, "archive_me", 1000, "user")
-		userRepo.setUser(user)
+		d.userRepo.setUser(user)
 
 		err := sut.ArchiveAccount(context.Background(), &inputport.ArchiveAccountRequest{
 			UserID: user.ID, Password: "password123",
@@ -493,17 +766,47 @@ func TestUserSettingsInteractor_ArchiveAccount(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("既定では相手側の取引履歴を匿名化しメモも消す", func(t *testing.T) {
+		d, sut := setup()
+		user := createTestUserWithBalance(t, "archive_me", 1000, "user")
+		d.userRepo.setUser(user)
+
+		err := sut.ArchiveAccount(context.Background(), &inputport.ArchiveAccountRequest{
+			UserID: user.ID, Password: "password123",
+		})
+		require.NoError(t, err)
+		scrubMemos, ok := d.txRepo.anonymized[user.ID]
+		assert.True(t, ok)
+		assert.True(t, scrubMemos)
+	})
+
+	t.Run("retain設定ではメモを残す", func(t *testing.T) {
+		d, sut := setup()
+		d.settingsRepo.settings[entities.AccountErasureModeSettingKey] = string(entities.AccountErasureModeRetain)
+		user := createTestUserWithBalance(t, "archive_me", 1000, "user")
+		d.userRepo.setUser(user)
+
+		err := sut.ArchiveAccount(context.Background(), &inputport.ArchiveAccountRequest{
+			UserID: user.ID, Password: "password123",
+		})
+		require.NoError(t, err)
+		scrubMemos, ok := d.txRepo.anonymized[user.ID]
+		assert.True(t, ok)
+		assert.False(t, scrubMemos)
+	})
+
 	t.Run("パスワードが不正な場合エラー", func(t *testing.T) {
-		userRepo, pwService, sut := setup()
-		pwService.verifyOK = false
+		d, sut := setup()
+		d.pwService.verifyOK = false
 		user := createTestUserWithBalance(t, "archive_me", 1000, "user")
-		userRepo.setUser(user)
+		d.userRepo.setUser(user)
 
 		err := sut.ArchiveAccount(context.Background(), &inputport.ArchiveAccountRequest{
 			UserID: user.ID, Password: "wrong",
 		})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "password is incorrect")
+		assert.Empty(t, d.txRepo.anonymized)
 	})
 }
 
@@ -516,8 +819,9 @@ func TestUserSettingsInteractor_GetProfile(t *testing.T) {
 			&ctxTrackingTxManager{}, userRepo, newMockUserSettingsRepo(),
 			&mockArchivedUserRepo{}, newMockEmailVerificationRepo(),
 			&mockUsernameChangeHistoryRepo{}, &mockPasswordChangeHistoryRepo{},
+			newCtxTrackingTransactionRepo(), newMockSystemSettingsRepo(),
 			&mockFileStorageService{}, &mockPasswordService{verifyOK: true},
-			&mockEmailService{}, &mockLogger{},
+			&mockEmailService{}, &mockContentModeration{}, &mockEarningEvents{}, &mockLogger{},
 		)
 		return userRepo, sut
 	}
@@ -0,0 +1,158 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockUserTierRepo はユーザーモックのランク列を直接書き換える UserTierRepository のモック
+type mockUserTierRepo struct {
+	users      *ctxTrackingUserRepo
+	activities map[uuid.UUID]*entities.TierActivity
+}
+
+func newMockUserTierRepo(users *ctxTrackingUserRepo) *mockUserTierRepo {
+	return &mockUserTierRepo{users: users, activities: make(map[uuid.UUID]*entities.TierActivity)}
+}
+
+func (m *mockUserTierRepo) setActivity(userID uuid.UUID, earned int64, streak int) {
+	m.activities[userID] = &entities.TierActivity{UserID: userID, EarnedPoints: earned, CheckInStreak: streak}
+}
+
+func (m *mockUserTierRepo) ReadActivities(ctx context.Context, earnedSince, streakAsOf time.Time) ([]*entities.TierActivity, error) {
+	result := make([]*entities.TierActivity, 0)
+	for id := range m.users.users {
+		a, err := m.ReadActivity(ctx, id, earnedSince, streakAsOf)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, a)
+	}
+	return result, nil
+}
+func (m *mockUserTierRepo) ReadActivity(ctx context.Context, userID uuid.UUID, earnedSince, streakAsOf time.Time) (*entities.TierActivity, error) {
+	if a, ok := m.activities[userID]; ok {
+		return a, nil
+	}
+	return &entities.TierActivity{UserID: userID}, nil
+}
+func (m *mockUserTierRepo) UpdateComputedTier(ctx context.Context, userID uuid.UUID, tier entities.UserTier, at time.Time) (bool, error) {
+	u, ok := m.users.users[userID]
+	if !ok || u.TierOverridden || u.CurrentTier() == tier {
+		return false, nil
+	}
+	u.Tier = tier
+	u.TierUpdatedAt = &at
+	return true, nil
+}
+func (m *mockUserTierRepo) SetOverride(ctx context.Context, userID uuid.UUID, tier *entities.UserTier, at time.Time) error {
+	u, ok := m.users.users[userID]
+	if !ok {
+		return entities.ErrUserNotFound
+	}
+	u.TierOverridden = tier != nil
+	if tier != nil {
+		u.Tier = *tier
+		u.TierUpdatedAt = &at
+	}
+	return nil
+}
+
+type userTierDeps struct {
+	tierRepo *mockUserTierRepo
+	userRepo *ctxTrackingUserRepo
+	admin    *entities.User
+	user     *entities.User
+}
+
+func setupUserTierInteractor(t *testing.T) (*userTierDeps, inputport.UserTierInputPort) {
+	userRepo := newCtxTrackingUserRepo()
+	d := &userTierDeps{
+		tierRepo: newMockUserTierRepo(userRepo),
+		userRepo: userRepo,
+		admin:    createTestUserWithBalance(t, "tier_admin", 0, "admin"),
+		user:     createTestUserWithBalance(t, "tier_user", 0, "user"),
+	}
+	userRepo.setUser(d.admin)
+	userRepo.setUser(d.user)
+	sut := interactor.NewUserTierInteractor(d.tierRepo, userRepo, newMockSystemSettingsRepo(), &mockLogger{})
+	return d, sut
+}
+
+// --- RecalculateTiers ---
+
+func TestUserTierInteractor_RecalculateTiers(t *testing.T) {
+	t.Run("利用状況からランクを判定し、変わったユーザー数を返す", func(t *testing.T) {
+		d, sut := setupUserTierInteractor(t)
+		d.tierRepo.setActivity(d.user.ID, 1200, 0)
+
+		changed, err := sut.RecalculateTiers(context.Background(), time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 1, changed)
+		assert.Equal(t, entities.TierSilver, d.user.Tier)
+		assert.Equal(t, entities.TierBronze, d.admin.CurrentTier())
+
+		// 2回目は変化なし
+		changed, err = sut.RecalculateTiers(context.Background(), time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 0, changed)
+	})
+
+	t.Run("管理者が固定したランクは上書きしない", func(t *testing.T) {
+		d, sut := setupUserTierInteractor(t)
+		_, err := sut.OverrideTier(context.Background(), &inputport.OverrideTierRequest{
+			AdminID: d.admin.ID, UserID: d.user.ID, Tier: entities.TierGold,
+		})
+		require.NoError(t, err)
+
+		_, err = sut.RecalculateTiers(context.Background(), time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, entities.TierGold, d.user.Tier)
+	})
+}
+
+// --- OverrideTier / ClearTierOverride ---
+
+func TestUserTierInteractor_OverrideTier(t *testing.T) {
+	t.Run("一般ユーザーは固定できない", func(t *testing.T) {
+		d, sut := setupUserTierInteractor(t)
+
+		_, err := sut.OverrideTier(context.Background(), &inputport.OverrideTierRequest{
+			AdminID: d.user.ID, UserID: d.user.ID, Tier: entities.TierGold,
+		})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+
+	t.Run("不明なランクはエラー", func(t *testing.T) {
+		d, sut := setupUserTierInteractor(t)
+
+		_, err := sut.OverrideTier(context.Background(), &inputport.OverrideTierRequest{
+			AdminID: d.admin.ID, UserID: d.user.ID, Tier: "platinum",
+		})
+		assert.ErrorIs(t, err, entities.ErrInvalidUserTier)
+	})
+
+	t.Run("固定を解除するとその場で判定し直す", func(t *testing.T) {
+		d, sut := setupUserTierInteractor(t)
+		d.tierRepo.setActivity(d.user.ID, 0, 6)
+		_, err := sut.OverrideTier(context.Background(), &inputport.OverrideTierRequest{
+			AdminID: d.admin.ID, UserID: d.user.ID, Tier: entities.TierGold,
+		})
+		require.NoError(t, err)
+
+		user, err := sut.ClearTierOverride(context.Background(), &inputport.ClearTierOverrideRequest{
+			AdminID: d.admin.ID, UserID: d.user.ID,
+		})
+		require.NoError(t, err)
+		assert.False(t, user.TierOverridden)
+		assert.Equal(t, entities.TierSilver, user.Tier)
+	})
+}
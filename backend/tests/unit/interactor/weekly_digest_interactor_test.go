@@ -0,0 +1,133 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeeklyDigestInteractor(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	type fixture struct {
+		repos *testsupport.Repositories
+		email *mockEmailService
+		sut   *interactor.WeeklyDigestInteractor
+		admin *entities.User
+		alice *entities.User
+		bob   *entities.User
+	}
+	setup := func(t *testing.T) *fixture {
+		f := &fixture{repos: testsupport.New(), email: &mockEmailService{}}
+		f.admin = createTestUserWithBalance(t, "admin", 0, entities.RoleAdmin)
+		f.alice = createTestUserWithBalance(t, "alice", 800, entities.RoleUser)
+		f.bob = createTestUserWithBalance(t, "bob", 0, entities.RoleUser)
+		f.repos.Users.Seed(f.admin, f.alice, f.bob)
+		f.sut = interactor.NewWeeklyDigestInteractor(f.repos.WeeklyDigests, f.repos.Users, f.repos.PointBatches,
+			f.repos.TransferRequests, f.repos.Notifications, f.email, &mockLogger{})
+		return f
+	}
+	record := func(t *testing.T, f *fixture, tx *entities.Transaction, createdAt time.Time) {
+		tx.Status = entities.TransactionStatusCompleted
+		tx.CreatedAt = createdAt
+		require.NoError(t, f.repos.Transactions.Create(ctx, tx))
+	}
+
+	t.Run("直近1週間の増減をまとめて送り、内容がないユーザーには送らない", func(t *testing.T) {
+		f := setup(t)
+		transfer, err := entities.NewTransfer(f.alice.ID, f.bob.ID, 200, "tx-1", "")
+		require.NoError(t, err)
+		record(t, f, transfer, now.Add(-24*time.Hour))
+		grant, err := entities.NewAdminGrant(f.alice.ID, 1000, "", f.admin.ID)
+		require.NoError(t, err)
+		record(t, f, grant, now.Add(-2*24*time.Hour))
+		old, err := entities.NewAdminGrant(f.alice.ID, 5000, "", f.admin.ID)
+		require.NoError(t, err)
+		record(t, f, old, now.Add(-8*24*time.Hour))
+		expired, err := entities.NewSystemExpire(f.alice.ID, 50, "", nil)
+		require.NoError(t, err)
+		record(t, f, expired, now.Add(-time.Hour))
+
+		sent, err := f.sut.SendWeeklyDigests(ctx, now)
+		require.NoError(t, err)
+
+		// 管理者は残高も増減もないので送らない
+		assert.Equal(t, int64(2), sent)
+		assert.ElementsMatch(t, []string{f.alice.Email, f.bob.Email}, f.email.notificationAddrs)
+		for n, addr := range f.email.notificationAddrs {
+			if addr == f.alice.Email {
+				body := f.email.notificationBodies[n]
+				assert.Contains(t, body, "獲得したポイント: 1000ポイント", "1週間より前の取引は含めない")
+				assert.Contains(t, body, "使ったポイント: 200ポイント", "失効は使ったポイントに含めない")
+			}
+		}
+	})
+
+	t.Run("通知設定で止めたユーザーには送らない", func(t *testing.T) {
+		f := setup(t)
+		prefs := entities.DefaultNotificationPreferences(f.alice.ID)
+		prefs.WeeklyDigest = false
+		require.NoError(t, f.repos.Notifications.SavePreferences(ctx, prefs))
+		f.bob.Balance = 10
+		_, err := f.repos.Users.Update(ctx, f.bob)
+		require.NoError(t, err)
+
+		sent, err := f.sut.SendWeeklyDigests(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), sent)
+		assert.Equal(t, []string{f.bob.Email}, f.email.notificationAddrs)
+	})
+
+	t.Run("メールの送信が止められたら残りのユーザーには送らない", func(t *testing.T) {
+		f := setup(t)
+		f.bob.Balance = 10
+		_, err := f.repos.Users.Update(ctx, f.bob)
+		require.NoError(t, err)
+		f.email.sendNotificationErr = fmt.Errorf("email: %w", service.ErrCircuitOpen)
+
+		sent, err := f.sut.SendWeeklyDigests(ctx, now)
+		assert.ErrorIs(t, err, service.ErrCircuitOpen)
+		assert.Zero(t, sent)
+		assert.Len(t, f.email.notificationAddrs, 1, "最初の失敗で打ち切る")
+	})
+
+	t.Run("送信対象を読めなければエラー", func(t *testing.T) {
+		f := setup(t)
+		f.repos.WeeklyDigests.FailOn("ReadRecipientIDs", errors.New("db down"))
+		_, err := f.sut.SendWeeklyDigests(ctx, now)
+		assert.Error(t, err)
+	})
+
+	t.Run("プレビューは送らずに本文と送信対象かどうかを返す", func(t *testing.T) {
+		f := setup(t)
+		batch := entities.NewPointBatch(f.alice.ID, 300, entities.PointBatchSourceAdminGrant, nil, now)
+		batch.ExpiresAt = now.Add(3 * 24 * time.Hour)
+		require.NoError(t, f.repos.PointBatches.Create(ctx, batch))
+		prefs := entities.DefaultNotificationPreferences(f.alice.ID)
+		prefs.EmailEnabled = false
+		require.NoError(t, f.repos.Notifications.SavePreferences(ctx, prefs))
+
+		preview, err := f.sut.PreviewWeeklyDigest(ctx, &inputport.PreviewWeeklyDigestRequest{AdminID: f.admin.ID, UserID: f.alice.ID})
+		require.NoError(t, err)
+		assert.Equal(t, int64(800), preview.Digest.Balance)
+		assert.Equal(t, int64(300), preview.Digest.ExpiringAmount())
+		assert.Contains(t, preview.Email.Body, "合計300ポイント")
+		assert.False(t, preview.OptedIn, "メールを止めているので送信対象ではない")
+		assert.False(t, preview.WouldSend)
+		assert.Empty(t, f.email.notificationAddrs)
+
+		_, err = f.sut.PreviewWeeklyDigest(ctx, &inputport.PreviewWeeklyDigestRequest{AdminID: f.bob.ID, UserID: f.alice.ID})
+		assert.ErrorIs(t, err, entities.ErrAdminRequired)
+	})
+}
@@ -0,0 +1,129 @@
+package interactor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockWorkerLeaseRepo はDBの条件付きUPSERTと同じ判定でリーダーを決める
+type mockWorkerLeaseRepo struct {
+	leases map[string]*entities.WorkerLease
+}
+
+func newMockWorkerLeaseRepo() *mockWorkerLeaseRepo {
+	return &mockWorkerLeaseRepo{leases: make(map[string]*entities.WorkerLease)}
+}
+
+func (m *mockWorkerLeaseRepo) TryAcquire(ctx context.Context, name, holder string, now, expiresAt time.Time) (bool, error) {
+	l, ok := m.leases[name]
+	if !ok {
+		m.leases[name] = &entities.WorkerLease{Name: name, Holder: holder, AcquiredAt: now, RenewedAt: now, ExpiresAt: expiresAt, LeaderChanges: 1}
+		return true, nil
+	}
+	if l.Holder != holder && l.IsHeld(now) {
+		return false, nil
+	}
+	if l.Holder != holder {
+		l.Holder = holder
+		l.AcquiredAt = now
+		l.LeaderChanges++
+	}
+	l.RenewedAt = now
+	l.ExpiresAt = expiresAt
+	return true, nil
+}
+
+func (m *mockWorkerLeaseRepo) Release(ctx context.Context, name, holder string, now time.Time) error {
+	if l, ok := m.leases[name]; ok && l.Holder == holder && l.IsHeld(now) {
+		l.ExpiresAt = now
+	}
+	return nil
+}
+
+func (m *mockWorkerLeaseRepo) ReadList(ctx context.Context) ([]*entities.WorkerLease, error) {
+	list := make([]*entities.WorkerLease, 0, len(m.leases))
+	for _, l := range m.leases {
+		list = append(list, l)
+	}
+	return list, nil
+}
+
+func TestWorkerLeaseInteractor_Campaign(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	t.Run("リーダーは1インスタンスだけで、更新が途絶えると期限後に引き継がれる", func(t *testing.T) {
+		repo := newMockWorkerLeaseRepo()
+		sut := interactor.NewWorkerLeaseInteractor(repo, newCtxTrackingUserRepo(), &mockLogger{})
+
+		ok, err := sut.Campaign(ctx, "point_expiry", "host-a:1", now)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.True(t, now.Add(entities.WorkerLeaseTTL).Equal(repo.leases["point_expiry"].ExpiresAt))
+
+		ok, err = sut.Campaign(ctx, "point_expiry", "host-b:1", now.Add(entities.WorkerLeaseRenewInterval))
+		require.NoError(t, err)
+		assert.False(t, ok, "期限内は他のインスタンスがリーダーになれない")
+
+		ok, err = sut.Campaign(ctx, "point_expiry", "host-a:1", now.Add(entities.WorkerLeaseRenewInterval))
+		require.NoError(t, err)
+		assert.True(t, ok, "リーダーは期限を延ばせる")
+
+		failover := now.Add(entities.WorkerLeaseRenewInterval + entities.WorkerLeaseTTL)
+		ok, err = sut.Campaign(ctx, "point_expiry", "host-b:1", failover)
+		require.NoError(t, err)
+		assert.True(t, ok, "期限が切れたら引き継ぐ")
+
+		lease := repo.leases["point_expiry"]
+		assert.Equal(t, "host-b:1", lease.Holder)
+		assert.Equal(t, int64(2), lease.LeaderChanges)
+		assert.True(t, failover.Equal(lease.AcquiredAt))
+	})
+
+	t.Run("降りたリーダーはすぐに引き継がれる", func(t *testing.T) {
+		repo := newMockWorkerLeaseRepo()
+		sut := interactor.NewWorkerLeaseInteractor(repo, newCtxTrackingUserRepo(), &mockLogger{})
+		start := time.Now()
+
+		_, err := sut.Campaign(ctx, "akerun", "host-a:1", start)
+		require.NoError(t, err)
+		require.NoError(t, sut.Resign(ctx, "akerun", "host-b:1"), "リーダーでないインスタンスは降りられない")
+		ok, err := sut.Campaign(ctx, "akerun", "host-b:1", time.Now())
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		require.NoError(t, sut.Resign(ctx, "akerun", "host-a:1"))
+		ok, err = sut.Campaign(ctx, "akerun", "host-b:1", time.Now())
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+}
+
+func TestWorkerLeaseInteractor_ListLeases(t *testing.T) {
+	ctx := context.Background()
+	userRepo := newCtxTrackingUserRepo()
+	admin := &entities.User{ID: uuid.New(), Username: "admin", Role: "admin"}
+	member := &entities.User{ID: uuid.New(), Username: "member", Role: "user"}
+	userRepo.setUser(admin)
+	userRepo.setUser(member)
+
+	repo := newMockWorkerLeaseRepo()
+	sut := interactor.NewWorkerLeaseInteractor(repo, userRepo, &mockLogger{})
+	_, err := sut.Campaign(ctx, "akerun", "host-a:1", time.Now())
+	require.NoError(t, err)
+
+	leases, err := sut.ListLeases(ctx, admin.ID)
+	require.NoError(t, err)
+	require.Len(t, leases, 1)
+	assert.Equal(t, "host-a:1", leases[0].Holder)
+
+	_, err = sut.ListLeases(ctx, member.ID)
+	assert.ErrorIs(t, err, entities.ErrAdminRequired)
+}
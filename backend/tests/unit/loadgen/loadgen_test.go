@@ -0,0 +1,82 @@
+package loadgen_test
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/pkg/loadgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var operations = []string{"register", "transfer", "request", "qr"}
+
+func TestParseMix(t *testing.T) {
+	t.Run("重みの比で操作を選ぶ", func(t *testing.T) {
+		mix, err := loadgen.ParseMix("transfer=3, qr=1", operations)
+		require.NoError(t, err)
+		assert.Equal(t, "transfer=3,qr=1", mix.String())
+		assert.InDelta(t, 0.75, mix.Share("transfer"), 1e-9)
+		assert.Zero(t, mix.Share("register"))
+
+		r := rand.New(rand.NewPCG(1, 2))
+		counts := map[string]int{}
+		for range 4000 {
+			counts[mix.Pick(r)]++
+		}
+		assert.Len(t, counts, 2, "配分にない操作は選ばない")
+		assert.InDelta(t, 3000, counts["transfer"], 150)
+	})
+
+	for _, tc := range []struct {
+		name string
+		mix  string
+	}{
+		{name: "知らない操作", mix: "transfer=1,refund=1"},
+		{name: "重みが0", mix: "transfer=0"},
+		{name: "重みが数でない", mix: "transfer=many"},
+		{name: "=がない", mix: "transfer"},
+		{name: "同じ操作を2回", mix: "qr=1,qr=2"},
+		{name: "空", mix: " , "},
+	} {
+		t.Run("不正な配分はエラー: "+tc.name, func(t *testing.T) {
+			_, err := loadgen.ParseMix(tc.mix, operations)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	t.Run("操作ごとに結果とパーセンタイルを集計する", func(t *testing.T) {
+		rec := loadgen.NewRecorder()
+		for i := 1; i <= 100; i++ {
+			rec.Record("transfer", time.Duration(i)*time.Millisecond, loadgen.OutcomeOK, "")
+		}
+		rec.Record("qr.scan", 5*time.Millisecond, loadgen.OutcomeRejected, "insufficient_balance")
+		rec.Record("qr.scan", 7*time.Millisecond, loadgen.OutcomeFailed, "connection_error")
+
+		stats := rec.Stats()
+		require.Len(t, stats, 2)
+		assert.Equal(t, "qr.scan", stats[0].Op, "操作の名前順")
+		assert.Equal(t, 1, stats[0].Rejected)
+		assert.Equal(t, 1, stats[0].Failed)
+		assert.Equal(t, map[string]int{"insufficient_balance": 1, "connection_error": 1}, stats[0].Reasons)
+
+		transfer := stats[1]
+		assert.Equal(t, 100, transfer.Count)
+		assert.Equal(t, 100, transfer.OK)
+		assert.Equal(t, 50*time.Millisecond, transfer.P50)
+		assert.Equal(t, 90*time.Millisecond, transfer.P90)
+		assert.Equal(t, 99*time.Millisecond, transfer.P99)
+		assert.Equal(t, 100*time.Millisecond, transfer.Max)
+	})
+
+	t.Run("パーセンタイルはnearest-rankで、空なら0", func(t *testing.T) {
+		sorted := []time.Duration{10, 20, 30}
+		assert.Equal(t, time.Duration(10), loadgen.Percentile(sorted, 0))
+		assert.Equal(t, time.Duration(20), loadgen.Percentile(sorted, 50))
+		assert.Equal(t, time.Duration(30), loadgen.Percentile(sorted, 99))
+		assert.Zero(t, loadgen.Percentile(nil, 50))
+	})
+}
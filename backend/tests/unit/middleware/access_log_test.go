@@ -0,0 +1,173 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/frameworks/web/middleware"
+	"github.com/gity/point-system/gateways/infra/infralogger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUserID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+
+// newEngine はアクセスログをJSONでbufに書くエンジンを作成する
+func newEngine(cfg middleware.AccessLogConfig, buf *bytes.Buffer) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(middleware.NewAccessLogMiddleware(infralogger.NewJSONLogger(buf), cfg).Handle())
+
+	api := engine.Group("/api/v1")
+	api.POST("/auth/login", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"user":       gin.H{"username": "taro", "email": "taro@example.com"},
+			"csrf_token": "csrf-secret",
+		})
+	})
+	api.GET("/points/balance", func(c *gin.Context) {
+		c.Set("user_id", testUserID)
+		c.JSON(http.StatusOK, gin.H{"balance": 100})
+	})
+	api.GET("/points/fail", func(c *gin.Context) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "boom"})
+	})
+	return engine
+}
+
+// entries はbufに書かれたJSONのログを1行ずつ読む
+func entries(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var result []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		result = append(result, entry)
+	}
+	return result
+}
+
+func TestAccessLogMiddleware(t *testing.T) {
+	t.Run("メソッド・パス・ステータス・ユーザーIDを記録する", func(t *testing.T) {
+		var buf bytes.Buffer
+		engine := newEngine(middleware.AccessLogConfig{Enabled: true, MaxBodyBytes: 4096}, &buf)
+
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/points/balance", nil))
+
+		logs := entries(t, &buf)
+		require.Len(t, logs, 1)
+		entry := logs[0]
+		assert.Equal(t, "INFO", entry["level"])
+		assert.Equal(t, "http_request", entry["msg"])
+		assert.Equal(t, "GET", entry["method"])
+		assert.Equal(t, "/api/v1/points/balance", entry["path"])
+		assert.Equal(t, "/api/v1/points/balance", entry["route"])
+		assert.Equal(t, float64(200), entry["status"])
+		assert.Equal(t, testUserID.String(), entry["user_id"])
+		assert.Contains(t, entry, "latency_ms")
+		assert.NotContains(t, entry, "request_body", "ボディは設定したルートのみ記録する")
+	})
+
+	t.Run("設定したルートはボディを伏せて記録する", func(t *testing.T) {
+		var buf bytes.Buffer
+		engine := newEngine(middleware.AccessLogConfig{
+			Enabled:      true,
+			BodyRoutes:   []string{"/api/auth/login"},
+			MaxBodyBytes: 4096,
+		}, &buf)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login",
+			strings.NewReader(`{"username":"taro","password":"hunter22"}`))
+		req.Header.Set("Content-Type", "application/json")
+		engine.ServeHTTP(httptest.NewRecorder(), req)
+
+		logs := entries(t, &buf)
+		require.Len(t, logs, 1)
+		request := logs[0]["request_body"].(map[string]interface{})
+		assert.Equal(t, "taro", request["username"])
+		assert.Equal(t, "********", request["password"])
+
+		response := logs[0]["response_body"].(map[string]interface{})
+		assert.Equal(t, "********", response["csrf_token"])
+		assert.Equal(t, "t***@example.com", response["user"].(map[string]interface{})["email"])
+		assert.NotContains(t, buf.String(), "hunter22")
+		assert.NotContains(t, buf.String(), "csrf-secret")
+	})
+
+	t.Run("長いボディは切り詰める", func(t *testing.T) {
+		var buf bytes.Buffer
+		engine := newEngine(middleware.AccessLogConfig{
+			Enabled:      true,
+			BodyRoutes:   []string{"/api/auth/login"},
+			MaxBodyBytes: 10,
+		}, &buf)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(`{"username":"taro"}`))
+		req.Header.Set("Content-Type", "application/json")
+		engine.ServeHTTP(httptest.NewRecorder(), req)
+
+		logs := entries(t, &buf)
+		require.Len(t, logs, 1)
+		assert.Equal(t, `{"username...(truncated)`, logs[0]["request_body"])
+	})
+
+	t.Run("割合0のルートは成功時に記録せず、エラーは記録する", func(t *testing.T) {
+		var buf bytes.Buffer
+		engine := newEngine(middleware.AccessLogConfig{
+			Enabled:      true,
+			MaxBodyBytes: 4096,
+			SampleRates:  map[string]float64{"/api/points/balance": 0, "/api/points/fail": 0},
+		}, &buf)
+
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/points/balance", nil))
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/points/fail", nil))
+
+		logs := entries(t, &buf)
+		require.Len(t, logs, 1)
+		assert.Equal(t, "/api/v1/points/fail", logs[0]["path"])
+		assert.Equal(t, "ERROR", logs[0]["level"])
+	})
+
+	t.Run("無効なら記録しない", func(t *testing.T) {
+		var buf bytes.Buffer
+		engine := newEngine(middleware.AccessLogConfig{Enabled: false}, &buf)
+
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/points/balance", nil))
+
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestMaskSensitive(t *testing.T) {
+	var value interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"current_password": "old",
+		"newPassword": "new",
+		"api_key": "k",
+		"key": "kiosk",
+		"items": [{"refresh-token": "r"}, "contact me at hanako@example.co.jp"],
+		"token_expires_in": null,
+		"amount": 10
+	}`), &value))
+
+	masked := middleware.MaskSensitive(value).(map[string]interface{})
+
+	assert.Equal(t, "********", masked["current_password"])
+	assert.Equal(t, "********", masked["newPassword"])
+	assert.Equal(t, "********", masked["api_key"])
+	assert.Equal(t, "********", masked["key"])
+	items := masked["items"].([]interface{})
+	assert.Equal(t, "********", items[0].(map[string]interface{})["refresh-token"])
+	assert.Equal(t, "contact me at h***@example.co.jp", items[1])
+	assert.Nil(t, masked["token_expires_in"], "値がないものはそのまま")
+	assert.Equal(t, float64(10), masked["amount"])
+}
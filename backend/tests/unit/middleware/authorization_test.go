@@ -0,0 +1,138 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/frameworks/web/middleware"
+	"github.com/gity/point-system/gateways/infra/infralogger"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceAuthorizationMiddleware(t *testing.T) {
+	ctx := context.Background()
+	repos := testsupport.New()
+	authorizer := interactor.NewResourceAuthorizationInteractor(
+		repos.TransferRequests, repos.QRCodes, repos.ProductExchanges, repos.ShippingAddresses, repos.Transactions, repos.Users, infralogger.NewJSONLogger(io.Discard))
+	mw := middleware.NewResourceAuthorizationMiddleware(authorizer)
+
+	from, to, stranger := uuid.New(), uuid.New(), uuid.New()
+	request := &entities.TransferRequest{ID: uuid.New(), FromUserID: from, ToUserID: to, IdempotencyKey: "authz"}
+	require.NoError(t, repos.TransferRequests.Create(ctx, request))
+	gift := &entities.ProductExchange{ID: uuid.New(), UserID: from, RecipientID: &to}
+	require.NoError(t, repos.ProductExchanges.Create(ctx, gift))
+	address := &entities.ShippingAddress{ID: uuid.New(), UserID: from}
+	require.NoError(t, repos.ShippingAddresses.Create(ctx, address))
+	transfer, err := entities.NewTransfer(from, to, 100, "authz", "")
+	require.NoError(t, err)
+	require.NoError(t, repos.Transactions.Create(ctx, transfer))
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		if id, err := uuid.Parse(c.GetHeader("X-Test-User")); err == nil {
+			c.Set("user_id", id)
+		}
+	})
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	engine.GET("/transfer-requests/:id", mw.Authorize(entities.ResourceTransferRequest, entities.ActionView), ok)
+	engine.POST("/transfer-requests/:id/approve", mw.Authorize(entities.ResourceTransferRequest, entities.ActionApprove), ok)
+	engine.POST("/products/exchanges/:id/decline", mw.Authorize(entities.ResourceExchange, entities.ActionDecline), ok)
+	engine.DELETE("/settings/addresses/:id", mw.Authorize(entities.ResourceShippingAddress, entities.ActionDelete), ok)
+	engine.GET("/points/transactions/:id/receipt", mw.Authorize(entities.ResourceTransaction, entities.ActionView), ok)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		user   uuid.UUID
+		status int
+		code   entities.ErrorCode
+	}{
+		{"送信者は閲覧できる", http.MethodGet, "/transfer-requests/" + request.ID.String(), from, http.StatusNoContent, ""},
+		{"受取人は承認できる", http.MethodPost, "/transfer-requests/" + request.ID.String() + "/approve", to, http.StatusNoContent, ""},
+		{"送信者の承認は403", http.MethodPost, "/transfer-requests/" + request.ID.String() + "/approve", from, http.StatusForbidden, entities.ErrCodeActionNotPermitted},
+		{"関わりのないユーザーには404", http.MethodGet, "/transfer-requests/" + request.ID.String(), stranger, http.StatusNotFound, entities.ErrCodeTransferRequestNotFound},
+		{"存在しない送金リクエストは404", http.MethodGet, "/transfer-requests/" + uuid.NewString(), from, http.StatusNotFound, entities.ErrCodeTransferRequestNotFound},
+		{"贈られた人は辞退できる", http.MethodPost, "/products/exchanges/" + gift.ID.String() + "/decline", to, http.StatusNoContent, ""},
+		{"贈った人の辞退は403", http.MethodPost, "/products/exchanges/" + gift.ID.String() + "/decline", from, http.StatusForbidden, entities.ErrCodeActionNotPermitted},
+		{"他人の交換は404", http.MethodPost, "/products/exchanges/" + gift.ID.String() + "/decline", stranger, http.StatusNotFound, entities.ErrCodeExchangeNotFound},
+		{"他人のお届け先は404", http.MethodDelete, "/settings/addresses/" + address.ID.String(), to, http.StatusNotFound, entities.ErrCodeShippingAddressNotFound},
+		{"受取人は取引の控えを見られる", http.MethodGet, "/points/transactions/" + transfer.ID.String() + "/receipt", to, http.StatusNoContent, ""},
+		{"他人の取引は404", http.MethodGet, "/points/transactions/" + transfer.ID.String() + "/receipt", stranger, http.StatusNotFound, entities.ErrCodeTransactionNotFound},
+		{"存在しない取引は404", http.MethodGet, "/points/transactions/" + uuid.NewString() + "/receipt", from, http.StatusNotFound, entities.ErrCodeTransactionNotFound},
+		{"IDの形式が不正なら400", http.MethodGet, "/transfer-requests/not-a-uuid", from, http.StatusBadRequest, entities.ErrCodeValidationFailed},
+		{"未認証なら401", http.MethodGet, "/transfer-requests/" + request.ID.String(), uuid.Nil, http.StatusUnauthorized, entities.ErrCodeUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.user != uuid.Nil {
+				req.Header.Set("X-Test-User", tt.user.String())
+			}
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.status, rec.Code, rec.Body.String())
+			if tt.code != "" {
+				var problem struct {
+					Code entities.ErrorCode `json:"code"`
+				}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+				assert.Equal(t, tt.code, problem.Code)
+			}
+		})
+	}
+}
+
+func TestResourceAuthorizationMiddleware_RequireAdmin(t *testing.T) {
+	repos := testsupport.New()
+	authorizer := interactor.NewResourceAuthorizationInteractor(
+		repos.TransferRequests, repos.QRCodes, repos.ProductExchanges, repos.ShippingAddresses, repos.Transactions, repos.Users, infralogger.NewJSONLogger(io.Discard))
+	mw := middleware.NewResourceAuthorizationMiddleware(authorizer)
+
+	admin := &entities.User{ID: uuid.New(), Username: "admin", Role: entities.RoleAdmin, IsActive: true}
+	member := &entities.User{ID: uuid.New(), Username: "member", Role: entities.RoleUser, IsActive: true}
+	repos.Users.Seed(admin, member)
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		if id, err := uuid.Parse(c.GetHeader("X-Test-User")); err == nil {
+			c.Set("user_id", id)
+		}
+	})
+	engine.PUT("/admin/bonus-timezone", mw.RequireAdmin(), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	tests := []struct {
+		name   string
+		user   uuid.UUID
+		status int
+	}{
+		{"管理者は通す", admin.ID, http.StatusNoContent},
+		{"一般ユーザーは403", member.ID, http.StatusForbidden},
+		{"未認証なら401", uuid.Nil, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/admin/bonus-timezone", nil)
+			if tt.user != uuid.Nil {
+				req.Header.Set("X-Test-User", tt.user.String())
+			}
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	frameworksweb "github.com/gity/point-system/frameworks/web"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubBreakers は決まった状態を返す CircuitBreakerMonitor
+type stubBreakers []service.CircuitBreakerStatus
+
+func (s stubBreakers) CircuitBreakers() []service.CircuitBreakerStatus { return s }
+
+func TestHealth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	check := func(t *testing.T, breakers stubBreakers) map[string]interface{} {
+		router := frameworksweb.NewRouter(&frameworksweb.RouterConfig{Env: "test", AllowedOrigins: []string{"http://localhost:3000"}}, frameworksweb.NewSystemTimeProvider()).
+			WithCircuitBreakers(breakers)
+		w := httptest.NewRecorder()
+		router.GetEngine().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+		require.Equal(t, http.StatusOK, w.Code, "外部サービスが落ちていてもこのサーバーは動いている")
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body
+	}
+
+	t.Run("すべてclosedならok", func(t *testing.T) {
+		body := check(t, stubBreakers{{Name: "email", State: service.CircuitClosed}})
+		assert.Equal(t, "ok", body["status"])
+		assert.Len(t, body["circuit_breakers"], 1)
+	})
+
+	t.Run("openのサーキットブレーカーがあればdegraded", func(t *testing.T) {
+		body := check(t, stubBreakers{
+			{Name: "akerun:org-1", State: service.CircuitOpen, ConsecutiveFailures: 5},
+			{Name: "email", State: service.CircuitClosed},
+		})
+		assert.Equal(t, "degraded", body["status"])
+		breakers := body["circuit_breakers"].([]interface{})
+		assert.Equal(t, "open", breakers[0].(map[string]interface{})["state"])
+	})
+}
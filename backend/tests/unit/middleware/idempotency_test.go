@@ -0,0 +1,120 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/frameworks/web/middleware"
+	"github.com/gity/point-system/gateways/infra/infralogger"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ctxBoundIdempotentRequests はDBのクエリと同じく、contextが終わっていれば更新・削除に失敗するリポジトリ
+type ctxBoundIdempotentRequests struct {
+	*testsupport.IdempotentRequestRepository
+}
+
+func (r ctxBoundIdempotentRequests) Update(ctx context.Context, req *entities.IdempotentRequest) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return r.IdempotentRequestRepository.Update(ctx, req)
+}
+
+func (r ctxBoundIdempotentRequests) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return r.IdempotentRequestRepository.Delete(ctx, id)
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+
+	setup := func(handler gin.HandlerFunc) *gin.Engine {
+		repo := ctxBoundIdempotentRequests{testsupport.NewIdempotentRequestRepository()}
+		logger := infralogger.NewJSONLogger(io.Discard)
+		idempotency := middleware.NewIdempotencyMiddleware(interactor.NewIdempotencyInteractor(repo, logger), logger)
+
+		engine := gin.New()
+		engine.POST("/transfers",
+			middleware.RequestTimeoutMiddleware(20*time.Millisecond),
+			func(c *gin.Context) { c.Set("user_id", userID) },
+			idempotency.Handle(),
+			handler,
+		)
+		return engine
+	}
+	post := func(engine *gin.Engine, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/transfers", strings.NewReader(`{"amount":100}`))
+		req.Header.Set(middleware.IdempotencyKeyHeader, key)
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("期限切れで中断したリクエストは同じキーで再送できる", func(t *testing.T) {
+		calls := 0
+		engine := setup(func(c *gin.Context) {
+			calls++
+			if calls == 1 {
+				waitForCancel(c)
+				return
+			}
+			c.JSON(http.StatusCreated, gin.H{"ok": true})
+		})
+
+		first := post(engine, "key-1")
+		assert.Equal(t, http.StatusGatewayTimeout, first.Code)
+
+		retry := post(engine, "key-1")
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("何も返さずに期限を過ぎたリクエストも同じキーで再送できる", func(t *testing.T) {
+		calls := 0
+		engine := setup(func(c *gin.Context) {
+			calls++
+			if calls == 1 {
+				<-c.Request.Context().Done()
+				return
+			}
+			c.JSON(http.StatusCreated, gin.H{"ok": true})
+		})
+
+		first := post(engine, "key-2")
+		assert.Equal(t, http.StatusGatewayTimeout, first.Code)
+
+		retry := post(engine, "key-2")
+		require.Equal(t, http.StatusCreated, retry.Code)
+		assert.Empty(t, retry.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("完了したリクエストの再送は保存済みのレスポンスを返す", func(t *testing.T) {
+		calls := 0
+		engine := setup(func(c *gin.Context) {
+			calls++
+			c.JSON(http.StatusCreated, gin.H{"ok": true})
+		})
+
+		post(engine, "key-3")
+		retry := post(engine, "key-3")
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,142 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	frameworksweb "github.com/gity/point-system/frameworks/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newProblemEngine はNewRouterのエンジン（リカバリー・エラーハンドラー・NoRoute設定済み）にテスト用のルートを追加する
+func newProblemEngine() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := frameworksweb.NewRouter(&frameworksweb.RouterConfig{Env: "test", AllowedOrigins: []string{"http://localhost:3000"}}, frameworksweb.NewSystemTimeProvider()).GetEngine()
+
+	engine.POST("/api/v1/points/transfer", func(c *gin.Context) {
+		var req struct {
+			ToUserID string `json:"to_user_id" binding:"required,uuid"`
+			Amount   int64  `json:"amount" binding:"required,gt=0"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			presenter.RenderError(c, http.StatusBadRequest, err)
+			return
+		}
+		presenter.RenderError(c, http.StatusBadRequest, entities.ErrInsufficientBalance.WithParams(map[string]interface{}{
+			"balance": 10, "required": req.Amount,
+		}))
+	})
+	engine.GET("/api/v1/recorded", func(c *gin.Context) {
+		c.Error(entities.ErrUserNotFound)
+	})
+	engine.GET("/api/v1/panic", func(c *gin.Context) {
+		panic("database password leaked")
+	})
+	engine.GET("/api/v1/internal", func(c *gin.Context) {
+		presenter.RenderError(c, http.StatusInternalServerError, errors.New("pq: connection refused"))
+	})
+	return engine
+}
+
+// problemOf はproblem+jsonのレスポンスを読む
+func problemOf(t *testing.T, w *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+	assert.Equal(t, presenter.ProblemContentType, w.Header().Get("Content-Type"))
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body), w.Body.String())
+	return body
+}
+
+func TestProblemResponses(t *testing.T) {
+	engine := newProblemEngine()
+
+	t.Run("ドメインエラーはコードごとのtype・翻訳済みのdetail・paramsで返す", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/points/transfer",
+			strings.NewReader(`{"to_user_id":"11111111-1111-1111-1111-111111111111","amount":50}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept-Language", "en")
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		body := problemOf(t, w)
+		assert.Equal(t, "urn:gity-point-system:error:insufficient_balance", body["type"])
+		assert.Equal(t, "Bad Request", body["title"])
+		assert.Equal(t, float64(400), body["status"])
+		assert.Equal(t, "Insufficient balance. (balance: 10, required: 50)", body["detail"])
+		assert.Equal(t, body["detail"], body["error"], "errorは以前の形式との互換のためdetailと同じ")
+		assert.Equal(t, "/api/v1/points/transfer", body["instance"])
+		assert.Equal(t, "insufficient_balance", body["error_code"])
+		assert.Equal(t, body["error_code"], body["code"])
+		assert.Equal(t, float64(50), body["params"].(map[string]interface{})["required"])
+	})
+
+	t.Run("バインドの検証エラーはJSONのキーで項目ごとのエラーを返す", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/points/transfer", strings.NewReader(`{"to_user_id":"abc"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		body := problemOf(t, w)
+		assert.Equal(t, "validation_failed", body["error_code"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"field": "to_user_id", "message": "must be a valid ID"},
+			map[string]interface{}{"field": "amount", "message": "is required"},
+		}, body["errors"])
+	})
+
+	t.Run("壊れたJSONはbodyの項目エラーにする", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/points/transfer", strings.NewReader(`{"amount":`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		body := problemOf(t, w)
+		assert.Equal(t, "validation_failed", body["error_code"])
+		assert.Equal(t, "body", body["errors"].([]interface{})[0].(map[string]interface{})["field"])
+	})
+
+	t.Run("c.Errorで記録しただけのエラーもproblem+jsonで返す", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/recorded", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "user_not_found", problemOf(t, w)["error_code"])
+	})
+
+	t.Run("パニックは内容を隠して500で返す", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/panic", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		body := problemOf(t, w)
+		assert.Equal(t, "about:blank", body["type"])
+		assert.Equal(t, "internal_error", body["error_code"])
+		assert.NotContains(t, w.Body.String(), "password")
+	})
+
+	t.Run("ドメインエラー以外の5xxは内部のメッセージを返さない", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/internal", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.NotContains(t, w.Body.String(), "pq:")
+	})
+
+	t.Run("存在しないルートはroute_not_foundを返す", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/nope", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "route_not_found", problemOf(t, w)["error_code"])
+	})
+}
@@ -0,0 +1,88 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/frameworks/web/middleware"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubTenants はスラッグからテナントを引くだけの TenantInputPort
+type stubTenants struct {
+	inputport.TenantInputPort
+	tenants map[string]*entities.Tenant
+}
+
+func (s *stubTenants) ResolveTenant(ctx context.Context, slug string) (*entities.Tenant, error) {
+	if slug == "" {
+		slug = entities.DefaultTenantSlug
+	}
+	tenant, ok := s.tenants[slug]
+	if !ok {
+		return nil, entities.ErrTenantNotFound
+	}
+	if !tenant.IsActive {
+		return nil, entities.ErrTenantInactive
+	}
+	return tenant, nil
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	acme, err := entities.NewTenant("acme", "Acme", time.Now())
+	require.NoError(t, err)
+	closed, err := entities.NewTenant("closed", "Closed", time.Now())
+	require.NoError(t, err)
+	closed.IsActive = false
+	stub := &stubTenants{tenants: map[string]*entities.Tenant{
+		entities.DefaultTenantSlug: {ID: entities.DefaultTenantID, Slug: entities.DefaultTenantSlug, IsActive: true},
+		"acme":                     acme,
+		"closed":                   closed,
+	}}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(middleware.NewTenantMiddleware(stub, "points.example.com").Handle())
+	engine.GET("/api/tenant", func(c *gin.Context) {
+		id, _ := entities.TenantIDFromContext(c.Request.Context())
+		c.String(http.StatusOK, id.String())
+	})
+
+	tests := []struct {
+		name   string
+		host   string
+		header string
+		status int
+		want   string
+	}{
+		{"指定がなければ既定のテナント", "localhost:8080", "", http.StatusOK, entities.DefaultTenantID.String()},
+		{"ヘッダーで指定", "localhost:8080", "acme", http.StatusOK, acme.ID.String()},
+		{"サブドメインで指定", "acme.points.example.com:443", "", http.StatusOK, acme.ID.String()},
+		{"ベースドメインそのものは既定のテナント", "points.example.com", "", http.StatusOK, entities.DefaultTenantID.String()},
+		{"存在しないテナント", "unknown.points.example.com", "", http.StatusNotFound, ""},
+		{"停止したテナント", "localhost", "closed", http.StatusForbidden, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/tenant", nil)
+			req.Host = tt.host
+			if tt.header != "" {
+				req.Header.Set(middleware.TenantHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			if tt.want != "" {
+				assert.Equal(t, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
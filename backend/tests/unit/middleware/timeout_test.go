@@ -0,0 +1,116 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/controllers/web/presenter"
+	"github.com/gity/point-system/entities"
+	frameworksweb "github.com/gity/point-system/frameworks/web"
+	"github.com/gity/point-system/frameworks/web/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waitForCancel はDBのクエリのようにcontextのキャンセルまで待つハンドラー
+func waitForCancel(c *gin.Context) {
+	select {
+	case <-c.Request.Context().Done():
+		presenter.RenderError(c, http.StatusInternalServerError, fmt.Errorf("query failed: %w", c.Request.Context().Err()))
+	case <-time.After(time.Second):
+		c.String(http.StatusOK, "done")
+	}
+}
+
+func TestRequestTimeoutMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	serve := func(engine *gin.Engine, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	t.Run("期限切れで中断したクエリのエラーは504", func(t *testing.T) {
+		engine := gin.New()
+		engine.GET("/slow", middleware.RequestTimeoutMiddleware(20*time.Millisecond), waitForCancel)
+
+		w := serve(engine, "/slow")
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		var problem presenter.Problem
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, string(entities.ErrCodeRequestTimeout), problem.ErrorCode)
+	})
+
+	t.Run("ハンドラーが何も返さずに期限を過ぎた場合も504", func(t *testing.T) {
+		engine := gin.New()
+		engine.GET("/silent", middleware.RequestTimeoutMiddleware(20*time.Millisecond), func(c *gin.Context) {
+			<-c.Request.Context().Done()
+		})
+
+		w := serve(engine, "/silent")
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assert.Contains(t, w.Body.String(), string(entities.ErrCodeRequestTimeout))
+	})
+
+	t.Run("期限内に終わればそのまま返す", func(t *testing.T) {
+		engine := gin.New()
+		engine.GET("/fast", middleware.RequestTimeoutMiddleware(time.Second), func(c *gin.Context) {
+			_, ok := c.Request.Context().Deadline()
+			assert.True(t, ok)
+			c.String(http.StatusOK, "ok")
+		})
+
+		w := serve(engine, "/fast")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("内側のグループの期限で置き換え、contextの値は引き継ぐ", func(t *testing.T) {
+		type key struct{}
+		engine := gin.New()
+		outer := engine.Group("", middleware.RequestTimeoutMiddleware(20*time.Millisecond), func(c *gin.Context) {
+			c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), key{}, "tenant"))
+		})
+		outer.GET("/admin/export", middleware.RequestTimeoutMiddleware(time.Second), func(c *gin.Context) {
+			time.Sleep(50 * time.Millisecond)
+			if err := c.Request.Context().Err(); err != nil {
+				presenter.RenderError(c, http.StatusInternalServerError, err)
+				return
+			}
+			c.String(http.StatusOK, "%v", c.Request.Context().Value(key{}))
+		})
+
+		w := serve(engine, "/admin/export")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "tenant", w.Body.String())
+	})
+
+	t.Run("0なら期限を付けない", func(t *testing.T) {
+		engine := gin.New()
+		engine.GET("/unlimited", middleware.RequestTimeoutMiddleware(0), func(c *gin.Context) {
+			_, ok := c.Request.Context().Deadline()
+			assert.False(t, ok)
+			c.Status(http.StatusNoContent)
+		})
+
+		w := serve(engine, "/unlimited")
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
+
+func TestRequestTimeouts_For(t *testing.T) {
+	timeouts := frameworksweb.RequestTimeouts{
+		Default: 10 * time.Second,
+		Groups:  map[string]time.Duration{frameworksweb.RouteGroupProtected: 20 * time.Second, frameworksweb.RouteGroupKiosk: 0},
+	}
+
+	assert.Equal(t, 10*time.Second, timeouts.For(frameworksweb.RouteGroupPublic))
+	assert.Equal(t, 20*time.Second, timeouts.For(frameworksweb.RouteGroupProtected))
+	assert.Equal(t, 20*time.Second, timeouts.For(frameworksweb.RouteGroupAdmin), "管理APIは指定がなければProtectedと同じ")
+	assert.Zero(t, timeouts.For(frameworksweb.RouteGroupKiosk))
+}
@@ -0,0 +1,213 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gity/point-system/frameworks/web/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, body string) interface{} {
+	var v interface{}
+	require.NoError(t, json.Unmarshal([]byte(body), &v))
+	return v
+}
+
+func TestRequestBodySchema_Transfer(t *testing.T) {
+	schema := openapi.RequestBodySchema(http.MethodPost, "/api/points/transfer")
+	require.NotNil(t, schema)
+
+	t.Run("正しいリクエストは通る", func(t *testing.T) {
+		errs := schema.Validate(decode(t, `{
+			"to_user_id": "6f1c2a8e-3b4d-4c5e-9f60-718293a4b5c6",
+			"amount": 100,
+			"idempotency_key": "key-1"
+		}`))
+		assert.Empty(t, errs)
+	})
+
+	t.Run("必須項目の欠落を検出", func(t *testing.T) {
+		errs := schema.Validate(decode(t, `{"amount": 100}`))
+		require.Len(t, errs, 2)
+		assert.Equal(t, "to_user_id", errs[0].Field)
+		assert.Equal(t, "idempotency_key", errs[1].Field)
+	})
+
+	t.Run("型・形式・範囲の違反を検出", func(t *testing.T) {
+		errs := schema.Validate(decode(t, `{
+			"to_user_id": "not-a-uuid",
+			"amount": 0,
+			"idempotency_key": 123
+		}`))
+		require.Len(t, errs, 3)
+		assert.Equal(t, openapi.ValidationError{Field: "amount", Message: "must be at least 1"}, errs[0])
+		assert.Equal(t, openapi.ValidationError{Field: "idempotency_key", Message: "must be a string"}, errs[1])
+		assert.Equal(t, openapi.ValidationError{Field: "to_user_id", Message: "must be a valid UUID"}, errs[2])
+	})
+
+	t.Run("小数は整数として扱わない", func(t *testing.T) {
+		errs := schema.Validate(decode(t, `{
+			"to_user_id": "6f1c2a8e-3b4d-4c5e-9f60-718293a4b5c6",
+			"amount": 1.5,
+			"idempotency_key": "key-1"
+		}`))
+		require.Len(t, errs, 1)
+		assert.Equal(t, "must be an integer", errs[0].Message)
+	})
+
+	t.Run("オブジェクト以外は拒否", func(t *testing.T) {
+		errs := schema.Validate(decode(t, `[1, 2]`))
+		require.Len(t, errs, 1)
+		assert.Equal(t, "must be an object", errs[0].Message)
+	})
+}
+
+func TestRequestBodySchema_Register(t *testing.T) {
+	schema := openapi.RequestBodySchema(http.MethodPost, "/api/auth/register")
+	require.NotNil(t, schema)
+
+	errs := schema.Validate(decode(t, `{
+		"username": "ab",
+		"email": "invalid",
+		"password": "short",
+		"display_name": "表示名",
+		"first_name": "太郎",
+		"last_name": "山田"
+	}`))
+	fields := make([]string, 0, len(errs))
+	for _, e := range errs {
+		fields = append(fields, e.Field)
+	}
+	assert.ElementsMatch(t, []string{"email", "password", "username"}, fields)
+
+	t.Run("文字数はバイト数ではなく文字数で数える", func(t *testing.T) {
+		errs := schema.Validate(decode(t, `{
+			"username": "山田太郎",
+			"email": "taro@example.com",
+			"password": "password123",
+			"display_name": "山",
+			"first_name": "太郎",
+			"last_name": "山田"
+		}`))
+		assert.Empty(t, errs)
+	})
+}
+
+func TestRequestBodySchema_NestedAndNullable(t *testing.T) {
+	t.Run("配列要素のエラーはインデックス付きのフィールド名", func(t *testing.T) {
+		schema := openapi.RequestBodySchema(http.MethodPut, "/api/admin/lottery-tiers")
+		require.NotNil(t, schema)
+
+		errs := schema.Validate(decode(t, `{"tiers": [{"name": "大当たり", "probability": 5}, {"probability": 120}]}`))
+		require.Len(t, errs, 2)
+		assert.Equal(t, "tiers[1].name", errs[0].Field)
+		assert.Equal(t, openapi.ValidationError{Field: "tiers[1].probability", Message: "must be at most 100"}, errs[1])
+	})
+
+	t.Run("nullableな項目はnullを許可", func(t *testing.T) {
+		schema := openapi.RequestBodySchema(http.MethodPost, "/api/qrcodes/receive")
+		require.NotNil(t, schema)
+
+		assert.Empty(t, schema.Validate(decode(t, `{"amount": null}`)))
+		assert.Empty(t, schema.Validate(decode(t, `{}`)))
+		assert.Len(t, schema.Validate(decode(t, `{"amount": 0}`)), 1)
+	})
+
+	t.Run("enumにない値を拒否", func(t *testing.T) {
+		schema := openapi.RequestBodySchema(http.MethodPut, "/api/admin/users/:id/role")
+		require.NotNil(t, schema)
+
+		assert.Empty(t, schema.Validate(decode(t, `{"role": "admin"}`)))
+		assert.Len(t, schema.Validate(decode(t, `{"role": "owner"}`)), 1)
+	})
+
+	t.Run("スキーマ未定義のルートはnil", func(t *testing.T) {
+		assert.Nil(t, openapi.RequestBodySchema(http.MethodGet, "/api/points/balance"))
+	})
+}
+
+func TestBuild(t *testing.T) {
+	doc := openapi.Build("Test API", "1.0.0", []openapi.Route{
+		{Method: http.MethodPost, Path: "/api/auth/login", Handler: "github.com/gity/point-system/frameworks/web.(*Router).RegisterRoutes.func2"},
+		{Method: http.MethodGet, Path: "/api/friends", Handler: "github.com/gity/point-system/controllers/web.(*FriendController).GetFriends-fm"},
+		{Method: http.MethodPut, Path: "/api/admin/users/:id/role", Handler: "github.com/gity/point-system/controllers/web.(*AdminController).UpdateUserRole-fm"},
+		{Method: http.MethodPost, Path: "/api/kiosk/grant", Handler: "github.com/gity/point-system/controllers/web.(*KioskController).GrantBonus-fm"},
+		{Method: http.MethodGet, Path: "/health", Handler: "main.func1"},
+	})
+
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	assert.Len(t, doc.Paths, 4, "/api 以外のルートは含めない")
+
+	t.Run("公開ルートはセキュリティ要件なし", func(t *testing.T) {
+		op := doc.Paths["/api/auth/login"].Post
+		require.NotNil(t, op)
+		assert.Equal(t, "ログイン", op.Summary)
+		assert.Empty(t, op.Security)
+		require.NotNil(t, op.RequestBody)
+		assert.NotNil(t, op.RequestBody.Content["application/json"].Schema)
+	})
+
+	t.Run("要約はハンドラー名から生成", func(t *testing.T) {
+		op := doc.Paths["/api/friends"].Get
+		require.NotNil(t, op)
+		assert.Equal(t, "FriendController.GetFriends", op.Summary)
+		assert.Equal(t, []string{"friends"}, op.Tags)
+		assert.Equal(t, []map[string][]string{{"sessionCookie": {}}}, op.Security)
+	})
+
+	t.Run("パスパラメータを変換し、状態変更はCSRFトークンが必要", func(t *testing.T) {
+		op := doc.Paths["/api/admin/users/{id}/role"].Put
+		require.NotNil(t, op)
+		assert.Equal(t, "put_api_admin_users_id_role", op.OperationID)
+		require.Len(t, op.Parameters, 1)
+		assert.Equal(t, "id", op.Parameters[0].Name)
+		assert.Equal(t, "path", op.Parameters[0].In)
+		assert.Equal(t, []map[string][]string{{"sessionCookie": {}, "csrfToken": {}}}, op.Security)
+	})
+
+	t.Run("キオスクは端末APIキー", func(t *testing.T) {
+		op := doc.Paths["/api/kiosk/grant"].Post
+		require.NotNil(t, op)
+		assert.Equal(t, []map[string][]string{{"kioskKey": {}}}, op.Security)
+	})
+
+	t.Run("JSONにシリアライズできる", func(t *testing.T) {
+		_, err := json.Marshal(doc)
+		assert.NoError(t, err)
+	})
+}
+
+func TestVersionedRoutes(t *testing.T) {
+	t.Run("バージョン付きのパスでもスキーマを引ける", func(t *testing.T) {
+		assert.NotNil(t, openapi.RequestBodySchema(http.MethodPost, "/api/v1/points/transfer"))
+		assert.NotNil(t, openapi.RequestBodySchema(http.MethodPost, "/api/v2/points/transfer"))
+		assert.Nil(t, openapi.RequestBodySchema(http.MethodPost, "/api/v1x/points/transfer"))
+	})
+
+	doc := openapi.Build("Test API", "1.0.0", []openapi.Route{
+		{Method: http.MethodPost, Path: "/api/v1/kiosk/grant", Handler: "web.(*KioskController).GrantBonus-fm", Deprecated: true},
+		{Method: http.MethodPost, Path: "/api/v2/kiosk/grant", Handler: "web.(*KioskController).GrantBonus-fm"},
+	})
+
+	t.Run("バージョンごとに別のパスとして出力し、廃止予定を示す", func(t *testing.T) {
+		v1 := doc.Paths["/api/v1/kiosk/grant"].Post
+		v2 := doc.Paths["/api/v2/kiosk/grant"].Post
+		require.NotNil(t, v1)
+		require.NotNil(t, v2)
+
+		assert.True(t, v1.Deprecated)
+		assert.False(t, v2.Deprecated)
+		assert.NotEqual(t, v1.OperationID, v2.OperationID)
+	})
+
+	t.Run("タグ・認証方式・スキーマはバージョンなしの定義から引き継ぐ", func(t *testing.T) {
+		op := doc.Paths["/api/v2/kiosk/grant"].Post
+		assert.Equal(t, []string{"kiosk"}, op.Tags)
+		assert.Equal(t, []map[string][]string{{"kioskKey": {}}}, op.Security)
+		assert.Equal(t, "端末からボーナスを付与", op.Summary)
+		require.NotNil(t, op.RequestBody)
+	})
+}
@@ -0,0 +1,118 @@
+package realtime_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/frameworks/web/realtime"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+const testOrigin = "http://localhost:3000"
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, fields ...entities.Field) {}
+func (nopLogger) Info(msg string, fields ...entities.Field)  {}
+func (nopLogger) Warn(msg string, fields ...entities.Field)  {}
+func (nopLogger) Error(msg string, fields ...entities.Field) {}
+func (nopLogger) Fatal(msg string, fields ...entities.Field) {}
+
+type wsMessage struct {
+	Topic string                 `json:"topic"`
+	Type  string                 `json:"type"`
+	Data  map[string]interface{} `json:"data"`
+}
+
+// newTestServer は認証済みユーザーとして /ws に接続できるサーバーを起動する
+func newTestServer(t *testing.T, hub *realtime.Hub, userID uuid.UUID) *httptest.Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/ws", func(c *gin.Context) {
+		c.Set("user_id", userID)
+	}, hub.Handler([]string{testOrigin}))
+
+	srv := httptest.NewServer(engine)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func dial(t *testing.T, srv *httptest.Server, query, origin string) (*websocket.Conn, error) {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws" + query
+	return websocket.Dial(url, "", origin)
+}
+
+func receive(t *testing.T, conn *websocket.Conn) wsMessage {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	var msg wsMessage
+	require.NoError(t, websocket.JSON.Receive(conn, &msg))
+	return msg
+}
+
+func TestHub_DeliversEventsToOwner(t *testing.T) {
+	hub := realtime.NewHub(nopLogger{})
+	userID := uuid.New()
+	srv := newTestServer(t, hub, userID)
+
+	conn, err := dial(t, srv, "?topics=qr", testOrigin)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	assert.Equal(t, "connected", receive(t, conn).Type)
+	assert.Equal(t, 1, hub.ConnectionCount())
+
+	// 他のユーザー宛のイベントは届かない
+	hub.Notify(context.Background(), uuid.New(), &entities.RealtimeEvent{Topic: entities.RealtimeTopicQR, Type: entities.RealtimeEventQRScanned})
+	hub.Notify(context.Background(), userID, &entities.RealtimeEvent{
+		Topic: entities.RealtimeTopicQR,
+		Type:  entities.RealtimeEventTransferCompleted,
+		Data:  map[string]interface{}{"amount": 300},
+	})
+
+	msg := receive(t, conn)
+	assert.Equal(t, "qr", msg.Topic)
+	assert.Equal(t, "transfer-completed", msg.Type)
+	assert.Equal(t, float64(300), msg.Data["amount"])
+}
+
+func TestHub_UnregistersOnClose(t *testing.T) {
+	hub := realtime.NewHub(nopLogger{})
+	srv := newTestServer(t, hub, uuid.New())
+
+	conn, err := dial(t, srv, "", testOrigin)
+	require.NoError(t, err)
+	receive(t, conn)
+	conn.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for hub.ConnectionCount() > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, 0, hub.ConnectionCount())
+}
+
+func TestHub_RejectsConnections(t *testing.T) {
+	hub := realtime.NewHub(nopLogger{})
+	srv := newTestServer(t, hub, uuid.New())
+
+	t.Run("許可されていないOrigin", func(t *testing.T) {
+		_, err := dial(t, srv, "", "http://evil.example.com")
+		assert.Error(t, err)
+	})
+
+	t.Run("不明なトピック", func(t *testing.T) {
+		_, err := dial(t, srv, "?topics=unknown", testOrigin)
+		assert.Error(t, err)
+	})
+}
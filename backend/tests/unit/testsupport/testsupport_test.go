@@ -0,0 +1,283 @@
+package testsupport_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/tests/testsupport"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/interactor"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ========================================
+// testsupport テスト
+// ========================================
+
+func newUser(t *testing.T, name string, balance int64) *entities.User {
+	t.Helper()
+	user, err := entities.NewUser(name, name+"@example.com", "hash", name, "太郎", "田中")
+	require.NoError(t, err)
+	user.Balance = balance
+	user.IsActive = true
+	return user
+}
+
+// --- Faults ---
+
+func TestFaults(t *testing.T) {
+	ctx := context.Background()
+	errDown := errors.New("db down")
+
+	t.Run("FailOnで指定したメソッドだけが失敗し続ける", func(t *testing.T) {
+		repos := testsupport.New()
+		user := newUser(t, "alice", 0)
+		repos.Users.Seed(user)
+		repos.Users.FailOn("Read", errDown)
+
+		for range 2 {
+			_, err := repos.Users.Read(ctx, user.ID)
+			assert.ErrorIs(t, err, errDown)
+		}
+		_, err := repos.Users.ReadByUsername(ctx, "alice")
+		assert.NoError(t, err)
+
+		repos.Users.FailOn("Read", nil)
+		_, err = repos.Users.Read(ctx, user.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("FailOnceは登録した順に一度ずつ失敗する", func(t *testing.T) {
+		repos := testsupport.New()
+		errFirst, errSecond := errors.New("first"), errors.New("second")
+		repos.Categories.FailOnce(testsupport.AnyMethod, errFirst)
+		repos.Categories.FailOnce(testsupport.AnyMethod, errSecond)
+
+		_, err := repos.Categories.ReadList(ctx, false)
+		assert.ErrorIs(t, err, errFirst)
+		_, err = repos.Categories.Count(ctx)
+		assert.ErrorIs(t, err, errSecond)
+		_, err = repos.Categories.ReadList(ctx, false)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Hookでn回目の呼び出しだけを失敗させられる", func(t *testing.T) {
+		repos := testsupport.New()
+		repos.Sessions.Hook("Read", func() error {
+			if repos.Sessions.Calls("Read") == 2 {
+				return errDown
+			}
+			return nil
+		})
+
+		_, err := repos.Sessions.Read(ctx, uuid.New())
+		assert.NotErrorIs(t, err, errDown)
+		_, err = repos.Sessions.Read(ctx, uuid.New())
+		assert.ErrorIs(t, err, errDown)
+		assert.Equal(t, 2, repos.Sessions.Calls("Read"))
+
+		repos.Sessions.Reset()
+		assert.Equal(t, 0, repos.Sessions.Calls("Read"))
+	})
+}
+
+// --- UserRepository ---
+
+func TestUserRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("古いversionでの更新はfalseを返す", func(t *testing.T) {
+		repos := testsupport.New()
+		user := newUser(t, "alice", 100)
+		require.NoError(t, repos.Users.Create(ctx, user))
+
+		first, err := repos.Users.Read(ctx, user.ID)
+		require.NoError(t, err)
+		stale, err := repos.Users.Read(ctx, user.ID)
+		require.NoError(t, err)
+
+		first.DisplayName = "Alice"
+		ok, err := repos.Users.Update(ctx, first)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		stale.DisplayName = "Stale"
+		ok, err = repos.Users.Update(ctx, stale)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		stored, err := repos.Users.Read(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Alice", stored.DisplayName)
+	})
+
+	t.Run("ユーザー名が重複するとErrDuplicate", func(t *testing.T) {
+		repos := testsupport.New()
+		require.NoError(t, repos.Users.Create(ctx, newUser(t, "alice", 0)))
+		err := repos.Users.Create(ctx, newUser(t, "alice", 0))
+		assert.ErrorIs(t, err, testsupport.ErrDuplicate)
+	})
+
+	t.Run("残高が足りなければどのユーザーの残高も変えない", func(t *testing.T) {
+		repos := testsupport.New()
+		alice, bob := newUser(t, "alice", 100), newUser(t, "bob", 0)
+		repos.Users.Seed(alice, bob)
+
+		err := repos.Users.UpdateBalancesWithLock(ctx, []repository.BalanceUpdate{
+			{UserID: bob.ID, Amount: 500},
+			{UserID: alice.ID, Amount: 500, IsDeduct: true},
+		})
+		assert.ErrorIs(t, err, entities.ErrInsufficientBalance)
+
+		stored, err := repos.Users.Read(ctx, bob.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), stored.Balance)
+	})
+
+	t.Run("取得した値を変更しても保存済みの行は変わらない", func(t *testing.T) {
+		repos := testsupport.New()
+		user := newUser(t, "alice", 100)
+		repos.Users.Seed(user)
+		user.Balance = 999
+
+		read, err := repos.Users.Read(ctx, user.ID)
+		require.NoError(t, err)
+		read.Balance = 999
+
+		stored, err := repos.Users.Read(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), stored.Balance)
+	})
+}
+
+// --- 並び順・ページング ---
+
+func TestTransactionRepository_ReadListByUserID(t *testing.T) {
+	ctx := context.Background()
+	repos := testsupport.New()
+	alice, bob := newUser(t, "alice", 0), newUser(t, "bob", 0)
+	repos.Users.Seed(alice, bob)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var ids []uuid.UUID
+	for i := range 5 {
+		tx, err := entities.NewTransfer(alice.ID, bob.ID, int64(i+1), uuid.NewString(), "")
+		require.NoError(t, err)
+		tx.CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		require.NoError(t, repos.Transactions.Create(ctx, tx))
+		ids = append(ids, tx.ID)
+	}
+
+	list, err := repos.Transactions.ReadListByUserID(ctx, bob.ID, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, ids[3], list[0].ID)
+	assert.Equal(t, ids[2], list[1].ID)
+
+	count, err := repos.Transactions.CountByUserID(ctx, alice.ID, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+}
+
+// --- インタラクターとの組み合わせ ---
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, fields ...entities.Field) {}
+func (nopLogger) Info(msg string, fields ...entities.Field)  {}
+func (nopLogger) Warn(msg string, fields ...entities.Field)  {}
+func (nopLogger) Error(msg string, fields ...entities.Field) {}
+func (nopLogger) Fatal(msg string, fields ...entities.Field) {}
+
+type nopNotifications struct{}
+
+func (nopNotifications) Dispatch(ctx context.Context, notification *entities.Notification) {}
+
+type nopScreener struct{}
+
+func (nopScreener) ScreenTransfer(ctx context.Context, req *inputport.TransferRequest, now time.Time) *entities.SuspiciousActivity {
+	return nil
+}
+
+func (nopScreener) RecordSuspiciousTransfer(ctx context.Context, activity *entities.SuspiciousActivity) error {
+	return nil
+}
+
+type allowAll struct{}
+
+func (allowAll) CheckSender(ctx context.Context, userID uuid.UUID) error { return nil }
+
+func (allowAll) CheckRecipient(ctx context.Context, senderID, recipientID uuid.UUID) error {
+	return nil
+}
+
+func (allowAll) AcceptanceOf(ctx context.Context, user *entities.User) (entities.TransferAcceptance, error) {
+	return entities.TransferAcceptanceAnyone, nil
+}
+
+type noFees struct{}
+
+func (noFees) CurrentPolicy(ctx context.Context) (*entities.TransferPolicy, error) {
+	return &entities.TransferPolicy{FeeType: entities.TransferFeeNone}, nil
+}
+
+type nopEarningEvents struct{}
+
+func (nopEarningEvents) Record(ctx context.Context, event *entities.EarningEvent) {}
+
+func TestPointTransferInteractor_WithFakes(t *testing.T) {
+	ctx := context.Background()
+	setup := func(t *testing.T) (*testsupport.Repositories, *interactor.PointTransferInteractor, *entities.User, *entities.User) {
+		repos := testsupport.New()
+		sender, receiver := newUser(t, "sender", 1000), newUser(t, "receiver", 0)
+		repos.Users.Seed(sender, receiver)
+		sut := interactor.NewPointTransferInteractor(
+			repos.TxManager, repos.Users, repos.Transactions, repos.IdempotencyKeys,
+			repos.Friendships, repos.PointBatches, repos.BalanceHolds, nopNotifications{}, nopScreener{}, allowAll{}, noFees{}, nopEarningEvents{}, nopLogger{},
+		)
+		return repos, sut, sender, receiver
+	}
+
+	t.Run("送金すると残高と取引履歴が更新される", func(t *testing.T) {
+		repos, sut, sender, receiver := setup(t)
+
+		resp, err := sut.Transfer(ctx, &inputport.TransferRequest{
+			FromUserID: sender.ID, ToUserID: receiver.ID, Amount: 300, IdempotencyKey: "key-1",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(700), resp.FromUser.Balance)
+		assert.Equal(t, int64(300), resp.ToUser.Balance)
+
+		stored, err := repos.Transactions.Read(ctx, resp.Transaction.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.TransactionStatusCompleted, stored.Status)
+
+		// 同じキーで再送すると同じ取引を返す
+		again, err := sut.Transfer(ctx, &inputport.TransferRequest{
+			FromUserID: sender.ID, ToUserID: receiver.ID, Amount: 300, IdempotencyKey: "key-1",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resp.Transaction.ID, again.Transaction.ID)
+		assert.Equal(t, 1, repos.Transactions.Calls("Create"))
+	})
+
+	t.Run("取引の記録に失敗すると冪等性キーは失敗状態になる", func(t *testing.T) {
+		repos, sut, sender, receiver := setup(t)
+		repos.Transactions.FailOn("Create", errors.New("db down"))
+
+		_, err := sut.Transfer(ctx, &inputport.TransferRequest{
+			FromUserID: sender.ID, ToUserID: receiver.ID, Amount: 300, IdempotencyKey: "key-2",
+		})
+		require.Error(t, err)
+
+		key, err := repos.IdempotencyKeys.ReadByKey(ctx, "key-2")
+		require.NoError(t, err)
+		assert.Equal(t, "failed", key.Status)
+	})
+}
@@ -0,0 +1,8 @@
+//go:build tools
+
+// Package tools は開発で使うツールのバージョンを go.mod で固定する（ビルドには含めない）
+package tools
+
+import (
+	_ "github.com/google/wire/cmd/wire"
+)
@@ -0,0 +1,49 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// AdminAccountInputPort は管理者アカウントの作成・パスワードの再設定のユースケースインターフェース
+// （管理CLIのcreate-admin・reset-passwordから使う）
+type AdminAccountInputPort interface {
+	// CreateAdmin は管理者を作成
+	// ActorIDがnilなら、ユーザーが1人もいないときだけ作成できる（最初の管理者の作成）
+	CreateAdmin(ctx context.Context, req *CreateAdminRequest) (*CreateAdminResponse, error)
+
+	// ResetPassword はユーザーのパスワードを再設定し、ログイン中のセッションをすべて無効にする（管理者のみ）
+	ResetPassword(ctx context.Context, req *ResetUserPasswordRequest) (*ResetUserPasswordResponse, error)
+}
+
+// CreateAdminRequest は管理者の作成リクエスト
+type CreateAdminRequest struct {
+	ActorID     *uuid.UUID // 作成する管理者（最初の管理者の作成ならnil）
+	Username    string
+	Email       string
+	DisplayName string // 空ならUsername
+	FirstName   string
+	LastName    string
+	Password    string // 空なら仮パスワードを生成する
+}
+
+// CreateAdminResponse は管理者の作成レスポンス
+type CreateAdminResponse struct {
+	Admin             *entities.User
+	TemporaryPassword string // 生成した仮パスワード（Passwordを指定した場合は空）
+}
+
+// ResetUserPasswordRequest はパスワードの再設定リクエスト
+type ResetUserPasswordRequest struct {
+	ActorID  uuid.UUID
+	UserID   uuid.UUID
+	Password string // 空なら仮パスワードを生成する
+}
+
+// ResetUserPasswordResponse はパスワードの再設定レスポンス
+type ResetUserPasswordResponse struct {
+	User              *entities.User
+	TemporaryPassword string // 生成した仮パスワード（Passwordを指定した場合は空）
+}
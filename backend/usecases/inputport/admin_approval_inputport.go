@@ -0,0 +1,72 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// AdminApprovalInputPort は大口の付与・減算の承認（二人承認）のユースケースインターフェース（すべて管理者のみ）
+type AdminApprovalInputPort interface {
+	// GetApprovalThreshold は承認が必要になる金額を取得（0なら承認は不要）
+	GetApprovalThreshold(ctx context.Context, adminID uuid.UUID) (int64, error)
+
+	// UpdateApprovalThreshold は承認が必要になる金額を設定（0で無効）
+	UpdateApprovalThreshold(ctx context.Context, req *UpdateApprovalThresholdRequest) (int64, error)
+
+	// ListPendingActions は承認待ちの操作を新しい順に取得
+	ListPendingActions(ctx context.Context, req *ListPendingActionsRequest) (*ListPendingActionsResponse, error)
+
+	// GetPendingAction は操作と監査記録を取得
+	GetPendingAction(ctx context.Context, req *GetPendingActionRequest) (*PendingActionDetail, error)
+
+	// ApproveAction は操作を承認して実行する（申請した本人は承認できない）
+	ApproveAction(ctx context.Context, req *ReviewPendingActionRequest) (*PendingActionDetail, error)
+
+	// RejectAction は操作を却下する
+	RejectAction(ctx context.Context, req *ReviewPendingActionRequest) (*PendingActionDetail, error)
+
+	// ExpireStaleActions は期限を過ぎた承認待ちの操作を期限切れにし、件数を返す（ワーカーから呼ぶ）
+	ExpireStaleActions(ctx context.Context, now time.Time) (int, error)
+}
+
+// UpdateApprovalThresholdRequest は承認が必要になる金額の設定リクエスト
+type UpdateApprovalThresholdRequest struct {
+	AdminID   uuid.UUID
+	Threshold int64
+}
+
+// ListPendingActionsRequest は承認待ちの操作一覧の取得リクエスト
+type ListPendingActionsRequest struct {
+	AdminID uuid.UUID
+	Status  entities.PendingAdminActionStatus // 空なら全状態
+	Offset  int
+	Limit   int
+}
+
+// ListPendingActionsResponse は承認待ちの操作一覧の取得レスポンス
+type ListPendingActionsResponse struct {
+	Actions []*entities.PendingAdminAction
+	Total   int64
+}
+
+// GetPendingActionRequest は操作の取得リクエスト
+type GetPendingActionRequest struct {
+	AdminID  uuid.UUID
+	ActionID uuid.UUID
+}
+
+// ReviewPendingActionRequest は操作の承認・却下リクエスト
+type ReviewPendingActionRequest struct {
+	AdminID  uuid.UUID
+	ActionID uuid.UUID
+	Comment  string
+}
+
+// PendingActionDetail は操作と監査記録
+type PendingActionDetail struct {
+	Action *entities.PendingAdminAction
+	Events []*entities.PendingAdminActionEvent
+}
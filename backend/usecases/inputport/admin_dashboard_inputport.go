@@ -0,0 +1,15 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// AdminDashboardInputPort は管理者ダッシュボードのユースケースインターフェース
+type AdminDashboardInputPort interface {
+	// GetDashboard はリアルタイムのカウンターとDBの状態を取得（数秒ごとのポーリングを想定し、短時間キャッシュする）
+	// 管理者でなければErrAdminRequired
+	GetDashboard(ctx context.Context, adminID uuid.UUID) (*entities.AdminDashboard, error)
+}
@@ -30,6 +30,12 @@ type AdminInputPort interface {
 
 	// GetAnalytics は分析データを取得
 	GetAnalytics(ctx context.Context, req *GetAnalyticsRequest) (*GetAnalyticsResponse, error)
+
+	// GetCohortAnalytics はアクティブユーザー推移・コホート継続率・機能別利用状況を取得
+	GetCohortAnalytics(ctx context.Context, req *GetCohortAnalyticsRequest) (*GetCohortAnalyticsResponse, error)
+
+	// GetForecast は失効予定ポイントの予測とポイントの流通速度を取得
+	GetForecast(ctx context.Context, req *GetForecastRequest) (*GetForecastResponse, error)
 }
 
 // GrantPointsRequest はポイント付与リクエスト
@@ -38,13 +44,27 @@ type GrantPointsRequest struct {
 	UserID         uuid.UUID
 	Amount         int64
 	Description    string
+	ReasonCode     string // 必須（有効な理由コードのみ）
+	Tag            string // 任意のプロジェクト・タグ
 	IdempotencyKey string
+	DryRun         bool // trueなら検証と処理を最後まで行い、常にロールバックして結果だけ返す
+	BudgetOverride bool // trueならoverrideの予算を超えて付与する（blockの予算は超えられない）
+	// ApprovedBy は承認待ちの操作を承認した管理者（承認後の実行時のみ設定し、閾値による承認を省く）
+	ApprovedBy *uuid.UUID
 }
 
 // GrantPointsResponse はポイント付与レスポンス
 type GrantPointsResponse struct {
 	Transaction *entities.Transaction
 	User        *entities.User
+	Batch       *entities.PointBatch     // 作成したポイントバッチ（有効期限の確認用）
+	Budgets     []*entities.BudgetStatus // 付与が対象になった予算の付与後の消化状況
+	DryRun      bool
+
+	// PendingAction は承認が必要なため実行せずに登録した操作（このときTransactionはnil）
+	PendingAction *entities.PendingAdminAction
+	// RequiresApproval はドライランの付与を実際に行うと承認待ちになるか
+	RequiresApproval bool
 }
 
 // DeductPointsRequest はポイント減算リクエスト
@@ -53,22 +73,39 @@ type DeductPointsRequest struct {
 	UserID         uuid.UUID
 	Amount         int64
 	Description    string
+	ReasonCode     string // 必須（有効な理由コードのみ）
+	Tag            string // 任意のプロジェクト・タグ
 	IdempotencyKey string
+	DryRun         bool // trueなら検証と処理を最後まで行い、常にロールバックして結果だけ返す
+	// ApprovedBy は承認待ちの操作を承認した管理者（承認後の実行時のみ設定し、閾値による承認を省く）
+	ApprovedBy *uuid.UUID
 }
 
 // DeductPointsResponse はポイント減算レスポンス
 type DeductPointsResponse struct {
 	Transaction *entities.Transaction
 	User        *entities.User
+	DryRun      bool
+
+	// ConsumptionPlan はドライラン時のみ設定する、どのバッチからいくつ引かれるか（古い順）
+	ConsumptionPlan []*entities.BatchConsumption
+	// UncoveredAmount はバッチで賄えず残高からのみ引かれる分（ドライラン時のみ）
+	UncoveredAmount int64
+
+	// PendingAction は承認が必要なため実行せずに登録した操作（このときTransactionはnil）
+	PendingAction *entities.PendingAdminAction
+	// RequiresApproval はドライランの減算を実際に行うと承認待ちになるか
+	RequiresApproval bool
 }
 
 // ListAllUsersRequest はユーザー一覧取得リクエスト
 type ListAllUsersRequest struct {
-	Offset    int
-	Limit     int
-	Search    string // 名前・ユーザー名・IDで検索
-	SortBy    string // created_at, balance, role, username, display_name
-	SortOrder string // asc, desc
+	Offset       int
+	Limit        int
+	Search       string     // 名前・ユーザー名・IDで検索
+	DepartmentID *uuid.UUID // 部署で絞り込み（配下の部署の所属ユーザーも含む）
+	SortBy       string     // created_at, balance, role, username, display_name
+	SortOrder    string     // asc, desc
 }
 
 // ListAllUsersResponse はユーザー一覧取得レスポンス
@@ -81,11 +118,13 @@ type ListAllUsersResponse struct {
 type ListAllTransactionsRequest struct {
 	Offset          int
 	Limit           int
-	TransactionType string // フィルタ: transfer, admin_grant, admin_deduct, system_grant, daily_bonus, etc.
-	DateFrom        string // フィルタ: 開始日（YYYY-MM-DD）
-	DateTo          string // フィルタ: 終了日（YYYY-MM-DD）
-	SortBy          string // ソート列: created_at, amount
-	SortOrder       string // ソート順: asc, desc
+	TransactionType string     // フィルタ: transfer, admin_grant, admin_deduct, system_grant, daily_bonus, etc.
+	DateFrom        string     // フィルタ: 開始日（YYYY-MM-DD）
+	DateTo          string     // フィルタ: 終了日（YYYY-MM-DD）
+	ReasonCode      string     // フィルタ: 理由コード
+	DepartmentID    *uuid.UUID // フィルタ: 部署（配下の部署を含む所属ユーザーが送受信した取引）
+	SortBy          string     // ソート列: created_at, amount
+	SortOrder       string     // ソート順: asc, desc
 }
 
 // TransactionWithUsers はユーザー情報付きトランザクション
@@ -126,7 +165,8 @@ type DeactivateUserResponse struct {
 
 // GetAnalyticsRequest は分析データ取得リクエスト
 type GetAnalyticsRequest struct {
-	Days int // 日別統計の日数（7, 30, 90）
+	Days       int    // 日別統計の日数（7, 30, 90）
+	ReasonCode string // 理由コード別の集計を絞り込む（空なら全コード）
 }
 
 // GetAnalyticsResponse は分析データ取得レスポンス
@@ -135,6 +175,8 @@ type GetAnalyticsResponse struct {
 	TopHolders               []*TopHolder
 	DailyStats               []*DailyStat
 	TransactionTypeBreakdown []*TransactionTypeBreakdown
+	ReasonCodeBreakdown      []*ReasonCodeBreakdown      // Days日間の管理者付与・減算の理由コード別集計
+	Conservation             *entities.PointConservation // システムの口座の残高とポイントの保存の確認結果
 }
 
 // AnalyticsSummary はKPIサマリー
@@ -169,3 +211,93 @@ type TransactionTypeBreakdown struct {
 	Count       int64
 	TotalAmount int64
 }
+
+// ReasonCodeBreakdown は理由コード・取引種別ごとの管理者付与・減算の集計
+type ReasonCodeBreakdown struct {
+	ReasonCode      string
+	Label           string
+	TransactionType string
+	Count           int64
+	TotalAmount     int64
+}
+
+// GetCohortAnalyticsRequest はコホート分析取得リクエスト
+type GetCohortAnalyticsRequest struct {
+	AdminID     uuid.UUID
+	From        time.Time                     // 集計開始（含む）。ゼロ値ならToの12期間前
+	To          time.Time                     // 集計終了（含まない）。ゼロ値なら翌日0時
+	Granularity entities.AnalyticsGranularity // week / month（未指定・不正ならweek）
+	Basis       entities.ActivityBasis        // transactions / logins（未指定・不正ならtransactions）
+}
+
+// GetCohortAnalyticsResponse はコホート分析取得レスポンス
+type GetCohortAnalyticsResponse struct {
+	From         time.Time
+	To           time.Time
+	Granularity  entities.AnalyticsGranularity
+	Basis        entities.ActivityBasis
+	ActiveUsers  []*ActiveUserCount
+	Cohorts      []*CohortRetention
+	FeatureUsage []*FeatureUsage
+}
+
+// ActiveUserCount は期間ごとのアクティブユーザー数
+type ActiveUserCount struct {
+	PeriodStart time.Time
+	ActiveUsers int64
+}
+
+// CohortRetention は登録期間ごとのコホートと継続率
+type CohortRetention struct {
+	CohortStart time.Time
+	Size        int64
+	Retention   []*RetentionPoint // Period=0（登録期間）から集計終了までの各期間
+}
+
+// RetentionPoint は登録から何期間後にどれだけアクティブだったか
+type RetentionPoint struct {
+	Period      int
+	ActiveUsers int64
+	Percentage  float64
+}
+
+// FeatureUsage は機能別の利用状況
+type FeatureUsage struct {
+	Feature     string
+	Count       int64
+	Users       int64
+	TotalAmount int64
+	Percentage  float64 // 全機能の利用回数に占める割合
+}
+
+// GetForecastRequest は失効予測・流通速度取得リクエスト
+type GetForecastRequest struct {
+	AdminID uuid.UUID
+	Weeks   int // 失効予測の週数（1〜26、範囲外なら8）
+	Days    int // 流通速度の集計対象とする直近日数（7, 30, 90、それ以外なら90）
+}
+
+// GetForecastResponse は失効予測・流通速度取得レスポンス
+type GetForecastResponse struct {
+	ExpiryForecast     []*ExpiryForecastWeek
+	TotalExpiring      int64   // 予測期間内に失効予定のポイント合計
+	OutstandingPoints  int64   // 失効前のバッチに残っているポイント合計
+	ExpiringPercentage float64 // OutstandingPointsのうち予測期間内に失効する割合
+	Velocity           *CirculationVelocity
+}
+
+// ExpiryForecastWeek は1週間分の失効予定
+type ExpiryForecastWeek struct {
+	WeekStart time.Time
+	WeekEnd   time.Time // 含まない
+	Amount    int64
+	Users     int64
+	Batches   int64
+}
+
+// CirculationVelocity はポイントを獲得してから使うまでの時間
+type CirculationVelocity struct {
+	Since       time.Time
+	SampleCount int64
+	MedianHours float64
+}
@@ -11,9 +11,12 @@ import (
 // AkerunWorker（インフラ層）からビジネスロジックを分離して呼び出すために使用する
 type AkerunBonusInputPort interface {
 	// ProcessAccesses はアクセス記録を処理してボーナスを付与する
+	// 付与に失敗したアクセス記録は再試行キューに登録する
 	ProcessAccesses(ctx context.Context, accesses []entities.AccessRecord) error
-	// GetLastPolledAt は前回ポーリング時刻を取得する
-	GetLastPolledAt(ctx context.Context) (time.Time, error)
-	// UpdateLastPolledAt はポーリング時刻を更新する
-	UpdateLastPolledAt(ctx context.Context, t time.Time) error
+	// RetryFailedAccesses は再試行時刻を迎えた失敗アクセス記録を再処理する
+	RetryFailedAccesses(ctx context.Context, now time.Time) error
+	// GetLastPolledAt は組織の前回ポーリング時刻を取得する
+	GetLastPolledAt(ctx context.Context, organizationID string) (time.Time, error)
+	// UpdateLastPolledAt は組織のポーリング時刻を更新する（組織ごとに独立して進める）
+	UpdateLastPolledAt(ctx context.Context, organizationID string, t time.Time) error
 }
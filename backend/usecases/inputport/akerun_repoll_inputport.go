@@ -0,0 +1,52 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// AkerunRepollInputPort はAkerunの入退室記録の再取得のユースケースインターフェース
+// 管理者が期間を指定して依頼し、AkerunWorkerが1時間ずつ取得してボーナスを付与し直す
+type AkerunRepollInputPort interface {
+	// RequestRepoll は再取得を依頼する（取得はワーカーが非同期で行う。同時に依頼できるのは1件まで）
+	RequestRepoll(ctx context.Context, req *RequestAkerunRepollRequest) (*entities.AkerunRepoll, error)
+
+	// GetRepoll は再取得の依頼と進み具合を取得
+	GetRepoll(ctx context.Context, adminID, id uuid.UUID) (*entities.AkerunRepoll, error)
+
+	// ListRepolls は再取得の依頼を新しい順に取得
+	ListRepolls(ctx context.Context, req *ListAkerunRepollsRequest) (*ListAkerunRepollsResponse, error)
+
+	// StartNextRepoll は処理待ちか取得中のまま止まった依頼を取得中にして返す（ない場合はnil、ワーカー用）
+	StartNextRepoll(ctx context.Context) (*entities.AkerunRepoll, error)
+
+	// RecordRepollWindow は1期間分の取得が終わったことを記録する（ワーカー用）
+	RecordRepollWindow(ctx context.Context, repoll *entities.AkerunRepoll, fetched int) error
+
+	// FinishRepoll は再取得を終える（causeがあれば失敗、なければ完了にする。ワーカー用）
+	FinishRepoll(ctx context.Context, repoll *entities.AkerunRepoll, cause error) error
+}
+
+// RequestAkerunRepollRequest は再取得の依頼リクエスト
+type RequestAkerunRepollRequest struct {
+	OrganizationID string // 空なら設定済みのすべての組織
+	From           time.Time
+	To             time.Time
+	AdminID        uuid.UUID
+}
+
+// ListAkerunRepollsRequest は再取得の依頼の一覧取得リクエスト
+type ListAkerunRepollsRequest struct {
+	AdminID uuid.UUID
+	Offset  int
+	Limit   int
+}
+
+// ListAkerunRepollsResponse は再取得の依頼の一覧取得レスポンス
+type ListAkerunRepollsResponse struct {
+	Repolls []*entities.AkerunRepoll
+	Total   int64
+}
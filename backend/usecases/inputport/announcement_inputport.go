@@ -0,0 +1,98 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// AnnouncementInputPort はお知らせ配信のユースケースインターフェース
+type AnnouncementInputPort interface {
+	// CreateAnnouncement はお知らせを作成（管理者のみ）
+	CreateAnnouncement(ctx context.Context, req *CreateAnnouncementRequest) (*entities.Announcement, error)
+
+	// UpdateAnnouncement はお知らせを更新（管理者のみ）
+	UpdateAnnouncement(ctx context.Context, req *UpdateAnnouncementRequest) (*entities.Announcement, error)
+
+	// DeleteAnnouncement はお知らせを削除（管理者のみ）
+	DeleteAnnouncement(ctx context.Context, req *DeleteAnnouncementRequest) error
+
+	// GetAnnouncementList は表示期間外を含むお知らせの一覧を取得（管理者のみ）
+	GetAnnouncementList(ctx context.Context, req *GetAnnouncementListRequest) (*GetAnnouncementListResponse, error)
+
+	// GetActiveAnnouncements はユーザーに表示するお知らせを取得
+	GetActiveAnnouncements(ctx context.Context, req *GetActiveAnnouncementsRequest) (*GetActiveAnnouncementsResponse, error)
+
+	// DismissAnnouncement はお知らせを非表示にする
+	DismissAnnouncement(ctx context.Context, req *DismissAnnouncementRequest) error
+}
+
+// AnnouncementContent はお知らせの作成・更新内容
+type AnnouncementContent struct {
+	Title      string
+	Body       string
+	Severity   entities.AnnouncementSeverity
+	Audience   entities.AnnouncementAudience
+	TargetRole entities.UserRole
+	StartsAt   time.Time  // ゼロ値なら即時
+	EndsAt     *time.Time // nilなら無期限
+}
+
+// CreateAnnouncementRequest はお知らせ作成リクエスト
+type CreateAnnouncementRequest struct {
+	AdminID uuid.UUID
+	AnnouncementContent
+}
+
+// UpdateAnnouncementRequest はお知らせ更新リクエスト
+type UpdateAnnouncementRequest struct {
+	AdminID        uuid.UUID
+	AnnouncementID uuid.UUID
+	AnnouncementContent
+}
+
+// DeleteAnnouncementRequest はお知らせ削除リクエスト
+type DeleteAnnouncementRequest struct {
+	AdminID        uuid.UUID
+	AnnouncementID uuid.UUID
+}
+
+// GetAnnouncementListRequest はお知らせ一覧取得リクエスト（管理者用）
+type GetAnnouncementListRequest struct {
+	AdminID uuid.UUID
+	Offset  int
+	Limit   int
+}
+
+// GetAnnouncementListResponse はお知らせ一覧レスポンス（管理者用）
+type GetAnnouncementListResponse struct {
+	Announcements []*entities.Announcement
+	Total         int64
+	Offset        int
+	Limit         int
+}
+
+// GetActiveAnnouncementsRequest はユーザー向けお知らせ取得リクエスト
+type GetActiveAnnouncementsRequest struct {
+	UserID           uuid.UUID
+	IncludeDismissed bool // trueなら非表示にしたお知らせも含める
+}
+
+// ActiveAnnouncement はユーザーに表示するお知らせと非表示状態
+type ActiveAnnouncement struct {
+	Announcement *entities.Announcement
+	Dismissed    bool
+}
+
+// GetActiveAnnouncementsResponse はユーザー向けお知らせレスポンス（重要度の高い順）
+type GetActiveAnnouncementsResponse struct {
+	Announcements []*ActiveAnnouncement
+}
+
+// DismissAnnouncementRequest はお知らせ非表示リクエスト
+type DismissAnnouncementRequest struct {
+	UserID         uuid.UUID
+	AnnouncementID uuid.UUID
+}
@@ -2,6 +2,7 @@ package inputport
 
 import (
 	"context"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/google/uuid"
@@ -23,6 +24,12 @@ type AuthInputPort interface {
 
 	// ValidateSession はセッションを検証
 	ValidateSession(ctx context.Context, sessionToken string) (*entities.Session, error)
+
+	// UnlockAccount はメールで送られたトークンでアカウントロックを解除
+	UnlockAccount(ctx context.Context, req *UnlockAccountRequest) error
+
+	// DeactivateAccount は本人がアカウントを一時的に休止（すべてのセッションを失効）
+	DeactivateAccount(ctx context.Context, req *DeactivateAccountRequest) (*DeactivateAccountResponse, error)
 }
 
 // RegisterRequest は登録リクエスト
@@ -33,12 +40,18 @@ type RegisterRequest struct {
 	DisplayName string
 	FirstName   string
 	LastName    string
+
+	ReferralCode string // 紹介コード（任意）
+	IPAddress    string
 }
 
 // RegisterResponse は登録レスポンス
 type RegisterResponse struct {
-	User    *entities.User
-	Session *entities.Session
+	User            *entities.User
+	Session         *entities.Session     // メール認証が済むまでログインできない設定ならnil
+	OnboardingBonus *entities.Transaction // 付与したウェルカムボーナス（無効・失敗ならnil）
+
+	EmailVerification entities.EmailVerificationStage // メール認証が済むまで制限する操作（求めなければoff）
 }
 
 // LoginRequest はログインリクエスト
@@ -47,12 +60,14 @@ type LoginRequest struct {
 	Password  string
 	IPAddress string
 	UserAgent string
+	Country   string // リバースプロキシが付与する国コード（不明な場合は空）
 }
 
 // LoginResponse はログインレスポンス
 type LoginResponse struct {
-	User    *entities.User
-	Session *entities.Session
+	User        *entities.User
+	Session     *entities.Session
+	Reactivated bool // 休止していたアカウントをこのログインで再開したか
 }
 
 // LogoutRequest はログアウトリクエスト
@@ -69,3 +84,20 @@ type GetCurrentUserRequest struct {
 type GetCurrentUserResponse struct {
 	User *entities.User
 }
+
+// UnlockAccountRequest はアカウントロック解除リクエスト
+type UnlockAccountRequest struct {
+	Token string
+}
+
+// DeactivateAccountRequest はアカウント休止リクエスト
+type DeactivateAccountRequest struct {
+	UserID   uuid.UUID
+	Password string
+}
+
+// DeactivateAccountResponse はアカウント休止レスポンス
+type DeactivateAccountResponse struct {
+	User               *entities.User
+	ReactivatableUntil time.Time // この日時までにログインすれば再開できる
+}
@@ -0,0 +1,24 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// BalanceHolder は送金リクエストのために送信者の残高を確保・解放するインターフェース
+// （TransferRequestInteractorから呼ぶ。確保の使用は承認時の送金と同じトランザクションでPointTransferInteractorが行う）
+type BalanceHolder interface {
+	// Hold は送信者の残高からリクエストの金額を確保し、同じトランザクションでsaveを呼ぶ（使える残高が足りなければErrInsufficientBalance）
+	Hold(ctx context.Context, tr *entities.TransferRequest, save func(ctx context.Context) error) error
+
+	// Release は送金リクエストの確保を戻す（確保していない・使用済み・解放済みなら何もしない）
+	Release(ctx context.Context, transferRequestID uuid.UUID) error
+}
+
+// BalanceHoldReleaser は承認待ちでなくなった送金リクエストの確保をまとめて戻すインターフェース（期限切れにするジョブから呼ぶ）
+type BalanceHoldReleaser interface {
+	// ReleaseClosedHolds は拒否・キャンセル・期限切れになった送金リクエストの確保を戻し、戻した件数を返す
+	ReleaseClosedHolds(ctx context.Context) (int64, error)
+}
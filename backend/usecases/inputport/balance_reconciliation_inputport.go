@@ -0,0 +1,31 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// BalanceReconciliationInputPort は残高と取引履歴の照合のユースケースインターフェース
+type BalanceReconciliationInputPort interface {
+	// RecomputeBalances は全ユーザーの残高を取引履歴から計算し直し、一致しないものを返す（管理者のみ）
+	// Applyなら補正取引を記録して残高を取引履歴に合わせる。BatchSize人ずつ別のトランザクションで処理する
+	RecomputeBalances(ctx context.Context, req *RecomputeBalancesRequest) (*RecomputeBalancesResponse, error)
+}
+
+// RecomputeBalancesRequest は残高の再計算リクエスト
+type RecomputeBalancesRequest struct {
+	AdminID   uuid.UUID
+	Apply     bool   // falseなら照合のみ（残高は変えない）
+	BatchSize int    // 1回に照合するユーザー数（0なら既定値）
+	Reason    string // 補正取引の説明に残す理由
+}
+
+// RecomputeBalancesResponse は残高の再計算レスポンス
+type RecomputeBalancesResponse struct {
+	Applied       bool
+	CheckedUsers  int
+	Batches       int
+	Discrepancies []*entities.BalanceDiscrepancy
+}
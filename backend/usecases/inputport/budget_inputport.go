@@ -0,0 +1,59 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// BudgetInputPort は管理者付与の予算のユースケースインターフェース（すべて管理者のみ）
+type BudgetInputPort interface {
+	// ListBudgets は全予算と現在の期間の消化状況を取得
+	ListBudgets(ctx context.Context, adminID uuid.UUID) ([]*entities.BudgetStatus, error)
+
+	// GetBudget は予算と現在の期間の消化状況を取得
+	GetBudget(ctx context.Context, req *GetBudgetRequest) (*entities.BudgetStatus, error)
+
+	// CreateBudget は予算を作成
+	CreateBudget(ctx context.Context, req *CreateBudgetRequest) (*entities.BudgetStatus, error)
+
+	// UpdateBudget は予算名・金額・超過時の扱い・有効状態を更新
+	UpdateBudget(ctx context.Context, req *UpdateBudgetRequest) (*entities.BudgetStatus, error)
+
+	// DeleteBudget は予算を削除
+	DeleteBudget(ctx context.Context, req *DeleteBudgetRequest) error
+}
+
+// GetBudgetRequest は予算取得リクエスト
+type GetBudgetRequest struct {
+	AdminID  uuid.UUID
+	BudgetID uuid.UUID
+}
+
+// CreateBudgetRequest は予算作成リクエスト
+type CreateBudgetRequest struct {
+	AdminID     uuid.UUID
+	Name        string
+	Scope       entities.BudgetScope
+	ScopeID     *uuid.UUID // departmentなら部署ID、adminなら管理者のユーザーID
+	Period      entities.BudgetPeriod
+	Amount      int64
+	Enforcement entities.BudgetEnforcement
+}
+
+// UpdateBudgetRequest は予算更新リクエスト（対象範囲と期間は変えられない）
+type UpdateBudgetRequest struct {
+	AdminID     uuid.UUID
+	BudgetID    uuid.UUID
+	Name        string
+	Amount      int64
+	Enforcement entities.BudgetEnforcement
+	IsActive    bool
+}
+
+// DeleteBudgetRequest は予算削除リクエスト
+type DeleteBudgetRequest struct {
+	AdminID  uuid.UUID
+	BudgetID uuid.UUID
+}
@@ -0,0 +1,25 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/service"
+)
+
+// BusinessMetricsInputPort は /metrics で公開する業務の指標のユースケースインターフェース
+type BusinessMetricsInputPort interface {
+	// RefreshBusinessMetrics はnow時点の指標を集計し直して保持する（失敗したら前回の値を残す）
+	RefreshBusinessMetrics(ctx context.Context, now time.Time) error
+
+	// GetBusinessMetrics は最後に集計した指標・集計の失敗回数・遅いクエリの件数を返す（DBは参照しない）
+	GetBusinessMetrics() *BusinessMetricsSnapshot
+}
+
+// BusinessMetricsSnapshot は最後に集計した業務の指標
+type BusinessMetricsSnapshot struct {
+	Metrics         *entities.BusinessMetrics // まだ集計できていなければnil
+	RefreshFailures int64                     // 起動してから集計に失敗した回数
+	SlowQueries     []service.SlowQueryCount  // 起動してから閾値を超えたクエリのリポジトリのメソッドごとの件数
+}
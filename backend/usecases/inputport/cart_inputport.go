@@ -0,0 +1,76 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// CartInputPort は商品交換のカートのユースケースインターフェース
+// 複数の商品をカートに入れ、決済で1回のポイント減算にまとめて交換する
+type CartInputPort interface {
+	// GetCart はカートの中身と現在の価格での合計を取得
+	GetCart(ctx context.Context, userID uuid.UUID) (*CartResponse, error)
+
+	// AddItem はカートに商品を追加（入っている商品なら数量を足す）
+	AddItem(ctx context.Context, req *AddCartItemRequest) (*CartResponse, error)
+
+	// UpdateItem はカートの商品の数量を変更（0なら削除）
+	UpdateItem(ctx context.Context, req *UpdateCartItemRequest) (*CartResponse, error)
+
+	// RemoveItem はカートから商品を削除
+	RemoveItem(ctx context.Context, userID, productID uuid.UUID) (*CartResponse, error)
+
+	// Checkout はカートの商品をまとめて交換する
+	// 在庫・購入上限・残高を確認し、ポイントを1回で減算して商品ごとの交換記録を作成する
+	// いずれかの商品で失敗した場合はすべて取り消す（一部だけ交換されることはない）
+	Checkout(ctx context.Context, req *CheckoutCartRequest) (*CheckoutCartResponse, error)
+}
+
+// AddCartItemRequest はカートへの商品の追加リクエスト
+type AddCartItemRequest struct {
+	UserID    uuid.UUID
+	ProductID uuid.UUID
+	Quantity  int
+}
+
+// UpdateCartItemRequest はカートの商品の数量変更リクエスト
+type UpdateCartItemRequest struct {
+	UserID    uuid.UUID
+	ProductID uuid.UUID
+	Quantity  int // 0なら削除
+}
+
+// CartLine はカートの商品1件と現在の価格
+type CartLine struct {
+	Item        *entities.CartItem
+	Product     *entities.Product
+	UnitPrice   int64                 // 価格ルール適用後の単価
+	Subtotal    int64                 // UnitPrice × 数量
+	PricingRule *entities.PricingRule // 適用する価格ルール（なければnil）
+	Available   bool                  // 今の在庫・販売状況で交換できるか
+}
+
+// CartResponse はカートの中身
+// 価格は表示時点のもので、決済時に価格ルールを適用し直す
+type CartResponse struct {
+	Lines       []*CartLine
+	TotalPoints int64
+}
+
+// CheckoutCartRequest はカートの決済リクエスト
+type CheckoutCartRequest struct {
+	UserID uuid.UUID
+	Notes  string // 受取場所、希望時間など（すべての交換記録に付ける）
+	// ShippingAddressID は配送が必要な商品のお届け先（nilなら既定のお届け先）
+	ShippingAddressID *uuid.UUID
+}
+
+// CheckoutCartResponse はカートの決済レスポンス
+type CheckoutCartResponse struct {
+	Exchanges   []*entities.ProductExchange // カートの商品ごとの交換記録（同じ取引に紐づく）
+	Transaction *entities.Transaction       // まとめて減算したポイントの取引
+	User        *entities.User
+	TotalPoints int64
+}
@@ -0,0 +1,42 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// ContentModerationInputPort はユーザー入力テキストのモデレーションユースケースインターフェース
+type ContentModerationInputPort interface {
+	// CheckContent はテキストを判定し、不許可なら違反を記録してErrContentRejectedを返す
+	CheckContent(ctx context.Context, userID uuid.UUID, field entities.ModerationField, text string) error
+
+	// GetViolations は違反記録の一覧を取得（管理者のみ）
+	GetViolations(ctx context.Context, req *GetContentViolationsRequest) (*GetContentViolationsResponse, error)
+
+	// ReviewViolation は違反記録をレビュー済みにする（管理者のみ）
+	ReviewViolation(ctx context.Context, req *ReviewContentViolationRequest) (*entities.ContentViolation, error)
+}
+
+// GetContentViolationsRequest は違反記録一覧取得リクエスト
+type GetContentViolationsRequest struct {
+	AdminID        uuid.UUID
+	UnreviewedOnly bool
+	Offset         int
+	Limit          int
+}
+
+// GetContentViolationsResponse は違反記録一覧レスポンス
+type GetContentViolationsResponse struct {
+	Violations []*entities.ContentViolation
+	Total      int64
+	Offset     int
+	Limit      int
+}
+
+// ReviewContentViolationRequest は違反記録のレビューリクエスト
+type ReviewContentViolationRequest struct {
+	AdminID     uuid.UUID
+	ViolationID uuid.UUID
+}
@@ -0,0 +1,39 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+)
+
+// CSRFInputPort はCSRFトークンのユースケースインターフェース
+type CSRFInputPort interface {
+	// VerifyToken は状態を変えるリクエストのCSRFトークンを検証し、次のリクエストで使うトークンを返す
+	// per_requestの設定ではトークンを入れ替える
+	VerifyToken(ctx context.Context, req *VerifyCSRFTokenRequest) (*CSRFTokenResponse, error)
+
+	// IssueToken は今のCSRFトークンを返す（期限切れなら入れ替える）
+	// SPAが再ログインせずにトークンを取得し直すために使う
+	IssueToken(ctx context.Context, req *IssueCSRFTokenRequest) (*CSRFTokenResponse, error)
+}
+
+// VerifyCSRFTokenRequest はCSRFトークンの検証リクエスト
+type VerifyCSRFTokenRequest struct {
+	Session *entities.Session
+	Token   string
+	Now     time.Time
+}
+
+// IssueCSRFTokenRequest はCSRFトークンの取得リクエスト
+type IssueCSRFTokenRequest struct {
+	Session *entities.Session
+	Now     time.Time
+}
+
+// CSRFTokenResponse は次のリクエストで使うCSRFトークン
+type CSRFTokenResponse struct {
+	Token     string
+	ExpiresAt *time.Time // 期限がなければnil
+	Rotated   bool       // このリクエストで入れ替えたか
+}
@@ -2,6 +2,7 @@ package inputport
 
 import (
 	"context"
+	"io"
 
 	"github.com/gity/point-system/entities"
 	"github.com/google/uuid"
@@ -21,11 +22,27 @@ type DailyBonusInputPort interface {
 	// UpdateLotteryTiers は抽選ティアを一括更新（管理者用）
 	UpdateLotteryTiers(ctx context.Context, req *UpdateLotteryTiersRequest) error
 
+	// UpdateBonusTimezone はボーナス日の区切りに使うタイムゾーンを変更（管理者用）
+	UpdateBonusTimezone(ctx context.Context, req *UpdateBonusTimezoneRequest) error
+
 	// MarkBonusViewed はボーナスを閲覧済みにする
 	MarkBonusViewed(ctx context.Context, req *MarkBonusViewedRequest) error
 
 	// DrawLotteryAndGrant はルーレットを実行しポイントを付与する
 	DrawLotteryAndGrant(ctx context.Context, req *DrawLotteryRequest) (*DrawLotteryResponse, error)
+
+	// ListFailedAccesses はボーナスの付与に失敗した入退室記録を取得（管理者用）
+	ListFailedAccesses(ctx context.Context, req *ListFailedAccessesRequest) (*ListFailedAccessesResponse, error)
+
+	// RequeueFailedAccess は再試行の上限に達した入退室記録を再試行待ちに戻す（管理者用）
+	RequeueFailedAccess(ctx context.Context, req *RequeueFailedAccessRequest) (*entities.FailedAkerunAccess, error)
+
+	// ExportBonuses はボーナス日が期間内のボーナスをCSVでwへ書き出す（管理者用）
+	// 期間が不正な場合はwに何も書かずにエラーを返す
+	ExportBonuses(ctx context.Context, req *ExportBonusesRequest, w io.Writer) error
+
+	// GetAttendanceMatrix は月のユーザーごと・日ごとの出席表を取得（管理者用）
+	GetAttendanceMatrix(ctx context.Context, req *GetAttendanceMatrixRequest) (*entities.AttendanceMatrix, error)
 }
 
 // GetTodayBonusRequest は本日のボーナス状況取得リクエスト
@@ -57,6 +74,12 @@ type GetRecentBonusesResponse struct {
 type BonusSettingsResponse struct {
 	BonusPoints  int64                   // フォールバック固定ポイント
 	LotteryTiers []*entities.LotteryTier // 抽選ティア一覧
+	Timezone     string                  // ボーナス日の区切りに使うタイムゾーン（ユーザーが設定していない場合）
+}
+
+// UpdateBonusTimezoneRequest はボーナス日のタイムゾーン変更リクエスト
+type UpdateBonusTimezoneRequest struct {
+	Timezone string // IANAタイムゾーン名（例: Asia/Tokyo）
 }
 
 // LotteryTierInput は抽選ティアの入力
@@ -89,3 +112,36 @@ type DrawLotteryResponse struct {
 	LotteryTierName string
 	BonusID         uuid.UUID
 }
+
+// ListFailedAccessesRequest は失敗した入退室記録の一覧取得リクエスト
+type ListFailedAccessesRequest struct {
+	AdminID uuid.UUID
+	Status  entities.FailedAkerunAccessStatus // 空なら全状態
+	Offset  int
+	Limit   int
+}
+
+// ListFailedAccessesResponse は失敗した入退室記録の一覧取得レスポンス
+type ListFailedAccessesResponse struct {
+	Accesses []*entities.FailedAkerunAccess
+	Total    int64
+}
+
+// RequeueFailedAccessRequest は失敗した入退室記録の再投入リクエスト
+type RequeueFailedAccessRequest struct {
+	ID      uuid.UUID
+	AdminID uuid.UUID
+}
+
+// ExportBonusesRequest はボーナスのCSV出力リクエスト
+type ExportBonusesRequest struct {
+	AdminID uuid.UUID
+	From    string // YYYY-MM-DD
+	To      string // YYYY-MM-DD（この日を含む）
+}
+
+// GetAttendanceMatrixRequest は出席表取得リクエスト
+type GetAttendanceMatrixRequest struct {
+	AdminID uuid.UUID
+	Month   string // YYYY-MM
+}
@@ -0,0 +1,33 @@
+package inputport
+
+import (
+	"context"
+	"io"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// DataExportInputPort は個人データエクスポートのユースケースインターフェース
+type DataExportInputPort interface {
+	// RequestExport はエクスポートを依頼する（作成はワーカーが非同期で行う）
+	RequestExport(ctx context.Context, userID uuid.UUID) (*entities.DataExport, error)
+
+	// GetLatestExport はユーザーの最新のエクスポート依頼を取得（ない場合はnil）
+	GetLatestExport(ctx context.Context, userID uuid.UUID) (*entities.DataExport, error)
+
+	// OpenExport は作成済みのエクスポートファイルを開く（本人のみ）
+	OpenExport(ctx context.Context, userID, exportID uuid.UUID) (*OpenDataExportResponse, error)
+
+	// ProcessPendingExports は処理待ちの依頼からエクスポートファイルを作成し、完了をメールで知らせる（ワーカー用）
+	ProcessPendingExports(ctx context.Context, limit int) (int, error)
+
+	// CleanupExpiredExports はダウンロード期限を過ぎたファイルを削除する（ワーカー用）
+	CleanupExpiredExports(ctx context.Context, limit int) (int, error)
+}
+
+// OpenDataExportResponse はエクスポートファイルのダウンロードレスポンス
+type OpenDataExportResponse struct {
+	Export  *entities.DataExport
+	Content io.ReadCloser
+}
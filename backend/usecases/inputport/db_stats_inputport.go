@@ -0,0 +1,14 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+)
+
+// DBStatsInputPort はDBのコネクションプールの状態の確認のユースケースインターフェース
+type DBStatsInputPort interface {
+	// GetDBStats はプライマリ・リードレプリカのコネクションプールの状態を取得（管理者のみ）
+	GetDBStats(ctx context.Context, adminID uuid.UUID) ([]service.DBPoolStats, error)
+}
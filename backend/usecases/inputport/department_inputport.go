@@ -0,0 +1,80 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// DepartmentInputPort は組織・部署のユースケースインターフェース（すべて管理者のみ）
+type DepartmentInputPort interface {
+	// ListDepartments は全部署を取得
+	ListDepartments(ctx context.Context, adminID uuid.UUID) ([]*entities.Department, error)
+
+	// CreateDepartment は部署を作成
+	CreateDepartment(ctx context.Context, req *CreateDepartmentRequest) (*entities.Department, error)
+
+	// UpdateDepartment は部署名・親・月間予算を更新
+	UpdateDepartment(ctx context.Context, req *UpdateDepartmentRequest) (*entities.Department, error)
+
+	// DeleteDepartment は部署を削除（子部署があれば削除できない、所属ユーザーは未所属になる）
+	DeleteDepartment(ctx context.Context, req *DeleteDepartmentRequest) error
+
+	// AssignUserDepartment はユーザーの所属部署を設定・解除
+	AssignUserDepartment(ctx context.Context, req *AssignUserDepartmentRequest) (*entities.User, error)
+
+	// GetDepartmentAnalytics は部署ごとの付与・利用ポイントを取得
+	GetDepartmentAnalytics(ctx context.Context, req *GetDepartmentAnalyticsRequest) (*GetDepartmentAnalyticsResponse, error)
+}
+
+// CreateDepartmentRequest は部署作成リクエスト
+type CreateDepartmentRequest struct {
+	AdminID            uuid.UUID
+	Name               string
+	ParentID           *uuid.UUID // nilなら最上位の組織
+	MonthlyGrantBudget *int64     // nilなら予算なし
+}
+
+// UpdateDepartmentRequest は部署更新リクエスト（指定した値で置き換える）
+type UpdateDepartmentRequest struct {
+	AdminID            uuid.UUID
+	DepartmentID       uuid.UUID
+	Name               string
+	ParentID           *uuid.UUID
+	MonthlyGrantBudget *int64
+}
+
+// DeleteDepartmentRequest は部署削除リクエスト
+type DeleteDepartmentRequest struct {
+	AdminID      uuid.UUID
+	DepartmentID uuid.UUID
+}
+
+// AssignUserDepartmentRequest は所属部署の設定リクエスト
+type AssignUserDepartmentRequest struct {
+	AdminID      uuid.UUID
+	UserID       uuid.UUID
+	DepartmentID *uuid.UUID // nilなら所属を解除
+}
+
+// GetDepartmentAnalyticsRequest は部署別分析リクエスト
+type GetDepartmentAnalyticsRequest struct {
+	AdminID uuid.UUID
+	Days    int // 集計期間（日数）
+}
+
+// GetDepartmentAnalyticsResponse は部署別分析レスポンス
+type GetDepartmentAnalyticsResponse struct {
+	Departments []*DepartmentAnalytics
+}
+
+// DepartmentAnalytics は部署ごとの集計（配下の部署を含めた合計も返す）
+type DepartmentAnalytics struct {
+	*entities.DepartmentBreakdownResult
+	MonthlyGrantBudget *int64
+	BudgetUsed         int64 // 当月の管理者付与の合計（予算の消化状況）
+	TotalGranted       int64 // 配下の部署を含めた付与ポイント
+	TotalSpent         int64 // 配下の部署を含めた利用ポイント
+	TotalMemberCount   int64 // 配下の部署を含めた所属ユーザー数
+}
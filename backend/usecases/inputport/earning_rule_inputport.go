@@ -0,0 +1,96 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// EarningEventRecorder は各機能のユースケースがポイント獲得ルールの判定対象となる出来事を伝えるインターフェース
+// （プロフィールの更新・友達申請の承認・送金の完了後に呼ぶ）
+type EarningEventRecorder interface {
+	// Record は出来事に一致する有効なルールでポイントを付与する
+	// 付与はルールごとに別のトランザクションで行い、失敗しても呼び出し側の処理は失敗させない
+	Record(ctx context.Context, event *entities.EarningEvent)
+}
+
+// EarningRuleInputPort はポイント獲得ルール管理のユースケースインターフェース（管理者のみ）
+type EarningRuleInputPort interface {
+	// CreateEarningRule はポイント獲得ルールを作成
+	CreateEarningRule(ctx context.Context, req *CreateEarningRuleRequest) (*entities.EarningRule, error)
+
+	// UpdateEarningRule はポイント獲得ルールを更新
+	UpdateEarningRule(ctx context.Context, req *UpdateEarningRuleRequest) (*entities.EarningRule, error)
+
+	// DeleteEarningRule はポイント獲得ルールを削除（付与済みのポイントは残る）
+	DeleteEarningRule(ctx context.Context, req *DeleteEarningRuleRequest) error
+
+	// GetEarningRule はポイント獲得ルールと付与の実績を取得
+	GetEarningRule(ctx context.Context, req *GetEarningRuleRequest) (*EarningRuleWithStats, error)
+
+	// GetEarningRuleList は期間外・無効を含むポイント獲得ルールの一覧を付与の実績つきで取得
+	GetEarningRuleList(ctx context.Context, req *GetEarningRuleListRequest) (*GetEarningRuleListResponse, error)
+}
+
+// EarningRuleContent はポイント獲得ルールの作成・更新内容
+type EarningRuleContent struct {
+	Name             string
+	Trigger          entities.EarningTrigger
+	Points           int64
+	Occurrence       int64      // 0なら毎回
+	MinAmount        int64      // 0なら条件なし
+	OncePerUser      bool       // 1人1回だけ
+	MaxGrantsPerUser int        // 0なら無制限
+	MaxGrantsTotal   int        // 0なら無制限
+	StartsAt         time.Time  // ゼロ値なら即時
+	EndsAt           *time.Time // nilなら無期限
+}
+
+// CreateEarningRuleRequest はポイント獲得ルール作成リクエスト
+type CreateEarningRuleRequest struct {
+	AdminID uuid.UUID
+	EarningRuleContent
+}
+
+// UpdateEarningRuleRequest はポイント獲得ルール更新リクエスト
+type UpdateEarningRuleRequest struct {
+	AdminID       uuid.UUID
+	EarningRuleID uuid.UUID
+	IsActive      bool // falseなら期間内でも付与しない
+	EarningRuleContent
+}
+
+// DeleteEarningRuleRequest はポイント獲得ルール削除リクエスト
+type DeleteEarningRuleRequest struct {
+	AdminID       uuid.UUID
+	EarningRuleID uuid.UUID
+}
+
+// GetEarningRuleRequest はポイント獲得ルール取得リクエスト
+type GetEarningRuleRequest struct {
+	AdminID       uuid.UUID
+	EarningRuleID uuid.UUID
+}
+
+// GetEarningRuleListRequest はポイント獲得ルール一覧取得リクエスト
+type GetEarningRuleListRequest struct {
+	AdminID uuid.UUID
+	Offset  int
+	Limit   int
+}
+
+// EarningRuleWithStats はポイント獲得ルールと付与の実績
+type EarningRuleWithStats struct {
+	Rule  *entities.EarningRule
+	Stats *entities.EarningRuleStats // 付与がなければ回数・合計が0
+}
+
+// GetEarningRuleListResponse はポイント獲得ルール一覧レスポンス
+type GetEarningRuleListResponse struct {
+	Rules  []*EarningRuleWithStats
+	Total  int64
+	Offset int
+	Limit  int
+}
@@ -0,0 +1,81 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// EmailTemplateInputPort はメールテンプレート管理のユースケースインターフェース（管理者用）
+type EmailTemplateInputPort interface {
+	// ListTemplates はメールの種類ごとに現在のテンプレートを取得
+	ListTemplates(ctx context.Context, adminID uuid.UUID) ([]*EmailTemplateSummary, error)
+
+	// GetTemplate はメールの種類の現在のテンプレートと過去の版を取得
+	GetTemplate(ctx context.Context, adminID uuid.UUID, key entities.EmailTemplateKey) (*GetEmailTemplateResponse, error)
+
+	// SaveTemplate はテンプレートを新しい版として保存
+	SaveTemplate(ctx context.Context, req *SaveEmailTemplateRequest) (*entities.EmailTemplate, error)
+
+	// RestoreVersion は過去の版の内容を新しい版として保存
+	RestoreVersion(ctx context.Context, req *RestoreEmailTemplateRequest) (*entities.EmailTemplate, error)
+
+	// ResetTemplate はすべての版を削除して組み込みの既定に戻す
+	ResetTemplate(ctx context.Context, adminID uuid.UUID, key entities.EmailTemplateKey) error
+
+	// PreviewTemplate はテンプレート（省略時は現在のテンプレート）を例の変数で描画
+	PreviewTemplate(ctx context.Context, req *PreviewEmailTemplateRequest) (*entities.RenderedEmail, error)
+
+	// SendTestEmail はテンプレート（省略時は現在のテンプレート）を例の変数で描画し、管理者自身のアドレスへ送信
+	SendTestEmail(ctx context.Context, req *SendTestEmailRequest) (*entities.RenderedEmail, error)
+}
+
+// EmailTemplateSummary はメールの種類と現在のテンプレート
+type EmailTemplateSummary struct {
+	Definition *entities.EmailTemplateDefinition
+	Current    *entities.EmailTemplate // 編集されていなければ組み込みの既定（Version=0）
+}
+
+// GetEmailTemplateResponse はテンプレート詳細レスポンス
+type GetEmailTemplateResponse struct {
+	Definition *entities.EmailTemplateDefinition
+	Current    *entities.EmailTemplate
+	Versions   []*entities.EmailTemplate // 新しい順
+}
+
+// EmailTemplateDraft は保存前のテンプレート
+type EmailTemplateDraft struct {
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+// SaveEmailTemplateRequest はテンプレート保存リクエスト
+type SaveEmailTemplateRequest struct {
+	AdminID uuid.UUID
+	Key     entities.EmailTemplateKey
+	EmailTemplateDraft
+}
+
+// RestoreEmailTemplateRequest は過去の版の復元リクエスト
+type RestoreEmailTemplateRequest struct {
+	AdminID uuid.UUID
+	Key     entities.EmailTemplateKey
+	Version int
+}
+
+// PreviewEmailTemplateRequest はプレビューリクエスト
+type PreviewEmailTemplateRequest struct {
+	AdminID   uuid.UUID
+	Key       entities.EmailTemplateKey
+	Draft     *EmailTemplateDraft // nilなら現在のテンプレート
+	Variables map[string]string   // 例の変数を上書きする値
+}
+
+// SendTestEmailRequest はテスト送信リクエスト
+type SendTestEmailRequest struct {
+	AdminID uuid.UUID
+	Key     entities.EmailTemplateKey
+	Draft   *EmailTemplateDraft // nilなら現在のテンプレート
+}
@@ -0,0 +1,42 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// EmailVerificationGate は新規登録ユーザーのメール認証を確認するインターフェース
+// （AuthInteractorから呼ぶ）
+type EmailVerificationGate interface {
+	// StartVerification は設定が有効なら登録したユーザーに認証メールを送り、認証まで制限する操作を返す（無効ならoff）
+	StartVerification(ctx context.Context, user *entities.User) (entities.EmailVerificationStage, error)
+
+	// CheckLogin はユーザーがログインできるかを確認（認証前のログインを制限していて未認証ならErrEmailNotVerified）
+	CheckLogin(ctx context.Context, user *entities.User) error
+}
+
+// EmailVerificationRequirementInputPort は新規登録ユーザーのメール認証のユースケースインターフェース
+type EmailVerificationRequirementInputPort interface {
+	// GetRequirement はメール認証を求める設定を取得（管理者のみ）
+	GetRequirement(ctx context.Context, adminID uuid.UUID) (*entities.EmailVerificationRequirement, error)
+
+	// UpdateRequirement はメール認証を求める設定を更新（管理者のみ）
+	UpdateRequirement(ctx context.Context, req *UpdateEmailVerificationRequirementRequest) (*entities.EmailVerificationRequirement, error)
+
+	// ResendVerification は未認証のユーザーに認証メールを再送
+	// 登録の有無を推測されないよう、対象外・回数の上限でもエラーにしない
+	ResendVerification(ctx context.Context, req *ResendEmailVerificationRequest) error
+}
+
+// UpdateEmailVerificationRequirementRequest はメール認証を求める設定の更新リクエスト
+type UpdateEmailVerificationRequirementRequest struct {
+	AdminID uuid.UUID
+	Stage   entities.EmailVerificationStage
+}
+
+// ResendEmailVerificationRequest は認証メールの再送リクエスト
+type ResendEmailVerificationRequest struct {
+	Email string
+}
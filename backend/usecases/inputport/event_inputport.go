@@ -0,0 +1,95 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// EventInputPort はチェックインイベントのユースケースインターフェース
+type EventInputPort interface {
+	// CreateEvent はイベントを作成（管理者のみ）
+	CreateEvent(ctx context.Context, req *CreateEventRequest) (*entities.Event, error)
+
+	// UpdateEvent はイベントを更新（管理者のみ）
+	UpdateEvent(ctx context.Context, req *UpdateEventRequest) (*entities.Event, error)
+
+	// GetEventList は終了・無効を含むイベントの一覧を取得（管理者のみ）
+	GetEventList(ctx context.Context, req *GetEventListRequest) (*GetEventListResponse, error)
+
+	// GetEventAttendees はイベントの参加者の一覧を取得（管理者のみ）
+	GetEventAttendees(ctx context.Context, req *GetEventAttendeesRequest) (*GetEventAttendeesResponse, error)
+
+	// CheckIn はイベントのQRコードを読み取ったユーザーにポイントを付与する（1人1回）
+	CheckIn(ctx context.Context, req *EventCheckInRequest) (*EventCheckInResponse, error)
+}
+
+// EventContent はイベントの作成・更新内容
+type EventContent struct {
+	Name        string
+	Description string
+	Points      int64
+	Capacity    int       // 0なら無制限
+	StartsAt    time.Time // ゼロ値なら即時
+	EndsAt      time.Time
+}
+
+// CreateEventRequest はイベント作成リクエスト
+type CreateEventRequest struct {
+	AdminID uuid.UUID
+	EventContent
+}
+
+// UpdateEventRequest はイベント更新リクエスト
+type UpdateEventRequest struct {
+	AdminID  uuid.UUID
+	EventID  uuid.UUID
+	IsActive bool // falseなら期間内でもチェックインを受け付けない
+	EventContent
+}
+
+// GetEventListRequest はイベント一覧取得リクエスト
+type GetEventListRequest struct {
+	AdminID uuid.UUID
+	Offset  int
+	Limit   int
+}
+
+// GetEventListResponse はイベント一覧レスポンス
+type GetEventListResponse struct {
+	Events []*entities.Event
+	Total  int64
+	Offset int
+	Limit  int
+}
+
+// GetEventAttendeesRequest はイベント参加者一覧取得リクエスト
+type GetEventAttendeesRequest struct {
+	AdminID uuid.UUID
+	EventID uuid.UUID
+	Offset  int
+	Limit   int
+}
+
+// GetEventAttendeesResponse はイベント参加者一覧レスポンス
+type GetEventAttendeesResponse struct {
+	Event     *entities.Event
+	Attendees []*entities.EventAttendee
+	Offset    int
+	Limit     int
+}
+
+// EventCheckInRequest はイベントチェックインリクエスト
+type EventCheckInRequest struct {
+	UserID uuid.UUID
+	Code   string // 読み取ったQRコードのデータ
+}
+
+// EventCheckInResponse はイベントチェックインレスポンス
+type EventCheckInResponse struct {
+	Event       *entities.Event
+	Attendance  *entities.EventAttendance
+	Transaction *entities.Transaction
+}
@@ -0,0 +1,26 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// FriendDiscoveryInputPort は連絡先ハッシュによる友達検索のユースケースインターフェース
+type FriendDiscoveryInputPort interface {
+	// DiscoverFriends はクライアントでSHA-256したメールアドレス・ユーザー名から登録済みのユーザーを探す
+	// 生の連絡先はサーバーに送られず、公開設定（Discoverable）のユーザーのみが一致する
+	DiscoverFriends(ctx context.Context, req *DiscoverFriendsRequest) (*DiscoverFriendsResponse, error)
+}
+
+// DiscoverFriendsRequest は友達検索リクエスト
+type DiscoverFriendsRequest struct {
+	UserID uuid.UUID
+	Hashes []string // lower(trim(メールアドレス or ユーザー名)) のSHA-256（16進数）
+}
+
+// DiscoverFriendsResponse は友達検索レスポンス
+type DiscoverFriendsResponse struct {
+	Matches []*entities.ContactMatch
+}
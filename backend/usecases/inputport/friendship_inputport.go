@@ -2,6 +2,7 @@ package inputport
 
 import (
 	"context"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/google/uuid"
@@ -29,6 +30,9 @@ type FriendshipInputPort interface {
 
 	// GetFriendPendingRequestCount は保留中の友達申請件数を取得
 	GetFriendPendingRequestCount(ctx context.Context, req *GetFriendPendingRequestCountRequest) (*GetFriendPendingRequestCountResponse, error)
+
+	// GetMutualFriends は相手のユーザーとの共通の友達を取得
+	GetMutualFriends(ctx context.Context, req *GetMutualFriendsRequest) (*GetMutualFriendsResponse, error)
 }
 
 // SendFriendRequestRequest は友達申請リクエスト
@@ -73,8 +77,10 @@ type GetFriendsRequest struct {
 
 // FriendInfo は友達情報
 type FriendInfo struct {
-	Friendship *entities.Friendship
-	Friend     *entities.User
+	Friendship      *entities.Friendship
+	Friend          *entities.User
+	Since           time.Time                // 友達になった日時
+	PointsExchanged entities.PointsExchanged // 2人の間でやり取りしたポイント
 }
 
 // GetFriendsResponse は友達一覧取得レスポンス
@@ -120,3 +126,17 @@ type GetFriendPendingRequestCountRequest struct {
 type GetFriendPendingRequestCountResponse struct {
 	Count int64
 }
+
+// GetMutualFriendsRequest は共通の友達取得リクエスト
+type GetMutualFriendsRequest struct {
+	UserID      uuid.UUID
+	OtherUserID uuid.UUID
+	Offset      int
+	Limit       int
+}
+
+// GetMutualFriendsResponse は共通の友達取得レスポンス
+type GetMutualFriendsResponse struct {
+	Friends []*entities.User
+	Count   int64 // ページングに関わらない共通の友達の総数
+}
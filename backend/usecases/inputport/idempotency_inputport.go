@@ -0,0 +1,40 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// IdempotencyInputPort はIdempotency-Keyヘッダーによる再送制御のユースケースインターフェース
+type IdempotencyInputPort interface {
+	// Begin はリクエストの処理開始を記録する
+	// 同じリクエストが処理済みなら、保存済みのレスポンスをReplayに入れて返す
+	Begin(ctx context.Context, req *BeginIdempotentRequestRequest) (*BeginIdempotentRequestResponse, error)
+
+	// Complete はレスポンスを保存する（5xxは保存せず、同じキーでの再試行を許可する）
+	Complete(ctx context.Context, req *CompleteIdempotentRequestRequest) error
+}
+
+// BeginIdempotentRequestRequest は処理開始リクエスト
+type BeginIdempotentRequestRequest struct {
+	Scope       string // "user:<id>" / "kiosk:<id>"
+	Key         string
+	Route       string
+	RequestHash string
+}
+
+// BeginIdempotentRequestResponse は処理開始レスポンス
+type BeginIdempotentRequestResponse struct {
+	RequestID uuid.UUID                   // 新しく処理する場合のID（Completeに渡す）
+	Replay    *entities.IdempotentRequest // 処理済みの場合の保存済みレスポンス
+}
+
+// CompleteIdempotentRequestRequest はレスポンス保存リクエスト
+type CompleteIdempotentRequestRequest struct {
+	RequestID   uuid.UUID
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
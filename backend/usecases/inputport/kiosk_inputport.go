@@ -0,0 +1,134 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// KioskInputPort はキオスク端末機能のユースケースインターフェース
+type KioskInputPort interface {
+	// AuthenticateDevice はAPIキーで端末を認証
+	AuthenticateDevice(ctx context.Context, apiKey string) (*entities.KioskDevice, error)
+
+	// LookupUser は個人QRコードまたはカードIDでユーザーを検索
+	LookupUser(ctx context.Context, req *KioskLookupUserRequest) (*KioskLookupUserResponse, error)
+
+	// GrantBonus は端末に設定されたボーナスをユーザーに付与
+	GrantBonus(ctx context.Context, req *KioskGrantBonusRequest) (*KioskGrantBonusResponse, error)
+
+	// RegisterDevice は端末を登録（管理者用）
+	RegisterDevice(ctx context.Context, req *RegisterKioskDeviceRequest) (*RegisterKioskDeviceResponse, error)
+
+	// ListDevices は端末一覧を取得（管理者用）
+	ListDevices(ctx context.Context, req *ListKioskDevicesRequest) (*ListKioskDevicesResponse, error)
+
+	// UpdateDevice は端末設定を更新（管理者用）
+	UpdateDevice(ctx context.Context, req *UpdateKioskDeviceRequest) (*UpdateKioskDeviceResponse, error)
+
+	// RotateDeviceKey は端末のAPIキーを再発行（管理者用）
+	RotateDeviceKey(ctx context.Context, req *RotateKioskDeviceKeyRequest) (*RegisterKioskDeviceResponse, error)
+
+	// DeactivateDevice は端末を無効化（管理者用）
+	DeactivateDevice(ctx context.Context, req *DeactivateKioskDeviceRequest) error
+
+	// RegisterCard はカードIDをユーザーに紐付け（管理者用）
+	RegisterCard(ctx context.Context, req *RegisterKioskCardRequest) (*entities.KioskCard, error)
+
+	// DeleteCard はカードIDの紐付けを解除（管理者用）
+	DeleteCard(ctx context.Context, req *DeleteKioskCardRequest) error
+}
+
+// KioskLookupUserRequest はユーザー検索リクエスト
+// QRCode（user:{user_id}形式）とCardIDのどちらか一方を指定する
+type KioskLookupUserRequest struct {
+	DeviceID uuid.UUID
+	QRCode   string
+	CardID   string
+}
+
+// KioskLookupUserResponse はユーザー検索レスポンス
+type KioskLookupUserResponse struct {
+	User           *entities.User
+	AlreadyGranted bool // 本日この端末で付与済み
+}
+
+// KioskGrantBonusRequest はボーナス付与リクエスト
+type KioskGrantBonusRequest struct {
+	DeviceID       uuid.UUID
+	QRCode         string
+	CardID         string
+	IdempotencyKey string // 端末側で生成（オフライン再送時も同じ値を使う）
+}
+
+// KioskGrantBonusResponse はボーナス付与レスポンス
+type KioskGrantBonusResponse struct {
+	Grant    *entities.KioskGrant
+	User     *entities.User
+	Replayed bool // 冪等性キーによる再送で、既存の付与結果を返した場合true
+}
+
+// RegisterKioskDeviceRequest は端末登録リクエスト
+type RegisterKioskDeviceRequest struct {
+	AdminID     uuid.UUID
+	Name        string
+	BonusAmount int64
+	DailyLimit  int
+}
+
+// RegisterKioskDeviceResponse は端末登録レスポンス
+type RegisterKioskDeviceResponse struct {
+	Device *entities.KioskDevice
+	APIKey string // 平文のAPIキー（この時のみ返却）
+}
+
+// ListKioskDevicesRequest は端末一覧取得リクエスト
+type ListKioskDevicesRequest struct {
+	AdminID uuid.UUID
+}
+
+// ListKioskDevicesResponse は端末一覧取得レスポンス
+type ListKioskDevicesResponse struct {
+	Devices []*entities.KioskDevice
+}
+
+// UpdateKioskDeviceRequest は端末設定更新リクエスト
+type UpdateKioskDeviceRequest struct {
+	AdminID     uuid.UUID
+	DeviceID    uuid.UUID
+	Name        string
+	BonusAmount int64
+	DailyLimit  int
+	IsActive    bool
+}
+
+// UpdateKioskDeviceResponse は端末設定更新レスポンス
+type UpdateKioskDeviceResponse struct {
+	Device *entities.KioskDevice
+}
+
+// RotateKioskDeviceKeyRequest はAPIキー再発行リクエスト
+type RotateKioskDeviceKeyRequest struct {
+	AdminID  uuid.UUID
+	DeviceID uuid.UUID
+}
+
+// DeactivateKioskDeviceRequest は端末無効化リクエスト
+type DeactivateKioskDeviceRequest struct {
+	AdminID  uuid.UUID
+	DeviceID uuid.UUID
+}
+
+// RegisterKioskCardRequest はカード登録リクエスト
+type RegisterKioskCardRequest struct {
+	AdminID uuid.UUID
+	CardID  string
+	UserID  uuid.UUID
+}
+
+// DeleteKioskCardRequest はカード紐付け解除リクエスト
+type DeleteKioskCardRequest struct {
+	AdminID uuid.UUID
+	CardID  string
+}
@@ -0,0 +1,36 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// MaintenanceInputPort はメンテナンスモードのユースケースインターフェース
+type MaintenanceInputPort interface {
+	// GetMaintenanceMode は現在のメンテナンスモードを取得（リクエストごとに呼ばれるため短時間キャッシュする）
+	GetMaintenanceMode(ctx context.Context) (*entities.MaintenanceMode, error)
+
+	// IsActive はメンテナンス中かどうかを返す（取得に失敗した場合はメンテナンス中でないとみなす）
+	IsActive(ctx context.Context) bool
+
+	// GetMaintenanceSettings は許可リストを含むメンテナンスモードの設定を取得（管理者のみ）
+	GetMaintenanceSettings(ctx context.Context, adminID uuid.UUID) (*entities.MaintenanceMode, error)
+
+	// UpdateMaintenanceMode はメンテナンスモードを切り替える（管理者のみ）
+	UpdateMaintenanceMode(ctx context.Context, req *UpdateMaintenanceModeRequest) (*UpdateMaintenanceModeResponse, error)
+}
+
+// UpdateMaintenanceModeRequest はメンテナンスモード切り替えリクエスト
+type UpdateMaintenanceModeRequest struct {
+	AdminID        uuid.UUID
+	Enabled        bool
+	Message        string
+	AllowedUserIDs []uuid.UUID
+}
+
+// UpdateMaintenanceModeResponse はメンテナンスモード切り替えレスポンス
+type UpdateMaintenanceModeResponse struct {
+	Mode *entities.MaintenanceMode
+}
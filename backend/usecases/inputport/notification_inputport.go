@@ -0,0 +1,73 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// NotificationDispatcher は通知を届けるユースケースインターフェース
+// 送金・送金リクエストなどの各機能はここを通して通知し、ユーザーの通知設定はここで一括して判定する
+// メール認証・ロック解除など本人の操作に必要なメールは設定にかかわらず届ける必要があるため、ここを通さない
+type NotificationDispatcher interface {
+	// Dispatch は通知設定に従ってプッシュ通知・メール・画面への通知で届ける
+	// 配信はベストエフォートで、失敗しても呼び出し側の処理は失敗させない
+	Dispatch(ctx context.Context, notification *entities.Notification)
+}
+
+// NotificationInputPort は通知の配信先・設定のユースケースインターフェース
+type NotificationInputPort interface {
+	NotificationDispatcher
+
+	// RegisterDevice はプッシュ通知を受け取る端末を登録する（登録済みなら更新）
+	RegisterDevice(ctx context.Context, req *RegisterDeviceRequest) (*entities.DeviceToken, error)
+
+	// UnregisterDevice は端末の登録を解除する（ログアウト時など）
+	UnregisterDevice(ctx context.Context, req *UnregisterDeviceRequest) error
+
+	// GetPreferences はユーザーの通知設定を取得
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*entities.NotificationPreferences, error)
+
+	// UpdatePreferences はユーザーの通知設定を更新
+	UpdatePreferences(ctx context.Context, req *UpdateNotificationPreferencesRequest) (*entities.NotificationPreferences, error)
+
+	// SendExpiryReminders は有効期限が近いポイントをユーザーごとにまとめて通知する（ワーカー用）
+	// 通知したユーザー数を返す
+	SendExpiryReminders(ctx context.Context, now time.Time, limit int) (int, error)
+}
+
+// RegisterDeviceRequest は端末登録リクエスト
+type RegisterDeviceRequest struct {
+	UserID   uuid.UUID
+	Platform entities.DevicePlatform
+	Token    string
+}
+
+// UnregisterDeviceRequest は端末登録解除リクエスト
+type UnregisterDeviceRequest struct {
+	UserID uuid.UUID
+	Token  string
+}
+
+// UpdateNotificationPreferencesRequest は通知設定更新リクエスト（nilの項目は変更しない）
+type UpdateNotificationPreferencesRequest struct {
+	UserID           uuid.UUID
+	PushEnabled      *bool
+	EmailEnabled     *bool
+	TransferRequests *bool
+	PointsReceived   *bool
+	PointsExpiring   *bool
+	NewLogin         *bool
+	WeeklyDigest     *bool
+	QuietHours       *QuietHoursRequest
+}
+
+// QuietHoursRequest はおやすみ時間の指定（時刻は "HH:MM"、Timezoneが空なら Asia/Tokyo）
+type QuietHoursRequest struct {
+	Enabled  bool
+	Start    string
+	End      string
+	Timezone string
+}
@@ -0,0 +1,32 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// OnboardingBonusGranter は登録したユーザーにウェルカムボーナスを付与するインターフェース
+// （AuthInteractorから呼ぶ）
+type OnboardingBonusGranter interface {
+	// GrantOnboardingBonus はウェルカムボーナスを付与（無効・付与済みならnilを返す）
+	GrantOnboardingBonus(ctx context.Context, userID uuid.UUID) (*entities.Transaction, error)
+}
+
+// OnboardingBonusInputPort はウェルカムボーナスの設定のユースケースインターフェース（管理者のみ）
+type OnboardingBonusInputPort interface {
+	// GetPolicy はウェルカムボーナスの設定を取得
+	GetPolicy(ctx context.Context, adminID uuid.UUID) (*entities.OnboardingBonusPolicy, error)
+
+	// UpdatePolicy はウェルカムボーナスの設定を更新
+	UpdatePolicy(ctx context.Context, req *UpdateOnboardingBonusRequest) (*entities.OnboardingBonusPolicy, error)
+}
+
+// UpdateOnboardingBonusRequest はウェルカムボーナスの設定の更新リクエスト
+type UpdateOnboardingBonusRequest struct {
+	AdminID      uuid.UUID
+	Enabled      bool
+	Amount       int64
+	ValidityDays int
+}
@@ -0,0 +1,131 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// PointExpiryPolicyInputPort はポイント有効期間の設定と失効取り消しのユースケースインターフェース（管理者のみ）
+type PointExpiryPolicyInputPort interface {
+	// GetPolicies は付与種別ごとの有効期間と猶予日数を取得
+	GetPolicies(ctx context.Context, adminID uuid.UUID) (*GetExpiryPoliciesResponse, error)
+
+	// UpdatePolicy は付与種別の有効期間を設定（以降の付与に反映され、延長は既存バッチにも反映される）
+	UpdatePolicy(ctx context.Context, req *UpdateExpiryPolicyRequest) (*entities.PointExpiryPolicy, error)
+
+	// DeletePolicy は付与種別の有効期間を既定に戻す
+	DeletePolicy(ctx context.Context, adminID uuid.UUID, sourceType entities.PointBatchSourceType) error
+
+	// UpdateGracePeriod は失効を取り消せる猶予日数を設定
+	UpdateGracePeriod(ctx context.Context, adminID uuid.UUID, graceDays int) error
+
+	// GetUserOverride はユーザー個別の有効期間を取得（ない場合はnil）
+	GetUserOverride(ctx context.Context, adminID, userID uuid.UUID) (*entities.UserPointExpiryOverride, error)
+
+	// SetUserOverride はユーザー個別の有効期間を設定し、有効なバッチの期限を再計算する
+	SetUserOverride(ctx context.Context, req *SetUserExpiryOverrideRequest) (*entities.UserPointExpiryOverride, error)
+
+	// DeleteUserOverride はユーザー個別の有効期間を削除し、有効なバッチの期限を再計算する
+	DeleteUserOverride(ctx context.Context, adminID, userID uuid.UUID) error
+
+	// ListRestorableBatches は猶予期間内で取り消し可能な失効済みバッチを取得
+	ListRestorableBatches(ctx context.Context, req *ListRestorableBatchesRequest) (*ListRestorableBatchesResponse, error)
+
+	// RestoreBatch は失効を取り消し、失効したポイントを補填バッチとして戻す
+	RestoreBatch(ctx context.Context, req *RestoreBatchRequest) (*RestoreBatchResponse, error)
+
+	// ListUserBatches はユーザーのすべてのバッチを調整の記録つきで新しい順に取得
+	ListUserBatches(ctx context.Context, req *ListUserBatchesRequest) (*ListUserBatchesResponse, error)
+
+	// AdjustBatch はバッチの有効期限を変更するか、バッチを取り消す（残っていた分は残高から減算する）
+	AdjustBatch(ctx context.Context, req *AdjustBatchRequest) (*AdjustBatchResponse, error)
+
+	// GetWorkerStatus はポイント有効期限ワーカーの直近の実行状況を取得（一度も実行していなければnil）
+	GetWorkerStatus(ctx context.Context, adminID uuid.UUID) (*entities.PointExpiryRun, error)
+}
+
+// GetExpiryPoliciesResponse は有効期間設定の取得レスポンス
+type GetExpiryPoliciesResponse struct {
+	Policies            []*entities.PointExpiryPolicy
+	DefaultValidityDays int // ポリシーがない種別の目安（POINT_EXPIRATION_MONTHS を日数換算）
+	GraceDays           int
+}
+
+// UpdateExpiryPolicyRequest は付与種別の有効期間設定リクエスト
+type UpdateExpiryPolicyRequest struct {
+	AdminID      uuid.UUID
+	SourceType   entities.PointBatchSourceType
+	ValidityDays int
+}
+
+// SetUserExpiryOverrideRequest はユーザー個別の有効期間設定リクエスト
+type SetUserExpiryOverrideRequest struct {
+	AdminID      uuid.UUID
+	UserID       uuid.UUID
+	ValidityDays int
+	Reason       string
+}
+
+// ListRestorableBatchesRequest は取り消し可能なバッチ一覧リクエスト
+type ListRestorableBatchesRequest struct {
+	AdminID uuid.UUID
+	UserID  *uuid.UUID
+	Limit   int
+}
+
+// ListRestorableBatchesResponse は取り消し可能なバッチ一覧レスポンス
+type ListRestorableBatchesResponse struct {
+	Batches   []*entities.PointBatch
+	GraceDays int
+}
+
+// RestoreBatchRequest は失効取り消しリクエスト
+type RestoreBatchRequest struct {
+	AdminID uuid.UUID
+	BatchID uuid.UUID
+}
+
+// RestoreBatchResponse は失効取り消しレスポンス
+type RestoreBatchResponse struct {
+	RestoredBatch *entities.PointBatch
+	NewBatch      *entities.PointBatch
+	Transaction   *entities.Transaction
+	User          *entities.User
+}
+
+// ListUserBatchesRequest はユーザーのバッチ一覧リクエスト
+type ListUserBatchesRequest struct {
+	AdminID uuid.UUID
+	UserID  uuid.UUID
+	Offset  int
+	Limit   int
+}
+
+// ListUserBatchesResponse はユーザーのバッチ一覧レスポンス
+type ListUserBatchesResponse struct {
+	Batches     []*entities.PointBatch
+	Adjustments map[uuid.UUID][]*entities.PointBatchAdjustment // バッチIDごとの調整の記録（古い順）
+	Total       int64
+	Offset      int
+	Limit       int
+}
+
+// AdjustBatchRequest はバッチの調整リクエスト（ExpiresAtとCancelはどちらか一方）
+type AdjustBatchRequest struct {
+	AdminID   uuid.UUID
+	BatchID   uuid.UUID
+	ExpiresAt *time.Time
+	Cancel    bool
+	Reason    string
+}
+
+// AdjustBatchResponse はバッチの調整レスポンス
+type AdjustBatchResponse struct {
+	Batch       *entities.PointBatch
+	Adjustment  *entities.PointBatchAdjustment
+	Transaction *entities.Transaction // 取り消しで残高から減算した場合のみ
+	User        *entities.User
+}
@@ -13,6 +13,9 @@ type PointTransferInputPort interface {
 	// Transfer はポイントを転送
 	Transfer(ctx context.Context, req *TransferRequest) (*TransferResponse, error)
 
+	// QuoteTransfer は送金前に手数料と送信者が支払う合計額を見積もる
+	QuoteTransfer(ctx context.Context, req *QuoteTransferRequest) (*QuoteTransferResponse, error)
+
 	// GetTransactionHistory はトランザクション履歴を取得
 	GetTransactionHistory(ctx context.Context, req *GetTransactionHistoryRequest) (*GetTransactionHistoryResponse, error)
 
@@ -30,6 +33,12 @@ type TransferRequest struct {
 	Amount         int64
 	IdempotencyKey string // 冪等性キー（クライアントが生成）
 	Description    string
+	ReleasedBy     *uuid.UUID // 保留した送金を実行する管理者（不審な送金の検出を行わない）
+	// RecipientConsented は受取人が受け取りに同意している送金（QRコードの読み取り・送金リクエストの承認）
+	// 受取人が受け取る相手を友達に限っていても確かめない
+	RecipientConsented bool
+	// HoldFor は送信者の残高を確保した送金リクエスト（確保中なら同じトランザクションで使用済みにし、確保した分から差し引く）
+	HoldFor *uuid.UUID
 }
 
 // TransferResponse はポイント転送レスポンス
@@ -37,20 +46,41 @@ type TransferResponse struct {
 	Transaction *entities.Transaction
 	FromUser    *entities.User
 	ToUser      *entities.User
+	Fee         int64 // 送金額とは別に送信者が支払った手数料
+}
+
+// QuoteTransferRequest は送金の見積もりリクエスト
+type QuoteTransferRequest struct {
+	FromUserID uuid.UUID
+	Amount     int64
+}
+
+// QuoteTransferResponse は送金の見積もり
+type QuoteTransferResponse struct {
+	Amount          int64 // 受信者が受け取る額
+	Fee             int64
+	Total           int64 // 送信者の残高から差し引く額（Amount + Fee）
+	FeeType         entities.TransferFeeType
+	MinAmount       int64 // 0なら下限なし
+	MaxAmount       int64 // 0なら上限なし
+	Balance         int64 // 使える残高（送金リクエストのために確保中の額を除く）
+	SufficientFunds bool  // 使える残高がTotal以上か
 }
 
 // GetTransactionHistoryRequest はトランザクション履歴取得リクエスト
 type GetTransactionHistoryRequest struct {
-	UserID uuid.UUID
-	Offset int
-	Limit  int
+	UserID     uuid.UUID
+	ReasonCode string // 空でなければその理由コードの取引のみ
+	Offset     int
+	Limit      int
 }
 
 // TransactionWithUsersForHistory はユーザー情報付きトランザクション（履歴用）
 type TransactionWithUsersForHistory struct {
-	Transaction *entities.Transaction
-	FromUser    *entities.User
-	ToUser      *entities.User
+	Transaction   *entities.Transaction
+	FromUser      *entities.User
+	ToUser        *entities.User
+	HasAttachment bool // 画像が添付されている（GET /api/points/transactions/:id/attachment で取得する）
 }
 
 // GetTransactionHistoryResponse はトランザクション履歴取得レスポンス
@@ -66,8 +96,10 @@ type GetBalanceRequest struct {
 
 // GetBalanceResponse は残高取得レスポンス
 type GetBalanceResponse struct {
-	Balance int64
-	User    *entities.User
+	Balance   int64
+	Reserved  int64 // 送金リクエストのために確保中の額
+	Available int64 // 送金・交換に使える額（Balance - Reserved）
+	User      *entities.User
 }
 
 // GetExpiringPointsRequest は失効予定ポイント取得リクエスト
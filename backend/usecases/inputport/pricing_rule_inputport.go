@@ -0,0 +1,73 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// PricingRuleInputPort は価格ルール管理のユースケースインターフェース（管理者のみ）
+type PricingRuleInputPort interface {
+	// CreatePricingRule は価格ルールを作成
+	CreatePricingRule(ctx context.Context, req *CreatePricingRuleRequest) (*entities.PricingRule, error)
+
+	// UpdatePricingRule は価格ルールを更新
+	UpdatePricingRule(ctx context.Context, req *UpdatePricingRuleRequest) (*entities.PricingRule, error)
+
+	// DeletePricingRule は価格ルールを削除
+	DeletePricingRule(ctx context.Context, req *DeletePricingRuleRequest) error
+
+	// GetPricingRuleList は期間外・無効を含む価格ルールの一覧を取得
+	GetPricingRuleList(ctx context.Context, req *GetPricingRuleListRequest) (*GetPricingRuleListResponse, error)
+}
+
+// PricingRuleContent は価格ルールの作成・更新内容
+type PricingRuleContent struct {
+	Name               string
+	ProductID          *uuid.UUID // 対象商品（CategoryCodeとどちらか一方）
+	CategoryCode       string     // 対象カテゴリ（ProductIDとどちらか一方）
+	DiscountType       entities.PricingDiscountType
+	DiscountValue      int64
+	TargetRole         entities.UserRole // 空なら全員
+	MinTier            entities.UserTier // 空なら全員
+	MaxQuantityPerUser int               // 0なら無制限
+	StartsAt           time.Time         // ゼロ値なら即時
+	EndsAt             *time.Time        // nilなら無期限
+}
+
+// CreatePricingRuleRequest は価格ルール作成リクエスト
+type CreatePricingRuleRequest struct {
+	AdminID uuid.UUID
+	PricingRuleContent
+}
+
+// UpdatePricingRuleRequest は価格ルール更新リクエスト
+type UpdatePricingRuleRequest struct {
+	AdminID       uuid.UUID
+	PricingRuleID uuid.UUID
+	IsActive      bool // falseなら期間内でも適用しない
+	PricingRuleContent
+}
+
+// DeletePricingRuleRequest は価格ルール削除リクエスト
+type DeletePricingRuleRequest struct {
+	AdminID       uuid.UUID
+	PricingRuleID uuid.UUID
+}
+
+// GetPricingRuleListRequest は価格ルール一覧取得リクエスト
+type GetPricingRuleListRequest struct {
+	AdminID uuid.UUID
+	Offset  int
+	Limit   int
+}
+
+// GetPricingRuleListResponse は価格ルール一覧レスポンス
+type GetPricingRuleListResponse struct {
+	Rules  []*entities.PricingRule
+	Total  int64
+	Offset int
+	Limit  int
+}
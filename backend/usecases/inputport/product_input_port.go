@@ -2,6 +2,7 @@ package inputport
 
 import (
 	"context"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/google/uuid"
@@ -11,12 +12,14 @@ import (
 
 // CreateProductRequest は商品作成リクエスト
 type CreateProductRequest struct {
+	AdminID     uuid.UUID `json:"-"` // 操作した管理者（モデレーション違反の記録用）
 	Name        string
 	Description string
 	Category    string // カテゴリコード
 	Price       int64
 	Stock       int
 	ImageURL    string
+	IsDigital   bool // 交換時に引換コードを発行する
 }
 
 // CreateProductResponse は商品作成レスポンス
@@ -27,6 +30,7 @@ type CreateProductResponse struct {
 // UpdateProductRequest は商品更新リクエスト
 type UpdateProductRequest struct {
 	ProductID   uuid.UUID
+	AdminID     uuid.UUID `json:"-"` // 操作した管理者（モデレーション違反の記録用）
 	Name        string
 	Description string
 	Category    string // カテゴリコード
@@ -34,6 +38,7 @@ type UpdateProductRequest struct {
 	Stock       int
 	ImageURL    string
 	IsAvailable bool
+	IsDigital   bool
 }
 
 // UpdateProductResponse は商品更新レスポンス
@@ -79,10 +84,13 @@ type ProductManagementInputPort interface {
 
 // ExchangeProductRequest は商品交換リクエスト
 type ExchangeProductRequest struct {
-	UserID    uuid.UUID
-	ProductID uuid.UUID
-	Quantity  int
-	Notes     string // 受取場所、希望時間など
+	UserID      uuid.UUID
+	ProductID   uuid.UUID
+	Quantity    int
+	Notes       string     // 受取場所、希望時間など
+	RecipientID *uuid.UUID // 友達に贈る場合の受取人（自分で受け取るならnil）
+	// ShippingAddressID は配送が必要な商品のお届け先（nilなら既定のお届け先。贈り物では受取人が受け取り時に選ぶ）
+	ShippingAddressID *uuid.UUID
 }
 
 // ExchangeProductResponse は商品交換レスポンス
@@ -91,6 +99,8 @@ type ExchangeProductResponse struct {
 	Product     *entities.Product
 	User        *entities.User
 	Transaction *entities.Transaction
+	UnitPrice   int64                 // 価格ルール適用後の単価
+	PricingRule *entities.PricingRule // 適用した価格ルール（なければnil）
 }
 
 // GetExchangeHistoryRequest は交換履歴取得リクエスト
@@ -104,6 +114,8 @@ type GetExchangeHistoryRequest struct {
 type GetExchangeHistoryResponse struct {
 	Exchanges []*entities.ProductExchange
 	Total     int64
+	// Recipients はギフトの交換IDごとの受取人（管理者用の一覧のみ。受け渡し先の確認に使う）
+	Recipients map[uuid.UUID]*ExchangeRecipient
 }
 
 // CancelExchangeRequest は交換キャンセルリクエスト
@@ -112,11 +124,57 @@ type CancelExchangeRequest struct {
 	ExchangeID uuid.UUID
 }
 
+// ExchangeRecipient はギフトの受取人の受け渡し先情報（管理者が受け渡しに使う）
+type ExchangeRecipient struct {
+	UserID       uuid.UUID
+	Username     string
+	DisplayName  string
+	Email        string
+	DepartmentID *uuid.UUID // 所属部署（受け渡し場所の目安。未所属ならnil）
+}
+
+// RespondGiftRequest はギフトの受け取り・辞退リクエスト
+type RespondGiftRequest struct {
+	UserID     uuid.UUID // 受取人
+	ExchangeID uuid.UUID
+	// ShippingAddressID は受け取る場合のお届け先（配送が必要な商品のみ。nilなら既定のお届け先）
+	ShippingAddressID *uuid.UUID
+}
+
 // MarkExchangeDeliveredRequest は配達完了リクエスト（管理者用）
 type MarkExchangeDeliveredRequest struct {
 	ExchangeID uuid.UUID
 }
 
+// RedeemCodeRequest は引換コードの確認・引き換えリクエスト（管理者用）
+type RedeemCodeRequest struct {
+	AdminID uuid.UUID
+	Code    string // 大文字・小文字、ハイフンの有無は問わない
+}
+
+// RedeemCodeResponse は引換コードに対応する交換
+type RedeemCodeResponse struct {
+	Exchange   *entities.ProductExchange
+	Product    *entities.Product
+	Redeemable bool               // まだ引き換えられるか（使用済み・キャンセル済み・ギフトの返事待ちならfalse）
+	Recipient  *ExchangeRecipient // ギフトなら受取人（ギフトでなければnil）
+}
+
+// GetExchangeReportRequest は交換実績レポート取得リクエスト（管理者用）
+type GetExchangeReportRequest struct {
+	From        time.Time // ゼロ値なら直近12期間
+	To          time.Time // ゼロ値なら今日まで
+	Granularity entities.AnalyticsGranularity
+}
+
+// GetExchangeReportResponse は交換実績レポート
+type GetExchangeReportResponse struct {
+	From        time.Time
+	To          time.Time
+	Granularity entities.AnalyticsGranularity
+	Rows        []*entities.ExchangeReportRow
+}
+
 // ProductExchangeInputPort は商品交換のユースケースインターフェース
 type ProductExchangeInputPort interface {
 	// ExchangeProduct はポイントで商品を交換
@@ -128,9 +186,27 @@ type ProductExchangeInputPort interface {
 	// CancelExchange は交換をキャンセル（ペンディング状態のみ）
 	CancelExchange(ctx context.Context, req *CancelExchangeRequest) error
 
+	// GetReceivedGifts は友達から贈られた交換を取得
+	GetReceivedGifts(ctx context.Context, req *GetExchangeHistoryRequest) (*GetExchangeHistoryResponse, error)
+
+	// AcceptGift は贈られた交換を受け取る
+	AcceptGift(ctx context.Context, req *RespondGiftRequest) (*entities.ProductExchange, error)
+
+	// DeclineGift は贈られた交換を辞退する（贈った人にポイントを返し、在庫を戻す）
+	DeclineGift(ctx context.Context, req *RespondGiftRequest) (*entities.ProductExchange, error)
+
 	// MarkExchangeDelivered は配達完了にする（管理者用）
 	MarkExchangeDelivered(ctx context.Context, req *MarkExchangeDeliveredRequest) error
 
 	// GetAllExchanges はすべての交換履歴を取得（管理者用）
 	GetAllExchanges(ctx context.Context, offset, limit int) (*GetExchangeHistoryResponse, error)
+
+	// ValidateRedemptionCode は引換コードに対応する交換を確認する（管理者用、使用済みにはしない）
+	ValidateRedemptionCode(ctx context.Context, req *RedeemCodeRequest) (*RedeemCodeResponse, error)
+
+	// RedeemCode は引換コードを使用済みにし、受け渡し済みにする（管理者用）
+	RedeemCode(ctx context.Context, req *RedeemCodeRequest) (*RedeemCodeResponse, error)
+
+	// GetExchangeReport は商品ごと・期間ごとの交換実績を集計する（管理者用、在庫計画向け）
+	GetExchangeReport(ctx context.Context, req *GetExchangeReportRequest) (*GetExchangeReportResponse, error)
 }
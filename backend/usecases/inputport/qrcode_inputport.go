@@ -20,6 +20,9 @@ type QRCodeInputPort interface {
 
 	// GetQRCodeHistory はQRコード履歴を取得
 	GetQRCodeHistory(ctx context.Context, req *GetQRCodeHistoryRequest) (*GetQRCodeHistoryResponse, error)
+
+	// GetPersonalQR は送金リクエストの宛先に使う個人QRコードを取得
+	GetPersonalQR(ctx context.Context, req *GetPersonalQRRequest) (*GetPersonalQRResponse, error)
 }
 
 // GenerateReceiveQRRequest は受取用QRコード生成リクエスト
@@ -31,7 +34,7 @@ type GenerateReceiveQRRequest struct {
 // GenerateReceiveQRResponse は受取用QRコード生成レスポンス
 type GenerateReceiveQRResponse struct {
 	QRCode     *entities.QRCode
-	QRCodeData string // QRコードに含めるデータ
+	QRCodeData string // QRコードに含めるデータ（署名付きのディープリンク）
 }
 
 // GenerateSendQRRequest は送信用QRコード生成リクエスト
@@ -49,7 +52,7 @@ type GenerateSendQRResponse struct {
 // ScanQRRequest はQRコードスキャンリクエスト
 type ScanQRRequest struct {
 	UserID         uuid.UUID
-	Code           string
+	Code           string  // QRコードから読み取った文字列（ディープリンク・旧形式）またはコードのみ
 	Amount         *int64  // QRコードに金額が含まれていない場合に指定
 	IdempotencyKey string
 }
@@ -73,3 +76,14 @@ type GetQRCodeHistoryRequest struct {
 type GetQRCodeHistoryResponse struct {
 	QRCodes []*entities.QRCode
 }
+
+// GetPersonalQRRequest は個人QRコード取得リクエスト
+type GetPersonalQRRequest struct {
+	UserID uuid.UUID
+}
+
+// GetPersonalQRResponse は個人QRコード取得レスポンス
+type GetPersonalQRResponse struct {
+	User       *entities.User
+	QRCodeData string // QRコードに含めるデータ（署名付きのディープリンク、無期限）
+}
@@ -0,0 +1,26 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// QuickSendInputPort はクイック送金（友達ごとの送金ショートカット）のユースケースインターフェース
+type QuickSendInputPort interface {
+	// GetQuickSend は友達ごとに最後に送った金額・よく送る金額を付けて、表示順に取得
+	GetQuickSend(ctx context.Context, req *GetQuickSendRequest) ([]*entities.QuickSendEntry, error)
+
+	// PinFriend は友達をクイック送金の先頭にピン留め（既にピン留めしていれば何もしない）
+	PinFriend(ctx context.Context, userID, friendID uuid.UUID) error
+
+	// UnpinFriend は友達のピン留めを外す
+	UnpinFriend(ctx context.Context, userID, friendID uuid.UUID) error
+}
+
+// GetQuickSendRequest はクイック送金の取得リクエスト
+type GetQuickSendRequest struct {
+	UserID uuid.UUID
+	Limit  int
+}
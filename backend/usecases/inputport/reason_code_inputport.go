@@ -0,0 +1,42 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// ReasonCodeInputPort は管理者の付与・減算に付ける理由コードのユースケースインターフェース
+type ReasonCodeInputPort interface {
+	// ListReasonCodes は理由コードの一覧を取得（管理者以外は有効なもののみ）
+	ListReasonCodes(ctx context.Context, req *ListReasonCodesRequest) ([]*entities.ReasonCode, error)
+
+	// CreateReasonCode は理由コードを作成（管理者のみ）
+	CreateReasonCode(ctx context.Context, req *CreateReasonCodeRequest) (*entities.ReasonCode, error)
+
+	// UpdateReasonCode は理由コードの表示名・説明・有効状態を更新（管理者のみ）
+	UpdateReasonCode(ctx context.Context, req *UpdateReasonCodeRequest) (*entities.ReasonCode, error)
+}
+
+// ListReasonCodesRequest は理由コード一覧取得リクエスト
+type ListReasonCodesRequest struct {
+	IncludeInactive bool // 管理画面用に無効なコードも含める
+}
+
+// CreateReasonCodeRequest は理由コード作成リクエスト
+type CreateReasonCodeRequest struct {
+	AdminID     uuid.UUID
+	Code        string
+	Label       string
+	Description string
+}
+
+// UpdateReasonCodeRequest は理由コード更新リクエスト
+type UpdateReasonCodeRequest struct {
+	AdminID     uuid.UUID
+	Code        string
+	Label       string
+	Description string
+	IsActive    bool // falseにすると新しい付与・減算で選べなくなる（過去の取引はそのまま）
+}
@@ -0,0 +1,63 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// RecurringTransferInputPort は定期送金のユースケースインターフェース
+type RecurringTransferInputPort interface {
+	// CreateRecurringTransfer は定期送金を登録する（送信者が承認）
+	CreateRecurringTransfer(ctx context.Context, req *CreateRecurringTransferRequest) (*RecurringTransferInfo, error)
+
+	// GetRecurringTransfers は送信者または受取人として関わる定期送金の一覧を取得
+	GetRecurringTransfers(ctx context.Context, req *GetRecurringTransfersRequest) (*GetRecurringTransfersResponse, error)
+
+	// CancelRecurringTransfer は定期送金を解約する（送信者・受取人のどちらからでも可能）
+	CancelRecurringTransfer(ctx context.Context, req *CancelRecurringTransferRequest) (*entities.RecurringTransfer, error)
+
+	// ProcessDueTransfers は実行日時を過ぎた定期送金を送金する（ワーカー用）
+	// 送金に成功した件数を返す
+	ProcessDueTransfers(ctx context.Context, now time.Time, limit int) (int, error)
+}
+
+// CreateRecurringTransferRequest は定期送金登録リクエスト
+type CreateRecurringTransferRequest struct {
+	FromUserID uuid.UUID
+	ToUserID   uuid.UUID
+	Amount     int64
+	Interval   entities.RecurringInterval
+	Message    string
+	StartAt    time.Time // ゼロ値なら即時開始
+}
+
+// RecurringTransferInfo は定期送金と送信者・受取人の情報
+type RecurringTransferInfo struct {
+	Transfer *entities.RecurringTransfer
+	FromUser *entities.User
+	ToUser   *entities.User
+}
+
+// GetRecurringTransfersRequest は定期送金一覧取得リクエスト
+type GetRecurringTransfersRequest struct {
+	UserID uuid.UUID
+	Offset int
+	Limit  int
+}
+
+// GetRecurringTransfersResponse は定期送金一覧レスポンス
+type GetRecurringTransfersResponse struct {
+	Transfers []*RecurringTransferInfo
+	Total     int64
+	Offset    int
+	Limit     int
+}
+
+// CancelRecurringTransferRequest は定期送金解約リクエスト
+type CancelRecurringTransferRequest struct {
+	TransferID uuid.UUID
+	UserID     uuid.UUID // 解約するユーザー（送信者または受取人）
+}
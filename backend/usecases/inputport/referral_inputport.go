@@ -0,0 +1,50 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// ReferralTracker は登録・チェックインの処理から紹介を記録するユースケースインターフェース
+type ReferralTracker interface {
+	// ResolveReferralCode は登録時に入力された紹介コードを確認する（形式が違う・存在しないコードはErrInvalidReferralCode）
+	ResolveReferralCode(ctx context.Context, code string) (*entities.ReferralCode, error)
+
+	// RecordReferral は紹介コードで登録したユーザーを記録する
+	// 紹介者本人のログイン履歴があるIPアドレスや、同じIPアドレスからの登録が多すぎる場合は特典の対象外として記録する
+	RecordReferral(ctx context.Context, req *RecordReferralRequest) (*entities.Referral, error)
+
+	// CompleteQualifyingAction は紹介された側の初回チェックインで双方に特典を付与する
+	// 紹介がない・処理済みなら何もしない。失敗しても呼び出し側の処理は失敗させない
+	CompleteQualifyingAction(ctx context.Context, refereeID uuid.UUID)
+}
+
+// ReferralInputPort は紹介プログラムのユースケースインターフェース
+type ReferralInputPort interface {
+	ReferralTracker
+
+	// GetReferralCode はユーザーの紹介コードを取得（未発行なら発行する）
+	GetReferralCode(ctx context.Context, userID uuid.UUID) (*entities.ReferralCode, error)
+
+	// GetReferralReport は期間内の紹介の実績を取得（管理者用）
+	GetReferralReport(ctx context.Context, req *GetReferralReportRequest) (*entities.ReferralReport, error)
+}
+
+// RecordReferralRequest は紹介の記録リクエスト
+type RecordReferralRequest struct {
+	Code      *entities.ReferralCode // ResolveReferralCodeで確認したコード
+	RefereeID uuid.UUID
+	IPAddress string
+}
+
+// GetReferralReportRequest は紹介の実績取得リクエスト
+// From/Toが未指定の場合は直近30日間
+type GetReferralReportRequest struct {
+	AdminID uuid.UUID
+	From    time.Time
+	To      time.Time
+	Limit   int // 紹介者の上位何件を返すか
+}
@@ -0,0 +1,20 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// ResourceAuthorizationInputPort はパスの:idが指すリソースをセッションのユーザーが操作できるかを確認するユースケースインターフェース
+// （ルートの前段のミドルウェアから呼ぶ。各インタラクターでも同じ判定（OwnedResource.Authorize）を行う）
+type ResourceAuthorizationInputPort interface {
+	// Authorize はユーザーがリソースにactionを行えるかを確認
+	// リソースがない・関わりがなければリソースのNotFound、関わっているが許されない操作ならErrActionNotPermitted
+	Authorize(ctx context.Context, userID uuid.UUID, resource entities.ResourceType, resourceID uuid.UUID, action entities.Action) error
+
+	// AuthorizeAdmin はユーザーが管理者かを確認（管理APIのグループの前段で呼ぶ）
+	// 管理者でなければErrAdminRequired
+	AuthorizeAdmin(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,19 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// ScheduledJobInputPort は定期実行ジョブのユースケースインターフェース
+type ScheduledJobInputPort interface {
+	// RunDueJobs は実行時刻を過ぎたジョブのロックを取って実行し、実行した数を返す（スケジューラーから呼ぶ）
+	// ownerはロックを持つインスタンスの識別子
+	RunDueJobs(ctx context.Context, owner string, now time.Time) int
+
+	// ListJobs は全ジョブのスケジュールと直近の実行状況を取得（管理者のみ）
+	ListJobs(ctx context.Context, adminID uuid.UUID) ([]*entities.ScheduledJob, error)
+}
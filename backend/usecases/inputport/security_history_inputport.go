@@ -0,0 +1,52 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// SecurityHistoryInputPort はユーザー名・パスワード変更履歴の閲覧ユースケースインターフェース
+type SecurityHistoryInputPort interface {
+	// GetOwnHistory は本人の変更履歴を取得（IPアドレスは一部を伏せる）
+	GetOwnHistory(ctx context.Context, req *GetSecurityHistoryRequest) (*GetSecurityHistoryResponse, error)
+
+	// GetUserHistory は指定ユーザーの変更履歴を取得（管理者のみ）
+	GetUserHistory(ctx context.Context, req *GetUserSecurityHistoryRequest) (*GetSecurityHistoryResponse, error)
+
+	// GetOwnLogins は本人のログイン履歴を取得（IPアドレスは一部を伏せる）
+	GetOwnLogins(ctx context.Context, req *GetSecurityHistoryRequest) (*GetLoginHistoryResponse, error)
+}
+
+// GetSecurityHistoryRequest は本人の変更履歴取得リクエスト
+type GetSecurityHistoryRequest struct {
+	UserID uuid.UUID
+	Offset int
+	Limit  int
+}
+
+// GetUserSecurityHistoryRequest は管理者による変更履歴取得リクエスト
+type GetUserSecurityHistoryRequest struct {
+	AdminID uuid.UUID
+	UserID  uuid.UUID
+	Offset  int
+	Limit   int
+}
+
+// GetSecurityHistoryResponse は変更履歴レスポンス（新しい順）
+type GetSecurityHistoryResponse struct {
+	UserID  uuid.UUID
+	Entries []*entities.SecurityHistoryEntry
+	Total   int64
+	Offset  int
+	Limit   int
+}
+
+// GetLoginHistoryResponse はログイン履歴レスポンス（新しい順）
+type GetLoginHistoryResponse struct {
+	Entries []*entities.LoginHistoryEntry
+	Total   int64
+	Offset  int
+	Limit   int
+}
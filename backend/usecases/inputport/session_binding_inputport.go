@@ -0,0 +1,21 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+)
+
+// SessionBindingInputPort はセッションを端末・接続元に固定するユースケースインターフェース
+type SessionBindingInputPort interface {
+	// VerifyBinding はセッションが固定した端末・接続元から使われているか検証
+	// 変化は記録し、設定で許容しない変化ならセッションを失効させてErrSessionReauthRequiredを返す
+	VerifyBinding(ctx context.Context, req *VerifySessionBindingRequest) error
+}
+
+// VerifySessionBindingRequest はセッションの固定の検証リクエスト
+type VerifySessionBindingRequest struct {
+	Session   *entities.Session
+	IPAddress string
+	UserAgent string
+}
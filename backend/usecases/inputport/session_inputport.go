@@ -0,0 +1,84 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// SessionInputPort はログイン中セッション（端末）管理のユースケースインターフェース
+type SessionInputPort interface {
+	// ListSessions はユーザーの有効なセッション一覧を取得
+	ListSessions(ctx context.Context, req *ListSessionsRequest) (*ListSessionsResponse, error)
+
+	// RevokeSession は指定したセッションを失効
+	RevokeSession(ctx context.Context, req *RevokeSessionRequest) (*RevokeSessionResponse, error)
+
+	// RevokeOtherSessions は現在のセッション以外の全セッションを失効
+	RevokeOtherSessions(ctx context.Context, req *RevokeOtherSessionsRequest) (*RevokeOtherSessionsResponse, error)
+
+	// ForceLogoutUser は管理者が指定ユーザーの全セッションを失効（強制ログアウト）
+	ForceLogoutUser(ctx context.Context, req *ForceLogoutUserRequest) (*ForceLogoutResponse, error)
+
+	// PurgeSessions は管理者が全ユーザーのセッションを失効（不正アクセスへの対応など）
+	PurgeSessions(ctx context.Context, req *PurgeSessionsRequest) (*ForceLogoutResponse, error)
+}
+
+// ListSessionsRequest はセッション一覧取得リクエスト
+type ListSessionsRequest struct {
+	UserID           uuid.UUID
+	CurrentSessionID uuid.UUID
+}
+
+// SessionInfo はセッション一覧の1件分
+type SessionInfo struct {
+	Session   *entities.Session
+	IsCurrent bool
+}
+
+// ListSessionsResponse はセッション一覧取得レスポンス
+type ListSessionsResponse struct {
+	Sessions []*SessionInfo
+}
+
+// RevokeSessionRequest はセッション失効リクエスト
+type RevokeSessionRequest struct {
+	UserID           uuid.UUID
+	CurrentSessionID uuid.UUID
+	SessionID        uuid.UUID
+}
+
+// RevokeSessionResponse はセッション失効レスポンス
+type RevokeSessionResponse struct {
+	RevokedCurrent bool // 現在のセッションを失効した場合はtrue（Cookieをクリアする）
+}
+
+// RevokeOtherSessionsRequest は他セッション一括失効リクエスト
+type RevokeOtherSessionsRequest struct {
+	UserID           uuid.UUID
+	CurrentSessionID uuid.UUID
+}
+
+// RevokeOtherSessionsResponse は他セッション一括失効レスポンス
+type RevokeOtherSessionsResponse struct {
+	RevokedCount int64
+}
+
+// ForceLogoutUserRequest は強制ログアウトリクエスト
+type ForceLogoutUserRequest struct {
+	AdminID          uuid.UUID
+	CurrentSessionID uuid.UUID // 管理者自身を指定した場合も、操作中のセッションは残す
+	UserID           uuid.UUID
+}
+
+// PurgeSessionsRequest は全セッション失効リクエスト
+type PurgeSessionsRequest struct {
+	AdminID          uuid.UUID
+	CurrentSessionID uuid.UUID // 操作中の管理者のセッションは残す
+}
+
+// ForceLogoutResponse は強制ログアウトのレスポンス
+type ForceLogoutResponse struct {
+	RevokedCount int64
+}
@@ -0,0 +1,42 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// ShippingAddressInputPort はお届け先（住所録）のユースケースインターフェース
+// 配送が必要な商品の交換ではお届け先が必須になる
+type ShippingAddressInputPort interface {
+	// ListAddresses はユーザーのお届け先を既定のもの、登録順の順に取得
+	ListAddresses(ctx context.Context, userID uuid.UUID) ([]*entities.ShippingAddress, error)
+
+	// CreateAddress はお届け先を登録（最初の1件とIsDefault指定時は既定にする）
+	CreateAddress(ctx context.Context, req *CreateShippingAddressRequest) (*entities.ShippingAddress, error)
+
+	// UpdateAddress はお届け先を変更（IsDefault指定時は既定にする）
+	UpdateAddress(ctx context.Context, req *UpdateShippingAddressRequest) (*entities.ShippingAddress, error)
+
+	// SetDefaultAddress は既定のお届け先を切り替える
+	SetDefaultAddress(ctx context.Context, userID, addressID uuid.UUID) (*entities.ShippingAddress, error)
+
+	// DeleteAddress はお届け先を削除（既定を削除した場合は最も古いお届け先を既定にする）
+	DeleteAddress(ctx context.Context, userID, addressID uuid.UUID) error
+}
+
+// CreateShippingAddressRequest はお届け先の登録リクエスト
+type CreateShippingAddressRequest struct {
+	UserID    uuid.UUID
+	Address   entities.ShippingAddressInput
+	IsDefault bool
+}
+
+// UpdateShippingAddressRequest はお届け先の変更リクエスト
+type UpdateShippingAddressRequest struct {
+	UserID    uuid.UUID
+	AddressID uuid.UUID
+	Address   entities.ShippingAddressInput
+	IsDefault bool // trueなら既定にする（falseでも既定は外さない）
+}
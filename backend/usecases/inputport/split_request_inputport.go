@@ -0,0 +1,72 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// SplitRequestInputPort は割り勘のユースケースインターフェース
+// 参加者ごとに作成者宛の送金リクエストを作り、参加者は送金リクエストの承認・拒否で支払う
+type SplitRequestInputPort interface {
+	// CreateSplitRequest は割り勘を作成し、友達の参加者それぞれに送金リクエストを送る
+	CreateSplitRequest(ctx context.Context, req *CreateSplitRequestRequest) (*SplitRequestDetail, error)
+
+	// GetSplitRequest は割り勘の支払い状況を取得（作成者・参加者のみ）
+	GetSplitRequest(ctx context.Context, req *GetSplitRequestRequest) (*SplitRequestDetail, error)
+
+	// ListSplitRequests は作成した割り勘の一覧を支払い状況とともに取得
+	ListSplitRequests(ctx context.Context, req *ListSplitRequestsRequest) (*ListSplitRequestsResponse, error)
+
+	// CancelSplitRequest は割り勘をキャンセルし、支払い待ちの送金リクエストを取り消す（作成者のみ）
+	CancelSplitRequest(ctx context.Context, req *CancelSplitRequestRequest) (*SplitRequestDetail, error)
+}
+
+// CreateSplitRequestRequest は割り勘作成リクエスト
+type CreateSplitRequestRequest struct {
+	RequesterID      uuid.UUID
+	TotalAmount      int64
+	SplitType        entities.SplitType
+	Participants     []entities.SplitShare // 均等の場合は金額を無視する
+	IncludeRequester bool
+	Message          string
+	IdempotencyKey   string
+}
+
+// SplitShareInfo は参加者1人分の送金リクエストと支払う参加者
+type SplitShareInfo struct {
+	TransferRequest *entities.TransferRequest
+	User            *entities.User
+}
+
+// SplitRequestDetail は割り勘と参加者ごとの支払い状況
+type SplitRequestDetail struct {
+	SplitRequest *entities.SplitRequest
+	Summary      *entities.SplitRequestSummary
+	Shares       []*SplitShareInfo
+}
+
+// GetSplitRequestRequest は割り勘取得リクエスト
+type GetSplitRequestRequest struct {
+	SplitRequestID uuid.UUID
+	UserID         uuid.UUID // 作成者または参加者
+}
+
+// ListSplitRequestsRequest は割り勘一覧取得リクエスト
+type ListSplitRequestsRequest struct {
+	RequesterID uuid.UUID
+	Offset      int
+	Limit       int
+}
+
+// ListSplitRequestsResponse は割り勘一覧取得レスポンス
+type ListSplitRequestsResponse struct {
+	SplitRequests []*SplitRequestDetail
+}
+
+// CancelSplitRequestRequest は割り勘キャンセルリクエスト
+type CancelSplitRequestRequest struct {
+	SplitRequestID uuid.UUID
+	UserID         uuid.UUID // 作成者
+}
@@ -0,0 +1,64 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// TransferScreener は送金前に不審な送金を検出するインターフェース（PointTransferInteractorから呼ぶ）
+type TransferScreener interface {
+	// ScreenTransfer は送金が不審かを判定し、不審なら保存前の記録を返す（不審でなければnil）
+	// 保留が有効なら記録は保留中の状態になる。判定に失敗しても送金は止めない（ログに残してnilを返す）
+	ScreenTransfer(ctx context.Context, req *TransferRequest, now time.Time) *entities.SuspiciousActivity
+
+	// RecordSuspiciousTransfer は記録を保存し、有効な管理者全員へ通知する（通知はベストエフォート）
+	RecordSuspiciousTransfer(ctx context.Context, activity *entities.SuspiciousActivity) error
+}
+
+// SuspiciousActivityInputPort は不審な送金の確認のユースケースインターフェース（すべて管理者のみ）
+type SuspiciousActivityInputPort interface {
+	// GetHoldEnabled は不審な送金を保留するかを取得
+	GetHoldEnabled(ctx context.Context, adminID uuid.UUID) (bool, error)
+
+	// UpdateHoldEnabled は不審な送金を保留するかを設定
+	UpdateHoldEnabled(ctx context.Context, req *UpdateTransferHoldRequest) (bool, error)
+
+	// ListActivities は不審な送金の記録を新しい順に取得
+	ListActivities(ctx context.Context, req *ListSuspiciousActivitiesRequest) (*ListSuspiciousActivitiesResponse, error)
+
+	// DismissActivity は問題なしと判断する（保留中の送金は実行する）
+	DismissActivity(ctx context.Context, req *ReviewSuspiciousActivityRequest) (*entities.SuspiciousActivity, error)
+
+	// ConfirmActivity は不正と判断する（保留中の送金は取り消す）
+	ConfirmActivity(ctx context.Context, req *ReviewSuspiciousActivityRequest) (*entities.SuspiciousActivity, error)
+}
+
+// UpdateTransferHoldRequest は不審な送金の保留の設定リクエスト
+type UpdateTransferHoldRequest struct {
+	AdminID uuid.UUID
+	Enabled bool
+}
+
+// ListSuspiciousActivitiesRequest は不審な送金の記録一覧の取得リクエスト
+type ListSuspiciousActivitiesRequest struct {
+	AdminID uuid.UUID
+	Status  entities.SuspiciousActivityStatus // 空なら全状態
+	Offset  int
+	Limit   int
+}
+
+// ListSuspiciousActivitiesResponse は不審な送金の記録一覧の取得レスポンス
+type ListSuspiciousActivitiesResponse struct {
+	Activities []*entities.SuspiciousActivity
+	Total      int64
+}
+
+// ReviewSuspiciousActivityRequest は不審な送金の確認リクエスト
+type ReviewSuspiciousActivityRequest struct {
+	AdminID    uuid.UUID
+	ActivityID uuid.UUID
+	Comment    string
+}
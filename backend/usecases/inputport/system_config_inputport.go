@@ -0,0 +1,14 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// SystemConfigInputPort はサーバー設定の確認のユースケースインターフェース
+type SystemConfigInputPort interface {
+	// GetSettings は起動時に読み込んだ設定を取得（秘密の値は伏せてある。管理者のみ）
+	GetSettings(ctx context.Context, adminID uuid.UUID) (entities.ConfigSettings, error)
+}
@@ -0,0 +1,66 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// TenantInputPort はテナントの解決と管理のユースケースインターフェース
+type TenantInputPort interface {
+	// ResolveTenant はサブドメイン・ヘッダーで指定されたスラッグからリクエストのテナントを決める
+	// 空なら既定のテナント。存在しなければErrTenantNotFound、無効ならErrTenantInactive
+	ResolveTenant(ctx context.Context, slug string) (*entities.Tenant, error)
+
+	// ListTenants はテナントの一覧を取得（スーパー管理者のみ）
+	ListTenants(ctx context.Context, req *ListTenantsRequest) (*ListTenantsResponse, error)
+
+	// CreateTenant はテナントと最初の管理者を作成（スーパー管理者のみ）
+	CreateTenant(ctx context.Context, req *CreateTenantRequest) (*CreateTenantResponse, error)
+
+	// UpdateTenant はテナント名と有効・無効を変更（スーパー管理者のみ）
+	UpdateTenant(ctx context.Context, req *UpdateTenantRequest) (*entities.Tenant, error)
+}
+
+// ListTenantsRequest はテナント一覧取得リクエスト
+type ListTenantsRequest struct {
+	SuperAdminID uuid.UUID
+	Offset       int
+	Limit        int
+}
+
+// ListTenantsResponse はテナント一覧取得レスポンス
+type ListTenantsResponse struct {
+	Tenants []*entities.Tenant
+	Total   int64
+	Offset  int
+	Limit   int
+}
+
+// CreateTenantRequest はテナント作成リクエスト
+type CreateTenantRequest struct {
+	SuperAdminID   uuid.UUID
+	Slug           string
+	Name           string
+	AdminUsername  string
+	AdminEmail     string
+	AdminFirstName string
+	AdminLastName  string
+}
+
+// CreateTenantResponse はテナント作成レスポンス
+// 最初の管理者の仮パスワードはこのレスポンスでしか返さない
+type CreateTenantResponse struct {
+	Tenant            *entities.Tenant
+	Admin             *entities.User
+	TemporaryPassword string
+}
+
+// UpdateTenantRequest はテナント更新リクエスト
+type UpdateTenantRequest struct {
+	SuperAdminID uuid.UUID
+	TenantID     uuid.UUID
+	Name         string
+	IsActive     bool
+}
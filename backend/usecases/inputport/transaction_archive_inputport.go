@@ -0,0 +1,57 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// TransactionArchiver は保持期間を過ぎた取引を保管用のテーブルへ移すインターフェース（定期実行ジョブから呼ぶ）
+type TransactionArchiver interface {
+	// ArchiveTransactions は保持期間を過ぎた確定済みの取引を移し、移した件数を返す（保持期間が未設定なら何もしない）
+	ArchiveTransactions(ctx context.Context, now time.Time) (int64, error)
+}
+
+// TransactionArchiveInputPort は保管済みの取引の検索のユースケースインターフェース（管理者のみ）
+type TransactionArchiveInputPort interface {
+	TransactionArchiver
+
+	// ListArchivedTransactions は保管済みの取引を検索する
+	// 保管用のテーブルは索引が少なく、通常の取引一覧より応答が遅い
+	ListArchivedTransactions(ctx context.Context, req *ListArchivedTransactionsRequest) (*ListArchivedTransactionsResponse, error)
+
+	// GetArchiveSummaries は保管済みの取引の月・種別・状態ごとの集計を取得
+	GetArchiveSummaries(ctx context.Context, req *GetArchiveSummariesRequest) (*GetArchiveSummariesResponse, error)
+}
+
+// ListArchivedTransactionsRequest は保管済みの取引の検索リクエスト
+type ListArchivedTransactionsRequest struct {
+	AdminID uuid.UUID
+	Filter  entities.ArchivedTransactionFilter
+	Offset  int
+	Limit   int
+}
+
+// ListArchivedTransactionsResponse は保管済みの取引の検索レスポンス
+type ListArchivedTransactionsResponse struct {
+	Transactions  []*entities.ArchivedTransaction
+	Total         int64
+	Offset        int
+	Limit         int
+	RetentionDays int // 現在の保持期間（0なら移していない）
+}
+
+// GetArchiveSummariesRequest は保管済みの取引の集計の取得リクエスト
+type GetArchiveSummariesRequest struct {
+	AdminID   uuid.UUID
+	FromMonth string // 2006-01（空なら制限なし）
+	ToMonth   string
+}
+
+// GetArchiveSummariesResponse は保管済みの取引の集計の取得レスポンス
+type GetArchiveSummariesResponse struct {
+	Summaries     []*entities.TransactionArchiveSummary
+	RetentionDays int
+}
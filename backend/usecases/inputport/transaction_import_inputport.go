@@ -0,0 +1,52 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// TransactionImportInputPort は過去の取引の取り込みのユースケースインターフェース
+type TransactionImportInputPort interface {
+	// ImportTransactions はCSVから他のシステムの過去の取引を元の日時で取り込む（管理者のみ）
+	// 1行でもエラーがあれば何も書き込まず、行ごとのエラーをレスポンスの各行に入れて返す
+	ImportTransactions(ctx context.Context, req *ImportTransactionsRequest) (*ImportTransactionsResponse, error)
+}
+
+// ImportTransactionsRequest は過去の取引の取り込みリクエスト
+type ImportTransactionsRequest struct {
+	AdminID uuid.UUID
+	CSVData []byte
+	DryRun  bool // trueなら検証と照合の結果だけを返し、書き込まない
+}
+
+// ImportTransactionsResponse は過去の取引の取り込みレスポンス
+type ImportTransactionsResponse struct {
+	ImportID      uuid.UUID
+	DryRun        bool
+	Imported      bool // 書き込んだかどうか（ドライラン・エラーありならfalse）
+	Results       []*TransactionImportResult
+	ImportedCount int
+	FailedCount   int
+	Balances      []*TransactionImportBalance // エラーがなければ、取り込み後の残高と取引履歴の照合結果（ユーザーID順）
+}
+
+// TransactionImportResult は取り込みの1行ごとの結果
+type TransactionImportResult struct {
+	Line          int
+	Date          time.Time
+	From          string
+	To            string
+	Amount        int64
+	Type          entities.TransactionType
+	TransactionID *uuid.UUID // 書き込んだ場合のみ
+	Err           error
+}
+
+// TransactionImportBalance は取引を取り込んだユーザーの残高
+type TransactionImportBalance struct {
+	entities.BalanceCheck
+	BalanceBefore int64 // 取り込み前の残高
+}
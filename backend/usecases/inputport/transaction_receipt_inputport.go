@@ -0,0 +1,36 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// TransactionReceiptInputPort は取引の控えのユースケースインターフェース
+type TransactionReceiptInputPort interface {
+	// GetReceipt は取引の控えを検証ハッシュ付きで発行（送信者・受取人の確認はルートの認可で行う）
+	GetReceipt(ctx context.Context, req *GetTransactionReceiptRequest) (*entities.TransactionReceipt, error)
+
+	// VerifyReceipt は提示された控えの検証ハッシュが本物かを確認（管理者のみ）
+	VerifyReceipt(ctx context.Context, req *VerifyTransactionReceiptRequest) (*VerifyTransactionReceiptResponse, error)
+}
+
+// GetTransactionReceiptRequest は取引の控えの発行リクエスト
+type GetTransactionReceiptRequest struct {
+	UserID        uuid.UUID
+	TransactionID uuid.UUID
+}
+
+// VerifyTransactionReceiptRequest は取引の控えの確認リクエスト
+type VerifyTransactionReceiptRequest struct {
+	AdminID       uuid.UUID
+	TransactionID uuid.UUID
+	Hash          string // 控えに印刷された検証ハッシュ
+}
+
+// VerifyTransactionReceiptResponse は取引の控えの確認レスポンス
+type VerifyTransactionReceiptResponse struct {
+	Valid   bool
+	Receipt *entities.TransactionReceipt // 現在の取引から作り直した控え（提示された控えと見比べる。取り消し済みかもわかる）
+}
@@ -0,0 +1,51 @@
+package inputport
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// TransferAttachmentInputPort は送金・送金リクエストの添付画像のユースケースインターフェース
+type TransferAttachmentInputPort interface {
+	// AttachToTransaction は完了した送金に画像を添付（当事者のみ。既に添付があれば置き換える）
+	AttachToTransaction(ctx context.Context, req *AttachTransferImageRequest) (*entities.TransferAttachment, error)
+
+	// AttachToTransferRequest は承認待ちの送金リクエストに画像を添付（当事者のみ。既に添付があれば置き換える）
+	AttachToTransferRequest(ctx context.Context, req *AttachTransferImageRequest) (*entities.TransferAttachment, error)
+
+	// GetTransactionAttachment は送金の添付画像を取得（当事者のみ。元の送金リクエストに添付したものを含む）
+	GetTransactionAttachment(ctx context.Context, req *GetTransferAttachmentRequest) (*GetTransferAttachmentResponse, error)
+
+	// GetTransferRequestAttachment は送金リクエストの添付画像を取得（当事者のみ）
+	GetTransferRequestAttachment(ctx context.Context, req *GetTransferAttachmentRequest) (*GetTransferAttachmentResponse, error)
+}
+
+// TransferAttachmentCleaner は保持期間を過ぎた添付画像を削除するインターフェース（定期実行ジョブから呼ぶ）
+type TransferAttachmentCleaner interface {
+	// CleanupAttachments は保持期間を過ぎた添付画像を削除し、削除した件数を返す（保持期間が未設定なら何もしない）
+	CleanupAttachments(ctx context.Context, now time.Time) (int64, error)
+}
+
+// AttachTransferImageRequest は画像の添付リクエスト
+type AttachTransferImageRequest struct {
+	UserID   uuid.UUID
+	TargetID uuid.UUID // 送金（取引）または送金リクエストのID
+	FileData []byte
+	FileName string
+}
+
+// GetTransferAttachmentRequest は添付画像の取得リクエスト
+type GetTransferAttachmentRequest struct {
+	UserID   uuid.UUID
+	TargetID uuid.UUID // 送金（取引）または送金リクエストのID
+}
+
+// GetTransferAttachmentResponse は添付画像の取得レスポンス
+type GetTransferAttachmentResponse struct {
+	Attachment *entities.TransferAttachment
+	Content    io.ReadCloser // 呼び出し側で閉じる
+}
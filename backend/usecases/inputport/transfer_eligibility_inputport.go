@@ -0,0 +1,38 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// TransferEligibilityChecker は送信者が送金できる条件を満たすかを確認するインターフェース
+// （PointTransferInteractor・TransferRequestInteractorから呼ぶ）
+type TransferEligibilityChecker interface {
+	// CheckSender は送信者が送金できるかを確認（新規登録時のメール認証が済んでいなければErrEmailNotVerified、メール未認証ならErrEmailVerificationRequired、作成直後ならErrAccountTooNew）
+	CheckSender(ctx context.Context, userID uuid.UUID) error
+
+	// CheckRecipient は受取人が送信者からのポイントを受け取るかを確認（友達からだけ受け取る受取人に友達でない人が送るとErrRecipientAcceptsFriendsOnly）
+	CheckRecipient(ctx context.Context, senderID, recipientID uuid.UUID) error
+
+	// AcceptanceOf はユーザーが誰からのポイントを受け取るか（ユーザーの設定がなければシステム設定の既定）
+	AcceptanceOf(ctx context.Context, user *entities.User) (entities.TransferAcceptance, error)
+}
+
+// TransferEligibilityInputPort は送金できるユーザーの条件のユースケースインターフェース（管理者のみ）
+type TransferEligibilityInputPort interface {
+	// GetPolicy は送金できるユーザーの条件を取得
+	GetPolicy(ctx context.Context, adminID uuid.UUID) (*entities.TransferEligibilityPolicy, error)
+
+	// UpdatePolicy は送金できるユーザーの条件を設定
+	UpdatePolicy(ctx context.Context, req *UpdateTransferEligibilityRequest) (*entities.TransferEligibilityPolicy, error)
+}
+
+// UpdateTransferEligibilityRequest は送金できるユーザーの条件の設定リクエスト
+type UpdateTransferEligibilityRequest struct {
+	AdminID                  uuid.UUID
+	RequireEmailVerification bool
+	MinAccountAgeHours       int
+	DefaultAcceptance        entities.TransferAcceptance // 受け取る相手を設定していないユーザーの既定（空ならanyone）
+}
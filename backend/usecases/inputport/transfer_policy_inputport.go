@@ -0,0 +1,35 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// TransferPolicyProvider は送金額の上下限と手数料の設定を読むインターフェース
+// （PointTransferInteractorから呼ぶ）
+type TransferPolicyProvider interface {
+	// CurrentPolicy は現在の設定を取得（未設定なら上下限なし・手数料なし）
+	CurrentPolicy(ctx context.Context) (*entities.TransferPolicy, error)
+}
+
+// TransferPolicyInputPort は送金額の上下限と手数料のユースケースインターフェース（管理者のみ）
+type TransferPolicyInputPort interface {
+	// GetPolicy は送金額の上下限と手数料を取得
+	GetPolicy(ctx context.Context, adminID uuid.UUID) (*entities.TransferPolicy, error)
+
+	// UpdatePolicy は送金額の上下限と手数料を設定
+	UpdatePolicy(ctx context.Context, req *UpdateTransferPolicyRequest) (*entities.TransferPolicy, error)
+}
+
+// UpdateTransferPolicyRequest は送金額の上下限と手数料の設定リクエスト
+type UpdateTransferPolicyRequest struct {
+	AdminID            uuid.UUID
+	MinAmount          int64
+	MaxAmount          int64
+	FeeType            entities.TransferFeeType
+	FeeFlat            int64
+	FeeRateBasisPoints int64
+	FeeAccountID       *uuid.UUID
+}
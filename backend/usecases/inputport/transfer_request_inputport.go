@@ -18,6 +18,12 @@ type TransferRequestInputPort interface {
 	// RejectTransferRequest は送金リクエストを拒否（受取人が拒否）
 	RejectTransferRequest(ctx context.Context, req *RejectTransferRequestRequest) (*RejectTransferRequestResponse, error)
 
+	// CounterTransferRequest は金額を変更して送信者に差し戻す（受取人がカウンターオファー）
+	CounterTransferRequest(ctx context.Context, req *CounterTransferRequestRequest) (*CounterTransferRequestResponse, error)
+
+	// ConfirmCounterOffer はカウンターオファーを確定して送金する（送信者が確認）
+	ConfirmCounterOffer(ctx context.Context, req *ConfirmCounterOfferRequest) (*ConfirmCounterOfferResponse, error)
+
 	// CancelTransferRequest は送金リクエストをキャンセル（送信者がキャンセル）
 	CancelTransferRequest(ctx context.Context, req *CancelTransferRequestRequest) (*CancelTransferRequestResponse, error)
 
@@ -32,6 +38,15 @@ type TransferRequestInputPort interface {
 
 	// GetPendingRequestCount は受取人宛の承認待ちリクエスト数を取得
 	GetPendingRequestCount(ctx context.Context, req *GetPendingRequestCountRequest) (*GetPendingRequestCountResponse, error)
+
+	// MuteSender は送信者をミュート（以降の送金リクエストは通知せず、modeに従って自動で拒否するか承認待ちのまま見せない）
+	MuteSender(ctx context.Context, req *MuteSenderRequest) (*entities.MutedSender, error)
+
+	// UnmuteSender は送信者のミュートを解除（ミュートしていなければ何もしない）
+	UnmuteSender(ctx context.Context, userID, mutedUserID uuid.UUID) error
+
+	// GetMutedSenders はミュートした送信者をミュートした順に取得
+	GetMutedSenders(ctx context.Context, userID uuid.UUID) ([]*entities.MutedSenderWithUser, error)
 }
 
 // CreateTransferRequestRequest は送金リクエスト作成リクエスト
@@ -41,6 +56,7 @@ type CreateTransferRequestRequest struct {
 	Amount         int64
 	Message        string
 	IdempotencyKey string
+	Hold           bool // 承認まで送信者の残高から金額を確保する
 }
 
 // CreateTransferRequestResponse は送金リクエスト作成レスポンス
@@ -53,7 +69,7 @@ type CreateTransferRequestResponse struct {
 // ApproveTransferRequestRequest は送金リクエスト承認リクエスト
 type ApproveTransferRequestRequest struct {
 	RequestID uuid.UUID
-	UserID    uuid.UUID // 承認者（受取人）
+	UserID    uuid.UUID // 承認者（受取人、割り勘の1人分は支払う参加者）
 }
 
 // ApproveTransferRequestResponse は送金リクエスト承認レスポンス
@@ -67,7 +83,7 @@ type ApproveTransferRequestResponse struct {
 // RejectTransferRequestRequest は送金リクエスト拒否リクエスト
 type RejectTransferRequestRequest struct {
 	RequestID uuid.UUID
-	UserID    uuid.UUID // 拒否者（受取人）
+	UserID    uuid.UUID // 拒否者（受取人、割り勘の1人分は支払う参加者）
 }
 
 // RejectTransferRequestResponse は送金リクエスト拒否レスポンス
@@ -75,10 +91,36 @@ type RejectTransferRequestResponse struct {
 	TransferRequest *entities.TransferRequest
 }
 
+// CounterTransferRequestRequest はカウンターオファーリクエスト
+type CounterTransferRequestRequest struct {
+	RequestID uuid.UUID
+	UserID    uuid.UUID // カウンターオファーする受取人
+	Amount    int64     // 変更後の金額
+}
+
+// CounterTransferRequestResponse はカウンターオファーレスポンス
+type CounterTransferRequestResponse struct {
+	TransferRequest *entities.TransferRequest
+}
+
+// ConfirmCounterOfferRequest はカウンターオファー確定リクエスト
+type ConfirmCounterOfferRequest struct {
+	RequestID uuid.UUID
+	UserID    uuid.UUID // 確定する送信者
+}
+
+// ConfirmCounterOfferResponse はカウンターオファー確定レスポンス
+type ConfirmCounterOfferResponse struct {
+	TransferRequest *entities.TransferRequest
+	Transaction     *entities.Transaction
+	FromUser        *entities.User
+	ToUser          *entities.User
+}
+
 // CancelTransferRequestRequest は送金リクエストキャンセルリクエスト
 type CancelTransferRequestRequest struct {
 	RequestID uuid.UUID
-	UserID    uuid.UUID // キャンセル者（送信者）
+	UserID    uuid.UUID // キャンセル者（送信者）。カウンターオファーの辞退にも使う
 }
 
 // CancelTransferRequestResponse は送金リクエストキャンセルレスポンス
@@ -139,3 +181,10 @@ type GetPendingRequestCountRequest struct {
 type GetPendingRequestCountResponse struct {
 	Count int64
 }
+
+// MuteSenderRequest は送信者のミュートリクエスト（既にミュートしていればmodeだけ変える）
+type MuteSenderRequest struct {
+	UserID      uuid.UUID
+	MutedUserID uuid.UUID
+	Mode        entities.MuteMode
+}
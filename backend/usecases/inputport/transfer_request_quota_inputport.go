@@ -0,0 +1,31 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// TransferRequestQuotaChecker は送信者が送金リクエストをもう1件出せるかを確認するインターフェース
+// （TransferRequestInteractorから呼ぶ）
+type TransferRequestQuotaChecker interface {
+	// CheckQuota は送信者の承認待ちのリクエストが上限に達していないかを確認（全体ならErrPendingRequestLimit、同じ受取人宛ならErrRecipientRequestLimit）
+	CheckQuota(ctx context.Context, fromUserID, toUserID uuid.UUID) error
+}
+
+// TransferRequestQuotaInputPort は承認待ちの送金リクエストの上限のユースケースインターフェース（管理者のみ）
+type TransferRequestQuotaInputPort interface {
+	// GetQuota は承認待ちの送金リクエストの上限を取得（未設定なら既定の上限）
+	GetQuota(ctx context.Context, adminID uuid.UUID) (*entities.TransferRequestQuota, error)
+
+	// UpdateQuota は承認待ちの送金リクエストの上限を設定
+	UpdateQuota(ctx context.Context, req *UpdateTransferRequestQuotaRequest) (*entities.TransferRequestQuota, error)
+}
+
+// UpdateTransferRequestQuotaRequest は承認待ちの送金リクエストの上限の設定リクエスト
+type UpdateTransferRequestQuotaRequest struct {
+	AdminID                uuid.UUID
+	MaxPendingPerSender    int // 0で無効
+	MaxPendingPerRecipient int // 0で無効
+}
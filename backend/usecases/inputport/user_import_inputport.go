@@ -0,0 +1,40 @@
+package inputport
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// UserImportInputPort はユーザー一括登録のユースケースインターフェース
+type UserImportInputPort interface {
+	// ImportUsers はCSVからユーザーを一括登録する（管理者のみ）
+	// 行ごとのエラーはレスポンスの各行に入れ、ファイル全体の不備だけをエラーとして返す
+	ImportUsers(ctx context.Context, req *ImportUsersRequest) (*ImportUsersResponse, error)
+}
+
+// ImportUsersRequest はユーザー一括登録リクエスト
+type ImportUsersRequest struct {
+	AdminID         uuid.UUID
+	CSVData         []byte
+	SendInvitations bool // 登録したユーザーへ仮パスワード入りの招待メールを送る
+}
+
+// ImportUsersResponse はユーザー一括登録レスポンス
+type ImportUsersResponse struct {
+	Results      []*UserImportResult
+	CreatedCount int
+	FailedCount  int
+}
+
+// UserImportResult は一括登録の1行ごとの結果
+type UserImportResult struct {
+	Line              int
+	Username          string
+	Email             string
+	UserID            *uuid.UUID // 登録できた場合のみ
+	InitialPoints     int64
+	TemporaryPassword string // 招待メールを送らなかった（送れなかった）場合のみ、管理者が本人へ伝える
+	InvitationSent    bool
+	Err               error
+}
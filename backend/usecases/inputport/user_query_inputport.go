@@ -23,7 +23,8 @@ type GetUserByIDRequest struct {
 
 // GetUserByIDResponse はユーザーID検索のレスポンス
 type GetUserByIDResponse struct {
-	User *entities.User
+	User                 *entities.User
+	AcceptsTransfersFrom entities.TransferAcceptance // 誰からのポイントを受け取るか（システム設定の既定を反映済み）
 }
 
 // SearchUserByUsernameRequest はユーザー名検索のリクエスト
@@ -33,5 +34,6 @@ type SearchUserByUsernameRequest struct {
 
 // SearchUserByUsernameResponse はユーザー名検索のレスポンス
 type SearchUserByUsernameResponse struct {
-	User *entities.User
+	User                 *entities.User
+	AcceptsTransfersFrom entities.TransferAcceptance // 誰からのポイントを受け取るか（システム設定の既定を反映済み）
 }
@@ -35,6 +35,15 @@ type UserSettingsInputPort interface {
 
 	// GetProfile はプロフィール情報を取得
 	GetProfile(ctx context.Context, req *GetProfileRequest) (*GetProfileResponse, error)
+
+	// UpdatePrivacy はプライバシー設定（友達検索での公開可否）を更新
+	UpdatePrivacy(ctx context.Context, req *UpdatePrivacyRequest) (*UpdatePrivacyResponse, error)
+
+	// UpdateTimezone はボーナス日の区切りに使うタイムゾーンを更新（空ならシステム設定に従う）
+	UpdateTimezone(ctx context.Context, req *UpdateTimezoneRequest) (*UpdateTimezoneResponse, error)
+
+	// UpdateTransferAcceptance は誰からのポイントを受け取るかを更新（空ならシステム設定に従う）
+	UpdateTransferAcceptance(ctx context.Context, req *UpdateTransferAcceptanceRequest) (*UpdateTransferAcceptanceResponse, error)
 }
 
 // UpdateProfileRequest はプロフィール更新リクエスト
@@ -49,7 +58,8 @@ type UpdateProfileRequest struct {
 // UpdateProfileResponse はプロフィール更新レスポンス
 type UpdateProfileResponse struct {
 	User                  *entities.User
-	EmailVerificationSent bool // メール変更時にtrueになる
+	EmailVerificationSent bool   // メール変更時にtrueになる
+	PendingEmail          string // 確認待ちの新しいメールアドレス（確認完了までUser.Emailは変更されない）
 }
 
 // UpdateUsernameRequest はユーザー名変更リクエスト
@@ -100,8 +110,10 @@ type VerifyEmailRequest struct {
 
 // VerifyEmailResponse はメール認証レスポンス
 type VerifyEmailResponse struct {
-	User  *entities.User
-	Email string
+	User             *entities.User
+	Email            string
+	AwaitingNewEmail bool // メール変更: 新アドレスの認証待ち
+	AwaitingOldEmail bool // メール変更: 旧アドレスの確認待ち（タイムアウト後は新アドレスのリンクで適用）
 }
 
 // ArchiveAccountRequest はアカウント削除（アーカイブ）リクエスト
@@ -120,3 +132,36 @@ type GetProfileRequest struct {
 type GetProfileResponse struct {
 	User *entities.User
 }
+
+// UpdatePrivacyRequest はプライバシー設定更新リクエスト
+type UpdatePrivacyRequest struct {
+	UserID       uuid.UUID
+	Discoverable bool
+}
+
+// UpdatePrivacyResponse はプライバシー設定更新レスポンス
+type UpdatePrivacyResponse struct {
+	User *entities.User
+}
+
+// UpdateTimezoneRequest はタイムゾーン更新リクエスト
+type UpdateTimezoneRequest struct {
+	UserID   uuid.UUID
+	Timezone string // IANAのタイムゾーン名（空ならシステム設定に従う）
+}
+
+// UpdateTimezoneResponse はタイムゾーン更新レスポンス
+type UpdateTimezoneResponse struct {
+	User *entities.User
+}
+
+// UpdateTransferAcceptanceRequest はポイントを受け取る相手の設定の更新リクエスト
+type UpdateTransferAcceptanceRequest struct {
+	UserID              uuid.UUID
+	AcceptTransfersFrom entities.TransferAcceptance // anyone / friends_only（空ならシステム設定に従う）
+}
+
+// UpdateTransferAcceptanceResponse はポイントを受け取る相手の設定の更新レスポンス
+type UpdateTransferAcceptanceResponse struct {
+	User *entities.User
+}
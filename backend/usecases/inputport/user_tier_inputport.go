@@ -0,0 +1,35 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// UserTierInputPort は会員ランクのユースケースインターフェース
+type UserTierInputPort interface {
+	// RecalculateTiers は全ユーザーのランクを利用状況から判定し直す（夜間ワーカー用）
+	// ランクが変わったユーザー数を返す
+	RecalculateTiers(ctx context.Context, now time.Time) (int, error)
+
+	// OverrideTier は管理者がユーザーのランクを固定する（夜間の再判定で上書きされない）
+	OverrideTier(ctx context.Context, req *OverrideTierRequest) (*entities.User, error)
+
+	// ClearTierOverride は固定を解除し、その場で利用状況から判定し直す
+	ClearTierOverride(ctx context.Context, req *ClearTierOverrideRequest) (*entities.User, error)
+}
+
+// OverrideTierRequest はランク固定リクエスト
+type OverrideTierRequest struct {
+	AdminID uuid.UUID
+	UserID  uuid.UUID
+	Tier    entities.UserTier
+}
+
+// ClearTierOverrideRequest はランク固定の解除リクエスト
+type ClearTierOverrideRequest struct {
+	AdminID uuid.UUID
+	UserID  uuid.UUID
+}
@@ -0,0 +1,39 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// WeeklyDigestSender は週次のまとめメールを送るインターフェース（定期実行ジョブから呼ぶ）
+type WeeklyDigestSender interface {
+	// SendWeeklyDigests はnowまでの1週間のまとめを送信対象のユーザーへ送り、送った件数を返す
+	SendWeeklyDigests(ctx context.Context, now time.Time) (int64, error)
+}
+
+// WeeklyDigestInputPort は週次のまとめメールのユースケースインターフェース
+type WeeklyDigestInputPort interface {
+	WeeklyDigestSender
+
+	// PreviewWeeklyDigest はユーザーに今送るまとめメールを送らずに作成する（管理者のみ）
+	PreviewWeeklyDigest(ctx context.Context, req *PreviewWeeklyDigestRequest) (*WeeklyDigestPreview, error)
+}
+
+// PreviewWeeklyDigestRequest は週次のまとめメールのプレビューリクエスト
+type PreviewWeeklyDigestRequest struct {
+	AdminID uuid.UUID
+	UserID  uuid.UUID
+}
+
+// WeeklyDigestPreview は週次のまとめメールのプレビュー
+type WeeklyDigestPreview struct {
+	Digest *entities.WeeklyDigest
+	Email  *entities.WeeklyDigestEmail
+	// OptedIn は送信対象か（有効でメールアドレスがあり、通知設定で止めていない）
+	OptedIn bool
+	// WouldSend は次のジョブで実際に送るか（送信対象で、知らせる内容がある）
+	WouldSend bool
+}
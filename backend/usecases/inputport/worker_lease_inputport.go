@@ -0,0 +1,21 @@
+package inputport
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// WorkerLeaseInputPort はワーカーのリーダー選出のユースケースインターフェース
+type WorkerLeaseInputPort interface {
+	// Campaign はholderをワーカーのリーダーにする（リーダーなら期限を延ばす）。リーダーになれたかを返す
+	Campaign(ctx context.Context, name, holder string, now time.Time) (bool, error)
+
+	// Resign はholderがリーダーなら降りる（他のインスタンスがすぐ引き継げる）
+	Resign(ctx context.Context, name, holder string) error
+
+	// ListLeases は全ワーカーのリーダーと交代回数を取得（管理者のみ）
+	ListLeases(ctx context.Context, adminID uuid.UUID) ([]*entities.WorkerLease, error)
+}
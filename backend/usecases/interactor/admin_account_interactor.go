@@ -0,0 +1,164 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+)
+
+// adminAccountMinPasswordLength は指定したパスワードの最短の長さ（登録・パスワード変更のAPIと同じ）
+const adminAccountMinPasswordLength = 8
+
+// AdminAccountInteractor は管理者アカウントの作成・パスワードの再設定のユースケース実装
+type AdminAccountInteractor struct {
+	userRepo        repository.UserRepository
+	sessionRepo     repository.SessionRepository
+	passwordService service.PasswordService
+	logger          entities.Logger
+}
+
+// NewAdminAccountInteractor は新しいAdminAccountInteractorを作成
+func NewAdminAccountInteractor(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	passwordService service.PasswordService,
+	logger entities.Logger,
+) inputport.AdminAccountInputPort {
+	return &AdminAccountInteractor{
+		userRepo:        userRepo,
+		sessionRepo:     sessionRepo,
+		passwordService: passwordService,
+		logger:          logger,
+	}
+}
+
+// CreateAdmin は管理者を作成
+func (i *AdminAccountInteractor) CreateAdmin(ctx context.Context, req *inputport.CreateAdminRequest) (*inputport.CreateAdminResponse, error) {
+	if req.ActorID != nil {
+		if err := requireAdmin(ctx, i.userRepo, *req.ActorID); err != nil {
+			return nil, err
+		}
+	} else {
+		// 操作する管理者がいないのは最初の管理者を作るときだけ
+		count, err := i.userRepo.Count(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, entities.ErrAdminRequired
+		}
+	}
+
+	password, temporary, err := i.choosePassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := i.passwordService.HashPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	displayName := req.DisplayName
+	if displayName == "" {
+		displayName = req.Username
+	}
+	admin, err := entities.NewUser(req.Username, req.Email, hash, displayName, req.FirstName, req.LastName)
+	if err != nil {
+		return nil, err
+	}
+	admin.Role = entities.RoleAdmin
+	// 管理者は自分で作るアカウントではないので、メール認証を求めない
+	admin.VerifyEmail()
+
+	if _, err := i.userRepo.ReadByUsername(ctx, admin.Username); err == nil {
+		return nil, entities.ErrUsernameAlreadyExists
+	}
+	if _, err := i.userRepo.ReadByEmail(ctx, admin.Email); err == nil {
+		return nil, entities.ErrEmailAlreadyExists
+	}
+	if err := i.userRepo.Create(ctx, admin); err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Admin account created",
+		entities.NewField("actor_id", actorField(req.ActorID)),
+		entities.NewField("admin_id", admin.ID),
+		entities.NewField("username", admin.Username))
+
+	return &inputport.CreateAdminResponse{Admin: admin, TemporaryPassword: temporary}, nil
+}
+
+// ResetPassword はユーザーのパスワードを再設定し、ログイン中のセッションをすべて無効にする
+func (i *AdminAccountInteractor) ResetPassword(ctx context.Context, req *inputport.ResetUserPasswordRequest) (*inputport.ResetUserPasswordResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.ActorID); err != nil {
+		return nil, err
+	}
+
+	user, err := i.userRepo.Read(ctx, req.UserID)
+	if err != nil {
+		return nil, entities.ErrUserNotFound
+	}
+
+	password, temporary, err := i.choosePassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := i.passwordService.HashPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	if err := user.UpdatePassword(hash); err != nil {
+		return nil, err
+	}
+
+	updated, err := i.userRepo.Update(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save password: %w", err)
+	}
+	if !updated {
+		return nil, errors.New("password update failed due to version conflict")
+	}
+
+	// 以前のパスワードでログインしたままにならないよう、セッションをすべて削除する
+	if err := i.sessionRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	i.logger.Info("User password reset by admin",
+		entities.NewField("actor_id", req.ActorID),
+		entities.NewField("user_id", user.ID))
+
+	return &inputport.ResetUserPasswordResponse{User: user, TemporaryPassword: temporary}, nil
+}
+
+// choosePassword は指定したパスワードを検証し、指定がなければ仮パスワードを生成する（生成したときだけtemporaryを返す）
+func (i *AdminAccountInteractor) choosePassword(password string) (string, string, error) {
+	if password != "" {
+		if len(password) < adminAccountMinPasswordLength {
+			return "", "", entities.NewValidationError(entities.FieldError{
+				Field:   "password",
+				Message: fmt.Sprintf("must be at least %d characters", adminAccountMinPasswordLength),
+			})
+		}
+		return password, "", nil
+	}
+	temporary, err := entities.GenerateTemporaryPassword()
+	if err != nil {
+		return "", "", err
+	}
+	return temporary, temporary, nil
+}
+
+// actorField はログに残す操作した管理者（最初の管理者の作成なら"bootstrap"）
+func actorField(actorID *uuid.UUID) string {
+	if actorID == nil {
+		return "bootstrap"
+	}
+	return actorID.String()
+}
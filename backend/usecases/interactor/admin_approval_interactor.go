@@ -0,0 +1,292 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// expireBatchSize は1回の期限切れ処理で扱う操作の上限
+const expireBatchSize = 100
+
+// AdminApprovalInteractor は大口の付与・減算の承認のユースケース実装
+// 承認待ちの登録はAdminInteractorが閾値を超える付与・減算のときに行う
+type AdminApprovalInteractor struct {
+	txManager    repository.TransactionManager
+	approvalRepo repository.PendingAdminActionRepository
+	settingsRepo repository.SystemSettingsRepository
+	userRepo     repository.UserRepository
+	admin        inputport.AdminInputPort
+	logger       entities.Logger
+}
+
+// NewAdminApprovalInteractor は新しいAdminApprovalInteractorを作成
+func NewAdminApprovalInteractor(
+	txManager repository.TransactionManager,
+	approvalRepo repository.PendingAdminActionRepository,
+	settingsRepo repository.SystemSettingsRepository,
+	userRepo repository.UserRepository,
+	admin inputport.AdminInputPort,
+	logger entities.Logger,
+) inputport.AdminApprovalInputPort {
+	return &AdminApprovalInteractor{
+		txManager:    txManager,
+		approvalRepo: approvalRepo,
+		settingsRepo: settingsRepo,
+		userRepo:     userRepo,
+		admin:        admin,
+		logger:       logger,
+	}
+}
+
+// GetApprovalThreshold は承認が必要になる金額を取得
+func (i *AdminApprovalInteractor) GetApprovalThreshold(ctx context.Context, adminID uuid.UUID) (int64, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return 0, err
+	}
+	value, err := i.settingsRepo.GetSetting(ctx, entities.AdminApprovalThresholdSettingKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get approval threshold: %w", err)
+	}
+	return entities.ParseAdminApprovalThreshold(value), nil
+}
+
+// UpdateApprovalThreshold は承認が必要になる金額を設定
+func (i *AdminApprovalInteractor) UpdateApprovalThreshold(ctx context.Context, req *inputport.UpdateApprovalThresholdRequest) (int64, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return 0, err
+	}
+	if req.Threshold < 0 {
+		return 0, entities.ErrInvalidApprovalLimit
+	}
+	if err := i.settingsRepo.SetSetting(ctx, entities.AdminApprovalThresholdSettingKey, strconv.FormatInt(req.Threshold, 10), "二人目の管理者の承認が必要になる付与・減算の金額"); err != nil {
+		return 0, fmt.Errorf("failed to save approval threshold: %w", err)
+	}
+
+	i.logger.Info("Admin approval threshold updated",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("threshold", req.Threshold))
+	return req.Threshold, nil
+}
+
+// ListPendingActions は承認待ちの操作を新しい順に取得
+func (i *AdminApprovalInteractor) ListPendingActions(ctx context.Context, req *inputport.ListPendingActionsRequest) (*inputport.ListPendingActionsResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	actions, err := i.approvalRepo.ReadList(ctx, req.Status, req.Offset, req.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending actions: %w", err)
+	}
+	total, err := i.approvalRepo.Count(ctx, req.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pending actions: %w", err)
+	}
+	return &inputport.ListPendingActionsResponse{Actions: actions, Total: total}, nil
+}
+
+// GetPendingAction は操作と監査記録を取得
+func (i *AdminApprovalInteractor) GetPendingAction(ctx context.Context, req *inputport.GetPendingActionRequest) (*inputport.PendingActionDetail, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	action, err := i.approvalRepo.Read(ctx, req.ActionID)
+	if err != nil {
+		return nil, err
+	}
+	return i.detail(ctx, action)
+}
+
+// ApproveAction は操作を承認して、申請した管理者の名前で付与・減算を実行する
+// 承認を先にコミットしてから実行するため、同時に承認されても実行は一度だけになる
+// 実行に失敗した場合（残高不足・予算超過など）は失敗として記録し、再申請が必要になる
+func (i *AdminApprovalInteractor) ApproveAction(ctx context.Context, req *inputport.ReviewPendingActionRequest) (*inputport.PendingActionDetail, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	action, err := i.approvalRepo.Read(ctx, req.ActionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if action.IsExpired(now) {
+		if err := i.expire(ctx, action, now); err != nil {
+			return nil, err
+		}
+		return nil, entities.ErrPendingActionExpired
+	}
+	if err := action.Approve(req.AdminID, req.Comment, now); err != nil {
+		return nil, err
+	}
+	if err := i.transition(ctx, action, entities.PendingAdminActionStatusPending, entities.PendingAdminActionEventApproved, &req.AdminID, action.ReviewComment); err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Admin action approved",
+		entities.NewField("action_id", action.ID),
+		entities.NewField("approved_by", req.AdminID),
+		entities.NewField("requested_by", action.RequestedBy))
+
+	transactionID, execErr := i.execute(ctx, action, req.AdminID)
+	if execErr != nil {
+		action.MarkFailed(execErr.Error(), time.Now())
+		if err := i.transition(ctx, action, entities.PendingAdminActionStatusApproved, entities.PendingAdminActionEventFailed, nil, action.FailureReason); err != nil {
+			return nil, err
+		}
+		i.logger.Warn("Approved admin action failed",
+			entities.NewField("action_id", action.ID),
+			entities.NewField("error", execErr))
+		return nil, execErr
+	}
+
+	action.MarkExecuted(transactionID, time.Now())
+	if err := i.transition(ctx, action, entities.PendingAdminActionStatusApproved, entities.PendingAdminActionEventExecuted, nil, ""); err != nil {
+		return nil, err
+	}
+	i.logger.Info("Approved admin action executed",
+		entities.NewField("action_id", action.ID),
+		entities.NewField("transaction_id", transactionID))
+	return i.detail(ctx, action)
+}
+
+// execute は承認された操作を申請した管理者の付与・減算として実行し、取引IDを返す
+func (i *AdminApprovalInteractor) execute(ctx context.Context, action *entities.PendingAdminAction, approvedBy uuid.UUID) (uuid.UUID, error) {
+	switch action.ActionType {
+	case entities.PendingAdminActionGrant:
+		resp, err := i.admin.GrantPoints(ctx, &inputport.GrantPointsRequest{
+			AdminID:        action.RequestedBy,
+			UserID:         action.TargetUserID,
+			Amount:         action.Amount,
+			Description:    action.Description,
+			ReasonCode:     action.ReasonCode,
+			Tag:            action.Tag,
+			IdempotencyKey: action.IdempotencyKey,
+			BudgetOverride: action.BudgetOverride,
+			ApprovedBy:     &approvedBy,
+		})
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if resp.Transaction == nil {
+			return uuid.Nil, fmt.Errorf("transaction for idempotency key %s not found", action.IdempotencyKey)
+		}
+		return resp.Transaction.ID, nil
+	case entities.PendingAdminActionDeduct:
+		resp, err := i.admin.DeductPoints(ctx, &inputport.DeductPointsRequest{
+			AdminID:        action.RequestedBy,
+			UserID:         action.TargetUserID,
+			Amount:         action.Amount,
+			Description:    action.Description,
+			ReasonCode:     action.ReasonCode,
+			Tag:            action.Tag,
+			IdempotencyKey: action.IdempotencyKey,
+			ApprovedBy:     &approvedBy,
+		})
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if resp.Transaction == nil {
+			return uuid.Nil, fmt.Errorf("transaction for idempotency key %s not found", action.IdempotencyKey)
+		}
+		return resp.Transaction.ID, nil
+	}
+	return uuid.Nil, fmt.Errorf("unknown admin action type: %s", action.ActionType)
+}
+
+// RejectAction は操作を却下する
+func (i *AdminApprovalInteractor) RejectAction(ctx context.Context, req *inputport.ReviewPendingActionRequest) (*inputport.PendingActionDetail, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	action, err := i.approvalRepo.Read(ctx, req.ActionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if action.IsExpired(now) {
+		if err := i.expire(ctx, action, now); err != nil {
+			return nil, err
+		}
+		return nil, entities.ErrPendingActionExpired
+	}
+	if err := action.Reject(req.AdminID, req.Comment, now); err != nil {
+		return nil, err
+	}
+	if err := i.transition(ctx, action, entities.PendingAdminActionStatusPending, entities.PendingAdminActionEventRejected, &req.AdminID, action.ReviewComment); err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Admin action rejected",
+		entities.NewField("action_id", action.ID),
+		entities.NewField("rejected_by", req.AdminID),
+		entities.NewField("requested_by", action.RequestedBy))
+	return i.detail(ctx, action)
+}
+
+// ExpireStaleActions は期限を過ぎた承認待ちの操作を期限切れにする
+func (i *AdminApprovalInteractor) ExpireStaleActions(ctx context.Context, now time.Time) (int, error) {
+	actions, err := i.approvalRepo.ReadExpired(ctx, now, expireBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read expired pending actions: %w", err)
+	}
+
+	expired := 0
+	for _, action := range actions {
+		if err := i.expire(ctx, action, now); err != nil {
+			// 他の管理者が先に承認・却下していた場合もここに来る
+			i.logger.Warn("Failed to expire pending action",
+				entities.NewField("action_id", action.ID),
+				entities.NewField("error", err))
+			continue
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// expire は承認待ちの操作を期限切れにする
+func (i *AdminApprovalInteractor) expire(ctx context.Context, action *entities.PendingAdminAction, now time.Time) error {
+	action.Expire(now)
+	if err := i.transition(ctx, action, entities.PendingAdminActionStatusPending, entities.PendingAdminActionEventExpired, nil, ""); err != nil {
+		return err
+	}
+	i.logger.Info("Pending admin action expired",
+		entities.NewField("action_id", action.ID),
+		entities.NewField("requested_by", action.RequestedBy))
+	return nil
+}
+
+// transition は操作の状態がfromのときだけ更新し、同じトランザクションで監査記録を残す
+// 他の管理者が先に処理していた場合はErrPendingActionNotPendingを返す
+func (i *AdminApprovalInteractor) transition(ctx context.Context, action *entities.PendingAdminAction, from entities.PendingAdminActionStatus, event entities.PendingAdminActionEventType, actorID *uuid.UUID, comment string) error {
+	return i.txManager.Do(ctx, func(ctx context.Context) error {
+		updated, err := i.approvalRepo.UpdateStatus(ctx, action, from)
+		if err != nil {
+			return fmt.Errorf("failed to update pending action: %w", err)
+		}
+		if !updated {
+			return entities.ErrPendingActionNotPending
+		}
+		if err := i.approvalRepo.CreateEvent(ctx, entities.NewPendingAdminActionEvent(action.ID, event, actorID, comment, action.UpdatedAt)); err != nil {
+			return fmt.Errorf("failed to create pending action event: %w", err)
+		}
+		return nil
+	})
+}
+
+func (i *AdminApprovalInteractor) detail(ctx context.Context, action *entities.PendingAdminAction) (*inputport.PendingActionDetail, error) {
+	events, err := i.approvalRepo.ReadEvents(ctx, action.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pending action events: %w", err)
+	}
+	return &inputport.PendingActionDetail{Action: action, Events: events}, nil
+}
@@ -0,0 +1,93 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	// adminDashboardCacheTTL はダッシュボードのキャッシュ期間
+	// 複数の管理者が数秒ごとにポーリングしても、集計はこの間隔に1回で済む
+	adminDashboardCacheTTL = 5 * time.Second
+	// adminDashboardPingTimeout はDBの疎通確認の待ち時間の上限
+	adminDashboardPingTimeout = 2 * time.Second
+)
+
+// AdminDashboardInteractor は管理者ダッシュボードのユースケース実装
+type AdminDashboardInteractor struct {
+	dashboardRepo repository.AdminDashboardRepository
+	userRepo      repository.UserRepository
+	logger        entities.Logger
+
+	// mu は集計中も保持し、キャッシュが切れた直後の同時リクエストで集計が重ならないようにする
+	mu    sync.Mutex
+	cache map[uuid.UUID]*entities.AdminDashboard // テナントごと（テナントのないcontextはuuid.Nil）
+}
+
+// NewAdminDashboardInteractor は新しいAdminDashboardInteractorを作成
+func NewAdminDashboardInteractor(
+	dashboardRepo repository.AdminDashboardRepository,
+	userRepo repository.UserRepository,
+	logger entities.Logger,
+) inputport.AdminDashboardInputPort {
+	return &AdminDashboardInteractor{
+		dashboardRepo: dashboardRepo,
+		userRepo:      userRepo,
+		logger:        logger,
+		cache:         make(map[uuid.UUID]*entities.AdminDashboard),
+	}
+}
+
+// GetDashboard はリアルタイムのカウンターとDBの状態を取得
+// DBに疎通できない場合もエラーにはせず、DBの状態だけを返す
+func (i *AdminDashboardInteractor) GetDashboard(ctx context.Context, adminID uuid.UUID) (*entities.AdminDashboard, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+
+	tenantID, _ := entities.TenantIDFromContext(ctx)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if cached, ok := i.cache[tenantID]; ok && time.Since(cached.GeneratedAt) < adminDashboardCacheTTL {
+		return cached, nil
+	}
+
+	now := time.Now()
+	dashboard := &entities.AdminDashboard{
+		Database:    i.checkDatabase(ctx),
+		GeneratedAt: now,
+	}
+	if dashboard.Database.Healthy {
+		counters, err := i.dashboardRepo.GetCounters(ctx, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dashboard counters: %w", err)
+		}
+		dashboard.Counters = counters
+	}
+
+	i.cache[tenantID] = dashboard
+	return dashboard, nil
+}
+
+func (i *AdminDashboardInteractor) checkDatabase(ctx context.Context) entities.DatabaseHealth {
+	ctx, cancel := context.WithTimeout(ctx, adminDashboardPingTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := i.dashboardRepo.Ping(ctx)
+	health := entities.DatabaseHealth{Healthy: err == nil, Latency: time.Since(start)}
+	if err != nil {
+		health.Error = err.Error()
+		i.logger.Warn("Dashboard database ping failed", entities.NewField("error", err.Error()))
+	}
+	return health
+}
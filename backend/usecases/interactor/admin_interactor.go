@@ -12,6 +12,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// errDryRunRollback はドライランのトランザクションを必ずロールバックさせるためのエラー
+var errDryRunRollback = errors.New("dry run: rolled back")
+
 // AdminInteractor は管理者機能のユースケース実装
 type AdminInteractor struct {
 	txManager       repository.TransactionManager
@@ -19,7 +22,13 @@ type AdminInteractor struct {
 	transactionRepo repository.TransactionRepository
 	idempotencyRepo repository.IdempotencyKeyRepository
 	pointBatchRepo  repository.PointBatchRepository
+	reasonCodeRepo  repository.ReasonCodeRepository
+	departmentRepo  repository.DepartmentRepository
+	budgetRepo      repository.BudgetRepository
+	approvalRepo    repository.PendingAdminActionRepository
+	settingsRepo    repository.SystemSettingsRepository
 	analyticsDS     repository.AnalyticsRepository
+	notifications   inputport.NotificationDispatcher
 	logger          entities.Logger
 }
 
@@ -30,7 +39,13 @@ func NewAdminInteractor(
 	transactionRepo repository.TransactionRepository,
 	idempotencyRepo repository.IdempotencyKeyRepository,
 	pointBatchRepo repository.PointBatchRepository,
+	reasonCodeRepo repository.ReasonCodeRepository,
+	departmentRepo repository.DepartmentRepository,
+	budgetRepo repository.BudgetRepository,
+	approvalRepo repository.PendingAdminActionRepository,
+	settingsRepo repository.SystemSettingsRepository,
 	analyticsDS repository.AnalyticsRepository,
+	notifications inputport.NotificationDispatcher,
 	logger entities.Logger,
 ) inputport.AdminInputPort {
 	return &AdminInteractor{
@@ -39,7 +54,13 @@ func NewAdminInteractor(
 		transactionRepo: transactionRepo,
 		idempotencyRepo: idempotencyRepo,
 		pointBatchRepo:  pointBatchRepo,
+		reasonCodeRepo:  reasonCodeRepo,
+		departmentRepo:  departmentRepo,
+		budgetRepo:      budgetRepo,
+		approvalRepo:    approvalRepo,
+		settingsRepo:    settingsRepo,
 		analyticsDS:     analyticsDS,
+		notifications:   notifications,
 		logger:          logger,
 	}
 }
@@ -49,11 +70,13 @@ func (i *AdminInteractor) GrantPoints(ctx context.Context, req *inputport.GrantP
 	i.logger.Info("Admin granting points",
 		entities.NewField("admin_id", req.AdminID),
 		entities.NewField("user_id", req.UserID),
-		entities.NewField("amount", req.Amount))
+		entities.NewField("amount", req.Amount),
+		entities.NewField("dry_run", req.DryRun),
+		entities.NewField("budget_override", req.BudgetOverride))
 
 	// 金額検証
 	if req.Amount <= 0 {
-		return nil, errors.New("amount must be positive")
+		return nil, entities.ErrInvalidAmount
 	}
 
 	// 管理者権限チェック
@@ -62,7 +85,17 @@ func (i *AdminInteractor) GrantPoints(ctx context.Context, req *inputport.GrantP
 		return nil, errors.New("admin not found")
 	}
 	if admin.Role != "admin" {
-		return nil, errors.New("unauthorized: admin role required")
+		return nil, entities.ErrAdminRequired
+	}
+
+	// 理由コード・タグ検証
+	reasonCode, err := i.resolveReasonCode(ctx, req.ReasonCode)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := entities.NormalizeTransactionTag(req.Tag)
+	if err != nil {
+		return nil, err
 	}
 
 	// 冪等性チェック
@@ -74,11 +107,29 @@ func (i *AdminInteractor) GrantPoints(ctx context.Context, req *inputport.GrantP
 		return &inputport.GrantPointsResponse{
 			Transaction: existingTx,
 			User:        user,
+			DryRun:      req.DryRun,
 		}, nil
 	}
 
+	// 閾値を超える付与は二人目の管理者の承認を待つ（ドライランは承認が必要かだけを返す）
+	requiresApproval, err := i.requiresApproval(ctx, req.Amount, req.ApprovedBy)
+	if err != nil {
+		return nil, err
+	}
+	if requiresApproval && !req.DryRun {
+		action, user, err := i.requestApproval(ctx, entities.NewPendingAdminAction(entities.PendingAdminActionGrant,
+			req.AdminID, req.UserID, req.Amount, req.Description, reasonCode, tag, req.IdempotencyKey, req.BudgetOverride, time.Now()))
+		if err != nil {
+			return nil, err
+		}
+		return &inputport.GrantPointsResponse{User: user, PendingAction: action}, nil
+	}
+
 	var user *entities.User
 	var transaction *entities.Transaction
+	var batch *entities.PointBatch
+	var budgets []*entities.BudgetStatus
+	var alerts []*budgetAlert
 
 	// トランザクション実行
 	err = i.txManager.Do(ctx, func(ctx context.Context) error {
@@ -87,11 +138,22 @@ func (i *AdminInteractor) GrantPoints(ctx context.Context, req *inputport.GrantP
 		var err error
 		user, err = i.userRepo.Read(ctx, req.UserID)
 		if err != nil {
-			return errors.New("user not found")
+			return entities.ErrUserNotFound
 		}
 
 		if !user.IsActive {
-			return errors.New("user is not active")
+			return entities.ErrUserInactive
+		}
+
+		// 所属部署に月間予算があれば超えないかを確認（ドライランでも確認する）
+		if err := i.checkDepartmentBudget(ctx, user, req.Amount); err != nil {
+			return err
+		}
+
+		// 対象の予算をロックして消化する（ドライランでも確認し、ロールバックで戻す）
+		budgets, alerts, err = i.consumeBudgets(ctx, req.AdminID, user, req.Amount, req.BudgetOverride)
+		if err != nil {
+			return err
 		}
 
 		// ポイント付与（残高更新はロック付きで実行）
@@ -112,17 +174,25 @@ func (i *AdminInteractor) GrantPoints(ctx context.Context, req *inputport.GrantP
 		if err != nil {
 			return err
 		}
+		if err := transaction.SetMemo(reasonCode, tag); err != nil {
+			return err
+		}
 
 		if err := i.transactionRepo.Create(ctx, transaction); err != nil {
 			return err
 		}
 
 		// ポイントバッチ作成
-		batch := entities.NewPointBatch(req.UserID, req.Amount, entities.PointBatchSourceAdminGrant, &transaction.ID, time.Now())
+		batch = entities.NewPointBatch(req.UserID, req.Amount, entities.PointBatchSourceAdminGrant, &transaction.ID, time.Now())
 		if err := i.pointBatchRepo.Create(ctx, batch); err != nil {
 			return fmt.Errorf("failed to create point batch: %w", err)
 		}
 
+		// ドライランはここまでの処理をすべてロールバックする（冪等性キーも保存しない）
+		if req.DryRun {
+			return errDryRunRollback
+		}
+
 		// 冪等性キー保存
 		idempotencyKey := entities.NewIdempotencyKey(req.IdempotencyKey, req.AdminID)
 		idempotencyKey.TransactionID = &transaction.ID
@@ -134,17 +204,29 @@ func (i *AdminInteractor) GrantPoints(ctx context.Context, req *inputport.GrantP
 		return nil
 	})
 
-	if err != nil {
+	if err != nil && !errors.Is(err, errDryRunRollback) {
 		return nil, err
 	}
 
-	i.logger.Info("Points granted successfully",
-		entities.NewField("user_id", req.UserID),
-		entities.NewField("amount", req.Amount))
+	if req.DryRun {
+		i.logger.Info("Points grant dry run completed",
+			entities.NewField("user_id", req.UserID),
+			entities.NewField("amount", req.Amount))
+	} else {
+		i.logger.Info("Points granted successfully",
+			entities.NewField("user_id", req.UserID),
+			entities.NewField("amount", req.Amount),
+			entities.NewField("approved_by", req.ApprovedBy))
+		i.notifyBudgetAlerts(ctx, alerts)
+	}
 
 	return &inputport.GrantPointsResponse{
-		Transaction: transaction,
-		User:        user,
+		Transaction:      transaction,
+		User:             user,
+		Batch:            batch,
+		Budgets:          budgets,
+		DryRun:           req.DryRun,
+		RequiresApproval: requiresApproval,
 	}, nil
 }
 
@@ -153,11 +235,12 @@ func (i *AdminInteractor) DeductPoints(ctx context.Context, req *inputport.Deduc
 	i.logger.Info("Admin deducting points",
 		entities.NewField("admin_id", req.AdminID),
 		entities.NewField("user_id", req.UserID),
-		entities.NewField("amount", req.Amount))
+		entities.NewField("amount", req.Amount),
+		entities.NewField("dry_run", req.DryRun))
 
 	// 金額検証
 	if req.Amount <= 0 {
-		return nil, errors.New("amount must be positive")
+		return nil, entities.ErrInvalidAmount
 	}
 
 	// 管理者権限チェック
@@ -166,7 +249,17 @@ func (i *AdminInteractor) DeductPoints(ctx context.Context, req *inputport.Deduc
 		return nil, errors.New("admin not found")
 	}
 	if admin.Role != "admin" {
-		return nil, errors.New("unauthorized: admin role required")
+		return nil, entities.ErrAdminRequired
+	}
+
+	// 理由コード・タグ検証
+	reasonCode, err := i.resolveReasonCode(ctx, req.ReasonCode)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := entities.NormalizeTransactionTag(req.Tag)
+	if err != nil {
+		return nil, err
 	}
 
 	// 冪等性チェック
@@ -178,11 +271,28 @@ func (i *AdminInteractor) DeductPoints(ctx context.Context, req *inputport.Deduc
 		return &inputport.DeductPointsResponse{
 			Transaction: existingTx,
 			User:        user,
+			DryRun:      req.DryRun,
 		}, nil
 	}
 
+	// 閾値を超える減算は二人目の管理者の承認を待つ（ドライランは承認が必要かだけを返す）
+	requiresApproval, err := i.requiresApproval(ctx, req.Amount, req.ApprovedBy)
+	if err != nil {
+		return nil, err
+	}
+	if requiresApproval && !req.DryRun {
+		action, user, err := i.requestApproval(ctx, entities.NewPendingAdminAction(entities.PendingAdminActionDeduct,
+			req.AdminID, req.UserID, req.Amount, req.Description, reasonCode, tag, req.IdempotencyKey, false, time.Now()))
+		if err != nil {
+			return nil, err
+		}
+		return &inputport.DeductPointsResponse{User: user, PendingAction: action}, nil
+	}
+
 	var user *entities.User
 	var transaction *entities.Transaction
+	var plan []*entities.BatchConsumption
+	var uncovered int64
 
 	// トランザクション実行
 	err = i.txManager.Do(ctx, func(ctx context.Context) error {
@@ -190,16 +300,16 @@ func (i *AdminInteractor) DeductPoints(ctx context.Context, req *inputport.Deduc
 		var err error
 		user, err = i.userRepo.Read(ctx, req.UserID)
 		if err != nil {
-			return errors.New("user not found")
+			return entities.ErrUserNotFound
 		}
 
 		if !user.IsActive {
-			return errors.New("user is not active")
+			return entities.ErrUserInactive
 		}
 
-		// 残高チェック
-		if user.Balance < req.Amount {
-			return errors.New("insufficient balance")
+		// 残高チェック（確保中の送金リクエストの分は減算できない）
+		if available := user.AvailableBalance(); available < req.Amount {
+			return entities.ErrInsufficientBalance.WithParams(map[string]interface{}{"balance": available, "required": req.Amount})
 		}
 
 		// ポイント減算（残高更新はロック付きで実行）
@@ -207,6 +317,15 @@ func (i *AdminInteractor) DeductPoints(ctx context.Context, req *inputport.Deduc
 			return err
 		}
 
+		// ドライランでは消費前のバッチから、どのバッチがいくつ減るかを求めておく
+		if req.DryRun {
+			batches, err := i.pointBatchRepo.FindActiveByUser(ctx, req.UserID)
+			if err != nil {
+				return fmt.Errorf("failed to find active batches: %w", err)
+			}
+			plan, uncovered = entities.PlanPointConsumption(batches, req.Amount, time.Now())
+		}
+
 		// ポイントバッチからも消費（FIFO順で remaining_amount を減算）
 		if err := i.pointBatchRepo.ConsumePointsFIFO(ctx, req.UserID, req.Amount); err != nil {
 			return fmt.Errorf("failed to consume point batches: %w", err)
@@ -225,11 +344,19 @@ func (i *AdminInteractor) DeductPoints(ctx context.Context, req *inputport.Deduc
 		if err != nil {
 			return err
 		}
+		if err := transaction.SetMemo(reasonCode, tag); err != nil {
+			return err
+		}
 
 		if err := i.transactionRepo.Create(ctx, transaction); err != nil {
 			return err
 		}
 
+		// ドライランはここまでの処理をすべてロールバックする（冪等性キーも保存しない）
+		if req.DryRun {
+			return errDryRunRollback
+		}
+
 		// 冪等性キー保存
 		idempotencyKey := entities.NewIdempotencyKey(req.IdempotencyKey, req.AdminID)
 		idempotencyKey.TransactionID = &transaction.ID
@@ -241,13 +368,28 @@ func (i *AdminInteractor) DeductPoints(ctx context.Context, req *inputport.Deduc
 		return nil
 	})
 
-	if err != nil {
+	if err != nil && !errors.Is(err, errDryRunRollback) {
 		return nil, err
 	}
 
+	if req.DryRun {
+		i.logger.Info("Points deduct dry run completed",
+			entities.NewField("user_id", req.UserID),
+			entities.NewField("amount", req.Amount))
+		return &inputport.DeductPointsResponse{
+			Transaction:      transaction,
+			User:             user,
+			DryRun:           true,
+			ConsumptionPlan:  plan,
+			UncoveredAmount:  uncovered,
+			RequiresApproval: requiresApproval,
+		}, nil
+	}
+
 	i.logger.Info("Points deducted successfully",
 		entities.NewField("user_id", req.UserID),
-		entities.NewField("amount", req.Amount))
+		entities.NewField("amount", req.Amount),
+		entities.NewField("approved_by", req.ApprovedBy))
 
 	return &inputport.DeductPointsResponse{
 		Transaction: transaction,
@@ -255,18 +397,215 @@ func (i *AdminInteractor) DeductPoints(ctx context.Context, req *inputport.Deduc
 	}, nil
 }
 
+// requiresApproval は付与・減算に二人目の管理者の承認が必要かを判定（承認済みの実行なら不要）
+func (i *AdminInteractor) requiresApproval(ctx context.Context, amount int64, approvedBy *uuid.UUID) (bool, error) {
+	if approvedBy != nil {
+		return false, nil
+	}
+	value, err := i.settingsRepo.GetSetting(ctx, entities.AdminApprovalThresholdSettingKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to get approval threshold: %w", err)
+	}
+	return entities.RequiresAdminApproval(entities.ParseAdminApprovalThreshold(value), amount), nil
+}
+
+// requestApproval は付与・減算を承認待ちとして登録し、申請の監査記録を残す
+// 同じ冪等性キーで申請済みなら、登録済みの操作をそのまま返す
+func (i *AdminInteractor) requestApproval(ctx context.Context, action *entities.PendingAdminAction) (*entities.PendingAdminAction, *entities.User, error) {
+	user, err := i.userRepo.Read(ctx, action.TargetUserID)
+	if err != nil {
+		return nil, nil, entities.ErrUserNotFound
+	}
+	if !user.IsActive {
+		return nil, nil, entities.ErrUserInactive
+	}
+
+	existing, err := i.approvalRepo.ReadByIdempotencyKey(ctx, action.IdempotencyKey)
+	if err == nil {
+		return existing, user, nil
+	}
+	if !errors.Is(err, entities.ErrPendingActionNotFound) {
+		return nil, nil, fmt.Errorf("failed to read pending action: %w", err)
+	}
+
+	err = i.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := i.approvalRepo.Create(ctx, action); err != nil {
+			return fmt.Errorf("failed to create pending action: %w", err)
+		}
+		event := entities.NewPendingAdminActionEvent(action.ID, entities.PendingAdminActionEventRequested, &action.RequestedBy, action.Description, action.CreatedAt)
+		if err := i.approvalRepo.CreateEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to create pending action event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	i.logger.Info("Admin action requires approval",
+		entities.NewField("action_id", action.ID),
+		entities.NewField("action_type", string(action.ActionType)),
+		entities.NewField("requested_by", action.RequestedBy),
+		entities.NewField("user_id", action.TargetUserID),
+		entities.NewField("amount", action.Amount))
+	return action, user, nil
+}
+
+// resolveReasonCode は付与・減算に指定された理由コードが登録済みで有効かを確認し、正規化したコードを返す
+func (i *AdminInteractor) resolveReasonCode(ctx context.Context, code string) (string, error) {
+	code = entities.NormalizeReasonCode(code)
+	if code == "" {
+		return "", entities.ErrReasonCodeRequired
+	}
+	reasonCode, err := i.reasonCodeRepo.ReadByCode(ctx, code)
+	if err != nil {
+		return "", err
+	}
+	if !reasonCode.IsActive {
+		return "", entities.ErrReasonCodeInactive
+	}
+	return reasonCode.Code, nil
+}
+
+// checkDepartmentBudget は付与先ユーザーの所属部署の月間予算を確認する
+// 部署の行をロックして、同じ部署への同時付与で予算を超えないようにする
+func (i *AdminInteractor) checkDepartmentBudget(ctx context.Context, user *entities.User, amount int64) error {
+	if user.DepartmentID == nil {
+		return nil
+	}
+	department, err := i.departmentRepo.ReadForUpdate(ctx, *user.DepartmentID)
+	if err != nil {
+		return err
+	}
+	if department.MonthlyGrantBudget == nil {
+		return nil
+	}
+	used, err := i.departmentRepo.SumAdminGrantsSince(ctx, department.ID, entities.DepartmentBudgetPeriodStart(time.Now()))
+	if err != nil {
+		return err
+	}
+	return department.CheckGrantBudget(used, amount)
+}
+
+// budgetAlert は付与で消化率が通知の閾値を超えた予算（コミット後に管理者へ通知する）
+type budgetAlert struct {
+	budget    *entities.Budget
+	usage     *entities.BudgetUsage
+	threshold int
+}
+
+// consumeBudgets は付与が対象になる有効な予算（全体・付与する管理者・付与先の所属部署とその上位の部署）を
+// 行ロック付きで取得して消化し、付与後の消化状況と通知が必要な予算を返す
+func (i *AdminInteractor) consumeBudgets(ctx context.Context, adminID uuid.UUID, user *entities.User, amount int64, allowOverride bool) ([]*entities.BudgetStatus, []*budgetAlert, error) {
+	var departmentIDs []uuid.UUID
+	if user.DepartmentID != nil {
+		departments, err := i.departmentRepo.ReadList(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		departmentIDs = entities.DepartmentAncestorIDs(departments, *user.DepartmentID)
+	}
+
+	budgets, err := i.budgetRepo.ReadApplicableForUpdate(ctx, adminID, departmentIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read budgets: %w", err)
+	}
+
+	now := time.Now()
+	statuses := make([]*entities.BudgetStatus, 0, len(budgets))
+	var alerts []*budgetAlert
+	for _, b := range budgets {
+		usage, err := i.budgetRepo.ReadUsage(ctx, b.ID, b.PeriodStart(now))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read budget usage: %w", err)
+		}
+		overrides := usage.Consumed+amount > b.Amount
+
+		threshold, err := b.Consume(usage, amount, allowOverride)
+		if err != nil {
+			return nil, nil, err
+		}
+		if overrides {
+			i.logger.Warn("Budget overridden by admin grant",
+				entities.NewField("budget_id", b.ID),
+				entities.NewField("admin_id", adminID),
+				entities.NewField("user_id", user.ID),
+				entities.NewField("amount", amount),
+				entities.NewField("budget", b.Amount),
+				entities.NewField("consumed", usage.Consumed))
+		}
+
+		if err := i.budgetRepo.SaveUsage(ctx, usage); err != nil {
+			return nil, nil, fmt.Errorf("failed to save budget usage: %w", err)
+		}
+		statuses = append(statuses, entities.NewBudgetStatus(b, usage))
+		if threshold > 0 {
+			alerts = append(alerts, &budgetAlert{budget: b, usage: usage, threshold: threshold})
+		}
+	}
+	return statuses, alerts, nil
+}
+
+// notifyBudgetAlerts は消化率が閾値を超えた予算を有効な管理者全員へ通知する（ベストエフォート）
+func (i *AdminInteractor) notifyBudgetAlerts(ctx context.Context, alerts []*budgetAlert) {
+	if len(alerts) == 0 {
+		return
+	}
+	recipients, err := i.budgetRepo.ReadAlertRecipients(ctx)
+	if err != nil {
+		i.logger.Warn("Failed to read budget alert recipients", entities.NewField("error", err))
+		return
+	}
+	for _, a := range alerts {
+		i.logger.Info("Budget alert threshold reached",
+			entities.NewField("budget_id", a.budget.ID),
+			entities.NewField("threshold", a.threshold),
+			entities.NewField("consumed", a.usage.Consumed))
+		for _, adminID := range recipients {
+			i.notifications.Dispatch(ctx, entities.NewBudgetAlertNotification(adminID, a.budget, a.usage, a.threshold))
+		}
+	}
+}
+
+// resolveDepartmentFilter は絞り込みに指定された部署とその配下の部署IDを返す（指定なしならnil）
+func (i *AdminInteractor) resolveDepartmentFilter(ctx context.Context, departmentID *uuid.UUID) ([]uuid.UUID, error) {
+	if departmentID == nil {
+		return nil, nil
+	}
+	departments, err := i.departmentRepo.ReadList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, d := range departments {
+		if d.ID == *departmentID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, entities.ErrDepartmentNotFound
+	}
+	return entities.DepartmentSubtreeIDs(departments, *departmentID), nil
+}
+
 // ListAllUsers はすべてのユーザー一覧を取得
 func (i *AdminInteractor) ListAllUsers(ctx context.Context, req *inputport.ListAllUsersRequest) (*inputport.ListAllUsersResponse, error) {
 	var users []*entities.User
 	var total int64
 	var err error
 
-	if req.Search != "" || req.SortBy != "" {
-		users, err = i.userRepo.ReadListWithSearch(ctx, req.Search, req.SortBy, req.SortOrder, req.Offset, req.Limit)
+	departmentIDs, err := i.resolveDepartmentFilter(ctx, req.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Search != "" || req.SortBy != "" || len(departmentIDs) > 0 {
+		users, err = i.userRepo.ReadListWithSearch(ctx, req.Search, departmentIDs, req.SortBy, req.SortOrder, req.Offset, req.Limit)
 		if err != nil {
 			return nil, err
 		}
-		total, err = i.userRepo.CountWithSearch(ctx, req.Search)
+		total, err = i.userRepo.CountWithSearch(ctx, req.Search, departmentIDs)
 		if err != nil {
 			total = int64(len(users))
 		}
@@ -292,15 +631,21 @@ func (i *AdminInteractor) ListAllTransactions(ctx context.Context, req *inputpor
 	var total int64
 	var err error
 
+	departmentIDs, err := i.resolveDepartmentFilter(ctx, req.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+
 	// JOINでユーザー情報付きトランザクション一覧を取得
-	results, err := i.transactionRepo.ReadListAllWithFilterAndUsers(ctx, req.TransactionType, req.DateFrom, req.DateTo, req.SortBy, req.SortOrder, req.Offset, req.Limit)
+	reasonCode := entities.NormalizeReasonCode(req.ReasonCode)
+	results, err := i.transactionRepo.ReadListAllWithFilterAndUsers(ctx, req.TransactionType, req.DateFrom, req.DateTo, reasonCode, departmentIDs, req.SortBy, req.SortOrder, req.Offset, req.Limit)
 	if err != nil {
 		return nil, err
 	}
 
-	hasFilter := req.TransactionType != "" || req.DateFrom != "" || req.DateTo != ""
+	hasFilter := req.TransactionType != "" || req.DateFrom != "" || req.DateTo != "" || reasonCode != "" || len(departmentIDs) > 0
 	if hasFilter {
-		total, err = i.transactionRepo.CountAllWithFilter(ctx, req.TransactionType, req.DateFrom, req.DateTo)
+		total, err = i.transactionRepo.CountAllWithFilter(ctx, req.TransactionType, req.DateFrom, req.DateTo, reasonCode, departmentIDs)
 		if err != nil {
 			total = int64(len(results))
 		}
@@ -340,7 +685,7 @@ func (i *AdminInteractor) UpdateUserRole(ctx context.Context, req *inputport.Upd
 		return nil, errors.New("admin not found")
 	}
 	if admin.Role != "admin" {
-		return nil, errors.New("unauthorized: admin role required")
+		return nil, entities.ErrAdminRequired
 	}
 
 	// 役割検証
@@ -353,7 +698,7 @@ func (i *AdminInteractor) UpdateUserRole(ctx context.Context, req *inputport.Upd
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		user, err := i.userRepo.Read(ctx, req.UserID)
 		if err != nil {
-			return nil, errors.New("user not found")
+			return nil, entities.ErrUserNotFound
 		}
 
 		if err := user.UpdateRole(entities.UserRole(req.Role)); err != nil {
@@ -375,7 +720,7 @@ func (i *AdminInteractor) UpdateUserRole(ctx context.Context, req *inputport.Upd
 			entities.NewField("attempt", attempt+1))
 	}
 
-	return nil, errors.New("update conflict: please retry later")
+	return nil, entities.ErrUpdateConflict
 }
 
 // DeactivateUser はユーザーを無効化
@@ -390,7 +735,7 @@ func (i *AdminInteractor) DeactivateUser(ctx context.Context, req *inputport.Dea
 		return nil, errors.New("admin not found")
 	}
 	if admin.Role != "admin" {
-		return nil, errors.New("unauthorized: admin role required")
+		return nil, entities.ErrAdminRequired
 	}
 
 	// 自分自身を無効化しようとしていないかチェック
@@ -403,7 +748,7 @@ func (i *AdminInteractor) DeactivateUser(ctx context.Context, req *inputport.Dea
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		user, err := i.userRepo.Read(ctx, req.UserID)
 		if err != nil {
-			return nil, errors.New("user not found")
+			return nil, entities.ErrUserNotFound
 		}
 
 		user.Deactivate()
@@ -421,7 +766,7 @@ func (i *AdminInteractor) DeactivateUser(ctx context.Context, req *inputport.Dea
 			entities.NewField("attempt", attempt+1))
 	}
 
-	return nil, errors.New("update conflict: please retry later")
+	return nil, entities.ErrUpdateConflict
 }
 
 // GetAnalytics は分析データを取得
@@ -465,6 +810,22 @@ func (i *AdminInteractor) GetAnalytics(ctx context.Context, req *inputport.GetAn
 		return nil, fmt.Errorf("failed to get transaction type breakdown: %w", err)
 	}
 
+	reasonBreakdownResult, err := i.analyticsDS.GetReasonCodeBreakdown(ctx, since, entities.NormalizeReasonCode(req.ReasonCode))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reason code breakdown: %w", err)
+	}
+
+	conservation, err := i.analyticsDS.GetPointConservation(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get point conservation: %w", err)
+	}
+	if !conservation.Conserved() {
+		i.logger.Warn("Points are not conserved between users and system accounts",
+			entities.NewField("user_balance_total", conservation.UserBalanceTotal),
+			entities.NewField("system_balance_total", conservation.SystemBalanceTotal()),
+			entities.NewField("difference", conservation.Difference()))
+	}
+
 	// レスポンス組み立て
 	analyticsSummary := &inputport.AnalyticsSummary{
 		TotalPointsInCirculation: summary.TotalBalance,
@@ -509,10 +870,235 @@ func (i *AdminInteractor) GetAnalytics(ctx context.Context, req *inputport.GetAn
 		})
 	}
 
+	reasonBreakdown := make([]*inputport.ReasonCodeBreakdown, 0, len(reasonBreakdownResult))
+	for _, r := range reasonBreakdownResult {
+		reasonBreakdown = append(reasonBreakdown, &inputport.ReasonCodeBreakdown{
+			ReasonCode:      r.ReasonCode,
+			Label:           r.Label,
+			TransactionType: r.TransactionType,
+			Count:           r.Count,
+			TotalAmount:     r.TotalAmount,
+		})
+	}
+
 	return &inputport.GetAnalyticsResponse{
 		Summary:                  analyticsSummary,
 		TopHolders:               topHolders,
 		DailyStats:               dailyStats,
 		TransactionTypeBreakdown: typeBreakdown,
+		ReasonCodeBreakdown:      reasonBreakdown,
+		Conservation:             conservation,
+	}, nil
+}
+
+// cohortAnalyticsDefaultPeriods は期間未指定時に遡る期間数
+const cohortAnalyticsDefaultPeriods = 12
+
+// cohortAnalyticsMaxYears は指定できる集計期間の上限（年）
+const cohortAnalyticsMaxYears = 2
+
+// GetCohortAnalytics はアクティブユーザー推移・コホート継続率・機能別利用状況を取得
+func (i *AdminInteractor) GetCohortAnalytics(ctx context.Context, req *inputport.GetCohortAnalyticsRequest) (*inputport.GetCohortAnalyticsResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	granularity := req.Granularity
+	if !granularity.IsValid() {
+		granularity = entities.AnalyticsGranularityWeek
+	}
+	basis := req.Basis
+	if !basis.IsValid() {
+		basis = entities.ActivityBasisTransactions
+	}
+
+	// 期間の補完とバリデーション
+	to := req.To
+	if to.IsZero() {
+		now := time.Now()
+		to = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	}
+	from := req.From
+	if from.IsZero() {
+		from = granularity.Truncate(to.Add(-time.Nanosecond))
+		for n := 1; n < cohortAnalyticsDefaultPeriods; n++ {
+			from = granularity.Truncate(from.Add(-time.Nanosecond))
+		}
+	}
+	if !from.Before(to) || to.After(from.AddDate(cohortAnalyticsMaxYears, 0, 0)) {
+		return nil, entities.ErrInvalidDateRange
+	}
+
+	i.logger.Info("Getting cohort analytics",
+		entities.NewField("from", from),
+		entities.NewField("to", to),
+		entities.NewField("granularity", granularity),
+		entities.NewField("basis", basis),
+	)
+
+	activeResult, err := i.analyticsDS.GetActiveUserCounts(ctx, granularity, basis, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active user counts: %w", err)
+	}
+
+	sizesResult, err := i.analyticsDS.GetCohortSizes(ctx, granularity, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cohort sizes: %w", err)
+	}
+
+	cohortActivityResult, err := i.analyticsDS.GetCohortActivity(ctx, granularity, basis, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cohort activity: %w", err)
+	}
+
+	featureResult, err := i.analyticsDS.GetFeatureUsage(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature usage: %w", err)
+	}
+
+	activeUsers := make([]*inputport.ActiveUserCount, 0, len(activeResult))
+	for _, a := range activeResult {
+		activeUsers = append(activeUsers, &inputport.ActiveUserCount{
+			PeriodStart: a.PeriodStart,
+			ActiveUsers: a.ActiveUsers,
+		})
+	}
+
+	// コホート×経過期間ごとのアクティブ数（期間はfromのタイムゾーンで揃える）
+	loc := from.Location()
+	activityMap := make(map[string]map[int]int64)
+	for _, a := range cohortActivityResult {
+		key := a.CohortStart.In(loc).Format("2006-01-02")
+		if activityMap[key] == nil {
+			activityMap[key] = make(map[int]int64)
+		}
+		activityMap[key][granularity.PeriodsBetween(a.CohortStart.In(loc), a.PeriodStart.In(loc))] = a.ActiveUsers
+	}
+
+	lastPeriod := to.Add(-time.Nanosecond)
+	cohorts := make([]*inputport.CohortRetention, 0, len(sizesResult))
+	for _, c := range sizesResult {
+		cohortStart := c.CohortStart.In(loc)
+		periods := granularity.PeriodsBetween(cohortStart, lastPeriod)
+		retention := make([]*inputport.RetentionPoint, 0, periods+1)
+		for p := 0; p <= periods; p++ {
+			active := activityMap[cohortStart.Format("2006-01-02")][p]
+			pct := float64(0)
+			if c.Users > 0 {
+				pct = float64(active) / float64(c.Users) * 100
+			}
+			retention = append(retention, &inputport.RetentionPoint{
+				Period:      p,
+				ActiveUsers: active,
+				Percentage:  pct,
+			})
+		}
+		cohorts = append(cohorts, &inputport.CohortRetention{
+			CohortStart: cohortStart,
+			Size:        c.Users,
+			Retention:   retention,
+		})
+	}
+
+	var totalUsage int64
+	for _, f := range featureResult {
+		totalUsage += f.Count
+	}
+	featureUsage := make([]*inputport.FeatureUsage, 0, len(featureResult))
+	for _, f := range featureResult {
+		pct := float64(0)
+		if totalUsage > 0 {
+			pct = float64(f.Count) / float64(totalUsage) * 100
+		}
+		featureUsage = append(featureUsage, &inputport.FeatureUsage{
+			Feature:     f.Feature,
+			Count:       f.Count,
+			Users:       f.Users,
+			TotalAmount: f.TotalAmount,
+			Percentage:  pct,
+		})
+	}
+
+	return &inputport.GetCohortAnalyticsResponse{
+		From:         from,
+		To:           to,
+		Granularity:  granularity,
+		Basis:        basis,
+		ActiveUsers:  activeUsers,
+		Cohorts:      cohorts,
+		FeatureUsage: featureUsage,
+	}, nil
+}
+
+// GetForecast は失効予定ポイントの予測とポイントの流通速度を取得
+func (i *AdminInteractor) GetForecast(ctx context.Context, req *inputport.GetForecastRequest) (*inputport.GetForecastResponse, error) {
+	i.logger.Info("Getting forecast", entities.NewField("weeks", req.Weeks), entities.NewField("days", req.Days))
+
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	weeks := req.Weeks
+	if weeks < 1 || weeks > 26 {
+		weeks = 8
+	}
+	days := req.Days
+	if days != 7 && days != 30 && days != 90 {
+		days = 90
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	since := today.AddDate(0, 0, -days)
+
+	forecastResult, err := i.analyticsDS.GetExpiryForecast(ctx, today, weeks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiry forecast: %w", err)
+	}
+
+	outstanding, err := i.analyticsDS.GetOutstandingPoints(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outstanding points: %w", err)
+	}
+
+	velocityResult, err := i.analyticsDS.GetCirculationVelocity(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get circulation velocity: %w", err)
+	}
+
+	// 失効予定がない週もゼロ埋めで返す
+	forecast := make([]*inputport.ExpiryForecastWeek, 0, weeks)
+	for w := 0; w < weeks; w++ {
+		forecast = append(forecast, &inputport.ExpiryForecastWeek{
+			WeekStart: today.AddDate(0, 0, 7*w),
+			WeekEnd:   today.AddDate(0, 0, 7*(w+1)),
+		})
+	}
+	var totalExpiring int64
+	for _, f := range forecastResult {
+		if f.WeekIndex < 0 || f.WeekIndex >= weeks {
+			continue
+		}
+		forecast[f.WeekIndex].Amount = f.Amount
+		forecast[f.WeekIndex].Users = f.Users
+		forecast[f.WeekIndex].Batches = f.Batches
+		totalExpiring += f.Amount
+	}
+
+	pct := float64(0)
+	if outstanding > 0 {
+		pct = float64(totalExpiring) / float64(outstanding) * 100
+	}
+
+	return &inputport.GetForecastResponse{
+		ExpiryForecast:     forecast,
+		TotalExpiring:      totalExpiring,
+		OutstandingPoints:  outstanding,
+		ExpiringPercentage: pct,
+		Velocity: &inputport.CirculationVelocity{
+			Since:       since,
+			SampleCount: velocityResult.SampleCount,
+			MedianHours: velocityResult.MedianSeconds / 3600,
+		},
 	}, nil
 }
@@ -0,0 +1,147 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// AkerunRepollInteractor はAkerunの入退室記録の再取得のユースケース実装
+type AkerunRepollInteractor struct {
+	repollRepo    repository.AkerunRepollRepository
+	userRepo      repository.UserRepository
+	organizations entities.AkerunOrganizations
+	logger        entities.Logger
+}
+
+// NewAkerunRepollInteractor は新しいAkerunRepollInteractorを作成
+func NewAkerunRepollInteractor(
+	repollRepo repository.AkerunRepollRepository,
+	userRepo repository.UserRepository,
+	organizations entities.AkerunOrganizations,
+	logger entities.Logger,
+) inputport.AkerunRepollInputPort {
+	return &AkerunRepollInteractor{
+		repollRepo:    repollRepo,
+		userRepo:      userRepo,
+		organizations: organizations,
+		logger:        logger,
+	}
+}
+
+// RequestRepoll は再取得を依頼する
+// 期間の重なる取得を並行して走らせないよう、処理待ち・取得中の依頼があれば受け付けない
+func (i *AkerunRepollInteractor) RequestRepoll(ctx context.Context, req *inputport.RequestAkerunRepollRequest) (*entities.AkerunRepoll, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	if req.OrganizationID != "" {
+		if _, ok := i.organizations[req.OrganizationID]; !ok {
+			return nil, entities.ErrInvalidAkerunRepoll
+		}
+	}
+
+	repoll, err := entities.NewAkerunRepoll(req.OrganizationID, req.From, req.To, req.AdminID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := i.repollRepo.ReadActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active akerun repoll: %w", err)
+	}
+	if active != nil {
+		return nil, entities.ErrAkerunRepollInProgress
+	}
+
+	if err := i.repollRepo.Create(ctx, repoll); err != nil {
+		return nil, fmt.Errorf("failed to create akerun repoll: %w", err)
+	}
+
+	i.logger.Info("Akerun repoll requested",
+		entities.NewField("repoll_id", repoll.ID),
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("organization", repoll.OrganizationID),
+		entities.NewField("from", repoll.From.Format(time.RFC3339)),
+		entities.NewField("to", repoll.To.Format(time.RFC3339)))
+
+	return repoll, nil
+}
+
+// GetRepoll は再取得の依頼と進み具合を取得
+func (i *AkerunRepollInteractor) GetRepoll(ctx context.Context, adminID, id uuid.UUID) (*entities.AkerunRepoll, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	return i.repollRepo.Read(ctx, id)
+}
+
+// ListRepolls は再取得の依頼を新しい順に取得
+func (i *AkerunRepollInteractor) ListRepolls(ctx context.Context, req *inputport.ListAkerunRepollsRequest) (*inputport.ListAkerunRepollsResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	repolls, err := i.repollRepo.ReadList(ctx, req.Offset, req.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list akerun repolls: %w", err)
+	}
+	total, err := i.repollRepo.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count akerun repolls: %w", err)
+	}
+	return &inputport.ListAkerunRepollsResponse{Repolls: repolls, Total: total}, nil
+}
+
+// StartNextRepoll は処理待ちか取得中のまま止まった依頼を取得中にして返す
+// 取得中の依頼は、前のワーカーが止まった（リーダーの交代・再起動）ものとして続きから再開する
+func (i *AkerunRepollInteractor) StartNextRepoll(ctx context.Context) (*entities.AkerunRepoll, error) {
+	repoll, err := i.repollRepo.ReadActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active akerun repoll: %w", err)
+	}
+	if repoll == nil {
+		return nil, nil
+	}
+
+	repoll.Start(time.Now())
+	if err := i.repollRepo.Update(ctx, repoll); err != nil {
+		return nil, fmt.Errorf("failed to start akerun repoll: %w", err)
+	}
+	return repoll, nil
+}
+
+// RecordRepollWindow は1期間分の取得が終わったことを記録する（途中で止まっても次の期間から再開できる）
+func (i *AkerunRepollInteractor) RecordRepollWindow(ctx context.Context, repoll *entities.AkerunRepoll, fetched int) error {
+	repoll.RecordWindow(fetched)
+	if err := i.repollRepo.Update(ctx, repoll); err != nil {
+		return fmt.Errorf("failed to record akerun repoll progress: %w", err)
+	}
+	return nil
+}
+
+// FinishRepoll は再取得を終える
+func (i *AkerunRepollInteractor) FinishRepoll(ctx context.Context, repoll *entities.AkerunRepoll, cause error) error {
+	now := time.Now()
+	fields := []entities.Field{
+		entities.NewField("repoll_id", repoll.ID),
+		entities.NewField("windows", fmt.Sprintf("%d/%d", repoll.CompletedWindows, repoll.TotalWindows)),
+		entities.NewField("accesses", repoll.FetchedAccesses),
+	}
+	if cause != nil {
+		repoll.MarkFailed(cause.Error(), now)
+		i.logger.Error("Akerun repoll failed", append(fields, entities.NewField("error", cause))...)
+	} else {
+		repoll.MarkSucceeded(now)
+		i.logger.Info("Akerun repoll completed", fields...)
+	}
+
+	if err := i.repollRepo.Update(ctx, repoll); err != nil {
+		return fmt.Errorf("failed to finish akerun repoll: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAnnouncementListLimit = 20
+	maxAnnouncementListLimit     = 100
+)
+
+// AnnouncementInteractor はお知らせ配信のユースケース実装
+type AnnouncementInteractor struct {
+	announcementRepo repository.AnnouncementRepository
+	userRepo         repository.UserRepository
+	logger           entities.Logger
+}
+
+// NewAnnouncementInteractor は新しいAnnouncementInteractorを作成
+func NewAnnouncementInteractor(
+	announcementRepo repository.AnnouncementRepository,
+	userRepo repository.UserRepository,
+	logger entities.Logger,
+) inputport.AnnouncementInputPort {
+	return &AnnouncementInteractor{
+		announcementRepo: announcementRepo,
+		userRepo:         userRepo,
+		logger:           logger,
+	}
+}
+
+// CreateAnnouncement はお知らせを作成（管理者のみ）
+func (i *AnnouncementInteractor) CreateAnnouncement(ctx context.Context, req *inputport.CreateAnnouncementRequest) (*entities.Announcement, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	c := req.AnnouncementContent
+	announcement, err := entities.NewAnnouncement(c.Title, c.Body, c.Severity, c.Audience, c.TargetRole, c.StartsAt, c.EndsAt, req.AdminID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.announcementRepo.Create(ctx, announcement); err != nil {
+		return nil, fmt.Errorf("failed to create announcement: %w", err)
+	}
+
+	i.logger.Info("Announcement created",
+		entities.NewField("announcement_id", announcement.ID),
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("audience", announcement.Audience))
+
+	return announcement, nil
+}
+
+// UpdateAnnouncement はお知らせを更新（管理者のみ）
+func (i *AnnouncementInteractor) UpdateAnnouncement(ctx context.Context, req *inputport.UpdateAnnouncementRequest) (*entities.Announcement, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	announcement, err := i.announcementRepo.Read(ctx, req.AnnouncementID)
+	if err != nil {
+		return nil, err
+	}
+
+	c := req.AnnouncementContent
+	if err := announcement.Update(c.Title, c.Body, c.Severity, c.Audience, c.TargetRole, c.StartsAt, c.EndsAt); err != nil {
+		return nil, err
+	}
+
+	if err := i.announcementRepo.Update(ctx, announcement); err != nil {
+		return nil, fmt.Errorf("failed to update announcement: %w", err)
+	}
+
+	i.logger.Info("Announcement updated",
+		entities.NewField("announcement_id", announcement.ID),
+		entities.NewField("admin_id", req.AdminID))
+
+	return announcement, nil
+}
+
+// DeleteAnnouncement はお知らせを削除（管理者のみ）
+func (i *AnnouncementInteractor) DeleteAnnouncement(ctx context.Context, req *inputport.DeleteAnnouncementRequest) error {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return err
+	}
+
+	if err := i.announcementRepo.Delete(ctx, req.AnnouncementID); err != nil {
+		return err
+	}
+
+	i.logger.Info("Announcement deleted",
+		entities.NewField("announcement_id", req.AnnouncementID),
+		entities.NewField("admin_id", req.AdminID))
+
+	return nil
+}
+
+// GetAnnouncementList はお知らせの一覧を取得（管理者のみ）
+func (i *AnnouncementInteractor) GetAnnouncementList(ctx context.Context, req *inputport.GetAnnouncementListRequest) (*inputport.GetAnnouncementListResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultAnnouncementListLimit
+	}
+	if limit > maxAnnouncementListLimit {
+		limit = maxAnnouncementListLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	announcements, err := i.announcementRepo.ReadList(ctx, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get announcements: %w", err)
+	}
+	total, err := i.announcementRepo.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count announcements: %w", err)
+	}
+
+	return &inputport.GetAnnouncementListResponse{
+		Announcements: announcements,
+		Total:         total,
+		Offset:        offset,
+		Limit:         limit,
+	}, nil
+}
+
+// GetActiveAnnouncements はユーザーに表示するお知らせを取得
+// 表示期間内かつ配信対象のものだけを返し、非表示にしたものは既定で除く
+func (i *AnnouncementInteractor) GetActiveAnnouncements(ctx context.Context, req *inputport.GetActiveAnnouncementsRequest) (*inputport.GetActiveAnnouncementsResponse, error) {
+	user, err := i.userRepo.Read(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := i.announcementRepo.ReadActive(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active announcements: %w", err)
+	}
+
+	visible := make([]*entities.Announcement, 0, len(active))
+	ids := make([]uuid.UUID, 0, len(active))
+	for _, a := range active {
+		if a.IsVisibleTo(user.Role) {
+			visible = append(visible, a)
+			ids = append(ids, a.ID)
+		}
+	}
+
+	dismissedIDs, err := i.announcementRepo.ReadDismissedIDs(ctx, req.UserID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dismissed announcements: %w", err)
+	}
+	dismissed := make(map[uuid.UUID]bool, len(dismissedIDs))
+	for _, id := range dismissedIDs {
+		dismissed[id] = true
+	}
+
+	result := make([]*inputport.ActiveAnnouncement, 0, len(visible))
+	for _, a := range visible {
+		if dismissed[a.ID] && !req.IncludeDismissed {
+			continue
+		}
+		result = append(result, &inputport.ActiveAnnouncement{
+			Announcement: a,
+			Dismissed:    dismissed[a.ID],
+		})
+	}
+
+	return &inputport.GetActiveAnnouncementsResponse{Announcements: result}, nil
+}
+
+// DismissAnnouncement はお知らせを非表示にする
+// 表示期間外や配信対象外のお知らせは見つからない扱いにする
+func (i *AnnouncementInteractor) DismissAnnouncement(ctx context.Context, req *inputport.DismissAnnouncementRequest) error {
+	user, err := i.userRepo.Read(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	announcement, err := i.announcementRepo.Read(ctx, req.AnnouncementID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if !announcement.IsActiveAt(now) || !announcement.IsVisibleTo(user.Role) {
+		return entities.ErrAnnouncementNotFound
+	}
+
+	if err := i.announcementRepo.Dismiss(ctx, announcement.ID, req.UserID, now); err != nil {
+		return fmt.Errorf("failed to dismiss announcement: %w", err)
+	}
+	return nil
+}
@@ -3,33 +3,53 @@ package interactor
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/gity/point-system/usecases/repository"
 	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
 )
 
 // AuthInteractor は認証のユースケース実装
 type AuthInteractor struct {
-	userRepo        repository.UserRepository
-	sessionRepo     repository.SessionRepository
-	passwordService service.PasswordService
-	logger          entities.Logger
+	userRepo         repository.UserRepository
+	sessionRepo      repository.SessionRepository
+	loginAttemptRepo repository.LoginAttemptRepository
+	passwordService  service.PasswordService
+	emailService     service.EmailService
+	notifications    inputport.NotificationDispatcher
+	referrals        inputport.ReferralTracker
+	onboarding       inputport.OnboardingBonusGranter
+	verification     inputport.EmailVerificationGate
+	logger           entities.Logger
 }
 
 // NewAuthInteractor は新しいAuthInteractorを作成
 func NewAuthInteractor(
 	userRepo repository.UserRepository,
 	sessionRepo repository.SessionRepository,
+	loginAttemptRepo repository.LoginAttemptRepository,
 	passwordService service.PasswordService,
+	emailService service.EmailService,
+	notifications inputport.NotificationDispatcher,
+	referrals inputport.ReferralTracker,
+	onboarding inputport.OnboardingBonusGranter,
+	verification inputport.EmailVerificationGate,
 	logger entities.Logger,
 ) inputport.AuthInputPort {
 	return &AuthInteractor{
-		userRepo:        userRepo,
-		sessionRepo:     sessionRepo,
-		passwordService: passwordService,
-		logger:          logger,
+		userRepo:         userRepo,
+		sessionRepo:      sessionRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		passwordService:  passwordService,
+		emailService:     emailService,
+		notifications:    notifications,
+		referrals:        referrals,
+		onboarding:       onboarding,
+		verification:     verification,
+		logger:           logger,
 	}
 }
 
@@ -37,6 +57,16 @@ func NewAuthInteractor(
 func (i *AuthInteractor) Register(ctx context.Context, req *inputport.RegisterRequest) (*inputport.RegisterResponse, error) {
 	i.logger.Info("Registering new user", entities.NewField("username", req.Username))
 
+	// 紹介コードはユーザーを作る前に確認する（間違ったコードで登録が済んでしまわないように）
+	var referralCode *entities.ReferralCode
+	if req.ReferralCode != "" {
+		code, err := i.referrals.ResolveReferralCode(ctx, req.ReferralCode)
+		if err != nil {
+			return nil, err
+		}
+		referralCode = code
+	}
+
 	// パスワードハッシュ化
 	hashedPassword, err := i.passwordService.HashPassword(req.Password)
 	if err != nil {
@@ -54,6 +84,48 @@ func (i *AuthInteractor) Register(ctx context.Context, req *inputport.RegisterRe
 		return nil, err
 	}
 
+	// 紹介の記録に失敗しても登録は完了させる
+	if referralCode != nil {
+		if _, err := i.referrals.RecordReferral(ctx, &inputport.RecordReferralRequest{
+			Code:      referralCode,
+			RefereeID: user.ID,
+			IPAddress: req.IPAddress,
+		}); err != nil {
+			i.logger.Error("Failed to record referral",
+				entities.NewField("user_id", user.ID),
+				entities.NewField("error", err))
+		}
+	}
+
+	// ウェルカムボーナスの付与に失敗しても登録は完了させる
+	bonus, err := i.onboarding.GrantOnboardingBonus(ctx, user.ID)
+	if err != nil {
+		i.logger.Error("Failed to grant onboarding bonus",
+			entities.NewField("user_id", user.ID),
+			entities.NewField("error", err))
+	}
+	if bonus != nil {
+		user.Balance += bonus.Amount
+	}
+
+	// メール認証を求める設定なら認証メールを送る（設定を読めなくても登録は完了させ、ログイン・送金時に改めて確認する）
+	verification, err := i.verification.StartVerification(ctx, user)
+	if err != nil {
+		i.logger.Error("Failed to start email verification",
+			entities.NewField("user_id", user.ID),
+			entities.NewField("error", err))
+	}
+	resp := &inputport.RegisterResponse{
+		User:              user,
+		OnboardingBonus:   bonus,
+		EmailVerification: verification,
+	}
+
+	// 認証が済むまでログインできない設定ならセッションを作らない
+	if verification == entities.EmailVerificationBeforeLogin {
+		return resp, nil
+	}
+
 	// セッション作成
 	session, err := entities.NewSession(user.ID, "", "")
 	if err != nil {
@@ -64,31 +136,111 @@ func (i *AuthInteractor) Register(ctx context.Context, req *inputport.RegisterRe
 		return nil, err
 	}
 
-	return &inputport.RegisterResponse{
-		User:    user,
-		Session: session,
-	}, nil
+	resp.Session = session
+	return resp, nil
 }
 
 // Login はログイン処理
+// アカウント単位・IP単位の失敗回数でロックアウトし、新しい端末・国からのログインはメールで通知する
 func (i *AuthInteractor) Login(ctx context.Context, req *inputport.LoginRequest) (*inputport.LoginResponse, error) {
 	i.logger.Info("User login attempt", entities.NewField("username", req.Username))
 
+	now := time.Now()
+
+	// IP単位の失敗回数チェック（パスワードスプレー対策）
+	if req.IPAddress != "" {
+		failed, err := i.loginAttemptRepo.CountFailedByIPSince(ctx, req.IPAddress, now.Add(-entities.LoginIPFailureWindow))
+		if err != nil {
+			return nil, err
+		}
+		if failed >= entities.LoginMaxFailuresPerIP {
+			i.recordAttempt(ctx, nil, req, false, entities.LoginFailureIPBlocked)
+			return nil, entities.ErrTooManyLoginAttempts
+		}
+	}
+
 	// ユーザー検索
 	user, err := i.userRepo.ReadByUsername(ctx, req.Username)
 	if err != nil {
-		return nil, errors.New("invalid username or password")
+		i.recordAttempt(ctx, nil, req, false, entities.LoginFailureInvalidCredentials)
+		return nil, entities.ErrInvalidCredentials
+	}
+
+	// ロック状態チェック
+	lockout, err := i.loginAttemptRepo.ReadLockout(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if lockout == nil {
+		lockout = entities.NewAccountLockout(user.ID)
+	}
+	if lockout.IsLocked(now) {
+		i.recordAttempt(ctx, &user.ID, req, false, entities.LoginFailureAccountLocked)
+		return nil, entities.ErrAccountLocked
 	}
 
 	// パスワード検証
 	if !i.passwordService.VerifyPassword(user.PasswordHash, req.Password) {
-		return nil, errors.New("invalid username or password")
+		i.recordAttempt(ctx, &user.ID, req, false, entities.LoginFailureInvalidCredentials)
+		if locked := i.registerFailure(ctx, user, lockout, now); locked {
+			return nil, entities.ErrAccountLocked
+		}
+		return nil, entities.ErrInvalidCredentials
+	}
+
+	// 設定と異なるアルゴリズム・パラメータのハッシュは作り直す（平文のパスワードがあるログイン時にしかできない）
+	rehashed := i.rehashPassword(user, req.Password)
+
+	// 本人が休止したアカウントは期間内のログインで再開する（作り直したハッシュも一緒に保存する）
+	reactivated := false
+	if user.IsDeactivated() {
+		if err := user.Reactivate(now); err != nil {
+			i.recordAttempt(ctx, &user.ID, req, false, entities.LoginFailureInactive)
+			return nil, err
+		}
+		updated, err := i.userRepo.Update(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		if !updated {
+			return nil, entities.ErrUpdateConflict
+		}
+		reactivated = true
+		i.logger.Info("Deactivated account reactivated by login", entities.NewField("user_id", user.ID))
 	}
 
 	// アクティブチェック
 	if !user.IsActive {
+		i.recordAttempt(ctx, &user.ID, req, false, entities.LoginFailureInactive)
 		return nil, errors.New("user account is not active")
 	}
+	if rehashed && !reactivated {
+		i.saveRehashedPassword(ctx, user)
+	}
+
+	// 新規登録ユーザーのメール認証（パスワードを確かめた後に判定し、未登録のユーザー名と区別できないようにする）
+	if err := i.verification.CheckLogin(ctx, user); err != nil {
+		if errors.Is(err, entities.ErrEmailNotVerified) {
+			i.recordAttempt(ctx, &user.ID, req, false, entities.LoginFailureEmailNotVerified)
+		}
+		return nil, err
+	}
+
+	// ロックアウト状態をリセット
+	if !lockout.IsClean() {
+		lockout.Reset(now)
+		if err := i.loginAttemptRepo.SaveLockout(ctx, lockout); err != nil {
+			i.logger.Warn("Failed to reset account lockout",
+				entities.NewField("user_id", user.ID),
+				entities.NewField("error", err))
+		}
+	}
+
+	// 新しい端末・国の判定は今回の成功を記録する前に行う
+	isNewDevice := i.isNewDeviceLogin(ctx, user.ID, req)
+	attempt := entities.NewLoginAttempt(&user.ID, req.Username, req.IPAddress, req.UserAgent, req.Country, true, "")
+	attempt.NewDevice = isNewDevice
+	i.saveAttempt(ctx, attempt)
 
 	// セッション作成
 	session, err := entities.NewSession(user.ID, req.IPAddress, req.UserAgent)
@@ -100,12 +252,46 @@ func (i *AuthInteractor) Login(ctx context.Context, req *inputport.LoginRequest)
 		return nil, err
 	}
 
+	if isNewDevice {
+		i.notifications.Dispatch(ctx, entities.NewLoginNotification(user, req.IPAddress, req.UserAgent, req.Country, now))
+	}
+
 	return &inputport.LoginResponse{
-		User:    user,
-		Session: session,
+		User:        user,
+		Session:     session,
+		Reactivated: reactivated,
 	}, nil
 }
 
+// rehashPassword はハッシュのアルゴリズム・パラメータが古ければ作り直してユーザーに設定する（保存は呼び出し側）
+func (i *AuthInteractor) rehashPassword(user *entities.User, password string) bool {
+	if !i.passwordService.NeedsRehash(user.PasswordHash) {
+		return false
+	}
+	hash, err := i.passwordService.HashPassword(password)
+	if err != nil {
+		i.logger.Warn("Failed to rehash password",
+			entities.NewField("user_id", user.ID),
+			entities.NewField("error", err))
+		return false
+	}
+	user.PasswordHash = hash
+	return true
+}
+
+// saveRehashedPassword は作り直したハッシュを保存する（失敗しても次のログインでまた作り直すため、ログインは続ける）
+func (i *AuthInteractor) saveRehashedPassword(ctx context.Context, user *entities.User) {
+	updated, err := i.userRepo.Update(ctx, user)
+	if err != nil || !updated {
+		i.logger.Warn("Failed to save rehashed password",
+			entities.NewField("user_id", user.ID),
+			entities.NewField("updated", updated),
+			entities.NewField("error", err))
+		return
+	}
+	i.logger.Info("Password rehashed with current parameters", entities.NewField("user_id", user.ID))
+}
+
 // Logout はログアウト処理
 func (i *AuthInteractor) Logout(ctx context.Context, req *inputport.LogoutRequest) error {
 	i.logger.Info("User logout", entities.NewField("user_id", req.UserID))
@@ -132,16 +318,163 @@ func (i *AuthInteractor) ValidateSession(ctx context.Context, sessionToken strin
 	}
 
 	if session.IsExpired() {
-		return nil, errors.New("session expired")
+		return nil, entities.ErrSessionExpired
 	}
 
 	// セッションをリフレッシュ（並行更新エラーは無視）
 	// 複数のリクエストが同時に来た場合、いずれかが成功すれば良い
 	session.Refresh()
 	if err := i.sessionRepo.Update(ctx, session); err != nil {
+		// 読み込み後に別リクエストで失効された場合は認証を拒否
+		if _, readErr := i.sessionRepo.Read(ctx, session.ID); readErr != nil {
+			return nil, errors.New("session revoked")
+		}
 		// 並行更新エラーやその他のエラーでも、セッション自体は有効なので認証は継続
 		i.logger.Debug("Failed to refresh session (ignoring)", entities.NewField("error", err))
 	}
 
+	// 休止中のアカウントのセッションは使えない（休止時に失効させるが、並行したリクエストに備える）
+	user, err := i.userRepo.Read(ctx, session.UserID)
+	if err != nil {
+		return nil, errors.New("invalid session")
+	}
+	if user.IsDeactivated() {
+		return nil, entities.ErrAccountDeactivated
+	}
+
 	return session, nil
 }
+
+// DeactivateAccount は本人がアカウントを一時的に休止
+// 休止中は検索に出ず取引できない。UserReactivationWindow以内にログインすると再開する（退会と違いデータは残る）
+func (i *AuthInteractor) DeactivateAccount(ctx context.Context, req *inputport.DeactivateAccountRequest) (*inputport.DeactivateAccountResponse, error) {
+	i.logger.Info("Deactivating account", entities.NewField("user_id", req.UserID))
+
+	user, err := i.userRepo.Read(ctx, req.UserID)
+	if err != nil {
+		return nil, entities.ErrUserNotFound
+	}
+
+	if !i.passwordService.VerifyPassword(user.PasswordHash, req.Password) {
+		return nil, entities.ErrInvalidCredentials
+	}
+
+	if err := user.DeactivateSelf(time.Now()); err != nil {
+		return nil, err
+	}
+
+	updated, err := i.userRepo.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, entities.ErrUpdateConflict
+	}
+
+	// すべての端末からログアウトさせる（失敗しても休止中のセッションはValidateSessionで拒否される）
+	if err := i.sessionRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		i.logger.Error("Failed to revoke sessions of deactivated account",
+			entities.NewField("user_id", user.ID),
+			entities.NewField("error", err))
+	}
+
+	i.logger.Info("Account deactivated", entities.NewField("user_id", user.ID))
+
+	return &inputport.DeactivateAccountResponse{
+		User:               user,
+		ReactivatableUntil: user.ReactivatableUntil(),
+	}, nil
+}
+
+// UnlockAccount はメールで送られたトークンでアカウントロックを解除
+func (i *AuthInteractor) UnlockAccount(ctx context.Context, req *inputport.UnlockAccountRequest) error {
+	if req.Token == "" {
+		return errors.New("unlock token is required")
+	}
+
+	lockout, err := i.loginAttemptRepo.ReadLockoutByUnlockTokenHash(ctx, entities.HashToken(req.Token))
+	if err != nil {
+		return errors.New("invalid unlock token")
+	}
+
+	if err := lockout.Unlock(time.Now()); err != nil {
+		return err
+	}
+
+	i.logger.Info("Account unlocked by email", entities.NewField("user_id", lockout.UserID))
+	return i.loginAttemptRepo.SaveLockout(ctx, lockout)
+}
+
+// registerFailure はログイン失敗をロックアウト状態に反映し、ロックされた場合はメールで通知する
+func (i *AuthInteractor) registerFailure(ctx context.Context, user *entities.User, lockout *entities.AccountLockout, now time.Time) bool {
+	locked := lockout.RecordFailure(now)
+
+	var unlockToken string
+	if locked {
+		token, err := lockout.IssueUnlockToken(now)
+		if err != nil {
+			i.logger.Error("Failed to issue unlock token", entities.NewField("error", err))
+		}
+		unlockToken = token
+	}
+
+	if err := i.loginAttemptRepo.SaveLockout(ctx, lockout); err != nil {
+		i.logger.Error("Failed to save account lockout",
+			entities.NewField("user_id", user.ID),
+			entities.NewField("error", err))
+		return locked
+	}
+
+	if locked {
+		i.logger.Warn("Account locked due to repeated login failures",
+			entities.NewField("user_id", user.ID),
+			entities.NewField("locked_until", lockout.LockedUntil))
+		if unlockToken != "" {
+			if err := i.emailService.SendAccountLockedNotification(user.Email, unlockToken, *lockout.LockedUntil); err != nil {
+				i.logger.Warn("Failed to send account locked notification",
+					entities.NewField("user_id", user.ID),
+					entities.NewField("error", err))
+			}
+		}
+	}
+
+	return locked
+}
+
+// isNewDeviceLogin は過去のログイン成功履歴と比べて新しい端末・国からのログインかを判定
+// 初回ログインは通知対象外
+func (i *AuthInteractor) isNewDeviceLogin(ctx context.Context, userID uuid.UUID, req *inputport.LoginRequest) bool {
+	count, err := i.loginAttemptRepo.CountSuccessByUser(ctx, userID)
+	if err != nil || count == 0 {
+		return false
+	}
+
+	knownDevice, err := i.loginAttemptRepo.ExistsSuccessByUserAndUserAgent(ctx, userID, req.UserAgent)
+	if err != nil {
+		return false
+	}
+	if !knownDevice {
+		return true
+	}
+
+	if req.Country == "" {
+		return false
+	}
+	knownCountry, err := i.loginAttemptRepo.ExistsSuccessByUserAndCountry(ctx, userID, req.Country)
+	if err != nil {
+		return false
+	}
+	return !knownCountry
+}
+
+// recordAttempt はログイン試行を記録
+func (i *AuthInteractor) recordAttempt(ctx context.Context, userID *uuid.UUID, req *inputport.LoginRequest, success bool, failureReason string) {
+	i.saveAttempt(ctx, entities.NewLoginAttempt(userID, req.Username, req.IPAddress, req.UserAgent, req.Country, success, failureReason))
+}
+
+// saveAttempt はログイン試行を保存（記録失敗はログイン処理に影響させない）
+func (i *AuthInteractor) saveAttempt(ctx context.Context, attempt *entities.LoginAttempt) {
+	if err := i.loginAttemptRepo.Create(ctx, attempt); err != nil {
+		i.logger.Warn("Failed to record login attempt", entities.NewField("error", err))
+	}
+}
@@ -0,0 +1,130 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// releaseHoldsBatchSize は期限切れにするジョブで1回に戻す確保の件数
+const releaseHoldsBatchSize = 500
+
+// BalanceHoldInteractor は送金リクエストのための残高の確保のユースケース実装
+// 確保した額はユーザーのReservedBalanceに加え、承認まではほかの送金・交換に使えないようにする
+type BalanceHoldInteractor struct {
+	txManager repository.TransactionManager
+	userRepo  repository.UserRepository
+	holdRepo  repository.BalanceHoldRepository
+	logger    entities.Logger
+}
+
+// NewBalanceHoldInteractor は新しいBalanceHoldInteractorを作成
+func NewBalanceHoldInteractor(
+	txManager repository.TransactionManager,
+	userRepo repository.UserRepository,
+	holdRepo repository.BalanceHoldRepository,
+	logger entities.Logger,
+) *BalanceHoldInteractor {
+	return &BalanceHoldInteractor{
+		txManager: txManager,
+		userRepo:  userRepo,
+		holdRepo:  holdRepo,
+		logger:    logger,
+	}
+}
+
+// Hold は送信者の残高からリクエストの金額を確保し、同じトランザクションでsaveを呼ぶ
+func (i *BalanceHoldInteractor) Hold(ctx context.Context, tr *entities.TransferRequest, save func(ctx context.Context) error) error {
+	hold, err := entities.NewBalanceHold(tr)
+	if err != nil {
+		return err
+	}
+
+	err = i.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := i.userRepo.UpdateReservedBalanceWithLock(ctx, hold.UserID, hold.Amount, false); err != nil {
+			return err
+		}
+		if err := save(ctx); err != nil {
+			return err
+		}
+		if err := i.holdRepo.Create(ctx, hold); err != nil {
+			return fmt.Errorf("failed to create balance hold: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	i.logger.Info("Balance held for transfer request",
+		entities.NewField("request_id", tr.ID),
+		entities.NewField("user_id", hold.UserID),
+		entities.NewField("amount", hold.Amount))
+	return nil
+}
+
+// Release は送金リクエストの確保を戻す（確保していない・使用済み・解放済みなら何もしない）
+func (i *BalanceHoldInteractor) Release(ctx context.Context, transferRequestID uuid.UUID) error {
+	var released *entities.BalanceHold
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		hold, err := i.holdRepo.ReadByTransferRequestIDForUpdate(ctx, transferRequestID)
+		if err != nil {
+			return err
+		}
+		if hold == nil || !hold.IsActive() {
+			return nil
+		}
+		if err := i.release(ctx, hold); err != nil {
+			return err
+		}
+		released = hold
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release balance hold: %w", err)
+	}
+
+	if released != nil {
+		i.logger.Info("Balance hold released",
+			entities.NewField("request_id", transferRequestID),
+			entities.NewField("user_id", released.UserID),
+			entities.NewField("amount", released.Amount))
+	}
+	return nil
+}
+
+// ReleaseClosedHolds は拒否・キャンセル・期限切れになった送金リクエストの確保を戻し、戻した件数を返す
+// 拒否・キャンセル時に戻せなかったものと、期限切れになったものをここで戻す
+func (i *BalanceHoldInteractor) ReleaseClosedHolds(ctx context.Context) (int64, error) {
+	holds, err := i.holdRepo.ReadReleasable(ctx, releaseHoldsBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read releasable balance holds: %w", err)
+	}
+
+	var released int64
+	for _, hold := range holds {
+		if err := i.Release(ctx, hold.TransferRequestID); err != nil {
+			i.logger.Error("Failed to release balance hold",
+				entities.NewField("request_id", hold.TransferRequestID),
+				entities.NewField("error", err))
+			continue
+		}
+		released++
+	}
+	return released, nil
+}
+
+// release は確保を解放済みにし、送信者の確保中の残高から差し引く（トランザクション内で呼ぶ）
+func (i *BalanceHoldInteractor) release(ctx context.Context, hold *entities.BalanceHold) error {
+	if err := hold.Release(time.Now()); err != nil {
+		return err
+	}
+	if err := i.userRepo.UpdateReservedBalanceWithLock(ctx, hold.UserID, hold.Amount, true); err != nil {
+		return err
+	}
+	return i.holdRepo.Update(ctx, hold)
+}
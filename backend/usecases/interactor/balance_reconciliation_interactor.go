@@ -0,0 +1,189 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// BalanceReconciliationInteractor は残高と取引履歴の照合のユースケース実装
+type BalanceReconciliationInteractor struct {
+	txManager       repository.TransactionManager
+	ledgerRepo      repository.BalanceLedgerRepository
+	userRepo        repository.UserRepository
+	transactionRepo repository.TransactionRepository
+	pointBatchRepo  repository.PointBatchRepository
+	logger          entities.Logger
+}
+
+// NewBalanceReconciliationInteractor は新しいBalanceReconciliationInteractorを作成
+func NewBalanceReconciliationInteractor(
+	txManager repository.TransactionManager,
+	ledgerRepo repository.BalanceLedgerRepository,
+	userRepo repository.UserRepository,
+	transactionRepo repository.TransactionRepository,
+	pointBatchRepo repository.PointBatchRepository,
+	logger entities.Logger,
+) inputport.BalanceReconciliationInputPort {
+	return &BalanceReconciliationInteractor{
+		txManager:       txManager,
+		ledgerRepo:      ledgerRepo,
+		userRepo:        userRepo,
+		transactionRepo: transactionRepo,
+		pointBatchRepo:  pointBatchRepo,
+		logger:          logger,
+	}
+}
+
+// RecomputeBalances は全ユーザーの残高を取引履歴から計算し直し、一致しないものを返す
+// 補正する場合はバッチごとに対象ユーザーの行をロックしてから計算するため、処理中の送金とは競合しない
+func (i *BalanceReconciliationInteractor) RecomputeBalances(ctx context.Context, req *inputport.RecomputeBalancesRequest) (*inputport.RecomputeBalancesResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = entities.BalanceRecomputeDefaultBatchSize
+	}
+	if batchSize > entities.BalanceRecomputeMaxBatchSize {
+		batchSize = entities.BalanceRecomputeMaxBatchSize
+	}
+
+	i.logger.Info("Recomputing balances",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("apply", req.Apply),
+		entities.NewField("batch_size", batchSize))
+
+	resp := &inputport.RecomputeBalancesResponse{
+		Applied:       req.Apply,
+		Discrepancies: []*entities.BalanceDiscrepancy{},
+	}
+	after := uuid.Nil
+	for {
+		userIDs, err := i.ledgerRepo.ReadUserIDs(ctx, after, batchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users: %w", err)
+		}
+		if len(userIDs) == 0 {
+			break
+		}
+
+		var found []*entities.BalanceDiscrepancy
+		if req.Apply {
+			err = i.txManager.Do(ctx, func(ctx context.Context) error {
+				var err error
+				found, err = i.correctBatch(ctx, userIDs, req)
+				return err
+			})
+		} else {
+			found, err = i.checkBatch(ctx, userIDs)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to recompute balances after %d users: %w", resp.CheckedUsers, err)
+		}
+
+		resp.CheckedUsers += len(userIDs)
+		resp.Batches++
+		resp.Discrepancies = append(resp.Discrepancies, found...)
+		if len(userIDs) < batchSize {
+			break
+		}
+		after = userIDs[len(userIDs)-1]
+	}
+
+	i.logger.Info("Balances recomputed",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("apply", req.Apply),
+		entities.NewField("checked_users", resp.CheckedUsers),
+		entities.NewField("discrepancies", len(resp.Discrepancies)))
+
+	return resp, nil
+}
+
+// checkBatch は残高が取引履歴と一致しないユーザーを返す（残高は変えない）
+func (i *BalanceReconciliationInteractor) checkBatch(ctx context.Context, userIDs []uuid.UUID) ([]*entities.BalanceDiscrepancy, error) {
+	checks, err := i.ledgerRepo.ReadBalanceChecks(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	found := []*entities.BalanceDiscrepancy{}
+	for _, check := range checks {
+		if check.HasDiscrepancy() {
+			found = append(found, &entities.BalanceDiscrepancy{BalanceCheck: *check})
+		}
+	}
+	return found, nil
+}
+
+// correctBatch は対象ユーザーの行をロックしてから照合し、一致しない残高を補正取引とともに取引履歴に合わせる
+// 取引履歴から計算した残高が負のユーザーは残高を負にできないため補正しない
+// 減らす分が確保中の送金リクエストの分に掛かるユーザーも、確保を崩さないよう補正しない
+func (i *BalanceReconciliationInteractor) correctBatch(ctx context.Context, userIDs []uuid.UUID, req *inputport.RecomputeBalancesRequest) ([]*entities.BalanceDiscrepancy, error) {
+	if err := i.ledgerRepo.LockUsers(ctx, userIDs); err != nil {
+		return nil, err
+	}
+	found, err := i.checkBatch(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, d := range found {
+		if d.LedgerBalance < 0 {
+			d.SkipReason = entities.BalanceCorrectionSkipNegativeLedger
+			i.logger.Warn("Balance not corrected: ledger balance is negative",
+				entities.NewField("user_id", d.UserID),
+				entities.NewField("stored_balance", d.StoredBalance),
+				entities.NewField("ledger_balance", d.LedgerBalance))
+			continue
+		}
+
+		tx, err := entities.NewBalanceCorrection(&d.BalanceCheck, req.AdminID, req.Reason, now)
+		if err != nil {
+			return nil, err
+		}
+		diff := d.Difference()
+		if err := i.userRepo.UpdateBalanceWithLock(ctx, d.UserID, tx.Amount, diff < 0); err != nil {
+			if errors.Is(err, entities.ErrInsufficientBalance) {
+				d.SkipReason = entities.BalanceCorrectionSkipReserved
+				i.logger.Warn("Balance not corrected: correction would use reserved balance",
+					entities.NewField("user_id", d.UserID),
+					entities.NewField("stored_balance", d.StoredBalance),
+					entities.NewField("ledger_balance", d.LedgerBalance))
+				continue
+			}
+			return nil, fmt.Errorf("failed to correct balance of %s: %w", d.UserID, err)
+		}
+		if err := i.transactionRepo.Create(ctx, tx); err != nil {
+			return nil, fmt.Errorf("failed to record balance correction: %w", err)
+		}
+
+		// 有効期限の管理も残高に合わせる（増やした分はバッチを作り、減らした分は古いバッチから消費する）
+		if diff > 0 {
+			batch := entities.NewPointBatch(d.UserID, tx.Amount, entities.PointBatchSourceBalanceCorrection, &tx.ID, now)
+			if err := i.pointBatchRepo.Create(ctx, batch); err != nil {
+				return nil, fmt.Errorf("failed to create point batch: %w", err)
+			}
+		} else if err := i.pointBatchRepo.ConsumePointsFIFO(ctx, d.UserID, tx.Amount); err != nil {
+			return nil, fmt.Errorf("failed to consume point batches: %w", err)
+		}
+
+		d.Corrected = true
+		d.TransactionID = &tx.ID
+		i.logger.Info("Balance corrected",
+			entities.NewField("admin_id", req.AdminID),
+			entities.NewField("user_id", d.UserID),
+			entities.NewField("stored_balance", d.StoredBalance),
+			entities.NewField("ledger_balance", d.LedgerBalance),
+			entities.NewField("transaction_id", tx.ID))
+	}
+	return found, nil
+}
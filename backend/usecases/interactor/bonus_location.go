@@ -0,0 +1,38 @@
+package interactor
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+// systemBonusLocation はsystem_settingsのボーナス日のタイムゾーンを返す
+// 未設定・読み込めない・不正な値の場合は既定のタイムゾーン（Asia/Tokyo）を使う
+func systemBonusLocation(ctx context.Context, settingsRepo repository.SystemSettingsRepository, logger entities.Logger) *time.Location {
+	name, err := settingsRepo.GetSetting(ctx, entities.BonusTimezoneSettingKey)
+	if err != nil {
+		logger.Warn("Failed to get bonus timezone setting", entities.NewField("error", err))
+		return entities.DefaultBonusLocation()
+	}
+	loc, err := entities.LoadBonusLocation(strings.TrimSpace(name))
+	if err != nil {
+		logger.Warn("Invalid bonus timezone setting", entities.NewField("timezone", name))
+		return entities.DefaultBonusLocation()
+	}
+	return loc
+}
+
+// bonusLocationFor はユーザーのボーナス日のタイムゾーンを返す（未設定・不正ならsystemLoc）
+func bonusLocationFor(user *entities.User, systemLoc *time.Location) *time.Location {
+	if user == nil || user.Timezone == "" {
+		return systemLoc
+	}
+	loc, err := entities.LoadBonusLocation(user.Timezone)
+	if err != nil {
+		return systemLoc
+	}
+	return loc
+}
@@ -0,0 +1,162 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// BudgetInteractor は管理者付与の予算のユースケース実装
+// 予算の消化はAdminInteractor.GrantPointsが付与のトランザクション内で行う
+type BudgetInteractor struct {
+	budgetRepo     repository.BudgetRepository
+	departmentRepo repository.DepartmentRepository
+	userRepo       repository.UserRepository
+	logger         entities.Logger
+}
+
+// NewBudgetInteractor は新しいBudgetInteractorを作成
+func NewBudgetInteractor(
+	budgetRepo repository.BudgetRepository,
+	departmentRepo repository.DepartmentRepository,
+	userRepo repository.UserRepository,
+	logger entities.Logger,
+) inputport.BudgetInputPort {
+	return &BudgetInteractor{
+		budgetRepo:     budgetRepo,
+		departmentRepo: departmentRepo,
+		userRepo:       userRepo,
+		logger:         logger,
+	}
+}
+
+// ListBudgets は全予算と現在の期間の消化状況を取得
+func (i *BudgetInteractor) ListBudgets(ctx context.Context, adminID uuid.UUID) ([]*entities.BudgetStatus, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+
+	budgets, err := i.budgetRepo.ReadList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	statuses := make([]*entities.BudgetStatus, 0, len(budgets))
+	for _, b := range budgets {
+		status, err := i.status(ctx, b, now)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// GetBudget は予算と現在の期間の消化状況を取得
+func (i *BudgetInteractor) GetBudget(ctx context.Context, req *inputport.GetBudgetRequest) (*entities.BudgetStatus, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	budget, err := i.budgetRepo.Read(ctx, req.BudgetID)
+	if err != nil {
+		return nil, err
+	}
+	return i.status(ctx, budget, time.Now())
+}
+
+// CreateBudget は予算を作成
+func (i *BudgetInteractor) CreateBudget(ctx context.Context, req *inputport.CreateBudgetRequest) (*entities.BudgetStatus, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	budget, err := entities.NewBudget(req.Name, req.Scope, req.ScopeID, req.Period, req.Amount, req.Enforcement)
+	if err != nil {
+		return nil, err
+	}
+
+	// 対象の部署・管理者が存在することを確認
+	switch budget.Scope {
+	case entities.BudgetScopeDepartment:
+		if _, err := i.departmentRepo.Read(ctx, *budget.ScopeID); err != nil {
+			return nil, err
+		}
+	case entities.BudgetScopeAdmin:
+		if err := requireAdmin(ctx, i.userRepo, *budget.ScopeID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := i.budgetRepo.Create(ctx, budget); err != nil {
+		return nil, fmt.Errorf("failed to create budget: %w", err)
+	}
+
+	i.logger.Info("Budget created",
+		entities.NewField("budget_id", budget.ID),
+		entities.NewField("scope", string(budget.Scope)),
+		entities.NewField("amount", budget.Amount),
+		entities.NewField("admin_id", req.AdminID))
+
+	return i.status(ctx, budget, time.Now())
+}
+
+// UpdateBudget は予算名・金額・超過時の扱い・有効状態を更新
+func (i *BudgetInteractor) UpdateBudget(ctx context.Context, req *inputport.UpdateBudgetRequest) (*entities.BudgetStatus, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	budget, err := i.budgetRepo.Read(ctx, req.BudgetID)
+	if err != nil {
+		return nil, err
+	}
+	if err := budget.Update(req.Name, req.Amount, req.Enforcement, req.IsActive); err != nil {
+		return nil, err
+	}
+
+	if err := i.budgetRepo.Update(ctx, budget); err != nil {
+		return nil, fmt.Errorf("failed to update budget: %w", err)
+	}
+
+	i.logger.Info("Budget updated",
+		entities.NewField("budget_id", budget.ID),
+		entities.NewField("amount", budget.Amount),
+		entities.NewField("enforcement", string(budget.Enforcement)),
+		entities.NewField("is_active", budget.IsActive),
+		entities.NewField("admin_id", req.AdminID))
+
+	return i.status(ctx, budget, time.Now())
+}
+
+// DeleteBudget は予算を削除
+func (i *BudgetInteractor) DeleteBudget(ctx context.Context, req *inputport.DeleteBudgetRequest) error {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return err
+	}
+
+	if err := i.budgetRepo.Delete(ctx, req.BudgetID); err != nil {
+		return err
+	}
+
+	i.logger.Info("Budget deleted",
+		entities.NewField("budget_id", req.BudgetID),
+		entities.NewField("admin_id", req.AdminID))
+
+	return nil
+}
+
+// status はnowを含む期間の消化状況を取得
+func (i *BudgetInteractor) status(ctx context.Context, budget *entities.Budget, now time.Time) (*entities.BudgetStatus, error) {
+	usage, err := i.budgetRepo.ReadUsage(ctx, budget.ID, budget.PeriodStart(now))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read budget usage: %w", err)
+	}
+	return entities.NewBudgetStatus(budget, usage), nil
+}
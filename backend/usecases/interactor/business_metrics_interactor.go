@@ -0,0 +1,54 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
+)
+
+// BusinessMetricsInteractor は /metrics で公開する業務の指標のユースケース実装
+// 集計はワーカーが定期的に行い、/metrics へのリクエストは保持した値を返すだけにする
+// （Grafanaなどから頻繁に取得されてもDBへの負荷は集計の間隔で決まる）
+type BusinessMetricsInteractor struct {
+	metricsRepo repository.BusinessMetricsRepository
+	slowQueries service.SlowQueryMonitor
+
+	mu       sync.RWMutex
+	latest   *entities.BusinessMetrics
+	failures int64
+}
+
+// NewBusinessMetricsInteractor は新しいBusinessMetricsInteractorを作成
+func NewBusinessMetricsInteractor(metricsRepo repository.BusinessMetricsRepository, slowQueries service.SlowQueryMonitor) inputport.BusinessMetricsInputPort {
+	return &BusinessMetricsInteractor{metricsRepo: metricsRepo, slowQueries: slowQueries}
+}
+
+// RefreshBusinessMetrics はnow時点の指標を集計し直して保持する
+func (i *BusinessMetricsInteractor) RefreshBusinessMetrics(ctx context.Context, now time.Time) error {
+	metrics, err := i.metricsRepo.GetBusinessMetrics(ctx, entities.BusinessMetricsDayStart(now), now)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if err != nil {
+		i.failures++
+		return fmt.Errorf("failed to collect business metrics: %w", err)
+	}
+	i.latest = metrics
+	return nil
+}
+
+// GetBusinessMetrics は最後に集計した指標と集計の失敗回数、遅いクエリの件数を返す
+// 遅いクエリの件数はクエリのたびにメモリ上で数えているので、集計を待たずにその時点の値を返す
+func (i *BusinessMetricsInteractor) GetBusinessMetrics() *inputport.BusinessMetricsSnapshot {
+	i.mu.RLock()
+	snapshot := &inputport.BusinessMetricsSnapshot{Metrics: i.latest, RefreshFailures: i.failures}
+	i.mu.RUnlock()
+	snapshot.SlowQueries = i.slowQueries.SlowQueries()
+	return snapshot
+}
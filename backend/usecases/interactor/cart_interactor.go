@@ -0,0 +1,356 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// CartInteractor は商品交換のカートのユースケース実装
+type CartInteractor struct {
+	txManager       repository.TransactionManager
+	cartRepo        repository.CartRepository
+	productRepo     repository.ProductRepository
+	exchangeRepo    repository.ProductExchangeRepository
+	userRepo        repository.UserRepository
+	transactionRepo repository.TransactionRepository
+	pointBatchRepo  repository.PointBatchRepository
+	pricingRuleRepo repository.PricingRuleRepository
+	addressRepo     repository.ShippingAddressRepository
+	logger          entities.Logger
+}
+
+// NewCartInteractor は新しいCartInteractorを作成
+func NewCartInteractor(
+	txManager repository.TransactionManager,
+	cartRepo repository.CartRepository,
+	productRepo repository.ProductRepository,
+	exchangeRepo repository.ProductExchangeRepository,
+	userRepo repository.UserRepository,
+	transactionRepo repository.TransactionRepository,
+	pointBatchRepo repository.PointBatchRepository,
+	pricingRuleRepo repository.PricingRuleRepository,
+	addressRepo repository.ShippingAddressRepository,
+	logger entities.Logger,
+) inputport.CartInputPort {
+	return &CartInteractor{
+		txManager:       txManager,
+		cartRepo:        cartRepo,
+		productRepo:     productRepo,
+		exchangeRepo:    exchangeRepo,
+		userRepo:        userRepo,
+		transactionRepo: transactionRepo,
+		pointBatchRepo:  pointBatchRepo,
+		pricingRuleRepo: pricingRuleRepo,
+		addressRepo:     addressRepo,
+		logger:          logger,
+	}
+}
+
+// GetCart はカートの中身と現在の価格での合計を取得
+func (i *CartInteractor) GetCart(ctx context.Context, userID uuid.UUID) (*inputport.CartResponse, error) {
+	user, err := i.userRepo.Read(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := i.cartRepo.ReadByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	resp := &inputport.CartResponse{Lines: make([]*inputport.CartLine, 0, len(items))}
+	for _, item := range items {
+		line := &inputport.CartLine{Item: item}
+		resp.Lines = append(resp.Lines, line)
+
+		// 削除された商品もカートからは消さず、交換できない商品として返す
+		product, err := i.productRepo.Read(ctx, item.ProductID)
+		if err != nil {
+			continue
+		}
+		rules, err := i.pricingRuleRepo.ReadActiveForProduct(ctx, product.ID, product.CategoryCode, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pricing rules: %w", err)
+		}
+		quote := entities.ResolvePrice(product, rules, user, now)
+
+		line.Product = product
+		line.UnitPrice = quote.UnitPrice
+		line.Subtotal = quote.UnitPrice * int64(item.Quantity)
+		line.PricingRule = quote.Rule
+		line.Available = product.CanExchange(item.Quantity) == nil
+		if line.Available {
+			resp.TotalPoints += line.Subtotal
+		}
+	}
+	return resp, nil
+}
+
+// AddItem はカートに商品を追加（入っている商品なら数量を足す）
+func (i *CartInteractor) AddItem(ctx context.Context, req *inputport.AddCartItemRequest) (*inputport.CartResponse, error) {
+	if req.Quantity <= 0 {
+		return nil, entities.ErrInvalidQuantity
+	}
+
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		product, err := i.productRepo.Read(ctx, req.ProductID)
+		if err != nil {
+			return fmt.Errorf("product not found: %w", err)
+		}
+		if !product.IsAvailable {
+			return entities.ErrProductNotAvailable
+		}
+
+		now := time.Now()
+		item, err := i.cartRepo.Read(ctx, req.UserID, req.ProductID)
+		switch {
+		case err == nil:
+			if err := item.AddQuantity(req.Quantity, now); err != nil {
+				return err
+			}
+		case errors.Is(err, entities.ErrCartItemNotFound):
+			items, err := i.cartRepo.ReadByUser(ctx, req.UserID)
+			if err != nil {
+				return err
+			}
+			if len(items) >= entities.CartMaxItems {
+				return entities.ErrCartFull
+			}
+			item, err = entities.NewCartItem(req.UserID, req.ProductID, req.Quantity, now)
+			if err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+
+		// 在庫を超える数量は決済まで待たずにここで断る
+		if err := product.CanExchange(item.Quantity); err != nil {
+			return err
+		}
+		return i.cartRepo.Save(ctx, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return i.GetCart(ctx, req.UserID)
+}
+
+// UpdateItem はカートの商品の数量を変更（0なら削除）
+func (i *CartInteractor) UpdateItem(ctx context.Context, req *inputport.UpdateCartItemRequest) (*inputport.CartResponse, error) {
+	if req.Quantity == 0 {
+		return i.RemoveItem(ctx, req.UserID, req.ProductID)
+	}
+
+	item, err := i.cartRepo.Read(ctx, req.UserID, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if err := item.SetQuantity(req.Quantity, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := i.cartRepo.Save(ctx, item); err != nil {
+		return nil, err
+	}
+	return i.GetCart(ctx, req.UserID)
+}
+
+// RemoveItem はカートから商品を削除
+func (i *CartInteractor) RemoveItem(ctx context.Context, userID, productID uuid.UUID) (*inputport.CartResponse, error) {
+	if err := i.cartRepo.Delete(ctx, userID, productID); err != nil {
+		return nil, err
+	}
+	return i.GetCart(ctx, userID)
+}
+
+// checkoutLine は決済中のカートの商品1件
+type checkoutLine struct {
+	item    *entities.CartItem
+	product *entities.Product
+	quote   *entities.PriceQuote
+	points  int64
+}
+
+// Checkout はカートの商品をまとめて交換する
+//
+// ExchangeProduct と同じ確認（在庫・価格ルールの購入上限・お届け先・残高）をすべての商品に行ってから、
+// 在庫の減算、ポイントの減算（取引は1件）、商品ごとの交換記録の作成を1つのトランザクションで実行する
+// いずれかで失敗した場合はトランザクションごと取り消し、カートもそのまま残す
+func (i *CartInteractor) Checkout(ctx context.Context, req *inputport.CheckoutCartRequest) (*inputport.CheckoutCartResponse, error) {
+	i.logger.Info("Starting cart checkout", entities.NewField("user_id", req.UserID))
+
+	var user *entities.User
+	var transaction *entities.Transaction
+	var exchanges []*entities.ProductExchange
+	var totalPoints int64
+
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		items, err := i.cartRepo.ReadByUser(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return entities.ErrCartEmpty
+		}
+
+		user, err = i.userRepo.Read(ctx, req.UserID)
+		if err != nil {
+			return fmt.Errorf("user not found: %w", err)
+		}
+		if !user.IsActive {
+			return errors.New("user account is not active")
+		}
+
+		// 1. すべての商品の交換可否と価格を確認（同じセールの商品は合わせて購入上限を数える）
+		lines := make([]*checkoutLine, 0, len(items))
+		pending := map[uuid.UUID]int{}
+		for _, item := range items {
+			product, err := i.productRepo.Read(ctx, item.ProductID)
+			if err != nil {
+				return entities.ErrCartItemUnavailable.WithParams(map[string]interface{}{"product": item.ProductID.String()})
+			}
+			if err := product.CanExchange(item.Quantity); err != nil {
+				return entities.ErrCartItemUnavailable.WithParams(map[string]interface{}{"product": product.Name})
+			}
+
+			quote, err := resolveExchangePrice(ctx, i.pricingRuleRepo, i.exchangeRepo, product, user, item.Quantity, pending)
+			if err != nil {
+				return err
+			}
+			if quote.Rule != nil {
+				pending[quote.Rule.ID] += item.Quantity
+			}
+
+			line := &checkoutLine{item: item, product: product, quote: quote, points: quote.UnitPrice * int64(item.Quantity)}
+			lines = append(lines, line)
+			totalPoints += line.points
+		}
+
+		// 配送が必要な商品があればお届け先を決めておく（すべての商品に同じ住所を控える）
+		var address *entities.ShippingAddress
+		for _, line := range lines {
+			if line.product.IsDigital {
+				continue
+			}
+			address, err = resolveShippingAddress(ctx, i.addressRepo, req.UserID, req.ShippingAddressID)
+			if err != nil {
+				return err
+			}
+			break
+		}
+
+		// 2. 残高チェック（合計で1回、送金リクエストのために確保中の額は使えない）
+		if user.AvailableBalance() < totalPoints {
+			return entities.ErrInsufficientBalance.WithParams(map[string]interface{}{"balance": user.AvailableBalance(), "required": totalPoints})
+		}
+
+		// 3. 在庫を減らす
+		for _, line := range lines {
+			if err := line.product.DeductStock(line.item.Quantity); err != nil {
+				return entities.ErrCartItemUnavailable.WithParams(map[string]interface{}{"product": line.product.Name})
+			}
+			if err := i.productRepo.Update(ctx, line.product); err != nil {
+				return fmt.Errorf("failed to update product stock: %w", err)
+			}
+		}
+
+		// 4. ポイントをまとめて減らし、取引を1件作成
+		updates := []repository.BalanceUpdate{
+			{UserID: req.UserID, Amount: totalPoints, IsDeduct: true},
+		}
+		if err := i.userRepo.UpdateBalancesWithLock(ctx, updates); err != nil {
+			return fmt.Errorf("failed to deduct balance: %w", err)
+		}
+
+		transaction, err = entities.NewAdminDeduct(
+			req.UserID,
+			totalPoints,
+			fmt.Sprintf("商品交換（カート）: %d商品", len(lines)),
+			uuid.Nil, // システム処理
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
+		transaction.Metadata["cart_items"] = cartTransactionMetadata(lines)
+
+		if err := i.transactionRepo.Create(ctx, transaction); err != nil {
+			return fmt.Errorf("failed to save transaction: %w", err)
+		}
+		if err := i.pointBatchRepo.ConsumePointsFIFO(ctx, req.UserID, totalPoints); err != nil {
+			return fmt.Errorf("failed to consume point batches: %w", err)
+		}
+
+		// 5. 商品ごとの交換記録を作成（キャンセルは商品ごとにできる）
+		exchanges = make([]*entities.ProductExchange, 0, len(lines))
+		for _, line := range lines {
+			exchange, err := entities.NewProductExchange(req.UserID, line.product.ID, line.item.Quantity, line.points, req.Notes)
+			if err != nil {
+				return fmt.Errorf("failed to create exchange: %w", err)
+			}
+			if line.quote.Rule != nil {
+				exchange.PricingRuleID = &line.quote.Rule.ID
+			}
+			if err := exchange.Complete(transaction.ID); err != nil {
+				return fmt.Errorf("failed to complete exchange: %w", err)
+			}
+			if line.product.IsDigital {
+				if err := exchange.AssignRedemptionCode(); err != nil {
+					return err
+				}
+			} else {
+				exchange.ShipTo(address)
+			}
+			if err := i.exchangeRepo.Create(ctx, exchange); err != nil {
+				return fmt.Errorf("failed to save exchange: %w", err)
+			}
+			exchanges = append(exchanges, exchange)
+		}
+
+		// 6. カートを空にする
+		return i.cartRepo.DeleteByUser(ctx, req.UserID)
+	})
+	if err != nil {
+		i.logger.Error("Cart checkout failed",
+			entities.NewField("user_id", req.UserID),
+			entities.NewField("error", err))
+		return nil, err
+	}
+
+	user, _ = i.userRepo.Read(ctx, req.UserID)
+
+	i.logger.Info("Cart checkout completed",
+		entities.NewField("transaction_id", transaction.ID),
+		entities.NewField("items", len(exchanges)),
+		entities.NewField("points_used", totalPoints))
+
+	return &inputport.CheckoutCartResponse{
+		Exchanges:   exchanges,
+		Transaction: transaction,
+		User:        user,
+		TotalPoints: totalPoints,
+	}, nil
+}
+
+// cartTransactionMetadata は取引のメタデータに残す商品ごとの内訳
+func cartTransactionMetadata(lines []*checkoutLine) []map[string]interface{} {
+	items := make([]map[string]interface{}, len(lines))
+	for n, line := range lines {
+		item := map[string]interface{}{
+			"product_id": line.product.ID.String(),
+			"quantity":   line.item.Quantity,
+			"unit_price": line.quote.UnitPrice,
+		}
+		for k, v := range line.quote.TransactionMetadata() {
+			item[k] = v
+		}
+		items[n] = item
+	}
+	return items
+}
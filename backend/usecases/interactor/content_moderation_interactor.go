@@ -0,0 +1,151 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultViolationListLimit = 20
+	maxViolationListLimit     = 100
+)
+
+// ContentModerationInteractor はユーザー入力テキストのモデレーションユースケース実装
+type ContentModerationInteractor struct {
+	moderator     service.ContentModerator
+	violationRepo repository.ContentViolationRepository
+	userRepo      repository.UserRepository
+	logger        entities.Logger
+}
+
+// NewContentModerationInteractor は新しいContentModerationInteractorを作成
+func NewContentModerationInteractor(
+	moderator service.ContentModerator,
+	violationRepo repository.ContentViolationRepository,
+	userRepo repository.UserRepository,
+	logger entities.Logger,
+) inputport.ContentModerationInputPort {
+	return &ContentModerationInteractor{
+		moderator:     moderator,
+		violationRepo: violationRepo,
+		userRepo:      userRepo,
+		logger:        logger,
+	}
+}
+
+// CheckContent はテキストを判定する
+// 不許可の場合は管理者レビュー用に違反を記録し、項目名付きのErrContentRejectedを返す
+func (i *ContentModerationInteractor) CheckContent(ctx context.Context, userID uuid.UUID, field entities.ModerationField, text string) error {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	verdict, err := i.moderator.Moderate(ctx, field, text)
+	if err != nil {
+		return fmt.Errorf("failed to moderate content: %w", err)
+	}
+	if verdict.Allowed {
+		return nil
+	}
+
+	i.logger.Warn("Content rejected by moderation",
+		entities.NewField("user_id", userID),
+		entities.NewField("field", field),
+		entities.NewField("matched_terms", verdict.MatchedTerms))
+
+	// 記録に失敗しても入力の拒否は変えない
+	violation := entities.NewContentViolation(userID, field, text, verdict.MatchedTerms)
+	if err := i.violationRepo.Create(ctx, violation); err != nil {
+		i.logger.Error("Failed to record content violation",
+			entities.NewField("user_id", userID),
+			entities.NewField("error", err))
+	}
+
+	return entities.ErrContentRejected.WithParams(map[string]interface{}{
+		"field": string(field),
+	})
+}
+
+// GetViolations は違反記録の一覧を取得（管理者のみ）
+func (i *ContentModerationInteractor) GetViolations(ctx context.Context, req *inputport.GetContentViolationsRequest) (*inputport.GetContentViolationsResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultViolationListLimit
+	}
+	if limit > maxViolationListLimit {
+		limit = maxViolationListLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	violations, err := i.violationRepo.ReadList(ctx, req.UnreviewedOnly, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content violations: %w", err)
+	}
+	total, err := i.violationRepo.Count(ctx, req.UnreviewedOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count content violations: %w", err)
+	}
+
+	return &inputport.GetContentViolationsResponse{
+		Violations: violations,
+		Total:      total,
+		Offset:     offset,
+		Limit:      limit,
+	}, nil
+}
+
+// ReviewViolation は違反記録をレビュー済みにする（管理者のみ）
+func (i *ContentModerationInteractor) ReviewViolation(ctx context.Context, req *inputport.ReviewContentViolationRequest) (*entities.ContentViolation, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	violation, err := i.violationRepo.Read(ctx, req.ViolationID)
+	if err != nil {
+		return nil, err
+	}
+	if violation.IsReviewed() {
+		return violation, nil
+	}
+
+	violation.MarkReviewed(req.AdminID)
+	if err := i.violationRepo.UpdateReviewed(ctx, violation); err != nil {
+		return nil, fmt.Errorf("failed to update content violation: %w", err)
+	}
+
+	i.logger.Info("Content violation reviewed",
+		entities.NewField("violation_id", violation.ID),
+		entities.NewField("admin_id", req.AdminID))
+
+	return violation, nil
+}
+
+// moderationTarget はモデレーション対象の項目とテキストの組
+type moderationTarget struct {
+	field entities.ModerationField
+	text  string
+}
+
+// checkContents は複数の項目を順に判定し、最初に拒否された項目のエラーを返す
+func checkContents(ctx context.Context, moderation inputport.ContentModerationInputPort, userID uuid.UUID, targets ...moderationTarget) error {
+	for _, t := range targets {
+		if err := moderation.CheckContent(ctx, userID, t.field, t.text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package interactor
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+// CSRFInteractor はCSRFトークンのユースケース実装
+type CSRFInteractor struct {
+	sessionRepo repository.SessionRepository
+	policy      entities.CSRFPolicy
+	logger      entities.Logger
+}
+
+// NewCSRFInteractor は新しいCSRFInteractorを作成
+func NewCSRFInteractor(
+	sessionRepo repository.SessionRepository,
+	policy entities.CSRFPolicy,
+	logger entities.Logger,
+) inputport.CSRFInputPort {
+	return &CSRFInteractor{
+		sessionRepo: sessionRepo,
+		policy:      policy,
+		logger:      logger,
+	}
+}
+
+// VerifyToken は状態を変えるリクエストのCSRFトークンを検証し、次のリクエストで使うトークンを返す
+func (i *CSRFInteractor) VerifyToken(ctx context.Context, req *inputport.VerifyCSRFTokenRequest) (*inputport.CSRFTokenResponse, error) {
+	session := req.Session
+	if err := session.ValidateCSRF(req.Token, i.policy, req.Now); err != nil {
+		return nil, err
+	}
+
+	// 入れ替える前のトークンで来たリクエスト（入れ替えと並行したリクエスト）では入れ替えない
+	if i.policy.Rotation != entities.CSRFRotationPerRequest || req.Token != session.CSRFToken {
+		return i.response(session, false), nil
+	}
+
+	// リクエスト自体は検証済みのため、入れ替えに失敗しても今のトークンのまま続ける
+	rotated, err := i.rotate(ctx, session, req.Now)
+	if err != nil {
+		i.logger.Warn("Failed to rotate CSRF token",
+			entities.NewField("session_id", session.ID),
+			entities.NewField("error", err))
+		return i.response(session, false), nil
+	}
+	return i.response(rotated, true), nil
+}
+
+// IssueToken は今のCSRFトークンを返す（期限切れなら入れ替える）
+func (i *CSRFInteractor) IssueToken(ctx context.Context, req *inputport.IssueCSRFTokenRequest) (*inputport.CSRFTokenResponse, error) {
+	session := req.Session
+	if !i.policy.Expired(session.CSRFIssuedAt, req.Now) {
+		return i.response(session, false), nil
+	}
+
+	rotated, err := i.rotate(ctx, session, req.Now)
+	if err != nil {
+		return nil, err
+	}
+	return i.response(rotated, true), nil
+}
+
+// rotate はCSRFトークンを入れ替えて保存し、入れ替えた後のセッションを返す
+// 並行したリクエストが先に入れ替えていたら、そちらのトークンを使う
+func (i *CSRFInteractor) rotate(ctx context.Context, session *entities.Session, now time.Time) (*entities.Session, error) {
+	rotated := *session
+	if err := rotated.RotateCSRF(now); err != nil {
+		return nil, err
+	}
+
+	ok, err := i.sessionRepo.RotateCSRFToken(ctx, &rotated, session.CSRFToken)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return i.sessionRepo.Read(ctx, session.ID)
+	}
+	return &rotated, nil
+}
+
+// response は次のリクエストで使うトークンを作成
+func (i *CSRFInteractor) response(session *entities.Session, rotated bool) *inputport.CSRFTokenResponse {
+	resp := &inputport.CSRFTokenResponse{Token: session.CSRFToken, Rotated: rotated}
+	if expiresAt, ok := i.policy.ExpiresAt(session.CSRFIssuedAt); ok {
+		resp.ExpiresAt = &expiresAt
+	}
+	return resp
+}
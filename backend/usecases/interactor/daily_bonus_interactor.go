@@ -2,8 +2,11 @@ package interactor
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
+	"io"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gity/point-system/entities"
@@ -12,6 +15,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// failedAccessRetryBatchSize は1回の再試行で処理する失敗アクセス記録の上限
+const failedAccessRetryBatchSize = 100
+
 // DailyBonusInteractor はデイリーボーナスの統合インタラクター
 // HTTP API 向けの参照メソッドと、AkerunWorker 向けのボーナス付与メソッドを両方提供する
 type DailyBonusInteractor struct {
@@ -22,6 +28,10 @@ type DailyBonusInteractor struct {
 	systemSettingsRepo repository.SystemSettingsRepository
 	pointBatchRepo     repository.PointBatchRepository
 	lotteryTierRepo    repository.LotteryTierRepository
+	failedAccessRepo   repository.FailedAkerunAccessRepository
+	processedRepo      repository.ProcessedAkerunAccessRepository
+	organizations      entities.AkerunOrganizations
+	referrals          inputport.ReferralTracker
 	logger             entities.Logger
 }
 
@@ -34,6 +44,10 @@ func NewDailyBonusInteractor(
 	systemSettingsRepo repository.SystemSettingsRepository,
 	pointBatchRepo repository.PointBatchRepository,
 	lotteryTierRepo repository.LotteryTierRepository,
+	failedAccessRepo repository.FailedAkerunAccessRepository,
+	processedRepo repository.ProcessedAkerunAccessRepository,
+	organizations entities.AkerunOrganizations,
+	referrals inputport.ReferralTracker,
 	logger entities.Logger,
 ) *DailyBonusInteractor {
 	return &DailyBonusInteractor{
@@ -44,6 +58,10 @@ func NewDailyBonusInteractor(
 		systemSettingsRepo: systemSettingsRepo,
 		pointBatchRepo:     pointBatchRepo,
 		lotteryTierRepo:    lotteryTierRepo,
+		failedAccessRepo:   failedAccessRepo,
+		processedRepo:      processedRepo,
+		organizations:      organizations,
+		referrals:          referrals,
 		logger:             logger,
 	}
 }
@@ -54,8 +72,8 @@ func NewDailyBonusInteractor(
 
 // GetTodayBonus は本日のボーナス状況を取得
 func (i *DailyBonusInteractor) GetTodayBonus(ctx context.Context, req *inputport.GetTodayBonusRequest) (*inputport.GetTodayBonusResponse, error) {
-	// 今日のボーナス日付を計算（JST AM6:00区切り）
-	bonusDate := entities.GetBonusDateJST(time.Now())
+	// 今日のボーナス日付を計算（ユーザーのタイムゾーンのAM6:00区切り）
+	bonusDate := entities.GetBonusDate(time.Now(), i.userBonusLocation(ctx, req.UserID))
 
 	// 今日のボーナスを取得
 	bonus, err := i.dailyBonusRepo.ReadByUserAndDate(ctx, req.UserID, bonusDate)
@@ -114,9 +132,27 @@ func (i *DailyBonusInteractor) GetBonusSettings(ctx context.Context) (*inputport
 	return &inputport.BonusSettingsResponse{
 		BonusPoints:  i.getBonusPoints(ctx),
 		LotteryTiers: tiers,
+		Timezone:     systemBonusLocation(ctx, i.systemSettingsRepo, i.logger).String(),
 	}, nil
 }
 
+// UpdateBonusTimezone はボーナス日の区切りに使うタイムゾーンを変更（管理者用）
+// タイムゾーンを設定していないユーザーの次のボーナス日から適用される
+func (i *DailyBonusInteractor) UpdateBonusTimezone(ctx context.Context, req *inputport.UpdateBonusTimezoneRequest) error {
+	if req.Timezone == "" {
+		return entities.ErrInvalidTimezone
+	}
+	if _, err := entities.LoadBonusLocation(req.Timezone); err != nil {
+		return err
+	}
+	if err := i.systemSettingsRepo.SetSetting(ctx, entities.BonusTimezoneSettingKey, req.Timezone, "ボーナス日の区切り（AM6:00）に使うタイムゾーン"); err != nil {
+		return fmt.Errorf("failed to update bonus timezone: %w", err)
+	}
+
+	i.logger.Info("Bonus timezone updated", entities.NewField("timezone", req.Timezone))
+	return nil
+}
+
 // UpdateLotteryTiers は抽選ティアを一括更新（管理者用）
 func (i *DailyBonusInteractor) UpdateLotteryTiers(ctx context.Context, req *inputport.UpdateLotteryTiersRequest) error {
 	tiers := make([]*entities.LotteryTier, len(req.Tiers))
@@ -132,7 +168,7 @@ func (i *DailyBonusInteractor) UpdateLotteryTiers(ctx context.Context, req *inpu
 // MarkBonusViewed はボーナスを閲覧済みにする
 func (i *DailyBonusInteractor) MarkBonusViewed(ctx context.Context, req *inputport.MarkBonusViewedRequest) error {
 	// ボーナスの所有者チェック
-	bonusDate := entities.GetBonusDateJST(time.Now())
+	bonusDate := entities.GetBonusDate(time.Now(), i.userBonusLocation(ctx, req.UserID))
 	bonus, err := i.dailyBonusRepo.ReadByUserAndDate(ctx, req.UserID, bonusDate)
 	if err != nil {
 		return err
@@ -153,9 +189,6 @@ func (i *DailyBonusInteractor) MarkBonusViewed(ctx context.Context, req *inputpo
 
 // DrawLotteryAndGrant はルーレットを実行しポイントを付与する（Phase 2: ユーザーがルーレットを回した時）
 func (i *DailyBonusInteractor) DrawLotteryAndGrant(ctx context.Context, req *inputport.DrawLotteryRequest) (*inputport.DrawLotteryResponse, error) {
-	// 今日のボーナス日付を計算
-	bonusDate := entities.GetBonusDateJST(time.Now())
-
 	// アクティブな抽選ティアを取得（トランザクション外で取得OK）
 	lotteryTiers, err := i.lotteryTierRepo.ReadActive(ctx)
 	if err != nil {
@@ -163,6 +196,19 @@ func (i *DailyBonusInteractor) DrawLotteryAndGrant(ctx context.Context, req *inp
 		lotteryTiers = nil
 	}
 
+	// 会員ランクに応じて当たりの確率を上げる（ユーザーが取得できなければ通常の確率のまま）
+	systemLoc := systemBonusLocation(ctx, i.systemSettingsRepo, i.logger)
+	bonusLoc := systemLoc
+	if user, err := i.userRepo.Read(ctx, req.UserID); err == nil {
+		lotteryTiers = entities.BoostLotteryTiers(lotteryTiers, user.CurrentTier().LotteryBoost())
+		bonusLoc = bonusLocationFor(user, systemLoc)
+	} else {
+		i.logger.Warn("DrawLotteryAndGrant: failed to read user for tier boost", entities.NewField("error", err))
+	}
+
+	// 今日のボーナス日付を計算（ユーザーのタイムゾーンのAM6:00区切り）
+	bonusDate := entities.GetBonusDate(time.Now(), bonusLoc)
+
 	var bonusPoints int64
 	var lotteryTierID *uuid.UUID
 	var lotteryTierName string
@@ -191,6 +237,8 @@ func (i *DailyBonusInteractor) DrawLotteryAndGrant(ctx context.Context, req *inp
 		// くじ引き実行
 		fallbackPoints := i.getFallbackPoints(lotteryTiers, ctx)
 		bonusPoints, lotteryTierID, lotteryTierName = i.drawLottery(lotteryTiers, fallbackPoints, req.UserID, bonus.AkerunUserName)
+		// 入退室したドアの倍率を掛ける
+		bonusPoints = bonus.ApplyMultiplier(bonusPoints)
 
 		// 抽選結果を更新
 		if err := i.dailyBonusRepo.UpdateDrawnResult(ctx, bonus.ID, bonusPoints, lotteryTierID, lotteryTierName); err != nil {
@@ -248,77 +296,305 @@ func (i *DailyBonusInteractor) DrawLotteryAndGrant(ctx context.Context, req *inp
 }
 
 // ProcessAccesses はアクセス記録を処理して未抽選ボーナスを作成する（Phase 1: アクセス記録のみ）
+// DB障害などで作成できなかったアクセス記録は再試行キューに登録し、RetryFailedAccessesで再処理する
+// 処理したアクセス記録はIDを記録し、次に受け取っても飛ばす
 func (i *DailyBonusInteractor) ProcessAccesses(ctx context.Context, accesses []entities.AccessRecord) error {
+	// 処理済みのアクセス記録は飛ばす（ボーナスの付与規則によらず、取得期間が重なっても二度処理しない）
+	accesses = i.skipProcessedAccesses(ctx, accesses)
+	if len(accesses) == 0 {
+		return nil
+	}
+
 	// 全ユーザーを取得してマッチング用マップを構築
 	nameToUser := i.buildUserNameMap(ctx)
 	if nameToUser == nil {
-		return fmt.Errorf("failed to build user name map")
+		// ポーリング時刻は進むため、マッチングできなかったアクセス記録はすべて再試行キューに回す
+		err := fmt.Errorf("failed to build user name map")
+		var handled []entities.AccessRecord
+		for _, access := range accesses {
+			if access.UserName == "" || i.enqueueFailedAccess(ctx, access, err) {
+				handled = append(handled, access)
+			}
+		}
+		i.markAccessesProcessed(ctx, handled)
+		return err
 	}
 
+	systemLoc := systemBonusLocation(ctx, i.systemSettingsRepo, i.logger)
+	handled := make([]entities.AccessRecord, 0, len(accesses))
 	for _, access := range accesses {
-		if access.UserName == "" {
-			continue
+		if err := i.createPendingBonus(ctx, nameToUser, systemLoc, access); err != nil {
+			if !i.enqueueFailedAccess(ctx, access, err) {
+				// 再試行キューにも登録できなければ処理済みにしない
+				continue
+			}
 		}
+		handled = append(handled, access)
+	}
+	i.markAccessesProcessed(ctx, handled)
 
-		// Akerunユーザー名を正規化
-		akerunName := entities.NormalizeName(access.UserName)
+	return nil
+}
 
-		// アプリユーザーとマッチング
-		userID, matched := nameToUser[akerunName]
-		if !matched {
-			continue
+// skipProcessedAccesses は処理済みのアクセス記録を除く
+// 処理済みかを確かめられない場合はすべて処理する（同じ日のボーナスは作り直さないため、重複して付与はしない）
+func (i *DailyBonusInteractor) skipProcessedAccesses(ctx context.Context, accesses []entities.AccessRecord) []entities.AccessRecord {
+	if len(accesses) == 0 {
+		return accesses
+	}
+	ids := make([]uuid.UUID, len(accesses))
+	for n, access := range accesses {
+		ids[n] = access.ID
+	}
+	processed, err := i.processedRepo.ReadProcessedIDs(ctx, ids)
+	if err != nil {
+		i.logger.Error("DailyBonusInteractor: failed to read processed accesses", entities.NewField("error", err))
+		return accesses
+	}
+
+	unprocessed := make([]entities.AccessRecord, 0, len(accesses))
+	for _, access := range accesses {
+		if !processed[access.ID] {
+			unprocessed = append(unprocessed, access)
 		}
+	}
+	if skipped := len(accesses) - len(unprocessed); skipped > 0 {
+		i.logger.Info("DailyBonusInteractor: skipped processed accesses", entities.NewField("count", skipped))
+	}
+	return unprocessed
+}
+
+// markAccessesProcessed はボーナスを作成した・作成しなかった・再試行キューに回したアクセス記録を処理済みにする
+func (i *DailyBonusInteractor) markAccessesProcessed(ctx context.Context, accesses []entities.AccessRecord) {
+	if len(accesses) == 0 {
+		return
+	}
+	now := time.Now()
+	processed := make([]*entities.ProcessedAkerunAccess, len(accesses))
+	for n, access := range accesses {
+		processed[n] = entities.NewProcessedAkerunAccess(access, now)
+	}
+	if err := i.processedRepo.Create(ctx, processed); err != nil {
+		i.logger.Error("DailyBonusInteractor: failed to mark accesses processed",
+			entities.NewField("count", len(accesses)),
+			entities.NewField("error", err))
+	}
+}
 
-		// ボーナス日付を計算（JST AM6:00区切り）
-		bonusDate := entities.GetBonusDateJST(access.AccessedAt)
+// RetryFailedAccesses は再試行時刻を迎えた失敗アクセス記録を再処理する
+// 作成済みのボーナスは作り直さないため、同じアクセス記録を何度処理しても付与は1回だけ
+func (i *DailyBonusInteractor) RetryFailedAccesses(ctx context.Context, now time.Time) error {
+	due, err := i.failedAccessRepo.ReadDue(ctx, now, failedAccessRetryBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to read failed accesses: %w", err)
+	}
+	if len(due) == 0 {
+		return nil
+	}
 
-		// 既にボーナス付与済みかチェック
-		existing, err := i.dailyBonusRepo.ReadByUserAndDate(ctx, userID, bonusDate)
-		if err != nil {
-			i.logger.Error("DailyBonusInteractor: failed to check existing bonus",
-				entities.NewField("user_id", userID),
-				entities.NewField("error", err))
+	// ユーザー一覧を取得できない場合は失敗回数を数えず、次回に持ち越す
+	nameToUser := i.buildUserNameMap(ctx)
+	if nameToUser == nil {
+		return fmt.Errorf("failed to build user name map")
+	}
+
+	systemLoc := systemBonusLocation(ctx, i.systemSettingsRepo, i.logger)
+	for _, failed := range due {
+		if err := i.createPendingBonus(ctx, nameToUser, systemLoc, failed.Access()); err != nil {
+			failed.RecordFailure(err, now)
+			if updateErr := i.failedAccessRepo.Update(ctx, failed); updateErr != nil {
+				i.logger.Error("DailyBonusInteractor: failed to record retry failure",
+					entities.NewField("access_id", failed.AccessID),
+					entities.NewField("error", updateErr))
+				continue
+			}
+			if failed.IsDead() {
+				i.logger.Warn("DailyBonusInteractor: failed access moved to dead letter",
+					entities.NewField("access_id", failed.AccessID),
+					entities.NewField("akerun_user", failed.UserName),
+					entities.NewField("attempts", failed.Attempts),
+					entities.NewField("error", err))
+			}
 			continue
 		}
 
-		if existing != nil {
+		if err := i.failedAccessRepo.Delete(ctx, failed.ID); err != nil {
+			i.logger.Error("DailyBonusInteractor: failed to dequeue failed access",
+				entities.NewField("access_id", failed.AccessID),
+				entities.NewField("error", err))
 			continue
 		}
+		i.logger.Info("DailyBonusInteractor: failed access reprocessed",
+			entities.NewField("access_id", failed.AccessID),
+			entities.NewField("akerun_user", failed.UserName),
+			entities.NewField("attempts", failed.Attempts))
+	}
 
-		// 未抽選のボーナスレコードを作成（ポイント未確定）
-		accessedAt := access.AccessedAt
-		accessIDStr := access.ID.String()
-		bonus := entities.NewPendingDailyBonus(userID, bonusDate, accessIDStr, access.UserName, &accessedAt)
-		if err := i.dailyBonusRepo.Create(ctx, bonus); err != nil {
-			i.logger.Error("DailyBonusInteractor: failed to create pending bonus",
-				entities.NewField("user_id", userID),
-				entities.NewField("akerun_user", access.UserName),
-				entities.NewField("error", err))
-		} else {
-			i.logger.Info("DailyBonusInteractor: pending bonus created",
-				entities.NewField("user_id", userID),
-				entities.NewField("akerun_user", access.UserName),
-				entities.NewField("date", bonusDate.Format("2006-01-02")))
+	return nil
+}
+
+// ListFailedAccesses はボーナスの付与に失敗した入退室記録を新しい順に取得（管理者用）
+func (i *DailyBonusInteractor) ListFailedAccesses(ctx context.Context, req *inputport.ListFailedAccessesRequest) (*inputport.ListFailedAccessesResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	accesses, err := i.failedAccessRepo.ReadList(ctx, req.Status, req.Offset, req.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failed accesses: %w", err)
+	}
+	total, err := i.failedAccessRepo.Count(ctx, req.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count failed accesses: %w", err)
+	}
+	return &inputport.ListFailedAccessesResponse{
+		Accesses: accesses,
+		Total:    total,
+	}, nil
+}
+
+// RequeueFailedAccess は再試行の上限に達した入退室記録を再試行待ちに戻す（管理者用）
+// 次のポーリングで再処理される
+func (i *DailyBonusInteractor) RequeueFailedAccess(ctx context.Context, req *inputport.RequeueFailedAccessRequest) (*entities.FailedAkerunAccess, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	failed, err := i.failedAccessRepo.Read(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := failed.Requeue(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := i.failedAccessRepo.Update(ctx, failed); err != nil {
+		return nil, fmt.Errorf("failed to requeue failed access: %w", err)
+	}
+
+	i.logger.Info("DailyBonusInteractor: failed access requeued",
+		entities.NewField("access_id", failed.AccessID),
+		entities.NewField("admin_id", req.AdminID))
+	return failed, nil
+}
+
+// bonusExportHeader はボーナスのCSVのヘッダー
+var bonusExportHeader = []string{
+	"user_id", "username", "display_name", "bonus_date", "points", "tier", "drawn",
+	"organization_id", "door_id", "door_name", "accessed_at",
+}
+
+// ExportBonuses はボーナス日が期間内のボーナスをCSVでwへ書き出す（管理者用）
+// Excelで開いても文字化けしないよう先頭にBOMを付け、入退室日時はボーナス日のタイムゾーンで出力する
+func (i *DailyBonusInteractor) ExportBonuses(ctx context.Context, req *inputport.ExportBonusesRequest, w io.Writer) error {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return err
+	}
+	period, err := entities.NewBonusReportPeriod(req.From, req.To)
+	if err != nil {
+		return err
+	}
+	loc := systemBonusLocation(ctx, i.systemSettingsRepo, i.logger)
+
+	if _, err := io.WriteString(w, "\uFEFF"); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(bonusExportHeader); err != nil {
+		return err
+	}
+
+	var rows int
+	err = i.dailyBonusRepo.StreamByPeriod(ctx, period, func(r *entities.DailyBonusRecord) error {
+		accessedAt := ""
+		if r.AccessedAt != nil {
+			accessedAt = r.AccessedAt.In(loc).Format(time.RFC3339)
 		}
+		rows++
+		return cw.Write([]string{
+			r.UserID.String(),
+			csvSafe(r.Username),
+			csvSafe(r.DisplayName),
+			r.BonusDate.Format("2006-01-02"),
+			strconv.FormatInt(r.BonusPoints, 10),
+			csvSafe(r.LotteryTierName),
+			strconv.FormatBool(r.IsDrawn),
+			csvSafe(r.AkerunOrganizationID),
+			csvSafe(r.AkerunDoorID),
+			csvSafe(r.AkerunDoorName),
+			accessedAt,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export bonuses: %w", err)
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
 	}
 
+	i.logger.Info("DailyBonusInteractor: bonuses exported",
+		entities.NewField("from", req.From),
+		entities.NewField("to", req.To),
+		entities.NewField("rows", rows))
 	return nil
 }
 
-// GetLastPolledAt は前回ポーリング時刻を取得する
-func (i *DailyBonusInteractor) GetLastPolledAt(ctx context.Context) (time.Time, error) {
-	return i.dailyBonusRepo.GetLastPolledAt(ctx)
+// GetAttendanceMatrix は月のユーザーごと・日ごとの出席表を取得（管理者用）
+func (i *DailyBonusInteractor) GetAttendanceMatrix(ctx context.Context, req *inputport.GetAttendanceMatrixRequest) (*entities.AttendanceMatrix, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	period, err := entities.NewBonusReportMonth(req.Month)
+	if err != nil {
+		return nil, err
+	}
+	matrix := entities.NewAttendanceMatrix(period)
+	err = i.dailyBonusRepo.StreamByPeriod(ctx, period, func(r *entities.DailyBonusRecord) error {
+		matrix.Add(r)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build attendance matrix: %w", err)
+	}
+	return matrix, nil
 }
 
-// UpdateLastPolledAt はポーリング時刻を更新する
-func (i *DailyBonusInteractor) UpdateLastPolledAt(ctx context.Context, t time.Time) error {
-	return i.dailyBonusRepo.UpdateLastPolledAt(ctx, t)
+// csvSafe は表計算ソフトで数式として解釈される文字で始まる値の先頭に ' を付ける
+func csvSafe(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
+
+// GetLastPolledAt は組織の前回ポーリング時刻を取得する
+func (i *DailyBonusInteractor) GetLastPolledAt(ctx context.Context, organizationID string) (time.Time, error) {
+	return i.dailyBonusRepo.GetLastPolledAt(ctx, organizationID)
+}
+
+// UpdateLastPolledAt は組織のポーリング時刻を更新する
+func (i *DailyBonusInteractor) UpdateLastPolledAt(ctx context.Context, organizationID string, t time.Time) error {
+	return i.dailyBonusRepo.UpdateLastPolledAt(ctx, organizationID, t)
 }
 
 // ========================================
 // プライベートヘルパー
 // ========================================
 
+// userBonusLocation はユーザーのボーナス日のタイムゾーンを返す
+// ユーザーを取得できない場合はsystem_settingsのタイムゾーンを使う
+func (i *DailyBonusInteractor) userBonusLocation(ctx context.Context, userID uuid.UUID) *time.Location {
+	systemLoc := systemBonusLocation(ctx, i.systemSettingsRepo, i.logger)
+	user, err := i.userRepo.Read(ctx, userID)
+	if err != nil {
+		i.logger.Warn("DailyBonusInteractor: failed to read user for bonus timezone",
+			entities.NewField("user_id", userID),
+			entities.NewField("error", err))
+		return systemLoc
+	}
+	return bonusLocationFor(user, systemLoc)
+}
+
 // getBonusPoints は現在のボーナスポイント設定を取得（フォールバック用）
 func (i *DailyBonusInteractor) getBonusPoints(ctx context.Context) int64 {
 	pointsStr, err := i.systemSettingsRepo.GetSetting(ctx, "akerun_bonus_points")
@@ -340,24 +616,104 @@ func (i *DailyBonusInteractor) getFallbackPoints(lotteryTiers []*entities.Lotter
 	return i.getBonusPoints(ctx)
 }
 
-// buildUserNameMap は全ユーザーを取得し正規化名→UserIDのマップを構築する
-func (i *DailyBonusInteractor) buildUserNameMap(ctx context.Context) map[string]uuid.UUID {
+// createPendingBonus は1件のアクセス記録から未抽選ボーナスを作成する
+// マッチしないユーザー・対象外のドア・作成済みの日は何もせずnilを返し、DBエラーのときだけエラーを返す
+// ボーナス日はユーザーのタイムゾーン（未設定ならsystemLoc）で決める
+func (i *DailyBonusInteractor) createPendingBonus(ctx context.Context, nameToUser map[string]*entities.User, systemLoc *time.Location, access entities.AccessRecord) error {
+	if access.UserName == "" {
+		return nil
+	}
+
+	// ボーナスの対象にしていないドアは数えない
+	rule, counts := i.organizations.RuleFor(access)
+	if !counts {
+		return nil
+	}
+
+	// Akerunユーザー名を正規化
+	akerunName := entities.NormalizeName(access.UserName)
+
+	// アプリユーザーとマッチング
+	user, matched := nameToUser[akerunName]
+	if !matched {
+		return nil
+	}
+	userID := user.ID
+
+	// ボーナス日付を計算（ユーザーのタイムゾーンのAM6:00区切り）
+	bonusDate := entities.GetBonusDate(access.AccessedAt, bonusLocationFor(user, systemLoc))
+
+	// 既にボーナス付与済みかチェック
+	existing, err := i.dailyBonusRepo.ReadByUserAndDate(ctx, userID, bonusDate)
+	if err != nil {
+		i.logger.Error("DailyBonusInteractor: failed to check existing bonus",
+			entities.NewField("user_id", userID),
+			entities.NewField("error", err))
+		return fmt.Errorf("failed to check existing bonus: %w", err)
+	}
+
+	if existing != nil {
+		return nil
+	}
+
+	// 未抽選のボーナスレコードを作成（ポイント未確定）
+	accessedAt := access.AccessedAt
+	accessIDStr := access.ID.String()
+	bonus := entities.NewPendingDailyBonus(userID, bonusDate, accessIDStr, access.UserName, &accessedAt).WithDoor(access, rule)
+	if err := i.dailyBonusRepo.Create(ctx, bonus); err != nil {
+		i.logger.Error("DailyBonusInteractor: failed to create pending bonus",
+			entities.NewField("user_id", userID),
+			entities.NewField("akerun_user", access.UserName),
+			entities.NewField("error", err))
+		return fmt.Errorf("failed to create pending bonus: %w", err)
+	}
+
+	i.logger.Info("DailyBonusInteractor: pending bonus created",
+		entities.NewField("user_id", userID),
+		entities.NewField("akerun_user", access.UserName),
+		entities.NewField("door", access.DoorName),
+		entities.NewField("date", bonusDate.Format("2006-01-02")))
+
+	// 紹介で登録したユーザーは初回チェックインで紹介特典の対象になる
+	i.referrals.CompleteQualifyingAction(ctx, userID)
+	return nil
+}
+
+// enqueueFailedAccess は付与に失敗したアクセス記録を再試行キューに登録し、登録できたかを返す
+func (i *DailyBonusInteractor) enqueueFailedAccess(ctx context.Context, access entities.AccessRecord, cause error) bool {
+	failed := entities.NewFailedAkerunAccess(access, cause, time.Now())
+	if err := i.failedAccessRepo.Enqueue(ctx, failed); err != nil {
+		i.logger.Error("DailyBonusInteractor: failed to enqueue failed access",
+			entities.NewField("access_id", access.ID),
+			entities.NewField("akerun_user", access.UserName),
+			entities.NewField("error", err))
+		return false
+	}
+	i.logger.Warn("DailyBonusInteractor: access queued for retry",
+		entities.NewField("access_id", access.ID),
+		entities.NewField("akerun_user", access.UserName),
+		entities.NewField("next_retry_at", failed.NextRetryAt.Format(time.RFC3339)))
+	return true
+}
+
+// buildUserNameMap は全ユーザーを取得し正規化名→ユーザーのマップを構築する
+func (i *DailyBonusInteractor) buildUserNameMap(ctx context.Context) map[string]*entities.User {
 	users, err := i.userRepo.ReadList(ctx, 0, 10000)
 	if err != nil {
 		i.logger.Error("DailyBonusInteractor: failed to get users", entities.NewField("error", err))
 		return nil
 	}
 
-	nameToUser := make(map[string]uuid.UUID)
+	nameToUser := make(map[string]*entities.User)
 	for _, user := range users {
 		if user.LastName != "" && user.FirstName != "" {
 			// "田中太郎" 形式
 			fullName := entities.NormalizeName(user.LastName + user.FirstName)
-			nameToUser[fullName] = user.ID
+			nameToUser[fullName] = user
 
 			// "田中 太郎" 形式（スペース区切り）もカバー
 			fullNameWithSpace := entities.NormalizeName(user.LastName + " " + user.FirstName)
-			nameToUser[fullNameWithSpace] = user.ID
+			nameToUser[fullNameWithSpace] = user
 		}
 	}
 
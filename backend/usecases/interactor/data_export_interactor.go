@@ -0,0 +1,355 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+)
+
+// dataExportPageSize はエクスポート作成時に一度に読み込む件数
+const dataExportPageSize = 200
+
+// DataExportInteractor は個人データエクスポートのユースケース実装
+type DataExportInteractor struct {
+	dataExportRepo      repository.DataExportRepository
+	userRepo            repository.UserRepository
+	transactionRepo     repository.TransactionRepository
+	dailyBonusRepo      repository.DailyBonusRepository
+	friendshipRepo      repository.FriendshipRepository
+	productExchangeRepo repository.ProductExchangeRepository
+	storage             service.DataExportStorage
+	emailService        service.EmailService
+	logger              entities.Logger
+}
+
+// NewDataExportInteractor は新しいDataExportInteractorを作成
+func NewDataExportInteractor(
+	dataExportRepo repository.DataExportRepository,
+	userRepo repository.UserRepository,
+	transactionRepo repository.TransactionRepository,
+	dailyBonusRepo repository.DailyBonusRepository,
+	friendshipRepo repository.FriendshipRepository,
+	productExchangeRepo repository.ProductExchangeRepository,
+	storage service.DataExportStorage,
+	emailService service.EmailService,
+	logger entities.Logger,
+) inputport.DataExportInputPort {
+	return &DataExportInteractor{
+		dataExportRepo:      dataExportRepo,
+		userRepo:            userRepo,
+		transactionRepo:     transactionRepo,
+		dailyBonusRepo:      dailyBonusRepo,
+		friendshipRepo:      friendshipRepo,
+		productExchangeRepo: productExchangeRepo,
+		storage:             storage,
+		emailService:        emailService,
+		logger:              logger,
+	}
+}
+
+// RequestExport はエクスポートを依頼する
+func (i *DataExportInteractor) RequestExport(ctx context.Context, userID uuid.UUID) (*entities.DataExport, error) {
+	latest, err := i.dataExportRepo.ReadLatestByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest data export: %w", err)
+	}
+	if latest != nil {
+		if latest.IsInProgress() {
+			return nil, entities.ErrDataExportInProgress
+		}
+		if !latest.CanRequestNext(time.Now()) {
+			return nil, entities.ErrDataExportTooSoon
+		}
+	}
+
+	export := entities.NewDataExport(userID)
+	if err := i.dataExportRepo.Create(ctx, export); err != nil {
+		return nil, fmt.Errorf("failed to create data export: %w", err)
+	}
+
+	i.logger.Info("Data export requested",
+		entities.NewField("user_id", userID),
+		entities.NewField("export_id", export.ID))
+
+	return export, nil
+}
+
+// GetLatestExport はユーザーの最新のエクスポート依頼を取得
+func (i *DataExportInteractor) GetLatestExport(ctx context.Context, userID uuid.UUID) (*entities.DataExport, error) {
+	return i.dataExportRepo.ReadLatestByUser(ctx, userID)
+}
+
+// OpenExport は作成済みのエクスポートファイルを開く
+func (i *DataExportInteractor) OpenExport(ctx context.Context, userID, exportID uuid.UUID) (*inputport.OpenDataExportResponse, error) {
+	export, err := i.dataExportRepo.Read(ctx, exportID)
+	if err != nil {
+		return nil, err
+	}
+	// 他人の依頼は存在しないものとして扱う
+	if export.UserID != userID {
+		return nil, entities.ErrDataExportNotFound
+	}
+	if !export.IsDownloadable(time.Now()) {
+		return nil, entities.ErrDataExportNotReady
+	}
+
+	content, err := i.storage.Open(export.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open data export: %w", err)
+	}
+
+	return &inputport.OpenDataExportResponse{
+		Export:  export,
+		Content: content,
+	}, nil
+}
+
+// ProcessPendingExports は処理待ちの依頼からエクスポートファイルを作成する
+// 1件の失敗で他の依頼を止めないよう、失敗した依頼は failed にして次へ進む
+func (i *DataExportInteractor) ProcessPendingExports(ctx context.Context, limit int) (int, error) {
+	exports, err := i.dataExportRepo.ClaimPending(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim pending data exports: %w", err)
+	}
+
+	completed := 0
+	for _, export := range exports {
+		if err := i.processExport(ctx, export); err != nil {
+			i.logger.Error("Failed to create data export",
+				entities.NewField("export_id", export.ID),
+				entities.NewField("error", err))
+
+			export.MarkFailed(err.Error(), time.Now())
+			if err := i.dataExportRepo.Update(ctx, export); err != nil {
+				i.logger.Error("Failed to mark data export as failed",
+					entities.NewField("export_id", export.ID),
+					entities.NewField("error", err))
+			}
+			continue
+		}
+		completed++
+	}
+
+	return completed, nil
+}
+
+// processExport は1件の依頼についてファイルを作成し、完了メールを送る
+func (i *DataExportInteractor) processExport(ctx context.Context, export *entities.DataExport) error {
+	user, err := i.userRepo.Read(ctx, export.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	data, err := i.collect(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode data export: %w", err)
+	}
+
+	filePath, err := i.storage.Save(export.ID.String(), map[string][]byte{"data.json": body})
+	if err != nil {
+		return fmt.Errorf("failed to save data export: %w", err)
+	}
+
+	export.MarkReady(filePath, time.Now())
+	if err := i.dataExportRepo.Update(ctx, export); err != nil {
+		// 記録できなかったファイルは残しておいても配布されないので削除する
+		if delErr := i.storage.Delete(filePath); delErr != nil {
+			i.logger.Warn("Failed to delete orphaned data export file", entities.NewField("error", delErr))
+		}
+		return fmt.Errorf("failed to update data export: %w", err)
+	}
+
+	// メール送信の失敗でエクスポート自体は失敗にしない（設定画面からもダウンロードできる）
+	if err := i.emailService.SendDataExportReady(user.Email, export.ID.String(), *export.ExpiresAt); err != nil {
+		i.logger.Warn("Failed to send data export notification",
+			entities.NewField("export_id", export.ID),
+			entities.NewField("error", err))
+	}
+
+	i.logger.Info("Data export created",
+		entities.NewField("user_id", user.ID),
+		entities.NewField("export_id", export.ID))
+
+	return nil
+}
+
+// collect はユーザーの個人データを集める
+func (i *DataExportInteractor) collect(ctx context.Context, user *entities.User) (*entities.PersonalDataExport, error) {
+	data := &entities.PersonalDataExport{
+		ExportedAt: time.Now(),
+		Profile: entities.ExportedProfile{
+			ID:            user.ID,
+			Username:      user.Username,
+			Email:         user.Email,
+			DisplayName:   user.DisplayName,
+			FirstName:     user.FirstName,
+			LastName:      user.LastName,
+			Balance:       user.Balance,
+			Role:          string(user.Role),
+			EmailVerified: user.EmailVerified,
+			AvatarURL:     user.AvatarURL,
+			CreatedAt:     user.CreatedAt,
+			UpdatedAt:     user.UpdatedAt,
+		},
+		Transactions: []entities.ExportedTransaction{},
+		DailyBonuses: []entities.ExportedDailyBonus{},
+		Friendships:  []entities.ExportedFriendship{},
+		Exchanges:    []entities.ExportedProductExchange{},
+	}
+
+	for offset := 0; ; offset += dataExportPageSize {
+		txs, err := i.transactionRepo.ReadListByUserIDWithUsers(ctx, user.ID, "", offset, dataExportPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transactions: %w", err)
+		}
+		for _, tx := range txs {
+			data.Transactions = append(data.Transactions, exportTransaction(user.ID, tx))
+		}
+		if len(txs) < dataExportPageSize {
+			break
+		}
+	}
+
+	bonusCount, err := i.dailyBonusRepo.CountByUser(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count daily bonuses: %w", err)
+	}
+	if bonusCount > 0 {
+		bonuses, err := i.dailyBonusRepo.ReadRecentByUser(ctx, user.ID, int(bonusCount))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get daily bonuses: %w", err)
+		}
+		for _, b := range bonuses {
+			data.DailyBonuses = append(data.DailyBonuses, entities.ExportedDailyBonus{
+				BonusDate:       b.BonusDate,
+				BonusPoints:     b.BonusPoints,
+				LotteryTierName: b.LotteryTierName,
+				CreatedAt:       b.CreatedAt,
+			})
+		}
+	}
+
+	friendLists := []func(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.FriendshipWithUser, error){
+		i.friendshipRepo.ReadListFriendsWithUsers,
+		i.friendshipRepo.ReadListPendingRequestsWithUsers,
+	}
+	for _, list := range friendLists {
+		for offset := 0; ; offset += dataExportPageSize {
+			friendships, err := list(ctx, user.ID, offset, dataExportPageSize)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get friendships: %w", err)
+			}
+			for _, f := range friendships {
+				data.Friendships = append(data.Friendships, exportFriendship(f))
+			}
+			if len(friendships) < dataExportPageSize {
+				break
+			}
+		}
+	}
+
+	for offset := 0; ; offset += dataExportPageSize {
+		exchanges, err := i.productExchangeRepo.ReadListByUserID(ctx, user.ID, offset, dataExportPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get exchanges: %w", err)
+		}
+		for _, e := range exchanges {
+			data.Exchanges = append(data.Exchanges, entities.ExportedProductExchange{
+				ID:         e.ID,
+				ProductID:  e.ProductID,
+				Quantity:   e.Quantity,
+				PointsUsed: e.PointsUsed,
+				Status:     string(e.Status),
+				Notes:      e.Notes,
+				CreatedAt:  e.CreatedAt,
+			})
+		}
+		if len(exchanges) < dataExportPageSize {
+			break
+		}
+	}
+
+	return data, nil
+}
+
+// exportTransaction は取引をエクスポート形式に変換（相手は表示名のみ）
+func exportTransaction(userID uuid.UUID, tx *entities.TransactionWithUsers) entities.ExportedTransaction {
+	t := tx.Transaction
+	exported := entities.ExportedTransaction{
+		ID:          t.ID,
+		Type:        string(t.TransactionType),
+		Status:      string(t.Status),
+		Direction:   "in",
+		Amount:      t.Amount,
+		Description: t.Description,
+		CreatedAt:   t.CreatedAt,
+		CompletedAt: t.CompletedAt,
+	}
+
+	counterparty, deleted := tx.FromUser, t.FromUserDeleted()
+	if t.FromUserID != nil && *t.FromUserID == userID {
+		exported.Direction = "out"
+		counterparty, deleted = tx.ToUser, t.ToUserDeleted()
+	}
+	switch {
+	case counterparty != nil:
+		exported.Counterparty = counterparty.DisplayName
+	case deleted:
+		exported.Counterparty = entities.DeletedUserDisplayName
+	}
+
+	return exported
+}
+
+// exportFriendship は友達関係をエクスポート形式に変換
+func exportFriendship(f *entities.FriendshipWithUser) entities.ExportedFriendship {
+	exported := entities.ExportedFriendship{
+		Status:    string(f.Friendship.Status),
+		CreatedAt: f.Friendship.CreatedAt,
+	}
+	if f.User != nil {
+		exported.Username = f.User.Username
+		exported.DisplayName = f.User.DisplayName
+	}
+	return exported
+}
+
+// CleanupExpiredExports はダウンロード期限を過ぎたファイルを削除する
+func (i *DataExportInteractor) CleanupExpiredExports(ctx context.Context, limit int) (int, error) {
+	exports, err := i.dataExportRepo.ReadListExpired(ctx, time.Now(), limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired data exports: %w", err)
+	}
+
+	cleaned := 0
+	var errs []error
+	for _, export := range exports {
+		if export.FilePath != "" {
+			if err := i.storage.Delete(export.FilePath); err != nil {
+				errs = append(errs, fmt.Errorf("export %s: %w", export.ID, err))
+				continue
+			}
+		}
+		export.MarkExpired()
+		if err := i.dataExportRepo.Update(ctx, export); err != nil {
+			errs = append(errs, fmt.Errorf("export %s: %w", export.ID, err))
+			continue
+		}
+		cleaned++
+	}
+
+	return cleaned, errors.Join(errs...)
+}
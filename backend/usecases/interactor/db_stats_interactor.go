@@ -0,0 +1,36 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+)
+
+// DBStatsInteractor はDBのコネクションプールの状態の確認のユースケース実装
+// 状態はこのインスタンスのプールのもの（複数台で動かしている場合はインスタンスごとに異なる）
+type DBStatsInteractor struct {
+	userRepo repository.UserRepository
+	monitor  service.DBStatsMonitor
+}
+
+// NewDBStatsInteractor は新しいDBStatsInteractorを作成
+func NewDBStatsInteractor(
+	userRepo repository.UserRepository,
+	monitor service.DBStatsMonitor,
+) inputport.DBStatsInputPort {
+	return &DBStatsInteractor{
+		userRepo: userRepo,
+		monitor:  monitor,
+	}
+}
+
+// GetDBStats はコネクションプールの状態を取得（管理者のみ）
+func (i *DBStatsInteractor) GetDBStats(ctx context.Context, adminID uuid.UUID) ([]service.DBPoolStats, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	return i.monitor.DBStats(), nil
+}
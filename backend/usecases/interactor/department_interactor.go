@@ -0,0 +1,204 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// DepartmentInteractor は組織・部署のユースケース実装
+type DepartmentInteractor struct {
+	departmentRepo repository.DepartmentRepository
+	userRepo       repository.UserRepository
+	logger         entities.Logger
+}
+
+// NewDepartmentInteractor は新しいDepartmentInteractorを作成
+func NewDepartmentInteractor(
+	departmentRepo repository.DepartmentRepository,
+	userRepo repository.UserRepository,
+	logger entities.Logger,
+) inputport.DepartmentInputPort {
+	return &DepartmentInteractor{
+		departmentRepo: departmentRepo,
+		userRepo:       userRepo,
+		logger:         logger,
+	}
+}
+
+// ListDepartments は全部署を取得
+func (i *DepartmentInteractor) ListDepartments(ctx context.Context, adminID uuid.UUID) ([]*entities.Department, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	return i.departmentRepo.ReadList(ctx)
+}
+
+// CreateDepartment は部署を作成
+func (i *DepartmentInteractor) CreateDepartment(ctx context.Context, req *inputport.CreateDepartmentRequest) (*entities.Department, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	department, err := entities.NewDepartment(req.Name, req.ParentID, req.MonthlyGrantBudget)
+	if err != nil {
+		return nil, err
+	}
+	if req.ParentID != nil {
+		if _, err := i.departmentRepo.Read(ctx, *req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := i.departmentRepo.Create(ctx, department); err != nil {
+		return nil, fmt.Errorf("failed to create department: %w", err)
+	}
+
+	i.logger.Info("Department created",
+		entities.NewField("department_id", department.ID),
+		entities.NewField("admin_id", req.AdminID))
+
+	return department, nil
+}
+
+// UpdateDepartment は部署名・親・月間予算を更新
+func (i *DepartmentInteractor) UpdateDepartment(ctx context.Context, req *inputport.UpdateDepartmentRequest) (*entities.Department, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	department, err := i.departmentRepo.Read(ctx, req.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := department.Update(req.Name, req.ParentID, req.MonthlyGrantBudget); err != nil {
+		return nil, err
+	}
+
+	// 親を付け替える場合は階層が循環しないことを確認
+	all, err := i.departmentRepo.ReadList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := entities.ValidateDepartmentParent(all, department.ID, department.ParentID); err != nil {
+		return nil, err
+	}
+
+	if err := i.departmentRepo.Update(ctx, department); err != nil {
+		return nil, fmt.Errorf("failed to update department: %w", err)
+	}
+
+	i.logger.Info("Department updated",
+		entities.NewField("department_id", department.ID),
+		entities.NewField("admin_id", req.AdminID))
+
+	return department, nil
+}
+
+// DeleteDepartment は部署を削除
+func (i *DepartmentInteractor) DeleteDepartment(ctx context.Context, req *inputport.DeleteDepartmentRequest) error {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return err
+	}
+
+	if err := i.departmentRepo.Delete(ctx, req.DepartmentID); err != nil {
+		return err
+	}
+
+	i.logger.Info("Department deleted",
+		entities.NewField("department_id", req.DepartmentID),
+		entities.NewField("admin_id", req.AdminID))
+
+	return nil
+}
+
+// AssignUserDepartment はユーザーの所属部署を設定・解除
+func (i *DepartmentInteractor) AssignUserDepartment(ctx context.Context, req *inputport.AssignUserDepartmentRequest) (*entities.User, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	user, err := i.userRepo.Read(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if req.DepartmentID != nil {
+		if _, err := i.departmentRepo.Read(ctx, *req.DepartmentID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := i.departmentRepo.SetUserDepartment(ctx, user.ID, req.DepartmentID); err != nil {
+		return nil, err
+	}
+	user.DepartmentID = req.DepartmentID
+
+	i.logger.Info("User department assigned",
+		entities.NewField("user_id", user.ID),
+		entities.NewField("department_id", req.DepartmentID),
+		entities.NewField("admin_id", req.AdminID))
+
+	return user, nil
+}
+
+// GetDepartmentAnalytics は部署ごとの付与・利用ポイントと予算の消化状況を取得
+func (i *DepartmentInteractor) GetDepartmentAnalytics(ctx context.Context, req *inputport.GetDepartmentAnalyticsRequest) (*inputport.GetDepartmentAnalyticsResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	days := req.Days
+	if days != 7 && days != 30 && days != 90 {
+		days = 30
+	}
+	now := time.Now()
+
+	breakdowns, err := i.departmentRepo.ReadBreakdown(ctx, now.AddDate(0, 0, -days))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get department breakdown: %w", err)
+	}
+	departments, err := i.departmentRepo.ReadList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]*entities.DepartmentBreakdownResult, len(breakdowns))
+	for _, b := range breakdowns {
+		byID[b.DepartmentID] = b
+	}
+	budgets := make(map[uuid.UUID]*int64, len(departments))
+	for _, d := range departments {
+		budgets[d.ID] = d.MonthlyGrantBudget
+	}
+
+	periodStart := entities.DepartmentBudgetPeriodStart(now)
+	results := make([]*inputport.DepartmentAnalytics, 0, len(breakdowns))
+	for _, b := range breakdowns {
+		a := &inputport.DepartmentAnalytics{
+			DepartmentBreakdownResult: b,
+			MonthlyGrantBudget:        budgets[b.DepartmentID],
+		}
+		// 配下の部署の集計を足し上げる
+		for _, id := range entities.DepartmentSubtreeIDs(departments, b.DepartmentID) {
+			if sub, ok := byID[id]; ok {
+				a.TotalGranted += sub.Granted
+				a.TotalSpent += sub.Spent
+				a.TotalMemberCount += sub.MemberCount
+			}
+		}
+		if a.MonthlyGrantBudget != nil {
+			a.BudgetUsed, err = i.departmentRepo.SumAdminGrantsSince(ctx, b.DepartmentID, periodStart)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get budget usage: %w", err)
+			}
+		}
+		results = append(results, a)
+	}
+
+	return &inputport.GetDepartmentAnalyticsResponse{Departments: results}, nil
+}
@@ -0,0 +1,306 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultEarningRuleListLimit = 20
+	maxEarningRuleListLimit     = 100
+)
+
+// errEarningRuleNotGranted は上限・付与済みのため付与しなかった場合にトランザクションを巻き戻すためのエラー
+var errEarningRuleNotGranted = errors.New("earning rule: not granted")
+
+// EarningRuleInteractor はポイント獲得ルールのユースケース実装
+// 管理者のルール管理と、各機能から伝えられた出来事の判定・付与を行う
+type EarningRuleInteractor struct {
+	txManager       repository.TransactionManager
+	earningRuleRepo repository.EarningRuleRepository
+	userRepo        repository.UserRepository
+	transactionRepo repository.TransactionRepository
+	pointBatchRepo  repository.PointBatchRepository
+	logger          entities.Logger
+}
+
+// NewEarningRuleInteractor は新しいEarningRuleInteractorを作成
+func NewEarningRuleInteractor(
+	txManager repository.TransactionManager,
+	earningRuleRepo repository.EarningRuleRepository,
+	userRepo repository.UserRepository,
+	transactionRepo repository.TransactionRepository,
+	pointBatchRepo repository.PointBatchRepository,
+	logger entities.Logger,
+) *EarningRuleInteractor {
+	return &EarningRuleInteractor{
+		txManager:       txManager,
+		earningRuleRepo: earningRuleRepo,
+		userRepo:        userRepo,
+		transactionRepo: transactionRepo,
+		pointBatchRepo:  pointBatchRepo,
+		logger:          logger,
+	}
+}
+
+// Record は出来事に一致する有効なルールでポイントを付与する
+func (i *EarningRuleInteractor) Record(ctx context.Context, event *entities.EarningEvent) {
+	rules, err := i.earningRuleRepo.ReadActiveByTrigger(ctx, event.Trigger, event.OccurredAt)
+	if err != nil {
+		i.logger.Error("Failed to read earning rules",
+			entities.NewField("trigger", event.Trigger),
+			entities.NewField("user_id", event.UserID),
+			entities.NewField("error", err))
+		return
+	}
+	if len(rules) == 0 {
+		return
+	}
+
+	user, err := i.userRepo.Read(ctx, event.UserID)
+	if err != nil || !user.IsActive {
+		return
+	}
+
+	// 何回目の出来事かは回数を条件にするルールがある場合だけ数える
+	var occurrence int64
+	for _, rule := range rules {
+		if rule.NeedsOccurrence() {
+			occurrence, err = i.earningRuleRepo.CountOccurrences(ctx, event.UserID, event.Trigger)
+			if err != nil {
+				i.logger.Error("Failed to count earning occurrences",
+					entities.NewField("trigger", event.Trigger),
+					entities.NewField("user_id", event.UserID),
+					entities.NewField("error", err))
+				return
+			}
+			break
+		}
+	}
+
+	for _, rule := range rules {
+		if !rule.Matches(event, occurrence) {
+			continue
+		}
+		i.grant(ctx, rule, event)
+	}
+}
+
+// grant はルールのポイントを付与する（上限に達している・同じ発生元で付与済みなら何もしない）
+func (i *EarningRuleInteractor) grant(ctx context.Context, rule *entities.EarningRule, event *entities.EarningEvent) {
+	var transaction *entities.Transaction
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		// ルールの行をロックしてから1人あたりの回数を数えるので、同時に付与しても上限を超えない
+		ok, err := i.earningRuleRepo.IncrementGrantCount(ctx, rule.ID)
+		if err != nil {
+			return fmt.Errorf("failed to update grant count: %w", err)
+		}
+		if !ok {
+			return errEarningRuleNotGranted
+		}
+		if limit := rule.PerUserLimit(); limit > 0 {
+			count, err := i.earningRuleRepo.CountUserGrants(ctx, rule.ID, event.UserID)
+			if err != nil {
+				return fmt.Errorf("failed to count user grants: %w", err)
+			}
+			if count >= limit {
+				return errEarningRuleNotGranted
+			}
+		}
+
+		tx, err := entities.NewSystemGrant(event.UserID, rule.Points, fmt.Sprintf("ポイント獲得: %s", rule.Name), rule.TransactionMetadata(event))
+		if err != nil {
+			return err
+		}
+		if err := i.transactionRepo.Create(ctx, tx); err != nil {
+			return fmt.Errorf("failed to save transaction: %w", err)
+		}
+		created, err := i.earningRuleRepo.CreateGrant(ctx, entities.NewEarningRuleGrant(rule, event, tx.ID))
+		if err != nil {
+			return fmt.Errorf("failed to record grant: %w", err)
+		}
+		if !created {
+			return errEarningRuleNotGranted
+		}
+		if err := i.userRepo.UpdateBalancesWithLock(ctx, []repository.BalanceUpdate{
+			{UserID: event.UserID, Amount: rule.Points, IsDeduct: false},
+		}); err != nil {
+			return fmt.Errorf("failed to update balance: %w", err)
+		}
+		batch := entities.NewPointBatch(event.UserID, rule.Points, entities.PointBatchSourceSystemGrant, &tx.ID, time.Now())
+		if err := i.pointBatchRepo.Create(ctx, batch); err != nil {
+			return fmt.Errorf("failed to create point batch: %w", err)
+		}
+		transaction = tx
+		return nil
+	})
+	if errors.Is(err, errEarningRuleNotGranted) {
+		return
+	}
+	if err != nil {
+		i.logger.Error("Failed to grant earning rule points",
+			entities.NewField("earning_rule_id", rule.ID),
+			entities.NewField("user_id", event.UserID),
+			entities.NewField("error", err))
+		return
+	}
+
+	i.logger.Info("Earning rule points granted",
+		entities.NewField("earning_rule_id", rule.ID),
+		entities.NewField("user_id", event.UserID),
+		entities.NewField("transaction_id", transaction.ID),
+		entities.NewField("points", rule.Points))
+}
+
+// CreateEarningRule はポイント獲得ルールを作成
+func (i *EarningRuleInteractor) CreateEarningRule(ctx context.Context, req *inputport.CreateEarningRuleRequest) (*entities.EarningRule, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	c := req.EarningRuleContent
+	rule, err := entities.NewEarningRule(c.Name, c.Trigger, c.Points, c.Occurrence, c.MinAmount, c.OncePerUser, c.MaxGrantsPerUser, c.MaxGrantsTotal, c.StartsAt, c.EndsAt, req.AdminID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.earningRuleRepo.Create(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to create earning rule: %w", err)
+	}
+
+	i.logger.Info("Earning rule created",
+		entities.NewField("earning_rule_id", rule.ID),
+		entities.NewField("trigger", rule.Trigger),
+		entities.NewField("admin_id", req.AdminID))
+
+	return rule, nil
+}
+
+// UpdateEarningRule はポイント獲得ルールを更新
+func (i *EarningRuleInteractor) UpdateEarningRule(ctx context.Context, req *inputport.UpdateEarningRuleRequest) (*entities.EarningRule, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	rule, err := i.earningRuleRepo.Read(ctx, req.EarningRuleID)
+	if err != nil {
+		return nil, err
+	}
+
+	c := req.EarningRuleContent
+	if err := rule.Update(c.Name, c.Trigger, c.Points, c.Occurrence, c.MinAmount, c.OncePerUser, c.MaxGrantsPerUser, c.MaxGrantsTotal, c.StartsAt, c.EndsAt, req.IsActive); err != nil {
+		return nil, err
+	}
+
+	if err := i.earningRuleRepo.Update(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to update earning rule: %w", err)
+	}
+
+	i.logger.Info("Earning rule updated",
+		entities.NewField("earning_rule_id", rule.ID),
+		entities.NewField("admin_id", req.AdminID))
+
+	return rule, nil
+}
+
+// DeleteEarningRule はポイント獲得ルールを削除
+func (i *EarningRuleInteractor) DeleteEarningRule(ctx context.Context, req *inputport.DeleteEarningRuleRequest) error {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return err
+	}
+
+	if err := i.earningRuleRepo.Delete(ctx, req.EarningRuleID); err != nil {
+		return err
+	}
+
+	i.logger.Info("Earning rule deleted",
+		entities.NewField("earning_rule_id", req.EarningRuleID),
+		entities.NewField("admin_id", req.AdminID))
+
+	return nil
+}
+
+// GetEarningRule はポイント獲得ルールと付与の実績を取得
+func (i *EarningRuleInteractor) GetEarningRule(ctx context.Context, req *inputport.GetEarningRuleRequest) (*inputport.EarningRuleWithStats, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	rule, err := i.earningRuleRepo.Read(ctx, req.EarningRuleID)
+	if err != nil {
+		return nil, err
+	}
+	withStats, err := i.withStats(ctx, []*entities.EarningRule{rule})
+	if err != nil {
+		return nil, err
+	}
+	return withStats[0], nil
+}
+
+// GetEarningRuleList はポイント獲得ルールの一覧を付与の実績つきで取得
+func (i *EarningRuleInteractor) GetEarningRuleList(ctx context.Context, req *inputport.GetEarningRuleListRequest) (*inputport.GetEarningRuleListResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultEarningRuleListLimit
+	}
+	if limit > maxEarningRuleListLimit {
+		limit = maxEarningRuleListLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	rules, err := i.earningRuleRepo.ReadList(ctx, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get earning rules: %w", err)
+	}
+	total, err := i.earningRuleRepo.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count earning rules: %w", err)
+	}
+	withStats, err := i.withStats(ctx, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inputport.GetEarningRuleListResponse{
+		Rules:  withStats,
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+	}, nil
+}
+
+// withStats はルールに付与の実績を付ける（付与のないルールは0件の実績）
+func (i *EarningRuleInteractor) withStats(ctx context.Context, rules []*entities.EarningRule) ([]*inputport.EarningRuleWithStats, error) {
+	ids := make([]uuid.UUID, len(rules))
+	for n, rule := range rules {
+		ids[n] = rule.ID
+	}
+	stats, err := i.earningRuleRepo.ReadStats(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate earning rule grants: %w", err)
+	}
+
+	result := make([]*inputport.EarningRuleWithStats, len(rules))
+	for n, rule := range rules {
+		s, ok := stats[rule.ID]
+		if !ok {
+			s = &entities.EarningRuleStats{RuleID: rule.ID}
+		}
+		result[n] = &inputport.EarningRuleWithStats{Rule: rule, Stats: s}
+	}
+	return result, nil
+}
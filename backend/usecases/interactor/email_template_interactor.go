@@ -0,0 +1,226 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+)
+
+// EmailTemplateInteractor はメールテンプレート管理のユースケース実装
+type EmailTemplateInteractor struct {
+	txManager    repository.TransactionManager
+	templateRepo repository.EmailTemplateRepository
+	userRepo     repository.UserRepository
+	emailService service.EmailService
+	logger       entities.Logger
+}
+
+// NewEmailTemplateInteractor は新しいEmailTemplateInteractorを作成
+func NewEmailTemplateInteractor(
+	txManager repository.TransactionManager,
+	templateRepo repository.EmailTemplateRepository,
+	userRepo repository.UserRepository,
+	emailService service.EmailService,
+	logger entities.Logger,
+) inputport.EmailTemplateInputPort {
+	return &EmailTemplateInteractor{
+		txManager:    txManager,
+		templateRepo: templateRepo,
+		userRepo:     userRepo,
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+// ListTemplates はメールの種類ごとに現在のテンプレートを取得
+func (i *EmailTemplateInteractor) ListTemplates(ctx context.Context, adminID uuid.UUID) ([]*inputport.EmailTemplateSummary, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	stored, err := i.templateRepo.ReadLatestList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latest := make(map[entities.EmailTemplateKey]*entities.EmailTemplate, len(stored))
+	for _, t := range stored {
+		latest[t.Key] = t
+	}
+
+	defs := entities.EmailTemplateDefinitions()
+	summaries := make([]*inputport.EmailTemplateSummary, 0, len(defs))
+	for _, def := range defs {
+		current, ok := latest[def.Key]
+		if !ok {
+			current = def.Default()
+		}
+		summaries = append(summaries, &inputport.EmailTemplateSummary{Definition: def, Current: current})
+	}
+	return summaries, nil
+}
+
+// GetTemplate はメールの種類の現在のテンプレートと過去の版を取得
+func (i *EmailTemplateInteractor) GetTemplate(ctx context.Context, adminID uuid.UUID, key entities.EmailTemplateKey) (*inputport.GetEmailTemplateResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	def, ok := entities.LookupEmailTemplateDefinition(key)
+	if !ok {
+		return nil, entities.ErrEmailTemplateNotFound
+	}
+	versions, err := i.templateRepo.ReadVersions(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	current := def.Default()
+	if len(versions) > 0 {
+		current = versions[0]
+	}
+	return &inputport.GetEmailTemplateResponse{Definition: def, Current: current, Versions: versions}, nil
+}
+
+// SaveTemplate はテンプレートを新しい版として保存
+func (i *EmailTemplateInteractor) SaveTemplate(ctx context.Context, req *inputport.SaveEmailTemplateRequest) (*entities.EmailTemplate, error) {
+	return i.createVersion(ctx, req.AdminID, req.Key, &req.EmailTemplateDraft)
+}
+
+// RestoreVersion は過去の版の内容を新しい版として保存（過去の版はそのまま残す）
+func (i *EmailTemplateInteractor) RestoreVersion(ctx context.Context, req *inputport.RestoreEmailTemplateRequest) (*entities.EmailTemplate, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	old, err := i.templateRepo.ReadVersion(ctx, req.Key, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	return i.createVersion(ctx, req.AdminID, req.Key, &inputport.EmailTemplateDraft{
+		Subject:  old.Subject,
+		HTMLBody: old.HTMLBody,
+		TextBody: old.TextBody,
+	})
+}
+
+func (i *EmailTemplateInteractor) createVersion(ctx context.Context, adminID uuid.UUID, key entities.EmailTemplateKey, draft *inputport.EmailTemplateDraft) (*entities.EmailTemplate, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+
+	var template *entities.EmailTemplate
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		version := 1
+		latest, err := i.templateRepo.ReadLatest(ctx, key)
+		switch {
+		case err == nil:
+			version = latest.Version + 1
+		case !errors.Is(err, entities.ErrEmailTemplateNotFound):
+			return err
+		}
+
+		template, err = entities.NewEmailTemplate(key, version, draft.Subject, draft.HTMLBody, draft.TextBody, &adminID)
+		if err != nil {
+			return err
+		}
+		if err := i.templateRepo.Create(ctx, template); err != nil {
+			return fmt.Errorf("failed to save email template: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Email template saved",
+		entities.NewField("template", string(key)),
+		entities.NewField("version", template.Version),
+		entities.NewField("admin_id", adminID))
+
+	return template, nil
+}
+
+// ResetTemplate はすべての版を削除して組み込みの既定に戻す
+func (i *EmailTemplateInteractor) ResetTemplate(ctx context.Context, adminID uuid.UUID, key entities.EmailTemplateKey) error {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return err
+	}
+	if _, ok := entities.LookupEmailTemplateDefinition(key); !ok {
+		return entities.ErrEmailTemplateNotFound
+	}
+	if err := i.templateRepo.DeleteByKey(ctx, key); err != nil {
+		return err
+	}
+
+	i.logger.Info("Email template reset to built-in default",
+		entities.NewField("template", string(key)),
+		entities.NewField("admin_id", adminID))
+
+	return nil
+}
+
+// PreviewTemplate はテンプレートを例の変数で描画
+func (i *EmailTemplateInteractor) PreviewTemplate(ctx context.Context, req *inputport.PreviewEmailTemplateRequest) (*entities.RenderedEmail, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	def, template, err := i.templateForRender(ctx, req.Key, req.Draft)
+	if err != nil {
+		return nil, err
+	}
+	vars := maps.Clone(def.Variables)
+	maps.Copy(vars, req.Variables)
+	return template.Render(vars)
+}
+
+// SendTestEmail はテンプレートを例の変数で描画し、管理者自身のアドレスへ送信
+func (i *EmailTemplateInteractor) SendTestEmail(ctx context.Context, req *inputport.SendTestEmailRequest) (*entities.RenderedEmail, error) {
+	admin, err := i.userRepo.Read(ctx, req.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin.IsAdmin() {
+		return nil, entities.ErrAdminRequired
+	}
+	def, template, err := i.templateForRender(ctx, req.Key, req.Draft)
+	if err != nil {
+		return nil, err
+	}
+	email, err := template.Render(def.Variables)
+	if err != nil {
+		return nil, err
+	}
+	email.Subject = "[テスト] " + email.Subject
+
+	if err := i.emailService.SendEmail(admin.Email, email); err != nil {
+		return nil, fmt.Errorf("failed to send test email: %w", err)
+	}
+	return email, nil
+}
+
+// templateForRender は下書き（検証済み）か、現在のテンプレートを返す
+func (i *EmailTemplateInteractor) templateForRender(ctx context.Context, key entities.EmailTemplateKey, draft *inputport.EmailTemplateDraft) (*entities.EmailTemplateDefinition, *entities.EmailTemplate, error) {
+	def, ok := entities.LookupEmailTemplateDefinition(key)
+	if !ok {
+		return nil, nil, entities.ErrEmailTemplateNotFound
+	}
+	if draft != nil {
+		template, err := entities.NewEmailTemplate(key, 0, draft.Subject, draft.HTMLBody, draft.TextBody, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		return def, template, nil
+	}
+
+	template, err := i.templateRepo.ReadLatest(ctx, key)
+	if errors.Is(err, entities.ErrEmailTemplateNotFound) {
+		return def, def.Default(), nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return def, template, nil
+}
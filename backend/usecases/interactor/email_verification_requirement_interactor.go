@@ -0,0 +1,167 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+)
+
+// EmailVerificationRequirementInteractor は新規登録ユーザーのメール認証のユースケース実装
+// 管理者による設定・認証メールの再送（EmailVerificationRequirementInputPort）と、登録・ログイン時の確認（EmailVerificationGate）を兼ねる
+type EmailVerificationRequirementInteractor struct {
+	settingsRepo          repository.SystemSettingsRepository
+	userRepo              repository.UserRepository
+	emailVerificationRepo repository.EmailVerificationRepository
+	emailService          service.EmailService
+	logger                entities.Logger
+}
+
+// NewEmailVerificationRequirementInteractor は新しいEmailVerificationRequirementInteractorを作成
+func NewEmailVerificationRequirementInteractor(
+	settingsRepo repository.SystemSettingsRepository,
+	userRepo repository.UserRepository,
+	emailVerificationRepo repository.EmailVerificationRepository,
+	emailService service.EmailService,
+	logger entities.Logger,
+) *EmailVerificationRequirementInteractor {
+	return &EmailVerificationRequirementInteractor{
+		settingsRepo:          settingsRepo,
+		userRepo:              userRepo,
+		emailVerificationRepo: emailVerificationRepo,
+		emailService:          emailService,
+		logger:                logger,
+	}
+}
+
+// StartVerification は設定が有効なら登録したユーザーに認証メールを送り、認証まで制限する操作を返す
+func (i *EmailVerificationRequirementInteractor) StartVerification(ctx context.Context, user *entities.User) (entities.EmailVerificationStage, error) {
+	requirement, err := readEmailVerificationRequirement(ctx, i.settingsRepo)
+	if err != nil {
+		return entities.EmailVerificationNotRequired, err
+	}
+	if !requirement.AppliesTo(user) {
+		return entities.EmailVerificationNotRequired, nil
+	}
+
+	// メールを送れなくても再送できるため、制限は有効のままにする
+	if err := i.sendVerification(ctx, user); err != nil {
+		i.logger.Error("Failed to send verification email on registration",
+			entities.NewField("user_id", user.ID),
+			entities.NewField("error", err))
+	}
+	return requirement.Stage, nil
+}
+
+// CheckLogin はユーザーがログインできるかを確認
+func (i *EmailVerificationRequirementInteractor) CheckLogin(ctx context.Context, user *entities.User) error {
+	requirement, err := readEmailVerificationRequirement(ctx, i.settingsRepo)
+	if err != nil {
+		return err
+	}
+	return requirement.CheckLogin(user)
+}
+
+// GetRequirement はメール認証を求める設定を取得
+func (i *EmailVerificationRequirementInteractor) GetRequirement(ctx context.Context, adminID uuid.UUID) (*entities.EmailVerificationRequirement, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	return readEmailVerificationRequirement(ctx, i.settingsRepo)
+}
+
+// UpdateRequirement はメール認証を求める設定を更新
+func (i *EmailVerificationRequirementInteractor) UpdateRequirement(ctx context.Context, req *inputport.UpdateEmailVerificationRequirementRequest) (*entities.EmailVerificationRequirement, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	previous, err := readEmailVerificationRequirement(ctx, i.settingsRepo)
+	if err != nil {
+		return nil, err
+	}
+	requirement, err := entities.NewEmailVerificationRequirement(previous, req.Stage, req.AdminID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(requirement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode email verification requirement: %w", err)
+	}
+	if err := i.settingsRepo.SetSetting(ctx, entities.EmailVerificationRequirementSettingKey, string(value), "新規登録ユーザーにメール認証を求める設定"); err != nil {
+		return nil, fmt.Errorf("failed to save email verification requirement: %w", err)
+	}
+
+	i.logger.Info("Email verification requirement updated",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("stage", req.Stage))
+	return requirement, nil
+}
+
+// ResendVerification は未認証のユーザーに認証メールを再送
+func (i *EmailVerificationRequirementInteractor) ResendVerification(ctx context.Context, req *inputport.ResendEmailVerificationRequest) error {
+	user, err := i.userRepo.ReadByEmail(ctx, req.Email)
+	if err != nil || user.EmailVerified || !user.IsActive {
+		return nil
+	}
+
+	now := time.Now()
+	recent, err := i.emailVerificationRepo.CountByUserIDSince(ctx, user.ID, entities.TokenTypeRegistration, now.Add(-entities.EmailVerificationResendInterval))
+	if err != nil {
+		return err
+	}
+	sent, err := i.emailVerificationRepo.CountByUserIDSince(ctx, user.ID, entities.TokenTypeRegistration, now.Add(-entities.EmailVerificationResendWindow))
+	if err != nil {
+		return err
+	}
+	if recent > 0 || sent >= entities.EmailVerificationResendMaxPerWindow {
+		i.logger.Info("Verification email resend rate limited",
+			entities.NewField("user_id", user.ID),
+			entities.NewField("sent_in_window", sent))
+		return nil
+	}
+
+	return i.sendVerification(ctx, user)
+}
+
+// sendVerification は登録時のメール認証トークンを発行して送信
+// 前に送ったリンクも期限までは使えるよう、既存のトークンは消さない（再送の回数もトークンの数で数える）
+func (i *EmailVerificationRequirementInteractor) sendVerification(ctx context.Context, user *entities.User) error {
+	token, err := entities.NewEmailVerificationToken(&user.ID, user.Email, entities.TokenTypeRegistration)
+	if err != nil {
+		return fmt.Errorf("failed to create token: %w", err)
+	}
+	if err := i.emailVerificationRepo.Create(ctx, token); err != nil {
+		return fmt.Errorf("failed to save token: %w", err)
+	}
+	if err := i.emailService.SendVerificationEmail(user.Email, token.Token); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	i.logger.Info("Verification email sent", entities.NewField("user_id", user.ID))
+	return nil
+}
+
+// readEmailVerificationRequirement はメール認証を求める設定を読み込む（未設定なら求めない）
+// 送金前の確認（TransferEligibilityInteractor）からも使う
+func readEmailVerificationRequirement(ctx context.Context, settingsRepo repository.SystemSettingsRepository) (*entities.EmailVerificationRequirement, error) {
+	value, err := settingsRepo.GetSetting(ctx, entities.EmailVerificationRequirementSettingKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email verification requirement: %w", err)
+	}
+
+	requirement := &entities.EmailVerificationRequirement{Stage: entities.EmailVerificationNotRequired}
+	if value != "" {
+		if err := json.Unmarshal([]byte(value), requirement); err != nil {
+			return nil, fmt.Errorf("failed to parse email verification requirement: %w", err)
+		}
+	}
+	return requirement, nil
+}
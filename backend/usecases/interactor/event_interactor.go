@@ -0,0 +1,252 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+const (
+	defaultEventListLimit = 20
+	maxEventListLimit     = 100
+
+	defaultEventAttendeeLimit = 100
+	maxEventAttendeeLimit     = 500
+)
+
+// EventInteractor はチェックインイベントのユースケース実装
+type EventInteractor struct {
+	txManager       repository.TransactionManager
+	eventRepo       repository.EventRepository
+	userRepo        repository.UserRepository
+	transactionRepo repository.TransactionRepository
+	pointBatchRepo  repository.PointBatchRepository
+	logger          entities.Logger
+}
+
+// NewEventInteractor は新しいEventInteractorを作成
+func NewEventInteractor(
+	txManager repository.TransactionManager,
+	eventRepo repository.EventRepository,
+	userRepo repository.UserRepository,
+	transactionRepo repository.TransactionRepository,
+	pointBatchRepo repository.PointBatchRepository,
+	logger entities.Logger,
+) inputport.EventInputPort {
+	return &EventInteractor{
+		txManager:       txManager,
+		eventRepo:       eventRepo,
+		userRepo:        userRepo,
+		transactionRepo: transactionRepo,
+		pointBatchRepo:  pointBatchRepo,
+		logger:          logger,
+	}
+}
+
+// CreateEvent はイベントを作成
+func (i *EventInteractor) CreateEvent(ctx context.Context, req *inputport.CreateEventRequest) (*entities.Event, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	c := req.EventContent
+	event, err := entities.NewEvent(c.Name, c.Description, c.Points, c.Capacity, c.StartsAt, c.EndsAt, req.AdminID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.eventRepo.Create(ctx, event); err != nil {
+		return nil, fmt.Errorf("failed to create event: %w", err)
+	}
+
+	i.logger.Info("Event created",
+		entities.NewField("event_id", event.ID),
+		entities.NewField("admin_id", req.AdminID))
+
+	return event, nil
+}
+
+// UpdateEvent はイベントを更新
+func (i *EventInteractor) UpdateEvent(ctx context.Context, req *inputport.UpdateEventRequest) (*entities.Event, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	event, err := i.eventRepo.Read(ctx, req.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	c := req.EventContent
+	if err := event.Update(c.Name, c.Description, c.Points, c.Capacity, c.StartsAt, c.EndsAt, req.IsActive); err != nil {
+		return nil, err
+	}
+
+	if err := i.eventRepo.Update(ctx, event); err != nil {
+		return nil, fmt.Errorf("failed to update event: %w", err)
+	}
+
+	i.logger.Info("Event updated",
+		entities.NewField("event_id", event.ID),
+		entities.NewField("admin_id", req.AdminID))
+
+	return event, nil
+}
+
+// GetEventList はイベントの一覧を取得
+func (i *EventInteractor) GetEventList(ctx context.Context, req *inputport.GetEventListRequest) (*inputport.GetEventListResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultEventListLimit
+	}
+	if limit > maxEventListLimit {
+		limit = maxEventListLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	events, err := i.eventRepo.ReadList(ctx, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	total, err := i.eventRepo.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count events: %w", err)
+	}
+
+	return &inputport.GetEventListResponse{
+		Events: events,
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+	}, nil
+}
+
+// GetEventAttendees はイベントの参加者の一覧を取得
+func (i *EventInteractor) GetEventAttendees(ctx context.Context, req *inputport.GetEventAttendeesRequest) (*inputport.GetEventAttendeesResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	event, err := i.eventRepo.Read(ctx, req.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultEventAttendeeLimit
+	}
+	if limit > maxEventAttendeeLimit {
+		limit = maxEventAttendeeLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	attendees, err := i.eventRepo.ReadAttendees(ctx, event.ID, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event attendees: %w", err)
+	}
+
+	return &inputport.GetEventAttendeesResponse{
+		Event:     event,
+		Attendees: attendees,
+		Offset:    offset,
+		Limit:     limit,
+	}, nil
+}
+
+// CheckIn はイベントのQRコードを読み取ったユーザーにポイントを付与する
+func (i *EventInteractor) CheckIn(ctx context.Context, req *inputport.EventCheckInRequest) (*inputport.EventCheckInResponse, error) {
+	code := entities.ParseEventQRCodeData(req.Code)
+	if code == "" {
+		return nil, entities.ErrEventNotFound
+	}
+	event, err := i.eventRepo.ReadByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := i.userRepo.Read(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, entities.ErrUserInactive
+	}
+
+	now := time.Now()
+	if err := event.CanCheckIn(now); err != nil {
+		return nil, err
+	}
+
+	var (
+		attendance  *entities.EventAttendance
+		transaction *entities.Transaction
+	)
+	err = i.txManager.Do(ctx, func(ctx context.Context) error {
+		tx, err := entities.NewSystemGrant(user.ID, event.Points, fmt.Sprintf("イベント参加: %s", event.Name), map[string]interface{}{
+			"event_id": event.ID.String(),
+		})
+		if err != nil {
+			return err
+		}
+		if err := i.transactionRepo.Create(ctx, tx); err != nil {
+			return fmt.Errorf("failed to save transaction: %w", err)
+		}
+
+		// 二重チェックインは一意制約で弾き、定員は人数の条件付き更新で守る
+		a := entities.NewEventAttendance(event, user.ID, tx.ID)
+		if err := i.eventRepo.CreateAttendance(ctx, a); err != nil {
+			return err
+		}
+		ok, err := i.eventRepo.IncrementAttendeeCount(ctx, event.ID)
+		if err != nil {
+			return fmt.Errorf("failed to update attendee count: %w", err)
+		}
+		if !ok {
+			return entities.ErrEventFull
+		}
+
+		if err := i.userRepo.UpdateBalancesWithLock(ctx, []repository.BalanceUpdate{
+			{UserID: user.ID, Amount: event.Points, IsDeduct: false},
+		}); err != nil {
+			return fmt.Errorf("failed to update balance: %w", err)
+		}
+		batch := entities.NewPointBatch(user.ID, event.Points, entities.PointBatchSourceSystemGrant, &tx.ID, now)
+		if err := i.pointBatchRepo.Create(ctx, batch); err != nil {
+			return fmt.Errorf("failed to create point batch: %w", err)
+		}
+
+		attendance = a
+		transaction = tx
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	event.AttendeeCount++
+
+	i.logger.Info("Event check-in",
+		entities.NewField("event_id", event.ID),
+		entities.NewField("user_id", user.ID),
+		entities.NewField("points", event.Points))
+
+	return &inputport.EventCheckInResponse{
+		Event:       event,
+		Attendance:  attendance,
+		Transaction: transaction,
+	}, nil
+}
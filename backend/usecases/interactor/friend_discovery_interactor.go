@@ -0,0 +1,62 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+// FriendDiscoveryInteractor は連絡先ハッシュによる友達検索のユースケース実装
+type FriendDiscoveryInteractor struct {
+	friendDiscoveryRepo repository.FriendDiscoveryRepository
+	logger              entities.Logger
+}
+
+// NewFriendDiscoveryInteractor は新しいFriendDiscoveryInteractorを作成
+func NewFriendDiscoveryInteractor(
+	friendDiscoveryRepo repository.FriendDiscoveryRepository,
+	logger entities.Logger,
+) inputport.FriendDiscoveryInputPort {
+	return &FriendDiscoveryInteractor{
+		friendDiscoveryRepo: friendDiscoveryRepo,
+		logger:              logger,
+	}
+}
+
+// DiscoverFriends は連絡先ハッシュに一致する公開設定のユーザーを返す
+func (i *FriendDiscoveryInteractor) DiscoverFriends(ctx context.Context, req *inputport.DiscoverFriendsRequest) (*inputport.DiscoverFriendsResponse, error) {
+	hashes, err := entities.NormalizeContactHashes(req.Hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := i.friendDiscoveryRepo.ReadDiscoverableByHashes(ctx, req.UserID, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	// どのハッシュに一致したかをアプリ側で連絡先と突き合わせられるように返す
+	hashSet := make(map[string]bool, len(hashes))
+	for _, h := range hashes {
+		hashSet[h] = true
+	}
+	matches := make([]*entities.ContactMatch, 0, len(users))
+	for _, user := range users {
+		if !user.Discoverable || !user.IsActive || user.ID == req.UserID {
+			continue
+		}
+		if match, ok := entities.MatchContactHashes(user, hashSet); ok {
+			matches = append(matches, match)
+		}
+	}
+
+	// ハッシュ自体は記録しない（連絡先の推測に使えるため）
+	i.logger.Info("Friend discovery",
+		entities.NewField("user_id", req.UserID),
+		entities.NewField("hash_count", len(hashes)),
+		entities.NewField("match_count", len(matches)))
+
+	return &inputport.DiscoverFriendsResponse{Matches: matches}, nil
+}
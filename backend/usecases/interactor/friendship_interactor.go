@@ -7,12 +7,15 @@ import (
 	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
 )
 
 // FriendshipInteractor は友達機能のユースケース実装
 type FriendshipInteractor struct {
 	friendshipRepo repository.FriendshipRepository
 	userRepo       repository.UserRepository
+	txRepo         repository.TransactionRepository
+	earningEvents  inputport.EarningEventRecorder
 	logger         entities.Logger
 }
 
@@ -20,11 +23,15 @@ type FriendshipInteractor struct {
 func NewFriendshipInteractor(
 	friendshipRepo repository.FriendshipRepository,
 	userRepo repository.UserRepository,
+	txRepo repository.TransactionRepository,
+	earningEvents inputport.EarningEventRecorder,
 	logger entities.Logger,
 ) inputport.FriendshipInputPort {
 	return &FriendshipInteractor{
 		friendshipRepo: friendshipRepo,
 		userRepo:       userRepo,
+		txRepo:         txRepo,
+		earningEvents:  earningEvents,
 		logger:         logger,
 	}
 }
@@ -38,11 +45,11 @@ func (i *FriendshipInteractor) SendFriendRequest(ctx context.Context, req *input
 	// 受信者の存在確認
 	addressee, err := i.userRepo.Read(ctx, req.AddresseeID)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, entities.ErrUserNotFound
 	}
 
 	if !addressee.IsActive {
-		return nil, errors.New("user is not active")
+		return nil, entities.ErrUserInactive
 	}
 
 	// 既存の友達関係チェック
@@ -101,6 +108,11 @@ func (i *FriendshipInteractor) AcceptFriendRequest(ctx context.Context, req *inp
 		return nil, err
 	}
 
+	// 申請した側・承認した側の両方に友達が増えた
+	for _, userID := range []uuid.UUID{friendship.RequesterID, friendship.AddresseeID} {
+		i.earningEvents.Record(ctx, entities.NewEarningEvent(entities.EarningTriggerFriendAdded, userID, 0, friendship.ID.String()))
+	}
+
 	return &inputport.AcceptFriendRequestResponse{Friendship: friendship}, nil
 }
 
@@ -126,19 +138,41 @@ func (i *FriendshipInteractor) RejectFriendRequest(ctx context.Context, req *inp
 	return &inputport.RejectFriendRequestResponse{Friendship: friendship}, nil
 }
 
-// GetFriends は友達一覧を取得
+// GetFriends は友達一覧を取得（友達になった日時と、やり取りしたポイントの合計付き）
 func (i *FriendshipInteractor) GetFriends(ctx context.Context, req *inputport.GetFriendsRequest) (*inputport.GetFriendsResponse, error) {
 	results, err := i.friendshipRepo.ReadListFriendsWithUsers(ctx, req.UserID, req.Offset, req.Limit)
 	if err != nil {
 		return nil, err
 	}
 
+	// ページ内の友達の分をまとめて集計する
+	friendIDs := make([]uuid.UUID, 0, len(results))
+	for _, r := range results {
+		if r.User != nil {
+			friendIDs = append(friendIDs, r.User.ID)
+		}
+	}
+	sums, err := i.txRepo.SumExchangedWith(ctx, req.UserID, friendIDs)
+	if err != nil {
+		return nil, err
+	}
+	exchanged := make(map[uuid.UUID]entities.PointsExchanged, len(sums))
+	for _, s := range sums {
+		exchanged[s.CounterpartID] = *s
+	}
+
 	friends := make([]*inputport.FriendInfo, 0, len(results))
 	for _, r := range results {
-		friends = append(friends, &inputport.FriendInfo{
+		info := &inputport.FriendInfo{
 			Friendship: r.Friendship,
 			Friend:     r.User,
-		})
+			Since:      r.Friendship.FriendsSince(),
+		}
+		if r.User != nil {
+			info.PointsExchanged = exchanged[r.User.ID]
+			info.PointsExchanged.CounterpartID = r.User.ID
+		}
+		friends = append(friends, info)
 	}
 
 	return &inputport.GetFriendsResponse{Friends: friends}, nil
@@ -189,3 +223,25 @@ func (i *FriendshipInteractor) GetFriendPendingRequestCount(ctx context.Context,
 
 	return &inputport.GetFriendPendingRequestCountResponse{Count: count}, nil
 }
+
+// GetMutualFriends は相手のユーザーとの共通の友達を取得
+// 友達でない相手も対象にできる（申請前に共通の知り合いを確かめられるようにする）
+func (i *FriendshipInteractor) GetMutualFriends(ctx context.Context, req *inputport.GetMutualFriendsRequest) (*inputport.GetMutualFriendsResponse, error) {
+	if req.UserID == req.OtherUserID {
+		return nil, errors.New("cannot get mutual friends with yourself")
+	}
+	if other, err := i.userRepo.Read(ctx, req.OtherUserID); err != nil || other == nil {
+		return nil, entities.ErrUserNotFound
+	}
+
+	count, err := i.friendshipRepo.CountMutualFriends(ctx, req.UserID, req.OtherUserID)
+	if err != nil {
+		return nil, err
+	}
+	friends, err := i.friendshipRepo.ReadListMutualFriends(ctx, req.UserID, req.OtherUserID, req.Offset, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inputport.GetMutualFriendsResponse{Friends: friends, Count: count}, nil
+}
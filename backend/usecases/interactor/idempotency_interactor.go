@@ -0,0 +1,88 @@
+package interactor
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+// IdempotencyInteractor はIdempotency-Keyヘッダーによる再送制御のユースケース実装
+type IdempotencyInteractor struct {
+	idempotentRequestRepo repository.IdempotentRequestRepository
+	logger                entities.Logger
+}
+
+// NewIdempotencyInteractor は新しいIdempotencyInteractorを作成
+func NewIdempotencyInteractor(
+	idempotentRequestRepo repository.IdempotentRequestRepository,
+	logger entities.Logger,
+) inputport.IdempotencyInputPort {
+	return &IdempotencyInteractor{
+		idempotentRequestRepo: idempotentRequestRepo,
+		logger:                logger,
+	}
+}
+
+// Begin はリクエストの処理開始を記録する
+func (i *IdempotencyInteractor) Begin(ctx context.Context, req *inputport.BeginIdempotentRequestRequest) (*inputport.BeginIdempotentRequestResponse, error) {
+	now := time.Now()
+
+	record, err := entities.NewIdempotentRequest(req.Scope, req.Key, req.Route, req.RequestHash, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// 期限切れのレコードが残っている場合は削除してから作り直す（1回だけ再試行）
+	for attempt := 0; attempt < 2; attempt++ {
+		created, err := i.idempotentRequestRepo.CreateIfAbsent(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			return &inputport.BeginIdempotentRequestResponse{RequestID: record.ID}, nil
+		}
+
+		existing, err := i.idempotentRequestRepo.ReadByKey(ctx, req.Scope, req.Key, req.Route)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			// 読み取りまでの間に削除された
+			continue
+		}
+
+		if existing.IsExpired(now) {
+			if err := i.idempotentRequestRepo.Delete(ctx, existing.ID); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := existing.CheckRetry(req.RequestHash); err != nil {
+			return nil, err
+		}
+
+		i.logger.Info("Replaying idempotent response",
+			entities.NewField("scope", req.Scope),
+			entities.NewField("route", req.Route))
+		return &inputport.BeginIdempotentRequestResponse{Replay: existing}, nil
+	}
+
+	// 削除と作成が競合し続けた場合は並行リクエストとして扱う
+	return nil, entities.ErrRequestInProgress
+}
+
+// Complete はレスポンスを保存する
+func (i *IdempotencyInteractor) Complete(ctx context.Context, req *inputport.CompleteIdempotentRequestRequest) error {
+	// サーバーエラー（5xx）は一時的な失敗の可能性があるため保存せず、同じキーでやり直せるようにする
+	if req.StatusCode >= 500 {
+		return i.idempotentRequestRepo.Delete(ctx, req.RequestID)
+	}
+
+	record := &entities.IdempotentRequest{ID: req.RequestID}
+	record.Complete(req.StatusCode, req.ContentType, req.Body, time.Now())
+	return i.idempotentRequestRepo.Update(ctx, record)
+}
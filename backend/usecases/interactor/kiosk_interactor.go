@@ -0,0 +1,419 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+)
+
+// KioskInteractor はキオスク端末機能のユースケース実装
+type KioskInteractor struct {
+	txManager       repository.TransactionManager
+	kioskRepo       repository.KioskRepository
+	userRepo        repository.UserRepository
+	transactionRepo repository.TransactionRepository
+	pointBatchRepo  repository.PointBatchRepository
+	settingsRepo    repository.SystemSettingsRepository
+	qrCodec         service.QRPayloadCodec
+	timeProvider    service.TimeProvider
+	logger          entities.Logger
+}
+
+// NewKioskInteractor は新しいKioskInteractorを作成
+func NewKioskInteractor(
+	txManager repository.TransactionManager,
+	kioskRepo repository.KioskRepository,
+	userRepo repository.UserRepository,
+	transactionRepo repository.TransactionRepository,
+	pointBatchRepo repository.PointBatchRepository,
+	settingsRepo repository.SystemSettingsRepository,
+	qrCodec service.QRPayloadCodec,
+	timeProvider service.TimeProvider,
+	logger entities.Logger,
+) inputport.KioskInputPort {
+	return &KioskInteractor{
+		txManager:       txManager,
+		kioskRepo:       kioskRepo,
+		userRepo:        userRepo,
+		transactionRepo: transactionRepo,
+		pointBatchRepo:  pointBatchRepo,
+		settingsRepo:    settingsRepo,
+		qrCodec:         qrCodec,
+		timeProvider:    timeProvider,
+		logger:          logger,
+	}
+}
+
+// ========================================
+// 端末向けメソッド
+// ========================================
+
+// AuthenticateDevice はAPIキーで端末を認証
+func (i *KioskInteractor) AuthenticateDevice(ctx context.Context, apiKey string) (*entities.KioskDevice, error) {
+	if apiKey == "" {
+		return nil, errors.New("invalid kiosk api key")
+	}
+
+	device, err := i.kioskRepo.ReadDeviceByAPIKeyHash(ctx, entities.HashKioskAPIKey(apiKey))
+	if err != nil {
+		return nil, errors.New("invalid kiosk api key")
+	}
+	if !device.IsActive {
+		return nil, errors.New("kiosk device is not active")
+	}
+
+	// 最終アクセス日時の更新失敗は認証結果に影響させない
+	if err := i.kioskRepo.UpdateDeviceLastSeen(ctx, device.ID, i.timeProvider.Now()); err != nil {
+		i.logger.Warn("Failed to update kiosk last seen",
+			entities.NewField("device_id", device.ID),
+			entities.NewField("error", err))
+	}
+
+	return device, nil
+}
+
+// LookupUser は個人QRコードまたはカードIDでユーザーを検索
+func (i *KioskInteractor) LookupUser(ctx context.Context, req *inputport.KioskLookupUserRequest) (*inputport.KioskLookupUserResponse, error) {
+	user, err := i.resolveUser(ctx, req.QRCode, req.CardID)
+	if err != nil {
+		return nil, err
+	}
+
+	granted, err := i.kioskRepo.ExistsGrantByDeviceAndUserSince(ctx, req.DeviceID, user.ID, i.dayStart(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &inputport.KioskLookupUserResponse{
+		User:           user,
+		AlreadyGranted: granted,
+	}, nil
+}
+
+// GrantBonus は端末に設定されたボーナスをユーザーに付与
+// 同じ冪等性キーでの再送は既存の付与結果を返す（オフライン時のキュー再送を想定）
+func (i *KioskInteractor) GrantBonus(ctx context.Context, req *inputport.KioskGrantBonusRequest) (*inputport.KioskGrantBonusResponse, error) {
+	if req.IdempotencyKey == "" {
+		return nil, entities.ErrIdempotencyKeyRequired
+	}
+
+	// 再送チェック（ユーザー解決より先に行い、カード紐付け変更後の再送でも結果を返す）
+	existing, err := i.kioskRepo.ReadGrantByIdempotencyKey(ctx, req.DeviceID, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		user, err := i.userRepo.Read(ctx, existing.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return &inputport.KioskGrantBonusResponse{
+			Grant:    existing,
+			User:     user,
+			Replayed: true,
+		}, nil
+	}
+
+	user, err := i.resolveUser(ctx, req.QRCode, req.CardID)
+	if err != nil {
+		return nil, err
+	}
+
+	var grant *entities.KioskGrant
+	var replayed bool
+
+	err = i.txManager.Do(ctx, func(ctx context.Context) error {
+		device, err := i.kioskRepo.ReadDevice(ctx, req.DeviceID)
+		if err != nil {
+			return err
+		}
+		if !device.IsActive {
+			return errors.New("kiosk device is not active")
+		}
+
+		// トランザクション内で再度冪等性チェック（同時再送対策）
+		existing, err := i.kioskRepo.ReadGrantByIdempotencyKey(ctx, device.ID, req.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			grant = existing
+			replayed = true
+			return nil
+		}
+
+		dayStart := i.dayStart(ctx)
+
+		count, err := i.kioskRepo.CountGrantsByDeviceSince(ctx, device.ID, dayStart)
+		if err != nil {
+			return err
+		}
+		if device.HasReachedDailyLimit(count) {
+			return entities.ErrKioskDailyLimitReached
+		}
+
+		granted, err := i.kioskRepo.ExistsGrantByDeviceAndUserSince(ctx, device.ID, user.ID, dayStart)
+		if err != nil {
+			return err
+		}
+		if granted {
+			return errors.New("bonus already granted today")
+		}
+
+		description := fmt.Sprintf("キオスクボーナス（%s）", device.Name)
+		tx, err := entities.NewSystemGrant(user.ID, device.BonusAmount, description, map[string]interface{}{
+			"kiosk_device_id": device.ID.String(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
+		if err := i.transactionRepo.Create(ctx, tx); err != nil {
+			return fmt.Errorf("failed to save transaction: %w", err)
+		}
+
+		updates := []repository.BalanceUpdate{
+			{UserID: user.ID, Amount: device.BonusAmount, IsDeduct: false},
+		}
+		if err := i.userRepo.UpdateBalancesWithLock(ctx, updates); err != nil {
+			return fmt.Errorf("failed to update balance: %w", err)
+		}
+
+		batch := entities.NewPointBatch(user.ID, device.BonusAmount, entities.PointBatchSourceSystemGrant, &tx.ID, i.timeProvider.Now())
+		if err := i.pointBatchRepo.Create(ctx, batch); err != nil {
+			return fmt.Errorf("failed to create point batch: %w", err)
+		}
+
+		grant, err = entities.NewKioskGrant(device.ID, user.ID, &tx.ID, device.BonusAmount, req.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+		return i.kioskRepo.CreateGrant(ctx, grant)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 付与後の残高を反映
+	updatedUser, err := i.userRepo.Read(ctx, grant.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !replayed {
+		i.logger.Info("Kiosk bonus granted",
+			entities.NewField("device_id", req.DeviceID),
+			entities.NewField("user_id", grant.UserID),
+			entities.NewField("amount", grant.Amount))
+	}
+
+	return &inputport.KioskGrantBonusResponse{
+		Grant:    grant,
+		User:     updatedUser,
+		Replayed: replayed,
+	}, nil
+}
+
+// ========================================
+// 管理者向けメソッド
+// ========================================
+
+// RegisterDevice は端末を登録
+func (i *KioskInteractor) RegisterDevice(ctx context.Context, req *inputport.RegisterKioskDeviceRequest) (*inputport.RegisterKioskDeviceResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	device, apiKey, err := entities.NewKioskDevice(req.Name, req.BonusAmount, req.DailyLimit, req.AdminID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.kioskRepo.CreateDevice(ctx, device); err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Kiosk device registered",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("device_id", device.ID))
+
+	return &inputport.RegisterKioskDeviceResponse{
+		Device: device,
+		APIKey: apiKey,
+	}, nil
+}
+
+// ListDevices は端末一覧を取得
+func (i *KioskInteractor) ListDevices(ctx context.Context, req *inputport.ListKioskDevicesRequest) (*inputport.ListKioskDevicesResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	devices, err := i.kioskRepo.ReadDeviceList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inputport.ListKioskDevicesResponse{
+		Devices: devices,
+	}, nil
+}
+
+// UpdateDevice は端末設定を更新
+func (i *KioskInteractor) UpdateDevice(ctx context.Context, req *inputport.UpdateKioskDeviceRequest) (*inputport.UpdateKioskDeviceResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	device, err := i.kioskRepo.ReadDevice(ctx, req.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := device.UpdateSettings(req.Name, req.BonusAmount, req.DailyLimit); err != nil {
+		return nil, err
+	}
+	device.IsActive = req.IsActive
+
+	if err := i.kioskRepo.UpdateDevice(ctx, device); err != nil {
+		return nil, err
+	}
+
+	return &inputport.UpdateKioskDeviceResponse{
+		Device: device,
+	}, nil
+}
+
+// RotateDeviceKey は端末のAPIキーを再発行
+func (i *KioskInteractor) RotateDeviceKey(ctx context.Context, req *inputport.RotateKioskDeviceKeyRequest) (*inputport.RegisterKioskDeviceResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	device, err := i.kioskRepo.ReadDevice(ctx, req.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := device.RotateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.kioskRepo.UpdateDevice(ctx, device); err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Kiosk device key rotated",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("device_id", device.ID))
+
+	return &inputport.RegisterKioskDeviceResponse{
+		Device: device,
+		APIKey: apiKey,
+	}, nil
+}
+
+// DeactivateDevice は端末を無効化
+func (i *KioskInteractor) DeactivateDevice(ctx context.Context, req *inputport.DeactivateKioskDeviceRequest) error {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return err
+	}
+
+	device, err := i.kioskRepo.ReadDevice(ctx, req.DeviceID)
+	if err != nil {
+		return err
+	}
+
+	device.Deactivate()
+	return i.kioskRepo.UpdateDevice(ctx, device)
+}
+
+// RegisterCard はカードIDをユーザーに紐付け
+func (i *KioskInteractor) RegisterCard(ctx context.Context, req *inputport.RegisterKioskCardRequest) (*entities.KioskCard, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	cardID := strings.TrimSpace(req.CardID)
+	if cardID == "" {
+		return nil, errors.New("card id is required")
+	}
+
+	if _, err := i.userRepo.Read(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	card := &entities.KioskCard{
+		CardID:    cardID,
+		UserID:    req.UserID,
+		CreatedAt: i.timeProvider.Now(),
+	}
+	if err := i.kioskRepo.CreateCard(ctx, card); err != nil {
+		return nil, err
+	}
+
+	return card, nil
+}
+
+// DeleteCard はカードIDの紐付けを解除
+func (i *KioskInteractor) DeleteCard(ctx context.Context, req *inputport.DeleteKioskCardRequest) error {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return err
+	}
+
+	return i.kioskRepo.DeleteCard(ctx, strings.TrimSpace(req.CardID))
+}
+
+// ========================================
+// プライベートヘルパー
+// ========================================
+
+// dayStart は端末の日次上限を区切る今日の開始時刻を返す（system_settingsのボーナス日のタイムゾーンの0:00）
+func (i *KioskInteractor) dayStart(ctx context.Context) time.Time {
+	return entities.GetKioskDayStart(i.timeProvider.Now(), systemBonusLocation(ctx, i.settingsRepo, i.logger))
+}
+
+// resolveUser は個人QRコードまたはカードIDからユーザーを特定
+func (i *KioskInteractor) resolveUser(ctx context.Context, qrCode, cardID string) (*entities.User, error) {
+	var userID uuid.UUID
+
+	switch {
+	case qrCode != "":
+		// 個人QRコード（署名付きのディープリンク、または旧形式の user:{user_id}）
+		payload, err := i.qrCodec.Decode(qrCode)
+		if err != nil {
+			return nil, errors.New("invalid personal QR code")
+		}
+		id, err := payload.UserID()
+		if err != nil {
+			return nil, errors.New("invalid personal QR code")
+		}
+		userID = id
+	case cardID != "":
+		card, err := i.kioskRepo.ReadCard(ctx, strings.TrimSpace(cardID))
+		if err != nil {
+			return nil, err
+		}
+		userID = card.UserID
+	default:
+		return nil, errors.New("qr_code or card_id is required")
+	}
+
+	user, err := i.userRepo.Read(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, entities.ErrUserInactive
+	}
+
+	return user, nil
+}
@@ -0,0 +1,131 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// maintenanceCacheTTL はメンテナンスモードのキャッシュ期間
+// 複数インスタンスで動かしている場合も、この時間内に切り替えが反映される
+const maintenanceCacheTTL = 5 * time.Second
+
+// MaintenanceInteractor はメンテナンスモードのユースケース実装
+type MaintenanceInteractor struct {
+	systemSettingsRepo repository.SystemSettingsRepository
+	userRepo           repository.UserRepository
+	logger             entities.Logger
+
+	mu       sync.RWMutex
+	cached   *entities.MaintenanceMode
+	cachedAt time.Time
+}
+
+// NewMaintenanceInteractor は新しいMaintenanceInteractorを作成
+func NewMaintenanceInteractor(
+	systemSettingsRepo repository.SystemSettingsRepository,
+	userRepo repository.UserRepository,
+	logger entities.Logger,
+) inputport.MaintenanceInputPort {
+	return &MaintenanceInteractor{
+		systemSettingsRepo: systemSettingsRepo,
+		userRepo:           userRepo,
+		logger:             logger,
+	}
+}
+
+// GetMaintenanceMode は現在のメンテナンスモードを取得
+func (i *MaintenanceInteractor) GetMaintenanceMode(ctx context.Context) (*entities.MaintenanceMode, error) {
+	i.mu.RLock()
+	if i.cached != nil && time.Since(i.cachedAt) < maintenanceCacheTTL {
+		mode := i.cached
+		i.mu.RUnlock()
+		return mode, nil
+	}
+	i.mu.RUnlock()
+
+	value, err := i.systemSettingsRepo.GetSetting(ctx, entities.MaintenanceSettingKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance mode: %w", err)
+	}
+
+	mode := &entities.MaintenanceMode{}
+	if value != "" {
+		if err := json.Unmarshal([]byte(value), mode); err != nil {
+			return nil, fmt.Errorf("failed to parse maintenance mode: %w", err)
+		}
+	}
+
+	i.setCache(mode)
+	return mode, nil
+}
+
+// IsActive はメンテナンス中かどうかを返す
+func (i *MaintenanceInteractor) IsActive(ctx context.Context) bool {
+	mode, err := i.GetMaintenanceMode(ctx)
+	if err != nil {
+		i.logger.Warn("Failed to check maintenance mode", entities.NewField("error", err))
+		return false
+	}
+	return mode.Enabled
+}
+
+// GetMaintenanceSettings は許可リストを含むメンテナンスモードの設定を取得
+func (i *MaintenanceInteractor) GetMaintenanceSettings(ctx context.Context, adminID uuid.UUID) (*entities.MaintenanceMode, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	return i.GetMaintenanceMode(ctx)
+}
+
+// UpdateMaintenanceMode はメンテナンスモードを切り替える
+func (i *MaintenanceInteractor) UpdateMaintenanceMode(ctx context.Context, req *inputport.UpdateMaintenanceModeRequest) (*inputport.UpdateMaintenanceModeResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	allowed := req.AllowedUserIDs
+	if allowed == nil {
+		allowed = []uuid.UUID{}
+	}
+	adminID := req.AdminID
+	mode := &entities.MaintenanceMode{
+		Enabled:        req.Enabled,
+		Message:        req.Message,
+		AllowedUserIDs: allowed,
+		UpdatedBy:      &adminID,
+		UpdatedAt:      time.Now(),
+	}
+
+	value, err := json.Marshal(mode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode maintenance mode: %w", err)
+	}
+	if err := i.systemSettingsRepo.SetSetting(ctx, entities.MaintenanceSettingKey, string(value), "メンテナンスモード"); err != nil {
+		return nil, fmt.Errorf("failed to save maintenance mode: %w", err)
+	}
+
+	i.setCache(mode)
+
+	i.logger.Info("Maintenance mode updated",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("enabled", req.Enabled),
+		entities.NewField("allowed_users", len(allowed)),
+	)
+
+	return &inputport.UpdateMaintenanceModeResponse{Mode: mode}, nil
+}
+
+func (i *MaintenanceInteractor) setCache(mode *entities.MaintenanceMode) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.cached = mode
+	i.cachedAt = time.Now()
+}
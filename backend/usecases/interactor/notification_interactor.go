@@ -0,0 +1,261 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+)
+
+// NotificationInteractor は通知の配信と配信先・設定のユースケース実装
+type NotificationInteractor struct {
+	notificationRepo repository.NotificationRepository
+	userRepo         repository.UserRepository
+	pushService      service.PushNotificationService
+	emailService     service.EmailService
+	notifier         service.RealtimeNotifier
+	logger           entities.Logger
+}
+
+// NewNotificationInteractor は新しいNotificationInteractorを作成
+func NewNotificationInteractor(
+	notificationRepo repository.NotificationRepository,
+	userRepo repository.UserRepository,
+	pushService service.PushNotificationService,
+	emailService service.EmailService,
+	notifier service.RealtimeNotifier,
+	logger entities.Logger,
+) inputport.NotificationInputPort {
+	return &NotificationInteractor{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		pushService:      pushService,
+		emailService:     emailService,
+		notifier:         notifier,
+		logger:           logger,
+	}
+}
+
+// Dispatch は通知設定に従ってプッシュ通知・メール・画面への通知で届ける
+// 経路ごとの失敗はログに残して他の経路の配信を続ける
+func (i *NotificationInteractor) Dispatch(ctx context.Context, notification *entities.Notification) {
+	prefs, err := i.notificationRepo.ReadPreferences(ctx, notification.UserID)
+	if err != nil {
+		i.logger.Warn("Failed to read notification preferences",
+			entities.NewField("user_id", notification.UserID),
+			entities.NewField("error", err))
+		return
+	}
+
+	now := time.Now()
+	for _, channel := range notification.Type.Channels() {
+		if !prefs.Allows(channel, notification.Type, now) {
+			continue
+		}
+		switch channel {
+		case entities.NotificationChannelPush:
+			i.sendPush(ctx, notification)
+		case entities.NotificationChannelEmail:
+			i.sendEmail(ctx, notification)
+		case entities.NotificationChannelInApp:
+			i.notifier.Notify(ctx, notification.UserID, entities.NewNotificationEvent(notification))
+		}
+	}
+}
+
+// sendPush はユーザーの登録済み端末へプッシュ通知を送る
+// 配信先に無効と判定されたトークンはその場で削除する
+func (i *NotificationInteractor) sendPush(ctx context.Context, notification *entities.Notification) {
+	devices, err := i.notificationRepo.ReadDeviceTokensByUser(ctx, notification.UserID)
+	if err != nil {
+		i.logger.Warn("Failed to read device tokens",
+			entities.NewField("user_id", notification.UserID),
+			entities.NewField("error", err))
+		return
+	}
+
+	for _, device := range devices {
+		err := i.pushService.Send(ctx, device, notification)
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, service.ErrPushTokenUnregistered) {
+			if err := i.notificationRepo.DeleteDeviceTokenByID(ctx, device.ID); err != nil {
+				i.logger.Warn("Failed to delete unregistered device token",
+					entities.NewField("device_id", device.ID),
+					entities.NewField("error", err))
+			}
+			continue
+		}
+		i.logger.Warn("Failed to send push notification",
+			entities.NewField("user_id", notification.UserID),
+			entities.NewField("device_id", device.ID),
+			entities.NewField("platform", string(device.Platform)),
+			entities.NewField("type", string(notification.Type)),
+			entities.NewField("error", err))
+	}
+}
+
+// sendEmail はユーザーの登録メールアドレスへ通知メールを送る
+func (i *NotificationInteractor) sendEmail(ctx context.Context, notification *entities.Notification) {
+	user, err := i.userRepo.Read(ctx, notification.UserID)
+	if err != nil {
+		i.logger.Warn("Failed to read user for notification email",
+			entities.NewField("user_id", notification.UserID),
+			entities.NewField("error", err))
+		return
+	}
+
+	switch notification.Type {
+	case entities.NotificationTypeNewLogin:
+		// ログイン通知は接続元の詳細を載せた専用のメールで送る
+		loggedInAt, parseErr := time.Parse(time.RFC3339, notification.Data["logged_in_at"])
+		if parseErr != nil {
+			loggedInAt = notification.CreatedAt
+		}
+		err = i.emailService.SendNewLoginNotification(user.Email,
+			notification.Data["ip_address"], notification.Data["user_agent"], notification.Data["country"], loggedInAt)
+	default:
+		err = i.emailService.SendNotificationEmail(user.Email, notification.Title, notification.Body)
+	}
+	if err != nil {
+		i.logger.Warn("Failed to send notification email",
+			entities.NewField("user_id", notification.UserID),
+			entities.NewField("type", string(notification.Type)),
+			entities.NewField("error", err))
+	}
+}
+
+// RegisterDevice はプッシュ通知を受け取る端末を登録する（登録済みなら更新）
+// 上限を超えた端末は古い順に削除する
+func (i *NotificationInteractor) RegisterDevice(ctx context.Context, req *inputport.RegisterDeviceRequest) (*entities.DeviceToken, error) {
+	device, err := entities.NewDeviceToken(req.UserID, req.Platform, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.notificationRepo.UpsertDeviceToken(ctx, device); err != nil {
+		return nil, fmt.Errorf("failed to register device token: %w", err)
+	}
+	if err := i.notificationRepo.PruneDeviceTokens(ctx, req.UserID, entities.DeviceTokensPerUserMax); err != nil {
+		return nil, fmt.Errorf("failed to prune device tokens: %w", err)
+	}
+
+	i.logger.Info("Device registered for push notifications",
+		entities.NewField("user_id", req.UserID),
+		entities.NewField("platform", string(device.Platform)))
+
+	return device, nil
+}
+
+// UnregisterDevice は端末の登録を解除する
+func (i *NotificationInteractor) UnregisterDevice(ctx context.Context, req *inputport.UnregisterDeviceRequest) error {
+	return i.notificationRepo.DeleteDeviceToken(ctx, req.UserID, req.Token)
+}
+
+// GetPreferences はユーザーの通知設定を取得
+func (i *NotificationInteractor) GetPreferences(ctx context.Context, userID uuid.UUID) (*entities.NotificationPreferences, error) {
+	return i.notificationRepo.ReadPreferences(ctx, userID)
+}
+
+// UpdatePreferences はユーザーの通知設定を更新（指定された項目のみ）
+func (i *NotificationInteractor) UpdatePreferences(ctx context.Context, req *inputport.UpdateNotificationPreferencesRequest) (*entities.NotificationPreferences, error) {
+	prefs, err := i.notificationRepo.ReadPreferences(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notification preferences: %w", err)
+	}
+
+	if req.PushEnabled != nil {
+		prefs.PushEnabled = *req.PushEnabled
+	}
+	if req.EmailEnabled != nil {
+		prefs.EmailEnabled = *req.EmailEnabled
+	}
+	if req.TransferRequests != nil {
+		prefs.TransferRequests = *req.TransferRequests
+	}
+	if req.PointsReceived != nil {
+		prefs.PointsReceived = *req.PointsReceived
+	}
+	if req.PointsExpiring != nil {
+		prefs.PointsExpiring = *req.PointsExpiring
+	}
+	if req.NewLogin != nil {
+		prefs.NewLogin = *req.NewLogin
+	}
+	if req.WeeklyDigest != nil {
+		prefs.WeeklyDigest = *req.WeeklyDigest
+	}
+	if req.QuietHours != nil {
+		quietHours, err := entities.NewQuietHours(req.QuietHours.Enabled, req.QuietHours.Start, req.QuietHours.End, req.QuietHours.Timezone)
+		if err != nil {
+			return nil, err
+		}
+		prefs.QuietHours = quietHours
+	}
+	prefs.UpdatedAt = time.Now()
+
+	if err := i.notificationRepo.SavePreferences(ctx, prefs); err != nil {
+		return nil, fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// SendExpiryReminders は有効期限が近いポイントをユーザーごとにまとめて通知する
+// 通知の可否にかかわらず対象のバッチは通知済みにする（設定を変えても過去の期限は通知しない）
+func (i *NotificationInteractor) SendExpiryReminders(ctx context.Context, now time.Time, limit int) (int, error) {
+	batches, err := i.notificationRepo.ReadExpiringBatches(ctx, now, entities.PointsExpiringReminderWindow, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expiring batches: %w", err)
+	}
+
+	groups := groupBatchesByUser(batches)
+	// 上限まで取得した場合、最後のユーザーのバッチは次回にまとめて通知する
+	if len(batches) == limit && len(groups) > 1 {
+		groups = groups[:len(groups)-1]
+	}
+
+	notified := 0
+	for _, group := range groups {
+		var amount int64
+		earliest := group[0].ExpiresAt
+		ids := make([]uuid.UUID, 0, len(group))
+		for _, batch := range group {
+			amount += batch.RemainingAmount
+			if batch.ExpiresAt.Before(earliest) {
+				earliest = batch.ExpiresAt
+			}
+			ids = append(ids, batch.ID)
+		}
+
+		if err := i.notificationRepo.MarkExpiryReminded(ctx, ids, now); err != nil {
+			i.logger.Error("Failed to mark expiry reminders",
+				entities.NewField("user_id", group[0].UserID),
+				entities.NewField("error", err))
+			continue
+		}
+		i.Dispatch(ctx, entities.NewPointsExpiringNotification(group[0].UserID, amount, earliest))
+		notified++
+	}
+	return notified, nil
+}
+
+// groupBatchesByUser はユーザー順に並んだバッチをユーザーごとに分ける
+func groupBatchesByUser(batches []*entities.PointBatch) [][]*entities.PointBatch {
+	var groups [][]*entities.PointBatch
+	for _, batch := range batches {
+		last := len(groups) - 1
+		if last >= 0 && groups[last][0].UserID == batch.UserID {
+			groups[last] = append(groups[last], batch)
+			continue
+		}
+		groups = append(groups, []*entities.PointBatch{batch})
+	}
+	return groups
+}
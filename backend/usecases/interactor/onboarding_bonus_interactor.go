@@ -0,0 +1,154 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// OnboardingBonusInteractor は登録時のウェルカムボーナスのユースケース実装
+// 管理者による設定（OnboardingBonusInputPort）と登録時の付与（OnboardingBonusGranter）を兼ねる
+type OnboardingBonusInteractor struct {
+	txManager       repository.TransactionManager
+	settingsRepo    repository.SystemSettingsRepository
+	userRepo        repository.UserRepository
+	transactionRepo repository.TransactionRepository
+	pointBatchRepo  repository.PointBatchRepository
+	logger          entities.Logger
+}
+
+// NewOnboardingBonusInteractor は新しいOnboardingBonusInteractorを作成
+func NewOnboardingBonusInteractor(
+	txManager repository.TransactionManager,
+	settingsRepo repository.SystemSettingsRepository,
+	userRepo repository.UserRepository,
+	transactionRepo repository.TransactionRepository,
+	pointBatchRepo repository.PointBatchRepository,
+	logger entities.Logger,
+) *OnboardingBonusInteractor {
+	return &OnboardingBonusInteractor{
+		txManager:       txManager,
+		settingsRepo:    settingsRepo,
+		userRepo:        userRepo,
+		transactionRepo: transactionRepo,
+		pointBatchRepo:  pointBatchRepo,
+		logger:          logger,
+	}
+}
+
+// currentPolicy は現在の設定を取得（未設定なら無効）
+func (i *OnboardingBonusInteractor) currentPolicy(ctx context.Context) (*entities.OnboardingBonusPolicy, error) {
+	value, err := i.settingsRepo.GetSetting(ctx, entities.OnboardingBonusSettingKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get onboarding bonus: %w", err)
+	}
+
+	policy := &entities.OnboardingBonusPolicy{}
+	if value != "" {
+		if err := json.Unmarshal([]byte(value), policy); err != nil {
+			return nil, fmt.Errorf("failed to parse onboarding bonus: %w", err)
+		}
+	}
+	return policy, nil
+}
+
+// GrantOnboardingBonus はウェルカムボーナスを通常の付与と同じく取引・残高・ポイントバッチとして記録する
+// 取引の冪等性キーでユーザーごとに1回に限り、付与済みならnilを返す
+func (i *OnboardingBonusInteractor) GrantOnboardingBonus(ctx context.Context, userID uuid.UUID) (*entities.Transaction, error) {
+	policy, err := i.currentPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.IsActive() {
+		return nil, nil
+	}
+
+	now := time.Now()
+	tx, err := entities.NewOnboardingBonus(userID, policy, now)
+	if err != nil {
+		return nil, err
+	}
+
+	granted := false
+	err = i.txManager.Do(ctx, func(ctx context.Context) error {
+		// 見つからない場合もエラーが返るため、同時の付与は冪等性キーの一意制約で防ぐ
+		if existing, err := i.transactionRepo.ReadByIdempotencyKey(ctx, *tx.IdempotencyKey); err == nil && existing != nil {
+			return nil
+		}
+		if err := i.transactionRepo.Create(ctx, tx); err != nil {
+			return fmt.Errorf("failed to save transaction: %w", err)
+		}
+		if err := i.userRepo.UpdateBalancesWithLock(ctx, []repository.BalanceUpdate{
+			{UserID: userID, Amount: tx.Amount, IsDeduct: false},
+		}); err != nil {
+			return fmt.Errorf("failed to update balance: %w", err)
+		}
+		batch := entities.NewPointBatch(userID, tx.Amount, entities.PointBatchSourceOnboardingBonus, &tx.ID, now)
+		batch.ExpiresAt = policy.ExpiresAt(now)
+		if err := i.pointBatchRepo.Create(ctx, batch); err != nil {
+			return fmt.Errorf("failed to create point batch: %w", err)
+		}
+		granted = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !granted {
+		return nil, nil
+	}
+
+	i.logger.Info("Onboarding bonus granted",
+		entities.NewField("user_id", userID),
+		entities.NewField("transaction_id", tx.ID),
+		entities.NewField("amount", tx.Amount))
+	return tx, nil
+}
+
+// GetPolicy はウェルカムボーナスの設定を取得
+func (i *OnboardingBonusInteractor) GetPolicy(ctx context.Context, adminID uuid.UUID) (*entities.OnboardingBonusPolicy, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	return i.currentPolicy(ctx)
+}
+
+// UpdatePolicy はウェルカムボーナスの設定を更新（以降の登録から反映し、付与済みのボーナスは変えない）
+func (i *OnboardingBonusInteractor) UpdatePolicy(ctx context.Context, req *inputport.UpdateOnboardingBonusRequest) (*entities.OnboardingBonusPolicy, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	adminID := req.AdminID
+	policy := &entities.OnboardingBonusPolicy{
+		Enabled:      req.Enabled,
+		Amount:       req.Amount,
+		ValidityDays: req.ValidityDays,
+		UpdatedBy:    &adminID,
+		UpdatedAt:    time.Now(),
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode onboarding bonus: %w", err)
+	}
+	if err := i.settingsRepo.SetSetting(ctx, entities.OnboardingBonusSettingKey, string(value), "登録時のウェルカムボーナス"); err != nil {
+		return nil, fmt.Errorf("failed to save onboarding bonus: %w", err)
+	}
+
+	i.logger.Info("Onboarding bonus updated",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("enabled", policy.Enabled),
+		entities.NewField("amount", policy.Amount),
+		entities.NewField("validity_days", policy.ValidityDays))
+	return policy, nil
+}
@@ -0,0 +1,559 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultRestorableBatchesLimit = 50
+	maxRestorableBatchesLimit     = 200
+	defaultUserBatchesLimit       = 50
+	maxUserBatchesLimit           = 200
+)
+
+// PointExpiryPolicyInteractor はポイント有効期間の設定と失効取り消しのユースケース実装
+type PointExpiryPolicyInteractor struct {
+	txManager          repository.TransactionManager
+	pointBatchRepo     repository.PointBatchRepository
+	policyRepo         repository.PointExpiryPolicyRepository
+	systemSettingsRepo repository.SystemSettingsRepository
+	userRepo           repository.UserRepository
+	transactionRepo    repository.TransactionRepository
+	logger             entities.Logger
+}
+
+// NewPointExpiryPolicyInteractor は新しいPointExpiryPolicyInteractorを作成
+func NewPointExpiryPolicyInteractor(
+	txManager repository.TransactionManager,
+	pointBatchRepo repository.PointBatchRepository,
+	policyRepo repository.PointExpiryPolicyRepository,
+	systemSettingsRepo repository.SystemSettingsRepository,
+	userRepo repository.UserRepository,
+	transactionRepo repository.TransactionRepository,
+	logger entities.Logger,
+) inputport.PointExpiryPolicyInputPort {
+	return &PointExpiryPolicyInteractor{
+		txManager:          txManager,
+		pointBatchRepo:     pointBatchRepo,
+		policyRepo:         policyRepo,
+		systemSettingsRepo: systemSettingsRepo,
+		userRepo:           userRepo,
+		transactionRepo:    transactionRepo,
+		logger:             logger,
+	}
+}
+
+// GetPolicies は付与種別ごとの有効期間と猶予日数を取得
+func (i *PointExpiryPolicyInteractor) GetPolicies(ctx context.Context, adminID uuid.UUID) (*inputport.GetExpiryPoliciesResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+
+	policies, err := i.policyRepo.ReadListPolicies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiry policies: %w", err)
+	}
+	graceDays, err := i.graceDays(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &inputport.GetExpiryPoliciesResponse{
+		Policies:            policies,
+		DefaultValidityDays: int(now.AddDate(0, entities.POINT_EXPIRATION_MONTHS, 0).Sub(now).Hours() / 24),
+		GraceDays:           graceDays,
+	}, nil
+}
+
+// UpdatePolicy は付与種別の有効期間を設定
+func (i *PointExpiryPolicyInteractor) UpdatePolicy(ctx context.Context, req *inputport.UpdateExpiryPolicyRequest) (*entities.PointExpiryPolicy, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	// 補填バッチの期限は取り消し時に決まるため、ポリシーの対象にしない
+	if req.SourceType == entities.PointBatchSourceExpiryRestore {
+		return nil, entities.ErrInvalidExpiryPolicy
+	}
+
+	policy, err := entities.NewPointExpiryPolicy(req.SourceType, req.ValidityDays, req.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.policyRepo.SavePolicy(ctx, policy); err != nil {
+		return nil, fmt.Errorf("failed to save expiry policy: %w", err)
+	}
+
+	i.logger.Info("Point expiry policy updated",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("source_type", req.SourceType),
+		entities.NewField("validity_days", req.ValidityDays))
+
+	return policy, nil
+}
+
+// DeletePolicy は付与種別の有効期間を既定に戻す
+func (i *PointExpiryPolicyInteractor) DeletePolicy(ctx context.Context, adminID uuid.UUID, sourceType entities.PointBatchSourceType) error {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return err
+	}
+	if !sourceType.IsValid() {
+		return entities.ErrInvalidExpiryPolicy
+	}
+	if err := i.policyRepo.DeletePolicy(ctx, sourceType); err != nil {
+		return fmt.Errorf("failed to delete expiry policy: %w", err)
+	}
+
+	i.logger.Info("Point expiry policy deleted",
+		entities.NewField("admin_id", adminID),
+		entities.NewField("source_type", sourceType))
+	return nil
+}
+
+// UpdateGracePeriod は失効を取り消せる猶予日数を設定
+func (i *PointExpiryPolicyInteractor) UpdateGracePeriod(ctx context.Context, adminID uuid.UUID, graceDays int) error {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return err
+	}
+	if graceDays < 0 || graceDays > entities.MaxPointExpiryGraceDays {
+		return entities.ErrInvalidExpiryPolicy
+	}
+	if err := i.systemSettingsRepo.SetSetting(ctx, entities.PointExpiryGraceDaysSettingKey, strconv.Itoa(graceDays), "失効したポイントを管理者が取り消せる日数"); err != nil {
+		return fmt.Errorf("failed to save grace period: %w", err)
+	}
+
+	i.logger.Info("Point expiry grace period updated",
+		entities.NewField("admin_id", adminID),
+		entities.NewField("grace_days", graceDays))
+	return nil
+}
+
+// GetUserOverride はユーザー個別の有効期間を取得
+func (i *PointExpiryPolicyInteractor) GetUserOverride(ctx context.Context, adminID, userID uuid.UUID) (*entities.UserPointExpiryOverride, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	return i.policyRepo.ReadUserOverride(ctx, userID)
+}
+
+// SetUserOverride はユーザー個別の有効期間を設定し、有効なバッチの期限を再計算する
+func (i *PointExpiryPolicyInteractor) SetUserOverride(ctx context.Context, req *inputport.SetUserExpiryOverrideRequest) (*entities.UserPointExpiryOverride, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	if _, err := i.userRepo.Read(ctx, req.UserID); err != nil {
+		return nil, entities.ErrUserNotFound
+	}
+
+	override, err := entities.NewUserPointExpiryOverride(req.UserID, req.ValidityDays, req.Reason, req.AdminID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := i.policyRepo.SaveUserOverride(ctx, override); err != nil {
+			return fmt.Errorf("failed to save expiry override: %w", err)
+		}
+		return i.recalculateUserBatches(ctx, req.UserID, override)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Point expiry override set",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("user_id", req.UserID),
+		entities.NewField("validity_days", req.ValidityDays))
+
+	return override, nil
+}
+
+// DeleteUserOverride はユーザー個別の有効期間を削除し、有効なバッチの期限を再計算する
+func (i *PointExpiryPolicyInteractor) DeleteUserOverride(ctx context.Context, adminID, userID uuid.UUID) error {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return err
+	}
+
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := i.policyRepo.DeleteUserOverride(ctx, userID); err != nil {
+			return fmt.Errorf("failed to delete expiry override: %w", err)
+		}
+		return i.recalculateUserBatches(ctx, userID, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	i.logger.Info("Point expiry override deleted",
+		entities.NewField("admin_id", adminID),
+		entities.NewField("user_id", userID))
+	return nil
+}
+
+// ListRestorableBatches は猶予期間内で取り消し可能な失効済みバッチを取得
+func (i *PointExpiryPolicyInteractor) ListRestorableBatches(ctx context.Context, req *inputport.ListRestorableBatchesRequest) (*inputport.ListRestorableBatchesResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultRestorableBatchesLimit
+	}
+	if limit > maxRestorableBatchesLimit {
+		limit = maxRestorableBatchesLimit
+	}
+
+	graceDays, err := i.graceDays(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Now().AddDate(0, 0, -graceDays)
+	batches, err := i.pointBatchRepo.FindRestorableBatches(ctx, since, req.UserID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find restorable batches: %w", err)
+	}
+
+	return &inputport.ListRestorableBatchesResponse{
+		Batches:   batches,
+		GraceDays: graceDays,
+	}, nil
+}
+
+// RestoreBatch は失効を取り消し、失効したポイントを補填バッチとして戻す
+// 元のバッチは失効済みのまま取り消し済みの印を付け、残高と取引履歴には管理者付与として記録する
+func (i *PointExpiryPolicyInteractor) RestoreBatch(ctx context.Context, req *inputport.RestoreBatchRequest) (*inputport.RestoreBatchResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	graceDays, err := i.graceDays(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp inputport.RestoreBatchResponse
+	err = i.txManager.Do(ctx, func(ctx context.Context) error {
+		batch, err := i.pointBatchRepo.Read(ctx, req.BatchID)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := batch.CheckRestorable(now, graceDays); err != nil {
+			return err
+		}
+
+		user, err := i.userRepo.Read(ctx, batch.UserID)
+		if err != nil {
+			return entities.ErrUserNotFound
+		}
+		if !user.IsActive {
+			return entities.ErrUserInactive
+		}
+
+		// 先に取り消し済みにして、同時に取り消された場合の二重付与を防ぐ
+		if err := i.pointBatchRepo.MarkRestored(ctx, batch.ID, now); err != nil {
+			return err
+		}
+		batch.RestoredAt = &now
+
+		if err := i.userRepo.UpdateBalanceWithLock(ctx, batch.UserID, batch.ExpiredAmount, false); err != nil {
+			return err
+		}
+		user.Balance += batch.ExpiredAmount
+
+		tx, err := entities.NewAdminGrant(
+			batch.UserID,
+			batch.ExpiredAmount,
+			fmt.Sprintf("ポイント失効の取り消し（バッチ: %s）", batch.ID),
+			req.AdminID,
+		)
+		if err != nil {
+			return err
+		}
+		tx.FromAccount = entities.SystemAccountExpirySink // 失効で回収したポイントを戻す
+		tx.Metadata["restored_batch_id"] = batch.ID.String()
+		if err := i.transactionRepo.Create(ctx, tx); err != nil {
+			return err
+		}
+
+		// 補填バッチには元の付与種別で今から付与した場合と同じ有効期間を与える
+		policies, err := i.policyRepo.ReadListPolicies(ctx)
+		if err != nil {
+			return err
+		}
+		override, err := i.policyRepo.ReadUserOverride(ctx, batch.UserID)
+		if err != nil {
+			return err
+		}
+		newBatch := entities.NewPointBatch(batch.UserID, batch.ExpiredAmount, entities.PointBatchSourceExpiryRestore, &tx.ID, now)
+		newBatch.ExpiresAt = entities.ResolvePointExpiry(batch.SourceType, now, policies, override)
+		if err := i.pointBatchRepo.Create(ctx, newBatch); err != nil {
+			return fmt.Errorf("failed to create point batch: %w", err)
+		}
+
+		resp = inputport.RestoreBatchResponse{
+			RestoredBatch: batch,
+			NewBatch:      newBatch,
+			Transaction:   tx,
+			User:          user,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Expired point batch restored",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("batch_id", req.BatchID),
+		entities.NewField("user_id", resp.User.ID),
+		entities.NewField("amount", resp.NewBatch.OriginalAmount))
+
+	return &resp, nil
+}
+
+// recalculateUserBatches はユーザーの有効なバッチの期限を現在の設定で再計算する
+func (i *PointExpiryPolicyInteractor) recalculateUserBatches(ctx context.Context, userID uuid.UUID, override *entities.UserPointExpiryOverride) error {
+	policies, err := i.policyRepo.ReadListPolicies(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get expiry policies: %w", err)
+	}
+	batches, err := i.pointBatchRepo.FindActiveByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find active batches: %w", err)
+	}
+
+	for _, batch := range batches {
+		if batch.HasFixedExpiry() {
+			continue
+		}
+		expiresAt := entities.ResolvePointExpiry(batch.SourceType, batch.CreatedAt, policies, override)
+		if expiresAt.Equal(batch.ExpiresAt) {
+			continue
+		}
+		if err := i.pointBatchRepo.UpdateExpiresAt(ctx, batch.ID, expiresAt); err != nil {
+			return fmt.Errorf("failed to update batch expiry: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListUserBatches はユーザーのすべてのバッチを調整の記録つきで新しい順に取得
+func (i *PointExpiryPolicyInteractor) ListUserBatches(ctx context.Context, req *inputport.ListUserBatchesRequest) (*inputport.ListUserBatchesResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	if _, err := i.userRepo.Read(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultUserBatchesLimit
+	}
+	if limit > maxUserBatchesLimit {
+		limit = maxUserBatchesLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	batches, err := i.pointBatchRepo.FindByUser(ctx, req.UserID, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find point batches: %w", err)
+	}
+	total, err := i.pointBatchRepo.CountByUser(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count point batches: %w", err)
+	}
+
+	batchIDs := make([]uuid.UUID, len(batches))
+	for idx, b := range batches {
+		batchIDs[idx] = b.ID
+	}
+	adjustments, err := i.pointBatchRepo.FindAdjustments(ctx, batchIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find point batch adjustments: %w", err)
+	}
+	byBatch := make(map[uuid.UUID][]*entities.PointBatchAdjustment, len(batches))
+	for _, a := range adjustments {
+		byBatch[a.BatchID] = append(byBatch[a.BatchID], a)
+	}
+
+	return &inputport.ListUserBatchesResponse{
+		Batches:     batches,
+		Adjustments: byBatch,
+		Total:       total,
+		Offset:      offset,
+		Limit:       limit,
+	}, nil
+}
+
+// AdjustBatch はバッチの有効期限を変更するか、バッチを取り消す
+// 期限を変更したバッチは以降の有効期間の設定の変更で再計算しない
+// 取り消しは残っていたポイントを管理者減算として残高から引き、どちらの場合も調整の記録を残す
+func (i *PointExpiryPolicyInteractor) AdjustBatch(ctx context.Context, req *inputport.AdjustBatchRequest) (*inputport.AdjustBatchResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	if (req.ExpiresAt == nil) == !req.Cancel {
+		return nil, entities.ErrInvalidBatchAdjustment
+	}
+
+	var resp inputport.AdjustBatchResponse
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		batch, err := i.pointBatchRepo.Read(ctx, req.BatchID)
+		if err != nil {
+			return err
+		}
+		user, err := i.userRepo.Read(ctx, batch.UserID)
+		if err != nil {
+			return entities.ErrUserNotFound
+		}
+		now := time.Now()
+
+		var adjustment *entities.PointBatchAdjustment
+		if req.Cancel {
+			adjustment, err = i.cancelBatch(ctx, batch, user, req, now, &resp)
+		} else {
+			adjustment, err = entities.NewPointBatchExpiryChange(batch, *req.ExpiresAt, req.AdminID, req.Reason, now)
+			if err == nil {
+				err = i.pointBatchRepo.AdjustExpiresAt(ctx, batch.ID, *req.ExpiresAt, now)
+			}
+			if err == nil {
+				batch.ExpiresAt = *req.ExpiresAt
+				batch.ExpiryAdjustedAt = &now
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := i.pointBatchRepo.CreateAdjustment(ctx, adjustment); err != nil {
+			return fmt.Errorf("failed to record point batch adjustment: %w", err)
+		}
+		resp.Batch = batch
+		resp.Adjustment = adjustment
+		resp.User = user
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []entities.Field{
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("batch_id", req.BatchID),
+		entities.NewField("user_id", resp.User.ID),
+		entities.NewField("action", resp.Adjustment.Action),
+		entities.NewField("previous_expires_at", resp.Adjustment.PreviousExpiresAt),
+		entities.NewField("reason", resp.Adjustment.Reason),
+	}
+	if resp.Adjustment.NewExpiresAt != nil {
+		fields = append(fields, entities.NewField("new_expires_at", *resp.Adjustment.NewExpiresAt))
+	}
+	if resp.Adjustment.CancelledAmount > 0 {
+		fields = append(fields, entities.NewField("cancelled_amount", resp.Adjustment.CancelledAmount))
+	}
+	i.logger.Info("Point batch adjusted", fields...)
+
+	return &resp, nil
+}
+
+// cancelBatch はバッチを取り消し、残っていたポイントを残高から減算する
+// 送金と同じくユーザーの残高を先にロックしてからバッチを更新し、読み取った後に消費されていればErrUpdateConflictにする
+// 取り消す分が確保中の送金リクエストの分に掛かればErrInsufficientBalance（確保が解放されるまで取り消せない）
+func (i *PointExpiryPolicyInteractor) cancelBatch(
+	ctx context.Context,
+	batch *entities.PointBatch,
+	user *entities.User,
+	req *inputport.AdjustBatchRequest,
+	now time.Time,
+	resp *inputport.AdjustBatchResponse,
+) (*entities.PointBatchAdjustment, error) {
+	adjustment, err := entities.NewPointBatchCancellation(batch, req.AdminID, req.Reason, now)
+	if err != nil {
+		return nil, err
+	}
+	amount := adjustment.CancelledAmount
+
+	if amount > 0 {
+		if err := i.userRepo.UpdateBalanceWithLock(ctx, batch.UserID, amount, true); err != nil {
+			return nil, err
+		}
+		user.Balance -= amount
+	}
+	if err := i.pointBatchRepo.Cancel(ctx, batch.ID, amount, now); err != nil {
+		return nil, err
+	}
+	batch.CancelledAt = &now
+	batch.CancelledAmount = amount
+	batch.RemainingAmount = 0
+
+	if amount == 0 {
+		return adjustment, nil
+	}
+	tx, err := entities.NewAdminDeduct(
+		batch.UserID,
+		amount,
+		fmt.Sprintf("ポイントバッチの取り消し（バッチ: %s）", batch.ID),
+		req.AdminID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	tx.Metadata["cancelled_batch_id"] = batch.ID.String()
+	tx.Metadata["reason"] = adjustment.Reason
+	if err := i.transactionRepo.Create(ctx, tx); err != nil {
+		return nil, err
+	}
+	adjustment.TransactionID = &tx.ID
+	resp.Transaction = tx
+	return adjustment, nil
+}
+
+// graceDays は失効を取り消せる猶予日数を取得（未設定・不正な値の場合は既定値）
+func (i *PointExpiryPolicyInteractor) graceDays(ctx context.Context) (int, error) {
+	value, err := i.systemSettingsRepo.GetSetting(ctx, entities.PointExpiryGraceDaysSettingKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get grace period: %w", err)
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return entities.DefaultPointExpiryGraceDays, nil
+	}
+	return days, nil
+}
+
+// GetWorkerStatus はポイント有効期限ワーカーの直近の実行状況を取得
+func (i *PointExpiryPolicyInteractor) GetWorkerStatus(ctx context.Context, adminID uuid.UUID) (*entities.PointExpiryRun, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+
+	value, err := i.systemSettingsRepo.GetSetting(ctx, entities.PointExpiryRunSettingKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get point expiry worker status: %w", err)
+	}
+	if value == "" {
+		return nil, nil
+	}
+
+	run := &entities.PointExpiryRun{}
+	if err := json.Unmarshal([]byte(value), run); err != nil {
+		return nil, fmt.Errorf("failed to parse point expiry worker status: %w", err)
+	}
+	return run, nil
+}
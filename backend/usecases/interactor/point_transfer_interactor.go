@@ -9,6 +9,13 @@ import (
 	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// 保留中・取り消した送金の冪等性キーの状態
+const (
+	idempotencyStatusHeld     = "held"
+	idempotencyStatusRejected = "rejected"
 )
 
 // PointTransferInteractor はポイント転送のユースケース実装
@@ -19,6 +26,12 @@ type PointTransferInteractor struct {
 	idempotencyRepo repository.IdempotencyKeyRepository
 	friendshipRepo  repository.FriendshipRepository
 	pointBatchRepo  repository.PointBatchRepository
+	holdRepo        repository.BalanceHoldRepository
+	notifications   inputport.NotificationDispatcher
+	screener        inputport.TransferScreener
+	eligibility     inputport.TransferEligibilityChecker
+	policy          inputport.TransferPolicyProvider
+	earningEvents   inputport.EarningEventRecorder
 	logger          entities.Logger
 }
 
@@ -30,6 +43,12 @@ func NewPointTransferInteractor(
 	idempotencyRepo repository.IdempotencyKeyRepository,
 	friendshipRepo repository.FriendshipRepository,
 	pointBatchRepo repository.PointBatchRepository,
+	holdRepo repository.BalanceHoldRepository,
+	notifications inputport.NotificationDispatcher,
+	screener inputport.TransferScreener,
+	eligibility inputport.TransferEligibilityChecker,
+	policy inputport.TransferPolicyProvider,
+	earningEvents inputport.EarningEventRecorder,
 	logger entities.Logger,
 ) *PointTransferInteractor {
 	return &PointTransferInteractor{
@@ -39,6 +58,12 @@ func NewPointTransferInteractor(
 		idempotencyRepo: idempotencyRepo,
 		friendshipRepo:  friendshipRepo,
 		pointBatchRepo:  pointBatchRepo,
+		holdRepo:        holdRepo,
+		notifications:   notifications,
+		screener:        screener,
+		eligibility:     eligibility,
+		policy:          policy,
+		earningEvents:   earningEvents,
 		logger:          logger,
 	}
 }
@@ -51,6 +76,11 @@ func NewPointTransferInteractor(
 // 3. 悲観的ロック: 残高更新時に競合を防止
 // 4. 残高チェック: 送信者の残高を厳密にチェック
 // 5. 友達チェック: 友達関係がある場合のみ転送可能（オプション）
+// 6. 送信者の条件: 管理者が設定した場合、メール未認証・作成直後のアカウントからは送金できない
+// 7. 不審な送金の検出: 検出した送金は記録して管理者へ通知し、保留が有効なら実行せずにErrTransferHeldを返す
+// 8. 上下限と手数料: 範囲外の送金額は受け付けず、手数料は送金額とは別に送信者から差し引いて別の取引として記録
+// 9. ポイント獲得ルール: 完了した送金をポイント獲得ルールに伝える（付与に失敗しても送金は失敗させない）
+// 10. 残高の確保: 送金リクエストのために確保した残高は、同じトランザクションで使用済みにして確保した分から差し引く
 //
 // 技術的説明:
 // - 高い分離レベルで一貫したスナップショットを保証
@@ -64,13 +94,13 @@ func (i *PointTransferInteractor) Transfer(ctx context.Context, req *inputport.T
 
 	// バリデーション
 	if req.FromUserID == req.ToUserID {
-		return nil, errors.New("cannot transfer to the same user")
+		return nil, entities.ErrSameUserTransfer
 	}
 	if req.Amount <= 0 {
-		return nil, errors.New("amount must be positive")
+		return nil, entities.ErrInvalidAmount
 	}
 	if req.IdempotencyKey == "" {
-		return nil, errors.New("idempotency key is required")
+		return nil, entities.ErrIdempotencyKeyRequired
 	}
 
 	// === 冪等性チェック ===
@@ -92,18 +122,63 @@ func (i *PointTransferInteractor) Transfer(ctx context.Context, req *inputport.T
 				Transaction: transaction,
 				FromUser:    fromUser,
 				ToUser:      toUser,
+				Fee:         transferFee(transaction),
 			}, nil
 		} else if existingKey.Status == "processing" {
 			// 処理中の場合はエラー（二重送信の可能性）
 			return nil, errors.New("transfer is already in progress")
+		} else if existingKey.Status == idempotencyStatusHeld && req.ReleasedBy == nil {
+			// 保留中の送金は管理者が確認するまで実行しない
+			return nil, entities.ErrTransferHeld
+		} else if existingKey.Status == idempotencyStatusRejected {
+			return nil, entities.ErrTransferRejected
+		}
+	}
+
+	// 送信者の条件（完了済みの再送は上で結果を返すため、条件が変わっても同じ結果になる）
+	if err := i.eligibility.CheckSender(ctx, req.FromUserID); err != nil {
+		return nil, err
+	}
+	if !req.RecipientConsented {
+		if err := i.eligibility.CheckRecipient(ctx, req.FromUserID, req.ToUserID); err != nil {
+			return nil, err
 		}
 	}
 
-	// 新しい冪等性キーを作成
-	idempotencyKey := entities.NewIdempotencyKey(req.IdempotencyKey, req.FromUserID)
-	if err := i.idempotencyRepo.Create(ctx, idempotencyKey); err != nil {
-		// 競合エラーの場合は二重送信
-		return nil, errors.New("duplicate idempotency key")
+	// 送金額の上下限と手数料（完了済みの再送は上で結果を返すため、設定が変わっても同じ結果になる）
+	policy, policyErr := i.policy.CurrentPolicy(ctx)
+	if policyErr != nil {
+		return nil, policyErr
+	}
+	if err := policy.CheckAmount(req.Amount); err != nil {
+		return nil, err
+	}
+	fee := policy.FeeFor(req.FromUserID, req.Amount)
+
+	var idempotencyKey *entities.IdempotencyKey
+	if err == nil && existingKey.Status == idempotencyStatusHeld {
+		// 保留を解除した送金は保留時のキーで実行する
+		idempotencyKey = existingKey
+		idempotencyKey.Status = "processing"
+		if err := i.idempotencyRepo.Update(ctx, idempotencyKey); err != nil {
+			return nil, err
+		}
+	} else {
+		// 新しい冪等性キーを作成
+		idempotencyKey = entities.NewIdempotencyKey(req.IdempotencyKey, req.FromUserID)
+		if err := i.idempotencyRepo.Create(ctx, idempotencyKey); err != nil {
+			// 競合エラーの場合は二重送信
+			return nil, errors.New("duplicate idempotency key")
+		}
+	}
+
+	// === 不審な送金の検出 ===
+	var suspicious *entities.SuspiciousActivity
+	if req.ReleasedBy == nil {
+		suspicious = i.screener.ScreenTransfer(ctx, req, time.Now())
+	}
+	if suspicious != nil && suspicious.IsHeld() {
+		return nil, i.hold(ctx, idempotencyKey, suspicious)
 	}
 
 	// === トランザクション開始 ===
@@ -130,27 +205,53 @@ func (i *PointTransferInteractor) Transfer(ctx context.Context, req *inputport.T
 			return errors.New("receiver account is not active")
 		}
 
-		// 3. 残高更新（悲観的ロックで競合を防止）
+		// 3. 送金リクエストのために確保した残高を使用済みにする
+		reserved, err := i.consumeHold(ctx, req.HoldFor)
+		if err != nil {
+			return err
+		}
+
+		// 4. 残高更新（悲観的ロックで競合を防止）
 		updates := []repository.BalanceUpdate{
-			{UserID: req.FromUserID, Amount: req.Amount, IsDeduct: true}, // 送信者から減算
-			{UserID: req.ToUserID, Amount: req.Amount, IsDeduct: false},  // 受信者に加算
+			{UserID: req.FromUserID, Amount: req.Amount + fee, IsDeduct: true, Reserved: reserved}, // 送信者から送金額と手数料を減算
+			{UserID: req.ToUserID, Amount: req.Amount, IsDeduct: false},                            // 受信者に加算
+		}
+		if fee > 0 && policy.FeeAccountID != nil {
+			// 受け取り用のユーザーがなければ手数料はシステムの手数料口座に入る
+			updates = append(updates, repository.BalanceUpdate{UserID: *policy.FeeAccountID, Amount: fee, IsDeduct: false})
 		}
 
 		if err := i.userRepo.UpdateBalancesWithLock(ctx, updates); err != nil {
 			return fmt.Errorf("failed to update balances: %w", err)
 		}
 
-		// 4. トランザクション記録作成
+		// 5. トランザクション記録作成
 		transaction, err = entities.NewTransfer(req.FromUserID, req.ToUserID, req.Amount, req.IdempotencyKey, req.Description)
 		if err != nil {
 			return err
 		}
 
+		if fee > 0 {
+			transaction.Metadata["fee"] = fee
+		}
+
 		if err := i.transactionRepo.Create(ctx, transaction); err != nil {
 			return fmt.Errorf("failed to create transaction: %w", err)
 		}
 
-		// 6. トランザクションを完了状態に
+		// 6. 手数料の取引を送金とは別に記録
+		var feeTransaction *entities.Transaction
+		if fee > 0 {
+			feeTransaction, err = entities.NewTransferFee(req.FromUserID, policy.FeeAccountID, fee, transaction)
+			if err != nil {
+				return err
+			}
+			if err := i.transactionRepo.Create(ctx, feeTransaction); err != nil {
+				return fmt.Errorf("failed to create fee transaction: %w", err)
+			}
+		}
+
+		// 7. トランザクションを完了状態に
 		if err := transaction.Complete(); err != nil {
 			return err
 		}
@@ -159,18 +260,24 @@ func (i *PointTransferInteractor) Transfer(ctx context.Context, req *inputport.T
 			return err
 		}
 
-		// 7. ポイントバッチ: 送信者のバッチからFIFO消費
-		if err := i.pointBatchRepo.ConsumePointsFIFO(ctx, req.FromUserID, req.Amount); err != nil {
+		// 8. ポイントバッチ: 送信者のバッチからFIFO消費
+		if err := i.pointBatchRepo.ConsumePointsFIFO(ctx, req.FromUserID, req.Amount+fee); err != nil {
 			return fmt.Errorf("failed to consume point batches: %w", err)
 		}
 
-		// 8. ポイントバッチ: 受信者のバッチを作成
+		// 9. ポイントバッチ: 受信者のバッチを作成
 		batch := entities.NewPointBatch(req.ToUserID, req.Amount, entities.PointBatchSourceTransfer, &transaction.ID, time.Now())
 		if err := i.pointBatchRepo.Create(ctx, batch); err != nil {
 			return fmt.Errorf("failed to create point batch: %w", err)
 		}
+		if feeTransaction != nil && feeTransaction.ToUserID != nil {
+			feeBatch := entities.NewPointBatch(*policy.FeeAccountID, fee, entities.PointBatchSourceTransfer, &feeTransaction.ID, time.Now())
+			if err := i.pointBatchRepo.Create(ctx, feeBatch); err != nil {
+				return fmt.Errorf("failed to create fee point batch: %w", err)
+			}
+		}
 
-		// 9. 冪等性キーを完了状態に
+		// 10. 冪等性キーを完了状態に
 		idempotencyKey.Status = "completed"
 		idempotencyKey.TransactionID = &transaction.ID
 		if err := i.idempotencyRepo.Update(ctx, idempotencyKey); err != nil {
@@ -182,8 +289,13 @@ func (i *PointTransferInteractor) Transfer(ctx context.Context, req *inputport.T
 
 	if err != nil {
 		// トランザクション失敗時は冪等性キーを失敗状態に
+		// 期限切れ・切断でcontextが終わっていても記録できるよう、キャンセルを外したcontextで更新する
 		idempotencyKey.Status = "failed"
-		i.idempotencyRepo.Update(ctx, idempotencyKey)
+		if updateErr := i.idempotencyRepo.Update(context.WithoutCancel(ctx), idempotencyKey); updateErr != nil {
+			i.logger.Error("Failed to mark idempotency key as failed",
+				entities.NewField("idempotency_key", idempotencyKey.Key),
+				entities.NewField("error", updateErr))
+		}
 		i.logger.Error("Point transfer failed", entities.NewField("error", err))
 		return nil, err
 	}
@@ -193,23 +305,133 @@ func (i *PointTransferInteractor) Transfer(ctx context.Context, req *inputport.T
 	toUser, _ = i.userRepo.Read(ctx, req.ToUserID)
 
 	i.logger.Info("Point transfer completed successfully",
-		entities.NewField("transaction_id", transaction.ID))
+		entities.NewField("transaction_id", transaction.ID),
+		entities.NewField("fee", fee))
+
+	if suspicious != nil {
+		suspicious.SetTransaction(transaction.ID, time.Now())
+		if err := i.screener.RecordSuspiciousTransfer(ctx, suspicious); err != nil {
+			i.logger.Error("Failed to record suspicious transfer",
+				entities.NewField("transaction_id", transaction.ID),
+				entities.NewField("error", err))
+		}
+	}
+
+	if fromUser != nil {
+		i.notifications.Dispatch(ctx, entities.NewPointsReceivedNotification(transaction, fromUser))
+	}
+	i.earningEvents.Record(ctx, entities.NewEarningEvent(entities.EarningTriggerTransferSent, req.FromUserID, req.Amount, transaction.ID.String()))
 
 	return &inputport.TransferResponse{
 		Transaction: transaction,
 		FromUser:    fromUser,
 		ToUser:      toUser,
+		Fee:         fee,
 	}, nil
 }
 
+// consumeHold は送金リクエストのために確保した残高を使用済みにし、確保していた額を返す（確保中でなければ0）
+// 確保を行ロックするため、同じリクエストの拒否・キャンセルによる解放とは同時に進まない
+func (i *PointTransferInteractor) consumeHold(ctx context.Context, transferRequestID *uuid.UUID) (int64, error) {
+	if transferRequestID == nil {
+		return 0, nil
+	}
+	hold, err := i.holdRepo.ReadByTransferRequestIDForUpdate(ctx, *transferRequestID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read balance hold: %w", err)
+	}
+	if hold == nil || !hold.IsActive() {
+		return 0, nil
+	}
+	if err := hold.Consume(time.Now()); err != nil {
+		return 0, err
+	}
+	if err := i.holdRepo.Update(ctx, hold); err != nil {
+		return 0, fmt.Errorf("failed to consume balance hold: %w", err)
+	}
+	return hold.Amount, nil
+}
+
+// QuoteTransfer は送金前に手数料と送信者が支払う合計額を見積もる
+// 上下限の範囲外の送金額はTransferと同じエラーを返す（残高不足はSufficientFundsで示す）
+func (i *PointTransferInteractor) QuoteTransfer(ctx context.Context, req *inputport.QuoteTransferRequest) (*inputport.QuoteTransferResponse, error) {
+	if req.Amount <= 0 {
+		return nil, entities.ErrInvalidAmount
+	}
+
+	policy, err := i.policy.CurrentPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := policy.CheckAmount(req.Amount); err != nil {
+		return nil, err
+	}
+
+	sender, err := i.userRepo.Read(ctx, req.FromUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	fee := policy.FeeFor(req.FromUserID, req.Amount)
+	total := req.Amount + fee
+	return &inputport.QuoteTransferResponse{
+		Amount:          req.Amount,
+		Fee:             fee,
+		Total:           total,
+		FeeType:         policy.FeeType,
+		MinAmount:       policy.MinAmount,
+		MaxAmount:       policy.MaxAmount,
+		Balance:         sender.AvailableBalance(),
+		SufficientFunds: sender.AvailableBalance() >= total,
+	}, nil
+}
+
+// transferFee は送金の取引に記録した手数料を読む（保存後はJSONの数値として読み戻される）
+func transferFee(transaction *entities.Transaction) int64 {
+	switch fee := transaction.Metadata["fee"].(type) {
+	case int64:
+		return fee
+	case float64:
+		return int64(fee)
+	default:
+		return 0
+	}
+}
+
+// hold は不審な送金を実行せずに保留し、管理者へ通知する
+// 記録できなかった場合は保留を確認できないため、送金の失敗として扱う
+func (i *PointTransferInteractor) hold(ctx context.Context, idempotencyKey *entities.IdempotencyKey, activity *entities.SuspiciousActivity) error {
+	if err := i.screener.RecordSuspiciousTransfer(ctx, activity); err != nil {
+		idempotencyKey.Status = "failed"
+		i.idempotencyRepo.Update(ctx, idempotencyKey)
+		i.logger.Error("Point transfer failed", entities.NewField("error", err))
+		return err
+	}
+
+	idempotencyKey.Status = idempotencyStatusHeld
+	if err := i.idempotencyRepo.Update(ctx, idempotencyKey); err != nil {
+		i.logger.Error("Failed to mark idempotency key as held",
+			entities.NewField("activity_id", activity.ID),
+			entities.NewField("error", err))
+	}
+
+	i.logger.Info("Point transfer held for review",
+		entities.NewField("activity_id", activity.ID),
+		entities.NewField("from_user_id", activity.FromUserID),
+		entities.NewField("to_user_id", activity.ToUserID),
+		entities.NewField("amount", activity.Amount))
+	return entities.ErrTransferHeld.WithParams(map[string]interface{}{"activity_id": activity.ID.String()})
+}
+
 // GetTransactionHistory はトランザクション履歴を取得
 func (i *PointTransferInteractor) GetTransactionHistory(ctx context.Context, req *inputport.GetTransactionHistoryRequest) (*inputport.GetTransactionHistoryResponse, error) {
-	results, err := i.transactionRepo.ReadListByUserIDWithUsers(ctx, req.UserID, req.Offset, req.Limit)
+	reasonCode := entities.NormalizeReasonCode(req.ReasonCode)
+	results, err := i.transactionRepo.ReadListByUserIDWithUsers(ctx, req.UserID, reasonCode, req.Offset, req.Limit)
 	if err != nil {
 		return nil, err
 	}
 
-	total, err := i.transactionRepo.CountByUserID(ctx, req.UserID)
+	total, err := i.transactionRepo.CountByUserID(ctx, req.UserID, reasonCode)
 	if err != nil {
 		return nil, err
 	}
@@ -218,9 +440,10 @@ func (i *PointTransferInteractor) GetTransactionHistory(ctx context.Context, req
 	transactionsWithUsers := make([]*inputport.TransactionWithUsersForHistory, 0, len(results))
 	for _, r := range results {
 		transactionsWithUsers = append(transactionsWithUsers, &inputport.TransactionWithUsersForHistory{
-			Transaction: r.Transaction,
-			FromUser:    r.FromUser,
-			ToUser:      r.ToUser,
+			Transaction:   r.Transaction,
+			FromUser:      r.FromUser,
+			ToUser:        r.ToUser,
+			HasAttachment: r.HasAttachment,
 		})
 	}
 
@@ -238,8 +461,10 @@ func (i *PointTransferInteractor) GetBalance(ctx context.Context, req *inputport
 	}
 
 	return &inputport.GetBalanceResponse{
-		Balance: user.Balance,
-		User:    user,
+		Balance:   user.Balance,
+		Reserved:  user.ReservedBalance,
+		Available: user.AvailableBalance(),
+		User:      user,
 	}, nil
 }
 
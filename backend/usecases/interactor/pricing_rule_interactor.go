@@ -0,0 +1,158 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPricingRuleListLimit = 20
+	maxPricingRuleListLimit     = 100
+)
+
+// PricingRuleInteractor は価格ルール管理のユースケース実装
+type PricingRuleInteractor struct {
+	pricingRuleRepo repository.PricingRuleRepository
+	productRepo     repository.ProductRepository
+	userRepo        repository.UserRepository
+	logger          entities.Logger
+}
+
+// NewPricingRuleInteractor は新しいPricingRuleInteractorを作成
+func NewPricingRuleInteractor(
+	pricingRuleRepo repository.PricingRuleRepository,
+	productRepo repository.ProductRepository,
+	userRepo repository.UserRepository,
+	logger entities.Logger,
+) inputport.PricingRuleInputPort {
+	return &PricingRuleInteractor{
+		pricingRuleRepo: pricingRuleRepo,
+		productRepo:     productRepo,
+		userRepo:        userRepo,
+		logger:          logger,
+	}
+}
+
+// CreatePricingRule は価格ルールを作成
+func (i *PricingRuleInteractor) CreatePricingRule(ctx context.Context, req *inputport.CreatePricingRuleRequest) (*entities.PricingRule, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	if err := i.checkProduct(ctx, req.ProductID); err != nil {
+		return nil, err
+	}
+
+	c := req.PricingRuleContent
+	rule, err := entities.NewPricingRule(c.Name, c.ProductID, c.CategoryCode, c.DiscountType, c.DiscountValue, c.TargetRole, c.MinTier, c.MaxQuantityPerUser, c.StartsAt, c.EndsAt, req.AdminID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.pricingRuleRepo.Create(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to create pricing rule: %w", err)
+	}
+
+	i.logger.Info("Pricing rule created",
+		entities.NewField("pricing_rule_id", rule.ID),
+		entities.NewField("admin_id", req.AdminID))
+
+	return rule, nil
+}
+
+// UpdatePricingRule は価格ルールを更新
+func (i *PricingRuleInteractor) UpdatePricingRule(ctx context.Context, req *inputport.UpdatePricingRuleRequest) (*entities.PricingRule, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	if err := i.checkProduct(ctx, req.ProductID); err != nil {
+		return nil, err
+	}
+
+	rule, err := i.pricingRuleRepo.Read(ctx, req.PricingRuleID)
+	if err != nil {
+		return nil, err
+	}
+
+	c := req.PricingRuleContent
+	if err := rule.Update(c.Name, c.ProductID, c.CategoryCode, c.DiscountType, c.DiscountValue, c.TargetRole, c.MinTier, c.MaxQuantityPerUser, c.StartsAt, c.EndsAt, req.IsActive); err != nil {
+		return nil, err
+	}
+
+	if err := i.pricingRuleRepo.Update(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to update pricing rule: %w", err)
+	}
+
+	i.logger.Info("Pricing rule updated",
+		entities.NewField("pricing_rule_id", rule.ID),
+		entities.NewField("admin_id", req.AdminID))
+
+	return rule, nil
+}
+
+// DeletePricingRule は価格ルールを削除
+func (i *PricingRuleInteractor) DeletePricingRule(ctx context.Context, req *inputport.DeletePricingRuleRequest) error {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return err
+	}
+
+	if err := i.pricingRuleRepo.Delete(ctx, req.PricingRuleID); err != nil {
+		return err
+	}
+
+	i.logger.Info("Pricing rule deleted",
+		entities.NewField("pricing_rule_id", req.PricingRuleID),
+		entities.NewField("admin_id", req.AdminID))
+
+	return nil
+}
+
+// GetPricingRuleList は価格ルールの一覧を取得
+func (i *PricingRuleInteractor) GetPricingRuleList(ctx context.Context, req *inputport.GetPricingRuleListRequest) (*inputport.GetPricingRuleListResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultPricingRuleListLimit
+	}
+	if limit > maxPricingRuleListLimit {
+		limit = maxPricingRuleListLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	rules, err := i.pricingRuleRepo.ReadList(ctx, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pricing rules: %w", err)
+	}
+	total, err := i.pricingRuleRepo.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pricing rules: %w", err)
+	}
+
+	return &inputport.GetPricingRuleListResponse{
+		Rules:  rules,
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+	}, nil
+}
+
+// checkProduct は対象商品が存在するかを確認（カテゴリ指定なら何もしない）
+func (i *PricingRuleInteractor) checkProduct(ctx context.Context, productID *uuid.UUID) error {
+	if productID == nil {
+		return nil
+	}
+	if _, err := i.productRepo.Read(ctx, *productID); err != nil {
+		return entities.ErrInvalidPricingRule
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
@@ -19,6 +20,10 @@ type ProductExchangeInteractor struct {
 	userRepo        repository.UserRepository
 	transactionRepo repository.TransactionRepository
 	pointBatchRepo  repository.PointBatchRepository
+	pricingRuleRepo repository.PricingRuleRepository
+	friendshipRepo  repository.FriendshipRepository
+	addressRepo     repository.ShippingAddressRepository
+	notifications   inputport.NotificationDispatcher
 	logger          entities.Logger
 }
 
@@ -30,6 +35,10 @@ func NewProductExchangeInteractor(
 	userRepo repository.UserRepository,
 	transactionRepo repository.TransactionRepository,
 	pointBatchRepo repository.PointBatchRepository,
+	pricingRuleRepo repository.PricingRuleRepository,
+	friendshipRepo repository.FriendshipRepository,
+	addressRepo repository.ShippingAddressRepository,
+	notifications inputport.NotificationDispatcher,
 	logger entities.Logger,
 ) *ProductExchangeInteractor {
 	return &ProductExchangeInteractor{
@@ -39,6 +48,10 @@ func NewProductExchangeInteractor(
 		userRepo:        userRepo,
 		transactionRepo: transactionRepo,
 		pointBatchRepo:  pointBatchRepo,
+		pricingRuleRepo: pricingRuleRepo,
+		friendshipRepo:  friendshipRepo,
+		addressRepo:     addressRepo,
+		notifications:   notifications,
 		logger:          logger,
 	}
 }
@@ -50,6 +63,10 @@ func NewProductExchangeInteractor(
 // 2. 悲観的ロック: 在庫とユーザー残高をロック
 // 3. 残高チェック: 十分なポイントがあるか確認
 // 4. 在庫チェック: 十分な在庫があるか確認
+// 5. 価格ルール: 交換時点で有効な割引を適用し、適用内容を取引のメタデータに残す
+//
+// RecipientID を指定すると友達への贈り物になる（ポイントは交換した人が払い、贈られた人へ通知する）
+// 配送が必要な商品はお届け先が必須で、交換記録に住所を控える（贈り物は受け取り時に受取人のお届け先を控える）
 func (i *ProductExchangeInteractor) ExchangeProduct(ctx context.Context, req *inputport.ExchangeProductRequest) (*inputport.ExchangeProductResponse, error) {
 	i.logger.Info("Starting product exchange",
 		entities.NewField("user_id", req.UserID),
@@ -58,13 +75,15 @@ func (i *ProductExchangeInteractor) ExchangeProduct(ctx context.Context, req *in
 
 	// バリデーション
 	if req.Quantity <= 0 {
-		return nil, errors.New("quantity must be positive")
+		return nil, entities.ErrInvalidQuantity
 	}
 
 	var user *entities.User
 	var product *entities.Product
 	var exchange *entities.ProductExchange
 	var transaction *entities.Transaction
+	var quote *entities.PriceQuote
+	var address *entities.ShippingAddress
 
 	err := i.txManager.Do(ctx, func(ctx context.Context) error {
 
@@ -80,10 +99,7 @@ func (i *ProductExchangeInteractor) ExchangeProduct(ctx context.Context, req *in
 			return fmt.Errorf("cannot exchange product: %w", err)
 		}
 
-		// 3. 必要なポイント数を計算
-		totalPoints := product.Price * int64(req.Quantity)
-
-		// 4. ユーザー情報を取得（残高確認のためロック）
+		// 3. ユーザー情報を取得（残高確認のためロック）
 		user, err = i.userRepo.Read(ctx, req.UserID)
 		if err != nil {
 			return fmt.Errorf("user not found: %w", err)
@@ -93,9 +109,29 @@ func (i *ProductExchangeInteractor) ExchangeProduct(ctx context.Context, req *in
 			return errors.New("user account is not active")
 		}
 
-		// 5. 残高チェック
-		if user.Balance < totalPoints {
-			return fmt.Errorf("insufficient balance: required %d, have %d", totalPoints, user.Balance)
+		// 贈り物は友達にだけ贈れる
+		if req.RecipientID != nil {
+			if err := i.checkGiftRecipient(ctx, req.UserID, *req.RecipientID); err != nil {
+				return err
+			}
+		} else if !product.IsDigital {
+			// 配送が必要な商品はお届け先を決めておく
+			address, err = resolveShippingAddress(ctx, i.addressRepo, req.UserID, req.ShippingAddressID)
+			if err != nil {
+				return err
+			}
+		}
+
+		// 4. 価格ルールを適用して必要なポイント数を計算
+		quote, err = resolveExchangePrice(ctx, i.pricingRuleRepo, i.exchangeRepo, product, user, req.Quantity, nil)
+		if err != nil {
+			return err
+		}
+		totalPoints := quote.UnitPrice * int64(req.Quantity)
+
+		// 5. 残高チェック（送金リクエストのために確保中の額は使えない）
+		if user.AvailableBalance() < totalPoints {
+			return entities.ErrInsufficientBalance.WithParams(map[string]interface{}{"balance": user.AvailableBalance(), "required": totalPoints})
 		}
 
 		// 6. 在庫を減らす（商品テーブルを更新）
@@ -125,6 +161,9 @@ func (i *ProductExchangeInteractor) ExchangeProduct(ctx context.Context, req *in
 		if err != nil {
 			return fmt.Errorf("failed to create transaction: %w", err)
 		}
+		for k, v := range quote.TransactionMetadata() {
+			transaction.Metadata[k] = v
+		}
 
 		if err := i.transactionRepo.Create(ctx, transaction); err != nil {
 			return fmt.Errorf("failed to save transaction: %w", err)
@@ -146,10 +185,28 @@ func (i *ProductExchangeInteractor) ExchangeProduct(ctx context.Context, req *in
 		if err != nil {
 			return fmt.Errorf("failed to create exchange: %w", err)
 		}
+		if quote.Rule != nil {
+			exchange.PricingRuleID = &quote.Rule.ID
+		}
 
 		if err := exchange.Complete(transaction.ID); err != nil {
 			return fmt.Errorf("failed to complete exchange: %w", err)
 		}
+		if req.RecipientID != nil {
+			if err := exchange.SendAsGift(*req.RecipientID); err != nil {
+				return err
+			}
+		}
+		if address != nil {
+			exchange.ShipTo(address)
+		}
+
+		// デジタル商品は受け取り時に提示する引換コードを発行
+		if product.IsDigital {
+			if err := exchange.AssignRedemptionCode(); err != nil {
+				return err
+			}
+		}
 
 		if err := i.exchangeRepo.Create(ctx, exchange); err != nil {
 			return fmt.Errorf("failed to save exchange: %w", err)
@@ -171,14 +228,82 @@ func (i *ProductExchangeInteractor) ExchangeProduct(ctx context.Context, req *in
 		entities.NewField("exchange_id", exchange.ID),
 		entities.NewField("points_used", exchange.PointsUsed))
 
+	if exchange.IsGift() && user != nil && product != nil {
+		i.notifications.Dispatch(ctx, entities.NewGiftReceivedNotification(exchange, product, user))
+	}
+
 	return &inputport.ExchangeProductResponse{
 		Exchange:    exchange,
 		Product:     product,
 		User:        user,
 		Transaction: transaction,
+		UnitPrice:   quote.UnitPrice,
+		PricingRule: quote.Rule,
 	}, nil
 }
 
+// checkGiftRecipient は贈り物の受け取る人が有効な友達かどうかを確認する
+func (i *ProductExchangeInteractor) checkGiftRecipient(ctx context.Context, userID, recipientID uuid.UUID) error {
+	if recipientID == uuid.Nil || recipientID == userID {
+		return entities.ErrInvalidGift
+	}
+	recipient, err := i.userRepo.Read(ctx, recipientID)
+	if err != nil {
+		return fmt.Errorf("recipient not found: %w", err)
+	}
+	if !recipient.IsActive {
+		return entities.ErrInvalidGift
+	}
+	friends, err := i.friendshipRepo.CheckAreFriends(ctx, userID, recipientID)
+	if err != nil {
+		return fmt.Errorf("failed to check friendship: %w", err)
+	}
+	if !friends {
+		return entities.ErrNotFriends
+	}
+	return nil
+}
+
+// resolveExchangePrice は交換時点の実効単価を求め、適用するルールの購入上限を確認する
+// pending は同じ決済で先に交換する数量（価格ルールIDごと）で、購入上限の計算に含める（単品の交換ではnil）
+func resolveExchangePrice(
+	ctx context.Context,
+	pricingRuleRepo repository.PricingRuleRepository,
+	exchangeRepo repository.ProductExchangeRepository,
+	product *entities.Product,
+	user *entities.User,
+	quantity int,
+	pending map[uuid.UUID]int,
+) (*entities.PriceQuote, error) {
+	now := time.Now()
+	rules, err := pricingRuleRepo.ReadActiveForProduct(ctx, product.ID, product.CategoryCode, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pricing rules: %w", err)
+	}
+
+	quote := entities.ResolvePrice(product, rules, user, now)
+	if quote.Rule == nil || quote.Rule.MaxQuantityPerUser == 0 {
+		return quote, nil
+	}
+
+	purchased, err := exchangeRepo.SumQuantityByPricingRule(ctx, user.ID, quote.Rule.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count sale purchases: %w", err)
+	}
+	purchased += pending[quote.Rule.ID]
+	if purchased+quantity > quote.Rule.MaxQuantityPerUser {
+		remaining := quote.Rule.MaxQuantityPerUser - purchased
+		if remaining < 0 {
+			remaining = 0
+		}
+		return nil, entities.ErrSaleLimitExceeded.WithParams(map[string]interface{}{
+			"limit":     quote.Rule.MaxQuantityPerUser,
+			"remaining": remaining,
+		})
+	}
+	return quote, nil
+}
+
 // GetExchangeHistory は交換履歴を取得
 func (i *ProductExchangeInteractor) GetExchangeHistory(ctx context.Context, req *inputport.GetExchangeHistoryRequest) (*inputport.GetExchangeHistoryResponse, error) {
 	exchanges, err := i.exchangeRepo.ReadListByUserID(ctx, req.UserID, req.Offset, req.Limit)
@@ -197,19 +322,120 @@ func (i *ProductExchangeInteractor) GetExchangeHistory(ctx context.Context, req
 	}, nil
 }
 
+// GetReceivedGifts は友達から贈られた交換を取得
+func (i *ProductExchangeInteractor) GetReceivedGifts(ctx context.Context, req *inputport.GetExchangeHistoryRequest) (*inputport.GetExchangeHistoryResponse, error) {
+	exchanges, err := i.exchangeRepo.ReadListByRecipientID(ctx, req.UserID, req.Offset, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := i.exchangeRepo.CountByRecipientID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inputport.GetExchangeHistoryResponse{
+		Exchanges: exchanges,
+		Total:     total,
+	}, nil
+}
+
+// AcceptGift は贈られた交換を受け取る（配送が必要な商品は受取人のお届け先を控える）
+func (i *ProductExchangeInteractor) AcceptGift(ctx context.Context, req *inputport.RespondGiftRequest) (*entities.ProductExchange, error) {
+	exchange, err := i.exchangeRepo.Read(ctx, req.ExchangeID)
+	if err != nil {
+		return nil, entities.ErrGiftNotFound
+	}
+	if err := exchange.AcceptGift(req.UserID, time.Now()); err != nil {
+		return nil, err
+	}
+	product, err := i.productRepo.Read(ctx, exchange.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("product not found: %w", err)
+	}
+	if !product.IsDigital {
+		address, err := resolveShippingAddress(ctx, i.addressRepo, req.UserID, req.ShippingAddressID)
+		if err != nil {
+			return nil, err
+		}
+		exchange.ShipTo(address)
+	}
+	if err := i.exchangeRepo.Update(ctx, exchange); err != nil {
+		return nil, fmt.Errorf("failed to update exchange: %w", err)
+	}
+
+	i.logger.Info("Gift accepted",
+		entities.NewField("exchange_id", exchange.ID),
+		entities.NewField("recipient_id", req.UserID))
+
+	return exchange, nil
+}
+
+// DeclineGift は贈られた交換を辞退する
+// 交換をキャンセルし、在庫を戻して、ポイントは贈った人へ返す
+func (i *ProductExchangeInteractor) DeclineGift(ctx context.Context, req *inputport.RespondGiftRequest) (*entities.ProductExchange, error) {
+	var exchange *entities.ProductExchange
+
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		exchange, err = i.exchangeRepo.Read(ctx, req.ExchangeID)
+		if err != nil {
+			return entities.ErrGiftNotFound
+		}
+		if err := exchange.DeclineGift(req.UserID, time.Now()); err != nil {
+			return err
+		}
+
+		// 在庫を戻す
+		product, err := i.productRepo.Read(ctx, exchange.ProductID)
+		if err != nil {
+			return fmt.Errorf("product not found: %w", err)
+		}
+		if err := product.RestoreStock(exchange.Quantity); err != nil {
+			return fmt.Errorf("failed to restore stock: %w", err)
+		}
+		if err := i.productRepo.Update(ctx, product); err != nil {
+			return fmt.Errorf("failed to update product: %w", err)
+		}
+
+		// ポイントは払った人へ戻す
+		updates := []repository.BalanceUpdate{
+			{UserID: exchange.UserID, Amount: exchange.PointsUsed, IsDeduct: false},
+		}
+		if err := i.userRepo.UpdateBalancesWithLock(ctx, updates); err != nil {
+			return fmt.Errorf("failed to restore balance: %w", err)
+		}
+
+		if err := i.exchangeRepo.Update(ctx, exchange); err != nil {
+			return fmt.Errorf("failed to update exchange: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Gift declined and refunded",
+		entities.NewField("exchange_id", exchange.ID),
+		entities.NewField("payer_id", exchange.UserID),
+		entities.NewField("points_refunded", exchange.PointsUsed))
+
+	return exchange, nil
+}
+
 // CancelExchange は交換をキャンセル
 func (i *ProductExchangeInteractor) CancelExchange(ctx context.Context, req *inputport.CancelExchangeRequest) error {
 	return i.txManager.Do(ctx, func(ctx context.Context) error {
 
-		// 交換情報を取得
+		// 交換情報を取得（見つからなければErrExchangeNotFound）
 		exchange, err := i.exchangeRepo.Read(ctx, req.ExchangeID)
 		if err != nil {
-			return fmt.Errorf("exchange not found: %w", err)
+			return err
 		}
 
-		// 権限チェック
-		if exchange.UserID != req.UserID {
-			return errors.New("unauthorized: not your exchange")
+		// 権限チェック（交換した人のみ）
+		if err := exchange.Authorize(req.UserID, entities.ActionCancel); err != nil {
+			return err
 		}
 
 		// キャンセル可能かチェック
@@ -254,7 +480,7 @@ func (i *ProductExchangeInteractor) MarkExchangeDelivered(ctx context.Context, r
 
 		exchange, err := i.exchangeRepo.Read(ctx, req.ExchangeID)
 		if err != nil {
-			return fmt.Errorf("exchange not found: %w", err)
+			return err
 		}
 
 		if err := exchange.MarkAsDelivered(); err != nil {
@@ -281,8 +507,134 @@ func (i *ProductExchangeInteractor) GetAllExchanges(ctx context.Context, offset,
 		return nil, err
 	}
 
+	// 贈り物は受け渡し先が払った人と異なるため、受け取る人を添える
+	recipients := make(map[uuid.UUID]*inputport.ExchangeRecipient)
+	for _, exchange := range exchanges {
+		if !exchange.IsGift() {
+			continue
+		}
+		recipient, err := i.userRepo.Read(ctx, *exchange.RecipientID)
+		if err != nil {
+			continue
+		}
+		recipients[exchange.ID] = newExchangeRecipient(recipient)
+	}
+
 	return &inputport.GetExchangeHistoryResponse{
-		Exchanges: exchanges,
-		Total:     total,
+		Exchanges:  exchanges,
+		Total:      total,
+		Recipients: recipients,
+	}, nil
+}
+
+// ValidateRedemptionCode は引換コードに対応する交換を確認する（管理者用、使用済みにはしない）
+func (i *ProductExchangeInteractor) ValidateRedemptionCode(ctx context.Context, req *inputport.RedeemCodeRequest) (*inputport.RedeemCodeResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	code := entities.NormalizeRedemptionCode(req.Code)
+	if code == "" {
+		return nil, entities.ErrRedemptionCodeNotFound
+	}
+
+	exchange, err := i.exchangeRepo.ReadByRedemptionCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	product, err := i.productRepo.Read(ctx, exchange.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("product not found: %w", err)
+	}
+
+	resp := &inputport.RedeemCodeResponse{
+		Exchange:   exchange,
+		Product:    product,
+		Redeemable: exchange.CanRedeem(),
+	}
+	if exchange.IsGift() {
+		recipient, err := i.userRepo.Read(ctx, *exchange.RecipientID)
+		if err != nil {
+			return nil, fmt.Errorf("recipient not found: %w", err)
+		}
+		resp.Recipient = newExchangeRecipient(recipient)
+	}
+	return resp, nil
+}
+
+// newExchangeRecipient は受け渡しに必要なユーザー情報だけを取り出す
+func newExchangeRecipient(user *entities.User) *inputport.ExchangeRecipient {
+	return &inputport.ExchangeRecipient{
+		UserID:       user.ID,
+		Username:     user.Username,
+		DisplayName:  user.DisplayName,
+		Email:        user.Email,
+		DepartmentID: user.DepartmentID,
+	}
+}
+
+// RedeemCode は引換コードを使用済みにし、受け渡し済みにする（管理者用）
+// 同じコードを同時に引き換えても成功するのは1回だけ
+func (i *ProductExchangeInteractor) RedeemCode(ctx context.Context, req *inputport.RedeemCodeRequest) (*inputport.RedeemCodeResponse, error) {
+	resp, err := i.ValidateRedemptionCode(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resp.Exchange.Redeem(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := i.exchangeRepo.MarkRedeemed(ctx, resp.Exchange); err != nil {
+		return nil, err
+	}
+	resp.Redeemable = false
+
+	i.logger.Info("Redemption code redeemed",
+		entities.NewField("exchange_id", resp.Exchange.ID),
+		entities.NewField("product_id", resp.Exchange.ProductID),
+		entities.NewField("admin_id", req.AdminID))
+
+	return resp, nil
+}
+
+// exchangeReportDefaultPeriods は期間未指定時に遡る期間数
+const exchangeReportDefaultPeriods = 12
+
+// exchangeReportMaxYears は指定できる集計期間の上限（年）
+const exchangeReportMaxYears = 2
+
+// GetExchangeReport は商品ごと・期間ごとの交換実績を集計する（管理者用、在庫計画向け）
+func (i *ProductExchangeInteractor) GetExchangeReport(ctx context.Context, req *inputport.GetExchangeReportRequest) (*inputport.GetExchangeReportResponse, error) {
+	granularity := req.Granularity
+	if !granularity.IsValid() {
+		granularity = entities.AnalyticsGranularityWeek
+	}
+
+	to := req.To
+	if to.IsZero() {
+		now := time.Now()
+		to = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	}
+	from := req.From
+	if from.IsZero() {
+		from = granularity.Truncate(to.Add(-time.Nanosecond))
+		for n := 1; n < exchangeReportDefaultPeriods; n++ {
+			from = granularity.Truncate(from.Add(-time.Nanosecond))
+		}
+	}
+	if !from.Before(to) || to.After(from.AddDate(exchangeReportMaxYears, 0, 0)) {
+		return nil, entities.ErrInvalidDateRange
+	}
+
+	rows, err := i.exchangeRepo.ReadReport(ctx, from, to, granularity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate exchanges: %w", err)
+	}
+
+	return &inputport.GetExchangeReportResponse{
+		From:        from,
+		To:          to,
+		Granularity: granularity,
+		Rows:        rows,
 	}, nil
 }
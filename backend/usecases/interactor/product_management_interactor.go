@@ -7,22 +7,26 @@ import (
 	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
 )
 
 // ProductManagementInteractor は商品管理のユースケース実装（管理者用）
 type ProductManagementInteractor struct {
-	productRepo repository.ProductRepository
-	logger      entities.Logger
+	productRepo       repository.ProductRepository
+	contentModeration inputport.ContentModerationInputPort
+	logger            entities.Logger
 }
 
 // NewProductManagementInteractor は新しいProductManagementInteractorを作成
 func NewProductManagementInteractor(
 	productRepo repository.ProductRepository,
+	contentModeration inputport.ContentModerationInputPort,
 	logger entities.Logger,
 ) inputport.ProductManagementInputPort {
 	return &ProductManagementInteractor{
-		productRepo: productRepo,
-		logger:      logger,
+		productRepo:       productRepo,
+		contentModeration: contentModeration,
+		logger:            logger,
 	}
 }
 
@@ -30,6 +34,10 @@ func NewProductManagementInteractor(
 func (i *ProductManagementInteractor) CreateProduct(ctx context.Context, req *inputport.CreateProductRequest) (*inputport.CreateProductResponse, error) {
 	i.logger.Info("Creating new product", entities.NewField("name", req.Name))
 
+	if err := i.moderateProduct(ctx, req.AdminID, req.Name, req.Description); err != nil {
+		return nil, err
+	}
+
 	product, err := entities.NewProduct(
 		req.Name,
 		req.Description,
@@ -42,6 +50,7 @@ func (i *ProductManagementInteractor) CreateProduct(ctx context.Context, req *in
 	}
 
 	product.ImageURL = req.ImageURL
+	product.IsDigital = req.IsDigital
 
 	if err := i.productRepo.Create(ctx, product); err != nil {
 		i.logger.Error("Failed to create product", entities.NewField("error", err))
@@ -64,6 +73,10 @@ func (i *ProductManagementInteractor) UpdateProduct(ctx context.Context, req *in
 		return nil, fmt.Errorf("product not found: %w", err)
 	}
 
+	if err := i.moderateProduct(ctx, req.AdminID, req.Name, req.Description); err != nil {
+		return nil, err
+	}
+
 	// 商品情報を更新
 	product.Name = req.Name
 	product.Description = req.Description
@@ -72,6 +85,7 @@ func (i *ProductManagementInteractor) UpdateProduct(ctx context.Context, req *in
 	product.Stock = req.Stock
 	product.ImageURL = req.ImageURL
 	product.IsAvailable = req.IsAvailable
+	product.IsDigital = req.IsDigital
 
 	if err := i.productRepo.Update(ctx, product); err != nil {
 		i.logger.Error("Failed to update product", entities.NewField("error", err))
@@ -128,3 +142,11 @@ func (i *ProductManagementInteractor) GetProductList(ctx context.Context, req *i
 		Total:    total,
 	}, nil
 }
+
+// moderateProduct は商品名と説明文をモデレーションする
+func (i *ProductManagementInteractor) moderateProduct(ctx context.Context, adminID uuid.UUID, name, description string) error {
+	return checkContents(ctx, i.contentModeration, adminID,
+		moderationTarget{field: entities.ModerationFieldProductName, text: name},
+		moderationTarget{field: entities.ModerationFieldProductDescription, text: description},
+	)
+}
@@ -4,28 +4,39 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
 )
 
 // QRCodeInteractor はQRコード機能のユースケース実装
 type QRCodeInteractor struct {
 	qrCodeRepo      repository.QRCodeRepository
+	userRepo        repository.UserRepository
 	pointTransferUC inputport.PointTransferInputPort
+	notifier        service.RealtimeNotifier
+	codec           service.QRPayloadCodec
 	logger          entities.Logger
 }
 
 // NewQRCodeInteractor は新しいQRCodeInteractorを作成
 func NewQRCodeInteractor(
 	qrCodeRepo repository.QRCodeRepository,
+	userRepo repository.UserRepository,
 	pointTransferUC inputport.PointTransferInputPort,
+	notifier service.RealtimeNotifier,
+	codec service.QRPayloadCodec,
 	logger entities.Logger,
 ) inputport.QRCodeInputPort {
 	return &QRCodeInteractor{
 		qrCodeRepo:      qrCodeRepo,
+		userRepo:        userRepo,
 		pointTransferUC: pointTransferUC,
+		notifier:        notifier,
+		codec:           codec,
 		logger:          logger,
 	}
 }
@@ -35,7 +46,7 @@ func (i *QRCodeInteractor) GenerateReceiveQR(ctx context.Context, req *inputport
 	i.logger.Info("Generating receive QR code", entities.NewField("user_id", req.UserID))
 
 	if req.Amount != nil && *req.Amount <= 0 {
-		return nil, errors.New("amount must be positive")
+		return nil, entities.ErrInvalidAmount
 	}
 
 	qrCode, err := entities.NewReceiveQRCode(req.UserID, req.Amount)
@@ -47,10 +58,9 @@ func (i *QRCodeInteractor) GenerateReceiveQR(ctx context.Context, req *inputport
 		return nil, err
 	}
 
-	// QRコードデータの形式: qr_type:code:amount
-	qrCodeData := fmt.Sprintf("receive:%s", qrCode.Code)
-	if qrCode.Amount != nil {
-		qrCodeData = fmt.Sprintf("%s:%d", qrCodeData, *qrCode.Amount)
+	qrCodeData, err := i.codec.Encode(entities.NewQRCodePayload(qrCode))
+	if err != nil {
+		return nil, err
 	}
 
 	return &inputport.GenerateReceiveQRResponse{
@@ -64,7 +74,7 @@ func (i *QRCodeInteractor) GenerateSendQR(ctx context.Context, req *inputport.Ge
 	i.logger.Info("Generating send QR code", entities.NewField("user_id", req.UserID))
 
 	if req.Amount <= 0 {
-		return nil, errors.New("amount must be positive")
+		return nil, entities.ErrInvalidAmount
 	}
 
 	qrCode, err := entities.NewSendQRCode(req.UserID, req.Amount)
@@ -76,7 +86,10 @@ func (i *QRCodeInteractor) GenerateSendQR(ctx context.Context, req *inputport.Ge
 		return nil, err
 	}
 
-	qrCodeData := fmt.Sprintf("send:%s:%d", qrCode.Code, req.Amount)
+	qrCodeData, err := i.codec.Encode(entities.NewQRCodePayload(qrCode))
+	if err != nil {
+		return nil, err
+	}
 
 	return &inputport.GenerateSendQRResponse{
 		QRCode:     qrCode,
@@ -90,10 +103,15 @@ func (i *QRCodeInteractor) ScanQR(ctx context.Context, req *inputport.ScanQRRequ
 		entities.NewField("user_id", req.UserID),
 		entities.NewField("code", req.Code))
 
-	// QRコード取得
-	qrCode, err := i.qrCodeRepo.ReadByCode(ctx, req.Code)
+	payload, err := i.scannedPayload(req.Code)
 	if err != nil {
-		return nil, errors.New("qr code not found")
+		return nil, err
+	}
+
+	// QRコード取得（読み取った種類と違うものは使わせない）
+	qrCode, err := i.qrCodeRepo.ReadByCode(ctx, payload.Data)
+	if err != nil || (payload.Type != "" && string(qrCode.QRType) != string(payload.Type)) {
+		return nil, entities.ErrQRCodeNotFound
 	}
 
 	// QRコード検証
@@ -119,6 +137,9 @@ func (i *QRCodeInteractor) ScanQR(ctx context.Context, req *inputport.ScanQRRequ
 		fromUserID, toUserID = qrCode.UserID, req.UserID
 	}
 
+	// QRコードの持ち主の画面に読み取られたことを即時に知らせる
+	i.notifier.Notify(ctx, qrCode.UserID, entities.NewQRScannedEvent(qrCode, req.UserID, amount))
+
 	// ポイント転送実行
 	transferResp, err := i.pointTransferUC.Transfer(ctx, &inputport.TransferRequest{
 		FromUserID:     fromUserID,
@@ -126,6 +147,8 @@ func (i *QRCodeInteractor) ScanQR(ctx context.Context, req *inputport.ScanQRRequ
 		Amount:         amount,
 		IdempotencyKey: req.IdempotencyKey,
 		Description:    fmt.Sprintf("QR code transfer: %s", qrCode.Code),
+		// 受取用は受取人が作ったQRコード、送信用は受取人が読み取るため、受け取る相手の制限は確かめない
+		RecipientConsented: true,
 	})
 
 	if err != nil {
@@ -141,6 +164,13 @@ func (i *QRCodeInteractor) ScanQR(ctx context.Context, req *inputport.ScanQRRequ
 		}
 	}
 
+	// 持ち主から見た相手（読み取ったユーザー）
+	counterpart := transferResp.FromUser
+	if qrCode.QRType == entities.QRCodeTypeSend {
+		counterpart = transferResp.ToUser
+	}
+	i.notifier.Notify(ctx, qrCode.UserID, entities.NewQRTransferCompletedEvent(qrCode, transferResp.Transaction, counterpart))
+
 	return &inputport.ScanQRResponse{
 		Transaction: transferResp.Transaction,
 		QRCode:      qrCode,
@@ -149,6 +179,24 @@ func (i *QRCodeInteractor) ScanQR(ctx context.Context, req *inputport.ScanQRRequ
 	}, nil
 }
 
+// scannedPayload は読み取った文字列からペイロードを得る
+// 個人QRコードは送金リクエストの宛先に使うもので、ここでは読み取れない
+func (i *QRCodeInteractor) scannedPayload(data string) (*entities.QRPayload, error) {
+	// コードだけが送られた場合（QRコードを介さないAPIの利用）は種類を問わない
+	if !strings.Contains(data, ":") {
+		return &entities.QRPayload{Version: entities.QRPayloadVersionLegacy, Data: data}, nil
+	}
+
+	payload, err := i.codec.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Type == entities.QRPayloadTypeUser {
+		return nil, entities.ErrInvalidQRPayload
+	}
+	return payload, nil
+}
+
 // GetQRCodeHistory はQRコード履歴を取得
 func (i *QRCodeInteractor) GetQRCodeHistory(ctx context.Context, req *inputport.GetQRCodeHistoryRequest) (*inputport.GetQRCodeHistoryResponse, error) {
 	qrCodes, err := i.qrCodeRepo.ReadListByUserID(ctx, req.UserID, req.Offset, req.Limit)
@@ -160,3 +208,22 @@ func (i *QRCodeInteractor) GetQRCodeHistory(ctx context.Context, req *inputport.
 		QRCodes: qrCodes,
 	}, nil
 }
+
+// GetPersonalQR は送金リクエストの宛先に使う個人QRコードを取得
+// 保存している旧形式（user:{user_id}）ではなく、署名付きのディープリンクを返す
+func (i *QRCodeInteractor) GetPersonalQR(ctx context.Context, req *inputport.GetPersonalQRRequest) (*inputport.GetPersonalQRResponse, error) {
+	user, err := i.userRepo.Read(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	qrCodeData, err := i.codec.Encode(entities.NewPersonalQRPayload(user.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &inputport.GetPersonalQRResponse{
+		User:       user,
+		QRCodeData: qrCodeData,
+	}, nil
+}
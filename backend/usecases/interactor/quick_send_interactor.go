@@ -0,0 +1,124 @@
+package interactor
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// QuickSendInteractor はクイック送金のユースケース実装
+type QuickSendInteractor struct {
+	friendshipRepo repository.FriendshipRepository
+	pinRepo        repository.FriendPinRepository
+	txRepo         repository.TransactionRepository
+	logger         entities.Logger
+}
+
+// NewQuickSendInteractor は新しいQuickSendInteractorを作成
+func NewQuickSendInteractor(
+	friendshipRepo repository.FriendshipRepository,
+	pinRepo repository.FriendPinRepository,
+	txRepo repository.TransactionRepository,
+	logger entities.Logger,
+) inputport.QuickSendInputPort {
+	return &QuickSendInteractor{
+		friendshipRepo: friendshipRepo,
+		pinRepo:        pinRepo,
+		txRepo:         txRepo,
+		logger:         logger,
+	}
+}
+
+// GetQuickSend は友達ごとに最後に送った金額・よく送る金額を付けて、表示順に取得
+// 送金できない無効なユーザーは含めない
+func (i *QuickSendInteractor) GetQuickSend(ctx context.Context, req *inputport.GetQuickSendRequest) ([]*entities.QuickSendEntry, error) {
+	friends, err := i.friendshipRepo.ReadListFriendsWithUsers(ctx, req.UserID, 0, entities.QuickSendMaxFriends)
+	if err != nil {
+		return nil, err
+	}
+	pins, err := i.pinRepo.ReadListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	friendIDs := make([]uuid.UUID, 0, len(friends))
+	for _, f := range friends {
+		if f.User != nil && f.User.IsActive {
+			friendIDs = append(friendIDs, f.User.ID)
+		}
+	}
+	usages, err := i.txRepo.ReadTransferAmountUsage(ctx, req.UserID, friendIDs, time.Now().Add(-entities.QuickSendLookback))
+	if err != nil {
+		return nil, err
+	}
+	amounts := entities.SummarizeTransferAmounts(usages)
+
+	pinnedAt := make(map[uuid.UUID]time.Time, len(pins))
+	for _, p := range pins {
+		pinnedAt[p.FriendID] = p.CreatedAt
+	}
+
+	entries := make([]*entities.QuickSendEntry, 0, len(friendIDs))
+	for _, f := range friends {
+		if f.User == nil || !f.User.IsActive {
+			continue
+		}
+		entry := &entities.QuickSendEntry{Friend: f.User, Amounts: amounts[f.User.ID]}
+		if at, ok := pinnedAt[f.User.ID]; ok {
+			entry.PinnedAt = &at
+		}
+		entries = append(entries, entry)
+	}
+	entities.SortQuickSendEntries(entries)
+
+	if req.Limit > 0 && len(entries) > req.Limit {
+		entries = entries[:req.Limit]
+	}
+	return entries, nil
+}
+
+// PinFriend は友達をクイック送金の先頭にピン留め（既にピン留めしていれば何もしない）
+func (i *QuickSendInteractor) PinFriend(ctx context.Context, userID, friendID uuid.UUID) error {
+	pin, err := entities.NewFriendPin(userID, friendID)
+	if err != nil {
+		return err
+	}
+
+	areFriends, err := i.friendshipRepo.CheckAreFriends(ctx, userID, friendID)
+	if err != nil {
+		return err
+	}
+	if !areFriends {
+		return entities.ErrPinTargetNotFriend
+	}
+
+	pins, err := i.pinRepo.ReadListByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, p := range pins {
+		if p.FriendID == friendID {
+			return nil
+		}
+	}
+	if len(pins) >= entities.FriendPinMaxPerUser {
+		return entities.ErrFriendPinLimit.WithParams(map[string]interface{}{"max": entities.FriendPinMaxPerUser})
+	}
+
+	if err := i.pinRepo.Create(ctx, pin); err != nil {
+		return err
+	}
+	i.logger.Info("Friend pinned",
+		entities.NewField("user_id", userID),
+		entities.NewField("friend_id", friendID))
+	return nil
+}
+
+// UnpinFriend は友達のピン留めを外す（友達を解除した後でも外せる）
+func (i *QuickSendInteractor) UnpinFriend(ctx context.Context, userID, friendID uuid.UUID) error {
+	return i.pinRepo.Delete(ctx, userID, friendID)
+}
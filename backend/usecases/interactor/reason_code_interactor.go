@@ -0,0 +1,84 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+// ReasonCodeInteractor は理由コードのユースケース実装
+type ReasonCodeInteractor struct {
+	reasonCodeRepo repository.ReasonCodeRepository
+	userRepo       repository.UserRepository
+	logger         entities.Logger
+}
+
+// NewReasonCodeInteractor は新しいReasonCodeInteractorを作成
+func NewReasonCodeInteractor(
+	reasonCodeRepo repository.ReasonCodeRepository,
+	userRepo repository.UserRepository,
+	logger entities.Logger,
+) inputport.ReasonCodeInputPort {
+	return &ReasonCodeInteractor{
+		reasonCodeRepo: reasonCodeRepo,
+		userRepo:       userRepo,
+		logger:         logger,
+	}
+}
+
+// ListReasonCodes は理由コードの一覧を取得
+func (i *ReasonCodeInteractor) ListReasonCodes(ctx context.Context, req *inputport.ListReasonCodesRequest) ([]*entities.ReasonCode, error) {
+	return i.reasonCodeRepo.ReadList(ctx, !req.IncludeInactive)
+}
+
+// CreateReasonCode は理由コードを作成
+func (i *ReasonCodeInteractor) CreateReasonCode(ctx context.Context, req *inputport.CreateReasonCodeRequest) (*entities.ReasonCode, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	reasonCode, err := entities.NewReasonCode(req.Code, req.Label, req.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.reasonCodeRepo.Create(ctx, reasonCode); err != nil {
+		return nil, fmt.Errorf("failed to create reason code: %w", err)
+	}
+
+	i.logger.Info("Reason code created",
+		entities.NewField("code", reasonCode.Code),
+		entities.NewField("admin_id", req.AdminID))
+
+	return reasonCode, nil
+}
+
+// UpdateReasonCode は理由コードの表示名・説明・有効状態を更新
+func (i *ReasonCodeInteractor) UpdateReasonCode(ctx context.Context, req *inputport.UpdateReasonCodeRequest) (*entities.ReasonCode, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	reasonCode, err := i.reasonCodeRepo.ReadByCode(ctx, entities.NormalizeReasonCode(req.Code))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := reasonCode.Update(req.Label, req.Description, req.IsActive); err != nil {
+		return nil, err
+	}
+
+	if err := i.reasonCodeRepo.Update(ctx, reasonCode); err != nil {
+		return nil, fmt.Errorf("failed to update reason code: %w", err)
+	}
+
+	i.logger.Info("Reason code updated",
+		entities.NewField("code", reasonCode.Code),
+		entities.NewField("is_active", reasonCode.IsActive),
+		entities.NewField("admin_id", req.AdminID))
+
+	return reasonCode, nil
+}
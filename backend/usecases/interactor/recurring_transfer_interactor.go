@@ -0,0 +1,244 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultRecurringTransferListLimit = 20
+	maxRecurringTransferListLimit     = 100
+)
+
+// RecurringTransferInteractor は定期送金のユースケース実装
+type RecurringTransferInteractor struct {
+	recurringTransferRepo repository.RecurringTransferRepository
+	userRepo              repository.UserRepository
+	pointTransferPort     inputport.PointTransferInputPort
+	contentModeration     inputport.ContentModerationInputPort
+	logger                entities.Logger
+}
+
+// NewRecurringTransferInteractor は新しいRecurringTransferInteractorを作成
+func NewRecurringTransferInteractor(
+	recurringTransferRepo repository.RecurringTransferRepository,
+	userRepo repository.UserRepository,
+	pointTransferPort inputport.PointTransferInputPort,
+	contentModeration inputport.ContentModerationInputPort,
+	logger entities.Logger,
+) inputport.RecurringTransferInputPort {
+	return &RecurringTransferInteractor{
+		recurringTransferRepo: recurringTransferRepo,
+		userRepo:              userRepo,
+		pointTransferPort:     pointTransferPort,
+		contentModeration:     contentModeration,
+		logger:                logger,
+	}
+}
+
+// CreateRecurringTransfer は定期送金を登録する（送信者が承認）
+func (i *RecurringTransferInteractor) CreateRecurringTransfer(ctx context.Context, req *inputport.CreateRecurringTransferRequest) (*inputport.RecurringTransferInfo, error) {
+	fromUser, err := i.userRepo.Read(ctx, req.FromUserID)
+	if err != nil {
+		return nil, entities.ErrUserNotFound
+	}
+	if !fromUser.IsActive {
+		return nil, entities.ErrUserInactive
+	}
+	toUser, err := i.userRepo.Read(ctx, req.ToUserID)
+	if err != nil {
+		return nil, entities.ErrUserNotFound
+	}
+	if !toUser.IsActive {
+		return nil, entities.ErrUserInactive
+	}
+
+	transfer, err := entities.NewRecurringTransfer(req.FromUserID, req.ToUserID, req.Amount, req.Interval, req.Message, req.StartAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// メッセージは毎回の送金の説明になるため、送金リクエストと同じくモデレーションする
+	if err := i.contentModeration.CheckContent(ctx, req.FromUserID, entities.ModerationFieldTransferMessage, transfer.Message); err != nil {
+		return nil, err
+	}
+
+	if err := i.recurringTransferRepo.Create(ctx, transfer); err != nil {
+		return nil, fmt.Errorf("failed to create recurring transfer: %w", err)
+	}
+
+	i.logger.Info("Recurring transfer created",
+		entities.NewField("recurring_transfer_id", transfer.ID),
+		entities.NewField("from_user_id", transfer.FromUserID),
+		entities.NewField("to_user_id", transfer.ToUserID),
+		entities.NewField("amount", transfer.Amount),
+		entities.NewField("interval", string(transfer.Interval)))
+
+	return &inputport.RecurringTransferInfo{
+		Transfer: transfer,
+		FromUser: fromUser,
+		ToUser:   toUser,
+	}, nil
+}
+
+// GetRecurringTransfers は送信者または受取人として関わる定期送金の一覧を取得
+func (i *RecurringTransferInteractor) GetRecurringTransfers(ctx context.Context, req *inputport.GetRecurringTransfersRequest) (*inputport.GetRecurringTransfersResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultRecurringTransferListLimit
+	}
+	if limit > maxRecurringTransferListLimit {
+		limit = maxRecurringTransferListLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	transfers, err := i.recurringTransferRepo.ReadListByUser(ctx, req.UserID, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring transfers: %w", err)
+	}
+	total, err := i.recurringTransferRepo.CountByUser(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recurring transfers: %w", err)
+	}
+
+	// 同じ相手との定期送金が多いため、ユーザー情報は1回だけ読む
+	users := make(map[uuid.UUID]*entities.User)
+	readUser := func(id uuid.UUID) (*entities.User, error) {
+		if user, ok := users[id]; ok {
+			return user, nil
+		}
+		user, err := i.userRepo.Read(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		users[id] = user
+		return user, nil
+	}
+
+	infos := make([]*inputport.RecurringTransferInfo, 0, len(transfers))
+	for _, transfer := range transfers {
+		fromUser, err := readUser(transfer.FromUserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sender: %w", err)
+		}
+		toUser, err := readUser(transfer.ToUserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get receiver: %w", err)
+		}
+		infos = append(infos, &inputport.RecurringTransferInfo{
+			Transfer: transfer,
+			FromUser: fromUser,
+			ToUser:   toUser,
+		})
+	}
+
+	return &inputport.GetRecurringTransfersResponse{
+		Transfers: infos,
+		Total:     total,
+		Offset:    offset,
+		Limit:     limit,
+	}, nil
+}
+
+// CancelRecurringTransfer は定期送金を解約する（送信者・受取人のどちらからでも可能）
+func (i *RecurringTransferInteractor) CancelRecurringTransfer(ctx context.Context, req *inputport.CancelRecurringTransferRequest) (*entities.RecurringTransfer, error) {
+	transfer, err := i.recurringTransferRepo.Read(ctx, req.TransferID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 関係のないユーザーには存在自体を見せない
+	if err := transfer.Cancel(req.UserID); err != nil {
+		return nil, err
+	}
+
+	if err := i.recurringTransferRepo.Update(ctx, transfer); err != nil {
+		return nil, fmt.Errorf("failed to cancel recurring transfer: %w", err)
+	}
+
+	i.logger.Info("Recurring transfer cancelled",
+		entities.NewField("recurring_transfer_id", transfer.ID),
+		entities.NewField("cancelled_by", req.UserID))
+
+	return transfer, nil
+}
+
+// ProcessDueTransfers は実行日時を過ぎた定期送金を送金する（ワーカー用）
+// 残高不足やアカウント無効の場合は自動停止し、それ以外の失敗は次回の実行で再試行する
+func (i *RecurringTransferInteractor) ProcessDueTransfers(ctx context.Context, now time.Time, limit int) (int, error) {
+	transfers, err := i.recurringTransferRepo.ReadDue(ctx, now, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get due recurring transfers: %w", err)
+	}
+
+	succeeded := 0
+	for _, transfer := range transfers {
+		if i.executeTransfer(ctx, transfer, now) {
+			succeeded++
+		}
+	}
+	return succeeded, nil
+}
+
+// executeTransfer は1件の定期送金を実行し、結果を記録する
+// 冪等性キーは (定期送金ID, 周期) から作るため、記録に失敗して再実行しても二重送金にはならない
+func (i *RecurringTransferInteractor) executeTransfer(ctx context.Context, transfer *entities.RecurringTransfer, now time.Time) bool {
+	description := "定期送金"
+	if transfer.Message != "" {
+		description = fmt.Sprintf("定期送金: %s", transfer.Message)
+	}
+
+	resp, err := i.pointTransferPort.Transfer(ctx, &inputport.TransferRequest{
+		FromUserID:     transfer.FromUserID,
+		ToUserID:       transfer.ToUserID,
+		Amount:         transfer.Amount,
+		IdempotencyKey: transfer.IdempotencyKey(),
+		Description:    description,
+	})
+
+	switch {
+	case err == nil:
+		transfer.MarkRun(now)
+	case errors.Is(err, entities.ErrInsufficientBalance):
+		transfer.Stop("insufficient_balance", now)
+	case errors.Is(err, entities.ErrUserInactive), errors.Is(err, entities.ErrUserNotFound):
+		transfer.Stop("user_unavailable", now)
+	default:
+		transfer.MarkFailed(err.Error(), now)
+	}
+
+	if err != nil {
+		i.logger.Warn("Recurring transfer failed",
+			entities.NewField("recurring_transfer_id", transfer.ID),
+			entities.NewField("period", transfer.PeriodKey()),
+			entities.NewField("status", string(transfer.Status)),
+			entities.NewField("error", err))
+	}
+
+	if updateErr := i.recurringTransferRepo.Update(ctx, transfer); updateErr != nil {
+		i.logger.Error("Failed to update recurring transfer",
+			entities.NewField("recurring_transfer_id", transfer.ID),
+			entities.NewField("error", updateErr))
+		return false
+	}
+
+	if err != nil {
+		return false
+	}
+
+	i.logger.Info("Recurring transfer executed",
+		entities.NewField("recurring_transfer_id", transfer.ID),
+		entities.NewField("transaction_id", resp.Transaction.ID),
+		entities.NewField("next_run_at", transfer.NextRunAt))
+	return true
+}
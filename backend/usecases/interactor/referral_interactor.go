@@ -0,0 +1,247 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	referralReportDefaultDays  = 30
+	defaultReferralReportLimit = 20
+	maxReferralReportLimit     = 100
+)
+
+// ReferralInteractor は紹介プログラムのユースケース実装
+type ReferralInteractor struct {
+	txManager        repository.TransactionManager
+	referralRepo     repository.ReferralRepository
+	userRepo         repository.UserRepository
+	transactionRepo  repository.TransactionRepository
+	pointBatchRepo   repository.PointBatchRepository
+	loginAttemptRepo repository.LoginAttemptRepository
+	logger           entities.Logger
+}
+
+// NewReferralInteractor は新しいReferralInteractorを作成
+func NewReferralInteractor(
+	txManager repository.TransactionManager,
+	referralRepo repository.ReferralRepository,
+	userRepo repository.UserRepository,
+	transactionRepo repository.TransactionRepository,
+	pointBatchRepo repository.PointBatchRepository,
+	loginAttemptRepo repository.LoginAttemptRepository,
+	logger entities.Logger,
+) inputport.ReferralInputPort {
+	return &ReferralInteractor{
+		txManager:        txManager,
+		referralRepo:     referralRepo,
+		userRepo:         userRepo,
+		transactionRepo:  transactionRepo,
+		pointBatchRepo:   pointBatchRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		logger:           logger,
+	}
+}
+
+// GetReferralCode はユーザーの紹介コードを取得（未発行なら発行する）
+func (i *ReferralInteractor) GetReferralCode(ctx context.Context, userID uuid.UUID) (*entities.ReferralCode, error) {
+	code, err := i.referralRepo.ReadCodeByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if code != nil {
+		return code, nil
+	}
+
+	code, err = entities.NewReferralCode(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.referralRepo.CreateCode(ctx, code); err != nil {
+		return nil, fmt.Errorf("failed to create referral code: %w", err)
+	}
+
+	// 同時に発行された場合は先に保存された方を返す
+	return i.referralRepo.ReadCodeByUser(ctx, userID)
+}
+
+// ResolveReferralCode は登録時に入力された紹介コードを確認する
+func (i *ReferralInteractor) ResolveReferralCode(ctx context.Context, code string) (*entities.ReferralCode, error) {
+	normalized := entities.NormalizeReferralCode(code)
+	if normalized == "" {
+		return nil, entities.ErrInvalidReferralCode
+	}
+	return i.referralRepo.ReadCodeByCode(ctx, normalized)
+}
+
+// RecordReferral は紹介コードで登録したユーザーを記録する
+func (i *ReferralInteractor) RecordReferral(ctx context.Context, req *inputport.RecordReferralRequest) (*entities.Referral, error) {
+	referral := entities.NewReferral(req.Code.UserID, req.RefereeID, req.Code.Code, req.IPAddress)
+
+	reason, err := i.checkFraud(ctx, referral)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		referral.Reject(reason)
+	}
+
+	if err := i.referralRepo.Create(ctx, referral); err != nil {
+		return nil, fmt.Errorf("failed to create referral: %w", err)
+	}
+
+	i.logger.Info("Referral recorded",
+		entities.NewField("referral_id", referral.ID),
+		entities.NewField("referrer_id", referral.ReferrerID),
+		entities.NewField("referee_id", referral.RefereeID),
+		entities.NewField("status", referral.Status),
+		entities.NewField("reject_reason", referral.RejectReason))
+
+	return referral, nil
+}
+
+// checkFraud は特典の対象外にすべき紹介かを判定し、その理由を返す（問題なければ空文字）
+func (i *ReferralInteractor) checkFraud(ctx context.Context, referral *entities.Referral) (entities.ReferralRejectReason, error) {
+	if referral.ReferrerID == referral.RefereeID {
+		return entities.ReferralRejectSelfReferral, nil
+	}
+	if referral.IPAddress == "" {
+		return "", nil
+	}
+
+	// 紹介者本人が使っている回線からの登録は自己紹介とみなす
+	known, err := i.loginAttemptRepo.ExistsSuccessByUserAndIP(ctx, referral.ReferrerID, referral.IPAddress)
+	if err != nil {
+		return "", err
+	}
+	if known {
+		return entities.ReferralRejectSelfReferral, nil
+	}
+
+	count, err := i.referralRepo.CountByIPSince(ctx, referral.IPAddress, referral.CreatedAt.Add(-entities.ReferralIPWindow))
+	if err != nil {
+		return "", err
+	}
+	if count >= entities.ReferralMaxPerIP {
+		return entities.ReferralRejectIPLimit, nil
+	}
+	return "", nil
+}
+
+// CompleteQualifyingAction は紹介された側の初回チェックインで双方に特典を付与する
+func (i *ReferralInteractor) CompleteQualifyingAction(ctx context.Context, refereeID uuid.UUID) {
+	referral, err := i.referralRepo.ReadPendingByReferee(ctx, refereeID)
+	if err != nil {
+		i.logger.Error("Failed to read pending referral",
+			entities.NewField("referee_id", refereeID),
+			entities.NewField("error", err))
+		return
+	}
+	if referral == nil {
+		return
+	}
+
+	// 紹介者が退会・無効化されていれば紹介された側にだけ付与する
+	referrerReward := entities.ReferrerRewardPoints
+	if referrer, err := i.userRepo.Read(ctx, referral.ReferrerID); err != nil || !referrer.IsActive {
+		referrerReward = 0
+	}
+
+	rewarded := false
+	err = i.txManager.Do(ctx, func(ctx context.Context) error {
+		ok, err := i.referralRepo.MarkRewarded(ctx, referral.ID, referrerReward, entities.RefereeRewardPoints, time.Now())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil // 同時に処理された
+		}
+
+		if err := i.grant(ctx, referral, referral.RefereeID, entities.RefereeRewardPoints, "友だち紹介特典（登録）"); err != nil {
+			return err
+		}
+		if referrerReward > 0 {
+			if err := i.grant(ctx, referral, referral.ReferrerID, referrerReward, "友だち紹介特典（紹介）"); err != nil {
+				return err
+			}
+		}
+		rewarded = true
+		return nil
+	})
+	if err != nil {
+		i.logger.Error("Failed to grant referral rewards",
+			entities.NewField("referral_id", referral.ID),
+			entities.NewField("error", err))
+		return
+	}
+
+	if rewarded {
+		i.logger.Info("Referral rewarded",
+			entities.NewField("referral_id", referral.ID),
+			entities.NewField("referrer_id", referral.ReferrerID),
+			entities.NewField("referee_id", referral.RefereeID),
+			entities.NewField("referrer_reward", referrerReward))
+	}
+}
+
+// grant は紹介特典のポイントをトランザクション内で付与する
+func (i *ReferralInteractor) grant(ctx context.Context, referral *entities.Referral, userID uuid.UUID, amount int64, description string) error {
+	tx, err := entities.NewSystemGrant(userID, amount, description, map[string]interface{}{
+		"referral_id": referral.ID.String(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := i.transactionRepo.Create(ctx, tx); err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+	if err := i.userRepo.UpdateBalancesWithLock(ctx, []repository.BalanceUpdate{
+		{UserID: userID, Amount: amount, IsDeduct: false},
+	}); err != nil {
+		return fmt.Errorf("failed to update balance: %w", err)
+	}
+	batch := entities.NewPointBatch(userID, amount, entities.PointBatchSourceSystemGrant, &tx.ID, time.Now())
+	if err := i.pointBatchRepo.Create(ctx, batch); err != nil {
+		return fmt.Errorf("failed to create point batch: %w", err)
+	}
+	return nil
+}
+
+// GetReferralReport は期間内の紹介の実績を取得
+func (i *ReferralInteractor) GetReferralReport(ctx context.Context, req *inputport.GetReferralReportRequest) (*entities.ReferralReport, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	to := req.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	from := req.From
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -referralReportDefaultDays)
+	}
+	if !from.Before(to) {
+		return nil, entities.ErrInvalidDateRange
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultReferralReportLimit
+	}
+	if limit > maxReferralReportLimit {
+		limit = maxReferralReportLimit
+	}
+
+	report, err := i.referralRepo.ReadReport(ctx, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate referrals: %w", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,22 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// requireAdmin は操作者が管理者（スーパー管理者を含む）かを確認
+// 管理者でなければErrAdminRequiredを返す
+func requireAdmin(ctx context.Context, userRepo repository.UserRepository, adminID uuid.UUID) error {
+	admin, err := userRepo.Read(ctx, adminID)
+	if err != nil {
+		return err
+	}
+	if !admin.IsAdmin() {
+		return entities.ErrAdminRequired
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// ResourceAuthorizationInteractor はユーザーが持つ（関わる）リソースの認可のユースケース実装
+type ResourceAuthorizationInteractor struct {
+	transferRequestRepo repository.TransferRequestRepository
+	qrCodeRepo          repository.QRCodeRepository
+	exchangeRepo        repository.ProductExchangeRepository
+	addressRepo         repository.ShippingAddressRepository
+	transactionRepo     repository.TransactionRepository
+	userRepo            repository.UserRepository
+	logger              entities.Logger
+}
+
+// NewResourceAuthorizationInteractor は新しいResourceAuthorizationInteractorを作成
+func NewResourceAuthorizationInteractor(
+	transferRequestRepo repository.TransferRequestRepository,
+	qrCodeRepo repository.QRCodeRepository,
+	exchangeRepo repository.ProductExchangeRepository,
+	addressRepo repository.ShippingAddressRepository,
+	transactionRepo repository.TransactionRepository,
+	userRepo repository.UserRepository,
+	logger entities.Logger,
+) *ResourceAuthorizationInteractor {
+	return &ResourceAuthorizationInteractor{
+		transferRequestRepo: transferRequestRepo,
+		qrCodeRepo:          qrCodeRepo,
+		exchangeRepo:        exchangeRepo,
+		addressRepo:         addressRepo,
+		transactionRepo:     transactionRepo,
+		userRepo:            userRepo,
+		logger:              logger,
+	}
+}
+
+// Authorize はユーザーがリソースにactionを行えるかを確認
+func (i *ResourceAuthorizationInteractor) Authorize(ctx context.Context, userID uuid.UUID, resource entities.ResourceType, resourceID uuid.UUID, action entities.Action) error {
+	owned, err := i.read(ctx, resource, resourceID)
+	if err != nil {
+		return err
+	}
+	if err := owned.Authorize(userID, action); err != nil {
+		i.logger.Info("Resource access denied",
+			entities.NewField("user_id", userID),
+			entities.NewField("resource", resource),
+			entities.NewField("resource_id", resourceID),
+			entities.NewField("action", action))
+		return err
+	}
+	return nil
+}
+
+// AuthorizeAdmin はユーザーが管理者かを確認
+func (i *ResourceAuthorizationInteractor) AuthorizeAdmin(ctx context.Context, userID uuid.UUID) error {
+	user, err := i.userRepo.Read(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsAdmin() {
+		i.logger.Info("Admin access denied", entities.NewField("user_id", userID))
+		return entities.ErrAdminRequired
+	}
+	return nil
+}
+
+// read はリソースを読む（見つからなければリソースのNotFound）
+func (i *ResourceAuthorizationInteractor) read(ctx context.Context, resource entities.ResourceType, id uuid.UUID) (entities.OwnedResource, error) {
+	switch resource {
+	case entities.ResourceTransferRequest:
+		tr, err := i.transferRequestRepo.Read(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if tr == nil {
+			return nil, entities.ErrTransferRequestNotFound
+		}
+		return tr, nil
+	case entities.ResourceQRCode:
+		return i.qrCodeRepo.Read(ctx, id)
+	case entities.ResourceExchange:
+		return i.exchangeRepo.Read(ctx, id)
+	case entities.ResourceShippingAddress:
+		return i.addressRepo.Read(ctx, id)
+	case entities.ResourceTransaction:
+		return i.transactionRepo.Read(ctx, id)
+	default:
+		return nil, fmt.Errorf("unknown resource type: %s", resource)
+	}
+}
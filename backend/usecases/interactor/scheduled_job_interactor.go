@@ -0,0 +1,237 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// scheduledJob は登録するジョブ（処理件数が分からないジョブはnilを返す）
+type scheduledJob struct {
+	name entities.ScheduledJobName
+	run  func(ctx context.Context, now time.Time) (*int64, error)
+}
+
+// ScheduledJobInteractor は定期実行ジョブのユースケース実装
+// cron式はsystem_settings、設定ファイル、既定の順に優先する
+type ScheduledJobInteractor struct {
+	jobRepo             repository.ScheduledJobRepository
+	settingsRepo        repository.SystemSettingsRepository
+	idempotencyRepo     repository.IdempotencyKeyRepository
+	sessionRepo         repository.SessionRepository
+	transferRequestRepo repository.TransferRequestRepository
+	userRepo            repository.UserRepository
+	processedAccessRepo repository.ProcessedAkerunAccessRepository
+	holds               inputport.BalanceHoldReleaser
+	archiver            inputport.TransactionArchiver
+	digests             inputport.WeeklyDigestSender
+	attachments         inputport.TransferAttachmentCleaner
+	schedules           entities.JobSchedules
+	logger              entities.Logger
+}
+
+// NewScheduledJobInteractor は新しいScheduledJobInteractorを作成
+func NewScheduledJobInteractor(
+	jobRepo repository.ScheduledJobRepository,
+	settingsRepo repository.SystemSettingsRepository,
+	idempotencyRepo repository.IdempotencyKeyRepository,
+	sessionRepo repository.SessionRepository,
+	transferRequestRepo repository.TransferRequestRepository,
+	userRepo repository.UserRepository,
+	processedAccessRepo repository.ProcessedAkerunAccessRepository,
+	holds inputport.BalanceHoldReleaser,
+	archiver inputport.TransactionArchiver,
+	digests inputport.WeeklyDigestSender,
+	attachments inputport.TransferAttachmentCleaner,
+	schedules entities.JobSchedules,
+	logger entities.Logger,
+) inputport.ScheduledJobInputPort {
+	return &ScheduledJobInteractor{
+		jobRepo:             jobRepo,
+		settingsRepo:        settingsRepo,
+		idempotencyRepo:     idempotencyRepo,
+		sessionRepo:         sessionRepo,
+		transferRequestRepo: transferRequestRepo,
+		userRepo:            userRepo,
+		processedAccessRepo: processedAccessRepo,
+		holds:               holds,
+		archiver:            archiver,
+		digests:             digests,
+		attachments:         attachments,
+		schedules:           schedules,
+		logger:              logger,
+	}
+}
+
+// jobs は登録するジョブの一覧
+func (i *ScheduledJobInteractor) jobs() []scheduledJob {
+	return []scheduledJob{
+		{entities.ScheduledJobIdempotencyKeyCleanup, func(ctx context.Context, now time.Time) (*int64, error) {
+			return nil, i.idempotencyRepo.DeleteExpired(ctx)
+		}},
+		{entities.ScheduledJobSessionPurge, func(ctx context.Context, now time.Time) (*int64, error) {
+			return nil, i.sessionRepo.DeleteExpired(ctx)
+		}},
+		{entities.ScheduledJobTransferRequestExpiry, func(ctx context.Context, now time.Time) (*int64, error) {
+			expired, err := i.transferRequestRepo.UpdateExpiredRequests(ctx)
+			if err != nil {
+				return &expired, err
+			}
+			// 期限切れ・拒否・キャンセルになったリクエストのために確保した残高を戻す
+			released, err := i.holds.ReleaseClosedHolds(ctx)
+			if released > 0 {
+				i.logger.Info("Released balance holds of closed transfer requests", entities.NewField("count", released))
+			}
+			return &expired, err
+		}},
+		{entities.ScheduledJobTransactionArchive, func(ctx context.Context, now time.Time) (*int64, error) {
+			archived, err := i.archiver.ArchiveTransactions(ctx, now)
+			return &archived, err
+		}},
+		{entities.ScheduledJobWeeklyDigest, func(ctx context.Context, now time.Time) (*int64, error) {
+			sent, err := i.digests.SendWeeklyDigests(ctx, now)
+			return &sent, err
+		}},
+		{entities.ScheduledJobProcessedAkerunAccessCleanup, func(ctx context.Context, now time.Time) (*int64, error) {
+			deleted, err := i.processedAccessRepo.DeleteProcessedBefore(ctx, now.Add(-entities.ProcessedAkerunAccessRetention))
+			return &deleted, err
+		}},
+		{entities.ScheduledJobTransferAttachmentCleanup, func(ctx context.Context, now time.Time) (*int64, error) {
+			deleted, err := i.attachments.CleanupAttachments(ctx, now)
+			return &deleted, err
+		}},
+	}
+}
+
+// RunDueJobs は実行時刻を過ぎたジョブを順に実行する
+// ロックを取れなかったジョブ（他のインスタンスが実行済み・実行中）は飛ばす
+func (i *ScheduledJobInteractor) RunDueJobs(ctx context.Context, owner string, now time.Time) int {
+	ran := 0
+	for _, job := range i.jobs() {
+		schedule := i.schedule(ctx, job.name)
+
+		var nextRunAt *time.Time
+		expr := entities.ScheduledJobDisabled
+		if schedule != nil {
+			next := schedule.Next(now)
+			nextRunAt = &next
+			expr = schedule.String()
+		}
+		if err := i.jobRepo.SyncSchedule(ctx, job.name, expr, nextRunAt); err != nil {
+			i.logger.Error("Failed to sync job schedule",
+				entities.NewField("job", string(job.name)),
+				entities.NewField("error", err))
+			continue
+		}
+		if schedule == nil {
+			continue
+		}
+
+		locked, err := i.jobRepo.TryLock(ctx, job.name, owner, now, now.Add(entities.ScheduledJobLockTimeout), *nextRunAt)
+		if err != nil {
+			i.logger.Error("Failed to lock scheduled job",
+				entities.NewField("job", string(job.name)),
+				entities.NewField("error", err))
+			continue
+		}
+		if !locked {
+			continue
+		}
+
+		i.run(ctx, job, owner, now)
+		ran++
+	}
+	return ran
+}
+
+// run はジョブを実行して結果を記録する
+func (i *ScheduledJobInteractor) run(ctx context.Context, job scheduledJob, owner string, now time.Time) {
+	started := time.Now()
+	processed, err := job.run(ctx, now)
+
+	result := &entities.ScheduledJobRun{
+		Name:       job.name,
+		Owner:      owner,
+		FinishedAt: time.Now(),
+		Status:     entities.ScheduledJobStatusSucceeded,
+		Processed:  processed,
+	}
+	if err != nil {
+		result.Status = entities.ScheduledJobStatusFailed
+		result.Error = err.Error()
+		i.logger.Error("Scheduled job failed",
+			entities.NewField("job", string(job.name)),
+			entities.NewField("error", err))
+	} else {
+		fields := []entities.Field{
+			entities.NewField("job", string(job.name)),
+			entities.NewField("duration", time.Since(started).String()),
+		}
+		if processed != nil {
+			fields = append(fields, entities.NewField("processed", *processed))
+		}
+		i.logger.Info("Scheduled job completed", fields...)
+	}
+
+	if err := i.jobRepo.Finish(ctx, result); err != nil {
+		i.logger.Error("Failed to record scheduled job result",
+			entities.NewField("job", string(job.name)),
+			entities.NewField("error", err))
+	}
+}
+
+// schedule はジョブのcron式を解釈する（停止中ならnil）
+// system_settingsや設定ファイルの値が不正な場合は警告を出して次の候補を使う
+func (i *ScheduledJobInteractor) schedule(ctx context.Context, name entities.ScheduledJobName) *entities.CronSchedule {
+	candidates := make([]string, 0, 3)
+	if value, err := i.settingsRepo.GetSetting(ctx, entities.JobScheduleSettingKey(name)); err != nil {
+		i.logger.Warn("Failed to get job schedule setting",
+			entities.NewField("job", string(name)),
+			entities.NewField("error", err))
+	} else {
+		candidates = append(candidates, value)
+	}
+	candidates = append(candidates, i.schedules[name], entities.DefaultJobSchedules[name])
+
+	for _, expr := range candidates {
+		expr = strings.TrimSpace(expr)
+		if expr == "" {
+			continue
+		}
+		if strings.EqualFold(expr, entities.ScheduledJobDisabled) {
+			return nil
+		}
+		schedule, err := entities.ParseCronSchedule(expr)
+		// 2月30日のように実行日が来ない式も不正として扱う
+		if err == nil && schedule.Next(time.Now()).IsZero() {
+			err = entities.ErrInvalidCronSchedule
+		}
+		if err != nil {
+			i.logger.Warn("Invalid job schedule",
+				entities.NewField("job", string(name)),
+				entities.NewField("schedule", expr))
+			continue
+		}
+		return schedule
+	}
+	return nil
+}
+
+// ListJobs は全ジョブのスケジュールと直近の実行状況を取得
+func (i *ScheduledJobInteractor) ListJobs(ctx context.Context, adminID uuid.UUID) ([]*entities.ScheduledJob, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+
+	jobs, err := i.jobRepo.ReadList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled jobs: %w", err)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,168 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultSecurityHistoryLimit = 20
+	maxSecurityHistoryLimit     = 100
+)
+
+// SecurityHistoryInteractor はユーザー名・パスワード変更履歴（セッションの固定の違反を含む）とログイン履歴の閲覧ユースケース実装
+type SecurityHistoryInteractor struct {
+	userRepo                  repository.UserRepository
+	usernameChangeHistoryRepo repository.UsernameChangeHistoryRepository
+	passwordChangeHistoryRepo repository.PasswordChangeHistoryRepository
+	loginAttemptRepo          repository.LoginAttemptRepository
+	sessionRepo               repository.SessionRepository
+	logger                    entities.Logger
+}
+
+// NewSecurityHistoryInteractor は新しいSecurityHistoryInteractorを作成
+func NewSecurityHistoryInteractor(
+	userRepo repository.UserRepository,
+	usernameChangeHistoryRepo repository.UsernameChangeHistoryRepository,
+	passwordChangeHistoryRepo repository.PasswordChangeHistoryRepository,
+	loginAttemptRepo repository.LoginAttemptRepository,
+	sessionRepo repository.SessionRepository,
+	logger entities.Logger,
+) inputport.SecurityHistoryInputPort {
+	return &SecurityHistoryInteractor{
+		userRepo:                  userRepo,
+		usernameChangeHistoryRepo: usernameChangeHistoryRepo,
+		passwordChangeHistoryRepo: passwordChangeHistoryRepo,
+		loginAttemptRepo:          loginAttemptRepo,
+		sessionRepo:               sessionRepo,
+		logger:                    logger,
+	}
+}
+
+// GetOwnHistory は本人の変更履歴を取得
+func (i *SecurityHistoryInteractor) GetOwnHistory(ctx context.Context, req *inputport.GetSecurityHistoryRequest) (*inputport.GetSecurityHistoryResponse, error) {
+	resp, err := i.readHistory(ctx, req.UserID, req.Offset, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	for idx, entry := range resp.Entries {
+		resp.Entries[idx] = entry.Masked()
+	}
+	return resp, nil
+}
+
+// GetUserHistory は指定ユーザーの変更履歴を取得（管理者のみ）
+func (i *SecurityHistoryInteractor) GetUserHistory(ctx context.Context, req *inputport.GetUserSecurityHistoryRequest) (*inputport.GetSecurityHistoryResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	if _, err := i.userRepo.Read(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Admin viewed security history",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("user_id", req.UserID))
+
+	return i.readHistory(ctx, req.UserID, req.Offset, req.Limit)
+}
+
+// GetOwnLogins は本人のログイン履歴を取得
+// 見覚えのない端末からのログインや続いた失敗に印を付け、本人が乗っ取りに気付けるようにする
+func (i *SecurityHistoryInteractor) GetOwnLogins(ctx context.Context, req *inputport.GetSecurityHistoryRequest) (*inputport.GetLoginHistoryResponse, error) {
+	offset, limit := securityHistoryPage(req.Offset, req.Limit)
+
+	total, err := i.loginAttemptRepo.CountByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count login history: %w", err)
+	}
+	attempts, err := i.loginAttemptRepo.ReadListByUserID(ctx, req.UserID, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login history: %w", err)
+	}
+
+	entries := entities.NewLoginHistory(attempts)
+	for idx, entry := range entries {
+		entries[idx] = entry.Masked()
+	}
+
+	return &inputport.GetLoginHistoryResponse{
+		Entries: entries,
+		Total:   total,
+		Offset:  offset,
+		Limit:   limit,
+	}, nil
+}
+
+// readHistory はユーザー名・パスワード変更履歴とセッションの固定の違反を新しい順に結合してページングする
+// どの履歴も新しい順に並んでいるため、それぞれ先頭からoffset+limit件を読めば結合後のページを作れる
+func (i *SecurityHistoryInteractor) readHistory(ctx context.Context, userID uuid.UUID, offset, limit int) (*inputport.GetSecurityHistoryResponse, error) {
+	offset, limit = securityHistoryPage(offset, limit)
+
+	usernameTotal, err := i.usernameChangeHistoryRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count username change history: %w", err)
+	}
+	passwordTotal, err := i.passwordChangeHistoryRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count password change history: %w", err)
+	}
+	violationTotal, err := i.sessionRepo.CountBindingViolationsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count session binding violations: %w", err)
+	}
+
+	window := offset + limit
+	entries := make([]*entities.SecurityHistoryEntry, 0, window)
+
+	usernameChanges, err := i.usernameChangeHistoryRepo.ReadListByUserID(ctx, userID, 0, window)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get username change history: %w", err)
+	}
+	for _, h := range usernameChanges {
+		entries = append(entries, entities.NewSecurityHistoryFromUsernameChange(h))
+	}
+
+	passwordChanges, err := i.passwordChangeHistoryRepo.ReadListByUserID(ctx, userID, 0, window)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get password change history: %w", err)
+	}
+	for _, h := range passwordChanges {
+		entries = append(entries, entities.NewSecurityHistoryFromPasswordChange(h))
+	}
+
+	violations, err := i.sessionRepo.ReadBindingViolationsByUserID(ctx, userID, 0, window)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session binding violations: %w", err)
+	}
+	for _, v := range violations {
+		entries = append(entries, entities.NewSecurityHistoryFromBindingViolation(v))
+	}
+
+	return &inputport.GetSecurityHistoryResponse{
+		UserID:  userID,
+		Entries: entities.MergeSecurityHistory(entries, offset, limit),
+		Total:   usernameTotal + passwordTotal + violationTotal,
+		Offset:  offset,
+		Limit:   limit,
+	}, nil
+}
+
+// securityHistoryPage は履歴のページ指定を既定値・上限に収める
+func securityHistoryPage(offset, limit int) (int, int) {
+	if limit <= 0 {
+		limit = defaultSecurityHistoryLimit
+	}
+	if limit > maxSecurityHistoryLimit {
+		limit = maxSecurityHistoryLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return offset, limit
+}
@@ -0,0 +1,87 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+// SessionBindingInteractor はセッションを端末・接続元に固定するユースケース実装
+type SessionBindingInteractor struct {
+	sessionRepo repository.SessionRepository
+	policy      entities.SessionBindingPolicy
+	logger      entities.Logger
+}
+
+// NewSessionBindingInteractor は新しいSessionBindingInteractorを作成
+func NewSessionBindingInteractor(
+	sessionRepo repository.SessionRepository,
+	policy entities.SessionBindingPolicy,
+	logger entities.Logger,
+) inputport.SessionBindingInputPort {
+	return &SessionBindingInteractor{
+		sessionRepo: sessionRepo,
+		policy:      policy,
+		logger:      logger,
+	}
+}
+
+// VerifyBinding はセッションが固定した端末・接続元から使われているか検証
+func (i *SessionBindingInteractor) VerifyBinding(ctx context.Context, req *inputport.VerifySessionBindingRequest) error {
+	if !i.policy.Enabled() {
+		return nil
+	}
+	session := req.Session
+
+	// 固定する前に作られたセッションは、ログインしたときの接続元に固定する
+	bound, ok := entities.ParseSessionFingerprint(session.Fingerprint)
+	if !ok {
+		bound = i.policy.Fingerprint(session.IPAddress, session.UserAgent)
+		i.bind(ctx, session, bound)
+	}
+
+	current := i.policy.Fingerprint(req.IPAddress, req.UserAgent)
+	change, reject := i.policy.Evaluate(bound, current)
+	if change == entities.SessionFingerprintUnchanged {
+		return nil
+	}
+
+	violation := entities.NewSessionBindingViolation(session, change, reject, req.IPAddress, req.UserAgent)
+	if err := i.sessionRepo.CreateBindingViolation(ctx, violation); err != nil {
+		i.logger.Error("Failed to record session binding violation",
+			entities.NewField("session_id", session.ID),
+			entities.NewField("error", err))
+	}
+	i.logger.Warn("Session used from a different device or network",
+		entities.NewField("user_id", session.UserID),
+		entities.NewField("session_id", session.ID),
+		entities.NewField("change", change),
+		entities.NewField("rejected", reject),
+		entities.NewField("ip_address", req.IPAddress))
+
+	if reject {
+		// トークンを持ち出された可能性があるため、セッションを失効させて本人に再ログインを求める
+		if err := i.sessionRepo.Delete(ctx, session.ID); err != nil {
+			i.logger.Error("Failed to revoke session after binding violation",
+				entities.NewField("session_id", session.ID),
+				entities.NewField("error", err))
+		}
+		return entities.ErrSessionReauthRequired
+	}
+
+	// 許容した変化は新しい接続元に固定し直し、同じ変化を毎回記録しない
+	i.bind(ctx, session, current)
+	return nil
+}
+
+// bind はセッションを指紋に固定する（失敗しても次のリクエストでまた固定する）
+func (i *SessionBindingInteractor) bind(ctx context.Context, session *entities.Session, fingerprint entities.SessionFingerprint) {
+	session.Fingerprint = fingerprint.String()
+	if err := i.sessionRepo.UpdateFingerprint(ctx, session.ID, session.Fingerprint); err != nil {
+		i.logger.Warn("Failed to bind session",
+			entities.NewField("session_id", session.ID),
+			entities.NewField("error", err))
+	}
+}
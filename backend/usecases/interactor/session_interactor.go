@@ -0,0 +1,120 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+// SessionInteractor はセッション管理のユースケース実装
+type SessionInteractor struct {
+	sessionRepo repository.SessionRepository
+	userRepo    repository.UserRepository
+	logger      entities.Logger
+}
+
+// NewSessionInteractor は新しいSessionInteractorを作成
+func NewSessionInteractor(
+	sessionRepo repository.SessionRepository,
+	userRepo repository.UserRepository,
+	logger entities.Logger,
+) inputport.SessionInputPort {
+	return &SessionInteractor{
+		sessionRepo: sessionRepo,
+		userRepo:    userRepo,
+		logger:      logger,
+	}
+}
+
+// ListSessions はユーザーの有効なセッション一覧を取得
+func (i *SessionInteractor) ListSessions(ctx context.Context, req *inputport.ListSessionsRequest) (*inputport.ListSessionsResponse, error) {
+	sessions, err := i.sessionRepo.ReadListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]*inputport.SessionInfo, 0, len(sessions))
+	for _, s := range sessions {
+		if s.IsExpired() {
+			continue
+		}
+		infos = append(infos, &inputport.SessionInfo{
+			Session:   s,
+			IsCurrent: s.ID == req.CurrentSessionID,
+		})
+	}
+
+	return &inputport.ListSessionsResponse{Sessions: infos}, nil
+}
+
+// RevokeSession は指定したセッションを失効
+// 他人のセッションIDを指定された場合も存在しない場合と同じエラーを返す
+func (i *SessionInteractor) RevokeSession(ctx context.Context, req *inputport.RevokeSessionRequest) (*inputport.RevokeSessionResponse, error) {
+	session, err := i.sessionRepo.Read(ctx, req.SessionID)
+	if err != nil || !session.BelongsTo(req.UserID) {
+		return nil, errors.New("session not found")
+	}
+
+	if err := i.sessionRepo.Delete(ctx, session.ID); err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Session revoked",
+		entities.NewField("user_id", req.UserID),
+		entities.NewField("session_id", session.ID))
+
+	return &inputport.RevokeSessionResponse{
+		RevokedCurrent: session.ID == req.CurrentSessionID,
+	}, nil
+}
+
+// RevokeOtherSessions は現在のセッション以外の全セッションを失効
+func (i *SessionInteractor) RevokeOtherSessions(ctx context.Context, req *inputport.RevokeOtherSessionsRequest) (*inputport.RevokeOtherSessionsResponse, error) {
+	count, err := i.sessionRepo.DeleteByUserIDExcept(ctx, req.UserID, req.CurrentSessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Other sessions revoked",
+		entities.NewField("user_id", req.UserID),
+		entities.NewField("revoked_count", count))
+
+	return &inputport.RevokeOtherSessionsResponse{RevokedCount: count}, nil
+}
+
+// ForceLogoutUser は管理者が指定ユーザーの全セッションを失効（強制ログアウト）
+// セッションはリクエストごとにDBから検証するため、失効したセッションは次のリクエストから使えない
+func (i *SessionInteractor) ForceLogoutUser(ctx context.Context, req *inputport.ForceLogoutUserRequest) (*inputport.ForceLogoutResponse, error) {
+	if _, err := i.userRepo.Read(ctx, req.UserID); err != nil {
+		return nil, entities.ErrUserNotFound
+	}
+
+	count, err := i.sessionRepo.DeleteByUserIDExcept(ctx, req.UserID, req.CurrentSessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("User sessions revoked by admin",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("user_id", req.UserID),
+		entities.NewField("revoked_count", count))
+
+	return &inputport.ForceLogoutResponse{RevokedCount: count}, nil
+}
+
+// PurgeSessions は管理者が全ユーザーのセッションを失効（テナントのあるリクエストではそのテナントのみ）
+func (i *SessionInteractor) PurgeSessions(ctx context.Context, req *inputport.PurgeSessionsRequest) (*inputport.ForceLogoutResponse, error) {
+	count, err := i.sessionRepo.DeleteAllExcept(ctx, req.CurrentSessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	i.logger.Warn("All sessions revoked by admin",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("revoked_count", count))
+
+	return &inputport.ForceLogoutResponse{RevokedCount: count}, nil
+}
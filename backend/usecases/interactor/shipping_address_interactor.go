@@ -0,0 +1,161 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// ShippingAddressInteractor はお届け先（住所録）のユースケース実装
+type ShippingAddressInteractor struct {
+	txManager   repository.TransactionManager
+	addressRepo repository.ShippingAddressRepository
+	logger      entities.Logger
+}
+
+// NewShippingAddressInteractor は新しいShippingAddressInteractorを作成
+func NewShippingAddressInteractor(
+	txManager repository.TransactionManager,
+	addressRepo repository.ShippingAddressRepository,
+	logger entities.Logger,
+) inputport.ShippingAddressInputPort {
+	return &ShippingAddressInteractor{
+		txManager:   txManager,
+		addressRepo: addressRepo,
+		logger:      logger,
+	}
+}
+
+// ListAddresses はユーザーのお届け先を既定のもの、登録順の順に取得
+func (i *ShippingAddressInteractor) ListAddresses(ctx context.Context, userID uuid.UUID) ([]*entities.ShippingAddress, error) {
+	return i.addressRepo.ReadListByUserID(ctx, userID)
+}
+
+// CreateAddress はお届け先を登録（最初の1件とIsDefault指定時は既定にする）
+func (i *ShippingAddressInteractor) CreateAddress(ctx context.Context, req *inputport.CreateShippingAddressRequest) (*entities.ShippingAddress, error) {
+	address, err := entities.NewShippingAddress(req.UserID, req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.txManager.Do(ctx, func(ctx context.Context) error {
+		existing, err := i.addressRepo.ReadListByUserID(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+		if len(existing) >= entities.ShippingAddressMaxPerUser {
+			return entities.ErrShippingAddressLimit.WithParams(map[string]interface{}{"max": entities.ShippingAddressMaxPerUser})
+		}
+		if err := i.addressRepo.Create(ctx, address); err != nil {
+			return err
+		}
+		if len(existing) == 0 || req.IsDefault {
+			if err := i.addressRepo.SetDefault(ctx, req.UserID, address.ID); err != nil {
+				return err
+			}
+			address.IsDefault = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+// UpdateAddress はお届け先を変更（IsDefault指定時は既定にする）
+func (i *ShippingAddressInteractor) UpdateAddress(ctx context.Context, req *inputport.UpdateShippingAddressRequest) (*entities.ShippingAddress, error) {
+	var address *entities.ShippingAddress
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		address, err = readUserShippingAddress(ctx, i.addressRepo, req.UserID, req.AddressID, entities.ActionUpdate)
+		if err != nil {
+			return err
+		}
+		if err := address.Update(req.Address); err != nil {
+			return err
+		}
+		if err := i.addressRepo.Update(ctx, address); err != nil {
+			return err
+		}
+		if req.IsDefault && !address.IsDefault {
+			if err := i.addressRepo.SetDefault(ctx, req.UserID, address.ID); err != nil {
+				return err
+			}
+			address.IsDefault = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+// SetDefaultAddress は既定のお届け先を切り替える
+func (i *ShippingAddressInteractor) SetDefaultAddress(ctx context.Context, userID, addressID uuid.UUID) (*entities.ShippingAddress, error) {
+	address, err := readUserShippingAddress(ctx, i.addressRepo, userID, addressID, entities.ActionUpdate)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.addressRepo.SetDefault(ctx, userID, addressID); err != nil {
+		return nil, err
+	}
+	address.IsDefault = true
+	return address, nil
+}
+
+// DeleteAddress はお届け先を削除（既定を削除した場合は最も古いお届け先を既定にする）
+// 交換記録には交換時点の住所を控えてあるため、削除しても配送先は分かる
+func (i *ShippingAddressInteractor) DeleteAddress(ctx context.Context, userID, addressID uuid.UUID) error {
+	return i.txManager.Do(ctx, func(ctx context.Context) error {
+		address, err := readUserShippingAddress(ctx, i.addressRepo, userID, addressID, entities.ActionDelete)
+		if err != nil {
+			return err
+		}
+		if err := i.addressRepo.Delete(ctx, addressID); err != nil {
+			return err
+		}
+		if !address.IsDefault {
+			return nil
+		}
+
+		remaining, err := i.addressRepo.ReadListByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if len(remaining) == 0 {
+			return nil
+		}
+		return i.addressRepo.SetDefault(ctx, userID, remaining[0].ID)
+	})
+}
+
+// readUserShippingAddress はユーザー自身のお届け先を取得（他のユーザーのお届け先はErrShippingAddressNotFound）
+func readUserShippingAddress(ctx context.Context, addressRepo repository.ShippingAddressRepository, userID, addressID uuid.UUID, action entities.Action) (*entities.ShippingAddress, error) {
+	address, err := addressRepo.Read(ctx, addressID)
+	if err != nil {
+		return nil, err
+	}
+	if err := address.Authorize(userID, action); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+// resolveShippingAddress は配送が必要な商品のお届け先を決める
+// addressIDを指定すればそのお届け先、なければ既定のお届け先。どちらもなければErrShippingAddressRequired
+func resolveShippingAddress(ctx context.Context, addressRepo repository.ShippingAddressRepository, userID uuid.UUID, addressID *uuid.UUID) (*entities.ShippingAddress, error) {
+	if addressID != nil {
+		return readUserShippingAddress(ctx, addressRepo, userID, *addressID, entities.ActionView)
+	}
+	address, err := addressRepo.ReadDefault(ctx, userID)
+	if errors.Is(err, entities.ErrShippingAddressNotFound) {
+		return nil, entities.ErrShippingAddressRequired
+	}
+	return address, err
+}
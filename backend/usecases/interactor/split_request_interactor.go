@@ -0,0 +1,277 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// SplitRequestInteractor は割り勘のユースケース実装
+// 支払いは送金リクエストの承認（TransferRequestInteractor）で行い、ここでは作成・集計・キャンセルを扱う
+type SplitRequestInteractor struct {
+	txManager           repository.TransactionManager
+	splitRequestRepo    repository.SplitRequestRepository
+	transferRequestRepo repository.TransferRequestRepository
+	userRepo            repository.UserRepository
+	friendshipRepo      repository.FriendshipRepository
+	contentModeration   inputport.ContentModerationInputPort
+	notifications       inputport.NotificationDispatcher
+	logger              entities.Logger
+}
+
+// NewSplitRequestInteractor は新しいSplitRequestInteractorを作成
+func NewSplitRequestInteractor(
+	txManager repository.TransactionManager,
+	splitRequestRepo repository.SplitRequestRepository,
+	transferRequestRepo repository.TransferRequestRepository,
+	userRepo repository.UserRepository,
+	friendshipRepo repository.FriendshipRepository,
+	contentModeration inputport.ContentModerationInputPort,
+	notifications inputport.NotificationDispatcher,
+	logger entities.Logger,
+) inputport.SplitRequestInputPort {
+	return &SplitRequestInteractor{
+		txManager:           txManager,
+		splitRequestRepo:    splitRequestRepo,
+		transferRequestRepo: transferRequestRepo,
+		userRepo:            userRepo,
+		friendshipRepo:      friendshipRepo,
+		contentModeration:   contentModeration,
+		notifications:       notifications,
+		logger:              logger,
+	}
+}
+
+// CreateSplitRequest は割り勘を作成し、友達の参加者それぞれに送金リクエストを送る
+// 割り勘と全員分の送金リクエストは1トランザクションで作成し、通知はコミット後に送る
+func (i *SplitRequestInteractor) CreateSplitRequest(ctx context.Context, req *inputport.CreateSplitRequestRequest) (*inputport.SplitRequestDetail, error) {
+	i.logger.Info("Creating split request",
+		entities.NewField("requester_id", req.RequesterID),
+		entities.NewField("total_amount", req.TotalAmount),
+		entities.NewField("participants", len(req.Participants)))
+
+	// 冪等性チェック
+	existing, err := i.splitRequestRepo.ReadByIdempotencyKey(ctx, req.IdempotencyKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check idempotency key: %w", err)
+	}
+	if existing != nil {
+		if existing.RequesterID != req.RequesterID {
+			return nil, entities.ErrIdempotencyKeyReused
+		}
+		return i.detail(ctx, existing)
+	}
+
+	requester, err := i.userRepo.Read(ctx, req.RequesterID)
+	if err != nil {
+		return nil, err
+	}
+	if !requester.IsActive {
+		return nil, errors.New("requester is not active")
+	}
+
+	split, shares, err := entities.NewSplitRequest(req.RequesterID, req.TotalAmount, req.SplitType, req.Participants, req.IncludeRequester, req.Message, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// 参加者は有効な友達のみ
+	for _, share := range shares {
+		user, err := i.userRepo.Read(ctx, share.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !user.IsActive {
+			return nil, entities.ErrInvalidSplitRequest
+		}
+		friends, err := i.friendshipRepo.CheckAreFriends(ctx, req.RequesterID, share.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check friendship: %w", err)
+		}
+		if !friends {
+			return nil, entities.ErrNotFriends
+		}
+	}
+
+	// メッセージのモデレーション
+	if err := i.contentModeration.CheckContent(ctx, req.RequesterID, entities.ModerationFieldTransferMessage, req.Message); err != nil {
+		return nil, err
+	}
+
+	requests := make([]*entities.TransferRequest, len(shares))
+	err = i.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := i.splitRequestRepo.Create(ctx, split); err != nil {
+			return fmt.Errorf("failed to save split request: %w", err)
+		}
+		for n, share := range shares {
+			tr, err := split.NewShareRequest(share)
+			if err != nil {
+				return err
+			}
+			if err := i.transferRequestRepo.Create(ctx, tr); err != nil {
+				return fmt.Errorf("failed to save transfer request: %w", err)
+			}
+			requests[n] = tr
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Split request created successfully",
+		entities.NewField("split_request_id", split.ID),
+		entities.NewField("shares", len(requests)))
+
+	// 参加者へ支払いのリクエストを通知
+	for _, tr := range requests {
+		i.notifications.Dispatch(ctx, entities.NewSplitShareNotification(tr, requester))
+	}
+
+	return i.detailWithShares(ctx, split, requests)
+}
+
+// GetSplitRequest は割り勘の支払い状況を取得（作成者・参加者のみ）
+func (i *SplitRequestInteractor) GetSplitRequest(ctx context.Context, req *inputport.GetSplitRequestRequest) (*inputport.SplitRequestDetail, error) {
+	split, err := i.splitRequestRepo.Read(ctx, req.SplitRequestID)
+	if err != nil {
+		return nil, err
+	}
+	requests, err := i.transferRequestRepo.ReadBySplitIDs(ctx, []uuid.UUID{split.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get split shares: %w", err)
+	}
+
+	// 関係のないユーザーには存在を明かさない
+	allowed := split.RequesterID == req.UserID
+	for _, tr := range requests {
+		if tr.FromUserID == req.UserID {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return nil, entities.ErrSplitRequestNotFound
+	}
+
+	return i.detailWithShares(ctx, split, requests)
+}
+
+// ListSplitRequests は作成した割り勘の一覧を支払い状況とともに取得
+func (i *SplitRequestInteractor) ListSplitRequests(ctx context.Context, req *inputport.ListSplitRequestsRequest) (*inputport.ListSplitRequestsResponse, error) {
+	splits, err := i.splitRequestRepo.ReadListByRequester(ctx, req.RequesterID, req.Offset, req.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get split requests: %w", err)
+	}
+
+	ids := make([]uuid.UUID, len(splits))
+	for n, s := range splits {
+		ids[n] = s.ID
+	}
+	requests, err := i.transferRequestRepo.ReadBySplitIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get split shares: %w", err)
+	}
+	bySplit := make(map[uuid.UUID][]*entities.TransferRequest, len(splits))
+	for _, tr := range requests {
+		bySplit[*tr.SplitID] = append(bySplit[*tr.SplitID], tr)
+	}
+
+	users := make(map[uuid.UUID]*entities.User)
+	resp := &inputport.ListSplitRequestsResponse{SplitRequests: make([]*inputport.SplitRequestDetail, 0, len(splits))}
+	for _, s := range splits {
+		detail, err := i.buildDetail(ctx, users, s, bySplit[s.ID])
+		if err != nil {
+			return nil, err
+		}
+		resp.SplitRequests = append(resp.SplitRequests, detail)
+	}
+	return resp, nil
+}
+
+// CancelSplitRequest は割り勘をキャンセルし、支払い待ちの送金リクエストを取り消す（作成者のみ）
+// 支払い済みの分はそのまま残る
+func (i *SplitRequestInteractor) CancelSplitRequest(ctx context.Context, req *inputport.CancelSplitRequestRequest) (*inputport.SplitRequestDetail, error) {
+	i.logger.Info("Cancelling split request",
+		entities.NewField("split_request_id", req.SplitRequestID),
+		entities.NewField("user_id", req.UserID))
+
+	split, err := i.splitRequestRepo.Read(ctx, req.SplitRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if split.RequesterID != req.UserID {
+		return nil, entities.ErrSplitRequestNotFound
+	}
+	requests, err := i.transferRequestRepo.ReadBySplitIDs(ctx, []uuid.UUID{split.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get split shares: %w", err)
+	}
+	if err := split.Cancel(requests); err != nil {
+		return nil, err
+	}
+
+	err = i.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := i.splitRequestRepo.Update(ctx, split); err != nil {
+			return fmt.Errorf("failed to update split request: %w", err)
+		}
+		for _, tr := range requests {
+			if tr.CanCancel() != nil {
+				continue
+			}
+			if err := tr.Cancel(); err != nil {
+				return err
+			}
+			if err := i.transferRequestRepo.Update(ctx, tr); err != nil {
+				return fmt.Errorf("failed to update transfer request: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Split request cancelled successfully",
+		entities.NewField("split_request_id", split.ID))
+
+	return i.detailWithShares(ctx, split, requests)
+}
+
+// detail は割り勘の送金リクエストを取得して支払い状況をまとめる
+func (i *SplitRequestInteractor) detail(ctx context.Context, split *entities.SplitRequest) (*inputport.SplitRequestDetail, error) {
+	requests, err := i.transferRequestRepo.ReadBySplitIDs(ctx, []uuid.UUID{split.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get split shares: %w", err)
+	}
+	return i.detailWithShares(ctx, split, requests)
+}
+
+func (i *SplitRequestInteractor) detailWithShares(ctx context.Context, split *entities.SplitRequest, requests []*entities.TransferRequest) (*inputport.SplitRequestDetail, error) {
+	return i.buildDetail(ctx, make(map[uuid.UUID]*entities.User), split, requests)
+}
+
+// buildDetail は支払い状況と参加者をまとめる（users は同じユーザーを何度も読まないためのキャッシュ）
+func (i *SplitRequestInteractor) buildDetail(ctx context.Context, users map[uuid.UUID]*entities.User, split *entities.SplitRequest, requests []*entities.TransferRequest) (*inputport.SplitRequestDetail, error) {
+	detail := &inputport.SplitRequestDetail{
+		SplitRequest: split,
+		Summary:      split.Summarize(requests),
+		Shares:       make([]*inputport.SplitShareInfo, 0, len(requests)),
+	}
+	for _, tr := range requests {
+		user, ok := users[tr.FromUserID]
+		if !ok {
+			var err error
+			if user, err = i.userRepo.Read(ctx, tr.FromUserID); err != nil {
+				return nil, fmt.Errorf("failed to get participant: %w", err)
+			}
+			users[tr.FromUserID] = user
+		}
+		detail.Shares = append(detail.Shares, &inputport.SplitShareInfo{TransferRequest: tr, User: user})
+	}
+	return detail, nil
+}
@@ -0,0 +1,192 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// SuspiciousActivityInteractor は不審な送金の確認のユースケース実装
+// 検出と記録は送金時にTransferScreeningInteractorが行う
+type SuspiciousActivityInteractor struct {
+	activityRepo    repository.SuspiciousActivityRepository
+	idempotencyRepo repository.IdempotencyKeyRepository
+	settingsRepo    repository.SystemSettingsRepository
+	userRepo        repository.UserRepository
+	pointTransfer   inputport.PointTransferInputPort
+	logger          entities.Logger
+}
+
+// NewSuspiciousActivityInteractor は新しいSuspiciousActivityInteractorを作成
+func NewSuspiciousActivityInteractor(
+	activityRepo repository.SuspiciousActivityRepository,
+	idempotencyRepo repository.IdempotencyKeyRepository,
+	settingsRepo repository.SystemSettingsRepository,
+	userRepo repository.UserRepository,
+	pointTransfer inputport.PointTransferInputPort,
+	logger entities.Logger,
+) inputport.SuspiciousActivityInputPort {
+	return &SuspiciousActivityInteractor{
+		activityRepo:    activityRepo,
+		idempotencyRepo: idempotencyRepo,
+		settingsRepo:    settingsRepo,
+		userRepo:        userRepo,
+		pointTransfer:   pointTransfer,
+		logger:          logger,
+	}
+}
+
+// GetHoldEnabled は不審な送金を保留するかを取得
+func (i *SuspiciousActivityInteractor) GetHoldEnabled(ctx context.Context, adminID uuid.UUID) (bool, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return false, err
+	}
+	value, err := i.settingsRepo.GetSetting(ctx, entities.TransferHoldSettingKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to get transfer hold setting: %w", err)
+	}
+	return entities.ParseTransferHoldEnabled(value), nil
+}
+
+// UpdateHoldEnabled は不審な送金を保留するかを設定
+func (i *SuspiciousActivityInteractor) UpdateHoldEnabled(ctx context.Context, req *inputport.UpdateTransferHoldRequest) (bool, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return false, err
+	}
+	if err := i.settingsRepo.SetSetting(ctx, entities.TransferHoldSettingKey, strconv.FormatBool(req.Enabled), "不審な送金を管理者の確認まで保留するか"); err != nil {
+		return false, fmt.Errorf("failed to save transfer hold setting: %w", err)
+	}
+
+	i.logger.Info("Transfer hold setting updated",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("enabled", req.Enabled))
+	return req.Enabled, nil
+}
+
+// ListActivities は不審な送金の記録を新しい順に取得
+func (i *SuspiciousActivityInteractor) ListActivities(ctx context.Context, req *inputport.ListSuspiciousActivitiesRequest) (*inputport.ListSuspiciousActivitiesResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	activities, err := i.activityRepo.ReadList(ctx, req.Status, req.Offset, req.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list suspicious activities: %w", err)
+	}
+	total, err := i.activityRepo.Count(ctx, req.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count suspicious activities: %w", err)
+	}
+	return &inputport.ListSuspiciousActivitiesResponse{Activities: activities, Total: total}, nil
+}
+
+// DismissActivity は問題なしと判断する
+// 保留中の送金は確認を先に保存してから実行するため、同時に確認されても実行は一度だけになる
+// 実行に失敗した場合（残高不足など）は失敗として記録する
+func (i *SuspiciousActivityInteractor) DismissActivity(ctx context.Context, req *inputport.ReviewSuspiciousActivityRequest) (*entities.SuspiciousActivity, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	activity, err := i.activityRepo.Read(ctx, req.ActivityID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := activity.Status
+	if err := activity.Dismiss(req.AdminID, req.Comment, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := i.transition(ctx, activity, from); err != nil {
+		return nil, err
+	}
+	i.logger.Info("Suspicious activity dismissed",
+		entities.NewField("activity_id", activity.ID),
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("status", string(activity.Status)))
+
+	if activity.Status != entities.SuspiciousActivityStatusReleased {
+		return activity, nil
+	}
+
+	resp, execErr := i.pointTransfer.Transfer(ctx, &inputport.TransferRequest{
+		FromUserID:     activity.FromUserID,
+		ToUserID:       activity.ToUserID,
+		Amount:         activity.Amount,
+		IdempotencyKey: activity.IdempotencyKey,
+		Description:    activity.Description,
+		ReleasedBy:     &req.AdminID,
+	})
+	if execErr != nil {
+		activity.MarkFailed(execErr.Error(), time.Now())
+		if err := i.transition(ctx, activity, entities.SuspiciousActivityStatusReleased); err != nil {
+			return nil, err
+		}
+		i.logger.Warn("Released transfer failed",
+			entities.NewField("activity_id", activity.ID),
+			entities.NewField("error", execErr))
+		return nil, execErr
+	}
+
+	activity.SetTransaction(resp.Transaction.ID, time.Now())
+	if err := i.transition(ctx, activity, entities.SuspiciousActivityStatusReleased); err != nil {
+		return nil, err
+	}
+	i.logger.Info("Held transfer released",
+		entities.NewField("activity_id", activity.ID),
+		entities.NewField("transaction_id", resp.Transaction.ID))
+	return activity, nil
+}
+
+// ConfirmActivity は不正と判断する
+// 保留中の送金は取り消し、同じ冪等性キーで再送されても実行しない
+func (i *SuspiciousActivityInteractor) ConfirmActivity(ctx context.Context, req *inputport.ReviewSuspiciousActivityRequest) (*entities.SuspiciousActivity, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	activity, err := i.activityRepo.Read(ctx, req.ActivityID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := activity.Status
+	if err := activity.Confirm(req.AdminID, req.Comment, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := i.transition(ctx, activity, from); err != nil {
+		return nil, err
+	}
+
+	if activity.Status == entities.SuspiciousActivityStatusRejected {
+		// 冪等性キーが期限切れで削除済みなら、再送は新しい送金として改めて検出される
+		if key, err := i.idempotencyRepo.ReadByKey(ctx, activity.IdempotencyKey); err == nil && key.Status == idempotencyStatusHeld {
+			key.Status = idempotencyStatusRejected
+			if err := i.idempotencyRepo.Update(ctx, key); err != nil {
+				return nil, fmt.Errorf("failed to reject held transfer: %w", err)
+			}
+		}
+	}
+
+	i.logger.Info("Suspicious activity confirmed",
+		entities.NewField("activity_id", activity.ID),
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("status", string(activity.Status)))
+	return activity, nil
+}
+
+// transition は状態がfromのときだけ記録を更新する（他の管理者が先に確認していればErrSuspiciousActivityReviewed）
+func (i *SuspiciousActivityInteractor) transition(ctx context.Context, activity *entities.SuspiciousActivity, from entities.SuspiciousActivityStatus) error {
+	updated, err := i.activityRepo.UpdateStatus(ctx, activity, from)
+	if err != nil {
+		return fmt.Errorf("failed to update suspicious activity: %w", err)
+	}
+	if !updated {
+		return entities.ErrSuspiciousActivityReviewed
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// SystemConfigInteractor はサーバー設定の確認のユースケース実装
+type SystemConfigInteractor struct {
+	userRepo repository.UserRepository
+	settings entities.ConfigSettings
+}
+
+// NewSystemConfigInteractor は新しいSystemConfigInteractorを作成
+func NewSystemConfigInteractor(
+	userRepo repository.UserRepository,
+	settings entities.ConfigSettings,
+) inputport.SystemConfigInputPort {
+	return &SystemConfigInteractor{
+		userRepo: userRepo,
+		settings: settings,
+	}
+}
+
+// GetSettings は起動時に読み込んだ設定を取得（管理者のみ）
+func (i *SystemConfigInteractor) GetSettings(ctx context.Context, adminID uuid.UUID) (entities.ConfigSettings, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	return i.settings, nil
+}
@@ -0,0 +1,171 @@
+package interactor
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultTenantListLimit = 50
+	maxTenantListLimit     = 200
+)
+
+// TenantInteractor はテナントの解決と管理のユースケース実装
+type TenantInteractor struct {
+	txManager       repository.TransactionManager
+	tenantRepo      repository.TenantRepository
+	userRepo        repository.UserRepository
+	passwordService service.PasswordService
+	logger          entities.Logger
+}
+
+// NewTenantInteractor は新しいTenantInteractorを作成
+func NewTenantInteractor(
+	txManager repository.TransactionManager,
+	tenantRepo repository.TenantRepository,
+	userRepo repository.UserRepository,
+	passwordService service.PasswordService,
+	logger entities.Logger,
+) inputport.TenantInputPort {
+	return &TenantInteractor{
+		txManager:       txManager,
+		tenantRepo:      tenantRepo,
+		userRepo:        userRepo,
+		passwordService: passwordService,
+		logger:          logger,
+	}
+}
+
+// ResolveTenant はスラッグからリクエストのテナントを決める
+func (i *TenantInteractor) ResolveTenant(ctx context.Context, slug string) (*entities.Tenant, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	var tenant *entities.Tenant
+	var err error
+	if slug == "" || slug == entities.DefaultTenantSlug {
+		tenant, err = i.tenantRepo.Read(ctx, entities.DefaultTenantID)
+	} else {
+		tenant, err = i.tenantRepo.ReadBySlug(ctx, slug)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !tenant.IsActive {
+		return nil, entities.ErrTenantInactive
+	}
+	return tenant, nil
+}
+
+// ListTenants はテナントの一覧を取得
+func (i *TenantInteractor) ListTenants(ctx context.Context, req *inputport.ListTenantsRequest) (*inputport.ListTenantsResponse, error) {
+	if err := i.requireSuperAdmin(ctx, req.SuperAdminID); err != nil {
+		return nil, err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultTenantListLimit
+	}
+	if limit > maxTenantListLimit {
+		limit = maxTenantListLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	tenants, err := i.tenantRepo.ReadList(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	total, err := i.tenantRepo.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &inputport.ListTenantsResponse{Tenants: tenants, Total: total, Offset: offset, Limit: limit}, nil
+}
+
+// CreateTenant はテナントと最初の管理者を1トランザクションで作成する
+// 管理者には仮パスワードを発行し、ログイン後に変更してもらう
+func (i *TenantInteractor) CreateTenant(ctx context.Context, req *inputport.CreateTenantRequest) (*inputport.CreateTenantResponse, error) {
+	if err := i.requireSuperAdmin(ctx, req.SuperAdminID); err != nil {
+		return nil, err
+	}
+	tenant, err := entities.NewTenant(req.Slug, req.Name, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := entities.GenerateTemporaryPassword()
+	if err != nil {
+		return nil, err
+	}
+	hash, err := i.passwordService.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := entities.NewUser(req.AdminUsername, req.AdminEmail, hash, req.AdminUsername, req.AdminFirstName, req.AdminLastName)
+	if err != nil {
+		return nil, err
+	}
+	admin.TenantID = tenant.ID
+	admin.Role = entities.RoleAdmin
+
+	err = i.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := i.tenantRepo.Create(ctx, tenant); err != nil {
+			return err
+		}
+		return i.userRepo.Create(entities.WithTenantID(ctx, tenant.ID), admin)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Tenant created",
+		entities.NewField("super_admin_id", req.SuperAdminID),
+		entities.NewField("tenant_id", tenant.ID),
+		entities.NewField("slug", tenant.Slug),
+		entities.NewField("admin_id", admin.ID))
+
+	return &inputport.CreateTenantResponse{Tenant: tenant, Admin: admin, TemporaryPassword: password}, nil
+}
+
+// UpdateTenant はテナント名と有効・無効を変更
+func (i *TenantInteractor) UpdateTenant(ctx context.Context, req *inputport.UpdateTenantRequest) (*entities.Tenant, error) {
+	if err := i.requireSuperAdmin(ctx, req.SuperAdminID); err != nil {
+		return nil, err
+	}
+	tenant, err := i.tenantRepo.Read(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tenant.Update(req.Name, req.IsActive, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := i.tenantRepo.Update(ctx, tenant); err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Tenant updated",
+		entities.NewField("super_admin_id", req.SuperAdminID),
+		entities.NewField("tenant_id", tenant.ID),
+		entities.NewField("is_active", tenant.IsActive))
+
+	return tenant, nil
+}
+
+func (i *TenantInteractor) requireSuperAdmin(ctx context.Context, userID uuid.UUID) error {
+	user, err := i.userRepo.Read(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsSuperAdmin() {
+		return entities.ErrSuperAdminRequired
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package interactor
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+const (
+	defaultArchiveListLimit = 50
+	maxArchiveListLimit     = 100
+	// transactionArchiveMaxRuntime は1回の実行で取引を移す時間の上限（残りは次の実行に回す）
+	// ジョブのロックが切れる前に終える
+	transactionArchiveMaxRuntime = entities.ScheduledJobLockTimeout * 2 / 3
+)
+
+// TransactionArchiveInteractor は取引の保管のユースケース実装
+type TransactionArchiveInteractor struct {
+	txManager   repository.TransactionManager
+	archiveRepo repository.TransactionArchiveRepository
+	userRepo    repository.UserRepository
+	retention   entities.TransactionRetention
+	logger      entities.Logger
+}
+
+// NewTransactionArchiveInteractor は新しいTransactionArchiveInteractorを作成
+func NewTransactionArchiveInteractor(
+	txManager repository.TransactionManager,
+	archiveRepo repository.TransactionArchiveRepository,
+	userRepo repository.UserRepository,
+	retention entities.TransactionRetention,
+	logger entities.Logger,
+) *TransactionArchiveInteractor {
+	return &TransactionArchiveInteractor{
+		txManager:   txManager,
+		archiveRepo: archiveRepo,
+		userRepo:    userRepo,
+		retention:   retention,
+		logger:      logger,
+	}
+}
+
+// ArchiveTransactions は保持期間を過ぎた確定済みの取引をBatchSize件ずつ別のトランザクションで移す
+// 長いトランザクションでロックを持ち続けないよう、1回の実行はtransactionArchiveMaxRuntimeまでとする
+func (i *TransactionArchiveInteractor) ArchiveTransactions(ctx context.Context, now time.Time) (int64, error) {
+	if !i.retention.Enabled() {
+		return 0, nil
+	}
+	cutoff := i.retention.Cutoff(now)
+	deadline := time.Now().Add(transactionArchiveMaxRuntime)
+
+	var total int64
+	for time.Now().Before(deadline) {
+		var moved int64
+		err := i.txManager.Do(ctx, func(ctx context.Context) error {
+			var err error
+			moved, err = i.archiveRepo.MoveBefore(ctx, cutoff, i.retention.BatchSize)
+			return err
+		})
+		if err != nil {
+			i.logger.Error("Failed to archive transactions",
+				entities.NewField("archived", total),
+				entities.NewField("error", err))
+			return total, err
+		}
+		total += moved
+		if moved < int64(i.retention.BatchSize) {
+			break
+		}
+	}
+
+	if total > 0 {
+		i.logger.Info("Transactions archived",
+			entities.NewField("cutoff", cutoff),
+			entities.NewField("archived", total))
+	}
+	return total, nil
+}
+
+// ListArchivedTransactions は保管済みの取引を検索する
+func (i *TransactionArchiveInteractor) ListArchivedTransactions(ctx context.Context, req *inputport.ListArchivedTransactionsRequest) (*inputport.ListArchivedTransactionsResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	if err := req.Filter.Validate(); err != nil {
+		return nil, err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultArchiveListLimit
+	}
+	if limit > maxArchiveListLimit {
+		limit = maxArchiveListLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	transactions, err := i.archiveRepo.ReadList(ctx, &req.Filter, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	total, err := i.archiveRepo.Count(ctx, &req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return &inputport.ListArchivedTransactionsResponse{
+		Transactions:  transactions,
+		Total:         total,
+		Offset:        offset,
+		Limit:         limit,
+		RetentionDays: i.retention.Days,
+	}, nil
+}
+
+// GetArchiveSummaries は保管済みの取引の月・種別・状態ごとの集計を取得
+func (i *TransactionArchiveInteractor) GetArchiveSummaries(ctx context.Context, req *inputport.GetArchiveSummariesRequest) (*inputport.GetArchiveSummariesResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	if err := entities.ValidateArchiveMonth(req.FromMonth); err != nil {
+		return nil, err
+	}
+	if err := entities.ValidateArchiveMonth(req.ToMonth); err != nil {
+		return nil, err
+	}
+
+	summaries, err := i.archiveRepo.ReadSummaries(ctx, req.FromMonth, req.ToMonth)
+	if err != nil {
+		return nil, err
+	}
+	return &inputport.GetArchiveSummariesResponse{Summaries: summaries, RetentionDays: i.retention.Days}, nil
+}
@@ -0,0 +1,301 @@
+package interactor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// TransactionImportInteractor は過去の取引の取り込みのユースケース実装
+type TransactionImportInteractor struct {
+	txManager       repository.TransactionManager
+	userRepo        repository.UserRepository
+	transactionRepo repository.TransactionRepository
+	pointBatchRepo  repository.PointBatchRepository
+	ledgerRepo      repository.BalanceLedgerRepository
+	logger          entities.Logger
+}
+
+// NewTransactionImportInteractor は新しいTransactionImportInteractorを作成
+func NewTransactionImportInteractor(
+	txManager repository.TransactionManager,
+	userRepo repository.UserRepository,
+	transactionRepo repository.TransactionRepository,
+	pointBatchRepo repository.PointBatchRepository,
+	ledgerRepo repository.BalanceLedgerRepository,
+	logger entities.Logger,
+) inputport.TransactionImportInputPort {
+	return &TransactionImportInteractor{
+		txManager:       txManager,
+		userRepo:        userRepo,
+		transactionRepo: transactionRepo,
+		pointBatchRepo:  pointBatchRepo,
+		ledgerRepo:      ledgerRepo,
+		logger:          logger,
+	}
+}
+
+// transactionImportEntry は取り込み処理中の1行分の状態
+type transactionImportEntry struct {
+	row    *entities.TransactionImportRow
+	result *inputport.TransactionImportResult
+	from   *entities.User
+	to     *entities.User
+}
+
+// ImportTransactions はCSVから過去の取引を元の日時で取り込む
+// 日時の順に残高を積み上げて検証し、すべての行が正しい場合だけ1トランザクションで書き込んで残高を照合する
+func (i *TransactionImportInteractor) ImportTransactions(ctx context.Context, req *inputport.ImportTransactionsRequest) (*inputport.ImportTransactionsResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	rows, err := entities.ParseTransactionImportCSV(bytes.NewReader(req.CSVData), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	importID := uuid.New()
+	i.logger.Info("Importing transactions",
+		entities.NewField("import_id", importID),
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("rows", len(rows)),
+		entities.NewField("dry_run", req.DryRun))
+
+	resp := &inputport.ImportTransactionsResponse{
+		ImportID: importID,
+		DryRun:   req.DryRun,
+		Results:  make([]*inputport.TransactionImportResult, 0, len(rows)),
+	}
+	entries := make([]*transactionImportEntry, 0, len(rows))
+	users := make(map[string]*entities.User)
+	for _, row := range rows {
+		result := &inputport.TransactionImportResult{
+			Line:   row.Line,
+			Date:   row.Date,
+			From:   row.From,
+			To:     row.To,
+			Amount: row.Amount,
+			Type:   row.Type,
+			Err:    row.Err,
+		}
+		resp.Results = append(resp.Results, result)
+		if row.Err != nil {
+			continue
+		}
+
+		entry := &transactionImportEntry{row: row, result: result}
+		if entry.from, err = i.resolveUser(ctx, users, row.From); err == nil {
+			entry.to, err = i.resolveUser(ctx, users, row.To)
+		}
+		if err != nil {
+			if !errors.Is(err, entities.ErrUserNotFound) {
+				return nil, err
+			}
+			result.Err = err
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	// 同じ日時の行はファイルの順に処理する
+	sort.SliceStable(entries, func(a, b int) bool {
+		return entries[a].row.Date.Before(entries[b].row.Date)
+	})
+	balances := make(map[uuid.UUID]int64, len(users))
+	for _, u := range users {
+		balances[u.ID] = u.Balance
+	}
+	for _, e := range entries {
+		if e.from != nil {
+			// 途中で残高が負になる減算・送金はエラーにする（後続の行はその行がなかったものとして検証する）
+			if balances[e.from.ID] < e.row.Amount {
+				e.result.Err = entities.ErrInsufficientBalance
+				continue
+			}
+			balances[e.from.ID] -= e.row.Amount
+		}
+		if e.to != nil {
+			balances[e.to.ID] += e.row.Amount
+		}
+	}
+
+	for _, r := range resp.Results {
+		if r.Err != nil {
+			resp.FailedCount++
+		}
+	}
+	if resp.FailedCount > 0 {
+		i.logger.Info("Transaction import rejected",
+			entities.NewField("import_id", importID),
+			entities.NewField("failed", resp.FailedCount))
+		return resp, nil
+	}
+
+	err = i.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := i.writeEntries(ctx, importID, req.AdminID, entries); err != nil {
+			return err
+		}
+
+		userIDs := make([]uuid.UUID, 0, len(users))
+		before := make(map[uuid.UUID]int64, len(users))
+		for _, u := range users {
+			userIDs = append(userIDs, u.ID)
+			before[u.ID] = u.Balance
+		}
+		sort.Slice(userIDs, func(a, b int) bool { return userIDs[a].String() < userIDs[b].String() })
+		checks, err := i.ledgerRepo.ReadBalanceChecks(ctx, userIDs)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile balances: %w", err)
+		}
+		for _, c := range checks {
+			resp.Balances = append(resp.Balances, &inputport.TransactionImportBalance{BalanceCheck: *c, BalanceBefore: before[c.UserID]})
+		}
+
+		// ドライランはここまでの処理をすべてロールバックする
+		if req.DryRun {
+			return errDryRunRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRunRollback) {
+		i.logger.Error("Failed to import transactions",
+			entities.NewField("import_id", importID),
+			entities.NewField("error", err))
+		return nil, err
+	}
+
+	if req.DryRun {
+		for _, r := range resp.Results {
+			r.TransactionID = nil
+		}
+	} else {
+		resp.Imported = true
+		resp.ImportedCount = len(entries)
+	}
+
+	var discrepancies int
+	for _, b := range resp.Balances {
+		if b.HasDiscrepancy() {
+			discrepancies++
+		}
+	}
+	i.logger.Info("Transactions imported",
+		entities.NewField("import_id", importID),
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("imported", resp.ImportedCount),
+		entities.NewField("dry_run", req.DryRun),
+		entities.NewField("discrepancies", discrepancies))
+
+	return resp, nil
+}
+
+// resolveUser はユーザー名からユーザーを取得する（空ならシステムの口座としてnil）
+func (i *TransactionImportInteractor) resolveUser(ctx context.Context, users map[string]*entities.User, username string) (*entities.User, error) {
+	if username == "" {
+		return nil, nil
+	}
+	if u, ok := users[username]; ok {
+		return u, nil
+	}
+	u, err := i.userRepo.ReadByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	users[username] = u
+	return u, nil
+}
+
+// writeEntries は取引・残高・ポイントバッチを書き込む
+// 受け取った分は取り込み時点から有効期限を数えるバッチにし、その後の日時の送金・減算はまず取り込んだバッチから古い順に消費する
+func (i *TransactionImportInteractor) writeEntries(ctx context.Context, importID, adminID uuid.UUID, entries []*transactionImportEntry) error {
+	now := time.Now()
+	net := make(map[uuid.UUID]int64)
+	var order []uuid.UUID
+	addNet := func(userID uuid.UUID, amount int64) {
+		if _, ok := net[userID]; !ok {
+			order = append(order, userID)
+		}
+		net[userID] += amount
+	}
+	pending := make(map[uuid.UUID][]*entities.PointBatch)
+	var batches []*entities.PointBatch
+	uncovered := make(map[uuid.UUID]int64)
+
+	for _, e := range entries {
+		var fromID, toID *uuid.UUID
+		if e.from != nil {
+			fromID = &e.from.ID
+		}
+		if e.to != nil {
+			toID = &e.to.ID
+		}
+		transaction := e.row.NewTransaction(fromID, toID, importID, adminID)
+		if err := i.transactionRepo.Create(ctx, transaction); err != nil {
+			return err
+		}
+		id := transaction.ID
+		e.result.TransactionID = &id
+
+		if fromID != nil {
+			addNet(*fromID, -e.row.Amount)
+			remaining := e.row.Amount
+			for _, b := range pending[*fromID] {
+				consume := b.RemainingAmount
+				if consume > remaining {
+					consume = remaining
+				}
+				b.RemainingAmount -= consume
+				remaining -= consume
+			}
+			uncovered[*fromID] += remaining
+		}
+		if toID != nil {
+			addNet(*toID, e.row.Amount)
+			batch := entities.NewPointBatch(*toID, e.row.Amount, e.row.PointBatchSource(), &transaction.ID, now)
+			pending[*toID] = append(pending[*toID], batch)
+			batches = append(batches, batch)
+		}
+	}
+
+	updates := make([]repository.BalanceUpdate, 0, len(order))
+	for _, userID := range order {
+		amount := net[userID]
+		if amount == 0 {
+			continue
+		}
+		update := repository.BalanceUpdate{UserID: userID, Amount: amount}
+		if amount < 0 {
+			update.Amount, update.IsDeduct = -amount, true
+		}
+		updates = append(updates, update)
+	}
+	if len(updates) > 0 {
+		if err := i.userRepo.UpdateBalancesWithLock(ctx, updates); err != nil {
+			return err
+		}
+	}
+
+	// 既存のバッチからの消費は、取り込んだバッチを作る前に行う（作った直後のバッチを二重に消費しない）
+	for _, userID := range order {
+		if amount := uncovered[userID]; amount > 0 {
+			if err := i.pointBatchRepo.ConsumePointsFIFO(ctx, userID, amount); err != nil {
+				return fmt.Errorf("failed to consume points: %w", err)
+			}
+		}
+	}
+	for _, batch := range batches {
+		if err := i.pointBatchRepo.Create(ctx, batch); err != nil {
+			return fmt.Errorf("failed to create point batch: %w", err)
+		}
+	}
+	return nil
+}
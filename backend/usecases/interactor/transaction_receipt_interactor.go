@@ -0,0 +1,101 @@
+package interactor
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+)
+
+// TransactionReceiptInteractor は取引の控えのユースケース実装
+type TransactionReceiptInteractor struct {
+	transactionRepo repository.TransactionRepository
+	userRepo        repository.UserRepository
+	signer          service.ReceiptSigner
+	logger          entities.Logger
+}
+
+// NewTransactionReceiptInteractor は新しいTransactionReceiptInteractorを作成
+func NewTransactionReceiptInteractor(
+	transactionRepo repository.TransactionRepository,
+	userRepo repository.UserRepository,
+	signer service.ReceiptSigner,
+	logger entities.Logger,
+) inputport.TransactionReceiptInputPort {
+	return &TransactionReceiptInteractor{
+		transactionRepo: transactionRepo,
+		userRepo:        userRepo,
+		signer:          signer,
+		logger:          logger,
+	}
+}
+
+// GetReceipt は取引の控えを検証ハッシュ付きで発行
+func (i *TransactionReceiptInteractor) GetReceipt(ctx context.Context, req *inputport.GetTransactionReceiptRequest) (*entities.TransactionReceipt, error) {
+	receipt, err := i.buildReceipt(ctx, req.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Transaction receipt issued",
+		entities.NewField("user_id", req.UserID),
+		entities.NewField("transaction_id", req.TransactionID))
+	return receipt, nil
+}
+
+// VerifyReceipt は提示された控えの検証ハッシュが本物かを確認
+// 控えは取引の変わらない内容から作り直すため、同じ取引の控えなら何度発行しても同じハッシュになる
+func (i *TransactionReceiptInteractor) VerifyReceipt(ctx context.Context, req *inputport.VerifyTransactionReceiptRequest) (*inputport.VerifyTransactionReceiptResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	receipt, err := i.buildReceipt(ctx, req.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	valid := i.signer.Verify(receipt.SigningPayload(), req.Hash)
+	if !valid {
+		i.logger.Warn("Transaction receipt hash mismatch",
+			entities.NewField("admin_id", req.AdminID),
+			entities.NewField("transaction_id", req.TransactionID))
+	}
+
+	return &inputport.VerifyTransactionReceiptResponse{
+		Valid:   valid,
+		Receipt: receipt,
+	}, nil
+}
+
+// buildReceipt は取引と当事者から控えを作り、検証ハッシュを付ける
+func (i *TransactionReceiptInteractor) buildReceipt(ctx context.Context, transactionID uuid.UUID) (*entities.TransactionReceipt, error) {
+	tx, err := i.transactionRepo.Read(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := entities.NewTransactionReceipt(tx, i.readParty(ctx, tx.FromUserID), i.readParty(ctx, tx.ToUserID), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	receipt.Hash = i.signer.Sign(receipt.SigningPayload())
+	return receipt, nil
+}
+
+// readParty は当事者のユーザーを読む（システムの口座ならnil。退会などで読めなければ名前なしで控えを作る）
+func (i *TransactionReceiptInteractor) readParty(ctx context.Context, userID *uuid.UUID) *entities.User {
+	if userID == nil {
+		return nil
+	}
+	user, err := i.userRepo.Read(ctx, *userID)
+	if err != nil {
+		i.logger.Warn("Failed to read receipt party", entities.NewField("user_id", *userID), entities.NewField("error", err))
+		return nil
+	}
+	return user
+}
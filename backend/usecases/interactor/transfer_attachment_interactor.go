@@ -0,0 +1,237 @@
+package interactor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+)
+
+// transferAttachmentCleanupBatchSize は保持期間を過ぎた添付画像を1回に取得して削除する件数
+const transferAttachmentCleanupBatchSize = 100
+
+// TransferAttachmentInteractor は送金・送金リクエストの添付画像のユースケース実装
+// 画像はアバターと同じくFileStorageServiceに保存し、拡張子・サイズの検証もそちらで行う
+type TransferAttachmentInteractor struct {
+	attachmentRepo      repository.TransferAttachmentRepository
+	transactionRepo     repository.TransactionRepository
+	transferRequestRepo repository.TransferRequestRepository
+	storage             service.FileStorageService
+	retention           entities.TransferAttachmentRetention
+	logger              entities.Logger
+}
+
+// NewTransferAttachmentInteractor は新しいTransferAttachmentInteractorを作成
+func NewTransferAttachmentInteractor(
+	attachmentRepo repository.TransferAttachmentRepository,
+	transactionRepo repository.TransactionRepository,
+	transferRequestRepo repository.TransferRequestRepository,
+	storage service.FileStorageService,
+	retention entities.TransferAttachmentRetention,
+	logger entities.Logger,
+) *TransferAttachmentInteractor {
+	return &TransferAttachmentInteractor{
+		attachmentRepo:      attachmentRepo,
+		transactionRepo:     transactionRepo,
+		transferRequestRepo: transferRequestRepo,
+		storage:             storage,
+		retention:           retention,
+		logger:              logger,
+	}
+}
+
+// AttachToTransaction は完了した送金に画像を添付
+// 元の送金リクエストに添付した画像があれば、それも置き換える（送金1件につき1枚）
+func (i *TransferAttachmentInteractor) AttachToTransaction(ctx context.Context, req *inputport.AttachTransferImageRequest) (*entities.TransferAttachment, error) {
+	tx, err := i.readTransaction(ctx, req.UserID, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.CanAttach(); err != nil {
+		return nil, err
+	}
+	existing, err := i.attachmentRepo.ReadByTransactionID(ctx, tx.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.attach(ctx, req, existing, func(filePath string) *entities.TransferAttachment {
+		return entities.NewTransactionAttachment(tx.ID, req.UserID, filePath, req.FileName, int64(len(req.FileData)))
+	})
+}
+
+// AttachToTransferRequest は承認待ちの送金リクエストに画像を添付
+func (i *TransferAttachmentInteractor) AttachToTransferRequest(ctx context.Context, req *inputport.AttachTransferImageRequest) (*entities.TransferAttachment, error) {
+	transferRequest, err := i.readTransferRequest(ctx, req.UserID, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if err := transferRequest.CanAttach(); err != nil {
+		return nil, err
+	}
+	existing, err := i.attachmentRepo.ReadByTransferRequestID(ctx, transferRequest.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.attach(ctx, req, existing, func(filePath string) *entities.TransferAttachment {
+		return entities.NewTransferRequestAttachment(transferRequest.ID, req.UserID, filePath, req.FileName, int64(len(req.FileData)))
+	})
+}
+
+// attach は画像を保存して添付を作成し、それまでの添付（existing）があれば画像ごと削除する
+func (i *TransferAttachmentInteractor) attach(ctx context.Context, req *inputport.AttachTransferImageRequest, existing *entities.TransferAttachment, newAttachment func(filePath string) *entities.TransferAttachment) (*entities.TransferAttachment, error) {
+	if len(req.FileData) == 0 {
+		return nil, entities.ErrInvalidAttachment
+	}
+
+	filePath, err := i.storage.SaveTransferAttachment(req.UserID.String(), req.FileName, bytes.NewReader(req.FileData), int64(len(req.FileData)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save attachment file: %w", err)
+	}
+
+	attachment := newAttachment(filePath)
+	if err := i.attachmentRepo.Create(ctx, attachment); err != nil {
+		// ファイルの削除を試みる
+		_ = i.storage.DeleteTransferAttachment(filePath)
+		return nil, fmt.Errorf("failed to save attachment: %w", err)
+	}
+
+	if existing != nil {
+		i.remove(ctx, existing)
+	}
+
+	i.logger.Info("Transfer attachment added",
+		entities.NewField("user_id", req.UserID),
+		entities.NewField("target_id", req.TargetID),
+		entities.NewField("attachment_id", attachment.ID))
+	return attachment, nil
+}
+
+// GetTransactionAttachment は送金の添付画像を取得
+func (i *TransferAttachmentInteractor) GetTransactionAttachment(ctx context.Context, req *inputport.GetTransferAttachmentRequest) (*inputport.GetTransferAttachmentResponse, error) {
+	tx, err := i.readTransaction(ctx, req.UserID, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	attachment, err := i.attachmentRepo.ReadByTransactionID(ctx, tx.ID)
+	if err != nil {
+		return nil, err
+	}
+	return i.open(attachment)
+}
+
+// GetTransferRequestAttachment は送金リクエストの添付画像を取得
+func (i *TransferAttachmentInteractor) GetTransferRequestAttachment(ctx context.Context, req *inputport.GetTransferAttachmentRequest) (*inputport.GetTransferAttachmentResponse, error) {
+	transferRequest, err := i.readTransferRequest(ctx, req.UserID, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	attachment, err := i.attachmentRepo.ReadByTransferRequestID(ctx, transferRequest.ID)
+	if err != nil {
+		return nil, err
+	}
+	return i.open(attachment)
+}
+
+// open は添付の画像を開く（添付がない・画像が削除済みならErrAttachmentNotFound）
+func (i *TransferAttachmentInteractor) open(attachment *entities.TransferAttachment) (*inputport.GetTransferAttachmentResponse, error) {
+	if attachment == nil {
+		return nil, entities.ErrAttachmentNotFound
+	}
+	content, err := i.storage.OpenTransferAttachment(attachment.FilePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, entities.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &inputport.GetTransferAttachmentResponse{Attachment: attachment, Content: content}, nil
+}
+
+// CleanupAttachments は添付した送金が保持期間より古い添付画像を削除する
+// 画像を削除できなかった添付は残し、次の実行で削除し直す
+func (i *TransferAttachmentInteractor) CleanupAttachments(ctx context.Context, now time.Time) (int64, error) {
+	if !i.retention.Enabled() {
+		return 0, nil
+	}
+	cutoff := i.retention.Cutoff(now)
+
+	var total int64
+	for {
+		attachments, err := i.attachmentRepo.ReadListBefore(ctx, cutoff, transferAttachmentCleanupBatchSize)
+		if err != nil {
+			return total, err
+		}
+		var deleted int
+		for _, a := range attachments {
+			if i.remove(ctx, a) {
+				deleted++
+			}
+		}
+		total += int64(deleted)
+		// 削除できなかった添付があれば同じ添付を取得し続けないよう、次の実行に回す
+		if len(attachments) < transferAttachmentCleanupBatchSize || deleted < len(attachments) {
+			break
+		}
+	}
+
+	if total > 0 {
+		i.logger.Info("Expired transfer attachments deleted",
+			entities.NewField("cutoff", cutoff),
+			entities.NewField("deleted", total))
+	}
+	return total, nil
+}
+
+// remove は添付の画像と記録を削除する（失敗はログに残し、削除できたかを返す）
+func (i *TransferAttachmentInteractor) remove(ctx context.Context, attachment *entities.TransferAttachment) bool {
+	if err := i.storage.DeleteTransferAttachment(attachment.FilePath); err != nil {
+		i.logger.Error("Failed to delete transfer attachment file",
+			entities.NewField("attachment_id", attachment.ID),
+			entities.NewField("error", err))
+		return false
+	}
+	if err := i.attachmentRepo.Delete(ctx, attachment.ID); err != nil {
+		i.logger.Error("Failed to delete transfer attachment",
+			entities.NewField("attachment_id", attachment.ID),
+			entities.NewField("error", err))
+		return false
+	}
+	return true
+}
+
+// readTransaction は当事者が見られる送金を取得
+func (i *TransferAttachmentInteractor) readTransaction(ctx context.Context, userID, transactionID uuid.UUID) (*entities.Transaction, error) {
+	tx, err := i.transactionRepo.Read(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Authorize(userID, entities.ActionView); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// readTransferRequest は当事者が見られる送金リクエストを取得
+func (i *TransferAttachmentInteractor) readTransferRequest(ctx context.Context, userID, requestID uuid.UUID) (*entities.TransferRequest, error) {
+	transferRequest, err := i.transferRequestRepo.Read(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if transferRequest == nil {
+		return nil, entities.ErrTransferRequestNotFound
+	}
+	if err := transferRequest.Authorize(userID, entities.ActionView); err != nil {
+		return nil, err
+	}
+	return transferRequest, nil
+}
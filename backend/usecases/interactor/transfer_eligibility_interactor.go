@@ -0,0 +1,165 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// TransferEligibilityInteractor は送金できるユーザーの条件のユースケース実装
+// 管理者による設定（TransferEligibilityInputPort）と送金前の確認（TransferEligibilityChecker）を兼ねる
+type TransferEligibilityInteractor struct {
+	settingsRepo   repository.SystemSettingsRepository
+	userRepo       repository.UserRepository
+	friendshipRepo repository.FriendshipRepository
+	logger         entities.Logger
+}
+
+// NewTransferEligibilityInteractor は新しいTransferEligibilityInteractorを作成
+func NewTransferEligibilityInteractor(
+	settingsRepo repository.SystemSettingsRepository,
+	userRepo repository.UserRepository,
+	friendshipRepo repository.FriendshipRepository,
+	logger entities.Logger,
+) *TransferEligibilityInteractor {
+	return &TransferEligibilityInteractor{
+		settingsRepo:   settingsRepo,
+		userRepo:       userRepo,
+		friendshipRepo: friendshipRepo,
+		logger:         logger,
+	}
+}
+
+// CheckSender は送信者が送金できるかを確認（条件が未設定ならユーザーを読まずに通す）
+// 新規登録ユーザーにメール認証を求める設定（EmailVerificationRequirement）もここで確認する
+func (i *TransferEligibilityInteractor) CheckSender(ctx context.Context, userID uuid.UUID) error {
+	policy, err := i.readPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	requirement, err := readEmailVerificationRequirement(ctx, i.settingsRepo)
+	if err != nil {
+		return err
+	}
+	if !policy.IsEnabled() && !requirement.IsEnabled() {
+		return nil
+	}
+
+	sender, err := i.userRepo.Read(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := requirement.CheckTransfer(sender); err != nil {
+		i.logger.Info("Transfer blocked until email is verified", entities.NewField("user_id", userID))
+		return err
+	}
+	if err := policy.Check(sender, time.Now()); err != nil {
+		i.logger.Info("Transfer blocked by eligibility policy",
+			entities.NewField("user_id", userID),
+			entities.NewField("error", err))
+		return err
+	}
+	return nil
+}
+
+// CheckRecipient は受取人が送信者からのポイントを受け取るかを確認（友達からだけ受け取る場合のみ友達かを調べる）
+func (i *TransferEligibilityInteractor) CheckRecipient(ctx context.Context, senderID, recipientID uuid.UUID) error {
+	recipient, err := i.userRepo.Read(ctx, recipientID)
+	if err != nil {
+		return err
+	}
+	acceptance, err := i.AcceptanceOf(ctx, recipient)
+	if err != nil {
+		return err
+	}
+	if acceptance != entities.TransferAcceptanceFriendsOnly {
+		return nil
+	}
+
+	friends, err := i.friendshipRepo.CheckAreFriends(ctx, senderID, recipientID)
+	if err != nil {
+		return fmt.Errorf("failed to check friendship: %w", err)
+	}
+	if !friends {
+		i.logger.Info("Transfer blocked because the recipient accepts points only from friends",
+			entities.NewField("from_user_id", senderID),
+			entities.NewField("to_user_id", recipientID))
+		return entities.ErrRecipientAcceptsFriendsOnly
+	}
+	return nil
+}
+
+// AcceptanceOf はユーザーが誰からのポイントを受け取るか（ユーザーの設定がなければシステム設定の既定）
+func (i *TransferEligibilityInteractor) AcceptanceOf(ctx context.Context, user *entities.User) (entities.TransferAcceptance, error) {
+	if user.AcceptTransfersFrom != "" {
+		return user.AcceptTransfersFrom, nil
+	}
+	policy, err := i.readPolicy(ctx)
+	if err != nil {
+		return "", err
+	}
+	return policy.AcceptanceOf(user), nil
+}
+
+// GetPolicy は送金できるユーザーの条件を取得
+func (i *TransferEligibilityInteractor) GetPolicy(ctx context.Context, adminID uuid.UUID) (*entities.TransferEligibilityPolicy, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	return i.readPolicy(ctx)
+}
+
+// UpdatePolicy は送金できるユーザーの条件を設定
+func (i *TransferEligibilityInteractor) UpdatePolicy(ctx context.Context, req *inputport.UpdateTransferEligibilityRequest) (*entities.TransferEligibilityPolicy, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	adminID := req.AdminID
+	policy := &entities.TransferEligibilityPolicy{
+		RequireEmailVerification: req.RequireEmailVerification,
+		MinAccountAgeHours:       req.MinAccountAgeHours,
+		DefaultAcceptance:        req.DefaultAcceptance,
+		UpdatedBy:                &adminID,
+		UpdatedAt:                time.Now(),
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transfer eligibility: %w", err)
+	}
+	if err := i.settingsRepo.SetSetting(ctx, entities.TransferEligibilitySettingKey, string(value), "送金できるユーザーの条件"); err != nil {
+		return nil, fmt.Errorf("failed to save transfer eligibility: %w", err)
+	}
+
+	i.logger.Info("Transfer eligibility updated",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("require_email_verification", req.RequireEmailVerification),
+		entities.NewField("min_account_age_hours", req.MinAccountAgeHours),
+		entities.NewField("default_accept_transfers_from", req.DefaultAcceptance))
+	return policy, nil
+}
+
+func (i *TransferEligibilityInteractor) readPolicy(ctx context.Context) (*entities.TransferEligibilityPolicy, error) {
+	value, err := i.settingsRepo.GetSetting(ctx, entities.TransferEligibilitySettingKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer eligibility: %w", err)
+	}
+
+	policy := &entities.TransferEligibilityPolicy{}
+	if value != "" {
+		if err := json.Unmarshal([]byte(value), policy); err != nil {
+			return nil, fmt.Errorf("failed to parse transfer eligibility: %w", err)
+		}
+	}
+	return policy, nil
+}
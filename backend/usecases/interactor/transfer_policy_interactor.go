@@ -0,0 +1,110 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// TransferPolicyInteractor は送金額の上下限と手数料のユースケース実装
+// 管理者による設定（TransferPolicyInputPort）と送金時の読み取り（TransferPolicyProvider）を兼ねる
+type TransferPolicyInteractor struct {
+	settingsRepo repository.SystemSettingsRepository
+	userRepo     repository.UserRepository
+	logger       entities.Logger
+}
+
+// NewTransferPolicyInteractor は新しいTransferPolicyInteractorを作成
+func NewTransferPolicyInteractor(
+	settingsRepo repository.SystemSettingsRepository,
+	userRepo repository.UserRepository,
+	logger entities.Logger,
+) *TransferPolicyInteractor {
+	return &TransferPolicyInteractor{
+		settingsRepo: settingsRepo,
+		userRepo:     userRepo,
+		logger:       logger,
+	}
+}
+
+// CurrentPolicy は現在の設定を取得（未設定なら上下限なし・手数料なし）
+func (i *TransferPolicyInteractor) CurrentPolicy(ctx context.Context) (*entities.TransferPolicy, error) {
+	value, err := i.settingsRepo.GetSetting(ctx, entities.TransferPolicySettingKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer policy: %w", err)
+	}
+
+	policy := &entities.TransferPolicy{FeeType: entities.TransferFeeNone}
+	if value != "" {
+		if err := json.Unmarshal([]byte(value), policy); err != nil {
+			return nil, fmt.Errorf("failed to parse transfer policy: %w", err)
+		}
+	}
+	return policy, nil
+}
+
+// GetPolicy は送金額の上下限と手数料を取得
+func (i *TransferPolicyInteractor) GetPolicy(ctx context.Context, adminID uuid.UUID) (*entities.TransferPolicy, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	return i.CurrentPolicy(ctx)
+}
+
+// UpdatePolicy は送金額の上下限と手数料を設定
+// 手数料の受け取り用アカウントは有効なユーザーでなければならない
+func (i *TransferPolicyInteractor) UpdatePolicy(ctx context.Context, req *inputport.UpdateTransferPolicyRequest) (*entities.TransferPolicy, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	adminID := req.AdminID
+	policy := &entities.TransferPolicy{
+		MinAmount:          req.MinAmount,
+		MaxAmount:          req.MaxAmount,
+		FeeType:            req.FeeType,
+		FeeFlat:            req.FeeFlat,
+		FeeRateBasisPoints: req.FeeRateBasisPoints,
+		FeeAccountID:       req.FeeAccountID,
+		UpdatedBy:          &adminID,
+		UpdatedAt:          time.Now(),
+	}
+	if policy.FeeType == "" {
+		policy.FeeType = entities.TransferFeeNone
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	if policy.FeeAccountID != nil {
+		account, err := i.userRepo.Read(ctx, *policy.FeeAccountID)
+		if err != nil {
+			return nil, err
+		}
+		if !account.IsActive {
+			return nil, entities.ErrUserInactive
+		}
+	}
+
+	value, err := json.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transfer policy: %w", err)
+	}
+	if err := i.settingsRepo.SetSetting(ctx, entities.TransferPolicySettingKey, string(value), "送金額の上下限と手数料"); err != nil {
+		return nil, fmt.Errorf("failed to save transfer policy: %w", err)
+	}
+
+	i.logger.Info("Transfer policy updated",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("min_amount", policy.MinAmount),
+		entities.NewField("max_amount", policy.MaxAmount),
+		entities.NewField("fee_type", policy.FeeType),
+		entities.NewField("fee_flat", policy.FeeFlat),
+		entities.NewField("fee_rate_basis_points", policy.FeeRateBasisPoints))
+	return policy, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
 )
 
 // TransferRequestInteractor は送金リクエスト機能のユースケース実装
@@ -15,6 +16,12 @@ type TransferRequestInteractor struct {
 	transferRequestRepo repository.TransferRequestRepository
 	userRepo            repository.UserRepository
 	pointTransferPort   inputport.PointTransferInputPort
+	contentModeration   inputport.ContentModerationInputPort
+	notifications       inputport.NotificationDispatcher
+	eligibility         inputport.TransferEligibilityChecker
+	quota               inputport.TransferRequestQuotaChecker
+	muteList            repository.MuteListRepository
+	holds               inputport.BalanceHolder
 	logger              entities.Logger
 }
 
@@ -23,12 +30,24 @@ func NewTransferRequestInteractor(
 	transferRequestRepo repository.TransferRequestRepository,
 	userRepo repository.UserRepository,
 	pointTransferPort inputport.PointTransferInputPort,
+	contentModeration inputport.ContentModerationInputPort,
+	notifications inputport.NotificationDispatcher,
+	eligibility inputport.TransferEligibilityChecker,
+	quota inputport.TransferRequestQuotaChecker,
+	muteList repository.MuteListRepository,
+	holds inputport.BalanceHolder,
 	logger entities.Logger,
 ) inputport.TransferRequestInputPort {
 	return &TransferRequestInteractor{
 		transferRequestRepo: transferRequestRepo,
 		userRepo:            userRepo,
 		pointTransferPort:   pointTransferPort,
+		contentModeration:   contentModeration,
+		notifications:       notifications,
+		eligibility:         eligibility,
+		quota:               quota,
+		muteList:            muteList,
+		holds:               holds,
 		logger:              logger,
 	}
 }
@@ -64,6 +83,9 @@ func (i *TransferRequestInteractor) CreateTransferRequest(ctx context.Context, r
 	if !fromUser.IsActive {
 		return nil, errors.New("sender is not active")
 	}
+	if err := i.eligibility.CheckSender(ctx, req.FromUserID); err != nil {
+		return nil, err
+	}
 
 	toUser, err := i.userRepo.Read(ctx, req.ToUserID)
 	if err != nil {
@@ -72,12 +94,31 @@ func (i *TransferRequestInteractor) CreateTransferRequest(ctx context.Context, r
 	if !toUser.IsActive {
 		return nil, errors.New("receiver is not active")
 	}
+	if err := i.eligibility.CheckRecipient(ctx, req.FromUserID, req.ToUserID); err != nil {
+		return nil, err
+	}
+
+	// 承認待ちのリクエストの上限（全体・同じ受取人宛）
+	if err := i.quota.CheckQuota(ctx, req.FromUserID, req.ToUserID); err != nil {
+		return nil, err
+	}
 
-	// 残高チェック
+	// 残高チェック（確保中の額を除いた使える残高で確かめる）
 	if err := fromUser.CanTransfer(req.Amount); err != nil {
 		return nil, fmt.Errorf("transfer validation failed: %w", err)
 	}
 
+	// メッセージのモデレーション
+	if err := i.contentModeration.CheckContent(ctx, req.FromUserID, entities.ModerationFieldTransferMessage, req.Message); err != nil {
+		return nil, err
+	}
+
+	// 受取人がミュートした送信者か（ほかの確認は同じように行い、ミュートされていることは送信者に明かさない）
+	mute, err := i.muteList.Read(ctx, req.ToUserID, req.FromUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check muted senders: %w", err)
+	}
+
 	// 送金リクエストエンティティを作成
 	transferRequest, err := entities.NewTransferRequest(
 		req.FromUserID,
@@ -89,14 +130,40 @@ func (i *TransferRequestInteractor) CreateTransferRequest(ctx context.Context, r
 	if err != nil {
 		return nil, fmt.Errorf("failed to create transfer request entity: %w", err)
 	}
+	if mute != nil && mute.Mode == entities.MuteModeReject {
+		if err := transferRequest.Reject(); err != nil {
+			return nil, fmt.Errorf("failed to reject transfer request: %w", err)
+		}
+	}
 
-	// DB保存
-	if err := i.transferRequestRepo.Create(ctx, transferRequest); err != nil {
-		return nil, fmt.Errorf("failed to save transfer request: %w", err)
+	// DB保存（確保するなら承認待ちのリクエストだけ、送信者の残高の確保と同じトランザクションで保存する）
+	save := func(ctx context.Context) error {
+		if err := i.transferRequestRepo.Create(ctx, transferRequest); err != nil {
+			return fmt.Errorf("failed to save transfer request: %w", err)
+		}
+		return nil
+	}
+	if req.Hold && transferRequest.IsPending() {
+		transferRequest.Hold = true
+		if err := i.holds.Hold(ctx, transferRequest, save); err != nil {
+			return nil, err
+		}
+	} else if err := save(ctx); err != nil {
+		return nil, err
 	}
 
-	i.logger.Info("Transfer request created successfully",
-		entities.NewField("request_id", transferRequest.ID))
+	if mute != nil {
+		// ミュートした送信者のリクエストは通知しない（dropなら承認待ちの一覧・件数にも出ず、そのまま期限切れになる）
+		i.logger.Info("Transfer request from a muted sender",
+			entities.NewField("request_id", transferRequest.ID),
+			entities.NewField("mode", mute.Mode))
+	} else {
+		i.logger.Info("Transfer request created successfully",
+			entities.NewField("request_id", transferRequest.ID))
+
+		// 受取人へ承認待ちを通知
+		i.notifications.Dispatch(ctx, entities.NewTransferRequestNotification(transferRequest, fromUser))
+	}
 
 	return &inputport.CreateTransferRequestResponse{
 		TransferRequest: transferRequest,
@@ -114,15 +181,15 @@ func (i *TransferRequestInteractor) ApproveTransferRequest(ctx context.Context,
 	// リクエストの取得
 	transferRequest, err := i.transferRequestRepo.Read(ctx, req.RequestID)
 	if err != nil {
-		return nil, errors.New("transfer request not found")
+		return nil, entities.ErrTransferRequestNotFound
 	}
 	if transferRequest == nil {
-		return nil, errors.New("transfer request not found")
+		return nil, entities.ErrTransferRequestNotFound
 	}
 
-	// 承認者が受取人であることを確認
-	if transferRequest.ToUserID != req.UserID {
-		return nil, errors.New("unauthorized to approve this request")
+	// 承認者が受取人（割り勘の1人分は支払う参加者）であることを確認
+	if err := transferRequest.Authorize(req.UserID, entities.ActionApprove); err != nil {
+		return nil, err
 	}
 
 	// 承認可能かチェック
@@ -131,12 +198,19 @@ func (i *TransferRequestInteractor) ApproveTransferRequest(ctx context.Context,
 	}
 
 	// ポイント送金を実行（ポイント転送機能内でトランザクションが管理される）
+	description := fmt.Sprintf("送金リクエスト承認: %s", transferRequest.Message)
+	if transferRequest.IsSplitShare() {
+		description = fmt.Sprintf("割り勘の支払い: %s", transferRequest.Message)
+	}
 	transferResp, err := i.pointTransferPort.Transfer(ctx, &inputport.TransferRequest{
 		FromUserID:     transferRequest.FromUserID,
 		ToUserID:       transferRequest.ToUserID,
 		Amount:         transferRequest.Amount,
 		IdempotencyKey: fmt.Sprintf("transfer-request-%s", transferRequest.ID.String()),
-		Description:    fmt.Sprintf("送金リクエスト承認: %s", transferRequest.Message),
+		Description:    description,
+		// 受取人の承認（割り勘は受取人が請求したもの）なので、受け取る相手の制限は確かめない
+		RecipientConsented: true,
+		HoldFor:            transferRequest.HoldRequestID(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute transfer: %w", err)
@@ -177,15 +251,15 @@ func (i *TransferRequestInteractor) RejectTransferRequest(ctx context.Context, r
 	// リクエストの取得
 	transferRequest, err := i.transferRequestRepo.Read(ctx, req.RequestID)
 	if err != nil {
-		return nil, errors.New("transfer request not found")
+		return nil, entities.ErrTransferRequestNotFound
 	}
 	if transferRequest == nil {
-		return nil, errors.New("transfer request not found")
+		return nil, entities.ErrTransferRequestNotFound
 	}
 
-	// 拒否者が受取人であることを確認
-	if transferRequest.ToUserID != req.UserID {
-		return nil, errors.New("unauthorized to reject this request")
+	// 拒否者が受取人（割り勘の1人分は支払う参加者）であることを確認
+	if err := transferRequest.Authorize(req.UserID, entities.ActionReject); err != nil {
+		return nil, err
 	}
 
 	// 拒否可能かチェック
@@ -206,11 +280,116 @@ func (i *TransferRequestInteractor) RejectTransferRequest(ctx context.Context, r
 	i.logger.Info("Transfer request rejected successfully",
 		entities.NewField("request_id", transferRequest.ID))
 
+	i.releaseHold(ctx, transferRequest)
+
 	return &inputport.RejectTransferRequestResponse{
 		TransferRequest: transferRequest,
 	}, nil
 }
 
+// CounterTransferRequest は金額を変更して送信者に差し戻す（受取人がカウンターオファー）
+func (i *TransferRequestInteractor) CounterTransferRequest(ctx context.Context, req *inputport.CounterTransferRequestRequest) (*inputport.CounterTransferRequestResponse, error) {
+	i.logger.Info("Countering transfer request",
+		entities.NewField("request_id", req.RequestID),
+		entities.NewField("user_id", req.UserID),
+		entities.NewField("counter_amount", req.Amount))
+
+	// リクエストの取得
+	transferRequest, err := i.transferRequestRepo.Read(ctx, req.RequestID)
+	if err != nil {
+		return nil, entities.ErrTransferRequestNotFound
+	}
+	if transferRequest == nil {
+		return nil, entities.ErrTransferRequestNotFound
+	}
+
+	// カウンターオファーできるのは受取人のみ
+	if err := transferRequest.Authorize(req.UserID, entities.ActionCounter); err != nil {
+		return nil, err
+	}
+
+	// 金額を変更して送信者の確認待ちにする
+	if err := transferRequest.Counter(req.Amount); err != nil {
+		return nil, fmt.Errorf("cannot counter request: %w", err)
+	}
+
+	// DB更新
+	if err := i.transferRequestRepo.Update(ctx, transferRequest); err != nil {
+		return nil, fmt.Errorf("failed to update transfer request: %w", err)
+	}
+
+	i.logger.Info("Transfer request countered successfully",
+		entities.NewField("request_id", transferRequest.ID))
+
+	return &inputport.CounterTransferRequestResponse{
+		TransferRequest: transferRequest,
+	}, nil
+}
+
+// ConfirmCounterOffer はカウンターオファーを確定して送金する（送信者が確認）
+func (i *TransferRequestInteractor) ConfirmCounterOffer(ctx context.Context, req *inputport.ConfirmCounterOfferRequest) (*inputport.ConfirmCounterOfferResponse, error) {
+	i.logger.Info("Confirming counter-offer",
+		entities.NewField("request_id", req.RequestID),
+		entities.NewField("user_id", req.UserID))
+
+	// リクエストの取得
+	transferRequest, err := i.transferRequestRepo.Read(ctx, req.RequestID)
+	if err != nil {
+		return nil, entities.ErrTransferRequestNotFound
+	}
+	if transferRequest == nil {
+		return nil, entities.ErrTransferRequestNotFound
+	}
+
+	// 確定できるのは送信者のみ
+	if err := transferRequest.Authorize(req.UserID, entities.ActionConfirm); err != nil {
+		return nil, err
+	}
+
+	// 確定可能かチェック
+	if err := transferRequest.CanConfirmCounter(); err != nil {
+		return nil, fmt.Errorf("cannot confirm counter-offer: %w", err)
+	}
+
+	// 変更後の金額でポイント送金を実行（リクエストごとに送金は1回のみのため冪等性キーは承認時と共通）
+	transferResp, err := i.pointTransferPort.Transfer(ctx, &inputport.TransferRequest{
+		FromUserID:     transferRequest.FromUserID,
+		ToUserID:       transferRequest.ToUserID,
+		Amount:         transferRequest.FinalAmount(),
+		IdempotencyKey: fmt.Sprintf("transfer-request-%s", transferRequest.ID.String()),
+		Description:    fmt.Sprintf("送金リクエスト承認（金額変更）: %s", transferRequest.Message),
+		// 金額を変えたのは受取人なので、受け取る相手の制限は確かめない
+		RecipientConsented: true,
+		HoldFor:            transferRequest.HoldRequestID(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute transfer: %w", err)
+	}
+
+	transaction := transferResp.Transaction
+
+	// リクエストを承認済みにマーク
+	if err := transferRequest.ConfirmCounter(transaction.ID); err != nil {
+		return nil, fmt.Errorf("failed to confirm counter-offer: %w", err)
+	}
+
+	// DB更新
+	if err := i.transferRequestRepo.Update(ctx, transferRequest); err != nil {
+		return nil, fmt.Errorf("failed to update transfer request: %w", err)
+	}
+
+	i.logger.Info("Counter-offer confirmed successfully",
+		entities.NewField("request_id", transferRequest.ID),
+		entities.NewField("transaction_id", transaction.ID))
+
+	return &inputport.ConfirmCounterOfferResponse{
+		TransferRequest: transferRequest,
+		Transaction:     transaction,
+		FromUser:        transferResp.FromUser,
+		ToUser:          transferResp.ToUser,
+	}, nil
+}
+
 // CancelTransferRequest は送金リクエストをキャンセル（送信者がキャンセル）
 func (i *TransferRequestInteractor) CancelTransferRequest(ctx context.Context, req *inputport.CancelTransferRequestRequest) (*inputport.CancelTransferRequestResponse, error) {
 	i.logger.Info("Canceling transfer request",
@@ -220,15 +399,15 @@ func (i *TransferRequestInteractor) CancelTransferRequest(ctx context.Context, r
 	// リクエストの取得
 	transferRequest, err := i.transferRequestRepo.Read(ctx, req.RequestID)
 	if err != nil {
-		return nil, errors.New("transfer request not found")
+		return nil, entities.ErrTransferRequestNotFound
 	}
 	if transferRequest == nil {
-		return nil, errors.New("transfer request not found")
+		return nil, entities.ErrTransferRequestNotFound
 	}
 
-	// キャンセル者が送信者であることを確認
-	if transferRequest.FromUserID != req.UserID {
-		return nil, errors.New("unauthorized to cancel this request")
+	// キャンセル者が送信者（割り勘の1人分は請求した作成者）であることを確認
+	if err := transferRequest.Authorize(req.UserID, entities.ActionCancel); err != nil {
+		return nil, err
 	}
 
 	// キャンセル可能かチェック
@@ -249,12 +428,27 @@ func (i *TransferRequestInteractor) CancelTransferRequest(ctx context.Context, r
 	i.logger.Info("Transfer request cancelled successfully",
 		entities.NewField("request_id", transferRequest.ID))
 
+	i.releaseHold(ctx, transferRequest)
+
 	return &inputport.CancelTransferRequestResponse{
 		TransferRequest: transferRequest,
 	}, nil
 }
 
-// GetPendingRequests は受取人宛の承認待ちリクエスト一覧を取得
+// releaseHold は承認待ちでなくなったリクエストのために確保した残高を戻す
+// 戻せなくてもリクエストの操作は失敗させない（期限切れにするジョブが改めて戻す）
+func (i *TransferRequestInteractor) releaseHold(ctx context.Context, transferRequest *entities.TransferRequest) {
+	if !transferRequest.Hold {
+		return
+	}
+	if err := i.holds.Release(ctx, transferRequest.ID); err != nil {
+		i.logger.Error("Failed to release balance hold",
+			entities.NewField("request_id", transferRequest.ID),
+			entities.NewField("error", err))
+	}
+}
+
+// GetPendingRequests は受取人宛の承認待ちリクエスト一覧を取得（自分が支払う割り勘の1人分を含む）
 func (i *TransferRequestInteractor) GetPendingRequests(ctx context.Context, req *inputport.GetPendingTransferRequestsRequest) (*inputport.GetPendingTransferRequestsResponse, error) {
 	results, err := i.transferRequestRepo.ReadPendingByToUserWithUsers(ctx, req.ToUserID, req.Offset, req.Limit)
 	if err != nil {
@@ -292,7 +486,7 @@ func (i *TransferRequestInteractor) GetSentRequests(ctx context.Context, req *in
 	infos := make([]*inputport.TransferRequestInfo, 0, len(results))
 	for _, r := range results {
 		// 期限切れチェック
-		if r.TransferRequest.IsPending() && r.TransferRequest.IsExpired() {
+		if r.TransferRequest.IsOpen() && r.TransferRequest.IsExpired() {
 			r.TransferRequest.MarkAsExpired()
 			i.transferRequestRepo.Update(ctx, r.TransferRequest)
 		}
@@ -313,15 +507,15 @@ func (i *TransferRequestInteractor) GetSentRequests(ctx context.Context, req *in
 func (i *TransferRequestInteractor) GetRequestDetail(ctx context.Context, req *inputport.GetTransferRequestDetailRequest) (*inputport.GetTransferRequestDetailResponse, error) {
 	transferRequest, err := i.transferRequestRepo.Read(ctx, req.RequestID)
 	if err != nil {
-		return nil, errors.New("transfer request not found")
+		return nil, entities.ErrTransferRequestNotFound
 	}
 	if transferRequest == nil {
-		return nil, errors.New("transfer request not found")
+		return nil, entities.ErrTransferRequestNotFound
 	}
 
 	// アクセス権限チェック（送信者または受取人のみ閲覧可能）
-	if transferRequest.FromUserID != req.UserID && transferRequest.ToUserID != req.UserID {
-		return nil, errors.New("unauthorized to view this request")
+	if err := transferRequest.Authorize(req.UserID, entities.ActionView); err != nil {
+		return nil, err
 	}
 
 	fromUser, err := i.userRepo.Read(ctx, transferRequest.FromUserID)
@@ -352,3 +546,55 @@ func (i *TransferRequestInteractor) GetPendingRequestCount(ctx context.Context,
 		Count: count,
 	}, nil
 }
+
+// MuteSender は送信者をミュート（既にミュートしていればmodeだけ変える）
+func (i *TransferRequestInteractor) MuteSender(ctx context.Context, req *inputport.MuteSenderRequest) (*entities.MutedSender, error) {
+	mute, err := entities.NewMutedSender(req.UserID, req.MutedUserID, req.Mode)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := i.userRepo.Read(ctx, req.MutedUserID); err != nil {
+		return nil, entities.ErrUserNotFound
+	}
+
+	if err := i.muteList.Upsert(ctx, mute); err != nil {
+		return nil, fmt.Errorf("failed to mute sender: %w", err)
+	}
+
+	i.logger.Info("Sender muted",
+		entities.NewField("user_id", req.UserID),
+		entities.NewField("muted_user_id", req.MutedUserID),
+		entities.NewField("mode", req.Mode))
+
+	return mute, nil
+}
+
+// UnmuteSender は送信者のミュートを解除（ミュート中に届いた承認待ちのリクエストは一覧に戻る）
+func (i *TransferRequestInteractor) UnmuteSender(ctx context.Context, userID, mutedUserID uuid.UUID) error {
+	if err := i.muteList.Delete(ctx, userID, mutedUserID); err != nil {
+		return fmt.Errorf("failed to unmute sender: %w", err)
+	}
+
+	i.logger.Info("Sender unmuted",
+		entities.NewField("user_id", userID),
+		entities.NewField("muted_user_id", mutedUserID))
+	return nil
+}
+
+// GetMutedSenders はミュートした送信者をユーザー情報付きでミュートした順に取得（退会などで読めないユーザーは除く）
+func (i *TransferRequestInteractor) GetMutedSenders(ctx context.Context, userID uuid.UUID) ([]*entities.MutedSenderWithUser, error) {
+	mutes, err := i.muteList.ReadListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read muted senders: %w", err)
+	}
+
+	results := make([]*entities.MutedSenderWithUser, 0, len(mutes))
+	for _, mute := range mutes {
+		user, err := i.userRepo.Read(ctx, mute.MutedUserID)
+		if err != nil {
+			continue
+		}
+		results = append(results, &entities.MutedSenderWithUser{MutedSender: mute, User: user})
+	}
+	return results, nil
+}
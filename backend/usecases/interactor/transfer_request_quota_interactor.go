@@ -0,0 +1,127 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// TransferRequestQuotaInteractor は承認待ちの送金リクエストの上限のユースケース実装
+// 管理者による設定（TransferRequestQuotaInputPort）とリクエスト作成前の確認（TransferRequestQuotaChecker）を兼ねる
+type TransferRequestQuotaInteractor struct {
+	settingsRepo        repository.SystemSettingsRepository
+	userRepo            repository.UserRepository
+	transferRequestRepo repository.TransferRequestRepository
+	logger              entities.Logger
+}
+
+// NewTransferRequestQuotaInteractor は新しいTransferRequestQuotaInteractorを作成
+func NewTransferRequestQuotaInteractor(
+	settingsRepo repository.SystemSettingsRepository,
+	userRepo repository.UserRepository,
+	transferRequestRepo repository.TransferRequestRepository,
+	logger entities.Logger,
+) *TransferRequestQuotaInteractor {
+	return &TransferRequestQuotaInteractor{
+		settingsRepo:        settingsRepo,
+		userRepo:            userRepo,
+		transferRequestRepo: transferRequestRepo,
+		logger:              logger,
+	}
+}
+
+// CheckQuota は送信者の承認待ちのリクエストが上限に達していないかを確認（上限が無効なら数えずに通す）
+// 有効期限を過ぎたものは数えないため、期限切れにするワーカーが動く前でも送信者の枠は空く
+func (i *TransferRequestQuotaInteractor) CheckQuota(ctx context.Context, fromUserID, toUserID uuid.UUID) error {
+	quota, err := i.readQuota(ctx)
+	if err != nil {
+		return err
+	}
+	if !quota.IsEnabled() {
+		return nil
+	}
+
+	var pending, pendingToRecipient int64
+	if quota.MaxPendingPerSender > 0 {
+		if pending, err = i.transferRequestRepo.CountPendingByFromUser(ctx, fromUserID); err != nil {
+			return fmt.Errorf("failed to count pending transfer requests: %w", err)
+		}
+	}
+	if quota.MaxPendingPerRecipient > 0 {
+		if pendingToRecipient, err = i.transferRequestRepo.CountPendingByFromUserTo(ctx, fromUserID, toUserID); err != nil {
+			return fmt.Errorf("failed to count pending transfer requests: %w", err)
+		}
+	}
+	if err := quota.Check(pending, pendingToRecipient); err != nil {
+		i.logger.Info("Transfer request blocked by pending request quota",
+			entities.NewField("from_user_id", fromUserID),
+			entities.NewField("to_user_id", toUserID),
+			entities.NewField("pending", pending),
+			entities.NewField("pending_to_recipient", pendingToRecipient))
+		return err
+	}
+	return nil
+}
+
+// GetQuota は承認待ちの送金リクエストの上限を取得
+func (i *TransferRequestQuotaInteractor) GetQuota(ctx context.Context, adminID uuid.UUID) (*entities.TransferRequestQuota, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	return i.readQuota(ctx)
+}
+
+// UpdateQuota は承認待ちの送金リクエストの上限を設定（上限を下げても出ているリクエストはそのまま）
+func (i *TransferRequestQuotaInteractor) UpdateQuota(ctx context.Context, req *inputport.UpdateTransferRequestQuotaRequest) (*entities.TransferRequestQuota, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	adminID := req.AdminID
+	quota := &entities.TransferRequestQuota{
+		MaxPendingPerSender:    req.MaxPendingPerSender,
+		MaxPendingPerRecipient: req.MaxPendingPerRecipient,
+		UpdatedBy:              &adminID,
+		UpdatedAt:              time.Now(),
+	}
+	if err := quota.Validate(); err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(quota)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transfer request quota: %w", err)
+	}
+	if err := i.settingsRepo.SetSetting(ctx, entities.TransferRequestQuotaSettingKey, string(value), "承認待ちの送金リクエストの上限"); err != nil {
+		return nil, fmt.Errorf("failed to save transfer request quota: %w", err)
+	}
+
+	i.logger.Info("Transfer request quota updated",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("max_pending_per_sender", req.MaxPendingPerSender),
+		entities.NewField("max_pending_per_recipient", req.MaxPendingPerRecipient))
+	return quota, nil
+}
+
+// readQuota は保存された上限を読む（未設定なら既定の上限）
+func (i *TransferRequestQuotaInteractor) readQuota(ctx context.Context) (*entities.TransferRequestQuota, error) {
+	value, err := i.settingsRepo.GetSetting(ctx, entities.TransferRequestQuotaSettingKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer request quota: %w", err)
+	}
+
+	quota := entities.DefaultTransferRequestQuota()
+	if value != "" {
+		quota = &entities.TransferRequestQuota{}
+		if err := json.Unmarshal([]byte(value), quota); err != nil {
+			return nil, fmt.Errorf("failed to parse transfer request quota: %w", err)
+		}
+	}
+	return quota, nil
+}
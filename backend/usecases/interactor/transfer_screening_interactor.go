@@ -0,0 +1,102 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+// TransferScreeningInteractor は送金前の不審な送金の検出の実装
+type TransferScreeningInteractor struct {
+	activityRepo  repository.SuspiciousActivityRepository
+	userRepo      repository.UserRepository
+	settingsRepo  repository.SystemSettingsRepository
+	notifications inputport.NotificationDispatcher
+	logger        entities.Logger
+}
+
+// NewTransferScreeningInteractor は新しいTransferScreeningInteractorを作成
+func NewTransferScreeningInteractor(
+	activityRepo repository.SuspiciousActivityRepository,
+	userRepo repository.UserRepository,
+	settingsRepo repository.SystemSettingsRepository,
+	notifications inputport.NotificationDispatcher,
+	logger entities.Logger,
+) inputport.TransferScreener {
+	return &TransferScreeningInteractor{
+		activityRepo:  activityRepo,
+		userRepo:      userRepo,
+		settingsRepo:  settingsRepo,
+		notifications: notifications,
+		logger:        logger,
+	}
+}
+
+// ScreenTransfer は送金が不審かを判定し、不審なら保存前の記録を返す
+func (i *TransferScreeningInteractor) ScreenTransfer(ctx context.Context, req *inputport.TransferRequest, now time.Time) *entities.SuspiciousActivity {
+	recipient, err := i.userRepo.Read(ctx, req.ToUserID)
+	if err != nil {
+		// 受取人がいなければ送金自体が失敗する
+		return nil
+	}
+	velocity, err := i.activityRepo.ReadTransferVelocity(ctx, entities.NewTransferVelocityQuery(req.FromUserID, req.ToUserID, now))
+	if err != nil {
+		i.logger.Warn("Failed to screen transfer",
+			entities.NewField("from_user_id", req.FromUserID),
+			entities.NewField("to_user_id", req.ToUserID),
+			entities.NewField("error", err))
+		return nil
+	}
+
+	reasons := entities.DetectSuspiciousTransfer(req.Amount, recipient, velocity, now)
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	hold := false
+	value, err := i.settingsRepo.GetSetting(ctx, entities.TransferHoldSettingKey)
+	if err != nil {
+		i.logger.Warn("Failed to get transfer hold setting", entities.NewField("error", err))
+	} else {
+		hold = entities.ParseTransferHoldEnabled(value)
+	}
+
+	i.logger.Warn("Suspicious transfer detected",
+		entities.NewField("from_user_id", req.FromUserID),
+		entities.NewField("to_user_id", req.ToUserID),
+		entities.NewField("amount", req.Amount),
+		entities.NewField("reasons", reasons),
+		entities.NewField("hold", hold))
+	return entities.NewSuspiciousActivity(req.FromUserID, req.ToUserID, req.Amount, req.Description, req.IdempotencyKey, reasons, hold, now)
+}
+
+// RecordSuspiciousTransfer は記録を保存し、有効な管理者全員へ通知する
+func (i *TransferScreeningInteractor) RecordSuspiciousTransfer(ctx context.Context, activity *entities.SuspiciousActivity) error {
+	if err := i.activityRepo.Create(ctx, activity); err != nil {
+		return fmt.Errorf("failed to record suspicious activity: %w", err)
+	}
+
+	recipients, err := i.activityRepo.ReadAlertRecipients(ctx)
+	if err != nil {
+		i.logger.Warn("Failed to read suspicious activity alert recipients", entities.NewField("error", err))
+		return nil
+	}
+	from, err := i.userRepo.Read(ctx, activity.FromUserID)
+	if err != nil {
+		i.logger.Warn("Failed to read sender for suspicious activity alert", entities.NewField("error", err))
+		return nil
+	}
+	to, err := i.userRepo.Read(ctx, activity.ToUserID)
+	if err != nil {
+		i.logger.Warn("Failed to read recipient for suspicious activity alert", entities.NewField("error", err))
+		return nil
+	}
+	for _, adminID := range recipients {
+		i.notifications.Dispatch(ctx, entities.NewSuspiciousTransferNotification(adminID, activity, from, to))
+	}
+	return nil
+}
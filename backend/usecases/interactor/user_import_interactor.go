@@ -0,0 +1,285 @@
+package interactor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+)
+
+// UserImportInteractor はユーザー一括登録のユースケース実装
+type UserImportInteractor struct {
+	txManager       repository.TransactionManager
+	userRepo        repository.UserRepository
+	transactionRepo repository.TransactionRepository
+	pointBatchRepo  repository.PointBatchRepository
+	passwordService service.PasswordService
+	emailService    service.EmailService
+	logger          entities.Logger
+}
+
+// NewUserImportInteractor は新しいUserImportInteractorを作成
+func NewUserImportInteractor(
+	txManager repository.TransactionManager,
+	userRepo repository.UserRepository,
+	transactionRepo repository.TransactionRepository,
+	pointBatchRepo repository.PointBatchRepository,
+	passwordService service.PasswordService,
+	emailService service.EmailService,
+	logger entities.Logger,
+) inputport.UserImportInputPort {
+	return &UserImportInteractor{
+		txManager:       txManager,
+		userRepo:        userRepo,
+		transactionRepo: transactionRepo,
+		pointBatchRepo:  pointBatchRepo,
+		passwordService: passwordService,
+		emailService:    emailService,
+		logger:          logger,
+	}
+}
+
+// importCandidate は登録処理中の1行分の状態
+type importCandidate struct {
+	row      *entities.UserImportRow
+	result   *inputport.UserImportResult
+	password string
+	user     *entities.User
+}
+
+// ImportUsers はCSVからユーザーを一括登録する
+// UserImportChunkSize行ずつトランザクションで登録し、まとまりの途中で失敗した場合はそのまとまりだけをやり直し対象にする
+func (i *UserImportInteractor) ImportUsers(ctx context.Context, req *inputport.ImportUsersRequest) (*inputport.ImportUsersResponse, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	rows, err := entities.ParseUserImportCSV(bytes.NewReader(req.CSVData))
+	if err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Importing users",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("rows", len(rows)))
+
+	results := make([]*inputport.UserImportResult, 0, len(rows))
+	var candidates []*importCandidate
+	seenUsernames := make(map[string]bool, len(rows))
+	seenEmails := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		result := &inputport.UserImportResult{
+			Line:          row.Line,
+			Username:      row.Username,
+			Email:         row.Email,
+			InitialPoints: row.InitialPoints,
+		}
+		results = append(results, result)
+
+		if row.Err != nil {
+			result.Err = row.Err
+			continue
+		}
+		// ファイル内の重複は後の行をエラーにする
+		if seenUsernames[row.Username] {
+			result.Err = entities.ErrUsernameAlreadyExists
+			continue
+		}
+		if seenEmails[strings.ToLower(row.Email)] {
+			result.Err = entities.ErrEmailAlreadyExists
+			continue
+		}
+		seenUsernames[row.Username] = true
+		seenEmails[strings.ToLower(row.Email)] = true
+
+		if err := i.checkNotRegistered(ctx, row); err != nil {
+			result.Err = err
+			continue
+		}
+		candidates = append(candidates, &importCandidate{row: row, result: result})
+	}
+
+	for start := 0; start < len(candidates); start += entities.UserImportChunkSize {
+		end := start + entities.UserImportChunkSize
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+		i.importChunk(ctx, req.AdminID, candidates[start:end])
+	}
+
+	if req.SendInvitations {
+		for _, c := range candidates {
+			if c.result.Err != nil {
+				continue
+			}
+			if err := i.emailService.SendUserInvitation(c.row.Email, c.row.Username, c.password); err != nil {
+				i.logger.Error("Failed to send user invitation",
+					entities.NewField("user_id", c.user.ID),
+					entities.NewField("error", err))
+				continue
+			}
+			c.result.InvitationSent = true
+		}
+	}
+
+	resp := &inputport.ImportUsersResponse{Results: results}
+	for _, c := range candidates {
+		// 招待メールで届けられなかった仮パスワードだけを管理者に返す
+		if c.result.Err == nil && !c.result.InvitationSent {
+			c.result.TemporaryPassword = c.password
+		}
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			resp.FailedCount++
+		} else {
+			resp.CreatedCount++
+		}
+	}
+
+	i.logger.Info("Users imported",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("created", resp.CreatedCount),
+		entities.NewField("failed", resp.FailedCount))
+
+	return resp, nil
+}
+
+// checkNotRegistered はユーザー名・メールアドレスが登録済みでないかを確認
+func (i *UserImportInteractor) checkNotRegistered(ctx context.Context, row *entities.UserImportRow) error {
+	if _, err := i.userRepo.ReadByUsername(ctx, row.Username); err == nil {
+		return entities.ErrUsernameAlreadyExists
+	} else if !errors.Is(err, entities.ErrUserNotFound) {
+		return err
+	}
+	if _, err := i.userRepo.ReadByEmail(ctx, row.Email); err == nil {
+		return entities.ErrEmailAlreadyExists
+	} else if !errors.Is(err, entities.ErrUserNotFound) {
+		return err
+	}
+	return nil
+}
+
+// importChunk はまとまり1つ分のユーザーを1トランザクションで登録する
+// 失敗した行にはその原因を、同じまとまりの他の行にはErrUserImportChunkFailedを設定する
+func (i *UserImportInteractor) importChunk(ctx context.Context, adminID uuid.UUID, chunk []*importCandidate) {
+	// bcryptは遅いのでトランザクションの外で並列にハッシュ化する
+	if err := i.preparePasswords(chunk); err != nil {
+		for _, c := range chunk {
+			c.result.Err = err
+		}
+		return
+	}
+
+	var failed *importCandidate
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		for _, c := range chunk {
+			if err := i.createUser(ctx, adminID, c); err != nil {
+				failed = c
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		i.logger.Error("Failed to import user chunk",
+			entities.NewField("first_line", chunk[0].row.Line),
+			entities.NewField("error", err))
+		for _, c := range chunk {
+			c.result.UserID = nil
+			c.result.Err = entities.ErrUserImportChunkFailed
+		}
+		if failed != nil {
+			failed.result.Err = err
+		}
+		return
+	}
+
+	for _, c := range chunk {
+		id := c.user.ID
+		c.result.UserID = &id
+	}
+}
+
+// preparePasswords は各行の仮パスワードを生成してハッシュ化したユーザーを用意する
+func (i *UserImportInteractor) preparePasswords(chunk []*importCandidate) error {
+	errs := make([]error, len(chunk))
+	sem := make(chan struct{}, runtime.NumCPU())
+	var wg sync.WaitGroup
+	for idx, c := range chunk {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(idx int, c *importCandidate) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			password, err := entities.GenerateTemporaryPassword()
+			if err != nil {
+				errs[idx] = err
+				return
+			}
+			hash, err := i.passwordService.HashPassword(password)
+			if err != nil {
+				errs[idx] = err
+				return
+			}
+			user, err := c.row.NewUser(hash)
+			if err != nil {
+				errs[idx] = err
+				return
+			}
+			c.password = password
+			c.user = user
+		}(idx, c)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return fmt.Errorf("failed to prepare password: %w", err)
+		}
+	}
+	return nil
+}
+
+// createUser はユーザーを作成し、初期ポイントがあれば管理者付与として記録する
+func (i *UserImportInteractor) createUser(ctx context.Context, adminID uuid.UUID, c *importCandidate) error {
+	if err := i.userRepo.Create(ctx, c.user); err != nil {
+		return err
+	}
+	if c.row.InitialPoints == 0 {
+		return nil
+	}
+
+	if err := i.userRepo.UpdateBalanceWithLock(ctx, c.user.ID, c.row.InitialPoints, false); err != nil {
+		return err
+	}
+	c.user.Balance += c.row.InitialPoints
+
+	transaction, err := entities.NewAdminGrant(c.user.ID, c.row.InitialPoints, "Admin grant: initial points (user import)", adminID)
+	if err != nil {
+		return err
+	}
+	if err := transaction.SetMemo("", entities.UserImportPointsTag); err != nil {
+		return err
+	}
+	if err := i.transactionRepo.Create(ctx, transaction); err != nil {
+		return err
+	}
+
+	batch := entities.NewPointBatch(c.user.ID, c.row.InitialPoints, entities.PointBatchSourceAdminGrant, &transaction.ID, time.Now())
+	if err := i.pointBatchRepo.Create(ctx, batch); err != nil {
+		return fmt.Errorf("failed to create point batch: %w", err)
+	}
+	return nil
+}
@@ -11,18 +11,21 @@ import (
 
 // UserQueryInteractor はユーザー情報検索のユースケース実装
 type UserQueryInteractor struct {
-	userRepo repository.UserRepository
-	logger   entities.Logger
+	userRepo    repository.UserRepository
+	eligibility inputport.TransferEligibilityChecker
+	logger      entities.Logger
 }
 
 // NewUserQueryInteractor は新しいUserQueryInteractorを作成
 func NewUserQueryInteractor(
 	userRepo repository.UserRepository,
+	eligibility inputport.TransferEligibilityChecker,
 	logger entities.Logger,
 ) inputport.UserQueryInputPort {
 	return &UserQueryInteractor{
-		userRepo: userRepo,
-		logger:   logger,
+		userRepo:    userRepo,
+		eligibility: eligibility,
+		logger:      logger,
 	}
 }
 
@@ -32,9 +35,14 @@ func (i *UserQueryInteractor) GetUserByID(ctx context.Context, req *inputport.Ge
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
+	acceptance, err := i.eligibility.AcceptanceOf(ctx, user)
+	if err != nil {
+		return nil, err
+	}
 
 	return &inputport.GetUserByIDResponse{
-		User: user,
+		User:                 user,
+		AcceptsTransfersFrom: acceptance,
 	}, nil
 }
 
@@ -44,8 +52,17 @@ func (i *UserQueryInteractor) SearchUserByUsername(ctx context.Context, req *inp
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
+	// 休止中のユーザーは検索に出さない
+	if user.IsDeactivated() {
+		return nil, entities.ErrUserNotFound
+	}
+	acceptance, err := i.eligibility.AcceptanceOf(ctx, user)
+	if err != nil {
+		return nil, err
+	}
 
 	return &inputport.SearchUserByUsernameResponse{
-		User: user,
+		User:                 user,
+		AcceptsTransfersFrom: acceptance,
 	}, nil
 }
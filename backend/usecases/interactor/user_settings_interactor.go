@@ -5,11 +5,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/gity/point-system/usecases/inputport"
 	"github.com/gity/point-system/usecases/repository"
 	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
 )
 
 // UserSettingsInteractor はユーザー設定のユースケース実装
@@ -21,9 +23,13 @@ type UserSettingsInteractor struct {
 	emailVerificationRepo     repository.EmailVerificationRepository
 	usernameChangeHistoryRepo repository.UsernameChangeHistoryRepository
 	passwordChangeHistoryRepo repository.PasswordChangeHistoryRepository
+	transactionRepo           repository.TransactionRepository
+	systemSettingsRepo        repository.SystemSettingsRepository
 	fileStorageService        service.FileStorageService
 	passwordService           service.PasswordService
 	emailService              service.EmailService
+	contentModeration         inputport.ContentModerationInputPort
+	earningEvents             inputport.EarningEventRecorder
 	logger                    entities.Logger
 }
 
@@ -36,9 +42,13 @@ func NewUserSettingsInteractor(
 	emailVerificationRepo repository.EmailVerificationRepository,
 	usernameChangeHistoryRepo repository.UsernameChangeHistoryRepository,
 	passwordChangeHistoryRepo repository.PasswordChangeHistoryRepository,
+	transactionRepo repository.TransactionRepository,
+	systemSettingsRepo repository.SystemSettingsRepository,
 	fileStorageService service.FileStorageService,
 	passwordService service.PasswordService,
 	emailService service.EmailService,
+	contentModeration inputport.ContentModerationInputPort,
+	earningEvents inputport.EarningEventRecorder,
 	logger entities.Logger,
 ) inputport.UserSettingsInputPort {
 	return &UserSettingsInteractor{
@@ -49,9 +59,13 @@ func NewUserSettingsInteractor(
 		emailVerificationRepo:     emailVerificationRepo,
 		usernameChangeHistoryRepo: usernameChangeHistoryRepo,
 		passwordChangeHistoryRepo: passwordChangeHistoryRepo,
+		transactionRepo:           transactionRepo,
+		systemSettingsRepo:        systemSettingsRepo,
 		fileStorageService:        fileStorageService,
 		passwordService:           passwordService,
 		emailService:              emailService,
+		contentModeration:         contentModeration,
+		earningEvents:             earningEvents,
 		logger:                    logger,
 	}
 }
@@ -75,13 +89,30 @@ func (i *UserSettingsInteractor) UpdateProfile(ctx context.Context, req *inputpo
 			return nil, fmt.Errorf("failed to check email existence: %w", err)
 		}
 		if exists {
-			return nil, errors.New("email already exists")
+			return nil, entities.ErrEmailAlreadyExists
 		}
 		emailChanged = true
 	}
 
+	// 変更された表示名・氏名のモデレーション
+	var targets []moderationTarget
+	if req.DisplayName != user.DisplayName {
+		targets = append(targets, moderationTarget{field: entities.ModerationFieldDisplayName, text: req.DisplayName})
+	}
+	if req.FirstName != user.FirstName {
+		targets = append(targets, moderationTarget{field: entities.ModerationFieldFirstName, text: req.FirstName})
+	}
+	if req.LastName != user.LastName {
+		targets = append(targets, moderationTarget{field: entities.ModerationFieldLastName, text: req.LastName})
+	}
+	if err := checkContents(ctx, i.contentModeration, user.ID, targets...); err != nil {
+		return nil, err
+	}
+
 	// プロフィールを更新
-	if err := user.UpdateProfile(req.DisplayName, req.Email, req.FirstName, req.LastName); err != nil {
+	// メールアドレスは新旧両方のアドレスで確認が取れるまで変更しない（VerifyEmailで適用）
+	oldEmail := user.Email
+	if err := user.UpdateProfile(req.DisplayName, "", req.FirstName, req.LastName); err != nil {
 		return nil, fmt.Errorf("failed to update profile: %w", err)
 	}
 
@@ -94,40 +125,56 @@ func (i *UserSettingsInteractor) UpdateProfile(ctx context.Context, req *inputpo
 		return nil, errors.New("profile update failed due to version conflict")
 	}
 
-	// メールアドレスが変更された場合は認証メールを送信
+	// メールアドレスが変更された場合は新アドレスへ認証メール、旧アドレスへ確認メールを送信
 	emailVerificationSent := false
+	pendingEmail := ""
 	if emailChanged {
-		// 古いトークンを削除
-		_ = i.emailVerificationRepo.DeleteByUserID(ctx, user.ID)
-
-		// 新しいトークンを作成
-		token, err := entities.NewEmailVerificationToken(&user.ID, req.Email, entities.TokenTypeEmailChange)
-		if err != nil {
-			i.logger.Error("Failed to create email verification token", entities.NewField("error", err))
-		} else {
-			if err := i.emailVerificationRepo.Create(ctx, token); err != nil {
-				i.logger.Error("Failed to save email verification token", entities.NewField("error", err))
-			} else {
-				// メール送信
-				if err := i.emailService.SendVerificationEmail(req.Email, token.Token); err != nil {
-					i.logger.Error("Failed to send verification email", entities.NewField("error", err))
-				} else {
-					emailVerificationSent = true
-				}
-			}
+		emailVerificationSent = i.startEmailChange(ctx, user.ID, oldEmail, req.Email)
+		if emailVerificationSent {
+			pendingEmail = req.Email
 		}
 	}
 
 	i.logger.Info("Profile updated successfully",
 		entities.NewField("user_id", user.ID),
 		entities.NewField("email_changed", emailChanged))
+	i.recordProfileCompleted(ctx, user)
 
 	return &inputport.UpdateProfileResponse{
 		User:                  user,
 		EmailVerificationSent: emailVerificationSent,
+		PendingEmail:          pendingEmail,
 	}, nil
 }
 
+// startEmailChange はメールアドレス変更の二段階確認を開始し、新アドレスへ送信できたかを返す
+func (i *UserSettingsInteractor) startEmailChange(ctx context.Context, userID uuid.UUID, oldEmail, newEmail string) bool {
+	// 古いトークンを削除
+	_ = i.emailVerificationRepo.DeleteByUserID(ctx, userID)
+
+	token, err := entities.NewEmailChangeToken(userID, oldEmail, newEmail)
+	if err != nil {
+		i.logger.Error("Failed to create email verification token", entities.NewField("error", err))
+		return false
+	}
+	if err := i.emailVerificationRepo.Create(ctx, token); err != nil {
+		i.logger.Error("Failed to save email verification token", entities.NewField("error", err))
+		return false
+	}
+
+	if err := i.emailService.SendVerificationEmail(newEmail, token.Token); err != nil {
+		i.logger.Error("Failed to send verification email", entities.NewField("error", err))
+		return false
+	}
+
+	// 旧アドレスへの確認メールは失敗しても、タイムアウト後に新アドレスの認証のみで適用される
+	if err := i.emailService.SendEmailChangeConfirmation(oldEmail, newEmail, *token.OldEmailToken); err != nil {
+		i.logger.Error("Failed to send email change confirmation", entities.NewField("error", err))
+	}
+
+	return true
+}
+
 // UpdateUsername はユーザー名を変更
 func (i *UserSettingsInteractor) UpdateUsername(ctx context.Context, req *inputport.UpdateUsernameRequest) error {
 	i.logger.Info("Updating username", entities.NewField("user_id", req.UserID))
@@ -146,7 +193,7 @@ func (i *UserSettingsInteractor) UpdateUsername(ctx context.Context, req *inputp
 		return fmt.Errorf("failed to check username existence: %w", err)
 	}
 	if exists {
-		return errors.New("username already exists")
+		return entities.ErrUsernameAlreadyExists
 	}
 
 	// ユーザー名を更新
@@ -290,12 +337,21 @@ func (i *UserSettingsInteractor) UploadAvatar(ctx context.Context, req *inputpor
 	i.logger.Info("Avatar uploaded successfully",
 		entities.NewField("user_id", user.ID),
 		entities.NewField("avatar_url", avatarURL))
+	i.recordProfileCompleted(ctx, user)
 
 	return &inputport.UploadAvatarResponse{
 		AvatarURL: avatarURL,
 	}, nil
 }
 
+// recordProfileCompleted はプロフィールがそろっていればポイント獲得ルールに伝える
+// 発生元はユーザーなので、そろえ直しても同じルールで二度は付与しない
+func (i *UserSettingsInteractor) recordProfileCompleted(ctx context.Context, user *entities.User) {
+	if user.ProfileCompleted() {
+		i.earningEvents.Record(ctx, entities.NewEarningEvent(entities.EarningTriggerProfileCompleted, user.ID, 0, user.ID.String()))
+	}
+}
+
 // DeleteAvatar はアバターを削除（自動生成に戻す）
 func (i *UserSettingsInteractor) DeleteAvatar(ctx context.Context, req *inputport.DeleteAvatarRequest) error {
 	i.logger.Info("Deleting avatar", entities.NewField("user_id", req.UserID))
@@ -370,25 +426,45 @@ func (i *UserSettingsInteractor) SendEmailVerification(ctx context.Context, req
 func (i *UserSettingsInteractor) VerifyEmail(ctx context.Context, req *inputport.VerifyEmailRequest) (*inputport.VerifyEmailResponse, error) {
 	i.logger.Info("Verifying email", entities.NewField("token", req.Token[:10]+"..."))
 
-	// トークンを取得
+	now := time.Now()
+
+	// トークンを取得（見つからない場合は旧アドレス確認用トークンとして検索）
+	isOldEmailToken := false
 	token, err := i.emailVerificationRepo.ReadByToken(ctx, req.Token)
 	if err != nil {
-		return nil, errors.New("invalid or expired token")
+		token, err = i.emailVerificationRepo.ReadByOldEmailToken(ctx, req.Token)
+		if err != nil {
+			return nil, errors.New("invalid or expired token")
+		}
+		isOldEmailToken = true
 	}
 
-	// トークンの有効期限をチェック
-	if token.IsExpired() {
-		return nil, errors.New("token has expired")
+	if token.IsApplied() {
+		return nil, entities.ErrTokenAlreadyUsed
 	}
 
-	// 既に検証済みかチェック
-	if token.IsVerified() {
-		return nil, errors.New("token has already been used")
+	// トークンの有効期限をチェック
+	if token.IsExpired() {
+		return nil, entities.ErrTokenExpired
 	}
 
-	// トークンを検証済みにする
-	if err := token.Verify(); err != nil {
-		return nil, fmt.Errorf("failed to verify token: %w", err)
+	switch {
+	case isOldEmailToken:
+		if token.IsOldEmailConfirmed() {
+			return nil, entities.ErrTokenAlreadyUsed
+		}
+		if err := token.ConfirmOldEmail(now); err != nil {
+			return nil, fmt.Errorf("failed to verify token: %w", err)
+		}
+	case token.IsVerified():
+		// 二段階確認で旧アドレス待ちの場合は、タイムアウト後の再アクセスで適用できるよう許可
+		if !token.RequiresOldEmailConfirmation() {
+			return nil, entities.ErrTokenAlreadyUsed
+		}
+	default:
+		if err := token.Verify(); err != nil {
+			return nil, fmt.Errorf("failed to verify token: %w", err)
+		}
 	}
 
 	// トークンを更新
@@ -396,30 +472,60 @@ func (i *UserSettingsInteractor) VerifyEmail(ctx context.Context, req *inputport
 		return nil, fmt.Errorf("failed to update token: %w", err)
 	}
 
-	var user *entities.User
+	// 登録時の認証
+	if token.UserID == nil {
+		i.logger.Info("Email verified successfully", entities.NewField("email", token.Email))
+		return &inputport.VerifyEmailResponse{Email: token.Email}, nil
+	}
 
-	// ユーザーIDがある場合（メール変更）
-	if token.UserID != nil {
-		user, err = i.userRepo.Read(ctx, *token.UserID)
-		if err != nil {
-			return nil, fmt.Errorf("user not found: %w", err)
-		}
+	user, err := i.userRepo.Read(ctx, *token.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
 
-		// メールアドレスを更新して認証
-		if err := user.UpdateProfile("", token.Email, "", ""); err != nil {
-			return nil, fmt.Errorf("failed to update email: %w", err)
-		}
+	// 新旧いずれかの確認待ち
+	if !token.CanApply(now) {
+		i.logger.Info("Email change awaiting confirmation",
+			entities.NewField("user_id", user.ID),
+			entities.NewField("new_email_verified", token.IsVerified()),
+			entities.NewField("old_email_confirmed", token.IsOldEmailConfirmed()))
 
-		user.VerifyEmail()
+		return &inputport.VerifyEmailResponse{
+			User:             user,
+			Email:            token.Email,
+			AwaitingNewEmail: !token.IsVerified(),
+			AwaitingOldEmail: token.IsVerified() && !token.IsOldEmailConfirmed(),
+		}, nil
+	}
 
-		// データベースに保存
-		success, err := i.userSettingsRepo.UpdateProfile(ctx, user)
-		if err != nil {
-			return nil, fmt.Errorf("failed to save email: %w", err)
-		}
-		if !success {
-			return nil, errors.New("email verification failed due to version conflict")
-		}
+	// 確認待ちの間に他ユーザーが同じアドレスを使っていないか再確認
+	exists, err := i.userSettingsRepo.CheckEmailExists(ctx, token.Email, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email existence: %w", err)
+	}
+	if exists {
+		return nil, entities.ErrEmailAlreadyExists
+	}
+
+	// メールアドレスを更新して認証
+	if err := user.UpdateProfile("", token.Email, user.FirstName, user.LastName); err != nil {
+		return nil, fmt.Errorf("failed to update email: %w", err)
+	}
+
+	user.VerifyEmail()
+
+	// データベースに保存
+	success, err := i.userSettingsRepo.UpdateProfile(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save email: %w", err)
+	}
+	if !success {
+		return nil, errors.New("email verification failed due to version conflict")
+	}
+
+	token.MarkApplied(now)
+	if err := i.emailVerificationRepo.Update(ctx, token); err != nil {
+		i.logger.Error("Failed to mark email change as applied", entities.NewField("error", err))
 	}
 
 	i.logger.Info("Email verified successfully", entities.NewField("email", token.Email))
@@ -455,6 +561,16 @@ func (i *UserSettingsInteractor) ArchiveAccount(ctx context.Context, req *inputp
 			return fmt.Errorf("failed to archive user: %w", err)
 		}
 
+		// 相手側の取引履歴に退会済みの印を付ける（削除で外部キーがNULLになる前に行う）
+		mode, err := i.systemSettingsRepo.GetSetting(ctx, entities.AccountErasureModeSettingKey)
+		if err != nil {
+			return fmt.Errorf("failed to get account erasure mode: %w", err)
+		}
+		scrubMemos := entities.ParseAccountErasureMode(mode).ScrubsMemos()
+		if err := i.transactionRepo.AnonymizeUserReferences(ctx, user.ID, scrubMemos); err != nil {
+			return fmt.Errorf("failed to anonymize transactions: %w", err)
+		}
+
 		// 元のユーザーを削除（論理削除ではなく物理削除）
 		if err := i.userRepo.Delete(ctx, user.ID); err != nil {
 			return fmt.Errorf("failed to delete user: %w", err)
@@ -497,3 +613,85 @@ func (i *UserSettingsInteractor) GetProfile(ctx context.Context, req *inputport.
 		User: user,
 	}, nil
 }
+
+// UpdatePrivacy はプライバシー設定（友達検索での公開可否）を更新
+func (i *UserSettingsInteractor) UpdatePrivacy(ctx context.Context, req *inputport.UpdatePrivacyRequest) (*inputport.UpdatePrivacyResponse, error) {
+	i.logger.Info("Updating privacy settings",
+		entities.NewField("user_id", req.UserID),
+		entities.NewField("discoverable", req.Discoverable))
+
+	user, err := i.userRepo.Read(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	user.SetDiscoverable(req.Discoverable)
+
+	success, err := i.userSettingsRepo.UpdateProfile(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update privacy settings: %w", err)
+	}
+	if !success {
+		return nil, errors.New("privacy settings update failed")
+	}
+
+	return &inputport.UpdatePrivacyResponse{
+		User: user,
+	}, nil
+}
+
+// UpdateTimezone はボーナス日の区切りに使うタイムゾーンを更新（空ならシステム設定に従う）
+func (i *UserSettingsInteractor) UpdateTimezone(ctx context.Context, req *inputport.UpdateTimezoneRequest) (*inputport.UpdateTimezoneResponse, error) {
+	i.logger.Info("Updating timezone",
+		entities.NewField("user_id", req.UserID),
+		entities.NewField("timezone", req.Timezone))
+
+	user, err := i.userRepo.Read(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	if err := user.SetTimezone(req.Timezone); err != nil {
+		return nil, err
+	}
+
+	success, err := i.userSettingsRepo.UpdateProfile(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update timezone: %w", err)
+	}
+	if !success {
+		return nil, errors.New("timezone update failed")
+	}
+
+	return &inputport.UpdateTimezoneResponse{
+		User: user,
+	}, nil
+}
+
+// UpdateTransferAcceptance は誰からのポイントを受け取るかを更新（空ならシステム設定に従う）
+func (i *UserSettingsInteractor) UpdateTransferAcceptance(ctx context.Context, req *inputport.UpdateTransferAcceptanceRequest) (*inputport.UpdateTransferAcceptanceResponse, error) {
+	i.logger.Info("Updating transfer acceptance",
+		entities.NewField("user_id", req.UserID),
+		entities.NewField("accept_transfers_from", req.AcceptTransfersFrom))
+
+	user, err := i.userRepo.Read(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	if err := user.SetAcceptTransfersFrom(req.AcceptTransfersFrom); err != nil {
+		return nil, err
+	}
+
+	success, err := i.userSettingsRepo.UpdateProfile(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update transfer acceptance: %w", err)
+	}
+	if !success {
+		return nil, errors.New("transfer acceptance update failed")
+	}
+
+	return &inputport.UpdateTransferAcceptanceResponse{
+		User: user,
+	}, nil
+}
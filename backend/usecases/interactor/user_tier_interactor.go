@@ -0,0 +1,112 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+)
+
+// UserTierInteractor は会員ランクのユースケース実装
+type UserTierInteractor struct {
+	userTierRepo repository.UserTierRepository
+	userRepo     repository.UserRepository
+	settingsRepo repository.SystemSettingsRepository
+	logger       entities.Logger
+}
+
+// NewUserTierInteractor は新しいUserTierInteractorを作成
+func NewUserTierInteractor(
+	userTierRepo repository.UserTierRepository,
+	userRepo repository.UserRepository,
+	settingsRepo repository.SystemSettingsRepository,
+	logger entities.Logger,
+) inputport.UserTierInputPort {
+	return &UserTierInteractor{
+		userTierRepo: userTierRepo,
+		userRepo:     userRepo,
+		settingsRepo: settingsRepo,
+		logger:       logger,
+	}
+}
+
+// RecalculateTiers は全ユーザーのランクを判定し直す
+// 1人の更新に失敗しても残りのユーザーの判定は続ける
+func (i *UserTierInteractor) RecalculateTiers(ctx context.Context, now time.Time) (int, error) {
+	activities, err := i.userTierRepo.ReadActivities(ctx, entities.TierEarningSince(now), i.streakAsOf(ctx, now))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read tier activities: %w", err)
+	}
+
+	changed := 0
+	for _, a := range activities {
+		tier := entities.ComputeTier(a)
+		updated, err := i.userTierRepo.UpdateComputedTier(ctx, a.UserID, tier, now)
+		if err != nil {
+			i.logger.Error("Failed to update user tier",
+				entities.NewField("user_id", a.UserID),
+				entities.NewField("error", err))
+			continue
+		}
+		if updated {
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// OverrideTier はユーザーのランクを固定する
+func (i *UserTierInteractor) OverrideTier(ctx context.Context, req *inputport.OverrideTierRequest) (*entities.User, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+	if !req.Tier.IsValid() {
+		return nil, entities.ErrInvalidUserTier
+	}
+
+	tier := req.Tier
+	if err := i.userTierRepo.SetOverride(ctx, req.UserID, &tier, time.Now()); err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("User tier overridden",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("user_id", req.UserID),
+		entities.NewField("tier", tier))
+
+	return i.userRepo.Read(ctx, req.UserID)
+}
+
+// ClearTierOverride は固定を解除して判定し直す
+func (i *UserTierInteractor) ClearTierOverride(ctx context.Context, req *inputport.ClearTierOverrideRequest) (*entities.User, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := i.userTierRepo.SetOverride(ctx, req.UserID, nil, now); err != nil {
+		return nil, err
+	}
+
+	activity, err := i.userTierRepo.ReadActivity(ctx, req.UserID, entities.TierEarningSince(now), i.streakAsOf(ctx, now))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := i.userTierRepo.UpdateComputedTier(ctx, req.UserID, entities.ComputeTier(activity), now); err != nil {
+		return nil, fmt.Errorf("failed to update user tier: %w", err)
+	}
+
+	i.logger.Info("User tier override cleared",
+		entities.NewField("admin_id", req.AdminID),
+		entities.NewField("user_id", req.UserID))
+
+	return i.userRepo.Read(ctx, req.UserID)
+}
+
+// streakAsOf は連続チェックイン日数を数える基準のボーナス日（system_settingsのタイムゾーン）
+func (i *UserTierInteractor) streakAsOf(ctx context.Context, now time.Time) time.Time {
+	return entities.GetBonusDate(now, systemBonusLocation(ctx, i.settingsRepo, i.logger))
+}
@@ -0,0 +1,175 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/gity/point-system/usecases/service"
+	"github.com/google/uuid"
+)
+
+// WeeklyDigestInteractor は週次のまとめメールのユースケース実装
+type WeeklyDigestInteractor struct {
+	digestRepo          repository.WeeklyDigestRepository
+	userRepo            repository.UserRepository
+	pointBatchRepo      repository.PointBatchRepository
+	transferRequestRepo repository.TransferRequestRepository
+	notificationRepo    repository.NotificationRepository
+	emailService        service.EmailService
+	logger              entities.Logger
+}
+
+// NewWeeklyDigestInteractor は新しいWeeklyDigestInteractorを作成
+func NewWeeklyDigestInteractor(
+	digestRepo repository.WeeklyDigestRepository,
+	userRepo repository.UserRepository,
+	pointBatchRepo repository.PointBatchRepository,
+	transferRequestRepo repository.TransferRequestRepository,
+	notificationRepo repository.NotificationRepository,
+	emailService service.EmailService,
+	logger entities.Logger,
+) *WeeklyDigestInteractor {
+	return &WeeklyDigestInteractor{
+		digestRepo:          digestRepo,
+		userRepo:            userRepo,
+		pointBatchRepo:      pointBatchRepo,
+		transferRequestRepo: transferRequestRepo,
+		notificationRepo:    notificationRepo,
+		emailService:        emailService,
+		logger:              logger,
+	}
+}
+
+// SendWeeklyDigests は送信対象のユーザーをWeeklyDigestBatchSize人ずつ読み出し、まとめメールを送る
+// 知らせる内容がないユーザーには送らない。1人分の作成・送信の失敗はログに残して他のユーザーへの送信を続ける
+// メールの送信がサーキットブレーカーで止められた場合はそこで打ち切り、エラーを返す
+func (i *WeeklyDigestInteractor) SendWeeklyDigests(ctx context.Context, now time.Time) (int64, error) {
+	from := now.Add(-entities.WeeklyDigestPeriod)
+
+	var sent int64
+	after := uuid.Nil
+	for {
+		ids, err := i.digestRepo.ReadRecipientIDs(ctx, after, entities.WeeklyDigestBatchSize)
+		if err != nil {
+			return sent, fmt.Errorf("failed to get weekly digest recipients: %w", err)
+		}
+		if len(ids) == 0 {
+			break
+		}
+		activities, err := i.readActivity(ctx, ids, from, now)
+		if err != nil {
+			return sent, err
+		}
+
+		for _, userID := range ids {
+			var digest *entities.WeeklyDigest
+			user, err := i.userRepo.Read(ctx, userID)
+			if err == nil {
+				digest, err = i.buildDigest(ctx, user, activities[userID], now)
+			}
+			if err != nil {
+				i.logger.Warn("Failed to build weekly digest",
+					entities.NewField("user_id", userID),
+					entities.NewField("error", err))
+				continue
+			}
+			if digest.IsEmpty() {
+				continue
+			}
+			email, err := digest.Render()
+			if err != nil {
+				return sent, fmt.Errorf("failed to render weekly digest: %w", err)
+			}
+			if err := i.emailService.SendNotificationEmail(digest.Email, email.Subject, email.Body); err != nil {
+				if errors.Is(err, service.ErrCircuitOpen) {
+					// メールサーバーの失敗が続いている間は残りのユーザーへの送信を試さない
+					return sent, fmt.Errorf("weekly digest stopped: %w", err)
+				}
+				i.logger.Warn("Failed to send weekly digest",
+					entities.NewField("user_id", userID),
+					entities.NewField("error", err))
+				continue
+			}
+			sent++
+		}
+
+		after = ids[len(ids)-1]
+		if len(ids) < entities.WeeklyDigestBatchSize {
+			break
+		}
+	}
+
+	i.logger.Info("Weekly digests sent", entities.NewField("sent", sent))
+	return sent, nil
+}
+
+// PreviewWeeklyDigest はユーザーに今送るまとめメールを送らずに作成する
+// 送信対象でないユーザーも、送った場合の内容を返す
+func (i *WeeklyDigestInteractor) PreviewWeeklyDigest(ctx context.Context, req *inputport.PreviewWeeklyDigestRequest) (*inputport.WeeklyDigestPreview, error) {
+	if err := requireAdmin(ctx, i.userRepo, req.AdminID); err != nil {
+		return nil, err
+	}
+
+	user, err := i.userRepo.Read(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	activities, err := i.readActivity(ctx, []uuid.UUID{user.ID}, now.Add(-entities.WeeklyDigestPeriod), now)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := i.buildDigest(ctx, user, activities[user.ID], now)
+	if err != nil {
+		return nil, err
+	}
+	email, err := digest.Render()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render weekly digest: %w", err)
+	}
+
+	prefs, err := i.notificationRepo.ReadPreferences(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notification preferences: %w", err)
+	}
+	optedIn := user.IsActive && user.Email != "" &&
+		prefs.Allows(entities.NotificationChannelEmail, entities.NotificationTypeWeeklyDigest, now)
+
+	return &inputport.WeeklyDigestPreview{
+		Digest:    digest,
+		Email:     email,
+		OptedIn:   optedIn,
+		WouldSend: optedIn && !digest.IsEmpty(),
+	}, nil
+}
+
+// readActivity は期間中の増減をユーザーごとに引けるようにする（取引がないユーザーは含まれない）
+func (i *WeeklyDigestInteractor) readActivity(ctx context.Context, userIDs []uuid.UUID, from, to time.Time) (map[uuid.UUID]*entities.WeeklyDigestActivity, error) {
+	list, err := i.digestRepo.ReadActivity(ctx, userIDs, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weekly activity: %w", err)
+	}
+	activities := make(map[uuid.UUID]*entities.WeeklyDigestActivity, len(list))
+	for _, a := range list {
+		activities[a.UserID] = a
+	}
+	return activities, nil
+}
+
+// buildDigest は残高・有効期限が近いポイント・承認待ちの送金リクエストを合わせてまとめを作る
+func (i *WeeklyDigestInteractor) buildDigest(ctx context.Context, user *entities.User, activity *entities.WeeklyDigestActivity, now time.Time) (*entities.WeeklyDigest, error) {
+	expirations, err := i.pointBatchRepo.FindUpcomingExpirations(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upcoming expirations: %w", err)
+	}
+	pending, err := i.transferRequestRepo.CountPendingByToUser(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pending transfer requests: %w", err)
+	}
+	return entities.NewWeeklyDigest(user, activity, expirations, pending, now), nil
+}
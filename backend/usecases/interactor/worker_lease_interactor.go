@@ -0,0 +1,62 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/gity/point-system/usecases/inputport"
+	"github.com/gity/point-system/usecases/repository"
+	"github.com/google/uuid"
+)
+
+// WorkerLeaseInteractor はワーカーのリーダー選出のユースケース実装
+type WorkerLeaseInteractor struct {
+	leaseRepo repository.WorkerLeaseRepository
+	userRepo  repository.UserRepository
+	logger    entities.Logger
+}
+
+// NewWorkerLeaseInteractor は新しいWorkerLeaseInteractorを作成
+func NewWorkerLeaseInteractor(
+	leaseRepo repository.WorkerLeaseRepository,
+	userRepo repository.UserRepository,
+	logger entities.Logger,
+) inputport.WorkerLeaseInputPort {
+	return &WorkerLeaseInteractor{
+		leaseRepo: leaseRepo,
+		userRepo:  userRepo,
+		logger:    logger,
+	}
+}
+
+// Campaign はholderをワーカーのリーダーにする
+func (i *WorkerLeaseInteractor) Campaign(ctx context.Context, name, holder string, now time.Time) (bool, error) {
+	acquired, err := i.leaseRepo.TryAcquire(ctx, name, holder, now, now.Add(entities.WorkerLeaseTTL))
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire worker lease: %w", err)
+	}
+	return acquired, nil
+}
+
+// Resign はholderがリーダーなら降りる
+func (i *WorkerLeaseInteractor) Resign(ctx context.Context, name, holder string) error {
+	if err := i.leaseRepo.Release(ctx, name, holder, time.Now()); err != nil {
+		return fmt.Errorf("failed to release worker lease: %w", err)
+	}
+	return nil
+}
+
+// ListLeases は全ワーカーのリーダーと交代回数を取得
+func (i *WorkerLeaseInteractor) ListLeases(ctx context.Context, adminID uuid.UUID) ([]*entities.WorkerLease, error) {
+	if err := requireAdmin(ctx, i.userRepo, adminID); err != nil {
+		return nil, err
+	}
+
+	leases, err := i.leaseRepo.ReadList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worker leases: %w", err)
+	}
+	return leases, nil
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+)
+
+// AdminDashboardRepository は管理者ダッシュボードの集計用リポジトリインターフェース
+type AdminDashboardRepository interface {
+	// GetCounters はnow時点のカウンターを取得
+	GetCounters(ctx context.Context, now time.Time) (*entities.AdminDashboardCounters, error)
+
+	// Ping はDBへ疎通できるか確認
+	Ping(ctx context.Context) error
+}
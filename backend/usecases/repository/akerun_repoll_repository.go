@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// AkerunRepollRepository はAkerunの再取得の依頼のリポジトリインターフェース
+type AkerunRepollRepository interface {
+	// Create は再取得の依頼を作成
+	Create(ctx context.Context, repoll *entities.AkerunRepoll) error
+
+	// Read はIDで取得（なければErrAkerunRepollNotFound）
+	Read(ctx context.Context, id uuid.UUID) (*entities.AkerunRepoll, error)
+
+	// ReadActive は処理待ちまたは取得中の依頼を取得（ない場合はnil）
+	ReadActive(ctx context.Context) (*entities.AkerunRepoll, error)
+
+	// ReadList は新しい順に取得
+	ReadList(ctx context.Context, offset, limit int) ([]*entities.AkerunRepoll, error)
+
+	// Count は件数を取得
+	Count(ctx context.Context) (int64, error)
+
+	// Update は状態と進み具合を更新
+	Update(ctx context.Context, repoll *entities.AkerunRepoll) error
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// AnnouncementRepository はお知らせのリポジトリインターフェース
+type AnnouncementRepository interface {
+	// Create はお知らせを作成
+	Create(ctx context.Context, announcement *entities.Announcement) error
+
+	// Read はIDでお知らせを取得
+	Read(ctx context.Context, id uuid.UUID) (*entities.Announcement, error)
+
+	// Update はお知らせを更新
+	Update(ctx context.Context, announcement *entities.Announcement) error
+
+	// Delete はお知らせを削除
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// ReadList はお知らせを表示開始日時の新しい順に取得（管理画面用）
+	ReadList(ctx context.Context, offset, limit int) ([]*entities.Announcement, error)
+
+	// Count はお知らせの件数を取得
+	Count(ctx context.Context) (int64, error)
+
+	// ReadActive は指定日時に表示期間内のお知らせを重要度の高い順に取得
+	ReadActive(ctx context.Context, now time.Time) ([]*entities.Announcement, error)
+
+	// Dismiss はユーザーがお知らせを非表示にしたことを記録（記録済みなら何もしない）
+	Dismiss(ctx context.Context, announcementID, userID uuid.UUID, dismissedAt time.Time) error
+
+	// ReadDismissedIDs は指定したお知らせのうちユーザーが非表示にしたもののIDを取得
+	ReadDismissedIDs(ctx context.Context, userID uuid.UUID, announcementIDs []uuid.UUID) ([]uuid.UUID, error)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// BalanceHoldRepository は送金リクエストのために確保した残高のリポジトリインターフェース
+type BalanceHoldRepository interface {
+	// Create は確保を作成
+	Create(ctx context.Context, hold *entities.BalanceHold) error
+
+	// ReadByTransferRequestIDForUpdate は送金リクエストの確保を行ロックして取得（確保していなければnil）
+	ReadByTransferRequestIDForUpdate(ctx context.Context, transferRequestID uuid.UUID) (*entities.BalanceHold, error)
+
+	// Update は確保の状態を更新
+	Update(ctx context.Context, hold *entities.BalanceHold) error
+
+	// ReadReleasable は送金リクエストが承認待ちでなくなった（拒否・キャンセル・期限切れ）のに確保中のものを古い順に取得
+	ReadReleasable(ctx context.Context, limit int) ([]*entities.BalanceHold, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// BalanceLedgerRepository は残高と取引履歴の照合のリポジトリインターフェース
+type BalanceLedgerRepository interface {
+	// ReadUserIDs はafterより後のユーザーID（退会済みを除く）を昇順にlimit件取得
+	ReadUserIDs(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error)
+
+	// LockUsers はユーザーの行をID順にロックする（トランザクション内で呼び、照合中の残高の変更を止める）
+	LockUsers(ctx context.Context, userIDs []uuid.UUID) error
+
+	// ReadBalanceChecks は保存されている残高と取引履歴から計算した残高をユーザーID順に取得
+	ReadBalanceChecks(ctx context.Context, userIDs []uuid.UUID) ([]*entities.BalanceCheck, error)
+}
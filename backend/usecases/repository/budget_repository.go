@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// BudgetRepository は管理者付与の予算のリポジトリインターフェース
+type BudgetRepository interface {
+	// Create は予算を作成
+	Create(ctx context.Context, budget *entities.Budget) error
+
+	// Read はIDで予算を取得
+	Read(ctx context.Context, id uuid.UUID) (*entities.Budget, error)
+
+	// ReadList は全予算を作成順に取得
+	ReadList(ctx context.Context) ([]*entities.Budget, error)
+
+	// Update は予算名・金額・超過時の扱い・有効状態を更新
+	Update(ctx context.Context, budget *entities.Budget) error
+
+	// Delete は予算と消化状況を削除
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// ReadApplicableForUpdate は管理者adminIDから部署departmentIDsの所属ユーザーへの付与が対象の有効な予算を
+	// 行ロック付きでID順に取得（付与のトランザクション内で使い、同じ予算への同時付与を直列化する）
+	ReadApplicableForUpdate(ctx context.Context, adminID uuid.UUID, departmentIDs []uuid.UUID) ([]*entities.Budget, error)
+
+	// ReadUsage は予算の期間の消化状況を取得（まだ付与がなければConsumed=0の状況を返す）
+	ReadUsage(ctx context.Context, budgetID uuid.UUID, periodStart time.Time) (*entities.BudgetUsage, error)
+
+	// SaveUsage は期間の消化状況を保存（なければ作成）
+	SaveUsage(ctx context.Context, usage *entities.BudgetUsage) error
+
+	// ReadAlertRecipients は予算の通知を届ける有効な管理者のIDを取得
+	ReadAlertRecipients(ctx context.Context) ([]uuid.UUID, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+)
+
+// BusinessMetricsRepository は /metrics で公開する業務の指標の集計用リポジトリインターフェース
+type BusinessMetricsRepository interface {
+	// GetBusinessMetrics はnow時点の指標を集計（「今日」の値はdayStartからnowまで）
+	GetBusinessMetrics(ctx context.Context, dayStart, now time.Time) (*entities.BusinessMetrics, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// CartRepository はカートのリポジトリインターフェース
+type CartRepository interface {
+	// ReadByUser はユーザーのカートの商品を入れた順に取得
+	ReadByUser(ctx context.Context, userID uuid.UUID) ([]*entities.CartItem, error)
+
+	// Read はカートの商品を取得（なければErrCartItemNotFound）
+	Read(ctx context.Context, userID, productID uuid.UUID) (*entities.CartItem, error)
+
+	// Save はカートの商品を作成または更新
+	Save(ctx context.Context, item *entities.CartItem) error
+
+	// Delete はカートから商品を削除（なければErrCartItemNotFound）
+	Delete(ctx context.Context, userID, productID uuid.UUID) error
+
+	// DeleteByUser はユーザーのカートを空にする
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
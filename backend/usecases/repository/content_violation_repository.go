@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// ContentViolationRepository はモデレーション違反記録のリポジトリインターフェース
+type ContentViolationRepository interface {
+	// Create は違反記録を作成
+	Create(ctx context.Context, violation *entities.ContentViolation) error
+
+	// Read はIDで違反記録を取得
+	Read(ctx context.Context, id uuid.UUID) (*entities.ContentViolation, error)
+
+	// ReadList は違反記録を新しい順に取得（unreviewedOnlyなら未レビューのみ）
+	ReadList(ctx context.Context, unreviewedOnly bool, offset, limit int) ([]*entities.ContentViolation, error)
+
+	// Count は違反記録の件数を取得
+	Count(ctx context.Context, unreviewedOnly bool) (int64, error)
+
+	// UpdateReviewed はレビュー結果を保存
+	UpdateReviewed(ctx context.Context, violation *entities.ContentViolation) error
+}
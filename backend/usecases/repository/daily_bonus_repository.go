@@ -22,15 +22,19 @@ type DailyBonusRepository interface {
 	// CountByUser はユーザーのボーナス獲得日数をカウント
 	CountByUser(ctx context.Context, userID uuid.UUID) (int64, error)
 
-	// GetLastPolledAt は前回ポーリング時刻を取得
-	GetLastPolledAt(ctx context.Context) (time.Time, error)
+	// GetLastPolledAt はAkerun組織の前回ポーリング時刻を取得
+	GetLastPolledAt(ctx context.Context, organizationID string) (time.Time, error)
 
-	// UpdateLastPolledAt はポーリング時刻を更新
-	UpdateLastPolledAt(ctx context.Context, t time.Time) error
+	// UpdateLastPolledAt はAkerun組織のポーリング時刻を更新
+	UpdateLastPolledAt(ctx context.Context, organizationID string, t time.Time) error
 
 	// MarkAsViewed はデイリーボーナスを閲覧済みにする
 	MarkAsViewed(ctx context.Context, id uuid.UUID) error
 
 	// UpdateDrawnResult は抽選結果を更新する（ルーレット実行時）
 	UpdateDrawnResult(ctx context.Context, id uuid.UUID, bonusPoints int64, lotteryTierID *uuid.UUID, lotteryTierName string) error
+
+	// StreamByPeriod はボーナス日が期間内のボーナスをユーザー名付きで、ボーナス日・入退室日時の順に1件ずつfnへ渡す
+	// 全件をメモリに載せないよう行を読みながら渡し、fnがエラーを返した時点で中断する
+	StreamByPeriod(ctx context.Context, period entities.BonusReportPeriod, fn func(*entities.DailyBonusRecord) error) error
 }
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// DataExportRepository は個人データエクスポート依頼のリポジトリインターフェース
+type DataExportRepository interface {
+	// Create はエクスポート依頼を作成
+	Create(ctx context.Context, export *entities.DataExport) error
+
+	// Read はIDでエクスポート依頼を取得
+	Read(ctx context.Context, id uuid.UUID) (*entities.DataExport, error)
+
+	// ReadLatestByUser はユーザーの最新のエクスポート依頼を取得（ない場合はnil）
+	ReadLatestByUser(ctx context.Context, userID uuid.UUID) (*entities.DataExport, error)
+
+	// ClaimPending は処理待ちの依頼を作成中にして取得（複数インスタンスで重複しない）
+	ClaimPending(ctx context.Context, limit int) ([]*entities.DataExport, error)
+
+	// Update はエクスポート依頼を更新
+	Update(ctx context.Context, export *entities.DataExport) error
+
+	// ReadListExpired はダウンロード期限を過ぎたか、退会によって不要になったファイルのある依頼を取得
+	ReadListExpired(ctx context.Context, now time.Time, limit int) ([]*entities.DataExport, error)
+}
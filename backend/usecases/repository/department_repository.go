@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// DepartmentRepository は組織・部署のリポジトリインターフェース
+type DepartmentRepository interface {
+	// Create は部署を作成（同じ親の下に同名の部署があればErrDepartmentExists）
+	Create(ctx context.Context, department *entities.Department) error
+
+	// Read はIDで部署を取得
+	Read(ctx context.Context, id uuid.UUID) (*entities.Department, error)
+
+	// ReadForUpdate は部署を行ロック付きで取得（予算の確認と付与を直列化する）
+	ReadForUpdate(ctx context.Context, id uuid.UUID) (*entities.Department, error)
+
+	// ReadList は全部署を取得（部署数は少ない前提で、階層の計算は呼び出し側で行う）
+	ReadList(ctx context.Context) ([]*entities.Department, error)
+
+	// Update は部署名・親・月間予算を更新
+	Update(ctx context.Context, department *entities.Department) error
+
+	// Delete は部署を削除（子部署があればErrDepartmentHasChildren、所属ユーザーは未所属になる）
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// SetUserDepartment はユーザーの所属部署を設定・解除（departmentIDがnilなら未所属）
+	SetUserDepartment(ctx context.Context, userID uuid.UUID, departmentID *uuid.UUID) error
+
+	// SumAdminGrantsSince はsince以降に部署の現在の所属ユーザーへ管理者が付与したポイントの合計を取得
+	SumAdminGrantsSince(ctx context.Context, departmentID uuid.UUID, since time.Time) (int64, error)
+
+	// ReadBreakdown はsince以降の部署ごとの付与・利用ポイントを取得（所属ユーザーのいない部署も含む）
+	ReadBreakdown(ctx context.Context, since time.Time) ([]*entities.DepartmentBreakdownResult, error)
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// EarningRuleRepository はポイント獲得ルールと付与の記録のリポジトリインターフェース
+type EarningRuleRepository interface {
+	// Create はポイント獲得ルールを作成
+	Create(ctx context.Context, rule *entities.EarningRule) error
+
+	// Read はIDでポイント獲得ルールを取得
+	Read(ctx context.Context, id uuid.UUID) (*entities.EarningRule, error)
+
+	// Update はポイント獲得ルールを更新（付与済みの回数は変えない）
+	Update(ctx context.Context, rule *entities.EarningRule) error
+
+	// Delete はポイント獲得ルールを削除（付与の記録も削除し、付与した取引は残す）
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// ReadList はポイント獲得ルールを作成日時の新しい順に取得（管理画面用）
+	ReadList(ctx context.Context, offset, limit int) ([]*entities.EarningRule, error)
+
+	// Count はポイント獲得ルールの件数を取得
+	Count(ctx context.Context) (int64, error)
+
+	// ReadActiveByTrigger は指定日時に有効な、きっかけが一致するルールを作成日時の古い順に取得
+	ReadActiveByTrigger(ctx context.Context, trigger entities.EarningTrigger, now time.Time) ([]*entities.EarningRule, error)
+
+	// CountOccurrences はユーザーのきっかけとなる出来事の回数を取得（送金した回数、友達の数など）
+	CountOccurrences(ctx context.Context, userID uuid.UUID, trigger entities.EarningTrigger) (int64, error)
+
+	// IncrementGrantCount はルール全体の上限に達していなければ付与済みの回数を1増やす（達していればfalse）
+	// ルールの行をロックするため、同じルールの付与はトランザクションの終わりまで直列になる
+	IncrementGrantCount(ctx context.Context, ruleID uuid.UUID) (bool, error)
+
+	// CountUserGrants はユーザーにルールで付与した回数を取得
+	CountUserGrants(ctx context.Context, ruleID, userID uuid.UUID) (int, error)
+
+	// CreateGrant は付与を記録（同じルール・ユーザー・発生元で記録済みならfalse）
+	CreateGrant(ctx context.Context, grant *entities.EarningRuleGrant) (bool, error)
+
+	// ReadStats はルールごとの付与の実績を取得（付与のないルールは含めない）
+	ReadStats(ctx context.Context, ruleIDs []uuid.UUID) (map[uuid.UUID]*entities.EarningRuleStats, error)
+}
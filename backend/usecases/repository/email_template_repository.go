@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+)
+
+// EmailTemplateRepository は管理者が編集したメールテンプレート（版ごと）のリポジトリインターフェース
+type EmailTemplateRepository interface {
+	// Create はテンプレートの新しい版を保存
+	Create(ctx context.Context, template *entities.EmailTemplate) error
+
+	// ReadLatest はメールの種類の最新の版を取得（版がなければErrEmailTemplateNotFound）
+	ReadLatest(ctx context.Context, key entities.EmailTemplateKey) (*entities.EmailTemplate, error)
+
+	// ReadVersion は指定した版を取得
+	ReadVersion(ctx context.Context, key entities.EmailTemplateKey, version int) (*entities.EmailTemplate, error)
+
+	// ReadVersions はメールの種類のすべての版を新しい順に取得
+	ReadVersions(ctx context.Context, key entities.EmailTemplateKey) ([]*entities.EmailTemplate, error)
+
+	// ReadLatestList は編集済みのメールの種類ごとに最新の版を取得
+	ReadLatestList(ctx context.Context) ([]*entities.EmailTemplate, error)
+
+	// DeleteByKey はメールの種類のすべての版を削除（組み込みの既定に戻す）
+	DeleteByKey(ctx context.Context, key entities.EmailTemplateKey) error
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// EventRepository はチェックインイベントのリポジトリインターフェース
+type EventRepository interface {
+	// Create はイベントを作成
+	Create(ctx context.Context, event *entities.Event) error
+
+	// Read はIDでイベントを取得
+	Read(ctx context.Context, id uuid.UUID) (*entities.Event, error)
+
+	// ReadByCode はQRコードのコードでイベントを取得（存在しなければErrEventNotFound）
+	ReadByCode(ctx context.Context, code string) (*entities.Event, error)
+
+	// Update はイベントの内容を更新（チェックイン済みの人数は変更しない）
+	Update(ctx context.Context, event *entities.Event) error
+
+	// ReadList はイベントを開始日時の新しい順に取得（管理画面用）
+	ReadList(ctx context.Context, offset, limit int) ([]*entities.Event, error)
+
+	// Count はイベントの件数を取得
+	Count(ctx context.Context) (int64, error)
+
+	// IncrementAttendeeCount は定員に空きがあればチェックイン済みの人数を1増やす（定員に達していればfalse）
+	IncrementAttendeeCount(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// CreateAttendance はチェックインを記録（同じユーザーが既にチェックイン済みならErrEventAlreadyCheckedIn）
+	CreateAttendance(ctx context.Context, attendance *entities.EventAttendance) error
+
+	// ReadAttendees はイベントの参加者をチェックインの早い順に取得
+	ReadAttendees(ctx context.Context, eventID uuid.UUID, offset, limit int) ([]*entities.EventAttendee, error)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// FailedAkerunAccessRepository はボーナスの付与に失敗したAkerunアクセス記録（再試行キュー）のリポジトリインターフェース
+type FailedAkerunAccessRepository interface {
+	// Enqueue は失敗したアクセス記録を登録する（同じアクセス記録が登録済みなら何もしない）
+	Enqueue(ctx context.Context, access *entities.FailedAkerunAccess) error
+
+	// Read はIDで取得（なければErrFailedAccessNotFound）
+	Read(ctx context.Context, id uuid.UUID) (*entities.FailedAkerunAccess, error)
+
+	// ReadDue は再試行待ちのうちnowまでに再試行時刻を迎えたものを古い順に取得
+	ReadDue(ctx context.Context, now time.Time, limit int) ([]*entities.FailedAkerunAccess, error)
+
+	// ReadList は新しい順に取得（statusが空なら全状態）
+	ReadList(ctx context.Context, status entities.FailedAkerunAccessStatus, offset, limit int) ([]*entities.FailedAkerunAccess, error)
+
+	// Count は件数を取得（statusが空なら全状態）
+	Count(ctx context.Context, status entities.FailedAkerunAccessStatus) (int64, error)
+
+	// Update は状態・失敗回数・次回の再試行時刻を更新
+	Update(ctx context.Context, access *entities.FailedAkerunAccess) error
+
+	// Delete は付与できたアクセス記録をキューから削除
+	Delete(ctx context.Context, id uuid.UUID) error
+}
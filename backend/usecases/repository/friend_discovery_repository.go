@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// FriendDiscoveryRepository は連絡先ハッシュによる友達検索のリポジトリインターフェース
+type FriendDiscoveryRepository interface {
+	// ReadDiscoverableByHashes はメールアドレスまたはユーザー名のハッシュが一致する公開設定のユーザーを取得
+	// 検索したユーザー本人・無効ユーザー・既に友達またはブロック関係にあるユーザーは含まない
+	ReadDiscoverableByHashes(ctx context.Context, userID uuid.UUID, hashes []string) ([]*entities.User, error)
+}
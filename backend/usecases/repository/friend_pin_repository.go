@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// FriendPinRepository は友達のピン留めのリポジトリインターフェース
+type FriendPinRepository interface {
+	// Create はピン留めを作成（既にピン留めしていれば何もしない）
+	Create(ctx context.Context, pin *entities.FriendPin) error
+
+	// Delete はピン留めを外す（ピン留めしていなければ何もしない）
+	Delete(ctx context.Context, userID, friendID uuid.UUID) error
+
+	// ReadListByUserID はユーザーのピン留めをピン留めした順に取得
+	ReadListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.FriendPin, error)
+}
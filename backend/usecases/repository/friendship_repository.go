@@ -44,4 +44,10 @@ type FriendshipRepository interface {
 
 	// CountPendingRequests は保留中の友達申請件数を取得
 	CountPendingRequests(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// ReadListMutualFriends は2人のユーザーの共通の友達一覧を取得（ユーザー名順）
+	ReadListMutualFriends(ctx context.Context, userID1, userID2 uuid.UUID, offset, limit int) ([]*entities.User, error)
+
+	// CountMutualFriends は2人のユーザーの共通の友達の人数を取得
+	CountMutualFriends(ctx context.Context, userID1, userID2 uuid.UUID) (int64, error)
 }
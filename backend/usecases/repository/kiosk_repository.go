@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// KioskRepository はキオスク端末・カード・付与記録のリポジトリインターフェース
+type KioskRepository interface {
+	// CreateDevice は新しい端末を登録
+	CreateDevice(ctx context.Context, device *entities.KioskDevice) error
+
+	// ReadDevice はIDで端末を検索
+	ReadDevice(ctx context.Context, id uuid.UUID) (*entities.KioskDevice, error)
+
+	// ReadDeviceByAPIKeyHash はAPIキーハッシュで端末を検索
+	ReadDeviceByAPIKeyHash(ctx context.Context, apiKeyHash string) (*entities.KioskDevice, error)
+
+	// ReadDeviceList は端末一覧を取得
+	ReadDeviceList(ctx context.Context) ([]*entities.KioskDevice, error)
+
+	// UpdateDevice は端末情報を更新
+	UpdateDevice(ctx context.Context, device *entities.KioskDevice) error
+
+	// UpdateDeviceLastSeen は端末の最終アクセス日時を更新
+	UpdateDeviceLastSeen(ctx context.Context, id uuid.UUID, seenAt time.Time) error
+
+	// CreateCard はカードIDとユーザーを紐付け
+	CreateCard(ctx context.Context, card *entities.KioskCard) error
+
+	// ReadCard はカードIDで紐付けを検索
+	ReadCard(ctx context.Context, cardID string) (*entities.KioskCard, error)
+
+	// DeleteCard はカードIDの紐付けを削除
+	DeleteCard(ctx context.Context, cardID string) error
+
+	// CreateGrant は付与記録を作成
+	CreateGrant(ctx context.Context, grant *entities.KioskGrant) error
+
+	// ReadGrantByIdempotencyKey は端末と冪等性キーで付与記録を検索（存在しない場合はnil）
+	ReadGrantByIdempotencyKey(ctx context.Context, deviceID uuid.UUID, key string) (*entities.KioskGrant, error)
+
+	// CountGrantsByDeviceSince は端末の指定日時以降の付与回数を取得
+	CountGrantsByDeviceSince(ctx context.Context, deviceID uuid.UUID, since time.Time) (int64, error)
+
+	// ExistsGrantByDeviceAndUserSince は端末が指定日時以降にユーザーへ付与済みか確認
+	ExistsGrantByDeviceAndUserSince(ctx context.Context, deviceID, userID uuid.UUID, since time.Time) (bool, error)
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// LoginAttemptRepository はログイン試行・アカウントロックアウトのリポジトリインターフェース
+type LoginAttemptRepository interface {
+	// Create はログイン試行を記録
+	Create(ctx context.Context, attempt *entities.LoginAttempt) error
+
+	// CountFailedByIPSince はIPアドレスの指定日時以降の失敗回数を取得
+	CountFailedByIPSince(ctx context.Context, ipAddress string, since time.Time) (int64, error)
+
+	// CountSuccessByUser はユーザーのログイン成功回数を取得
+	CountSuccessByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// ExistsSuccessByUserAndUserAgent は同じ端末（User-Agent）でのログイン成功履歴があるか確認
+	ExistsSuccessByUserAndUserAgent(ctx context.Context, userID uuid.UUID, userAgent string) (bool, error)
+
+	// ExistsSuccessByUserAndCountry は同じ国からのログイン成功履歴があるか確認
+	ExistsSuccessByUserAndCountry(ctx context.Context, userID uuid.UUID, country string) (bool, error)
+
+	// ExistsSuccessByUserAndIP は同じIPアドレスからのログイン成功履歴があるか確認
+	ExistsSuccessByUserAndIP(ctx context.Context, userID uuid.UUID, ipAddress string) (bool, error)
+
+	// ReadListByUserID はユーザーのログイン試行を新しい順に取得
+	ReadListByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.LoginAttempt, error)
+
+	// CountByUserID はユーザーのログイン試行の件数を取得
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// ReadLockout はユーザーのロックアウト状態を取得（存在しない場合はnil）
+	ReadLockout(ctx context.Context, userID uuid.UUID) (*entities.AccountLockout, error)
+
+	// ReadLockoutByUnlockTokenHash はロック解除トークンのハッシュでロックアウト状態を取得
+	ReadLockoutByUnlockTokenHash(ctx context.Context, tokenHash string) (*entities.AccountLockout, error)
+
+	// SaveLockout はロックアウト状態を保存
+	SaveLockout(ctx context.Context, lockout *entities.AccountLockout) error
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// MuteListRepository は送金リクエストの送信者のミュートのリポジトリインターフェース
+type MuteListRepository interface {
+	// Upsert はミュートを作成（既にミュートしていればミュートの方法だけ変える）
+	Upsert(ctx context.Context, mute *entities.MutedSender) error
+
+	// Delete はミュートを解除（ミュートしていなければ何もしない）
+	Delete(ctx context.Context, userID, mutedUserID uuid.UUID) error
+
+	// Read はユーザーが送信者をミュートしているかを取得（ミュートしていなければnil）
+	Read(ctx context.Context, userID, mutedUserID uuid.UUID) (*entities.MutedSender, error)
+
+	// ReadListByUserID はユーザーのミュートをミュートした順に取得
+	ReadListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.MutedSender, error)
+}
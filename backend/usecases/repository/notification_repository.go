@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// NotificationRepository は通知の配信先・設定のリポジトリインターフェース
+type NotificationRepository interface {
+	// UpsertDeviceToken はトークンを登録する（登録済みなら所有者とLastSeenAtを更新）
+	UpsertDeviceToken(ctx context.Context, device *entities.DeviceToken) error
+
+	// DeleteDeviceToken はユーザーのトークンを削除（存在しない場合はErrDeviceTokenNotFound）
+	DeleteDeviceToken(ctx context.Context, userID uuid.UUID, token string) error
+
+	// DeleteDeviceTokenByID はトークンを削除（配信先に無効と判定されたトークンの掃除用）
+	DeleteDeviceTokenByID(ctx context.Context, id uuid.UUID) error
+
+	// ReadDeviceTokensByUser はユーザーの登録済みトークンを新しい順に取得
+	ReadDeviceTokensByUser(ctx context.Context, userID uuid.UUID) ([]*entities.DeviceToken, error)
+
+	// PruneDeviceTokens はユーザーのトークンを新しい順にkeep件だけ残して削除
+	PruneDeviceTokens(ctx context.Context, userID uuid.UUID, keep int) error
+
+	// ReadPreferences はユーザーの通知設定を取得（未設定なら初期設定を返す）
+	ReadPreferences(ctx context.Context, userID uuid.UUID) (*entities.NotificationPreferences, error)
+
+	// SavePreferences はユーザーの通知設定を保存
+	SavePreferences(ctx context.Context, prefs *entities.NotificationPreferences) error
+
+	// ReadExpiringBatches は期限までwithin以内で、まだ期限の通知をしていない残量のあるバッチをユーザー順に取得
+	ReadExpiringBatches(ctx context.Context, now time.Time, within time.Duration, limit int) ([]*entities.PointBatch, error)
+
+	// MarkExpiryReminded はバッチを期限の通知済みにする
+	MarkExpiryReminded(ctx context.Context, batchIDs []uuid.UUID, remindedAt time.Time) error
+}
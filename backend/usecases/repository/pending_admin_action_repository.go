@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// PendingAdminActionRepository は承認待ちの管理者操作のリポジトリインターフェース
+type PendingAdminActionRepository interface {
+	// Create は承認待ちの操作を作成
+	Create(ctx context.Context, action *entities.PendingAdminAction) error
+
+	// Read はIDで承認待ちの操作を取得
+	Read(ctx context.Context, id uuid.UUID) (*entities.PendingAdminAction, error)
+
+	// ReadByIdempotencyKey は申請時の冪等性キーで取得（なければErrPendingActionNotFound）
+	ReadByIdempotencyKey(ctx context.Context, key string) (*entities.PendingAdminAction, error)
+
+	// ReadList は操作を新しい順に取得（statusが空なら全状態）
+	ReadList(ctx context.Context, status entities.PendingAdminActionStatus, offset, limit int) ([]*entities.PendingAdminAction, error)
+
+	// Count は操作の件数を取得（statusが空なら全状態）
+	Count(ctx context.Context, status entities.PendingAdminActionStatus) (int64, error)
+
+	// UpdateStatus は状態がfromのときだけ状態と審査・実行の結果を更新する
+	// 他の管理者が先に処理していた場合はfalseを返す
+	UpdateStatus(ctx context.Context, action *entities.PendingAdminAction, from entities.PendingAdminActionStatus) (bool, error)
+
+	// ReadExpired は承認待ちのままnowまでに期限を過ぎた操作を古い順に取得
+	ReadExpired(ctx context.Context, now time.Time, limit int) ([]*entities.PendingAdminAction, error)
+
+	// CreateEvent は監査記録を追加
+	CreateEvent(ctx context.Context, event *entities.PendingAdminActionEvent) error
+
+	// ReadEvents は操作の監査記録を古い順に取得
+	ReadEvents(ctx context.Context, actionID uuid.UUID) ([]*entities.PendingAdminActionEvent, error)
+}
@@ -24,4 +24,38 @@ type PointBatchRepository interface {
 
 	// FindUpcomingExpirations はユーザーの有効なバッチを期限が近い順に取得
 	FindUpcomingExpirations(ctx context.Context, userID uuid.UUID) ([]*entities.PointBatch, error)
+
+	// Read はIDでバッチを取得
+	Read(ctx context.Context, id uuid.UUID) (*entities.PointBatch, error)
+
+	// UpdateExpiresAt はバッチの有効期限を更新
+	UpdateExpiresAt(ctx context.Context, id uuid.UUID, expiresAt time.Time) error
+
+	// FindActiveByUser はユーザーの残量があるバッチを古い順に取得
+	FindActiveByUser(ctx context.Context, userID uuid.UUID) ([]*entities.PointBatch, error)
+
+	// MarkRestored はバッチを取り消し済みに更新（取り消し済みならErrPointBatchRestored）
+	MarkRestored(ctx context.Context, id uuid.UUID, restoredAt time.Time) error
+
+	// FindRestorableBatches は指定日時以降に失効し、まだ取り消されていないバッチを新しい順に取得
+	FindRestorableBatches(ctx context.Context, expiredAfter time.Time, userID *uuid.UUID, limit int) ([]*entities.PointBatch, error)
+
+	// FindByUser はユーザーのすべてのバッチ（使用済み・失効済み・取り消し済みを含む）を作成日時の新しい順に取得
+	FindByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.PointBatch, error)
+
+	// CountByUser はユーザーのバッチの件数を取得
+	CountByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// AdjustExpiresAt は管理者が変更した有効期限を設定する（失効済み・取り消し済みならErrPointBatchNotAdjustable）
+	AdjustExpiresAt(ctx context.Context, id uuid.UUID, expiresAt, adjustedAt time.Time) error
+
+	// Cancel はバッチを取り消し、残量を0にする
+	// 残量がremainingから変わっていればErrUpdateConflict、失効済み・取り消し済みならErrPointBatchNotAdjustable
+	Cancel(ctx context.Context, id uuid.UUID, remaining int64, cancelledAt time.Time) error
+
+	// CreateAdjustment は管理者による調整の監査記録を作成
+	CreateAdjustment(ctx context.Context, adjustment *entities.PointBatchAdjustment) error
+
+	// FindAdjustments はバッチの調整の記録を古い順に取得
+	FindAdjustments(ctx context.Context, batchIDs []uuid.UUID) ([]*entities.PointBatchAdjustment, error)
 }
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// PointExpiryPolicyRepository はポイント有効期間設定のリポジトリインターフェース
+type PointExpiryPolicyRepository interface {
+	// ReadListPolicies は全ての付与種別ポリシーを取得
+	ReadListPolicies(ctx context.Context) ([]*entities.PointExpiryPolicy, error)
+
+	// SavePolicy は付与種別ポリシーを保存（upsert）
+	SavePolicy(ctx context.Context, policy *entities.PointExpiryPolicy) error
+
+	// DeletePolicy は付与種別ポリシーを削除（既定の有効期間に戻る）
+	DeletePolicy(ctx context.Context, sourceType entities.PointBatchSourceType) error
+
+	// ReadUserOverride はユーザー個別の設定を取得（ない場合はnil）
+	ReadUserOverride(ctx context.Context, userID uuid.UUID) (*entities.UserPointExpiryOverride, error)
+
+	// SaveUserOverride はユーザー個別の設定を保存（upsert）
+	SaveUserOverride(ctx context.Context, override *entities.UserPointExpiryOverride) error
+
+	// DeleteUserOverride はユーザー個別の設定を削除
+	DeleteUserOverride(ctx context.Context, userID uuid.UUID) error
+}
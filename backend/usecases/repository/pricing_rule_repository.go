@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// PricingRuleRepository は価格ルールのリポジトリインターフェース
+type PricingRuleRepository interface {
+	// Create は価格ルールを作成
+	Create(ctx context.Context, rule *entities.PricingRule) error
+
+	// Read はIDで価格ルールを取得
+	Read(ctx context.Context, id uuid.UUID) (*entities.PricingRule, error)
+
+	// Update は価格ルールを更新
+	Update(ctx context.Context, rule *entities.PricingRule) error
+
+	// Delete は価格ルールを削除
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// ReadList は価格ルールを開始日時の新しい順に取得（管理画面用）
+	ReadList(ctx context.Context, offset, limit int) ([]*entities.PricingRule, error)
+
+	// Count は価格ルールの件数を取得
+	Count(ctx context.Context) (int64, error)
+
+	// ReadActiveForProduct は指定日時に有効な、商品またはそのカテゴリを対象とする価格ルールを取得
+	ReadActiveForProduct(ctx context.Context, productID uuid.UUID, categoryCode string, now time.Time) ([]*entities.PricingRule, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// ProcessedAkerunAccessRepository は処理済みのAkerunアクセス記録IDのリポジトリインターフェース
+type ProcessedAkerunAccessRepository interface {
+	// ReadProcessedIDs はaccessIDsのうち処理済みのIDを返す
+	ReadProcessedIDs(ctx context.Context, accessIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+
+	// Create は処理済みとして記録する（記録済みのアクセス記録は何もしない）
+	Create(ctx context.Context, accesses []*entities.ProcessedAkerunAccess) error
+
+	// DeleteProcessedBefore はbeforeより前に処理した記録を削除し、削除した件数を返す
+	DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/google/uuid"
@@ -42,7 +43,7 @@ type ProductExchangeRepository interface {
 	// Create は新しい交換を作成
 	Create(ctx context.Context, exchange *entities.ProductExchange) error
 
-	// Read はIDで交換を検索
+	// Read はIDで交換を検索（見つからなければErrExchangeNotFound）
 	Read(ctx context.Context, id uuid.UUID) (*entities.ProductExchange, error)
 
 	// Update は交換情報を更新
@@ -57,6 +58,24 @@ type ProductExchangeRepository interface {
 	// CountByUserID はユーザーの交換総数を取得
 	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
 
+	// ReadListByRecipientID はユーザーが贈られた交換を取得
+	ReadListByRecipientID(ctx context.Context, recipientID uuid.UUID, offset, limit int) ([]*entities.ProductExchange, error)
+
+	// CountByRecipientID はユーザーが贈られた交換の総数を取得
+	CountByRecipientID(ctx context.Context, recipientID uuid.UUID) (int64, error)
+
 	// CountAll は全体の交換総数を取得
 	CountAll(ctx context.Context) (int64, error)
+
+	// ReadByRedemptionCode は引換コードで交換を検索（見つからなければErrRedemptionCodeNotFound）
+	ReadByRedemptionCode(ctx context.Context, code string) (*entities.ProductExchange, error)
+
+	// MarkRedeemed は引換コードを使用済みにする（使用済み・キャンセル済みならErrRedemptionCodeUsed）
+	MarkRedeemed(ctx context.Context, exchange *entities.ProductExchange) error
+
+	// ReadReport は期間内の交換を商品ごと・期間ごとに集計（キャンセルは除く）
+	ReadReport(ctx context.Context, from, to time.Time, granularity entities.AnalyticsGranularity) ([]*entities.ExchangeReportRow, error)
+
+	// SumQuantityByPricingRule はユーザーが価格ルールの割引価格で交換した数量の合計を取得（キャンセルは除く）
+	SumQuantityByPricingRule(ctx context.Context, userID, ruleID uuid.UUID) (int, error)
 }
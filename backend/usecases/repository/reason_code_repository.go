@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+)
+
+// ReasonCodeRepository は管理者の付与・減算に付ける理由コードのリポジトリインターフェース
+type ReasonCodeRepository interface {
+	// Create は理由コードを作成（同じコードが既にあればErrReasonCodeExists）
+	Create(ctx context.Context, reasonCode *entities.ReasonCode) error
+
+	// ReadByCode はコードで理由コードを取得
+	ReadByCode(ctx context.Context, code string) (*entities.ReasonCode, error)
+
+	// Update は理由コードの表示名・説明・有効状態を更新
+	Update(ctx context.Context, reasonCode *entities.ReasonCode) error
+
+	// ReadList は理由コードをコード順に取得（activeOnlyがfalseなら無効なものも含む）
+	ReadList(ctx context.Context, activeOnly bool) ([]*entities.ReasonCode, error)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// RecurringTransferRepository は定期送金のリポジトリインターフェース
+type RecurringTransferRepository interface {
+	// Create は定期送金を作成
+	Create(ctx context.Context, transfer *entities.RecurringTransfer) error
+
+	// Read はIDで定期送金を取得（存在しない場合はErrRecurringNotFound）
+	Read(ctx context.Context, id uuid.UUID) (*entities.RecurringTransfer, error)
+
+	// Update は定期送金の状態と実行履歴を更新
+	Update(ctx context.Context, transfer *entities.RecurringTransfer) error
+
+	// ReadListByUser は送信者または受取人として関わる定期送金を新しい順に取得
+	ReadListByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.RecurringTransfer, error)
+
+	// CountByUser は送信者または受取人として関わる定期送金の件数を取得
+	CountByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// ReadDue は実行日時を過ぎた有効な定期送金を古い順に取得
+	ReadDue(ctx context.Context, now time.Time, limit int) ([]*entities.RecurringTransfer, error)
+}
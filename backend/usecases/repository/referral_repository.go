@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// ReferralRepository は紹介コード・紹介のリポジトリインターフェース
+type ReferralRepository interface {
+	// ReadCodeByUser はユーザーの紹介コードを取得（未発行ならnil）
+	ReadCodeByUser(ctx context.Context, userID uuid.UUID) (*entities.ReferralCode, error)
+
+	// ReadCodeByCode は紹介コードから持ち主を取得（存在しなければErrInvalidReferralCode）
+	ReadCodeByCode(ctx context.Context, code string) (*entities.ReferralCode, error)
+
+	// CreateCode は紹介コードを保存（同じユーザーのコードが既にあれば何もしない）
+	CreateCode(ctx context.Context, code *entities.ReferralCode) error
+
+	// Create は紹介を記録
+	Create(ctx context.Context, referral *entities.Referral) error
+
+	// ReadPendingByReferee は紹介された側の初回チェックイン待ちの紹介を取得（なければnil）
+	ReadPendingByReferee(ctx context.Context, refereeID uuid.UUID) (*entities.Referral, error)
+
+	// CountByIPSince は指定日時以降に同じIPアドレスから登録された紹介の数を取得
+	CountByIPSince(ctx context.Context, ipAddress string, since time.Time) (int64, error)
+
+	// MarkRewarded は初回チェックイン待ちの紹介を特典付与済みにする（既に処理済みならfalse）
+	MarkRewarded(ctx context.Context, id uuid.UUID, referrerReward, refereeReward int64, at time.Time) (bool, error)
+
+	// ReadReport は期間内に登録された紹介の実績を集計（紹介者は登録人数の多い順にlimit件）
+	ReadReport(ctx context.Context, from, to time.Time, limit int) (*entities.ReferralReport, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+)
+
+// ScheduledJobRepository は定期実行ジョブの状況とロックのリポジトリインターフェース
+type ScheduledJobRepository interface {
+	// SyncSchedule はジョブの行がなければ作成し、cron式が変わっていれば次回実行日時とともに更新する
+	SyncSchedule(ctx context.Context, name entities.ScheduledJobName, schedule string, nextRunAt *time.Time) error
+
+	// TryLock は次回実行日時を過ぎていて、他のインスタンスが実行中でなければロックを取り、次回実行日時を進める
+	// ロックを取れたかを返す（同じ時刻の実行を取れるのは1インスタンスだけ）
+	TryLock(ctx context.Context, name entities.ScheduledJobName, owner string, now, lockedUntil, nextRunAt time.Time) (bool, error)
+
+	// Finish は実行結果を記録してロックを解放する（ownerがロックを持っている場合のみ）
+	Finish(ctx context.Context, run *entities.ScheduledJobRun) error
+
+	// ReadList は全ジョブの状況を名前順に取得
+	ReadList(ctx context.Context) ([]*entities.ScheduledJob, error)
+}
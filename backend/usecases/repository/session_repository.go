@@ -15,15 +15,43 @@ type SessionRepository interface {
 	// ReadByToken はトークンでセッションを検索
 	ReadByToken(ctx context.Context, token string) (*entities.Session, error)
 
+	// Read はIDでセッションを検索
+	Read(ctx context.Context, id uuid.UUID) (*entities.Session, error)
+
+	// ReadListByUserID はユーザーの有効なセッション一覧を取得（最終アクティブ順）
+	ReadListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Session, error)
+
 	// Update はセッションを更新
 	Update(ctx context.Context, session *entities.Session) error
 
+	// RotateCSRFToken はCSRFトークンを入れ替えたセッションを保存
+	// 保存されたトークンがpreviousTokenのときだけ更新し、並行したリクエストが先に入れ替えていたらfalseを返す
+	RotateCSRFToken(ctx context.Context, session *entities.Session, previousToken string) (bool, error)
+
+	// UpdateFingerprint はセッションを固定する端末・接続元の指紋を更新
+	UpdateFingerprint(ctx context.Context, id uuid.UUID, fingerprint string) error
+
 	// Delete はセッションを削除
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// DeleteByUserID はユーザーの全セッションを削除（ログアウト）
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
 
+	// DeleteByUserIDExcept は指定セッション以外のユーザーの全セッションを削除し、削除件数を返す
+	DeleteByUserIDExcept(ctx context.Context, userID, exceptID uuid.UUID) (int64, error)
+
+	// DeleteAllExcept は指定セッション以外の全ユーザーのセッションを削除し、削除件数を返す
+	DeleteAllExcept(ctx context.Context, exceptID uuid.UUID) (int64, error)
+
 	// DeleteExpired は期限切れセッションを削除
 	DeleteExpired(ctx context.Context) error
+
+	// CreateBindingViolation はセッションの固定の違反を記録
+	CreateBindingViolation(ctx context.Context, violation *entities.SessionBindingViolation) error
+
+	// ReadBindingViolationsByUserID はユーザーのセッションの固定の違反を新しい順に取得
+	ReadBindingViolationsByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.SessionBindingViolation, error)
+
+	// CountBindingViolationsByUserID はユーザーのセッションの固定の違反の件数を取得
+	CountBindingViolationsByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
 }
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// ShippingAddressRepository はお届け先のリポジトリインターフェース
+type ShippingAddressRepository interface {
+	// Create はお届け先を作成
+	Create(ctx context.Context, address *entities.ShippingAddress) error
+
+	// Read はIDでお届け先を取得（見つからなければErrShippingAddressNotFound）
+	Read(ctx context.Context, id uuid.UUID) (*entities.ShippingAddress, error)
+
+	// ReadListByUserID はユーザーのお届け先を既定のもの、登録順の順に取得
+	ReadListByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.ShippingAddress, error)
+
+	// ReadDefault はユーザーの既定のお届け先を取得（なければErrShippingAddressNotFound）
+	ReadDefault(ctx context.Context, userID uuid.UUID) (*entities.ShippingAddress, error)
+
+	// Update はお届け先を更新
+	Update(ctx context.Context, address *entities.ShippingAddress) error
+
+	// SetDefault はユーザーの既定のお届け先をidに切り替える（他のお届け先は既定でなくなる）
+	SetDefault(ctx context.Context, userID, id uuid.UUID) error
+
+	// Delete はお届け先を削除（見つからなければErrShippingAddressNotFound）
+	Delete(ctx context.Context, id uuid.UUID) error
+}
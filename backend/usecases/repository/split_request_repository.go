@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// SplitRequestRepository は割り勘のリポジトリインターフェース
+// 各自の金額・支払い状況は送金リクエスト（TransferRequestRepository.ReadBySplitIDs）から取得する
+type SplitRequestRepository interface {
+	// Create は割り勘を作成
+	Create(ctx context.Context, split *entities.SplitRequest) error
+
+	// Read はIDで割り勘を取得（見つからなければErrSplitRequestNotFound）
+	Read(ctx context.Context, id uuid.UUID) (*entities.SplitRequest, error)
+
+	// ReadByIdempotencyKey は冪等性キーで割り勘を取得（存在しない場合はnil）
+	ReadByIdempotencyKey(ctx context.Context, key string) (*entities.SplitRequest, error)
+
+	// ReadListByRequester は作成者の割り勘を新しい順に取得
+	ReadListByRequester(ctx context.Context, requesterID uuid.UUID, offset, limit int) ([]*entities.SplitRequest, error)
+
+	// Update は割り勘のキャンセル日時を更新
+	Update(ctx context.Context, split *entities.SplitRequest) error
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// SuspiciousActivityRepository は不審な送金の記録のリポジトリインターフェース
+type SuspiciousActivityRepository interface {
+	// Create は不審な送金の記録を作成
+	Create(ctx context.Context, activity *entities.SuspiciousActivity) error
+
+	// Read はIDで記録を取得（なければErrSuspiciousActivityNotFound）
+	Read(ctx context.Context, id uuid.UUID) (*entities.SuspiciousActivity, error)
+
+	// ReadList は記録を新しい順に取得（statusが空なら全状態）
+	ReadList(ctx context.Context, status entities.SuspiciousActivityStatus, offset, limit int) ([]*entities.SuspiciousActivity, error)
+
+	// Count は記録の件数を取得（statusが空なら全状態）
+	Count(ctx context.Context, status entities.SuspiciousActivityStatus) (int64, error)
+
+	// UpdateStatus は状態がfromのときだけ状態と確認・実行の結果を更新する
+	// 他の管理者が先に確認していた場合はfalseを返す
+	UpdateStatus(ctx context.Context, activity *entities.SuspiciousActivity, from entities.SuspiciousActivityStatus) (bool, error)
+
+	// ReadTransferVelocity は送信者の直近の完了済み取引を検出条件に沿って集計
+	ReadTransferVelocity(ctx context.Context, query *entities.TransferVelocityQuery) (*entities.TransferVelocity, error)
+
+	// ReadAlertRecipients は検出を通知する有効な管理者のIDを取得
+	ReadAlertRecipients(ctx context.Context) ([]uuid.UUID, error)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// TenantRepository はテナントのリポジトリインターフェース
+// テナント自体はテナントごとに分けない（どのテナントのcontextからも同じ一覧が見える）
+type TenantRepository interface {
+	// Create はテナントを作成（スラッグが重複すればErrTenantSlugTaken）
+	Create(ctx context.Context, tenant *entities.Tenant) error
+
+	// Read はIDでテナントを取得
+	Read(ctx context.Context, id uuid.UUID) (*entities.Tenant, error)
+
+	// ReadBySlug はスラッグでテナントを取得
+	ReadBySlug(ctx context.Context, slug string) (*entities.Tenant, error)
+
+	// ReadList はテナントを作成日時の古い順に取得
+	ReadList(ctx context.Context, offset, limit int) ([]*entities.Tenant, error)
+
+	// Count はテナントの件数を取得
+	Count(ctx context.Context) (int64, error)
+
+	// Update はテナント名と有効・無効を更新
+	Update(ctx context.Context, tenant *entities.Tenant) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+)
+
+// TransactionArchiveRepository は保持期間を過ぎた取引の保管のリポジトリインターフェース
+type TransactionArchiveRepository interface {
+	// MoveBefore はcutoffより前に作成された確定済みの取引を古い順にlimit件、保管用のテーブルへ移して件数を返す
+	// 移した分は月・種別・状態ごとの集計に加算する
+	MoveBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error)
+
+	// ReadList は保管済みの取引を作成日時の新しい順に取得
+	ReadList(ctx context.Context, filter *entities.ArchivedTransactionFilter, offset, limit int) ([]*entities.ArchivedTransaction, error)
+
+	// Count は検索条件に一致する保管済みの取引の件数を取得
+	Count(ctx context.Context, filter *entities.ArchivedTransactionFilter) (int64, error)
+
+	// ReadSummaries は年月がfromMonthからtoMonthまで（空なら制限なし）の集計を年月・種別・状態の順に取得
+	ReadSummaries(ctx context.Context, fromMonth, toMonth string) ([]*entities.TransactionArchiveSummary, error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/google/uuid"
@@ -25,25 +26,37 @@ type TransactionRepository interface {
 	ReadListAll(ctx context.Context, offset, limit int) ([]*entities.Transaction, error)
 
 	// ReadListAllWithFilter はフィルタ・ソート付きで全トランザクション一覧を取得
-	ReadListAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, sortBy, sortOrder string, offset, limit int) ([]*entities.Transaction, error)
+	ReadListAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode, sortBy, sortOrder string, offset, limit int) ([]*entities.Transaction, error)
 
 	// CountAll は全トランザクション総数を取得
 	CountAll(ctx context.Context) (int64, error)
 
-	// CountAllWithFilter はフィルタ付きで全トランザクション総数を取得
-	CountAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo string) (int64, error)
+	// CountAllWithFilter はフィルタ付きで全トランザクション総数を取得（departmentIDsが空でなければその部署の所属ユーザーが送受信した取引のみ）
+	CountAllWithFilter(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode string, departmentIDs []uuid.UUID) (int64, error)
 
 	// Update はトランザクションを更新
 	Update(ctx context.Context, transaction *entities.Transaction) error
 
-	// CountByUserID はユーザーのトランザクション総数を取得
-	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+	// CountByUserID はユーザーのトランザクション総数を取得（reasonCodeが空でなければその理由コードの取引のみ）
+	CountByUserID(ctx context.Context, userID uuid.UUID, reasonCode string) (int64, error)
 
 	// ReadListByUserIDWithUsers はユーザーに関連するトランザクション一覧をユーザー情報付きで取得（JOIN）
-	ReadListByUserIDWithUsers(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*entities.TransactionWithUsers, error)
+	// reasonCodeが空でなければその理由コードの取引のみ
+	ReadListByUserIDWithUsers(ctx context.Context, userID uuid.UUID, reasonCode string, offset, limit int) ([]*entities.TransactionWithUsers, error)
 
 	// ReadListAllWithFilterAndUsers はフィルタ・ソート付きで全トランザクション一覧をユーザー情報付きで取得（JOIN）
-	ReadListAllWithFilterAndUsers(ctx context.Context, transactionType, dateFrom, dateTo, sortBy, sortOrder string, offset, limit int) ([]*entities.TransactionWithUsers, error)
+	ReadListAllWithFilterAndUsers(ctx context.Context, transactionType, dateFrom, dateTo, reasonCode string, departmentIDs []uuid.UUID, sortBy, sortOrder string, offset, limit int) ([]*entities.TransactionWithUsers, error)
+
+	// AnonymizeUserReferences は退会するユーザーが相手側の取引履歴に残らないよう印を付ける
+	// ユーザー削除で外部キーがNULLになっても「退会済みユーザー」と表示できるようにする
+	AnonymizeUserReferences(ctx context.Context, userID uuid.UUID, scrubMemos bool) error
+
+	// SumExchangedWith はユーザーと各相手の間でやり取りした完了済みの取引の合計を取得
+	// 取引のない相手は結果に含まれない
+	SumExchangedWith(ctx context.Context, userID uuid.UUID, counterpartIDs []uuid.UUID) ([]*entities.PointsExchanged, error)
+
+	// ReadTransferAmountUsage はsince以降にユーザーが各送金先へ送った完了済みの送金を、送金先・金額ごとに集計
+	ReadTransferAmountUsage(ctx context.Context, fromUserID uuid.UUID, toUserIDs []uuid.UUID, since time.Time) ([]*entities.TransferAmountUsage, error)
 }
 
 // IdempotencyKeyRepository は冪等性キーのリポジトリインターフェース
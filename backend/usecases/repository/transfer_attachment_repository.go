@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// TransferAttachmentRepository は送金・送金リクエストの添付画像のリポジトリインターフェース
+type TransferAttachmentRepository interface {
+	// Create は添付を作成
+	Create(ctx context.Context, attachment *entities.TransferAttachment) error
+
+	// Delete は添付を削除（存在しなければ何もしない）
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// ReadByTransactionID は送金の添付を取得
+	// 送金に直接添付したものがなければ、承認してその送金になった送金リクエストの添付を返す（どちらもなければnil）
+	ReadByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entities.TransferAttachment, error)
+
+	// ReadByTransferRequestID は送金リクエストの添付を取得（なければnil）
+	ReadByTransferRequestID(ctx context.Context, requestID uuid.UUID) (*entities.TransferAttachment, error)
+
+	// ReadListBefore は添付した送金（送金になっていない送金リクエストの添付は添付した日時）がcutoffより前の添付を古い順にlimit件取得
+	ReadListBefore(ctx context.Context, cutoff time.Time, limit int) ([]*entities.TransferAttachment, error)
+}
@@ -21,19 +21,28 @@ type TransferRequestRepository interface {
 	// Update は送金リクエストを更新
 	Update(ctx context.Context, transferRequest *entities.TransferRequest) error
 
-	// ReadPendingByToUser は受取人宛の承認待ちリクエストを取得
+	// ReadPendingByToUser は受取人宛の承認待ちリクエストを取得（自分が支払う割り勘の1人分を含み、ミュートした送信者のものは除く）
 	ReadPendingByToUser(ctx context.Context, toUserID uuid.UUID, offset, limit int) ([]*entities.TransferRequest, error)
 
-	// ReadSentByFromUser は送信者が送ったリクエストを取得
+	// ReadSentByFromUser は送信者が送ったリクエストを取得（割り勘の1人分は除く）
 	ReadSentByFromUser(ctx context.Context, fromUserID uuid.UUID, offset, limit int) ([]*entities.TransferRequest, error)
 
-	// CountPendingByToUser は受取人宛の承認待ちリクエスト数を取得
+	// CountPendingByToUser は受取人宛の承認待ちリクエスト数を取得（ミュートした送信者のものは除く）
 	CountPendingByToUser(ctx context.Context, toUserID uuid.UUID) (int64, error)
 
+	// CountPendingByFromUser は送信者が送った有効期限内の承認待ちリクエスト数を取得（割り勘の1人分は除く）
+	CountPendingByFromUser(ctx context.Context, fromUserID uuid.UUID) (int64, error)
+
+	// CountPendingByFromUserTo は送信者が受取人に送った有効期限内の承認待ちリクエスト数を取得（割り勘の1人分は除く）
+	CountPendingByFromUserTo(ctx context.Context, fromUserID, toUserID uuid.UUID) (int64, error)
+
+	// ReadBySplitIDs は割り勘ごとの送金リクエストを作成順に取得
+	ReadBySplitIDs(ctx context.Context, splitIDs []uuid.UUID) ([]*entities.TransferRequest, error)
+
 	// UpdateExpiredRequests は期限切れのリクエストを一括更新
 	UpdateExpiredRequests(ctx context.Context) (int64, error)
 
-	// ReadPendingByToUserWithUsers は受取人宛の承認待ちリクエストをユーザー情報付きで取得（JOIN）
+	// ReadPendingByToUserWithUsers は受取人宛の承認待ちリクエストをユーザー情報付きで取得（JOIN、ミュートした送信者のものは除く）
 	ReadPendingByToUserWithUsers(ctx context.Context, toUserID uuid.UUID, offset, limit int) ([]*entities.TransferRequestWithUsers, error)
 
 	// ReadSentByFromUserWithUsers は送信者が送ったリクエストをユーザー情報付きで取得（JOIN）
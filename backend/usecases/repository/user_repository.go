@@ -11,7 +11,8 @@ import (
 type BalanceUpdate struct {
 	UserID   uuid.UUID
 	Amount   int64
-	IsDeduct bool // true: 減算, false: 加算
+	IsDeduct bool  // true: 減算, false: 加算
+	Reserved int64 // 減算のうち確保中の分から使う額（確保を使う送金リクエストの承認）。確保中の分はこれ以外の減算には使えない
 }
 
 // UserRepository はユーザーのリポジトリインターフェース
@@ -35,23 +36,30 @@ type UserRepository interface {
 
 	// UpdateBalanceWithLock は残高を更新（悲観的ロック）
 	// トランザクション内で使用する（contextからトランザクションを取得）
+	// 減算は確保中の分（ReservedBalance）を除いた残高から行い、足りなければErrInsufficientBalance
+	// （失効・残高の補正も確保中の送金リクエストの分には手を付けず、確保が解放されるまで失敗・スキップする）
 	UpdateBalanceWithLock(ctx context.Context, userID uuid.UUID, amount int64, isDeduct bool) error
 
 	// UpdateBalancesWithLock は複数ユーザーの残高を一括更新（悲観的ロック、デッドロック回避）
 	// 内部でID順にロックを取得することでデッドロックを回避します
+	// 減算は確保中の分（ReservedBalance）を除いた残高から行う
 	UpdateBalancesWithLock(ctx context.Context, updates []BalanceUpdate) error
 
+	// UpdateReservedBalanceWithLock は確保中の額を増減（悲観的ロック）
+	// 確保（isRelease=false）は使える残高が足りなければErrInsufficientBalance
+	UpdateReservedBalanceWithLock(ctx context.Context, userID uuid.UUID, amount int64, isRelease bool) error
+
 	// ReadList はユーザー一覧を取得（ページネーション対応）
 	ReadList(ctx context.Context, offset, limit int) ([]*entities.User, error)
 
-	// ReadListWithSearch は検索・ソート付きでユーザー一覧を取得
-	ReadListWithSearch(ctx context.Context, search, sortBy, sortOrder string, offset, limit int) ([]*entities.User, error)
+	// ReadListWithSearch は検索・ソート付きでユーザー一覧を取得（departmentIDsが空でなければその部署の所属ユーザーに絞り込む）
+	ReadListWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID, sortBy, sortOrder string, offset, limit int) ([]*entities.User, error)
 
 	// Count はユーザー総数を取得
 	Count(ctx context.Context) (int64, error)
 
 	// CountWithSearch は検索条件付きでユーザー総数を取得
-	CountWithSearch(ctx context.Context, search string) (int64, error)
+	CountWithSearch(ctx context.Context, search string, departmentIDs []uuid.UUID) (int64, error)
 
 	// Delete はユーザーを論理削除
 	Delete(ctx context.Context, id uuid.UUID) error
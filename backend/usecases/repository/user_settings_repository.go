@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/gity/point-system/entities"
 	"github.com/google/uuid"
@@ -54,6 +55,9 @@ type EmailVerificationRepository interface {
 	// ReadByToken はトークンで検索
 	ReadByToken(ctx context.Context, token string) (*entities.EmailVerificationToken, error)
 
+	// ReadByOldEmailToken は旧アドレス確認用トークンで検索（メール変更時）
+	ReadByOldEmailToken(ctx context.Context, token string) (*entities.EmailVerificationToken, error)
+
 	// Update はトークン情報を更新
 	Update(ctx context.Context, token *entities.EmailVerificationToken) error
 
@@ -62,6 +66,9 @@ type EmailVerificationRepository interface {
 
 	// DeleteByUserID はユーザーIDに紐づくトークンを削除
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+
+	// CountByUserIDSince はユーザーに指定日時以降に発行した種類ごとのトークン数を取得（再送の制限に使う）
+	CountByUserIDSince(ctx context.Context, userID uuid.UUID, tokenType entities.TokenType, since time.Time) (int64, error)
 }
 
 // UsernameChangeHistoryRepository はユーザー名変更履歴のリポジトリインターフェース
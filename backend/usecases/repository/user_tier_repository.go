@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// UserTierRepository は会員ランクの判定材料の集計とランクの保存を行うリポジトリインターフェース
+// ランクの列はユーザーの通常の更新（UserRepository.Update）では書き換えない
+type UserTierRepository interface {
+	// ReadActivities は有効な全ユーザーの利用状況を取得
+	// earnedSince以降の獲得ポイントと、streakAsOf時点で継続中の連続チェックイン日数を集計する
+	ReadActivities(ctx context.Context, earnedSince, streakAsOf time.Time) ([]*entities.TierActivity, error)
+
+	// ReadActivity は1ユーザーの利用状況を取得
+	ReadActivity(ctx context.Context, userID uuid.UUID, earnedSince, streakAsOf time.Time) (*entities.TierActivity, error)
+
+	// UpdateComputedTier は判定したランクを保存（管理者が固定したユーザーと、ランクが変わらないユーザーは更新しない）
+	// 更新した場合はtrueを返す
+	UpdateComputedTier(ctx context.Context, userID uuid.UUID, tier entities.UserTier, at time.Time) (bool, error)
+
+	// SetOverride は管理者によるランクの固定を設定（tierがnilなら固定を解除）
+	SetOverride(ctx context.Context, userID uuid.UUID, tier *entities.UserTier, at time.Time) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// WeeklyDigestRepository は週次のまとめメールのリポジトリインターフェース
+type WeeklyDigestRepository interface {
+	// ReadRecipientIDs はafterより後の送信対象のユーザーIDを昇順にlimit件取得
+	// 有効でメールアドレスがあり、通知設定でメールと週次のまとめを止めていないユーザーが対象
+	ReadRecipientIDs(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error)
+
+	// ReadActivity はユーザーごとに[from, to)の確定済みの取引の獲得・使用の合計を取得（残高の補正を除き、失効は使用に数えない）
+	ReadActivity(ctx context.Context, userIDs []uuid.UUID, from, to time.Time) ([]*entities.WeeklyDigestActivity, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gity/point-system/entities"
+)
+
+// WorkerLeaseRepository はワーカーのリーダーのリポジトリインターフェース
+type WorkerLeaseRepository interface {
+	// TryAcquire はholderがリーダーなら期限を延ばし、他のリーダーの期限が切れていれば引き継ぐ
+	// リーダーになれたかを返す（同じワーカーのリーダーは常に1インスタンスだけ）
+	TryAcquire(ctx context.Context, name, holder string, now, expiresAt time.Time) (bool, error)
+
+	// Release はholderがリーダーなら期限を切って、他のインスタンスがすぐ引き継げるようにする
+	Release(ctx context.Context, name, holder string, now time.Time) error
+
+	// ReadList は全ワーカーのリーダーを名前順に取得
+	ReadList(ctx context.Context) ([]*entities.WorkerLease, error)
+}
@@ -2,15 +2,23 @@ package service
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/gity/point-system/entities"
 )
 
-// AkerunAccessGateway はAkerun入退室APIとの通信インターフェース
+// ErrAccessesTruncated はページ数の上限までに期間内のアクセス記録を取り切れなかったことを表す
+var ErrAccessesTruncated = errors.New("akerun accesses truncated at the page limit")
+
+// AkerunAccessGateway はAkerun入退室APIとの通信インターフェース（1組織分）
 // インフラ層のAkerunClientがこのインターフェースを実装する
 type AkerunAccessGateway interface {
-	// FetchAccesses は指定期間のアクセス記録を取得する
+	// Organization はポーリングする組織とドアごとのボーナス規則を返す
+	Organization() entities.AkerunOrganization
+	// FetchAccesses は指定期間のアクセス記録を取得する（組織・ドアの情報付き）
+	// limitは1ページの件数で、ページをたどって期間内のすべての記録を返す
+	// ページ数の上限で取り切れなかった場合は、取得できた分とErrAccessesTruncatedを返す
 	FetchAccesses(ctx context.Context, after, before time.Time, limit int) ([]entities.AccessRecord, error)
 	// IsConfigured はAkerun APIが設定済みかを返す
 	IsConfigured() bool
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrCircuitOpen は外部サービスの失敗が続いたため、呼び出しをせずに失敗させたことを表す
+// 受け取ったら同じサービスへの呼び出しを続けず、次回の実行まで待つ
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// サーキットブレーカーの状態
+const (
+	CircuitClosed   = "closed"    // 通常どおり呼び出す
+	CircuitOpen     = "open"      // 呼び出さずにErrCircuitOpenを返す
+	CircuitHalfOpen = "half_open" // 回復したかを確かめるため、一部の呼び出しだけ通す
+)
+
+// CircuitBreakerStatus はサーキットブレーカー1つ分の状態
+type CircuitBreakerStatus struct {
+	Name                string     `json:"name"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+}
+
+// CircuitBreakerMonitor は外部サービスごとのサーキットブレーカーの状態を返すインターフェース（ヘルスチェック用）
+type CircuitBreakerMonitor interface {
+	CircuitBreakers() []CircuitBreakerStatus
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+)
+
+// ContentModerator はユーザー入力テキストを判定するサービスのインターフェース
+// 既定は禁止語リストによる実装で、外部のモデレーションAPIに差し替えられる
+type ContentModerator interface {
+	// Moderate はテキストを判定する（判定できなかった場合はエラーを返す）
+	Moderate(ctx context.Context, field entities.ModerationField, text string) (*entities.ModerationVerdict, error)
+}
@@ -0,0 +1,15 @@
+package service
+
+import "io"
+
+// DataExportStorage は個人データエクスポートのアーカイブを保存するサービスのインターフェース
+type DataExportStorage interface {
+	// Save はファイル名と内容の組をひとつのアーカイブにまとめて保存し、保存先のパスを返す
+	Save(exportID string, files map[string][]byte) (string, error)
+
+	// Open は保存したアーカイブを読み出す
+	Open(filePath string) (io.ReadCloser, error)
+
+	// Delete は保存したアーカイブを削除
+	Delete(filePath string) error
+}
@@ -0,0 +1,24 @@
+package service
+
+import "time"
+
+// DBPoolStats はDB接続1つ分（プライマリ・リードレプリカ）のコネクションプールの状態（database/sqlのDBStats）
+type DBPoolStats struct {
+	Name               string // primary / replica
+	MaxOpenConnections int    // 接続数の上限（0なら無制限）
+
+	OpenConnections int // 使用中と待機中の接続数
+	InUse           int // 使用中の接続数
+	Idle            int // 待機中の接続数
+
+	WaitCount         int64         // 空きを待った回数
+	WaitDuration      time.Duration // 空きを待った時間の合計
+	MaxIdleClosed     int64         // 待機数の上限で閉じた接続数
+	MaxIdleTimeClosed int64         // 待機時間の上限で閉じた接続数
+	MaxLifetimeClosed int64         // 接続の寿命で閉じた接続数
+}
+
+// DBStatsMonitor はコネクションプールの状態を返すインターフェース（管理者向けの確認用）
+type DBStatsMonitor interface {
+	DBStats() []DBPoolStats
+}
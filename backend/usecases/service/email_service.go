@@ -1,13 +1,41 @@
 package service
 
+import (
+	"time"
+
+	"github.com/gity/point-system/entities"
+)
+
 // EmailService はメール送信サービスのインターフェース
 type EmailService interface {
 	// SendVerificationEmail はメール認証用のメールを送信
 	SendVerificationEmail(to, token string) error
 
+	// SendEmailChangeConfirmation はメールアドレス変更の確認メールを変更前のアドレスへ送信
+	SendEmailChangeConfirmation(to, newEmail, token string) error
+
 	// SendPasswordChangeNotification はパスワード変更通知メールを送信
 	SendPasswordChangeNotification(to string) error
 
 	// SendAccountDeletedNotification はアカウント削除通知メールを送信
 	SendAccountDeletedNotification(to string) error
+
+	// SendAccountLockedNotification はアカウントロック通知とロック解除リンクを送信
+	SendAccountLockedNotification(to, unlockToken string, lockedUntil time.Time) error
+
+	// SendNewLoginNotification は新しい端末・国からのログイン通知を送信
+	SendNewLoginNotification(to, ipAddress, userAgent, country string, loggedInAt time.Time) error
+
+	// SendNotificationEmail は通知（ポイントの有効期限など）をメールで送信
+	// 通知設定の判定は呼び出し側（NotificationDispatcher）で行う
+	SendNotificationEmail(to, subject, body string) error
+
+	// SendDataExportReady は個人データエクスポートの完了とダウンロードリンクを送信
+	SendDataExportReady(to, exportID string, expiresAt time.Time) error
+
+	// SendUserInvitation は管理者が一括登録したユーザーへログイン情報（仮パスワード）を送信
+	SendUserInvitation(to, username, temporaryPassword string) error
+
+	// SendEmail は描画済みのメールをそのまま送信（メールテンプレートのテスト送信に使う）
+	SendEmail(to string, email *entities.RenderedEmail) error
 }
@@ -14,4 +14,14 @@ type FileStorageService interface {
 
 	// GetAvatarURL はアバター画像のURLを取得
 	GetAvatarURL(filePath string) string
+
+	// SaveTransferAttachment は送金の添付画像を保存し、保存先のパスを返す（アバターと同じく拡張子・サイズを検証する）
+	SaveTransferAttachment(userID string, fileName string, file io.Reader, fileSize int64) (string, error)
+
+	// OpenTransferAttachment は送金の添付画像を読み出す（当事者にだけ見せるため、URLでは公開しない）
+	// 存在しなければfs.ErrNotExistを返す
+	OpenTransferAttachment(filePath string) (io.ReadCloser, error)
+
+	// DeleteTransferAttachment は送金の添付画像を削除（存在しなければ何もしない）
+	DeleteTransferAttachment(filePath string) error
 }
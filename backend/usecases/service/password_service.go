@@ -7,4 +7,7 @@ type PasswordService interface {
 
 	// VerifyPassword はパスワードを検証
 	VerifyPassword(hashedPassword, password string) bool
+
+	// NeedsRehash はハッシュのアルゴリズム・パラメータが現在の設定と異なればtrue（検証に成功したら作り直す）
+	NeedsRehash(hashedPassword string) bool
 }
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gity/point-system/entities"
+)
+
+// ErrPushTokenUnregistered は配信先がトークンを無効と判定した（アプリの削除など）ことを表す
+// このエラーを受け取ったら登録済みのトークンを削除する
+var ErrPushTokenUnregistered = errors.New("push token is no longer registered")
+
+// PushNotificationService は端末へプッシュ通知を送るサービスのインターフェース
+// 実装はプラットフォームに応じてAPNs（iOS）またはFCM（Android）へ送る
+type PushNotificationService interface {
+	// Send は1台の端末へ通知を送る（トークンが無効ならErrPushTokenUnregisteredを返す）
+	Send(ctx context.Context, device *entities.DeviceToken, notification *entities.Notification) error
+}
@@ -0,0 +1,14 @@
+package service
+
+import "github.com/gity/point-system/entities"
+
+// QRPayloadCodec はQRコードに埋め込む文字列の作成・読み取りを行うサービスのインターフェース
+type QRPayloadCodec interface {
+	// Encode はペイロードに署名し、QRコードに埋め込む文字列（ディープリンク）にする
+	Encode(payload *entities.QRPayload) (string, error)
+
+	// Decode はQRコードから読み取った文字列の署名・有効期限を検証してペイロードを返す
+	// 旧形式（user:{user_id} など）は署名のないバージョン0のペイロードとして読む
+	// 読めない・署名が合わない文字列はErrInvalidQRPayload、期限切れはErrQRCodeExpiredを返す
+	Decode(data string) (*entities.QRPayload, error)
+}
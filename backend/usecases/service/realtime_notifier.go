@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gity/point-system/entities"
+	"github.com/google/uuid"
+)
+
+// RealtimeNotifier はログイン中の端末へイベントを即時に届けるサービスのインターフェース
+type RealtimeNotifier interface {
+	// Notify はユーザーの接続中の端末へイベントを送る
+	// 配信はベストエフォートで、接続がなければ何もしない（呼び出し側の処理は失敗させない）
+	Notify(ctx context.Context, userID uuid.UUID, event *entities.RealtimeEvent)
+}
@@ -0,0 +1,10 @@
+package service
+
+// ReceiptSigner は取引の控えの検証ハッシュを計算するサービスのインターフェース
+type ReceiptSigner interface {
+	// Sign は控えの内容（TransactionReceipt.SigningPayload）の検証ハッシュを返す
+	Sign(payload string) string
+
+	// Verify は提示された検証ハッシュが控えの内容と一致するかを判定
+	Verify(payload, hash string) bool
+}
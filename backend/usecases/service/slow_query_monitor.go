@@ -0,0 +1,12 @@
+package service
+
+// SlowQueryCount はリポジトリのメソッドごとの遅いクエリの件数
+type SlowQueryCount struct {
+	Method string // 例: transaction.RepositoryImpl.ReadListByUserIDWithUsers
+	Count  int64
+}
+
+// SlowQueryMonitor は起動してから閾値を超えたクエリの件数を返すインターフェース（/metrics 用）
+type SlowQueryMonitor interface {
+	SlowQueries() []SlowQueryCount
+}
@@ -7,13 +7,26 @@ export const ScanQRPage: React.FC = () => {
   const [isCameraActive, setIsCameraActive] = useState(true);
   const navigate = useNavigate();
 
+  // 個人QRコードから宛先のユーザーIDを取り出す（送金リクエストの宛先に使うだけなので署名は確かめない）
+  const parsePersonalQRCode = (code: string): string | null => {
+    if (code.startsWith('gity://qr?')) {
+      // 署名付きのディープリンク: gity://qr?p=UUID&t=user&v=1&sig=...
+      const params = new URLSearchParams(code.slice('gity://qr?'.length));
+      return params.get('t') === 'user' ? params.get('p') : null;
+    }
+    if (code.startsWith('user:')) {
+      // 旧形式: user:UUID
+      return code.replace('user:', '');
+    }
+    return null;
+  };
+
   // QRコードのタイプを判定して遷移
   const parseQRCodeAndNavigate = (code: string) => {
-    if (code.startsWith('user:')) {
-      // 個人QRコード: user:UUID
-      const userId = code.replace('user:', '');
+    const userId = parsePersonalQRCode(code);
+    if (userId) {
       // 確認ページに遷移
-      navigate(`/qr/confirm?userId=${userId}`);
+      navigate(`/qr/confirm?userId=${encodeURIComponent(userId)}`);
       return true;
     }
     return false;